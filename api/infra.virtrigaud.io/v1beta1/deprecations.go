@@ -0,0 +1,180 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeprecatedField is a v1beta1 construct that v1 renames or removes. The
+// deprecation webhook, the virtrigaud_deprecated_field_usage_total metric
+// and 'vrtg admin deprecations' all read DeprecatedFields, so they report
+// the same fields with the same advice.
+// +kubebuilder:object:generate=false
+type DeprecatedField struct {
+	// Kind is the kind the field belongs to.
+	Kind string
+	// Field is the field's path, with [*] for any list index. It is the
+	// field label of the usage metric.
+	Field string
+	// Description names the deprecated construct.
+	Description string
+	// Replacement is what to use instead.
+	Replacement string
+
+	// uses returns the paths at which obj, an object of Kind, uses the
+	// construct.
+	uses func(obj runtime.Object) []string
+}
+
+// DeprecationUse is a use of a DeprecatedField in an object.
+// +kubebuilder:object:generate=false
+type DeprecationUse struct {
+	*DeprecatedField
+	// Path is the field's path in the object, with list indexes filled in.
+	Path string
+}
+
+// Warning returns the use as an admission warning.
+func (u DeprecationUse) Warning() string {
+	return fmt.Sprintf("%s: %s is deprecated and will be removed in v1; %s", u.Path, u.Description, u.Replacement)
+}
+
+// DeprecatedFields lists every deprecated v1beta1 construct.
+var DeprecatedFields = []DeprecatedField{
+	networkNameField("VirtualMachine", "spec.networks", func(obj runtime.Object) []VMNetworkRef {
+		if vm, ok := obj.(*VirtualMachine); ok {
+			return vm.Spec.Networks
+		}
+		return nil
+	}),
+	networkNameField("VMSet", "spec.template.spec.networks", func(obj runtime.Object) []VMNetworkRef {
+		if set, ok := obj.(*VMSet); ok {
+			return set.Spec.Template.Spec.Networks
+		}
+		return nil
+	}),
+	networkNameField("VMClone", "spec.target.networks", func(obj runtime.Object) []VMNetworkRef {
+		if clone, ok := obj.(*VMClone); ok {
+			return clone.Spec.Target.Networks
+		}
+		return nil
+	}),
+	networkNameField("VMMigration", "spec.target.networks", func(obj runtime.Object) []VMNetworkRef {
+		if migration, ok := obj.(*VMMigration); ok {
+			return migration.Spec.Target.Networks
+		}
+		return nil
+	}),
+	inlineUserDataField("VirtualMachine", "spec.userData", func(obj runtime.Object) *UserData {
+		if vm, ok := obj.(*VirtualMachine); ok {
+			return vm.Spec.UserData
+		}
+		return nil
+	}),
+	inlineUserDataField("VMSet", "spec.template.spec.userData", func(obj runtime.Object) *UserData {
+		if set, ok := obj.(*VMSet); ok {
+			return set.Spec.Template.Spec.UserData
+		}
+		return nil
+	}),
+	inlineUserDataField("VMClone", "spec.customization.userData", func(obj runtime.Object) *UserData {
+		if clone, ok := obj.(*VMClone); ok && clone.Spec.Customization != nil {
+			return clone.Spec.Customization.UserData
+		}
+		return nil
+	}),
+	{
+		Kind:        "Provider",
+		Field:       "spec.insecureSkipVerify",
+		Description: "the top-level TLS verification switch",
+		Replacement: "set spec.runtime.service.tls.insecureSkipVerify instead",
+		uses: func(obj runtime.Object) []string {
+			if p, ok := obj.(*Provider); ok && p.Spec.InsecureSkipVerify {
+				return []string{"spec.insecureSkipVerify"}
+			}
+			return nil
+		},
+	},
+}
+
+// networkNameField is the deprecated free-form network: a networks entry
+// without a networkRef, whose name each provider interprets on its own.
+func networkNameField(kind, networks string, get func(runtime.Object) []VMNetworkRef) DeprecatedField {
+	return DeprecatedField{
+		Kind:        kind,
+		Field:       networks + "[*].name",
+		Description: "a network selected by name alone",
+		Replacement: "set networkRef to a VMNetworkAttachment with a binding for the provider",
+		uses: func(obj runtime.Object) []string {
+			var paths []string
+			for i, n := range get(obj) {
+				if n.NetworkRef == nil {
+					paths = append(paths, fmt.Sprintf("%s[%d].name", networks, i))
+				}
+			}
+			return paths
+		},
+	}
+}
+
+// inlineUserDataField is deprecated inline cloud-init user data, which v1
+// only reads from a Secret.
+func inlineUserDataField(kind, userData string, get func(runtime.Object) *UserData) DeprecatedField {
+	return DeprecatedField{
+		Kind:        kind,
+		Field:       userData + ".cloudInit.inline",
+		Description: "inline cloud-init user data",
+		Replacement: "move it into a Secret and set " + userData + ".cloudInit.secretRef",
+		uses: func(obj runtime.Object) []string {
+			if ud := get(obj); ud != nil && ud.CloudInit != nil && ud.CloudInit.Inline != "" {
+				return []string{userData + ".cloudInit.inline"}
+			}
+			return nil
+		},
+	}
+}
+
+// FindDeprecations returns the deprecated constructs obj uses, in the
+// order of DeprecatedFields.
+func FindDeprecations(obj runtime.Object) []DeprecationUse {
+	var uses []DeprecationUse
+	for i := range DeprecatedFields {
+		field := &DeprecatedFields[i]
+		for _, path := range field.uses(obj) {
+			uses = append(uses, DeprecationUse{DeprecatedField: field, Path: path})
+		}
+	}
+	return uses
+}
+
+// DeprecatedKinds returns the kinds that have deprecated fields, sorted.
+func DeprecatedKinds() []string {
+	seen := map[string]bool{}
+	var kinds []string
+	for _, field := range DeprecatedFields {
+		if !seen[field.Kind] {
+			seen[field.Kind] = true
+			kinds = append(kinds, field.Kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func deprecationPaths(uses []DeprecationUse) []string {
+	var paths []string
+	for _, use := range uses {
+		paths = append(paths, use.Path)
+	}
+	return paths
+}
+
+func TestFindDeprecations(t *testing.T) {
+	vm := &VirtualMachine{Spec: VirtualMachineSpec{
+		Networks: []VMNetworkRef{
+			{Name: "attached", NetworkRef: &ObjectRef{Name: "net"}},
+			{Name: "vmbr0"},
+		},
+		UserData: &UserData{CloudInit: &CloudInit{Inline: "#cloud-config\n"}},
+	}}
+	want := []string{"spec.networks[1].name", "spec.userData.cloudInit.inline"}
+	if got := deprecationPaths(FindDeprecations(vm)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("VirtualMachine uses = %v, want %v", got, want)
+	}
+
+	set := &VMSet{Spec: VMSetSpec{Template: VMSetTemplate{Spec: vm.Spec}}}
+	want = []string{"spec.template.spec.networks[1].name", "spec.template.spec.userData.cloudInit.inline"}
+	if got := deprecationPaths(FindDeprecations(set)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("VMSet uses = %v, want %v", got, want)
+	}
+
+	clone := &VMClone{Spec: VMCloneSpec{Customization: &VMCustomization{
+		UserData: &UserData{CloudInit: &CloudInit{SecretRef: &LocalObjectReference{Name: "ud"}}},
+	}}}
+	if uses := FindDeprecations(clone); len(uses) != 0 {
+		t.Fatalf("VMClone with user data in a Secret uses %v", deprecationPaths(uses))
+	}
+
+	provider := &Provider{Spec: ProviderSpec{InsecureSkipVerify: true}}
+	uses := FindDeprecations(provider)
+	if len(uses) != 1 {
+		t.Fatalf("Provider uses = %v, want spec.insecureSkipVerify", deprecationPaths(uses))
+	}
+	if warning := uses[0].Warning(); !strings.Contains(warning, "spec.runtime.service.tls.insecureSkipVerify") {
+		t.Fatalf("warning %q does not name the replacement", warning)
+	}
+}
+
+// TestDeprecatedKindsAreListable guards the registry against kinds the
+// webhook cannot decode and 'vrtg admin deprecations' cannot list.
+func TestDeprecatedKindsAreListable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range DeprecatedKinds() {
+		for _, k := range []string{kind, kind + "List"} {
+			if !scheme.Recognizes(GroupVersion.WithKind(k)) {
+				t.Errorf("%s is not registered in the scheme", k)
+			}
+		}
+	}
+	for _, field := range DeprecatedFields {
+		if field.Description == "" || field.Replacement == "" || field.uses == nil {
+			t.Errorf("%s %s is missing a description, replacement or check", field.Kind, field.Field)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ProviderType represents the type of virtualization provider
@@ -83,6 +84,91 @@ type ProviderTLSSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// SPIFFE switches the mTLS identity source from SecretRef to SPIFFE
+	// workload identity. The provider pod fetches its SVID from the
+	// Workload API socket mounted by the SPIFFE CSI driver, and the manager
+	// authenticates with its own SVID. SecretRef is not required when set.
+	// +optional
+	SPIFFE *ProviderSPIFFESpec `json:"spiffe,omitempty"`
+
+	// CertManager has the controller request the provider's certificate
+	// from cert-manager instead of expecting a hand-made Secret. The
+	// certificate is issued into SecretRef when it is set, else into
+	// virtrigaud-provider-<namespace>-<name>-tls.
+	// +optional
+	CertManager *ProviderCertManagerSpec `json:"certManager,omitempty"`
+}
+
+// ProviderCertManagerSpec configures the cert-manager Certificate the
+// controller creates for a provider.
+type ProviderCertManagerSpec struct {
+	// IssuerRef is the Issuer or ClusterIssuer that signs the certificate.
+	// Its CA must be published as ca.crt in the issued Secret, which is
+	// the case for CA and self-signed issuers.
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+
+	// Duration is the requested certificate lifetime. Unset leaves it to
+	// cert-manager, which defaults to 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// HotReload states that the provider picks up a renewed certificate
+	// without a restart, as providers built on the virtrigaud SDK do. When
+	// false the provider Deployment is rolled on every renewal. A changed
+	// CA always rolls it, since the SDK loads the CA only at startup.
+	// +optional
+	// +kubebuilder:default=true
+	HotReload *bool `json:"hotReload,omitempty"`
+}
+
+// CertManagerIssuerReference names a cert-manager issuer.
+type CertManagerIssuerReference struct {
+	// Name of the issuer
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind of the issuer, Issuer (in the Provider's namespace) or
+	// ClusterIssuer, or the kind of an external issuer
+	// +optional
+	// +kubebuilder:default="Issuer"
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer
+	// +optional
+	// +kubebuilder:default="cert-manager.io"
+	Group string `json:"group,omitempty"`
+}
+
+// ProviderSPIFFESpec configures SPIFFE workload-identity mTLS between the
+// manager and a provider.
+type ProviderSPIFFESpec struct {
+	// TrustDomain is the SPIFFE trust domain shared by the manager and the
+	// provider, e.g. "example.org".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern="^[a-z0-9._-]+$"
+	TrustDomain string `json:"trustDomain"`
+
+	// ProviderID is the SPIFFE ID the provider server must present. Empty
+	// accepts any ID in TrustDomain.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+
+	// ManagerIDs lists the SPIFFE IDs the provider accepts from the
+	// manager. Empty accepts any ID in TrustDomain.
+	// +optional
+	ManagerIDs []string `json:"managerIDs,omitempty"`
+
+	// SocketPath is the in-pod path of the Workload API socket.
+	// +optional
+	// +kubebuilder:default="/spiffe-workload-api/spire-agent.sock"
+	SocketPath string `json:"socketPath,omitempty"`
+
+	// CSIDriver is the CSI driver that mounts the Workload API socket
+	// directory into the provider pod.
+	// +optional
+	// +kubebuilder:default="csi.spiffe.io"
+	CSIDriver string `json:"csiDriver,omitempty"`
 }
 
 // ProviderRuntimeSpec defines the runtime configuration for providers
@@ -153,6 +239,16 @@ type ProviderRuntimeSpec struct {
 	// +kubebuilder:default="text"
 	LogFormat string `json:"logFormat,omitempty"`
 
+	// MaxConcurrentClones caps how many VM clones each provider replica
+	// runs at once. A Create over the limit is turned away as busy rather
+	// than queued, and the manager retries it after a short, jittered
+	// delay. Unset or 0 means no limit. Enforced by the Proxmox, libvirt
+	// and mock providers.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	MaxConcurrentClones *int32 `json:"maxConcurrentClones,omitempty"`
+
 	// Env defines additional environment variables for provider pods
 	// +optional
 	// +kubebuilder:validation:MaxItems=50
@@ -165,8 +261,29 @@ type ProviderRuntimeSpec struct {
 	// ReadinessProbe defines the readiness probe for provider pods
 	// +optional
 	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// SessionAffinity controls how the manager spreads RPCs across
+	// replicas. None load-balances through the ClusterIP Service. PerVM
+	// also publishes a headless Service and sends every RPC for a given
+	// VirtualMachine to the same pod, moving it only when that pod goes
+	// away; use it for providers that keep per-connection state, such as
+	// libvirt over SSH.
+	// +optional
+	// +kubebuilder:default=None
+	SessionAffinity ProviderSessionAffinity `json:"sessionAffinity,omitempty"`
 }
 
+// ProviderSessionAffinity selects how VM RPCs map to provider replicas
+// +kubebuilder:validation:Enum=PerVM;None
+type ProviderSessionAffinity string
+
+const (
+	// ProviderSessionAffinityNone load-balances RPCs across replicas
+	ProviderSessionAffinityNone ProviderSessionAffinity = "None"
+	// ProviderSessionAffinityPerVM pins each VM's RPCs to one replica
+	ProviderSessionAffinityPerVM ProviderSessionAffinity = "PerVM"
+)
+
 // ProviderRuntimeStatus defines the runtime status for providers
 type ProviderRuntimeStatus struct {
 	// Mode indicates the current runtime mode
@@ -181,6 +298,17 @@ type ProviderRuntimeStatus struct {
 	// +optional
 	ServiceRef *corev1.LocalObjectReference `json:"serviceRef,omitempty"`
 
+	// EndpointRef locates the provider's gRPC Service by name and port.
+	// The manager resolves it when dialing, so a changed Service port is
+	// followed without waiting for Endpoint to be rewritten
+	// +optional
+	EndpointRef *ServiceEndpointRef `json:"endpointRef,omitempty"`
+
+	// HeadlessServiceRef references the headless Service listing the
+	// provider's pods. Set only when spec.runtime.sessionAffinity is PerVM.
+	// +optional
+	HeadlessServiceRef *corev1.LocalObjectReference `json:"headlessServiceRef,omitempty"`
+
 	// Phase indicates the runtime phase
 	// +optional
 	Phase ProviderRuntimePhase `json:"phase,omitempty"`
@@ -215,7 +343,32 @@ const (
 	ProviderRuntimePhaseFailed ProviderRuntimePhase = "Failed"
 )
 
+// ServiceEndpointRef locates an endpoint through the Kubernetes Service
+// that serves it, as <serviceName>.<namespace>.svc.cluster.local and the
+// Service's port.
+type ServiceEndpointRef struct {
+	// ServiceName is the name of the Service
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ServiceName string `json:"serviceName"`
+
+	// Namespace of the Service. Defaults to the namespace of the object
+	// holding the reference; another namespace must admit references from
+	// it (virtrigaud.io/allow-references-from)
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// Port is the Service port, by number or by name. When unset the
+	// Service must have exactly one port
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+}
+
 // ProviderSpec defines the desired state of Provider
+// +kubebuilder:validation:XValidation:rule="has(self.endpoint) != has(self.endpointRef)",message="exactly one of endpoint and endpointRef must be set"
+// +kubebuilder:validation:XValidation:rule="self.type != 'proxmox' || !has(self.defaultTags) || self.defaultTags.all(t, t.matches('^[a-z0-9_][a-z0-9_+.-]*$'))",message="Proxmox VE tags are lowercase letters, digits and _-+., not starting with -, + or ."
+// +kubebuilder:validation:XValidation:rule="self.type != 'proxmox' || !has(self.defaultLabels) || size(self.defaultLabels) == 0",message="Proxmox VE tags carry no values; use defaultTags instead of defaultLabels"
 type ProviderSpec struct {
 	// Type specifies the provider type
 	Type ProviderType `json:"type"`
@@ -223,10 +376,25 @@ type ProviderSpec struct {
 	// Endpoint is the provider endpoint URI
 	// Supports multiple protocols: HTTP(S), TCP, gRPC for general providers
 	// and LibVirt-specific schemes: qemu://, qemu+ssh://, qemu+tcp://, qemu+tls://
+	// Exactly one of Endpoint and EndpointRef is set
+	// +optional
 	// +kubebuilder:validation:Pattern="^((https?://[a-zA-Z0-9.-]+(:[0-9]+)?(/.*)?|(tcp|grpc)://[a-zA-Z0-9.-]+:[0-9]+(/.*)?)|qemu(\\+ssh|\\+tcp|\\+tls)?://([a-zA-Z0-9@.-]+(:[0-9]+)?)?(/.*))$"
-	Endpoint string `json:"endpoint"`
+	Endpoint string `json:"endpoint,omitempty"`
 
-	// CredentialSecretRef references the Secret containing credentials
+	// EndpointRef locates the hypervisor endpoint through an in-cluster
+	// Service instead of a literal URL. It resolves to
+	// https://<service-dns-name>:<port> for vSphere and Proxmox VE,
+	// qemu+tcp://<service-dns-name>:<port>/system for libvirt and
+	// tcp://<service-dns-name>:<port> otherwise, and is resolved again
+	// whenever the Service changes. The result is reported in
+	// status.endpoint
+	// +optional
+	EndpointRef *ServiceEndpointRef `json:"endpointRef,omitempty"`
+
+	// CredentialSecretRef references the Secret containing credentials.
+	// A Secret in another namespace is used only if that namespace grants
+	// it to the Provider's (virtrigaud.io/allow-credential-references-from),
+	// and is copied next to the Provider for its runtime to mount
 	CredentialSecretRef ObjectRef `json:"credentialSecretRef"`
 
 	// InsecureSkipVerify disables TLS verification (deprecated, use runtime.service.tls.insecureSkipVerify)
@@ -238,6 +406,22 @@ type ProviderSpec struct {
 	// +optional
 	Defaults *ProviderDefaults `json:"defaults,omitempty"`
 
+	// DefaultTags are applied to every VM the provider creates, ahead of
+	// the VM's own spec.tags. Changing them affects VMs created afterwards
+	// only
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=128
+	DefaultTags []string `json:"defaultTags,omitempty"`
+
+	// DefaultLabels are applied to every VM the provider creates as
+	// key=value tags. A VM tag with the same key replaces the default.
+	// Changing them affects VMs created afterwards only
+	// +optional
+	// +kubebuilder:validation:MaxProperties=50
+	DefaultLabels map[string]string `json:"defaultLabels,omitempty"`
+
 	// RateLimit configures API rate limiting
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
@@ -252,6 +436,38 @@ type ProviderSpec struct {
 	// ConnectionPooling defines connection pooling settings
 	// +optional
 	ConnectionPooling *ConnectionPooling `json:"connectionPooling,omitempty"`
+
+	// MaintenanceMode holds new provisioning on the provider: VMs are not
+	// created, cloned or have images prepared, and VMSnapshots are not
+	// taken, until it is cleared. Existing VMs are still described, powered
+	// and deleted
+	// +optional
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+
+	// ReconcileInterval is how often VMs on this provider whose state has
+	// settled (powered on with addresses, suspended or powered off) are
+	// described again. It is kept between 10s and 1h. When unset, the
+	// manager's requeue.running and requeue.poweredOff apply
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// StatusRefreshInterval is how often VMs on this provider whose state is
+	// changing (in a transitional power state, or powered on without
+	// addresses yet) are described again. It is kept between 5s and the
+	// effective reconcile interval. When unset, the manager's
+	// requeue.transitional and requeue.waitingForIP apply
+	// +optional
+	StatusRefreshInterval *metav1.Duration `json:"statusRefreshInterval,omitempty"`
+
+	// RequiredCapabilities lists capabilities the provider image must
+	// report, such as image_import. If the running image lacks one, the
+	// Provider is marked Degraded naming the missing capabilities and the
+	// image.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Enum=validate;create;delete;power;describe;get_capabilities;reconfigure;reconfigure_online;disk_expansion_online;snapshots;memory_snapshots;linked_clones;image_import;disk_export;disk_import;export_compression;task_status;console_output;sysprep;cloud_init_status;live_migration;console_proxy;image_publish;quiesced_snapshots;vsphere;libvirt;firecracker;qemu;mock
+	RequiredCapabilities []string `json:"requiredCapabilities,omitempty"`
 }
 
 // ProviderHealthCheck defines health checking configuration
@@ -326,6 +542,17 @@ type ProviderStatus struct {
 	// +optional
 	LastHealthCheck *metav1.Time `json:"lastHealthCheck,omitempty"`
 
+	// Endpoint is the hypervisor endpoint the provider runtime is given:
+	// spec.endpoint, or the URL spec.endpointRef resolves to
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// VerifiedEndpoint is the endpoint the provider was last verified
+	// against. While the endpoint in effect differs from it the endpoint is
+	// being migrated, and the ProviderEndpointMigrating condition is True
+	// +optional
+	VerifiedEndpoint string `json:"verifiedEndpoint,omitempty"`
+
 	// Runtime provides runtime status information
 	// +optional
 	Runtime *ProviderRuntimeStatus `json:"runtime,omitempty"`
@@ -350,21 +577,123 @@ type ProviderStatus struct {
 	// +optional
 	ReportedCapabilities *ReportedCapabilities `json:"reportedCapabilities,omitempty"`
 
+	// Protocol reports the protocol version the manager negotiated with the
+	// running provider and the features it leaves unavailable
+	// +optional
+	Protocol *ProviderProtocolStatus `json:"protocol,omitempty"`
+
 	// Version reports the provider version
 	// +optional
 	Version string `json:"version,omitempty"`
 
+	// GitSHA is the commit the running provider was built from
+	// +optional
+	GitSHA string `json:"gitSHA,omitempty"`
+
+	// Hypervisor identifies the hypervisor release the provider is
+	// connected to, as reported by the provider GetInfo RPC
+	// +optional
+	Hypervisor *HypervisorInfo `json:"hypervisor,omitempty"`
+
 	// ConnectedVMs is the number of VMs currently managed by this provider
 	// +optional
 	ConnectedVMs int32 `json:"connectedVMs,omitempty"`
 
-	// ResourceUsage provides resource usage statistics
+	// ResourceUsage is the hypervisor capacity and utilization summed over
+	// every host the provider reports. It is only set for providers that
+	// implement capacity reporting.
 	// +optional
 	ResourceUsage *ProviderResourceUsage `json:"resourceUsage,omitempty"`
 
 	// Adoption tracks VM adoption status
 	// +optional
 	Adoption *ProviderAdoptionStatus `json:"adoption,omitempty"`
+
+	// TLS reports the certificate the controller requested from
+	// cert-manager, for Providers with spec.runtime.service.tls.certManager
+	// +optional
+	TLS *ProviderTLSStatus `json:"tls,omitempty"`
+
+	// Maintenance is set while the provider is in maintenance, from
+	// spec.maintenanceMode or the manager configuration
+	// +optional
+	Maintenance *ProviderMaintenanceStatus `json:"maintenance,omitempty"`
+}
+
+// ProviderMaintenanceStatus describes a provider in maintenance.
+type ProviderMaintenanceStatus struct {
+	// Since is when the provider entered maintenance
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// Global is true when maintenance comes from the manager configuration
+	// rather than from spec.maintenanceMode
+	// +optional
+	Global bool `json:"global,omitempty"`
+
+	// HeldOperations is the number of VM creates, snapshots and clones
+	// waiting for maintenance to end
+	HeldOperations int32 `json:"heldOperations"`
+}
+
+// ProviderProtocolStatus describes the protocol version the manager speaks
+// with a provider.
+type ProviderProtocolStatus struct {
+	// Compatibility summarizes how well the manager and the provider
+	// understand each other. The VMs of an Incompatible provider are not
+	// reconciled, except to delete them.
+	// +kubebuilder:validation:Enum=Compatible;DegradedCompatibility;Incompatible
+	Compatibility string `json:"compatibility"`
+
+	// ProviderMinVersion is the oldest protocol version the provider speaks
+	// +optional
+	ProviderMinVersion int32 `json:"providerMinVersion,omitempty"`
+
+	// ProviderMaxVersion is the newest protocol version the provider speaks
+	// +optional
+	ProviderMaxVersion int32 `json:"providerMaxVersion,omitempty"`
+
+	// NegotiatedVersion is the version the manager speaks to the provider.
+	// It is unset when the provider is Incompatible.
+	// +optional
+	NegotiatedVersion int32 `json:"negotiatedVersion,omitempty"`
+
+	// MissingFeatures lists the manager features the negotiated version is
+	// too old for
+	// +optional
+	MissingFeatures []string `json:"missingFeatures,omitempty"`
+}
+
+// ProviderTLSStatus describes a provider's cert-manager certificate.
+type ProviderTLSStatus struct {
+	// SecretName is the Secret the certificate is issued into
+	SecretName string `json:"secretName"`
+
+	// Ready is true once the Secret holds an issued certificate and CA
+	Ready bool `json:"ready"`
+
+	// NotAfter is when the current certificate expires
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is when cert-manager will renew the certificate
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// HypervisorInfo identifies the product and release of a hypervisor
+type HypervisorInfo struct {
+	// Product is the hypervisor product, e.g. "Proxmox VE"
+	// +optional
+	Product string `json:"product,omitempty"`
+
+	// Version is the product version, e.g. "8.2.4"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Details carries extra provider-specific detail such as a build number
+	// +optional
+	Details string `json:"details,omitempty"`
 }
 
 // ReportedCapabilities mirrors the provider.v1 GetCapabilitiesResponse — the
@@ -426,6 +755,42 @@ type ReportedCapabilities struct {
 	// that predates ADR-0006 (ADR-0006 Slice 0).
 	// +optional
 	SupportedTransferModes []string `json:"supportedTransferModes,omitempty"`
+	// SupportsConsoleOutput reports serial/console log retrieval support
+	// (GetConsoleOutput RPC).
+	// +optional
+	SupportsConsoleOutput bool `json:"supportsConsoleOutput,omitempty"`
+	// SupportsSysprep reports that Create applies Sysprep guest
+	// customization.
+	// +optional
+	SupportsSysprep bool `json:"supportsSysprep,omitempty"`
+	// SupportsCloudInitStatus reports cloud-init status retrieval from the
+	// guest (GetCloudInitStatus RPC).
+	// +optional
+	SupportsCloudInitStatus bool `json:"supportsCloudInitStatus,omitempty"`
+	// SupportsLiveMigration reports live migration of running VMs between
+	// the hosts the provider manages (MigrateHost RPC).
+	// +optional
+	SupportsLiveMigration bool `json:"supportsLiveMigration,omitempty"`
+	// SupportsConsoleProxy reports interactive consoles through the
+	// manager's console proxy (ConsoleTicket RPC).
+	// +optional
+	SupportsConsoleProxy bool `json:"supportsConsoleProxy,omitempty"`
+	// SupportsImagePublish reports publishing VMs as templates or images
+	// (PublishImage RPC).
+	// +optional
+	SupportsImagePublish bool `json:"supportsImagePublish,omitempty"`
+	// SupportsEventWatch reports streaming hypervisor events to the manager,
+	// which records them on the affected VirtualMachines (WatchEvents RPC).
+	// +optional
+	SupportsEventWatch bool `json:"supportsEventWatch,omitempty"`
+	// SupportsQuiescedSnapshots reports snapshots taken with the guest's
+	// filesystems frozen through its agent (VMSnapshot quiesce).
+	// +optional
+	SupportsQuiescedSnapshots bool `json:"supportsQuiescedSnapshots,omitempty"`
+	// CapabilityManifest lists the capability flags the provider image was
+	// built with. Images built before the manifest existed leave it empty.
+	// +optional
+	CapabilityManifest []string `json:"capabilityManifest,omitempty"`
 }
 
 // ProviderAdoptionStatus tracks VM adoption progress
@@ -482,15 +847,16 @@ const (
 
 // ProviderResourceUsage provides resource usage statistics
 type ProviderResourceUsage struct {
-	// CPU usage statistics
+	// CPU usage statistics, in physical cores. Used is the core-equivalent
+	// of the current CPU load.
 	// +optional
 	CPU *ResourceUsageStats `json:"cpu,omitempty"`
 
-	// Memory usage statistics
+	// Memory usage statistics, in bytes
 	// +optional
 	Memory *ResourceUsageStats `json:"memory,omitempty"`
 
-	// Storage usage statistics
+	// Storage usage statistics, in bytes
 	// +optional
 	Storage *ResourceUsageStats `json:"storage,omitempty"`
 
@@ -604,9 +970,12 @@ const (
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
-//+kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+//+kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`
 //+kubebuilder:printcolumn:name="Healthy",type=boolean,JSONPath=`.status.healthy`
 //+kubebuilder:printcolumn:name="Connected VMs",type=integer,JSONPath=`.status.connectedVMs`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`,priority=1
+//+kubebuilder:printcolumn:name="Hypervisor",type=string,JSONPath=`.status.hypervisor.version`,priority=1
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.status.protocol.compatibility`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:resource:shortName=prov
 
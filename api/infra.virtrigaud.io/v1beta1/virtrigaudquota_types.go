@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtrigaudQuotaSpec defines the limits of a VirtrigaudQuota. A limit that
+// is not set is not enforced.
+type VirtrigaudQuotaSpec struct {
+	// MaxVMs limits the number of VirtualMachines in the namespace
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxVMs *int32 `json:"maxVMs,omitempty"`
+
+	// MaxSnapshotsPerVM limits the number of VMSnapshots of any one VM.
+	// Failed snapshots are not counted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSnapshotsPerVM *int32 `json:"maxSnapshotsPerVM,omitempty"`
+
+	// MaxTotalSnapshots limits the number of VMSnapshots in the namespace.
+	// Failed snapshots are not counted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxTotalSnapshots *int32 `json:"maxTotalSnapshots,omitempty"`
+
+	// MaxClonesInFlight limits the number of VMClones in the namespace that
+	// are neither Ready nor Failed
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxClonesInFlight *int32 `json:"maxClonesInFlight,omitempty"`
+
+	// MaxTotalDiskGiB limits the disk allocated to the namespace's VMs: each
+	// VM's class default disk plus its additional disks
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxTotalDiskGiB *int64 `json:"maxTotalDiskGiB,omitempty"`
+}
+
+// VirtrigaudQuotaUsage is what the namespace currently consumes, in the
+// units of the matching limits.
+type VirtrigaudQuotaUsage struct {
+	// VMs is the number of VirtualMachines
+	VMs int32 `json:"vms"`
+
+	// MostSnapshotsPerVM is the snapshot count of the VM with the most
+	// snapshots, compared against maxSnapshotsPerVM
+	MostSnapshotsPerVM int32 `json:"mostSnapshotsPerVM"`
+
+	// TotalSnapshots is the number of VMSnapshots that are not Failed
+	TotalSnapshots int32 `json:"totalSnapshots"`
+
+	// ClonesInFlight is the number of VMClones that are neither Ready nor
+	// Failed
+	ClonesInFlight int32 `json:"clonesInFlight"`
+
+	// TotalDiskGiB is the disk allocated to the namespace's VMs, rounded up
+	// to whole GiB
+	TotalDiskGiB int64 `json:"totalDiskGiB"`
+}
+
+// VirtrigaudQuotaStatus defines the observed state of VirtrigaudQuota
+type VirtrigaudQuotaStatus struct {
+	// Used is the namespace's current consumption
+	// +optional
+	Used *VirtrigaudQuotaUsage `json:"used,omitempty"`
+
+	// Exceeded lists the limits the namespace is above, e.g. after a limit
+	// was lowered. Existing objects are left alone; requests that add to
+	// these dimensions are denied.
+	// +optional
+	Exceeded []string `json:"exceeded,omitempty"`
+
+	// ObservedGeneration is the generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="VMs",type=integer,JSONPath=`.status.used.vms`
+//+kubebuilder:printcolumn:name="Max VMs",type=integer,JSONPath=`.spec.maxVMs`
+//+kubebuilder:printcolumn:name="Snapshots",type=integer,JSONPath=`.status.used.totalSnapshots`
+//+kubebuilder:printcolumn:name="Clones",type=integer,JSONPath=`.status.used.clonesInFlight`
+//+kubebuilder:printcolumn:name="Disk GiB",type=integer,JSONPath=`.status.used.totalDiskGiB`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:resource:shortName=vquota
+
+// VirtrigaudQuota limits the VMs, snapshots, clones and disk a namespace may
+// consume. Admission denies requests that would exceed a limit, and the
+// controllers check again before acting, so concurrent requests cannot
+// overshoot it. A namespace may hold several quotas; each is enforced.
+// +kubebuilder:storageversion
+type VirtrigaudQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtrigaudQuotaSpec   `json:"spec,omitempty"`
+	Status VirtrigaudQuotaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VirtrigaudQuotaList contains a list of VirtrigaudQuota
+type VirtrigaudQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtrigaudQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtrigaudQuota{}, &VirtrigaudQuotaList{})
+}
@@ -48,6 +48,10 @@ type VirtualMachineSpec struct {
 	// +kubebuilder:validation:MaxItems=10
 	Networks []VMNetworkRef `json:"networks,omitempty"`
 
+	// Networking configures how the VM's addresses are reported in status
+	// +optional
+	Networking *VMNetworkingSpec `json:"networking,omitempty"`
+
 	// Disks specifies additional disks beyond the root disk
 	// +optional
 	// +kubebuilder:validation:MaxItems=20
@@ -61,6 +65,11 @@ type VirtualMachineSpec struct {
 	// +optional
 	MetaData *MetaData `json:"metaData,omitempty"`
 
+	// GuestCustomization selects how the guest OS is customized on first
+	// boot. Without it the guest gets cloud-init from userData and metaData.
+	// +optional
+	GuestCustomization *GuestCustomization `json:"guestCustomization,omitempty"`
+
 	// Placement provides hints for VM placement
 	// +optional
 	Placement *Placement `json:"placement,omitempty"`
@@ -69,6 +78,12 @@ type VirtualMachineSpec struct {
 	// +optional
 	PowerState PowerState `json:"powerState,omitempty"`
 
+	// PowerOpRequest asks for a one-shot power operation such as a restart.
+	// The controller performs it once per requestID, records the outcome in
+	// status.lastPowerOp and then clears this field.
+	// +optional
+	PowerOpRequest *PowerOpRequest `json:"powerOpRequest,omitempty"`
+
 	// Tags are applied to the VM for organization
 	// +optional
 	// +kubebuilder:validation:MaxItems=50
@@ -78,6 +93,15 @@ type VirtualMachineSpec struct {
 	// +optional
 	Resources *VirtualMachineResources `json:"resources,omitempty"`
 
+	// UpdateStrategy decides what happens to changes the provider cannot
+	// apply to the running VM. LiveOnly leaves them pending until the VM is
+	// next powered off; Automatic shuts the VM down, applies them and powers
+	// it back on; Manual does the same once the VM is annotated
+	// virtrigaud.io/apply-pending-changes.
+	// +optional
+	// +kubebuilder:default=LiveOnly
+	UpdateStrategy VMUpdateStrategy `json:"updateStrategy,omitempty"`
+
 	// PlacementRef references a VMPlacementPolicy for advanced placement rules
 	// +optional
 	PlacementRef *LocalObjectReference `json:"placementRef,omitempty"`
@@ -89,8 +113,69 @@ type VirtualMachineSpec struct {
 	// Lifecycle defines VM lifecycle configuration
 	// +optional
 	Lifecycle *VirtualMachineLifecycle `json:"lifecycle,omitempty"`
+
+	// Readiness adds conditions the VM must meet, beyond running on the
+	// hypervisor, before it is reported Ready
+	// +optional
+	Readiness *VMReadinessSpec `json:"readiness,omitempty"`
+
+	// AdoptExisting takes over a VM that already exists on the hypervisor
+	// instead of creating one. ImageRef and ImportedDisk are not needed.
+	// +optional
+	AdoptExisting *AdoptExistingSpec `json:"adoptExisting,omitempty"`
 }
 
+// VMNetworkingSpec configures address reporting for a VM
+type VMNetworkingSpec struct {
+	// PrimaryIPPolicy selects status.primaryIP. FirstIPv4 and FirstIPv6
+	// take the first address of that family, falling back to the first
+	// address of the other; Subnet:<cidr> takes the first address inside
+	// the CIDR and leaves primaryIP empty while there is none. Defaults to
+	// FirstIPv4.
+	// +optional
+	PrimaryIPPolicy PrimaryIPPolicy `json:"primaryIPPolicy,omitempty"`
+}
+
+// PrimaryIPPolicy selects a VM's primary IP: FirstIPv4, FirstIPv6 or
+// Subnet:<cidr>
+// +kubebuilder:validation:Pattern=`^(FirstIPv4|FirstIPv6|Subnet:.+)$`
+type PrimaryIPPolicy string
+
+const (
+	// PrimaryIPPolicyFirstIPv4 prefers the first IPv4 address
+	PrimaryIPPolicyFirstIPv4 PrimaryIPPolicy = "FirstIPv4"
+	// PrimaryIPPolicyFirstIPv6 prefers the first IPv6 address
+	PrimaryIPPolicyFirstIPv6 PrimaryIPPolicy = "FirstIPv6"
+	// PrimaryIPPolicySubnetPrefix precedes the CIDR of a subnet policy
+	PrimaryIPPolicySubnetPrefix = "Subnet:"
+)
+
+// AdoptExistingSpec identifies the hypervisor VM a VirtualMachine adopts.
+type AdoptExistingSpec struct {
+	// ID is the provider-specific identifier of the VM to adopt, in the form
+	// the provider reports in status.id. It cannot be changed once set.
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// DeletionPolicy controls what happens to the hypervisor VM when the
+	// VirtualMachine is deleted. Retain leaves it in place; Delete removes
+	// it as for any other VirtualMachine.
+	// +optional
+	// +kubebuilder:default=Retain
+	DeletionPolicy AdoptDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// AdoptDeletionPolicy is the fate of an adopted VM on deletion
+// +kubebuilder:validation:Enum=Retain;Delete
+type AdoptDeletionPolicy string
+
+const (
+	// AdoptDeletionPolicyRetain leaves the hypervisor VM in place
+	AdoptDeletionPolicyRetain AdoptDeletionPolicy = "Retain"
+	// AdoptDeletionPolicyDelete deletes the hypervisor VM with the VirtualMachine
+	AdoptDeletionPolicyDelete AdoptDeletionPolicy = "Delete"
+)
+
 // PowerState represents the desired power state of a VM
 // +kubebuilder:validation:Enum=On;Off;OffGraceful
 type PowerState string
@@ -104,6 +189,217 @@ const (
 	PowerStateOffGraceful PowerState = "OffGraceful"
 )
 
+// PowerOpRequest is a one-shot power operation on a running VM
+type PowerOpRequest struct {
+	// Op is the operation to perform
+	Op PowerOpType `json:"op"`
+
+	// RequestID identifies this request. An operation is performed at most
+	// once per ID, so re-applying the same request is a no-op; use a new ID
+	// to ask again.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	RequestID string `json:"requestID"`
+}
+
+// PowerOpType is a one-shot power operation
+// +kubebuilder:validation:Enum=Restart;HardReset
+type PowerOpType string
+
+const (
+	// PowerOpRestart reboots the VM, through the guest where the provider
+	// supports it
+	PowerOpRestart PowerOpType = "Restart"
+	// PowerOpHardReset resets the VM without involving the guest
+	PowerOpHardReset PowerOpType = "HardReset"
+)
+
+// PowerOpStatus records the last one-shot power operation
+type PowerOpStatus struct {
+	// Op is the operation that was requested
+	Op PowerOpType `json:"op"`
+
+	// RequestID is the spec.powerOpRequest.requestID this entry answers
+	RequestID string `json:"requestID"`
+
+	// Result is the outcome of the operation
+	Result PowerOpResult `json:"result"`
+
+	// Message explains a failed operation
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TaskRef is the provider task performing the operation, while it runs
+	// +optional
+	TaskRef string `json:"taskRef,omitempty"`
+
+	// CompletedAt is when the operation finished
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// TaskRecord identifies the last hypervisor task started for an object,
+// so that it can be found in the hypervisor's own UI
+type TaskRecord struct {
+	// ID is the provider's task reference, e.g. a Proxmox UPID or a vSphere
+	// task MoRef
+	ID string `json:"id"`
+
+	// Operation is what the task was started for, e.g. Create or Power On
+	// +optional
+	Operation string `json:"operation,omitempty"`
+
+	// Link opens the task in the hypervisor's web UI. It is only recorded
+	// when it points at the Provider's configured endpoint.
+	// +optional
+	Link string `json:"link,omitempty"`
+
+	// StartTime is when the task was started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Error is the reason the task failed, as the provider reported it
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// PowerOpResult is the outcome of a one-shot power operation
+// +kubebuilder:validation:Enum=InProgress;Succeeded;Failed
+type PowerOpResult string
+
+const (
+	// PowerOpResultInProgress indicates the operation has been sent to the provider
+	PowerOpResultInProgress PowerOpResult = "InProgress"
+	// PowerOpResultSucceeded indicates the provider performed the operation
+	PowerOpResultSucceeded PowerOpResult = "Succeeded"
+	// PowerOpResultFailed indicates the operation failed or was not attempted
+	PowerOpResultFailed PowerOpResult = "Failed"
+)
+
+// VMUpdateStrategy is how changes that need a power cycle are applied
+// +kubebuilder:validation:Enum=LiveOnly;Automatic;Manual
+type VMUpdateStrategy string
+
+const (
+	// VMUpdateStrategyLiveOnly never restarts the VM. Changes the provider
+	// cannot apply live stay pending until the VM is powered off.
+	VMUpdateStrategyLiveOnly VMUpdateStrategy = "LiveOnly"
+	// VMUpdateStrategyAutomatic shuts the VM down gracefully, applies the
+	// pending changes and powers it back on.
+	VMUpdateStrategyAutomatic VMUpdateStrategy = "Automatic"
+	// VMUpdateStrategyManual records the pending changes and power cycles
+	// the VM to apply them once it is annotated with
+	// ApplyPendingChangesAnnotation.
+	VMUpdateStrategyManual VMUpdateStrategy = "Manual"
+)
+
+// ApplyPendingChangesAnnotation approves the power cycle that applies the
+// pending changes of a VM with the Manual update strategy. The controller
+// removes it once the power cycle has started.
+const ApplyPendingChangesAnnotation = "virtrigaud.io/apply-pending-changes"
+
+// PausedAnnotation, set to "true" on a VirtualMachine or on its Namespace,
+// stops the controller from acting on the VM (no create, power correction
+// or reconfigure) until it is removed. Deletion is still processed so the
+// finalizer never wedges.
+const PausedAnnotation = "virtrigaud.io/paused"
+
+const (
+	// ConsoleLogRequestAnnotation asks the controller to capture the VM's
+	// serial/console output. The value is the number of tail lines to fetch
+	// ("" or an unparsable value selects the provider default). The controller
+	// removes the annotation once the capture has been written, which is the
+	// signal `vrtg vm console-log` waits for.
+	ConsoleLogRequestAnnotation = "virtrigaud.io/console-log-request"
+
+	// ConsoleLogConfigMapSuffix is appended to the VM name to form the name of
+	// the ConfigMap that holds the captured console output.
+	ConsoleLogConfigMapSuffix = "-console-log"
+
+	// ConsoleLogDataKey is the ConfigMap data key holding the console text.
+	ConsoleLogDataKey = "output"
+
+	// ConsoleLogErrorKey is the ConfigMap data key set instead of
+	// ConsoleLogDataKey when the capture failed.
+	ConsoleLogErrorKey = "error"
+)
+
+// ConsoleLogConfigMapName returns the name of the ConfigMap holding a VM's
+// captured console output.
+func ConsoleLogConfigMapName(vmName string) string {
+	return vmName + ConsoleLogConfigMapSuffix
+}
+
+// UpdateCyclePhase is the step a power cycle that applies pending changes
+// is at
+// +kubebuilder:validation:Enum=ShuttingDown;PoweringOff;Applying;PoweringOn;Completed;Failed
+type UpdateCyclePhase string
+
+const (
+	// UpdateCyclePhaseShuttingDown waits for the guest to shut down
+	UpdateCyclePhaseShuttingDown UpdateCyclePhase = "ShuttingDown"
+	// UpdateCyclePhasePoweringOff waits for a VM that did not shut down in
+	// time to be powered off
+	UpdateCyclePhasePoweringOff UpdateCyclePhase = "PoweringOff"
+	// UpdateCyclePhaseApplying applies the changes to the powered-off VM
+	UpdateCyclePhaseApplying UpdateCyclePhase = "Applying"
+	// UpdateCyclePhasePoweringOn waits for the VM to be powered back on,
+	// whether or not the changes were applied
+	UpdateCyclePhasePoweringOn UpdateCyclePhase = "PoweringOn"
+	// UpdateCyclePhaseCompleted means the changes were applied and the VM
+	// is running again
+	UpdateCyclePhaseCompleted UpdateCyclePhase = "Completed"
+	// UpdateCyclePhaseFailed means the changes were not applied; the VM was
+	// powered back on regardless
+	UpdateCyclePhaseFailed UpdateCyclePhase = "Failed"
+)
+
+// UpdateCycleStatus tracks the last power cycle the controller performed
+// to apply pending changes
+type UpdateCycleStatus struct {
+	// Phase is the step the power cycle is at
+	Phase UpdateCyclePhase `json:"phase"`
+
+	// Changes are the pending changes the power cycle applies
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+
+	// StartedAt is when the VM was asked to shut down
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// Generation is the metadata.generation the power cycle started at.
+	// An Automatic power cycle that failed is not retried until it changes.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// LastTransitionTime is when the power cycle entered its phase
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// TaskRef is the provider task applying the changes, while it runs
+	// +optional
+	TaskRef string `json:"taskRef,omitempty"`
+
+	// Failure explains why the changes were not applied
+	// +optional
+	Failure string `json:"failure,omitempty"`
+}
+
+// VMReadinessSpec configures what a VM waits for before it is Ready
+type VMReadinessSpec struct {
+	// WaitForCloudInit keeps the VM from becoming Ready until cloud-init in
+	// the guest reports done, as shown by the CloudInitCompleted condition.
+	// It needs the QEMU guest agent (libvirt, Proxmox) or a guest that
+	// publishes its status in guestinfo (vSphere). Without them, the VM is
+	// Ready as soon as it runs.
+	// +optional
+	WaitForCloudInit bool `json:"waitForCloudInit,omitempty"`
+
+	// CloudInitTimeout bounds the wait for cloud-init. When it passes, the
+	// VM becomes Ready anyway. Defaults to 15m.
+	// +optional
+	CloudInitTimeout *metav1.Duration `json:"cloudInitTimeout,omitempty"`
+}
+
 // VirtualMachineLifecycle defines lifecycle configuration for a VM
 type VirtualMachineLifecycle struct {
 	// PreStop defines actions to take before stopping the VM
@@ -227,14 +523,33 @@ type VirtualMachineStatus struct {
 	// +optional
 	ID string `json:"id,omitempty"`
 
+	// Identity is the identity marker the provider VM behind ID was
+	// verified to carry: the VirtualMachine's UID for a VM virtrigaud
+	// created, or whatever marker an adopted VM had. A VM at ID carrying a
+	// different marker is not acted on.
+	// +optional
+	Identity string `json:"identity,omitempty"`
+
 	// PowerState reflects the current power state
 	// +optional
 	PowerState PowerState `json:"powerState,omitempty"`
 
-	// IPs contains the IP addresses assigned to the VM
+	// IPs contains the IP addresses assigned to the VM, IPv4 before IPv6
+	// and each family in address order. Loopback and link-local addresses
+	// are left out.
 	// +optional
 	IPs []string `json:"ips,omitempty"`
 
+	// PrimaryIP is the address spec.networking.primaryIPPolicy selects
+	// from IPs
+	// +optional
+	PrimaryIP string `json:"primaryIP,omitempty"`
+
+	// Addresses lists IPs with their family and, where the provider
+	// reports it, the interface each was found on
+	// +optional
+	Addresses []VMAddress `json:"addresses,omitempty"`
+
 	// ConsoleURL provides access to the VM console
 	// +optional
 	ConsoleURL string `json:"consoleURL,omitempty"`
@@ -243,19 +558,46 @@ type VirtualMachineStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// ObservedGeneration reflects the generation observed by the controller
+	// ObservedGeneration is the most recent generation the controller has
+	// acted on: it has issued the provider operations that spec called for,
+	// or found that none were needed. It lags metadata.generation while a
+	// change is blocked on a dependency or an earlier operation.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// LastPowerOp records the last spec.powerOpRequest handled
+	// +optional
+	LastPowerOp *PowerOpStatus `json:"lastPowerOp,omitempty"`
+
 	// LastTaskRef references the last async operation
 	// +optional
 	LastTaskRef string `json:"lastTaskRef,omitempty"`
 
+	// LastTask records the last hypervisor task started for the VM and,
+	// when the provider reports one, a link to it
+	// +optional
+	LastTask *TaskRecord `json:"lastTask,omitempty"`
+
 	// Provider contains provider-specific details
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Provider map[string]string `json:"provider,omitempty"`
 
+	// Tags are the tags the VM was created with: its provider's defaultTags
+	// and defaultLabels merged with spec.tags
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Host is the hypervisor host the VM runs on, as the provider last
+	// reported it, or the host chosen for it at creation until then.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// MigrationTaskRef tracks the live migration to another host
+	// requested with the virtrigaud.io/requested-host annotation
+	// +optional
+	MigrationTaskRef string `json:"migrationTaskRef,omitempty"`
+
 	// ReconfigureTaskRef tracks reconfiguration operations
 	// +optional
 	ReconfigureTaskRef string `json:"reconfigureTaskRef,omitempty"`
@@ -268,10 +610,47 @@ type VirtualMachineStatus struct {
 	// +optional
 	CurrentResources *VirtualMachineResources `json:"currentResources,omitempty"`
 
-	// Snapshots lists available snapshots for this VM
+	// AppliedConfig records the disk and network layout last applied to the
+	// provider. Together with CurrentResources it is the baseline a spec
+	// change is diffed against to decide what a reconfigure must change.
+	// +optional
+	AppliedConfig *VirtualMachineAppliedConfig `json:"appliedConfig,omitempty"`
+
+	// PendingPowerCycle lists changes (cpu, memoryMiB, disks, networks) the
+	// provider could not apply to the running VM. They are applied once the
+	// VM is powered off.
+	// +optional
+	PendingPowerCycle []string `json:"pendingPowerCycle,omitempty"`
+
+	// UpdateCycle is the power cycle performed to apply PendingPowerCycle
+	// under the Automatic or Manual update strategy, while it runs and
+	// after it finished
+	// +optional
+	UpdateCycle *UpdateCycleStatus `json:"updateCycle,omitempty"`
+
+	// PlannedActions lists the provider operations the controller would
+	// perform while the VM is annotated virtrigaud.io/dry-run=true. It is
+	// cleared once the annotation is removed.
+	// +optional
+	PlannedActions []PlannedAction `json:"plannedActions,omitempty"`
+
+	// Snapshots lists the snapshots that exist for this VM on the
+	// hypervisor, as last observed by the VMSnapshot controller. It includes
+	// snapshots taken outside virtrigaud.
 	// +optional
 	Snapshots []VMSnapshotInfo `json:"snapshots,omitempty"`
 
+	// SnapshotChain counts the VM's snapshots as the provider last
+	// described them. Unset when the provider does not report them.
+	// +optional
+	SnapshotChain *VMSnapshotChainStatus `json:"snapshotChain,omitempty"`
+
+	// GuestStats is the resource usage the provider reported on the last
+	// reconcile. Unset when the provider does not report usage or the VM
+	// is not running.
+	// +optional
+	GuestStats *VMGuestStats `json:"guestStats,omitempty"`
+
 	// Phase represents the current phase of the VM
 	// +optional
 	Phase VirtualMachinePhase `json:"phase,omitempty"`
@@ -281,6 +660,121 @@ type VirtualMachineStatus struct {
 	Message string `json:"message,omitempty"`
 }
 
+// PlannedOperation is the provider operation a planned action stands for
+// +kubebuilder:validation:Enum=Create;Reconfigure;Power;Migrate;Delete
+type PlannedOperation string
+
+const (
+	// PlannedOperationCreate creates the VM on the provider
+	PlannedOperationCreate PlannedOperation = "Create"
+	// PlannedOperationReconfigure applies a change set to the VM
+	PlannedOperationReconfigure PlannedOperation = "Reconfigure"
+	// PlannedOperationPower changes the power state or performs a
+	// requested power operation
+	PlannedOperationPower PlannedOperation = "Power"
+	// PlannedOperationMigrate live-migrates the VM to another host
+	PlannedOperationMigrate PlannedOperation = "Migrate"
+	// PlannedOperationDelete deletes the VM from the provider
+	PlannedOperationDelete PlannedOperation = "Delete"
+)
+
+// PlannedAction is a provider operation a dry-run reconcile would perform
+type PlannedAction struct {
+	// Operation is the provider operation that would be performed
+	Operation PlannedOperation `json:"operation"`
+
+	// Parameters summarizes what the operation would change
+	// +optional
+	Parameters string `json:"parameters,omitempty"`
+
+	// PowerCycleRequired is true when the provider cannot apply the change
+	// to the running VM, so it would only take effect after a power off
+	// +optional
+	PowerCycleRequired bool `json:"powerCycleRequired,omitempty"`
+}
+
+// VMGuestStats is a sample of a VM's resource usage. A field the provider
+// did not report is unset.
+type VMGuestStats struct {
+	// CPUUsagePercent is utilization across all vCPUs, rounded to a whole
+	// percent
+	// +optional
+	CPUUsagePercent *int32 `json:"cpuUsagePercent,omitempty"`
+
+	// MemoryUsageBytes is memory in use by the guest
+	// +optional
+	MemoryUsageBytes *int64 `json:"memoryUsageBytes,omitempty"`
+
+	// UptimeSeconds is the time since the VM was last powered on
+	// +optional
+	UptimeSeconds *int64 `json:"uptimeSeconds,omitempty"`
+
+	// ObservedAt is when the sample was taken
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// VMAddress is an IP address of a VM
+type VMAddress struct {
+	// Address is the IP address
+	Address string `json:"address"`
+
+	// Family is IPv4 or IPv6
+	Family IPFamily `json:"family"`
+
+	// Interface is the guest's name for the interface the address is on,
+	// or the network its NIC is attached to when the provider does not
+	// know the guest's name for it
+	// +optional
+	Interface string `json:"interface,omitempty"`
+}
+
+// IPFamily is the family of an IP address
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 is an IPv4 address
+	IPFamilyIPv4 IPFamily = "IPv4"
+	// IPFamilyIPv6 is an IPv6 address
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
+// VirtualMachineAppliedConfig is the disk and network layout last applied to a VM
+type VirtualMachineAppliedConfig struct {
+	// Disks lists the applied size of each disk. The class default disk is
+	// named "root".
+	// +optional
+	Disks []AppliedDisk `json:"disks,omitempty"`
+
+	// Networks lists the names of the applied network attachments
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+
+	// SecurityGroups lists the firewall security groups applied to each
+	// network attachment that has any
+	// +optional
+	SecurityGroups []AppliedSecurityGroups `json:"securityGroups,omitempty"`
+}
+
+// AppliedSecurityGroups are the security groups last applied to a network
+// attachment
+type AppliedSecurityGroups struct {
+	// Network is the name of the network attachment
+	Network string `json:"network"`
+
+	// Groups are the applied security group names
+	Groups []string `json:"groups"`
+}
+
+// AppliedDisk is the size last applied to a disk
+type AppliedDisk struct {
+	// Name identifies the disk
+	Name string `json:"name"`
+
+	// SizeGiB is the applied size in GiB
+	SizeGiB int32 `json:"sizeGiB"`
+}
+
 // VirtualMachinePhase represents the phase of a VM
 // +kubebuilder:validation:Enum=Pending;Provisioning;Running;Stopped;Reconfiguring;Deleting;Failed
 type VirtualMachinePhase string
@@ -302,6 +796,18 @@ const (
 	VirtualMachinePhaseFailed VirtualMachinePhase = "Failed"
 )
 
+// VMSnapshotChainStatus counts a VM's snapshots on the hypervisor
+type VMSnapshotChainStatus struct {
+	// Count is the number of snapshots, on every branch of the VM's
+	// snapshot tree
+	Count int32 `json:"count"`
+
+	// Depth is the number of snapshots the running state is built on: the
+	// current snapshot and its ancestors. Each is a layer the VM's disks
+	// read through on hypervisors that keep snapshots as deltas.
+	Depth int32 `json:"depth"`
+}
+
 // VMSnapshotInfo provides information about a VM snapshot
 type VMSnapshotInfo struct {
 	// ID is the provider-specific snapshot identifier
@@ -310,7 +816,9 @@ type VMSnapshotInfo struct {
 	// Name is the snapshot name
 	Name string `json:"name"`
 
-	// CreationTime is when the snapshot was created
+	// CreationTime is when the snapshot was created; unset when the
+	// hypervisor does not record it
+	// +optional
 	CreationTime metav1.Time `json:"creationTime"`
 
 	// Description provides additional context
@@ -321,9 +829,18 @@ type VMSnapshotInfo struct {
 	// +optional
 	SizeBytes *int64 `json:"sizeBytes,omitempty"`
 
+	// ConsumedBytes is the datastore space the snapshot occupies
+	// +optional
+	ConsumedBytes *int64 `json:"consumedBytes,omitempty"`
+
 	// HasMemory indicates if memory state is included
 	// +optional
 	HasMemory bool `json:"hasMemory,omitempty"`
+
+	// ParentID is the ID of the snapshot this one was taken on top of;
+	// empty for a root snapshot
+	// +optional
+	ParentID string `json:"parentID,omitempty"`
 }
 
 // LocalObjectReference represents a reference to an object in the same namespace
@@ -348,6 +865,70 @@ type ObjectRef struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// CleanupArtifactKind is the kind of provider-side artifact a
+// CleanupRecord refers to
+// +kubebuilder:validation:Enum=VM;Snapshot;Disk
+type CleanupArtifactKind string
+
+const (
+	// CleanupArtifactVM is a VM, deleted with the provider Delete operation
+	CleanupArtifactVM CleanupArtifactKind = "VM"
+	// CleanupArtifactSnapshot is a snapshot of VMID, deleted with the
+	// provider SnapshotDelete operation
+	CleanupArtifactSnapshot CleanupArtifactKind = "Snapshot"
+	// CleanupArtifactDisk is a disk volume. Providers have no operation to
+	// delete a disk on its own, so a Disk record is abandoned on its first
+	// attempt and reports the volume for manual removal.
+	CleanupArtifactDisk CleanupArtifactKind = "Disk"
+)
+
+// CleanupRecord is a provider-side artifact that an aborted operation left
+// behind. It is kept in the status of the resource whose operation created
+// the artifact until the cleanup worker has deleted it or given up.
+type CleanupRecord struct {
+	// Kind is the kind of artifact
+	Kind CleanupArtifactKind `json:"kind"`
+
+	// ID is the provider's identifier of the artifact
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// VMID is the provider identifier of the VM a Snapshot belongs to
+	// +optional
+	VMID string `json:"vmID,omitempty"`
+
+	// ProviderRef is the Provider the artifact lives on
+	ProviderRef ObjectRef `json:"providerRef"`
+
+	// Reason says why the artifact was left behind
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// RecordedAt is when the artifact was recorded
+	RecordedAt metav1.Time `json:"recordedAt"`
+
+	// Attempts is the number of deletions that failed
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastError is the error of the last failed deletion
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// NextAttemptTime is the earliest time of the next deletion
+	// +optional
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
+
+	// TaskRef tracks an asynchronous deletion in flight
+	// +optional
+	TaskRef string `json:"taskRef,omitempty"`
+
+	// Abandoned is set once the artifact can no longer be deleted
+	// automatically and must be removed by hand
+	// +optional
+	Abandoned bool `json:"abandoned,omitempty"`
+}
+
 // VMNetworkRef represents a reference to a network attachment
 type VMNetworkRef struct {
 	// Name is the name of this network attachment
@@ -356,7 +937,10 @@ type VMNetworkRef struct {
 	Name string `json:"name"`
 
 	// NetworkRef references the VMNetworkAttachment (optional)
-	// When not specified, the template's pre-configured network adapter is used.
+	// When not specified, the template's pre-configured network adapter is used
+	// and providers interpret Name on their own (Proxmox maps it to a bridge).
+	// The referenced network must define a binding for the VM's provider type;
+	// only that binding is passed to the provider.
 	// +optional
 	NetworkRef *ObjectRef `json:"networkRef,omitempty"`
 
@@ -384,6 +968,25 @@ type VMNetworkRef struct {
 	// +optional
 	// +kubebuilder:validation:Pattern="^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"
 	MACAddress string `json:"macAddress,omitempty"`
+
+	// IPv6 sets a static IPv6 address, alongside ipAddress for dual-stack
+	// or on its own. Without it the guest keeps its default IPv6
+	// configuration, usually router advertisements.
+	// +optional
+	IPv6 *IPv6StaticConfig `json:"ipv6,omitempty"`
+}
+
+// IPv6StaticConfig is a static IPv6 address of a network attachment
+type IPv6StaticConfig struct {
+	// Address is the address with its prefix length, e.g. 2001:db8::10/64
+	// +kubebuilder:validation:MaxLength=49
+	Address string `json:"address"`
+
+	// Gateway is the IPv6 default gateway, e.g. 2001:db8::1 or a
+	// link-local router address such as fe80::1
+	// +optional
+	// +kubebuilder:validation:MaxLength=45
+	Gateway string `json:"gateway,omitempty"`
 }
 
 // DiskSpec defines a disk configuration
@@ -488,6 +1091,39 @@ type UserData struct {
 	// Ignition contains Ignition configuration for CoreOS/RHEL
 	// +optional
 	Ignition *Ignition `json:"ignition,omitempty"`
+
+	// NetworkConfig is a cloud-init network-config document (version 1 or 2).
+	// It replaces the DHCP networking the provider would otherwise configure,
+	// e.g. to give the VM a static address.
+	// +optional
+	NetworkConfig *CloudInitDocument `json:"networkConfig,omitempty"`
+
+	// VendorData is cloud-init vendor-data: configuration cloud-init applies
+	// before user-data, which user-data may override. Typically an
+	// organisation-wide Secret shared by many VMs.
+	// +optional
+	VendorData *CloudInitDocument `json:"vendorData,omitempty"`
+}
+
+// CloudInitDocument is a cloud-init document given inline or read from a
+// Secret. Documents larger than a few kilobytes belong in a Secret.
+type CloudInitDocument struct {
+	// Inline contains the document
+	// +optional
+	// +kubebuilder:validation:MaxLength=65536
+	Inline string `json:"inline,omitempty"`
+
+	// SecretRef references a Secret containing the document. Set either
+	// inline or secretRef, not both.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Key is the Secret key holding the document. Defaults to the first of
+	// the document's conventional keys present (network-config or
+	// vendor-data, with or without the dash).
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
 }
 
 // CloudInit defines cloud-init configuration
@@ -496,9 +1132,92 @@ type CloudInit struct {
 	// +optional
 	Inline string `json:"inline,omitempty"`
 
-	// SecretRef references a Secret containing cloud-init data
+	// SecretRef references a Secret containing cloud-init data. Prefer it
+	// to inline for large user-data: inline is limited to 256 KiB.
 	// +optional
 	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Key is the Secret key holding the user-data. Defaults to the first of
+	// userdata, user-data, cloud-init and cloud-config present.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
+}
+
+// GuestCustomizationType selects the guest customization mechanism
+// +kubebuilder:validation:Enum=CloudInit;Sysprep
+type GuestCustomizationType string
+
+const (
+	// GuestCustomizationCloudInit customizes the guest with cloud-init
+	GuestCustomizationCloudInit GuestCustomizationType = "CloudInit"
+	// GuestCustomizationSysprep customizes a generalized Windows guest with
+	// an unattend.xml answer file
+	GuestCustomizationSysprep GuestCustomizationType = "Sysprep"
+)
+
+// GuestCustomization configures first-boot customization of the guest OS
+type GuestCustomization struct {
+	// Type is CloudInit, which uses spec.userData and spec.metaData, or
+	// Sysprep, which uses sysprep and cannot be combined with them
+	Type GuestCustomizationType `json:"type"`
+
+	// Sysprep configures a Windows guest. Required when type is Sysprep.
+	// +optional
+	Sysprep *SysprepSpec `json:"sysprep,omitempty"`
+}
+
+// SysprepSpec is a Windows answer file, given whole as unattend or
+// generated from the basic fields. Set unattend or the basic fields, not
+// both.
+type SysprepSpec struct {
+	// Unattend is a complete unattend.xml answer file
+	// +optional
+	Unattend *UnattendDocument `json:"unattend,omitempty"`
+
+	// Hostname is the computer name. Defaults to the VM name, cut to the 15
+	// characters Windows allows.
+	// +optional
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?$`
+	Hostname string `json:"hostname,omitempty"`
+
+	// AdminPasswordSecretRef references a Secret holding the local
+	// Administrator password
+	// +optional
+	AdminPasswordSecretRef *LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
+
+	// AdminPasswordKey is the Secret key holding the password. Defaults to
+	// password.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	AdminPasswordKey string `json:"adminPasswordKey,omitempty"`
+
+	// Timezone is a Windows time zone ID, such as "W. Europe Standard Time".
+	// Defaults to the template's time zone.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// UnattendDocument is an unattend.xml answer file given inline or read from
+// a Secret
+type UnattendDocument struct {
+	// Inline contains the answer file
+	// +optional
+	// +kubebuilder:validation:MaxLength=65536
+	Inline string `json:"inline,omitempty"`
+
+	// SecretRef references a Secret containing the answer file. Set either
+	// inline or secretRef, not both.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Key is the Secret key holding the answer file. Defaults to
+	// unattend.xml.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
 }
 
 // MetaData defines cloud-init metadata configuration
@@ -555,9 +1274,69 @@ type Placement struct {
 	// +optional
 	Folder string `json:"folder,omitempty"`
 
-	// ResourcePool specifies the target resource pool
+	// ResourcePool specifies the target resource pool. On Proxmox VE this is
+	// the PVE resource pool the VM is added to; the pool must exist.
 	// +optional
 	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// HA puts the VM under the hypervisor's high-availability manager so it
+	// is restarted elsewhere after a node failure. Supported by Proxmox VE.
+	// +optional
+	HA *HAPlacement `json:"ha,omitempty"`
+
+	// AntiAffinity keeps the VM off the hosts its peer VMs run on. It is
+	// applied when the VM is created only; running VMs are not moved.
+	// +optional
+	AntiAffinity *PlacementAntiAffinity `json:"antiAffinity,omitempty"`
+}
+
+// TopologyKeyHost is the topology key of the hypervisor host (a PVE node,
+// a vSphere ESXi host) a VM runs on.
+const TopologyKeyHost = "virtrigaud.io/host"
+
+// UnsatisfiableConstraintAction says what to do with a VM whose placement
+// constraint no host satisfies.
+type UnsatisfiableConstraintAction string
+
+const (
+	// DoNotSchedule holds the VM uncreated until a compliant host exists.
+	DoNotSchedule UnsatisfiableConstraintAction = "DoNotSchedule"
+	// ScheduleAnyway creates the VM on the host with the fewest peers.
+	ScheduleAnyway UnsatisfiableConstraintAction = "ScheduleAnyway"
+)
+
+// PlacementAntiAffinity spreads a VM and its peers across failure domains.
+type PlacementAntiAffinity struct {
+	// TopologyKey is the failure domain the VM and its peers are spread
+	// across. Only virtrigaud.io/host is supported.
+	// +optional
+	// +kubebuilder:default="virtrigaud.io/host"
+	// +kubebuilder:validation:Enum="virtrigaud.io/host"
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// LabelSelector selects the peer VMs, in the VM's namespace and on the
+	// same provider, whose hosts the VM avoids.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// WhenUnsatisfiable says what happens when every host runs a peer.
+	// +optional
+	// +kubebuilder:default=DoNotSchedule
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	WhenUnsatisfiable UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// HAPlacement configures high-availability management of a VM.
+type HAPlacement struct {
+	// Group restricts the nodes the VM may be recovered to. Empty allows
+	// any node.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// State is the state the HA manager keeps the VM in. Defaults to
+	// started.
+	// +optional
+	// +kubebuilder:validation:Enum=started;stopped;enabled;disabled;ignored
+	State string `json:"state,omitempty"`
 }
 
 // VM condition types
@@ -570,6 +1349,24 @@ const (
 	VirtualMachineConditionReconfiguring = "Reconfiguring"
 	// VirtualMachineConditionDeleting indicates whether the VM is being deleted
 	VirtualMachineConditionDeleting = "Deleting"
+	// VirtualMachineConditionAntiAffinity indicates whether the host the VM
+	// was created on satisfies spec.placement.antiAffinity
+	VirtualMachineConditionAntiAffinity = "AntiAffinitySatisfied"
+	// VirtualMachineConditionHypervisorAlarm indicates whether the provider
+	// reported a hypervisor warning or error about the VM that has not
+	// been resolved or timed out
+	VirtualMachineConditionHypervisorAlarm = "HypervisorAlarm"
+	// VirtualMachineConditionIdentityMismatch indicates that the provider
+	// VM at status.id carries another identity marker than the one
+	// recorded, so the ID now names a different VM
+	VirtualMachineConditionIdentityMismatch = "IdentityMismatch"
+	// VirtualMachineConditionSnapshotChainLong indicates whether the VM's
+	// snapshot chain is deeper than the manager's snapshots.chainWarningDepth
+	VirtualMachineConditionSnapshotChainLong = "SnapshotChainLong"
+	// VirtualMachineConditionProviderBusy indicates whether the provider
+	// reports the VM busy with an operation such as a backup or a
+	// migration, during which changes to it wait
+	VirtualMachineConditionProviderBusy = "ProviderBusy"
 )
 
 //+kubebuilder:object:root=true
@@ -581,6 +1378,7 @@ const (
 //+kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.spec.classRef.name`
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.imageRef.name`
 //+kubebuilder:printcolumn:name="IPs",type=string,JSONPath=`.status.ips[*]`
+//+kubebuilder:printcolumn:name="Primary-IP",type=string,JSONPath=`.status.primaryIP`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:storageversion
 
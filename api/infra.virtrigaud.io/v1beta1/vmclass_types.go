@@ -22,28 +22,56 @@ import (
 )
 
 // VMClassSpec defines the desired state of VMClass
+// +kubebuilder:validation:XValidation:rule="has(self.inheritFrom) || (has(self.cpu) && has(self.memory))",message="cpu and memory are required unless inheritFrom is set"
 type VMClassSpec struct {
-	// CPU specifies the number of virtual CPUs
+	// InheritFrom names a VMClass in the same namespace whose spec this
+	// class starts from. Fields set here override the parent's: scalars
+	// replace, maps and nested settings merge field by field, and lists
+	// replace. A field left unset, or set to its zero value, is inherited.
+	// The merged result is reported in status.resolvedSpec.
+	// +optional
+	InheritFrom *LocalObjectReference `json:"inheritFrom,omitempty"`
+
+	// CPU specifies the number of virtual CPUs. Required unless inherited.
+	// +optional
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=128
-	CPU int32 `json:"cpu"`
+	CPU int32 `json:"cpu,omitempty"`
 
-	// Memory specifies memory allocation using Kubernetes resource quantities
-	Memory resource.Quantity `json:"memory"`
+	// Memory specifies memory allocation using Kubernetes resource quantities.
+	// Providers receive it as whole MiB (rounded down); the normalized value
+	// is reported in status.normalized.memoryMiB. Required unless inherited.
+	// +optional
+	Memory resource.Quantity `json:"memory,omitempty"`
 
-	// Firmware specifies the firmware type
+	// Firmware specifies the firmware type. Defaults to BIOS once
+	// inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="BIOS"
-	// +kubebuilder:validation:Enum=BIOS;UEFI;EFI
+	// +kubebuilder:validation:Enum=BIOS;UEFI;EFI;UEFI-SecureBoot
 	Firmware FirmwareType `json:"firmware,omitempty"`
 
+	// MachineType is a hint for the emulated chipset, such as q35 or a
+	// versioned pc-q35-8.2. Providers that have no choice of machine type
+	// ignore it; libvirt defaults to q35 for UEFI and pc otherwise.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9._-]*$`
+	MachineType string `json:"machineType,omitempty"`
+
+	// MemoryBalloon configures the guest memory balloon. When enabled the
+	// hypervisor may reclaim guest memory down to MinimumMiB, and memory
+	// changes within the VM's configured maximum are applied online by
+	// resizing the balloon. Providers without a balloon device ignore it.
+	// +optional
+	MemoryBalloon *MemoryBalloon `json:"memoryBalloon,omitempty"`
+
 	// DiskDefaults provides default disk settings
 	// +optional
 	DiskDefaults *DiskDefaults `json:"diskDefaults,omitempty"`
 
-	// GuestToolsPolicy specifies guest tools installation policy
+	// GuestToolsPolicy specifies guest tools installation policy. Defaults
+	// to install once inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="install"
 	GuestToolsPolicy GuestToolsPolicy `json:"guestToolsPolicy,omitempty"`
 
 	// ExtraConfig contains provider-specific extra configuration
@@ -65,7 +93,7 @@ type VMClassSpec struct {
 }
 
 // FirmwareType represents the firmware type for VMs
-// +kubebuilder:validation:Enum=BIOS;UEFI;EFI
+// +kubebuilder:validation:Enum=BIOS;UEFI;EFI;UEFI-SecureBoot
 type FirmwareType string
 
 const (
@@ -75,6 +103,9 @@ const (
 	FirmwareTypeUEFI FirmwareType = "UEFI"
 	// FirmwareTypeEFI indicates EFI firmware (alias for UEFI)
 	FirmwareTypeEFI FirmwareType = "EFI"
+	// FirmwareTypeUEFISecureBoot indicates UEFI firmware with Secure Boot
+	// enabled
+	FirmwareTypeUEFISecureBoot FirmwareType = "UEFI-SecureBoot"
 )
 
 // GuestToolsPolicy represents the guest tools installation policy
@@ -121,11 +152,32 @@ type VMResourceLimits struct {
 	CPUShares *int32 `json:"cpuShares,omitempty"`
 }
 
+// MemoryBalloon configures the guest memory balloon
+type MemoryBalloon struct {
+	// Enabled attaches a balloon device. Setting it to false removes the
+	// device, which makes every memory change need a power cycle.
+	Enabled bool `json:"enabled"`
+
+	// MinimumMiB is the memory the hypervisor may shrink the guest to
+	// under host memory pressure. It must not exceed the class memory.
+	// Defaults to the class memory, which disables automatic reclaim.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumMiB *int32 `json:"minimumMiB,omitempty"`
+
+	// Shares weighs this VM against others when the hypervisor reclaims
+	// memory automatically. Higher values keep more memory.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50000
+	Shares *int32 `json:"shares,omitempty"`
+}
+
 // PerformanceProfile defines performance-related settings
 type PerformanceProfile struct {
-	// LatencySensitivity configures latency sensitivity
+	// LatencySensitivity configures latency sensitivity. Defaults to
+	// normal once inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="normal"
 	// +kubebuilder:validation:Enum=low;normal;high
 	LatencySensitivity string `json:"latencySensitivity,omitempty"`
 
@@ -149,11 +201,17 @@ type PerformanceProfile struct {
 	// +kubebuilder:default=false
 	NestedVirtualization bool `json:"nestedVirtualization,omitempty"`
 
-	// HyperThreadingPolicy controls hyperthreading usage
+	// HyperThreadingPolicy controls hyperthreading usage. Defaults to auto
+	// once inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="auto"
 	// +kubebuilder:validation:Enum=auto;prefer;avoid;require
 	HyperThreadingPolicy string `json:"hyperThreadingPolicy,omitempty"`
+
+	// HugePages backs guest memory with huge pages of the given size. "any"
+	// lets the hypervisor pick. Memory must be a multiple of the page size.
+	// +optional
+	// +kubebuilder:validation:Enum="2Mi";"1Gi";"any"
+	HugePages string `json:"hugePages,omitempty"`
 }
 
 // SecurityProfile defines security-related settings
@@ -202,14 +260,14 @@ type EncryptionPolicy struct {
 
 // DiskDefaults provides default disk settings
 type DiskDefaults struct {
-	// Type specifies the default disk type
+	// Type specifies the default disk type. Defaults to thin once
+	// inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="thin"
 	Type DiskType `json:"type,omitempty"`
 
-	// Size specifies the default root disk size
+	// Size specifies the default root disk size. Defaults to 40Gi once
+	// inheritance is resolved.
 	// +optional
-	// +kubebuilder:default="40Gi"
 	Size resource.Quantity `json:"size,omitempty"`
 
 	// IOPS specifies the default IOPS limit
@@ -264,6 +322,31 @@ type VMClassStatus struct {
 	// ValidationResults contains validation results for different providers
 	// +optional
 	ValidationResults map[string]ValidationResult `json:"validationResults,omitempty"`
+
+	// Normalized reports the spec quantities converted to the integer units
+	// providers consume
+	// +optional
+	Normalized *VMClassNormalized `json:"normalized,omitempty"`
+
+	// ResolvedSpec is the spec VirtualMachines get from this class: the
+	// inheritFrom chain merged and defaults applied
+	// +optional
+	ResolvedSpec *VMClassSpec `json:"resolvedSpec,omitempty"`
+
+	// InheritanceChain lists the classes merged into ResolvedSpec, from
+	// the root ancestor to this class
+	// +optional
+	InheritanceChain []string `json:"inheritanceChain,omitempty"`
+}
+
+// VMClassNormalized holds the VMClass quantities in provider units
+type VMClassNormalized struct {
+	// MemoryMiB is spec.memory in MiB, rounded down
+	MemoryMiB int64 `json:"memoryMiB"`
+
+	// DiskSizeGiB is spec.diskDefaults.size in GiB, rounded up
+	// +optional
+	DiskSizeGiB int64 `json:"diskSizeGiB,omitempty"`
 }
 
 // ValidationResult represents a validation result for a provider
@@ -20,6 +20,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// VMCloneFinalizer is the finalizer for VMClone resources. Deleting a
+// VMClone never cascades to the VM it produced; only the finalizer is removed.
+const VMCloneFinalizer = "clone.infra.virtrigaud.io/finalizer"
+
 // VMCloneSpec defines the desired state of VMClone
 type VMCloneSpec struct {
 	// Source defines the source for cloning
@@ -39,6 +43,12 @@ type VMCloneSpec struct {
 	// Metadata contains clone operation metadata
 	// +optional
 	Metadata *CloneMetadata `json:"metadata,omitempty"`
+
+	// TTLAfterCompletion deletes the clone this long after it became Ready
+	// or Failed. The target VM is kept. Unset keeps the clone until it is
+	// deleted by hand; zero deletes it as soon as it finishes.
+	// +optional
+	TTLAfterCompletion *metav1.Duration `json:"ttlAfterCompletion,omitempty"`
 }
 
 // CloneSource defines the source for cloning
@@ -288,7 +298,9 @@ type VMCustomization struct {
 
 // NetworkCustomization defines network-specific customization
 type NetworkCustomization struct {
-	// Name identifies the network to customize
+	// Name identifies the network to customize: one of the target's
+	// networks (spec.target.networks, else the source VM's). When neither
+	// lists networks, entries apply to the clone's NICs in order.
 	// +kubebuilder:validation:MaxLength=255
 	Name string `json:"name"`
 
@@ -503,11 +515,16 @@ type VMCloneStatus struct {
 	// +optional
 	TaskRef string `json:"taskRef,omitempty"`
 
+	// LastTask records the clone task and, when the provider reports one,
+	// a link to it. It is kept after the task finishes.
+	// +optional
+	LastTask *TaskRecord `json:"lastTask,omitempty"`
+
 	// StartTime is when the clone operation started
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 
-	// CompletionTime is when the clone operation completed
+	// CompletionTime is when the clone operation completed or failed
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
@@ -538,6 +555,11 @@ type VMCloneStatus struct {
 	// CustomizationStatus contains customization operation status
 	// +optional
 	CustomizationStatus *CustomizationStatus `json:"customizationStatus,omitempty"`
+
+	// PendingCleanup lists provider-side artifacts the failed clone left
+	// behind that are still to be deleted
+	// +optional
+	PendingCleanup []CleanupRecord `json:"pendingCleanup,omitempty"`
 }
 
 // ClonePhase represents the phase of a clone operation
@@ -647,6 +669,10 @@ const (
 	VMCloneConditionCustomizing = "Customizing"
 	// VMCloneConditionFailed indicates whether the clone has failed
 	VMCloneConditionFailed = "Failed"
+	// VMCloneConditionCustomizationFailed indicates the clone was made but
+	// spec.customization could not be applied to it. The target VM is left
+	// powered off.
+	VMCloneConditionCustomizationFailed = "CloneCustomizationFailed"
 )
 
 // VMClone condition reasons
@@ -667,6 +693,9 @@ const (
 	VMCloneReasonInsufficientResources = "InsufficientResources"
 	// VMCloneReasonCustomizationFailed indicates customization failed
 	VMCloneReasonCustomizationFailed = "CustomizationFailed"
+	// VMCloneReasonReferenceNotAllowed indicates a cross-namespace
+	// reference the target namespace does not admit
+	VMCloneReasonReferenceNotAllowed = "ReferenceNotAllowed"
 )
 
 //+kubebuilder:object:root=true
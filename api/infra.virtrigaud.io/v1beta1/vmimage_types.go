@@ -112,7 +112,8 @@ type ContentLibraryRef struct {
 	// +kubebuilder:validation:MaxLength=255
 	Item string `json:"item"`
 
-	// Version specifies the item version (optional)
+	// Version pins the item's content version; an item at another version
+	// is reported as not found (optional)
 	// +optional
 	Version string `json:"version,omitempty"`
 }
@@ -146,6 +147,12 @@ type LibvirtImageSource struct {
 	// +optional
 	// +kubebuilder:validation:MaxLength=255
 	StoragePool string `json:"storagePool,omitempty"`
+	// LinkedClone creates each VM's disk as a qcow2 overlay backed by the
+	// image instead of a full copy per VM. A URL image is downloaded into the
+	// storage pool once either way. It needs a qcow2 image; otherwise, or if
+	// the overlay cannot be made, the disk is a full copy.
+	// +optional
+	LinkedClone bool `json:"linkedClone,omitempty"`
 }
 
 // HTTPImageSource defines HTTP/HTTPS download configuration
@@ -0,0 +1,237 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VMImagePublishFinalizer is the finalizer for VMImagePublish resources.
+// Deleting a VMImagePublish restores its source VM if a publish is still
+// in flight, but never deletes the image it published.
+const VMImagePublishFinalizer = "imagepublish.infra.virtrigaud.io/finalizer"
+
+// VMImagePublishSpec defines the desired state of VMImagePublish
+type VMImagePublishSpec struct {
+	// VMRef references the VirtualMachine to publish, in the same namespace
+	VMRef LocalObjectReference `json:"vmRef"`
+
+	// ImageName names both the published template or image on the provider
+	// and the VMImage created in this namespace to point at it
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	ImageName string `json:"imageName"`
+
+	// Quiesce selects how the source VM is made consistent for the copy:
+	// Shutdown powers it off and restores its power state afterwards,
+	// Snapshot publishes from a snapshot taken for the purpose and deleted
+	// afterwards, leaving the VM running
+	// +optional
+	// +kubebuilder:default="Shutdown"
+	Quiesce PublishQuiesceMode `json:"quiesce,omitempty"`
+
+	// Overwrite replaces an existing VMImage and provider image of the same
+	// name. Without it, publishing over an existing name fails.
+	// +optional
+	Overwrite bool `json:"overwrite,omitempty"`
+
+	// Cleanup lists virt-sysprep operations run against the published copy,
+	// such as machine-id or ssh-hostkeys (libvirt only)
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	Cleanup []string `json:"cleanup,omitempty"`
+
+	// ContentLibrary exports the image to this vSphere content library
+	// instead of converting a copy into a template (vSphere only)
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	ContentLibrary string `json:"contentLibrary,omitempty"`
+
+	// Storage names where the published image is kept: a vSphere datastore,
+	// libvirt pool or Proxmox storage. Defaults to the source VM's.
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	Storage string `json:"storage,omitempty"`
+}
+
+// PublishQuiesceMode selects how a VMImagePublish quiesces its source VM
+// +kubebuilder:validation:Enum=Shutdown;Snapshot
+type PublishQuiesceMode string
+
+const (
+	// PublishQuiesceShutdown powers the source VM off while it is published
+	PublishQuiesceShutdown PublishQuiesceMode = "Shutdown"
+	// PublishQuiesceSnapshot publishes from a snapshot of the running VM
+	PublishQuiesceSnapshot PublishQuiesceMode = "Snapshot"
+)
+
+// VMImagePublishStatus defines the observed state of VMImagePublish
+type VMImagePublishStatus struct {
+	// Phase represents the current phase of the publish
+	// +optional
+	Phase PublishPhase `json:"phase,omitempty"`
+
+	// Message provides additional details about the current state
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ImageRef references the VMImage pointing at the published image
+	// +optional
+	ImageRef *LocalObjectReference `json:"imageRef,omitempty"`
+
+	// ImageID is the provider's identifier of the published image, such as
+	// a Proxmox template VMID or a content library item ID
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// ImagePath is the path of the published image file, for providers
+	// that address images by path
+	// +optional
+	ImagePath string `json:"imagePath,omitempty"`
+
+	// Checksum is the checksum of the published image, when the provider
+	// computes one
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// ChecksumType is the algorithm of Checksum
+	// +optional
+	ChecksumType ChecksumType `json:"checksumType,omitempty"`
+
+	// TaskRef tracks the in-flight snapshot or publish task
+	// +optional
+	TaskRef string `json:"taskRef,omitempty"`
+
+	// SnapshotID is the snapshot taken to publish from, until it is deleted
+	// +optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	// RestorePowerState is the power state the source VM is returned to
+	// once published, set while the publish holds it powered off
+	// +optional
+	RestorePowerState PowerState `json:"restorePowerState,omitempty"`
+
+	// StartTime is when the publish started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the publish completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration reflects the generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// PublishPhase represents the phase of a VMImagePublish
+// +kubebuilder:validation:Enum=Pending;Quiescing;Publishing;Ready;Failed
+type PublishPhase string
+
+const (
+	// PublishPhasePending indicates the publish is waiting to start
+	PublishPhasePending PublishPhase = "Pending"
+	// PublishPhaseQuiescing indicates the source VM is being powered off
+	// or snapshotted
+	PublishPhaseQuiescing PublishPhase = "Quiescing"
+	// PublishPhasePublishing indicates the provider is publishing the image
+	PublishPhasePublishing PublishPhase = "Publishing"
+	// PublishPhaseReady indicates the image is published and its VMImage
+	// is in place
+	PublishPhaseReady PublishPhase = "Ready"
+	// PublishPhaseFailed indicates the publish failed
+	PublishPhaseFailed PublishPhase = "Failed"
+)
+
+// VMImagePublish condition types
+const (
+	// VMImagePublishConditionReady indicates whether the image is published
+	VMImagePublishConditionReady = "Ready"
+	// VMImagePublishConditionFailed indicates whether the publish failed
+	VMImagePublishConditionFailed = "Failed"
+)
+
+// VMImagePublish condition reasons
+const (
+	// VMImagePublishReasonSourceNotFound indicates the source VM was not found
+	VMImagePublishReasonSourceNotFound = "SourceNotFound"
+	// VMImagePublishReasonWaitingForSource indicates the source VM is not
+	// provisioned yet
+	VMImagePublishReasonWaitingForSource = "WaitingForSource"
+	// VMImagePublishReasonImageExists indicates a VMImage of the same name
+	// exists and overwrite is not set
+	VMImagePublishReasonImageExists = "ImageExists"
+	// VMImagePublishReasonUnsupported indicates the provider cannot publish
+	// images
+	VMImagePublishReasonUnsupported = "Unsupported"
+	// VMImagePublishReasonQuiescing indicates the source VM is being quiesced
+	VMImagePublishReasonQuiescing = "Quiescing"
+	// VMImagePublishReasonPublishing indicates the publish is in progress
+	VMImagePublishReasonPublishing = "Publishing"
+	// VMImagePublishReasonCompleted indicates the publish completed
+	VMImagePublishReasonCompleted = "Completed"
+	// VMImagePublishReasonProviderError indicates a provider error
+	VMImagePublishReasonProviderError = "ProviderError"
+)
+
+// Label and annotation keys set on the VMImage a VMImagePublish creates.
+const (
+	// PublishedByLabel names the VMImagePublish that last published the
+	// VMImage
+	PublishedByLabel = "virtrigaud.io/published-by"
+	// PublishedFromAnnotation names the VirtualMachine the VMImage was
+	// published from
+	PublishedFromAnnotation = "virtrigaud.io/published-from"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="VM",type=string,JSONPath=`.spec.vmRef.name`
+//+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.imageName`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:resource:shortName=vmpub
+
+// VMImagePublish publishes a copy of a VirtualMachine as a provider template
+// or image and creates the VMImage that new VMs are created from. Like a
+// VMClone it is a one-shot job: Ready and Failed are terminal, and
+// publishing again takes a new VMImagePublish.
+// +kubebuilder:storageversion
+type VMImagePublish struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMImagePublishSpec   `json:"spec,omitempty"`
+	Status VMImagePublishStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VMImagePublishList contains a list of VMImagePublish
+type VMImagePublishList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMImagePublish `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMImagePublish{}, &VMImagePublishList{})
+}
@@ -21,6 +21,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// VMMigrationFinalizer is the finalizer for VMMigration resources
+const VMMigrationFinalizer = "vmmigration.infra.virtrigaud.io/finalizer"
+
 // VMMigrationSpec defines the desired state of VMMigration
 type VMMigrationSpec struct {
 	// Source defines the source VM to migrate from
@@ -40,6 +43,13 @@ type VMMigrationSpec struct {
 	// Metadata contains migration metadata
 	// +optional
 	Metadata *MigrationMetadata `json:"metadata,omitempty"`
+
+	// TTLAfterCompletion deletes the migration, and its intermediate storage
+	// PVC if still present, this long after it finished: it became Ready, or
+	// Failed with no retries left. Unset keeps the migration until it is
+	// deleted by hand; zero deletes it as soon as it finishes.
+	// +optional
+	TTLAfterCompletion *metav1.Duration `json:"ttlAfterCompletion,omitempty"`
 }
 
 // MigrationSource defines the source VM for migration
@@ -448,6 +458,11 @@ type VMMigrationStatus struct {
 	// ValidationResults contains results of validation checks
 	// +optional
 	ValidationResults *ValidationResults `json:"validationResults,omitempty"`
+
+	// PendingCleanup lists provider-side artifacts the failed migration
+	// left behind that are still to be deleted
+	// +optional
+	PendingCleanup []CleanupRecord `json:"pendingCleanup,omitempty"`
 }
 
 // MigrationPhase represents the phase of a migration operation
@@ -732,6 +732,13 @@ type ProxmoxNetworkConfig struct {
 	// Examples: "vmbr0", "vmbr1", "vmbr2"
 	Bridge string `json:"bridge,omitempty"`
 
+	// VNet names a Proxmox VE SDN vnet to attach to instead of a bridge. It
+	// must exist in /cluster/sdn/vnets and takes precedence over Bridge.
+	// +optional
+	// +kubebuilder:validation:MaxLength=8
+	// +kubebuilder:validation:Pattern="^[a-zA-Z][a-zA-Z0-9]*$"
+	VNet string `json:"vnet,omitempty"`
+
 	// Model specifies the network card model
 	// +optional
 	// +kubebuilder:default="virtio"
@@ -749,6 +756,14 @@ type ProxmoxNetworkConfig struct {
 	// +kubebuilder:default=false
 	Firewall *bool `json:"firewall,omitempty"`
 
+	// SecurityGroups are cluster firewall security groups applied to this
+	// interface. Each becomes a group rule on the VM's firewall, which is
+	// enabled along with the interface firewall. Groups removed from the list
+	// are removed from the VM.
+	// +optional
+	// +listType=set
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
 	// RateLimit specifies the bandwidth limit in MB/s
 	// +optional
 	// +kubebuilder:validation:Minimum=1
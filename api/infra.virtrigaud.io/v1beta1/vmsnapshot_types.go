@@ -21,6 +21,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// VMSnapshotFinalizer is the finalizer for VMSnapshot resources
+const VMSnapshotFinalizer = "snapshot.infra.virtrigaud.io/finalizer"
+
 // VMSnapshotSpec defines the desired state of VMSnapshot
 type VMSnapshotSpec struct {
 	// VMRef references the virtual machine to snapshot
@@ -41,8 +44,28 @@ type VMSnapshotSpec struct {
 	// Metadata contains snapshot metadata
 	// +optional
 	Metadata *SnapshotMetadata `json:"metadata,omitempty"`
+
+	// DeletionPolicy controls the provider-side snapshot when this VMSnapshot
+	// is deleted. Delete removes it; Retain leaves it on the hypervisor, and
+	// also keeps the VirtualMachine from being deleted while this VMSnapshot
+	// exists, unless the VM carries the virtrigaud.io/force-delete annotation.
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy SnapshotDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// SnapshotDeletionPolicy is the fate of a provider-side snapshot when its
+// VMSnapshot is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type SnapshotDeletionPolicy string
+
+const (
+	// SnapshotDeletionPolicyDelete removes the provider-side snapshot
+	SnapshotDeletionPolicyDelete SnapshotDeletionPolicy = "Delete"
+	// SnapshotDeletionPolicyRetain leaves the provider-side snapshot in place
+	SnapshotDeletionPolicyRetain SnapshotDeletionPolicy = "Retain"
+)
+
 // SnapshotConfig defines snapshot configuration options
 type SnapshotConfig struct {
 	// Name provides a name hint for the snapshot (provider may modify)
@@ -66,6 +89,13 @@ type SnapshotConfig struct {
 	// +kubebuilder:default=true
 	Quiesce bool `json:"quiesce,omitempty"`
 
+	// QuiesceFailurePolicy decides what happens when Quiesce is set but the
+	// VM's guest agent does not answer: Fail fails the snapshot, Degrade
+	// takes a crash-consistent snapshot and records status.quiesce Degraded
+	// +optional
+	// +kubebuilder:default=Fail
+	QuiesceFailurePolicy QuiesceFailurePolicy `json:"quiesceFailurePolicy,omitempty"`
+
 	// Type specifies the snapshot type
 	// +optional
 	// +kubebuilder:default="Standard"
@@ -88,6 +118,35 @@ type SnapshotConfig struct {
 	ConsistencyLevel string `json:"consistencyLevel,omitempty"`
 }
 
+// QuiesceFailurePolicy is what a VMSnapshot does when its VM cannot be
+// quiesced
+// +kubebuilder:validation:Enum=Fail;Degrade
+type QuiesceFailurePolicy string
+
+const (
+	// QuiesceFailurePolicyFail fails the snapshot
+	QuiesceFailurePolicyFail QuiesceFailurePolicy = "Fail"
+	// QuiesceFailurePolicyDegrade takes a crash-consistent snapshot instead
+	QuiesceFailurePolicyDegrade QuiesceFailurePolicy = "Degrade"
+)
+
+// SnapshotQuiesceOutcome is whether a snapshot was taken with the guest's
+// filesystems quiesced
+// +kubebuilder:validation:Enum=Achieved;Degraded;Skipped
+type SnapshotQuiesceOutcome string
+
+const (
+	// SnapshotQuiesceAchieved means the guest's filesystems were frozen for
+	// the snapshot
+	SnapshotQuiesceAchieved SnapshotQuiesceOutcome = "Achieved"
+	// SnapshotQuiesceDegraded means quiesce was requested but not possible,
+	// so the snapshot is crash-consistent
+	SnapshotQuiesceDegraded SnapshotQuiesceOutcome = "Degraded"
+	// SnapshotQuiesceSkipped means quiesce was not requested, or not needed
+	// because the VM was off or its memory was captured
+	SnapshotQuiesceSkipped SnapshotQuiesceOutcome = "Skipped"
+)
+
 // SnapshotType represents the type of snapshot
 // +kubebuilder:validation:Enum=Standard;Crash;Application
 type SnapshotType string
@@ -229,7 +288,8 @@ type VMSnapshotStatus struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 
-	// CreationTime is when the snapshot was created
+	// CreationTime is when the snapshot was created, as recorded by the
+	// hypervisor when it reports it
 	// +optional
 	CreationTime *metav1.Time `json:"creationTime,omitempty"`
 
@@ -237,14 +297,35 @@ type VMSnapshotStatus struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
-	// Size is the size of the snapshot
+	// Size is the size of the snapshot, SizeBytes rounded up to MiB
 	// +optional
 	Size *resource.Quantity `json:"size,omitempty"`
 
+	// SizeBytes is the size of the data the snapshot holds, as reported by
+	// the provider; unset when the provider cannot tell
+	// +optional
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+
+	// ConsumedBytes is the datastore space the snapshot occupies, which is
+	// less than SizeBytes on thin or deduplicated storage; unset when the
+	// provider cannot tell
+	// +optional
+	ConsumedBytes *int64 `json:"consumedBytes,omitempty"`
+
 	// VirtualSize is the virtual size of the snapshot
 	// +optional
 	VirtualSize *resource.Quantity `json:"virtualSize,omitempty"`
 
+	// Quiesce is whether the snapshot was taken with the guest's filesystems
+	// quiesced, as reported by the provider; unset when the provider does
+	// not report it
+	// +optional
+	Quiesce SnapshotQuiesceOutcome `json:"quiesce,omitempty"`
+
+	// QuiesceMessage says why quiesce was degraded or skipped
+	// +optional
+	QuiesceMessage string `json:"quiesceMessage,omitempty"`
+
 	// TaskRef tracks any ongoing async operations
 	// +optional
 	TaskRef string `json:"taskRef,omitempty"`
@@ -399,6 +480,8 @@ const (
 //+kubebuilder:printcolumn:name="VM",type=string,JSONPath=`.spec.vmRef.name`
 //+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 //+kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`
+//+kubebuilder:printcolumn:name="Consumed",type=integer,JSONPath=`.status.consumedBytes`,priority=1
+//+kubebuilder:printcolumn:name="Quiesce",type=string,JSONPath=`.status.quiesce`,priority=1
 //+kubebuilder:printcolumn:name="Created",type=date,JSONPath=`.status.creationTime`
 //+kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiryTime`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
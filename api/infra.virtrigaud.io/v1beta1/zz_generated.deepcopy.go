@@ -27,6 +27,21 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptExistingSpec) DeepCopyInto(out *AdoptExistingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptExistingSpec.
+func (in *AdoptExistingSpec) DeepCopy() *AdoptExistingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptExistingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AffinityRules) DeepCopyInto(out *AffinityRules) {
 	*out = *in
@@ -147,6 +162,41 @@ func (in *ApplicationAntiAffinityRule) DeepCopy() *ApplicationAntiAffinityRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedDisk) DeepCopyInto(out *AppliedDisk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedDisk.
+func (in *AppliedDisk) DeepCopy() *AppliedDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedSecurityGroups) DeepCopyInto(out *AppliedSecurityGroups) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedSecurityGroups.
+func (in *AppliedSecurityGroups) DeepCopy() *AppliedSecurityGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedSecurityGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuthConfig) DeepCopyInto(out *BasicAuthConfig) {
 	*out = *in
@@ -219,6 +269,42 @@ func (in *CertificateSpec) DeepCopy() *CertificateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupRecord) DeepCopyInto(out *CleanupRecord) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	in.RecordedAt.DeepCopyInto(&out.RecordedAt)
+	if in.NextAttemptTime != nil {
+		in, out := &in.NextAttemptTime, &out.NextAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupRecord.
+func (in *CleanupRecord) DeepCopy() *CleanupRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientCertConfig) DeepCopyInto(out *ClientCertConfig) {
 	*out = *in
@@ -447,6 +533,26 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudInitDocument) DeepCopyInto(out *CloudInitDocument) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInitDocument.
+func (in *CloudInitDocument) DeepCopy() *CloudInitDocument {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudInitDocument)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudInitMetaData) DeepCopyInto(out *CloudInitMetaData) {
 	*out = *in
@@ -915,6 +1021,41 @@ func (in *GuestCommand) DeepCopy() *GuestCommand {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestCustomization) DeepCopyInto(out *GuestCustomization) {
+	*out = *in
+	if in.Sysprep != nil {
+		in, out := &in.Sysprep, &out.Sysprep
+		*out = new(SysprepSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestCustomization.
+func (in *GuestCustomization) DeepCopy() *GuestCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HAPlacement) DeepCopyInto(out *HAPlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAPlacement.
+func (in *HAPlacement) DeepCopy() *HAPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(HAPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPAuthentication) DeepCopyInto(out *HTTPAuthentication) {
 	*out = *in
@@ -1032,6 +1173,21 @@ func (in *HostAntiAffinityRule) DeepCopy() *HostAntiAffinityRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorInfo) DeepCopyInto(out *HypervisorInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorInfo.
+func (in *HypervisorInfo) DeepCopy() *HypervisorInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAllocation) DeepCopyInto(out *IPAllocation) {
 	*out = *in
@@ -1106,6 +1262,21 @@ func (in *IPPoolConfig) DeepCopy() *IPPoolConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPv6StaticConfig) DeepCopyInto(out *IPv6StaticConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPv6StaticConfig.
+func (in *IPv6StaticConfig) DeepCopy() *IPv6StaticConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPv6StaticConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ignition) DeepCopyInto(out *Ignition) {
 	*out = *in
@@ -1434,6 +1605,31 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryBalloon) DeepCopyInto(out *MemoryBalloon) {
+	*out = *in
+	if in.MinimumMiB != nil {
+		in, out := &in.MinimumMiB, &out.MinimumMiB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Shares != nil {
+		in, out := &in.Shares, &out.Shares
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryBalloon.
+func (in *MemoryBalloon) DeepCopy() *MemoryBalloon {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryBalloon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaData) DeepCopyInto(out *MetaData) {
 	*out = *in
@@ -2148,6 +2344,16 @@ func (in *PersistentVolumeClaimTemplate) DeepCopy() *PersistentVolumeClaimTempla
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Placement) DeepCopyInto(out *Placement) {
 	*out = *in
+	if in.HA != nil {
+		in, out := &in.HA, &out.HA
+		*out = new(HAPlacement)
+		**out = **in
+	}
+	if in.AntiAffinity != nil {
+		in, out := &in.AntiAffinity, &out.AntiAffinity
+		*out = new(PlacementAntiAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
@@ -2160,6 +2366,26 @@ func (in *Placement) DeepCopy() *Placement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAntiAffinity) DeepCopyInto(out *PlacementAntiAffinity) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAntiAffinity.
+func (in *PlacementAntiAffinity) DeepCopy() *PlacementAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementConstraints) DeepCopyInto(out *PlacementConstraints) {
 	*out = *in
@@ -2275,6 +2501,21 @@ func (in *PlacementStatistics) DeepCopy() *PlacementStatistics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedAction) DeepCopyInto(out *PlannedAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedAction.
+func (in *PlannedAction) DeepCopy() *PlannedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyConflict) DeepCopyInto(out *PolicyConflict) {
 	*out = *in
@@ -2379,6 +2620,40 @@ func (in *PortgroupSecurityConfig) DeepCopy() *PortgroupSecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerOpRequest) DeepCopyInto(out *PowerOpRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerOpRequest.
+func (in *PowerOpRequest) DeepCopy() *PowerOpRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerOpRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerOpStatus) DeepCopyInto(out *PowerOpStatus) {
+	*out = *in
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerOpStatus.
+func (in *PowerOpStatus) DeepCopy() *PowerOpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerOpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
@@ -2425,6 +2700,32 @@ func (in *ProviderAdoptionStatus) DeepCopy() *ProviderAdoptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCertManagerSpec) DeepCopyInto(out *ProviderCertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HotReload != nil {
+		in, out := &in.HotReload, &out.HotReload
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCertManagerSpec.
+func (in *ProviderCertManagerSpec) DeepCopy() *ProviderCertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderDefaults) DeepCopyInto(out *ProviderDefaults) {
 	*out = *in
@@ -2521,6 +2822,25 @@ func (in *ProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderMaintenanceStatus) DeepCopyInto(out *ProviderMaintenanceStatus) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderMaintenanceStatus.
+func (in *ProviderMaintenanceStatus) DeepCopy() *ProviderMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderNetworkStatus) DeepCopyInto(out *ProviderNetworkStatus) {
 	*out = *in
@@ -2575,6 +2895,26 @@ func (in *ProviderResourceUsage) DeepCopy() *ProviderResourceUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderProtocolStatus) DeepCopyInto(out *ProviderProtocolStatus) {
+	*out = *in
+	if in.MissingFeatures != nil {
+		in, out := &in.MissingFeatures, &out.MissingFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderProtocolStatus.
+func (in *ProviderProtocolStatus) DeepCopy() *ProviderProtocolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderProtocolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderRuntimeSpec) DeepCopyInto(out *ProviderRuntimeSpec) {
 	*out = *in
@@ -2622,6 +2962,11 @@ func (in *ProviderRuntimeSpec) DeepCopyInto(out *ProviderRuntimeSpec) {
 		*out = new(v1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxConcurrentClones != nil {
+		in, out := &in.MaxConcurrentClones, &out.MaxConcurrentClones
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -2659,6 +3004,16 @@ func (in *ProviderRuntimeStatus) DeepCopyInto(out *ProviderRuntimeStatus) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.EndpointRef != nil {
+		in, out := &in.EndpointRef, &out.EndpointRef
+		*out = new(ServiceEndpointRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadlessServiceRef != nil {
+		in, out := &in.HeadlessServiceRef, &out.HeadlessServiceRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRuntimeStatus.
@@ -2672,12 +3027,32 @@ func (in *ProviderRuntimeStatus) DeepCopy() *ProviderRuntimeStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderServiceSpec) DeepCopyInto(out *ProviderServiceSpec) {
+func (in *ProviderSPIFFESpec) DeepCopyInto(out *ProviderSPIFFESpec) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(ProviderTLSSpec)
-		(*in).DeepCopyInto(*out)
+	if in.ManagerIDs != nil {
+		in, out := &in.ManagerIDs, &out.ManagerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSPIFFESpec.
+func (in *ProviderSPIFFESpec) DeepCopy() *ProviderSPIFFESpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSPIFFESpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderServiceSpec) DeepCopyInto(out *ProviderServiceSpec) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ProviderTLSSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -2713,12 +3088,29 @@ func (in *ProviderSnapshotStatus) DeepCopy() *ProviderSnapshotStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
+	if in.EndpointRef != nil {
+		in, out := &in.EndpointRef, &out.EndpointRef
+		*out = new(ServiceEndpointRef)
+		(*in).DeepCopyInto(*out)
+	}
 	out.CredentialSecretRef = in.CredentialSecretRef
 	if in.Defaults != nil {
 		in, out := &in.Defaults, &out.Defaults
 		*out = new(ProviderDefaults)
 		**out = **in
 	}
+	if in.DefaultTags != nil {
+		in, out := &in.DefaultTags, &out.DefaultTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultLabels != nil {
+		in, out := &in.DefaultLabels, &out.DefaultLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.RateLimit != nil {
 		in, out := &in.RateLimit, &out.RateLimit
 		*out = new(RateLimit)
@@ -2739,6 +3131,21 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 		*out = new(ConnectionPooling)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StatusRefreshInterval != nil {
+		in, out := &in.StatusRefreshInterval, &out.StatusRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RequiredCapabilities != nil {
+		in, out := &in.RequiredCapabilities, &out.RequiredCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
@@ -2780,6 +3187,16 @@ func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
 		*out = new(ReportedCapabilities)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(ProviderProtocolStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hypervisor != nil {
+		in, out := &in.Hypervisor, &out.Hypervisor
+		*out = new(HypervisorInfo)
+		**out = **in
+	}
 	if in.ResourceUsage != nil {
 		in, out := &in.ResourceUsage, &out.ResourceUsage
 		*out = new(ProviderResourceUsage)
@@ -2790,6 +3207,16 @@ func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
 		*out = new(ProviderAdoptionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ProviderTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(ProviderMaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
@@ -2810,6 +3237,16 @@ func (in *ProviderTLSSpec) DeepCopyInto(out *ProviderTLSSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(ProviderSPIFFESpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ProviderCertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTLSSpec.
@@ -2822,6 +3259,29 @@ func (in *ProviderTLSSpec) DeepCopy() *ProviderTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderTLSStatus) DeepCopyInto(out *ProviderTLSStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTLSStatus.
+func (in *ProviderTLSStatus) DeepCopy() *ProviderTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxmoxImageSource) DeepCopyInto(out *ProxmoxImageSource) {
 	*out = *in
@@ -2860,6 +3320,11 @@ func (in *ProxmoxNetworkConfig) DeepCopyInto(out *ProxmoxNetworkConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.RateLimit != nil {
 		in, out := &in.RateLimit, &out.RateLimit
 		*out = new(int32)
@@ -2955,6 +3420,11 @@ func (in *ReportedCapabilities) DeepCopyInto(out *ReportedCapabilities) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CapabilityManifest != nil {
+		in, out := &in.CapabilityManifest, &out.CapabilityManifest
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportedCapabilities.
@@ -3168,6 +3638,26 @@ func (in *SecurityProfile) DeepCopy() *SecurityProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceEndpointRef) DeepCopyInto(out *ServiceEndpointRef) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpointRef.
+func (in *ServiceEndpointRef) DeepCopy() *ServiceEndpointRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpointRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotAction) DeepCopyInto(out *SnapshotAction) {
 	*out = *in
@@ -3451,6 +3941,50 @@ func (in *SysprepCustomization) DeepCopy() *SysprepCustomization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysprepSpec) DeepCopyInto(out *SysprepSpec) {
+	*out = *in
+	if in.Unattend != nil {
+		in, out := &in.Unattend, &out.Unattend
+		*out = new(UnattendDocument)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysprepSpec.
+func (in *SysprepSpec) DeepCopy() *SysprepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SysprepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRecord) DeepCopyInto(out *TaskRecord) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRecord.
+func (in *TaskRecord) DeepCopy() *TaskRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficShapingConfig) DeepCopyInto(out *TrafficShapingConfig) {
 	*out = *in
@@ -3481,6 +4015,48 @@ func (in *TrafficShapingConfig) DeepCopy() *TrafficShapingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnattendDocument) DeepCopyInto(out *UnattendDocument) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnattendDocument.
+func (in *UnattendDocument) DeepCopy() *UnattendDocument {
+	if in == nil {
+		return nil
+	}
+	out := new(UnattendDocument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateCycleStatus) DeepCopyInto(out *UpdateCycleStatus) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateCycleStatus.
+func (in *UpdateCycleStatus) DeepCopy() *UpdateCycleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateCycleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserData) DeepCopyInto(out *UserData) {
 	*out = *in
@@ -3494,6 +4070,16 @@ func (in *UserData) DeepCopyInto(out *UserData) {
 		*out = new(Ignition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkConfig != nil {
+		in, out := &in.NetworkConfig, &out.NetworkConfig
+		*out = new(CloudInitDocument)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VendorData != nil {
+		in, out := &in.VendorData, &out.VendorData
+		*out = new(CloudInitDocument)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserData.
@@ -3541,6 +4127,21 @@ func (in *VLANConfig) DeepCopy() *VLANConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAddress) DeepCopyInto(out *VMAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAddress.
+func (in *VMAddress) DeepCopy() *VMAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAffinity) DeepCopyInto(out *VMAffinity) {
 	*out = *in
@@ -3695,10 +4296,35 @@ func (in *VMClassList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClassNormalized) DeepCopyInto(out *VMClassNormalized) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClassNormalized.
+func (in *VMClassNormalized) DeepCopy() *VMClassNormalized {
+	if in == nil {
+		return nil
+	}
+	out := new(VMClassNormalized)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMClassSpec) DeepCopyInto(out *VMClassSpec) {
 	*out = *in
+	if in.InheritFrom != nil {
+		in, out := &in.InheritFrom, &out.InheritFrom
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	out.Memory = in.Memory.DeepCopy()
+	if in.MemoryBalloon != nil {
+		in, out := &in.MemoryBalloon, &out.MemoryBalloon
+		*out = new(MemoryBalloon)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DiskDefaults != nil {
 		in, out := &in.DiskDefaults, &out.DiskDefaults
 		*out = new(DiskDefaults)
@@ -3760,6 +4386,21 @@ func (in *VMClassStatus) DeepCopyInto(out *VMClassStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Normalized != nil {
+		in, out := &in.Normalized, &out.Normalized
+		*out = new(VMClassNormalized)
+		**out = **in
+	}
+	if in.ResolvedSpec != nil {
+		in, out := &in.ResolvedSpec, &out.ResolvedSpec
+		*out = new(VMClassSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InheritanceChain != nil {
+		in, out := &in.InheritanceChain, &out.InheritanceChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClassStatus.
@@ -3851,6 +4492,11 @@ func (in *VMCloneSpec) DeepCopyInto(out *VMCloneSpec) {
 		*out = new(CloneMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TTLAfterCompletion != nil {
+		in, out := &in.TTLAfterCompletion, &out.TTLAfterCompletion
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMCloneSpec.
@@ -3871,6 +4517,11 @@ func (in *VMCloneStatus) DeepCopyInto(out *VMCloneStatus) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.LastTask != nil {
+		in, out := &in.LastTask, &out.LastTask
+		*out = new(TaskRecord)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StartTime != nil {
 		in, out := &in.StartTime, &out.StartTime
 		*out = (*in).DeepCopy()
@@ -3900,6 +4551,13 @@ func (in *VMCloneStatus) DeepCopyInto(out *VMCloneStatus) {
 		*out = new(CustomizationStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PendingCleanup != nil {
+		in, out := &in.PendingCleanup, &out.PendingCleanup
+		*out = make([]CleanupRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMCloneStatus.
@@ -4021,6 +4679,37 @@ func (in *VMCustomization) DeepCopy() *VMCustomization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMGuestStats) DeepCopyInto(out *VMGuestStats) {
+	*out = *in
+	if in.CPUUsagePercent != nil {
+		in, out := &in.CPUUsagePercent, &out.CPUUsagePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUsageBytes != nil {
+		in, out := &in.MemoryUsageBytes, &out.MemoryUsageBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UptimeSeconds != nil {
+		in, out := &in.UptimeSeconds, &out.UptimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGuestStats.
+func (in *VMGuestStats) DeepCopy() *VMGuestStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VMGuestStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMImage) DeepCopyInto(out *VMImage) {
 	*out = *in
@@ -4045,41 +4734,156 @@ func (in *VMImage) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMImageList) DeepCopyInto(out *VMImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImageList.
+func (in *VMImageList) DeepCopy() *VMImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMImagePublish) DeepCopyInto(out *VMImagePublish) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImagePublish.
+func (in *VMImagePublish) DeepCopy() *VMImagePublish {
+	if in == nil {
+		return nil
+	}
+	out := new(VMImagePublish)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMImagePublish) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMImagePublishList) DeepCopyInto(out *VMImagePublishList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMImagePublish, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImagePublishList.
+func (in *VMImagePublishList) DeepCopy() *VMImagePublishList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMImagePublishList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMImagePublishList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMImagePublishSpec) DeepCopyInto(out *VMImagePublishSpec) {
+	*out = *in
+	out.VMRef = in.VMRef
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImagePublishSpec.
+func (in *VMImagePublishSpec) DeepCopy() *VMImagePublishSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMImagePublishSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMImageList) DeepCopyInto(out *VMImageList) {
+func (in *VMImagePublishStatus) DeepCopyInto(out *VMImagePublishStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VMImage, len(*in))
+	if in.ImageRef != nil {
+		in, out := &in.ImageRef, &out.ImageRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImageList.
-func (in *VMImageList) DeepCopy() *VMImageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMImagePublishStatus.
+func (in *VMImagePublishStatus) DeepCopy() *VMImagePublishStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMImageList)
+	out := new(VMImagePublishStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMImageList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMImageSpec) DeepCopyInto(out *VMImageSpec) {
 	*out = *in
@@ -4238,6 +5042,11 @@ func (in *VMMigrationSpec) DeepCopyInto(out *VMMigrationSpec) {
 		*out = new(MigrationMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TTLAfterCompletion != nil {
+		in, out := &in.TTLAfterCompletion, &out.TTLAfterCompletion
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMMigrationSpec.
@@ -4297,6 +5106,13 @@ func (in *VMMigrationStatus) DeepCopyInto(out *VMMigrationStatus) {
 		*out = new(ValidationResults)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PendingCleanup != nil {
+		in, out := &in.PendingCleanup, &out.PendingCleanup
+		*out = make([]CleanupRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMMigrationStatus.
@@ -4453,6 +5269,11 @@ func (in *VMNetworkRef) DeepCopyInto(out *VMNetworkRef) {
 		*out = new(ObjectRef)
 		**out = **in
 	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(IPv6StaticConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNetworkRef.
@@ -4465,6 +5286,21 @@ func (in *VMNetworkRef) DeepCopy() *VMNetworkRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMNetworkingSpec) DeepCopyInto(out *VMNetworkingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNetworkingSpec.
+func (in *VMNetworkingSpec) DeepCopy() *VMNetworkingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMNetworkingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMPlacementPolicy) DeepCopyInto(out *VMPlacementPolicy) {
 	*out = *in
@@ -4623,6 +5459,26 @@ func (in *VMPlacementPolicyStatus) DeepCopy() *VMPlacementPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMReadinessSpec) DeepCopyInto(out *VMReadinessSpec) {
+	*out = *in
+	if in.CloudInitTimeout != nil {
+		in, out := &in.CloudInitTimeout, &out.CloudInitTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMReadinessSpec.
+func (in *VMReadinessSpec) DeepCopy() *VMReadinessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMReadinessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMResourceLimits) DeepCopyInto(out *VMResourceLimits) {
 	*out = *in
@@ -5006,6 +5862,21 @@ func (in *VMSnapshot) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshotChainStatus) DeepCopyInto(out *VMSnapshotChainStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotChainStatus.
+func (in *VMSnapshotChainStatus) DeepCopy() *VMSnapshotChainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSnapshotChainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMSnapshotInfo) DeepCopyInto(out *VMSnapshotInfo) {
 	*out = *in
@@ -5015,6 +5886,11 @@ func (in *VMSnapshotInfo) DeepCopyInto(out *VMSnapshotInfo) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ConsumedBytes != nil {
+		in, out := &in.ConsumedBytes, &out.ConsumedBytes
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotInfo.
@@ -5131,6 +6007,16 @@ func (in *VMSnapshotStatus) DeepCopyInto(out *VMSnapshotStatus) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.SizeBytes != nil {
+		in, out := &in.SizeBytes, &out.SizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsumedBytes != nil {
+		in, out := &in.ConsumedBytes, &out.ConsumedBytes
+		*out = new(int64)
+		**out = **in
+	}
 	if in.VirtualSize != nil {
 		in, out := &in.VirtualSize, &out.VirtualSize
 		x := (*in).DeepCopy()
@@ -5365,6 +6251,145 @@ func (in *ValidationResults) DeepCopy() *ValidationResults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtrigaudQuota) DeepCopyInto(out *VirtrigaudQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtrigaudQuota.
+func (in *VirtrigaudQuota) DeepCopy() *VirtrigaudQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtrigaudQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtrigaudQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtrigaudQuotaList) DeepCopyInto(out *VirtrigaudQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtrigaudQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtrigaudQuotaList.
+func (in *VirtrigaudQuotaList) DeepCopy() *VirtrigaudQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtrigaudQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtrigaudQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtrigaudQuotaSpec) DeepCopyInto(out *VirtrigaudQuotaSpec) {
+	*out = *in
+	if in.MaxVMs != nil {
+		in, out := &in.MaxVMs, &out.MaxVMs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSnapshotsPerVM != nil {
+		in, out := &in.MaxSnapshotsPerVM, &out.MaxSnapshotsPerVM
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalSnapshots != nil {
+		in, out := &in.MaxTotalSnapshots, &out.MaxTotalSnapshots
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClonesInFlight != nil {
+		in, out := &in.MaxClonesInFlight, &out.MaxClonesInFlight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalDiskGiB != nil {
+		in, out := &in.MaxTotalDiskGiB, &out.MaxTotalDiskGiB
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtrigaudQuotaSpec.
+func (in *VirtrigaudQuotaSpec) DeepCopy() *VirtrigaudQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtrigaudQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtrigaudQuotaStatus) DeepCopyInto(out *VirtrigaudQuotaStatus) {
+	*out = *in
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = new(VirtrigaudQuotaUsage)
+		**out = **in
+	}
+	if in.Exceeded != nil {
+		in, out := &in.Exceeded, &out.Exceeded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtrigaudQuotaStatus.
+func (in *VirtrigaudQuotaStatus) DeepCopy() *VirtrigaudQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtrigaudQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtrigaudQuotaUsage) DeepCopyInto(out *VirtrigaudQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtrigaudQuotaUsage.
+func (in *VirtrigaudQuotaUsage) DeepCopy() *VirtrigaudQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtrigaudQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
 	*out = *in
@@ -5392,6 +6417,38 @@ func (in *VirtualMachine) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAppliedConfig) DeepCopyInto(out *VirtualMachineAppliedConfig) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]AppliedDisk, len(*in))
+		copy(*out, *in)
+	}
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]AppliedSecurityGroups, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAppliedConfig.
+func (in *VirtualMachineAppliedConfig) DeepCopy() *VirtualMachineAppliedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAppliedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineLifecycle) DeepCopyInto(out *VirtualMachineLifecycle) {
 	*out = *in
@@ -5506,6 +6563,11 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(VMNetworkingSpec)
+		**out = **in
+	}
 	if in.Disks != nil {
 		in, out := &in.Disks, &out.Disks
 		*out = make([]DiskSpec, len(*in))
@@ -5523,9 +6585,19 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(MetaData)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GuestCustomization != nil {
+		in, out := &in.GuestCustomization, &out.GuestCustomization
+		*out = new(GuestCustomization)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Placement != nil {
 		in, out := &in.Placement, &out.Placement
 		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerOpRequest != nil {
+		in, out := &in.PowerOpRequest, &out.PowerOpRequest
+		*out = new(PowerOpRequest)
 		**out = **in
 	}
 	if in.Tags != nil {
@@ -5553,6 +6625,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineLifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(VMReadinessSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdoptExisting != nil {
+		in, out := &in.AdoptExisting, &out.AdoptExisting
+		*out = new(AdoptExistingSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSpec.
@@ -5573,6 +6655,11 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]VMAddress, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -5580,6 +6667,16 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastPowerOp != nil {
+		in, out := &in.LastPowerOp, &out.LastPowerOp
+		*out = new(PowerOpStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastTask != nil {
+		in, out := &in.LastTask, &out.LastTask
+		*out = new(TaskRecord)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Provider != nil {
 		in, out := &in.Provider, &out.Provider
 		*out = make(map[string]string, len(*in))
@@ -5587,6 +6684,11 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastReconfigureTime != nil {
 		in, out := &in.LastReconfigureTime, &out.LastReconfigureTime
 		*out = (*in).DeepCopy()
@@ -5596,6 +6698,26 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(VirtualMachineResources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AppliedConfig != nil {
+		in, out := &in.AppliedConfig, &out.AppliedConfig
+		*out = new(VirtualMachineAppliedConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingPowerCycle != nil {
+		in, out := &in.PendingPowerCycle, &out.PendingPowerCycle
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateCycle != nil {
+		in, out := &in.UpdateCycle, &out.UpdateCycle
+		*out = new(UpdateCycleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]PlannedAction, len(*in))
+		copy(*out, *in)
+	}
 	if in.Snapshots != nil {
 		in, out := &in.Snapshots, &out.Snapshots
 		*out = make([]VMSnapshotInfo, len(*in))
@@ -5603,6 +6725,16 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SnapshotChain != nil {
+		in, out := &in.SnapshotChain, &out.SnapshotChain
+		*out = new(VMSnapshotChainStatus)
+		**out = **in
+	}
+	if in.GuestStats != nil {
+		in, out := &in.GuestStats, &out.GuestStats
+		*out = new(VMGuestStats)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStatus.
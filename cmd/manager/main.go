@@ -17,22 +17,31 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -40,12 +49,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/consoleproxy"
 	"github.com/projectbeskar/virtrigaud/internal/controller"
+	"github.com/projectbeskar/virtrigaud/internal/httpapi"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/resilience"
 	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
 	storagemigration "github.com/projectbeskar/virtrigaud/internal/storage/migration"
+	grpcClient "github.com/projectbeskar/virtrigaud/internal/transport/grpc"
 	"github.com/projectbeskar/virtrigaud/internal/version"
+	webhookv1beta1 "github.com/projectbeskar/virtrigaud/internal/webhook/v1beta1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
 )
 
 var (
@@ -76,6 +94,60 @@ func versionString() string {
 	return fmt.Sprintf("virtrigaud-manager %s", version.String())
 }
 
+// flagSet reports whether name was given on the command line.
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// configOverrides returns a function that writes the VirtrigaudConfig
+// fields given as flags over a configuration, so that the command line
+// wins over the ConfigMap on every reload.
+func configOverrides(fs *flag.FlagSet) func(*config.VirtrigaudConfig) {
+	set := map[string]string{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value.String() })
+	return func(c *config.VirtrigaudConfig) {
+		if v, ok := set["metrics-bind-address"]; ok {
+			c.Metrics.BindAddress = v
+		}
+		if v, ok := set["metrics-secure"]; ok {
+			c.Metrics.Secure = v == "true"
+		}
+		if v, ok := set["health-probe-bind-address"]; ok {
+			c.HealthProbeBindAddress = v
+		}
+		if v, ok := set["zap-log-level"]; ok {
+			c.LogLevel = v
+		}
+	}
+}
+
+// applyLogLevel sets the level the manager logs at. A level the config
+// package cannot parse (e.g. a --zap-log-level value it does not know) is
+// left alone; zap was already configured from the flag.
+func applyLogLevel(level uberzap.AtomicLevel, name string) {
+	if l, err := config.ParseLogLevel(name); err == nil {
+		level.SetLevel(zapcore.Level(l))
+	}
+}
+
+// applyLogLevels sets the per-logger levels of the VirtrigaudConfig's
+// logLevels. Validate has already rejected levels that do not parse.
+func applyLogLevels(levels *logging.Levels, names map[string]string) {
+	overrides := make(map[string]zapcore.Level, len(names))
+	for name, level := range names {
+		if l, err := config.ParseLogLevel(level); err == nil {
+			overrides[name] = zapcore.Level(l)
+		}
+	}
+	levels.SetOverrides(overrides)
+}
+
 // nolint:gocyclo
 func main() {
 	// Handle --version flag before any other flag parsing, mirroring
@@ -95,8 +167,19 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
 	var enforceProviderCapabilities bool
 	var migrationStorageAllowedHosts string
+	var spiffeEndpointSocket string
+	var configName, configNamespace string
+	var enableGuestStatsCollector bool
+	var guestStatsInterval time.Duration
+	var enableHypervisorEvents bool
+	var hypervisorAlarmTimeout time.Duration
+	var auditLevel, auditLogFile string
+	var httpAPIAddr, httpAPICertPath string
+	var enableConsoleProxy bool
+	var dnsPublishing string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -122,6 +205,12 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	// Validating webhooks are off by default because they need a serving
+	// certificate and a ValidatingWebhookConfiguration; the controllers
+	// enforce the same cross-namespace reference rules and VirtrigaudQuotas
+	// either way, the webhooks just reject bad objects at admission time.
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, serve the validating admission webhooks for VirtualMachine, VMSnapshot, VMClone, VMMigration and Provider.")
 	// Capability enforcement (issue #176). OFF by default: when off,
 	// snapshot/migration behaviour is byte-for-byte unchanged. When on, the
 	// snapshot and migration controllers gate capability-dependent
@@ -141,15 +230,96 @@ func main() {
 			"endpoint host and NFS server). Empty = permissive except the always-denied "+
 			"loopback/link-local/metadata/multicast targets (ADR-0006 C3, SSRF gate). "+
 			"Set to lock migration egress to known storage networks.")
+	flag.StringVar(&spiffeEndpointSocket, "spiffe-endpoint-socket", os.Getenv(spiffe.EnvEndpointSocket),
+		"SPIFFE Workload API address (e.g. unix:///spiffe-workload-api/spire-agent.sock). When set, the "+
+			"manager fetches its own SVID for Providers that use spec.runtime.service.tls.spiffe. "+
+			"Defaults to $SPIFFE_ENDPOINT_SOCKET.")
+	flag.StringVar(&configName, "config-name", config.DefaultVirtrigaudConfigName,
+		"Name of the ConfigMap holding the manager's VirtrigaudConfig (key "+config.VirtrigaudConfigKey+"). "+
+			"Flags given on the command line take precedence over it.")
+	flag.StringVar(&configNamespace, "config-namespace", os.Getenv("VIRTRIGAUD_NAMESPACE"),
+		"Namespace of the --config-name ConfigMap. Defaults to $VIRTRIGAUD_NAMESPACE; "+
+			"when empty no ConfigMap is read and only flags and defaults apply.")
+	flag.BoolVar(&enableGuestStatsCollector, "enable-guest-stats-collector", false,
+		"If set, periodically describe every provisioned VirtualMachine and export the guest CPU and memory "+
+			"usage its provider reports as virtrigaud_vm_guest_* gauges, e.g. for a Prometheus adapter serving "+
+			"the custom metrics API. Off by default as it adds one Describe per VM per interval.")
+	flag.DurationVar(&guestStatsInterval, "guest-stats-interval", controller.DefaultGuestStatsInterval,
+		"How often the guest stats collector samples VirtualMachines.")
+	flag.BoolVar(&enableHypervisorEvents, "enable-hypervisor-events", true,
+		"If set, subscribe to the event stream of every Provider advertising event watch and surface "+
+			"hypervisor events on the affected VirtualMachines as Kubernetes events and a HypervisorAlarm condition.")
+	flag.DurationVar(&hypervisorAlarmTimeout, "hypervisor-alarm-timeout", controller.DefaultHypervisorAlarmTimeout,
+		"How long a HypervisorAlarm condition stays raised when the provider reports no resolution.")
+	flag.StringVar(&auditLevel, "audit-log", string(middleware.AuditMutations),
+		"Which provider RPCs to record in the audit log: off, mutations (create, delete, power, reconfigure, "+
+			"snapshot, clone, image prepare and disk import/export) or all.")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"Append audit records to this file as JSON lines. By default they go to the manager's log under "+
+			"the \"audit\" logger.")
+	// The read-only HTTP API is off unless given an address. Without a
+	// certificate it serves plain HTTP, for use behind a TLS-terminating
+	// proxy; bearer tokens must never cross the network unencrypted.
+	flag.StringVar(&httpAPIAddr, "http-api-bind-address", "",
+		"The address the read-only HTTP API (/api/v1/vms, /api/v1/providers) binds to, e.g. :8443. "+
+			"Empty disables it. Callers authenticate with a Kubernetes bearer token and need RBAC read "+
+			"access to the resources they list.")
+	flag.StringVar(&httpAPICertPath, "http-api-cert-path", "",
+		"The directory holding tls.crt and tls.key for the HTTP API. When set the API serves HTTPS and "+
+			"reloads the certificate when it changes.")
+	flag.BoolVar(&enableConsoleProxy, "enable-console-proxy", false,
+		"Serve VM graphical consoles through the HTTP API, to callers with get on virtualmachines/console. "+
+			"Needs --http-api-bind-address. Session tokens are bearer credentials, so serve the API over HTTPS. "+
+			"Sessions are kept in memory, so run a single manager replica.")
+	flag.StringVar(&dnsPublishing, "dns-publishing", string(controller.DNSPublishingAuto),
+		"How VirtualMachines annotated with "+controller.DNSNameAnnotation+" are published for external-dns: "+
+			"auto (a DNSEndpoint when the externaldns.k8s.io CRD is installed, otherwise a headless Service), "+
+			"dnsendpoint, service, or off to disable the integration and create no objects.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// Unless --zap-log-level pins it, the log level follows the
+	// VirtrigaudConfig and can be changed without a restart.
+	logLevel := uberzap.NewAtomicLevel()
+	if !flagSet(flag.CommandLine, "zap-log-level") {
+		opts.Level = logLevel
+	}
+	// The VirtrigaudConfig's logLevels override the level per logger, on
+	// top of either.
+	logLevels := logging.NewLevels(opts.Level)
+	opts.Level = logLevels
+	opts.ZapOpts = append(opts.ZapOpts, uberzap.WrapCore(logLevels.WrapCore))
+
 	// Override the logger with flag-based options if provided
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	dnsMode, err := controller.ParseDNSPublishing(dnsPublishing)
+	if err != nil {
+		setupLog.Error(err, "invalid --dns-publishing")
+		os.Exit(1)
+	}
+
+	level, err := middleware.ParseAuditLevel(auditLevel)
+	if err != nil {
+		setupLog.Error(err, "invalid --audit-log")
+		os.Exit(1)
+	}
+	auditLogger := ctrl.Log
+	if auditLogFile != "" {
+		fileLogger, closeAuditLog, err := audit.NewFileLogger(auditLogFile)
+		if err != nil {
+			setupLog.Error(err, "unable to open the audit log file", "path", auditLogFile)
+			os.Exit(1)
+		}
+		defer closeAuditLog() //nolint:errcheck // Flushed on a clean exit only
+		auditLogger = fileLogger
+	}
+	audit.Configure(level, auditLogger)
+	setupLog.Info("provider call auditing", "level", level, "file", auditLogFile)
+
 	// Emit a virtrigaud_build_info{version,git_sha,go_version,component} sample
 	// so the manager's /metrics endpoint exposes a virtrigaud_* family at startup.
 	// Without this call the build_info GaugeVec stays empty and the family does
@@ -160,6 +330,36 @@ func main() {
 		"gitSHA", version.GitSHA,
 		"component", metrics.ComponentManager)
 
+	// Load the VirtrigaudConfig: flags > ConfigMap > defaults. Fields read
+	// only here need a restart to change; the rest are reloaded by the
+	// VirtrigaudConfig controller registered below.
+	configKey := types.NamespacedName{Namespace: configNamespace, Name: configName}
+	initialConfig := config.DefaultVirtrigaudConfig()
+	if configNamespace != "" {
+		reader, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client to read the manager configuration")
+			os.Exit(1)
+		}
+		loadCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		initialConfig, _, err = controller.LoadVirtrigaudConfig(loadCtx, reader, configKey)
+		cancel()
+		if err != nil {
+			setupLog.Error(err, "invalid manager configuration", "configMap", configKey)
+			os.Exit(1)
+		}
+	}
+	configStore := config.NewConfigStore(initialConfig, configOverrides(flag.CommandLine))
+	configStore.OnChange(func(_, updated *config.VirtrigaudConfig) {
+		applyLogLevel(logLevel, updated.LogLevel)
+		applyLogLevels(logLevels, updated.LogLevels)
+	})
+	managerConfig := configStore.Get()
+	applyLogLevel(logLevel, managerConfig.LogLevel)
+	applyLogLevels(logLevels, managerConfig.LogLevels)
+	metricsAddr, probeAddr, secureMetrics = managerConfig.Metrics.BindAddress, managerConfig.HealthProbeBindAddress, managerConfig.Metrics.Secure
+	setupLog.Info("manager configuration loaded", "configMap", configKey, "logLevel", managerConfig.LogLevel)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -253,10 +453,9 @@ func main() {
 
 	// Configure client with rate limiting to prevent API server overload
 	// These settings prevent reconciliation storms from overwhelming etcd/API server
-	// Conservative settings for cluster stability
 	restConfig := ctrl.GetConfigOrDie()
-	restConfig.QPS = 20   // Max 20 queries per second to API server
-	restConfig.Burst = 40 // Allow bursts up to 40 requests
+	restConfig.QPS = managerConfig.APIServer.QPS
+	restConfig.Burst = managerConfig.APIServer.Burst
 
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
@@ -291,11 +490,39 @@ func main() {
 
 	// Create remote provider resolver (all providers are now remote)
 	remoteResolver := remote.NewResolver(mgr.GetClient(), cbRegistry)
+	remoteResolver.SetRPCTimeouts(func() grpcClient.RPCTimeouts {
+		rpc := configStore.Get().ProviderRPC
+		return grpcClient.RPCTimeouts{
+			Read:       rpc.Read.Duration,
+			Mutating:   rpc.Mutating.Duration,
+			Power:      rpc.Power.Duration,
+			TaskStatus: rpc.TaskStatus.Duration,
+		}
+	})
+
+	// SPIFFE workload identity for manager→provider mTLS. Only Providers
+	// with tls.spiffe use it; Secret-based Providers are unaffected.
+	if spiffeEndpointSocket != "" {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		src, err := spiffe.NewX509Source(fetchCtx, spiffeEndpointSocket,
+			slog.New(logr.ToSlogHandler(ctrl.Log.WithName("spiffe"))))
+		cancel()
+		if err != nil {
+			setupLog.Error(err, "unable to obtain SPIFFE SVID", "endpoint", spiffeEndpointSocket)
+			os.Exit(1)
+		}
+		defer src.Close() //nolint:errcheck // best-effort on shutdown
+		remoteResolver.SetSPIFFESource(src)
+		setupLog.Info("SPIFFE workload identity enabled for provider mTLS", "endpoint", spiffeEndpointSocket)
+	}
 
 	if err = (&controller.VirtualMachineReconciler{
 		Client:         mgr.GetClient(),
 		Scheme:         mgr.GetScheme(),
 		RemoteResolver: remoteResolver,
+		Recorder:       mgr.GetEventRecorderFor("virtualmachine-controller"),
+		Config:         configStore,
+		DNSPublishing:  dnsMode,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VirtualMachine")
 		os.Exit(1)
@@ -304,6 +531,8 @@ func main() {
 		Client:         mgr.GetClient(),
 		Scheme:         mgr.GetScheme(),
 		RemoteResolver: remoteResolver,
+		Config:         configStore,
+		Recorder:       mgr.GetEventRecorderFor("provider-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Provider")
 		os.Exit(1)
@@ -315,6 +544,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VMClass")
 		os.Exit(1)
 	}
+	if err = (&controller.VirtrigaudQuotaReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VirtrigaudQuota")
+		os.Exit(1)
+	}
 	if err = (&controller.VMImageReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -338,6 +574,7 @@ func main() {
 		mgr.GetEventRecorderFor("vmsnapshot-controller"),
 		enforceProviderCapabilities,
 	)
+	vmsnapshotReconciler.Config = configStore
 	if err = vmsnapshotReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VMSnapshot")
 		os.Exit(1)
@@ -357,6 +594,7 @@ func main() {
 		os.Exit(1)
 	}
 	vmmigrationReconciler.StorageHostPolicy = storageHostPolicy
+	vmmigrationReconciler.Config = configStore
 	if err = vmmigrationReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VMMigration")
 		os.Exit(1)
@@ -367,6 +605,7 @@ func main() {
 		Client:         mgr.GetClient(),
 		Scheme:         mgr.GetScheme(),
 		RemoteResolver: remoteResolver,
+		Config:         configStore,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VMAdoption")
 		os.Exit(1)
@@ -379,11 +618,23 @@ func main() {
 		remoteResolver,
 		mgr.GetEventRecorderFor("vmclone-controller"),
 	)
+	vmcloneReconciler.Config = configStore
 	if err = vmcloneReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VMClone")
 		os.Exit(1)
 	}
 
+	// Register VMImagePublish controller (publish a VM as a template or image)
+	if err = controller.NewVMImagePublishReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		remoteResolver,
+		mgr.GetEventRecorderFor("vmimagepublish-controller"),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VMImagePublish")
+		os.Exit(1)
+	}
+
 	// Register VMSet controller (not-yet-active stub; reports
 	// ControllerNotImplemented condition only)
 	if err = (&controller.VMSetReconciler{
@@ -393,6 +644,91 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VMSet")
 		os.Exit(1)
 	}
+	if configNamespace != "" {
+		if err = (&controller.VirtrigaudConfigReconciler{
+			Client:   mgr.GetClient(),
+			Key:      configKey,
+			Store:    configStore,
+			Recorder: mgr.GetEventRecorderFor("virtrigaudconfig-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VirtrigaudConfig")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err = webhookv1beta1.SetupVirtualMachineWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VirtualMachine")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupVMClassWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VMClass")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupVMSnapshotWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VMSnapshot")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupVMCloneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VMClone")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupVMMigrationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VMMigration")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupProviderWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Provider")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupDeprecationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "deprecations")
+			os.Exit(1)
+		}
+	}
+	if enableGuestStatsCollector {
+		if err = mgr.Add(&controller.GuestStatsCollector{
+			Client:         mgr.GetClient(),
+			RemoteResolver: remoteResolver,
+			Interval:       guestStatsInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add guest stats collector to manager")
+			os.Exit(1)
+		}
+	}
+	if enableHypervisorEvents {
+		if err = mgr.Add(&controller.HypervisorEventWatcher{
+			Client:         mgr.GetClient(),
+			RemoteResolver: remoteResolver,
+			Recorder:       mgr.GetEventRecorderFor("hypervisor-events"),
+			AlarmTimeout:   hypervisorAlarmTimeout,
+		}); err != nil {
+			setupLog.Error(err, "unable to add hypervisor event watcher to manager")
+			os.Exit(1)
+		}
+	}
+	if err = mgr.Add(&controller.CleanupWorker{
+		Client:         mgr.GetClient(),
+		RemoteResolver: remoteResolver,
+		Recorder:       mgr.GetEventRecorderFor("cleanup-worker"),
+		Config:         configStore,
+	}); err != nil {
+		setupLog.Error(err, "unable to add cleanup worker to manager")
+		os.Exit(1)
+	}
+	if enableConsoleProxy && httpAPIAddr == "" {
+		setupLog.Error(fmt.Errorf("--enable-console-proxy needs --http-api-bind-address"), "invalid flags")
+		os.Exit(1)
+	}
+	if httpAPIAddr != "" {
+		var console *consoleproxy.Proxy
+		if enableConsoleProxy {
+			console = consoleproxy.New(mgr.GetCache(), remoteResolver)
+		}
+		if err := addHTTPAPI(mgr, restConfig, httpAPIAddr, httpAPICertPath, tlsOpts, console); err != nil {
+			setupLog.Error(err, "unable to set up the HTTP API")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	// Register cert watchers with the manager so they run as Runnables
@@ -428,3 +764,36 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// addHTTPAPI adds the read-only HTTP API, serving from the manager's cache,
+// to mgr. With a certPath it serves HTTPS with the certificate there,
+// reloaded when it changes. A non-nil console serves console sessions.
+func addHTTPAPI(mgr ctrl.Manager, restConfig *rest.Config, addr, certPath string, tlsOpts []func(*tls.Config), console *consoleproxy.Proxy) error {
+	authn, authz, err := httpapi.NewDelegatingAuth(restConfig, mgr.GetHTTPClient())
+	if err != nil {
+		return err
+	}
+	server := &httpapi.Server{
+		Addr:          addr,
+		Reader:        mgr.GetCache(),
+		Authenticator: authn,
+		Authorizer:    authz,
+		Console:       console,
+	}
+	if certPath != "" {
+		watcher, err := certwatcher.New(filepath.Join(certPath, "tls.crt"), filepath.Join(certPath, "tls.key"))
+		if err != nil {
+			return fmt.Errorf("failed to load the HTTP API certificate: %w", err)
+		}
+		if err := mgr.Add(watcher); err != nil {
+			return err
+		}
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: watcher.GetCertificate}
+		for _, opt := range tlsOpts {
+			opt(server.TLSConfig)
+		}
+	} else {
+		setupLog.Info("The HTTP API serves plain HTTP; put it behind a TLS-terminating proxy", "address", addr)
+	}
+	return mgr.Add(server)
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"flag"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/projectbeskar/virtrigaud/internal/config"
 	"github.com/projectbeskar/virtrigaud/internal/version"
 )
 
@@ -55,3 +57,26 @@ func TestVersionString(t *testing.T) {
 	assert.Equal(t, "virtrigaud-manager "+version.String(), s,
 		"banner must be 'virtrigaud-manager ' + version.String() verbatim")
 }
+
+// TestConfigOverrides pins flags > VirtrigaudConfig: only flags actually
+// given on the command line override the ConfigMap.
+func TestConfigOverrides(t *testing.T) {
+	fs := flag.NewFlagSet("manager", flag.ContinueOnError)
+	fs.String("metrics-bind-address", ":8080", "")
+	fs.String("health-probe-bind-address", ":8081", "")
+	fs.Bool("metrics-secure", false, "")
+	fs.String("zap-log-level", "", "")
+	require.NoError(t, fs.Parse([]string{"--metrics-secure", "--zap-log-level=debug"}))
+
+	cfg := config.DefaultVirtrigaudConfig()
+	cfg.Metrics.BindAddress = ":9443"
+	cfg.HealthProbeBindAddress = ":9444"
+	configOverrides(fs)(cfg)
+
+	assert.Equal(t, ":9443", cfg.Metrics.BindAddress, "an unset flag leaves the ConfigMap value")
+	assert.Equal(t, ":9444", cfg.HealthProbeBindAddress)
+	assert.True(t, cfg.Metrics.Secure)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.True(t, flagSet(fs, "zap-log-level"))
+	assert.False(t, flagSet(fs, "metrics-bind-address"))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/projectbeskar/virtrigaud/internal/version"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/server"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
 )
 
 func main() {
@@ -46,6 +47,11 @@ func main() {
 	// for the contract.
 	tlsResolution, tlsErr := server.ResolveTLSAndAuth()
 	switch {
+	case tlsErr == nil && tlsResolution.TLS.SPIFFE != nil:
+		logger.Info("mTLS enabled (SPIFFE workload identity)",
+			"trust_domain", tlsResolution.TLS.SPIFFE.TrustDomain,
+			"allowed_ids", tlsResolution.TLS.SPIFFE.AllowedIDs,
+		)
 	case tlsErr == nil:
 		logger.Info("mTLS enabled",
 			"cert_path", server.ProviderTLSCertFile,
@@ -59,10 +65,33 @@ func main() {
 			"This is audit-flagged per ADR-0003.",
 			"mount_path", server.ProviderTLSMountPath,
 		)
+		logger.Warn("AUDIT: provider authentication bypassed; bearer token checks are off and any client that can reach this port may call every RPC",
+			"env", server.EnvInsecure,
+		)
 	default:
 		logger.Error("Failed to resolve TLS configuration", "error", tlsErr)
 		os.Exit(1)
 	}
+	if tlsResolution.Auth.BearerTokenAuth {
+		logger.Info("Bearer token authentication enabled", "token_path", server.ProviderAuthTokenFile)
+	}
+
+	// Opt-in, redacted payload sampling for debugging contract mismatches.
+	debugSample, err := middleware.DebugSampleConfigFromEnv(logger)
+	if err != nil {
+		logger.Error("Invalid debug sampling configuration", "error", err)
+		os.Exit(1)
+	}
+	if debugSample != nil {
+		logger.Warn("RPC payload sampling enabled (redacted)", "rate", debugSample.Rate)
+	}
+
+	// Audit record of the RPCs that change hypervisor state, or of all RPCs.
+	audit, err := middleware.AuditConfigFromEnv(logger)
+	if err != nil {
+		logger.Error("Invalid audit configuration", "error", err)
+		os.Exit(1)
+	}
 
 	// Create server configuration
 	config := server.DefaultConfig()
@@ -77,7 +106,9 @@ func main() {
 			Enabled: true,
 			Logger:  logger,
 		},
-		Auth: tlsResolution.Auth,
+		Auth:        tlsResolution.Auth,
+		DebugSample: debugSample,
+		Audit:       audit,
 	}
 
 	// Create server
@@ -87,8 +118,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Task records outlive a restart when the manager mounted a task store
+	taskStore, err := tasks.OpenStore()
+	if err != nil {
+		logger.Error("Failed to open task store", "error", err)
+		os.Exit(1)
+	}
+
 	// Create and register mock provider
-	mockProvider := mock.NewProvider()
+	mockProvider := mock.NewProviderWithTaskStore(taskStore)
 	srv.RegisterProvider(mockProvider)
 
 	// Start server
@@ -64,6 +64,11 @@ func main() {
 	//   - (nil, hard error)                       → refuse to start
 	tlsResolution, tlsErr := server.ResolveTLSAndAuth()
 	switch {
+	case tlsErr == nil && tlsResolution.TLS.SPIFFE != nil:
+		logger.Info("mTLS enabled (SPIFFE workload identity)",
+			"trust_domain", tlsResolution.TLS.SPIFFE.TrustDomain,
+			"allowed_ids", tlsResolution.TLS.SPIFFE.AllowedIDs,
+		)
 	case tlsErr == nil:
 		logger.Info("mTLS enabled",
 			"cert_path", server.ProviderTLSCertFile,
@@ -77,10 +82,33 @@ func main() {
 			"This is audit-flagged per ADR-0003.",
 			"mount_path", server.ProviderTLSMountPath,
 		)
+		logger.Warn("AUDIT: provider authentication bypassed; bearer token checks are off and any client that can reach this port may call every RPC",
+			"env", server.EnvInsecure,
+		)
 	default:
 		logger.Error("Failed to resolve TLS configuration", "error", tlsErr)
 		os.Exit(1)
 	}
+	if tlsResolution.Auth.BearerTokenAuth {
+		logger.Info("Bearer token authentication enabled", "token_path", server.ProviderAuthTokenFile)
+	}
+
+	// Opt-in, redacted payload sampling for debugging contract mismatches.
+	debugSample, err := middleware.DebugSampleConfigFromEnv(logger)
+	if err != nil {
+		logger.Error("Invalid debug sampling configuration", "error", err)
+		os.Exit(1)
+	}
+	if debugSample != nil {
+		logger.Warn("RPC payload sampling enabled (redacted)", "rate", debugSample.Rate)
+	}
+
+	// Audit record of the RPCs that change hypervisor state, or of all RPCs.
+	audit, err := middleware.AuditConfigFromEnv(logger)
+	if err != nil {
+		logger.Error("Invalid audit configuration", "error", err)
+		os.Exit(1)
+	}
 
 	// Create server configuration
 	config := server.DefaultConfig()
@@ -97,7 +125,9 @@ func main() {
 			Enabled: true,
 			Logger:  logger,
 		},
-		Auth: tlsResolution.Auth,
+		Auth:        tlsResolution.Auth,
+		DebugSample: debugSample,
+		Audit:       audit,
 	}
 
 	// Create server
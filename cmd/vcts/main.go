@@ -18,9 +18,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -40,6 +43,12 @@ var (
 	timeout    time.Duration
 	parallel   int
 	verbose    bool
+	strict     bool
+
+	iterations int
+	baseline   string
+	threshold  float64
+	vars       map[string]string
 )
 
 func main() {
@@ -48,6 +57,8 @@ func main() {
 		Short: "Virtrigaud Conformance Test Suite",
 		Long: `VCTS (Virtrigaud Conformance Test Suite) runs standardized tests against 
 virtrigaud providers to verify compliance with the provider contract.`,
+		// main prints the returned error itself.
+		SilenceErrors: true,
 	}
 
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file")
@@ -78,11 +89,43 @@ virtrigaud providers to verify compliance with the provider contract.`,
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate test specifications",
-		Long:  "Validate conformance test specifications for correctness",
-		RunE:  validateTests,
+		Long: `Validate conformance test specifications against the spec schema:
+required fields, step types and their parameters, capability names,
+wait conditions, operators and timeout formats. Exits non-zero when any
+test is invalid.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: validateTests,
+		// A failed validation is reported per field; usage text would bury it.
+		SilenceUsage: true,
 	}
-
-	rootCmd.AddCommand(runCmd, listCmd, validateCmd)
+	validateCmd.Flags().BoolVar(&strict, "strict", false, "Also reject fields the spec schema does not define")
+
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Time standard operations against a provider",
+		Long: `Run the benchmark scenarios in test/conformance/bench against a provider
+several times and report the median and p95 time of each operation.
+Scenarios the provider lacks the capabilities for are skipped. Results
+are written to benchmark.json and benchmark.md in the output directory.
+
+With --baseline, the run is compared against a previous benchmark.json,
+which may be of another provider. The command exits non-zero when an
+operation's median or p95 is slower than the baseline by more than
+--threshold percent, or when any iteration fails.`,
+		RunE:         runBenchmark,
+		SilenceUsage: true,
+	}
+	benchCmd.Flags().StringVarP(&provider, "provider", "p", "", "Provider name to benchmark (required)")
+	benchCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "./benchmark-results", "Output directory for benchmark results")
+	benchCmd.Flags().StringSliceVar(&skipTests, "skip", []string{}, "List of scenario names to skip")
+	benchCmd.Flags().DurationVar(&timeout, "timeout", 4*time.Hour, "Benchmark timeout")
+	benchCmd.Flags().IntVar(&iterations, "iterations", 5, "Number of times to run each scenario")
+	benchCmd.Flags().StringVar(&baseline, "baseline", "", "Previous benchmark.json to compare against")
+	benchCmd.Flags().Float64Var(&threshold, "threshold", 20, "Slowdown over the baseline, in percent, that counts as a regression")
+	benchCmd.Flags().StringToStringVar(&vars, "set", map[string]string{}, "Scenario variables, such as image=ubuntu-24 or diskImageURL=https://...")
+	_ = benchCmd.MarkFlagRequired("provider")
+
+	rootCmd.AddCommand(runCmd, listCmd, validateCmd, benchCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -136,6 +179,12 @@ func runConformanceTests(cmd *cobra.Command, args []string) error {
 	// Print summary
 	fmt.Printf("\nConformance Test Results:\n")
 	fmt.Printf("Provider: %s\n", provider)
+	if results.ProviderVersion != "" {
+		fmt.Printf("Provider Version: %s\n", results.ProviderVersion)
+	}
+	if hv := results.Hypervisor; hv != nil {
+		fmt.Printf("Hypervisor: %s %s\n", hv.Product, hv.Version)
+	}
 	fmt.Printf("Total Tests: %d\n", results.Total)
 	fmt.Printf("Passed: %d\n", results.Passed)
 	fmt.Printf("Failed: %d\n", results.Failed)
@@ -150,6 +199,102 @@ func runConformanceTests(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runBenchmark(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var base *conformance.Benchmark
+	if baseline != "" {
+		var err error
+		if base, err = conformance.LoadBenchmark(baseline); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	runner := conformance.NewRunner(conformance.Config{
+		KubeClient: k8sClient,
+		Namespace:  namespace,
+		Provider:   provider,
+		OutputDir:  outputDir,
+		SkipTests:  skipTests,
+		Verbose:    verbose,
+		Vars:       vars,
+	})
+
+	bench, err := runner.Bench(ctx, conformance.BenchOptions{
+		Iterations: iterations,
+		Baseline:   base,
+		Threshold:  threshold,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run benchmark: %w", err)
+	}
+
+	fmt.Printf("\nBenchmark Results:\n")
+	fmt.Printf("Provider: %s\n", provider)
+	if bench.ProviderVersion != "" {
+		fmt.Printf("Provider Version: %s\n", bench.ProviderVersion)
+	}
+	printBenchmark(os.Stdout, bench)
+	fmt.Printf("\nResults saved to: %s\n", outputDir)
+
+	regressions := bench.Comparison.Regressions()
+	switch {
+	case len(regressions) > 0 && bench.Failures() > 0:
+		return fmt.Errorf("%d operations regressed and %d iterations failed", len(regressions), bench.Failures())
+	case len(regressions) > 0:
+		return fmt.Errorf("%d operations regressed beyond %.0f%%", len(regressions), threshold)
+	case bench.Failures() > 0:
+		return fmt.Errorf("%d benchmark iterations failed", bench.Failures())
+	}
+	return nil
+}
+
+// printBenchmark writes the per-operation timings, and the comparison with
+// the baseline if there was one.
+func printBenchmark(w io.Writer, bench *conformance.Benchmark) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "\nOPERATION\tSTATUS\tSAMPLES\tMEDIAN\tP95\tFAILURES")
+	for _, op := range bench.Operations {
+		median, p95 := "-", "-"
+		if op.Status == "measured" {
+			median, p95 = op.Median.Round(time.Millisecond).String(), op.P95.Round(time.Millisecond).String()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%d\n", op.Name, op.Status, len(op.Samples), median, p95, op.Failures)
+	}
+	_ = tw.Flush()
+
+	c := bench.Comparison
+	if c == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nCompared with %s %s (threshold %.0f%%):\n", c.BaselineProvider, c.BaselineVersion, c.Threshold)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "OPERATION\tMEDIAN\tP95\tRESULT")
+	for _, d := range c.Deltas {
+		result := "ok"
+		if d.Regressed {
+			result = "REGRESSED"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%+.1f%%\t%+.1f%%\t%s\n", d.Operation, d.MedianChange, d.P95Change, result)
+	}
+	_ = tw.Flush()
+}
+
 func listTests(cmd *cobra.Command, args []string) error {
 	runner := conformance.NewRunner(conformance.Config{})
 	tests, err := runner.ListTests()
@@ -184,34 +329,59 @@ func validateTests(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no test specification files found in %s", specDir)
 	}
 
-	validator := conformance.NewValidator()
+	validator := conformance.NewValidator().SetStrict(strict)
 
 	totalTests := 0
-	validTests := 0
+	invalidTests := 0
+	invalidFiles := 0
 
 	for _, specFile := range specFiles {
 		fmt.Printf("Validating %s...\n", specFile)
 
 		tests, err := validator.ValidateFile(specFile)
-		if err != nil {
+		var verrs conformance.ValidationErrors
+		if err != nil && !errors.As(err, &verrs) {
 			fmt.Printf("  ERROR: %v\n", err)
+			invalidFiles++
 			continue
 		}
 
+		invalid := verrs.InvalidTests()
+		for _, verr := range verrs {
+			if verr.TestIndex < 0 {
+				// The file itself could not be read as a list of tests.
+				invalidFiles++
+				break
+			}
+		}
 		totalTests += len(tests)
-		validTests += len(tests)
-
-		for _, test := range tests {
-			fmt.Printf("  ✓ %s\n", test.Name)
+		invalidTests += len(invalid)
+
+		for i, test := range tests {
+			name := test.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			if invalid[i] {
+				fmt.Printf("  ✗ %s\n", name)
+			} else {
+				fmt.Printf("  ✓ %s\n", name)
+			}
+		}
+		for _, verr := range verrs {
+			fmt.Printf("    %v\n", verr)
 		}
 	}
 
 	fmt.Printf("\nValidation Results:\n")
 	fmt.Printf("Total Tests: %d\n", totalTests)
-	fmt.Printf("Valid Tests: %d\n", validTests)
-	fmt.Printf("Invalid Tests: %d\n", totalTests-validTests)
+	fmt.Printf("Valid Tests: %d\n", totalTests-invalidTests)
+	fmt.Printf("Invalid Tests: %d\n", invalidTests)
+	if invalidFiles > 0 {
+		fmt.Printf("Invalid Files: %d\n", invalidFiles)
+	}
 
-	if validTests != totalTests {
+	if invalidTests > 0 || invalidFiles > 0 {
 		return fmt.Errorf("validation failed")
 	}
 
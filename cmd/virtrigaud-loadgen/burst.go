@@ -0,0 +1,303 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// burstLabel groups the VMs of one burst run
+const burstLabel = "loadgen-burst"
+
+// BurstConfig is what a burst creates and how long it waits
+type BurstConfig struct {
+	Count    int
+	Provider string
+	ClassRef string
+	ImageRef string
+	Timeout  time.Duration
+	Poll     time.Duration
+	// MaxConcurrentClones, when not negative, is set on the Provider's
+	// spec.runtime.maxConcurrentClones before the burst starts
+	MaxConcurrentClones int
+}
+
+var burstConfig BurstConfig
+
+// burstSample is what a burst observed of one VM
+type burstSample struct {
+	Name    string
+	Created time.Time
+	// Ready is when the VM was first seen Running, zero if it never was
+	Ready time.Time
+	// Failed is set once the VM reached the Failed phase
+	Failed bool
+	// Deferred is set once the VM waited for a busy provider
+	Deferred bool
+	// Errored is set once the provider's Create failed for the VM
+	Errored bool
+}
+
+// BurstSummary sums up a burst run
+type BurstSummary struct {
+	Total    int
+	Ready    int
+	Failed   int
+	TimedOut int
+	Deferred int
+	Errored  int
+	Elapsed  time.Duration
+	// Throughput is ready VMs per minute over the whole run
+	Throughput float64
+	P50        time.Duration
+	P95        time.Duration
+	Max        time.Duration
+}
+
+func newBurstCmd() *cobra.Command {
+	burstCmd := &cobra.Command{
+		Use:   "burst",
+		Short: "Create many VMs from one image at once",
+		Long: `Create --count VirtualMachines from the same image on one provider at once,
+wait until each is Running or --timeout passes, and summarise throughput,
+time to ready, timeouts, failures and how many VMs waited on a busy provider.
+Run it with and without --max-concurrent-clones to compare.`,
+		RunE: runBurst,
+	}
+	burstCmd.Flags().IntVar(&burstConfig.Count, "count", 50, "Number of VMs to create")
+	burstCmd.Flags().StringVar(&burstConfig.Provider, "provider", "test-provider", "Provider the VMs are created on")
+	burstCmd.Flags().StringVar(&burstConfig.ClassRef, "class", "test-class", "VMClass of the VMs")
+	burstCmd.Flags().StringVar(&burstConfig.ImageRef, "image", "test-image", "VMImage all VMs are created from")
+	burstCmd.Flags().DurationVar(&burstConfig.Timeout, "timeout", 30*time.Minute, "How long to wait for the VMs to become Running")
+	burstCmd.Flags().DurationVar(&burstConfig.Poll, "poll-interval", 5*time.Second, "How often VM status is checked")
+	burstCmd.Flags().IntVar(&burstConfig.MaxConcurrentClones, "max-concurrent-clones", -1,
+		"Set the Provider's maxConcurrentClones before the burst (0 for unlimited; -1 leaves it unchanged)")
+	return burstCmd
+}
+
+func runBurst(cmd *cobra.Command, args []string) error {
+	k8sClient, err := newClient()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if burstConfig.MaxConcurrentClones >= 0 && !dryRun {
+		if err := setMaxConcurrentClones(ctx, k8sClient, burstConfig.Provider, int32(burstConfig.MaxConcurrentClones)); err != nil {
+			return err
+		}
+	}
+
+	runID := time.Now().UTC().Format("20060102-150405")
+	samples, err := burst(ctx, k8sClient, namespace, runID, burstConfig)
+	if err != nil {
+		return err
+	}
+	summary := summarizeBurst(samples, time.Now())
+
+	printBurstSummary(cmd.OutOrStdout(), summary)
+	filename := filepath.Join(outputDir, fmt.Sprintf("burst-%s.md", runID))
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create summary file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	printBurstSummary(file, summary)
+	fmt.Printf("Results saved to: %s\n", filename)
+	return nil
+}
+
+// setMaxConcurrentClones sets spec.runtime.maxConcurrentClones on provider;
+// the provider picks it up when its Deployment rolls out again.
+func setMaxConcurrentClones(ctx context.Context, c client.Client, provider string, n int32) error {
+	p := &infrav1beta1.Provider{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: provider}, p); err != nil {
+		return fmt.Errorf("failed to get Provider %s: %w", provider, err)
+	}
+	if p.Spec.Runtime == nil {
+		return fmt.Errorf("provider %s has no spec.runtime to set maxConcurrentClones on", provider)
+	}
+	patch := client.MergeFrom(p.DeepCopy())
+	p.Spec.Runtime.MaxConcurrentClones = &n
+	if err := c.Patch(ctx, p, patch); err != nil {
+		return fmt.Errorf("failed to set maxConcurrentClones on Provider %s: %w", provider, err)
+	}
+	return nil
+}
+
+// burst creates the VMs of one run and follows them until every one is
+// Running or Failed, or cfg.Timeout passes.
+func burst(ctx context.Context, c client.Client, namespace, runID string, cfg BurstConfig) ([]*burstSample, error) {
+	samples := make([]*burstSample, 0, cfg.Count)
+	byName := make(map[string]*burstSample, cfg.Count)
+	for i := range cfg.Count {
+		vm := &infrav1beta1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("burst-%s-%03d", runID, i),
+				Namespace: namespace,
+				Labels:    map[string]string{loadgenLabel: loadgenLabelValue, burstLabel: runID},
+			},
+			Spec: infrav1beta1.VirtualMachineSpec{
+				ProviderRef: infrav1beta1.ObjectRef{Name: cfg.Provider},
+				ClassRef:    infrav1beta1.ObjectRef{Name: cfg.ClassRef},
+				ImageRef:    &infrav1beta1.ObjectRef{Name: cfg.ImageRef},
+				PowerState:  "On",
+			},
+		}
+		sample := &burstSample{Name: vm.Name, Created: time.Now()}
+		if !dryRun {
+			if err := c.Create(ctx, vm); err != nil {
+				return samples, fmt.Errorf("failed to create VirtualMachine %s: %w", vm.Name, err)
+			}
+		} else {
+			sample.Ready = sample.Created
+		}
+		samples = append(samples, sample)
+		byName[vm.Name] = sample
+	}
+	if dryRun {
+		return samples, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(cfg.Poll)
+	defer ticker.Stop()
+	for {
+		list := &infrav1beta1.VirtualMachineList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{burstLabel: runID}); err != nil {
+			if ctx.Err() != nil {
+				return samples, nil
+			}
+			return samples, fmt.Errorf("failed to list burst VMs: %w", err)
+		}
+		pending := 0
+		for i := range list.Items {
+			if sample, ok := byName[list.Items[i].Name]; ok {
+				observeBurstVM(sample, &list.Items[i], time.Now())
+			}
+		}
+		for _, sample := range samples {
+			if sample.Ready.IsZero() && !sample.Failed {
+				pending++
+			}
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "%d of %d VMs pending\n", pending, len(samples))
+		}
+		if pending == 0 {
+			return samples, nil
+		}
+		select {
+		case <-ctx.Done():
+			return samples, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeBurstVM records what vm's status shows at now in sample
+func observeBurstVM(sample *burstSample, vm *infrav1beta1.VirtualMachine, now time.Time) {
+	switch vm.Status.Phase {
+	case infrav1beta1.VirtualMachinePhaseRunning:
+		if sample.Ready.IsZero() {
+			sample.Ready = now
+		}
+	case infrav1beta1.VirtualMachinePhaseFailed:
+		sample.Failed = true
+	}
+	if cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionProvisioning); cond != nil {
+		switch cond.Reason {
+		case k8s.ReasonProviderThrottled:
+			sample.Deferred = true
+		case k8s.ReasonProviderError:
+			sample.Errored = true
+		}
+	}
+}
+
+// summarizeBurst sums up samples of a burst that ended at end. A VM that
+// is neither ready nor failed timed out.
+func summarizeBurst(samples []*burstSample, end time.Time) BurstSummary {
+	summary := BurstSummary{Total: len(samples)}
+	if len(samples) == 0 {
+		return summary
+	}
+	start := samples[0].Created
+	var toReady []time.Duration
+	for _, s := range samples {
+		if s.Created.Before(start) {
+			start = s.Created
+		}
+		switch {
+		case !s.Ready.IsZero():
+			summary.Ready++
+			toReady = append(toReady, s.Ready.Sub(s.Created))
+		case s.Failed:
+			summary.Failed++
+		default:
+			summary.TimedOut++
+		}
+		if s.Deferred {
+			summary.Deferred++
+		}
+		if s.Errored {
+			summary.Errored++
+		}
+	}
+	summary.Elapsed = end.Sub(start)
+	if summary.Elapsed > 0 {
+		summary.Throughput = float64(summary.Ready) / summary.Elapsed.Minutes()
+	}
+	if len(toReady) > 0 {
+		sort.Slice(toReady, func(i, j int) bool { return toReady[i] < toReady[j] })
+		summary.P50 = toReady[len(toReady)*50/100]
+		summary.P95 = toReady[len(toReady)*95/100]
+		summary.Max = toReady[len(toReady)-1]
+	}
+	return summary
+}
+
+func printBurstSummary(w io.Writer, s BurstSummary) {
+	_, _ = fmt.Fprintf(w, "# Burst Summary\n\n")
+	_, _ = fmt.Fprintf(w, "- **VMs**: %d\n", s.Total)
+	_, _ = fmt.Fprintf(w, "- **Ready**: %d\n", s.Ready)
+	_, _ = fmt.Fprintf(w, "- **Failed**: %d\n", s.Failed)
+	_, _ = fmt.Fprintf(w, "- **Timed out**: %d\n", s.TimedOut)
+	_, _ = fmt.Fprintf(w, "- **Create errors**: %d\n", s.Errored)
+	_, _ = fmt.Fprintf(w, "- **Deferred by a busy provider**: %d\n", s.Deferred)
+	_, _ = fmt.Fprintf(w, "- **Elapsed**: %v\n", s.Elapsed.Round(time.Second))
+	_, _ = fmt.Fprintf(w, "- **Throughput**: %.1f VMs/min\n", s.Throughput)
+	_, _ = fmt.Fprintf(w, "- **Time to ready**: P50 %v, P95 %v, max %v\n",
+		s.P50.Round(time.Second), s.P95.Round(time.Second), s.Max.Round(time.Second))
+}
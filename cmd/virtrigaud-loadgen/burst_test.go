@@ -0,0 +1,85 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func TestSummarizeBurst(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var samples []*burstSample
+	for i := range 10 {
+		samples = append(samples, &burstSample{
+			Created:  start,
+			Ready:    start.Add(time.Duration(i+1) * time.Minute),
+			Deferred: i >= 5,
+		})
+	}
+	samples = append(samples,
+		&burstSample{Created: start, Failed: true, Errored: true},
+		&burstSample{Created: start, Deferred: true},
+	)
+
+	summary := summarizeBurst(samples, start.Add(20*time.Minute))
+	assert.Equal(t, 12, summary.Total)
+	assert.Equal(t, 10, summary.Ready)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 1, summary.TimedOut, "a VM neither ready nor failed timed out")
+	assert.Equal(t, 6, summary.Deferred)
+	assert.Equal(t, 1, summary.Errored)
+	assert.Equal(t, 20*time.Minute, summary.Elapsed)
+	assert.InDelta(t, 0.5, summary.Throughput, 0.001)
+	assert.Equal(t, 6*time.Minute, summary.P50)
+	assert.Equal(t, 10*time.Minute, summary.P95)
+	assert.Equal(t, 10*time.Minute, summary.Max)
+
+	var out bytes.Buffer
+	printBurstSummary(&out, summary)
+	assert.Contains(t, out.String(), "**Timed out**: 1")
+	assert.Contains(t, out.String(), "**Throughput**: 0.5 VMs/min")
+
+	assert.Equal(t, BurstSummary{}, summarizeBurst(nil, start))
+}
+
+func TestObserveBurstVM(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sample := &burstSample{Created: now}
+	vm := &infrav1beta1.VirtualMachine{}
+	vm.Status.Phase = infrav1beta1.VirtualMachinePhaseProvisioning
+	vm.Status.Conditions = []metav1.Condition{{Type: k8s.ConditionProvisioning, Reason: k8s.ReasonProviderThrottled}}
+
+	observeBurstVM(sample, vm, now.Add(time.Minute))
+	assert.True(t, sample.Deferred)
+	assert.True(t, sample.Ready.IsZero())
+
+	vm.Status.Phase = infrav1beta1.VirtualMachinePhaseRunning
+	vm.Status.Conditions[0].Reason = k8s.ReasonReconcileSuccess
+	observeBurstVM(sample, vm, now.Add(2*time.Minute))
+	observeBurstVM(sample, vm, now.Add(3*time.Minute))
+	assert.Equal(t, now.Add(2*time.Minute), sample.Ready, "ready is when the VM was first seen Running")
+	assert.True(t, sample.Deferred, "a VM that waited stays counted")
+	assert.False(t, sample.Errored)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+var olderThan time.Duration
+
+// CleanupResult counts what cleanup did with the loadgen objects of a kind
+type CleanupResult struct {
+	Kind    string
+	Deleted int
+	Skipped int // newer than --older-than
+	Failed  int
+}
+
+// cleanupKinds lists the kinds cleanup removes, dependents before the VMs
+// they reference.
+func cleanupKinds() []struct {
+	kind string
+	list client.ObjectList
+} {
+	return []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"VMClone", &infrav1beta1.VMCloneList{}},
+		{"VMSnapshot", &infrav1beta1.VMSnapshotList{}},
+		{"VirtualMachine", &infrav1beta1.VirtualMachineList{}},
+	}
+}
+
+func runCleanup(cmd *cobra.Command, args []string) error {
+	k8sClient, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	results, err := cleanup(cmd.Context(), k8sClient, namespace, olderThan, time.Now(), dryRun, cmd.ErrOrStderr())
+	printCleanupSummary(cmd.OutOrStdout(), results, dryRun)
+	return err
+}
+
+// cleanup deletes the objects carrying the loadgen label in namespace that
+// are at least olderThan old. A dry run only counts them. Failed deletions
+// are reported on errOut and make cleanup return an error once every kind
+// has been tried.
+func cleanup(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	olderThan time.Duration,
+	now time.Time,
+	dryRun bool,
+	errOut io.Writer,
+) ([]CleanupResult, error) {
+	var results []CleanupResult
+	failed := 0
+	for _, k := range cleanupKinds() {
+		result := CleanupResult{Kind: k.kind}
+		if err := c.List(ctx, k.list, client.InNamespace(namespace),
+			client.MatchingLabels{loadgenLabel: loadgenLabelValue}); err != nil {
+			return results, fmt.Errorf("failed to list %s objects: %w", k.kind, err)
+		}
+		items, err := meta.ExtractList(k.list)
+		if err != nil {
+			return results, fmt.Errorf("failed to read %s list: %w", k.kind, err)
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if now.Sub(obj.GetCreationTimestamp().Time) < olderThan {
+				result.Skipped++
+				continue
+			}
+			if dryRun {
+				result.Deleted++
+				continue
+			}
+			err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				_, _ = fmt.Fprintf(errOut, "failed to delete %s %s: %v\n", k.kind, obj.GetName(), err)
+				result.Failed++
+				continue
+			}
+			if verbose {
+				_, _ = fmt.Fprintf(errOut, "deleted %s %s\n", k.kind, obj.GetName())
+			}
+			result.Deleted++
+		}
+		failed += result.Failed
+		results = append(results, result)
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("failed to delete %d objects", failed)
+	}
+	return results, nil
+}
+
+func printCleanupSummary(w io.Writer, results []CleanupResult, dryRun bool) {
+	deleted := "DELETED"
+	if dryRun {
+		deleted = "WOULD DELETE"
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "KIND\t%s\tSKIPPED\tFAILED\n", deleted)
+	for _, r := range results {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", r.Kind, r.Deleted, r.Skipped, r.Failed)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func loadgenMeta(name string, created time.Time, labeled bool) metav1.ObjectMeta {
+	m := metav1.ObjectMeta{Name: name, Namespace: "load", CreationTimestamp: metav1.NewTime(created)}
+	if labeled {
+		m.Labels = map[string]string{loadgenLabel: loadgenLabelValue}
+	}
+	return m
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	old, recent := now.Add(-2*time.Hour), now.Add(-time.Minute)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1beta1.VirtualMachine{ObjectMeta: loadgenMeta("vm-old", old, true)},
+		&infrav1beta1.VirtualMachine{ObjectMeta: loadgenMeta("vm-recent", recent, true)},
+		&infrav1beta1.VirtualMachine{ObjectMeta: loadgenMeta("vm-user", old, false)},
+		&infrav1beta1.VMSnapshot{ObjectMeta: loadgenMeta("snap-old", old, true)},
+		&infrav1beta1.VMClone{ObjectMeta: loadgenMeta("clone-old", old, true)},
+	).Build()
+
+	var errOut bytes.Buffer
+	results, err := cleanup(ctx, c, "load", time.Hour, now, true, &errOut)
+	require.NoError(t, err)
+	assert.Equal(t, []CleanupResult{
+		{Kind: "VMClone", Deleted: 1},
+		{Kind: "VMSnapshot", Deleted: 1},
+		{Kind: "VirtualMachine", Deleted: 1, Skipped: 1},
+	}, results)
+	vms := &infrav1beta1.VirtualMachineList{}
+	require.NoError(t, c.List(ctx, vms))
+	assert.Len(t, vms.Items, 3, "a dry run deletes nothing")
+
+	results, err = cleanup(ctx, c, "load", time.Hour, now, false, &errOut)
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[2].Deleted)
+	require.NoError(t, c.List(ctx, vms))
+	var names []string
+	for _, vm := range vms.Items {
+		names = append(names, vm.Name)
+	}
+	assert.ElementsMatch(t, []string{"vm-recent", "vm-user"}, names)
+	err = c.Get(ctx, client.ObjectKey{Namespace: "load", Name: "snap-old"}, &infrav1beta1.VMSnapshot{})
+	assert.True(t, apierrors.IsNotFound(err), "got %v", err)
+	assert.Empty(t, errOut.String())
+
+	var out bytes.Buffer
+	printCleanupSummary(&out, results, false)
+	assert.Equal(t, `KIND            DELETED  SKIPPED  FAILED
+VMClone         1        0        0
+VMSnapshot      1        0        0
+VirtualMachine  1        1        0
+`, out.String())
+}
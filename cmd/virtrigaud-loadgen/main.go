@@ -31,6 +31,7 @@ import (
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
@@ -38,6 +39,13 @@ import (
 	"github.com/projectbeskar/virtrigaud/internal/util/closer"
 )
 
+// loadgenLabel marks every resource load generation creates, so cleanup
+// can find them
+const (
+	loadgenLabel      = "generated-by"
+	loadgenLabelValue = "loadgen"
+)
+
 var (
 	kubeconfig string
 	namespace  string
@@ -155,7 +163,18 @@ func main() {
 
 	runCmd.Flags().StringVarP(&configFile, "config", "c", "", "Load generation config file")
 
-	rootCmd.AddCommand(runCmd)
+	cleanupCmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Delete the resources load generation created",
+		Long: `Delete the VirtualMachines, VMSnapshots and VMClones carrying the
+` + loadgenLabel + "=" + loadgenLabelValue + ` label in the namespace, such as those a failed or
+interrupted run left behind, and print how many of each were removed.`,
+		RunE: runCleanup,
+	}
+
+	cleanupCmd.Flags().DurationVar(&olderThan, "older-than", 0, "Only delete resources created at least this long ago")
+
+	rootCmd.AddCommand(runCmd, cleanupCmd, newBurstCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -172,14 +191,9 @@ func runLoadGen(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create Kubernetes client
-	cfg, err := config.GetConfig()
+	k8sClient, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to get kubeconfig: %w", err)
-	}
-
-	k8sClient, err := client.New(cfg, client.Options{})
-	if err != nil {
-		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		return err
 	}
 
 	// Create output directory
@@ -229,6 +243,26 @@ func runLoadGen(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newClient returns a client for the cluster of the current kubeconfig
+// that knows the virtrigaud kinds
+func newClient() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := infrav1beta1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to register virtrigaud types: %w", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return k8sClient, nil
+}
+
 func (lg *LoadGenerator) run(ctx context.Context) error {
 	var wg sync.WaitGroup
 
@@ -288,11 +322,17 @@ func (lg *LoadGenerator) performCreate(ctx context.Context, provider string, wor
 	startTime := time.Now()
 	vmName := lg.generateVMName(workerID)
 
+	labels := make(map[string]string, len(lg.config.VMTemplate.Labels)+1)
+	for k, v := range lg.config.VMTemplate.Labels {
+		labels[k] = v
+	}
+	labels[loadgenLabel] = loadgenLabelValue
+
 	vm := &infrav1beta1.VirtualMachine{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      vmName,
 			Namespace: lg.namespace,
-			Labels:    lg.config.VMTemplate.Labels,
+			Labels:    labels,
 		},
 		Spec: infrav1beta1.VirtualMachineSpec{
 			ProviderRef: infrav1beta1.ObjectRef{Name: provider},
@@ -651,7 +691,7 @@ func getDefaultConfig() LoadGenConfig {
 			ClassRef: "test-class",
 			ImageRef: "test-image",
 			Labels: map[string]string{
-				"generated-by": "loadgen",
+				loadgenLabel: loadgenLabelValue,
 			},
 		},
 		Operations: OperationMix{
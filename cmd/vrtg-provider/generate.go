@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/projectbeskar/virtrigaud/internal/scaffold"
 )
 
 // generateOptions holds options for the generate command.
@@ -53,9 +56,92 @@ The command must be run from within a provider project directory.`,
 	cmd.Flags().BoolVar(&opts.protoOnly, "proto-only", false, "Only regenerate protocol buffer bindings")
 	cmd.Flags().BoolVar(&opts.clean, "clean", false, "Clean generated files before regenerating")
 
+	cmd.AddCommand(
+		newGenerateCapabilityCommand(),
+		newGenerateCRDExamplesCommand(),
+	)
+
+	return cmd
+}
+
+// newGenerateCapabilityCommand creates the generate capability command.
+func newGenerateCapabilityCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "capability <name>",
+		Short: "Add an optional capability to the provider",
+		Long: `Add an optional capability to a scaffolded provider project.
+
+This command:
+- Inserts an Unimplemented stub for each of the capability's RPCs the
+  provider does not define yet, above the rpcs marker in provider.go
+- Advertises the capability above the capabilities marker in capabilities.go
+- Writes test/conformance/<name>.yaml, a conformance test requiring it
+
+Capabilities it depends on are added first. Running it again changes
+nothing. Known capabilities: ` + strings.Join(scaffold.CapabilityNames(), ", ") + `.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateScaffold(force, func(s *scaffold.Scaffolder) ([]string, error) {
+				return s.AddCapability(args[0])
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing conformance test")
+
+	return cmd
+}
+
+// newGenerateCRDExamplesCommand creates the generate crd-examples command.
+func newGenerateCRDExamplesCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "crd-examples",
+		Short: "Generate example manifests for the provider",
+		Long: `Generate example Provider, VMClass, VMImage and VMNetworkAttachment
+manifests under config/examples, tailored to the disk and network types the
+provider declares in capabilities.go.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateScaffold(force, (*scaffold.Scaffolder).GenerateCRDExamples)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing examples")
+
 	return cmd
 }
 
+// runGenerateScaffold runs generate on the provider project in the current
+// directory and lists the files it wrote.
+func runGenerateScaffold(force bool, generate func(*scaffold.Scaffolder) ([]string, error)) error {
+	if err := checkProviderProject(); err != nil {
+		return fmt.Errorf("not in a provider project directory: %w", err)
+	}
+
+	config, err := scaffold.LoadConfig(".")
+	if err != nil {
+		return err
+	}
+	config.Force = force
+
+	written, err := generate(scaffold.New(config))
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 {
+		fmt.Println("✅ Already up to date")
+		return nil
+	}
+	for _, file := range written {
+		fmt.Printf("📝 %s\n", file)
+	}
+	return nil
+}
+
 // runGenerate executes the generate command.
 func runGenerate(opts *generateOptions) error {
 	// Check if we're in a provider project directory
@@ -106,7 +192,7 @@ func checkProviderProject() error {
 		return fmt.Errorf("failed to read go.mod: %w", err)
 	}
 
-	if !contains([]string{string(content)}, "github.com/projectbeskar/virtrigaud") {
+	if !strings.Contains(string(content), "github.com/projectbeskar/virtrigaud") {
 		return fmt.Errorf("go.mod does not contain virtrigaud dependency")
 	}
 
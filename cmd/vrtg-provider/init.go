@@ -34,6 +34,9 @@ type initOptions struct {
 	providerType string
 	remote       bool
 	force        bool
+
+	sdkVersion     string
+	virtrigaudPath string
 }
 
 // newInitCommand creates the init command.
@@ -50,7 +53,8 @@ This command creates a complete provider project structure including:
 - Dockerfile for containerization
 - Makefile with build and test targets
 - GitHub Actions CI workflow
-- Example provider implementation
+- A provider stub implementing every RPC as Unimplemented, with tests
+- An in-memory fake (--fake) the conformance suite can run against
 - Deployment manifests for Kubernetes
 
 Examples:
@@ -61,7 +65,10 @@ Examples:
   vrtg-provider init myprovider --remote
 
   # Create in a specific directory
-  vrtg-provider init myprovider --output ./providers/`,
+  vrtg-provider init myprovider --output ./providers/
+
+  # Build against a local virtrigaud checkout instead of a released SDK
+  vrtg-provider init myprovider --virtrigaud-path ~/src/virtrigaud`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.providerName = args[0]
@@ -73,6 +80,8 @@ Examples:
 	cmd.Flags().StringVarP(&opts.providerType, "type", "t", "generic", "Provider type (vsphere, libvirt, firecracker, qemu, generic)")
 	cmd.Flags().BoolVar(&opts.remote, "remote", false, "Generate remote runtime configuration")
 	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite existing files")
+	cmd.Flags().StringVar(&opts.sdkVersion, "sdk-version", scaffold.DefaultSDKVersion, "Version of the virtrigaud SDK the project requires")
+	cmd.Flags().StringVar(&opts.virtrigaudPath, "virtrigaud-path", "", "Local virtrigaud checkout to replace the SDK modules with")
 
 	return cmd
 }
@@ -103,13 +112,25 @@ func runInit(opts *initOptions) error {
 		}
 	}
 
+	// go.mod replace directives are resolved relative to the project
+	virtrigaudPath := opts.virtrigaudPath
+	if virtrigaudPath != "" {
+		abs, err := filepath.Abs(virtrigaudPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", virtrigaudPath, err)
+		}
+		virtrigaudPath = abs
+	}
+
 	// Create scaffolder
 	scaffolder := scaffold.New(scaffold.Config{
-		ProviderName: opts.providerName,
-		ProviderType: opts.providerType,
-		TargetDir:    targetDir,
-		Remote:       opts.remote,
-		Force:        opts.force,
+		ProviderName:   opts.providerName,
+		ProviderType:   opts.providerType,
+		TargetDir:      targetDir,
+		Remote:         opts.remote,
+		Force:          opts.force,
+		SDKVersion:     opts.sdkVersion,
+		VirtrigaudPath: virtrigaudPath,
 	})
 
 	// Generate project structure
@@ -121,6 +142,7 @@ func runInit(opts *initOptions) error {
 	fmt.Printf("📁 Project created in: %s\n", targetDir)
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  cd %s\n", targetDir)
+	fmt.Printf("  go mod tidy\n")
 	fmt.Printf("  make build\n")
 	fmt.Printf("  make test\n")
 	fmt.Printf("  vrtg-provider verify\n")
@@ -70,6 +70,11 @@ type CatalogProvider struct {
 	Maturity      string             `yaml:"maturity"`
 	Tags          []string           `yaml:"tags,omitempty"`
 	Documentation string             `yaml:"documentation,omitempty"`
+
+	// CapabilitySince maps each capability to the oldest image tag that
+	// provides it. Publishing keeps the tags already recorded and stamps
+	// newly added capabilities with the tag being published.
+	CapabilitySince map[string]string `yaml:"capabilitySince,omitempty"`
 }
 
 // ConformanceResults holds conformance test results.
@@ -292,7 +297,7 @@ func createCatalogEntry(opts *publishOptions, results ConformanceResults) Catalo
 	displayName := caser.String(strings.ReplaceAll(opts.providerName, "-", " ")) + " Provider"
 	description := fmt.Sprintf("%s provider for VirtRigaud", caser.String(opts.providerName))
 
-	return CatalogProvider{
+	entry := CatalogProvider{
 		Name:         opts.providerName,
 		DisplayName:  displayName,
 		Description:  description,
@@ -306,6 +311,27 @@ func createCatalogEntry(opts *publishOptions, results ConformanceResults) Catalo
 		Maturity:     "beta", // Default to beta for new providers
 		Tags:         []string{opts.providerName, "community"},
 	}
+	entry.CapabilitySince = capabilitySince(CatalogProvider{}, entry)
+	return entry
+}
+
+// capabilitySince returns the CapabilitySince map for entry replacing
+// previous: a capability previous already had keeps its recorded tag, and
+// one it did not gets entry.Tag. Capabilities entry dropped are forgotten,
+// so one that comes back is stamped with the tag that restores it.
+func capabilitySince(previous, entry CatalogProvider) map[string]string {
+	if len(entry.Capabilities) == 0 {
+		return nil
+	}
+	since := make(map[string]string, len(entry.Capabilities))
+	for _, name := range entry.Capabilities {
+		if tag, ok := previous.CapabilitySince[name]; ok {
+			since[name] = tag
+		} else {
+			since[name] = entry.Tag
+		}
+	}
+	return since
 }
 
 // printCatalogEntry prints a catalog entry in YAML format.
@@ -369,6 +395,7 @@ func updateCatalog(catalogPath string, entry CatalogProvider) error {
 	found := false
 	for i, provider := range catalog.Providers {
 		if provider.Name == entry.Name {
+			entry.CapabilitySince = capabilitySince(provider, entry)
 			catalog.Providers[i] = entry
 			found = true
 			break
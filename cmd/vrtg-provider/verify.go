@@ -17,13 +17,29 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"net"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/server"
 )
 
+// capabilitiesCheckTimeout bounds how long verify waits for the built
+// provider to start and answer GetCapabilities.
+const capabilitiesCheckTimeout = 30 * time.Second
+
 // verifyOptions holds options for the verify command.
 type verifyOptions struct {
 	skipBuild       bool
@@ -45,6 +61,7 @@ This command performs the following checks:
 - Code compilation and build verification
 - Unit tests for provider implementation
 - Linting and code quality checks
+- Startup check: the built binary, run with --fake, answers GetCapabilities
 - VCTS conformance tests (if applicable)
 - Integration tests with mock hypervisor
 
@@ -99,7 +116,16 @@ func runVerify(opts *verifyOptions) error {
 		fmt.Println("✅ Unit tests passed")
 	}
 
-	// Step 4: Conformance tests
+	// Step 4: Start the provider and query it
+	if !opts.skipBuild {
+		fmt.Println("\n📡 Checking GetCapabilities...")
+		if err := verifyCapabilities(); err != nil {
+			return fmt.Errorf("startup check failed: %w", err)
+		}
+		fmt.Println("✅ Provider answers GetCapabilities")
+	}
+
+	// Step 5: Conformance tests
 	if !opts.skipConformance {
 		fmt.Println("\n🎯 Running conformance tests...")
 		if err := verifyConformance(opts.profile); err != nil {
@@ -110,7 +136,7 @@ func runVerify(opts *verifyOptions) error {
 		}
 	}
 
-	// Step 5: Final verification summary
+	// Step 6: Final verification summary
 	fmt.Println("\n📊 Verification Summary:")
 	fmt.Println("✅ Provider builds successfully")
 	if !opts.skipTests {
@@ -183,6 +209,72 @@ func verifyUnitTests() error {
 	return runCommand("go", "test", "-v", "./...")
 }
 
+// verifyCapabilities builds the provider, starts it in fake mode on free
+// ports and checks that it answers GetCapabilities, catching providers that
+// compile but fail at startup or never register the Provider service.
+func verifyCapabilities() error {
+	dir, err := os.MkdirTemp("", "vrtg-provider-verify-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // Best-effort cleanup of a temp dir
+
+	bin := filepath.Join(dir, "provider")
+	if err := runCommand("go", "build", "-o", bin, "."); err != nil {
+		return fmt.Errorf("failed to build provider binary: %w", err)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	healthPort, err := freePort()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), capabilitiesCheckTimeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin, "--fake", "--port", strconv.Itoa(port), "--health-port", strconv.Itoa(healthPort))
+	cmd.Env = append(os.Environ(), server.EnvInsecure+"=true")
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start provider: %w", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	conn, err := grpc.NewClient(net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck // Connection close in defer is not critical
+
+	// WaitForReady rides out the time the provider takes to start listening.
+	_, err = providerv1.NewProviderClient(conn).GetCapabilities(ctx, &providerv1.GetCapabilitiesRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return fmt.Errorf("GetCapabilities failed: %w\nprovider output:\n%s", err, output.String())
+	}
+	return nil
+}
+
+// freePort returns a TCP port on the loopback interface that was free a
+// moment ago.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close() //nolint:errcheck // Listener only reserved the port
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
 // verifyConformance runs conformance tests.
 func verifyConformance(profile string) error {
 	// Check if VCTS is available
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/projectbeskar/virtrigaud/internal/storagemigration"
+)
+
+func newAdminCmd() *cobra.Command {
+	adminCmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Cluster administration tasks",
+	}
+	adminCmd.AddCommand(newMigrateStorageCmd(), newExportStateCmd(), newImportStateCmd(), newDeprecationsCmd(), newPreflightCmd())
+	return adminCmd
+}
+
+func newMigrateStorageCmd() *cobra.Command {
+	var opts storagemigration.Options
+	cmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Rewrite resources stored in old API versions",
+		Long: `Find virtrigaud CRDs whose status.storedVersions lists versions other than
+the current storage version, rewrite every object of those CRDs so the API
+server persists it at the storage version, and then drop the old versions
+from storedVersions.
+
+Run this after upgrading to a release that changes a storage version, and
+before upgrading to one that stops serving the old version. Objects are
+rewritten with no-op updates at a limited rate; the command is safe to
+re-run, and storedVersions is only changed once every object of a CRD has
+been rewritten.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			c, err := getAdminClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			opts.Out = cmd.OutOrStdout()
+			results, err := storagemigration.New(c, opts).Run(ctx)
+			printStorageMigrationSummary(cmd, results, opts.DryRun)
+			return err
+		},
+	}
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report the objects that would be rewritten without changing anything")
+	cmd.Flags().Float32Var(&opts.QPS, "qps", storagemigration.DefaultQPS, "Maximum object updates per second")
+	cmd.Flags().Int64Var(&opts.PageSize, "page-size", storagemigration.DefaultPageSize, "Objects fetched per list request")
+	cmd.Flags().StringVar(&opts.Group, "group", storagemigration.DefaultGroup, "API group whose CRDs are migrated")
+	return cmd
+}
+
+func printStorageMigrationSummary(cmd *cobra.Command, results []storagemigration.Result, dryRun bool) {
+	if len(results) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	done := "MIGRATED"
+	if dryRun {
+		done = "TO MIGRATE"
+	}
+	_, _ = fmt.Fprintf(w, "\nKIND\tSTORAGE\tSTALE\tOBJECTS\t%s\tFAILED\n", done)
+	for _, r := range results {
+		stale, objects, migrated := "-", "-", "-"
+		if r.NeedsMigration() {
+			stale = strings.Join(r.StaleVersions, ",")
+			objects = fmt.Sprint(r.Objects)
+			migrated = fmt.Sprint(r.Migrated)
+			if dryRun {
+				migrated = objects
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", r.Kind, r.StorageVersion, stale, objects, migrated, r.Failed)
+	}
+}
+
+// getAdminClient returns a client that can read CRDs and core resources and
+// handle any virtrigaud resource as unstructured, whichever version it is
+// served at.
+func getAdminClient() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
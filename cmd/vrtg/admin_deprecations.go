@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func newDeprecationsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deprecations",
+		Short: "Report resources using fields deprecated for v1",
+		Long: `List every virtrigaud resource in the cluster, across all namespaces, that
+uses a v1beta1 field v1 renames or removes, such as a network selected by
+name alone or inline cloud-init user data, with what to use instead.
+
+The fields checked are the ones the deprecation webhook warns about and
+virtrigaud_deprecated_field_usage_total counts, so the report also covers
+resources created before the webhook was enabled.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			c, err := getClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			findings, err := findDeprecations(ctx, c)
+			if err != nil {
+				return err
+			}
+			printDeprecationReport(cmd.OutOrStdout(), findings)
+			return nil
+		},
+	}
+}
+
+// deprecationFinding is a use of a deprecated field by one resource.
+type deprecationFinding struct {
+	kind      string
+	namespace string
+	name      string
+	use       infrav1beta1.DeprecationUse
+}
+
+// findDeprecations lists the resources of every kind with deprecated fields
+// and returns their uses of those fields, by kind and then in list order.
+func findDeprecations(ctx context.Context, c client.Client) ([]deprecationFinding, error) {
+	var findings []deprecationFinding
+	for _, kind := range infrav1beta1.DeprecatedKinds() {
+		obj, err := c.Scheme().New(infrav1beta1.GroupVersion.WithKind(kind + "List"))
+		if err != nil {
+			return nil, err
+		}
+		list, ok := obj.(client.ObjectList)
+		if !ok {
+			return nil, fmt.Errorf("%sList is not a list", kind)
+		}
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list %s resources: %w", kind, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			for _, use := range infrav1beta1.FindDeprecations(item) {
+				findings = append(findings, deprecationFinding{
+					kind: kind, namespace: o.GetNamespace(), name: o.GetName(), use: use,
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+func printDeprecationReport(w io.Writer, findings []deprecationFinding) {
+	if len(findings) == 0 {
+		_, _ = fmt.Fprintln(w, "No resources use deprecated fields.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KIND\tNAMESPACE\tNAME\tFIELD\tREPLACEMENT")
+	resources := map[string]bool{}
+	for _, f := range findings {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", f.kind, f.namespace, f.name, f.use.Path, f.use.Replacement)
+		resources[f.kind+"/"+f.namespace+"/"+f.name] = true
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d resources use deprecated fields (%d uses)\n", len(resources), len(findings))
+}
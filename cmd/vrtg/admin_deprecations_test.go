@@ -0,0 +1,63 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func TestFindDeprecations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	meta := func(ns, name string) metav1.ObjectMeta { return metav1.ObjectMeta{Namespace: ns, Name: name} }
+
+	legacy := &infrav1beta1.VirtualMachine{ObjectMeta: meta("team-a", "legacy")}
+	legacy.Spec.Networks = []infrav1beta1.VMNetworkRef{{Name: "vmbr0"}}
+	legacy.Spec.UserData = &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{Inline: "#cloud-config\n"}}
+	current := &infrav1beta1.VirtualMachine{ObjectMeta: meta("team-a", "current")}
+	current.Spec.Networks = []infrav1beta1.VMNetworkRef{{Name: "lan", NetworkRef: &infrav1beta1.ObjectRef{Name: "lan"}}}
+	provider := &infrav1beta1.Provider{ObjectMeta: meta("infra", "pve")}
+	provider.Spec.InsecureSkipVerify = true
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(legacy, current, provider).Build()
+	findings, err := findDeprecations(context.Background(), c)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printDeprecationReport(&out, findings)
+	assert.Equal(t, `KIND            NAMESPACE  NAME    FIELD                           REPLACEMENT
+Provider        infra      pve     spec.insecureSkipVerify         set spec.runtime.service.tls.insecureSkipVerify instead
+VirtualMachine  team-a     legacy  spec.networks[0].name           set networkRef to a VMNetworkAttachment with a binding for the provider
+VirtualMachine  team-a     legacy  spec.userData.cloudInit.inline  move it into a Secret and set spec.userData.cloudInit.secretRef
+
+2 resources use deprecated fields (3 uses)
+`, out.String())
+
+	out.Reset()
+	printDeprecationReport(&out, nil)
+	assert.Equal(t, "No resources use deprecated fields.\n", out.String())
+}
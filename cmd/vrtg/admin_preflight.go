@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectbeskar/virtrigaud/internal/preflight"
+)
+
+func newPreflightCmd() *cobra.Command {
+	var (
+		targetVersion string
+		target        preflight.Target
+	)
+	cmd := &cobra.Command{
+		Use:   "preflight --target-version vX.Y.Z",
+		Short: "Check that the cluster is ready to upgrade to a release",
+		Long: `Check the cluster against the release named by --target-version before
+upgrading to it:
+
+  schemas       every stored virtrigaud object validates against the
+                release's CRD schemas, and no stored version or CRD it drops
+                is still in use
+  providers     every provider's image speaks a protocol version the
+                release's manager accepts
+  deprecations  no resource uses a deprecated field the release removes
+  webhooks      the certificate source is in place, with --webhooks
+  finalizers    no object carries a virtrigaud finalizer that no controller
+                of the release removes
+
+Each finding is pass, warn or fail. The command exits non-zero when any
+check fails, so it can gate an upgrade in CI.
+
+The CRD schemas checked are the ones bundled into this vrtg binary, so run
+the vrtg from the release being upgraded to.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if err := checkBundledVersion(cmd.ErrOrStderr(), targetVersion); err != nil {
+				return err
+			}
+			bundled, err := preflight.Bundled(targetVersion)
+			if err != nil {
+				return fmt.Errorf("failed to load the bundled CRDs: %w", err)
+			}
+			bundled.Webhooks = target.Webhooks
+			bundled.WebhookCertSource = target.WebhookCertSource
+			bundled.WebhookCertSecret = target.WebhookCertSecret
+			bundled.Namespace = target.Namespace
+
+			c, err := getAdminClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			report, err := preflight.New(c, bundled).Run(ctx)
+			if err != nil {
+				return err
+			}
+			if err := printPreflightReport(cmd.OutOrStdout(), report, output); err != nil {
+				return err
+			}
+			if report.Failed() {
+				return fmt.Errorf("%d preflight checks failed", report.Count(preflight.StatusFail))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetVersion, "target-version", "", "Release to check the cluster against (required)")
+	cmd.Flags().BoolVar(&target.Webhooks, "webhooks", false, "The upgrade enables the admission webhooks")
+	cmd.Flags().StringVar(&target.WebhookCertSource, "webhook-cert-source", preflight.CertSourceSelfSigned, "Webhook certificate source (cert-manager|self-signed|manual)")
+	cmd.Flags().StringVar(&target.WebhookCertSecret, "webhook-cert-secret", "virtrigaud-webhook-certs", "Secret holding a manual webhook certificate")
+	cmd.Flags().StringVar(&target.Namespace, "manager-namespace", "virtrigaud-system", "Namespace the manager runs in")
+	_ = cmd.MarkFlagRequired("target-version")
+	return cmd
+}
+
+// checkBundledVersion refuses a target other than the release this vrtg
+// was built from, whose CRDs are the only ones it carries. A development
+// build checks against its own CRDs whatever the target, with a warning.
+func checkBundledVersion(stderr io.Writer, targetVersion string) error {
+	if version == "dev" {
+		_, _ = fmt.Fprintf(stderr, "Warning: this is a development build of vrtg; checking against its own CRDs as %s\n", targetVersion)
+		return nil
+	}
+	if strings.TrimPrefix(version, "v") != strings.TrimPrefix(targetVersion, "v") {
+		return fmt.Errorf("this vrtg carries the CRDs of %s; run the preflight with the vrtg from %s", version, targetVersion)
+	}
+	return nil
+}
+
+func printPreflightReport(w io.Writer, report *preflight.Report, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CATEGORY\tSTATUS\tOBJECT\tMESSAGE")
+	for _, r := range report.Results {
+		object := r.Object
+		if object == "" {
+			object = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Category, strings.ToUpper(string(r.Status)), object, r.Message)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\nPreflight for %s: %d passed, %d warnings, %d failed\n", report.TargetVersion,
+		report.Count(preflight.StatusPass), report.Count(preflight.StatusWarn), report.Count(preflight.StatusFail))
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/preflight"
+)
+
+func TestPrintPreflightReport(t *testing.T) {
+	report := &preflight.Report{TargetVersion: "v0.9.0", Results: []preflight.Result{
+		{Category: preflight.CategorySchemas, Status: preflight.StatusPass, Message: "3 stored objects validate against the v0.9.0 schemas"},
+		{Category: preflight.CategoryProviders, Status: preflight.StatusFail, Object: "Provider infra/pve", Message: "upgrade the provider"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, printPreflightReport(&out, report, "table"))
+	assert.Equal(t, `CATEGORY   STATUS  OBJECT              MESSAGE
+schemas    PASS    -                   3 stored objects validate against the v0.9.0 schemas
+providers  FAIL    Provider infra/pve  upgrade the provider
+
+Preflight for v0.9.0: 1 passed, 0 warnings, 1 failed
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, printPreflightReport(&out, report, "json"))
+	assert.Contains(t, out.String(), `"status": "fail"`)
+}
+
+func TestCheckBundledVersion(t *testing.T) {
+	defer func(v string) { version = v }(version)
+
+	var stderr bytes.Buffer
+	version = "dev"
+	require.NoError(t, checkBundledVersion(&stderr, "v0.9.0"))
+	assert.Contains(t, stderr.String(), "development build")
+
+	version = "v0.9.0"
+	assert.NoError(t, checkBundledVersion(&stderr, "0.9.0"))
+	assert.ErrorContains(t, checkBundledVersion(&stderr, "v1.0.0"), "run the preflight with the vrtg from v1.0.0")
+}
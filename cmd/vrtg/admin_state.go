@@ -0,0 +1,241 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+	"github.com/projectbeskar/virtrigaud/internal/statebundle"
+)
+
+func newExportStateCmd() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "export-state",
+		Short: "Export all virtrigaud resources to a portable bundle",
+		Long: `Write every VMClass, VMImage, VMNetworkAttachment, VMPlacementPolicy,
+VirtrigaudQuota, VirtualMachine, VMSet and Provider in the cluster, across
+all namespaces, to a YAML bundle that 'vrtg admin import-state' can restore
+into a new cluster.
+
+Status is not exported. Each VirtualMachine's status.id and Provider are
+recorded as annotations instead, so that the import reattaches to the
+existing hypervisor VMs. Secrets the resources reference, such as provider
+credentials, are not part of the bundle and must be restored separately.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			c, err := getClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			objs, err := statebundle.Export(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			out, summary := cmd.OutOrStdout(), cmd.ErrOrStderr()
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = f.Close() }()
+				out, summary = f, cmd.OutOrStdout()
+			}
+			if err := statebundle.Write(out, objs); err != nil {
+				return err
+			}
+			printExportSummary(summary, objs)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Write the bundle to this file instead of stdout")
+	return cmd
+}
+
+func printExportSummary(w io.Writer, objs []*unstructured.Unstructured) {
+	counts := map[string]int{}
+	bound := 0
+	for _, obj := range objs {
+		counts[obj.GetKind()]++
+		if obj.GetAnnotations()[statebundle.ProviderIDAnnotation] != "" {
+			bound++
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+	_, _ = fmt.Fprintln(tw, "KIND\tEXPORTED")
+	for _, kind := range statebundle.Kinds {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\n", kind, counts[kind])
+	}
+	_, _ = fmt.Fprintf(tw, "\n%d VirtualMachines recorded with a provider ID\n", bound)
+}
+
+func newImportStateCmd() *cobra.Command {
+	var (
+		file       string
+		remapFile  string
+		dryRun     bool
+		skipVerify bool
+		verifyOnly bool
+	)
+	cmd := &cobra.Command{
+		Use:   "import-state",
+		Short: "Recreate virtrigaud resources from an exported bundle",
+		Long: `Create the resources of a bundle written by 'vrtg admin export-state'.
+VirtualMachines exported with a provider ID are created with
+spec.adoptExisting, so the controller reattaches to the existing hypervisor
+VM instead of creating a new one. Objects that already exist are left
+untouched. Namespaces and referenced Secrets must exist beforehand.
+
+--remap names a YAML file for Providers renamed, or VMs whose IDs changed,
+between the clusters:
+
+  providers:
+    default/vsphere-prod: dr/vsphere-dr
+  ids:
+    default/vsphere-prod:
+      vm-1042: vm-2187
+
+After the import, each adopted ID is described on its Provider and VMs that
+are missing, or whose VirtualMachine is bound elsewhere, are reported. The
+verification changes nothing; it needs the providers to be running and
+reachable, and can be repeated later with --verify-only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			objs, err := statebundle.Read(f)
+			_ = f.Close()
+			if err != nil {
+				return err
+			}
+			var remap *statebundle.Remap
+			if remapFile != "" {
+				if remap, err = statebundle.LoadRemap(remapFile); err != nil {
+					return err
+				}
+			}
+			objs, err = statebundle.Prepare(objs, remap)
+			if err != nil {
+				return err
+			}
+
+			c, err := getClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			if !verifyOnly {
+				results, err := statebundle.Import(ctx, c, objs, statebundle.ImportOptions{DryRun: dryRun})
+				failed := printImportSummary(cmd.OutOrStdout(), results, dryRun)
+				if err != nil {
+					return err
+				}
+				if failed > 0 {
+					return fmt.Errorf("%d objects could not be imported", failed)
+				}
+			}
+			if skipVerify {
+				return nil
+			}
+
+			results, err := statebundle.Verify(ctx, c, remote.NewResolver(c, nil), objs)
+			if err != nil {
+				return err
+			}
+			if bad := printVerifySummary(cmd.OutOrStdout(), results); bad > 0 {
+				return fmt.Errorf("%d VirtualMachines failed verification", bad)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Bundle written by export-state")
+	cmd.Flags().StringVar(&remapFile, "remap", "", "YAML file mapping exported Providers and VM IDs to new ones")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the creates with the API server without persisting them")
+	cmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "Do not describe the adopted VMs after importing")
+	cmd.Flags().BoolVar(&verifyOnly, "verify-only", false, "Only verify the bundle's adopted VMs against the cluster and providers")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.MarkFlagsMutuallyExclusive("skip-verify", "verify-only")
+	return cmd
+}
+
+// printImportSummary prints one line per object and returns how many
+// failed.
+func printImportSummary(w io.Writer, results []statebundle.ImportResult, dryRun bool) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	failed := 0
+	header := "RESULT"
+	if dryRun {
+		header = "RESULT (DRY RUN)"
+	}
+	_, _ = fmt.Fprintf(tw, "KIND\tNAMESPACE\tNAME\t%s\tADOPT-ID\tERROR\n", header)
+	for _, r := range results {
+		adoptID, errMsg := r.AdoptID, ""
+		if adoptID == "" {
+			adoptID = "-"
+		}
+		if r.Err != nil {
+			failed++
+			errMsg = r.Err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Kind, r.Namespace, r.Name, r.Action, adoptID, errMsg)
+	}
+	return failed
+}
+
+// printVerifySummary prints one line per adopting VM and returns how many
+// did not verify OK.
+func printVerifySummary(w io.Writer, results []statebundle.VerifyResult) int {
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(w, "\nNo VirtualMachines adopt existing VMs; nothing to verify")
+		return 0
+	}
+	_, _ = fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	bad := 0
+	_, _ = fmt.Fprintln(tw, "NAMESPACE\tNAME\tPROVIDER\tID\tVERIFY\tDETAILS")
+	for _, r := range results {
+		if r.Status != statebundle.VerifyOK {
+			bad++
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Namespace, r.Name, r.Provider, r.ID, r.Status, r.Message)
+	}
+	return bad
+}
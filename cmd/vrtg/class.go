@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+)
+
+var (
+	classCheckProvider string
+	classCheckImage    string
+)
+
+func newClassCmd() *cobra.Command {
+	classCmd := &cobra.Command{
+		Use:     "class",
+		Aliases: []string{"classes", "vmclass"},
+		Short:   "Manage VM classes",
+	}
+
+	checkCmd := &cobra.Command{
+		Use:   "check <class> --provider <provider>",
+		Short: "Check which fields of a class a provider honors",
+		Long: `List the fields of a VMClass, and of a VMImage with --image, that the
+provider ignores or cannot create a VM with. The class is checked with its
+inheritFrom chain resolved, and against the capabilities the provider
+reported.
+
+These are the fields the ProviderFieldsSupported condition of a
+VirtualMachine lists. The command fails when a field would make the create
+fail.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeClassNames,
+		RunE:              checkClass,
+	}
+	checkCmd.Flags().StringVar(&classCheckProvider, "provider", "", "Provider to check the class against")
+	checkCmd.Flags().StringVar(&classCheckImage, "image", "", "VMImage to check along with the class")
+	_ = checkCmd.MarkFlagRequired("provider")
+	_ = checkCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+	_ = checkCmd.RegisterFlagCompletionFunc("image", completeImageNames)
+
+	classCmd.AddCommand(checkCmd)
+	return classCmd
+}
+
+func checkClass(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	provider := &infrav1beta1.Provider{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: classCheckProvider}, provider); err != nil {
+		return fmt.Errorf("failed to get provider %s: %w", classCheckProvider, err)
+	}
+	class, err := vmclass.Get(ctx, c, client.ObjectKey{Namespace: namespace, Name: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to get VMClass %s: %w", args[0], err)
+	}
+	var image *infrav1beta1.VMImageSpec
+	if classCheckImage != "" {
+		vmImage := &infrav1beta1.VMImage{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: classCheckImage}, vmImage); err != nil {
+			return fmt.Errorf("failed to get VMImage %s: %w", classCheckImage, err)
+		}
+		image = &vmImage.Spec
+	}
+
+	issues := capability.CheckProvider(&class.Spec, image, provider)
+	printPortabilityReport(cmd.OutOrStdout(), provider, issues)
+	if capability.HasUnsupported(issues) {
+		return fmt.Errorf("provider %s cannot create VMs from class %s", provider.Name, class.Name)
+	}
+	return nil
+}
+
+func printPortabilityReport(w io.Writer, provider *infrav1beta1.Provider, issues []capability.PortabilityIssue) {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintf(w, "Provider %s (%s) honors every field.\n", provider.Name, provider.Spec.Type)
+		return
+	}
+	if provider.Status.ReportedCapabilities == nil {
+		_, _ = fmt.Fprintf(w, "Provider %s has not reported its capabilities; checked by type (%s) only.\n\n",
+			provider.Name, provider.Spec.Type)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KIND\tFIELD\tSUPPORT\tDETAIL")
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", issue.Kind, issue.Field, issue.Support, issue.Message)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+)
+
+func TestPrintPortabilityReport(t *testing.T) {
+	provider := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeProxmox},
+		Status:     infrav1beta1.ProviderStatus{ReportedCapabilities: &infrav1beta1.ReportedCapabilities{}},
+	}
+	class := &infrav1beta1.VMClassSpec{
+		DiskDefaults: &infrav1beta1.DiskDefaults{Type: infrav1beta1.DiskTypeEagerZeroedThick},
+	}
+	image := &infrav1beta1.VMImageSpec{Source: infrav1beta1.ImageSource{
+		VSphere: &infrav1beta1.VSphereImageSource{TemplateName: "ubuntu"},
+	}}
+
+	var out bytes.Buffer
+	printPortabilityReport(&out, provider, capability.CheckProvider(class, image, provider))
+	assert.Equal(t, `KIND     FIELD                                             SUPPORT      DETAIL
+VMImage  spec.source                                       Unsupported  no source a proxmox provider can create VMs from (the image has spec.source.vsphere)
+VMClass  spec.diskDefaults.type (thick, eagerzeroedthick)  Ignored      ignored by proxmox providers
+`, out.String())
+
+	out.Reset()
+	printPortabilityReport(&out, provider, nil)
+	assert.Equal(t, "Provider pve (proxmox) honors every field.\n", out.String())
+}
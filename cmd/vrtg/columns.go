@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/cli/printers"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// The columns of each list command. Scripts parse this output, so a
+// column's header and rendering stay put once released; new columns go
+// at the end, or into the wide form.
+
+var (
+	noHeaders bool
+	sortBy    string
+)
+
+// addListFlags adds the --no-headers and --sort-by flags every list
+// command takes.
+func addListFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().BoolVar(&noHeaders, "no-headers", false, "Do not print the header row")
+	cmd.Flags().StringVar(&sortBy, "sort-by", "", "Sort rows by a JSONPath expression, e.g. .metadata.creationTimestamp")
+	return cmd
+}
+
+// listTableOptions reads the -o, --no-headers and --sort-by flags. It
+// reports false for the formats outputResource prints.
+func listTableOptions() (printers.TableOptions, bool, error) {
+	return printers.ParseTableOptions(output, noHeaders, sortBy)
+}
+
+// printList prints items as the list flags ask: as a table of columns, or
+// through outputResource as list.
+func printList[T any](w io.Writer, list any, items []T, columns []printers.Column[T]) error {
+	opts, table, err := listTableOptions()
+	if err != nil {
+		return err
+	}
+	if !table {
+		return outputResource(list)
+	}
+	return printers.PrintTable(w, items, columns, opts)
+}
+
+// itemPointers returns pointers to the elements of items, the form the
+// column definitions take.
+func itemPointers[T any](items []T) []*T {
+	ptrs := make([]*T, len(items))
+	for i := range items {
+		ptrs[i] = &items[i]
+	}
+	return ptrs
+}
+
+// vmColumns are the columns of vrtg vm list. providerTypes maps a
+// provider's namespace/name to its type, for the wide PROVIDER-TYPE
+// column.
+func vmColumns(now time.Time, providerTypes map[string]string) []printers.Column[*infrav1beta1.VirtualMachine] {
+	return []printers.Column[*infrav1beta1.VirtualMachine]{
+		{Header: "NAME", Value: func(vm *infrav1beta1.VirtualMachine) string { return vm.Name }},
+		{Header: "READY", Value: func(vm *infrav1beta1.VirtualMachine) string {
+			return printers.ConditionStatus(vm.Status.Conditions, infrav1beta1.VirtualMachineConditionReady)
+		}},
+		{Header: "STATUS", Value: func(vm *infrav1beta1.VirtualMachine) string { return string(vm.Status.Phase) }},
+		{Header: "PROVIDER", Value: func(vm *infrav1beta1.VirtualMachine) string { return vm.Spec.ProviderRef.Name }},
+		{Header: "CLASS", Value: func(vm *infrav1beta1.VirtualMachine) string { return vm.Spec.ClassRef.Name }},
+		{Header: "IMAGE", Value: func(vm *infrav1beta1.VirtualMachine) string {
+			if vm.Spec.ImageRef == nil {
+				return ""
+			}
+			return vm.Spec.ImageRef.Name
+		}},
+		{Header: "IPS", Value: func(vm *infrav1beta1.VirtualMachine) string { return strings.Join(vm.Status.IPs, ",") }},
+		{Header: "AGE", Value: func(vm *infrav1beta1.VirtualMachine) string { return printers.Age(vm.CreationTimestamp.Time, now) }},
+		{Header: "PROVIDER-TYPE", Wide: true, Value: func(vm *infrav1beta1.VirtualMachine) string {
+			return providerTypes[vmProviderKey(vm)]
+		}},
+		{Header: "POWER", Wide: true, Value: func(vm *infrav1beta1.VirtualMachine) string { return string(vm.Status.PowerState) }},
+		{Header: "HOST", Wide: true, Value: func(vm *infrav1beta1.VirtualMachine) string { return vm.Status.Host }},
+	}
+}
+
+// vmProviderKey is the namespace/name of the provider a VM runs on.
+func vmProviderKey(vm *infrav1beta1.VirtualMachine) string {
+	ns := vm.Spec.ProviderRef.Namespace
+	if ns == "" {
+		ns = vm.Namespace
+	}
+	return ns + "/" + vm.Spec.ProviderRef.Name
+}
+
+// providerColumns are the columns of vrtg provider list.
+func providerColumns(now time.Time) []printers.Column[*infrav1beta1.Provider] {
+	return []printers.Column[*infrav1beta1.Provider]{
+		{Header: "NAME", Value: func(p *infrav1beta1.Provider) string { return p.Name }},
+		{Header: "TYPE", Value: func(p *infrav1beta1.Provider) string { return string(p.Spec.Type) }},
+		{Header: "ENDPOINT", Value: func(p *infrav1beta1.Provider) string { return cmp.Or(p.Spec.Endpoint, p.Status.Endpoint) }},
+		{Header: "AGE", Value: func(p *infrav1beta1.Provider) string { return printers.Age(p.CreationTimestamp.Time, now) }},
+		{Header: "READY", Wide: true, Value: func(p *infrav1beta1.Provider) string {
+			return printers.ConditionStatus(p.Status.Conditions, "Ready")
+		}},
+		{Header: "RUNTIME", Wide: true, Value: func(p *infrav1beta1.Provider) string {
+			if p.Status.Runtime == nil {
+				return ""
+			}
+			return string(p.Status.Runtime.Phase)
+		}},
+		{Header: "VERSION", Wide: true, Value: func(p *infrav1beta1.Provider) string { return p.Status.Version }},
+	}
+}
+
+// snapshotColumns are the columns of vrtg snapshot list for VMSnapshot
+// resources.
+func snapshotColumns(now time.Time) []printers.Column[*infrav1beta1.VMSnapshot] {
+	return []printers.Column[*infrav1beta1.VMSnapshot]{
+		{Header: "NAME", Value: func(s *infrav1beta1.VMSnapshot) string { return s.Name }},
+		{Header: "VM", Value: func(s *infrav1beta1.VMSnapshot) string { return s.Spec.VMRef.Name }},
+		{Header: "PHASE", Value: func(s *infrav1beta1.VMSnapshot) string { return string(s.Status.Phase) }},
+		{Header: "SNAPSHOT-ID", Value: func(s *infrav1beta1.VMSnapshot) string { return s.Status.SnapshotID }},
+		{Header: "AGE", Value: func(s *infrav1beta1.VMSnapshot) string { return printers.Age(s.CreationTimestamp.Time, now) }},
+		{Header: "QUIESCE", Wide: true, Value: func(s *infrav1beta1.VMSnapshot) string { return string(s.Status.Quiesce) }},
+		{Header: "SIZE", Wide: true, Value: func(s *infrav1beta1.VMSnapshot) string {
+			if s.Status.Size == nil {
+				return ""
+			}
+			return s.Status.Size.String()
+		}},
+	}
+}
+
+// liveSnapshotColumns are the columns of vrtg snapshot list <vm>, for the
+// snapshots its provider reports.
+func liveSnapshotColumns() []printers.Column[contracts.SnapshotInfo] {
+	return []printers.Column[contracts.SnapshotInfo]{
+		{Header: "NAME", Value: func(s contracts.SnapshotInfo) string { return s.Name }},
+		{Header: "ID", Value: func(s contracts.SnapshotInfo) string { return s.ID }},
+		{Header: "PARENT", Value: func(s contracts.SnapshotInfo) string { return s.ParentID }},
+		{Header: "MEMORY", Value: func(s contracts.SnapshotInfo) string { return strconv.FormatBool(s.HasMemory) }},
+		{Header: "CREATED", Value: func(s contracts.SnapshotInfo) string {
+			if s.CreatedAt.IsZero() {
+				return "<unknown>"
+			}
+			return s.CreatedAt.Local().Format("2006-01-02 15:04:05")
+		}},
+		{Header: "DESCRIPTION", Value: func(s contracts.SnapshotInfo) string { return s.Description }},
+	}
+}
+
+// cloneColumns are the columns of vrtg clone list.
+func cloneColumns(now time.Time) []printers.Column[*infrav1beta1.VMClone] {
+	return []printers.Column[*infrav1beta1.VMClone]{
+		{Header: "NAME", Value: func(c *infrav1beta1.VMClone) string { return c.Name }},
+		{Header: "SOURCE", Value: cloneSource},
+		{Header: "TARGET", Value: func(c *infrav1beta1.VMClone) string { return c.Spec.Target.Name }},
+		{Header: "PHASE", Value: func(c *infrav1beta1.VMClone) string { return string(c.Status.Phase) }},
+		{Header: "AGE", Value: func(c *infrav1beta1.VMClone) string { return printers.Age(c.CreationTimestamp.Time, now) }},
+		{Header: "TYPE", Wide: true, Value: func(c *infrav1beta1.VMClone) string { return string(c.Status.ActualCloneType) }},
+		{Header: "PROGRESS", Wide: true, Value: func(c *infrav1beta1.VMClone) string {
+			if c.Status.Progress == nil || c.Status.Progress.OverallPercentage == nil {
+				return ""
+			}
+			return fmt.Sprintf("%d%%", *c.Status.Progress.OverallPercentage)
+		}},
+		{Header: "TARGET-ID", Wide: true, Value: func(c *infrav1beta1.VMClone) string { return c.Status.TargetVMID }},
+	}
+}
+
+// cloneSource names what a clone copies: "vm/web", "snapshot/nightly" or
+// "template/ubuntu".
+func cloneSource(c *infrav1beta1.VMClone) string {
+	src := c.Spec.Source
+	switch {
+	case src.VMRef != nil:
+		return "vm/" + src.VMRef.Name
+	case src.SnapshotRef != nil:
+		return "snapshot/" + src.SnapshotRef.Name
+	case src.TemplateRef != nil:
+		return "template/" + src.TemplateRef.Name
+	default:
+		return ""
+	}
+}
+
+// migrationColumns are the columns of vrtg migration list.
+func migrationColumns(now time.Time) []printers.Column[*infrav1beta1.VMMigration] {
+	return []printers.Column[*infrav1beta1.VMMigration]{
+		{Header: "NAME", Value: func(m *infrav1beta1.VMMigration) string { return m.Name }},
+		{Header: "SOURCE", Value: func(m *infrav1beta1.VMMigration) string { return m.Spec.Source.VMRef.Name }},
+		{Header: "TARGET", Value: func(m *infrav1beta1.VMMigration) string {
+			return m.Spec.Target.ProviderRef.Name + "/" + m.Spec.Target.Name
+		}},
+		{Header: "PHASE", Value: func(m *infrav1beta1.VMMigration) string { return string(m.Status.Phase) }},
+		{Header: "PROGRESS", Value: migrationPercentage},
+		{Header: "AGE", Value: func(m *infrav1beta1.VMMigration) string { return printers.Age(m.CreationTimestamp.Time, now) }},
+		{Header: "RETRIES", Wide: true, Value: func(m *infrav1beta1.VMMigration) string { return strconv.Itoa(int(m.Status.RetryCount)) }},
+		{Header: "SNAPSHOT", Wide: true, Value: func(m *infrav1beta1.VMMigration) string { return m.Status.SnapshotID }},
+	}
+}
+
+// imageColumns are the columns of vrtg image list.
+func imageColumns(now time.Time) []printers.Column[*infrav1beta1.VMImage] {
+	return []printers.Column[*infrav1beta1.VMImage]{
+		{Header: "NAME", Value: func(img *infrav1beta1.VMImage) string { return img.Name }},
+		{Header: "SOURCE", Value: imageSourceType},
+		{Header: "SIZE", Value: imageSize},
+		{Header: "PHASE", Value: func(img *infrav1beta1.VMImage) string { return string(img.Status.Phase) }},
+		{Header: "PROVIDERS", Value: imageProviders},
+		{Header: "AGE", Value: func(img *infrav1beta1.VMImage) string { return printers.Age(img.CreationTimestamp.Time, now) }},
+		{Header: "READY", Wide: true, Value: func(img *infrav1beta1.VMImage) string {
+			return printers.ConditionStatus(img.Status.Conditions, "Ready")
+		}},
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/cli/printers"
+)
+
+// The tests below pin the list output for fixed objects: scripts parse it,
+// so a change here is a change to vrtg's interface.
+
+var listNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func created(ago time.Duration) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: "default", CreationTimestamp: metav1.NewTime(listNow.Add(-ago))}
+}
+
+func renderList[T any](t *testing.T, output string, items []T, columns []printers.Column[T]) string {
+	t.Helper()
+	opts, ok, err := printers.ParseTableOptions(output, false, "")
+	require.NoError(t, err)
+	require.True(t, ok)
+	var buf bytes.Buffer
+	require.NoError(t, printers.PrintTable(&buf, items, columns, opts))
+	return buf.String()
+}
+
+func TestVMColumns(t *testing.T) {
+	web := &infrav1beta1.VirtualMachine{
+		ObjectMeta: created(4 * 24 * time.Hour),
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ProviderRef: infrav1beta1.ObjectRef{Name: "vsphere-prod"},
+			ClassRef:    infrav1beta1.ObjectRef{Name: "large"},
+			ImageRef:    &infrav1beta1.ObjectRef{Name: "ubuntu-24.04"},
+		},
+		Status: infrav1beta1.VirtualMachineStatus{
+			Phase:      infrav1beta1.VirtualMachinePhaseRunning,
+			PowerState: infrav1beta1.PowerStateOn,
+			IPs:        []string{"10.0.0.5", "fd00::5"},
+			Host:       "esx-03.lab",
+			Conditions: []metav1.Condition{{Type: infrav1beta1.VirtualMachineConditionReady, Status: metav1.ConditionTrue}},
+		},
+	}
+	web.Name = "web-frontend-production-eu-west-01"
+	db := &infrav1beta1.VirtualMachine{
+		ObjectMeta: created(90 * time.Second),
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ProviderRef: infrav1beta1.ObjectRef{Name: "pve", Namespace: "infra"},
+			ClassRef:    infrav1beta1.ObjectRef{Name: "small"},
+			ImageRef:    &infrav1beta1.ObjectRef{Name: "debian-12"},
+		},
+	}
+	db.Name = "db"
+	vms := []*infrav1beta1.VirtualMachine{web, db}
+	columns := vmColumns(listNow, map[string]string{"default/vsphere-prod": "vsphere", "infra/pve": "proxmox"})
+
+	assert.Equal(t, `NAME                                READY   STATUS   PROVIDER      CLASS  IMAGE         IPS               AGE
+web-frontend-production-eu-west-01  True    Running  vsphere-prod  large  ubuntu-24.04  10.0.0.5,fd00::5  4d
+db                                  <none>  <none>   pve           small  debian-12     <none>            90s
+`, renderList(t, "table", vms, columns))
+
+	assert.Equal(t, `NAME                                READY   STATUS   PROVIDER      CLASS  IMAGE         IPS               AGE  PROVIDER-TYPE  POWER   HOST
+web-frontend-production-eu-west-01  True    Running  vsphere-prod  large  ubuntu-24.04  10.0.0.5,fd00::5  4d   vsphere        On      esx-03.lab
+db                                  <none>  <none>   pve           small  debian-12     <none>            90s  proxmox        <none>  <none>
+`, renderList(t, "wide", vms, columns))
+
+	assert.Equal(t, `NAME                                IP
+web-frontend-production-eu-west-01  10.0.0.5
+db                                  <none>
+`, renderList(t, "custom-columns=NAME:.metadata.name,IP:.status.ips[0]", vms, columns))
+}
+
+func TestProviderColumns(t *testing.T) {
+	p := &infrav1beta1.Provider{
+		ObjectMeta: created(30 * 24 * time.Hour),
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeProxmox, Endpoint: "https://pve.lab:8006"},
+		Status: infrav1beta1.ProviderStatus{
+			Version:    "v0.4.0",
+			Runtime:    &infrav1beta1.ProviderRuntimeStatus{Phase: infrav1beta1.ProviderRuntimePhaseRunning},
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue}},
+		},
+	}
+	p.Name = "pve"
+	providers := []*infrav1beta1.Provider{p}
+
+	assert.Equal(t, `NAME  TYPE     ENDPOINT              AGE
+pve   proxmox  https://pve.lab:8006  30d
+`, renderList(t, "table", providers, providerColumns(listNow)))
+	assert.Equal(t, `NAME  TYPE     ENDPOINT              AGE  READY  RUNTIME  VERSION
+pve   proxmox  https://pve.lab:8006  30d  True   Running  v0.4.0
+`, renderList(t, "wide", providers, providerColumns(listNow)))
+}
+
+func TestSnapshotColumns(t *testing.T) {
+	s := &infrav1beta1.VMSnapshot{
+		ObjectMeta: created(2 * time.Hour),
+		Spec:       infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: "web"}},
+		Status: infrav1beta1.VMSnapshotStatus{
+			Phase:      "Ready",
+			SnapshotID: "snapshot-42",
+			Quiesce:    "Achieved",
+			Size:       ptr.To(resource.MustParse("512Mi")),
+		},
+	}
+	s.Name = "nightly"
+	snapshots := []*infrav1beta1.VMSnapshot{s}
+
+	assert.Equal(t, `NAME     VM   PHASE  SNAPSHOT-ID  AGE
+nightly  web  Ready  snapshot-42  120m
+`, renderList(t, "table", snapshots, snapshotColumns(listNow)))
+	assert.Equal(t, `NAME     VM   PHASE  SNAPSHOT-ID  AGE   QUIESCE   SIZE
+nightly  web  Ready  snapshot-42  120m  Achieved  512Mi
+`, renderList(t, "wide", snapshots, snapshotColumns(listNow)))
+}
+
+func TestCloneColumns(t *testing.T) {
+	c := &infrav1beta1.VMClone{
+		ObjectMeta: created(5 * time.Minute),
+		Spec: infrav1beta1.VMCloneSpec{
+			Source: infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "web"}},
+			Target: infrav1beta1.VMCloneTarget{Name: "web-copy"},
+		},
+		Status: infrav1beta1.VMCloneStatus{
+			Phase:           infrav1beta1.ClonePhaseCloning,
+			ActualCloneType: infrav1beta1.CloneTypeLinkedClone,
+			Progress:        &infrav1beta1.CloneProgress{OverallPercentage: ptr.To[int32](40)},
+		},
+	}
+	c.Name = "web-clone"
+	clones := []*infrav1beta1.VMClone{c}
+
+	assert.Equal(t, `NAME       SOURCE  TARGET    PHASE    AGE
+web-clone  vm/web  web-copy  Cloning  5m
+`, renderList(t, "table", clones, cloneColumns(listNow)))
+	assert.Equal(t, `NAME       SOURCE  TARGET    PHASE    AGE  TYPE         PROGRESS  TARGET-ID
+web-clone  vm/web  web-copy  Cloning  5m   LinkedClone  40%       <none>
+`, renderList(t, "wide", clones, cloneColumns(listNow)))
+}
+
+func TestMigrationColumns(t *testing.T) {
+	m := &infrav1beta1.VMMigration{
+		ObjectMeta: created(time.Hour),
+		Spec: infrav1beta1.VMMigrationSpec{
+			Source: infrav1beta1.MigrationSource{VMRef: infrav1beta1.LocalObjectReference{Name: "web"}},
+			Target: infrav1beta1.MigrationTarget{Name: "web", ProviderRef: infrav1beta1.ObjectRef{Name: "pve"}},
+		},
+		Status: infrav1beta1.VMMigrationStatus{
+			Phase:      infrav1beta1.MigrationPhaseTransferring,
+			RetryCount: 1,
+			SnapshotID: "migration-snap",
+		},
+	}
+	m.Name = "move-web"
+	migrations := []*infrav1beta1.VMMigration{m}
+
+	assert.Equal(t, `NAME      SOURCE  TARGET   PHASE         PROGRESS  AGE
+move-web  web     pve/web  Transferring  <none>    60m
+`, renderList(t, "table", migrations, migrationColumns(listNow)))
+	assert.Equal(t, `NAME      SOURCE  TARGET   PHASE         PROGRESS  AGE  RETRIES  SNAPSHOT
+move-web  web     pve/web  Transferring  <none>    60m  1        migration-snap
+`, renderList(t, "wide", migrations, migrationColumns(listNow)))
+}
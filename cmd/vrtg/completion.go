@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// completionTimeout bounds every cluster lookup made while completing a
+// command line, so an unreachable API server costs the shell a short pause
+// instead of a hang.
+const completionTimeout = 2 * time.Second
+
+// completionClient returns the client used for dynamic completions. Tests
+// replace it with a fake.
+var completionClient = getClient
+
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Generate a shell completion script",
+		Long: `Generate a completion script for vrtg. Besides commands and flags, it
+completes VM, provider, image, migration and snapshot names from the cluster, in the namespace
+given by --namespace.
+
+  bash:  source <(vrtg completion bash)
+  zsh:   vrtg completion zsh > "${fpath[1]}/_vrtg"
+  fish:  vrtg completion fish > ~/.config/fish/completions/vrtg.fish`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			default:
+				return fmt.Errorf("unsupported shell %q (want bash, zsh or fish)", args[0])
+			}
+		},
+	}
+}
+
+// completeNames runs list against the cluster and returns the names that
+// start with toComplete. Any failure, including the timeout, yields no
+// completions rather than an error in the user's shell.
+func completeNames(toComplete string, list func(ctx context.Context, c client.Client) ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	c, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	// Not every step of a request honours the context (discovery, for
+	// one), so the result is abandoned once the deadline passes.
+	done := make(chan []string, 1)
+	go func() {
+		names, err := list(ctx, c)
+		if err != nil {
+			names = nil
+		}
+		done <- names
+	}()
+
+	var names []string
+	select {
+	case names = <-done:
+	case <-ctx.Done():
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	matches := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeVMNames completes a VirtualMachine name as the first argument.
+func completeVMNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+		list := &infrav1beta1.VirtualMachineList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, vm := range list.Items {
+			names = append(names, vm.Name)
+		}
+		return names, nil
+	})
+}
+
+// completeProviderNames completes a Provider name as the first argument.
+func completeProviderNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+		list := &infrav1beta1.ProviderList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, provider := range list.Items {
+			names = append(names, provider.Name)
+		}
+		return names, nil
+	})
+}
+
+// completeImageNames completes a VMImage name as the first argument.
+func completeImageNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+		list := &infrav1beta1.VMImageList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, img := range list.Items {
+			names = append(names, img.Name)
+		}
+		return names, nil
+	})
+}
+
+// completeMigrationNames completes a VMMigration name as the first argument.
+func completeMigrationNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+		list := &infrav1beta1.VMMigrationList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, m := range list.Items {
+			names = append(names, m.Name)
+		}
+		return names, nil
+	})
+}
+
+// completeClassNames completes a VMClass name as the first argument.
+func completeClassNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+		list := &infrav1beta1.VMClassList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, class := range list.Items {
+			names = append(names, class.Name)
+		}
+		return names, nil
+	})
+}
+
+// completeVMThenSnapshot completes "<vm-name> <snapshot-name>": a VM first,
+// then the VMSnapshots taken of that VM.
+func completeVMThenSnapshot(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeVMNames(cmd, args, toComplete)
+	case 1:
+		vmName := args[0]
+		return completeNames(toComplete, func(ctx context.Context, c client.Client) ([]string, error) {
+			list := &infrav1beta1.VMSnapshotList{}
+			if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+				return nil, err
+			}
+			var names []string
+			for _, snap := range list.Items {
+				if snap.Spec.VMRef.Name == vmName {
+					names = append(names, snap.Name)
+				}
+			}
+			return names, nil
+		})
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
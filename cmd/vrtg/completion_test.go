@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// useCompletionClient points the completion functions at c and at ns for
+// the duration of the test.
+func useCompletionClient(t *testing.T, c client.Client, ns string) {
+	t.Helper()
+	prevClient, prevNamespace := completionClient, namespace
+	completionClient = func() (client.Client, error) { return c, nil }
+	namespace = ns
+	t.Cleanup(func() { completionClient, namespace = prevClient, prevNamespace })
+}
+
+func completionFixture(t *testing.T) *fake.ClientBuilder {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+
+	meta := func(ns, name string) metav1.ObjectMeta { return metav1.ObjectMeta{Namespace: ns, Name: name} }
+	snapshot := func(name, vm string) *infrav1beta1.VMSnapshot {
+		snap := &infrav1beta1.VMSnapshot{ObjectMeta: meta("default", name)}
+		snap.Spec.VMRef.Name = vm
+		return snap
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1beta1.VirtualMachine{ObjectMeta: meta("default", "web-02")},
+		&infrav1beta1.VirtualMachine{ObjectMeta: meta("default", "web-01")},
+		&infrav1beta1.VirtualMachine{ObjectMeta: meta("default", "db-01")},
+		&infrav1beta1.VirtualMachine{ObjectMeta: meta("staging", "web-99")},
+		&infrav1beta1.Provider{ObjectMeta: meta("default", "vsphere-prod")},
+		&infrav1beta1.Provider{ObjectMeta: meta("default", "libvirt-lab")},
+		&infrav1beta1.Provider{ObjectMeta: meta("staging", "proxmox-stage")},
+		snapshot("web-01-nightly", "web-01"),
+		snapshot("web-01-pre-upgrade", "web-01"),
+		snapshot("db-01-nightly", "db-01"),
+	)
+}
+
+func TestCompleteVMNames(t *testing.T) {
+	useCompletionClient(t, completionFixture(t).Build(), "default")
+
+	names, directive := completeVMNames(nil, nil, "")
+	assert.Equal(t, []string{"db-01", "web-01", "web-02"}, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	names, _ = completeVMNames(nil, nil, "web")
+	assert.Equal(t, []string{"web-01", "web-02"}, names)
+
+	names, _ = completeVMNames(nil, []string{"web-01"}, "")
+	assert.Empty(t, names, "only the first argument is a VM")
+
+	namespace = "staging"
+	names, _ = completeVMNames(nil, nil, "")
+	assert.Equal(t, []string{"web-99"}, names, "--namespace scopes the names")
+}
+
+func TestCompleteProviderNames(t *testing.T) {
+	useCompletionClient(t, completionFixture(t).Build(), "default")
+
+	names, directive := completeProviderNames(nil, nil, "")
+	assert.Equal(t, []string{"libvirt-lab", "vsphere-prod"}, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	names, _ = completeProviderNames(nil, nil, "vs")
+	assert.Equal(t, []string{"vsphere-prod"}, names)
+}
+
+func TestCompleteVMThenSnapshot(t *testing.T) {
+	useCompletionClient(t, completionFixture(t).Build(), "default")
+
+	names, _ := completeVMThenSnapshot(nil, nil, "db")
+	assert.Equal(t, []string{"db-01"}, names)
+
+	names, _ = completeVMThenSnapshot(nil, []string{"web-01"}, "")
+	assert.Equal(t, []string{"web-01-nightly", "web-01-pre-upgrade"}, names)
+
+	names, _ = completeVMThenSnapshot(nil, []string{"web-01", "web-01-nightly"}, "")
+	assert.Empty(t, names)
+}
+
+func TestCompletionWithoutCluster(t *testing.T) {
+	prev := completionClient
+	completionClient = func() (client.Client, error) { return nil, errors.New("no kubeconfig") }
+	t.Cleanup(func() { completionClient = prev })
+
+	names, directive := completeVMNames(nil, nil, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// A server that never answers must not hold the shell past the timeout.
+	hanging := completionFixture(t).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			select {}
+		},
+	}).Build()
+	useCompletionClient(t, hanging, "default")
+
+	start := time.Now()
+	names, _ = completeProviderNames(nil, nil, "")
+	assert.Empty(t, names)
+	assert.Less(t, time.Since(start), completionTimeout+time.Second)
+}
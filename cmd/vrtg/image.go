@@ -0,0 +1,452 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/cli/printers"
+	"github.com/projectbeskar/virtrigaud/internal/controller"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+)
+
+var (
+	// imagePreparePoll is how often `image prepare --wait` polls the task.
+	imagePreparePoll = 2 * time.Second
+
+	prepareProvider    string
+	prepareWait        bool
+	prepareWaitTimeout time.Duration
+)
+
+func newImageCmd() *cobra.Command {
+	imageCmd := &cobra.Command{
+		Use:     "image",
+		Aliases: []string{"images", "vmimage"},
+		Short:   "Manage VM images",
+	}
+
+	prepareCmd := &cobra.Command{
+		Use:   "prepare <name> --provider <provider>",
+		Short: "Prepare an image on a provider ahead of the VMs that use it",
+		Long: `Import a VMImage into a provider, as the VirtualMachine controller does on
+the first VM create, so a large rollout does not wait for the download.
+
+The provider is called directly, so this needs a route to the provider
+Service, e.g. from inside the cluster or through a port-forward. A prepare
+that completes is recorded on the VMImage status; this also releases VMs
+held by prepare.onMissing: Wait. An asynchronous prepare is only recorded
+with --wait, which follows the provider's task until it finishes.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeImageNames,
+		RunE:              prepareImage,
+	}
+	prepareCmd.Flags().StringVar(&prepareProvider, "provider", "", "Provider to prepare the image on")
+	prepareCmd.Flags().BoolVar(&prepareWait, "wait", false, "Follow an asynchronous prepare until it finishes")
+	prepareCmd.Flags().DurationVar(&prepareWaitTimeout, "wait-timeout", time.Hour, "How long --wait follows the prepare")
+	_ = prepareCmd.MarkFlagRequired("provider")
+	_ = prepareCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+
+	imageCmd.AddCommand(
+		addListFlags(&cobra.Command{
+			Use:   "list",
+			Short: "List images and the providers they are prepared on",
+			Args:  cobra.NoArgs,
+			RunE:  listImages,
+		}),
+		&cobra.Command{
+			Use:               "describe <name>",
+			Short:             "Describe an image, its source and checksums",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeImageNames,
+			RunE:              describeImage,
+		},
+		prepareCmd,
+	)
+	return imageCmd
+}
+
+func listImages(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	list := &infrav1beta1.VMImageList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list VMImages: %w", err)
+	}
+	return printList(os.Stdout, list, itemPointers(list.Items), imageColumns(time.Now()))
+}
+
+// imageSourceType names the kind of source an image is built from, e.g.
+// "vsphere-ova" or "libvirt-path".
+func imageSourceType(img *infrav1beta1.VMImage) string {
+	src := img.Spec.Source
+	switch {
+	case src.VSphere != nil:
+		switch {
+		case src.VSphere.OVAURL != "":
+			return "vsphere-ova"
+		case src.VSphere.ContentLibrary != nil:
+			return "vsphere-library"
+		default:
+			return "vsphere-template"
+		}
+	case src.Libvirt != nil:
+		if src.Libvirt.URL != "" {
+			return "libvirt-url"
+		}
+		return "libvirt-path"
+	case src.Proxmox != nil:
+		return "proxmox-template"
+	case src.HTTP != nil:
+		return "http"
+	case src.Registry != nil:
+		return "registry"
+	case src.DataVolume != nil:
+		return "datavolume"
+	default:
+		return printers.None
+	}
+}
+
+// imageSize is the size of the prepared image: the overall size if the
+// status records one, else the largest size recorded for a provider.
+func imageSize(img *infrav1beta1.VMImage) string {
+	if img.Status.Size != nil {
+		return img.Status.Size.String()
+	}
+	var largest *resource.Quantity
+	for _, ps := range img.Status.ProviderStatus {
+		if ps.Size != nil && (largest == nil || ps.Size.Cmp(*largest) > 0) {
+			largest = ps.Size
+		}
+	}
+	if largest == nil {
+		return printers.None
+	}
+	return largest.String()
+}
+
+// imageProviders lists the providers an image has been prepared on or is
+// being prepared on, e.g. "vsphere-a,proxmox-b(importing)".
+func imageProviders(img *infrav1beta1.VMImage) string {
+	names := make([]string, 0, len(img.Status.ProviderStatus))
+	for name := range img.Status.ProviderStatus {
+		names = append(names, name)
+	}
+	for _, name := range img.Status.AvailableOn {
+		if _, ok := img.Status.ProviderStatus[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return printers.None
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		if ps, ok := img.Status.ProviderStatus[name]; ok && !ps.Available {
+			names[i] = name + "(importing)"
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func describeImage(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	img := &infrav1beta1.VMImage{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: args[0]}, img); err != nil {
+		return fmt.Errorf("failed to get VMImage: %w", err)
+	}
+	if output != "table" {
+		return outputResource(img)
+	}
+
+	now := time.Now()
+	fmt.Printf("%s\n\n", printers.ReadySummary(img.Status.Conditions, now))
+	fmt.Printf("Name: %s\n", img.Name)
+	fmt.Printf("Namespace: %s\n", img.Namespace)
+	fmt.Printf("Source: %s\n", imageSourceType(img))
+	printImageSource(os.Stdout, img.Spec.Source)
+	if img.Spec.Prepare != nil && img.Spec.Prepare.OnMissing != "" {
+		fmt.Printf("On Missing: %s\n", img.Spec.Prepare.OnMissing)
+	}
+	if img.Status.Phase != "" {
+		fmt.Printf("Phase: %s\n", img.Status.Phase)
+	}
+	if img.Status.Message != "" {
+		fmt.Printf("Message: %s\n", img.Status.Message)
+	}
+	if img.Status.Format != "" {
+		fmt.Printf("Format: %s\n", img.Status.Format)
+	}
+	fmt.Printf("Size: %s\n", imageSize(img))
+	if img.Status.Checksum != "" {
+		fmt.Printf("Prepared Checksum: %s\n", img.Status.Checksum)
+	}
+	if img.Status.PrepareTaskRef != "" {
+		fmt.Printf("Prepare Task: %s\n", img.Status.PrepareTaskRef)
+	}
+	if img.Status.LastPrepareTime != nil {
+		fmt.Printf("Last Prepared: %s (%s ago)\n", img.Status.LastPrepareTime.Format(time.RFC3339),
+			printers.Age(img.Status.LastPrepareTime.Time, now))
+	}
+	fmt.Printf("Created: %s (%s ago)\n", img.CreationTimestamp.Format(time.RFC3339), printers.Age(img.CreationTimestamp.Time, now))
+
+	if len(img.Status.ProviderStatus) > 0 {
+		fmt.Printf("\nProviders:\n")
+		names := make([]string, 0, len(img.Status.ProviderStatus))
+		for name := range img.Status.ProviderStatus {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "  NAME\tAVAILABLE\tID\tPATH\tMESSAGE\n")
+		for _, name := range names {
+			ps := img.Status.ProviderStatus[name]
+			_, _ = fmt.Fprintf(w, "  %s\t%t\t%s\t%s\t%s\n", name, ps.Available, orNone(ps.ID), orNone(ps.Path), ps.Message)
+		}
+		_ = w.Flush()
+	}
+
+	printers.Conditions(os.Stdout, img.Status.Conditions, now, printers.TerminalWidth())
+	return nil
+}
+
+// printImageSource prints the details of whichever source is set, with the
+// checksum the provider verifies the download against.
+func printImageSource(w io.Writer, src infrav1beta1.ImageSource) {
+	field := func(name, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(w, "  %s: %s\n", name, value)
+		}
+	}
+	checksum := func(sum string, kind infrav1beta1.ChecksumType) {
+		if sum != "" {
+			field("Checksum", fmt.Sprintf("%s:%s", strings.ToLower(string(kind)), sum))
+		}
+	}
+	switch {
+	case src.VSphere != nil:
+		field("Template", src.VSphere.TemplateName)
+		if cl := src.VSphere.ContentLibrary; cl != nil {
+			item := cl.Library + "/" + cl.Item
+			if cl.Version != "" {
+				item += "@" + cl.Version
+			}
+			field("Content Library Item", item)
+		}
+		field("OVA URL", src.VSphere.OVAURL)
+		checksum(src.VSphere.Checksum, src.VSphere.ChecksumType)
+	case src.Libvirt != nil:
+		field("Path", src.Libvirt.Path)
+		field("URL", src.Libvirt.URL)
+		field("Storage Pool", src.Libvirt.StoragePool)
+		checksum(src.Libvirt.Checksum, src.Libvirt.ChecksumType)
+	case src.Proxmox != nil:
+		if src.Proxmox.TemplateID != nil {
+			field("Template ID", fmt.Sprint(*src.Proxmox.TemplateID))
+		}
+		field("Template", src.Proxmox.TemplateName)
+		field("Node", src.Proxmox.Node)
+		field("Storage", src.Proxmox.Storage)
+	case src.HTTP != nil:
+		field("URL", src.HTTP.URL)
+		checksum(src.HTTP.Checksum, src.HTTP.ChecksumType)
+	case src.Registry != nil:
+		field("Image", src.Registry.Image)
+		field("Format", string(src.Registry.Format))
+	case src.DataVolume != nil:
+		name := src.DataVolume.Name
+		if src.DataVolume.Namespace != "" {
+			name = src.DataVolume.Namespace + "/" + name
+		}
+		field("DataVolume", name)
+	}
+}
+
+func prepareImage(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	img := &infrav1beta1.VMImage{}
+	if err := c.Get(reqCtx, types.NamespacedName{Namespace: namespace, Name: args[0]}, img); err != nil {
+		return fmt.Errorf("failed to get VMImage: %w", err)
+	}
+	provider := &infrav1beta1.Provider{}
+	if err := c.Get(reqCtx, types.NamespacedName{Namespace: namespace, Name: prepareProvider}, provider); err != nil {
+		return fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	if !controller.ProviderAdvertisesImageImport(provider) {
+		return fmt.Errorf("provider %s does not prepare images (capabilities: %s)",
+			provider.Name, capabilitySummary(provider.Status.ReportedCapabilities))
+	}
+	if !controller.ImageSourceNeedsPrepare(img) {
+		fmt.Printf("Image %s is a %s source, which is already on the provider; nothing to prepare\n",
+			img.Name, imageSourceType(img))
+		return nil
+	}
+	if ps, ok := img.Status.ProviderStatus[provider.Name]; ok && ps.Available {
+		fmt.Printf("Image %s is already prepared on %s\n", img.Name, provider.Name)
+		return nil
+	}
+
+	resolver := remote.NewResolver(c, nil)
+	defer resolver.CleanupAllClients()
+	instance, err := resolver.GetProvider(reqCtx, provider)
+	if err != nil {
+		return err
+	}
+	preparer, ok := instance.(contracts.ImagePreparer)
+	if !ok {
+		return fmt.Errorf("provider %s does not prepare images", provider.Name)
+	}
+
+	imageJSON, err := json.Marshal(img.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to encode VMImage %s: %w", img.Name, err)
+	}
+	resp, err := preparer.PrepareImage(reqCtx, contracts.ImagePrepareRequest{
+		ImageJSON:  string(imageJSON),
+		TargetName: img.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("provider %s cannot prepare image %s (%s source): %w (capabilities: %s)", provider.Name,
+			img.Name, imageSourceType(img), err, capabilitySummary(provider.Status.ReportedCapabilities))
+	}
+
+	if resp.TaskRef != "" {
+		if !prepareWait {
+			fmt.Printf("Preparing image %s on %s (task %s)\n", img.Name, provider.Name, resp.TaskRef)
+			fmt.Printf("Run again with --wait to follow it; the VMImage status is updated once it finishes\n")
+			return nil
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, prepareWaitTimeout)
+		defer cancel()
+		if err := waitForImageTask(waitCtx, instance, resp.TaskRef, os.Stdout); err != nil {
+			return fmt.Errorf("image %s on provider %s: %w", img.Name, provider.Name, err)
+		}
+	}
+
+	recordCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := controller.RecordImagePrepared(recordCtx, c, img, provider.Name, resp.PreparedImageID, resp.PreparedImagePath); err != nil {
+		return fmt.Errorf("image %s is prepared on %s but its status was not updated: %w", img.Name, provider.Name, err)
+	}
+	fmt.Printf("Image %s is prepared on %s\n", img.Name, provider.Name)
+	return nil
+}
+
+// waitForImageTask polls taskRef on provider until it finishes, printing
+// the provider's progress message whenever it changes.
+func waitForImageTask(ctx context.Context, provider contracts.Provider, taskRef string, out io.Writer) error {
+	last := ""
+	for {
+		status, err := provider.TaskStatus(ctx, taskRef)
+		if err != nil {
+			return fmt.Errorf("failed to check prepare task %s: %w", taskRef, err)
+		}
+		if status.Error != "" {
+			return fmt.Errorf("prepare failed: %s", status.Error)
+		}
+		if status.IsCompleted {
+			return nil
+		}
+		if status.Message != "" && status.Message != last {
+			_, _ = fmt.Fprintf(out, "  %s\n", status.Message)
+			last = status.Message
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up waiting for prepare task %s: %w", taskRef, ctx.Err())
+		case <-time.After(imagePreparePoll):
+		}
+	}
+}
+
+// capabilitySummary lists what a provider reported it supports, for errors
+// that explain why it cannot do something.
+func capabilitySummary(caps *infrav1beta1.ReportedCapabilities) string {
+	if caps == nil {
+		return "not reported yet"
+	}
+	raw, err := json.Marshal(caps)
+	if err != nil {
+		return "unknown"
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil || len(fields) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if list, ok := value.([]interface{}); ok {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+			name += "=" + strings.Join(items, "|")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// orNone renders an empty string as printers.None.
+func orNone(s string) string {
+	if s == "" {
+		return printers.None
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestImageSourceType(t *testing.T) {
+	cases := map[string]infrav1beta1.ImageSource{
+		"vsphere-ova":      {VSphere: &infrav1beta1.VSphereImageSource{OVAURL: "https://images/web.ova"}},
+		"vsphere-library":  {VSphere: &infrav1beta1.VSphereImageSource{ContentLibrary: &infrav1beta1.ContentLibraryRef{Library: "l", Item: "i"}}},
+		"vsphere-template": {VSphere: &infrav1beta1.VSphereImageSource{TemplateName: "ubuntu-24"}},
+		"libvirt-url":      {Libvirt: &infrav1beta1.LibvirtImageSource{URL: "https://images/web.qcow2"}},
+		"libvirt-path":     {Libvirt: &infrav1beta1.LibvirtImageSource{Path: "/var/lib/libvirt/images/web.qcow2"}},
+		"proxmox-template": {Proxmox: &infrav1beta1.ProxmoxImageSource{TemplateName: "ubuntu-24"}},
+		"http":             {HTTP: &infrav1beta1.HTTPImageSource{URL: "https://images/web.img"}},
+		"<none>":           {},
+	}
+	for want, src := range cases {
+		assert.Equal(t, want, imageSourceType(&infrav1beta1.VMImage{Spec: infrav1beta1.VMImageSpec{Source: src}}))
+	}
+}
+
+func TestImageProvidersAndSize(t *testing.T) {
+	img := &infrav1beta1.VMImage{}
+	assert.Equal(t, "<none>", imageProviders(img))
+	assert.Equal(t, "<none>", imageSize(img))
+
+	small, large := resource.MustParse("2Gi"), resource.MustParse("10Gi")
+	img.Status.AvailableOn = []string{"vsphere-a", "libvirt-c"}
+	img.Status.ProviderStatus = map[string]infrav1beta1.ProviderImageStatus{
+		"vsphere-a": {Available: true, Size: &small},
+		"proxmox-b": {Available: false, Size: &large},
+	}
+	assert.Equal(t, "libvirt-c,proxmox-b(importing),vsphere-a", imageProviders(img))
+	assert.Equal(t, "10Gi", imageSize(img))
+}
+
+func TestCapabilitySummary(t *testing.T) {
+	assert.Equal(t, "not reported yet", capabilitySummary(nil))
+	assert.Equal(t, "none", capabilitySummary(&infrav1beta1.ReportedCapabilities{}))
+	assert.Equal(t, "supportedDiskTypes=qcow2|raw, supportsSnapshots", capabilitySummary(&infrav1beta1.ReportedCapabilities{
+		SupportsSnapshots:  true,
+		SupportedDiskTypes: []string{"qcow2", "raw"},
+	}))
+}
+
+// taskProvider reports a fixed sequence of task statuses.
+type taskProvider struct {
+	contracts.Provider
+	statuses []contracts.TaskStatus
+}
+
+func (p *taskProvider) TaskStatus(context.Context, string) (contracts.TaskStatus, error) {
+	status := p.statuses[0]
+	if len(p.statuses) > 1 {
+		p.statuses = p.statuses[1:]
+	}
+	return status, nil
+}
+
+func TestWaitForImageTask(t *testing.T) {
+	prev := imagePreparePoll
+	imagePreparePoll = 0
+	t.Cleanup(func() { imagePreparePoll = prev })
+
+	var out bytes.Buffer
+	provider := &taskProvider{statuses: []contracts.TaskStatus{
+		{Message: "downloading 10%"}, {Message: "downloading 10%"}, {Message: "converting"}, {IsCompleted: true},
+	}}
+	require.NoError(t, waitForImageTask(context.Background(), provider, "task-1", &out))
+	assert.Equal(t, "  downloading 10%\n  converting\n", out.String())
+
+	provider = &taskProvider{statuses: []contracts.TaskStatus{{IsCompleted: true, Error: "checksum mismatch"}}}
+	assert.ErrorContains(t, waitForImageTask(context.Background(), provider, "task-2", &out), "prepare failed: checksum mismatch")
+}
@@ -17,27 +17,63 @@ limitations under the License.
 package main
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+	"github.com/projectbeskar/virtrigaud/internal/cli/printers"
+	"github.com/projectbeskar/virtrigaud/internal/controller"
+	"github.com/projectbeskar/virtrigaud/internal/httpapi"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
 )
 
+// version is the vrtg release, set at build time with
+// -ldflags "-X main.version=<tag>".
+var version = "dev"
+
 var (
 	kubeconfig string
 	namespace  string
 	output     string
 	timeout    time.Duration
+
+	consoleTail int32
+	showEvents  int
+
+	consoleWeb bool
+	apiURL     string
+	apiCAFile  string
 )
 
 func main() {
@@ -49,9 +85,39 @@ func main() {
 
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "Output format (table|json|yaml)")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "Output format (table|wide|custom-columns=HEADER:EXPR,...|json|yaml)")
 	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Request timeout")
 
+	consoleLogCmd := &cobra.Command{
+		Use:               "console-log <name>",
+		Short:             "Show recent serial console output for a virtual machine",
+		Args:              cobra.ExactArgs(1),
+		RunE:              vmConsoleLog,
+		ValidArgsFunction: completeVMNames,
+	}
+	consoleLogCmd.Flags().Int32Var(&consoleTail, "tail", 200, "Number of most recent console lines to fetch (0 = provider default)")
+
+	consoleCmd := &cobra.Command{
+		Use:               "console <name>",
+		Short:             "Open a console session for a virtual machine through the manager's console proxy",
+		Args:              cobra.ExactArgs(1),
+		RunE:              vmConsole,
+		ValidArgsFunction: completeVMNames,
+	}
+	consoleCmd.Flags().BoolVar(&consoleWeb, "web", false, "Print a temporary WebSocket URL for a browser console client")
+	consoleCmd.Flags().StringVar(&apiURL, "api-url", os.Getenv("VRTG_API_URL"), "Base URL of the manager's HTTP API (default $VRTG_API_URL)")
+	consoleCmd.Flags().StringVar(&apiCAFile, "api-ca-file", "", "CA bundle verifying the HTTP API's certificate (default system roots)")
+
+	describeVMCmd := &cobra.Command{
+		Use:               "describe <name>",
+		Short:             "Describe a virtual machine",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeVMNames,
+		RunE:              describeVM,
+	}
+	describeVMCmd.Flags().IntVar(&showEvents, "show-events", 0, "Append the last N events for the VM (10 when given without a value)")
+	describeVMCmd.Flags().Lookup("show-events").NoOptDefVal = "10"
+
 	// VM commands
 	vmCmd := &cobra.Command{
 		Use:     "vm",
@@ -60,28 +126,42 @@ func main() {
 	}
 
 	vmCmd.AddCommand(
-		&cobra.Command{
+		addListFlags(&cobra.Command{
 			Use:   "list",
 			Short: "List virtual machines",
 			RunE:  listVMs,
+		}),
+		describeVMCmd,
+		&cobra.Command{
+			Use:               "events <name>",
+			Short:             "Show events for a virtual machine",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeVMNames,
+			RunE:              vmEvents,
 		},
 		&cobra.Command{
-			Use:   "describe <name>",
-			Short: "Describe a virtual machine",
-			Args:  cobra.ExactArgs(1),
-			RunE:  describeVM,
+			Use:               "console-url <name>",
+			Short:             "Get console URL for a virtual machine",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeVMNames,
+			RunE:              vmConsoleURL,
 		},
+		consoleCmd,
+		consoleLogCmd,
+		newTopCmd(),
 		&cobra.Command{
-			Use:   "events <name>",
-			Short: "Show events for a virtual machine",
-			Args:  cobra.ExactArgs(1),
-			RunE:  vmEvents,
+			Use:               "pause [name]",
+			Short:             "Pause reconciliation of a virtual machine, or of every VM in the namespace given by --namespace",
+			Args:              cobra.MaximumNArgs(1),
+			ValidArgsFunction: completeVMNames,
+			RunE:              func(cmd *cobra.Command, args []string) error { return setPaused(cmd, args, true) },
 		},
 		&cobra.Command{
-			Use:   "console-url <name>",
-			Short: "Get console URL for a virtual machine",
-			Args:  cobra.ExactArgs(1),
-			RunE:  vmConsoleURL,
+			Use:               "resume [name]",
+			Short:             "Resume reconciliation of a virtual machine, or of the namespace given by --namespace",
+			Args:              cobra.MaximumNArgs(1),
+			ValidArgsFunction: completeVMNames,
+			RunE:              func(cmd *cobra.Command, args []string) error { return setPaused(cmd, args, false) },
 		},
 	)
 
@@ -93,22 +173,31 @@ func main() {
 	}
 
 	providerCmd.AddCommand(
-		&cobra.Command{
+		addListFlags(&cobra.Command{
 			Use:   "list",
 			Short: "List providers",
 			RunE:  listProviders,
+		}),
+		&cobra.Command{
+			Use:               "status <name>",
+			Short:             "Show provider status",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeProviderNames,
+			RunE:              providerStatus,
 		},
 		&cobra.Command{
-			Use:   "status <name>",
-			Short: "Show provider status",
-			Args:  cobra.ExactArgs(1),
-			RunE:  providerStatus,
+			Use:               "logs <name>",
+			Short:             "Show provider logs",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeProviderNames,
+			RunE:              providerLogs,
 		},
 		&cobra.Command{
-			Use:   "logs <name>",
-			Short: "Show provider logs",
-			Args:  cobra.ExactArgs(1),
-			RunE:  providerLogs,
+			Use:               "usage <name>",
+			Short:             "Show resources allocated to a provider's VMs and the hypervisor capacity",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeProviderNames,
+			RunE:              providerUsage,
 		},
 	)
 
@@ -121,22 +210,25 @@ func main() {
 
 	snapshotCmd.AddCommand(
 		&cobra.Command{
-			Use:   "create <vm-name> <snapshot-name>",
-			Short: "Create a VM snapshot",
-			Args:  cobra.ExactArgs(2),
-			RunE:  createSnapshot,
-		},
-		&cobra.Command{
-			Use:   "list [vm-name]",
-			Short: "List snapshots",
-			Args:  cobra.MaximumNArgs(1),
-			RunE:  listSnapshots,
+			Use:               "create <vm-name> <snapshot-name>",
+			Short:             "Create a VM snapshot",
+			Args:              cobra.ExactArgs(2),
+			ValidArgsFunction: completeVMNames,
+			RunE:              createSnapshot,
 		},
+		addListFlags(&cobra.Command{
+			Use:               "list [vm-name]",
+			Short:             "List snapshots; for a VM, as reported live by its provider",
+			Args:              cobra.MaximumNArgs(1),
+			ValidArgsFunction: completeVMNames,
+			RunE:              listSnapshots,
+		}),
 		&cobra.Command{
-			Use:   "revert <vm-name> <snapshot-name>",
-			Short: "Revert VM to snapshot",
-			Args:  cobra.ExactArgs(2),
-			RunE:  revertSnapshot,
+			Use:               "revert <vm-name> <snapshot-name>",
+			Short:             "Revert VM to snapshot",
+			Args:              cobra.ExactArgs(2),
+			ValidArgsFunction: completeVMThenSnapshot,
+			RunE:              revertSnapshot,
 		},
 	)
 
@@ -149,16 +241,17 @@ func main() {
 
 	cloneCmd.AddCommand(
 		&cobra.Command{
-			Use:   "run <source-vm> <target-vm>",
-			Short: "Clone a virtual machine",
-			Args:  cobra.ExactArgs(2),
-			RunE:  runClone,
+			Use:               "run <source-vm> <target-vm>",
+			Short:             "Clone a virtual machine",
+			Args:              cobra.ExactArgs(2),
+			ValidArgsFunction: completeVMNames,
+			RunE:              runClone,
 		},
-		&cobra.Command{
+		addListFlags(&cobra.Command{
 			Use:   "list",
 			Short: "List clone operations",
 			RunE:  listClones,
-		},
+		}),
 	)
 
 	// Conformance commands
@@ -170,10 +263,11 @@ func main() {
 
 	conformanceCmd.AddCommand(
 		&cobra.Command{
-			Use:   "run <provider>",
-			Short: "Run conformance tests against a provider",
-			Args:  cobra.ExactArgs(1),
-			RunE:  runConformance,
+			Use:               "run <provider>",
+			Short:             "Run conformance tests against a provider",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeProviderNames,
+			RunE:              runConformance,
 		},
 	)
 
@@ -200,14 +294,33 @@ func main() {
 		RunE:  initVirtrigaud,
 	}
 
-	rootCmd.AddCommand(vmCmd, providerCmd, snapshotCmd, cloneCmd, conformanceCmd, diagCmd, initCmd)
+	// The completion command is ours so it offers only the shells whose
+	// scripts are maintained, and so its help covers the dynamic names.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
+	rootCmd.AddCommand(vmCmd, providerCmd, newClassCmd(), newImageCmd(), newMigrationCmd(), snapshotCmd, cloneCmd, conformanceCmd, diagCmd, initCmd, newAdminCmd(), newCompletionCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var exit exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitError makes vrtg exit with code rather than 1, for commands whose
+// exit status tells failures apart.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+
+func (e exitError) Unwrap() error { return e.err }
+
 func listVMs(cmd *cobra.Command, args []string) error {
 	client, err := getClient()
 	if err != nil {
@@ -223,24 +336,19 @@ func listVMs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list VMs: %w", err)
 	}
 
-	if output == "table" {
-		fmt.Printf("%-20s %-15s %-15s %-15s %-20s %-10s\n",
-			"NAME", "PROVIDER", "CLASS", "IMAGE", "IPS", "AGE")
-		for _, vm := range vmList.Items {
-			age := time.Since(vm.CreationTimestamp.Time).Truncate(time.Second)
-			ips := strings.Join(vm.Status.IPs, ",")
-			if ips == "" {
-				ips = "<none>"
-			}
-			fmt.Printf("%-20s %-15s %-15s %-15s %-20s %-10s\n",
-				vm.Name, vm.Spec.ProviderRef.Name, vm.Spec.ClassRef.Name,
-				vm.Spec.ImageRef.Name, ips, age)
+	// Only the wide table shows provider types, which cost a second list.
+	providerTypes := map[string]string{}
+	if opts, _, err := listTableOptions(); err == nil && opts.Wide {
+		providerList := &infrav1beta1.ProviderList{}
+		if err := client.List(ctx, providerList); err != nil {
+			return fmt.Errorf("failed to list providers: %w", err)
+		}
+		for _, p := range providerList.Items {
+			providerTypes[p.Namespace+"/"+p.Name] = string(p.Spec.Type)
 		}
-	} else {
-		return outputResource(vmList)
 	}
 
-	return nil
+	return printList(os.Stdout, vmList, itemPointers(vmList.Items), vmColumns(time.Now(), providerTypes))
 }
 
 func describeVM(cmd *cobra.Command, args []string) error {
@@ -260,6 +368,8 @@ func describeVM(cmd *cobra.Command, args []string) error {
 	}
 
 	if output == "table" {
+		now := time.Now()
+		fmt.Printf("%s\n\n", vmSummary(vm, now))
 		fmt.Printf("Name: %s\n", vm.Name)
 		fmt.Printf("Namespace: %s\n", vm.Namespace)
 		fmt.Printf("Provider: %s\n", vm.Spec.ProviderRef.Name)
@@ -269,14 +379,28 @@ func describeVM(cmd *cobra.Command, args []string) error {
 		fmt.Printf("VM ID: %s\n", vm.Status.ID)
 		fmt.Printf("Current Power State: %s\n", vm.Status.PowerState)
 		fmt.Printf("IPs: %s\n", strings.Join(vm.Status.IPs, ", "))
+		if vm.Status.PrimaryIP != "" {
+			fmt.Printf("Primary IP: %s\n", vm.Status.PrimaryIP)
+		}
 		fmt.Printf("Console URL: %s\n", vm.Status.ConsoleURL)
-		fmt.Printf("Created: %s\n", vm.CreationTimestamp.Format(time.RFC3339))
+		if chain := vm.Status.SnapshotChain; chain != nil {
+			fmt.Printf("Snapshots: %d (chain depth %d)\n", chain.Count, chain.Depth)
+		}
+		fmt.Printf("Phase: %s\n", vm.Status.Phase)
+		if vm.Status.Message != "" {
+			fmt.Printf("Message: %s\n", vm.Status.Message)
+		}
+		fmt.Printf("Created: %s (%s ago)\n", vm.CreationTimestamp.Format(time.RFC3339), printers.Age(vm.CreationTimestamp.Time, now))
+
+		width := printers.TerminalWidth()
+		printers.Conditions(os.Stdout, vm.Status.Conditions, now, width)
 
-		if len(vm.Status.Conditions) > 0 {
-			fmt.Printf("\nConditions:\n")
-			for _, condition := range vm.Status.Conditions {
-				fmt.Printf("  %s: %s (%s)\n", condition.Type, condition.Status, condition.Reason)
+		if showEvents > 0 {
+			events, err := objectEvents(ctx, "VirtualMachine", vm.Name)
+			if err != nil {
+				return err
 			}
+			printers.Events(os.Stdout, events, showEvents, now, width)
 		}
 	} else {
 		return outputResource(vm)
@@ -285,24 +409,47 @@ func describeVM(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func vmEvents(cmd *cobra.Command, args []string) error {
+// vmSummary is the Ready summary of a VM, with how long provisioning took
+// once it has finished: the Provisioning condition turns False with
+// ReconcileSuccess when the VM is created and keeps that transition time.
+func vmSummary(vm *infrav1beta1.VirtualMachine, now time.Time) string {
+	summary := printers.ReadySummary(vm.Status.Conditions, now)
+	prov := apimeta.FindStatusCondition(vm.Status.Conditions, infrav1beta1.VirtualMachineConditionProvisioning)
+	if prov != nil && prov.Status == metav1.ConditionFalse && prov.Reason == k8s.ReasonReconcileSuccess &&
+		!prov.LastTransitionTime.Before(&vm.CreationTimestamp) {
+		took := prov.LastTransitionTime.Sub(vm.CreationTimestamp.Time)
+		summary += fmt.Sprintf(" (provisioned in %s)", duration.HumanDuration(took))
+	}
+	return summary
+}
+
+// objectEvents returns the events recorded for an object of the given kind
+// in the current namespace.
+func objectEvents(ctx context.Context, kind, name string) ([]corev1.Event, error) {
 	clientset, err := getClientset()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=%s", name, kind),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
+	return events.Items, nil
+}
 
+func vmEvents(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=VirtualMachine", args[0]),
-	})
+	events, err := objectEvents(ctx, "VirtualMachine", args[0])
 	if err != nil {
-		return fmt.Errorf("failed to get events: %w", err)
+		return err
 	}
 
 	fmt.Printf("%-30s %-10s %-15s %-50s\n", "LAST SEEN", "TYPE", "REASON", "MESSAGE")
-	for _, event := range events.Items {
+	for _, event := range events {
 		lastSeen := event.LastTimestamp.Format("2006-01-02 15:04:05")
 		fmt.Printf("%-30s %-10s %-15s %-50s\n",
 			lastSeen, event.Type, event.Reason, event.Message)
@@ -336,8 +483,33 @@ func vmConsoleURL(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func listProviders(cmd *cobra.Command, args []string) error {
-	client, err := getClient()
+// vmConsole asks the manager's HTTP API for a console session, sending the
+// kubeconfig's credentials, and prints the session's URL.
+func vmConsole(cmd *cobra.Command, args []string) error {
+	if !consoleWeb {
+		return fmt.Errorf("only --web console sessions are supported; see console-url for the provider's own console")
+	}
+	if apiURL == "" {
+		return fmt.Errorf("the manager's HTTP API is not set; pass --api-url or set VRTG_API_URL")
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if apiCAFile != "" {
+		pem, err := os.ReadFile(apiCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read --api-ca-file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("--api-ca-file %s holds no PEM certificates", apiCAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	rt, err := rest.HTTPWrappersForConfig(cfg, transport)
 	if err != nil {
 		return err
 	}
@@ -345,26 +517,165 @@ func listProviders(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	providerList := &infrav1beta1.ProviderList{}
-	err = client.List(ctx, providerList)
+	endpoint := strings.TrimSuffix(apiURL, "/") + "/api/v1/vms/" + url.PathEscape(namespace) + "/" +
+		url.PathEscape(args[0]) + "/console"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
 	if err != nil {
-		return fmt.Errorf("failed to list providers: %w", err)
+		return err
+	}
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request console session: %w", err)
 	}
+	defer func() { _ = resp.Body.Close() }()
 
-	if output == "table" {
-		fmt.Printf("%-20s %-15s %-15s %-10s\n", "NAME", "TYPE", "ENDPOINT", "AGE")
-		for _, provider := range providerList.Items {
-			age := time.Since(provider.CreationTimestamp.Time).Truncate(time.Second)
-			fmt.Printf("%-20s %-15s %-15s %-10s\n",
-				provider.Name, provider.Spec.Type, provider.Spec.Endpoint, age)
+	if resp.StatusCode != http.StatusCreated {
+		var apiErr httpapi.Error
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("failed to request console session: %s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("failed to request console session: %s", resp.Status)
+	}
+	var session httpapi.ConsoleSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return fmt.Errorf("failed to decode console session: %w", err)
+	}
+
+	fmt.Printf("%s\n", session.URL)
+	fmt.Fprintf(os.Stderr, "Protocol %s. The URL can be connected once, before %s.\n",
+		session.Protocol, session.ExpiresAt.Local().Format("15:04:05"))
+	return nil
+}
+
+func vmConsoleLog(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	vm := &infrav1beta1.VirtualMachine{}
+	key := types.NamespacedName{Namespace: namespace, Name: args[0]}
+	if err := c.Get(ctx, key, vm); err != nil {
+		return fmt.Errorf("failed to get VM: %w", err)
+	}
+	if vm.Status.ID == "" {
+		return fmt.Errorf("VM %s has not been created on its provider yet", args[0])
+	}
+
+	// Ask the controller for a capture; it clears the annotation once the
+	// console-log ConfigMap has been written.
+	patch := client.MergeFrom(vm.DeepCopy())
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[infrav1beta1.ConsoleLogRequestAnnotation] = strconv.Itoa(int(consoleTail))
+	if err := c.Patch(ctx, vm, patch); err != nil {
+		return fmt.Errorf("failed to request console log: %w", err)
+	}
+
+	for {
+		if err := c.Get(ctx, key, vm); err != nil {
+			return fmt.Errorf("failed to get VM: %w", err)
+		}
+		if _, pending := vm.Annotations[infrav1beta1.ConsoleLogRequestAnnotation]; !pending {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for console output from the controller")
+		case <-time.After(time.Second):
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Namespace: namespace, Name: infrav1beta1.ConsoleLogConfigMapName(args[0])}
+	if err := c.Get(ctx, cmKey, cm); err != nil {
+		return fmt.Errorf("failed to get console log: %w", err)
+	}
+	if msg, failed := cm.Data[infrav1beta1.ConsoleLogErrorKey]; failed {
+		return fmt.Errorf("console output unavailable: %s", msg)
+	}
+
+	fmt.Print(cm.Data[infrav1beta1.ConsoleLogDataKey])
+	return nil
+}
+
+// setPaused sets or clears the pause annotation on a VM, or on the
+// namespace itself when no VM is named. Pausing a whole namespace requires
+// --namespace to be given explicitly so the default is never paused by
+// accident.
+func setPaused(cmd *cobra.Command, args []string, paused bool) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var obj client.Object
+	var what string
+	switch {
+	case len(args) == 1:
+		obj = &infrav1beta1.VirtualMachine{}
+		what = fmt.Sprintf("VirtualMachine %s/%s", namespace, args[0])
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: args[0]}, obj); err != nil {
+			return fmt.Errorf("failed to get VM: %w", err)
+		}
+	case cmd.Flags().Changed("namespace"):
+		obj = &corev1.Namespace{}
+		what = fmt.Sprintf("namespace %s", namespace)
+		if err := c.Get(ctx, types.NamespacedName{Name: namespace}, obj); err != nil {
+			return fmt.Errorf("failed to get namespace: %w", err)
+		}
+	default:
+		return fmt.Errorf("specify a VM name, or --namespace to act on every VM in a namespace")
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if paused {
+		if annotations == nil {
+			annotations = map[string]string{}
 		}
+		annotations[infrav1beta1.PausedAnnotation] = "true"
 	} else {
-		return outputResource(providerList)
+		delete(annotations, infrav1beta1.PausedAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	if err := c.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to update %s: %w", what, err)
 	}
 
+	if paused {
+		fmt.Printf("Paused reconciliation of %s\n", what)
+	} else {
+		fmt.Printf("Resumed reconciliation of %s\n", what)
+	}
 	return nil
 }
 
+func listProviders(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	providerList := &infrav1beta1.ProviderList{}
+	err = client.List(ctx, providerList)
+	if err != nil {
+		return fmt.Errorf("failed to list providers: %w", err)
+	}
+
+	return printList(os.Stdout, providerList, itemPointers(providerList.Items), providerColumns(time.Now()))
+}
+
 func providerStatus(cmd *cobra.Command, args []string) error {
 	client, err := getClient()
 	if err != nil {
@@ -381,23 +692,233 @@ func providerStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get provider: %w", err)
 	}
 
+	now := time.Now()
+	fmt.Printf("%s\n\n", printers.ReadySummary(provider.Status.Conditions, now))
 	fmt.Printf("Provider: %s\n", provider.Name)
 	fmt.Printf("Type: %s\n", provider.Spec.Type)
-	fmt.Printf("Endpoint: %s\n", provider.Spec.Endpoint)
+	fmt.Printf("Endpoint: %s\n", cmp.Or(provider.Spec.Endpoint, provider.Status.Endpoint))
 	fmt.Printf("Status: Available\n")
-
-	if len(provider.Status.Conditions) > 0 {
-		fmt.Printf("\nConditions:\n")
-		for _, condition := range provider.Status.Conditions {
-			fmt.Printf("  %s: %s (%s)\n", condition.Type, condition.Status, condition.Reason)
+	if provider.Status.Version != "" {
+		fmt.Printf("Provider Version: %s\n", provider.Status.Version)
+	}
+	if provider.Status.GitSHA != "" {
+		fmt.Printf("Provider Git SHA: %s\n", provider.Status.GitSHA)
+	}
+	if hv := provider.Status.Hypervisor; hv != nil {
+		fmt.Printf("Hypervisor: %s %s\n", hv.Product, hv.Version)
+		if hv.Details != "" {
+			fmt.Printf("Hypervisor Details: %s\n", hv.Details)
 		}
 	}
 
+	printProviderCapabilities(os.Stdout, provider)
+
+	printers.Conditions(os.Stdout, provider.Status.Conditions, now, printers.TerminalWidth())
+
 	fmt.Printf("\nNote: Use kubectl describe for full provider details\n")
 
 	return nil
 }
 
+// printProviderCapabilities prints spec.requiredCapabilities next to the
+// capabilities the running image reports, one row per capability, so a
+// Degraded provider shows at a glance what its image lacks.
+func printProviderCapabilities(w io.Writer, provider *infrav1beta1.Provider) {
+	if provider.Status.ReportedCapabilities == nil && len(provider.Spec.RequiredCapabilities) == 0 {
+		return
+	}
+
+	required := map[string]bool{}
+	for _, name := range provider.Spec.RequiredCapabilities {
+		required[name] = true
+	}
+	reported := map[string]bool{}
+	if provider.Status.ReportedCapabilities != nil {
+		for _, name := range capability.Names(provider) {
+			reported[name] = true
+		}
+	}
+	var names []string
+	for name := range required {
+		names = append(names, name)
+	}
+	for name := range reported {
+		if !required[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "\n%-24s %-9s %s\n", "CAPABILITY", "REQUIRED", "REPORTED")
+	for _, name := range names {
+		has := "no"
+		switch {
+		case provider.Status.ReportedCapabilities == nil:
+			has = "unknown"
+		case reported[name]:
+			has = "yes"
+		}
+		need := "-"
+		if required[name] {
+			need = "yes"
+		}
+		fmt.Fprintf(w, "%-24s %-9s %s\n", name, need, has)
+	}
+	if provider.Status.ReportedCapabilities != nil && len(provider.Status.ReportedCapabilities.CapabilityManifest) == 0 {
+		fmt.Fprintf(w, "(image reports no capability manifest; derived from its capability flags)\n")
+	}
+	fmt.Fprintln(w)
+}
+
+// namespaceUsage is the allocation of one namespace's VMs on a provider.
+type namespaceUsage struct {
+	Namespace string `json:"namespace"`
+	VMs       int    `json:"vms"`
+	usage.VMAllocation
+}
+
+func providerUsage(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	provider := &infrav1beta1.Provider{}
+	key := types.NamespacedName{Namespace: namespace, Name: args[0]}
+	if err := client.Get(ctx, key, provider); err != nil {
+		return fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	vmList := &infrav1beta1.VirtualMachineList{}
+	if err := client.List(ctx, vmList); err != nil {
+		return fmt.Errorf("failed to list VMs: %w", err)
+	}
+
+	// Allocations are resolved the same way the VirtualMachine controller
+	// resolves them for the virtrigaud_vm_allocated_* gauges.
+	classes := map[types.NamespacedName]*infrav1beta1.VMClass{}
+	byNamespace := map[string]*namespaceUsage{}
+	for i := range vmList.Items {
+		vm := &vmList.Items[i]
+		if !controller.VMUsesProvider(vm, provider) {
+			continue
+		}
+
+		classKey := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Spec.ClassRef.Name}
+		if vm.Spec.ClassRef.Namespace != "" {
+			classKey.Namespace = vm.Spec.ClassRef.Namespace
+		}
+		class, cached := classes[classKey]
+		if !cached {
+			var err error
+			class, err = vmclass.Get(ctx, client, classKey)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: VMClass %s for VM %s/%s: %v\n", classKey, vm.Namespace, vm.Name, err)
+				class = nil
+			}
+			classes[classKey] = class
+		}
+
+		row := byNamespace[vm.Namespace]
+		if row == nil {
+			row = &namespaceUsage{Namespace: vm.Namespace}
+			byNamespace[vm.Namespace] = row
+		}
+		alloc := usage.ResolveVMAllocation(vm, class)
+		row.VMs++
+		row.CPU += alloc.CPU
+		row.MemoryBytes += alloc.MemoryBytes
+		row.DiskBytes += alloc.DiskBytes
+	}
+
+	rows := make([]namespaceUsage, 0, len(byNamespace))
+	total := namespaceUsage{Namespace: "TOTAL"}
+	for _, row := range byNamespace {
+		rows = append(rows, *row)
+		total.VMs += row.VMs
+		total.CPU += row.CPU
+		total.MemoryBytes += row.MemoryBytes
+		total.DiskBytes += row.DiskBytes
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Namespace < rows[j].Namespace })
+
+	if output != "table" {
+		return outputResource(map[string]interface{}{
+			"provider":   provider.Name,
+			"namespaces": rows,
+			"total":      total,
+			"capacity":   provider.Status.ResourceUsage,
+		})
+	}
+
+	fmt.Printf("%-20s %-6s %-6s %-12s %-12s\n", "NAMESPACE", "VMS", "VCPU", "MEMORY", "DISK")
+	for _, row := range append(rows, total) {
+		fmt.Printf("%-20s %-6d %-6d %-12s %-12s\n",
+			row.Namespace, row.VMs, row.CPU, formatBytes(row.MemoryBytes), formatBytes(row.DiskBytes))
+	}
+
+	capacity := provider.Status.ResourceUsage
+	if capacity == nil {
+		fmt.Printf("\nCapacity: not reported by this provider\n")
+		return nil
+	}
+	fmt.Printf("\nCapacity:\n")
+	if s := capacity.CPU; s != nil && s.Total != nil {
+		fmt.Printf("  CPU:     %s of %d cores in use, %d vCPU allocated (%.1fx)\n",
+			usedSummary(s, func(n int64) string { return strconv.FormatInt(n, 10) }), *s.Total, total.CPU, ratio(total.CPU, *s.Total))
+	}
+	if s := capacity.Memory; s != nil && s.Total != nil {
+		fmt.Printf("  Memory:  %s of %s in use, %s allocated (%.1fx)\n",
+			usedSummary(s, formatBytes), formatBytes(*s.Total), formatBytes(total.MemoryBytes),
+			ratio(total.MemoryBytes, *s.Total))
+	}
+	if s := capacity.Storage; s != nil && s.Total != nil {
+		fmt.Printf("  Storage: %s of %s in use, %s allocated (%.1fx)\n",
+			usedSummary(s, formatBytes), formatBytes(*s.Total), formatBytes(total.DiskBytes),
+			ratio(total.DiskBytes, *s.Total))
+	}
+	return nil
+}
+
+// usedSummary renders the used figure of s with its percentage, e.g.
+// "12 (37%)".
+func usedSummary(s *infrav1beta1.ResourceUsageStats, format func(int64) string) string {
+	if s.Used == nil {
+		return "?"
+	}
+	out := format(*s.Used)
+	if s.UsagePercent != nil {
+		out += fmt.Sprintf(" (%d%%)", *s.UsagePercent)
+	}
+	return out
+}
+
+// ratio is the allocated-to-capacity (overcommit) ratio.
+func ratio(allocated, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(allocated) / float64(capacity)
+}
+
+// formatBytes renders a byte count in the largest binary unit that keeps
+// the value at or above one.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 4; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTP"[exp])
+}
+
 func providerLogs(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Provider logs for %s (not implemented - use kubectl logs)\n", args[0])
 	return nil
@@ -409,8 +930,76 @@ func createSnapshot(cmd *cobra.Command, args []string) error {
 }
 
 func listSnapshots(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Listing snapshots (not implemented)\n")
-	return nil
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if len(args) == 0 {
+		return listSnapshotResources(ctx, c, "")
+	}
+
+	vm := &infrav1beta1.VirtualMachine{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: args[0]}, vm); err != nil {
+		return fmt.Errorf("failed to get VM: %w", err)
+	}
+	if vm.Status.ID == "" {
+		return listSnapshotResources(ctx, c, vm.Name)
+	}
+
+	snapshots, err := liveSnapshots(ctx, c, vm)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot list snapshots from the provider (%v); showing VMSnapshot resources\n", err)
+		return listSnapshotResources(ctx, c, vm.Name)
+	}
+
+	return printList(os.Stdout, snapshots, snapshots, liveSnapshotColumns())
+}
+
+// liveSnapshots asks the VM's provider for its snapshots. This needs a
+// route to the provider Service, so it typically fails outside the cluster
+// unless the endpoint is reachable (e.g. through a port-forward).
+func liveSnapshots(ctx context.Context, c client.Client, vm *infrav1beta1.VirtualMachine) ([]contracts.SnapshotInfo, error) {
+	provider := &infrav1beta1.Provider{}
+	key := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Spec.ProviderRef.Name}
+	if vm.Spec.ProviderRef.Namespace != "" {
+		key.Namespace = vm.Spec.ProviderRef.Namespace
+	}
+	if err := c.Get(ctx, key, provider); err != nil {
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	instance, err := remote.NewResolver(c, nil).GetProvider(dialCtx, provider)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := instance.(contracts.SnapshotLister)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not list snapshots", provider.Name)
+	}
+	return lister.SnapshotList(dialCtx, vm.Status.ID)
+}
+
+// listSnapshotResources prints the VMSnapshot resources in the namespace,
+// limited to one VM when vmName is set.
+func listSnapshotResources(ctx context.Context, c client.Client, vmName string) error {
+	list := &infrav1beta1.VMSnapshotList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list VMSnapshots: %w", err)
+	}
+
+	var snapshots []*infrav1beta1.VMSnapshot
+	for i := range list.Items {
+		if vmName == "" || list.Items[i].Spec.VMRef.Name == vmName {
+			snapshots = append(snapshots, &list.Items[i])
+		}
+	}
+	return printList(os.Stdout, list, snapshots, snapshotColumns(time.Now()))
 }
 
 func revertSnapshot(cmd *cobra.Command, args []string) error {
@@ -424,8 +1013,19 @@ func runClone(cmd *cobra.Command, args []string) error {
 }
 
 func listClones(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Listing clone operations (not implemented)\n")
-	return nil
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	list := &infrav1beta1.VMCloneList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list VMClones: %w", err)
+	}
+	return printList(os.Stdout, list, itemPointers(list.Items), cloneColumns(time.Now()))
 }
 
 func runConformance(cmd *cobra.Command, args []string) error {
@@ -448,7 +1048,14 @@ func getClient() (client.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return client.New(cfg, client.Options{})
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := infrav1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
 }
 
 func getClientset() (kubernetes.Interface, error) {
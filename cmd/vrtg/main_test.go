@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func TestVMSummary(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-4*24*time.Hour - 3*time.Minute)
+	vm := &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Status: infrav1beta1.VirtualMachineStatus{Conditions: []metav1.Condition{{
+			Type:               infrav1beta1.VirtualMachineConditionReady,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-4 * 24 * time.Hour)),
+		}}},
+	}
+	assert.Equal(t, "Ready for 4d", vmSummary(vm, now))
+
+	vm.Status.Conditions = append(vm.Status.Conditions, metav1.Condition{
+		Type:               infrav1beta1.VirtualMachineConditionProvisioning,
+		Status:             metav1.ConditionFalse,
+		Reason:             k8s.ReasonReconcileSuccess,
+		LastTransitionTime: metav1.NewTime(created.Add(2*time.Minute + 13*time.Second)),
+	})
+	assert.Equal(t, "Ready for 4d (provisioned in 2m13s)", vmSummary(vm, now))
+}
+
+func TestPrintProviderCapabilities(t *testing.T) {
+	provider := &infrav1beta1.Provider{
+		Spec: infrav1beta1.ProviderSpec{
+			Type:                 infrav1beta1.ProviderTypeLibvirt,
+			RequiredCapabilities: []string{"image_import", "snapshots"},
+		},
+	}
+
+	var out bytes.Buffer
+	printProviderCapabilities(&out, provider)
+	assert.Contains(t, out.String(), "image_import             yes       unknown")
+
+	provider.Status.ReportedCapabilities = &infrav1beta1.ReportedCapabilities{
+		CapabilityManifest: []string{"create", "snapshots"},
+	}
+	out.Reset()
+	printProviderCapabilities(&out, provider)
+	assert.Contains(t, out.String(), "image_import             yes       no")
+	assert.Contains(t, out.String(), "snapshots                yes       yes")
+	assert.Contains(t, out.String(), "create                   -         yes")
+	assert.NotContains(t, out.String(), "no capability manifest")
+
+	out.Reset()
+	printProviderCapabilities(&out, &infrav1beta1.Provider{})
+	assert.Empty(t, out.String())
+}
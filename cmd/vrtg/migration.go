@@ -0,0 +1,621 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/cli/printers"
+	"github.com/projectbeskar/virtrigaud/internal/controller"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+)
+
+// Exit codes of `migration run`, besides 0 for a migration that became Ready.
+const (
+	exitMigrationFailed  = 1
+	exitMigrationTimeout = 2
+)
+
+// defaultStorageClassAnnotation marks the cluster's default StorageClass.
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+var (
+	// migrationPoll is how often `migration run` and `migration cancel`
+	// read the VMMigration.
+	migrationPoll = 2 * time.Second
+
+	migrationRun         migrationRunOptions
+	migrationWaitTimeout time.Duration
+	cancelWaitTimeout    time.Duration
+)
+
+// migrationRunOptions are the flags of `migration run`.
+type migrationRunOptions struct {
+	name           string
+	targetProvider string
+	targetName     string
+	storageClass   string
+	size           string
+	mode           string
+	deleteSource   bool
+}
+
+func newMigrationCmd() *cobra.Command {
+	migrationCmd := &cobra.Command{
+		Use:     "migration",
+		Aliases: []string{"migrations", "vmmigration"},
+		Short:   "Migrate virtual machines between providers",
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run <vm> --target-provider <provider>",
+		Short: "Migrate a virtual machine to another provider and follow the migration",
+		Long: `Create a VMMigration moving a VirtualMachine to another provider, then follow
+it phase by phase until it finishes.
+
+The disk is staged on a PVC the migration creates, of --storage-class (the
+cluster's default StorageClass when unset) and --size (the VM's root disk
+plus 10% when unset). Both providers must report disk export and import.
+
+--mode warm exports a snapshot of the running VM; cold powers it off first.
+With --delete-source the source VM is deleted once the migration is Ready.
+
+Exits 0 when the migration is Ready, 1 when it fails and 2 when it is still
+running after --wait-timeout. Interrupting vrtg does not stop the
+migration; use "vrtg migration cancel" for that.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeVMNames,
+		RunE:              runMigration,
+	}
+	runCmd.Flags().StringVar(&migrationRun.targetProvider, "target-provider", "", "Provider to migrate the VM to")
+	runCmd.Flags().StringVar(&migrationRun.targetName, "target-name", "", "Name of the migrated VM (default <vm>-migrated)")
+	runCmd.Flags().StringVar(&migrationRun.name, "name", "", "Name of the VMMigration (default generated from the VM and provider)")
+	runCmd.Flags().StringVar(&migrationRun.storageClass, "storage-class", "", "StorageClass of the staging PVC (default the cluster's default class)")
+	runCmd.Flags().StringVar(&migrationRun.size, "size", "", "Size of the staging PVC, e.g. 100Gi (default the root disk plus 10%)")
+	runCmd.Flags().StringVar(&migrationRun.mode, "mode", "warm", "warm exports a snapshot of the running VM; cold powers it off first")
+	runCmd.Flags().BoolVar(&migrationRun.deleteSource, "delete-source", false, "Delete the source VM once the migration is Ready")
+	runCmd.Flags().DurationVar(&migrationWaitTimeout, "wait-timeout", 6*time.Hour, "How long to follow the migration before exiting with code 2")
+	_ = runCmd.MarkFlagRequired("target-provider")
+	_ = runCmd.RegisterFlagCompletionFunc("target-provider", completeProviderNames)
+	_ = runCmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions([]string{"warm", "cold"}, cobra.ShellCompDirectiveNoFileComp))
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <name>",
+		Short: "Cancel a migration and check its snapshot and staging PVC are cleaned up",
+		Long: `Delete a VMMigration and wait for the controller to clean up after it: the
+staging PVC, the staged disk, the snapshot the migration took of the source
+VM and, for a migration that had not finished, the partially created target
+VM. Exits 1 when any of these is left behind.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeMigrationNames,
+		RunE:              cancelMigration,
+	}
+	cancelCmd.Flags().DurationVar(&cancelWaitTimeout, "wait-timeout", 5*time.Minute, "How long to wait for the cleanup")
+
+	migrationCmd.AddCommand(
+		runCmd,
+		addListFlags(&cobra.Command{
+			Use:   "list",
+			Short: "List migrations",
+			Args:  cobra.NoArgs,
+			RunE:  listMigrations,
+		}),
+		&cobra.Command{
+			Use:               "describe <name>",
+			Short:             "Describe a migration, its phases and the disk it moved",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeMigrationNames,
+			RunE:              describeMigration,
+		},
+		cancelCmd,
+	)
+	return migrationCmd
+}
+
+func runMigration(cmd *cobra.Command, args []string) error {
+	opts := migrationRun
+	if opts.mode != "warm" && opts.mode != "cold" {
+		return fmt.Errorf("--mode must be warm or cold, not %q", opts.mode)
+	}
+	if opts.size != "" {
+		if _, err := resource.ParseQuantity(opts.size); err != nil {
+			return fmt.Errorf("invalid --size %q: %w", opts.size, err)
+		}
+	}
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	vm := &infrav1beta1.VirtualMachine{}
+	if err := c.Get(reqCtx, types.NamespacedName{Namespace: namespace, Name: args[0]}, vm); err != nil {
+		return fmt.Errorf("failed to get VirtualMachine: %w", err)
+	}
+	if vm.Status.ID == "" {
+		return fmt.Errorf("VM %s is not provisioned yet", vm.Name)
+	}
+	source := &infrav1beta1.Provider{}
+	sourceKey := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Spec.ProviderRef.Name}
+	if vm.Spec.ProviderRef.Namespace != "" {
+		sourceKey.Namespace = vm.Spec.ProviderRef.Namespace
+	}
+	if err := c.Get(reqCtx, sourceKey, source); err != nil {
+		return fmt.Errorf("failed to get source provider: %w", err)
+	}
+	target := &infrav1beta1.Provider{}
+	if err := c.Get(reqCtx, types.NamespacedName{Namespace: namespace, Name: opts.targetProvider}, target); err != nil {
+		return fmt.Errorf("failed to get target provider: %w", err)
+	}
+	if source.Name == target.Name && source.Namespace == target.Namespace {
+		return fmt.Errorf("VM %s is already on provider %s", vm.Name, target.Name)
+	}
+	if problem := controller.MigrationCapabilityProblem(source, target); problem != "" {
+		return fmt.Errorf("%s (source capabilities: %s; target capabilities: %s)", problem,
+			capabilitySummary(source.Status.ReportedCapabilities), capabilitySummary(target.Status.ReportedCapabilities))
+	}
+
+	if opts.storageClass == "" {
+		if opts.storageClass, err = defaultStorageClass(reqCtx, c); err != nil {
+			return err
+		}
+	}
+	if opts.size == "" {
+		if opts.size = stagingSize(vm); opts.size == "" {
+			return fmt.Errorf("VM %s has no applied root disk size to size the staging PVC from; pass --size", vm.Name)
+		}
+	}
+
+	migration := newMigration(vm, opts)
+	if err := c.Create(reqCtx, migration); err != nil {
+		return fmt.Errorf("failed to create VMMigration: %w", err)
+	}
+	fmt.Printf("Created VMMigration %s: %s on %s -> %s on %s (%s, staging PVC %s of %s)\n", migration.Name,
+		vm.Name, source.Name, migration.Spec.Target.Name, target.Name, opts.mode, opts.size, opts.storageClass)
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, migrationWaitTimeout)
+	defer cancelWait()
+	return followMigration(waitCtx, c, client.ObjectKeyFromObject(migration), os.Stdout)
+}
+
+// newMigration builds the VMMigration `migration run` creates for vm.
+func newMigration(vm *infrav1beta1.VirtualMachine, opts migrationRunOptions) *infrav1beta1.VMMigration {
+	targetName := opts.targetName
+	if targetName == "" {
+		targetName = vm.Name + "-migrated"
+	}
+	m := &infrav1beta1.VMMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: vm.Namespace, Name: opts.name},
+		Spec: infrav1beta1.VMMigrationSpec{
+			Source: infrav1beta1.MigrationSource{
+				VMRef:                   infrav1beta1.LocalObjectReference{Name: vm.Name},
+				CreateSnapshot:          opts.mode == "warm",
+				PowerOffBeforeMigration: opts.mode == "cold",
+				DeleteAfterMigration:    opts.deleteSource,
+			},
+			Target: infrav1beta1.MigrationTarget{
+				Name:        targetName,
+				ProviderRef: infrav1beta1.ObjectRef{Name: opts.targetProvider},
+				PowerOn:     vm.Spec.PowerState == infrav1beta1.PowerStateOn,
+			},
+			Storage: &infrav1beta1.MigrationStorage{
+				Type: "pvc",
+				PVC: &infrav1beta1.PVCStorageConfig{
+					StorageClassName: opts.storageClass,
+					Size:             opts.size,
+				},
+			},
+			Metadata: &infrav1beta1.MigrationMetadata{CreatedBy: "vrtg"},
+		},
+	}
+	if opts.name == "" {
+		m.GenerateName = fmt.Sprintf("%s-to-%s-", vm.Name, opts.targetProvider)
+	}
+	return m
+}
+
+// defaultStorageClass returns the name of the cluster's default
+// StorageClass.
+func defaultStorageClass(ctx context.Context, c client.Client) (string, error) {
+	list := &storagev1.StorageClassList{}
+	if err := c.List(ctx, list); err != nil {
+		return "", fmt.Errorf("failed to list StorageClasses: %w", err)
+	}
+	for _, sc := range list.Items {
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" {
+			return sc.Name, nil
+		}
+	}
+	return "", fmt.Errorf("the cluster has no default StorageClass; pass --storage-class")
+}
+
+// stagingSize sizes the staging PVC from the VM's applied root disk, with
+// 10% headroom for the image format and filesystem, or returns "" when the
+// VM has none recorded.
+func stagingSize(vm *infrav1beta1.VirtualMachine) string {
+	if vm.Status.AppliedConfig == nil {
+		return ""
+	}
+	for _, d := range vm.Status.AppliedConfig.Disks {
+		if d.Name == "root" && d.SizeGiB > 0 {
+			return fmt.Sprintf("%dGi", (int64(d.SizeGiB)*11+9)/10)
+		}
+	}
+	return ""
+}
+
+// followMigration prints the phases the migration goes through, with the
+// time spent in each and its progress percentage once the status reports
+// one, until it is Ready or has failed for good. A failure or ctx ending
+// first return an exitError.
+func followMigration(ctx context.Context, c client.Client, key types.NamespacedName, out io.Writer) error {
+	start := time.Now()
+	var phase infrav1beta1.MigrationPhase
+	var phaseStart time.Time
+	var percentage int32 = -1
+	var retries int32
+	for {
+		m := &infrav1beta1.VMMigration{}
+		err := c.Get(ctx, key, m)
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err):
+			return exitError{code: exitMigrationFailed, err: fmt.Errorf("VMMigration %s was deleted", key.Name)}
+		case ctx.Err() == nil:
+			// A lost API call does not end a migration; try again.
+			_, _ = fmt.Fprintf(out, "  (failed to read VMMigration: %v)\n", err)
+		}
+
+		now := time.Now()
+		if err == nil && m.Status.Phase != phase {
+			if phase != "" {
+				_, _ = fmt.Fprintf(out, "  %-18s %s\n", phase, duration.HumanDuration(now.Sub(phaseStart)))
+			}
+			phase, phaseStart, percentage = m.Status.Phase, now, -1
+		}
+		if err == nil && m.Status.Progress != nil && m.Status.Progress.Percentage != nil &&
+			*m.Status.Progress.Percentage != percentage {
+			percentage = *m.Status.Progress.Percentage
+			_, _ = fmt.Fprintf(out, "  %-18s %d%%\n", phase, percentage)
+		}
+		if err == nil {
+			switch {
+			case phase == infrav1beta1.MigrationPhaseReady:
+				_, _ = fmt.Fprintf(out, "Migration %s is Ready after %s; the migrated VM is %s\n",
+					m.Name, duration.HumanDuration(now.Sub(start)), migratedVMName(m))
+				return nil
+			case controller.MigrationFailedForGood(m):
+				return exitError{code: exitMigrationFailed, err: fmt.Errorf("migration %s failed after %s: %s",
+					m.Name, duration.HumanDuration(now.Sub(start)), m.Status.Message)}
+			case phase == infrav1beta1.MigrationPhaseFailed && m.Status.RetryCount == retries:
+				retries = m.Status.RetryCount + 1
+				_, _ = fmt.Fprintf(out, "  Failed, to be retried: %s\n", m.Status.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			state := string(phase)
+			if state == "" {
+				state = "Pending"
+			}
+			err := fmt.Errorf("stopped following migration %s after %s; it is still %s (vrtg migration describe %s)",
+				key.Name, duration.HumanDuration(time.Since(start)), state, key.Name)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return exitError{code: exitMigrationTimeout, err: err}
+			}
+			return exitError{code: exitMigrationFailed, err: err}
+		case <-time.After(migrationPoll):
+		}
+	}
+}
+
+// migratedVMName is the namespace/name of the VM a migration creates.
+func migratedVMName(m *infrav1beta1.VMMigration) string {
+	ns := m.Spec.Target.Namespace
+	if ns == "" {
+		ns = m.Namespace
+	}
+	name := m.Spec.Target.Name
+	if m.Status.TargetVMRef != nil {
+		name = m.Status.TargetVMRef.Name
+	}
+	return ns + "/" + name
+}
+
+func listMigrations(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	list := &infrav1beta1.VMMigrationList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list VMMigrations: %w", err)
+	}
+	return printList(os.Stdout, list, itemPointers(list.Items), migrationColumns(time.Now()))
+}
+
+// migrationPercentage renders the progress the status reports, if any.
+func migrationPercentage(m *infrav1beta1.VMMigration) string {
+	if m.Status.Progress == nil || m.Status.Progress.Percentage == nil {
+		return printers.None
+	}
+	return fmt.Sprintf("%d%%", *m.Status.Progress.Percentage)
+}
+
+func describeMigration(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	m := &infrav1beta1.VMMigration{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: args[0]}, m); err != nil {
+		return fmt.Errorf("failed to get VMMigration: %w", err)
+	}
+	if output != "table" {
+		return outputResource(m)
+	}
+	printMigration(os.Stdout, m, time.Now())
+	return nil
+}
+
+// printMigration writes the `migration describe` view of m.
+func printMigration(w io.Writer, m *infrav1beta1.VMMigration, now time.Time) {
+	field := func(name, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(w, "%s: %s\n", name, value)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%s\n\n", printers.ReadySummary(m.Status.Conditions, now))
+	field("Name", m.Name)
+	field("Namespace", m.Namespace)
+	source := m.Spec.Source.VMRef.Name
+	if m.Spec.Source.ProviderRef != nil {
+		source += " on " + m.Spec.Source.ProviderRef.Name
+	}
+	field("Source", source)
+	field("Target", migratedVMName(m)+" on "+m.Spec.Target.ProviderRef.Name)
+	mode := "warm"
+	if m.Spec.Source.PowerOffBeforeMigration {
+		mode = "cold"
+	}
+	field("Mode", mode)
+	if m.Spec.Source.DeleteAfterMigration {
+		field("Delete Source", "true")
+	}
+	if s := m.Spec.Storage; s != nil {
+		storage := s.Type
+		if s.PVC != nil {
+			storage = fmt.Sprintf("pvc %s", orNone(m.Status.StoragePVCName))
+			if s.PVC.Name == "" {
+				storage += fmt.Sprintf(" (%s of %s)", s.PVC.Size, s.PVC.StorageClassName)
+			}
+		}
+		field("Storage", storage)
+	}
+	field("Phase", string(m.Status.Phase))
+	field("Message", m.Status.Message)
+	if p := m.Status.Progress; p != nil {
+		progress := migrationPercentage(m)
+		if p.TransferredBytes != nil && p.TotalBytes != nil {
+			progress += fmt.Sprintf(" (%s of %s)", formatBytes(*p.TransferredBytes), formatBytes(*p.TotalBytes))
+		}
+		if p.ETA != nil {
+			progress += fmt.Sprintf(", %s left", duration.HumanDuration(p.ETA.Duration))
+		}
+		field("Progress", progress)
+		if p.PhaseStartTime != nil {
+			field("Phase Started", fmt.Sprintf("%s (%s ago)", p.PhaseStartTime.Format(time.RFC3339),
+				printers.Age(p.PhaseStartTime.Time, now)))
+		}
+	}
+	field("Snapshot", m.Status.SnapshotID)
+	if m.Status.RetryCount > 0 {
+		field("Retries", fmt.Sprint(m.Status.RetryCount))
+	}
+	if m.Status.StartTime != nil {
+		field("Started", fmt.Sprintf("%s (%s ago)", m.Status.StartTime.Format(time.RFC3339), printers.Age(m.Status.StartTime.Time, now)))
+	}
+	if m.Status.CompletionTime != nil {
+		finished := m.Status.CompletionTime.Format(time.RFC3339)
+		if m.Status.StartTime != nil {
+			finished += fmt.Sprintf(" (took %s)", duration.HumanDuration(m.Status.CompletionTime.Sub(m.Status.StartTime.Time)))
+		}
+		field("Finished", finished)
+	}
+	field("Created", fmt.Sprintf("%s (%s ago)", m.CreationTimestamp.Format(time.RFC3339), printers.Age(m.CreationTimestamp.Time, now)))
+
+	if d := m.Status.DiskInfo; d != nil {
+		quantity := func(q *resource.Quantity) string {
+			if q == nil {
+				return ""
+			}
+			return q.String()
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "\nDisk:\n")
+		_, _ = fmt.Fprintf(tw, "  \tDISK\tFORMAT\tSIZE\tCHECKSUM\n")
+		sourceSum := d.SourceChecksum
+		if sourceSum == "" {
+			sourceSum = d.Checksum
+		}
+		_, _ = fmt.Fprintf(tw, "  Source\t%s\t%s\t%s\t%s\n", orNone(d.SourceDiskID), orNone(d.SourceFormat),
+			orNone(quantity(d.SourceSize)), orNone(sourceSum))
+		_, _ = fmt.Fprintf(tw, "  Target\t%s\t%s\t%s\t%s\n", orNone(d.TargetDiskID), orNone(d.TargetFormat),
+			orNone(quantity(d.TargetSize)), orNone(d.TargetChecksum))
+		_ = tw.Flush()
+		if d.TargetPath != "" {
+			_, _ = fmt.Fprintf(w, "  Target Path: %s\n", d.TargetPath)
+		}
+	}
+	if v := m.Status.ValidationResults; v != nil && len(v.ValidationErrors) > 0 {
+		_, _ = fmt.Fprintf(w, "\nValidation Errors:\n")
+		for _, e := range v.ValidationErrors {
+			_, _ = fmt.Fprintf(w, "  %s\n", e)
+		}
+	}
+
+	printers.Conditions(w, m.Status.Conditions, now, printers.TerminalWidth())
+}
+
+func cancelMigration(cmd *cobra.Command, args []string) error {
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	m := &infrav1beta1.VMMigration{}
+	if err := c.Get(reqCtx, types.NamespacedName{Namespace: namespace, Name: args[0]}, m); err != nil {
+		return fmt.Errorf("failed to get VMMigration: %w", err)
+	}
+	if m.Status.Phase == infrav1beta1.MigrationPhaseReady {
+		fmt.Printf("Migration %s already finished; deleting it keeps the migrated VM %s\n", m.Name, migratedVMName(m))
+	}
+	if err := c.Delete(reqCtx, m); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete VMMigration: %w", err)
+	}
+	fmt.Printf("Deleted VMMigration %s; waiting for its cleanup\n", m.Name)
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, cancelWaitTimeout)
+	defer cancelWait()
+	if err := waitForDeletion(waitCtx, c, m); err != nil {
+		return err
+	}
+
+	checkCtx, cancelCheck := context.WithTimeout(ctx, timeout)
+	defer cancelCheck()
+	problems := migrationLeftovers(checkCtx, c, m, os.Stdout)
+	recorded, err := objectEvents(checkCtx, "VMMigration", m.Name)
+	if err != nil {
+		fmt.Printf("  Could not read the cleanup events: %v\n", err)
+	}
+	for _, e := range recorded {
+		if e.InvolvedObject.UID == m.UID && e.Reason == events.ReasonCleanupErrors {
+			problems = append(problems, fmt.Sprintf("the controller reported: %s", e.Message))
+		}
+	}
+	if len(problems) > 0 {
+		return exitError{code: exitMigrationFailed,
+			err: fmt.Errorf("migration %s left things behind: %s", m.Name, strings.Join(problems, "; "))}
+	}
+	fmt.Printf("Migration %s is cancelled and cleaned up\n", m.Name)
+	return nil
+}
+
+// waitForDeletion polls until the VMMigration is gone, i.e. the controller
+// ran its cleanup and removed the finalizer.
+func waitForDeletion(ctx context.Context, c client.Client, m *infrav1beta1.VMMigration) error {
+	for {
+		err := c.Get(ctx, client.ObjectKeyFromObject(m), &infrav1beta1.VMMigration{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return exitError{code: exitMigrationTimeout, err: fmt.Errorf(
+				"VMMigration %s is still being deleted; is the manager running? (%w)", m.Name, ctx.Err())}
+		case <-time.After(migrationPoll):
+		}
+	}
+}
+
+// migrationLeftovers checks that the staging PVC and the snapshot of the
+// source VM a deleted migration recorded are gone, printing what it found,
+// and returns what is left.
+func migrationLeftovers(ctx context.Context, c client.Client, m *infrav1beta1.VMMigration, out io.Writer) []string {
+	var problems []string
+	if pvcName := m.Status.StoragePVCName; pvcName != "" {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: pvcName}, pvc)
+		switch {
+		case apierrors.IsNotFound(err):
+			_, _ = fmt.Fprintf(out, "  Staging PVC %s deleted\n", pvcName)
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("could not check staging PVC %s: %v", pvcName, err))
+		case pvc.DeletionTimestamp != nil:
+			_, _ = fmt.Fprintf(out, "  Staging PVC %s is being deleted\n", pvcName)
+		default:
+			problems = append(problems, fmt.Sprintf("staging PVC %s still exists", pvcName))
+		}
+	}
+
+	snapshotID := m.Status.SnapshotID
+	keptByPolicy := m.Spec.Options != nil && m.Spec.Options.CleanupPolicy == infrav1beta1.CleanupPolicyNever
+	switch {
+	case snapshotID == "":
+	case m.Spec.Source.SnapshotRef != nil:
+		_, _ = fmt.Fprintf(out, "  Snapshot %s was not taken by the migration and is kept\n", snapshotID)
+	case keptByPolicy:
+		_, _ = fmt.Fprintf(out, "  Snapshot %s is kept (cleanupPolicy: Never)\n", snapshotID)
+	default:
+		vm := &infrav1beta1.VirtualMachine{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: m.Spec.Source.VMRef.Name}, vm); err != nil {
+			problems = append(problems, fmt.Sprintf("could not check snapshot %s: %v", snapshotID, err))
+			break
+		}
+		snaps, err := liveSnapshots(ctx, c, vm)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("could not check snapshot %s: %v", snapshotID, err))
+			break
+		}
+		for _, s := range snaps {
+			if s.ID == snapshotID {
+				problems = append(problems, fmt.Sprintf("snapshot %s of VM %s still exists", snapshotID, vm.Name))
+				return problems
+			}
+		}
+		_, _ = fmt.Fprintf(out, "  Snapshot %s of VM %s deleted\n", snapshotID, vm.Name)
+	}
+	return problems
+}
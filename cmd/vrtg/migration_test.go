@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func migrationScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestNewMigration(t *testing.T) {
+	vm := &infrav1beta1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"}}
+	vm.Spec.PowerState = infrav1beta1.PowerStateOn
+
+	m := newMigration(vm, migrationRunOptions{targetProvider: "pve", storageClass: "fast", size: "22Gi", mode: "warm"})
+	assert.Equal(t, "web-to-pve-", m.GenerateName)
+	assert.Equal(t, "apps", m.Namespace)
+	assert.Equal(t, "web", m.Spec.Source.VMRef.Name)
+	assert.True(t, m.Spec.Source.CreateSnapshot)
+	assert.False(t, m.Spec.Source.PowerOffBeforeMigration)
+	assert.Equal(t, "web-migrated", m.Spec.Target.Name)
+	assert.Equal(t, "pve", m.Spec.Target.ProviderRef.Name)
+	assert.True(t, m.Spec.Target.PowerOn, "a running VM is powered on after the migration")
+	assert.Equal(t, &infrav1beta1.MigrationStorage{Type: "pvc",
+		PVC: &infrav1beta1.PVCStorageConfig{StorageClassName: "fast", Size: "22Gi"}}, m.Spec.Storage)
+
+	m = newMigration(vm, migrationRunOptions{name: "move-web", targetProvider: "pve", targetName: "web", mode: "cold", deleteSource: true})
+	assert.Equal(t, "move-web", m.Name)
+	assert.Empty(t, m.GenerateName)
+	assert.False(t, m.Spec.Source.CreateSnapshot)
+	assert.True(t, m.Spec.Source.PowerOffBeforeMigration)
+	assert.True(t, m.Spec.Source.DeleteAfterMigration)
+	assert.Equal(t, "web", m.Spec.Target.Name)
+}
+
+func TestStagingSizeAndStorageClass(t *testing.T) {
+	vm := &infrav1beta1.VirtualMachine{}
+	assert.Empty(t, stagingSize(vm))
+	vm.Status.AppliedConfig = &infrav1beta1.VirtualMachineAppliedConfig{Disks: []infrav1beta1.AppliedDisk{
+		{Name: "data", SizeGiB: 500}, {Name: "root", SizeGiB: 40},
+	}}
+	assert.Equal(t, "44Gi", stagingSize(vm))
+	vm.Status.AppliedConfig.Disks[1].SizeGiB = 25
+	assert.Equal(t, "28Gi", stagingSize(vm), "rounded up")
+
+	c := fake.NewClientBuilder().WithScheme(migrationScheme(t)).Build()
+	_, err := defaultStorageClass(context.Background(), c)
+	assert.ErrorContains(t, err, "--storage-class")
+
+	c = fake.NewClientBuilder().WithScheme(migrationScheme(t)).WithObjects(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "slow"}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast",
+			Annotations: map[string]string{defaultStorageClassAnnotation: "true"}}},
+	).Build()
+	name, err := defaultStorageClass(context.Background(), c)
+	require.NoError(t, err)
+	assert.Equal(t, "fast", name)
+}
+
+// steppedMigration serves the given statuses of the VMMigration "web-to-pve"
+// one per read, repeating the last.
+func steppedMigration(t *testing.T, statuses ...infrav1beta1.VMMigrationStatus) client.Client {
+	t.Helper()
+	m := &infrav1beta1.VMMigration{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-to-pve"}}
+	m.Spec.Target.Name = "web-migrated"
+	reads := 0
+	return fake.NewClientBuilder().WithScheme(migrationScheme(t)).WithObjects(m).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				obj.(*infrav1beta1.VMMigration).Status = statuses[min(reads, len(statuses)-1)]
+				reads++
+				return nil
+			},
+		}).Build()
+}
+
+func useMigrationPoll(t *testing.T) {
+	prev := migrationPoll
+	migrationPoll = time.Millisecond
+	t.Cleanup(func() { migrationPoll = prev })
+}
+
+func TestFollowMigration(t *testing.T) {
+	useMigrationPoll(t)
+	key := types.NamespacedName{Namespace: "default", Name: "web-to-pve"}
+	progress := func(p int32) *infrav1beta1.MigrationProgress {
+		return &infrav1beta1.MigrationProgress{Percentage: ptr.To(p)}
+	}
+
+	c := steppedMigration(t,
+		infrav1beta1.VMMigrationStatus{},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseValidating},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseExporting, Progress: progress(10)},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseExporting, Progress: progress(10)},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseExporting, Progress: progress(60)},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseReady},
+	)
+	var out bytes.Buffer
+	require.NoError(t, followMigration(context.Background(), c, key, &out))
+	lines := out.String()
+	assert.Regexp(t, `(?m)^  Validating +\d+[a-z]+\n  Exporting +10%\n  Exporting +60%\n  Exporting +\d+[a-z]+\n`, lines)
+	assert.Contains(t, lines, "Migration web-to-pve is Ready after")
+	assert.Contains(t, lines, "the migrated VM is default/web-migrated")
+}
+
+func TestFollowMigrationExitCodes(t *testing.T) {
+	useMigrationPoll(t)
+	key := types.NamespacedName{Namespace: "default", Name: "web-to-pve"}
+
+	c := steppedMigration(t,
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseExporting},
+		infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseFailed, Message: "export failed"},
+	)
+	var exit exitError
+	err := followMigration(context.Background(), c, key, &bytes.Buffer{})
+	require.True(t, errors.As(err, &exit))
+	assert.Equal(t, exitMigrationFailed, exit.code)
+	assert.ErrorContains(t, err, "export failed")
+
+	c = steppedMigration(t, infrav1beta1.VMMigrationStatus{Phase: infrav1beta1.MigrationPhaseImporting})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = followMigration(ctx, c, key, &bytes.Buffer{})
+	require.True(t, errors.As(err, &exit))
+	assert.Equal(t, exitMigrationTimeout, exit.code)
+	assert.ErrorContains(t, err, "it is still Importing")
+}
+
+func TestPrintMigrationDiskInfo(t *testing.T) {
+	size := resource.MustParse("40Gi")
+	m := &infrav1beta1.VMMigration{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-to-pve"}}
+	m.Spec.Source.VMRef.Name = "web"
+	m.Spec.Target = infrav1beta1.MigrationTarget{Name: "web-migrated", ProviderRef: infrav1beta1.ObjectRef{Name: "pve"}}
+	m.Status.Phase = infrav1beta1.MigrationPhaseReady
+	m.Status.DiskInfo = &infrav1beta1.MigrationDiskInfo{
+		SourceDiskID: "disk-0", SourceFormat: "vmdk", SourceSize: &size, SourceChecksum: "abc123",
+		TargetFormat: "qcow2", TargetChecksum: "abc123", TargetPath: "/var/lib/vz/images/101/disk-0.qcow2",
+	}
+
+	var out bytes.Buffer
+	printMigration(&out, m, time.Now())
+	assert.Contains(t, out.String(), "Target: default/web-migrated on pve\n")
+	assert.Contains(t, out.String(), "Mode: warm\n")
+	assert.Regexp(t, `Source +disk-0 +vmdk +40Gi +abc123`, out.String())
+	assert.Regexp(t, `Target +<none> +qcow2 +<none> +abc123`, out.String())
+	assert.Contains(t, out.String(), "Target Path: /var/lib/vz/images/101/disk-0.qcow2")
+}
+
+func TestMigrationLeftovers(t *testing.T) {
+	m := &infrav1beta1.VMMigration{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-to-pve"}}
+	m.Status.StoragePVCName = "web-to-pve-storage"
+
+	c := fake.NewClientBuilder().WithScheme(migrationScheme(t)).Build()
+	var out bytes.Buffer
+	assert.Empty(t, migrationLeftovers(context.Background(), c, m, &out))
+	assert.Contains(t, out.String(), "Staging PVC web-to-pve-storage deleted")
+
+	c = fake.NewClientBuilder().WithScheme(migrationScheme(t)).WithObjects(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-to-pve-storage"}},
+	).Build()
+	assert.Equal(t, []string{"staging PVC web-to-pve-storage still exists"}, migrationLeftovers(context.Background(), c, m, &out))
+
+	// A snapshot the user named, or one cleanupPolicy Never keeps, is not
+	// expected to be gone.
+	m.Status.StoragePVCName = ""
+	m.Status.SnapshotID = "snap-1"
+	m.Spec.Options = &infrav1beta1.MigrationOptions{CleanupPolicy: infrav1beta1.CleanupPolicyNever}
+	out.Reset()
+	assert.Empty(t, migrationLeftovers(context.Background(), c, m, &out))
+	assert.Contains(t, out.String(), "Snapshot snap-1 is kept (cleanupPolicy: Never)")
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/controller"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+)
+
+// notReported fills a top column the provider did not report, so a missing
+// sample is not mistaken for an idle VM.
+const notReported = "-"
+
+var (
+	topSort     string
+	topWatch    bool
+	topInterval time.Duration
+	topLive     bool
+)
+
+func newTopCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show CPU, memory and uptime of the virtual machines in a namespace",
+		Long: `Show CPU, memory and uptime of the virtual machines in a namespace.
+
+Figures come from VirtualMachine status, which the controller refreshes on
+every reconcile. --live asks each provider directly instead; this needs a
+route to the provider Services, e.g. from inside the cluster or through a
+port-forward. Columns a provider does not report show "-".`,
+		Args: cobra.NoArgs,
+		RunE: vmTop,
+	}
+	cmd.Flags().StringVar(&topSort, "sort", "", "Sort by cpu or memory, highest first (default: by name)")
+	cmd.Flags().BoolVarP(&topWatch, "watch", "w", false, "Refresh until interrupted")
+	cmd.Flags().DurationVar(&topInterval, "interval", 5*time.Second, "Refresh interval with --watch")
+	cmd.Flags().BoolVar(&topLive, "live", false, "Query providers directly instead of reading VM status")
+	return cmd
+}
+
+// topRow is one VM in the top table. Nil figures were not reported.
+type topRow struct {
+	name      string
+	provider  string
+	cpu       *int32
+	memUsed   *int64
+	memAlloc  int64
+	uptimeSec *int64
+}
+
+func vmTop(cmd *cobra.Command, args []string) error {
+	if topSort != "" && topSort != "cpu" && topSort != "memory" {
+		return fmt.Errorf("invalid --sort %q: must be cpu or memory", topSort)
+	}
+	if topWatch && topInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	src := &topSource{client: c}
+	if topLive {
+		src.resolver = remote.NewResolver(c, nil)
+		defer src.resolver.CleanupAllClients()
+	}
+	table := &topTable{}
+	clearScreen := topWatch && term.IsTerminal(int(os.Stdout.Fd()))
+
+	for {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		rows, err := src.rows(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		sortTopRows(rows, topSort)
+		if clearScreen {
+			fmt.Print("\033[H\033[2J")
+		}
+		table.write(os.Stdout, rows)
+		if !topWatch {
+			return nil
+		}
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-time.After(topInterval):
+		}
+	}
+}
+
+// topSource gathers top rows from VM status, or from provider Describe
+// calls when resolver is set.
+type topSource struct {
+	client   client.Client
+	resolver *remote.Resolver
+	// warned records providers whose live query failure was reported, so
+	// --watch does not repeat the warning every refresh.
+	warned map[client.ObjectKey]bool
+}
+
+func (s *topSource) rows(ctx context.Context) ([]topRow, error) {
+	vms := &infrav1beta1.VirtualMachineList{}
+	if err := s.client.List(ctx, vms, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VMs: %w", err)
+	}
+
+	classes := map[client.ObjectKey]*infrav1beta1.VMClass{}
+	providers := map[client.ObjectKey]contracts.Provider{}
+	rows := make([]topRow, 0, len(vms.Items))
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		row := topRow{name: vm.Name, provider: vm.Spec.ProviderRef.Name, memAlloc: s.allocatedMemory(ctx, vm, classes)}
+
+		stats := vm.Status.GuestStats
+		if s.resolver != nil && vm.Status.ID != "" {
+			if live, ok := s.liveStats(ctx, vm, providers); ok {
+				stats = live
+			}
+		}
+		if stats != nil {
+			row.cpu, row.memUsed, row.uptimeSec = stats.CPUUsagePercent, stats.MemoryUsageBytes, stats.UptimeSeconds
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// allocatedMemory is the VM's memory in bytes: what the provider last
+// applied, else what its class and overrides ask for. Classes are looked
+// up once per refresh.
+func (s *topSource) allocatedMemory(ctx context.Context, vm *infrav1beta1.VirtualMachine, classes map[client.ObjectKey]*infrav1beta1.VMClass) int64 {
+	if cur := vm.Status.CurrentResources; cur != nil && cur.MemoryMiB != nil {
+		return *cur.MemoryMiB * 1024 * 1024
+	}
+	key := k8sutil.RefKey(vm.Spec.ClassRef, vm.Namespace)
+	class, seen := classes[key]
+	if !seen {
+		class, _ = vmclass.Get(ctx, s.client, key)
+		classes[key] = class
+	}
+	return usage.ResolveVMAllocation(vm, class).MemoryBytes
+}
+
+// liveStats describes vm on its provider. ok is false when the provider
+// cannot be reached, in which case the caller keeps the status figures.
+func (s *topSource) liveStats(ctx context.Context, vm *infrav1beta1.VirtualMachine, providers map[client.ObjectKey]contracts.Provider) (*infrav1beta1.VMGuestStats, bool) {
+	key := k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace)
+	instance, seen := providers[key]
+	if !seen {
+		provider := &infrav1beta1.Provider{}
+		err := s.client.Get(ctx, key, provider)
+		if err == nil {
+			instance, err = s.resolver.GetProvider(ctx, provider)
+		}
+		if err != nil {
+			s.warn(key, err)
+		}
+		providers[key] = instance
+	}
+	if instance == nil {
+		return nil, false
+	}
+	desc, err := instance.Describe(ctx, vm.Status.ID)
+	if err != nil {
+		s.warn(key, err)
+		return nil, false
+	}
+	return controller.GuestStatsStatus(desc.GuestStats, time.Now()), true
+}
+
+func (s *topSource) warn(provider client.ObjectKey, err error) {
+	if s.warned == nil {
+		s.warned = map[client.ObjectKey]bool{}
+	}
+	if s.warned[provider] {
+		return
+	}
+	s.warned[provider] = true
+	fmt.Fprintf(os.Stderr, "Cannot query provider %s live (%v); showing VM status instead\n", provider, err)
+}
+
+// sortTopRows orders rows by name, or by the given figure highest first.
+// VMs that do not report the figure sort last, and ties fall back to
+// name, so the order only changes when the numbers do.
+func sortTopRows(rows []topRow, by string) {
+	value := func(r topRow) (int64, bool) {
+		switch by {
+		case "cpu":
+			if r.cpu != nil {
+				return int64(*r.cpu), true
+			}
+		case "memory":
+			if r.memUsed != nil {
+				return *r.memUsed, true
+			}
+		}
+		return 0, false
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		vi, oki := value(rows[i])
+		vj, okj := value(rows[j])
+		if oki != okj {
+			return oki
+		}
+		if vi != vj {
+			return vi > vj
+		}
+		return rows[i].name < rows[j].name
+	})
+}
+
+// topTable prints rows with column widths that only ever grow, so a
+// --watch refresh does not shift the columns when a value gets shorter.
+type topTable struct {
+	widths []int
+}
+
+var topHeader = []string{"NAME", "PROVIDER", "CPU", "MEMORY", "MEMORY%", "UPTIME"}
+
+func (t *topTable) write(w io.Writer, rows []topRow) {
+	cells := [][]string{topHeader}
+	for _, r := range rows {
+		cells = append(cells, r.cells())
+	}
+	if t.widths == nil {
+		t.widths = make([]int, len(topHeader))
+	}
+	for _, line := range cells {
+		for i, cell := range line {
+			t.widths[i] = max(t.widths[i], len(cell))
+		}
+	}
+	for _, line := range cells {
+		var b strings.Builder
+		for i, cell := range line {
+			if i == len(line)-1 {
+				b.WriteString(cell)
+				break
+			}
+			fmt.Fprintf(&b, "%-*s   ", t.widths[i], cell)
+		}
+		fmt.Fprintln(w, b.String())
+	}
+}
+
+func (r topRow) cells() []string {
+	cpu, mem, memPct, uptime := notReported, notReported, notReported, notReported
+	if r.cpu != nil {
+		cpu = fmt.Sprintf("%d%%", *r.cpu)
+	}
+	alloc := notReported
+	if r.memAlloc > 0 {
+		alloc = formatBytes(r.memAlloc)
+	}
+	if r.memUsed != nil {
+		mem = formatBytes(*r.memUsed) + "/" + alloc
+		if r.memAlloc > 0 {
+			memPct = fmt.Sprintf("%d%%", *r.memUsed*100/r.memAlloc)
+		}
+	} else if r.memAlloc > 0 {
+		mem = notReported + "/" + alloc
+	}
+	if r.uptimeSec != nil {
+		uptime = duration.HumanDuration(time.Duration(*r.uptimeSec) * time.Second)
+	}
+	return []string{r.name, r.provider, cpu, mem, memPct, uptime}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func rowNames(rows []topRow) []string {
+	names := make([]string, len(rows))
+	for i, r := range rows {
+		names[i] = r.name
+	}
+	return names
+}
+
+func TestSortTopRows(t *testing.T) {
+	rows := []topRow{
+		{name: "web-2", cpu: ptr.To(int32(10)), memUsed: ptr.To(int64(4 << 30))},
+		{name: "db", cpu: ptr.To(int32(80))},
+		{name: "cache"},
+		{name: "web-1", cpu: ptr.To(int32(10)), memUsed: ptr.To(int64(1 << 30))},
+	}
+
+	sortTopRows(rows, "")
+	assert.Equal(t, []string{"cache", "db", "web-1", "web-2"}, rowNames(rows))
+
+	sortTopRows(rows, "cpu")
+	assert.Equal(t, []string{"db", "web-1", "web-2", "cache"}, rowNames(rows),
+		"ties break by name and unreported VMs go last")
+
+	sortTopRows(rows, "memory")
+	assert.Equal(t, []string{"web-2", "web-1", "cache", "db"}, rowNames(rows))
+}
+
+func TestTopRowCells(t *testing.T) {
+	full := topRow{
+		name: "web", provider: "vsphere",
+		cpu: ptr.To(int32(42)), memUsed: ptr.To(int64(1 << 30)), memAlloc: 4 << 30,
+		uptimeSec: ptr.To(int64(90061)),
+	}
+	assert.Equal(t, []string{"web", "vsphere", "42%", "1.0GiB/4.0GiB", "25%", "25h"}, full.cells())
+
+	// An idle VM reports 0%; one whose provider reports nothing shows dashes.
+	idle := topRow{name: "idle", provider: "libvirt", cpu: ptr.To(int32(0)), memAlloc: 2 << 30}
+	assert.Equal(t, []string{"idle", "libvirt", "0%", "-/2.0GiB", "-", "-"}, idle.cells())
+
+	bare := topRow{name: "bare", provider: "libvirt"}
+	assert.Equal(t, []string{"bare", "libvirt", "-", "-", "-", "-"}, bare.cells())
+}
+
+func TestTopTableWidthsOnlyGrow(t *testing.T) {
+	table := &topTable{}
+	var first, second bytes.Buffer
+	table.write(&first, []topRow{{name: "a-long-vm-name", provider: "vsphere"}})
+	table.write(&second, []topRow{{name: "a", provider: "vsphere"}})
+
+	header := func(b *bytes.Buffer) string { return strings.SplitN(b.String(), "\n", 2)[0] }
+	assert.Equal(t, header(&first), header(&second), "columns must not move when values shrink")
+}
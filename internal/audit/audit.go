@@ -0,0 +1,230 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every provider RPC the manager makes, with the
+// object whose reconcile made it. Records go to the "audit" logger as one
+// JSON line when the call starts and one when it ends; the provider's own
+// audit interceptor (sdk/provider/middleware) logs the same calls under the
+// same correlation ID.
+//
+// Reconcilers tag their context with WithSubject once they have read the
+// object; the client interceptor returned by UnaryClientInterceptor picks
+// the subject up from there. Record adds records of other access, such as
+// console sessions, to the same log.
+package audit
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
+)
+
+// LoggerName is the name of the audit logger, and the value of the
+// "logger" key on every record.
+const LoggerName = middleware.AuditLogger
+
+type settings struct {
+	level  middleware.AuditLevel
+	logger logr.Logger
+}
+
+// current is off until Configure is called, so that tools sharing the
+// provider client, such as vrtg, do not audit.
+var current atomic.Pointer[settings]
+
+func init() {
+	current.Store(&settings{level: middleware.AuditOff, logger: logr.Discard()})
+}
+
+// Configure sets the audit level and the logger records are written to.
+// The logger is named LoggerName.
+func Configure(level middleware.AuditLevel, logger logr.Logger) {
+	current.Store(&settings{level: level, logger: logger.WithName(LoggerName)})
+}
+
+// NewFileLogger returns a logger that appends JSON records to path, and a
+// function that flushes and closes the file.
+func NewFileLogger(path string) (logr.Logger, func() error, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return logr.Logger{}, nil, err
+	}
+	encoder := zap.NewProductionEncoderConfig()
+	encoder.EncodeTime = zapcore.ISO8601TimeEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoder), zapcore.AddSync(f), zapcore.InfoLevel)
+	zl := zap.New(core)
+	return zapr.NewLogger(zl), func() error {
+		_ = zl.Sync()
+		return f.Close()
+	}, nil
+}
+
+// Record writes an audit record of something other than a provider RPC,
+// such as a console session, unless auditing is off.
+func Record(message string, keysAndValues ...interface{}) {
+	s := current.Load()
+	if s.level == middleware.AuditOff {
+		return
+	}
+	s.logger.Info(message, keysAndValues...)
+}
+
+// Subject identifies the object whose reconcile calls a provider.
+type Subject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+	// ResourceVersion is the version of the object the reconcile read, that
+	// is, of the change that led to the call.
+	ResourceVersion string `json:"resourceVersion"`
+	// Initiator is the field manager of the object's most recent spec or
+	// metadata change, e.g. "kubectl-client-side-apply" or the name of a
+	// controller.
+	Initiator string `json:"initiator,omitempty"`
+}
+
+type subjectKey struct{}
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = infrav1beta1.AddToScheme(scheme)
+}
+
+// WithSubject returns ctx tagged with obj, which the provider RPCs made
+// with it are attributed to.
+func WithSubject(ctx context.Context, obj client.Object) context.Context {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		gvk, _ = apiutil.GVKForObject(obj, scheme)
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return context.WithValue(ctx, subjectKey{}, Subject{
+		APIVersion:      apiVersion,
+		Kind:            kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             string(obj.GetUID()),
+		ResourceVersion: obj.GetResourceVersion(),
+		Initiator:       initiator(obj.GetManagedFields()),
+	})
+}
+
+// SubjectFrom returns the subject set by WithSubject.
+func SubjectFrom(ctx context.Context) (Subject, bool) {
+	s, ok := ctx.Value(subjectKey{}).(Subject)
+	return s, ok
+}
+
+// initiator returns the manager of the latest managed fields entry outside
+// the status subresource.
+func initiator(entries []metav1.ManagedFieldsEntry) string {
+	var latest *metav1.ManagedFieldsEntry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if latest == nil || !entry.Time.Before(latest.Time) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Manager
+}
+
+// Provider identifies the provider a client talks to.
+type Provider struct {
+	Namespace string
+	Name      string
+	Type      string
+}
+
+// UnaryClientInterceptor returns an interceptor that audits the RPCs of a
+// client to provider, at the level set by Configure when the RPC is made.
+func UnaryClientInterceptor(provider Provider) grpc.UnaryClientInterceptor {
+	providerKey := provider.Namespace + "/" + provider.Name
+	return func(
+		ctx context.Context,
+		fullMethod string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		s := current.Load()
+		if !s.level.Audits(fullMethod) {
+			return invoker(ctx, fullMethod, req, reply, cc, opts...)
+		}
+
+		operation := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+		kv := []interface{}{
+			"operation", operation,
+			"mutating", middleware.IsMutatingMethod(operation),
+			"provider", providerKey,
+			"providerType", provider.Type,
+			"correlationID", correlationID(ctx),
+		}
+		if subject, ok := SubjectFrom(ctx); ok {
+			kv = append(kv, "object", subject)
+		}
+		s.logger.Info("provider call started", append(kv, "stage", "request", "request", middleware.RedactPayload(req))...)
+
+		start := time.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, opts...)
+
+		kv = append(kv,
+			"stage", "response",
+			"outcome", status.Code(err).String(),
+			"duration", time.Since(start).String(),
+		)
+		if err != nil {
+			kv = append(kv, "error", status.Convert(err).Message())
+		}
+		s.logger.Info("provider call completed", kv...)
+		return err
+	}
+}
+
+// correlationID matches the ID the transport forwards to the provider.
+func correlationID(ctx context.Context) string {
+	if id := logging.CorrelationIDFromContext(ctx); id != "" {
+		return id
+	}
+	return string(controller.ReconcileIDFromContext(ctx))
+}
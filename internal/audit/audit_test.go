@@ -0,0 +1,158 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
+)
+
+// capture configures auditing at level and returns the records written.
+func capture(t *testing.T, level middleware.AuditLevel) *[]map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	logger := funcr.NewJSON(func(obj string) {
+		record := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(obj), &record))
+		records = append(records, record)
+	}, funcr.Options{})
+	Configure(level, logger)
+	t.Cleanup(func() { Configure(middleware.AuditOff, logger) })
+	return &records
+}
+
+func call(ctx context.Context, method string, req interface{}, err error) error {
+	interceptor := UnaryClientInterceptor(Provider{Namespace: "infra", Name: "vsphere-prod", Type: "vsphere"})
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return err
+	}
+	return interceptor(ctx, "/provider.v1.Provider/"+method, req, nil, nil, invoker)
+}
+
+func testVM() *infrav1beta1.VirtualMachine {
+	now := metav1.NewTime(time.Now())
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	return &infrav1beta1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{
+		Name: "web", Namespace: "apps", UID: "uid-1", ResourceVersion: "42",
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "virtrigaud-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &now},
+			{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &now},
+			{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate, Time: &earlier},
+		},
+	}}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	records := capture(t, middleware.AuditMutations)
+	ctx := WithSubject(logging.WithCorrelationID(context.Background(), "vm-apps/web"), testVM())
+
+	req := &providerv1.CreateRequest{Name: "web", UserData: []byte("#cloud-config\npassword: hunter2")}
+	err := call(ctx, "Create", req, status.Error(codes.Unavailable, "provider down"))
+	require.Error(t, err)
+	require.Len(t, *records, 2)
+
+	started, completed := (*records)[0], (*records)[1]
+	assert.Equal(t, "audit", started["logger"])
+	assert.Equal(t, "request", started["stage"])
+	assert.Equal(t, "Create", started["operation"])
+	assert.Equal(t, true, started["mutating"])
+	assert.Equal(t, "infra/vsphere-prod", started["provider"])
+	assert.Equal(t, "vsphere", started["providerType"])
+	assert.Equal(t, "vm-apps/web", started["correlationID"])
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "infra.virtrigaud.io/v1beta1", "kind": "VirtualMachine",
+		"namespace": "apps", "name": "web", "uid": "uid-1", "resourceVersion": "42",
+		"initiator": "kubectl-edit",
+	}, started["object"])
+	assert.Contains(t, started["request"], `"name":"web"`)
+
+	assert.Equal(t, "response", completed["stage"])
+	assert.Equal(t, "Unavailable", completed["outcome"])
+	assert.Equal(t, "provider down", completed["error"])
+	assert.NotEmpty(t, completed["duration"])
+}
+
+func TestUnaryClientInterceptor_Redacts(t *testing.T) {
+	records := capture(t, middleware.AuditMutations)
+	ctx := WithSubject(context.Background(), testVM())
+
+	require.NoError(t, call(ctx, "Create", &providerv1.CreateRequest{
+		Name:      "web",
+		UserData:  []byte("#cloud-config\nchpasswd: leaked-userdata"),
+		ImageJson: `{"TemplateName":"ubuntu","Credentials":{"Password":"leaked-image-password"}}`,
+	}, nil))
+	require.NoError(t, call(ctx, "ImportDisk", &providerv1.ImportDiskRequest{
+		SourceUrl:   "s3://bucket/disk.qcow2",
+		Credentials: map[string]string{"accessKeyId": "AKIALEAKED", "secretAccessKey": "leaked-secret-key"},
+	}, nil))
+
+	out, err := json.Marshal(*records)
+	require.NoError(t, err)
+	for _, secret := range []string{"leaked-userdata", "leaked-image-password", "AKIALEAKED", "leaked-secret-key"} {
+		assert.NotContains(t, string(out), secret)
+	}
+	assert.Contains(t, string(out), "s3://bucket/disk.qcow2", "fields that are not secret stay visible")
+	assert.True(t, strings.Contains(string(out), "TemplateName"))
+}
+
+func TestUnaryClientInterceptor_Levels(t *testing.T) {
+	ctx := context.Background()
+	describe := &providerv1.DescribeRequest{Id: "vm-1"}
+
+	records := capture(t, middleware.AuditOff)
+	require.NoError(t, call(ctx, "Delete", &providerv1.DeleteRequest{Id: "vm-1"}, nil))
+	assert.Empty(t, *records)
+
+	records = capture(t, middleware.AuditMutations)
+	require.NoError(t, call(ctx, "Describe", describe, nil))
+	assert.Empty(t, *records, "reads are not audited at the mutations level")
+
+	records = capture(t, middleware.AuditAll)
+	require.NoError(t, call(ctx, "Describe", describe, nil))
+	require.Len(t, *records, 2)
+	assert.Equal(t, false, (*records)[0]["mutating"])
+	assert.NotContains(t, (*records)[0], "object", "an RPC outside a reconcile has no subject")
+}
+
+func TestRecord(t *testing.T) {
+	records := capture(t, middleware.AuditOff)
+	Record("console session opened", "user", "alice")
+	assert.Empty(t, *records)
+
+	records = capture(t, middleware.AuditMutations)
+	Record("console session opened", "user", "alice")
+	require.Len(t, *records, 1)
+	assert.Equal(t, "console session opened", (*records)[0]["msg"])
+	assert.Equal(t, "alice", (*records)[0]["user"])
+	assert.Equal(t, LoggerName, (*records)[0]["logger"])
+}
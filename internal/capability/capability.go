@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capability derives the capability names a Provider supports
+// from its status. The names are the canonical flags from
+// sdk/provider/capabilities, the same vocabulary spec.requiredCapabilities
+// and the conformance suite use.
+//
+// A provider image built with the capabilities Builder reports the
+// manifest of flags it was built with, and that manifest is authoritative.
+// Images that predate the manifest, and providers that fill in the
+// GetCapabilities response by hand, are described by their Supports*
+// booleans instead.
+package capability
+
+import (
+	"sort"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+)
+
+// Names returns the sorted capability names provider supports. Every
+// provider implements the core profile; the rest comes from what it
+// self-reported via GetCapabilities.
+func Names(provider *infrav1beta1.Provider) []string {
+	caps := capabilities.GetProfileCapabilities(capabilities.ProfileCore)
+	if provider.Spec.Type == infrav1beta1.ProviderTypeVSphere {
+		caps = append(caps, capabilities.CapabilityReconfigure, capabilities.CapabilitySnapshots)
+	}
+
+	if reported := provider.Status.ReportedCapabilities; reported != nil {
+		if len(reported.CapabilityManifest) > 0 {
+			for _, name := range reported.CapabilityManifest {
+				caps = append(caps, capabilities.Capability(name))
+			}
+		} else {
+			caps = append(caps, fromFlags(reported)...)
+		}
+	}
+
+	names := make([]string, 0, len(caps))
+	seen := map[capabilities.Capability]bool{}
+	for _, c := range caps {
+		if !seen[c] {
+			seen[c] = true
+			names = append(names, string(c))
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Missing returns the entries of spec.requiredCapabilities that provider
+// does not report, in spec order. It returns nil when nothing is missing.
+func Missing(provider *infrav1beta1.Provider) []string {
+	if len(provider.Spec.RequiredCapabilities) == 0 {
+		return nil
+	}
+	have := map[string]bool{}
+	for _, name := range Names(provider) {
+		have[name] = true
+	}
+	var missing []string
+	for _, name := range provider.Spec.RequiredCapabilities {
+		if !have[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// fromFlags maps the Supports* booleans onto capability flags.
+func fromFlags(reported *infrav1beta1.ReportedCapabilities) []capabilities.Capability {
+	var caps []capabilities.Capability
+	for flag, supported := range map[capabilities.Capability]bool{
+		capabilities.CapabilityReconfigureOnline:   reported.SupportsReconfigureOnline,
+		capabilities.CapabilityDiskExpansionOnline: reported.SupportsDiskExpansionOnline,
+		capabilities.CapabilitySnapshots:           reported.SupportsSnapshots,
+		capabilities.CapabilityMemorySnapshots:     reported.SupportsMemorySnapshots,
+		capabilities.CapabilityLinkedClones:        reported.SupportsLinkedClones,
+		capabilities.CapabilityImageImport:         reported.SupportsImageImport,
+		capabilities.CapabilityDiskExport:          reported.SupportsDiskExport,
+		capabilities.CapabilityDiskImport:          reported.SupportsDiskImport,
+		capabilities.CapabilityExportCompression:   reported.SupportsExportCompression,
+		capabilities.CapabilityConsoleOutput:       reported.SupportsConsoleOutput,
+		capabilities.CapabilitySysprep:             reported.SupportsSysprep,
+		capabilities.CapabilityCloudInitStatus:     reported.SupportsCloudInitStatus,
+		capabilities.CapabilityLiveMigration:       reported.SupportsLiveMigration,
+		capabilities.CapabilityConsoleProxy:        reported.SupportsConsoleProxy,
+		capabilities.CapabilityImagePublish:        reported.SupportsImagePublish,
+		capabilities.CapabilityEventWatch:          reported.SupportsEventWatch,
+		capabilities.CapabilityQuiescedSnapshots:   reported.SupportsQuiescedSnapshots,
+	} {
+		if supported {
+			caps = append(caps, flag)
+		}
+	}
+	return caps
+}
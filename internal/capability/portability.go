@@ -0,0 +1,342 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capability
+
+import (
+	"fmt"
+	"strings"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+)
+
+// Support is how a provider type treats a VMClass or VMImage field.
+type Support string
+
+const (
+	// Supported fields are applied by the provider.
+	Supported Support = "Supported"
+	// Ignored fields are accepted by the provider and have no effect.
+	Ignored Support = "Ignored"
+	// Unsupported fields make the provider fail the create.
+	Unsupported Support = "Unsupported"
+)
+
+// PortabilityRule describes a VMClass or VMImage field that not every
+// provider type honors. Fields every provider applies, such as cpu or
+// firmware, have no rule.
+type PortabilityRule struct {
+	// Kind is VMClass or VMImage.
+	Kind string
+	// Field is the spec path, with the values concerned when only some are
+	// provider-specific.
+	Field string
+	// Providers is how each provider type treats the field. Types not
+	// listed ignore it.
+	Providers map[infrav1beta1.ProviderType]Support
+	// Capability, if set, is the capability a provider supporting the field
+	// must report for the field to have an effect.
+	Capability capabilities.Capability
+	// ImageSource marks the VMImage sources. An image is only unusable when
+	// none of the sources it sets is supported.
+	ImageSource bool
+
+	classSet func(*infrav1beta1.VMClassSpec) bool
+	imageSet func(*infrav1beta1.VMImageSpec) bool
+}
+
+// supports returns how providerType treats the field.
+func (r *PortabilityRule) supports(providerType infrav1beta1.ProviderType) Support {
+	if s, ok := r.Providers[providerType]; ok {
+		return s
+	}
+	return Ignored
+}
+
+// only is a Providers map for fields the given provider types support.
+func only(types ...infrav1beta1.ProviderType) map[infrav1beta1.ProviderType]Support {
+	m := map[infrav1beta1.ProviderType]Support{}
+	for _, t := range types {
+		m[t] = Supported
+	}
+	return m
+}
+
+const (
+	kindVMClass = "VMClass"
+	kindVMImage = "VMImage"
+)
+
+// PortabilityRules lists the provider-specific VMClass and VMImage fields.
+// Supporting a new provider type means adding it to the Providers of the
+// rules it honors; Check needs no change.
+var PortabilityRules = []PortabilityRule{
+	{
+		Kind: kindVMClass, Field: "spec.diskDefaults.type (thick, eagerzeroedthick)",
+		Providers: only(infrav1beta1.ProviderTypeVSphere),
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.DiskDefaults != nil &&
+				(s.DiskDefaults.Type == infrav1beta1.DiskTypeThick || s.DiskDefaults.Type == infrav1beta1.DiskTypeEagerZeroedThick)
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.diskDefaults.type (ssd, hdd, nvme)",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			if s.DiskDefaults == nil {
+				return false
+			}
+			switch s.DiskDefaults.Type {
+			case infrav1beta1.DiskTypeSSD, infrav1beta1.DiskTypeHDD, infrav1beta1.DiskTypeNVMe:
+				return true
+			}
+			return false
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.diskDefaults.iops",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool { return s.DiskDefaults != nil && s.DiskDefaults.IOPS != nil },
+	},
+	{
+		Kind: kindVMClass, Field: "spec.diskDefaults.storageClass",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.DiskDefaults != nil && s.DiskDefaults.StorageClass != ""
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.machineType",
+		Providers: only(infrav1beta1.ProviderTypeLibvirt),
+		classSet:  func(s *infrav1beta1.VMClassSpec) bool { return s.MachineType != "" },
+	},
+	{
+		Kind: kindVMClass, Field: "spec.memoryBalloon",
+		Providers: only(infrav1beta1.ProviderTypeProxmox),
+		classSet:  func(s *infrav1beta1.VMClassSpec) bool { return s.MemoryBalloon != nil },
+	},
+	{
+		Kind: kindVMClass, Field: "spec.extraConfig",
+		Providers: only(infrav1beta1.ProviderTypeVSphere),
+		classSet:  func(s *infrav1beta1.VMClassSpec) bool { return len(s.ExtraConfig) > 0 },
+	},
+	{
+		Kind: kindVMClass, Field: "spec.guestToolsPolicy (skip, upgrade, uninstall)",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.GuestToolsPolicy != "" && s.GuestToolsPolicy != infrav1beta1.GuestToolsPolicyInstall
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.resourceLimits",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool { return s.ResourceLimits != nil },
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.cpuHotAddEnabled",
+		Providers:  only(infrav1beta1.ProviderTypeVSphere, infrav1beta1.ProviderTypeLibvirt),
+		Capability: capabilities.CapabilityReconfigureOnline,
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil && s.PerformanceProfile.CPUHotAddEnabled
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.memoryHotAddEnabled",
+		Providers:  only(infrav1beta1.ProviderTypeVSphere, infrav1beta1.ProviderTypeLibvirt),
+		Capability: capabilities.CapabilityReconfigureOnline,
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil && s.PerformanceProfile.MemoryHotAddEnabled
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.virtualizationBasedSecurity",
+		Providers: only(infrav1beta1.ProviderTypeVSphere),
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil && s.PerformanceProfile.VirtualizationBasedSecurity
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.hugePages",
+		Providers: only(infrav1beta1.ProviderTypeProxmox),
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil && s.PerformanceProfile.HugePages != ""
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.latencySensitivity (low, high)",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil &&
+				s.PerformanceProfile.LatencySensitivity != "" && s.PerformanceProfile.LatencySensitivity != "normal"
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.performanceProfile.hyperThreadingPolicy (prefer, avoid, require)",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.PerformanceProfile != nil &&
+				s.PerformanceProfile.HyperThreadingPolicy != "" && s.PerformanceProfile.HyperThreadingPolicy != "auto"
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.securityProfile.vtdEnabled",
+		Providers: only(infrav1beta1.ProviderTypeVSphere, infrav1beta1.ProviderTypeLibvirt),
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.SecurityProfile != nil && s.SecurityProfile.VTDEnabled
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.securityProfile.tpmVersion",
+		Providers: only(infrav1beta1.ProviderTypeProxmox),
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.SecurityProfile != nil && s.SecurityProfile.TPMVersion != ""
+		},
+	},
+	{
+		Kind: kindVMClass, Field: "spec.securityProfile.encryptionPolicy",
+		classSet: func(s *infrav1beta1.VMClassSpec) bool {
+			return s.SecurityProfile != nil && s.SecurityProfile.EncryptionPolicy != nil &&
+				(s.SecurityProfile.EncryptionPolicy.Enabled || s.SecurityProfile.EncryptionPolicy.RequireEncryption)
+		},
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.vsphere", ImageSource: true,
+		Providers: only(infrav1beta1.ProviderTypeVSphere),
+		imageSet:  func(s *infrav1beta1.VMImageSpec) bool { return s.Source.VSphere != nil },
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.libvirt", ImageSource: true,
+		Providers: only(infrav1beta1.ProviderTypeLibvirt),
+		imageSet:  func(s *infrav1beta1.VMImageSpec) bool { return s.Source.Libvirt != nil },
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.proxmox", ImageSource: true,
+		Providers: only(infrav1beta1.ProviderTypeProxmox),
+		imageSet:  func(s *infrav1beta1.VMImageSpec) bool { return s.Source.Proxmox != nil },
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.http", ImageSource: true,
+		Providers:  only(infrav1beta1.ProviderTypeProxmox),
+		Capability: capabilities.CapabilityImageImport,
+		imageSet:   func(s *infrav1beta1.VMImageSpec) bool { return s.Source.HTTP != nil },
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.registry", ImageSource: true,
+		imageSet: func(s *infrav1beta1.VMImageSpec) bool { return s.Source.Registry != nil },
+	},
+	{
+		Kind: kindVMImage, Field: "spec.source.dataVolume", ImageSource: true,
+		imageSet: func(s *infrav1beta1.VMImageSpec) bool { return s.Source.DataVolume != nil },
+	},
+}
+
+// PortabilityIssue is a VMClass or VMImage field a provider does not honor.
+type PortabilityIssue struct {
+	// Kind is VMClass or VMImage.
+	Kind string
+	// Field is the spec path of the field.
+	Field string
+	// Support is Ignored or Unsupported.
+	Support Support
+	// Message says what the provider does with the field.
+	Message string
+}
+
+// String formats the issue for conditions, warnings and reports.
+func (i PortabilityIssue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Kind, i.Field, i.Message)
+}
+
+// Check returns the fields of class and image, either of which may be nil,
+// that a provider of providerType with the capability names caps ignores or
+// cannot create a VM with: unsupported issues first, then ignored ones, each
+// in PortabilityRules order. A nil caps means the capabilities are unknown,
+// and only the provider type is considered. class should be the resolved
+// spec, so that inherited fields are checked too.
+func Check(class *infrav1beta1.VMClassSpec, image *infrav1beta1.VMImageSpec, providerType infrav1beta1.ProviderType, caps []string) []PortabilityIssue {
+	have := map[string]bool{}
+	for _, c := range caps {
+		have[c] = true
+	}
+
+	var unsupported, ignored []PortabilityIssue
+	var sources []string
+	usableSource := false
+	for i := range PortabilityRules {
+		rule := &PortabilityRules[i]
+		switch {
+		case rule.classSet != nil && class != nil && rule.classSet(class):
+		case rule.imageSet != nil && image != nil && rule.imageSet(image):
+		default:
+			continue
+		}
+
+		support := rule.supports(providerType)
+		message := ""
+		switch support {
+		case Ignored:
+			message = fmt.Sprintf("ignored by %s providers", providerType)
+		case Unsupported:
+			message = fmt.Sprintf("%s providers fail to create VMs that set it", providerType)
+		case Supported:
+			if rule.Capability != "" && caps != nil && !have[string(rule.Capability)] {
+				support = Ignored
+				message = fmt.Sprintf("has no effect: the provider does not report the %s capability", rule.Capability)
+			}
+		}
+
+		if rule.ImageSource {
+			sources = append(sources, rule.Field)
+			if support == Supported {
+				usableSource = true
+			}
+			// An unused source is only a problem when no other is usable,
+			// which is reported below.
+			continue
+		}
+		issue := PortabilityIssue{Kind: rule.Kind, Field: rule.Field, Support: support, Message: message}
+		switch support {
+		case Unsupported:
+			unsupported = append(unsupported, issue)
+		case Ignored:
+			ignored = append(ignored, issue)
+		}
+	}
+
+	if len(sources) > 0 && !usableSource {
+		unsupported = append(unsupported, PortabilityIssue{
+			Kind: kindVMImage, Field: "spec.source", Support: Unsupported,
+			Message: fmt.Sprintf("no source a %s provider can create VMs from (the image has %s)",
+				providerType, strings.Join(sources, ", ")),
+		})
+	}
+	return append(unsupported, ignored...)
+}
+
+// CheckProvider runs Check for provider, using the capabilities it reported.
+// A provider that has not reported its capabilities yet is checked by type
+// alone.
+func CheckProvider(class *infrav1beta1.VMClassSpec, image *infrav1beta1.VMImageSpec, provider *infrav1beta1.Provider) []PortabilityIssue {
+	var caps []string
+	if provider.Status.ReportedCapabilities != nil {
+		caps = Names(provider)
+	}
+	return Check(class, image, provider.Spec.Type, caps)
+}
+
+// HasUnsupported reports whether any of issues will fail the create.
+func HasUnsupported(issues []PortabilityIssue) bool {
+	for _, i := range issues {
+		if i.Support == Unsupported {
+			return true
+		}
+	}
+	return false
+}
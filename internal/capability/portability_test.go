@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func issueFields(issues []PortabilityIssue) []string {
+	var fields []string
+	for _, i := range issues {
+		fields = append(fields, i.Field)
+	}
+	return fields
+}
+
+// vsphereClass is a class written for vSphere.
+func vsphereClass() *infrav1beta1.VMClassSpec {
+	return &infrav1beta1.VMClassSpec{
+		CPU:          2,
+		DiskDefaults: &infrav1beta1.DiskDefaults{Type: infrav1beta1.DiskTypeEagerZeroedThick},
+		ExtraConfig:  map[string]string{"vsphere.hardwareVersion": "vmx-19"},
+		PerformanceProfile: &infrav1beta1.PerformanceProfile{
+			CPUHotAddEnabled:     true,
+			NestedVirtualization: true,
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	assert.Empty(t, Check(vsphereClass(), nil, infrav1beta1.ProviderTypeVSphere, nil))
+
+	issues := Check(vsphereClass(), nil, infrav1beta1.ProviderTypeProxmox, nil)
+	assert.Equal(t, []string{
+		"spec.diskDefaults.type (thick, eagerzeroedthick)",
+		"spec.extraConfig",
+		"spec.performanceProfile.cpuHotAddEnabled",
+	}, issueFields(issues))
+	assert.False(t, HasUnsupported(issues))
+	assert.Equal(t, "VMClass spec.extraConfig: ignored by proxmox providers", issues[1].String())
+
+	// libvirt applies hot-add only with online reconfigure.
+	issues = Check(vsphereClass(), nil, infrav1beta1.ProviderTypeLibvirt, []string{"create"})
+	require.Len(t, issues, 3)
+	assert.Equal(t, "has no effect: the provider does not report the reconfigure_online capability", issues[2].Message)
+	issues = Check(vsphereClass(), nil, infrav1beta1.ProviderTypeLibvirt, []string{"create", "reconfigure_online"})
+	assert.Len(t, issues, 2)
+}
+
+func TestCheckDefaultsAreNotReported(t *testing.T) {
+	class := &infrav1beta1.VMClassSpec{
+		DiskDefaults:       &infrav1beta1.DiskDefaults{Type: infrav1beta1.DiskTypeThin},
+		GuestToolsPolicy:   infrav1beta1.GuestToolsPolicyInstall,
+		PerformanceProfile: &infrav1beta1.PerformanceProfile{LatencySensitivity: "normal", HyperThreadingPolicy: "auto"},
+		SecurityProfile:    &infrav1beta1.SecurityProfile{EncryptionPolicy: &infrav1beta1.EncryptionPolicy{}},
+	}
+	assert.Empty(t, Check(class, nil, infrav1beta1.ProviderTypeLibvirt, nil))
+}
+
+func TestCheckImageSources(t *testing.T) {
+	vsphereOnly := &infrav1beta1.VMImageSpec{Source: infrav1beta1.ImageSource{
+		VSphere: &infrav1beta1.VSphereImageSource{TemplateName: "ubuntu-22.04"},
+	}}
+	assert.Empty(t, Check(nil, vsphereOnly, infrav1beta1.ProviderTypeVSphere, nil))
+
+	issues := Check(nil, vsphereOnly, infrav1beta1.ProviderTypeProxmox, nil)
+	require.Len(t, issues, 1)
+	assert.Equal(t, Unsupported, issues[0].Support)
+	assert.Equal(t, "no source a proxmox provider can create VMs from (the image has spec.source.vsphere)", issues[0].Message)
+
+	// A second source makes the image usable elsewhere; the unused one is
+	// not reported.
+	both := vsphereOnly.DeepCopy()
+	both.Source.Proxmox = &infrav1beta1.ProxmoxImageSource{TemplateID: ptr.To(9000)}
+	assert.Empty(t, Check(nil, both, infrav1beta1.ProviderTypeProxmox, nil))
+
+	// An HTTP source is imported, which needs image_import.
+	http := &infrav1beta1.VMImageSpec{Source: infrav1beta1.ImageSource{
+		HTTP: &infrav1beta1.HTTPImageSource{URL: "https://example.com/disk.qcow2"},
+	}}
+	assert.Empty(t, Check(nil, http, infrav1beta1.ProviderTypeProxmox, []string{"image_import"}))
+	assert.True(t, HasUnsupported(Check(nil, http, infrav1beta1.ProviderTypeProxmox, []string{"create"})))
+	assert.True(t, HasUnsupported(Check(nil, http, infrav1beta1.ProviderTypeLibvirt, nil)))
+}
+
+func TestCheckProviderWithoutReportedCapabilities(t *testing.T) {
+	provider := &infrav1beta1.Provider{Spec: infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeLibvirt}}
+	class := &infrav1beta1.VMClassSpec{PerformanceProfile: &infrav1beta1.PerformanceProfile{MemoryHotAddEnabled: true}}
+	assert.Empty(t, CheckProvider(class, nil, provider), "capabilities not reported yet are not held against the provider")
+
+	provider.Status.ReportedCapabilities = &infrav1beta1.ReportedCapabilities{}
+	assert.Equal(t, []string{"spec.performanceProfile.memoryHotAddEnabled"}, issueFields(CheckProvider(class, nil, provider)))
+}
+
+// TestPortabilityRules guards the table against rules Check cannot apply.
+func TestPortabilityRules(t *testing.T) {
+	for _, rule := range PortabilityRules {
+		switch rule.Kind {
+		case kindVMClass:
+			assert.NotNil(t, rule.classSet, rule.Field)
+			assert.Nil(t, rule.imageSet, rule.Field)
+			assert.False(t, rule.ImageSource, rule.Field)
+		case kindVMImage:
+			assert.NotNil(t, rule.imageSet, rule.Field)
+			assert.Nil(t, rule.classSet, rule.Field)
+		default:
+			t.Errorf("%s: unknown kind %q", rule.Field, rule.Kind)
+		}
+		for providerType := range rule.Providers {
+			assert.Contains(t, []infrav1beta1.ProviderType{
+				infrav1beta1.ProviderTypeVSphere, infrav1beta1.ProviderTypeLibvirt, infrav1beta1.ProviderTypeProxmox,
+				infrav1beta1.ProviderTypeFirecracker, infrav1beta1.ProviderTypeQEMU,
+			}, providerType, rule.Field)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package printers holds the table formatting shared by the vrtg list and
+// describe commands: list tables with their wide and custom-columns forms,
+// relative ages, condition tables with wrapped messages, Ready summaries
+// and inline event lists.
+package printers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// None is printed for values that are not set.
+const None = "<none>"
+
+// DefaultWidth is the terminal width assumed when it cannot be detected.
+const DefaultWidth = 100
+
+// TerminalWidth returns the width of the terminal on stdout, then $COLUMNS,
+// then DefaultWidth.
+func TerminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return DefaultWidth
+}
+
+// Age formats the time elapsed since t the way kubectl does ("45s",
+// "2m13s", "4d"). A zero t is "<unknown>".
+func Age(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
+
+// ConditionStatus returns the status of the condition of the given type,
+// for READY-style list columns, or None when it is not reported.
+func ConditionStatus(conditions []metav1.Condition, conditionType string) string {
+	if c := meta.FindStatusCondition(conditions, conditionType); c != nil {
+		return string(c.Status)
+	}
+	return None
+}
+
+// ReadySummary is the one-line state of an object from its Ready
+// condition: "Ready for 4d", or "NotReady: ProviderError for 32m".
+func ReadySummary(conditions []metav1.Condition, now time.Time) string {
+	c := meta.FindStatusCondition(conditions, "Ready")
+	if c == nil {
+		return "Ready condition not reported yet"
+	}
+	age := Age(c.LastTransitionTime.Time, now)
+	switch c.Status {
+	case metav1.ConditionTrue:
+		return "Ready for " + age
+	case metav1.ConditionFalse:
+		return fmt.Sprintf("NotReady: %s for %s", reasonOrNone(c.Reason), age)
+	default:
+		return fmt.Sprintf("Unknown: %s for %s", reasonOrNone(c.Reason), age)
+	}
+}
+
+func reasonOrNone(reason string) string {
+	if reason == "" {
+		return None
+	}
+	return reason
+}
+
+// Conditions prints a condition table: type, status, reason and age on one
+// row, followed by the message indented and wrapped to width.
+func Conditions(w io.Writer, conditions []metav1.Condition, now time.Time, width int) {
+	if len(conditions) == 0 {
+		return
+	}
+	typeWidth, reasonWidth := len("TYPE"), len("REASON")
+	for _, c := range conditions {
+		typeWidth = max(typeWidth, len(c.Type))
+		reasonWidth = max(reasonWidth, len(c.Reason))
+	}
+
+	fmt.Fprintf(w, "\nConditions:\n")
+	row := fmt.Sprintf("  %%-%ds  %%-7s  %%-%ds  %%s\n", typeWidth, reasonWidth)
+	fmt.Fprintf(w, row, "TYPE", "STATUS", "REASON", "AGE")
+	for _, c := range conditions {
+		fmt.Fprintf(w, row, c.Type, c.Status, c.Reason, Age(c.LastTransitionTime.Time, now))
+		for _, line := range Wrap(c.Message, width-4) {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+// Events prints the last limit events, oldest first, with their age. A
+// limit of zero prints all of them.
+func Events(w io.Writer, events []corev1.Event, limit int, now time.Time, width int) {
+	events = append([]corev1.Event(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(&events[i]).Before(EventTime(&events[j]))
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+
+	fmt.Fprintf(w, "\nEvents:\n")
+	if len(events) == 0 {
+		fmt.Fprintf(w, "  %s\n", None)
+		return
+	}
+	reasonWidth := len("REASON")
+	for i := range events {
+		reasonWidth = max(reasonWidth, len(events[i].Reason))
+	}
+	row := fmt.Sprintf("  %%-8s  %%-7s  %%-%ds  %%s\n", reasonWidth)
+	fmt.Fprintf(w, row, "AGE", "TYPE", "REASON", "MESSAGE")
+	indent := strings.Repeat(" ", 2+8+2+7+2+reasonWidth+2)
+	for i := range events {
+		e := &events[i]
+		lines := Wrap(e.Message, width-len(indent))
+		first := ""
+		if len(lines) > 0 {
+			first = lines[0]
+		}
+		fmt.Fprintf(w, row, Age(EventTime(e), now), e.Type, e.Reason, first)
+		for _, line := range lines[min(1, len(lines)):] {
+			fmt.Fprintf(w, "%s%s\n", indent, line)
+		}
+	}
+}
+
+// EventTime is when an event was last seen, whichever of the legacy and
+// events.k8s.io timestamps the reporter filled in.
+func EventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// Wrap splits s into lines of at most width runes, breaking at spaces.
+// Words longer than width are split. Existing line breaks are kept.
+func Wrap(s string, width int) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	if width < 20 {
+		width = 20
+	}
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(para) {
+			rw := []rune(word)
+			for len(rw) > width {
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+					line = nil
+				}
+				lines = append(lines, string(rw[:width]))
+				rw = rw[width:]
+			}
+			if len(line) > 0 && len(line)+1+len(rw) > width {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			if len(line) > 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, rw...)
+		}
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines
+}
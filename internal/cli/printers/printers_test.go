@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func ago(d time.Duration) metav1.Time {
+	return metav1.NewTime(now.Add(-d))
+}
+
+func TestReadySummary(t *testing.T) {
+	assert.Equal(t, "Ready for 4d", ReadySummary([]metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: ago(4 * 24 * time.Hour)},
+	}, now))
+	assert.Equal(t, "NotReady: ProviderError for 32m", ReadySummary([]metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ProviderError", LastTransitionTime: ago(32 * time.Minute)},
+	}, now))
+	assert.Equal(t, "Ready condition not reported yet", ReadySummary(nil, now))
+}
+
+func TestConditions(t *testing.T) {
+	var buf bytes.Buffer
+	Conditions(&buf, []metav1.Condition{{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		Reason:             "DependenciesNotReady",
+		Message:            "waiting for VMClass small and VMImage ubuntu-22 to become ready before provisioning",
+		LastTransitionTime: ago(3 * time.Second),
+	}}, now, 44)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"Conditions:",
+		"  TYPE   STATUS   REASON                AGE",
+		"  Ready  False    DependenciesNotReady  3s",
+		"    waiting for VMClass small and VMImage",
+		"    ubuntu-22 to become ready before",
+		"    provisioning",
+	}, lines)
+}
+
+func TestEvents(t *testing.T) {
+	events := []corev1.Event{
+		{Type: "Normal", Reason: "Created", Message: "created", LastTimestamp: ago(time.Hour)},
+		{Type: "Warning", Reason: "ProviderError", Message: "timeout", LastTimestamp: ago(time.Minute)},
+		{Type: "Normal", Reason: "Scheduled", Message: "scheduled", LastTimestamp: ago(2 * time.Hour)},
+	}
+	var buf bytes.Buffer
+	Events(&buf, events, 2, now, 100)
+
+	out := buf.String()
+	assert.NotContains(t, out, "Scheduled", "only the last N events are printed")
+	assert.Less(t, strings.Index(out, "Created"), strings.Index(out, "ProviderError"), "oldest first")
+	assert.Contains(t, out, "60m")
+}
+
+func TestWrap(t *testing.T) {
+	assert.Nil(t, Wrap("  ", 40))
+	assert.Equal(t, []string{"short"}, Wrap("short", 40))
+	assert.Equal(t, []string{strings.Repeat("x", 20), "xxxxx end"}, Wrap(strings.Repeat("x", 25)+" end", 20))
+	assert.Equal(t, []string{"line one", "line two"}, Wrap("line one\nline two", 40))
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Output formats of list commands, the values of -o besides json and yaml.
+const (
+	OutputTable         = "table"
+	OutputWide          = "wide"
+	OutputCustomColumns = "custom-columns="
+)
+
+// Column is one column of a list table.
+type Column[T any] struct {
+	// Header is the column's title, upper case as kubectl prints it.
+	Header string
+	// Wide columns are only printed with -o wide.
+	Wide bool
+	// Value renders the column's cell for an item.
+	Value func(T) string
+}
+
+// CustomColumn is a column given on the command line with
+// -o custom-columns=HEADER:EXPR.
+type CustomColumn struct {
+	Header string
+	// Expr is the JSONPath expression, e.g. ".status.ips[0]", evaluated
+	// against the item's JSON form.
+	Expr string
+
+	path *jsonpath.JSONPath
+}
+
+// TableOptions selects how a list table is printed.
+type TableOptions struct {
+	// Wide adds the columns marked Wide.
+	Wide bool
+	// CustomColumns replaces the table's columns when set.
+	CustomColumns []CustomColumn
+	// NoHeaders leaves out the header row.
+	NoHeaders bool
+	// SortBy is a JSONPath expression the rows are sorted by, e.g.
+	// ".metadata.creationTimestamp"; empty keeps the order items come in.
+	SortBy string
+}
+
+// ParseTableOptions reads the -o, --no-headers and --sort-by flags of a
+// list command. It reports false when output asks for a format that is not
+// a table, e.g. json.
+func ParseTableOptions(output string, noHeaders bool, sortBy string) (TableOptions, bool, error) {
+	opts := TableOptions{NoHeaders: noHeaders, SortBy: sortBy}
+	if sortBy != "" {
+		if _, err := parseJSONPath("sort-by", sortBy); err != nil {
+			return opts, false, err
+		}
+	}
+	switch {
+	case output == "" || output == OutputTable:
+	case output == OutputWide:
+		opts.Wide = true
+	case strings.HasPrefix(output, OutputCustomColumns):
+		columns, err := ParseCustomColumns(strings.TrimPrefix(output, OutputCustomColumns))
+		if err != nil {
+			return opts, false, err
+		}
+		opts.CustomColumns = columns
+	default:
+		return opts, false, nil
+	}
+	return opts, true, nil
+}
+
+// ParseCustomColumns parses a custom-columns spec,
+// "NAME:.metadata.name,IP:.status.ips[0]".
+func ParseCustomColumns(spec string) ([]CustomColumn, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format requires at least one HEADER:EXPR column")
+	}
+	var columns []CustomColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(part, ":")
+		if !ok || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q: expected HEADER:EXPR, e.g. NAME:.metadata.name", part)
+		}
+		path, err := parseJSONPath(header, expr)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, CustomColumn{Header: header, Expr: expr, path: path})
+	}
+	return columns, nil
+}
+
+// parseJSONPath parses expr the way kubectl's custom-columns and --sort-by
+// do: the braces and the leading dot are optional.
+func parseJSONPath(name, expr string) (*jsonpath.JSONPath, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "{") {
+		if !strings.HasPrefix(expr, ".") {
+			expr = "." + expr
+		}
+		expr = "{" + expr + "}"
+	}
+	path := jsonpath.New(name).AllowMissingKeys(true)
+	if err := path.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression %q: %w", expr, err)
+	}
+	return path, nil
+}
+
+// PrintTable prints items as a table of columns, or of opts.CustomColumns,
+// in aligned columns. Empty cells print as None.
+func PrintTable[T any](w io.Writer, items []T, columns []Column[T], opts TableOptions) error {
+	if opts.SortBy != "" {
+		sorted, err := sortItems(items, opts.SortBy)
+		if err != nil {
+			return err
+		}
+		items = sorted
+	}
+
+	var headers []string
+	var cells func(T) ([]string, error)
+	if len(opts.CustomColumns) > 0 {
+		for _, c := range opts.CustomColumns {
+			headers = append(headers, c.Header)
+		}
+		cells = func(item T) ([]string, error) {
+			data, err := jsonData(item)
+			if err != nil {
+				return nil, err
+			}
+			row := make([]string, 0, len(opts.CustomColumns))
+			for _, c := range opts.CustomColumns {
+				values, err := c.path.FindResults(data)
+				if err != nil {
+					return nil, fmt.Errorf("column %s: %w", c.Header, err)
+				}
+				row = append(row, formatResults(values))
+			}
+			return row, nil
+		}
+	} else {
+		var shown []Column[T]
+		for _, c := range columns {
+			if !c.Wide || opts.Wide {
+				shown = append(shown, c)
+				headers = append(headers, c.Header)
+			}
+		}
+		cells = func(item T) ([]string, error) {
+			row := make([]string, 0, len(shown))
+			for _, c := range shown {
+				row = append(row, c.Value(item))
+			}
+			return row, nil
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if !opts.NoHeaders {
+		_, _ = fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, item := range items {
+		row, err := cells(item)
+		if err != nil {
+			return err
+		}
+		for i, cell := range row {
+			// A tab or line break in a value would break the alignment
+			// of every row after it.
+			cell = strings.Join(strings.Fields(cell), " ")
+			if cell == "" {
+				cell = None
+			}
+			row[i] = cell
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// jsonData returns item as encoding/json would decode its JSON form, so
+// expressions name the fields by their JSON names.
+func jsonData(item any) (any, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// formatResults renders what a JSONPath expression found: strings as they
+// are, anything else as JSON, several values comma separated.
+func formatResults(results [][]reflect.Value) string {
+	var parts []string
+	for _, values := range results {
+		for _, v := range values {
+			parts = append(parts, formatValue(v))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.Interface && v.IsNil() {
+		return ""
+	}
+	i := v.Interface()
+	switch x := i.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(i); err != nil {
+		return fmt.Sprint(i)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// sortItems returns items sorted by the value of the JSONPath expression
+// sortBy: numerically when both values are numbers, otherwise as strings.
+// Items without the value come first; ties keep their order.
+func sortItems[T any](items []T, sortBy string) ([]T, error) {
+	path, err := parseJSONPath("sort-by", sortBy)
+	if err != nil {
+		return nil, err
+	}
+	type keyed struct {
+		item T
+		key  any
+	}
+	rows := make([]keyed, len(items))
+	for i, item := range items {
+		data, err := jsonData(item)
+		if err != nil {
+			return nil, err
+		}
+		results, err := path.FindResults(data)
+		if err != nil {
+			return nil, fmt.Errorf("sort-by %s: %w", sortBy, err)
+		}
+		rows[i].item = item
+		if len(results) > 0 && len(results[0]) > 0 && results[0][0].IsValid() {
+			rows[i].key = results[0][0].Interface()
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].key, rows[j].key
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		}
+		af, aNum := a.(float64)
+		bf, bNum := b.(float64)
+		if aNum && bNum {
+			return af < bf
+		}
+		return formatValue(reflect.ValueOf(a)) < formatValue(reflect.ValueOf(b))
+	})
+	sorted := make([]T, len(rows))
+	for i := range rows {
+		sorted[i] = rows[i].item
+	}
+	return sorted, nil
+}
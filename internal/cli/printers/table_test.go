@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableItem struct {
+	Name string   `json:"name"`
+	CPU  int      `json:"cpu"`
+	IPs  []string `json:"ips,omitempty"`
+}
+
+var tableItems = []tableItem{
+	{Name: "web-frontend-production-01", CPU: 4, IPs: []string{"10.0.0.5", "fd00::5"}},
+	{Name: "db", CPU: 16},
+	{Name: "cache", CPU: 2, IPs: []string{"10.0.0.7"}},
+}
+
+var tableColumns = []Column[tableItem]{
+	{Header: "NAME", Value: func(i tableItem) string { return i.Name }},
+	{Header: "CPU", Value: func(i tableItem) string { return strconv.Itoa(i.CPU) }},
+	{Header: "IPS", Wide: true, Value: func(i tableItem) string {
+		if len(i.IPs) == 0 {
+			return ""
+		}
+		return i.IPs[0]
+	}},
+}
+
+func printTable(t *testing.T, output string, noHeaders bool, sortBy string) string {
+	t.Helper()
+	opts, ok, err := ParseTableOptions(output, noHeaders, sortBy)
+	require.NoError(t, err)
+	require.True(t, ok)
+	var buf bytes.Buffer
+	require.NoError(t, PrintTable(&buf, tableItems, tableColumns, opts))
+	return buf.String()
+}
+
+func TestPrintTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		noHeaders bool
+		sortBy    string
+		want      string
+	}{{
+		name:   "default",
+		output: "table",
+		want: `NAME                        CPU
+web-frontend-production-01  4
+db                          16
+cache                       2
+`,
+	}, {
+		name:   "wide",
+		output: "wide",
+		want: `NAME                        CPU  IPS
+web-frontend-production-01  4    10.0.0.5
+db                          16   <none>
+cache                       2    10.0.0.7
+`,
+	}, {
+		name:   "custom columns",
+		output: "custom-columns=VM:.name,IP:.ips[0],ALL:.ips[*],MISSING:.nothing",
+		want: `VM                          IP        ALL               MISSING
+web-frontend-production-01  10.0.0.5  10.0.0.5,fd00::5  <none>
+db                          <none>    <none>            <none>
+cache                       10.0.0.7  10.0.0.7          <none>
+`,
+	}, {
+		name:      "no headers, sorted numerically",
+		output:    "table",
+		noHeaders: true,
+		sortBy:    ".cpu",
+		want: `cache                       2
+web-frontend-production-01  4
+db                          16
+`,
+	}, {
+		name:   "sorted by string, braces and dot optional",
+		output: "custom-columns=NAME:{.name}",
+		sortBy: "name",
+		want: `NAME
+cache
+db
+web-frontend-production-01
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, printTable(t, tt.output, tt.noHeaders, tt.sortBy))
+		})
+	}
+}
+
+func TestPrintTableKeepsRowsOnOneLine(t *testing.T) {
+	var buf bytes.Buffer
+	items := []tableItem{{Name: "bad\tname\nwith breaks"}}
+	require.NoError(t, PrintTable(&buf, items, tableColumns[:1], TableOptions{NoHeaders: true}))
+	assert.Equal(t, "bad name with breaks\n", buf.String())
+}
+
+func TestParseTableOptions(t *testing.T) {
+	_, ok, err := ParseTableOptions("json", false, "")
+	require.NoError(t, err)
+	assert.False(t, ok, "json is not a table")
+
+	for _, output := range []string{"custom-columns=", "custom-columns=NAME", "custom-columns=NAME:.name,:.cpu", "custom-columns=NAME:{.name"} {
+		_, _, err := ParseTableOptions(output, false, "")
+		assert.Error(t, err, output)
+	}
+	_, _, err = ParseTableOptions("table", false, "{.name")
+	assert.Error(t, err)
+}
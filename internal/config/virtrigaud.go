@@ -0,0 +1,532 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// VirtrigaudConfigAPIVersion and VirtrigaudConfigKind identify the
+	// document stored in the manager's config ConfigMap.
+	VirtrigaudConfigAPIVersion = "config.virtrigaud.io/v1beta1"
+	VirtrigaudConfigKind       = "VirtrigaudConfig"
+
+	// VirtrigaudConfigKey is the ConfigMap data key holding the document.
+	VirtrigaudConfigKey = "config.yaml"
+
+	// DefaultVirtrigaudConfigName is the ConfigMap the manager reads unless
+	// --config-name says otherwise.
+	DefaultVirtrigaudConfigName = "virtrigaud-config"
+)
+
+// VirtrigaudConfig is the manager's tuning configuration. It is read from
+// the config.yaml key of a ConfigMap at startup and watched afterwards.
+// Fields left out keep their defaults, and unknown fields are rejected.
+//
+// Precedence is flags > VirtrigaudConfig > defaults: a value given on the
+// command line is never overridden by the ConfigMap.
+//
+// LogLevel, LogLevels, Requeue, ProviderRPC, Deletion, EndpointMigration,
+// Maintenance, Snapshots, Cleanup, Busy and StatusUpdates are applied on
+// change without a restart.
+// Concurrency, APIServer, Metrics and HealthProbeBindAddress are only read
+// at startup; changing them is reported and takes effect on the next restart.
+type VirtrigaudConfig struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+
+	// LogLevel is "debug", "info", "error", or an integer verbosity as
+	// accepted by --zap-log-level.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// LogLevels overrides LogLevel for individual loggers, keyed by
+	// "controller.<name>" for a controller (controller.virtualmachine) or
+	// by logger name (provider.resolver). An override also applies to the
+	// loggers named below it, e.g. provider to provider.resolver.
+	LogLevels map[string]string `json:"logLevels,omitempty"`
+
+	// Requeue holds how long controllers wait before looking at an object
+	// again.
+	Requeue RequeueConfig `json:"requeue,omitempty"`
+
+	// ProviderRPC holds the deadlines of calls to provider services.
+	ProviderRPC ProviderRPCConfig `json:"providerRPC,omitempty"`
+
+	// Deletion holds when a deletion the provider cannot be reached for
+	// is reported as blocked.
+	Deletion DeletionConfig `json:"deletion,omitempty"`
+
+	// EndpointMigration holds how a Provider's new endpoint is verified.
+	EndpointMigration EndpointMigrationConfig `json:"endpointMigration,omitempty"`
+
+	// Maintenance puts every Provider in maintenance.
+	Maintenance MaintenanceConfig `json:"maintenance,omitempty"`
+
+	// Snapshots holds when a VM's snapshot chain is reported as long.
+	Snapshots SnapshotsConfig `json:"snapshots,omitempty"`
+
+	// Cleanup holds how artifacts left behind by failed operations are
+	// deleted.
+	Cleanup CleanupConfig `json:"cleanup,omitempty"`
+
+	// Busy holds how long changes wait for a VM the hypervisor reports
+	// busy.
+	Busy BusyConfig `json:"busy,omitempty"`
+
+	// StatusUpdates holds how often VirtualMachine status is written for
+	// usage alone.
+	StatusUpdates StatusUpdatesConfig `json:"statusUpdates,omitempty"`
+
+	// Concurrency holds MaxConcurrentReconciles per controller.
+	Concurrency ConcurrencyConfig `json:"concurrency,omitempty"`
+
+	// APIServer holds the manager's client-side rate limit.
+	APIServer APIServerConfig `json:"apiServer,omitempty"`
+
+	// Metrics configures the metrics endpoint.
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+
+	// HealthProbeBindAddress is where /healthz and /readyz are served.
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+}
+
+// RequeueConfig holds controller requeue intervals.
+type RequeueConfig struct {
+	// Running is the poll interval for a powered-on VM with addresses.
+	Running metav1.Duration `json:"running,omitempty"`
+	// PoweredOff is the poll interval for a powered-off VM.
+	PoweredOff metav1.Duration `json:"poweredOff,omitempty"`
+	// Transitional is the poll interval for a VM in any other power state.
+	Transitional metav1.Duration `json:"transitional,omitempty"`
+	// WaitingForIP is the poll interval for a powered-on VM with no
+	// addresses reported yet.
+	WaitingForIP metav1.Duration `json:"waitingForIP,omitempty"`
+	// InProgress is the requeue while a VM waits on a provider task or a
+	// dependency.
+	InProgress metav1.Duration `json:"inProgress,omitempty"`
+	// DeleteRetry is the requeue while a provider Delete keeps failing.
+	DeleteRetry metav1.Duration `json:"deleteRetry,omitempty"`
+	// AdoptionRetry is the requeue while an adopted VM cannot be found.
+	AdoptionRetry metav1.Duration `json:"adoptionRetry,omitempty"`
+	// ProviderNotReady is the requeue while a Provider's runtime
+	// Deployment has no ready replicas.
+	ProviderNotReady metav1.Duration `json:"providerNotReady,omitempty"`
+	// ProviderError is the requeue after a Provider failed to reconcile.
+	ProviderError metav1.Duration `json:"providerError,omitempty"`
+}
+
+// Bounds of a Provider's spec.reconcileInterval and
+// spec.statusRefreshInterval. The upper bound of the status refresh is the
+// effective reconcile interval: a VM whose state is changing is never
+// polled less often than one whose state has settled.
+const (
+	MinReconcileInterval     = 10 * time.Second
+	MaxReconcileInterval     = time.Hour
+	MinStatusRefreshInterval = 5 * time.Second
+)
+
+// ForProvider returns c with a Provider's spec.reconcileInterval and
+// spec.statusRefreshInterval applied, each kept within its bounds. The
+// reconcile interval replaces Running and PoweredOff, the status refresh
+// Transitional and WaitingForIP. Nil intervals keep c's values.
+func (c RequeueConfig) ForProvider(reconcile, statusRefresh *metav1.Duration) RequeueConfig {
+	if reconcile == nil && statusRefresh == nil {
+		return c
+	}
+	if reconcile != nil {
+		d := min(max(reconcile.Duration, MinReconcileInterval), MaxReconcileInterval)
+		c.Running.Duration, c.PoweredOff.Duration = d, d
+	}
+	if statusRefresh != nil {
+		d := max(statusRefresh.Duration, MinStatusRefreshInterval)
+		c.Transitional.Duration, c.WaitingForIP.Duration = d, d
+	}
+	settled := min(c.Running.Duration, c.PoweredOff.Duration)
+	c.Transitional.Duration = min(c.Transitional.Duration, settled)
+	c.WaitingForIP.Duration = min(c.WaitingForIP.Duration, settled)
+	return c
+}
+
+// DescribeCacheTTL is the longest a Describe result may be served from a
+// cache under c: the shortest interval a VM is polled at. A longer TTL
+// would have a poll read the result of the previous one, and the cache
+// and the requeue would fight over how fresh the status is.
+func (c RequeueConfig) DescribeCacheTTL() time.Duration {
+	return min(c.Running.Duration, c.PoweredOff.Duration, c.Transitional.Duration, c.WaitingForIP.Duration)
+}
+
+// ProviderRPCConfig holds provider RPC deadlines.
+type ProviderRPCConfig struct {
+	// Read covers Validate, GetCapabilities, Describe and other lookups.
+	Read metav1.Duration `json:"read,omitempty"`
+	// Mutating covers Create, Reconfigure, Clone and PrepareImage.
+	Mutating metav1.Duration `json:"mutating,omitempty"`
+	// Power covers Power and Delete.
+	Power metav1.Duration `json:"power,omitempty"`
+	// TaskStatus covers task polling.
+	TaskStatus metav1.Duration `json:"taskStatus,omitempty"`
+}
+
+// DeletionConfig holds when a deletion is reported as blocked. A deletion
+// is blocked once at least BlockedAttempts attempts in a row found the
+// provider unreachable, over at least BlockedAfter. A blocked VM or
+// VMSnapshot gets a DeletionBlocked condition, and its finalizer is removed
+// when it opted in with virtrigaud.io/abandon-on-provider-loss or its
+// Provider is gone.
+type DeletionConfig struct {
+	BlockedAfter    metav1.Duration `json:"blockedAfter,omitempty"`
+	BlockedAttempts int             `json:"blockedAttempts,omitempty"`
+}
+
+// EndpointMigrationConfig holds how a Provider whose spec.endpoint changed
+// is verified before its VMs are changed again. VerifySampleSize of its
+// VMs must still be found through the new endpoint.
+type EndpointMigrationConfig struct {
+	VerifySampleSize int `json:"verifySampleSize,omitempty"`
+}
+
+// MaintenanceConfig holds cluster-wide maintenance. While Enabled, every
+// Provider is treated as if its spec.maintenanceMode were set: new VMs,
+// clones and snapshots are held, and existing VMs are still reconciled.
+type MaintenanceConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// SnapshotsConfig holds when a VM's snapshot chain is reported. A VM whose
+// chain is deeper than ChainWarningDepth snapshots gets a SnapshotChainLong
+// condition and counts towards virtrigaud_vm_snapshot_chain_long.
+type SnapshotsConfig struct {
+	ChainWarningDepth int `json:"chainWarningDepth,omitempty"`
+}
+
+// CleanupConfig holds how the cleanup worker deletes the provider-side
+// artifacts that failed clones and migrations left behind. A failed
+// deletion is retried after InitialBackoff, doubling up to MaxBackoff, and
+// the artifact is abandoned after MaxAttempts failures. A pass of the
+// worker makes at most BatchSize deletions.
+type CleanupConfig struct {
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     metav1.Duration `json:"maxBackoff,omitempty"`
+	MaxAttempts    int             `json:"maxAttempts,omitempty"`
+	BatchSize      int             `json:"batchSize,omitempty"`
+}
+
+// BusyConfig holds how long changes to a VM wait while its provider reports
+// it busy, e.g. locked by a backup or mid-migration. The VM gets a
+// ProviderBusy condition and changes are not attempted for up to MaxWait;
+// after that they are attempted again and fail as they would otherwise.
+type BusyConfig struct {
+	MaxWait metav1.Duration `json:"maxWait,omitempty"`
+}
+
+// StatusUpdatesConfig holds how often usage refreshes VirtualMachine status.
+// Guest stats and the usage keys of status.provider change on every describe
+// but are not worth a status write each time: a reconcile that changes
+// nothing else writes them only once the stored sample is UsageInterval old.
+type StatusUpdatesConfig struct {
+	UsageInterval metav1.Duration `json:"usageInterval,omitempty"`
+}
+
+// ConcurrencyConfig holds MaxConcurrentReconciles per controller.
+type ConcurrencyConfig struct {
+	VirtualMachine int `json:"virtualMachine,omitempty"`
+	Provider       int `json:"provider,omitempty"`
+	VMMigration    int `json:"vmMigration,omitempty"`
+	VMAdoption     int `json:"vmAdoption,omitempty"`
+}
+
+// APIServerConfig is the manager's client-side rate limit.
+type APIServerConfig struct {
+	QPS   float32 `json:"qps,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// MetricsConfig configures the metrics endpoint.
+type MetricsConfig struct {
+	BindAddress string `json:"bindAddress,omitempty"`
+	Secure      bool   `json:"secure,omitempty"`
+}
+
+// DefaultVirtrigaudConfig returns the values the manager uses when neither
+// a flag nor the ConfigMap sets them.
+func DefaultVirtrigaudConfig() *VirtrigaudConfig {
+	d := func(v time.Duration) metav1.Duration { return metav1.Duration{Duration: v} }
+	return &VirtrigaudConfig{
+		APIVersion: VirtrigaudConfigAPIVersion,
+		Kind:       VirtrigaudConfigKind,
+		LogLevel:   "info",
+		Requeue: RequeueConfig{
+			Running:          d(2 * time.Minute),
+			PoweredOff:       d(5 * time.Minute),
+			Transitional:     d(10 * time.Second),
+			WaitingForIP:     d(10 * time.Second),
+			InProgress:       d(5 * time.Second),
+			DeleteRetry:      d(15 * time.Second),
+			AdoptionRetry:    d(30 * time.Second),
+			ProviderNotReady: d(30 * time.Second),
+			ProviderError:    d(time.Minute),
+		},
+		ProviderRPC: ProviderRPCConfig{
+			Read:       d(30 * time.Second),
+			Mutating:   d(5 * time.Minute),
+			Power:      d(2 * time.Minute),
+			TaskStatus: d(10 * time.Second),
+		},
+		Deletion: DeletionConfig{
+			BlockedAfter:    d(time.Hour),
+			BlockedAttempts: 5,
+		},
+		EndpointMigration: EndpointMigrationConfig{
+			VerifySampleSize: 5,
+		},
+		Snapshots: SnapshotsConfig{
+			ChainWarningDepth: 5,
+		},
+		Cleanup: CleanupConfig{
+			InitialBackoff: d(time.Minute),
+			MaxBackoff:     d(time.Hour),
+			MaxAttempts:    8,
+			BatchSize:      10,
+		},
+		Busy: BusyConfig{
+			MaxWait: d(time.Hour),
+		},
+		StatusUpdates: StatusUpdatesConfig{
+			UsageInterval: d(5 * time.Minute),
+		},
+		Concurrency: ConcurrencyConfig{
+			VirtualMachine: 10,
+			Provider:       5,
+			VMMigration:    3,
+			VMAdoption:     1,
+		},
+		APIServer: APIServerConfig{
+			QPS:   20,
+			Burst: 40,
+		},
+		Metrics: MetricsConfig{
+			BindAddress: ":8080",
+		},
+		HealthProbeBindAddress: ":8081",
+	}
+}
+
+// ParseVirtrigaudConfig decodes a VirtrigaudConfig document over the
+// defaults. Unknown fields, a wrong apiVersion or kind, and out-of-range
+// values are errors.
+func ParseVirtrigaudConfig(data []byte) (*VirtrigaudConfig, error) {
+	cfg := DefaultVirtrigaudConfig()
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", VirtrigaudConfigKind, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that every field is usable.
+func (c *VirtrigaudConfig) Validate() error {
+	var errs []error
+	if c.APIVersion != VirtrigaudConfigAPIVersion {
+		errs = append(errs, fmt.Errorf("apiVersion must be %q, got %q", VirtrigaudConfigAPIVersion, c.APIVersion))
+	}
+	if c.Kind != VirtrigaudConfigKind {
+		errs = append(errs, fmt.Errorf("kind must be %q, got %q", VirtrigaudConfigKind, c.Kind))
+	}
+	if _, err := ParseLogLevel(c.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	for name, level := range c.LogLevels {
+		if name == "" {
+			errs = append(errs, fmt.Errorf("logLevels: logger name must not be empty"))
+		}
+		if _, err := ParseLogLevel(level); err != nil {
+			errs = append(errs, fmt.Errorf("logLevels[%s]: %w", name, err))
+		}
+	}
+	for name, v := range map[string]metav1.Duration{
+		"requeue.running":             c.Requeue.Running,
+		"requeue.poweredOff":          c.Requeue.PoweredOff,
+		"requeue.transitional":        c.Requeue.Transitional,
+		"requeue.waitingForIP":        c.Requeue.WaitingForIP,
+		"requeue.inProgress":          c.Requeue.InProgress,
+		"requeue.deleteRetry":         c.Requeue.DeleteRetry,
+		"requeue.adoptionRetry":       c.Requeue.AdoptionRetry,
+		"requeue.providerNotReady":    c.Requeue.ProviderNotReady,
+		"requeue.providerError":       c.Requeue.ProviderError,
+		"providerRPC.read":            c.ProviderRPC.Read,
+		"providerRPC.mutating":        c.ProviderRPC.Mutating,
+		"providerRPC.power":           c.ProviderRPC.Power,
+		"providerRPC.taskStatus":      c.ProviderRPC.TaskStatus,
+		"deletion.blockedAfter":       c.Deletion.BlockedAfter,
+		"cleanup.initialBackoff":      c.Cleanup.InitialBackoff,
+		"cleanup.maxBackoff":          c.Cleanup.MaxBackoff,
+		"busy.maxWait":                c.Busy.MaxWait,
+		"statusUpdates.usageInterval": c.StatusUpdates.UsageInterval,
+	} {
+		if v.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", name, v.Duration))
+		}
+	}
+	for name, v := range map[string]int{
+		"concurrency.virtualMachine":         c.Concurrency.VirtualMachine,
+		"concurrency.provider":               c.Concurrency.Provider,
+		"concurrency.vmMigration":            c.Concurrency.VMMigration,
+		"concurrency.vmAdoption":             c.Concurrency.VMAdoption,
+		"deletion.blockedAttempts":           c.Deletion.BlockedAttempts,
+		"endpointMigration.verifySampleSize": c.EndpointMigration.VerifySampleSize,
+		"snapshots.chainWarningDepth":        c.Snapshots.ChainWarningDepth,
+		"cleanup.maxAttempts":                c.Cleanup.MaxAttempts,
+		"cleanup.batchSize":                  c.Cleanup.BatchSize,
+		"apiServer.burst":                    c.APIServer.Burst,
+	} {
+		if v < 1 {
+			errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", name, v))
+		}
+	}
+	if c.Cleanup.MaxBackoff.Duration < c.Cleanup.InitialBackoff.Duration {
+		errs = append(errs, fmt.Errorf("cleanup.maxBackoff %s must not be shorter than cleanup.initialBackoff %s",
+			c.Cleanup.MaxBackoff.Duration, c.Cleanup.InitialBackoff.Duration))
+	}
+	if c.APIServer.QPS <= 0 {
+		errs = append(errs, fmt.Errorf("apiServer.qps must be positive, got %g", c.APIServer.QPS))
+	}
+	// Sorting keeps the message stable; map iteration above is not.
+	return sortedJoin(errs)
+}
+
+// ParseLogLevel turns a log level name or integer verbosity into the zap
+// level it stands for: "debug" is -1, "info" 0, "error" 2, and an integer
+// n is -n, as with --zap-log-level.
+func ParseLogLevel(level string) (int8, error) {
+	switch level {
+	case "debug":
+		return -1, nil
+	case "info":
+		return 0, nil
+	case "error":
+		return 2, nil
+	}
+	n, err := strconv.Atoi(level)
+	if err != nil || n < 0 || n > 127 {
+		return 0, fmt.Errorf("logLevel must be debug, info, error or a verbosity from 0 to 127, got %q", level)
+	}
+	return int8(-n), nil
+}
+
+// restartRequiredFields are the fields read only at startup.
+var restartRequiredFields = []struct {
+	name  string
+	value func(*VirtrigaudConfig) any
+	keep  func(dst, src *VirtrigaudConfig)
+}{
+	{"concurrency", func(c *VirtrigaudConfig) any { return c.Concurrency }, func(dst, src *VirtrigaudConfig) { dst.Concurrency = src.Concurrency }},
+	{"apiServer", func(c *VirtrigaudConfig) any { return c.APIServer }, func(dst, src *VirtrigaudConfig) { dst.APIServer = src.APIServer }},
+	{"metrics", func(c *VirtrigaudConfig) any { return c.Metrics }, func(dst, src *VirtrigaudConfig) { dst.Metrics = src.Metrics }},
+	{"healthProbeBindAddress", func(c *VirtrigaudConfig) any { return c.HealthProbeBindAddress }, func(dst, src *VirtrigaudConfig) {
+		dst.HealthProbeBindAddress = src.HealthProbeBindAddress
+	}},
+}
+
+// ConfigStore holds the manager's current VirtrigaudConfig. Controllers
+// read it on every use, so hot-reloadable fields take effect on their next
+// reconcile. A nil *ConfigStore serves the defaults.
+type ConfigStore struct {
+	current   atomic.Pointer[VirtrigaudConfig]
+	overrides func(*VirtrigaudConfig)
+
+	mu        sync.Mutex
+	listeners []func(old, updated *VirtrigaudConfig)
+}
+
+// NewConfigStore returns a store holding initial with overrides applied.
+// overrides sets the values given as flags; it is applied again to every
+// later update so the command line keeps precedence. It may be nil.
+func NewConfigStore(initial *VirtrigaudConfig, overrides func(*VirtrigaudConfig)) *ConfigStore {
+	if overrides == nil {
+		overrides = func(*VirtrigaudConfig) {}
+	}
+	cfg := *initial
+	overrides(&cfg)
+	s := &ConfigStore{overrides: overrides}
+	s.current.Store(&cfg)
+	return s
+}
+
+// Get returns the configuration in effect. The result must not be modified.
+func (s *ConfigStore) Get() *VirtrigaudConfig {
+	if s == nil {
+		return DefaultVirtrigaudConfig()
+	}
+	return s.current.Load()
+}
+
+// OnChange registers fn to be called after each Update, with the previous
+// and the new configuration.
+func (s *ConfigStore) OnChange(fn func(old, updated *VirtrigaudConfig)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Update makes next the configuration in effect, after applying the flag
+// overrides. Fields that are only read at startup keep their current values;
+// the ones next changes are returned so the caller can report that a
+// restart is needed.
+func (s *ConfigStore) Update(next *VirtrigaudConfig) (restartRequired []string) {
+	cfg := *next
+	s.overrides(&cfg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := s.current.Load()
+	for _, f := range restartRequiredFields {
+		if f.value(&cfg) != f.value(old) {
+			restartRequired = append(restartRequired, f.name)
+		}
+		f.keep(&cfg, old)
+	}
+	s.current.Store(&cfg)
+	for _, fn := range s.listeners {
+		fn(old, &cfg)
+	}
+	return restartRequired
+}
+
+func sortedJoin(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	slices.Sort(msgs)
+	return errors.New(strings.Join(msgs, "; "))
+}
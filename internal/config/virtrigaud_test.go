@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseVirtrigaudConfig_OverDefaults(t *testing.T) {
+	cfg, err := ParseVirtrigaudConfig([]byte(`
+apiVersion: config.virtrigaud.io/v1beta1
+kind: VirtrigaudConfig
+logLevel: debug
+logLevels:
+  controller.virtualmachine: debug
+  provider.resolver: "2"
+requeue:
+  running: 90s
+providerRPC:
+  mutating: 10m
+concurrency:
+  virtualMachine: 25
+maintenance:
+  enabled: true
+snapshots:
+  chainWarningDepth: 8
+cleanup:
+  maxAttempts: 3
+busy:
+  maxWait: 30m
+statusUpdates:
+  usageInterval: 15m
+`))
+	require.NoError(t, err)
+
+	want := DefaultVirtrigaudConfig()
+	want.LogLevel = "debug"
+	want.LogLevels = map[string]string{"controller.virtualmachine": "debug", "provider.resolver": "2"}
+	want.Requeue.Running.Duration = 90 * time.Second
+	want.ProviderRPC.Mutating.Duration = 10 * time.Minute
+	want.Concurrency.VirtualMachine = 25
+	want.Maintenance.Enabled = true
+	want.Snapshots.ChainWarningDepth = 8
+	want.Cleanup.MaxAttempts = 3
+	want.Busy.MaxWait.Duration = 30 * time.Minute
+	want.StatusUpdates.UsageInterval.Duration = 15 * time.Minute
+	assert.Equal(t, want, cfg)
+}
+
+func TestParseVirtrigaudConfig_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "unknown field",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\nrequeue:\n  runing: 1m\n",
+			want: `unknown field "runing"`,
+		},
+		{
+			name: "wrong kind",
+			doc:  "kind: ConfigMap\napiVersion: config.virtrigaud.io/v1beta1\n",
+			want: `kind must be "VirtrigaudConfig", got "ConfigMap"`,
+		},
+		{
+			name: "bad duration",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\nrequeue:\n  running: soon\n",
+			want: "invalid duration",
+		},
+		{
+			name: "non-positive values",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\nrequeue:\n  inProgress: 0s\nconcurrency:\n  provider: -1\n",
+			want: "concurrency.provider must be at least 1, got -1; requeue.inProgress must be positive, got 0s",
+		},
+		{
+			name: "cleanup backoff",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\ncleanup:\n  initialBackoff: 2h\n",
+			want: "cleanup.maxBackoff 1h0m0s must not be shorter than cleanup.initialBackoff 2h0m0s",
+		},
+		{
+			name: "log level",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\nlogLevel: loud\n",
+			want: `logLevel must be debug, info, error or a verbosity from 0 to 127, got "loud"`,
+		},
+		{
+			name: "per-logger log level",
+			doc:  "kind: VirtrigaudConfig\napiVersion: config.virtrigaud.io/v1beta1\nlogLevels:\n  controller.vmclone: trace\n",
+			want: `logLevels[controller.vmclone]: logLevel must be debug, info, error or a verbosity from 0 to 127, got "trace"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseVirtrigaudConfig([]byte(tc.doc))
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	for level, want := range map[string]int8{"debug": -1, "info": 0, "error": 2, "0": 0, "3": -3} {
+		got, err := ParseLogLevel(level)
+		require.NoError(t, err, level)
+		assert.Equal(t, want, got, level)
+	}
+	for _, level := range []string{"", "warn", "-1", "128"} {
+		_, err := ParseLogLevel(level)
+		assert.Error(t, err, level)
+	}
+}
+
+func TestRequeueConfigForProvider(t *testing.T) {
+	base := DefaultVirtrigaudConfig().Requeue
+	d := func(v time.Duration) *metav1.Duration { return &metav1.Duration{Duration: v} }
+	intervals := func(c RequeueConfig) [4]time.Duration {
+		return [4]time.Duration{c.Running.Duration, c.PoweredOff.Duration, c.Transitional.Duration, c.WaitingForIP.Duration}
+	}
+
+	cases := []struct {
+		name                     string
+		reconcile, statusRefresh *metav1.Duration
+		want                     [4]time.Duration
+	}{
+		{"unset", nil, nil, [4]time.Duration{2 * time.Minute, 5 * time.Minute, 10 * time.Second, 10 * time.Second}},
+		{"static estate", d(30 * time.Minute), d(time.Minute), [4]time.Duration{30 * time.Minute, 30 * time.Minute, time.Minute, time.Minute}},
+		{"busy cluster", d(15 * time.Second), d(5 * time.Second), [4]time.Duration{15 * time.Second, 15 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"reconcile below the minimum", d(time.Second), nil, [4]time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second}},
+		{"reconcile above the maximum", d(24 * time.Hour), nil, [4]time.Duration{time.Hour, time.Hour, 10 * time.Second, 10 * time.Second}},
+		{"status refresh below the minimum", nil, d(0), [4]time.Duration{2 * time.Minute, 5 * time.Minute, 5 * time.Second, 5 * time.Second}},
+		{"status refresh above the reconcile interval", d(20 * time.Second), d(time.Minute), [4]time.Duration{20 * time.Second, 20 * time.Second, 20 * time.Second, 20 * time.Second}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := base.ForProvider(tc.reconcile, tc.statusRefresh)
+			assert.Equal(t, tc.want, intervals(got))
+			assert.Equal(t, base.InProgress, got.InProgress, "other requeues are not per provider")
+			assert.Equal(t, min(tc.want[0], tc.want[1], tc.want[2], tc.want[3]), got.DescribeCacheTTL())
+		})
+	}
+}
+
+func TestConfigStore_Precedence(t *testing.T) {
+	// The flag sets the metrics address and the log level; the ConfigMap
+	// sets both as well as the probe address.
+	flags := func(c *VirtrigaudConfig) {
+		c.Metrics.BindAddress = ":9090"
+		c.LogLevel = "error"
+	}
+	resource := DefaultVirtrigaudConfig()
+	resource.Metrics.BindAddress = ":7070"
+	resource.HealthProbeBindAddress = ":7071"
+	resource.LogLevel = "debug"
+
+	store := NewConfigStore(resource, flags)
+	got := store.Get()
+	assert.Equal(t, ":9090", got.Metrics.BindAddress, "flag beats ConfigMap")
+	assert.Equal(t, "error", got.LogLevel, "flag beats ConfigMap")
+	assert.Equal(t, ":7071", got.HealthProbeBindAddress, "ConfigMap beats default")
+	assert.Equal(t, DefaultVirtrigaudConfig().Requeue, got.Requeue, "defaults fill the rest")
+
+	// Flags keep winning on reload.
+	update := DefaultVirtrigaudConfig()
+	update.LogLevel = "debug"
+	store.Update(update)
+	assert.Equal(t, "error", store.Get().LogLevel)
+
+	var nilStore *ConfigStore
+	assert.Equal(t, DefaultVirtrigaudConfig(), nilStore.Get())
+}
+
+func TestConfigStore_Update(t *testing.T) {
+	store := NewConfigStore(DefaultVirtrigaudConfig(), nil)
+	var seen []string
+	store.OnChange(func(old, updated *VirtrigaudConfig) {
+		seen = append(seen, old.LogLevel+"->"+updated.LogLevel)
+	})
+
+	next := DefaultVirtrigaudConfig()
+	next.LogLevel = "debug"
+	next.Requeue.Running.Duration = time.Minute
+	next.Concurrency.VirtualMachine = 50
+	next.Metrics.BindAddress = ":9999"
+
+	restart := store.Update(next)
+	assert.Equal(t, []string{"concurrency", "metrics"}, restart)
+	got := store.Get()
+	assert.Equal(t, "debug", got.LogLevel)
+	assert.Equal(t, time.Minute, got.Requeue.Running.Duration)
+	assert.Equal(t, 10, got.Concurrency.VirtualMachine, "restart-required fields keep the startup value")
+	assert.Equal(t, ":8080", got.Metrics.BindAddress)
+	assert.Equal(t, []string{"info->debug"}, seen)
+
+	// Applying the same document again reports the same pending restart.
+	assert.Equal(t, []string{"concurrency", "metrics"}, store.Update(next))
+	assert.Empty(t, store.Update(DefaultVirtrigaudConfig()))
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// DefaultBenchDir holds the benchmark scenarios. They use the conformance
+// spec format; a scenario's setup steps are untimed and its steps are the
+// timed operation.
+const DefaultBenchDir = "test/conformance/bench"
+
+// BenchOptions configures a benchmark run.
+type BenchOptions struct {
+	// Dir holds the scenario files; DefaultBenchDir when empty.
+	Dir string
+	// Iterations is how many times each scenario is run.
+	Iterations int
+	// Baseline, if set, is a previous run to compare against.
+	Baseline *Benchmark
+	// Threshold is the slowdown, in percent of the baseline median or
+	// p95, beyond which an operation counts as a regression.
+	Threshold float64
+}
+
+// Benchmark is the result of a benchmark run, written as benchmark.json.
+type Benchmark struct {
+	Provider        string                       `json:"provider"`
+	ProviderType    string                       `json:"providerType,omitempty"`
+	ProviderVersion string                       `json:"providerVersion,omitempty"`
+	ProviderGitSHA  string                       `json:"providerGitSHA,omitempty"`
+	Hypervisor      *infrav1beta1.HypervisorInfo `json:"hypervisor,omitempty"`
+
+	Iterations int             `json:"iterations"`
+	Operations []OperationTime `json:"operations"`
+	Duration   time.Duration   `json:"duration"`
+	Timestamp  time.Time       `json:"timestamp"`
+
+	// Comparison is set when the run was compared against a baseline.
+	Comparison *Comparison `json:"comparison,omitempty"`
+}
+
+// OperationTime holds the timings of one scenario.
+type OperationTime struct {
+	Name                 string   `json:"name"`
+	Description          string   `json:"description,omitempty"`
+	RequiredCapabilities []string `json:"requiredCapabilities,omitempty"`
+	// Status is measured when at least one iteration succeeded, failed
+	// when none did, and skipped when the scenario was not run.
+	Status   string          `json:"status"`
+	Samples  []time.Duration `json:"samples,omitempty"`
+	Median   time.Duration   `json:"median,omitempty"`
+	P95      time.Duration   `json:"p95,omitempty"`
+	Failures int             `json:"failures,omitempty"`
+	// Error is the last failure, or why the scenario was skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// Comparison is a benchmark compared against a baseline run.
+type Comparison struct {
+	BaselineProvider  string    `json:"baselineProvider"`
+	BaselineVersion   string    `json:"baselineVersion,omitempty"`
+	BaselineTimestamp time.Time `json:"baselineTimestamp"`
+	Threshold         float64   `json:"threshold"`
+	Deltas            []Delta   `json:"deltas"`
+}
+
+// Delta compares one operation measured in both runs. Changes are in
+// percent of the baseline; positive means slower.
+type Delta struct {
+	Operation      string        `json:"operation"`
+	BaselineMedian time.Duration `json:"baselineMedian"`
+	Median         time.Duration `json:"median"`
+	MedianChange   float64       `json:"medianChange"`
+	BaselineP95    time.Duration `json:"baselineP95"`
+	P95            time.Duration `json:"p95"`
+	P95Change      float64       `json:"p95Change"`
+	Regressed      bool          `json:"regressed"`
+}
+
+// Regressions returns the operations slower than the threshold allows.
+func (c *Comparison) Regressions() []Delta {
+	if c == nil {
+		return nil
+	}
+	var out []Delta
+	for _, d := range c.Deltas {
+		if d.Regressed {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Failures returns the number of failed iterations across all operations.
+func (b *Benchmark) Failures() int {
+	n := 0
+	for _, op := range b.Operations {
+		n += op.Failures
+	}
+	return n
+}
+
+// LoadBenchmark reads a benchmark.json written by a previous run.
+func LoadBenchmark(path string) (*Benchmark, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark: %w", err)
+	}
+	b := &Benchmark{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Bench runs every scenario the provider has the capabilities for
+// opts.Iterations times and records how long its steps take. Each
+// iteration starts from scratch: setup runs untimed before the steps, and
+// everything the iteration created is removed afterwards, whether or not
+// it succeeded. Results are saved to benchmark.json and benchmark.md.
+func (r *Runner) Bench(ctx context.Context, opts BenchOptions) (*Benchmark, error) {
+	startTime := time.Now()
+
+	if opts.Iterations < 1 {
+		return nil, fmt.Errorf("iterations must be at least 1, got %d", opts.Iterations)
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = DefaultBenchDir
+	}
+	scenarios, err := loadSpecs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load benchmark scenarios: %w", err)
+	}
+
+	provider, err := r.getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := providerCapabilities(provider)
+
+	bench := &Benchmark{
+		Provider:        r.config.Provider,
+		ProviderType:    string(provider.Spec.Type),
+		ProviderVersion: provider.Status.Version,
+		ProviderGitSHA:  provider.Status.GitSHA,
+		Hypervisor:      provider.Status.Hypervisor,
+		Iterations:      opts.Iterations,
+		Timestamp:       startTime,
+	}
+
+	for _, scenario := range scenarios {
+		op := OperationTime{
+			Name:                 scenario.Name,
+			Description:          scenario.Description,
+			RequiredCapabilities: scenario.RequiredCapabilities,
+		}
+		if reason := r.benchSkipReason(scenario, capabilities); reason != "" {
+			op.Status = "skipped"
+			op.Error = reason
+			bench.Operations = append(bench.Operations, op)
+			continue
+		}
+
+		for i := 0; i < opts.Iterations; i++ {
+			d, err := r.benchIteration(ctx, scenario)
+			if err != nil {
+				op.Failures++
+				op.Error = err.Error()
+			} else {
+				op.Samples = append(op.Samples, d)
+			}
+			if r.config.Verbose {
+				printIteration(scenario.Name, i+1, opts.Iterations, d, err)
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		op.Status = "failed"
+		if len(op.Samples) > 0 {
+			op.Status = "measured"
+			op.Median = percentile(op.Samples, 50)
+			op.P95 = percentile(op.Samples, 95)
+		}
+		bench.Operations = append(bench.Operations, op)
+	}
+
+	if opts.Baseline != nil {
+		bench.Comparison = CompareBenchmarks(opts.Baseline, bench, opts.Threshold)
+	}
+	bench.Duration = time.Since(startTime)
+
+	if err := r.saveBenchmark(bench); err != nil {
+		return bench, fmt.Errorf("failed to save benchmark: %w", err)
+	}
+	return bench, nil
+}
+
+// benchSkipReason says why a scenario cannot run against this provider,
+// or returns "" if it can.
+func (r *Runner) benchSkipReason(scenario TestSpec, capabilities []string) string {
+	var missing []string
+	for _, required := range scenario.RequiredCapabilities {
+		if !slices.Contains(capabilities, required) {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return "provider lacks capabilities: " + strings.Join(missing, ", ")
+	}
+
+	if r.shouldSkipTest(scenario.Name) {
+		return "skipped by request"
+	}
+
+	vars := map[string]string{"provider": r.config.Provider, "namespace": r.config.Namespace}
+	for k, v := range r.config.Vars {
+		vars[k] = v
+	}
+	var undefined []string
+	for _, steps := range [][]TestStep{scenario.Setup, scenario.Steps, scenario.Cleanup} {
+		for _, step := range steps {
+			undefined = append(undefined, referencedVars(step.Resource, vars)...)
+		}
+	}
+	if len(undefined) > 0 {
+		slices.Sort(undefined)
+		undefined = slices.Compact(undefined)
+		return "undefined variables " + strings.Join(undefined, ", ") + "; pass them with --set name=value"
+	}
+	return ""
+}
+
+// benchIteration runs a scenario once and returns how long its steps took.
+func (r *Runner) benchIteration(ctx context.Context, scenario TestSpec) (_ time.Duration, err error) {
+	testCtx, cancel := context.WithTimeout(ctx, testTimeout(scenario))
+	defer cancel()
+
+	s := &session{}
+	defer func() {
+		if cleanupErr := r.finish(ctx, s, scenario.Cleanup); cleanupErr != nil && err == nil {
+			err = cleanupErr
+		}
+	}()
+
+	if _, err := r.runSteps(testCtx, s, scenario.Setup); err != nil {
+		return 0, fmt.Errorf("setup: %w", err)
+	}
+	start := time.Now()
+	if _, err := r.runSteps(testCtx, s, scenario.Steps); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// percentile returns the p-th percentile of samples by the nearest-rank
+// method; the 50th of an even number of samples is the mean of the two
+// middle ones.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if p == 50 && n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	rank := int(math.Ceil(p / 100 * float64(n)))
+	return sorted[max(rank, 1)-1]
+}
+
+// CompareBenchmarks compares the operations measured in both runs. An
+// operation regresses when its median or p95 is more than threshold
+// percent slower than the baseline's. The runs may be of different
+// providers, which makes this a comparison report between them.
+func CompareBenchmarks(baseline, current *Benchmark, threshold float64) *Comparison {
+	c := &Comparison{
+		BaselineProvider:  baseline.Provider,
+		BaselineVersion:   baseline.ProviderVersion,
+		BaselineTimestamp: baseline.Timestamp,
+		Threshold:         threshold,
+		Deltas:            []Delta{},
+	}
+	for _, op := range current.Operations {
+		i := slices.IndexFunc(baseline.Operations, func(b OperationTime) bool { return b.Name == op.Name })
+		if i < 0 || op.Status != "measured" || baseline.Operations[i].Status != "measured" {
+			continue
+		}
+		base := baseline.Operations[i]
+		d := Delta{
+			Operation:      op.Name,
+			BaselineMedian: base.Median,
+			Median:         op.Median,
+			MedianChange:   change(base.Median, op.Median),
+			BaselineP95:    base.P95,
+			P95:            op.P95,
+			P95Change:      change(base.P95, op.P95),
+		}
+		d.Regressed = d.MedianChange > threshold || d.P95Change > threshold
+		c.Deltas = append(c.Deltas, d)
+	}
+	return c
+}
+
+// change returns how much slower current is than baseline, in percent.
+func change(baseline, current time.Duration) float64 {
+	if baseline <= 0 {
+		return 0
+	}
+	return float64(current-baseline) / float64(baseline) * 100
+}
+
+// saveBenchmark writes benchmark.json and the benchmark.md summary.
+func (r *Runner) saveBenchmark(bench *Benchmark) error {
+	data, err := json.MarshalIndent(bench, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal benchmark: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(r.config.OutputDir, "benchmark.json"), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write benchmark: %w", err)
+	}
+	summary := generateBenchmarkReport(bench)
+	if err := os.WriteFile(filepath.Join(r.config.OutputDir, "benchmark.md"), []byte(summary), 0o644); err != nil {
+		return fmt.Errorf("failed to write benchmark summary: %w", err)
+	}
+	return nil
+}
+
+// generateBenchmarkReport generates the Markdown summary of a benchmark.
+func generateBenchmarkReport(bench *Benchmark) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `# Virtrigaud Benchmark Report
+
+## Summary
+
+- **Provider**: %s
+- **Provider Version**: %s
+- **Hypervisor**: %s
+- **Iterations**: %d
+- **Duration**: %v
+- **Timestamp**: %s
+
+## Operations
+
+| Operation | Status | Samples | Median | p95 | Failures | Notes |
+|-----------|--------|---------|--------|-----|----------|-------|
+`, bench.Provider, versionString(bench.ProviderVersion, bench.ProviderGitSHA),
+		hypervisorString(bench.Hypervisor), bench.Iterations, bench.Duration.Round(time.Second),
+		bench.Timestamp.Format(time.RFC3339))
+
+	for _, op := range bench.Operations {
+		median, p95 := "-", "-"
+		if op.Status == "measured" {
+			median, p95 = roundDuration(op.Median).String(), roundDuration(op.P95).String()
+		}
+		notes := op.Error
+		if notes == "" {
+			notes = "-"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %d | %s |\n",
+			op.Name, op.Status, len(op.Samples), median, p95, op.Failures, notes)
+	}
+
+	if c := bench.Comparison; c != nil {
+		fmt.Fprintf(&b, `
+## Comparison
+
+Baseline: %s %s from %s. Threshold: %.0f%%.
+
+| Operation | Baseline median | Median | Change | Baseline p95 | p95 | Change | Result |
+|-----------|-----------------|--------|--------|--------------|-----|--------|--------|
+`, c.BaselineProvider, versionString(c.BaselineVersion, ""), c.BaselineTimestamp.Format(time.RFC3339), c.Threshold)
+		for _, d := range c.Deltas {
+			result := "ok"
+			if d.Regressed {
+				result = "**regressed**"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %+.1f%% | %s | %s | %+.1f%% | %s |\n",
+				d.Operation, roundDuration(d.BaselineMedian), roundDuration(d.Median), d.MedianChange,
+				roundDuration(d.BaselineP95), roundDuration(d.P95), d.P95Change, result)
+		}
+	}
+
+	return b.String()
+}
+
+// roundDuration rounds a timing for display.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(100 * time.Millisecond)
+}
+
+// printIteration prints the outcome of one benchmark iteration to stdout.
+func printIteration(name string, i, n int, d time.Duration, err error) {
+	if err != nil {
+		fmt.Printf("❌ %s [%d/%d]: %v\n", name, i, n, err)
+		return
+	}
+	fmt.Printf("✅ %s [%d/%d] (%v)\n", name, i, n, roundDuration(d))
+}
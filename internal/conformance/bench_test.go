@@ -0,0 +1,235 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+const benchScenarios = `
+- name: configmap-create
+  description: Create and update a ConfigMap
+  requiredCapabilities: [create, delete]
+  setup:
+    - name: create-setup
+      type: create
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: bench-setup
+  steps:
+    - name: create
+      type: create
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: bench-timed
+        data:
+          provider: ${provider}
+    - name: update
+      type: update
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: bench-timed
+        data:
+          updated: "yes"
+
+- name: needs-snapshots
+  description: Gated on a capability the provider lacks
+  requiredCapabilities: [snapshots]
+  steps:
+    - name: create
+      type: create
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: never-created
+
+- name: needs-image
+  description: Uses a variable that is not set
+  steps:
+    - name: create
+      type: create
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: never-created
+        data:
+          image: ${image}
+
+- name: fails
+  description: Fails after setup
+  setup:
+    - name: create-setup
+      type: create
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: fail-setup
+  steps:
+    - name: update-missing
+      type: update
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: does-not-exist
+`
+
+func benchRunner(t *testing.T) (*Runner, client.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, infrav1beta1.AddToScheme(s))
+	provider := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeLibvirt},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(provider).Build()
+
+	oldInterval := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = oldInterval })
+
+	return NewRunner(Config{KubeClient: c, Namespace: "default", Provider: "pve", OutputDir: t.TempDir()}), c
+}
+
+func TestBench(t *testing.T) {
+	r, c := benchRunner(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bench.yaml"), []byte(benchScenarios), 0o644))
+
+	bench, err := r.Bench(context.Background(), BenchOptions{Dir: dir, Iterations: 3})
+	require.NoError(t, err)
+	require.Len(t, bench.Operations, 4)
+
+	measured := bench.Operations[0]
+	assert.Equal(t, "measured", measured.Status, measured.Error)
+	assert.Len(t, measured.Samples, 3, "each iteration starts clean, so names can be reused")
+	assert.Positive(t, measured.Median)
+
+	assert.Equal(t, "skipped", bench.Operations[1].Status)
+	assert.Contains(t, bench.Operations[1].Error, "snapshots")
+	assert.Equal(t, "skipped", bench.Operations[2].Status)
+	assert.Contains(t, bench.Operations[2].Error, "image")
+
+	failed := bench.Operations[3]
+	assert.Equal(t, "failed", failed.Status)
+	assert.Equal(t, 3, failed.Failures)
+	assert.Contains(t, failed.Error, "update-missing")
+	assert.Equal(t, 3, bench.Failures())
+
+	configMaps := &corev1.ConfigMapList{}
+	require.NoError(t, c.List(context.Background(), configMaps))
+	assert.Empty(t, configMaps.Items, "everything created, including by failed iterations, is removed")
+
+	saved, err := LoadBenchmark(filepath.Join(r.config.OutputDir, "benchmark.json"))
+	require.NoError(t, err)
+	assert.Equal(t, bench.Operations[0].Samples, saved.Operations[0].Samples)
+	summary, err := os.ReadFile(filepath.Join(r.config.OutputDir, "benchmark.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "| configmap-create | measured | 3 |")
+}
+
+func TestPercentile(t *testing.T) {
+	samples := []time.Duration{5, 1, 4, 2, 3}
+	assert.Equal(t, time.Duration(3), percentile(samples, 50))
+	assert.Equal(t, time.Duration(5), percentile(samples, 95))
+	assert.Equal(t, []time.Duration{5, 1, 4, 2, 3}, samples, "samples are not reordered")
+
+	assert.Equal(t, time.Duration(25), percentile([]time.Duration{10, 20, 30, 40}, 50))
+	assert.Equal(t, time.Duration(7), percentile([]time.Duration{7}, 95))
+	assert.Zero(t, percentile(nil, 50))
+
+	var many []time.Duration
+	for i := 1; i <= 20; i++ {
+		many = append(many, time.Duration(i))
+	}
+	assert.Equal(t, time.Duration(19), percentile(many, 95))
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	baseline := &Benchmark{Provider: "pve", Operations: []OperationTime{
+		{Name: "create-to-ready", Status: "measured", Median: 100 * time.Second, P95: 120 * time.Second},
+		{Name: "power-cycle", Status: "measured", Median: 10 * time.Second, P95: 12 * time.Second},
+		{Name: "snapshot", Status: "measured", Median: 5 * time.Second, P95: 6 * time.Second},
+	}}
+	current := &Benchmark{Provider: "pve", Operations: []OperationTime{
+		{Name: "create-to-ready", Status: "measured", Median: 105 * time.Second, P95: 125 * time.Second},
+		{Name: "power-cycle", Status: "measured", Median: 10 * time.Second, P95: 15 * time.Second},
+		{Name: "snapshot", Status: "failed"},
+		{Name: "disk-import-10g", Status: "measured", Median: time.Minute, P95: time.Minute},
+	}}
+
+	c := CompareBenchmarks(baseline, current, 20)
+	require.Len(t, c.Deltas, 2, "only operations measured in both runs are compared")
+	assert.False(t, c.Deltas[0].Regressed)
+	assert.InDelta(t, 5.0, c.Deltas[0].MedianChange, 0.001)
+
+	regressions := c.Regressions()
+	require.Len(t, regressions, 1)
+	assert.Equal(t, "power-cycle", regressions[0].Operation)
+	assert.InDelta(t, 25.0, regressions[0].P95Change, 0.001)
+
+	assert.Empty(t, CompareBenchmarks(baseline, current, 30).Regressions())
+	assert.Empty(t, (*Comparison)(nil).Regressions())
+}
+
+func TestConditionMet(t *testing.T) {
+	obj := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	}
+
+	ready := obj(map[string]interface{}{"conditions": []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}})
+	assert.True(t, conditionMet(ready, "Ready"))
+	assert.False(t, conditionMet(ready, "Available"))
+	assert.True(t, conditionMet(obj(map[string]interface{}{"phase": "Ready"}), "Ready"))
+	assert.True(t, conditionMet(obj(map[string]interface{}{"ready": true}), "Ready"))
+	assert.False(t, conditionMet(obj(map[string]interface{}{"conditions": []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False"},
+	}}), "Ready"))
+
+	assert.True(t, conditionMet(obj(map[string]interface{}{"powerState": "On"}), "Running"))
+	assert.True(t, conditionMet(obj(map[string]interface{}{"powerState": "Off"}), "Stopped"))
+	assert.True(t, conditionMet(obj(map[string]interface{}{"id": "vm-100"}), "Provisioned"))
+	assert.False(t, conditionMet(obj(map[string]interface{}{}), "Provisioned"))
+	assert.False(t, conditionMet(obj(map[string]interface{}{}), "Deleted"), "an existing object is not deleted")
+}
@@ -22,13 +22,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
-	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
 )
 
 // Config holds configuration for the conformance runner
@@ -41,6 +47,10 @@ type Config struct {
 	SkipTests  []string
 	Parallel   int
 	Verbose    bool
+
+	// Vars are substituted for ${name} references in step resources.
+	// "provider" and "namespace" are always defined from the fields above.
+	Vars map[string]string
 }
 
 // Runner executes conformance tests
@@ -54,6 +64,7 @@ type TestSpec struct {
 	Name                 string            `yaml:"name"`
 	Description          string            `yaml:"description"`
 	RequiredCapabilities []string          `yaml:"requiredCapabilities"`
+	Setup                []TestStep        `yaml:"setup"`
 	Steps                []TestStep        `yaml:"steps"`
 	Cleanup              []TestStep        `yaml:"cleanup"`
 	Timeout              string            `yaml:"timeout"`
@@ -87,7 +98,15 @@ type WaitCondition struct {
 
 // Results holds test execution results
 type Results struct {
-	Provider  string        `json:"provider"`
+	Provider string `json:"provider"`
+	// ProviderType, ProviderVersion, ProviderGitSHA and Hypervisor record
+	// what the Provider reported when the run started, so results can be
+	// attributed to a provider build and hypervisor release.
+	ProviderType    string                       `json:"providerType,omitempty"`
+	ProviderVersion string                       `json:"providerVersion,omitempty"`
+	ProviderGitSHA  string                       `json:"providerGitSHA,omitempty"`
+	Hypervisor      *infrav1beta1.HypervisorInfo `json:"hypervisor,omitempty"`
+
 	Total     int           `json:"total"`
 	Passed    int           `json:"passed"`
 	Failed    int           `json:"failed"`
@@ -131,20 +150,24 @@ func (r *Runner) Run(ctx context.Context) (*Results, error) {
 		return nil, fmt.Errorf("failed to load tests: %w", err)
 	}
 
-	// Get provider capabilities
-	capabilities, err := r.getProviderCapabilities(ctx)
+	provider, err := r.getProvider(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get provider capabilities: %w", err)
+		return nil, err
 	}
+	capabilities := providerCapabilities(provider)
 
 	// Filter tests based on capabilities and skip list
 	filteredTests := r.filterTests(capabilities)
 
 	results := &Results{
-		Provider:  r.config.Provider,
-		Total:     len(filteredTests),
-		Timestamp: startTime,
-		Tests:     make([]TestResult, 0, len(filteredTests)),
+		Provider:        r.config.Provider,
+		ProviderType:    string(provider.Spec.Type),
+		ProviderVersion: provider.Status.Version,
+		ProviderGitSHA:  provider.Status.GitSHA,
+		Hypervisor:      provider.Status.Hypervisor,
+		Total:           len(filteredTests),
+		Timestamp:       startTime,
+		Tests:           make([]TestResult, 0, len(filteredTests)),
 	}
 
 	// Execute tests
@@ -193,35 +216,41 @@ func (r *Runner) ListTests() ([]TestSpec, error) {
 	return r.tests, nil
 }
 
-// loadTests loads test specifications from files
+// loadTests loads test specifications from files. Every file goes through
+// the same validator as `vcts validate`, so an invalid spec fails the run
+// instead of being silently filtered out.
 func (r *Runner) loadTests() error {
-	specDir := "test/conformance/specs"
+	tests, err := loadSpecs("test/conformance/specs")
+	if err != nil {
+		return err
+	}
+	r.tests = tests
+	return nil
+}
+
+// loadSpecs validates and loads every spec file in dir.
+func loadSpecs(specDir string) ([]TestSpec, error) {
 	specFiles, err := filepath.Glob(filepath.Join(specDir, "*.yaml"))
 	if err != nil {
-		return fmt.Errorf("failed to find test specs: %w", err)
+		return nil, fmt.Errorf("failed to find test specs: %w", err)
 	}
 
-	r.tests = []TestSpec{}
+	validator := NewValidator()
+	specs := []TestSpec{}
 	for _, specFile := range specFiles {
-		data, err := os.ReadFile(specFile)
+		tests, err := validator.ValidateFile(specFile)
 		if err != nil {
-			return fmt.Errorf("failed to read %s: %w", specFile, err)
+			return nil, fmt.Errorf("invalid test spec %s:\n%w", specFile, err)
 		}
 
-		var tests []TestSpec
-		if err := yaml.Unmarshal(data, &tests); err != nil {
-			return fmt.Errorf("failed to parse %s: %w", specFile, err)
-		}
-
-		r.tests = append(r.tests, tests...)
+		specs = append(specs, tests...)
 	}
 
-	return nil
+	return specs, nil
 }
 
-// getProviderCapabilities retrieves provider capabilities
-func (r *Runner) getProviderCapabilities(ctx context.Context) ([]string, error) {
-	// Get the provider resource
+// getProvider fetches the Provider under test
+func (r *Runner) getProvider(ctx context.Context) (*infrav1beta1.Provider, error) {
 	provider := &infrav1beta1.Provider{}
 	key := client.ObjectKey{
 		Namespace: r.config.Namespace,
@@ -231,18 +260,14 @@ func (r *Runner) getProviderCapabilities(ctx context.Context) ([]string, error)
 	if err := r.config.KubeClient.Get(ctx, key, provider); err != nil {
 		return nil, fmt.Errorf("failed to get provider %s: %w", r.config.Provider, err)
 	}
+	return provider, nil
+}
 
-	// Extract capabilities from provider status
-	// This would be populated by the provider's GetCapabilities RPC
-	capabilities := []string{}
-	// For now, return basic capabilities based on provider type
-	if string(provider.Spec.Type) == "vsphere" {
-		capabilities = append(capabilities, "vm-create", "vm-delete", "vm-power", "vm-reconfigure", "vm-snapshot")
-	} else if string(provider.Spec.Type) == "libvirt" {
-		capabilities = append(capabilities, "vm-create", "vm-delete", "vm-power")
-	}
-
-	return capabilities, nil
+// providerCapabilities returns the capability names provider supports.
+// Names are the canonical flags from sdk/provider/capabilities, which is
+// also what the validator accepts in requiredCapabilities.
+func providerCapabilities(provider *infrav1beta1.Provider) []string {
+	return capability.Names(provider)
 }
 
 // filterTests filters tests based on provider capabilities
@@ -288,47 +313,65 @@ func (r *Runner) runTest(ctx context.Context, test TestSpec, capabilities []stri
 	result := TestResult{
 		Name:         test.Name,
 		Capabilities: capabilities,
-		Steps:        make([]StepResult, 0, len(test.Steps)),
+		Steps:        make([]StepResult, 0, len(test.Setup)+len(test.Steps)),
 	}
 
-	// Parse test timeout
-	timeout := 5 * time.Minute
-	if test.Timeout != "" {
-		if d, err := time.ParseDuration(test.Timeout); err == nil {
-			timeout = d
-		}
-	}
-
-	testCtx, cancel := context.WithTimeout(ctx, timeout)
+	testCtx, cancel := context.WithTimeout(ctx, testTimeout(test))
 	defer cancel()
 
-	// Execute test steps
-	for _, step := range test.Steps {
-		stepResult := r.runStep(testCtx, step)
-		result.Steps = append(result.Steps, stepResult)
-
-		if stepResult.Status == "failed" && !step.Optional {
-			result.Status = "failed"
-			result.Error = stepResult.Error
-			break
-		}
+	// Execute setup and test steps
+	s := &session{}
+	steps, err := r.runSteps(testCtx, s, test.Setup)
+	if err == nil {
+		var more []StepResult
+		more, err = r.runSteps(testCtx, s, test.Steps)
+		steps = append(steps, more...)
 	}
+	result.Steps = steps
 
-	// If all steps passed, mark test as passed
-	if result.Status == "" {
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+	} else {
 		result.Status = "passed"
 	}
 
 	result.Duration = time.Since(startTime)
 
-	// Run cleanup steps
-	r.runCleanup(ctx, test.Cleanup)
+	// Run cleanup steps and remove whatever the test left behind
+	if err := r.finish(ctx, s, test.Cleanup); err != nil && result.Status == "passed" {
+		result.Status = "failed"
+		result.Error = err.Error()
+	}
 
 	return result
 }
 
+// testTimeout returns the test's timeout, five minutes when unset.
+func testTimeout(test TestSpec) time.Duration {
+	if d, err := time.ParseDuration(test.Timeout); err == nil && test.Timeout != "" {
+		return d
+	}
+	return 5 * time.Minute
+}
+
+// runSteps runs steps in order, stopping at the first one that fails and
+// is not optional.
+func (r *Runner) runSteps(ctx context.Context, s *session, steps []TestStep) ([]StepResult, error) {
+	results := make([]StepResult, 0, len(steps))
+	for _, step := range steps {
+		stepResult := r.runStep(ctx, s, step)
+		results = append(results, stepResult)
+
+		if stepResult.Status == "failed" && !step.Optional {
+			return results, fmt.Errorf("step %s: %s", step.Name, stepResult.Error)
+		}
+	}
+	return results, nil
+}
+
 // runStep executes a single test step
-func (r *Runner) runStep(ctx context.Context, step TestStep) StepResult {
+func (r *Runner) runStep(ctx context.Context, s *session, step TestStep) StepResult {
 	startTime := time.Now()
 
 	result := StepResult{
@@ -350,13 +393,13 @@ func (r *Runner) runStep(ctx context.Context, step TestStep) StepResult {
 	var err error
 	switch step.Type {
 	case "create":
-		err = r.createResource(stepCtx, step.Resource)
+		err = r.createResource(stepCtx, s, step.Resource)
 	case "update":
-		err = r.updateResource(stepCtx, step.Resource)
+		err = r.updateResource(stepCtx, s, step.Resource)
 	case "delete":
-		err = r.deleteResource(stepCtx, step.Resource)
+		err = r.deleteResource(stepCtx, s, step.Resource)
 	case "wait":
-		err = r.waitForCondition(stepCtx, step.WaitFor)
+		err = r.waitForCondition(stepCtx, s, step.WaitFor)
 	case "validate":
 		err = r.validateResource(stepCtx, step.Resource, step.Validate)
 	default:
@@ -374,33 +417,252 @@ func (r *Runner) runStep(ctx context.Context, step TestStep) StepResult {
 	return result
 }
 
-// runCleanup runs cleanup steps
-func (r *Runner) runCleanup(ctx context.Context, cleanup []TestStep) {
+// cleanupTimeout bounds the cleanup steps and the removal of leftover
+// objects after a test. Cleanup runs even when the test's own context has
+// expired, so it gets a budget of its own.
+const cleanupTimeout = 10 * time.Minute
+
+// pollInterval is how often wait steps and cleanup re-read an object.
+var pollInterval = 2 * time.Second
+
+// session is what one run of a test has touched: the object wait steps
+// watch, and every object the run created so it can be removed however
+// the run ends.
+type session struct {
+	last    *unstructured.Unstructured
+	created []*unstructured.Unstructured
+}
+
+// finish runs the cleanup steps, then deletes any object the session
+// created that is still present, newest first, and waits for each to be
+// gone so the next test can reuse its name. Cleanup step failures are
+// ignored; objects that could not be removed are returned as an error.
+func (r *Runner) finish(ctx context.Context, s *session, cleanup []TestStep) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
 	for _, step := range cleanup {
-		r.runStep(ctx, step)
+		r.runStep(ctx, s, step)
+	}
+
+	var failed []string
+	for i := len(s.created) - 1; i >= 0; i-- {
+		obj := s.created[i]
+		err := r.config.KubeClient.Delete(ctx, obj.DeepCopy(), client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err == nil || apierrors.IsNotFound(err) {
+			err = r.waitFor(ctx, obj, "Deleted")
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", describeObject(obj), err))
+		}
+	}
+	s.created = nil
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to clean up %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// object builds the unstructured object for a step resource, expanding
+// ${name} variables and defaulting the namespace.
+func (r *Runner) object(resource map[string]interface{}) (*unstructured.Unstructured, error) {
+	vars := map[string]string{"provider": r.config.Provider, "namespace": r.config.Namespace}
+	for k, v := range r.config.Vars {
+		vars[k] = v
+	}
+	if missing := referencedVars(resource, vars); len(missing) > 0 {
+		return nil, fmt.Errorf("undefined variables: %s", strings.Join(missing, ", "))
+	}
+
+	// A JSON round trip turns the YAML decoder's ints into the float64s
+	// unstructured objects expect.
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource: %w", err)
+	}
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("invalid resource: %w", err)
 	}
+	obj := &unstructured.Unstructured{Object: expandVars(content, vars).(map[string]interface{})}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(r.config.Namespace)
+	}
+	return obj, nil
 }
 
-// createResource creates a Kubernetes resource
-func (r *Runner) createResource(ctx context.Context, resource map[string]interface{}) error {
-	// Convert to unstructured object and create via client
-	// This is a simplified implementation
-	return fmt.Errorf("createResource not implemented")
+// referencedVars returns the sorted names of the ${name} variables used
+// in v that vars does not define.
+func referencedVars(v interface{}, vars map[string]string) []string {
+	missing := map[string]bool{}
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			os.Expand(v, func(name string) string {
+				if _, ok := vars[name]; !ok {
+					missing[name] = true
+				}
+				return ""
+			})
+		case map[string]interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(v)
+	return sortedKeys(missing)
 }
 
-// updateResource updates a Kubernetes resource
-func (r *Runner) updateResource(ctx context.Context, resource map[string]interface{}) error {
-	return fmt.Errorf("updateResource not implemented")
+func expandVars(v interface{}, vars map[string]string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return os.Expand(v, func(name string) string { return vars[name] })
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = expandVars(item, vars)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = expandVars(item, vars)
+		}
+	}
+	return v
 }
 
-// deleteResource deletes a Kubernetes resource
-func (r *Runner) deleteResource(ctx context.Context, resource map[string]interface{}) error {
-	return fmt.Errorf("deleteResource not implemented")
+func describeObject(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
 }
 
-// waitForCondition waits for a specific condition
-func (r *Runner) waitForCondition(ctx context.Context, condition *WaitCondition) error {
-	return fmt.Errorf("waitForCondition not implemented")
+// createResource creates a Kubernetes resource and records it for cleanup
+func (r *Runner) createResource(ctx context.Context, s *session, resource map[string]interface{}) error {
+	obj, err := r.object(resource)
+	if err != nil {
+		return err
+	}
+	if err := r.config.KubeClient.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to create %s: %w", describeObject(obj), err)
+	}
+	s.created = append(s.created, obj)
+	s.last = obj
+	return nil
+}
+
+// updateResource merge-patches a Kubernetes resource with the step's
+// resource, so a step only needs to carry the fields it changes
+func (r *Runner) updateResource(ctx context.Context, s *session, resource map[string]interface{}) error {
+	obj, err := r.object(resource)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %w", err)
+	}
+	if err := r.config.KubeClient.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", describeObject(obj), err)
+	}
+	s.last = obj
+	return nil
+}
+
+// deleteResource deletes a Kubernetes resource. Waiting for it to be gone
+// is left to a following "Deleted" wait step.
+func (r *Runner) deleteResource(ctx context.Context, s *session, resource map[string]interface{}) error {
+	obj, err := r.object(resource)
+	if err != nil {
+		return err
+	}
+	if err := r.config.KubeClient.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", describeObject(obj), err)
+	}
+	s.last = obj
+	return nil
+}
+
+// waitForCondition waits for the object the previous create, update or
+// delete step touched to reach a condition
+func (r *Runner) waitForCondition(ctx context.Context, s *session, condition *WaitCondition) error {
+	if s.last == nil {
+		return fmt.Errorf("no resource to wait for")
+	}
+	if condition.Timeout != "" {
+		if d, err := time.ParseDuration(condition.Timeout); err == nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		}
+	}
+	return r.waitFor(ctx, s.last, condition.Condition)
+}
+
+// waitFor polls obj until it meets condition or ctx is done.
+func (r *Runner) waitFor(ctx context.Context, obj *unstructured.Unstructured, condition string) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	key := client.ObjectKeyFromObject(obj)
+
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		lastErr = r.config.KubeClient.Get(ctx, key, current)
+		switch {
+		case apierrors.IsNotFound(lastErr):
+			return condition == "Deleted", nil
+		case lastErr != nil:
+			return false, nil
+		}
+		return conditionMet(current, condition), nil
+	})
+	if err != nil {
+		if lastErr != nil && !apierrors.IsNotFound(lastErr) {
+			return fmt.Errorf("timed out waiting for %s to be %s: %w", describeObject(obj), condition, lastErr)
+		}
+		return fmt.Errorf("timed out waiting for %s to be %s", describeObject(obj), condition)
+	}
+	return nil
+}
+
+// conditionMet reports whether an existing object meets a wait condition.
+// Ready accepts a Ready condition, a Ready phase or status.ready, since
+// virtrigaud kinds differ in which of these they report.
+func conditionMet(obj *unstructured.Unstructured, condition string) bool {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	powerState, _, _ := unstructured.NestedString(obj.Object, "status", "powerState")
+
+	switch condition {
+	case "Ready":
+		ready, _, _ := unstructured.NestedBool(obj.Object, "status", "ready")
+		return statusCondition(obj, "Ready") || phase == "Ready" || ready
+	case "Available":
+		return statusCondition(obj, "Available")
+	case "Running":
+		return powerState == "On"
+	case "Stopped":
+		return powerState == "Off"
+	case "Failed":
+		return phase == "Failed"
+	case "Provisioned":
+		id, _, _ := unstructured.NestedString(obj.Object, "status", "id")
+		return id != ""
+	}
+	return false
+}
+
+// statusCondition reports whether obj has condition conditionType set to True.
+func statusCondition(obj *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]interface{})
+		if ok && c["type"] == conditionType {
+			return c["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
 }
 
 // validateResource validates a resource against criteria
@@ -474,6 +736,8 @@ func (r *Runner) generateMarkdownReport(results *Results) string {
 ## Summary
 
 - **Provider**: %s
+- **Provider Version**: %s
+- **Hypervisor**: %s
 - **Total Tests**: %d
 - **Passed**: %d
 - **Failed**: %d
@@ -485,7 +749,8 @@ func (r *Runner) generateMarkdownReport(results *Results) string {
 
 | Test Name | Status | Duration | Error |
 |-----------|--------|----------|-------|
-`, results.Provider, results.Total, results.Passed, results.Failed, results.Skipped,
+`, results.Provider, versionString(results.ProviderVersion, results.ProviderGitSHA),
+		hypervisorString(results.Hypervisor), results.Total, results.Passed, results.Failed, results.Skipped,
 		results.Duration, results.Timestamp.Format(time.RFC3339))
 
 	for _, test := range results.Tests {
@@ -526,3 +791,26 @@ func (r *Runner) printTestResult(result TestResult) {
 		fmt.Printf("   Error: %s\n", result.Error)
 	}
 }
+
+// versionString formats a version and git SHA, either of which may be unset.
+func versionString(version, gitSHA string) string {
+	if version == "" {
+		version = "unknown"
+	}
+	if gitSHA == "" {
+		return version
+	}
+	return fmt.Sprintf("%s (%s)", version, gitSHA)
+}
+
+// hypervisorString formats what the provider reported about its hypervisor.
+func hypervisorString(hv *infrav1beta1.HypervisorInfo) string {
+	if hv == nil || hv.Product == "" {
+		return "unknown"
+	}
+	out := strings.TrimSpace(hv.Product + " " + hv.Version)
+	if hv.Details != "" {
+		out += " (" + hv.Details + ")"
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// TestMarkdownReportIncludesVersions checks the report attributes results
+// to the provider build and hypervisor release.
+func TestMarkdownReportIncludesVersions(t *testing.T) {
+	r := NewRunner(Config{})
+
+	report := r.generateMarkdownReport(&Results{
+		Provider:        "pve",
+		ProviderVersion: "v0.4.0",
+		ProviderGitSHA:  "abc1234",
+		Hypervisor:      &infrav1beta1.HypervisorInfo{Product: "Proxmox VE", Version: "8.2.4", Details: "release 8.2"},
+	})
+	assert.Contains(t, report, "- **Provider Version**: v0.4.0 (abc1234)")
+	assert.Contains(t, report, "- **Hypervisor**: Proxmox VE 8.2.4 (release 8.2)")
+
+	report = r.generateMarkdownReport(&Results{Provider: "pve"})
+	assert.Contains(t, report, "- **Provider Version**: unknown")
+	assert.Contains(t, report, "- **Hypervisor**: unknown")
+}
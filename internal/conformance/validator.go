@@ -20,22 +20,95 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+)
+
+// Known keys at each level of a spec file. In strict mode any other key is
+// an error; otherwise unknown keys are ignored as before.
+var (
+	testFields       = []string{"name", "description", "requiredCapabilities", "setup", "steps", "cleanup", "timeout", "labels"}
+	stepFields       = []string{"name", "type", "resource", "validate", "waitFor", "timeout", "optional", "description"}
+	waitFields       = []string{"condition", "timeout"}
+	validationFields = []string{"path", "value", "operator"}
 )
 
+// ValidationError is a single schema violation in a spec file.
+type ValidationError struct {
+	File   string
+	Line   int
+	Column int
+
+	// TestIndex is the position of the offending test in the file, or -1
+	// for errors that concern the whole file.
+	TestIndex int
+	// Test is the test's name, if it has one.
+	Test string
+	// Field is the path of the offending field within the test, such as
+	// "steps[2].waitFor.condition".
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", e.Line, e.Column)
+	}
+	switch {
+	case e.Test != "":
+		fmt.Fprintf(&b, ": test %q", e.Test)
+	case e.TestIndex >= 0:
+		fmt.Fprintf(&b, ": test #%d", e.TestIndex)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, ": %s", e.Field)
+	}
+	fmt.Fprintf(&b, ": %s", e.Message)
+	return b.String()
+}
+
+// ValidationErrors is every violation found in a spec file.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// InvalidTests returns the indexes of the tests with at least one error.
+func (e ValidationErrors) InvalidTests() map[int]bool {
+	invalid := map[int]bool{}
+	for _, err := range e {
+		if err.TestIndex >= 0 {
+			invalid[err.TestIndex] = true
+		}
+	}
+	return invalid
+}
+
 // Validator validates conformance test specifications
 type Validator struct {
-	validStepTypes  map[string]bool
-	validOperators  map[string]bool
-	validConditions map[string]bool
-	namePattern     *regexp.Regexp
+	validStepTypes    map[string]bool
+	validOperators    map[string]bool
+	validConditions   map[string]bool
+	validCapabilities map[string]bool
+	namePattern       *regexp.Regexp
+	strict            bool
 }
 
 // NewValidator creates a new test specification validator
 func NewValidator() *Validator {
-	return &Validator{
+	v := &Validator{
 		validStepTypes: map[string]bool{
 			"create":   true,
 			"update":   true,
@@ -63,241 +136,507 @@ func NewValidator() *Validator {
 			"Failed":      true,
 			"Provisioned": true,
 		},
-		namePattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`),
+		validCapabilities: map[string]bool{},
+		namePattern:       regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`),
 	}
+	for _, c := range capabilities.All() {
+		v.validCapabilities[string(c)] = true
+	}
+	return v
+}
+
+// SetStrict makes the validator reject keys the schema does not define,
+// which catches misspelled optional fields such as "waitfor".
+func (v *Validator) SetStrict(strict bool) *Validator {
+	v.strict = strict
+	return v
 }
 
-// ValidateFile validates a test specification file
+// ValidateFile validates a test specification file. It returns the parsed
+// tests and, if any of them is invalid, a ValidationErrors listing every
+// problem found. Tests from a file that failed validation must not be run.
 func (v *Validator) ValidateFile(filename string) ([]TestSpec, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return v.Validate(filename, data)
+}
 
-	var tests []TestSpec
-	if err := yaml.Unmarshal(data, &tests); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
-	}
-
-	for i, test := range tests {
-		if err := v.ValidateTest(test); err != nil {
-			return nil, fmt.Errorf("test %d (%s): %w", i, test.Name, err)
+// Validate validates the contents of a spec file; filename is only used
+// to label errors.
+func (v *Validator) Validate(filename string, data []byte) ([]TestSpec, error) {
+	c := &specCheck{v: v, file: filename, testIndex: -1}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		c.errs = append(c.errs, ValidationError{File: filename, TestIndex: -1, Message: fmt.Sprintf("invalid YAML: %v", err)})
+		return nil, c.errs
+	}
+	if len(doc.Content) == 0 {
+		c.errorf(&doc, "", "file contains no tests")
+		return nil, c.errs
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		c.errorf(root, "", "expected a list of tests, got %s", nodeKind(root))
+		return nil, c.errs
+	}
+	if len(root.Content) == 0 {
+		c.errorf(root, "", "file contains no tests")
+	}
+
+	tests := make([]TestSpec, len(root.Content))
+	names := map[string]int{}
+	for i, n := range root.Content {
+		c.testIndex, c.test = i, ""
+		c.checkTest(n)
+		if c.test != "" {
+			if first, dup := names[c.test]; dup {
+				c.errorf(n, "name", "duplicate test name (first used by test #%d)", first)
+			} else {
+				names[c.test] = i
+			}
+		}
+		if err := n.Decode(&tests[i]); err != nil && !c.testHasErrors(i) {
+			c.errorf(n, "", "%v", err)
 		}
 	}
 
+	if len(c.errs) > 0 {
+		return tests, c.errs
+	}
 	return tests, nil
 }
 
-// ValidateTest validates a single test specification
-func (v *Validator) ValidateTest(test TestSpec) error {
-	// Validate test name
-	if test.Name == "" {
-		return fmt.Errorf("test name is required")
+// specCheck walks the YAML node tree of one file, collecting errors with
+// the line and field they refer to.
+type specCheck struct {
+	v         *Validator
+	file      string
+	errs      ValidationErrors
+	testIndex int
+	test      string
+}
+
+func (c *specCheck) errorf(n *yaml.Node, field, format string, args ...interface{}) {
+	c.errs = append(c.errs, ValidationError{
+		File:      c.file,
+		Line:      n.Line,
+		Column:    n.Column,
+		TestIndex: c.testIndex,
+		Test:      c.test,
+		Field:     field,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *specCheck) testHasErrors(i int) bool {
+	for _, err := range c.errs {
+		if err.TestIndex == i {
+			return true
+		}
 	}
+	return false
+}
 
-	if !v.namePattern.MatchString(test.Name) {
-		return fmt.Errorf("test name must match pattern %s", v.namePattern.String())
+// fields returns the values of a mapping node by key. When the validator
+// is strict, keys not in known are reported; a nil known accepts any key.
+func (c *specCheck) fields(n *yaml.Node, path string, known []string) map[string]*yaml.Node {
+	out := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		if c.v.strict && known != nil && !contains(known, key.Value) {
+			c.errorf(key, join(path, key.Value), "unknown field%s", suggest(key.Value, known))
+		}
+		out[key.Value] = val
 	}
+	return out
+}
 
-	// Validate description
-	if test.Description == "" {
-		return fmt.Errorf("test description is required")
+func (c *specCheck) checkTest(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, "", "expected a test mapping, got %s", nodeKind(n))
+		return
 	}
+	f := c.fields(n, "", testFields)
 
-	// Validate timeout
-	if test.Timeout != "" {
-		if _, err := time.ParseDuration(test.Timeout); err != nil {
-			return fmt.Errorf("invalid timeout format: %w", err)
+	if name, ok := c.requiredString(n, f, "", "name"); ok {
+		c.test = name
+		if !c.v.namePattern.MatchString(name) {
+			c.errorf(f["name"], "name", "%q must be lowercase alphanumerics and '-' (pattern %s)", name, c.v.namePattern)
 		}
 	}
+	c.requiredString(n, f, "", "description")
+	if t, ok := f["timeout"]; ok {
+		c.checkDuration(t, "timeout")
+	}
+	if caps, ok := f["requiredCapabilities"]; ok {
+		c.checkCapabilities(caps)
+	}
+	if labels, ok := f["labels"]; ok {
+		c.checkLabels(labels)
+	}
 
-	// Validate required capabilities
-	for _, capability := range test.RequiredCapabilities {
-		if capability == "" {
-			return fmt.Errorf("empty capability in requiredCapabilities")
+	if setup, ok := f["setup"]; ok && !isNull(setup) {
+		if setup.Kind != yaml.SequenceNode {
+			c.errorf(setup, "setup", "expected a list of steps, got %s", nodeKind(setup))
+		} else {
+			c.checkSteps(setup, "setup")
 		}
 	}
 
-	// Validate steps
-	if len(test.Steps) == 0 {
-		return fmt.Errorf("at least one step is required")
+	steps, ok := f["steps"]
+	switch {
+	case !ok:
+		c.errorf(n, "steps", "required field is missing; a test needs at least one step")
+	case steps.Kind != yaml.SequenceNode:
+		c.errorf(steps, "steps", "expected a list of steps, got %s", nodeKind(steps))
+	case len(steps.Content) == 0:
+		c.errorf(steps, "steps", "a test needs at least one step")
+	default:
+		c.checkSteps(steps, "steps")
 	}
 
-	for i, step := range test.Steps {
-		if err := v.ValidateStep(step); err != nil {
-			return fmt.Errorf("step %d (%s): %w", i, step.Name, err)
+	if cleanup, ok := f["cleanup"]; ok && !isNull(cleanup) {
+		if cleanup.Kind != yaml.SequenceNode {
+			c.errorf(cleanup, "cleanup", "expected a list of steps, got %s", nodeKind(cleanup))
+		} else {
+			c.checkSteps(cleanup, "cleanup")
 		}
 	}
+}
 
-	// Validate cleanup steps
-	for i, step := range test.Cleanup {
-		if err := v.ValidateStep(step); err != nil {
-			return fmt.Errorf("cleanup step %d (%s): %w", i, step.Name, err)
+func (c *specCheck) checkCapabilities(n *yaml.Node) {
+	const path = "requiredCapabilities"
+	if n.Kind != yaml.SequenceNode {
+		c.errorf(n, path, "expected a list of capability names, got %s", nodeKind(n))
+		return
+	}
+	known := c.v.capabilityNames()
+	seen := map[string]bool{}
+	for i, item := range n.Content {
+		field := fmt.Sprintf("%s[%d]", path, i)
+		name, ok := scalar(item)
+		switch {
+		case !ok || name == "":
+			c.errorf(item, field, "expected a capability name")
+		case !c.v.validCapabilities[name]:
+			if hint := suggest(name, known); hint != "" {
+				c.errorf(item, field, "unknown capability %q%s", name, hint)
+			} else {
+				c.errorf(item, field, "unknown capability %q; known capabilities: %s", name, strings.Join(known, ", "))
+			}
+		case seen[name]:
+			c.errorf(item, field, "capability %q is listed twice", name)
 		}
+		seen[name] = true
 	}
-
-	return nil
 }
 
-// ValidateStep validates a single test step
-func (v *Validator) ValidateStep(step TestStep) error {
-	// Validate step name
-	if step.Name == "" {
-		return fmt.Errorf("step name is required")
+func (c *specCheck) checkLabels(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, "labels", "expected a mapping of label names to values, got %s", nodeKind(n))
+		return
 	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if _, ok := scalar(n.Content[i+1]); !ok {
+			c.errorf(n.Content[i+1], join("labels", n.Content[i].Value), "label values must be strings")
+		}
+	}
+}
 
-	// Validate step type
-	if step.Type == "" {
-		return fmt.Errorf("step type is required")
+// checkSteps validates each step of a steps or cleanup list, and that wait
+// steps follow something that can change state.
+func (c *specCheck) checkSteps(n *yaml.Node, path string) {
+	names := map[string]bool{}
+	mutated := false
+	for i, item := range n.Content {
+		field := fmt.Sprintf("%s[%d]", path, i)
+		name, stepType := c.checkStep(item, field)
+		if name != "" {
+			if names[name] {
+				c.errorf(item, field+".name", "duplicate step name %q", name)
+			}
+			names[name] = true
+		}
+		switch stepType {
+		case "create", "update", "delete":
+			mutated = true
+		case "wait":
+			if !mutated {
+				c.errorf(item, field+".type", "wait step has nothing to wait for; it must come after a create, update or delete step")
+			}
+		}
 	}
+}
 
-	if !v.validStepTypes[step.Type] {
-		return fmt.Errorf("invalid step type: %s", step.Type)
+// checkStep validates a single step and returns its name and type for the
+// list-level checks ("" when missing or invalid).
+func (c *specCheck) checkStep(n *yaml.Node, path string) (string, string) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, path, "expected a step mapping, got %s", nodeKind(n))
+		return "", ""
 	}
+	f := c.fields(n, path, stepFields)
 
-	// Validate timeout
-	if step.Timeout != "" {
-		if _, err := time.ParseDuration(step.Timeout); err != nil {
-			return fmt.Errorf("invalid timeout format: %w", err)
-		}
+	name, _ := c.requiredString(n, f, path, "name")
+	stepType, ok := c.requiredString(n, f, path, "type")
+	if ok && !c.v.validStepTypes[stepType] {
+		known := sortedKeys(c.v.validStepTypes)
+		c.errorf(f["type"], join(path, "type"), "unknown step type %q%s; valid types: %s",
+			stepType, suggest(stepType, known), strings.Join(known, ", "))
+		stepType = ""
+	}
+	if t, ok := f["timeout"]; ok {
+		c.checkDuration(t, join(path, "timeout"))
+	}
+	if opt, ok := f["optional"]; ok && opt.Tag != "!!bool" {
+		c.errorf(opt, join(path, "optional"), "expected true or false, got %q", opt.Value)
 	}
 
-	// Type-specific validation
-	switch step.Type {
+	switch stepType {
 	case "create", "update", "delete", "validate":
-		if step.Resource == nil {
-			return fmt.Errorf("resource is required for %s step", step.Type)
-		}
-		if err := v.ValidateResource(step.Resource); err != nil {
-			return fmt.Errorf("invalid resource: %w", err)
+		if res, ok := f["resource"]; !ok || isNull(res) {
+			c.errorf(n, join(path, "resource"), "required for %s steps", stepType)
+		} else {
+			c.checkResource(res, join(path, "resource"))
 		}
-
 	case "wait":
-		if step.WaitFor == nil {
-			return fmt.Errorf("waitFor is required for wait step")
-		}
-		if err := v.ValidateWaitCondition(*step.WaitFor); err != nil {
-			return fmt.Errorf("invalid wait condition: %w", err)
+		if wait, ok := f["waitFor"]; !ok || isNull(wait) {
+			c.errorf(n, join(path, "waitFor"), "required for wait steps")
+		} else {
+			c.checkWait(wait, join(path, "waitFor"))
 		}
 	}
 
-	// Validate validations
-	for i, validation := range step.Validate {
-		if err := v.ValidateValidation(validation); err != nil {
-			return fmt.Errorf("validation %d: %w", i, err)
+	validations, hasValidations := f["validate"]
+	if stepType == "validate" && (!hasValidations || len(validations.Content) == 0) {
+		c.errorf(n, join(path, "validate"), "a validate step needs at least one check")
+	}
+	if hasValidations && !isNull(validations) {
+		if validations.Kind != yaml.SequenceNode {
+			c.errorf(validations, join(path, "validate"), "expected a list of checks, got %s", nodeKind(validations))
+		} else {
+			for i, item := range validations.Content {
+				c.checkValidation(item, fmt.Sprintf("%s.validate[%d]", path, i))
+			}
 		}
 	}
 
-	return nil
+	return name, stepType
 }
 
-// ValidateResource validates a resource specification
-func (v *Validator) ValidateResource(resource map[string]interface{}) error {
-	// Check for required fields
-	apiVersion, ok := resource["apiVersion"]
-	if !ok {
-		return fmt.Errorf("apiVersion is required")
+// checkResource checks the fields the runner needs to address the object.
+// The rest of the manifest is free-form and left to the API server.
+func (c *specCheck) checkResource(n *yaml.Node, path string) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, path, "expected a Kubernetes object, got %s", nodeKind(n))
+		return
 	}
+	f := c.fields(n, path, nil)
+	c.requiredString(n, f, path, "apiVersion")
+	c.requiredString(n, f, path, "kind")
 
-	if _, ok := apiVersion.(string); !ok {
-		return fmt.Errorf("apiVersion must be a string")
+	meta, ok := f["metadata"]
+	if !ok || meta.Kind != yaml.MappingNode {
+		c.errorf(n, join(path, "metadata.name"), "required field is missing")
+		return
 	}
-
-	kind, ok := resource["kind"]
-	if !ok {
-		return fmt.Errorf("kind is required")
+	metaPath := join(path, "metadata")
+	mf := c.fields(meta, metaPath, nil)
+	if name, ok := c.requiredString(meta, mf, metaPath, "name"); ok && !c.v.namePattern.MatchString(name) {
+		c.errorf(mf["name"], join(metaPath, "name"), "%q must be lowercase alphanumerics and '-' (pattern %s)", name, c.v.namePattern)
 	}
+}
 
-	if _, ok := kind.(string); !ok {
-		return fmt.Errorf("kind must be a string")
+func (c *specCheck) checkWait(n *yaml.Node, path string) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, path, "expected a mapping, got %s", nodeKind(n))
+		return
 	}
-
-	// Validate metadata
-	metadata, ok := resource["metadata"]
-	if ok {
-		if metaMap, ok := metadata.(map[string]interface{}); ok {
-			if name, exists := metaMap["name"]; exists {
-				if nameStr, ok := name.(string); ok {
-					if !v.namePattern.MatchString(nameStr) {
-						return fmt.Errorf("resource name must match pattern %s", v.namePattern.String())
-					}
-				}
-			}
-		}
+	f := c.fields(n, path, waitFields)
+	if cond, ok := c.requiredString(n, f, path, "condition"); ok && !c.v.validConditions[cond] {
+		known := sortedKeys(c.v.validConditions)
+		c.errorf(f["condition"], join(path, "condition"), "unknown condition %q%s; valid conditions: %s",
+			cond, suggest(cond, known), strings.Join(known, ", "))
+	}
+	if t, ok := f["timeout"]; ok {
+		c.checkDuration(t, join(path, "timeout"))
 	}
-
-	return nil
 }
 
-// ValidateWaitCondition validates a wait condition
-func (v *Validator) ValidateWaitCondition(condition WaitCondition) error {
-	if condition.Condition == "" {
-		return fmt.Errorf("condition is required")
+func (c *specCheck) checkValidation(n *yaml.Node, path string) {
+	if n.Kind != yaml.MappingNode {
+		c.errorf(n, path, "expected a mapping, got %s", nodeKind(n))
+		return
 	}
+	f := c.fields(n, path, validationFields)
 
-	if !v.validConditions[condition.Condition] {
-		return fmt.Errorf("invalid condition: %s", condition.Condition)
+	if p, ok := c.requiredString(n, f, path, "path"); ok && p[0] != '.' && p[0] != '$' {
+		c.errorf(f["path"], join(path, "path"), "%q must be a JSONPath starting with '.' or '$'", p)
 	}
 
-	if condition.Timeout != "" {
-		if _, err := time.ParseDuration(condition.Timeout); err != nil {
-			return fmt.Errorf("invalid timeout format: %w", err)
+	operator := "eq"
+	if op, ok := f["operator"]; ok {
+		operator, _ = scalar(op)
+		if !c.v.validOperators[operator] {
+			known := sortedKeys(c.v.validOperators)
+			c.errorf(op, join(path, "operator"), "unknown operator %q%s; valid operators: %s",
+				operator, suggest(operator, known), strings.Join(known, ", "))
+			return
 		}
 	}
-
-	return nil
+	if val, ok := f["value"]; operator != "exists" && (!ok || isNull(val)) {
+		c.errorf(n, join(path, "value"), "required for operator %s", operator)
+	}
 }
 
-// ValidateValidation validates a validation specification
-func (v *Validator) ValidateValidation(validation Validation) error {
-	if validation.Path == "" {
-		return fmt.Errorf("path is required")
+// checkDuration accepts Go duration strings such as "30s" or "1h30m".
+func (c *specCheck) checkDuration(n *yaml.Node, path string) {
+	s, ok := scalar(n)
+	if !ok {
+		c.errorf(n, path, "expected a duration such as 30s, 5m or 1h30m")
+		return
 	}
-
-	// Validate JSONPath format (basic check)
-	if validation.Path[0] != '.' && validation.Path[0] != '$' {
-		return fmt.Errorf("path must start with '.' or '$'")
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		c.errorf(n, path, "invalid duration %q; use a value such as 30s, 5m or 1h30m", s)
+		return
 	}
-
-	if validation.Operator == "" {
-		validation.Operator = "eq" // default operator
+	if d <= 0 {
+		c.errorf(n, path, "duration %q must be positive", s)
 	}
+}
 
-	if !v.validOperators[validation.Operator] {
-		return fmt.Errorf("invalid operator: %s", validation.Operator)
+// requiredString returns the non-empty string value of key, reporting it
+// when missing, empty or not a scalar.
+func (c *specCheck) requiredString(parent *yaml.Node, f map[string]*yaml.Node, path, key string) (string, bool) {
+	n, ok := f[key]
+	if !ok || isNull(n) {
+		c.errorf(parent, join(path, key), "required field is missing")
+		return "", false
 	}
-
-	// Some operators don't require a value
-	if validation.Operator != "exists" && validation.Value == nil {
-		return fmt.Errorf("value is required for operator %s", validation.Operator)
+	s, ok := scalar(n)
+	if !ok {
+		c.errorf(n, join(path, key), "expected a string, got %s", nodeKind(n))
+		return "", false
 	}
+	if strings.TrimSpace(s) == "" {
+		c.errorf(n, join(path, key), "must not be empty")
+		return "", false
+	}
+	return s, true
+}
 
-	return nil
+func (v *Validator) capabilityNames() []string {
+	return sortedKeys(v.validCapabilities)
 }
 
 // GetValidStepTypes returns valid step types
 func (v *Validator) GetValidStepTypes() []string {
-	types := make([]string, 0, len(v.validStepTypes))
-	for stepType := range v.validStepTypes {
-		types = append(types, stepType)
-	}
-	return types
+	return sortedKeys(v.validStepTypes)
 }
 
 // GetValidOperators returns valid validation operators
 func (v *Validator) GetValidOperators() []string {
-	operators := make([]string, 0, len(v.validOperators))
-	for operator := range v.validOperators {
-		operators = append(operators, operator)
-	}
-	return operators
+	return sortedKeys(v.validOperators)
 }
 
 // GetValidConditions returns valid wait conditions
 func (v *Validator) GetValidConditions() []string {
-	conditions := make([]string, 0, len(v.validConditions))
-	for condition := range v.validConditions {
-		conditions = append(conditions, condition)
+	return sortedKeys(v.validConditions)
+}
+
+func scalar(n *yaml.Node) (string, bool) {
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return "", false
+	}
+	return n.Value, true
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func nodeKind(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.ScalarNode:
+		if isNull(n) {
+			return "null"
+		}
+		return fmt.Sprintf("%q", n.Value)
+	default:
+		return "an unsupported node"
+	}
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggest returns a "did you mean" hint for a misspelled name: an exact
+// match after dropping a "vm-" prefix and using '_' for '-' (the old spec
+// naming), otherwise the closest candidate within two edits.
+func suggest(name string, candidates []string) string {
+	legacy := strings.ReplaceAll(strings.TrimPrefix(name, "vm-"), "-", "_")
+	best, bestDist := "", 3
+	for _, cand := range candidates {
+		if cand == legacy {
+			return fmt.Sprintf(" (did you mean %q?)", cand)
+		}
+		if d := editDistance(strings.ToLower(name), strings.ToLower(cand)); d < bestDist {
+			best, bestDist = cand, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
 	}
-	return conditions
+	return prev[len(b)]
 }
@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validSpec = `
+- name: vm-create-delete
+  description: create then delete
+  requiredCapabilities: [create, delete]
+  timeout: 10m
+  steps:
+    - name: create-vm
+      type: create
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: test-vm
+    - name: wait-ready
+      type: wait
+      waitFor:
+        condition: Ready
+    - name: check
+      type: validate
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: test-vm
+      validate:
+        - path: .status.id
+          operator: exists
+`
+
+func validationErrors(t *testing.T, err error) ValidationErrors {
+	t.Helper()
+	var verrs ValidationErrors
+	require.True(t, errors.As(err, &verrs), "expected ValidationErrors, got %v", err)
+	return verrs
+}
+
+func TestValidator_ValidSpec(t *testing.T) {
+	tests, err := NewValidator().SetStrict(true).Validate("spec.yaml", []byte(validSpec))
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	assert.Equal(t, "vm-create-delete", tests[0].Name)
+	assert.Equal(t, []string{"create", "delete"}, tests[0].RequiredCapabilities)
+	assert.Equal(t, "test-vm", tests[0].Steps[0].Resource["metadata"].(map[string]interface{})["name"])
+}
+
+// TestValidator_Errors checks that each class of mistake is reported with
+// the field path and line it occurs on.
+func TestValidator_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		field   string
+		line    int
+		message string
+	}{
+		{
+			name: "misspelled capability",
+			spec: `
+- name: t
+  description: d
+  requiredCapabilities: [snapshotss]
+  steps: [{name: c, type: delete, resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}}]
+`,
+			field: "requiredCapabilities[0]", line: 4, message: `did you mean "snapshots"?`,
+		},
+		{
+			name: "legacy capability name",
+			spec: `
+- name: t
+  description: d
+  requiredCapabilities: [vm-console-output]
+  steps: [{name: c, type: delete, resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}}]
+`,
+			field: "requiredCapabilities[0]", line: 4, message: `did you mean "console_output"?`,
+		},
+		{
+			name: "missing description",
+			spec: `
+- name: t
+  steps: [{name: c, type: delete, resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}}]
+`,
+			field: "description", line: 2, message: "required field is missing",
+		},
+		{
+			name: "bad timeout",
+			spec: `
+- name: t
+  description: d
+  steps:
+    - name: c
+      type: delete
+      timeout: 5 minutes
+      resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}
+`,
+			field: "steps[0].timeout", line: 7, message: `invalid duration "5 minutes"`,
+		},
+		{
+			name: "step missing required parameter",
+			spec: `
+- name: t
+  description: d
+  steps:
+    - name: c
+      type: create
+`,
+			field: "steps[0].resource", line: 5, message: "required for create steps",
+		},
+		{
+			name: "wait before any change",
+			spec: `
+- name: t
+  description: d
+  steps:
+    - name: w
+      type: wait
+      waitFor: {condition: Ready}
+`,
+			field: "steps[0].type", line: 5, message: "must come after a create, update or delete step",
+		},
+		{
+			name: "unknown operator",
+			spec: `
+- name: t
+  description: d
+  steps:
+    - name: v
+      type: validate
+      resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}
+      validate:
+        - {path: .data, operator: equals, value: x}
+`,
+			field: "steps[0].validate[0].operator", line: 9, message: `unknown operator "equals"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidator().Validate("spec.yaml", []byte(tt.spec))
+			verrs := validationErrors(t, err)
+			require.Len(t, verrs, 1, "errors: %v", verrs)
+			assert.Equal(t, tt.field, verrs[0].Field)
+			assert.Equal(t, tt.line, verrs[0].Line)
+			assert.Contains(t, verrs[0].Message, tt.message)
+			assert.Equal(t, map[int]bool{0: true}, verrs.InvalidTests())
+		})
+	}
+}
+
+func TestValidator_StrictRejectsUnknownFields(t *testing.T) {
+	spec := `
+- name: t
+  description: d
+  steps:
+    - name: c
+      type: delete
+      resource: {apiVersion: v1, kind: ConfigMap, metadata: {name: x}}
+      timout: 30s
+`
+	_, err := NewValidator().Validate("spec.yaml", []byte(spec))
+	require.NoError(t, err, "unknown fields are tolerated without --strict")
+
+	_, err = NewValidator().SetStrict(true).Validate("spec.yaml", []byte(spec))
+	verrs := validationErrors(t, err)
+	require.Len(t, verrs, 1)
+	assert.Equal(t, "steps[0].timout", verrs[0].Field)
+	assert.Contains(t, verrs[0].Error(), `spec.yaml:8:7: test "t": steps[0].timout: unknown field (did you mean "timeout"?)`)
+}
+
+func TestValidator_FileLevelErrors(t *testing.T) {
+	for name, spec := range map[string]string{
+		"empty":      "",
+		"not a list": "name: t\n",
+		"invalid":    "- name: [\n",
+		"empty list": "[]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewValidator().Validate("spec.yaml", []byte(spec))
+			verrs := validationErrors(t, err)
+			assert.Equal(t, -1, verrs[0].TestIndex)
+			assert.Empty(t, verrs.InvalidTests())
+		})
+	}
+}
+
+// TestValidator_ShippedSpecs keeps the specs in the repo valid under the
+// strict schema, since the runner refuses to load an invalid file.
+func TestValidator_ShippedSpecs(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "test", "conformance", "specs", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		_, err := NewValidator().SetStrict(true).ValidateFile(f)
+		assert.NoError(t, err, f)
+	}
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package consoleproxy lets users reach a VM's graphical console through
+// the manager, authenticated as themselves against Kubernetes, without
+// network access to the hypervisor or its credentials.
+//
+// A session is issued to a caller the HTTP API has authorized and is named
+// by a random token that is good for one connection within TTL. When the
+// browser connects with it, the proxy asks the VM's provider for a console
+// ticket, connects to the hypervisor, and carries bytes between the two
+// over WebSockets. For VNC consoles the proxy answers the hypervisor's VNC
+// authentication itself and offers the browser none, so the password
+// stays in the manager. Issuing, opening and closing a session are
+// recorded in the audit log.
+//
+// Sessions are held in memory, so only the manager that issued a session
+// can serve it. The proxy is meant for a single manager replica.
+package consoleproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // vSphere reports SHA-1 certificate thumbprints.
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// DefaultTTL is how long an issued session may wait to be connected.
+const DefaultTTL = 30 * time.Second
+
+// dialTimeout bounds connecting to the hypervisor.
+const dialTimeout = 15 * time.Second
+
+// ErrUnavailable is returned by Issue when the VM has no console to offer
+// right now; the message says why.
+var ErrUnavailable = errors.New("console unavailable")
+
+// Resolver returns the provider client serving a VM. The manager's
+// remote.Resolver is one.
+type Resolver interface {
+	GetProviderForVM(ctx context.Context, provider *infrav1beta1.Provider, vmUID types.UID) (contracts.Provider, error)
+}
+
+// Proxy issues console sessions and serves them.
+type Proxy struct {
+	// Reader reads VirtualMachines and Providers; the manager's cache.
+	Reader client.Reader
+	// Resolver finds the provider of a session's VM.
+	Resolver Resolver
+	// TTL is how long an issued session may wait to be connected;
+	// DefaultTTL when zero.
+	TTL time.Duration
+
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// New returns a Proxy reading objects with reader.
+func New(reader client.Reader, resolver Resolver) *Proxy {
+	return &Proxy{Reader: reader, Resolver: resolver, now: time.Now, sessions: map[string]*Session{}}
+}
+
+// Session is an issued console session.
+type Session struct {
+	// Token names the session in the URL the browser connects to.
+	Token string
+	// Protocol is the console's protocol, contracts.ConsoleProtocolVNC or
+	// contracts.ConsoleProtocolWebMKS, which decides the browser client.
+	Protocol string
+	// ExpiresAt is when the session can no longer be connected.
+	ExpiresAt time.Time
+	// VM is the VirtualMachine whose console this is.
+	VM types.NamespacedName
+
+	vmUID    types.UID
+	vmID     string
+	provider types.NamespacedName
+	user     string
+}
+
+// Issue issues a session for the console of vm to caller. It fails with
+// ErrUnavailable when the VM is not running or its provider has no
+// console proxy.
+func (p *Proxy) Issue(ctx context.Context, vm *infrav1beta1.VirtualMachine, caller user.Info, remoteAddr string) (*Session, error) {
+	if vm.Status.ID == "" {
+		return nil, fmt.Errorf("%w: the VM has not been created yet", ErrUnavailable)
+	}
+	if vm.Status.PowerState != infrav1beta1.PowerStateOn {
+		return nil, fmt.Errorf("%w: the VM is not running", ErrUnavailable)
+	}
+	providerKey := types.NamespacedName{Namespace: vm.Spec.ProviderRef.Namespace, Name: vm.Spec.ProviderRef.Name}
+	if providerKey.Namespace == "" {
+		providerKey.Namespace = vm.Namespace
+	}
+	provider := &infrav1beta1.Provider{}
+	if err := p.Reader.Get(ctx, providerKey, provider); err != nil {
+		return nil, fmt.Errorf("failed to get Provider %s: %w", providerKey, err)
+	}
+	if caps := provider.Status.ReportedCapabilities; caps == nil || !caps.SupportsConsoleProxy {
+		return nil, fmt.Errorf("%w: Provider %s does not offer a console proxy", ErrUnavailable, providerKey)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	ttl := p.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	s := &Session{
+		Token:     token,
+		Protocol:  protocolFor(provider.Spec.Type),
+		ExpiresAt: p.now().Add(ttl),
+		VM:        types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name},
+		vmUID:     vm.UID,
+		vmID:      vm.Status.ID,
+		provider:  providerKey,
+		user:      caller.GetName(),
+	}
+
+	p.mu.Lock()
+	for t, other := range p.sessions {
+		if !p.now().Before(other.ExpiresAt) {
+			delete(p.sessions, t)
+		}
+	}
+	p.sessions[token] = s
+	p.mu.Unlock()
+
+	audit.Record("Console session issued", "user", s.user, "groups", caller.GetGroups(), "vm", s.VM.String(),
+		"provider", s.provider.String(), "remoteAddr", remoteAddr, "expiresAt", s.ExpiresAt)
+	return s, nil
+}
+
+// Take returns the session named by token and forgets it, so that it is
+// connected at most once. It returns false for an unknown or expired
+// token.
+func (p *Proxy) Take(token string) (*Session, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.sessions[token]
+	if !ok {
+		return nil, false
+	}
+	delete(p.sessions, token)
+	return s, p.now().Before(s.ExpiresAt)
+}
+
+// protocolFor returns the console protocol of a provider type: WebMKS for
+// vSphere, VNC for the rest.
+func protocolFor(t infrav1beta1.ProviderType) string {
+	if t == infrav1beta1.ProviderTypeVSphere {
+		return contracts.ConsoleProtocolWebMKS
+	}
+	return contracts.ConsoleProtocolVNC
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate a session token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Upstream is a session's connection to the hypervisor.
+type Upstream struct {
+	conn io.ReadWriteCloser
+	// protocol is the WebSocket subprotocol the hypervisor chose, offered
+	// back to the browser.
+	protocol string
+	ticket   contracts.ConsoleTicket
+}
+
+// Dial connects session s to its VM's console: directly to the ticket's
+// URL or, for a ticket without one, through the provider's relay. VNC
+// authentication is completed before Dial returns. The connection lasts
+// until ctx is done or Serve returns. offered are the WebSocket
+// subprotocols the browser offers, offered to the hypervisor in turn.
+func (p *Proxy) Dial(ctx context.Context, s *Session, offered []string) (*Upstream, error) {
+	up, err := p.dial(ctx, s, offered)
+	if err != nil {
+		audit.Record("Console session failed", "user", s.user, "vm", s.VM.String(), "provider", s.provider.String(),
+			"error", err.Error())
+	}
+	return up, err
+}
+
+func (p *Proxy) dial(ctx context.Context, s *Session, offered []string) (*Upstream, error) {
+	provider := &infrav1beta1.Provider{}
+	if err := p.Reader.Get(ctx, s.provider, provider); err != nil {
+		return nil, fmt.Errorf("failed to get Provider %s: %w", s.provider, err)
+	}
+	client, err := p.Resolver.GetProviderForVM(ctx, provider, s.vmUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Provider %s: %w", s.provider, err)
+	}
+	issuer, ok := client.(contracts.ConsoleTicketIssuer)
+	if !ok {
+		return nil, fmt.Errorf("%w: Provider %s does not offer a console proxy", ErrUnavailable, s.provider)
+	}
+	ticket, err := issuer.ConsoleTicket(ctx, s.vmID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a console ticket: %w", err)
+	}
+
+	up := &Upstream{ticket: ticket}
+	if ticket.URL == "" {
+		relayer, ok := client.(contracts.ConsoleRelayer)
+		if !ok {
+			return nil, fmt.Errorf("Provider %s gave a console ticket without a URL but cannot relay", s.provider)
+		}
+		if up.conn, err = relayer.ConsoleRelay(ctx, s.vmID); err != nil {
+			return nil, fmt.Errorf("failed to open the console relay: %w", err)
+		}
+		// A relay carries bare RFB, so the browser may pick any subprotocol.
+		if len(offered) > 0 {
+			up.protocol = offered[0]
+		}
+	} else {
+		ws, err := dialWebSocket(ctx, ticket, offered)
+		if err != nil {
+			return nil, err
+		}
+		up.conn = ws
+		if len(ws.Config().Protocol) > 0 {
+			up.protocol = ws.Config().Protocol[0]
+		}
+	}
+
+	if ticket.Protocol == contracts.ConsoleProtocolVNC {
+		if err := rfbAuthenticate(up.conn, ticket.Password); err != nil {
+			_ = up.conn.Close()
+			return nil, err
+		}
+	}
+	return up, nil
+}
+
+// OfferedProtocols returns the WebSocket subprotocols r offers, to pass
+// to Dial.
+func OfferedProtocols(r *http.Request) []string {
+	var out []string
+	for _, p := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// dialWebSocket connects to a ticket's URL.
+func dialWebSocket(ctx context.Context, ticket contracts.ConsoleTicket, offered []string) (*websocket.Conn, error) {
+	target, err := url.Parse(ticket.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid console URL: %w", err)
+	}
+	origin := url.URL{Scheme: "https", Host: target.Host}
+	if target.Scheme == "ws" {
+		origin.Scheme = "http"
+	}
+	config, err := websocket.NewConfig(ticket.URL, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid console URL: %w", err)
+	}
+	config.Protocol = offered
+	if len(config.Protocol) == 0 {
+		config.Protocol = []string{"binary"}
+	}
+	for k, v := range ticket.Headers {
+		config.Header.Set(k, v)
+	}
+	if config.TlsConfig, err = ticketTLSConfig(ticket, target.Hostname()); err != nil {
+		return nil, err
+	}
+	config.Dialer = &net.Dialer{Timeout: dialTimeout}
+
+	ws, err := config.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the console: %w", err)
+	}
+	ws.PayloadType = websocket.BinaryFrame
+	return ws, nil
+}
+
+// ticketTLSConfig returns the TLS configuration verifying a ticket's URL.
+func ticketTLSConfig(ticket contracts.ConsoleTicket, serverName string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	switch {
+	case ticket.CertificateFingerprint != "":
+		want, err := hex.DecodeString(strings.ReplaceAll(ticket.CertificateFingerprint, ":", ""))
+		if err != nil || (len(want) != sha1.Size && len(want) != sha256.Size) {
+			return nil, fmt.Errorf("invalid certificate fingerprint %q", ticket.CertificateFingerprint)
+		}
+		// The certificate is pinned rather than verified against roots.
+		config.InsecureSkipVerify = true //nolint:gosec // the fingerprint is checked below.
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("the console server sent no certificate")
+			}
+			if !slices.Equal(fingerprint(cs.PeerCertificates[0], len(want)), want) {
+				return fmt.Errorf("the console server's certificate does not match fingerprint %s", ticket.CertificateFingerprint)
+			}
+			return nil
+		}
+	case ticket.InsecureSkipVerify:
+		config.InsecureSkipVerify = true //nolint:gosec // the provider is configured so.
+	case len(ticket.CABundle) > 0:
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ticket.CABundle) {
+			return nil, fmt.Errorf("the console ticket's CA bundle holds no certificate")
+		}
+	}
+	return config, nil
+}
+
+// fingerprint returns the SHA-1 or SHA-256 digest of cert, by size.
+func fingerprint(cert *x509.Certificate, size int) []byte {
+	if size == sha1.Size {
+		sum := sha1.Sum(cert.Raw) //nolint:gosec // see the import.
+		return sum[:]
+	}
+	sum := sha256.Sum256(cert.Raw)
+	return sum[:]
+}
+
+// Serve upgrades the browser's request to a WebSocket and carries the
+// console between it and up until either side closes. It closes up.
+func (p *Proxy) Serve(w http.ResponseWriter, r *http.Request, s *Session, up *Upstream) {
+	log := logf.FromContext(r.Context()).WithValues("vm", s.VM.String(), "user", s.user)
+	defer func() { _ = up.conn.Close() }()
+
+	server := websocket.Server{
+		// The token authenticates the connection, so any origin may use it.
+		Handshake: func(config *websocket.Config, _ *http.Request) error {
+			if slices.Contains(config.Protocol, up.protocol) {
+				config.Protocol = []string{up.protocol}
+			} else {
+				config.Protocol = nil
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			ws.PayloadType = websocket.BinaryFrame
+			opened := p.now()
+			audit.Record("Console session opened", "user", s.user, "vm", s.VM.String(), "provider", s.provider.String(),
+				"protocol", up.ticket.Protocol, "remoteAddr", r.RemoteAddr)
+
+			var in, out atomic.Int64
+			err := p.pipe(ws, up, &in, &out)
+			if err != nil {
+				log.Info("Console session ended with an error", "error", err.Error())
+			}
+			audit.Record("Console session closed", "user", s.user, "vm", s.VM.String(), "provider", s.provider.String(),
+				"remoteAddr", r.RemoteAddr, "duration", p.now().Sub(opened).String(),
+				"bytesFromClient", in.Load(), "bytesToClient", out.Load())
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// pipe carries bytes between the browser and the hypervisor, counting
+// them, until either side closes. For VNC the browser is first offered no
+// authentication.
+func (p *Proxy) pipe(ws *websocket.Conn, up *Upstream, in, out *atomic.Int64) error {
+	if up.ticket.Protocol == contracts.ConsoleProtocolVNC {
+		if err := rfbAcceptNone(ws); err != nil {
+			return err
+		}
+	}
+	done := make(chan error, 2)
+	go func() {
+		n, err := io.Copy(up.conn, ws)
+		in.Add(n)
+		done <- err
+	}()
+	go func() {
+		n, err := io.Copy(ws, up.conn)
+		out.Add(n)
+		done <- err
+	}()
+	err := <-done
+	// Closing both ends stops the other copy.
+	_ = ws.Close()
+	_ = up.conn.Close()
+	<-done
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consoleproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// relayProvider issues VNC tickets without a URL and relays them to a fake
+// VNC server asking for password.
+type relayProvider struct {
+	contracts.Provider
+	password string
+	t        *testing.T
+	gotID    string
+}
+
+func (p *relayProvider) ConsoleTicket(_ context.Context, id string) (contracts.ConsoleTicket, error) {
+	p.gotID = id
+	return contracts.ConsoleTicket{Protocol: contracts.ConsoleProtocolVNC, Password: p.password}, nil
+}
+
+func (p *relayProvider) ConsoleRelay(_ context.Context, _ string) (io.ReadWriteCloser, error) {
+	client, server := net.Pipe()
+	go fakeVNCServer(p.t, server, p.password)
+	return client, nil
+}
+
+type staticResolver struct{ provider contracts.Provider }
+
+func (r staticResolver) GetProviderForVM(context.Context, *infrav1beta1.Provider, types.UID) (contracts.Provider, error) {
+	return r.provider, nil
+}
+
+func testObjects(t *testing.T, consoleProxy bool) (*infrav1beta1.VirtualMachine, *fake.ClientBuilder) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(s))
+	vm := &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-1"},
+		Spec:       infrav1beta1.VirtualMachineSpec{ProviderRef: infrav1beta1.ObjectRef{Name: "kvm"}},
+		Status:     infrav1beta1.VirtualMachineStatus{ID: "web-1-domain", PowerState: infrav1beta1.PowerStateOn},
+	}
+	provider := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "kvm", Namespace: "default"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeLibvirt},
+		Status: infrav1beta1.ProviderStatus{
+			ReportedCapabilities: &infrav1beta1.ReportedCapabilities{SupportsConsoleProxy: consoleProxy},
+		},
+	}
+	return vm, fake.NewClientBuilder().WithScheme(s).WithObjects(vm, provider)
+}
+
+func TestIssue(t *testing.T) {
+	vm, builder := testObjects(t, true)
+	p := New(builder.Build(), staticResolver{})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+	caller := &user.DefaultInfo{Name: "alice"}
+
+	s, err := p.Issue(context.Background(), vm, caller, "192.0.2.1:4000")
+	require.NoError(t, err)
+	assert.Equal(t, contracts.ConsoleProtocolVNC, s.Protocol)
+	assert.Equal(t, now.Add(DefaultTTL), s.ExpiresAt)
+	assert.Len(t, s.Token, 43)
+
+	taken, ok := p.Take(s.Token)
+	require.True(t, ok)
+	assert.Equal(t, s, taken)
+	_, ok = p.Take(s.Token)
+	assert.False(t, ok, "a session is connected at most once")
+
+	s, err = p.Issue(context.Background(), vm, caller, "192.0.2.1:4000")
+	require.NoError(t, err)
+	now = now.Add(DefaultTTL)
+	_, ok = p.Take(s.Token)
+	assert.False(t, ok, "an expired session cannot be connected")
+
+	stopped := vm.DeepCopy()
+	stopped.Status.PowerState = infrav1beta1.PowerStateOff
+	_, err = p.Issue(context.Background(), stopped, caller, "")
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	vm, builder = testObjects(t, false)
+	_, err = New(builder.Build(), staticResolver{}).Issue(context.Background(), vm, caller, "")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorContains(t, err, "does not offer a console proxy")
+}
+
+// TestSession connects a browser-side WebSocket through the proxy to a
+// relayed VNC server that asks for a password the browser never sees.
+func TestSession(t *testing.T) {
+	vm, builder := testObjects(t, true)
+	provider := &relayProvider{password: "PVEVNC:ticket", t: t}
+	p := New(builder.Build(), staticResolver{provider: provider})
+	s, err := p.Issue(context.Background(), vm, &user.DefaultInfo{Name: "alice"}, "")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, ok := p.Take(strings.TrimPrefix(r.URL.Path, "/"))
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		up, err := p.Dial(r.Context(), session, OfferedProtocols(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		p.Serve(w, r, session, up)
+	}))
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/"+s.Token, "binary", "http://localhost/")
+	require.NoError(t, err)
+	defer ws.Close() //nolint:errcheck
+	assert.Equal(t, "web-1-domain", provider.gotID)
+
+	require.NoError(t, rfbAuthenticate(ws, ""), "the browser is offered no authentication")
+	_, err = ws.Write([]byte("ClientInit"))
+	require.NoError(t, err)
+	echo := make([]byte, len("ClientInit"))
+	_, err = io.ReadFull(ws, echo)
+	require.NoError(t, err)
+	assert.Equal(t, "ClientInit", string(echo))
+
+	_, err = websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/"+s.Token, "binary", "http://localhost/")
+	assert.Error(t, err, "the token is single-use")
+}
+
+// TestTicketTLSConfig verifies a fingerprint pins the console server's
+// certificate.
+func TestTicketTLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	sum := sha256.Sum256(srv.Certificate().Raw)
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	dial := func(ticket contracts.ConsoleTicket) error {
+		config, err := ticketTLSConfig(ticket, "127.0.0.1")
+		if err != nil {
+			return err
+		}
+		conn, err := tls.Dial("tcp", addr, config)
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, dial(contracts.ConsoleTicket{CertificateFingerprint: hex.EncodeToString(sum[:])}))
+	assert.ErrorContains(t, dial(contracts.ConsoleTicket{CertificateFingerprint: strings.Repeat("00:", 31) + "00"}), "does not match")
+	assert.Error(t, dial(contracts.ConsoleTicket{}), "the test server's certificate is not trusted by the system roots")
+	_, err := ticketTLSConfig(contracts.ConsoleTicket{CertificateFingerprint: "zz"}, "")
+	assert.ErrorContains(t, err, "invalid certificate fingerprint")
+}
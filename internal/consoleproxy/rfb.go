@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consoleproxy
+
+import (
+	"crypto/des" //nolint:gosec // VNC authentication is defined in terms of DES.
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// rfbVersion is the only RFB version spoken on either side, which every
+// supported hypervisor and noVNC speak.
+const rfbVersion = "RFB 003.008\n"
+
+// RFB security types.
+const (
+	rfbSecurityNone = 1
+	rfbSecurityVNC  = 2
+)
+
+// rfbAuthenticate runs the RFB security handshake with a VNC server as a
+// client, answering VNC authentication with password, and leaves conn at
+// the ClientInit message. Without a password the server must offer None.
+func rfbAuthenticate(conn io.ReadWriter, password string) error {
+	version := make([]byte, len(rfbVersion))
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return fmt.Errorf("read the server's RFB version: %w", err)
+	}
+	if string(version) != rfbVersion {
+		return fmt.Errorf("the server speaks %q, not RFB 3.8", version)
+	}
+	if _, err := io.WriteString(conn, rfbVersion); err != nil {
+		return err
+	}
+
+	var n [1]byte
+	if _, err := io.ReadFull(conn, n[:]); err != nil {
+		return fmt.Errorf("read the server's security types: %w", err)
+	}
+	if n[0] == 0 {
+		return fmt.Errorf("the server refused the connection: %s", rfbReason(conn))
+	}
+	offered := make([]byte, n[0])
+	if _, err := io.ReadFull(conn, offered); err != nil {
+		return fmt.Errorf("read the server's security types: %w", err)
+	}
+
+	want := byte(rfbSecurityNone)
+	if password != "" {
+		want = rfbSecurityVNC
+	}
+	found := false
+	for _, t := range offered {
+		found = found || t == want
+	}
+	if !found {
+		return fmt.Errorf("the server does not offer security type %d (offers %v)", want, offered)
+	}
+	if _, err := conn.Write([]byte{want}); err != nil {
+		return err
+	}
+
+	if want == rfbSecurityVNC {
+		challenge := make([]byte, 16)
+		if _, err := io.ReadFull(conn, challenge); err != nil {
+			return fmt.Errorf("read the VNC authentication challenge: %w", err)
+		}
+		if _, err := conn.Write(vncAuthResponse(password, challenge)); err != nil {
+			return err
+		}
+	}
+
+	var result uint32
+	if err := binary.Read(conn, binary.BigEndian, &result); err != nil {
+		return fmt.Errorf("read the security result: %w", err)
+	}
+	if result != 0 {
+		return fmt.Errorf("VNC authentication failed: %s", rfbReason(conn))
+	}
+	return nil
+}
+
+// rfbAcceptNone runs the RFB security handshake with a VNC client as a
+// server, offering only None, and leaves conn at the ClientInit message.
+// The client thus never sees the hypervisor's credentials.
+func rfbAcceptNone(conn io.ReadWriter) error {
+	if _, err := io.WriteString(conn, rfbVersion); err != nil {
+		return err
+	}
+	version := make([]byte, len(rfbVersion))
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return fmt.Errorf("read the client's RFB version: %w", err)
+	}
+	if string(version) != rfbVersion {
+		return fmt.Errorf("the client speaks %q, not RFB 3.8", version)
+	}
+	if _, err := conn.Write([]byte{1, rfbSecurityNone}); err != nil {
+		return err
+	}
+	var chosen [1]byte
+	if _, err := io.ReadFull(conn, chosen[:]); err != nil {
+		return fmt.Errorf("read the client's security type: %w", err)
+	}
+	if chosen[0] != rfbSecurityNone {
+		return fmt.Errorf("the client chose security type %d, which was not offered", chosen[0])
+	}
+	return binary.Write(conn, binary.BigEndian, uint32(0))
+}
+
+// rfbReason reads the reason string that follows a failure, as far as it
+// can.
+func rfbReason(r io.Reader) string {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil || n > 1<<10 {
+		return "no reason given"
+	}
+	reason := make([]byte, n)
+	if _, err := io.ReadFull(r, reason); err != nil {
+		return "no reason given"
+	}
+	return string(reason)
+}
+
+// vncAuthResponse encrypts the 16-byte challenge with DES, keyed with the
+// first 8 bytes of password, each bit-reversed as VNC does.
+func vncAuthResponse(password string, challenge []byte) []byte {
+	var key [8]byte
+	copy(key[:], password)
+	for i, b := range key {
+		var r byte
+		for bit := 0; bit < 8; bit++ {
+			r |= (b >> bit & 1) << (7 - bit)
+		}
+		key[i] = r
+	}
+	block, _ := des.NewCipher(key[:]) //nolint:gosec // see the import.
+	out := make([]byte, 16)
+	block.Encrypt(out[:8], challenge[:8])
+	block.Encrypt(out[8:], challenge[8:])
+	return out
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consoleproxy
+
+import (
+	"bytes"
+	"crypto/des" //nolint:gosec // VNC authentication is defined in terms of DES.
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVNCServer runs the server side of the RFB 3.8 security handshake on
+// conn, offering VNC authentication when password is set and None
+// otherwise, then echoes what it reads.
+func fakeVNCServer(t *testing.T, conn net.Conn, password string) {
+	t.Helper()
+	defer conn.Close() //nolint:errcheck
+	_, _ = io.WriteString(conn, rfbVersion)
+	version := make([]byte, len(rfbVersion))
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return
+	}
+	if password == "" {
+		_, _ = conn.Write([]byte{1, rfbSecurityNone})
+	} else {
+		_, _ = conn.Write([]byte{2, 16, rfbSecurityVNC})
+	}
+	var chosen [1]byte
+	if _, err := io.ReadFull(conn, chosen[:]); err != nil {
+		return
+	}
+	result := uint32(0)
+	if password != "" {
+		challenge := bytes.Repeat([]byte{0x5a}, 16)
+		_, _ = conn.Write(challenge)
+		response := make([]byte, 16)
+		if _, err := io.ReadFull(conn, response); err != nil {
+			return
+		}
+		if !bytes.Equal(response, expectedVNCResponse(password, challenge)) {
+			result = 1
+		}
+	}
+	_ = binary.Write(conn, binary.BigEndian, result)
+	if result != 0 {
+		reason := "bad password"
+		_ = binary.Write(conn, binary.BigEndian, uint32(len(reason)))
+		_, _ = io.WriteString(conn, reason)
+		return
+	}
+	_, _ = io.Copy(conn, conn)
+}
+
+// expectedVNCResponse computes the VNC authentication response
+// independently of vncAuthResponse.
+func expectedVNCResponse(password string, challenge []byte) []byte {
+	key := make([]byte, 8)
+	copy(key, password)
+	for i := range key {
+		key[i] = bits.Reverse8(key[i])
+	}
+	block, _ := des.NewCipher(key) //nolint:gosec // see the import.
+	out := make([]byte, 16)
+	block.Encrypt(out[:8], challenge[:8])
+	block.Encrypt(out[8:], challenge[8:])
+	return out
+}
+
+func TestRFBAuthenticate(t *testing.T) {
+	for _, tc := range []struct {
+		name, serverPassword, password, wantErr string
+	}{
+		{name: "VNC authentication", serverPassword: "PVEVNC:ticket", password: "PVEVNC:ticket"},
+		{name: "no authentication", serverPassword: "", password: ""},
+		{name: "wrong password", serverPassword: "secret", password: "guess", wantErr: "bad password"},
+		{name: "password for an open server", serverPassword: "", password: "secret", wantErr: "does not offer security type 2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close() //nolint:errcheck
+			go fakeVNCServer(t, server, tc.serverPassword)
+
+			err := rfbAuthenticate(client, tc.password)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			_, err = client.Write([]byte{1})
+			require.NoError(t, err)
+			var echo [1]byte
+			_, err = io.ReadFull(client, echo[:])
+			require.NoError(t, err)
+			assert.Equal(t, byte(1), echo[0], "the connection is left at ClientInit")
+		})
+	}
+}
+
+// TestRFBAcceptNone verifies a client is offered no authentication, which
+// the client side of the handshake accepts.
+func TestRFBAcceptNone(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close() //nolint:errcheck
+	defer server.Close() //nolint:errcheck
+	errCh := make(chan error, 1)
+	go func() { errCh <- rfbAcceptNone(server) }()
+
+	require.NoError(t, rfbAuthenticate(client, ""))
+	require.NoError(t, <-errCh)
+}
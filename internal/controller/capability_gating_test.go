@@ -83,13 +83,15 @@ func readyCondition(conds []metav1.Condition, condType string) *metav1.Condition
 
 func TestGateSnapshotCreate(t *testing.T) {
 	tests := []struct {
-		name           string
-		enforce        bool
-		provider       contracts.Provider
-		includeMemory  bool
-		wantBlocked    bool
-		wantPhase      infrav1beta1.SnapshotPhase
-		wantReadyFalse bool
+		name            string
+		enforce         bool
+		provider        contracts.Provider
+		includeMemory   bool
+		quiesce         bool
+		allowUnquiesced bool
+		wantBlocked     bool
+		wantPhase       infrav1beta1.SnapshotPhase
+		wantReadyFalse  bool
 	}{
 		{
 			name:        "enforcement off does not block even when unsupported",
@@ -133,6 +135,33 @@ func TestGateSnapshotCreate(t *testing.T) {
 			includeMemory: true,
 			wantBlocked:   false,
 		},
+		{
+			name:           "enforcement on, quiesce requested and unsupported, blocks",
+			enforce:        true,
+			provider:       &capReporterProvider{caps: contracts.Capabilities{SupportsSnapshots: true}},
+			quiesce:        true,
+			wantBlocked:    true,
+			wantPhase:      infrav1beta1.SnapshotPhaseFailed,
+			wantReadyFalse: true,
+		},
+		{
+			name:            "enforcement on, quiesce unsupported but may degrade, proceeds",
+			enforce:         true,
+			provider:        &capReporterProvider{caps: contracts.Capabilities{SupportsSnapshots: true}},
+			quiesce:         true,
+			allowUnquiesced: true,
+			wantBlocked:     false,
+		},
+		{
+			name:    "enforcement on, quiesce requested and supported, proceeds",
+			enforce: true,
+			provider: &capReporterProvider{caps: contracts.Capabilities{
+				SupportsSnapshots:         true,
+				SupportsQuiescedSnapshots: true,
+			}},
+			quiesce:     true,
+			wantBlocked: false,
+		},
 		{
 			name:        "enforcement on, provider is not a CapabilityReporter, fails open",
 			enforce:     true,
@@ -167,8 +196,10 @@ func TestGateSnapshotCreate(t *testing.T) {
 			}
 
 			req := contracts.SnapshotCreateRequest{
-				VmId:          "vm-1",
-				IncludeMemory: tc.includeMemory,
+				VmId:            "vm-1",
+				IncludeMemory:   tc.includeMemory,
+				Quiesce:         tc.quiesce,
+				AllowUnquiesced: tc.allowUnquiesced,
 			}
 
 			blocked, _ := r.gateSnapshotCreate(context.Background(), snapshot, tc.provider, req)
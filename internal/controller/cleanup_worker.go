@@ -0,0 +1,456 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// DefaultCleanupInterval is how often CleanupWorker looks for artifacts due
+// for deletion when no Interval is set.
+const DefaultCleanupInterval = 30 * time.Second
+
+// cleanupExpiryRecheck is how long a finished object whose TTL has expired
+// waits for its pending cleanup before it is looked at again.
+const cleanupExpiryRecheck = time.Minute
+
+// Outcomes of a cleanup attempt, as reported by
+// virtrigaud_cleanup_attempts_total.
+const (
+	cleanupOutcomeDeleted   = "deleted"
+	cleanupOutcomeFailed    = "failed"
+	cleanupOutcomeAbandoned = "abandoned"
+)
+
+// errDiskDeleteUnsupported is why Disk records are abandoned: the provider
+// contract has no operation that deletes a disk on its own.
+var errDiskDeleteUnsupported = stderrors.New("providers have no operation to delete a disk; remove the volume by hand")
+
+// CleanupWorker deletes the provider-side artifacts that failed clones and
+// migrations left behind. The controllers record each artifact in the
+// pendingCleanup status of the object whose operation created it; the
+// worker picks the records that are due, deletes their artifacts and drops
+// the records that succeeded. A failed deletion is retried with
+// exponential backoff until cleanup.maxAttempts, after which the record is
+// marked abandoned and reported for manual removal.
+//
+// It runs on its own interval and makes at most cleanup.batchSize
+// deletions per pass, VMs before snapshots before disks and the longest
+// waiting first, so cleanup never competes with reconciles for provider
+// capacity.
+type CleanupWorker struct {
+	Client         client.Client
+	RemoteResolver ProviderResolver
+	Recorder       record.EventRecorder
+	Config         *config.ConfigStore
+	// Interval overrides DefaultCleanupInterval.
+	Interval time.Duration
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader deletes, so replicas do not race each other for the same records.
+func (w *CleanupWorker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (w *CleanupWorker) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultCleanupInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.runPass(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanupItem is one record due for deletion and the object holding it.
+type cleanupItem struct {
+	owner  client.Object
+	record infrav1beta1.CleanupRecord
+}
+
+// runPass deletes the artifacts of up to cleanup.batchSize due records and
+// writes the outcomes back to their owners.
+func (w *CleanupWorker) runPass(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("cleanup")
+	cfg := w.Config.Get().Cleanup
+	now := w.clock()
+
+	owners, err := w.listOwners(ctx)
+	if err != nil {
+		log.Error(err, "Failed to list objects with pending cleanup")
+		return
+	}
+
+	var due []cleanupItem
+	counts := map[infrav1beta1.CleanupArtifactKind][2]int{}
+	for _, owner := range owners {
+		records, _ := cleanupLedger(owner)
+		for _, rec := range *records {
+			c := counts[rec.Kind]
+			if rec.Abandoned {
+				c[1]++
+			} else {
+				c[0]++
+				if rec.NextAttemptTime == nil || !now.Before(rec.NextAttemptTime.Time) {
+					due = append(due, cleanupItem{owner: owner, record: rec})
+				}
+			}
+			counts[rec.Kind] = c
+		}
+	}
+	for _, kind := range []infrav1beta1.CleanupArtifactKind{
+		infrav1beta1.CleanupArtifactVM, infrav1beta1.CleanupArtifactSnapshot, infrav1beta1.CleanupArtifactDisk,
+	} {
+		metrics.SetCleanupRecords(string(kind), "pending", float64(counts[kind][0]))
+		metrics.SetCleanupRecords(string(kind), "abandoned", float64(counts[kind][1]))
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		a, b := due[i].record, due[j].record
+		if cleanupPriority(a.Kind) != cleanupPriority(b.Kind) {
+			return cleanupPriority(a.Kind) < cleanupPriority(b.Kind)
+		}
+		return cleanupDueAt(a).Before(cleanupDueAt(b))
+	})
+	if len(due) > cfg.BatchSize {
+		due = due[:cfg.BatchSize]
+	}
+
+	// Outcomes are written back per owner, after all of its records in
+	// this batch were tried.
+	var order []client.Object
+	outcomes := map[client.Object][]cleanupOutcome{}
+	for _, item := range due {
+		if _, seen := outcomes[item.owner]; !seen {
+			order = append(order, item.owner)
+		}
+		outcomes[item.owner] = append(outcomes[item.owner], w.attempt(ctx, item.owner, item.record, cfg, now))
+	}
+	for _, owner := range order {
+		if err := w.writeOutcomes(ctx, owner, outcomes[owner]); err != nil {
+			log.Error(err, "Failed to record cleanup outcomes", "kind", ownerKind(owner), "object", client.ObjectKeyFromObject(owner))
+		}
+	}
+}
+
+// listOwners returns every object whose status holds cleanup records.
+func (w *CleanupWorker) listOwners(ctx context.Context) ([]client.Object, error) {
+	var owners []client.Object
+	var clones infrav1beta1.VMCloneList
+	if err := w.Client.List(ctx, &clones); err != nil {
+		return nil, err
+	}
+	for i := range clones.Items {
+		if len(clones.Items[i].Status.PendingCleanup) > 0 {
+			owners = append(owners, &clones.Items[i])
+		}
+	}
+	var migrations infrav1beta1.VMMigrationList
+	if err := w.Client.List(ctx, &migrations); err != nil {
+		return nil, err
+	}
+	for i := range migrations.Items {
+		if len(migrations.Items[i].Status.PendingCleanup) > 0 {
+			owners = append(owners, &migrations.Items[i])
+		}
+	}
+	return owners, nil
+}
+
+// cleanupOutcome is what one attempt did to a record: removed it, or left
+// the updated copy in its place.
+type cleanupOutcome struct {
+	record  infrav1beta1.CleanupRecord
+	removed bool
+}
+
+// attempt tries to delete rec's artifact once, or checks on the deletion
+// task an earlier attempt started.
+func (w *CleanupWorker) attempt(
+	ctx context.Context,
+	owner client.Object,
+	rec infrav1beta1.CleanupRecord,
+	cfg config.CleanupConfig,
+	now time.Time,
+) cleanupOutcome {
+	log := logf.FromContext(ctx).WithName("cleanup").WithValues(
+		"kind", ownerKind(owner), "object", client.ObjectKeyFromObject(owner), "artifact", describeArtifact(rec))
+
+	taskRef, err := w.deleteArtifact(ctx, owner, rec)
+	switch {
+	case err == nil && taskRef != "":
+		log.V(1).Info("Artifact deletion in progress", "task_ref", taskRef)
+		rec.TaskRef = taskRef
+		rec.NextAttemptTime = nil
+		return cleanupOutcome{record: rec}
+	case err == nil || contracts.IsNotFound(err):
+		log.Info("Deleted artifact left by a failed operation")
+		metrics.RecordCleanupAttempt(string(rec.Kind), cleanupOutcomeDeleted)
+		w.emit(ctx, owner, corev1.EventTypeNormal, events.ReasonArtifactCleanedUp,
+			fmt.Sprintf("Deleted %s", describeArtifact(rec)))
+		return cleanupOutcome{record: rec, removed: true}
+	}
+
+	rec.Attempts++
+	rec.LastError = err.Error()
+	rec.TaskRef = ""
+	if stderrors.Is(err, errDiskDeleteUnsupported) || int(rec.Attempts) >= cfg.MaxAttempts {
+		log.Info("Giving up on deleting artifact", "attempts", rec.Attempts, "error", err.Error())
+		rec.Abandoned = true
+		rec.NextAttemptTime = nil
+		metrics.RecordCleanupAttempt(string(rec.Kind), cleanupOutcomeAbandoned)
+		w.emit(ctx, owner, corev1.EventTypeWarning, events.ReasonArtifactCleanupAbandoned,
+			fmt.Sprintf("Gave up deleting %s after %d attempts, remove it by hand: %v", describeArtifact(rec), rec.Attempts, err))
+		return cleanupOutcome{record: rec}
+	}
+	next := metav1.NewTime(now.Add(cleanupBackoff(cfg, rec.Attempts)))
+	rec.NextAttemptTime = &next
+	log.V(1).Info("Failed to delete artifact, will retry", "attempts", rec.Attempts, "next_attempt", next.Time, "error", err.Error())
+	metrics.RecordCleanupAttempt(string(rec.Kind), cleanupOutcomeFailed)
+	return cleanupOutcome{record: rec}
+}
+
+// deleteArtifact issues the deletion of rec's artifact, or, when one is in
+// flight, reports on its task: an empty taskRef and nil error mean the
+// artifact is gone.
+func (w *CleanupWorker) deleteArtifact(ctx context.Context, owner client.Object, rec infrav1beta1.CleanupRecord) (string, error) {
+	if rec.Kind == infrav1beta1.CleanupArtifactDisk {
+		return "", errDiskDeleteUnsupported
+	}
+
+	provider := &infrav1beta1.Provider{}
+	key := client.ObjectKey{Namespace: rec.ProviderRef.Namespace, Name: rec.ProviderRef.Name}
+	if key.Namespace == "" {
+		key.Namespace = owner.GetNamespace()
+	}
+	if err := w.Client.Get(ctx, key, provider); err != nil {
+		return "", fmt.Errorf("provider %s: %w", key, err)
+	}
+	p, err := w.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		return "", fmt.Errorf("provider %s: %w", key, err)
+	}
+
+	if rec.TaskRef != "" {
+		done, err := p.IsTaskComplete(ctx, rec.TaskRef)
+		if err != nil {
+			return "", fmt.Errorf("deletion task %s: %w", rec.TaskRef, err)
+		}
+		if !done {
+			return rec.TaskRef, nil
+		}
+		status, err := p.TaskStatus(ctx, rec.TaskRef)
+		if err != nil {
+			return "", fmt.Errorf("deletion task %s: %w", rec.TaskRef, err)
+		}
+		if status.Error != "" {
+			return "", fmt.Errorf("deletion task %s failed: %s", rec.TaskRef, status.Error)
+		}
+		return "", nil
+	}
+
+	switch rec.Kind {
+	case infrav1beta1.CleanupArtifactVM:
+		return p.Delete(ctx, rec.ID)
+	case infrav1beta1.CleanupArtifactSnapshot:
+		return p.SnapshotDelete(ctx, rec.VMID, rec.ID)
+	default:
+		return "", fmt.Errorf("unknown artifact kind %q", rec.Kind)
+	}
+}
+
+// writeOutcomes applies outcomes to the latest version of owner's ledger.
+// Records added since owner was listed are kept as they are.
+func (w *CleanupWorker) writeOutcomes(ctx context.Context, owner client.Object, outcomes []cleanupOutcome) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := owner.DeepCopyObject().(client.Object)
+		if err := w.Client.Get(ctx, client.ObjectKeyFromObject(owner), latest); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		records, _ := cleanupLedger(latest)
+		kept := make([]infrav1beta1.CleanupRecord, 0, len(*records))
+		for _, rec := range *records {
+			if o, ok := findCleanupOutcome(outcomes, rec); ok {
+				if o.removed {
+					continue
+				}
+				rec = o.record
+			}
+			kept = append(kept, rec)
+		}
+		*records = kept
+		return w.Client.Status().Update(ctx, latest)
+	})
+}
+
+func findCleanupOutcome(outcomes []cleanupOutcome, rec infrav1beta1.CleanupRecord) (cleanupOutcome, bool) {
+	for _, o := range outcomes {
+		if sameArtifact(rec, o.record) {
+			return o, true
+		}
+	}
+	return cleanupOutcome{}, false
+}
+
+func (w *CleanupWorker) emit(ctx context.Context, owner client.Object, eventType, reason, message string) {
+	_, phase := cleanupLedger(owner)
+	events.Emit(ctx, w.Recorder, owner, eventType, reason, phase, message)
+}
+
+func (w *CleanupWorker) clock() time.Time {
+	if w.now != nil {
+		return w.now()
+	}
+	return time.Now()
+}
+
+// cleanupLedger returns the cleanup records of owner and its phase.
+func cleanupLedger(owner client.Object) (*[]infrav1beta1.CleanupRecord, string) {
+	switch o := owner.(type) {
+	case *infrav1beta1.VMClone:
+		return &o.Status.PendingCleanup, string(o.Status.Phase)
+	case *infrav1beta1.VMMigration:
+		return &o.Status.PendingCleanup, string(o.Status.Phase)
+	default:
+		panic(fmt.Sprintf("no cleanup ledger on %T", owner))
+	}
+}
+
+func ownerKind(owner client.Object) string {
+	switch owner.(type) {
+	case *infrav1beta1.VMClone:
+		return "VMClone"
+	default:
+		return "VMMigration"
+	}
+}
+
+// addCleanupRecord appends rec to records unless the same artifact is
+// already recorded, and reports whether it did. Recording an artifact
+// twice, as a repeated reconcile of a failed object does, is harmless.
+func addCleanupRecord(records *[]infrav1beta1.CleanupRecord, rec infrav1beta1.CleanupRecord) bool {
+	for _, existing := range *records {
+		if sameArtifact(existing, rec) {
+			return false
+		}
+	}
+	if rec.RecordedAt.IsZero() {
+		rec.RecordedAt = metav1.Now()
+	}
+	*records = append(*records, rec)
+	return true
+}
+
+// cleanupPending reports whether any of records still waits for deletion.
+// Abandoned records do not: nothing more is done about them.
+func cleanupPending(records []infrav1beta1.CleanupRecord) bool {
+	for _, rec := range records {
+		if !rec.Abandoned {
+			return true
+		}
+	}
+	return false
+}
+
+// describeCleanupRecords lists the artifacts of records for an event.
+func describeCleanupRecords(records []infrav1beta1.CleanupRecord) string {
+	described := make([]string, 0, len(records))
+	for _, rec := range records {
+		described = append(described, describeArtifact(rec))
+	}
+	return strings.Join(described, ", ")
+}
+
+func sameArtifact(a, b infrav1beta1.CleanupRecord) bool {
+	return a.Kind == b.Kind && a.ID == b.ID && a.VMID == b.VMID && a.ProviderRef == b.ProviderRef
+}
+
+func describeArtifact(rec infrav1beta1.CleanupRecord) string {
+	switch rec.Kind {
+	case infrav1beta1.CleanupArtifactVM:
+		return fmt.Sprintf("VM %s on provider %s", rec.ID, rec.ProviderRef.Name)
+	case infrav1beta1.CleanupArtifactSnapshot:
+		return fmt.Sprintf("snapshot %s of VM %s on provider %s", rec.ID, rec.VMID, rec.ProviderRef.Name)
+	default:
+		return fmt.Sprintf("disk %s on provider %s", rec.ID, rec.ProviderRef.Name)
+	}
+}
+
+// cleanupPriority orders artifacts by what they hold up: a VM holds
+// compute, locks and disks, a snapshot slows its VM down, a disk only
+// takes space.
+func cleanupPriority(kind infrav1beta1.CleanupArtifactKind) int {
+	switch kind {
+	case infrav1beta1.CleanupArtifactVM:
+		return 0
+	case infrav1beta1.CleanupArtifactSnapshot:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func cleanupDueAt(rec infrav1beta1.CleanupRecord) time.Time {
+	if rec.NextAttemptTime != nil {
+		return rec.NextAttemptTime.Time
+	}
+	return rec.RecordedAt.Time
+}
+
+// cleanupBackoff is the wait after the attempts-th failed deletion:
+// cfg.InitialBackoff, doubling with every failure, at most cfg.MaxBackoff.
+func cleanupBackoff(cfg config.CleanupConfig, attempts int32) time.Duration {
+	backoff := cfg.InitialBackoff.Duration
+	for i := int32(1); i < attempts && backoff < cfg.MaxBackoff.Duration; i++ {
+		backoff *= 2
+	}
+	return min(backoff, cfg.MaxBackoff.Duration)
+}
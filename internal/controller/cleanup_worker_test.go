@@ -0,0 +1,236 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// cleanupProvider records the deletions made against it and fails them
+// with deleteErr.
+type cleanupProvider struct {
+	stubProvider
+	deleteErr error
+	deleted   []string
+}
+
+func (p *cleanupProvider) Delete(_ context.Context, id string) (string, error) {
+	p.deleted = append(p.deleted, "vm/"+id)
+	return "", p.deleteErr
+}
+
+func (p *cleanupProvider) SnapshotDelete(_ context.Context, vmID, snapshotID string) (string, error) {
+	p.deleted = append(p.deleted, "snapshot/"+vmID+"/"+snapshotID)
+	return "", p.deleteErr
+}
+
+func newCleanupWorker(t *testing.T, p contracts.Provider, cfg *config.VirtrigaudConfig, objs ...client.Object) (*CleanupWorker, *record.FakeRecorder, *time.Time) {
+	t.Helper()
+	fc := fake.NewClientBuilder().
+		WithScheme(cloneTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&infrav1beta1.VMClone{}, &infrav1beta1.VMMigration{}).
+		Build()
+	store := config.NewConfigStore(cfg, nil)
+	recorder := record.NewFakeRecorder(20)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return &CleanupWorker{
+		Client:         fc,
+		RemoteResolver: &stubResolver{provider: p},
+		Recorder:       recorder,
+		Config:         store,
+		now:            func() time.Time { return now },
+	}, recorder, &now
+}
+
+func failedClone(records ...infrav1beta1.CleanupRecord) *infrav1beta1.VMClone {
+	clone := &infrav1beta1.VMClone{ObjectMeta: metav1.ObjectMeta{Name: "clone-1", Namespace: "default"}}
+	clone.Status.Phase = infrav1beta1.ClonePhaseFailed
+	clone.Status.PendingCleanup = records
+	return clone
+}
+
+func cleanupRecord(kind infrav1beta1.CleanupArtifactKind, id string, recordedAt time.Time) infrav1beta1.CleanupRecord {
+	return infrav1beta1.CleanupRecord{
+		Kind:        kind,
+		ID:          id,
+		VMID:        "vm-src",
+		ProviderRef: infrav1beta1.ObjectRef{Name: "prov-1", Namespace: "default"},
+		RecordedAt:  metav1.NewTime(recordedAt),
+	}
+}
+
+func TestCleanupWorker_DeletesByPriorityWithinBatch(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.DefaultVirtrigaudConfig()
+	cfg.Cleanup.BatchSize = 1
+	p := &cleanupProvider{}
+	older := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	clone := failedClone(
+		cleanupRecord(infrav1beta1.CleanupArtifactSnapshot, "snap-1", older),
+		cleanupRecord(infrav1beta1.CleanupArtifactVM, "vm-1", older.Add(time.Hour)),
+	)
+	w, recorder, _ := newCleanupWorker(t, p, cfg, runningProvider("default", "prov-1"), clone)
+
+	w.runPass(ctx)
+	assert.Equal(t, []string{"vm/vm-1"}, p.deleted, "VMs go first, one per pass")
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, w.Client.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	require.Len(t, got.Status.PendingCleanup, 1)
+	assert.Equal(t, "snap-1", got.Status.PendingCleanup[0].ID)
+	assert.Contains(t, <-recorder.Events, events.ReasonArtifactCleanedUp)
+
+	w.runPass(ctx)
+	assert.Equal(t, []string{"vm/vm-1", "snapshot/vm-src/snap-1"}, p.deleted)
+	require.NoError(t, w.Client.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	assert.Empty(t, got.Status.PendingCleanup)
+}
+
+func TestCleanupWorker_BacksOffThenAbandons(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.DefaultVirtrigaudConfig()
+	cfg.Cleanup.MaxAttempts = 2
+	p := &cleanupProvider{deleteErr: stderrors.New("VM is locked")}
+	clone := failedClone(
+		cleanupRecord(infrav1beta1.CleanupArtifactVM, "vm-1", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+		cleanupRecord(infrav1beta1.CleanupArtifactDisk, "local:vm-1-disk-0", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+	)
+	w, recorder, now := newCleanupWorker(t, p, cfg, runningProvider("default", "prov-1"), clone)
+	get := func() []infrav1beta1.CleanupRecord {
+		got := &infrav1beta1.VMClone{}
+		require.NoError(t, w.Client.Get(ctx, client.ObjectKeyFromObject(clone), got))
+		return got.Status.PendingCleanup
+	}
+
+	w.runPass(ctx)
+	records := get()
+	require.Len(t, records, 2)
+	vm, disk := records[0], records[1]
+	assert.Equal(t, int32(1), vm.Attempts)
+	assert.Equal(t, "VM is locked", vm.LastError)
+	require.NotNil(t, vm.NextAttemptTime)
+	assert.Equal(t, now.Add(time.Minute), vm.NextAttemptTime.Time.UTC())
+	assert.False(t, vm.Abandoned)
+	assert.True(t, disk.Abandoned, "disks cannot be deleted through a provider")
+	assert.Contains(t, <-recorder.Events, events.ReasonArtifactCleanupAbandoned)
+
+	// Not due yet: nothing is tried.
+	*now = now.Add(30 * time.Second)
+	w.runPass(ctx)
+	assert.Len(t, p.deleted, 1)
+
+	*now = now.Add(time.Minute)
+	w.runPass(ctx)
+	assert.Len(t, p.deleted, 2)
+	vm = get()[0]
+	assert.True(t, vm.Abandoned)
+	assert.Equal(t, int32(2), vm.Attempts)
+	assert.Contains(t, <-recorder.Events, events.ReasonArtifactCleanupAbandoned)
+
+	// Abandoned records are left alone.
+	*now = now.Add(24 * time.Hour)
+	w.runPass(ctx)
+	assert.Len(t, p.deleted, 2)
+}
+
+func TestCleanupWorker_AwaitsDeletionTask(t *testing.T) {
+	ctx := context.Background()
+	done := false
+	p := &cleanupProvider{stubProvider: stubProvider{
+		IsTaskCompleteFn: func(_ context.Context, _ string) (bool, error) { return done, nil },
+	}}
+	rec := cleanupRecord(infrav1beta1.CleanupArtifactVM, "vm-1", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	rec.TaskRef = "task-7"
+	clone := failedClone(rec)
+	w, _, _ := newCleanupWorker(t, p, config.DefaultVirtrigaudConfig(), runningProvider("default", "prov-1"), clone)
+
+	w.runPass(ctx)
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, w.Client.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	require.Len(t, got.Status.PendingCleanup, 1)
+	assert.Equal(t, "task-7", got.Status.PendingCleanup[0].TaskRef)
+	assert.Empty(t, p.deleted, "a deletion in flight is not issued again")
+
+	done = true
+	w.runPass(ctx)
+	require.NoError(t, w.Client.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	assert.Empty(t, got.Status.PendingCleanup)
+}
+
+func TestCleanupBackoff(t *testing.T) {
+	cfg := config.DefaultVirtrigaudConfig().Cleanup
+	assert.Equal(t, time.Minute, cleanupBackoff(cfg, 1))
+	assert.Equal(t, 4*time.Minute, cleanupBackoff(cfg, 3))
+	assert.Equal(t, time.Hour, cleanupBackoff(cfg, 20))
+}
+
+// TestVMClone_FailedTaskRecordsTargetForCleanup: a clone whose task fails
+// after the provider reported the target VM ID records that VM for cleanup,
+// once, and holds its TTL until the record is gone.
+func TestVMClone_FailedTaskRecordsTargetForCleanup(t *testing.T) {
+	ctx := context.Background()
+	s := cloneTestScheme(t)
+	ns := "default"
+	prov := runningProvider(ns, "prov-1")
+	src := sourceVMWithID(ns, "src-vm", "prov-1", "vm-source-123")
+	clone := &infrav1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone-1", Namespace: ns},
+		Spec: infrav1beta1.VMCloneSpec{
+			Source:             infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "src-vm"}},
+			Target:             infrav1beta1.VMCloneTarget{Name: "clone-target"},
+			TTLAfterCompletion: &metav1.Duration{},
+		},
+	}
+	cp := &clonerProvider{
+		stubProvider: stubProvider{IsTaskCompleteFn: func(_ context.Context, _ string) (bool, error) {
+			return false, stderrors.New("disk lock timeout")
+		}},
+		cloneResp: contracts.CloneResponse{TargetVmID: "104", TaskRef: "UPID:clone"},
+	}
+	r := newCloneReconciler(s, &stubResolver{provider: cp}, prov, src, clone)
+
+	for i := 0; i < 5; i++ {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(clone)})
+		require.NoError(t, err)
+	}
+
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got), "pending cleanup holds the TTL")
+	assert.Equal(t, infrav1beta1.ClonePhaseFailed, got.Status.Phase)
+	require.Len(t, got.Status.PendingCleanup, 1)
+	rec := got.Status.PendingCleanup[0]
+	assert.Equal(t, infrav1beta1.CleanupArtifactVM, rec.Kind)
+	assert.Equal(t, "104", rec.ID)
+	assert.Equal(t, infrav1beta1.ObjectRef{Name: "prov-1", Namespace: ns}, rec.ProviderRef)
+	assert.Equal(t, "clone task failed", rec.Reason)
+}
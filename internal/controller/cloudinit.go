@@ -19,16 +19,31 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
 )
 
 const cloudInitBoundary = "VIRTRIGAUD_CLOUD_INIT_BOUNDARY"
 
+// maxCloudInitDocumentBytes caps each resolved cloud-init document (user-data,
+// meta-data, network-config, vendor-data), so that all four together stay well
+// inside the 4 MiB gRPC message limit of the create request.
+const maxCloudInitDocumentBytes = 512 * 1024
+
+// Secret keys tried, in order, for a network-config or vendor-data document
+// whose key is not given.
+var (
+	networkConfigSecretKeys = []string{"network-config", "networkconfig", "network_config"}
+	vendorDataSecretKeys    = []string{"vendor-data", "vendordata", "vendor_data"}
+)
+
 // extractCloudInitFromSecret extracts cloud-init user data from a Secret.
 // Accepted keys: userdata, user-data, cloud-init, cloud-config.
 func extractCloudInitFromSecret(s *corev1.Secret) (string, error) {
@@ -72,7 +87,15 @@ func mergeCloudConfigParts(parts []string) string {
 
 // resolveCloudInitUserData resolves cloud-init user data from inline content,
 // a Secret reference, or both (merged as MIME multipart when both are set).
+// The Secret key is ci.Key when set, else one extractCloudInitFromSecret
+// accepts.
 func (r *VirtualMachineReconciler) resolveCloudInitUserData(ctx context.Context, namespace string, ci *infravirtrigaudiov1beta1.CloudInit) (string, error) {
+	return cloudInitUserData(ctx, r.Client, namespace, ci)
+}
+
+// cloudInitUserData is resolveCloudInitUserData for callers other than the
+// VirtualMachine controller.
+func cloudInitUserData(ctx context.Context, c client.Reader, namespace string, ci *infravirtrigaudiov1beta1.CloudInit) (string, error) {
 	var parts []string
 
 	if ci.Inline != "" {
@@ -81,12 +104,21 @@ func (r *VirtualMachineReconciler) resolveCloudInitUserData(ctx context.Context,
 
 	if ci.SecretRef != nil {
 		secret := &corev1.Secret{}
-		if err := r.Get(ctx, types.NamespacedName{Name: ci.SecretRef.Name, Namespace: namespace}, secret); err != nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: ci.SecretRef.Name, Namespace: namespace}, secret); err != nil {
 			return "", fmt.Errorf("fetching cloud-init secret %q: %w", ci.SecretRef.Name, err)
 		}
-		data, err := extractCloudInitFromSecret(secret)
-		if err != nil {
-			return "", err
+		var data string
+		if ci.Key != "" {
+			val, ok := secret.Data[ci.Key]
+			if !ok {
+				return "", fmt.Errorf("secret %q has no key %q", secret.Name, ci.Key)
+			}
+			data = string(val)
+		} else {
+			var err error
+			if data, err = extractCloudInitFromSecret(secret); err != nil {
+				return "", err
+			}
 		}
 		parts = append(parts, data)
 	}
@@ -124,3 +156,43 @@ func (r *VirtualMachineReconciler) resolveCloudInitMetaData(ctx context.Context,
 
 	return strings.Join(parts, "\n"), nil
 }
+
+// resolveCloudInitDocument returns the content of doc, inline or read from
+// its Secret. Without doc.Key, the first of keys present in the Secret is
+// used. field names the document in errors.
+func (r *VirtualMachineReconciler) resolveCloudInitDocument(ctx context.Context, namespace, field string, doc *infravirtrigaudiov1beta1.CloudInitDocument, keys []string) (string, error) {
+	if doc == nil {
+		return "", nil
+	}
+	if doc.SecretRef == nil {
+		return doc.Inline, nil
+	}
+	if doc.Inline != "" {
+		return "", fmt.Errorf("%s: set inline or secretRef, not both", field)
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: doc.SecretRef.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("fetching %s secret %q: %w", field, doc.SecretRef.Name, err)
+	}
+	if doc.Key != "" {
+		keys = []string{doc.Key}
+	}
+	for _, key := range keys {
+		if val, ok := secret.Data[key]; ok {
+			return string(val), nil
+		}
+	}
+	return "", fmt.Errorf("secret %q contains no %s key; looked for: %v", secret.Name, field, keys)
+}
+
+// checkCloudInitSizes rejects any cloud-init document over
+// maxCloudInitDocumentBytes.
+func checkCloudInitSizes(docs map[string]string) error {
+	for _, field := range slices.Sorted(maps.Keys(docs)) {
+		if doc := docs[field]; len(doc) > maxCloudInitDocumentBytes {
+			return fmt.Errorf("%s is %d bytes, over the %d byte limit", field, len(doc), maxCloudInitDocumentBytes)
+		}
+	}
+	return nil
+}
@@ -307,6 +307,50 @@ func TestResolveCloudInitUserData_SecretHasNoRecognisedKey_ReturnsError(t *testi
 	}
 }
 
+func TestResolveCloudInitUserData_SecretRefWithKey(t *testing.T) {
+	// A 400 KiB document, over the 256 KiB inline limit but within the
+	// 512 KiB a resolved document may be, under a key of the user's choosing.
+	large := "#cloud-config\n" + strings.Repeat("#", 400<<10)
+	secret := makeSecret("ci-secret", "default", map[string][]byte{
+		"userdata": []byte("#cloud-config\nhostname: default-key"),
+		"web.yaml": []byte(large),
+	})
+	r := reconcilerWithSecrets(t, secret)
+	ci := &infravirtrigaudiov1beta1.CloudInit{
+		SecretRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "ci-secret"},
+		Key:       "web.yaml",
+	}
+
+	got, err := r.resolveCloudInitUserData(context.Background(), "default", ci)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != large {
+		t.Errorf("expected the content under web.yaml, got %d bytes", len(got))
+	}
+	if err := checkCloudInitSizes(map[string]string{"userData": got}); err != nil {
+		t.Errorf("unexpected size error: %v", err)
+	}
+}
+
+func TestResolveCloudInitUserData_SecretRefKeyMissing_ReturnsError(t *testing.T) {
+	secret := makeSecret("ci-secret", "default", map[string][]byte{
+		"userdata": []byte("#cloud-config"),
+	})
+	r := reconcilerWithSecrets(t, secret)
+	ci := &infravirtrigaudiov1beta1.CloudInit{
+		SecretRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "ci-secret"},
+		Key:       "web.yaml",
+	}
+
+	_, err := r.resolveCloudInitUserData(context.Background(), "default", ci)
+
+	if err == nil || !strings.Contains(err.Error(), "web.yaml") {
+		t.Fatalf("expected an error naming the missing key, got: %v", err)
+	}
+}
+
 func TestResolveCloudInitUserData_NeitherInlineNorSecretRef_ReturnsEmpty(t *testing.T) {
 	r := reconcilerWithSecrets(t)
 	ci := &infravirtrigaudiov1beta1.CloudInit{}
@@ -780,3 +824,117 @@ func TestBuildCreateRequest_MetaData_InlineAndSecretRef_Concatenated(t *testing.
 		t.Error("expected secretRef content in MetaData")
 	}
 }
+
+// ─── network-config and vendor-data ───────────────────────────────────────────
+
+func TestResolveCloudInitDocument(t *testing.T) {
+	secret := makeSecret("net", "default", map[string][]byte{
+		"network_config": []byte("version: 2"),
+		"custom":         []byte("version: 1"),
+	})
+	r := reconcilerWithSecrets(t, secret)
+	ref := &infravirtrigaudiov1beta1.LocalObjectReference{Name: "net"}
+
+	cases := []struct {
+		name    string
+		doc     *infravirtrigaudiov1beta1.CloudInitDocument
+		want    string
+		wantErr string
+	}{
+		{name: "nil"},
+		{name: "inline", doc: &infravirtrigaudiov1beta1.CloudInitDocument{Inline: "version: 2\nethernets: {}"}, want: "version: 2\nethernets: {}"},
+		{name: "conventional key", doc: &infravirtrigaudiov1beta1.CloudInitDocument{SecretRef: ref}, want: "version: 2"},
+		{name: "explicit key", doc: &infravirtrigaudiov1beta1.CloudInitDocument{SecretRef: ref, Key: "custom"}, want: "version: 1"},
+		{name: "missing key", doc: &infravirtrigaudiov1beta1.CloudInitDocument{SecretRef: ref, Key: "other"}, wantErr: "no network-config key"},
+		{name: "inline and secret", doc: &infravirtrigaudiov1beta1.CloudInitDocument{SecretRef: ref, Inline: "x"}, wantErr: "not both"},
+		{name: "missing secret", doc: &infravirtrigaudiov1beta1.CloudInitDocument{
+			SecretRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "absent"},
+		}, wantErr: `fetching network-config secret "absent"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.resolveCloudInitDocument(context.Background(), "default", "network-config", tc.doc, networkConfigSecretKeys)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildCreateRequest_NetworkConfigAndVendorData(t *testing.T) {
+	secret := makeSecret("org-vendor-data", "default", map[string][]byte{
+		"vendor-data": []byte("#cloud-config\nntp:\n  servers: [ntp.example.com]"),
+	})
+	r := reconcilerWithSecrets(t, secret)
+
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "p"},
+			ClassRef:    infravirtrigaudiov1beta1.ObjectRef{Name: "c"},
+			UserData: &infravirtrigaudiov1beta1.UserData{
+				NetworkConfig: &infravirtrigaudiov1beta1.CloudInitDocument{
+					Inline: "version: 2\nethernets:\n  eth0:\n    addresses: [10.0.0.5/24]",
+				},
+				VendorData: &infravirtrigaudiov1beta1.CloudInitDocument{
+					SecretRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "org-vendor-data"},
+				},
+			},
+		},
+	}
+	vmClass := &infravirtrigaudiov1beta1.VMClass{
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{CPU: 2, Memory: resource.MustParse("4Gi")},
+	}
+
+	req, err := r.buildCreateRequest(context.Background(), vm, "", vmClass, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.UserData == nil {
+		t.Fatal("expected UserData to carry network-config and vendor-data without user-data")
+	}
+	if req.UserData.CloudInitData != "" {
+		t.Errorf("expected no user-data, got %q", req.UserData.CloudInitData)
+	}
+	if !strings.Contains(req.UserData.NetworkConfig, "10.0.0.5/24") {
+		t.Errorf("network-config not passed through: %q", req.UserData.NetworkConfig)
+	}
+	if !strings.Contains(req.UserData.VendorData, "ntp.example.com") {
+		t.Errorf("vendor-data not read from the Secret: %q", req.UserData.VendorData)
+	}
+}
+
+func TestBuildCreateRequest_CloudInitDocumentTooLarge(t *testing.T) {
+	secret := makeSecret("big", "default", map[string][]byte{
+		"vendor-data": []byte(strings.Repeat("#", maxCloudInitDocumentBytes+1)),
+	})
+	r := reconcilerWithSecrets(t, secret)
+
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			UserData: &infravirtrigaudiov1beta1.UserData{
+				VendorData: &infravirtrigaudiov1beta1.CloudInitDocument{
+					SecretRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "big"},
+				},
+			},
+		},
+	}
+	vmClass := &infravirtrigaudiov1beta1.VMClass{
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{CPU: 1, Memory: resource.MustParse("1Gi")},
+	}
+
+	_, err := r.buildCreateRequest(context.Background(), vm, "", vmClass, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "vendor-data is 524289 bytes") {
+		t.Fatalf("expected a size error for vendor-data, got %v", err)
+	}
+}
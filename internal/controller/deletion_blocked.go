@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// unreachableDeletion describes a deletion attempt that could not reach the
+// provider.
+type unreachableDeletion struct {
+	// obj is the object being deleted and conditions its status
+	// conditions.
+	obj        client.Object
+	conditions *[]metav1.Condition
+	// providerGone is set when the Provider CR no longer exists.
+	providerGone bool
+	// optedIn is set when the object, or the VM it belongs to, carries
+	// virtrigaud.io/abandon-on-provider-loss: "true".
+	optedIn bool
+	// cause is why the provider could not be reached.
+	cause error
+	// retry is how long the caller waits before trying again. Attempts
+	// closer together than that are counted once.
+	retry time.Duration
+	// emit records an event on obj.
+	emit func(eventType, reason, message string)
+}
+
+// handleUnreachableDeletion counts a deletion attempt that could not reach
+// the provider. Once the attempts pass the configured thresholds, the
+// deletion is blocked: the DeletionBlocked condition is set, a warning
+// event is emitted, and abandon is returned when the finalizer may be
+// removed, which needs the object to opt in or its Provider to be gone.
+// The caller writes the status when it keeps the finalizer.
+func handleUnreachableDeletion(
+	ctx context.Context,
+	c client.Client,
+	policy config.DeletionConfig,
+	d unreachableDeletion,
+) (abandon bool, err error) {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	run, err := k8sutil.RecordUnreachableDeletion(ctx, c, d.obj, now, d.retry)
+	if err != nil {
+		return false, fmt.Errorf("recording unreachable deletion attempt: %w", err)
+	}
+	if !run.Blocked(policy.BlockedAttempts, policy.BlockedAfter.Duration, now) {
+		logger.Info("Provider unreachable during deletion; retrying",
+			"attempts", run.Attempts, "since", run.Since, "error", d.cause.Error())
+		return false, nil
+	}
+
+	summary := fmt.Sprintf("Provider unreachable for %d deletion attempts since %s: %v",
+		run.Attempts, run.Since.UTC().Format(time.RFC3339), d.cause)
+	if d.providerGone || d.optedIn {
+		why := "the Provider no longer exists"
+		if !d.providerGone {
+			why = k8sutil.AbandonOnProviderLossAnnotation + " is set"
+		}
+		message := fmt.Sprintf("%s; removing the finalizer because %s. The hypervisor object may be left behind", summary, why)
+		logger.Info("Abandoning blocked deletion", "attempts", run.Attempts, "reason", why)
+		k8sutil.SetCondition(d.conditions, k8sutil.ConditionDeletionBlocked, metav1.ConditionTrue,
+			k8sutil.ReasonFinalizerAbandoned, message)
+		d.emit(corev1.EventTypeWarning, events.ReasonDeletionBlocked, message)
+		return true, nil
+	}
+
+	message := fmt.Sprintf("%s; set annotation %s: \"true\" to remove the finalizer and leave the hypervisor object behind",
+		summary, k8sutil.AbandonOnProviderLossAnnotation)
+	if !k8sutil.IsConditionTrue(*d.conditions, k8sutil.ConditionDeletionBlocked) {
+		d.emit(corev1.EventTypeWarning, events.ReasonDeletionBlocked, message)
+	}
+	logger.Info("Deletion blocked on an unreachable provider", "attempts", run.Attempts, "since", run.Since)
+	k8sutil.SetCondition(d.conditions, k8sutil.ConditionDeletionBlocked, metav1.ConditionTrue,
+		k8sutil.ReasonProviderUnreachable, message)
+	return false, nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// withUnreachableRun marks obj as having already failed attempts deletion
+// attempts against an unreachable provider, the first of them since ago and
+// the last long enough ago for the next one to count.
+func withUnreachableRun(obj metav1.Object, attempts int, since time.Duration) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["virtrigaud.io/deletion-unreachable-attempts"] = strconv.Itoa(attempts)
+	annotations["virtrigaud.io/deletion-unreachable-since"] = time.Now().Add(-since).UTC().Format(time.RFC3339)
+	delete(annotations, "virtrigaud.io/deletion-unreachable-last")
+	obj.SetAnnotations(annotations)
+}
+
+func unreachableErr() error {
+	return contracts.NewRetryableError("connection refused", nil)
+}
+
+// TestHandleDeletion_UnreachableRetriesBeforeThreshold verifies an
+// unreachable provider is retried quietly until the thresholds are reached.
+func TestHandleDeletion_UnreachableRetriesBeforeThreshold(t *testing.T) {
+	ctx := context.Background()
+	prov := &deleteStubProvider{err: unreachableErr()}
+	vm := deletionVM("vm-unreachable")
+	vm.Annotations = map[string]string{k8sutil.AbandonOnProviderLossAnnotation: "true"}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+
+	res, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Greater(t, res.RequeueAfter, time.Duration(0))
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after), "a first failure must not abandon the VM")
+	assert.Equal(t, "1", after.Annotations["virtrigaud.io/deletion-unreachable-attempts"])
+	assert.Nil(t, k8sutil.GetCondition(after.Status.Conditions, k8sutil.ConditionDeletionBlocked))
+}
+
+// TestHandleDeletion_UnreachableCountsSpacedAttempts verifies the reconciles
+// that follow the VM's own tracking and status updates do not count as
+// attempts, so the run only grows once per DeleteRetry.
+func TestHandleDeletion_UnreachableCountsSpacedAttempts(t *testing.T) {
+	ctx := context.Background()
+	prov := &deleteStubProvider{err: unreachableErr()}
+	vm := deletionVM("vm-spaced")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+	key := client.ObjectKeyFromObject(vm)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	for i := 0; i < 10; i++ {
+		_, err := r.handleDeletion(ctx, marked)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(ctx, key, &after))
+		marked = &after
+	}
+	assert.Equal(t, "1", after.Annotations["virtrigaud.io/deletion-unreachable-attempts"])
+	assert.Nil(t, k8sutil.GetCondition(after.Status.Conditions, k8sutil.ConditionDeletionBlocked))
+
+	// Once DeleteRetry has passed since the last counted attempt, the next
+	// one counts.
+	after.Annotations["virtrigaud.io/deletion-unreachable-last"] = time.Now().
+		Add(-r.requeue().DeleteRetry.Duration).UTC().Format(time.RFC3339)
+	require.NoError(t, r.Update(ctx, &after))
+	_, err := r.handleDeletion(ctx, &after)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, key, &after))
+	assert.Equal(t, "2", after.Annotations["virtrigaud.io/deletion-unreachable-attempts"])
+}
+
+// TestHandleDeletion_UnreachableBlocked verifies a deletion past the
+// thresholds keeps the finalizer, reports why and emits a single warning.
+func TestHandleDeletion_UnreachableBlocked(t *testing.T) {
+	ctx := context.Background()
+	prov := &deleteStubProvider{err: unreachableErr()}
+	vm := deletionVM("vm-blocked")
+	withUnreachableRun(vm, 4, 2*time.Hour)
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	marked := markForDeletion(t, r, vm)
+
+	_, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.Contains(t, after.Finalizers, infravirtrigaudiov1beta1.VirtualMachineFinalizer)
+	cond := k8sutil.GetCondition(after.Status.Conditions, k8sutil.ConditionDeletionBlocked)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, k8sutil.ReasonProviderUnreachable, cond.Reason)
+	assert.Contains(t, cond.Message, k8sutil.AbandonOnProviderLossAnnotation)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonDeletionBlocked)
+
+	_, err = r.handleDeletion(ctx, &after)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events, "the warning is emitted once per blocked deletion")
+}
+
+// TestHandleDeletion_UnreachableAbandoned verifies an opted-in VM is
+// released once its deletion is blocked.
+func TestHandleDeletion_UnreachableAbandoned(t *testing.T) {
+	ctx := context.Background()
+	prov := &deleteStubProvider{err: unreachableErr()}
+	vm := deletionVM("vm-abandoned")
+	withUnreachableRun(vm, 4, 2*time.Hour)
+	vm.Annotations[k8sutil.AbandonOnProviderLossAnnotation] = "true"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+
+	_, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	getErr := r.Get(ctx, client.ObjectKeyFromObject(vm), &after)
+	assert.True(t, apierrors.IsNotFound(getErr), "an opted-in VM must be released once blocked")
+}
+
+// TestHandleDeletion_ResolveFailureKeepsFinalizer verifies a provider that
+// cannot be resolved does not orphan the hypervisor VM: the attempt counts
+// as unreachable, and an opted-in VM is released once blocked.
+func TestHandleDeletion_ResolveFailureKeepsFinalizer(t *testing.T) {
+	ctx := context.Background()
+	resolver := &stubResolver{err: errors.New("dial tcp: connection refused")}
+	vm := deletionVM("vm-unresolved")
+	r := newTestReconciler(coverageTestScheme(t), resolver, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+	key := client.ObjectKeyFromObject(vm)
+
+	res, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Equal(t, r.requeue().DeleteRetry.Duration, res.RequeueAfter)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, key, &after), "a resolve failure must not release the VM")
+	assert.Contains(t, after.Finalizers, infravirtrigaudiov1beta1.VirtualMachineFinalizer)
+	assert.Equal(t, "1", after.Annotations["virtrigaud.io/deletion-unreachable-attempts"])
+
+	withUnreachableRun(&after, 4, 2*time.Hour)
+	after.Annotations[k8sutil.AbandonOnProviderLossAnnotation] = "true"
+	require.NoError(t, r.Update(ctx, &after))
+	_, err = r.handleDeletion(ctx, &after)
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, key, &after)), "an opted-in VM must be released once blocked")
+}
+
+// TestHandleDeletion_ProviderAnswerResetsRun verifies a provider that
+// answers, even with an error, starts the run of attempts over.
+func TestHandleDeletion_ProviderAnswerResetsRun(t *testing.T) {
+	ctx := context.Background()
+	prov := &deleteStubProvider{err: contracts.NewInvalidSpecError("VM 100 is running", nil)}
+	vm := deletionVM("vm-answered")
+	withUnreachableRun(vm, 3, 30*time.Minute)
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+
+	_, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.NotContains(t, after.Annotations, "virtrigaud.io/deletion-unreachable-attempts")
+}
+
+// TestVMSnapshotDeletion_ProviderGone verifies a snapshot whose Provider was
+// deleted is kept until its deletion is blocked, then released.
+func TestVMSnapshotDeletion_ProviderGone(t *testing.T) {
+	ctx := context.Background()
+	sch := coverageTestScheme(t)
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.VirtualMachineSpec{ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "gone"}},
+	}
+	vm.Status.ID = "100"
+	snapshot := &infravirtrigaudiov1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "nightly",
+			Namespace:  "default",
+			Finalizers: []string{infravirtrigaudiov1beta1.VMSnapshotFinalizer},
+		},
+		Spec: infravirtrigaudiov1beta1.VMSnapshotSpec{VMRef: infravirtrigaudiov1beta1.LocalObjectReference{Name: "web"}},
+	}
+	snapshot.Status.SnapshotID = "snap-1"
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm, snapshot).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VMSnapshot{}).
+		Build()
+	r := &VMSnapshotReconciler{Client: cli, Scheme: sch, RemoteResolver: &stubResolver{provider: &stubProvider{}}}
+	require.NoError(t, cli.Delete(ctx, snapshot))
+	key := client.ObjectKeyFromObject(snapshot)
+
+	var marked infravirtrigaudiov1beta1.VMSnapshot
+	require.NoError(t, cli.Get(ctx, key, &marked))
+	_, err := r.handleDeletion(ctx, &marked)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, key, &marked), "the first attempt must keep the finalizer")
+
+	withUnreachableRun(&marked, 4, 2*time.Hour)
+	require.NoError(t, cli.Update(ctx, &marked))
+	_, err = r.handleDeletion(ctx, &marked)
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, key, &marked)), "a blocked snapshot of a deleted Provider must be released")
+}
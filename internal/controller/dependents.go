@@ -0,0 +1,237 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// Field indexes over the references between resources, so that a change to
+// a referenced object maps to the objects that reference it with one cache
+// lookup. Values are the "namespace/name" of the referenced object.
+const (
+	vmClassRefIndex    = "spec.classRef"
+	vmImageRefIndex    = "spec.imageRef"
+	vmProviderRefIndex = "spec.providerRef"
+
+	snapshotVMRefIndex = "spec.vmRef"
+
+	cloneSourceVMIndex       = "spec.source.vmRef"
+	cloneSourceSnapshotIndex = "spec.source.snapshotRef"
+
+	publishSourceVMIndex = "spec.vmRef"
+
+	migrationSourceVMIndex = "spec.source.vmRef"
+	// migrationProviderIndex holds both the source provider, when given,
+	// and the target provider.
+	migrationProviderIndex = "spec.providerRefs"
+)
+
+// Enqueues beyond dependentsBurst for one change are spread over
+// dependentsSpread, so that editing a class used by a thousand VMs does
+// not start a thousand reconciles at once.
+const (
+	dependentsBurst  = 10
+	dependentsSpread = 30 * time.Second
+)
+
+// indexFields registers field indexes on obj's kind with mgr's cache.
+func indexFields(mgr ctrl.Manager, obj client.Object, indexes map[string]client.IndexerFunc) error {
+	for field, fn := range indexes {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), obj, field, fn); err != nil {
+			return fmt.Errorf("indexing %T by %s: %w", obj, field, err)
+		}
+	}
+	return nil
+}
+
+func refIndexValue(ref infrav1beta1.ObjectRef, fromNamespace string) []string {
+	return []string{k8sutil.RefKey(ref, fromNamespace).String()}
+}
+
+func localRefIndexValue(ref *infrav1beta1.LocalObjectReference, namespace string) []string {
+	if ref == nil || ref.Name == "" {
+		return nil
+	}
+	return []string{types.NamespacedName{Namespace: namespace, Name: ref.Name}.String()}
+}
+
+func indexVMClassRef(obj client.Object) []string {
+	vm := obj.(*infrav1beta1.VirtualMachine)
+	return refIndexValue(vm.Spec.ClassRef, vm.Namespace)
+}
+
+func indexVMImageRef(obj client.Object) []string {
+	vm := obj.(*infrav1beta1.VirtualMachine)
+	if vm.Spec.ImageRef == nil {
+		return nil
+	}
+	return refIndexValue(*vm.Spec.ImageRef, vm.Namespace)
+}
+
+func indexVMProviderRef(obj client.Object) []string {
+	vm := obj.(*infrav1beta1.VirtualMachine)
+	return refIndexValue(vm.Spec.ProviderRef, vm.Namespace)
+}
+
+func indexSnapshotVMRef(obj client.Object) []string {
+	snapshot := obj.(*infrav1beta1.VMSnapshot)
+	return localRefIndexValue(&snapshot.Spec.VMRef, snapshot.Namespace)
+}
+
+func indexCloneSourceVM(obj client.Object) []string {
+	clone := obj.(*infrav1beta1.VMClone)
+	return localRefIndexValue(clone.Spec.Source.VMRef, clone.Namespace)
+}
+
+func indexCloneSourceSnapshot(obj client.Object) []string {
+	clone := obj.(*infrav1beta1.VMClone)
+	return localRefIndexValue(clone.Spec.Source.SnapshotRef, clone.Namespace)
+}
+
+func indexPublishSourceVM(obj client.Object) []string {
+	pub := obj.(*infrav1beta1.VMImagePublish)
+	return localRefIndexValue(&pub.Spec.VMRef, pub.Namespace)
+}
+
+func indexMigrationSourceVM(obj client.Object) []string {
+	migration := obj.(*infrav1beta1.VMMigration)
+	return localRefIndexValue(&migration.Spec.Source.VMRef, migration.Namespace)
+}
+
+func indexMigrationProviders(obj client.Object) []string {
+	migration := obj.(*infrav1beta1.VMMigration)
+	keys := refIndexValue(migration.Spec.Target.ProviderRef, migration.Namespace)
+	if ref := migration.Spec.Source.ProviderRef; ref != nil {
+		if source := refIndexValue(*ref, migration.Namespace); source[0] != keys[0] {
+			keys = append(keys, source...)
+		}
+	}
+	return keys
+}
+
+// referencingRequests lists the objects of list's kind whose index field
+// names obj and returns a request for each.
+func referencingRequests(ctx context.Context, c client.Reader, list client.ObjectList, index string, obj client.Object) []reconcile.Request {
+	if err := c.List(ctx, list, client.MatchingFields{index: client.ObjectKeyFromObject(obj).String()}); err != nil {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if o, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+		}
+	}
+	return requests
+}
+
+// enqueueDependents is handler.EnqueueRequestsFromMapFunc with the
+// requests of one event past dependentsBurst added after a random delay of
+// up to dependentsSpread. The workqueue dedupes a request that is already
+// waiting.
+func enqueueDependents(fn handler.MapFunc) handler.EventHandler {
+	return jitteredHandler{inner: handler.EnqueueRequestsFromMapFunc(fn)}
+}
+
+type jitteredHandler struct {
+	inner handler.EventHandler
+}
+
+func (h jitteredHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.inner.Create(ctx, e, &jitteredQueue{TypedRateLimitingInterface: q})
+}
+
+func (h jitteredHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.inner.Update(ctx, e, &jitteredQueue{TypedRateLimitingInterface: q})
+}
+
+func (h jitteredHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.inner.Delete(ctx, e, &jitteredQueue{TypedRateLimitingInterface: q})
+}
+
+func (h jitteredHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.inner.Generic(ctx, e, &jitteredQueue{TypedRateLimitingInterface: q})
+}
+
+// jitteredQueue adds its first dependentsBurst requests at once and delays
+// the rest. One is used per event.
+type jitteredQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	added int
+}
+
+func (q *jitteredQueue) Add(req reconcile.Request) {
+	q.added++
+	if q.added <= dependentsBurst {
+		q.TypedRateLimitingInterface.Add(req)
+		return
+	}
+	q.AddAfter(req, rand.N(dependentsSpread))
+}
+
+// skipCreates drops create events, which for a watch on referenced objects
+// only replay the initial list.
+var skipCreates = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+}
+
+// snapshotPhaseChanged passes VMSnapshot updates that change its phase.
+var snapshotPhaseChanged = predicate.And(skipCreates, predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSnap, ok1 := e.ObjectOld.(*infrav1beta1.VMSnapshot)
+		newSnap, ok2 := e.ObjectNew.(*infrav1beta1.VMSnapshot)
+		return ok1 && ok2 && oldSnap.Status.Phase != newSnap.Status.Phase
+	},
+})
+
+// vmProvisioningChanged passes VirtualMachine events that can unblock an
+// object waiting on the VM: it was provisioned or changed phase, or it is
+// going away.
+var vmProvisioningChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldVM, ok1 := e.ObjectOld.(*infrav1beta1.VirtualMachine)
+		newVM, ok2 := e.ObjectNew.(*infrav1beta1.VirtualMachine)
+		if !ok1 || !ok2 {
+			return false
+		}
+		return oldVM.Status.ID != newVM.Status.ID || oldVM.Status.Phase != newVM.Status.Phase ||
+			oldVM.DeletionTimestamp.IsZero() != newVM.DeletionTimestamp.IsZero()
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// recordingQueue records what a handler enqueues.
+type recordingQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	added   []reconcile.Request
+	delayed map[reconcile.Request]time.Duration
+}
+
+func (q *recordingQueue) Add(req reconcile.Request) {
+	q.added = append(q.added, req)
+}
+
+func (q *recordingQueue) AddAfter(req reconcile.Request, d time.Duration) {
+	if q.delayed == nil {
+		q.delayed = map[reconcile.Request]time.Duration{}
+	}
+	q.delayed[req] = d
+}
+
+func (q *recordingQueue) all() []reconcile.Request {
+	all := append([]reconcile.Request(nil), q.added...)
+	for req := range q.delayed {
+		all = append(all, req)
+	}
+	return all
+}
+
+func refVM(namespace, name string, class, image, provider infrav1beta1.ObjectRef) *infrav1beta1.VirtualMachine {
+	vm := &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ClassRef:    class,
+			ProviderRef: provider,
+		},
+	}
+	if image.Name != "" {
+		vm.Spec.ImageRef = &image
+	}
+	return vm
+}
+
+func vmRequest(namespace, name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+func newDependentsClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	return fake.NewClientBuilder().
+		WithScheme(newMetricsScheme(t)).
+		WithObjects(objs...).
+		WithIndex(&infrav1beta1.VirtualMachine{}, vmClassRefIndex, indexVMClassRef).
+		WithIndex(&infrav1beta1.VirtualMachine{}, vmImageRefIndex, indexVMImageRef).
+		WithIndex(&infrav1beta1.VirtualMachine{}, vmProviderRefIndex, indexVMProviderRef).
+		WithIndex(&infrav1beta1.VMSnapshot{}, snapshotVMRefIndex, indexSnapshotVMRef).
+		WithIndex(&infrav1beta1.VMMigration{}, migrationProviderIndex, indexMigrationProviders).
+		Build()
+}
+
+// TestVMClassUpdate_EnqueuesReferencingVMs updates a VMClass and checks
+// that exactly the VMs using it are enqueued, including one in another
+// namespace that names the class's namespace explicitly.
+func TestVMClassUpdate_EnqueuesReferencingVMs(t *testing.T) {
+	small := infrav1beta1.ObjectRef{Name: "small"}
+	large := infrav1beta1.ObjectRef{Name: "large"}
+	pve := infrav1beta1.ObjectRef{Name: "pve"}
+	none := infrav1beta1.ObjectRef{}
+	cli := newDependentsClient(t,
+		refVM("default", "web-1", small, none, pve),
+		refVM("default", "web-2", small, none, pve),
+		refVM("default", "db", large, none, pve),
+		refVM("team-a", "api", infrav1beta1.ObjectRef{Name: "small", Namespace: "default"}, none, pve),
+		// Same class name, but its own namespace's class.
+		refVM("team-b", "cache", small, none, pve),
+	)
+	r := &VirtualMachineReconciler{Client: cli}
+
+	oldClass := &infrav1beta1.VMClass{ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default", Generation: 1}}
+	newClass := oldClass.DeepCopy()
+	newClass.Generation = 2
+	newClass.Spec.CPU = 4
+
+	q := &recordingQueue{}
+	enqueueDependents(r.vmsForClass).Update(context.Background(),
+		event.UpdateEvent{ObjectOld: oldClass, ObjectNew: newClass}, q)
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		vmRequest("default", "web-1"),
+		vmRequest("default", "web-2"),
+		vmRequest("team-a", "api"),
+	}, q.all())
+	assert.Empty(t, q.delayed, "a small fan-out is not delayed")
+}
+
+func TestVMsForImageAndProvider(t *testing.T) {
+	small := infrav1beta1.ObjectRef{Name: "small"}
+	ubuntu := infrav1beta1.ObjectRef{Name: "ubuntu"}
+	cli := newDependentsClient(t,
+		refVM("default", "web", small, ubuntu, infrav1beta1.ObjectRef{Name: "pve"}),
+		refVM("default", "db", small, infrav1beta1.ObjectRef{Name: "debian"}, infrav1beta1.ObjectRef{Name: "vsphere"}),
+		refVM("default", "adopted", small, infrav1beta1.ObjectRef{}, infrav1beta1.ObjectRef{Name: "pve"}),
+	)
+	r := &VirtualMachineReconciler{Client: cli}
+
+	image := &infrav1beta1.VMImage{ObjectMeta: metav1.ObjectMeta{Name: "ubuntu", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{vmRequest("default", "web")}, r.vmsForImage(context.Background(), image))
+
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"}}
+	assert.ElementsMatch(t, []reconcile.Request{vmRequest("default", "web"), vmRequest("default", "adopted")},
+		r.vmsForProvider(context.Background(), provider))
+}
+
+// TestEnqueueDependents_Jitter spreads a large fan-out: the first
+// dependentsBurst requests go in at once, the rest after a random delay.
+func TestEnqueueDependents_Jitter(t *testing.T) {
+	var objs []client.Object
+	for i := range 25 {
+		objs = append(objs, refVM("default", fmt.Sprintf("vm-%02d", i),
+			infrav1beta1.ObjectRef{Name: "shared"}, infrav1beta1.ObjectRef{}, infrav1beta1.ObjectRef{Name: "pve"}))
+	}
+	r := &VirtualMachineReconciler{Client: newDependentsClient(t, objs...)}
+	class := &infrav1beta1.VMClass{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}
+
+	q := &recordingQueue{}
+	enqueueDependents(r.vmsForClass).Update(context.Background(),
+		event.UpdateEvent{ObjectOld: class, ObjectNew: class}, q)
+
+	assert.Len(t, q.added, dependentsBurst)
+	assert.Len(t, q.delayed, 25-dependentsBurst)
+	assert.Len(t, q.all(), 25)
+	for req, d := range q.delayed {
+		assert.GreaterOrEqual(t, d, time.Duration(0), req.Name)
+		assert.Less(t, d, dependentsSpread, req.Name)
+	}
+
+	// The burst is per event.
+	q2 := &recordingQueue{}
+	enqueueDependents(r.vmsForClass).Update(context.Background(),
+		event.UpdateEvent{ObjectOld: class, ObjectNew: class}, q2)
+	assert.Len(t, q2.added, dependentsBurst)
+}
+
+func TestSnapshotsForVM(t *testing.T) {
+	snapshot := func(name, vm string) *infrav1beta1.VMSnapshot {
+		return &infrav1beta1.VMSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: vm}},
+		}
+	}
+	r := &VMSnapshotReconciler{Client: newDependentsClient(t,
+		snapshot("web-nightly", "web"), snapshot("web-weekly", "web"), snapshot("db-nightly", "db"))}
+
+	vm := &infrav1beta1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	assert.ElementsMatch(t, []reconcile.Request{vmRequest("default", "web-nightly"), vmRequest("default", "web-weekly")},
+		r.snapshotsForVM(context.Background(), vm))
+}
+
+// TestMigrationsForProvider maps a provider to migrations from and to it.
+func TestMigrationsForProvider(t *testing.T) {
+	migration := func(name string, source *infrav1beta1.ObjectRef, target string) *infrav1beta1.VMMigration {
+		m := &infrav1beta1.VMMigration{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		m.Spec.Source.VMRef = infrav1beta1.LocalObjectReference{Name: "web"}
+		m.Spec.Source.ProviderRef = source
+		m.Spec.Target.ProviderRef = infrav1beta1.ObjectRef{Name: target}
+		return m
+	}
+	r := &VMMigrationReconciler{Client: newDependentsClient(t,
+		migration("to-pve", &infrav1beta1.ObjectRef{Name: "vsphere"}, "pve"),
+		migration("from-pve", &infrav1beta1.ObjectRef{Name: "pve"}, "libvirt"),
+		migration("auto-source", nil, "libvirt"),
+	)}
+
+	pve := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"}}
+	assert.ElementsMatch(t, []reconcile.Request{vmRequest("default", "to-pve"), vmRequest("default", "from-pve")},
+		r.migrationsForProvider(context.Background(), pve))
+
+	libvirt := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "libvirt", Namespace: "default"}}
+	require.Len(t, r.migrationsForProvider(context.Background(), libvirt), 2)
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Endpoint migration vocabulary. A Provider whose spec.endpoint differs
+// from status.verifiedEndpoint is migrating: its runtime is rolled out
+// with the new endpoint, which is then validated and made to resolve a
+// sample of the Provider's VM IDs. Until that succeeds the
+// ProviderEndpointMigrating condition is True, ProviderAvailable is False,
+// and the VM controller only reads from the provider.
+//
+// Reasons:
+//   - RollingOut: the runtime Deployment still runs pods with the old
+//     endpoint.
+//   - VerificationFailed: the new endpoint did not validate, or some sampled
+//     VMs were not found through it. The message names them.
+//   - Verified: the migration finished (condition False).
+//   - Reverted: spec.endpoint went back to the verified endpoint before the
+//     migration finished (condition False).
+const (
+	providerConditionEndpointMigrating       = "ProviderEndpointMigrating"
+	providerReasonEndpointRollingOut         = "RollingOut"
+	providerReasonEndpointVerificationFailed = "VerificationFailed"
+	providerReasonEndpointVerified           = "Verified"
+	providerReasonEndpointReverted           = "Reverted"
+
+	// ReasonProviderEndpointMigrating marks a VM whose provider is
+	// verifying a new endpoint. The VM is described but not changed.
+	ReasonProviderEndpointMigrating = events.ReasonProviderEndpointMigrating
+
+	// endpointMigrationRecheck is how often a VM held by an endpoint
+	// migration looks again. The VM is also requeued when it ends.
+	endpointMigrationRecheck = 30 * time.Second
+)
+
+// providerEndpointMigrating reports whether provider is verifying a new
+// endpoint, with a message for the VMs it holds.
+func providerEndpointMigrating(provider *infravirtrigaudiov1beta1.Provider) (string, bool) {
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+	if c == nil || c.Status != metav1.ConditionTrue {
+		return "", false
+	}
+	message := fmt.Sprintf("provider %s is migrating to a new endpoint; VM changes are paused", provider.Name)
+	if c.Message != "" {
+		message += ": " + c.Message
+	}
+	return message, true
+}
+
+// reconcileEndpointMigration verifies a changed spec.endpoint once the
+// runtime has rolled out with it. It returns true while the migration is
+// unfinished, with when to look again; the caller must not mark the
+// provider available then.
+func (r *ProviderReconciler) reconcileEndpointMigration(
+	ctx context.Context,
+	provider *infravirtrigaudiov1beta1.Provider,
+	deployment *appsv1.Deployment,
+) (time.Duration, bool) {
+	endpoint, verified := hypervisorEndpoint(provider), provider.Status.VerifiedEndpoint
+	if verified == "" {
+		// A new Provider, or one reconciled before endpoints were tracked,
+		// has nothing to migrate from.
+		provider.Status.VerifiedEndpoint = endpoint
+		return 0, false
+	}
+	if verified == endpoint {
+		if _, migrating := providerEndpointMigrating(provider); migrating {
+			k8s.SetCondition(&provider.Status.Conditions, providerConditionEndpointMigrating, metav1.ConditionFalse,
+				providerReasonEndpointReverted, fmt.Sprintf("Endpoint is back to %s", verified))
+		}
+		return 0, false
+	}
+
+	logger := log.FromContext(ctx)
+	if _, migrating := providerEndpointMigrating(provider); !migrating {
+		logger.Info("Provider endpoint changed; verifying it before VMs are changed again", "from", verified, "to", endpoint)
+		events.Emit(ctx, r.Recorder, provider, corev1.EventTypeNormal, events.ReasonProviderEndpointMigrating, "",
+			fmt.Sprintf("Endpoint changed from %s to %s; verifying it", verified, endpoint))
+	}
+
+	if !deploymentRolledOut(deployment) {
+		message := fmt.Sprintf("Waiting for the provider runtime to roll out with endpoint %s", endpoint)
+		k8s.SetCondition(&provider.Status.Conditions, providerConditionEndpointMigrating, metav1.ConditionTrue,
+			providerReasonEndpointRollingOut, message)
+		k8s.SetCondition(&provider.Status.Conditions, "ProviderAvailable", metav1.ConditionFalse,
+			"EndpointMigrating", message)
+		return r.Config.Get().Requeue.ProviderNotReady.Duration, true
+	}
+
+	checked, err := r.verifyEndpoint(ctx, provider)
+	if !r.recordEndpointVerification(ctx, provider, checked, err) {
+		return r.Config.Get().Requeue.ProviderError.Duration, true
+	}
+	return 0, false
+}
+
+// verifyEndpoint validates the provider's connection to its new endpoint
+// and looks up a sample of its VMs there. It returns how many VMs it
+// looked up.
+func (r *ProviderReconciler) verifyEndpoint(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (int, error) {
+	if r.RemoteResolver == nil {
+		return 0, fmt.Errorf("no remote resolver configured")
+	}
+	vmList := &infravirtrigaudiov1beta1.VirtualMachineList{}
+	if err := r.List(ctx, vmList); err != nil {
+		return 0, fmt.Errorf("failed to list VirtualMachines: %w", err)
+	}
+	sample := endpointVerificationSample(vmList.Items, provider, r.Config.Get().EndpointMigration.VerifySampleSize)
+
+	providerInstance, err := r.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		return 0, fmt.Errorf("cannot reach the provider runtime: %w", err)
+	}
+	return len(sample), verifyEndpointSample(ctx, providerInstance, hypervisorEndpoint(provider), sample)
+}
+
+// verifyEndpointSample validates providerInstance and describes each VM
+// of sample. The error names every VM that was not found.
+func verifyEndpointSample(
+	ctx context.Context,
+	providerInstance contracts.Provider,
+	endpoint string,
+	sample []*infravirtrigaudiov1beta1.VirtualMachine,
+) error {
+	if err := providerInstance.Validate(ctx); err != nil {
+		return fmt.Errorf("endpoint %s did not validate: %w", endpoint, err)
+	}
+	var unresolved []string
+	for _, vm := range sample {
+		desc, err := providerInstance.Describe(ctx, vm.Status.ID)
+		switch {
+		case err != nil:
+			unresolved = append(unresolved, fmt.Sprintf("%s/%s (%s: %v)", vm.Namespace, vm.Name, vm.Status.ID, err))
+		case !desc.Exists:
+			unresolved = append(unresolved, fmt.Sprintf("%s/%s (%s: not found)", vm.Namespace, vm.Name, vm.Status.ID))
+		}
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("%d of %d sampled VMs do not resolve through endpoint %s: %s",
+			len(unresolved), len(sample), endpoint, strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+// recordEndpointVerification records the outcome of verifying the
+// provider's new endpoint and reports whether it succeeded. A failure is
+// announced once per distinct message.
+func (r *ProviderReconciler) recordEndpointVerification(
+	ctx context.Context,
+	provider *infravirtrigaudiov1beta1.Provider,
+	checked int,
+	err error,
+) bool {
+	endpoint := hypervisorEndpoint(provider)
+	if err != nil {
+		log.FromContext(ctx).Info("Provider endpoint verification failed", "endpoint", endpoint, "error", err.Error())
+		previous := k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+		if previous == nil || previous.Reason != providerReasonEndpointVerificationFailed || previous.Message != err.Error() {
+			events.Emit(ctx, r.Recorder, provider, corev1.EventTypeWarning, events.ReasonProviderEndpointUnverified, "", err.Error())
+		}
+		k8s.SetCondition(&provider.Status.Conditions, providerConditionEndpointMigrating, metav1.ConditionTrue,
+			providerReasonEndpointVerificationFailed, err.Error())
+		k8s.SetCondition(&provider.Status.Conditions, "ProviderAvailable", metav1.ConditionFalse,
+			"EndpointVerificationFailed", err.Error())
+		return false
+	}
+
+	message := fmt.Sprintf("Endpoint %s validated and resolved %d sampled VMs", endpoint, checked)
+	log.FromContext(ctx).Info("Provider endpoint verified", "from", provider.Status.VerifiedEndpoint, "to", endpoint, "sampledVMs", checked)
+	events.Emit(ctx, r.Recorder, provider, corev1.EventTypeNormal, events.ReasonProviderEndpointVerified, "", message)
+	provider.Status.VerifiedEndpoint = endpoint
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionEndpointMigrating, metav1.ConditionFalse,
+		providerReasonEndpointVerified, message)
+	return true
+}
+
+// endpointVerificationSample returns up to n of provider's VMs that exist on
+// the hypervisor, the first ones by namespace and name.
+func endpointVerificationSample(
+	vms []infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+	n int,
+) []*infravirtrigaudiov1beta1.VirtualMachine {
+	var sample []*infravirtrigaudiov1beta1.VirtualMachine
+	for i := range vms {
+		if vms[i].Status.ID != "" && VMUsesProvider(&vms[i], provider) {
+			sample = append(sample, &vms[i])
+		}
+	}
+	sort.Slice(sample, func(i, j int) bool {
+		if sample[i].Namespace != sample[j].Namespace {
+			return sample[i].Namespace < sample[j].Namespace
+		}
+		return sample[i].Name < sample[j].Name
+	})
+	if len(sample) > n {
+		sample = sample[:n]
+	}
+	return sample
+}
+
+// deploymentRolledOut reports whether every pod of deployment runs its
+// current template, so that no RPC can reach a pod with the old endpoint.
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	want := int32(1)
+	if deployment.Spec.Replicas != nil {
+		want = *deployment.Spec.Replicas
+	}
+	s := deployment.Status
+	return s.ObservedGeneration >= deployment.Generation &&
+		s.UpdatedReplicas == want && s.Replicas == want && s.ReadyReplicas >= want
+}
+
+// gateEndpointMigration stops a VM from being changed while its provider
+// verifies a new endpoint, recording why on the Ready condition. The VM's
+// observed state is still refreshed from Describe, but a VM the provider
+// does not find is left alone: the new endpoint may not be the right one.
+func (r *VirtualMachineReconciler) gateEndpointMigration(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+	providerInstance contracts.Provider,
+) (blocked bool) {
+	message, migrating := providerEndpointMigrating(provider)
+	if !migrating {
+		return false
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("Not changing VM while its provider verifies a new endpoint", "provider", provider.Name)
+	if vm.Status.ID != "" {
+		desc, err := providerInstance.Describe(ctx, vm.Status.ID)
+		switch {
+		case err != nil:
+			logger.V(1).Info("Describe failed during provider endpoint migration", "id", vm.Status.ID, "error", err.Error())
+		case desc.Exists:
+			vm.Status.PowerState = infravirtrigaudiov1beta1.PowerState(desc.PowerState)
+			setStatusAddresses(vm, vmAddresses(desc.IPs, desc.Addresses))
+			vm.Status.ConsoleURL = desc.ConsoleURL
+		}
+	}
+	k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonProviderEndpointMigrating, message)
+	r.updateStatus(ctx, vm)
+	return true
+}
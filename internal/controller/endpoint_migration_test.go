@@ -0,0 +1,233 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// rolledOutDeployment returns a Deployment whose single pod runs its
+// current template.
+func rolledOutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1,
+		},
+	}
+}
+
+// unvalidatedProvider is a provider whose Validate fails.
+type unvalidatedProvider struct {
+	stubProvider
+	err error
+}
+
+func (p *unvalidatedProvider) Validate(_ context.Context) error { return p.err }
+
+// TestReconcileEndpointMigration walks a Provider through an endpoint
+// change that cannot be verified, and back.
+func TestReconcileEndpointMigration(t *testing.T) {
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(8)
+	r := &ProviderReconciler{Recorder: recorder} // RemoteResolver is nil
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcenter", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.ProviderSpec{Endpoint: "https://vc-old.example.com"},
+	}
+
+	_, migrating := r.reconcileEndpointMigration(ctx, provider, rolledOutDeployment())
+	assert.False(t, migrating, "a first endpoint has nothing to migrate from")
+	assert.Equal(t, "https://vc-old.example.com", provider.Status.VerifiedEndpoint)
+	assert.Nil(t, k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating))
+
+	provider.Spec.Endpoint = "https://vc-new.example.com"
+	rollingOut := rolledOutDeployment()
+	rollingOut.Generation = 3
+	_, migrating = r.reconcileEndpointMigration(ctx, provider, rollingOut)
+	require.True(t, migrating)
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, providerReasonEndpointRollingOut, c.Reason)
+	assert.False(t, k8s.IsConditionTrue(provider.Status.Conditions, "ProviderAvailable"))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonProviderEndpointMigrating)
+
+	_, migrating = r.reconcileEndpointMigration(ctx, provider, rolledOutDeployment())
+	require.True(t, migrating)
+	c = k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+	assert.Equal(t, providerReasonEndpointVerificationFailed, c.Reason)
+	assert.Equal(t, "https://vc-old.example.com", provider.Status.VerifiedEndpoint, "an unverified endpoint is not recorded")
+	require.Len(t, recorder.Events, 1, "the migration start is announced once")
+	assert.Contains(t, <-recorder.Events, "ProviderEndpointUnverified")
+
+	_, migrating = r.reconcileEndpointMigration(ctx, provider, rolledOutDeployment())
+	require.True(t, migrating)
+	assert.Empty(t, recorder.Events, "the same failure is announced once")
+
+	provider.Spec.Endpoint = "https://vc-old.example.com"
+	_, migrating = r.reconcileEndpointMigration(ctx, provider, rolledOutDeployment())
+	assert.False(t, migrating)
+	c = k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, providerReasonEndpointReverted, c.Reason)
+}
+
+func TestRecordEndpointVerification(t *testing.T) {
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(4)
+	r := &ProviderReconciler{Recorder: recorder}
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.ProviderSpec{Endpoint: "https://pve-lb.example.com:8006"},
+		Status:     infravirtrigaudiov1beta1.ProviderStatus{VerifiedEndpoint: "https://pve1.example.com:8006"},
+	}
+
+	require.True(t, r.recordEndpointVerification(ctx, provider, 3, nil))
+	assert.Equal(t, "https://pve-lb.example.com:8006", provider.Status.VerifiedEndpoint)
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionEndpointMigrating)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, providerReasonEndpointVerified, c.Reason)
+	assert.Contains(t, c.Message, "resolved 3 sampled VMs")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "ProviderEndpointVerified")
+	_, migrating := providerEndpointMigrating(provider)
+	assert.False(t, migrating)
+}
+
+func TestVerifyEndpointSample(t *testing.T) {
+	ctx := context.Background()
+	vm := func(name, id string) *infravirtrigaudiov1beta1.VirtualMachine {
+		return &infravirtrigaudiov1beta1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+			Status:     infravirtrigaudiov1beta1.VirtualMachineStatus{ID: id},
+		}
+	}
+	sample := []*infravirtrigaudiov1beta1.VirtualMachine{vm("db", "vm-101"), vm("web", "vm-102"), vm("cache", "vm-103")}
+	provider := &fakeDescribeProvider{DescribeFn: func(_ context.Context, id string) (contracts.DescribeResponse, error) {
+		switch id {
+		case "vm-101":
+			return contracts.DescribeResponse{Exists: true}, nil
+		case "vm-102":
+			return contracts.DescribeResponse{}, nil
+		default:
+			return contracts.DescribeResponse{}, errors.New("no node hosts VM 103")
+		}
+	}}
+
+	err := verifyEndpointSample(ctx, provider, "https://pve-lb:8006", sample)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 3 sampled VMs")
+	assert.Contains(t, err.Error(), "apps/web (vm-102: not found)")
+	assert.Contains(t, err.Error(), "apps/cache (vm-103: no node hosts VM 103)")
+	assert.NotContains(t, err.Error(), "apps/db")
+
+	assert.NoError(t, verifyEndpointSample(ctx, provider, "https://pve-lb:8006", sample[:1]))
+	assert.NoError(t, verifyEndpointSample(ctx, provider, "https://pve-lb:8006", nil), "a provider without VMs only validates")
+
+	err = verifyEndpointSample(ctx, &unvalidatedProvider{err: errors.New("x509: certificate is valid for vc-old")}, "https://vc-new", sample)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "endpoint https://vc-new did not validate")
+}
+
+func TestEndpointVerificationSample(t *testing.T) {
+	provider := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "vcenter", Namespace: "infra"}}
+	vm := func(ns, name, providerName, id string) infravirtrigaudiov1beta1.VirtualMachine {
+		return infravirtrigaudiov1beta1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+				ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: providerName, Namespace: "infra"},
+			},
+			Status: infravirtrigaudiov1beta1.VirtualMachineStatus{ID: id},
+		}
+	}
+	vms := []infravirtrigaudiov1beta1.VirtualMachine{
+		vm("team-b", "api", "vcenter", "vm-3"),
+		vm("team-a", "web", "vcenter", "vm-2"),
+		vm("team-a", "db", "vcenter", "vm-1"),
+		vm("team-a", "new", "vcenter", ""),
+		vm("team-a", "other", "pve", "104"),
+	}
+
+	sample := endpointVerificationSample(vms, provider, 2)
+	require.Len(t, sample, 2)
+	assert.Equal(t, "db", sample[0].Name)
+	assert.Equal(t, "web", sample[1].Name)
+
+	assert.Len(t, endpointVerificationSample(vms, provider, 10), 3, "VMs without an ID or on another provider are not sampled")
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	assert.True(t, deploymentRolledOut(rolledOutDeployment()))
+
+	stale := rolledOutDeployment()
+	stale.Generation = 3
+	assert.False(t, deploymentRolledOut(stale), "the Deployment controller has not seen the new template")
+
+	surging := rolledOutDeployment()
+	surging.Status.Replicas = 2
+	assert.False(t, deploymentRolledOut(surging), "an old pod is still running")
+}
+
+// TestGateEndpointMigration verifies a VM is described but held while its
+// provider verifies a new endpoint, and that a VM the endpoint does not
+// find keeps its ID.
+func TestGateEndpointMigration(t *testing.T) {
+	ctx := context.Background()
+	s := cloudInitScheme(t)
+	vm := baseVM("default")
+	vm.Status.ID = "vm-42"
+	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerStateOff
+	fc := fake.NewClientBuilder().WithScheme(s).WithObjects(vm).WithStatusSubresource(vm).Build()
+	r := &VirtualMachineReconciler{Client: fc, Scheme: s}
+	provider := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "test-prov", Namespace: "default"}}
+	exists := true
+	instance := &fakeDescribeProvider{DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+		return contracts.DescribeResponse{Exists: exists, PowerState: "On"}, nil
+	}}
+
+	assert.False(t, r.gateEndpointMigration(ctx, vm, provider, instance), "a provider that is not migrating does not hold VMs")
+
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionEndpointMigrating, metav1.ConditionTrue,
+		providerReasonEndpointVerificationFailed, "1 of 1 sampled VMs do not resolve through endpoint https://vc-new: default/test-vm")
+	require.True(t, r.gateEndpointMigration(ctx, vm, provider, instance))
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, vm.Status.PowerState, "reads still refresh the status")
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonProviderEndpointMigrating, ready.Reason)
+	assert.Contains(t, ready.Message, "do not resolve through endpoint https://vc-new")
+
+	exists = false
+	require.True(t, r.gateEndpointMigration(ctx, vm, provider, instance))
+	assert.Equal(t, "vm-42", vm.Status.ID, "a VM the new endpoint cannot find is not forgotten")
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, vm.Status.PowerState)
+}
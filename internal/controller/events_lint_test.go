@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventReasonArg is the index of the reason argument for every function
+// that records an event, keyed by function name.
+var eventReasonArg = map[string]int{
+	"Event":           2, // record.EventRecorder.Event(obj, type, reason, msg)
+	"Eventf":          2,
+	"AnnotatedEventf": 3,
+	"Emit":            4, // events.Emit(ctx, recorder, obj, type, reason, ...)
+	"recordEvent":     3, // reconciler helpers: (ctx, obj, type, reason, msg)
+	"event":           3,
+}
+
+// TestEventReasonsComeFromTaxonomy fails when a controller records an event
+// with a reason that is not one of the internal/events constants: a string
+// literal, or a package-level constant holding one.
+func TestEventReasonsComeFromTaxonomy(t *testing.T) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		files = append(files, f)
+	}
+	require.NotEmpty(t, files)
+
+	// Package-level constants and variables initialised from a literal
+	// rather than from the events package.
+	literalNames := map[string]bool{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i < len(vs.Values) && !fromEventsPackage(vs.Values[i]) {
+						literalNames[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	var violations []string
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			idx, ok := eventReasonArg[calledName(call)]
+			if !ok || idx >= len(call.Args) {
+				return true
+			}
+			switch arg := call.Args[idx].(type) {
+			case *ast.BasicLit:
+				violations = append(violations, fset.Position(arg.Pos()).String()+": literal reason "+arg.Value)
+			case *ast.BinaryExpr:
+				violations = append(violations, fset.Position(arg.Pos()).String()+": computed reason")
+			case *ast.Ident:
+				if literalNames[arg.Name] {
+					violations = append(violations, fset.Position(arg.Pos()).String()+": reason "+arg.Name+" is not from internal/events")
+				}
+			}
+			return true
+		})
+	}
+	assert.Empty(t, violations, "event reasons must be internal/events constants")
+}
+
+func calledName(call *ast.CallExpr) string {
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+func fromEventsPackage(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "events"
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// DefaultGuestStatsInterval is how often GuestStatsCollector samples VMs
+// when no Interval is set.
+const DefaultGuestStatsInterval = 60 * time.Second
+
+// GuestStatsCollector periodically describes every provisioned
+// VirtualMachine and publishes the guest stats its provider reports as the
+// virtrigaud_vm_guest_* gauges, for consumption by a Prometheus adapter
+// serving the custom metrics API. It runs on its own interval rather than
+// from the reconcile loop so sampling frequency does not depend on how
+// often VMs happen to be reconciled.
+type GuestStatsCollector struct {
+	Client         client.Client
+	RemoteResolver ProviderResolver
+	// Interval overrides DefaultGuestStatsInterval.
+	Interval time.Duration
+
+	// published is the label set of every series set by the last pass, so
+	// series of VMs that are gone or stopped reporting can be dropped.
+	published map[guestStatsKey]guestStatsSeries
+}
+
+type guestStatsKey struct {
+	vm           types.NamespacedName
+	providerType string
+}
+
+type guestStatsSeries struct {
+	cpu, memory bool
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader samples, so replicas do not multiply the Describe load on
+// providers or export conflicting series.
+func (c *GuestStatsCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (c *GuestStatsCollector) Start(ctx context.Context) error {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = DefaultGuestStatsInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.collect(ctx)
+		select {
+		case <-ctx.Done():
+			c.publish(nil)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect samples every VM once and publishes the result. The VMs of one
+// provider are described with a single DescribeBatch call rather than one
+// Describe each.
+func (c *GuestStatsCollector) collect(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("guest-stats")
+
+	var vms infravirtrigaudiov1beta1.VirtualMachineList
+	if err := c.Client.List(ctx, &vms); err != nil {
+		log.Error(err, "Failed to list VirtualMachines")
+		return
+	}
+
+	var order []client.ObjectKey
+	byProvider := map[client.ObjectKey][]*infravirtrigaudiov1beta1.VirtualMachine{}
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		if vm.Status.ID == "" || !vm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace)
+		if _, seen := byProvider[key]; !seen {
+			order = append(order, key)
+		}
+		byProvider[key] = append(byProvider[key], vm)
+	}
+
+	samples := map[guestStatsKey]*contracts.GuestStats{}
+	for _, key := range order {
+		provider := &infravirtrigaudiov1beta1.Provider{}
+		if err := c.Client.Get(ctx, key, provider); err != nil {
+			log.V(1).Info("Provider unavailable, skipping its VMs", "provider", key, "error", err.Error())
+			continue
+		}
+		members := byProvider[key]
+		described, err := c.describe(ctx, provider, members)
+		if err != nil {
+			log.V(1).Info("Failed to describe the provider's VMs", "provider", key, "vms", len(members), "error", err.Error())
+			continue
+		}
+		for _, vm := range members {
+			if err := described.Errors[vm.Status.ID]; err != nil {
+				log.V(1).Info("Failed to describe VM", "vm", client.ObjectKeyFromObject(vm), "error", err.Error())
+				continue
+			}
+			desc, ok := described.Results[vm.Status.ID]
+			if !ok || !desc.Exists || desc.GuestStats == nil {
+				continue
+			}
+			samples[guestStatsKey{vm: client.ObjectKeyFromObject(vm), providerType: string(provider.Spec.Type)}] = desc.GuestStats
+		}
+	}
+	c.publish(samples)
+}
+
+func (c *GuestStatsCollector) describe(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, vms []*infravirtrigaudiov1beta1.VirtualMachine) (contracts.DescribeBatchResponse, error) {
+	p, err := c.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		return contracts.DescribeBatchResponse{}, err
+	}
+	ids := make([]string, 0, len(vms))
+	for _, vm := range vms {
+		ids = append(ids, vm.Status.ID)
+	}
+	return contracts.DescribeMany(ctx, p, ids)
+}
+
+// publish sets the gauges from samples and deletes every series the
+// previous pass set that this one did not.
+func (c *GuestStatsCollector) publish(samples map[guestStatsKey]*contracts.GuestStats) {
+	published := make(map[guestStatsKey]guestStatsSeries, len(samples))
+	for key, stats := range samples {
+		var series guestStatsSeries
+		if stats.CPUUsagePercent != nil {
+			metrics.SetVMGuestCPUUsage(key.vm.Namespace, key.vm.Name, key.providerType, *stats.CPUUsagePercent)
+			series.cpu = true
+		}
+		if stats.MemoryUsageBytes != nil {
+			metrics.SetVMGuestMemoryUsage(key.vm.Namespace, key.vm.Name, key.providerType, float64(*stats.MemoryUsageBytes))
+			series.memory = true
+		}
+		published[key] = series
+	}
+	for key, prev := range c.published {
+		cur := published[key]
+		if prev.cpu && !cur.cpu {
+			metrics.DeleteVMGuestCPUUsage(key.vm.Namespace, key.vm.Name, key.providerType)
+		}
+		if prev.memory && !cur.memory {
+			metrics.DeleteVMGuestMemoryUsage(key.vm.Namespace, key.vm.Name, key.providerType)
+		}
+	}
+	c.published = published
+}
+
+// GuestStatsStatus converts a provider's guest stats sample, taken at now,
+// to its VirtualMachine status form. It returns nil when nothing was
+// reported.
+func GuestStatsStatus(stats *contracts.GuestStats, now time.Time) *infravirtrigaudiov1beta1.VMGuestStats {
+	if stats == nil || (stats.CPUUsagePercent == nil && stats.MemoryUsageBytes == nil && stats.UptimeSeconds == nil) {
+		return nil
+	}
+	out := &infravirtrigaudiov1beta1.VMGuestStats{
+		MemoryUsageBytes: stats.MemoryUsageBytes,
+		UptimeSeconds:    stats.UptimeSeconds,
+		ObservedAt:       metav1.NewTime(now),
+	}
+	if stats.CPUUsagePercent != nil {
+		cpu := int32(math.Round(*stats.CPUUsagePercent))
+		out.CPUUsagePercent = &cpu
+	}
+	return out
+}
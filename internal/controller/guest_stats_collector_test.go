@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// guestStatsGauge returns the value of a guest stats gauge for a VM, and
+// false when the series does not exist.
+func guestStatsGauge(t *testing.T, metric, namespace, name string) (float64, bool) {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != metric {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if labelsMatch(m.GetLabel(), map[string]string{"namespace": namespace, "name": name}) {
+				return m.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestGuestStatsCollector(t *testing.T) {
+	const ns = "guest-stats"
+	prov, _ := providerAndClass(ns)
+	prov.Spec.Type = infravirtrigaudiov1beta1.ProviderTypeLibvirt
+
+	running := baseVM(ns)
+	running.Name = "running"
+	running.Status.ID = "vm-running"
+	memOnly := baseVM(ns)
+	memOnly.Name = "mem-only"
+	memOnly.Status.ID = "vm-mem-only"
+	pending := baseVM(ns)
+	pending.Name = "pending"
+
+	cpu, mem := 42.0, int64(3<<30)
+	stats := map[string]*contracts.GuestStats{
+		"vm-running":  {CPUUsagePercent: &cpu, MemoryUsageBytes: &mem},
+		"vm-mem-only": {MemoryUsageBytes: &mem},
+	}
+	var described []string
+	provider := &fakeDescribeProvider{DescribeFn: func(_ context.Context, id string) (contracts.DescribeResponse, error) {
+		described = append(described, id)
+		return contracts.DescribeResponse{Exists: true, PowerState: "On", GuestStats: stats[id]}, nil
+	}}
+
+	fc := fake.NewClientBuilder().WithScheme(coverageTestScheme(t)).
+		WithObjects(prov, running, memOnly, pending).Build()
+	c := &GuestStatsCollector{Client: fc, RemoteResolver: &stubResolver{provider: provider}}
+
+	c.collect(context.Background())
+	assert.ElementsMatch(t, []string{"vm-running", "vm-mem-only"}, described, "VMs without an ID are not described")
+
+	v, ok := guestStatsGauge(t, "virtrigaud_vm_guest_cpu_usage_percent", ns, "running")
+	require.True(t, ok)
+	assert.Equal(t, 42.0, v)
+	v, ok = guestStatsGauge(t, "virtrigaud_vm_guest_memory_usage_bytes", ns, "running")
+	require.True(t, ok)
+	assert.Equal(t, float64(3<<30), v)
+	_, ok = guestStatsGauge(t, "virtrigaud_vm_guest_cpu_usage_percent", ns, "mem-only")
+	assert.False(t, ok, "unreported fields have no series")
+
+	// A VM that stops reporting loses its series.
+	delete(stats, "vm-running")
+	c.collect(context.Background())
+	_, ok = guestStatsGauge(t, "virtrigaud_vm_guest_cpu_usage_percent", ns, "running")
+	assert.False(t, ok)
+	_, ok = guestStatsGauge(t, "virtrigaud_vm_guest_memory_usage_bytes", ns, "mem-only")
+	assert.True(t, ok)
+
+	// Stopping the collector drops everything it published.
+	c.publish(nil)
+	_, ok = guestStatsGauge(t, "virtrigaud_vm_guest_memory_usage_bytes", ns, "mem-only")
+	assert.False(t, ok)
+}
+
+func TestGuestStatsStatus(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Nil(t, GuestStatsStatus(nil, now))
+	assert.Nil(t, GuestStatsStatus(&contracts.GuestStats{}, now))
+
+	cpu, mem, uptime := 37.6, int64(1<<30), int64(3600)
+	got := GuestStatsStatus(&contracts.GuestStats{CPUUsagePercent: &cpu, MemoryUsageBytes: &mem, UptimeSeconds: &uptime}, now)
+	require.NotNil(t, got)
+	require.NotNil(t, got.CPUUsagePercent)
+	assert.Equal(t, int32(38), *got.CPUUsagePercent)
+	assert.Equal(t, &mem, got.MemoryUsageBytes)
+	assert.Equal(t, &uptime, got.UptimeSeconds)
+	assert.True(t, got.ObservedAt.Time.Equal(now))
+
+	got = GuestStatsStatus(&contracts.GuestStats{MemoryUsageBytes: &mem}, now)
+	require.NotNil(t, got)
+	assert.Nil(t, got.CPUUsagePercent, "an unreported figure stays unset rather than zero")
+}
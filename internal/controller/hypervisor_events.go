@@ -0,0 +1,475 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// DefaultHypervisorAlarmTimeout is how long a HypervisorAlarm condition
+// stays set without the provider reporting its resolution, when no
+// AlarmTimeout is set.
+const DefaultHypervisorAlarmTimeout = 30 * time.Minute
+
+const (
+	// hypervisorWatchResync is how often the watched providers are matched
+	// against the Providers advertising event watch, and alarms past their
+	// timeout are cleared.
+	hypervisorWatchResync = 30 * time.Second
+	// An event repeating the reason and message of one recorded on the same
+	// VM within hypervisorEventDedupWindow is dropped.
+	hypervisorEventDedupWindow = 10 * time.Minute
+	// At most hypervisorEventBurst events are recorded per VM per
+	// hypervisorEventRateWindow; alarms beyond it still set the condition.
+	hypervisorEventBurst      = 10
+	hypervisorEventRateWindow = time.Minute
+	// A provider's watch is reopened after a backoff growing from
+	// hypervisorWatchMinBackoff to hypervisorWatchMaxBackoff.
+	hypervisorWatchMinBackoff = 5 * time.Second
+	hypervisorWatchMaxBackoff = 5 * time.Minute
+)
+
+// Condition reasons of HypervisorAlarm once it is cleared. While it is set
+// the reason is the one the provider reported.
+const (
+	hypervisorAlarmResolved = "Resolved"
+	hypervisorAlarmTimedOut = "TimedOut"
+	// hypervisorAlarmOther stands in for a provider reason that is not a
+	// valid condition reason.
+	hypervisorAlarmOther = "HypervisorAlarm"
+)
+
+// conditionReasonPattern is the format metav1.Condition requires of Reason.
+var conditionReasonPattern = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+// HypervisorEventWatcher keeps a WatchEvents stream open to every Provider
+// that reports SupportsEventWatch, and records the events on the
+// VirtualMachines they concern: warnings and errors as HypervisorAlarm
+// Warning events that also set the HypervisorAlarm condition, the rest as
+// Normal HypervisorEvent events. The condition clears when the provider
+// reports the alarm resolved or after AlarmTimeout.
+type HypervisorEventWatcher struct {
+	Client         client.Client
+	RemoteResolver ProviderResolver
+	Recorder       record.EventRecorder
+	// AlarmTimeout overrides DefaultHypervisorAlarmTimeout.
+	AlarmTimeout time.Duration
+
+	// now is time.Now outside tests.
+	now func() time.Time
+
+	mu sync.Mutex
+	// watches are the open watches, by Provider.
+	watches map[types.NamespacedName]*hypervisorWatch
+	// recorded is when each recent event was recorded, for deduplication.
+	recorded map[hypervisorEventKey]time.Time
+	// rates counts the events recorded per VM in the current window.
+	rates map[types.NamespacedName]*hypervisorEventRate
+	// raised is when each VM's alarm was last raised by this process. A
+	// repeated alarm keeps the condition's LastTransitionTime but restarts
+	// the timeout.
+	raised map[types.NamespacedName]time.Time
+}
+
+type hypervisorWatch struct {
+	uid        types.UID
+	generation int64
+	cancel     context.CancelFunc
+}
+
+type hypervisorEventKey struct {
+	vm              types.NamespacedName
+	reason, message string
+}
+
+type hypervisorEventRate struct {
+	start time.Time
+	count int
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader watches, so events are not recorded once per replica.
+func (w *HypervisorEventWatcher) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (w *HypervisorEventWatcher) Start(ctx context.Context) error {
+	w.init()
+	ticker := time.NewTicker(hypervisorWatchResync)
+	defer ticker.Stop()
+	for {
+		w.syncWatches(ctx)
+		w.expireAlarms(ctx)
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			for key, watch := range w.watches {
+				watch.cancel()
+				delete(w.watches, key)
+			}
+			w.mu.Unlock()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *HypervisorEventWatcher) init() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.now == nil {
+		w.now = time.Now
+	}
+	if w.watches == nil {
+		w.watches = map[types.NamespacedName]*hypervisorWatch{}
+		w.recorded = map[hypervisorEventKey]time.Time{}
+		w.rates = map[types.NamespacedName]*hypervisorEventRate{}
+		w.raised = map[types.NamespacedName]time.Time{}
+	}
+}
+
+func (w *HypervisorEventWatcher) alarmTimeout() time.Duration {
+	if w.AlarmTimeout > 0 {
+		return w.AlarmTimeout
+	}
+	return DefaultHypervisorAlarmTimeout
+}
+
+// syncWatches opens a watch for each Provider advertising event watch that
+// has none, reopens the watches of Providers whose spec changed, and closes
+// the rest.
+func (w *HypervisorEventWatcher) syncWatches(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("hypervisor-events")
+
+	var providers infravirtrigaudiov1beta1.ProviderList
+	if err := w.Client.List(ctx, &providers); err != nil {
+		log.Error(err, "Failed to list Providers")
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	wanted := map[types.NamespacedName]bool{}
+	for i := range providers.Items {
+		provider := &providers.Items[i]
+		caps := provider.Status.ReportedCapabilities
+		if caps == nil || !caps.SupportsEventWatch || !provider.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := client.ObjectKeyFromObject(provider)
+		wanted[key] = true
+		if watch, ok := w.watches[key]; ok {
+			if watch.uid == provider.UID && watch.generation == provider.Generation {
+				continue
+			}
+			watch.cancel()
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		w.watches[key] = &hypervisorWatch{uid: provider.UID, generation: provider.Generation, cancel: cancel}
+		log.V(1).Info("Watching hypervisor events", "provider", key)
+		go w.watch(watchCtx, key)
+	}
+	for key, watch := range w.watches {
+		if !wanted[key] {
+			watch.cancel()
+			delete(w.watches, key)
+		}
+	}
+}
+
+// watch keeps the provider's event stream open until ctx is done,
+// reopening it after a backoff. A reopened stream asks for the events
+// after the last one received.
+func (w *HypervisorEventWatcher) watch(ctx context.Context, key types.NamespacedName) {
+	log := logf.FromContext(ctx).WithName("hypervisor-events").WithValues("provider", key)
+	var since time.Time
+	backoff := hypervisorWatchMinBackoff
+	for {
+		opened := w.now()
+		err := w.watchOnce(ctx, key, since, func(ev contracts.HypervisorEvent) {
+			if !ev.Time.Before(since) {
+				since = ev.Time.Add(time.Second)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if w.now().Sub(opened) > hypervisorWatchMaxBackoff {
+			backoff = hypervisorWatchMinBackoff
+		}
+		switch {
+		case contracts.IsNotSupported(err):
+			log.Info("Provider advertises event watch but does not implement it", "error", err.Error())
+			backoff = hypervisorWatchMaxBackoff
+		case err != nil:
+			log.V(1).Info("Hypervisor event stream failed", "error", err.Error(), "retryIn", backoff)
+		default:
+			log.V(1).Info("Provider ended the hypervisor event stream", "retryIn", backoff)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, hypervisorWatchMaxBackoff)
+	}
+}
+
+// watchOnce opens one stream to the provider, from since, and handles its
+// events; received is called with each.
+func (w *HypervisorEventWatcher) watchOnce(ctx context.Context, key types.NamespacedName, since time.Time,
+	received func(contracts.HypervisorEvent)) error {
+	provider := &infravirtrigaudiov1beta1.Provider{}
+	if err := w.Client.Get(ctx, key, provider); err != nil {
+		return err
+	}
+	p, err := w.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		return err
+	}
+	watcher, ok := p.(contracts.EventWatcher)
+	if !ok {
+		return contracts.NewNotSupportedError("WatchEvents")
+	}
+	return watcher.WatchEvents(ctx, since, func(ev contracts.HypervisorEvent) {
+		received(ev)
+		w.handle(ctx, key, ev)
+	})
+}
+
+// handle records ev on the VirtualMachine of provider whose ID it names.
+// Events for VMs virtrigaud does not manage are dropped.
+func (w *HypervisorEventWatcher) handle(ctx context.Context, provider types.NamespacedName, ev contracts.HypervisorEvent) {
+	log := logf.FromContext(ctx).WithName("hypervisor-events")
+	if ev.VMID == "" {
+		return
+	}
+	vm, err := w.findVM(ctx, provider, ev.VMID)
+	if err != nil {
+		log.Error(err, "Failed to look up the VM of a hypervisor event", "provider", provider, "vmID", ev.VMID)
+		return
+	}
+	if vm == nil {
+		return
+	}
+	key := client.ObjectKeyFromObject(vm)
+	reason := alarmConditionReason(ev.Reason)
+
+	if ev.Resolved {
+		cleared, err := w.setAlarm(ctx, key, func(cond *metav1.Condition) (bool, string, string) {
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reason {
+				return false, "", ""
+			}
+			return true, hypervisorAlarmResolved, ev.Message
+		})
+		if err != nil {
+			log.Error(err, "Failed to clear HypervisorAlarm", "vm", key)
+			return
+		}
+		if cleared {
+			w.mu.Lock()
+			delete(w.raised, key)
+			w.mu.Unlock()
+			events.Emit(ctx, w.Recorder, vm, corev1.EventTypeNormal, events.ReasonHypervisorAlarmCleared,
+				string(vm.Status.Phase), fmt.Sprintf("%s: %s", ev.Reason, ev.Message))
+		}
+		return
+	}
+
+	if ev.Severity.AtLeastWarning() {
+		w.mu.Lock()
+		w.raised[key] = w.now()
+		w.mu.Unlock()
+		if _, err := w.setAlarm(ctx, key, func(*metav1.Condition) (bool, string, string) {
+			return true, reason, ev.Message
+		}); err != nil {
+			log.Error(err, "Failed to set HypervisorAlarm", "vm", key)
+		}
+	}
+	if !w.admit(hypervisorEventKey{vm: key, reason: ev.Reason, message: ev.Message}) {
+		return
+	}
+	eventType, eventReason := corev1.EventTypeNormal, events.ReasonHypervisorEvent
+	if ev.Severity.AtLeastWarning() {
+		eventType, eventReason = corev1.EventTypeWarning, events.ReasonHypervisorAlarm
+	}
+	events.Emit(ctx, w.Recorder, vm, eventType, eventReason, string(vm.Status.Phase),
+		fmt.Sprintf("%s: %s", ev.Reason, ev.Message))
+}
+
+// findVM returns the VirtualMachine on provider whose status.id is id, or
+// nil.
+func (w *HypervisorEventWatcher) findVM(ctx context.Context, provider types.NamespacedName, id string) (*infravirtrigaudiov1beta1.VirtualMachine, error) {
+	var vms infravirtrigaudiov1beta1.VirtualMachineList
+	if err := w.Client.List(ctx, &vms, client.MatchingFields{vmProviderRefIndex: provider.String()}); err != nil {
+		return nil, err
+	}
+	for i := range vms.Items {
+		if vms.Items[i].Status.ID == id && vms.Items[i].DeletionTimestamp.IsZero() {
+			return &vms.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// admit reports whether an event may be recorded: it repeats none recorded
+// on the VM within the dedup window, and the VM's rate allows it.
+func (w *HypervisorEventWatcher) admit(key hypervisorEventKey) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	for k, at := range w.recorded {
+		if now.Sub(at) >= hypervisorEventDedupWindow {
+			delete(w.recorded, k)
+		}
+	}
+	if _, dup := w.recorded[key]; dup {
+		return false
+	}
+	rate := w.rates[key.vm]
+	if rate == nil || now.Sub(rate.start) >= hypervisorEventRateWindow {
+		rate = &hypervisorEventRate{start: now}
+		w.rates[key.vm] = rate
+	}
+	if rate.count >= hypervisorEventBurst {
+		return false
+	}
+	rate.count++
+	w.recorded[key] = now
+	return true
+}
+
+// setAlarm updates the VM's HypervisorAlarm condition from the latest VM,
+// retrying on conflicts. decide sees the current condition, or nil, and
+// returns whether to write it and the reason and message to write; the
+// status is True unless the reason is one a cleared alarm carries. It
+// reports whether the condition was written.
+func (w *HypervisorEventWatcher) setAlarm(ctx context.Context, key types.NamespacedName,
+	decide func(cond *metav1.Condition) (write bool, reason, message string)) (bool, error) {
+	written := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		written = false
+		vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+		if err := w.Client.Get(ctx, key, vm); err != nil {
+			return err
+		}
+		cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionHypervisorAlarm)
+		write, reason, message := decide(cond)
+		if !write {
+			return nil
+		}
+		status := metav1.ConditionTrue
+		if reason == hypervisorAlarmResolved || reason == hypervisorAlarmTimedOut {
+			status = metav1.ConditionFalse
+		}
+		if cond != nil && cond.Status == status && cond.Reason == reason && cond.Message == message {
+			return nil
+		}
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionHypervisorAlarm,
+			status, reason, message)
+		if err := w.Client.Status().Update(ctx, vm); err != nil {
+			return err
+		}
+		written = true
+		return nil
+	})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return written, err
+}
+
+// expireAlarms clears the HypervisorAlarm conditions raised longer than the
+// alarm timeout ago. An alarm raised before this process started is timed
+// from the condition's last transition.
+func (w *HypervisorEventWatcher) expireAlarms(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("hypervisor-events")
+
+	var vms infravirtrigaudiov1beta1.VirtualMachineList
+	if err := w.Client.List(ctx, &vms); err != nil {
+		log.Error(err, "Failed to list VirtualMachines")
+		return
+	}
+	timeout := w.alarmTimeout()
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionHypervisorAlarm)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		key := client.ObjectKeyFromObject(vm)
+		w.mu.Lock()
+		raisedAt, ok := w.raised[key]
+		if !ok {
+			raisedAt = cond.LastTransitionTime.Time
+		}
+		expired := w.now().Sub(raisedAt) >= timeout
+		w.mu.Unlock()
+		if !expired {
+			continue
+		}
+
+		alarm := cond.Reason
+		message := fmt.Sprintf("No resolution reported within %s", timeout)
+		cleared, err := w.setAlarm(ctx, key, func(cond *metav1.Condition) (bool, string, string) {
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != alarm {
+				return false, "", ""
+			}
+			return true, hypervisorAlarmTimedOut, message
+		})
+		if err != nil {
+			log.Error(err, "Failed to clear HypervisorAlarm", "vm", key)
+			continue
+		}
+		w.mu.Lock()
+		delete(w.raised, key)
+		w.mu.Unlock()
+		if cleared {
+			events.Emit(ctx, w.Recorder, vm, corev1.EventTypeNormal, events.ReasonHypervisorAlarmCleared,
+				string(vm.Status.Phase), fmt.Sprintf("%s: %s", alarm, message))
+		}
+	}
+}
+
+// alarmConditionReason returns the provider's reason as a HypervisorAlarm
+// condition reason.
+func alarmConditionReason(reason string) string {
+	if conditionReasonPattern.MatchString(reason) && len(reason) <= 1024 &&
+		reason != hypervisorAlarmResolved && reason != hypervisorAlarmTimedOut {
+		return reason
+	}
+	return hypervisorAlarmOther
+}
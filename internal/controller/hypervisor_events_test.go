@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// eventWatchProvider sends its events on each WatchEvents call, then waits
+// for the call to end.
+type eventWatchProvider struct {
+	stubProvider
+	events []contracts.HypervisorEvent
+}
+
+func (p *eventWatchProvider) WatchEvents(ctx context.Context, _ time.Time, handle func(contracts.HypervisorEvent)) error {
+	for _, ev := range p.events {
+		handle(ev)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func newHypervisorEventWatcher(t *testing.T, provider contracts.Provider, objs ...client.Object) (*HypervisorEventWatcher, *record.FakeRecorder, client.Client) {
+	t.Helper()
+	fc := fake.NewClientBuilder().WithScheme(coverageTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		WithIndex(&infravirtrigaudiov1beta1.VirtualMachine{}, vmProviderRefIndex, indexVMProviderRef).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	w := &HypervisorEventWatcher{Client: fc, RemoteResolver: &stubResolver{provider: provider}, Recorder: recorder}
+	w.init()
+	return w, recorder, fc
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var got []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func hypervisorAlarm(t *testing.T, c client.Client, vm *infravirtrigaudiov1beta1.VirtualMachine) *metav1.Condition {
+	t.Helper()
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(vm), got))
+	return k8s.GetCondition(got.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionHypervisorAlarm)
+}
+
+func TestHypervisorEventWatcher_Handle(t *testing.T) {
+	const ns = "hv-events"
+	ctx := context.Background()
+	prov, _ := providerAndClass(ns)
+	vm := baseVM(ns)
+	vm.Status.ID = "100"
+	other := baseVM(ns)
+	other.Name = "other-provider"
+	other.Spec.ProviderRef.Name = "other"
+	other.Status.ID = "100"
+	w, recorder, c := newHypervisorEventWatcher(t, nil, prov, vm, other)
+	key := client.ObjectKeyFromObject(prov)
+
+	alarm := contracts.HypervisorEvent{VMID: "100", Severity: contracts.EventSeverityWarning, Reason: "TaskFailed", Message: "qmstart failed"}
+	w.handle(ctx, key, alarm)
+	w.handle(ctx, key, alarm)
+	assert.Equal(t, []string{"Warning HypervisorAlarm TaskFailed: qmstart failed"}, drainEvents(recorder),
+		"a repeated event is recorded once")
+	cond := hypervisorAlarm(t, c, vm)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "TaskFailed", cond.Reason)
+	assert.Nil(t, hypervisorAlarm(t, c, other), "only the VM on the reporting provider is touched")
+
+	w.handle(ctx, key, contracts.HypervisorEvent{VMID: "100", Severity: contracts.EventSeverityInfo, Reason: "DomainLifecycle", Message: "Started Booted"})
+	w.handle(ctx, key, contracts.HypervisorEvent{VMID: "999", Severity: contracts.EventSeverityError, Reason: "DomainCrashed"})
+	assert.Equal(t, []string{"Normal HypervisorEvent DomainLifecycle: Started Booted"}, drainEvents(recorder),
+		"events for VMs virtrigaud does not manage are dropped")
+
+	w.handle(ctx, key, contracts.HypervisorEvent{VMID: "100", Reason: "DiskIOError", Resolved: true})
+	assert.Empty(t, drainEvents(recorder), "a resolution for another alarm is ignored")
+	w.handle(ctx, key, contracts.HypervisorEvent{VMID: "100", Reason: "TaskFailed", Message: "qmstart succeeded", Resolved: true})
+	assert.Equal(t, []string{"Normal HypervisorAlarmCleared TaskFailed: qmstart succeeded"}, drainEvents(recorder))
+	cond = hypervisorAlarm(t, c, vm)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, hypervisorAlarmResolved, cond.Reason)
+}
+
+func TestHypervisorEventWatcher_RateLimit(t *testing.T) {
+	const ns = "hv-events-rate"
+	ctx := context.Background()
+	prov, _ := providerAndClass(ns)
+	vm := baseVM(ns)
+	vm.Status.ID = "web"
+	w, recorder, _ := newHypervisorEventWatcher(t, nil, prov, vm)
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	for i := range hypervisorEventBurst + 5 {
+		w.handle(ctx, client.ObjectKeyFromObject(prov), contracts.HypervisorEvent{VMID: "web", Reason: "DomainLifecycle", Message: fmt.Sprintf("event %d", i)})
+	}
+	assert.Len(t, drainEvents(recorder), hypervisorEventBurst)
+
+	now = now.Add(hypervisorEventRateWindow)
+	w.handle(ctx, client.ObjectKeyFromObject(prov), contracts.HypervisorEvent{VMID: "web", Reason: "DomainLifecycle", Message: "later"})
+	assert.Len(t, drainEvents(recorder), 1)
+}
+
+func TestHypervisorEventWatcher_AlarmTimeout(t *testing.T) {
+	const ns = "hv-events-timeout"
+	ctx := context.Background()
+	prov, _ := providerAndClass(ns)
+	vm := baseVM(ns)
+	vm.Status.ID = "web"
+	restored := baseVM(ns)
+	restored.Name = "raised-before-restart"
+	k8s.SetCondition(&restored.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionHypervisorAlarm,
+		metav1.ConditionTrue, "WatchdogFired", "reset")
+	w, recorder, c := newHypervisorEventWatcher(t, nil, prov, vm, restored)
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	w.handle(ctx, client.ObjectKeyFromObject(prov), contracts.HypervisorEvent{VMID: "web", Severity: contracts.EventSeverityError, Reason: "DomainCrashed", Message: "Stopped Failed"})
+	drainEvents(recorder)
+
+	now = now.Add(DefaultHypervisorAlarmTimeout - time.Minute)
+	w.expireAlarms(ctx)
+	assert.Equal(t, metav1.ConditionTrue, hypervisorAlarm(t, c, vm).Status)
+	assert.Empty(t, drainEvents(recorder))
+
+	now = now.Add(time.Minute)
+	w.expireAlarms(ctx)
+	for _, obj := range []*infravirtrigaudiov1beta1.VirtualMachine{vm, restored} {
+		cond := hypervisorAlarm(t, c, obj)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status, obj.Name)
+		assert.Equal(t, hypervisorAlarmTimedOut, cond.Reason, obj.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"Normal HypervisorAlarmCleared DomainCrashed: No resolution reported within 30m0s",
+		"Normal HypervisorAlarmCleared WatchdogFired: No resolution reported within 30m0s",
+	}, drainEvents(recorder))
+}
+
+func TestHypervisorEventWatcher_SyncWatches(t *testing.T) {
+	const ns = "hv-events-sync"
+	prov, _ := providerAndClass(ns)
+	prov.Status.ReportedCapabilities = &infravirtrigaudiov1beta1.ReportedCapabilities{SupportsEventWatch: true}
+	silent := prov.DeepCopy()
+	silent.Name = "silent"
+	silent.Status.ReportedCapabilities = &infravirtrigaudiov1beta1.ReportedCapabilities{}
+	vm := baseVM(ns)
+	vm.Status.ID = "web"
+	provider := &eventWatchProvider{events: []contracts.HypervisorEvent{
+		{VMID: "web", Severity: contracts.EventSeverityWarning, Reason: "WatchdogFired", Message: "reset"},
+	}}
+	w, recorder, c := newHypervisorEventWatcher(t, provider, prov, silent, vm)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.syncWatches(ctx)
+	w.mu.Lock()
+	assert.Len(t, w.watches, 1, "only providers advertising event watch are watched")
+	assert.Contains(t, w.watches, types.NamespacedName{Namespace: ns, Name: "test-prov"})
+	w.mu.Unlock()
+
+	select {
+	case e := <-recorder.Events:
+		assert.Equal(t, "Warning HypervisorAlarm WatchdogFired: reset", e)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event recorded")
+	}
+	assert.Equal(t, metav1.ConditionTrue, hypervisorAlarm(t, c, vm).Status)
+
+	got := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(prov), got))
+	got.Status.ReportedCapabilities.SupportsEventWatch = false
+	require.NoError(t, c.Update(ctx, got))
+	w.syncWatches(ctx)
+	w.mu.Lock()
+	assert.Empty(t, w.watches, "the watch closes once the provider stops advertising it")
+	w.mu.Unlock()
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// Provider maintenance vocabulary. A Provider is in maintenance while its
+// spec.maintenanceMode is set or maintenance.enabled is set in the manager
+// configuration. New provisioning on it is then held: VMs are not created
+// and images are not prepared for them, and VMSnapshots and VMClones are
+// not started. Existing VMs are still described, powered, reconfigured and
+// deleted. A held object has Ready=False with reason ProviderInMaintenance,
+// and is requeued as soon as the maintenance ends.
+//
+// Reasons of the Provider's ProviderInMaintenance condition:
+//   - MaintenanceMode: spec.maintenanceMode is set.
+//   - GlobalMaintenance: the manager configuration enables maintenance.
+//   - MaintenanceEnded: the maintenance is over (condition False).
+const (
+	providerConditionInMaintenance  = "ProviderInMaintenance"
+	providerReasonMaintenanceMode   = "MaintenanceMode"
+	providerReasonGlobalMaintenance = "GlobalMaintenance"
+	providerReasonMaintenanceEnded  = "MaintenanceEnded"
+
+	// ReasonProviderInMaintenance marks a VM, VMSnapshot or VMClone whose
+	// provider is in maintenance.
+	ReasonProviderInMaintenance = events.ReasonProviderInMaintenance
+
+	// MaintenanceExemptAnnotation, set to "true" on a VirtualMachine,
+	// VMSnapshot or VMClone, lets it be provisioned on a provider in
+	// maintenance.
+	MaintenanceExemptAnnotation = "virtrigaud.io/maintenance-exempt"
+
+	// maintenanceRecheck is how often a held object looks again. It is
+	// also requeued when the maintenance ends, so this is only a backstop.
+	maintenanceRecheck = 10 * time.Minute
+
+	// maintenanceRecount is how often a Provider in maintenance recounts
+	// the operations it holds.
+	maintenanceRecount = time.Minute
+)
+
+// providerMaintenance reports whether provider is in maintenance, and
+// whether that is because the manager configuration puts every provider
+// in maintenance.
+func providerMaintenance(provider *infravirtrigaudiov1beta1.Provider, cfg *config.VirtrigaudConfig) (inMaintenance, global bool) {
+	if provider.Spec.MaintenanceMode {
+		return true, false
+	}
+	if cfg.Maintenance.Enabled {
+		return true, true
+	}
+	return false, false
+}
+
+// maintenanceHold reports whether obj must wait for provider's maintenance
+// to end before it is provisioned, with a message for its Ready condition.
+func maintenanceHold(provider *infravirtrigaudiov1beta1.Provider, cfg *config.VirtrigaudConfig, obj metav1.Object) (string, bool) {
+	inMaintenance, global := providerMaintenance(provider, cfg)
+	if !inMaintenance || isMaintenanceExempt(obj) {
+		return "", false
+	}
+	if global {
+		return fmt.Sprintf("all providers are in maintenance; provisioning on %s is held until it ends", provider.Name), true
+	}
+	return fmt.Sprintf("provider %s is in maintenance; provisioning is held until it ends", provider.Name), true
+}
+
+// isMaintenanceExempt reports whether obj carries the maintenance-exempt
+// annotation.
+func isMaintenanceExempt(obj metav1.Object) bool {
+	return obj.GetAnnotations()[MaintenanceExemptAnnotation] == "true"
+}
+
+// heldForMaintenance reports whether conditions record an object held by
+// its provider's maintenance.
+func heldForMaintenance(conditions []metav1.Condition) bool {
+	c := k8s.GetCondition(conditions, k8s.ConditionReady)
+	return c != nil && c.Status == metav1.ConditionFalse && c.Reason == ReasonProviderInMaintenance
+}
+
+// providerInMaintenanceCondition reports whether provider's
+// ProviderInMaintenance condition is True.
+func providerInMaintenanceCondition(provider *infravirtrigaudiov1beta1.Provider) bool {
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionInMaintenance)
+	return c != nil && c.Status == metav1.ConditionTrue
+}
+
+// providerMaintenanceEnded passes Provider updates that can release held
+// objects: spec.maintenanceMode was cleared, or the ProviderInMaintenance
+// condition stopped being True.
+var providerMaintenanceEnded = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldProvider, ok1 := e.ObjectOld.(*infravirtrigaudiov1beta1.Provider)
+		newProvider, ok2 := e.ObjectNew.(*infravirtrigaudiov1beta1.Provider)
+		if !ok1 || !ok2 {
+			return false
+		}
+		return (oldProvider.Spec.MaintenanceMode && !newProvider.Spec.MaintenanceMode) ||
+			(providerInMaintenanceCondition(oldProvider) && !providerInMaintenanceCondition(newProvider))
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// vmsOnProvider lists the VirtualMachines that reference provider.
+func vmsOnProvider(ctx context.Context, c client.Reader, provider client.Object) []infravirtrigaudiov1beta1.VirtualMachine {
+	vms := &infravirtrigaudiov1beta1.VirtualMachineList{}
+	if err := c.List(ctx, vms, client.MatchingFields{vmProviderRefIndex: client.ObjectKeyFromObject(provider).String()}); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to list the VMs of a provider", "provider", provider.GetName(), "error", err.Error())
+		return nil
+	}
+	return vms.Items
+}
+
+// reconcileMaintenance records on provider's status whether it is in
+// maintenance and how many operations it holds, with an event when it
+// enters or leaves maintenance. While it is in maintenance it returns how
+// soon to recount them.
+func (r *ProviderReconciler) reconcileMaintenance(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) time.Duration {
+	inMaintenance, global := providerMaintenance(provider, r.Config.Get())
+	wasInMaintenance := providerInMaintenanceCondition(provider)
+	if !inMaintenance {
+		provider.Status.Maintenance = nil
+		if wasInMaintenance {
+			k8s.SetCondition(&provider.Status.Conditions, providerConditionInMaintenance, metav1.ConditionFalse,
+				providerReasonMaintenanceEnded, "New provisioning is no longer held")
+			events.Emit(ctx, r.Recorder, provider, corev1.EventTypeNormal, events.ReasonProviderMaintenanceEnded, "",
+				"Maintenance ended; held VM creates, snapshots and clones are released")
+		}
+		return 0
+	}
+
+	reason, message := providerReasonMaintenanceMode, "spec.maintenanceMode is set; new VMs, snapshots and clones are held"
+	if global {
+		reason, message = providerReasonGlobalMaintenance, "Maintenance is enabled in the manager configuration; new VMs, snapshots and clones are held"
+	}
+	if provider.Status.Maintenance == nil || !wasInMaintenance {
+		now := metav1.Now()
+		provider.Status.Maintenance = &infravirtrigaudiov1beta1.ProviderMaintenanceStatus{Since: &now}
+	}
+	provider.Status.Maintenance.Global = global
+	if held, err := r.countHeldOperations(ctx, provider); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to count operations held by maintenance", "error", err.Error())
+	} else {
+		provider.Status.Maintenance.HeldOperations = held
+	}
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionInMaintenance, metav1.ConditionTrue, reason, message)
+	if !wasInMaintenance {
+		log.FromContext(ctx).Info("Provider entered maintenance", "global", global)
+		events.Emit(ctx, r.Recorder, provider, corev1.EventTypeNormal, events.ReasonProviderMaintenanceStarted, "", message)
+	}
+	return maintenanceRecount
+}
+
+// countHeldOperations counts the VMs, VMSnapshots and VMClones on
+// provider that are held by its maintenance.
+func (r *ProviderReconciler) countHeldOperations(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (int32, error) {
+	vmList := &infravirtrigaudiov1beta1.VirtualMachineList{}
+	if err := r.List(ctx, vmList); err != nil {
+		return 0, fmt.Errorf("failed to list VirtualMachines: %w", err)
+	}
+	held := int32(0)
+	onProvider := map[client.ObjectKey]bool{}
+	for i := range vmList.Items {
+		vm := &vmList.Items[i]
+		if !VMUsesProvider(vm, provider) {
+			continue
+		}
+		onProvider[client.ObjectKeyFromObject(vm)] = true
+		if heldForMaintenance(vm.Status.Conditions) {
+			held++
+		}
+	}
+
+	snapshots := &infravirtrigaudiov1beta1.VMSnapshotList{}
+	if err := r.List(ctx, snapshots); err != nil {
+		return 0, fmt.Errorf("failed to list VMSnapshots: %w", err)
+	}
+	for i := range snapshots.Items {
+		s := &snapshots.Items[i]
+		if onProvider[client.ObjectKey{Namespace: s.Namespace, Name: s.Spec.VMRef.Name}] && heldForMaintenance(s.Status.Conditions) {
+			held++
+		}
+	}
+
+	clones := &infravirtrigaudiov1beta1.VMCloneList{}
+	if err := r.List(ctx, clones); err != nil {
+		return 0, fmt.Errorf("failed to list VMClones: %w", err)
+	}
+	for i := range clones.Items {
+		c := &clones.Items[i]
+		if c.Spec.Source.VMRef != nil && onProvider[client.ObjectKey{Namespace: c.Namespace, Name: c.Spec.Source.VMRef.Name}] &&
+			heldForMaintenance(c.Status.Conditions) {
+			held++
+		}
+	}
+	return held, nil
+}
+
+// allProviders maps an event to every Provider, so that a change to the
+// manager's maintenance setting is recorded on all of them.
+func (r *ProviderReconciler) allProviders(ctx context.Context, _ client.Object) []reconcile.Request {
+	providers := &infravirtrigaudiov1beta1.ProviderList{}
+	if err := r.List(ctx, providers); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Providers after a maintenance change")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(providers.Items))
+	for i := range providers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&providers.Items[i])})
+	}
+	return requests
+}
+
+// holdForMaintenance records on vm's Ready condition that its provider's
+// maintenance holds it, with an event the first time.
+func (r *VirtualMachineReconciler) holdForMaintenance(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, message string) {
+	log.FromContext(ctx).Info("Not provisioning VM while its provider is in maintenance", "provider", vm.Spec.ProviderRef.Name)
+	if !heldForMaintenance(vm.Status.Conditions) {
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonProviderInMaintenance, message)
+	}
+	k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonProviderInMaintenance, message)
+	r.updateStatus(ctx, vm)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// holdInMaintenance marks conditions as held by maintenance.
+func holdInMaintenance(conditions *[]metav1.Condition) {
+	k8s.SetReadyCondition(conditions, metav1.ConditionFalse, ReasonProviderInMaintenance, "held")
+}
+
+func globalMaintenance() *config.ConfigStore {
+	cfg := config.DefaultVirtrigaudConfig()
+	cfg.Maintenance.Enabled = true
+	return config.NewConfigStore(cfg, nil)
+}
+
+func TestReconcileVM_MaintenanceHoldsCreate(t *testing.T) {
+	prov := &createCountingProvider{}
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	k8sProv.Spec.MaintenanceMode = true
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+
+	vm := baseVM("default")
+	vm.Spec.ImportedDisk = &infravirtrigaudiov1beta1.ImportedDiskRef{DiskID: "disk-1", Format: "qcow2", Source: "manual"}
+	result, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, maintenanceRecheck, result.RequeueAfter)
+	assert.Zero(t, prov.createCnt, "a provider in maintenance creates nothing")
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonProviderInMaintenance, ready.Reason)
+	assert.Contains(t, ready.Message, "provider test-prov is in maintenance")
+
+	vm.Annotations = map[string]string{MaintenanceExemptAnnotation: "true"}
+	_, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, prov.createCnt, "an exempt VM is provisioned")
+}
+
+func TestReconcileVM_MaintenanceKeepsExistingVMs(t *testing.T) {
+	exists := true
+	prov := &fakeDescribeProvider{DescribeFn: func(context.Context, string) (contracts.DescribeResponse, error) {
+		return contracts.DescribeResponse{Exists: exists, PowerState: "On", IPs: []string{"10.0.0.1"}}, nil
+	}}
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+	r.Config = globalMaintenance()
+
+	vm := baseVM("default")
+	vm.Status.ID = "vm-42"
+	result, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.NotEqual(t, maintenanceRecheck, result.RequeueAfter)
+	assert.False(t, heldForMaintenance(vm.Status.Conditions), "an existing VM is still reconciled")
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, vm.Status.PowerState)
+
+	exists = false
+	result, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, maintenanceRecheck, result.RequeueAfter)
+	assert.Equal(t, "vm-42", vm.Status.ID, "a vanished VM is not recreated during maintenance")
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonProviderInMaintenance, ready.Reason)
+	assert.Contains(t, ready.Message, "all providers are in maintenance")
+}
+
+// TestReconcileMaintenance walks a Provider into and out of maintenance,
+// counting the operations held on it.
+func TestReconcileMaintenance(t *testing.T) {
+	ctx := context.Background()
+	s := cloneTestScheme(t)
+	provider := runningProvider("default", "pve")
+	provider.Spec.MaintenanceMode = true
+
+	heldVM := sourceVMWithID("default", "held", "pve", "")
+	holdInMaintenance(&heldVM.Status.Conditions)
+	runningVM := sourceVMWithID("default", "web", "pve", "vm-1")
+	otherVM := sourceVMWithID("default", "elsewhere", "vcenter", "")
+	holdInMaintenance(&otherVM.Status.Conditions)
+	snapshot := &infravirtrigaudiov1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-snap", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.VMSnapshotSpec{VMRef: infravirtrigaudiov1beta1.LocalObjectReference{Name: "web"}},
+	}
+	holdInMaintenance(&snapshot.Status.Conditions)
+	clone := &infravirtrigaudiov1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-clone", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMCloneSpec{
+			Source: infravirtrigaudiov1beta1.CloneSource{VMRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "web"}},
+		},
+	}
+	holdInMaintenance(&clone.Status.Conditions)
+	fc := fake.NewClientBuilder().WithScheme(s).WithObjects(heldVM, runningVM, otherVM, snapshot, clone).Build()
+	recorder := record.NewFakeRecorder(8)
+	r := &ProviderReconciler{Client: fc, Recorder: recorder}
+
+	assert.Equal(t, maintenanceRecount, r.reconcileMaintenance(ctx, provider))
+	require.NotNil(t, provider.Status.Maintenance)
+	assert.Equal(t, int32(3), provider.Status.Maintenance.HeldOperations, "the held VM, snapshot and clone on this provider")
+	assert.False(t, provider.Status.Maintenance.Global)
+	since := provider.Status.Maintenance.Since
+	require.NotNil(t, since)
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionInMaintenance)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, providerReasonMaintenanceMode, c.Reason)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "ProviderMaintenanceStarted")
+
+	r.reconcileMaintenance(ctx, provider)
+	assert.Empty(t, recorder.Events, "entering maintenance is announced once")
+	assert.Equal(t, since, provider.Status.Maintenance.Since)
+
+	// The spec flag cleared while maintenance is enabled cluster-wide.
+	provider.Spec.MaintenanceMode = false
+	r.Config = globalMaintenance()
+	r.reconcileMaintenance(ctx, provider)
+	assert.True(t, provider.Status.Maintenance.Global)
+	assert.Equal(t, providerReasonGlobalMaintenance, k8s.GetCondition(provider.Status.Conditions, providerConditionInMaintenance).Reason)
+	assert.Empty(t, recorder.Events)
+
+	r.Config = nil
+	assert.Zero(t, r.reconcileMaintenance(ctx, provider))
+	assert.Nil(t, provider.Status.Maintenance)
+	c = k8s.GetCondition(provider.Status.Conditions, providerConditionInMaintenance)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, providerReasonMaintenanceEnded, c.Reason)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "ProviderMaintenanceEnded")
+}
+
+func TestGateSnapshotMaintenance(t *testing.T) {
+	ctx := context.Background()
+	s := cloneTestScheme(t)
+	provider := runningProvider("default", "pve")
+	provider.Spec.MaintenanceMode = true
+	vm := sourceVMWithID("default", "web", "pve", "vm-1")
+	snapshot := &infravirtrigaudiov1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-snap", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.VMSnapshotSpec{VMRef: infravirtrigaudiov1beta1.LocalObjectReference{Name: "web"}},
+	}
+	fc := fake.NewClientBuilder().WithScheme(s).WithObjects(provider, vm, snapshot).WithStatusSubresource(snapshot).Build()
+	recorder := record.NewFakeRecorder(4)
+	r := &VMSnapshotReconciler{Client: fc, Scheme: s, Recorder: recorder}
+
+	require.True(t, r.gateSnapshotMaintenance(ctx, snapshot, vm))
+	assert.Empty(t, snapshot.Status.Phase, "a held snapshot is not started")
+	assert.True(t, heldForMaintenance(snapshot.Status.Conditions))
+	require.True(t, r.gateSnapshotMaintenance(ctx, snapshot, vm))
+	assert.Len(t, recorder.Events, 1, "holding is announced once")
+
+	snapshot.Annotations = map[string]string{MaintenanceExemptAnnotation: "true"}
+	assert.False(t, r.gateSnapshotMaintenance(ctx, snapshot, vm), "an exempt snapshot is taken")
+}
+
+func TestVMClone_HeldByProviderMaintenance(t *testing.T) {
+	ctx := context.Background()
+	s := cloneTestScheme(t)
+	prov := runningProvider("default", "prov-1")
+	prov.Spec.MaintenanceMode = true
+	src := sourceVMWithID("default", "src-vm", "prov-1", "vm-source-123")
+	clone := &infravirtrigaudiov1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone-1", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMCloneSpec{
+			Source: infravirtrigaudiov1beta1.CloneSource{VMRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "src-vm"}},
+			Target: infravirtrigaudiov1beta1.VMCloneTarget{Name: "clone-target"},
+		},
+	}
+	cp := &clonerProvider{cloneResp: contracts.CloneResponse{TargetVmID: "vm-clone-999"}}
+	r := newCloneReconciler(s, &stubResolver{provider: cp}, prov, src, clone)
+
+	reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+	got := &infravirtrigaudiov1beta1.VMClone{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	assert.Zero(t, cp.cloneCnt, "a provider in maintenance clones nothing")
+	assert.Equal(t, infravirtrigaudiov1beta1.ClonePhasePending, got.Status.Phase)
+	assert.True(t, heldForMaintenance(got.Status.Conditions))
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(prov), prov))
+	prov.Spec.MaintenanceMode = false
+	require.NoError(t, r.Update(ctx, prov))
+	reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	assert.Equal(t, 1, cp.cloneCnt)
+	assert.Equal(t, infravirtrigaudiov1beta1.ClonePhaseReady, got.Status.Phase)
+}
+
+// TestHeldSnapshotsForProvider verifies that only the held snapshots of a
+// provider's VMs are released when its maintenance ends.
+func TestHeldSnapshotsForProvider(t *testing.T) {
+	s := cloneTestScheme(t)
+	provider := runningProvider("default", "pve")
+	web := sourceVMWithID("default", "web", "pve", "vm-1")
+	db := sourceVMWithID("default", "db", "vcenter", "vm-2")
+	newSnapshot := func(name, vm string, held bool) *infravirtrigaudiov1beta1.VMSnapshot {
+		snapshot := &infravirtrigaudiov1beta1.VMSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       infravirtrigaudiov1beta1.VMSnapshotSpec{VMRef: infravirtrigaudiov1beta1.LocalObjectReference{Name: vm}},
+		}
+		if held {
+			holdInMaintenance(&snapshot.Status.Conditions)
+		}
+		return snapshot
+	}
+	fc := fake.NewClientBuilder().WithScheme(s).
+		WithObjects(provider, web, db,
+			newSnapshot("web-held", "web", true), newSnapshot("web-ready", "web", false), newSnapshot("db-held", "db", true)).
+		WithIndex(&infravirtrigaudiov1beta1.VirtualMachine{}, vmProviderRefIndex, indexVMProviderRef).
+		WithIndex(&infravirtrigaudiov1beta1.VMSnapshot{}, snapshotVMRefIndex, indexSnapshotVMRef).
+		Build()
+	r := &VMSnapshotReconciler{Client: fc, Scheme: s}
+
+	assert.Equal(t, []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: "default", Name: "web-held"}}},
+		r.heldSnapshotsForProvider(context.Background(), provider))
+}
+
+func TestProviderMaintenanceEnded(t *testing.T) {
+	inMaintenance := runningProvider("default", "pve")
+	inMaintenance.Spec.MaintenanceMode = true
+	k8s.SetCondition(&inMaintenance.Status.Conditions, providerConditionInMaintenance, metav1.ConditionTrue, providerReasonMaintenanceMode, "")
+
+	cleared := inMaintenance.DeepCopy()
+	cleared.Spec.MaintenanceMode = false
+	assert.True(t, providerMaintenanceEnded.Update(event.UpdateEvent{ObjectOld: inMaintenance, ObjectNew: cleared}),
+		"clearing the flag releases held objects without waiting for the status")
+
+	recorded := cleared.DeepCopy()
+	k8s.SetCondition(&recorded.Status.Conditions, providerConditionInMaintenance, metav1.ConditionFalse, providerReasonMaintenanceEnded, "")
+	assert.True(t, providerMaintenanceEnded.Update(event.UpdateEvent{ObjectOld: cleared, ObjectNew: recorded}),
+		"the end of a cluster-wide maintenance shows on the condition")
+
+	assert.False(t, providerMaintenanceEnded.Update(event.UpdateEvent{ObjectOld: cleared, ObjectNew: inMaintenance}))
+	assert.False(t, providerMaintenanceEnded.Update(event.UpdateEvent{ObjectOld: recorded, ObjectNew: recorded.DeepCopy()}))
+}
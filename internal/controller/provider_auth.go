@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+)
+
+// RotateAuthTokenAnnotation on a Provider requests a new bearer token
+// whenever its value changes (a timestamp works well). The previous token
+// stays accepted for remote.AuthTokenGracePeriod.
+const RotateAuthTokenAnnotation = "virtrigaud.io/rotate-auth-token"
+
+// authTokenRotationAnnotation on the token Secret records the
+// RotateAuthTokenAnnotation value last acted on.
+const authTokenRotationAnnotation = "virtrigaud.io/auth-token-rotation"
+
+// providerAuthMountPath is where the token Secret is mounted in the
+// provider pod. It MUST match sdk/provider/server.ProviderAuthMountPath,
+// duplicated for the same reason as providerTLSMountPath.
+const providerAuthMountPath = "/etc/virtrigaud/auth"
+
+// providerAuthVolumeName names the token Secret's volume and mount.
+const providerAuthVolumeName = "provider-auth"
+
+// providerTokenAuthEnabled reports whether the provider is deployed with a
+// bearer token. Tokens are only issued alongside TLS: a plaintext provider
+// bypasses auth, and sending the token in the clear would leak it.
+func providerTokenAuthEnabled(provider *infravirtrigaudiov1beta1.Provider) bool {
+	return providerTLSEnabled(provider)
+}
+
+// newAuthToken returns 32 random bytes, URL-safe base64 encoded.
+func newAuthToken() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b[:]), nil
+}
+
+// reconcileAuthToken creates the provider's token Secret, rotates the token
+// when RotateAuthTokenAnnotation changes, and drops the previous token once
+// the grace window has passed. It returns how long until that window ends,
+// or zero when no rotation is in progress.
+func (r *ProviderReconciler) reconcileAuthToken(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+	trigger := provider.Annotations[RotateAuthTokenAnnotation]
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: provider.Namespace, Name: remote.AuthSecretName(provider)}
+	err := r.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		token, err := newAuthToken()
+		if err != nil {
+			return 0, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "virtrigaud-provider",
+					"app.kubernetes.io/instance":   provider.Name,
+					"app.kubernetes.io/component":  "provider-auth",
+					"app.kubernetes.io/managed-by": "virtrigaud",
+				},
+				Annotations: map[string]string{authTokenRotationAnnotation: trigger},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{remote.AuthTokenKey: []byte(token)},
+		}
+		if err := controllerutil.SetControllerReference(provider, secret, r.Scheme); err != nil {
+			return 0, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return 0, fmt.Errorf("failed to create token Secret: %w", err)
+		}
+		logger.Info("Created provider token Secret", "secret", key.Name)
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to get token Secret: %w", err)
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	if secret.Annotations[authTokenRotationAnnotation] != trigger || len(secret.Data[remote.AuthTokenKey]) == 0 {
+		token, err := newAuthToken()
+		if err != nil {
+			return 0, err
+		}
+		if current := secret.Data[remote.AuthTokenKey]; len(current) > 0 {
+			secret.Data[remote.AuthPreviousTokenKey] = current
+		}
+		secret.Data[remote.AuthTokenKey] = []byte(token)
+		secret.Annotations[authTokenRotationAnnotation] = trigger
+		secret.Annotations[remote.AuthTokenRotatedAtAnnotation] = now.UTC().Format(time.RFC3339)
+		if err := r.Update(ctx, secret); err != nil {
+			return 0, fmt.Errorf("failed to rotate token: %w", err)
+		}
+		logger.Info("Rotated provider token; previous token accepted during grace period",
+			"secret", key.Name, "grace", remote.AuthTokenGracePeriod)
+		return remote.AuthTokenGracePeriod, nil
+	}
+
+	if len(secret.Data[remote.AuthPreviousTokenKey]) == 0 {
+		return 0, nil
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[remote.AuthTokenRotatedAtAnnotation])
+	if err == nil {
+		if remaining := rotatedAt.Add(remote.AuthTokenGracePeriod).Sub(now); remaining > 0 {
+			return remaining, nil
+		}
+	}
+	delete(secret.Data, remote.AuthPreviousTokenKey)
+	if err := r.Update(ctx, secret); err != nil {
+		return 0, fmt.Errorf("failed to retire previous token: %w", err)
+	}
+	logger.Info("Retired previous provider token", "secret", key.Name)
+	return 0, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+)
+
+func TestReconcileAuthToken_CreateRotateRetire(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("auth", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: true})
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(prov).Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Namespace: "default", Name: remote.AuthSecretName(prov)}
+
+	wait, err := r.reconcileAuthToken(ctx, prov)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+	secret := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, key, secret))
+	first := string(secret.Data[remote.AuthTokenKey])
+	require.NotEmpty(t, first)
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, "auth", secret.OwnerReferences[0].Name)
+
+	// Nothing changes without a rotation request.
+	_, err = r.reconcileAuthToken(ctx, prov)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, key, secret))
+	assert.Equal(t, first, string(secret.Data[remote.AuthTokenKey]))
+
+	prov.Annotations = map[string]string{RotateAuthTokenAnnotation: "2026-01-01T00:00:00Z"}
+	wait, err = r.reconcileAuthToken(ctx, prov)
+	require.NoError(t, err)
+	assert.Equal(t, remote.AuthTokenGracePeriod, wait)
+	require.NoError(t, cli.Get(ctx, key, secret))
+	second := string(secret.Data[remote.AuthTokenKey])
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, string(secret.Data[remote.AuthPreviousTokenKey]))
+	assert.Equal(t, first, remote.ActiveAuthToken(secret, time.Now()), "manager keeps the old token during the grace window")
+
+	wait, err = r.reconcileAuthToken(ctx, prov)
+	require.NoError(t, err)
+	assert.Positive(t, wait)
+	assert.LessOrEqual(t, wait, remote.AuthTokenGracePeriod)
+
+	// Back-date the rotation past the grace window.
+	secret.Annotations[remote.AuthTokenRotatedAtAnnotation] = time.Now().Add(-2 * remote.AuthTokenGracePeriod).UTC().Format(time.RFC3339)
+	require.NoError(t, cli.Update(ctx, secret))
+	wait, err = r.reconcileAuthToken(ctx, prov)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+	require.NoError(t, cli.Get(ctx, key, secret))
+	assert.NotContains(t, secret.Data, remote.AuthPreviousTokenKey)
+	assert.Equal(t, second, string(secret.Data[remote.AuthTokenKey]))
+}
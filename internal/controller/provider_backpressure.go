@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+)
+
+// A provider that already runs its maxConcurrentClones turns a Create away
+// with a Busy error (gRPC ResourceExhausted with a RetryInfo delay). That is
+// backpressure, not a failure: the provider is held for the delay, during
+// which no VM sends it another Create, and every waiting VM is requeued at a
+// jittered point after the hold so a burst reaches the provider spread out
+// instead of all at once. No Warning event is raised and the Provisioning
+// condition reads ProviderThrottled.
+const (
+	// providerBusyMaxHold bounds the delay a provider may ask for.
+	providerBusyMaxHold = 5 * time.Minute
+	// providerBusyMinSpread is the smallest window the retries of waiting
+	// VMs are spread over.
+	providerBusyMinSpread = time.Second
+)
+
+// backpressureTracker remembers until when each Provider asked not to be
+// sent new Creates.
+type backpressureTracker struct {
+	mu    sync.Mutex
+	until map[types.NamespacedName]time.Time
+	now   func() time.Time
+}
+
+func newBackpressureTracker() *backpressureTracker {
+	return &backpressureTracker{
+		until: make(map[types.NamespacedName]time.Time),
+		now:   time.Now,
+	}
+}
+
+// providerBackpressure is shared by all VirtualMachine reconcilers, so every
+// VM on a busy provider waits out the same hold.
+var providerBackpressure = newBackpressureTracker()
+
+// hold records that provider is busy for delay. A hold is only ever
+// extended, never shortened, by a later Busy answer.
+func (t *backpressureTracker) hold(provider types.NamespacedName, delay time.Duration) {
+	if delay <= 0 {
+		delay = common.DefaultCloneRetryAfter
+	}
+	delay = min(delay, providerBusyMaxHold)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := t.now().Add(delay)
+	if until.After(t.until[provider]) {
+		t.until[provider] = until
+	}
+}
+
+// remaining returns how long provider is still held, zero once it is not.
+func (t *backpressureTracker) remaining(provider types.NamespacedName) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until, ok := t.until[provider]
+	if !ok {
+		return 0
+	}
+	left := until.Sub(t.now())
+	if left <= 0 {
+		delete(t.until, provider)
+		return 0
+	}
+	return left
+}
+
+// backpressureRetry spreads the retries of VMs waiting wait over half as
+// long again, so they do not all return to the provider at once.
+func backpressureRetry(wait time.Duration) time.Duration {
+	return wait + rand.N(max(wait/2, providerBusyMinSpread))
+}
+
+// deferCreate leaves vm waiting for a busy provider and requeues it after
+// wait plus jitter. The VMCreateDeferred event is raised when the VM starts
+// waiting, not on every retry.
+func (r *VirtualMachineReconciler) deferCreate(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerName string, wait time.Duration, err error) ctrl.Result {
+	retry := backpressureRetry(wait)
+	logger := log.FromContext(ctx)
+	if err != nil {
+		logger.V(1).Info("Provider is busy; deferring VM creation", "provider", providerName, "retryAfter", retry, "error", err.Error())
+	} else {
+		logger.V(1).Info("Provider is held after reporting busy; deferring VM creation", "provider", providerName, "retryAfter", retry)
+	}
+
+	message := fmt.Sprintf("Provider %s is at its limit for concurrent creates; creation is retried shortly", providerName)
+	if cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionProvisioning); cond == nil || cond.Reason != k8s.ReasonProviderThrottled {
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonVMCreateDeferred, message)
+	}
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderThrottled, message)
+	r.updateStatus(ctx, vm)
+	return ctrl.Result{RequeueAfter: retry}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// throttlingProvider answers Create with err until it is cleared.
+type throttlingProvider struct {
+	stubProvider
+	err   error
+	calls int
+}
+
+func (p *throttlingProvider) Create(_ context.Context, _ contracts.CreateRequest) (contracts.CreateResponse, error) {
+	p.calls++
+	if p.err != nil {
+		return contracts.CreateResponse{}, p.err
+	}
+	return contracts.CreateResponse{ID: "vm-1"}, nil
+}
+
+func TestCreateVM_ProviderBusy(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	providerBackpressure.now = func() time.Time { return now }
+	t.Cleanup(func() { providerBackpressure.now = time.Now })
+
+	s := coverageTestScheme(t)
+	_, class := providerAndClass("default")
+	first, second := baseVM("default"), baseVM("default")
+	first.Name, second.Name = "burst-1", "burst-2"
+	first.Spec.ImageRef = &infravirtrigaudiov1beta1.ObjectRef{Name: "img"}
+	second.Spec.ImageRef = &infravirtrigaudiov1beta1.ObjectRef{Name: "img"}
+	r := newTestReconciler(s, nil, first, second)
+	recorder := record.NewFakeRecorder(8)
+	r.Recorder = recorder
+	k8sProv := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "busy-prov", Namespace: "default"}}
+	t.Cleanup(func() { delete(providerBackpressure.until, client.ObjectKeyFromObject(k8sProv)) })
+	ctx := context.Background()
+
+	prov := &throttlingProvider{err: contracts.NewBusyError("5 clones already in progress", 20*time.Second, nil)}
+	create := func(vm *infravirtrigaudiov1beta1.VirtualMachine) time.Duration {
+		result, err := r.createVM(ctx, vm, prov, k8sProv, class, nil, nil)
+		require.NoError(t, err)
+		return result.RequeueAfter
+	}
+
+	retry := create(first)
+	assert.GreaterOrEqual(t, retry, 20*time.Second)
+	assert.Less(t, retry, 30*time.Second, "retries are spread over half the delay again")
+	cond := k8s.GetCondition(first.Status.Conditions, k8s.ConditionProvisioning)
+	require.NotNil(t, cond)
+	assert.Equal(t, k8s.ReasonProviderThrottled, cond.Reason)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal "+events.ReasonVMCreateDeferred)
+
+	create(first)
+	assert.Empty(t, recorder.Events, "a VM already waiting raises no further event")
+
+	// The provider is held: the second VM waits without calling Create.
+	calls := prov.calls
+	now = now.Add(5 * time.Second)
+	retry = create(second)
+	assert.Equal(t, calls, prov.calls)
+	assert.GreaterOrEqual(t, retry, 15*time.Second)
+	assert.Contains(t, <-recorder.Events, events.ReasonVMCreateDeferred)
+
+	// Once the hold has passed the provider is asked again.
+	now = now.Add(20 * time.Second)
+	prov.err = nil
+	create(second)
+	assert.Equal(t, calls+1, prov.calls)
+	assert.Equal(t, "vm-1", second.Status.ID)
+	assert.Contains(t, <-recorder.Events, events.ReasonVMCreated)
+}
+
+func TestCreateVM_ProviderFailureIsNotBusy(t *testing.T) {
+	s := coverageTestScheme(t)
+	_, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Spec.ImageRef = &infravirtrigaudiov1beta1.ObjectRef{Name: "img"}
+	r := newTestReconciler(s, nil, vm)
+	recorder := record.NewFakeRecorder(8)
+	r.Recorder = recorder
+	k8sProv := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "failing-prov", Namespace: "default"}}
+
+	prov := &throttlingProvider{err: errors.New("datastore full")}
+	_, err := r.createVM(context.Background(), vm, prov, k8sProv, class, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, "Warning "+events.ReasonVMCreateFailed)
+	assert.Zero(t, providerBackpressure.remaining(client.ObjectKeyFromObject(k8sProv)))
+}
+
+func TestBackpressureTracker(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newBackpressureTracker()
+	tracker.now = func() time.Time { return now }
+	name := types.NamespacedName{Namespace: "default", Name: "p"}
+
+	assert.Zero(t, tracker.remaining(name))
+	tracker.hold(name, 0)
+	assert.Equal(t, 10*time.Second, tracker.remaining(name), "no delay holds for the default")
+	tracker.hold(name, time.Second)
+	assert.Equal(t, 10*time.Second, tracker.remaining(name), "a shorter hold does not cut one short")
+	tracker.hold(name, time.Hour)
+	assert.Equal(t, providerBusyMaxHold, tracker.remaining(name))
+	now = now.Add(providerBusyMaxHold)
+	assert.Zero(t, tracker.remaining(name))
+	assert.Empty(t, tracker.until)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// TestReconcileRequiredCapabilities verifies the Degraded condition follows
+// spec.requiredCapabilities against the reported manifest, and that the
+// warning names the missing capability and the image once.
+func TestReconcileRequiredCapabilities(t *testing.T) {
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(4)
+	r := &ProviderReconciler{Recorder: recorder}
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "kvm", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.ProviderSpec{
+			Type:    infravirtrigaudiov1beta1.ProviderTypeLibvirt,
+			Runtime: &infravirtrigaudiov1beta1.ProviderRuntimeSpec{Image: "ghcr.io/projectbeskar/virtrigaud/provider-libvirt:v0.3.0"},
+		},
+	}
+
+	r.reconcileRequiredCapabilities(ctx, provider)
+	assert.Nil(t, k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded), "nothing required, no condition")
+
+	provider.Spec.RequiredCapabilities = []string{"image_import", "snapshots"}
+	r.reconcileRequiredCapabilities(ctx, provider)
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionUnknown, c.Status)
+
+	provider.Status.ReportedCapabilities = &infravirtrigaudiov1beta1.ReportedCapabilities{
+		CapabilityManifest: []string{"validate", "create", "delete", "power", "describe", "get_capabilities", "snapshots"},
+	}
+	r.reconcileRequiredCapabilities(ctx, provider)
+	c = k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, providerReasonMissingCapabilities, c.Reason)
+	assert.Contains(t, c.Message, "image_import")
+	assert.NotContains(t, c.Message, "snapshots")
+	assert.Contains(t, c.Message, "provider-libvirt:v0.3.0")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonProviderMissingCapabilities)
+
+	r.reconcileRequiredCapabilities(ctx, provider)
+	assert.Empty(t, recorder.Events, "the warning is emitted on the transition, not on every reconcile")
+
+	provider.Status.ReportedCapabilities.CapabilityManifest = append(provider.Status.ReportedCapabilities.CapabilityManifest, "image_import")
+	r.reconcileRequiredCapabilities(ctx, provider)
+	c = k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, providerReasonCapabilitiesSatisfied, c.Reason)
+
+	provider.Spec.RequiredCapabilities = nil
+	r.reconcileRequiredCapabilities(ctx, provider)
+	assert.Nil(t, k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded))
+}
+
+// TestReconcileRequiredCapabilities_Flags verifies an image without a
+// manifest is judged by its Supports* flags.
+func TestReconcileRequiredCapabilities_Flags(t *testing.T) {
+	r := &ProviderReconciler{Recorder: record.NewFakeRecorder(4)}
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "kvm", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.ProviderSpec{
+			Type:                 infravirtrigaudiov1beta1.ProviderTypeLibvirt,
+			RequiredCapabilities: []string{"create", "image_import"},
+		},
+		Status: infravirtrigaudiov1beta1.ProviderStatus{
+			ReportedCapabilities: &infravirtrigaudiov1beta1.ReportedCapabilities{SupportsImageImport: true},
+		},
+	}
+
+	r.reconcileRequiredCapabilities(context.Background(), provider)
+	c := k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+}
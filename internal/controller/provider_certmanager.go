@@ -0,0 +1,370 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+)
+
+// certificateGVK is cert-manager's Certificate. virtrigaud does not depend
+// on the cert-manager module, so Certificates are handled unstructured; that
+// also keeps the manager starting on clusters without cert-manager.
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// CertificateReady Condition vocabulary, set on Providers with
+// spec.runtime.service.tls.certManager.
+//
+// Reasons:
+//   - Issued — the Secret holds an issued certificate and CA.
+//   - Issuing — cert-manager has not issued the certificate yet; the
+//     Deployment is held back until it has.
+//   - CertManagerNotInstalled — the cert-manager.io/v1 API is not served.
+const (
+	providerConditionCertificateReady     = "CertificateReady"
+	providerReasonCertificateIssued       = "Issued"
+	providerReasonCertificateIssuing      = "Issuing"
+	providerReasonCertManagerNotInstalled = "CertManagerNotInstalled"
+
+	errReasonCertificateReconcile = "certificate-reconcile-failed"
+)
+
+// Pod template annotations that roll the provider Deployment when its TLS
+// material changes in a way the provider cannot pick up by itself.
+const (
+	// tlsCAHashAnnotation carries a hash of the CA. The SDK loads the CA
+	// once at startup, so a new CA always needs a restart.
+	tlsCAHashAnnotation = "virtrigaud.io/tls-ca-hash"
+
+	// tlsCertRevisionAnnotation carries a hash of the certificate, for
+	// providers with hotReload=false only.
+	tlsCertRevisionAnnotation = "virtrigaud.io/tls-cert-revision"
+)
+
+// providerCertificate is the outcome of reconcileCertificate.
+type providerCertificate struct {
+	// notInstalled is set when cert-manager is not installed.
+	notInstalled bool
+	// ready is set once the Secret can be mounted.
+	ready bool
+	// renewalTime is when cert-manager will next renew, if known.
+	renewalTime *metav1.Time
+	// podAnnotations go on the provider's pod template.
+	podAnnotations map[string]string
+}
+
+// providerCertManager returns the cert-manager block when the Provider's
+// certificate comes from cert-manager. SPIFFE takes precedence.
+func providerCertManager(provider *infravirtrigaudiov1beta1.Provider) *infravirtrigaudiov1beta1.ProviderCertManagerSpec {
+	if !providerTLSEnabled(provider) || providerSPIFFE(provider) != nil {
+		return nil
+	}
+	return provider.Spec.Runtime.Service.TLS.CertManager
+}
+
+// reconcileCertificate has cert-manager issue the provider's certificate
+// into remote.TLSSecretName, copies the CA to the CA bundle ConfigMap the
+// resolver trusts, and reports the certificate on Status.TLS and the
+// CertificateReady Condition.
+func (r *ProviderReconciler) reconcileCertificate(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, spec *infravirtrigaudiov1beta1.ProviderCertManagerSpec) (*providerCertificate, error) {
+	secretName := remote.TLSSecretName(provider)
+	if provider.Status.TLS == nil || provider.Status.TLS.SecretName != secretName {
+		provider.Status.TLS = &infravirtrigaudiov1beta1.ProviderTLSStatus{SecretName: secretName}
+	}
+
+	cert, err := r.applyCertificate(ctx, provider, spec, secretName)
+	if meta.IsNoMatchError(err) {
+		provider.Status.TLS.Ready = false
+		k8s.SetCondition(&provider.Status.Conditions,
+			providerConditionCertificateReady, metav1.ConditionFalse,
+			providerReasonCertManagerNotInstalled,
+			"spec.runtime.service.tls.certManager is set but cert-manager (cert-manager.io/v1) is not installed. Install cert-manager, or remove certManager and set secretRef to a Secret with tls.crt/tls.key/ca.crt.")
+		return &providerCertificate{notInstalled: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &providerCertificate{}
+	issued, issuedMessage := certificateReadyCondition(cert)
+	provider.Status.TLS.NotAfter = certificateTime(cert, "notAfter")
+	provider.Status.TLS.RenewalTime = certificateTime(cert, "renewalTime")
+	out.renewalTime = provider.Status.TLS.RenewalTime
+
+	secret := &corev1.Secret{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: provider.Namespace, Name: secretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get TLS Secret: %w", err)
+	}
+	certPEM, keyPEM, caPEM := secret.Data["tls.crt"], secret.Data["tls.key"], secret.Data["ca.crt"]
+
+	switch {
+	case len(certPEM) == 0 || len(keyPEM) == 0:
+		message := fmt.Sprintf("Waiting for cert-manager to issue Certificate %q into Secret %q", cert.GetName(), secretName)
+		if issuedMessage != "" {
+			message += ": " + issuedMessage
+		}
+		provider.Status.TLS.Ready = false
+		k8s.SetCondition(&provider.Status.Conditions,
+			providerConditionCertificateReady, metav1.ConditionFalse,
+			providerReasonCertificateIssuing, message)
+		return out, nil
+
+	case len(caPEM) == 0:
+		provider.Status.TLS.Ready = false
+		k8s.SetCondition(&provider.Status.Conditions,
+			providerConditionCertificateReady, metav1.ConditionFalse,
+			providerReasonCertificateIssuing,
+			fmt.Sprintf("Secret %q has no ca.crt; the issuer must publish its CA (CA and self-signed issuers do)", secretName))
+		return out, nil
+	}
+
+	if err := r.reconcileCABundle(ctx, provider, caPEM); err != nil {
+		return nil, err
+	}
+
+	out.ready = true
+	out.podAnnotations = map[string]string{tlsCAHashAnnotation: shortHash(caPEM)}
+	if spec.HotReload != nil && !*spec.HotReload {
+		out.podAnnotations[tlsCertRevisionAnnotation] = shortHash(certPEM)
+	}
+
+	provider.Status.TLS.Ready = true
+	message := fmt.Sprintf("Certificate %q issued into Secret %q", cert.GetName(), secretName)
+	if provider.Status.TLS.NotAfter != nil {
+		message += fmt.Sprintf(", valid until %s", provider.Status.TLS.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if !issued && issuedMessage != "" {
+		message += "; cert-manager reports: " + issuedMessage
+	}
+	k8s.SetCondition(&provider.Status.Conditions,
+		providerConditionCertificateReady, metav1.ConditionTrue,
+		providerReasonCertificateIssued, message)
+	return out, nil
+}
+
+// applyCertificate creates or updates the provider's Certificate and
+// returns it as stored.
+func (r *ProviderReconciler) applyCertificate(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, spec *infravirtrigaudiov1beta1.ProviderCertManagerSpec, secretName string) (*unstructured.Unstructured, error) {
+	logger := log.FromContext(ctx)
+
+	serviceName := r.getServiceName(provider)
+	var dnsNames []interface{}
+	for _, name := range []string{
+		serviceName,
+		serviceName + "." + provider.Namespace,
+		serviceName + "." + provider.Namespace + ".svc",
+		serviceName + "." + provider.Namespace + ".svc.cluster.local",
+	} {
+		dnsNames = append(dnsNames, name)
+	}
+
+	issuerRef := map[string]interface{}{"name": spec.IssuerRef.Name}
+	if spec.IssuerRef.Kind != "" {
+		issuerRef["kind"] = spec.IssuerRef.Kind
+	}
+	if spec.IssuerRef.Group != "" {
+		issuerRef["group"] = spec.IssuerRef.Group
+	}
+	desiredSpec := map[string]interface{}{
+		"secretName": secretName,
+		"dnsNames":   dnsNames,
+		// The manager presents the same certificate as its client
+		// certificate, so it needs both usages.
+		"usages":    []interface{}{"digital signature", "key encipherment", "server auth", "client auth"},
+		"issuerRef": issuerRef,
+	}
+	if spec.Duration != nil {
+		desiredSpec["duration"] = spec.Duration.Duration.String()
+	}
+
+	desired := &unstructured.Unstructured{}
+	desired.SetGroupVersionKind(certificateGVK)
+	desired.SetName(r.getDeploymentName(provider))
+	desired.SetNamespace(provider.Namespace)
+	desired.SetLabels(map[string]string{
+		"app.kubernetes.io/name":       "virtrigaud-provider",
+		"app.kubernetes.io/instance":   provider.Name,
+		"app.kubernetes.io/component":  "provider-tls",
+		"app.kubernetes.io/managed-by": "virtrigaud",
+	})
+	desired.Object["spec"] = desiredSpec
+	if err := controllerutil.SetControllerReference(provider, desired, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(certificateGVK)
+	err := r.Get(ctx, types.NamespacedName{Namespace: provider.Namespace, Name: desired.GetName()}, existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create Certificate: %w", err)
+		}
+		logger.Info("Created provider Certificate", "certificate", desired.GetName(), "secret", secretName)
+		return desired, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Compare only the fields the controller sets: the stored object
+	// carries cert-manager's defaults on top of them.
+	currentSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	if currentSpec == nil {
+		currentSpec = map[string]interface{}{}
+	}
+	changed := false
+	for key, value := range desiredSpec {
+		if !reflect.DeepEqual(currentSpec[key], value) {
+			changed = true
+			break
+		}
+	}
+	if _, has := currentSpec["duration"]; has && spec.Duration == nil {
+		changed = true
+	}
+	if !changed {
+		return existing, nil
+	}
+	for key, value := range desiredSpec {
+		currentSpec[key] = value
+	}
+	if spec.Duration == nil {
+		delete(currentSpec, "duration")
+	}
+	existing.Object["spec"] = currentSpec
+	if err := r.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update Certificate: %w", err)
+	}
+	logger.Info("Updated provider Certificate", "certificate", existing.GetName())
+	return existing, nil
+}
+
+// certificateReadyCondition returns whether cert-manager reports the
+// Certificate Ready, and the message of its Ready condition.
+func certificateReadyCondition(cert *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == string(metav1.ConditionTrue), message
+	}
+	return false, ""
+}
+
+// certificateTime parses an RFC 3339 time from the Certificate's status.
+func certificateTime(cert *unstructured.Unstructured, field string) *metav1.Time {
+	value, _, _ := unstructured.NestedString(cert.Object, "status", field)
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
+// reconcileCABundle writes the provider's CA to its CA bundle ConfigMap.
+func (r *ProviderReconciler) reconcileCABundle(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, caPEM []byte) error {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: provider.Namespace, Name: remote.CABundleConfigMapName(provider)}
+	err := r.Get(ctx, key, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "virtrigaud-provider",
+					"app.kubernetes.io/instance":   provider.Name,
+					"app.kubernetes.io/component":  "provider-tls",
+					"app.kubernetes.io/managed-by": "virtrigaud",
+					remote.CABundleLabel:           "true",
+				},
+			},
+			Data: map[string]string{remote.CABundleKey: string(caPEM)},
+		}
+		if err := controllerutil.SetControllerReference(provider, cm, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create CA bundle ConfigMap: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
+	}
+
+	if cm.Data[remote.CABundleKey] == string(caPEM) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[remote.CABundleKey] = string(caPEM)
+	if err := r.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update CA bundle ConfigMap: %w", err)
+	}
+	log.FromContext(ctx).Info("Updated provider CA bundle", "configMap", key.Name)
+	return nil
+}
+
+// certificateRequeue returns when to look at the certificate again: just
+// after its renewal, so the status and, for hotReload=false, the pods
+// follow it.
+func certificateRequeue(cert *providerCertificate, now time.Time) time.Duration {
+	if cert == nil || cert.renewalTime == nil {
+		return 0
+	}
+	return cert.renewalTime.Add(time.Minute).Sub(now)
+}
+
+// shortHash returns the first 16 hex digits of data's SHA-256.
+func shortHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// certManagerIssuerKind returns the issuer kind, applying the CRD default
+// for objects that predate it.
+func certManagerIssuerKind(spec *infravirtrigaudiov1beta1.ProviderCertManagerSpec) string {
+	if spec.IssuerRef.Kind != "" {
+		return spec.IssuerRef.Kind
+	}
+	return "Issuer"
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
+)
+
+func certManagerProvider(name string, hotReload bool) *infravirtrigaudiov1beta1.Provider {
+	return providerWithRuntime(name, &infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled: true,
+		CertManager: &infravirtrigaudiov1beta1.ProviderCertManagerSpec{
+			IssuerRef: infravirtrigaudiov1beta1.CertManagerIssuerReference{Name: "virtrigaud-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			Duration:  &metav1.Duration{Duration: 720 * time.Hour},
+			HotReload: &hotReload,
+		},
+	})
+}
+
+// issueCertificate plays cert-manager: it writes the Secret and marks the
+// Certificate Ready.
+func issueCertificate(t *testing.T, cli client.Client, prov *infravirtrigaudiov1beta1.Provider, certPEM, caPEM string, renewal time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: prov.Namespace, Name: remote.TLSSecretName(prov)}
+	data := map[string][]byte{"tls.crt": []byte(certPEM), "tls.key": []byte("key"), "ca.crt": []byte(caPEM)}
+	if err := cli.Get(ctx, key, secret); err == nil {
+		secret.Data = data
+		require.NoError(t, cli.Update(ctx, secret))
+	} else {
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}, Type: corev1.SecretTypeTLS, Data: data}
+		require.NoError(t, cli.Create(ctx, secret))
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: prov.Namespace, Name: "virtrigaud-provider-default-" + prov.Name}, cert))
+	require.NoError(t, unstructured.SetNestedField(cert.Object, map[string]interface{}{
+		"conditions":  []interface{}{map[string]interface{}{"type": "Ready", "status": "True", "message": "Certificate is up to date and has not expired"}},
+		"notAfter":    renewal.Add(240 * time.Hour).UTC().Format(time.RFC3339),
+		"renewalTime": renewal.UTC().Format(time.RFC3339),
+	}, "status"))
+	require.NoError(t, cli.Update(ctx, cert))
+}
+
+func reconcileProvider(t *testing.T, r *ProviderReconciler, name string) (ctrl.Result, *infravirtrigaudiov1beta1.Provider) {
+	t.Helper()
+	key := types.NamespacedName{Name: name, Namespace: "default"}
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, r.Get(context.Background(), key, latest))
+	return res, latest
+}
+
+func TestProvider_CertManager_IssuesBeforeDeploying(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := certManagerProvider("cm", true)
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	res, latest := reconcileProvider(t, r, "cm")
+	assert.NotZero(t, res.RequeueAfter)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: "virtrigaud-provider-default-cm"}, cert))
+	secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+	assert.Equal(t, "virtrigaud-provider-default-cm-tls", secretName)
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	assert.Contains(t, dnsNames, "virtrigaud-provider-default-cm.default.svc.cluster.local",
+		"the resolver verifies the Service FQDN")
+	issuer, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "virtrigaud-ca", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuer)
+	duration, _, _ := unstructured.NestedString(cert.Object, "spec", "duration")
+	assert.Equal(t, "720h0m0s", duration)
+	require.Len(t, cert.GetOwnerReferences(), 1)
+
+	// Nothing to mount yet.
+	deps := &appsv1.DeploymentList{}
+	require.NoError(t, cli.List(ctx, deps))
+	assert.Empty(t, deps.Items, "the Deployment waits for the Secret")
+	assert.Equal(t, infravirtrigaudiov1beta1.ProviderRuntimePhasePending, latest.Status.Runtime.Phase)
+	require.NotNil(t, latest.Status.TLS)
+	assert.False(t, latest.Status.TLS.Ready)
+	c := getConditionByType(t, latest.Status.Conditions, providerConditionCertificateReady)
+	require.NotNil(t, c)
+	assert.Equal(t, providerReasonCertificateIssuing, c.Reason)
+	c = getConditionByType(t, latest.Status.Conditions, providerConditionTLSConfigured)
+	require.NotNil(t, c)
+	assert.Equal(t, providerReasonTLSCertManager, c.Reason)
+
+	renewal := time.Now().Add(2 * time.Hour)
+	issueCertificate(t, cli, prov, "cert-1", "ca-1", renewal)
+	_, latest = reconcileProvider(t, r, "cm")
+
+	require.NotNil(t, latest.Status.TLS)
+	assert.True(t, latest.Status.TLS.Ready)
+	require.NotNil(t, latest.Status.TLS.NotAfter)
+	require.NotNil(t, latest.Status.TLS.RenewalTime)
+	assert.WithinDuration(t, renewal, latest.Status.TLS.RenewalTime.Time, time.Second)
+	c = getConditionByType(t, latest.Status.Conditions, providerConditionCertificateReady)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, providerReasonCertificateIssued, c.Reason)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: "virtrigaud-provider-default-cm"}, dep))
+	dep.Status.ReadyReplicas = 1
+	require.NoError(t, cli.Status().Update(ctx, dep))
+	res, _ = reconcileProvider(t, r, "cm")
+	assert.InDelta(t, time.Until(renewal.Add(time.Minute)).Seconds(), res.RequeueAfter.Seconds(), 5,
+		"the next reconcile follows the renewal")
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(dep), dep))
+	var tlsVolume *corev1.Volume
+	for i := range dep.Spec.Template.Spec.Volumes {
+		if dep.Spec.Template.Spec.Volumes[i].Name == providerTLSVolumeName {
+			tlsVolume = &dep.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	require.NotNil(t, tlsVolume)
+	assert.Equal(t, "virtrigaud-provider-default-cm-tls", tlsVolume.Secret.SecretName)
+	caHash := dep.Spec.Template.Annotations[tlsCAHashAnnotation]
+	assert.NotEmpty(t, caHash)
+	assert.NotContains(t, dep.Spec.Template.Annotations, tlsCertRevisionAnnotation, "hot-reloading providers are not rolled on renewal")
+
+	bundle := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: remote.CABundleConfigMapName(prov)}, bundle))
+	assert.Equal(t, "true", bundle.Labels[remote.CABundleLabel])
+	assert.Equal(t, "ca-1", bundle.Data[remote.CABundleKey])
+
+	// A renewed leaf leaves the pods alone; a new CA rolls them.
+	issueCertificate(t, cli, prov, "cert-2", "ca-1", renewal)
+	reconcileProvider(t, r, "cm")
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(dep), dep))
+	assert.Equal(t, caHash, dep.Spec.Template.Annotations[tlsCAHashAnnotation])
+
+	issueCertificate(t, cli, prov, "cert-3", "ca-2", renewal)
+	reconcileProvider(t, r, "cm")
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(dep), dep))
+	assert.NotEqual(t, caHash, dep.Spec.Template.Annotations[tlsCAHashAnnotation])
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(bundle), bundle))
+	assert.Equal(t, "ca-2", bundle.Data[remote.CABundleKey])
+}
+
+func TestProvider_CertManager_RollsWithoutHotReload(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := certManagerProvider("cm-cold", false)
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	reconcileProvider(t, r, "cm-cold")
+	issueCertificate(t, cli, prov, "cert-1", "ca-1", time.Now().Add(time.Hour))
+	reconcileProvider(t, r, "cm-cold")
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: "virtrigaud-provider-default-cm-cold"}, dep))
+	revision := dep.Spec.Template.Annotations[tlsCertRevisionAnnotation]
+	require.NotEmpty(t, revision)
+
+	issueCertificate(t, cli, prov, "cert-2", "ca-1", time.Now().Add(time.Hour))
+	reconcileProvider(t, r, "cm-cold")
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(dep), dep))
+	assert.NotEqual(t, revision, dep.Spec.Template.Annotations[tlsCertRevisionAnnotation])
+}
+
+func TestProvider_CertManager_NotInstalled(t *testing.T) {
+	sch := newProviderTLSScheme(t)
+	prov := certManagerProvider("cm-missing", true)
+	noMatch := func(obj client.Object) error {
+		if u, ok := obj.(*unstructured.Unstructured); ok && u.GroupVersionKind() == certificateGVK {
+			return &meta.NoKindMatchError{GroupKind: certificateGVK.GroupKind(), SearchedVersions: []string{"v1"}}
+		}
+		return nil
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := noMatch(obj); err != nil {
+					return err
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := noMatch(obj); err != nil {
+					return err
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	res, latest := reconcileProvider(t, r, "cm-missing")
+	assert.NotZero(t, res.RequeueAfter)
+
+	c := getConditionByType(t, latest.Status.Conditions, providerConditionCertificateReady)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, providerReasonCertManagerNotInstalled, c.Reason)
+	assert.Contains(t, c.Message, "not installed")
+	assert.Equal(t, infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed, latest.Status.Runtime.Phase)
+
+	deps := &appsv1.DeploymentList{}
+	require.NoError(t, cli.List(context.Background(), deps))
+	assert.Empty(t, deps.Items)
+}
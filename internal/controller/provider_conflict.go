@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// A provider call that raced another change to the same VM, such as a PVE
+// config lock or a vSphere task already running on the VM, fails with a
+// Conflict (gRPC Aborted). It is not a failure: the call is retried after
+// providerConflictRetry without a Warning event or an error metric. Only a
+// conflict that persists beyond providerConflictWarnAfter is reported, once,
+// with a ProviderConflictPersisting event.
+const (
+	providerConflictRetry     = 5 * time.Second
+	providerConflictWarnAfter = 2 * time.Minute
+)
+
+// conflictTracker remembers since when each object's provider calls have
+// been conflicting.
+type conflictTracker struct {
+	mu     sync.Mutex
+	since  map[types.NamespacedName]time.Time
+	warned map[types.NamespacedName]bool
+	now    func() time.Time
+}
+
+func newConflictTracker() *conflictTracker {
+	return &conflictTracker{
+		since:  make(map[types.NamespacedName]time.Time),
+		warned: make(map[types.NamespacedName]bool),
+		now:    time.Now,
+	}
+}
+
+// vmConflicts and snapshotConflicts are shared by all reconcilers of their
+// kind, so a conflict run survives a reconciler restart within the process.
+var (
+	vmConflicts       = newConflictTracker()
+	snapshotConflicts = newConflictTracker()
+)
+
+// observe records a conflict for name. It returns since when name has been
+// conflicting, and true the first time that exceeds
+// providerConflictWarnAfter.
+func (t *conflictTracker) observe(name types.NamespacedName) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	since, ok := t.since[name]
+	if !ok {
+		t.since[name] = now
+		return now, false
+	}
+	if t.warned[name] || now.Sub(since) < providerConflictWarnAfter {
+		return since, false
+	}
+	t.warned[name] = true
+	return since, true
+}
+
+// clear ends name's run of conflicts.
+func (t *conflictTracker) clear(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.since, name)
+	delete(t.warned, name)
+}
+
+// providerConflict reports whether err from operation is a Conflict. If so
+// it is logged and, when it has persisted, reported with a Warning event;
+// the caller requeues after providerConflictRetry.
+func providerConflict(ctx context.Context, tracker *conflictTracker, obj client.Object, operation string, err error,
+	emit func(eventType, reason, message string)) bool {
+	if !contracts.IsConflict(err) {
+		return false
+	}
+	since, warn := tracker.observe(client.ObjectKeyFromObject(obj))
+	log.FromContext(ctx).V(1).Info("Provider call conflicted with another change; retrying",
+		"operation", operation, "since", since, "error", err.Error())
+	if warn {
+		emit(corev1.EventTypeWarning, events.ReasonProviderConflictPersisting,
+			fmt.Sprintf("%s has conflicted with other changes on the provider since %s: %v",
+				operation, since.UTC().Format(time.RFC3339), err))
+	}
+	return true
+}
+
+// vmProviderConflict is providerConflict for a VirtualMachine.
+func (r *VirtualMachineReconciler) vmProviderConflict(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, operation string, err error) bool {
+	return providerConflict(ctx, vmConflicts, vm, operation, err, func(eventType, reason, message string) {
+		r.recordEvent(ctx, vm, eventType, reason, message)
+	})
+}
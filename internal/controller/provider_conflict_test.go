@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestReconfigureVM_ProviderConflict(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	vmConflicts.now = func() time.Time { return now }
+	t.Cleanup(func() { vmConflicts.now = time.Now })
+
+	s := coverageTestScheme(t)
+	vm := baseVM("default")
+	vm.Name = "conflicted"
+	vm.Status.ID = "vm-123"
+	class := &infravirtrigaudiov1beta1.VMClass{Spec: infravirtrigaudiov1beta1.VMClassSpec{CPU: 4, Memory: resource.MustParse("8Gi")}}
+	r := newTestReconciler(s, nil, vm)
+	recorder := record.NewFakeRecorder(8)
+	r.Recorder = recorder
+	t.Cleanup(func() { vmConflicts.clear(client.ObjectKeyFromObject(vm)) })
+
+	var reconfigureErr error = contracts.NewConflictError("reconfigure: VM config is locked", nil)
+	provider := &stubProvider{ReconfigureFn: func(context.Context, string, contracts.CreateRequest, contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+		return contracts.ReconfigureResult{}, reconfigureErr
+	}}
+	reconfigure := func() time.Duration {
+		result, err := r.reconfigureVM(context.Background(), vm, provider, "", class, nil, nil, contracts.ChangeSet{})
+		require.NoError(t, err)
+		return result.RequeueAfter
+	}
+
+	assert.Equal(t, providerConflictRetry, reconfigure())
+	assert.Nil(t, k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring), "a conflict is not recorded as a failure")
+	assert.Empty(t, recorder.Events, "a fresh conflict raises no event")
+
+	now = now.Add(providerConflictWarnAfter)
+	assert.Equal(t, providerConflictRetry, reconfigure())
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning "+events.ReasonProviderConflictPersisting)
+	reconfigure()
+	assert.Empty(t, recorder.Events, "a persisting conflict is reported once")
+
+	reconfigureErr = nil
+	reconfigure()
+	assert.Contains(t, <-recorder.Events, events.ReasonReconfigured)
+	reconfigureErr = contracts.NewConflictError("reconfigure: VM config is locked", nil)
+	now = now.Add(time.Hour)
+	reconfigure()
+	assert.Empty(t, recorder.Events, "a success starts the conflict run over")
+
+	reconfigureErr = errors.New("disk shrinking not allowed")
+	reconfigure()
+	cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Contains(t, <-recorder.Events, "Warning "+events.ReasonReconfigureFailed)
+}
@@ -18,28 +18,41 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"path"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
 	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
@@ -51,12 +64,14 @@ import (
 // These constants are package-local; the `errReason*` prefix prevents
 // collisions with the VirtualMachine reconciler's taxonomy.
 const (
-	errReasonGetProvider         = "get-provider"
-	errReasonRuntimeSpecInvalid  = "runtime-spec-invalid"
-	errReasonServiceReconcile    = "service-reconcile-failed"
-	errReasonDeploymentReconcile = "deployment-reconcile-failed"
-	errReasonCleanupFailed       = "cleanup-failed"
-	errReasonTLSNotConfigured    = "tls-not-configured"
+	errReasonGetProvider          = "get-provider"
+	errReasonRuntimeSpecInvalid   = "runtime-spec-invalid"
+	errReasonServiceReconcile     = "service-reconcile-failed"
+	errReasonDeploymentReconcile  = "deployment-reconcile-failed"
+	errReasonCleanupFailed        = "cleanup-failed"
+	errReasonTLSNotConfigured     = "tls-not-configured"
+	errReasonAuthTokenReconcile   = "auth-token-reconcile-failed"
+	errReasonCredentialsReconcile = "credentials-reconcile-failed"
 )
 
 // TLS Condition vocabulary surfaced on Provider.Status.Conditions by
@@ -71,12 +86,19 @@ const (
 //   - SecretRefMissing — tls.enabled=true but secretRef is empty.
 //   - Enabled — tls.enabled=true with a referenced Secret; the
 //     Deployment is provisioned with the TLS volume mounted.
+//   - SPIFFE — tls.enabled=true with tls.spiffe set; the Deployment
+//     mounts the SPIFFE Workload API socket instead of a Secret.
+//   - CertManager — tls.enabled=true with tls.certManager set; the
+//     controller has cert-manager issue the Secret (see
+//     provider_certmanager.go).
 const (
 	providerConditionTLSConfigured  = "TLSConfigured"
 	providerReasonTLSBlockMissing   = "TLSBlockMissing"
 	providerReasonTLSDisabled       = "ExplicitlyDisabled"
 	providerReasonTLSSecretRefEmpty = "SecretRefMissing"
 	providerReasonTLSEnabled        = "Enabled"
+	providerReasonTLSSPIFFE         = "SPIFFE"
+	providerReasonTLSCertManager    = "CertManager"
 )
 
 // tlsBlockMissingMessage is the operator-facing message attached to the
@@ -113,6 +135,36 @@ const envProviderInsecure = "VIRTRIGAUD_PROVIDER_INSECURE"
 // VolumeMount (in buildProviderContainer) — must match.
 const providerTLSVolumeName = "provider-tls"
 
+// Task store wiring. providerTaskStoreMountPath and envProviderTaskStoreDir
+// MUST match sdk/provider/tasks.DefaultDir and EnvDir. The volume is an
+// emptyDir: it outlives a provider container restart, which is what loses
+// in-memory task bookkeeping, though not a rescheduled pod.
+const (
+	providerTaskStoreMountPath  = "/var/lib/virtrigaud/tasks"
+	envProviderTaskStoreDir     = "VIRTRIGAUD_TASK_STORE_DIR"
+	providerTaskStoreVolumeName = "task-store"
+)
+
+// SPIFFE-mode wiring. The env-var names MUST match
+// sdk/provider/server.EnvTLSMode / EnvSPIFFETrustDomain /
+// EnvSPIFFEAllowedIDs and the standard SPIFFE_ENDPOINT_SOCKET, duplicated
+// for the same reason as envProviderInsecure.
+const (
+	envProviderTLSMode           = "VIRTRIGAUD_PROVIDER_TLS_MODE"
+	envProviderSPIFFETrustDomain = "VIRTRIGAUD_PROVIDER_SPIFFE_TRUST_DOMAIN"
+	envProviderSPIFFEAllowedIDs  = "VIRTRIGAUD_PROVIDER_SPIFFE_ALLOWED_IDS"
+	envSPIFFEEndpointSocket      = "SPIFFE_ENDPOINT_SOCKET"
+
+	providerTLSModeSPIFFE = "spiffe"
+
+	// providerSPIFFEVolumeName is the Pod volume carrying the Workload
+	// API socket directory.
+	providerSPIFFEVolumeName = "spiffe-workload-api"
+
+	defaultSPIFFESocketPath = "/spiffe-workload-api/spire-agent.sock"
+	defaultSPIFFECSIDriver  = "csi.spiffe.io"
+)
+
 // Capability-reporting Condition vocabulary surfaced on
 // Provider.Status.Conditions (issue #176). The reconciler best-effort
 // queries the provider's GetCapabilities RPC once the provider runtime is
@@ -132,6 +184,29 @@ const (
 	providerReasonCapabilitiesUnavailable = "CapabilitiesUnavailable"
 )
 
+// providerConditionProtocolCompatible reports the protocol version
+// negotiated with the provider each time its capabilities are fetched. It
+// is True for Compatible and DegradedCompatibility providers and False for
+// Incompatible ones; the reason is the compatibility state.
+const providerConditionProtocolCompatible = "ProtocolCompatible"
+
+// providerConditionDegraded reports whether the running provider image
+// reports every capability in Spec.RequiredCapabilities (see
+// reconcileRequiredCapabilities).
+//
+// Reasons:
+//   - MissingCapabilities — the image lacks at least one required
+//     capability; the message names them and the image.
+//   - CapabilitiesSatisfied — every required capability is reported.
+//   - CapabilitiesUnknown — capabilities are required but the provider has
+//     not reported any yet.
+const (
+	providerConditionDegraded                 = "Degraded"
+	providerReasonMissingCapabilities         = "MissingCapabilities"
+	providerReasonCapabilitiesSatisfied       = "CapabilitiesSatisfied"
+	providerReasonRequiredCapabilitiesUnknown = "CapabilitiesUnknown"
+)
+
 // ProviderReconciler reconciles a Provider object
 type ProviderReconciler struct {
 	client.Client
@@ -144,14 +219,27 @@ type ProviderReconciler struct {
 	// that do not exercise capability reporting; reconcileReportedCapabilities
 	// is nil-safe and treats a nil resolver as "capabilities unavailable".
 	RemoteResolver *remote.Resolver
+
+	// Config supplies requeue intervals and concurrency; nil uses the
+	// defaults.
+	Config *config.ConfigStore
+
+	// Recorder receives ProviderHealthy/ProviderUnhealthy events when
+	// Status.Healthy changes. May be nil.
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=providers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=providers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=providers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmsnapshots;vmclones,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
@@ -180,6 +268,7 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		timer.Finish(outcome)
 	}()
 
+	ctx = logging.StartReconcile(ctx, "provider", req.NamespacedName)
 	logger := log.FromContext(ctx)
 
 	// Fetch the Provider
@@ -187,6 +276,9 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 	if err := r.Get(ctx, req.NamespacedName, &provider); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Provider not found, may have been deleted")
+			metrics.DeleteProviderInfo(req.Namespace, req.Name)
+			metrics.DeleteProviderCompatibility(req.Namespace, req.Name)
+			metrics.DeleteProviderRequeueIntervals(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get Provider")
@@ -194,6 +286,14 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		return ctrl.Result{}, err
 	}
 
+	ctx = audit.WithSubject(ctx, &provider)
+	phase := ""
+	if provider.Status.Runtime != nil {
+		phase = string(provider.Status.Runtime.Phase)
+	}
+	ctx = logging.WithLogFields(ctx, provider.Name, phase)
+	logger = log.FromContext(ctx)
+
 	// Handle deletion (cleanup deployments and services)
 	if !provider.DeletionTimestamp.IsZero() {
 		return r.handleDeletion(ctx, &provider)
@@ -231,9 +331,20 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		provider.Status.ConnectedVMs = connectedVMs
 	}
 
+	// Record maintenance, and keep its count of held operations current.
+	if recount := r.reconcileMaintenance(ctx, &provider); recount > 0 &&
+		(result.RequeueAfter == 0 || recount < result.RequeueAfter) {
+		result.RequeueAfter = recount
+	}
+
+	// Publish the VM poll intervals in force for this Provider.
+	recordRequeueIntervals(&provider, providerRequeue(r.Config.Get().Requeue, &provider))
+
 	// Set healthy status based on ProviderAvailable condition
+	wasHealthy := provider.Status.Healthy
 	providerAvailable := k8s.GetCondition(provider.Status.Conditions, "ProviderAvailable")
 	provider.Status.Healthy = providerAvailable != nil && providerAvailable.Status == metav1.ConditionTrue
+	r.reportHealthTransition(ctx, &provider, wasHealthy, providerAvailable)
 	if provider.Status.Healthy {
 		now := metav1.Now()
 		provider.Status.LastHealthCheck = &now
@@ -247,7 +358,10 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		provider.Status.Runtime != nil &&
 		provider.Status.Runtime.Phase == infravirtrigaudiov1beta1.ProviderRuntimePhaseRunning {
 		r.reconcileReportedCapabilities(ctx, &provider)
+		r.reconcileResourceUsage(ctx, &provider)
+		r.reconcileProviderInfo(ctx, &provider)
 	}
+	r.reconcileRequiredCapabilities(ctx, &provider)
 
 	// Update provider status with retry on conflict
 	provider.Status.ObservedGeneration = provider.Generation
@@ -342,6 +456,201 @@ func (r *ProviderReconciler) reconcileReportedCapabilities(ctx context.Context,
 	k8s.SetCondition(&provider.Status.Conditions,
 		providerConditionCapabilitiesReported, metav1.ConditionTrue,
 		providerReasonCapabilitiesFetched, "Provider capabilities reported")
+	r.reconcileProtocol(ctx, provider, caps)
+}
+
+// reconcileProtocol negotiates a protocol version with the provider from
+// the versions in caps and records the outcome on Status.Protocol, the
+// ProtocolCompatible condition and the compatibility metric. A provider
+// that becomes Incompatible gets a warning event.
+func (r *ProviderReconciler) reconcileProtocol(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, caps contracts.Capabilities) {
+	n := contracts.NegotiateProtocol(caps)
+	wasIncompatible := provider.Status.Protocol != nil &&
+		provider.Status.Protocol.Compatibility == string(contracts.Incompatible)
+
+	var missing []string
+	for _, f := range n.MissingFeatures {
+		missing = append(missing, string(f))
+	}
+	provider.Status.Protocol = &infravirtrigaudiov1beta1.ProviderProtocolStatus{
+		Compatibility:      string(n.Compatibility),
+		ProviderMinVersion: n.ProviderMin,
+		ProviderMaxVersion: n.ProviderMax,
+		NegotiatedVersion:  n.Version,
+		MissingFeatures:    missing,
+	}
+	status := metav1.ConditionTrue
+	if n.Compatibility == contracts.Incompatible {
+		status = metav1.ConditionFalse
+	}
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionProtocolCompatible, status, string(n.Compatibility), n.Message)
+	metrics.SetProviderCompatibility(provider.Namespace, provider.Name, string(n.Compatibility))
+
+	if n.Compatibility == contracts.Incompatible && !wasIncompatible {
+		log.FromContext(ctx).Info("Provider is incompatible with this manager", "message", n.Message)
+		events.Emit(ctx, r.Recorder, provider, corev1.EventTypeWarning, events.ReasonProviderIncompatible, "", n.Message)
+	}
+}
+
+// reconcileRequiredCapabilities compares Spec.RequiredCapabilities with the
+// capabilities the provider last reported and records the result on the
+// Degraded condition. The running image is never changed; a provider
+// missing a capability gets a warning event naming it and the image so the
+// operator can upgrade. With nothing required the condition is cleared.
+func (r *ProviderReconciler) reconcileRequiredCapabilities(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) {
+	if len(provider.Spec.RequiredCapabilities) == 0 {
+		meta.RemoveStatusCondition(&provider.Status.Conditions, providerConditionDegraded)
+		return
+	}
+	if provider.Status.ReportedCapabilities == nil {
+		k8s.SetCondition(&provider.Status.Conditions, providerConditionDegraded,
+			metav1.ConditionUnknown, providerReasonRequiredCapabilitiesUnknown,
+			"Provider has not reported its capabilities yet")
+		return
+	}
+
+	missing := capability.Missing(provider)
+	if len(missing) == 0 {
+		k8s.SetCondition(&provider.Status.Conditions, providerConditionDegraded,
+			metav1.ConditionFalse, providerReasonCapabilitiesSatisfied,
+			"Provider reports all required capabilities")
+		return
+	}
+
+	image := "unknown image"
+	if provider.Spec.Runtime != nil && provider.Spec.Runtime.Image != "" {
+		image = provider.Spec.Runtime.Image
+	}
+	message := fmt.Sprintf("Provider image %s does not report required capabilities: %s",
+		image, strings.Join(missing, ", "))
+
+	previous := k8s.GetCondition(provider.Status.Conditions, providerConditionDegraded)
+	changed := previous == nil || previous.Status != metav1.ConditionTrue || previous.Message != message
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionDegraded,
+		metav1.ConditionTrue, providerReasonMissingCapabilities, message)
+	if changed {
+		log.FromContext(ctx).Info("Provider is missing required capabilities", "missing", missing, "image", image)
+		events.Emit(ctx, r.Recorder, provider, corev1.EventTypeWarning, events.ReasonProviderMissingCapabilities, "", message)
+	}
+}
+
+// reconcileResourceUsage best-effort fetches hypervisor capacity from
+// providers that implement contracts.CapacityReporter and records it on
+// Status.ResourceUsage. Like capability reporting it never fails the
+// reconcile; on any error the previous snapshot is kept.
+func (r *ProviderReconciler) reconcileResourceUsage(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) {
+	logger := log.FromContext(ctx)
+
+	if r.RemoteResolver == nil {
+		return
+	}
+	providerInstance, err := r.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		logger.V(1).Info("Skipping capacity report: failed to resolve provider",
+			"provider", provider.Name, "namespace", provider.Namespace, "error", err.Error())
+		return
+	}
+	reporter, ok := providerInstance.(contracts.CapacityReporter)
+	if !ok {
+		return
+	}
+
+	hosts, err := reporter.GetCapacity(ctx)
+	if err != nil {
+		if !contracts.IsNotSupported(err) {
+			logger.V(1).Info("Skipping capacity report: GetCapacity RPC failed",
+				"provider", provider.Name, "namespace", provider.Namespace, "error", err.Error())
+		}
+		return
+	}
+	provider.Status.ResourceUsage = capacityToResourceUsage(hosts)
+}
+
+// reconcileProviderInfo best-effort fetches the provider build and
+// hypervisor version from providers that implement contracts.InfoReporter.
+// Like capacity reporting it never fails the reconcile.
+func (r *ProviderReconciler) reconcileProviderInfo(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) {
+	logger := log.FromContext(ctx)
+
+	if r.RemoteResolver == nil {
+		return
+	}
+	providerInstance, err := r.RemoteResolver.GetProvider(ctx, provider)
+	if err != nil {
+		logger.V(1).Info("Skipping version report: failed to resolve provider",
+			"provider", provider.Name, "namespace", provider.Namespace, "error", err.Error())
+		return
+	}
+	recordProviderInfo(ctx, provider, providerInstance)
+}
+
+// recordProviderInfo copies what providerInstance reports through
+// contracts.InfoReporter into the Provider status and the
+// virtrigaud_provider_info metric. On any error the previous status is kept.
+func recordProviderInfo(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, providerInstance contracts.Provider) {
+	reporter, ok := providerInstance.(contracts.InfoReporter)
+	if !ok {
+		return
+	}
+
+	info, err := reporter.GetInfo(ctx)
+	if err != nil {
+		if !contracts.IsNotSupported(err) {
+			log.FromContext(ctx).V(1).Info("Skipping version report: GetInfo RPC failed",
+				"provider", provider.Name, "namespace", provider.Namespace, "error", err.Error())
+		}
+		return
+	}
+
+	provider.Status.Version = info.ProviderVersion
+	provider.Status.GitSHA = info.GitSHA
+	provider.Status.Hypervisor = &infravirtrigaudiov1beta1.HypervisorInfo{
+		Product: info.HypervisorProduct,
+		Version: info.HypervisorVersion,
+		Details: info.HypervisorDetails,
+	}
+	metrics.SetProviderInfo(provider.Namespace, provider.Name, string(provider.Spec.Type),
+		info.ProviderVersion, info.GitSHA, info.HypervisorProduct, info.HypervisorVersion)
+}
+
+// capacityToResourceUsage sums per-host capacity into the Provider status
+// shape. CPU is counted in cores, with the used figure being each host's
+// load fraction applied to its core count; memory and storage are bytes.
+func capacityToResourceUsage(hosts []contracts.HostCapacity) *infravirtrigaudiov1beta1.ProviderResourceUsage {
+	var cpuTotal, memTotal, memUsed, storageTotal, storageUsed int64
+	var cpuUsed float64
+	for _, h := range hosts {
+		cpuTotal += int64(h.CPUCores)
+		cpuUsed += float64(h.CPUCores) * h.CPUUtilization
+		memTotal += h.MemoryTotalBytes
+		memUsed += h.MemoryUsedBytes
+		storageTotal += h.StorageTotalBytes
+		storageUsed += h.StorageUsedBytes
+	}
+
+	usage := &infravirtrigaudiov1beta1.ProviderResourceUsage{
+		CPU:    usageStats(cpuTotal, int64(math.Round(cpuUsed))),
+		Memory: usageStats(memTotal, memUsed),
+	}
+	if storageTotal > 0 {
+		usage.Storage = usageStats(storageTotal, storageUsed)
+	}
+	return usage
+}
+
+func usageStats(total, used int64) *infravirtrigaudiov1beta1.ResourceUsageStats {
+	used = min(used, total)
+	available := total - used
+	stats := &infravirtrigaudiov1beta1.ResourceUsageStats{
+		Total:     &total,
+		Used:      &used,
+		Available: &available,
+	}
+	if total > 0 {
+		percent := int32(used * 100 / total) // #nosec G115 -- bounded to 0..100
+		stats.UsagePercent = &percent
+	}
+	return stats
 }
 
 // capabilitiesToReported maps the transport-agnostic contracts.Capabilities
@@ -366,6 +675,15 @@ func capabilitiesToReported(caps contracts.Capabilities) *infravirtrigaudiov1bet
 		SupportedExportBackends:     caps.SupportedExportBackends,
 		SupportedImportBackends:     caps.SupportedImportBackends,
 		SupportedTransferModes:      caps.SupportedTransferModes,
+		SupportsConsoleOutput:       caps.SupportsConsoleOutput,
+		SupportsSysprep:             caps.SupportsSysprep,
+		SupportsCloudInitStatus:     caps.SupportsCloudInitStatus,
+		SupportsLiveMigration:       caps.SupportsLiveMigration,
+		SupportsConsoleProxy:        caps.SupportsConsoleProxy,
+		SupportsImagePublish:        caps.SupportsImagePublish,
+		SupportsEventWatch:          caps.SupportsEventWatch,
+		SupportsQuiescedSnapshots:   caps.SupportsQuiescedSnapshots,
+		CapabilityManifest:          caps.CapabilityManifest,
 	}
 }
 
@@ -377,8 +695,11 @@ func (r *ProviderReconciler) handleDeletion(ctx context.Context, provider *infra
 	if err := r.cleanupRemoteRuntime(ctx, provider); err != nil {
 		logger.Error(err, "Failed to cleanup remote runtime resources")
 		metrics.RecordError(errReasonCleanupFailed, metrics.ComponentManager)
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
 	}
+	metrics.DeleteProviderInfo(provider.Namespace, provider.Name)
+	metrics.DeleteProviderCompatibility(provider.Namespace, provider.Name)
+	metrics.DeleteProviderRequeueIntervals(provider.Namespace, provider.Name)
 
 	return ctrl.Result{}, nil
 }
@@ -393,7 +714,7 @@ func (r *ProviderReconciler) reconcileRemoteRuntime(ctx context.Context, provide
 		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
 		provider.Status.Runtime.Message = err.Error()
 		metrics.RecordError(errReasonRuntimeSpecInvalid, metrics.ComponentManager)
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
 	}
 
 	// Enforce the v0.3.7 TLS posture before provisioning anything.
@@ -410,7 +731,36 @@ func (r *ProviderReconciler) reconcileRemoteRuntime(ctx context.Context, provide
 		// Requeue on the same cadence as other config errors. Once the
 		// operator edits the CR the Watch fires regardless, so the
 		// requeue is just a belt-and-braces backstop.
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, nil
+	}
+
+	// spec.endpointRef must resolve before the runtime can be given an
+	// endpoint. Until it does, the runtime keeps the one it has.
+	if err := r.resolveEndpoint(ctx, provider); err != nil {
+		logger.Info("Provider endpointRef does not resolve", "error", err.Error())
+		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, providerReasonEndpointRefUnresolved, err.Error())
+		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhasePending
+		provider.Status.Runtime.Message = err.Error()
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderNotReady.Duration}, nil
+	}
+
+	// Credentials granted from another namespace are copied next to the
+	// Provider before the pod that mounts them.
+	if err := r.reconcileCredentials(ctx, provider); err != nil {
+		var unavailable *credentialsUnavailableError
+		if errors.As(err, &unavailable) {
+			logger.Info("Provider credentials are not available", "reason", unavailable.Reason, "error", err.Error())
+			k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, unavailable.Reason, err.Error())
+			provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhasePending
+			provider.Status.Runtime.Message = err.Error()
+			return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderNotReady.Duration}, nil
+		}
+		logger.Error(err, "Failed to reconcile provider credentials")
+		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "CredentialsError", fmt.Sprintf("Failed to reconcile credentials: %v", err))
+		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
+		provider.Status.Runtime.Message = err.Error()
+		metrics.RecordError(errReasonCredentialsReconcile, metrics.ComponentManager)
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
 	}
 
 	// Generate names for deployment and service
@@ -425,18 +775,79 @@ func (r *ProviderReconciler) reconcileRemoteRuntime(ctx context.Context, provide
 		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
 		provider.Status.Runtime.Message = err.Error()
 		metrics.RecordError(errReasonServiceReconcile, metrics.ComponentManager)
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
+	}
+
+	headless, err := r.reconcileHeadlessService(ctx, provider)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile headless service")
+		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "ServiceError", fmt.Sprintf("Failed to reconcile headless service: %v", err))
+		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
+		provider.Status.Runtime.Message = err.Error()
+		metrics.RecordError(errReasonServiceReconcile, metrics.ComponentManager)
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
+	}
+
+	// The token Secret must exist before the pod that mounts it.
+	var tokenGrace time.Duration
+	if providerTokenAuthEnabled(provider) {
+		tokenGrace, err = r.reconcileAuthToken(ctx, provider)
+		if err != nil {
+			logger.Error(err, "Failed to reconcile provider token")
+			k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "AuthTokenError", fmt.Sprintf("Failed to reconcile token Secret: %v", err))
+			provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
+			provider.Status.Runtime.Message = err.Error()
+			metrics.RecordError(errReasonAuthTokenReconcile, metrics.ComponentManager)
+			return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
+		}
+	}
+
+	// A cert-manager certificate must be issued before the pod that
+	// mounts it.
+	var cert *providerCertificate
+	if spec := providerCertManager(provider); spec != nil {
+		cert, err = r.reconcileCertificate(ctx, provider, spec)
+		if err != nil {
+			logger.Error(err, "Failed to reconcile provider certificate")
+			k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "CertificateError", fmt.Sprintf("Failed to reconcile Certificate: %v", err))
+			provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
+			provider.Status.Runtime.Message = err.Error()
+			metrics.RecordError(errReasonCertificateReconcile, metrics.ComponentManager)
+			return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
+		}
+		if cert.notInstalled {
+			// Not an error to retry hard on: nothing changes until
+			// someone installs cert-manager or edits the Provider.
+			k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, providerReasonCertManagerNotInstalled, "Refusing to deploy provider runtime until cert-manager is installed (see CertificateReady Condition)")
+			provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
+			provider.Status.Runtime.Message = "cert-manager is not installed"
+			metrics.RecordError(errReasonTLSNotConfigured, metrics.ComponentManager)
+			return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, nil
+		}
+		if !cert.ready {
+			k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "CertificateNotReady", "Waiting for cert-manager to issue the provider certificate")
+			provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhasePending
+			provider.Status.Runtime.Message = "Waiting for the provider certificate"
+			return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderNotReady.Duration}, nil
+		}
+	} else {
+		provider.Status.TLS = nil
+		meta.RemoveStatusCondition(&provider.Status.Conditions, providerConditionCertificateReady)
 	}
 
 	// Reconcile Deployment
-	deployment, err := r.reconcileDeployment(ctx, provider, deploymentName)
+	var podAnnotations map[string]string
+	if cert != nil {
+		podAnnotations = cert.podAnnotations
+	}
+	deployment, err := r.reconcileDeployment(ctx, provider, deploymentName, podAnnotations)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile deployment")
 		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "DeploymentError", fmt.Sprintf("Failed to create deployment: %v", err))
 		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed
 		provider.Status.Runtime.Message = err.Error()
 		metrics.RecordError(errReasonDeploymentReconcile, metrics.ComponentManager)
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderError.Duration}, err
 	}
 
 	// Update runtime status
@@ -447,26 +858,49 @@ func (r *ProviderReconciler) reconcileRemoteRuntime(ctx context.Context, provide
 
 	provider.Status.Runtime.Endpoint = fmt.Sprintf("%s.%s.svc.cluster.local:%d", service.Name, provider.Namespace, port)
 	provider.Status.Runtime.ServiceRef = &corev1.LocalObjectReference{Name: service.Name}
+	provider.Status.Runtime.EndpointRef = runtimeEndpointRef(provider, service.Name)
+	provider.Status.Runtime.HeadlessServiceRef = nil
+	if headless != nil {
+		provider.Status.Runtime.HeadlessServiceRef = &corev1.LocalObjectReference{Name: headless.Name}
+	}
 
 	// Check deployment readiness
-	if deployment.Status.ReadyReplicas > 0 {
+	ready := deployment.Status.ReadyReplicas > 0
+	if ready {
 		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhaseRunning
 		provider.Status.Runtime.Message = "Remote provider runtime is ready"
 
 		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionTrue, "DeploymentReady", fmt.Sprintf("Deployment has %d ready replicas", deployment.Status.ReadyReplicas))
-
-		k8s.SetCondition(&provider.Status.Conditions, "ProviderAvailable", metav1.ConditionTrue, "RemoteAvailable", "Remote provider is available")
 	} else {
 		provider.Status.Runtime.Phase = infravirtrigaudiov1beta1.ProviderRuntimePhasePending
 		provider.Status.Runtime.Message = "Waiting for deployment to be ready"
 
 		k8s.SetCondition(&provider.Status.Conditions, "ProviderRuntimeReady", metav1.ConditionFalse, "DeploymentNotReady", "Deployment pods are not ready yet")
+	}
 
+	// A changed spec.endpoint is verified before the provider is available
+	// again, so VMs are not changed through an endpoint that cannot find
+	// them.
+	if requeue, migrating := r.reconcileEndpointMigration(ctx, provider, deployment); migrating {
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+	if !ready {
 		// Requeue to check readiness again
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.Config.Get().Requeue.ProviderNotReady.Duration}, nil
 	}
+	k8s.SetCondition(&provider.Status.Conditions, "ProviderAvailable", metav1.ConditionTrue, "RemoteAvailable", "Remote provider is available")
 
-	return ctrl.Result{}, nil
+	// Come back to retire the previous token when its grace window ends,
+	// to follow a certificate renewal, or to resync credentials copied
+	// from another namespace, whichever is first.
+	requeue := tokenGrace
+	if renewal := certificateRequeue(cert, time.Now()); renewal > 0 && (requeue == 0 || renewal < requeue) {
+		requeue = renewal
+	}
+	if crossNamespaceCredentials(provider) && (requeue == 0 || credentialsResyncInterval < requeue) {
+		requeue = credentialsResyncInterval
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
 // providerTLSEnabled returns true iff the operator has explicitly
@@ -483,6 +917,24 @@ func providerTLSEnabled(provider *infravirtrigaudiov1beta1.Provider) bool {
 	return provider.Spec.Runtime.Service.TLS.Enabled
 }
 
+// providerSPIFFE returns the SPIFFE block when the Provider uses
+// workload-identity mTLS (tls.enabled=true with tls.spiffe set), else nil.
+func providerSPIFFE(provider *infravirtrigaudiov1beta1.Provider) *infravirtrigaudiov1beta1.ProviderSPIFFESpec {
+	if !providerTLSEnabled(provider) {
+		return nil
+	}
+	return provider.Spec.Runtime.Service.TLS.SPIFFE
+}
+
+// spiffeSocketPath returns the in-pod Workload API socket path, applying
+// the CRD default for objects that predate it.
+func spiffeSocketPath(spec *infravirtrigaudiov1beta1.ProviderSPIFFESpec) string {
+	if spec.SocketPath != "" {
+		return spec.SocketPath
+	}
+	return defaultSPIFFESocketPath
+}
+
 // evaluateTLSPosture sets the TLSConfigured Condition on the Provider
 // and returns true iff the reconciler may proceed to deploy the provider
 // runtime. It implements the ADR-0003 / umbrella #156 contract:
@@ -495,6 +947,12 @@ func providerTLSEnabled(provider *infravirtrigaudiov1beta1.Provider) bool {
 //     secretRef               → Condition=False, Reason=SecretRefMissing.
 //     Returns false (stop reconcile).
 //   - tls.enabled=true with
+//     spiffe                  → Condition=True, Reason=SPIFFE.
+//     Returns true (proceed with workload-identity mTLS; no Secret).
+//   - tls.enabled=true with
+//     certManager             → Condition=True, Reason=CertManager.
+//     Returns true (proceed; the Secret is issued before the Deployment).
+//   - tls.enabled=true with
 //     secretRef               → Condition=True, Reason=Enabled.
 //     Returns true (proceed with TLS).
 func (r *ProviderReconciler) evaluateTLSPosture(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) bool {
@@ -527,6 +985,21 @@ func (r *ProviderReconciler) evaluateTLSPosture(ctx context.Context, provider *i
 			"provider", provider.Name, "namespace", provider.Namespace)
 		return true
 
+	case tlsSpec.SPIFFE != nil:
+		k8s.SetCondition(&provider.Status.Conditions,
+			providerConditionTLSConfigured, metav1.ConditionTrue,
+			providerReasonTLSSPIFFE,
+			fmt.Sprintf("TLS enabled; using SPIFFE workload identity in trust domain %q", tlsSpec.SPIFFE.TrustDomain))
+		return true
+
+	case tlsSpec.CertManager != nil:
+		k8s.SetCondition(&provider.Status.Conditions,
+			providerConditionTLSConfigured, metav1.ConditionTrue,
+			providerReasonTLSCertManager,
+			fmt.Sprintf("TLS enabled; certificate issued by cert-manager %s %q into Secret %q",
+				certManagerIssuerKind(tlsSpec.CertManager), tlsSpec.CertManager.IssuerRef.Name, remote.TLSSecretName(provider)))
+		return true
+
 	case tlsSpec.SecretRef == nil || tlsSpec.SecretRef.Name == "":
 		k8s.SetCondition(&provider.Status.Conditions,
 			providerConditionTLSConfigured, metav1.ConditionFalse,
@@ -566,6 +1039,12 @@ func (r *ProviderReconciler) getServiceName(provider *infravirtrigaudiov1beta1.P
 	return fmt.Sprintf("virtrigaud-provider-%s-%s", provider.Namespace, provider.Name)
 }
 
+// getHeadlessServiceName names the headless Service used for PerVM
+// session affinity
+func (r *ProviderReconciler) getHeadlessServiceName(provider *infravirtrigaudiov1beta1.Provider) string {
+	return r.getServiceName(provider) + "-pods"
+}
+
 // reconcileService creates or updates the service for remote provider
 func (r *ProviderReconciler) reconcileService(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, serviceName string) (*corev1.Service, error) {
 	port := int32(9443)
@@ -637,8 +1116,84 @@ func (r *ProviderReconciler) reconcileService(ctx context.Context, provider *inf
 	return existing, nil
 }
 
+// reconcileHeadlessService publishes the provider's pods through a headless
+// Service when spec.runtime.sessionAffinity is PerVM, so the resolver can
+// dial individual replicas, and removes it otherwise. It returns nil when
+// no headless Service should exist.
+func (r *ProviderReconciler) reconcileHeadlessService(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (*corev1.Service, error) {
+	name := r.getHeadlessServiceName(provider)
+	if provider.Spec.Runtime.SessionAffinity != infravirtrigaudiov1beta1.ProviderSessionAffinityPerVM {
+		stale := &corev1.Service{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: provider.Namespace}, stale)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get headless service: %w", err)
+		}
+		if err := r.Delete(ctx, stale); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete headless service: %w", err)
+		}
+		return nil, nil
+	}
+
+	port := int32(9443)
+	if provider.Spec.Runtime.Service != nil && provider.Spec.Runtime.Service.Port != 0 {
+		port = provider.Spec.Runtime.Service.Port
+	}
+	desired := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: provider.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "virtrigaud-provider",
+				"app.kubernetes.io/instance":   provider.Name,
+				"app.kubernetes.io/component":  "provider",
+				"app.kubernetes.io/managed-by": "virtrigaud",
+				"virtrigaud.io/provider-type":  string(provider.Spec.Type),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+			Selector: map[string]string{
+				"app.kubernetes.io/name":     "virtrigaud-provider",
+				"app.kubernetes.io/instance": provider.Name,
+			},
+			Ports: []corev1.ServicePort{{
+				Name:       "grpc",
+				Port:       port,
+				TargetPort: intstr.FromInt32(port),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+	if err := controllerutil.SetControllerReference(provider, desired, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: provider.Namespace}, existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create headless service: %w", err)
+		}
+		return desired, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get headless service: %w", err)
+	}
+
+	existing.Spec.Ports = desired.Spec.Ports
+	existing.Labels = desired.Labels
+	if err := r.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update headless service: %w", err)
+	}
+	return existing, nil
+}
+
 // reconcileDeployment creates or updates the deployment for remote provider
-func (r *ProviderReconciler) reconcileDeployment(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, deploymentName string) (*appsv1.Deployment, error) {
+// podAnnotations are added to the pod template, so a change to them rolls
+// the pods.
+func (r *ProviderReconciler) reconcileDeployment(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, deploymentName string, podAnnotations map[string]string) (*appsv1.Deployment, error) {
 	// Default values
 	replicas := int32(1)
 	if provider.Spec.Runtime.Replicas != nil {
@@ -681,6 +1236,7 @@ func (r *ProviderReconciler) reconcileDeployment(ctx context.Context, provider *
 						"app.kubernetes.io/managed-by": "virtrigaud",
 						"virtrigaud.io/provider-type":  string(provider.Spec.Type),
 					},
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					Containers:                    []corev1.Container{*container},
@@ -766,7 +1322,7 @@ func (r *ProviderReconciler) buildProviderContainer(provider *infravirtrigaudiov
 		},
 		{
 			Name:  "PROVIDER_ENDPOINT",
-			Value: provider.Spec.Endpoint,
+			Value: hypervisorEndpoint(provider),
 		},
 		{
 			Name: "PROVIDER_NAMESPACE",
@@ -821,6 +1377,28 @@ func (r *ProviderReconciler) buildProviderContainer(provider *infravirtrigaudiov
 		})
 	}
 
+	// SPIFFE mode: the provider fetches its SVID from the mounted Workload
+	// API socket instead of reading /etc/virtrigaud/tls.
+	spiffeSpec := providerSPIFFE(provider)
+	if spiffeSpec != nil {
+		env = append(env,
+			corev1.EnvVar{Name: envProviderTLSMode, Value: providerTLSModeSPIFFE},
+			corev1.EnvVar{Name: envSPIFFEEndpointSocket, Value: "unix://" + spiffeSocketPath(spiffeSpec)},
+			corev1.EnvVar{Name: envProviderSPIFFETrustDomain, Value: spiffeSpec.TrustDomain},
+		)
+		if len(spiffeSpec.ManagerIDs) > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  envProviderSPIFFEAllowedIDs,
+				Value: strings.Join(spiffeSpec.ManagerIDs, ","),
+			})
+		}
+	}
+
+	env = append(env, corev1.EnvVar{
+		Name:  envProviderTaskStoreDir,
+		Value: providerTaskStoreMountPath,
+	})
+
 	// Add TLS insecure skip verify configuration
 	env = append(env, corev1.EnvVar{
 		Name:  "TLS_INSECURE_SKIP_VERIFY",
@@ -843,6 +1421,14 @@ func (r *ProviderReconciler) buildProviderContainer(provider *infravirtrigaudiov
 		})
 	}
 
+	// Add the clone limit from spec.runtime.maxConcurrentClones if specified
+	if n := provider.Spec.Runtime.MaxConcurrentClones; n != nil && *n > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "PROVIDER_MAX_CONCURRENT_CLONES",
+			Value: fmt.Sprintf("%d", *n),
+		})
+	}
+
 	// Add provider defaults as environment variables (for vSphere provider)
 	if provider.Spec.Defaults != nil {
 		if provider.Spec.Defaults.Datastore != "" {
@@ -892,13 +1478,28 @@ func (r *ProviderReconciler) buildProviderContainer(provider *infravirtrigaudiov
 	// Mount TLS certificates if enabled. Mount name matches the
 	// Volume produced in buildPodVolumes; mount path is the canonical
 	// location consumed by the PR-2 provider-side wiring.
-	if tlsEnabled {
+	switch {
+	case spiffeSpec != nil:
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      providerSPIFFEVolumeName,
+			MountPath: path.Dir(spiffeSocketPath(spiffeSpec)),
+			ReadOnly:  true,
+		})
+	case tlsEnabled:
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      providerTLSVolumeName,
 			MountPath: providerTLSMountPath,
 			ReadOnly:  true,
 		})
 	}
+	if providerTokenAuthEnabled(provider) {
+		// Not a subPath mount, so a rotated token reaches the pod.
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      providerAuthVolumeName,
+			MountPath: providerAuthMountPath,
+			ReadOnly:  true,
+		})
+	}
 
 	// Auto-discover and mount migration PVCs
 	migrationMounts := r.discoverMigrationVolumeMounts(context.Background(), provider.Namespace)
@@ -910,6 +1511,11 @@ func (r *ProviderReconciler) buildProviderContainer(provider *infravirtrigaudiov
 		MountPath: "/tmp",
 	})
 
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      providerTaskStoreVolumeName,
+		MountPath: providerTaskStoreMountPath,
+	})
+
 	// Back the libvirt provider's SSH private-key directory with a memory-backed
 	// (tmpfs) volume so the key it materialises from the credentials Secret is
 	// never written to the node's disk-backed /tmp emptyDir. Nested under /tmp so
@@ -1018,7 +1624,7 @@ func (r *ProviderReconciler) buildPodVolumes(provider *infravirtrigaudiov1beta1.
 		Name: "provider-credentials",
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
-				SecretName: provider.Spec.CredentialSecretRef.Name,
+				SecretName: credentialsSecretName(provider),
 			},
 		},
 	})
@@ -1033,13 +1639,39 @@ func (r *ProviderReconciler) buildPodVolumes(provider *infravirtrigaudiov1beta1.
 	// wiring will read TLS_CERT_PATH / TLS_KEY_PATH / TLS_CA_PATH
 	// against. evaluateTLSPosture has already guaranteed
 	// secretRef.Name is non-empty when we reach this code with
-	// tls.enabled=true.
-	if providerTLSEnabled(provider) {
+	// tls.enabled=true. With tls.certManager the Secret is the one
+	// cert-manager issues into.
+	if spiffeSpec := providerSPIFFE(provider); spiffeSpec != nil {
+		// SPIFFE mode: the CSI driver mounts the node agent's Workload
+		// API socket directory; no Secret is involved.
+		driver := spiffeSpec.CSIDriver
+		if driver == "" {
+			driver = defaultSPIFFECSIDriver
+		}
+		readOnly := true
+		volumes = append(volumes, corev1.Volume{
+			Name: providerSPIFFEVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{Driver: driver, ReadOnly: &readOnly},
+			},
+		})
+	} else if providerTLSEnabled(provider) {
 		volumes = append(volumes, corev1.Volume{
 			Name: providerTLSVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: provider.Spec.Runtime.Service.TLS.SecretRef.Name,
+					SecretName: remote.TLSSecretName(provider),
+				},
+			},
+		})
+	}
+
+	if providerTokenAuthEnabled(provider) {
+		volumes = append(volumes, corev1.Volume{
+			Name: providerAuthVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: remote.AuthSecretName(provider),
 				},
 			},
 		})
@@ -1058,6 +1690,14 @@ func (r *ProviderReconciler) buildPodVolumes(provider *infravirtrigaudiov1beta1.
 		},
 	})
 
+	// Persists async task bookkeeping across provider restarts
+	volumes = append(volumes, corev1.Volume{
+		Name: providerTaskStoreVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
 	// Memory-backed companion for the libvirt SSH key mount: keeps the private key
 	// in RAM (tmpfs), never on node disk. Small sizeLimit — only key material lives
 	// here. Scoped to libvirt providers (see the matching volume mount).
@@ -1233,15 +1873,8 @@ func (r *ProviderReconciler) countConnectedVMs(ctx context.Context, provider *in
 
 	// Count VMs that reference this provider
 	count := int32(0)
-	for _, vm := range vmList.Items {
-		// Check if VM references this provider
-		// Provider namespace defaults to VM namespace if not specified
-		providerNamespace := vm.Namespace
-		if vm.Spec.ProviderRef.Namespace != "" {
-			providerNamespace = vm.Spec.ProviderRef.Namespace
-		}
-
-		if vm.Spec.ProviderRef.Name == provider.Name && providerNamespace == provider.Namespace {
+	for i := range vmList.Items {
+		if VMUsesProvider(&vmList.Items[i], provider) {
 			count++
 		}
 	}
@@ -1276,15 +1909,59 @@ func (r *ProviderReconciler) cleanupRemoteRuntime(ctx context.Context, provider
 		return fmt.Errorf("failed to delete service: %w", err)
 	}
 
+	headless := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.getHeadlessServiceName(provider),
+			Namespace: provider.Namespace,
+		},
+	}
+	if err := r.Delete(ctx, headless); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete headless service: %w", err)
+	}
+
+	// The copy would be garbage-collected with the Provider, but credentials
+	// should not outlive it any longer than they have to.
+	if err := r.deleteCredentialsCopy(ctx, provider); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Every Provider records a change to the manager's maintenance setting
+	// at once, so the objects it held are released promptly.
+	maintenanceChanged := make(chan event.GenericEvent, 1)
+	if r.Config != nil {
+		r.Config.OnChange(func(old, updated *config.VirtrigaudConfig) {
+			if old.Maintenance == updated.Maintenance {
+				return
+			}
+			select {
+			case maintenanceChanged <- event.GenericEvent{Object: &infravirtrigaudiov1beta1.Provider{}}:
+			default:
+				// One is already pending; it covers this change too.
+			}
+		})
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infravirtrigaudiov1beta1.Provider{}).
+		WatchesRawSource(source.Channel(maintenanceChanged, handler.EnqueueRequestsFromMapFunc(r.allProviders))).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.Secret{}).
+		// Resolve spec.endpointRef again when the Service it names changes.
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.providersForEndpointService),
+		).
+		// Bring a rotated credential Secret over to the copies made of it
+		// for Providers in other namespaces.
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.providersForCredentialSecret),
+		).
 		// Re-reconcile a namespace's Providers when a migration storage PVC
 		// appears or starts deleting, so provider Deployments mount/unmount it
 		// promptly instead of waiting for the next resync (issue #184).
@@ -1293,8 +1970,33 @@ func (r *ProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.providersForMigrationPVC),
 		).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 5, // Process up to 5 providers in parallel
+			MaxConcurrentReconciles: r.Config.Get().Concurrency.Provider,
 		}).
 		Named("provider").
 		Complete(r)
 }
+
+// reportHealthTransition records an event when Status.Healthy changes,
+// carrying the ProviderAvailable condition message when there is one.
+func (r *ProviderReconciler) reportHealthTransition(
+	ctx context.Context,
+	provider *infravirtrigaudiov1beta1.Provider,
+	wasHealthy bool,
+	available *metav1.Condition,
+) {
+	if provider.Status.Healthy == wasHealthy {
+		return
+	}
+	eventType, reason, message := corev1.EventTypeNormal, events.ReasonProviderHealthy, "Provider is available"
+	if !provider.Status.Healthy {
+		eventType, reason, message = corev1.EventTypeWarning, events.ReasonProviderUnhealthy, "Provider is unavailable"
+	}
+	if available != nil && available.Message != "" {
+		message += ": " + available.Message
+	}
+	phase := ""
+	if provider.Status.Runtime != nil {
+		phase = string(provider.Status.Runtime.Phase)
+	}
+	events.Emit(ctx, r.Recorder, provider, eventType, reason, phase, message)
+}
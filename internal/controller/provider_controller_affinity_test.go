@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestProvider_PerVMAffinity_PublishesHeadlessService(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("libvirt", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	prov.Spec.Runtime.SessionAffinity = infravirtrigaudiov1beta1.ProviderSessionAffinityPerVM
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Name: "libvirt", Namespace: "default"}
+	headlessKey := types.NamespacedName{Name: "virtrigaud-provider-default-libvirt-pods", Namespace: "default"}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	svc := &corev1.Service{}
+	require.NoError(t, cli.Get(ctx, headlessKey, svc))
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	require.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, "grpc", svc.Spec.Ports[0].Name)
+	assert.Equal(t, int32(9443), svc.Spec.Ports[0].Port)
+
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(ctx, key, latest))
+	require.NotNil(t, latest.Status.Runtime.HeadlessServiceRef)
+	assert.Equal(t, headlessKey.Name, latest.Status.Runtime.HeadlessServiceRef.Name)
+
+	// Switching back to None removes the headless Service.
+	latest.Spec.Runtime.SessionAffinity = infravirtrigaudiov1beta1.ProviderSessionAffinityNone
+	require.NoError(t, cli.Update(ctx, latest))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, headlessKey, &corev1.Service{})))
+	require.NoError(t, cli.Get(ctx, key, latest))
+	assert.Nil(t, latest.Status.Runtime.HeadlessServiceRef)
+}
+
+// pinningResolver records which entry point resolved the provider.
+type pinningResolver struct {
+	stubResolver
+	vmUID types.UID
+}
+
+func (p *pinningResolver) GetProviderForVM(_ context.Context, _ *infravirtrigaudiov1beta1.Provider, vmUID types.UID) (contracts.Provider, error) {
+	p.vmUID = vmUID
+	return p.provider, nil
+}
+
+func (p *pinningResolver) ReleaseVM(*infravirtrigaudiov1beta1.Provider, types.UID) {}
+
+func TestResolveForVM_UsesPinningResolver(t *testing.T) {
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	vm.UID = "vm-uid"
+	prov := &infravirtrigaudiov1beta1.Provider{}
+
+	pinning := &pinningResolver{stubResolver: stubResolver{provider: &stubProvider{}}}
+	_, err := resolveForVM(context.Background(), pinning, prov, vm)
+	require.NoError(t, err)
+	assert.Equal(t, types.UID("vm-uid"), pinning.vmUID)
+
+	plain := &stubResolver{provider: &stubProvider{}}
+	p, err := resolveForVM(context.Background(), plain, prov, vm)
+	require.NoError(t, err)
+	assert.Same(t, plain.provider, p)
+}
@@ -98,6 +98,7 @@ func TestCapabilitiesToReported(t *testing.T) {
 		SupportedExportBackends:     []string{"pvc"},
 		SupportedImportBackends:     []string{"pvc"},
 		SupportedTransferModes:      []string{"relay"},
+		SupportsConsoleOutput:       true,
 	}
 
 	got := capabilitiesToReported(caps)
@@ -120,4 +121,72 @@ func TestCapabilitiesToReported(t *testing.T) {
 	assert.Equal(t, []string{"pvc"}, got.SupportedExportBackends)
 	assert.Equal(t, []string{"pvc"}, got.SupportedImportBackends)
 	assert.Equal(t, []string{"relay"}, got.SupportedTransferModes)
+	assert.True(t, got.SupportsConsoleOutput)
+}
+
+// TestCapacityToResourceUsage verifies hosts are summed and CPU load is
+// expressed in cores.
+func TestCapacityToResourceUsage(t *testing.T) {
+	usage := capacityToResourceUsage([]contracts.HostCapacity{
+		{Name: "pve1", CPUCores: 8, CPUUtilization: 0.25, MemoryTotalBytes: 32 << 30, MemoryUsedBytes: 8 << 30,
+			StorageTotalBytes: 500 << 30, StorageUsedBytes: 125 << 30},
+		{Name: "pve2", CPUCores: 8, CPUUtilization: 0.5, MemoryTotalBytes: 32 << 30, MemoryUsedBytes: 24 << 30},
+	})
+
+	require.NotNil(t, usage.CPU)
+	assert.Equal(t, int64(16), *usage.CPU.Total)
+	assert.Equal(t, int64(6), *usage.CPU.Used)
+	assert.Equal(t, int64(10), *usage.CPU.Available)
+	assert.Equal(t, int32(37), *usage.CPU.UsagePercent)
+
+	require.NotNil(t, usage.Memory)
+	assert.Equal(t, int64(64<<30), *usage.Memory.Total)
+	assert.Equal(t, int32(50), *usage.Memory.UsagePercent)
+
+	require.NotNil(t, usage.Storage)
+	assert.Equal(t, int64(375<<30), *usage.Storage.Available)
+
+	// No storage reported at all: the field stays unset.
+	assert.Nil(t, capacityToResourceUsage([]contracts.HostCapacity{{CPUCores: 4}}).Storage)
+}
+
+// infoProvider is a stub provider that also reports version information.
+type infoProvider struct {
+	stubProvider
+	info contracts.ProviderInfo
+	err  error
+}
+
+func (p *infoProvider) GetInfo(_ context.Context) (contracts.ProviderInfo, error) {
+	return p.info, p.err
+}
+
+// TestRecordProviderInfo verifies reported versions land on the Provider
+// status, and that a provider that cannot report them leaves the previous
+// values in place.
+func TestRecordProviderInfo(t *testing.T) {
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.ProviderSpec{Type: infravirtrigaudiov1beta1.ProviderTypeProxmox},
+	}
+
+	recordProviderInfo(context.Background(), provider, &infoProvider{info: contracts.ProviderInfo{
+		ProviderVersion:   "v0.4.0",
+		GitSHA:            "abc1234",
+		HypervisorProduct: "Proxmox VE",
+		HypervisorVersion: "8.2.4",
+		HypervisorDetails: "release 8.2",
+	}})
+	assert.Equal(t, "v0.4.0", provider.Status.Version)
+	assert.Equal(t, "abc1234", provider.Status.GitSHA)
+	assert.Equal(t, &infravirtrigaudiov1beta1.HypervisorInfo{
+		Product: "Proxmox VE", Version: "8.2.4", Details: "release 8.2",
+	}, provider.Status.Hypervisor)
+
+	recordProviderInfo(context.Background(), provider, &infoProvider{
+		err: contracts.NewNotSupportedError("getInfo: provider does not report version information"),
+	})
+	recordProviderInfo(context.Background(), provider, &stubProvider{})
+	assert.Equal(t, "v0.4.0", provider.Status.Version)
+	assert.Equal(t, "8.2.4", provider.Status.Hypervisor.Version)
 }
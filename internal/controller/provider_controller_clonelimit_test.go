@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+)
+
+func TestBuildProviderContainer_MaxConcurrentClones(t *testing.T) {
+	prov := providerWithRuntime("clones", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	sch := newProviderTLSScheme(t)
+	r := &ProviderReconciler{Client: fake.NewClientBuilder().WithScheme(sch).Build(), Scheme: sch}
+	cloneLimit := func() (string, bool) {
+		container, err := r.buildProviderContainer(prov)
+		require.NoError(t, err)
+		for _, env := range container.Env {
+			if env.Name == common.MaxConcurrentClonesEnv {
+				return env.Value, true
+			}
+		}
+		return "", false
+	}
+
+	_, set := cloneLimit()
+	assert.False(t, set, "no limit unless one is configured")
+
+	prov.Spec.Runtime.MaxConcurrentClones = ptr.To[int32](4)
+	value, set := cloneLimit()
+	assert.True(t, set)
+	assert.Equal(t, "4", value)
+
+	prov.Spec.Runtime.MaxConcurrentClones = ptr.To[int32](0)
+	_, set = cloneLimit()
+	assert.False(t, set, "zero means unlimited")
+}
@@ -276,6 +276,12 @@ func TestProvider_TLSEnabledTrue_DeploymentHasVolume(t *testing.T) {
 	assert.Equal(t, "true", tlsEnabledEnv,
 		"TLS_ENABLED env var must reflect spec.runtime.service.tls.enabled")
 
+	// The task store is mounted where the provider is told to find it.
+	storeDir, _ := envValue(dep, envProviderTaskStoreDir)
+	assert.Equal(t, providerTaskStoreMountPath, storeDir)
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: providerTaskStoreVolumeName, MountPath: providerTaskStoreMountPath})
+
 	// The TLS-on pod must NOT carry the plaintext opt-out env-var —
 	// otherwise the provider would skip TLS even though certs are mounted
 	// (the SDK's ResolveTLSAndAuth prefers on-disk files, but a spurious
@@ -334,3 +340,81 @@ func TestProvider_TLSEnabledTrue_NoSecretRef_SurfacesCondition(t *testing.T) {
 	assert.True(t, apierrors.IsNotFound(getErr),
 		"no Deployment should exist when secretRef is missing")
 }
+
+// TestProvider_TLSSPIFFE_DeploymentMountsWorkloadAPI — enabled=true with a
+// spiffe block and no secretRef. The controller deploys, mounts the
+// Workload API socket via the CSI driver instead of a Secret, and sets the
+// env-vars that put the SDK's ResolveTLSAndAuth into SPIFFE mode.
+func TestProvider_TLSSPIFFE_DeploymentMountsWorkloadAPI(t *testing.T) {
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("tls-spiffe",
+		&infravirtrigaudiov1beta1.ProviderTLSSpec{
+			Enabled: true,
+			SPIFFE: &infravirtrigaudiov1beta1.ProviderSPIFFESpec{
+				TrustDomain: "example.org",
+				ManagerIDs: []string{
+					"spiffe://example.org/ns/virtrigaud-system/sa/manager",
+					"spiffe://example.org/ns/virtrigaud-system/sa/manager-canary",
+				},
+			},
+		})
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "tls-spiffe", Namespace: "default"},
+	})
+	require.NoError(t, err)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.Background(),
+		types.NamespacedName{Name: "virtrigaud-provider-default-tls-spiffe", Namespace: "default"}, dep))
+
+	podSpec := dep.Spec.Template.Spec
+	var spiffeVol *corev1.Volume
+	for i := range podSpec.Volumes {
+		assert.NotEqual(t, providerTLSVolumeName, podSpec.Volumes[i].Name,
+			"SPIFFE mode must not mount a TLS Secret")
+		if podSpec.Volumes[i].Name == providerSPIFFEVolumeName {
+			spiffeVol = &podSpec.Volumes[i]
+		}
+	}
+	require.NotNil(t, spiffeVol, "Deployment must carry the Workload API volume")
+	require.NotNil(t, spiffeVol.CSI)
+	assert.Equal(t, "csi.spiffe.io", spiffeVol.CSI.Driver)
+
+	var mount *corev1.VolumeMount
+	for i := range podSpec.Containers[0].VolumeMounts {
+		if podSpec.Containers[0].VolumeMounts[i].Name == providerSPIFFEVolumeName {
+			mount = &podSpec.Containers[0].VolumeMounts[i]
+		}
+	}
+	require.NotNil(t, mount)
+	assert.Equal(t, "/spiffe-workload-api", mount.MountPath)
+	assert.True(t, mount.ReadOnly)
+
+	for name, want := range map[string]string{
+		envProviderTLSMode:           "spiffe",
+		envSPIFFEEndpointSocket:      "unix:///spiffe-workload-api/spire-agent.sock",
+		envProviderSPIFFETrustDomain: "example.org",
+		envProviderSPIFFEAllowedIDs:  "spiffe://example.org/ns/virtrigaud-system/sa/manager,spiffe://example.org/ns/virtrigaud-system/sa/manager-canary",
+	} {
+		got, ok := envValue(dep, name)
+		assert.True(t, ok, "missing env %s", name)
+		assert.Equal(t, want, got, "env %s", name)
+	}
+	_, insecurePresent := envValue(dep, envProviderInsecure)
+	assert.False(t, insecurePresent)
+
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(context.Background(),
+		types.NamespacedName{Name: "tls-spiffe", Namespace: "default"}, latest))
+	c := getConditionByType(t, latest.Status.Conditions, providerConditionTLSConfigured)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, providerReasonTLSSPIFFE, c.Reason)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// ProviderRuntimeReady reasons while spec.credentialSecretRef points into
+// another namespace and cannot be used.
+const (
+	providerReasonCredentialsNotGranted = "CredentialsNotGranted"
+	providerReasonCredentialsNotFound   = "CredentialsNotFound"
+)
+
+// credentialsSourceAnnotation on a credentials copy records the Secret it
+// was copied from, as namespace/name.
+const credentialsSourceAnnotation = "virtrigaud.io/credentials-source"
+
+// credentialsResyncInterval bounds how long a copy can lag its source. The
+// Secret watch normally brings a rotation over within seconds; the resync
+// covers a missed event and a grant revoked on the Namespace, which is not
+// watched.
+const credentialsResyncInterval = 5 * time.Minute
+
+// credentialsUnavailableError reports that the provider's credentials
+// cannot be used yet. Reason is the ProviderRuntimeReady reason to set.
+type credentialsUnavailableError struct {
+	Reason string
+	Err    error
+}
+
+func (e *credentialsUnavailableError) Error() string { return e.Err.Error() }
+
+func (e *credentialsUnavailableError) Unwrap() error { return e.Err }
+
+// credentialsCopyName returns the name of the copy of a Provider's
+// credentials made in its namespace.
+func credentialsCopyName(provider *infravirtrigaudiov1beta1.Provider) string {
+	return fmt.Sprintf("virtrigaud-provider-%s-%s-credentials", provider.Namespace, provider.Name)
+}
+
+// crossNamespaceCredentials reports whether spec.credentialSecretRef points
+// into a namespace other than the Provider's.
+func crossNamespaceCredentials(provider *infravirtrigaudiov1beta1.Provider) bool {
+	return k8sutil.RefNamespace(provider.Spec.CredentialSecretRef, provider.Namespace) != provider.Namespace
+}
+
+// credentialsSecretName returns the Secret the provider pod mounts: the
+// referenced Secret itself, or its copy when it lives in another
+// namespace.
+func credentialsSecretName(provider *infravirtrigaudiov1beta1.Provider) string {
+	if crossNamespaceCredentials(provider) {
+		return credentialsCopyName(provider)
+	}
+	return provider.Spec.CredentialSecretRef.Name
+}
+
+// reconcileCredentials copies credentials granted from another namespace
+// next to the Provider and keeps the copy in sync with its source. The copy
+// is deleted once the reference is local again, the grant is revoked or the
+// source is deleted. A *credentialsUnavailableError means the provider
+// cannot be deployed until the grant or the source appears.
+func (r *ProviderReconciler) reconcileCredentials(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) error {
+	if !crossNamespaceCredentials(provider) {
+		return r.deleteCredentialsCopy(ctx, provider)
+	}
+
+	key := k8sutil.RefKey(provider.Spec.CredentialSecretRef, provider.Namespace)
+	err := k8sutil.CheckCredentialAccess(ctx, r.Client, "spec.credentialSecretRef", provider.Namespace, key.Namespace, key.Name)
+	if k8sutil.IsCrossNamespaceRefDenied(err) {
+		if delErr := r.deleteCredentialsCopy(ctx, provider); delErr != nil {
+			return delErr
+		}
+		if c := meta.FindStatusCondition(provider.Status.Conditions, "ProviderRuntimeReady"); c == nil || c.Reason != providerReasonCredentialsNotGranted {
+			events.Emit(ctx, r.Recorder, provider, corev1.EventTypeWarning, events.ReasonProviderCredentialsDenied, "", err.Error())
+		}
+		return &credentialsUnavailableError{Reason: providerReasonCredentialsNotGranted, Err: err}
+	}
+	if err != nil {
+		return err
+	}
+
+	source := &corev1.Secret{}
+	if err := r.Get(ctx, key, source); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get credentials Secret %s: %w", key, err)
+		}
+		if delErr := r.deleteCredentialsCopy(ctx, provider); delErr != nil {
+			return delErr
+		}
+		return &credentialsUnavailableError{
+			Reason: providerReasonCredentialsNotFound,
+			Err:    fmt.Errorf("credentials Secret %s not found", key),
+		}
+	}
+
+	copyKey := types.NamespacedName{Namespace: provider.Namespace, Name: credentialsCopyName(provider)}
+	existing := &corev1.Secret{}
+	err = r.Get(ctx, copyKey, existing)
+	if apierrors.IsNotFound(err) {
+		// Copies are always Opaque: a Secret's type cannot change, and the
+		// provider reads the keys as files whatever the type.
+		copied := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      copyKey.Name,
+				Namespace: copyKey.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "virtrigaud-provider",
+					"app.kubernetes.io/instance":   provider.Name,
+					"app.kubernetes.io/component":  "provider-credentials",
+					"app.kubernetes.io/managed-by": "virtrigaud",
+				},
+				Annotations: map[string]string{credentialsSourceAnnotation: key.String()},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: maps.Clone(source.Data),
+		}
+		if err := controllerutil.SetControllerReference(provider, copied, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, copied); err != nil {
+			return fmt.Errorf("failed to create credentials copy: %w", err)
+		}
+		r.recordCredentialsUse(ctx, provider, source, events.ReasonProviderCredentialsCopied,
+			fmt.Sprintf("Copied credentials %s to Secret %s", key, copyKey))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get credentials copy: %w", err)
+	}
+	if !metav1.IsControlledBy(existing, provider) {
+		return fmt.Errorf("secret %s exists and is not managed by this Provider", copyKey)
+	}
+
+	repointed := existing.Annotations[credentialsSourceAnnotation] != key.String()
+	if !repointed && maps.EqualFunc(existing.Data, source.Data, func(a, b []byte) bool { return string(a) == string(b) }) {
+		return nil
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[credentialsSourceAnnotation] = key.String()
+	existing.Data = maps.Clone(source.Data)
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update credentials copy: %w", err)
+	}
+	if repointed {
+		r.recordCredentialsUse(ctx, provider, source, events.ReasonProviderCredentialsCopied,
+			fmt.Sprintf("Copied credentials %s to Secret %s", key, copyKey))
+	} else {
+		r.recordCredentialsUse(ctx, provider, source, events.ReasonProviderCredentialsSynced,
+			fmt.Sprintf("Updated Secret %s from changed credentials %s", copyKey, key))
+	}
+	return nil
+}
+
+// recordCredentialsUse records a cross-namespace use of credentials on the
+// Provider and on the source Secret, so it shows in the audit trail of both
+// namespaces.
+func (r *ProviderReconciler) recordCredentialsUse(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider,
+	source *corev1.Secret, reason, message string) {
+	log.FromContext(ctx).Info("Provider uses credentials from another namespace",
+		"secret", client.ObjectKeyFromObject(source), "reason", reason)
+	events.Emit(ctx, r.Recorder, provider, corev1.EventTypeNormal, reason, "", message)
+	events.Emit(ctx, r.Recorder, source, corev1.EventTypeNormal, reason, "",
+		fmt.Sprintf("%s for Provider %s/%s", message, provider.Namespace, provider.Name))
+}
+
+// deleteCredentialsCopy deletes the Provider's credentials copy, if it has
+// one.
+func (r *ProviderReconciler) deleteCredentialsCopy(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) error {
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: provider.Namespace, Name: credentialsCopyName(provider)}, existing)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get credentials copy: %w", err)
+	}
+	if !metav1.IsControlledBy(existing, provider) {
+		return nil
+	}
+	if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete credentials copy: %w", err)
+	}
+	log.FromContext(ctx).Info("Deleted provider credentials copy", "secret", existing.Name)
+	return nil
+}
+
+// providersForCredentialSecret maps a Secret to the Providers in other
+// namespaces whose spec.credentialSecretRef points at it, so a rotation
+// reaches their copies promptly.
+func (r *ProviderReconciler) providersForCredentialSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	providers := &infravirtrigaudiov1beta1.ProviderList{}
+	if err := r.List(ctx, providers); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Providers for a Secret change", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range providers.Items {
+		p := &providers.Items[i]
+		if !crossNamespaceCredentials(p) || p.Spec.CredentialSecretRef.Name != obj.GetName() ||
+			p.Spec.CredentialSecretRef.Namespace != obj.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(p)})
+	}
+	return requests
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// credentialGrantEnv returns a reconciler for a Provider in "default" whose
+// credentials live in the "vault" namespace, annotated with annotations.
+func credentialGrantEnv(t *testing.T, annotations map[string]string) (*ProviderReconciler, client.Client, *record.FakeRecorder) {
+	t.Helper()
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("vcenter", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	prov.Spec.CredentialSecretRef = infravirtrigaudiov1beta1.ObjectRef{Name: "vcenter-admin", Namespace: "vault"}
+	vault := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vault", Annotations: annotations}}
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcenter-admin", Namespace: "vault"},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("s3cret")},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov, vault, source).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	recorder := record.NewFakeRecorder(16)
+	return &ProviderReconciler{Client: cli, Scheme: sch, Recorder: recorder}, cli, recorder
+}
+
+// countEvents returns how many of the recorded events carry reason.
+func countEvents(recorded []string, reason string) int {
+	n := 0
+	for _, e := range recorded {
+		if strings.Contains(e, " "+reason+" ") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestProvider_CredentialGrant_CopiesAndSyncsSecret(t *testing.T) {
+	ctx := context.Background()
+	r, cli, recorder := credentialGrantEnv(t, map[string]string{
+		k8sutil.AllowCredentialReferencesFromAnnotation: "default",
+	})
+	key := types.NamespacedName{Name: "vcenter", Namespace: "default"}
+	copyKey := types.NamespacedName{Name: "virtrigaud-provider-default-vcenter-credentials", Namespace: "default"}
+	sourceKey := types.NamespacedName{Name: "vcenter-admin", Namespace: "vault"}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+	assert.LessOrEqual(t, res.RequeueAfter, credentialsResyncInterval)
+
+	copied := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, copyKey, copied))
+	assert.Equal(t, "s3cret", string(copied.Data["password"]))
+	assert.Equal(t, corev1.SecretTypeOpaque, copied.Type)
+	assert.Equal(t, "vault/vcenter-admin", copied.Annotations[credentialsSourceAnnotation])
+	require.Len(t, copied.OwnerReferences, 1)
+	assert.Equal(t, "vcenter", copied.OwnerReferences[0].Name)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "virtrigaud-provider-default-vcenter", Namespace: "default"}, dep))
+	var mounted string
+	for _, v := range dep.Spec.Template.Spec.Volumes {
+		if v.Name == "provider-credentials" {
+			mounted = v.Secret.SecretName
+		}
+	}
+	assert.Equal(t, copyKey.Name, mounted)
+
+	recorded := drainEvents(recorder)
+	assert.Equal(t, 2, countEvents(recorded, events.ReasonProviderCredentialsCopied),
+		"the copy is recorded on the Provider and on the source Secret")
+
+	// An unchanged source is not copied again.
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+
+	// A rotated source is brought over.
+	source := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, sourceKey, source))
+	source.Data["password"] = []byte("rotated")
+	require.NoError(t, cli.Update(ctx, source))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, copyKey, copied))
+	assert.Equal(t, "rotated", string(copied.Data["password"]))
+	assert.Equal(t, 2, countEvents(drainEvents(recorder), events.ReasonProviderCredentialsSynced))
+
+	// A deleted source takes the copy with it.
+	require.NoError(t, cli.Delete(ctx, source))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, copyKey, copied)))
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(ctx, key, latest))
+	cond := getConditionByType(t, latest.Status.Conditions, "ProviderRuntimeReady")
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, providerReasonCredentialsNotFound, cond.Reason)
+}
+
+func TestProvider_CredentialGrant_Denied(t *testing.T) {
+	ctx := context.Background()
+	// The general reference grant does not hand out credentials.
+	r, cli, recorder := credentialGrantEnv(t, map[string]string{
+		k8sutil.AllowReferencesFromAnnotation: "*",
+	})
+	key := types.NamespacedName{Name: "vcenter", Namespace: "default"}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(ctx, key, latest))
+	cond := getConditionByType(t, latest.Status.Conditions, "ProviderRuntimeReady")
+	require.NotNil(t, cond)
+	assert.Equal(t, providerReasonCredentialsNotGranted, cond.Reason)
+	assert.Contains(t, cond.Message, k8sutil.AllowCredentialReferencesFromAnnotation)
+
+	err = cli.Get(ctx, types.NamespacedName{Name: "virtrigaud-provider-default-vcenter", Namespace: "default"}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err), "no runtime is deployed without the credentials")
+	assert.Equal(t, 1, countEvents(drainEvents(recorder), events.ReasonProviderCredentialsDenied))
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Zero(t, countEvents(drainEvents(recorder), events.ReasonProviderCredentialsDenied),
+		"the same denial is announced once")
+}
+
+func TestProvider_CredentialGrant_RevokedDeletesCopy(t *testing.T) {
+	ctx := context.Background()
+	r, cli, _ := credentialGrantEnv(t, map[string]string{
+		k8sutil.AllowCredentialReferencesFromAnnotation: "default",
+	})
+	key := types.NamespacedName{Name: "vcenter", Namespace: "default"}
+	copyKey := types.NamespacedName{Name: "virtrigaud-provider-default-vcenter-credentials", Namespace: "default"}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, copyKey, &corev1.Secret{}))
+
+	vault := &corev1.Namespace{}
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "vault"}, vault))
+	vault.Annotations = nil
+	require.NoError(t, cli.Update(ctx, vault))
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, copyKey, &corev1.Secret{})))
+}
+
+func TestProvidersForCredentialSecret(t *testing.T) {
+	sch := newProviderTLSScheme(t)
+	granted := providerWithRuntime("granted", nil)
+	granted.Spec.CredentialSecretRef = infravirtrigaudiov1beta1.ObjectRef{Name: "creds", Namespace: "vault"}
+	local := providerWithRuntime("local", nil)
+	local.Spec.CredentialSecretRef = infravirtrigaudiov1beta1.ObjectRef{Name: "creds"}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(granted, local).Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "vault"}}
+	reqs := r.providersForCredentialSecret(context.Background(), secret)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, types.NamespacedName{Name: "granted", Namespace: "default"}, reqs[0].NamespacedName)
+
+	secret.Namespace = "default"
+	assert.Empty(t, r.providersForCredentialSecret(context.Background(), secret),
+		"local credentials are mounted directly and need no copy")
+}
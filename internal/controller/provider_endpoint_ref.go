@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// providerReasonEndpointRefUnresolved is the ProviderRuntimeReady reason
+// while spec.endpointRef cannot be resolved.
+const providerReasonEndpointRefUnresolved = "EndpointRefUnresolved"
+
+// providerServicePortName names the gRPC port of the provider Service;
+// status.runtime.endpointRef selects it by name so the manager follows a
+// changed spec.runtime.service.port.
+const providerServicePortName = "grpc"
+
+// resolveEndpoint records in status.endpoint the hypervisor endpoint the
+// provider runtime is given: spec.endpoint, or spec.endpointRef resolved to
+// its Service's DNS name and port.
+func (r *ProviderReconciler) resolveEndpoint(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) error {
+	ref := provider.Spec.EndpointRef
+	if ref == nil {
+		provider.Status.Endpoint = provider.Spec.Endpoint
+		return nil
+	}
+	host, port, err := k8sutil.ResolveServiceEndpoint(ctx, r.Client, "spec.endpointRef", *ref, provider.Namespace)
+	if err != nil {
+		return err
+	}
+	endpoint := endpointRefURL(provider.Spec.Type, host, port)
+	if endpoint != provider.Status.Endpoint {
+		log.FromContext(ctx).Info("Resolved provider endpointRef", "service", ref.ServiceName, "endpoint", endpoint)
+	}
+	provider.Status.Endpoint = endpoint
+	return nil
+}
+
+// endpointRefURL builds the hypervisor URL for a Service reached at
+// host:port, in the scheme the provider type expects.
+func endpointRefURL(providerType infravirtrigaudiov1beta1.ProviderType, host string, port int32) string {
+	switch providerType {
+	case infravirtrigaudiov1beta1.ProviderTypeVSphere, infravirtrigaudiov1beta1.ProviderTypeProxmox:
+		return fmt.Sprintf("https://%s:%d", host, port)
+	case infravirtrigaudiov1beta1.ProviderTypeLibvirt:
+		return fmt.Sprintf("qemu+tcp://%s:%d/system", host, port)
+	default:
+		return fmt.Sprintf("tcp://%s:%d", host, port)
+	}
+}
+
+// hypervisorEndpoint is the endpoint the provider runtime is given and
+// verified against. With spec.endpointRef it is what resolveEndpoint last
+// recorded.
+func hypervisorEndpoint(provider *infravirtrigaudiov1beta1.Provider) string {
+	if provider.Spec.EndpointRef != nil {
+		return provider.Status.Endpoint
+	}
+	return provider.Spec.Endpoint
+}
+
+// runtimeEndpointRef is the status.runtime.endpointRef of a Provider whose
+// gRPC Service is serviceName.
+func runtimeEndpointRef(provider *infravirtrigaudiov1beta1.Provider, serviceName string) *infravirtrigaudiov1beta1.ServiceEndpointRef {
+	port := intstr.FromString(providerServicePortName)
+	return &infravirtrigaudiov1beta1.ServiceEndpointRef{
+		ServiceName: serviceName,
+		Namespace:   provider.Namespace,
+		Port:        &port,
+	}
+}
+
+// providersForEndpointService maps a Service to the Providers whose
+// spec.endpointRef points at it, so a changed port is resolved again.
+func (r *ProviderReconciler) providersForEndpointService(ctx context.Context, obj client.Object) []reconcile.Request {
+	providers := &infravirtrigaudiov1beta1.ProviderList{}
+	if err := r.List(ctx, providers); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Providers for a Service change", "service", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range providers.Items {
+		p := &providers.Items[i]
+		ref := p.Spec.EndpointRef
+		if ref == nil || ref.ServiceName != obj.GetName() ||
+			k8sutil.ServiceRefNamespace(*ref, p.Namespace) != obj.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(p)})
+	}
+	return requests
+}
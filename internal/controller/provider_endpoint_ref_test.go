@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// providerEndpointEnv returns PROVIDER_ENDPOINT from the provider
+// Deployment's first container.
+func providerEndpointEnv(t *testing.T, dep *appsv1.Deployment) string {
+	t.Helper()
+	require.NotEmpty(t, dep.Spec.Template.Spec.Containers)
+	for _, env := range dep.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "PROVIDER_ENDPOINT" {
+			return env.Value
+		}
+	}
+	t.Fatal("PROVIDER_ENDPOINT not set on the provider container")
+	return ""
+}
+
+func TestProvider_EndpointRef_ResolvesService(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("libvirt", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	prov.Spec.Type = infravirtrigaudiov1beta1.ProviderTypeLibvirt
+	prov.Spec.Endpoint = ""
+	prov.Spec.EndpointRef = &infravirtrigaudiov1beta1.ServiceEndpointRef{ServiceName: "libvirtd"}
+	libvirtd := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "libvirtd", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "tcp", Port: 16509}}},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov, libvirtd).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Name: "libvirt", Namespace: "default"}
+	depKey := types.NamespacedName{Name: "virtrigaud-provider-default-libvirt", Namespace: "default"}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(ctx, depKey, dep))
+	assert.Equal(t, "qemu+tcp://libvirtd.default.svc.cluster.local:16509/system", providerEndpointEnv(t, dep))
+
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(ctx, key, latest))
+	assert.Equal(t, "qemu+tcp://libvirtd.default.svc.cluster.local:16509/system", latest.Status.Endpoint)
+	require.NotNil(t, latest.Status.Runtime.EndpointRef)
+	grpcPort := intstr.FromString("grpc")
+	assert.Equal(t, &infravirtrigaudiov1beta1.ServiceEndpointRef{
+		ServiceName: depKey.Name, Namespace: "default", Port: &grpcPort,
+	}, latest.Status.Runtime.EndpointRef)
+
+	// A changed Service port is picked up on the next reconcile.
+	libvirtd.Spec.Ports[0].Port = 16514
+	require.NoError(t, cli.Update(ctx, libvirtd))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, depKey, dep))
+	assert.Equal(t, "qemu+tcp://libvirtd.default.svc.cluster.local:16514/system", providerEndpointEnv(t, dep))
+}
+
+func TestProvider_EndpointRef_MissingService(t *testing.T) {
+	ctx := context.Background()
+	sch := newProviderTLSScheme(t)
+	prov := providerWithRuntime("pve", &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	prov.Spec.Type = infravirtrigaudiov1beta1.ProviderTypeProxmox
+	prov.Spec.Endpoint = ""
+	prov.Spec.EndpointRef = &infravirtrigaudiov1beta1.ServiceEndpointRef{ServiceName: "pve-api"}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(prov).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.Provider{}).
+		Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Name: "pve", Namespace: "default"}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+
+	latest := &infravirtrigaudiov1beta1.Provider{}
+	require.NoError(t, cli.Get(ctx, key, latest))
+	cond := getConditionByType(t, latest.Status.Conditions, "ProviderRuntimeReady")
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, providerReasonEndpointRefUnresolved, cond.Reason)
+	assert.Contains(t, cond.Message, "pve-api")
+}
+
+func TestProvidersForEndpointService(t *testing.T) {
+	sch := newProviderTLSScheme(t)
+	byRef := providerWithRuntime("by-ref", nil)
+	byRef.Spec.Endpoint = ""
+	byRef.Spec.EndpointRef = &infravirtrigaudiov1beta1.ServiceEndpointRef{ServiceName: "hv", Namespace: "infra"}
+	byURL := providerWithRuntime("by-url", nil)
+	other := providerWithRuntime("other", nil)
+	other.Spec.Endpoint = ""
+	other.Spec.EndpointRef = &infravirtrigaudiov1beta1.ServiceEndpointRef{ServiceName: "hv"}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(byRef, byURL, other).Build()
+	r := &ProviderReconciler{Client: cli, Scheme: sch}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "hv", Namespace: "infra"}}
+	reqs := r.providersForEndpointService(context.Background(), svc)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, types.NamespacedName{Name: "by-ref", Namespace: "default"}, reqs[0].NamespacedName)
+}
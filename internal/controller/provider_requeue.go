@@ -0,0 +1,52 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// A Provider's spec.reconcileInterval and spec.statusRefreshInterval replace
+// the manager's requeue intervals for its VMs. Both controllers read them
+// from the Provider on every reconcile, so an edit applies from each VM's
+// next poll, and the Provider watch brings that poll forward.
+
+// providerRequeue returns requeue with provider's reconcile and status
+// refresh intervals applied. provider may be nil.
+func providerRequeue(requeue config.RequeueConfig, provider *infravirtrigaudiov1beta1.Provider) config.RequeueConfig {
+	if provider == nil {
+		return requeue
+	}
+	return requeue.ForProvider(provider.Spec.ReconcileInterval, provider.Spec.StatusRefreshInterval)
+}
+
+// recordRequeueIntervals publishes requeue, the intervals in force for
+// provider's VMs, as virtrigaud_provider_requeue_interval_seconds.
+func recordRequeueIntervals(provider *infravirtrigaudiov1beta1.Provider, requeue config.RequeueConfig) {
+	for state, interval := range map[string]time.Duration{
+		metrics.RequeueStateRunning:      requeue.Running.Duration,
+		metrics.RequeueStatePoweredOff:   requeue.PoweredOff.Duration,
+		metrics.RequeueStateTransitional: requeue.Transitional.Duration,
+		metrics.RequeueStateWaitingForIP: requeue.WaitingForIP.Duration,
+	} {
+		metrics.SetProviderRequeueInterval(provider.Namespace, provider.Name, state, interval)
+	}
+}
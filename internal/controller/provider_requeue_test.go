@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// requeueIntervals returns the virtrigaud_provider_requeue_interval_seconds
+// series of one Provider by state.
+func requeueIntervals(t *testing.T, namespace, provider string) map[string]float64 {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	require.NoError(t, err)
+	got := map[string]float64{}
+	for _, f := range families {
+		if f.GetName() != "virtrigaud_provider_requeue_interval_seconds" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["namespace"] == namespace && labels["provider"] == provider {
+				got[labels["state"]] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	return got
+}
+
+func TestProviderIntervalsApplyOnTheNextPoll(t *testing.T) {
+	store := config.NewConfigStore(config.DefaultVirtrigaudConfig(), nil)
+	r := &VirtualMachineReconciler{Config: store}
+	provider := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Namespace: "requeue-test", Name: "vsphere"}}
+	running := contracts.DescribeResponse{PowerState: string(contracts.PowerStateOn), IPs: []string{"10.0.0.5"}}
+
+	assert.Equal(t, 2*time.Minute, r.getRequeueInterval(provider, running))
+
+	// An edit to the Provider is read on the VM's next reconcile; nothing
+	// is cached between them.
+	provider.Spec.ReconcileInterval = &metav1.Duration{Duration: 30 * time.Minute}
+	assert.Equal(t, 30*time.Minute, r.getRequeueInterval(provider, running))
+
+	recordRequeueIntervals(provider, providerRequeue(store.Get().Requeue, provider))
+	assert.Equal(t, map[string]float64{
+		metrics.RequeueStateRunning:      1800,
+		metrics.RequeueStatePoweredOff:   1800,
+		metrics.RequeueStateTransitional: 10,
+		metrics.RequeueStateWaitingForIP: 10,
+	}, requeueIntervals(t, "requeue-test", "vsphere"))
+
+	metrics.DeleteProviderRequeueIntervals("requeue-test", "vsphere")
+	assert.Empty(t, requeueIntervals(t, "requeue-test", "vsphere"))
+}
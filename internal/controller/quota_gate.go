@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+)
+
+// The quota gates re-check VirtrigaudQuota right before a controller asks a
+// provider for resources. Admission already checked, but two requests
+// admitted at the same moment each saw the other's usage missing; counting
+// only older objects here lets the older one proceed and holds the newer
+// one back until usage drops. A held object stays pending and is checked
+// again every quotaRecheckInterval.
+
+// ReasonQuotaExceeded marks an object a VirtrigaudQuota holds back.
+const ReasonQuotaExceeded = events.ReasonQuotaExceeded
+
+const quotaRecheckInterval = time.Minute
+
+// quotaBlocked reports whether err from quota.Check holds the object
+// back, and the condition reason to record. Failing to measure usage also
+// holds it back, as proceeding could overshoot the limit.
+func quotaBlocked(ctx context.Context, err error) (blocked bool, reason string) {
+	switch {
+	case err == nil:
+		return false, ""
+	case quota.IsExceeded(err):
+		return true, ReasonQuotaExceeded
+	default:
+		logging.FromContext(ctx).Error(err, "Failed to check VirtrigaudQuota")
+		return true, k8s.ReasonReconcileError
+	}
+}
+
+// quotaConditionChanged reports whether conditions do not yet record err
+// under conditionType, so the Warning event is only emitted once per
+// denial rather than on every recheck.
+func quotaConditionChanged(conditions []metav1.Condition, conditionType string, err error) bool {
+	c := meta.FindStatusCondition(conditions, conditionType)
+	return c == nil || c.Reason != ReasonQuotaExceeded || c.Message != err.Error()
+}
+
+// gateVMQuota holds back the provider create of a new VM that would exceed
+// a quota of its namespace.
+func (r *VirtualMachineReconciler) gateVMQuota(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	req, err := quota.VMRequest(ctx, r.Client, vm)
+	if err == nil {
+		err = quota.Check(ctx, r.Client, vm.Namespace, req, quota.CreatedBefore(vm))
+	}
+	blocked, reason := quotaBlocked(ctx, err)
+	if !blocked {
+		return false
+	}
+	if reason == ReasonQuotaExceeded && quotaConditionChanged(vm.Status.Conditions, k8s.ConditionProvisioning, err) {
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonQuotaExceeded, err.Error())
+	}
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, reason, err.Error())
+	r.updateStatus(ctx, vm)
+	return true
+}
+
+// gateSnapshotQuota holds back a snapshot that would exceed a quota of its
+// namespace. The snapshot keeps its empty phase until it fits.
+func (r *VMSnapshotReconciler) gateSnapshotQuota(ctx context.Context, snapshot *infravirtrigaudiov1beta1.VMSnapshot) (bool, ctrl.Result) {
+	req := quota.Request{Snapshots: 1, SnapshotVM: snapshot.Spec.VMRef.Name}
+	err := quota.Check(ctx, r.Client, snapshot.Namespace, req, quota.CreatedBefore(snapshot))
+	blocked, reason := quotaBlocked(ctx, err)
+	if !blocked {
+		return false, ctrl.Result{}
+	}
+	if reason == ReasonQuotaExceeded && quotaConditionChanged(snapshot.Status.Conditions, infravirtrigaudiov1beta1.VMSnapshotConditionReady, err) {
+		r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, events.ReasonQuotaExceeded, err.Error())
+	}
+	snapshot.Status.Message = err.Error()
+	k8s.SetCondition(&snapshot.Status.Conditions, infravirtrigaudiov1beta1.VMSnapshotConditionReady,
+		metav1.ConditionFalse, reason, err.Error())
+	// Status update errors are intentionally ignored to avoid blocking reconciliation
+	_ = r.updateStatus(ctx, snapshot)
+	return true, ctrl.Result{RequeueAfter: quotaRecheckInterval}
+}
+
+// gateCloneQuota holds back a clone that would exceed maxClonesInFlight in
+// its own namespace, or whose target VM would exceed a quota of the target
+// namespace.
+func (r *VMCloneReconciler) gateCloneQuota(
+	ctx context.Context,
+	clone *infravirtrigaudiov1beta1.VMClone,
+	sourceVM *infravirtrigaudiov1beta1.VirtualMachine,
+	targetNamespace string,
+) (bool, ctrl.Result) {
+	olderFirst := quota.CreatedBefore(clone)
+	err := quota.Check(ctx, r.Client, clone.Namespace, quota.Request{Clones: 1}, olderFirst)
+	if err == nil {
+		var req quota.Request
+		if req, err = quota.CloneTargetRequest(ctx, r.Client, clone, sourceVM); err == nil {
+			err = quota.Check(ctx, r.Client, targetNamespace, req, olderFirst)
+		}
+	}
+	blocked, reason := quotaBlocked(ctx, err)
+	if !blocked {
+		return false, ctrl.Result{}
+	}
+	if reason == ReasonQuotaExceeded && quotaConditionChanged(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionReady, err) {
+		r.recordEvent(ctx, clone, corev1.EventTypeWarning, events.ReasonQuotaExceeded, err.Error())
+	}
+	r.markPending(ctx, clone, reason, err.Error())
+	return true, ctrl.Result{RequeueAfter: quotaRecheckInterval}
+}
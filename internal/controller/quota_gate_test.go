@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+)
+
+// Two snapshots admitted together against a quota with room for one: the
+// older proceeds, the newer waits with a QuotaExceeded condition.
+func TestGateSnapshotQuotaOlderWins(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newSnapshot := func(name string, age time.Duration) *infrav1beta1.VMSnapshot {
+		return &infrav1beta1.VMSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name),
+				CreationTimestamp: metav1.NewTime(created.Add(age))},
+			Spec: infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: "web"}},
+		}
+	}
+	first, second := newSnapshot("first", 0), newSnapshot("second", time.Second)
+	limits := &infrav1beta1.VirtrigaudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default"},
+		Spec:       infrav1beta1.VirtrigaudQuotaSpec{MaxSnapshotsPerVM: ptr.To[int32](1)},
+	}
+	scheme := capGatingScheme(t)
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(first, second, limits).
+		WithStatusSubresource(first, second).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &VMSnapshotReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+
+	blocked, _ := r.gateSnapshotQuota(ctx, first)
+	assert.False(t, blocked, "the older snapshot fits")
+
+	blocked, res := r.gateSnapshotQuota(ctx, second)
+	require.True(t, blocked)
+	assert.Equal(t, quotaRecheckInterval, res.RequeueAfter)
+	assert.Empty(t, second.Status.Phase, "a held snapshot stays pending")
+	ready := readyCondition(second.Status.Conditions, infrav1beta1.VMSnapshotConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonQuotaExceeded, ready.Reason)
+	assert.Contains(t, ready.Message, quota.DimensionSnapshotsPerVM)
+	assert.Len(t, recorder.Events, 1)
+
+	// A recheck with the same outcome does not repeat the event.
+	blocked, _ = r.gateSnapshotQuota(ctx, second)
+	assert.True(t, blocked)
+	assert.Len(t, recorder.Events, 1)
+
+	// Once the older snapshot fails it no longer counts.
+	first.Status.Phase = infrav1beta1.SnapshotPhaseFailed
+	require.NoError(t, c.Status().Update(ctx, first))
+	latest := &infrav1beta1.VMSnapshot{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(second), latest))
+	blocked, _ = r.gateSnapshotQuota(ctx, latest)
+	assert.False(t, blocked)
+}
+
+func TestVirtrigaudQuotaReconcilerReportsUsage(t *testing.T) {
+	limits := &infrav1beta1.VirtrigaudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default", Generation: 2},
+		Spec:       infrav1beta1.VirtrigaudQuotaSpec{MaxVMs: ptr.To[int32](1)},
+	}
+	web := usageVM("default", "web", "pve")
+	db := usageVM("default", "db", "pve")
+	db.Spec.Disks = []infrav1beta1.DiskSpec{{Name: "data", SizeGiB: 30}}
+	scheme := capGatingScheme(t)
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(limits, web, db).
+		WithStatusSubresource(limits).
+		Build()
+	r := &VirtrigaudQuotaReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(limits)})
+	require.NoError(t, err)
+
+	got := &infrav1beta1.VirtrigaudQuota{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(limits), got))
+	require.NotNil(t, got.Status.Used)
+	assert.Equal(t, int32(2), got.Status.Used.VMs)
+	assert.Equal(t, int64(30), got.Status.Used.TotalDiskGiB)
+	assert.Equal(t, []string{quota.DimensionVMs}, got.Status.Exceeded)
+	assert.Equal(t, int64(2), got.Status.ObservedGeneration)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// startedTask returns the status.lastTask record of the task taskRef that p
+// started for operation. The link p reported with the task is kept only if
+// it points at the Provider's configured endpoint, so a provider runtime
+// cannot put an arbitrary URL into status or Events.
+func startedTask(provider *infravirtrigaudiov1beta1.Provider, p contracts.Provider, operation, taskRef string) *infravirtrigaudiov1beta1.TaskRecord {
+	now := metav1.Now()
+	record := &infravirtrigaudiov1beta1.TaskRecord{ID: taskRef, Operation: operation, StartTime: &now}
+	if linker, ok := p.(contracts.TaskLinker); ok && provider != nil {
+		record.Link = trustedTaskLink(hypervisorEndpoint(provider), linker.TaskLink(taskRef))
+	}
+	return record
+}
+
+// trustedTaskLink returns link if it is an http(s) URL on the host and port
+// of endpoint, and "" otherwise.
+func trustedTaskLink(endpoint, link string) string {
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.User != nil || u.Host == "" {
+		return ""
+	}
+	e, err := url.Parse(endpoint)
+	if err != nil || e.Host == "" || !strings.EqualFold(u.Host, e.Host) {
+		return ""
+	}
+	return link
+}
+
+// taskNote names task in an Event or condition message: " (task <id>)", with
+// the link when there is one, or "" without a task.
+func taskNote(task *infravirtrigaudiov1beta1.TaskRecord) string {
+	switch {
+	case task == nil || task.ID == "":
+		return ""
+	case task.Link != "":
+		return fmt.Sprintf(" (task %s: %s)", task.ID, task.Link)
+	default:
+		return fmt.Sprintf(" (task %s)", task.ID)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// Tiers of a tieredQueue, in the order they are served.
+const (
+	tierExpedited = iota
+	tierNormal
+)
+
+var tierNames = [...]string{tierExpedited: "expedited", tierNormal: "normal"}
+
+// expeditedWeight is how many expedited requests are handed out for each
+// normal one while both tiers have work, so that a stream of user changes
+// cannot starve the periodic resync of every other object.
+const expeditedWeight = 4
+
+// tieredQueue orders a controller's work queue in two tiers. Requests
+// marked with Expedite, which the event filters do for user-initiated
+// changes, are handed out before the rest, most of which is periodic
+// resync. It is the ordering inside client-go's workqueue rather than a
+// queue of its own, so deduplication, rate limiting and delayed requeues
+// behave as with the default FIFO. While nothing is expedited the order is
+// that FIFO's.
+//
+// A nil *tieredQueue expedites nothing.
+type tieredQueue struct {
+	clock clock.PassiveClock
+
+	mu sync.Mutex
+	// expedite holds the requests to put in the expedited tier when the
+	// workqueue next pushes or touches them.
+	expedite map[reconcile.Request]bool
+	fifos    [2][]tieredItem
+	// queued maps each queued request to its live entry. Promoting a
+	// request leaves a stale entry behind in the normal FIFO, which Pop
+	// skips.
+	queued map[reconcile.Request]tieredItem
+	// streak counts the expedited requests handed out in a row.
+	streak int
+	// seq numbers the entries, so a stale one is never mistaken for live.
+	seq uint64
+}
+
+// tieredItem is one entry of a tier's FIFO.
+type tieredItem struct {
+	req   reconcile.Request
+	tier  int
+	seq   uint64
+	since time.Time
+}
+
+var _ workqueue.Queue[reconcile.Request] = (*tieredQueue)(nil)
+
+func newTieredQueue(c clock.PassiveClock) *tieredQueue {
+	return &tieredQueue{
+		clock:    c,
+		expedite: map[reconcile.Request]bool{},
+		queued:   map[reconcile.Request]tieredItem{},
+	}
+}
+
+// Expedite puts req in the expedited tier on its next add. Call it just
+// before the request is added to the work queue.
+func (q *tieredQueue) Expedite(req reconcile.Request) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.expedite[req] = true
+}
+
+// NewQueue builds the rate-limited work queue of controllerName around q,
+// as the controller.Options NewQueue hook, and exposes q's tiers as
+// metrics under the same name.
+func (q *tieredQueue) NewQueue(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	metrics.RegisterWorkqueueTiers(controllerName, q.stats)
+	base := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[reconcile.Request]{Name: controllerName, Queue: q})
+	delaying := workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[reconcile.Request]{Name: controllerName, Queue: base})
+	return workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+		Name:          controllerName,
+		DelayingQueue: delaying,
+	})
+}
+
+// Push queues a request that is not queued yet.
+func (q *tieredQueue) Push(req reconcile.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tier := tierNormal
+	if q.expedite[req] {
+		tier = tierExpedited
+		delete(q.expedite, req)
+	}
+	q.push(req, tier, q.clock.Now())
+}
+
+// Touch is called when a queued request is added again. An expedited add
+// promotes it; nothing is ever demoted.
+func (q *tieredQueue) Touch(req reconcile.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.expedite[req] {
+		return
+	}
+	delete(q.expedite, req)
+	if item, ok := q.queued[req]; ok && item.tier == tierNormal {
+		q.push(req, tierExpedited, item.since)
+	}
+}
+
+func (q *tieredQueue) push(req reconcile.Request, tier int, since time.Time) {
+	q.seq++
+	item := tieredItem{req: req, tier: tier, seq: q.seq, since: since}
+	q.queued[req] = item
+	q.fifos[tier] = append(q.fifos[tier], item)
+}
+
+// Len returns the number of queued requests.
+func (q *tieredQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.queued)
+}
+
+// Pop hands out the next request: from the expedited tier, except that
+// after expeditedWeight expedited requests in a row a waiting normal one
+// goes first. The workqueue only calls it when Len is not 0.
+func (q *tieredQueue) Pop() reconcile.Request {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.dropStale(tierExpedited)
+	q.dropStale(tierNormal)
+
+	tier := tierNormal
+	if len(q.fifos[tierExpedited]) > 0 && (len(q.fifos[tierNormal]) == 0 || q.streak < expeditedWeight) {
+		tier = tierExpedited
+		q.streak++
+	} else {
+		q.streak = 0
+	}
+	item := q.fifos[tier][0]
+	q.fifos[tier][0] = tieredItem{}
+	q.fifos[tier] = q.fifos[tier][1:]
+	delete(q.queued, item.req)
+	return item.req
+}
+
+// dropStale removes the entries at the head of a tier's FIFO that are no
+// longer live.
+func (q *tieredQueue) dropStale(tier int) {
+	for len(q.fifos[tier]) > 0 && !q.live(q.fifos[tier][0]) {
+		q.fifos[tier][0] = tieredItem{}
+		q.fifos[tier] = q.fifos[tier][1:]
+	}
+}
+
+func (q *tieredQueue) live(item tieredItem) bool {
+	current, ok := q.queued[item.req]
+	return ok && current.seq == item.seq
+}
+
+// stats reports the depth of each tier and the age of its oldest request.
+// A promoted request counts as waiting since it was first queued.
+func (q *tieredQueue) stats() []metrics.WorkqueueTier {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.clock.Now()
+	out := make([]metrics.WorkqueueTier, len(tierNames))
+	for tier, name := range tierNames {
+		out[tier].Tier = name
+	}
+	for _, item := range q.queued {
+		t := &out[item.tier]
+		t.Depth++
+		t.OldestAge = max(t.OldestAge, now.Sub(item.since))
+	}
+	return out
+}
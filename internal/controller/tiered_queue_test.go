@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+func queuedVM(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+}
+
+func newTestTieredQueue(t *testing.T) (*tieredQueue, workqueue.TypedRateLimitingInterface[reconcile.Request], *clocktesting.FakePassiveClock) {
+	t.Helper()
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	tiers := newTieredQueue(clock)
+	q := tiers.NewQueue("tiered-"+t.Name(), workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	t.Cleanup(q.ShutDown)
+	return tiers, q, clock
+}
+
+// drain gets n requests, marking each done, and returns their names.
+func drain(t *testing.T, q workqueue.TypedRateLimitingInterface[reconcile.Request], n int) []string {
+	t.Helper()
+	var names []string
+	for range n {
+		req, shutdown := q.Get()
+		require.False(t, shutdown)
+		names = append(names, req.Name)
+		q.Done(req)
+	}
+	return names
+}
+
+func TestTieredQueue_FIFOWithoutExpedite(t *testing.T) {
+	_, q, _ := newTestTieredQueue(t)
+	for _, name := range []string{"a", "b", "c", "b"} {
+		q.Add(queuedVM(name))
+	}
+	assert.Equal(t, 3, q.Len(), "adding a queued request again does not duplicate it")
+	assert.Equal(t, []string{"a", "b", "c"}, drain(t, q, 3))
+}
+
+func TestTieredQueue_ExpeditedFirstWithoutStarvation(t *testing.T) {
+	tiers, q, _ := newTestTieredQueue(t)
+	for i := range 3 {
+		q.Add(queuedVM(fmt.Sprintf("resync-%d", i)))
+	}
+	for i := range 6 {
+		req := queuedVM(fmt.Sprintf("user-%d", i))
+		tiers.Expedite(req)
+		q.Add(req)
+	}
+
+	assert.Equal(t, []string{
+		"user-0", "user-1", "user-2", "user-3", "resync-0",
+		"user-4", "user-5", "resync-1", "resync-2",
+	}, drain(t, q, 9))
+}
+
+func TestTieredQueue_PromotesQueuedRequest(t *testing.T) {
+	tiers, q, _ := newTestTieredQueue(t)
+	q.Add(queuedVM("a"))
+	q.Add(queuedVM("b"))
+
+	tiers.Expedite(queuedVM("b"))
+	q.Add(queuedVM("b"))
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, []string{"b", "a"}, drain(t, q, 2))
+	assert.Zero(t, q.Len())
+
+	// The stale entry "b" left in the normal tier is not handed out again.
+	q.Add(queuedVM("c"))
+	assert.Equal(t, []string{"c"}, drain(t, q, 1))
+}
+
+func TestTieredQueue_ExpediteWhileProcessing(t *testing.T) {
+	tiers, q, _ := newTestTieredQueue(t)
+	q.Add(queuedVM("a"))
+	req, _ := q.Get()
+
+	// A change to "a" arrives while it is reconciled; it is queued again
+	// when the reconcile is done, ahead of "b".
+	tiers.Expedite(req)
+	q.Add(req)
+	q.Add(queuedVM("b"))
+	q.Done(req)
+
+	assert.Equal(t, []string{"a", "b"}, drain(t, q, 2))
+}
+
+func TestTieredQueue_Stats(t *testing.T) {
+	tiers, q, clock := newTestTieredQueue(t)
+	q.Add(queuedVM("resync"))
+	clock.SetTime(clock.Now().Add(30 * time.Second))
+	q.Add(queuedVM("later"))
+	tiers.Expedite(queuedVM("user"))
+	q.Add(queuedVM("user"))
+	clock.SetTime(clock.Now().Add(10 * time.Second))
+
+	assert.Equal(t, []metrics.WorkqueueTier{
+		{Tier: "expedited", Depth: 1, OldestAge: 10 * time.Second},
+		{Tier: "normal", Depth: 2, OldestAge: 40 * time.Second},
+	}, tiers.stats())
+}
+
+func TestTieredQueue_NilExpedite(t *testing.T) {
+	var tiers *tieredQueue
+	assert.NotPanics(t, func() { tiers.Expedite(queuedVM("a")) })
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// expireAfterTTL deletes a finished object once ttl has passed since it
+// finished, the way the Job TTL controller does. Before then it requeues
+// the object for when the TTL expires, so a TTL changed after the object
+// finished is still honored. Nothing is done when ttl or finished is nil;
+// a negative ttl counts as zero.
+//
+// The delete is conditional on the object being unchanged since it was
+// read, so an object updated meanwhile, for instance to lengthen its TTL,
+// is looked at again instead.
+func expireAfterTTL(
+	ctx context.Context,
+	c client.Client,
+	obj client.Object,
+	kind string,
+	ttl *metav1.Duration,
+	finished *metav1.Time,
+) (ctrl.Result, error) {
+	if ttl == nil || finished == nil {
+		return ctrl.Result{}, nil
+	}
+	expiry := finished.Add(max(ttl.Duration, 0))
+	if remaining := time.Until(expiry); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	logging.FromContext(ctx).Info("Deleting finished object after its TTL",
+		"kind", kind, "ttl", ttl.Duration.String(), "finished", finished.Time)
+	if err := c.Delete(ctx, obj, client.Preconditions{
+		UID:             ptr.To(obj.GetUID()),
+		ResourceVersion: ptr.To(obj.GetResourceVersion()),
+	}); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	metrics.RecordTTLDeletion(kind, time.Since(expiry))
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func finishedClone(phase infrav1beta1.ClonePhase, ttl *metav1.Duration, finished time.Time) *infrav1beta1.VMClone {
+	completed := metav1.NewTime(finished)
+	return &infrav1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "clone-1",
+			Namespace:  "default",
+			Finalizers: []string{infrav1beta1.VMCloneFinalizer},
+		},
+		Spec: infrav1beta1.VMCloneSpec{
+			Source:             infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "src-vm"}},
+			Target:             infrav1beta1.VMCloneTarget{Name: "clone-target"},
+			TTLAfterCompletion: ttl,
+		},
+		Status: infrav1beta1.VMCloneStatus{Phase: phase, CompletionTime: &completed},
+	}
+}
+
+func TestExpireAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	s := cloneTestScheme(t)
+
+	clone := finishedClone(infrav1beta1.ClonePhaseReady, nil, time.Now().Add(-time.Hour))
+	r := newCloneReconciler(s, nil, clone)
+	result, err := expireAfterTTL(ctx, r.Client, clone, "VMClone", nil, clone.Status.CompletionTime)
+	require.NoError(t, err)
+	assert.Zero(t, result, "without a TTL the object is kept")
+
+	ttl := &metav1.Duration{Duration: 2 * time.Hour}
+	result, err = expireAfterTTL(ctx, r.Client, clone, "VMClone", ttl, clone.Status.CompletionTime)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Minute), "requeued for when the TTL expires")
+
+	result, err = expireAfterTTL(ctx, r.Client, clone, "VMClone", ttl, nil)
+	require.NoError(t, err)
+	assert.Zero(t, result, "an object that has not finished is kept")
+
+	// A negative TTL counts as zero.
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	_, err = expireAfterTTL(ctx, r.Client, got, "VMClone", &metav1.Duration{Duration: -time.Minute}, got.Status.CompletionTime)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	assert.False(t, got.DeletionTimestamp.IsZero())
+}
+
+func TestVMClone_TTLAfterCompletion(t *testing.T) {
+	s := cloneTestScheme(t)
+	ctx := context.Background()
+
+	clone := finishedClone(infrav1beta1.ClonePhaseReady, &metav1.Duration{Duration: time.Hour}, time.Now().Add(-time.Minute))
+	r := newCloneReconciler(s, nil, clone)
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(clone)})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, 58*time.Minute)
+
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(clone), got))
+	got.Spec.TTLAfterCompletion = &metav1.Duration{}
+	require.NoError(t, r.Update(ctx, got))
+
+	// The deletion runs handleDeletion, which drops the finalizer.
+	for range 2 {
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(clone)})
+		require.NoError(t, err)
+	}
+	err = r.Get(ctx, client.ObjectKeyFromObject(clone), got)
+	assert.True(t, apierrors.IsNotFound(err), "got %v", err)
+}
+
+func TestCloneFinishedAt(t *testing.T) {
+	clone := finishedClone(infrav1beta1.ClonePhaseFailed, nil, time.Now())
+	assert.Equal(t, clone.Status.CompletionTime, cloneFinishedAt(clone))
+
+	// Clones that failed before the completion time was set on failure.
+	clone.Status.CompletionTime = nil
+	assert.Nil(t, cloneFinishedAt(clone))
+	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionFailed,
+		metav1.ConditionTrue, "ProviderError", "boom")
+	require.NotNil(t, cloneFinishedAt(clone))
+	assert.False(t, cloneFinishedAt(clone).IsZero())
+}
+
+func TestVMMigration_TTLAfterCompletionDeletesPVC(t *testing.T) {
+	ctx := context.Background()
+	_, _, migration := snapshotFixture()
+	migration.Finalizers = []string{infrav1beta1.VMMigrationFinalizer}
+	migration.Spec.TTLAfterCompletion = &metav1.Duration{Duration: time.Minute}
+	migration.Status.SnapshotID = ""
+	migration.Status.Phase = infrav1beta1.MigrationPhaseFailed
+	migration.Status.StoragePVCName = "snap-migration-storage"
+	migration.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap-migration-storage", Namespace: "default"},
+	}
+	scheme := capGatingScheme(t)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(migration, pvc).
+		WithStatusSubresource(migration).
+		Build()
+	r := &VMMigrationReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	key := client.ObjectKeyFromObject(migration)
+
+	// Failed with no retry policy: finished. The first reconcile deletes the
+	// migration, the second runs its finalizer.
+	for range 2 {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+	}
+	err := c.Get(ctx, key, &infrav1beta1.VMMigration{})
+	assert.True(t, apierrors.IsNotFound(err), "got %v", err)
+	err = c.Get(ctx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+	assert.True(t, apierrors.IsNotFound(err), "got %v", err)
+}
+
+func TestVMMigration_TTLRequeuesReadyMigration(t *testing.T) {
+	ctx := context.Background()
+	_, _, migration := snapshotFixture()
+	migration.Finalizers = []string{infrav1beta1.VMMigrationFinalizer}
+	migration.Spec.TTLAfterCompletion = &metav1.Duration{Duration: time.Hour}
+	migration.Status.Phase = infrav1beta1.MigrationPhaseReady
+	migration.Status.SnapshotID = ""
+	migration.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	r, c := newSnapshotReconciler(t, nil, migration)
+	key := client.ObjectKeyFromObject(migration)
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, 58*time.Minute)
+	require.NoError(t, c.Get(ctx, key, &infrav1beta1.VMMigration{}))
+}
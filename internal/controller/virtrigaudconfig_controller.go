@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+)
+
+// VirtrigaudConfigReconciler watches the manager's config ConfigMap and
+// applies each change to Store. An invalid document is reported and the
+// configuration in effect is kept; a deleted ConfigMap falls back to the
+// defaults.
+type VirtrigaudConfigReconciler struct {
+	client.Client
+	// Key names the ConfigMap.
+	Key   types.NamespacedName
+	Store *config.ConfigStore
+	// Recorder emits events on the ConfigMap; optional.
+	Recorder record.EventRecorder
+}
+
+// LoadVirtrigaudConfig reads the VirtrigaudConfig in the ConfigMap key
+// names. A missing ConfigMap is not an error: the defaults are returned.
+func LoadVirtrigaudConfig(ctx context.Context, c client.Reader, key types.NamespacedName) (*config.VirtrigaudConfig, *corev1.ConfigMap, error) {
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return config.DefaultVirtrigaudConfig(), nil, nil
+		}
+		return nil, nil, fmt.Errorf("getting config ConfigMap %s: %w", key, err)
+	}
+	data, ok := cm.Data[config.VirtrigaudConfigKey]
+	if !ok {
+		return nil, &cm, fmt.Errorf("ConfigMap %s has no %s key", key, config.VirtrigaudConfigKey)
+	}
+	cfg, err := config.ParseVirtrigaudConfig([]byte(data))
+	if err != nil {
+		return nil, &cm, fmt.Errorf("ConfigMap %s: %w", key, err)
+	}
+	return cfg, &cm, nil
+}
+
+// Reconcile loads the ConfigMap and applies it.
+func (r *VirtrigaudConfigReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	cfg, cm, err := LoadVirtrigaudConfig(ctx, r.Client, r.Key)
+	if err != nil {
+		if cm == nil {
+			return ctrl.Result{}, err
+		}
+		// Retrying will not fix the document; the next edit triggers a
+		// reconcile.
+		logger.Error(err, "Ignoring invalid manager configuration; keeping the current one")
+		r.event(ctx, cm, corev1.EventTypeWarning, events.ReasonConfigInvalid, err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	restartRequired := r.Store.Update(cfg)
+	if cm == nil {
+		logger.Info("Manager config ConfigMap not found; using defaults", "configMap", r.Key)
+	} else {
+		logger.Info("Applied manager configuration", "configMap", r.Key, "logLevel", cfg.LogLevel)
+		r.event(ctx, cm, corev1.EventTypeNormal, events.ReasonConfigApplied, "Manager configuration applied")
+	}
+	if len(restartRequired) > 0 {
+		msg := fmt.Sprintf("Changes to %s take effect after the manager restarts", strings.Join(restartRequired, ", "))
+		logger.Info(msg, "fields", restartRequired)
+		if cm != nil {
+			r.event(ctx, cm, corev1.EventTypeWarning, events.ReasonConfigRestartRequired, msg)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *VirtrigaudConfigReconciler) event(ctx context.Context, cm *corev1.ConfigMap, eventType, reason, msg string) {
+	events.Emit(ctx, r.Recorder, cm, eventType, reason, "", msg)
+}
+
+// SetupWithManager watches only the ConfigMap named by Key.
+func (r *VirtrigaudConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isConfig := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.Key.Namespace && obj.GetName() == r.Key.Name
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(isConfig)).
+		Named("virtrigaudconfig").
+		Complete(r)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+var testConfigKey = types.NamespacedName{Namespace: "virtrigaud-system", Name: config.DefaultVirtrigaudConfigName}
+
+func configMapWith(doc string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testConfigKey.Namespace, Name: testConfigKey.Name},
+		Data:       map[string]string{config.VirtrigaudConfigKey: doc},
+	}
+}
+
+func TestVirtrigaudConfigReconciler(t *testing.T) {
+	ctx := context.Background()
+	cm := configMapWith(`
+apiVersion: config.virtrigaud.io/v1beta1
+kind: VirtrigaudConfig
+requeue:
+  running: 45s
+concurrency:
+  virtualMachine: 20
+`)
+	c := fake.NewClientBuilder().WithScheme(coverageTestScheme(t)).WithObjects(cm).Build()
+	store := config.NewConfigStore(config.DefaultVirtrigaudConfig(), nil)
+	recorder := record.NewFakeRecorder(10)
+	r := &VirtrigaudConfigReconciler{Client: c, Key: testConfigKey, Store: store, Recorder: recorder}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: testConfigKey})
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, store.Get().Requeue.Running.Duration)
+	assert.Equal(t, 10, store.Get().Concurrency.VirtualMachine)
+	assert.Equal(t, "Normal ConfigApplied Manager configuration applied", <-recorder.Events)
+	assert.Equal(t, "Warning RestartRequired Changes to concurrency take effect after the manager restarts", <-recorder.Events)
+
+	// The VM controller picks up the new interval on its next reconcile.
+	vmr := &VirtualMachineReconciler{Config: store}
+	assert.Equal(t, 45*time.Second, vmr.getRequeueInterval(&infravirtrigaudiov1beta1.Provider{},
+		contracts.DescribeResponse{PowerState: "On", IPs: []string{"10.0.0.5"}}))
+
+	// An invalid edit is reported and the previous configuration kept.
+	cm.Data[config.VirtrigaudConfigKey] = "apiVersion: config.virtrigaud.io/v1beta1\nkind: VirtrigaudConfig\nrequeue:\n  running: -1s\n"
+	require.NoError(t, c.Update(ctx, cm))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: testConfigKey})
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, store.Get().Requeue.Running.Duration)
+	assert.Contains(t, <-recorder.Events, "Warning ConfigInvalid ConfigMap virtrigaud-system/virtrigaud-config: requeue.running must be positive")
+
+	// Deleting the ConfigMap goes back to the defaults.
+	require.NoError(t, c.Delete(ctx, cm))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: testConfigKey})
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultVirtrigaudConfig().Requeue, store.Get().Requeue)
+}
+
+func TestLoadVirtrigaudConfig_MissingKey(t *testing.T) {
+	cm := configMapWith("")
+	cm.Data = map[string]string{"other.yaml": ""}
+	c := fake.NewClientBuilder().WithScheme(coverageTestScheme(t)).WithObjects(cm).Build()
+
+	_, got, err := LoadVirtrigaudConfig(context.Background(), c, testConfigKey)
+	assert.ErrorContains(t, err, "has no config.yaml key")
+	assert.NotNil(t, got)
+}
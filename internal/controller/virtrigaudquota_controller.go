@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+)
+
+// VirtrigaudQuotaReconciler reports a namespace's usage in the status of
+// its VirtrigaudQuotas. Enforcement does not depend on it: the webhooks and
+// the VM, snapshot and clone controllers measure usage afresh through
+// quota.Check.
+type VirtrigaudQuotaReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtrigaudquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtrigaudquotas/status,verbs=get;update;patch
+
+// Reconcile measures the quota's namespace and records the usage, and the
+// limits it is above, in status.
+func (r *VirtrigaudQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
+	timer := metrics.NewReconcileTimer("VirtrigaudQuota")
+	defer func() {
+		outcome := metrics.OutcomeSuccess
+		if retErr != nil {
+			outcome = metrics.OutcomeError
+		}
+		timer.Finish(outcome)
+	}()
+
+	q := &infravirtrigaudiov1beta1.VirtrigaudQuota{}
+	if err := r.Get(ctx, req.NamespacedName, q); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	used, err := quota.Measure(ctx, r.Client, q.Namespace, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	before := q.Status.DeepCopy()
+	q.Status.Used = used.Status()
+	q.Status.Exceeded = quota.Exceeded(q, used)
+	q.Status.ObservedGeneration = q.Generation
+	if equality.Semantic.DeepEqual(before, &q.Status) {
+		return ctrl.Result{}, nil
+	}
+	if len(q.Status.Exceeded) > 0 {
+		logf.FromContext(ctx).Info("Namespace is above its quota", "quota", req.NamespacedName, "exceeded", q.Status.Exceeded)
+	}
+	if err := r.Status().Update(ctx, q); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// quotasInNamespace enqueues every VirtrigaudQuota in the namespace of obj,
+// whose usage obj counts towards.
+func (r *VirtrigaudQuotaReconciler) quotasInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	quotas := &infravirtrigaudiov1beta1.VirtrigaudQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list VirtrigaudQuotas", "namespace", obj.GetNamespace())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(quotas.Items))
+	for i := range quotas.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&quotas.Items[i])})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VirtrigaudQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	toQuotas := handler.EnqueueRequestsFromMapFunc(r.quotasInNamespace)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infravirtrigaudiov1beta1.VirtrigaudQuota{}).
+		Watches(&infravirtrigaudiov1beta1.VirtualMachine{}, toQuotas).
+		Watches(&infravirtrigaudiov1beta1.VMSnapshot{}, toQuotas).
+		Watches(&infravirtrigaudiov1beta1.VMClone{}, toQuotas).
+		Named("virtrigaudquota").
+		Complete(r)
+}
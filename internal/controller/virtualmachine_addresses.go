@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// vmAddresses turns the addresses a provider reported into the order they
+// are published in: IPv4 before IPv6, each family in address order. Guest
+// agents report addresses in whatever order the guest enumerates them, so
+// without sorting status.ips would reorder between reconciles.
+//
+// ips is the provider's flat list and known the subset it could place on
+// an interface; an address may appear in either or both. Entries that do
+// not parse, and loopback, link-local, multicast and unspecified
+// addresses, are dropped as they do not reach the VM from outside. When
+// an address is reported on several interfaces the lexically first name
+// is kept, again so the result does not depend on report order.
+func vmAddresses(ips []string, known []contracts.GuestAddress) []infravirtrigaudiov1beta1.VMAddress {
+	ifaces := map[netip.Addr]string{}
+	add := func(raw, iface string) {
+		addr, ok := parseReportedIP(raw)
+		if !ok {
+			return
+		}
+		cur, seen := ifaces[addr]
+		if !seen || (iface != "" && (cur == "" || iface < cur)) {
+			ifaces[addr] = iface
+		}
+	}
+	for _, a := range known {
+		add(a.IP, a.Interface)
+	}
+	for _, ip := range ips {
+		add(ip, "")
+	}
+
+	addrs := make([]netip.Addr, 0, len(ifaces))
+	for addr := range ifaces {
+		addrs = append(addrs, addr)
+	}
+	// netip orders IPv4 before IPv6 and then by address bytes.
+	slices.SortFunc(addrs, netip.Addr.Compare)
+
+	out := make([]infravirtrigaudiov1beta1.VMAddress, 0, len(addrs))
+	for _, addr := range addrs {
+		family := infravirtrigaudiov1beta1.IPFamilyIPv6
+		if addr.Is4() {
+			family = infravirtrigaudiov1beta1.IPFamilyIPv4
+		}
+		out = append(out, infravirtrigaudiov1beta1.VMAddress{
+			Address:   addr.String(),
+			Family:    family,
+			Interface: ifaces[addr],
+		})
+	}
+	return out
+}
+
+// parseReportedIP parses an address as providers report it, tolerating a
+// zone ("fe80::1%eth0") or prefix length ("10.0.0.5/24"), and reports
+// whether it is one worth publishing.
+func parseReportedIP(raw string) (netip.Addr, bool) {
+	raw = strings.TrimSpace(raw)
+	if i := strings.IndexByte(raw, '/'); i >= 0 {
+		raw = raw[:i]
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr = addr.WithZone("").Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// setStatusAddresses publishes addrs, as returned by vmAddresses, in the
+// VM's status: the structured list, the flat ips kept for existing
+// consumers, and the primary IP selected by the VM's policy.
+func setStatusAddresses(vm *infravirtrigaudiov1beta1.VirtualMachine, addrs []infravirtrigaudiov1beta1.VMAddress) {
+	var policy infravirtrigaudiov1beta1.PrimaryIPPolicy
+	if vm.Spec.Networking != nil {
+		policy = vm.Spec.Networking.PrimaryIPPolicy
+	}
+
+	vm.Status.IPs = addressIPs(addrs)
+	vm.Status.Addresses = addrs
+	if len(addrs) == 0 {
+		vm.Status.Addresses = nil
+	}
+	vm.Status.PrimaryIP = selectPrimaryIP(addrs, policy)
+}
+
+// addressIPs returns the flat list of addrs, nil when there are none.
+func addressIPs(addrs []infravirtrigaudiov1beta1.VMAddress) []string {
+	var ips []string
+	for _, a := range addrs {
+		ips = append(ips, a.Address)
+	}
+	return ips
+}
+
+// selectPrimaryIP applies a PrimaryIPPolicy to addresses sorted by
+// vmAddresses. An empty policy is FirstIPv4. A Subnet policy whose CIDR
+// does not parse selects nothing; the webhook rejects such specs.
+func selectPrimaryIP(addrs []infravirtrigaudiov1beta1.VMAddress, policy infravirtrigaudiov1beta1.PrimaryIPPolicy) string {
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	if cidr, ok := strings.CutPrefix(string(policy), infravirtrigaudiov1beta1.PrimaryIPPolicySubnetPrefix); ok {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return ""
+		}
+		for _, a := range addrs {
+			if addr, err := netip.ParseAddr(a.Address); err == nil && prefix.Contains(addr) {
+				return a.Address
+			}
+		}
+		return ""
+	}
+
+	want := infravirtrigaudiov1beta1.IPFamilyIPv4
+	if policy == infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv6 {
+		want = infravirtrigaudiov1beta1.IPFamilyIPv6
+	}
+	for _, a := range addrs {
+		if a.Family == want {
+			return a.Address
+		}
+	}
+	return addrs[0].Address
+}
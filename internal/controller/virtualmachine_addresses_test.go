@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func v4(addr, iface string) infravirtrigaudiov1beta1.VMAddress {
+	return infravirtrigaudiov1beta1.VMAddress{Address: addr, Family: infravirtrigaudiov1beta1.IPFamilyIPv4, Interface: iface}
+}
+
+func v6(addr, iface string) infravirtrigaudiov1beta1.VMAddress {
+	return infravirtrigaudiov1beta1.VMAddress{Address: addr, Family: infravirtrigaudiov1beta1.IPFamilyIPv6, Interface: iface}
+}
+
+func TestVMAddresses(t *testing.T) {
+	tests := []struct {
+		name  string
+		ips   []string
+		known []contracts.GuestAddress
+		want  []infravirtrigaudiov1beta1.VMAddress
+	}{
+		{
+			name: "empty",
+			want: []infravirtrigaudiov1beta1.VMAddress{},
+		},
+		{
+			name: "IPv4 before IPv6, each in address order",
+			ips:  []string{"2001:db8::20", "10.0.0.10", "2001:db8::3", "10.0.0.9", "192.168.1.5"},
+			want: []infravirtrigaudiov1beta1.VMAddress{
+				v4("10.0.0.9", ""), v4("10.0.0.10", ""), v4("192.168.1.5", ""),
+				v6("2001:db8::3", ""), v6("2001:db8::20", ""),
+			},
+		},
+		{
+			name: "report order does not matter",
+			ips:  []string{"192.168.1.5", "2001:db8::20", "10.0.0.9", "2001:db8::3", "10.0.0.10"},
+			want: []infravirtrigaudiov1beta1.VMAddress{
+				v4("10.0.0.9", ""), v4("10.0.0.10", ""), v4("192.168.1.5", ""),
+				v6("2001:db8::3", ""), v6("2001:db8::20", ""),
+			},
+		},
+		{
+			name: "loopback, link-local and unparseable entries are dropped",
+			ips: []string{
+				"fe80::5054:ff:fe12:3456%eth0", "127.0.0.1", "169.254.10.1", "::1",
+				"10.0.0.5", "not-an-ip", "", "fe80::1", "2001:db8::5",
+			},
+			want: []infravirtrigaudiov1beta1.VMAddress{v4("10.0.0.5", ""), v6("2001:db8::5", "")},
+		},
+		{
+			name: "interfaces annotate the flat list",
+			ips:  []string{"10.0.0.5", "10.0.1.7", "2001:db8::5"},
+			known: []contracts.GuestAddress{
+				{IP: "2001:db8::5", Interface: "eth0"},
+				{IP: "10.0.0.5", Interface: "eth0"},
+				{IP: "fe80::1", Interface: "eth0"},
+			},
+			want: []infravirtrigaudiov1beta1.VMAddress{
+				v4("10.0.0.5", "eth0"), v4("10.0.1.7", ""), v6("2001:db8::5", "eth0"),
+			},
+		},
+		{
+			name: "addresses only in the per-interface list are kept",
+			known: []contracts.GuestAddress{
+				{IP: "10.0.0.5/24", Interface: "ens3"},
+				{IP: "::ffff:10.0.0.6", Interface: "ens4"},
+			},
+			want: []infravirtrigaudiov1beta1.VMAddress{v4("10.0.0.5", "ens3"), v4("10.0.0.6", "ens4")},
+		},
+		{
+			name: "an address on several interfaces keeps the first name",
+			ips:  []string{"10.0.0.5", "10.0.0.5"},
+			known: []contracts.GuestAddress{
+				{IP: "10.0.0.5", Interface: "eth1"},
+				{IP: "10.0.0.5", Interface: "br0"},
+			},
+			want: []infravirtrigaudiov1beta1.VMAddress{v4("10.0.0.5", "br0")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, vmAddresses(tt.ips, tt.known))
+		})
+	}
+}
+
+func TestSelectPrimaryIP(t *testing.T) {
+	mixed := vmAddresses([]string{"2001:db8::5", "fe80::1", "192.168.1.5", "10.0.0.5", "169.254.0.9"}, nil)
+	v6Only := vmAddresses([]string{"2001:db8::9", "fe80::1", "2001:db8::5"}, nil)
+	v4Only := vmAddresses([]string{"10.0.0.9", "169.254.0.9", "10.0.0.5"}, nil)
+	// Two dual-stack NICs, reported as the mock provider does for static
+	// addresses.
+	dualStack := vmAddresses(nil, []contracts.GuestAddress{
+		{IP: "2001:db8:1::5", Interface: "eth1"},
+		{IP: "10.0.0.5", Interface: "eth0"},
+		{IP: "2001:db8::5", Interface: "eth0"},
+	})
+
+	tests := []struct {
+		name   string
+		addrs  []infravirtrigaudiov1beta1.VMAddress
+		policy infravirtrigaudiov1beta1.PrimaryIPPolicy
+		want   string
+	}{
+		{"default is FirstIPv4", mixed, "", "10.0.0.5"},
+		{"FirstIPv4", mixed, infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv4, "10.0.0.5"},
+		{"FirstIPv6", mixed, infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv6, "2001:db8::5"},
+		{"FirstIPv4 falls back to IPv6", v6Only, infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv4, "2001:db8::5"},
+		{"FirstIPv6 falls back to IPv4", v4Only, infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv6, "10.0.0.5"},
+		{"dual-stack FirstIPv6", dualStack, infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv6, "2001:db8::5"},
+		{"dual-stack second NIC by subnet", dualStack, "Subnet:2001:db8:1::/48", "2001:db8:1::5"},
+		{"IPv4 subnet", mixed, "Subnet:192.168.1.0/24", "192.168.1.5"},
+		{"IPv6 subnet", mixed, "Subnet:2001:db8::/32", "2001:db8::5"},
+		{"subnet without a match", mixed, "Subnet:172.16.0.0/12", ""},
+		{"link-local subnet never matches", mixed, "Subnet:169.254.0.0/16", ""},
+		{"invalid subnet", mixed, "Subnet:10.0.0.0", ""},
+		{"no addresses", nil, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectPrimaryIP(tt.addrs, tt.policy))
+		})
+	}
+}
+
+func TestSetStatusAddresses(t *testing.T) {
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	vm.Spec.Networking = &infravirtrigaudiov1beta1.VMNetworkingSpec{PrimaryIPPolicy: infravirtrigaudiov1beta1.PrimaryIPPolicyFirstIPv6}
+
+	setStatusAddresses(vm, vmAddresses([]string{"2001:db8::5", "10.0.0.5"}, []contracts.GuestAddress{{IP: "10.0.0.5", Interface: "eth0"}}))
+	assert.Equal(t, []string{"10.0.0.5", "2001:db8::5"}, vm.Status.IPs)
+	assert.Equal(t, "2001:db8::5", vm.Status.PrimaryIP)
+	assert.Equal(t, []infravirtrigaudiov1beta1.VMAddress{v4("10.0.0.5", "eth0"), v6("2001:db8::5", "")}, vm.Status.Addresses)
+
+	// A powered-off VM reports no addresses; all three fields clear.
+	setStatusAddresses(vm, vmAddresses(nil, nil))
+	assert.Nil(t, vm.Status.IPs)
+	assert.Empty(t, vm.Status.PrimaryIP)
+	assert.Nil(t, vm.Status.Addresses)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// ConditionDriftDetected is True while an adopted VM's CPU or memory differs
+// from what its VMClass (and spec.resources) ask for. Drift is reported, not
+// corrected: the controller does not resize a VM just because it adopted it.
+const ConditionDriftDetected = "DriftDetected"
+
+// Reasons for the DriftDetected condition and for adoption failures.
+const (
+	ReasonResourcesDiffer  = "ResourcesDiffer"
+	ReasonResourcesMatch   = "ResourcesMatch"
+	ReasonAdoptedVMMissing = "AdoptedVMMissing"
+)
+
+// adoptsExisting reports whether vm takes over a hypervisor VM through
+// spec.adoptExisting rather than creating one.
+func adoptsExisting(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return vm.Spec.AdoptExisting != nil && vm.Spec.AdoptExisting.ID != ""
+}
+
+// retainsProviderVM reports whether deleting vm must leave its hypervisor VM
+// in place. Only adopted VMs can retain, and they do unless the deletion
+// policy explicitly says Delete.
+func retainsProviderVM(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return adoptsExisting(vm) &&
+		vm.Spec.AdoptExisting.DeletionPolicy != infravirtrigaudiov1beta1.AdoptDeletionPolicyDelete
+}
+
+// adoptExistingVM binds vm to the hypervisor VM named by
+// spec.adoptExisting.id. It never calls Create: a VM the provider does not
+// report is surfaced on the Ready condition and re-checked later.
+func (r *VirtualMachineReconciler) adoptExistingVM(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	id := vm.Spec.AdoptExisting.ID
+
+	desc, err := provider.Describe(ctx, id)
+	if err != nil && !contracts.IsNotFound(err) {
+		logger.Error(err, "Failed to describe VM to adopt", "id", id)
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError,
+			fmt.Sprintf("Failed to describe VM %s to adopt: %v", id, err))
+		metrics.RecordError(errReasonProviderDescribe, metrics.ComponentManager)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+	}
+	if err != nil || !desc.Exists {
+		logger.Info("VM to adopt does not exist on the provider", "id", id)
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonAdoptedVMMissing,
+			fmt.Sprintf("VM %s to adopt was not found on the provider", id))
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().AdoptionRetry.Duration}, nil
+	}
+
+	logger.Info("Adopted existing VM", "id", id, "powerState", desc.PowerState)
+	vm.Status.ID = id
+	vm.Status.Identity = desc.Identity
+	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerState(desc.PowerState)
+	setStatusAddresses(vm, vmAddresses(desc.IPs, desc.Addresses))
+	vm.Status.ConsoleURL = desc.ConsoleURL
+	vm.Status.Provider = desc.ProviderRaw
+
+	// The class becomes the baseline for reconfigure, so only later edits
+	// to it or to spec.resources resize the VM.
+	r.recordAppliedSpec(vm, vmClass, nil)
+	r.recordAdoptionDrift(ctx, vm, provider)
+
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess,
+		fmt.Sprintf("Adopted existing VM %s", id))
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonVMAdopted, fmt.Sprintf("Adopted existing VM %s", id))
+	r.updateStatus(ctx, vm)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// recordAdoptionDrift compares the adopted VM's CPU and memory, as listed by
+// the provider, with vm.Status.CurrentResources and sets DriftDetected. A
+// provider that cannot list the VM leaves the condition unset.
+func (r *VirtualMachineReconciler) recordAdoptionDrift(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, provider contracts.Provider) {
+	logger := log.FromContext(ctx)
+
+	vms, err := provider.ListVMs(ctx)
+	if err != nil {
+		logger.Info("Cannot list VMs to check adopted VM for drift", "error", err.Error())
+		return
+	}
+	for _, info := range vms {
+		if info.ID != vm.Status.ID {
+			continue
+		}
+		if drift := resourceDrift(info, vm.Status.CurrentResources); len(drift) > 0 {
+			message := "Adopted VM differs from its VMClass: " + strings.Join(drift, ", ")
+			k8s.SetCondition(&vm.Status.Conditions, ConditionDriftDetected, metav1.ConditionTrue, ReasonResourcesDiffer, message)
+			r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonDriftDetected, message)
+		} else {
+			k8s.SetCondition(&vm.Status.Conditions, ConditionDriftDetected, metav1.ConditionFalse, ReasonResourcesMatch,
+				"Adopted VM matches its VMClass")
+		}
+		return
+	}
+	logger.V(1).Info("Adopted VM not listed by the provider; skipping drift check", "id", vm.Status.ID)
+}
+
+// resourceDrift describes each way actual differs from desired. Values the
+// provider does not report (zero) are not compared.
+func resourceDrift(actual contracts.VMInfo, desired *infravirtrigaudiov1beta1.VirtualMachineResources) []string {
+	if desired == nil {
+		return nil
+	}
+	var drift []string
+	if desired.CPU != nil && actual.CPU != 0 && actual.CPU != *desired.CPU {
+		drift = append(drift, fmt.Sprintf("cpu %d, want %d", actual.CPU, *desired.CPU))
+	}
+	if desired.MemoryMiB != nil && actual.MemoryMiB != 0 && actual.MemoryMiB != *desired.MemoryMiB {
+		drift = append(drift, fmt.Sprintf("memory %dMiB, want %dMiB", actual.MemoryMiB, *desired.MemoryMiB))
+	}
+	return drift
+}
+
+// clearDrift marks a previously drifted VM as matching once a reconfigure
+// has brought it in line with its class.
+func clearDrift(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	if k8s.IsConditionTrue(vm.Status.Conditions, ConditionDriftDetected) {
+		k8s.SetCondition(&vm.Status.Conditions, ConditionDriftDetected, metav1.ConditionFalse, ReasonResourcesMatch,
+			"VM reconfigured to match its VMClass")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// adoptStubProvider reports one existing VM through Describe and ListVMs
+// and counts Create and Delete calls.
+type adoptStubProvider struct {
+	createCountingProvider
+	vm        *contracts.VMInfo
+	deleteCnt int
+}
+
+func (p *adoptStubProvider) Describe(_ context.Context, id string) (contracts.DescribeResponse, error) {
+	if p.vm == nil || p.vm.ID != id {
+		return contracts.DescribeResponse{}, nil
+	}
+	return contracts.DescribeResponse{Exists: true, PowerState: p.vm.PowerState, IPs: p.vm.IPs}, nil
+}
+
+func (p *adoptStubProvider) ListVMs(_ context.Context) ([]contracts.VMInfo, error) {
+	if p.vm == nil {
+		return nil, nil
+	}
+	return []contracts.VMInfo{*p.vm}, nil
+}
+
+func (p *adoptStubProvider) Delete(_ context.Context, _ string) (string, error) {
+	p.deleteCnt++
+	return "", nil
+}
+
+func adoptingVM(ns, id string) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM(ns)
+	vm.Name = "adopting-vm"
+	vm.Spec.AdoptExisting = &infravirtrigaudiov1beta1.AdoptExistingSpec{ID: id}
+	return vm
+}
+
+func TestReconcileVM_AdoptExisting(t *testing.T) {
+	cases := []struct {
+		name      string
+		cpu       int32
+		memoryMiB int64
+		wantDrift metav1.ConditionStatus
+	}{
+		{"matches class", 4, 8192, metav1.ConditionFalse},
+		{"differs from class", 2, 4096, metav1.ConditionTrue},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := coverageTestScheme(t)
+			prov, class := providerAndClass("default")
+			vm := adoptingVM("default", "10042")
+			p := &adoptStubProvider{vm: &contracts.VMInfo{
+				ID: "10042", PowerState: "On", IPs: []string{"10.0.0.5"}, CPU: tc.cpu, MemoryMiB: tc.memoryMiB,
+			}}
+			r := newTestReconciler(s, &stubResolver{provider: p}, prov, class, vm)
+
+			_, err := r.reconcileVM(ctx, vm)
+			require.NoError(t, err)
+			assert.Equal(t, 0, p.createCnt, "an adopted VM must never be created")
+
+			var got infravirtrigaudiov1beta1.VirtualMachine
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &got))
+			assert.Equal(t, "10042", got.Status.ID)
+			assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, got.Status.PowerState)
+			assert.Equal(t, []string{"10.0.0.5"}, got.Status.IPs)
+
+			drift := k8s.GetCondition(got.Status.Conditions, ConditionDriftDetected)
+			require.NotNil(t, drift)
+			assert.Equal(t, tc.wantDrift, drift.Status)
+
+			// The next pass manages the VM like any other, without resizing it.
+			_, err = r.reconcileVM(ctx, &got)
+			require.NoError(t, err)
+			assert.Equal(t, 0, p.createCnt)
+			assert.True(t, k8s.IsConditionTrue(got.Status.Conditions, k8s.ConditionReady))
+		})
+	}
+}
+
+func TestReconcileVM_AdoptExistingMissing(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	prov, class := providerAndClass("default")
+	vm := adoptingVM("default", "10042")
+	p := &adoptStubProvider{}
+	r := newTestReconciler(s, &stubResolver{provider: p}, prov, class, vm)
+
+	res, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultVirtrigaudConfig().Requeue.AdoptionRetry.Duration, res.RequeueAfter)
+	assert.Equal(t, 0, p.createCnt, "a missing VM to adopt must not be created")
+	assert.Empty(t, vm.Status.ID)
+
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, ReasonAdoptedVMMissing, ready.Reason)
+
+	// An adopted VM that later disappears is reported, not recreated.
+	vm.Status.ID = "10042"
+	res, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultVirtrigaudConfig().Requeue.AdoptionRetry.Duration, res.RequeueAfter)
+	assert.Equal(t, 0, p.createCnt)
+	assert.Equal(t, "10042", vm.Status.ID)
+}
+
+func TestHandleDeletion_AdoptedVM(t *testing.T) {
+	cases := []struct {
+		name       string
+		policy     infravirtrigaudiov1beta1.AdoptDeletionPolicy
+		wantDelete int
+	}{
+		{"default retains", "", 0},
+		{"retain", infravirtrigaudiov1beta1.AdoptDeletionPolicyRetain, 0},
+		{"delete", infravirtrigaudiov1beta1.AdoptDeletionPolicyDelete, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := coverageTestScheme(t)
+			p := &adoptStubProvider{}
+			vm := deletionVM("vm-adopted")
+			vm.Spec.AdoptExisting = &infravirtrigaudiov1beta1.AdoptExistingSpec{ID: "100", DeletionPolicy: tc.policy}
+			r := newTestReconciler(s, &stubResolver{provider: p}, vm, deletionProviderCR())
+			marked := markForDeletion(t, r, vm)
+
+			_, err := r.handleDeletion(ctx, marked)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDelete, p.deleteCnt)
+
+			var after infravirtrigaudiov1beta1.VirtualMachine
+			assert.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(vm), &after)),
+				"finalizer must be removed either way")
+		})
+	}
+}
+
+func TestResourceDrift(t *testing.T) {
+	cpu, memory := int32(4), int64(8192)
+	desired := &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: &cpu, MemoryMiB: &memory}
+
+	assert.Empty(t, resourceDrift(contracts.VMInfo{CPU: 4, MemoryMiB: 8192}, desired))
+	assert.Empty(t, resourceDrift(contracts.VMInfo{}, desired), "unreported values are not drift")
+	assert.Empty(t, resourceDrift(contracts.VMInfo{CPU: 2}, nil))
+	assert.Equal(t, []string{"cpu 2, want 4", "memory 4096MiB, want 8192MiB"},
+		resourceDrift(contracts.VMInfo{CPU: 2, MemoryMiB: 4096}, desired))
+}
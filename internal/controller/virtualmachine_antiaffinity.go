@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Reasons for the AntiAffinitySatisfied condition.
+const (
+	ReasonHostSelected    = "HostSelected"
+	ReasonNoCompliantHost = "NoCompliantHost"
+	ReasonScheduledAnyway = "ScheduledAnyway"
+	ReasonInvalidSelector = "InvalidSelector"
+)
+
+// antiAffinityRecheckInterval is how often a VM held back by anti-affinity
+// looks for a compliant host again.
+const antiAffinityRecheckInterval = 30 * time.Second
+
+// hostRawKeys are the provider details keys, in order of preference, that
+// providers report a VM's hypervisor host under: flattened describe details
+// and vSphere report "host", Proxmox VE releases before them "node".
+var hostRawKeys = []string{"host", "node"}
+
+// vmHost returns the hypervisor host vm runs on: status.host, or for a VM
+// last described before status.host was recorded, the host in its provider
+// details.
+func vmHost(vm *infravirtrigaudiov1beta1.VirtualMachine) string {
+	if vm.Status.Host != "" {
+		return vm.Status.Host
+	}
+	return hostFromProviderRaw(vm.Status.Provider)
+}
+
+// describedHost returns the host a Describe reports: the typed details
+// host, or for a provider that sends free-form details, the host in them.
+func describedHost(desc contracts.DescribeResponse) string {
+	if desc.Details != nil && desc.Details.Host != "" {
+		return desc.Details.Host
+	}
+	return hostFromProviderRaw(desc.ProviderRaw)
+}
+
+// hostFromProviderRaw returns the host in the provider details of a
+// Describe, or "" when the provider does not report one.
+func hostFromProviderRaw(raw map[string]string) string {
+	for _, key := range hostRawKeys {
+		if host := raw[key]; host != "" {
+			return host
+		}
+	}
+	return ""
+}
+
+// placeVM applies spec.placement.antiAffinity to a VM about to be created.
+// It returns the host to pass as the placement hint, "" to leave the choice
+// to the provider, and whether creation must wait for a compliant host. The
+// outcome is recorded in the AntiAffinitySatisfied condition.
+func (r *VirtualMachineReconciler) placeVM(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+	providerType infravirtrigaudiov1beta1.ProviderType,
+) (string, bool) {
+	aa := vm.Spec.Placement.AntiAffinity
+	peerHosts, err := r.peerHosts(ctx, vm)
+	if err != nil {
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity,
+			metav1.ConditionFalse, ReasonInvalidSelector, err.Error())
+		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonInvalidSelector, err.Error())
+		r.updateStatus(ctx, vm)
+		return "", true
+	}
+
+	if len(peerHosts) == 0 {
+		// Wherever the provider puts the VM satisfies the constraint.
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity,
+			metav1.ConditionTrue, ReasonHostSelected, "No peer VM runs on a known host")
+		return vm.Spec.Placement.Host, false
+	}
+
+	var candidates []contracts.HostCapacity
+	if pinned := vm.Spec.Placement.Host; pinned != "" {
+		candidates = []contracts.HostCapacity{{Name: pinned}}
+	} else {
+		candidates = hostCandidates(ctx, provider, providerType)
+	}
+	host, satisfied := chooseHost(candidates, peerHosts)
+	if satisfied {
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity,
+			metav1.ConditionTrue, ReasonHostSelected, fmt.Sprintf("Host %s runs no peer VM", host))
+		return host, false
+	}
+
+	occupied := strings.Join(slices.Sorted(maps.Keys(peerHosts)), ", ")
+	message := fmt.Sprintf("Every host runs a peer VM (%s)", occupied)
+	if len(candidates) == 0 {
+		message = fmt.Sprintf("Peer VMs run on %s and the provider does not report its other hosts", occupied)
+	}
+	if aa.WhenUnsatisfiable == infravirtrigaudiov1beta1.ScheduleAnyway {
+		log.FromContext(ctx).Info("No host satisfies anti-affinity; creating anyway", "host", host, "reason", message)
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity,
+			metav1.ConditionFalse, ReasonScheduledAnyway, message)
+		return host, false
+	}
+	k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity,
+		metav1.ConditionFalse, ReasonNoCompliantHost, message)
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonNoCompliantHost, message)
+	r.updateStatus(ctx, vm)
+	return "", true
+}
+
+// peerHosts counts the peer VMs of vm on each host. Peers are the VMs in
+// vm's namespace on the same provider that spec.placement.antiAffinity
+// selects, other than vm and VMs being deleted. Peers whose host is not
+// known yet are not counted.
+func (r *VirtualMachineReconciler) peerHosts(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (map[string]int, error) {
+	selector, err := metav1.LabelSelectorAsSelector(vm.Spec.Placement.AntiAffinity.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid antiAffinity labelSelector: %w", err)
+	}
+	var vms infravirtrigaudiov1beta1.VirtualMachineList
+	if err := r.List(ctx, &vms, client.InNamespace(vm.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list peer VMs: %w", err)
+	}
+	hosts := map[string]int{}
+	for i := range vms.Items {
+		peer := &vms.Items[i]
+		if peer.Name == vm.Name || !peer.DeletionTimestamp.IsZero() || peer.Spec.ProviderRef != vm.Spec.ProviderRef {
+			continue
+		}
+		if host := vmHost(peer); host != "" {
+			hosts[host]++
+		}
+	}
+	return hosts, nil
+}
+
+// hostCandidates returns the hosts the provider reports capacity for, or
+// nil when it reports none. vSphere reports capacity per cluster rather
+// than per ESXi host, so its entries are not host hints.
+func hostCandidates(ctx context.Context, provider contracts.Provider, providerType infravirtrigaudiov1beta1.ProviderType) []contracts.HostCapacity {
+	reporter, ok := provider.(contracts.CapacityReporter)
+	if !ok || providerType == infravirtrigaudiov1beta1.ProviderTypeVSphere {
+		return nil
+	}
+	hosts, err := reporter.GetCapacity(ctx)
+	if err != nil {
+		if !contracts.IsNotSupported(err) {
+			log.FromContext(ctx).V(1).Info("Failed to list hosts for anti-affinity", "error", err.Error())
+		}
+		return nil
+	}
+	return hosts
+}
+
+// chooseHost picks the candidate with the fewest peers, breaking ties by
+// the most free memory and then by name, and reports whether it runs no
+// peer. With no candidates it returns "" and false.
+func chooseHost(candidates []contracts.HostCapacity, peerHosts map[string]int) (string, bool) {
+	if len(candidates) == 0 {
+		return "", false
+	}
+	best := slices.MinFunc(candidates, func(a, b contracts.HostCapacity) int {
+		return cmp.Or(
+			cmp.Compare(peerHosts[a.Name], peerHosts[b.Name]),
+			cmp.Compare(b.MemoryTotalBytes-b.MemoryUsedBytes, a.MemoryTotalBytes-a.MemoryUsedBytes),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return best.Name, peerHosts[best.Name] == 0
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// hostsProvider reports capacity for a fixed set of hosts and records the
+// create requests it receives.
+type hostsProvider struct {
+	stubProvider
+	hosts   []contracts.HostCapacity
+	created []contracts.CreateRequest
+}
+
+func (p *hostsProvider) GetCapacity(_ context.Context) ([]contracts.HostCapacity, error) {
+	return p.hosts, nil
+}
+
+func (p *hostsProvider) Create(_ context.Context, req contracts.CreateRequest) (contracts.CreateResponse, error) {
+	p.created = append(p.created, req)
+	return contracts.CreateResponse{ID: "vm-new"}, nil
+}
+
+var _ contracts.CapacityReporter = (*hostsProvider)(nil)
+
+// antiAffinityVM returns a VM labelled app=db that avoids the hosts of the
+// other app=db VMs.
+func antiAffinityVM(name string, when infravirtrigaudiov1beta1.UnsatisfiableConstraintAction) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.Name = name
+	vm.Labels = map[string]string{"app": "db"}
+	vm.Spec.ImportedDisk = &infravirtrigaudiov1beta1.ImportedDiskRef{DiskID: "disk-1", Format: "qcow2", Source: "manual"}
+	vm.Spec.Placement = &infravirtrigaudiov1beta1.Placement{
+		AntiAffinity: &infravirtrigaudiov1beta1.PlacementAntiAffinity{
+			TopologyKey:       infravirtrigaudiov1beta1.TopologyKeyHost,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			WhenUnsatisfiable: when,
+		},
+	}
+	return vm
+}
+
+// peerVM returns a created app=db VM whose provider reported it on host.
+func peerVM(name, host string) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.Name = name
+	vm.Labels = map[string]string{"app": "db"}
+	vm.Status.ID = name
+	vm.Status.Host = host
+	return vm
+}
+
+func hostCapacities(names ...string) []contracts.HostCapacity {
+	hosts := make([]contracts.HostCapacity, 0, len(names))
+	for _, name := range names {
+		hosts = append(hosts, contracts.HostCapacity{Name: name, MemoryTotalBytes: 64 << 30})
+	}
+	return hosts
+}
+
+func TestAntiAffinity_AvoidsPeerHosts(t *testing.T) {
+	prov, class := providerAndClass("default")
+	vm := antiAffinityVM("db-2", infravirtrigaudiov1beta1.DoNotSchedule)
+
+	// Peers found through their provider details or on another provider
+	// count only in the first case.
+	legacy := peerVM("db-0", "")
+	legacy.Status.Provider = map[string]string{"node": "pve-a"}
+	elsewhere := peerVM("db-x", "pve-b")
+	elsewhere.Spec.ProviderRef.Name = "other-prov"
+	unrelated := peerVM("web-0", "pve-b")
+	unrelated.Labels = map[string]string{"app": "web"}
+
+	p := &hostsProvider{hosts: hostCapacities("pve-a", "pve-b", "pve-c")}
+	p.hosts[2].MemoryUsedBytes = 8 << 30
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p},
+		prov, class, vm, legacy, peerVM("db-1", "pve-c"), elsewhere, unrelated)
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+
+	require.Len(t, p.created, 1)
+	assert.Equal(t, "pve-b", p.created[0].Placement.Host)
+	assert.Equal(t, "pve-b", vm.Status.Host)
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, ReasonHostSelected, cond.Reason)
+}
+
+func TestAntiAffinity_DoNotSchedule(t *testing.T) {
+	prov, class := providerAndClass("default")
+	vm := antiAffinityVM("db-2", infravirtrigaudiov1beta1.DoNotSchedule)
+	p := &hostsProvider{hosts: hostCapacities("pve-a", "pve-b")}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p},
+		prov, class, vm, peerVM("db-0", "pve-a"), peerVM("db-1", "pve-b"))
+
+	res, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+
+	assert.Empty(t, p.created, "no host satisfies the constraint")
+	assert.Equal(t, antiAffinityRecheckInterval, res.RequeueAfter)
+	stored := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(vm), stored))
+	cond := k8s.GetCondition(stored.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonNoCompliantHost, cond.Reason)
+	assert.Contains(t, cond.Message, "pve-a, pve-b")
+}
+
+func TestAntiAffinity_ScheduleAnyway(t *testing.T) {
+	prov, class := providerAndClass("default")
+	vm := antiAffinityVM("db-3", infravirtrigaudiov1beta1.ScheduleAnyway)
+	p := &hostsProvider{hosts: hostCapacities("pve-a", "pve-b")}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p},
+		prov, class, vm, peerVM("db-0", "pve-a"), peerVM("db-1", "pve-a"), peerVM("db-2", "pve-b"))
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+
+	require.Len(t, p.created, 1)
+	assert.Equal(t, "pve-b", p.created[0].Placement.Host, "the host with the fewest peers")
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonScheduledAnyway, cond.Reason)
+}
+
+// TestAntiAffinity_UnknownHosts covers a provider that does not report its
+// hosts: the VM can only be placed while no peer has a known host.
+func TestAntiAffinity_UnknownHosts(t *testing.T) {
+	prov, class := providerAndClass("default")
+
+	vm := antiAffinityVM("db-1", infravirtrigaudiov1beta1.DoNotSchedule)
+	cp := &createCountingProvider{}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: cp}, prov, class, vm, peerVM("db-0", ""))
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cp.createCnt)
+
+	vm = antiAffinityVM("db-1", infravirtrigaudiov1beta1.DoNotSchedule)
+	cp = &createCountingProvider{}
+	r = newTestReconciler(coverageTestScheme(t), &stubResolver{provider: cp}, prov, class, vm, peerVM("db-0", "esxi-1"))
+	_, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cp.createCnt)
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionAntiAffinity)
+	require.NotNil(t, cond)
+	assert.Contains(t, cond.Message, "does not report its other hosts")
+}
+
+func TestChooseHost(t *testing.T) {
+	hosts := hostCapacities("a", "b", "c")
+	hosts[0].MemoryUsedBytes = 1 << 30
+
+	host, ok := chooseHost(hosts, map[string]int{"c": 1})
+	assert.Equal(t, "b", host, "most free memory among hosts without peers")
+	assert.True(t, ok)
+
+	host, ok = chooseHost(hosts, map[string]int{"a": 1, "b": 2, "c": 1})
+	assert.Equal(t, "c", host, "fewest peers, then most free memory")
+	assert.False(t, ok)
+
+	host, ok = chooseHost(nil, map[string]int{"a": 1})
+	assert.Empty(t, host)
+	assert.False(t, ok)
+}
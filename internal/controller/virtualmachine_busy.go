@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Reasons for the ProviderBusy condition.
+const (
+	ReasonHypervisorBusy   = "HypervisorBusy"
+	ReasonBusyWaitExceeded = "BusyWaitExceeded"
+	ReasonNotBusy          = "NotBusy"
+)
+
+// A busy VM is described again after providerBusyRecheck at first, the
+// interval growing with the time it has been busy up to
+// providerBusyMaxRecheck: backups and migrations take minutes to hours.
+const (
+	providerBusyRecheck    = 10 * time.Second
+	providerBusyMaxRecheck = 2 * time.Minute
+)
+
+// describedBusyReason returns the busy reason desc reports, "" when the VM
+// is free or the provider does not tell.
+func describedBusyReason(desc contracts.DescribeResponse) string {
+	if desc.Details == nil {
+		return ""
+	}
+	return desc.Details.BusyReason
+}
+
+// busyRecheck is the requeue for a VM that has been busy for busyFor.
+func busyRecheck(busyFor time.Duration) time.Duration {
+	return min(max(busyFor/4, providerBusyRecheck), providerBusyMaxRecheck)
+}
+
+// holdWhileBusy reports whether changes to vm must wait because desc says
+// the hypervisor is busy with it, e.g. locked by a backup or mid-vMotion.
+// Such a change would fail, and the failure is expected: instead of
+// attempting it, with the Warning event and error metric that follow, the
+// VM gets a ProviderBusy condition and is described again shortly. The
+// hold ends when the provider reports the VM free, or after the
+// configured busy.maxWait so a lock nobody releases still surfaces as a
+// failure.
+//
+// A live migration the VM itself requested is followed as usual; the
+// hypervisor reports it busy with that migration.
+func (r *VirtualMachineReconciler) holdWhileBusy(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, desc contracts.DescribeResponse) (ctrl.Result, bool) {
+	logger := log.FromContext(ctx)
+	condType := infravirtrigaudiov1beta1.VirtualMachineConditionProviderBusy
+	current := k8s.GetCondition(vm.Status.Conditions, condType)
+	busy := describedBusyReason(desc)
+
+	if busy == "" || vm.Status.MigrationTaskRef != "" {
+		if current != nil && current.Status == metav1.ConditionTrue {
+			logger.Info("Provider no longer reports the VM busy")
+			k8s.SetCondition(&vm.Status.Conditions, condType, metav1.ConditionFalse, ReasonNotBusy,
+				"The provider no longer reports the VM busy")
+		}
+		return ctrl.Result{}, false
+	}
+
+	maxWait := r.Config.Get().Busy.MaxWait.Duration
+	now := time.Now()
+	since := now
+	if current != nil && current.Status == metav1.ConditionTrue {
+		if current.Reason == ReasonBusyWaitExceeded {
+			// Already past the wait: changes go ahead and fail as usual.
+			return ctrl.Result{}, false
+		}
+		since = current.LastTransitionTime.Time
+	}
+
+	if busyFor := now.Sub(since); busyFor < maxWait {
+		if current == nil || current.Status != metav1.ConditionTrue {
+			logger.Info("Provider reports the VM busy; holding changes", "reason", busy)
+			r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonProviderBusy,
+				fmt.Sprintf("Provider reports the VM busy (%s); changes wait until it is free", busy))
+		}
+		k8s.SetCondition(&vm.Status.Conditions, condType, metav1.ConditionTrue, ReasonHypervisorBusy,
+			fmt.Sprintf("Provider reports the VM busy (%s); changes wait until it is free, at most until %s",
+				busy, since.Add(maxWait).UTC().Format(time.RFC3339)))
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: busyRecheck(busyFor)}, true
+	}
+
+	message := fmt.Sprintf("Provider has reported the VM busy (%s) since %s, longer than %s; changes are attempted again",
+		busy, since.UTC().Format(time.RFC3339), maxWait)
+	logger.Info("VM has been busy longer than the busy wait; attempting changes", "reason", busy, "since", since)
+	r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonProviderBusyExceeded, message)
+	k8s.SetCondition(&vm.Status.Conditions, condType, metav1.ConditionTrue, ReasonBusyWaitExceeded, message)
+	return ctrl.Result{}, false
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// busyProvider describes a running VM the hypervisor reports busy with
+// *busy, "" once free, and counts the reconfigures made against it.
+func busyProvider(busy *string, reconfigures *int) *fakeDescribeProvider {
+	return &fakeDescribeProvider{
+		stubProvider: stubProvider{
+			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+				*reconfigures++
+				return contracts.ReconfigureResult{}, nil
+			},
+		},
+		DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+			return contracts.DescribeResponse{
+				Exists: true, PowerState: "On", IPs: []string{"10.0.0.1"},
+				Details: &describe.Details{BusyReason: *busy},
+			}, nil
+		},
+	}
+}
+
+// busyVM is a VM with a pending disk expansion.
+func busyVM() *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.Status.ID = "vm-1"
+	vm.Spec.Disks = []infravirtrigaudiov1beta1.DiskSpec{{Name: "data", SizeGiB: 20}}
+	vm.Status.AppliedConfig = &infravirtrigaudiov1beta1.VirtualMachineAppliedConfig{
+		Disks: []infravirtrigaudiov1beta1.AppliedDisk{{Name: "data", SizeGiB: 10}},
+	}
+	return vm
+}
+
+func TestReconcileVM_ProviderBusy(t *testing.T) {
+	ctx := context.Background()
+	busy := "config locked: backup"
+	var reconfigures int
+	k8sProv, class := providerAndClass("default")
+	vm := busyVM()
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: busyProvider(&busy, &reconfigures)}, k8sProv, class, vm)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	res, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, providerBusyRecheck, res.RequeueAfter)
+	assert.Zero(t, reconfigures, "changes wait while the VM is busy")
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionProviderBusy)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, ReasonHypervisorBusy, cond.Reason)
+	assert.Contains(t, cond.Message, "config locked: backup")
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, "Normal")
+	assert.Contains(t, event, events.ReasonProviderBusy)
+
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Zero(t, reconfigures)
+	assert.Empty(t, recorder.Events, "the busy VM is reported once")
+
+	// Once free the change goes ahead.
+	busy = ""
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reconfigures)
+	cond = k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionProviderBusy)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonNotBusy, cond.Reason)
+}
+
+func TestReconcileVM_ProviderBusyExceeded(t *testing.T) {
+	ctx := context.Background()
+	busy := "job running: backup job"
+	var reconfigures int
+	k8sProv, class := providerAndClass("default")
+	vm := busyVM()
+	vm.Status.Conditions = []metav1.Condition{{
+		Type:               infravirtrigaudiov1beta1.VirtualMachineConditionProviderBusy,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonHypervisorBusy,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+	}}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: busyProvider(&busy, &reconfigures)}, k8sProv, class, vm)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	_, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reconfigures, "past busy.maxWait changes are attempted")
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionProviderBusy)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonBusyWaitExceeded, cond.Reason)
+	require.NotEmpty(t, recorder.Events)
+	event := <-recorder.Events
+	assert.Contains(t, event, "Warning")
+	assert.Contains(t, event, events.ReasonProviderBusyExceeded)
+}
+
+func TestBusyRecheck(t *testing.T) {
+	assert.Equal(t, providerBusyRecheck, busyRecheck(0))
+	assert.Equal(t, time.Minute, busyRecheck(4*time.Minute))
+	assert.Equal(t, providerBusyMaxRecheck, busyRecheck(time.Hour))
+}
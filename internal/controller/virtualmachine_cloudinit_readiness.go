@@ -0,0 +1,166 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// ConditionCloudInitCompleted reports the first-boot result of cloud-init on
+// VMs with spec.readiness.waitForCloudInit. It is False while cloud-init runs
+// and after it failed, True once it is done, and Unknown when the result
+// could not be learned; only the first two hold the VM back from Ready.
+const ConditionCloudInitCompleted = "CloudInitCompleted"
+
+// Reasons for the CloudInitCompleted condition, and for the Ready condition
+// while it is held back by cloud-init.
+const (
+	ReasonCloudInitWaiting     = "Waiting"
+	ReasonCloudInitDone        = "Done"
+	ReasonCloudInitFailed      = "Failed"
+	ReasonCloudInitTimeout     = "Timeout"
+	ReasonCloudInitUnsupported = "Unsupported"
+	ReasonCloudInitDisabled    = "Disabled"
+
+	ReasonWaitingForCloudInit = "WaitingForCloudInit"
+	ReasonCloudInitError      = "CloudInitFailed"
+)
+
+// defaultCloudInitTimeout applies when spec.readiness.cloudInitTimeout is
+// not set.
+const defaultCloudInitTimeout = 15 * time.Minute
+
+// waitsForCloudInit reports whether vm opted in to the cloud-init gate.
+func waitsForCloudInit(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return vm.Spec.Readiness != nil && vm.Spec.Readiness.WaitForCloudInit
+}
+
+// cloudInitTimeout returns how long a VM may wait for cloud-init before it
+// is reported Ready regardless.
+func cloudInitTimeout(vm *infravirtrigaudiov1beta1.VirtualMachine) time.Duration {
+	if t := vm.Spec.Readiness.CloudInitTimeout; t != nil && t.Duration > 0 {
+		return t.Duration
+	}
+	return defaultCloudInitTimeout
+}
+
+// reconcileCloudInit holds a running VM back from Ready until cloud-init in
+// the guest has finished, and records the outcome on the CloudInitCompleted
+// condition. It returns true when the VM must not be reported Ready yet, in
+// which case it has already set the Ready condition.
+//
+// The guest is asked only until a result is known: apart from a failure,
+// which is re-checked so a guest repaired in place becomes Ready, a settled
+// condition is not re-evaluated and a finished first boot costs no further
+// guest calls.
+// Providers or guests that cannot report cloud-init status, and guests that
+// take longer than the timeout, fall back to the behaviour without the gate.
+func (r *VirtualMachineReconciler) reconcileCloudInit(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+	powerState string,
+) bool {
+	if !waitsForCloudInit(vm) {
+		meta.RemoveStatusCondition(&vm.Status.Conditions, ConditionCloudInitCompleted)
+		return false
+	}
+
+	if cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted); cond != nil &&
+		cond.Reason != ReasonCloudInitWaiting && cond.Reason != ReasonCloudInitFailed {
+		return false
+	}
+	if powerState != string(infravirtrigaudiov1beta1.PowerStateOn) {
+		return false
+	}
+
+	reader, ok := provider.(contracts.CloudInitStatusReader)
+	if !ok {
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionUnknown,
+			ReasonCloudInitUnsupported, "Provider cannot report cloud-init status")
+		return false
+	}
+
+	status, err := reader.GetCloudInitStatus(ctx, vm.Status.ID)
+	switch {
+	case contracts.IsNotSupported(err):
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionUnknown,
+			ReasonCloudInitUnsupported, fmt.Sprintf("Cloud-init status cannot be read: %v", err))
+		return false
+	case err != nil:
+		log.FromContext(ctx).V(1).Info("Cloud-init status not available yet", "vm", vm.Name, "error", err.Error())
+		return waitForCloudInit(vm, fmt.Sprintf("Cloud-init status not available yet: %v", err))
+	}
+
+	switch status.Status {
+	case contracts.CloudInitDone:
+		message := "Cloud-init finished"
+		if len(status.RecoverableErrors) > 0 {
+			message = fmt.Sprintf("Cloud-init finished with recoverable errors: %s", strings.Join(status.RecoverableErrors, "; "))
+		}
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionTrue, ReasonCloudInitDone, message)
+		return false
+	case contracts.CloudInitError:
+		message := "Cloud-init failed"
+		if len(status.Errors) > 0 {
+			message = fmt.Sprintf("Cloud-init failed: %s", strings.Join(status.Errors, "; "))
+		} else if status.Detail != "" {
+			message = fmt.Sprintf("Cloud-init failed: %s", status.Detail)
+		}
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionFalse, ReasonCloudInitFailed, message)
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonCloudInitError, message)
+		return true
+	case contracts.CloudInitDisabled:
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionUnknown,
+			ReasonCloudInitDisabled, "Cloud-init is disabled in the guest")
+		return false
+	}
+
+	message := fmt.Sprintf("Cloud-init is %s", status.Status)
+	if status.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, status.Detail)
+	}
+	return waitForCloudInit(vm, message)
+}
+
+// waitForCloudInit keeps the CloudInitCompleted condition waiting, or gives
+// up on it once the timeout, counted from when waiting began, has passed.
+func waitForCloudInit(vm *infravirtrigaudiov1beta1.VirtualMachine, message string) bool {
+	k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionFalse, ReasonCloudInitWaiting, message)
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+
+	timeout := cloudInitTimeout(vm)
+	if time.Since(cond.LastTransitionTime.Time) >= timeout {
+		k8s.SetCondition(&vm.Status.Conditions, ConditionCloudInitCompleted, metav1.ConditionUnknown, ReasonCloudInitTimeout,
+			fmt.Sprintf("Cloud-init did not finish within %s (last status: %s)", timeout, message))
+		return false
+	}
+
+	k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonWaitingForCloudInit, message)
+	return true
+}
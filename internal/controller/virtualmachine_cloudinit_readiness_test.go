@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// cloudInitStubProvider embeds stubProvider and implements
+// contracts.CloudInitStatusReader, counting the calls.
+type cloudInitStubProvider struct {
+	stubProvider
+	status contracts.CloudInitStatus
+	err    error
+	calls  int
+}
+
+func (p *cloudInitStubProvider) GetCloudInitStatus(_ context.Context, _ string) (contracts.CloudInitStatus, error) {
+	p.calls++
+	return p.status, p.err
+}
+
+func cloudInitVM(timeout time.Duration) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm-ci", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			Readiness: &infravirtrigaudiov1beta1.VMReadinessSpec{WaitForCloudInit: true},
+		},
+	}
+	if timeout > 0 {
+		vm.Spec.Readiness.CloudInitTimeout = &metav1.Duration{Duration: timeout}
+	}
+	vm.Status.ID = "vm-42"
+	return vm
+}
+
+func TestReconcileCloudInit_WaitsUntilDone(t *testing.T) {
+	ctx := context.Background()
+	prov := &cloudInitStubProvider{status: contracts.CloudInitStatus{Status: contracts.CloudInitRunning, Detail: "modules:final"}}
+	vm := cloudInitVM(0)
+	r := &VirtualMachineReconciler{}
+
+	require.True(t, r.reconcileCloudInit(ctx, vm, prov, "On"))
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonCloudInitWaiting, cond.Reason)
+	assert.Contains(t, cond.Message, "modules:final")
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonWaitingForCloudInit, ready.Reason)
+
+	prov.status = contracts.CloudInitStatus{Status: contracts.CloudInitDone, RecoverableErrors: []string{"WARNING: deprecated key"}}
+	require.False(t, r.reconcileCloudInit(ctx, vm, prov, "On"))
+	cond = k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, ReasonCloudInitDone, cond.Reason)
+	assert.Contains(t, cond.Message, "deprecated key")
+
+	// A finished first boot is not asked about again.
+	require.False(t, r.reconcileCloudInit(ctx, vm, prov, "On"))
+	assert.Equal(t, 2, prov.calls)
+}
+
+func TestReconcileCloudInit_FailureHoldsReady(t *testing.T) {
+	prov := &cloudInitStubProvider{status: contracts.CloudInitStatus{
+		Status: contracts.CloudInitError,
+		Errors: []string{"('scripts_user', RuntimeError('runparts: 1 failed'))"},
+	}}
+	vm := cloudInitVM(0)
+
+	require.True(t, (&VirtualMachineReconciler{}).reconcileCloudInit(context.Background(), vm, prov, "On"))
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonCloudInitFailed, cond.Reason)
+	assert.Contains(t, cond.Message, "runparts: 1 failed")
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, ReasonCloudInitError, ready.Reason)
+}
+
+func TestReconcileCloudInit_TimeoutDegrades(t *testing.T) {
+	prov := &cloudInitStubProvider{err: contracts.NewRetryableError("guest agent not responding", nil)}
+	vm := cloudInitVM(time.Minute)
+	vm.Status.Conditions = []metav1.Condition{{
+		Type:               ConditionCloudInitCompleted,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonCloudInitWaiting,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+	}}
+
+	require.False(t, (&VirtualMachineReconciler{}).reconcileCloudInit(context.Background(), vm, prov, "On"))
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, ReasonCloudInitTimeout, cond.Reason)
+	assert.Contains(t, cond.Message, "guest agent not responding")
+}
+
+func TestReconcileCloudInit_UnsupportedDegrades(t *testing.T) {
+	ctx := context.Background()
+	r := &VirtualMachineReconciler{}
+
+	// A provider without the capability at all.
+	vm := cloudInitVM(0)
+	require.False(t, r.reconcileCloudInit(ctx, vm, &stubProvider{}, "On"))
+	assert.Equal(t, ReasonCloudInitUnsupported, k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted).Reason)
+
+	// A guest without an agent.
+	vm = cloudInitVM(0)
+	prov := &cloudInitStubProvider{err: contracts.NewNotSupportedError("domain has no QEMU guest agent channel")}
+	require.False(t, r.reconcileCloudInit(ctx, vm, prov, "On"))
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, ReasonCloudInitUnsupported, cond.Reason)
+}
+
+func TestReconcileCloudInit_NotRequested(t *testing.T) {
+	prov := &cloudInitStubProvider{err: errors.New("must not be called")}
+	vm := cloudInitVM(0)
+	vm.Spec.Readiness = nil
+	vm.Status.Conditions = []metav1.Condition{{Type: ConditionCloudInitCompleted, Status: metav1.ConditionFalse, Reason: ReasonCloudInitWaiting}}
+
+	require.False(t, (&VirtualMachineReconciler{}).reconcileCloudInit(context.Background(), vm, prov, "On"))
+	assert.Nil(t, k8s.GetCondition(vm.Status.Conditions, ConditionCloudInitCompleted))
+	assert.Zero(t, prov.calls)
+}
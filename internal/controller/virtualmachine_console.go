@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+const (
+	consoleLogSourceAnnotation     = "virtrigaud.io/console-log-source"
+	consoleLogTruncatedAnnotation  = "virtrigaud.io/console-log-truncated"
+	consoleLogCapturedAtAnnotation = "virtrigaud.io/console-log-captured-at"
+)
+
+// consoleLogRequested reports whether the VM carries a pending console-log
+// capture request.
+func consoleLogRequested(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	_, ok := vm.Annotations[infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation]
+	return ok
+}
+
+// consoleLogTailLines parses the requested tail length from the annotation.
+func consoleLogTailLines(vm *infravirtrigaudiov1beta1.VirtualMachine) int32 {
+	n, err := strconv.ParseInt(strings.TrimSpace(vm.Annotations[infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation]), 10, 32)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return int32(n)
+}
+
+// handleConsoleLogRequest services a pending console-log request: it fetches
+// the console output from the provider (when it implements
+// contracts.ConsoleOutputReader), stores it — or the failure — in the VM's
+// console-log ConfigMap, and clears the request annotation. The VM's status is
+// left to the reconcile's status write. Failures here never fail the
+// reconcile; they are recorded in the ConfigMap for the requester.
+func (r *VirtualMachineReconciler) handleConsoleLogRequest(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) {
+	if !consoleLogRequested(vm) {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	var (
+		out     contracts.ConsoleOutput
+		captErr error
+	)
+	if reader, ok := providerInstance.(contracts.ConsoleOutputReader); ok {
+		out, captErr = reader.GetConsoleOutput(ctx, vm.Status.ID, consoleLogTailLines(vm))
+	} else {
+		captErr = contracts.NewNotSupportedError("provider does not support console output")
+	}
+	if captErr != nil {
+		logger.Info("Console output capture failed", "vm", vm.Name, "error", captErr.Error())
+	}
+
+	if err := r.writeConsoleLogConfigMap(ctx, vm, out, captErr); err != nil {
+		// Keep the annotation so the request is retried on the next reconcile.
+		logger.Error(err, "Failed to write console log ConfigMap", "vm", vm.Name)
+		return
+	}
+
+	// The annotation is cleared on a copy: the patch response carries the
+	// stored status, which would replace the status this reconcile has
+	// built but not yet written. Only the new metadata is taken back, so
+	// the reconcile's status write applies on top of it.
+	cleared := vm.DeepCopy()
+	delete(cleared.Annotations, infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation)
+	if err := r.Patch(ctx, cleared, client.MergeFrom(vm)); err != nil {
+		logger.Error(err, "Failed to clear console log request annotation", "vm", vm.Name)
+		return
+	}
+	vm.Annotations = cleared.Annotations
+	vm.ResourceVersion = cleared.ResourceVersion
+}
+
+// writeConsoleLogConfigMap creates or updates the VM-owned ConfigMap holding
+// the latest console capture.
+func (r *VirtualMachineReconciler) writeConsoleLogConfigMap(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	out contracts.ConsoleOutput,
+	captErr error,
+) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      infravirtrigaudiov1beta1.ConsoleLogConfigMapName(vm.Name),
+			Namespace: vm.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[consoleLogCapturedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		cm.Annotations[consoleLogSourceAnnotation] = out.Source
+		cm.Annotations[consoleLogTruncatedAnnotation] = strconv.FormatBool(out.Truncated)
+		if captErr != nil {
+			cm.Data = map[string]string{infravirtrigaudiov1beta1.ConsoleLogErrorKey: captErr.Error()}
+		} else {
+			cm.Data = map[string]string{infravirtrigaudiov1beta1.ConsoleLogDataKey: out.Output}
+		}
+		if err := controllerutil.SetControllerReference(vm, cm, r.Scheme); err != nil {
+			return fmt.Errorf("set owner reference: %w", err)
+		}
+		return nil
+	})
+	return err
+}
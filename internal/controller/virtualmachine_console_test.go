@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// consoleStubProvider embeds stubProvider and implements
+// contracts.ConsoleOutputReader, recording the requested tail length.
+type consoleStubProvider struct {
+	stubProvider
+	gotID   string
+	gotTail int32
+}
+
+func (p *consoleStubProvider) GetConsoleOutput(_ context.Context, id string, tailLines int32) (contracts.ConsoleOutput, error) {
+	p.gotID, p.gotTail = id, tailLines
+	return contracts.ConsoleOutput{Output: "boot ok\n", Source: "serial0"}, nil
+}
+
+func consoleRequestVM(name, tail string) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation: tail},
+		},
+	}
+	vm.Status.ID = "vm-42"
+	return vm
+}
+
+// TestHandleConsoleLogRequest_WritesConfigMap verifies a pending request is
+// served into the VM-owned ConfigMap and the request annotation is cleared.
+func TestHandleConsoleLogRequest_WritesConfigMap(t *testing.T) {
+	ctx := context.Background()
+	prov := &consoleStubProvider{}
+	vm := consoleRequestVM("vm-console", "25")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+
+	r.handleConsoleLogRequest(ctx, vm, prov)
+
+	assert.Equal(t, "vm-42", prov.gotID)
+	assert.EqualValues(t, 25, prov.gotTail)
+
+	var cm corev1.ConfigMap
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "default", Name: infravirtrigaudiov1beta1.ConsoleLogConfigMapName("vm-console")}, &cm))
+	assert.Equal(t, "boot ok\n", cm.Data[infravirtrigaudiov1beta1.ConsoleLogDataKey])
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "vm-console", cm.OwnerReferences[0].Name)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.NotContains(t, after.Annotations, infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation)
+}
+
+// TestHandleConsoleLogRequest_KeepsPendingStatus verifies clearing the
+// request leaves the status the reconcile has built in place, and that the
+// reconcile's status write still goes through afterwards.
+func TestHandleConsoleLogRequest_KeepsPendingStatus(t *testing.T) {
+	ctx := context.Background()
+	prov := &consoleStubProvider{}
+	vm := consoleRequestVM("vm-pending", "")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), vm))
+	ctx = withStatusBaseline(ctx, vm)
+
+	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerStateOn
+	r.handleConsoleLogRequest(ctx, vm, prov)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, vm.Status.PowerState)
+	assert.NotContains(t, vm.Annotations, infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation)
+
+	require.NoError(t, r.writeStatus(ctx, vm))
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerStateOn, after.Status.PowerState)
+	assert.NotContains(t, after.Annotations, infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation)
+}
+
+// TestHandleConsoleLogRequest_Unsupported verifies a provider without console
+// support still completes the request, recording the error for the requester.
+func TestHandleConsoleLogRequest_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	prov := &stubProvider{}
+	vm := consoleRequestVM("vm-noconsole", "")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+
+	r.handleConsoleLogRequest(ctx, vm, prov)
+
+	var cm corev1.ConfigMap
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "default", Name: infravirtrigaudiov1beta1.ConsoleLogConfigMapName("vm-noconsole")}, &cm))
+	assert.Contains(t, cm.Data[infravirtrigaudiov1beta1.ConsoleLogErrorKey], "does not support console output")
+	assert.NotContains(t, cm.Data, infravirtrigaudiov1beta1.ConsoleLogDataKey)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.NotContains(t, after.Annotations, infravirtrigaudiov1beta1.ConsoleLogRequestAnnotation)
+}
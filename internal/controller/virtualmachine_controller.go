@@ -17,27 +17,43 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
 	stderrors "errors"
 	"fmt"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
 	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+	"github.com/projectbeskar/virtrigaud/internal/vmtags"
 )
 
 // Reason labels used in metrics.RecordError calls for the VirtualMachine
@@ -58,6 +74,8 @@ const (
 	errReasonProviderTask     = "provider-task-status"
 	errReasonProviderDelete   = "provider-delete"
 	errReasonImagePrepare     = "image-prepare"
+	errReasonNetworkBinding   = "network-binding"
+	errReasonRefDenied        = "ref-denied"
 )
 
 // forceDeleteAnnotation, when set to "true" on a VirtualMachine, lets the
@@ -67,10 +85,6 @@ const (
 // silently orphaned.
 const forceDeleteAnnotation = "virtrigaud.io/force-delete"
 
-// vmDeleteRetryInterval is the requeue cadence while a provider Delete keeps
-// failing (finalizer retained until it succeeds or force-delete is set).
-const vmDeleteRetryInterval = 15 * time.Second
-
 // hasForceDeleteAnnotation reports whether the VM carries the force-delete
 // escape-hatch annotation set to "true".
 func hasForceDeleteAnnotation(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
@@ -83,21 +97,65 @@ type ProviderResolver interface {
 	GetProvider(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (contracts.Provider, error)
 }
 
+// VMProviderResolver is implemented by resolvers that can route all of a
+// VM's RPCs to one provider replica (spec.runtime.sessionAffinity: PerVM).
+// ReleaseVM drops the VM's pin once it is deleted.
+type VMProviderResolver interface {
+	GetProviderForVM(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, vmUID types.UID) (contracts.Provider, error)
+	ReleaseVM(provider *infravirtrigaudiov1beta1.Provider, vmUID types.UID)
+}
+
+// resolveForVM resolves provider through resolver, pinned to vm when the
+// resolver supports it.
+func resolveForVM(
+	ctx context.Context,
+	resolver ProviderResolver,
+	provider *infravirtrigaudiov1beta1.Provider,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+) (contracts.Provider, error) {
+	if vr, ok := resolver.(VMProviderResolver); ok && vm != nil {
+		return vr.GetProviderForVM(ctx, provider, vm.UID) //nolint:wrapcheck
+	}
+	return resolver.GetProvider(ctx, provider) //nolint:wrapcheck
+}
+
 type VirtualMachineReconciler struct {
 	client.Client
 	Scheme         *runtime.Scheme
 	RemoteResolver ProviderResolver
+	// Recorder emits events on the VM; optional.
+	Recorder record.EventRecorder
+	// Config supplies requeue intervals and concurrency; nil uses the
+	// defaults.
+	Config *config.ConfigStore
+	// DNSPublishing selects how VMs with the virtrigaud.io/dns-name
+	// annotation are published; the zero value is DNSPublishingAuto.
+	DNSPublishing DNSPublishing
+
+	// queueTiers orders the work queue so that user changes are not stuck
+	// behind a resync backlog. It is set up by SetupWithManager.
+	queueTiers *tieredQueue
+}
+
+// requeue returns the requeue intervals currently configured.
+func (r *VirtualMachineReconciler) requeue() config.RequeueConfig {
+	return r.Config.Get().Requeue
 }
 
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines/finalizers,verbs=update
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=providers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmsnapshots,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimages,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimages/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmnetworkattachments,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services;endpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile handles VirtualMachine reconciliation.
@@ -126,14 +184,19 @@ func (r *VirtualMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		timer.Finish(outcome)
 	}()
 
+	ctx = logging.StartReconcile(ctx, "vm", req.NamespacedName)
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling VirtualMachine", "name", req.Name, "namespace", req.Namespace)
+	logger.Info("Reconciling VirtualMachine")
 
 	// Fetch the VirtualMachine instance
 	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
 	if err := r.Get(ctx, req.NamespacedName, vm); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info("VirtualMachine not found, assuming deleted")
+			vmAllocations.remove(req.NamespacedName)
+			pausedVMs.remove(req.NamespacedName)
+			longSnapshotChains.remove(req.NamespacedName)
+			vmConflicts.clear(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to fetch VirtualMachine")
@@ -141,18 +204,39 @@ func (r *VirtualMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	// Provider RPCs made from here on are audited against this object.
+	ctx = audit.WithSubject(ctx, vm)
+	ctx = logging.WithLogFields(ctx, vm.Spec.ProviderRef.Name, string(vm.Status.Phase))
+	// Status writes from here on are skipped when they change nothing.
+	ctx = withStatusBaseline(ctx, vm)
+	logger = log.FromContext(ctx)
+
 	// Handle deletion
 	if k8s.IsBeingDeleted(vm) {
+		vmAllocations.remove(req.NamespacedName)
+		pausedVMs.remove(req.NamespacedName)
+		longSnapshotChains.remove(req.NamespacedName)
 		return r.handleDeletion(ctx, vm)
 	}
 
+	// A paused VM is left alone until the annotation is removed; the
+	// watches below enqueue it again at that point.
+	paused, err := r.reconcilePause(ctx, vm)
+	if err != nil {
+		logger.Error(err, "Failed to check whether reconciliation is paused")
+		return ctrl.Result{}, err
+	}
+	if paused {
+		logger.V(1).Info("Reconciliation paused")
+		return ctrl.Result{}, nil
+	}
+
 	// Add finalizer if not present
-	if !k8s.HasFinalizer(vm, infravirtrigaudiov1beta1.VirtualMachineFinalizer) {
-		if err := k8s.AddFinalizer(ctx, r.Client, vm, infravirtrigaudiov1beta1.VirtualMachineFinalizer); err != nil {
-			logger.Error(err, "Failed to add finalizer")
-			metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
-			return ctrl.Result{}, err
-		}
+	if added, err := k8sutil.EnsureFinalizer(ctx, r.Client, vm, infravirtrigaudiov1beta1.VirtualMachineFinalizer); err != nil {
+		logger.Error(err, "Failed to add finalizer")
+		metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	} else if added {
 		// Requeue to continue reconciliation
 		return ctrl.Result{Requeue: true}, nil
 	}
@@ -165,8 +249,10 @@ func (r *VirtualMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Update observed generation
-	vm.Status.ObservedGeneration = vm.Generation
+	dryRun := isDryRun(vm)
+	if !dryRun {
+		clearPlan(vm)
+	}
 
 	// Get dependencies
 	imageRefName := ""
@@ -178,6 +264,15 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 	logger.V(1).Info("Resolving VM dependencies", "provider", vm.Spec.ProviderRef.Name, "class", vm.Spec.ClassRef.Name, "image", imageRefName)
 	provider, vmClass, vmImage, networks, err := r.getDependencies(ctx, vm)
 	if err != nil {
+		// A reference into a namespace that does not admit it only changes
+		// when the spec or the namespace annotation is edited.
+		if k8sutil.IsCrossNamespaceRefDenied(err) {
+			logger.Info("VM references an object in a namespace that does not allow it", "error", err.Error())
+			k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonValidationError, err.Error())
+			metrics.RecordError(errReasonRefDenied, metrics.ComponentManager)
+			r.updateStatus(ctx, vm)
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
 		// Check if Provider is missing - log at INFO level and skip reconciliation
 		// Check both wrapped errors and error message for "not found"
 		if errors.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
@@ -192,22 +287,49 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonWaitingForDependencies, err.Error())
 		metrics.RecordError(errReasonDepsError, metrics.ComponentManager)
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
 	logger.V(1).Info("Dependencies resolved successfully")
+	vmAllocations.set(vm, usage.ResolveVMAllocation(vm, vmClass))
+
+	// Keep only the network bindings for this provider's type. A network
+	// without one is a spec error that only an edit can fix, so poll slowly.
+	networks, err = selectNetworkBindings(provider.Spec.Type, networks)
+	if err != nil {
+		logger.Info("VM references a network without a binding for its provider type", "providerType", provider.Spec.Type, "error", err.Error())
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonValidationError, err.Error())
+		metrics.RecordError(errReasonNetworkBinding, metrics.ComponentManager)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	// Warn about class and image fields the provider will not honor.
+	r.checkPortability(ctx, vm, provider, vmClass, vmImage)
+
+	// A provider sharing no protocol version with the manager would
+	// misread its requests; wait for one of them to be upgraded.
+	if r.gateIncompatibleProvider(ctx, vm, provider) {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
 
 	// Get provider instance (remote or in-process)
 	logger.V(1).Info("Getting provider instance", "provider", provider.Name, "runtime_phase", provider.Status.Runtime.Phase, "endpoint", provider.Status.Runtime.Endpoint)
-	providerInstance, err := r.getProviderInstance(ctx, provider)
+	providerInstance, err := r.getProviderInstance(ctx, provider, vm)
 	if err != nil {
 		logger.Error(err, "Failed to get provider instance - will retry in 5s", "provider", provider.Name, "runtime_phase", provider.Status.Runtime.Phase)
 		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, err.Error())
 		metrics.RecordError(errReasonProviderResolve, metrics.ComponentManager)
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
 	logger.V(1).Info("Provider instance obtained successfully", "provider", provider.Name)
 
+	// While the provider verifies a new endpoint, the VM is only described:
+	// an endpoint that cannot find it must not recreate or change it.
+	if r.gateEndpointMigration(ctx, vm, provider, providerInstance) {
+		return ctrl.Result{RequeueAfter: endpointMigrationRecheck}, nil
+	}
+
 	// Provider liveness is already verified by getProviderInstance →
 	// Resolver.GetProvider (it validates the cached/new client before returning).
 	// Re-validating here doubled the real virsh-over-ssh Validate calls on every
@@ -221,7 +343,19 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 	// providers that do not advertise/implement image import, for ImportedDisk
 	// VMs, and for images already prepared on this provider; in those cases it
 	// returns (false, nil) and we fall through to the unchanged create path.
-	if requeue, err := r.EnsureImageOnProvider(ctx, vm, vmImage, provider, providerInstance); err != nil {
+	// A dry run leaves the image alone: preparing it is part of the planned
+	// create. So does a provider in maintenance, which provisions nothing
+	// new; a VM still to be created waits for the maintenance to end.
+	maintenanceMessage, maintenanceHeld := maintenanceHold(provider, r.Config.Get(), vm)
+	if dryRun {
+		logger.V(1).Info("Dry run: not preparing the image")
+	} else if maintenanceHeld {
+		if vm.Status.ID == "" && !adoptsExisting(vm) && !vmIsAdopted(vm) {
+			r.holdForMaintenance(ctx, vm, maintenanceMessage)
+			return ctrl.Result{RequeueAfter: maintenanceRecheck}, nil
+		}
+		logger.V(1).Info("Provider in maintenance: not preparing the image")
+	} else if requeue, err := r.EnsureImageOnProvider(ctx, vm, vmImage, provider, providerInstance); err != nil {
 		if stderrors.Is(err, errImagePrepareHold) {
 			// OnMissing forbids preparing (Fail/Wait); the condition is recorded
 			// on the VMImage. Reflect a waiting condition on the VM and requeue
@@ -239,7 +373,7 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 			fmt.Sprintf("Image prepare failed: %v", err))
 		metrics.RecordError(errReasonImagePrepare, metrics.ComponentManager)
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	} else if requeue {
 		// A prepare is in flight; surface a provisioning condition and requeue to
 		// poll it. We do NOT create the VM until the image is Ready on the provider.
@@ -256,17 +390,20 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 		done, err := providerInstance.IsTaskComplete(ctx, vm.Status.LastTaskRef)
 		if err != nil {
 			logger.Error(err, "Failed to check task status")
+			if done {
+				r.recordTaskFailed(ctx, vm, err)
+			}
 			k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to check task: %v", err))
 			metrics.RecordError(errReasonProviderTask, metrics.ComponentManager)
 			r.updateStatus(ctx, vm)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 		}
 
 		if !done {
 			logger.Info("Task still in progress", "taskRef", vm.Status.LastTaskRef)
 			k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonTaskInProgress, "Task in progress")
 			r.updateStatus(ctx, vm)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 		}
 
 		// Task completed, clear it
@@ -281,22 +418,30 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 			k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to check reconfigure task: %v", err))
 			metrics.RecordError(errReasonProviderTask, metrics.ComponentManager)
 			r.updateStatus(ctx, vm)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 		}
 
 		if !done {
 			logger.Info("Reconfigure task still in progress", "taskRef", vm.Status.ReconfigureTaskRef)
 			k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonTaskInProgress, "Reconfiguration in progress")
 			r.updateStatus(ctx, vm)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 		}
 
-		// Reconfigure task completed, update current resources and clear task ref
+		// Reconfigure task completed, record what was applied and clear task ref
 		logger.Info("Reconfigure task completed", "taskRef", vm.Status.ReconfigureTaskRef)
-		r.updateCurrentResources(vm, vmClass)
+		r.recordAppliedSpec(vm, vmClass, vm.Status.PendingPowerCycle)
+		recordAppliedSecurityGroups(vm, networks)
+		clearDrift(vm)
 		vm.Status.ReconfigureTaskRef = ""
 		vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseRunning
 		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "VM reconfigured successfully")
+		r.reportPowerCycleRequired(ctx, vm, nil)
+	}
+
+	// A dry run stops here: what follows is planned instead of performed.
+	if dryRun {
+		return r.planVM(ctx, vm, provider, providerInstance, vmClass, vmImage, networks)
 	}
 
 	// Ensure VM exists.
@@ -310,16 +455,24 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 	// create path for non-adopted VMs; an adopted VM with an empty Status.ID
 	// waits for its ID to be set (issue #179).
 	if vm.Status.ID == "" {
+		// A marker verified on an earlier VM says nothing about the next.
+		forgetIdentity(vm)
+		if adoptsExisting(vm) {
+			return r.adoptExistingVM(ctx, vm, providerInstance, vmClass)
+		}
 		if vmIsAdopted(vm) {
 			logger.Info("Adopted VM has no Status.ID yet; waiting for adoption/clone controller to set it (not creating)",
 				"name", vm.Name, "namespace", vm.Namespace)
 			k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionTrue,
 				k8s.ReasonWaitingForDependencies, "Waiting for adoption/clone controller to set Status.ID")
 			r.updateStatus(ctx, vm)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+		}
+		if r.gateVMQuota(ctx, vm) {
+			return ctrl.Result{RequeueAfter: quotaRecheckInterval}, nil
 		}
 		logger.Info("Creating VM")
-		return r.createVM(ctx, vm, providerInstance, provider.Name, vmClass, vmImage, networks)
+		return r.createVM(ctx, vm, providerInstance, provider, vmClass, vmImage, networks)
 	}
 
 	// VM exists, check current state
@@ -329,28 +482,81 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to describe VM: %v", err))
 		metrics.RecordError(errReasonProviderDescribe, metrics.ComponentManager)
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
 
+	if !desc.Exists && adoptsExisting(vm) {
+		// Recreating would produce a blank VM under the adopted one's name.
+		logger.Info("Adopted VM no longer exists on the provider; not recreating", "id", vm.Status.ID)
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonAdoptedVMMissing,
+			fmt.Sprintf("Adopted VM %s no longer exists on the provider", vm.Status.ID))
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().AdoptionRetry.Duration}, nil
+	}
+	if !desc.Exists && maintenanceHeld {
+		r.holdForMaintenance(ctx, vm, maintenanceMessage)
+		return ctrl.Result{RequeueAfter: maintenanceRecheck}, nil
+	}
 	if !desc.Exists {
 		logger.Info("VM no longer exists, recreating")
 		vm.Status.ID = ""
-		return r.createVM(ctx, vm, providerInstance, provider.Name, vmClass, vmImage, networks)
+		forgetIdentity(vm)
+		return r.createVM(ctx, vm, providerInstance, provider, vmClass, vmImage, networks)
+	}
+
+	// An ID the hypervisor reused for another VM must not be acted on:
+	// nothing below runs until an operator resolves the mismatch.
+	if !r.verifyIdentity(ctx, vm, desc.Identity) {
+		return ctrl.Result{RequeueAfter: identityMismatchRecheck}, nil
 	}
 
 	// G7.2 (#127): record virtrigaud_ip_discovery_duration_seconds on
 	// the first reconcile that observes the no-IPs → has-IPs transition
-	// for this VM. Must be called BEFORE the vm.Status.IPs = desc.IPs
-	// assignment below so the gate sees the pre-update value of
-	// vm.Status.IPs. Idempotent across manager restarts because
-	// vm.Status.IPs is persisted in etcd.
-	recordIPDiscoveryIfFirstSeen(vm.Status.IPs, desc.IPs, vm.CreationTimestamp, string(provider.Spec.Type))
+	// for this VM. Must be called BEFORE setStatusAddresses below so the
+	// gate sees the pre-update value of vm.Status.IPs. Idempotent across
+	// manager restarts because vm.Status.IPs is persisted in etcd.
+	addresses := vmAddresses(desc.IPs, desc.Addresses)
+	recordIPDiscoveryIfFirstSeen(vm.Status.IPs, addressIPs(addresses), vm.CreationTimestamp, string(provider.Spec.Type))
 
 	// Update status with current state
 	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerState(desc.PowerState)
-	vm.Status.IPs = desc.IPs
+	setStatusAddresses(vm, addresses)
 	vm.Status.ConsoleURL = desc.ConsoleURL
 	vm.Status.Provider = desc.ProviderRaw
+	if host := describedHost(desc); host != "" {
+		vm.Status.Host = host
+	}
+	vm.Status.GuestStats = GuestStatsStatus(desc.GuestStats, time.Now())
+	r.reconcileSnapshotChain(ctx, vm, desc)
+
+	// Publish the addresses under virtrigaud.io/dns-name, if set.
+	r.reconcileDNS(ctx, vm)
+
+	// Service an on-demand console-log capture (`vrtg vm console-log`).
+	r.handleConsoleLogRequest(ctx, vm, providerInstance)
+
+	// Changes wait while the hypervisor is busy with the VM, e.g. a backup
+	// holding its config lock, rather than failing against it.
+	if res, held := r.holdWhileBusy(ctx, vm, desc); held {
+		return res, nil
+	}
+
+	// A power cycle applying pending changes under the Automatic or Manual
+	// update strategy comes first, so nothing powers the VM on mid-cycle.
+	if res, acted := r.reconcileUpdateCycle(ctx, vm, providerInstance, provider.Name, vmClass, vmImage, networks, desc.PowerState); acted {
+		return res, nil
+	}
+
+	// A requested restart or reset, and any one still running, comes before
+	// power-state correction so the VM is not powered on mid-restart.
+	if res, acted := r.reconcilePowerOpRequest(ctx, vm, providerInstance, desc.PowerState); acted {
+		return res, nil
+	}
+
+	// A requested live migration to another host, and one still running.
+	if res, acted := r.reconcileHostMigration(ctx, vm, provider, providerInstance, desc.PowerState); acted {
+		return res, nil
+	}
 
 	// Check desired power state
 	desiredPowerState := vm.Spec.PowerState
@@ -360,27 +566,44 @@ func (r *VirtualMachineReconciler) reconcileVM(ctx context.Context, vm *infravir
 
 	if desc.PowerState != string(desiredPowerState) {
 		logger.Info("Power state mismatch, adjusting", "current", desc.PowerState, "desired", desiredPowerState)
-		return r.adjustPowerState(ctx, vm, providerInstance, string(desiredPowerState))
+		return r.adjustPowerState(ctx, vm, provider, providerInstance, string(desiredPowerState))
 	}
 
-	// Check if VMClass resources have changed and need reconfiguration
-	if r.needsReconfigure(vm, vmClass) {
-		logger.Info("VMClass resources changed, reconfiguring VM",
+	// Check if the spec has changed since it was last applied. Changes
+	// already waiting for a power cycle are not re-sent while the VM runs,
+	// nor are changes the VM's snapshots would make the provider refuse.
+	changes := desiredChangeSet(vm, vmClass)
+	changes.SecurityGroups = securityGroupChanges(vm, networks)
+	if !changes.IsEmpty() && !awaitingPowerCycle(vm, changes, desc.PowerState) && !r.reconfigureBlockedBySnapshots(ctx, vm, changes) {
+		logger.Info("VM spec changed, reconfiguring VM", "changes", changes.Keys(),
 			"currentCPU", r.getCurrentCPU(vm),
-			"desiredCPU", vmClass.Spec.CPU,
-			"currentMemoryMiB", r.getCurrentMemoryMiB(vm),
-			"desiredMemoryMiB", vmClass.Spec.Memory.Value()/(1024*1024))
-		return r.reconfigureVM(ctx, vm, providerInstance, provider.Name, vmClass, vmImage, networks)
+			"currentMemoryMiB", r.getCurrentMemoryMiB(vm))
+		return r.reconfigureVM(ctx, vm, providerInstance, provider.Name, vmClass, vmImage, networks, changes)
+	} else if changes.IsEmpty() && len(vm.Status.PendingPowerCycle) > 0 {
+		// The spec was reverted to what is running
+		vm.Status.PendingPowerCycle = nil
+		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "No changes pending")
+	} else if changes.IsEmpty() {
+		clearSnapshotBlock(vm)
+	}
+
+	// A VM that opted in is not Ready until cloud-init finished first boot
+	if r.reconcileCloudInit(ctx, vm, providerInstance, desc.PowerState) {
+		vm.Status.ObservedGeneration = vm.Generation
+		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "VM provisioned")
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
 
 	// VM is ready
+	vm.Status.ObservedGeneration = vm.Generation
 	k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonReconcileSuccess, "VM is ready")
 	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "VM provisioned")
 
 	r.updateStatus(ctx, vm)
 
 	// Optimize polling frequency based on VM state
-	return ctrl.Result{RequeueAfter: r.getRequeueInterval(vm, desc)}, nil
+	return ctrl.Result{RequeueAfter: r.getRequeueInterval(provider, desc)}, nil
 }
 
 // handleDeletion handles VM deletion
@@ -391,30 +614,84 @@ func (r *VirtualMachineReconciler) handleDeletion(ctx context.Context, vm *infra
 		return ctrl.Result{}, nil
 	}
 
-	// Get provider if we have a provider ref and VM ID
-	if vm.Status.ID != "" && vm.Spec.ProviderRef.Name != "" {
-		provider := &infravirtrigaudiov1beta1.Provider{}
-		providerKey := types.NamespacedName{
-			Name:      vm.Spec.ProviderRef.Name,
-			Namespace: vm.Namespace,
+	// A dry run keeps the finalizer and reports the Delete it would issue.
+	if isDryRun(vm) {
+		logger.Info("Dry run: planning VM deletion")
+		return r.planDeletion(ctx, vm), nil
+	}
+
+	// An adopted VM outlives its VirtualMachine unless its deletion policy
+	// says otherwise.
+	if vm.Status.ID != "" && retainsProviderVM(vm) {
+		logger.Info("Leaving adopted VM on the provider", "id", vm.Status.ID)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonProviderVMRetained,
+			fmt.Sprintf("Provider VM %s left in place", vm.Status.ID))
+	}
+
+	// A VM found to carry another identity marker is someone else's: none
+	// of it is deleted.
+	foreign := vm.Status.ID != "" && identityMismatched(vm)
+	if foreign {
+		logger.Info("Leaving provider VM with another identity in place", "id", vm.Status.ID)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonProviderVMRetained,
+			fmt.Sprintf("Provider VM %s left in place: it carries another VM's identity marker", vm.Status.ID))
+	}
+
+	// Snapshots go first: the provider deletes them with the VM, which would
+	// defeat a Retain policy and skip the Delete-policy finalizers.
+	if vm.Status.ID != "" && !retainsProviderVM(vm) && !foreign {
+		wait, err := r.releaseSnapshots(ctx, vm)
+		if err != nil {
+			logger.Error(err, "Failed to release VM snapshots")
+			return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 		}
-		if vm.Spec.ProviderRef.Namespace != "" {
-			providerKey.Namespace = vm.Spec.ProviderRef.Namespace
+		if wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
 		}
+	}
 
+	// Get provider if we have a provider ref and VM ID
+	if vm.Status.ID != "" && vm.Spec.ProviderRef.Name != "" && !retainsProviderVM(vm) && !foreign {
+		// The cross-namespace policy is not re-checked here: the reference
+		// was admitted when the VM was created, and refusing it now would
+		// orphan the hypervisor VM.
+		provider := &infravirtrigaudiov1beta1.Provider{}
+		providerKey := k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace)
 		if err := r.Get(ctx, providerKey, provider); err != nil {
 			if !errors.IsNotFound(err) {
 				logger.Error(err, "Failed to get provider for deletion")
 				metrics.RecordError(errReasonDepsError, metrics.ComponentManager)
-				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+				return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 			}
 			// Provider not found, continue with cleanup
+		} else if message, migrating := providerEndpointMigrating(provider); migrating {
+			// A new endpoint that cannot find the VM would report it
+			// deleted and release the finalizer with the VM still running.
+			logger.Info("Holding VM deletion until its provider's new endpoint is verified", "id", vm.Status.ID)
+			k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonProviderEndpointMigrating, message)
+			r.updateStatus(ctx, vm)
+			return ctrl.Result{RequeueAfter: endpointMigrationRecheck}, nil
 		} else {
 			// Delete VM from provider
-			providerInstance, err := r.getProviderInstance(ctx, provider)
+			providerInstance, err := r.getProviderInstance(ctx, provider, vm)
 			if err != nil {
-				logger.Error(err, "Failed to get provider instance for deletion")
 				metrics.RecordError(errReasonProviderResolve, metrics.ComponentManager)
+				if hasForceDeleteAnnotation(vm) {
+					logger.Error(err, "Failed to get provider instance for deletion but force-delete annotation is set; removing finalizer (the provider VM may be orphaned)",
+						"id", vm.Status.ID, "annotation", forceDeleteAnnotation)
+				} else {
+					// Removing the finalizer now would orphan the hypervisor
+					// VM, so this counts as an attempt that could not reach
+					// the provider.
+					logger.Error(err, "Failed to get provider instance for deletion; retaining finalizer", "id", vm.Status.ID)
+					if !r.abandonUnreachableDeletion(ctx, vm, err) {
+						return ctrl.Result{RequeueAfter: r.requeue().DeleteRetry.Duration}, nil
+					}
+				}
+			} else if problem := r.deletionIdentityProblem(ctx, vm, providerInstance); problem != "" {
+				// The ID was reused since the last reconcile.
+				logger.Info("Not deleting provider VM with another identity", "id", vm.Status.ID)
+				r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonIdentityMismatch, problem+"; left in place")
 			} else {
 				logger.Info("Deleting VM from provider", "id", vm.Status.ID)
 				taskRef, err := providerInstance.Delete(ctx, vm.Status.ID)
@@ -424,16 +701,28 @@ func (r *VirtualMachineReconciler) handleDeletion(ctx context.Context, vm *infra
 						logger.Info("VM deletion initiated", "taskRef", taskRef)
 						// TODO: Wait for task completion in future iterations
 					}
+					r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonVMDeleted,
+						fmt.Sprintf("Deleted provider VM %s", vm.Status.ID))
 				case contracts.IsNotFound(err):
 					// The hypervisor VM is already gone — nothing to orphan, so
 					// proceed to finalizer removal (idempotent delete).
 					logger.Info("VM already absent from provider; proceeding with cleanup", "id", vm.Status.ID)
+				case r.vmProviderConflict(ctx, vm, "Delete", err):
+					return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
 				case hasForceDeleteAnnotation(vm):
 					// Operator opted out of the safety gate: drop the finalizer even
 					// though the provider VM may be left behind. Logged loudly.
 					logger.Error(err, "Provider VM delete failed but force-delete annotation is set; removing finalizer (the provider VM may be orphaned)",
 						"id", vm.Status.ID, "annotation", forceDeleteAnnotation)
 					metrics.RecordError(errReasonProviderDelete, metrics.ComponentManager)
+				case contracts.IsUnreachable(err):
+					// The provider may be gone for good. Keep retrying, and
+					// once the deletion counts as blocked let an opted-in VM go.
+					logger.Error(err, "Provider unreachable while deleting VM; retaining finalizer", "id", vm.Status.ID)
+					metrics.RecordError(errReasonProviderDelete, metrics.ComponentManager)
+					if !r.abandonUnreachableDeletion(ctx, vm, err) {
+						return ctrl.Result{RequeueAfter: r.requeue().DeleteRetry.Duration}, nil
+					}
 				default:
 					// Real failure (e.g. PVE "VM is running - destroy failed"). Do
 					// NOT remove the finalizer — that would orphan the hypervisor VM.
@@ -441,15 +730,25 @@ func (r *VirtualMachineReconciler) handleDeletion(ctx context.Context, vm *infra
 					// set the force-delete annotation to break out if needed.
 					logger.Error(err, "Failed to delete VM from provider; retaining finalizer and retrying",
 						"id", vm.Status.ID)
+					// The provider answered, so any run of unreachable
+					// attempts is over.
+					if cerr := k8sutil.ClearUnreachableDeletion(ctx, r.Client, vm); cerr != nil {
+						logger.Error(cerr, "Failed to reset blocked deletion tracking")
+					}
 					metrics.RecordError(errReasonProviderDelete, metrics.ComponentManager)
-					return ctrl.Result{RequeueAfter: vmDeleteRetryInterval}, nil
+					r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonVMDeleteFailed,
+						fmt.Sprintf("Failed to delete provider VM %s: %v", vm.Status.ID, err))
+					return ctrl.Result{RequeueAfter: r.requeue().DeleteRetry.Duration}, nil
+				}
+				if vr, ok := r.RemoteResolver.(VMProviderResolver); ok {
+					vr.ReleaseVM(provider, vm.UID)
 				}
 			}
 		}
 	}
 
 	// Remove finalizer
-	if err := k8s.RemoveFinalizer(ctx, r.Client, vm, infravirtrigaudiov1beta1.VirtualMachineFinalizer); err != nil {
+	if err := k8sutil.RemoveFinalizer(ctx, r.Client, vm, infravirtrigaudiov1beta1.VirtualMachineFinalizer); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
 		metrics.RecordError(errReasonRemoveFinalizer, metrics.ComponentManager)
 		return ctrl.Result{}, err
@@ -459,6 +758,29 @@ func (r *VirtualMachineReconciler) handleDeletion(ctx context.Context, vm *infra
 	return ctrl.Result{}, nil
 }
 
+// abandonUnreachableDeletion counts a deletion attempt that could not reach
+// the VM's provider and reports whether the finalizer may be removed anyway.
+// When it may not, the VM's status is written.
+func (r *VirtualMachineReconciler) abandonUnreachableDeletion(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, cause error) bool {
+	abandon, err := handleUnreachableDeletion(ctx, r.Client, r.Config.Get().Deletion, unreachableDeletion{
+		obj:        vm,
+		conditions: &vm.Status.Conditions,
+		optedIn:    k8sutil.AbandonsOnProviderLoss(vm),
+		cause:      cause,
+		retry:      r.requeue().DeleteRetry.Duration,
+		emit: func(eventType, reason, message string) {
+			r.recordEvent(ctx, vm, eventType, reason, message)
+		},
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to track blocked deletion")
+	}
+	if !abandon {
+		r.updateStatus(ctx, vm)
+	}
+	return abandon
+}
+
 // getDependencies fetches all required dependencies for the VM
 func (r *VirtualMachineReconciler) getDependencies(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (
 	*infravirtrigaudiov1beta1.Provider,
@@ -469,60 +791,40 @@ func (r *VirtualMachineReconciler) getDependencies(ctx context.Context, vm *infr
 ) {
 	// Get Provider
 	provider := &infravirtrigaudiov1beta1.Provider{}
-	providerKey := types.NamespacedName{
-		Name:      vm.Spec.ProviderRef.Name,
-		Namespace: vm.Namespace,
-	}
-	if vm.Spec.ProviderRef.Namespace != "" {
-		providerKey.Namespace = vm.Spec.ProviderRef.Namespace
-	}
-	if err := r.Get(ctx, providerKey, provider); err != nil {
+	if err := k8sutil.GetRef(ctx, r.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
 		if errors.IsNotFound(err) {
 			// Provider doesn't exist yet - preserve the NotFound error for proper handling upstream
-			return nil, nil, nil, nil, fmt.Errorf("provider %s not found (namespace: %s): %w", vm.Spec.ProviderRef.Name, providerKey.Namespace, err)
+			return nil, nil, nil, nil, fmt.Errorf("provider %s not found (namespace: %s): %w",
+				vm.Spec.ProviderRef.Name, k8sutil.RefNamespace(vm.Spec.ProviderRef, vm.Namespace), err)
 		}
 		return nil, nil, nil, nil, fmt.Errorf("failed to get provider %s: %w", vm.Spec.ProviderRef.Name, err)
 	}
 
 	// Get VMClass
 	vmClass := &infravirtrigaudiov1beta1.VMClass{}
-	classKey := types.NamespacedName{
-		Name:      vm.Spec.ClassRef.Name,
-		Namespace: vm.Namespace,
-	}
-	if vm.Spec.ClassRef.Namespace != "" {
-		classKey.Namespace = vm.Spec.ClassRef.Namespace
-	}
-	if err := r.Get(ctx, classKey, vmClass); err != nil {
+	if err := k8sutil.GetRef(ctx, r.Client, "spec.classRef", vm.Spec.ClassRef, vm.Namespace, vmClass); err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("failed to get vmclass %s: %w", vm.Spec.ClassRef.Name, err)
 	}
+	if err := vmclass.ResolveInto(ctx, r.Client, vmClass); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to resolve vmclass %s: %w", vm.Spec.ClassRef.Name, err)
+	}
 
 	// Get VMImage (only if ImageRef is specified, not ImportedDisk)
 	var vmImage *infravirtrigaudiov1beta1.VMImage
 	if vm.Spec.ImageRef != nil {
 		vmImage = &infravirtrigaudiov1beta1.VMImage{}
-		imageKey := types.NamespacedName{
-			Name:      vm.Spec.ImageRef.Name,
-			Namespace: vm.Namespace,
-		}
-		if vm.Spec.ImageRef.Namespace != "" {
-			imageKey.Namespace = vm.Spec.ImageRef.Namespace
-		}
-		if err := r.Get(ctx, imageKey, vmImage); err != nil {
+		if err := k8sutil.GetRef(ctx, r.Client, "spec.imageRef", *vm.Spec.ImageRef, vm.Namespace, vmImage); err != nil {
 			return nil, nil, nil, nil, fmt.Errorf("failed to get vmimage %s: %w", vm.Spec.ImageRef.Name, err)
 		}
 	}
 
 	// Get VMNetworkAttachments (only for networks that have networkRef specified)
 	var networks []*infravirtrigaudiov1beta1.VMNetworkAttachment
-	for _, netRef := range vm.Spec.Networks {
+	for i, netRef := range vm.Spec.Networks {
 		if netRef.NetworkRef != nil {
 			network := &infravirtrigaudiov1beta1.VMNetworkAttachment{}
-			netKey := types.NamespacedName{
-				Name:      netRef.NetworkRef.Name,
-				Namespace: vm.Namespace,
-			}
-			if err := r.Get(ctx, netKey, network); err != nil {
+			field := fmt.Sprintf("spec.networks[%d].networkRef", i)
+			if err := k8sutil.GetRef(ctx, r.Client, field, *netRef.NetworkRef, vm.Namespace, network); err != nil {
 				return nil, nil, nil, nil, fmt.Errorf("failed to get vmnetworkattachment %s: %w", netRef.NetworkRef.Name, err)
 			}
 			networks = append(networks, network)
@@ -540,12 +842,13 @@ func (r *VirtualMachineReconciler) createVM(
 	ctx context.Context,
 	vm *infravirtrigaudiov1beta1.VirtualMachine,
 	provider contracts.Provider,
-	providerName string,
+	k8sProvider *infravirtrigaudiov1beta1.Provider,
 	vmClass *infravirtrigaudiov1beta1.VMClass,
 	vmImage *infravirtrigaudiov1beta1.VMImage,
 	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
+	providerName, providerType := k8sProvider.Name, k8sProvider.Spec.Type
 
 	// Validate that either ImageRef or ImportedDisk is specified
 	if vm.Spec.ImageRef == nil && vm.Spec.ImportedDisk == nil {
@@ -565,44 +868,146 @@ func (r *VirtualMachineReconciler) createVM(
 		return ctrl.Result{}, err
 	}
 
+	if r.gateGuestCustomization(ctx, vm, provider) {
+		return ctrl.Result{RequeueAfter: r.requeue().ProviderNotReady.Duration}, nil
+	}
+	if r.gateProtocolFeatures(ctx, vm, provider) {
+		return ctrl.Result{RequeueAfter: r.requeue().ProviderNotReady.Duration}, nil
+	}
+
 	// Build create request
 	req, err := r.buildCreateRequest(ctx, vm, providerName, vmClass, vmImage, networks)
 	if err != nil {
 		logger.Error(err, "Failed to build create request")
 		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to build create request: %v", err))
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+	}
+
+	// The provider's default tags and labels apply to the VMs it creates.
+	req.Tags = vmtags.ForVM(k8sProvider, vm)
+	if err := vmtags.Validate(providerType, req.Tags); err != nil {
+		message := fmt.Sprintf("Tags cannot be applied on provider %s: %v", providerName, err)
+		logger.Error(err, "Invalid VM tags")
+		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonValidationError, message)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().ProviderNotReady.Duration}, nil
+	}
+
+	if vm.Spec.Placement != nil && vm.Spec.Placement.AntiAffinity != nil {
+		host, hold := r.placeVM(ctx, vm, provider, providerType)
+		if hold {
+			return ctrl.Result{RequeueAfter: antiAffinityRecheckInterval}, nil
+		}
+		req.Placement.Host = host
 	}
 
 	// Create VM
+	providerKey := client.ObjectKeyFromObject(k8sProvider)
+	if wait := providerBackpressure.remaining(providerKey); wait > 0 {
+		return r.deferCreate(ctx, vm, providerName, wait, nil), nil
+	}
 	resp, err := provider.Create(ctx, req)
+	if r.vmProviderConflict(ctx, vm, "Create", err) {
+		return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
+	}
+	if delay, busy := contracts.IsBusy(err); busy {
+		providerBackpressure.hold(providerKey, delay)
+		return r.deferCreate(ctx, vm, providerName, providerBackpressure.remaining(providerKey), err), nil
+	}
 	if err != nil {
 		logger.Error(err, "Failed to create VM")
 		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to create VM: %v", err))
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonVMCreateFailed, fmt.Sprintf("Failed to create VM: %v", err))
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+	}
+
+	vmConflicts.clear(client.ObjectKeyFromObject(vm))
+	if len(resp.ProviderRaw) > 0 {
+		logger.V(1).Info("Provider created VM", "id", resp.ID, "details", resp.ProviderRaw)
 	}
 
 	// Update status
 	vm.Status.ID = resp.ID
+	vm.Status.Tags = req.Tags
+	if req.Placement != nil {
+		vm.Status.Host = req.Placement.Host
+	}
+	vm.Status.ObservedGeneration = vm.Generation
 	// Initialize current resources to track for future resize detection
-	r.updateCurrentResources(vm, vmClass)
+	r.recordAppliedSpec(vm, vmClass, nil)
+	recordAppliedSecurityGroups(vm, networks)
 
+	var task *infravirtrigaudiov1beta1.TaskRecord
 	if resp.TaskRef != "" {
+		task = startedTask(k8sProvider, provider, "Create", resp.TaskRef)
 		vm.Status.LastTaskRef = resp.TaskRef
+		vm.Status.LastTask = task
 		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonCreating, "VM creation initiated")
 	} else {
 		k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "VM created")
 	}
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonVMCreated, fmt.Sprintf("Created VM %s on provider %s%s", resp.ID, providerName, taskNote(task)))
 
-	r.updateStatus(ctx, vm)
-	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	if err := r.persistCreatedStatus(ctx, vm); err != nil {
+		// The next reconcile repeats Create with the same idempotency key,
+		// which returns this VM rather than creating another.
+		logger.Error(err, "Failed to record created VM ID; Create will be repeated", "id", resp.ID)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+}
+
+// recordTaskFailed records in status.lastTask that the task in
+// status.lastTaskRef failed with err, and raises a TaskFailed Event naming
+// the task the first time.
+func (r *VirtualMachineReconciler) recordTaskFailed(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, err error) {
+	task := vm.Status.LastTask
+	if task == nil || task.ID != vm.Status.LastTaskRef {
+		task = &infravirtrigaudiov1beta1.TaskRecord{ID: vm.Status.LastTaskRef}
+		vm.Status.LastTask = task
+	}
+	if task.Error != "" {
+		return
+	}
+	task.Error = err.Error()
+	operation := cmp.Or(task.Operation, "Task")
+	r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonTaskFailed,
+		fmt.Sprintf("%s failed: %v%s", operation, err, taskNote(task)))
+}
+
+// persistCreatedStatus writes the status createVM built, re-reading the VM
+// and retrying on a conflict instead of dropping the write as updateStatus
+// would: until status.id is stored, every reconcile calls Create again.
+// createVM is the only writer of a VM that has no ID yet, so the status it
+// built replaces the one re-read.
+func (r *VirtualMachineReconciler) persistCreatedStatus(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) error {
+	desired := vm.Status.DeepCopy()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Update(ctx, vm)
+		if !errors.IsConflict(err) {
+			return err
+		}
+		latest := &infravirtrigaudiov1beta1.VirtualMachine{}
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(vm), latest); getErr != nil {
+			return getErr
+		}
+		latest.Status = *desired.DeepCopy()
+		*vm = *latest
+		return err
+	})
+	if err == nil {
+		r.statusWritten(ctx, vm)
+	}
+	return err
 }
 
 // adjustPowerState adjusts the VM power state
 func (r *VirtualMachineReconciler) adjustPowerState(
 	ctx context.Context,
 	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	k8sProvider *infravirtrigaudiov1beta1.Provider,
 	provider contracts.Provider,
 	desiredState string,
 ) (ctrl.Result, error) {
@@ -618,24 +1023,34 @@ func (r *VirtualMachineReconciler) adjustPowerState(
 		powerOp = contracts.PowerOpShutdownGraceful
 	default:
 		logger.Error(nil, "Unsupported power state", "state", desiredState)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
 
 	taskRef, err := provider.Power(ctx, vm.Status.ID, powerOp)
+	if r.vmProviderConflict(ctx, vm, "Power "+desiredState, err) {
+		return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
+	}
 	if err != nil {
 		logger.Error(err, "Failed to adjust power state")
 		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to adjust power state: %v", err))
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonPowerChangeFailed, fmt.Sprintf("Failed to power %s: %v", desiredState, err))
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
+	vmConflicts.clear(client.ObjectKeyFromObject(vm))
 
+	vm.Status.ObservedGeneration = vm.Generation
+	var task *infravirtrigaudiov1beta1.TaskRecord
 	if taskRef != "" {
+		task = startedTask(k8sProvider, provider, "Power "+desiredState, taskRef)
 		vm.Status.LastTaskRef = taskRef
+		vm.Status.LastTask = task
 		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonUpdating, "Adjusting power state")
 	}
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonPowerChangeRequested, fmt.Sprintf("Requested power state %s%s", desiredState, taskNote(task)))
 
 	r.updateStatus(ctx, vm)
-	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 }
 
 // buildCreateRequest builds a provider create request from VM spec.
@@ -650,6 +1065,12 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 ) (contracts.CreateRequest, error) {
 	log := ctrl.Log.WithName("buildCreateRequest")
 
+	// Refuse a class the VMClass controller would mark invalid rather than
+	// sending providers a truncated or misaligned size.
+	if err := validateVMClass(&vmClass.Spec); err != nil {
+		return contracts.CreateRequest{}, fmt.Errorf("VMClass %s is invalid: %w", vmClass.Name, err)
+	}
+
 	// Check if using imported disk or template
 	usingImportedDisk := vm.Spec.ImportedDisk != nil
 
@@ -671,8 +1092,9 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 	// Convert VMClass
 	class := contracts.VMClass{
 		CPU:              vmClass.Spec.CPU,
-		MemoryMiB:        int32(vmClass.Spec.Memory.Value() / (1024 * 1024)), // Convert bytes to MiB
+		MemoryMiB:        int32(usage.ClassMemoryMiB(&vmClass.Spec)), // #nosec G115 -- bounded by validateVMClass
 		Firmware:         string(vmClass.Spec.Firmware),
+		MachineType:      vmClass.Spec.MachineType,
 		GuestToolsPolicy: string(vmClass.Spec.GuestToolsPolicy),
 		ExtraConfig:      vmClass.Spec.ExtraConfig,
 	}
@@ -680,7 +1102,15 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 	if vmClass.Spec.DiskDefaults != nil {
 		class.DiskDefaults = &contracts.DiskDefaults{
 			Type:    string(vmClass.Spec.DiskDefaults.Type),
-			SizeGiB: int32(vmClass.Spec.DiskDefaults.Size.Value() / (1024 * 1024 * 1024)), // Convert bytes to GiB
+			SizeGiB: int32(usage.ClassDiskSizeGiB(&vmClass.Spec)), // #nosec G115 -- GiB count fits int32
+		}
+	}
+
+	if mb := vmClass.Spec.MemoryBalloon; mb != nil {
+		class.MemoryBalloon = &contracts.MemoryBalloon{
+			Enabled:    mb.Enabled,
+			MinimumMiB: mb.MinimumMiB,
+			Shares:     mb.Shares,
 		}
 	}
 
@@ -693,6 +1123,7 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 			VirtualizationBasedSecurity: vmClass.Spec.PerformanceProfile.VirtualizationBasedSecurity,
 			NestedVirtualization:        vmClass.Spec.PerformanceProfile.NestedVirtualization,
 			HyperThreadingPolicy:        vmClass.Spec.PerformanceProfile.HyperThreadingPolicy,
+			HugePages:                   vmClass.Spec.PerformanceProfile.HugePages,
 		}
 	}
 
@@ -814,6 +1245,13 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 			if vmImage.Spec.Source.VSphere.ChecksumType != "" {
 				image.ChecksumType = string(vmImage.Spec.Source.VSphere.ChecksumType)
 			}
+			if cl := vmImage.Spec.Source.VSphere.ContentLibrary; cl != nil {
+				image.ContentLibrary = &contracts.ContentLibraryItem{
+					Library: cl.Library,
+					Item:    cl.Item,
+					Version: cl.Version,
+				}
+			}
 		}
 
 		if vmImage.Spec.Source.Libvirt != nil {
@@ -830,6 +1268,7 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 			if vmImage.Spec.Source.Libvirt.ChecksumType != "" {
 				image.ChecksumType = string(vmImage.Spec.Source.Libvirt.ChecksumType)
 			}
+			image.LinkedClone = vmImage.Spec.Source.Libvirt.LinkedClone
 			log.V(1).Info("Set image from Libvirt source",
 				"image.Path", image.Path,
 				"image.URL", image.URL,
@@ -853,6 +1292,9 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 				log.V(1).Info("Set TemplateName from TemplateName",
 					"image.TemplateName", image.TemplateName)
 			}
+			if fc := vmImage.Spec.Source.Proxmox.FullClone; fc != nil && !*fc {
+				image.LinkedClone = true
+			}
 		} else {
 			log.V(1).Info("Proxmox image source is nil")
 		}
@@ -885,6 +1327,10 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 			Gateway:  netRef.Gateway,
 			DNS:      netRef.DNS,
 		}
+		if netRef.IPv6 != nil {
+			attachment.StaticIPv6 = netRef.IPv6.Address
+			attachment.GatewayIPv6 = netRef.IPv6.Gateway
+		}
 
 		// Only look up VMNetworkAttachment if networkRef is specified
 		if netRef.NetworkRef != nil && i < len(networks) && networks[i] != nil {
@@ -911,10 +1357,15 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 
 			if net.Spec.Network.Proxmox != nil {
 				attachment.Bridge = net.Spec.Network.Proxmox.Bridge
+				attachment.VNet = net.Spec.Network.Proxmox.VNet
 				attachment.Model = net.Spec.Network.Proxmox.Model
 				if net.Spec.Network.Proxmox.VLANTag != nil {
 					attachment.VLAN = *net.Spec.Network.Proxmox.VLANTag
 				}
+				if net.Spec.Network.Proxmox.Firewall != nil {
+					attachment.Firewall = *net.Spec.Network.Proxmox.Firewall
+				}
+				attachment.SecurityGroups = net.Spec.Network.Proxmox.SecurityGroups
 			}
 		} else if netRef.NetworkRef == nil {
 			log.V(1).Info("NetworkRef not specified, using template's pre-configured NIC with guestinfo for IP config",
@@ -945,24 +1396,37 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 	}
 
 	// Convert UserData — resolve inline and/or SecretRef, merging if both present
-	var userData *contracts.UserData
-	if vm.Spec.UserData != nil && vm.Spec.UserData.CloudInit != nil {
-		cloudInitData, err := r.resolveCloudInitUserData(ctx, vm.Namespace, vm.Spec.UserData.CloudInit)
-		if err != nil {
-			return contracts.CreateRequest{}, fmt.Errorf("resolving cloud-init user data: %w", err)
-		}
-		if cloudInitData != "" {
-			userData = &contracts.UserData{
-				Type:          "cloud-init",
-				CloudInitData: cloudInitData,
+	var cloudInitData, networkConfig, vendorData string
+	if ud := vm.Spec.UserData; ud != nil {
+		var err error
+		if ud.CloudInit != nil {
+			if cloudInitData, err = r.resolveCloudInitUserData(ctx, vm.Namespace, ud.CloudInit); err != nil {
+				return contracts.CreateRequest{}, fmt.Errorf("resolving cloud-init user data: %w", err)
 			}
 		}
+		if networkConfig, err = r.resolveCloudInitDocument(ctx, vm.Namespace, "network-config", ud.NetworkConfig, networkConfigSecretKeys); err != nil {
+			return contracts.CreateRequest{}, fmt.Errorf("resolving cloud-init network-config: %w", err)
+		}
+		if vendorData, err = r.resolveCloudInitDocument(ctx, vm.Namespace, "vendor-data", ud.VendorData, vendorDataSecretKeys); err != nil {
+			return contracts.CreateRequest{}, fmt.Errorf("resolving cloud-init vendor-data: %w", err)
+		}
+	}
+	var userData *contracts.UserData
+	if cloudInitData != "" || networkConfig != "" || vendorData != "" {
+		userData = &contracts.UserData{
+			Type:          "cloud-init",
+			CloudInitData: cloudInitData,
+			NetworkConfig: networkConfig,
+			VendorData:    vendorData,
+		}
 	}
 
 	// Convert MetaData — resolve inline and/or SecretRef, merging if both present
 	var metaData *contracts.MetaData
+	var metaDataStr string
 	if vm.Spec.MetaData != nil && vm.Spec.MetaData.CloudInit != nil {
-		metaDataStr, err := r.resolveCloudInitMetaData(ctx, vm.Namespace, vm.Spec.MetaData.CloudInit)
+		var err error
+		metaDataStr, err = r.resolveCloudInitMetaData(ctx, vm.Namespace, vm.Spec.MetaData.CloudInit)
 		if err != nil {
 			return contracts.CreateRequest{}, fmt.Errorf("resolving cloud-init metadata: %w", err)
 		}
@@ -973,6 +1437,20 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 		}
 	}
 
+	if err := checkCloudInitSizes(map[string]string{
+		"user-data":      cloudInitData,
+		"meta-data":      metaDataStr,
+		"network-config": networkConfig,
+		"vendor-data":    vendorData,
+	}); err != nil {
+		return contracts.CreateRequest{}, fmt.Errorf("cloud-init: %w", err)
+	}
+
+	guestCustomization, err := r.resolveGuestCustomization(ctx, vm)
+	if err != nil {
+		return contracts.CreateRequest{}, err
+	}
+
 	// Convert Placement
 	var placement *contracts.Placement
 	if vm.Spec.Placement != nil {
@@ -981,12 +1459,18 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 			"cluster", vm.Spec.Placement.Cluster,
 			"datastore", vm.Spec.Placement.Datastore,
 			"storagePod", vm.Spec.Placement.StoragePod,
-			"folder", vm.Spec.Placement.Folder)
+			"folder", vm.Spec.Placement.Folder,
+			"host", vm.Spec.Placement.Host)
 		placement = &contracts.Placement{
 			Datastore:  vm.Spec.Placement.Datastore,
 			StoragePod: vm.Spec.Placement.StoragePod,
 			Cluster:    vm.Spec.Placement.Cluster,
 			Folder:     vm.Spec.Placement.Folder,
+			Host:       vm.Spec.Placement.Host,
+			Pool:       vm.Spec.Placement.ResourcePool,
+		}
+		if ha := vm.Spec.Placement.HA; ha != nil {
+			placement.HA = &contracts.HAPlacement{Group: ha.Group, State: ha.State}
 		}
 	} else {
 		log.Info("No placement specified in VM spec", "vm", vm.Name)
@@ -1002,6 +1486,11 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 		MetaData:  metaData,
 		Placement: placement,
 		Tags:      vm.Spec.Tags,
+
+		GuestCustomization: guestCustomization,
+		// The UID outlives any number of Create retries but not the
+		// object, so a VM recreated under the same name gets a new VM.
+		IdempotencyKey: string(vm.UID),
 	}, nil
 }
 
@@ -1023,7 +1512,8 @@ func (r *VirtualMachineReconciler) buildCreateRequest(
 //     libvirt Create resolves /path → CreateVolumeFromImageFile instead of
 //     re-downloading the URL.
 //   - vSphere: set image.TemplateName to the prepared template name and clear
-//     image.URL (the OVA URL), so Create clones the prepared template.
+//     image.URL (the OVA URL), so Create clones the prepared template. A
+//     content library source is kept: its item is deployed from the library.
 //   - Proxmox: set image.TemplateName to the prepared template name/VMID.
 func overrideImageWithPreparedLocation(
 	image *contracts.VMImage,
@@ -1035,6 +1525,7 @@ func overrideImageWithPreparedLocation(
 		return false, "not prepared/available on provider"
 	}
 
+	vs := vmImage.Spec.Source.VSphere
 	switch {
 	case vmImage.Spec.Source.Libvirt != nil:
 		if ps.Path == "" {
@@ -1043,7 +1534,11 @@ func overrideImageWithPreparedLocation(
 		image.Path = ps.Path
 		image.URL = "" // prefer the local prepared template over re-downloading
 		return true, fmt.Sprintf("libvirt path=%s", ps.Path)
-	case vmImage.Spec.Source.VSphere != nil:
+	case vs != nil && vs.TemplateName == "" && vs.ContentLibrary != nil:
+		// The prepared image is the synced library item itself, which Create
+		// resolves by library and item name.
+		return false, "content library items are deployed by name"
+	case vs != nil:
 		if ps.ID == "" {
 			return false, "prepared but no template id recorded"
 		}
@@ -1061,41 +1556,17 @@ func overrideImageWithPreparedLocation(
 	}
 }
 
-// updateStatus updates the VM status
+// updateStatus writes the VM status if it changed, logging a failure
 func (r *VirtualMachineReconciler) updateStatus(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) {
-	if err := r.Status().Update(ctx, vm); err != nil {
+	if err := r.writeStatus(ctx, vm); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to update VirtualMachine status")
 	}
 }
 
-// needsReconfigure checks if the VM needs to be reconfigured based on VMClass changes
+// needsReconfigure reports whether the desired spec differs from what was
+// last applied to the VM.
 func (r *VirtualMachineReconciler) needsReconfigure(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass) bool {
-	// Get desired resources from VMClass (with possible overrides from VM spec)
-	desiredCPU := vmClass.Spec.CPU
-	desiredMemoryMiB := vmClass.Spec.Memory.Value() / (1024 * 1024)
-
-	// Check for VM-level resource overrides
-	if vm.Spec.Resources != nil {
-		if vm.Spec.Resources.CPU != nil {
-			desiredCPU = *vm.Spec.Resources.CPU
-		}
-		if vm.Spec.Resources.MemoryMiB != nil {
-			desiredMemoryMiB = *vm.Spec.Resources.MemoryMiB
-		}
-	}
-
-	// Get current resources from status
-	currentCPU := r.getCurrentCPU(vm)
-	currentMemoryMiB := r.getCurrentMemoryMiB(vm)
-
-	// If no current resources tracked, assume first reconcile after creation
-	// and update status without triggering reconfigure
-	if currentCPU == 0 && currentMemoryMiB == 0 {
-		return false
-	}
-
-	// Check if CPU or memory changed
-	return currentCPU != desiredCPU || currentMemoryMiB != desiredMemoryMiB
+	return !desiredChangeSet(vm, vmClass).IsEmpty()
 }
 
 // getCurrentCPU returns the current CPU count from VM status
@@ -1123,106 +1594,92 @@ func (r *VirtualMachineReconciler) reconfigureVM(
 	vmClass *infravirtrigaudiov1beta1.VMClass,
 	vmImage *infravirtrigaudiov1beta1.VMImage,
 	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
+	changes contracts.ChangeSet,
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Build the desired configuration
+	// Build the desired configuration, still sent for providers that
+	// predate change sets
 	req, err := r.buildCreateRequest(ctx, vm, providerName, vmClass, vmImage, networks)
 	if err != nil {
 		logger.Error(err, "Failed to build create request")
 		return ctrl.Result{}, err
 	}
+	changes = withAttachments(changes, req.Networks)
 
 	// Call provider reconfigure
-	taskRef, err := provider.Reconfigure(ctx, vm.Status.ID, req)
+	result, err := provider.Reconfigure(ctx, vm.Status.ID, req, changes)
+	if r.vmProviderConflict(ctx, vm, "Reconfigure", err) {
+		return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
+	}
 	if err != nil {
 		logger.Error(err, "Failed to reconfigure VM")
 		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to reconfigure VM: %v", err))
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonReconfigureFailed, fmt.Sprintf("Failed to reconfigure VM: %v", err))
 		r.updateStatus(ctx, vm)
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 	}
+	vmConflicts.clear(client.ObjectKeyFromObject(vm))
 
 	// Update status with reconfiguration info
+	vm.Status.ObservedGeneration = vm.Generation
 	vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseReconfiguring
 	now := metav1.Now()
 	vm.Status.LastReconfigureTime = &now
 
-	if taskRef != "" {
-		vm.Status.ReconfigureTaskRef = taskRef
+	previousPending := vm.Status.PendingPowerCycle
+	if result.TaskRef != "" {
+		vm.Status.ReconfigureTaskRef = result.TaskRef
+		vm.Status.PendingPowerCycle = result.PowerCycleRequired
 		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonUpdating, "VM reconfiguration in progress")
 	} else {
-		// Reconfigure completed synchronously, update current resources
-		r.updateCurrentResources(vm, vmClass)
+		// Reconfigure completed synchronously, record what was applied
+		r.recordAppliedSpec(vm, vmClass, result.PowerCycleRequired)
+		recordAppliedSecurityGroups(vm, networks)
 		vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseRunning
 		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "VM reconfigured successfully")
 		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionTrue, k8s.ReasonReconcileSuccess, "VM is ready")
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonReconfigured, "Applied "+strings.Join(changes.Keys(), ", "))
+		r.reportPowerCycleRequired(ctx, vm, previousPending)
 	}
 
 	r.updateStatus(ctx, vm)
-	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
 }
 
-// updateCurrentResources updates the VM status with current resource allocation
-func (r *VirtualMachineReconciler) updateCurrentResources(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass) {
-	cpu := vmClass.Spec.CPU
-	memoryMiB := vmClass.Spec.Memory.Value() / (1024 * 1024)
-
-	// Check for VM-level resource overrides
-	if vm.Spec.Resources != nil {
-		if vm.Spec.Resources.CPU != nil {
-			cpu = *vm.Spec.Resources.CPU
-		}
-		if vm.Spec.Resources.MemoryMiB != nil {
-			memoryMiB = *vm.Spec.Resources.MemoryMiB
-		}
-	}
-
-	if vm.Status.CurrentResources == nil {
-		vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{}
-	}
-	vm.Status.CurrentResources.CPU = &cpu
-	vm.Status.CurrentResources.MemoryMiB = &memoryMiB
-}
-
-func (r *VirtualMachineReconciler) getRequeueInterval(vm *infravirtrigaudiov1beta1.VirtualMachine, desc contracts.DescribeResponse) time.Duration {
-	// Polling intervals for various states
-	const (
-		fastPoll     = 10 * time.Second // For transitional states
-		waitingForIP = 10 * time.Second // Waiting for IP address (VMware Tools)
-		normalPoll   = 2 * time.Minute  // For stable running VMs
-		slowPoll     = 5 * time.Minute  // For stable powered-off VMs
-	)
+// getRequeueInterval is how long a VM on provider waits before it is
+// described again, by the state desc reports: the provider's reconcile
+// interval once it has settled, its status refresh while it changes.
+func (r *VirtualMachineReconciler) getRequeueInterval(provider *infravirtrigaudiov1beta1.Provider, desc contracts.DescribeResponse) time.Duration {
+	requeue := providerRequeue(r.requeue(), provider)
 
 	// Check if VM has no IP addresses yet (waiting for DHCP/network or VMware Tools)
-	if desc.PowerState == "poweredOn" && len(desc.IPs) == 0 {
-		return waitingForIP // Poll less frequently while waiting for IP
+	if desc.PowerState == string(contracts.PowerStateOn) && len(desc.IPs) == 0 {
+		return requeue.WaitingForIP.Duration
 	}
 
 	// Check VM power state for different polling frequencies
-	switch desc.PowerState {
-	case "poweredOn":
-		// VM is running and has IP - normal monitoring frequency
-		return normalPoll
-	case "poweredOff":
+	switch contracts.PowerState(desc.PowerState) {
+	case contracts.PowerStateOn, contracts.PowerStateSuspended:
+		// Running with addresses, or suspended - normal monitoring frequency
+		return requeue.Running.Duration
+	case contracts.PowerStateOff:
 		// VM is off - slower polling
-		return slowPoll
-	case "suspended":
-		// VM is suspended - normal polling
-		return normalPoll
+		return requeue.PoweredOff.Duration
 	default:
 		// Unknown or transitional state - fast polling
-		return fastPoll
+		return requeue.Transitional.Duration
 	}
 }
 
-// getProviderInstance resolves a provider to a remote implementation
-func (r *VirtualMachineReconciler) getProviderInstance(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (contracts.Provider, error) {
+// getProviderInstance resolves a provider to a remote implementation for vm
+func (r *VirtualMachineReconciler) getProviderInstance(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, vm *infravirtrigaudiov1beta1.VirtualMachine) (contracts.Provider, error) {
 	// All providers are now remote
 	if r.RemoteResolver == nil {
 		return nil, fmt.Errorf("no remote resolver available")
 	}
 
-	return r.RemoteResolver.GetProvider(ctx, provider) //nolint:wrapcheck
+	return resolveForVM(ctx, r.RemoteResolver, provider, vm)
 }
 
 // recordIPDiscoveryIfFirstSeen emits a single
@@ -1278,8 +1735,20 @@ func vmIsAdopted(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VirtualMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexFields(mgr, &infravirtrigaudiov1beta1.VirtualMachine{}, map[string]client.IndexerFunc{
+		vmClassRefIndex:    indexVMClassRef,
+		vmImageRefIndex:    indexVMImageRef,
+		vmProviderRefIndex: indexVMProviderRef,
+	}); err != nil {
+		return err
+	}
+	r.queueTiers = newTieredQueue(clock.RealClock{})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.vmsForNamespace)).
+		Watches(&infravirtrigaudiov1beta1.VMClass{}, enqueueDependents(r.vmsForClass)).
+		Watches(&infravirtrigaudiov1beta1.VMImage{}, enqueueDependents(r.vmsForImage)).
+		Watches(&infravirtrigaudiov1beta1.Provider{}, enqueueDependents(r.vmsForProvider)).
 		WithEventFilter(predicate.Funcs{
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				// Only reconcile if spec changed (ignore status-only updates)
@@ -1287,8 +1756,69 @@ func (r *VirtualMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				oldVM, ok1 := e.ObjectOld.(*infravirtrigaudiov1beta1.VirtualMachine)
 				newVM, ok2 := e.ObjectNew.(*infravirtrigaudiov1beta1.VirtualMachine)
 				if ok1 && ok2 {
-					// Reconcile if generation changed (spec changed) or if being deleted
-					return oldVM.Generation != newVM.Generation || !newVM.DeletionTimestamp.IsZero()
+					// Reconcile if generation changed (spec changed), if being
+					// deleted, if a console-log capture or an identity
+					// acceptance was just requested, or if the VM was paused,
+					// resumed or put in or out of dry-run (annotations do not
+					// bump the generation). These are all user changes, so
+					// they skip the resync backlog.
+					changed := oldVM.Generation != newVM.Generation || !newVM.DeletionTimestamp.IsZero() ||
+						(consoleLogRequested(newVM) && !consoleLogRequested(oldVM)) ||
+						(identityAcceptRequested(newVM) && !identityAcceptRequested(oldVM)) ||
+						isPaused(oldVM) != isPaused(newVM) || isDryRun(oldVM) != isDryRun(newVM) ||
+						isMaintenanceExempt(oldVM) != isMaintenanceExempt(newVM)
+					if changed {
+						r.queueTiers.Expedite(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(newVM)})
+					}
+					return changed
+				}
+				oldNS, ok1 := e.ObjectOld.(*corev1.Namespace)
+				newNS, ok2 := e.ObjectNew.(*corev1.Namespace)
+				if ok1 && ok2 {
+					return isPaused(oldNS) != isPaused(newNS)
+				}
+				// A class's resolved spec changes with its own spec and
+				// with every class it inherits from.
+				oldClass, ok1 := e.ObjectOld.(*infravirtrigaudiov1beta1.VMClass)
+				newClass, ok2 := e.ObjectNew.(*infravirtrigaudiov1beta1.VMClass)
+				if ok1 && ok2 {
+					return !equality.Semantic.DeepEqual(oldClass.Status.ResolvedSpec, newClass.Status.ResolvedSpec)
+				}
+				// An image matters when it is edited or becomes (un)usable.
+				oldImage, ok1 := e.ObjectOld.(*infravirtrigaudiov1beta1.VMImage)
+				newImage, ok2 := e.ObjectNew.(*infravirtrigaudiov1beta1.VMImage)
+				if ok1 && ok2 {
+					return oldImage.Generation != newImage.Generation || oldImage.Status.Phase != newImage.Status.Phase
+				}
+				// A provider matters when its endpoint, credentials or
+				// other spec change, an endpoint migration starts or ends,
+				// or it enters or leaves maintenance, not on its health
+				// probes.
+				oldProvider, ok1 := e.ObjectOld.(*infravirtrigaudiov1beta1.Provider)
+				newProvider, ok2 := e.ObjectNew.(*infravirtrigaudiov1beta1.Provider)
+				if ok1 && ok2 {
+					_, wasMigrating := providerEndpointMigrating(oldProvider)
+					_, migrating := providerEndpointMigrating(newProvider)
+					return oldProvider.Generation != newProvider.Generation || wasMigrating != migrating ||
+						providerInMaintenanceCondition(oldProvider) != providerInMaintenanceCondition(newProvider)
+				}
+				return true
+			},
+			CreateFunc: func(e event.CreateEvent) bool {
+				// The namespace, class, image and provider watches only
+				// exist for later changes; their initial list must not
+				// enqueue every VM a second time.
+				switch obj := e.Object.(type) {
+				case *corev1.Namespace, *infravirtrigaudiov1beta1.VMClass,
+					*infravirtrigaudiov1beta1.VMImage, *infravirtrigaudiov1beta1.Provider:
+					return false
+				case *infravirtrigaudiov1beta1.VirtualMachine:
+					// A new VM, or one whose spec or deletion was not acted on
+					// before the manager restarted, is expedited; the
+					// initial list of every other VM is resync.
+					if obj.Generation != obj.Status.ObservedGeneration || !obj.DeletionTimestamp.IsZero() {
+						r.queueTiers.Expedite(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+					}
 				}
 				return true
 			},
@@ -1298,8 +1828,25 @@ func (r *VirtualMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			},
 		}).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 10, // Process up to 10 VMs in parallel
+			MaxConcurrentReconciles: r.Config.Get().Concurrency.VirtualMachine,
+			NewQueue:                r.queueTiers.NewQueue,
 		}).
 		Named("virtualmachine").
 		Complete(r)
 }
+
+// vmsForClass maps a VMClass to the VirtualMachines that use it, so that a
+// change to the class or to a class it inherits from is applied to them.
+func (r *VirtualMachineReconciler) vmsForClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infravirtrigaudiov1beta1.VirtualMachineList{}, vmClassRefIndex, obj)
+}
+
+// vmsForImage maps a VMImage to the VirtualMachines created from it.
+func (r *VirtualMachineReconciler) vmsForImage(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infravirtrigaudiov1beta1.VirtualMachineList{}, vmImageRefIndex, obj)
+}
+
+// vmsForProvider maps a Provider to the VirtualMachines it manages.
+func (r *VirtualMachineReconciler) vmsForProvider(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infravirtrigaudiov1beta1.VirtualMachineList{}, vmProviderRefIndex, obj)
+}
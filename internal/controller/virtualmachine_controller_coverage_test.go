@@ -18,17 +18,20 @@ package controller
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 )
 
@@ -44,6 +47,50 @@ func (s *stubResolver) GetProvider(_ context.Context, _ *infravirtrigaudiov1beta
 	return s.provider, s.err
 }
 
+func TestCreateVM_MergesProviderDefaultTags(t *testing.T) {
+	s := coverageTestScheme(t)
+	_, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Spec.ImageRef = &infravirtrigaudiov1beta1.ObjectRef{Name: "img"}
+	vm.Spec.Tags = []string{"web", "env=dev"}
+	r := newTestReconciler(s, nil, vm)
+	ctx := context.Background()
+	k8sProv := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-prov"},
+		Spec: infravirtrigaudiov1beta1.ProviderSpec{
+			Type:          infravirtrigaudiov1beta1.ProviderTypeLibvirt,
+			DefaultTags:   []string{"managed"},
+			DefaultLabels: map[string]string{"cost-center": "42", "env": "prod"},
+		},
+	}
+
+	prov := &createRecordingProvider{id: "vm-123"}
+	if _, err := r.createVM(ctx, vm, prov, k8sProv, class, nil, nil); err != nil {
+		t.Fatalf("createVM: %v", err)
+	}
+	want := []string{"managed", "cost-center=42", "web", "env=dev"}
+	if len(prov.requests) != 1 || !slices.Equal(prov.requests[0].Tags, want) {
+		t.Fatalf("expected one Create with tags %v, got %+v", want, prov.requests)
+	}
+	if !slices.Equal(vm.Status.Tags, want) {
+		t.Errorf("status.tags = %v, want %v", vm.Status.Tags, want)
+	}
+
+	// A Proxmox provider cannot hold key=value tags: nothing is created.
+	vm.Status.ID = ""
+	k8sProv.Spec.Type = infravirtrigaudiov1beta1.ProviderTypeProxmox
+	if _, err := r.createVM(ctx, vm, prov, k8sProv, class, nil, nil); err != nil {
+		t.Fatalf("createVM: %v", err)
+	}
+	if len(prov.requests) != 1 {
+		t.Fatalf("expected no Create with tags Proxmox cannot hold, got %d", len(prov.requests)-1)
+	}
+	cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionProvisioning)
+	if cond == nil || cond.Reason != k8s.ReasonValidationError {
+		t.Errorf("Provisioning condition = %+v, want reason %s", cond, k8s.ReasonValidationError)
+	}
+}
+
 // ─── helpers ──────────────────────────────────────────────────────────────────
 
 // coverageTestScheme builds a Scheme with both core k8s and virtrigaud types.
@@ -79,8 +126,8 @@ func testProvider(isTaskDone bool, isTaskErr error, reconfigureTaskRef string, r
 		IsTaskCompleteFn: func(_ context.Context, _ string) (bool, error) {
 			return isTaskDone, isTaskErr
 		},
-		ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
-			return reconfigureTaskRef, reconfigureErr
+		ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+			return contracts.ReconfigureResult{TaskRef: reconfigureTaskRef}, reconfigureErr
 		},
 	}
 }
@@ -546,9 +593,9 @@ func TestReconcileVM_NeedsReconfigure_TriggersReconfigureVM(t *testing.T) {
 	var reconfigureCalled bool
 	prov := &fakeDescribeProvider{
 		stubProvider: stubProvider{
-			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
+			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
 				reconfigureCalled = true
-				return "task-new", nil
+				return contracts.ReconfigureResult{TaskRef: "task-new"}, nil
 			},
 		},
 		DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
@@ -589,9 +636,9 @@ func TestReconcileVM_NeedsReconfigure_False_DoesNotReconfigure(t *testing.T) {
 	var reconfigureCalled bool
 	prov := &fakeDescribeProvider{
 		stubProvider: stubProvider{
-			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
+			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
 				reconfigureCalled = true
-				return "", nil
+				return contracts.ReconfigureResult{}, nil
 			},
 		},
 		DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
@@ -624,6 +671,62 @@ func TestReconcileVM_NeedsReconfigure_False_DoesNotReconfigure(t *testing.T) {
 	}
 }
 
+// ─── createVM ─────────────────────────────────────────────────────────────────
+
+// createRecordingProvider returns a fixed ID from Create and records the
+// requests it was sent.
+type createRecordingProvider struct {
+	stubProvider
+	id       string
+	requests []contracts.CreateRequest
+}
+
+func (p *createRecordingProvider) Create(_ context.Context, req contracts.CreateRequest) (contracts.CreateResponse, error) {
+	p.requests = append(p.requests, req)
+	return contracts.CreateResponse{ID: p.id}, nil
+}
+
+func TestCreateVM_SendsUIDAsIdempotencyKeyAndPersistsIDOnConflict(t *testing.T) {
+	s := coverageTestScheme(t)
+	_, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.UID = types.UID("3f6c1a9e-uid")
+	vm.Spec.ImageRef = &infravirtrigaudiov1beta1.ObjectRef{Name: "img"}
+	r := newTestReconciler(s, nil, vm)
+	ctx := context.Background()
+
+	stale := &infravirtrigaudiov1beta1.VirtualMachine{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vm), stale); err != nil {
+		t.Fatalf("get VM: %v", err)
+	}
+	// A concurrent write leaves the copy createVM works on out of date, so
+	// its first status write conflicts.
+	current := stale.DeepCopy()
+	current.Labels = map[string]string{"touched": "true"}
+	if err := r.Update(ctx, current); err != nil {
+		t.Fatalf("update VM: %v", err)
+	}
+
+	prov := &createRecordingProvider{id: "vm-123"}
+	if _, err := r.createVM(ctx, stale, prov, &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "test-prov"}}, class, nil, nil); err != nil {
+		t.Fatalf("createVM: %v", err)
+	}
+
+	if len(prov.requests) != 1 || prov.requests[0].IdempotencyKey != "3f6c1a9e-uid" {
+		t.Fatalf("expected one Create keyed by the VM UID, got %+v", prov.requests)
+	}
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vm), got); err != nil {
+		t.Fatalf("get VM: %v", err)
+	}
+	if got.Status.ID != "vm-123" {
+		t.Errorf("status.id = %q after a conflicting write, want vm-123", got.Status.ID)
+	}
+	if got.Labels["touched"] != "true" {
+		t.Error("persisting the status dropped the concurrent label change")
+	}
+}
+
 // ─── helpers ──────────────────────────────────────────────────────────────────
 
 func errTest(msg string) error {
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,12 +32,13 @@ import (
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
 )
 
 // stubProvider implements contracts.Provider for unit tests.
 // Only ReconfigureFn and IsTaskCompleteFn are configurable; all other methods are no-ops.
 type stubProvider struct {
-	ReconfigureFn    func(ctx context.Context, id string, desired contracts.CreateRequest) (string, error)
+	ReconfigureFn    func(ctx context.Context, id string, desired contracts.CreateRequest, changes contracts.ChangeSet) (contracts.ReconfigureResult, error)
 	IsTaskCompleteFn func(ctx context.Context, taskRef string) (bool, error)
 }
 
@@ -48,11 +50,11 @@ func (s *stubProvider) Delete(_ context.Context, _ string) (string, error) { ret
 func (s *stubProvider) Power(_ context.Context, _ string, _ contracts.PowerOp) (string, error) {
 	return "", nil
 }
-func (s *stubProvider) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest) (string, error) {
+func (s *stubProvider) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest, changes contracts.ChangeSet) (contracts.ReconfigureResult, error) {
 	if s.ReconfigureFn != nil {
-		return s.ReconfigureFn(ctx, id, desired)
+		return s.ReconfigureFn(ctx, id, desired, changes)
 	}
-	return "", nil
+	return contracts.ReconfigureResult{}, nil
 }
 func (s *stubProvider) Describe(_ context.Context, _ string) (contracts.DescribeResponse, error) {
 	return contracts.DescribeResponse{}, nil
@@ -335,12 +337,12 @@ var _ = Describe("VirtualMachine Controller", func() {
 
 			It("should set error condition and requeue when provider returns error", func() {
 				provider := &stubProvider{
-					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
-						return "", fmt.Errorf("provider unavailable")
+					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+						return contracts.ReconfigureResult{}, fmt.Errorf("provider unavailable")
 					},
 				}
 
-				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil)
+				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil, contracts.ChangeSet{})
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.RequeueAfter).To(Equal(5 * time.Second))
@@ -357,12 +359,12 @@ var _ = Describe("VirtualMachine Controller", func() {
 
 			It("should set ReconfigureTaskRef and phase Reconfiguring for async operation", func() {
 				provider := &stubProvider{
-					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
-						return "task-456", nil
+					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+						return contracts.ReconfigureResult{TaskRef: "task-456"}, nil
 					},
 				}
 
-				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil)
+				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil, contracts.ChangeSet{})
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.RequeueAfter).To(Equal(5 * time.Second))
@@ -373,12 +375,12 @@ var _ = Describe("VirtualMachine Controller", func() {
 
 			It("should update CurrentResources and set phase Running for synchronous completion", func() {
 				provider := &stubProvider{
-					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest) (string, error) {
-						return "", nil
+					ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+						return contracts.ReconfigureResult{}, nil
 					},
 				}
 
-				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil)
+				result, err := reconciler.reconfigureVM(ctx, vm, provider, "", vmClass, nil, nil, contracts.ChangeSet{})
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.RequeueAfter).To(Equal(5 * time.Second))
@@ -390,38 +392,49 @@ var _ = Describe("VirtualMachine Controller", func() {
 		})
 
 		Describe("getRequeueInterval", func() {
-			It("should return 10s for poweredOn VM with no IPs (waiting for VMware Tools)", func() {
-				vm := &infravirtrigaudiov1beta1.VirtualMachine{}
-				desc := contracts.DescribeResponse{PowerState: "poweredOn", IPs: nil}
-				Expect(reconciler.getRequeueInterval(vm, desc)).To(Equal(10 * time.Second))
+			It("should return 10s for a powered-on VM with no IPs (waiting for VMware Tools)", func() {
+				provider := &infravirtrigaudiov1beta1.Provider{}
+				desc := contracts.DescribeResponse{PowerState: "On", IPs: nil}
+				Expect(reconciler.getRequeueInterval(provider, desc)).To(Equal(10 * time.Second))
 			})
 
-			It("should return 2m for poweredOn VM with IPs", func() {
-				vm := &infravirtrigaudiov1beta1.VirtualMachine{}
-				desc := contracts.DescribeResponse{PowerState: "poweredOn", IPs: []string{"10.0.0.1"}}
-				Expect(reconciler.getRequeueInterval(vm, desc)).To(Equal(2 * time.Minute))
+			It("should return 2m for a powered-on VM with IPs", func() {
+				provider := &infravirtrigaudiov1beta1.Provider{}
+				desc := contracts.DescribeResponse{PowerState: "On", IPs: []string{"10.0.0.1"}}
+				Expect(reconciler.getRequeueInterval(provider, desc)).To(Equal(2 * time.Minute))
 			})
 
-			It("should return 5m for poweredOff VM", func() {
-				vm := &infravirtrigaudiov1beta1.VirtualMachine{}
-				desc := contracts.DescribeResponse{PowerState: "poweredOff"}
-				Expect(reconciler.getRequeueInterval(vm, desc)).To(Equal(5 * time.Minute))
+			It("should return 5m for a powered-off VM", func() {
+				provider := &infravirtrigaudiov1beta1.Provider{}
+				desc := contracts.DescribeResponse{PowerState: "Off"}
+				Expect(reconciler.getRequeueInterval(provider, desc)).To(Equal(5 * time.Minute))
 			})
 
 			It("should return 2m for suspended VM", func() {
-				vm := &infravirtrigaudiov1beta1.VirtualMachine{}
-				desc := contracts.DescribeResponse{PowerState: "suspended"}
-				Expect(reconciler.getRequeueInterval(vm, desc)).To(Equal(2 * time.Minute))
+				provider := &infravirtrigaudiov1beta1.Provider{}
+				desc := contracts.DescribeResponse{PowerState: "Suspended"}
+				Expect(reconciler.getRequeueInterval(provider, desc)).To(Equal(2 * time.Minute))
 			})
 
 			It("should return 10s for unknown/transitional state", func() {
-				vm := &infravirtrigaudiov1beta1.VirtualMachine{}
-				desc := contracts.DescribeResponse{PowerState: "unknown"}
-				Expect(reconciler.getRequeueInterval(vm, desc)).To(Equal(10 * time.Second))
+				provider := &infravirtrigaudiov1beta1.Provider{}
+				desc := contracts.DescribeResponse{PowerState: "Unknown"}
+				Expect(reconciler.getRequeueInterval(provider, desc)).To(Equal(10 * time.Second))
+			})
+
+			It("should use the provider's intervals", func() {
+				provider := &infravirtrigaudiov1beta1.Provider{Spec: infravirtrigaudiov1beta1.ProviderSpec{
+					ReconcileInterval:     &metav1.Duration{Duration: 30 * time.Minute},
+					StatusRefreshInterval: &metav1.Duration{Duration: 30 * time.Second},
+				}}
+				Expect(reconciler.getRequeueInterval(provider, contracts.DescribeResponse{PowerState: "On", IPs: []string{"10.0.0.1"}})).To(Equal(30 * time.Minute))
+				Expect(reconciler.getRequeueInterval(provider, contracts.DescribeResponse{PowerState: "Off"})).To(Equal(30 * time.Minute))
+				Expect(reconciler.getRequeueInterval(provider, contracts.DescribeResponse{PowerState: "On"})).To(Equal(30 * time.Second))
+				Expect(reconciler.getRequeueInterval(provider, contracts.DescribeResponse{PowerState: "Unknown"})).To(Equal(30 * time.Second))
 			})
 		})
 
-		Describe("updateCurrentResources", func() {
+		Describe("recordAppliedSpec", func() {
 			It("should initialize CurrentResources when nil", func() {
 				vm := &infravirtrigaudiov1beta1.VirtualMachine{
 					Status: infravirtrigaudiov1beta1.VirtualMachineStatus{},
@@ -433,7 +446,7 @@ var _ = Describe("VirtualMachine Controller", func() {
 					},
 				}
 
-				reconciler.updateCurrentResources(vm, vmClass)
+				reconciler.recordAppliedSpec(vm, vmClass, nil)
 
 				Expect(vm.Status.CurrentResources).NotTo(BeNil())
 				Expect(*vm.Status.CurrentResources.CPU).To(Equal(int32(4)))
@@ -458,7 +471,7 @@ var _ = Describe("VirtualMachine Controller", func() {
 					},
 				}
 
-				reconciler.updateCurrentResources(vm, vmClass)
+				reconciler.recordAppliedSpec(vm, vmClass, nil)
 
 				Expect(*vm.Status.CurrentResources.CPU).To(Equal(int32(8)))
 				Expect(*vm.Status.CurrentResources.MemoryMiB).To(Equal(int64(16384)))
@@ -483,7 +496,7 @@ var _ = Describe("VirtualMachine Controller", func() {
 					},
 				}
 
-				reconciler.updateCurrentResources(vm, vmClass)
+				reconciler.recordAppliedSpec(vm, vmClass, nil)
 
 				// Should use VM overrides, not VMClass values
 				Expect(*vm.Status.CurrentResources.CPU).To(Equal(int32(16)))
@@ -492,3 +505,68 @@ var _ = Describe("VirtualMachine Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Stuck deletions", func() {
+	ctx := context.Background()
+
+	It("should release an opted-in VM once its deletion is blocked on an unreachable provider", func() {
+		provider := &infravirtrigaudiov1beta1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck-provider", Namespace: "default"},
+			Spec: infravirtrigaudiov1beta1.ProviderSpec{
+				Type:                "vsphere",
+				Endpoint:            "https://vcenter.example.com:443",
+				CredentialSecretRef: infravirtrigaudiov1beta1.ObjectRef{Name: "test-creds"},
+				Runtime: &infravirtrigaudiov1beta1.ProviderRuntimeSpec{
+					Mode:  infravirtrigaudiov1beta1.RuntimeModeRemote,
+					Image: "virtrigaud/provider-vsphere:test",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, provider)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, provider)).To(Succeed()) })
+
+		vm := &infravirtrigaudiov1beta1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "stuck-vm",
+				Namespace:  "default",
+				Finalizers: []string{infravirtrigaudiov1beta1.VirtualMachineFinalizer},
+			},
+			Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+				ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "stuck-provider"},
+				ClassRef:    infravirtrigaudiov1beta1.ObjectRef{Name: "test-class"},
+				ImageRef:    &infravirtrigaudiov1beta1.ObjectRef{Name: "test-image"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, vm)).To(Succeed())
+		vm.Status.ID = "100"
+		Expect(k8sClient.Status().Update(ctx, vm)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, vm)).To(Succeed())
+
+		reconciler := &VirtualMachineReconciler{
+			Client:         k8sClient,
+			Scheme:         k8sClient.Scheme(),
+			RemoteResolver: &stubResolver{provider: &deleteStubProvider{err: unreachableErr()}},
+		}
+		key := types.NamespacedName{Name: "stuck-vm", Namespace: "default"}
+
+		By("keeping the finalizer while the deletion is blocked")
+		Expect(k8sClient.Get(ctx, key, vm)).To(Succeed())
+		withUnreachableRun(vm, 4, 2*time.Hour)
+		Expect(k8sClient.Update(ctx, vm)).To(Succeed())
+		_, err := reconciler.handleDeletion(ctx, vm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, key, vm)).To(Succeed())
+		cond := meta.FindStatusCondition(vm.Status.Conditions, k8sutil.ConditionDeletionBlocked)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(k8sutil.ReasonProviderUnreachable))
+
+		By("removing the finalizer once the VM opts in")
+		vm.Annotations[k8sutil.AbandonOnProviderLossAnnotation] = "true"
+		Expect(k8sClient.Update(ctx, vm)).To(Succeed())
+		_, err = reconciler.handleDeletion(ctx, vm)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, key, vm))
+		}).Should(BeTrue())
+	})
+})
@@ -0,0 +1,380 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// DNSNameAnnotation opts a VirtualMachine in to having its IPs published
+// under the given DNS name, for external-dns to pick up.
+const DNSNameAnnotation = "virtrigaud.io/dns-name"
+
+// External-dns annotations on the headless Service fallback. The target
+// annotation carries the IPs because external-dns only publishes the
+// endpoints of headless Services that belong to pods.
+const (
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTargetAnnotation   = "external-dns.alpha.kubernetes.io/target"
+)
+
+// dnsEndpointGVK is external-dns's DNSEndpoint. Like cert-manager's
+// Certificate it is handled unstructured, so the manager neither depends on
+// external-dns nor needs its CRD installed.
+var dnsEndpointGVK = schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"}
+
+// DNSPublishing selects how VMs annotated with DNSNameAnnotation are
+// published (--dns-publishing).
+type DNSPublishing string
+
+const (
+	// DNSPublishingAuto writes a DNSEndpoint when the external-dns CRD is
+	// installed and a headless Service otherwise. It is the zero value.
+	DNSPublishingAuto DNSPublishing = "auto"
+	// DNSPublishingDNSEndpoint only writes DNSEndpoints.
+	DNSPublishingDNSEndpoint DNSPublishing = "dnsendpoint"
+	// DNSPublishingService only writes headless Services.
+	DNSPublishingService DNSPublishing = "service"
+	// DNSPublishingOff disables the integration: the annotation is ignored
+	// and no objects are read or written.
+	DNSPublishingOff DNSPublishing = "off"
+)
+
+// ParseDNSPublishing validates a --dns-publishing value.
+func ParseDNSPublishing(value string) (DNSPublishing, error) {
+	switch mode := DNSPublishing(value); mode {
+	case DNSPublishingAuto, DNSPublishingDNSEndpoint, DNSPublishingService, DNSPublishingOff:
+		return mode, nil
+	case "":
+		return DNSPublishingAuto, nil
+	}
+	return "", fmt.Errorf("invalid DNS publishing mode %q: must be auto, dnsendpoint, service or off", value)
+}
+
+// ConditionDNSPublished reports, on VMs with DNSNameAnnotation, whether
+// their IPs are published under the annotated name.
+const ConditionDNSPublished = "DNSPublished"
+
+// Reasons for the DNSPublished condition.
+const (
+	ReasonDNSPublished     = "Published"
+	ReasonDNSWaitingForIPs = "WaitingForIPs"
+	ReasonDNSInvalidName   = "InvalidName"
+	ReasonDNSNotInstalled  = "ExternalDNSNotInstalled"
+	ReasonDNSPublishFailed = "PublishFailed"
+)
+
+const errReasonDNSPublish = "dns-publish-failed"
+
+// reconcileDNS publishes the VM's IPs under its virtrigaud.io/dns-name
+// annotation as a DNSEndpoint or a headless Service with Endpoints, and
+// removes what it published once the annotation or the IPs are gone. Both
+// objects are owned by the VM and are garbage-collected with it.
+//
+// Publishing is best-effort: a failure is recorded on the DNSPublished
+// condition and retried on the next reconcile, and never holds the VM back
+// from Ready.
+func (r *VirtualMachineReconciler) reconcileDNS(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	if r.DNSPublishing == DNSPublishingOff {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	name := strings.TrimSuffix(vm.Annotations[DNSNameAnnotation], ".")
+	if name == "" || len(vm.Status.IPs) == 0 {
+		if err := r.unpublishDNS(ctx, vm, ""); err != nil {
+			logger.Error(err, "Failed to remove published DNS records")
+		}
+		if name == "" {
+			meta.RemoveStatusCondition(&vm.Status.Conditions, ConditionDNSPublished)
+		} else {
+			k8s.SetCondition(&vm.Status.Conditions, ConditionDNSPublished, metav1.ConditionFalse,
+				ReasonDNSWaitingForIPs, "Waiting for the VM to report an IP address")
+		}
+		return
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		k8s.SetCondition(&vm.Status.Conditions, ConditionDNSPublished, metav1.ConditionFalse,
+			ReasonDNSInvalidName, fmt.Sprintf("%s %q is not a valid DNS name: %s",
+				DNSNameAnnotation, name, strings.Join(errs, "; ")))
+		return
+	}
+
+	kind, err := r.publishDNS(ctx, vm, name)
+	switch {
+	case meta.IsNoMatchError(err):
+		k8s.SetCondition(&vm.Status.Conditions, ConditionDNSPublished, metav1.ConditionFalse,
+			ReasonDNSNotInstalled, "The DNSEndpoint CRD (externaldns.k8s.io/v1alpha1) is not installed")
+		return
+	case err != nil:
+		logger.Error(err, "Failed to publish DNS records", "dnsName", name)
+		metrics.RecordError(errReasonDNSPublish, metrics.ComponentManager)
+		k8s.SetCondition(&vm.Status.Conditions, ConditionDNSPublished, metav1.ConditionFalse,
+			ReasonDNSPublishFailed, err.Error())
+		return
+	}
+	if err := r.unpublishDNS(ctx, vm, kind); err != nil {
+		logger.Error(err, "Failed to remove stale DNS records")
+	}
+	k8s.SetCondition(&vm.Status.Conditions, ConditionDNSPublished, metav1.ConditionTrue, ReasonDNSPublished,
+		fmt.Sprintf("%s published as %s %s: %s", name, kind, dnsObjectName(vm), strings.Join(vm.Status.IPs, ", ")))
+}
+
+// publishDNS writes the DNS object for the configured mode and returns its
+// kind. In auto mode a missing DNSEndpoint CRD falls back to a Service.
+func (r *VirtualMachineReconciler) publishDNS(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, name string) (string, error) {
+	if r.DNSPublishing != DNSPublishingService {
+		err := r.applyDNSEndpoint(ctx, vm, name)
+		if err == nil {
+			return dnsEndpointGVK.Kind, nil
+		}
+		if r.DNSPublishing == DNSPublishingDNSEndpoint || !meta.IsNoMatchError(err) {
+			return "", err
+		}
+	}
+	if err := r.applyDNSService(ctx, vm, name); err != nil {
+		return "", err
+	}
+	return "Service", nil
+}
+
+// dnsRecords splits ips into A and AAAA records for name, leaving out
+// anything that does not parse as an IP.
+func dnsRecords(name string, ips []string) []interface{} {
+	var v4, v6 []interface{}
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		switch {
+		case parsed == nil:
+		case parsed.To4() != nil:
+			v4 = append(v4, ip)
+		default:
+			v6 = append(v6, ip)
+		}
+	}
+	var records []interface{}
+	if len(v4) > 0 {
+		records = append(records, map[string]interface{}{"dnsName": name, "recordType": "A", "targets": v4})
+	}
+	if len(v6) > 0 {
+		records = append(records, map[string]interface{}{"dnsName": name, "recordType": "AAAA", "targets": v6})
+	}
+	return records
+}
+
+// applyDNSEndpoint creates or updates the VM's DNSEndpoint.
+func (r *VirtualMachineReconciler) applyDNSEndpoint(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, name string) error {
+	desiredSpec := map[string]interface{}{"endpoints": dnsRecords(name, vm.Status.IPs)}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(dnsEndpointGVK)
+	err := r.Get(ctx, types.NamespacedName{Namespace: vm.Namespace, Name: dnsObjectName(vm)}, existing)
+	if apierrors.IsNotFound(err) {
+		desired := &unstructured.Unstructured{}
+		desired.SetGroupVersionKind(dnsEndpointGVK)
+		desired.SetName(dnsObjectName(vm))
+		desired.SetNamespace(vm.Namespace)
+		desired.SetLabels(dnsObjectLabels(vm))
+		desired.Object["spec"] = desiredSpec
+		if err := controllerutil.SetControllerReference(vm, desired, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create DNSEndpoint: %w", err)
+		}
+		log.FromContext(ctx).Info("Created DNSEndpoint", "dnsEndpoint", desired.GetName(), "dnsName", name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, vm) {
+		return fmt.Errorf("DNSEndpoint %s already exists and is not owned by this VM", existing.GetName())
+	}
+
+	current, _, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", "endpoints")
+	if reflect.DeepEqual(current, desiredSpec["endpoints"]) {
+		return nil
+	}
+	existing.Object["spec"] = desiredSpec
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update DNSEndpoint: %w", err)
+	}
+	return nil
+}
+
+// applyDNSService creates or updates the VM's headless Service and the
+// Endpoints carrying its IPs. The Service resolves to the VM inside the
+// cluster, and its annotations tell external-dns what to publish.
+func (r *VirtualMachineReconciler) applyDNSService(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, name string) error {
+	key := types.NamespacedName{Namespace: vm.Namespace, Name: dnsObjectName(vm)}
+	annotations := map[string]string{
+		externalDNSHostnameAnnotation: name,
+		externalDNSTargetAnnotation:   strings.Join(vm.Status.IPs, ","),
+	}
+
+	svc := &corev1.Service{}
+	err := r.Get(ctx, key, svc)
+	switch {
+	case apierrors.IsNotFound(err):
+		svc = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      dnsObjectLabels(vm),
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+		}
+		if err := controllerutil.SetControllerReference(vm, svc, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, svc); err != nil {
+			return fmt.Errorf("failed to create Service: %w", err)
+		}
+		log.FromContext(ctx).Info("Created DNS Service", "service", svc.Name, "dnsName", name)
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(svc, vm):
+		return fmt.Errorf("service %s already exists and is not owned by this VM", svc.Name)
+	default:
+		changed := false
+		for k, v := range annotations {
+			if svc.Annotations[k] != v {
+				if svc.Annotations == nil {
+					svc.Annotations = map[string]string{}
+				}
+				svc.Annotations[k] = v
+				changed = true
+			}
+		}
+		if changed {
+			if err := r.Update(ctx, svc); err != nil {
+				return fmt.Errorf("failed to update Service: %w", err)
+			}
+		}
+	}
+
+	var addresses []corev1.EndpointAddress
+	for _, ip := range vm.Status.IPs {
+		if net.ParseIP(ip) != nil {
+			addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+		}
+	}
+	var subsets []corev1.EndpointSubset
+	if len(addresses) > 0 {
+		subsets = []corev1.EndpointSubset{{Addresses: addresses}}
+	}
+
+	endpoints := &corev1.Endpoints{}
+	err = r.Get(ctx, key, endpoints)
+	if apierrors.IsNotFound(err) {
+		endpoints = &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Labels: dnsObjectLabels(vm)},
+			Subsets:    subsets,
+		}
+		if err := controllerutil.SetControllerReference(vm, endpoints, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, endpoints); err != nil {
+			return fmt.Errorf("failed to create Endpoints: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(endpoints.Subsets, subsets) {
+		return nil
+	}
+	endpoints.Subsets = subsets
+	if err := r.Update(ctx, endpoints); err != nil {
+		return fmt.Errorf("failed to update Endpoints: %w", err)
+	}
+	return nil
+}
+
+// unpublishDNS deletes the DNS objects the VM owns, except those of kind
+// keep. A missing DNSEndpoint CRD means there is no DNSEndpoint to delete.
+func (r *VirtualMachineReconciler) unpublishDNS(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, keep string) error {
+	key := types.NamespacedName{Namespace: vm.Namespace, Name: dnsObjectName(vm)}
+
+	var objects []client.Object
+	if keep != dnsEndpointGVK.Kind && r.DNSPublishing != DNSPublishingService {
+		dnsEndpoint := &unstructured.Unstructured{}
+		dnsEndpoint.SetGroupVersionKind(dnsEndpointGVK)
+		objects = append(objects, dnsEndpoint)
+	}
+	if keep != "Service" && r.DNSPublishing != DNSPublishingDNSEndpoint {
+		objects = append(objects, &corev1.Endpoints{}, &corev1.Service{})
+	}
+
+	for _, obj := range objects {
+		err := r.Get(ctx, key, obj)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(obj, vm) {
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, key.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted published DNS object", "name", key.Name, "type", fmt.Sprintf("%T", obj))
+	}
+	return nil
+}
+
+// dnsObjectName names the VM's DNSEndpoint and Service. A Service name must
+// be a DNS label, which VM names containing dots are not; those get a
+// hashed name.
+func dnsObjectName(vm *infravirtrigaudiov1beta1.VirtualMachine) string {
+	name := vm.Name + "-dns"
+	if len(validation.IsDNS1035Label(name)) == 0 {
+		return name
+	}
+	return "vm-dns-" + shortHash([]byte(vm.Name))
+}
+
+// dnsObjectLabels labels the objects published for vm.
+func dnsObjectLabels(vm *infravirtrigaudiov1beta1.VirtualMachine) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "virtrigaud-vm-dns",
+		"app.kubernetes.io/instance":   dnsObjectName(vm),
+		"app.kubernetes.io/managed-by": "virtrigaud",
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// dnsReconciler returns a reconciler on a fake client. Without externalDNS
+// the client answers for DNSEndpoints as if the CRD were not installed.
+func dnsReconciler(t *testing.T, mode DNSPublishing, externalDNS bool) *VirtualMachineReconciler {
+	t.Helper()
+	s := cloudInitScheme(t)
+	noMatch := func(obj client.Object) error {
+		if u, ok := obj.(*unstructured.Unstructured); ok && !externalDNS && u.GroupVersionKind() == dnsEndpointGVK {
+			return &meta.NoKindMatchError{GroupKind: dnsEndpointGVK.GroupKind(), SearchedVersions: []string{dnsEndpointGVK.Version}}
+		}
+		return nil
+	}
+	fc := fake.NewClientBuilder().WithScheme(s).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := noMatch(obj); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if err := noMatch(obj); err != nil {
+				return err
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	return &VirtualMachineReconciler{Client: fc, Scheme: s, DNSPublishing: mode}
+}
+
+func dnsVM() *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.UID = types.UID("vm-uid")
+	vm.Annotations = map[string]string{DNSNameAnnotation: "web.internal.example.com."}
+	vm.Status.IPs = []string{"10.0.0.5", "10.0.0.6", "fd00::5"}
+	return vm
+}
+
+func getDNSEndpoint(t *testing.T, c client.Client, vm *infravirtrigaudiov1beta1.VirtualMachine) (*unstructured.Unstructured, error) {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(dnsEndpointGVK)
+	return obj, c.Get(context.Background(), types.NamespacedName{Namespace: vm.Namespace, Name: dnsObjectName(vm)}, obj)
+}
+
+func TestReconcileDNS_DNSEndpoint(t *testing.T) {
+	ctx := context.Background()
+	r := dnsReconciler(t, DNSPublishingAuto, true)
+	vm := dnsVM()
+
+	r.reconcileDNS(ctx, vm)
+	c := k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status, c.Message)
+
+	obj, err := getDNSEndpoint(t, r.Client, vm)
+	require.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(obj, vm))
+	endpoints, _, _ := unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, map[string]interface{}{
+		"dnsName": "web.internal.example.com", "recordType": "A", "targets": []interface{}{"10.0.0.5", "10.0.0.6"},
+	}, endpoints[0])
+	assert.Equal(t, "AAAA", endpoints[1].(map[string]interface{})["recordType"])
+
+	vm.Status.IPs = []string{"10.0.0.7"}
+	r.reconcileDNS(ctx, vm)
+	obj, err = getDNSEndpoint(t, r.Client, vm)
+	require.NoError(t, err)
+	endpoints, _, _ = unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	require.Len(t, endpoints, 1, "records follow the VM's IPs")
+
+	delete(vm.Annotations, DNSNameAnnotation)
+	r.reconcileDNS(ctx, vm)
+	_, err = getDNSEndpoint(t, r.Client, vm)
+	assert.True(t, apierrors.IsNotFound(err), "removing the annotation removes the records")
+	assert.Nil(t, k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished))
+}
+
+func TestReconcileDNS_ServiceFallback(t *testing.T) {
+	ctx := context.Background()
+	r := dnsReconciler(t, DNSPublishingAuto, false)
+	vm := dnsVM()
+
+	r.reconcileDNS(ctx, vm)
+	c := k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status, c.Message)
+
+	key := types.NamespacedName{Namespace: vm.Namespace, Name: dnsObjectName(vm)}
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, "web.internal.example.com", svc.Annotations[externalDNSHostnameAnnotation])
+	assert.Equal(t, "10.0.0.5,10.0.0.6,fd00::5", svc.Annotations[externalDNSTargetAnnotation])
+	assert.True(t, metav1.IsControlledBy(svc, vm))
+
+	endpoints := &corev1.Endpoints{}
+	require.NoError(t, r.Get(ctx, key, endpoints))
+	require.Len(t, endpoints.Subsets, 1)
+	assert.Len(t, endpoints.Subsets[0].Addresses, 3)
+
+	vm.Status.IPs = nil
+	r.reconcileDNS(ctx, vm)
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, key, &corev1.Service{})), "a VM without IPs publishes nothing")
+	c = k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished)
+	assert.Equal(t, ReasonDNSWaitingForIPs, c.Reason)
+}
+
+func TestReconcileDNS_Modes(t *testing.T) {
+	ctx := context.Background()
+
+	r := dnsReconciler(t, DNSPublishingDNSEndpoint, false)
+	vm := dnsVM()
+	r.reconcileDNS(ctx, vm)
+	assert.Equal(t, ReasonDNSNotInstalled, k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished).Reason)
+
+	r = dnsReconciler(t, DNSPublishingOff, true)
+	vm = dnsVM()
+	r.reconcileDNS(ctx, vm)
+	assert.Nil(t, k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished))
+	_, err := getDNSEndpoint(t, r.Client, vm)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	r = dnsReconciler(t, DNSPublishingAuto, true)
+	vm = dnsVM()
+	vm.Annotations[DNSNameAnnotation] = "not a name"
+	r.reconcileDNS(ctx, vm)
+	assert.Equal(t, ReasonDNSInvalidName, k8s.GetCondition(vm.Status.Conditions, ConditionDNSPublished).Reason)
+}
+
+func TestParseDNSPublishing(t *testing.T) {
+	for _, value := range []string{"", "auto", "dnsendpoint", "service", "off"} {
+		_, err := ParseDNSPublishing(value)
+		assert.NoError(t, err, value)
+	}
+	_, err := ParseDNSPublishing("on")
+	assert.Error(t, err)
+}
+
+func TestDNSObjectName(t *testing.T) {
+	vm := baseVM("default")
+	assert.Equal(t, "test-vm-dns", dnsObjectName(vm))
+	vm.Name = "web.example"
+	assert.Regexp(t, `^vm-dns-[0-9a-f]{16}$`, dnsObjectName(vm))
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// DryRunAnnotation, set to "true" on a VirtualMachine, makes the controller
+// write the provider operations it would perform to status.plannedActions
+// instead of performing them. Only read-only RPCs (Describe,
+// GetCapabilities, task status) reach the provider. Removing the annotation
+// lets the next reconcile carry the plan out.
+const DryRunAnnotation = "virtrigaud.io/dry-run"
+
+// ConditionPlanReady is True once status.plannedActions holds the plan for
+// the VM's current spec.
+const ConditionPlanReady = "PlanReady"
+
+// Reasons for the PlanReady condition.
+const (
+	ReasonPlanComputed   = events.ReasonPlanComputed
+	ReasonDryRunDisabled = "DryRunDisabled"
+)
+
+// isDryRun reports whether vm carries the dry-run annotation.
+func isDryRun(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return vm.Annotations[DryRunAnnotation] == "true"
+}
+
+// planVM computes what the rest of reconcileVM would do to a VM that is not
+// waiting on a task, and records it as the VM's plan. It follows the same
+// order: create (or adopt) a VM without an ID, recreate one the provider
+// lost, then the requested power operation, the requested host migration,
+// power-state correction and the reconfigure. Where reconcileVM performs
+// one of these per reconcile, the plan lists all of them.
+func (r *VirtualMachineReconciler) planVM(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+	providerInstance contracts.Provider,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if vm.Status.ID == "" {
+		switch {
+		case adoptsExisting(vm):
+			// Adoption only describes the provider VM, so it goes ahead
+			// and later reconciles plan against the adopted VM.
+			return r.adoptExistingVM(ctx, vm, providerInstance, vmClass)
+		case vmIsAdopted(vm):
+			return r.recordPlan(ctx, vm, nil), nil
+		default:
+			return r.recordPlan(ctx, vm, []infravirtrigaudiov1beta1.PlannedAction{
+				planCreate(vm, provider.Name, vmClass, vmImage),
+			}), nil
+		}
+	}
+
+	desc, err := providerInstance.Describe(ctx, vm.Status.ID)
+	if err != nil {
+		logger.Error(err, "Failed to describe VM")
+		k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonProviderError, fmt.Sprintf("Failed to describe VM: %v", err))
+		metrics.RecordError(errReasonProviderDescribe, metrics.ComponentManager)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, nil
+	}
+	if !desc.Exists {
+		if adoptsExisting(vm) {
+			return r.recordPlan(ctx, vm, nil), nil
+		}
+		return r.recordPlan(ctx, vm, []infravirtrigaudiov1beta1.PlannedAction{
+			planCreate(vm, provider.Name, vmClass, vmImage),
+		}), nil
+	}
+	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerState(desc.PowerState)
+
+	desired := vm.Spec.PowerState
+	if desired == "" {
+		desired = infravirtrigaudiov1beta1.PowerStateOn
+	}
+	powerState := desc.PowerState
+
+	var actions []infravirtrigaudiov1beta1.PlannedAction
+	if powerOpPending(vm) && desired == infravirtrigaudiov1beta1.PowerStateOn && powerState == string(desired) {
+		if _, ok := contractPowerOp(vm.Spec.PowerOpRequest.Op); ok {
+			actions = append(actions, infravirtrigaudiov1beta1.PlannedAction{
+				Operation:  infravirtrigaudiov1beta1.PlannedOperationPower,
+				Parameters: fmt.Sprintf("op: %s (request %s)", vm.Spec.PowerOpRequest.Op, vm.Spec.PowerOpRequest.RequestID),
+			})
+		}
+	}
+	if target := requestedHost(vm); vm.Status.MigrationTaskRef == "" && target != "" && target != vm.Status.Host {
+		actions = append(actions, infravirtrigaudiov1beta1.PlannedAction{
+			Operation:  infravirtrigaudiov1beta1.PlannedOperationMigrate,
+			Parameters: fmt.Sprintf("host: %s -> %s", vm.Status.Host, target),
+		})
+	}
+	if powerState != string(desired) {
+		actions = append(actions, infravirtrigaudiov1beta1.PlannedAction{
+			Operation:  infravirtrigaudiov1beta1.PlannedOperationPower,
+			Parameters: fmt.Sprintf("powerState: %s -> %s", powerState, desired),
+		})
+		// The reconfigure below follows the power change.
+		powerState = string(desired)
+	}
+
+	changes := desiredChangeSet(vm, vmClass)
+	changes.SecurityGroups = securityGroupChanges(vm, networks)
+	if !changes.IsEmpty() && !awaitingPowerCycle(vm, changes, powerState) {
+		actions = append(actions, planReconfigure(ctx, providerInstance, changes, powerState))
+	}
+	return r.recordPlan(ctx, vm, actions), nil
+}
+
+// planDeletion records the provider Delete that handleDeletion would issue.
+// The finalizer is kept, so the plan stays visible until the annotation is
+// removed.
+func (r *VirtualMachineReconciler) planDeletion(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) ctrl.Result {
+	var actions []infravirtrigaudiov1beta1.PlannedAction
+	if vm.Status.ID != "" && vm.Spec.ProviderRef.Name != "" && !retainsProviderVM(vm) {
+		actions = append(actions, infravirtrigaudiov1beta1.PlannedAction{
+			Operation:  infravirtrigaudiov1beta1.PlannedOperationDelete,
+			Parameters: fmt.Sprintf("id: %s", vm.Status.ID),
+		})
+	}
+	return r.recordPlan(ctx, vm, actions)
+}
+
+// planCreate describes the VM that createVM would ask the provider for.
+func planCreate(
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerName string,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+) infravirtrigaudiov1beta1.PlannedAction {
+	cpu, memoryMiB := desiredResources(vm, vmClass)
+	params := []string{
+		"provider: " + providerName,
+		fmt.Sprintf("cpu: %d", cpu),
+		fmt.Sprintf("memoryMiB: %d", memoryMiB),
+	}
+	switch {
+	case vmImage != nil:
+		params = append(params, "image: "+vmImage.Name)
+	case vm.Spec.ImportedDisk != nil:
+		params = append(params, "importedDisk: "+vm.Spec.ImportedDisk.DiskID)
+	}
+	applied := desiredAppliedConfig(vm, vmClass)
+	for _, d := range applied.Disks {
+		params = append(params, fmt.Sprintf("disk %s: %d GiB", d.Name, d.SizeGiB))
+	}
+	if len(applied.Networks) > 0 {
+		params = append(params, "networks: "+strings.Join(applied.Networks, ", "))
+	}
+	if defaulted := vm.Annotations[k8sutil.DefaultedRefsAnnotation]; defaulted != "" {
+		params = append(params, "namespace defaults: "+strings.ReplaceAll(defaulted, ",", ", "))
+	}
+	return infravirtrigaudiov1beta1.PlannedAction{
+		Operation:  infravirtrigaudiov1beta1.PlannedOperationCreate,
+		Parameters: strings.Join(params, "; "),
+	}
+}
+
+// planReconfigure describes the change set reconfigureVM would send, and
+// whether applying it would need a power cycle. That is decided from the
+// provider's capabilities; a provider that does not report them is assumed
+// to apply CPU, memory and disk changes only to a powered-off VM.
+func planReconfigure(
+	ctx context.Context,
+	provider contracts.Provider,
+	changes contracts.ChangeSet,
+	powerState string,
+) infravirtrigaudiov1beta1.PlannedAction {
+	var params []string
+	if c := changes.CPU; c != nil {
+		params = append(params, fmt.Sprintf("cpu: %d -> %d", c.Old, c.New))
+	}
+	if c := changes.MemoryMiB; c != nil {
+		params = append(params, fmt.Sprintf("memoryMiB: %d -> %d", c.Old, c.New))
+	}
+	for _, d := range changes.Disks {
+		params = append(params, fmt.Sprintf("disk %s: %d -> %d GiB", d.Name, d.OldSizeGiB, d.NewSizeGiB))
+	}
+	for _, n := range changes.NetworksAdded {
+		params = append(params, "network added: "+n.Name)
+	}
+	for _, name := range changes.NetworksRemoved {
+		params = append(params, "network removed: "+name)
+	}
+	for _, sg := range changes.SecurityGroups {
+		params = append(params, fmt.Sprintf("securityGroups %s: [%s]", sg.Network, strings.Join(sg.Groups, ", ")))
+	}
+	action := infravirtrigaudiov1beta1.PlannedAction{
+		Operation:  infravirtrigaudiov1beta1.PlannedOperationReconfigure,
+		Parameters: strings.Join(params, "; "),
+	}
+	if powerState != string(infravirtrigaudiov1beta1.PowerStateOn) {
+		return action
+	}
+
+	var caps contracts.Capabilities
+	if reporter, ok := provider.(contracts.CapabilityReporter); ok {
+		if reported, err := reporter.GetCapabilities(ctx); err == nil {
+			caps = reported
+		} else {
+			log.FromContext(ctx).V(1).Info("GetCapabilities failed; planning a power cycle", "error", err.Error())
+		}
+	}
+	action.PowerCycleRequired = ((changes.CPU != nil || changes.MemoryMiB != nil) && !caps.SupportsReconfigureOnline) ||
+		(len(changes.Disks) > 0 && !caps.SupportsDiskExpansionOnline)
+	return action
+}
+
+// recordPlan stores actions as the VM's plan and sets PlanReady. The event
+// fires only when the plan changes, so resyncs of an unchanged VM stay
+// quiet.
+func (r *VirtualMachineReconciler) recordPlan(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	actions []infravirtrigaudiov1beta1.PlannedAction,
+) ctrl.Result {
+	message := planMessage(actions)
+	changed := !k8s.IsConditionTrue(vm.Status.Conditions, ConditionPlanReady) ||
+		!slices.Equal(vm.Status.PlannedActions, actions)
+	vm.Status.PlannedActions = actions
+	k8s.SetCondition(&vm.Status.Conditions, ConditionPlanReady, metav1.ConditionTrue, ReasonPlanComputed, message)
+	if changed {
+		log.FromContext(ctx).Info("Dry-run plan computed", "actions", len(actions))
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonPlanComputed, message)
+	}
+	r.updateStatus(ctx, vm)
+	return ctrl.Result{RequeueAfter: r.requeue().Running.Duration}
+}
+
+// clearPlan drops the plan of a VM whose dry-run annotation was removed.
+// The caller writes the status.
+func clearPlan(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	if vm.Status.PlannedActions == nil && k8s.GetCondition(vm.Status.Conditions, ConditionPlanReady) == nil {
+		return
+	}
+	vm.Status.PlannedActions = nil
+	k8s.SetCondition(&vm.Status.Conditions, ConditionPlanReady, metav1.ConditionFalse, ReasonDryRunDisabled,
+		fmt.Sprintf("VirtualMachine is no longer annotated %s=true", DryRunAnnotation))
+}
+
+// planMessage summarizes a plan for the PlanReady condition.
+func planMessage(actions []infravirtrigaudiov1beta1.PlannedAction) string {
+	if len(actions) == 0 {
+		return "No provider operations planned"
+	}
+	ops := make([]string, 0, len(actions))
+	for _, a := range actions {
+		op := string(a.Operation)
+		if a.PowerCycleRequired {
+			op += " (power cycle required)"
+		}
+		ops = append(ops, op)
+	}
+	return "Planned " + strings.Join(ops, ", ")
+}
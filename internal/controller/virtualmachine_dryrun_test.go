@@ -0,0 +1,198 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// dryRunProvider counts the mutating calls a dry run must not make and
+// reports fixed capabilities.
+type dryRunProvider struct {
+	fakeDescribeProvider
+	caps        contracts.Capabilities
+	reconfigure int
+	power       int
+	deletes     int
+}
+
+func (p *dryRunProvider) Reconfigure(context.Context, string, contracts.CreateRequest, contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+	p.reconfigure++
+	return contracts.ReconfigureResult{}, nil
+}
+
+func (p *dryRunProvider) Power(context.Context, string, contracts.PowerOp) (string, error) {
+	p.power++
+	return "", nil
+}
+
+func (p *dryRunProvider) Delete(context.Context, string) (string, error) {
+	p.deletes++
+	return "", nil
+}
+
+func (p *dryRunProvider) GetCapabilities(context.Context) (contracts.Capabilities, error) {
+	return p.caps, nil
+}
+
+func newDryRunProvider(caps contracts.Capabilities) *dryRunProvider {
+	return &dryRunProvider{
+		fakeDescribeProvider: fakeDescribeProvider{
+			DescribeFn: func(context.Context, string) (contracts.DescribeResponse, error) {
+				return contracts.DescribeResponse{Exists: true, PowerState: "On", IPs: []string{"10.0.0.1"}}, nil
+			},
+		},
+		caps: caps,
+	}
+}
+
+func TestReconcileVM_DryRunPlansReconfigure(t *testing.T) {
+	prov := newDryRunProvider(contracts.Capabilities{SupportsDiskExpansionOnline: true})
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	class.Spec.DiskDefaults = &infravirtrigaudiov1beta1.DiskDefaults{Size: resource.MustParse("40Gi")}
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+
+	vm := baseVM("default")
+	vm.Annotations = map[string]string{DryRunAnnotation: "true"}
+	vm.Status.ID = "vm-xyz"
+	oldCPU, oldMem := int32(2), int64(8192)
+	vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: &oldCPU, MemoryMiB: &oldMem}
+	vm.Status.AppliedConfig = &infravirtrigaudiov1beta1.VirtualMachineAppliedConfig{
+		Disks: []infravirtrigaudiov1beta1.AppliedDisk{{Name: contracts.RootDiskName, SizeGiB: 20}},
+	}
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Zero(t, prov.reconfigure, "a dry run must not reconfigure")
+	assert.Zero(t, prov.power)
+
+	require.Len(t, vm.Status.PlannedActions, 1)
+	action := vm.Status.PlannedActions[0]
+	assert.Equal(t, infravirtrigaudiov1beta1.PlannedOperationReconfigure, action.Operation)
+	assert.Equal(t, "cpu: 2 -> 4; disk root: 20 -> 40 GiB", action.Parameters)
+	assert.True(t, action.PowerCycleRequired, "the provider cannot change CPUs online")
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionPlanReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "Planned Reconfigure (power cycle required)", cond.Message)
+
+	// A provider that resizes CPUs online needs no power cycle.
+	prov.caps.SupportsReconfigureOnline = true
+	_, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	require.Len(t, vm.Status.PlannedActions, 1)
+	assert.False(t, vm.Status.PlannedActions[0].PowerCycleRequired)
+
+	// Removing the annotation carries the plan out.
+	vm.Annotations = nil
+	_, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, prov.reconfigure)
+	assert.Empty(t, vm.Status.PlannedActions)
+	cond = k8s.GetCondition(vm.Status.Conditions, ConditionPlanReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonDryRunDisabled, cond.Reason)
+}
+
+func TestReconcileVM_DryRunPlansCreate(t *testing.T) {
+	prov := newDryRunProvider(contracts.Capabilities{})
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+
+	vm := baseVM("default")
+	vm.Annotations = map[string]string{DryRunAnnotation: "true"}
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Empty(t, vm.Status.ID, "a dry run must not create")
+	require.Len(t, vm.Status.PlannedActions, 1)
+	assert.Equal(t, infravirtrigaudiov1beta1.PlannedOperationCreate, vm.Status.PlannedActions[0].Operation)
+	assert.Equal(t, "provider: test-prov; cpu: 4; memoryMiB: 8192", vm.Status.PlannedActions[0].Parameters)
+}
+
+func TestReconcileVM_DryRunPlansCreateWithNamespaceDefaults(t *testing.T) {
+	prov := newDryRunProvider(contracts.Capabilities{})
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+
+	// The admission webhook filled the references in from the namespace.
+	vm := baseVM("default")
+	vm.Annotations = map[string]string{
+		DryRunAnnotation:                "true",
+		k8sutil.DefaultedRefsAnnotation: "providerRef,classRef",
+	}
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	require.Len(t, vm.Status.PlannedActions, 1)
+	assert.Equal(t, "provider: test-prov; cpu: 4; memoryMiB: 8192; namespace defaults: providerRef, classRef",
+		vm.Status.PlannedActions[0].Parameters)
+}
+
+func TestReconcile_DryRunPlansDeletion(t *testing.T) {
+	prov := newDryRunProvider(contracts.Capabilities{})
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Annotations = map[string]string{DryRunAnnotation: "true"}
+	vm.Finalizers = []string{infravirtrigaudiov1beta1.VirtualMachineFinalizer}
+	now := metav1.Now()
+	vm.DeletionTimestamp = &now
+	vm.Status.ID = "vm-xyz"
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class, vm)
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(vm)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Zero(t, prov.deletes, "a dry run must not delete")
+
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(ctx, key, got))
+	assert.Contains(t, got.Finalizers, infravirtrigaudiov1beta1.VirtualMachineFinalizer)
+	assert.Equal(t, []infravirtrigaudiov1beta1.PlannedAction{{
+		Operation:  infravirtrigaudiov1beta1.PlannedOperationDelete,
+		Parameters: "id: vm-xyz",
+	}}, got.Status.PlannedActions)
+	assert.True(t, k8s.IsConditionTrue(got.Status.Conditions, ConditionPlanReady))
+
+	// Removing the annotation lets the deletion go ahead.
+	got.Annotations = nil
+	require.NoError(t, r.Update(ctx, got))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, 1, prov.deletes)
+	err = r.Get(ctx, key, &infravirtrigaudiov1beta1.VirtualMachine{})
+	assert.True(t, errors.IsNotFound(err), "finalizer should have been removed, got %v", err)
+}
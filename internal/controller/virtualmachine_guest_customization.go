@@ -0,0 +1,270 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// ReasonGuestCustomizationUnsupported marks a VM whose provider reports it
+// cannot apply the requested guest customization. Create is not attempted.
+const ReasonGuestCustomizationUnsupported = events.ReasonGuestCustomizationUnsupported
+
+const (
+	defaultUnattendSecretKey      = "unattend.xml"
+	defaultAdminPasswordSecretKey = "password"
+	// maxWindowsHostnameLength is the NetBIOS name limit Windows enforces
+	// on computer names.
+	maxWindowsHostnameLength = 15
+)
+
+// gateGuestCustomization stops a Sysprep VM from being created on a
+// provider that reports no Sysprep support, recording why on the
+// Provisioning condition. Like the linked-clone gate it fails open when the
+// provider does not report capabilities or the query fails.
+func (r *VirtualMachineReconciler) gateGuestCustomization(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+) (blocked bool) {
+	gc := vm.Spec.GuestCustomization
+	if gc == nil || gc.Type != infravirtrigaudiov1beta1.GuestCustomizationSysprep {
+		return false
+	}
+	logger := log.FromContext(ctx)
+
+	reporter, ok := provider.(contracts.CapabilityReporter)
+	if !ok {
+		logger.V(1).Info("Provider does not report capabilities; attempting Sysprep create (fail open)")
+		return false
+	}
+	caps, err := reporter.GetCapabilities(ctx)
+	if err != nil {
+		logger.V(1).Info("GetCapabilities failed; attempting Sysprep create (fail open)", "error", err.Error())
+		return false
+	}
+	if caps.SupportsSysprep {
+		return false
+	}
+
+	message := fmt.Sprintf("provider %s does not support Sysprep guest customization", vm.Spec.ProviderRef.Name)
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonGuestCustomizationUnsupported, message)
+	r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonGuestCustomizationUnsupported, message)
+	r.updateStatus(ctx, vm)
+	return true
+}
+
+// resolveGuestCustomization returns the guest customization to send with
+// Create, or nil for cloud-init. A Sysprep customization always carries a
+// complete unattend.xml: the user's own, or one generated from the basic
+// fields.
+func (r *VirtualMachineReconciler) resolveGuestCustomization(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+) (*contracts.GuestCustomization, error) {
+	gc := vm.Spec.GuestCustomization
+	if gc == nil || gc.Type != infravirtrigaudiov1beta1.GuestCustomizationSysprep {
+		return nil, nil
+	}
+	sp := gc.Sysprep
+	if sp == nil {
+		return nil, fmt.Errorf("guestCustomization.sysprep is required when type is Sysprep")
+	}
+	if vm.Spec.UserData != nil || vm.Spec.MetaData != nil {
+		return nil, fmt.Errorf("userData and metaData cannot be combined with Sysprep guest customization")
+	}
+
+	var unattend string
+	if sp.Unattend != nil {
+		if sp.Hostname != "" || sp.AdminPasswordSecretRef != nil || sp.Timezone != "" {
+			return nil, fmt.Errorf("sysprep: set unattend or hostname/adminPasswordSecretRef/timezone, not both")
+		}
+		var err error
+		if unattend, err = r.resolveUnattend(ctx, vm.Namespace, sp.Unattend); err != nil {
+			return nil, err
+		}
+	} else {
+		var password string
+		if ref := sp.AdminPasswordSecretRef; ref != nil {
+			key := sp.AdminPasswordKey
+			if key == "" {
+				key = defaultAdminPasswordSecretKey
+			}
+			var err error
+			if password, err = r.secretValue(ctx, vm.Namespace, ref.Name, key); err != nil {
+				return nil, fmt.Errorf("resolving sysprep admin password: %w", err)
+			}
+		}
+		hostname := sp.Hostname
+		if hostname == "" {
+			hostname = windowsHostname(vm.Name)
+		}
+		var err error
+		if unattend, err = renderUnattend(hostname, password, sp.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkCloudInitSizes(map[string]string{"unattend.xml": unattend}); err != nil {
+		return nil, fmt.Errorf("sysprep: %w", err)
+	}
+	return &contracts.GuestCustomization{
+		Type:    contracts.GuestCustomizationSysprep,
+		Sysprep: &contracts.SysprepCustomization{UnattendXML: unattend},
+	}, nil
+}
+
+// resolveUnattend returns an answer file given inline or in a Secret.
+func (r *VirtualMachineReconciler) resolveUnattend(ctx context.Context, namespace string, doc *infravirtrigaudiov1beta1.UnattendDocument) (string, error) {
+	if doc.SecretRef == nil {
+		return doc.Inline, nil
+	}
+	if doc.Inline != "" {
+		return "", fmt.Errorf("sysprep unattend: set inline or secretRef, not both")
+	}
+	key := doc.Key
+	if key == "" {
+		key = defaultUnattendSecretKey
+	}
+	unattend, err := r.secretValue(ctx, namespace, doc.SecretRef.Name, key)
+	if err != nil {
+		return "", fmt.Errorf("resolving sysprep unattend: %w", err)
+	}
+	return unattend, nil
+}
+
+// secretValue reads one key of a Secret in namespace.
+func (r *VirtualMachineReconciler) secretValue(ctx context.Context, namespace, name, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("fetching secret %q: %w", name, err)
+	}
+	val, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", name, key)
+	}
+	return string(val), nil
+}
+
+// windowsHostname cuts name to the length Windows allows a computer name,
+// without leaving a trailing hyphen.
+func windowsHostname(name string) string {
+	if len(name) > maxWindowsHostnameLength {
+		name = name[:maxWindowsHostnameLength]
+	}
+	for len(name) > 1 && name[len(name)-1] == '-' {
+		name = name[:len(name)-1]
+	}
+	return name
+}
+
+// unattendFile is the subset of the Windows answer file schema the controller
+// generates: computer name and time zone in the specialize pass, the
+// Administrator password and a skipped OOBE in the oobeSystem pass.
+type unattendFile struct {
+	XMLName  xml.Name           `xml:"urn:schemas-microsoft-com:unattend unattend"`
+	Settings []unattendSettings `xml:"settings"`
+}
+
+type unattendSettings struct {
+	Pass      string             `xml:"pass,attr"`
+	Component unattendShellSetup `xml:"component"`
+}
+
+// unattendShellSetup is the Microsoft-Windows-Shell-Setup component.
+type unattendShellSetup struct {
+	Name                  string                `xml:"name,attr"`
+	ProcessorArchitecture string                `xml:"processorArchitecture,attr"`
+	PublicKeyToken        string                `xml:"publicKeyToken,attr"`
+	Language              string                `xml:"language,attr"`
+	VersionScope          string                `xml:"versionScope,attr"`
+	ComputerName          string                `xml:"ComputerName,omitempty"`
+	TimeZone              string                `xml:"TimeZone,omitempty"`
+	UserAccounts          *unattendUserAccounts `xml:"UserAccounts,omitempty"`
+	OOBE                  *unattendOOBE         `xml:"OOBE,omitempty"`
+}
+
+type unattendUserAccounts struct {
+	AdministratorPassword unattendPassword `xml:"AdministratorPassword"`
+}
+
+type unattendPassword struct {
+	Value     string `xml:"Value"`
+	PlainText bool   `xml:"PlainText"`
+}
+
+type unattendOOBE struct {
+	HideEULAPage    bool   `xml:"HideEULAPage"`
+	SkipMachineOOBE bool   `xml:"SkipMachineOOBE"`
+	SkipUserOOBE    bool   `xml:"SkipUserOOBE"`
+	ProtectYourPC   int    `xml:"ProtectYourPC"`
+	NetworkLocation string `xml:"NetworkLocation"`
+}
+
+// renderUnattend generates an amd64 answer file setting the computer name
+// and, when given, the Administrator password and time zone.
+func renderUnattend(hostname, adminPassword, timezone string) (string, error) {
+	shellSetup := func() unattendShellSetup {
+		return unattendShellSetup{
+			Name:                  "Microsoft-Windows-Shell-Setup",
+			ProcessorArchitecture: "amd64",
+			PublicKeyToken:        "31bf3856ad364e35",
+			Language:              "neutral",
+			VersionScope:          "nonSxS",
+		}
+	}
+
+	specialize := shellSetup()
+	specialize.ComputerName = hostname
+	specialize.TimeZone = timezone
+
+	oobe := shellSetup()
+	oobe.OOBE = &unattendOOBE{
+		HideEULAPage:    true,
+		SkipMachineOOBE: true,
+		SkipUserOOBE:    true,
+		ProtectYourPC:   3,
+		NetworkLocation: "Work",
+	}
+	if adminPassword != "" {
+		oobe.UserAccounts = &unattendUserAccounts{
+			AdministratorPassword: unattendPassword{Value: adminPassword, PlainText: true},
+		}
+	}
+
+	doc := unattendFile{Settings: []unattendSettings{
+		{Pass: "specialize", Component: specialize},
+		{Pass: "oobeSystem", Component: oobe},
+	}}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("rendering unattend.xml: %w", err)
+	}
+	return xml.Header + string(out) + "\n", nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func sysprepVM(sysprep *infrav1beta1.SysprepSpec) *infrav1beta1.VirtualMachine {
+	return &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "windows-server-2022", Namespace: "default"},
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ProviderRef: infrav1beta1.ObjectRef{Name: "p"},
+			ClassRef:    infrav1beta1.ObjectRef{Name: "c"},
+			GuestCustomization: &infrav1beta1.GuestCustomization{
+				Type:    infrav1beta1.GuestCustomizationSysprep,
+				Sysprep: sysprep,
+			},
+		},
+	}
+}
+
+func TestRenderUnattend(t *testing.T) {
+	out, err := renderUnattend("WIN-01", "s3cret", "UTC")
+	require.NoError(t, err)
+
+	var doc unattendFile
+	require.NoError(t, xml.Unmarshal([]byte(out), &doc))
+	require.Len(t, doc.Settings, 2)
+	assert.Equal(t, "specialize", doc.Settings[0].Pass)
+	assert.Equal(t, "WIN-01", doc.Settings[0].Component.ComputerName)
+	assert.Equal(t, "UTC", doc.Settings[0].Component.TimeZone)
+	assert.Equal(t, "oobeSystem", doc.Settings[1].Pass)
+	require.NotNil(t, doc.Settings[1].Component.UserAccounts)
+	assert.Equal(t, "s3cret", doc.Settings[1].Component.UserAccounts.AdministratorPassword.Value)
+	assert.Contains(t, out, `xmlns="urn:schemas-microsoft-com:unattend"`)
+
+	out, err = renderUnattend("WIN-01", "", "")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "AdministratorPassword")
+	assert.NotContains(t, out, "TimeZone")
+}
+
+func TestWindowsHostname(t *testing.T) {
+	assert.Equal(t, "web", windowsHostname("web"))
+	assert.Equal(t, "windows-server", windowsHostname("windows-server-2022"))
+	assert.Len(t, windowsHostname("abcdefghijklmnopqrstuvwxyz"), maxWindowsHostnameLength)
+}
+
+func TestResolveGuestCustomization(t *testing.T) {
+	ctx := context.Background()
+	r := reconcilerWithSecrets(t,
+		makeSecret("admin", "default", map[string][]byte{"password": []byte("P@ssw0rd")}),
+		makeSecret("answers", "default", map[string][]byte{"unattend.xml": []byte("<unattend>custom</unattend>")}),
+	)
+
+	t.Run("cloud-init is the default", func(t *testing.T) {
+		gc, err := r.resolveGuestCustomization(ctx, &infrav1beta1.VirtualMachine{})
+		require.NoError(t, err)
+		assert.Nil(t, gc)
+	})
+
+	t.Run("basic fields render an answer file", func(t *testing.T) {
+		gc, err := r.resolveGuestCustomization(ctx, sysprepVM(&infrav1beta1.SysprepSpec{
+			AdminPasswordSecretRef: &infrav1beta1.LocalObjectReference{Name: "admin"},
+			Timezone:               "Pacific Standard Time",
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, gc)
+		assert.Equal(t, contracts.GuestCustomizationSysprep, gc.Type)
+		assert.Contains(t, gc.Sysprep.UnattendXML, "<ComputerName>windows-server</ComputerName>")
+		assert.Contains(t, gc.Sysprep.UnattendXML, "P@ssw0rd")
+		assert.Contains(t, gc.Sysprep.UnattendXML, "Pacific Standard Time")
+	})
+
+	t.Run("unattend from a Secret is passed through", func(t *testing.T) {
+		gc, err := r.resolveGuestCustomization(ctx, sysprepVM(&infrav1beta1.SysprepSpec{
+			Unattend: &infrav1beta1.UnattendDocument{SecretRef: &infrav1beta1.LocalObjectReference{Name: "answers"}},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "<unattend>custom</unattend>", gc.Sysprep.UnattendXML)
+	})
+
+	t.Run("missing password Secret", func(t *testing.T) {
+		_, err := r.resolveGuestCustomization(ctx, sysprepVM(&infrav1beta1.SysprepSpec{
+			AdminPasswordSecretRef: &infrav1beta1.LocalObjectReference{Name: "absent"},
+		}))
+		assert.ErrorContains(t, err, "admin password")
+	})
+
+	t.Run("cloud-init user data is rejected", func(t *testing.T) {
+		vm := sysprepVM(&infrav1beta1.SysprepSpec{})
+		vm.Spec.UserData = &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{Inline: "#cloud-config"}}
+		_, err := r.resolveGuestCustomization(ctx, vm)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildCreateRequest_GuestCustomization(t *testing.T) {
+	r := reconcilerWithSecrets(t)
+	vm := sysprepVM(&infrav1beta1.SysprepSpec{Hostname: "WIN-01"})
+	vmClass := &infrav1beta1.VMClass{
+		Spec: infrav1beta1.VMClassSpec{CPU: 2, Memory: resource.MustParse("4Gi")},
+	}
+
+	req, err := r.buildCreateRequest(context.Background(), vm, "", vmClass, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, req.UserData)
+	require.NotNil(t, req.GuestCustomization)
+	assert.Contains(t, req.GuestCustomization.Sysprep.UnattendXML, "<ComputerName>WIN-01</ComputerName>")
+}
+
+func TestGateGuestCustomization(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    contracts.Provider
+		wantBlocked bool
+	}{
+		{
+			name:     "provider supports sysprep",
+			provider: &capReporterProvider{caps: contracts.Capabilities{SupportsSysprep: true}},
+		},
+		{
+			name:        "provider lacks sysprep",
+			provider:    &capReporterProvider{caps: contracts.Capabilities{}},
+			wantBlocked: true,
+		},
+		{
+			name:     "provider is not a CapabilityReporter, fails open",
+			provider: &stubProvider{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cloudInitScheme(t)
+			vm := sysprepVM(&infrav1beta1.SysprepSpec{})
+			fc := fake.NewClientBuilder().WithScheme(s).WithObjects(vm).WithStatusSubresource(vm).Build()
+			recorder := record.NewFakeRecorder(4)
+			r := &VirtualMachineReconciler{Client: fc, Scheme: s, Recorder: recorder}
+
+			blocked := r.gateGuestCustomization(context.Background(), vm, tc.provider)
+
+			assert.Equal(t, tc.wantBlocked, blocked)
+			cond := readyCondition(vm.Status.Conditions, k8s.ConditionProvisioning)
+			if !tc.wantBlocked {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, ReasonGuestCustomizationUnsupported, cond.Reason)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+
+	t.Run("cloud-init VMs are never gated", func(t *testing.T) {
+		r := &VirtualMachineReconciler{}
+		vm := sysprepVM(nil)
+		vm.Spec.GuestCustomization.Type = infrav1beta1.GuestCustomizationCloudInit
+		assert.False(t, r.gateGuestCustomization(context.Background(), vm, &capReporterProvider{}))
+	})
+}
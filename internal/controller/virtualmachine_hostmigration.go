@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// RequestedHostAnnotation asks the controller to live-migrate a running VM
+// to the named hypervisor host. The controller removes the annotation once
+// the migration finished or was refused; the HostMigrated condition holds
+// the outcome and status.host the host the VM runs on.
+const RequestedHostAnnotation = "virtrigaud.io/requested-host"
+
+// ConditionHostMigrated reports the last live migration requested with
+// RequestedHostAnnotation. It is False while the migration runs, with the
+// provider's progress as message, and after it failed; True once the VM
+// runs on the requested host.
+const ConditionHostMigrated = "HostMigrated"
+
+// Reasons for the HostMigrated condition
+const (
+	ReasonHostMigrationInProgress   = "InProgress"
+	ReasonHostMigrationSucceeded    = "Succeeded"
+	ReasonHostMigrationFailed       = "Failed"
+	ReasonHostMigrationNotSupported = "NotSupported"
+)
+
+// requestedHost returns the host the VM was asked to move to, or "".
+func requestedHost(vm *infravirtrigaudiov1beta1.VirtualMachine) string {
+	return strings.TrimSpace(vm.Annotations[RequestedHostAnnotation])
+}
+
+// providerAdvertisesLiveMigration reports whether the Provider CR advertises
+// the live-migration capability. A provider that has not reported its
+// capabilities reads as unable to migrate.
+func providerAdvertisesLiveMigration(provider *infravirtrigaudiov1beta1.Provider) bool {
+	caps := provider.Status.ReportedCapabilities
+	return caps != nil && caps.SupportsLiveMigration
+}
+
+// reconcileHostMigration services the RequestedHostAnnotation: it starts the
+// live migration through contracts.HostMigrator and follows its task until
+// it completes. It reports whether it acted, in which case the returned
+// result ends the reconcile, so the VM is neither power-managed nor
+// reconfigured mid-migration.
+func (r *VirtualMachineReconciler) reconcileHostMigration(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+	providerInstance contracts.Provider,
+	currentPowerState string,
+) (ctrl.Result, bool) {
+	if vm.Status.MigrationTaskRef != "" {
+		return r.followHostMigration(ctx, vm, providerInstance), true
+	}
+	if _, ok := vm.Annotations[RequestedHostAnnotation]; !ok {
+		return ctrl.Result{}, false
+	}
+	target := requestedHost(vm)
+	if target == "" || target == vm.Status.Host {
+		// Nothing to do: the VM already runs there.
+		r.clearRequestedHost(ctx, vm)
+		return ctrl.Result{}, false
+	}
+	logger := log.FromContext(ctx)
+
+	migrator, ok := providerInstance.(contracts.HostMigrator)
+	if !ok || !providerAdvertisesLiveMigration(provider) {
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationNotSupported,
+			fmt.Sprintf("Provider %s does not support live migration", provider.Name))
+		return ctrl.Result{Requeue: true}, true
+	}
+	if currentPowerState != string(infravirtrigaudiov1beta1.PowerStateOn) {
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationFailed,
+			fmt.Sprintf("Only a running VM can be live-migrated (power state is %s)", currentPowerState))
+		return ctrl.Result{Requeue: true}, true
+	}
+
+	logger.Info("Live-migrating VM", "from", vm.Status.Host, "to", target)
+	taskRef, err := migrator.MigrateHost(ctx, vm.Status.ID, target)
+	switch {
+	case contracts.IsNotSupported(err):
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationNotSupported, err.Error())
+	case err != nil:
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationFailed, err.Error())
+	case taskRef == "":
+		vm.Status.Host = target
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationSucceeded, fmt.Sprintf("VM runs on %s", target))
+	default:
+		vm.Status.MigrationTaskRef = taskRef
+		k8s.SetCondition(&vm.Status.Conditions, ConditionHostMigrated, metav1.ConditionFalse,
+			ReasonHostMigrationInProgress, fmt.Sprintf("Migrating to %s", target))
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonHostMigrationStarted,
+			fmt.Sprintf("Live migration from %s to %s started", vm.Status.Host, target))
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}, true
+	}
+	return ctrl.Result{Requeue: true}, true
+}
+
+// followHostMigration polls the task of an in-flight live migration,
+// surfacing the provider's progress in the HostMigrated condition.
+func (r *VirtualMachineReconciler) followHostMigration(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	status, err := providerInstance.TaskStatus(ctx, vm.Status.MigrationTaskRef)
+	if err != nil {
+		logger.Error(err, "Failed to check live migration task", "taskRef", vm.Status.MigrationTaskRef)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}
+	}
+	if !status.IsCompleted {
+		message := status.Message
+		if message == "" {
+			message = fmt.Sprintf("Migrating to %s", requestedHost(vm))
+		}
+		k8s.SetCondition(&vm.Status.Conditions, ConditionHostMigrated, metav1.ConditionFalse,
+			ReasonHostMigrationInProgress, message)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: r.requeue().InProgress.Duration}
+	}
+
+	vm.Status.MigrationTaskRef = ""
+	if status.Error != "" {
+		r.finishHostMigration(ctx, vm, ReasonHostMigrationFailed, status.Error)
+		return ctrl.Result{Requeue: true}
+	}
+	if target := requestedHost(vm); target != "" {
+		vm.Status.Host = target
+	}
+	r.finishHostMigration(ctx, vm, ReasonHostMigrationSucceeded, fmt.Sprintf("VM runs on %s", vm.Status.Host))
+	return ctrl.Result{Requeue: true}
+}
+
+// finishHostMigration records the outcome of a requested migration and then
+// clears the request annotation. The status is written first; if it cannot
+// be, the annotation stays and the request is served again.
+func (r *VirtualMachineReconciler) finishHostMigration(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	reason, message string,
+) {
+	logger := log.FromContext(ctx)
+
+	status := metav1.ConditionFalse
+	if reason == ReasonHostMigrationSucceeded {
+		status = metav1.ConditionTrue
+		logger.Info("Live migration completed", "host", vm.Status.Host)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonHostMigrationSucceeded, message)
+	} else {
+		logger.Info("Live migration failed", "reason", reason, "message", message)
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonHostMigrationFailed, message)
+	}
+	k8s.SetCondition(&vm.Status.Conditions, ConditionHostMigrated, status, reason, message)
+	if err := r.writeStatus(ctx, vm); err != nil {
+		logger.Error(err, "Failed to record live migration result")
+		return
+	}
+	r.clearRequestedHost(ctx, vm)
+}
+
+// clearRequestedHost removes RequestedHostAnnotation from the VM.
+func (r *VirtualMachineReconciler) clearRequestedHost(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	patch := client.MergeFrom(vm.DeepCopy())
+	delete(vm.Annotations, RequestedHostAnnotation)
+	if err := r.Patch(ctx, vm, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to clear requested-host annotation")
+	}
+}
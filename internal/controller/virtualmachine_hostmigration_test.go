@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// migratingStubProvider embeds stubProvider and implements
+// contracts.HostMigrator with a controllable task.
+type migratingStubProvider struct {
+	stubProvider
+	gotID, gotHost string
+	taskRef        string
+	status         contracts.TaskStatus
+}
+
+func (p *migratingStubProvider) MigrateHost(_ context.Context, id, targetHost string) (string, error) {
+	p.gotID, p.gotHost = id, targetHost
+	return p.taskRef, nil
+}
+
+func (p *migratingStubProvider) TaskStatus(_ context.Context, _ string) (contracts.TaskStatus, error) {
+	return p.status, nil
+}
+
+func hostMigrationVM(name, target string) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{RequestedHostAnnotation: target},
+		},
+	}
+	vm.Status.ID = "vm-7"
+	vm.Status.Host = "kvm-a"
+	return vm
+}
+
+func liveMigrationProvider(supported bool) *infravirtrigaudiov1beta1.Provider {
+	return &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "kvm", Namespace: "default"},
+		Status: infravirtrigaudiov1beta1.ProviderStatus{
+			ReportedCapabilities: &infravirtrigaudiov1beta1.ReportedCapabilities{SupportsLiveMigration: supported},
+		},
+	}
+}
+
+// TestReconcileHostMigration_FollowsTask verifies a request starts the
+// migration, the task's progress is surfaced while it runs, and completion
+// records the new host and clears the request.
+func TestReconcileHostMigration_FollowsTask(t *testing.T) {
+	ctx := context.Background()
+	prov := &migratingStubProvider{taskRef: "migrate:vm-7:1"}
+	vm := hostMigrationVM("vm-move", "kvm-b")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+	provider := liveMigrationProvider(true)
+	on := string(infravirtrigaudiov1beta1.PowerStateOn)
+
+	_, acted := r.reconcileHostMigration(ctx, vm, provider, prov, on)
+	require.True(t, acted)
+	assert.Equal(t, "vm-7", prov.gotID)
+	assert.Equal(t, "kvm-b", prov.gotHost)
+	assert.Equal(t, "migrate:vm-7:1", vm.Status.MigrationTaskRef)
+
+	prov.status = contracts.TaskStatus{Message: "migrating to kvm-b: 40% of 8.0 GiB transferred, copying memory"}
+	_, acted = r.reconcileHostMigration(ctx, vm, provider, prov, on)
+	require.True(t, acted)
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionHostMigrated)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonHostMigrationInProgress, cond.Reason)
+	assert.Contains(t, cond.Message, "40%")
+
+	prov.status = contracts.TaskStatus{IsCompleted: true, Message: "running on kvm-b"}
+	_, acted = r.reconcileHostMigration(ctx, vm, provider, prov, on)
+	require.True(t, acted)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.Equal(t, "kvm-b", after.Status.Host)
+	assert.Empty(t, after.Status.MigrationTaskRef)
+	assert.True(t, k8s.IsConditionTrue(after.Status.Conditions, ConditionHostMigrated))
+	assert.NotContains(t, after.Annotations, RequestedHostAnnotation)
+}
+
+// TestReconcileHostMigration_Failed verifies a failed migration keeps the
+// VM's host, reports the error and clears the request.
+func TestReconcileHostMigration_Failed(t *testing.T) {
+	ctx := context.Background()
+	prov := &migratingStubProvider{
+		taskRef: "migrate:vm-7:1",
+		status:  contracts.TaskStatus{IsCompleted: true, Error: "live migration to kvm-b failed; the domain keeps running on kvm-a"},
+	}
+	vm := hostMigrationVM("vm-move-fail", "kvm-b")
+	vm.Status.MigrationTaskRef = "migrate:vm-7:1"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+
+	_, acted := r.reconcileHostMigration(ctx, vm, liveMigrationProvider(true), prov, string(infravirtrigaudiov1beta1.PowerStateOn))
+	require.True(t, acted)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.Equal(t, "kvm-a", after.Status.Host)
+	cond := k8s.GetCondition(after.Status.Conditions, ConditionHostMigrated)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonHostMigrationFailed, cond.Reason)
+	assert.Contains(t, cond.Message, "keeps running on kvm-a")
+	assert.NotContains(t, after.Annotations, RequestedHostAnnotation)
+}
+
+// TestReconcileHostMigration_Refused verifies requests the controller cannot
+// serve are answered without calling the provider.
+func TestReconcileHostMigration_Refused(t *testing.T) {
+	tests := []struct {
+		name       string
+		supported  bool
+		powerState infravirtrigaudiov1beta1.PowerState
+		reason     string
+	}{
+		{"capability not reported", false, infravirtrigaudiov1beta1.PowerStateOn, ReasonHostMigrationNotSupported},
+		{"powered off", true, infravirtrigaudiov1beta1.PowerStateOff, ReasonHostMigrationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			prov := &migratingStubProvider{}
+			vm := hostMigrationVM("vm-refused", "kvm-b")
+			r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+
+			_, acted := r.reconcileHostMigration(ctx, vm, liveMigrationProvider(tt.supported), prov, string(tt.powerState))
+			require.True(t, acted)
+			assert.Empty(t, prov.gotHost)
+
+			var after infravirtrigaudiov1beta1.VirtualMachine
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+			cond := k8s.GetCondition(after.Status.Conditions, ConditionHostMigrated)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.reason, cond.Reason)
+			assert.NotContains(t, after.Annotations, RequestedHostAnnotation)
+		})
+	}
+}
+
+// TestReconcileHostMigration_AlreadyThere verifies a request for the host
+// the VM runs on is dropped and the reconcile carries on.
+func TestReconcileHostMigration_AlreadyThere(t *testing.T) {
+	ctx := context.Background()
+	prov := &migratingStubProvider{}
+	vm := hostMigrationVM("vm-stay", "kvm-a")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm)
+
+	_, acted := r.reconcileHostMigration(ctx, vm, liveMigrationProvider(true), prov, string(infravirtrigaudiov1beta1.PowerStateOn))
+	assert.False(t, acted)
+	assert.Empty(t, prov.gotHost)
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	assert.NotContains(t, after.Annotations, RequestedHostAnnotation)
+}
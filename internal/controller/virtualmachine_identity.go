@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// AcceptIdentityAnnotation, set on a VirtualMachine whose IdentityMismatch
+// condition is True, makes the provider VM now at status.id this
+// VirtualMachine's VM: its marker is recorded in status.identity and the
+// annotation removed. The other way out is clearing status.id, which
+// creates a new VM.
+const AcceptIdentityAnnotation = "virtrigaud.io/accept-identity"
+
+// Reasons for the IdentityMismatch condition.
+const (
+	ReasonIdentityMismatch = "IdentityMismatch"
+	ReasonIdentityVerified = "IdentityVerified"
+)
+
+// identityMismatchRecheck is how often a VM held by an identity mismatch
+// describes its provider VM again. Setting AcceptIdentityAnnotation
+// requeues it at once; clearing status.id waits for the recheck.
+const identityMismatchRecheck = time.Minute
+
+// identityAcceptRequested reports whether vm carries AcceptIdentityAnnotation.
+func identityAcceptRequested(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	_, ok := vm.Annotations[AcceptIdentityAnnotation]
+	return ok
+}
+
+// identityMismatched reports whether vm's status.id was last found to name
+// a provider VM with another identity marker. Nothing may act on that VM.
+func identityMismatched(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return k8s.IsConditionTrue(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch)
+}
+
+// forgetIdentity drops what was verified about the VM at vm.Status.ID,
+// which no longer names this VirtualMachine's VM: it is being created or
+// adopted anew.
+func forgetIdentity(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	vm.Status.Identity = ""
+	meta.RemoveStatusCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch)
+}
+
+// identityProblem compares the identity marker the provider reports for the
+// VM at vm.Status.ID with the one recorded in vm.Status.Identity, and
+// describes the mismatch, if any. Without a recorded marker, a VM carrying
+// none (made before markers, or by an older provider) is accepted, and so
+// is any marker on an adopted VM; otherwise the marker must be the
+// VirtualMachine's UID, which createVM passes as the idempotency key.
+func identityProblem(vm *infravirtrigaudiov1beta1.VirtualMachine, observed string) string {
+	recorded := vm.Status.Identity
+	switch {
+	case recorded != "":
+		if observed == recorded {
+			return ""
+		}
+		if observed == "" {
+			return fmt.Sprintf("Provider VM %s carries no identity marker, want %q; it was likely recreated outside virtrigaud",
+				vm.Status.ID, recorded)
+		}
+		return fmt.Sprintf("Provider VM %s carries identity marker %q, want %q; the ID now names another VM",
+			vm.Status.ID, observed, recorded)
+	case observed == "", observed == string(vm.UID), adoptsExisting(vm), vmIsAdopted(vm):
+		return ""
+	default:
+		return fmt.Sprintf("Provider VM %s carries identity marker %q of another VirtualMachine; the ID now names another VM",
+			vm.Status.ID, observed)
+	}
+}
+
+// verifyIdentity checks the marker observed on the VM at vm.Status.ID and
+// reports whether the VM may be acted on. A matching marker is recorded in
+// status.identity. On a mismatch the IdentityMismatch condition is set and
+// the status written, unless AcceptIdentityAnnotation is present, which
+// records the observed marker instead.
+func (r *VirtualMachineReconciler) verifyIdentity(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, observed string) bool {
+	logger := log.FromContext(ctx)
+
+	problem := identityProblem(vm, observed)
+	if problem != "" {
+		if !identityAcceptRequested(vm) {
+			if !identityMismatched(vm) {
+				logger.Info("Provider VM identity does not match; not acting on it", "id", vm.Status.ID,
+					"recorded", vm.Status.Identity, "observed", observed)
+				r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonIdentityMismatch, problem)
+			}
+			k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch,
+				metav1.ConditionTrue, ReasonIdentityMismatch, problem)
+			k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonIdentityMismatch, problem)
+			r.updateStatus(ctx, vm)
+			return false
+		}
+
+		patch := client.MergeFrom(vm.DeepCopy())
+		delete(vm.Annotations, AcceptIdentityAnnotation)
+		if err := r.Patch(ctx, vm, patch); err != nil {
+			logger.Error(err, "Failed to clear accept-identity annotation")
+			return false
+		}
+		vm.Status.Identity = observed
+		logger.Info("Accepting provider VM identity", "id", vm.Status.ID, "identity", observed)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonIdentityAccepted,
+			fmt.Sprintf("Provider VM %s accepted with identity marker %q", vm.Status.ID, observed))
+	}
+
+	if observed != "" {
+		vm.Status.Identity = observed
+	}
+	if k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch) != nil {
+		k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch,
+			metav1.ConditionFalse, ReasonIdentityVerified, fmt.Sprintf("Provider VM %s carries the recorded identity marker", vm.Status.ID))
+	}
+	return true
+}
+
+// deletionIdentityProblem describes the VM at vm.Status.ID if it carries
+// another identity marker, so deletion leaves it alone. A VM that cannot be
+// described is deleted as before.
+func (r *VirtualMachineReconciler) deletionIdentityProblem(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, provider contracts.Provider) string {
+	desc, err := provider.Describe(ctx, vm.Status.ID)
+	if err != nil || !desc.Exists {
+		return ""
+	}
+	return identityProblem(vm, desc.Identity)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// markedProvider describes a powered-off VM carrying identity and counts
+// the power and delete calls made against it.
+type markedProvider struct {
+	stubProvider
+	identity string
+	powers   int
+	deletes  int
+}
+
+func (p *markedProvider) Describe(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+	return contracts.DescribeResponse{Exists: true, PowerState: "Off", Identity: p.identity}, nil
+}
+
+func (p *markedProvider) Power(_ context.Context, _ string, _ contracts.PowerOp) (string, error) {
+	p.powers++
+	return "", nil
+}
+
+func (p *markedProvider) Delete(_ context.Context, _ string) (string, error) {
+	p.deletes++
+	return "", nil
+}
+
+func TestIdentityProblem(t *testing.T) {
+	vm := baseVM("default")
+	vm.UID = "uid-1"
+	vm.Status.ID = "101"
+
+	assert.Empty(t, identityProblem(vm, ""), "a VM without a marker predates markers")
+	assert.Empty(t, identityProblem(vm, "uid-1"))
+	assert.Contains(t, identityProblem(vm, "uid-2"), `marker "uid-2" of another VirtualMachine`)
+
+	vm.Status.Identity = "uid-1"
+	assert.Empty(t, identityProblem(vm, "uid-1"))
+	assert.Contains(t, identityProblem(vm, ""), "carries no identity marker")
+	assert.Contains(t, identityProblem(vm, "uid-2"), `want "uid-1"`)
+
+	// An adopted VM keeps whatever marker it had when adopted.
+	vm.Status.Identity = ""
+	vm.Labels = map[string]string{AdoptedLabel: AdoptedLabelValue}
+	assert.Empty(t, identityProblem(vm, "clone-uid"))
+	vm.Status.Identity = "clone-uid"
+	assert.NotEmpty(t, identityProblem(vm, "uid-2"))
+}
+
+func TestReconcileVM_IdentityMismatchHoldsVM(t *testing.T) {
+	ctx := context.Background()
+	prov := &markedProvider{identity: "uid-2"}
+	k8sProv, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.UID = "uid-1"
+	vm.Status.ID = "101"
+	vm.Status.Identity = "uid-1"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, k8sProv, class, vm)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	res, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, identityMismatchRecheck, res.RequeueAfter)
+	assert.Zero(t, prov.powers, "a VM with another identity is not powered on")
+	assert.True(t, identityMismatched(vm))
+	assert.True(t, k8s.IsConditionFalse(vm.Status.Conditions, k8s.ConditionReady))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonIdentityMismatch)
+
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Zero(t, prov.powers)
+	assert.Empty(t, recorder.Events, "the mismatch is reported once")
+
+	// Accepting the VM now at the ID resumes reconciliation.
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), vm))
+	vm.Annotations = map[string]string{AcceptIdentityAnnotation: "true"}
+	require.NoError(t, r.Update(ctx, vm))
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, prov.powers)
+	assert.Equal(t, "uid-2", vm.Status.Identity)
+	assert.False(t, identityMismatched(vm))
+	assert.Contains(t, <-recorder.Events, events.ReasonIdentityAccepted)
+
+	var stored infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &stored))
+	assert.NotContains(t, stored.Annotations, AcceptIdentityAnnotation)
+}
+
+func TestReconcileVM_RecordsIdentity(t *testing.T) {
+	ctx := context.Background()
+	prov := &markedProvider{}
+	k8sProv, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.UID = "uid-1"
+	vm.Status.ID = "101"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, k8sProv, class, vm)
+
+	// A VM made before markers is acted on and nothing is recorded.
+	_, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, prov.powers)
+	assert.Empty(t, vm.Status.Identity)
+
+	prov.identity = "uid-1"
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, "uid-1", vm.Status.Identity)
+	assert.Nil(t, k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch))
+}
+
+func TestHandleDeletion_LeavesVMWithAnotherIdentity(t *testing.T) {
+	ctx := context.Background()
+	prov := &markedProvider{identity: "uid-2"}
+	vm := deletionVM("vm-reused")
+	vm.UID = "uid-1"
+	vm.Status.Identity = "uid-1"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked := markForDeletion(t, r, vm)
+
+	_, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Zero(t, prov.deletes, "the ID was reused since the last reconcile")
+	err = r.Get(ctx, client.ObjectKeyFromObject(vm), &infravirtrigaudiov1beta1.VirtualMachine{})
+	assert.True(t, apierrors.IsNotFound(err), "the finalizer is released")
+
+	// A mismatch already on record needs no Describe.
+	prov = &markedProvider{identity: "uid-1"}
+	vm = deletionVM("vm-held")
+	vm.UID = "uid-1"
+	vm.Status.Identity = "uid-1"
+	k8s.SetCondition(&vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionIdentityMismatch,
+		metav1.ConditionTrue, ReasonIdentityMismatch, "reused")
+	r = newTestReconciler(coverageTestScheme(t), &stubResolver{provider: prov}, vm, deletionProviderCR())
+	marked = markForDeletion(t, r, vm)
+
+	_, err = r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Zero(t, prov.deletes)
+}
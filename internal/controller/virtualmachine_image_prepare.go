@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
@@ -93,7 +94,8 @@ func (r *VirtualMachineReconciler) EnsureImageOnProvider(
 
 	// Skip: nothing to prepare. ImportedDisk VMs (vm.Spec.ImageRef == nil) carry
 	// their own disk and never reference a VMImage; a nil vmImage means the same.
-	if vm.Spec.ImageRef == nil || vmImage == nil {
+	// An adopted VM already exists, so there is nothing to build from the image.
+	if vm.Spec.ImageRef == nil || vmImage == nil || adoptsExisting(vm) {
 		return false, nil
 	}
 
@@ -110,7 +112,7 @@ func (r *VirtualMachineReconciler) EnsureImageOnProvider(
 			"provider", provider.Name, "image", vmImage.Name)
 		return false, nil
 	}
-	if !providerAdvertisesImageImport(provider) {
+	if !ProviderAdvertisesImageImport(provider) {
 		logger.V(1).Info("Provider does not advertise SupportsImageImport; skipping image prepare",
 			"provider", provider.Name, "image", vmImage.Name)
 		return false, nil
@@ -135,7 +137,7 @@ func (r *VirtualMachineReconciler) EnsureImageOnProvider(
 	// overrideImageWithPreparedLocation's fallback — and a wrong path/template
 	// still fails honestly at create time, which is where a missing backing
 	// artifact belongs.
-	if !imageSourceNeedsPrepare(vmImage) {
+	if !ImageSourceNeedsPrepare(vmImage) {
 		logger.V(1).Info("Image source is already present on the provider (no import needed); proceeding to create",
 			"provider", provider.Name, "image", vmImage.Name)
 		return false, nil
@@ -284,14 +286,14 @@ func (r *VirtualMachineReconciler) EnsureImageOnProvider(
 	return false, nil
 }
 
-// providerAdvertisesImageImport reports whether the Provider CR advertises the
+// ProviderAdvertisesImageImport reports whether the Provider CR advertises the
 // SupportsImageImport capability via its self-reported capabilities
 // (Status.ReportedCapabilities, surfaced from GetCapabilities by issue #176). A
 // nil ReportedCapabilities (provider has not reported yet, or runs an older
 // provider) reads as false, which makes EnsureImageOnProvider fall through to
 // the unchanged by-reference create path — fail-safe, not fail-open into a
 // possibly-Unimplemented RPC.
-func providerAdvertisesImageImport(provider *infravirtrigaudiov1beta1.Provider) bool {
+func ProviderAdvertisesImageImport(provider *infravirtrigaudiov1beta1.Provider) bool {
 	caps := provider.Status.ReportedCapabilities
 	return caps != nil && caps.SupportsImageImport
 }
@@ -306,7 +308,7 @@ func imageMissingAction(vmImage *infravirtrigaudiov1beta1.VMImage) infravirtriga
 	return vmImage.Spec.Prepare.OnMissing
 }
 
-// imageSourceNeedsPrepare reports whether the VMImage's source must be imported
+// ImageSourceNeedsPrepare reports whether the VMImage's source must be imported
 // onto the provider before a VM can use it.
 //
 // Import-style sources produce a NEW artifact on the provider and DO need
@@ -325,7 +327,7 @@ func imageMissingAction(vmImage *infravirtrigaudiov1beta1.VMImage) infravirtriga
 // prepare over silently skipping a real import): a libvirt source carrying BOTH a
 // path and a URL, an HTTP/Registry/DataVolume source, or an entirely empty
 // source.
-func imageSourceNeedsPrepare(vmImage *infravirtrigaudiov1beta1.VMImage) bool {
+func ImageSourceNeedsPrepare(vmImage *infravirtrigaudiov1beta1.VMImage) bool {
 	src := vmImage.Spec.Source
 	switch {
 	case src.Libvirt != nil:
@@ -369,7 +371,19 @@ func (r *VirtualMachineReconciler) markImagePreparing(
 	})
 }
 
-// markImagePrepared stamps a completed prepare for providerName: it records the
+// markImagePrepared stamps a completed prepare for providerName; see
+// RecordImagePrepared.
+func (r *VirtualMachineReconciler) markImagePrepared(
+	ctx context.Context,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+	providerName string,
+	id string,
+	path string,
+) error {
+	return RecordImagePrepared(ctx, r.Client, vmImage, providerName, id, path)
+}
+
+// RecordImagePrepared stamps a completed prepare for providerName: it records the
 // per-provider ProviderStatus entry (including the prepared image's location —
 // id/path — so create can consume it instead of re-resolving the source, issue
 // #154 PR-6 / #214), adds providerName to AvailableOn (deduped), clears the
@@ -381,14 +395,19 @@ func (r *VirtualMachineReconciler) markImagePreparing(
 // are known at trigger time even for async prepares; an empty id/path here
 // preserves whatever was already stamped (e.g. by the async trigger), so the
 // task-completion poll path can call this without the original response in hand.
-func (r *VirtualMachineReconciler) markImagePrepared(
+//
+// Besides the VirtualMachine controller, `vrtg image prepare` calls this once an
+// out-of-band prepare finishes, which is what releases VMs held by
+// Prepare.OnMissing=Wait. It goes through the same conflict-safe write.
+func RecordImagePrepared(
 	ctx context.Context,
+	c client.Client,
 	vmImage *infravirtrigaudiov1beta1.VMImage,
 	providerName string,
 	id string,
 	path string,
 ) error {
-	return r.writeImageStatus(ctx, vmImage, func(img *infravirtrigaudiov1beta1.VMImage) {
+	return updateImageStatus(ctx, c, vmImage, func(img *infravirtrigaudiov1beta1.VMImage) {
 		if img.Status.ProviderStatus == nil {
 			img.Status.ProviderStatus = map[string]infravirtrigaudiov1beta1.ProviderImageStatus{}
 		}
@@ -444,15 +463,25 @@ func (r *VirtualMachineReconciler) writeImageStatus(
 	ctx context.Context,
 	vmImage *infravirtrigaudiov1beta1.VMImage,
 	mutate func(*infravirtrigaudiov1beta1.VMImage),
+) error {
+	return updateImageStatus(ctx, r.Client, vmImage, mutate)
+}
+
+// updateImageStatus is writeImageStatus for any client.
+func updateImageStatus(
+	ctx context.Context,
+	c client.Client,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+	mutate func(*infravirtrigaudiov1beta1.VMImage),
 ) error {
 	key := types.NamespacedName{Name: vmImage.Name, Namespace: vmImage.Namespace}
 	latest := &infravirtrigaudiov1beta1.VMImage{}
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		if getErr := r.Get(ctx, key, latest); getErr != nil {
+		if getErr := c.Get(ctx, key, latest); getErr != nil {
 			return getErr
 		}
 		mutate(latest)
-		return r.Status().Update(ctx, latest)
+		return c.Status().Update(ctx, latest)
 	}); err != nil {
 		return fmt.Errorf("update VMImage %s status: %w", vmImage.Name, err)
 	}
@@ -461,6 +461,20 @@ func TestOverrideImageWithPreparedLocation_Consume(t *testing.T) {
 		assert.Empty(t, image.URL, "OVA URL cleared so Create clones the prepared template")
 	})
 
+	t.Run("vsphere content library keeps the library item", func(t *testing.T) {
+		ref := &infrav1beta1.ContentLibraryRef{Library: "golden", Item: "jammy"}
+		img := imageWithProviderStatus(
+			infrav1beta1.ImageSource{VSphere: &infrav1beta1.VSphereImageSource{ContentLibrary: ref}},
+			provider,
+			infrav1beta1.ProviderImageStatus{Available: true, ID: "c6a1f2f4-item-id"},
+		)
+		image := contracts.VMImage{ContentLibrary: &contracts.ContentLibraryItem{Library: "golden", Item: "jammy"}}
+		overrode, _ := overrideImageWithPreparedLocation(&image, img, provider)
+		assert.False(t, overrode)
+		assert.Empty(t, image.TemplateName, "the item ID is not a template name")
+		assert.Equal(t, "jammy", image.ContentLibrary.Item)
+	})
+
 	t.Run("proxmox uses prepared template ref", func(t *testing.T) {
 		img := imageWithProviderStatus(
 			infrav1beta1.ImageSource{Proxmox: &infrav1beta1.ProxmoxImageSource{}},
@@ -548,7 +562,7 @@ func TestImageSourceNeedsPrepare(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			img := &infrav1beta1.VMImage{Spec: infrav1beta1.VMImageSpec{Source: tc.src}}
-			assert.Equal(t, tc.want, imageSourceNeedsPrepare(img))
+			assert.Equal(t, tc.want, ImageSourceNeedsPrepare(img))
 		})
 	}
 }
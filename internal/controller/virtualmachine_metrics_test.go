@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -80,6 +81,7 @@ func labelsMatch(got []*dto.LabelPair, want map[string]string) bool {
 func newMetricsScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
 	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
 	require.NoError(t, infravirtrigaudiov1beta1.AddToScheme(sch))
 	return sch
 }
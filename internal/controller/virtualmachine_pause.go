@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// ConditionReconciliationPaused is True while a VM is paused.
+const ConditionReconciliationPaused = "ReconciliationPaused"
+
+// Reasons for the ReconciliationPaused condition.
+const (
+	ReasonVMPaused        = "VMPaused"
+	ReasonNamespacePaused = "NamespacePaused"
+	ReasonResumed         = "Resumed"
+)
+
+// isPaused reports whether obj carries the pause annotation.
+func isPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[infravirtrigaudiov1beta1.PausedAnnotation] == "true"
+}
+
+// pauseReason returns the ReconciliationPaused reason when vm is paused,
+// either directly or through its namespace, and "" otherwise. A namespace
+// that cannot be read does not pause the VM.
+func (r *VirtualMachineReconciler) pauseReason(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (string, error) {
+	if isPaused(vm) {
+		return ReasonVMPaused, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: vm.Namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if isPaused(ns) {
+		return ReasonNamespacePaused, nil
+	}
+	return "", nil
+}
+
+// reconcilePause records the pause state of vm on its status and reports
+// whether reconciliation must stop here. Events are emitted only when the
+// state flips, so repeated reconciles of a paused VM stay quiet.
+func (r *VirtualMachineReconciler) reconcilePause(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (bool, error) {
+	reason, err := r.pauseReason(ctx, vm)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pause state: %w", err)
+	}
+	name := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name}
+	wasPaused := k8s.IsConditionTrue(vm.Status.Conditions, ConditionReconciliationPaused)
+
+	if reason == "" {
+		pausedVMs.remove(name)
+		if wasPaused {
+			k8s.SetCondition(&vm.Status.Conditions, ConditionReconciliationPaused, metav1.ConditionFalse,
+				ReasonResumed, "Reconciliation resumed")
+			r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonReconciliationResumed, "Reconciliation resumed")
+		}
+		return false, nil
+	}
+
+	pausedVMs.add(name)
+	message := fmt.Sprintf("VirtualMachine is annotated %s=true", infravirtrigaudiov1beta1.PausedAnnotation)
+	if reason == ReasonNamespacePaused {
+		message = fmt.Sprintf("Namespace %s is annotated %s=true", vm.Namespace, infravirtrigaudiov1beta1.PausedAnnotation)
+	}
+	cond := k8s.GetCondition(vm.Status.Conditions, ConditionReconciliationPaused)
+	if wasPaused && cond.Reason == reason {
+		return true, nil
+	}
+	k8s.SetCondition(&vm.Status.Conditions, ConditionReconciliationPaused, metav1.ConditionTrue, reason, message)
+	if !wasPaused {
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonReconciliationPaused, message)
+	}
+	r.updateStatus(ctx, vm)
+	return true, nil
+}
+
+// recordEvent emits an event carrying the VM's phase when the reconciler
+// has a recorder.
+func (r *VirtualMachineReconciler) recordEvent(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, eventType, reason, message string) {
+	events.Emit(ctx, r.Recorder, vm, eventType, reason, string(vm.Status.Phase), message)
+}
+
+// vmsForNamespace enqueues every VM in a namespace whose pause annotation
+// changed, so resuming a namespace takes effect immediately.
+func (r *VirtualMachineReconciler) vmsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	vms := &infravirtrigaudiov1beta1.VirtualMachineList{}
+	if err := r.List(ctx, vms, client.InNamespace(obj.GetName())); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(vms.Items))
+	for _, vm := range vms.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name},
+		})
+	}
+	return requests
+}
+
+// pauseTracker counts paused VMs per namespace for the paused-VM gauge.
+type pauseTracker struct {
+	mu  sync.Mutex
+	vms map[types.NamespacedName]struct{}
+}
+
+func newPauseTracker() *pauseTracker {
+	return &pauseTracker{vms: make(map[types.NamespacedName]struct{})}
+}
+
+// pausedVMs is shared by all VirtualMachine reconcilers, as the gauge it
+// drives is process-wide.
+var pausedVMs = newPauseTracker()
+
+func (t *pauseTracker) add(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.vms[name]; ok {
+		return
+	}
+	t.vms[name] = struct{}{}
+	t.publish(name.Namespace)
+}
+
+func (t *pauseTracker) remove(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.vms[name]; !ok {
+		return
+	}
+	delete(t.vms, name)
+	t.publish(name.Namespace)
+}
+
+// publish writes the paused count of a namespace. Callers hold t.mu.
+func (t *pauseTracker) publish(namespace string) {
+	count := 0
+	for name := range t.vms {
+		if name.Namespace == namespace {
+			count++
+		}
+	}
+	if count == 0 {
+		metrics.DeletePausedVMs(namespace)
+		return
+	}
+	metrics.SetPausedVMs(namespace, float64(count))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func pausedTestVM(namespace string) *infravirtrigaudiov1beta1.VirtualMachine {
+	return &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "web",
+			Namespace:  namespace,
+			Finalizers: []string{infravirtrigaudiov1beta1.VirtualMachineFinalizer},
+		},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "missing"},
+			ClassRef:    infravirtrigaudiov1beta1.ObjectRef{Name: "missing"},
+		},
+	}
+}
+
+// pausedGauge returns the paused-VM gauge of a namespace, and false when
+// the series does not exist.
+func pausedGauge(t *testing.T, namespace string) (float64, bool) {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != "virtrigaud_vm_paused" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == "namespace" && lp.GetValue() == namespace {
+					return m.GetGauge().GetValue(), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestReconcile_PausedVM(t *testing.T) {
+	sch := newMetricsScheme(t)
+	vm := pausedTestVM("pause-vm")
+	vm.Annotations = map[string]string{infravirtrigaudiov1beta1.PausedAnnotation: "true"}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &VirtualMachineReconciler{Client: cli, Scheme: sch, Recorder: recorder}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "web", Namespace: "pause-vm"}
+
+	// Paused: no requeue and no dependency lookups, which would otherwise
+	// mark the VM as waiting for its missing provider.
+	for range 2 {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	}
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, cli.Get(ctx, key, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionReconciliationPaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, ReasonVMPaused, cond.Reason)
+	assert.Nil(t, meta.FindStatusCondition(got.Status.Conditions, k8s.ConditionReady))
+	assert.Len(t, recorder.Events, 1, "the pause event is emitted once, on transition")
+	assert.Contains(t, <-recorder.Events, "ReconciliationPaused")
+
+	v, ok := pausedGauge(t, "pause-vm")
+	require.True(t, ok)
+	assert.Equal(t, float64(1), v)
+
+	// Resuming runs the normal reconcile straight away.
+	delete(got.Annotations, infravirtrigaudiov1beta1.PausedAnnotation)
+	require.NoError(t, cli.Update(ctx, got))
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter, "missing dependencies are now reported")
+
+	require.NoError(t, cli.Get(ctx, key, got))
+	cond = meta.FindStatusCondition(got.Status.Conditions, ConditionReconciliationPaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, ReasonResumed, cond.Reason)
+	assert.Contains(t, <-recorder.Events, "ReconciliationResumed")
+	_, ok = pausedGauge(t, "pause-vm")
+	assert.False(t, ok)
+}
+
+func TestReconcile_PausedNamespace(t *testing.T) {
+	sch := newMetricsScheme(t)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "pause-ns",
+		Annotations: map[string]string{infravirtrigaudiov1beta1.PausedAnnotation: "true"},
+	}}
+	vm := pausedTestVM("pause-ns")
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(ns, vm).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		Build()
+	r := &VirtualMachineReconciler{Client: cli, Scheme: sch}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "web", Namespace: "pause-ns"}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, cli.Get(ctx, key, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionReconciliationPaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonNamespacePaused, cond.Reason)
+
+	// A namespace change maps to every VM inside it.
+	requests := r.vmsForNamespace(ctx, ns)
+	assert.Equal(t, []ctrl.Request{{NamespacedName: key}}, requests)
+}
+
+// TestReconcile_PausedVMCanBeDeleted guards against a paused VM wedging on
+// its finalizer.
+func TestReconcile_PausedVMCanBeDeleted(t *testing.T) {
+	sch := newMetricsScheme(t)
+	vm := pausedTestVM("pause-delete")
+	vm.Annotations = map[string]string{infravirtrigaudiov1beta1.PausedAnnotation: "true"}
+	now := metav1.Now()
+	vm.DeletionTimestamp = &now
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		Build()
+	r := &VirtualMachineReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Name: "web", Namespace: "pause-delete"}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	err = cli.Get(context.Background(), key, &infravirtrigaudiov1beta1.VirtualMachine{})
+	assert.True(t, errors.IsNotFound(err), "finalizer should have been removed, got %v", err)
+}
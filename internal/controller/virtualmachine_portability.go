@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// ConditionProviderFieldsSupported reports whether the VM's provider honors
+// every field of its class and image. It is a warning only: a False
+// condition does not hold the VM back, although a create with an
+// unsupported field is expected to fail.
+const ConditionProviderFieldsSupported = "ProviderFieldsSupported"
+
+// Reasons for the ProviderFieldsSupported condition.
+const (
+	ReasonFieldsSupported   = "FieldsSupported"
+	ReasonFieldsIgnored     = "FieldsIgnored"
+	ReasonFieldsUnsupported = "FieldsUnsupported"
+)
+
+// checkPortability records on the ProviderFieldsSupported condition the
+// class and image fields provider ignores or cannot create a VM with. The
+// warning event is emitted when the condition turns False, or its list of
+// fields changes, so that rebinding the VM to another class or provider is
+// reported again.
+func (r *VirtualMachineReconciler) checkPortability(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+) {
+	var image *infravirtrigaudiov1beta1.VMImageSpec
+	if vmImage != nil {
+		image = &vmImage.Spec
+	}
+	issues := capability.CheckProvider(&vmClass.Spec, image, provider)
+	if len(issues) == 0 {
+		k8s.SetCondition(&vm.Status.Conditions, ConditionProviderFieldsSupported, metav1.ConditionTrue,
+			ReasonFieldsSupported, fmt.Sprintf("Provider %s honors every field of the class and image", provider.Name))
+		return
+	}
+
+	reason := ReasonFieldsIgnored
+	if capability.HasUnsupported(issues) {
+		reason = ReasonFieldsUnsupported
+	}
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+	message := fmt.Sprintf("Provider %s (%s) does not honor %s", provider.Name, provider.Spec.Type, strings.Join(lines, "; "))
+
+	if c := k8s.GetCondition(vm.Status.Conditions, ConditionProviderFieldsSupported); c == nil || c.Message != message {
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonProviderFieldsIgnored, message)
+	}
+	k8s.SetCondition(&vm.Status.Conditions, ConditionProviderFieldsSupported, metav1.ConditionFalse, reason, message)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func TestCheckPortability(t *testing.T) {
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(4)
+	r := &VirtualMachineReconciler{Recorder: recorder}
+	vm := baseVM("default")
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.ProviderSpec{Type: infravirtrigaudiov1beta1.ProviderTypeProxmox},
+	}
+	class := &infravirtrigaudiov1beta1.VMClass{Spec: infravirtrigaudiov1beta1.VMClassSpec{
+		DiskDefaults: &infravirtrigaudiov1beta1.DiskDefaults{Type: infravirtrigaudiov1beta1.DiskTypeEagerZeroedThick},
+	}}
+	image := &infravirtrigaudiov1beta1.VMImage{Spec: infravirtrigaudiov1beta1.VMImageSpec{
+		Source: infravirtrigaudiov1beta1.ImageSource{Proxmox: &infravirtrigaudiov1beta1.ProxmoxImageSource{TemplateName: "ubuntu"}},
+	}}
+
+	r.checkPortability(ctx, vm, provider, class, image)
+	c := k8s.GetCondition(vm.Status.Conditions, ConditionProviderFieldsSupported)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, ReasonFieldsIgnored, c.Reason)
+	assert.Contains(t, c.Message, "VMClass spec.diskDefaults.type (thick, eagerzeroedthick): ignored by proxmox providers")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonProviderFieldsIgnored)
+
+	r.checkPortability(ctx, vm, provider, class, image)
+	assert.Empty(t, recorder.Events, "the warning is emitted when the fields change, not on every reconcile")
+
+	image.Spec.Source = infravirtrigaudiov1beta1.ImageSource{VSphere: &infravirtrigaudiov1beta1.VSphereImageSource{TemplateName: "ubuntu"}}
+	r.checkPortability(ctx, vm, provider, class, image)
+	c = k8s.GetCondition(vm.Status.Conditions, ConditionProviderFieldsSupported)
+	assert.Equal(t, ReasonFieldsUnsupported, c.Reason)
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	provider.Spec.Type = infravirtrigaudiov1beta1.ProviderTypeVSphere
+	r.checkPortability(ctx, vm, provider, class, nil)
+	c = k8s.GetCondition(vm.Status.Conditions, ConditionProviderFieldsSupported)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Empty(t, recorder.Events)
+}
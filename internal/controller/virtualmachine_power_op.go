@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// powerOpPollInterval is how often an in-flight power operation task is
+// polled.
+const powerOpPollInterval = 5 * time.Second
+
+// powerOpPending reports whether spec.powerOpRequest asks for an operation
+// that has not been started yet.
+func powerOpPending(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	req := vm.Spec.PowerOpRequest
+	if req == nil || req.RequestID == "" {
+		return false
+	}
+	last := vm.Status.LastPowerOp
+	return last == nil || last.RequestID != req.RequestID
+}
+
+// contractPowerOp maps a requested operation to the provider power op.
+func contractPowerOp(op infravirtrigaudiov1beta1.PowerOpType) (contracts.PowerOp, bool) {
+	switch op {
+	case infravirtrigaudiov1beta1.PowerOpRestart:
+		return contracts.PowerOpReboot, true
+	case infravirtrigaudiov1beta1.PowerOpHardReset:
+		return contracts.PowerOpReset, true
+	default:
+		return "", false
+	}
+}
+
+// reconcilePowerOpRequest performs spec.powerOpRequest, or follows the one
+// already in flight, and reports whether it acted; the caller returns the
+// result when it did. A request waits while the VM is being powered on, so a
+// restart asked for during creation happens once the VM is up.
+//
+// The in-progress entry is written to status before the provider is called,
+// which is what makes the operation happen at most once per request ID: a
+// reconcile that cannot record the attempt does not make it, and one that
+// finds an attempt without an outcome reports it rather than retrying.
+func (r *VirtualMachineReconciler) reconcilePowerOpRequest(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+	currentPowerState string,
+) (ctrl.Result, bool) {
+	logger := log.FromContext(ctx)
+
+	if last := vm.Status.LastPowerOp; last != nil && last.Result == infravirtrigaudiov1beta1.PowerOpResultInProgress {
+		return r.followPowerOp(ctx, vm, provider), true
+	}
+	if !powerOpPending(vm) {
+		return ctrl.Result{}, false
+	}
+	req := vm.Spec.PowerOpRequest
+
+	desired := vm.Spec.PowerState
+	if desired == "" {
+		desired = infravirtrigaudiov1beta1.PowerStateOn
+	}
+	if desired != infravirtrigaudiov1beta1.PowerStateOn {
+		vm.Status.LastPowerOp = &infravirtrigaudiov1beta1.PowerOpStatus{Op: req.Op, RequestID: req.RequestID}
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed,
+			fmt.Sprintf("VM is not meant to be running (spec.powerState is %s)", desired))
+		return ctrl.Result{Requeue: true}, true
+	}
+	if currentPowerState != string(infravirtrigaudiov1beta1.PowerStateOn) {
+		return ctrl.Result{}, false
+	}
+
+	op, ok := contractPowerOp(req.Op)
+	if !ok {
+		vm.Status.LastPowerOp = &infravirtrigaudiov1beta1.PowerOpStatus{Op: req.Op, RequestID: req.RequestID}
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed,
+			fmt.Sprintf("unsupported power operation %q", req.Op))
+		return ctrl.Result{Requeue: true}, true
+	}
+	if op == contracts.PowerOpReset {
+		if reason, unsupported := unsupportedProtocolFeature(ctx, provider, contracts.FeatureHardReset); unsupported {
+			vm.Status.LastPowerOp = &infravirtrigaudiov1beta1.PowerOpStatus{Op: req.Op, RequestID: req.RequestID}
+			r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed, reason)
+			return ctrl.Result{Requeue: true}, true
+		}
+	}
+
+	vm.Status.LastPowerOp = &infravirtrigaudiov1beta1.PowerOpStatus{
+		Op:        req.Op,
+		RequestID: req.RequestID,
+		Result:    infravirtrigaudiov1beta1.PowerOpResultInProgress,
+	}
+	vm.Status.ObservedGeneration = vm.Generation
+	if err := r.writeStatus(ctx, vm); err != nil {
+		logger.Error(err, "Failed to record power operation; not performing it yet", "op", req.Op, "requestID", req.RequestID)
+		return ctrl.Result{RequeueAfter: powerOpPollInterval}, true
+	}
+
+	logger.Info("Performing requested power operation", "op", req.Op, "requestID", req.RequestID)
+	taskRef, err := provider.Power(ctx, vm.Status.ID, op)
+	switch {
+	case err != nil:
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed, err.Error())
+	case taskRef != "":
+		vm.Status.LastPowerOp.TaskRef = taskRef
+		r.updateStatus(ctx, vm)
+	default:
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultSucceeded, "")
+	}
+	return ctrl.Result{RequeueAfter: powerOpPollInterval}, true
+}
+
+// followPowerOp polls the task of an in-flight power operation. An entry
+// without a task was interrupted between being recorded and the provider
+// answering, so whether it ran is unknown; it is failed rather than re-sent.
+func (r *VirtualMachineReconciler) followPowerOp(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+	last := vm.Status.LastPowerOp
+
+	if last.TaskRef == "" {
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed,
+			"interrupted before the provider confirmed the operation; it may or may not have been performed")
+		return ctrl.Result{Requeue: true}
+	}
+
+	status, err := provider.TaskStatus(ctx, last.TaskRef)
+	if err != nil {
+		logger.Error(err, "Failed to check power operation task", "taskRef", last.TaskRef)
+		return ctrl.Result{RequeueAfter: powerOpPollInterval}
+	}
+	if !status.IsCompleted {
+		logger.V(1).Info("Power operation still in progress", "op", last.Op, "taskRef", last.TaskRef)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: powerOpPollInterval}
+	}
+
+	if status.Error != "" {
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultFailed, status.Error)
+	} else {
+		r.finishPowerOp(ctx, vm, infravirtrigaudiov1beta1.PowerOpResultSucceeded, "")
+	}
+	return ctrl.Result{Requeue: true}
+}
+
+// finishPowerOp records the outcome of vm.Status.LastPowerOp and then clears
+// spec.powerOpRequest. The status is written first: the request ID it holds
+// is what keeps the operation from repeating if clearing the spec fails, or
+// if the same request is applied again later.
+func (r *VirtualMachineReconciler) finishPowerOp(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	result infravirtrigaudiov1beta1.PowerOpResult,
+	message string,
+) {
+	logger := log.FromContext(ctx)
+	last := vm.Status.LastPowerOp
+
+	now := metav1.Now()
+	last.Result = result
+	last.Message = message
+	last.TaskRef = ""
+	last.CompletedAt = &now
+	if result == infravirtrigaudiov1beta1.PowerOpResultSucceeded {
+		logger.Info("Power operation completed", "op", last.Op, "requestID", last.RequestID)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonPowerOpSucceeded, fmt.Sprintf("%s completed", last.Op))
+	} else {
+		logger.Info("Power operation failed", "op", last.Op, "requestID", last.RequestID, "message", message)
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonPowerOpFailed, fmt.Sprintf("%s failed: %s", last.Op, message))
+	}
+	if err := r.writeStatus(ctx, vm); err != nil {
+		logger.Error(err, "Failed to record power operation result")
+		return
+	}
+
+	if req := vm.Spec.PowerOpRequest; req == nil || req.RequestID != last.RequestID {
+		return
+	}
+	patch := client.MergeFrom(vm.DeepCopy())
+	vm.Spec.PowerOpRequest = nil
+	if err := r.Patch(ctx, vm, patch); err != nil {
+		logger.Error(err, "Failed to clear spec.powerOpRequest")
+	}
+}
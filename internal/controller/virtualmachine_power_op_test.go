@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// powerOpStubProvider records Power calls and answers TaskStatus with a
+// configurable status.
+type powerOpStubProvider struct {
+	stubProvider
+	ops        []contracts.PowerOp
+	taskRef    string
+	powerErr   error
+	taskStatus contracts.TaskStatus
+}
+
+func (p *powerOpStubProvider) Power(_ context.Context, _ string, op contracts.PowerOp) (string, error) {
+	p.ops = append(p.ops, op)
+	return p.taskRef, p.powerErr
+}
+
+func (p *powerOpStubProvider) TaskStatus(_ context.Context, _ string) (contracts.TaskStatus, error) {
+	return p.taskStatus, nil
+}
+
+func powerOpVM(op infravirtrigaudiov1beta1.PowerOpType, requestID string) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.Generation = 2
+	vm.Status.ID = "vm-1"
+	vm.Spec.PowerOpRequest = &infravirtrigaudiov1beta1.PowerOpRequest{Op: op, RequestID: requestID}
+	return vm
+}
+
+func TestReconcilePowerOpRequest_Restart(t *testing.T) {
+	ctx := context.Background()
+	p := &powerOpStubProvider{}
+	vm := powerOpVM(infravirtrigaudiov1beta1.PowerOpRestart, "r1")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+	_, acted := r.reconcilePowerOpRequest(ctx, vm, p, "On")
+	require.True(t, acted)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpReboot}, p.ops)
+
+	var got infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &got))
+	require.NotNil(t, got.Status.LastPowerOp)
+	assert.Equal(t, "r1", got.Status.LastPowerOp.RequestID)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerOpResultSucceeded, got.Status.LastPowerOp.Result)
+	assert.NotNil(t, got.Status.LastPowerOp.CompletedAt)
+	assert.Nil(t, got.Spec.PowerOpRequest, "a completed request is cleared")
+	assert.Equal(t, int64(2), got.Status.ObservedGeneration)
+
+	// Re-applying the same request (say, by a GitOps sync) does nothing.
+	got.Spec.PowerOpRequest = &infravirtrigaudiov1beta1.PowerOpRequest{Op: infravirtrigaudiov1beta1.PowerOpRestart, RequestID: "r1"}
+	_, acted = r.reconcilePowerOpRequest(ctx, &got, p, "On")
+	assert.False(t, acted)
+	assert.Len(t, p.ops, 1)
+}
+
+func TestReconcilePowerOpRequest_HardResetTask(t *testing.T) {
+	ctx := context.Background()
+	p := &powerOpStubProvider{taskRef: "task-9"}
+	vm := powerOpVM(infravirtrigaudiov1beta1.PowerOpHardReset, "r2")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+	_, acted := r.reconcilePowerOpRequest(ctx, vm, p, "On")
+	require.True(t, acted)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpReset}, p.ops)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerOpResultInProgress, vm.Status.LastPowerOp.Result)
+	assert.Equal(t, "task-9", vm.Status.LastPowerOp.TaskRef)
+
+	// While the task runs the VM may look powered off; that must not start
+	// it again or send the reset twice.
+	_, acted = r.reconcilePowerOpRequest(ctx, vm, p, "Off")
+	require.True(t, acted)
+	assert.Len(t, p.ops, 1)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerOpResultInProgress, vm.Status.LastPowerOp.Result)
+
+	p.taskStatus = contracts.TaskStatus{IsCompleted: true, Error: "guest refused"}
+	_, acted = r.reconcilePowerOpRequest(ctx, vm, p, "On")
+	require.True(t, acted)
+	assert.Len(t, p.ops, 1)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerOpResultFailed, vm.Status.LastPowerOp.Result)
+	assert.Equal(t, "guest refused", vm.Status.LastPowerOp.Message)
+	assert.Empty(t, vm.Status.LastPowerOp.TaskRef)
+}
+
+func TestReconcilePowerOpRequest_NotAttempted(t *testing.T) {
+	cases := []struct {
+		name       string
+		powerState infravirtrigaudiov1beta1.PowerState
+		current    string
+		last       *infravirtrigaudiov1beta1.PowerOpStatus
+		wantActed  bool
+		wantResult infravirtrigaudiov1beta1.PowerOpResult
+	}{
+		{name: "spec powered off", powerState: infravirtrigaudiov1beta1.PowerStateOff, current: "Off",
+			wantActed: true, wantResult: infravirtrigaudiov1beta1.PowerOpResultFailed},
+		{name: "waiting for power on", current: "Off"},
+		{name: "interrupted attempt", current: "On",
+			last: &infravirtrigaudiov1beta1.PowerOpStatus{
+				Op: infravirtrigaudiov1beta1.PowerOpRestart, RequestID: "r3",
+				Result: infravirtrigaudiov1beta1.PowerOpResultInProgress,
+			},
+			wantActed: true, wantResult: infravirtrigaudiov1beta1.PowerOpResultFailed},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := &powerOpStubProvider{powerErr: errors.New("must not be called")}
+			vm := powerOpVM(infravirtrigaudiov1beta1.PowerOpRestart, "r3")
+			vm.Spec.PowerState = tc.powerState
+			vm.Status.LastPowerOp = tc.last
+			r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+			_, acted := r.reconcilePowerOpRequest(ctx, vm, p, tc.current)
+			assert.Equal(t, tc.wantActed, acted)
+			assert.Empty(t, p.ops)
+			if tc.wantResult != "" {
+				require.NotNil(t, vm.Status.LastPowerOp)
+				assert.Equal(t, tc.wantResult, vm.Status.LastPowerOp.Result)
+			}
+		})
+	}
+}
+
+func TestReconcileVM_ObservedGeneration(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	prov, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Generation = 4
+	vm.Status.ID = "vm-1"
+	vm.Status.ObservedGeneration = 3
+	p := &fakeDescribeProvider{DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+		return contracts.DescribeResponse{Exists: true, PowerState: "On"}, nil
+	}}
+
+	// A spec the controller cannot act on yet is not observed.
+	r := newTestReconciler(s, &stubResolver{provider: p}, prov, vm)
+	_, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), vm.Status.ObservedGeneration)
+
+	r = newTestReconciler(s, &stubResolver{provider: p}, prov, class, vm)
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), vm.Status.ObservedGeneration)
+}
+
+// versionedPowerOpProvider is a powerOpStubProvider that reports protocol
+// versions.
+type versionedPowerOpProvider struct {
+	powerOpStubProvider
+	caps contracts.Capabilities
+}
+
+func (p *versionedPowerOpProvider) GetCapabilities(context.Context) (contracts.Capabilities, error) {
+	return p.caps, nil
+}
+
+// TestReconcilePowerOpRequest_HardResetOldProtocol verifies a HardReset is
+// refused, not sent, when the provider's protocol predates the Reset op.
+func TestReconcilePowerOpRequest_HardResetOldProtocol(t *testing.T) {
+	ctx := context.Background()
+	p := &versionedPowerOpProvider{}
+	vm := powerOpVM(infravirtrigaudiov1beta1.PowerOpHardReset, "r3")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+	_, acted := r.reconcilePowerOpRequest(ctx, vm, p, "On")
+	require.True(t, acted)
+	assert.Empty(t, p.ops)
+	require.NotNil(t, vm.Status.LastPowerOp)
+	assert.Equal(t, infravirtrigaudiov1beta1.PowerOpResultFailed, vm.Status.LastPowerOp.Result)
+	assert.Contains(t, vm.Status.LastPowerOp.Message, string(contracts.FeatureHardReset))
+
+	p.caps = contracts.Capabilities{MinProtocolVersion: contracts.ProtocolVersion1, MaxProtocolVersion: contracts.ProtocolVersionCurrent}
+	vm = powerOpVM(infravirtrigaudiov1beta1.PowerOpHardReset, "r4")
+	r = newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+	_, acted = r.reconcilePowerOpRequest(ctx, vm, p, "On")
+	require.True(t, acted)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpReset}, p.ops)
+}
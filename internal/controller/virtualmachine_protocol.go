@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+const (
+	// ReasonProviderIncompatible marks a VM whose provider shares no
+	// protocol version with the manager. The VM is not reconciled until
+	// the provider or the manager is upgraded.
+	ReasonProviderIncompatible = events.ReasonProviderIncompatible
+	// ReasonProtocolFeatureUnsupported marks a VM whose spec needs a
+	// protocol feature its provider's version lacks. Create is not
+	// attempted.
+	ReasonProtocolFeatureUnsupported = events.ReasonProtocolFeatureUnsupported
+)
+
+// providerIncompatible reports whether the Provider controller found the
+// provider Incompatible, with the message it recorded.
+func providerIncompatible(provider *infravirtrigaudiov1beta1.Provider) (string, bool) {
+	p := provider.Status.Protocol
+	if p == nil || p.Compatibility != string(contracts.Incompatible) {
+		return "", false
+	}
+	message := fmt.Sprintf("provider %s is incompatible with this manager", provider.Name)
+	if c := k8s.GetCondition(provider.Status.Conditions, providerConditionProtocolCompatible); c != nil && c.Message != "" {
+		message += ": " + c.Message
+	}
+	return message, true
+}
+
+// gateIncompatibleProvider stops a VM from being reconciled against an
+// Incompatible provider, recording why on the Ready condition. The warning
+// event is emitted once, when the VM is first refused.
+func (r *VirtualMachineReconciler) gateIncompatibleProvider(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider *infravirtrigaudiov1beta1.Provider,
+) (blocked bool) {
+	message, incompatible := providerIncompatible(provider)
+	if !incompatible {
+		return false
+	}
+	log.FromContext(ctx).Info("Not reconciling VM on an incompatible provider", "provider", provider.Name)
+	if ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady); ready == nil || ready.Reason != ReasonProviderIncompatible {
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonProviderIncompatible, message)
+	}
+	k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonProviderIncompatible, message)
+	r.updateStatus(ctx, vm)
+	return true
+}
+
+// unsupportedProtocolFeature reports why provider cannot be asked for
+// feature, or false when it can. Like the capability gates it fails open:
+// a provider that does not report capabilities, or whose query fails, is
+// assumed to support the feature.
+func unsupportedProtocolFeature(ctx context.Context, provider contracts.Provider, feature contracts.Feature) (string, bool) {
+	reporter, ok := provider.(contracts.CapabilityReporter)
+	if !ok {
+		return "", false
+	}
+	caps, err := reporter.GetCapabilities(ctx)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("GetCapabilities failed; assuming protocol feature is supported (fail open)",
+			"feature", feature, "error", err.Error())
+		return "", false
+	}
+	n := contracts.NegotiateProtocol(caps)
+	if n.Supports(feature) {
+		return "", false
+	}
+	return fmt.Sprintf("%s needs provider protocol version %d; the provider speaks %d at most",
+		feature, contracts.FeatureVersion(feature), n.ProviderMax), true
+}
+
+// gateProtocolFeatures stops a VM from being created when its spec needs a
+// protocol feature the provider lacks, recording why on the Provisioning
+// condition. An older provider would otherwise drop the setting silently.
+func (r *VirtualMachineReconciler) gateProtocolFeatures(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	provider contracts.Provider,
+) (blocked bool) {
+	ud := vm.Spec.UserData
+	if ud == nil || (ud.NetworkConfig == nil && ud.VendorData == nil) {
+		return false
+	}
+	reason, unsupported := unsupportedProtocolFeature(ctx, provider, contracts.FeatureCloudInitNetworkConfig)
+	if !unsupported {
+		return false
+	}
+
+	message := fmt.Sprintf("provider %s cannot apply userData.networkConfig or userData.vendorData: %s",
+		vm.Spec.ProviderRef.Name, reason)
+	k8s.SetProvisioningCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonProtocolFeatureUnsupported, message)
+	r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonProtocolFeatureUnsupported, message)
+	r.updateStatus(ctx, vm)
+	return true
+}
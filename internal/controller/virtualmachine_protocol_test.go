@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// currentProtocolCaps are the capabilities of a provider built against the
+// current proto.
+var currentProtocolCaps = contracts.Capabilities{
+	MinProtocolVersion: contracts.ProtocolVersion1,
+	MaxProtocolVersion: contracts.ProtocolVersionCurrent,
+}
+
+func TestReconcileProtocol(t *testing.T) {
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(4)
+	r := &ProviderReconciler{Recorder: recorder}
+	provider := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"}}
+
+	r.reconcileProtocol(ctx, provider, contracts.Capabilities{})
+	require.NotNil(t, provider.Status.Protocol)
+	assert.Equal(t, string(contracts.DegradedCompatibility), provider.Status.Protocol.Compatibility)
+	assert.Equal(t, int32(1), provider.Status.Protocol.NegotiatedVersion)
+	assert.Contains(t, provider.Status.Protocol.MissingFeatures, string(contracts.FeatureHardReset))
+	c := getConditionByType(t, provider.Status.Conditions, providerConditionProtocolCompatible)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, string(contracts.DegradedCompatibility), c.Reason)
+	assert.Empty(t, recorder.Events)
+
+	future := contracts.Capabilities{MinProtocolVersion: contracts.ProtocolVersionCurrent + 1, MaxProtocolVersion: contracts.ProtocolVersionCurrent + 1}
+	r.reconcileProtocol(ctx, provider, future)
+	assert.Equal(t, string(contracts.Incompatible), provider.Status.Protocol.Compatibility)
+	assert.Zero(t, provider.Status.Protocol.NegotiatedVersion)
+	c = getConditionByType(t, provider.Status.Conditions, providerConditionProtocolCompatible)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Contains(t, c.Message, "upgrade the manager")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonProviderIncompatible)
+
+	r.reconcileProtocol(ctx, provider, future)
+	assert.Empty(t, recorder.Events, "the warning is emitted when the provider becomes incompatible, not on every fetch")
+
+	r.reconcileProtocol(ctx, provider, currentProtocolCaps)
+	assert.Equal(t, string(contracts.Compatible), provider.Status.Protocol.Compatibility)
+	assert.Empty(t, provider.Status.Protocol.MissingFeatures)
+}
+
+// TestGateIncompatibleProvider verifies a VM on an Incompatible provider is
+// held with a Ready reason and a single warning.
+func TestGateIncompatibleProvider(t *testing.T) {
+	ctx := context.Background()
+	s := cloudInitScheme(t)
+	vm := baseVM("default")
+	fc := fake.NewClientBuilder().WithScheme(s).WithObjects(vm).WithStatusSubresource(vm).Build()
+	recorder := record.NewFakeRecorder(4)
+	r := &VirtualMachineReconciler{Client: fc, Scheme: s, Recorder: recorder}
+	provider := &infravirtrigaudiov1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "test-prov", Namespace: "default"}}
+
+	assert.False(t, r.gateIncompatibleProvider(ctx, vm, provider), "a provider without a negotiated protocol is not held")
+
+	provider.Status.Protocol = &infravirtrigaudiov1beta1.ProviderProtocolStatus{Compatibility: string(contracts.Incompatible)}
+	k8s.SetCondition(&provider.Status.Conditions, providerConditionProtocolCompatible, metav1.ConditionFalse,
+		string(contracts.Incompatible), "provider needs protocol version 3 or newer")
+
+	require.True(t, r.gateIncompatibleProvider(ctx, vm, provider))
+	ready := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonProviderIncompatible, ready.Reason)
+	assert.Contains(t, ready.Message, "protocol version 3")
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	require.True(t, r.gateIncompatibleProvider(ctx, vm, provider))
+	assert.Empty(t, recorder.Events, "the warning is emitted once per refused VM")
+}
+
+func TestGateProtocolFeatures(t *testing.T) {
+	networkConfigVM := func() *infravirtrigaudiov1beta1.VirtualMachine {
+		vm := baseVM("default")
+		vm.Spec.UserData = &infravirtrigaudiov1beta1.UserData{
+			NetworkConfig: &infravirtrigaudiov1beta1.CloudInitDocument{Inline: "version: 2"},
+		}
+		return vm
+	}
+	tests := []struct {
+		name        string
+		vm          *infravirtrigaudiov1beta1.VirtualMachine
+		provider    contracts.Provider
+		wantBlocked bool
+	}{
+		{
+			name:        "unversioned provider drops network-config",
+			vm:          networkConfigVM(),
+			provider:    &capReporterProvider{},
+			wantBlocked: true,
+		},
+		{
+			name:     "current provider",
+			vm:       networkConfigVM(),
+			provider: &capReporterProvider{caps: currentProtocolCaps},
+		},
+		{
+			name:     "provider is not a CapabilityReporter, fails open",
+			vm:       networkConfigVM(),
+			provider: &stubProvider{},
+		},
+		{
+			name:     "spec needs no version 2 feature",
+			vm:       baseVM("default"),
+			provider: &capReporterProvider{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cloudInitScheme(t)
+			fc := fake.NewClientBuilder().WithScheme(s).WithObjects(tc.vm).WithStatusSubresource(tc.vm).Build()
+			recorder := record.NewFakeRecorder(4)
+			r := &VirtualMachineReconciler{Client: fc, Scheme: s, Recorder: recorder}
+
+			blocked := r.gateProtocolFeatures(context.Background(), tc.vm, tc.provider)
+
+			assert.Equal(t, tc.wantBlocked, blocked)
+			cond := k8s.GetCondition(tc.vm.Status.Conditions, k8s.ConditionProvisioning)
+			if !tc.wantBlocked {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, ReasonProtocolFeatureUnsupported, cond.Reason)
+			assert.Contains(t, cond.Message, "protocol version 2")
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
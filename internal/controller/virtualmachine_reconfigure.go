@@ -0,0 +1,260 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+)
+
+// ReasonPowerCycleRequired is the Reconfiguring condition reason, and event
+// reason, while changes wait for the VM to be powered off.
+const ReasonPowerCycleRequired = events.ReasonPowerCycleRequired
+
+// desiredResources returns the CPU count and memory (MiB) the VM should
+// have: the class values unless spec.resources overrides them.
+func desiredResources(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass) (int32, int64) {
+	cpu := vmClass.Spec.CPU
+	memoryMiB := vmClass.Spec.Memory.Value() / (1024 * 1024)
+	if vm.Spec.Resources != nil {
+		if vm.Spec.Resources.CPU != nil {
+			cpu = *vm.Spec.Resources.CPU
+		}
+		if vm.Spec.Resources.MemoryMiB != nil {
+			memoryMiB = *vm.Spec.Resources.MemoryMiB
+		}
+	}
+	return cpu, memoryMiB
+}
+
+// desiredAppliedConfig returns the disk and network layout the spec asks
+// for, in the shape recorded in status.appliedConfig.
+func desiredAppliedConfig(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass) infravirtrigaudiov1beta1.VirtualMachineAppliedConfig {
+	var applied infravirtrigaudiov1beta1.VirtualMachineAppliedConfig
+	if size := usage.ClassDiskSizeGiB(&vmClass.Spec); size > 0 {
+		applied.Disks = append(applied.Disks, infravirtrigaudiov1beta1.AppliedDisk{
+			Name:    contracts.RootDiskName,
+			SizeGiB: int32(size), // #nosec G115 -- GiB count fits int32
+		})
+	}
+	for _, d := range vm.Spec.Disks {
+		applied.Disks = append(applied.Disks, infravirtrigaudiov1beta1.AppliedDisk{Name: d.Name, SizeGiB: d.SizeGiB})
+	}
+	for _, n := range vm.Spec.Networks {
+		applied.Networks = append(applied.Networks, n.Name)
+	}
+	return applied
+}
+
+// desiredChangeSet diffs the desired spec against what was last applied.
+// CPU and memory are not diffed until CurrentResources has been recorded,
+// nor disks and networks until AppliedConfig has (VMs created before it
+// existed). Disks only grow: a smaller size is ignored, as providers have
+// always refused to shrink. Added networks carry only their name; the
+// caller fills in the attachment.
+func desiredChangeSet(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass) contracts.ChangeSet {
+	var changes contracts.ChangeSet
+
+	cpu, memoryMiB := desiredResources(vm, vmClass)
+	if current := vm.Status.CurrentResources; current != nil && (current.CPU != nil || current.MemoryMiB != nil) {
+		if current.CPU != nil && *current.CPU != cpu {
+			changes.CPU = &contracts.Int32Change{Old: *current.CPU, New: cpu}
+		}
+		if current.MemoryMiB != nil && *current.MemoryMiB != memoryMiB {
+			changes.MemoryMiB = &contracts.Int64Change{Old: *current.MemoryMiB, New: memoryMiB}
+		}
+	}
+
+	applied := vm.Status.AppliedConfig
+	if applied == nil {
+		return changes
+	}
+	desired := desiredAppliedConfig(vm, vmClass)
+	for _, disk := range desired.Disks {
+		for _, old := range applied.Disks {
+			if old.Name == disk.Name && disk.SizeGiB > old.SizeGiB {
+				changes.Disks = append(changes.Disks, contracts.DiskExpansion{
+					Name:       disk.Name,
+					OldSizeGiB: old.SizeGiB,
+					NewSizeGiB: disk.SizeGiB,
+				})
+			}
+		}
+	}
+	for _, name := range desired.Networks {
+		if !slices.Contains(applied.Networks, name) {
+			changes.NetworksAdded = append(changes.NetworksAdded, contracts.NetworkAttachment{Name: name})
+		}
+	}
+	for _, name := range applied.Networks {
+		if !slices.Contains(desired.Networks, name) {
+			changes.NetworksRemoved = append(changes.NetworksRemoved, name)
+		}
+	}
+	return changes
+}
+
+// desiredSecurityGroups returns the firewall security groups each
+// spec.networks entry asks for through its VMNetworkAttachment, in the shape
+// recorded in status.appliedConfig. networks is indexed like spec.networks.
+func desiredSecurityGroups(vm *infravirtrigaudiov1beta1.VirtualMachine, networks []*infravirtrigaudiov1beta1.VMNetworkAttachment) []infravirtrigaudiov1beta1.AppliedSecurityGroups {
+	var desired []infravirtrigaudiov1beta1.AppliedSecurityGroups
+	for i, n := range vm.Spec.Networks {
+		if i >= len(networks) || networks[i] == nil || networks[i].Spec.Network.Proxmox == nil {
+			continue
+		}
+		if groups := networks[i].Spec.Network.Proxmox.SecurityGroups; len(groups) > 0 {
+			desired = append(desired, infravirtrigaudiov1beta1.AppliedSecurityGroups{Network: n.Name, Groups: slices.Clone(groups)})
+		}
+	}
+	return desired
+}
+
+// securityGroupChanges diffs the desired security groups of the VM's
+// networks against those last applied. Like disks and networks they are not
+// diffed until AppliedConfig has been recorded. A network whose groups were
+// all removed gets a change with no groups; one that left spec.networks
+// gets none, as the attachment itself is not removed from the VM.
+func securityGroupChanges(vm *infravirtrigaudiov1beta1.VirtualMachine, networks []*infravirtrigaudiov1beta1.VMNetworkAttachment) []contracts.SecurityGroupChange {
+	applied := vm.Status.AppliedConfig
+	if applied == nil {
+		return nil
+	}
+	appliedGroups := func(network string) []string {
+		for _, sg := range applied.SecurityGroups {
+			if sg.Network == network {
+				return sg.Groups
+			}
+		}
+		return nil
+	}
+	desired := desiredSecurityGroups(vm, networks)
+	desiredGroups := func(network string) []string {
+		for _, sg := range desired {
+			if sg.Network == network {
+				return sg.Groups
+			}
+		}
+		return nil
+	}
+
+	var changes []contracts.SecurityGroupChange
+	for _, n := range vm.Spec.Networks {
+		want, have := desiredGroups(n.Name), appliedGroups(n.Name)
+		if !slices.Equal(want, have) {
+			changes = append(changes, contracts.SecurityGroupChange{Network: n.Name, Groups: want})
+		}
+	}
+	return changes
+}
+
+// recordAppliedSecurityGroups records the desired security groups as
+// applied. It is called after recordAppliedSpec, which creates AppliedConfig.
+func recordAppliedSecurityGroups(vm *infravirtrigaudiov1beta1.VirtualMachine, networks []*infravirtrigaudiov1beta1.VMNetworkAttachment) {
+	if vm.Status.AppliedConfig == nil || slices.Contains(vm.Status.PendingPowerCycle, contracts.ChangeSecurityGroups) {
+		return
+	}
+	vm.Status.AppliedConfig.SecurityGroups = desiredSecurityGroups(vm, networks)
+}
+
+// withAttachments replaces the name-only added networks with the full
+// attachments from the built create request.
+func withAttachments(changes contracts.ChangeSet, attachments []contracts.NetworkAttachment) contracts.ChangeSet {
+	for i, added := range changes.NetworksAdded {
+		for _, a := range attachments {
+			if a.Name == added.Name {
+				changes.NetworksAdded[i] = a
+				break
+			}
+		}
+	}
+	return changes
+}
+
+// awaitingPowerCycle reports whether every pending change already waits for
+// a power cycle the running VM has not had. Re-sending them would only get
+// the same answer from the provider.
+func awaitingPowerCycle(vm *infravirtrigaudiov1beta1.VirtualMachine, changes contracts.ChangeSet, powerState string) bool {
+	if powerState != string(infravirtrigaudiov1beta1.PowerStateOn) || len(vm.Status.PendingPowerCycle) == 0 {
+		return false
+	}
+	for _, key := range changes.Keys() {
+		if !slices.Contains(vm.Status.PendingPowerCycle, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordAppliedSpec records the desired spec as applied, except for the
+// changes in pending, which the provider could not apply yet.
+func (r *VirtualMachineReconciler) recordAppliedSpec(vm *infravirtrigaudiov1beta1.VirtualMachine, vmClass *infravirtrigaudiov1beta1.VMClass, pending []string) {
+	cpu, memoryMiB := desiredResources(vm, vmClass)
+	if vm.Status.CurrentResources == nil {
+		vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{}
+	}
+	if !slices.Contains(pending, contracts.ChangeCPU) || vm.Status.CurrentResources.CPU == nil {
+		vm.Status.CurrentResources.CPU = &cpu
+	}
+	if !slices.Contains(pending, contracts.ChangeMemoryMiB) || vm.Status.CurrentResources.MemoryMiB == nil {
+		vm.Status.CurrentResources.MemoryMiB = &memoryMiB
+	}
+
+	desired := desiredAppliedConfig(vm, vmClass)
+	if vm.Status.AppliedConfig == nil {
+		vm.Status.AppliedConfig = &desired
+	} else {
+		if !slices.Contains(pending, contracts.ChangeDisks) {
+			vm.Status.AppliedConfig.Disks = desired.Disks
+		}
+		if !slices.Contains(pending, contracts.ChangeNetworks) {
+			vm.Status.AppliedConfig.Networks = desired.Networks
+		}
+	}
+	vm.Status.PendingPowerCycle = pending
+}
+
+// reportPowerCycleRequired surfaces changes the provider deferred until the
+// VM is powered off, and how the update strategy will get them applied. The
+// event fires only when the set changes.
+func (r *VirtualMachineReconciler) reportPowerCycleRequired(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, previous []string) {
+	if len(vm.Status.PendingPowerCycle) == 0 {
+		return
+	}
+	message := fmt.Sprintf("Changes to %s take effect after the VM is powered off", strings.Join(vm.Status.PendingPowerCycle, ", "))
+	switch updateStrategy(vm) {
+	case infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic:
+		message += "; the VM will be power cycled to apply them"
+	case infravirtrigaudiov1beta1.VMUpdateStrategyManual:
+		message += fmt.Sprintf("; annotate the VM %s to power cycle it now", infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation)
+	}
+	k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, ReasonPowerCycleRequired, message)
+	if !slices.Equal(previous, vm.Status.PendingPowerCycle) {
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonPowerCycleRequired, message)
+	}
+}
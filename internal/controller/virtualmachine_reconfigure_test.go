@@ -0,0 +1,149 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestDesiredChangeSet(t *testing.T) {
+	class := &infravirtrigaudiov1beta1.VMClass{
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{
+			CPU:          4,
+			Memory:       resource.MustParse("8Gi"),
+			DiskDefaults: &infravirtrigaudiov1beta1.DiskDefaults{Size: resource.MustParse("40Gi")},
+		},
+	}
+	cpu, mem := int32(2), int64(8192)
+	vm := baseVM("default")
+	vm.Spec.Disks = []infravirtrigaudiov1beta1.DiskSpec{{Name: "data", SizeGiB: 10}, {Name: "logs", SizeGiB: 5}}
+	vm.Spec.Networks = []infravirtrigaudiov1beta1.VMNetworkRef{{Name: "app"}, {Name: "storage"}}
+	vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: &cpu, MemoryMiB: &mem}
+
+	// Without an applied config only CPU and memory are diffed.
+	changes := desiredChangeSet(vm, class)
+	assert.Equal(t, &contracts.Int32Change{Old: 2, New: 4}, changes.CPU)
+	assert.Nil(t, changes.MemoryMiB)
+	assert.Equal(t, []string{contracts.ChangeCPU}, changes.Keys())
+
+	vm.Status.AppliedConfig = &infravirtrigaudiov1beta1.VirtualMachineAppliedConfig{
+		Disks: []infravirtrigaudiov1beta1.AppliedDisk{
+			{Name: contracts.RootDiskName, SizeGiB: 20},
+			{Name: "data", SizeGiB: 10},
+			{Name: "logs", SizeGiB: 8},
+		},
+		Networks: []string{"app", "mgmt"},
+	}
+	changes = desiredChangeSet(vm, class)
+	assert.Equal(t, []contracts.DiskExpansion{{Name: contracts.RootDiskName, OldSizeGiB: 20, NewSizeGiB: 40}}, changes.Disks,
+		"only grown disks are expanded; logs shrank and is ignored")
+	require.Len(t, changes.NetworksAdded, 1)
+	assert.Equal(t, "storage", changes.NetworksAdded[0].Name)
+	assert.Equal(t, []string{"mgmt"}, changes.NetworksRemoved)
+
+	changes = withAttachments(changes, []contracts.NetworkAttachment{{Name: "storage", NetworkName: "vlan-20"}})
+	assert.Equal(t, "vlan-20", changes.NetworksAdded[0].NetworkName)
+}
+
+func TestSecurityGroupChanges(t *testing.T) {
+	proxmoxNet := func(groups ...string) *infravirtrigaudiov1beta1.VMNetworkAttachment {
+		return &infravirtrigaudiov1beta1.VMNetworkAttachment{
+			Spec: infravirtrigaudiov1beta1.VMNetworkAttachmentSpec{
+				Network: infravirtrigaudiov1beta1.NetworkConfig{
+					Proxmox: &infravirtrigaudiov1beta1.ProxmoxNetworkConfig{Bridge: "vmbr1", SecurityGroups: groups},
+				},
+			},
+		}
+	}
+	vm := baseVM("default")
+	vm.Spec.Networks = []infravirtrigaudiov1beta1.VMNetworkRef{{Name: "app"}, {Name: "db"}, {Name: "mgmt"}}
+	networks := []*infravirtrigaudiov1beta1.VMNetworkAttachment{proxmoxNet("web", "db"), proxmoxNet(), nil}
+
+	// Nothing is diffed until an applied config has been recorded.
+	assert.Nil(t, securityGroupChanges(vm, networks))
+
+	vm.Status.AppliedConfig = &infravirtrigaudiov1beta1.VirtualMachineAppliedConfig{
+		SecurityGroups: []infravirtrigaudiov1beta1.AppliedSecurityGroups{
+			{Network: "app", Groups: []string{"web"}},
+			{Network: "db", Groups: []string{"db"}},
+		},
+	}
+	assert.Equal(t, []contracts.SecurityGroupChange{
+		{Network: "app", Groups: []string{"web", "db"}},
+		{Network: "db"},
+	}, securityGroupChanges(vm, networks), "a network whose groups were all removed gets an empty change")
+
+	recordAppliedSecurityGroups(vm, networks)
+	assert.Equal(t, []infravirtrigaudiov1beta1.AppliedSecurityGroups{{Network: "app", Groups: []string{"web", "db"}}},
+		vm.Status.AppliedConfig.SecurityGroups)
+	assert.Empty(t, securityGroupChanges(vm, networks))
+}
+
+func TestReconcileVM_PowerCycleRequired(t *testing.T) {
+	var calls int
+	var sent contracts.ChangeSet
+	prov := &fakeDescribeProvider{
+		stubProvider: stubProvider{
+			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, changes contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+				calls++
+				sent = changes
+				return contracts.ReconfigureResult{PowerCycleRequired: []string{contracts.ChangeCPU}}, nil
+			},
+		},
+		DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+			return contracts.DescribeResponse{Exists: true, PowerState: "On", IPs: []string{"10.0.0.1"}}, nil
+		},
+	}
+	s := coverageTestScheme(t)
+	k8sProv, class := providerAndClass("default")
+	r := newTestReconciler(s, &stubResolver{provider: prov}, k8sProv, class)
+
+	vm := baseVM("default")
+	vm.Status.ID = "vm-xyz"
+	oldCPU, oldMem := int32(2), int64(4096)
+	vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: &oldCPU, MemoryMiB: &oldMem}
+
+	_, err := r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	assert.Equal(t, []string{contracts.ChangeCPU, contracts.ChangeMemoryMiB}, sent.Keys())
+
+	// Memory was applied live; CPU waits for a power cycle.
+	assert.Equal(t, int32(2), *vm.Status.CurrentResources.CPU)
+	assert.Equal(t, int64(8192), *vm.Status.CurrentResources.MemoryMiB)
+	assert.Equal(t, []string{contracts.ChangeCPU}, vm.Status.PendingPowerCycle)
+	var reason string
+	for _, c := range vm.Status.Conditions {
+		if c.Type == "Reconfiguring" {
+			reason = c.Reason
+		}
+	}
+	assert.Equal(t, ReasonPowerCycleRequired, reason)
+
+	// While the VM keeps running, the pending CPU change is not re-sent.
+	_, err = r.reconcileVM(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Reasons for the SnapshotChainLong condition.
+const (
+	ReasonSnapshotChainLong        = "ChainLong"
+	ReasonSnapshotChainWithinLimit = "WithinLimit"
+)
+
+// ReasonBlockedBySnapshots is the Reconfiguring condition reason, and
+// event reason, while a change the VM's snapshots would make the provider
+// refuse is held back.
+const ReasonBlockedBySnapshots = events.ReasonReconfigureBlockedBySnapshots
+
+// snapshotChainStatus returns the snapshot counts desc reports, or nil when
+// the provider does not report them.
+func snapshotChainStatus(desc contracts.DescribeResponse) *infravirtrigaudiov1beta1.VMSnapshotChainStatus {
+	if desc.Details == nil || desc.Details.Snapshots == nil {
+		return nil
+	}
+	return &infravirtrigaudiov1beta1.VMSnapshotChainStatus{
+		Count: desc.Details.Snapshots.Count,
+		Depth: desc.Details.Snapshots.ChainDepth,
+	}
+}
+
+// reconcileSnapshotChain records the snapshot counts desc reports in
+// status.snapshotChain and sets SnapshotChainLong when the chain is deeper
+// than the configured warning depth. Counts the provider could not report
+// this time keep the last ones, as does the condition.
+func (r *VirtualMachineReconciler) reconcileSnapshotChain(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, desc contracts.DescribeResponse) {
+	chain := snapshotChainStatus(desc)
+	if chain == nil {
+		return
+	}
+	vm.Status.SnapshotChain = chain
+
+	limit := r.Config.Get().Snapshots.ChainWarningDepth
+	long := int(chain.Depth) > limit
+	longSnapshotChains.set(vm, long)
+
+	cond := infravirtrigaudiov1beta1.VirtualMachineConditionSnapshotChainLong
+	if !long {
+		if k8s.GetCondition(vm.Status.Conditions, cond) != nil {
+			k8s.SetCondition(&vm.Status.Conditions, cond, metav1.ConditionFalse, ReasonSnapshotChainWithinLimit,
+				fmt.Sprintf("Snapshot chain is %d deep, within the warning depth of %d", chain.Depth, limit))
+		}
+		return
+	}
+	message := fmt.Sprintf("Snapshot chain is %d deep (%d snapshots), above the warning depth of %d; delete snapshots to shorten it",
+		chain.Depth, chain.Count, limit)
+	if !k8s.IsConditionTrue(vm.Status.Conditions, cond) {
+		log.FromContext(ctx).Info("Snapshot chain is long", "depth", chain.Depth, "snapshots", chain.Count, "limit", limit)
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonSnapshotChainLong, message)
+	}
+	k8s.SetCondition(&vm.Status.Conditions, cond, metav1.ConditionTrue, ReasonSnapshotChainLong, message)
+}
+
+// reconfigureBlockedBySnapshots reports whether changes must wait for the
+// VM's snapshots to be deleted: every in-tree hypervisor refuses to grow a
+// disk that has snapshots. The Reconfiguring condition then says so rather
+// than the provider's error, and the event fires once per block.
+func (r *VirtualMachineReconciler) reconfigureBlockedBySnapshots(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, changes contracts.ChangeSet) bool {
+	chain := vm.Status.SnapshotChain
+	if len(changes.Disks) == 0 || chain == nil || chain.Count == 0 {
+		return false
+	}
+	message := fmt.Sprintf("Disk expansion blocked by %d existing snapshots; delete them to apply %s",
+		chain.Count, strings.Join(changes.Keys(), ", "))
+	if cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring); cond == nil || cond.Reason != ReasonBlockedBySnapshots {
+		log.FromContext(ctx).Info("Not reconfiguring while the VM has snapshots", "snapshots", chain.Count, "changes", changes.Keys())
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonReconfigureBlockedBySnapshots, message)
+	}
+	k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonBlockedBySnapshots, message)
+	return true
+}
+
+// clearSnapshotBlock ends a block by snapshots once no change is pending.
+func clearSnapshotBlock(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	if cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring); cond != nil && cond.Reason == ReasonBlockedBySnapshots {
+		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, "No changes pending")
+	}
+}
+
+// snapshotChainTracker holds the VMs whose snapshot chain is long and
+// publishes their count per (provider, namespace), like allocationTracker.
+type snapshotChainTracker struct {
+	mu  sync.Mutex
+	vms map[types.NamespacedName]allocationKey
+}
+
+func newSnapshotChainTracker() *snapshotChainTracker {
+	return &snapshotChainTracker{vms: make(map[types.NamespacedName]allocationKey)}
+}
+
+// longSnapshotChains is shared by all VirtualMachine reconcilers, as the
+// gauge it drives is process-wide.
+var longSnapshotChains = newSnapshotChainTracker()
+
+// set records whether the chain of vm is long.
+func (t *snapshotChainTracker) set(vm *infravirtrigaudiov1beta1.VirtualMachine, long bool) {
+	name := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name}
+	if !long {
+		t.remove(name)
+		return
+	}
+	key := allocationKey{provider: vm.Spec.ProviderRef.Name, namespace: vm.Namespace}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev, existed := t.vms[name]
+	if existed && prev == key {
+		return
+	}
+	t.vms[name] = key
+	if existed {
+		t.publish(prev)
+	}
+	t.publish(key)
+}
+
+// remove forgets a VM whose chain is no longer long, or that was deleted.
+func (t *snapshotChainTracker) remove(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev, existed := t.vms[name]
+	if !existed {
+		return
+	}
+	delete(t.vms, name)
+	t.publish(prev)
+}
+
+// publish writes the count for key. Callers hold t.mu.
+func (t *snapshotChainTracker) publish(key allocationKey) {
+	count := 0
+	for _, k := range t.vms {
+		if k == key {
+			count++
+		}
+	}
+	if count == 0 {
+		metrics.DeleteSnapshotChainLongVMs(key.provider, key.namespace)
+		return
+	}
+	metrics.SetSnapshotChainLongVMs(key.provider, key.namespace, float64(count))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// snapshotChainProvider describes a running VM with the given snapshot
+// counts and counts the reconfigures made against it.
+func snapshotChainProvider(chain **describe.SnapshotStats, reconfigures *int) *fakeDescribeProvider {
+	return &fakeDescribeProvider{
+		stubProvider: stubProvider{
+			ReconfigureFn: func(_ context.Context, _ string, _ contracts.CreateRequest, _ contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+				*reconfigures++
+				return contracts.ReconfigureResult{}, nil
+			},
+		},
+		DescribeFn: func(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+			return contracts.DescribeResponse{
+				Exists: true, PowerState: "On", IPs: []string{"10.0.0.1"},
+				Details: &describe.Details{Snapshots: *chain},
+			}, nil
+		},
+	}
+}
+
+func TestReconcileVM_SnapshotChainLong(t *testing.T) {
+	ctx := context.Background()
+	chain := &describe.SnapshotStats{Count: 7, ChainDepth: 6}
+	var reconfigures int
+	k8sProv, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Status.ID = "vm-1"
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: snapshotChainProvider(&chain, &reconfigures)}, k8sProv, class, vm)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	name := types.NamespacedName{Namespace: "default", Name: vm.Name}
+	t.Cleanup(func() { longSnapshotChains.remove(name) })
+
+	_, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, &infravirtrigaudiov1beta1.VMSnapshotChainStatus{Count: 7, Depth: 6}, vm.Status.SnapshotChain)
+	cond := k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionSnapshotChainLong)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonSnapshotChainLong, cond.Reason)
+	assert.Contains(t, cond.Message, "6 deep (7 snapshots), above the warning depth of 5")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonSnapshotChainLong)
+	assert.Contains(t, longSnapshotChains.vms, name)
+
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events, "a long chain is reported once")
+
+	// A describe without snapshot counts keeps the last ones.
+	chain = nil
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), vm.Status.SnapshotChain.Depth)
+	assert.True(t, k8s.IsConditionTrue(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionSnapshotChainLong))
+
+	chain = &describe.SnapshotStats{Count: 2, ChainDepth: 2}
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	cond = k8s.GetCondition(vm.Status.Conditions, infravirtrigaudiov1beta1.VirtualMachineConditionSnapshotChainLong)
+	assert.Equal(t, ReasonSnapshotChainWithinLimit, cond.Reason)
+	assert.NotContains(t, longSnapshotChains.vms, name)
+	assert.Zero(t, reconfigures)
+}
+
+func TestReconcileVM_DiskExpansionBlockedBySnapshots(t *testing.T) {
+	ctx := context.Background()
+	chain := &describe.SnapshotStats{Count: 2, ChainDepth: 1}
+	var reconfigures int
+	k8sProv, class := providerAndClass("default")
+	vm := baseVM("default")
+	vm.Status.ID = "vm-1"
+	vm.Spec.Disks = []infravirtrigaudiov1beta1.DiskSpec{{Name: "data", SizeGiB: 20}}
+	vm.Status.AppliedConfig = &infravirtrigaudiov1beta1.VirtualMachineAppliedConfig{
+		Disks: []infravirtrigaudiov1beta1.AppliedDisk{{Name: "data", SizeGiB: 10}},
+	}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: snapshotChainProvider(&chain, &reconfigures)}, k8sProv, class, vm)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	_, err := r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Zero(t, reconfigures, "a disk with snapshots is not grown")
+	cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring)
+	require.NotNil(t, cond)
+	assert.Equal(t, ReasonBlockedBySnapshots, cond.Reason)
+	assert.Contains(t, cond.Message, "blocked by 2 existing snapshots")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, events.ReasonReconfigureBlockedBySnapshots)
+
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Zero(t, reconfigures)
+	assert.Empty(t, recorder.Events, "the block is reported once")
+
+	// Once the snapshots are gone the disk is grown.
+	chain = &describe.SnapshotStats{}
+	_, err = r.reconcileVM(ctx, vm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reconfigures)
+	assert.NotEqual(t, ReasonBlockedBySnapshots, k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReconfiguring).Reason)
+}
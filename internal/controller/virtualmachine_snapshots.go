@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// ReasonDeletionBlockedBySnapshots is the Ready reason while a VM's deletion
+// waits on VMSnapshots whose deletion policy is Retain.
+const ReasonDeletionBlockedBySnapshots = events.ReasonDeletionBlockedBySnapshots
+
+// vmSnapshots lists the VMSnapshots in the VM's namespace that reference it.
+func (r *VirtualMachineReconciler) vmSnapshots(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) ([]infravirtrigaudiov1beta1.VMSnapshot, error) {
+	list := &infravirtrigaudiov1beta1.VMSnapshotList{}
+	if err := r.List(ctx, list, client.InNamespace(vm.Namespace)); err != nil {
+		return nil, err
+	}
+	var out []infravirtrigaudiov1beta1.VMSnapshot
+	for _, snap := range list.Items {
+		if snap.Spec.VMRef.Name == vm.Name {
+			out = append(out, snap)
+		}
+	}
+	return out, nil
+}
+
+// releaseSnapshots runs before the provider VM is deleted. VMSnapshots with
+// the Delete policy are deleted so their finalizers remove the provider
+// snapshots while the VM still exists. Retain snapshots block the deletion
+// unless the force-delete annotation is set. It returns how long to wait
+// before checking again, or zero once the VM delete may proceed.
+func (r *VirtualMachineReconciler) releaseSnapshots(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	snapshots, err := r.vmSnapshots(ctx, vm)
+	if err != nil {
+		return 0, fmt.Errorf("listing snapshots of VM %s: %w", vm.Name, err)
+	}
+
+	var retained []string
+	pending := 0
+	for i := range snapshots {
+		snap := &snapshots[i]
+		if snap.Spec.DeletionPolicy == infravirtrigaudiov1beta1.SnapshotDeletionPolicyRetain {
+			retained = append(retained, snap.Name)
+			continue
+		}
+		pending++
+		if snap.DeletionTimestamp.IsZero() {
+			logger.Info("Deleting VMSnapshot ahead of its VM", "snapshot", snap.Name)
+			if err := r.Delete(ctx, snap); client.IgnoreNotFound(err) != nil {
+				return 0, fmt.Errorf("deleting VMSnapshot %s: %w", snap.Name, err)
+			}
+		}
+	}
+
+	if len(retained) > 0 && !hasForceDeleteAnnotation(vm) {
+		sort.Strings(retained)
+		msg := fmt.Sprintf("Deletion blocked by VMSnapshots with deletionPolicy Retain: %s; delete them or set the %s=true annotation",
+			strings.Join(retained, ", "), forceDeleteAnnotation)
+		cond := k8s.GetCondition(vm.Status.Conditions, k8s.ConditionReady)
+		if cond == nil || cond.Reason != ReasonDeletionBlockedBySnapshots || cond.Message != msg {
+			k8s.SetReadyCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonDeletionBlockedBySnapshots, msg)
+			r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonDeletionBlockedBySnapshots, msg)
+			r.updateStatus(ctx, vm)
+		}
+		return r.requeue().DeleteRetry.Duration, nil
+	}
+	if len(retained) > 0 {
+		logger.Info("Force-deleting VM with retained snapshots; they are lost with the provider VM",
+			"snapshots", retained, "annotation", forceDeleteAnnotation)
+	}
+	if pending > 0 {
+		return r.requeue().InProgress.Duration, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// snapshotDeleteRecorder records the snapshots the provider is asked to delete.
+type snapshotDeleteRecorder struct {
+	stubProvider
+	deleted []string
+}
+
+func (p *snapshotDeleteRecorder) SnapshotDelete(_ context.Context, _, snapshotID string) (string, error) {
+	p.deleted = append(p.deleted, snapshotID)
+	return "", nil
+}
+
+func vmSnapshotFor(name, vmName string, policy infravirtrigaudiov1beta1.SnapshotDeletionPolicy) *infravirtrigaudiov1beta1.VMSnapshot {
+	return &infravirtrigaudiov1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Finalizers: []string{"snapshot.infra.virtrigaud.io/finalizer"},
+		},
+		Spec: infravirtrigaudiov1beta1.VMSnapshotSpec{
+			VMRef:          infravirtrigaudiov1beta1.LocalObjectReference{Name: vmName},
+			DeletionPolicy: policy,
+		},
+	}
+}
+
+func TestHandleDeletion_DeletesSnapshotsFirst(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	prov := &deleteStubProvider{}
+	vm := deletionVM("vm-snaps")
+	snap := vmSnapshotFor("nightly", "vm-snaps", infravirtrigaudiov1beta1.SnapshotDeletionPolicyDelete)
+	other := vmSnapshotFor("unrelated", "other-vm", infravirtrigaudiov1beta1.SnapshotDeletionPolicyDelete)
+	r := newTestReconciler(s, &stubResolver{provider: prov}, vm, deletionProviderCR(), snap, other)
+	marked := markForDeletion(t, r, vm)
+
+	res, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Equal(t, r.requeue().InProgress.Duration, res.RequeueAfter)
+	assert.Zero(t, prov.calls.Load(), "the provider VM must outlive its snapshots")
+
+	var got infravirtrigaudiov1beta1.VMSnapshot
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(snap), &got))
+	assert.False(t, got.DeletionTimestamp.IsZero(), "Delete-policy snapshot must be deleted")
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(other), &got))
+	assert.True(t, got.DeletionTimestamp.IsZero(), "another VM's snapshot must be left alone")
+
+	// The snapshot controller drops its finalizer once the provider snapshot
+	// is gone; the VM delete then goes ahead.
+	got = infravirtrigaudiov1beta1.VMSnapshot{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(snap), &got))
+	got.Finalizers = nil
+	require.NoError(t, r.Update(ctx, &got))
+
+	_, err = r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, prov.calls.Load())
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(vm), marked)))
+}
+
+func TestHandleDeletion_BlockedByRetainedSnapshots(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	prov := &deleteStubProvider{}
+	vm := deletionVM("vm-retain")
+	keep := vmSnapshotFor("golden", "vm-retain", infravirtrigaudiov1beta1.SnapshotDeletionPolicyRetain)
+	r := newTestReconciler(s, &stubResolver{provider: prov}, vm, deletionProviderCR(), keep)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	marked := markForDeletion(t, r, vm)
+
+	res, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.Equal(t, r.requeue().DeleteRetry.Duration, res.RequeueAfter)
+	assert.Zero(t, prov.calls.Load())
+
+	var after infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &after))
+	ready := k8s.GetCondition(after.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, ReasonDeletionBlockedBySnapshots, ready.Reason)
+	assert.Contains(t, ready.Message, "golden")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonDeletionBlockedBySnapshots)
+
+	var snap infravirtrigaudiov1beta1.VMSnapshot
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(keep), &snap))
+	assert.True(t, snap.DeletionTimestamp.IsZero(), "a Retain snapshot is never deleted by the VM")
+
+	// Re-checking an unchanged block does not repeat the event.
+	_, err = r.handleDeletion(ctx, &after)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestHandleDeletion_ForceDeleteIgnoresRetainedSnapshots(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	prov := &deleteStubProvider{}
+	vm := deletionVM("vm-force-snaps")
+	vm.Annotations = map[string]string{forceDeleteAnnotation: "true"}
+	keep := vmSnapshotFor("golden", "vm-force-snaps", infravirtrigaudiov1beta1.SnapshotDeletionPolicyRetain)
+	r := newTestReconciler(s, &stubResolver{provider: prov}, vm, deletionProviderCR(), keep)
+	marked := markForDeletion(t, r, vm)
+
+	_, err := r.handleDeletion(ctx, marked)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, prov.calls.Load())
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(vm), marked)))
+}
+
+func TestVMSnapshot_RetainPolicySkipsProviderDelete(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.VirtualMachineSpec{ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "test-provider"}},
+	}
+	vm.Status.ID = "100"
+	snap := vmSnapshotFor("golden", "web", infravirtrigaudiov1beta1.SnapshotDeletionPolicyRetain)
+	snap.Status.SnapshotID = "golden"
+	prov := &snapshotDeleteRecorder{}
+	cli := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(vm, snap, deletionProviderCR()).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VMSnapshot{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &VMSnapshotReconciler{Client: cli, Scheme: s, RemoteResolver: &stubResolver{provider: prov}, Recorder: recorder}
+
+	require.NoError(t, cli.Delete(ctx, snap))
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "golden", Namespace: "default"}})
+	require.NoError(t, err)
+	assert.Empty(t, prov.deleted, "Retain must leave the provider snapshot")
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(snap), snap)))
+
+	var reasons []string
+	for len(recorder.Events) > 0 {
+		reasons = append(reasons, <-recorder.Events)
+	}
+	assert.Contains(t, reasons, "Normal SnapshotRetained Provider snapshot golden retained (deletionPolicy: Retain) (phase: Deleting, correlationID: vmsnapshot-default/golden)")
+}
+
+func TestVMSnapshot_OwnedByVM(t *testing.T) {
+	ctx := context.Background()
+	s := coverageTestScheme(t)
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "vm-uid"},
+	}
+	snap := vmSnapshotFor("nightly", "web", "")
+	cli := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(vm, snap).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VMSnapshot{}).
+		Build()
+	r := &VMSnapshotReconciler{Client: cli, Scheme: s, Recorder: record.NewFakeRecorder(10)}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "nightly", Namespace: "default"}})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, res.RequeueAfter, "unprovisioned VM: wait")
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(snap), snap))
+	require.Len(t, snap.OwnerReferences, 1)
+	owner := snap.OwnerReferences[0]
+	assert.Equal(t, "VirtualMachine", owner.Kind)
+	assert.Equal(t, "web", owner.Name)
+	assert.Nil(t, owner.Controller, "the VM does not control its snapshots")
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// statusWriteKind labels the VirtualMachine status writes in
+// virtrigaud_status_writes_total.
+const statusWriteKind = "VirtualMachine"
+
+// volatileProviderKeys are the status.provider keys that change on every
+// describe of a running VM: usage counters rather than state. A difference
+// in them alone does not make a status write; they are refreshed with the
+// guest stats.
+var volatileProviderKeys = map[string]bool{
+	"resources.cpuUsagePercent":  true,
+	"resources.memoryUsageBytes": true,
+	"resources.uptimeSeconds":    true,
+	// libvirt domain statistics
+	"cpu_time":               true,
+	"cpu_user":               true,
+	"cpu_system":             true,
+	"used_memory":            true,
+	"memory_actual":          true,
+	"memory_swap_in":         true,
+	"memory_swap_out":        true,
+	"memory_major_fault":     true,
+	"memory_minor_fault":     true,
+	"memory_unused":          true,
+	"memory_available":       true,
+	"memory_usable":          true,
+	"memory_last_update":     true,
+	"memory_disk_caches":     true,
+	"memory_hugetlb_pgalloc": true,
+	"memory_hugetlb_pgfail":  true,
+	"memory_rss":             true,
+}
+
+type statusBaselineKey struct{}
+
+// statusBaseline is a VM's status as the API server has it: read at the
+// start of a reconcile and replaced by every status write made during it.
+type statusBaseline struct {
+	status *infravirtrigaudiov1beta1.VirtualMachineStatus
+}
+
+// withStatusBaseline returns ctx carrying vm's status as just read, so
+// that status writes later in the reconcile can be skipped when they
+// would change nothing.
+func withStatusBaseline(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) context.Context {
+	return context.WithValue(ctx, statusBaselineKey{}, &statusBaseline{status: vm.Status.DeepCopy()})
+}
+
+func statusBaselineFrom(ctx context.Context) *statusBaseline {
+	b, _ := ctx.Value(statusBaselineKey{}).(*statusBaseline)
+	return b
+}
+
+// writeStatus writes vm's status when it differs meaningfully from the one
+// stored. Conditions keep their lastTransitionTime unless their status or
+// reason changes, so a reconcile that only re-sets them writes nothing.
+// Changes to guest stats and volatileProviderKeys alone are written once
+// the stored sample is statusUpdates.usageInterval old.
+//
+// The write is a merge patch of the changed fields, guarded by the VM's
+// resourceVersion. Without a baseline, outside a reconcile, the status is
+// updated whole as before.
+func (r *VirtualMachineReconciler) writeStatus(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) error {
+	baseline := statusBaselineFrom(ctx)
+	if baseline == nil || vm.ResourceVersion == "" {
+		if err := r.Status().Update(ctx, vm); err != nil {
+			return err
+		}
+		r.statusWritten(ctx, vm)
+		return nil
+	}
+
+	usageInterval := r.Config.Get().StatusUpdates.UsageInterval.Duration
+	if !statusChanged(baseline.status, &vm.Status, usageInterval) {
+		metrics.RecordStatusWrite(statusWriteKind, metrics.StatusWriteSkipped)
+		return nil
+	}
+	stored := vm.DeepCopy()
+	stored.Status = *baseline.status.DeepCopy()
+	if err := r.Status().Patch(ctx, vm, client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
+	}
+	r.statusWritten(ctx, vm)
+	return nil
+}
+
+// statusWritten records a status write of vm and makes its status the
+// baseline for the rest of the reconcile.
+func (r *VirtualMachineReconciler) statusWritten(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine) {
+	metrics.RecordStatusWrite(statusWriteKind, metrics.StatusWriteWritten)
+	if baseline := statusBaselineFrom(ctx); baseline != nil {
+		baseline.status = vm.Status.DeepCopy()
+	}
+}
+
+// statusChanged reports whether desired differs from stored in anything
+// worth a write. Usage is compared only once stored's guest stats sample is
+// usageInterval old, or when guest stats appear or disappear.
+func statusChanged(stored, desired *infravirtrigaudiov1beta1.VirtualMachineStatus, usageInterval time.Duration) bool {
+	if (stored.GuestStats == nil) != (desired.GuestStats == nil) {
+		return true
+	}
+	if stored.GuestStats != nil && desired.GuestStats.ObservedAt.Sub(stored.GuestStats.ObservedAt.Time) >= usageInterval {
+		return !equality.Semantic.DeepEqual(stored, desired)
+	}
+
+	a, b := stored.DeepCopy(), desired.DeepCopy()
+	a.GuestStats, b.GuestStats = nil, nil
+	a.Provider, b.Provider = stableProviderKeys(a.Provider), stableProviderKeys(b.Provider)
+	return !equality.Semantic.DeepEqual(a, b)
+}
+
+// stableProviderKeys returns provider without the values of
+// volatileProviderKeys. The keys stay, so one appearing or disappearing is
+// still a change.
+func stableProviderKeys(provider map[string]string) map[string]string {
+	if provider == nil {
+		return nil
+	}
+	out := maps.Clone(provider)
+	for k := range out {
+		if volatileProviderKeys[k] {
+			out[k] = ""
+		}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+func TestStatusChanged(t *testing.T) {
+	sampled := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	stored := infravirtrigaudiov1beta1.VirtualMachineStatus{
+		Phase:      infravirtrigaudiov1beta1.VirtualMachinePhaseRunning,
+		PowerState: infravirtrigaudiov1beta1.PowerStateOn,
+		Provider:   map[string]string{"host": "esx-1", "resources.uptimeSeconds": "100", "cpu_time": "12.0s"},
+		GuestStats: &infravirtrigaudiov1beta1.VMGuestStats{UptimeSeconds: ptr.To[int64](100), ObservedAt: metav1.NewTime(sampled)},
+	}
+	k8s.SetCondition(&stored.Conditions, k8s.ConditionReady, metav1.ConditionTrue, "Running", "VM is running")
+
+	cases := []struct {
+		name   string
+		change func(*infravirtrigaudiov1beta1.VirtualMachineStatus)
+		want   bool
+	}{
+		{"nothing", func(*infravirtrigaudiov1beta1.VirtualMachineStatus) {}, false},
+		{"condition set again", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			k8s.SetCondition(&s.Conditions, k8s.ConditionReady, metav1.ConditionTrue, "Running", "VM is running")
+		}, false},
+		{"condition changed", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			k8s.SetCondition(&s.Conditions, k8s.ConditionReady, metav1.ConditionFalse, "PoweredOff", "VM is off")
+		}, true},
+		{"phase", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseReconfiguring
+		}, true},
+		{"volatile provider key", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.Provider["resources.uptimeSeconds"] = "160"
+			s.Provider["cpu_time"] = "13.5s"
+		}, false},
+		{"volatile provider key added", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.Provider["resources.cpuUsagePercent"] = "3"
+		}, true},
+		{"stable provider key", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.Provider["host"] = "esx-2"
+		}, true},
+		{"guest stats sampled again", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.GuestStats = &infravirtrigaudiov1beta1.VMGuestStats{UptimeSeconds: ptr.To[int64](160), ObservedAt: metav1.NewTime(sampled.Add(time.Minute))}
+		}, false},
+		{"guest stats sample due", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.GuestStats = &infravirtrigaudiov1beta1.VMGuestStats{UptimeSeconds: ptr.To[int64](400), ObservedAt: metav1.NewTime(sampled.Add(5 * time.Minute))}
+		}, true},
+		{"guest stats gone", func(s *infravirtrigaudiov1beta1.VirtualMachineStatus) {
+			s.GuestStats = nil
+		}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			desired := stored.DeepCopy()
+			tc.change(desired)
+			assert.Equal(t, tc.want, statusChanged(&stored, desired, 5*time.Minute))
+		})
+	}
+}
+
+func TestWriteStatus(t *testing.T) {
+	k8sProv, class := providerAndClass("default")
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{}, k8sProv, class, baseVM("default"))
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(baseVM("default")), vm))
+	ctx := withStatusBaseline(context.Background(), vm)
+
+	// Nothing changed: no write, so the resourceVersion stays.
+	version := vm.ResourceVersion
+	require.NoError(t, r.writeStatus(ctx, vm))
+	assert.Equal(t, version, vm.ResourceVersion)
+
+	vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseRunning
+	require.NoError(t, r.writeStatus(ctx, vm))
+	assert.NotEqual(t, version, vm.ResourceVersion)
+	stored := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), stored))
+	assert.Equal(t, infravirtrigaudiov1beta1.VirtualMachinePhaseRunning, stored.Status.Phase)
+
+	// The write is the new baseline.
+	version = vm.ResourceVersion
+	require.NoError(t, r.writeStatus(ctx, vm))
+	assert.Equal(t, version, vm.ResourceVersion)
+
+	// A write based on an outdated VM conflicts instead of overwriting.
+	stale := vm.DeepCopy()
+	stored.Status.Message = "written elsewhere"
+	require.NoError(t, r.Status().Update(ctx, stored))
+	stale.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseFailed
+	err := r.writeStatus(ctx, stale)
+	assert.True(t, apierrors.IsConflict(err), "got %v", err)
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Reconfiguring condition reasons for the power cycle that applies pending
+// changes
+const (
+	ReasonUpdateCycleInProgress = "UpdateCycleInProgress"
+	ReasonUpdateCycleFailed     = events.ReasonUpdateCycleFailed
+)
+
+// updateCycleShutdownTimeout is how long the guest is given to shut down
+// before the VM is powered off.
+const updateCycleShutdownTimeout = 5 * time.Minute
+
+// updateCyclePollInterval is how often a running power cycle is polled.
+const updateCyclePollInterval = 10 * time.Second
+
+// updateStrategy returns the VM's update strategy, LiveOnly when unset.
+func updateStrategy(vm *infravirtrigaudiov1beta1.VirtualMachine) infravirtrigaudiov1beta1.VMUpdateStrategy {
+	if vm.Spec.UpdateStrategy == "" {
+		return infravirtrigaudiov1beta1.VMUpdateStrategyLiveOnly
+	}
+	return vm.Spec.UpdateStrategy
+}
+
+// updateCycleRunning reports whether a power cycle is under way.
+func updateCycleRunning(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	cycle := vm.Status.UpdateCycle
+	return cycle != nil &&
+		cycle.Phase != infravirtrigaudiov1beta1.UpdateCyclePhaseCompleted &&
+		cycle.Phase != infravirtrigaudiov1beta1.UpdateCyclePhaseFailed
+}
+
+// updateCycleApproved reports whether the VM's pending changes should be
+// applied by a power cycle now. Automatic approves them unless a power
+// cycle for the same changes already failed at this generation; Manual
+// waits for ApplyPendingChangesAnnotation.
+func updateCycleApproved(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	switch updateStrategy(vm) {
+	case infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic:
+		last := vm.Status.UpdateCycle
+		return last == nil || last.Phase != infravirtrigaudiov1beta1.UpdateCyclePhaseFailed ||
+			last.Generation != vm.Generation || !slices.Equal(last.Changes, vm.Status.PendingPowerCycle)
+	case infravirtrigaudiov1beta1.VMUpdateStrategyManual:
+		_, ok := vm.Annotations[infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation]
+		return ok
+	default:
+		return false
+	}
+}
+
+// reconcileUpdateCycle starts the power cycle that applies the VM's pending
+// changes when its update strategy calls for one, and drives the one under
+// way: graceful shutdown (powering off after updateCycleShutdownTimeout),
+// reconfigure while off, power on. The VM is powered back on even when the
+// changes could not be applied. It reports whether it acted, in which case
+// the returned result ends the reconcile, so nothing else powers the VM on
+// or off mid-cycle.
+func (r *VirtualMachineReconciler) reconcileUpdateCycle(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+	providerName string,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
+	powerState string,
+) (ctrl.Result, bool) {
+	on := string(infravirtrigaudiov1beta1.PowerStateOn)
+	off := string(infravirtrigaudiov1beta1.PowerStateOff)
+
+	if !updateCycleRunning(vm) {
+		if len(vm.Status.PendingPowerCycle) == 0 || vm.Status.ReconfigureTaskRef != "" || powerState != on ||
+			!meantToRun(vm) || !updateCycleApproved(vm) {
+			return ctrl.Result{}, false
+		}
+		// A requested power operation or live migration goes first.
+		if powerOpPending(vm) || vm.Status.MigrationTaskRef != "" ||
+			(vm.Status.LastPowerOp != nil && vm.Status.LastPowerOp.Result == infravirtrigaudiov1beta1.PowerOpResultInProgress) {
+			return ctrl.Result{}, false
+		}
+		return r.startUpdateCycle(ctx, vm, providerInstance), true
+	}
+
+	cycle := vm.Status.UpdateCycle
+	switch cycle.Phase {
+	case infravirtrigaudiov1beta1.UpdateCyclePhaseShuttingDown:
+		if powerState == off {
+			return r.applyPendingChanges(ctx, vm, providerInstance, providerName, vmClass, vmImage, networks), true
+		}
+		if time.Since(cycle.LastTransitionTime.Time) < updateCycleShutdownTimeout {
+			return ctrl.Result{RequeueAfter: updateCyclePollInterval}, true
+		}
+		message := fmt.Sprintf("Guest did not shut down within %s; powering off", updateCycleShutdownTimeout)
+		if _, err := providerInstance.Power(ctx, vm.Status.ID, contracts.PowerOpOff); err != nil {
+			// The VM is still running, so there is nothing to roll back.
+			r.finishUpdateCycle(ctx, vm, fmt.Sprintf("failed to power off: %v", err))
+			return ctrl.Result{Requeue: true}, true
+		}
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonUpdateCycleForcedOff, message)
+		r.setUpdateCyclePhase(vm, infravirtrigaudiov1beta1.UpdateCyclePhasePoweringOff, message)
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: updateCyclePollInterval}, true
+
+	case infravirtrigaudiov1beta1.UpdateCyclePhasePoweringOff:
+		if powerState == off {
+			return r.applyPendingChanges(ctx, vm, providerInstance, providerName, vmClass, vmImage, networks), true
+		}
+		return ctrl.Result{RequeueAfter: updateCyclePollInterval}, true
+
+	case infravirtrigaudiov1beta1.UpdateCyclePhaseApplying:
+		return r.applyPendingChanges(ctx, vm, providerInstance, providerName, vmClass, vmImage, networks), true
+
+	default: // PoweringOn
+		if powerState == on || !meantToRun(vm) {
+			r.finishUpdateCycle(ctx, vm, cycle.Failure)
+			return ctrl.Result{Requeue: true}, true
+		}
+		// Power on is safe to repeat while the VM is still off.
+		if _, err := providerInstance.Power(ctx, vm.Status.ID, contracts.PowerOpOn); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to power VM back on; retrying")
+		}
+		return ctrl.Result{RequeueAfter: updateCyclePollInterval}, true
+	}
+}
+
+// meantToRun reports whether spec.powerState asks for the VM to be on.
+func meantToRun(vm *infravirtrigaudiov1beta1.VirtualMachine) bool {
+	return vm.Spec.PowerState == "" || vm.Spec.PowerState == infravirtrigaudiov1beta1.PowerStateOn
+}
+
+// startUpdateCycle records a new power cycle and asks the guest to shut
+// down. The cycle is written to status before the provider is called, and
+// a Manual approval is consumed once it is, so one approval power cycles
+// the VM once.
+func (r *VirtualMachineReconciler) startUpdateCycle(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	now := metav1.Now()
+	vm.Status.UpdateCycle = &infravirtrigaudiov1beta1.UpdateCycleStatus{
+		Phase:              infravirtrigaudiov1beta1.UpdateCyclePhaseShuttingDown,
+		Changes:            slices.Clone(vm.Status.PendingPowerCycle),
+		StartedAt:          now,
+		Generation:         vm.Generation,
+		LastTransitionTime: now,
+	}
+	vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseReconfiguring
+	message := fmt.Sprintf("Shutting down to apply %s", strings.Join(vm.Status.PendingPowerCycle, ", "))
+	k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, ReasonUpdateCycleInProgress, message)
+	if err := r.writeStatus(ctx, vm); err != nil {
+		logger.Error(err, "Failed to record power cycle; not starting it yet")
+		return ctrl.Result{RequeueAfter: updateCyclePollInterval}
+	}
+	if _, ok := vm.Annotations[infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation]; ok {
+		patch := client.MergeFrom(vm.DeepCopy())
+		delete(vm.Annotations, infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation)
+		if err := r.Patch(ctx, vm, patch); err != nil {
+			logger.Error(err, "Failed to clear apply-pending-changes annotation")
+		}
+	}
+
+	logger.Info("Power cycling VM to apply pending changes", "changes", vm.Status.UpdateCycle.Changes,
+		"strategy", updateStrategy(vm))
+	if _, err := providerInstance.Power(ctx, vm.Status.ID, contracts.PowerOpShutdownGraceful); err != nil {
+		r.finishUpdateCycle(ctx, vm, fmt.Sprintf("failed to shut down: %v", err))
+		return ctrl.Result{Requeue: true}
+	}
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonUpdateCycleStarted, message)
+	return ctrl.Result{RequeueAfter: updateCyclePollInterval}
+}
+
+// applyPendingChanges reconfigures the powered-off VM with every change
+// between its spec and what was last applied, then powers it back on. A
+// provider that still defers a change with the VM off fails the cycle
+// rather than starting another.
+func (r *VirtualMachineReconciler) applyPendingChanges(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+	providerName string,
+	vmClass *infravirtrigaudiov1beta1.VMClass,
+	vmImage *infravirtrigaudiov1beta1.VMImage,
+	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
+) ctrl.Result {
+	cycle := vm.Status.UpdateCycle
+
+	if cycle.TaskRef != "" {
+		done, err := providerInstance.IsTaskComplete(ctx, cycle.TaskRef)
+		switch {
+		case err != nil:
+			return r.powerOnAfterUpdate(ctx, vm, providerInstance, fmt.Sprintf("reconfigure task failed: %v", err))
+		case !done:
+			return ctrl.Result{RequeueAfter: updateCyclePollInterval}
+		}
+		cycle.TaskRef = ""
+		r.recordAppliedSpec(vm, vmClass, nil)
+		recordAppliedSecurityGroups(vm, networks)
+		return r.powerOnAfterUpdate(ctx, vm, providerInstance, "")
+	}
+
+	if cycle.Phase != infravirtrigaudiov1beta1.UpdateCyclePhaseApplying {
+		r.setUpdateCyclePhase(vm, infravirtrigaudiov1beta1.UpdateCyclePhaseApplying,
+			fmt.Sprintf("Applying %s", strings.Join(cycle.Changes, ", ")))
+	}
+	changes := desiredChangeSet(vm, vmClass)
+	changes.SecurityGroups = securityGroupChanges(vm, networks)
+	if changes.IsEmpty() {
+		// The spec went back to what the VM had; nothing is left to apply.
+		vm.Status.PendingPowerCycle = nil
+		return r.powerOnAfterUpdate(ctx, vm, providerInstance, "")
+	}
+
+	req, err := r.buildCreateRequest(ctx, vm, providerName, vmClass, vmImage, networks)
+	if err != nil {
+		return r.powerOnAfterUpdate(ctx, vm, providerInstance, err.Error())
+	}
+	changes = withAttachments(changes, req.Networks)
+	result, err := providerInstance.Reconfigure(ctx, vm.Status.ID, req, changes)
+	if err != nil {
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonReconfigureFailed, fmt.Sprintf("Failed to reconfigure VM: %v", err))
+		return r.powerOnAfterUpdate(ctx, vm, providerInstance, err.Error())
+	}
+	now := metav1.Now()
+	vm.Status.LastReconfigureTime = &now
+	if result.TaskRef != "" {
+		cycle.TaskRef = result.TaskRef
+		r.updateStatus(ctx, vm)
+		return ctrl.Result{RequeueAfter: updateCyclePollInterval}
+	}
+
+	r.recordAppliedSpec(vm, vmClass, result.PowerCycleRequired)
+	recordAppliedSecurityGroups(vm, networks)
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonReconfigured, "Applied "+strings.Join(changes.Keys(), ", ")+" while powered off")
+	failure := ""
+	if len(result.PowerCycleRequired) > 0 {
+		failure = fmt.Sprintf("the provider did not apply %s with the VM powered off", strings.Join(result.PowerCycleRequired, ", "))
+	}
+	return r.powerOnAfterUpdate(ctx, vm, providerInstance, failure)
+}
+
+// powerOnAfterUpdate powers the VM back on, recording failure, if any, as
+// the reason the changes were not applied. A VM whose spec.powerState no
+// longer asks for it to run is left off.
+func (r *VirtualMachineReconciler) powerOnAfterUpdate(
+	ctx context.Context,
+	vm *infravirtrigaudiov1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+	failure string,
+) ctrl.Result {
+	vm.Status.UpdateCycle.Failure = failure
+	if !meantToRun(vm) {
+		r.finishUpdateCycle(ctx, vm, failure)
+		return ctrl.Result{Requeue: true}
+	}
+
+	message := "Powering the VM back on"
+	if failure != "" {
+		message = fmt.Sprintf("Powering the VM back on without the changes: %s", failure)
+	}
+	r.setUpdateCyclePhase(vm, infravirtrigaudiov1beta1.UpdateCyclePhasePoweringOn, message)
+	if _, err := providerInstance.Power(ctx, vm.Status.ID, contracts.PowerOpOn); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to power VM back on; retrying")
+	}
+	r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonUpdateCyclePoweringOn, message)
+	r.updateStatus(ctx, vm)
+	return ctrl.Result{RequeueAfter: updateCyclePollInterval}
+}
+
+// finishUpdateCycle records the outcome of the power cycle: Completed, or
+// Failed with failure as the reason.
+func (r *VirtualMachineReconciler) finishUpdateCycle(ctx context.Context, vm *infravirtrigaudiov1beta1.VirtualMachine, failure string) {
+	cycle := vm.Status.UpdateCycle
+	cycle.Failure = failure
+	vm.Status.Phase = infravirtrigaudiov1beta1.VirtualMachinePhaseRunning
+	if failure == "" {
+		cycle.Phase = infravirtrigaudiov1beta1.UpdateCyclePhaseCompleted
+		message := fmt.Sprintf("Applied %s; the VM is running again", strings.Join(cycle.Changes, ", "))
+		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, k8s.ReasonReconcileSuccess, message)
+		r.recordEvent(ctx, vm, corev1.EventTypeNormal, events.ReasonUpdateCycleCompleted, message)
+	} else {
+		cycle.Phase = infravirtrigaudiov1beta1.UpdateCyclePhaseFailed
+		message := fmt.Sprintf("Failed to apply %s: %s", strings.Join(cycle.Changes, ", "), failure)
+		k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionFalse, ReasonUpdateCycleFailed, message)
+		r.recordEvent(ctx, vm, corev1.EventTypeWarning, events.ReasonUpdateCycleFailed, message)
+	}
+	cycle.LastTransitionTime = metav1.Now()
+	cycle.TaskRef = ""
+	log.FromContext(ctx).Info("Power cycle finished", "phase", cycle.Phase, "failure", failure)
+	r.updateStatus(ctx, vm)
+}
+
+// setUpdateCyclePhase moves the power cycle to phase, with message on the
+// Reconfiguring condition.
+func (r *VirtualMachineReconciler) setUpdateCyclePhase(vm *infravirtrigaudiov1beta1.VirtualMachine, phase infravirtrigaudiov1beta1.UpdateCyclePhase, message string) {
+	vm.Status.UpdateCycle.Phase = phase
+	vm.Status.UpdateCycle.LastTransitionTime = metav1.Now()
+	k8s.SetReconfiguringCondition(&vm.Status.Conditions, metav1.ConditionTrue, ReasonUpdateCycleInProgress, message)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// updateCycleProvider records Power and Reconfigure calls.
+type updateCycleProvider struct {
+	stubProvider
+	ops            []contracts.PowerOp
+	reconfigured   []contracts.ChangeSet
+	reconfigureErr error
+}
+
+func (p *updateCycleProvider) Power(_ context.Context, _ string, op contracts.PowerOp) (string, error) {
+	p.ops = append(p.ops, op)
+	return "", nil
+}
+
+func (p *updateCycleProvider) Reconfigure(_ context.Context, _ string, _ contracts.CreateRequest, changes contracts.ChangeSet) (contracts.ReconfigureResult, error) {
+	p.reconfigured = append(p.reconfigured, changes)
+	return contracts.ReconfigureResult{}, p.reconfigureErr
+}
+
+// updateCycleVM returns a running VM whose CPU change waits for a power
+// cycle.
+func updateCycleVM(strategy infravirtrigaudiov1beta1.VMUpdateStrategy) *infravirtrigaudiov1beta1.VirtualMachine {
+	vm := baseVM("default")
+	vm.Generation = 3
+	vm.Spec.UpdateStrategy = strategy
+	vm.Spec.Resources = &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: ptr.To[int32](8)}
+	vm.Status.ID = "vm-1"
+	vm.Status.CurrentResources = &infravirtrigaudiov1beta1.VirtualMachineResources{
+		CPU:       ptr.To[int32](4),
+		MemoryMiB: ptr.To[int64](8192),
+	}
+	vm.Status.PendingPowerCycle = []string{contracts.ChangeCPU}
+	return vm
+}
+
+func TestReconcileUpdateCycle_Automatic(t *testing.T) {
+	ctx := context.Background()
+	_, class := providerAndClass("default")
+	p := &updateCycleProvider{}
+	vm := updateCycleVM(infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic)
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+	step := func(powerState string) bool {
+		_, acted := r.reconcileUpdateCycle(ctx, vm, p, "test-prov", class, nil, nil, powerState)
+		return acted
+	}
+
+	require.True(t, step("On"))
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpShutdownGraceful}, p.ops)
+	require.NotNil(t, vm.Status.UpdateCycle)
+	assert.Equal(t, infravirtrigaudiov1beta1.UpdateCyclePhaseShuttingDown, vm.Status.UpdateCycle.Phase)
+	assert.Equal(t, []string{contracts.ChangeCPU}, vm.Status.UpdateCycle.Changes)
+
+	var stored infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &stored))
+	require.NotNil(t, stored.Status.UpdateCycle, "the cycle is recorded before the VM is shut down")
+
+	// The guest is still shutting down.
+	require.True(t, step("On"))
+	assert.Len(t, p.ops, 1)
+
+	require.True(t, step("Off"))
+	require.Len(t, p.reconfigured, 1)
+	assert.Equal(t, &contracts.Int32Change{Old: 4, New: 8}, p.reconfigured[0].CPU)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpShutdownGraceful, contracts.PowerOpOn}, p.ops)
+	assert.Equal(t, infravirtrigaudiov1beta1.UpdateCyclePhasePoweringOn, vm.Status.UpdateCycle.Phase)
+	assert.Equal(t, int32(8), *vm.Status.CurrentResources.CPU)
+	assert.Empty(t, vm.Status.PendingPowerCycle)
+
+	require.True(t, step("On"))
+	assert.Equal(t, infravirtrigaudiov1beta1.UpdateCyclePhaseCompleted, vm.Status.UpdateCycle.Phase)
+	assert.Empty(t, vm.Status.UpdateCycle.Failure)
+
+	assert.False(t, step("On"), "nothing is left to apply")
+	assert.Len(t, p.ops, 2)
+}
+
+func TestReconcileUpdateCycle_RollbackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	_, class := providerAndClass("default")
+	p := &updateCycleProvider{reconfigureErr: errors.New("datastore busy")}
+	vm := updateCycleVM(infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic)
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+	step := func(powerState string) bool {
+		_, acted := r.reconcileUpdateCycle(ctx, vm, p, "test-prov", class, nil, nil, powerState)
+		return acted
+	}
+
+	require.True(t, step("On"))
+	require.True(t, step("Off"))
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpShutdownGraceful, contracts.PowerOpOn}, p.ops,
+		"the VM is powered back on although the change failed")
+	assert.Equal(t, []string{contracts.ChangeCPU}, vm.Status.PendingPowerCycle)
+
+	require.True(t, step("On"))
+	assert.Equal(t, infravirtrigaudiov1beta1.UpdateCyclePhaseFailed, vm.Status.UpdateCycle.Phase)
+	assert.Contains(t, vm.Status.UpdateCycle.Failure, "datastore busy")
+
+	assert.False(t, step("On"), "a failed cycle is not retried for the same spec")
+	assert.Len(t, p.ops, 2)
+
+	vm.Generation++
+	assert.True(t, step("On"), "a new spec gets a new attempt")
+	assert.Len(t, p.ops, 3)
+}
+
+func TestReconcileUpdateCycle_ShutdownTimeout(t *testing.T) {
+	ctx := context.Background()
+	_, class := providerAndClass("default")
+	p := &updateCycleProvider{}
+	vm := updateCycleVM(infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic)
+	vm.Status.UpdateCycle = &infravirtrigaudiov1beta1.UpdateCycleStatus{
+		Phase:              infravirtrigaudiov1beta1.UpdateCyclePhaseShuttingDown,
+		Changes:            []string{contracts.ChangeCPU},
+		StartedAt:          metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+	}
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+	_, acted := r.reconcileUpdateCycle(ctx, vm, p, "test-prov", class, nil, nil, "On")
+	require.True(t, acted)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpOff}, p.ops)
+	assert.Equal(t, infravirtrigaudiov1beta1.UpdateCyclePhasePoweringOff, vm.Status.UpdateCycle.Phase)
+}
+
+func TestReconcileUpdateCycle_Manual(t *testing.T) {
+	ctx := context.Background()
+	_, class := providerAndClass("default")
+	p := &updateCycleProvider{}
+	vm := updateCycleVM(infravirtrigaudiov1beta1.VMUpdateStrategyManual)
+	r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+	_, acted := r.reconcileUpdateCycle(ctx, vm, p, "test-prov", class, nil, nil, "On")
+	assert.False(t, acted, "Manual waits for the annotation")
+
+	var stored infravirtrigaudiov1beta1.VirtualMachine
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &stored))
+	stored.Annotations = map[string]string{infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation: ""}
+	require.NoError(t, r.Update(ctx, &stored))
+	vm = &stored
+
+	_, acted = r.reconcileUpdateCycle(ctx, vm, p, "test-prov", class, nil, nil, "On")
+	require.True(t, acted)
+	assert.Equal(t, []contracts.PowerOp{contracts.PowerOpShutdownGraceful}, p.ops)
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vm), &stored))
+	assert.NotContains(t, stored.Annotations, infravirtrigaudiov1beta1.ApplyPendingChangesAnnotation,
+		"one approval power cycles the VM once")
+}
+
+func TestReconcileUpdateCycle_NotStarted(t *testing.T) {
+	cases := []struct {
+		name       string
+		mutate     func(vm *infravirtrigaudiov1beta1.VirtualMachine)
+		powerState string
+	}{
+		{name: "LiveOnly", mutate: func(vm *infravirtrigaudiov1beta1.VirtualMachine) { vm.Spec.UpdateStrategy = "" }, powerState: "On"},
+		{name: "nothing pending", mutate: func(vm *infravirtrigaudiov1beta1.VirtualMachine) { vm.Status.PendingPowerCycle = nil }, powerState: "On"},
+		{name: "already off", mutate: func(*infravirtrigaudiov1beta1.VirtualMachine) {}, powerState: "Off"},
+		{name: "meant to be off", mutate: func(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+			vm.Spec.PowerState = infravirtrigaudiov1beta1.PowerStateOff
+		}, powerState: "On"},
+		{name: "power op requested", mutate: func(vm *infravirtrigaudiov1beta1.VirtualMachine) {
+			vm.Spec.PowerOpRequest = &infravirtrigaudiov1beta1.PowerOpRequest{Op: infravirtrigaudiov1beta1.PowerOpRestart, RequestID: "r1"}
+		}, powerState: "On"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, class := providerAndClass("default")
+			p := &updateCycleProvider{}
+			vm := updateCycleVM(infravirtrigaudiov1beta1.VMUpdateStrategyAutomatic)
+			tc.mutate(vm)
+			r := newTestReconciler(coverageTestScheme(t), &stubResolver{provider: p}, vm)
+
+			_, acted := r.reconcileUpdateCycle(context.Background(), vm, p, "test-prov", class, nil, nil, tc.powerState)
+			assert.False(t, acted)
+			assert.Empty(t, p.ops)
+			assert.Nil(t, vm.Status.UpdateCycle)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// VMUsesProvider reports whether vm references provider. The provider
+// namespace defaults to the VM's own namespace.
+func VMUsesProvider(vm *infravirtrigaudiov1beta1.VirtualMachine, provider *infravirtrigaudiov1beta1.Provider) bool {
+	return k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace) == client.ObjectKeyFromObject(provider)
+}
+
+// allocationKey is the label set of the allocation gauges: the provider
+// and the namespace it is in, which is not always the VM's.
+type allocationKey struct {
+	provider  string
+	namespace string
+}
+
+type trackedAllocation struct {
+	key   allocationKey
+	alloc usage.VMAllocation
+}
+
+// allocationTracker holds the last resolved allocation of every VM and
+// publishes per-(provider, namespace) sums. Sums are recomputed from the
+// full set on every change rather than adjusted by deltas, so a missed
+// event cannot leave a gauge permanently off, and a restarted manager
+// converges as soon as the initial list of VMs has been reconciled.
+type allocationTracker struct {
+	mu  sync.Mutex
+	vms map[types.NamespacedName]trackedAllocation
+}
+
+func newAllocationTracker() *allocationTracker {
+	return &allocationTracker{vms: make(map[types.NamespacedName]trackedAllocation)}
+}
+
+// vmAllocations is shared by all VirtualMachine reconcilers, as the
+// gauges it drives are process-wide.
+var vmAllocations = newAllocationTracker()
+
+// set records the allocation of a VM and refreshes the gauges it affects,
+// including the old series when the VM moved to another provider.
+func (t *allocationTracker) set(vm *infravirtrigaudiov1beta1.VirtualMachine, alloc usage.VMAllocation) {
+	name := types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name}
+	ref := k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace)
+	key := allocationKey{provider: ref.Name, namespace: ref.Namespace}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev, existed := t.vms[name]
+	if existed && prev.key == key && prev.alloc == alloc {
+		return
+	}
+	t.vms[name] = trackedAllocation{key: key, alloc: alloc}
+	if existed && prev.key != key {
+		t.publish(prev.key)
+	}
+	t.publish(key)
+}
+
+// remove forgets a deleted VM and refreshes its gauges.
+func (t *allocationTracker) remove(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev, existed := t.vms[name]
+	if !existed {
+		return
+	}
+	delete(t.vms, name)
+	t.publish(prev.key)
+}
+
+// sum totals the tracked VMs with the given key. Callers hold t.mu.
+func (t *allocationTracker) sum(key allocationKey) (usage.VMAllocation, int) {
+	var total usage.VMAllocation
+	count := 0
+	for _, v := range t.vms {
+		if v.key != key {
+			continue
+		}
+		total.CPU += v.alloc.CPU
+		total.MemoryBytes += v.alloc.MemoryBytes
+		total.DiskBytes += v.alloc.DiskBytes
+		count++
+	}
+	return total, count
+}
+
+// publish writes the current sum for key, dropping the series once no VM
+// is left. Callers hold t.mu.
+func (t *allocationTracker) publish(key allocationKey) {
+	total, count := t.sum(key)
+	if count == 0 {
+		metrics.DeleteVMAllocation(key.provider, key.namespace)
+		return
+	}
+	metrics.SetVMAllocation(key.provider, key.namespace, float64(total.CPU), float64(total.MemoryBytes))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+)
+
+func usageVM(namespace, name, provider string) *infravirtrigaudiov1beta1.VirtualMachine {
+	return &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: provider},
+		},
+	}
+}
+
+// allocationGauge returns the value of an allocation gauge, and false when
+// the series does not exist.
+func allocationGauge(t *testing.T, metric, provider, namespace string) (float64, bool) {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != metric {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["provider"] == provider && labels["namespace"] == namespace {
+				return m.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestAllocationTracker_RecomputesSums(t *testing.T) {
+	tracker := newAllocationTracker()
+	const cpuMetric = "virtrigaud_vm_allocated_cpu_cores"
+	const memMetric = "virtrigaud_vm_allocated_memory_bytes"
+
+	a := usageVM("usage-test", "a", "pve-usage")
+	b := usageVM("usage-test", "b", "pve-usage")
+	tracker.set(a, usage.VMAllocation{CPU: 2, MemoryBytes: 2 << 30})
+	tracker.set(b, usage.VMAllocation{CPU: 4, MemoryBytes: 8 << 30})
+
+	v, ok := allocationGauge(t, cpuMetric, "pve-usage", "usage-test")
+	require.True(t, ok)
+	assert.Equal(t, float64(6), v)
+	v, _ = allocationGauge(t, memMetric, "pve-usage", "usage-test")
+	assert.Equal(t, float64(10<<30), v)
+
+	// Resizing replaces the VM's share instead of adding to it.
+	tracker.set(b, usage.VMAllocation{CPU: 1, MemoryBytes: 1 << 30})
+	v, _ = allocationGauge(t, cpuMetric, "pve-usage", "usage-test")
+	assert.Equal(t, float64(3), v)
+
+	// Moving a VM to another provider updates both series.
+	a.Spec.ProviderRef.Name = "vsphere-usage"
+	tracker.set(a, usage.VMAllocation{CPU: 2, MemoryBytes: 2 << 30})
+	v, _ = allocationGauge(t, cpuMetric, "pve-usage", "usage-test")
+	assert.Equal(t, float64(1), v)
+	v, _ = allocationGauge(t, cpuMetric, "vsphere-usage", "usage-test")
+	assert.Equal(t, float64(2), v)
+
+	// Deleting the last VM of a series drops it; removing twice is harmless.
+	tracker.remove(types.NamespacedName{Namespace: "usage-test", Name: "b"})
+	tracker.remove(types.NamespacedName{Namespace: "usage-test", Name: "b"})
+	_, ok = allocationGauge(t, cpuMetric, "pve-usage", "usage-test")
+	assert.False(t, ok)
+	_, ok = allocationGauge(t, memMetric, "pve-usage", "usage-test")
+	assert.False(t, ok)
+}
+
+func TestAllocationTracker_KeysByProviderReference(t *testing.T) {
+	tracker := newAllocationTracker()
+	const cpuMetric = "virtrigaud_vm_allocated_cpu_cores"
+
+	// VMs in two namespaces share the provider in usage-infra.
+	a := usageVM("usage-team-a", "a", "pve-shared")
+	a.Spec.ProviderRef.Namespace = "usage-infra"
+	b := usageVM("usage-team-b", "b", "pve-shared")
+	b.Spec.ProviderRef.Namespace = "usage-infra"
+	// A provider of the same name in the VM's own namespace is another one.
+	c := usageVM("usage-team-a", "c", "pve-shared")
+	tracker.set(a, usage.VMAllocation{CPU: 2})
+	tracker.set(b, usage.VMAllocation{CPU: 4})
+	tracker.set(c, usage.VMAllocation{CPU: 8})
+
+	v, ok := allocationGauge(t, cpuMetric, "pve-shared", "usage-infra")
+	require.True(t, ok)
+	assert.Equal(t, float64(6), v)
+	v, ok = allocationGauge(t, cpuMetric, "pve-shared", "usage-team-a")
+	require.True(t, ok)
+	assert.Equal(t, float64(8), v)
+	_, ok = allocationGauge(t, cpuMetric, "pve-shared", "usage-team-b")
+	assert.False(t, ok)
+}
@@ -38,6 +38,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
 	"github.com/projectbeskar/virtrigaud/internal/k8s"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
@@ -93,6 +95,8 @@ type VMAdoptionReconciler struct {
 	client.Client
 	Scheme         *runtime.Scheme
 	RemoteResolver *remote.Resolver
+	// Config supplies concurrency; nil uses the defaults.
+	Config *config.ConfigStore
 }
 
 // VMAdoptionReconciler watches Providers and, on the adoption annotation,
@@ -147,6 +151,8 @@ func (r *VMAdoptionReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	ctx = audit.WithSubject(ctx, &provider)
+
 	// Check if adoption is requested
 	adoptVMs := provider.Annotations[AdoptionAnnotation]
 	logger.Info("Checking adoption annotation", "provider", provider.Name, "adoptVMs", adoptVMs)
@@ -280,14 +286,14 @@ func (r *VMAdoptionReconciler) discoverUnmanagedVMs(ctx context.Context, provide
 	managedVMIDs := make(map[string]bool)
 	for _, vm := range vmList.Items {
 		// Check if VM is managed by this provider
-		providerNamespace := vm.Namespace
-		if vm.Spec.ProviderRef.Namespace != "" {
-			providerNamespace = vm.Spec.ProviderRef.Namespace
-		}
-
-		if vm.Spec.ProviderRef.Name == provider.Name && providerNamespace == provider.Namespace {
-			// Use status.ID if available, otherwise use name
+		if VMUsesProvider(&vm, provider) {
+			// Use status.ID if available, otherwise use name. A VM still
+			// waiting to adopt (e.g. just restored by vrtg admin
+			// import-state) already claims its spec.adoptExisting.id.
 			vmID := vm.Status.ID
+			if vmID == "" && adoptsExisting(&vm) {
+				vmID = vm.Spec.AdoptExisting.ID
+			}
 			if vmID == "" {
 				vmID = vm.Name
 			}
@@ -340,7 +346,7 @@ func (r *VMAdoptionReconciler) adoptVM(ctx context.Context, provider *infravirtr
 				logger.Info("Fixing Status.ID for existing adopted VM", "vm_name", vmName, "vm_id", vmInfo.ID)
 				existingVM.Status.ID = vmInfo.ID
 				existingVM.Status.PowerState = infravirtrigaudiov1beta1.PowerState(vmInfo.PowerState)
-				existingVM.Status.IPs = vmInfo.IPs
+				setStatusAddresses(existingVM, vmAddresses(vmInfo.IPs, nil))
 				existingVM.Status.Provider = vmInfo.ProviderRaw
 				if err := r.Status().Update(ctx, existingVM); err != nil {
 					logger.Error(err, "Failed to update Status.ID for existing adopted VM", "vm_name", vmName)
@@ -430,7 +436,7 @@ func (r *VMAdoptionReconciler) adoptVM(ctx context.Context, provider *infravirtr
 	// knows the VM already exists and skips creation
 	vm.Status.ID = vmInfo.ID
 	vm.Status.PowerState = infravirtrigaudiov1beta1.PowerState(vmInfo.PowerState)
-	vm.Status.IPs = vmInfo.IPs
+	setStatusAddresses(vm, vmAddresses(vmInfo.IPs, nil))
 	vm.Status.Provider = vmInfo.ProviderRaw
 	if err := r.Status().Update(ctx, vm); err != nil {
 		// If status update fails, log error but don't fail adoption
@@ -609,7 +615,7 @@ func (r *VMAdoptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("vmadoption").
 		Watches(&infravirtrigaudiov1beta1.Provider{}, &handler.EnqueueRequestForObject{}).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1, // Process one provider at a time
+			MaxConcurrentReconciles: r.Config.Get().Concurrency.VMAdoption,
 		}).
 		WithEventFilter(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
@@ -18,14 +18,24 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
 )
 
 // VMClassReconciler reconciles a VMClass object
@@ -34,24 +44,61 @@ type VMClassReconciler struct {
 	Scheme *runtime.Scheme
 }
 
-// VMClassReconciler is a watch-only stub: its Reconcile is a no-op and it
-// holds an informer cache on VMClass via For(). Least-privilege (issue #152)
-// therefore grants only the get;list;watch the cache requires — no write,
-// status, or finalizer verbs are exercised. (vmadoption_controller.go owns
-// the create/update grant for the VMClass objects it provisions during
-// adoption.) The apiGroup is infra.virtrigaud.io; the previous doubled value
-// generated a phantom rule for a non-existent group.
+// The VMClass controller only writes status: it resolves spec.inheritFrom,
+// validates the quantity fields of the result and publishes it together
+// with the normalized integer form (the same MiB/GiB
+// values buildCreateRequest sends to providers). No write or finalizer verbs
+// on the object itself are exercised here (issue #152);
+// vmadoption_controller.go owns the create/update grant for the VMClass
+// objects it provisions during adoption.
 // +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmclasses/status,verbs=get;update;patch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the VMClass object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.20.4/pkg/reconcile
+const (
+	// vmClassReasonValid, vmClassReasonInvalidSpec, vmClassReasonParentNotFound
+	// and vmClassReasonInvalidInheritance are the reasons of the Validated
+	// condition.
+	vmClassReasonValid              = "Valid"
+	vmClassReasonInvalidSpec        = "InvalidSpec"
+	vmClassReasonParentNotFound     = "ParentNotFound"
+	vmClassReasonInvalidInheritance = "InvalidInheritance"
+)
+
+// hugePageSizeMiB maps the PerformanceProfile.HugePages enum to a page size
+// in MiB; "any" has no fixed size and is absent.
+var hugePageSizeMiB = map[string]int64{
+	"2Mi": 2,
+	"1Gi": 1024,
+}
+
+// validateVMClass reports the first spec error, naming the offending field.
+func validateVMClass(spec *infravirtrigaudiov1beta1.VMClassSpec) error {
+	memMiB := usage.ClassMemoryMiB(spec)
+	if memMiB < 1 {
+		return fmt.Errorf("spec.memory: %s is below the 1Mi minimum", spec.Memory.String())
+	}
+	// The contract carries MemoryMiB as int32.
+	if memMiB > int64(^uint32(0)>>1) {
+		return fmt.Errorf("spec.memory: %s exceeds the supported maximum", spec.Memory.String())
+	}
+	if spec.DiskDefaults != nil && spec.DiskDefaults.Size.Sign() < 0 {
+		return fmt.Errorf("spec.diskDefaults.size: %s must not be negative", spec.DiskDefaults.Size.String())
+	}
+	if pp := spec.PerformanceProfile; pp != nil {
+		if page, ok := hugePageSizeMiB[pp.HugePages]; ok && memMiB%page != 0 {
+			return fmt.Errorf("spec.performanceProfile.hugePages: memory of %dMiB is not a multiple of the %s page size",
+				memMiB, pp.HugePages)
+		}
+	}
+	if mb := spec.MemoryBalloon; mb != nil && mb.MinimumMiB != nil && int64(*mb.MinimumMiB) > memMiB {
+		return fmt.Errorf("spec.memoryBalloon.minimumMiB: %dMiB exceeds the class memory of %dMiB",
+			*mb.MinimumMiB, memMiB)
+	}
+	return nil
+}
+
+// Reconcile resolves and validates the VMClass spec and records the resolved
+// spec, the normalized quantities and a Validated condition in status.
 func (r *VMClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
 	timer := metrics.NewReconcileTimer("VMClass")
 	defer func() {
@@ -65,10 +112,66 @@ func (r *VMClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		timer.Finish(outcome)
 	}()
 
-	_ = logf.FromContext(ctx)
+	log := logf.FromContext(ctx)
+
+	vmClass := &infravirtrigaudiov1beta1.VMClass{}
+	if err := r.Get(ctx, req.NamespacedName, vmClass); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
 
-	// TODO(user): your logic here
+	condition := metav1.Condition{
+		Type:               infravirtrigaudiov1beta1.VMClassConditionValidated,
+		Status:             metav1.ConditionTrue,
+		Reason:             vmClassReasonValid,
+		Message:            "VMClass spec is valid",
+		ObservedGeneration: vmClass.Generation,
+	}
+	var (
+		normalized *infravirtrigaudiov1beta1.VMClassNormalized
+		resolved   *vmclass.Resolved
+	)
+	resolved, err := vmclass.Resolve(ctx, r.Client, vmClass)
+	switch {
+	case apierrors.IsNotFound(err):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = vmClassReasonParentNotFound
+		condition.Message = err.Error()
+	case errors.Is(err, vmclass.ErrInheritance):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = vmClassReasonInvalidInheritance
+		condition.Message = err.Error()
+	case err != nil:
+		return ctrl.Result{}, err
+	default:
+		if err := validateVMClass(&resolved.Spec); err != nil {
+			log.Info("VMClass spec is invalid", "error", err.Error())
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = vmClassReasonInvalidSpec
+			condition.Message = err.Error()
+		} else {
+			normalized = &infravirtrigaudiov1beta1.VMClassNormalized{
+				MemoryMiB:   usage.ClassMemoryMiB(&resolved.Spec),
+				DiskSizeGiB: usage.ClassDiskSizeGiB(&resolved.Spec),
+			}
+		}
+	}
 
+	before := vmClass.Status.DeepCopy()
+	vmClass.Status.Normalized = normalized
+	vmClass.Status.ResolvedSpec = nil
+	vmClass.Status.InheritanceChain = nil
+	if resolved != nil {
+		vmClass.Status.ResolvedSpec = &resolved.Spec
+		vmClass.Status.InheritanceChain = resolved.Chain
+	}
+	vmClass.Status.ObservedGeneration = vmClass.Generation
+	meta.SetStatusCondition(&vmClass.Status.Conditions, condition)
+	if equality.Semantic.DeepEqual(before, &vmClass.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Status().Update(ctx, vmClass); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -76,6 +179,25 @@ func (r *VMClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 func (r *VMClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infravirtrigaudiov1beta1.VMClass{}).
+		Watches(&infravirtrigaudiov1beta1.VMClass{}, handler.EnqueueRequestsFromMapFunc(r.childClasses)).
 		Named("vmclass").
 		Complete(r)
 }
+
+// childClasses maps a VMClass to the classes that inherit from it directly.
+// Their status update in turn requeues their own children, so a change
+// reaches the whole subtree.
+func (r *VMClassReconciler) childClasses(ctx context.Context, obj client.Object) []reconcile.Request {
+	classes := &infravirtrigaudiov1beta1.VMClassList{}
+	if err := r.List(ctx, classes, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list VMClasses inheriting from a changed VMClass", "vmclass", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, class := range classes.Items {
+		if class.Spec.InheritFrom != nil && class.Spec.InheritFrom.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&class)})
+		}
+	}
+	return requests
+}
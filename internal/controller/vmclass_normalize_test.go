@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func reconcileVMClass(t *testing.T, spec infravirtrigaudiov1beta1.VMClassSpec) *infravirtrigaudiov1beta1.VMClass {
+	t.Helper()
+	sch := runtime.NewScheme()
+	require.NoError(t, infravirtrigaudiov1beta1.AddToScheme(sch))
+	vmClass := &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cls", Namespace: "default", Generation: 3},
+		Spec:       spec,
+	}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(vmClass).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VMClass{}).Build()
+
+	r := &VMClassReconciler{Client: cli, Scheme: sch}
+	key := types.NamespacedName{Name: "cls", Namespace: "default"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	got := &infravirtrigaudiov1beta1.VMClass{}
+	require.NoError(t, cli.Get(context.Background(), key, got))
+	return got
+}
+
+func TestVMClassReconcile_NormalizesQuantities(t *testing.T) {
+	got := reconcileVMClass(t, infravirtrigaudiov1beta1.VMClassSpec{
+		CPU:    2,
+		Memory: resource.MustParse("4G"), // 3814.7 MiB
+		DiskDefaults: &infravirtrigaudiov1beta1.DiskDefaults{
+			Size: resource.MustParse("40G"), // 37.25 GiB
+		},
+	})
+
+	require.NotNil(t, got.Status.Normalized)
+	assert.Equal(t, int64(3814), got.Status.Normalized.MemoryMiB, "memory rounds down to whole MiB")
+	assert.Equal(t, int64(38), got.Status.Normalized.DiskSizeGiB, "disk rounds up to whole GiB")
+	assert.Equal(t, int64(3), got.Status.ObservedGeneration)
+
+	cond := meta.FindStatusCondition(got.Status.Conditions, infravirtrigaudiov1beta1.VMClassConditionValidated)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+}
+
+func TestVMClassReconcile_InvalidSpecNamesField(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  infravirtrigaudiov1beta1.VMClassSpec
+		field string
+	}{
+		{
+			name:  "memory below 1Mi",
+			spec:  infravirtrigaudiov1beta1.VMClassSpec{CPU: 1, Memory: resource.MustParse("512Ki")},
+			field: "spec.memory",
+		},
+		{
+			name: "memory not a multiple of the huge page size",
+			spec: infravirtrigaudiov1beta1.VMClassSpec{
+				CPU:                1,
+				Memory:             resource.MustParse("1536Mi"),
+				PerformanceProfile: &infravirtrigaudiov1beta1.PerformanceProfile{HugePages: "1Gi"},
+			},
+			field: "spec.performanceProfile.hugePages",
+		},
+		{
+			name: "balloon minimum above the class memory",
+			spec: infravirtrigaudiov1beta1.VMClassSpec{
+				CPU:           1,
+				Memory:        resource.MustParse("2Gi"),
+				MemoryBalloon: &infravirtrigaudiov1beta1.MemoryBalloon{Enabled: true, MinimumMiB: ptr.To[int32](4096)},
+			},
+			field: "spec.memoryBalloon.minimumMiB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reconcileVMClass(t, tt.spec)
+
+			assert.Nil(t, got.Status.Normalized)
+			cond := meta.FindStatusCondition(got.Status.Conditions, infravirtrigaudiov1beta1.VMClassConditionValidated)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, vmClassReasonInvalidSpec, cond.Reason)
+			assert.Contains(t, cond.Message, tt.field)
+		})
+	}
+}
+
+func TestVMClassReconcile_ResolvesInheritance(t *testing.T) {
+	sch := runtime.NewScheme()
+	require.NoError(t, infravirtrigaudiov1beta1.AddToScheme(sch))
+	parent := &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{
+			CPU:          2,
+			Memory:       resource.MustParse("4Gi"),
+			DiskDefaults: &infravirtrigaudiov1beta1.DiskDefaults{Size: resource.MustParse("80Gi")},
+		},
+	}
+	child := &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "large", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{
+			InheritFrom: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "base"},
+			Memory:      resource.MustParse("16Gi"),
+		},
+	}
+	orphan := &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{
+			InheritFrom: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "missing"},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(parent, child, orphan).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VMClass{}).Build()
+	r := &VMClassReconciler{Client: cli, Scheme: sch}
+	ctx := context.Background()
+
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "large"}}},
+		r.childClasses(ctx, parent), "a change to base requeues the classes inheriting from it")
+
+	reconcile := func(name string) *infravirtrigaudiov1beta1.VMClass {
+		key := types.NamespacedName{Namespace: "default", Name: name}
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		got := &infravirtrigaudiov1beta1.VMClass{}
+		require.NoError(t, cli.Get(ctx, key, got))
+		return got
+	}
+
+	got := reconcile("large")
+	assert.Equal(t, []string{"base", "large"}, got.Status.InheritanceChain)
+	require.NotNil(t, got.Status.ResolvedSpec)
+	assert.Equal(t, int32(2), got.Status.ResolvedSpec.CPU)
+	assert.Nil(t, got.Status.ResolvedSpec.InheritFrom)
+	require.NotNil(t, got.Status.Normalized)
+	assert.Equal(t, int64(16384), got.Status.Normalized.MemoryMiB)
+	assert.Equal(t, int64(80), got.Status.Normalized.DiskSizeGiB)
+
+	got = reconcile("orphan")
+	assert.Nil(t, got.Status.ResolvedSpec)
+	cond := meta.FindStatusCondition(got.Status.Conditions, infravirtrigaudiov1beta1.VMClassConditionValidated)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, vmClassReasonParentNotFound, cond.Reason)
+}
@@ -19,31 +19,34 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
 	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
 	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
 )
 
 const (
-	// vmCloneFinalizer guards a VMClone so the controller runs its (minimal)
-	// cleanup before the object disappears. Deleting a VMClone never cascades
-	// to the produced target VM — only the finalizer is removed.
-	vmCloneFinalizer = "clone.infra.virtrigaud.io/finalizer"
-
 	// errReasonGetClone is the metrics.RecordError reason for a failed VMClone
 	// Get in the reconcile entry path.
 	errReasonGetClone = "get-clone"
@@ -76,6 +79,10 @@ type VMCloneReconciler struct {
 	// VirtualMachine controller.
 	RemoteResolver ProviderResolver
 	Recorder       record.EventRecorder
+
+	// Config supplies the cluster-wide maintenance setting; nil uses the
+	// defaults.
+	Config *config.ConfigStore
 }
 
 // NewVMCloneReconciler creates a new VMClone reconciler.
@@ -123,9 +130,9 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		timer.Finish(outcome)
 	}()
 
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmclone-%s/%s", req.Namespace, req.Name))
+	ctx = logging.StartReconcile(ctx, "vmclone", req.NamespacedName)
 	logger := logging.FromContext(ctx)
-	logger.Info("Reconciling VMClone", "clone", req.NamespacedName)
+	logger.Info("Reconciling VMClone")
 
 	clone := &infrav1beta1.VMClone{}
 	if err := r.Get(ctx, req.NamespacedName, clone); err != nil {
@@ -138,6 +145,10 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
+	ctx = audit.WithSubject(ctx, clone)
+	ctx = logging.WithLogFields(ctx, "", string(clone.Status.Phase))
+	logger = logging.FromContext(ctx)
+
 	// Handle deletion: removing a VMClone must NOT delete the target VM. Just
 	// drop the finalizer.
 	if !clone.DeletionTimestamp.IsZero() {
@@ -145,20 +156,23 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 
 	// Add finalizer if needed.
-	if !controllerutil.ContainsFinalizer(clone, vmCloneFinalizer) {
-		controllerutil.AddFinalizer(clone, vmCloneFinalizer)
-		if err := r.Update(ctx, clone); err != nil {
-			logger.Error(err, "Failed to add finalizer")
-			metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
-			return ctrl.Result{}, err
-		}
+	if added, err := k8s.EnsureFinalizer(ctx, r.Client, clone, infrav1beta1.VMCloneFinalizer); err != nil {
+		logger.Error(err, "Failed to add finalizer")
+		metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	} else if added {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Terminal states: nothing further to do.
+	// Terminal states: nothing further to do until ttlAfterCompletion, if
+	// set, expires.
 	switch clone.Status.Phase {
 	case infrav1beta1.ClonePhaseReady, infrav1beta1.ClonePhaseFailed:
-		return ctrl.Result{}, nil
+		if cleanupPending(clone.Status.PendingCleanup) {
+			return ctrl.Result{RequeueAfter: cleanupExpiryRecheck}, nil
+		}
+		return expireAfterTTL(ctx, r.Client, clone, "VMClone",
+			clone.Spec.TTLAfterCompletion, cloneFinishedAt(clone))
 	}
 
 	clone.Status.ObservedGeneration = clone.Generation
@@ -188,19 +202,20 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonCloning,
 			"waiting for source VM to be provisioned"), nil
 	}
+	if identityMismatched(sourceVM) {
+		return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonCloning,
+			"waiting for source VM's IdentityMismatch to be resolved"), nil
+	}
 
 	// Resolve the source provider — the produced VM lives on this provider
 	// (same-provider clone only in this MVP).
 	provider := &infrav1beta1.Provider{}
-	providerKey := client.ObjectKey{
-		Name:      sourceVM.Spec.ProviderRef.Name,
-		Namespace: sourceVM.Namespace,
-	}
-	if sourceVM.Spec.ProviderRef.Namespace != "" {
-		providerKey.Namespace = sourceVM.Spec.ProviderRef.Namespace
-	}
-	if err := r.Get(ctx, providerKey, provider); err != nil {
+	providerKey := k8s.RefKey(sourceVM.Spec.ProviderRef, sourceVM.Namespace)
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", sourceVM.Spec.ProviderRef, sourceVM.Namespace, provider); err != nil {
 		logger.Error(err, "Failed to get provider", "provider", providerKey.Name)
+		if k8s.IsCrossNamespaceRefDenied(err) {
+			return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonReferenceNotAllowed, err.Error()), nil
+		}
 		return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonProviderError,
 			fmt.Sprintf("provider %q not found", providerKey.Name)), nil
 	}
@@ -217,6 +232,13 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	if targetNamespace == "" {
 		targetNamespace = clone.Namespace
 	}
+	if err := k8s.CheckNamespaceAccess(ctx, r.Client, "spec.target.namespace",
+		clone.Namespace, targetNamespace, clone.Spec.Target.Name); err != nil {
+		if k8s.IsCrossNamespaceRefDenied(err) {
+			return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonReferenceNotAllowed, err.Error()), nil
+		}
+		return ctrl.Result{}, err
+	}
 
 	linked := r.requestedCloneType(clone) == infrav1beta1.CloneTypeLinkedClone
 
@@ -259,6 +281,15 @@ func (r *VMCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	if blocked, res := r.gateCloneQuota(ctx, clone, sourceVM, targetNamespace); blocked {
+		return res, nil
+	}
+
+	// A provider in maintenance clones nothing new until it ends.
+	if message, held := maintenanceHold(provider, r.Config.Get(), clone); held {
+		return r.holdForMaintenance(ctx, clone, message), nil
+	}
+
 	// Issue the clone.
 	return r.startClone(ctx, clone, sourceVM, provider, providerInstance, targetNamespace, linked)
 }
@@ -282,6 +313,15 @@ func (r *VMCloneReconciler) startClone(
 			"provider does not support clone"), nil
 	}
 
+	customization, err := r.cloneCustomization(ctx, clone, sourceVM)
+	var secretMissing errCustomizationSecretMissing
+	switch {
+	case stderrors.As(err, &secretMissing):
+		return r.markPending(ctx, clone, infrav1beta1.VMCloneReasonCustomizationFailed, err.Error()), nil
+	case err != nil:
+		return r.markCustomizationFailed(ctx, clone, nil, err.Error()), nil
+	}
+
 	req := contracts.CloneRequest{
 		SourceVmID:    sourceVM.Status.ID,
 		TargetName:    clone.Spec.Target.Name,
@@ -289,6 +329,7 @@ func (r *VMCloneReconciler) startClone(
 		ClassJSON:     r.classJSON(ctx, clone),
 		PlacementJSON: r.placementJSON(ctx, clone),
 		CustomizeJSON: r.customizeJSON(ctx, clone),
+		Customization: customization,
 	}
 
 	now := metav1.Now()
@@ -305,21 +346,26 @@ func (r *VMCloneReconciler) startClone(
 	resp, err := cloner.Clone(ctx, req)
 	if err != nil {
 		logger.Error(err, "Clone RPC failed")
-		r.Recorder.Event(clone, "Warning", infrav1beta1.VMCloneReasonProviderError,
-			fmt.Sprintf("Clone failed: %v", err))
 		return r.markFailed(ctx, clone, infrav1beta1.VMCloneReasonProviderError,
 			fmt.Sprintf("clone failed: %v", err)), nil
 	}
 
 	clone.Status.TargetVMID = resp.TargetVmID
 	clone.Status.TaskRef = resp.TaskRef
+	if resp.TaskRef != "" {
+		clone.Status.LastTask = startedTask(provider, providerInstance, "Clone", resp.TaskRef)
+	}
+	if customization != nil {
+		recordCloneCustomization(clone, resp)
+	}
 
 	// Persist the target VM ID BEFORE attempting to bind. The bind step writes
 	// the target VM's Status.ID and can lose a race with the VirtualMachine
 	// controller, which reconciles the freshly-created adopted target VM
 	// immediately. If that happens and we requeue, this persisted TargetVMID is
 	// what lets the next reconcile resume binding (via the idempotency check)
-	// instead of issuing a second clone.
+	// instead of issuing a second clone. A customization failure is persisted
+	// with it, so that the resumed bind still leaves the target powered off.
 	if err := r.updateStatus(ctx, clone); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -330,7 +376,7 @@ func (r *VMCloneReconciler) startClone(
 		return r.bindTargetVM(ctx, clone, sourceVM, targetNamespace, resp.TargetVmID, linked)
 	}
 
-	logger.Info("Clone task started", "task_ref", resp.TaskRef)
+	logger.Info("Clone task started", "task_ref", resp.TaskRef, "task_link", clone.Status.LastTask.Link)
 	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 }
 
@@ -349,10 +395,15 @@ func (r *VMCloneReconciler) pollCloneTask(
 	done, err := providerInstance.IsTaskComplete(ctx, clone.Status.TaskRef)
 	if err != nil {
 		logger.Error(err, "Clone task failed", "task_ref", clone.Status.TaskRef)
-		r.Recorder.Event(clone, "Warning", infrav1beta1.VMCloneReasonProviderError,
-			fmt.Sprintf("Clone task failed: %v", err))
+		r.recordOrphanedTarget(ctx, clone, sourceVM, "clone task failed")
+		task := clone.Status.LastTask
+		if task == nil || task.ID != clone.Status.TaskRef {
+			task = &infrav1beta1.TaskRecord{ID: clone.Status.TaskRef, Operation: "Clone"}
+			clone.Status.LastTask = task
+		}
+		task.Error = err.Error()
 		return r.markFailed(ctx, clone, infrav1beta1.VMCloneReasonProviderError,
-			fmt.Sprintf("clone task failed: %v", err)), nil
+			fmt.Sprintf("clone task failed: %v%s", err, taskNote(task))), nil
 	}
 	if !done {
 		logger.Info("Clone task still in progress", "task_ref", clone.Status.TaskRef)
@@ -404,12 +455,20 @@ func (r *VMCloneReconciler) bindTargetVM(
 	case errors.IsNotFound(err):
 		targetVM = r.buildTargetVM(clone, sourceVM, targetNamespace)
 		if createErr := r.Create(ctx, targetVM); createErr != nil && !errors.IsAlreadyExists(createErr) {
+			// The provider VM exists already; a quota admitted by
+			// gateCloneQuota but since used up elsewhere only delays
+			// its VirtualMachine.
+			if quota.IsDenied(createErr) {
+				logger.Info("Target VM CR denied by quota, waiting", "vm", vmKey.Name, "reason", createErr.Error())
+				r.markPending(ctx, clone, ReasonQuotaExceeded, createErr.Error())
+				return ctrl.Result{RequeueAfter: quotaRecheckInterval}, nil
+			}
 			logger.Error(createErr, "Failed to create target VM CR", "vm", vmKey.Name)
+			r.recordOrphanedTarget(ctx, clone, sourceVM, "target VirtualMachine could not be created")
 			return r.markFailed(ctx, clone, infrav1beta1.VMCloneReasonProviderError,
 				fmt.Sprintf("failed to create target VM: %v", createErr)), nil
 		}
-		r.Recorder.Event(clone, "Normal", infrav1beta1.VMCloneReasonCompleted,
-			fmt.Sprintf("Created target VM %q", vmKey.Name))
+		r.recordEvent(ctx, clone, corev1.EventTypeNormal, events.ReasonCloneCompleted, fmt.Sprintf("Created target VM %q%s", vmKey.Name, taskNote(clone.Status.LastTask)))
 	case err != nil:
 		logger.Error(err, "Failed to get target VM CR", "vm", vmKey.Name)
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -435,6 +494,9 @@ func (r *VMCloneReconciler) bindTargetVM(
 	}
 
 	logger.Info("Target VM bound to cloned VM", "vm", vmKey.Name, "vm_id", targetVMID)
+	if message, failed := cloneCustomizationFailed(clone); failed {
+		return r.markCustomizationFailed(ctx, clone, targetVM, message), nil
+	}
 	return r.finalizeReady(ctx, clone, targetVM)
 }
 
@@ -442,6 +504,8 @@ func (r *VMCloneReconciler) bindTargetVM(
 // the adopted label and clone provenance annotations, inherits the source VM's
 // provider and class (unless the clone overrides the class), and copies the
 // requested networks/placement. Status.ID is seeded separately by bindTargetVM.
+// A clone whose customization failed is created powered off, so that an
+// uncustomized copy of the source does not boot next to it.
 func (r *VMCloneReconciler) buildTargetVM(
 	clone *infrav1beta1.VMClone,
 	sourceVM *infrav1beta1.VirtualMachine,
@@ -461,10 +525,17 @@ func (r *VMCloneReconciler) buildTargetVM(
 		annotations[k] = v
 	}
 
+	providerRef := sourceVM.Spec.ProviderRef
 	classRef := sourceVM.Spec.ClassRef
 	if clone.Spec.Target.ClassRef != nil && clone.Spec.Target.ClassRef.Name != "" {
 		classRef = infrav1beta1.ObjectRef{Name: clone.Spec.Target.ClassRef.Name}
 	}
+	// A VM cloned into another namespace keeps pointing at the objects the
+	// source resolved, rather than same-named ones in its own namespace.
+	if targetNamespace != sourceVM.Namespace {
+		providerRef.Namespace = k8s.RefNamespace(providerRef, sourceVM.Namespace)
+		classRef.Namespace = k8s.RefNamespace(classRef, sourceVM.Namespace)
+	}
 
 	targetVM := &infrav1beta1.VirtualMachine{
 		ObjectMeta: metav1.ObjectMeta{
@@ -474,7 +545,7 @@ func (r *VMCloneReconciler) buildTargetVM(
 			Annotations: annotations,
 		},
 		Spec: infrav1beta1.VirtualMachineSpec{
-			ProviderRef: sourceVM.Spec.ProviderRef,
+			ProviderRef: providerRef,
 			ClassRef:    classRef,
 		},
 	}
@@ -484,6 +555,9 @@ func (r *VMCloneReconciler) buildTargetVM(
 	if clone.Spec.Target.PlacementRef != nil && clone.Spec.Target.PlacementRef.Name != "" {
 		targetVM.Spec.PlacementRef = &infrav1beta1.LocalObjectReference{Name: clone.Spec.Target.PlacementRef.Name}
 	}
+	if _, failed := cloneCustomizationFailed(clone); failed {
+		targetVM.Spec.PowerState = infrav1beta1.PowerStateOff
+	}
 	return targetVM
 }
 
@@ -545,9 +619,12 @@ func (r *VMCloneReconciler) gateLinkedClone(
 func (r *VMCloneReconciler) handleDeletion(ctx context.Context, clone *infrav1beta1.VMClone) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
 	logger.Info("Deleting VMClone (target VM is intentionally preserved)")
+	if records := clone.Status.PendingCleanup; len(records) > 0 {
+		r.recordEvent(ctx, clone, corev1.EventTypeWarning, events.ReasonArtifactCleanupAbandoned,
+			"VMClone deleted before its cleanup finished, remove by hand: "+describeCleanupRecords(records))
+	}
 
-	controllerutil.RemoveFinalizer(clone, vmCloneFinalizer)
-	if err := r.Update(ctx, clone); err != nil {
+	if err := k8s.RemoveFinalizer(ctx, r.Client, clone, infrav1beta1.VMCloneFinalizer); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
 		metrics.RecordError(errReasonRemoveFinalizer, metrics.ComponentManager)
 		return ctrl.Result{}, err
@@ -555,6 +632,26 @@ func (r *VMCloneReconciler) handleDeletion(ctx context.Context, clone *infrav1be
 	return ctrl.Result{}, nil
 }
 
+// recordOrphanedTarget records the provider VM of a clone that failed after
+// the provider created it, for CleanupWorker to delete. Nothing is recorded
+// when the provider returned no VM ID.
+func (r *VMCloneReconciler) recordOrphanedTarget(ctx context.Context, clone *infrav1beta1.VMClone, sourceVM *infrav1beta1.VirtualMachine, reason string) {
+	if clone.Status.TargetVMID == "" {
+		return
+	}
+	providerKey := k8s.RefKey(sourceVM.Spec.ProviderRef, sourceVM.Namespace)
+	rec := infrav1beta1.CleanupRecord{
+		Kind:        infrav1beta1.CleanupArtifactVM,
+		ID:          clone.Status.TargetVMID,
+		ProviderRef: infrav1beta1.ObjectRef{Name: providerKey.Name, Namespace: providerKey.Namespace},
+		Reason:      reason,
+	}
+	if addCleanupRecord(&clone.Status.PendingCleanup, rec) {
+		r.recordEvent(ctx, clone, corev1.EventTypeNormal, events.ReasonArtifactCleanupScheduled,
+			fmt.Sprintf("Scheduled deletion of %s: %s", describeArtifact(rec), reason))
+	}
+}
+
 // markFailed sets the VMClone to the Failed phase with a Ready=False / Failed
 // condition and persists status. It does not requeue, and Failed is terminal:
 // the Reconcile entry short-circuits on a Failed phase, so a failed clone is
@@ -565,19 +662,34 @@ func (r *VMCloneReconciler) markFailed(ctx context.Context, clone *infrav1beta1.
 	logger := logging.FromContext(ctx)
 	logger.Info("VMClone failed", "reason", reason, "message", message)
 
+	now := metav1.Now()
 	clone.Status.Phase = infrav1beta1.ClonePhaseFailed
 	clone.Status.Message = message
 	clone.Status.TaskRef = ""
+	clone.Status.CompletionTime = &now
 	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionReady,
 		metav1.ConditionFalse, reason, message)
 	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionFailed,
 		metav1.ConditionTrue, reason, message)
-	r.Recorder.Event(clone, "Warning", reason, message)
+	r.recordEvent(ctx, clone, corev1.EventTypeWarning, events.ReasonCloneFailed, fmt.Sprintf("%s: %s", reason, message))
 
 	_ = r.updateStatus(ctx, clone) //nolint:errcheck // status errors retried next reconcile
 	return ctrl.Result{}
 }
 
+// cloneFinishedAt returns when a Ready or Failed clone finished. Clones
+// that failed before markFailed set the completion time fall back to the
+// Failed condition.
+func cloneFinishedAt(clone *infrav1beta1.VMClone) *metav1.Time {
+	if clone.Status.CompletionTime != nil {
+		return clone.Status.CompletionTime
+	}
+	if c := k8s.GetCondition(clone.Status.Conditions, infrav1beta1.VMCloneConditionFailed); c != nil && c.Status == metav1.ConditionTrue {
+		return &c.LastTransitionTime
+	}
+	return nil
+}
+
 // markPending sets the VMClone to the Pending phase (still waiting on a
 // prerequisite) and requeues.
 func (r *VMCloneReconciler) markPending(ctx context.Context, clone *infrav1beta1.VMClone, reason, message string) ctrl.Result {
@@ -589,6 +701,22 @@ func (r *VMCloneReconciler) markPending(ctx context.Context, clone *infrav1beta1
 	return ctrl.Result{RequeueAfter: 30 * time.Second}
 }
 
+// holdForMaintenance keeps the VMClone Pending while its provider is in
+// maintenance, with an event the first time. It is requeued when the
+// maintenance ends.
+func (r *VMCloneReconciler) holdForMaintenance(ctx context.Context, clone *infrav1beta1.VMClone, message string) ctrl.Result {
+	logging.FromContext(ctx).Info("Not cloning while the provider is in maintenance", "message", message)
+	if !heldForMaintenance(clone.Status.Conditions) {
+		r.recordEvent(ctx, clone, corev1.EventTypeNormal, events.ReasonProviderInMaintenance, message)
+	}
+	clone.Status.Phase = infrav1beta1.ClonePhasePending
+	clone.Status.Message = message
+	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionReady,
+		metav1.ConditionFalse, ReasonProviderInMaintenance, message)
+	_ = r.updateStatus(ctx, clone) //nolint:errcheck // status errors retried next reconcile
+	return ctrl.Result{RequeueAfter: maintenanceRecheck}
+}
+
 // requestedCloneType returns the clone type from spec.options, defaulting to
 // FullClone when unset.
 func (r *VMCloneReconciler) requestedCloneType(clone *infrav1beta1.VMClone) infrav1beta1.CloneType {
@@ -598,15 +726,16 @@ func (r *VMCloneReconciler) requestedCloneType(clone *infrav1beta1.VMClone) infr
 	return infrav1beta1.CloneTypeFullClone
 }
 
-// classJSON best-effort marshals the referenced VMClass override to JSON, or
+// classJSON best-effort marshals the resolved spec of the referenced VMClass
+// override to JSON, or
 // returns "" when no class override is referenced or the lookup/marshal fails.
 func (r *VMCloneReconciler) classJSON(ctx context.Context, clone *infrav1beta1.VMClone) string {
 	if clone.Spec.Target.ClassRef == nil || clone.Spec.Target.ClassRef.Name == "" {
 		return ""
 	}
-	vmClass := &infrav1beta1.VMClass{}
 	key := client.ObjectKey{Namespace: clone.Namespace, Name: clone.Spec.Target.ClassRef.Name}
-	if err := r.Get(ctx, key, vmClass); err != nil {
+	vmClass, err := vmclass.Get(ctx, r.Client, key)
+	if err != nil {
 		return ""
 	}
 	data, err := json.Marshal(vmClass.Spec)
@@ -650,6 +779,11 @@ func (r *VMCloneReconciler) getProviderInstance(ctx context.Context, provider *i
 	return r.RemoteResolver.GetProvider(ctx, provider)
 }
 
+// recordEvent emits an event on the VMClone carrying its phase.
+func (r *VMCloneReconciler) recordEvent(ctx context.Context, clone *infrav1beta1.VMClone, eventType, reason, message string) {
+	events.Emit(ctx, r.Recorder, clone, eventType, reason, string(clone.Status.Phase), message)
+}
+
 // updateStatus persists the VMClone status subresource.
 func (r *VMCloneReconciler) updateStatus(ctx context.Context, clone *infrav1beta1.VMClone) error {
 	if err := r.Status().Update(ctx, clone); err != nil {
@@ -661,7 +795,48 @@ func (r *VMCloneReconciler) updateStatus(ctx context.Context, clone *infrav1beta
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VMCloneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexFields(mgr, &infrav1beta1.VMClone{}, map[string]client.IndexerFunc{
+		cloneSourceVMIndex:       indexCloneSourceVM,
+		cloneSourceSnapshotIndex: indexCloneSourceSnapshot,
+	}); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1beta1.VMClone{}).
+		Watches(&infrav1beta1.VirtualMachine{}, enqueueDependents(r.clonesForVM),
+			builder.WithPredicates(vmProvisioningChanged)).
+		Watches(&infrav1beta1.VMSnapshot{}, enqueueDependents(r.clonesForSnapshot),
+			builder.WithPredicates(snapshotPhaseChanged)).
+		Watches(&infrav1beta1.Provider{}, enqueueDependents(r.heldClonesForProvider),
+			builder.WithPredicates(providerMaintenanceEnded)).
 		Complete(r)
 }
+
+// clonesForVM maps a VirtualMachine to the VMClones of it, so that a clone
+// waiting for its source to be provisioned proceeds when it is.
+func (r *VMCloneReconciler) clonesForVM(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMCloneList{}, cloneSourceVMIndex, obj)
+}
+
+// clonesForSnapshot maps a VMSnapshot to the VMClones taken from it.
+func (r *VMCloneReconciler) clonesForSnapshot(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMCloneList{}, cloneSourceSnapshotIndex, obj)
+}
+
+// heldClonesForProvider maps a Provider to the VMClones its maintenance
+// holds, so that they start as soon as it ends.
+func (r *VMCloneReconciler) heldClonesForProvider(ctx context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, vm := range vmsOnProvider(ctx, r.Client, obj) {
+		clones := &infrav1beta1.VMCloneList{}
+		if err := r.List(ctx, clones, client.MatchingFields{cloneSourceVMIndex: client.ObjectKeyFromObject(&vm).String()}); err != nil {
+			continue
+		}
+		for i := range clones.Items {
+			if heldForMaintenance(clones.Items[i].Status.Conditions) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clones.Items[i])})
+			}
+		}
+	}
+	return requests
+}
@@ -408,7 +408,7 @@ func TestVMClone_ResumeSeedsStatusIDOnExistingTarget(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "clone-resume",
 			Namespace:  ns,
-			Finalizers: []string{vmCloneFinalizer},
+			Finalizers: []string{infrav1beta1.VMCloneFinalizer},
 		},
 		Spec: infrav1beta1.VMCloneSpec{
 			Source: infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "src-vm"}},
@@ -460,7 +460,7 @@ func TestVMClone_DeletionDoesNotDeleteTarget(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              "clone-del",
 			Namespace:         ns,
-			Finalizers:        []string{vmCloneFinalizer},
+			Finalizers:        []string{infrav1beta1.VMCloneFinalizer},
 			DeletionTimestamp: &now,
 		},
 		Spec: infrav1beta1.VMCloneSpec{
@@ -481,7 +481,7 @@ func TestVMClone_DeletionDoesNotDeleteTarget(t *testing.T) {
 	got := &infrav1beta1.VMClone{}
 	err = r.Get(context.Background(), client.ObjectKeyFromObject(clone), got)
 	if err == nil {
-		assert.NotContains(t, got.Finalizers, vmCloneFinalizer)
+		assert.NotContains(t, got.Finalizers, infrav1beta1.VMCloneFinalizer)
 	}
 
 	// Target VM must still exist.
@@ -0,0 +1,231 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// errCustomizationSecretMissing marks a customization that cannot be
+// resolved yet because a referenced Secret does not exist.
+type errCustomizationSecretMissing struct{ error }
+
+// cloneCustomization resolves spec.customization into what the provider
+// applies: the user-data Secret is read and each network override is given
+// the index of its NIC on the clone. It returns nil when nothing is to be
+// customized, and an errCustomizationSecretMissing while a Secret is missing.
+func (r *VMCloneReconciler) cloneCustomization(
+	ctx context.Context,
+	clone *infrav1beta1.VMClone,
+	sourceVM *infrav1beta1.VirtualMachine,
+) (*contracts.CloneCustomization, error) {
+	spec := clone.Spec.Customization
+	if spec == nil {
+		return nil, nil
+	}
+	if unsupported := unsupportedCloneCustomization(spec); len(unsupported) > 0 {
+		return nil, fmt.Errorf("spec.customization: %s not supported for clones", strings.Join(unsupported, ", "))
+	}
+
+	out := &contracts.CloneCustomization{Hostname: spec.Hostname, Domain: spec.Domain}
+	if spec.UserData != nil && spec.UserData.CloudInit != nil {
+		userData, err := cloudInitUserData(ctx, r.Client, clone.Namespace, spec.UserData.CloudInit)
+		if errors.IsNotFound(err) {
+			return nil, errCustomizationSecretMissing{err}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("spec.customization.userData: %w", err)
+		}
+		out.UserData = userData
+	}
+
+	nics := clone.Spec.Target.Networks
+	if len(nics) == 0 {
+		nics = sourceVM.Spec.Networks
+	}
+	seen := map[int]string{}
+	for i, n := range spec.Networks {
+		override, err := cloneNetworkOverride(n, i, nics)
+		if err != nil {
+			return nil, fmt.Errorf("spec.customization.networks[%d]: %w", i, err)
+		}
+		if other, dup := seen[override.Index]; dup {
+			return nil, fmt.Errorf("spec.customization.networks[%d]: %q and %q are the same NIC", i, other, n.Name)
+		}
+		seen[override.Index] = n.Name
+		out.Networks = append(out.Networks, override)
+	}
+
+	if out.Hostname == "" && out.Domain == "" && out.UserData == "" && len(out.Networks) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// unsupportedCloneCustomization lists the fields of spec no provider applies
+// to a clone. They are refused rather than dropped.
+func unsupportedCloneCustomization(spec *infrav1beta1.VMCustomization) []string {
+	var fields []string
+	if spec.TimeZone != "" {
+		fields = append(fields, "timeZone")
+	}
+	if spec.Sysprep != nil {
+		fields = append(fields, "sysprep")
+	}
+	if len(spec.Tags) > 0 {
+		fields = append(fields, "tags")
+	}
+	if len(spec.GuestCommands) > 0 {
+		fields = append(fields, "guestCommands")
+	}
+	if len(spec.Certificates) > 0 {
+		fields = append(fields, "certificates")
+	}
+	if ud := spec.UserData; ud != nil {
+		if ud.Ignition != nil {
+			fields = append(fields, "userData.ignition")
+		}
+		if ud.NetworkConfig != nil {
+			fields = append(fields, "userData.networkConfig")
+		}
+		if ud.VendorData != nil {
+			fields = append(fields, "userData.vendorData")
+		}
+	}
+	return fields
+}
+
+// cloneNetworkOverride converts the position-th network override. Its NIC
+// is the one of nics with the same name or, when nics is empty, the
+// position-th NIC.
+func cloneNetworkOverride(n infrav1beta1.NetworkCustomization, position int, nics []infrav1beta1.VMNetworkRef) (contracts.CloneNetworkOverride, error) {
+	o := contracts.CloneNetworkOverride{
+		Index:     position,
+		Name:      n.Name,
+		DHCP:      n.DHCP,
+		IPAddress: n.IPAddress,
+		Gateway:   n.Gateway,
+		DNS:       n.DNS,
+	}
+	if len(nics) > 0 {
+		o.Index = -1
+		names := make([]string, len(nics))
+		for i, nic := range nics {
+			names[i] = nic.Name
+			if nic.Name == n.Name {
+				o.Index = i
+			}
+		}
+		if o.Index < 0 {
+			return o, fmt.Errorf("network %q is not one of the target's networks (%s)", n.Name, strings.Join(names, ", "))
+		}
+	}
+
+	if n.MACAddress != "" {
+		mac, err := net.ParseMAC(n.MACAddress)
+		if err != nil {
+			return o, fmt.Errorf("macAddress: %w", err)
+		}
+		o.MACAddress = mac.String()
+	}
+
+	switch {
+	case n.DHCP && (n.IPAddress != "" || n.SubnetMask != "" || n.Gateway != ""):
+		return o, fmt.Errorf("network %q: set dhcp or a static address, not both", n.Name)
+	case n.IPAddress == "" && (n.SubnetMask != "" || n.Gateway != ""):
+		return o, fmt.Errorf("network %q: subnetMask and gateway need ipAddress", n.Name)
+	case n.IPAddress != "" && n.SubnetMask == "":
+		return o, fmt.Errorf("network %q: ipAddress needs subnetMask", n.Name)
+	case n.SubnetMask != "":
+		mask := net.ParseIP(n.SubnetMask).To4()
+		if mask == nil {
+			return o, fmt.Errorf("network %q: subnetMask %q is not an IPv4 mask", n.Name, n.SubnetMask)
+		}
+		prefix, bits := net.IPMask(mask).Size()
+		if bits == 0 {
+			return o, fmt.Errorf("network %q: subnetMask %q is not contiguous", n.Name, n.SubnetMask)
+		}
+		o.Prefix = prefix
+	}
+	return o, nil
+}
+
+// recordCloneCustomization records on clone whether the provider applied
+// the requested customization. A provider that does not say it did, as
+// one predating clone customization does not, counts as a failure.
+func recordCloneCustomization(clone *infrav1beta1.VMClone, resp contracts.CloneResponse) {
+	status := &infrav1beta1.CustomizationStatus{Started: true}
+	switch {
+	case resp.CustomizationError != "":
+		status.Message = resp.CustomizationError
+	case !resp.CustomizationApplied:
+		status.Message = "the provider did not apply the customization; it may predate clone customization"
+	default:
+		status.Completed = true
+		status.Message = "Customization applied"
+	}
+	clone.Status.CustomizationStatus = status
+
+	if status.Completed {
+		k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed,
+			metav1.ConditionFalse, infrav1beta1.VMCloneReasonCompleted, status.Message)
+		return
+	}
+	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed,
+		metav1.ConditionTrue, infrav1beta1.VMCloneReasonCustomizationFailed, status.Message)
+}
+
+// cloneCustomizationFailed reports whether the clone was made but could not
+// be customized, and why.
+func cloneCustomizationFailed(clone *infrav1beta1.VMClone) (string, bool) {
+	c := k8s.GetCondition(clone.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed)
+	if c == nil || c.Status != metav1.ConditionTrue {
+		return "", false
+	}
+	return c.Message, true
+}
+
+// markCustomizationFailed fails a clone whose customization cannot be
+// applied. targetVM is the bound, powered-off target, or nil when the
+// customization was refused before cloning.
+func (r *VMCloneReconciler) markCustomizationFailed(
+	ctx context.Context,
+	clone *infrav1beta1.VMClone,
+	targetVM *infrav1beta1.VirtualMachine,
+	message string,
+) ctrl.Result {
+	k8s.SetCondition(&clone.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed,
+		metav1.ConditionTrue, infrav1beta1.VMCloneReasonCustomizationFailed, message)
+	if targetVM != nil {
+		now := metav1.Now()
+		clone.Status.CompletionTime = &now
+		clone.Status.TargetRef = &infrav1beta1.LocalObjectReference{Name: targetVM.Name}
+		message = fmt.Sprintf("target VM %q was cloned but not customized, and is left powered off: %s", targetVM.Name, message)
+	}
+	return r.markFailed(ctx, clone, infrav1beta1.VMCloneReasonCustomizationFailed, message)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// customizedClone returns a VMClone of src-vm into clone-target with c as
+// its customization.
+func customizedClone(ns string, c *infrav1beta1.VMCustomization) *infrav1beta1.VMClone {
+	return &infrav1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone-1", Namespace: ns},
+		Spec: infrav1beta1.VMCloneSpec{
+			Source: infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "src-vm"}},
+			Target: infrav1beta1.VMCloneTarget{
+				Name:     "clone-target",
+				Networks: []infrav1beta1.VMNetworkRef{{Name: "mgmt"}, {Name: "lan"}},
+			},
+			Customization: c,
+		},
+	}
+}
+
+func TestCloneNetworkOverride(t *testing.T) {
+	nics := []infrav1beta1.VMNetworkRef{{Name: "mgmt"}, {Name: "lan"}}
+	tests := []struct {
+		name    string
+		n       infrav1beta1.NetworkCustomization
+		nics    []infrav1beta1.VMNetworkRef
+		want    contracts.CloneNetworkOverride
+		wantErr string
+	}{
+		{
+			name: "static address on a named network",
+			n: infrav1beta1.NetworkCustomization{
+				Name: "lan", IPAddress: "10.0.0.5", SubnetMask: "255.255.255.0", Gateway: "10.0.0.1",
+				DNS: []string{"10.0.0.2"}, MACAddress: "52-54-00-AA-BB-CC",
+			},
+			nics: nics,
+			want: contracts.CloneNetworkOverride{
+				Index: 1, Name: "lan", IPAddress: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1",
+				DNS: []string{"10.0.0.2"}, MACAddress: "52:54:00:aa:bb:cc",
+			},
+		},
+		{
+			name: "without named networks, the position is the NIC",
+			n:    infrav1beta1.NetworkCustomization{Name: "anything", DHCP: true},
+			want: contracts.CloneNetworkOverride{Index: 0, Name: "anything", DHCP: true},
+		},
+		{
+			name:    "unknown network",
+			n:       infrav1beta1.NetworkCustomization{Name: "storage", DHCP: true},
+			nics:    nics,
+			wantErr: `network "storage" is not one of the target's networks (mgmt, lan)`,
+		},
+		{
+			name:    "dhcp and a static address",
+			n:       infrav1beta1.NetworkCustomization{Name: "lan", DHCP: true, IPAddress: "10.0.0.5", SubnetMask: "255.255.255.0"},
+			nics:    nics,
+			wantErr: "not both",
+		},
+		{
+			name:    "address without a mask",
+			n:       infrav1beta1.NetworkCustomization{Name: "lan", IPAddress: "10.0.0.5"},
+			nics:    nics,
+			wantErr: "ipAddress needs subnetMask",
+		},
+		{
+			name:    "gateway without an address",
+			n:       infrav1beta1.NetworkCustomization{Name: "lan", Gateway: "10.0.0.1"},
+			nics:    nics,
+			wantErr: "need ipAddress",
+		},
+		{
+			name:    "non-contiguous mask",
+			n:       infrav1beta1.NetworkCustomization{Name: "lan", IPAddress: "10.0.0.5", SubnetMask: "255.0.255.0"},
+			nics:    nics,
+			wantErr: "not contiguous",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cloneNetworkOverride(tc.n, 0, tc.nics)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestVMClone_CustomizationApplied: the resolved customization reaches the
+// provider and an applied customization leaves the clone Ready.
+func TestVMClone_CustomizationApplied(t *testing.T) {
+	s := cloneTestScheme(t)
+	ns := "default"
+	clone := customizedClone(ns, &infrav1beta1.VMCustomization{
+		Hostname: "web-01",
+		UserData: &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{Inline: "#cloud-config\n"}},
+		Networks: []infrav1beta1.NetworkCustomization{{
+			Name: "lan", IPAddress: "10.0.0.5", SubnetMask: "255.255.255.0",
+		}},
+	})
+	cp := &clonerProvider{cloneResp: contracts.CloneResponse{TargetVmID: "vm-clone-999", CustomizationApplied: true}}
+	r := newCloneReconciler(s, &stubResolver{provider: cp},
+		runningProvider(ns, "prov-1"), sourceVMWithID(ns, "src-vm", "prov-1", "vm-source-123"), clone)
+
+	reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+
+	require.NotNil(t, cp.lastClone)
+	assert.Equal(t, &contracts.CloneCustomization{
+		Hostname: "web-01",
+		UserData: "#cloud-config\n",
+		Networks: []contracts.CloneNetworkOverride{{Index: 1, Name: "lan", IPAddress: "10.0.0.5", Prefix: 24}},
+	}, cp.lastClone.Customization)
+
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(clone), got))
+	assert.Equal(t, infrav1beta1.ClonePhaseReady, got.Status.Phase)
+	require.NotNil(t, got.Status.CustomizationStatus)
+	assert.True(t, got.Status.CustomizationStatus.Completed)
+	failed := readyCondition(got.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed)
+	require.NotNil(t, failed)
+	assert.Equal(t, metav1.ConditionFalse, failed.Status)
+}
+
+// TestVMClone_CustomizationFailed: a clone the provider made but could not
+// customize gets a powered-off target VM, and the clone fails with the
+// CloneCustomizationFailed condition.
+func TestVMClone_CustomizationFailed(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        contracts.CloneResponse
+		wantMessage string
+	}{
+		{
+			name:        "provider reports an error",
+			resp:        contracts.CloneResponse{TargetVmID: "vm-clone-999", CustomizationError: "no cloud-init drive"},
+			wantMessage: "no cloud-init drive",
+		},
+		{
+			name:        "provider does not apply it",
+			resp:        contracts.CloneResponse{TargetVmID: "vm-clone-999"},
+			wantMessage: "may predate clone customization",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := cloneTestScheme(t)
+			ns := "default"
+			clone := customizedClone(ns, &infrav1beta1.VMCustomization{Hostname: "web-01"})
+			cp := &clonerProvider{cloneResp: tc.resp}
+			r := newCloneReconciler(s, &stubResolver{provider: cp},
+				runningProvider(ns, "prov-1"), sourceVMWithID(ns, "src-vm", "prov-1", "vm-source-123"), clone)
+
+			reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+
+			got := &infrav1beta1.VMClone{}
+			require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(clone), got))
+			assert.Equal(t, infrav1beta1.ClonePhaseFailed, got.Status.Phase)
+			require.NotNil(t, got.Status.TargetRef)
+			assert.Equal(t, "clone-target", got.Status.TargetRef.Name)
+			failed := readyCondition(got.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed)
+			require.NotNil(t, failed)
+			assert.Equal(t, metav1.ConditionTrue, failed.Status)
+			assert.Equal(t, infrav1beta1.VMCloneReasonCustomizationFailed, failed.Reason)
+			assert.Contains(t, failed.Message, tc.wantMessage)
+
+			target := &infrav1beta1.VirtualMachine{}
+			require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: "clone-target"}, target))
+			assert.Equal(t, "vm-clone-999", target.Status.ID)
+			assert.Equal(t, infrav1beta1.PowerStateOff, target.Spec.PowerState, "an uncustomized clone is not powered on")
+			assert.Equal(t, 1, cp.cloneCnt)
+		})
+	}
+}
+
+// TestVMClone_CustomizationRefused: a customization that cannot be applied
+// fails the clone before anything is cloned.
+func TestVMClone_CustomizationRefused(t *testing.T) {
+	s := cloneTestScheme(t)
+	ns := "default"
+	clone := customizedClone(ns, &infrav1beta1.VMCustomization{Hostname: "web-01", TimeZone: "Europe/Sofia"})
+	cp := &clonerProvider{cloneResp: contracts.CloneResponse{TargetVmID: "vm-clone-999"}}
+	r := newCloneReconciler(s, &stubResolver{provider: cp},
+		runningProvider(ns, "prov-1"), sourceVMWithID(ns, "src-vm", "prov-1", "vm-source-123"), clone)
+
+	reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(clone), got))
+	assert.Equal(t, infrav1beta1.ClonePhaseFailed, got.Status.Phase)
+	assert.Nil(t, got.Status.TargetRef)
+	failed := readyCondition(got.Status.Conditions, infrav1beta1.VMCloneConditionCustomizationFailed)
+	require.NotNil(t, failed)
+	assert.Contains(t, failed.Message, "timeZone not supported for clones")
+	assert.Zero(t, cp.cloneCnt)
+}
+
+// TestVMClone_CustomizationSecretMissing: the clone waits for a missing
+// user-data Secret instead of cloning without it.
+func TestVMClone_CustomizationSecretMissing(t *testing.T) {
+	s := cloneTestScheme(t)
+	require.NoError(t, corev1.AddToScheme(s))
+	ns := "default"
+	clone := customizedClone(ns, &infrav1beta1.VMCustomization{
+		UserData: &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{
+			SecretRef: &infrav1beta1.LocalObjectReference{Name: "clone-user-data"},
+		}},
+	})
+	cp := &clonerProvider{cloneResp: contracts.CloneResponse{TargetVmID: "vm-clone-999"}}
+	r := newCloneReconciler(s, &stubResolver{provider: cp},
+		runningProvider(ns, "prov-1"), sourceVMWithID(ns, "src-vm", "prov-1", "vm-source-123"), clone)
+
+	reconcileTwice(t, r, client.ObjectKeyFromObject(clone))
+
+	got := &infrav1beta1.VMClone{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(clone), got))
+	assert.Equal(t, infrav1beta1.ClonePhasePending, got.Status.Phase)
+	assert.Zero(t, cp.cloneCnt)
+}
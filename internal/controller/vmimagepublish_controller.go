@@ -0,0 +1,741 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+const (
+	// errReasonGetImagePublish is the metrics.RecordError reason for a failed
+	// VMImagePublish Get in the reconcile entry path.
+	errReasonGetImagePublish = "get-image-publish"
+
+	// publishPollInterval is how often an in-flight quiesce or publish is
+	// checked.
+	publishPollInterval = 10 * time.Second
+)
+
+// VMImagePublishReconciler reconciles a VMImagePublish object: it quiesces
+// the source VM, has the provider publish a copy of it, and points a VMImage
+// at the result.
+type VMImagePublishReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// RemoteResolver resolves a Provider CR to a provider implementation,
+	// as for the VMClone controller.
+	RemoteResolver ProviderResolver
+	Recorder       record.EventRecorder
+}
+
+// NewVMImagePublishReconciler creates a new VMImagePublish reconciler.
+func NewVMImagePublishReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	remoteResolver ProviderResolver,
+	recorder record.EventRecorder,
+) *VMImagePublishReconciler {
+	return &VMImagePublishReconciler{
+		Client:         c,
+		Scheme:         scheme,
+		RemoteResolver: remoteResolver,
+		Recorder:       recorder,
+	}
+}
+
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimagepublishes,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimagepublishes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimagepublishes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimages,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmimages/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=providers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile drives a VMImagePublish through its phases: resolve the source
+// VM and its provider, refuse to publish over an existing VMImage without
+// overwrite, quiesce the source (power off or snapshot), publish, then
+// restore the source and create or update the VMImage.
+//
+// Named return values (`result`, `retErr`) are required by the deferred
+// metrics block — do not change the signature without updating the defer.
+func (r *VMImagePublishReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
+	timer := metrics.NewReconcileTimer("VMImagePublish")
+	defer func() {
+		outcome := metrics.OutcomeSuccess
+		switch {
+		case retErr != nil:
+			outcome = metrics.OutcomeError
+		case result.Requeue || result.RequeueAfter > 0:
+			outcome = metrics.OutcomeRequeue
+		}
+		timer.Finish(outcome)
+	}()
+
+	ctx = logging.StartReconcile(ctx, "vmimagepublish", req.NamespacedName)
+	logger := logging.FromContext(ctx)
+	logger.Info("Reconciling VMImagePublish")
+
+	pub := &infrav1beta1.VMImagePublish{}
+	if err := r.Get(ctx, req.NamespacedName, pub); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			logger.Info("VMImagePublish not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get VMImagePublish")
+		metrics.RecordError(errReasonGetImagePublish, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	}
+
+	ctx = audit.WithSubject(ctx, pub)
+	ctx = logging.WithLogFields(ctx, "", string(pub.Status.Phase))
+	logger = logging.FromContext(ctx)
+
+	if !pub.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, pub)
+	}
+
+	if added, err := k8s.EnsureFinalizer(ctx, r.Client, pub, infrav1beta1.VMImagePublishFinalizer); err != nil {
+		logger.Error(err, "Failed to add finalizer")
+		metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	} else if added {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Terminal states: nothing further to do.
+	switch pub.Status.Phase {
+	case infrav1beta1.PublishPhaseReady, infrav1beta1.PublishPhaseFailed:
+		return ctrl.Result{}, nil
+	}
+
+	pub.Status.ObservedGeneration = pub.Generation
+
+	sourceVM := &infrav1beta1.VirtualMachine{}
+	sourceKey := client.ObjectKey{Namespace: pub.Namespace, Name: pub.Spec.VMRef.Name}
+	if err := r.Get(ctx, sourceKey, sourceVM); err != nil {
+		if errors.IsNotFound(err) {
+			return r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonSourceNotFound,
+				fmt.Sprintf("source VM %q not found", sourceKey.Name)), nil
+		}
+		logger.Error(err, "Failed to get source VM", "vm", sourceKey.Name)
+		metrics.RecordError(errReasonGetVM, metrics.ComponentManager)
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	if sourceVM.Status.ID == "" {
+		return r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonWaitingForSource,
+			"waiting for source VM to be provisioned"), nil
+	}
+	if identityMismatched(sourceVM) {
+		return r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonWaitingForSource,
+			"waiting for source VM's IdentityMismatch to be resolved"), nil
+	}
+
+	provider := &infrav1beta1.Provider{}
+	providerKey := k8s.RefKey(sourceVM.Spec.ProviderRef, sourceVM.Namespace)
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", sourceVM.Spec.ProviderRef, sourceVM.Namespace, provider); err != nil {
+		logger.Error(err, "Failed to get provider", "provider", providerKey.Name)
+		return r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonProviderError,
+			fmt.Sprintf("provider %q not found", providerKey.Name)), nil
+	}
+	providerInstance, err := r.getProviderInstance(ctx, provider)
+	if err != nil {
+		logger.Error(err, "Failed to get provider instance", "provider", providerKey.Name)
+		return r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonProviderError,
+			fmt.Sprintf("failed to resolve provider %q: %v", providerKey.Name, err)), nil
+	}
+
+	publisher, ok := providerInstance.(contracts.ImagePublisher)
+	if !ok || !r.providerSupportsPublish(ctx, providerInstance) {
+		return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonUnsupported,
+			"provider does not support publishing images"), nil
+	}
+
+	if pub.Status.Phase == infrav1beta1.PublishPhasePublishing {
+		return r.pollPublish(ctx, pub, sourceVM, provider, providerInstance)
+	}
+
+	// Refuse to publish over an image this namespace already has, before
+	// the source VM is touched.
+	if pub.Status.Phase != infrav1beta1.PublishPhaseQuiescing && !pub.Spec.Overwrite {
+		existing := &infrav1beta1.VMImage{}
+		imageKey := client.ObjectKey{Namespace: pub.Namespace, Name: pub.Spec.ImageName}
+		if err := r.Get(ctx, imageKey, existing); err == nil {
+			return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonImageExists,
+				fmt.Sprintf("VMImage %q already exists; set overwrite to replace it", imageKey.Name)), nil
+		} else if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to check for existing VMImage", "image", imageKey.Name)
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	if pub.Status.StartTime == nil {
+		now := metav1.Now()
+		pub.Status.StartTime = &now
+	}
+
+	var quiesced bool
+	if r.quiesceMode(pub) == infrav1beta1.PublishQuiesceSnapshot {
+		quiesced, result, err = r.quiesceBySnapshot(ctx, pub, sourceVM, providerInstance)
+	} else {
+		quiesced, result, err = r.quiesceByShutdown(ctx, pub, sourceVM, providerInstance)
+	}
+	if err != nil || !quiesced {
+		return result, err
+	}
+	return r.startPublish(ctx, pub, sourceVM, provider, providerInstance, publisher)
+}
+
+// quiesceByShutdown powers the source VM off through its spec, so that the
+// VirtualMachine controller does not power it back on mid-publish, and
+// remembers the power state to return it to. It reports quiesced once the
+// provider sees the VM off.
+func (r *VMImagePublishReconciler) quiesceByShutdown(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) (bool, ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+
+	desc, err := providerInstance.Describe(ctx, sourceVM.Status.ID)
+	if err != nil {
+		logger.Error(err, "Failed to describe source VM", "vm", sourceVM.Name)
+		return false, r.markPending(ctx, pub, infrav1beta1.VMImagePublishReasonProviderError,
+			fmt.Sprintf("describe source VM: %v", err)), nil
+	}
+	if desc.PowerState == string(contracts.PowerStateOff) {
+		return true, ctrl.Result{}, nil
+	}
+
+	if sourceVM.Spec.PowerState != infrav1beta1.PowerStateOff &&
+		sourceVM.Spec.PowerState != infrav1beta1.PowerStateOffGraceful {
+		// Persist the state to restore before changing it, so that a
+		// publish interrupted after the patch still restores the VM.
+		restore := sourceVM.Spec.PowerState
+		if restore == "" {
+			restore = infrav1beta1.PowerStateOn
+		}
+		pub.Status.RestorePowerState = restore
+		pub.Status.Phase = infrav1beta1.PublishPhaseQuiescing
+		pub.Status.Message = "Shutting down the source VM"
+		k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+			metav1.ConditionFalse, infrav1beta1.VMImagePublishReasonQuiescing, pub.Status.Message)
+		if err := r.updateStatus(ctx, pub); err != nil {
+			return false, ctrl.Result{}, err
+		}
+
+		patch := client.MergeFrom(sourceVM.DeepCopy())
+		sourceVM.Spec.PowerState = infrav1beta1.PowerStateOffGraceful
+		if err := r.Patch(ctx, sourceVM, patch); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("shut down source VM %s: %w", sourceVM.Name, err)
+		}
+		logger.Info("Shutting down source VM to publish it", "vm", sourceVM.Name)
+		r.recordEvent(ctx, pub, corev1.EventTypeNormal, events.ReasonImagePublishQuiescing,
+			fmt.Sprintf("Shutting down VM %q to publish it", sourceVM.Name))
+		return false, ctrl.Result{RequeueAfter: publishPollInterval}, nil
+	}
+
+	if pub.Status.Phase != infrav1beta1.PublishPhaseQuiescing {
+		pub.Status.Phase = infrav1beta1.PublishPhaseQuiescing
+		pub.Status.Message = "Waiting for the source VM to power off"
+		k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+			metav1.ConditionFalse, infrav1beta1.VMImagePublishReasonQuiescing, pub.Status.Message)
+		if err := r.updateStatus(ctx, pub); err != nil {
+			return false, ctrl.Result{}, err
+		}
+	}
+	return false, ctrl.Result{RequeueAfter: publishPollInterval}, nil
+}
+
+// quiesceBySnapshot snapshots the running source VM so that the publish
+// copies a consistent point in time. It reports quiesced once the snapshot
+// exists.
+func (r *VMImagePublishReconciler) quiesceBySnapshot(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) (bool, ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+
+	if pub.Status.SnapshotID == "" {
+		resp, err := providerInstance.SnapshotCreate(ctx, contracts.SnapshotCreateRequest{
+			VmId:        sourceVM.Status.ID,
+			NameHint:    "publish-" + pub.Spec.ImageName,
+			Description: fmt.Sprintf("Taken by VMImagePublish %s/%s", pub.Namespace, pub.Name),
+			Quiesce:     true,
+			// A guest without an agent is still published, as before quiesce
+			// reached providers.
+			AllowUnquiesced: true,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to snapshot source VM", "vm", sourceVM.Name)
+			return false, r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonProviderError,
+				fmt.Sprintf("snapshot source VM: %v", err)), nil
+		}
+		pub.Status.SnapshotID = resp.SnapshotId
+		if resp.Task != nil {
+			pub.Status.TaskRef = resp.Task.ID
+		}
+		pub.Status.Phase = infrav1beta1.PublishPhaseQuiescing
+		pub.Status.Message = "Snapshotting the source VM"
+		k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+			metav1.ConditionFalse, infrav1beta1.VMImagePublishReasonQuiescing, pub.Status.Message)
+		r.recordEvent(ctx, pub, corev1.EventTypeNormal, events.ReasonImagePublishQuiescing,
+			fmt.Sprintf("Snapshotting VM %q to publish it", sourceVM.Name))
+		if err := r.updateStatus(ctx, pub); err != nil {
+			return false, ctrl.Result{}, err
+		}
+	}
+
+	if pub.Status.TaskRef != "" {
+		done, err := providerInstance.IsTaskComplete(ctx, pub.Status.TaskRef)
+		if err != nil {
+			logger.Error(err, "Snapshot task failed", "task_ref", pub.Status.TaskRef)
+			return false, r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonProviderError,
+				fmt.Sprintf("snapshot task failed: %v", err)), nil
+		}
+		if !done {
+			return false, ctrl.Result{RequeueAfter: publishPollInterval}, nil
+		}
+		pub.Status.TaskRef = ""
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// startPublish issues the PublishImage RPC for the quiesced source VM.
+func (r *VMImagePublishReconciler) startPublish(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	provider *infrav1beta1.Provider,
+	providerInstance contracts.Provider,
+	publisher contracts.ImagePublisher,
+) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+
+	resp, err := publisher.PublishImage(ctx, contracts.PublishImageRequest{
+		VMID:      sourceVM.Status.ID,
+		ImageName: pub.Spec.ImageName,
+		Options: contracts.PublishImageOptions{
+			SnapshotID:     pub.Status.SnapshotID,
+			Cleanup:        pub.Spec.Cleanup,
+			ContentLibrary: pub.Spec.ContentLibrary,
+			Storage:        pub.Spec.Storage,
+		},
+		Overwrite: pub.Spec.Overwrite,
+	})
+	if err != nil {
+		logger.Error(err, "PublishImage RPC failed")
+		return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonProviderError,
+			fmt.Sprintf("publish failed: %v", err)), nil
+	}
+
+	pub.Status.Phase = infrav1beta1.PublishPhasePublishing
+	pub.Status.Message = "Publishing the image"
+	pub.Status.TaskRef = resp.TaskRef
+	pub.Status.ImageID = resp.ImageID
+	pub.Status.ImagePath = resp.ImagePath
+	pub.Status.Checksum = resp.Checksum
+	pub.Status.ChecksumType = infrav1beta1.ChecksumType(resp.ChecksumType)
+	k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+		metav1.ConditionFalse, infrav1beta1.VMImagePublishReasonPublishing, pub.Status.Message)
+	if err := r.updateStatus(ctx, pub); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if resp.TaskRef == "" {
+		return r.completePublish(ctx, pub, sourceVM, provider, providerInstance)
+	}
+	logger.Info("Publish task started", "task_ref", resp.TaskRef)
+	return ctrl.Result{RequeueAfter: publishPollInterval}, nil
+}
+
+// pollPublish checks an in-flight publish task and completes the publish
+// once it is done.
+func (r *VMImagePublishReconciler) pollPublish(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	provider *infrav1beta1.Provider,
+	providerInstance contracts.Provider,
+) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+
+	if pub.Status.TaskRef != "" {
+		done, err := providerInstance.IsTaskComplete(ctx, pub.Status.TaskRef)
+		if err != nil {
+			logger.Error(err, "Publish task failed", "task_ref", pub.Status.TaskRef)
+			return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonProviderError,
+				fmt.Sprintf("publish task failed: %v", err)), nil
+		}
+		if !done {
+			logger.Info("Publish task still in progress", "task_ref", pub.Status.TaskRef)
+			return ctrl.Result{RequeueAfter: publishPollInterval}, nil
+		}
+		pub.Status.TaskRef = ""
+	}
+	return r.completePublish(ctx, pub, sourceVM, provider, providerInstance)
+}
+
+// completePublish restores the source VM, points the VMImage at the
+// published image and marks the publish Ready. The source is restored first
+// so that a VMImage failure does not leave it off.
+func (r *VMImagePublishReconciler) completePublish(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	provider *infrav1beta1.Provider,
+	providerInstance contracts.Provider,
+) (ctrl.Result, error) {
+	if err := r.releaseSource(ctx, pub, sourceVM, providerInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	source, err := publishedImageSource(provider.Spec.Type, pub)
+	if err != nil {
+		return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonUnsupported, err.Error()), nil
+	}
+	if err := r.ensureImage(ctx, pub, source); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return r.markFailed(ctx, pub, sourceVM, providerInstance, infrav1beta1.VMImagePublishReasonImageExists,
+				fmt.Sprintf("VMImage %q was created while publishing; set overwrite to replace it", pub.Spec.ImageName)), nil
+		}
+		logging.FromContext(ctx).Error(err, "Failed to write VMImage", "image", pub.Spec.ImageName)
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	pub.Status.Phase = infrav1beta1.PublishPhaseReady
+	pub.Status.Message = "Image published"
+	pub.Status.TaskRef = ""
+	pub.Status.CompletionTime = &now
+	pub.Status.ImageRef = &infrav1beta1.LocalObjectReference{Name: pub.Spec.ImageName}
+	k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+		metav1.ConditionTrue, infrav1beta1.VMImagePublishReasonCompleted, "Image published")
+	r.recordEvent(ctx, pub, corev1.EventTypeNormal, events.ReasonImagePublished,
+		fmt.Sprintf("Published VM %q as VMImage %q", sourceVM.Name, pub.Spec.ImageName))
+	if err := r.updateStatus(ctx, pub); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// ensureImage creates the VMImage named by spec.imageName, or with
+// overwrite replaces the source of an existing one. A replaced VMImage's
+// prepared locations are dropped: they described the image it used to be.
+func (r *VMImagePublishReconciler) ensureImage(ctx context.Context, pub *infrav1beta1.VMImagePublish, source infrav1beta1.ImageSource) error {
+	key := client.ObjectKey{Namespace: pub.Namespace, Name: pub.Spec.ImageName}
+	image := &infrav1beta1.VMImage{}
+	switch err := r.Get(ctx, key, image); {
+	case errors.IsNotFound(err):
+		image = &infrav1beta1.VMImage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      map[string]string{infrav1beta1.PublishedByLabel: pub.Name},
+				Annotations: map[string]string{infrav1beta1.PublishedFromAnnotation: pub.Spec.VMRef.Name},
+			},
+			Spec: infrav1beta1.VMImageSpec{Source: source},
+		}
+		return r.Create(ctx, image)
+	case err != nil:
+		return err
+	}
+
+	if !pub.Spec.Overwrite && image.Labels[infrav1beta1.PublishedByLabel] != pub.Name {
+		return errors.NewAlreadyExists(infrav1beta1.GroupVersion.WithResource("vmimages").GroupResource(), key.Name)
+	}
+
+	patch := client.MergeFrom(image.DeepCopy())
+	if image.Labels == nil {
+		image.Labels = map[string]string{}
+	}
+	if image.Annotations == nil {
+		image.Annotations = map[string]string{}
+	}
+	image.Labels[infrav1beta1.PublishedByLabel] = pub.Name
+	image.Annotations[infrav1beta1.PublishedFromAnnotation] = pub.Spec.VMRef.Name
+	image.Spec.Source = source
+	if err := r.Patch(ctx, image, patch); err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &infrav1beta1.VMImage{}
+		if err := r.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		if len(latest.Status.ProviderStatus) == 0 && len(latest.Status.AvailableOn) == 0 {
+			return nil
+		}
+		latest.Status.ProviderStatus = nil
+		latest.Status.AvailableOn = nil
+		latest.Status.PrepareTaskRef = ""
+		return r.Status().Update(ctx, latest)
+	})
+}
+
+// publishedImageSource builds the VMImage source that refers to the image
+// pub published on a provider of providerType.
+func publishedImageSource(providerType infrav1beta1.ProviderType, pub *infrav1beta1.VMImagePublish) (infrav1beta1.ImageSource, error) {
+	switch providerType {
+	case infrav1beta1.ProviderTypeProxmox:
+		source := &infrav1beta1.ProxmoxImageSource{
+			TemplateName: pub.Spec.ImageName,
+			Storage:      pub.Spec.Storage,
+		}
+		if vmid, err := strconv.Atoi(pub.Status.ImageID); err == nil {
+			source.TemplateID = &vmid
+		}
+		return infrav1beta1.ImageSource{Proxmox: source}, nil
+	case infrav1beta1.ProviderTypeLibvirt:
+		return infrav1beta1.ImageSource{Libvirt: &infrav1beta1.LibvirtImageSource{
+			Path:         pub.Status.ImagePath,
+			Format:       infrav1beta1.ImageFormatQCOW2,
+			Checksum:     pub.Status.Checksum,
+			ChecksumType: pub.Status.ChecksumType,
+			StoragePool:  pub.Spec.Storage,
+		}}, nil
+	case infrav1beta1.ProviderTypeVSphere:
+		if pub.Spec.ContentLibrary != "" {
+			return infrav1beta1.ImageSource{VSphere: &infrav1beta1.VSphereImageSource{
+				ContentLibrary: &infrav1beta1.ContentLibraryRef{
+					Library: pub.Spec.ContentLibrary,
+					Item:    pub.Spec.ImageName,
+				},
+			}}, nil
+		}
+		return infrav1beta1.ImageSource{VSphere: &infrav1beta1.VSphereImageSource{
+			TemplateName: pub.Spec.ImageName,
+		}}, nil
+	default:
+		return infrav1beta1.ImageSource{}, fmt.Errorf("provider type %q has no VMImage source for published images", providerType)
+	}
+}
+
+// releaseSource undoes the quiesce: it deletes the snapshot taken to
+// publish from and returns the source VM to the power state it had, unless
+// someone has changed that since. It clears what it has undone from status.
+func (r *VMImagePublishReconciler) releaseSource(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+) error {
+	logger := logging.FromContext(ctx)
+
+	if pub.Status.SnapshotID != "" && providerInstance != nil && sourceVM != nil {
+		if _, err := providerInstance.SnapshotDelete(ctx, sourceVM.Status.ID, pub.Status.SnapshotID); err != nil {
+			// The snapshot is left behind rather than failing a publish
+			// that has otherwise succeeded.
+			logger.Error(err, "Failed to delete publish snapshot", "snapshot_id", pub.Status.SnapshotID)
+		}
+		pub.Status.SnapshotID = ""
+	}
+
+	if pub.Status.RestorePowerState != "" && sourceVM != nil {
+		if sourceVM.Spec.PowerState == infrav1beta1.PowerStateOffGraceful {
+			patch := client.MergeFrom(sourceVM.DeepCopy())
+			sourceVM.Spec.PowerState = pub.Status.RestorePowerState
+			if err := r.Patch(ctx, sourceVM, patch); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("restore power state of source VM %s: %w", sourceVM.Name, err)
+			}
+			logger.Info("Restored source VM power state", "vm", sourceVM.Name, "power_state", pub.Status.RestorePowerState)
+		}
+		pub.Status.RestorePowerState = ""
+	}
+	return nil
+}
+
+// providerSupportsPublish reports whether the provider advertises image
+// publishing. Like gateLinkedClone it fails open when the provider does not
+// report capabilities or the query errors; the RPC then answers for itself.
+func (r *VMImagePublishReconciler) providerSupportsPublish(ctx context.Context, providerInstance contracts.Provider) bool {
+	reporter, ok := providerInstance.(contracts.CapabilityReporter)
+	if !ok {
+		return true
+	}
+	caps, err := reporter.GetCapabilities(ctx)
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("GetCapabilities failed; attempting publish (fail open)", "error", err.Error())
+		return true
+	}
+	return caps.SupportsImagePublish
+}
+
+// quiesceMode returns spec.quiesce, defaulting to Shutdown.
+func (r *VMImagePublishReconciler) quiesceMode(pub *infrav1beta1.VMImagePublish) infrav1beta1.PublishQuiesceMode {
+	if pub.Spec.Quiesce == "" {
+		return infrav1beta1.PublishQuiesceShutdown
+	}
+	return pub.Spec.Quiesce
+}
+
+// handleDeletion restores the source VM of a publish still in flight, then
+// drops the finalizer. The published image and its VMImage are kept.
+func (r *VMImagePublishReconciler) handleDeletion(ctx context.Context, pub *infrav1beta1.VMImagePublish) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+	logger.Info("Deleting VMImagePublish (published image is intentionally preserved)")
+
+	if pub.Status.SnapshotID != "" || pub.Status.RestorePowerState != "" {
+		sourceVM, providerInstance := r.resolveSource(ctx, pub)
+		if err := r.releaseSource(ctx, pub, sourceVM, providerInstance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := k8s.RemoveFinalizer(ctx, r.Client, pub, infrav1beta1.VMImagePublishFinalizer); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		metrics.RecordError(errReasonRemoveFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// resolveSource best-effort looks up the source VM and its provider for
+// releaseSource; either is nil when it cannot be resolved.
+func (r *VMImagePublishReconciler) resolveSource(ctx context.Context, pub *infrav1beta1.VMImagePublish) (*infrav1beta1.VirtualMachine, contracts.Provider) {
+	sourceVM := &infrav1beta1.VirtualMachine{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pub.Namespace, Name: pub.Spec.VMRef.Name}, sourceVM); err != nil {
+		return nil, nil
+	}
+	provider := &infrav1beta1.Provider{}
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", sourceVM.Spec.ProviderRef, sourceVM.Namespace, provider); err != nil {
+		return sourceVM, nil
+	}
+	providerInstance, err := r.getProviderInstance(ctx, provider)
+	if err != nil {
+		return sourceVM, nil
+	}
+	return sourceVM, providerInstance
+}
+
+// markFailed restores the source VM, sets the VMImagePublish to the Failed
+// phase and persists status. Failed is terminal, as for a VMClone: a
+// partial provider-side publish makes blind retry unsafe, so recreate the
+// VMImagePublish to retry.
+func (r *VMImagePublishReconciler) markFailed(
+	ctx context.Context,
+	pub *infrav1beta1.VMImagePublish,
+	sourceVM *infrav1beta1.VirtualMachine,
+	providerInstance contracts.Provider,
+	reason, message string,
+) ctrl.Result {
+	logger := logging.FromContext(ctx)
+	logger.Info("VMImagePublish failed", "reason", reason, "message", message)
+
+	if err := r.releaseSource(ctx, pub, sourceVM, providerInstance); err != nil {
+		// Stay in the current phase so the restore is retried.
+		logger.Error(err, "Failed to restore source VM")
+		return ctrl.Result{RequeueAfter: publishPollInterval}
+	}
+
+	now := metav1.Now()
+	pub.Status.Phase = infrav1beta1.PublishPhaseFailed
+	pub.Status.Message = message
+	pub.Status.TaskRef = ""
+	pub.Status.CompletionTime = &now
+	k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+		metav1.ConditionFalse, reason, message)
+	k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionFailed,
+		metav1.ConditionTrue, reason, message)
+	r.recordEvent(ctx, pub, corev1.EventTypeWarning, events.ReasonImagePublishFailed, fmt.Sprintf("%s: %s", reason, message))
+
+	_ = r.updateStatus(ctx, pub) //nolint:errcheck // status errors retried next reconcile
+	return ctrl.Result{}
+}
+
+// markPending sets the VMImagePublish to the Pending phase (still waiting on
+// a prerequisite) and requeues. A publish already quiescing keeps its phase.
+func (r *VMImagePublishReconciler) markPending(ctx context.Context, pub *infrav1beta1.VMImagePublish, reason, message string) ctrl.Result {
+	if pub.Status.Phase == "" {
+		pub.Status.Phase = infrav1beta1.PublishPhasePending
+	}
+	pub.Status.Message = message
+	k8s.SetCondition(&pub.Status.Conditions, infrav1beta1.VMImagePublishConditionReady,
+		metav1.ConditionFalse, reason, message)
+	_ = r.updateStatus(ctx, pub) //nolint:errcheck // status errors retried next reconcile
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// getProviderInstance resolves a Provider CR to a remote provider implementation.
+func (r *VMImagePublishReconciler) getProviderInstance(ctx context.Context, provider *infrav1beta1.Provider) (contracts.Provider, error) {
+	if r.RemoteResolver == nil {
+		return nil, fmt.Errorf("no remote resolver available")
+	}
+	return r.RemoteResolver.GetProvider(ctx, provider)
+}
+
+// recordEvent emits an event on the VMImagePublish carrying its phase.
+func (r *VMImagePublishReconciler) recordEvent(ctx context.Context, pub *infrav1beta1.VMImagePublish, eventType, reason, message string) {
+	events.Emit(ctx, r.Recorder, pub, eventType, reason, string(pub.Status.Phase), message)
+}
+
+// updateStatus persists the VMImagePublish status subresource.
+func (r *VMImagePublishReconciler) updateStatus(ctx context.Context, pub *infrav1beta1.VMImagePublish) error {
+	if err := r.Status().Update(ctx, pub); err != nil {
+		logging.FromContext(ctx).Error(err, "Failed to update VMImagePublish status")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMImagePublishReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexFields(mgr, &infrav1beta1.VMImagePublish{}, map[string]client.IndexerFunc{
+		publishSourceVMIndex: indexPublishSourceVM,
+	}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1beta1.VMImagePublish{}).
+		Watches(&infrav1beta1.VirtualMachine{}, enqueueDependents(r.publishesForVM),
+			builder.WithPredicates(vmProvisioningChanged)).
+		Complete(r)
+}
+
+// publishesForVM maps a VirtualMachine to the VMImagePublishes of it, so
+// that a publish waiting for its source to be provisioned proceeds when it
+// is.
+func (r *VMImagePublishReconciler) publishesForVM(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMImagePublishList{}, publishSourceVMIndex, obj)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// publisherProvider is a contracts.Provider that also implements
+// contracts.ImagePublisher and contracts.CapabilityReporter. It reports the
+// power state in powerState and records the requests it received.
+type publisherProvider struct {
+	stubProvider
+
+	powerState   contracts.PowerState
+	publishResp  contracts.PublishImageResponse
+	lastPublish  *contracts.PublishImageRequest
+	publishCnt   int
+	snapshotCnt  int
+	deletedSnaps []string
+	caps         contracts.Capabilities
+}
+
+func (p *publisherProvider) Describe(_ context.Context, _ string) (contracts.DescribeResponse, error) {
+	return contracts.DescribeResponse{Exists: true, PowerState: string(p.powerState)}, nil
+}
+
+func (p *publisherProvider) SnapshotCreate(_ context.Context, _ contracts.SnapshotCreateRequest) (contracts.SnapshotCreateResponse, error) {
+	p.snapshotCnt++
+	return contracts.SnapshotCreateResponse{SnapshotId: "snap-1"}, nil
+}
+
+func (p *publisherProvider) SnapshotDelete(_ context.Context, _, snapshotID string) (string, error) {
+	p.deletedSnaps = append(p.deletedSnaps, snapshotID)
+	return "", nil
+}
+
+func (p *publisherProvider) PublishImage(_ context.Context, req contracts.PublishImageRequest) (contracts.PublishImageResponse, error) {
+	p.publishCnt++
+	r := req
+	p.lastPublish = &r
+	return p.publishResp, nil
+}
+
+func (p *publisherProvider) GetCapabilities(_ context.Context) (contracts.Capabilities, error) {
+	return p.caps, nil
+}
+
+var (
+	_ contracts.ImagePublisher     = (*publisherProvider)(nil)
+	_ contracts.CapabilityReporter = (*publisherProvider)(nil)
+)
+
+func newPublishReconciler(s *runtime.Scheme, resolver ProviderResolver, objs ...client.Object) *VMImagePublishReconciler {
+	fc := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(
+			&infrav1beta1.VMImagePublish{},
+			&infrav1beta1.VirtualMachine{},
+			&infrav1beta1.VMImage{},
+		).
+		Build()
+	return NewVMImagePublishReconciler(fc, s, resolver, record.NewFakeRecorder(20))
+}
+
+func reconcilePublish(t *testing.T, r *VMImagePublishReconciler, key client.ObjectKey, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+	}
+}
+
+func libvirtPublishFixture(ns string) (*infrav1beta1.Provider, *infrav1beta1.VirtualMachine, *infrav1beta1.VMImagePublish) {
+	prov := runningProvider(ns, "prov-1")
+	prov.Spec.Type = infrav1beta1.ProviderTypeLibvirt
+	src := sourceVMWithID(ns, "golden", "prov-1", "vm-golden")
+	pub := &infrav1beta1.VMImagePublish{
+		ObjectMeta: metav1.ObjectMeta{Name: "publish-1", Namespace: ns},
+		Spec: infrav1beta1.VMImagePublishSpec{
+			VMRef:     infrav1beta1.LocalObjectReference{Name: "golden"},
+			ImageName: "golden-image",
+			Cleanup:   []string{"machine-id"},
+		},
+	}
+	return prov, src, pub
+}
+
+func TestVMImagePublish_PoweredOffVMPublishesAndCreatesVMImage(t *testing.T) {
+	s := cloneTestScheme(t)
+	prov, src, pub := libvirtPublishFixture("default")
+	pp := &publisherProvider{
+		powerState: contracts.PowerStateOff,
+		caps:       contracts.Capabilities{SupportsImagePublish: true},
+		publishResp: contracts.PublishImageResponse{
+			ImageID:      "golden-image",
+			ImagePath:    "/var/lib/libvirt/images/golden-image.qcow2",
+			Checksum:     "abc123",
+			ChecksumType: "sha256",
+		},
+	}
+	r := newPublishReconciler(s, &stubResolver{provider: pp}, prov, src, pub)
+
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 4)
+
+	got := &infrav1beta1.VMImagePublish{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseReady, got.Status.Phase)
+	require.NotNil(t, got.Status.ImageRef)
+	assert.Equal(t, "golden-image", got.Status.ImageRef.Name)
+	assert.Equal(t, "abc123", got.Status.Checksum)
+	assert.Empty(t, got.Status.RestorePowerState)
+
+	require.Equal(t, 1, pp.publishCnt)
+	assert.Equal(t, "vm-golden", pp.lastPublish.VMID)
+	assert.Equal(t, "golden-image", pp.lastPublish.ImageName)
+	assert.Equal(t, []string{"machine-id"}, pp.lastPublish.Options.Cleanup)
+	assert.False(t, pp.lastPublish.Overwrite)
+
+	image := &infrav1beta1.VMImage{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "golden-image"}, image))
+	require.NotNil(t, image.Spec.Source.Libvirt)
+	assert.Equal(t, "/var/lib/libvirt/images/golden-image.qcow2", image.Spec.Source.Libvirt.Path)
+	assert.Equal(t, "abc123", image.Spec.Source.Libvirt.Checksum)
+	assert.Equal(t, infrav1beta1.ChecksumTypeSHA256, image.Spec.Source.Libvirt.ChecksumType)
+	assert.Equal(t, "publish-1", image.Labels[infrav1beta1.PublishedByLabel])
+	assert.Equal(t, "golden", image.Annotations[infrav1beta1.PublishedFromAnnotation])
+}
+
+func TestVMImagePublish_ShutdownRestoresPowerState(t *testing.T) {
+	s := cloneTestScheme(t)
+	prov, src, pub := libvirtPublishFixture("default")
+	pp := &publisherProvider{
+		powerState: contracts.PowerStateOn,
+		caps:       contracts.Capabilities{SupportsImagePublish: true},
+	}
+	r := newPublishReconciler(s, &stubResolver{provider: pp}, prov, src, pub)
+
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 3)
+
+	got := &infrav1beta1.VMImagePublish{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseQuiescing, got.Status.Phase)
+	assert.Equal(t, infrav1beta1.PowerStateOn, got.Status.RestorePowerState)
+	vm := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(src), vm))
+	assert.Equal(t, infrav1beta1.PowerStateOffGraceful, vm.Spec.PowerState)
+	assert.Zero(t, pp.publishCnt, "the VM must be off before it is published")
+
+	pp.powerState = contracts.PowerStateOff
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 2)
+
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseReady, got.Status.Phase)
+	assert.Empty(t, got.Status.RestorePowerState)
+	assert.Equal(t, 1, pp.publishCnt)
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(src), vm))
+	assert.Equal(t, infrav1beta1.PowerStateOn, vm.Spec.PowerState)
+}
+
+func TestVMImagePublish_SnapshotQuiesce(t *testing.T) {
+	s := cloneTestScheme(t)
+	prov, src, pub := libvirtPublishFixture("default")
+	pub.Spec.Quiesce = infrav1beta1.PublishQuiesceSnapshot
+	pp := &publisherProvider{
+		powerState: contracts.PowerStateOn,
+		caps:       contracts.Capabilities{SupportsImagePublish: true},
+	}
+	r := newPublishReconciler(s, &stubResolver{provider: pp}, prov, src, pub)
+
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 4)
+
+	got := &infrav1beta1.VMImagePublish{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseReady, got.Status.Phase)
+	assert.Equal(t, 1, pp.snapshotCnt)
+	require.NotNil(t, pp.lastPublish)
+	assert.Equal(t, "snap-1", pp.lastPublish.Options.SnapshotID)
+	assert.Equal(t, []string{"snap-1"}, pp.deletedSnaps)
+	assert.Empty(t, got.Status.SnapshotID)
+
+	vm := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(src), vm))
+	assert.Empty(t, vm.Spec.PowerState, "a snapshot publish leaves the VM running")
+}
+
+func TestVMImagePublish_ExistingImageRequiresOverwrite(t *testing.T) {
+	s := cloneTestScheme(t)
+	prov, src, pub := libvirtPublishFixture("default")
+	existing := &infrav1beta1.VMImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "golden-image", Namespace: "default"},
+		Spec: infrav1beta1.VMImageSpec{Source: infrav1beta1.ImageSource{
+			Libvirt: &infrav1beta1.LibvirtImageSource{Path: "/old.qcow2"},
+		}},
+	}
+	pp := &publisherProvider{
+		powerState: contracts.PowerStateOff,
+		caps:       contracts.Capabilities{SupportsImagePublish: true},
+		publishResp: contracts.PublishImageResponse{
+			ImagePath: "/var/lib/libvirt/images/golden-image.qcow2",
+		},
+	}
+	r := newPublishReconciler(s, &stubResolver{provider: pp}, prov, src, pub, existing)
+
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 3)
+
+	got := &infrav1beta1.VMImagePublish{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseFailed, got.Status.Phase)
+	failed := readyCondition(got.Status.Conditions, infrav1beta1.VMImagePublishConditionFailed)
+	require.NotNil(t, failed)
+	assert.Equal(t, infrav1beta1.VMImagePublishReasonImageExists, failed.Reason)
+	assert.Zero(t, pp.publishCnt)
+
+	// The same publish with overwrite replaces the VMImage's source.
+	overwrite := pub.DeepCopy()
+	overwrite.ObjectMeta = metav1.ObjectMeta{Name: "publish-2", Namespace: "default"}
+	overwrite.Spec.Overwrite = true
+	require.NoError(t, r.Create(context.Background(), overwrite))
+	reconcilePublish(t, r, client.ObjectKeyFromObject(overwrite), 3)
+
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(overwrite), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseReady, got.Status.Phase)
+	assert.True(t, pp.lastPublish.Overwrite)
+	image := &infrav1beta1.VMImage{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(existing), image))
+	assert.Equal(t, "/var/lib/libvirt/images/golden-image.qcow2", image.Spec.Source.Libvirt.Path)
+	assert.Equal(t, "publish-2", image.Labels[infrav1beta1.PublishedByLabel])
+}
+
+func TestVMImagePublish_UnsupportedProviderFails(t *testing.T) {
+	s := cloneTestScheme(t)
+	prov, src, pub := libvirtPublishFixture("default")
+	pp := &publisherProvider{powerState: contracts.PowerStateOn}
+	r := newPublishReconciler(s, &stubResolver{provider: pp}, prov, src, pub)
+
+	reconcilePublish(t, r, client.ObjectKeyFromObject(pub), 3)
+
+	got := &infrav1beta1.VMImagePublish{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(pub), got))
+	assert.Equal(t, infrav1beta1.PublishPhaseFailed, got.Status.Phase)
+	assert.Zero(t, pp.publishCnt)
+	vm := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(src), vm))
+	assert.Empty(t, vm.Spec.PowerState, "an unsupported publish must not touch the VM")
+}
+
+func TestPublishedImageSource(t *testing.T) {
+	pub := &infrav1beta1.VMImagePublish{
+		Spec:   infrav1beta1.VMImagePublishSpec{ImageName: "golden", Storage: "local-lvm"},
+		Status: infrav1beta1.VMImagePublishStatus{ImageID: "9001"},
+	}
+	source, err := publishedImageSource(infrav1beta1.ProviderTypeProxmox, pub)
+	require.NoError(t, err)
+	require.NotNil(t, source.Proxmox)
+	require.NotNil(t, source.Proxmox.TemplateID)
+	assert.Equal(t, 9001, *source.Proxmox.TemplateID)
+	assert.Equal(t, "golden", source.Proxmox.TemplateName)
+
+	source, err = publishedImageSource(infrav1beta1.ProviderTypeVSphere, pub)
+	require.NoError(t, err)
+	assert.Equal(t, "golden", source.VSphere.TemplateName)
+
+	pub.Spec.ContentLibrary = "images"
+	source, err = publishedImageSource(infrav1beta1.ProviderTypeVSphere, pub)
+	require.NoError(t, err)
+	require.NotNil(t, source.VSphere.ContentLibrary)
+	assert.Equal(t, infrav1beta1.ContentLibraryRef{Library: "images", Item: "golden"}, *source.VSphere.ContentLibrary)
+
+	_, err = publishedImageSource(infrav1beta1.ProviderTypeFirecracker, pub)
+	assert.Error(t, err)
+}
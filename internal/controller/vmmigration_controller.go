@@ -34,11 +34,17 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
 	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
@@ -80,6 +86,9 @@ type VMMigrationReconciler struct {
 	// the always-forbidden loopback/link-local/metadata/multicast targets.
 	StorageHostPolicy *storagemigration.HostPolicy
 
+	// Config supplies concurrency; nil uses the defaults.
+	Config *config.ConfigStore
+
 	// longOpInFlight is an in-memory guard against re-issuing a long-running,
 	// non-idempotent migration RPC (ExportDisk / ImportDisk) when a reconcile
 	// re-enters before the prior status write has propagated to the informer
@@ -200,11 +209,10 @@ func (r *VMMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		timer.Finish(outcome)
 	}()
 
-	// Add correlation context
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmmigration-%s", req.Name))
+	ctx = logging.StartReconcile(ctx, "vmmigration", req.NamespacedName)
 	logger := logging.FromContext(ctx)
 
-	logger.Info("Reconciling VMMigration", "migration", req.NamespacedName)
+	logger.Info("Reconciling VMMigration")
 
 	// Fetch the VMMigration instance
 	migration := &infrav1beta1.VMMigration{}
@@ -218,8 +226,8 @@ func (r *VMMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	// Add migration context
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmmigration-%s/%s", migration.Namespace, migration.Name))
+	ctx = audit.WithSubject(ctx, migration)
+	ctx = logging.WithLogFields(ctx, "", string(migration.Status.Phase))
 	logger = logging.FromContext(ctx)
 
 	// Handle deletion
@@ -228,14 +236,11 @@ func (r *VMMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Add finalizer if needed
-	const finalizerName = "vmmigration.infra.virtrigaud.io/finalizer"
-	if !controllerutil.ContainsFinalizer(migration, finalizerName) {
-		controllerutil.AddFinalizer(migration, finalizerName)
-		if err := r.Update(ctx, migration); err != nil {
-			logger.Error(err, "Failed to add finalizer")
-			metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
-			return ctrl.Result{}, err
-		}
+	if added, err := k8s.EnsureFinalizer(ctx, r.Client, migration, infrav1beta1.VMMigrationFinalizer); err != nil {
+		logger.Error(err, "Failed to add finalizer")
+		metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	} else if added {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -251,24 +256,16 @@ func (r *VMMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Short-circuit for migrations in terminal failed state (max retries exceeded)
 	// This prevents continuous reconciliation of permanently failed migrations
-	if migration.Status.Phase == infrav1beta1.MigrationPhaseFailed {
-		if migration.Spec.Options != nil && migration.Spec.Options.RetryPolicy != nil {
-			maxRetries := int32(3) // Default
-			if migration.Spec.Options.RetryPolicy.MaxRetries != nil {
-				maxRetries = *migration.Spec.Options.RetryPolicy.MaxRetries
-			}
-			if migration.Status.RetryCount >= maxRetries {
-				// Migration has permanently failed, no need to reconcile further
-				logger.V(1).Info("Migration permanently failed, skipping reconciliation",
-					"retries", migration.Status.RetryCount,
-					"max_retries", maxRetries)
-				return ctrl.Result{}, nil
+	if MigrationFailedForGood(migration) {
+		// Migration has permanently failed, no need to reconcile further
+		logger.V(1).Info("Migration permanently failed, skipping reconciliation",
+			"retries", migration.Status.RetryCount)
+		if r.cleanupOnTerminalFailure(ctx, migration) {
+			if err := r.updateStatus(ctx, migration); err != nil {
+				return ctrl.Result{}, err
 			}
-		} else {
-			// No retry policy, migration is permanently failed
-			logger.V(1).Info("Migration failed with no retry policy, skipping reconciliation")
-			return ctrl.Result{}, nil
 		}
+		return r.expireFinished(ctx, migration)
 	}
 
 	// Handle migration lifecycle based on phase
@@ -314,7 +311,7 @@ func (r *VMMigrationReconciler) handlePendingPhase(ctx context.Context, migratio
 		metav1.ConditionTrue, "ValidationStarted",
 		"Migration validation started")
 
-	r.Recorder.Event(migration, "Normal", "ValidationStarted", "Starting migration validation")
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationValidationStarted, "Starting migration validation")
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -344,6 +341,15 @@ func (r *VMMigrationReconciler) handleValidatingPhase(ctx context.Context, migra
 		}
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
+	if identityMismatched(sourceVM) {
+		k8s.SetCondition(&migration.Status.Conditions, infrav1beta1.VMMigrationConditionValidating,
+			metav1.ConditionFalse, "SourceVMNotReady",
+			"Source VM's provider ID names another VM (IdentityMismatch)")
+		if err := r.updateStatus(ctx, migration); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: identityMismatchRecheck}, nil
+	}
 
 	// Validate source provider
 	var sourceProviderRef infrav1beta1.ObjectRef
@@ -353,17 +359,25 @@ func (r *VMMigrationReconciler) handleValidatingPhase(ctx context.Context, migra
 		// Auto-detect from source VM
 		sourceProviderRef = sourceVM.Spec.ProviderRef
 	}
-	sourceProvider, err := r.getProvider(ctx, sourceProviderRef, migration.Namespace)
+	sourceProvider, err := r.getProvider(ctx, "spec.source.providerRef", sourceProviderRef, migration.Namespace)
 	if err != nil {
 		return r.transitionToFailed(ctx, migration, fmt.Sprintf("Failed to get source provider: %v", err))
 	}
 
 	// Validate target provider
-	targetProvider, err := r.getProvider(ctx, migration.Spec.Target.ProviderRef, migration.Namespace)
+	targetProvider, err := r.getProvider(ctx, "spec.target.providerRef", migration.Spec.Target.ProviderRef, migration.Namespace)
 	if err != nil {
 		return r.transitionToFailed(ctx, migration, fmt.Sprintf("Failed to get target provider: %v", err))
 	}
 
+	// Validate the migrated VM may be created in the target namespace
+	if targetNamespace := migration.Spec.Target.Namespace; targetNamespace != "" {
+		if err := k8s.CheckNamespaceAccess(ctx, r.Client, "spec.target.namespace",
+			migration.Namespace, targetNamespace, migration.Spec.Target.Name); err != nil {
+			return r.transitionToFailed(ctx, migration, err.Error())
+		}
+	}
+
 	// Validate providers are ready
 	if !r.isProviderReady(sourceProvider) {
 		k8s.SetCondition(&migration.Status.Conditions, infrav1beta1.VMMigrationConditionValidating,
@@ -511,7 +525,7 @@ func (r *VMMigrationReconciler) handleValidatingPhase(ctx context.Context, migra
 		metav1.ConditionTrue, "ValidationComplete",
 		"Migration validation completed successfully")
 
-	r.Recorder.Event(migration, "Normal", "ValidationComplete", "Migration validation completed")
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationValidationComplete, "Migration validation completed")
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -556,7 +570,7 @@ func (r *VMMigrationReconciler) ensureSourcePoweredOff(ctx context.Context, migr
 		logger.Info("Set source VM desired power state to Off for migration", "vm", sourceVM.Name)
 	}
 
-	sourceProvider, err := r.getProvider(ctx, sourceVM.Spec.ProviderRef, migration.Namespace)
+	sourceProvider, err := r.getProvider(ctx, "spec.source.providerRef", sourceVM.Spec.ProviderRef, migration.Namespace)
 	if err != nil {
 		return false, ctrl.Result{}, fmt.Errorf("get source provider: %w", err)
 	}
@@ -581,7 +595,7 @@ func (r *VMMigrationReconciler) ensureSourcePoweredOff(ctx context.Context, migr
 		if _, err := providerInstance.Power(ctx, sourceVM.Status.ID, contracts.PowerOpOff); err != nil {
 			return false, ctrl.Result{}, fmt.Errorf("power off source VM: %w", err)
 		}
-		r.Recorder.Event(migration, "Normal", "SourcePowerOff", "Powering off source VM before migration")
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationSourcePowerOff, "Powering off source VM before migration")
 	}
 
 	migration.Status.Message = "Powering off source VM before migration"
@@ -627,7 +641,7 @@ func (r *VMMigrationReconciler) handleSnapshottingPhase(ctx context.Context, mig
 	} else {
 		sourceProviderRef = sourceVM.Spec.ProviderRef
 	}
-	sourceProvider, err := r.getProvider(ctx, sourceProviderRef, migration.Namespace)
+	sourceProvider, err := r.getProvider(ctx, "spec.source.providerRef", sourceProviderRef, migration.Namespace)
 	if err != nil {
 		return r.transitionToFailed(ctx, migration, fmt.Sprintf("Failed to get source provider: %v", err))
 	}
@@ -650,7 +664,7 @@ func (r *VMMigrationReconciler) handleSnapshottingPhase(ctx context.Context, mig
 			metav1.ConditionTrue, "SnapshotComplete",
 			"Source VM snapshot created")
 
-		r.Recorder.Event(migration, "Normal", "SnapshotComplete", "Source VM snapshot created")
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationSnapshotComplete, "Source VM snapshot created")
 
 		if err := r.updateStatus(ctx, migration); err != nil {
 			return ctrl.Result{}, err
@@ -700,7 +714,7 @@ func (r *VMMigrationReconciler) handleSnapshottingPhase(ctx context.Context, mig
 		metav1.ConditionTrue, "SnapshotComplete",
 		"Source VM snapshot created")
 
-	r.Recorder.Event(migration, "Normal", "SnapshotComplete", fmt.Sprintf("Snapshot %s created", snapshotResp.SnapshotId))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationSnapshotComplete, fmt.Sprintf("Snapshot %s created", snapshotResp.SnapshotId))
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -727,7 +741,7 @@ func (r *VMMigrationReconciler) handleExportingPhase(ctx context.Context, migrat
 	} else {
 		sourceProviderRef = sourceVM.Spec.ProviderRef
 	}
-	sourceProvider, err := r.getProvider(ctx, sourceProviderRef, migration.Namespace)
+	sourceProvider, err := r.getProvider(ctx, "spec.source.providerRef", sourceProviderRef, migration.Namespace)
 	if err != nil {
 		return r.transitionToFailed(ctx, migration, fmt.Sprintf("Failed to get source provider: %v", err))
 	}
@@ -791,7 +805,7 @@ func (r *VMMigrationReconciler) handleExportingPhase(ctx context.Context, migrat
 			metav1.ConditionTrue, "ExportComplete",
 			"Source VM disk exported")
 
-		r.Recorder.Event(migration, "Normal", "ExportComplete", "Disk exported successfully")
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationExportComplete, "Disk exported successfully")
 
 		if err := r.updateStatus(ctx, migration); err != nil {
 			return ctrl.Result{}, err
@@ -915,7 +929,7 @@ func (r *VMMigrationReconciler) handleExportingPhase(ctx context.Context, migrat
 		metav1.ConditionTrue, "ExportComplete",
 		"Source VM disk exported")
 
-	r.Recorder.Event(migration, "Normal", "ExportComplete", fmt.Sprintf("Disk exported to %s", destinationURL))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationExportComplete, fmt.Sprintf("Disk exported to %s", destinationURL))
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -947,7 +961,7 @@ func (r *VMMigrationReconciler) handleTransferringPhase(ctx context.Context, mig
 		metav1.ConditionTrue, "TransferComplete",
 		"Disk transfer completed")
 
-	r.Recorder.Event(migration, "Normal", "TransferComplete", "Disk transfer completed")
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationTransferComplete, "Disk transfer completed")
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -966,7 +980,7 @@ func (r *VMMigrationReconciler) handleConvertingPhase(ctx context.Context, migra
 	migration.Status.Phase = infrav1beta1.MigrationPhaseImporting
 	migration.Status.Message = "Conversion complete, starting import"
 
-	r.Recorder.Event(migration, "Normal", "ConversionComplete", "Disk format conversion completed")
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationConversionComplete, "Disk format conversion completed")
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -981,7 +995,7 @@ func (r *VMMigrationReconciler) handleImportingPhase(ctx context.Context, migrat
 	logger.Info("Handling importing phase")
 
 	// Get target provider
-	targetProvider, err := r.getProvider(ctx, migration.Spec.Target.ProviderRef, migration.Namespace)
+	targetProvider, err := r.getProvider(ctx, "spec.target.providerRef", migration.Spec.Target.ProviderRef, migration.Namespace)
 	if err != nil {
 		return r.transitionToFailed(ctx, migration, fmt.Sprintf("Failed to get target provider: %v", err))
 	}
@@ -1039,7 +1053,7 @@ func (r *VMMigrationReconciler) handleImportingPhase(ctx context.Context, migrat
 			metav1.ConditionTrue, "ImportComplete",
 			"Disk imported to target provider")
 
-		r.Recorder.Event(migration, "Normal", "ImportComplete", "Disk imported successfully")
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationImportComplete, "Disk imported successfully")
 
 		if err := r.updateStatus(ctx, migration); err != nil {
 			return ctrl.Result{}, err
@@ -1205,7 +1219,7 @@ func (r *VMMigrationReconciler) handleImportingPhase(ctx context.Context, migrat
 		metav1.ConditionTrue, "ImportComplete",
 		"Disk imported to target provider")
 
-	r.Recorder.Event(migration, "Normal", "ImportComplete", fmt.Sprintf("Disk imported as %s", importResp.DiskId))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationImportComplete, fmt.Sprintf("Disk imported as %s", importResp.DiskId))
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -1309,7 +1323,12 @@ func (r *VMMigrationReconciler) handleCreatingPhase(ctx context.Context, migrati
 			},
 		},
 		Spec: infrav1beta1.VirtualMachineSpec{
-			ProviderRef: migration.Spec.Target.ProviderRef,
+			// Pinned so a VM created in another namespace still points at
+			// the provider the migration resolved.
+			ProviderRef: infrav1beta1.ObjectRef{
+				Name:      migration.Spec.Target.ProviderRef.Name,
+				Namespace: k8s.RefNamespace(migration.Spec.Target.ProviderRef, migration.Namespace),
+			},
 		},
 	}
 
@@ -1369,7 +1388,7 @@ func (r *VMMigrationReconciler) handleCreatingPhase(ctx context.Context, migrati
 	}
 
 	logger.Info("Target VM created", "name", targetVMName)
-	r.Recorder.Event(migration, "Normal", "TargetVMCreated", fmt.Sprintf("Created target VM %s", targetVMName))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationTargetVMCreated, fmt.Sprintf("Created target VM %s", targetVMName))
 
 	migration.Status.Message = fmt.Sprintf("Target VM %s created, waiting for provisioning", targetVMName)
 
@@ -1437,7 +1456,7 @@ func (r *VMMigrationReconciler) handleValidatingTargetPhase(ctx context.Context,
 		metav1.ConditionTrue, "MigrationComplete",
 		"VM migration completed successfully")
 
-	r.Recorder.Event(migration, "Normal", "MigrationComplete", fmt.Sprintf("VM migrated successfully to %s/%s", targetNamespace, targetVMName))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationComplete, fmt.Sprintf("VM migrated successfully to %s/%s", targetNamespace, targetVMName))
 
 	// Mark the target VM as completed to prevent deletion when migration is removed
 	// This annotation protects the VM from being deleted with the migration resource
@@ -1470,7 +1489,7 @@ func (r *VMMigrationReconciler) handleReadyPhase(ctx context.Context, migration
 
 	// Check if cleanup has already been performed
 	if migration.Status.StorageInfo != nil && migration.Status.StorageInfo.CleanedUp {
-		return ctrl.Result{}, nil
+		return r.expireFinished(ctx, migration)
 	}
 
 	// Perform post-migration cleanup
@@ -1526,10 +1545,22 @@ func (r *VMMigrationReconciler) handleReadyPhase(ctx context.Context, migration
 			return ctrl.Result{}, err
 		}
 
-		r.Recorder.Event(migration, "Normal", "CleanupComplete", "Post-migration cleanup completed")
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonCleanupComplete, "Post-migration cleanup completed")
 	}
 
-	return ctrl.Result{}, nil
+	return r.expireFinished(ctx, migration)
+}
+
+// expireFinished deletes a finished migration once its ttlAfterCompletion
+// has passed. A Ready migration only gets here after its post-migration
+// cleanup; the deletion itself goes through handleDeletion, which removes
+// the intermediate PVC.
+func (r *VMMigrationReconciler) expireFinished(ctx context.Context, migration *infrav1beta1.VMMigration) (ctrl.Result, error) {
+	if cleanupPending(migration.Status.PendingCleanup) {
+		return ctrl.Result{RequeueAfter: cleanupExpiryRecheck}, nil
+	}
+	return expireAfterTTL(ctx, r.Client, migration, "VMMigration",
+		migration.Spec.TTLAfterCompletion, migration.Status.CompletionTime)
 }
 
 // handleFailedPhase handles migrations that have failed
@@ -1540,7 +1571,11 @@ func (r *VMMigrationReconciler) handleFailedPhase(ctx context.Context, migration
 	// Check if retry is configured and allowed
 	if migration.Spec.Options == nil || migration.Spec.Options.RetryPolicy == nil {
 		logger.Info("No retry policy configured, migration remains failed")
-		r.cleanupSnapshotOnTerminalFailure(ctx, migration)
+		if r.cleanupOnTerminalFailure(ctx, migration) {
+			if err := r.updateStatus(ctx, migration); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -1557,7 +1592,7 @@ func (r *VMMigrationReconciler) handleFailedPhase(ctx context.Context, migration
 			"max_retries", maxRetries)
 		migration.Status.Message = fmt.Sprintf("Migration failed after %d retries: %s",
 			migration.Status.RetryCount, migration.Status.Message)
-		r.cleanupSnapshotOnTerminalFailure(ctx, migration)
+		r.cleanupOnTerminalFailure(ctx, migration)
 		if err := r.updateStatus(ctx, migration); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -1622,8 +1657,7 @@ func (r *VMMigrationReconciler) handleFailedPhase(ctx context.Context, migration
 		metav1.ConditionFalse, "Retrying",
 		fmt.Sprintf("Retrying migration (attempt %d/%d)", migration.Status.RetryCount, maxRetries))
 
-	r.Recorder.Event(migration, "Normal", "RetryingMigration",
-		fmt.Sprintf("Retrying migration (attempt %d/%d)", migration.Status.RetryCount, maxRetries))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationRetrying, fmt.Sprintf("Retrying migration (attempt %d/%d)", migration.Status.RetryCount, maxRetries))
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -1641,10 +1675,12 @@ func (r *VMMigrationReconciler) handleFailedPhase(ctx context.Context, migration
 // CR is deleted — which, combined with the await fix in deleteSourceSnapshot, is
 // the accumulation observed on real hardware. The deletion is best-effort: a
 // cleanup error is logged and swallowed so a transient provider failure cannot
-// wedge a migration that is already terminal. Because deleteSourceSnapshot now
-// awaits the task, this usually succeeds on the first pass. Skipped when the
-// user opted out (CleanupPolicy=Never), when there is no migration-created
-// snapshot, or when the user supplied their own snapshot (SnapshotRef set).
+// wedge a migration that is already terminal; the snapshot is then recorded
+// in PendingCleanup, and CleanupWorker retries the deletion. Because
+// deleteSourceSnapshot now awaits the task, this usually succeeds on the first
+// pass. Skipped when the user opted out (CleanupPolicy=Never), when there is no
+// migration-created snapshot, or when the user supplied their own snapshot
+// (SnapshotRef set).
 func (r *VMMigrationReconciler) cleanupSnapshotOnTerminalFailure(ctx context.Context, migration *infrav1beta1.VMMigration) {
 	if !cleanupAllowed(migration) || migration.Status.SnapshotID == "" || migration.Spec.Source.SnapshotRef != nil {
 		return
@@ -1655,11 +1691,86 @@ func (r *VMMigrationReconciler) cleanupSnapshotOnTerminalFailure(ctx context.Con
 	if err := r.deleteSourceSnapshot(ctx, migration); err != nil {
 		logger.Error(err, "Failed to delete source snapshot on terminal failure (best-effort, continuing)",
 			"snapshot_id", snapshotID)
+		r.recordOrphanedSnapshot(ctx, migration, err)
 		return
 	}
 	logger.Info("Source snapshot deleted on terminal failure", "snapshot_id", snapshotID)
 }
 
+// cleanupOnTerminalFailure hands what a migration that is not going to be
+// retried left on the providers over to cleanup, and reports whether its
+// status changed: the source snapshot is deleted right away, or recorded
+// for CleanupWorker when that fails, and with CleanupPolicy=Always an
+// imported disk no target VM took over is recorded. It is idempotent, so
+// every reconcile of a failed migration may call it.
+func (r *VMMigrationReconciler) cleanupOnTerminalFailure(ctx context.Context, migration *infrav1beta1.VMMigration) bool {
+	snapshotID := migration.Status.SnapshotID
+	pending := len(migration.Status.PendingCleanup)
+	r.cleanupSnapshotOnTerminalFailure(ctx, migration)
+	r.recordOrphanedImport(ctx, migration)
+	return migration.Status.SnapshotID != snapshotID || len(migration.Status.PendingCleanup) != pending
+}
+
+// recordOrphanedSnapshot moves the migration-created source snapshot, whose
+// deletion failed with cause, to PendingCleanup. The snapshot stays in
+// SnapshotID when its VM cannot be resolved.
+func (r *VMMigrationReconciler) recordOrphanedSnapshot(ctx context.Context, migration *infrav1beta1.VMMigration, cause error) {
+	sourceVM, err := r.getSourceVM(ctx, migration)
+	if err != nil || sourceVM.Status.ID == "" {
+		return
+	}
+	providerRef := sourceVM.Spec.ProviderRef
+	if migration.Spec.Source.ProviderRef != nil {
+		providerRef = *migration.Spec.Source.ProviderRef
+	}
+	providerKey := k8s.RefKey(providerRef, migration.Namespace)
+	r.recordCleanup(ctx, migration, infrav1beta1.CleanupRecord{
+		Kind:        infrav1beta1.CleanupArtifactSnapshot,
+		ID:          migration.Status.SnapshotID,
+		VMID:        sourceVM.Status.ID,
+		ProviderRef: infrav1beta1.ObjectRef{Name: providerKey.Name, Namespace: providerKey.Namespace},
+		Reason:      fmt.Sprintf("source snapshot deletion failed: %v", cause),
+	})
+	migration.Status.SnapshotID = ""
+}
+
+// recordOrphanedImport records the disk imported on the target provider
+// when no target VM was created to take it over. Only CleanupPolicy=Always
+// asks for it; OnSuccess keeps what a failed migration produced.
+func (r *VMMigrationReconciler) recordOrphanedImport(ctx context.Context, migration *infrav1beta1.VMMigration) {
+	if migration.Status.ImportID == "" || migration.Spec.Options == nil ||
+		migration.Spec.Options.CleanupPolicy != infrav1beta1.CleanupPolicyAlways {
+		return
+	}
+	targetName := migration.Spec.Target.Name
+	if targetName == "" {
+		targetName = fmt.Sprintf("%s-migrated", migration.Spec.Source.VMRef.Name)
+	}
+	targetNamespace := migration.Spec.Target.Namespace
+	if targetNamespace == "" {
+		targetNamespace = migration.Namespace
+	}
+	err := r.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: targetName}, &infrav1beta1.VirtualMachine{})
+	if !errors.IsNotFound(err) {
+		return
+	}
+	providerKey := k8s.RefKey(migration.Spec.Target.ProviderRef, migration.Namespace)
+	r.recordCleanup(ctx, migration, infrav1beta1.CleanupRecord{
+		Kind:        infrav1beta1.CleanupArtifactDisk,
+		ID:          migration.Status.ImportID,
+		ProviderRef: infrav1beta1.ObjectRef{Name: providerKey.Name, Namespace: providerKey.Namespace},
+		Reason:      "migration failed after the disk was imported",
+	})
+}
+
+// recordCleanup adds rec to PendingCleanup and reports it the first time.
+func (r *VMMigrationReconciler) recordCleanup(ctx context.Context, migration *infrav1beta1.VMMigration, rec infrav1beta1.CleanupRecord) {
+	if addCleanupRecord(&migration.Status.PendingCleanup, rec) {
+		r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonArtifactCleanupScheduled,
+			fmt.Sprintf("Scheduled deletion of %s: %s", describeArtifact(rec), rec.Reason))
+	}
+}
+
 // handleDeletion handles deletion of VMMigration resources
 func (r *VMMigrationReconciler) handleDeletion(ctx context.Context, migration *infrav1beta1.VMMigration) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
@@ -1757,21 +1868,24 @@ func (r *VMMigrationReconciler) handleDeletion(ctx context.Context, migration *i
 		}
 	}
 
+	if records := migration.Status.PendingCleanup; len(records) > 0 {
+		r.recordEvent(ctx, migration, corev1.EventTypeWarning, events.ReasonArtifactCleanupAbandoned,
+			"VMMigration deleted before its cleanup finished, remove by hand: "+describeCleanupRecords(records))
+	}
+
 	// If there were errors, log them but continue with finalizer removal
 	if len(cleanupErrors) > 0 {
 		logger.Info("Cleanup completed with errors", "error_count", len(cleanupErrors))
 		for i, err := range cleanupErrors {
 			logger.Error(err, "Cleanup error", "index", i)
 		}
-		r.Recorder.Event(migration, "Warning", "CleanupErrors",
-			fmt.Sprintf("Cleanup completed with %d errors", len(cleanupErrors)))
+		r.recordEvent(ctx, migration, corev1.EventTypeWarning, events.ReasonCleanupErrors, fmt.Sprintf("Cleanup completed with %d errors", len(cleanupErrors)))
 	}
 
 	// Remove finalizer
-	const finalizerName = "vmmigration.infra.virtrigaud.io/finalizer"
-	controllerutil.RemoveFinalizer(migration, finalizerName)
-	if err := r.Update(ctx, migration); err != nil {
+	if err := k8s.RemoveFinalizer(ctx, r.Client, migration, infrav1beta1.VMMigrationFinalizer); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
+		metrics.RecordError(errReasonRemoveFinalizer, metrics.ComponentManager)
 		return ctrl.Result{}, err
 	}
 
@@ -1795,7 +1909,7 @@ func (r *VMMigrationReconciler) transitionToFailed(ctx context.Context, migratio
 		metav1.ConditionFalse, "MigrationFailed",
 		message)
 
-	r.Recorder.Event(migration, "Warning", "MigrationFailed", message)
+	r.recordEvent(ctx, migration, corev1.EventTypeWarning, events.ReasonMigrationFailed, message)
 
 	if err := r.updateStatus(ctx, migration); err != nil {
 		return ctrl.Result{}, err
@@ -1823,20 +1937,11 @@ func (r *VMMigrationReconciler) getSourceVM(ctx context.Context, migration *infr
 	return vm, nil
 }
 
-// getProvider retrieves a provider
-func (r *VMMigrationReconciler) getProvider(ctx context.Context, providerRef infrav1beta1.ObjectRef, defaultNamespace string) (*infrav1beta1.Provider, error) {
+// getProvider retrieves the provider providerRef points at from an object
+// in fromNamespace. field names the reference in cross-namespace errors.
+func (r *VMMigrationReconciler) getProvider(ctx context.Context, field string, providerRef infrav1beta1.ObjectRef, fromNamespace string) (*infrav1beta1.Provider, error) {
 	provider := &infrav1beta1.Provider{}
-	namespace := providerRef.Namespace
-	if namespace == "" {
-		namespace = defaultNamespace
-	}
-
-	key := client.ObjectKey{
-		Namespace: namespace,
-		Name:      providerRef.Name,
-	}
-
-	if err := r.Get(ctx, key, provider); err != nil {
+	if err := k8s.GetRef(ctx, r.Client, field, providerRef, fromNamespace, provider); err != nil {
 		return nil, err
 	}
 
@@ -1856,12 +1961,12 @@ func (r *VMMigrationReconciler) getSourceProvider(ctx context.Context, migration
 		}
 		sourceProviderRef = sourceVM.Spec.ProviderRef
 	}
-	return r.getProvider(ctx, sourceProviderRef, migration.Namespace)
+	return r.getProvider(ctx, "spec.source.providerRef", sourceProviderRef, migration.Namespace)
 }
 
 // getTargetProvider retrieves the target provider for a migration
 func (r *VMMigrationReconciler) getTargetProvider(ctx context.Context, migration *infrav1beta1.VMMigration) (*infrav1beta1.Provider, error) {
-	return r.getProvider(ctx, migration.Spec.Target.ProviderRef, migration.Namespace)
+	return r.getProvider(ctx, "spec.target.providerRef", migration.Spec.Target.ProviderRef, migration.Namespace)
 }
 
 // isProviderReady checks if a provider is ready
@@ -2077,7 +2182,7 @@ func (r *VMMigrationReconciler) ensureMigrationPVC(ctx context.Context, migratio
 	}
 
 	logger.Info("Successfully created migration PVC", "pvc", pvcName)
-	r.Recorder.Event(migration, "Normal", "PVCCreated", fmt.Sprintf("Created migration storage PVC: %s", pvcName))
+	r.recordEvent(ctx, migration, corev1.EventTypeNormal, events.ReasonMigrationPVCCreated, fmt.Sprintf("Created migration storage PVC: %s", pvcName))
 
 	// Trigger provider reconciliation to mount the new PVC
 	// This will cause provider pods to restart with the new PVC mounted
@@ -2590,6 +2695,45 @@ func (r *VMMigrationReconciler) gateMigrationStorageBackend(
 	return ""
 }
 
+// MigrationFailedForGood reports whether the migration is Failed with no
+// retries left: it has no retry policy, or has used up its retries.
+func MigrationFailedForGood(migration *infrav1beta1.VMMigration) bool {
+	if migration.Status.Phase != infrav1beta1.MigrationPhaseFailed {
+		return false
+	}
+	if migration.Spec.Options == nil || migration.Spec.Options.RetryPolicy == nil {
+		return true
+	}
+	maxRetries := int32(3) // Default
+	if migration.Spec.Options.RetryPolicy.MaxRetries != nil {
+		maxRetries = *migration.Spec.Options.RetryPolicy.MaxRetries
+	}
+	return migration.Status.RetryCount >= maxRetries
+}
+
+// MigrationCapabilityProblem returns why a pvc-staged migration from source
+// to target cannot run, judged from the capabilities both providers report,
+// or an empty string when it can. `vrtg migration run` checks it before
+// creating the VMMigration; the controller gates the same capabilities as
+// the migration proceeds.
+func MigrationCapabilityProblem(source, target *infrav1beta1.Provider) string {
+	if caps := source.Status.ReportedCapabilities; caps == nil || !caps.SupportsDiskExport {
+		return fmt.Sprintf("source provider %q does not report disk export support", source.Name)
+	}
+	if caps := target.Status.ReportedCapabilities; caps == nil || !caps.SupportsDiskImport {
+		return fmt.Sprintf("target provider %q does not report disk import support", target.Name)
+	}
+	if backends := reportedExportBackends(source); !containsString(backends, storagemigration.BackendPVC) {
+		return fmt.Sprintf("source provider %q does not export to %q storage (supported: %v)",
+			source.Name, storagemigration.BackendPVC, backends)
+	}
+	if backends := reportedImportBackends(target); !containsString(backends, storagemigration.BackendPVC) {
+		return fmt.Sprintf("target provider %q does not import from %q storage (supported: %v)",
+			target.Name, storagemigration.BackendPVC, backends)
+	}
+	return ""
+}
+
 // getProviderInstance retrieves a provider gRPC client
 func (r *VMMigrationReconciler) getProviderInstance(ctx context.Context, provider *infrav1beta1.Provider) (contracts.Provider, error) {
 	// Test hook: allow injecting a fake/counting provider without dialing gRPC.
@@ -2606,6 +2750,11 @@ func (r *VMMigrationReconciler) getProviderInstance(ctx context.Context, provide
 	return providerClient, nil
 }
 
+// recordEvent emits an event on the VMMigration carrying its phase.
+func (r *VMMigrationReconciler) recordEvent(ctx context.Context, migration *infrav1beta1.VMMigration, eventType, reason, message string) {
+	events.Emit(ctx, r.Recorder, migration, eventType, reason, string(migration.Status.Phase), message)
+}
+
 // updateStatus updates the migration status with retry on conflicts
 func (r *VMMigrationReconciler) updateStatus(ctx context.Context, migration *infrav1beta1.VMMigration) error {
 	logger := logging.FromContext(ctx)
@@ -2813,7 +2962,7 @@ func (r *VMMigrationReconciler) deleteSourceSnapshot(ctx context.Context, migrat
 		sourceProviderRef = sourceVM.Spec.ProviderRef
 	}
 
-	sourceProvider, err := r.getProvider(ctx, sourceProviderRef, migration.Namespace)
+	sourceProvider, err := r.getProvider(ctx, "spec.source.providerRef", sourceProviderRef, migration.Namespace)
 	if err != nil {
 		return fmt.Errorf("failed to get source provider: %w", err)
 	}
@@ -2896,10 +3045,32 @@ func (r *VMMigrationReconciler) deleteSourceVM(ctx context.Context, migration *i
 
 // SetupWithManager sets up the controller with the Manager
 func (r *VMMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexFields(mgr, &infrav1beta1.VMMigration{}, map[string]client.IndexerFunc{
+		migrationSourceVMIndex: indexMigrationSourceVM,
+		migrationProviderIndex: indexMigrationProviders,
+	}); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1beta1.VMMigration{}).
+		Watches(&infrav1beta1.VirtualMachine{}, enqueueDependents(r.migrationsForVM),
+			builder.WithPredicates(vmProvisioningChanged)).
+		Watches(&infrav1beta1.Provider{}, enqueueDependents(r.migrationsForProvider),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, skipCreates)).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 3, // Limit concurrent reconciliations to prevent API server overload
+			MaxConcurrentReconciles: r.Config.Get().Concurrency.VMMigration,
 		}).
 		Complete(r)
 }
+
+// migrationsForVM maps a VirtualMachine to the VMMigrations of it.
+func (r *VMMigrationReconciler) migrationsForVM(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMMigrationList{}, migrationSourceVMIndex, obj)
+}
+
+// migrationsForProvider maps a Provider to the VMMigrations from or to it,
+// so that a migration blocked on a misconfigured provider retries once the
+// provider is fixed.
+func (r *VMMigrationReconciler) migrationsForProvider(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMMigrationList{}, migrationProviderIndex, obj)
+}
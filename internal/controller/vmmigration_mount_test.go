@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
 )
 
 // mountTestScheme builds a scheme with the apps + core + infra types the
@@ -333,9 +334,14 @@ func TestHandleValidatingPhase_CrossNamespaceFailsFast(t *testing.T) {
 	}
 	srcProv := readyProvider("team-a", "src-prov") // different namespace than the migration
 	tgtProv := readyProvider("default", "tgt-prov")
+	// team-a admits the reference, so the storage gate is what fails.
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "team-a",
+		Annotations: map[string]string{k8s.AllowReferencesFromAnnotation: "default"},
+	}}
 
 	c := fake.NewClientBuilder().WithScheme(scheme).
-		WithObjects(migration, srcVM, srcProv, tgtProv).
+		WithObjects(migration, srcVM, srcProv, tgtProv, teamA).
 		WithStatusSubresource(&infravirtrigaudiov1beta1.VMMigration{}).
 		Build()
 
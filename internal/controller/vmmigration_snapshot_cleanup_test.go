@@ -266,7 +266,8 @@ func TestHandleFailedPhase_TerminalCleansSnapshot(t *testing.T) {
 
 // TestHandleFailedPhase_TerminalCleanupBestEffort proves a snapshot-cleanup
 // failure on terminal failure does NOT wedge the migration: handleFailedPhase
-// still returns without error.
+// still returns without error, and the snapshot is recorded for the cleanup
+// worker.
 func TestHandleFailedPhase_TerminalCleanupBestEffort(t *testing.T) {
 	ctx := context.Background()
 	prov := &countingSnapshotProvider{
@@ -285,8 +286,14 @@ func TestHandleFailedPhase_TerminalCleanupBestEffort(t *testing.T) {
 	require.NoError(t, err, "a best-effort cleanup failure must not wedge a terminal migration")
 
 	assert.EqualValues(t, 1, prov.snapshotDeleteCalls.Load(), "cleanup was attempted")
-	// The delete failed, so the latch must retain the ID for a later retry/delete.
-	assert.Equal(t, "snap-abc", migration.Status.SnapshotID)
+	// The delete failed, so the snapshot is handed to the cleanup worker.
+	assert.Empty(t, migration.Status.SnapshotID)
+	require.Len(t, migration.Status.PendingCleanup, 1)
+	rec := migration.Status.PendingCleanup[0]
+	assert.Equal(t, infrav1beta1.CleanupArtifactSnapshot, rec.Kind)
+	assert.Equal(t, "snap-abc", rec.ID)
+	assert.Equal(t, "vm-123", rec.VMID)
+	assert.Equal(t, infrav1beta1.ObjectRef{Name: "source-provider", Namespace: "default"}, rec.ProviderRef)
 }
 
 // TestCleanupAllowed exercises the policy gate directly.
@@ -213,3 +213,38 @@ func TestGateMigrationStorageBackend(t *testing.T) {
 		})
 	}
 }
+
+// TestMigrationCapabilityProblem covers the up-front check `vrtg migration
+// run` makes: disk export on the source, disk import on the target, and pvc
+// staging in both directions.
+func TestMigrationCapabilityProblem(t *testing.T) {
+	capable := func(name string) *infrav1beta1.Provider {
+		p := providerWithCaps(name, nil, nil, nil)
+		p.Status.ReportedCapabilities.SupportsDiskExport = true
+		p.Status.ReportedCapabilities.SupportsDiskImport = true
+		return p
+	}
+	if got := MigrationCapabilityProblem(capable("src"), capable("tgt")); got != "" {
+		t.Fatalf("capable providers: got %q", got)
+	}
+
+	unreported := &infrav1beta1.Provider{}
+	unreported.Name = "new"
+	noImport := capable("tgt")
+	noImport.Status.ReportedCapabilities.SupportsDiskImport = false
+	s3Only := capable("tgt")
+	s3Only.Status.ReportedCapabilities.SupportedImportBackends = []string{"s3"}
+
+	for name, tc := range map[string]struct {
+		source, target *infrav1beta1.Provider
+		want           string
+	}{
+		"source not reported": {unreported, capable("tgt"), `source provider "new" does not report disk export`},
+		"target no import":    {capable("src"), noImport, `target provider "tgt" does not report disk import`},
+		"target no pvc":       {capable("src"), s3Only, `target provider "tgt" does not import from "pvc"`},
+	} {
+		if got := MigrationCapabilityProblem(tc.source, tc.target); !strings.Contains(got, tc.want) {
+			t.Errorf("%s: got %q, want it to contain %q", name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// hasNetworkBinding reports whether a VMNetworkAttachment carries a binding
+// for the provider type. ok is false for provider types that have no
+// binding section at all; those receive only the inline network name.
+func hasNetworkBinding(network *infravirtrigaudiov1beta1.NetworkConfig, providerType infravirtrigaudiov1beta1.ProviderType) (bound, ok bool) {
+	switch providerType {
+	case infravirtrigaudiov1beta1.ProviderTypeVSphere:
+		return network.VSphere != nil, true
+	case infravirtrigaudiov1beta1.ProviderTypeLibvirt:
+		return network.Libvirt != nil, true
+	case infravirtrigaudiov1beta1.ProviderTypeProxmox:
+		return network.Proxmox != nil, true
+	default:
+		return false, false
+	}
+}
+
+// selectNetworkBindings narrows each referenced VMNetworkAttachment down to
+// the binding for the VM's provider type, so a network that also describes
+// other hypervisors cannot leak their bridge or portgroup into the create
+// request. It returns an error naming the first spec.networks entry whose
+// network has no binding for the provider type. Entries without a
+// networkRef stay nil and fall back to the inline network name.
+func selectNetworkBindings(
+	providerType infravirtrigaudiov1beta1.ProviderType,
+	networks []*infravirtrigaudiov1beta1.VMNetworkAttachment,
+) ([]*infravirtrigaudiov1beta1.VMNetworkAttachment, error) {
+	selected := make([]*infravirtrigaudiov1beta1.VMNetworkAttachment, len(networks))
+	for i, net := range networks {
+		if net == nil {
+			continue
+		}
+		bound, ok := hasNetworkBinding(&net.Spec.Network, providerType)
+		if !ok {
+			selected[i] = net
+			continue
+		}
+		if !bound {
+			return nil, fmt.Errorf("spec.networks[%d]: VMNetworkAttachment %s has no %s binding", i, net.Name, providerType)
+		}
+
+		narrowed := net.DeepCopy()
+		if providerType != infravirtrigaudiov1beta1.ProviderTypeVSphere {
+			narrowed.Spec.Network.VSphere = nil
+		}
+		if providerType != infravirtrigaudiov1beta1.ProviderTypeLibvirt {
+			narrowed.Spec.Network.Libvirt = nil
+		}
+		if providerType != infravirtrigaudiov1beta1.ProviderTypeProxmox {
+			narrowed.Spec.Network.Proxmox = nil
+		}
+		selected[i] = narrowed
+	}
+	return selected, nil
+}
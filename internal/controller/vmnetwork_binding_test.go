@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// multiBindingNetwork describes the same logical network on all three
+// hypervisors.
+func multiBindingNetwork() *infravirtrigaudiov1beta1.VMNetworkAttachment {
+	vlan := int32(42)
+	return &infravirtrigaudiov1beta1.VMNetworkAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-net", Namespace: "default"},
+		Spec: infravirtrigaudiov1beta1.VMNetworkAttachmentSpec{
+			Network: infravirtrigaudiov1beta1.NetworkConfig{
+				VSphere: &infravirtrigaudiov1beta1.VSphereNetworkConfig{Portgroup: "pg-app"},
+				Libvirt: &infravirtrigaudiov1beta1.LibvirtNetworkConfig{NetworkName: "app"},
+				Proxmox: &infravirtrigaudiov1beta1.ProxmoxNetworkConfig{Bridge: "vmbr1", VLANTag: &vlan},
+			},
+		},
+	}
+}
+
+func TestSelectNetworkBindings_PicksProviderBinding(t *testing.T) {
+	r := &VirtualMachineReconciler{}
+	vm := baseVM("default")
+	vm.Spec.Networks = []infravirtrigaudiov1beta1.VMNetworkRef{
+		{Name: "app", NetworkRef: &infravirtrigaudiov1beta1.ObjectRef{Name: "app-net"}},
+		{Name: "lan"},
+	}
+	vmClass := &infravirtrigaudiov1beta1.VMClass{
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{CPU: 1, Memory: resource.MustParse("1Gi")},
+	}
+	network := multiBindingNetwork()
+
+	tests := []struct {
+		providerType infravirtrigaudiov1beta1.ProviderType
+		networkName  string
+		bridge       string
+		vlan         int32
+	}{
+		{providerType: infravirtrigaudiov1beta1.ProviderTypeVSphere, networkName: "pg-app"},
+		{providerType: infravirtrigaudiov1beta1.ProviderTypeLibvirt, networkName: "app"},
+		{providerType: infravirtrigaudiov1beta1.ProviderTypeProxmox, bridge: "vmbr1", vlan: 42},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.providerType), func(t *testing.T) {
+			selected, err := selectNetworkBindings(tt.providerType,
+				[]*infravirtrigaudiov1beta1.VMNetworkAttachment{network, nil})
+			require.NoError(t, err)
+
+			req, err := r.buildCreateRequest(context.Background(), vm, "", vmClass, nil, selected)
+			require.NoError(t, err)
+			require.Len(t, req.Networks, 2)
+			assert.Equal(t, tt.networkName, req.Networks[0].NetworkName)
+			assert.Equal(t, tt.bridge, req.Networks[0].Bridge)
+			assert.Equal(t, tt.vlan, req.Networks[0].VLAN)
+
+			// The inline entry is passed through by name only.
+			assert.Equal(t, "lan", req.Networks[1].Name)
+			assert.Empty(t, req.Networks[1].Bridge)
+		})
+	}
+
+	// The shared object from the cache must not be modified.
+	assert.NotNil(t, network.Spec.Network.VSphere)
+	assert.NotNil(t, network.Spec.Network.Proxmox)
+}
+
+func TestSelectNetworkBindings_MissingBinding(t *testing.T) {
+	network := multiBindingNetwork()
+	network.Spec.Network.Proxmox = nil
+
+	_, err := selectNetworkBindings(infravirtrigaudiov1beta1.ProviderTypeProxmox,
+		[]*infravirtrigaudiov1beta1.VMNetworkAttachment{nil, network})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.networks[1]")
+	assert.Contains(t, err.Error(), "app-net has no proxmox binding")
+
+	// Provider types without a binding section are not validated.
+	selected, err := selectNetworkBindings(infravirtrigaudiov1beta1.ProviderTypeFirecracker,
+		[]*infravirtrigaudiov1beta1.VMNetworkAttachment{network})
+	require.NoError(t, err)
+	assert.Same(t, network, selected[0])
+}
+
+// TestReconcile_NetworkWithoutBindingIsFlagged covers the full reconcile: a
+// VM on a Proxmox provider referencing a vSphere-only network is marked not
+// Ready with a ValidationError before any provider call is attempted.
+func TestReconcile_NetworkWithoutBindingIsFlagged(t *testing.T) {
+	sch := newMetricsScheme(t)
+	network := multiBindingNetwork()
+	network.Spec.Network.Proxmox = nil
+	network.Spec.Network.Libvirt = nil
+
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "web",
+			Namespace:  "default",
+			Finalizers: []string{infravirtrigaudiov1beta1.VirtualMachineFinalizer},
+		},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: "pve"},
+			ClassRef:    infravirtrigaudiov1beta1.ObjectRef{Name: "small"},
+			Networks: []infravirtrigaudiov1beta1.VMNetworkRef{
+				{Name: "app", NetworkRef: &infravirtrigaudiov1beta1.ObjectRef{Name: "app-net"}},
+			},
+		},
+	}
+	provider := &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.ProviderSpec{Type: infravirtrigaudiov1beta1.ProviderTypeProxmox},
+	}
+	vmClass := &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default"},
+		Spec:       infravirtrigaudiov1beta1.VMClassSpec{CPU: 1, Memory: resource.MustParse("1Gi")},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm, provider, vmClass, network).
+		WithStatusSubresource(&infravirtrigaudiov1beta1.VirtualMachine{}).
+		Build()
+	r := &VirtualMachineReconciler{Client: cli, Scheme: sch}
+
+	key := types.NamespacedName{Name: "web", Namespace: "default"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter)
+
+	got := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, cli.Get(context.Background(), key, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, k8s.ConditionReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, k8s.ReasonValidationError, cond.Reason)
+	assert.Contains(t, cond.Message, "app-net has no proxmox binding")
+}
@@ -18,7 +18,6 @@ package controller
 
 import (
 	"context"
-	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -75,7 +74,7 @@ func (r *VMSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 		timer.Finish(outcome)
 	}()
 
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmset-%s/%s", req.Namespace, req.Name))
+	ctx = logging.StartReconcile(ctx, "vmset", req.NamespacedName)
 	logger := logging.FromContext(ctx)
 
 	vmSet := &infrav1beta1.VMSet{}
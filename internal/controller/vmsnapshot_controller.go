@@ -21,18 +21,24 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
+	"github.com/projectbeskar/virtrigaud/internal/config"
+	"github.com/projectbeskar/virtrigaud/internal/events"
 	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
-	"github.com/projectbeskar/virtrigaud/internal/runtime/remote"
 	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
 )
 
@@ -49,14 +55,16 @@ const errReasonGetSnapshot = "get-snapshot"
 // for a memory-inclusive request). Only emitted when
 // EnforceCapabilities is true and the provider implements
 // contracts.CapabilityReporter.
-const snapshotReasonUnsupportedByProvider = "UnsupportedByProvider"
+const snapshotReasonUnsupportedByProvider = events.ReasonSnapshotUnsupportedByProvider
 
 // VMSnapshotReconciler reconciles a VMSnapshot object
 type VMSnapshotReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
-	RemoteResolver *remote.Resolver
+	// RemoteResolver resolves a Provider CR to a provider implementation;
+	// *remote.Resolver in production.
+	RemoteResolver ProviderResolver
 	Recorder       record.EventRecorder
 	metrics        *metrics.ReconcileMetrics
 
@@ -65,6 +73,10 @@ type VMSnapshotReconciler struct {
 	// default) the create path is byte-for-byte unchanged. See the gate in
 	// createSnapshot.
 	EnforceCapabilities bool
+
+	// Config supplies the blocked-deletion thresholds; nil uses the
+	// defaults.
+	Config *config.ConfigStore
 }
 
 // NewVMSnapshotReconciler creates a new VMSnapshot reconciler.
@@ -76,7 +88,7 @@ type VMSnapshotReconciler struct {
 func NewVMSnapshotReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
-	remoteResolver *remote.Resolver,
+	remoteResolver ProviderResolver,
 	recorder record.EventRecorder,
 	enforceCapabilities bool,
 ) *VMSnapshotReconciler {
@@ -95,6 +107,7 @@ func NewVMSnapshotReconciler(
 //+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmsnapshots/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=vmsnapshots/finalizers,verbs=update
 //+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.virtrigaud.io,resources=virtualmachines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop.
@@ -124,17 +137,17 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		timer.Finish(outcome)
 	}()
 
-	// Add correlation context
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmsnapshot-%s", req.Name))
+	ctx = logging.StartReconcile(ctx, "vmsnapshot", req.NamespacedName)
 	logger := logging.FromContext(ctx)
 
-	logger.Info("Reconciling VMSnapshot", "snapshot", req.NamespacedName)
+	logger.Info("Reconciling VMSnapshot")
 
 	// Fetch the VMSnapshot instance
 	snapshot := &infrav1beta1.VMSnapshot{}
 	if err := r.Get(ctx, req.NamespacedName, snapshot); err != nil {
 		if client.IgnoreNotFound(err) == nil {
 			logger.Info("VMSnapshot not found, ignoring")
+			snapshotConflicts.clear(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get VMSnapshot")
@@ -142,8 +155,8 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	// Add snapshot context
-	ctx = logging.WithCorrelationID(ctx, fmt.Sprintf("vmsnapshot-%s/%s", snapshot.Namespace, snapshot.Name))
+	ctx = audit.WithSubject(ctx, snapshot)
+	ctx = logging.WithLogFields(ctx, "", string(snapshot.Status.Phase))
 	logger = logging.FromContext(ctx)
 
 	// Handle deletion
@@ -152,13 +165,11 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Add finalizer if needed
-	if !controllerutil.ContainsFinalizer(snapshot, "snapshot.infra.virtrigaud.io/finalizer") {
-		controllerutil.AddFinalizer(snapshot, "snapshot.infra.virtrigaud.io/finalizer")
-		if err := r.Update(ctx, snapshot); err != nil {
-			logger.Error(err, "Failed to add finalizer")
-			metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
-			return ctrl.Result{}, err
-		}
+	if added, err := k8s.EnsureFinalizer(ctx, r.Client, snapshot, infrav1beta1.VMSnapshotFinalizer); err != nil {
+		logger.Error(err, "Failed to add finalizer")
+		metrics.RecordError(errReasonAddFinalizer, metrics.ComponentManager)
+		return ctrl.Result{}, err
+	} else if added {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -183,6 +194,18 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	ctx = logging.WithVM(ctx, vm.Namespace, vm.Name)
 	logger = logging.FromContext(ctx)
 
+	// Owned by the VM so a deleted VM's snapshots are garbage-collected.
+	// Not a controller reference: the VM controller does not manage them.
+	if owned, err := controllerutil.HasOwnerReference(snapshot.OwnerReferences, vm, r.Scheme); err == nil && !owned {
+		if err := controllerutil.SetOwnerReference(vm, snapshot, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Update(ctx, snapshot); err != nil {
+			logger.Error(err, "Failed to set VM owner reference")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Check VM status
 	if vm.Status.ID == "" {
 		logger.Info("VM not yet provisioned, waiting")
@@ -193,6 +216,18 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		_ = r.updateStatus(ctx, snapshot)
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
+	// Nothing is created, checked or retired on a VM whose ID now names
+	// another VM.
+	if identityMismatched(vm) {
+		logger.Info("VM's provider ID names another VM, waiting", "vm", vm.Name)
+		if snapshot.Status.Phase == "" {
+			k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionCreating,
+				metav1.ConditionTrue, infrav1beta1.VMSnapshotReasonCreating,
+				"Waiting for the VM's IdentityMismatch to be resolved")
+			_ = r.updateStatus(ctx, snapshot)
+		}
+		return ctrl.Result{RequeueAfter: identityMismatchRecheck}, nil
+	}
 
 	// Handle snapshot lifecycle based on phase
 	switch snapshot.Status.Phase {
@@ -203,7 +238,14 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Check if snapshot creation is complete
 		return r.checkSnapshotCreation(ctx, snapshot, vm)
 	case infrav1beta1.SnapshotPhaseReady:
-		// Snapshot is ready, check for retention policy
+		// Snapshot is ready: refresh the VM's observed snapshots (this also
+		// picks up snapshots taken outside virtrigaud) and this snapshot's
+		// size, then check for retention policy
+		if applyObservedSnapshot(&snapshot.Status, r.syncObservedSnapshots(ctx, vm)) {
+			if err := r.updateStatus(ctx, snapshot); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 		return r.handleRetention(ctx, snapshot)
 	case infrav1beta1.SnapshotPhaseFailed:
 		// Handle failed snapshots
@@ -220,6 +262,13 @@ func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 func (r *VMSnapshotReconciler) createSnapshot(ctx context.Context, snapshot *infrav1beta1.VMSnapshot, vm *infrav1beta1.VirtualMachine) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
 
+	if blocked, res := r.gateSnapshotQuota(ctx, snapshot); blocked {
+		return res, nil
+	}
+	if r.gateSnapshotMaintenance(ctx, snapshot, vm) {
+		return ctrl.Result{RequeueAfter: maintenanceRecheck}, nil
+	}
+
 	logger.Info("Creating VM snapshot")
 
 	// Update phase to creating
@@ -231,15 +280,7 @@ func (r *VMSnapshotReconciler) createSnapshot(ctx context.Context, snapshot *inf
 
 	// Get the provider for this VM
 	provider := &infrav1beta1.Provider{}
-	providerKey := client.ObjectKey{
-		Name:      vm.Spec.ProviderRef.Name,
-		Namespace: vm.Namespace,
-	}
-	if vm.Spec.ProviderRef.Namespace != "" {
-		providerKey.Namespace = vm.Spec.ProviderRef.Namespace
-	}
-
-	if err := r.Get(ctx, providerKey, provider); err != nil {
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
 		logger.Error(err, "Failed to get provider", "provider", vm.Spec.ProviderRef.Name)
 		k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionReady,
 			metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonProviderError,
@@ -250,7 +291,7 @@ func (r *VMSnapshotReconciler) createSnapshot(ctx context.Context, snapshot *inf
 	}
 
 	// Get provider instance
-	providerInstance, err := r.getProviderInstance(ctx, provider)
+	providerInstance, err := r.getProviderInstance(ctx, provider, vm)
 	if err != nil {
 		logger.Error(err, "Failed to get provider instance")
 		k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionReady,
@@ -275,25 +316,41 @@ func (r *VMSnapshotReconciler) createSnapshot(ctx context.Context, snapshot *inf
 
 	// Call provider to create snapshot
 	resp, err := providerInstance.SnapshotCreate(ctx, req)
+	if r.snapshotProviderConflict(ctx, snapshot, "SnapshotCreate", err) {
+		return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
+	}
 	if err != nil {
 		logger.Error(err, "Failed to create snapshot")
+		reason, eventReason := infrav1beta1.VMSnapshotReasonProviderError, events.ReasonSnapshotFailed
+		if quiesceFailed(req, err) {
+			// No snapshot was taken: set quiesce to false, or
+			// quiesceFailurePolicy to Degrade, to take one anyway.
+			reason, eventReason = infrav1beta1.VMSnapshotReasonQuiesceFailed, events.ReasonSnapshotQuiesceFailed
+		}
 		snapshot.Status.Phase = infrav1beta1.SnapshotPhaseFailed
 		snapshot.Status.Message = fmt.Sprintf("Failed to create snapshot: %v", err)
 		k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionReady,
-			metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonProviderError,
+			metav1.ConditionFalse, reason,
 			fmt.Sprintf("Snapshot creation failed: %v", err))
 		k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionCreating,
-			metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonProviderError,
+			metav1.ConditionFalse, reason,
 			fmt.Sprintf("Snapshot creation failed: %v", err))
-		r.Recorder.Event(snapshot, "Warning", "SnapshotFailed", fmt.Sprintf("Failed to create snapshot: %v", err))
+		r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, eventReason, fmt.Sprintf("Failed to create snapshot: %v", err))
 		// Status update errors are intentionally ignored to avoid blocking reconciliation
 		_ = r.updateStatus(ctx, snapshot)
 		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 	}
 
+	snapshotConflicts.clear(client.ObjectKeyFromObject(snapshot))
+
 	// Update status with snapshot information
 	snapshot.Status.SnapshotID = resp.SnapshotId
 	snapshot.Status.CreationTime = &metav1.Time{Time: time.Now()}
+	if !resp.CreatedAt.IsZero() {
+		snapshot.Status.CreationTime = &metav1.Time{Time: resp.CreatedAt}
+	}
+	setSnapshotSize(&snapshot.Status, resp.SizeBytes, resp.ConsumedBytes)
+	r.recordQuiesceOutcome(ctx, snapshot, resp)
 
 	// Check if there's a task to monitor
 	if resp.Task != nil && resp.Task.ID != "" {
@@ -312,7 +369,7 @@ func (r *VMSnapshotReconciler) createSnapshot(ctx context.Context, snapshot *inf
 			metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonCreated,
 			"Snapshot creation completed")
 
-		r.Recorder.Event(snapshot, "Normal", "SnapshotReady", "Snapshot created successfully")
+		r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotReady, "Snapshot created successfully")
 		logger.Info("Snapshot created successfully", "snapshot_id", resp.SnapshotId)
 	}
 
@@ -348,32 +405,24 @@ func (r *VMSnapshotReconciler) checkSnapshotCreation(ctx context.Context, snapsh
 			metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonCreated,
 			"Snapshot creation completed")
 
-		r.Recorder.Event(snapshot, "Normal", "SnapshotReady", "Snapshot created successfully")
+		r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotReady, "Snapshot created successfully")
 
+		applyObservedSnapshot(&snapshot.Status, r.syncObservedSnapshots(ctx, vm))
 		if err := r.updateStatus(ctx, snapshot); err != nil {
 			return ctrl.Result{}, err
 		}
-
 		return ctrl.Result{}, nil
 	}
 
 	// Get the provider to check task status
 	provider := &infrav1beta1.Provider{}
-	providerKey := client.ObjectKey{
-		Name:      vm.Spec.ProviderRef.Name,
-		Namespace: vm.Namespace,
-	}
-	if vm.Spec.ProviderRef.Namespace != "" {
-		providerKey.Namespace = vm.Spec.ProviderRef.Namespace
-	}
-
-	if err := r.Get(ctx, providerKey, provider); err != nil {
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
 		logger.Error(err, "Failed to get provider")
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
 	// Get provider instance
-	providerInstance, err := r.getProviderInstance(ctx, provider)
+	providerInstance, err := r.getProviderInstance(ctx, provider, vm)
 	if err != nil {
 		logger.Error(err, "Failed to get provider instance")
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -401,7 +450,7 @@ func (r *VMSnapshotReconciler) checkSnapshotCreation(ctx context.Context, snapsh
 				metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonProviderError,
 				"Snapshot creation failed")
 
-			r.Recorder.Event(snapshot, "Warning", "SnapshotFailed", fmt.Sprintf("Snapshot creation failed: %s", taskStatus.Error))
+			r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, events.ReasonSnapshotFailed, fmt.Sprintf("Snapshot creation failed: %s", taskStatus.Error))
 		} else {
 			// Task succeeded
 			snapshot.Status.Phase = infrav1beta1.SnapshotPhaseReady
@@ -415,13 +464,15 @@ func (r *VMSnapshotReconciler) checkSnapshotCreation(ctx context.Context, snapsh
 				metav1.ConditionFalse, infrav1beta1.VMSnapshotReasonCreated,
 				"Snapshot creation completed")
 
-			r.Recorder.Event(snapshot, "Normal", "SnapshotReady", "Snapshot created successfully")
+			r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotReady, "Snapshot created successfully")
+
+			// Async providers know the size only once the task is done.
+			applyObservedSnapshot(&snapshot.Status, r.syncObservedSnapshots(ctx, vm))
 		}
 
 		if err := r.updateStatus(ctx, snapshot); err != nil {
 			return ctrl.Result{}, err
 		}
-
 		return ctrl.Result{}, nil
 	}
 
@@ -487,7 +538,7 @@ func (r *VMSnapshotReconciler) handleDeletion(ctx context.Context, snapshot *inf
 
 	_ = r.updateStatus(ctx, snapshot) //nolint:errcheck // Status update errors logged elsewhere
 
-	r.Recorder.Event(snapshot, "Normal", "SnapshotDeleting", "Started snapshot deletion")
+	r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotDeleting, "Started snapshot deletion")
 
 	// Get the VM to find the provider
 	vm := &infrav1beta1.VirtualMachine{}
@@ -509,56 +560,129 @@ func (r *VMSnapshotReconciler) handleDeletion(ctx context.Context, snapshot *inf
 		}
 	}
 
+	retain := snapshot.Spec.DeletionPolicy == infrav1beta1.SnapshotDeletionPolicyRetain
+	if retain && snapshot.Status.SnapshotID != "" {
+		logger.Info("Retaining provider snapshot per deletion policy", "snapshot_id", snapshot.Status.SnapshotID)
+		r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotRetained, fmt.Sprintf("Provider snapshot %s retained (deletionPolicy: Retain)", snapshot.Status.SnapshotID))
+	}
+
+	// The snapshot went with the VM the ID used to name; the one there now
+	// is not touched.
+	foreign := !vmNotFound && identityMismatched(vm)
+	if foreign {
+		logger.Info("VM's provider ID names another VM, skipping provider snapshot deletion", "vm", vm.Name)
+	}
+
 	// Only call provider if VM still exists and we have a snapshot ID
-	if !vmNotFound && snapshot.Status.SnapshotID != "" && vm.Status.ID != "" {
+	if !retain && !vmNotFound && !foreign && snapshot.Status.SnapshotID != "" && vm.Status.ID != "" {
 		// Get the provider
+		// Resolved without the cross-namespace check so a revoked grant
+		// cannot leave the provider snapshot behind.
 		provider := &infrav1beta1.Provider{}
-		providerKey := client.ObjectKey{
-			Name:      vm.Spec.ProviderRef.Name,
-			Namespace: vm.Namespace,
-		}
-		if vm.Spec.ProviderRef.Namespace != "" {
-			providerKey.Namespace = vm.Spec.ProviderRef.Namespace
-		}
-
-		if err := r.Get(ctx, providerKey, provider); err != nil {
+		err := r.Get(ctx, k8s.RefKey(vm.Spec.ProviderRef, vm.Namespace), provider)
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Error(err, "Provider not found", "provider", vm.Spec.ProviderRef.Name)
+			return r.unreachableSnapshotDeletion(ctx, snapshot, vm, true, err)
+		case err != nil:
 			logger.Error(err, "Failed to get provider", "provider", vm.Spec.ProviderRef.Name)
 			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 		}
 
 		// Get provider instance
-		providerInstance, err := r.getProviderInstance(ctx, provider)
+		providerInstance, err := r.getProviderInstance(ctx, provider, vm)
 		if err != nil {
 			logger.Error(err, "Failed to get provider instance")
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			return r.unreachableSnapshotDeletion(ctx, snapshot, vm, false, err)
 		}
 
 		// Delete the snapshot via provider
 		logger.Info("Calling provider to delete snapshot", "snapshot_id", snapshot.Status.SnapshotID, "vm_id", vm.Status.ID)
 		_, err = providerInstance.SnapshotDelete(ctx, vm.Status.ID, snapshot.Status.SnapshotID)
+		if r.snapshotProviderConflict(ctx, snapshot, "SnapshotDelete", err) {
+			// Dropping the finalizer now would leave the snapshot on the
+			// hypervisor; the delete goes through once the other change ends.
+			return ctrl.Result{RequeueAfter: providerConflictRetry}, nil
+		}
+		snapshotConflicts.clear(client.ObjectKeyFromObject(snapshot))
 		if err != nil {
 			logger.Error(err, "Failed to delete snapshot via provider")
 			// Log the error but continue with finalizer removal
 			// The snapshot may already be deleted or the VM may be gone
-			r.Recorder.Event(snapshot, "Warning", "SnapshotDeleteFailed", fmt.Sprintf("Failed to delete snapshot: %v", err))
+			r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, events.ReasonSnapshotDeleteFailed, fmt.Sprintf("Failed to delete snapshot: %v", err))
 		} else {
 			logger.Info("Snapshot deleted successfully via provider")
+			r.syncObservedSnapshots(ctx, vm)
 		}
 	}
 
-	// Remove finalizer
-	controllerutil.RemoveFinalizer(snapshot, "snapshot.infra.virtrigaud.io/finalizer")
-	if err := r.Update(ctx, snapshot); err != nil {
+	return r.removeSnapshotFinalizer(ctx, snapshot)
+}
+
+// removeSnapshotFinalizer lets the API server delete the VMSnapshot.
+func (r *VMSnapshotReconciler) removeSnapshotFinalizer(ctx context.Context, snapshot *infrav1beta1.VMSnapshot) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+	if err := k8s.RemoveFinalizer(ctx, r.Client, snapshot, infrav1beta1.VMSnapshotFinalizer); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
+		metrics.RecordError(errReasonRemoveFinalizer, metrics.ComponentManager)
 		return ctrl.Result{}, err
 	}
 
-	r.Recorder.Event(snapshot, "Normal", "SnapshotDeleted", "Snapshot deleted successfully")
+	r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonSnapshotDeleted, "Snapshot deleted successfully")
 	logger.Info("VM snapshot deleted successfully")
 
 	return ctrl.Result{}, nil
 }
 
+// unreachableSnapshotRetry is how often a snapshot deletion that cannot
+// reach the provider is retried.
+const unreachableSnapshotRetry = 30 * time.Second
+
+// unreachableSnapshotDeletion handles a deletion that could not reach the
+// provider of the snapshot's VM: the finalizer stays, unless the deletion
+// is blocked and the snapshot or its VM opted in to abandoning it, or the
+// Provider is gone.
+func (r *VMSnapshotReconciler) unreachableSnapshotDeletion(
+	ctx context.Context,
+	snapshot *infrav1beta1.VMSnapshot,
+	vm *infrav1beta1.VirtualMachine,
+	providerGone bool,
+	cause error,
+) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx)
+	abandon, err := handleUnreachableDeletion(ctx, r.Client, r.Config.Get().Deletion, unreachableDeletion{
+		obj:          snapshot,
+		conditions:   &snapshot.Status.Conditions,
+		providerGone: providerGone,
+		optedIn:      k8s.AbandonsOnProviderLoss(snapshot) || k8s.AbandonsOnProviderLoss(vm),
+		cause:        cause,
+		retry:        unreachableSnapshotRetry,
+		emit: func(eventType, reason, message string) {
+			r.recordEvent(ctx, snapshot, eventType, reason, message)
+		},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to track blocked deletion")
+	}
+	if abandon {
+		return r.removeSnapshotFinalizer(ctx, snapshot)
+	}
+	_ = r.updateStatus(ctx, snapshot) //nolint:errcheck // Status update errors logged in updateStatus
+	return ctrl.Result{RequeueAfter: unreachableSnapshotRetry}, nil
+}
+
+// recordEvent emits an event on the VMSnapshot carrying its phase.
+func (r *VMSnapshotReconciler) recordEvent(ctx context.Context, snapshot *infrav1beta1.VMSnapshot, eventType, reason, message string) {
+	events.Emit(ctx, r.Recorder, snapshot, eventType, reason, string(snapshot.Status.Phase), message)
+}
+
+// snapshotProviderConflict is providerConflict for a VMSnapshot.
+func (r *VMSnapshotReconciler) snapshotProviderConflict(ctx context.Context, snapshot *infrav1beta1.VMSnapshot, operation string, err error) bool {
+	return providerConflict(ctx, snapshotConflicts, snapshot, operation, err, func(eventType, reason, message string) {
+		r.recordEvent(ctx, snapshot, eventType, reason, message)
+	})
+}
+
 // updateStatus updates the snapshot status
 func (r *VMSnapshotReconciler) updateStatus(ctx context.Context, snapshot *infrav1beta1.VMSnapshot) error {
 	if err := r.Status().Update(ctx, snapshot); err != nil {
@@ -576,8 +700,9 @@ func (r *VMSnapshotReconciler) updateStatus(ctx context.Context, snapshot *infra
 //   - providerInstance implements contracts.CapabilityReporter and its
 //     GetCapabilities call succeeds, AND
 //   - the provider reports it cannot satisfy the request: snapshots are
-//     unsupported, or the request includes memory and memory snapshots are
-//     unsupported.
+//     unsupported, the request includes memory and memory snapshots are
+//     unsupported, or the request must be quiesced and quiesced snapshots
+//     are unsupported.
 //
 // In all other cases it returns blocked=false and the snapshot proceeds:
 //   - enforcement off → no-op (byte-for-byte unchanged path),
@@ -621,11 +746,39 @@ func (r *VMSnapshotReconciler) gateSnapshotCreate(
 	case req.IncludeMemory && !caps.SupportsMemorySnapshots:
 		return true, r.blockSnapshot(ctx, snapshot,
 			"Provider does not support memory-inclusive snapshots")
+	case req.Quiesce && !req.AllowUnquiesced && !caps.SupportsQuiescedSnapshots:
+		return true, r.blockSnapshot(ctx, snapshot,
+			"Provider does not support quiesced snapshots; set quiesce to false or quiesceFailurePolicy to Degrade")
 	default:
 		return false, ctrl.Result{}
 	}
 }
 
+// gateSnapshotMaintenance holds a snapshot that has not been taken yet
+// while the VM's provider is in maintenance, recording why on its Ready
+// condition. A provider that cannot be read does not hold it; the create
+// path reports that.
+func (r *VMSnapshotReconciler) gateSnapshotMaintenance(ctx context.Context, snapshot *infrav1beta1.VMSnapshot, vm *infrav1beta1.VirtualMachine) bool {
+	provider := &infrav1beta1.Provider{}
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
+		return false
+	}
+	message, held := maintenanceHold(provider, r.Config.Get(), snapshot)
+	if !held {
+		return false
+	}
+	logging.FromContext(ctx).Info("Not taking snapshot while its provider is in maintenance", "provider", provider.Name)
+	if !heldForMaintenance(snapshot.Status.Conditions) {
+		r.recordEvent(ctx, snapshot, corev1.EventTypeNormal, events.ReasonProviderInMaintenance, message)
+	}
+	snapshot.Status.Message = message
+	k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionReady,
+		metav1.ConditionFalse, ReasonProviderInMaintenance, message)
+	// Status update errors are intentionally ignored to avoid blocking reconciliation
+	_ = r.updateStatus(ctx, snapshot)
+	return true
+}
+
 // blockSnapshot marks the snapshot as failed because capability enforcement
 // refused the operation (issue #176), records a Warning event, and persists
 // status. The returned ctrl.Result intentionally does not requeue: the
@@ -642,7 +795,7 @@ func (r *VMSnapshotReconciler) blockSnapshot(ctx context.Context, snapshot *infr
 		metav1.ConditionFalse, snapshotReasonUnsupportedByProvider, message)
 	k8s.SetCondition(&snapshot.Status.Conditions, infrav1beta1.VMSnapshotConditionCreating,
 		metav1.ConditionFalse, snapshotReasonUnsupportedByProvider, message)
-	r.Recorder.Event(snapshot, "Warning", snapshotReasonUnsupportedByProvider, message)
+	r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, events.ReasonSnapshotUnsupportedByProvider, message)
 
 	// Status update errors are intentionally ignored to avoid blocking
 	// reconciliation, matching the surrounding create-path error handling.
@@ -650,14 +803,15 @@ func (r *VMSnapshotReconciler) blockSnapshot(ctx context.Context, snapshot *infr
 	return ctrl.Result{}
 }
 
-// getProviderInstance resolves a provider to a remote implementation
-func (r *VMSnapshotReconciler) getProviderInstance(ctx context.Context, provider *infrav1beta1.Provider) (contracts.Provider, error) {
+// getProviderInstance resolves a provider to a remote implementation,
+// reaching the same replica as the VM's own RPCs
+func (r *VMSnapshotReconciler) getProviderInstance(ctx context.Context, provider *infrav1beta1.Provider, vm *infrav1beta1.VirtualMachine) (contracts.Provider, error) {
 	// All providers are now remote
 	if r.RemoteResolver == nil {
 		return nil, fmt.Errorf("no remote resolver available")
 	}
 
-	return r.RemoteResolver.GetProvider(ctx, provider)
+	return resolveForVM(ctx, r.RemoteResolver, provider, vm)
 }
 
 // buildSnapshotCreateRequest builds a snapshot create request from the snapshot spec
@@ -677,6 +831,7 @@ func (r *VMSnapshotReconciler) buildSnapshotCreateRequest(snapshot *infrav1beta1
 		req.Description = snapshot.Spec.SnapshotConfig.Description
 		req.IncludeMemory = snapshot.Spec.SnapshotConfig.IncludeMemory
 		req.Quiesce = snapshot.Spec.SnapshotConfig.Quiesce
+		req.AllowUnquiesced = allowUnquiesced(snapshot)
 	} else {
 		// Use the VMSnapshot resource name as a hint
 		req.NameHint = snapshot.Name
@@ -688,7 +843,40 @@ func (r *VMSnapshotReconciler) buildSnapshotCreateRequest(snapshot *infrav1beta1
 
 // SetupWithManager sets up the controller with the Manager
 func (r *VMSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexFields(mgr, &infrav1beta1.VMSnapshot{}, map[string]client.IndexerFunc{
+		snapshotVMRefIndex: indexSnapshotVMRef,
+	}); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1beta1.VMSnapshot{}).
+		Watches(&infrav1beta1.VirtualMachine{}, enqueueDependents(r.snapshotsForVM),
+			builder.WithPredicates(vmProvisioningChanged)).
+		Watches(&infrav1beta1.Provider{}, enqueueDependents(r.heldSnapshotsForProvider),
+			builder.WithPredicates(providerMaintenanceEnded)).
 		Complete(r)
 }
+
+// snapshotsForVM maps a VirtualMachine to its VMSnapshots, so that a
+// snapshot waiting for the VM to be provisioned proceeds when it is.
+func (r *VMSnapshotReconciler) snapshotsForVM(ctx context.Context, obj client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &infrav1beta1.VMSnapshotList{}, snapshotVMRefIndex, obj)
+}
+
+// heldSnapshotsForProvider maps a Provider to the VMSnapshots its
+// maintenance holds, so that they are taken as soon as it ends.
+func (r *VMSnapshotReconciler) heldSnapshotsForProvider(ctx context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, vm := range vmsOnProvider(ctx, r.Client, obj) {
+		snapshots := &infrav1beta1.VMSnapshotList{}
+		if err := r.List(ctx, snapshots, client.MatchingFields{snapshotVMRefIndex: client.ObjectKeyFromObject(&vm).String()}); err != nil {
+			continue
+		}
+		for i := range snapshots.Items {
+			if heldForMaintenance(snapshots.Items[i].Status.Conditions) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&snapshots.Items[i])})
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// observedSnapshots converts a provider snapshot list into VM status form.
+func observedSnapshots(list []contracts.SnapshotInfo) []infrav1beta1.VMSnapshotInfo {
+	if len(list) == 0 {
+		return nil
+	}
+	out := make([]infrav1beta1.VMSnapshotInfo, 0, len(list))
+	for _, s := range list {
+		info := infrav1beta1.VMSnapshotInfo{
+			ID:            s.ID,
+			Name:          s.Name,
+			Description:   s.Description,
+			HasMemory:     s.HasMemory,
+			ParentID:      s.ParentID,
+			SizeBytes:     s.SizeBytes,
+			ConsumedBytes: s.ConsumedBytes,
+		}
+		if !s.CreatedAt.IsZero() {
+			info.CreationTime = metav1.NewTime(s.CreatedAt)
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// setSnapshotSize records the sizes a provider reported for a snapshot.
+// A nil size is unknown and leaves what is recorded alone. Size, shown by
+// kubectl, is SizeBytes rounded up to MiB. It reports whether status
+// changed.
+func setSnapshotSize(status *infrav1beta1.VMSnapshotStatus, sizeBytes, consumedBytes *int64) bool {
+	changed := false
+	if sizeBytes != nil && (status.SizeBytes == nil || *status.SizeBytes != *sizeBytes) {
+		status.SizeBytes = ptr.To(*sizeBytes)
+		const mib = 1 << 20
+		status.Size = resource.NewQuantity((*sizeBytes+mib-1)/mib*mib, resource.BinarySI)
+		changed = true
+	}
+	if consumedBytes != nil && (status.ConsumedBytes == nil || *status.ConsumedBytes != *consumedBytes) {
+		status.ConsumedBytes = ptr.To(*consumedBytes)
+		changed = true
+	}
+	return changed
+}
+
+// applyObservedSnapshot copies the hypervisor's creation time and the sizes
+// of the snapshot status.SnapshotID from a provider snapshot list. It
+// reports whether status changed.
+func applyObservedSnapshot(status *infrav1beta1.VMSnapshotStatus, list []contracts.SnapshotInfo) bool {
+	if status.SnapshotID == "" {
+		return false
+	}
+	for _, s := range list {
+		if s.ID != status.SnapshotID {
+			continue
+		}
+		changed := setSnapshotSize(status, s.SizeBytes, s.ConsumedBytes)
+		if !s.CreatedAt.IsZero() && (status.CreationTime == nil || !status.CreationTime.Time.Equal(s.CreatedAt)) {
+			status.CreationTime = &metav1.Time{Time: s.CreatedAt}
+			changed = true
+		}
+		return changed
+	}
+	return false
+}
+
+// syncObservedSnapshots records the snapshots the provider reports for vm in
+// vm.Status.Snapshots and returns them. It is best effort: providers that
+// cannot list snapshots are skipped, and failures are logged without
+// affecting the VMSnapshot being reconciled; either way it returns nil.
+func (r *VMSnapshotReconciler) syncObservedSnapshots(ctx context.Context, vm *infrav1beta1.VirtualMachine) []contracts.SnapshotInfo {
+	logger := logging.FromContext(ctx)
+	if vm.Status.ID == "" {
+		return nil
+	}
+
+	provider := &infrav1beta1.Provider{}
+	if err := k8s.GetRef(ctx, r.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
+		logger.V(1).Info("Skipping snapshot sync: provider unavailable", "error", err.Error())
+		return nil
+	}
+	providerInstance, err := r.getProviderInstance(ctx, provider, vm)
+	if err != nil {
+		logger.V(1).Info("Skipping snapshot sync: provider unavailable", "error", err.Error())
+		return nil
+	}
+	lister, ok := providerInstance.(contracts.SnapshotLister)
+	if !ok {
+		return nil
+	}
+
+	list, err := lister.SnapshotList(ctx, vm.Status.ID)
+	if err != nil {
+		if !contracts.IsNotSupported(err) {
+			logger.Error(err, "Failed to list snapshots from provider")
+		}
+		return nil
+	}
+	observed := observedSnapshots(list)
+
+	key := client.ObjectKeyFromObject(vm)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &infrav1beta1.VirtualMachine{}
+		if err := r.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(latest.Status.Snapshots, observed) {
+			return nil
+		}
+		latest.Status.Snapshots = observed
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to record observed snapshots on VirtualMachine")
+	}
+	return list
+}
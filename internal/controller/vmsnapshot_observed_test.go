@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// snapshotListerProvider is a stub provider that also lists snapshots.
+type snapshotListerProvider struct {
+	stubProvider
+	snapshots []contracts.SnapshotInfo
+	err       error
+	listedID  string
+}
+
+func (p *snapshotListerProvider) SnapshotList(_ context.Context, vmID string) ([]contracts.SnapshotInfo, error) {
+	p.listedID = vmID
+	return p.snapshots, p.err
+}
+
+func TestVMSnapshot_ReadySyncsObservedSnapshots(t *testing.T) {
+	sch := newMetricsScheme(t)
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	vm := &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       infrav1beta1.VirtualMachineSpec{ProviderRef: infrav1beta1.ObjectRef{Name: "pve"}},
+	}
+	vm.Status.ID = "pve/100"
+	snapshot := &infrav1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "nightly",
+			Namespace:  "default",
+			Finalizers: []string{"snapshot.infra.virtrigaud.io/finalizer"},
+		},
+		Spec: infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: "web"}},
+	}
+	snapshot.Status.Phase = infrav1beta1.SnapshotPhaseReady
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"}}
+
+	lister := &snapshotListerProvider{snapshots: []contracts.SnapshotInfo{
+		{ID: "nightly", Name: "nightly", CreatedAt: created},
+		// Taken by hand on the hypervisor, not through a VMSnapshot.
+		{ID: "manual", Name: "manual", HasMemory: true, ParentID: "nightly"},
+	}}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm, snapshot, provider).
+		WithStatusSubresource(&infrav1beta1.VirtualMachine{}, &infrav1beta1.VMSnapshot{}).
+		Build()
+	r := &VMSnapshotReconciler{
+		Client:         cli,
+		Scheme:         sch,
+		RemoteResolver: &stubResolver{provider: lister},
+		Recorder:       record.NewFakeRecorder(10),
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "nightly", Namespace: "default"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pve/100", lister.listedID)
+
+	got := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Name: "web", Namespace: "default"}, got))
+	require.Len(t, got.Status.Snapshots, 2)
+	assert.Equal(t, "nightly", got.Status.Snapshots[0].ID)
+	assert.True(t, got.Status.Snapshots[0].CreationTime.Equal(&metav1.Time{Time: created}))
+	assert.Equal(t, infrav1beta1.VMSnapshotInfo{ID: "manual", Name: "manual", HasMemory: true, ParentID: "nightly"},
+		got.Status.Snapshots[1])
+
+	// A provider that cannot list snapshots leaves the status alone.
+	lister.err = contracts.NewNotSupportedError("snapshotList: provider does not list snapshots")
+	r.syncObservedSnapshots(context.Background(), got)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Name: "web", Namespace: "default"}, got))
+	assert.Len(t, got.Status.Snapshots, 2)
+
+	// Deleting the last snapshot on the hypervisor clears the list.
+	lister.err = nil
+	lister.snapshots = nil
+	r.syncObservedSnapshots(context.Background(), got)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Name: "web", Namespace: "default"}, got))
+	assert.Empty(t, got.Status.Snapshots)
+}
+
+// TestVMSnapshot_ReadyRecordsSnapshotSize copies the hypervisor's creation
+// time and the provider-reported sizes of the snapshot into its status.
+func TestVMSnapshot_ReadyRecordsSnapshotSize(t *testing.T) {
+	sch := newMetricsScheme(t)
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	vm := &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       infrav1beta1.VirtualMachineSpec{ProviderRef: infrav1beta1.ObjectRef{Name: "pve"}},
+	}
+	vm.Status.ID = "pve/100"
+	snapshot := &infrav1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "nightly",
+			Namespace:  "default",
+			Finalizers: []string{"snapshot.infra.virtrigaud.io/finalizer"},
+		},
+		Spec: infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: "web"}},
+	}
+	snapshot.Status.Phase = infrav1beta1.SnapshotPhaseReady
+	snapshot.Status.SnapshotID = "nightly"
+	snapshot.Status.CreationTime = &metav1.Time{Time: created.Add(time.Minute)}
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "pve", Namespace: "default"}}
+
+	lister := &snapshotListerProvider{snapshots: []contracts.SnapshotInfo{
+		{ID: "nightly", Name: "nightly", CreatedAt: created, SizeBytes: ptr.To[int64](4<<30 + 1), ConsumedBytes: ptr.To[int64](3 << 30)},
+		{ID: "other", Name: "other", SizeBytes: ptr.To[int64](1 << 30)},
+	}}
+	cli := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(vm, snapshot, provider).
+		WithStatusSubresource(&infrav1beta1.VirtualMachine{}, &infrav1beta1.VMSnapshot{}).
+		Build()
+	r := &VMSnapshotReconciler{
+		Client:         cli,
+		Scheme:         sch,
+		RemoteResolver: &stubResolver{provider: lister},
+		Recorder:       record.NewFakeRecorder(10),
+	}
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	got := &infrav1beta1.VMSnapshot{}
+	require.NoError(t, cli.Get(context.Background(), key, got))
+	assert.Equal(t, ptr.To[int64](4<<30+1), got.Status.SizeBytes)
+	assert.Equal(t, ptr.To[int64](3<<30), got.Status.ConsumedBytes)
+	require.NotNil(t, got.Status.Size)
+	assert.Equal(t, "4097Mi", got.Status.Size.String(), "rounded up to MiB")
+	require.NotNil(t, got.Status.CreationTime)
+	assert.True(t, got.Status.CreationTime.Time.Equal(created), "hypervisor time wins")
+
+	// A size the provider can no longer tell is not erased.
+	lister.snapshots = []contracts.SnapshotInfo{{ID: "nightly", Name: "nightly"}}
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(context.Background(), key, got))
+	assert.Equal(t, ptr.To[int64](4<<30+1), got.Status.SizeBytes)
+	assert.Equal(t, ptr.To[int64](3<<30), got.Status.ConsumedBytes)
+}
+
+func TestSetSnapshotSize(t *testing.T) {
+	var status infrav1beta1.VMSnapshotStatus
+	assert.False(t, setSnapshotSize(&status, nil, nil))
+	assert.Nil(t, status.Size, "unknown is not zero")
+
+	assert.True(t, setSnapshotSize(&status, ptr.To[int64](2<<30), nil))
+	assert.Equal(t, "2Gi", status.Size.String())
+	assert.Nil(t, status.ConsumedBytes)
+
+	assert.False(t, setSnapshotSize(&status, ptr.To[int64](2<<30), nil))
+	assert.True(t, setSnapshotSize(&status, nil, ptr.To[int64](1<<30)))
+	assert.Equal(t, ptr.To[int64](1<<30), status.ConsumedBytes)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// allowUnquiesced reports whether snapshot may be taken crash-consistent
+// when its VM cannot be quiesced. Only an explicit Degrade policy allows it.
+func allowUnquiesced(snapshot *infrav1beta1.VMSnapshot) bool {
+	cfg := snapshot.Spec.SnapshotConfig
+	return cfg != nil && cfg.QuiesceFailurePolicy == infrav1beta1.QuiesceFailurePolicyDegrade
+}
+
+// quiesceFailed reports whether err is a provider refusing a quiesced
+// snapshot because the VM's guest agent did not answer. Providers answer
+// FailedPrecondition for that and for nothing else SnapshotCreate does.
+func quiesceFailed(req contracts.SnapshotCreateRequest, err error) bool {
+	return req.Quiesce && contracts.IsFailedPrecondition(err)
+}
+
+// snapshotQuiesceOutcome maps the quiesce outcome a provider reports onto
+// the VMSnapshot status; an unreported outcome stays unset.
+func snapshotQuiesceOutcome(outcome contracts.QuiesceOutcome) infrav1beta1.SnapshotQuiesceOutcome {
+	switch outcome {
+	case contracts.QuiesceAchieved:
+		return infrav1beta1.SnapshotQuiesceAchieved
+	case contracts.QuiesceDegraded:
+		return infrav1beta1.SnapshotQuiesceDegraded
+	case contracts.QuiesceSkipped:
+		return infrav1beta1.SnapshotQuiesceSkipped
+	default:
+		return ""
+	}
+}
+
+// recordQuiesceOutcome records in snapshot's status whether the provider
+// quiesced the VM for it, and warns when the snapshot is crash-consistent
+// though quiesce was requested.
+func (r *VMSnapshotReconciler) recordQuiesceOutcome(ctx context.Context, snapshot *infrav1beta1.VMSnapshot, resp contracts.SnapshotCreateResponse) {
+	snapshot.Status.Quiesce = snapshotQuiesceOutcome(resp.Quiesce)
+	snapshot.Status.QuiesceMessage = resp.QuiesceMessage
+	if snapshot.Status.Quiesce != infrav1beta1.SnapshotQuiesceDegraded {
+		return
+	}
+	message := "Snapshot is crash-consistent: the VM could not be quiesced"
+	if resp.QuiesceMessage != "" {
+		message = fmt.Sprintf("%s: %s", message, resp.QuiesceMessage)
+	}
+	r.recordEvent(ctx, snapshot, corev1.EventTypeWarning, events.ReasonSnapshotQuiesceDegraded, message)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestAllowUnquiesced(t *testing.T) {
+	snapshot := &infrav1beta1.VMSnapshot{}
+	assert.False(t, allowUnquiesced(snapshot), "no snapshot config defaults to Fail")
+
+	snapshot.Spec.SnapshotConfig = &infrav1beta1.SnapshotConfig{}
+	assert.False(t, allowUnquiesced(snapshot), "an unset policy is Fail")
+
+	snapshot.Spec.SnapshotConfig.QuiesceFailurePolicy = infrav1beta1.QuiesceFailurePolicyDegrade
+	assert.True(t, allowUnquiesced(snapshot))
+}
+
+func TestQuiesceFailed(t *testing.T) {
+	refused := contracts.NewFailedPreconditionError("guest agent does not respond", nil)
+
+	assert.True(t, quiesceFailed(contracts.SnapshotCreateRequest{Quiesce: true}, refused))
+	assert.False(t, quiesceFailed(contracts.SnapshotCreateRequest{}, refused),
+		"a snapshot that did not ask for quiesce cannot fail to quiesce")
+	assert.False(t, quiesceFailed(contracts.SnapshotCreateRequest{Quiesce: true}, errors.New("disk full")))
+}
+
+func TestRecordQuiesceOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      contracts.SnapshotCreateResponse
+		want      infrav1beta1.SnapshotQuiesceOutcome
+		wantEvent bool
+	}{
+		{
+			name: "achieved",
+			resp: contracts.SnapshotCreateResponse{Quiesce: contracts.QuiesceAchieved},
+			want: infrav1beta1.SnapshotQuiesceAchieved,
+		},
+		{
+			name: "skipped",
+			resp: contracts.SnapshotCreateResponse{Quiesce: contracts.QuiesceSkipped, QuiesceMessage: "the VM is not running"},
+			want: infrav1beta1.SnapshotQuiesceSkipped,
+		},
+		{
+			name:      "degraded warns",
+			resp:      contracts.SnapshotCreateResponse{Quiesce: contracts.QuiesceDegraded, QuiesceMessage: "guest agent does not respond"},
+			want:      infrav1beta1.SnapshotQuiesceDegraded,
+			wantEvent: true,
+		},
+		{
+			name: "older provider leaves the outcome unset",
+			resp: contracts.SnapshotCreateResponse{},
+			want: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &VMSnapshotReconciler{Recorder: recorder}
+			snapshot := &infrav1beta1.VMSnapshot{}
+
+			r.recordQuiesceOutcome(context.Background(), snapshot, tc.resp)
+
+			assert.Equal(t, tc.want, snapshot.Status.Quiesce)
+			assert.Equal(t, tc.resp.QuiesceMessage, snapshot.Status.QuiesceMessage)
+			if !tc.wantEvent {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			event := <-recorder.Events
+			assert.Contains(t, event, events.ReasonSnapshotQuiesceDegraded)
+			assert.Contains(t, event, tc.resp.QuiesceMessage)
+		})
+	}
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events is the taxonomy of Kubernetes Event reasons the
+// controllers emit. Every reason belongs to one lifecycle Area, so alerts
+// can select on a reason without knowing which controller raised it.
+// docs/events.md lists the reasons by area.
+//
+// Controllers emit through Emit, which appends the object's phase and the
+// reconcile's correlation ID to the message. A unit test in
+// internal/controller rejects reasons that are not taken from this package.
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+)
+
+// Area groups event reasons by the lifecycle they report on.
+type Area string
+
+// Lifecycle areas
+const (
+	AreaProvisioning       Area = "Provisioning"
+	AreaPowerChange        Area = "PowerChange"
+	AreaReconfigure        Area = "Reconfigure"
+	AreaSnapshotLifecycle  Area = "SnapshotLifecycle"
+	AreaCloneLifecycle     Area = "CloneLifecycle"
+	AreaImageLifecycle     Area = "ImageLifecycle"
+	AreaMigrationLifecycle Area = "MigrationLifecycle"
+	AreaProviderHealth     Area = "ProviderHealth"
+	AreaCleanup            Area = "Cleanup"
+	// AreaConfiguration covers how the manager itself is configured:
+	// manager settings, paused reconciliation and dry-run plans.
+	AreaConfiguration Area = "Configuration"
+	// AreaQuota covers VirtrigaudQuota enforcement.
+	AreaQuota Area = "Quota"
+	// AreaHypervisor covers what providers report from the hypervisor
+	// about the VMs they manage.
+	AreaHypervisor Area = "Hypervisor"
+)
+
+// Provisioning reasons
+const (
+	ReasonVMCreated                     = "VMCreated"
+	ReasonVMCreateFailed                = "VMCreateFailed"
+	ReasonVMCreateDeferred              = "VMCreateDeferred"
+	ReasonVMAdopted                     = "Adopted"
+	ReasonGuestCustomizationUnsupported = "GuestCustomizationUnsupported"
+	ReasonProtocolFeatureUnsupported    = "ProtocolFeatureUnsupported"
+	ReasonProviderFieldsIgnored         = "ProviderFieldsIgnored"
+	ReasonIdentityMismatch              = "IdentityMismatch"
+	ReasonIdentityAccepted              = "IdentityAccepted"
+	ReasonTaskFailed                    = "TaskFailed"
+)
+
+// PowerChange reasons
+const (
+	ReasonPowerChangeRequested = "PowerChangeRequested"
+	ReasonPowerChangeFailed    = "PowerChangeFailed"
+	ReasonPowerOpSucceeded     = "PowerOpSucceeded"
+	ReasonPowerOpFailed        = "PowerOpFailed"
+)
+
+// Reconfigure reasons
+const (
+	ReasonReconfigured       = "Reconfigured"
+	ReasonReconfigureFailed  = "ReconfigureFailed"
+	ReasonPowerCycleRequired = "PowerCycleRequired"
+	ReasonDriftDetected      = "DriftDetected"
+	// A power cycle that applies pending changes under the Automatic or
+	// Manual update strategy
+	ReasonUpdateCycleStarted    = "UpdateCycleStarted"
+	ReasonUpdateCycleForcedOff  = "UpdateCycleForcedOff"
+	ReasonUpdateCyclePoweringOn = "UpdateCyclePoweringOn"
+	ReasonUpdateCycleCompleted  = "UpdateCycleCompleted"
+	ReasonUpdateCycleFailed     = "UpdateCycleFailed"
+	// A change the VM's snapshots would make the provider refuse
+	ReasonReconfigureBlockedBySnapshots = "ReconfigureBlockedBySnapshots"
+)
+
+// SnapshotLifecycle reasons
+const (
+	ReasonSnapshotReady                 = "SnapshotReady"
+	ReasonSnapshotFailed                = "SnapshotFailed"
+	ReasonSnapshotUnsupportedByProvider = "UnsupportedByProvider"
+	ReasonSnapshotDeleting              = "SnapshotDeleting"
+	ReasonSnapshotDeleted               = "SnapshotDeleted"
+	ReasonSnapshotDeleteFailed          = "SnapshotDeleteFailed"
+	ReasonSnapshotRetained              = "SnapshotRetained"
+	ReasonDeletionBlockedBySnapshots    = "DeletionBlockedBySnapshots"
+	ReasonSnapshotQuiesceFailed         = "QuiesceFailed"
+	ReasonSnapshotQuiesceDegraded       = "QuiesceDegraded"
+	ReasonSnapshotChainLong             = "SnapshotChainLong"
+)
+
+// CloneLifecycle reasons
+const (
+	ReasonCloneCompleted = "CloneCompleted"
+	ReasonCloneFailed    = "CloneFailed"
+)
+
+// ImageLifecycle reasons
+const (
+	ReasonImagePublishQuiescing = "ImagePublishQuiescing"
+	ReasonImagePublished        = "ImagePublished"
+	ReasonImagePublishFailed    = "ImagePublishFailed"
+)
+
+// MigrationLifecycle reasons
+const (
+	ReasonMigrationValidationStarted  = "ValidationStarted"
+	ReasonMigrationValidationComplete = "ValidationComplete"
+	ReasonMigrationSourcePowerOff     = "SourcePowerOff"
+	ReasonMigrationSnapshotComplete   = "SnapshotComplete"
+	ReasonMigrationPVCCreated         = "PVCCreated"
+	ReasonMigrationExportComplete     = "ExportComplete"
+	ReasonMigrationTransferComplete   = "TransferComplete"
+	ReasonMigrationConversionComplete = "ConversionComplete"
+	ReasonMigrationImportComplete     = "ImportComplete"
+	ReasonMigrationTargetVMCreated    = "TargetVMCreated"
+	ReasonMigrationComplete           = "MigrationComplete"
+	ReasonMigrationRetrying           = "RetryingMigration"
+	ReasonMigrationFailed             = "MigrationFailed"
+
+	// Live migration of a running VM between hypervisor hosts
+	ReasonHostMigrationStarted   = "HostMigrationStarted"
+	ReasonHostMigrationSucceeded = "HostMigrationSucceeded"
+	ReasonHostMigrationFailed    = "HostMigrationFailed"
+)
+
+// ProviderHealth reasons
+const (
+	ReasonProviderHealthy      = "ProviderHealthy"
+	ReasonProviderUnhealthy    = "ProviderUnhealthy"
+	ReasonProviderIncompatible = "ProviderIncompatible"
+
+	ReasonProviderMissingCapabilities = "ProviderMissingCapabilities"
+
+	ReasonProviderEndpointMigrating  = "ProviderEndpointMigrating"
+	ReasonProviderEndpointVerified   = "ProviderEndpointVerified"
+	ReasonProviderEndpointUnverified = "ProviderEndpointUnverified"
+
+	ReasonProviderMaintenanceStarted = "ProviderMaintenanceStarted"
+	ReasonProviderMaintenanceEnded   = "ProviderMaintenanceEnded"
+	ReasonProviderInMaintenance      = "ProviderInMaintenance"
+
+	ReasonProviderConflictPersisting = "ProviderConflictPersisting"
+
+	ReasonProviderBusy         = "ProviderBusy"
+	ReasonProviderBusyExceeded = "ProviderBusyExceeded"
+
+	// Credentials granted from another namespace, recorded on both the
+	// Provider and the source Secret
+	ReasonProviderCredentialsCopied = "ProviderCredentialsCopied"
+	ReasonProviderCredentialsSynced = "ProviderCredentialsSynced"
+	ReasonProviderCredentialsDenied = "ProviderCredentialsDenied"
+)
+
+// Cleanup reasons
+const (
+	ReasonVMDeleted          = "VMDeleted"
+	ReasonVMDeleteFailed     = "VMDeleteFailed"
+	ReasonProviderVMRetained = "ProviderVMRetained"
+	ReasonCleanupComplete    = "CleanupComplete"
+	ReasonCleanupErrors      = "CleanupErrors"
+	ReasonDeletionBlocked    = "DeletionBlocked"
+
+	// Artifacts that failed clones and migrations left on the provider
+	ReasonArtifactCleanupScheduled = "ArtifactCleanupScheduled"
+	ReasonArtifactCleanedUp        = "ArtifactCleanedUp"
+	ReasonArtifactCleanupAbandoned = "ArtifactCleanupAbandoned"
+)
+
+// Configuration reasons
+const (
+	ReasonConfigApplied         = "ConfigApplied"
+	ReasonConfigInvalid         = "ConfigInvalid"
+	ReasonConfigRestartRequired = "RestartRequired"
+	ReasonReconciliationPaused  = "ReconciliationPaused"
+	ReasonReconciliationResumed = "ReconciliationResumed"
+	ReasonPlanComputed          = "PlanComputed"
+)
+
+// Quota reasons
+const (
+	ReasonQuotaExceeded = "QuotaExceeded"
+)
+
+// Hypervisor reasons. The reason the provider reported starts the message.
+const (
+	ReasonHypervisorEvent        = "HypervisorEvent"
+	ReasonHypervisorAlarm        = "HypervisorAlarm"
+	ReasonHypervisorAlarmCleared = "HypervisorAlarmCleared"
+)
+
+// reasonAreas assigns every reason above to its area.
+var reasonAreas = map[string]Area{
+	ReasonVMCreated:                     AreaProvisioning,
+	ReasonVMCreateFailed:                AreaProvisioning,
+	ReasonVMCreateDeferred:              AreaProvisioning,
+	ReasonVMAdopted:                     AreaProvisioning,
+	ReasonGuestCustomizationUnsupported: AreaProvisioning,
+	ReasonProtocolFeatureUnsupported:    AreaProvisioning,
+	ReasonProviderFieldsIgnored:         AreaProvisioning,
+	ReasonIdentityMismatch:              AreaProvisioning,
+	ReasonIdentityAccepted:              AreaProvisioning,
+	ReasonTaskFailed:                    AreaProvisioning,
+
+	ReasonPowerChangeRequested: AreaPowerChange,
+	ReasonPowerChangeFailed:    AreaPowerChange,
+	ReasonPowerOpSucceeded:     AreaPowerChange,
+	ReasonPowerOpFailed:        AreaPowerChange,
+
+	ReasonReconfigured:       AreaReconfigure,
+	ReasonReconfigureFailed:  AreaReconfigure,
+	ReasonPowerCycleRequired: AreaReconfigure,
+	ReasonDriftDetected:      AreaReconfigure,
+
+	ReasonUpdateCycleStarted:    AreaReconfigure,
+	ReasonUpdateCycleForcedOff:  AreaReconfigure,
+	ReasonUpdateCyclePoweringOn: AreaReconfigure,
+	ReasonUpdateCycleCompleted:  AreaReconfigure,
+	ReasonUpdateCycleFailed:     AreaReconfigure,
+
+	ReasonReconfigureBlockedBySnapshots: AreaReconfigure,
+
+	ReasonSnapshotReady:                 AreaSnapshotLifecycle,
+	ReasonSnapshotFailed:                AreaSnapshotLifecycle,
+	ReasonSnapshotUnsupportedByProvider: AreaSnapshotLifecycle,
+	ReasonSnapshotDeleting:              AreaSnapshotLifecycle,
+	ReasonSnapshotDeleted:               AreaSnapshotLifecycle,
+	ReasonSnapshotDeleteFailed:          AreaSnapshotLifecycle,
+	ReasonSnapshotRetained:              AreaSnapshotLifecycle,
+	ReasonDeletionBlockedBySnapshots:    AreaSnapshotLifecycle,
+	ReasonSnapshotQuiesceFailed:         AreaSnapshotLifecycle,
+	ReasonSnapshotQuiesceDegraded:       AreaSnapshotLifecycle,
+	ReasonSnapshotChainLong:             AreaSnapshotLifecycle,
+
+	ReasonCloneCompleted: AreaCloneLifecycle,
+	ReasonCloneFailed:    AreaCloneLifecycle,
+
+	ReasonImagePublishQuiescing: AreaImageLifecycle,
+	ReasonImagePublished:        AreaImageLifecycle,
+	ReasonImagePublishFailed:    AreaImageLifecycle,
+
+	ReasonMigrationValidationStarted:  AreaMigrationLifecycle,
+	ReasonMigrationValidationComplete: AreaMigrationLifecycle,
+	ReasonMigrationSourcePowerOff:     AreaMigrationLifecycle,
+	ReasonMigrationSnapshotComplete:   AreaMigrationLifecycle,
+	ReasonMigrationPVCCreated:         AreaMigrationLifecycle,
+	ReasonMigrationExportComplete:     AreaMigrationLifecycle,
+	ReasonMigrationTransferComplete:   AreaMigrationLifecycle,
+	ReasonMigrationConversionComplete: AreaMigrationLifecycle,
+	ReasonMigrationImportComplete:     AreaMigrationLifecycle,
+	ReasonMigrationTargetVMCreated:    AreaMigrationLifecycle,
+	ReasonMigrationComplete:           AreaMigrationLifecycle,
+	ReasonMigrationRetrying:           AreaMigrationLifecycle,
+	ReasonMigrationFailed:             AreaMigrationLifecycle,
+	ReasonHostMigrationStarted:        AreaMigrationLifecycle,
+	ReasonHostMigrationSucceeded:      AreaMigrationLifecycle,
+	ReasonHostMigrationFailed:         AreaMigrationLifecycle,
+
+	ReasonProviderHealthy:      AreaProviderHealth,
+	ReasonProviderUnhealthy:    AreaProviderHealth,
+	ReasonProviderIncompatible: AreaProviderHealth,
+
+	ReasonProviderMissingCapabilities: AreaProviderHealth,
+
+	ReasonProviderEndpointMigrating:  AreaProviderHealth,
+	ReasonProviderEndpointVerified:   AreaProviderHealth,
+	ReasonProviderEndpointUnverified: AreaProviderHealth,
+
+	ReasonProviderMaintenanceStarted: AreaProviderHealth,
+	ReasonProviderMaintenanceEnded:   AreaProviderHealth,
+	ReasonProviderInMaintenance:      AreaProviderHealth,
+
+	ReasonProviderConflictPersisting: AreaProviderHealth,
+
+	ReasonProviderBusy:         AreaProviderHealth,
+	ReasonProviderBusyExceeded: AreaProviderHealth,
+
+	ReasonProviderCredentialsCopied: AreaProviderHealth,
+	ReasonProviderCredentialsSynced: AreaProviderHealth,
+	ReasonProviderCredentialsDenied: AreaProviderHealth,
+
+	ReasonVMDeleted:          AreaCleanup,
+	ReasonVMDeleteFailed:     AreaCleanup,
+	ReasonProviderVMRetained: AreaCleanup,
+	ReasonCleanupComplete:    AreaCleanup,
+	ReasonCleanupErrors:      AreaCleanup,
+	ReasonDeletionBlocked:    AreaCleanup,
+
+	ReasonArtifactCleanupScheduled: AreaCleanup,
+	ReasonArtifactCleanedUp:        AreaCleanup,
+	ReasonArtifactCleanupAbandoned: AreaCleanup,
+
+	ReasonConfigApplied:         AreaConfiguration,
+	ReasonConfigInvalid:         AreaConfiguration,
+	ReasonConfigRestartRequired: AreaConfiguration,
+	ReasonReconciliationPaused:  AreaConfiguration,
+	ReasonReconciliationResumed: AreaConfiguration,
+	ReasonPlanComputed:          AreaConfiguration,
+
+	ReasonQuotaExceeded: AreaQuota,
+
+	ReasonHypervisorEvent:        AreaHypervisor,
+	ReasonHypervisorAlarm:        AreaHypervisor,
+	ReasonHypervisorAlarmCleared: AreaHypervisor,
+}
+
+// AreaOf returns the area of reason, and false for a reason outside the
+// taxonomy.
+func AreaOf(reason string) (Area, bool) {
+	area, ok := reasonAreas[reason]
+	return area, ok
+}
+
+// Message appends the object's phase and the correlation ID carried by ctx
+// to message, e.g. "Snapshot created (phase: Ready, correlationID:
+// vmsnapshot-default/nightly)". Either is left out when empty.
+func Message(ctx context.Context, phase, message string) string {
+	var details []string
+	if phase != "" {
+		details = append(details, "phase: "+phase)
+	}
+	if id := logging.CorrelationIDFromContext(ctx); id != "" {
+		details = append(details, "correlationID: "+id)
+	}
+	if len(details) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+}
+
+// Emit records an event on obj with Message's phase and correlation
+// details. A nil recorder records nothing, so reconcilers built without
+// one in tests need no guard.
+func Emit(ctx context.Context, recorder record.EventRecorder, obj runtime.Object, eventType, reason, phase, message string) {
+	if recorder == nil {
+		return
+	}
+	recorder.Event(obj, eventType, reason, Message(ctx, phase, message))
+}
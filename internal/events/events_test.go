@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+)
+
+func TestEveryReasonHasAnArea(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "events.go", nil, 0)
+	require.NoError(t, err)
+
+	var reasons int
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "Reason") {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				require.True(t, ok, "%s must be a string literal", name.Name)
+				value, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				_, ok = AreaOf(value)
+				assert.True(t, ok, "%s has no area in reasonAreas", name.Name)
+				reasons++
+			}
+		}
+	}
+	assert.Equal(t, len(reasonAreas), reasons, "reasonAreas lists a reason that is not a constant")
+
+	_, ok := AreaOf("NotAReason")
+	assert.False(t, ok)
+}
+
+func TestMessage(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "done", Message(ctx, "", "done"))
+	assert.Equal(t, "done (phase: Ready)", Message(ctx, "Ready", "done"))
+
+	ctx = logging.WithCorrelationID(ctx, "vm-default/web")
+	assert.Equal(t, "done (correlationID: vm-default/web)", Message(ctx, "", "done"))
+	assert.Equal(t, "done (phase: Ready, correlationID: vm-default/web)", Message(ctx, "Ready", "done"))
+}
+
+func TestEmit(t *testing.T) {
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm"}}
+	ctx := logging.WithCorrelationID(context.Background(), "id-1")
+
+	recorder := record.NewFakeRecorder(1)
+	Emit(ctx, recorder, obj, corev1.EventTypeNormal, ReasonConfigApplied, "Active", "applied")
+	assert.Equal(t, "Normal ConfigApplied applied (phase: Active, correlationID: id-1)", <-recorder.Events)
+
+	assert.NotPanics(t, func() {
+		Emit(ctx, nil, obj, corev1.EventTypeNormal, ReasonConfigApplied, "", "applied")
+	})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/consoleproxy"
+)
+
+// createConsole issues a console session for a VM. Opening a console is
+// authorized as get on the virtualmachines/console subresource, so it can
+// be granted apart from reading the VM.
+func (s *Server) createConsole(w http.ResponseWriter, r *http.Request, caller user.Info) {
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if !s.authorize(w, r, caller, "get", "virtualmachines/console", key.Namespace, key.Name) {
+		return
+	}
+
+	vm := &infrav1beta1.VirtualMachine{}
+	if err := s.Reader.Get(r.Context(), key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("VirtualMachine %s not found", key))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get VirtualMachine %s: %v", key, err))
+		return
+	}
+
+	session, err := s.Console.Issue(r.Context(), vm, caller, r.RemoteAddr)
+	if errors.Is(err, consoleproxy.ErrUnavailable) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to open the console of %s: %v", key, err))
+		return
+	}
+	writeJSON(w, http.StatusCreated, ConsoleSession{
+		URL:       consoleURL(r, session.Token),
+		Protocol:  session.Protocol,
+		ExpiresAt: session.ExpiresAt.UTC(),
+	})
+}
+
+// connectConsole connects the WebSocket of a console session.
+func (s *Server) connectConsole(w http.ResponseWriter, r *http.Request) {
+	session, ok := s.Console.Take(r.PathValue("token"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "console session not found; it may have expired or been used")
+		return
+	}
+	upstream, err := s.Console.Dial(r.Context(), session, consoleproxy.OfferedProtocols(r))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to connect to the console of %s: %v", session.VM, err))
+		return
+	}
+	s.Console.Serve(w, r, session, upstream)
+}
+
+// consoleURL returns the WebSocket URL of the session named token, on the
+// host the caller reached the API at. Behind a TLS-terminating proxy the
+// scheme follows X-Forwarded-Proto.
+func consoleURL(r *http.Request, token string) string {
+	u := url.URL{Scheme: "ws", Host: r.Host, Path: "/api/v1/console/" + token}
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		u.Scheme = "wss"
+	}
+	return u.String()
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// route documents one operation of the API. The OpenAPI document is
+// generated from routes and the Go types of their responses, so it cannot
+// drift from the handlers.
+type route struct {
+	path string
+	// method is the route's lowercase HTTP method; get when empty.
+	method string
+	// created routes answer 201 rather than 200.
+	created     bool
+	operationID string
+	summary     string
+	// list routes take the namespace, labelSelector, limit and continue
+	// query parameters.
+	list bool
+	// pathParams are the route's {placeholders}, in order.
+	pathParams []string
+	response   any
+}
+
+var routes = []route{
+	{
+		path:        "/api/v1/vms",
+		operationID: "listVMs",
+		summary:     "List VirtualMachines. Requires list on virtualmachines in the namespace, or cluster-wide without one.",
+		list:        true,
+		response:    VMList{},
+	},
+	{
+		path:        "/api/v1/vms/{namespace}/{name}",
+		operationID: "getVM",
+		summary:     "Get a VirtualMachine. Requires get on the virtualmachine.",
+		pathParams:  []string{"namespace", "name"},
+		response:    VM{},
+	},
+	{
+		path:        "/api/v1/providers",
+		operationID: "listProviders",
+		summary:     "List Providers. Requires list on providers in the namespace, or cluster-wide without one.",
+		list:        true,
+		response:    ProviderList{},
+	},
+	{
+		path:        "/api/v1/vms/{namespace}/{name}/console",
+		method:      "post",
+		created:     true,
+		operationID: "createConsoleSession",
+		summary: "Issue a session for the VM's graphical console, to be connected with a VNC or WebMKS client within seconds. " +
+			"Requires get on virtualmachines/console. Served when the manager runs with --enable-console-proxy.",
+		pathParams: []string{"namespace", "name"},
+		response:   ConsoleSession{},
+	},
+}
+
+// listParameters are the query parameters of list routes.
+var listParameters = []map[string]any{
+	queryParameter("namespace", "Only list objects in this namespace.", map[string]any{"type": "string"}),
+	queryParameter("labelSelector", "Only list objects whose labels match this Kubernetes label selector.", map[string]any{"type": "string"}),
+	queryParameter("limit", "The maximum number of items to return.", map[string]any{
+		"type": "integer", "minimum": 1, "maximum": MaxLimit, "default": DefaultLimit,
+	}),
+	queryParameter("continue", "The continue token of the previous page.", map[string]any{"type": "string"}),
+}
+
+func queryParameter(name, description string, schema map[string]any) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": schema}
+}
+
+// openAPIDocument is the OpenAPI 3 document of the API, served at
+// /api/v1/openapi.json.
+var openAPIDocument = mustMarshal(OpenAPI())
+
+// OpenAPI returns the OpenAPI 3 document describing the API.
+func OpenAPI() map[string]any {
+	schemas := map[string]any{}
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": schemaRef(reflect.TypeFor[Error](), schemas)},
+		},
+	}
+
+	paths := map[string]any{}
+	for _, rt := range routes {
+		var params []map[string]any
+		for _, name := range rt.pathParams {
+			params = append(params, map[string]any{"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+		}
+		if rt.list {
+			params = append(params, listParameters...)
+		}
+		code, description := "200", "OK"
+		if rt.created {
+			code, description = "201", "Created"
+		}
+		op := map[string]any{
+			"operationId": rt.operationID,
+			"summary":     rt.summary,
+			"security":    []map[string]any{{"bearerAuth": []string{}}},
+			"responses": map[string]any{
+				code: map[string]any{
+					"description": description,
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": schemaRef(reflect.TypeOf(rt.response), schemas)},
+					},
+				},
+				"400":     errorResponse,
+				"401":     errorResponse,
+				"403":     errorResponse,
+				"default": errorResponse,
+			},
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		method := rt.method
+		if method == "" {
+			method = "get"
+		}
+		paths[rt.path] = map[string]any{method: op}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "virtrigaud manager API",
+			"description": "Read-only view of VirtualMachines and Providers, and VM console sessions. Requests are authenticated with a Kubernetes bearer token and authorized against the underlying resources.",
+			"version":     "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemaRef returns the schema of t, adding the schemas of the structs it
+// uses to schemas and referring to them.
+func schemaRef(t reflect.Type, schemas map[string]any) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct:
+		if _, ok := schemas[t.Name()]; !ok {
+			schemas[t.Name()] = nil // guards recursion
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	case t.Kind() == reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaRef(t.Elem(), schemas)}
+	case t.Kind() == reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaRef(t.Elem(), schemas)}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() == reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case t.Kind() == reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	}
+	panic("httpapi: no OpenAPI schema for " + t.String())
+}
+
+// structSchema returns the object schema of struct t from its json tags.
+// Fields without omitempty are required.
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for field := range t.Fields() {
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		properties[name] = schemaRef(field.Type, schemas)
+		if opts != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPIDocument)
+}
+
+func mustMarshal(v any) []byte {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return append(data, '\n')
+}
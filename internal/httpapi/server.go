@@ -0,0 +1,378 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpapi serves a read-only JSON view of VirtualMachines and
+// Providers for dashboards that should not need kubectl access, and, when
+// a console proxy is configured, issues VM console sessions.
+//
+// Objects are read from the manager's informer cache, so requests add no
+// load on the API server beyond authenticating the caller with a
+// TokenReview and authorizing them with a SubjectAccessReview against the
+// resource they read, both cached. RBAC on the CRs therefore applies
+// unchanged: a user who cannot list VirtualMachines in a namespace cannot
+// list them here either.
+package httpapi
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/consoleproxy"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// DefaultLimit is the page size when a list request gives no limit.
+	DefaultLimit = 100
+	// MaxLimit is the largest page size a list request may ask for.
+	MaxLimit = 500
+)
+
+// Server serves the API. It is a manager.Runnable.
+type Server struct {
+	// Addr is the address to listen on.
+	Addr string
+	// Reader reads the objects served; the manager's cache.
+	Reader client.Reader
+	// Authenticator identifies the caller from their bearer token.
+	Authenticator authenticator.Request
+	// Authorizer decides whether the caller may read a resource.
+	Authorizer authorizer.Authorizer
+	// TLSConfig, when set, makes the server serve HTTPS.
+	TLSConfig *tls.Config
+	// Console, when set, serves VM console sessions. Its sessions are
+	// local to this replica.
+	Console *consoleproxy.Proxy
+}
+
+// NeedLeaderElection lets every manager replica serve the API.
+func (s *Server) NeedLeaderElection() bool { return false }
+
+// Start serves the API until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("httpapi")
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		TLSConfig:         s.TLSConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.Addr, err)
+	}
+	if s.TLSConfig != nil {
+		ln = tls.NewListener(ln, s.TLSConfig)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("Serving HTTP API", "address", ln.Addr().String(), "tls", s.TLSConfig != nil)
+		errCh <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Handler returns the API's routes. Every route but the OpenAPI document
+// requires authentication.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/openapi.json", serveOpenAPI)
+	mux.Handle("GET /api/v1/vms", s.authenticated(s.listVMs))
+	mux.Handle("GET /api/v1/vms/{namespace}/{name}", s.authenticated(s.getVM))
+	mux.Handle("GET /api/v1/providers", s.authenticated(s.listProviders))
+	if s.Console != nil {
+		mux.Handle("POST /api/v1/vms/{namespace}/{name}/console", s.authenticated(s.createConsole))
+		// The single-use session token authenticates the connection, as
+		// browsers cannot set headers on a WebSocket.
+		mux.HandleFunc("GET /api/v1/console/{token}", s.connectConsole)
+	}
+	return mux
+}
+
+// handlerFunc is a handler that runs once the caller is authenticated.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, caller user.Info)
+
+// authenticated wraps next with authentication of the caller.
+func (s *Server) authenticated(next handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok, err := s.Authenticator.AuthenticateRequest(r)
+		if err != nil {
+			logf.FromContext(r.Context()).Error(err, "Authentication failed")
+			writeError(w, http.StatusInternalServerError, "authentication failed")
+			return
+		}
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r, resp.User)
+	})
+}
+
+// authorize checks that caller may perform verb on the infra.virtrigaud.io
+// resource in namespace, named name unless empty, and writes the error
+// response when they may not. resource may name a subresource, as in
+// "virtualmachines/console".
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, caller user.Info, verb, resource, namespace, name string) bool {
+	resourceName, subresource, _ := strings.Cut(resource, "/")
+	attrs := authorizer.AttributesRecord{
+		User:            caller,
+		Verb:            verb,
+		Namespace:       namespace,
+		APIGroup:        infrav1beta1.GroupVersion.Group,
+		APIVersion:      infrav1beta1.GroupVersion.Version,
+		Resource:        resourceName,
+		Subresource:     subresource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, reason, err := s.Authorizer.Authorize(r.Context(), attrs)
+	if err != nil {
+		logf.FromContext(r.Context()).Error(err, "Authorization failed", "user", caller.GetName())
+		writeError(w, http.StatusInternalServerError, "authorization failed")
+		return false
+	}
+	if decision != authorizer.DecisionAllow {
+		scope := "cluster scope"
+		if namespace != "" {
+			scope = fmt.Sprintf("namespace %q", namespace)
+		}
+		message := fmt.Sprintf("user %q cannot %s %s in %s", caller.GetName(), verb, resource, scope)
+		if reason != "" {
+			message += ": " + reason
+		}
+		writeError(w, http.StatusForbidden, message)
+		return false
+	}
+	return true
+}
+
+// listQuery is the query of a list request.
+type listQuery struct {
+	namespace string
+	selector  labels.Selector
+	limit     int
+	// after is the namespace/name of the last item of the previous page.
+	after string
+}
+
+// parseListQuery parses the namespace, labelSelector, limit and continue
+// parameters.
+func parseListQuery(r *http.Request) (listQuery, error) {
+	q := r.URL.Query()
+	query := listQuery{namespace: q.Get("namespace"), limit: DefaultLimit}
+
+	selector, err := labels.Parse(q.Get("labelSelector"))
+	if err != nil {
+		return listQuery{}, fmt.Errorf("invalid labelSelector: %w", err)
+	}
+	query.selector = selector
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return listQuery{}, fmt.Errorf("invalid limit %q: must be between 1 and %d", v, MaxLimit)
+		}
+		query.limit = limit
+	}
+
+	if v := q.Get("continue"); v != "" {
+		after, err := base64.RawURLEncoding.DecodeString(v)
+		if err != nil || !strings.Contains(string(after), "/") {
+			return listQuery{}, fmt.Errorf("invalid continue token")
+		}
+		query.after = string(after)
+	}
+	return query, nil
+}
+
+// listOptions returns the options listing the objects query selects.
+func (q listQuery) listOptions() []client.ListOption {
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: q.selector}}
+	if q.namespace != "" {
+		opts = append(opts, client.InNamespace(q.namespace))
+	}
+	return opts
+}
+
+// page sorts items by namespace and name and returns the query's page of
+// them, with the continue token of the next page.
+func page[T client.Object](items []T, q listQuery) ([]T, string) {
+	key := func(obj T) string { return obj.GetNamespace() + "/" + obj.GetName() }
+	slices.SortFunc(items, func(a, b T) int { return cmp.Compare(key(a), key(b)) })
+	if q.after != "" {
+		start, _ := slices.BinarySearchFunc(items, q.after, func(obj T, after string) int {
+			if key(obj) <= after {
+				return -1
+			}
+			return 1
+		})
+		items = items[start:]
+	}
+	if len(items) <= q.limit {
+		return items, ""
+	}
+	items = items[:q.limit]
+	return items, base64.RawURLEncoding.EncodeToString([]byte(key(items[len(items)-1])))
+}
+
+func (s *Server) listVMs(w http.ResponseWriter, r *http.Request, caller user.Info) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.authorize(w, r, caller, "list", "virtualmachines", query.namespace, "") {
+		return
+	}
+
+	var vms infrav1beta1.VirtualMachineList
+	if err := s.Reader.List(r.Context(), &vms, query.listOptions()...); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list VirtualMachines: %v", err))
+		return
+	}
+	items := make([]*infrav1beta1.VirtualMachine, 0, len(vms.Items))
+	for i := range vms.Items {
+		items = append(items, &vms.Items[i])
+	}
+	items, next := page(items, query)
+
+	providerTypes, err := s.providerTypes(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list Providers: %v", err))
+		return
+	}
+	out := VMList{Items: make([]VM, 0, len(items)), Continue: next}
+	for _, vm := range items {
+		out.Items = append(out.Items, vmFromObject(vm, providerTypes[providerKey(vm)]))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getVM(w http.ResponseWriter, r *http.Request, caller user.Info) {
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if !s.authorize(w, r, caller, "get", "virtualmachines", key.Namespace, key.Name) {
+		return
+	}
+
+	vm := &infrav1beta1.VirtualMachine{}
+	if err := s.Reader.Get(r.Context(), key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("VirtualMachine %s not found", key))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get VirtualMachine %s: %v", key, err))
+		return
+	}
+
+	providerType := ""
+	provider := &infrav1beta1.Provider{}
+	if err := s.Reader.Get(r.Context(), providerKey(vm), provider); err == nil {
+		providerType = string(provider.Spec.Type)
+	}
+	writeJSON(w, http.StatusOK, vmFromObject(vm, providerType))
+}
+
+func (s *Server) listProviders(w http.ResponseWriter, r *http.Request, caller user.Info) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.authorize(w, r, caller, "list", "providers", query.namespace, "") {
+		return
+	}
+
+	var providers infrav1beta1.ProviderList
+	if err := s.Reader.List(r.Context(), &providers, query.listOptions()...); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list Providers: %v", err))
+		return
+	}
+	items := make([]*infrav1beta1.Provider, 0, len(providers.Items))
+	for i := range providers.Items {
+		items = append(items, &providers.Items[i])
+	}
+	items, next := page(items, query)
+
+	out := ProviderList{Items: make([]Provider, 0, len(items)), Continue: next}
+	for _, p := range items {
+		out.Items = append(out.Items, providerFromObject(p))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// providerTypes returns the type of every Provider, keyed by namespaced
+// name. A VM may reference a Provider in another namespace, so all
+// namespaces are read. The caller is not authorized against Providers: a
+// VM's provider type is part of the VM's projection.
+func (s *Server) providerTypes(ctx context.Context) (map[types.NamespacedName]string, error) {
+	var providers infrav1beta1.ProviderList
+	if err := s.Reader.List(ctx, &providers); err != nil {
+		return nil, err
+	}
+	out := make(map[types.NamespacedName]string, len(providers.Items))
+	for _, p := range providers.Items {
+		out[types.NamespacedName{Namespace: p.Namespace, Name: p.Name}] = string(p.Spec.Type)
+	}
+	return out, nil
+}
+
+// providerKey returns the namespaced name of the Provider vm references.
+func providerKey(vm *infrav1beta1.VirtualMachine) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: cmp.Or(vm.Spec.ProviderRef.Namespace, vm.Namespace),
+		Name:      vm.Spec.ProviderRef.Name,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Error{Message: message})
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/consoleproxy"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite docs/http-api.openapi.json")
+
+// testServer serves objs. The bearer token is the user name; grants maps
+// "user verb resource namespace" to allowed, with an empty namespace for
+// cluster scope and "resource/subresource" for a subresource.
+func testServer(t *testing.T, grants map[string]bool, objs ...client.Object) http.Handler {
+	t.Helper()
+	return newTestServer(t, grants, objs...).Handler()
+}
+
+func newTestServer(t *testing.T, grants map[string]bool, objs ...client.Object) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	s := &Server{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Authenticator: authenticator.RequestFunc(func(r *http.Request) (*authenticator.Response, bool, error) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				return nil, false, nil
+			}
+			return &authenticator.Response{User: &user.DefaultInfo{Name: token}}, true, nil
+		}),
+		Authorizer: authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			if a.GetAPIGroup() != infrav1beta1.GroupVersion.Group {
+				return authorizer.DecisionDeny, "wrong group", nil
+			}
+			resource := a.GetResource()
+			if a.GetSubresource() != "" {
+				resource += "/" + a.GetSubresource()
+			}
+			if grants[strings.Join([]string{a.GetUser().GetName(), a.GetVerb(), resource, a.GetNamespace()}, " ")] {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionNoOpinion, "", nil
+		}),
+	}
+	return s
+}
+
+// get requests path as user and decodes the response into out.
+func get(t *testing.T, h http.Handler, user, path string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if user != "" {
+		req.Header.Set("Authorization", "Bearer "+user)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func testVM(namespace, name, app string) *infrav1beta1.VirtualMachine {
+	return &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"app": app}},
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ProviderRef: infrav1beta1.ObjectRef{Name: "pve"},
+			ClassRef:    infrav1beta1.ObjectRef{Name: "small"},
+			ImageRef:    &infrav1beta1.ObjectRef{Name: "ubuntu"},
+		},
+		Status: infrav1beta1.VirtualMachineStatus{
+			Phase:            infrav1beta1.VirtualMachinePhaseRunning,
+			IPs:              []string{"10.0.0.5"},
+			Host:             "pve-a",
+			CurrentResources: &infrav1beta1.VirtualMachineResources{CPU: ptr.To[int32](2), MemoryMiB: ptr.To[int64](4096)},
+			Conditions: []metav1.Condition{{
+				Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconcileSuccess",
+			}},
+		},
+	}
+}
+
+func testProvider(namespace string) *infrav1beta1.Provider {
+	return &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "pve"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeProxmox, Endpoint: "https://pve.example.com:8006"},
+		Status: infrav1beta1.ProviderStatus{
+			Healthy: true,
+			Runtime: &infrav1beta1.ProviderRuntimeStatus{Phase: "Running"},
+		},
+	}
+}
+
+func TestListVMs_Pagination(t *testing.T) {
+	var objs []client.Object
+	for _, ns := range []string{"b", "a"} {
+		for i := range 3 {
+			objs = append(objs, testVM(ns, fmt.Sprintf("vm-%d", i), "web"))
+		}
+	}
+	objs = append(objs, testProvider("a"))
+	h := testServer(t, map[string]bool{"alice list virtualmachines ": true}, objs...)
+
+	var names []string
+	path := "/api/v1/vms?limit=4"
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		var list VMList
+		require.Equal(t, http.StatusOK, get(t, h, "alice", path, &list))
+		for _, vm := range list.Items {
+			names = append(names, vm.Namespace+"/"+vm.Name)
+		}
+		if list.Continue == "" {
+			break
+		}
+		path = "/api/v1/vms?limit=4&continue=" + list.Continue
+	}
+	assert.Equal(t, []string{"a/vm-0", "a/vm-1", "a/vm-2", "b/vm-0", "b/vm-1", "b/vm-2"}, names)
+
+	var bad Error
+	assert.Equal(t, http.StatusBadRequest, get(t, h, "alice", "/api/v1/vms?limit=0", &bad))
+	assert.Contains(t, bad.Message, "invalid limit")
+	assert.Equal(t, http.StatusBadRequest, get(t, h, "alice", "/api/v1/vms?continue=!!", nil))
+}
+
+func TestListVMs_Projection(t *testing.T) {
+	h := testServer(t, map[string]bool{"alice list virtualmachines a": true},
+		testVM("a", "web-0", "web"), testVM("a", "db-0", "db"), testVM("b", "db-0", "db"), testProvider("a"))
+
+	var list VMList
+	require.Equal(t, http.StatusOK, get(t, h, "alice", "/api/v1/vms?namespace=a&labelSelector=app%3Ddb", &list))
+	require.Len(t, list.Items, 1)
+	vm := list.Items[0]
+	assert.Equal(t, "db-0", vm.Name)
+	assert.Equal(t, "proxmox", vm.ProviderType)
+	assert.Equal(t, "ubuntu", vm.Image)
+	assert.Equal(t, "Running", vm.Phase)
+	assert.Equal(t, []string{"10.0.0.5"}, vm.IPs)
+	assert.Equal(t, "pve-a", vm.Host)
+	assert.Equal(t, int32(2), vm.CPU)
+	assert.Equal(t, int64(4096), vm.MemoryMiB)
+	require.Len(t, vm.Conditions, 1)
+	assert.Equal(t, "Ready", vm.Conditions[0].Type)
+
+	assert.Equal(t, http.StatusBadRequest, get(t, h, "alice", "/api/v1/vms?namespace=a&labelSelector=app%3D%3D%3D", nil))
+}
+
+func TestAuthorization(t *testing.T) {
+	h := testServer(t, map[string]bool{
+		"alice list virtualmachines a": true,
+		"alice get virtualmachines a":  true,
+	}, testVM("a", "vm-0", "web"), testVM("b", "vm-0", "web"), testProvider("a"))
+
+	assert.Equal(t, http.StatusUnauthorized, get(t, h, "", "/api/v1/vms", nil))
+
+	var denied Error
+	assert.Equal(t, http.StatusForbidden, get(t, h, "alice", "/api/v1/vms", &denied))
+	assert.Contains(t, denied.Message, "cannot list virtualmachines in cluster scope")
+	assert.Equal(t, http.StatusOK, get(t, h, "alice", "/api/v1/vms?namespace=a", nil))
+	assert.Equal(t, http.StatusForbidden, get(t, h, "alice", "/api/v1/vms?namespace=b", nil))
+	assert.Equal(t, http.StatusForbidden, get(t, h, "alice", "/api/v1/providers?namespace=a", nil))
+
+	var vm VM
+	assert.Equal(t, http.StatusOK, get(t, h, "alice", "/api/v1/vms/a/vm-0", &vm))
+	assert.Equal(t, "proxmox", vm.ProviderType)
+	assert.Equal(t, http.StatusForbidden, get(t, h, "alice", "/api/v1/vms/b/vm-0", nil))
+	assert.Equal(t, http.StatusNotFound, get(t, h, "alice", "/api/v1/vms/a/missing", nil))
+	assert.Equal(t, http.StatusForbidden, get(t, h, "bob", "/api/v1/vms/a/missing", nil),
+		"existence is not revealed to callers who cannot read it")
+
+	// Only GET is routed.
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/vms/a/vm-0", nil)
+	req.Header.Set("Authorization", "Bearer alice")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestListProviders(t *testing.T) {
+	h := testServer(t, map[string]bool{"alice list providers ": true}, testProvider("a"), testProvider("b"))
+
+	var list ProviderList
+	require.Equal(t, http.StatusOK, get(t, h, "alice", "/api/v1/providers", &list))
+	require.Len(t, list.Items, 2)
+	p := list.Items[0]
+	assert.Equal(t, "a", p.Namespace)
+	assert.Equal(t, "proxmox", p.Type)
+	assert.True(t, p.Healthy)
+	assert.Equal(t, "Running", p.Phase)
+}
+
+// TestOpenAPI checks the served document against the copy in docs. Run
+// with -update to rewrite it.
+func TestOpenAPI(t *testing.T) {
+	h := testServer(t, nil)
+	var doc map[string]any
+	require.Equal(t, http.StatusOK, get(t, h, "", "/api/v1/openapi.json", &doc), "served without authentication")
+	assert.Contains(t, doc["paths"], "/api/v1/vms/{namespace}/{name}")
+
+	const golden = "../../docs/http-api.openapi.json"
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, openAPIDocument, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run go test -update to create %s", golden)
+	assert.Equal(t, string(want), string(openAPIDocument))
+}
+
+// TestCreateConsole verifies opening a console needs get on the console
+// subresource, not just on the VM, and that the session URL is a WebSocket
+// URL on the API's own host.
+func TestCreateConsole(t *testing.T) {
+	vm := testVM("team-a", "web-1", "web")
+	vm.Status.ID = "101"
+	vm.Status.PowerState = infrav1beta1.PowerStateOn
+	provider := testProvider("team-a")
+	provider.Status.ReportedCapabilities = &infrav1beta1.ReportedCapabilities{SupportsConsoleProxy: true}
+	s := newTestServer(t, map[string]bool{
+		"viewer get virtualmachines team-a":           true,
+		"operator get virtualmachines/console team-a": true,
+	}, vm, provider)
+	s.Console = consoleproxy.New(s.Reader, nil)
+	h := s.Handler()
+
+	post := func(user string, out any) int {
+		req := httptest.NewRequest(http.MethodPost, "https://api.example.com/api/v1/vms/team-a/web-1/console", nil)
+		req.Header.Set("Authorization", "Bearer "+user)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+		return rec.Code
+	}
+
+	var e Error
+	assert.Equal(t, http.StatusForbidden, post("viewer", &e))
+	assert.Contains(t, e.Message, "virtualmachines/console")
+
+	var session ConsoleSession
+	require.Equal(t, http.StatusCreated, post("operator", &session))
+	assert.Equal(t, "vnc", session.Protocol)
+	assert.Regexp(t, `^wss://api\.example\.com/api/v1/console/[A-Za-z0-9_-]{43}$`, session.URL)
+
+	assert.Equal(t, http.StatusNotFound, get(t, h, "", "/api/v1/console/unknown", &e))
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpapi
+
+import (
+	"cmp"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// VM is the projection of a VirtualMachine the API returns.
+type VM struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+
+	// Provider is the name of the Provider the VM runs on, and
+	// ProviderType its type, empty when the Provider does not exist.
+	Provider          string `json:"provider"`
+	ProviderType      string `json:"providerType,omitempty"`
+	Class             string `json:"class"`
+	Image             string `json:"image,omitempty"`
+	DesiredPowerState string `json:"desiredPowerState,omitempty"`
+
+	Phase      string `json:"phase,omitempty"`
+	PowerState string `json:"powerState,omitempty"`
+	// CPU and MemoryMiB are the resources the VM was last configured with.
+	CPU        int32       `json:"cpu,omitempty"`
+	MemoryMiB  int64       `json:"memoryMiB,omitempty"`
+	IPs        []string    `json:"ips,omitempty"`
+	PrimaryIP  string      `json:"primaryIP,omitempty"`
+	Host       string      `json:"host,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// VMList is a page of VMs.
+type VMList struct {
+	Items []VM `json:"items"`
+	// Continue, when set, is passed as the continue parameter to fetch the
+	// next page.
+	Continue string `json:"continue,omitempty"`
+}
+
+// Provider is the projection of a Provider the API returns.
+type Provider struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+
+	Type     string `json:"type"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	Healthy bool `json:"healthy"`
+	// Phase is the phase of the provider's runtime.
+	Phase             string      `json:"phase,omitempty"`
+	Version           string      `json:"version,omitempty"`
+	Hypervisor        string      `json:"hypervisor,omitempty"`
+	HypervisorVersion string      `json:"hypervisorVersion,omitempty"`
+	ConnectedVMs      int32       `json:"connectedVMs,omitempty"`
+	Conditions        []Condition `json:"conditions,omitempty"`
+}
+
+// ProviderList is a page of Providers.
+type ProviderList struct {
+	Items []Provider `json:"items"`
+	// Continue, when set, is passed as the continue parameter to fetch the
+	// next page.
+	Continue string `json:"continue,omitempty"`
+}
+
+// Condition is a status condition of a VM or Provider.
+type Condition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// ConsoleSession is a console session issued to the caller.
+type ConsoleSession struct {
+	// URL is the WebSocket URL to connect the console client to. It can be
+	// connected once, before ExpiresAt, and needs no other credentials.
+	URL string `json:"url"`
+	// Protocol is the console's protocol, "vnc" or "webmks", which decides
+	// the client to connect with.
+	Protocol  string    `json:"protocol"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Error is the body of every response with a non-2xx status.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// vmFromObject projects vm. providerType is the type of the Provider it
+// references, if known.
+func vmFromObject(vm *infrav1beta1.VirtualMachine, providerType string) VM {
+	out := VM{
+		Namespace:         vm.Namespace,
+		Name:              vm.Name,
+		Labels:            vm.Labels,
+		CreatedAt:         vm.CreationTimestamp.UTC(),
+		Provider:          vm.Spec.ProviderRef.Name,
+		ProviderType:      providerType,
+		Class:             vm.Spec.ClassRef.Name,
+		DesiredPowerState: string(vm.Spec.PowerState),
+		Phase:             string(vm.Status.Phase),
+		PowerState:        string(vm.Status.PowerState),
+		IPs:               vm.Status.IPs,
+		PrimaryIP:         vm.Status.PrimaryIP,
+		Host:              vm.Status.Host,
+		Message:           vm.Status.Message,
+		Conditions:        conditions(vm.Status.Conditions),
+	}
+	if vm.Spec.ImageRef != nil {
+		out.Image = vm.Spec.ImageRef.Name
+	}
+	if res := vm.Status.CurrentResources; res != nil {
+		if res.CPU != nil {
+			out.CPU = *res.CPU
+		}
+		if res.MemoryMiB != nil {
+			out.MemoryMiB = *res.MemoryMiB
+		}
+	}
+	return out
+}
+
+// providerFromObject projects p.
+func providerFromObject(p *infrav1beta1.Provider) Provider {
+	out := Provider{
+		Namespace:    p.Namespace,
+		Name:         p.Name,
+		Labels:       p.Labels,
+		CreatedAt:    p.CreationTimestamp.UTC(),
+		Type:         string(p.Spec.Type),
+		Endpoint:     cmp.Or(p.Spec.Endpoint, p.Status.Endpoint),
+		Healthy:      p.Status.Healthy,
+		Version:      p.Status.Version,
+		ConnectedVMs: p.Status.ConnectedVMs,
+		Conditions:   conditions(p.Status.Conditions),
+	}
+	if p.Status.Runtime != nil {
+		out.Phase = string(p.Status.Runtime.Phase)
+	}
+	if p.Status.Hypervisor != nil {
+		out.Hypervisor = p.Status.Hypervisor.Product
+		out.HypervisorVersion = p.Status.Hypervisor.Version
+	}
+	return out
+}
+
+func conditions(in []metav1.Condition) []Condition {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Condition, 0, len(in))
+	for _, c := range in {
+		out = append(out, Condition{
+			Type:               c.Type,
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.UTC(),
+		})
+	}
+	return out
+}
@@ -54,6 +54,8 @@ const (
 	ReasonWaitingForDependencies = "WaitingForDependencies"
 	// ReasonTaskInProgress indicates async task in progress
 	ReasonTaskInProgress = "TaskInProgress"
+	// ReasonProviderThrottled indicates the provider asked for no new work yet
+	ReasonProviderThrottled = "ProviderThrottled"
 )
 
 // SetCondition sets a condition on the given list of conditions
@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels decides per logger whether an entry is logged. A logger is known
+// by its name ("provider.resolver" for ctrl.Log.WithName("provider").
+// WithName("resolver")) and, for controller-runtime reconcile loggers, by
+// "controller." and the controller's name ("controller.virtualmachine").
+// An override for a name also applies to the names below it; the longest
+// match wins, and loggers with none log at the base level. Overrides can be
+// replaced at any time, without rebuilding loggers.
+type Levels struct {
+	base      zapcore.LevelEnabler
+	overrides atomic.Pointer[map[string]zapcore.Level]
+}
+
+// NewLevels returns Levels logging at base unless overridden.
+func NewLevels(base zapcore.LevelEnabler) *Levels {
+	l := &Levels{base: base}
+	l.SetOverrides(nil)
+	return l
+}
+
+// SetOverrides replaces the per-logger levels.
+func (l *Levels) SetOverrides(overrides map[string]zapcore.Level) {
+	copied := make(map[string]zapcore.Level, len(overrides))
+	for name, level := range overrides {
+		copied[name] = level
+	}
+	l.overrides.Store(&copied)
+}
+
+// Enabled implements zapcore.LevelEnabler: it reports whether lvl is logged
+// by any logger. Cores built with Levels pass every such entry on to
+// WrapCore's core, which makes the per-logger decision.
+func (l *Levels) Enabled(lvl zapcore.Level) bool {
+	if l.base.Enabled(lvl) {
+		return true
+	}
+	for _, level := range *l.overrides.Load() {
+		if lvl >= level {
+			return true
+		}
+	}
+	return false
+}
+
+// EnabledFor reports whether a logger logs at lvl. name is the logger's
+// name and controller the name of the controller-runtime controller it
+// logs for, either of which may be empty. An override for name, which is
+// the more specific, wins over one for the controller.
+func (l *Levels) EnabledFor(name, controller string, lvl zapcore.Level) bool {
+	overrides := *l.overrides.Load()
+	if level, ok := lookupLevel(overrides, name); ok {
+		return lvl >= level
+	}
+	if controller != "" {
+		if level, ok := lookupLevel(overrides, "controller."+controller); ok {
+			return lvl >= level
+		}
+	}
+	return l.base.Enabled(lvl)
+}
+
+// lookupLevel returns the override for name or, failing that, for the
+// nearest name above it.
+func lookupLevel(overrides map[string]zapcore.Level, name string) (zapcore.Level, bool) {
+	for key := name; key != ""; {
+		if level, ok := overrides[key]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(key, '.')
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return 0, false
+}
+
+// WrapCore returns core filtered by l, for zap.WrapCore.
+func (l *Levels) WrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+// levelCore filters the entries of its Core by the level of the logger
+// that wrote them.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+	// controller is the name of the controller-runtime controller whose
+	// logger this is, from its "controller" field.
+	controller string
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.levels.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	controller := c.controller
+	for _, f := range fields {
+		if f.Key == "controller" && f.Type == zapcore.StringType {
+			controller = strings.ToLower(f.String)
+		}
+	}
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels, controller: controller}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.EnabledFor(ent.LoggerName, c.controller, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
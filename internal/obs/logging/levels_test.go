@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevels_EnabledFor(t *testing.T) {
+	levels := NewLevels(zapcore.InfoLevel)
+	levels.SetOverrides(map[string]zapcore.Level{
+		"provider":                  zapcore.ErrorLevel,
+		"provider.resolver":         zapcore.DebugLevel,
+		"controller.virtualmachine": zapcore.DebugLevel,
+	})
+
+	assert.True(t, levels.EnabledFor("provider.resolver", "", zapcore.DebugLevel))
+	assert.True(t, levels.EnabledFor("provider.resolver.dial", "", zapcore.DebugLevel), "an override applies below its name")
+	assert.False(t, levels.EnabledFor("provider.client", "", zapcore.InfoLevel), "the nearest override wins")
+	assert.True(t, levels.EnabledFor("", "virtualmachine", zapcore.DebugLevel))
+	assert.False(t, levels.EnabledFor("", "vmclone", zapcore.DebugLevel), "other controllers log at the base level")
+	assert.False(t, levels.EnabledFor("provider", "virtualmachine", zapcore.InfoLevel), "the logger name wins over the controller")
+	assert.True(t, levels.Enabled(zapcore.DebugLevel), "some logger logs debug")
+}
+
+func TestLevels_WrapCore(t *testing.T) {
+	levels := NewLevels(zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(levels.WrapCore(core))
+	vmLogger := logger.With(zap.String("controller", "VirtualMachine"))
+	resolver := logger.Named("provider").Named("resolver")
+
+	vmLogger.Debug("hidden")
+	resolver.Debug("hidden")
+	assert.Equal(t, 0, logs.Len())
+
+	levels.SetOverrides(map[string]zapcore.Level{
+		"controller.virtualmachine": zapcore.DebugLevel,
+		"provider.resolver":         zapcore.ErrorLevel,
+	})
+	vmLogger.Debug("reconciling")
+	resolver.Info("hidden")
+	resolver.Error("unreachable")
+	logger.Debug("hidden")
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"reconciling", "unreachable"}, messages, "overrides apply to existing loggers")
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -174,6 +175,55 @@ func WithCorrelationID(ctx context.Context, correlationID string) context.Contex
 	return context.WithValue(ctx, CorrelationIDKey, correlationID)
 }
 
+// Keys of the structured fields every controller logs, so log lines can be
+// joined on them whichever controller wrote them. controller-runtime's
+// reconcile logger already carries namespace and name; StartReconcile and
+// WithLogFields add the others.
+const (
+	KeyNamespace     = "namespace"
+	KeyName          = "name"
+	KeyProvider      = "provider"
+	KeyCorrelationID = "correlationID"
+	KeyPhase         = "phase"
+)
+
+// correlationOnLoggerKey marks a context whose logger already carries the
+// correlation ID, so FromContext does not add it twice.
+type correlationOnLoggerKey struct{}
+
+// StartReconcile sets the correlation ID of a reconcile of the object req
+// names to "<prefix>-<namespace>/<name>" and adds it to the context's
+// logger, so the reconcile's log lines, Events and provider RPCs all carry
+// it. Controllers call it first thing in Reconcile.
+func StartReconcile(ctx context.Context, prefix string, req types.NamespacedName) context.Context {
+	id := fmt.Sprintf("%s-%s/%s", prefix, req.Namespace, req.Name)
+	ctx = context.WithValue(WithCorrelationID(ctx, id), correlationOnLoggerKey{}, true)
+	return ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues(KeyCorrelationID, id))
+}
+
+// WithLogFields returns ctx whose logger also carries the provider and
+// phase of the object being reconciled. Empty values are left out.
+func WithLogFields(ctx context.Context, provider, phase string) context.Context {
+	var fields []interface{}
+	if provider != "" {
+		fields = append(fields, KeyProvider, provider)
+	}
+	if phase != "" {
+		fields = append(fields, KeyPhase, phase)
+	}
+	if len(fields) == 0 {
+		return ctx
+	}
+	return ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues(fields...))
+}
+
+// CorrelationIDFromContext returns the correlation ID set by
+// WithCorrelationID, or "" when there is none
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(CorrelationIDKey).(string)
+	return id
+}
+
 // WithTraceID adds trace ID to context
 func WithTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, TraceIDKey, traceID)
@@ -183,8 +233,8 @@ func WithTraceID(ctx context.Context, traceID string) context.Context {
 func enrichLogger(ctx context.Context, logger logr.Logger) logr.Logger {
 	fields := make([]interface{}, 0, 14) // Pre-allocate for typical usage
 
-	if val := ctx.Value(CorrelationIDKey); val != nil {
-		fields = append(fields, "correlationID", val)
+	if val := ctx.Value(CorrelationIDKey); val != nil && ctx.Value(correlationOnLoggerKey{}) == nil {
+		fields = append(fields, KeyCorrelationID, val)
 	}
 	if val := ctx.Value(TraceIDKey); val != nil {
 		fields = append(fields, "traceID", val)
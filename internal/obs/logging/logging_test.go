@@ -17,10 +17,14 @@ limitations under the License.
 package logging
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 // TestRedactStringValuesNotKeys is the regression canary for issue #95.
@@ -254,3 +258,20 @@ func TestRedactMapHidesS3MigrationCredentials(t *testing.T) {
 		assert.False(t, strings.Contains(joined, secret), "secret %q leaked", secret)
 	}
 }
+
+func TestStartReconcile(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+	ctx := ctrl.LoggerInto(context.Background(), logger)
+
+	ctx = StartReconcile(ctx, "vm", types.NamespacedName{Namespace: "apps", Name: "web"})
+	ctx = WithLogFields(ctx, "vsphere", "")
+	assert.Equal(t, "vm-apps/web", CorrelationIDFromContext(ctx))
+
+	FromContext(ctx).Info("reconciling")
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, 1, strings.Count(lines[0], `"correlationID"="vm-apps/web"`), lines[0])
+		assert.Contains(t, lines[0], `"provider"="vsphere"`)
+		assert.NotContains(t, lines[0], `"phase"`, "empty fields are left out")
+	}
+}
@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -115,6 +116,48 @@ var (
 		[]string{"provider_type", "provider"},
 	)
 
+	// providerInfo is an info-style gauge: always 1, with the provider build
+	// and hypervisor release as labels.
+	providerInfo = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_provider_info",
+			Help: "Provider build and hypervisor version reported by each Provider",
+		},
+		[]string{"namespace", "provider", "provider_type", "version", "git_sha", "hypervisor_product", "hypervisor_version"},
+	)
+
+	// providerCompatibility is 1 for each Provider, labelled with the
+	// protocol compatibility the manager negotiated with it, so
+	// count by (state) gives the number of providers in each state.
+	providerCompatibility = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_provider_compatibility",
+			Help: "Protocol compatibility of each Provider with the manager (Compatible, DegradedCompatibility or Incompatible)",
+		},
+		[]string{"namespace", "provider", "state"},
+	)
+
+	// providerRequeueInterval is the poll interval in force for the VMs of
+	// each Provider, by the state they are in: the manager's requeue
+	// configuration with the Provider's own intervals applied.
+	providerRequeueInterval = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_provider_requeue_interval_seconds",
+			Help: "Effective interval at which VMs of each Provider are described again, by VM state (running, powered_off, transitional, waiting_for_ip)",
+		},
+		[]string{"namespace", "provider", "state"},
+	)
+
+	// providerResponseViolationsTotal counts provider response values the
+	// manager dropped, replaced or truncated, by the rule they broke.
+	providerResponseViolationsTotal = registerer.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "virtrigaud_provider_response_violations_total",
+			Help: "Total number of provider response values sanitized by the manager by provider type, provider, and rule",
+		},
+		[]string{"provider_type", "provider", "rule"},
+	)
+
 	// Error metrics
 	errorsTotal = registerer.NewCounterVec(
 		prometheus.CounterOpts{
@@ -124,6 +167,33 @@ var (
 		[]string{"reason", "component"},
 	)
 
+	// API deprecation metrics. field is a DeprecatedFields path such as
+	// spec.networks[*].name, so the label set stays small.
+	deprecatedFieldUsageTotal = registerer.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "virtrigaud_deprecated_field_usage_total",
+			Help: "Total number of admitted creates and updates using a deprecated field, by kind and field",
+		},
+		[]string{"kind", "field"},
+	)
+
+	// TTL cleanup metrics. kind is VMMigration or VMClone.
+	ttlDeletedTotal = registerer.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "virtrigaud_ttl_deleted_total",
+			Help: "Total number of finished objects deleted after their ttlAfterCompletion, by kind",
+		},
+		[]string{"kind"},
+	)
+	ttlDeletionDelay = registerer.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "virtrigaud_ttl_deletion_delay_seconds",
+			Help:    "Time between an object's TTL expiring and its deletion, by kind",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 14), // 100ms to ~27m
+		},
+		[]string{"kind"},
+	)
+
 	// IP discovery metrics
 	ipDiscoveryDuration = registerer.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -150,13 +220,89 @@ var (
 		},
 		[]string{"provider_type", "provider"},
 	)
+
+	// VM allocation metrics. Values are absolute sums recomputed by the
+	// VirtualMachine controller, never incremented, so they converge after
+	// a manager restart once every VM has been reconciled.
+	vmAllocatedCPUCores = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_allocated_cpu_cores",
+			Help: "vCPUs allocated to VirtualMachines by provider and provider namespace",
+		},
+		[]string{"provider", "namespace"},
+	)
+
+	vmAllocatedMemoryBytes = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_allocated_memory_bytes",
+			Help: "Memory allocated to VirtualMachines by provider and provider namespace",
+		},
+		[]string{"provider", "namespace"},
+	)
+
+	vmPaused = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_paused",
+			Help: "Number of VirtualMachines whose reconciliation is paused, by namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	vmSnapshotChainLong = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_snapshot_chain_long",
+			Help: "Number of VirtualMachines whose snapshot chain is deeper than the configured warning depth, by provider and VM namespace",
+		},
+		[]string{"provider", "namespace"},
+	)
+
+	cleanupRecords = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_cleanup_records",
+			Help: "Number of provider-side artifacts left by failed operations that are recorded for cleanup, by artifact kind and state (pending or abandoned)",
+		},
+		[]string{"kind", "state"},
+	)
+	cleanupAttemptsTotal = registerer.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "virtrigaud_cleanup_attempts_total",
+			Help: "Total number of deletions of artifacts left by failed operations, by artifact kind and outcome",
+		},
+		[]string{"kind", "outcome"},
+	)
+
+	statusWritesTotal = registerer.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "virtrigaud_status_writes_total",
+			Help: "Total number of status writes a reconcile made or skipped because nothing meaningful changed, by resource kind and result",
+		},
+		[]string{"kind", "result"},
+	)
+
+	vmGuestCPUUsagePercent = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_guest_cpu_usage_percent",
+			Help: "CPU utilization of a VirtualMachine across all its vCPUs, as reported by its provider",
+		},
+		[]string{"namespace", "name", "provider_type"},
+	)
+
+	vmGuestMemoryUsageBytes = registerer.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "virtrigaud_vm_guest_memory_usage_bytes",
+			Help: "Memory in use by a VirtualMachine's guest, as reported by its provider",
+		},
+		[]string{"namespace", "name", "provider_type"},
+	)
 )
 
 // Outcomes for reconcile operations
 const (
-	OutcomeSuccess = "success"
-	OutcomeError   = "error"
-	OutcomeRequeue = "requeue"
+	OutcomeSuccess  = "success"
+	OutcomeError    = "error"
+	OutcomeRequeue  = "requeue"
+	OutcomeConflict = "conflict"
+	OutcomeBusy     = "busy"
 )
 
 // VM Operations
@@ -281,11 +427,30 @@ func (m *TaskMetrics) SetInflightTasks(count float64) {
 	providerTasksInflight.WithLabelValues(m.providerType, m.provider).Set(count)
 }
 
+// RecordProviderResponseViolation counts a provider response value that
+// broke rule
+func RecordProviderResponseViolation(providerType, provider, rule string) {
+	providerResponseViolationsTotal.WithLabelValues(providerType, provider, rule).Inc()
+}
+
 // RecordError records an error with its reason and component
 func RecordError(reason, component string) {
 	errorsTotal.WithLabelValues(reason, component).Inc()
 }
 
+// RecordDeprecatedFieldUsage counts an admitted object using a deprecated
+// field
+func RecordDeprecatedFieldUsage(kind, field string) {
+	deprecatedFieldUsageTotal.WithLabelValues(kind, field).Inc()
+}
+
+// RecordTTLDeletion counts a finished object deleted after its TTL, delay
+// after the TTL expired
+func RecordTTLDeletion(kind string, delay time.Duration) {
+	ttlDeletedTotal.WithLabelValues(kind).Inc()
+	ttlDeletionDelay.WithLabelValues(kind).Observe(delay.Seconds())
+}
+
 // RecordIPDiscovery records IP discovery duration
 func RecordIPDiscovery(providerType string, duration time.Duration) {
 	ipDiscoveryDuration.WithLabelValues(providerType).Observe(duration.Seconds())
@@ -315,6 +480,130 @@ func (m *CircuitBreakerMetrics) RecordFailure() {
 	circuitBreakerFailures.WithLabelValues(m.providerType, m.provider).Inc()
 }
 
+// SetProviderInfo records the build and hypervisor version of a Provider,
+// replacing any series it had with other versions
+func SetProviderInfo(namespace, provider, providerType, version, gitSHA, hypervisorProduct, hypervisorVersion string) {
+	DeleteProviderInfo(namespace, provider)
+	providerInfo.WithLabelValues(namespace, provider, providerType, version, gitSHA, hypervisorProduct, hypervisorVersion).Set(1)
+}
+
+// DeleteProviderInfo drops the info series of a Provider
+func DeleteProviderInfo(namespace, provider string) {
+	providerInfo.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "provider": provider})
+}
+
+// SetProviderCompatibility records the protocol compatibility of a
+// Provider, replacing the series of its previous state
+func SetProviderCompatibility(namespace, provider, state string) {
+	DeleteProviderCompatibility(namespace, provider)
+	providerCompatibility.WithLabelValues(namespace, provider, state).Set(1)
+}
+
+// DeleteProviderCompatibility drops the compatibility series of a Provider
+func DeleteProviderCompatibility(namespace, provider string) {
+	providerCompatibility.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "provider": provider})
+}
+
+// Values of the state label of virtrigaud_provider_requeue_interval_seconds
+const (
+	RequeueStateRunning      = "running"
+	RequeueStatePoweredOff   = "powered_off"
+	RequeueStateTransitional = "transitional"
+	RequeueStateWaitingForIP = "waiting_for_ip"
+)
+
+// SetProviderRequeueInterval records the interval in force for the VMs of
+// a Provider in one state
+func SetProviderRequeueInterval(namespace, provider, state string, interval time.Duration) {
+	providerRequeueInterval.WithLabelValues(namespace, provider, state).Set(interval.Seconds())
+}
+
+// DeleteProviderRequeueIntervals drops the interval series of a Provider
+func DeleteProviderRequeueIntervals(namespace, provider string) {
+	providerRequeueInterval.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "provider": provider})
+}
+
+// SetVMAllocation sets the total vCPUs and memory allocated to the VMs of
+// the provider in namespace
+func SetVMAllocation(provider, namespace string, cpuCores, memoryBytes float64) {
+	vmAllocatedCPUCores.WithLabelValues(provider, namespace).Set(cpuCores)
+	vmAllocatedMemoryBytes.WithLabelValues(provider, namespace).Set(memoryBytes)
+}
+
+// DeleteVMAllocation drops the allocation series of the provider in
+// namespace once it no longer has any VMs
+func DeleteVMAllocation(provider, namespace string) {
+	vmAllocatedCPUCores.DeleteLabelValues(provider, namespace)
+	vmAllocatedMemoryBytes.DeleteLabelValues(provider, namespace)
+}
+
+// SetPausedVMs sets the number of paused VMs in a namespace
+func SetPausedVMs(namespace string, count float64) {
+	vmPaused.WithLabelValues(namespace).Set(count)
+}
+
+// DeletePausedVMs drops the paused-VM series of a namespace with no paused
+// VMs left
+func DeletePausedVMs(namespace string) {
+	vmPaused.DeleteLabelValues(namespace)
+}
+
+// SetSnapshotChainLongVMs sets the number of VMs of one provider in one
+// namespace whose snapshot chain is too deep
+func SetSnapshotChainLongVMs(provider, namespace string, count float64) {
+	vmSnapshotChainLong.WithLabelValues(provider, namespace).Set(count)
+}
+
+// DeleteSnapshotChainLongVMs drops the long-chain series of a provider and
+// namespace with no such VMs left
+func DeleteSnapshotChainLongVMs(provider, namespace string) {
+	vmSnapshotChainLong.DeleteLabelValues(provider, namespace)
+}
+
+// SetCleanupRecords sets the number of recorded artifacts of one kind in
+// one state
+func SetCleanupRecords(kind, state string, count float64) {
+	cleanupRecords.WithLabelValues(kind, state).Set(count)
+}
+
+// Results of a status write
+const (
+	StatusWriteWritten = "written"
+	StatusWriteSkipped = "skipped"
+)
+
+// RecordStatusWrite records a status write of a resource kind that was
+// made or skipped
+func RecordStatusWrite(kind, result string) {
+	statusWritesTotal.WithLabelValues(kind, result).Inc()
+}
+
+// RecordCleanupAttempt records a deletion of an artifact left by a failed
+// operation
+func RecordCleanupAttempt(kind, outcome string) {
+	cleanupAttemptsTotal.WithLabelValues(kind, outcome).Inc()
+}
+
+// SetVMGuestCPUUsage sets the CPU utilization of a VM
+func SetVMGuestCPUUsage(namespace, name, providerType string, percent float64) {
+	vmGuestCPUUsagePercent.WithLabelValues(namespace, name, providerType).Set(percent)
+}
+
+// SetVMGuestMemoryUsage sets the memory in use by a VM's guest
+func SetVMGuestMemoryUsage(namespace, name, providerType string, bytes float64) {
+	vmGuestMemoryUsageBytes.WithLabelValues(namespace, name, providerType).Set(bytes)
+}
+
+// DeleteVMGuestCPUUsage drops the CPU utilization series of a VM
+func DeleteVMGuestCPUUsage(namespace, name, providerType string) {
+	vmGuestCPUUsagePercent.DeleteLabelValues(namespace, name, providerType)
+}
+
+// DeleteVMGuestMemoryUsage drops the memory usage series of a VM
+func DeleteVMGuestMemoryUsage(namespace, name, providerType string) {
+	vmGuestMemoryUsageBytes.DeleteLabelValues(namespace, name, providerType)
+}
+
 // Timer is a helper for measuring operation duration
 type Timer struct {
 	start time.Time
@@ -370,6 +659,63 @@ func (rt *RPCTimer) Finish(code string) {
 	rt.metrics.RecordRPC(rt.method, code, rt.timer.Duration())
 }
 
+// WorkqueueTier is the backlog of one tier of a tiered work queue
+type WorkqueueTier struct {
+	Tier      string
+	Depth     int
+	OldestAge time.Duration
+}
+
+var (
+	workqueueTierDepthDesc = prometheus.NewDesc(
+		"virtrigaud_workqueue_tier_depth",
+		"Requests waiting in each tier of a controller's work queue",
+		[]string{"name", "tier"}, nil,
+	)
+	workqueueTierOldestAgeDesc = prometheus.NewDesc(
+		"virtrigaud_workqueue_tier_oldest_item_age_seconds",
+		"How long the oldest request in each tier of a controller's work queue has waited",
+		[]string{"name", "tier"}, nil,
+	)
+)
+
+// workqueueTierCollector reads the tiers of each registered work queue at
+// scrape time, so the age of the oldest item is current.
+type workqueueTierCollector struct {
+	mu     sync.Mutex
+	queues map[string]func() []WorkqueueTier
+}
+
+var workqueueTiers = func() *workqueueTierCollector {
+	c := &workqueueTierCollector{queues: map[string]func() []WorkqueueTier{}}
+	ctrlmetrics.Registry.MustRegister(c)
+	return c
+}()
+
+func (c *workqueueTierCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workqueueTierDepthDesc
+	ch <- workqueueTierOldestAgeDesc
+}
+
+func (c *workqueueTierCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, stats := range c.queues {
+		for _, t := range stats() {
+			ch <- prometheus.MustNewConstMetric(workqueueTierDepthDesc, prometheus.GaugeValue, float64(t.Depth), name, t.Tier)
+			ch <- prometheus.MustNewConstMetric(workqueueTierOldestAgeDesc, prometheus.GaugeValue, t.OldestAge.Seconds(), name, t.Tier)
+		}
+	}
+}
+
+// RegisterWorkqueueTiers exposes the tiers of the work queue name, read from
+// stats at each scrape. Registering a name again replaces its stats.
+func RegisterWorkqueueTiers(name string, stats func() []WorkqueueTier) {
+	workqueueTiers.mu.Lock()
+	defer workqueueTiers.mu.Unlock()
+	workqueueTiers.queues[name] = stats
+}
+
 // GetRegistry returns the Prometheus registry used by controller-runtime,
 // which is the registry served by the manager's /metrics endpoint and into
 // which all virtrigaud_* metrics are registered.
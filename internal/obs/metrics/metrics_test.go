@@ -47,17 +47,26 @@ func TestMetricsRegisteredInControllerRuntimeRegistry(t *testing.T) {
 	// Metric vectors only emit a family entry once they have at least one observation.
 	SetupMetrics("test-version", "test-sha", "test-component")
 
-	// Exercise each metric vector with one observation so all 12 families appear.
+	// Exercise each metric vector with one observation so all 14 families appear.
 	NewReconcileMetrics("VirtualMachine").RecordReconcile(OutcomeSuccess, 5*time.Millisecond)
 	NewReconcileMetrics("VirtualMachine").SetQueueDepth(1)
 	NewVMOperationMetrics("test", "p1").RecordOperation(OpCreate, OutcomeSuccess)
 	NewProviderRPCMetrics("test").RecordRPC("Validate", "OK", 2*time.Millisecond)
 	NewTaskMetrics("test", "p1").SetInflightTasks(0)
+	RecordProviderResponseViolation("test", "p1", "ip")
 	RecordError("UnitTest", ComponentManager)
+	RecordDeprecatedFieldUsage("VirtualMachine", "spec.networks[*].name")
+	RecordTTLDeletion("VMClone", time.Second)
 	RecordIPDiscovery("test", 100*time.Millisecond)
 	cb := NewCircuitBreakerMetrics("test", "p1")
 	cb.SetState(CircuitBreakerClosed)
 	cb.RecordFailure()
+	SetVMAllocation("p1", "default", 2, 2<<30)
+	SetPausedVMs("default", 1)
+	SetProviderInfo("default", "p1", "test", "v1", "abc", "Test", "1.0")
+	SetProviderCompatibility("default", "p1", "Compatible")
+	SetProviderRequeueInterval("default", "p1", RequeueStateRunning, 2*time.Minute)
+	RegisterWorkqueueTiers("test", func() []WorkqueueTier { return []WorkqueueTier{{Tier: "normal"}} })
 
 	names := gatheredNames(t)
 
@@ -70,10 +79,22 @@ func TestMetricsRegisteredInControllerRuntimeRegistry(t *testing.T) {
 		"virtrigaud_provider_rpc_requests_total",
 		"virtrigaud_provider_rpc_latency_seconds",
 		"virtrigaud_provider_tasks_inflight",
+		"virtrigaud_provider_response_violations_total",
 		"virtrigaud_errors_total",
+		"virtrigaud_deprecated_field_usage_total",
+		"virtrigaud_ttl_deleted_total",
+		"virtrigaud_ttl_deletion_delay_seconds",
 		"virtrigaud_ip_discovery_duration_seconds",
 		"virtrigaud_circuit_breaker_state",
 		"virtrigaud_circuit_breaker_failures_total",
+		"virtrigaud_vm_allocated_cpu_cores",
+		"virtrigaud_vm_allocated_memory_bytes",
+		"virtrigaud_vm_paused",
+		"virtrigaud_provider_info",
+		"virtrigaud_provider_compatibility",
+		"virtrigaud_provider_requeue_interval_seconds",
+		"virtrigaud_workqueue_tier_depth",
+		"virtrigaud_workqueue_tier_oldest_item_age_seconds",
 	}
 
 	for _, name := range expected {
@@ -109,3 +130,107 @@ func TestSetupMetricsEmitsBuildInfo(t *testing.T) {
 	}
 	assert.True(t, found, "virtrigaud_build_info should have a sample with the labels passed to SetupMetrics")
 }
+
+// TestSetProviderInfoReplacesSeries verifies a version change leaves a
+// single virtrigaud_provider_info series per Provider, and that deleting
+// the Provider drops it.
+func TestSetProviderInfoReplacesSeries(t *testing.T) {
+	SetProviderInfo("infra", "pve", "proxmox", "v0.4.0", "abc", "Proxmox VE", "8.1.4")
+	SetProviderInfo("infra", "pve", "proxmox", "v0.4.1", "def", "Proxmox VE", "8.2.4")
+	SetProviderInfo("other", "pve", "proxmox", "v0.4.0", "abc", "Proxmox VE", "8.1.4")
+
+	series := func(namespace string) []map[string]string {
+		families, err := GetRegistry().Gather()
+		require.NoError(t, err)
+		var out []map[string]string
+		for _, f := range families {
+			if f.GetName() != "virtrigaud_provider_info" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				labels := make(map[string]string, len(m.GetLabel()))
+				for _, lp := range m.GetLabel() {
+					labels[lp.GetName()] = lp.GetValue()
+				}
+				if labels["namespace"] == namespace && labels["provider"] == "pve" {
+					out = append(out, labels)
+				}
+			}
+		}
+		return out
+	}
+
+	got := series("infra")
+	require.Len(t, got, 1)
+	assert.Equal(t, "v0.4.1", got[0]["version"])
+	assert.Equal(t, "8.2.4", got[0]["hypervisor_version"])
+
+	DeleteProviderInfo("infra", "pve")
+	assert.Empty(t, series("infra"))
+	assert.Len(t, series("other"), 1, "a same-named Provider in another namespace is untouched")
+}
+
+// TestSetProviderCompatibilityReplacesState verifies a Provider is counted
+// in a single compatibility state at a time.
+func TestSetProviderCompatibilityReplacesState(t *testing.T) {
+	SetProviderCompatibility("infra", "legacy", "Compatible")
+	SetProviderCompatibility("infra", "legacy", "DegradedCompatibility")
+
+	states := func() []string {
+		families, err := GetRegistry().Gather()
+		require.NoError(t, err)
+		var out []string
+		for _, f := range families {
+			if f.GetName() != "virtrigaud_provider_compatibility" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				labels := make(map[string]string, len(m.GetLabel()))
+				for _, lp := range m.GetLabel() {
+					labels[lp.GetName()] = lp.GetValue()
+				}
+				if labels["namespace"] == "infra" && labels["provider"] == "legacy" {
+					out = append(out, labels["state"])
+				}
+			}
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"DegradedCompatibility"}, states())
+	DeleteProviderCompatibility("infra", "legacy")
+	assert.Empty(t, states())
+}
+
+func TestRegisterWorkqueueTiersReadsAtScrape(t *testing.T) {
+	depth := 0
+	RegisterWorkqueueTiers("tiered", func() []WorkqueueTier {
+		return []WorkqueueTier{{Tier: "expedited", Depth: depth, OldestAge: time.Duration(depth) * time.Second}}
+	})
+
+	sample := func(family string) float64 {
+		families, err := GetRegistry().Gather()
+		require.NoError(t, err)
+		for _, f := range families {
+			if f.GetName() != family {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				labels := make(map[string]string, len(m.GetLabel()))
+				for _, lp := range m.GetLabel() {
+					labels[lp.GetName()] = lp.GetValue()
+				}
+				if labels["name"] == "tiered" && labels["tier"] == "expedited" {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+		t.Fatalf("no %s sample for the expedited tier", family)
+		return 0
+	}
+
+	assert.Zero(t, sample("virtrigaud_workqueue_tier_depth"))
+	depth = 3
+	assert.Equal(t, 3.0, sample("virtrigaud_workqueue_tier_depth"))
+	assert.Equal(t, 3.0, sample("virtrigaud_workqueue_tier_oldest_item_age_seconds"))
+}
@@ -0,0 +1,476 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight checks whether a cluster is ready to be upgraded to a
+// target virtrigaud release: that the objects already stored validate
+// against the target's CRD schemas, that running providers speak a
+// protocol version the target manager accepts, and that nothing the
+// cluster still depends on is removed by the target.
+//
+// Checks only read from the cluster. Each finding is a Result with a
+// pass, warn or fail Status; an upgrade should not go ahead while any
+// Result fails.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// DefaultPageSize is the number of objects fetched per list request.
+const DefaultPageSize = 500
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPass means nothing blocks the upgrade.
+	StatusPass Status = "pass"
+	// StatusWarn means the upgrade can go ahead but something degrades or
+	// needs attention afterwards.
+	StatusWarn Status = "warn"
+	// StatusFail means the upgrade must not go ahead until it is fixed.
+	StatusFail Status = "fail"
+)
+
+// Category groups related checks.
+type Category string
+
+const (
+	// CategorySchemas checks stored objects against the target CRDs.
+	CategorySchemas Category = "schemas"
+	// CategoryProviders checks provider protocol versions.
+	CategoryProviders Category = "providers"
+	// CategoryDeprecations checks for deprecated fields the target removes.
+	CategoryDeprecations Category = "deprecations"
+	// CategoryWebhooks checks the prerequisites of the admission webhooks.
+	CategoryWebhooks Category = "webhooks"
+	// CategoryFinalizers checks for finalizers no target controller removes.
+	CategoryFinalizers Category = "finalizers"
+)
+
+// Categories lists every category in report order.
+var Categories = []Category{CategorySchemas, CategoryProviders, CategoryDeprecations, CategoryWebhooks, CategoryFinalizers}
+
+// Result is one finding.
+type Result struct {
+	Category Category `json:"category"`
+	Status   Status   `json:"status"`
+	// Object is what the finding is about, such as "VirtualMachine
+	// default/web"; empty for a category summary.
+	Object  string `json:"object,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report is the outcome of a preflight run. Results are grouped by
+// category in Categories order, and every category has at least one.
+type Report struct {
+	TargetVersion string   `json:"targetVersion"`
+	Results       []Result `json:"results"`
+}
+
+// Count returns the number of results with status s.
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed reports whether any result failed.
+func (r *Report) Failed() bool {
+	return r.Count(StatusFail) > 0
+}
+
+// Webhook certificate sources, as in the chart's
+// webhooks.certificates.source.
+const (
+	CertSourceCertManager = "cert-manager"
+	CertSourceSelfSigned  = "self-signed"
+	CertSourceManual      = "manual"
+)
+
+// Target is the release being upgraded to and how it will be installed.
+type Target struct {
+	// Version is the release version, such as v0.4.0.
+	Version string
+	// CRDs are the release's CustomResourceDefinitions.
+	CRDs []apiextensionsv1.CustomResourceDefinition
+	// Finalizers are the finalizers the release's controllers remove.
+	Finalizers []string
+	// RemovesDeprecatedFields is set when the release no longer accepts
+	// the fields in infrav1beta1.DeprecatedFields.
+	RemovesDeprecatedFields bool
+
+	// Webhooks is set when the upgrade enables the admission webhooks.
+	Webhooks bool
+	// WebhookCertSource is where the webhook serving certificate comes
+	// from: CertSourceCertManager, CertSourceSelfSigned or
+	// CertSourceManual.
+	WebhookCertSource string
+	// WebhookCertSecret is the Secret holding a manual certificate, in
+	// Namespace.
+	WebhookCertSecret string
+	// Namespace is the namespace the manager runs in.
+	Namespace string
+}
+
+// Checker runs the preflight checks for a Target.
+type Checker struct {
+	client   client.Client
+	target   *Target
+	group    string
+	pageSize int64
+	scheme   *runtime.Scheme
+
+	report *Report
+	// checked counts what each category looked at, for its summary.
+	checked map[Category]int
+}
+
+// New returns a Checker. c must be able to read CustomResourceDefinitions
+// and Secrets, and to list virtrigaud resources as unstructured objects.
+func New(c client.Client, target *Target) *Checker {
+	scheme := runtime.NewScheme()
+	_ = infrav1beta1.AddToScheme(scheme)
+	return &Checker{
+		client:   c,
+		target:   target,
+		group:    infrav1beta1.GroupVersion.Group,
+		pageSize: DefaultPageSize,
+		scheme:   scheme,
+	}
+}
+
+// Run checks the cluster and returns the report. An error means the
+// checks could not be completed, not that one failed.
+func (c *Checker) Run(ctx context.Context) (*Report, error) {
+	c.report = &Report{TargetVersion: c.target.Version}
+	c.checked = map[Category]int{}
+
+	var crds apiextensionsv1.CustomResourceDefinitionList
+	if err := c.client.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+	current := map[string]*apiextensionsv1.CustomResourceDefinition{}
+	crdNames := map[string]bool{}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		crdNames[crd.Name] = true
+		if crd.Spec.Group == c.group {
+			current[crd.Name] = crd
+		}
+	}
+	target := map[string]*apiextensionsv1.CustomResourceDefinition{}
+	for i := range c.target.CRDs {
+		target[c.target.CRDs[i].Name] = &c.target.CRDs[i]
+	}
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := c.checkCRD(ctx, current[name], target[name]); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if err := c.checkWebhooks(ctx, crdNames); err != nil {
+		return nil, err
+	}
+
+	c.summarize()
+	return c.report, nil
+}
+
+func (c *Checker) add(category Category, status Status, object, format string, args ...any) {
+	c.report.Results = append(c.report.Results, Result{
+		Category: category, Status: status, Object: object, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// summarize orders the results by category and gives every category
+// without findings a passing summary.
+func (c *Checker) summarize() {
+	byCategory := map[Category][]Result{}
+	for _, r := range c.report.Results {
+		byCategory[r.Category] = append(byCategory[r.Category], r)
+	}
+	var ordered []Result
+	for _, cat := range Categories {
+		results := byCategory[cat]
+		if len(results) == 0 {
+			results = []Result{{Category: cat, Status: StatusPass, Message: c.passMessage(cat)}}
+		}
+		ordered = append(ordered, results...)
+	}
+	c.report.Results = ordered
+}
+
+func (c *Checker) passMessage(cat Category) string {
+	n := c.checked[cat]
+	switch cat {
+	case CategorySchemas:
+		return fmt.Sprintf("%d stored objects validate against the %s schemas", n, c.target.Version)
+	case CategoryProviders:
+		return fmt.Sprintf("%d providers speak a protocol version the %s manager accepts", n, c.target.Version)
+	case CategoryDeprecations:
+		if !c.target.RemovesDeprecatedFields {
+			return fmt.Sprintf("%s still accepts the deprecated v1beta1 fields", c.target.Version)
+		}
+		return fmt.Sprintf("none of %d resources use a field %s removes", n, c.target.Version)
+	case CategoryWebhooks:
+		if !c.target.Webhooks {
+			return "admission webhooks stay disabled"
+		}
+		return fmt.Sprintf("%s webhook certificates are available", c.target.WebhookCertSource)
+	case CategoryFinalizers:
+		return fmt.Sprintf("every finalizer on %d objects is removed by a %s controller", n, c.target.Version)
+	}
+	return "ok"
+}
+
+// checkCRD checks a CRD installed in the cluster, and every object of it,
+// against the target's definition of the same CRD, nil when the target
+// drops it.
+func (c *Checker) checkCRD(ctx context.Context, current, target *apiextensionsv1.CustomResourceDefinition) error {
+	kind := current.Spec.Names.Kind
+	crdObject := "CustomResourceDefinition " + current.Name
+
+	listVersion, ok := storageVersion(current)
+	if !ok {
+		return errors.New("no version is marked as the storage version")
+	}
+	var validator *objectValidator
+	if target != nil {
+		for _, v := range current.Status.StoredVersions {
+			if targetVersion(target, v) == nil {
+				c.add(CategorySchemas, StatusFail, crdObject,
+					"objects are stored at %s, which %s no longer defines; run vrtg admin migrate-storage first", v, c.target.Version)
+			}
+		}
+		if target.Spec.Conversion != nil && target.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter && !c.target.Webhooks {
+			c.add(CategorySchemas, StatusFail, crdObject,
+				"%s converts %s with the conversion webhook; enable webhooks for the upgrade", c.target.Version, kind)
+		}
+		// Read objects at the version the target stores them at, when
+		// the cluster can already serve it.
+		if storage, ok := storageVersion(target); ok && servesVersion(current, storage) {
+			listVersion = storage
+		}
+		if v := targetVersion(target, listVersion); v != nil {
+			var err error
+			if validator, err = newObjectValidator(v.Schema); err != nil {
+				return fmt.Errorf("invalid %s schema for %s: %w", c.target.Version, listVersion, err)
+			}
+		} else {
+			c.add(CategorySchemas, StatusWarn, crdObject,
+				"%s does not define %s, so stored objects cannot be validated", c.target.Version, listVersion)
+		}
+	}
+
+	objects := 0
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: current.Spec.Group, Version: listVersion, Kind: current.Spec.Names.ListKind})
+	opts := []client.ListOption{client.Limit(c.pageSize)}
+	for {
+		if err := c.client.List(ctx, list, opts...); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to list %s: %w", current.Spec.Names.Plural, err)
+		}
+		objects += len(list.Items)
+		for i := range list.Items {
+			c.checkObject(ctx, &list.Items[i], validator)
+		}
+		if list.GetContinue() == "" {
+			break
+		}
+		opts = []client.ListOption{client.Limit(c.pageSize), client.Continue(list.GetContinue())}
+	}
+
+	if target == nil && objects > 0 {
+		c.add(CategorySchemas, StatusFail, crdObject,
+			"%s no longer defines %s; its %d objects would be left without a controller", c.target.Version, kind, objects)
+	}
+	return nil
+}
+
+// checkObject runs every per-object check on obj.
+func (c *Checker) checkObject(ctx context.Context, obj *unstructured.Unstructured, validator *objectValidator) {
+	object := objectName(obj)
+
+	if validator != nil {
+		c.checked[CategorySchemas]++
+		if errs := validator.validate(ctx, obj); len(errs) > 0 {
+			c.add(CategorySchemas, StatusFail, object, "invalid under %s: %v", c.target.Version, errs.ToAggregate())
+		}
+		if dropped := validator.unknownFields(obj); len(dropped) > 0 {
+			c.add(CategorySchemas, StatusWarn, object,
+				"%s does not define %s; the values are dropped on the next write", c.target.Version, strings.Join(dropped, ", "))
+		}
+	}
+
+	c.checkFinalizers(obj, object)
+
+	typed, err := c.scheme.New(obj.GroupVersionKind())
+	if err != nil {
+		return
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), typed); err != nil {
+		return
+	}
+	if c.target.RemovesDeprecatedFields {
+		c.checked[CategoryDeprecations]++
+		for _, use := range infrav1beta1.FindDeprecations(typed) {
+			c.add(CategoryDeprecations, StatusFail, object, "%s is removed in %s; %s", use.Path, c.target.Version, use.Replacement)
+		}
+	}
+	if provider, ok := typed.(*infrav1beta1.Provider); ok {
+		c.checkProvider(provider, object)
+	}
+}
+
+// checkProvider checks that the target manager can talk to provider. The
+// provider's protocol range comes from what its running image reported.
+func (c *Checker) checkProvider(provider *infrav1beta1.Provider, object string) {
+	c.checked[CategoryProviders]++
+	image := "an unknown image"
+	if rt := provider.Spec.Runtime; rt != nil && rt.Image != "" {
+		image = rt.Image
+	}
+	protocol := provider.Status.Protocol
+	if protocol == nil {
+		c.add(CategoryProviders, StatusWarn, object,
+			"%s has not reported its protocol versions, so it cannot be checked against %s", image, c.target.Version)
+		return
+	}
+	n := contracts.NegotiateProtocol(contracts.Capabilities{
+		MinProtocolVersion: protocol.ProviderMinVersion,
+		MaxProtocolVersion: protocol.ProviderMaxVersion,
+	})
+	switch n.Compatibility {
+	case contracts.Incompatible:
+		c.add(CategoryProviders, StatusFail, object, "%s: %s", image, n.Message)
+	case contracts.DegradedCompatibility:
+		c.add(CategoryProviders, StatusWarn, object, "%s: %s", image, n.Message)
+	}
+}
+
+// checkFinalizers reports virtrigaud finalizers no target controller
+// removes. Such a finalizer blocks deletion forever, which fails at once
+// for an object that is already being deleted.
+func (c *Checker) checkFinalizers(obj *unstructured.Unstructured, object string) {
+	c.checked[CategoryFinalizers]++
+	for _, f := range obj.GetFinalizers() {
+		domain, _, _ := strings.Cut(f, "/")
+		if !strings.HasSuffix(domain, "virtrigaud.io") || slices.Contains(c.target.Finalizers, f) {
+			continue
+		}
+		if obj.GetDeletionTimestamp() != nil {
+			c.add(CategoryFinalizers, StatusFail, object,
+				"is being deleted but no %s controller removes finalizer %s; remove it by hand", c.target.Version, f)
+			continue
+		}
+		c.add(CategoryFinalizers, StatusWarn, object,
+			"no %s controller removes finalizer %s; deleting the object will hang until it is removed by hand", c.target.Version, f)
+	}
+}
+
+// checkWebhooks checks that the certificate source the webhooks will use
+// is in place. crds are the names of every CRD in the cluster.
+func (c *Checker) checkWebhooks(ctx context.Context, crds map[string]bool) error {
+	if !c.target.Webhooks {
+		return nil
+	}
+	switch c.target.WebhookCertSource {
+	case CertSourceCertManager:
+		for _, name := range []string{"certificates.cert-manager.io", "issuers.cert-manager.io"} {
+			if !crds[name] {
+				c.add(CategoryWebhooks, StatusFail, "CustomResourceDefinition "+name,
+					"webhook certificates come from cert-manager, which is not installed")
+			}
+		}
+	case CertSourceManual:
+		object := "Secret " + c.target.Namespace + "/" + c.target.WebhookCertSecret
+		var secret corev1.Secret
+		err := c.client.Get(ctx, client.ObjectKey{Namespace: c.target.Namespace, Name: c.target.WebhookCertSecret}, &secret)
+		switch {
+		case apierrors.IsNotFound(err):
+			c.add(CategoryWebhooks, StatusFail, object, "the manual webhook certificate Secret does not exist")
+		case err != nil:
+			return fmt.Errorf("failed to get webhook certificate Secret: %w", err)
+		case len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0:
+			c.add(CategoryWebhooks, StatusFail, object, "the webhook certificate Secret has no %s and %s", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+	case CertSourceSelfSigned:
+		// The manager generates its own certificate.
+	default:
+		return fmt.Errorf("unknown webhook certificate source %q", c.target.WebhookCertSource)
+	}
+	return nil
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return obj.GetKind() + " " + ns + "/" + obj.GetName()
+	}
+	return obj.GetKind() + " " + obj.GetName()
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) (string, bool) {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name, true
+		}
+	}
+	return "", false
+}
+
+func servesVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) bool {
+	v := targetVersion(crd, version)
+	return v != nil && v.Served
+}
+
+func targetVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
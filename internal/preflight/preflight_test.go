@@ -0,0 +1,262 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func bundledTarget(t *testing.T, version string) *Target {
+	t.Helper()
+	target, err := Bundled(version)
+	require.NoError(t, err)
+	return target
+}
+
+// installed returns the target's CRDs as installed in a cluster that
+// stores every one at its storage version.
+func installed(target *Target) []client.Object {
+	objs := make([]client.Object, 0, len(target.CRDs))
+	for i := range target.CRDs {
+		crd := target.CRDs[i].DeepCopy()
+		storage, _ := storageVersion(crd)
+		crd.Status.StoredVersions = []string{storage}
+		objs = append(objs, crd)
+	}
+	return objs
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func provider(name string, protocol *infrav1beta1.ProviderProtocolStatus) *infrav1beta1.Provider {
+	return &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: infrav1beta1.ProviderSpec{
+			Type:                infrav1beta1.ProviderTypeLibvirt,
+			Endpoint:            "qemu:///system",
+			CredentialSecretRef: infrav1beta1.ObjectRef{Name: "creds"},
+			Runtime:             &infrav1beta1.ProviderRuntimeSpec{Image: "ghcr.io/projectbeskar/virtrigaud/provider-libvirt:v0.3.0"},
+		},
+		Status: infrav1beta1.ProviderStatus{Protocol: protocol},
+	}
+}
+
+func vmClass(name string, cpu int32) *infrav1beta1.VMClass {
+	return &infrav1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       infrav1beta1.VMClassSpec{CPU: cpu, Memory: resource.MustParse("2Gi")},
+	}
+}
+
+func findings(r *Report, status Status) []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if res.Status == status {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+func TestRun_ReadyClusterPasses(t *testing.T) {
+	target := bundledTarget(t, "v0.9.0")
+	objs := append(installed(target),
+		vmClass("small", 2),
+		provider("libvirt", &infrav1beta1.ProviderProtocolStatus{
+			Compatibility:      string(contracts.Compatible),
+			ProviderMinVersion: contracts.ProtocolVersionMin,
+			ProviderMaxVersion: contracts.ProtocolVersionCurrent,
+		}),
+	)
+
+	report, err := New(newFakeClient(t, objs...), target).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, findings(report, StatusFail))
+	assert.Empty(t, findings(report, StatusWarn))
+	require.Len(t, report.Results, len(Categories))
+	for i, res := range report.Results {
+		assert.Equal(t, Categories[i], res.Category)
+	}
+	assert.Equal(t, "2 stored objects validate against the v0.9.0 schemas", report.Results[0].Message)
+}
+
+func TestRun_ReportsBlockers(t *testing.T) {
+	target := bundledTarget(t, "v1.0.0")
+	target.Webhooks = true
+	target.WebhookCertSource = CertSourceCertManager
+	objs := installed(target)
+	for _, o := range objs {
+		if crd := o.(*apiextensionsv1.CustomResourceDefinition); crd.Name == "vmclasses.infra.virtrigaud.io" {
+			crd.Status.StoredVersions = []string{"v1alpha1", "v1beta1"}
+		}
+	}
+
+	deprecated := provider("deprecated", &infrav1beta1.ProviderProtocolStatus{
+		Compatibility: string(contracts.Compatible), ProviderMinVersion: 1, ProviderMaxVersion: contracts.ProtocolVersionCurrent,
+	})
+	deprecated.Spec.InsecureSkipVerify = true
+	deleting := vmClass("deleting", 2)
+	deleting.Finalizers = []string{"legacy.infra.virtrigaud.io/finalizer"}
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	lingering := vmClass("lingering", 2)
+	lingering.Finalizers = []string{"legacy.infra.virtrigaud.io/finalizer", "example.com/keep"}
+
+	objs = append(objs,
+		vmClass("huge", 512),
+		deleting, lingering, deprecated,
+		provider("unreported", nil),
+		provider("future", &infrav1beta1.ProviderProtocolStatus{
+			Compatibility: string(contracts.Incompatible), ProviderMinVersion: 9, ProviderMaxVersion: 9,
+		}),
+		provider("older", &infrav1beta1.ProviderProtocolStatus{
+			Compatibility: string(contracts.DegradedCompatibility), ProviderMinVersion: 1, ProviderMaxVersion: 1,
+		}),
+	)
+
+	report, err := New(newFakeClient(t, objs...), target).Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+
+	type finding struct {
+		Category Category
+		Object   string
+	}
+	var failed, warned []finding
+	for _, res := range findings(report, StatusFail) {
+		failed = append(failed, finding{res.Category, res.Object})
+	}
+	for _, res := range findings(report, StatusWarn) {
+		warned = append(warned, finding{res.Category, res.Object})
+	}
+	assert.ElementsMatch(t, []finding{
+		{CategorySchemas, "CustomResourceDefinition vmclasses.infra.virtrigaud.io"},
+		{CategorySchemas, "VMClass default/huge"},
+		{CategoryProviders, "Provider default/future"},
+		{CategoryDeprecations, "Provider default/deprecated"},
+		{CategoryWebhooks, "CustomResourceDefinition certificates.cert-manager.io"},
+		{CategoryWebhooks, "CustomResourceDefinition issuers.cert-manager.io"},
+		{CategoryFinalizers, "VMClass default/deleting"},
+	}, failed)
+	assert.ElementsMatch(t, []finding{
+		{CategoryProviders, "Provider default/unreported"},
+		{CategoryProviders, "Provider default/older"},
+		{CategoryFinalizers, "VMClass default/lingering"},
+	}, warned)
+}
+
+func TestRun_DroppedCRDWithObjectsFails(t *testing.T) {
+	target := bundledTarget(t, "v0.9.0")
+	objs := installed(target)
+	var kept []apiextensionsv1.CustomResourceDefinition
+	for _, crd := range target.CRDs {
+		if crd.Name != "vmclasses.infra.virtrigaud.io" {
+			kept = append(kept, crd)
+		}
+	}
+	target.CRDs = kept
+
+	report, err := New(newFakeClient(t, append(objs, vmClass("small", 2))...), target).Run(context.Background())
+	require.NoError(t, err)
+
+	fails := findings(report, StatusFail)
+	require.Len(t, fails, 1)
+	assert.Equal(t, "CustomResourceDefinition vmclasses.infra.virtrigaud.io", fails[0].Object)
+	assert.Contains(t, fails[0].Message, "its 1 objects would be left without a controller")
+}
+
+func TestRun_ManualWebhookCertificate(t *testing.T) {
+	target := bundledTarget(t, "v0.9.0")
+	target.Webhooks = true
+	target.WebhookCertSource = CertSourceManual
+	target.Namespace = "virtrigaud-system"
+	target.WebhookCertSecret = "virtrigaud-webhook-certs"
+
+	report, err := New(newFakeClient(t, installed(target)...), target).Run(context.Background())
+	require.NoError(t, err)
+	fails := findings(report, StatusFail)
+	require.Len(t, fails, 1)
+	assert.Equal(t, "Secret virtrigaud-system/virtrigaud-webhook-certs", fails[0].Object)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "virtrigaud-system", Name: "virtrigaud-webhook-certs"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+	}
+	report, err = New(newFakeClient(t, append(installed(target), secret)...), target).Run(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.Failed())
+}
+
+func TestObjectValidator_UnknownFields(t *testing.T) {
+	target := bundledTarget(t, "v0.9.0")
+	var classes *apiextensionsv1.CustomResourceDefinition
+	for i := range target.CRDs {
+		if target.CRDs[i].Name == "vmclasses.infra.virtrigaud.io" {
+			classes = &target.CRDs[i]
+		}
+	}
+	require.NotNil(t, classes)
+	v, err := newObjectValidator(targetVersion(classes, "v1beta1").Schema)
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "infra.virtrigaud.io/v1beta1",
+		"kind":       "VMClass",
+		"metadata":   map[string]interface{}{"name": "small", "namespace": "default"},
+		"spec":       map[string]interface{}{"cpu": int64(2), "memory": "2Gi", "legacyTuning": "fast"},
+	}}
+	assert.Empty(t, v.validate(context.Background(), obj))
+	assert.Equal(t, []string{"spec.legacyTuning"}, v.unknownFields(obj))
+	assert.Contains(t, obj.Object["spec"], "legacyTuning", "unknownFields must not modify the object")
+}
+
+func TestBundled(t *testing.T) {
+	target := bundledTarget(t, "v0.9.0")
+	assert.False(t, target.RemovesDeprecatedFields)
+	assert.Contains(t, target.Finalizers, infrav1beta1.VirtualMachineFinalizer)
+	var names []string
+	for _, crd := range target.CRDs {
+		names = append(names, crd.Name)
+	}
+	assert.Contains(t, names, "virtualmachines.infra.virtrigaud.io")
+
+	assert.True(t, bundledTarget(t, "v1.0.0").RemovesDeprecatedFields)
+	assert.False(t, bundledTarget(t, "dev").RemovesDeprecatedFields)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+)
+
+// objectValidator validates objects the way the API server would on a
+// create under one CRD version's schema: OpenAPI validation followed by
+// the x-kubernetes-validations rules.
+type objectValidator struct {
+	schema     validation.SchemaValidator
+	structural *structuralschema.Structural
+	// cel is nil when the schema has no rules.
+	cel *cel.Validator
+}
+
+// newObjectValidator returns a validator for v, or nil when the version
+// has no schema.
+func newObjectValidator(v *apiextensionsv1.CustomResourceValidation) (*objectValidator, error) {
+	if v == nil || v.OpenAPIV3Schema == nil {
+		return nil, nil
+	}
+	var internal apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(v.OpenAPIV3Schema, &internal, nil); err != nil {
+		return nil, err
+	}
+	sv, _, err := validation.NewSchemaValidator(&internal)
+	if err != nil {
+		return nil, err
+	}
+	s, err := structuralschema.NewStructural(&internal)
+	if err != nil {
+		return nil, err
+	}
+	return &objectValidator{
+		schema:     sv,
+		structural: s,
+		cel:        cel.NewValidator(s, true, celconfig.PerCallLimit),
+	}, nil
+}
+
+// validate returns what makes obj invalid. Transition rules are not
+// evaluated, as there is no old object.
+func (v *objectValidator) validate(ctx context.Context, obj *unstructured.Unstructured) field.ErrorList {
+	errs := validation.ValidateCustomResource(nil, obj.UnstructuredContent(), v.schema)
+	if v.cel != nil {
+		celErrs, _ := v.cel.Validate(ctx, nil, v.structural, obj.UnstructuredContent(), nil, celconfig.RuntimeCELCostBudget)
+		errs = append(errs, celErrs...)
+	}
+	return errs
+}
+
+// unknownFields returns the paths of fields in obj the schema does not
+// define, which the API server prunes when the object is next written.
+func (v *objectValidator) unknownFields(obj *unstructured.Unstructured) []string {
+	content := runtime.DeepCopyJSON(obj.UnstructuredContent())
+	return pruning.PruneWithOptions(content, v.structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/config/crd"
+)
+
+// Bundled returns the Target for the release this binary was built from:
+// its embedded CRDs and the finalizers its controllers own. releaseVersion
+// is that release's version; a development build passes "dev".
+func Bundled(releaseVersion string) (*Target, error) {
+	crds, err := loadCRDs(crd.Bases)
+	if err != nil {
+		return nil, err
+	}
+	t := &Target{
+		Version: releaseVersion,
+		CRDs:    crds,
+		Finalizers: []string{
+			infrav1beta1.VirtualMachineFinalizer,
+			infrav1beta1.VMMigrationFinalizer,
+			infrav1beta1.VMSnapshotFinalizer,
+			infrav1beta1.VMImagePublishFinalizer,
+			infrav1beta1.VMCloneFinalizer,
+		},
+	}
+	// The deprecated v1beta1 fields are removed in v1.
+	if v, err := version.ParseSemantic(releaseVersion); err == nil && v.Major() >= 1 {
+		t.RemovesDeprecatedFields = true
+	}
+	return t, nil
+}
+
+// loadCRDs reads every bases/*.yaml file in fsys, sorted by CRD name.
+func loadCRDs(fsys fs.FS) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	files, err := fs.Glob(fsys, "bases/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	crds := make([]apiextensionsv1.CustomResourceDefinition, 0, len(files))
+	for _, name := range files {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(data, &crd); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		crds = append(crds, crd)
+	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
+	return crds, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// MaxConcurrentClonesEnv names the environment variable a provider reads
+// its clone limit from. The manager sets it from the Provider's
+// spec.runtime.maxConcurrentClones.
+const MaxConcurrentClonesEnv = "PROVIDER_MAX_CONCURRENT_CLONES"
+
+// DefaultCloneRetryAfter is how long a turned-away request is told to wait
+// before trying again. The manager spreads its retries around it.
+const DefaultCloneRetryAfter = 10 * time.Second
+
+// CloneLimiter caps how many VM clones a provider runs at once. A request
+// over the limit is turned away immediately with a busy error rather than
+// queued, so a burst of Creates cannot pile up unbounded work on the
+// hypervisor, and the manager backs off instead of timing out. A nil
+// CloneLimiter, or one with no limit, admits everything.
+type CloneLimiter struct {
+	slots      chan struct{}
+	retryAfter time.Duration
+}
+
+// NewCloneLimiter returns a limiter admitting max clones at once; max <= 0
+// means no limit.
+func NewCloneLimiter(max int, retryAfter time.Duration) *CloneLimiter {
+	l := &CloneLimiter{retryAfter: retryAfter}
+	if max > 0 {
+		l.slots = make(chan struct{}, max)
+	}
+	if l.retryAfter <= 0 {
+		l.retryAfter = DefaultCloneRetryAfter
+	}
+	return l
+}
+
+// CloneLimiterFromEnv returns a limiter configured from
+// PROVIDER_MAX_CONCURRENT_CLONES. An unset or invalid value means no limit.
+func CloneLimiterFromEnv() *CloneLimiter {
+	max, err := strconv.Atoi(strings.TrimSpace(os.Getenv(MaxConcurrentClonesEnv)))
+	if err != nil {
+		max = 0
+	}
+	return NewCloneLimiter(max, DefaultCloneRetryAfter)
+}
+
+// Acquire claims a clone slot. It returns a function that gives the slot
+// back, to be called once the clone has finished (calling it again is a
+// no-op), or a busy error when every slot is taken.
+func (l *CloneLimiter) Acquire() (release func(), err error) {
+	if l == nil || l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-l.slots }) }, nil
+	default:
+		return nil, errors.NewBusy(l.retryAfter, "%d clones already in progress, the provider's maxConcurrentClones", cap(l.slots))
+	}
+}
+
+// Limit returns the number of clones admitted at once, or zero for no
+// limit.
+func (l *CloneLimiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+	return cap(l.slots)
+}
+
+// InFlight returns the number of clone slots currently taken.
+func (l *CloneLimiter) InFlight() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.slots)
+}
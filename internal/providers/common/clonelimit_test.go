@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+func TestCloneLimiter(t *testing.T) {
+	l := NewCloneLimiter(2, 5*time.Second)
+
+	release1, err := l.Acquire()
+	require.NoError(t, err)
+	release2, err := l.Acquire()
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.InFlight())
+
+	// Over the limit the request is turned away, not queued, and the
+	// status the manager sees carries the retry delay.
+	_, err = l.Acquire()
+	require.Error(t, err)
+	delay, busy := errors.IsBusy(err)
+	assert.True(t, busy)
+	assert.Equal(t, 5*time.Second, delay)
+	st := status.Convert(errors.ToGRPCError(err))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, 5*time.Second, errors.RetryDelay(st))
+
+	// Releasing twice gives back one slot only.
+	release1()
+	release1()
+	assert.Equal(t, 1, l.InFlight())
+	release3, err := l.Acquire()
+	require.NoError(t, err)
+	release2()
+	release3()
+	assert.Zero(t, l.InFlight())
+}
+
+func TestCloneLimiterUnlimited(t *testing.T) {
+	t.Setenv(MaxConcurrentClonesEnv, "")
+	for _, l := range []*CloneLimiter{nil, NewCloneLimiter(0, 0), CloneLimiterFromEnv()} {
+		for i := 0; i < 10; i++ {
+			_, err := l.Acquire()
+			require.NoError(t, err)
+		}
+		assert.Zero(t, l.Limit())
+	}
+
+	t.Setenv(MaxConcurrentClonesEnv, "3")
+	assert.Equal(t, 3, CloneLimiterFromEnv().Limit())
+}
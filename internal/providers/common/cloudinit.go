@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// CloudInitWaitSeconds bounds how long CloudInitStatusCommand waits for
+// cloud-init inside the guest, so a status call never holds a reconcile.
+const CloudInitWaitSeconds = 10
+
+// CloudInitStatusCommand is the shell command providers run in the guest
+// through a guest agent. Its result is parsed by ParseCloudInitStatus.
+var CloudInitStatusCommand = fmt.Sprintf("timeout %d cloud-init status --wait --format json", CloudInitWaitSeconds)
+
+// Exit codes of CloudInitStatusCommand that are not cloud-init's own.
+const (
+	exitTimedOut        = 124
+	exitCommandNotFound = 127
+)
+
+// cloudInitStatusJSON is the part of `cloud-init status --format json` that
+// is reported. recoverable_errors only exists since cloud-init 23.4.
+type cloudInitStatusJSON struct {
+	Status            string              `json:"status"`
+	ExtendedStatus    string              `json:"extended_status"`
+	Detail            string              `json:"detail"`
+	Errors            []string            `json:"errors"`
+	RecoverableErrors map[string][]string `json:"recoverable_errors"`
+}
+
+// ParseCloudInitStatus turns the exit code and output of
+// CloudInitStatusCommand into a GetCloudInitStatusResponse. cloud-init exits
+// non-zero when it failed or recovered from errors, so the exit code alone
+// decides nothing; only the timeout and a missing cloud-init are detected
+// from it.
+func ParseCloudInitStatus(exitCode int, stdout, stderr string) (*providerv1.GetCloudInitStatusResponse, error) {
+	switch exitCode {
+	case exitTimedOut:
+		return &providerv1.GetCloudInitStatusResponse{
+			Status: "running",
+			Detail: fmt.Sprintf("cloud-init still running after %ds", CloudInitWaitSeconds),
+		}, nil
+	case exitCommandNotFound:
+		return nil, errors.NewNotSupported("cloud-init is not installed in the guest")
+	}
+
+	var out cloudInitStatusJSON
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &out); err != nil || out.Status == "" {
+		return nil, errors.NewInternal(fmt.Sprintf("unexpected output from cloud-init status (exit code %d): %s",
+			exitCode, firstNonEmpty(strings.TrimSpace(stderr), strings.TrimSpace(stdout))), err)
+	}
+
+	resp := &providerv1.GetCloudInitStatusResponse{
+		Status: normalizeCloudInitStatus(out.Status),
+		Errors: out.Errors,
+		Detail: firstNonEmpty(out.ExtendedStatus, out.Detail),
+	}
+	levels := make([]string, 0, len(out.RecoverableErrors))
+	for level := range out.RecoverableErrors {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		for _, msg := range out.RecoverableErrors[level] {
+			resp.RecoverableErrors = append(resp.RecoverableErrors, level+": "+msg)
+		}
+	}
+	return resp, nil
+}
+
+// normalizeCloudInitStatus maps the statuses of older cloud-init releases
+// onto the current ones.
+func normalizeCloudInitStatus(status string) string {
+	status = strings.TrimPrefix(status, "degraded ")
+	if status == "not run" {
+		return "not started"
+	}
+	return status
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseCloudInitStatus(t *testing.T) {
+	// cloud-init exits 1 on error; the JSON, not the exit code, decides.
+	resp, err := ParseCloudInitStatus(1, `{"status": "error", "detail": "DataSourceNoCloud",
+		"errors": ["('scripts_user', RuntimeError('runparts: 1 failed'))"]}`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "error", resp.Status)
+	assert.Equal(t, "DataSourceNoCloud", resp.Detail)
+	assert.Equal(t, []string{"('scripts_user', RuntimeError('runparts: 1 failed'))"}, resp.Errors)
+
+	resp, err = ParseCloudInitStatus(0, `{"status": "not run"}`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "not started", resp.Status)
+
+	resp, err = ParseCloudInitStatus(0, `{"status": "degraded done",
+		"recoverable_errors": {"WARNING": ["b"], "DEPRECATED": ["a"]}}`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "done", resp.Status)
+	assert.Equal(t, []string{"DEPRECATED: a", "WARNING: b"}, resp.RecoverableErrors)
+}
+
+func TestParseCloudInitStatus_ExitCodes(t *testing.T) {
+	resp, err := ParseCloudInitStatus(124, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "running", resp.Status)
+
+	_, err = ParseCloudInitStatus(127, "", "sh: 1: cloud-init: not found")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = ParseCloudInitStatus(2, "usage: cloud-init", "unrecognized arguments: --format")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unrecognized arguments")
+}
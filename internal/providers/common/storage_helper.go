@@ -70,6 +70,8 @@ func IsRetryableStorageError(err error) bool {
 		return false
 	case storage.ErrorTypeInvalidConfig:
 		return false
+	case storage.ErrorTypeResourceExhausted:
+		return false
 	default:
 		return true
 	}
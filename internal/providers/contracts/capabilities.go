@@ -25,40 +25,74 @@ import "context"
 type Capabilities struct {
 	// SupportsReconfigureOnline reports whether CPU/memory can be reconfigured
 	// without a power cycle.
-	SupportsReconfigureOnline bool
+	SupportsReconfigureOnline bool `json:"supportsReconfigureOnline"`
 	// SupportsDiskExpansionOnline reports whether disks can be expanded online.
-	SupportsDiskExpansionOnline bool
+	SupportsDiskExpansionOnline bool `json:"supportsDiskExpansionOnline"`
 	// SupportsSnapshots reports whether the provider supports VM snapshots.
-	SupportsSnapshots bool
+	SupportsSnapshots bool `json:"supportsSnapshots"`
 	// SupportsMemorySnapshots reports whether snapshots can include memory state.
-	SupportsMemorySnapshots bool
+	SupportsMemorySnapshots bool `json:"supportsMemorySnapshots"`
 	// SupportsLinkedClones reports whether copy-on-write linked clones are supported.
-	SupportsLinkedClones bool
+	SupportsLinkedClones bool `json:"supportsLinkedClones"`
 	// SupportsImageImport reports whether the provider can import/prepare images.
-	SupportsImageImport bool
+	SupportsImageImport bool `json:"supportsImageImport"`
 	// SupportedDiskTypes lists the disk formats the provider supports.
-	SupportedDiskTypes []string
+	SupportedDiskTypes []string `json:"supportedDiskTypes"`
 	// SupportedNetworkTypes lists the NIC models the provider supports.
-	SupportedNetworkTypes []string
+	SupportedNetworkTypes []string `json:"supportedNetworkTypes"`
 	// SupportsDiskExport reports whether the provider can export disks (migration source).
-	SupportsDiskExport bool
+	SupportsDiskExport bool `json:"supportsDiskExport"`
 	// SupportsDiskImport reports whether the provider can import disks (migration target).
-	SupportsDiskImport bool
+	SupportsDiskImport bool `json:"supportsDiskImport"`
 	// SupportedExportFormats lists the formats the provider can export to.
-	SupportedExportFormats []string
+	SupportedExportFormats []string `json:"supportedExportFormats"`
 	// SupportedImportFormats lists the formats the provider can import from.
-	SupportedImportFormats []string
+	SupportedImportFormats []string `json:"supportedImportFormats"`
 	// SupportsExportCompression reports whether disk export can be compressed.
-	SupportsExportCompression bool
+	SupportsExportCompression bool `json:"supportsExportCompression"`
 	// SupportedExportBackends lists the storage backends the provider can export
 	// disks to ("pvc", "nfs", "s3"). Empty means pvc-only (ADR-0006 Slice 0).
-	SupportedExportBackends []string
+	SupportedExportBackends []string `json:"supportedExportBackends"`
 	// SupportedImportBackends lists the storage backends the provider can import
 	// disks from ("pvc", "nfs", "s3"). Empty means pvc-only (ADR-0006 Slice 0).
-	SupportedImportBackends []string
+	SupportedImportBackends []string `json:"supportedImportBackends"`
 	// SupportedTransferModes lists the disk-transfer modes the provider supports
 	// ("relay", "direct"). Empty means relay-only (ADR-0006 Slice 0).
-	SupportedTransferModes []string
+	SupportedTransferModes []string `json:"supportedTransferModes"`
+	// SupportsConsoleOutput reports whether the provider implements
+	// GetConsoleOutput (serial/console log retrieval).
+	SupportsConsoleOutput bool `json:"supportsConsoleOutput"`
+	// SupportsSysprep reports whether Create applies a Sysprep
+	// GuestCustomization.
+	SupportsSysprep bool `json:"supportsSysprep"`
+	// SupportsCloudInitStatus reports whether the provider implements
+	// GetCloudInitStatus (cloud-init progress read from the guest).
+	SupportsCloudInitStatus bool `json:"supportsCloudInitStatus"`
+	// SupportsLiveMigration reports whether the provider implements
+	// MigrateHost (live migration between the hosts it manages).
+	SupportsLiveMigration bool `json:"supportsLiveMigration"`
+	// MinProtocolVersion and MaxProtocolVersion are the oldest and newest
+	// provider protocol versions the provider implements; 0 when it does
+	// not report them (see NegotiateProtocol).
+	MinProtocolVersion int32 `json:"minProtocolVersion"`
+	MaxProtocolVersion int32 `json:"maxProtocolVersion"`
+	// SupportsConsoleProxy reports whether the provider implements
+	// ConsoleTicket (interactive consoles through the manager's proxy).
+	SupportsConsoleProxy bool `json:"supportsConsoleProxy"`
+	// SupportsImagePublish reports whether the provider implements
+	// PublishImage (publishing a VM as a template or image).
+	SupportsImagePublish bool `json:"supportsImagePublish"`
+	// SupportsEventWatch reports whether the provider implements
+	// WatchEvents (hypervisor events streamed to the manager).
+	SupportsEventWatch bool `json:"supportsEventWatch"`
+	// SupportsQuiescedSnapshots reports whether SnapshotCreate honors
+	// Quiesce, freezing the guest's filesystems through its agent, and
+	// reports the outcome.
+	SupportsQuiescedSnapshots bool `json:"supportsQuiescedSnapshots"`
+	// CapabilityManifest lists the capability flags the provider image was
+	// built with, in sdk/provider/capabilities naming; empty when the
+	// provider does not report one.
+	CapabilityManifest []string `json:"capabilityManifest"`
 }
 
 // CapabilityReporter is an optional capability of a Provider: it reports the
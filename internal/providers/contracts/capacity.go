@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// HostCapacity is the capacity and current utilization of one placement
+// target (a PVE node, a vSphere cluster, a libvirt host). It mirrors the
+// provider.v1 HostCapacity message.
+type HostCapacity struct {
+	Name string `json:"name"`
+	// CPUCores is the number of physical cores the hypervisor schedules on.
+	CPUCores int32 `json:"cpuCores"`
+	// CPUUtilization is the current CPU load, from 0 to 1.
+	CPUUtilization    float64 `json:"cpuUtilization"`
+	MemoryTotalBytes  int64   `json:"memoryTotalBytes"`
+	MemoryUsedBytes   int64   `json:"memoryUsedBytes"`
+	StorageTotalBytes int64   `json:"storageTotalBytes"`
+	StorageUsedBytes  int64   `json:"storageUsedBytes"`
+}
+
+// CapacityReporter is an optional capability of a Provider: it reports the
+// hypervisor's actual capacity so allocated VM resources can be compared
+// against it. Callers type-assert a Provider to CapacityReporter; remote
+// providers that cannot report capacity return a NotSupported error.
+type CapacityReporter interface {
+	GetCapacity(ctx context.Context) ([]HostCapacity, error)
+}
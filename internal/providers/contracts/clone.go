@@ -16,37 +16,123 @@ limitations under the License.
 
 package contracts
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+)
 
 // CloneRequest contains all information needed to clone an existing VM into a
 // new one. It is the manager-side, transport-agnostic mirror of the
 // provider.v1 CloneRequest message (issue #179).
 type CloneRequest struct {
 	// SourceVmID is the provider-specific identifier of the VM to clone from.
-	SourceVmID string
+	SourceVmID string `json:"sourceVmID"`
 	// TargetName is the desired name of the cloned VM.
-	TargetName string
+	TargetName string `json:"targetName"`
 	// Linked requests a copy-on-write linked clone when true. Best-effort:
 	// providers that cannot honor it fall back to a full clone unless the
 	// caller has already gated on SupportsLinkedClones.
-	Linked bool
+	Linked bool `json:"linked"`
 	// ClassJSON is a JSON-encoded VMClass override for the target VM, or
 	// empty to inherit the source VM's resources.
-	ClassJSON string
+	ClassJSON string `json:"classJSON"`
 	// PlacementJSON is a JSON-encoded placement hint for the target VM, or
 	// empty to let the provider choose.
-	PlacementJSON string
+	PlacementJSON string `json:"placementJSON"`
 	// CustomizeJSON is a JSON-encoded customization spec (hostname, network,
 	// cloud-init, sysprep, ...), or empty for no customization.
-	CustomizeJSON string
+	CustomizeJSON string `json:"customizeJSON"`
+	// Customization is applied to the clone before it first boots, or nil
+	// for none. It travels as the customization_json payload.
+	Customization *CloneCustomization `json:"customization"`
+}
+
+// CloneCustomization is what the manager resolved from a VMClone's
+// spec.customization: references are read and NICs are numbered, so a
+// provider applies it as is.
+type CloneCustomization struct {
+	// Hostname is the clone's host name, or empty to keep the source's.
+	Hostname string `json:"hostname"`
+	// Domain is the DNS domain of Hostname, or empty.
+	Domain string `json:"domain"`
+	// UserData is the cloud-init user-data that replaces the source's, or
+	// empty to keep it.
+	UserData string `json:"userData"`
+	// Networks override the addressing of individual NICs. Providers that
+	// render the whole network configuration give the other NICs DHCP.
+	Networks []CloneNetworkOverride `json:"networks"`
+}
+
+// NetworkConfig renders a cloud-init network-config (version 2) for a clone
+// whose NICs have macs, in order: overridden NICs get their address, the
+// others DHCP. It returns "" without network overrides, leaving the guest's
+// default networking in place.
+func (c CloneCustomization) NetworkConfig(macs []string) (string, error) {
+	if len(c.Networks) == 0 {
+		return "", nil
+	}
+	byIndex := map[int]CloneNetworkOverride{}
+	for _, o := range c.Networks {
+		if o.Index >= len(macs) {
+			return "", fmt.Errorf("network %q is NIC %d, but the clone has %d NICs", o.Name, o.Index, len(macs))
+		}
+		byIndex[o.Index] = o
+	}
+
+	var b strings.Builder
+	b.WriteString("version: 2\nethernets:\n")
+	for i, mac := range macs {
+		fmt.Fprintf(&b, "  nic%d:\n    match:\n      macaddress: %q\n", i, mac)
+		o, ok := byIndex[i]
+		if !ok || o.IPAddress == "" {
+			b.WriteString("    dhcp4: true\n")
+			continue
+		}
+		fmt.Fprintf(&b, "    addresses:\n      - %s/%d\n", o.IPAddress, o.Prefix)
+		if o.Gateway != "" {
+			fmt.Fprintf(&b, "    routes:\n      - to: default\n        via: %s\n", o.Gateway)
+		}
+		if len(o.DNS) > 0 {
+			fmt.Fprintf(&b, "    nameservers:\n      addresses: [%s]\n", strings.Join(o.DNS, ", "))
+		}
+	}
+	return b.String(), nil
+}
+
+// CloneNetworkOverride sets the addressing of one NIC of a clone.
+type CloneNetworkOverride struct {
+	// Index is the NIC's position on the clone, from 0.
+	Index int `json:"index"`
+	// Name is the network the NIC was named by in the VMClone.
+	Name string `json:"name"`
+	// DHCP requests a dynamic address; the static fields are then empty.
+	DHCP bool `json:"dhcp"`
+	// IPAddress is the static address, e.g. "10.0.0.5".
+	IPAddress string `json:"ipAddress"`
+	// Prefix is the prefix length of IPAddress, e.g. 24.
+	Prefix int `json:"prefix"`
+	// Gateway is the default gateway, or empty.
+	Gateway string `json:"gateway"`
+	// DNS lists the name servers.
+	DNS []string `json:"dns"`
+	// MACAddress replaces the NIC's MAC address, or empty to keep the one
+	// the clone was given.
+	MACAddress string `json:"macAddress"`
 }
 
 // CloneResponse contains the result of a clone operation.
 type CloneResponse struct {
 	// TargetVmID is the provider-specific identifier of the newly cloned VM.
-	TargetVmID string
+	TargetVmID string `json:"targetVmID"`
 	// TaskRef references an async operation if the clone is not synchronous.
-	TaskRef string
+	TaskRef string `json:"taskRef"`
+	// CustomizationApplied is set when the provider applied
+	// CloneRequest.Customization.
+	CustomizationApplied bool `json:"customizationApplied"`
+	// CustomizationError says why customization failed. The clone exists
+	// as TargetVmID but is not customized, and is left powered off.
+	CustomizationError string `json:"customizationError"`
 }
 
 // Cloner is an optional capability of a Provider: it clones an existing VM
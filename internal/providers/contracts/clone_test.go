@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneCustomizationNetworkConfig(t *testing.T) {
+	macs := []string{"52:54:00:00:00:01", "52:54:00:00:00:02"}
+
+	config, err := CloneCustomization{}.NetworkConfig(macs)
+	require.NoError(t, err)
+	assert.Empty(t, config, "without overrides the guest keeps its default networking")
+
+	config, err = CloneCustomization{Networks: []CloneNetworkOverride{{
+		Index: 1, Name: "lan", IPAddress: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1", DNS: []string{"10.0.0.2", "10.0.0.3"},
+	}}}.NetworkConfig(macs)
+	require.NoError(t, err)
+	assert.Equal(t, `version: 2
+ethernets:
+  nic0:
+    match:
+      macaddress: "52:54:00:00:00:01"
+    dhcp4: true
+  nic1:
+    match:
+      macaddress: "52:54:00:00:00:02"
+    addresses:
+      - 10.0.0.5/24
+    routes:
+      - to: default
+        via: 10.0.0.1
+    nameservers:
+      addresses: [10.0.0.2, 10.0.0.3]
+`, config)
+
+	_, err = CloneCustomization{Networks: []CloneNetworkOverride{{Index: 2, Name: "storage", DHCP: true}}}.NetworkConfig(macs)
+	assert.ErrorContains(t, err, `network "storage" is NIC 2, but the clone has 2 NICs`)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// Values of CloudInitStatus.Status. They are the statuses cloud-init itself
+// reports in `cloud-init status --format json`.
+const (
+	CloudInitNotStarted = "not started"
+	CloudInitRunning    = "running"
+	CloudInitDone       = "done"
+	CloudInitError      = "error"
+	CloudInitDisabled   = "disabled"
+)
+
+// CloudInitStatus is cloud-init's progress inside a guest. It mirrors the
+// provider.v1 GetCloudInitStatusResponse message.
+type CloudInitStatus struct {
+	// Status is one of the CloudInit* values.
+	Status string `json:"status"`
+	// Errors are the errors cloud-init reported when Status is error.
+	Errors []string `json:"errors"`
+	// RecoverableErrors are warnings cloud-init recovered from. They can
+	// accompany any status.
+	RecoverableErrors []string `json:"recoverableErrors"`
+	// Detail is cloud-init's extended status or the provider's explanation.
+	Detail string `json:"detail"`
+}
+
+// CloudInitStatusReader is an optional capability of a Provider: it reads
+// cloud-init's status inside a guest through the hypervisor's guest channel.
+// Callers type-assert a Provider to CloudInitStatusReader and should treat a
+// NotSupported error as "this VM cannot report cloud-init status", since
+// remote providers may still answer Unimplemented and a guest may lack an
+// agent or cloud-init altogether.
+type CloudInitStatusReader interface {
+	// GetCloudInitStatus returns the cloud-init status of the VM identified
+	// by id. It waits at most a few seconds, never until cloud-init is done.
+	GetCloudInitStatus(ctx context.Context, id string) (CloudInitStatus, error)
+}
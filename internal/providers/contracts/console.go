@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"context"
+	"io"
+)
+
+// ConsoleOutput is the recent serial/console output captured for a VM. It
+// mirrors the provider.v1 GetConsoleOutputResponse message.
+type ConsoleOutput struct {
+	// Output is the captured console text.
+	Output string `json:"output"`
+	// Truncated is true when older output was dropped to honour the requested
+	// tail length.
+	Truncated bool `json:"truncated"`
+	// Source describes where the output came from (e.g. "serial0" or a log
+	// file path on the hypervisor host).
+	Source string `json:"source"`
+}
+
+// ConsoleOutputReader is an optional capability of a Provider: it returns the
+// recent serial/console output of a VM, primarily for diagnosing boot
+// failures. Callers type-assert a Provider to ConsoleOutputReader, mirroring
+// the Cloner and CapabilityReporter pattern, and should additionally check
+// Capabilities.SupportsConsoleOutput since remote providers may still answer
+// Unimplemented.
+type ConsoleOutputReader interface {
+	// GetConsoleOutput returns up to tailLines of the most recent console
+	// output for the VM identified by id. A tailLines of 0 selects the
+	// provider default.
+	GetConsoleOutput(ctx context.Context, id string, tailLines int32) (ConsoleOutput, error)
+}
+
+// Console protocols a ConsoleTicket can speak.
+const (
+	// ConsoleProtocolVNC is RFB, the VNC protocol.
+	ConsoleProtocolVNC = "vnc"
+	// ConsoleProtocolWebMKS is vSphere's WebMKS protocol.
+	ConsoleProtocolWebMKS = "webmks"
+)
+
+// ConsoleTicket says how to reach a VM's interactive console. It mirrors
+// the provider.v1 ConsoleTicketResponse message. The credentials in it are
+// short-lived and, where the hypervisor supports it, single-use: the
+// console proxy connects with them as soon as it has them.
+type ConsoleTicket struct {
+	// Protocol is ConsoleProtocolVNC or ConsoleProtocolWebMKS.
+	Protocol string `json:"protocol"`
+	// URL is the ws:// or wss:// URL to connect to. It is empty when the
+	// provider relays the console itself; see ConsoleRelayer.
+	URL string `json:"url"`
+	// Headers are sent when connecting to URL.
+	Headers map[string]string `json:"headers"`
+	// Password answers the console's VNC authentication; empty when the
+	// console asks for none.
+	Password string `json:"password"`
+	// CABundle verifies URL's certificate; the system roots are used when
+	// it is empty.
+	CABundle []byte `json:"caBundle"`
+	// CertificateFingerprint pins URL's certificate instead: its SHA-1 or
+	// SHA-256 fingerprint in hex, with or without colons.
+	CertificateFingerprint string `json:"certificateFingerprint"`
+	// InsecureSkipVerify skips verifying URL's certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+}
+
+// ConsoleTicketIssuer is an optional capability of a Provider: it opens a
+// VM's interactive console for the manager's console proxy. Callers
+// type-assert a Provider to ConsoleTicketIssuer and should additionally
+// check Capabilities.SupportsConsoleProxy, since remote providers may still
+// answer Unimplemented.
+type ConsoleTicketIssuer interface {
+	// ConsoleTicket returns how to reach the console of the VM identified
+	// by id. The VM must be running.
+	ConsoleTicket(ctx context.Context, id string) (ConsoleTicket, error)
+}
+
+// ConsoleRelayer is implemented alongside ConsoleTicketIssuer by providers
+// that relay consoles the manager cannot reach itself, for tickets without
+// a URL.
+type ConsoleRelayer interface {
+	// ConsoleRelay connects to the console of the VM identified by id.
+	// Closing the connection, or cancelling ctx, ends the relay.
+	ConsoleRelay(ctx context.Context, id string) (io.ReadWriteCloser, error)
+}
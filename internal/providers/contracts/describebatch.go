@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// DescribeBatchResponse is the state of several VMs. It mirrors the
+// provider.v1 DescribeBatchResponse message: each requested id is in
+// exactly one of Results and Errors.
+type DescribeBatchResponse struct {
+	Results map[string]DescribeResponse `json:"results"`
+	// Errors holds the ids that could not be described.
+	Errors map[string]error `json:"errors"`
+}
+
+// BatchDescriber is an optional capability of a Provider: it describes
+// many VMs in one call instead of one Describe per VM. Callers that need
+// several VMs of one provider should use DescribeMany, which falls back to
+// Describe for providers without it.
+type BatchDescriber interface {
+	// DescribeBatch describes the VMs in ids. The error return is for
+	// failures of the call as a whole; per-VM failures are in Errors.
+	DescribeBatch(ctx context.Context, ids []string) (DescribeBatchResponse, error)
+}
+
+// DescribeMany describes ids through p's DescribeBatch when it has one, or
+// by calling Describe per id.
+func DescribeMany(ctx context.Context, p Provider, ids []string) (DescribeBatchResponse, error) {
+	if batcher, ok := p.(BatchDescriber); ok {
+		return batcher.DescribeBatch(ctx, ids)
+	}
+	return DescribeEach(ctx, p, ids), nil
+}
+
+// DescribeEach describes ids with one Describe call per id.
+func DescribeEach(ctx context.Context, p Provider, ids []string) DescribeBatchResponse {
+	out := DescribeBatchResponse{Results: make(map[string]DescribeResponse, len(ids))}
+	for _, id := range ids {
+		if _, done := out.Results[id]; done {
+			continue
+		}
+		desc, err := p.Describe(ctx, id)
+		if err != nil {
+			if out.Errors == nil {
+				out.Errors = map[string]error{}
+			}
+			out.Errors[id] = err
+			continue
+		}
+		out.Results[id] = desc
+	}
+	return out
+}
@@ -19,6 +19,7 @@ package contracts
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // ErrorType represents the category of error
@@ -45,18 +46,27 @@ const (
 	ErrorTypeQuotaExceeded ErrorType = "QuotaExceeded"
 	// ErrorTypeConflict indicates resource conflict
 	ErrorTypeConflict ErrorType = "Conflict"
+	// ErrorTypeFailedPrecondition indicates the resource is not in a state
+	// the operation needs
+	ErrorTypeFailedPrecondition ErrorType = "FailedPrecondition"
+	// ErrorTypeBusy indicates the provider turned the request away to stay
+	// under a concurrency limit, such as its maximum concurrent clones
+	ErrorTypeBusy ErrorType = "Busy"
 )
 
 // ProviderError represents a categorized error from a provider
 type ProviderError struct {
 	// Type categorizes the error
-	Type ErrorType
+	Type ErrorType `json:"type"`
 	// Message describes the error
-	Message string
-	// Cause contains the underlying error
-	Cause error
+	Message string `json:"message"`
+	// Cause contains the underlying error. It does not cross the wire.
+	Cause error `json:"-"`
 	// Retryable indicates if the operation should be retried
-	Retryable bool
+	Retryable bool `json:"retryable"`
+	// RetryAfter is how long the provider asked the caller to wait before
+	// retrying, if it said. It does not cross the wire.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements the error interface
@@ -76,7 +86,7 @@ func (e *ProviderError) Unwrap() error {
 func (e *ProviderError) IsRetryable() bool {
 	return e.Retryable || e.Type == ErrorTypeRetryable ||
 		e.Type == ErrorTypeUnavailable || e.Type == ErrorTypeTimeout ||
-		e.Type == ErrorTypeRateLimit
+		e.Type == ErrorTypeRateLimit || e.Type == ErrorTypeBusy
 }
 
 // IsNotFound reports whether err is, or wraps, a provider NotFound error. The
@@ -88,6 +98,62 @@ func IsNotFound(err error) bool {
 	return errors.As(err, &pe) && pe.Type == ErrorTypeNotFound
 }
 
+// IsNotSupported reports whether err is, or wraps, a provider NotSupported
+// error, as returned for optional RPCs a provider does not implement.
+func IsNotSupported(err error) bool {
+	var pe *ProviderError
+	return errors.As(err, &pe) && pe.Type == ErrorTypeNotSupported
+}
+
+// IsConflict reports whether err is, or wraps, a provider Conflict error:
+// the request raced another change to the same resource, such as a locked
+// VM config. The transport client maps gRPC Aborted to it (see
+// mapGRPCError). Retrying shortly is expected to succeed.
+func IsConflict(err error) bool {
+	var pe *ProviderError
+	return errors.As(err, &pe) && pe.Type == ErrorTypeConflict
+}
+
+// IsFailedPrecondition reports whether err is, or wraps, a provider
+// FailedPrecondition error: the resource is not in a state the operation
+// needs, such as a quiesced snapshot of a VM whose guest agent does not
+// answer. The transport client maps gRPC FailedPrecondition to it (see
+// mapGRPCError). Retrying unchanged fails the same way until the resource
+// changes.
+func IsFailedPrecondition(err error) bool {
+	var pe *ProviderError
+	return errors.As(err, &pe) && pe.Type == ErrorTypeFailedPrecondition
+}
+
+// IsBusy reports whether err is, or wraps, a provider Busy error: the
+// provider is running as much of this work as it allows at once and asked
+// the caller to come back later. The transport client maps gRPC
+// ResourceExhausted carrying a retry delay to it (see mapGRPCError). It
+// returns the delay the provider asked for.
+func IsBusy(err error) (time.Duration, bool) {
+	var pe *ProviderError
+	if errors.As(err, &pe) && pe.Type == ErrorTypeBusy {
+		return pe.RetryAfter, true
+	}
+	return 0, false
+}
+
+// IsUnreachable reports whether err is, or wraps, an error that means the
+// provider could not be reached at all, as opposed to a provider that
+// answered with a failure. The transport client maps gRPC Unavailable and
+// DeadlineExceeded to retryable errors (see mapGRPCError).
+func IsUnreachable(err error) bool {
+	var pe *ProviderError
+	if !errors.As(err, &pe) {
+		return false
+	}
+	switch pe.Type {
+	case ErrorTypeRetryable, ErrorTypeUnavailable, ErrorTypeTimeout:
+		return true
+	}
+	return false
+}
+
 // NewNotFoundError creates a not found error
 func NewNotFoundError(message string, cause error) *ProviderError {
 	return &ProviderError{
@@ -186,3 +252,24 @@ func NewConflictError(message string, cause error) *ProviderError {
 		Retryable: false,
 	}
 }
+
+// NewBusyError creates a busy error carrying the provider's retry delay
+func NewBusyError(message string, retryAfter time.Duration, cause error) *ProviderError {
+	return &ProviderError{
+		Type:       ErrorTypeBusy,
+		Message:    message,
+		Cause:      cause,
+		Retryable:  true,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewFailedPreconditionError creates a failed precondition error
+func NewFailedPreconditionError(message string, cause error) *ProviderError {
+	return &ProviderError{
+		Type:      ErrorTypeFailedPrecondition,
+		Message:   message,
+		Cause:     cause,
+		Retryable: false,
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// EventSeverity ranks a HypervisorEvent.
+type EventSeverity string
+
+// Event severities, from least to most severe.
+const (
+	EventSeverityInfo    EventSeverity = "Info"
+	EventSeverityWarning EventSeverity = "Warning"
+	EventSeverityError   EventSeverity = "Error"
+)
+
+// AtLeastWarning reports whether s is EventSeverityWarning or
+// EventSeverityError.
+func (s EventSeverity) AtLeastWarning() bool {
+	return s == EventSeverityWarning || s == EventSeverityError
+}
+
+// HypervisorEvent is something the hypervisor reported about a VM, such as
+// a task started outside virtrigaud that failed or an alarm. It mirrors the
+// provider.v1 HypervisorEvent message.
+type HypervisorEvent struct {
+	// VMID is the VM's provider ID, as returned by Create; empty for events
+	// that concern no single VM.
+	VMID     string        `json:"vmID"`
+	Severity EventSeverity `json:"severity"`
+	// Reason is a short UpperCamelCase cause, e.g. "TaskFailed".
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Time is when the hypervisor recorded the event.
+	Time time.Time `json:"time"`
+	// Resolved reports that the condition an earlier warning or error with
+	// the same VMID and Reason reported has cleared.
+	Resolved bool `json:"resolved"`
+}
+
+// EventWatcher is an optional capability of a Provider: it streams the
+// events its hypervisor reports. Callers type-assert a Provider to
+// EventWatcher and should additionally check
+// Capabilities.SupportsEventWatch, since remote providers may still answer
+// Unimplemented.
+type EventWatcher interface {
+	// WatchEvents calls handle with each event, in order, until ctx is done
+	// or the stream ends. Events recorded from since on are sent first
+	// where the provider keeps a history; a zero since sends new events
+	// only. It returns nil when ctx is done or the provider ends the
+	// stream.
+	WatchEvents(ctx context.Context, since time.Time, handle func(HypervisorEvent)) error
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"fmt"
+)
+
+// SysprepUnattendFromJSON decodes the guest_customization_json field of a
+// gRPC CreateRequest and returns its unattend.xml. It returns "" when the
+// field is empty or selects cloud-init.
+func SysprepUnattendFromJSON(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	var gc GuestCustomization
+	if err := UnmarshalPayload([]byte(data), &gc); err != nil {
+		return "", fmt.Errorf("failed to parse guest customization JSON: %w", err)
+	}
+	switch gc.Type {
+	case "", GuestCustomizationCloudInit:
+		return "", nil
+	case GuestCustomizationSysprep:
+		if gc.Sysprep == nil || gc.Sysprep.UnattendXML == "" {
+			return "", fmt.Errorf("sysprep guest customization has no unattend.xml")
+		}
+		return gc.Sysprep.UnattendXML, nil
+	default:
+		return "", fmt.Errorf("unsupported guest customization type %q", gc.Type)
+	}
+}
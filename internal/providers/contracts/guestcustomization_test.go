@@ -0,0 +1,41 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSysprepUnattendFromJSON(t *testing.T) {
+	unattend, err := SysprepUnattendFromJSON(`{"Type":"Sysprep","Sysprep":{"UnattendXML":"<unattend/>"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "<unattend/>", unattend)
+
+	for _, data := range []string{"", `{"Type":"CloudInit"}`, `{}`} {
+		unattend, err := SysprepUnattendFromJSON(data)
+		require.NoError(t, err, data)
+		assert.Empty(t, unattend, data)
+	}
+
+	for _, data := range []string{`{"Type":"Sysprep"}`, `{"Type":"Ignition"}`, `not json`} {
+		_, err := SysprepUnattendFromJSON(data)
+		assert.Error(t, err, data)
+	}
+}
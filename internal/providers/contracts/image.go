@@ -25,30 +25,30 @@ import "context"
 type ImagePrepareRequest struct {
 	// ImageJSON is the JSON-encoded VMImage spec describing the image source
 	// (e.g. source.vsphere.ovaURL, source.libvirt.path/url, source.proxmox.*).
-	ImageJSON string
+	ImageJSON string `json:"imageJSON"`
 	// TargetName is the desired name of the prepared template/image on the
 	// provider.
-	TargetName string
+	TargetName string `json:"targetName"`
 	// StorageHint names the target storage location (vSphere datastore, libvirt
 	// pool, Proxmox storage), or empty to let the provider choose.
-	StorageHint string
+	StorageHint string `json:"storageHint"`
 }
 
 // ImagePrepareResponse contains the result of an image-prepare operation.
 type ImagePrepareResponse struct {
 	// TaskRef references an async operation when the prepare is not synchronous;
 	// an empty TaskRef means the operation completed synchronously.
-	TaskRef string
+	TaskRef string `json:"taskRef"`
 	// PreparedImageID is the provider-specific identifier of the prepared image
 	// (e.g. a vSphere/Proxmox template name or VMID). It is known at trigger time
 	// — even for async prepares — so the manager can stamp it onto VMImage.status
 	// and use it as the template ref when creating VMs (issue #154, PR-6 / #214).
 	// Empty for providers that address the prepared image by path only.
-	PreparedImageID string
+	PreparedImageID string `json:"preparedImageID"`
 	// PreparedImagePath is the provider-specific path of the prepared image (e.g.
 	// a libvirt pool path, <poolPath>/<target>.qcow2). Empty for providers that
 	// address the prepared image by id/name only (vSphere, Proxmox).
-	PreparedImagePath string
+	PreparedImagePath string `json:"preparedImagePath"`
 }
 
 // ImagePreparer is an optional capability of a Provider: it prepares/imports a
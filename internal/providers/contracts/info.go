@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// ProviderInfo identifies the provider build and the hypervisor behind it.
+// It mirrors the provider.v1 GetInfoResponse message.
+type ProviderInfo struct {
+	ProviderVersion   string `json:"providerVersion"`
+	GitSHA            string `json:"gitSHA"`
+	HypervisorProduct string `json:"hypervisorProduct"`
+	HypervisorVersion string `json:"hypervisorVersion"`
+	// HypervisorDetails is free-form extra detail such as a build number.
+	HypervisorDetails string `json:"hypervisorDetails"`
+}
+
+// InfoReporter is an optional capability of a Provider: it reports which
+// provider build is running and which hypervisor release it talks to.
+// Callers type-assert a Provider to InfoReporter; remote providers that
+// cannot report it return a NotSupported error.
+type InfoReporter interface {
+	GetInfo(ctx context.Context) (ProviderInfo, error)
+}
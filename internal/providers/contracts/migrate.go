@@ -0,0 +1,31 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// HostMigrator is an optional capability of a Provider: it live-migrates a
+// running VM between the hosts the provider manages. Callers type-assert a
+// Provider to HostMigrator, but should only call it on providers that
+// advertise SupportsLiveMigration; the others return a NotSupported error.
+type HostMigrator interface {
+	// MigrateHost starts moving the VM identified by id to targetHost, a
+	// host name as reported in the "host" key of ProviderRaw. It returns a
+	// task reference to poll with TaskStatus, whose Message carries the
+	// progress.
+	MigrateHost(ctx context.Context, id, targetHost string) (taskRef string, err error)
+}
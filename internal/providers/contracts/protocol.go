@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Provider protocol versions. They mirror the provider.v1 ProtocolVersion
+// enum; a transport test keeps the two in step.
+const (
+	// ProtocolVersion1 is the protocol before versioning. A provider that
+	// reports no version speaks it.
+	ProtocolVersion1 int32 = 1
+	// ProtocolVersion2 adds the Reset power op, cloud-init network-config
+	// and vendor-data, Create idempotency keys and Reconfigure change sets.
+	ProtocolVersion2 int32 = 2
+
+	// ProtocolVersionCurrent is the newest version the manager speaks.
+	ProtocolVersionCurrent = ProtocolVersion2
+	// ProtocolVersionMin is the oldest version the manager still speaks.
+	ProtocolVersionMin = ProtocolVersion1
+)
+
+// Feature names a manager behaviour that needs a minimum protocol version.
+type Feature string
+
+const (
+	// FeatureHardReset is the Reset power op behind HardReset requests.
+	FeatureHardReset Feature = "HardReset"
+	// FeatureCloudInitNetworkConfig is cloud-init network-config and
+	// vendor-data on Create. Older providers drop them.
+	FeatureCloudInitNetworkConfig Feature = "CloudInitNetworkConfig"
+	// FeatureIdempotentCreate is the Create idempotency key. Older
+	// providers may create a second VM when a Create is retried.
+	FeatureIdempotentCreate Feature = "IdempotentCreate"
+	// FeatureReconfigureChangeSet is the change set sent with Reconfigure.
+	// Older providers diff the desired spec against the VM themselves.
+	FeatureReconfigureChangeSet Feature = "ReconfigureChangeSet"
+)
+
+// featureVersions is the protocol version each feature arrived in.
+var featureVersions = map[Feature]int32{
+	FeatureHardReset:              ProtocolVersion2,
+	FeatureCloudInitNetworkConfig: ProtocolVersion2,
+	FeatureIdempotentCreate:       ProtocolVersion2,
+	FeatureReconfigureChangeSet:   ProtocolVersion2,
+}
+
+// Compatibility is how well the manager and a provider understand each
+// other.
+type Compatibility string
+
+const (
+	// Compatible means the provider has every feature the manager uses.
+	Compatible Compatibility = "Compatible"
+	// DegradedCompatibility means the provider speaks an older version;
+	// the features that need a newer one are unavailable.
+	DegradedCompatibility Compatibility = "DegradedCompatibility"
+	// Incompatible means the two share no version. The manager stops
+	// reconciling the provider's VMs, except to delete them.
+	Incompatible Compatibility = "Incompatible"
+)
+
+// ProtocolNegotiation is the outcome of comparing a provider's protocol
+// versions with the manager's.
+type ProtocolNegotiation struct {
+	// Compatibility summarizes the outcome.
+	Compatibility Compatibility
+	// ProviderMin and ProviderMax are the versions the provider implements,
+	// with an unreported version read as ProtocolVersion1.
+	ProviderMin, ProviderMax int32
+	// Version is the version the manager speaks to the provider, the
+	// newest both implement; 0 when Incompatible.
+	Version int32
+	// MissingFeatures lists the features Version is too old for, sorted.
+	MissingFeatures []Feature
+	// Message explains the outcome.
+	Message string
+}
+
+// NegotiateProtocol compares the protocol versions in caps with the ones
+// the manager speaks.
+func NegotiateProtocol(caps Capabilities) ProtocolNegotiation {
+	n := ProtocolNegotiation{ProviderMin: caps.MinProtocolVersion, ProviderMax: caps.MaxProtocolVersion}
+	if n.ProviderMax <= 0 {
+		n.ProviderMax = ProtocolVersion1
+	}
+	if n.ProviderMin <= 0 || n.ProviderMin > n.ProviderMax {
+		n.ProviderMin = min(ProtocolVersion1, n.ProviderMax)
+	}
+
+	switch {
+	case n.ProviderMin > ProtocolVersionCurrent:
+		n.Compatibility = Incompatible
+		n.Message = fmt.Sprintf("provider needs protocol version %d or newer; this manager speaks %d to %d: upgrade the manager",
+			n.ProviderMin, ProtocolVersionMin, ProtocolVersionCurrent)
+		return n
+	case n.ProviderMax < ProtocolVersionMin:
+		n.Compatibility = Incompatible
+		n.Message = fmt.Sprintf("provider speaks protocol version %d at most; this manager needs %d or newer: upgrade the provider",
+			n.ProviderMax, ProtocolVersionMin)
+		return n
+	}
+
+	n.Version = min(n.ProviderMax, ProtocolVersionCurrent)
+	for f, v := range featureVersions {
+		if v > n.Version {
+			n.MissingFeatures = append(n.MissingFeatures, f)
+		}
+	}
+	sort.Slice(n.MissingFeatures, func(i, j int) bool { return n.MissingFeatures[i] < n.MissingFeatures[j] })
+
+	if len(n.MissingFeatures) == 0 {
+		n.Compatibility = Compatible
+		n.Message = fmt.Sprintf("provider speaks protocol version %d", n.Version)
+		return n
+	}
+	names := make([]string, len(n.MissingFeatures))
+	for i, f := range n.MissingFeatures {
+		names[i] = string(f)
+	}
+	n.Compatibility = DegradedCompatibility
+	n.Message = fmt.Sprintf("provider speaks protocol version %d, older than this manager's %d; unavailable: %s",
+		n.Version, ProtocolVersionCurrent, strings.Join(names, ", "))
+	return n
+}
+
+// FeatureVersion returns the protocol version feature arrived in.
+func FeatureVersion(feature Feature) int32 {
+	if v, ok := featureVersions[feature]; ok {
+		return v
+	}
+	return ProtocolVersion1
+}
+
+// Supports reports whether the negotiated version provides feature.
+func (n ProtocolNegotiation) Supports(feature Feature) bool {
+	return n.Compatibility != Incompatible && FeatureVersion(feature) <= n.Version
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	allV2 := []Feature{FeatureCloudInitNetworkConfig, FeatureHardReset, FeatureIdempotentCreate, FeatureReconfigureChangeSet}
+	tests := []struct {
+		name        string
+		min, max    int32
+		want        Compatibility
+		wantVersion int32
+		wantMissing []Feature
+	}{
+		{name: "unversioned provider speaks version 1", want: DegradedCompatibility, wantVersion: 1, wantMissing: allV2},
+		{name: "older provider", min: 1, max: 1, want: DegradedCompatibility, wantVersion: 1, wantMissing: allV2},
+		{name: "current provider", min: 1, max: 2, want: Compatible, wantVersion: 2},
+		{name: "newer provider still speaking ours", min: 2, max: 5, want: Compatible, wantVersion: 2},
+		{name: "provider past this manager", min: 3, max: 4, want: Incompatible},
+		{name: "only a max reported", max: 2, want: Compatible, wantVersion: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n := NegotiateProtocol(Capabilities{MinProtocolVersion: tc.min, MaxProtocolVersion: tc.max})
+			assert.Equal(t, tc.want, n.Compatibility)
+			assert.Equal(t, tc.wantVersion, n.Version)
+			assert.Equal(t, tc.wantMissing, n.MissingFeatures)
+			assert.NotEmpty(t, n.Message)
+		})
+	}
+}
+
+func TestProtocolNegotiationSupports(t *testing.T) {
+	old := NegotiateProtocol(Capabilities{})
+	assert.False(t, old.Supports(FeatureHardReset))
+	assert.True(t, old.Supports(Feature("Power")), "features the table does not know are version 1")
+
+	current := NegotiateProtocol(Capabilities{MinProtocolVersion: 1, MaxProtocolVersion: ProtocolVersionCurrent})
+	assert.True(t, current.Supports(FeatureHardReset))
+
+	incompatible := NegotiateProtocol(Capabilities{MinProtocolVersion: ProtocolVersionCurrent + 1, MaxProtocolVersion: ProtocolVersionCurrent + 1})
+	assert.False(t, incompatible.Supports(Feature("Power")))
+}
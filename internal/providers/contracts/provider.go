@@ -18,6 +18,8 @@ package contracts
 
 import (
 	"context"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
 )
 
 // PowerOp represents a power operation type
@@ -32,50 +34,95 @@ const (
 	PowerOpReboot PowerOp = "Reboot"
 	// PowerOpShutdownGraceful gracefully shuts down the VM using guest tools
 	PowerOpShutdownGraceful PowerOp = "ShutdownGraceful"
+	// PowerOpReset hard-resets the VM without involving the guest
+	PowerOpReset PowerOp = "Reset"
 )
 
 // CreateRequest contains all information needed to create a VM
 type CreateRequest struct {
 	// Name of the VM to create
-	Name string
+	Name string `json:"name"`
 	// Class defines the VM resource allocation
-	Class VMClass
+	Class VMClass `json:"class"`
 	// Image defines the base template/image
-	Image VMImage
+	Image VMImage `json:"image"`
 	// Networks defines network attachments
-	Networks []NetworkAttachment
+	Networks []NetworkAttachment `json:"networks"`
 	// Disks defines additional disks
-	Disks []DiskSpec
+	Disks []DiskSpec `json:"disks"`
 	// UserData contains cloud-init/ignition configuration
-	UserData *UserData
+	UserData *UserData `json:"userData"`
 	// MetaData contains cloud-init metadata configuration
-	MetaData *MetaData
+	MetaData *MetaData `json:"metaData"`
+	// GuestCustomization selects a customization other than cloud-init;
+	// nil means cloud-init from UserData and MetaData
+	GuestCustomization *GuestCustomization `json:"guestCustomization"`
 	// Placement provides placement hints
-	Placement *Placement
+	Placement *Placement `json:"placement"`
 	// Tags are applied to the VM
-	Tags []string
+	Tags []string `json:"tags"`
+	// IdempotencyKey identifies the intended VM across retries; the
+	// controller sets it to the VirtualMachine UID. Providers must answer
+	// a Create whose key matches an earlier one with that VM (resuming its
+	// creation if it is still in progress) rather than creating another.
+	IdempotencyKey string `json:"idempotencyKey"`
 }
 
 // CreateResponse contains the result of a create operation
 type CreateResponse struct {
 	// ID is the provider-specific identifier
-	ID string
+	ID string `json:"id"`
 	// TaskRef references an async operation if applicable
-	TaskRef string
+	TaskRef string `json:"taskRef"`
+	// ProviderRaw contains provider-specific details, e.g. how the VM was
+	// placed
+	ProviderRaw map[string]string `json:"providerRaw"`
 }
 
 // DescribeResponse contains the current state of a VM
 type DescribeResponse struct {
 	// Exists indicates if the VM exists
-	Exists bool
+	Exists bool `json:"exists"`
 	// PowerState is the current power state
-	PowerState string
+	PowerState string `json:"powerState"`
 	// IPs contains assigned IP addresses
-	IPs []string
+	IPs []string `json:"ips"`
 	// ConsoleURL provides console access
-	ConsoleURL string
-	// ProviderRaw contains provider-specific details
-	ProviderRaw map[string]string
+	ConsoleURL string `json:"consoleURL"`
+	// ProviderRaw contains provider-specific details, flattened to strings
+	// for VirtualMachine status.provider
+	ProviderRaw map[string]string `json:"providerRaw"`
+	// GuestStats is the VM's current resource usage, nil when the provider
+	// cannot observe it
+	GuestStats *GuestStats `json:"guestStats"`
+	// Addresses lists the IPs whose interface the provider knows
+	Addresses []GuestAddress `json:"addresses"`
+	// Identity is the marker stamped on the VM at create time, the create
+	// request's idempotency key; empty when the VM carries none
+	Identity string `json:"identity"`
+	// Details are the provider details in the typed describe layout; nil
+	// when the provider sends a free-form provider_raw_json
+	Details *describe.Details `json:"details"`
+}
+
+// GuestAddress is an IP address together with the NIC it was found on
+type GuestAddress struct {
+	// IP is the address
+	IP string `json:"ip"`
+	// Interface is the guest's interface name, or the NIC's network when
+	// the guest name is unknown
+	Interface string `json:"interface"`
+}
+
+// GuestStats is a normalized resource usage sample. A nil field was not
+// reported by the hypervisor.
+type GuestStats struct {
+	// CPUUsagePercent is utilization across all vCPUs, 0-100
+	CPUUsagePercent *float64 `json:"cpuUsagePercent"`
+	// MemoryUsageBytes is memory in use by the guest
+	MemoryUsageBytes *int64 `json:"memoryUsageBytes"`
+	// UptimeSeconds is the time since the VM was last powered on
+	UptimeSeconds *int64 `json:"uptimeSeconds"`
 }
 
 // Provider defines the interface that all providers must implement
@@ -95,10 +142,11 @@ type Provider interface {
 	// Returns TaskRef if the operation is asynchronous
 	Power(ctx context.Context, id string, op PowerOp) (taskRef string, err error)
 
-	// Reconfigure modifies VM resources (CPU/RAM/Disks)
+	// Reconfigure applies changes to VM resources (CPU/RAM/Disks/Networks)
+	// Only the fields in changes are applied; desired is the full spec
+	// for providers that still need it
 	// May be no-op for unsupported fields
-	// Returns TaskRef if the operation is asynchronous
-	Reconfigure(ctx context.Context, id string, desired CreateRequest) (taskRef string, err error)
+	Reconfigure(ctx context.Context, id string, desired CreateRequest, changes ChangeSet) (ReconfigureResult, error)
 
 	// Describe returns the current state of the VM
 	// Should be cheap and resilient to call frequently
@@ -139,43 +187,43 @@ type Provider interface {
 // VMInfo contains basic information about a VM for discovery
 type VMInfo struct {
 	// ID is the provider-specific VM identifier
-	ID string
+	ID string `json:"id"`
 	// Name is the VM name
-	Name string
+	Name string `json:"name"`
 	// PowerState is the current power state
-	PowerState string
+	PowerState string `json:"powerState"`
 	// IPs contains assigned IP addresses
-	IPs []string
+	IPs []string `json:"ips"`
 	// CPU is the number of virtual CPUs
-	CPU int32
+	CPU int32 `json:"cpu"`
 	// MemoryMiB is the amount of memory in MiB
-	MemoryMiB int64
+	MemoryMiB int64 `json:"memoryMiB"`
 	// Disks contains disk information
-	Disks []DiskInfo
+	Disks []DiskInfo `json:"disks"`
 	// Networks contains network information
-	Networks []NetworkInfo
+	Networks []NetworkInfo `json:"networks"`
 	// ProviderRaw contains provider-specific metadata
-	ProviderRaw map[string]string
+	ProviderRaw map[string]string `json:"providerRaw"`
 }
 
 // DiskInfo contains information about a VM disk
 type DiskInfo struct {
 	// ID is the disk identifier
-	ID string
+	ID string `json:"id"`
 	// Path is the disk path
-	Path string
+	Path string `json:"path"`
 	// SizeGiB is the disk size in GiB
-	SizeGiB int32
+	SizeGiB int32 `json:"sizeGiB"`
 	// Format is the disk format (qcow2, vmdk, etc.)
-	Format string
+	Format string `json:"format"`
 }
 
 // NetworkInfo contains information about a VM network interface
 type NetworkInfo struct {
 	// Name is the network name
-	Name string
+	Name string `json:"name"`
 	// MAC is the MAC address
-	MAC string
+	MAC string `json:"mac"`
 	// IPAddress is the IP address if static
-	IPAddress string
+	IPAddress string `json:"ipAddress"`
 }
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import "context"
+
+// PublishImageOptions tunes how a VM is published. It travels as the
+// provider.v1 PublishImageRequest's options_json.
+type PublishImageOptions struct {
+	// SnapshotID publishes the VM as of this snapshot instead of its
+	// current disks; the VM may then keep running.
+	SnapshotID string `json:"snapshotID"`
+	// Cleanup lists virt-sysprep operations (such as "machine-id" or
+	// "ssh-hostkeys") run against the published copy. Providers that
+	// cannot run them reject a non-empty list.
+	Cleanup []string `json:"cleanup"`
+	// ContentLibrary exports the image to this vSphere content library
+	// instead of converting a copy into a template.
+	ContentLibrary string `json:"contentLibrary"`
+	// Storage names where the published image is kept (vSphere datastore,
+	// libvirt pool, Proxmox storage), or empty to keep the VM's.
+	Storage string `json:"storage"`
+}
+
+// PublishImageRequest asks a provider to publish a copy of a VM as a
+// template or image. It mirrors the provider.v1 PublishImageRequest message.
+type PublishImageRequest struct {
+	// VMID is the provider ID of the source VM. The VM itself is never
+	// converted; the provider publishes a copy.
+	VMID string `json:"vmID"`
+	// ImageName is the name of the published template or image.
+	ImageName string `json:"imageName"`
+	// Options tunes the publish.
+	Options PublishImageOptions `json:"options"`
+	// Overwrite replaces an existing template or image of the same name.
+	// Without it such a name fails with AlreadyExists.
+	Overwrite bool `json:"overwrite"`
+}
+
+// PublishImageResponse describes the published template or image.
+type PublishImageResponse struct {
+	// TaskRef references the async operation; empty when the publish
+	// completed synchronously.
+	TaskRef string `json:"taskRef"`
+	// ImageID is the provider-specific identifier of the published image,
+	// such as a Proxmox template VMID or a content library item ID.
+	ImageID string `json:"imageID"`
+	// ImagePath is the path of the published image file for providers that
+	// address images by path (libvirt).
+	ImagePath string `json:"imagePath"`
+	// Checksum and ChecksumType identify the published image's content
+	// when the provider computes it, such as a sha256 of a libvirt qcow2.
+	Checksum     string `json:"checksum"`
+	ChecksumType string `json:"checksumType"`
+}
+
+// ImagePublisher is an optional capability of a Provider: it publishes a
+// copy of a VM as a template or image that later VMs can be created from.
+// Callers type-assert a Provider to ImagePublisher, mirroring the
+// ImagePreparer pattern, and should additionally check
+// Capabilities.SupportsImagePublish since remote providers may still answer
+// Unimplemented.
+type ImagePublisher interface {
+	// PublishImage publishes the VM identified by req.VMID as req.ImageName.
+	// The VM should be powered off, or req.Options.SnapshotID set, so the
+	// copy is consistent.
+	PublishImage(ctx context.Context, req PublishImageRequest) (PublishImageResponse, error)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+// Change keys name the fields of a ChangeSet, e.g. in
+// ReconfigureResult.PowerCycleRequired.
+const (
+	ChangeCPU            = "cpu"
+	ChangeMemoryMiB      = "memoryMiB"
+	ChangeDisks          = "disks"
+	ChangeNetworks       = "networks"
+	ChangeSecurityGroups = "securityGroups"
+)
+
+// Int32Change is the old and new value of a changed field
+type Int32Change struct {
+	Old int32 `json:"old"`
+	New int32 `json:"new"`
+}
+
+// Int64Change is the old and new value of a changed field
+type Int64Change struct {
+	Old int64 `json:"old"`
+	New int64 `json:"new"`
+}
+
+// DiskExpansion grows a disk. Disks are never shrunk.
+type DiskExpansion struct {
+	// Name is the DiskSpec name, or RootDiskName for the class default disk
+	Name       string `json:"name"`
+	OldSizeGiB int32  `json:"oldSizeGiB"`
+	NewSizeGiB int32  `json:"newSizeGiB"`
+}
+
+// RootDiskName names the VM's boot disk, sized by VMClass.DiskDefaults, in a
+// ChangeSet.
+const RootDiskName = "root"
+
+// SecurityGroupChange is the full list of firewall security groups a
+// network attachment should have. An empty list removes them all.
+type SecurityGroupChange struct {
+	// Network is the attachment name
+	Network string   `json:"network"`
+	Groups  []string `json:"groups"`
+}
+
+// ChangeSet describes how a VM's desired spec differs from what was last
+// applied to it. It is computed by the manager so that every provider
+// agrees on what counts as a change; nil and empty fields are unchanged.
+type ChangeSet struct {
+	CPU             *Int32Change          `json:"cpu"`
+	MemoryMiB       *Int64Change          `json:"memoryMiB"`
+	Disks           []DiskExpansion       `json:"disks"`
+	NetworksAdded   []NetworkAttachment   `json:"networksAdded"`
+	NetworksRemoved []string              `json:"networksRemoved"`
+	SecurityGroups  []SecurityGroupChange `json:"securityGroups"`
+}
+
+// IsEmpty reports whether the change set changes nothing
+func (c ChangeSet) IsEmpty() bool {
+	return len(c.Keys()) == 0
+}
+
+// Keys returns the change keys of the fields the change set touches
+func (c ChangeSet) Keys() []string {
+	var keys []string
+	if c.CPU != nil {
+		keys = append(keys, ChangeCPU)
+	}
+	if c.MemoryMiB != nil {
+		keys = append(keys, ChangeMemoryMiB)
+	}
+	if len(c.Disks) > 0 {
+		keys = append(keys, ChangeDisks)
+	}
+	if len(c.NetworksAdded) > 0 || len(c.NetworksRemoved) > 0 {
+		keys = append(keys, ChangeNetworks)
+	}
+	if len(c.SecurityGroups) > 0 {
+		keys = append(keys, ChangeSecurityGroups)
+	}
+	return keys
+}
+
+// ReconfigureResult contains the result of a reconfigure operation
+type ReconfigureResult struct {
+	// TaskRef references an async operation if applicable
+	TaskRef string `json:"taskRef"`
+	// PowerCycleRequired lists the change keys that could not be applied to
+	// the running VM. The manager re-sends them until the VM is powered off.
+	PowerCycleRequired []string `json:"powerCycleRequired"`
+}
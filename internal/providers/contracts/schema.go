@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+//go:generate go run ./schemagen ../../../docs/provider-contract.v1.schema.json
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// wirePayloads are the RPC fields that carry contract types as JSON.
+var wirePayloads = []struct {
+	field string
+	value any
+}{
+	{"CreateRequest.class_json", VMClass{}},
+	{"CreateRequest.image_json", VMImage{}},
+	{"CreateRequest.networks_json", []NetworkAttachment{}},
+	{"CreateRequest.disks_json", []DiskSpec{}},
+	{"CreateRequest.placement_json", Placement{}},
+	{"CreateRequest.guest_customization_json", GuestCustomization{}},
+	{"ReconfigureRequest.desired_json", CreateRequest{}},
+	{"NetworkChange.attachment_json", NetworkAttachment{}},
+	{"CloneRequest.customization_json", CloneCustomization{}},
+	{"PublishImageRequest.options_json", PublishImageOptions{}},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of the contract
+// payloads at ContractVersion. x-payloads maps each RPC field to its
+// schema. docs/provider-contract.v1.schema.json is generated from it.
+func JSONSchema() ([]byte, error) {
+	defs := map[string]map[string]any{}
+	payloads := map[string]any{}
+	for _, p := range wirePayloads {
+		t := reflect.TypeOf(p.value)
+		s, err := typeSchema(t, defs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.field, err)
+		}
+		if t.Kind() == reflect.Struct {
+			defs[t.Name()]["properties"].(map[string]any)[ContractVersionKey] = map[string]any{"const": ContractVersion}
+		}
+		payloads[p.field] = s
+	}
+
+	out, err := json.MarshalIndent(map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"$comment":   "Generated by go generate ./internal/providers/contracts. DO NOT EDIT.",
+		"title":      "virtrigaud provider contract " + ContractVersion,
+		"x-payloads": payloads,
+		"$defs":      defs,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// typeSchema returns the schema of t, adding the structs it reaches to
+// defs. Pointers, slices and maps marshal as null when nil, so their
+// schemas admit null.
+func typeSchema(t reflect.Type, defs map[string]map[string]any) (map[string]any, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		s, err := typeSchema(t.Elem(), defs)
+		return nullable(s), err
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]any{"type": "string", "format": "date-time"}, nil
+		}
+		ref := map[string]any{"$ref": "#/$defs/" + t.Name()}
+		if _, ok := defs[t.Name()]; ok {
+			return ref, nil
+		}
+		properties := map[string]any{}
+		defs[t.Name()] = map[string]any{"type": "object", "properties": properties}
+		for i := 0; i < t.NumField(); i++ {
+			name := jsonFieldName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			s, err := typeSchema(t.Field(i).Type, defs)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name(), t.Field(i).Name, err)
+			}
+			properties[name] = s
+		}
+		return ref, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nullable(map[string]any{"type": "string", "contentEncoding": "base64"}), nil
+		}
+		items, err := typeSchema(t.Elem(), defs)
+		return nullable(map[string]any{"type": "array", "items": items}), err
+	case reflect.Map:
+		values, err := typeSchema(t.Elem(), defs)
+		return nullable(map[string]any{"type": "object", "additionalProperties": values}), err
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	default:
+		return nil, fmt.Errorf("no JSON schema for kind %s", t.Kind())
+	}
+}
+
+func nullable(s map[string]any) map[string]any {
+	return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+}
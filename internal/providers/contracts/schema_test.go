@@ -0,0 +1,55 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONSchema_CheckedIn fails when the contract types changed without
+// regenerating the schema providers vendor.
+func TestJSONSchema_CheckedIn(t *testing.T) {
+	generated, err := JSONSchema()
+	require.NoError(t, err)
+	checkedIn, err := os.ReadFile("../../../docs/provider-contract.v1.schema.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(checkedIn), "run go generate ./internal/providers/contracts")
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+	var doc struct {
+		Payloads map[string]json.RawMessage `json:"x-payloads"`
+		Defs     map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.JSONEq(t, `{"$ref":"#/$defs/VMClass"}`, string(doc.Payloads["CreateRequest.class_json"]))
+	assert.Len(t, doc.Payloads, len(wirePayloads))
+	assert.JSONEq(t, `{"const":"v1"}`, string(doc.Defs["VMClass"].Properties[ContractVersionKey]))
+	assert.NotContains(t, doc.Defs["DiskSpec"].Properties, ContractVersionKey, "array elements are not stamped")
+	assert.Contains(t, doc.Defs["Placement"].Properties, "ha")
+	assert.Contains(t, doc.Defs, "HAPlacement")
+}
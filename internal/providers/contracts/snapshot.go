@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// QuiesceOutcome is whether a snapshot was taken with the guest's
+// filesystems quiesced. It mirrors the provider.v1 QuiesceOutcome enum.
+type QuiesceOutcome string
+
+const (
+	// QuiesceUnknown is reported by providers that predate quiesce support.
+	QuiesceUnknown QuiesceOutcome = ""
+	// QuiesceAchieved means the guest's filesystems were frozen for the
+	// snapshot.
+	QuiesceAchieved QuiesceOutcome = "Achieved"
+	// QuiesceDegraded means quiesce was requested but not possible, and the
+	// snapshot is crash-consistent.
+	QuiesceDegraded QuiesceOutcome = "Degraded"
+	// QuiesceSkipped means quiesce was not requested, or not needed because
+	// the VM was off or its memory was captured.
+	QuiesceSkipped QuiesceOutcome = "Skipped"
+)
+
+// SnapshotInfo describes a snapshot that exists on the hypervisor. It
+// mirrors the provider.v1 SnapshotInfo message.
+type SnapshotInfo struct {
+	// ID is the provider snapshot ID, as returned by SnapshotCreate.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// CreatedAt is zero when the hypervisor does not record it.
+	CreatedAt time.Time `json:"createdAt"`
+	// HasMemory is true when the snapshot includes memory state.
+	HasMemory bool `json:"hasMemory"`
+	// ParentID is the ID of the snapshot this one was taken on top of, or
+	// empty for a root snapshot.
+	ParentID string `json:"parentID"`
+	// SizeBytes is the size of the snapshot's data: its memory state and
+	// disk deltas. Nil when the provider cannot compute it.
+	SizeBytes *int64 `json:"sizeBytes"`
+	// ConsumedBytes is the storage the snapshot occupies, which is less
+	// than SizeBytes on thin or deduplicating storage. Nil when unknown.
+	ConsumedBytes *int64 `json:"consumedBytes"`
+}
+
+// SnapshotLister is an optional capability of a Provider: it enumerates the
+// snapshots a VM actually has, including ones taken outside virtrigaud.
+// Callers type-assert a Provider to SnapshotLister; remote providers that
+// cannot list snapshots return a NotSupported error.
+type SnapshotLister interface {
+	SnapshotList(ctx context.Context, vmID string) ([]SnapshotInfo, error)
+}
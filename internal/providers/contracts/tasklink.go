@@ -0,0 +1,28 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+// TaskLinker is an optional capability of a Provider: it returns the link a
+// provider sent with a task it started, which opens the task in the
+// hypervisor's own UI. Callers type-assert a Provider to TaskLinker. The link
+// comes from the provider runtime, so callers check it against the
+// Provider's configured endpoint before recording it.
+type TaskLinker interface {
+	// TaskLink returns the link reported with taskRef, or "" when there was
+	// none or the task has since finished.
+	TaskLink(taskRef string) string
+}
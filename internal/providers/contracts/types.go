@@ -16,273 +16,368 @@ limitations under the License.
 
 package contracts
 
+import "time"
+
 // VMClass defines VM resource allocation (provider-agnostic)
 type VMClass struct {
 	// CPU specifies the number of virtual CPUs
-	CPU int32
+	CPU int32 `json:"cpu"`
 	// MemoryMiB specifies memory in MiB
-	MemoryMiB int32
-	// Firmware specifies the firmware type (BIOS/UEFI)
-	Firmware string
+	MemoryMiB int32 `json:"memoryMiB"`
+	// Firmware specifies the firmware type (BIOS/UEFI/UEFI-SecureBoot)
+	Firmware string `json:"firmware"`
+	// MachineType is an optional chipset hint such as q35
+	MachineType string `json:"machineType"`
+	// MemoryBalloon configures the guest memory balloon; nil leaves the
+	// provider default
+	MemoryBalloon *MemoryBalloon `json:"memoryBalloon"`
 	// DiskDefaults provides default disk settings
-	DiskDefaults *DiskDefaults
+	DiskDefaults *DiskDefaults `json:"diskDefaults"`
 	// GuestToolsPolicy specifies guest tools policy
-	GuestToolsPolicy string
+	GuestToolsPolicy string `json:"guestToolsPolicy"`
 	// ExtraConfig contains provider-specific configuration
-	ExtraConfig map[string]string
+	ExtraConfig map[string]string `json:"extraConfig"`
 	// PerformanceProfile defines performance-related settings
-	PerformanceProfile *PerformanceProfile
+	PerformanceProfile *PerformanceProfile `json:"performanceProfile"`
 	// SecurityProfile defines security-related settings
-	SecurityProfile *SecurityProfile
+	SecurityProfile *SecurityProfile `json:"securityProfile"`
 	// ResourceLimits defines resource limits and reservations
-	ResourceLimits *ResourceLimits
+	ResourceLimits *ResourceLimits `json:"resourceLimits"`
 }
 
 // VMImage defines the base template/image (provider-agnostic)
 type VMImage struct {
 	// TemplateName for vSphere templates
-	TemplateName string
+	TemplateName string `json:"templateName"`
 	// Path for local image files
-	Path string
+	Path string `json:"path"`
 	// URL for remote images
-	URL string
+	URL string `json:"url"`
 	// Format specifies image format
-	Format string
+	Format string `json:"format"`
 	// Checksum for verification
-	Checksum string
+	Checksum string `json:"checksum"`
 	// ChecksumType specifies algorithm
-	ChecksumType string
+	ChecksumType string `json:"checksumType"`
+	// LinkedClone asks for a disk that shares the template's or cached
+	// image's blocks instead of a full copy, where the storage allows it.
+	// Providers fall back to a full copy when it does not.
+	LinkedClone bool `json:"linkedClone"`
+	// ContentLibrary deploys from a vSphere content library item instead
+	// of cloning TemplateName
+	ContentLibrary *ContentLibraryItem `json:"contentLibrary"`
+}
+
+// ContentLibraryItem names a vSphere content library item (vSphere)
+type ContentLibraryItem struct {
+	// Library is the content library name
+	Library string `json:"library"`
+	// Item is the library item name
+	Item string `json:"item"`
+	// Version pins the item's content version; empty accepts any
+	Version string `json:"version"`
 }
 
 // NetworkAttachment defines network configuration (provider-agnostic)
 type NetworkAttachment struct {
 	// Name identifies the network
-	Name string
+	Name string `json:"name"`
 	// Portgroup for vSphere
-	Portgroup string
+	Portgroup string `json:"portgroup"`
 	// NetworkName for generic networks
-	NetworkName string
+	NetworkName string `json:"networkName"`
 	// Bridge for bridge networks
-	Bridge string
+	Bridge string `json:"bridge"`
 	// VLAN ID if applicable
-	VLAN int32
+	VLAN int32 `json:"vlan"`
 	// Model specifies network device model
-	Model string
+	Model string `json:"model"`
 	// MacAddress specifies static MAC
-	MacAddress string
+	MacAddress string `json:"macAddress"`
 	// IPPolicy specifies IP assignment
-	IPPolicy string
+	IPPolicy string `json:"ipPolicy"`
 	// StaticIP for static assignments
-	StaticIP string
+	StaticIP string `json:"staticIP"`
 	// Prefix specifies the network prefix length (e.g., 24 for /24)
-	Prefix int32
+	Prefix int32 `json:"prefix"`
 	// Gateway specifies the default gateway
-	Gateway string
+	Gateway string `json:"gateway"`
 	// DNS specifies DNS servers (comma-separated)
-	DNS string
+	DNS string `json:"dns"`
 	// PCISlotNumber specifies the PCI slot for predictable interface naming (vSphere)
 	// Common values: 192 for ens192, 224 for ens224, 256 for ens256
-	PCISlotNumber *int32
+	PCISlotNumber *int32 `json:"pciSlotNumber"`
+	// VNet names an SDN vnet to attach to instead of Bridge (Proxmox)
+	VNet string `json:"vnet"`
+	// Firewall enables the hypervisor firewall on the interface (Proxmox)
+	Firewall bool `json:"firewall"`
+	// SecurityGroups are firewall security groups applied to the interface (Proxmox)
+	SecurityGroups []string `json:"securityGroups"`
+	// StaticIPv6 is a static IPv6 address with its prefix length, e.g.
+	// 2001:db8::10/64, alongside or instead of StaticIP
+	StaticIPv6 string `json:"staticIPv6"`
+	// GatewayIPv6 specifies the IPv6 default gateway
+	GatewayIPv6 string `json:"gatewayIPv6"`
 }
 
 // DiskSpec defines disk requirements (provider-agnostic)
 type DiskSpec struct {
 	// SizeGiB specifies disk size in GiB
-	SizeGiB int32
+	SizeGiB int32 `json:"sizeGiB"`
 	// Type specifies disk type (thin, thick, etc.)
-	Type string
+	Type string `json:"type"`
 	// Name provides a name for the disk
-	Name string
+	Name string `json:"name"`
 }
 
 // DiskDefaults provides default disk settings
 type DiskDefaults struct {
 	// Type specifies the default disk type
-	Type string
+	Type string `json:"type"`
 	// SizeGiB specifies the default root disk size
-	SizeGiB int32
+	SizeGiB int32 `json:"sizeGiB"`
 }
 
 // UserData contains cloud-init/ignition configuration
 type UserData struct {
 	// CloudInitData contains the cloud-init configuration
-	CloudInitData string
+	CloudInitData string `json:"cloudInitData"`
 	// Type specifies the user data type (cloud-init, ignition, etc.)
-	Type string
+	Type string `json:"type"`
+	// NetworkConfig contains a cloud-init network-config document
+	NetworkConfig string `json:"networkConfig"`
+	// VendorData contains cloud-init vendor-data
+	VendorData string `json:"vendorData"`
+}
+
+// Guest customization types
+const (
+	// GuestCustomizationCloudInit customizes the guest with cloud-init from
+	// UserData and MetaData
+	GuestCustomizationCloudInit = "CloudInit"
+	// GuestCustomizationSysprep customizes a generalized Windows guest with
+	// an unattend.xml answer file
+	GuestCustomizationSysprep = "Sysprep"
+)
+
+// GuestCustomization selects how the guest OS is customized on first boot
+type GuestCustomization struct {
+	// Type is GuestCustomizationCloudInit or GuestCustomizationSysprep
+	Type string `json:"type"`
+	// Sysprep is set when Type is GuestCustomizationSysprep
+	Sysprep *SysprepCustomization `json:"sysprep"`
+}
+
+// SysprepCustomization is a resolved Sysprep customization
+type SysprepCustomization struct {
+	// UnattendXML is the complete unattend.xml answer file, either the
+	// user's own or one the controller generated from the basic fields
+	UnattendXML string `json:"unattendXML"`
 }
 
 // MetaData contains cloud-init metadata configuration
 type MetaData struct {
 	// MetaDataYAML contains the cloud-init metadata in YAML format
-	MetaDataYAML string
+	MetaDataYAML string `json:"metaDataYAML"`
 }
 
 // Placement provides VM placement hints
 type Placement struct {
 	// Datastore specifies preferred datastore
-	Datastore string
+	Datastore string `json:"datastore"`
 	// StoragePod specifies a vSphere Datastore Cluster for automatic datastore selection
-	StoragePod string
+	StoragePod string `json:"storagePod"`
 	// Cluster specifies preferred cluster
-	Cluster string
+	Cluster string `json:"cluster"`
 	// Folder specifies preferred folder
-	Folder string
+	Folder string `json:"folder"`
 	// Host specifies preferred host
-	Host string
+	Host string `json:"host"`
+	// Pool is the resource pool the VM joins (a PVE pool on Proxmox)
+	Pool string `json:"pool"`
+	// HA puts the VM under the hypervisor's HA manager when set
+	HA *HAPlacement `json:"ha"`
+	// AntiNodes lists hosts a new VM must not be created on
+	AntiNodes []string `json:"antiNodes"`
+}
+
+// HAPlacement configures high-availability management of a VM
+type HAPlacement struct {
+	// Group restricts the nodes the VM may be recovered to
+	Group string `json:"group"`
+	// State is the state the HA manager keeps the VM in
+	State string `json:"state"`
 }
 
 // TaskRef represents an asynchronous operation
 type TaskRef struct {
 	// ID is the task identifier
-	ID string
+	ID string `json:"id"`
 	// Provider specifies which provider owns the task
-	Provider string
+	Provider string `json:"provider"`
 	// Type specifies the operation type
-	Type string
+	Type string `json:"type"`
+	// Link opens the task in the hypervisor's UI, as the provider reported
+	// it; empty when there is none
+	Link string `json:"link"`
 }
 
 // TaskStatus represents the status of an async task
 type TaskStatus struct {
 	// IsCompleted indicates if the task is done
-	IsCompleted bool
+	IsCompleted bool `json:"isCompleted"`
 	// Error contains error message if task failed
-	Error string
+	Error string `json:"error"`
 	// Message contains status message
-	Message string
+	Message string `json:"message"`
 }
 
 // SnapshotCreateRequest defines snapshot creation request
 type SnapshotCreateRequest struct {
 	// VmId is the VM identifier
-	VmId string
+	VmId string `json:"vmId"`
 	// NameHint provides a name suggestion for the snapshot
-	NameHint string
+	NameHint string `json:"nameHint"`
 	// Description provides context for the snapshot
-	Description string
+	Description string `json:"description"`
 	// IncludeMemory indicates whether to include memory state
-	IncludeMemory bool
+	IncludeMemory bool `json:"includeMemory"`
 	// Quiesce indicates whether to quiesce the filesystem
-	Quiesce bool
+	Quiesce bool `json:"quiesce"`
+	// AllowUnquiesced lets a Quiesce request the guest agent cannot honor
+	// produce a crash-consistent snapshot instead of failing
+	AllowUnquiesced bool `json:"allowUnquiesced"`
 }
 
 // SnapshotCreateResponse contains the result of snapshot creation
 type SnapshotCreateResponse struct {
 	// SnapshotId is the provider-specific snapshot identifier
-	SnapshotId string
+	SnapshotId string `json:"snapshotId"`
 	// Task references an async operation if applicable
-	Task *TaskRef
+	Task *TaskRef `json:"task"`
+	// SizeBytes and ConsumedBytes are as in SnapshotInfo. Nil when unknown,
+	// including while Task is still running.
+	SizeBytes     *int64 `json:"sizeBytes"`
+	ConsumedBytes *int64 `json:"consumedBytes"`
+	// CreatedAt is the creation time on the hypervisor; zero when unknown.
+	CreatedAt time.Time `json:"createdAt"`
+	// Quiesce is whether the guest's filesystems were quiesced; empty when
+	// the provider does not report it. QuiesceMessage says why it was
+	// degraded or skipped.
+	Quiesce        QuiesceOutcome `json:"quiesce"`
+	QuiesceMessage string         `json:"quiesceMessage"`
 }
 
 // ExportDiskRequest defines a disk export request for migration
 type ExportDiskRequest struct {
 	// VmId is the VM identifier
-	VmId string
+	VmId string `json:"vmId"`
 	// DiskId identifies which disk to export (empty = primary disk)
-	DiskId string
+	DiskId string `json:"diskId"`
 	// SnapshotId specifies a snapshot to export from (optional)
-	SnapshotId string
+	SnapshotId string `json:"snapshotId"`
 	// DestinationURL where to upload the disk (S3, HTTP, etc.)
-	DestinationURL string
+	DestinationURL string `json:"destinationURL"`
 	// Format specifies the desired export format (qcow2, vmdk, raw)
-	Format string
+	Format string `json:"format"`
 	// Compress enables compression during export
-	Compress bool
+	Compress bool `json:"compress"`
 	// Credentials for accessing the destination
-	Credentials map[string]string
+	Credentials map[string]string `json:"credentials"`
 	// BackendType names the staging backend ("pvc", "nfs", "s3"); empty means
 	// the legacy pvc path (ADR-0006 Slice 0).
-	BackendType string
+	BackendType string `json:"backendType"`
 	// TransferMode selects the transfer path ("auto", "relay", "direct");
 	// empty/"auto" defers to the provider (ADR-0006 Slice 0).
-	TransferMode string
+	TransferMode string `json:"transferMode"`
 	// StorageOptionsJSON carries backend-specific options as JSON (ADR-0006
 	// Slice 0: always empty).
-	StorageOptionsJSON string
+	StorageOptionsJSON string `json:"storageOptionsJSON"`
 }
 
 // ExportDiskResponse contains the result of a disk export operation
 type ExportDiskResponse struct {
 	// ExportId is the export operation identifier
-	ExportId string
+	ExportId string `json:"exportId"`
 	// TaskRef references an async operation if applicable
-	TaskRef string
+	TaskRef string `json:"taskRef"`
 	// EstimatedSizeBytes is the estimated size of the export
-	EstimatedSizeBytes int64
+	EstimatedSizeBytes int64 `json:"estimatedSizeBytes"`
 	// Checksum is the SHA256 checksum of the exported disk
-	Checksum string
+	Checksum string `json:"checksum"`
 }
 
 // ImportDiskRequest defines a disk import request for migration
 type ImportDiskRequest struct {
 	// SourceURL where to download the disk from (S3, HTTP, etc.)
-	SourceURL string
+	SourceURL string `json:"sourceURL"`
 	// StorageHint suggests target storage location (datastore, pool, etc.)
-	StorageHint string
+	StorageHint string `json:"storageHint"`
 	// Format specifies the source disk format (qcow2, vmdk, raw)
-	Format string
+	Format string `json:"format"`
 	// TargetName is the name for the imported disk
-	TargetName string
+	TargetName string `json:"targetName"`
 	// VerifyChecksum enables checksum verification after import
-	VerifyChecksum bool
+	VerifyChecksum bool `json:"verifyChecksum"`
 	// ExpectedChecksum is the expected SHA256 checksum
-	ExpectedChecksum string
+	ExpectedChecksum string `json:"expectedChecksum"`
 	// Credentials for accessing the source
-	Credentials map[string]string
+	Credentials map[string]string `json:"credentials"`
 	// BackendType names the staging backend ("pvc", "nfs", "s3"); empty means
 	// the legacy pvc path (ADR-0006 Slice 0).
-	BackendType string
+	BackendType string `json:"backendType"`
 	// TransferMode selects the transfer path ("auto", "relay", "direct");
 	// empty/"auto" defers to the provider (ADR-0006 Slice 0).
-	TransferMode string
+	TransferMode string `json:"transferMode"`
 	// StorageOptionsJSON carries backend-specific options as JSON (ADR-0006
 	// Slice 0: always empty).
-	StorageOptionsJSON string
+	StorageOptionsJSON string `json:"storageOptionsJSON"`
 }
 
 // ImportDiskResponse contains the result of a disk import operation
 type ImportDiskResponse struct {
 	// DiskId is the imported disk identifier
-	DiskId string
+	DiskId string `json:"diskId"`
 	// Path to the imported disk in provider storage
-	Path string
+	Path string `json:"path"`
 	// TaskRef references an async operation if applicable
-	TaskRef string
+	TaskRef string `json:"taskRef"`
 	// ActualSizeBytes is the actual size of the imported disk
-	ActualSizeBytes int64
+	ActualSizeBytes int64 `json:"actualSizeBytes"`
 	// Checksum is the SHA256 checksum of the imported disk
-	Checksum string
+	Checksum string `json:"checksum"`
 }
 
 // GetDiskInfoRequest defines a request for disk information
 type GetDiskInfoRequest struct {
 	// VmId is the VM identifier
-	VmId string
+	VmId string `json:"vmId"`
 	// DiskId identifies which disk (empty = primary disk)
-	DiskId string
+	DiskId string `json:"diskId"`
 	// SnapshotId gets info for a specific snapshot (optional)
-	SnapshotId string
+	SnapshotId string `json:"snapshotId"`
 }
 
 // GetDiskInfoResponse contains detailed disk information
 type GetDiskInfoResponse struct {
 	// DiskId is the disk identifier
-	DiskId string
+	DiskId string `json:"diskId"`
 	// Format is the disk format (qcow2, vmdk, raw)
-	Format string
+	Format string `json:"format"`
 	// VirtualSizeBytes is the virtual size (capacity)
-	VirtualSizeBytes int64
+	VirtualSizeBytes int64 `json:"virtualSizeBytes"`
 	// ActualSizeBytes is the actual size (allocated)
-	ActualSizeBytes int64
+	ActualSizeBytes int64 `json:"actualSizeBytes"`
 	// Path is the path or location of the disk
-	Path string
+	Path string `json:"path"`
 	// IsBootable indicates if this is a boot disk
-	IsBootable bool
+	IsBootable bool `json:"isBootable"`
 	// Snapshots lists available snapshots for this disk
-	Snapshots []string
+	Snapshots []string `json:"snapshots"`
 	// BackingFile is the backing file (for linked clones)
-	BackingFile string
+	BackingFile string `json:"backingFile"`
 	// Metadata contains additional provider-specific metadata
-	Metadata map[string]string
+	Metadata map[string]string `json:"metadata"`
 }
 
 // PowerState represents VM power states
@@ -302,57 +397,71 @@ const (
 // IPAddress represents an assigned IP address
 type IPAddress struct {
 	// IP is the IP address
-	IP string
+	IP string `json:"ip"`
 	// Type specifies the IP type (IPv4, IPv6)
-	Type string
+	Type string `json:"type"`
 	// Source specifies how the IP was assigned (DHCP, static, etc.)
-	Source string
+	Source string `json:"source"`
 }
 
 // PerformanceProfile defines performance-related settings
 type PerformanceProfile struct {
 	// LatencySensitivity configures latency sensitivity
-	LatencySensitivity string
+	LatencySensitivity string `json:"latencySensitivity"`
 	// CPUHotAddEnabled allows adding CPUs while VM is running
-	CPUHotAddEnabled bool
+	CPUHotAddEnabled bool `json:"cpuHotAddEnabled"`
 	// MemoryHotAddEnabled allows adding memory while VM is running
-	MemoryHotAddEnabled bool
+	MemoryHotAddEnabled bool `json:"memoryHotAddEnabled"`
 	// VirtualizationBasedSecurity enables VBS features
-	VirtualizationBasedSecurity bool
+	VirtualizationBasedSecurity bool `json:"virtualizationBasedSecurity"`
 	// NestedVirtualization enables nested virtualization
-	NestedVirtualization bool
+	NestedVirtualization bool `json:"nestedVirtualization"`
 	// HyperThreadingPolicy controls hyperthreading usage
-	HyperThreadingPolicy string
+	HyperThreadingPolicy string `json:"hyperThreadingPolicy"`
+	// HugePages is the huge page size backing guest memory ("2Mi", "1Gi",
+	// "any"); empty disables huge pages
+	HugePages string `json:"hugePages"`
+}
+
+// MemoryBalloon configures the guest memory balloon (provider-agnostic)
+type MemoryBalloon struct {
+	// Enabled attaches a balloon device
+	Enabled bool `json:"enabled"`
+	// MinimumMiB is the floor automatic reclaim may shrink the guest to;
+	// nil means the class memory
+	MinimumMiB *int32 `json:"minimumMiB"`
+	// Shares weighs the VM when the hypervisor reclaims memory
+	Shares *int32 `json:"shares"`
 }
 
 // SecurityProfile defines security-related settings
 type SecurityProfile struct {
 	// SecureBoot enables secure boot functionality
-	SecureBoot bool
+	SecureBoot bool `json:"secureBoot"`
 	// TPMEnabled enables TPM (Trusted Platform Module)
-	TPMEnabled bool
+	TPMEnabled bool `json:"tpmEnabled"`
 	// TPMVersion specifies the TPM version
-	TPMVersion string
+	TPMVersion string `json:"tpmVersion"`
 	// VTDEnabled enables Intel VT-d or AMD-Vi
-	VTDEnabled bool
+	VTDEnabled bool `json:"vtdEnabled"`
 	// EncryptionEnabled indicates if encryption should be used
-	EncryptionEnabled bool
+	EncryptionEnabled bool `json:"encryptionEnabled"`
 	// KeyProvider specifies the encryption key provider
-	KeyProvider string
+	KeyProvider string `json:"keyProvider"`
 	// RequireEncryption mandates encryption (fails if not available)
-	RequireEncryption bool
+	RequireEncryption bool `json:"requireEncryption"`
 }
 
 // ResourceLimits defines resource limits and reservations
 type ResourceLimits struct {
 	// CPULimit is the maximum CPU usage limit (in MHz or percentage)
-	CPULimit *int32
+	CPULimit *int32 `json:"cpuLimit"`
 	// CPUReservation is the guaranteed CPU allocation (in MHz)
-	CPUReservation *int32
+	CPUReservation *int32 `json:"cpuReservation"`
 	// MemoryLimitMiB is the maximum memory usage limit in MiB
-	MemoryLimitMiB *int32
+	MemoryLimitMiB *int32 `json:"memoryLimitMiB"`
 	// MemoryReservationMiB is the guaranteed memory allocation in MiB
-	MemoryReservationMiB *int32
+	MemoryReservationMiB *int32 `json:"memoryReservationMiB"`
 	// CPUShares defines the relative CPU priority (higher = more priority)
-	CPUShares *int32
+	CPUShares *int32 `json:"cpuShares"`
 }
@@ -0,0 +1,160 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ContractVersion is the version of the JSON encoding of the contract types
+// carried in the *_json fields of provider RPCs. It changes only when a
+// field is renamed or removed; adding a field does not bump it.
+const ContractVersion = "v1"
+
+// ContractVersionKey is the key under which MarshalPayload records
+// ContractVersion in every JSON object payload.
+const ContractVersionKey = "contractVersion"
+
+// MarshalPayload encodes a contract value for a *_json field of a provider
+// RPC. Object payloads are stamped with ContractVersion. Array payloads
+// (networks, disks) are not, and are read at the version of the other
+// payloads of the same request.
+func MarshalPayload(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(data) < 2 || data[0] != '{' {
+		return data, err
+	}
+	stamped := fmt.Sprintf(`{%q:%q`, ContractVersionKey, ContractVersion)
+	if data[1] != '}' {
+		stamped += ","
+	}
+	return append([]byte(stamped), data[1:]...), nil
+}
+
+// UnmarshalPayload decodes a *_json field of a provider RPC into v. A
+// payload stamped with a newer ContractVersion is rejected. An unstamped
+// payload comes from a manager that predates ContractVersion and keyed
+// fields by their Go names; those keys are renamed to the json tags of v's
+// type before decoding.
+func UnmarshalPayload(data []byte, v any) error {
+	var header struct {
+		ContractVersion string `json:"contractVersion"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		_ = json.Unmarshal(trimmed, &header)
+	}
+	switch header.ContractVersion {
+	case ContractVersion:
+	case "":
+		data = legacyPayload(data, reflect.TypeOf(v))
+	default:
+		return fmt.Errorf("unsupported provider contract version %q (supported: %s)", header.ContractVersion, ContractVersion)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// legacyPayload renames the Go field name keys of an unstamped payload to
+// the json tags of t, recursing into nested structs, slices and map values.
+// Map keys are data, not field names, and are left alone. Anything that
+// does not parse as the shape t expects is returned unchanged for
+// json.Unmarshal to report.
+//
+// Remove once managers older than ContractVersion v1 are unsupported.
+func legacyPayload(data []byte, t reflect.Type) []byte {
+	if t == nil {
+		return data
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+			return data
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonFieldName(field)
+			if name == "" {
+				continue
+			}
+			value, ok := obj[name]
+			if !ok && field.Name != name {
+				if value, ok = obj[field.Name]; ok {
+					delete(obj, field.Name)
+				}
+			}
+			if ok {
+				obj[name] = legacyPayload(value, field.Type)
+			}
+		}
+		return remarshal(obj, data)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return data
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil || items == nil {
+			return data
+		}
+		for i := range items {
+			items[i] = legacyPayload(items[i], t.Elem())
+		}
+		return remarshal(items, data)
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+			return data
+		}
+		for k, value := range obj {
+			obj[k] = legacyPayload(value, t.Elem())
+		}
+		return remarshal(obj, data)
+	default:
+		return data
+	}
+}
+
+func remarshal(v any, fallback []byte) []byte {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fallback
+	}
+	return out
+}
+
+// jsonFieldName returns the JSON key of a struct field, or "" when the
+// field is unexported or excluded from JSON.
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
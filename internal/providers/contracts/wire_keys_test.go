@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// TestWireKeys pins the JSON keys of every contract type. A failure here
+// means the wire format changed: renaming or removing a key needs a new
+// ContractVersion and a provider-side shim, adding one needs this table
+// and the checked-in schema updated.
+func TestWireKeys(t *testing.T) {
+	tests := []struct {
+		value any
+		keys  []string
+	}{
+		{contracts.Capabilities{}, []string{"supportsReconfigureOnline", "supportsDiskExpansionOnline", "supportsSnapshots", "supportsMemorySnapshots", "supportsLinkedClones", "supportsImageImport", "supportedDiskTypes", "supportedNetworkTypes", "supportsDiskExport", "supportsDiskImport", "supportedExportFormats", "supportedImportFormats", "supportsExportCompression", "supportedExportBackends", "supportedImportBackends", "supportedTransferModes", "supportsConsoleOutput", "supportsSysprep", "supportsCloudInitStatus", "supportsLiveMigration", "minProtocolVersion", "maxProtocolVersion", "supportsConsoleProxy", "supportsImagePublish", "supportsEventWatch", "supportsQuiescedSnapshots", "capabilityManifest"}},
+		{contracts.HostCapacity{}, []string{"name", "cpuCores", "cpuUtilization", "memoryTotalBytes", "memoryUsedBytes", "storageTotalBytes", "storageUsedBytes"}},
+		{contracts.CloneRequest{}, []string{"sourceVmID", "targetName", "linked", "classJSON", "placementJSON", "customizeJSON", "customization"}},
+		{contracts.CloneCustomization{}, []string{"hostname", "domain", "userData", "networks"}},
+		{contracts.CloneNetworkOverride{}, []string{"index", "name", "dhcp", "ipAddress", "prefix", "gateway", "dns", "macAddress"}},
+		{contracts.CloneResponse{}, []string{"targetVmID", "taskRef", "customizationApplied", "customizationError"}},
+		{contracts.CloudInitStatus{}, []string{"status", "errors", "recoverableErrors", "detail"}},
+		{contracts.ConsoleOutput{}, []string{"output", "truncated", "source"}},
+		{contracts.DescribeBatchResponse{}, []string{"results", "errors"}},
+		{contracts.ProviderError{}, []string{"type", "message", "retryable"}},
+		{contracts.ImagePrepareRequest{}, []string{"imageJSON", "targetName", "storageHint"}},
+		{contracts.ImagePrepareResponse{}, []string{"taskRef", "preparedImageID", "preparedImagePath"}},
+		{contracts.PublishImageOptions{}, []string{"snapshotID", "cleanup", "contentLibrary", "storage"}},
+		{contracts.PublishImageRequest{}, []string{"vmID", "imageName", "options", "overwrite"}},
+		{contracts.PublishImageResponse{}, []string{"taskRef", "imageID", "imagePath", "checksum", "checksumType"}},
+		{contracts.ProviderInfo{}, []string{"providerVersion", "gitSHA", "hypervisorProduct", "hypervisorVersion", "hypervisorDetails"}},
+		{contracts.CreateRequest{}, []string{"name", "class", "image", "networks", "disks", "userData", "metaData", "guestCustomization", "placement", "tags", "idempotencyKey"}},
+		{contracts.CreateResponse{}, []string{"id", "taskRef", "providerRaw"}},
+		{contracts.DescribeResponse{}, []string{"exists", "powerState", "ips", "consoleURL", "providerRaw", "guestStats", "addresses", "identity", "details"}},
+		{contracts.GuestAddress{}, []string{"ip", "interface"}},
+		{contracts.GuestStats{}, []string{"cpuUsagePercent", "memoryUsageBytes", "uptimeSeconds"}},
+		{contracts.VMInfo{}, []string{"id", "name", "powerState", "ips", "cpu", "memoryMiB", "disks", "networks", "providerRaw"}},
+		{contracts.DiskInfo{}, []string{"id", "path", "sizeGiB", "format"}},
+		{contracts.NetworkInfo{}, []string{"name", "mac", "ipAddress"}},
+		{contracts.Int32Change{}, []string{"old", "new"}},
+		{contracts.Int64Change{}, []string{"old", "new"}},
+		{contracts.DiskExpansion{}, []string{"name", "oldSizeGiB", "newSizeGiB"}},
+		{contracts.SecurityGroupChange{}, []string{"network", "groups"}},
+		{contracts.ChangeSet{}, []string{"cpu", "memoryMiB", "disks", "networksAdded", "networksRemoved", "securityGroups"}},
+		{contracts.ReconfigureResult{}, []string{"taskRef", "powerCycleRequired"}},
+		{contracts.SnapshotInfo{}, []string{"id", "name", "description", "createdAt", "hasMemory", "parentID", "sizeBytes", "consumedBytes"}},
+		{contracts.VMClass{}, []string{"cpu", "memoryMiB", "firmware", "machineType", "memoryBalloon", "diskDefaults", "guestToolsPolicy", "extraConfig", "performanceProfile", "securityProfile", "resourceLimits"}},
+		{contracts.VMImage{}, []string{"templateName", "path", "url", "format", "checksum", "checksumType", "linkedClone", "contentLibrary"}},
+		{contracts.ContentLibraryItem{}, []string{"library", "item", "version"}},
+		{contracts.NetworkAttachment{}, []string{"name", "portgroup", "networkName", "bridge", "vlan", "model", "macAddress", "ipPolicy", "staticIP", "prefix", "gateway", "dns", "pciSlotNumber", "vnet", "firewall", "securityGroups", "staticIPv6", "gatewayIPv6"}},
+		{contracts.DiskSpec{}, []string{"sizeGiB", "type", "name"}},
+		{contracts.DiskDefaults{}, []string{"type", "sizeGiB"}},
+		{contracts.UserData{}, []string{"cloudInitData", "type", "networkConfig", "vendorData"}},
+		{contracts.GuestCustomization{}, []string{"type", "sysprep"}},
+		{contracts.SysprepCustomization{}, []string{"unattendXML"}},
+		{contracts.MetaData{}, []string{"metaDataYAML"}},
+		{contracts.Placement{}, []string{"datastore", "storagePod", "cluster", "folder", "host", "pool", "ha", "antiNodes"}},
+		{contracts.HAPlacement{}, []string{"group", "state"}},
+		{contracts.TaskRef{}, []string{"id", "provider", "type", "link"}},
+		{contracts.TaskStatus{}, []string{"isCompleted", "error", "message"}},
+		{contracts.SnapshotCreateRequest{}, []string{"vmId", "nameHint", "description", "includeMemory", "quiesce", "allowUnquiesced"}},
+		{contracts.SnapshotCreateResponse{}, []string{"snapshotId", "task", "sizeBytes", "consumedBytes", "createdAt", "quiesce", "quiesceMessage"}},
+		{contracts.ExportDiskRequest{}, []string{"vmId", "diskId", "snapshotId", "destinationURL", "format", "compress", "credentials", "backendType", "transferMode", "storageOptionsJSON"}},
+		{contracts.ExportDiskResponse{}, []string{"exportId", "taskRef", "estimatedSizeBytes", "checksum"}},
+		{contracts.ImportDiskRequest{}, []string{"sourceURL", "storageHint", "format", "targetName", "verifyChecksum", "expectedChecksum", "credentials", "backendType", "transferMode", "storageOptionsJSON"}},
+		{contracts.ImportDiskResponse{}, []string{"diskId", "path", "taskRef", "actualSizeBytes", "checksum"}},
+		{contracts.GetDiskInfoRequest{}, []string{"vmId", "diskId", "snapshotId"}},
+		{contracts.GetDiskInfoResponse{}, []string{"diskId", "format", "virtualSizeBytes", "actualSizeBytes", "path", "isBootable", "snapshots", "backingFile", "metadata"}},
+		{contracts.IPAddress{}, []string{"ip", "type", "source"}},
+		{contracts.PerformanceProfile{}, []string{"latencySensitivity", "cpuHotAddEnabled", "memoryHotAddEnabled", "virtualizationBasedSecurity", "nestedVirtualization", "hyperThreadingPolicy", "hugePages"}},
+		{contracts.MemoryBalloon{}, []string{"enabled", "minimumMiB", "shares"}},
+		{contracts.SecurityProfile{}, []string{"secureBoot", "tpmEnabled", "tpmVersion", "vtdEnabled", "encryptionEnabled", "keyProvider", "requireEncryption"}},
+		{contracts.HypervisorEvent{}, []string{"vmID", "severity", "reason", "message", "time", "resolved"}},
+		{contracts.ResourceLimits{}, []string{"cpuLimit", "cpuReservation", "memoryLimitMiB", "memoryReservationMiB", "cpuShares"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.value), func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			var obj map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(data, &obj))
+
+			got := make([]string, 0, len(obj))
+			for k := range obj {
+				got = append(got, k)
+			}
+			want := append([]string(nil), tt.keys...)
+			sort.Strings(got)
+			sort.Strings(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
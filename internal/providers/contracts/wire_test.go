@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalPayload(t *testing.T) {
+	data, err := MarshalPayload(VMImage{TemplateName: "ubuntu"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contractVersion":"v1","templateName":"ubuntu","path":"","url":"","format":"","checksum":"","checksumType":"","linkedClone":false,"contentLibrary":null}`, string(data))
+
+	data, err = MarshalPayload(struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"contractVersion":"v1"}`, string(data))
+
+	data, err = MarshalPayload([]DiskSpec{{Name: "data", SizeGiB: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"sizeGiB":10,"type":"","name":"data"}]`, string(data), "arrays are not stamped")
+
+	data, err = MarshalPayload((*Placement)(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestUnmarshalPayload_RoundTrip(t *testing.T) {
+	want := CreateRequest{
+		Name: "web-1",
+		Class: VMClass{
+			CPU:                4,
+			MemoryMiB:          8192,
+			PerformanceProfile: &PerformanceProfile{CPUHotAddEnabled: true},
+		},
+		Networks:  []NetworkAttachment{{Name: "lan", VNet: "tenant1", SecurityGroups: []string{"web"}}},
+		Placement: &Placement{Pool: "prod", HA: &HAPlacement{State: "started"}},
+	}
+	data, err := MarshalPayload(want)
+	require.NoError(t, err)
+
+	var got CreateRequest
+	require.NoError(t, UnmarshalPayload(data, &got))
+	assert.Equal(t, want, got)
+}
+
+// TestUnmarshalPayload_Legacy — an unstamped payload keyed by Go field
+// names, as sent by managers predating ContractVersion, decodes into the
+// tagged types, including nested structs and slice elements. Map keys are
+// data and keep their case.
+func TestUnmarshalPayload_Legacy(t *testing.T) {
+	legacy := `{
+		"Name": "web-1",
+		"Class": {
+			"CPU": 4,
+			"MemoryMiB": 8192,
+			"ExtraConfig": {"CPU": "keep", "Firmware": "keep"},
+			"PerformanceProfile": {"CPUHotAddEnabled": true}
+		},
+		"Networks": [{"Name": "lan", "VNet": "tenant1", "PCISlotNumber": 192}],
+		"Placement": {"Pool": "prod", "HA": {"Group": "g1"}}
+	}`
+
+	var got CreateRequest
+	require.NoError(t, UnmarshalPayload([]byte(legacy), &got))
+	assert.Equal(t, "web-1", got.Name)
+	assert.Equal(t, int32(4), got.Class.CPU)
+	assert.Equal(t, int32(8192), got.Class.MemoryMiB)
+	assert.Equal(t, map[string]string{"CPU": "keep", "Firmware": "keep"}, got.Class.ExtraConfig)
+	require.NotNil(t, got.Class.PerformanceProfile)
+	assert.True(t, got.Class.PerformanceProfile.CPUHotAddEnabled)
+	require.Len(t, got.Networks, 1)
+	assert.Equal(t, "tenant1", got.Networks[0].VNet)
+	require.NotNil(t, got.Networks[0].PCISlotNumber)
+	assert.Equal(t, int32(192), *got.Networks[0].PCISlotNumber)
+	require.NotNil(t, got.Placement)
+	assert.Equal(t, "prod", got.Placement.Pool)
+	require.NotNil(t, got.Placement.HA)
+	assert.Equal(t, "g1", got.Placement.HA.Group)
+
+	// The shim follows the json tags of whatever type is decoded into.
+	var sizing struct {
+		CPU int32 `json:"cpu"`
+	}
+	require.NoError(t, UnmarshalPayload([]byte(`{"CPU":2}`), &sizing))
+	assert.Equal(t, int32(2), sizing.CPU)
+}
+
+func TestUnmarshalPayload_Errors(t *testing.T) {
+	var class VMClass
+	err := UnmarshalPayload([]byte(`{"contractVersion":"v2","cpu":2}`), &class)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"v2"`)
+
+	assert.Error(t, UnmarshalPayload([]byte(`not json`), &class))
+	assert.Error(t, UnmarshalPayload([]byte(`{"cpu":"two"}`), &class))
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// baseImagePrefix starts the name of every pool volume holding a cached
+// base image. volumeOwner does not claim these, so the orphan volume sweep
+// leaves them alone.
+const baseImagePrefix = "virtrigaud-base-"
+
+// baseImageVolumeName returns the name of the pool volume imageURL is
+// cached in. It is derived from the URL alone, so every Create from the
+// same image, and a provider restarted mid-burst, finds the same volume.
+func baseImageVolumeName(imageURL string) string {
+	sum := sha256.Sum256([]byte(imageURL))
+	return baseImagePrefix + hex.EncodeToString(sum[:8]) + ".qcow2"
+}
+
+// baseImageCache makes concurrent Creates from one image share a single
+// preparation of its base volume instead of each downloading the image.
+// The zero value is ready to use.
+type baseImageCache struct {
+	mu      sync.Mutex
+	flights map[string]*baseImageFlight
+}
+
+type baseImageFlight struct {
+	done chan struct{}
+	path string
+	err  error
+}
+
+// get returns the path of the base volume key, calling prepare for it
+// unless another caller already is, in which case it waits for that
+// caller's result. A failure is not remembered: the next caller prepares
+// again.
+func (c *baseImageCache) get(ctx context.Context, key string, prepare func() (string, error)) (string, error) {
+	c.mu.Lock()
+	if f, ok := c.flights[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.path, f.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	f := &baseImageFlight{done: make(chan struct{})}
+	if c.flights == nil {
+		c.flights = make(map[string]*baseImageFlight)
+	}
+	c.flights[key] = f
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+		close(f.done)
+	}()
+	f.path, f.err = prepare()
+	return f.path, f.err
+}
+
+// ensureBaseImage returns the path of the pool volume caching imageURL,
+// downloading it the first time any VM asks for it. The download lands in
+// a partial volume that is renamed once complete, so an interrupted
+// download is never mistaken for a usable base.
+func (p *Provider) ensureBaseImage(ctx context.Context, storage *StorageProvider, imageURL, poolName string) (string, error) {
+	name := baseImageVolumeName(imageURL)
+	return p.baseImages.get(ctx, name, func() (string, error) {
+		if vol, err := storage.GetVolumeInfo(ctx, poolName, name); err == nil && vol.Path != "" {
+			log.Printf("INFO Reusing cached base image %s for %s", vol.Path, imageURL)
+			return vol.Path, nil
+		}
+
+		partial := strings.TrimSuffix(name, ".qcow2") + "-partial"
+		vol, err := storage.DownloadCloudImage(ctx, imageURL, partial, poolName, 0)
+		if err != nil {
+			return "", err
+		}
+		path := filepath.Join(filepath.Dir(vol.Path), name)
+		if res, err := p.virshProvider.runVirshCommand(ctx, "!", "mv", "-f", vol.Path, path); err != nil {
+			return "", fmt.Errorf("failed to move base image into place: %w, output: %s", err, commandStderr(res))
+		}
+		if _, err := p.virshProvider.runVirshCommand(ctx, "pool-refresh", poolName); err != nil {
+			log.Printf("WARN Failed to refresh storage pool: %v", err)
+		}
+		log.Printf("INFO Cached base image %s for %s", path, imageURL)
+		return path, nil
+	})
+}
+
+// linkableImageFormat reports whether a local image of format can back an
+// overlay disk. The provider only layers on qcow2; an unset format is the
+// VMImage default, qcow2.
+func linkableImageFormat(format string) bool {
+	return format == "" || format == "qcow2"
+}
+
+// overlayDiskArgs is the qemu-img command creating target as a qcow2
+// overlay on base, grown to sizeGB when set.
+func overlayDiskArgs(base, target string, sizeGB int) []string {
+	args := []string{"qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", base, target}
+	if sizeGB > 0 {
+		args = append(args, fmt.Sprintf("%dG", sizeGB))
+	}
+	return args
+}
+
+// diskFromBaseImage creates the VM disk volumeName from the qcow2 image at
+// base. A linked disk is an overlay sharing base's blocks, which takes
+// moments however large the image; if the overlay cannot be made, or
+// linked is unset, the disk is a full copy of base.
+func (p *Provider) diskFromBaseImage(ctx context.Context, storage *StorageProvider, base, volumeName, poolName string, sizeGB int, linked bool) (*StorageVolume, error) {
+	poolInfo, err := storage.GetPoolInfo(ctx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool info: %w", err)
+	}
+	name := volumeName + ".qcow2"
+	target := filepath.Join(poolInfo.Path, name)
+
+	created := false
+	if linked {
+		res, err := p.virshProvider.runVirshCommand(ctx, append([]string{"!"}, overlayDiskArgs(base, target, sizeGB)...)...)
+		if err == nil {
+			created = true
+		} else {
+			log.Printf("WARN Failed to create overlay on %s, falling back to a full copy: %v, output: %s", base, err, commandStderr(res))
+			_, _ = p.virshProvider.runVirshCommand(ctx, "!", "rm", "-f", target)
+		}
+	}
+	if !created {
+		if res, err := p.virshProvider.runVirshCommand(ctx, "!", "qemu-img", "convert", "-f", "qcow2", "-O", "qcow2", base, target); err != nil {
+			return nil, fmt.Errorf("failed to copy base image: %w, output: %s", err, commandStderr(res))
+		}
+		if sizeGB > 0 {
+			if res, err := p.virshProvider.runVirshCommand(ctx, "!", "qemu-img", "resize", target, fmt.Sprintf("%dG", sizeGB)); err != nil {
+				return nil, fmt.Errorf("failed to resize image: %w, output: %s", err, commandStderr(res))
+			}
+		}
+	}
+
+	// Same ownership and labelling as a downloaded disk gets.
+	if _, err := p.virshProvider.runVirshCommand(ctx, "!", "sudo", "chown", "libvirt-qemu:kvm", target); err != nil {
+		log.Printf("WARN Failed to set ownership: %v", err)
+	}
+	if _, err := p.virshProvider.runVirshCommand(ctx, "!", "sudo", "chmod", "777", target); err != nil {
+		log.Printf("WARN Failed to set permissions: %v", err)
+	}
+	if _, err := p.virshProvider.runVirshCommand(ctx, "!", "sudo", "restorecon", target); err != nil {
+		log.Printf("WARN Failed to restore SELinux context (may not be using SELinux): %v", err)
+	}
+	if _, err := p.virshProvider.runVirshCommand(ctx, "pool-refresh", poolName); err != nil {
+		log.Printf("WARN Failed to refresh storage pool: %v", err)
+	}
+
+	return &StorageVolume{Name: name, Pool: poolName, Path: target, Format: "qcow2", Type: "file"}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestBaseImageVolumeName(t *testing.T) {
+	name := baseImageVolumeName("https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img")
+	assert.True(t, strings.HasPrefix(name, baseImagePrefix))
+	assert.True(t, strings.HasSuffix(name, ".qcow2"))
+	assert.Equal(t, name, baseImageVolumeName("https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img"))
+	assert.NotEqual(t, name, baseImageVolumeName("https://cloud-images.ubuntu.com/jammy/current/jammy-server-cloudimg-amd64.img"))
+
+	// The orphan sweep must never take a cached base for a VM's disk.
+	_, owned := volumeOwner(name)
+	assert.False(t, owned)
+}
+
+func TestBaseImageCache_PreparesOnce(t *testing.T) {
+	var c baseImageCache
+	var prepared atomic.Int32
+	gate := make(chan struct{})
+	prepare := func() (string, error) {
+		prepared.Add(1)
+		<-gate
+		return "/var/lib/libvirt/images/base.qcow2", nil
+	}
+
+	var wg sync.WaitGroup
+	paths := make([]string, 20)
+	for i := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			path, err := c.get(context.Background(), "base", prepare)
+			assert.NoError(t, err)
+			paths[i] = path
+		}()
+	}
+	// While the first preparation runs, every other caller waits for it
+	// rather than starting its own.
+	require.Eventually(t, func() bool { return prepared.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), prepared.Load())
+	close(gate)
+	wg.Wait()
+
+	for _, path := range paths {
+		assert.Equal(t, "/var/lib/libvirt/images/base.qcow2", path)
+	}
+}
+
+func TestBaseImageCache_FailureIsNotRemembered(t *testing.T) {
+	var c baseImageCache
+	_, err := c.get(context.Background(), "base", func() (string, error) { return "", errors.New("download failed") })
+	require.Error(t, err)
+
+	path, err := c.get(context.Background(), "base", func() (string, error) { return "/images/base.qcow2", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "/images/base.qcow2", path)
+}
+
+func TestOverlayDiskArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", "/images/base.qcow2", "/images/web-disk.qcow2", "40G"},
+		overlayDiskArgs("/images/base.qcow2", "/images/web-disk.qcow2", 40))
+	assert.NotContains(t, overlayDiskArgs("/images/base.qcow2", "/images/web-disk.qcow2", 0), "0G")
+
+	assert.True(t, linkableImageFormat(""))
+	assert.True(t, linkableImageFormat("qcow2"))
+	assert.False(t, linkableImageFormat("raw"))
+}
+
+func TestServerCreate_BusyOverCloneLimit(t *testing.T) {
+	s := &Server{provider: &fakeDescribeProvider{}, clones: common.NewCloneLimiter(1, 0)}
+	release, err := s.clones.Acquire()
+	require.NoError(t, err)
+	defer release()
+
+	_, err = s.Create(context.Background(), &providerv1.CreateRequest{Name: "web"})
+	require.Error(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+}
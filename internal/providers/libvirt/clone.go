@@ -166,14 +166,19 @@ func (p *Provider) Clone(ctx context.Context, req contracts.CloneRequest) (contr
 	// Apply best-effort CPU/memory overrides from ClassJSON.
 	targetXML = applyClassOverrides(targetXML, req.ClassJSON)
 
-	// CustomizeJSON (hostname / cloud-init) is intentionally NOT applied in this
-	// MVP: a faithful implementation requires regenerating the nocloud ISO and
-	// re-pointing the CD-ROM, which is a follow-up. Log loudly so callers do not
-	// mistake a clone for a customized VM (issue #153).
-	if req.CustomizeJSON != "" {
-		log.Printf("WARN Clone of %s: CustomizeJSON provided but not applied by the libvirt provider (MVP); "+
-			"the clone inherits the source's hostname/cloud-init. Tracked as a follow-up to issue #153.",
-			req.TargetName)
+	// Customization goes into the domain before it is defined. A failure
+	// leaves the clone defined uncustomized, and reported as such, rather
+	// than discarding the copied disk.
+	resp := contracts.CloneResponse{TargetVmID: req.TargetName}
+	if req.Customization != nil {
+		customizedXML, cerr := p.customizeClone(ctx, req.TargetName, targetXML, req.Customization)
+		if cerr != nil {
+			log.Printf("WARN Clone of %s: customization failed, defining it uncustomized: %v", req.TargetName, cerr)
+			resp.CustomizationError = cerr.Error()
+		} else {
+			targetXML = customizedXML
+			resp.CustomizationApplied = true
+		}
 	}
 
 	if err := p.createDomainDefinition(ctx, req.TargetName, targetXML); err != nil {
@@ -186,9 +191,7 @@ func (p *Provider) Clone(ctx context.Context, req contracts.CloneRequest) (contr
 	log.Printf("INFO Successfully cloned VM %s -> %s (linked=%t)", req.SourceVmID, req.TargetName, req.Linked)
 
 	// virsh define is synchronous; no TaskRef. The clone is left powered off.
-	return contracts.CloneResponse{
-		TargetVmID: req.TargetName,
-	}, nil
+	return resp, nil
 }
 
 // resolvePrimaryDisk returns the source domain's primary (boot) disk path and
@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// reDevicesEnd matches the closing </devices> tag, before which a cloud-init
+// CD-ROM is added to a clone whose source has none.
+var reDevicesEnd = regexp.MustCompile(`</devices>`)
+
+// customizeClone applies c to a clone's domain XML before it is defined: NIC
+// MACs are overridden and a fresh NoCloud ISO, carrying the clone's hostname,
+// user-data and network-config, replaces the source's. Cloud-init sees a new
+// instance-id and so applies it on first boot.
+//
+// User-data not given in c is carried over from the source, which works for
+// sources created by this provider (their ISO directory keeps user-data).
+func (p *Provider) customizeClone(ctx context.Context, targetName, domainXML string, c *contracts.CloneCustomization) (string, error) {
+	out, err := applyCloneMACs(domainXML, c.Networks)
+	if err != nil {
+		return "", err
+	}
+	d, err := parseDomainXML(out)
+	if err != nil {
+		return "", err
+	}
+	sourceISO := cloudInitISOSource(d)
+
+	userData := c.UserData
+	if userData == "" {
+		if filepath.Base(sourceISO) != "cloud-init.iso" {
+			return "", fmt.Errorf("the source VM's user-data cannot be carried over; set spec.customization.userData")
+		}
+		res, err := p.virshProvider.runVirshCommand(ctx, "!", "cat", filepath.Join(filepath.Dir(sourceISO), "user-data"))
+		if err != nil {
+			return "", fmt.Errorf("read the source VM's user-data (set spec.customization.userData instead): %w", err)
+		}
+		userData = res.Stdout
+	}
+
+	macs := make([]string, len(d.Devices.Interfaces))
+	for i, nic := range d.Devices.Interfaces {
+		macs[i] = nic.MAC.Address
+	}
+	networkConfig, err := c.NetworkConfig(macs)
+	if err != nil {
+		return "", err
+	}
+
+	hostname := c.Hostname
+	if hostname == "" {
+		hostname = targetName
+	}
+	if c.Domain != "" {
+		hostname += "." + c.Domain
+	}
+	isoPath, err := NewCloudInitProvider(p.virshProvider).PrepareCloudInit(ctx, CloudInitConfig{
+		UserData:      userData,
+		NetworkConfig: networkConfig,
+		InstanceID:    targetName,
+		Hostname:      hostname,
+	})
+	if err != nil {
+		return "", err
+	}
+	return pointCloudInitISO(out, sourceISO, isoPath), nil
+}
+
+// applyCloneMACs sets the MAC of each overridden NIC, counting <mac>
+// elements in document order, which is NIC order.
+func applyCloneMACs(domainXML string, overrides []contracts.CloneNetworkOverride) (string, error) {
+	macs := map[int]string{}
+	for _, o := range overrides {
+		if o.MACAddress != "" {
+			macs[o.Index] = o.MACAddress
+		}
+	}
+	if len(macs) == 0 {
+		return domainXML, nil
+	}
+	nics := reMACAddress.FindAllStringIndex(domainXML, -1)
+	for index := range macs {
+		if index >= len(nics) {
+			return "", fmt.Errorf("NIC %d has a MAC override, but the clone has %d NICs", index, len(nics))
+		}
+	}
+	i := 0
+	return reMACAddress.ReplaceAllStringFunc(domainXML, func(element string) string {
+		defer func() { i++ }()
+		if mac, ok := macs[i]; ok {
+			return fmt.Sprintf("<mac address='%s'/>", mac)
+		}
+		return element
+	}), nil
+}
+
+// cloudInitISOSource returns the path of the domain's cloud-init CD-ROM, or
+// "" when it has none.
+func cloudInitISOSource(d *domainXML) string {
+	for _, disk := range d.Devices.Disks {
+		path := disk.Source.File
+		if disk.Device == "cdrom" && (strings.HasSuffix(path, "-cidata.iso") || strings.HasSuffix(path, "cloud-init.iso")) {
+			return path
+		}
+	}
+	return ""
+}
+
+// pointCloudInitISO re-points the CD-ROM at sourceISO to isoPath, or adds a
+// CD-ROM for isoPath when sourceISO is empty.
+func pointCloudInitISO(domainXML, sourceISO, isoPath string) string {
+	if sourceISO != "" {
+		out := strings.Replace(domainXML, fmt.Sprintf("file='%s'", sourceISO), fmt.Sprintf("file='%s'", isoPath), 1)
+		return strings.Replace(out, fmt.Sprintf("file=\"%s\"", sourceISO), fmt.Sprintf("file=\"%s\"", isoPath), 1)
+	}
+	cdrom := fmt.Sprintf(`  <disk type='file' device='cdrom'>
+      <driver name='qemu' type='raw'/>
+      <source file='%s'/>
+      <target dev='sdz' bus='sata'/>
+      <readonly/>
+    </disk>
+  </devices>`, isoPath)
+	return replaceFirst(reDevicesEnd, domainXML, cdrom)
+}
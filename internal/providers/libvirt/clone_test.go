@@ -414,3 +414,37 @@ func TestClone_RequiredFields(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "target name is required")
 }
+
+func TestApplyCloneMACs(t *testing.T) {
+	out, err := applyCloneMACs(sourceDomainXML, []contracts.CloneNetworkOverride{{Index: 0, MACAddress: "52:54:00:11:22:33"}})
+	require.NoError(t, err)
+	assert.Contains(t, out, "<mac address='52:54:00:11:22:33'/>")
+	assert.NotContains(t, out, "52:54:00:aa:bb:cc")
+
+	out, err = applyCloneMACs(sourceDomainXML, []contracts.CloneNetworkOverride{{Index: 0, DHCP: true}})
+	require.NoError(t, err)
+	assert.Equal(t, sourceDomainXML, out, "overrides without a MAC leave the XML alone")
+
+	_, err = applyCloneMACs(sourceDomainXML, []contracts.CloneNetworkOverride{{Index: 1, MACAddress: "52:54:00:11:22:33"}})
+	assert.ErrorContains(t, err, "the clone has 1 NICs")
+}
+
+func TestPointCloudInitISO(t *testing.T) {
+	d, err := parseDomainXML(sourceDomainXML)
+	require.NoError(t, err)
+	source := cloudInitISOSource(d)
+	assert.Equal(t, "/var/lib/libvirt/images/vm-source-cidata.iso", source)
+
+	out := pointCloudInitISO(sourceDomainXML, source, "/var/lib/libvirt/cloud-init/vm-target/cloud-init.iso")
+	assert.Contains(t, out, "<source file='/var/lib/libvirt/cloud-init/vm-target/cloud-init.iso'/>")
+	assert.NotContains(t, out, source)
+
+	noCDROM := strings.Replace(sourceDomainXML, "device='cdrom'", "device='floppy'", 1)
+	d, err = parseDomainXML(noCDROM)
+	require.NoError(t, err)
+	assert.Empty(t, cloudInitISOSource(d))
+	out = pointCloudInitISO(noCDROM, "", "/var/lib/libvirt/cloud-init/vm-target/cloud-init.iso")
+	assert.Contains(t, out, "<source file='/var/lib/libvirt/cloud-init/vm-target/cloud-init.iso'/>")
+	assert.Contains(t, out, "<target dev='sdz' bus='sata'/>")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(out), "</devices>\n</domain>"))
+}
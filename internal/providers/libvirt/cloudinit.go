@@ -19,19 +19,42 @@ package libvirt
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
-	"time"
 )
 
 // CloudInitConfig represents cloud-init configuration for libvirt VMs
 type CloudInitConfig struct {
-	UserData   string // YAML cloud-init configuration
-	MetaData   string // Instance metadata (JSON)
-	InstanceID string // Unique instance identifier
-	Hostname   string // VM hostname
+	UserData      string // YAML cloud-init configuration
+	MetaData      string // Instance metadata (JSON)
+	NetworkConfig string // network-config (version 1 or 2), optional
+	VendorData    string // vendor-data, optional
+	InstanceID    string // Unique instance identifier
+	Hostname      string // VM hostname
+}
+
+// noCloudFiles returns the files of the NoCloud ISO, keyed by name.
+// network-config and vendor-data are only written when given: an empty
+// network-config file would disable networking rather than fall back to the
+// network section of meta-data.
+func (config CloudInitConfig) noCloudFiles() map[string]string {
+	files := map[string]string{
+		"user-data": config.UserData,
+		"meta-data": config.MetaData,
+	}
+	if config.NetworkConfig != "" {
+		files["network-config"] = config.NetworkConfig
+	}
+	if config.VendorData != "" {
+		files["vendor-data"] = config.VendorData
+	}
+	return files
 }
 
 // CloudInitProvider manages cloud-init ISO creation and attachment for libvirt
@@ -66,21 +89,14 @@ func (c *CloudInitProvider) PrepareCloudInit(ctx context.Context, config CloudIn
 	}
 	log.Printf("DEBUG Generated meta-data (length=%d): %s", len(config.MetaData), config.MetaData)
 
-	// Write user-data file remotely
-	userDataPath := filepath.Join(remoteDir, "user-data")
-	if err := c.writeRemoteFile(ctx, userDataPath, config.UserData); err != nil {
-		return "", fmt.Errorf("failed to write remote user-data: %w", err)
-	}
-
-	// Write meta-data file remotely
-	metaDataPath := filepath.Join(remoteDir, "meta-data")
-	if err := c.writeRemoteFile(ctx, metaDataPath, config.MetaData); err != nil {
-		return "", fmt.Errorf("failed to write remote meta-data: %w", err)
+	// Write user-data, meta-data and the optional documents remotely
+	if err := c.writeRemoteFiles(ctx, remoteDir, config.noCloudFiles()); err != nil {
+		return "", err
 	}
 
 	// Create cloud-init ISO using genisoimage (NoCloud datasource) on remote host
 	isoPath := filepath.Join(remoteDir, "cloud-init.iso")
-	if err := c.createRemoteCloudInitISO(ctx, remoteDir, isoPath); err != nil {
+	if err := c.createRemoteISO(ctx, remoteDir, isoPath, "cidata"); err != nil {
 		return "", fmt.Errorf("failed to create remote cloud-init ISO: %w", err)
 	}
 
@@ -88,29 +104,88 @@ func (c *CloudInitProvider) PrepareCloudInit(ctx context.Context, config CloudIn
 	return isoPath, nil
 }
 
-// writeRemoteFile writes content to a file on the remote libvirt host
-func (c *CloudInitProvider) writeRemoteFile(ctx context.Context, remotePath, content string) error {
-	// Use cat with heredoc to write content to remote file (handles multiline content)
-	// This approach avoids shell escaping issues with printf
-	heredocMarker := "EOF_CLOUDINIT_" + fmt.Sprintf("%d", time.Now().UnixNano())
-	command := fmt.Sprintf("cat > '%s' << '%s'\n%s\n%s", remotePath, heredocMarker, content, heredocMarker)
+// sysprepFiles returns the files of the Sysprep ISO. Windows Setup looks for
+// Unattend.xml on read/write media and Autounattend.xml on read-only media,
+// so the answer file is written under both names.
+func sysprepFiles(unattendXML string) map[string]string {
+	return map[string]string{
+		"unattend.xml":     unattendXML,
+		"autounattend.xml": unattendXML,
+	}
+}
 
-	_, err := c.virshProvider.runVirshCommand(ctx, "!", "bash", "-c", command)
-	if err != nil {
+// PrepareSysprep writes a Windows answer file to an ISO on the libvirt host
+// and returns its path. The ISO lives where a cloud-init ISO would, under the
+// same name, so the domain's config-drive handling (disk listing, cleanup on
+// delete) covers it unchanged.
+func (c *CloudInitProvider) PrepareSysprep(ctx context.Context, instanceID, unattendXML string) (string, error) {
+	log.Printf("INFO Preparing sysprep answer file for instance: %s", instanceID)
+
+	remoteDir := fmt.Sprintf("/tmp/virtrigaud-cloudinit/%s", instanceID)
+	if _, err := c.virshProvider.runVirshCommand(ctx, "!", "mkdir", "-p", remoteDir); err != nil {
+		return "", fmt.Errorf("failed to create remote sysprep directory: %w", err)
+	}
+	if err := c.writeRemoteFiles(ctx, remoteDir, sysprepFiles(unattendXML)); err != nil {
+		return "", err
+	}
+
+	isoPath := filepath.Join(remoteDir, "cloud-init.iso")
+	if err := c.createRemoteISO(ctx, remoteDir, isoPath, "UNATTEND"); err != nil {
+		return "", fmt.Errorf("failed to create remote sysprep ISO: %w", err)
+	}
+
+	log.Printf("INFO Successfully created remote sysprep ISO: %s", isoPath)
+	return isoPath, nil
+}
+
+// writeRemoteFiles writes files into dir on the remote libvirt host
+func (c *CloudInitProvider) writeRemoteFiles(ctx context.Context, dir string, files map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := c.writeRemoteFile(ctx, filepath.Join(dir, name), files[name]); err != nil {
+			return fmt.Errorf("failed to write remote %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeRemoteFile writes content to a file on the remote libvirt host. The
+// content goes over stdin: as part of the command line, a document over the
+// kernel's 128 KiB limit on one argument, e.g. user-data with embedded
+// files, would fail to run at all.
+func (c *CloudInitProvider) writeRemoteFile(ctx context.Context, remotePath, content string) error {
+	if err := runHostStdin(ctx, c.virshProvider, strings.NewReader(content), "cat > "+shellQuote(remotePath)); err != nil {
 		return fmt.Errorf("failed to write remote file %s: %w", remotePath, err)
 	}
 
-	log.Printf("DEBUG Wrote remote file: %s", remotePath)
+	log.Printf("DEBUG Wrote remote file: %s (%d bytes)", remotePath, len(content))
+	return nil
+}
+
+// runHostStdin runs command in a shell on the libvirt host with r as its
+// stdin: over SSH for an ssh:// URI, locally otherwise.
+func runHostStdin(ctx context.Context, vp *VirshProvider, r io.Reader, command string) error {
+	if strings.Contains(vp.uri, "ssh://") {
+		return runSSHStdin(ctx, vp, r, command)
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = vp.env
+	cmd.Stdin = r
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w (stderr: %s)", err, strings.TrimSpace(stderr.String()))
+	}
 	return nil
 }
 
-// createRemoteCloudInitISO creates an ISO9660 filesystem with cloud-init files on remote host
-func (c *CloudInitProvider) createRemoteCloudInitISO(ctx context.Context, sourceDir, isoPath string) error {
-	// Use genisoimage to create NoCloud datasource ISO on remote host
-	// The ISO must contain user-data and meta-data files at the root
+// createRemoteISO creates an ISO9660 filesystem from sourceDir on remote host
+func (c *CloudInitProvider) createRemoteISO(ctx context.Context, sourceDir, isoPath, volid string) error {
+	// Use genisoimage to create the ISO on remote host. A NoCloud datasource
+	// ISO must use volume ID "cidata" and contain user-data and meta-data at
+	// the root, plus network-config and vendor-data when given
 	result, err := c.virshProvider.runVirshCommand(ctx, "!", "genisoimage",
 		"-output", isoPath,
-		"-volid", "cidata", // Volume ID for NoCloud datasource
+		"-volid", volid,
 		"-joliet", // Enable Joliet extensions
 		"-rock",   // Enable Rock Ridge extensions
 		"-input-charset", "utf-8",
@@ -120,7 +195,7 @@ func (c *CloudInitProvider) createRemoteCloudInitISO(ctx context.Context, source
 		return fmt.Errorf("genisoimage failed on remote host: %w, output: %s", err, result.Stderr)
 	}
 
-	log.Printf("DEBUG Created remote cloud-init ISO with genisoimage: %s", isoPath)
+	log.Printf("DEBUG Created remote ISO with genisoimage: %s", isoPath)
 	return nil
 }
 
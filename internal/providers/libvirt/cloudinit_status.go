@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// guestAgentChannel is the virtio-serial target name of the QEMU guest agent
+// in a domain's XML.
+const guestAgentChannel = "org.qemu.guest_agent.0"
+
+// guestExecResult is the decoded return of guest-exec-status.
+type guestExecResult struct {
+	Exited   bool
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// parseGuestExecStatus decodes a guest-exec-status reply. The agent
+// base64-encodes the captured output.
+func parseGuestExecStatus(raw string) (guestExecResult, error) {
+	var resp struct {
+		Return struct {
+			Exited   bool   `json:"exited"`
+			ExitCode int    `json:"exitcode"`
+			OutData  string `json:"out-data"`
+			ErrData  string `json:"err-data"`
+		} `json:"return"`
+	}
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		return guestExecResult{}, fmt.Errorf("failed to parse guest-exec-status response: %w", err)
+	}
+	stdout, err := base64.StdEncoding.DecodeString(resp.Return.OutData)
+	if err != nil {
+		return guestExecResult{}, fmt.Errorf("failed to decode guest command output: %w", err)
+	}
+	stderr, err := base64.StdEncoding.DecodeString(resp.Return.ErrData)
+	if err != nil {
+		return guestExecResult{}, fmt.Errorf("failed to decode guest command error output: %w", err)
+	}
+	return guestExecResult{
+		Exited:   resp.Return.Exited,
+		ExitCode: resp.Return.ExitCode,
+		Stdout:   string(stdout),
+		Stderr:   string(stderr),
+	}, nil
+}
+
+// agentCommand runs one QEMU guest agent command against a domain. The JSON
+// is passed through a quoted heredoc so neither the local shell nor the ssh
+// remote shell interprets it.
+func (g *GuestAgentProvider) agentCommand(ctx context.Context, domainName string, command interface{}) (string, error) {
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return "", err
+	}
+	heredocCmd := fmt.Sprintf("virsh qemu-agent-command %s \"$(cat <<'EOF'\n%s\nEOF\n)\"", domainName, payload)
+	result, err := g.virshProvider.runVirshCommand(ctx, "!", "bash", "-c", heredocCmd)
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, nil
+}
+
+// runShell runs script with /bin/sh in the guest and waits up to wait for it
+// to exit. Unlike ExecuteGuestCommand, a non-zero exit code is returned to
+// the caller rather than turned into an error.
+func (g *GuestAgentProvider) runShell(ctx context.Context, domainName, script string, wait time.Duration) (guestExecResult, error) {
+	out, err := g.agentCommand(ctx, domainName, map[string]interface{}{
+		"execute": "guest-exec",
+		"arguments": map[string]interface{}{
+			"path":           "/bin/sh",
+			"arg":            []string{"-c", script},
+			"capture-output": true,
+		},
+	})
+	if err != nil {
+		return guestExecResult{}, fmt.Errorf("guest-exec failed: %w", err)
+	}
+	var started struct {
+		Return struct {
+			PID int `json:"pid"`
+		} `json:"return"`
+	}
+	if err := json.Unmarshal([]byte(out), &started); err != nil {
+		return guestExecResult{}, fmt.Errorf("failed to parse guest-exec response: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		out, err := g.agentCommand(ctx, domainName, map[string]interface{}{
+			"execute":   "guest-exec-status",
+			"arguments": map[string]int{"pid": started.Return.PID},
+		})
+		if err != nil {
+			return guestExecResult{}, fmt.Errorf("guest-exec-status failed: %w", err)
+		}
+		result, err := parseGuestExecStatus(out)
+		if err != nil || result.Exited || time.Now().After(deadline) {
+			return result, err
+		}
+		select {
+		case <-ctx.Done():
+			return guestExecResult{}, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// getCloudInitStatus runs `cloud-init status` in the guest through the QEMU
+// guest agent. A domain defined without the agent channel cannot report it;
+// an agent that does not answer yet is expected early in boot and reported
+// as Unavailable so the caller keeps asking.
+func (p *Provider) getCloudInitStatus(ctx context.Context, domainName string) (*providerv1.GetCloudInitStatusResponse, error) {
+	xml, err := p.virshProvider.runVirshCommand(ctx, "dumpxml", domainName)
+	if err != nil {
+		return nil, errors.NewNotFound("domain", domainName)
+	}
+	if !strings.Contains(xml.Stdout, guestAgentChannel) {
+		return nil, errors.NewNotSupported("domain %s has no QEMU guest agent channel", domainName)
+	}
+
+	ga := NewGuestAgentProvider(p.virshProvider)
+	if !ga.isGuestAgentAvailable(ctx, domainName) {
+		return nil, errors.NewUnavailable("QEMU guest agent", fmt.Errorf("no response from the guest agent of domain %s", domainName))
+	}
+
+	// Allow a few seconds beyond the in-guest timeout for the agent round trips.
+	result, err := ga.runShell(ctx, domainName, common.CloudInitStatusCommand, (common.CloudInitWaitSeconds+5)*time.Second)
+	if err != nil {
+		return nil, errors.NewUnavailable("QEMU guest agent", err)
+	}
+	if !result.Exited {
+		return &providerv1.GetCloudInitStatusResponse{
+			Status: "running",
+			Detail: "cloud-init status did not return in time",
+		}, nil
+	}
+	return common.ParseCloudInitStatus(result.ExitCode, result.Stdout, result.Stderr)
+}
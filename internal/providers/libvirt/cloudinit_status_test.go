@@ -0,0 +1,41 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGuestExecStatus(t *testing.T) {
+	// {"status": "done"} and "warn" base64-encoded, as the agent returns them.
+	result, err := parseGuestExecStatus(`{"return":{"exitcode":2,"err-data":"d2Fybg==","out-data":"eyJzdGF0dXMiOiAiZG9uZSJ9","exited":true}}`)
+	require.NoError(t, err)
+	assert.True(t, result.Exited)
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, `{"status": "done"}`, result.Stdout)
+	assert.Equal(t, "warn", result.Stderr)
+
+	result, err = parseGuestExecStatus(`{"return":{"exited":false}}`)
+	require.NoError(t, err)
+	assert.False(t, result.Exited)
+
+	_, err = parseGuestExecStatus(`{"return":{"exited":true,"out-data":"%%%"}}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestCloudInitConfig_NoCloudFiles(t *testing.T) {
+	base := CloudInitConfig{UserData: "#cloud-config", MetaData: "instance-id: vm"}
+	assert.Equal(t, map[string]string{
+		"user-data": "#cloud-config",
+		"meta-data": "instance-id: vm",
+	}, base.noCloudFiles(), "no empty network-config is written")
+
+	full := base
+	full.NetworkConfig = "version: 2"
+	full.VendorData = "#cloud-config\nntp: {}"
+	files := full.noCloudFiles()
+	assert.Equal(t, "version: 2", files["network-config"])
+	assert.Equal(t, "#cloud-config\nntp: {}", files["vendor-data"])
+}
+
+func TestServer_ParseCreateRequest_CloudInitDocuments(t *testing.T) {
+	s := &Server{}
+
+	req, err := s.parseCreateRequest(&providerv1.CreateRequest{
+		Name:          "vm",
+		NetworkConfig: []byte("version: 2"),
+		VendorData:    []byte("#cloud-config"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, req.UserData, "network-config alone still carries cloud-init")
+	assert.Empty(t, req.UserData.CloudInitData)
+	assert.Equal(t, "version: 2", req.UserData.NetworkConfig)
+	assert.Equal(t, "#cloud-config", req.UserData.VendorData)
+
+	req, err = s.parseCreateRequest(&providerv1.CreateRequest{Name: "vm"})
+	require.NoError(t, err)
+	assert.Nil(t, req.UserData)
+}
+
+func TestSysprepFiles(t *testing.T) {
+	files := sysprepFiles("<unattend/>")
+	assert.Equal(t, "<unattend/>", files["unattend.xml"])
+	assert.Equal(t, "<unattend/>", files["autounattend.xml"])
+	assert.NotContains(t, files, "user-data", "a sysprep ISO carries no cloud-init documents")
+}
+
+func TestServer_ParseCreateRequest_Sysprep(t *testing.T) {
+	s := &Server{}
+
+	req, err := s.parseCreateRequest(&providerv1.CreateRequest{
+		Name:                   "win",
+		GuestCustomizationJson: `{"Type":"Sysprep","Sysprep":{"UnattendXML":"<unattend/>"}}`,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, req.GuestCustomization)
+	assert.Equal(t, "<unattend/>", req.GuestCustomization.Sysprep.UnattendXML)
+
+	req, err = s.parseCreateRequest(&providerv1.CreateRequest{Name: "vm", GuestCustomizationJson: `{"Type":"CloudInit"}`})
+	require.NoError(t, err)
+	assert.Nil(t, req.GuestCustomization)
+}
+
+func TestCloudInitProvider_WriteRemoteFileLargeDocument(t *testing.T) {
+	// Over the kernel's 128 KiB limit on a single argument, so the content
+	// only arrives if it is streamed rather than put on the command line.
+	content := "#cloud-config\n" + strings.Repeat("# padding line for a large user-data document\n", 300<<10/47)
+	require.Greater(t, len(content), 128<<10)
+
+	c := &CloudInitProvider{virshProvider: &VirshProvider{uri: "qemu:///system"}}
+	path := filepath.Join(t.TempDir(), "user-data")
+	require.NoError(t, c.writeRemoteFile(context.Background(), path, content))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
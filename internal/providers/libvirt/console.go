@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+const (
+	// serialLogDir is where libvirtd writes the serial0 log file configured in
+	// the generated domain XML. It is the qemu driver's own log directory, so
+	// it exists and is writable by libvirtd (and covered by its AppArmor/SELinux
+	// profile) on every supported distribution.
+	serialLogDir = "/var/log/libvirt/qemu"
+
+	// defaultConsoleTailLines is used when the caller passes tailLines <= 0.
+	defaultConsoleTailLines = 200
+
+	// maxConsoleTailLines caps a single request so a chatty guest cannot push
+	// megabytes of console text through a gRPC response.
+	maxConsoleTailLines = 5000
+)
+
+// serialLogPath returns the host path of the serial0 log for a domain.
+func serialLogPath(domainName string) string {
+	return path.Join(serialLogDir, domainName+"-serial0.log")
+}
+
+// clampConsoleTailLines normalises a requested tail length to the provider
+// default and upper bound.
+func clampConsoleTailLines(tailLines int32) int {
+	switch {
+	case tailLines <= 0:
+		return defaultConsoleTailLines
+	case tailLines > maxConsoleTailLines:
+		return maxConsoleTailLines
+	default:
+		return int(tailLines)
+	}
+}
+
+// tailConsoleOutput trims raw to the last n lines. raw is expected to hold at
+// most n+1 lines (the caller reads one extra line) so that truncation can be
+// detected without a second round-trip to count the file.
+func tailConsoleOutput(raw string, n int) (string, bool) {
+	lines := strings.SplitAfter(raw, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= n {
+		return strings.Join(lines, ""), false
+	}
+	return strings.Join(lines[len(lines)-n:], ""), true
+}
+
+// getConsoleOutput reads the tail of the domain's serial0 log from the
+// (possibly remote) libvirt host. Domains defined before serial logging was
+// added to the generated XML have no log file; that is reported as NotFound
+// rather than as an empty console so the caller can tell the two apart.
+func (p *Provider) getConsoleOutput(ctx context.Context, domainName string, tailLines int32) (contracts.ConsoleOutput, error) {
+	if p.virshProvider == nil {
+		return contracts.ConsoleOutput{}, fmt.Errorf("libvirt provider not initialized")
+	}
+
+	// Domain names are Kubernetes object names (DNS-1123), so the path needs no
+	// quoting on either the local exec or the ssh remote-command path.
+	logPath := serialLogPath(domainName)
+	if _, err := p.virshProvider.runVirshCommand(ctx, "!", "test", "-r", logPath); err != nil {
+		return contracts.ConsoleOutput{}, contracts.NewNotFoundError(
+			fmt.Sprintf("no serial console log for domain %s at %s (domain may predate serial logging)", domainName, logPath), err)
+	}
+
+	n := clampConsoleTailLines(tailLines)
+	result, err := p.virshProvider.runVirshCommand(ctx, "!", "tail", "-n", strconv.Itoa(n+1), logPath)
+	if err != nil {
+		return contracts.ConsoleOutput{}, fmt.Errorf("failed to read serial console log %s: %w", logPath, err)
+	}
+
+	output, truncated := tailConsoleOutput(result.Stdout, n)
+	return contracts.ConsoleOutput{
+		Output:    output,
+		Truncated: truncated,
+		Source:    logPath,
+	}, nil
+}
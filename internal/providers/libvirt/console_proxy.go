@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+	"google.golang.org/grpc"
+)
+
+// consoleRelayChunk is the largest frame a ConsoleRelay response carries.
+const consoleRelayChunk = 32 << 10
+
+// ConsoleTicket reports the console of a running domain. The generated
+// domain XML binds VNC to the host's loopback address without a password,
+// so the console is only reachable through ConsoleRelay and the ticket
+// carries nothing but the protocol.
+func (s *Server) ConsoleTicket(ctx context.Context, req *providerv1.ConsoleTicketRequest) (*providerv1.ConsoleTicketResponse, error) {
+	p, ok := s.providerFor(ctx, req.Id).(*Provider)
+	if !ok || p == nil || p.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+	if _, err := p.getVNCPort(ctx, req.Id); err != nil {
+		return nil, errors.NewUnavailable("VNC console of "+req.Id, err)
+	}
+	return &providerv1.ConsoleTicketResponse{Protocol: contracts.ConsoleProtocolVNC}, nil
+}
+
+// ConsoleRelay carries a domain's VNC stream. The first request names the
+// domain; the port is looked up then, so a domain restarted since the
+// ticket was issued is still reached. The relay lasts until either side
+// closes.
+func (s *Server) ConsoleRelay(stream grpc.BidiStreamingServer[providerv1.ConsoleRelayRequest, providerv1.ConsoleRelayResponse]) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	p, ok := s.providerFor(ctx, first.Id).(*Provider)
+	if !ok || p == nil || p.virshProvider == nil {
+		return fmt.Errorf("libvirt provider not initialized")
+	}
+	port, err := p.getVNCPort(ctx, first.Id)
+	if err != nil {
+		return errors.NewUnavailable("VNC console of "+first.Id, err)
+	}
+	conn, err := p.virshProvider.dialVNC(ctx, port)
+	if err != nil {
+		return errors.NewUnavailable("VNC console of "+first.Id, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	go func() {
+		// The client's side ends the relay by closing its send direction;
+		// closing conn then unblocks the read loop below.
+		defer func() { _ = conn.Close() }()
+		if len(first.Data) > 0 {
+			if _, err := conn.Write(first.Data); err != nil {
+				return
+			}
+		}
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if _, err := conn.Write(req.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, consoleRelayChunk)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&providerv1.ConsoleRelayResponse{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err != nil {
+			// The end of the VNC stream, or conn closed by the client, ends
+			// the relay cleanly.
+			return nil
+		}
+	}
+}
+
+// dialVNC connects to a VNC port on the libvirt host's loopback address:
+// directly for a local URI, and through an `ssh -W` tunnel for a qemu+ssh
+// one. Other remote transports cannot reach the host's loopback address.
+func (v *VirshProvider) dialVNC(ctx context.Context, port int) (io.ReadWriteCloser, error) {
+	parsedURI, err := url.Parse(v.uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse libvirt URI: %w", err)
+	}
+	if parsedURI.Host == "" {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	}
+	if !strings.Contains(parsedURI.Scheme, "ssh") {
+		return nil, fmt.Errorf("the console of a %s:// host is not reachable; only local and qemu+ssh:// hosts are", parsedURI.Scheme)
+	}
+
+	v.hostKey.logVerificationMode(v.logger, parsedURI.Host)
+	if err := v.hostKey.verifyKnownHostsPresent(parsedURI.Host); err != nil {
+		return nil, fmt.Errorf("ssh tunnel host-key verification pre-flight failed: %w", err)
+	}
+	name, args := v.vncTunnelCommand(parsedURI, port)
+	// The tunnel outlives the RPC that starts it, so it is bound to the
+	// relay's stream rather than a request deadline.
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = v.env
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ssh tunnel: %w", err)
+	}
+	return &tunnelConn{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+// vncTunnelCommand returns the command forwarding stdin and stdout to a VNC
+// port on the host's loopback address, authenticated like the direct ssh
+// commands of runVirshCommand.
+func (v *VirshProvider) vncTunnelCommand(parsedURI *url.URL, port int) (string, []string) {
+	var args []string
+	name := "ssh"
+	switch {
+	case v.credentials != nil && v.credentials.Password != "":
+		name = "sshpass"
+		args = []string{
+			"-e", // Read password from SSHPASS environment variable
+			"ssh",
+			"-o", "PasswordAuthentication=yes",
+			"-o", "PubkeyAuthentication=no",
+		}
+	case v.credentials != nil && strings.TrimSpace(v.credentials.SSHPrivateKey) != "":
+		args = sshKeyAuthOptions(resolveSSHKeyFile(parsedURI))
+	}
+	args = append(args, "-o", "LogLevel=ERROR")
+	args = append(args, v.hostKey.sshHostKeyOptions()...)
+	args = append(args, sshMultiplexOptions()...)
+	args = append(args, "-W", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	return name, append(args, sshDestination(parsedURI)...)
+}
+
+// tunnelConn is the byte stream of an ssh tunnel process.
+type tunnelConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	once   sync.Once
+}
+
+func (t *tunnelConn) Read(p []byte) (int, error)  { return t.stdout.Read(p) }
+func (t *tunnelConn) Write(p []byte) (int, error) { return t.stdin.Write(p) }
+
+// Close ends the tunnel. It is safe to call more than once.
+func (t *tunnelConn) Close() error {
+	t.once.Do(func() {
+		_ = t.stdin.Close()
+		_ = t.cmd.Process.Kill()
+		_ = t.cmd.Wait()
+	})
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVNCTunnelCommand verifies the tunnel forwards to the loopback VNC port
+// and authenticates like the direct ssh commands.
+func TestVNCTunnelCommand(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "true")
+	uri, err := url.Parse("qemu+ssh://virtrigaud@kvm-a:2222/system?keyfile=/etc/virtrigaud/key")
+	require.NoError(t, err)
+
+	keyAuth := &VirshProvider{credentials: &Credentials{SSHPrivateKey: "KEY"}}
+	name, args := keyAuth.vncTunnelCommand(uri, 5901)
+	assert.Equal(t, "ssh", name)
+	assert.Subset(t, args, []string{"-i", "/etc/virtrigaud/key", "-W", "127.0.0.1:5901"})
+	assert.Equal(t, []string{"-p", "2222", "virtrigaud@kvm-a"}, args[len(args)-3:])
+
+	passwordAuth := &VirshProvider{credentials: &Credentials{Password: "secret"}}
+	name, args = passwordAuth.vncTunnelCommand(uri, 5900)
+	assert.Equal(t, "sshpass", name)
+	assert.Equal(t, []string{"-e", "ssh"}, args[:2])
+	assert.NotContains(t, args, "secret", "the password is passed in SSHPASS, not on the command line")
+}
+
+// TestDialVNC_UnreachableTransport verifies a remote host whose loopback
+// address the provider cannot reach is refused.
+func TestDialVNC_UnreachableTransport(t *testing.T) {
+	v := &VirshProvider{uri: "qemu+tcp://kvm-a/system"}
+	_, err := v.dialVNC(context.Background(), 5900)
+	assert.ErrorContains(t, err, "qemu+tcp://")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import "testing"
+
+// TestTailConsoleOutput verifies truncation is detected from the one extra line
+// read past the requested tail, and that short logs are returned whole.
+func TestTailConsoleOutput(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		n             int
+		want          string
+		wantTruncated bool
+	}{
+		{"empty", "", 3, "", false},
+		{"shorter than tail", "a\nb\n", 3, "a\nb\n", false},
+		{"exactly tail", "a\nb\nc\n", 3, "a\nb\nc\n", false},
+		{"one extra line", "a\nb\nc\nd\n", 3, "b\nc\nd\n", true},
+		{"no trailing newline", "a\nb\nc\nd", 2, "c\nd", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := tailConsoleOutput(tt.raw, tt.n)
+			if got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("tailConsoleOutput(%q, %d) = (%q, %v), want (%q, %v)",
+					tt.raw, tt.n, got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+// TestClampConsoleTailLines verifies the default and upper bound.
+func TestClampConsoleTailLines(t *testing.T) {
+	if got := clampConsoleTailLines(0); got != defaultConsoleTailLines {
+		t.Errorf("clampConsoleTailLines(0) = %d, want %d", got, defaultConsoleTailLines)
+	}
+	if got := clampConsoleTailLines(50); got != 50 {
+		t.Errorf("clampConsoleTailLines(50) = %d, want 50", got)
+	}
+	if got := clampConsoleTailLines(maxConsoleTailLines + 1); got != maxConsoleTailLines {
+		t.Errorf("clampConsoleTailLines(max+1) = %d, want %d", got, maxConsoleTailLines)
+	}
+}
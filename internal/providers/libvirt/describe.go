@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// typedInfoKeys are the domain info keys describeDetails moves to a typed
+// field of the describe layout, and so leaves out of its extras. The
+// per-interface net_<name>_mac keys go too.
+var typedInfoKeys = map[string]bool{
+	"host":                 true,
+	"hostname":             true,
+	"guest_hostname":       true,
+	"guest_os":             true,
+	"guest_os_pretty_name": true,
+	"tools_status":         true,
+	"guest_agent_version":  true,
+	"CPU(s)":               true,
+	"Max memory":           true,
+	"snapshot_count":       true,
+	"snapshot_chain_depth": true,
+	"job_type":             true,
+	"job_operation":        true,
+}
+
+// describeDetails returns resp in the describe layout. resp.ProviderRaw is
+// the domain info map getDomainInfo builds; host is the libvirt host the
+// domain runs on, "" for a single-host provider that does not name it.
+func describeDetails(resp contracts.DescribeResponse, host string) *describe.Details {
+	info := resp.ProviderRaw
+	if host == "" {
+		host = info["host"]
+	}
+	details := &describe.Details{Host: host}
+
+	guest := describe.GuestInfo{
+		// "hostname" falls back to the domain name; only the guest
+		// agent's answer is the guest's own.
+		Hostname:     info["guest_hostname"],
+		OSName:       info["guest_os_pretty_name"],
+		ToolsStatus:  info["tools_status"],
+		ToolsVersion: info["guest_agent_version"],
+	}
+	if guest.OSName == "" {
+		guest.OSName = info["guest_os"]
+	}
+	if guest != (describe.GuestInfo{}) {
+		details.Guest = &guest
+	}
+
+	cpus, _ := strconv.ParseInt(info["CPU(s)"], 10, 32)
+	var maxMemory int64
+	if kib, ok := strings.CutSuffix(info["Max memory"], " KiB"); ok {
+		if n, err := strconv.ParseInt(kib, 10, 64); err == nil {
+			maxMemory = n << 10
+		}
+	}
+	details.Resources = describe.NewResourceStats(int32(cpus), maxMemory, guestStatsToProto(resp.GuestStats))
+
+	macs := map[string]string{}
+	for k, v := range info {
+		if name, ok := interfaceMACKey(k); ok {
+			macs[name] = v
+		}
+	}
+	details.Interfaces = describe.InterfacesFromAddresses(guestAddressesToProto(resp.Addresses), macs)
+
+	if count, err := strconv.ParseInt(info["snapshot_count"], 10, 32); err == nil {
+		depth, _ := strconv.ParseInt(info["snapshot_chain_depth"], 10, 32)
+		details.Snapshots = &describe.SnapshotStats{Count: int32(count), ChainDepth: int32(depth)}
+	}
+
+	details.BusyReason = jobBusyReason(info)
+
+	for k, v := range info {
+		if _, mac := interfaceMACKey(k); typedInfoKeys[k] || mac || v == "" {
+			continue
+		}
+		if details.Extras == nil {
+			details.Extras = map[string]string{}
+		}
+		// The dominfo names ("OS Type", "Used memory", ...) become
+		// snake_case like the rest.
+		details.Extras[strings.ToLower(strings.ReplaceAll(k, " ", "_"))] = v
+	}
+	return details
+}
+
+// jobBusyReason returns the describe busyReason for the job addJobInfo
+// found running on the domain, e.g. "job running: outgoing migration", or
+// "" when there is none. Libvirt runs one job per domain at a time, so
+// changes that need one wait for it.
+func jobBusyReason(info map[string]string) string {
+	if info["job_type"] == "" {
+		return ""
+	}
+	what := info["job_operation"]
+	if what == "" {
+		what = info["job_type"] + " job"
+	}
+	return "job running: " + strings.ToLower(what)
+}
+
+// interfaceMACKey returns the interface a net_<name>_mac domain info key
+// holds the MAC address of.
+func interfaceMACKey(key string) (string, bool) {
+	name, ok := strings.CutPrefix(key, "net_")
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(name, "_mac")
+}
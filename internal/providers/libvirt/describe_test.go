@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// fakeDescribeProvider answers Describe with a fixed response.
+type fakeDescribeProvider struct {
+	contracts.Provider
+	resp contracts.DescribeResponse
+}
+
+func (f *fakeDescribeProvider) Describe(context.Context, string) (contracts.DescribeResponse, error) {
+	return f.resp, nil
+}
+
+// TestServer_DescribeDetails checks a running domain's info, as
+// getDomainInfo reports it, reaches the manager in the describe layout.
+func TestServer_DescribeDetails(t *testing.T) {
+	s := &Server{provider: &fakeDescribeProvider{resp: contracts.DescribeResponse{
+		Exists:     true,
+		PowerState: "On",
+		IPs:        []string{"192.168.122.10"},
+		Addresses:  []contracts.GuestAddress{{IP: "192.168.122.10", Interface: "enp1s0"}},
+		GuestStats: &contracts.GuestStats{CPUUsagePercent: ptr.To(7.5), UptimeSeconds: ptr.To[int64](120)},
+		ProviderRaw: map[string]string{
+			"Name":                 "web",
+			"OS Type":              "hvm",
+			"State":                "running",
+			"CPU(s)":               "2",
+			"Max memory":           "2097152 KiB",
+			"Used memory":          "2097152 KiB",
+			"hostname":             "web-guest",
+			"guest_hostname":       "web-guest",
+			"guest_os":             "Ubuntu",
+			"guest_os_pretty_name": "Ubuntu 24.04.1 LTS",
+			"guest_agent_version":  "8.2.2",
+			"tools_status":         "toolsOk",
+			"net_enp1s0_mac":       "52:54:00:12:34:56",
+			"net_enp1s0_rx_bytes":  "1024",
+			"snapshot_count":       "3",
+			"snapshot_chain_depth": "2",
+			"job_type":             "Unbounded",
+			"job_operation":        "Outgoing migration",
+			"vnc_port":             "",
+		},
+	}}}
+
+	details, err := conformance.CheckDescribeDetails(context.Background(), s, "web")
+	require.NoError(t, err)
+
+	assert.Equal(t, &describe.GuestInfo{
+		Hostname:     "web-guest",
+		OSName:       "Ubuntu 24.04.1 LTS",
+		ToolsStatus:  "toolsOk",
+		ToolsVersion: "8.2.2",
+	}, details.Guest)
+	assert.Equal(t, &describe.ResourceStats{
+		CPUCount:        2,
+		CPUUsagePercent: ptr.To(7.5),
+		MemoryMaxBytes:  ptr.To[int64](2 << 30),
+		UptimeSeconds:   ptr.To[int64](120),
+	}, details.Resources)
+	assert.Equal(t, []describe.NetworkInterface{
+		{Name: "enp1s0", MAC: "52:54:00:12:34:56", IPs: []string{"192.168.122.10"}, Addresses: []describe.InterfaceAddress{
+			{IP: "192.168.122.10", Family: describe.FamilyIPv4},
+		}},
+	}, details.Interfaces)
+	assert.Equal(t, &describe.SnapshotStats{Count: 3, ChainDepth: 2}, details.Snapshots)
+	assert.Equal(t, "job running: outgoing migration", details.BusyReason)
+	assert.Equal(t, map[string]string{
+		"name":                "web",
+		"os_type":             "hvm",
+		"state":               "running",
+		"used_memory":         "2097152 KiB",
+		"net_enp1s0_rx_bytes": "1024",
+	}, details.Extras)
+}
+
+func TestJobBusyReason(t *testing.T) {
+	assert.Empty(t, jobBusyReason(map[string]string{}))
+	assert.Equal(t, "job running: backup", jobBusyReason(map[string]string{"job_type": "Unbounded", "job_operation": "Backup"}))
+	assert.Equal(t, "job running: unbounded job", jobBusyReason(map[string]string{"job_type": "Unbounded"}),
+		"libvirt before 3.3 does not name the operation")
+}
@@ -24,14 +24,25 @@ import (
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 )
 
-// domainXML is the minimal subset of `virsh dumpxml` output that ListVMs/adoption
-// needs: identity, cpu, memory, disk paths+format, and NIC MACs. Everything here
-// is config (not runtime state), so power state still comes from `virsh list`.
+// domainXML is the subset of `virsh dumpxml` output that ListVMs/adoption and
+// Describe need: identity, cpu, memory, disk paths+format, NIC MACs and
+// graphics. Everything here is config (not runtime state), so power state
+// comes from `virsh list` or `virsh domstats`.
 type domainXML struct {
-	Name    string   `xml:"name"`
-	UUID    string   `xml:"uuid"`
-	VCPU    int32    `xml:"vcpu"` // <vcpu>N</vcpu> — configured vCPU count
-	Memory  memValue `xml:"memory"`
+	// ID is the runtime domain id; libvirt only sets it while the domain runs.
+	ID            string    `xml:"id,attr"`
+	Name          string    `xml:"name"`
+	UUID          string    `xml:"uuid"`
+	VCPU          vcpuValue `xml:"vcpu"`
+	Memory        memValue  `xml:"memory"`
+	CurrentMemory memValue  `xml:"currentMemory"`
+	OS            struct {
+		Type struct {
+			Arch    string `xml:"arch,attr"`
+			Machine string `xml:"machine,attr"`
+			Value   string `xml:",chardata"`
+		} `xml:"type"`
+	} `xml:"os"`
 	Devices struct {
 		Disks []struct {
 			Device string `xml:"device,attr"`
@@ -41,13 +52,35 @@ type domainXML struct {
 			Source struct {
 				File string `xml:"file,attr"`
 			} `xml:"source"`
+			Target struct {
+				Dev string `xml:"dev,attr"`
+			} `xml:"target"`
 		} `xml:"disk"`
 		Interfaces []struct {
 			MAC struct {
 				Address string `xml:"address,attr"`
 			} `xml:"mac"`
+			Target struct {
+				Dev string `xml:"dev,attr"`
+			} `xml:"target"`
 		} `xml:"interface"`
+		Graphics []struct {
+			Type string `xml:"type,attr"`
+			Port int    `xml:"port,attr"`
+		} `xml:"graphics"`
 	} `xml:"devices"`
+	// IdempotencyKey is the key of the Create that defined the domain,
+	// recorded in its <metadata>; empty for domains defined elsewhere or
+	// by older providers.
+	IdempotencyKey string `xml:"metadata>instance>idempotencyKey"`
+}
+
+// vcpuValue is the <vcpu> element: the maximum vCPU count, plus the count
+// currently plugged when CPU hot-add headroom was provisioned
+// (<vcpu current='2'>8</vcpu>).
+type vcpuValue struct {
+	Max     int32 `xml:",chardata"`
+	Current int32 `xml:"current,attr"`
 }
 
 // memValue is a <memory>/<currentMemory> element: its value plus the unit
@@ -60,6 +93,40 @@ type memValue struct {
 	Unit string `xml:"unit,attr"`
 }
 
+// instanceMetadataNS is the XML namespace of the <metadata> element the
+// provider adds to domains it defines.
+const instanceMetadataNS = "https://virtrigaud.io/xmlns/libvirt/instance/1.0"
+
+// instanceMetadataXML renders the <metadata> element recording a Create's
+// idempotency key, or "" when there is none.
+func instanceMetadataXML(idempotencyKey string) string {
+	if idempotencyKey == "" {
+		return ""
+	}
+	var key strings.Builder
+	_ = xml.EscapeText(&key, []byte(idempotencyKey))
+	return fmt.Sprintf(`<metadata>
+    <virtrigaud:instance xmlns:virtrigaud='%s'>
+      <virtrigaud:idempotencyKey>%s</virtrigaud:idempotencyKey>
+    </virtrigaud:instance>
+  </metadata>`, instanceMetadataNS, key.String())
+}
+
+// checkDomainOwner decides whether an existing domain named like a Create
+// request is the VM that request asks for. Libvirt domain names are unique
+// per host, so the name is the idempotency key in practice; the recorded
+// key only breaks the tie when two VMs with the same name (e.g. from two
+// namespaces) target one host. A domain without a recorded key, or a
+// request without one, is adopted as before.
+func checkDomainOwner(name, recordedKey, requestKey string) error {
+	if recordedKey == "" || requestKey == "" || recordedKey == requestKey {
+		return nil
+	}
+	return contracts.NewConflictError(fmt.Sprintf(
+		"domain %q already exists for a different VM (idempotency key %q, request has %q)",
+		name, recordedKey, requestKey), nil)
+}
+
 // parseDomainXML unmarshals a single `virsh dumpxml` document.
 func parseDomainXML(raw string) (*domainXML, error) {
 	var d domainXML
@@ -82,6 +149,26 @@ func (d *domainXML) MemoryMiB() (int64, error) {
 	return d.Memory.KiB / 1024, nil
 }
 
+// VCPUs returns the configured vCPU count: the plugged count when hot-add
+// headroom exists, the <vcpu> value otherwise.
+func (d *domainXML) VCPUs() int32 {
+	if d.VCPU.Current > 0 {
+		return d.VCPU.Current
+	}
+	return d.VCPU.Max
+}
+
+// VNCPort returns the port of the first VNC display, or 0 when the domain
+// has none or it is not running (libvirt reports port='-1' until then).
+func (d *domainXML) VNCPort() int {
+	for _, g := range d.Devices.Graphics {
+		if g.Type == "vnc" && g.Port > 0 {
+			return g.Port
+		}
+	}
+	return 0
+}
+
 // Disks returns the file-backed data disks, skipping cdrom/floppy and cloud-init
 // ISOs. Size is 0: it is not in the XML and adoption does not need it (the old
 // local os.Stat on a remote path always yielded 0 anyway).
@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func isInvalidSpec(err error) bool {
+	var perr *contracts.ProviderError
+	return errors.As(err, &perr) && perr.Type == contracts.ErrorTypeInvalidSpec
+}
+
+func renderRequest(class contracts.VMClass) contracts.CreateRequest {
+	class.CPU, class.MemoryMiB = 2, 2048
+	return contracts.CreateRequest{
+		Name:  "web-01",
+		Class: class,
+		Networks: []contracts.NetworkAttachment{
+			{NetworkName: "default", MacAddress: "52:54:00:12:34:56"},
+			{Bridge: "br0"},
+		},
+	}
+}
+
+// TestRenderDomainXML pins the domain XML for each firmware and machine
+// type. Run go test -update to rewrite testdata/domainxml after an
+// intended change, and review the diff.
+func TestRenderDomainXML(t *testing.T) {
+	allReadable := func(string) bool { return true }
+	tests := []struct {
+		golden string
+		class  contracts.VMClass
+	}{
+		{"bios-pc.xml", contracts.VMClass{}},
+		{"uefi-q35.xml", contracts.VMClass{Firmware: "UEFI"}},
+		{"uefi-secureboot.xml", contracts.VMClass{
+			Firmware:        "UEFI-SecureBoot",
+			SecurityProfile: &contracts.SecurityProfile{TPMEnabled: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			fw, err := firmwareFromClass(tt.class)
+			require.NoError(t, err)
+			if fw.UEFI {
+				require.NoError(t, locateOVMF(&fw, allReadable))
+			}
+			got := renderDomainXML(renderRequest(tt.class), "kvm", "3f1c2b9a-5d4e-4c1a-9b7e-2a6f8d0c1e34", fw,
+				"/var/lib/libvirt/images/web-01.qcow2", "/var/lib/libvirt/images/web-01-cidata.iso")
+
+			golden := filepath.Join("testdata", "domainxml", tt.golden)
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, []byte(got+"\n"), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err, "run go test -update to create it")
+			assert.Equal(t, string(want), got+"\n")
+
+			_, err = parseDomainXML(got)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFirmwareFromClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		class   contracts.VMClass
+		want    domainFirmware
+		wantErr string
+	}{
+		{name: "default is BIOS on pc", want: domainFirmware{Machine: "pc"}},
+		{name: "UEFI defaults to q35", class: contracts.VMClass{Firmware: "UEFI"}, want: domainFirmware{Machine: "q35", UEFI: true}},
+		{name: "EFI is UEFI", class: contracts.VMClass{Firmware: "EFI"}, want: domainFirmware{Machine: "q35", UEFI: true}},
+		{name: "securityProfile secure boot implies UEFI", class: contracts.VMClass{
+			SecurityProfile: &contracts.SecurityProfile{SecureBoot: true},
+		}, want: domainFirmware{Machine: "q35", UEFI: true, SecureBoot: true}},
+		{name: "VT-d defaults to q35", class: contracts.VMClass{
+			SecurityProfile: &contracts.SecurityProfile{VTDEnabled: true},
+		}, want: domainFirmware{Machine: "q35"}},
+		{name: "versioned machine type", class: contracts.VMClass{Firmware: "BIOS", MachineType: "pc-q35-8.2"}, want: domainFirmware{Machine: "pc-q35-8.2"}},
+		{name: "UEFI on pc", class: contracts.VMClass{Firmware: "UEFI", MachineType: "pc-i440fx-7.2"}, want: domainFirmware{Machine: "pc-i440fx-7.2", UEFI: true}},
+		{name: "secure boot on pc", class: contracts.VMClass{Firmware: "UEFI-SecureBoot", MachineType: "pc"}, wantErr: "SMM"},
+		{name: "VT-d on pc", class: contracts.VMClass{MachineType: "pc", SecurityProfile: &contracts.SecurityProfile{VTDEnabled: true}}, wantErr: "IOMMU"},
+		{name: "unknown machine type", class: contracts.VMClass{MachineType: "virt"}, wantErr: "unsupported machine type"},
+		{name: "unknown firmware", class: contracts.VMClass{Firmware: "coreboot"}, wantErr: "unsupported firmware"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := firmwareFromClass(tt.class)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.True(t, isInvalidSpec(err), err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocateOVMF(t *testing.T) {
+	only := func(paths ...string) func(string) bool {
+		return func(p string) bool {
+			for _, want := range paths {
+				if p == want {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	fw := domainFirmware{Machine: "q35", UEFI: true}
+	require.NoError(t, locateOVMF(&fw, only("/usr/share/edk2/ovmf/OVMF_CODE.fd", "/usr/share/edk2/ovmf/OVMF_VARS.fd")))
+	assert.Equal(t, "/usr/share/edk2/ovmf/OVMF_CODE.fd", fw.Loader)
+	assert.Equal(t, "/usr/share/edk2/ovmf/OVMF_VARS.fd", fw.NVRAM)
+
+	fw = domainFirmware{Machine: "q35", UEFI: true, SecureBoot: true}
+	require.NoError(t, locateOVMF(&fw, only("/usr/share/OVMF/OVMF_CODE_4M.secboot.fd", "/usr/share/OVMF/OVMF_VARS_4M.fd")))
+	assert.Equal(t, "/usr/share/OVMF/OVMF_VARS_4M.fd", fw.NVRAM, "falls back to the varstore without enrolled keys")
+
+	fw = domainFirmware{Machine: "q35", UEFI: true, SecureBoot: true}
+	err := locateOVMF(&fw, only("/usr/share/OVMF/OVMF_CODE_4M.fd", "/usr/share/OVMF/OVMF_VARS_4M.fd"))
+	require.Error(t, err, "plain OVMF does not satisfy Secure Boot")
+	assert.True(t, isInvalidSpec(err))
+	assert.Contains(t, err.Error(), EnvOVMFSecureBootCode)
+
+	t.Setenv(EnvOVMFCode, "/opt/ovmf/code.fd")
+	t.Setenv(EnvOVMFVars, "/opt/ovmf/vars.fd")
+	fw = domainFirmware{Machine: "q35", UEFI: true}
+	require.NoError(t, locateOVMF(&fw, only("/opt/ovmf/code.fd", "/opt/ovmf/vars.fd", "/usr/share/OVMF/OVMF_CODE_4M.fd", "/usr/share/OVMF/OVMF_VARS_4M.fd")))
+	assert.Equal(t, "/opt/ovmf/code.fd", fw.Loader, "the environment overrides the distribution paths")
+
+	err = locateOVMF(&fw, only())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/opt/ovmf/code.fd")
+
+	t.Setenv(EnvOVMFVars, "")
+	err = locateOVMF(&fw, only("/opt/ovmf/code.fd"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set together")
+}
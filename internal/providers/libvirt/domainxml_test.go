@@ -53,8 +53,8 @@ func TestParseDomainXML(t *testing.T) {
 	if dx.UUID != "4dea22b3-1d52-d8f3-2516-782e98ab3fa0" {
 		t.Errorf("UUID = %q", dx.UUID)
 	}
-	if dx.VCPU != 2 {
-		t.Errorf("VCPU = %d, want 2", dx.VCPU)
+	if dx.VCPUs() != 2 {
+		t.Errorf("VCPUs() = %d, want 2", dx.VCPUs())
 	}
 	if dx.Memory.KiB != 2097152 {
 		t.Errorf("Memory = %d, want 2097152 KiB", dx.Memory.KiB)
@@ -148,3 +148,50 @@ func TestParseDomainListTable_empty(t *testing.T) {
 		t.Errorf("got %d domains, want 0", len(domains))
 	}
 }
+
+// The key a Create records in <metadata> must read back from dumpxml, which
+// keeps the namespace prefix the provider wrote.
+func TestInstanceMetadataRoundTrip(t *testing.T) {
+	raw := "<domain type='kvm'>\n  <name>web-01</name>\n  " +
+		instanceMetadataXML("3f6c<uid>") + "\n</domain>"
+	dx, err := parseDomainXML(raw)
+	if err != nil {
+		t.Fatalf("parseDomainXML: %v", err)
+	}
+	if dx.IdempotencyKey != "3f6c<uid>" {
+		t.Errorf("IdempotencyKey = %q, want 3f6c<uid>", dx.IdempotencyKey)
+	}
+	// Describe reports it as the VM's identity.
+	if got := domainInfoMap(dx, nil)["identity"]; got != "3f6c<uid>" {
+		t.Errorf("described identity = %q, want 3f6c<uid>", got)
+	}
+	if instanceMetadataXML("") != "" {
+		t.Error("no key should render no <metadata>")
+	}
+
+	dx, err = parseDomainXML(sampleDomainXML)
+	if err != nil {
+		t.Fatalf("parseDomainXML: %v", err)
+	}
+	if dx.IdempotencyKey != "" {
+		t.Errorf("domain without metadata has IdempotencyKey %q", dx.IdempotencyKey)
+	}
+}
+
+func TestCheckDomainOwner(t *testing.T) {
+	cases := []struct {
+		recorded, request string
+		wantErr           bool
+	}{
+		{"uid-1", "uid-1", false},
+		{"", "uid-1", false}, // defined before keys were recorded
+		{"uid-1", "", false}, // caller sends no key
+		{"uid-1", "uid-2", true},
+	}
+	for _, c := range cases {
+		err := checkDomainOwner("web-01", c.recorded, c.request)
+		if (err != nil) != c.wantErr {
+			t.Errorf("checkDomainOwner(recorded=%q, request=%q) = %v, wantErr %t", c.recorded, c.request, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// domainStats holds the records of `virsh domstats --raw` for one domain,
+// keyed by their libvirt names ("state.state", "balloon.current",
+// "block.0.rd.bytes", ...). Unlike dominfo and friends the records are never
+// translated, and values are plain numbers or paths.
+type domainStats map[string]string
+
+// parseDomstats parses `virsh domstats --raw` output for a single domain.
+// Records are the indented key=value lines; the "Domain: '<name>'" header,
+// whose label is localized and whose name may contain anything, is skipped.
+func parseDomstats(raw string) (domainStats, error) {
+	stats := domainStats{}
+	for _, line := range strings.Split(raw, "\n") {
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || key == "" {
+			continue
+		}
+		stats[key] = value
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("no records in domstats output")
+	}
+	return stats, nil
+}
+
+// int returns the integer value of key.
+func (s domainStats) int(key string) (int64, bool) {
+	v, ok := s[key]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// domainStateNames are the untranslated virsh names of virDomainState, as
+// printed by dominfo/domstate under the C locale. mapLibvirtPowerState and
+// the "running" checks throughout the provider expect these.
+var domainStateNames = map[int64]string{
+	0: "no state",
+	1: "running",
+	2: "idle",
+	3: "paused",
+	4: "in shutdown",
+	5: "shut off",
+	6: "crashed",
+	7: "pmsuspended",
+}
+
+// State returns the domain state name, or "" if state.state is missing or
+// unknown.
+func (s domainStats) State() string {
+	n, ok := s.int("state.state")
+	if !ok {
+		return ""
+	}
+	return domainStateNames[n]
+}
+
+// names returns the <prefix>.N.name values for N in 0..<prefix>.count-1.
+func (s domainStats) names(prefix string) []string {
+	count, _ := s.int(prefix + ".count")
+	var names []string
+	for i := int64(0); i < count; i++ {
+		if name := s[fmt.Sprintf("%s.%d.name", prefix, i)]; name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// balloonKeys maps domstats balloon records to the memory_* keys Describe
+// has always reported (the dommemstat names).
+var balloonKeys = map[string]string{
+	"balloon.current":         "memory_actual",
+	"balloon.swap_in":         "memory_swap_in",
+	"balloon.swap_out":        "memory_swap_out",
+	"balloon.major_fault":     "memory_major_fault",
+	"balloon.minor_fault":     "memory_minor_fault",
+	"balloon.unused":          "memory_unused",
+	"balloon.available":       "memory_available",
+	"balloon.usable":          "memory_usable",
+	"balloon.last-update":     "memory_last_update",
+	"balloon.disk_caches":     "memory_disk_caches",
+	"balloon.hugetlb_pgalloc": "memory_hugetlb_pgalloc",
+	"balloon.hugetlb_pgfail":  "memory_hugetlb_pgfail",
+	"balloon.rss":             "memory_rss",
+}
+
+// blockKeys maps the per-device block records of the first disk to the
+// block_* keys Describe reports.
+var blockKeys = map[string]string{
+	"rd.reqs":    "block_rd_req",
+	"rd.bytes":   "block_rd_bytes",
+	"rd.times":   "block_rd_total_times",
+	"wr.reqs":    "block_wr_req",
+	"wr.bytes":   "block_wr_bytes",
+	"wr.times":   "block_wr_total_times",
+	"fl.reqs":    "block_flush_operations",
+	"fl.times":   "block_flush_total_times",
+	"allocation": "block_allocation",
+	"capacity":   "block_capacity",
+	"physical":   "block_physical",
+}
+
+// domainInfoMap flattens a domain's XML and domstats into the map Describe
+// returns as ProviderRaw. It keeps the dominfo key names ("State", "CPU(s)",
+// "Max memory", ...) the provider and its consumers already read, but fills
+// them from structured sources. Runtime values come from stats when the
+// domain reports them and fall back to the XML configuration otherwise.
+func domainInfoMap(dx *domainXML, stats domainStats) map[string]string {
+	info := map[string]string{
+		"Name":    dx.Name,
+		"UUID":    dx.UUID,
+		"OS Type": dx.OS.Type.Value,
+		"State":   stats.State(),
+	}
+
+	// The idempotency key Create stamped into the domain metadata, which
+	// Describe reports as the VM's identity.
+	if dx.IdempotencyKey != "" {
+		info["identity"] = dx.IdempotencyKey
+	}
+
+	info["Id"] = "-"
+	if dx.ID != "" && dx.ID != "-1" {
+		info["Id"] = dx.ID
+	}
+
+	cpus := int64(dx.VCPUs())
+	if n, ok := stats.int("vcpu.current"); ok {
+		cpus = n
+	}
+	info["CPU(s)"] = strconv.FormatInt(cpus, 10)
+
+	maxMemory := dx.Memory.KiB
+	if n, ok := stats.int("balloon.maximum"); ok {
+		maxMemory = n
+	}
+	info["Max memory"] = fmt.Sprintf("%d KiB", maxMemory)
+	usedMemory := dx.CurrentMemory.KiB
+	if n, ok := stats.int("balloon.current"); ok {
+		usedMemory = n
+	}
+	if usedMemory == 0 {
+		usedMemory = maxMemory
+	}
+	info["Used memory"] = fmt.Sprintf("%d KiB", usedMemory)
+
+	if ns, ok := stats.int("cpu.time"); ok {
+		info["CPU time"] = fmt.Sprintf("%.1fs", float64(ns)/1e9)
+	}
+	for _, key := range []string{"time", "user", "system"} {
+		if v, ok := stats["cpu."+key]; ok {
+			info["cpu_"+key] = v
+		}
+	}
+
+	for record, key := range balloonKeys {
+		if v, ok := stats[record]; ok {
+			info[key] = v
+		}
+	}
+
+	// Interface and disk names come from the live stats; a domain that is
+	// not running has no tap devices, so only the configured disk targets
+	// are listed for it.
+	interfaces := stats.names("net")
+	if len(interfaces) == 0 {
+		for _, iface := range dx.Devices.Interfaces {
+			if iface.Target.Dev != "" {
+				interfaces = append(interfaces, iface.Target.Dev)
+			}
+		}
+	}
+	info["network_interfaces"] = strings.Join(interfaces, ",")
+
+	devices := stats.names("block")
+	if len(devices) == 0 {
+		for _, disk := range dx.Devices.Disks {
+			if disk.Target.Dev != "" {
+				devices = append(devices, disk.Target.Dev)
+			}
+		}
+	}
+	info["block_devices"] = strings.Join(devices, ",")
+	for record, key := range blockKeys {
+		if v, ok := stats["block.0."+record]; ok {
+			info[key] = v
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// eventRestartDelay is how long a host's event subscription waits before
+// it is restarted after `virsh event` exited, e.g. because the host went
+// away.
+const eventRestartDelay = 10 * time.Second
+
+// Reasons of the alarms a domain event raises. A domain starting or
+// resuming resolves all of them.
+var domainAlarmReasons = []string{"DomainCrashed", "DiskIOError", "WatchdogFired", "ControlError"}
+
+// WatchEvents streams the domain events of every managed host, subscribed
+// to with `virsh event --all --loop`. libvirt keeps no event history, so
+// since is ignored: only events raised while the stream is open are sent.
+func (s *Server) WatchEvents(req *providerv1.WatchEventsRequest, stream providerv1.Provider_WatchEventsServer) error {
+	p, ok := s.provider.(*Provider)
+	if !ok || p == nil || p.virshProvider == nil {
+		return fmt.Errorf("libvirt provider not initialized")
+	}
+	hosts := []*VirshProvider{p.virshProvider}
+	if p.hosts.multiHost() {
+		hosts = hosts[:0]
+		for _, h := range p.hosts.hosts {
+			hosts = append(hosts, h.provider.virshProvider)
+		}
+	}
+
+	ctx := stream.Context()
+	var mu sync.Mutex
+	send := func(ev *providerv1.HypervisorEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(ev)
+	}
+	errs := make(chan error, len(hosts))
+	for _, v := range hosts {
+		go func() { errs <- v.watchDomainEvents(ctx, send) }()
+	}
+	for range hosts {
+		// Only a failed send ends a host's subscription before ctx is done,
+		// and the stream is then unusable for every host.
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchDomainEvents runs `virsh event` on the host and passes each event
+// it maps to send, restarting the subscription whenever it exits, until
+// ctx is done or send fails.
+func (v *VirshProvider) watchDomainEvents(ctx context.Context, send func(*providerv1.HypervisorEvent) error) error {
+	for {
+		err := v.runDomainEvents(ctx, send)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			if _, isSend := err.(sendError); isSend {
+				return err
+			}
+			v.slogger().Warn("Domain event subscription ended", "uri", v.uri, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(eventRestartDelay):
+		}
+	}
+}
+
+// sendError wraps a failed stream send, which ends the watch rather than
+// restarting the subscription.
+type sendError struct{ error }
+
+func (v *VirshProvider) runDomainEvents(ctx context.Context, send func(*providerv1.HypervisorEvent) error) error {
+	cmd := v.domainEventsCommand(ctx)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start virsh event: %w", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		for _, ev := range parseDomainEvent(scanner.Text(), time.Now()) {
+			if err := send(ev); err != nil {
+				return sendError{err}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("virsh event exited")
+}
+
+// domainEventsCommand returns the `virsh event` subscription for the host,
+// run like runVirshCommand runs virsh: through sshpass for a password-
+// authenticated qemu+ssh:// URI, and locally against LIBVIRT_DEFAULT_URI
+// otherwise. The command is bound to ctx, not a request deadline.
+func (v *VirshProvider) domainEventsCommand(ctx context.Context) *exec.Cmd {
+	args := []string{"event", "--all", "--loop", "--timestamp"}
+	var cmd *exec.Cmd
+	if v.credentials != nil && v.credentials.Password != "" && strings.Contains(v.uri, "ssh://") {
+		parsedURI, _ := url.Parse(v.uri)
+		sshArgs := []string{
+			"-e", // Read password from SSHPASS environment variable
+			"ssh",
+			"-o", "PasswordAuthentication=yes",
+			"-o", "PubkeyAuthentication=no",
+			"-o", "LogLevel=ERROR",
+		}
+		sshArgs = append(sshArgs, v.hostKey.sshHostKeyOptions()...)
+		sshArgs = append(sshArgs, sshMultiplexOptions()...)
+		sshArgs = append(sshArgs, sshDestination(parsedURI)...)
+		sshArgs = append(sshArgs, "virsh")
+		if uri := remoteVirshConnectURI(v.uri); uri != "" {
+			sshArgs = append(sshArgs, "-c", uri)
+		}
+		cmd = exec.CommandContext(ctx, "sshpass", append(sshArgs, args...)...)
+	} else {
+		cmd = exec.CommandContext(ctx, "virsh", args...)
+	}
+	cmd.Env = v.env
+	return cmd
+}
+
+// domainEventLine matches a line of `virsh event --timestamp`, e.g.
+//
+//	2026-03-01 10:20:30.123+0000: event 'lifecycle' for domain 'web-1': Stopped Failed
+//
+// Releases before libvirt 8 print the domain name unquoted.
+var domainEventLine = regexp.MustCompile(`^(?:(.+?): )?event '([^']+)' for domain (?:'([^']*)'|(\S+?))(?::\s*(.*))?$`)
+
+// virshEventTimeLayout is the format of the --timestamp prefix.
+const virshEventTimeLayout = "2006-01-02 15:04:05.000-0700"
+
+// parseDomainEvent maps a line of `virsh event` output to the events it
+// reports, none for event kinds the manager has no use for. now stands in
+// for a missing or unreadable timestamp.
+func parseDomainEvent(line string, now time.Time) []*providerv1.HypervisorEvent {
+	m := domainEventLine.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return nil
+	}
+	at := now
+	if t, err := time.Parse(virshEventTimeLayout, m[1]); err == nil {
+		at = t
+	}
+	kind, domain, detail := m[2], m[3]+m[4], m[5]
+	event := func(severity providerv1.EventSeverity, reason string) *providerv1.HypervisorEvent {
+		return &providerv1.HypervisorEvent{
+			VmId:     domain,
+			Severity: severity,
+			Reason:   reason,
+			Message:  detail,
+			TimeUnix: at.Unix(),
+		}
+	}
+
+	switch kind {
+	case "lifecycle":
+		switch {
+		case strings.HasPrefix(detail, "Crashed"), detail == "Stopped Failed", detail == "Stopped Crashed":
+			return []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_ERROR, "DomainCrashed")}
+		case detail == "Suspended I/O Error":
+			return []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_ERROR, "DiskIOError")}
+		case strings.HasPrefix(detail, "Started"), strings.HasPrefix(detail, "Resumed"):
+			events := []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_INFO, "DomainLifecycle")}
+			for _, reason := range domainAlarmReasons {
+				resolved := event(providerv1.EventSeverity_EVENT_SEVERITY_INFO, reason)
+				resolved.Resolved = true
+				events = append(events, resolved)
+			}
+			return events
+		default:
+			return []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_INFO, "DomainLifecycle")}
+		}
+	case "io-error":
+		return []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "DiskIOError")}
+	case "watchdog":
+		return []*providerv1.HypervisorEvent{event(providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "WatchdogFired")}
+	case "control-error":
+		ev := event(providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "ControlError")
+		ev.Message = "libvirt lost control of the domain's QEMU process"
+		return []*providerv1.HypervisorEvent{ev}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestParseDomainEvent(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	at := time.Date(2026, 3, 1, 10, 20, 30, 0, time.UTC).Unix()
+
+	tests := []struct {
+		line     string
+		vmID     string
+		severity providerv1.EventSeverity
+		reason   string
+		message  string
+		time     int64
+	}{
+		{"2026-03-01 10:20:30.123+0000: event 'lifecycle' for domain 'web-1': Stopped Failed",
+			"web-1", providerv1.EventSeverity_EVENT_SEVERITY_ERROR, "DomainCrashed", "Stopped Failed", at},
+		{"event 'lifecycle' for domain web-1: Crashed Panicked",
+			"web-1", providerv1.EventSeverity_EVENT_SEVERITY_ERROR, "DomainCrashed", "Crashed Panicked", now.Unix()},
+		{"2026-03-01 10:20:30.123+0000: event 'lifecycle' for domain 'db': Suspended I/O Error",
+			"db", providerv1.EventSeverity_EVENT_SEVERITY_ERROR, "DiskIOError", "Suspended I/O Error", at},
+		{"2026-03-01 10:20:30.123+0000: event 'io-error' for domain 'db': /var/lib/libvirt/images/db.qcow2 (virtio-disk0) pause",
+			"db", providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "DiskIOError", "/var/lib/libvirt/images/db.qcow2 (virtio-disk0) pause", at},
+		{"2026-03-01 10:20:30.123+0000: event 'watchdog' for domain 'db': reset",
+			"db", providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "WatchdogFired", "reset", at},
+		{"2026-03-01 10:20:30.123+0000: event 'control-error' for domain 'db'",
+			"db", providerv1.EventSeverity_EVENT_SEVERITY_WARNING, "ControlError", "libvirt lost control of the domain's QEMU process", at},
+		{"2026-03-01 10:20:30.123+0000: event 'lifecycle' for domain 'db': Stopped Shutdown",
+			"db", providerv1.EventSeverity_EVENT_SEVERITY_INFO, "DomainLifecycle", "Stopped Shutdown", at},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			events := parseDomainEvent(tt.line, now)
+			require.Len(t, events, 1)
+			assert.Equal(t, &providerv1.HypervisorEvent{
+				VmId:     tt.vmID,
+				Severity: tt.severity,
+				Reason:   tt.reason,
+				Message:  tt.message,
+				TimeUnix: tt.time,
+			}, events[0])
+		})
+	}
+
+	assert.Empty(t, parseDomainEvent("2026-03-01 10:20:30.123+0000: event 'agent-lifecycle' for domain 'db': state: 'connected' reason: 'channel event'", now))
+	assert.Empty(t, parseDomainEvent("events received: 3", now))
+}
+
+func TestParseDomainEvent_StartResolves(t *testing.T) {
+	events := parseDomainEvent("2026-03-01 10:20:30.123+0000: event 'lifecycle' for domain 'web-1': Started Booted", time.Now())
+	require.Len(t, events, 1+len(domainAlarmReasons))
+	assert.Equal(t, "DomainLifecycle", events[0].Reason)
+	assert.False(t, events[0].Resolved)
+	for i, reason := range domainAlarmReasons {
+		assert.Equal(t, reason, events[i+1].Reason)
+		assert.True(t, events[i+1].Resolved)
+		assert.Equal(t, "web-1", events[i+1].VmId)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// Environment variables that point the provider at the host's OVMF images
+// when they are not in any of the distribution paths it looks in. Each
+// pair must be set together: the code image and the variable store
+// template built for it.
+const (
+	EnvOVMFCode           = "LIBVIRT_OVMF_CODE"
+	EnvOVMFVars           = "LIBVIRT_OVMF_VARS"
+	EnvOVMFSecureBootCode = "LIBVIRT_OVMF_SECURE_BOOT_CODE"
+	EnvOVMFSecureBootVars = "LIBVIRT_OVMF_SECURE_BOOT_VARS"
+)
+
+// ovmfImages is a UEFI code image and the variable store template that
+// goes with it.
+type ovmfImages struct {
+	code, vars string
+}
+
+// ovmfCandidates are where distributions install OVMF, most common first.
+var ovmfCandidates = []ovmfImages{
+	{"/usr/share/OVMF/OVMF_CODE_4M.fd", "/usr/share/OVMF/OVMF_VARS_4M.fd"},           // Debian, Ubuntu
+	{"/usr/share/OVMF/OVMF_CODE.fd", "/usr/share/OVMF/OVMF_VARS.fd"},                 // older Debian, Ubuntu
+	{"/usr/share/edk2/ovmf/OVMF_CODE.fd", "/usr/share/edk2/ovmf/OVMF_VARS.fd"},       // Fedora, RHEL
+	{"/usr/share/edk2/x64/OVMF_CODE.4m.fd", "/usr/share/edk2/x64/OVMF_VARS.4m.fd"},   // Arch
+	{"/usr/share/qemu/ovmf-x86_64-code.bin", "/usr/share/qemu/ovmf-x86_64-vars.bin"}, // SUSE
+}
+
+// ovmfSecureBootCandidates are the Secure Boot builds, which need SMM.
+// Variable stores with the Microsoft keys enrolled come first so Secure
+// Boot is enforced from the first boot.
+var ovmfSecureBootCandidates = []ovmfImages{
+	{"/usr/share/OVMF/OVMF_CODE_4M.secboot.fd", "/usr/share/OVMF/OVMF_VARS_4M.ms.fd"},              // Debian, Ubuntu
+	{"/usr/share/OVMF/OVMF_CODE_4M.secboot.fd", "/usr/share/OVMF/OVMF_VARS_4M.fd"},                 // Debian, Ubuntu without ovmf-ms
+	{"/usr/share/edk2/ovmf/OVMF_CODE.secboot.fd", "/usr/share/edk2/ovmf/OVMF_VARS.secboot.fd"},     // Fedora, RHEL
+	{"/usr/share/edk2/x64/OVMF_CODE.secboot.4m.fd", "/usr/share/edk2/x64/OVMF_VARS.4m.fd"},         // Arch
+	{"/usr/share/qemu/ovmf-x86_64-smm-ms-code.bin", "/usr/share/qemu/ovmf-x86_64-smm-ms-vars.bin"}, // SUSE
+}
+
+// domainFirmware is how a new domain boots: its machine type and, for
+// UEFI, the loader and varstore template on the host.
+type domainFirmware struct {
+	Machine    string
+	UEFI       bool
+	SecureBoot bool
+	Loader     string
+	NVRAM      string
+}
+
+// Q35 reports whether the domain has a q35 (PCI Express) chipset.
+func (f domainFirmware) Q35() bool {
+	return isQ35(f.Machine)
+}
+
+// isQ35 matches "q35" and the versioned "pc-q35-8.2" machine types.
+func isQ35(machine string) bool {
+	return machine == "q35" || strings.HasPrefix(machine, "pc-q35-")
+}
+
+// isI440FX matches "pc" and the versioned "pc-i440fx-7.2" machine types.
+func isI440FX(machine string) bool {
+	return machine == "pc" || strings.HasPrefix(machine, "pc-i440fx-")
+}
+
+// firmwareFromClass maps a VMClass's firmware, machine type hint and
+// security profile onto a domainFirmware, without the loader paths. The
+// machine defaults to q35 when the class needs it (UEFI, Secure Boot or
+// VT-d) and to the historical pc otherwise. securityProfile.secureBoot
+// still implies UEFI, as it always has on this provider.
+func firmwareFromClass(class contracts.VMClass) (domainFirmware, error) {
+	var fw domainFirmware
+	switch strings.ToUpper(class.Firmware) {
+	case "", "BIOS":
+	case "UEFI", "EFI":
+		fw.UEFI = true
+	case "UEFI-SECUREBOOT":
+		fw.UEFI, fw.SecureBoot = true, true
+	default:
+		return fw, contracts.NewInvalidSpecError(fmt.Sprintf("spec.firmware: unsupported firmware %q", class.Firmware), nil)
+	}
+
+	vtd := false
+	if sp := class.SecurityProfile; sp != nil {
+		if sp.SecureBoot {
+			fw.UEFI, fw.SecureBoot = true, true
+		}
+		vtd = sp.VTDEnabled
+	}
+
+	fw.Machine = class.MachineType
+	if fw.Machine == "" {
+		fw.Machine = "pc"
+		if fw.UEFI || vtd {
+			fw.Machine = "q35"
+		}
+	}
+
+	switch {
+	case isQ35(fw.Machine):
+	case isI440FX(fw.Machine):
+		if fw.SecureBoot {
+			return fw, contracts.NewInvalidSpecError(fmt.Sprintf(
+				"spec.machineType: Secure Boot needs SMM, which machine type %q does not have; use q35", fw.Machine), nil)
+		}
+		if vtd {
+			return fw, contracts.NewInvalidSpecError(fmt.Sprintf(
+				"spec.machineType: machine type %q has no IOMMU for securityProfile.vtdEnabled; use q35", fw.Machine), nil)
+		}
+	default:
+		return fw, contracts.NewInvalidSpecError(fmt.Sprintf(
+			"spec.machineType: unsupported machine type %q; use pc, q35 or a versioned pc-i440fx-* or pc-q35-* type", fw.Machine), nil)
+	}
+	return fw, nil
+}
+
+// resolveFirmware is firmwareFromClass plus, for UEFI, the OVMF images
+// found on the libvirt host.
+func (p *Provider) resolveFirmware(ctx context.Context, class contracts.VMClass) (domainFirmware, error) {
+	fw, err := firmwareFromClass(class)
+	if err != nil || !fw.UEFI {
+		return fw, err
+	}
+	readable := func(path string) bool {
+		_, err := p.virshProvider.runVirshCommand(ctx, "!", "test", "-r", path)
+		return err == nil
+	}
+	if err := locateOVMF(&fw, readable); err != nil {
+		return fw, err
+	}
+	log.Printf("INFO Using UEFI firmware %s (varstore template %s), machine %s", fw.Loader, fw.NVRAM, fw.Machine)
+	return fw, nil
+}
+
+// locateOVMF sets the loader and varstore template of a UEFI domain to
+// the first OVMF images readable on the host, trying the paths from the
+// environment in place of the distribution paths when they are set. A
+// host without the firmware the class asks for is an InvalidSpec: the
+// class cannot be booted there until OVMF is installed.
+func locateOVMF(fw *domainFirmware, readable func(path string) bool) error {
+	candidates, codeEnv, varsEnv, kind := ovmfCandidates, EnvOVMFCode, EnvOVMFVars, "UEFI"
+	if fw.SecureBoot {
+		candidates, codeEnv, varsEnv, kind = ovmfSecureBootCandidates, EnvOVMFSecureBootCode, EnvOVMFSecureBootVars, "Secure Boot UEFI"
+	}
+
+	code, vars := strings.TrimSpace(os.Getenv(codeEnv)), strings.TrimSpace(os.Getenv(varsEnv))
+	switch {
+	case code != "" && vars != "":
+		candidates = []ovmfImages{{code, vars}}
+	case code != "" || vars != "":
+		return contracts.NewInvalidSpecError(fmt.Sprintf("%s and %s must be set together", codeEnv, varsEnv), nil)
+	}
+
+	var looked []string
+	for _, c := range candidates {
+		if readable(c.code) && readable(c.vars) {
+			fw.Loader, fw.NVRAM = c.code, c.vars
+			return nil
+		}
+		looked = append(looked, c.code)
+	}
+	return contracts.NewInvalidSpecError(fmt.Sprintf(
+		"spec.firmware: the libvirt host has no %s firmware (looked for %s); install OVMF or set %s and %s",
+		kind, strings.Join(looked, ", "), codeEnv, varsEnv), nil)
+}
@@ -203,6 +203,25 @@ func (g *GuestAgentProvider) getGuestOSInfo(ctx context.Context, domainName stri
 	return nil
 }
 
+// GetHostname retrieves the guest's hostname
+func (g *GuestAgentProvider) GetHostname(ctx context.Context, domainName string) (string, error) {
+	heredocCmd := fmt.Sprintf("virsh qemu-agent-command %s \"$(cat <<'EOF'\n{\"execute\":\"guest-get-host-name\"}\nEOF\n)\"", domainName)
+	result, err := g.virshProvider.runVirshCommand(ctx, "!", "bash", "-c", heredocCmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	var response struct {
+		Return struct {
+			HostName string `json:"host-name"`
+		} `json:"return"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &response); err != nil {
+		return "", fmt.Errorf("failed to parse hostname response: %w", err)
+	}
+	return response.Return.HostName, nil
+}
+
 // getGuestNetworkInfo retrieves network interface information from the guest
 func (g *GuestAgentProvider) getGuestNetworkInfo(ctx context.Context, domainName string, info *GuestAgentInfo) error {
 	// Get network interfaces using guest-network-get-interfaces command
@@ -212,6 +231,18 @@ func (g *GuestAgentProvider) getGuestNetworkInfo(ctx context.Context, domainName
 		return fmt.Errorf("failed to get network info: %w", err)
 	}
 
+	interfaces, err := parseGuestNetworkInterfaces(result.Stdout)
+	if err != nil {
+		return err
+	}
+	info.NetworkInterfaces = append(info.NetworkInterfaces, interfaces...)
+
+	log.Printf("DEBUG Retrieved %d network interfaces", len(info.NetworkInterfaces))
+	return nil
+}
+
+// parseGuestNetworkInterfaces decodes a guest-network-get-interfaces reply.
+func parseGuestNetworkInterfaces(raw string) ([]GuestNetworkInterface, error) {
 	// Parse the JSON response
 	var response struct {
 		Return []struct {
@@ -231,11 +262,12 @@ func (g *GuestAgentProvider) getGuestNetworkInfo(ctx context.Context, domainName
 		} `json:"return"`
 	}
 
-	if err := json.Unmarshal([]byte(result.Stdout), &response); err != nil {
-		return fmt.Errorf("failed to parse network info response: %w", err)
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse network info response: %w", err)
 	}
 
 	// Convert to our structure
+	var interfaces []GuestNetworkInterface
 	for _, iface := range response.Return {
 		guestIface := GuestNetworkInterface{
 			Name:         iface.Name,
@@ -253,11 +285,9 @@ func (g *GuestAgentProvider) getGuestNetworkInfo(ctx context.Context, domainName
 			guestIface.IPAddresses = append(guestIface.IPAddresses, ip.IPAddress)
 		}
 
-		info.NetworkInterfaces = append(info.NetworkInterfaces, guestIface)
+		interfaces = append(interfaces, guestIface)
 	}
-
-	log.Printf("DEBUG Retrieved %d network interfaces", len(info.NetworkInterfaces))
-	return nil
+	return interfaces, nil
 }
 
 // getGuestFilesystemInfo retrieves filesystem information from the guest
@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// minCPUSampleInterval is the shortest window a CPU percentage is computed
+// over. Describe calls closer together than this (a reconcile racing the
+// stats collector) reuse the last value instead of producing a noisy one.
+const minCPUSampleInterval = time.Second
+
+// cpuSampler turns libvirt's cumulative cpu.time counter into a utilization
+// percentage by remembering the previous sample of each domain. The zero
+// value is ready to use.
+type cpuSampler struct {
+	mu   sync.Mutex
+	last map[string]cpuSample
+}
+
+type cpuSample struct {
+	cpuNS   int64
+	at      time.Time
+	percent float64
+	valid   bool
+}
+
+// percent records a sample of cpuNS nanoseconds of CPU time consumed by a
+// domain with vcpus vCPUs, and returns utilization since the previous
+// sample. ok is false for the first sample, or when the counter went
+// backwards because the domain restarted.
+func (s *cpuSampler) percent(id string, cpuNS, vcpus int64, now time.Time) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = map[string]cpuSample{}
+	}
+
+	prev, seen := s.last[id]
+	elapsed := now.Sub(prev.at)
+	if seen && elapsed < minCPUSampleInterval && cpuNS >= prev.cpuNS {
+		return prev.percent, prev.valid
+	}
+
+	next := cpuSample{cpuNS: cpuNS, at: now}
+	if seen && vcpus > 0 && cpuNS >= prev.cpuNS {
+		pct := float64(cpuNS-prev.cpuNS) / float64(elapsed.Nanoseconds()*vcpus) * 100
+		if pct > 100 {
+			pct = 100
+		}
+		next.percent, next.valid = pct, true
+	}
+	s.last[id] = next
+	return next.percent, next.valid
+}
+
+// forget drops the sample of a domain that no longer exists.
+func (s *cpuSampler) forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.last, id)
+}
+
+// guestStats builds the normalized stats of a running domain from the
+// domainInfoMap keys. Memory in use is what the balloon driver reports the
+// guest has available minus what it leaves unused (both KiB); without a
+// balloon driver in the guest it falls back to the QEMU process RSS, which
+// also counts emulator overhead.
+func (p *Provider) guestStats(id string, info map[string]string, now time.Time) *contracts.GuestStats {
+	stats := &contracts.GuestStats{}
+
+	cpuNS, errT := strconv.ParseInt(info["cpu_time"], 10, 64)
+	vcpus, errC := strconv.ParseInt(info["CPU(s)"], 10, 64)
+	if errT == nil && errC == nil {
+		if pct, ok := p.cpuSamples.percent(id, cpuNS, vcpus, now); ok {
+			stats.CPUUsagePercent = &pct
+		}
+	}
+
+	available, errA := strconv.ParseInt(info["memory_available"], 10, 64)
+	unused, errU := strconv.ParseInt(info["memory_unused"], 10, 64)
+	if errA == nil && errU == nil && available >= unused {
+		mem := (available - unused) * 1024
+		stats.MemoryUsageBytes = &mem
+	} else if rss, err := strconv.ParseInt(info["memory_rss"], 10, 64); err == nil && rss > 0 {
+		mem := rss * 1024
+		stats.MemoryUsageBytes = &mem
+	}
+
+	if stats.CPUUsagePercent == nil && stats.MemoryUsageBytes == nil {
+		return nil
+	}
+	return stats
+}
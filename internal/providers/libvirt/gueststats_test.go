@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestStats(t *testing.T) {
+	p := &Provider{}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	info := map[string]string{
+		"CPU(s)":           "2",
+		"cpu_time":         "10000000000",
+		"memory_available": "4194304",
+		"memory_unused":    "3145728",
+		"memory_rss":       "2097152",
+	}
+
+	// The first sample has no window to compute CPU over.
+	stats := p.guestStats("vm-1", info, now)
+	require.NotNil(t, stats)
+	assert.Nil(t, stats.CPUUsagePercent)
+	require.NotNil(t, stats.MemoryUsageBytes)
+	assert.Equal(t, int64(1<<30), *stats.MemoryUsageBytes)
+
+	// 5s of CPU over 10s on two vCPUs is 25%.
+	info["cpu_time"] = "15000000000"
+	stats = p.guestStats("vm-1", info, now.Add(10*time.Second))
+	require.NotNil(t, stats.CPUUsagePercent)
+	assert.InDelta(t, 25.0, *stats.CPUUsagePercent, 1e-9)
+
+	// A second call within the minimum interval repeats the last value.
+	info["cpu_time"] = "15100000000"
+	stats = p.guestStats("vm-1", info, now.Add(10*time.Second+100*time.Millisecond))
+	require.NotNil(t, stats.CPUUsagePercent)
+	assert.InDelta(t, 25.0, *stats.CPUUsagePercent, 1e-9)
+
+	// A restarted domain's counter goes backwards and starts a new window.
+	info["cpu_time"] = "1000000000"
+	stats = p.guestStats("vm-1", info, now.Add(20*time.Second))
+	assert.Nil(t, stats.CPUUsagePercent)
+
+	// Without balloon stats, memory falls back to the QEMU RSS.
+	delete(info, "memory_available")
+	stats = p.guestStats("vm-1", info, now.Add(30*time.Second))
+	assert.Equal(t, int64(2<<30), *stats.MemoryUsageBytes)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// EnvPeerHosts lists the libvirt URIs of the hosts this provider manages
+// besides its endpoint, separated by commas. Every host is reached with the
+// provider's credentials. With peers configured, running domains can be
+// live-migrated between the hosts (MigrateHost) and per-VM RPCs follow a
+// domain to whichever host runs it.
+const EnvPeerHosts = "VIRTRIGAUD_LIBVIRT_PEER_HOSTS"
+
+// libvirtHost is one hypervisor host the provider manages. Its provider is
+// bound to the host's libvirt connection.
+type libvirtHost struct {
+	name     string
+	provider *Provider
+}
+
+// hostRouter tracks the hosts a provider manages and which of them runs
+// each domain. hosts[0] is the provider's endpoint.
+type hostRouter struct {
+	hosts []*libvirtHost
+
+	mu sync.Mutex
+	// domains maps a domain name to the host it was last found on.
+	domains map[string]*libvirtHost
+	// migrations holds the live migrations started by MigrateHost, keyed by
+	// task reference.
+	migrations map[string]*migrationJob
+}
+
+// newHostRouter returns a router for primary, the provider connected to
+// the endpoint, and the peer hosts listed in peerURIs (see EnvPeerHosts).
+// A peer whose connection cannot be set up is logged and left out.
+func newHostRouter(ctx context.Context, primary *Provider, peerURIs string) *hostRouter {
+	r := &hostRouter{
+		hosts:      []*libvirtHost{{name: hostNameFromURI(primary.virshProvider.uri), provider: primary}},
+		domains:    map[string]*libvirtHost{},
+		migrations: map[string]*migrationJob{},
+	}
+	for _, uri := range splitPeerHosts(peerURIs) {
+		name := hostNameFromURI(uri)
+		if r.byName(name) != nil {
+			log.Printf("WARN Ignoring peer host %s: host %s is already managed", uri, name)
+			continue
+		}
+		virshProvider := NewVirshProvider(&ProviderConfig{Spec: ProviderSpec{Endpoint: uri}})
+		virshProvider.logger = primary.virshProvider.logger
+		if err := virshProvider.Initialize(ctx); err != nil {
+			if virshProvider.uri == "" {
+				log.Printf("ERROR Ignoring peer host %s: %v", uri, err)
+				continue
+			}
+			// The connection is configured; the host may only be down.
+			log.Printf("WARN Peer host %s is not reachable yet: %v", name, err)
+		}
+		r.hosts = append(r.hosts, &libvirtHost{
+			name: name,
+			provider: &Provider{
+				config:        primary.config,
+				k8sClient:     primary.k8sClient,
+				virshProvider: virshProvider,
+				credentials:   primary.credentials,
+				hosts:         r,
+			},
+		})
+		log.Printf("INFO Managing peer libvirt host %s", name)
+	}
+	return r
+}
+
+// splitPeerHosts splits an EnvPeerHosts value into URIs.
+func splitPeerHosts(value string) []string {
+	var uris []string
+	for _, uri := range strings.Split(value, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// hostNameFromURI returns the host a libvirt URI connects to, or this
+// machine's hostname for a local URI such as qemu:///system.
+func hostNameFromURI(uri string) string {
+	if parsed, err := url.Parse(uri); err == nil && parsed.Hostname() != "" {
+		return parsed.Hostname()
+	}
+	if name, err := os.Hostname(); err == nil && name != "" {
+		return name
+	}
+	return "localhost"
+}
+
+// multiHost reports whether the router manages more than one host.
+func (r *hostRouter) multiHost() bool {
+	return r != nil && len(r.hosts) > 1
+}
+
+// names returns the names of the managed hosts.
+func (r *hostRouter) names() []string {
+	names := make([]string, 0, len(r.hosts))
+	for _, h := range r.hosts {
+		names = append(names, h.name)
+	}
+	return names
+}
+
+// byName returns the host called name, or nil.
+func (r *hostRouter) byName(name string) *libvirtHost {
+	for _, h := range r.hosts {
+		if h.name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+// locate returns the host that runs domain id. With a single host it is
+// always that host. Otherwise the host the domain was last seen on is
+// trusted; on a miss every host is asked, endpoint first. found is false
+// when no host knows the domain, in which case the endpoint is returned so
+// callers report the miss as before.
+func (r *hostRouter) locate(ctx context.Context, id string) (host *libvirtHost, found bool) {
+	if !r.multiHost() {
+		return r.hosts[0], true
+	}
+	r.mu.Lock()
+	h, ok := r.domains[id]
+	r.mu.Unlock()
+	if ok {
+		return h, true
+	}
+	for _, h := range r.hosts {
+		// domid succeeds for defined domains whether or not they run.
+		if _, err := h.provider.virshProvider.runVirshCommand(ctx, "domid", id); err == nil {
+			r.remember(id, h)
+			return h, true
+		}
+	}
+	return r.hosts[0], false
+}
+
+// remember records that domain id runs on h.
+func (r *hostRouter) remember(id string, h *libvirtHost) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.domains[id] = h
+}
+
+// forget drops what the router knows about domain id, so the next locate
+// asks every host again. Callers forget a domain when the host they
+// trusted no longer has it, e.g. after an operator migrated it by hand.
+func (r *hostRouter) forget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.domains, id)
+}
+
+// hostOf returns the name of the host p is bound to, or "" for a provider
+// without a router.
+func (p *Provider) hostOf() string {
+	if p.hosts == nil {
+		return ""
+	}
+	for _, h := range p.hosts.hosts {
+		if h.provider == p {
+			return h.name
+		}
+	}
+	return ""
+}
+
+// providerFor returns the provider bound to the host that runs domain id:
+// s.provider itself unless peer hosts are configured.
+func (s *Server) providerFor(ctx context.Context, id string) contracts.Provider {
+	p, ok := s.provider.(*Provider)
+	if !ok || p == nil || !p.hosts.multiHost() {
+		return s.provider
+	}
+	h, _ := p.hosts.locate(ctx, id)
+	return h.provider
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/version"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// virshVersion is the subset of `virsh version` output GetInfo reports.
+type virshVersion struct {
+	// Library is the libvirt version of the daemon, e.g. "10.0.0".
+	Library string
+	// Hypervisor is the driver and its version, e.g. "QEMU 8.2.2".
+	Hypervisor string
+}
+
+// parseVirshVersion parses `virsh version` output:
+//
+//	Compiled against library: libvirt 10.0.0
+//	Using library: libvirt 10.0.0
+//	Using API: QEMU 10.0.0
+//	Running hypervisor: QEMU 8.2.2
+func parseVirshVersion(out string) virshVersion {
+	var v virshVersion
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Using library":
+			v.Library = strings.TrimSpace(strings.TrimPrefix(value, "libvirt"))
+		case "Running hypervisor":
+			v.Hypervisor = value
+		}
+	}
+	return v
+}
+
+// GetInfo reports the provider build and the libvirt and hypervisor driver
+// versions of the host, from `virsh version`.
+func (s *Server) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	libvirtProvider, ok := s.provider.(*Provider)
+	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+
+	result, err := libvirtProvider.virshProvider.runVirshCommand(ctx, "version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get libvirt version: %w", err)
+	}
+
+	v := parseVirshVersion(result.Stdout)
+	return &providerv1.GetInfoResponse{
+		ProviderVersion:   version.Version,
+		GitSha:            version.GitSHA,
+		HypervisorProduct: "libvirt",
+		HypervisorVersion: v.Library,
+		HypervisorDetails: v.Hypervisor,
+	}, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVirshVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want virshVersion
+	}{
+		{
+			name: "qemu host",
+			out: "Compiled against library: libvirt 10.0.0\n" +
+				"Using library: libvirt 10.0.0\n" +
+				"Using API: QEMU 10.0.0\n" +
+				"Running hypervisor: QEMU 8.2.2\n",
+			want: virshVersion{Library: "10.0.0", Hypervisor: "QEMU 8.2.2"},
+		},
+		{
+			name: "no hypervisor connection",
+			out:  "Compiled against library: libvirt 8.0.0\nUsing library: libvirt 8.0.0\n",
+			want: virshVersion{Library: "8.0.0"},
+		},
+		{
+			name: "empty",
+			out:  "",
+			want: virshVersion{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseVirshVersion(tt.out))
+		})
+	}
+}
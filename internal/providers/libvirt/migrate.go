@@ -0,0 +1,502 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+const (
+	// migrationTaskPrefix starts the task references MigrateHost returns.
+	migrationTaskPrefix = "migrate:"
+
+	// migrationJobRetention is how long a finished migration stays
+	// answerable through TaskStatus.
+	migrationJobRetention = time.Hour
+)
+
+// sharedFilesystems are the `stat -f -c %T` names of network and cluster
+// filesystems. A disk file on one of them is reached at the same path from
+// every host, so migrating it needs no storage copy.
+var sharedFilesystems = []string{"nfs", "cifs", "smb2", "ceph", "gfs", "gfs2", "ocfs2", "lustre", "gpfs"}
+
+// sharedPoolTypes are the libvirt storage pool types whose block volumes
+// every host reaches at the same path.
+var sharedPoolTypes = []string{"iscsi", "iscsi-direct", "mpath", "rbd", "gluster", "netfs", "vstorage"}
+
+// migrationDisk is a disk of a domain about to be migrated.
+type migrationDisk struct {
+	// Path is the file or block device backing the disk; empty for network
+	// disks.
+	Path     string
+	Block    bool
+	ReadOnly bool
+	// Shared reports that the target host reaches Path too.
+	Shared bool
+}
+
+// migrationDomainXML is the subset of `virsh dumpxml` a migration plan
+// needs.
+type migrationDomainXML struct {
+	Disks []struct {
+		Type     string    `xml:"type,attr"`
+		Device   string    `xml:"device,attr"`
+		ReadOnly *struct{} `xml:"readonly"`
+		Source   struct {
+			File string `xml:"file,attr"`
+			Dev  string `xml:"dev,attr"`
+		} `xml:"source"`
+	} `xml:"devices>disk"`
+}
+
+// parseMigrationDisks returns the disks of a domain XML. Network disks are
+// shared by definition; cdroms are read-only whether or not the XML says so.
+func parseMigrationDisks(raw string) ([]migrationDisk, error) {
+	var dx migrationDomainXML
+	if err := xml.Unmarshal([]byte(raw), &dx); err != nil {
+		return nil, fmt.Errorf("failed to parse domain XML: %w", err)
+	}
+	var disks []migrationDisk
+	for _, d := range dx.Disks {
+		disk := migrationDisk{
+			ReadOnly: d.ReadOnly != nil || d.Device == "cdrom" || d.Device == "floppy",
+		}
+		switch d.Type {
+		case "network":
+			disk.Shared = true
+		case "block":
+			disk.Path, disk.Block = d.Source.Dev, true
+		default:
+			disk.Path = d.Source.File
+		}
+		if disk.Path == "" && !disk.Shared {
+			continue // empty cdrom drive
+		}
+		disks = append(disks, disk)
+	}
+	return disks, nil
+}
+
+// migrationPlan is what MigrateHost decided before starting virsh migrate.
+type migrationPlan struct {
+	disks []migrationDisk
+	// copyStorage selects --copy-storage-all: at least one writable disk is
+	// on storage the target host does not share.
+	copyStorage bool
+	// createdOnTarget are the writable disk paths the storage copy creates
+	// on the target host. A failed migration deletes them again.
+	createdOnTarget []string
+}
+
+// migrationJob is a live migration started by MigrateHost.
+type migrationJob struct {
+	ref     string
+	vmID    string
+	source  *libvirtHost
+	target  *libvirtHost
+	plan    migrationPlan
+	started time.Time
+
+	mu       sync.Mutex
+	done     bool
+	err      error
+	finished time.Time
+}
+
+func (j *migrationJob) finish(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.done, j.err, j.finished = true, err, time.Now()
+}
+
+func (j *migrationJob) state() (done bool, err error, finished time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.done, j.err, j.finished
+}
+
+// migrate starts a live migration of domain id to the host called
+// targetName and returns its task reference. It returns "" when the domain
+// already runs there, and the reference of the migration in flight when
+// one was already started for the domain.
+func (r *hostRouter) migrate(ctx context.Context, id, targetName string) (string, error) {
+	target := r.byName(targetName)
+	if target == nil {
+		return "", errors.NewInvalidSpec("unknown host %q; this provider manages %s", targetName, strings.Join(r.names(), ", "))
+	}
+	source, found := r.locate(ctx, id)
+	if !found {
+		return "", errors.NewNotFound("domain", id)
+	}
+	if source == target {
+		return "", nil
+	}
+
+	if ref := r.migrationInFlight(id, nil); ref != "" {
+		return ref, nil
+	}
+
+	state, err := source.provider.virshProvider.getDomainState(ctx, id)
+	if err != nil {
+		return "", errors.NewUnavailable("libvirt", err)
+	}
+	if state != "running" && state != "paused" {
+		return "", errors.NewInvalidSpec("domain %s is %s; only running domains can be live-migrated", id, state)
+	}
+
+	plan, err := source.provider.planMigration(ctx, id, target)
+	if err != nil {
+		return "", err
+	}
+
+	job := &migrationJob{
+		ref:     fmt.Sprintf("%s%s:%d", migrationTaskPrefix, id, time.Now().UnixNano()),
+		vmID:    id,
+		source:  source,
+		target:  target,
+		plan:    plan,
+		started: time.Now(),
+	}
+	if ref := r.migrationInFlight(id, job); ref != "" {
+		return ref, nil
+	}
+
+	log.Printf("INFO Live-migrating domain %s from %s to %s (copy storage: %t)", id, source.name, target.name, plan.copyStorage)
+	go r.runMigration(job)
+	return job.ref, nil
+}
+
+// migrationInFlight returns the reference of the migration of domain id
+// still running, pruning finished migrations past their retention. When
+// none runs and job is not nil, job is registered as that migration.
+func (r *hostRouter) migrationInFlight(id string, job *migrationJob) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ref, j := range r.migrations {
+		if done, _, finished := j.state(); done && time.Since(finished) > migrationJobRetention {
+			delete(r.migrations, ref)
+		} else if !done && j.vmID == id {
+			return ref
+		}
+	}
+	if job != nil {
+		r.migrations[job.ref] = job
+	}
+	return ""
+}
+
+// planMigration inspects the disks of domain id on p's host: it decides
+// whether the storage has to be copied to target, refuses read-only disks
+// the target cannot open, and records which disk files the copy creates.
+func (p *Provider) planMigration(ctx context.Context, id string, target *libvirtHost) (migrationPlan, error) {
+	raw, err := p.virshProvider.runVirshCommand(ctx, "dumpxml", id)
+	if err != nil {
+		return migrationPlan{}, errors.NewUnavailable("libvirt", fmt.Errorf("failed to dump domain XML: %w", err))
+	}
+	disks, err := parseMigrationDisks(raw.Stdout)
+	if err != nil {
+		return migrationPlan{}, errors.NewInternal("failed to read the disks of domain "+id, err)
+	}
+
+	var plan migrationPlan
+	for i := range disks {
+		disk := &disks[i]
+		if !disk.Shared {
+			disk.Shared = p.storageShared(ctx, disk)
+		}
+		if disk.Shared {
+			continue
+		}
+		exists := target.provider.pathExists(ctx, disk.Path)
+		if disk.ReadOnly {
+			// libvirt copies writable disks only; the target has to
+			// provide read-only media such as ISOs itself.
+			if !exists {
+				return migrationPlan{}, errors.NewInvalidSpec("read-only disk %s of domain %s is on storage host %s does not share; copy it there or detach it first", disk.Path, id, target.name)
+			}
+			continue
+		}
+		plan.copyStorage = true
+		if !exists {
+			plan.createdOnTarget = append(plan.createdOnTarget, disk.Path)
+		}
+	}
+	plan.disks = disks
+	return plan, nil
+}
+
+// storageShared reports whether disk is on storage every host reaches: a
+// file on a network or cluster filesystem, or a block volume of a shared
+// pool. Anything it cannot tell is treated as host-local, which costs a
+// storage copy rather than a failed migration.
+func (p *Provider) storageShared(ctx context.Context, disk *migrationDisk) bool {
+	if disk.Block {
+		pool, err := p.virshProvider.runVirshCommand(ctx, "vol-pool", disk.Path)
+		if err != nil {
+			return false
+		}
+		raw, err := p.virshProvider.runVirshCommand(ctx, "pool-dumpxml", strings.TrimSpace(pool.Stdout))
+		if err != nil {
+			return false
+		}
+		var poolXML struct {
+			Type string `xml:"type,attr"`
+		}
+		if err := xml.Unmarshal([]byte(raw.Stdout), &poolXML); err != nil {
+			return false
+		}
+		return slices.Contains(sharedPoolTypes, poolXML.Type)
+	}
+	res, err := p.virshProvider.runVirshCommand(ctx, "!", "stat", "-f", "-c", "%T", disk.Path)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(sharedFilesystems, strings.TrimSpace(res.Stdout))
+}
+
+// pathExists reports whether path exists on p's host.
+func (p *Provider) pathExists(ctx context.Context, path string) bool {
+	_, err := p.virshProvider.runVirshCommand(ctx, "!", "test", "-e", path)
+	return err == nil
+}
+
+// migrationArgs returns the virsh arguments that live-migrate domain id to
+// destURI.
+func migrationArgs(id, destURI string, copyStorage bool) []string {
+	args := []string{"migrate", "--live", "--persistent", "--undefinesource"}
+	if copyStorage {
+		args = append(args, "--copy-storage-all")
+	}
+	return append(args, id, destURI)
+}
+
+// migrationDestURI returns the URI virsh migrate connects to the target
+// with. When the source runs virsh on the source host over SSH (password
+// authentication), the query options that point at files in the provider
+// pod, such as keyfile, are dropped; the source host then reaches the
+// target with its own SSH configuration.
+func migrationDestURI(source, target *VirshProvider) string {
+	if source.credentials == nil || source.credentials.Password == "" || !strings.Contains(source.uri, "ssh://") {
+		return target.uri
+	}
+	parsed, err := url.Parse(target.uri)
+	if err != nil {
+		return target.uri
+	}
+	parsed.RawQuery = ""
+	return parsed.String()
+}
+
+// runMigration runs job to completion. virsh migrate holds one of the
+// source host's command slots for as long as the migration runs.
+func (r *hostRouter) runMigration(job *migrationJob) {
+	ctx := context.Background()
+	src := job.source.provider.virshProvider
+	args := migrationArgs(job.vmID, migrationDestURI(src, job.target.provider.virshProvider), job.plan.copyStorage)
+
+	_, err := src.runVirshCommand(ctx, args...)
+	if err == nil {
+		r.remember(job.vmID, job.target)
+		job.source.provider.cpuSamples.forget(job.vmID)
+		job.source.provider.removeMigratedDisks(ctx, job)
+		log.Printf("INFO Domain %s now runs on %s (%s)", job.vmID, job.target.name, time.Since(job.started).Round(time.Second))
+		job.finish(nil)
+		return
+	}
+
+	log.Printf("ERROR Live migration of domain %s to %s failed: %v", job.vmID, job.target.name, err)
+	if moved := r.rollbackMigration(ctx, job); moved {
+		// libvirt reported a failure after the target took over.
+		r.remember(job.vmID, job.target)
+		job.finish(nil)
+		return
+	}
+	job.finish(fmt.Errorf("live migration to %s failed; the domain keeps running on %s: %w", job.target.name, job.source.name, err))
+}
+
+// rollbackMigration undoes what a failed migration left behind, so the
+// domain keeps running on the source host alone: it aborts a job still
+// running, resumes a domain paused for the switch-over, removes a
+// half-created copy of the domain from the target and deletes the disk
+// files the storage copy created there. It never touches the target when
+// the source no longer has the domain; moved then reports whether the
+// target runs it.
+func (r *hostRouter) rollbackMigration(ctx context.Context, job *migrationJob) (moved bool) {
+	src := job.source.provider.virshProvider
+	dst := job.target.provider.virshProvider
+
+	// Nothing to abort is the usual case: virsh migrate already cancelled
+	// the job when it failed.
+	_, _ = src.runVirshCommand(ctx, "domjobabort", job.vmID)
+
+	state, err := src.getDomainState(ctx, job.vmID)
+	if err != nil {
+		if _, err := dst.getDomainState(ctx, job.vmID); err == nil {
+			log.Printf("WARN Domain %s left %s despite the failed migration; it runs on %s", job.vmID, job.source.name, job.target.name)
+			return true
+		}
+		log.Printf("ERROR Domain %s is on neither %s nor %s after the failed migration", job.vmID, job.source.name, job.target.name)
+		return false
+	}
+	if state == "paused" {
+		if _, err := src.runVirshCommand(ctx, "resume", job.vmID); err != nil {
+			log.Printf("WARN Failed to resume domain %s on %s after the failed migration: %v", job.vmID, job.source.name, err)
+		}
+	}
+
+	if targetState, err := dst.getDomainState(ctx, job.vmID); err == nil {
+		if targetState != "shut off" {
+			if _, err := dst.runVirshCommand(ctx, "destroy", job.vmID); err != nil {
+				log.Printf("WARN Failed to stop the incomplete copy of domain %s on %s: %v", job.vmID, job.target.name, err)
+			}
+		}
+		// A transient copy is gone once stopped; undefine a persistent one.
+		// Its NVRAM file may be shared with the source, so it stays.
+		_, _ = dst.runVirshCommand(ctx, "undefine", job.vmID, "--keep-nvram")
+	}
+
+	for _, path := range job.plan.createdOnTarget {
+		if !job.target.provider.pathExists(ctx, path) {
+			continue
+		}
+		if _, err := dst.runVirshCommand(ctx, "vol-delete", path); err != nil {
+			log.Printf("WARN Failed to delete %s copied to %s by the failed migration: %v", path, job.target.name, err)
+		}
+	}
+	return false
+}
+
+// removeMigratedDisks deletes the source copies of the disks a successful
+// storage copy moved to the target: the domain no longer uses them.
+func (p *Provider) removeMigratedDisks(ctx context.Context, job *migrationJob) {
+	if !job.plan.copyStorage {
+		return
+	}
+	for _, disk := range job.plan.disks {
+		if disk.Shared || disk.ReadOnly {
+			continue
+		}
+		if _, err := p.virshProvider.runVirshCommand(ctx, "vol-delete", disk.Path); err != nil {
+			log.Printf("WARN Failed to delete %s left on %s by the migration of %s: %v", disk.Path, job.source.name, job.vmID, err)
+		}
+	}
+}
+
+// migrationStatus answers TaskStatus for a migration task. While the
+// migration runs, the message reports its progress from virsh domjobinfo.
+// A reference this provider instance does not know, typically after a
+// restart killed the migration, is reported done: Describe tells where the
+// domain runs.
+func (r *hostRouter) migrationStatus(ctx context.Context, ref string) *providerv1.TaskStatusResponse {
+	r.mu.Lock()
+	job, ok := r.migrations[ref]
+	r.mu.Unlock()
+	if !ok {
+		return &providerv1.TaskStatusResponse{
+			Done:    true,
+			Message: "migration task is unknown to this provider instance; the VM stays where Describe reports it",
+		}
+	}
+
+	done, err, _ := job.state()
+	if done {
+		resp := &providerv1.TaskStatusResponse{Done: true, Message: "running on " + job.target.name}
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		return resp
+	}
+
+	progress := "starting"
+	if res, err := job.source.provider.virshProvider.runVirshCommand(ctx, "domjobinfo", job.vmID); err == nil {
+		if p := migrationProgress(parseDomjobinfo(res.Stdout)); p != "" {
+			progress = p
+		}
+	}
+	return &providerv1.TaskStatusResponse{
+		Message: fmt.Sprintf("migrating to %s: %s", job.target.name, progress),
+	}
+}
+
+// parseDomjobinfo parses `virsh domjobinfo` output into its fields.
+func parseDomjobinfo(out string) map[string]string {
+	fields := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = strings.Join(strings.Fields(value), " ")
+	}
+	return fields
+}
+
+// migrationProgress summarizes domjobinfo fields, e.g. "42% of 2.016 GiB
+// transferred, copying storage". It returns "" when no job runs.
+func migrationProgress(info map[string]string) string {
+	if t := info["Job type"]; t == "" || t == "None" {
+		return ""
+	}
+	processed, okProcessed := parseJobSize(info["Data processed"])
+	total, okTotal := parseJobSize(info["Data total"])
+	if !okProcessed || !okTotal || total <= 0 {
+		return ""
+	}
+	progress := fmt.Sprintf("%d%% of %s transferred", processed*100/total, info["Data total"])
+	if remaining, ok := parseJobSize(info["File remaining"]); ok && remaining > 0 {
+		return progress + ", copying storage"
+	}
+	return progress + ", copying memory"
+}
+
+// jobSizeUnits are the binary units virsh domjobinfo prints sizes in.
+var jobSizeUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// parseJobSize parses a domjobinfo size such as "545.340 MiB" into bytes.
+func parseJobSize(s string) (int64, bool) {
+	number, unit, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return 0, false
+	}
+	multiplier, ok := jobSizeUnits[unit]
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(value * multiplier), true
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationTestXML = `<domain type='kvm'>
+  <name>web-1</name>
+  <devices>
+    <disk type='file' device='disk'>
+      <source file='/var/lib/libvirt/images/web-1.qcow2'/>
+    </disk>
+    <disk type='block' device='disk'>
+      <source dev='/dev/vg0/web-1-data'/>
+    </disk>
+    <disk type='network' device='disk'>
+      <source protocol='rbd' name='pool/web-1-ceph'/>
+    </disk>
+    <disk type='file' device='cdrom'>
+      <source file='/var/lib/libvirt/images/web-1-cidata.iso'/>
+    </disk>
+    <disk type='file' device='cdrom'/>
+  </devices>
+</domain>`
+
+// TestParseMigrationDisks verifies disks are classified for the migration
+// plan and empty drives are skipped.
+func TestParseMigrationDisks(t *testing.T) {
+	disks, err := parseMigrationDisks(migrationTestXML)
+	require.NoError(t, err)
+	assert.Equal(t, []migrationDisk{
+		{Path: "/var/lib/libvirt/images/web-1.qcow2"},
+		{Path: "/dev/vg0/web-1-data", Block: true},
+		{Shared: true},
+		{Path: "/var/lib/libvirt/images/web-1-cidata.iso", ReadOnly: true},
+	}, disks)
+
+	_, err = parseMigrationDisks("<domain")
+	assert.Error(t, err)
+}
+
+// TestMigrationArgs verifies storage is only copied when asked to.
+func TestMigrationArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"migrate", "--live", "--persistent", "--undefinesource", "web-1", "qemu+ssh://root@kvm-b/system"},
+		migrationArgs("web-1", "qemu+ssh://root@kvm-b/system", false))
+	assert.Equal(t,
+		[]string{"migrate", "--live", "--persistent", "--undefinesource", "--copy-storage-all", "web-1", "qemu+ssh://root@kvm-b/system"},
+		migrationArgs("web-1", "qemu+ssh://root@kvm-b/system", true))
+}
+
+// TestMigrationDestURI verifies pod-local query options are dropped when
+// virsh runs on the source host.
+func TestMigrationDestURI(t *testing.T) {
+	target := &VirshProvider{uri: "qemu+ssh://root@kvm-b/system?keyfile=/etc/virtrigaud/key&no_verify=1"}
+
+	keyAuth := &VirshProvider{uri: "qemu+ssh://root@kvm-a/system?keyfile=/etc/virtrigaud/key", credentials: &Credentials{}}
+	assert.Equal(t, target.uri, migrationDestURI(keyAuth, target))
+
+	passwordAuth := &VirshProvider{uri: "qemu+ssh://root@kvm-a/system", credentials: &Credentials{Password: "secret"}}
+	assert.Equal(t, "qemu+ssh://root@kvm-b/system", migrationDestURI(passwordAuth, target))
+}
+
+// TestMigrationProgress verifies domjobinfo output is summarized, telling
+// the storage copy from the memory copy.
+func TestMigrationProgress(t *testing.T) {
+	storage := parseDomjobinfo(`Job type:         Unbounded
+Operation:        Outgoing migration
+Time elapsed:     5021         ms
+Data processed:   2.000 GiB
+Data remaining:   6.000 GiB
+Data total:       8.000 GiB
+File processed:   1.500 GiB
+File remaining:   5.000 GiB
+`)
+	assert.Equal(t, "25% of 8.000 GiB transferred, copying storage", migrationProgress(storage))
+
+	memory := parseDomjobinfo(`Job type:         Unbounded
+Data processed:   512.000 MiB
+Data total:       1.000 GiB
+File remaining:   0.000 B
+`)
+	assert.Equal(t, "50% of 1.000 GiB transferred, copying memory", migrationProgress(memory))
+
+	assert.Empty(t, migrationProgress(parseDomjobinfo("Job type:         None\n")))
+}
+
+// TestParseJobSize verifies domjobinfo sizes are read in binary units.
+func TestParseJobSize(t *testing.T) {
+	size, ok := parseJobSize("1.500 KiB")
+	assert.True(t, ok)
+	assert.EqualValues(t, 1536, size)
+
+	for _, bad := range []string{"", "12", "1.0 parsecs", "x MiB"} {
+		_, ok := parseJobSize(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+// TestSplitPeerHosts verifies blank entries are ignored.
+func TestSplitPeerHosts(t *testing.T) {
+	assert.Equal(t, []string{"qemu+ssh://kvm-b/system", "qemu+ssh://kvm-c/system"},
+		splitPeerHosts(" qemu+ssh://kvm-b/system,, qemu+ssh://kvm-c/system ,"))
+	assert.Empty(t, splitPeerHosts(""))
+}
+
+// TestHostNameFromURI verifies remote URIs are named after their host.
+func TestHostNameFromURI(t *testing.T) {
+	assert.Equal(t, "kvm-b", hostNameFromURI("qemu+ssh://root@kvm-b:2222/system"))
+	assert.NotEmpty(t, hostNameFromURI("qemu:///system"))
+}
+
+// TestHostRouterMigrate_Refused verifies requests are refused before any
+// host is contacted.
+func TestHostRouterMigrate_Refused(t *testing.T) {
+	ctx := context.Background()
+	a := &libvirtHost{name: "kvm-a", provider: &Provider{}}
+	b := &libvirtHost{name: "kvm-b", provider: &Provider{}}
+	r := &hostRouter{
+		hosts:      []*libvirtHost{a, b},
+		domains:    map[string]*libvirtHost{"web-1": b},
+		migrations: map[string]*migrationJob{},
+	}
+
+	_, err := r.migrate(ctx, "web-1", "kvm-z")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kvm-a, kvm-b")
+
+	ref, err := r.migrate(ctx, "web-1", "kvm-b")
+	require.NoError(t, err)
+	assert.Empty(t, ref, "a domain already on the target needs no task")
+}
+
+// TestHostRouter_SingleHost verifies a provider without peers routes every
+// domain to its endpoint and does not advertise migration.
+func TestHostRouter_SingleHost(t *testing.T) {
+	primary := &Provider{}
+	r := &hostRouter{hosts: []*libvirtHost{{name: "kvm-a", provider: primary}}}
+	primary.hosts = r
+
+	assert.False(t, r.multiHost())
+	h, found := r.locate(context.Background(), "anything")
+	assert.True(t, found)
+	assert.Same(t, primary, h.provider)
+	assert.Equal(t, "kvm-a", primary.hostOf())
+
+	var none *hostRouter
+	assert.False(t, none.multiHost())
+}
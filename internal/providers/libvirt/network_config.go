@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// staticNetworkConfig renders a cloud-init network-config (version 2)
+// giving networks their static IPv4 and IPv6 addresses. It returns "" and
+// networks unchanged when none has a static address, leaving the guest's
+// default networking in place.
+//
+// NICs are matched by MAC address, so every network without one is given a
+// generated MAC; the returned networks carry them into the domain XML.
+// A NIC without a static IPv4 address keeps DHCPv4, and one without a
+// static IPv6 address keeps router advertisements.
+func staticNetworkConfig(networks []contracts.NetworkAttachment) ([]contracts.NetworkAttachment, string, error) {
+	if !slices.ContainsFunc(networks, func(n contracts.NetworkAttachment) bool {
+		return n.StaticIP != "" || n.StaticIPv6 != ""
+	}) {
+		return networks, "", nil
+	}
+
+	networks = slices.Clone(networks)
+	var b strings.Builder
+	b.WriteString("version: 2\nethernets:\n")
+	for i := range networks {
+		n := &networks[i]
+		if n.MacAddress == "" {
+			mac, err := generateRandomMAC()
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to generate a MAC address: %w", err)
+			}
+			n.MacAddress = mac
+		}
+		fmt.Fprintf(&b, "  nic%d:\n    match:\n      macaddress: %q\n", i, strings.ToLower(n.MacAddress))
+
+		var addresses, routes []string
+		if n.StaticIP == "" {
+			b.WriteString("    dhcp4: true\n")
+		} else {
+			addr := n.StaticIP
+			if n.Prefix > 0 && !strings.Contains(addr, "/") {
+				addr = fmt.Sprintf("%s/%d", addr, n.Prefix)
+			}
+			addresses = append(addresses, addr)
+			if n.Gateway != "" {
+				routes = append(routes, n.Gateway)
+			}
+		}
+		if n.StaticIPv6 != "" {
+			addresses = append(addresses, n.StaticIPv6)
+			if n.GatewayIPv6 != "" {
+				routes = append(routes, n.GatewayIPv6)
+			}
+		}
+
+		if len(addresses) > 0 {
+			b.WriteString("    addresses:\n")
+			for _, a := range addresses {
+				fmt.Fprintf(&b, "      - %q\n", a)
+			}
+		}
+		if len(routes) > 0 {
+			b.WriteString("    routes:\n")
+			for _, via := range routes {
+				fmt.Fprintf(&b, "      - to: default\n        via: %q\n", via)
+			}
+		}
+		if n.DNS != "" {
+			var servers []string
+			for _, s := range strings.Split(n.DNS, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					servers = append(servers, fmt.Sprintf("%q", s))
+				}
+			}
+			fmt.Fprintf(&b, "    nameservers:\n      addresses: [%s]\n", strings.Join(servers, ", "))
+		}
+	}
+	return networks, b.String(), nil
+}
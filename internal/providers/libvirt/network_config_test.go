@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestStaticNetworkConfig(t *testing.T) {
+	in := []contracts.NetworkAttachment{
+		{
+			Name: "dual", MacAddress: "52:54:00:AA:BB:CC",
+			StaticIP: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1", DNS: "10.0.0.53, 2001:db8::53",
+			StaticIPv6: "2001:db8::5/64", GatewayIPv6: "fe80::1",
+		},
+		{Name: "v6only", StaticIPv6: "2001:db8:1::5/64"},
+		{Name: "dhcp"},
+	}
+
+	networks, config, err := staticNetworkConfig(in)
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.Empty(t, in[1].MacAddress, "the caller's networks are not modified")
+	assert.Regexp(t, `^52:54:00:[0-9a-f]{2}:[0-9a-f]{2}:[0-9a-f]{2}$`, networks[1].MacAddress)
+	assert.NotEqual(t, networks[1].MacAddress, networks[2].MacAddress)
+
+	var doc struct {
+		Version   int `json:"version"`
+		Ethernets map[string]struct {
+			Match     map[string]string `json:"match"`
+			DHCP4     bool              `json:"dhcp4"`
+			Addresses []string          `json:"addresses"`
+			Routes    []struct {
+				To  string `json:"to"`
+				Via string `json:"via"`
+			} `json:"routes"`
+			Nameservers struct {
+				Addresses []string `json:"addresses"`
+			} `json:"nameservers"`
+		} `json:"ethernets"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &doc), config)
+	assert.Equal(t, 2, doc.Version)
+
+	dual := doc.Ethernets["nic0"]
+	assert.Equal(t, "52:54:00:aa:bb:cc", dual.Match["macaddress"])
+	assert.False(t, dual.DHCP4)
+	assert.Equal(t, []string{"10.0.0.5/24", "2001:db8::5/64"}, dual.Addresses)
+	require.Len(t, dual.Routes, 2)
+	assert.Equal(t, "10.0.0.1", dual.Routes[0].Via)
+	assert.Equal(t, "fe80::1", dual.Routes[1].Via)
+	assert.Equal(t, []string{"10.0.0.53", "2001:db8::53"}, dual.Nameservers.Addresses)
+
+	v6only := doc.Ethernets["nic1"]
+	assert.Equal(t, networks[1].MacAddress, v6only.Match["macaddress"])
+	assert.True(t, v6only.DHCP4, "IPv4 keeps DHCP next to a static IPv6 address")
+	assert.Equal(t, []string{"2001:db8:1::5/64"}, v6only.Addresses)
+
+	assert.True(t, doc.Ethernets["nic2"].DHCP4)
+	assert.Empty(t, doc.Ethernets["nic2"].Addresses)
+}
+
+func TestStaticNetworkConfig_AllDHCP(t *testing.T) {
+	in := []contracts.NetworkAttachment{{Name: "lan"}}
+	networks, config, err := staticNetworkConfig(in)
+	require.NoError(t, err)
+	assert.Empty(t, config)
+	assert.Equal(t, in, networks)
+}
@@ -43,6 +43,19 @@ type Provider struct {
 
 	// cached credentials
 	credentials *Credentials
+
+	// cpuSamples keeps the previous cpu.time of each domain so Describe can
+	// report CPU utilization.
+	cpuSamples cpuSampler
+
+	// hosts routes per-VM calls to the host running the domain when peer
+	// hosts are configured (EnvPeerHosts). Nil for providers built by
+	// NewProvider.
+	hosts *hostRouter
+
+	// baseImages makes concurrent Creates from one image URL share a single
+	// download into the storage pool.
+	baseImages baseImageCache
 }
 
 // ProviderConfig represents the configuration for the provider
@@ -143,6 +156,13 @@ func New() *Provider {
 	} else {
 		log.Printf("INFO Successfully initialized virsh provider")
 	}
+	p.hosts = newHostRouter(ctx, p, os.Getenv(EnvPeerHosts))
+
+	if sweep, err := orphanSweepConfigFromEnv(); err != nil {
+		log.Printf("ERROR Orphaned volume sweep disabled: %v", err)
+	} else {
+		p.startOrphanVolumeSweep(ctx, sweep)
+	}
 
 	return p
 }
@@ -191,6 +211,17 @@ func NewProvider(ctx context.Context, k8sClient client.Client, provider *v1beta1
 }
 
 // Validate ensures the provider connection is healthy using virsh
+// SetLogger routes the virsh transport's structured logs, including the
+// debug-level command latency and SSH session pool stats, to logger.
+func (p *Provider) SetLogger(logger *slog.Logger) {
+	p.virshProvider.logger = logger
+	if p.hosts != nil {
+		for _, h := range p.hosts.hosts {
+			h.provider.virshProvider.logger = logger
+		}
+	}
+}
+
 func (p *Provider) Validate(ctx context.Context) error {
 	if p.virshProvider == nil {
 		return contracts.NewRetryableError("virsh provider not initialized", nil)
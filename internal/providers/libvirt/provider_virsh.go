@@ -48,17 +48,39 @@ func (p *Provider) Create(ctx context.Context, req contracts.CreateRequest) (con
 		return contracts.CreateResponse{}, contracts.NewRetryableError("failed to list existing domains", err)
 	}
 
+	// The domain name is the VM ID, so a repeated Create finds the domain
+	// its earlier attempt defined and returns it instead of failing.
 	for _, domain := range domains {
 		if domain.Name == req.Name {
 			log.Printf("INFO Domain %s already exists with state: %s", req.Name, domain.State)
+			if req.IdempotencyKey != "" {
+				raw, err := p.virshProvider.runVirshCommand(ctx, "dumpxml", req.Name)
+				if err != nil {
+					return contracts.CreateResponse{}, contracts.NewRetryableError("failed to read existing domain", err)
+				}
+				dx, err := parseDomainXML(raw.Stdout)
+				if err != nil {
+					return contracts.CreateResponse{}, contracts.NewRetryableError("failed to read existing domain", err)
+				}
+				if err := checkDomainOwner(req.Name, dx.IdempotencyKey, req.IdempotencyKey); err != nil {
+					return contracts.CreateResponse{}, err
+				}
+			}
 			return contracts.CreateResponse{
 				ID: req.Name,
 			}, nil
 		}
 	}
 
+	// Resolve the firmware before any storage is created, so a class the
+	// host cannot boot fails as InvalidSpec without leaving volumes behind.
+	fw, err := p.resolveFirmware(ctx, req.Class)
+	if err != nil {
+		return contracts.CreateResponse{}, err
+	}
+
 	// Create VM with cloud-init support
-	vmID, err := p.createVMWithCloudInit(ctx, req)
+	vmID, err := p.createVMWithCloudInit(ctx, req, fw)
 	if err != nil {
 		return contracts.CreateResponse{}, contracts.NewRetryableError("failed to create VM", err)
 	}
@@ -70,9 +92,27 @@ func (p *Provider) Create(ctx context.Context, req contracts.CreateRequest) (con
 }
 
 // createVMWithCloudInit creates a VM with comprehensive cloud-init support and storage management
-func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.CreateRequest) (string, error) {
+func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.CreateRequest, fw domainFirmware) (string, error) {
 	log.Printf("INFO Creating VM with enhanced cloud-init configuration and storage: %s", req.Name)
 
+	// Static addresses reach the guest through a generated network-config,
+	// unless the request brings its own.
+	if req.UserData == nil || req.UserData.NetworkConfig == "" {
+		networks, networkConfig, err := staticNetworkConfig(req.Networks)
+		if err != nil {
+			return "", err
+		}
+		if networkConfig != "" {
+			req.Networks = networks
+			userData := contracts.UserData{}
+			if req.UserData != nil {
+				userData = *req.UserData
+			}
+			userData.NetworkConfig = networkConfig
+			req.UserData = &userData
+		}
+	}
+
 	// Initialize providers
 	cloudInitProvider := NewCloudInitProvider(p.virshProvider)
 	storageProvider := NewStorageProvider(p.virshProvider)
@@ -99,9 +139,17 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 
 		// Determine how to handle the image based on its type
 		if strings.HasPrefix(imageSpec, "http://") || strings.HasPrefix(imageSpec, "https://") {
-			// Handle URL - download the image
-			log.Printf("INFO Downloading cloud image from URL: %s", imageSpec)
-			volume, err = storageProvider.DownloadCloudImage(ctx, imageSpec, diskVolumeName, "default", diskSizeGB)
+			// Handle URL - download the image into the pool once, then
+			// give the VM an overlay on it or a copy of it
+			log.Printf("INFO Preparing cloud image from URL: %s", imageSpec)
+			var base string
+			if base, err = p.ensureBaseImage(ctx, storageProvider, imageSpec, "default"); err == nil {
+				volume, err = p.diskFromBaseImage(ctx, storageProvider, base, diskVolumeName, "default", diskSizeGB, req.Image.LinkedClone)
+			}
+		} else if strings.HasPrefix(imageSpec, "/") && req.Image.LinkedClone && linkableImageFormat(req.Image.Format) {
+			// Handle linked clone of a local qcow2 image - overlay it in place
+			log.Printf("INFO Creating overlay disk on local image file: %s", imageSpec)
+			volume, err = p.diskFromBaseImage(ctx, storageProvider, imageSpec, diskVolumeName, "default", diskSizeGB, true)
 		} else if strings.HasPrefix(imageSpec, "/") {
 			// Handle absolute path - copy from existing image file
 			log.Printf("INFO Creating disk from local template file: %s", imageSpec)
@@ -126,9 +174,15 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 		diskPath = volume.Path
 	}
 
-	// Prepare cloud-init if provided
+	// Prepare the sysprep answer file, or cloud-init if provided
 	var cloudInitISOPath string
-	if req.UserData != nil && req.UserData.CloudInitData != "" {
+	if gc := req.GuestCustomization; gc != nil && gc.Type == contracts.GuestCustomizationSysprep && gc.Sysprep != nil {
+		var err error
+		cloudInitISOPath, err = cloudInitProvider.PrepareSysprep(ctx, req.Name, gc.Sysprep.UnattendXML)
+		if err != nil {
+			return "", fmt.Errorf("failed to prepare sysprep: %w", err)
+		}
+	} else if req.UserData != nil && req.UserData.CloudInitData != "" {
 		log.Printf("INFO Preparing cloud-init configuration for VM: %s", req.Name)
 
 		// Extract hostname from cloud-init data
@@ -144,9 +198,11 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 
 		// Prepare cloud-init configuration
 		cloudInitConfig := CloudInitConfig{
-			UserData:   req.UserData.CloudInitData,
-			InstanceID: req.Name,
-			Hostname:   hostname,
+			UserData:      req.UserData.CloudInitData,
+			NetworkConfig: req.UserData.NetworkConfig,
+			VendorData:    req.UserData.VendorData,
+			InstanceID:    req.Name,
+			Hostname:      hostname,
 		}
 
 		var err error
@@ -171,6 +227,11 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 			InstanceID: req.Name,
 			Hostname:   req.Name,
 		}
+		if req.UserData != nil {
+			// network-config or vendor-data without user-data.
+			cloudInitConfig.NetworkConfig = req.UserData.NetworkConfig
+			cloudInitConfig.VendorData = req.UserData.VendorData
+		}
 
 		var err error
 		cloudInitISOPath, err = cloudInitProvider.PrepareCloudInit(ctx, cloudInitConfig)
@@ -188,7 +249,7 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 	}
 
 	// Generate domain XML with proper disk and cloud-init ISO
-	domainXML, err := p.generateDomainXMLWithStorage(ctx, req, diskPath, cloudInitISOPath)
+	domainXML, err := p.generateDomainXMLWithStorage(ctx, req, fw, diskPath, cloudInitISOPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate domain XML: %w", err)
 	}
@@ -207,9 +268,17 @@ func (p *Provider) createVMWithCloudInit(ctx context.Context, req contracts.Crea
 	return req.Name, nil
 }
 
-// Delete removes a VM using virsh and cleans up all associated resources
+// Delete removes a VM using virsh, together with the volumes and cloud-init
+// files the provider created for it
 func (p *Provider) Delete(ctx context.Context, id string) (taskRef string, err error) {
-	log.Printf("INFO Deleting VM and all associated resources: %s", id)
+	return p.deleteVM(ctx, id, true)
+}
+
+// deleteVM removes a VM using virsh. With deleteVolumes it also deletes the
+// VM's storage that follows the provider's naming convention (see
+// vmVolumes); other disks of the domain are left in place.
+func (p *Provider) deleteVM(ctx context.Context, id string, deleteVolumes bool) (taskRef string, err error) {
+	log.Printf("INFO Deleting VM: %s (delete volumes: %t)", id, deleteVolumes)
 
 	if p.virshProvider == nil {
 		return "", contracts.NewRetryableError("virsh provider not initialized", nil)
@@ -230,24 +299,22 @@ func (p *Provider) Delete(ctx context.Context, id string) (taskRef string, err e
 	}
 
 	if !domainExists {
-		log.Printf("INFO Domain %s does not exist, cleaning up any remaining resources", id)
-		// Even if domain doesn't exist, try to clean up orphaned resources
-		p.cleanupOrphanedResources(ctx, id)
+		log.Printf("INFO Domain %s does not exist", id)
+		if deleteVolumes {
+			// A previous delete may have undefined the domain and failed
+			// before its volumes were gone.
+			p.deleteVMStorage(ctx, id, nil, true)
+		}
 		return "", nil
 	}
 
 	// Get disk paths before deleting the domain
-	diskPaths, err := p.getDomainDiskPaths(ctx, id)
-	if err != nil {
-		log.Printf("WARN Failed to get disk paths for %s: %v", id, err)
-		// Continue with deletion even if we can't get disk paths
-	}
-
-	// Get cloud-init ISO path before deleting the domain
-	cloudInitISOPath, err := p.getCloudInitISOPath(ctx, id)
-	if err != nil {
-		log.Printf("WARN Failed to get cloud-init ISO path for %s: %v", id, err)
-		// Continue with deletion
+	var diskPaths []string
+	if deleteVolumes {
+		diskPaths, err = p.getDomainDiskPaths(ctx, id)
+		if err != nil {
+			return "", contracts.NewRetryableError("failed to read domain disks", err)
+		}
 	}
 
 	// Stop the domain if running
@@ -256,36 +323,19 @@ func (p *Provider) Delete(ctx context.Context, id string) (taskRef string, err e
 		// Continue with undefine even if destroy fails
 	}
 
-	// Remove the domain definition (this should also remove storage if --remove-all-storage is used)
-	// However, we'll explicitly delete disks to ensure cleanup
-	if err := p.virshProvider.undefineDomain(ctx, id); err != nil {
-		return "", contracts.NewRetryableError("failed to undefine domain", err)
+	if err := p.virshProvider.deleteAllSnapshots(ctx, id); err != nil {
+		return "", contracts.NewRetryableError("failed to delete snapshots", err)
 	}
 
-	// Delete disk images
-	if len(diskPaths) > 0 {
-		log.Printf("INFO Deleting %d disk(s) for VM %s", len(diskPaths), id)
-		for _, diskPath := range diskPaths {
-			if err := p.deleteDiskFile(ctx, diskPath); err != nil {
-				log.Printf("WARN Failed to delete disk %s: %v", diskPath, err)
-				// Continue with other deletions
-			} else {
-				log.Printf("INFO Successfully deleted disk: %s", diskPath)
-			}
-		}
+	if err := p.virshProvider.undefineDomain(ctx, id); err != nil {
+		return "", contracts.NewRetryableError("failed to undefine domain", err)
 	}
 
-	// Delete cloud-init ISO
-	if cloudInitISOPath != "" {
-		if err := p.deleteCloudInitResources(ctx, id, cloudInitISOPath); err != nil {
-			log.Printf("WARN Failed to delete cloud-init resources: %v", err)
-			// Continue - not a critical error
-		} else {
-			log.Printf("INFO Successfully deleted cloud-init resources for: %s", id)
-		}
+	if deleteVolumes {
+		p.deleteVMStorage(ctx, id, diskPaths, false)
 	}
 
-	log.Printf("INFO Successfully deleted domain and all resources: %s", id)
+	log.Printf("INFO Successfully deleted domain: %s", id)
 	return "", nil
 }
 
@@ -327,104 +377,6 @@ func (p *Provider) getDomainDiskPaths(ctx context.Context, domainName string) ([
 	return diskPaths, nil
 }
 
-// getCloudInitISOPath retrieves the cloud-init ISO path for a domain
-func (p *Provider) getCloudInitISOPath(ctx context.Context, domainName string) (string, error) {
-	// Get domain XML
-	result, err := p.virshProvider.runVirshCommand(ctx, "dumpxml", domainName)
-	if err != nil {
-		return "", fmt.Errorf("failed to dump domain XML: %w", err)
-	}
-
-	// Look for cloud-init ISO in XML
-	lines := strings.Split(result.Stdout, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "<source file=") {
-			// Extract the file path
-			start := strings.Index(line, "file='")
-			if start == -1 {
-				start = strings.Index(line, "file=\"")
-			}
-			if start != -1 {
-				start += 6
-				end := strings.IndexAny(line[start:], "\"'")
-				if end != -1 {
-					filePath := line[start : start+end]
-					// Check if this is a cloud-init ISO
-					if strings.HasSuffix(filePath, "cloud-init.iso") || strings.Contains(filePath, "virtrigaud-cloudinit") {
-						return filePath, nil
-					}
-				}
-			}
-		}
-	}
-
-	return "", nil
-}
-
-// deleteDiskFile deletes a disk file from the libvirt host
-func (p *Provider) deleteDiskFile(ctx context.Context, diskPath string) error {
-	log.Printf("INFO Deleting disk file: %s", diskPath)
-
-	// Use rm to delete the disk file
-	_, err := p.virshProvider.runVirshCommand(ctx, "!", "sudo", "rm", "-f", diskPath)
-	if err != nil {
-		return fmt.Errorf("failed to delete disk file %s: %w", diskPath, err)
-	}
-
-	return nil
-}
-
-// deleteCloudInitResources deletes cloud-init ISO and associated files
-func (p *Provider) deleteCloudInitResources(ctx context.Context, domainName, isoPath string) error {
-	log.Printf("INFO Deleting cloud-init resources for: %s", domainName)
-
-	// Delete the cloud-init directory which contains ISO, user-data, and meta-data
-	// Extract directory from ISO path by removing the filename
-	lastSlash := strings.LastIndex(isoPath, "/")
-	cloudInitDir := isoPath
-	if lastSlash != -1 {
-		cloudInitDir = isoPath[:lastSlash]
-	}
-
-	_, err := p.virshProvider.runVirshCommand(ctx, "!", "rm", "-rf", cloudInitDir)
-	if err != nil {
-		return fmt.Errorf("failed to delete cloud-init directory %s: %w", cloudInitDir, err)
-	}
-
-	return nil
-}
-
-// cleanupOrphanedResources attempts to clean up any resources that might be left behind
-func (p *Provider) cleanupOrphanedResources(ctx context.Context, domainName string) {
-	log.Printf("INFO Cleaning up orphaned resources for: %s", domainName)
-
-	// Try to delete disk files with common naming patterns
-	diskPatterns := []string{
-		fmt.Sprintf("/var/lib/libvirt/images/%s-disk.qcow2", domainName),
-		fmt.Sprintf("/var/lib/libvirt/images/%s.qcow2", domainName),
-		fmt.Sprintf("/var/lib/libvirt/images/%s-disk", domainName),
-	}
-
-	for _, diskPath := range diskPatterns {
-		_, err := p.virshProvider.runVirshCommand(ctx, "!", "sudo", "rm", "-f", diskPath)
-		if err != nil {
-			log.Printf("DEBUG Could not delete potential orphaned disk %s: %v", diskPath, err)
-		} else {
-			log.Printf("INFO Cleaned up orphaned disk: %s", diskPath)
-		}
-	}
-
-	// Try to delete cloud-init directory
-	cloudInitDir := fmt.Sprintf("/tmp/virtrigaud-cloudinit/%s", domainName)
-	_, err := p.virshProvider.runVirshCommand(ctx, "!", "rm", "-rf", cloudInitDir)
-	if err != nil {
-		log.Printf("DEBUG Could not delete cloud-init directory %s: %v", cloudInitDir, err)
-	} else {
-		log.Printf("INFO Cleaned up orphaned cloud-init directory: %s", cloudInitDir)
-	}
-}
-
 // Power controls VM power state using virsh
 func (p *Provider) Power(ctx context.Context, id string, op contracts.PowerOp) (taskRef string, err error) {
 	log.Printf("INFO Power operation %s on VM: %s", op, id)
@@ -447,8 +399,9 @@ func (p *Provider) Power(ctx context.Context, id string, op contracts.PowerOp) (
 		}
 	case contracts.PowerOpOff:
 		err = p.virshProvider.stopDomain(ctx, id)
-	case contracts.PowerOpReboot:
-		// Restart by stopping then starting
+	case contracts.PowerOpReboot, contracts.PowerOpReset:
+		// Restart by stopping then starting; the stop is a hard one, so this
+		// doubles as a reset
 		if stopErr := p.virshProvider.stopDomain(ctx, id); stopErr != nil {
 			log.Printf("WARN Failed to stop domain for reboot: %v", stopErr)
 		}
@@ -516,21 +469,27 @@ func (p *Provider) syncPersistentXML(ctx context.Context, domainName string) err
 	return nil
 }
 
-// Reconfigure updates VM configuration using virsh
-func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest) (taskRef string, err error) {
+// Reconfigure applies a ChangeSet using virsh. CPU and memory changes that
+// exceed the hotplug headroom of a running domain are reported in
+// PowerCycleRequired. An empty ChangeSet, from a manager that predates it,
+// is derived from the desired spec.
+func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest, changes contracts.ChangeSet) (result contracts.ReconfigureResult, err error) {
 	log.Printf("INFO Reconfiguring VM: %s", id)
 
 	if p.virshProvider == nil {
-		return "", contracts.NewRetryableError("virsh provider not initialized", nil)
+		return result, contracts.NewRetryableError("virsh provider not initialized", nil)
+	}
+
+	if changes.IsEmpty() {
+		changes = legacyChangeSet(desired)
 	}
 
 	hasChanges := false
-	requiresRestart := false
 
 	// Get current domain state
 	domainState, err := p.virshProvider.getDomainState(ctx, id)
 	if err != nil {
-		return "", contracts.NewRetryableError("failed to get domain state", err)
+		return result, contracts.NewRetryableError("failed to get domain state", err)
 	}
 
 	isRunning := domainState == "running"
@@ -539,19 +498,20 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 	// Get current domain info for comparison
 	currentInfo, err := p.virshProvider.getDomainInfo(ctx, id)
 	if err != nil {
-		return "", contracts.NewRetryableError("failed to get current domain info", err)
+		return result, contracts.NewRetryableError("failed to get current domain info", err)
 	}
 
 	// Handle CPU changes
-	if desired.Class.CPU > 0 {
+	if changes.CPU != nil && changes.CPU.New > 0 {
+		desiredCPUs := changes.CPU.New
 		currentCPUs, err := p.extractCPUCount(currentInfo)
-		if err == nil && currentCPUs != desired.Class.CPU {
-			log.Printf("INFO CPU change requested for %s: %d -> %d", id, currentCPUs, desired.Class.CPU)
+		if err == nil && currentCPUs != desiredCPUs {
+			log.Printf("INFO CPU change requested for %s: %d -> %d", id, currentCPUs, desiredCPUs)
 
 			if isRunning {
 				// Try online CPU change with --live flag
 				_, err = p.virshProvider.runVirshCommand(ctx, "setvcpus", id,
-					fmt.Sprintf("%d", desired.Class.CPU), "--live")
+					fmt.Sprintf("%d", desiredCPUs), "--live")
 				if err != nil {
 					// A `setvcpus --live` failure here means the desired vCPU
 					// count exceeds the hotplug headroom provisioned at create
@@ -559,8 +519,8 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 					// CPUHotAddEnabled (no headroom at all). Either way the
 					// increase requires a power cycle to take effect (#203).
 					log.Printf("WARN Online CPU change to %d failed for %s: exceeds provisioned hotplug headroom (the <vcpu> max) or the VM was created without CPUHotAddEnabled; a power cycle is required to apply this increase: %v",
-						desired.Class.CPU, id, err)
-					requiresRestart = true
+						desiredCPUs, id, err)
+					result.PowerCycleRequired = append(result.PowerCycleRequired, contracts.ChangeCPU)
 				} else {
 					log.Printf("INFO Successfully changed CPUs online for domain: %s", id)
 					hasChanges = true
@@ -568,10 +528,9 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 			} else {
 				// Domain is off, change config
 				_, err = p.virshProvider.runVirshCommand(ctx, "setvcpus", id,
-					fmt.Sprintf("%d", desired.Class.CPU), "--config")
+					fmt.Sprintf("%d", desiredCPUs), "--config")
 				if err != nil {
 					log.Printf("WARN Failed to set CPUs in config: %v", err)
-					requiresRestart = true
 				} else {
 					hasChanges = true
 				}
@@ -580,9 +539,9 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 	}
 
 	// Handle Memory changes
-	if desired.Class.MemoryMiB > 0 {
+	if changes.MemoryMiB != nil && changes.MemoryMiB.New > 0 {
 		currentMemoryKB, err := p.extractMemoryKB(currentInfo)
-		desiredMemoryKB := int64(desired.Class.MemoryMiB) * 1024 // Convert MiB to KiB
+		desiredMemoryKB := changes.MemoryMiB.New * 1024 // Convert MiB to KiB
 
 		if err == nil && currentMemoryKB != desiredMemoryKB {
 			log.Printf("INFO Memory change requested for %s: %d KiB -> %d KiB", id, currentMemoryKB, desiredMemoryKB)
@@ -600,7 +559,7 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 					// increase requires a power cycle to take effect (#203).
 					log.Printf("WARN Online memory change to %d KiB failed for %s: exceeds provisioned hotplug headroom (the <memory> balloon maximum) or the VM was created without MemoryHotAddEnabled; a power cycle is required to apply this increase: %v",
 						desiredMemoryKB, id, err)
-					requiresRestart = true
+					result.PowerCycleRequired = append(result.PowerCycleRequired, contracts.ChangeMemoryMiB)
 				} else {
 					log.Printf("INFO Successfully changed memory online for domain: %s", id)
 					hasChanges = true
@@ -611,7 +570,6 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 					fmt.Sprintf("%dK", desiredMemoryKB), "--config")
 				if err != nil {
 					log.Printf("WARN Failed to set memory in config: %v", err)
-					requiresRestart = true
 				} else {
 					// Also update max memory
 					_, _ = p.virshProvider.runVirshCommand(ctx, "setmaxmem", id,
@@ -635,59 +593,83 @@ func (p *Provider) Reconfigure(ctx context.Context, id string, desired contracts
 	//
 	// Offline (domain stopped): resize the backing volume so the larger size
 	// applies on next boot.
-	if len(desired.Disks) > 0 || (desired.Class.DiskDefaults != nil && desired.Class.DiskDefaults.SizeGiB > 0) {
-		storageProvider := NewStorageProvider(p.virshProvider)
-
-		// Get desired disk size
-		var desiredDiskGB int
-		if desired.Class.DiskDefaults != nil && desired.Class.DiskDefaults.SizeGiB > 0 {
-			desiredDiskGB = int(desired.Class.DiskDefaults.SizeGiB)
+	//
+	// Only the boot disk, the one DiskDefaults sizes, is resized.
+	for _, expansion := range changes.Disks {
+		if expansion.Name != contracts.RootDiskName {
+			log.Printf("WARN Skipping expansion of disk %q for VM %s: only the root disk can be resized", expansion.Name, id)
+			continue
 		}
+		desiredDiskGB := int(expansion.NewSizeGiB)
+		if desiredDiskGB <= 0 {
+			continue
+		}
+		storageProvider := NewStorageProvider(p.virshProvider)
 
-		if desiredDiskGB > 0 {
-			if isRunning {
-				// Online live grow (grow-only + idempotent guards inside).
-				log.Printf("INFO Attempting online disk grow for running VM %s to %dGB", id, desiredDiskGB)
-				grew, gerr := p.growDiskOnline(ctx, id, desiredDiskGB, storageProvider)
-				if gerr != nil {
-					// The live block-device resize failing IS fatal to the disk
-					// step: the guest would not see the requested capacity.
-					log.Printf("WARN Online disk grow failed for VM %s: %v", id, gerr)
-					return "", contracts.NewRetryableError("online disk grow failed", gerr)
-				}
-				if grew {
-					hasChanges = true
-				}
+		if isRunning {
+			// Online live grow (grow-only + idempotent guards inside).
+			log.Printf("INFO Attempting online disk grow for running VM %s to %dGB", id, desiredDiskGB)
+			grew, gerr := p.growDiskOnline(ctx, id, desiredDiskGB, storageProvider)
+			if gerr != nil {
+				// The live block-device resize failing IS fatal to the disk
+				// step: the guest would not see the requested capacity.
+				log.Printf("WARN Online disk grow failed for VM %s: %v", id, gerr)
+				return result, contracts.NewRetryableError("online disk grow failed", gerr)
+			}
+			if grew {
+				hasChanges = true
+			}
+		} else {
+			// Offline: resize the backing volume so the larger size applies
+			// on next boot. Find the VM's disk volume by the pool convention.
+			volumeName := fmt.Sprintf("%s-disk", id)
+			log.Printf("INFO Attempting offline disk resize for VM %s to %dGB", id, desiredDiskGB)
+			err = storageProvider.ResizeVolume(ctx, "default", volumeName, desiredDiskGB)
+			if err != nil {
+				log.Printf("WARN Offline disk resize failed: %v", err)
+				// Offline resize failure is not fatal, just log it.
 			} else {
-				// Offline: resize the backing volume so the larger size applies
-				// on next boot. Find the VM's disk volume by the pool convention.
-				volumeName := fmt.Sprintf("%s-disk", id)
-				log.Printf("INFO Attempting offline disk resize for VM %s to %dGB", id, desiredDiskGB)
-				err = storageProvider.ResizeVolume(ctx, "default", volumeName, desiredDiskGB)
-				if err != nil {
-					log.Printf("WARN Offline disk resize failed: %v", err)
-					// Offline resize failure is not fatal, just log it.
-				} else {
-					log.Printf("INFO Successfully resized disk for VM: %s", id)
-					hasChanges = true
-				}
+				log.Printf("INFO Successfully resized disk for VM: %s", id)
+				hasChanges = true
 			}
 		}
 	}
 
+	if len(changes.NetworksAdded) > 0 || len(changes.NetworksRemoved) > 0 {
+		log.Printf("INFO Network attachment changes for %s are not applied by Reconfigure", id)
+	}
+
 	// Log reconfiguration results
-	if !hasChanges && !requiresRestart {
+	if !hasChanges && len(result.PowerCycleRequired) == 0 {
 		log.Printf("INFO No configuration changes needed for domain: %s", id)
-		return "", nil
+		return result, nil
 	}
 
-	if requiresRestart {
-		log.Printf("WARN Some changes for domain %s require a restart to take effect", id)
-		// Note: The caller (controller) should handle restarting the VM if needed
+	if len(result.PowerCycleRequired) > 0 {
+		// The manager re-sends these until the domain is off, when the
+		// --config path above applies them.
+		log.Printf("WARN Changes %v for domain %s require a power cycle to take effect", result.PowerCycleRequired, id)
 	}
 
 	log.Printf("INFO Successfully reconfigured domain: %s", id)
-	return "", nil
+	return result, nil
+}
+
+// legacyChangeSet builds a ChangeSet from the full desired spec, as sent by a
+// manager that predates ChangeSet. Old values are unknown; Reconfigure
+// compares against the domain itself.
+func legacyChangeSet(desired contracts.CreateRequest) contracts.ChangeSet {
+	var changes contracts.ChangeSet
+	if desired.Class.CPU > 0 {
+		changes.CPU = &contracts.Int32Change{New: desired.Class.CPU}
+	}
+	if desired.Class.MemoryMiB > 0 {
+		changes.MemoryMiB = &contracts.Int64Change{New: int64(desired.Class.MemoryMiB)}
+	}
+	if desired.Class.DiskDefaults != nil && desired.Class.DiskDefaults.SizeGiB > 0 {
+		changes.Disks = []contracts.DiskExpansion{{Name: contracts.RootDiskName, NewSizeGiB: desired.Class.DiskDefaults.SizeGiB}}
+	}
+	return changes
 }
 
 // getVNCPort extracts the VNC port from domain XML
@@ -698,27 +680,13 @@ func (p *Provider) getVNCPort(ctx context.Context, domainName string) (int, erro
 		return 0, fmt.Errorf("failed to get domain XML: %w", err)
 	}
 
-	// Parse XML to find VNC port
-	// Look for <graphics type='vnc' port='XXXX'/>
-	lines := strings.Split(result.Stdout, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "<graphics") && strings.Contains(line, "type='vnc'") {
-			// Extract port attribute
-			if portIdx := strings.Index(line, "port='"); portIdx != -1 {
-				portStart := portIdx + 6 // len("port='")
-				portEnd := strings.Index(line[portStart:], "'")
-				if portEnd > 0 {
-					portStr := line[portStart : portStart+portEnd]
-					port, err := strconv.Atoi(portStr)
-					if err == nil && port > 0 {
-						return port, nil
-					}
-				}
-			}
-		}
+	dx, err := parseDomainXML(result.Stdout)
+	if err != nil {
+		return 0, err
+	}
+	if port := dx.VNCPort(); port > 0 {
+		return port, nil
 	}
-
 	return 0, fmt.Errorf("VNC port not found in domain XML")
 }
 
@@ -783,6 +751,7 @@ func (p *Provider) Describe(ctx context.Context, id string) (contracts.DescribeR
 
 	// Extract IP addresses from enhanced domain info
 	var ips []string
+	var addresses []contracts.GuestAddress
 	if guestIPs := domainInfo["guest_ip_addresses"]; guestIPs != "" {
 		ips = strings.Split(guestIPs, ",")
 		// Filter out empty strings
@@ -833,6 +802,9 @@ func (p *Provider) Describe(ctx context.Context, id string) (contracts.DescribeR
 				for _, iface := range guestInfo.NetworkInterfaces {
 					interfaceNames = append(interfaceNames, iface.Name)
 					guestIPs = append(guestIPs, iface.IPAddresses...)
+					for _, ip := range iface.IPAddresses {
+						addresses = append(addresses, contracts.GuestAddress{IP: ip, Interface: iface.Name})
+					}
 
 					// Add detailed network statistics
 					domainInfo[fmt.Sprintf("net_%s_rx_bytes", iface.Name)] = fmt.Sprintf("%d", iface.Statistics.RxBytes)
@@ -912,6 +884,14 @@ func (p *Provider) Describe(ctx context.Context, id string) (contracts.DescribeR
 		domainInfo["guest_os"] = domainInfo["OS Type"]
 	}
 
+	// Snapshot count and chain depth; a failed lookup leaves them out
+	if snapshots, err := p.virshProvider.snapshotStats(ctx, id); err != nil {
+		log.Printf("DEBUG Failed to count snapshots of domain %s: %v", id, err)
+	} else {
+		domainInfo["snapshot_count"] = strconv.Itoa(int(snapshots.Count))
+		domainInfo["snapshot_chain_depth"] = strconv.Itoa(int(snapshots.ChainDepth))
+	}
+
 	// Generate console URL (VNC/SPICE access info)
 	consoleURL := ""
 	if powerState == "On" {
@@ -940,8 +920,15 @@ func (p *Provider) Describe(ctx context.Context, id string) (contracts.DescribeR
 		Exists:      true,
 		PowerState:  string(powerState),
 		IPs:         ips,
+		Addresses:   addresses,
 		ConsoleURL:  consoleURL,
 		ProviderRaw: domainInfo, // Pass the enhanced domain info as provider-specific data
+		Identity:    domainInfo["identity"],
+	}
+	if powerState == "On" {
+		response.GuestStats = p.guestStats(id, domainInfo, time.Now())
+	} else {
+		p.cpuSamples.forget(id)
 	}
 
 	log.Printf("INFO Domain %s comprehensive state: power=%s, ips=%v, monitoring_data=collected", id, response.PowerState, ips)
@@ -1114,14 +1101,64 @@ final_message: "VM %s is ready!"
 `, vmName, vmName, vmName)
 }
 
+// pcieHotplugPorts is how many empty pcie-root-ports a q35 domain gets for
+// devices hot-plugged after it is defined. PCI Express has no hotplug on
+// the root bus, so without spare ports a running q35 guest cannot take
+// another disk or NIC.
+const pcieHotplugPorts = 4
+
+// pciLayout hands out the PCI addresses of a new domain's devices. On pc
+// (i440fx) devices sit at the fixed root-bus slots this provider has always
+// used. On q35 every virtio device gets a pcie-root-port of its own, the
+// layout libvirt itself generates for PCI Express guests.
+type pciLayout struct {
+	q35   bool
+	ports int // pcie-root-ports handed out; port N is bus N
+}
+
+// pciAddress renders a PCI <address> element.
+func pciAddress(bus, slot, function int, multifunction bool) string {
+	mf := ""
+	if multifunction {
+		mf = " multifunction='on'"
+	}
+	return fmt.Sprintf("<address type='pci' domain='0x0000' bus='0x%02x' slot='0x%02x' function='0x%x'%s/>", bus, slot, function, mf)
+}
+
+// endpoint returns the address of a virtio device that sits at pcSlot on
+// the pc root bus, or on the next free root port on q35.
+func (l *pciLayout) endpoint(pcSlot int) string {
+	if !l.q35 {
+		return pciAddress(0, pcSlot, 0, false)
+	}
+	l.ports++
+	return pciAddress(l.ports, 0x00, 0x0, false)
+}
+
+// rootPortsXML renders the pcie-root-ports handed out so far plus the
+// hotplug spares, eight functions to a root-bus slot from slot 0x02.
+func (l *pciLayout) rootPortsXML() string {
+	var b strings.Builder
+	for i := 1; i <= l.ports+pcieHotplugPorts; i++ {
+		slot, function := 0x02+(i-1)/8, (i-1)%8
+		fmt.Fprintf(&b, `
+    <controller type='pci' index='%d' model='pcie-root-port'>
+      <model name='pcie-root-port'/>
+      <target chassis='%d' port='0x%x'/>
+      %s
+    </controller>`, i, i, 0x10+i-1, pciAddress(0, slot, function, function == 0))
+	}
+	return b.String()
+}
+
 // generateNetworkInterfacesXML creates network interface XML from network attachments
-func (p *Provider) generateNetworkInterfacesXML(networks []contracts.NetworkAttachment) string {
+func generateNetworkInterfacesXML(networks []contracts.NetworkAttachment, layout *pciLayout) string {
 	if len(networks) == 0 {
 		// Default to user network if no networks specified
-		return `    <interface type='user'>
+		return fmt.Sprintf(`    <interface type='user'>
       <model type='virtio'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x03' function='0x0'/>
-    </interface>`
+      %s
+    </interface>`, layout.endpoint(0x03))
 	}
 
 	var interfacesXML string
@@ -1132,8 +1169,14 @@ func (p *Provider) generateNetworkInterfacesXML(networks []contracts.NetworkAtta
 			model = net.Model
 		}
 
-		// Determine PCI slot (start at 0x03, increment for each interface)
-		pciSlot := fmt.Sprintf("0x%02x", 3+idx)
+		// On pc the first interface keeps its historical slot 0x03; the
+		// rest go after the balloon at 0x08 so they do not collide with
+		// the sound card and USB controllers at 0x04 and 0x05.
+		pcSlot := 0x03
+		if idx > 0 {
+			pcSlot = 0x08 + idx
+		}
+		address := layout.endpoint(pcSlot)
 
 		// Generate MAC address if specified
 		macXML := ""
@@ -1149,21 +1192,21 @@ func (p *Provider) generateNetworkInterfacesXML(networks []contracts.NetworkAtta
 			interfaceXML = fmt.Sprintf(`    <interface type='bridge'>%s
       <source bridge='%s'/>
       <model type='%s'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='%s' function='0x0'/>
-    </interface>`, macXML, net.Bridge, model, pciSlot)
+      %s
+    </interface>`, macXML, net.Bridge, model, address)
 		} else if net.NetworkName != "" {
 			// Libvirt managed network
 			interfaceXML = fmt.Sprintf(`    <interface type='network'>%s
       <source network='%s'/>
       <model type='%s'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='%s' function='0x0'/>
-    </interface>`, macXML, net.NetworkName, model, pciSlot)
+      %s
+    </interface>`, macXML, net.NetworkName, model, address)
 		} else {
 			// Default to user network (NAT)
 			interfaceXML = fmt.Sprintf(`    <interface type='user'>%s
       <model type='%s'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='%s' function='0x0'/>
-    </interface>`, macXML, model, pciSlot)
+      %s
+    </interface>`, macXML, model, address)
 		}
 
 		if idx > 0 {
@@ -1176,7 +1219,19 @@ func (p *Provider) generateNetworkInterfacesXML(networks []contracts.NetworkAtta
 }
 
 // generateDomainXMLWithStorage creates libvirt domain XML with proper storage configuration
-func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contracts.CreateRequest, diskPath, cloudInitISOPath string) (string, error) {
+func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contracts.CreateRequest, fw domainFirmware, diskPath, cloudInitISOPath string) (string, error) {
+	// Pick the domain type from the host: KVM for hardware acceleration when
+	// available, otherwise TCG software emulation. Hard-coding either value is
+	// wrong — 'qemu' cripples guests on KVM hosts (~100% CPU, glacial boot),
+	// while 'kvm' fails to start on hosts without /dev/kvm.
+	domainType := p.detectDomainType(ctx)
+
+	return renderDomainXML(req, domainType, p.generateUUID(), fw, diskPath, cloudInitISOPath), nil
+}
+
+// renderDomainXML builds the domain XML for a new VM. It does no I/O, so the
+// output for each firmware and machine type is pinned by golden files.
+func renderDomainXML(req contracts.CreateRequest, domainType, uuid string, fw domainFirmware, diskPath, cloudInitISOPath string) string {
 	// Extract specifications from request
 	cpuCount := int32(1)    // default
 	memoryMB := int64(1024) // default 1GB
@@ -1195,7 +1250,6 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 	var cpuHotAddEnabled bool
 	var memoryHotAddEnabled bool
 	var vtdEnabled bool
-	var secureBoot bool
 	var tpmEnabled bool
 
 	if req.Class.PerformanceProfile != nil {
@@ -1211,38 +1265,42 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 
 	if req.Class.SecurityProfile != nil {
 		vtdEnabled = req.Class.SecurityProfile.VTDEnabled
-		secureBoot = req.Class.SecurityProfile.SecureBoot
 		tpmEnabled = req.Class.SecurityProfile.TPMEnabled
 	}
 
-	// Generate UUID for the domain
-	uuid := p.generateUUID()
+	q35 := fw.Q35()
+	layout := &pciLayout{q35: q35}
 
 	// Build disk devices XML
 	diskDevicesXML := fmt.Sprintf(`    <disk type='file' device='disk'>
       <driver name='qemu' type='qcow2'/>
       <source file='%s'/>
       <target dev='vda' bus='virtio'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x07' function='0x0'/>
-    </disk>`, diskPath)
+      %s
+    </disk>`, diskPath, layout.endpoint(0x07))
 
-	// Add cloud-init ISO if available
+	// Add cloud-init ISO if available. q35 has no IDE, so the CD-ROM goes
+	// on the ICH9's built-in SATA controller there.
+	cdromTarget := `<target dev='hda' bus='ide'/>`
+	if q35 {
+		cdromTarget = `<target dev='sda' bus='sata'/>`
+	}
 	if cloudInitISOPath != "" {
 		diskDevicesXML += fmt.Sprintf(`
     <disk type='file' device='cdrom'>
       <driver name='qemu' type='raw'/>
       <source file='%s'/>
-      <target dev='hda' bus='ide'/>
+      %s
       <readonly/>
       <address type='drive' controller='0' bus='0' target='0' unit='0'/>
-    </disk>`, cloudInitISOPath)
+    </disk>`, cloudInitISOPath, cdromTarget)
 	}
 
 	// Build features XML based on configuration
 	featuresXML := `    <acpi/>
     <apic/>`
 
-	if secureBoot {
+	if fw.SecureBoot {
 		featuresXML += `
     <smm state='on'>
       <tseg unit='MiB'>16</tseg>
@@ -1263,18 +1321,22 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 	}
 	cpuXML += `</cpu>`
 
-	// Build OS configuration with secure boot if needed
-	osXML := `    <type arch='x86_64' machine='pc'>hvm</type>
-    <boot dev='hd'/>
-    <boot dev='cdrom'/>`
-
-	if secureBoot {
-		osXML = `    <type arch='x86_64' machine='q35'>hvm</type>
-    <loader readonly='yes' type='pflash' secure='yes'>/usr/share/OVMF/OVMF_CODE_4M.secboot.fd</loader>
-    <nvram template='/usr/share/OVMF/OVMF_VARS_4M.fd'/>
+	// Build OS configuration. UEFI domains boot the host's OVMF image and
+	// get a private copy of its varstore template, which libvirt creates on
+	// first start and removes on undefine --nvram.
+	osXML := fmt.Sprintf(`    <type arch='x86_64' machine='%s'>hvm</type>`, fw.Machine)
+	if fw.UEFI {
+		secure := ""
+		if fw.SecureBoot {
+			secure = " secure='yes'"
+		}
+		osXML += fmt.Sprintf(`
+    <loader readonly='yes' type='pflash'%s>%s</loader>
+    <nvram template='%s'/>`, secure, fw.Loader, fw.NVRAM)
+	}
+	osXML += `
     <boot dev='hd'/>
     <boot dev='cdrom'/>`
-	}
 
 	// Build devices XML with TPM if needed.
 	//
@@ -1294,7 +1356,33 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 	}
 
 	// Generate network interfaces based on request
-	networkInterfacesXML := p.generateNetworkInterfacesXML(req.Networks)
+	networkInterfacesXML := generateNetworkInterfacesXML(req.Networks, layout)
+
+	virtioSerialAddress := layout.endpoint(0x06)
+	memballoonAddress := layout.endpoint(0x08)
+
+	// The chipset's own devices. On q35 they sit where the ICH9 has them
+	// (USB at 0x1d, HD audio at 0x1b, SATA at 0x1f.2) and the video card
+	// takes slot 0x01; the root ports fill the slots from 0x02. OVMF has no
+	// driver for the Cirrus card, so q35 gets a standard VGA.
+	usbSlot, sound, video := 0x05, "ich6", "cirrus"
+	soundAddress := pciAddress(0, 0x04, 0x0, false)
+	videoAddress := pciAddress(0, 0x02, 0x0, false)
+	busControllersXML := fmt.Sprintf(`
+    <controller type='pci' index='0' model='pci-root'/>
+    <controller type='ide' index='0'>
+      %s
+    </controller>`, pciAddress(0, 0x01, 0x1, false))
+	if q35 {
+		usbSlot, sound, video = 0x1d, "ich9", "vga"
+		soundAddress = pciAddress(0, 0x1b, 0x0, false)
+		videoAddress = pciAddress(0, 0x01, 0x0, false)
+		busControllersXML = fmt.Sprintf(`
+    <controller type='pci' index='0' model='pcie-root'/>%s
+    <controller type='sata' index='0'>
+      %s
+    </controller>`, layout.rootPortsXML(), pciAddress(0, 0x1f, 0x2, false))
+	}
 
 	// Build the CPU/memory elements, provisioning hotplug headroom when the
 	// VMClass opts into CPU/MemoryHotAddEnabled (#203). When hot-add is off the
@@ -1302,18 +1390,13 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 	// <memory>==<currentMemory>, <vcpu placement='static'>N</vcpu> layout.
 	cpuMem := buildCPUMemoryXML(cpuCount, memoryMB, cpuHotAddEnabled, memoryHotAddEnabled)
 
-	// Pick the domain type from the host: KVM for hardware acceleration when
-	// available, otherwise TCG software emulation. Hard-coding either value is
-	// wrong — 'qemu' cripples guests on KVM hosts (~100% CPU, glacial boot),
-	// while 'kvm' fails to start on hosts without /dev/kvm.
-	domainType := p.detectDomainType(ctx)
-
-	domainXML := fmt.Sprintf(`<domain type='%s'>
+	return fmt.Sprintf(`<domain type='%s'>
   <name>%s</name>
   <uuid>%s</uuid>
   %s
   %s
   %s
+  %s
   <os>
 %s
   </os>
@@ -1332,29 +1415,26 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
   <devices>
 %s
     <controller type='usb' index='0' model='ich9-ehci1'>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x05' function='0x7'/>
+      %s
     </controller>
     <controller type='usb' index='0' model='ich9-uhci1'>
       <master startport='0'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x05' function='0x0' multifunction='on'/>
+      %s
     </controller>
     <controller type='usb' index='0' model='ich9-uhci2'>
       <master startport='2'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x05' function='0x1'/>
+      %s
     </controller>
     <controller type='usb' index='0' model='ich9-uhci3'>
       <master startport='4'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x05' function='0x2'/>
-    </controller>
-    <controller type='pci' index='0' model='pci-root'/>
-    <controller type='ide' index='0'>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x01' function='0x1'/>
-    </controller>
+      %s
+    </controller>%s
     <controller type='virtio-serial' index='0'>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x06' function='0x0'/>
+      %s
     </controller>
 %s
     <serial type='pty'>
+      <log file='%s' append='on'/>
       <target type='isa-serial' port='0'>
         <model name='isa-serial'/>
       </target>
@@ -1374,21 +1454,22 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
     <graphics type='vnc' port='-1' autoport='yes' listen='127.0.0.1'>
       <listen type='address' address='127.0.0.1'/>
     </graphics>
-    <sound model='ich6'>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x04' function='0x0'/>
+    <sound model='%s'>
+      %s
     </sound>
     <video>
-      <model type='cirrus' vram='16384' heads='1' primary='yes'/>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x02' function='0x0'/>
+      <model type='%s' vram='16384' heads='1' primary='yes'/>
+      %s
     </video>
     <memballoon model='virtio'>
-      <address type='pci' domain='0x0000' bus='0x00' slot='0x08' function='0x0'/>
+      %s
     </memballoon>
   </devices>
 </domain>`,
 		domainType,
 		req.Name,
 		uuid,
+		instanceMetadataXML(req.IdempotencyKey),
 		cpuMem.Memory,
 		cpuMem.CurrentMemory,
 		cpuMem.VCPU,
@@ -1396,9 +1477,19 @@ func (p *Provider) generateDomainXMLWithStorage(ctx context.Context, req contrac
 		featuresXML,
 		cpuXML,
 		devicesXML,
-		networkInterfacesXML)
-
-	return domainXML, nil
+		pciAddress(0, usbSlot, 0x7, false),
+		pciAddress(0, usbSlot, 0x0, true),
+		pciAddress(0, usbSlot, 0x1, false),
+		pciAddress(0, usbSlot, 0x2, false),
+		busControllersXML,
+		virtioSerialAddress,
+		networkInterfacesXML,
+		serialLogPath(req.Name),
+		sound,
+		soundAddress,
+		video,
+		videoAddress,
+		memballoonAddress)
 }
 
 // detectDomainType returns the libvirt <domain type> for new domains: "kvm" when
@@ -2022,7 +2113,7 @@ func (p *Provider) ListVMs(ctx context.Context) ([]contracts.VMInfo, error) {
 
 		powerState := string(p.mapLibvirtPowerState(domain.State))
 
-		cpu := dx.VCPU
+		cpu := dx.VCPUs()
 		if cpu == 0 {
 			cpu = 1 // Default to 1 CPU
 		}
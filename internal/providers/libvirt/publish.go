@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// sysprepOperation matches a virt-sysprep operation name such as
+// "machine-id" or "ssh-hostkeys".
+var sysprepOperation = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// PublishImage publishes a copy of a domain's primary disk into a storage
+// pool as <image_name>.qcow2, the layout ImagePrepare produces, so the
+// result can back VMImages like any prepared image. It is synchronous and
+// returns no task.
+func (s *Server) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	p, ok := s.providerFor(ctx, req.VmId).(*Provider)
+	if !ok || p == nil || p.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+	var options contracts.PublishImageOptions
+	if req.OptionsJson != "" {
+		if err := contracts.UnmarshalPayload([]byte(req.OptionsJson), &options); err != nil {
+			return nil, errors.NewInvalidSpec("invalid publish options JSON: %v", err)
+		}
+	}
+
+	path, checksum, err := p.publishImage(ctx, req.VmId, req.ImageName, options, req.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+	return &providerv1.PublishImageResponse{
+		ImageId:      req.ImageName,
+		ImagePath:    path,
+		Checksum:     checksum,
+		ChecksumType: defaultChecksumType,
+	}, nil
+}
+
+// publishImage copies the domain's primary disk to a hidden file in the
+// pool, flattened and sparse (qemu-img convert skips zeroed clusters), runs
+// the requested virt-sysprep operations on the copy, hashes it and only
+// then moves it into place. A failed publish therefore never leaves a
+// half-written image under the published name, and an overwrite replaces
+// the old image in one rename.
+//
+// The domain must be shut off unless options.SnapshotID names one of its
+// internal snapshots, which is read without stopping it.
+func (p *Provider) publishImage(ctx context.Context, vmID, imageName string, options contracts.PublishImageOptions, overwrite bool) (path, checksum string, err error) {
+	if strings.TrimSpace(imageName) == "" || strings.ContainsAny(imageName, "/ ") {
+		return "", "", errors.NewInvalidSpec("PublishImage: image name %q must be non-empty without slashes or spaces", imageName)
+	}
+	if options.ContentLibrary != "" {
+		return "", "", errors.NewInvalidSpec("PublishImage: content libraries are a vSphere feature")
+	}
+	for _, op := range options.Cleanup {
+		if !sysprepOperation.MatchString(op) {
+			return "", "", errors.NewInvalidSpec("PublishImage: %q is not a virt-sysprep operation", op)
+		}
+	}
+
+	state, err := p.virshProvider.getDomainState(ctx, vmID)
+	if err != nil {
+		return "", "", errors.NewNotFound("VM", vmID)
+	}
+	if options.SnapshotID == "" && state != "shut off" {
+		return "", "", errors.NewInvalidSpec("PublishImage: VM %s is %s; shut it off or publish from a snapshot", vmID, state)
+	}
+
+	storageProvider := NewStorageProvider(p.virshProvider)
+	poolName := resolveTargetPool(options.Storage, "")
+	poolInfo, err := storageProvider.GetPoolInfo(ctx, poolName)
+	if err != nil {
+		return "", "", errors.NewUnavailable(fmt.Sprintf("storage pool %q", poolName), err)
+	}
+	if poolInfo.Path == "" {
+		return "", "", errors.NewInvalidSpec("storage pool %q has no filesystem path; cannot place image", poolName)
+	}
+	path = targetImagePath(poolInfo.Path, imageName)
+	if p.targetImageExists(ctx, path) && !overwrite {
+		return "", "", errors.NewAlreadyExists("image", path)
+	}
+
+	srcPath, _, err := p.resolvePrimaryDisk(ctx, vmID, storageProvider)
+	if err != nil {
+		return "", "", err
+	}
+
+	tmpPath := filepath.Join(poolInfo.Path, fmt.Sprintf(".virtrigaud-publish-%s.qcow2", imageName))
+	log.Printf("INFO PublishImage: copying %q (snapshot %q) of VM %s to %q", srcPath, options.SnapshotID, vmID, tmpPath)
+	if res, err := p.virshProvider.runVirshCommand(ctx, publishConvertArgs(srcPath, tmpPath, options.SnapshotID)...); err != nil {
+		p.removeHostFile(ctx, tmpPath)
+		return "", "", errors.NewInternal(fmt.Sprintf("PublishImage: copy disk of VM %s: %s", vmID, commandStderr(res)), err)
+	}
+
+	if len(options.Cleanup) > 0 {
+		log.Printf("INFO PublishImage: running virt-sysprep %v on %q", options.Cleanup, tmpPath)
+		res, err := p.virshProvider.runVirshCommand(ctx, "!",
+			"virt-sysprep", "-a", tmpPath, "--operations", strings.Join(options.Cleanup, ","))
+		if err != nil {
+			p.removeHostFile(ctx, tmpPath)
+			return "", "", errors.NewInternal(fmt.Sprintf("PublishImage: virt-sysprep: %s", commandStderr(res)), err)
+		}
+	}
+
+	res, err := p.virshProvider.runVirshCommand(ctx, "!", "sha256sum", tmpPath)
+	if err != nil || len(strings.Fields(res.Stdout)) == 0 {
+		p.removeHostFile(ctx, tmpPath)
+		return "", "", errors.NewInternal(fmt.Sprintf("PublishImage: hash %q: %s", tmpPath, commandStderr(res)), err)
+	}
+	checksum = strings.Fields(res.Stdout)[0]
+
+	if res, err := p.virshProvider.runVirshCommand(ctx, "!", "mv", "-f", tmpPath, path); err != nil {
+		p.removeHostFile(ctx, tmpPath)
+		return "", "", errors.NewInternal(fmt.Sprintf("PublishImage: move image into place: %s", commandStderr(res)), err)
+	}
+	p.finalizeClonedDisk(ctx, path)
+	log.Printf("INFO PublishImage: published VM %s as %q (sha256 %s)", vmID, path, checksum)
+	return path, checksum, nil
+}
+
+// publishConvertArgs builds the host command copying srcPath to dstPath as
+// a standalone qcow2. With a snapshot the copy reads that internal snapshot
+// and -U lets it share the disk with the running domain.
+func publishConvertArgs(srcPath, dstPath, snapshot string) []string {
+	args := []string{"!", "qemu-img", "convert", "-O", "qcow2"}
+	if snapshot != "" {
+		args = append(args, "-U", "-l", "snapshot.name="+snapshot)
+	}
+	return append(args, srcPath, dstPath)
+}
+
+// commandStderr is the trimmed stderr of a host command, if it ran.
+func commandStderr(res *VirshResult) string {
+	if res == nil {
+		return ""
+	}
+	return strings.TrimSpace(res.Stderr)
+}
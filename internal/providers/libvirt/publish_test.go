@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestPublishConvertArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"!", "qemu-img", "convert", "-O", "qcow2", "/pool/web-disk.qcow2", "/pool/.tmp.qcow2"},
+		publishConvertArgs("/pool/web-disk.qcow2", "/pool/.tmp.qcow2", ""))
+	assert.Equal(t,
+		[]string{"!", "qemu-img", "convert", "-O", "qcow2", "-U", "-l", "snapshot.name=pre", "/pool/web-disk.qcow2", "/pool/.tmp.qcow2"},
+		publishConvertArgs("/pool/web-disk.qcow2", "/pool/.tmp.qcow2", "pre"))
+}
+
+// TestPublishImage_RejectsBadOptions verifies option validation fires before
+// any host interaction.
+func TestPublishImage_RejectsBadOptions(t *testing.T) {
+	p := &Provider{virshProvider: &VirshProvider{}}
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		imageName string
+		options   contracts.PublishImageOptions
+	}{
+		"no name":         {"", contracts.PublishImageOptions{}},
+		"path in name":    {"../etc/x", contracts.PublishImageOptions{}},
+		"content library": {"golden", contracts.PublishImageOptions{ContentLibrary: "lib"}},
+		"bad operation":   {"golden", contracts.PublishImageOptions{Cleanup: []string{"machine-id", "rm -rf"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := p.publishImage(ctx, "web", tc.imageName, tc.options, false)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestServer_PublishImage_NilProvider(t *testing.T) {
+	s := &Server{}
+	resp, err := s.PublishImage(context.Background(), &providerv1.PublishImageRequest{VmId: "web", ImageName: "golden"})
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "not initialized")
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// quiesceTimeout bounds each guest agent call made to quiesce a domain, so
+// that a hung agent cannot hold the guest's filesystems frozen.
+const quiesceTimeout = 30 * time.Second
+
+// fsFreezer freezes and thaws a domain's filesystems through its guest
+// agent.
+type fsFreezer interface {
+	agentResponsive(ctx context.Context) bool
+	freeze(ctx context.Context) error
+	thaw(ctx context.Context) error
+}
+
+// domainFreezer is the fsFreezer of a libvirt domain, driven through virsh
+// (guest-ping, guest-fsfreeze-freeze and guest-fsfreeze-thaw).
+type domainFreezer struct {
+	virsh  *VirshProvider
+	domain string
+}
+
+func (f domainFreezer) agentResponsive(ctx context.Context) bool {
+	return NewGuestAgentProvider(f.virsh).isGuestAgentAvailable(ctx, f.domain)
+}
+
+func (f domainFreezer) freeze(ctx context.Context) error {
+	_, err := f.virsh.runVirshCommand(ctx, "domfsfreeze", f.domain)
+	return err
+}
+
+func (f domainFreezer) thaw(ctx context.Context) error {
+	_, err := f.virsh.runVirshCommand(ctx, "domfsthaw", f.domain)
+	return err
+}
+
+// snapshotQuiesced runs take, the snapshot itself, with the domain's
+// filesystems frozen when req asks for quiesce, and reports the outcome.
+// A stopped domain and a memory snapshot are not frozen: the disks of the
+// one are at rest, and freezing the other would capture a frozen guest.
+// When the agent does not answer or the freeze fails, the snapshot is
+// refused with FailedPrecondition, or taken crash-consistent if
+// req.AllowUnquiesced is set. A frozen domain is always thawed, also when
+// take fails or ctx is canceled.
+func snapshotQuiesced(ctx context.Context, f fsFreezer, req *providerv1.SnapshotCreateRequest, running, memorySnapshot bool,
+	take func() error) (providerv1.QuiesceOutcome, string, error) {
+	var skipped string
+	switch {
+	case !req.Quiesce:
+		skipped = "quiesce was not requested"
+	case !running:
+		skipped = "the domain is not running, so its disks are at rest"
+	case memorySnapshot:
+		skipped = "the snapshot captures the running guest's memory"
+	}
+	if skipped != "" {
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, skipped, take()
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, quiesceTimeout)
+	responsive := f.agentResponsive(pingCtx)
+	cancel()
+	problem := ""
+	if !responsive {
+		problem = "the guest agent does not respond"
+	} else {
+		freezeCtx, cancel := context.WithTimeout(ctx, quiesceTimeout)
+		err := f.freeze(freezeCtx)
+		cancel()
+		if err != nil {
+			problem = fmt.Sprintf("freezing the guest's filesystems failed: %v", err)
+			// A freeze that timed out may still have frozen some filesystems.
+			thawDomain(ctx, f, req.VmId)
+		}
+	}
+	if problem != "" {
+		if !req.AllowUnquiesced {
+			return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_UNSPECIFIED, "",
+				errors.NewFailedPrecondition("cannot quiesce domain %s: %s", req.VmId, problem)
+		}
+		log.Printf("WARN Taking a crash-consistent snapshot of domain %s: %s", req.VmId, problem)
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, problem, take()
+	}
+
+	defer thawDomain(ctx, f, req.VmId)
+	if err := take(); err != nil {
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_UNSPECIFIED, "", err
+	}
+	return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED, "", nil
+}
+
+// thawDomain thaws the domain's filesystems on a context of its own, since
+// the caller's may be the reason the snapshot stopped.
+func thawDomain(ctx context.Context, f fsFreezer, domain string) {
+	thawCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), quiesceTimeout)
+	defer cancel()
+	if err := f.thaw(thawCtx); err != nil {
+		log.Printf("ERROR Failed to thaw the filesystems of domain %s; the guest may stay frozen until `virsh domfsthaw %s`: %v",
+			domain, domain, err)
+	}
+}
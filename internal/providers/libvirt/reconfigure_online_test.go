@@ -21,6 +21,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 )
 
 // TestBuildCPUMemoryXML_HotAddOff verifies that with both hot-add flags off the
@@ -150,3 +154,28 @@ func TestComputeHotplugCeilingMemoryMiB(t *testing.T) {
 		})
 	}
 }
+
+// TestChangeSetFromProto covers the wire conversion, including the nil
+// ChangeSet an older manager sends, which falls back to legacyChangeSet.
+func TestChangeSetFromProto(t *testing.T) {
+	empty, err := changeSetFromProto(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+
+	changes, err := changeSetFromProto(&providerv1.ChangeSet{
+		Cpu:           &providerv1.Int32Change{Old: 2, New: 4},
+		Disks:         []*providerv1.DiskExpansion{{Name: "root", OldSizeGib: 20, NewSizeGib: 40}},
+		NetworksAdded: []*providerv1.NetworkChange{{Name: "data", AttachmentJson: `{"Name":"data","NetworkName":"isolated"}`}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{contracts.ChangeCPU, contracts.ChangeDisks, contracts.ChangeNetworks}, changes.Keys())
+	assert.Equal(t, int32(4), changes.CPU.New)
+	assert.Equal(t, "isolated", changes.NetworksAdded[0].NetworkName)
+
+	legacy := legacyChangeSet(contracts.CreateRequest{Class: contracts.VMClass{
+		CPU: 2, MemoryMiB: 4096, DiskDefaults: &contracts.DiskDefaults{SizeGiB: 30},
+	}})
+	assert.Equal(t, int32(2), legacy.CPU.New)
+	assert.Equal(t, int64(4096), legacy.MemoryMiB.New)
+	assert.Equal(t, []contracts.DiskExpansion{{Name: contracts.RootDiskName, NewSizeGiB: 30}}, legacy.Disks)
+}
@@ -0,0 +1,248 @@
+//go:build integration
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+)
+
+// The TestScenario* tests drive the provider's RPC handlers through whole
+// VM lifecycles against a real libvirtd: the containerized one in
+// test/integration/docker-compose.yaml, reached over the SSH transport. They
+// need the integration build tag and the virsh client, and run only when
+// both variables below are set, as `make integration-fixtures-up
+// test-integration` does. Each test works on domains of its own, so they
+// run in parallel against the one libvirtd.
+const (
+	envTestLibvirtURI   = "VIRTRIGAUD_TEST_LIBVIRT_URI"
+	envTestLibvirtKey   = "VIRTRIGAUD_TEST_LIBVIRT_KEY"
+	envTestLibvirtImage = "VIRTRIGAUD_TEST_LIBVIRT_IMAGE"
+
+	// defaultScenarioImage is the blank qcow2 the fixture's entrypoint
+	// creates to clone VMs from.
+	defaultScenarioImage = "/var/lib/libvirt/images/scenario-base.qcow2"
+
+	scenarioTimeout = 5 * time.Minute
+)
+
+// newScenarioServer returns a Server whose provider talks to the fixture's
+// libvirtd over SSH.
+func newScenarioServer(t *testing.T) *Server {
+	t.Helper()
+	raw, key := os.Getenv(envTestLibvirtURI), os.Getenv(envTestLibvirtKey)
+	if raw == "" || key == "" {
+		t.Skipf("%s and %s not set; skipping libvirt scenario tests", envTestLibvirtURI, envTestLibvirtKey)
+	}
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	q := u.Query()
+	q.Set("keyfile", key)
+	q.Set("no_tty", "1")
+	u.RawQuery = q.Encode()
+
+	v := &VirshProvider{
+		uri:         u.String(),
+		credentials: &Credentials{SSHPrivateKey: "mounted"},
+		// The fixture's host key is generated at container start; there
+		// is nothing to pin it against.
+		hostKey: hostKeyPolicy{insecure: true},
+		env:     append(os.Environ(), "LIBVIRT_DEFAULT_URI="+u.String()),
+		execSem: make(chan struct{}, defaultMaxConcurrentVirsh),
+	}
+	require.NoError(t, v.testConnection(context.Background()))
+	return NewServer(&Provider{virshProvider: v, credentials: &Credentials{}})
+}
+
+// scenarioName returns a domain name unique to this run of t, so parallel
+// tests and reruns against a long-lived fixture never collide.
+func scenarioName(t *testing.T, prefix string) string {
+	b := make([]byte, 3)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return prefix + "-" + hex.EncodeToString(b)
+}
+
+func scenarioImageJSON() string {
+	image := os.Getenv(envTestLibvirtImage)
+	if image == "" {
+		image = defaultScenarioImage
+	}
+	return `{"path": "` + image + `"}`
+}
+
+// createScenarioVM creates a small VM named name from the fixture's base
+// image and deletes it, with its volumes, when t ends.
+func createScenarioVM(ctx context.Context, t *testing.T, s *Server, name string) string {
+	t.Helper()
+	resp, err := s.Create(ctx, &providerv1.CreateRequest{
+		Name:      name,
+		ClassJson: `{"cpu": 1, "memoryMiB": 256, "diskDefaults": {"sizeGiB": 1}}`,
+		ImageJson: scenarioImageJSON(),
+		UserData:  []byte("#cloud-config\nhostname: " + name),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Id)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+		defer cancel()
+		if _, err := s.Delete(ctx, &providerv1.DeleteRequest{Id: resp.Id}); err != nil {
+			t.Logf("cleanup of %s failed: %v", resp.Id, err)
+		}
+	})
+	return resp.Id
+}
+
+// inactiveDomain returns the persistent definition of the domain id.
+func inactiveDomain(ctx context.Context, t *testing.T, s *Server, id string) *domainXML {
+	t.Helper()
+	v := s.provider.(*Provider).virshProvider
+	res, err := v.runVirshCommand(ctx, "dumpxml", "--inactive", id)
+	require.NoError(t, err)
+	dx, err := parseDomainXML(res.Stdout)
+	require.NoError(t, err)
+	return dx
+}
+
+func powerScenarioVM(ctx context.Context, t *testing.T, s *Server, id string, op providerv1.PowerOp, want string) {
+	t.Helper()
+	_, err := s.Power(ctx, &providerv1.PowerRequest{Id: id, Op: op})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		d, err := s.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+		return err == nil && d.PowerState == want
+	}, time.Minute, time.Second)
+}
+
+func TestScenario_CreateFromTemplate(t *testing.T) {
+	t.Parallel()
+	s := newScenarioServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+
+	id := createScenarioVM(ctx, t, s, scenarioName(t, "scenario-create"))
+
+	details, err := conformance.CheckDescribeDetails(ctx, s, id)
+	require.NoError(t, err)
+	require.NotNil(t, details.Resources)
+	assert.Equal(t, int32(1), details.Resources.CPUCount)
+	dx := inactiveDomain(ctx, t, s, id)
+	assert.Equal(t, int32(1), dx.VCPUs())
+	memory, err := dx.MemoryMiB()
+	require.NoError(t, err)
+	assert.Equal(t, int64(256), memory)
+}
+
+func TestScenario_Reconfigure(t *testing.T) {
+	t.Parallel()
+	s := newScenarioServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+	id := createScenarioVM(ctx, t, s, scenarioName(t, "scenario-reconfigure"))
+	powerScenarioVM(ctx, t, s, id, providerv1.PowerOp_POWER_OP_OFF, "Off")
+
+	resp, err := s.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id: id,
+		Changes: &providerv1.ChangeSet{
+			Cpu:       &providerv1.Int32Change{Old: 1, New: 2},
+			MemoryMib: &providerv1.Int64Change{Old: 256, New: 512},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.PowerCycleRequired, "a stopped domain takes every change")
+
+	dx := inactiveDomain(ctx, t, s, id)
+	assert.Equal(t, int32(2), dx.VCPUs())
+	memory, err := dx.MemoryMiB()
+	require.NoError(t, err)
+	assert.Equal(t, int64(512), memory)
+}
+
+func TestScenario_SnapshotLifecycle(t *testing.T) {
+	t.Parallel()
+	s := newScenarioServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+	id := createScenarioVM(ctx, t, s, scenarioName(t, "scenario-snapshots"))
+	powerScenarioVM(ctx, t, s, id, providerv1.PowerOp_POWER_OP_OFF, "Off")
+
+	var ids []string
+	for _, name := range []string{"base", "upgrade"} {
+		resp, err := s.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: id, NameHint: name})
+		require.NoError(t, err)
+		assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, resp.Quiesce)
+		ids = append(ids, resp.SnapshotId)
+	}
+
+	list, err := s.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: id})
+	require.NoError(t, err)
+	require.Len(t, list.Snapshots, 2)
+	assert.Equal(t, ids[0], list.Snapshots[1].ParentId, "snapshots chain onto the latest one")
+
+	_, err = s.SnapshotRevert(ctx, &providerv1.SnapshotRevertRequest{VmId: id, SnapshotId: ids[0]})
+	require.NoError(t, err)
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		_, err := s.SnapshotDelete(ctx, &providerv1.SnapshotDeleteRequest{VmId: id, SnapshotId: ids[i]})
+		require.NoError(t, err)
+	}
+	list, err = s.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: id})
+	require.NoError(t, err)
+	assert.Empty(t, list.Snapshots)
+}
+
+func TestScenario_DeleteIdempotent(t *testing.T) {
+	t.Parallel()
+	s := newScenarioServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+	name := scenarioName(t, "scenario-delete")
+	id := createScenarioVM(ctx, t, s, name)
+
+	// A running domain is stopped before it is undefined.
+	powerScenarioVM(ctx, t, s, id, providerv1.PowerOp_POWER_OP_ON, "On")
+	_, err := s.Delete(ctx, &providerv1.DeleteRequest{Id: id})
+	require.NoError(t, err)
+
+	describe, err := s.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+	require.NoError(t, err)
+	assert.False(t, describe.Exists)
+	vols, err := s.provider.(*Provider).listPoolVolumes(ctx)
+	require.NoError(t, err)
+	for _, vol := range vols {
+		assert.False(t, strings.HasPrefix(vol.name, name), "volume %s/%s outlived its domain", vol.pool, vol.name)
+	}
+
+	// Deleting it again, as a controller retrying after a lost response
+	// does, succeeds.
+	_, err = s.Delete(ctx, &providerv1.DeleteRequest{Id: id})
+	require.NoError(t, err)
+}
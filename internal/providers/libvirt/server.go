@@ -18,7 +18,6 @@ package libvirt
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -28,21 +27,29 @@ import (
 	"strings"
 	"time"
 
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/storage/migration"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
 // Server implements the providerv1.ProviderServer interface for Libvirt
 type Server struct {
 	providerv1.UnimplementedProviderServer
 	provider contracts.Provider
+
+	// clones caps the Creates and Clones copying disks at once
+	// (PROVIDER_MAX_CONCURRENT_CLONES); one over the limit is turned away
+	// as busy.
+	clones *common.CloneLimiter
 }
 
 // NewServer creates a new Libvirt gRPC server
 func NewServer(provider contracts.Provider) *Server {
 	return &Server{
 		provider: provider,
+		clones:   common.CloneLimiterFromEnv(),
 	}
 }
 
@@ -83,6 +90,12 @@ func (s *Server) Create(ctx context.Context, req *providerv1.CreateRequest) (*pr
 		return nil, fmt.Errorf("failed to parse create request: %w", err)
 	}
 
+	release, err := s.clones.Acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	resp, err := s.provider.Create(ctx, createReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create VM: %w", err)
@@ -101,10 +114,21 @@ func (s *Server) Create(ctx context.Context, req *providerv1.CreateRequest) (*pr
 
 // Delete deletes a virtual machine
 func (s *Server) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*providerv1.TaskResponse, error) {
-	taskRef, err := s.provider.Delete(ctx, req.Id)
+	var taskRef string
+	var err error
+	provider := s.providerFor(ctx, req.Id)
+	if p, ok := provider.(*Provider); ok {
+		// Unset means delete: only volumes the provider created are touched.
+		taskRef, err = p.deleteVM(ctx, req.Id, req.DeleteVolumes == nil || req.GetDeleteVolumes())
+	} else {
+		taskRef, err = provider.Delete(ctx, req.Id)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete VM: %w", err)
 	}
+	if p, ok := s.provider.(*Provider); ok && p.hosts != nil {
+		p.hosts.forget(req.Id)
+	}
 
 	result := &providerv1.TaskResponse{}
 	if taskRef != "" {
@@ -126,11 +150,13 @@ func (s *Server) Power(ctx context.Context, req *providerv1.PowerRequest) (*prov
 		powerOp = contracts.PowerOpReboot
 	case providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL:
 		powerOp = contracts.PowerOpShutdownGraceful
+	case providerv1.PowerOp_POWER_OP_RESET:
+		powerOp = contracts.PowerOpReset
 	default:
 		return nil, fmt.Errorf("unsupported power operation: %v", req.Op)
 	}
 
-	taskRef, err := s.provider.Power(ctx, req.Id, powerOp)
+	taskRef, err := s.providerFor(ctx, req.Id).Power(ctx, req.Id, powerOp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform power operation: %w", err)
 	}
@@ -144,24 +170,59 @@ func (s *Server) Power(ctx context.Context, req *providerv1.PowerRequest) (*prov
 }
 
 // Reconfigure reconfigures a virtual machine
-func (s *Server) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+func (s *Server) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	// Parse the desired configuration
 	var createReq contracts.CreateRequest
-	if err := json.Unmarshal([]byte(req.DesiredJson), &createReq); err != nil {
+	if err := contracts.UnmarshalPayload([]byte(req.DesiredJson), &createReq); err != nil {
 		return nil, fmt.Errorf("failed to parse desired configuration: %w", err)
 	}
 
-	taskRef, err := s.provider.Reconfigure(ctx, req.Id, createReq)
+	changes, err := changeSetFromProto(req.GetChanges())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.providerFor(ctx, req.Id).Reconfigure(ctx, req.Id, createReq, changes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to reconfigure VM: %w", err)
 	}
 
-	result := &providerv1.TaskResponse{}
-	if taskRef != "" {
-		result.Task = &providerv1.TaskRef{Id: taskRef}
+	resp := &providerv1.ReconfigureResponse{PowerCycleRequired: result.PowerCycleRequired}
+	if result.TaskRef != "" {
+		resp.Task = &providerv1.TaskRef{Id: result.TaskRef}
 	}
 
-	return result, nil
+	return resp, nil
+}
+
+// changeSetFromProto converts the wire ChangeSet. A nil ChangeSet, sent by a
+// manager that predates it, yields an empty one.
+func changeSetFromProto(in *providerv1.ChangeSet) (contracts.ChangeSet, error) {
+	var out contracts.ChangeSet
+	if in == nil {
+		return out, nil
+	}
+	if in.Cpu != nil {
+		out.CPU = &contracts.Int32Change{Old: in.Cpu.Old, New: in.Cpu.New}
+	}
+	if in.MemoryMib != nil {
+		out.MemoryMiB = &contracts.Int64Change{Old: in.MemoryMib.Old, New: in.MemoryMib.New}
+	}
+	for _, d := range in.Disks {
+		out.Disks = append(out.Disks, contracts.DiskExpansion{Name: d.Name, OldSizeGiB: d.OldSizeGib, NewSizeGiB: d.NewSizeGib})
+	}
+	for _, n := range in.NetworksAdded {
+		var attachment contracts.NetworkAttachment
+		if err := contracts.UnmarshalPayload([]byte(n.AttachmentJson), &attachment); err != nil {
+			return out, fmt.Errorf("failed to parse network attachment %q: %w", n.Name, err)
+		}
+		out.NetworksAdded = append(out.NetworksAdded, attachment)
+	}
+	out.NetworksRemoved = in.NetworksRemoved
+	for _, sg := range in.SecurityGroups {
+		out.SecurityGroups = append(out.SecurityGroups, contracts.SecurityGroupChange{Network: sg.Network, Groups: sg.Groups})
+	}
+	return out, nil
 }
 
 // Describe describes the current state of a virtual machine
@@ -170,18 +231,27 @@ func (s *Server) Describe(ctx context.Context, req *providerv1.DescribeRequest)
 		return nil, fmt.Errorf("provider not initialized")
 	}
 
-	resp, err := s.provider.Describe(ctx, req.Id)
+	provider := s.providerFor(ctx, req.Id)
+	resp, err := provider.Describe(ctx, req.Id)
+	if p, ok := s.provider.(*Provider); ok && err != nil && p.hosts.multiHost() {
+		// The domain may have moved since it was last seen, e.g. migrated by
+		// hand: look for it on every host again.
+		p.hosts.forget(req.Id)
+		if relocated := s.providerFor(ctx, req.Id); relocated != provider {
+			provider = relocated
+			resp, err = provider.Describe(ctx, req.Id)
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe VM: %w", err)
 	}
-
-	// Convert provider raw data to JSON
-	providerRawJSON := "{}"
-	if len(resp.ProviderRaw) > 0 {
-		data, err := json.Marshal(resp.ProviderRaw)
-		if err == nil {
-			providerRawJSON = string(data)
-		}
+	host := ""
+	if p, ok := provider.(*Provider); ok {
+		host = p.hostOf()
+	}
+	providerRawJSON, err := describeDetails(resp, host).Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode VM details: %w", err)
 	}
 
 	return &providerv1.DescribeResponse{
@@ -190,11 +260,39 @@ func (s *Server) Describe(ctx context.Context, req *providerv1.DescribeRequest)
 		Ips:             resp.IPs,
 		ConsoleUrl:      resp.ConsoleURL,
 		ProviderRawJson: providerRawJSON,
+		GuestStats:      guestStatsToProto(resp.GuestStats),
+		Addresses:       guestAddressesToProto(resp.Addresses),
+		Identity:        resp.Identity,
 	}, nil
 }
 
+func guestAddressesToProto(addrs []contracts.GuestAddress) []*providerv1.GuestAddress {
+	if len(addrs) == 0 {
+		return nil
+	}
+	out := make([]*providerv1.GuestAddress, 0, len(addrs))
+	for _, a := range addrs {
+		out = append(out, &providerv1.GuestAddress{Ip: a.IP, Interface: a.Interface})
+	}
+	return out
+}
+
+func guestStatsToProto(gs *contracts.GuestStats) *providerv1.GuestStats {
+	if gs == nil {
+		return nil
+	}
+	return &providerv1.GuestStats{
+		CpuUsagePercent:  gs.CPUUsagePercent,
+		MemoryUsageBytes: gs.MemoryUsageBytes,
+		UptimeSeconds:    gs.UptimeSeconds,
+	}
+}
+
 // TaskStatus checks the status of an async task
 func (s *Server) TaskStatus(ctx context.Context, req *providerv1.TaskStatusRequest) (*providerv1.TaskStatusResponse, error) {
+	if p, ok := s.provider.(*Provider); ok && p.hosts != nil && strings.HasPrefix(req.GetTask().GetId(), migrationTaskPrefix) {
+		return p.hosts.migrationStatus(ctx, req.Task.Id), nil
+	}
 	done, err := s.provider.IsTaskComplete(ctx, req.Task.Id)
 	if err != nil {
 		return &providerv1.TaskStatusResponse{
@@ -212,48 +310,63 @@ func (s *Server) TaskStatus(ctx context.Context, req *providerv1.TaskStatusReque
 // parseCreateRequest converts gRPC request to contracts.CreateRequest
 func (s *Server) parseCreateRequest(req *providerv1.CreateRequest) (contracts.CreateRequest, error) {
 	createReq := contracts.CreateRequest{
-		Name: req.Name,
-		Tags: req.Tags,
+		Name:           req.Name,
+		Tags:           req.Tags,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
-	// Parse UserData if provided
-	if len(req.UserData) > 0 {
+	// Parse UserData, network-config and vendor-data if provided
+	if len(req.UserData) > 0 || len(req.NetworkConfig) > 0 || len(req.VendorData) > 0 {
 		createReq.UserData = &contracts.UserData{
 			CloudInitData: string(req.UserData),
+			NetworkConfig: string(req.NetworkConfig),
+			VendorData:    string(req.VendorData),
+		}
+	}
+
+	// Parse guest customization; only Sysprep needs anything beyond cloud-init
+	unattend, err := contracts.SysprepUnattendFromJSON(req.GuestCustomizationJson)
+	if err != nil {
+		return createReq, err
+	}
+	if unattend != "" {
+		createReq.GuestCustomization = &contracts.GuestCustomization{
+			Type:    contracts.GuestCustomizationSysprep,
+			Sysprep: &contracts.SysprepCustomization{UnattendXML: unattend},
 		}
 	}
 
 	// Parse VMClass
 	if req.ClassJson != "" {
-		if err := json.Unmarshal([]byte(req.ClassJson), &createReq.Class); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.ClassJson), &createReq.Class); err != nil {
 			return createReq, fmt.Errorf("failed to parse class JSON: %w", err)
 		}
 	}
 
 	// Parse VMImage
 	if req.ImageJson != "" {
-		if err := json.Unmarshal([]byte(req.ImageJson), &createReq.Image); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.ImageJson), &createReq.Image); err != nil {
 			return createReq, fmt.Errorf("failed to parse image JSON: %w", err)
 		}
 	}
 
 	// Parse Networks
 	if req.NetworksJson != "" {
-		if err := json.Unmarshal([]byte(req.NetworksJson), &createReq.Networks); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.NetworksJson), &createReq.Networks); err != nil {
 			return createReq, fmt.Errorf("failed to parse networks JSON: %w", err)
 		}
 	}
 
 	// Parse Disks
 	if req.DisksJson != "" {
-		if err := json.Unmarshal([]byte(req.DisksJson), &createReq.Disks); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.DisksJson), &createReq.Disks); err != nil {
 			return createReq, fmt.Errorf("failed to parse disks JSON: %w", err)
 		}
 	}
 
 	// Parse Placement
 	if req.PlacementJson != "" {
-		if err := json.Unmarshal([]byte(req.PlacementJson), &createReq.Placement); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.PlacementJson), &createReq.Placement); err != nil {
 			return createReq, fmt.Errorf("failed to parse placement JSON: %w", err)
 		}
 	}
@@ -266,7 +379,7 @@ func (s *Server) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotCre
 	log.Printf("INFO Creating snapshot for VM: %s", req.VmId)
 
 	// Get the provider instance and cast to libvirt Provider
-	libvirtProvider, ok := s.provider.(*Provider)
+	libvirtProvider, ok := s.providerFor(ctx, req.VmId).(*Provider)
 	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
 		return nil, fmt.Errorf("libvirt provider not initialized")
 	}
@@ -312,18 +425,27 @@ func (s *Server) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotCre
 		log.Printf("INFO Creating disk-only snapshot for domain %s", req.VmId)
 	}
 
-	// Execute snapshot creation
-	result, err := libvirtProvider.virshProvider.runVirshCommand(ctx, args...)
+	// Execute snapshot creation, with the guest's filesystems frozen when
+	// quiesce is requested
+	freezer := domainFreezer{virsh: libvirtProvider.virshProvider, domain: req.VmId}
+	quiesce, quiesceMessage, err := snapshotQuiesced(ctx, freezer, req, domainState == "running", memorySnapshot, func() error {
+		result, err := libvirtProvider.virshProvider.runVirshCommand(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot: %w", err)
+		}
+		log.Printf("INFO Snapshot created successfully: %s\nOutput: %s", snapshotName, result.Stdout)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+		return nil, err
 	}
 
-	log.Printf("INFO Snapshot created successfully: %s\nOutput: %s", snapshotName, result.Stdout)
-
 	// Return snapshot ID (synchronous operation for libvirt)
 	return &providerv1.SnapshotCreateResponse{
 		SnapshotId: snapshotName,
 		// No task reference - libvirt snapshots are synchronous
+		Quiesce:        quiesce,
+		QuiesceMessage: quiesceMessage,
 	}, nil
 }
 
@@ -355,7 +477,7 @@ func (s *Server) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotDel
 	log.Printf("INFO Deleting snapshot %s from VM: %s", req.SnapshotId, req.VmId)
 
 	// Get the provider instance and cast to libvirt Provider
-	libvirtProvider, ok := s.provider.(*Provider)
+	libvirtProvider, ok := s.providerFor(ctx, req.VmId).(*Provider)
 	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
 		return nil, fmt.Errorf("libvirt provider not initialized")
 	}
@@ -397,7 +519,7 @@ func (s *Server) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotRev
 	log.Printf("INFO Reverting VM %s to snapshot: %s", req.VmId, req.SnapshotId)
 
 	// Get the provider instance and cast to libvirt Provider
-	libvirtProvider, ok := s.provider.(*Provider)
+	libvirtProvider, ok := s.providerFor(ctx, req.VmId).(*Provider)
 	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
 		return nil, fmt.Errorf("libvirt provider not initialized")
 	}
@@ -445,31 +567,62 @@ func (s *Server) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotRev
 	return &providerv1.TaskResponse{}, nil
 }
 
+// SnapshotList lists the snapshots of a VM with their parentage. libvirt
+// addresses snapshots by name, so the name doubles as the snapshot ID.
+func (s *Server) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	libvirtProvider, ok := s.providerFor(ctx, req.VmId).(*Provider)
+	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+
+	snapshots, err := libvirtProvider.virshProvider.listSnapshotInfo(ctx, req.VmId)
+	if err != nil {
+		return nil, err
+	}
+	return &providerv1.SnapshotListResponse{Snapshots: snapshots}, nil
+}
+
 // Clone creates a VM clone. It delegates to the libvirt Provider
 // implementation (clone.go), translating between the gRPC and provider-contract
 // types. A full clone copies the source disk into an independent volume; a
 // linked clone (req.Linked) creates a qcow2 overlay backed by the source disk
 // and is therefore lifecycle-bound to it (issue #153).
 func (s *Server) Clone(ctx context.Context, req *providerv1.CloneRequest) (*providerv1.CloneResponse, error) {
-	libvirtProvider, ok := s.provider.(*Provider)
+	libvirtProvider, ok := s.providerFor(ctx, req.SourceVmId).(*Provider)
 	if !ok || libvirtProvider == nil {
 		return nil, fmt.Errorf("libvirt provider not initialized")
 	}
 
-	resp, err := libvirtProvider.Clone(ctx, contracts.CloneRequest{
+	release, err := s.clones.Acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cloneReq := contracts.CloneRequest{
 		SourceVmID:    req.SourceVmId,
 		TargetName:    req.TargetName,
 		Linked:        req.Linked,
 		ClassJSON:     req.ClassJson,
 		PlacementJSON: req.PlacementJson,
 		CustomizeJSON: req.CustomizeJson,
-	})
+	}
+	if req.CustomizationJson != "" {
+		cloneReq.Customization = &contracts.CloneCustomization{}
+		if err := contracts.UnmarshalPayload([]byte(req.CustomizationJson), cloneReq.Customization); err != nil {
+			return nil, fmt.Errorf("failed to parse customization JSON: %w", err)
+		}
+	}
+
+	resp, err := libvirtProvider.Clone(ctx, cloneReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to clone VM: %w", err)
 	}
 
 	result := &providerv1.CloneResponse{
-		TargetVmId: resp.TargetVmID,
+		TargetVmId:           resp.TargetVmID,
+		CustomizationApplied: resp.CustomizationApplied,
+		CustomizationError:   resp.CustomizationError,
 	}
 	if resp.TaskRef != "" {
 		result.Task = &providerv1.TaskRef{Id: resp.TaskRef}
@@ -518,11 +671,13 @@ func (s *Server) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepareR
 
 // GetCapabilities returns the capabilities of the Libvirt provider
 func (s *Server) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
+	p, _ := s.provider.(*Provider)
 	return &providerv1.GetCapabilitiesResponse{
 		SupportsReconfigureOnline:   true, // Online CPU/mem reconfigure via `setvcpus/setmem --live` for VMs created with CPU/MemoryHotAddEnabled (headroom provisioned at create); grows up to the ~4× ceiling, beyond which a power-cycle is required (#203)
 		SupportsDiskExpansionOnline: true, // Online grow via `virsh blockresize` + best-effort in-guest FS grow (resize2fs/xfs_growfs) when the guest agent is present; grow-only (#201)
 		SupportsSnapshots:           true, // Libvirt supports snapshots (storage-dependent)
 		SupportsMemorySnapshots:     true, // Full system checkpoints incl. RAM via `snapshot-create-as` without --disk-only; requires the VM running (#202)
+		SupportsQuiescedSnapshots:   true, // `virsh domfsfreeze`/`domfsthaw` through the guest agent around snapshot-create-as
 		SupportsLinkedClones:        true, // Clone RPC implemented: qcow2 overlay (linked) + vol-clone (full) (issue #153)
 		SupportsImageImport:         true, // ImagePrepare RPC implemented: import/convert image into a storage pool (issue #154)
 		SupportedDiskTypes:          []string{"qcow2", "raw", "vmdk"},
@@ -541,9 +696,50 @@ func (s *Server) GetCapabilities(ctx context.Context, req *providerv1.GetCapabil
 		SupportedExportBackends: migration.PVCS3AndNFSExportBackends(),
 		SupportedImportBackends: migration.PVCS3AndNFSImportBackends(),
 		SupportedTransferModes:  migration.RelayOnlyTransferModes(),
+		SupportsSysprep:         true,                            // unattend.xml is attached on an ISO in place of the cloud-init ISO
+		SupportsConsoleOutput:   true,                            // serial0 is logged to /var/log/libvirt/qemu/<name>-serial0.log for domains created by this provider
+		SupportsCloudInitStatus: true,                            // cloud-init status runs through the QEMU guest agent channel the generated domain XML includes
+		SupportsLiveMigration:   p != nil && p.hosts.multiHost(), // MigrateHost needs peer hosts (VIRTRIGAUD_LIBVIRT_PEER_HOSTS)
+		SupportsConsoleProxy:    true,                            // VNC on the host's loopback address, relayed through ConsoleRelay (ssh -W for qemu+ssh:// hosts)
+		SupportsImagePublish:    true,                            // qemu-img copy of the primary disk into a pool, optional virt-sysprep, sha256
+		SupportsEventWatch:      true,                            // `virsh event --all --loop` on every managed host; no history, so since is ignored
+	}, nil
+}
+
+// GetConsoleOutput returns the tail of a domain's serial console log. The log
+// is written by libvirtd for domains defined with the serial <log> element the
+// provider generates; older domains report NotFound.
+func (s *Server) GetConsoleOutput(ctx context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error) {
+	libvirtProvider, ok := s.providerFor(ctx, req.Id).(*Provider)
+	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
+		return nil, errors.NewUnavailable("libvirt provider not initialized", nil)
+	}
+
+	out, err := libvirtProvider.getConsoleOutput(ctx, req.Id, req.TailLines)
+	if err != nil {
+		if contracts.IsNotFound(err) {
+			return nil, errors.NewNotFound("serial console log for domain", req.Id)
+		}
+		return nil, errors.NewInternal("failed to get console output", err)
+	}
+
+	return &providerv1.GetConsoleOutputResponse{
+		Output:    out.Output,
+		Truncated: out.Truncated,
+		Source:    out.Source,
 	}, nil
 }
 
+// GetCloudInitStatus reports cloud-init's status inside a domain, read with
+// guest-exec through the QEMU guest agent.
+func (s *Server) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	libvirtProvider, ok := s.providerFor(ctx, req.Id).(*Provider)
+	if !ok || libvirtProvider == nil || libvirtProvider.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+	return libvirtProvider.getCloudInitStatus(ctx, req.Id)
+}
+
 // ExportDisk exports a VM disk for migration. It delegates to the libvirt
 // Provider implementation (provider_virsh.go), translating between the gRPC and
 // provider-contract types. Previously this RPC was unreachable over gRPC and
@@ -581,7 +777,7 @@ func (s *Server) ExportDisk(ctx context.Context, req *providerv1.ExportDiskReque
 		return nil, fmt.Errorf("provider not initialized")
 	}
 
-	resp, err := s.provider.ExportDisk(ctx, contracts.ExportDiskRequest{
+	resp, err := s.providerFor(ctx, req.VmId).ExportDisk(ctx, contracts.ExportDiskRequest{
 		VmId:           req.VmId,
 		DiskId:         req.DiskId,
 		SnapshotId:     req.SnapshotId,
@@ -615,7 +811,7 @@ func (s *Server) GetDiskInfo(ctx context.Context, req *providerv1.GetDiskInfoReq
 		return nil, fmt.Errorf("provider not initialized")
 	}
 
-	resp, err := s.provider.GetDiskInfo(ctx, contracts.GetDiskInfoRequest{
+	resp, err := s.providerFor(ctx, req.VmId).GetDiskInfo(ctx, contracts.GetDiskInfoRequest{
 		VmId:       req.VmId,
 		DiskId:     req.DiskId,
 		SnapshotId: req.SnapshotId,
@@ -805,7 +1001,7 @@ func (s *Server) ListVMs(ctx context.Context, req *providerv1.ListVMsRequest) (*
 		return nil, fmt.Errorf("provider not initialized")
 	}
 
-	vmInfos, err := s.provider.ListVMs(ctx)
+	vmInfos, err := s.listVMs(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list VMs: %w", err)
 	}
@@ -852,6 +1048,57 @@ func (s *Server) ListVMs(ctx context.Context, req *providerv1.ListVMsRequest) (*
 	}, nil
 }
 
+// listVMs lists the domains of every managed host, recording the host of
+// each in its ProviderRaw.
+func (s *Server) listVMs(ctx context.Context) ([]contracts.VMInfo, error) {
+	p, ok := s.provider.(*Provider)
+	if !ok || p.hosts == nil {
+		return s.provider.ListVMs(ctx)
+	}
+	var all []contracts.VMInfo
+	for _, h := range p.hosts.hosts {
+		vmInfos, err := h.provider.ListVMs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("host %s: %w", h.name, err)
+		}
+		for _, vmInfo := range vmInfos {
+			if vmInfo.ProviderRaw == nil {
+				vmInfo.ProviderRaw = map[string]string{}
+			}
+			vmInfo.ProviderRaw["host"] = h.name
+			if p.hosts.multiHost() {
+				p.hosts.remember(vmInfo.ID, h)
+			}
+			all = append(all, vmInfo)
+		}
+	}
+	return all, nil
+}
+
+// MigrateHost live-migrates a running domain to another managed host with
+// `virsh migrate --live --persistent --undefinesource`, copying its storage
+// unless the target shares it. The returned task reports progress through
+// TaskStatus. Providers without peer hosts answer Unimplemented.
+func (s *Server) MigrateHost(ctx context.Context, req *providerv1.MigrateHostRequest) (*providerv1.TaskResponse, error) {
+	p, ok := s.provider.(*Provider)
+	if !ok || p == nil || p.virshProvider == nil {
+		return nil, fmt.Errorf("libvirt provider not initialized")
+	}
+	if !p.hosts.multiHost() {
+		return nil, errors.NewNotSupported("this provider manages a single host; list the others in %s to migrate between them", EnvPeerHosts)
+	}
+
+	taskRef, err := p.hosts.migrate(ctx, req.Id, req.TargetHost)
+	if err != nil {
+		return nil, err
+	}
+	result := &providerv1.TaskResponse{}
+	if taskRef != "" {
+		result.Task = &providerv1.TaskRef{Id: taskRef}
+	}
+	return result, nil
+}
+
 // copyDiskToRemote copies a disk file from local pod storage to the remote libvirt host
 func (s *Server) copyDiskToRemote(ctx context.Context, virshProvider *VirshProvider, localPath, volumeName string) (string, error) {
 	// IMPORTANT: Copy directly to libvirt pool directory for efficient in-place usage
@@ -65,6 +65,19 @@ func TestServer_ImagePrepare_NilProvider(t *testing.T) {
 	assert.Contains(t, err.Error(), "not initialized")
 }
 
+// TestServer_GetConsoleOutput_NilProvider verifies that GetConsoleOutput
+// reports a typed Unavailable error, not an untyped one the manager would map
+// to Unknown, when no provider is wired.
+func TestServer_GetConsoleOutput_NilProvider(t *testing.T) {
+	s := &Server{}
+
+	resp, err := s.GetConsoleOutput(context.Background(), &providerv1.GetConsoleOutputRequest{Id: "vm-1"})
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
 // TestServer_GetCapabilities_HonestFlags verifies that the libvirt provider
 // advertises capabilities that match its actual behavior: linked clones are now
 // supported (Clone implemented, issue #153), image import remains unsupported
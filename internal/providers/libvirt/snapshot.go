@@ -0,0 +1,351 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"strings"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// snapshotTreeEntry is one snapshot from `virsh snapshot-list --tree`.
+type snapshotTreeEntry struct {
+	Name   string
+	Parent string
+}
+
+// parseSnapshotTree parses `virsh snapshot-list DOMAIN --tree` output. Roots
+// are printed flush left and every child as "+- name", indented four
+// columns per level below the first:
+//
+//	base
+//	  |
+//	  +- upgrade
+//	  |   |
+//	  |   +- upgrade-2
+//	  |
+//	  +- hotfix
+//
+// Entries are returned in tree order, so a parent precedes its children.
+func parseSnapshotTree(out string) []snapshotTreeEntry {
+	var entries []snapshotTreeEntry
+	var path []string // path[d] is the last snapshot seen at depth d
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Trim(line, " |\t\r") == "" {
+			continue
+		}
+		depth := 0
+		name := strings.TrimSpace(line)
+		if idx := strings.Index(line, "+- "); idx >= 0 {
+			depth = (idx-2)/4 + 1
+			name = strings.TrimSpace(line[idx+3:])
+		}
+		if depth > len(path) {
+			// Malformed indentation; attach to the deepest known snapshot.
+			depth = len(path)
+		}
+		entry := snapshotTreeEntry{Name: name}
+		if depth > 0 {
+			entry.Parent = path[depth-1]
+		}
+		path = append(path[:depth], name)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// snapshotDeleteOrder reverses tree order, which puts every snapshot after
+// all of its descendants.
+func snapshotDeleteOrder(entries []snapshotTreeEntry) []string {
+	order := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		order = append(order, entries[i].Name)
+	}
+	return order
+}
+
+// deleteAllSnapshots removes every snapshot of a domain, leaves first.
+// `virsh undefine` refuses a domain that still has snapshot metadata, and
+// snapshots taken outside virtrigaud would otherwise keep their data in the
+// disk images. A snapshot libvirt cannot delete (older libvirt cannot merge
+// external snapshots) has only its metadata dropped; its overlay file goes
+// with the domain's disks.
+func (v *VirshProvider) deleteAllSnapshots(ctx context.Context, domainName string) error {
+	result, err := v.runVirshCommand(ctx, "snapshot-list", domainName, "--tree")
+	if err != nil {
+		if strings.Contains(err.Error(), "no domain snapshot") {
+			return nil
+		}
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, name := range snapshotDeleteOrder(parseSnapshotTree(result.Stdout)) {
+		log.Printf("INFO Deleting snapshot %s of %s before undefine", name, domainName)
+		if _, err := v.runVirshCommand(ctx, "snapshot-delete", domainName, name); err != nil {
+			log.Printf("WARN Failed to delete snapshot %s of %s, dropping its metadata: %v", name, domainName, err)
+			if _, err := v.runVirshCommand(ctx, "snapshot-delete", domainName, name, "--metadata"); err != nil {
+				return fmt.Errorf("failed to delete snapshot %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// domainSnapshotXML is the subset of `virsh snapshot-dumpxml` output that
+// SnapshotList reports.
+type domainSnapshotXML struct {
+	Name         string `xml:"name"`
+	Description  string `xml:"description"`
+	State        string `xml:"state"`
+	CreationTime int64  `xml:"creationTime"`
+	Parent       struct {
+		Name string `xml:"name"`
+	} `xml:"parent"`
+	Memory struct {
+		Snapshot string `xml:"snapshot,attr"`
+		File     string `xml:"file,attr"`
+	} `xml:"memory"`
+	Disks []struct {
+		Name     string `xml:"name,attr"`
+		Snapshot string `xml:"snapshot,attr"`
+		Source   struct {
+			File string `xml:"file,attr"`
+		} `xml:"source"`
+	} `xml:"disks>disk"`
+}
+
+// hasMemory reports whether the snapshot captured RAM. Older libvirt omits
+// the <memory> element; there, only a snapshot of a running or paused
+// domain that is not disk-only carries memory state.
+func (s *domainSnapshotXML) hasMemory() bool {
+	if s.Memory.Snapshot != "" {
+		return s.Memory.Snapshot != "no"
+	}
+	return s.State == "running" || s.State == "paused"
+}
+
+// parseSnapshotXML decodes `virsh snapshot-dumpxml` output.
+func parseSnapshotXML(data string) (*domainSnapshotXML, error) {
+	var snap domainSnapshotXML
+	if err := xml.Unmarshal([]byte(data), &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot XML: %w", err)
+	}
+	return &snap, nil
+}
+
+// qemuImgInfo is the subset of `qemu-img info --output=json` used to size
+// snapshots.
+type qemuImgInfo struct {
+	VirtualSize int64             `json:"virtual-size"`
+	ActualSize  int64             `json:"actual-size"`
+	Snapshots   []qemuImgSnapshot `json:"snapshots"`
+}
+
+// qemuImgSnapshot is an internal snapshot listed by qemu-img info.
+type qemuImgSnapshot struct {
+	Name        string `json:"name"`
+	VMStateSize int64  `json:"vm-state-size"`
+}
+
+// snapshotSize returns the storage a snapshot occupies, or nils when it
+// cannot be told. activeDisks maps disk targets to their current image and
+// imgInfo returns the qemu-img info of a path, nil if unreadable.
+//
+// An external snapshot owns the overlays it created and its memory file, so
+// its size and consumption are their allocated bytes. An internal snapshot
+// shares clusters with the image it lives in; only its RAM state is its own,
+// and qemu-img reports that as vm-state-size.
+func snapshotSize(snap *domainSnapshotXML, activeDisks map[string]string, imgInfo func(path string) *qemuImgInfo) (size, consumed *int64) {
+	var external, internal int64
+	hasExternal, complete := false, true
+	addExternal := func(path string) {
+		hasExternal = true
+		if info := imgInfo(path); path != "" && info != nil {
+			external += info.ActualSize
+		} else {
+			complete = false
+		}
+	}
+	for _, disk := range snap.Disks {
+		switch disk.Snapshot {
+		case "external":
+			addExternal(disk.Source.File)
+		case "internal":
+			path, ok := activeDisks[disk.Name]
+			if !ok {
+				continue
+			}
+			info := imgInfo(path)
+			if info == nil {
+				continue
+			}
+			for _, s := range info.Snapshots {
+				if s.Name == snap.Name && s.VMStateSize > internal {
+					internal = s.VMStateSize
+				}
+			}
+		}
+	}
+	if snap.Memory.Snapshot == "external" {
+		addExternal(snap.Memory.File)
+	}
+
+	switch {
+	case hasExternal && complete && external > 0:
+		return &external, &external
+	case !hasExternal && internal > 0:
+		return &internal, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parseDomblklistDisks maps the targets of a domain's disks (not CD-ROMs)
+// to their source images from `virsh domblklist --details` output:
+//
+//	 Type   Device   Target   Source
+//	-----------------------------------------------------------
+//	 file   disk     vda      /var/lib/libvirt/images/vm.qcow2
+//	 file   cdrom    sda      /var/lib/libvirt/images/vm-cidata.iso
+func parseDomblklistDisks(out string) map[string]string {
+	disks := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[1] != "disk" || fields[3] == "-" {
+			continue
+		}
+		disks[fields[2]] = strings.Join(fields[3:], " ")
+	}
+	return disks
+}
+
+// snapshotSizer sizes the snapshots of one domain, running qemu-img info
+// at most once per image and listing the domain's disks only if an
+// internal snapshot needs them.
+type snapshotSizer struct {
+	v           *VirshProvider
+	domainName  string
+	infos       map[string]*qemuImgInfo
+	activeDisks map[string]string
+}
+
+func (s *snapshotSizer) size(ctx context.Context, snap *domainSnapshotXML) (size, consumed *int64) {
+	if s.activeDisks == nil {
+		s.activeDisks = map[string]string{}
+		for _, disk := range snap.Disks {
+			if disk.Snapshot != "internal" {
+				continue
+			}
+			result, err := s.v.runVirshCommand(ctx, "domblklist", s.domainName, "--details")
+			if err != nil {
+				log.Printf("WARN Failed to list disks of %s for snapshot sizes: %v", s.domainName, err)
+			} else {
+				s.activeDisks = parseDomblklistDisks(result.Stdout)
+			}
+			break
+		}
+	}
+	return snapshotSize(snap, s.activeDisks, func(path string) *qemuImgInfo {
+		if info, ok := s.infos[path]; ok {
+			return info
+		}
+		var info *qemuImgInfo
+		result, err := s.v.runVirshCommand(ctx, "!", "qemu-img", "info", "-U", "--output=json", path)
+		if err == nil {
+			info = &qemuImgInfo{}
+			if err = json.Unmarshal([]byte(result.Stdout), info); err != nil {
+				info = nil
+			}
+		}
+		if err != nil {
+			log.Printf("WARN qemu-img info failed for %s: %v", path, err)
+		}
+		s.infos[path] = info
+		return info
+	})
+}
+
+// snapshotStats counts the snapshots of a domain, following the chain from
+// the current snapshot, the one the running state was taken after.
+func (v *VirshProvider) snapshotStats(ctx context.Context, domainName string) (*describe.SnapshotStats, error) {
+	parents := map[string]string{}
+	result, err := v.runVirshCommand(ctx, "snapshot-list", domainName, "--tree")
+	if err != nil {
+		if strings.Contains(err.Error(), "no domain snapshot") {
+			return describe.NewSnapshotStats(parents, ""), nil
+		}
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, entry := range parseSnapshotTree(result.Stdout) {
+		parents[entry.Name] = entry.Parent
+	}
+	current := ""
+	if len(parents) > 0 {
+		// Fails when there is no current snapshot, e.g. after it was
+		// deleted; the chain is then empty.
+		if out, err := v.runVirshCommand(ctx, "snapshot-current", domainName, "--name"); err == nil {
+			current = strings.TrimSpace(out.Stdout)
+		}
+	}
+	return describe.NewSnapshotStats(parents, current), nil
+}
+
+// listSnapshotInfo returns the snapshots of a domain. The tree gives names
+// and parentage in one call; details come from each snapshot's XML, and a
+// snapshot whose XML cannot be read is still reported with what the tree
+// provides.
+func (v *VirshProvider) listSnapshotInfo(ctx context.Context, domainName string) ([]*providerv1.SnapshotInfo, error) {
+	result, err := v.runVirshCommand(ctx, "snapshot-list", domainName, "--tree")
+	if err != nil {
+		if strings.Contains(err.Error(), "no domain snapshot") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var snapshots []*providerv1.SnapshotInfo
+	sizer := &snapshotSizer{v: v, domainName: domainName, infos: map[string]*qemuImgInfo{}}
+	for _, entry := range parseSnapshotTree(result.Stdout) {
+		info := &providerv1.SnapshotInfo{
+			Id:       entry.Name,
+			Name:     entry.Name,
+			ParentId: entry.Parent,
+		}
+		snapshots = append(snapshots, info)
+
+		dump, err := v.runVirshCommand(ctx, "snapshot-dumpxml", domainName, entry.Name)
+		if err != nil {
+			log.Printf("WARN Failed to dump snapshot %s of %s: %v", entry.Name, domainName, err)
+			continue
+		}
+		snap, err := parseSnapshotXML(dump.Stdout)
+		if err != nil {
+			log.Printf("WARN Snapshot %s of %s: %v", entry.Name, domainName, err)
+			continue
+		}
+		info.Description = snap.Description
+		info.CreatedUnix = snap.CreationTime
+		info.HasMemory = snap.hasMemory()
+		info.SizeBytes, info.ConsumedBytes = sizer.size(ctx, snap)
+	}
+	return snapshots, nil
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 )
@@ -75,3 +77,219 @@ func TestServer_GetCapabilities_MemorySnapshots(t *testing.T) {
 	assert.True(t, caps.SupportsMemorySnapshots,
 		"libvirt advertises memory snapshots now that SnapshotCreate honors IncludeMemory for running VMs (#202)")
 }
+
+// TestParseSnapshotTree covers a branching snapshot tree plus a second root.
+func TestParseSnapshotTree(t *testing.T) {
+	out := `base
+  |
+  +- upgrade
+  |   |
+  |   +- upgrade-2
+  |
+  +- hotfix
+
+standalone
+`
+	assert.Equal(t, []snapshotTreeEntry{
+		{Name: "base"},
+		{Name: "upgrade", Parent: "base"},
+		{Name: "upgrade-2", Parent: "upgrade"},
+		{Name: "hotfix", Parent: "base"},
+		{Name: "standalone"},
+	}, parseSnapshotTree(out))
+
+	assert.Empty(t, parseSnapshotTree("\n"))
+
+	assert.Equal(t, []string{"standalone", "hotfix", "upgrade-2", "upgrade", "base"},
+		snapshotDeleteOrder(parseSnapshotTree(out)))
+}
+
+func TestParseSnapshotXML(t *testing.T) {
+	snap, err := parseSnapshotXML(`<domainsnapshot>
+  <name>upgrade</name>
+  <description>before dist-upgrade</description>
+  <state>running</state>
+  <parent>
+    <name>base</name>
+  </parent>
+  <creationTime>1700000000</creationTime>
+  <memory snapshot='internal'/>
+  <domain type='kvm'><name>vm-1</name></domain>
+</domainsnapshot>`)
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", snap.Name)
+	assert.Equal(t, "before dist-upgrade", snap.Description)
+	assert.Equal(t, int64(1700000000), snap.CreationTime)
+	assert.Equal(t, "base", snap.Parent.Name)
+	assert.True(t, snap.hasMemory())
+
+	diskOnly, err := parseSnapshotXML(`<domainsnapshot><name>d</name><state>disk-snapshot</state><memory snapshot='no'/></domainsnapshot>`)
+	require.NoError(t, err)
+	assert.False(t, diskOnly.hasMemory())
+
+	// Without a <memory> element the state decides.
+	legacy, err := parseSnapshotXML(`<domainsnapshot><name>l</name><state>running</state></domainsnapshot>`)
+	require.NoError(t, err)
+	assert.True(t, legacy.hasMemory())
+
+	_, err = parseSnapshotXML("not xml")
+	assert.Error(t, err)
+}
+
+func TestSnapshotSize(t *testing.T) {
+	images := map[string]*qemuImgInfo{
+		"/images/vm.qcow2":   {ActualSize: 10 << 30, Snapshots: []qemuImgSnapshot{{Name: "internal-ram", VMStateSize: 2 << 30}, {Name: "internal-disk"}}},
+		"/images/vm.ext":     {ActualSize: 300 << 20},
+		"/images/vm-b.ext":   {ActualSize: 100 << 20},
+		"/images/vm.mem.ext": {ActualSize: 1 << 30},
+	}
+	imgInfo := func(path string) *qemuImgInfo { return images[path] }
+	active := map[string]string{"vda": "/images/vm.qcow2"}
+
+	parse := func(xml string) *domainSnapshotXML {
+		snap, err := parseSnapshotXML(xml)
+		require.NoError(t, err)
+		return snap
+	}
+
+	// External: overlays plus the memory file, all owned by the snapshot.
+	size, consumed := snapshotSize(parse(`<domainsnapshot><name>ext</name>
+  <memory snapshot='external' file='/images/vm.mem.ext'/>
+  <disks>
+    <disk name='vda' snapshot='external' type='file'><source file='/images/vm.ext'/></disk>
+    <disk name='vdb' snapshot='external' type='file'><source file='/images/vm-b.ext'/></disk>
+    <disk name='sda' snapshot='no'/>
+  </disks>
+</domainsnapshot>`), active, imgInfo)
+	require.NotNil(t, size)
+	require.NotNil(t, consumed)
+	assert.Equal(t, int64(1<<30+400<<20), *size)
+	assert.Equal(t, *size, *consumed)
+
+	// Internal with RAM: the vm-state-size of the active image.
+	size, consumed = snapshotSize(parse(`<domainsnapshot><name>internal-ram</name>
+  <memory snapshot='internal'/>
+  <disks><disk name='vda' snapshot='internal'/></disks>
+</domainsnapshot>`), active, imgInfo)
+	require.NotNil(t, size)
+	assert.Equal(t, int64(2<<30), *size)
+	assert.Nil(t, consumed, "internal snapshots share clusters with the image")
+
+	// Unknown rather than zero.
+	size, consumed = snapshotSize(parse(`<domainsnapshot><name>internal-disk</name>
+  <disks><disk name='vda' snapshot='internal'/></disks>
+</domainsnapshot>`), active, imgInfo)
+	assert.Nil(t, size)
+	assert.Nil(t, consumed)
+
+	size, consumed = snapshotSize(parse(`<domainsnapshot><name>gone</name>
+  <disks><disk name='vda' snapshot='external'><source file='/images/missing.ext'/></disk></disks>
+</domainsnapshot>`), active, imgInfo)
+	assert.Nil(t, size, "a partial sum is not reported")
+	assert.Nil(t, consumed)
+}
+
+func TestParseDomblklistDisks(t *testing.T) {
+	out := ` Type   Device   Target   Source
+-----------------------------------------------------------
+ file   disk     vda      /var/lib/libvirt/images/vm.qcow2
+ file   disk     vdb      /var/lib/libvirt/images/data disk.qcow2
+ file   cdrom    sda      /var/lib/libvirt/images/vm-cidata.iso
+ file   cdrom    sdb      -
+`
+	assert.Equal(t, map[string]string{
+		"vda": "/var/lib/libvirt/images/vm.qcow2",
+		"vdb": "/var/lib/libvirt/images/data disk.qcow2",
+	}, parseDomblklistDisks(out))
+}
+
+// fakeFreezer records the guest agent calls made around a snapshot.
+type fakeFreezer struct {
+	responsive bool
+	freezeErr  error
+	calls      []string
+}
+
+func (f *fakeFreezer) agentResponsive(context.Context) bool {
+	f.calls = append(f.calls, "ping")
+	return f.responsive
+}
+
+func (f *fakeFreezer) freeze(context.Context) error {
+	f.calls = append(f.calls, "freeze")
+	return f.freezeErr
+}
+
+func (f *fakeFreezer) thaw(context.Context) error {
+	f.calls = append(f.calls, "thaw")
+	return nil
+}
+
+func TestSnapshotQuiesced(t *testing.T) {
+	quiesce := &providerv1.SnapshotCreateRequest{VmId: "vm-1", Quiesce: true}
+	take := func(f *fakeFreezer, err error) func() error {
+		return func() error {
+			f.calls = append(f.calls, "snapshot")
+			return err
+		}
+	}
+
+	t.Run("frozen around the snapshot", func(t *testing.T) {
+		f := &fakeFreezer{responsive: true}
+		outcome, _, err := snapshotQuiesced(context.Background(), f, quiesce, true, false, take(f, nil))
+		require.NoError(t, err)
+		assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED, outcome)
+		assert.Equal(t, []string{"ping", "freeze", "snapshot", "thaw"}, f.calls)
+	})
+
+	t.Run("thawed when the snapshot fails", func(t *testing.T) {
+		f := &fakeFreezer{responsive: true}
+		_, _, err := snapshotQuiesced(context.Background(), f, quiesce, true, false, take(f, assert.AnError))
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, []string{"ping", "freeze", "snapshot", "thaw"}, f.calls)
+	})
+
+	t.Run("unresponsive agent fails fast", func(t *testing.T) {
+		f := &fakeFreezer{}
+		_, _, err := snapshotQuiesced(context.Background(), f, quiesce, true, false, take(f, nil))
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, []string{"ping"}, f.calls, "no snapshot is taken")
+	})
+
+	t.Run("unresponsive agent degrades when allowed", func(t *testing.T) {
+		f := &fakeFreezer{}
+		req := &providerv1.SnapshotCreateRequest{VmId: "vm-1", Quiesce: true, AllowUnquiesced: true}
+		outcome, message, err := snapshotQuiesced(context.Background(), f, req, true, false, take(f, nil))
+		require.NoError(t, err)
+		assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, outcome)
+		assert.Contains(t, message, "guest agent does not respond")
+		assert.Equal(t, []string{"ping", "snapshot"}, f.calls)
+	})
+
+	t.Run("failed freeze is thawed before degrading", func(t *testing.T) {
+		f := &fakeFreezer{responsive: true, freezeErr: assert.AnError}
+		req := &providerv1.SnapshotCreateRequest{VmId: "vm-1", Quiesce: true, AllowUnquiesced: true}
+		outcome, _, err := snapshotQuiesced(context.Background(), f, req, true, false, take(f, nil))
+		require.NoError(t, err)
+		assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, outcome)
+		assert.Equal(t, []string{"ping", "freeze", "thaw", "snapshot"}, f.calls)
+	})
+
+	for name, tc := range map[string]struct {
+		req             *providerv1.SnapshotCreateRequest
+		running, memory bool
+	}{
+		"not requested":   {&providerv1.SnapshotCreateRequest{VmId: "vm-1"}, true, false},
+		"stopped domain":  {quiesce, false, false},
+		"memory snapshot": {quiesce, true, true},
+	} {
+		t.Run("skipped: "+name, func(t *testing.T) {
+			f := &fakeFreezer{}
+			outcome, _, err := snapshotQuiesced(context.Background(), f, tc.req, tc.running, tc.memory, take(f, nil))
+			require.NoError(t, err)
+			assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, outcome)
+			assert.Equal(t, []string{"snapshot"}, f.calls)
+		})
+	}
+}
@@ -51,6 +51,23 @@ const (
 	// logs.
 	EnvInsecureSkipHostKeyVerification = "LIBVIRT_INSECURE_SKIP_HOST_KEY_VERIFICATION"
 
+	// EnvTrustOnFirstUse opts into trust-on-first-use. Set to the literal
+	// "true" (case-insensitive, trimmed) and the provider records the key of
+	// any host it has no entry for instead of refusing to connect. A host
+	// whose key CHANGES is still rejected: unlike the insecure escape hatch,
+	// TOFU only widens the first connection, so it suits fleets where keys
+	// cannot be collected up front. Entries from the mounted known_hosts are
+	// honoured, and learned keys live in tofuKnownHostsFile, which does not
+	// survive a pod restart. Ignored when
+	// EnvInsecureSkipHostKeyVerification=true.
+	EnvTrustOnFirstUse = "LIBVIRT_SSH_TRUST_ON_FIRST_USE"
+
+	// EnvKnownHostsFile overrides the path of the operator-supplied
+	// known_hosts (default KnownHostsFile), for operators who mount the trust
+	// material from its own ConfigMap or Secret rather than the credentials
+	// Secret.
+	EnvKnownHostsFile = "LIBVIRT_SSH_KNOWN_HOSTS_FILE"
+
 	// KnownHostsFile is the in-pod path at which the verifying host-key
 	// material is read. It lives inside the existing credentials Secret mount
 	// (CredentialsPath, /etc/virtrigaud/credentials), so an operator who adds a
@@ -70,6 +87,13 @@ const (
 	strictYes       = "yes"
 	strictAcceptNew = "accept-new"
 
+	// sshServerAliveInterval and sshServerAliveCountMax make the ControlMaster
+	// notice a dead hypervisor connection within about 45s and exit, so the
+	// next command opens a fresh connection instead of hanging on the old
+	// socket.
+	sshServerAliveInterval = "15"
+	sshServerAliveCountMax = "3"
+
 	// EnvDisableSSHMultiplexing is the escape hatch that turns OFF SSH
 	// connection multiplexing (ControlMaster). Multiplexing is ON by default
 	// (#194) so a burst of `virsh`/`scp` invocations reuses a single SSH
@@ -116,9 +140,17 @@ func sshMultiplexOptions() []string {
 		"-o", "ControlMaster=auto",
 		"-o", "ControlPath=" + sshControlPath,
 		"-o", "ControlPersist=" + sshControlPersist,
+		"-o", "ServerAliveInterval=" + sshServerAliveInterval,
+		"-o", "ServerAliveCountMax=" + sshServerAliveCountMax,
 	}
 }
 
+// tofuKnownHostsFile is the writable known_hosts used in trust-on-first-use
+// mode. It is seeded from the mounted file and then grows with every host
+// seen for the first time. It is a package var (not a const) only so tests
+// can point it at a temp dir.
+var tofuKnownHostsFile = "/tmp/virtrigaud-known_hosts"
+
 // hostKeyPolicy captures the resolved SSH host-key verification posture for a
 // single provider process. It is computed once (resolveHostKeyPolicy) and then
 // consulted by every SSH/scp call site so the decision is taken in exactly one
@@ -127,6 +159,14 @@ type hostKeyPolicy struct {
 	// insecure is true when EnvInsecureSkipHostKeyVerification=true; host-key
 	// verification is disabled and a WARN is logged on every connection.
 	insecure bool
+
+	// tofu is true when EnvTrustOnFirstUse=true (and insecure is not): keys
+	// of unknown hosts are learned, changed keys are still rejected.
+	tofu bool
+
+	// mounted is the operator-supplied known_hosts path from
+	// EnvKnownHostsFile; empty means KnownHostsFile.
+	mounted string
 }
 
 // resolveHostKeyPolicy reads the escape-hatch env var once and returns the
@@ -137,28 +177,44 @@ type hostKeyPolicy struct {
 // word "true" (case-insensitive, trimmed) rather than any truthy value, so the
 // operator's opt-out is deliberate and auditable.
 func resolveHostKeyPolicy() hostKeyPolicy {
+	insecure := strings.EqualFold(strings.TrimSpace(os.Getenv(EnvInsecureSkipHostKeyVerification)), "true")
 	return hostKeyPolicy{
-		insecure: strings.EqualFold(strings.TrimSpace(os.Getenv(EnvInsecureSkipHostKeyVerification)), "true"),
+		insecure: insecure,
+		tofu:     !insecure && strings.EqualFold(strings.TrimSpace(os.Getenv(EnvTrustOnFirstUse)), "true"),
+		mounted:  strings.TrimSpace(os.Getenv(EnvKnownHostsFile)),
 	}
 }
 
+// mountedKnownHostsFile returns the path of the operator-supplied
+// known_hosts: EnvKnownHostsFile when set, else KnownHostsFile.
+func (p hostKeyPolicy) mountedKnownHostsFile() string {
+	if p.mounted != "" {
+		return p.mounted
+	}
+	return KnownHostsFile
+}
+
 // strictHostKeyChecking returns the StrictHostKeyChecking value for the policy:
-// "yes" when verifying, "accept-new" (legacy TOFU) on the insecure path.
+// "yes" when verifying, "accept-new" in TOFU mode and on the insecure path.
+// accept-new still refuses a host whose recorded key has changed.
 func (p hostKeyPolicy) strictHostKeyChecking() string {
-	if p.insecure {
+	if p.insecure || p.tofu {
 		return strictAcceptNew
 	}
 	return strictYes
 }
 
 // knownHostsFile returns the UserKnownHostsFile path for the policy: the
-// credentials-mounted, operator-supplied file when verifying; the ephemeral
-// /tmp file on the insecure path.
+// operator-supplied mounted file when verifying; the writable, seeded copy
+// in TOFU mode; the ephemeral /tmp file on the insecure path.
 func (p hostKeyPolicy) knownHostsFile() string {
-	if p.insecure {
+	switch {
+	case p.insecure:
 		return insecureKnownHostsFile
+	case p.tofu:
+		return tofuKnownHostsFile
 	}
-	return KnownHostsFile
+	return p.mountedKnownHostsFile()
 }
 
 // sshHostKeyOptions returns the `ssh`/`scp` `-o key=value` flag pairs that
@@ -189,7 +245,9 @@ func (p hostKeyPolicy) sshConfigStanza() string {
 		stanza += fmt.Sprintf(`    ControlMaster auto
     ControlPath %s
     ControlPersist %s
-`, sshControlPath, sshControlPersist)
+    ServerAliveInterval %s
+    ServerAliveCountMax %s
+`, sshControlPath, sshControlPersist, sshServerAliveInterval, sshServerAliveCountMax)
 	}
 	return stanza
 }
@@ -212,14 +270,16 @@ func (p hostKeyPolicy) applyURIHostKeyOptions(query interface {
 
 // verifyKnownHostsPresent is the loud, actionable hard-fail gate. When
 // verification is ON it requires that a non-empty known_hosts file is present at
-// KnownHostsFile; otherwise it returns an error that names (a) the host, (b) the
-// expected file path, (c) the ssh-keyscan recipe to populate it, and (d) the
-// escape-hatch env var as the explicit opt-out. On the insecure path it is a
-// no-op (the WARN log carries the audit signal instead).
+// the mounted path (KnownHostsFile unless EnvKnownHostsFile overrides it);
+// otherwise it returns an error that names (a) the host, (b) the expected file
+// path, (c) the ssh-keyscan recipe to populate it, and (d) the opt-in env vars.
+// On the insecure path it is a no-op (the WARN log carries the audit signal
+// instead); in TOFU mode it seeds the writable known_hosts.
 //
-// We deliberately reject trust-on-first-use: TOFU accepts whatever key the host
+// Trust-on-first-use is rejected by default: TOFU accepts whatever key the host
 // presents on the first connection, which is exactly the MITM window #149 is
-// about. The hard fail forces the operator to seed an out-of-band trust anchor.
+// about. The hard fail forces the operator to seed an out-of-band trust anchor
+// unless they explicitly opt into EnvTrustOnFirstUse.
 //
 // Interaction with I1 (ADR-0004): post-#149, an operator hitting the I1 libvirt
 // connectivity failure with a stale/missing known_hosts will see a clean
@@ -232,11 +292,15 @@ func (p hostKeyPolicy) verifyKnownHostsPresent(host string) error {
 	if p.insecure {
 		return nil
 	}
+	if p.tofu {
+		return p.seedTOFUKnownHosts()
+	}
 
 	// A present, non-empty file is the only acceptable state. A missing file,
 	// or a file that exists but is empty (no trust material), both fall through
 	// to the actionable hard-fail error below.
-	if info, err := os.Stat(filepath.Clean(KnownHostsFile)); err == nil && info.Size() > 0 {
+	known := p.mountedKnownHostsFile()
+	if info, err := os.Stat(filepath.Clean(known)); err == nil && info.Size() > 0 {
 		return nil
 	}
 
@@ -245,11 +309,30 @@ func (p hostKeyPolicy) verifyKnownHostsPresent(host string) error {
 			"was found at %s for host %q. Seed it from a trusted bastion with "+
 			"`ssh-keyscan -H %s >> known_hosts` and add it as the `known_hosts` key in the "+
 			"credentials Secret referenced by the Provider's credentialSecretRef, OR set "+
+			"%s=true to record host keys on first use, OR set "+
 			"%s=true to connect without verification (audit-flagged, NOT recommended for production)",
-		KnownHostsFile, host, host, EnvInsecureSkipHostKeyVerification,
+		known, host, host, EnvTrustOnFirstUse, EnvInsecureSkipHostKeyVerification,
 	)
 }
 
+// seedTOFUKnownHosts creates the writable TOFU known_hosts from the mounted
+// file, so keys the operator did supply are enforced from the first
+// connection. An existing TOFU file is left alone: it already holds the
+// seed plus every key learned since the pod started.
+func (p hostKeyPolicy) seedTOFUKnownHosts() error {
+	if _, err := os.Stat(tofuKnownHostsFile); err == nil {
+		return nil
+	}
+	seed, err := os.ReadFile(filepath.Clean(p.mountedKnownHostsFile()))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read known_hosts seed %s: %w", p.mountedKnownHostsFile(), err)
+	}
+	if err := os.WriteFile(tofuKnownHostsFile, seed, 0600); err != nil {
+		return fmt.Errorf("create trust-on-first-use known_hosts %s: %w", tofuKnownHostsFile, err)
+	}
+	return nil
+}
+
 // logVerificationMode emits exactly one startup/connect audit line for the
 // policy. Banking auditors grep for this line, the same way they grep
 // ADR-0003's TLSConfigured. On the insecure path it is a loud WARN that names
@@ -272,9 +355,21 @@ func (p hostKeyPolicy) logVerificationMode(logger *slog.Logger, host string) {
 		)
 		return
 	}
+	if p.tofu {
+		logger.Warn("libvirt SSH host-key verification: trust-on-first-use. "+
+			"Keys of hosts missing from known_hosts are accepted and recorded on first connection; "+
+			"changed keys are rejected. Learned keys are lost on pod restart.",
+			"provider", "libvirt",
+			"host", host,
+			"known_hosts", tofuKnownHostsFile,
+			"seed", p.mountedKnownHostsFile(),
+			"env_var", EnvTrustOnFirstUse,
+		)
+		return
+	}
 	logger.Info("libvirt SSH host-key verification: enabled",
 		"provider", "libvirt",
 		"host", host,
-		"known_hosts", KnownHostsFile,
+		"known_hosts", p.mountedKnownHostsFile(),
 	)
 }
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"log/slog"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
 
@@ -250,3 +251,101 @@ func TestSetupConnection_VerifyingLocalURI_NoSSH(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotContains(t, v.uri, "no_verify")
 }
+
+// TestResolveHostKeyPolicy_TOFU verifies trust-on-first-use is opt-in via the
+// literal "true" and never combines with the insecure escape hatch.
+func TestResolveHostKeyPolicy_TOFU(t *testing.T) {
+	t.Setenv(EnvInsecureSkipHostKeyVerification, "")
+	t.Setenv(EnvTrustOnFirstUse, "")
+	assert.False(t, resolveHostKeyPolicy().tofu)
+
+	t.Setenv(EnvTrustOnFirstUse, " TRUE ")
+	assert.True(t, resolveHostKeyPolicy().tofu)
+
+	t.Setenv(EnvTrustOnFirstUse, "1")
+	assert.False(t, resolveHostKeyPolicy().tofu)
+
+	t.Setenv(EnvTrustOnFirstUse, "true")
+	t.Setenv(EnvInsecureSkipHostKeyVerification, "true")
+	policy := resolveHostKeyPolicy()
+	assert.True(t, policy.insecure)
+	assert.False(t, policy.tofu, "the insecure escape hatch takes precedence")
+}
+
+// TestHostKeyPolicy_TOFU covers the options, URI handling and seeding of the
+// trust-on-first-use mode.
+func TestHostKeyPolicy_TOFU(t *testing.T) {
+	dir := t.TempDir()
+	orig := tofuKnownHostsFile
+	tofuKnownHostsFile = dir + "/tofu_known_hosts"
+	t.Cleanup(func() { tofuKnownHostsFile = orig })
+
+	mounted := dir + "/known_hosts"
+	require.NoError(t, os.WriteFile(mounted, []byte("host1 ssh-ed25519 AAAA\n"), 0600))
+	policy := hostKeyPolicy{tofu: true, mounted: mounted}
+
+	opts := optsToString(policy.sshHostKeyOptions())
+	assert.Contains(t, opts, "StrictHostKeyChecking=accept-new")
+	assert.Contains(t, opts, "UserKnownHostsFile="+tofuKnownHostsFile)
+	assert.Contains(t, policy.sshConfigStanza(), "UserKnownHostsFile "+tofuKnownHostsFile)
+
+	q := url.Values{}
+	q.Set("no_verify", "1")
+	policy.applyURIHostKeyOptions(q)
+	assert.Empty(t, q.Get("no_verify"), "TOFU still verifies known hosts")
+
+	// The writable file is seeded from the mounted one...
+	require.NoError(t, policy.verifyKnownHostsPresent("host1"))
+	seeded, err := os.ReadFile(tofuKnownHostsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "host1 ssh-ed25519 AAAA\n", string(seeded))
+
+	// ...and keys learned afterwards are not overwritten by a later seed.
+	require.NoError(t, os.WriteFile(tofuKnownHostsFile, []byte("learned\n"), 0600))
+	require.NoError(t, policy.verifyKnownHostsPresent("host2"))
+	kept, err := os.ReadFile(tofuKnownHostsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "learned\n", string(kept))
+
+	var buf bytes.Buffer
+	policy.logVerificationMode(slog.New(slog.NewTextHandler(&buf, nil)), "host1")
+	assert.Contains(t, buf.String(), "trust-on-first-use")
+	assert.Contains(t, buf.String(), EnvTrustOnFirstUse)
+}
+
+// TestHostKeyPolicy_TOFU_NoMountedFile confirms TOFU starts from an empty
+// known_hosts when the operator mounted none.
+func TestHostKeyPolicy_TOFU_NoMountedFile(t *testing.T) {
+	dir := t.TempDir()
+	orig := tofuKnownHostsFile
+	tofuKnownHostsFile = dir + "/tofu_known_hosts"
+	t.Cleanup(func() { tofuKnownHostsFile = orig })
+
+	policy := hostKeyPolicy{tofu: true, mounted: dir + "/missing"}
+	require.NoError(t, policy.verifyKnownHostsPresent("host1"))
+	info, err := os.Stat(tofuKnownHostsFile)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
+// TestHostKeyPolicy_KnownHostsFileOverride verifies EnvKnownHostsFile moves
+// the verifying known_hosts, both in the emitted options and in the
+// pre-flight check.
+func TestHostKeyPolicy_KnownHostsFileOverride(t *testing.T) {
+	mounted := t.TempDir() + "/known_hosts"
+	t.Setenv(EnvInsecureSkipHostKeyVerification, "")
+	t.Setenv(EnvTrustOnFirstUse, "")
+	t.Setenv(EnvKnownHostsFile, mounted)
+	policy := resolveHostKeyPolicy()
+
+	assert.Contains(t, optsToString(policy.sshHostKeyOptions()), "UserKnownHostsFile="+mounted)
+	assert.Contains(t, policy.sshConfigStanza(), "UserKnownHostsFile "+mounted)
+
+	err := policy.verifyKnownHostsPresent("172.16.56.8")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), mounted)
+	assert.Contains(t, err.Error(), EnvTrustOnFirstUse)
+
+	require.NoError(t, os.WriteFile(mounted, []byte("172.16.56.8 ssh-ed25519 AAAA\n"), 0600))
+	assert.NoError(t, policy.verifyKnownHostsPresent("172.16.56.8"))
+}
@@ -54,7 +54,7 @@ func TestSSHMultiplexingEnabled_EnvParsing(t *testing.T) {
 	}
 }
 
-// TestSSHMultiplexOptions_Enabled verifies the ControlMaster option triplet is
+// TestSSHMultiplexOptions_Enabled verifies the ControlMaster and keepalive options are
 // emitted (as alternating -o k=v pairs) when multiplexing is on (#194).
 func TestSSHMultiplexOptions_Enabled(t *testing.T) {
 	t.Setenv(EnvDisableSSHMultiplexing, "")
@@ -63,8 +63,10 @@ func TestSSHMultiplexOptions_Enabled(t *testing.T) {
 	assert.Contains(t, joined, "ControlMaster=auto")
 	assert.Contains(t, joined, "ControlPath=/tmp/virtrigaud-ssh-%C")
 	assert.Contains(t, joined, "ControlPersist=60s")
+	assert.Contains(t, joined, "ServerAliveInterval=15")
+	assert.Contains(t, joined, "ServerAliveCountMax=3")
 	// Laid out as alternating -o pairs so it spreads into an argv builder.
-	assert.Equal(t, 6, len(opts), "expected three -o k=v pairs")
+	assert.Equal(t, 10, len(opts), "expected five -o k=v pairs")
 	for i := 0; i < len(opts); i += 2 {
 		assert.Equal(t, "-o", opts[i])
 	}
@@ -88,6 +90,7 @@ func TestSSHConfigStanza_Multiplexing(t *testing.T) {
 	assert.Contains(t, on, "ControlMaster auto")
 	assert.Contains(t, on, "ControlPath /tmp/virtrigaud-ssh-%C")
 	assert.Contains(t, on, "ControlPersist 60s")
+	assert.Contains(t, on, "ServerAliveInterval 15")
 
 	t.Setenv(EnvDisableSSHMultiplexing, "true")
 	off := p.sshConfigStanza()
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"net/url"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SSH session pool for the virsh-over-SSH transport.
+//
+// Each hypervisor host gets one multiplexed SSH connection (the ControlMaster
+// configured by sshMultiplexOptions) and one sshHostSessions, which bounds how
+// many sessions ride that connection at once. The bound is shared by every
+// VirshProvider talking to the same host, so a reconcile burst cannot open
+// more sessions than the host allows: sshd refuses sessions beyond its
+// MaxSessions (10 by default) on a single connection.
+
+// sshMasterExitTimeout bounds `ssh -O exit` when dropping a broken master.
+const sshMasterExitTimeout = 5 * time.Second
+
+// sshHostSessions bounds and counts the SSH sessions open against one host.
+type sshHostSessions struct {
+	host  string
+	slots chan struct{}
+
+	waiting    atomic.Int64
+	peak       atomic.Int64
+	commands   atomic.Uint64
+	failures   atomic.Uint64
+	reconnects atomic.Uint64
+}
+
+// sshHosts holds the session pool of every host this process has connected
+// to, keyed by URI host (host or host:port).
+var sshHosts = struct {
+	sync.Mutex
+	byHost map[string]*sshHostSessions
+}{byHost: map[string]*sshHostSessions{}}
+
+// sessionsForHost returns the shared session pool for host, creating it with
+// room for limit concurrent sessions on first use. A later caller asking for
+// a different limit gets the existing pool: the first provider to connect
+// sets the bound for the host.
+func sessionsForHost(host string, limit int) *sshHostSessions {
+	sshHosts.Lock()
+	defer sshHosts.Unlock()
+	if s, ok := sshHosts.byHost[host]; ok {
+		if cap(s.slots) != limit {
+			log.Printf("WARN SSH session limit for %s is already %d; ignoring requested limit %d", host, cap(s.slots), limit)
+		}
+		return s
+	}
+	s := &sshHostSessions{host: host, slots: make(chan struct{}, limit)}
+	sshHosts.byHost[host] = s
+	return s
+}
+
+// sshPoolStats is a snapshot of one host's session pool.
+type sshPoolStats struct {
+	InUse      int
+	Capacity   int
+	Waiting    int64
+	Peak       int64
+	Commands   uint64
+	Failures   uint64
+	Reconnects uint64
+}
+
+func (s *sshHostSessions) stats() sshPoolStats {
+	return sshPoolStats{
+		InUse:      len(s.slots),
+		Capacity:   cap(s.slots),
+		Waiting:    s.waiting.Load(),
+		Peak:       s.peak.Load(),
+		Commands:   s.commands.Load(),
+		Failures:   s.failures.Load(),
+		Reconnects: s.reconnects.Load(),
+	}
+}
+
+// notePeak records n sessions in use if it is the highest seen.
+func (s *sshHostSessions) notePeak(n int64) {
+	for {
+		cur := s.peak.Load()
+		if n <= cur || s.peak.CompareAndSwap(cur, n) {
+			return
+		}
+	}
+}
+
+// logAttrs renders the pool stats as slog attributes for the debug
+// command-latency line.
+func (st sshPoolStats) logAttrs() []any {
+	return []any{
+		"sessionsInUse", st.InUse,
+		"sessionLimit", st.Capacity,
+		"sessionsWaiting", st.Waiting,
+		"peakSessions", st.Peak,
+		"commands", st.Commands,
+		"failures", st.Failures,
+		"reconnects", st.Reconnects,
+	}
+}
+
+// slogger returns the provider's structured logger, defaulting to
+// slog.Default().
+func (v *VirshProvider) slogger() *slog.Logger {
+	if v.logger != nil {
+		return v.logger
+	}
+	return slog.Default()
+}
+
+// sshDestination returns the trailing ssh argv naming the remote end of
+// parsedURI: user@host, preceded by -p when the URI carries a port. ssh does
+// not accept host:port in the destination itself.
+func sshDestination(parsedURI *url.URL) []string {
+	dest := parsedURI.User.Username() + "@" + parsedURI.Hostname()
+	if port := parsedURI.Port(); port != "" {
+		return []string{"-p", port, dest}
+	}
+	return []string{dest}
+}
+
+// resetSSHMaster drops the host's ControlMaster connection after a transient
+// connection failure, so the retry dials a fresh connection rather than
+// queueing behind a master whose TCP connection has silently died. It is a
+// no-op without multiplexing or for local URIs. A missing master is not an
+// error; the reconnect itself happens lazily on the next command, paced by
+// the retry backoff in retryOnTransientSSH.
+func (v *VirshProvider) resetSSHMaster(ctx context.Context) {
+	if !sshMultiplexingEnabled() {
+		return
+	}
+	parsedURI, err := url.Parse(v.uri)
+	if err != nil || parsedURI.Host == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sshMasterExitTimeout)
+	defer cancel()
+	args := append([]string{"-o", "ControlPath=" + sshControlPath, "-O", "exit"}, sshDestination(parsedURI)...)
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Env = v.env
+	if out, err := cmd.CombinedOutput(); err != nil {
+		log.Printf("DEBUG No SSH control connection to drop for %s: %v (%s)", parsedURI.Host, err, out)
+	} else {
+		log.Printf("INFO Dropped SSH control connection to %s after a connection failure; the next command reconnects", parsedURI.Host)
+	}
+	if v.sessions != nil {
+		v.sessions.reconnects.Add(1)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The TestSSHD* tests drive the SSH transport against a real sshd. They run
+// only when both variables below are set; the libvirt-ssh CI job points them
+// at an openssh-server container. Locally:
+//
+//	docker run -d -p 2222:2222 -e USER_NAME=virtrigaud \
+//	  -e PUBLIC_KEY="$(cat key.pub)" lscr.io/linuxserver/openssh-server
+//	VIRTRIGAUD_TEST_SSHD_URI=qemu+ssh://virtrigaud@127.0.0.1:2222/system \
+//	VIRTRIGAUD_TEST_SSHD_KEY=$PWD/key go test ./internal/providers/libvirt -run TestSSHD
+const (
+	envTestSSHDURI = "VIRTRIGAUD_TEST_SSHD_URI"
+	envTestSSHDKey = "VIRTRIGAUD_TEST_SSHD_KEY"
+)
+
+func sshdTarget(t *testing.T) *url.URL {
+	t.Helper()
+	raw, key := os.Getenv(envTestSSHDURI), os.Getenv(envTestSSHDKey)
+	if raw == "" || key == "" {
+		t.Skipf("%s and %s not set; skipping sshd tests", envTestSSHDURI, envTestSSHDKey)
+	}
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	q := u.Query()
+	q.Set("keyfile", key)
+	u.RawQuery = q.Encode()
+	return u
+}
+
+// sshdProvider returns a provider for the test sshd with a session pool of
+// limit slots.
+func sshdProvider(t *testing.T, policy hostKeyPolicy, limit int) *VirshProvider {
+	u := sshdTarget(t)
+	s := &sshHostSessions{host: u.Host, slots: make(chan struct{}, limit)}
+	return &VirshProvider{
+		uri:         u.String(),
+		credentials: &Credentials{SSHPrivateKey: "mounted"},
+		hostKey:     policy,
+		env:         os.Environ(),
+		execSem:     s.slots,
+		sessions:    s,
+	}
+}
+
+// scanHostKeys writes the sshd's real host keys to a known_hosts file.
+func scanHostKeys(t *testing.T) string {
+	u := sshdTarget(t)
+	args := []string{"-T", "5"}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	out, err := exec.Command("ssh-keyscan", append(args, u.Hostname())...).Output()
+	require.NoError(t, err)
+	require.NotEmpty(t, out, "ssh-keyscan returned no keys")
+
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, os.WriteFile(path, out, 0600))
+	return path
+}
+
+// forgedHostKeys writes a known_hosts that pins the sshd's host name to a
+// freshly generated key, as a MITM would present.
+func forgedHostKeys(t *testing.T) string {
+	real, err := os.ReadFile(scanHostKeys(t))
+	require.NoError(t, err)
+	hostPattern := strings.Fields(string(real))[0]
+
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", filepath.Join(dir, "forged")).Run())
+	pub, err := os.ReadFile(filepath.Join(dir, "forged.pub"))
+	require.NoError(t, err)
+	fields := strings.Fields(string(pub))
+
+	path := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(path, []byte(hostPattern+" "+fields[0]+" "+fields[1]+"\n"), 0600))
+	return path
+}
+
+func withTOFUFile(t *testing.T) {
+	orig := tofuKnownHostsFile
+	tofuKnownHostsFile = filepath.Join(t.TempDir(), "tofu_known_hosts")
+	t.Cleanup(func() { tofuKnownHostsFile = orig })
+}
+
+func TestSSHDVerifyingAcceptsKnownKey(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "true")
+	v := sshdProvider(t, hostKeyPolicy{mounted: scanHostKeys(t)}, 1)
+
+	res, err := v.runVirshCommand(context.Background(), "!", "echo", "ok")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", strings.TrimSpace(res.Stdout))
+}
+
+func TestSSHDRejectsChangedHostKey(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "true")
+	withTOFUFile(t)
+	forged := forgedHostKeys(t)
+
+	for name, policy := range map[string]hostKeyPolicy{
+		"verifying": {mounted: forged},
+		"tofu":      {tofu: true, mounted: forged},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, policy.verifyKnownHostsPresent("sshd"))
+			v := sshdProvider(t, policy, 1)
+			res, err := v.runVirshCommand(context.Background(), "!", "echo", "ok")
+			require.Error(t, err)
+			assert.Contains(t, strings.ToLower(res.Stderr), "host key verification failed")
+		})
+	}
+}
+
+func TestSSHDTrustOnFirstUseLearnsKey(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "true")
+	withTOFUFile(t)
+	policy := hostKeyPolicy{tofu: true, mounted: filepath.Join(t.TempDir(), "missing")}
+	require.NoError(t, policy.verifyKnownHostsPresent("sshd"))
+
+	v := sshdProvider(t, policy, 1)
+	_, err := v.runVirshCommand(context.Background(), "!", "true")
+	require.NoError(t, err)
+
+	learned, err := os.ReadFile(tofuKnownHostsFile)
+	require.NoError(t, err)
+	assert.NotEmpty(t, learned, "the host key is recorded on first use")
+
+	// The recorded key is enforced from now on.
+	_, err = v.runVirshCommand(context.Background(), "!", "true")
+	require.NoError(t, err)
+}
+
+func TestSSHDSessionPoolBoundsConcurrency(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "")
+	v := sshdProvider(t, hostKeyPolicy{mounted: scanHostKeys(t)}, 2)
+	t.Cleanup(func() { v.resetSSHMaster(context.Background()) })
+
+	const commands = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, commands)
+	for i := 0; i < commands; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := v.runVirshCommand(context.Background(), "!", "sleep", "0.2")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	st := v.sessions.stats()
+	assert.Equal(t, int64(2), st.Peak, "no more sessions than the pool allows")
+	assert.Equal(t, uint64(commands), st.Commands)
+	assert.Zero(t, st.Failures)
+}
+
+func TestSSHDReconnectsAfterMasterLoss(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "")
+	v := sshdProvider(t, hostKeyPolicy{mounted: scanHostKeys(t)}, 2)
+	t.Cleanup(func() { v.resetSSHMaster(context.Background()) })
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := v.runVirshCommand(ctx, "!", "true")
+	require.NoError(t, err)
+
+	v.resetSSHMaster(ctx)
+	assert.Equal(t, uint64(1), v.sessions.stats().Reconnects)
+
+	res, err := v.runVirshCommand(ctx, "!", "echo", "again")
+	require.NoError(t, err)
+	assert.Equal(t, "again", strings.TrimSpace(res.Stdout))
+}
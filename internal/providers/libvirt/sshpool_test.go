@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionsForHostSharedPerHost(t *testing.T) {
+	a := sessionsForHost("pool-test-a.example:22", 3)
+	assert.Same(t, a, sessionsForHost("pool-test-a.example:22", 3))
+	// The first provider to connect sets the bound.
+	assert.Same(t, a, sessionsForHost("pool-test-a.example:22", 8))
+	assert.Equal(t, 3, cap(a.slots))
+
+	b := sessionsForHost("pool-test-b.example", 3)
+	assert.NotSame(t, a, b)
+}
+
+func TestSetupConnectionUsesHostSessionPool(t *testing.T) {
+	t.Setenv(EnvInsecureSkipHostKeyVerification, "true")
+
+	newProvider := func() *VirshProvider {
+		v := NewVirshProvider(&ProviderConfig{
+			Spec: ProviderSpec{Endpoint: "qemu+ssh://virtrigaud@pool-test-setup.example/system"},
+		})
+		v.credentials = &Credentials{Username: "virtrigaud"}
+		require.NoError(t, v.setupConnection())
+		return v
+	}
+	v1, v2 := newProvider(), newProvider()
+	require.NotNil(t, v1.sessions)
+	assert.Same(t, v1.sessions, v2.sessions)
+	assert.Equal(t, v1.execSem, v2.execSem, "providers for one host share its session slots")
+}
+
+func TestAcquireExecSlotRecordsPoolStats(t *testing.T) {
+	s := &sshHostSessions{host: "h", slots: make(chan struct{}, 2)}
+	v := &VirshProvider{execSem: s.slots, sessions: s}
+
+	rel1, err := v.acquireExecSlot(context.Background())
+	require.NoError(t, err)
+	rel2, err := v.acquireExecSlot(context.Background())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		r, err := v.acquireExecSlot(context.Background())
+		if err == nil {
+			r()
+		}
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return s.stats().Waiting == 1 }, time.Second, 5*time.Millisecond)
+
+	st := s.stats()
+	assert.Equal(t, 2, st.InUse)
+	assert.Equal(t, 2, st.Capacity)
+	assert.Equal(t, int64(2), st.Peak)
+
+	rel1()
+	<-done
+	rel2()
+	st = s.stats()
+	assert.Zero(t, st.InUse)
+	assert.Zero(t, st.Waiting)
+	assert.Equal(t, int64(2), st.Peak, "peak survives releases")
+}
+
+func TestSSHDestination(t *testing.T) {
+	cases := []struct {
+		uri  string
+		want []string
+	}{
+		{"qemu+ssh://root@10.0.0.1/system", []string{"root@10.0.0.1"}},
+		{"qemu+ssh://root@10.0.0.1:2222/system", []string{"-p", "2222", "root@10.0.0.1"}},
+		{"qemu+ssh://root@[fd00::1]:2222/system", []string{"-p", "2222", "root@fd00::1"}},
+	}
+	for _, tc := range cases {
+		u, err := url.Parse(tc.uri)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, sshDestination(u), tc.uri)
+	}
+}
+
+func TestResetSSHMasterNoopWithoutMultiplexing(t *testing.T) {
+	t.Setenv(EnvDisableSSHMultiplexing, "true")
+	s := &sshHostSessions{host: "h", slots: make(chan struct{}, 1)}
+	v := &VirshProvider{uri: "qemu+ssh://root@10.0.0.1/system", sessions: s}
+	v.resetSSHMaster(context.Background())
+	assert.Zero(t, s.stats().Reconnects)
+}
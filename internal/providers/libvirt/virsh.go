@@ -65,8 +65,13 @@ type VirshProvider struct {
 	// execSem bounds concurrent virsh/ssh subprocess forks (see
 	// defaultMaxConcurrentVirsh). nil means unbounded (zero-value provider, e.g.
 	// in tests). Set by NewVirshProvider; shared across all goroutines using
-	// this provider instance.
+	// this provider instance. For SSH URIs setupConnection swaps it for the
+	// slots of the host's shared session pool (sessions).
 	execSem chan struct{}
+
+	// sessions is the per-host SSH session pool (sshpool.go) shared with every
+	// provider talking to the same host; nil for local URIs.
+	sessions *sshHostSessions
 }
 
 // VirshDomain represents a VM domain from virsh list output
@@ -117,8 +122,15 @@ func (v *VirshProvider) acquireExecSlot(ctx context.Context) (release func(), er
 	if v.execSem == nil {
 		return func() {}, nil
 	}
+	if v.sessions != nil {
+		v.sessions.waiting.Add(1)
+		defer v.sessions.waiting.Add(-1)
+	}
 	select {
 	case v.execSem <- struct{}{}:
+		if v.sessions != nil {
+			v.sessions.notePeak(int64(len(v.execSem)))
+		}
 		return func() { <-v.execSem }, nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -255,6 +267,14 @@ func (v *VirshProvider) setupConnection() error {
 		}
 		parsedURI.RawQuery = query.Encode()
 
+		// Share one bounded session pool per host across every provider
+		// instance, so concurrent reconciles queue instead of opening more
+		// sessions than the host's sshd accepts.
+		if limit := cap(v.execSem); limit > 0 {
+			v.sessions = sessionsForHost(parsedURI.Host, limit)
+			v.execSem = v.sessions.slots
+		}
+
 		// Emit the one-line host-key verification-mode audit log (WARN on the
 		// escape hatch, INFO when verifying) and hard-fail if verification is on
 		// but no usable known_hosts is present (no TOFU).
@@ -440,6 +460,7 @@ func transientSSHConnectError(stderr string) bool {
 		"no route to host",
 		"ssh: connect to host",                 // generic ssh connect failure
 		"temporary failure in name resolution", // transient DNS
+		"session open refused by peer",         // sshd MaxSessions reached on the shared connection
 	} {
 		if strings.Contains(s, m) {
 			return true
@@ -458,7 +479,12 @@ func transientSSHConnectError(stderr string) bool {
 // command (not through virsh).
 func (v *VirshProvider) runVirshCommand(ctx context.Context, args ...string) (*VirshResult, error) {
 	return retryOnTransientSSH(ctx, func() (*VirshResult, error) {
-		return v.runVirshCommandOnce(ctx, args...)
+		result, err := v.runVirshCommandOnce(ctx, args...)
+		if err != nil && result != nil && v.sessions != nil && transientSSHConnectError(result.Stderr) {
+			// The shared master may be the broken part; make the retry dial anew.
+			v.resetSSHMaster(ctx)
+		}
+		return result, err
 	})
 }
 
@@ -503,6 +529,7 @@ func retryOnTransientSSH(ctx context.Context, attempt func() (*VirshResult, erro
 func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string) (*VirshResult, error) {
 	// Bound concurrent subprocess forks so a reconcile burst cannot exhaust the
 	// host fork limit (cannot fork child process). Held only across the fork.
+	queued := time.Now()
 	release, err := v.acquireExecSlot(ctx)
 	if err != nil {
 		return nil, err
@@ -510,6 +537,7 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 	defer release()
 
 	start := time.Now()
+	waited := start.Sub(queued)
 
 	var cmd *exec.Cmd
 	var command string
@@ -526,6 +554,7 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 			parsedURI, _ := url.Parse(v.uri)
 			host := parsedURI.Host
 			user := parsedURI.User.Username()
+			dest := sshDestination(parsedURI)
 
 			if v.credentials.Password != "" {
 				// For remote execution with password authentication, use SSH via sshpass.
@@ -540,7 +569,7 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 				// ControlMaster multiplexing reuses one connection (#194).
 				sshArgs = append(sshArgs, v.hostKey.sshHostKeyOptions()...)
 				sshArgs = append(sshArgs, sshMultiplexOptions()...)
-				sshArgs = append(sshArgs, fmt.Sprintf("%s@%s", user, host))
+				sshArgs = append(sshArgs, dest...)
 				sshArgs = append(sshArgs, directArgs...)
 
 				cmd = exec.CommandContext(ctx, "sshpass", sshArgs...)
@@ -551,7 +580,7 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 				sshArgs = append(sshArgs, "-o", "LogLevel=ERROR")
 				sshArgs = append(sshArgs, v.hostKey.sshHostKeyOptions()...)
 				sshArgs = append(sshArgs, sshMultiplexOptions()...)
-				sshArgs = append(sshArgs, fmt.Sprintf("%s@%s", user, host))
+				sshArgs = append(sshArgs, dest...)
 				sshArgs = append(sshArgs, directArgs...)
 
 				cmd = exec.CommandContext(ctx, "ssh", sshArgs...)
@@ -590,7 +619,8 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 			// ControlMaster multiplexing reuses one connection (#194).
 			sshArgs = append(sshArgs, v.hostKey.sshHostKeyOptions()...)
 			sshArgs = append(sshArgs, sshMultiplexOptions()...)
-			sshArgs = append(sshArgs, fmt.Sprintf("%s@%s", user, host), "virsh")
+			sshArgs = append(sshArgs, sshDestination(parsedURI)...)
+			sshArgs = append(sshArgs, "virsh")
 			// Pin the remote virsh to the connection URI's libvirtd (root → system,
 			// non-root → session) instead of letting it pick the ssh user's default.
 			remoteVirshArgs := args
@@ -628,6 +658,21 @@ func (v *VirshProvider) runVirshCommandOnce(ctx context.Context, args ...string)
 		Duration: duration,
 	}
 
+	if v.sessions != nil {
+		v.sessions.commands.Add(1)
+		if err != nil {
+			v.sessions.failures.Add(1)
+		}
+		attrs := append([]any{
+			"host", v.sessions.host,
+			"command", command,
+			"exitCode", result.ExitCode,
+			"duration", duration,
+			"slotWait", waited,
+		}, v.sessions.stats().logAttrs()...)
+		v.slogger().Debug("virsh command finished", attrs...)
+	}
+
 	if err != nil {
 		log.Printf("ERROR Command failed: %s (exit code: %d, duration: %v)",
 			command, result.ExitCode, duration)
@@ -773,11 +818,12 @@ func (v *VirshProvider) destroyDomain(ctx context.Context, domainName string) er
 	return nil
 }
 
-// undefineDomain removes a domain definition
+// undefineDomain removes a domain definition, along with the UEFI varstore
+// of a UEFI domain (libvirt refuses to undefine one without --nvram).
 func (v *VirshProvider) undefineDomain(ctx context.Context, domainName string) error {
 	log.Printf("INFO Undefining domain: %s", domainName)
 
-	_, err := v.runVirshCommand(ctx, "undefine", domainName)
+	_, err := v.runVirshCommand(ctx, "undefine", domainName, "--nvram")
 	if err != nil {
 		return fmt.Errorf("failed to undefine domain %s: %w", domainName, err)
 	}
@@ -786,33 +832,46 @@ func (v *VirshProvider) undefineDomain(ctx context.Context, domainName string) e
 	return nil
 }
 
-// getDomainInfo gets comprehensive information about a domain (enhanced monitoring)
+// getDomainInfo collects a domain's configuration and runtime state into the
+// flat map Describe returns as ProviderRaw. Configuration comes from
+// `virsh dumpxml`, runtime counters from `virsh domstats --raw` and guest data
+// from the QEMU guest agent; virsh translates none of these, so the result
+// does not depend on the host's locale.
 func (v *VirshProvider) getDomainInfo(ctx context.Context, domainName string) (map[string]string, error) {
-	result, err := v.runVirshCommand(ctx, "dominfo", domainName)
+	result, err := v.runVirshCommand(ctx, "dumpxml", domainName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get domain info for %s: %w", domainName, err)
 	}
-
-	info := make(map[string]string)
-	lines := strings.Split(result.Stdout, "\n")
-
-	for _, line := range lines {
-		if parts := strings.SplitN(line, ":", 2); len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			info[key] = value
-		}
+	dx, err := parseDomainXML(result.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain info for %s: %w", domainName, err)
 	}
-
-	// Enhance with comprehensive monitoring data (like vSphere provider)
-	if err := v.enrichDomainInfo(ctx, domainName, info); err != nil {
-		log.Printf("WARN Failed to get enhanced monitoring data for %s: %v", domainName, err)
-		// Continue with basic info if enhanced monitoring fails
+	stats, err := v.getDomainStats(ctx, domainName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain info for %s: %w", domainName, err)
 	}
 
+	info := domainInfoMap(dx, stats)
+	if stats.State() == "running" {
+		v.addGuestInfo(ctx, domainName, info)
+	}
+	if state := stats.State(); state == "running" || state == "paused" {
+		v.addJobInfo(ctx, domainName, info)
+	}
 	return info, nil
 }
 
+// getDomainStats runs `virsh domstats --raw` for one domain, restricted to
+// the given stat groups (e.g. "--state") or all of them when none are given.
+func (v *VirshProvider) getDomainStats(ctx context.Context, domainName string, groups ...string) (domainStats, error) {
+	args := append([]string{"domstats", "--raw"}, groups...)
+	result, err := v.runVirshCommand(ctx, append(args, domainName)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDomstats(result.Stdout)
+}
+
 // createSSHConfig writes an SSH configuration file for non-interactive
 // authentication that honours the centralized host-key verification policy
 // (#149/ADR-0004). It is consumed by libvirt's own qemu+ssh:// transport (the
@@ -865,289 +924,139 @@ func (v *VirshProvider) Cleanup() error {
 	return nil
 }
 
-// enrichDomainInfo adds comprehensive monitoring data similar to vSphere provider
-func (v *VirshProvider) enrichDomainInfo(ctx context.Context, domainName string, info map[string]string) error {
-	// Get memory statistics
-	if memStats, err := v.getDomainMemoryStats(ctx, domainName); err == nil {
-		for k, v := range memStats {
-			info[k] = v
-		}
-	}
-
-	// Get CPU statistics
-	if cpuStats, err := v.getDomainCPUStats(ctx, domainName); err == nil {
-		for k, v := range cpuStats {
-			info[k] = v
-		}
-	}
-
-	// Get network interfaces and IP addresses
-	if netInfo, err := v.getDomainNetworkInfo(ctx, domainName); err == nil {
-		for k, v := range netInfo {
-			info[k] = v
-		}
-	}
-
-	// Get block device statistics
-	if blockStats, err := v.getDomainBlockStats(ctx, domainName); err == nil {
-		for k, v := range blockStats {
-			info[k] = v
-		}
-	}
-
-	// Get guest agent information (if available)
-	if guestInfo, err := v.getDomainGuestInfo(ctx, domainName); err == nil {
-		for k, v := range guestInfo {
-			info[k] = v
-		}
-	}
-
-	return nil
-}
-
-// getDomainMemoryStats retrieves memory usage statistics
-func (v *VirshProvider) getDomainMemoryStats(ctx context.Context, domainName string) (map[string]string, error) {
-	result, err := v.runVirshCommand(ctx, "dommemstat", domainName)
-	if err != nil {
-		return nil, err
+// addGuestInfo adds the guest hostname and IP addresses to info. Both are
+// best effort: a guest without an agent or a DHCP lease simply lacks them.
+func (v *VirshProvider) addGuestInfo(ctx context.Context, domainName string, info map[string]string) {
+	if hostname, err := NewGuestAgentProvider(v).GetHostname(ctx, domainName); err == nil && hostname != "" {
+		info["guest_hostname"] = hostname
 	}
-
-	stats := make(map[string]string)
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for _, line := range lines {
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			key := fmt.Sprintf("memory_%s", parts[0])
-			stats[key] = parts[1]
-		}
+	if ips, err := v.getDomainIPAddresses(ctx, domainName); err == nil && ips != "" {
+		info["guest_ip_addresses"] = ips
 	}
-	return stats, nil
 }
 
-// getDomainCPUStats retrieves CPU usage statistics
-func (v *VirshProvider) getDomainCPUStats(ctx context.Context, domainName string) (map[string]string, error) {
-	result, err := v.runVirshCommand(ctx, "cpu-stats", domainName)
+// addJobInfo adds the job libvirt runs on the domain, such as a migration
+// or a backup, as job_type and job_operation (`virsh domjobinfo`). Like
+// addGuestInfo it is best effort: without them the domain counts as idle.
+func (v *VirshProvider) addJobInfo(ctx context.Context, domainName string, info map[string]string) {
+	result, err := v.runVirshCommand(ctx, "domjobinfo", domainName)
 	if err != nil {
-		return nil, err
-	}
-
-	stats := make(map[string]string)
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, ":") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := fmt.Sprintf("cpu_%s", strings.TrimSpace(parts[0]))
-				stats[key] = strings.TrimSpace(parts[1])
-			}
+		log.Printf("DEBUG Failed to get job info for %s: %v", domainName, err)
+		return
+	}
+	fields := parseDomjobinfo(result.Stdout)
+	if t := fields["Job type"]; t != "" && t != "None" {
+		info["job_type"] = t
+		if op := fields["Operation"]; op != "" {
+			info["job_operation"] = op
 		}
 	}
-	return stats, nil
 }
 
-// getDomainNetworkInfo retrieves network interface information and IP addresses
-func (v *VirshProvider) getDomainNetworkInfo(ctx context.Context, domainName string) (map[string]string, error) {
-	info := make(map[string]string)
-
-	// Get domain interface list
-	result, err := v.runVirshCommand(ctx, "domiflist", domainName)
-	if err != nil {
-		return nil, err
-	}
-
-	interfaces := []string{}
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for i, line := range lines {
-		if i == 0 || strings.HasPrefix(line, "-") {
-			continue // Skip header lines
-		}
-		parts := strings.Fields(line)
-		if len(parts) >= 1 {
-			interfaces = append(interfaces, parts[0])
-		}
-	}
-
-	info["network_interfaces"] = strings.Join(interfaces, ",")
-
-	// Try to get IP addresses via guest agent (if available)
-	if ipInfo, err := v.getDomainIPAddresses(ctx, domainName); err == nil {
-		info["guest_ip_addresses"] = ipInfo
-	}
-
-	return info, nil
-}
-
-// getDomainIPAddresses attempts to get IP addresses via multiple sources
+// getDomainIPAddresses returns the guest's addresses as a comma-separated
+// list. The guest agent's guest-network-get-interfaces reply is preferred
+// (most reliable, requires qemu-guest-agent in the guest); without it the
+// DHCP lease and ARP sources of `virsh domifaddr` are tried in turn.
 func (v *VirshProvider) getDomainIPAddresses(ctx context.Context, domainName string) (string, error) {
-	ips := []string{}
-
-	// Try multiple sources in order of preference:
-	// 1. Guest agent (most reliable, requires qemu-guest-agent installed)
-	// 2. DHCP lease (default, may be empty if network is bridged)
-	// 3. ARP table (fallback, may not work in all network configurations)
-
-	sources := []string{"agent", "lease", "arp"}
+	var ips []string
 
-	for _, source := range sources {
-		var result *VirshResult
-		var err error
+	guest := &GuestAgentInfo{}
+	if err := NewGuestAgentProvider(v).getGuestNetworkInfo(ctx, domainName, guest); err == nil {
+		ips = guestAgentAddresses(guest.NetworkInterfaces)
+	} else {
+		log.Printf("DEBUG Failed to get IPs from the guest agent for %s: %v", domainName, err)
+	}
 
-		if source == "lease" {
-			// Default source, no need to specify
-			result, err = v.runVirshCommand(ctx, "domifaddr", domainName)
-		} else {
-			result, err = v.runVirshCommand(ctx, "domifaddr", domainName, "--source", source)
+	for _, source := range []string{"lease", "arp"} {
+		if len(ips) > 0 {
+			break
 		}
-
+		result, err := v.runVirshCommand(ctx, "domifaddr", domainName, "--source", source)
 		if err != nil {
 			log.Printf("DEBUG Failed to get IPs from source '%s' for %s: %v", source, domainName, err)
 			continue
 		}
-
-		// Parse the output
-		lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-		for i, line := range lines {
-			if i == 0 || strings.HasPrefix(line, "-") || strings.TrimSpace(line) == "" {
-				continue // Skip header lines and empty lines
-			}
-			parts := strings.Fields(line)
-			if len(parts) >= 4 {
-				// Format: Name MAC address Protocol Address
-				interfaceName := parts[0]
-				ip := parts[3]
-
-				// Skip loopback interface and invalid entries
-				if interfaceName == "lo" || interfaceName == "-" || ip == "N/A" || ip == "-" {
-					continue
-				}
-
-				// Remove CIDR notation if present (must be done before IP filtering)
-				if strings.Contains(ip, "/") {
-					ip = strings.Split(ip, "/")[0]
-				}
-
-				// Filter out unwanted IPs:
-				// - Loopback addresses (127.0.0.1, ::1)
-				// - IPv6 link-local addresses (fe80::)
-				if ip == "127.0.0.1" || ip == "::1" || strings.HasPrefix(ip, "fe80:") {
-					continue
-				}
-
-				// Only include IPs from interfaces starting with 'e' (eth*, ens*, enp*, etc.)
-				// This excludes docker, virbr, and other virtual interfaces
-				if strings.HasPrefix(interfaceName, "e") {
-					ips = append(ips, ip)
-				}
-			}
-		}
-
-		// If we found IPs from this source, stop trying other sources
-		if len(ips) > 0 {
-			log.Printf("DEBUG Successfully retrieved %d IP(s) from source '%s' for %s", len(ips), source, domainName)
-			break
-		}
+		ips = parseDomifaddr(result.Stdout)
 	}
 
 	if len(ips) == 0 {
 		log.Printf("DEBUG No IP addresses found for domain %s from any source", domainName)
 		return "", nil
 	}
-
 	return strings.Join(ips, ","), nil
 }
 
-// getDomainBlockStats retrieves storage device statistics
-func (v *VirshProvider) getDomainBlockStats(ctx context.Context, domainName string) (map[string]string, error) {
-	info := make(map[string]string)
-
-	// Get block device list
-	result, err := v.runVirshCommand(ctx, "domblklist", domainName)
-	if err != nil {
-		return nil, err
-	}
-
-	devices := []string{}
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for i, line := range lines {
-		if i == 0 || strings.HasPrefix(line, "-") {
-			continue // Skip header lines
-		}
-		parts := strings.Fields(line)
-		if len(parts) >= 1 {
-			devices = append(devices, parts[0])
+// guestAgentAddresses returns the usable addresses of the guest's own NICs.
+// Only interfaces whose name starts with 'e' (eth*, ens*, enp*, ...) count,
+// which excludes docker, virbr and other virtual interfaces inside the guest.
+func guestAgentAddresses(interfaces []GuestNetworkInterface) []string {
+	var ips []string
+	for _, iface := range interfaces {
+		if !strings.HasPrefix(iface.Name, "e") {
+			continue
 		}
-	}
-
-	info["block_devices"] = strings.Join(devices, ",")
-
-	// Get stats for first device (if any)
-	if len(devices) > 0 {
-		if blockStats, err := v.getBlockDeviceStats(ctx, domainName, devices[0]); err == nil {
-			for k, v := range blockStats {
-				info[k] = v
+		for _, ip := range iface.IPAddresses {
+			if ip = usableGuestAddress(ip); ip != "" {
+				ips = append(ips, ip)
 			}
 		}
 	}
-
-	return info, nil
+	return ips
 }
 
-// getBlockDeviceStats retrieves statistics for a specific block device
-func (v *VirshProvider) getBlockDeviceStats(ctx context.Context, domainName, device string) (map[string]string, error) {
-	result, err := v.runVirshCommand(ctx, "domblkstat", domainName, device)
-	if err != nil {
-		return nil, err
-	}
-
-	stats := make(map[string]string)
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for _, line := range lines {
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			key := fmt.Sprintf("block_%s", parts[0])
-			stats[key] = parts[1]
+// parseDomifaddr returns the usable addresses in `virsh domifaddr` output.
+// domifaddr has no machine-readable mode, but only its header is translated:
+// the rows after the dashed separator are fixed columns of interface, MAC,
+// protocol and address. The interface column names the host-side tap device
+// (or "-" for further addresses on the same NIC), so it is not filtered.
+func parseDomifaddr(out string) []string {
+	var ips []string
+	inRows := false
+	for _, line := range strings.Split(out, "\n") {
+		if !inRows {
+			inRows = strings.HasPrefix(strings.TrimSpace(line), "---")
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		if ip := usableGuestAddress(fields[3]); ip != "" {
+			ips = append(ips, ip)
 		}
 	}
-	return stats, nil
+	return ips
 }
 
-// getDomainGuestInfo retrieves guest agent information
-func (v *VirshProvider) getDomainGuestInfo(ctx context.Context, domainName string) (map[string]string, error) {
-	info := make(map[string]string)
-
-	// Try to get guest OS information
-	result, err := v.runVirshCommand(ctx, "guestinfo", domainName, "--os")
-	if err == nil {
-		lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-		for _, line := range lines {
-			if strings.Contains(line, ":") {
-				parts := strings.SplitN(line, ":", 2)
-				if len(parts) == 2 {
-					key := fmt.Sprintf("guest_%s", strings.TrimSpace(parts[0]))
-					info[key] = strings.TrimSpace(parts[1])
-				}
-			}
-		}
+// usableGuestAddress strips any prefix length from addr and returns it,
+// or "" for placeholders, loopback and IPv6 link-local addresses.
+func usableGuestAddress(addr string) string {
+	if addr == "N/A" {
+		return ""
 	}
-
-	// Try to get guest hostname
-	if result, err := v.runVirshCommand(ctx, "guestinfo", domainName, "--hostname"); err == nil {
-		if strings.TrimSpace(result.Stdout) != "" {
-			info["guest_hostname"] = strings.TrimSpace(result.Stdout)
-		}
+	ip, _, _ := strings.Cut(addr, "/")
+	switch {
+	case ip == "" || ip == "-":
+		return ""
+	case ip == "127.0.0.1" || ip == "::1":
+		return ""
+	case strings.HasPrefix(strings.ToLower(ip), "fe80:"):
+		return ""
 	}
-
-	return info, nil
+	return ip
 }
 
-// getDomainState returns the current state of a domain
+// getDomainState returns the current state of a domain by its untranslated
+// virsh name ("running", "shut off", ...).
 func (v *VirshProvider) getDomainState(ctx context.Context, domainName string) (string, error) {
-	result, err := v.runVirshCommand(ctx, "domstate", domainName)
+	stats, err := v.getDomainStats(ctx, domainName, "--state")
 	if err != nil {
 		return "", fmt.Errorf("failed to get domain state: %w", err)
 	}
-
-	state := strings.TrimSpace(result.Stdout)
+	state := stats.State()
+	if state == "" {
+		return "", fmt.Errorf("failed to get domain state: no state.state record for %s", domainName)
+	}
 	log.Printf("DEBUG Domain %s state: %s", domainName, state)
 	return state, nil
 }
@@ -37,6 +37,7 @@ func TestTransientSSHConnectError(t *testing.T) {
 		{"connection timed out", "ssh: connect to host h port 22: Connection timed out", true},
 		{"no route to host", "ssh: connect to host h port 22: No route to host", true},
 		{"dns transient", "ssh: Could not resolve hostname h: Temporary failure in name resolution", true},
+		{"max sessions", "mux_client_request_session: session request failed: Session open refused by peer", true},
 		{"case-insensitive", "KEX_EXCHANGE_IDENTIFICATION: Connection Closed By Remote Host", true},
 		// Real virsh errors must NOT be treated as transient.
 		{"domain not found", "error: failed to get domain 'vm1'\nerror: Domain not found", false},
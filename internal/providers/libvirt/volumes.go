@@ -0,0 +1,396 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Orphan volume sweep configuration.
+const (
+	// EnvOrphanVolumePolicy is Log (the default) to only log orphaned
+	// volumes, Delete to also delete those older than
+	// EnvOrphanVolumeMinAge, or Disabled to not look for them.
+	EnvOrphanVolumePolicy = "PROVIDER_ORPHAN_VOLUME_POLICY"
+	// EnvOrphanVolumeMinAge is how long an orphaned volume must have gone
+	// unmodified before the Delete policy deletes it (default 24h).
+	EnvOrphanVolumeMinAge = "PROVIDER_ORPHAN_VOLUME_MIN_AGE"
+	// EnvOrphanVolumeSweepInterval is how often the sweep runs (default 1h).
+	EnvOrphanVolumeSweepInterval = "PROVIDER_ORPHAN_VOLUME_SWEEP_INTERVAL"
+)
+
+const (
+	orphanVolumePolicyLog      = "Log"
+	orphanVolumePolicyDelete   = "Delete"
+	orphanVolumePolicyDisabled = "Disabled"
+
+	defaultOrphanVolumeMinAge        = 24 * time.Hour
+	defaultOrphanVolumeSweepInterval = time.Hour
+)
+
+// cloudInitRoot is the directory on the libvirt host holding a directory
+// of cloud-init (or sysprep) files per VM, named after the VM.
+const cloudInitRoot = "/tmp/virtrigaud-cloudinit"
+
+// volumeOwner returns the VM a pool volume was created for. The provider
+// names a VM's disk volume "<vm>-disk", with a ".qcow2" suffix when the
+// disk was copied from an image; no other volume is the provider's to
+// delete.
+func volumeOwner(name string) (string, bool) {
+	vm, ok := strings.CutSuffix(strings.TrimSuffix(name, ".qcow2"), "-disk")
+	if !ok || vm == "" {
+		return "", false
+	}
+	return vm, true
+}
+
+// poolVolume is a volume in a libvirt storage pool.
+type poolVolume struct {
+	pool string
+	name string
+	path string
+	// backing is the path of the volume's qcow2 backing file, if any.
+	backing string
+	// modified is when the volume was last written; zero if unknown.
+	modified time.Time
+}
+
+// volumeXML is the part of `virsh vol-dumpxml` the provider reads.
+type volumeXML struct {
+	Target struct {
+		Path       string `xml:"path"`
+		Timestamps struct {
+			Mtime string `xml:"mtime"`
+		} `xml:"timestamps"`
+	} `xml:"target"`
+	BackingStore struct {
+		Path string `xml:"path"`
+	} `xml:"backingStore"`
+}
+
+// parseVolumeXML reads a volume's path, backing file and modification time
+// from `virsh vol-dumpxml` output.
+func parseVolumeXML(pool, name, raw string) (poolVolume, error) {
+	var x volumeXML
+	if err := xml.Unmarshal([]byte(raw), &x); err != nil {
+		return poolVolume{}, fmt.Errorf("parse volume %s/%s XML: %w", pool, name, err)
+	}
+	vol := poolVolume{pool: pool, name: name, path: x.Target.Path, backing: x.BackingStore.Path}
+	// Timestamps are seconds since the epoch with a fractional part.
+	if secs, _, _ := strings.Cut(x.Target.Timestamps.Mtime, "."); secs != "" {
+		if n, err := strconv.ParseInt(secs, 10, 64); err == nil {
+			vol.modified = time.Unix(n, 0)
+		}
+	}
+	return vol, nil
+}
+
+// parseVolListNames returns the volume names in `virsh vol-list` output.
+func parseVolListNames(stdout string) []string {
+	var names []string
+	header := true
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if header {
+			header = !strings.HasPrefix(line, "---")
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
+
+// listPoolVolumes returns the volumes of every active storage pool.
+func (p *Provider) listPoolVolumes(ctx context.Context) ([]poolVolume, error) {
+	res, err := p.virshProvider.runVirshCommand(ctx, "pool-list", "--name")
+	if err != nil {
+		return nil, fmt.Errorf("list storage pools: %w", err)
+	}
+	var vols []poolVolume
+	for _, pool := range strings.Fields(res.Stdout) {
+		list, err := p.virshProvider.runVirshCommand(ctx, "vol-list", pool)
+		if err != nil {
+			return nil, fmt.Errorf("list volumes of pool %s: %w", pool, err)
+		}
+		for _, name := range parseVolListNames(list.Stdout) {
+			raw, err := p.virshProvider.runVirshCommand(ctx, "vol-dumpxml", "--pool", pool, name)
+			if err != nil {
+				return nil, fmt.Errorf("read volume %s/%s: %w", pool, name, err)
+			}
+			vol, err := parseVolumeXML(pool, name, raw.Stdout)
+			if err != nil {
+				return nil, err
+			}
+			vols = append(vols, vol)
+		}
+	}
+	return vols, nil
+}
+
+// backingUsers maps each path that is the backing file of a volume, such as
+// a template or the source of a linked clone, to one volume backed by it.
+func backingUsers(vols []poolVolume) map[string]string {
+	users := map[string]string{}
+	for _, vol := range vols {
+		if vol.backing != "" {
+			users[vol.backing] = vol.pool + "/" + vol.name
+		}
+	}
+	return users
+}
+
+// keptVolume is a volume left in place, and why.
+type keptVolume struct {
+	vol    poolVolume
+	reason string
+}
+
+// vmVolumes picks the volumes to delete with VM id: those named for id by
+// the provider's convention that are one of the domain's disks, or any
+// such volume once the domain is gone. A volume another volume is backed
+// by is kept, so linked clones of the VM keep working.
+func vmVolumes(id string, diskPaths []string, domainGone bool, vols []poolVolume) (deleted []poolVolume, kept []keptVolume) {
+	disks := map[string]bool{}
+	for _, p := range diskPaths {
+		disks[p] = true
+	}
+	users := backingUsers(vols)
+	for _, vol := range vols {
+		attached := disks[vol.path]
+		owner, owned := volumeOwner(vol.name)
+		switch {
+		case !attached && (!domainGone || owner != id):
+			continue
+		case !owned || owner != id:
+			kept = append(kept, keptVolume{vol, "not created by virtrigaud for this VM"})
+		case users[vol.path] != "":
+			kept = append(kept, keptVolume{vol, "backing file of " + users[vol.path]})
+		default:
+			deleted = append(deleted, vol)
+		}
+	}
+	return deleted, kept
+}
+
+// orphanVolume is a volume named for a VM that no longer exists.
+type orphanVolume struct {
+	vol poolVolume
+	// deletable is whether the Delete policy may delete the volume;
+	// reason says why not.
+	deletable bool
+	reason    string
+}
+
+// findOrphanVolumes returns the volumes named for a VM by the provider's
+// convention whose VM is not among domains and that no domain uses. Only
+// those unmodified for minAge and not backing another volume are
+// deletable.
+func findOrphanVolumes(vols []poolVolume, domains, inUse map[string]bool, now time.Time, minAge time.Duration) []orphanVolume {
+	users := backingUsers(vols)
+	var orphans []orphanVolume
+	for _, vol := range vols {
+		owner, owned := volumeOwner(vol.name)
+		if !owned || domains[owner] || inUse[vol.path] {
+			continue
+		}
+		o := orphanVolume{vol: vol}
+		switch {
+		case users[vol.path] != "":
+			o.reason = "backing file of " + users[vol.path]
+		case vol.modified.IsZero():
+			o.reason = "modification time unknown"
+		case now.Sub(vol.modified) < minAge:
+			o.reason = fmt.Sprintf("modified %s ago", now.Sub(vol.modified).Round(time.Minute))
+		default:
+			o.deletable = true
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans
+}
+
+// deleteVMStorage deletes the volumes and cloud-init files the provider
+// created for VM id. diskPaths are the domain's disks, read before it was
+// undefined; domainGone says the domain was already missing, in which case
+// the volumes are found by name alone. Failures are logged: the domain is
+// gone, and leftovers are reported by the orphan sweep.
+func (p *Provider) deleteVMStorage(ctx context.Context, id string, diskPaths []string, domainGone bool) {
+	vols, err := p.listPoolVolumes(ctx)
+	if err != nil {
+		log.Printf("WARN Not deleting volumes of %s: %v", id, err)
+	} else {
+		deleted, kept := vmVolumes(id, diskPaths, domainGone, vols)
+		for _, k := range kept {
+			log.Printf("INFO Keeping volume %s/%s of %s: %s", k.vol.pool, k.vol.name, id, k.reason)
+		}
+		sp := NewStorageProvider(p.virshProvider)
+		for _, vol := range deleted {
+			if err := sp.DeleteVolume(ctx, vol.pool, vol.name); err != nil {
+				log.Printf("WARN Failed to delete volume %s/%s of %s: %v", vol.pool, vol.name, id, err)
+			}
+		}
+	}
+
+	// The directory is named after the VM, so only a plain name may be
+	// joined onto cloudInitRoot.
+	if id == "" || id != path.Base(id) || id == ".." {
+		return
+	}
+	dir := path.Join(cloudInitRoot, id)
+	if _, err := p.virshProvider.runVirshCommand(ctx, "!", "rm", "-rf", dir); err != nil {
+		log.Printf("WARN Failed to delete cloud-init directory %s: %v", dir, err)
+	}
+}
+
+// orphanSweepConfig is the orphan sweep configuration from the environment.
+type orphanSweepConfig struct {
+	policy   string
+	minAge   time.Duration
+	interval time.Duration
+}
+
+// orphanSweepConfigFromEnv reads the EnvOrphanVolume* variables.
+func orphanSweepConfigFromEnv() (orphanSweepConfig, error) {
+	cfg := orphanSweepConfig{
+		policy:   orphanVolumePolicyLog,
+		minAge:   defaultOrphanVolumeMinAge,
+		interval: defaultOrphanVolumeSweepInterval,
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvOrphanVolumePolicy)); v != "" {
+		switch {
+		case strings.EqualFold(v, orphanVolumePolicyLog):
+			cfg.policy = orphanVolumePolicyLog
+		case strings.EqualFold(v, orphanVolumePolicyDelete):
+			cfg.policy = orphanVolumePolicyDelete
+		case strings.EqualFold(v, orphanVolumePolicyDisabled):
+			cfg.policy = orphanVolumePolicyDisabled
+		default:
+			return cfg, fmt.Errorf("%s=%q: must be Log, Delete or Disabled", EnvOrphanVolumePolicy, v)
+		}
+	}
+	for env, d := range map[string]*time.Duration{
+		EnvOrphanVolumeMinAge:        &cfg.minAge,
+		EnvOrphanVolumeSweepInterval: &cfg.interval,
+	} {
+		v := strings.TrimSpace(os.Getenv(env))
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			return cfg, fmt.Errorf("%s=%q: must be a positive duration such as 24h", env, v)
+		}
+		*d = parsed
+	}
+	return cfg, nil
+}
+
+// startOrphanVolumeSweep looks for orphaned volumes on every managed host
+// every cfg.interval until ctx is done.
+func (p *Provider) startOrphanVolumeSweep(ctx context.Context, cfg orphanSweepConfig) {
+	if cfg.policy == orphanVolumePolicyDisabled {
+		return
+	}
+	log.Printf("INFO Sweeping for orphaned volumes every %s (policy %s, minimum age %s)", cfg.interval, cfg.policy, cfg.minAge)
+	go func() {
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.sweepOrphanVolumes(ctx, cfg)
+			}
+		}
+	}()
+}
+
+// sweepOrphanVolumes logs the volumes named for VMs that no managed host
+// defines, and deletes the deletable ones under the Delete policy. Pools
+// may be shared between hosts, so a volume is only an orphan when no host
+// has its VM or uses it; if any host cannot be listed, the sweep is
+// skipped.
+func (p *Provider) sweepOrphanVolumes(ctx context.Context, cfg orphanSweepConfig) {
+	hosts := []*Provider{p}
+	if p.hosts != nil {
+		hosts = hosts[:0]
+		for _, h := range p.hosts.hosts {
+			hosts = append(hosts, h.provider)
+		}
+	}
+
+	domains, inUse := map[string]bool{}, map[string]bool{}
+	for _, h := range hosts {
+		list, err := h.virshProvider.listDomains(ctx)
+		if err != nil {
+			log.Printf("WARN Skipping orphaned volume sweep: %v", err)
+			return
+		}
+		for _, d := range list {
+			domains[d.Name] = true
+			paths, err := h.getDomainDiskPaths(ctx, d.Name)
+			if err != nil {
+				log.Printf("WARN Skipping orphaned volume sweep: disks of %s: %v", d.Name, err)
+				return
+			}
+			for _, disk := range paths {
+				inUse[disk] = true
+			}
+		}
+	}
+
+	// A volume of a pool shared between hosts is seen from each of them.
+	seen := map[string]bool{}
+	now := time.Now()
+	for _, h := range hosts {
+		vols, err := h.listPoolVolumes(ctx)
+		if err != nil {
+			log.Printf("WARN Skipping orphaned volume sweep of %s: %v", h.virshProvider.uri, err)
+			continue
+		}
+		for _, o := range findOrphanVolumes(vols, domains, inUse, now, cfg.minAge) {
+			if seen[o.vol.path] {
+				continue
+			}
+			seen[o.vol.path] = true
+			if cfg.policy != orphanVolumePolicyDelete || !o.deletable {
+				reason := o.reason
+				if o.deletable {
+					reason = "policy " + cfg.policy
+				}
+				log.Printf("INFO Orphaned volume %s/%s (%s): kept, %s", o.vol.pool, o.vol.name, o.vol.path, reason)
+				continue
+			}
+			if err := NewStorageProvider(h.virshProvider).DeleteVolume(ctx, o.vol.pool, o.vol.name); err != nil {
+				log.Printf("WARN Failed to delete orphaned volume %s/%s: %v", o.vol.pool, o.vol.name, err)
+				continue
+			}
+			log.Printf("INFO Deleted orphaned volume %s/%s (%s)", o.vol.pool, o.vol.name, o.vol.path)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libvirt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const imagesDir = "/var/lib/libvirt/images/"
+
+func testVolume(name, backing string) poolVolume {
+	vol := poolVolume{pool: "default", name: name, path: imagesDir + name}
+	if backing != "" {
+		vol.backing = imagesDir + backing
+	}
+	return vol
+}
+
+func volumeNames(vols []poolVolume) []string {
+	var names []string
+	for _, vol := range vols {
+		names = append(names, vol.name)
+	}
+	return names
+}
+
+func TestVolumeOwner(t *testing.T) {
+	for name, want := range map[string]string{
+		"web-disk":       "web",
+		"web-disk.qcow2": "web",
+		"web-01-disk":    "web-01",
+	} {
+		owner, ok := volumeOwner(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, owner, name)
+	}
+	for _, name := range []string{"-disk", "web.qcow2", "ubuntu-22.04-server-cloudimg-amd64.img", "web-disk.img", "web-diskette"} {
+		_, ok := volumeOwner(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestParseVolumeXML(t *testing.T) {
+	raw := `<volume type='file'>
+  <name>web-disk</name>
+  <target>
+    <path>/var/lib/libvirt/images/web-disk</path>
+    <format type='qcow2'/>
+    <timestamps>
+      <atime>1760000100.500000000</atime>
+      <mtime>1760000000.250000000</mtime>
+    </timestamps>
+  </target>
+  <backingStore>
+    <path>/var/lib/libvirt/images/ubuntu-base.qcow2</path>
+    <format type='qcow2'/>
+  </backingStore>
+</volume>`
+	vol, err := parseVolumeXML("default", "web-disk", raw)
+	require.NoError(t, err)
+	assert.Equal(t, imagesDir+"web-disk", vol.path)
+	assert.Equal(t, imagesDir+"ubuntu-base.qcow2", vol.backing)
+	assert.Equal(t, time.Unix(1760000000, 0), vol.modified)
+}
+
+func TestParseVolListNames(t *testing.T) {
+	out := ` Name             Path
+------------------------------------------------------------
+ db-disk.qcow2    /var/lib/libvirt/images/db-disk.qcow2
+ web-disk         /var/lib/libvirt/images/web-disk
+
+`
+	assert.Equal(t, []string{"db-disk.qcow2", "web-disk"}, parseVolListNames(out))
+}
+
+func TestVMVolumes(t *testing.T) {
+	pool := []poolVolume{
+		// A shared template: web's disk and tpl's linked clones are backed
+		// by it.
+		testVolume("ubuntu-base.qcow2", ""),
+		testVolume("web-disk", "ubuntu-base.qcow2"),
+		testVolume("web-data.qcow2", ""),
+		testVolume("tpl-disk", ""),
+		testVolume("tpl-clone-disk.qcow2", "tpl-disk"),
+		testVolume("db-disk", ""),
+	}
+
+	t.Run("deletes the VM's own disk only", func(t *testing.T) {
+		deleted, kept := vmVolumes("web", []string{
+			imagesDir + "web-disk",
+			imagesDir + "web-data.qcow2",
+		}, false, pool)
+		assert.Equal(t, []string{"web-disk"}, volumeNames(deleted))
+		require.Len(t, kept, 1)
+		assert.Equal(t, "web-data.qcow2", kept[0].vol.name, "a disk attached by hand is not the provider's")
+	})
+
+	t.Run("keeps a template backing linked clones", func(t *testing.T) {
+		deleted, kept := vmVolumes("tpl", []string{imagesDir + "tpl-disk"}, false, pool)
+		assert.Empty(t, deleted)
+		require.Len(t, kept, 1)
+		assert.Equal(t, "backing file of default/tpl-clone-disk.qcow2", kept[0].reason)
+	})
+
+	t.Run("does not follow a disk to its backing file", func(t *testing.T) {
+		deleted, _ := vmVolumes("tpl-clone", []string{imagesDir + "tpl-clone-disk.qcow2"}, false, pool)
+		assert.Equal(t, []string{"tpl-clone-disk.qcow2"}, volumeNames(deleted))
+	})
+
+	t.Run("finds volumes by name once the domain is gone", func(t *testing.T) {
+		deleted, kept := vmVolumes("db", nil, true, pool)
+		assert.Equal(t, []string{"db-disk"}, volumeNames(deleted))
+		assert.Empty(t, kept)
+
+		deleted, _ = vmVolumes("db", nil, false, pool)
+		assert.Empty(t, deleted, "a defined domain's volumes are only found through its disks")
+	})
+}
+
+func TestFindOrphanVolumes(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+	aged := func(vol poolVolume, age time.Duration) poolVolume {
+		vol.modified = now.Add(-age)
+		return vol
+	}
+	pool := []poolVolume{
+		aged(testVolume("ubuntu-base.qcow2", ""), 30*24*time.Hour),
+		aged(testVolume("gone-disk", ""), 48*time.Hour),
+		aged(testVolume("recent-disk", ""), time.Hour),
+		testVolume("unknown-disk", ""),
+		aged(testVolume("old-tpl-disk", ""), 48*time.Hour),
+		aged(testVolume("child-disk.qcow2", "old-tpl-disk"), 48*time.Hour),
+		aged(testVolume("web-disk", ""), 48*time.Hour),
+		aged(testVolume("attached-disk", ""), 48*time.Hour),
+	}
+	domains := map[string]bool{"web": true, "child": true, "other": true}
+	inUse := map[string]bool{imagesDir + "attached-disk": true}
+
+	orphans := findOrphanVolumes(pool, domains, inUse, now, 24*time.Hour)
+	got := map[string]orphanVolume{}
+	for _, o := range orphans {
+		got[o.vol.name] = o
+	}
+	assert.ElementsMatch(t, []string{"gone-disk", "recent-disk", "unknown-disk", "old-tpl-disk"}, func() []string {
+		var names []string
+		for name := range got {
+			names = append(names, name)
+		}
+		return names
+	}())
+	assert.True(t, got["gone-disk"].deletable)
+	assert.False(t, got["recent-disk"].deletable)
+	assert.Equal(t, "modified 1h0m0s ago", got["recent-disk"].reason)
+	assert.False(t, got["unknown-disk"].deletable)
+	assert.False(t, got["old-tpl-disk"].deletable, "a shared backing file survives")
+	assert.Equal(t, "backing file of default/child-disk.qcow2", got["old-tpl-disk"].reason)
+}
+
+func TestOrphanSweepConfigFromEnv(t *testing.T) {
+	cfg, err := orphanSweepConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, orphanSweepConfig{policy: "Log", minAge: 24 * time.Hour, interval: time.Hour}, cfg)
+
+	t.Setenv(EnvOrphanVolumePolicy, "delete")
+	t.Setenv(EnvOrphanVolumeMinAge, "72h")
+	t.Setenv(EnvOrphanVolumeSweepInterval, "15m")
+	cfg, err = orphanSweepConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, orphanSweepConfig{policy: "Delete", minAge: 72 * time.Hour, interval: 15 * time.Minute}, cfg)
+
+	t.Setenv(EnvOrphanVolumePolicy, "Purge")
+	_, err = orphanSweepConfigFromEnv()
+	assert.ErrorContains(t, err, EnvOrphanVolumePolicy)
+
+	t.Setenv(EnvOrphanVolumePolicy, "")
+	t.Setenv(EnvOrphanVolumeMinAge, "-1h")
+	_, err = orphanSweepConfigFromEnv()
+	assert.ErrorContains(t, err, EnvOrphanVolumeMinAge)
+}
@@ -19,16 +19,26 @@ package mock
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/storage/migration"
+	"github.com/projectbeskar/virtrigaud/internal/version"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
 )
 
 // Provider implements a mock provider for testing and demos.
@@ -36,16 +46,30 @@ type Provider struct {
 	providerv1.UnimplementedProviderServer
 	mu           sync.RWMutex
 	vms          map[string]*VirtualMachine
-	tasks        map[string]*Task
+	images       map[string]bool // names PublishImage has published
+	tasks        *tasks.Tracker
 	capabilities *capabilities.Manager
 	failureMode  string
 	slowMode     bool
+	// taskDuration, when set, is how long every async task takes instead
+	// of its usual delay
+	taskDuration time.Duration
+	// clones caps the create tasks running at once
+	// (PROVIDER_MAX_CONCURRENT_CLONES), as the real providers cap clones
+	clones *common.CloneLimiter
 }
 
 // VirtualMachine represents a mock virtual machine.
 type VirtualMachine struct {
-	ID          string
-	Name        string
+	ID   string
+	Name string
+	Key  string // idempotency key of the Create that made it
+	// Customization is the guest customization Create was given, kept so
+	// tests can check what reached the provider
+	Customization *contracts.GuestCustomization
+	// Networks are the NICs Create was given; power-on assigns their
+	// static addresses
+	Networks    []contracts.NetworkAttachment
 	PowerState  string
 	IPs         []string
 	ConsoleURL  string
@@ -61,31 +85,36 @@ type Snapshot struct {
 	CreatedTime time.Time
 	Description string
 	SizeBytes   int64
-	HasMemory   bool
+	// ConsumedBytes is less than SizeBytes, as on thin storage.
+	ConsumedBytes int64
+	HasMemory     bool
+	ParentID      string
 }
 
-// Task represents an async operation.
-type Task struct {
-	ID        string
-	Done      bool
-	Error     string
-	Created   time.Time
-	Completed time.Time
+// NewProvider creates a new mock provider that keeps its tasks in memory.
+func NewProvider() *Provider {
+	return NewProviderWithTaskStore(tasks.NewMemoryStore())
 }
 
-// NewProvider creates a new mock provider.
-func NewProvider() *Provider {
+// NewProviderWithTaskStore creates a new mock provider whose task records
+// live in store, so tasks issued before a restart can still be polled.
+func NewProviderWithTaskStore(store tasks.Store) *Provider {
 	// Build comprehensive capabilities for mock provider
 	caps := capabilities.NewBuilder().
 		Core().
 		Mock().
 		Snapshots().
 		MemorySnapshots().
+		QuiescedSnapshots().
 		LinkedClones().
 		OnlineReconfigure().
 		OnlineDiskExpansion().
 		ImageImport().
+		ImagePublish().
 		TaskStatus().
+		ConsoleOutput().
+		Sysprep().
+		CloudInitStatus().
 		// ADR-0006 Slice 0: advertise the status quo honestly. The mock's
 		// migration path (like the production providers) is pod-side only —
 		// pvc staging, relay-shaped; nfs/s3 and direct transfer are not
@@ -97,12 +126,18 @@ func NewProvider() *Provider {
 		NetworkTypes("bridge", "nat", "distributed").
 		Build()
 
+	tracker := tasks.NewTracker(store)
+	tracker.ValidRef = func(ref string) bool { return strings.HasPrefix(ref, "task-") }
+
 	provider := &Provider{
 		vms:          make(map[string]*VirtualMachine),
-		tasks:        make(map[string]*Task),
+		images:       make(map[string]bool),
+		tasks:        tracker,
 		capabilities: caps,
 		failureMode:  os.Getenv("MOCK_FAILURE_MODE"),
 		slowMode:     os.Getenv("MOCK_SLOW_MODE") == "true",
+		taskDuration: taskDurationFromEnv(),
+		clones:       common.CloneLimiterFromEnv(),
 	}
 
 	// Create some sample VMs for demos
@@ -163,39 +198,67 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 		return nil, errors.NewInternal("mock provider configured to fail create operations", nil)
 	}
 
+	var customization *contracts.GuestCustomization
+	if req.GuestCustomizationJson != "" {
+		customization = &contracts.GuestCustomization{}
+		if err := contracts.UnmarshalPayload([]byte(req.GuestCustomizationJson), customization); err != nil {
+			return nil, errors.NewInvalidSpec("invalid guest customization: %v", err)
+		}
+	}
+
+	var networks []contracts.NetworkAttachment
+	if req.NetworksJson != "" {
+		if err := contracts.UnmarshalPayload([]byte(req.NetworksJson), &networks); err != nil {
+			return nil, errors.NewInvalidSpec("invalid networks payload: %v", err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// A retried Create returns the VM its first attempt made
+	if req.IdempotencyKey != "" {
+		for _, existing := range p.vms {
+			if existing.Key == req.IdempotencyKey {
+				return &providerv1.CreateResponse{Id: existing.ID}, nil
+			}
+		}
+	}
+
+	// A create holds a clone slot until its task completes
+	release, err := p.clones.Acquire()
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate VM ID
 	id := p.generateID("vm")
 
 	// Create VM
 	vm := &VirtualMachine{
-		ID:          id,
-		Name:        req.Name,
-		PowerState:  "Off", // Start powered off
-		IPs:         []string{},
-		ConsoleURL:  fmt.Sprintf("https://console.example.com/vm/%s", id),
-		Created:     time.Now(),
-		LastUpdated: time.Now(),
-		Snapshots:   make(map[string]*Snapshot),
+		ID:            id,
+		Name:          req.Name,
+		Key:           req.IdempotencyKey,
+		Customization: customization,
+		Networks:      networks,
+		PowerState:    "Off", // Start powered off
+		IPs:           []string{},
+		ConsoleURL:    fmt.Sprintf("https://console.example.com/vm/%s", id),
+		Created:       time.Now(),
+		LastUpdated:   time.Now(),
+		Snapshots:     make(map[string]*Snapshot),
 	}
 
-	p.mu.Lock()
 	p.vms[id] = vm
-	p.mu.Unlock()
 
 	// Create async task for the operation
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "create")
 
 	// Complete the task after a delay
-	go p.completeTaskAfterDelay(taskID, 2*time.Second)
+	go func() {
+		defer release()
+		p.completeTaskAfterDelay(taskID, 2*time.Second)
+	}()
 
 	return &providerv1.CreateResponse{
 		Id: id,
@@ -222,25 +285,15 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 	}
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "delete")
 
 	// Delete VM after delay
 	go func() {
 		time.Sleep(1 * time.Second)
 		p.mu.Lock()
 		delete(p.vms, req.Id)
-		task.Done = true
-		task.Completed = time.Now()
 		p.mu.Unlock()
+		p.finishTask(taskID)
 	}()
 
 	return &providerv1.TaskResponse{
@@ -267,48 +320,38 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	}
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "power")
 
 	// Update power state after delay
 	go func() {
 		time.Sleep(1 * time.Second)
 
-		var newState string
+		// Describe and the other RPCs read these fields under p.mu.
+		p.mu.Lock()
 		switch req.Op {
 		case providerv1.PowerOp_POWER_OP_ON:
-			newState = "On"
-			// Assign IP when powering on
+			vm.PowerState = "On"
+			// Assign IPs when powering on
 			if len(vm.IPs) == 0 {
-				vm.IPs = []string{fmt.Sprintf("192.168.1.%d", 100+rand.Intn(50))}
+				vm.IPs = mockIPs(vm.Networks)
 			}
 		case providerv1.PowerOp_POWER_OP_OFF:
-			newState = "Off"
+			vm.PowerState = "Off"
 			// Clear IPs when powering off
 			vm.IPs = []string{}
-		case providerv1.PowerOp_POWER_OP_REBOOT:
-			newState = "On"
+		case providerv1.PowerOp_POWER_OP_REBOOT, providerv1.PowerOp_POWER_OP_RESET:
+			vm.PowerState = "On"
 		case providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL:
 			// Mock graceful shutdown - same as regular Off but with a slight delay
-			newState = "Off"
+			vm.PowerState = "Off"
 			// Clear IPs when shutting down
 			vm.IPs = []string{}
+		default:
+			vm.PowerState = ""
 		}
-
-		p.mu.Lock()
-		vm.PowerState = newState
 		vm.LastUpdated = time.Now()
-		task.Done = true
-		task.Completed = time.Now()
 		p.mu.Unlock()
+		p.finishTask(taskID)
 	}()
 
 	return &providerv1.TaskResponse{
@@ -318,8 +361,10 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	}, nil
 }
 
-// Reconfigure reconfigures a virtual machine.
-func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+// Reconfigure reconfigures a virtual machine. Like most hypervisors, the
+// mock cannot remove CPUs or memory from a running VM; such changes are
+// reported as needing a power cycle.
+func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	p.simulateDelay()
 
 	if p.shouldFail("reconfigure") {
@@ -327,33 +372,35 @@ func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureR
 	}
 
 	p.mu.RLock()
-	_, exists := p.vms[req.Id]
+	vm, exists := p.vms[req.Id]
+	var powerState string
+	if exists {
+		powerState = vm.PowerState
+	}
 	p.mu.RUnlock()
 
 	if !exists {
 		return nil, errors.NewNotFound("VirtualMachine", req.Id)
 	}
 
-	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
+	resp := &providerv1.ReconfigureResponse{}
+	if powerState == "On" {
+		if cpu := req.GetChanges().GetCpu(); cpu != nil && cpu.New < cpu.Old {
+			resp.PowerCycleRequired = append(resp.PowerCycleRequired, "cpu")
+		}
+		if mem := req.GetChanges().GetMemoryMib(); mem != nil && mem.New < mem.Old {
+			resp.PowerCycleRequired = append(resp.PowerCycleRequired, "memoryMiB")
+		}
 	}
 
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	// Create async task
+	taskID := p.startTask(ctx, "reconfigure")
 
 	// Complete reconfiguration after delay
 	go p.completeTaskAfterDelay(taskID, 3*time.Second)
 
-	return &providerv1.TaskResponse{
-		Task: &providerv1.TaskRef{
-			Id: taskID,
-		},
-	}, nil
+	resp.Task = &providerv1.TaskRef{Id: taskID}
+	return resp, nil
 }
 
 // Describe describes a virtual machine's current state.
@@ -365,8 +412,8 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 	}
 
 	p.mu.RLock()
+	defer p.mu.RUnlock()
 	vm, exists := p.vms[req.Id]
-	p.mu.RUnlock()
 
 	if !exists {
 		return &providerv1.DescribeResponse{
@@ -374,13 +421,204 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		}, nil
 	}
 
+	raw := map[string]string{
+		"id":          vm.ID,
+		"name":        vm.Name,
+		"created":     vm.Created.Format(time.RFC3339),
+		"lastUpdated": vm.LastUpdated.Format(time.RFC3339),
+	}
+	// The answer file can hold the Administrator password, so only its
+	// digest is reported.
+	if c := vm.Customization; c != nil {
+		raw["guestCustomization"] = c.Type
+		if c.Sysprep != nil {
+			sum := sha256.Sum256([]byte(c.Sysprep.UnattendXML))
+			raw["unattendSha256"] = hex.EncodeToString(sum[:])
+		}
+	}
+	rawJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.NewInternal("failed to encode VM details", err)
+	}
+
+	// The IPs are copied so the response does not share the slice a later
+	// power operation replaces.
 	return &providerv1.DescribeResponse{
-		Exists:     true,
-		PowerState: vm.PowerState,
-		Ips:        vm.IPs,
-		ConsoleUrl: vm.ConsoleURL,
-		ProviderRawJson: fmt.Sprintf(`{"id":"%s","name":"%s","created":"%s","lastUpdated":"%s"}`,
-			vm.ID, vm.Name, vm.Created.Format(time.RFC3339), vm.LastUpdated.Format(time.RFC3339)),
+		Exists:          true,
+		PowerState:      vm.PowerState,
+		Ips:             slices.Clone(vm.IPs),
+		ConsoleUrl:      vm.ConsoleURL,
+		ProviderRawJson: string(rawJSON),
+		GuestStats:      mockGuestStats(vm),
+		Addresses:       mockAddresses(vm),
+		Identity:        vm.Key,
+	}, nil
+}
+
+// mockIPs returns the addresses a VM with networks comes up with: the
+// static IPv4 and IPv6 addresses of each network, and a DHCP-like
+// 192.168.1.x when none has a static IPv4 address.
+func mockIPs(networks []contracts.NetworkAttachment) []string {
+	var ips []string
+	hasIPv4 := false
+	for _, n := range networks {
+		if n.StaticIP != "" {
+			ips = append(ips, n.StaticIP)
+			hasIPv4 = true
+		}
+		if n.StaticIPv6 != "" {
+			ip, _, _ := strings.Cut(n.StaticIPv6, "/")
+			ips = append(ips, ip)
+		}
+	}
+	if !hasIPv4 {
+		ips = append([]string{fmt.Sprintf("192.168.1.%d", 100+rand.Intn(50))}, ips...)
+	}
+	return ips
+}
+
+// mockAddresses places the IPs of the VM on its NICs, eth0 for the first
+// network and so on. A static address goes on the NIC of its network;
+// anything else, such as the DHCP-like address, on eth0. Callers hold p.mu.
+func mockAddresses(vm *VirtualMachine) []*providerv1.GuestAddress {
+	var addrs []*providerv1.GuestAddress
+	for _, ip := range vm.IPs {
+		iface := "eth0"
+		for i, n := range vm.Networks {
+			if v6, _, _ := strings.Cut(n.StaticIPv6, "/"); ip == n.StaticIP || (v6 != "" && ip == v6) {
+				iface = fmt.Sprintf("eth%d", i)
+				break
+			}
+		}
+		addrs = append(addrs, &providerv1.GuestAddress{Ip: ip, Interface: iface})
+	}
+	return addrs
+}
+
+// mockGuestStats reports fixed usage for a running VM: 10% CPU and 1 GiB of
+// memory. Callers hold p.mu.
+func mockGuestStats(vm *VirtualMachine) *providerv1.GuestStats {
+	if vm.PowerState != "On" {
+		return nil
+	}
+	cpu, mem := 10.0, int64(1<<30)
+	return &providerv1.GuestStats{CpuUsagePercent: &cpu, MemoryUsageBytes: &mem}
+}
+
+// GetConsoleOutput returns a synthetic serial console transcript for a VM.
+func (p *Provider) GetConsoleOutput(ctx context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error) {
+	p.simulateDelay()
+
+	if p.shouldFail("console") {
+		return nil, errors.NewInternal("mock provider configured to fail console operations", nil)
+	}
+
+	p.mu.RLock()
+	vm, exists := p.vms[req.Id]
+	p.mu.RUnlock()
+
+	if !exists {
+		return nil, errors.NewNotFound("VM", req.Id)
+	}
+
+	lines := []string{
+		fmt.Sprintf("[    0.000000] Linux version 6.1.0-mock (%s)", vm.Name),
+		"[    1.204511] Run /sbin/init as init process",
+		fmt.Sprintf("[    4.883120] cloud-init[412]: Cloud-init finished on %s", vm.Created.Format(time.RFC3339)),
+		fmt.Sprintf("%s login:", vm.Name),
+	}
+	truncated := false
+	if req.TailLines > 0 && int(req.TailLines) < len(lines) {
+		lines = lines[len(lines)-int(req.TailLines):]
+		truncated = true
+	}
+
+	return &providerv1.GetConsoleOutputResponse{
+		Output:    strings.Join(lines, "\n") + "\n",
+		Truncated: truncated,
+		Source:    "serial0",
+	}, nil
+}
+
+// mockCloudInitDuration is how long cloud-init runs in a mock VM after it
+// is created.
+const mockCloudInitDuration = 10 * time.Second
+
+// GetCloudInitStatus reports cloud-init as running for a short while after
+// a VM is created and done afterwards. MOCK_FAILURE_MODE=cloudinit makes it
+// report a failed run instead.
+func (p *Provider) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	p.simulateDelay()
+
+	p.mu.RLock()
+	vm, exists := p.vms[req.Id]
+	var running bool
+	var created time.Time
+	if exists {
+		running, created = vm.PowerState == "On", vm.Created
+	}
+	p.mu.RUnlock()
+
+	if !exists {
+		return nil, errors.NewNotFound("VM", req.Id)
+	}
+	if !running {
+		return nil, errors.NewUnavailable("mock guest agent", fmt.Errorf("VM %s is not running", req.Id))
+	}
+
+	switch {
+	case time.Since(created) < mockCloudInitDuration:
+		return &providerv1.GetCloudInitStatusResponse{Status: "running", Detail: "DataSourceNoCloud [seed=/dev/sr0]"}, nil
+	case p.shouldFail("cloudinit"):
+		return &providerv1.GetCloudInitStatusResponse{
+			Status: "error",
+			Errors: []string{"('scripts_user', RuntimeError('Runparts: 1 failures (runcmd) in /var/lib/cloud/instance/scripts'))"},
+			Detail: "error - done",
+		}, nil
+	default:
+		return &providerv1.GetCloudInitStatusResponse{Status: "done", Detail: "done"}, nil
+	}
+}
+
+// GetCapacity reports a single fixed-size mock host whose load grows with
+// the number of running VMs.
+func (p *Provider) GetCapacity(ctx context.Context, req *providerv1.GetCapacityRequest) (*providerv1.GetCapacityResponse, error) {
+	p.simulateDelay()
+
+	if p.shouldFail("capacity") {
+		return nil, errors.NewUnavailable("mock provider configured to fail capacity reports", nil)
+	}
+
+	p.mu.RLock()
+	running, total := 0, len(p.vms)
+	for _, vm := range p.vms {
+		if vm.PowerState == "On" {
+			running++
+		}
+	}
+	p.mu.RUnlock()
+
+	const gib = int64(1024 * 1024 * 1024)
+	return &providerv1.GetCapacityResponse{
+		Hosts: []*providerv1.HostCapacity{{
+			Name:              "mock-host-1",
+			CpuCores:          32,
+			CpuUtilization:    min(float64(running)*0.05, 1),
+			MemoryTotalBytes:  128 * gib,
+			MemoryUsedBytes:   int64(running) * 2 * gib,
+			StorageTotalBytes: 2048 * gib,
+			StorageUsedBytes:  int64(total) * 20 * gib,
+		}},
+	}, nil
+}
+
+// GetInfo reports the provider build and a fixed mock hypervisor release.
+func (p *Provider) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	return &providerv1.GetInfoResponse{
+		ProviderVersion:   version.Version,
+		GitSha:            version.GitSHA,
+		HypervisorProduct: "Mock Hypervisor",
+		HypervisorVersion: "1.0.0",
 	}, nil
 }
 
@@ -426,7 +664,7 @@ func (p *Provider) ListVMs(ctx context.Context, req *providerv1.ListVMsRequest)
 			Id:          vm.ID,
 			Name:        vm.Name,
 			PowerState:  vm.PowerState,
-			Ips:         vm.IPs,
+			Ips:         slices.Clone(vm.IPs),
 			Cpu:         2,    // Default CPU
 			MemoryMib:   4096, // Default 4 GiB
 			Disks:       disks,
@@ -450,21 +688,7 @@ func (p *Provider) TaskStatus(ctx context.Context, req *providerv1.TaskStatusReq
 		return nil, errors.NewInternal("mock provider configured to fail task status operations", nil)
 	}
 
-	p.mu.RLock()
-	task, exists := p.tasks[req.Task.Id]
-	p.mu.RUnlock()
-
-	if !exists {
-		return &providerv1.TaskStatusResponse{
-			Done:  true,
-			Error: "task not found",
-		}, nil
-	}
-
-	return &providerv1.TaskStatusResponse{
-		Done:  task.Done,
-		Error: task.Error,
-	}, nil
+	return p.tasks.Status(ctx, req.GetTask().GetId())
 }
 
 // SnapshotCreate creates a VM snapshot.
@@ -495,34 +719,84 @@ func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotC
 		SizeBytes:   int64(rand.Intn(1000000000) + 100000000), // 100MB to 1GB
 		HasMemory:   req.IncludeMemory,
 	}
+	snapshot.ConsumedBytes = snapshot.SizeBytes / 2
 
 	p.mu.Lock()
+	if latest := latestSnapshot(vm); latest != nil {
+		snapshot.ParentID = latest.ID
+	}
 	vm.Snapshots[snapshotID] = snapshot
+	// The mock guest always has a responsive agent, so a running VM is
+	// quiesced whenever asked to be.
+	quiesce := providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED
+	if req.Quiesce && vm.PowerState == "On" && !req.IncludeMemory {
+		quiesce = providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED
+	}
 	p.mu.Unlock()
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "snapshot_create")
 
 	// Complete snapshot creation after delay
 	go p.completeTaskAfterDelay(taskID, 5*time.Second)
 
 	return &providerv1.SnapshotCreateResponse{
 		SnapshotId: snapshotID,
+		Quiesce:    quiesce,
 		Task: &providerv1.TaskRef{
 			Id: taskID,
 		},
 	}, nil
 }
 
+// latestSnapshot returns the most recently created snapshot of vm, which
+// new snapshots are chained onto. Callers hold p.mu.
+func latestSnapshot(vm *VirtualMachine) *Snapshot {
+	var latest *Snapshot
+	for _, snap := range vm.Snapshots {
+		if latest == nil || snap.CreatedTime.After(latest.CreatedTime) {
+			latest = snap
+		}
+	}
+	return latest
+}
+
+// SnapshotList lists a VM's snapshots, oldest first.
+func (p *Provider) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	p.simulateDelay()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	vm, exists := p.vms[req.VmId]
+	if !exists {
+		return nil, errors.NewNotFound("VirtualMachine", req.VmId)
+	}
+
+	snapshots := make([]*Snapshot, 0, len(vm.Snapshots))
+	for _, snap := range vm.Snapshots {
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedTime.Before(snapshots[j].CreatedTime)
+	})
+
+	resp := &providerv1.SnapshotListResponse{}
+	for _, snap := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, &providerv1.SnapshotInfo{
+			Id:            snap.ID,
+			Name:          snap.Name,
+			CreatedUnix:   snap.CreatedTime.Unix(),
+			Description:   snap.Description,
+			HasMemory:     snap.HasMemory,
+			ParentId:      snap.ParentID,
+			SizeBytes:     &snap.SizeBytes,
+			ConsumedBytes: &snap.ConsumedBytes,
+		})
+	}
+	return resp, nil
+}
+
 // SnapshotDelete deletes a VM snapshot.
 func (p *Provider) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotDeleteRequest) (*providerv1.TaskResponse, error) {
 	p.simulateDelay()
@@ -548,25 +822,15 @@ func (p *Provider) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotD
 	}
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "snapshot_delete")
 
 	// Delete snapshot after delay
 	go func() {
 		time.Sleep(2 * time.Second)
 		p.mu.Lock()
 		delete(vm.Snapshots, req.SnapshotId)
-		task.Done = true
-		task.Completed = time.Now()
 		p.mu.Unlock()
+		p.finishTask(taskID)
 	}()
 
 	return &providerv1.TaskResponse{
@@ -601,16 +865,7 @@ func (p *Provider) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotR
 	}
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "snapshot_revert")
 
 	// Complete revert after delay
 	go p.completeTaskAfterDelay(taskID, 4*time.Second)
@@ -658,16 +913,7 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 	p.mu.Unlock()
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "clone")
 
 	// Complete clone after delay
 	go p.completeTaskAfterDelay(taskID, 10*time.Second)
@@ -677,6 +923,8 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 		Task: &providerv1.TaskRef{
 			Id: taskID,
 		},
+		// The mock has no guest to customize; it accepts any customization.
+		CustomizationApplied: req.CustomizationJson != "",
 	}, nil
 }
 
@@ -692,16 +940,7 @@ func (p *Provider) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepar
 	}
 
 	// Create async task
-	taskID := p.generateID("task")
-	task := &Task{
-		ID:      taskID,
-		Done:    false,
-		Created: time.Now(),
-	}
-
-	p.mu.Lock()
-	p.tasks[taskID] = task
-	p.mu.Unlock()
+	taskID := p.startTask(ctx, "image_prepare")
 
 	// Complete image preparation after delay
 	go p.completeTaskAfterDelay(taskID, 15*time.Second)
@@ -718,6 +957,41 @@ func (p *Provider) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepar
 	}, nil
 }
 
+// PublishImage publishes a VM as an image. Like ImagePrepare it answers with
+// a synthetic pool path and an async task; republishing a name needs
+// overwrite.
+func (p *Provider) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	p.simulateDelay()
+
+	if p.shouldFail("publish_image") {
+		return nil, errors.NewInternal("mock provider configured to fail image operations", nil)
+	}
+	if req.GetImageName() == "" {
+		return nil, errors.NewInvalidSpec("image name is required")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.vms[req.GetVmId()]; !exists {
+		return nil, errors.NewNotFound("VirtualMachine", req.GetVmId())
+	}
+	if p.images[req.GetImageName()] && !req.GetOverwrite() {
+		return nil, errors.NewAlreadyExists("image", req.GetImageName())
+	}
+	p.images[req.GetImageName()] = true
+
+	taskID := p.startTask(ctx, "publish_image")
+	go p.completeTaskAfterDelay(taskID, 5*time.Second)
+
+	return &providerv1.PublishImageResponse{
+		Task:         &providerv1.TaskRef{Id: taskID},
+		ImageId:      req.GetImageName(),
+		ImagePath:    fmt.Sprintf("/var/lib/virtrigaud/mock/%s.qcow2", req.GetImageName()),
+		Checksum:     fmt.Sprintf("%064x", len(req.GetImageName())),
+		ChecksumType: "sha256",
+	}, nil
+}
+
 // GetCapabilities returns the provider's capabilities.
 func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
 	return p.capabilities.GetCapabilities(ctx, req)
@@ -730,15 +1004,39 @@ func (p *Provider) generateID(prefix string) string {
 	return fmt.Sprintf("%s-%d-%d", prefix, time.Now().Unix(), rand.Intn(10000))
 }
 
-// completeTaskAfterDelay completes a task after the specified delay.
+// startTask records a new async task and returns its ID. A task whose
+// record cannot be written is still returned; polling it then exercises
+// the unknown-task grace period.
+func (p *Provider) startTask(ctx context.Context, operation string) string {
+	taskID := p.generateID("task")
+	_ = p.tasks.Begin(ctx, tasks.Record{Ref: taskID, Operation: operation})
+	return taskID
+}
+
+// finishTask marks a task done.
+func (p *Provider) finishTask(taskID string) {
+	_ = p.tasks.Finish(context.Background(), taskID, nil)
+}
+
+// completeTaskAfterDelay completes a task after the specified delay, or
+// after MOCK_TASK_DURATION when that is set.
 func (p *Provider) completeTaskAfterDelay(taskID string, delay time.Duration) {
+	if p.taskDuration > 0 {
+		delay = p.taskDuration
+	}
 	time.Sleep(delay)
-	p.mu.Lock()
-	if task, exists := p.tasks[taskID]; exists {
-		task.Done = true
-		task.Completed = time.Now()
+	p.finishTask(taskID)
+}
+
+// taskDurationFromEnv reads MOCK_TASK_DURATION, e.g. "60s", which stretches
+// every async task so tests can disrupt one while it runs. An unset or
+// malformed value keeps the usual delays.
+func taskDurationFromEnv() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("MOCK_TASK_DURATION"))
+	if err != nil || d < 0 {
+		return 0
 	}
-	p.mu.Unlock()
+	return d
 }
 
 // shouldFail checks if the provider should fail for the given operation.
@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
+)
+
+func TestTaskStatusAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := tasks.NewFileStore(dir)
+	require.NoError(t, err)
+	p := NewProviderWithTaskStore(store)
+	running := p.startTask(ctx, "create")
+	finished := p.startTask(ctx, "delete")
+	p.finishTask(finished)
+
+	// A new process with the same store still knows both tasks.
+	store, err = tasks.NewFileStore(dir)
+	require.NoError(t, err)
+	restarted := NewProviderWithTaskStore(store)
+
+	resp, err := restarted.TaskStatus(ctx, &providerv1.TaskStatusRequest{Task: &providerv1.TaskRef{Id: running}})
+	require.NoError(t, err)
+	assert.False(t, resp.Done)
+	resp, err = restarted.TaskStatus(ctx, &providerv1.TaskStatusRequest{Task: &providerv1.TaskRef{Id: finished}})
+	require.NoError(t, err)
+	assert.True(t, resp.Done)
+
+	assert.NoError(t, tasks.CheckUnknownRef(ctx, restarted, "task-0-0"))
+}
+
+func TestCreateIsIdempotent(t *testing.T) {
+	p := NewProvider()
+	id, err := conformance.CheckIdempotentCreate(context.Background(), p, &providerv1.CreateRequest{
+		Name:           "retried",
+		IdempotencyKey: "3f0c6a52-uid",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "retried", p.vms[id].Name)
+}
+
+func TestDescribeReportsIdentity(t *testing.T) {
+	p := NewProvider()
+	_, err := conformance.CheckIdentityRoundTrip(context.Background(), p, &providerv1.CreateRequest{
+		Name:           "marked",
+		IdempotencyKey: "3f0c6a52-0b1d-4c2a-9e7f-1234567890ab",
+	})
+	require.NoError(t, err)
+}
+
+func TestTaskDurationOverride(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("MOCK_TASK_DURATION", "10ms")
+	p := NewProvider()
+	taskID := p.startTask(ctx, "clone")
+	p.completeTaskAfterDelay(taskID, time.Hour)
+	resp, err := p.TaskStatus(ctx, &providerv1.TaskStatusRequest{Task: &providerv1.TaskRef{Id: taskID}})
+	require.NoError(t, err)
+	assert.True(t, resp.Done)
+
+	t.Setenv("MOCK_TASK_DURATION", "soon")
+	assert.Zero(t, NewProvider().taskDuration)
+}
+
+func TestCreateBusyOverCloneLimit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("MOCK_TASK_DURATION", "50ms")
+	t.Setenv(common.MaxConcurrentClonesEnv, "1")
+	p := NewProvider()
+
+	first, err := p.Create(ctx, &providerv1.CreateRequest{Name: "first", IdempotencyKey: "first-uid"})
+	require.NoError(t, err)
+
+	// The first create's task holds the only slot.
+	_, err = p.Create(ctx, &providerv1.CreateRequest{Name: "second"})
+	_, busy := errors.IsBusy(err)
+	assert.True(t, busy)
+
+	// A retry of the first create is answered without a slot.
+	retried, err := p.Create(ctx, &providerv1.CreateRequest{Name: "first", IdempotencyKey: "first-uid"})
+	require.NoError(t, err)
+	assert.Equal(t, first.Id, retried.Id)
+
+	require.Eventually(t, func() bool {
+		_, err := p.Create(ctx, &providerv1.CreateRequest{Name: "second"})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDescribeDuringPowerOn(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider()
+
+	created, err := p.Create(ctx, &providerv1.CreateRequest{Name: "polled"})
+	require.NoError(t, err)
+	_, err = p.Power(ctx, &providerv1.PowerRequest{Id: created.Id, Op: providerv1.PowerOp_POWER_OP_ON})
+	require.NoError(t, err)
+
+	// Polling while the power task runs must not race with it (go test -race).
+	var desc *providerv1.DescribeResponse
+	require.Eventually(t, func() bool {
+		desc, err = p.Describe(ctx, &providerv1.DescribeRequest{Id: created.Id})
+		require.NoError(t, err)
+		return desc.PowerState == "On" && desc.GuestStats != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NotEmpty(t, desc.Ips)
+}
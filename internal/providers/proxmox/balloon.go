@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// pveDefaultMemoryMiB is the memory PVE gives a VM whose config has none.
+const pveDefaultMemoryMiB = 512
+
+// memoryChange is how a VM is brought to a new memory size.
+type memoryChange struct {
+	// memory is the new PVE `memory`, the most the guest can hold; nil
+	// keeps it.
+	memory *int64
+	// balloon is the new balloon target; nil keeps it.
+	balloon *int64
+	// powerCycle reports that the running guest keeps its old size until
+	// it is power cycled.
+	powerCycle bool
+}
+
+// planMemoryChange decides how to resize a VM to targetMiB. PVE's `memory`
+// is the guest's ceiling: below it, a running guest whose balloon driver is
+// loaded is resized online by moving the balloon target, and `memory` stays
+// as headroom to grow back into. Going past the ceiling needs memory
+// hotplug. Anything else is written to the config, where PVE holds it as
+// pending until the next power cycle. A stopped VM just takes the new size.
+func (p *Provider) planMemoryChange(ctx context.Context, node string, vmid int, currentConfig map[string]interface{}, targetMiB int64) memoryChange {
+	maxMiB, ok := configInt(currentConfig["memory"])
+	if !ok || maxMiB <= 0 {
+		maxMiB = pveDefaultMemoryMiB
+	}
+	// Without a balloon key PVE attaches a balloon device targeting the
+	// full memory; balloon=0 removes the device.
+	balloonMiB, hasBalloon := configInt(currentConfig["balloon"])
+	balloonDevice := !hasBalloon || balloonMiB > 0
+
+	change := memoryChange{memory: &targetMiB}
+	// PVE rejects a balloon target above memory.
+	if hasBalloon && balloonMiB > targetMiB {
+		change.balloon = &targetMiB
+	}
+
+	vm, err := p.client.GetVM(ctx, node, vmid)
+	if err != nil || vm.Status != "running" {
+		return change
+	}
+
+	if targetMiB <= maxMiB && balloonDevice && vm.BalloonInfo != nil {
+		p.logger.Info("Resizing the memory balloon", "vmid", vmid, "targetMiB", targetMiB, "maxMiB", maxMiB)
+		return memoryChange{balloon: &targetMiB}
+	}
+	if hotplugSet(currentConfig)["memory"] {
+		return change
+	}
+
+	var reason string
+	switch {
+	case targetMiB > maxMiB:
+		reason = "exceeds the VM's maximum memory and memory hotplug is off"
+	case !balloonDevice:
+		reason = "the balloon device is disabled"
+	default:
+		reason = "the guest balloon driver is not running"
+	}
+	p.logger.Info("Memory change cannot be applied online", "vmid", vmid, "targetMiB", targetMiB, "maxMiB", maxMiB, "reason", reason)
+	change.powerCycle = true
+	return change
+}
+
+// hotplugSet returns the VM's enabled hotplug features. PVE's default is
+// "network,disk,usb".
+func hotplugSet(currentConfig map[string]interface{}) map[string]bool {
+	hotplug, _ := currentConfig["hotplug"].(string)
+	if hotplug == "" {
+		hotplug = "network,disk,usb"
+	}
+	enabled := map[string]bool{}
+	for _, h := range strings.Split(hotplug, ",") {
+		enabled[strings.TrimSpace(h)] = true
+	}
+	return enabled
+}
+
+// configInt reads an integer VM config value, which PVE returns as a
+// number or, for some keys and versions, a string.
+func configInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
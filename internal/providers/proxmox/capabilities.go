@@ -27,6 +27,9 @@ func GetProviderCapabilities() *capabilities.Manager {
 		Core().
 		Snapshots().
 		MemorySnapshots().
+		// PVE freezes the guest's filesystems for snapshots of VMs with the
+		// guest agent enabled; SnapshotCreate pings the agent first.
+		QuiescedSnapshots().
 		LinkedClones().
 		OnlineReconfigure().
 		OnlineDiskExpansion().
@@ -55,6 +58,23 @@ func GetProviderCapabilities() *capabilities.Manager {
 		// transfer mode as informational and exempts it from the relay check, so
 		// advertising relay (for the S3 path) remains accurate.
 		TransferModes(migration.RelayOnlyTransferModes()...).
+		// Console output is a short live capture of the serial0 socket over
+		// SSH; VMs without `serial0: socket` answer InvalidSpec.
+		ConsoleOutput().
+		// The graphical console is a vncproxy session reached over PVE's
+		// vncwebsocket endpoint; it needs an API token.
+		ConsoleProxy().
+		// Windows answer files reach the guest on an ISO built over SSH.
+		Sysprep().
+		// PublishImage full-clones the VM (or one of its snapshots) and
+		// converts the clone into a template.
+		ImagePublish().
+		// cloud-init status runs through the guest agent exec API on VMs
+		// with agent: 1.
+		CloudInitStatus().
+		// WatchEvents polls the cluster task log for guest tasks started
+		// outside virtrigaud that failed.
+		EventWatch().
 		DiskTypes("raw", "qcow2").
 		NetworkTypes("bridge", "vlan").
 		Build()
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// GetCapacity reports CPU and memory per node from the PVE node status, and
+// the size of the storage new VM disks land on. Nodes that do not answer
+// are left out rather than failing the whole report.
+func (p *Provider) GetCapacity(ctx context.Context, req *providerv1.GetCapacityRequest) (*providerv1.GetCapacityResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	nodes, err := p.clusterNodes(ctx)
+	if err != nil {
+		return nil, errors.NewUnavailable("node discovery failed", err)
+	}
+
+	storage := p.profileStorage("")
+	resp := &providerv1.GetCapacityResponse{}
+	for _, node := range nodes {
+		status, err := p.inventory().NodeStatus(ctx, node)
+		if err != nil {
+			p.logger.Warn("Failed to get node status for capacity report", "node", node, "error", err)
+			continue
+		}
+		host := &providerv1.HostCapacity{
+			Name:             node,
+			CpuCores:         int32(status.CPUInfo.CPUs), // #nosec G115 -- CPU count fits int32
+			CpuUtilization:   status.CPU,
+			MemoryTotalBytes: status.Memory.Total,
+			MemoryUsedBytes:  status.Memory.Used,
+		}
+		if st, err := p.inventory().Storage(ctx, node, storage); err == nil {
+			host.StorageTotalBytes = st.Total
+			host.StorageUsedBytes = st.Used
+		} else {
+			p.logger.Debug("Storage status unavailable for capacity report", "node", node, "storage", storage, "error", err)
+		}
+		resp.Hosts = append(resp.Hosts, host)
+	}
+
+	if len(resp.Hosts) == 0 {
+		return nil, errors.NewUnavailable("no node reported its status", nil)
+	}
+	return resp, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TestProxmoxProvider_GetCapacity reports one entry per discovered node,
+// with the default VM storage's size attached.
+func TestProxmoxProvider_GetCapacity(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.GetCapacity(context.Background(), &providerv1.GetCapacityRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Hosts, 2, "the fake cluster has nodes pve and pve2")
+
+	host := resp.Hosts[0]
+	assert.Equal(t, "pve", host.Name)
+	assert.EqualValues(t, 8, host.CpuCores)
+	assert.InDelta(t, 0.25, host.CpuUtilization, 1e-9)
+	assert.Equal(t, int64(32)<<30, host.MemoryTotalBytes)
+	assert.Equal(t, int64(8)<<30, host.MemoryUsedBytes)
+	assert.Equal(t, int64(500)<<30, host.StorageTotalBytes)
+	assert.Equal(t, int64(125)<<30, host.StorageUsedBytes)
+}
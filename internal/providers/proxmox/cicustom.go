@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// defaultSnippetStorage is where cicustom snippets go unless
+// PROVIDER_SNIPPETS_STORAGE names another storage. A stock PVE install has
+// to have "snippets" added to its content types first.
+const defaultSnippetStorage = "local"
+
+// PVE's API can set cicustom but cannot upload snippets, so they are written
+// over the SSH data-plane transport into the path `pvesm path` reports for
+// the volume. SSH reaches the endpoint's node; in a cluster, VMs on other
+// nodes need the snippet storage to be shared.
+
+// cloudInitSnippet is one cicustom document: the cicustom key it is set
+// under, the volume it lives in, and its content.
+type cloudInitSnippet struct {
+	key     string
+	volume  string
+	content []byte
+}
+
+// cicustomKeys are the cicustom documents this provider manages. meta, and
+// user unless it is too large for the API, stay generated by PVE from
+// ciuser, sshkeys and friends.
+var cicustomKeys = []string{"user", "network", "vendor"}
+
+// userDataSnippetThreshold is the size above which user-data reaches the
+// guest whole, as a user snippet, instead of through the ciuser and sshkeys
+// options PVE builds its own user-data from. Options are API parameters and
+// lines of the VM's config file in pmxcfs, so a large user-data, e.g. one
+// with embedded files, is neither practical nor intact as options. Below it,
+// user-data needs no snippet storage.
+const userDataSnippetThreshold = 8 << 10
+
+// userDataSnippet returns the user-data to upload as a snippet: userData
+// when it is over userDataSnippetThreshold, otherwise nil.
+func userDataSnippet(userData []byte) []byte {
+	if len(userData) <= userDataSnippetThreshold {
+		return nil
+	}
+	return userData
+}
+
+// cloudInitSnippetVolume names vmid's snippet for a cicustom key. Names are
+// per VMID, so re-creating a VM overwrites its previous snippets.
+func cloudInitSnippetVolume(storage string, vmid int, key string) string {
+	return fmt.Sprintf("%s:snippets/virtrigaud-%d-%s.yaml", storage, vmid, key)
+}
+
+// cloudInitSnippets returns the snippets for vmid's user-data,
+// network-config and vendor-data, skipping empty documents.
+func cloudInitSnippets(storage string, vmid int, userData, networkConfig, vendorData []byte) []cloudInitSnippet {
+	var snippets []cloudInitSnippet
+	for i, content := range [][]byte{userData, networkConfig, vendorData} {
+		if len(content) == 0 {
+			continue
+		}
+		key := cicustomKeys[i]
+		snippets = append(snippets, cloudInitSnippet{
+			key:     key,
+			volume:  cloudInitSnippetVolume(storage, vmid, key),
+			content: content,
+		})
+	}
+	return snippets
+}
+
+// cicustomValue renders snippets as a cicustom option, e.g.
+// "network=local:snippets/virtrigaud-100-network.yaml".
+func cicustomValue(snippets []cloudInitSnippet) string {
+	parts := make([]string, 0, len(snippets))
+	for _, s := range snippets {
+		parts = append(parts, s.key+"="+s.volume)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (p *Provider) snippetStorageName() string {
+	if p.snippetStorage != "" {
+		return p.snippetStorage
+	}
+	return defaultSnippetStorage
+}
+
+// uploadCloudInitSnippets writes vmid's user-data, network-config and
+// vendor-data as snippets on node and returns the cicustom value
+// referencing them, or "" when none is given.
+func (p *Provider) uploadCloudInitSnippets(ctx context.Context, node string, vmid int, userData, networkConfig, vendorData []byte) (string, error) {
+	storage := p.snippetStorageName()
+	snippets := cloudInitSnippets(storage, vmid, userData, networkConfig, vendorData)
+	if len(snippets) == 0 {
+		return "", nil
+	}
+
+	status, err := p.inventory().Storage(ctx, node, storage)
+	if err != nil {
+		return "", errors.NewInvalidSpec("cloud-init %s need snippet storage %q on node %s: %v", snippetDocuments(snippets), storage, node, err)
+	}
+	if !status.SupportsContent("snippets") {
+		return "", errors.NewInvalidSpec(
+			"cloud-init %s need a storage that allows snippets, and %q on node %s allows only %q; "+
+				"add snippets to its content or set PROVIDER_SNIPPETS_STORAGE", snippetDocuments(snippets), storage, node, status.Content)
+	}
+	if p.ssh == nil {
+		return "", errors.NewUnavailable(
+			fmt.Sprintf("cloud-init %s are uploaded as snippets over SSH, and no SSH credentials are configured", snippetDocuments(snippets)), nil)
+	}
+
+	for _, s := range snippets {
+		stdout, stderr, err := p.ssh.runSSH(ctx, "pvesm path "+proxmoxShellQuote(s.volume))
+		if err != nil {
+			return "", errors.NewInternal(fmt.Sprintf("resolve snippet path for %s (stderr: %s)", s.volume, strings.TrimSpace(stderr)), err)
+		}
+		file := strings.TrimSpace(stdout)
+		write := fmt.Sprintf("mkdir -p %s && cat > %s", proxmoxShellQuote(path.Dir(file)), proxmoxShellQuote(file))
+		if err := p.ssh.runSSHStdin(ctx, bytes.NewReader(s.content), write); err != nil {
+			return "", errors.NewInternal(fmt.Sprintf("write snippet %s", s.volume), err)
+		}
+		p.logger.Info("Uploaded cloud-init snippet", "vmid", vmid, "volume", s.volume, "bytes", len(s.content))
+	}
+	return cicustomValue(snippets), nil
+}
+
+// snippetDocuments names the documents of snippets for errors, e.g.
+// "user-data/network-config".
+func snippetDocuments(snippets []cloudInitSnippet) string {
+	names := make([]string, 0, len(snippets))
+	for _, s := range snippets {
+		if s.key == "network" {
+			names = append(names, "network-config")
+		} else {
+			names = append(names, s.key+"-data")
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// removeCloudInitSnippets deletes vmid's snippets, if any, after the VM is
+// destroyed. Failures are logged only; a leftover snippet is overwritten
+// when the VMID is reused.
+func (p *Provider) removeCloudInitSnippets(ctx context.Context, vmid int) {
+	if p.ssh == nil {
+		return
+	}
+	var cmds []string
+	for _, key := range cicustomKeys {
+		volume := cloudInitSnippetVolume(p.snippetStorageName(), vmid, key)
+		cmds = append(cmds, fmt.Sprintf(`f=$(pvesm path %s 2>/dev/null) && rm -f "$f"`, proxmoxShellQuote(volume)))
+	}
+	cmds = append(cmds, "true")
+	if _, stderr, err := p.ssh.runSSH(ctx, strings.Join(cmds, "; ")); err != nil {
+		p.logger.Warn("Failed to remove cloud-init snippets", "vmid", vmid, "error", err, "stderr", strings.TrimSpace(stderr))
+	}
+}
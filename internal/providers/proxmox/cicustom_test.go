@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestCloudInitSnippets(t *testing.T) {
+	assert.Empty(t, cloudInitSnippets("local", 100, nil, nil, nil))
+
+	snippets := cloudInitSnippets("cephfs", 100, nil, []byte("version: 2"), []byte("#cloud-config"))
+	require.Len(t, snippets, 2)
+	assert.Equal(t, "network=cephfs:snippets/virtrigaud-100-network.yaml,vendor=cephfs:snippets/virtrigaud-100-vendor.yaml",
+		cicustomValue(snippets))
+
+	snippets = cloudInitSnippets("local", 101, nil, nil, []byte("#cloud-config"))
+	assert.Equal(t, "vendor=local:snippets/virtrigaud-101-vendor.yaml", cicustomValue(snippets))
+
+	snippets = cloudInitSnippets("local", 102, []byte("#cloud-config"), []byte("version: 2"), nil)
+	assert.Equal(t, "user=local:snippets/virtrigaud-102-user.yaml,network=local:snippets/virtrigaud-102-network.yaml",
+		cicustomValue(snippets))
+	assert.Equal(t, "user-data/network-config", snippetDocuments(snippets))
+}
+
+// largeUserData returns a cloud-config of exactly size bytes with one SSH
+// key, padded with a comment.
+func largeUserData(t *testing.T, size int) []byte {
+	t.Helper()
+	head := "#cloud-config\nssh_authorized_keys:\n  - ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey admin@example\n# "
+	require.Less(t, len(head), size)
+	return []byte(head + strings.Repeat("x", size-len(head)))
+}
+
+// TestParseCreateRequest_UserDataSnippetThreshold: up to the threshold,
+// user-data becomes ciuser/sshkeys options; one byte more and it goes whole
+// as a snippet, with no options PVE would build a competing user-data from.
+func TestParseCreateRequest_UserDataSnippetThreshold(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	for _, tc := range []struct {
+		size    int
+		snippet bool
+	}{
+		{size: userDataSnippetThreshold, snippet: false},
+		{size: userDataSnippetThreshold + 1, snippet: true},
+		{size: 512 << 10, snippet: true},
+	} {
+		userData := largeUserData(t, tc.size)
+		require.Len(t, userData, tc.size)
+		cfg, err := provider.parseCreateRequest(context.Background(), &providerv1.CreateRequest{Name: "big", UserData: userData})
+		require.NoError(t, err)
+		assert.NotEmpty(t, cfg.IDE2, "%d bytes: cloud-init drive", tc.size)
+		if tc.snippet {
+			assert.Equal(t, userData, userDataSnippet(userData), "%d bytes", tc.size)
+			assert.Empty(t, cfg.SSHKeys, "%d bytes", tc.size)
+		} else {
+			assert.Nil(t, userDataSnippet(userData), "%d bytes", tc.size)
+			assert.Contains(t, cfg.SSHKeys, "admin@example", "%d bytes", tc.size)
+		}
+	}
+}
+
+// TestProxmoxProvider_CreateLargeUserDataNeedsSnippetStorage: user-data
+// over the threshold is uploaded as a snippet, so like network-config it
+// needs snippet storage.
+func TestProxmoxProvider_CreateLargeUserDataNeedsSnippetStorage(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:      "big-user-data",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+		UserData:  largeUserData(t, 300<<10),
+	})
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), "cloud-init user-data need a storage that allows snippets")
+}
+
+// TestProxmoxProvider_CreateRejectsSnippetsWithoutSnippetStorage: the fake's
+// storages, like a stock PVE install, do not allow snippets, so a VM asking
+// for network-config fails as InvalidSpec before anything is created.
+func TestProxmoxProvider_CreateRejectsSnippetsWithoutSnippetStorage(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	cicustom, err := provider.uploadCloudInitSnippets(ctx, "pve", 100, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, cicustom, "nothing to upload needs no snippet storage")
+
+	_, err = provider.Create(ctx, &providerv1.CreateRequest{
+		Name:          "static-ip",
+		ClassJson:     `{"CPU":1,"MemoryMiB":1024}`,
+		NetworkConfig: []byte("version: 2\nethernets:\n  eth0:\n    addresses: [10.0.0.5/24]"),
+	})
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), "PROVIDER_SNIPPETS_STORAGE")
+
+	list, err := provider.ListVMs(ctx, &providerv1.ListVMsRequest{})
+	require.NoError(t, err)
+	for _, vm := range list.Vms {
+		assert.NotEqual(t, "static-ip", vm.Name)
+	}
+}
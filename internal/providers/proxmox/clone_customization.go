@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+)
+
+// reDriveKey matches the config keys of PVE drives, one of which holds the
+// cloud-init drive.
+var reDriveKey = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+
+// applyCloneCustomization sets the cloud-init options of a cloned VM from c.
+// PVE renders them onto the clone's cloud-init drive when it starts, so they
+// take effect on first boot. The clone inherits its cloud-init drive from the
+// source; without one there is nothing to customize.
+func (p *Provider) applyCloneCustomization(ctx context.Context, node string, vmid int, c *contracts.CloneCustomization) error {
+	current, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return fmt.Errorf("read the clone's config: %w", err)
+	}
+	if !hasCloudInitDrive(current) {
+		return fmt.Errorf("the clone has no cloud-init drive to customize; the source VM needs one")
+	}
+	values, err := cloneCustomizationValues(c, current)
+	if err != nil || len(values) == 0 {
+		return err
+	}
+
+	task, err := p.client.ReconfigureVMRaw(ctx, node, vmid, values)
+	if err != nil {
+		return fmt.Errorf("set the clone's cloud-init options: %w", err)
+	}
+	if task != "" {
+		if err := p.client.WaitForTask(ctx, node, task); err != nil {
+			return fmt.Errorf("set the clone's cloud-init options: %w", err)
+		}
+	}
+	p.logger.Info("Customized cloned VM", "vmid", vmid, "options", slices.Sorted(maps.Keys(values)))
+	return nil
+}
+
+// hasCloudInitDrive reports whether config has a cloud-init drive.
+func hasCloudInitDrive(config map[string]interface{}) bool {
+	for key, v := range config {
+		if s, ok := v.(string); ok && reDriveKey.MatchString(key) && strings.Contains(s, "cloudinit") {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneCustomizationValues converts c into PVE options, reading the clone's
+// current NICs from config. The hostname becomes the VM name, which PVE
+// reports to cloud-init as the host name. Like Create, only the user and SSH
+// keys of user-data reach the guest: PVE generates the user-data document
+// from ciuser and sshkeys.
+func cloneCustomizationValues(c *contracts.CloneCustomization, config map[string]interface{}) (url.Values, error) {
+	values := url.Values{}
+	if c.Hostname != "" {
+		values.Set("name", c.Hostname)
+	}
+	if c.Domain != "" {
+		values.Set("searchdomain", c.Domain)
+	}
+	if c.UserData != "" {
+		keys := cloudInitSSHKeys([]byte(c.UserData))
+		user := cloudInitUser(c.UserData)
+		if len(keys) == 0 && user == "" {
+			return nil, fmt.Errorf("userData sets no user or SSH key, the only parts of it PVE applies")
+		}
+		pveapi.SetSSHKeys(values, keys...)
+		if user != "" {
+			values.Set("ciuser", user)
+		}
+	}
+
+	var nameservers []string
+	for _, o := range c.Networks {
+		netKey := fmt.Sprintf("net%d", o.Index)
+		nic, _ := config[netKey].(string)
+		if nic == "" {
+			return nil, fmt.Errorf("network %q is NIC %d, but the clone has no %s", o.Name, o.Index, netKey)
+		}
+		if o.MACAddress != "" {
+			values.Set(netKey, nicWithMAC(nic, o.MACAddress))
+		}
+		switch {
+		case o.DHCP:
+			values.Set(fmt.Sprintf("ipconfig%d", o.Index), "ip=dhcp")
+		case o.IPAddress != "":
+			ipconfig := fmt.Sprintf("ip=%s/%d", o.IPAddress, o.Prefix)
+			if o.Gateway != "" {
+				ipconfig += ",gw=" + o.Gateway
+			}
+			values.Set(fmt.Sprintf("ipconfig%d", o.Index), ipconfig)
+		}
+		for _, server := range o.DNS {
+			if !slices.Contains(nameservers, server) {
+				nameservers = append(nameservers, server)
+			}
+		}
+	}
+	if len(nameservers) > 0 {
+		values.Set("nameserver", strings.Join(nameservers, " "))
+	}
+	return values, nil
+}
+
+// nicWithMAC returns a PVE netN value with its MAC set to mac. PVE writes
+// the MAC after the model ("virtio=BC:24:11:..."); a value with an explicit
+// macaddr option has it replaced instead.
+func nicWithMAC(nic, mac string) string {
+	mac = strings.ToUpper(mac)
+	parts := strings.Split(nic, ",")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "macaddr=") {
+			parts[i] = "macaddr=" + mac
+			return strings.Join(parts, ",")
+		}
+	}
+	model, _, _ := strings.Cut(parts[0], "=")
+	parts[0] = model + "=" + mac
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func customizationJSON(t *testing.T, c contracts.CloneCustomization) string {
+	t.Helper()
+	data, err := json.Marshal(c)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProxmoxProvider_CloneAppliesCustomization(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVM(&pvefake.VM{
+		VMID: 400, Name: "ci-source", Node: "pve", Status: "stopped",
+		Config:   map[string]string{"ide2": "local-lvm:vm-400-cloudinit,media=cdrom"},
+		Networks: []pvefake.NetworkConfig{{Model: "virtio", Bridge: "vmbr0", MAC: "BC:24:11:00:00:01"}},
+	})
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.Clone(context.Background(), &providerv1.CloneRequest{
+		SourceVmId: "400",
+		TargetName: "ci-clone",
+		CustomizationJson: customizationJSON(t, contracts.CloneCustomization{
+			Hostname: "web-01",
+			Domain:   "example.com",
+			UserData: "#cloud-config\nusers:\n  - name: ops\n    ssh_authorized_keys:\n      - ssh-ed25519 AAAA ops@example.com\n",
+			Networks: []contracts.CloneNetworkOverride{{
+				Index: 0, Name: "lan", IPAddress: "10.0.0.10", Prefix: 24, Gateway: "10.0.0.1",
+				DNS: []string{"10.0.0.2"}, MACAddress: "02:00:00:00:00:0a",
+			}},
+		}),
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.CustomizationApplied)
+	assert.Empty(t, resp.CustomizationError)
+
+	vmid, err := strconv.Atoi(resp.TargetVmId)
+	require.NoError(t, err)
+	config, err := provider.client.GetVMConfig(context.Background(), "pve", vmid)
+	require.NoError(t, err)
+	assert.Equal(t, "web-01", config["name"])
+	assert.Equal(t, "example.com", config["searchdomain"])
+	assert.Equal(t, "ops", config["ciuser"])
+	assert.Equal(t, "ip=10.0.0.10/24,gw=10.0.0.1", config["ipconfig0"])
+	assert.Equal(t, "10.0.0.2", config["nameserver"])
+	assert.Contains(t, config["net0"], "02:00:00:00:00:0A")
+}
+
+func TestProxmoxProvider_CloneWithoutCloudInitDriveReportsCustomizationError(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVM(&pvefake.VM{VMID: 401, Name: "plain-source", Node: "pve", Status: "stopped"})
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.Clone(context.Background(), &providerv1.CloneRequest{
+		SourceVmId:        "401",
+		TargetName:        "plain-clone",
+		CustomizationJson: customizationJSON(t, contracts.CloneCustomization{Hostname: "web-02"}),
+	})
+	require.NoError(t, err, "the clone is made even though it cannot be customized")
+	assert.NotEmpty(t, resp.TargetVmId)
+	assert.False(t, resp.CustomizationApplied)
+	assert.Contains(t, resp.CustomizationError, "no cloud-init drive")
+}
+
+func TestCloneCustomizationValues(t *testing.T) {
+	config := map[string]interface{}{"net0": "virtio=BC:24:11:00:00:01,bridge=vmbr0", "net1": "e1000,bridge=vmbr1"}
+
+	values, err := cloneCustomizationValues(&contracts.CloneCustomization{
+		Networks: []contracts.CloneNetworkOverride{
+			{Index: 0, Name: "lan", DHCP: true, DNS: []string{"1.1.1.1"}},
+			{Index: 1, Name: "mgmt", IPAddress: "192.168.1.5", Prefix: 24, DNS: []string{"1.1.1.1", "8.8.8.8"}},
+		},
+	}, config)
+	require.NoError(t, err)
+	assert.Equal(t, "ip=dhcp", values.Get("ipconfig0"))
+	assert.Equal(t, "ip=192.168.1.5/24", values.Get("ipconfig1"))
+	assert.Equal(t, "1.1.1.1 8.8.8.8", values.Get("nameserver"))
+	assert.False(t, values.Has("net0"), "a NIC without a MAC override is left alone")
+
+	_, err = cloneCustomizationValues(&contracts.CloneCustomization{
+		Networks: []contracts.CloneNetworkOverride{{Index: 2, Name: "storage", DHCP: true}},
+	}, config)
+	assert.ErrorContains(t, err, "no net2")
+
+	_, err = cloneCustomizationValues(&contracts.CloneCustomization{UserData: "#cloud-config\npackages: [nginx]\n"}, config)
+	assert.ErrorContains(t, err, "no user or SSH key")
+}
+
+func TestNicWithMAC(t *testing.T) {
+	assert.Equal(t, "virtio=02:00:00:00:00:0A,bridge=vmbr0",
+		nicWithMAC("virtio=BC:24:11:00:00:01,bridge=vmbr0", "02:00:00:00:00:0a"))
+	assert.Equal(t, "virtio,bridge=vmbr0,macaddr=02:00:00:00:00:0A",
+		nicWithMAC("virtio,bridge=vmbr0,macaddr=BC:24:11:00:00:01", "02:00:00:00:00:0a"))
+	assert.Equal(t, "e1000=02:00:00:00:00:0A,bridge=vmbr1", nicWithMAC("e1000,bridge=vmbr1", "02:00:00:00:00:0a"))
+}
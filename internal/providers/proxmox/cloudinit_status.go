@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// guestExecPollInterval is how often GetCloudInitStatus polls a running
+// guest command.
+var guestExecPollInterval = time.Second
+
+// agentEnabled reports whether the VM config enables the QEMU guest agent.
+// The option is either a bare flag ("1") or a property string whose first,
+// unnamed property or "enabled" property is the flag.
+func agentEnabled(config map[string]interface{}) bool {
+	raw := strings.TrimSpace(fmt.Sprint(config["agent"]))
+	for _, prop := range strings.Split(raw, ",") {
+		value := strings.TrimPrefix(prop, "enabled=")
+		if value != prop || !strings.Contains(prop, "=") {
+			return value == "1"
+		}
+	}
+	return false
+}
+
+// GetCloudInitStatus runs `cloud-init status` in the guest through the PVE
+// guest agent API. VMs without the agent enabled cannot report it; an agent
+// that does not answer yet is expected early in boot and reported as
+// Unavailable so the caller keeps asking.
+func (p *Provider) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	vmid, node, err := p.parseVMReference(req.Id)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+
+	config, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return nil, errors.NewInternal("failed to get VM config", err)
+	}
+	if !agentEnabled(config) {
+		return nil, errors.NewNotSupported("VM %d does not have the QEMU guest agent enabled (agent: 1)", vmid)
+	}
+
+	pid, err := p.client.AgentExec(ctx, node, vmid, []string{"/bin/sh", "-c", common.CloudInitStatusCommand})
+	if err != nil {
+		return nil, errors.NewUnavailable("QEMU guest agent", err)
+	}
+
+	// Allow a few seconds beyond the in-guest timeout for the API round trips.
+	deadline := time.Now().Add((common.CloudInitWaitSeconds + 5) * time.Second)
+	for {
+		st, err := p.client.AgentExecStatus(ctx, node, vmid, pid)
+		if err != nil {
+			return nil, errors.NewUnavailable("QEMU guest agent", err)
+		}
+		if st.Exited {
+			return common.ParseCloudInitStatus(st.ExitCode, st.OutData, st.ErrData)
+		}
+		if time.Now().After(deadline) {
+			return &providerv1.GetCloudInitStatusResponse{
+				Status: "running",
+				Detail: "cloud-init status did not return in time",
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.NewCanceled("GetCloudInitStatus")
+		case <-time.After(guestExecPollInterval):
+		}
+	}
+}
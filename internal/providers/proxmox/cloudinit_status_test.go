@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestAgentEnabled(t *testing.T) {
+	for raw, want := range map[interface{}]bool{
+		"1":                               true,
+		"0":                               false,
+		"enabled=1,fstrim_cloned_disks=1": true,
+		"fstrim_cloned_disks=1,enabled=0": false,
+		"1,type=virtio":                   true,
+		float64(1):                        true,
+	} {
+		assert.Equal(t, want, agentEnabled(map[string]interface{}{"agent": raw}), "agent: %v", raw)
+	}
+	assert.False(t, agentEnabled(map[string]interface{}{}))
+}
+
+func TestProxmoxProvider_GetCloudInitStatus(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	var gotCommand []string
+	srv.AddVM(&pvefake.VM{
+		VMID: 310, Name: "ci-done", Node: "pve", Status: "running",
+		Config: map[string]string{"agent": "1"},
+		GuestExec: func(command []string) (int, string, string) {
+			gotCommand = command
+			return 2, `{"status": "done", "extended_status": "degraded done", "errors": [],
+				"recoverable_errors": {"WARNING": ["Unknown key 'foo'"]}}`, ""
+		},
+	})
+
+	resp, err := provider.GetCloudInitStatus(context.Background(), &providerv1.GetCloudInitStatusRequest{Id: "310"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bin/sh", "-c", common.CloudInitStatusCommand}, gotCommand)
+	assert.Equal(t, "done", resp.Status)
+	assert.Equal(t, "degraded done", resp.Detail)
+	assert.Equal(t, []string{"WARNING: Unknown key 'foo'"}, resp.RecoverableErrors)
+}
+
+func TestProxmoxProvider_GetCloudInitStatus_NoAgent(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	srv.AddVM(&pvefake.VM{VMID: 311, Name: "ci-noagent", Node: "pve", Status: "running"})
+	srv.AddVM(&pvefake.VM{VMID: 312, Name: "ci-stopped", Node: "pve", Status: "stopped", Config: map[string]string{"agent": "1"}})
+
+	_, err = provider.GetCloudInitStatus(context.Background(), &providerv1.GetCloudInitStatusRequest{Id: "311"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = provider.GetCloudInitStatus(context.Background(), &providerv1.GetCloudInitStatusRequest{Id: "312"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
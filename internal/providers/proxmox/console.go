@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+const (
+	// consoleCaptureSeconds is how long GetConsoleOutput listens on the serial0
+	// socket. QEMU keeps no scrollback for a socket chardev, so the capture only
+	// contains what the guest writes (or redraws) while connected.
+	consoleCaptureSeconds = 3
+
+	// defaultConsoleTailLines is used when the caller passes tail_lines <= 0.
+	defaultConsoleTailLines = 200
+
+	// maxConsoleTailLines caps a single request.
+	maxConsoleTailLines = 5000
+)
+
+// serialSocketPath returns the QEMU chardev socket PVE creates for a VM whose
+// config has `serial0: socket` (the same socket `qm terminal` attaches to).
+func serialSocketPath(vmid int) string {
+	return fmt.Sprintf("/var/run/qemu-server/%d.serial0", vmid)
+}
+
+// hasSerialSocket reports whether the VM config wires serial0 to a socket.
+func hasSerialSocket(config map[string]interface{}) bool {
+	v, ok := config["serial0"].(string)
+	return ok && strings.TrimSpace(v) == "socket"
+}
+
+// GetConsoleOutput captures recent serial console output for a VM with a
+// `serial0: socket` device. The node-side socket is read over the provider's
+// SSH data plane with socat for a short window, so output reflects what the
+// guest emits while attached rather than full boot history. An interactive
+// `qm terminal` session on the same VM holds the socket and blocks capture.
+func (p *Provider) GetConsoleOutput(ctx context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+	if p.ssh == nil {
+		return nil, errors.NewUnavailable("SSH data plane not configured (set PROVIDER_ENDPOINT and SSH credentials)", nil)
+	}
+
+	vmid, node, err := p.parseVMReference(req.Id)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+
+	config, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return nil, errors.NewInternal("failed to get VM config", err)
+	}
+	if !hasSerialSocket(config) {
+		return nil, errors.NewInvalidSpec("VM %d has no serial0 socket; set serial0: socket to enable console capture", vmid)
+	}
+
+	n := int(req.TailLines)
+	if n <= 0 {
+		n = defaultConsoleTailLines
+	}
+	if n > maxConsoleTailLines {
+		n = maxConsoleTailLines
+	}
+
+	socket := serialSocketPath(vmid)
+	// timeout exits 124 once the window closes; the pipeline status is tail's,
+	// so a normal capture is not reported as an error. One extra line is read
+	// to detect truncation.
+	cmd := fmt.Sprintf("timeout %d socat -u UNIX-CONNECT:%s STDOUT | tail -n %d",
+		consoleCaptureSeconds, proxmoxShellQuote(socket), n+1)
+	stdout, stderr, err := p.ssh.runSSH(ctx, cmd)
+	if err != nil {
+		return nil, errors.NewInternal(fmt.Sprintf("failed to capture serial console: %s", strings.TrimSpace(stderr)), err)
+	}
+
+	lines := strings.SplitAfter(stdout, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	truncated := len(lines) > n
+	if truncated {
+		lines = lines[len(lines)-n:]
+	}
+
+	return &providerv1.GetConsoleOutputResponse{
+		Output:    strings.Join(lines, ""),
+		Truncated: truncated,
+		Source:    socket,
+	}, nil
+}
+
+// ConsoleTicket opens a VNC session on a running VM's display. The ticket
+// names PVE's vncwebsocket endpoint, authenticated with the provider's API
+// token; the session's VNC password is the PVE ticket itself. PVE drops an
+// unused session within seconds, so the proxy asks for it when the user
+// connects rather than ahead of time.
+func (p *Provider) ConsoleTicket(ctx context.Context, req *providerv1.ConsoleTicketRequest) (*providerv1.ConsoleTicketResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+	headers := p.client.AuthHeader()
+	if headers == nil {
+		return nil, errors.NewNotSupported("the console proxy needs an API token (PVE_TOKEN_ID and PVE_TOKEN_SECRET)")
+	}
+
+	vmid, node, err := p.parseVMReference(req.Id)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+	proxy, err := p.client.VNCProxy(ctx, node, vmid)
+	if err != nil {
+		return nil, errors.NewUnavailable(fmt.Sprintf("VNC console of VM %d", vmid), err)
+	}
+
+	config := p.client.Config()
+	return &providerv1.ConsoleTicketResponse{
+		Protocol:           contracts.ConsoleProtocolVNC,
+		Url:                p.client.VNCWebSocketURL(node, vmid, proxy),
+		Headers:            headers,
+		Password:           proxy.Ticket,
+		CaBundle:           config.CABundle,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestProxmoxProvider_ConsoleTicket(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	srv.AddVM(&pvefake.VM{VMID: 320, Name: "console", Node: "pve", Status: "running"})
+	srv.AddVM(&pvefake.VM{VMID: 321, Name: "console-stopped", Node: "pve", Status: "stopped"})
+
+	resp, err := provider.ConsoleTicket(context.Background(), &providerv1.ConsoleTicketRequest{Id: "320"})
+	require.NoError(t, err)
+	assert.Equal(t, "vnc", resp.Protocol)
+	assert.Equal(t, "PVEVNC:320::fake", resp.Password)
+	assert.Equal(t, "PVEAPIToken=test@pve!token=secret", resp.Headers["Authorization"])
+	assert.True(t, resp.InsecureSkipVerify)
+
+	u, err := url.Parse(resp.Url)
+	require.NoError(t, err)
+	assert.Contains(t, []string{"ws", "wss"}, u.Scheme)
+	assert.Equal(t, "/api2/json/nodes/pve/qemu/320/vncwebsocket", u.Path)
+	assert.Equal(t, "5920", u.Query().Get("port"))
+	assert.Equal(t, resp.Password, u.Query().Get("vncticket"))
+
+	_, err = provider.ConsoleTicket(context.Background(), &providerv1.ConsoleTicketRequest{Id: "321"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
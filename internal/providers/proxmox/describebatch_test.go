@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// TestProxmoxProvider_DescribeBatch describes a mix of running and stopped
+// VMs with one cluster/resources request plus one guest agent request per
+// running VM, and no per-VM status, snapshot or HA requests.
+func TestProxmoxProvider_DescribeBatch(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	var ids []string
+	for i := 0; i < 20; i++ {
+		vm := &pvefake.VM{VMID: 200 + i, Name: "batch-" + strconv.Itoa(i), Node: "pve2", Status: "stopped", Memory: 4 << 30}
+		if i%2 == 0 {
+			vm.Status = "running"
+			vm.CPU = 0.5
+			vm.Mem = 1 << 30
+			vm.Uptime = 3600
+			vm.IPAddrs = []string{"10.0.0." + strconv.Itoa(i+10)}
+		}
+		srv.AddVM(vm)
+		ids = append(ids, strconv.Itoa(vm.VMID))
+	}
+	ids = append(ids, "pve2:999", "not-a-vmid")
+
+	resp, err := provider.DescribeBatch(context.Background(), &providerv1.DescribeBatchRequest{Ids: ids})
+	require.NoError(t, err)
+
+	running := resp.Results["200"]
+	require.NotNil(t, running)
+	assert.True(t, running.Exists)
+	assert.Equal(t, "On", running.PowerState)
+	assert.Equal(t, []string{"10.0.0.10"}, running.Ips)
+	require.NotNil(t, running.GuestStats)
+	assert.InDelta(t, 50, running.GuestStats.GetCpuUsagePercent(), 1e-9)
+	assert.Equal(t, int64(1<<30), running.GuestStats.GetMemoryUsageBytes())
+	details, err := describe.Parse(running.ProviderRawJson)
+	require.NoError(t, err)
+	assert.Equal(t, "pve2", details.Host)
+	require.NotNil(t, details.Resources)
+	assert.Equal(t, running.GuestStats.CpuUsagePercent, details.Resources.CPUUsagePercent)
+
+	stopped := resp.Results["201"]
+	require.NotNil(t, stopped)
+	assert.Equal(t, "Off", stopped.PowerState)
+	assert.Empty(t, stopped.Ips)
+	assert.Nil(t, stopped.GuestStats)
+
+	require.NotNil(t, resp.Results["pve2:999"])
+	assert.False(t, resp.Results["pve2:999"].Exists)
+	assert.Contains(t, resp.Errors, "not-a-vmid")
+
+	assert.Equal(t, 1, srv.RequestCount("/cluster/resources"))
+	assert.Equal(t, 10, srv.RequestCount("/nodes/{node}/qemu/{vmid}/agent/network-get-interfaces"))
+	assert.Zero(t, srv.RequestCount("/nodes/{node}/qemu/{vmid}/status/current"))
+	assert.Zero(t, srv.RequestCount("/nodes/{node}/qemu/{vmid}/snapshot"))
+}
+
+// TestProxmoxProvider_DescribeBatchMatchesDescribe keeps the batch answer
+// for a VM in line with Describe's, apart from the details batch omits.
+func TestProxmoxProvider_DescribeBatchMatchesDescribe(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	want, err := provider.Describe(ctx, &providerv1.DescribeRequest{Id: "100"})
+	require.NoError(t, err)
+	resp, err := provider.DescribeBatch(ctx, &providerv1.DescribeBatchRequest{Ids: []string{"100"}})
+	require.NoError(t, err)
+	got := resp.Results["100"]
+	require.NotNil(t, got)
+
+	assert.Equal(t, want.Exists, got.Exists)
+	assert.Equal(t, want.PowerState, got.PowerState)
+	assert.Equal(t, want.Ips, got.Ips)
+	assert.Equal(t, want.ConsoleUrl, got.ConsoleUrl)
+	assert.Equal(t, want.GuestStats.GetCpuUsagePercent(), got.GuestStats.GetCpuUsagePercent())
+	assert.Equal(t, want.GuestStats.GetMemoryUsageBytes(), got.GuestStats.GetMemoryUsageBytes())
+}
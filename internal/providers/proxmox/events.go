@@ -0,0 +1,191 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// defaultEventPollInterval is how often WatchEvents reads the cluster task
+// log when PROVIDER_EVENT_POLL_INTERVAL is unset.
+const defaultEventPollInterval = 15 * time.Second
+
+// eventPollIntervalFromEnv reads PROVIDER_EVENT_POLL_INTERVAL, a Go
+// duration such as "30s". Unset or invalid values use the default.
+func eventPollIntervalFromEnv() time.Duration {
+	raw := strings.TrimSpace(os.Getenv("PROVIDER_EVENT_POLL_INTERVAL"))
+	if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+		return d
+	}
+	return defaultEventPollInterval
+}
+
+// WatchEvents reports guest tasks started outside virtrigaud, from the PVE
+// web UI, the CLI or another API client, that failed: a failed finished
+// task sends a TaskFailed warning for its VMID, and a later successful task
+// of the same type on that VM resolves it. The cluster task log is polled;
+// PVE keeps only its most recent entries, so since reaches back only as far
+// as they do.
+func (p *Provider) WatchEvents(req *providerv1.WatchEventsRequest, stream providerv1.Provider_WatchEventsServer) error {
+	if p.client == nil {
+		return status.Error(codes.FailedPrecondition, "PVE client not configured")
+	}
+	ctx := stream.Context()
+	w := newTaskEventWatcher(p.ownTaskOwner(), req.GetSinceUnix())
+
+	ticker := time.NewTicker(eventPollIntervalFromEnv())
+	defer ticker.Stop()
+	for {
+		tasks, err := p.client.ClusterTasks(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Keep the stream open: the next poll usually succeeds, and
+			// reconnecting would not help.
+			p.logger.Warn("Failed to read cluster tasks for events", "error", err)
+		} else {
+			for _, ev := range w.poll(tasks) {
+				if err := stream.Send(ev); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ownTaskOwner is the ClusterTask.Owner of tasks this provider starts.
+func (p *Provider) ownTaskOwner() string {
+	cfg := p.client.Config()
+	if cfg.TokenID != "" {
+		return cfg.TokenID
+	}
+	return cfg.Username
+}
+
+// taskEventWatcher turns successive reads of the cluster task log into
+// events. It is not safe for concurrent use.
+type taskEventWatcher struct {
+	owner string
+	since int64
+	// primed is set once the first read has been taken in.
+	primed bool
+	// seen are the UPIDs of finished tasks already handled. Entries are
+	// dropped once PVE no longer lists the task.
+	seen map[string]bool
+	// failed holds, per VMID, the task types whose last run failed.
+	failed map[string]map[string]bool
+}
+
+func newTaskEventWatcher(owner string, since int64) *taskEventWatcher {
+	return &taskEventWatcher{
+		owner:  owner,
+		since:  since,
+		seen:   map[string]bool{},
+		failed: map[string]map[string]bool{},
+	}
+}
+
+// poll returns the events for the finished guest tasks in tasks that
+// earlier polls have not seen. On the first poll only tasks that ended at
+// or after since count; a zero since counts none of them.
+func (w *taskEventWatcher) poll(tasks []pveapi.ClusterTask) []*providerv1.HypervisorEvent {
+	finished := make([]pveapi.ClusterTask, 0, len(tasks))
+	for _, t := range tasks {
+		if t.EndTime != 0 {
+			finished = append(finished, t)
+		}
+	}
+	sort.SliceStable(finished, func(i, j int) bool { return finished[i].EndTime < finished[j].EndTime })
+
+	var events []*providerv1.HypervisorEvent
+	seen := make(map[string]bool, len(finished))
+	for _, t := range finished {
+		seen[t.UPID] = true
+		if w.seen[t.UPID] {
+			continue
+		}
+		if !w.primed && (w.since == 0 || t.EndTime < w.since) {
+			continue
+		}
+		if ev := w.handle(t); ev != nil {
+			events = append(events, ev)
+		}
+	}
+	w.seen = seen
+	w.primed = true
+	return events
+}
+
+func (w *taskEventWatcher) handle(t pveapi.ClusterTask) *providerv1.HypervisorEvent {
+	if _, err := strconv.Atoi(t.ID); err != nil || t.Owner() == w.owner {
+		return nil
+	}
+	if taskSucceeded(t.Status) {
+		types := w.failed[t.ID]
+		if !types[t.Type] {
+			return nil
+		}
+		delete(types, t.Type)
+		if len(types) > 0 {
+			return nil
+		}
+		delete(w.failed, t.ID)
+		return &providerv1.HypervisorEvent{
+			VmId:     t.ID,
+			Severity: providerv1.EventSeverity_EVENT_SEVERITY_INFO,
+			Reason:   "TaskFailed",
+			Message:  fmt.Sprintf("%s on node %s by %s succeeded", t.Type, t.Node, t.Owner()),
+			TimeUnix: t.EndTime,
+			Resolved: true,
+		}
+	}
+	if w.failed[t.ID] == nil {
+		w.failed[t.ID] = map[string]bool{}
+	}
+	w.failed[t.ID][t.Type] = true
+	return &providerv1.HypervisorEvent{
+		VmId:     t.ID,
+		Severity: providerv1.EventSeverity_EVENT_SEVERITY_WARNING,
+		Reason:   "TaskFailed",
+		Message:  fmt.Sprintf("%s on node %s by %s failed: %s", t.Type, t.Node, t.Owner(), t.Status),
+		TimeUnix: t.EndTime,
+	}
+}
+
+// taskSucceeded reports whether a finished task's status is a success;
+// tasks that finished with warnings count as successful.
+func taskSucceeded(status string) bool {
+	return status == "OK" || strings.HasPrefix(status, "WARNINGS")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestTaskEventWatcher(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "100", User: "root@pam", StartTime: 1000, EndTime: 1001, Status: "start failed: old"})
+	w := newTaskEventWatcher(provider.ownTaskOwner(), 0)
+	tasks, err := provider.client.ClusterTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, w.poll(tasks), "a zero since sends new events only")
+
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "101", User: "root@pam", StartTime: 1100})
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "102", User: "test@pve", TokenID: "token", StartTime: 1100, EndTime: 1101, Status: "start failed: ours"})
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "vzdump", ID: "local", User: "root@pam", StartTime: 1100, EndTime: 1101, Status: "backup failed"})
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve2", Type: "qmigrate", ID: "100", User: "admin@pve", StartTime: 1100, EndTime: 1150, Status: "migration aborted"})
+	tasks, err = provider.client.ClusterTasks(ctx)
+	require.NoError(t, err)
+	events := w.poll(tasks)
+	require.Len(t, events, 1, "running tasks, tasks virtrigaud started and non-guest tasks are skipped")
+	assert.Equal(t, &providerv1.HypervisorEvent{
+		VmId:     "100",
+		Severity: providerv1.EventSeverity_EVENT_SEVERITY_WARNING,
+		Reason:   "TaskFailed",
+		Message:  "qmigrate on node pve2 by admin@pve failed: migration aborted",
+		TimeUnix: 1150,
+	}, events[0])
+
+	tasks, err = provider.client.ClusterTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, w.poll(tasks), "a task is reported once")
+
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "100", User: "root@pam", StartTime: 1200, EndTime: 1201, Status: "OK"})
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve2", Type: "qmigrate", ID: "100", User: "admin@pve", StartTime: 1300, EndTime: 1360, Status: "OK"})
+	tasks, err = provider.client.ClusterTasks(ctx)
+	require.NoError(t, err)
+	events = w.poll(tasks)
+	require.Len(t, events, 1, "only a success of the failed task type resolves")
+	assert.True(t, events[0].Resolved)
+	assert.Equal(t, "TaskFailed", events[0].Reason)
+	assert.Equal(t, int64(1360), events[0].TimeUnix)
+}
+
+func TestTaskEventWatcher_Since(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "100", User: "root@pam", StartTime: 1000, EndTime: 1001, Status: "old"})
+	srv.AddClusterTask(pvefake.ClusterTask{Node: "pve", Type: "qmstart", ID: "101", User: "root@pam", StartTime: 2000, EndTime: 2001, Status: "new"})
+	tasks, err := provider.client.ClusterTasks(context.Background())
+	require.NoError(t, err)
+
+	events := newTaskEventWatcher(provider.ownTaskOwner(), 1500).poll(tasks)
+	require.Len(t, events, 1)
+	assert.Equal(t, "101", events[0].VmId)
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// securityGroupComment marks the firewall group rules the provider manages.
+// Rules without it were added by someone else and are never removed.
+const securityGroupComment = "managed by virtrigaud"
+
+// validateNetworks checks that the SDN vnets and security groups the NICs
+// name exist before the VM is created, so a typo fails with the choices
+// rather than with a half-configured VM.
+func (p *Provider) validateNetworks(ctx context.Context, networks []pveapi.NetworkConfig) error {
+	var vnets, groups []string
+	for _, n := range networks {
+		if n.VNet != "" {
+			vnets = append(vnets, n.VNet)
+		}
+		groups = append(groups, n.SecurityGroups...)
+	}
+
+	if len(vnets) > 0 {
+		available, err := p.client.ListSDNVNets(ctx)
+		if err != nil {
+			return errors.NewInternal("failed to list SDN vnets", err)
+		}
+		for _, vnet := range vnets {
+			if !slices.Contains(available, vnet) {
+				return notFoundAmong("Proxmox SDN vnet", vnet, "vnets", "availableVNets", available)
+			}
+		}
+	}
+
+	if len(groups) > 0 {
+		available, err := p.client.ListSecurityGroups(ctx)
+		if err != nil {
+			return errors.NewInternal("failed to list firewall security groups", err)
+		}
+		for _, group := range groups {
+			if !slices.Contains(available, group) {
+				return notFoundAmong("Proxmox firewall security group", group, "security groups", "availableSecurityGroups", available)
+			}
+		}
+	}
+	return nil
+}
+
+// hasSecurityGroups reports whether any NIC asks for security groups.
+func hasSecurityGroups(networks []pveapi.NetworkConfig) bool {
+	for _, n := range networks {
+		if len(n.SecurityGroups) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applySecurityGroups applies the security groups of a new VM's NICs.
+func (p *Provider) applySecurityGroups(ctx context.Context, node string, vmid int, networks []pveapi.NetworkConfig) error {
+	if !hasSecurityGroups(networks) {
+		return nil
+	}
+	desired := make(map[string][]string, len(networks))
+	for _, n := range networks {
+		desired[fmt.Sprintf("net%d", n.Index)] = n.SecurityGroups
+	}
+	return p.syncSecurityGroups(ctx, node, vmid, desired)
+}
+
+// syncSecurityGroups makes the managed group rules of each interface in
+// desired, keyed by interface ("net0"), match its groups: stale rules are
+// deleted and missing ones added. Interfaces not in desired are left alone.
+// The VM firewall is switched on when any group is applied, as its rules
+// have no effect otherwise.
+func (p *Provider) syncSecurityGroups(ctx context.Context, node string, vmid int, desired map[string][]string) error {
+	rules, err := p.client.ListVMFirewallRules(ctx, node, vmid)
+	if err != nil {
+		return err
+	}
+
+	present := map[string][]string{}
+	var stale []int
+	for _, rule := range rules {
+		want, managed := desired[rule.Iface]
+		if rule.Type != "group" || rule.Comment != securityGroupComment || !managed {
+			continue
+		}
+		if slices.Contains(want, rule.Action) && !slices.Contains(present[rule.Iface], rule.Action) {
+			present[rule.Iface] = append(present[rule.Iface], rule.Action)
+			continue
+		}
+		stale = append(stale, rule.Pos)
+	}
+
+	// Deleting a rule moves the ones after it up, so go from the back.
+	sort.Sort(sort.Reverse(sort.IntSlice(stale)))
+	for _, pos := range stale {
+		if err := p.client.DeleteVMFirewallRule(ctx, node, vmid, pos); err != nil {
+			return err
+		}
+	}
+
+	ifaces := make([]string, 0, len(desired))
+	for iface := range desired {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Strings(ifaces)
+
+	applied := false
+	for _, iface := range ifaces {
+		for _, group := range desired[iface] {
+			applied = true
+			if slices.Contains(present[iface], group) {
+				continue
+			}
+			if err := p.client.AddVMFirewallGroupRule(ctx, node, vmid, group, iface, securityGroupComment); err != nil {
+				return err
+			}
+		}
+	}
+	if !applied {
+		return nil
+	}
+
+	enabled, err := p.client.GetVMFirewallEnabled(ctx, node, vmid)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return p.client.EnableVMFirewall(ctx, node, vmid)
+	}
+	return nil
+}
+
+// describeFirewall adds the VM firewall's state and the security groups
+// applied to each interface, as "net0=web,ssh;net1=db", to a Describe's
+// provider details. Both are informational, so lookup failures are logged
+// and skipped.
+func (p *Provider) describeFirewall(ctx context.Context, node string, vmid int, raw map[string]string) {
+	enabled, err := p.client.GetVMFirewallEnabled(ctx, node, vmid)
+	if err != nil {
+		p.logger.Debug("Failed to get firewall options for describe", "error", err)
+		return
+	}
+	raw["firewall"] = "disabled"
+	if enabled {
+		raw["firewall"] = "enabled"
+	}
+
+	rules, err := p.client.ListVMFirewallRules(ctx, node, vmid)
+	if err != nil {
+		p.logger.Debug("Failed to list firewall rules for describe", "error", err)
+		return
+	}
+	groups := map[string][]string{}
+	for _, rule := range rules {
+		if rule.Type == "group" && rule.Enable == 1 {
+			iface := rule.Iface
+			if iface == "" {
+				iface = "all"
+			}
+			groups[iface] = append(groups[iface], rule.Action)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	ifaces := make([]string, 0, len(groups))
+	for iface := range groups {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Strings(ifaces)
+	entries := make([]string, 0, len(ifaces))
+	for _, iface := range ifaces {
+		entries = append(entries, iface+"="+strings.Join(groups[iface], ","))
+	}
+	raw["security_groups"] = strings.Join(entries, ";")
+}
+
+// reconfigureSecurityGroups applies the ChangeSet's security group changes.
+// They name networks, which are mapped to interfaces by their position in
+// the desired networks; a network not found there is skipped. An interface
+// given groups gets the firewall flag it needs for them to take effect.
+func (p *Provider) reconfigureSecurityGroups(ctx context.Context, node string, vmid int, currentConfig map[string]interface{}, desiredJSON string, groupChanges []*providerv1.SecurityGroupChange) error {
+	var desired contracts.CreateRequest
+	if err := contracts.UnmarshalPayload([]byte(desiredJSON), &desired); err != nil {
+		return errors.NewInvalidSpec("failed to parse desired configuration: %v", err)
+	}
+
+	var networks []pveapi.NetworkConfig
+	for _, change := range groupChanges {
+		index := slices.IndexFunc(desired.Networks, func(n contracts.NetworkAttachment) bool { return n.Name == change.Network })
+		if index < 0 {
+			p.logger.Warn("Skipping security groups of a network not in the desired configuration", "vmid", vmid, "network", change.Network)
+			continue
+		}
+		networks = append(networks, pveapi.NetworkConfig{Index: index, SecurityGroups: change.Groups})
+	}
+	if len(networks) == 0 {
+		return nil
+	}
+	if err := p.validateNetworks(ctx, networks); err != nil {
+		return err
+	}
+
+	values := url.Values{}
+	desiredGroups := make(map[string][]string, len(networks))
+	for _, n := range networks {
+		iface := fmt.Sprintf("net%d", n.Index)
+		desiredGroups[iface] = n.SecurityGroups
+		current, _ := currentConfig[iface].(string)
+		if len(n.SecurityGroups) > 0 && current != "" && !strings.Contains(current, "firewall=1") {
+			values.Set(iface, withFirewallFlag(current))
+		}
+	}
+	if len(values) > 0 {
+		taskID, err := p.client.ReconfigureVMRaw(ctx, node, vmid, values)
+		if err != nil {
+			return errors.NewInternal("failed to enable the interface firewall", err)
+		}
+		if err := p.client.WaitForTask(ctx, node, taskID); err != nil {
+			return errors.NewInternal("failed waiting for reconfigure task", err)
+		}
+	}
+	if err := p.syncSecurityGroups(ctx, node, vmid, desiredGroups); err != nil {
+		return errors.NewInternal("failed to apply firewall security groups", err)
+	}
+	return nil
+}
+
+// withFirewallFlag sets firewall=1 in a netN config string.
+func withFirewallFlag(netConfig string) string {
+	parts := strings.Split(netConfig, ",")
+	parts = slices.DeleteFunc(parts, func(part string) bool { return strings.HasPrefix(part, "firewall=") })
+	return strings.Join(append(parts, "firewall=1"), ",")
+}
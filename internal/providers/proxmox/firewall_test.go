@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func networksJSON(t *testing.T, networks ...contracts.NetworkAttachment) string {
+	t.Helper()
+	data, err := json.Marshal(networks)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProxmoxProvider_CreateAttachesVNetWithSecurityGroups(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVNet("tenant1")
+	server.AddSecurityGroup("web")
+	server.AddSecurityGroup("ssh")
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	resp, err := provider.Create(ctx, &providerv1.CreateRequest{
+		Name:      "fenced",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+		NetworksJson: networksJSON(t,
+			contracts.NetworkAttachment{Name: "mgmt", Bridge: "vmbr0"},
+			contracts.NetworkAttachment{Name: "app", VNet: "tenant1", SecurityGroups: []string{"web", "ssh"}},
+		),
+	})
+	require.NoError(t, err)
+	vmid, err := strconv.Atoi(resp.Id)
+	require.NoError(t, err)
+
+	config, err := provider.client.GetVMConfig(ctx, "pve", vmid)
+	require.NoError(t, err)
+	assert.NotContains(t, config["net0"], "firewall=1")
+	assert.Contains(t, config["net1"], "bridge=tenant1")
+	assert.Contains(t, config["net1"], "firewall=1")
+
+	assert.True(t, server.FirewallEnabled(vmid))
+	rules := server.FirewallRules(vmid)
+	require.Len(t, rules, 2)
+	for _, rule := range rules {
+		assert.Equal(t, "group", rule.Type)
+		assert.Equal(t, "net1", rule.Iface)
+		assert.True(t, rule.Enable)
+	}
+
+	raw := describeRaw(t, provider, resp.Id)
+	assert.Equal(t, "enabled", raw["firewall"])
+	assert.Equal(t, "net1=ssh,web", raw["security_groups"])
+}
+
+func TestProxmoxProvider_CreateRejectsUnknownVNetAndSecurityGroup(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVNet("tenant1")
+	server.AddSecurityGroup("web")
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	_, err = provider.Create(ctx, &providerv1.CreateRequest{
+		Name:         "lost",
+		NetworksJson: networksJSON(t, contracts.NetworkAttachment{Name: "app", VNet: "tenant9"}),
+	})
+	assert.Equal(t, codes.NotFound, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), "available vnets: tenant1")
+
+	_, err = provider.Create(ctx, &providerv1.CreateRequest{
+		Name:         "lost",
+		NetworksJson: networksJSON(t, contracts.NetworkAttachment{Name: "app", SecurityGroups: []string{"db"}}),
+	})
+	assert.Equal(t, codes.NotFound, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), "available security groups: web")
+}
+
+func TestProxmoxProvider_ReconfigureSyncsSecurityGroups(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddSecurityGroup("web")
+	server.AddSecurityGroup("ssh")
+	server.AddSecurityGroup("db")
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	attachment := contracts.NetworkAttachment{Name: "app", Bridge: "vmbr1", SecurityGroups: []string{"web", "ssh"}}
+	resp, err := provider.Create(ctx, &providerv1.CreateRequest{
+		Name:         "fenced",
+		NetworksJson: networksJSON(t, attachment),
+	})
+	require.NoError(t, err)
+	vmid, err := strconv.Atoi(resp.Id)
+	require.NoError(t, err)
+
+	// A rule added by hand is not the provider's to remove.
+	rules := append(server.FirewallRules(vmid), pvefake.FirewallRule{Type: "group", Action: "web", Iface: "net0", Enable: true, Comment: "ops"})
+	server.SetFirewallRules(vmid, rules)
+
+	attachment.SecurityGroups = []string{"web", "db"}
+	desired, err := json.Marshal(contracts.CreateRequest{Name: "fenced", Networks: []contracts.NetworkAttachment{attachment}})
+	require.NoError(t, err)
+	_, err = provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id:          resp.Id,
+		DesiredJson: string(desired),
+		Changes: &providerv1.ChangeSet{SecurityGroups: []*providerv1.SecurityGroupChange{
+			{Network: "app", Groups: []string{"web", "db"}},
+		}},
+	})
+	require.NoError(t, err)
+
+	var managed []string
+	handAdded := 0
+	for _, rule := range server.FirewallRules(vmid) {
+		if rule.Comment == securityGroupComment {
+			managed = append(managed, rule.Action)
+		} else {
+			handAdded++
+		}
+	}
+	assert.ElementsMatch(t, []string{"web", "db"}, managed)
+	assert.Equal(t, 1, handAdded)
+
+	// Removing every group leaves only the hand-added rule.
+	_, err = provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id:          resp.Id,
+		DesiredJson: string(desired),
+		Changes: &providerv1.ChangeSet{SecurityGroups: []*providerv1.SecurityGroupChange{
+			{Network: "app"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []pvefake.FirewallRule{{Type: "group", Action: "web", Iface: "net0", Enable: true, Comment: "ops"}}, server.FirewallRules(vmid))
+
+	// An unknown group is NotFound rather than a PVE error.
+	_, err = provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id:          resp.Id,
+		DesiredJson: string(desired),
+		Changes: &providerv1.ChangeSet{SecurityGroups: []*providerv1.SecurityGroupChange{
+			{Network: "app", Groups: []string{"cache"}},
+		}},
+	})
+	assert.Equal(t, codes.NotFound, s3GRPCCode(t, err))
+}
+
+func TestWithFirewallFlag(t *testing.T) {
+	assert.Equal(t, "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=1", withFirewallFlag("virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0"))
+	assert.Equal(t, "virtio,bridge=vmbr0,tag=5,firewall=1", withFirewallFlag("virtio,bridge=vmbr0,firewall=0,tag=5"))
+}
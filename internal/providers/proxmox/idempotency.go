@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// idempotencyTagPrefix starts the PVE tag that records the idempotency key
+// of the Create that made a VM.
+const idempotencyTagPrefix = "virtrigaud-"
+
+// detachedCreateTimeout bounds a keyed Create once it no longer follows its
+// caller's deadline.
+const detachedCreateTimeout = 30 * time.Minute
+
+// createFlight is a keyed Create in progress. done is closed once resp and
+// err are set.
+type createFlight struct {
+	done chan struct{}
+	resp *providerv1.CreateResponse
+	err  error
+}
+
+// idempotencyTag returns the tag recording key. PVE tags are lowercase
+// letters, digits and "_-+."; a Kubernetes UID already fits, and any other
+// character is replaced with "-".
+func idempotencyTag(key string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-', r == '+', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, key)
+	return idempotencyTagPrefix + mapped
+}
+
+// vmIdentity returns the idempotency key recorded in a guest's tags, as
+// idempotencyTag mapped it, or "" when no tag records one.
+func vmIdentity(tags string) string {
+	for _, tag := range pveapi.SplitTags(tags) {
+		if key, ok := strings.CutPrefix(tag, idempotencyTagPrefix); ok && key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// createIdempotent serves a Create that carries an idempotency key. A
+// request whose key matches a Create still running in this process waits
+// for that Create's result; otherwise a VM already tagged with the key (made
+// by an attempt whose response was lost, possibly before a restart) is
+// returned; only if neither exists is a VM created. The creation itself
+// runs detached from the caller's deadline, so a client that times out on a
+// slow template clone and retries picks up the finished VM instead of
+// abandoning it half-built.
+func (p *Provider) createIdempotent(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
+	key := req.IdempotencyKey
+
+	p.flightsMu.Lock()
+	if f, ok := p.createFlights[key]; ok {
+		p.flightsMu.Unlock()
+		p.logger.Info("Create with this idempotency key is in progress, waiting for it", "name", req.Name, "key", key)
+		select {
+		case <-f.done:
+			return f.resp, f.err
+		case <-ctx.Done():
+			return nil, errors.NewUnavailable("Proxmox VE create still in progress", ctx.Err())
+		}
+	}
+	f := &createFlight{done: make(chan struct{})}
+	if p.createFlights == nil {
+		p.createFlights = make(map[string]*createFlight)
+	}
+	p.createFlights[key] = f
+	p.flightsMu.Unlock()
+
+	defer func() {
+		p.flightsMu.Lock()
+		delete(p.createFlights, key)
+		p.flightsMu.Unlock()
+		close(f.done)
+	}()
+
+	detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), detachedCreateTimeout)
+	defer cancel()
+	f.resp, f.err = p.lookupOrCreate(detached, req)
+	return f.resp, f.err
+}
+
+// lookupOrCreate returns the VM tagged with req's idempotency key, making
+// sure it is under HA management as requested, or creates it.
+func (p *Provider) lookupOrCreate(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
+	tag := idempotencyTag(req.IdempotencyKey)
+	existing, err := p.findTaggedVM(ctx, tag)
+	if err != nil {
+		return nil, errors.NewUnavailable("Proxmox VE cluster resources", err)
+	}
+	if existing == nil {
+		return p.create(ctx, req, tag)
+	}
+
+	p.logger.Info("VM for this idempotency key already exists, returning it",
+		"vmid", existing.VMID, "node", existing.Node, "name", req.Name)
+	p.inventory().RecordVM(existing.VMID, existing.Node)
+	pl, err := p.resolvePlacement(req.PlacementJson)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ensureHA(ctx, existing.VMID, pl.HA); err != nil {
+		return nil, errors.NewInternal("failed to add VM to HA manager", err)
+	}
+	return &providerv1.CreateResponse{Id: fmt.Sprintf("%d", existing.VMID)}, nil
+}
+
+// findTaggedVM returns the guest carrying tag, or nil. It reads the cluster
+// resources directly rather than the inventory cache, which may predate
+// the VM.
+func (p *Provider) findTaggedVM(ctx context.Context, tag string) (*pveapi.ClusterVM, error) {
+	vms, err := p.client.ClusterVMs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vms {
+		if vms[i].Type != "lxc" && vms[i].HasTag(tag) {
+			return &vms[i], nil
+		}
+	}
+	return nil, nil
+}
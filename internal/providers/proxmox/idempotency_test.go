@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+)
+
+func TestIdempotencyTag(t *testing.T) {
+	assert.Equal(t, "virtrigaud-3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab",
+		idempotencyTag("3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab"))
+	assert.Equal(t, "virtrigaud-ns-vm-1", idempotencyTag("NS/vm 1"))
+}
+
+func TestProxmoxProvider_CreateIsIdempotent(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	id, err := conformance.CheckIdempotentCreate(context.Background(), provider, &providerv1.CreateRequest{
+		Name:           "keyed",
+		ClassJson:      `{"CPU":1,"MemoryMiB":1024}`,
+		IdempotencyKey: "0d5e7c2a-uid",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+}
+
+func TestProxmoxProvider_DescribeReportsIdentity(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = conformance.CheckIdentityRoundTrip(context.Background(), provider, &providerv1.CreateRequest{
+		Name:           "marked",
+		ClassJson:      `{"CPU":1,"MemoryMiB":1024}`,
+		IdempotencyKey: "3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab", vmIdentity("prod;virtrigaud-3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab"))
+	assert.Empty(t, vmIdentity("prod;web"))
+}
+
+func TestProxmoxProvider_RetriedCloneReturnsFirstVM(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	req := &providerv1.CreateRequest{
+		Name:           "slow-clone",
+		ImageJson:      `{"TemplateName":"9000"}`,
+		IdempotencyKey: "7a1c-uid",
+	}
+
+	// The first attempt's caller gives up long before the clone task
+	// finishes; the clone carries on regardless.
+	first := make(chan *providerv1.CreateResponse, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		resp, err := provider.Create(ctx, req)
+		assert.NoError(t, err)
+		first <- resp
+	}()
+	time.Sleep(300 * time.Millisecond)
+
+	// The retry joins the running clone instead of allocating a VMID.
+	retried, err := provider.Create(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, (<-first).GetId(), retried.Id)
+
+	// After a provider restart the key is found through the VM's tag.
+	restarted, err := createTestProvider(endpoint).Create(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, retried.Id, restarted.Id)
+
+	list, err := provider.ListVMs(context.Background(), &providerv1.ListVMsRequest{})
+	require.NoError(t, err)
+	named := 0
+	for _, vm := range list.Vms {
+		if vm.Name == req.Name {
+			named++
+		}
+	}
+	assert.Equal(t, 1, named)
+}
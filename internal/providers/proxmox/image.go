@@ -74,7 +74,7 @@ type proxmoxImageSource struct {
 	// defaultProxmoxStorage.
 	Storage string
 	// Node is the source-preferred Proxmox node the template lives on / is imported
-	// to; falls back to the client's FindNode default.
+	// to; falls back to the provider's findNode default.
 	Node string
 	// Format is the disk format of the imported image (raw/qcow2/vmdk); defaults to
 	// defaultProxmoxImageFormat when unset.
@@ -159,10 +159,10 @@ func parseProxmoxImageSource(imageJSON string) proxmoxImageSource {
 		}
 	}
 
-	// Shape 2: flat contracts.VMImage (Go field names, no JSON tags). It can only
-	// express a TemplateName (existing template) or a URL (import) for Proxmox.
+	// Shape 2: flat contracts.VMImage. It can only express a TemplateName
+	// (existing template) or a URL (import) for Proxmox.
 	var img contracts.VMImage
-	if err := json.Unmarshal([]byte(imageJSON), &img); err == nil {
+	if err := contracts.UnmarshalPayload([]byte(imageJSON), &img); err == nil {
 		if img.TemplateName != "" || img.URL != "" {
 			src.TemplateName = img.TemplateName
 			src.URL = img.URL
@@ -173,30 +173,26 @@ func parseProxmoxImageSource(imageJSON string) proxmoxImageSource {
 	return src
 }
 
-// resolveImageStorage selects the Proxmox storage to import into, in priority
-// order: the request StorageHint, then source.proxmox.storage, then the provider
-// default. This mirrors the libvirt/vSphere hint-or-default behavior while
-// additionally honoring the image's own storage preference.
+// resolveImageStorage returns the Proxmox storage an import asks for: the
+// request StorageHint, then source.proxmox.storage. Empty means neither names
+// one and selectStorage falls back to the preference list or the provider
+// default.
 func resolveImageStorage(storageHint, sourceStorage string) string {
-	switch {
-	case strings.TrimSpace(storageHint) != "":
-		return strings.TrimSpace(storageHint)
-	case strings.TrimSpace(sourceStorage) != "":
-		return strings.TrimSpace(sourceStorage)
-	default:
-		return defaultProxmoxStorage
+	if hint := strings.TrimSpace(storageHint); hint != "" {
+		return hint
 	}
+	return strings.TrimSpace(sourceStorage)
 }
 
 // resolveImageNode selects the Proxmox node, preferring the source-supplied node
-// and falling back to the client's FindNode default. A FindNode failure is only
+// and falling back to the provider's findNode default. A findNode failure is only
 // surfaced when no source node was given, so an explicit node short-circuits node
 // discovery entirely.
 func (p *Provider) resolveImageNode(ctx context.Context, sourceNode string) (string, error) {
 	if n := strings.TrimSpace(sourceNode); n != "" {
 		return n, nil
 	}
-	node, err := p.client.FindNode(ctx)
+	node, err := p.findNode(ctx)
 	if err != nil {
 		return "", errors.NewInternal("ImagePrepare: find Proxmox node", err)
 	}
@@ -345,7 +341,14 @@ func (p *Provider) imagePrepareImport(ctx context.Context, node, targetName stri
 		return imagePrepareDone(targetName), nil
 	}
 
-	storage := resolveImageStorage(storageHint, src.Storage)
+	storage, err := p.selectStorage(ctx, storageRequest{
+		node:     node,
+		hint:     resolveImageStorage(storageHint, src.Storage),
+		fallback: p.profileStorage(""),
+	})
+	if err != nil {
+		return nil, err
+	}
 	format := strings.TrimSpace(src.Format)
 	if format == "" {
 		format = defaultProxmoxImageFormat
@@ -368,7 +371,7 @@ func (p *Provider) imagePrepareImport(ctx context.Context, node, targetName stri
 	// completes, but it never has to re-discover the template name.
 	result := imagePrepareDone(targetName)
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 	return result, nil
 }
@@ -144,11 +144,12 @@ func TestParseProxmoxImageSource_Empty(t *testing.T) {
 	}
 }
 
-// TestResolveImageStorage verifies the hint > source > default precedence.
+// TestResolveImageStorage verifies the hint > source precedence; with neither
+// the default is left to selectStorage.
 func TestResolveImageStorage(t *testing.T) {
 	assert.Equal(t, "hint", resolveImageStorage("hint", "src"))
 	assert.Equal(t, "src", resolveImageStorage("", "src"))
 	assert.Equal(t, "src", resolveImageStorage("   ", "src"))
-	assert.Equal(t, defaultProxmoxStorage, resolveImageStorage("", ""))
-	assert.Equal(t, defaultProxmoxStorage, resolveImageStorage("  ", "  "))
+	assert.Empty(t, resolveImageStorage("", ""))
+	assert.Empty(t, resolveImageStorage("  ", "  "))
 }
@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/projectbeskar/virtrigaud/internal/version"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// GetInfo reports the provider build and the PVE release from /version.
+func (p *Provider) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	v, err := p.client.GetVersion(ctx)
+	if err != nil {
+		return nil, errors.NewUnavailable("failed to read PVE version", err)
+	}
+
+	return &providerv1.GetInfoResponse{
+		ProviderVersion:   version.Version,
+		GitSha:            version.GitSHA,
+		HypervisorProduct: "Proxmox VE",
+		HypervisorVersion: v.Version,
+		HypervisorDetails: fmt.Sprintf("release %s, repoid %s", v.Release, v.RepoID),
+	}, nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	"github.com/projectbeskar/virtrigaud/internal/version"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TestProxmoxProvider_GetInfo reports the PVE release from /version
+// alongside the provider's own build.
+func TestProxmoxProvider_GetInfo(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.GetInfo(context.Background(), &providerv1.GetInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, version.Version, resp.ProviderVersion)
+	assert.Equal(t, version.GitSHA, resp.GitSha)
+	assert.Equal(t, "Proxmox VE", resp.HypervisorProduct)
+	assert.Equal(t, "8.2.4", resp.HypervisorVersion)
+	assert.Equal(t, "release 8.2, repoid faa83925c9641325", resp.HypervisorDetails)
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+)
+
+// defaultInventoryTTL is how long node and storage lookups are served from
+// the inventory before PVE is asked again.
+const defaultInventoryTTL = 30 * time.Second
+
+// inventorySource is the part of the PVE API the inventory caches.
+// *pveapi.Client implements it.
+type inventorySource interface {
+	ListNodes(ctx context.Context) ([]string, error)
+	GetNodeStatus(ctx context.Context, node string) (*pveapi.NodeStatus, error)
+	ListStorages(ctx context.Context, node string) ([]pveapi.NodeStorage, error)
+	ClusterVMs(ctx context.Context) ([]pveapi.ClusterVM, error)
+}
+
+// cached is a value and when it was fetched.
+type cached[T any] struct {
+	value     T
+	fetchedAt time.Time
+}
+
+// inventory caches the cluster's node list, per-node status and storages,
+// and which node each VMID lives on, so that RPCs do not each pay one or
+// more PVE round trips just to find their way. Entries expire after ttl and
+// everything is dropped when PVE answers in a way that suggests the cluster
+// changed under us (see observeServerError).
+//
+// Fetches run without the lock held, so two callers missing at once may
+// both ask PVE; the later answer wins.
+type inventory struct {
+	src inventorySource
+	ttl time.Duration
+	now func() time.Time
+
+	mu           sync.Mutex
+	nodes        *cached[[]string]
+	nodeStatus   map[string]cached[*pveapi.NodeStatus]
+	storages     map[string]cached[[]pveapi.NodeStorage]
+	vmNodes      map[int]string
+	vmPools      map[int]string
+	vmNodesAt    time.Time
+	generation   uint64
+	hits, misses atomic.Int64
+}
+
+func newInventory(src inventorySource, ttl time.Duration) *inventory {
+	if ttl <= 0 {
+		ttl = defaultInventoryTTL
+	}
+	return &inventory{
+		src:        src,
+		ttl:        ttl,
+		now:        time.Now,
+		nodeStatus: map[string]cached[*pveapi.NodeStatus]{},
+		storages:   map[string]cached[[]pveapi.NodeStorage]{},
+	}
+}
+
+// inventoryTTLFromEnv reads PROVIDER_INVENTORY_TTL (or PVE_INVENTORY_TTL),
+// a Go duration such as "30s". Unset or invalid values use the default.
+func inventoryTTLFromEnv() time.Duration {
+	raw := os.Getenv("PROVIDER_INVENTORY_TTL")
+	if raw == "" {
+		raw = os.Getenv("PVE_INVENTORY_TTL")
+	}
+	if ttl, err := time.ParseDuration(strings.TrimSpace(raw)); err == nil && ttl > 0 {
+		return ttl
+	}
+	return defaultInventoryTTL
+}
+
+func (inv *inventory) fresh(fetchedAt time.Time) bool {
+	return inv.now().Sub(fetchedAt) < inv.ttl
+}
+
+// hit and miss count lookups served from the cache and from PVE.
+func (inv *inventory) hit()  { inv.hits.Add(1) }
+func (inv *inventory) miss() { inv.misses.Add(1) }
+
+// stats returns the hit and miss counts so far. The provider has no metrics
+// registry yet; these are what it would export.
+func (inv *inventory) stats() (hits, misses int64) {
+	return inv.hits.Load(), inv.misses.Load()
+}
+
+// Nodes returns the names of the cluster's nodes.
+func (inv *inventory) Nodes(ctx context.Context) ([]string, error) {
+	inv.mu.Lock()
+	if inv.nodes != nil && inv.fresh(inv.nodes.fetchedAt) {
+		nodes := inv.nodes.value
+		inv.mu.Unlock()
+		inv.hit()
+		return nodes, nil
+	}
+	gen := inv.generation
+	inv.mu.Unlock()
+
+	inv.miss()
+	nodes, err := inv.src.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.mu.Lock()
+	if gen == inv.generation {
+		inv.nodes = &cached[[]string]{value: nodes, fetchedAt: inv.now()}
+		// Forget nodes that left the cluster.
+		for node := range inv.nodeStatus {
+			if !slices.Contains(nodes, node) {
+				delete(inv.nodeStatus, node)
+				delete(inv.storages, node)
+			}
+		}
+	}
+	inv.mu.Unlock()
+	return nodes, nil
+}
+
+// NodeStatus returns the CPU and memory status of node.
+func (inv *inventory) NodeStatus(ctx context.Context, node string) (*pveapi.NodeStatus, error) {
+	inv.mu.Lock()
+	if entry, ok := inv.nodeStatus[node]; ok && inv.fresh(entry.fetchedAt) {
+		inv.mu.Unlock()
+		inv.hit()
+		return entry.value, nil
+	}
+	gen := inv.generation
+	inv.mu.Unlock()
+
+	inv.miss()
+	status, err := inv.src.GetNodeStatus(ctx, node)
+	if err != nil {
+		inv.forgetNode(node)
+		return nil, err
+	}
+
+	inv.mu.Lock()
+	if gen == inv.generation {
+		inv.nodeStatus[node] = cached[*pveapi.NodeStatus]{value: status, fetchedAt: inv.now()}
+	}
+	inv.mu.Unlock()
+	return status, nil
+}
+
+// Storages returns the storages configured on node.
+func (inv *inventory) Storages(ctx context.Context, node string) ([]pveapi.NodeStorage, error) {
+	inv.mu.Lock()
+	if entry, ok := inv.storages[node]; ok && inv.fresh(entry.fetchedAt) {
+		inv.mu.Unlock()
+		inv.hit()
+		return entry.value, nil
+	}
+	gen := inv.generation
+	inv.mu.Unlock()
+
+	inv.miss()
+	storages, err := inv.src.ListStorages(ctx, node)
+	if err != nil {
+		inv.forgetNode(node)
+		return nil, err
+	}
+
+	inv.mu.Lock()
+	if gen == inv.generation {
+		inv.storages[node] = cached[[]pveapi.NodeStorage]{value: storages, fetchedAt: inv.now()}
+	}
+	inv.mu.Unlock()
+	return storages, nil
+}
+
+// Storage returns the status of one storage on node, or an error if the node
+// does not have it.
+func (inv *inventory) Storage(ctx context.Context, node, name string) (*pveapi.StorageStatus, error) {
+	storages, err := inv.Storages(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	for i := range storages {
+		if storages[i].Storage == name {
+			status := storages[i].StorageStatus
+			return &status, nil
+		}
+	}
+	return nil, fmt.Errorf("storage %q not found on node %s", name, node)
+}
+
+// VMNode returns the node VMID vmid lives on. An unknown VMID, or a stale
+// index, refreshes the index from /cluster/resources once; ok is false if
+// the VMID is still unknown after that.
+func (inv *inventory) VMNode(ctx context.Context, vmid int) (node string, ok bool, err error) {
+	inv.mu.Lock()
+	if inv.vmNodes != nil && inv.fresh(inv.vmNodesAt) {
+		if node, ok := inv.vmNodes[vmid]; ok {
+			inv.mu.Unlock()
+			inv.hit()
+			return node, true, nil
+		}
+	}
+	gen := inv.generation
+	inv.mu.Unlock()
+
+	nodes, _, err := inv.refreshVMs(ctx, gen)
+	if err != nil {
+		return "", false, err
+	}
+	node, ok = nodes[vmid]
+	return node, ok, nil
+}
+
+// VMPool returns the resource pool VMID vmid belongs to, "" for none. It
+// is served from the same /cluster/resources index as VMNode, so pool
+// changes made on PVE show up within the TTL.
+func (inv *inventory) VMPool(ctx context.Context, vmid int) (string, error) {
+	inv.mu.Lock()
+	if inv.vmPools != nil && inv.fresh(inv.vmNodesAt) {
+		if pool, ok := inv.vmPools[vmid]; ok {
+			inv.mu.Unlock()
+			inv.hit()
+			return pool, nil
+		}
+	}
+	gen := inv.generation
+	inv.mu.Unlock()
+
+	_, pools, err := inv.refreshVMs(ctx, gen)
+	if err != nil {
+		return "", err
+	}
+	return pools[vmid], nil
+}
+
+// refreshVMs rebuilds the VMID index from /cluster/resources, returning
+// each QEMU guest's node and pool.
+func (inv *inventory) refreshVMs(ctx context.Context, gen uint64) (nodes, pools map[int]string, err error) {
+	inv.miss()
+	vms, err := inv.src.ClusterVMs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes = make(map[int]string, len(vms))
+	pools = make(map[int]string, len(vms))
+	for _, vm := range vms {
+		if vm.Type == "" || vm.Type == "qemu" {
+			nodes[vm.VMID] = vm.Node
+			pools[vm.VMID] = vm.Pool
+		}
+	}
+
+	inv.mu.Lock()
+	if gen == inv.generation {
+		inv.vmNodes = nodes
+		inv.vmPools = pools
+		inv.vmNodesAt = inv.now()
+	}
+	inv.mu.Unlock()
+	return nodes, pools, nil
+}
+
+// RecordVM notes that vmid now lives on node, e.g. right after creating it.
+func (inv *inventory) RecordVM(vmid int, node string) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if inv.vmNodes != nil {
+		inv.vmNodes[vmid] = node
+	}
+}
+
+// ForgetVM drops vmid from the index, e.g. after deleting it.
+func (inv *inventory) ForgetVM(vmid int) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	delete(inv.vmNodes, vmid)
+	delete(inv.vmPools, vmid)
+}
+
+// forgetNode drops what is cached about one node after a lookup on it failed.
+func (inv *inventory) forgetNode(node string) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	delete(inv.nodeStatus, node)
+	delete(inv.storages, node)
+}
+
+// Invalidate drops everything. Fetches already in flight do not repopulate
+// the cache with what they read.
+func (inv *inventory) Invalidate() {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.generation++
+	inv.nodes = nil
+	inv.nodeStatus = map[string]cached[*pveapi.NodeStatus]{}
+	inv.storages = map[string]cached[[]pveapi.NodeStorage]{}
+	inv.vmNodes = nil
+	inv.vmPools = nil
+}
+
+// observeServerError drops cached inventory that a PVE error shows to be
+// stale. A 595 is pveproxy failing to reach the node a request was routed
+// to; together with an offline or unknown node it means the node set may
+// have changed, so everything goes. A guest config that "does not exist" only
+// says the VMID index may be wrong, e.g. after a migration.
+func (inv *inventory) observeServerError(status int, body string) {
+	msg := strings.ToLower(body)
+	switch {
+	case status == 595 || containsAny(msg, "offline", "no such node", "hostname lookup"):
+		inv.Invalidate()
+	case strings.Contains(msg, "does not exist"):
+		inv.mu.Lock()
+		inv.vmNodes = nil
+		inv.vmPools = nil
+		inv.mu.Unlock()
+	}
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// inventory returns the provider's inventory of its current client, making
+// a new one if the client was replaced since the last call.
+func (p *Provider) inventory() *inventory {
+	p.invMu.Lock()
+	defer p.invMu.Unlock()
+	if p.inv == nil || p.invClient != p.client {
+		inv := newInventory(p.client, p.inventoryTTL)
+		p.client.SetServerErrorHook(inv.observeServerError)
+		p.inv, p.invClient = inv, p.client
+	}
+	return p.inv
+}
+
+// clusterNodes returns the configured node selector or, without one, every
+// node in the cluster.
+func (p *Provider) clusterNodes(ctx context.Context) ([]string, error) {
+	if nodes := p.client.Config().NodeSelector; len(nodes) > 0 {
+		return nodes, nil
+	}
+	nodes, err := p.inventory().Nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("the cluster reports no nodes")
+	}
+	return nodes, nil
+}
+
+// findNode picks the node new VMs and images go to: the first node of the
+// selector, or the first node in the cluster.
+func (p *Provider) findNode(ctx context.Context) (string, error) {
+	nodes, err := p.clusterNodes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("no NodeSelector configured and node discovery failed: %w", err)
+	}
+	return nodes[0], nil
+}
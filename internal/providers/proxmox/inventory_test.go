@@ -0,0 +1,300 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+)
+
+// fakeInventorySource is an in-memory cluster that counts the calls made
+// to it.
+type fakeInventorySource struct {
+	mu       sync.Mutex
+	nodes    []string
+	vms      map[int]string
+	calls    map[string]int
+	failNode string
+}
+
+func newFakeInventorySource(nodes ...string) *fakeInventorySource {
+	return &fakeInventorySource{nodes: nodes, vms: map[int]string{}, calls: map[string]int{}}
+}
+
+func (f *fakeInventorySource) count(call string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[call]
+}
+
+func (f *fakeInventorySource) setNodes(nodes ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodes = nodes
+}
+
+func (f *fakeInventorySource) ListNodes(context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["nodes"]++
+	return append([]string(nil), f.nodes...), nil
+}
+
+func (f *fakeInventorySource) GetNodeStatus(_ context.Context, node string) (*pveapi.NodeStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["status"]++
+	if node == f.failNode {
+		return nil, fmt.Errorf("node %s offline", node)
+	}
+	status := &pveapi.NodeStatus{CPU: 0.5}
+	status.CPUInfo.CPUs = 8
+	return status, nil
+}
+
+func (f *fakeInventorySource) ListStorages(_ context.Context, node string) ([]pveapi.NodeStorage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["storages"]++
+	return []pveapi.NodeStorage{{
+		Storage:       "local-lvm",
+		StorageStatus: pveapi.StorageStatus{Content: "images", Active: 1, Total: 100},
+	}}, nil
+}
+
+func (f *fakeInventorySource) ClusterVMs(context.Context) ([]pveapi.ClusterVM, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["vms"]++
+	var vms []pveapi.ClusterVM
+	for vmid, node := range f.vms {
+		vms = append(vms, pveapi.ClusterVM{VMID: vmid, Node: node, Type: "qemu"})
+	}
+	return vms, nil
+}
+
+// testInventory returns an inventory over src with a clock the test moves
+// by hand.
+func testInventory(src inventorySource) (*inventory, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	inv := newInventory(src, 30*time.Second)
+	inv.now = func() time.Time { return now }
+	return inv, &now
+}
+
+func TestInventory_NodesAcrossTTL(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeInventorySource("pve1", "pve2")
+	inv, now := testInventory(src)
+
+	nodes, err := inv.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pve1", "pve2"}, nodes)
+
+	// A node joins, but within the TTL the cached list is served.
+	src.setNodes("pve1", "pve2", "pve3")
+	*now = now.Add(29 * time.Second)
+	nodes, err = inv.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pve1", "pve2"}, nodes)
+	assert.Equal(t, 1, src.count("nodes"))
+
+	*now = now.Add(2 * time.Second)
+	nodes, err = inv.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pve1", "pve2", "pve3"}, nodes)
+
+	// A node leaves: once the list is refreshed its cached status goes too.
+	_, err = inv.NodeStatus(ctx, "pve2")
+	require.NoError(t, err)
+	src.setNodes("pve1", "pve3")
+	*now = now.Add(31 * time.Second)
+	nodes, err = inv.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pve1", "pve3"}, nodes)
+	_, err = inv.NodeStatus(ctx, "pve2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.count("status"), "the departed node's status was dropped")
+
+	hits, misses := inv.stats()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(5), misses)
+}
+
+func TestInventory_StatusAndStorage(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeInventorySource("pve1")
+	inv, now := testInventory(src)
+
+	for range 3 {
+		status, err := inv.NodeStatus(ctx, "pve1")
+		require.NoError(t, err)
+		assert.Equal(t, 8, status.CPUInfo.CPUs)
+		st, err := inv.Storage(ctx, "pve1", "local-lvm")
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), st.Total)
+	}
+	assert.Equal(t, 1, src.count("status"))
+	assert.Equal(t, 1, src.count("storages"))
+
+	_, err := inv.Storage(ctx, "pve1", "ceph")
+	assert.ErrorContains(t, err, `storage "ceph" not found on node pve1`)
+	assert.Equal(t, 1, src.count("storages"), "a missing storage is answered from the cached list")
+
+	*now = now.Add(time.Minute)
+	_, err = inv.NodeStatus(ctx, "pve1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.count("status"))
+
+	src.failNode = "pve1"
+	*now = now.Add(time.Minute)
+	_, err = inv.NodeStatus(ctx, "pve1")
+	assert.Error(t, err)
+}
+
+func TestInventory_ServerErrorInvalidates(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		body        string
+		wantNodes   bool // node list refetched
+		wantVMIndex bool // VMID index refetched
+	}{
+		{name: "595", status: 595, body: "no route to host", wantNodes: true, wantVMIndex: true},
+		{name: "node offline", status: 500, body: `{"errors":"node 'pve2' seems to be offline"}`, wantNodes: true, wantVMIndex: true},
+		{name: "missing guest", status: 500, body: "Configuration file 'nodes/pve1/qemu-server/101.conf' does not exist", wantVMIndex: true},
+		{name: "unrelated", status: 500, body: "VM is locked (backup)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			src := newFakeInventorySource("pve1", "pve2")
+			src.vms[101] = "pve1"
+			inv, _ := testInventory(src)
+			_, err := inv.Nodes(ctx)
+			require.NoError(t, err)
+			_, _, err = inv.VMNode(ctx, 101)
+			require.NoError(t, err)
+
+			inv.observeServerError(tc.status, tc.body)
+
+			_, err = inv.Nodes(ctx)
+			require.NoError(t, err)
+			_, _, err = inv.VMNode(ctx, 101)
+			require.NoError(t, err)
+			assert.Equal(t, map[bool]int{false: 1, true: 2}[tc.wantNodes], src.count("nodes"))
+			assert.Equal(t, map[bool]int{false: 1, true: 2}[tc.wantVMIndex], src.count("vms"))
+		})
+	}
+}
+
+func TestInventory_VMNode(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeInventorySource("pve1", "pve2")
+	src.vms[101] = "pve2"
+	inv, now := testInventory(src)
+
+	node, ok, err := inv.VMNode(ctx, 101)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "pve2", node)
+
+	// A VMID created elsewhere is not in the index yet: the miss refreshes it.
+	src.vms[102] = "pve1"
+	node, ok, err = inv.VMNode(ctx, 102)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "pve1", node)
+	assert.Equal(t, 2, src.count("vms"))
+
+	_, ok, err = inv.VMNode(ctx, 999)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// VMs this provider creates and deletes update the index in place.
+	inv.RecordVM(103, "pve2")
+	inv.ForgetVM(101)
+	node, ok, err = inv.VMNode(ctx, 103)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "pve2", node)
+	assert.Equal(t, 3, src.count("vms"))
+
+	// A migration is picked up once the index goes stale.
+	src.vms[102] = "pve2"
+	*now = now.Add(time.Minute)
+	node, _, err = inv.VMNode(ctx, 102)
+	require.NoError(t, err)
+	assert.Equal(t, "pve2", node)
+}
+
+func TestInventory_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeInventorySource("pve1", "pve2")
+	src.vms[101] = "pve1"
+	inv := newInventory(src, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				_, _ = inv.Nodes(ctx)
+				_, _ = inv.NodeStatus(ctx, "pve2")
+				_, _ = inv.Storage(ctx, "pve1", "local-lvm")
+				_, _, _ = inv.VMNode(ctx, 101)
+				if (i+j)%10 == 0 {
+					inv.observeServerError(595, "")
+				}
+				inv.RecordVM(200+i, "pve2")
+			}
+		}()
+	}
+	wg.Wait()
+
+	hits, misses := inv.stats()
+	assert.Equal(t, int64(16*50*4), hits+misses)
+}
+
+// TestProxmoxProvider_InventoryVMIDLookup resolves a bare VMID to the node it
+// lives on rather than the first node in the cluster.
+func TestProxmoxProvider_InventoryVMIDLookup(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	_, err = provider.client.CreateVM(context.Background(), "pve2", &pveapi.VMConfig{VMID: 4242, Name: "on-pve2"})
+	require.NoError(t, err)
+
+	vmid, node, err := provider.parseVMReference("4242")
+	require.NoError(t, err)
+	assert.Equal(t, 4242, vmid)
+	assert.Equal(t, "pve2", node)
+
+	_, node, err = provider.parseVMReference("4343")
+	require.NoError(t, err)
+	assert.Equal(t, "pve", node, "an unknown VMID falls back to the default node")
+}
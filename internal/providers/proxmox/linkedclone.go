@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+)
+
+// cloneFromTemplate starts the clone of templateID into vmConfig and
+// returns its task. When linked is set a linked clone is tried first: it
+// shares the template's disks instead of copying them, so a burst of VMs
+// from one template is not bound by copying a disk per VM. PVE refuses a
+// linked clone up front when the template's storage cannot snapshot, such
+// as thick LVM, and the clone is then made as a full copy onto the selected
+// storage instead.
+func (p *Provider) cloneFromTemplate(ctx context.Context, node string, templateID int, vmConfig *pveapi.VMConfig, linked bool) (string, error) {
+	if linked {
+		// A linked clone stays on the template's storage; PVE rejects a
+		// storage parameter for one.
+		linkedConfig := *vmConfig
+		linkedConfig.Storage = ""
+		linkedConfig.Custom = maps.Clone(vmConfig.Custom)
+		linkedConfig.Custom["full"] = "0"
+		taskID, err := p.client.CloneVM(ctx, node, templateID, &linkedConfig)
+		if err == nil || !linkedCloneUnsupported(err) {
+			return taskID, err
+		}
+		p.logger.Info("Linked clone not possible, falling back to a full clone",
+			"template_id", templateID, "vmid", vmConfig.VMID, "error", err)
+	}
+
+	vmConfig.Custom["full"] = "1"
+	return p.client.CloneVM(ctx, node, templateID, vmConfig)
+}
+
+// linkedCloneUnsupported reports whether PVE refused a clone because a
+// linked clone is not possible for the template or its storage.
+func linkedCloneUnsupported(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "linked clone")
+}
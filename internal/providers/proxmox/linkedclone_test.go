@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+func newLinkedCloneProvider(t *testing.T) (*Provider, *pvefake.Server) {
+	t.Helper()
+	srv, endpoint := pvefake.NewTestServer(t)
+	client, err := pveapi.NewClient(&pveapi.Config{
+		Endpoint:         endpoint,
+		TokenID:          "test@pve!token",
+		TokenSecret:      "secret",
+		TaskPollInterval: 20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	p := New()
+	p.client = client
+	return p, srv
+}
+
+func linkedCloneRequest(name string) *providerv1.CreateRequest {
+	return &providerv1.CreateRequest{
+		Name:      name,
+		ClassJson: `{"cpu": 1, "memoryMiB": 1024}`,
+		ImageJson: `{"templateName": "9000", "linkedClone": true}`,
+	}
+}
+
+func createdVMID(t *testing.T, resp *providerv1.CreateResponse) int {
+	t.Helper()
+	vmid, err := strconv.Atoi(resp.Id)
+	require.NoError(t, err)
+	return vmid
+}
+
+func TestCreate_LinkedClone(t *testing.T) {
+	p, srv := newLinkedCloneProvider(t)
+
+	resp, err := p.Create(context.Background(), linkedCloneRequest("linked"))
+	require.NoError(t, err)
+	assert.True(t, srv.VMLinked(createdVMID(t, resp)))
+
+	// Without the image asking for it, a clone is a full copy.
+	req := linkedCloneRequest("full")
+	req.ImageJson = `{"templateName": "9000"}`
+	resp, err = p.Create(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, srv.VMLinked(createdVMID(t, resp)))
+}
+
+func TestCreate_LinkedCloneFallsBackToFullClone(t *testing.T) {
+	p, srv := newLinkedCloneProvider(t)
+	srv.InjectFault(pvefake.Fault{
+		Method:  http.MethodPost,
+		Route:   "/nodes/{node}/qemu/{vmid}/clone",
+		Message: "Linked clone feature is not supported for drive 'scsi0'",
+		Times:   1,
+	})
+
+	resp, err := p.Create(context.Background(), linkedCloneRequest("fallback"))
+	require.NoError(t, err)
+	assert.False(t, srv.VMLinked(createdVMID(t, resp)))
+	assert.Equal(t, 2, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+}
+
+func TestCreate_LinkedCloneKeepsOtherFailures(t *testing.T) {
+	p, srv := newLinkedCloneProvider(t)
+	srv.InjectFault(pvefake.Fault{
+		Method: http.MethodPost,
+		Route:  "/nodes/{node}/qemu/{vmid}/clone",
+		Times:  1,
+	})
+
+	_, err := p.Create(context.Background(), linkedCloneRequest("refused"))
+	require.Error(t, err)
+	assert.Equal(t, 1, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+}
+
+func TestCreate_BusyOverCloneLimit(t *testing.T) {
+	p, srv := newLinkedCloneProvider(t)
+	p.clones = common.NewCloneLimiter(1, 3*time.Second)
+	release, err := p.clones.Acquire()
+	require.NoError(t, err)
+
+	_, err = p.Create(context.Background(), linkedCloneRequest("turned-away"))
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(errors.ToGRPCError(err)))
+	delay, busy := errors.IsBusy(err)
+	assert.True(t, busy)
+	assert.Equal(t, 3*time.Second, delay)
+	assert.Zero(t, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+
+	// A VM built without a template takes no clone slot.
+	_, err = p.Create(context.Background(), &providerv1.CreateRequest{Name: "bare", ClassJson: `{"cpu": 1, "memoryMiB": 1024}`})
+	require.NoError(t, err)
+
+	release()
+	_, err = p.Create(context.Background(), linkedCloneRequest("admitted"))
+	require.NoError(t, err)
+	assert.Zero(t, p.clones.InFlight())
+}
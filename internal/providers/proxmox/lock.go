@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// configLockMessages are the PVE error texts for a VM config held by
+// another change: the per-VM config file lock that every config write
+// takes ("can't lock file '/var/lock/qemu-server/lock-100.conf' - got
+// timeout"), and the lock a long-running task such as a backup or clone
+// records in the config ("VM is locked (backup)").
+var configLockMessages = []string{
+	"can't lock file",
+	"config locked",
+	"vm is locked",
+}
+
+// isConfigLocked reports whether err is PVE refusing a change because
+// another one holds the VM's config.
+func isConfigLocked(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range configLockMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// pveFailure returns the error for a failed PVE call: a Conflict when the
+// VM's config was locked by a concurrent change, which the manager retries
+// shortly, and an Internal error otherwise.
+func pveFailure(message string, err error) *errors.ProviderError {
+	if isConfigLocked(err) {
+		return errors.NewConflict("%s: VM config is locked by another change: %v", message, err)
+	}
+	return errors.NewInternal(message, err)
+}
+
+// busyReason returns the describe busyReason of vm: the lock a long-running
+// task such as a backup, migration or snapshot records in its config, which
+// fails every change until the task ends. It is "" for an unlocked VM.
+func busyReason(vm *pveapi.VM) string {
+	if vm.ConfigLock == "" {
+		return ""
+	}
+	return "config locked: " + vm.ConfigLock
+}
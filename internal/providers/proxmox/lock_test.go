@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+func TestPVEFailure(t *testing.T) {
+	cases := []struct {
+		body     string
+		conflict bool
+	}{
+		{"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout", true},
+		{"VM is locked (backup)", true},
+		{"task failed with exit code: can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout", true},
+		{"VM 100 not running", false},
+		{"unable to find configuration file for VM 100", false},
+	}
+	for _, tc := range cases {
+		err := pveFailure("failed to reconfigure VM", fmt.Errorf("reconfigure VM failed with status 500: %s", tc.body))
+		assert.Equal(t, tc.conflict, errors.IsConflict(err), tc.body)
+	}
+	assert.False(t, isConfigLocked(nil))
+}
+
+func TestProxmoxProvider_ConcurrentReconfigure(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	srv.EnableConfigLocks()
+	provider := createTestProvider(endpoint)
+
+	reqs := make([]*providerv1.ReconfigureRequest, 3)
+	for i := range reqs {
+		reqs[i] = &providerv1.ReconfigureRequest{
+			Id:      "100",
+			Changes: &providerv1.ChangeSet{Cpu: &providerv1.Int32Change{Old: 2, New: int32(3 + i)}},
+		}
+	}
+	conflicts, err := conformance.CheckConcurrentReconfigure(context.Background(), provider, reqs...)
+	require.NoError(t, err)
+	assert.Equal(t, 2, conflicts, "one write takes the config lock, the others conflict")
+}
+
+func TestProxmoxProvider_LockedVMConflicts(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	srv.AddVM(&pvefake.VM{VMID: 330, Name: "backing-up", Node: "pve", Status: "running", Lock: "backup"})
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Reconfigure(context.Background(), &providerv1.ReconfigureRequest{
+		Id:      "330",
+		Changes: &providerv1.ChangeSet{Cpu: &providerv1.Int32Change{Old: 1, New: 2}},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.IsConflict(err), "a VM locked by a backup must conflict, got %v", err)
+}
+
+func TestProxmoxProvider_LockedVMIsBusy(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	srv.AddVM(&pvefake.VM{VMID: 330, Name: "backing-up", Node: "pve", Status: "running", Lock: "backup"})
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	details, err := conformance.CheckDescribeDetails(ctx, provider, "330")
+	require.NoError(t, err)
+	assert.Equal(t, "config locked: backup", details.BusyReason)
+
+	batch, err := provider.DescribeBatch(ctx, &providerv1.DescribeBatchRequest{Ids: []string{"330", "100"}})
+	require.NoError(t, err)
+	locked, err := describe.Parse(batch.Results["330"].ProviderRawJson)
+	require.NoError(t, err)
+	assert.Equal(t, "config locked: backup", locked.BusyReason)
+	free, err := describe.Parse(batch.Results["100"].ProviderRawJson)
+	require.NoError(t, err)
+	assert.Empty(t, free.BusyReason)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func TestParseNetworkAttachments(t *testing.T) {
+	payload, err := json.Marshal([]contracts.NetworkAttachment{
+		// Resolved from a VMNetworkAttachment proxmox binding.
+		{Name: "app", Bridge: "vmbr5", VLAN: 42, Model: "e1000", StaticIP: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1", DNS: "1.1.1.1"},
+		// Inline names fall back to the historical mapping.
+		{Name: "dmz"},
+		{Name: "vmbr7"},
+		{Name: "anything-else"},
+	})
+	require.NoError(t, err)
+
+	networks, ipConfigs, err := parseNetworkAttachments(string(payload))
+	require.NoError(t, err)
+	require.Len(t, networks, 4)
+
+	assert.Equal(t, "vmbr5", networks[0].Bridge)
+	assert.Equal(t, 42, networks[0].VLAN)
+	assert.Equal(t, "e1000", networks[0].Model)
+	assert.Equal(t, "10.0.0.5/24", ipConfigs[0].IP)
+	assert.False(t, ipConfigs[0].DHCP)
+	assert.Equal(t, "10.0.0.1", ipConfigs[0].Gateway)
+	assert.Equal(t, "1.1.1.1", ipConfigs[0].DNS)
+
+	assert.Equal(t, "vmbr1", networks[1].Bridge)
+	assert.Equal(t, "vmbr7", networks[2].Bridge)
+	assert.Equal(t, "vmbr0", networks[3].Bridge)
+	assert.Equal(t, "virtio", networks[3].Model)
+	assert.True(t, ipConfigs[3].DHCP)
+	assert.Equal(t, 3, networks[3].Index)
+
+	_, _, err = parseNetworkAttachments(`{"not":"a list"}`)
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+}
+
+func TestParseNetworkAttachments_IPv6(t *testing.T) {
+	payload, err := json.Marshal([]contracts.NetworkAttachment{
+		{Name: "dual", StaticIP: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1", StaticIPv6: "2001:db8::5/64", GatewayIPv6: "2001:db8::1"},
+		{Name: "v6only", StaticIPv6: "2001:db8:1::5/64"},
+	})
+	require.NoError(t, err)
+
+	_, ipConfigs, err := parseNetworkAttachments(string(payload))
+	require.NoError(t, err)
+	require.Len(t, ipConfigs, 2)
+
+	p := &Provider{}
+	assert.Equal(t, "ip=10.0.0.5/24,gw=10.0.0.1,ip6=2001:db8::5/64,gw6=2001:db8::1", p.buildIPConfigString(ipConfigs[0]))
+	// IPv4 keeps DHCP next to a static IPv6 address.
+	assert.Equal(t, "ip=dhcp,ip6=2001:db8:1::5/64", p.buildIPConfigString(ipConfigs[1]))
+}
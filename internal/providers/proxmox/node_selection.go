@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// maintenanceNodesFromEnv reads PROVIDER_MAINTENANCE_NODES (or
+// PVE_MAINTENANCE_NODES), a comma-separated list of node names or glob
+// patterns such as "pve3,*-drain" that new VMs are kept off.
+func maintenanceNodesFromEnv() []string {
+	raw := os.Getenv("PROVIDER_MAINTENANCE_NODES")
+	if raw == "" {
+		raw = os.Getenv("PVE_MAINTENANCE_NODES")
+	}
+	var patterns []string
+	for _, pattern := range strings.Split(raw, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" && !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// nodeFilter decides which nodes a new VM may go to.
+type nodeFilter struct {
+	// selector is the PROVIDER_NODE_SELECTOR allowlist; empty allows every
+	// node.
+	selector []string
+	// maintenance are the PROVIDER_MAINTENANCE_NODES names and patterns.
+	maintenance []string
+	// haMaintenance are the nodes the HA manager has in maintenance mode.
+	haMaintenance []string
+	// antiNodes are the nodes the placement payload rules out.
+	antiNodes []string
+}
+
+// exclusion returns why node may not take a new VM, or "" if it may.
+func (f nodeFilter) exclusion(n pveapi.NodeResource) string {
+	switch {
+	case len(f.selector) > 0 && !slices.Contains(f.selector, n.Node):
+		return "not in PROVIDER_NODE_SELECTOR"
+	case n.Status != "online":
+		return "status " + n.Status
+	case slices.Contains(f.haMaintenance, n.Node):
+		return "in HA maintenance mode"
+	case slices.Contains(f.antiNodes, n.Node):
+		return "in placement.antiNodes"
+	}
+	for _, pattern := range f.maintenance {
+		if ok, _ := path.Match(pattern, n.Node); ok {
+			return fmt.Sprintf("matches maintenance marker %q", pattern)
+		}
+	}
+	return ""
+}
+
+// nodeSelection is the node a new VM goes to and how it was picked.
+type nodeSelection struct {
+	node string
+	// reason is how node was picked: from placement.host, by free memory,
+	// or as the node an imported disk was staged on.
+	reason string
+	// candidates are the eligible nodes, most free memory first.
+	candidates []pveapi.NodeResource
+	// excluded holds why each other node was passed over.
+	excluded map[string]string
+}
+
+// providerRaw returns the selection as CreateResponse provider details, so
+// the manager can tell why the VM landed where it did.
+func (s nodeSelection) providerRaw() map[string]string {
+	raw := map[string]string{"node": s.node, "node_selection": s.reason}
+	if len(s.candidates) > 0 {
+		scores := make([]string, 0, len(s.candidates))
+		for _, n := range s.candidates {
+			scores = append(scores, fmt.Sprintf("%s=%dMiB free", n.Node, n.FreeMem()>>20))
+		}
+		raw["node_candidates"] = strings.Join(scores, ", ")
+	}
+	if len(s.excluded) > 0 {
+		raw["node_excluded"] = formatExclusions(s.excluded)
+	}
+	return raw
+}
+
+// formatExclusions renders node exclusions as "node: reason; ..." sorted
+// by node.
+func formatExclusions(excluded map[string]string) string {
+	nodes := make([]string, 0, len(excluded))
+	for node := range excluded {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		parts = append(parts, node+": "+excluded[node])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// chooseNode picks the node with the most free memory among those f
+// allows. Nodes with equal free memory keep the order PVE listed them in.
+func chooseNode(nodes []pveapi.NodeResource, f nodeFilter) (nodeSelection, error) {
+	sel := nodeSelection{reason: "most free memory", excluded: map[string]string{}}
+	for _, n := range nodes {
+		if reason := f.exclusion(n); reason != "" {
+			sel.excluded[n.Node] = reason
+			continue
+		}
+		sel.candidates = append(sel.candidates, n)
+	}
+	if len(sel.candidates) == 0 {
+		return sel, fmt.Errorf("no Proxmox node can take the VM (%s)", formatExclusions(sel.excluded))
+	}
+	sort.SliceStable(sel.candidates, func(i, j int) bool {
+		return sel.candidates[i].FreeMem() > sel.candidates[j].FreeMem()
+	})
+	sel.node = sel.candidates[0].Node
+	return sel, nil
+}
+
+// selectNode picks the node a new VM is created on: placement.host when
+// the request names one, else the online node with the most free memory
+// that the node selector allows and that is neither in maintenance nor in
+// placement.antiNodes. The node list is read from PVE rather than the
+// inventory, so a node cordoned a moment ago is already skipped.
+func (p *Provider) selectNode(ctx context.Context, pl placement) (nodeSelection, error) {
+	if pl.Host != "" {
+		if slices.Contains(pl.AntiNodes, pl.Host) {
+			return nodeSelection{}, errors.NewInvalidSpec("placement.host %s is also listed in placement.antiNodes", pl.Host)
+		}
+		return nodeSelection{node: pl.Host, reason: "placement.host"}, nil
+	}
+
+	nodes, err := p.client.ListNodeResources(ctx)
+	if err != nil {
+		return nodeSelection{}, errors.NewUnavailable("Proxmox VE node list", err)
+	}
+	haMaintenance, err := p.client.HAMaintenanceNodes(ctx)
+	if err != nil {
+		p.logger.Debug("HA manager status unavailable; not checking HA maintenance mode", "error", err)
+	}
+	sel, err := chooseNode(nodes, nodeFilter{
+		selector:      p.client.Config().NodeSelector,
+		maintenance:   p.maintenanceNodes,
+		haMaintenance: haMaintenance,
+		antiNodes:     pl.AntiNodes,
+	})
+	if err != nil {
+		return sel, errors.NewResourceExhausted("%v", err)
+	}
+	raw := sel.providerRaw()
+	p.logger.Debug("Selected node", "node", sel.node, "reason", sel.reason,
+		"candidates", raw["node_candidates"], "excluded", raw["node_excluded"])
+	return sel, nil
+}
+
+// templateNode returns the node template vmid is on, or fallback when the
+// cluster's VMID index does not know it.
+func (p *Provider) templateNode(ctx context.Context, vmid int, fallback string) string {
+	node, ok, err := p.inventory().VMNode(ctx, vmid)
+	if err != nil {
+		p.logger.Debug("VMID index unavailable, cloning on the selected node", "template_id", vmid, "error", err)
+	}
+	if !ok {
+		return fallback
+	}
+	return node
+}
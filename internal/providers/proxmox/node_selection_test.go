@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// testNode returns an online node with freeGiB of its 64 GiB unused.
+func testNode(name string, freeGiB int64) pveapi.NodeResource {
+	return pveapi.NodeResource{Node: name, Status: "online", MaxMem: 64 << 30, Mem: (64 - freeGiB) << 30}
+}
+
+func TestChooseNode(t *testing.T) {
+	offline := testNode("pve4", 60)
+	offline.Status = "offline"
+	inventory := []pveapi.NodeResource{
+		testNode("pve1", 8),
+		testNode("pve2", 32),
+		testNode("pve3-drain", 48),
+		offline,
+	}
+
+	cases := []struct {
+		name     string
+		filter   nodeFilter
+		want     string
+		excluded map[string]string
+	}{
+		{
+			name:     "most free memory",
+			filter:   nodeFilter{},
+			want:     "pve3-drain",
+			excluded: map[string]string{"pve4": "status offline"},
+		},
+		{
+			name:   "maintenance marker",
+			filter: nodeFilter{maintenance: []string{"*-drain"}},
+			want:   "pve2",
+			excluded: map[string]string{
+				"pve3-drain": `matches maintenance marker "*-drain"`,
+				"pve4":       "status offline",
+			},
+		},
+		{
+			name:   "HA maintenance mode",
+			filter: nodeFilter{haMaintenance: []string{"pve3-drain", "pve2"}},
+			want:   "pve1",
+			excluded: map[string]string{
+				"pve2":       "in HA maintenance mode",
+				"pve3-drain": "in HA maintenance mode",
+				"pve4":       "status offline",
+			},
+		},
+		{
+			name:   "selector",
+			filter: nodeFilter{selector: []string{"pve1", "pve4"}},
+			want:   "pve1",
+			excluded: map[string]string{
+				"pve2":       "not in PROVIDER_NODE_SELECTOR",
+				"pve3-drain": "not in PROVIDER_NODE_SELECTOR",
+				"pve4":       "status offline",
+			},
+		},
+		{
+			name:   "anti nodes",
+			filter: nodeFilter{antiNodes: []string{"pve3-drain"}},
+			want:   "pve2",
+			excluded: map[string]string{
+				"pve3-drain": "in placement.antiNodes",
+				"pve4":       "status offline",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sel, err := chooseNode(inventory, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, sel.node)
+			assert.Equal(t, tc.excluded, sel.excluded)
+			for i := 1; i < len(sel.candidates); i++ {
+				assert.GreaterOrEqual(t, sel.candidates[i-1].FreeMem(), sel.candidates[i].FreeMem())
+			}
+		})
+	}
+
+	t.Run("equal free memory keeps PVE's order", func(t *testing.T) {
+		sel, err := chooseNode([]pveapi.NodeResource{testNode("b", 8), testNode("a", 8)}, nodeFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, "b", sel.node)
+	})
+
+	t.Run("no node left", func(t *testing.T) {
+		_, err := chooseNode(inventory, nodeFilter{selector: []string{"pve4"}})
+		assert.ErrorContains(t, err, "pve4: status offline")
+	})
+}
+
+func TestProxmoxProvider_CreateSkipsDrainedNodes(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.SetNodes(
+		pvefake.Node{Name: "pve1", MemTotalGiB: 64, MemUsedGiB: 40},
+		pvefake.Node{Name: "pve2", MemTotalGiB: 64, MemUsedGiB: 8},
+		pvefake.Node{Name: "pve3", MemTotalGiB: 64, MemUsedGiB: 20},
+		pvefake.Node{Name: "pve4", Status: "offline", MemTotalGiB: 64},
+	)
+	server.SetHAMaintenance("pve2", true)
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:      "placed",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+	})
+	require.NoError(t, err)
+	vmid, err := strconv.Atoi(resp.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "pve3", server.VMNode(vmid))
+
+	raw := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(resp.ProviderRawJson), &raw))
+	assert.Equal(t, "pve3", raw["node"])
+	assert.Equal(t, "most free memory", raw["node_selection"])
+	assert.Equal(t, "pve3=45056MiB free, pve1=24576MiB free", raw["node_candidates"])
+	assert.Equal(t, "pve2: in HA maintenance mode; pve4: status offline", raw["node_excluded"])
+}
+
+func TestProxmoxProvider_CreateHonorsSelectorAndAntiNodes(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.SetNodes(
+		pvefake.Node{Name: "pve1", MemTotalGiB: 64, MemUsedGiB: 40},
+		pvefake.Node{Name: "pve2", MemTotalGiB: 64, MemUsedGiB: 8},
+		pvefake.Node{Name: "pve3", MemTotalGiB: 64, MemUsedGiB: 20},
+	)
+	provider := createTestProvider(endpoint)
+	provider.client.Config().NodeSelector = []string{"pve1", "pve3"}
+	ctx := context.Background()
+
+	create := func(name string, pl contracts.Placement) (*providerv1.CreateResponse, error) {
+		return provider.Create(ctx, &providerv1.CreateRequest{
+			Name:          name,
+			ClassJson:     `{"CPU":1,"MemoryMiB":1024}`,
+			PlacementJson: placementJSON(t, pl),
+		})
+	}
+
+	resp, err := create("selected", contracts.Placement{})
+	require.NoError(t, err)
+	vmid, _ := strconv.Atoi(resp.Id)
+	assert.Equal(t, "pve3", server.VMNode(vmid), "pve2 has more free memory but is not selected")
+
+	resp, err = create("anti", contracts.Placement{AntiNodes: []string{"pve3"}})
+	require.NoError(t, err)
+	vmid, _ = strconv.Atoi(resp.Id)
+	assert.Equal(t, "pve1", server.VMNode(vmid))
+
+	_, err = create("nowhere", contracts.Placement{AntiNodes: []string{"pve1", "pve3"}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestProxmoxProvider_CreateClonesTemplateToSelectedNode(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.SetNodes(
+		pvefake.Node{Name: "pve", MemTotalGiB: 64, MemUsedGiB: 60},
+		pvefake.Node{Name: "pve2", MemTotalGiB: 64, MemUsedGiB: 8},
+	)
+	server.AddVM(&pvefake.VM{VMID: 9000, Name: "ubuntu-tpl", Node: "pve", Status: "stopped", Template: 1})
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:      "from-template",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+		ImageJson: `{"TemplateName":"9000"}`,
+	})
+	require.NoError(t, err)
+	vmid, _ := strconv.Atoi(resp.Id)
+	assert.Equal(t, "pve2", server.VMNode(vmid), "cloned on the template's node with pve2 as target")
+}
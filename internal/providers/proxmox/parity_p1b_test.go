@@ -91,6 +91,24 @@ func TestProxmoxProvider_PowerGracefulNoTimeout(t *testing.T) {
 	assert.Empty(t, last.ForceStop)
 }
 
+// TestProxmoxProvider_PowerReset verifies a hard reset maps to PVE's
+// status/reset endpoint rather than a guest reboot.
+func TestProxmoxProvider_PowerReset(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Power(context.Background(), &providerv1.PowerRequest{
+		Id: "100",
+		Op: providerv1.PowerOp_POWER_OP_RESET,
+	})
+	require.NoError(t, err)
+
+	last := srv.LastPowerOp()
+	require.NotNil(t, last)
+	assert.Equal(t, "reset", last.Operation)
+}
+
 // TestProxmoxProvider_NodeDiscovery is the #261 P1-4 fix: with no NodeSelector
 // configured the client discovers nodes from the cluster API instead of assuming
 // "pve". The fake reports two nodes (incl. "pve2"), which the legacy hardcode
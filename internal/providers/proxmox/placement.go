@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// placement is the part of a placement payload, a marshaled
+// contracts.Placement, the provider honors.
+type placement struct {
+	Host      string       `json:"host"`
+	Datastore string       `json:"datastore"`
+	Pool      string       `json:"pool"`
+	HA        *haPlacement `json:"ha"`
+	// AntiNodes are nodes a new VM must not be created on.
+	AntiNodes []string `json:"antiNodes"`
+}
+
+// haPlacement is the HA manager entry requested for a VM.
+type haPlacement struct {
+	Group string `json:"group"`
+	State string `json:"state"`
+}
+
+// placementDefaults are the provider-wide pool and HA settings applied to
+// VMs whose placement names none.
+type placementDefaults struct {
+	pool string
+	ha   *haPlacement
+}
+
+// placementDefaultsFromEnv reads PROVIDER_DEFAULT_POOL,
+// PROVIDER_DEFAULT_HA_GROUP and PROVIDER_DEFAULT_HA_STATE (or their PVE_*
+// forms). Setting either HA variable puts every VM under HA management.
+func placementDefaultsFromEnv() placementDefaults {
+	env := func(name string) string {
+		v := os.Getenv("PROVIDER_" + name)
+		if v == "" {
+			v = os.Getenv("PVE_" + name)
+		}
+		return strings.TrimSpace(v)
+	}
+	d := placementDefaults{pool: env("DEFAULT_POOL")}
+	group, state := env("DEFAULT_HA_GROUP"), env("DEFAULT_HA_STATE")
+	if group != "" || state != "" {
+		d.ha = &haPlacement{Group: group, State: state}
+	}
+	return d
+}
+
+// resolvePlacement parses a placement payload and fills in the provider
+// defaults for the pool and HA settings it leaves unset.
+func (p *Provider) resolvePlacement(raw string) (placement, error) {
+	var pl placement
+	if raw != "" {
+		if err := contracts.UnmarshalPayload([]byte(raw), &pl); err != nil {
+			return pl, errors.NewInvalidSpec("invalid placement payload: %v", err)
+		}
+	}
+	pl.Pool = strings.TrimSpace(pl.Pool)
+	if pl.Pool == "" {
+		pl.Pool = p.placementDefaults.pool
+	}
+	if pl.HA == nil && p.placementDefaults.ha != nil {
+		ha := *p.placementDefaults.ha
+		pl.HA = &ha
+	}
+	if pl.HA != nil && pl.HA.State != "" && !slices.Contains(pveapi.HAStates, pl.HA.State) {
+		return pl, errors.NewInvalidSpec("placement.ha.state %q is not one of %s",
+			pl.HA.State, strings.Join(pveapi.HAStates, ", "))
+	}
+	return pl, nil
+}
+
+// validatePool checks that pool exists before a VM is created in it, so a
+// typo fails with the pools to choose from rather than a PVE error after
+// the VMID was allocated.
+func (p *Provider) validatePool(ctx context.Context, pool string) error {
+	if pool == "" {
+		return nil
+	}
+	pools, err := p.client.ListPools(ctx)
+	if err != nil {
+		return errors.NewInternal("failed to list resource pools", err)
+	}
+	if slices.Contains(pools, pool) {
+		return nil
+	}
+	return notFoundAmong("Proxmox resource pool", pool, "pools", "availablePools", pools)
+}
+
+// notFoundAmong returns the NotFound error for a named resource that is not
+// among those available, listing them in the message and under detailsKey
+// in the details.
+func notFoundAmong(resourceType, name, plural, detailsKey string, available []string) *errors.ProviderError {
+	available = slices.Clone(available)
+	slices.Sort(available)
+	list := "none"
+	if len(available) > 0 {
+		list = strings.Join(available, ", ")
+	}
+	notFound := errors.NewNotFound(resourceType, name)
+	notFound.Message = fmt.Sprintf("%s (available %s: %s)", notFound.Message, plural, list)
+	notFound.Details = map[string]interface{}{detailsKey: available}
+	return notFound
+}
+
+// ensureHA adds vmid to the HA manager as requested. A VM that is already
+// managed is left as it is, so a retried Create does not fail on the
+// entry its first attempt made.
+func (p *Provider) ensureHA(ctx context.Context, vmid int, ha *haPlacement) error {
+	if ha == nil {
+		return nil
+	}
+	existing, err := p.client.GetHAResource(ctx, vmid)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	p.logger.Info("Adding VM to HA manager", "vmid", vmid, "group", ha.Group, "state", ha.State)
+	return p.client.AddHAResource(ctx, vmid, ha.Group, ha.State)
+}
+
+// describePlacement adds the VM's pool and HA membership to a Describe's
+// provider details. Both are informational, so lookup failures are logged
+// and skipped.
+func (p *Provider) describePlacement(ctx context.Context, vmid int, raw map[string]string) {
+	if pool, err := p.inventory().VMPool(ctx, vmid); err != nil {
+		p.logger.Debug("Failed to look up VM pool for describe", "error", err)
+	} else if pool != "" {
+		raw["pool"] = pool
+	}
+
+	ha, err := p.client.GetHAResource(ctx, vmid)
+	if err != nil {
+		p.logger.Debug("Failed to look up HA resource for describe", "error", err)
+		return
+	}
+	if ha != nil {
+		raw["ha_state"] = ha.State
+		if ha.Group != "" {
+			raw["ha_group"] = ha.Group
+		}
+	}
+}
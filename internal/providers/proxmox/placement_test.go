@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+)
+
+func placementJSON(t *testing.T, p contracts.Placement) string {
+	t.Helper()
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// describeRaw describes the VM id, checks its details conform to the
+// describe layout and returns their provider-specific extras.
+func describeRaw(t *testing.T, provider *Provider, id string) map[string]string {
+	t.Helper()
+	details, err := conformance.CheckDescribeDetails(context.Background(), provider, id)
+	require.NoError(t, err)
+	return details.Extras
+}
+
+func TestProxmoxProvider_CreateJoinsPoolAndHA(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddPool("tenant-a")
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	resp, err := provider.Create(ctx, &providerv1.CreateRequest{
+		Name:      "pooled",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+		PlacementJson: placementJSON(t, contracts.Placement{
+			Pool: "tenant-a",
+			HA:   &contracts.HAPlacement{Group: "rack-1", State: "started"},
+		}),
+	})
+	require.NoError(t, err)
+	vmid, err := strconv.Atoi(resp.Id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "tenant-a", server.VMPool(vmid))
+	assert.Equal(t, &pvefake.HAResource{Group: "rack-1", State: "started"}, server.HAResource(vmid))
+
+	raw := describeRaw(t, provider, resp.Id)
+	assert.Equal(t, "tenant-a", raw["pool"])
+	assert.Equal(t, "rack-1", raw["ha_group"])
+	assert.Equal(t, "started", raw["ha_state"])
+
+	// Deleting the VM removes its HA entry rather than leaving it stale.
+	_, err = provider.Delete(ctx, &providerv1.DeleteRequest{Id: resp.Id})
+	require.NoError(t, err)
+	assert.Nil(t, server.HAResource(vmid))
+}
+
+func TestProxmoxProvider_CreateRejectsUnknownPool(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddPool("tenant-b")
+	server.AddPool("tenant-a")
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:          "lost",
+		PlacementJson: placementJSON(t, contracts.Placement{Pool: "tenant-z"}),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), `"tenant-z" not found`)
+	assert.Contains(t, err.Error(), "available pools: tenant-a, tenant-b")
+}
+
+func TestProxmoxProvider_CreateRejectsUnknownHAState(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:          "bad-ha",
+		PlacementJson: `{"HA":{"State":"running"}}`,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+}
+
+func TestProxmoxProvider_PlacementDefaultsFromEnv(t *testing.T) {
+	t.Setenv("PROVIDER_DEFAULT_POOL", "tenant-a")
+	t.Setenv("PVE_DEFAULT_HA_GROUP", "rack-2")
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddPool("tenant-a")
+	server.AddPool("tenant-b")
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	// No placement: both defaults apply, with PVE's default HA state.
+	resp, err := provider.Create(ctx, &providerv1.CreateRequest{Name: "defaulted"})
+	require.NoError(t, err)
+	vmid, _ := strconv.Atoi(resp.Id)
+	assert.Equal(t, "tenant-a", server.VMPool(vmid))
+	assert.Equal(t, &pvefake.HAResource{Group: "rack-2", State: "started"}, server.HAResource(vmid))
+
+	// A placement naming a pool overrides the default pool only.
+	resp, err = provider.Create(ctx, &providerv1.CreateRequest{
+		Name:          "overridden",
+		PlacementJson: placementJSON(t, contracts.Placement{Pool: "tenant-b"}),
+	})
+	require.NoError(t, err)
+	vmid, _ = strconv.Atoi(resp.Id)
+	assert.Equal(t, "tenant-b", server.VMPool(vmid))
+	assert.Equal(t, "rack-2", server.HAResource(vmid).Group)
+}
+
+func TestProxmoxProvider_CloneJoinsPoolAndHA(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddPool("tenant-a")
+	provider := createTestProvider(endpoint)
+
+	resp, err := provider.Clone(context.Background(), &providerv1.CloneRequest{
+		SourceVmId: "100",
+		TargetName: "pooled-clone",
+		PlacementJson: placementJSON(t, contracts.Placement{
+			Pool: "tenant-a",
+			HA:   &contracts.HAPlacement{State: "stopped"},
+		}),
+	})
+	require.NoError(t, err)
+	vmid, err := strconv.Atoi(resp.TargetVmId)
+	require.NoError(t, err)
+
+	assert.Equal(t, "tenant-a", server.VMPool(vmid))
+	assert.Equal(t, &pvefake.HAResource{State: "stopped"}, server.HAResource(vmid))
+}
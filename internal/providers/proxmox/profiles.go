@@ -0,0 +1,255 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// Field paths used in InvalidSpec errors so the message points at the
+// VMClass field the user has to change.
+const (
+	fieldHugePages  = "spec.performanceProfile.hugePages"
+	fieldTPMEnabled = "spec.securityProfile.tpmEnabled"
+	fieldTPMVersion = "spec.securityProfile.tpmVersion"
+	fieldSecureBoot = "spec.securityProfile.secureBoot"
+	fieldFirmware   = "spec.firmware"
+	fieldBalloonMin = "spec.memoryBalloon.minimumMiB"
+)
+
+// pveHugePages maps the VMClass hugePages enum to the PVE `hugepages` value
+// (page size in MiB, or "any") and the page size the memory must align to.
+var pveHugePages = map[string]struct {
+	value  string
+	sizeMi int64
+}{
+	"2Mi": {value: "2", sizeMi: 2},
+	"1Gi": {value: "1024", sizeMi: 1024},
+	"any": {value: "any"},
+}
+
+// pveTPMVersions maps the VMClass tpmVersion enum to the PVE tpmstate0
+// version option. An empty version defaults to TPM 2.0.
+var pveTPMVersions = map[string]string{
+	"":    "v2.0",
+	"2.0": "v2.0",
+	"1.2": "v1.2",
+}
+
+// classProfiles is the hardware a VMClass asks for beyond CPU/memory sizing,
+// resolved to PVE config keys. The zero value requests nothing.
+type classProfiles struct {
+	values url.Values
+	// diskKeys are the keys in values that allocate a volume (efidisk0,
+	// tpmstate0); they need a storage that accepts VM images.
+	diskKeys []string
+	// diskField is the VMClass field that asked for those volumes, reported
+	// when the storage cannot hold them.
+	diskField string
+}
+
+// empty reports whether the class asks for any profile setting.
+func (c *classProfiles) empty() bool {
+	return len(c.values) == 0
+}
+
+// buildClassProfiles maps the PerformanceProfile, SecurityProfile and
+// Firmware of a marshaled contracts.VMClass onto PVE config keys:
+//
+//   - nestedVirtualization → cpu=host, which passes the node's vmx/svm flag
+//     through to the guest (PVE's `flags` option does not accept vmx/svm)
+//   - hugePages → hugepages=2|1024|any, plus numa=1 which PVE requires
+//   - firmware UEFI/EFI or secureBoot → bios=ovmf, machine=q35 and an
+//     efidisk0; secureBoot pre-enrolls the Microsoft/distribution keys
+//   - tpmEnabled → a tpmstate0 volume backed by swtpm
+//   - memoryBalloon → balloon=<minimumMiB> (the class memory when unset) and
+//     shares; enabled=false sets balloon=0, which removes the device
+//
+// Volumes are allocated on storage. Combinations PVE cannot honor are
+// rejected with an InvalidSpec naming the field, and a class that does not
+// parse with an InvalidSpec.
+func buildClassProfiles(classJSON, storage string) (*classProfiles, error) {
+	out := &classProfiles{values: url.Values{}}
+	if classJSON == "" {
+		return out, nil
+	}
+	var class contracts.VMClass
+	if err := contracts.UnmarshalPayload([]byte(classJSON), &class); err != nil {
+		// Skipping the profiles would create a VM without the firmware or
+		// TPM the class asks for.
+		return nil, errors.NewInvalidSpec("invalid VMClass JSON: %v", err)
+	}
+
+	if pp := class.PerformanceProfile; pp != nil {
+		if pp.NestedVirtualization {
+			out.values.Set("cpu", "host")
+		}
+		if pp.HugePages != "" {
+			hp, ok := pveHugePages[pp.HugePages]
+			if !ok {
+				return nil, errors.NewInvalidSpec("%s: unsupported page size %q (want 2Mi, 1Gi or any)", fieldHugePages, pp.HugePages)
+			}
+			if hp.sizeMi > 0 && int64(class.MemoryMiB)%hp.sizeMi != 0 {
+				return nil, errors.NewInvalidSpec("%s: memory of %dMiB is not a multiple of the %s page size",
+					fieldHugePages, class.MemoryMiB, pp.HugePages)
+			}
+			out.values.Set("hugepages", hp.value)
+			out.values.Set("numa", "1")
+		}
+	}
+
+	if mb := class.MemoryBalloon; mb != nil {
+		if !mb.Enabled {
+			out.values.Set("balloon", "0")
+		} else {
+			minimum := class.MemoryMiB
+			if mb.MinimumMiB != nil {
+				minimum = *mb.MinimumMiB
+			}
+			if class.MemoryMiB > 0 && minimum > class.MemoryMiB {
+				return nil, errors.NewInvalidSpec("%s: %dMiB exceeds the class memory of %dMiB",
+					fieldBalloonMin, minimum, class.MemoryMiB)
+			}
+			if minimum > 0 {
+				out.values.Set("balloon", strconv.Itoa(int(minimum)))
+			}
+			if mb.Shares != nil {
+				out.values.Set("shares", strconv.Itoa(int(*mb.Shares)))
+			}
+		}
+	}
+
+	var uefi, secureBoot, tpm bool
+	switch strings.ToUpper(class.Firmware) {
+	case "", "BIOS":
+	case "UEFI", "EFI":
+		uefi = true
+	case "UEFI-SECUREBOOT":
+		uefi, secureBoot = true, true
+	default:
+		return nil, errors.NewInvalidSpec("%s: unsupported firmware %q", fieldFirmware, class.Firmware)
+	}
+
+	tpmVersion := ""
+	if sp := class.SecurityProfile; sp != nil {
+		secureBoot = secureBoot || sp.SecureBoot
+		tpm = sp.TPMEnabled
+		tpmVersion = sp.TPMVersion
+	}
+
+	if uefi || secureBoot {
+		enrolled := "0"
+		out.diskField = fieldFirmware
+		if secureBoot {
+			enrolled = "1"
+			if sp := class.SecurityProfile; sp != nil && sp.SecureBoot {
+				out.diskField = fieldSecureBoot
+			}
+		}
+		out.values.Set("bios", "ovmf")
+		out.values.Set("machine", "q35")
+		out.values.Set("efidisk0", fmt.Sprintf("%s:1,efitype=4m,pre-enrolled-keys=%s", storage, enrolled))
+		out.diskKeys = append(out.diskKeys, "efidisk0")
+	}
+
+	if tpm {
+		version, ok := pveTPMVersions[tpmVersion]
+		if !ok {
+			return nil, errors.NewInvalidSpec("%s: unsupported TPM version %q", fieldTPMVersion, tpmVersion)
+		}
+		out.values.Set("tpmstate0", fmt.Sprintf("%s:1,version=%s", storage, version))
+		out.diskKeys = append(out.diskKeys, "tpmstate0")
+		out.diskField = fieldTPMEnabled
+	}
+
+	return out, nil
+}
+
+// profileStorage picks the storage for EFI vars and TPM state volumes: the
+// VM's own storage, then the provider default, then local-lvm.
+func (p *Provider) profileStorage(vmStorage string) string {
+	if vmStorage != "" {
+		return vmStorage
+	}
+	if p.client != nil {
+		if s := p.client.Config().DefaultStorage; s != "" {
+			return s
+		}
+	}
+	return defaultProxmoxStorage
+}
+
+// validateProfileStorage confirms the node can allocate the profile's EFI
+// vars and TPM state volumes before anything is created, so a class asking
+// for TPM on a node whose storage cannot hold it fails with InvalidSpec
+// instead of leaving a half-configured VM behind.
+func (p *Provider) validateProfileStorage(ctx context.Context, node, storage string, profiles *classProfiles) error {
+	if len(profiles.diskKeys) == 0 {
+		return nil
+	}
+	field := profiles.diskField
+	status, err := p.inventory().Storage(ctx, node, storage)
+	if err != nil {
+		return errors.NewInvalidSpec("%s: storage %q is not available on node %s for %s: %v",
+			field, storage, node, strings.Join(profiles.diskKeys, "/"), err)
+	}
+	if status.Active == 0 || !status.SupportsContent("images") {
+		return errors.NewInvalidSpec("%s: storage %q on node %s cannot hold %s (content %q, active=%d)",
+			field, storage, node, strings.Join(profiles.diskKeys, "/"), status.Content, status.Active)
+	}
+	return nil
+}
+
+// applyClassProfiles applies the profile settings to an existing VM (after a
+// clone). Volumes the source template already carries are kept rather than
+// reallocated, since PVE would otherwise replace the template's EFI vars or
+// TPM state.
+func (p *Provider) applyClassProfiles(ctx context.Context, node string, vmid int, profiles *classProfiles) error {
+	if profiles.empty() {
+		return nil
+	}
+	vals := url.Values{}
+	for k, v := range profiles.values {
+		vals[k] = v
+	}
+	if len(profiles.diskKeys) > 0 {
+		current, err := p.client.GetVMConfig(ctx, node, vmid)
+		if err != nil {
+			return err
+		}
+		for _, key := range profiles.diskKeys {
+			if existing, ok := current[key].(string); ok && existing != "" {
+				vals.Del(key)
+			}
+		}
+	}
+	task, err := p.client.ReconfigureVMRaw(ctx, node, vmid, vals)
+	if err != nil {
+		return err
+	}
+	if task != "" {
+		return p.client.WaitForTask(ctx, node, task)
+	}
+	return nil
+}
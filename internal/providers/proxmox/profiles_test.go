@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestBuildClassProfiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		classJSON string
+		want      map[string]string
+		wantField string // non-empty: expect InvalidSpec naming this field
+	}{
+		{
+			name:      "no profiles",
+			classJSON: `{"CPU":2,"MemoryMiB":2048,"Firmware":"BIOS"}`,
+			want:      map[string]string{},
+		},
+		{
+			name:      "nested virtualization",
+			classJSON: `{"MemoryMiB":2048,"PerformanceProfile":{"NestedVirtualization":true}}`,
+			want:      map[string]string{"cpu": "host"},
+		},
+		{
+			name:      "1Gi huge pages",
+			classJSON: `{"MemoryMiB":4096,"PerformanceProfile":{"HugePages":"1Gi"}}`,
+			want:      map[string]string{"hugepages": "1024", "numa": "1"},
+		},
+		{
+			name:      "huge pages misaligned with memory",
+			classJSON: `{"MemoryMiB":1536,"PerformanceProfile":{"HugePages":"1Gi"}}`,
+			wantField: fieldHugePages,
+		},
+		{
+			name:      "UEFI without secure boot",
+			classJSON: `{"MemoryMiB":2048,"Firmware":"UEFI"}`,
+			want: map[string]string{
+				"bios": "ovmf", "machine": "q35",
+				"efidisk0": "local-lvm:1,efitype=4m,pre-enrolled-keys=0",
+			},
+		},
+		{
+			name:      "UEFI-SecureBoot firmware",
+			classJSON: `{"MemoryMiB":2048,"Firmware":"UEFI-SecureBoot"}`,
+			want: map[string]string{
+				"bios": "ovmf", "machine": "q35",
+				"efidisk0": "local-lvm:1,efitype=4m,pre-enrolled-keys=1",
+			},
+		},
+		{
+			name:      "secure boot and TPM",
+			classJSON: `{"MemoryMiB":2048,"Firmware":"BIOS","SecurityProfile":{"SecureBoot":true,"TPMEnabled":true,"TPMVersion":"2.0"}}`,
+			want: map[string]string{
+				"bios": "ovmf", "machine": "q35",
+				"efidisk0":  "local-lvm:1,efitype=4m,pre-enrolled-keys=1",
+				"tpmstate0": "local-lvm:1,version=v2.0",
+			},
+		},
+		{
+			name:      "TPM 1.2",
+			classJSON: `{"MemoryMiB":2048,"SecurityProfile":{"TPMEnabled":true,"TPMVersion":"1.2"}}`,
+			want:      map[string]string{"tpmstate0": "local-lvm:1,version=v1.2"},
+		},
+		{
+			name:      "unknown TPM version",
+			classJSON: `{"MemoryMiB":2048,"SecurityProfile":{"TPMEnabled":true,"TPMVersion":"3.0"}}`,
+			wantField: fieldTPMVersion,
+		},
+		{
+			name:      "balloon with a minimum and shares",
+			classJSON: `{"MemoryMiB":4096,"MemoryBalloon":{"Enabled":true,"MinimumMiB":2048,"Shares":500}}`,
+			want:      map[string]string{"balloon": "2048", "shares": "500"},
+		},
+		{
+			name:      "balloon minimum defaults to the class memory",
+			classJSON: `{"MemoryMiB":4096,"MemoryBalloon":{"Enabled":true}}`,
+			want:      map[string]string{"balloon": "4096"},
+		},
+		{
+			name:      "balloon disabled",
+			classJSON: `{"MemoryMiB":4096,"MemoryBalloon":{"Enabled":false,"Shares":500}}`,
+			want:      map[string]string{"balloon": "0"},
+		},
+		{
+			name:      "balloon minimum above the class memory",
+			classJSON: `{"MemoryMiB":2048,"MemoryBalloon":{"Enabled":true,"MinimumMiB":4096}}`,
+			wantField: fieldBalloonMin,
+		},
+		{
+			name:      "unparseable class",
+			classJSON: `{"MemoryMiB":"lots"}`,
+			wantField: "VMClass",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles, err := buildClassProfiles(tt.classJSON, "local-lvm")
+			if tt.wantField != "" {
+				assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+				assert.Contains(t, err.Error(), tt.wantField)
+				return
+			}
+			require.NoError(t, err)
+			got := map[string]string{}
+			for k := range profiles.values {
+				got[k] = profiles.values.Get(k)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestProxmoxProvider_CreateAppliesClassProfiles covers both create paths: a
+// fresh VM takes the profile keys at create time, a template clone gets them
+// in the post-clone reconfigure.
+func TestProxmoxProvider_CreateAppliesClassProfiles(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	classJSON := `{"CPU":2,"MemoryMiB":4096,"PerformanceProfile":{"NestedVirtualization":true,"HugePages":"2Mi"},` +
+		`"SecurityProfile":{"SecureBoot":true,"TPMEnabled":true}}`
+
+	for _, tc := range []struct{ name, imageJSON string }{
+		{name: "profiles-create"},
+		{name: "profiles-clone", imageJSON: `{"TemplateName": "100"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := provider.Create(ctx, &providerv1.CreateRequest{
+				Name:      tc.name,
+				ClassJson: classJSON,
+				ImageJson: tc.imageJSON,
+			})
+			require.NoError(t, err)
+			if resp.Task != nil {
+				require.NoError(t, waitForTask(ctx, provider, resp.Task.Id))
+			}
+
+			vmid, node, err := provider.parseVMReference(resp.Id)
+			require.NoError(t, err)
+			cfg, err := provider.client.GetVMConfig(ctx, node, vmid)
+			require.NoError(t, err)
+			assert.Equal(t, "host", cfg["cpu"])
+			assert.Equal(t, "2", cfg["hugepages"])
+			assert.Equal(t, "ovmf", cfg["bios"])
+			assert.Equal(t, "local-lvm:1,efitype=4m,pre-enrolled-keys=1", cfg["efidisk0"])
+			assert.Equal(t, "local-lvm:1,version=v2.0", cfg["tpmstate0"])
+		})
+	}
+}
+
+// TestProxmoxProvider_CreateRejectsTPMWithoutImageStorage: the fake's "local"
+// storage only holds ISOs/templates/backups, so it cannot back a TPM state
+// volume. Create must fail with InvalidSpec naming the field and leave no VM.
+func TestProxmoxProvider_CreateRejectsTPMWithoutImageStorage(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	provider.client.Config().DefaultStorage = "local"
+	ctx := context.Background()
+
+	_, err = provider.Create(ctx, &providerv1.CreateRequest{
+		Name:      "tpm-on-local",
+		ClassJson: `{"CPU":2,"MemoryMiB":2048,"SecurityProfile":{"TPMEnabled":true}}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), fieldTPMEnabled)
+
+	list, err := provider.ListVMs(ctx, &providerv1.ListVMsRequest{})
+	require.NoError(t, err)
+	for _, vm := range list.Vms {
+		assert.NotEqual(t, "tpm-on-local", vm.Name, "no VM may be created for a rejected class")
+	}
+}
@@ -88,6 +88,24 @@ func TestProxmoxProvider_CreateAndDescribe(t *testing.T) {
 	assert.NotEmpty(t, describeResp.ConsoleUrl)
 }
 
+func TestProxmoxProvider_DescribeGuestStats(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	// VM 100 is seeded running with 25% CPU and 512 MiB in use.
+	resp, err := provider.Describe(context.Background(), &providerv1.DescribeRequest{Id: "100"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GuestStats)
+	assert.InDelta(t, 25.0, resp.GuestStats.GetCpuUsagePercent(), 1e-9)
+	assert.Equal(t, int64(512*1024*1024), resp.GuestStats.GetMemoryUsageBytes())
+
+	// The template is stopped, so it has no stats.
+	resp, err = provider.Describe(context.Background(), &providerv1.DescribeRequest{Id: "9000"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GuestStats)
+}
+
 func TestProxmoxProvider_PowerOperations(t *testing.T) {
 	// Start fake PVE server
 	_, endpoint, err := pvefake.StartFakeServer()
@@ -599,10 +617,16 @@ func TestProxmoxProvider_ImagePrepare_ImportFromURL(t *testing.T) {
 func TestProxmoxProvider_ImagePrepare_StoragePrecedence(t *testing.T) {
 	ctx := context.Background()
 	const imgURL = "https://images.example.com/base.img"
+	addStores := func(server *pvefake.Server) {
+		for _, name := range []string{"hint-store", "src-store"} {
+			server.AddStorage("pve", name, pvefake.Storage{Content: "import,images", TotalGiB: 100})
+		}
+	}
 
 	t.Run("hint wins over source storage", func(t *testing.T) {
 		server, endpoint, err := pvefake.StartFakeServer()
 		require.NoError(t, err)
+		addStores(server)
 		provider := createTestProvider(endpoint)
 
 		_, err = provider.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
@@ -618,6 +642,7 @@ func TestProxmoxProvider_ImagePrepare_StoragePrecedence(t *testing.T) {
 	t.Run("source storage wins over default", func(t *testing.T) {
 		server, endpoint, err := pvefake.StartFakeServer()
 		require.NoError(t, err)
+		addStores(server)
 		provider := createTestProvider(endpoint)
 
 		_, err = provider.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
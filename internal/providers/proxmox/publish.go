@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// PublishImage publishes a VM as a template named req.ImageName. The VM
+// itself is left alone: it is full-cloned, from options.snapshotID when set,
+// and the clone is converted into a template. The returned image ID is the
+// template's VMID, and the task is the conversion.
+//
+// Proxmox has no virt-sysprep step and no content libraries, so options
+// asking for either are rejected.
+func (p *Provider) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+	if req.GetImageName() == "" {
+		return nil, errors.NewInvalidSpec("PublishImage: image name is required")
+	}
+	var options contracts.PublishImageOptions
+	if req.GetOptionsJson() != "" {
+		if err := contracts.UnmarshalPayload([]byte(req.GetOptionsJson()), &options); err != nil {
+			return nil, errors.NewInvalidSpec("PublishImage: invalid options JSON: %v", err)
+		}
+	}
+	if len(options.Cleanup) > 0 {
+		return nil, errors.NewInvalidSpec("PublishImage: Proxmox cannot run cleanup operations %v; clean the guest before publishing", options.Cleanup)
+	}
+	if options.ContentLibrary != "" {
+		return nil, errors.NewInvalidSpec("PublishImage: content libraries are a vSphere feature")
+	}
+
+	vmid, node, err := p.parseVMReference(req.GetVmId())
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+	source, err := p.client.GetVM(ctx, node, vmid)
+	if err != nil {
+		if err == pveapi.ErrVMNotFound {
+			return nil, errors.NewNotFound("VM", req.GetVmId())
+		}
+		return nil, errors.NewInternal(fmt.Sprintf("PublishImage: look up VM %d", vmid), err)
+	}
+	if source.Template == proxmoxTemplateFlag {
+		return nil, errors.NewInvalidSpec("PublishImage: VM %d is already a template", vmid)
+	}
+	if options.SnapshotID == "" && source.Status == "running" {
+		return nil, errors.NewInvalidSpec("PublishImage: VM %d is running; stop it or publish from a snapshot", vmid)
+	}
+
+	if err := p.replacePublishedTemplate(ctx, node, req.GetImageName(), req.GetOverwrite()); err != nil {
+		return nil, err
+	}
+	storage, err := p.selectStorage(ctx, storageRequest{node: node, hint: options.Storage, content: "images"})
+	if err != nil {
+		return nil, err
+	}
+
+	templateVMID := p.nextVMID(ctx)
+	config := &pveapi.VMConfig{
+		VMID:    templateVMID,
+		Name:    req.GetImageName(),
+		Storage: storage,
+		Custom: map[string]string{
+			"full":        "1",
+			"description": fmt.Sprintf("Published from VM %d", vmid),
+		},
+	}
+	if options.SnapshotID != "" {
+		config.Custom["snapname"] = options.SnapshotID
+	}
+
+	p.logger.Info("Publishing VM as template", "node", node, "vmid", vmid,
+		"template", req.GetImageName(), "template_id", templateVMID, "snapshot", options.SnapshotID)
+	cloneTask, err := p.client.CloneVM(ctx, node, vmid, config)
+	if err != nil {
+		return nil, errors.NewInternal("PublishImage: failed to clone VM", err)
+	}
+	if cloneTask != "" {
+		if err := p.client.WaitForTask(ctx, node, cloneTask); err != nil {
+			return nil, errors.NewInternal("PublishImage: clone task failed", err)
+		}
+	}
+	task, err := p.client.ConvertToTemplate(ctx, node, templateVMID)
+	if err != nil {
+		return nil, errors.NewInternal(fmt.Sprintf("PublishImage: convert VM %d to a template", templateVMID), err)
+	}
+
+	resp := &providerv1.PublishImageResponse{ImageId: strconv.Itoa(templateVMID)}
+	if task != "" {
+		resp.Task = p.taskRef(task)
+	}
+	return resp, nil
+}
+
+// replacePublishedTemplate makes room for a template named name on node:
+// an existing one is destroyed when overwrite is set and is an
+// AlreadyExists error otherwise.
+func (p *Provider) replacePublishedTemplate(ctx context.Context, node, name string, overwrite bool) error {
+	existing, err := p.findTemplateByName(ctx, node, name)
+	if err != nil || existing == nil {
+		return err
+	}
+	if !overwrite {
+		return errors.NewAlreadyExists("Proxmox template", name)
+	}
+	p.logger.Info("Replacing published template", "node", node, "template", name, "template_id", existing.VMID)
+	task, err := p.client.DeleteVM(ctx, node, existing.VMID, true)
+	if err != nil {
+		return errors.NewInternal(fmt.Sprintf("PublishImage: delete template %d", existing.VMID), err)
+	}
+	if task != "" {
+		if err := p.client.WaitForTask(ctx, node, task); err != nil {
+			return errors.NewInternal(fmt.Sprintf("PublishImage: delete template %d", existing.VMID), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestProxmoxProvider_PublishImage(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVM(&pvefake.VM{VMID: 420, Name: "web", Node: "pve", Status: "stopped"})
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	resp, err := provider.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: "420", ImageName: "web-golden"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Task)
+	templateID, err := strconv.Atoi(resp.ImageId)
+	require.NoError(t, err)
+
+	template, err := provider.client.GetVM(ctx, "pve", templateID)
+	require.NoError(t, err)
+	assert.Equal(t, "web-golden", template.Name)
+	assert.Equal(t, proxmoxTemplateFlag, template.Template)
+	source, err := provider.client.GetVM(ctx, "pve", 420)
+	require.NoError(t, err)
+	assert.Zero(t, source.Template, "the source VM must not be converted")
+
+	// Publishing the same name again needs overwrite.
+	_, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: "420", ImageName: "web-golden"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	resp, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: "420", ImageName: "web-golden", Overwrite: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, strconv.Itoa(templateID), resp.ImageId)
+	_, err = provider.client.GetVM(ctx, "pve", templateID)
+	assert.Error(t, err, "the replaced template should be gone")
+}
+
+func TestProxmoxProvider_PublishImageRejections(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVM(&pvefake.VM{VMID: 421, Name: "db", Node: "pve", Status: "running"})
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	_, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: "421", ImageName: "db-golden"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a running VM needs a snapshot")
+	assert.Contains(t, err.Error(), "is running")
+
+	_, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: "421", ImageName: "db-golden", OptionsJson: `{"snapshotID":"missing"}`,
+	})
+	assert.Equal(t, codes.Internal, status.Code(err), "PVE rejects an unknown snapshot")
+
+	_, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: "421", ImageName: "db-golden", OptionsJson: `{"cleanup":["machine-id"]}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = provider.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: "100", ImageName: "x"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the seeded VM 100 is already a template")
+}
+
+func TestProxmoxProvider_PublishImageFromSnapshot(t *testing.T) {
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddVM(&pvefake.VM{VMID: 422, Name: "app", Node: "pve", Status: "running"})
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	snap, err := provider.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: "422", NameHint: "pre-publish"})
+	require.NoError(t, err)
+
+	resp, err := provider.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: "422", ImageName: "app-golden", OptionsJson: `{"snapshotID":"` + snap.SnapshotId + `"}`,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ImageId)
+}
@@ -27,7 +27,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -57,11 +57,19 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    *url.URL
 
-	// cachedNodes memoizes the cluster's node names (discovered via /nodes) for
-	// the client's lifetime; the node set rarely changes and a provider restart
-	// re-discovers it.
-	nodesMu     sync.Mutex
-	cachedNodes []string
+	// serverErrorHook, when set, sees every 5xx response; the provider's
+	// inventory cache uses it to notice nodes going away.
+	serverErrorHook atomic.Pointer[func(status int, body string)]
+}
+
+// SetServerErrorHook registers fn to be called with the status and body of
+// every 5xx response, before the caller reads it. A nil fn removes the hook.
+func (c *Client) SetServerErrorHook(fn func(status int, body string)) {
+	if fn == nil {
+		c.serverErrorHook.Store(nil)
+		return
+	}
+	c.serverErrorHook.Store(&fn)
 }
 
 // NewClient creates a new PVE API client
@@ -133,16 +141,29 @@ func (c *Client) Config() *Config {
 
 // VM represents a Proxmox VE virtual machine
 type VM struct {
-	VMID       int    `json:"vmid"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	Node       string `json:"node"`
-	CPUs       int    `json:"cpus,omitempty"`
-	Memory     int64  `json:"maxmem,omitempty"`
-	Template   int    `json:"template,omitempty"`
-	QMPStatus  string `json:"qmpstatus,omitempty"`
-	PID        int    `json:"pid,omitempty"`
-	ConfigLock string `json:"lock,omitempty"`
+	VMID       int     `json:"vmid"`
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Node       string  `json:"node"`
+	CPUs       int     `json:"cpus,omitempty"`
+	Memory     int64   `json:"maxmem,omitempty"`
+	CPU        float64 `json:"cpu,omitempty"`    // Utilization as a fraction of all vCPUs, 0-1
+	Mem        int64   `json:"mem,omitempty"`    // Memory in use, bytes
+	Uptime     int64   `json:"uptime,omitempty"` // Seconds since start
+	Template   int     `json:"template,omitempty"`
+	QMPStatus  string  `json:"qmpstatus,omitempty"`
+	PID        int     `json:"pid,omitempty"`
+	ConfigLock string  `json:"lock,omitempty"`
+	Tags       string  `json:"tags,omitempty"` // ";"-separated
+	// BalloonInfo is reported only while the guest's balloon driver is
+	// loaded and talking to QEMU.
+	BalloonInfo *BalloonInfo `json:"ballooninfo,omitempty"`
+}
+
+// BalloonInfo is the balloon driver's view of guest memory, in bytes.
+type BalloonInfo struct {
+	Actual int64 `json:"actual"`
+	MaxMem int64 `json:"max_mem"`
 }
 
 // VMConfig represents VM configuration parameters
@@ -154,6 +175,8 @@ type VMConfig struct {
 	Template  string            `json:"template,omitempty"`
 	Clone     string            `json:"clone,omitempty"`
 	Storage   string            `json:"storage,omitempty"`
+	Pool      string            `json:"pool,omitempty"` // resource pool the VM is added to
+	Tags      []string          `json:"-"`              // sent as the ";"-separated tags option
 	IDE2      string            `json:"ide2,omitempty"`
 	CIUser    string            `json:"ciuser,omitempty"`
 	CIPasswd  string            `json:"cipassword,omitempty"`
@@ -183,6 +206,12 @@ type NetworkConfig struct {
 	MAC      string `json:"mac,omitempty"`  // MAC address
 	Firewall bool   `json:"firewall"`       // Enable firewall
 	LinkDown bool   `json:"link_down"`      // Start with link down
+
+	// VNet is the SDN vnet Bridge was set from, empty for a plain bridge.
+	VNet string `json:"-"`
+	// SecurityGroups are applied as firewall group rules once the VM
+	// exists; they are not part of the netN option.
+	SecurityGroups []string `json:"-"`
 }
 
 // IPConfig represents IP configuration for a network interface
@@ -192,6 +221,9 @@ type IPConfig struct {
 	Gateway string `json:"gateway,omitempty"`    // Gateway IP
 	DNS     string `json:"nameserver,omitempty"` // DNS servers (comma-separated)
 	DHCP    bool   `json:"dhcp"`                 // Use DHCP instead of static IP
+
+	IP6      string `json:"ip6,omitempty"`      // IPv6 address with prefix (e.g., "2001:db8::10/64"); DHCP covers IPv4 only
+	Gateway6 string `json:"gateway6,omitempty"` // IPv6 gateway
 }
 
 // Task represents a PVE task
@@ -227,34 +259,7 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 	var reqBody io.Reader
 	if body != nil {
 		if data, ok := body.(url.Values); ok {
-			// Special handling for sshkeys parameter:
-			// Python working code does DOUBLE encoding:
-			//   1. quote(sshKey, safe='') - first encoding
-			//   2. wPost form-encodes the dictionary - second encoding
-			// Result: space → %20 → %2520
-			var encoded string
-			if sshKey := data.Get("sshkeys"); sshKey != "" {
-				// Remove from url.Values to prevent automatic encoding.
-				data.Del("sshkeys")
-
-				// Proxmox needs sshkeys DOUBLE-url-encoded with %20 for spaces
-				// (not +): the form encoder applies the second pass.
-				cleanedKey := strings.TrimSpace(sshKey)
-				firstEncode := strings.ReplaceAll(url.QueryEscape(cleanedKey), "+", "%20")
-				doubleEncoded := strings.ReplaceAll(url.QueryEscape(firstEncode), "+", "%20")
-
-				// Encode the other parameters normally, then append the
-				// already-encoded sshkeys verbatim.
-				baseEncoded := data.Encode()
-				if baseEncoded != "" {
-					encoded = baseEncoded + "&sshkeys=" + doubleEncoded
-				} else {
-					encoded = "sshkeys=" + doubleEncoded
-				}
-			} else {
-				encoded = data.Encode()
-			}
-			reqBody = strings.NewReader(encoded)
+			reqBody = strings.NewReader(data.Encode())
 		} else {
 			jsonData, err := json.Marshal(body)
 			if err != nil {
@@ -292,7 +297,18 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 		}
 	}
 
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil || resp.StatusCode < 500 {
+		return resp, err
+	}
+	if hook := c.serverErrorHook.Load(); hook != nil {
+		// Hand the body to the hook and put it back for the caller.
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		(*hook)(resp.StatusCode, string(body))
+	}
+	return resp, nil
 }
 
 // ListVMs lists all VMs on a node
@@ -407,6 +423,9 @@ func (c *Client) CloneVM(ctx context.Context, node string, vmid int, config *VMC
 	if config.Storage != "" {
 		values.Set("storage", config.Storage)
 	}
+	if config.Pool != "" {
+		values.Set("pool", config.Pool)
+	}
 	// Check if full clone is requested via Custom map
 	if fullClone, ok := config.Custom["full"]; ok {
 		values.Set("full", fullClone)
@@ -420,6 +439,9 @@ func (c *Client) CloneVM(ctx context.Context, node string, vmid int, config *VMC
 	if format, ok := config.Custom["format"]; ok {
 		values.Set("format", format)
 	}
+	if snapname, ok := config.Custom["snapname"]; ok {
+		values.Set("snapname", snapname)
+	}
 
 	resp, err := c.request(ctx, "POST", path, values)
 	if err != nil {
@@ -444,6 +466,35 @@ func (c *Client) CloneVM(ctx context.Context, node string, vmid int, config *VMC
 	return "", fmt.Errorf("unexpected response format")
 }
 
+// ConvertToTemplate turns a stopped VM into a template
+// (POST /nodes/{node}/qemu/{vmid}/template). It cannot be undone: the VM's
+// disks become read-only base images that clones are made from.
+func (c *Client) ConvertToTemplate(ctx context.Context, node string, vmid int) (string, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/template", node, vmid)
+
+	resp, err := c.request(ctx, "POST", path, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("failed to convert VM to template: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Response body close in defer is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("convert to template failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp APIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if taskID, ok := apiResp.Data.(string); ok {
+		return taskID, nil
+	}
+
+	return "", nil
+}
+
 // StorageVolume is a volume as listed by /nodes/{node}/storage/{storage}/content.
 type StorageVolume struct {
 	VolID  string `json:"volid"`
@@ -477,6 +528,133 @@ func (c *Client) GetStorageContent(ctx context.Context, node, storage string) ([
 	return out.Data, nil
 }
 
+// StorageStatus is a node's view of a storage as reported by
+// /nodes/{node}/storage/{storage}/status.
+type StorageStatus struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	Active  int    `json:"active"`
+	Enabled int    `json:"enabled"`
+	Total   int64  `json:"total"` // bytes
+	Used    int64  `json:"used"`  // bytes
+	Avail   int64  `json:"avail"` // bytes
+}
+
+// Free returns the bytes available for new volumes: what PVE reports as
+// avail, or total less used when it does not.
+func (s *StorageStatus) Free() int64 {
+	if s.Avail > 0 {
+		return s.Avail
+	}
+	if free := s.Total - s.Used; free > 0 {
+		return free
+	}
+	return 0
+}
+
+// SupportsContent reports whether the storage accepts the given content type
+// (e.g. "images" for VM disks, EFI vars and TPM state).
+func (s *StorageStatus) SupportsContent(content string) bool {
+	for _, c := range strings.Split(s.Content, ",") {
+		if strings.TrimSpace(c) == content {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStorageStatus returns the status of a storage on a node
+// (GET /nodes/{node}/storage/{storage}/status). PVE answers with an error
+// when the storage does not exist or is not available on the node.
+func (c *Client) GetStorageStatus(ctx context.Context, node, storage string) (*StorageStatus, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/storage/%s/status", node, storage)
+	resp, err := c.request(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get storage status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data StorageStatus `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode storage status: %w", err)
+	}
+	return &out.Data, nil
+}
+
+// NodeStatus is the subset of GET /nodes/{node}/status used for capacity
+// reporting.
+type NodeStatus struct {
+	// CPU is the node's current CPU utilization, 0-1.
+	CPU     float64 `json:"cpu"`
+	CPUInfo struct {
+		CPUs int `json:"cpus"` // logical CPUs
+	} `json:"cpuinfo"`
+	Memory struct {
+		Total int64 `json:"total"` // bytes
+		Used  int64 `json:"used"`  // bytes
+	} `json:"memory"`
+}
+
+// GetNodeStatus returns the CPU and memory status of a node
+// (GET /nodes/{node}/status).
+func (c *Client) GetNodeStatus(ctx context.Context, node string) (*NodeStatus, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/api2/json/nodes/%s/status", node), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get node status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data NodeStatus `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode node status: %w", err)
+	}
+	return &out.Data, nil
+}
+
+// Version is GET /version: the PVE manager version of the node answering the
+// API request.
+type Version struct {
+	Version string `json:"version"` // e.g. "8.2.4"
+	Release string `json:"release"` // e.g. "8.2"
+	RepoID  string `json:"repoid"`  // pve-manager commit
+}
+
+// GetVersion returns the PVE version (GET /version).
+func (c *Client) GetVersion(ctx context.Context) (*Version, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/version", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get version failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data Version `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode version: %w", err)
+	}
+	return &out.Data, nil
+}
+
 // GetNextVMID returns the next free VMID from the cluster (GET /cluster/nextid).
 // Allocating from PVE avoids the collisions a purely time-derived VMID risks when
 // two VMs are created within the same second or on a busy cluster.
@@ -677,6 +855,12 @@ func (c *Client) configToValues(config *VMConfig) url.Values {
 	if config.Storage != "" {
 		values.Set("storage", config.Storage)
 	}
+	if config.Pool != "" {
+		values.Set("pool", config.Pool)
+	}
+	if len(config.Tags) > 0 {
+		values.Set("tags", strings.Join(config.Tags, ";"))
+	}
 	if config.IDE2 != "" {
 		values.Set("ide2", config.IDE2)
 	}
@@ -686,12 +870,7 @@ func (c *Client) configToValues(config *VMConfig) url.Values {
 	if config.CIPasswd != "" {
 		values.Set("cipassword", config.CIPasswd)
 	}
-	if config.SSHKeys != "" {
-		// DO NOT pre-encode! Let url.Values handle the encoding naturally.
-		// Just clean up trailing newlines/whitespace
-		cleanedKeys := strings.TrimSpace(config.SSHKeys)
-		values.Set("sshkeys", cleanedKeys)
-	}
+	SetSSHKeys(values, config.SSHKeys)
 
 	// Configure network interfaces
 	for _, netConfig := range config.Networks {
@@ -760,25 +939,33 @@ func (c *Client) buildNetworkString(netConfig NetworkConfig) string {
 
 // buildIPConfigString constructs the IP configuration string for PVE
 func (c *Client) buildIPConfigString(ipConfig IPConfig) string {
+	var parts []string
+
 	if ipConfig.DHCP {
 		// Proxmox expects "ip=dhcp" not "dhcp=1"
-		return "ip=dhcp"
-	}
-
-	var parts []string
+		parts = append(parts, "ip=dhcp")
+	} else {
+		// IP address
+		if ipConfig.IP != "" {
+			parts = append(parts, fmt.Sprintf("ip=%s", ipConfig.IP))
+		}
 
-	// IP address
-	if ipConfig.IP != "" {
-		parts = append(parts, fmt.Sprintf("ip=%s", ipConfig.IP))
+		// Gateway
+		if ipConfig.Gateway != "" {
+			parts = append(parts, fmt.Sprintf("gw=%s", ipConfig.Gateway))
+		}
 	}
 
-	// Gateway
-	if ipConfig.Gateway != "" {
-		parts = append(parts, fmt.Sprintf("gw=%s", ipConfig.Gateway))
+	// IPv6 address and gateway
+	if ipConfig.IP6 != "" {
+		parts = append(parts, fmt.Sprintf("ip6=%s", ipConfig.IP6))
+		if ipConfig.Gateway6 != "" {
+			parts = append(parts, fmt.Sprintf("gw6=%s", ipConfig.Gateway6))
+		}
 	}
 
 	// DNS servers
-	if ipConfig.DNS != "" {
+	if !ipConfig.DHCP && ipConfig.DNS != "" {
 		parts = append(parts, fmt.Sprintf("nameserver=%s", ipConfig.DNS))
 	}
 
@@ -806,15 +993,40 @@ func (c *Client) FindNode(ctx context.Context) (string, error) {
 	return nodes[0], nil
 }
 
-// ListNodes returns the names of the nodes in the PVE cluster (GET /nodes). The
-// result is cached for the client's lifetime since the node set rarely changes.
+// NodeResource is a node's entry in GET /nodes: its status and current
+// usage.
+type NodeResource struct {
+	Node   string  `json:"node"`
+	Status string  `json:"status"` // online, offline or unknown
+	CPU    float64 `json:"cpu"`    // utilization, 0-1
+	MaxCPU int     `json:"maxcpu"`
+	Mem    int64   `json:"mem"`    // bytes used
+	MaxMem int64   `json:"maxmem"` // bytes
+}
+
+// FreeMem returns the node's unused memory in bytes.
+func (n NodeResource) FreeMem() int64 {
+	return max(n.MaxMem-n.Mem, 0)
+}
+
+// ListNodes returns the names of the nodes in the PVE cluster (GET /nodes).
+// Callers that ask often should go through a cache; the Proxmox provider's
+// inventory is one.
 func (c *Client) ListNodes(ctx context.Context) ([]string, error) {
-	c.nodesMu.Lock()
-	defer c.nodesMu.Unlock()
-	if len(c.cachedNodes) > 0 {
-		return c.cachedNodes, nil
+	resources, err := c.ListNodeResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0, len(resources))
+	for _, n := range resources {
+		nodes = append(nodes, n.Node)
 	}
+	return nodes, nil
+}
 
+// ListNodeResources returns the nodes in the PVE cluster with their status
+// and usage (GET /nodes), in the order PVE lists them.
+func (c *Client) ListNodeResources(ctx context.Context) ([]NodeResource, error) {
 	resp, err := c.request(ctx, "GET", "/api2/json/nodes", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
@@ -827,32 +1039,116 @@ func (c *Client) ListNodes(ctx context.Context) ([]string, error) {
 	}
 
 	var out struct {
-		Data []struct {
-			Node string `json:"node"`
-		} `json:"data"`
+		Data []NodeResource `json:"data"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
 		return nil, fmt.Errorf("failed to decode nodes response: %w", err)
 	}
 
-	nodes := make([]string, 0, len(out.Data))
+	nodes := make([]NodeResource, 0, len(out.Data))
 	for _, n := range out.Data {
 		if n.Node != "" {
-			nodes = append(nodes, n.Node)
+			nodes = append(nodes, n)
 		}
 	}
 	if len(nodes) == 0 {
 		return nil, fmt.Errorf("PVE returned no nodes")
 	}
-	c.cachedNodes = nodes
 	return nodes, nil
 }
 
+// NodeStorage is one entry of GET /nodes/{node}/storage: a storage's name
+// and its status on that node.
+type NodeStorage struct {
+	Storage string `json:"storage"`
+	StorageStatus
+}
+
+// ListStorages returns the storages configured on a node with their status
+// (GET /nodes/{node}/storage).
+func (c *Client) ListStorages(ctx context.Context, node string) ([]NodeStorage, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/api2/json/nodes/%s/storage", node), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storages: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list storages failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []NodeStorage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode storages response: %w", err)
+	}
+	return out.Data, nil
+}
+
+// ClusterVM is a guest as listed by GET /cluster/resources?type=vm.
+type ClusterVM struct {
+	VMID   int    `json:"vmid"`
+	Node   string `json:"node"`
+	Name   string `json:"name"`
+	Type   string `json:"type"` // "qemu" or "lxc"
+	Status string `json:"status"`
+	Pool   string `json:"pool,omitempty"`
+	Tags   string `json:"tags,omitempty"` // ";"-separated
+	// Usage, as in VM.
+	CPU    float64 `json:"cpu,omitempty"`
+	Mem    int64   `json:"mem,omitempty"`
+	MaxMem int64   `json:"maxmem,omitempty"`
+	Uptime int64   `json:"uptime,omitempty"`
+	Lock   string  `json:"lock,omitempty"`
+}
+
+// VM returns the status fields the listing shares with VM.
+func (g ClusterVM) VM() *VM {
+	return &VM{
+		VMID:       g.VMID,
+		Name:       g.Name,
+		Status:     g.Status,
+		Node:       g.Node,
+		Memory:     g.MaxMem,
+		CPU:        g.CPU,
+		Mem:        g.Mem,
+		Uptime:     g.Uptime,
+		ConfigLock: g.Lock,
+		Tags:       g.Tags,
+	}
+}
+
+// ClusterVMs lists every guest in the cluster with the node it runs on, in
+// one request (GET /cluster/resources?type=vm).
+func (c *Client) ClusterVMs(ctx context.Context) ([]ClusterVM, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/resources?type=vm", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster resources: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list cluster resources failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []ClusterVM `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster resources: %w", err)
+	}
+	return out.Data, nil
+}
+
 // ReconfigureConfig represents VM reconfiguration parameters
 type ReconfigureConfig struct {
 	CPUs     *int   `json:"cores,omitempty"`
 	Sockets  *int   `json:"sockets,omitempty"`
 	Memory   *int64 `json:"memory,omitempty"`   // Memory in MB
+	Balloon  *int64 `json:"balloon,omitempty"`  // Balloon target in MB; 0 removes the device
 	DiskSize *int64 `json:"disksize,omitempty"` // Disk size in GB
 	Disk     string `json:"disk,omitempty"`     // Disk identifier (e.g., "scsi0")
 }
@@ -872,6 +1168,9 @@ func (c *Client) ReconfigureVM(ctx context.Context, node string, vmid int, confi
 	if config.Memory != nil {
 		values.Set("memory", strconv.FormatInt(*config.Memory, 10))
 	}
+	if config.Balloon != nil {
+		values.Set("balloon", strconv.FormatInt(*config.Balloon, 10))
+	}
 	if config.DiskSize != nil && config.Disk != "" {
 		// For disk resize, we need to modify the disk parameter
 		values.Set(config.Disk, fmt.Sprintf("size=%dG", *config.DiskSize))
@@ -1263,3 +1562,154 @@ func (c *Client) GetGuestNetworkInterfaces(ctx context.Context, node string, vmi
 
 	return result.Result, nil
 }
+
+// AgentPing checks that the VM's QEMU guest agent answers.
+func (c *Client) AgentPing(ctx context.Context, node string, vmid int) error {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/agent/ping", node, vmid)
+
+	resp, err := c.request(ctx, "POST", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to ping guest agent: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("guest agent ping failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// GuestExecStatus is the state of a command started with AgentExec. PVE
+// decodes the agent's base64 output itself.
+type GuestExecStatus struct {
+	Exited   bool
+	ExitCode int
+	OutData  string
+	ErrData  string
+}
+
+// AgentExec starts command in the guest through the QEMU guest agent and
+// returns its PID, to be polled with AgentExecStatus.
+func (c *Client) AgentExec(ctx context.Context, node string, vmid int, command []string) (int, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/agent/exec", node, vmid)
+
+	resp, err := c.request(ctx, "POST", path, url.Values{"command": command})
+	if err != nil {
+		return 0, fmt.Errorf("failed to exec in guest: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("guest exec failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Data struct {
+			PID int `json:"pid"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return apiResp.Data.PID, nil
+}
+
+// AgentExecStatus reports the state of a command started with AgentExec.
+func (c *Client) AgentExecStatus(ctx context.Context, node string, vmid int, pid int) (*GuestExecStatus, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/agent/exec-status?pid=%d", node, vmid, pid)
+
+	resp, err := c.request(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest exec status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get guest exec status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// PVE reports exited as 0/1 on some releases and as a boolean on others.
+	var apiResp struct {
+		Data struct {
+			Exited   json.RawMessage `json:"exited"`
+			ExitCode int             `json:"exitcode"`
+			OutData  string          `json:"out-data"`
+			ErrData  string          `json:"err-data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	exited := string(apiResp.Data.Exited)
+	return &GuestExecStatus{
+		Exited:   exited == "1" || exited == "true",
+		ExitCode: apiResp.Data.ExitCode,
+		OutData:  apiResp.Data.OutData,
+		ErrData:  apiResp.Data.ErrData,
+	}, nil
+}
+
+// VNCProxy is a VNC session PVE opened on a VM's display with VNCProxy.
+type VNCProxy struct {
+	Port   int
+	Ticket string
+}
+
+// VNCProxy opens a VNC session on a running VM's display for the
+// vncwebsocket endpoint. The ticket is also the session's VNC password, and
+// PVE drops the session unless it is connected to within seconds.
+func (c *Client) VNCProxy(ctx context.Context, node string, vmid int) (*VNCProxy, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/vncproxy", node, vmid)
+
+	resp, err := c.request(ctx, "POST", path, url.Values{"websocket": {"1"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open VNC proxy: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("open VNC proxy failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// PVE reports the port as a string.
+	var apiResp struct {
+		Data struct {
+			Port   json.Number `json:"port"`
+			Ticket string      `json:"ticket"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	port, err := strconv.Atoi(apiResp.Data.Port.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid VNC proxy port %q", apiResp.Data.Port)
+	}
+	return &VNCProxy{Port: port, Ticket: apiResp.Data.Ticket}, nil
+}
+
+// VNCWebSocketURL returns the vncwebsocket URL connecting to proxy, to be
+// dialed with AuthHeader.
+func (c *Client) VNCWebSocketURL(node string, vmid int, proxy *VNCProxy) string {
+	u := c.baseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/vncwebsocket", node, vmid)})
+	u.Scheme = "wss"
+	if c.baseURL.Scheme == "http" {
+		u.Scheme = "ws"
+	}
+	u.RawQuery = url.Values{"port": {strconv.Itoa(proxy.Port)}, "vncticket": {proxy.Ticket}}.Encode()
+	return u.String()
+}
+
+// AuthHeader returns the headers authenticating a request outside the
+// client, or nil when the client has no API token; password login is not
+// implemented.
+func (c *Client) AuthHeader() map[string]string {
+	if c.config.TokenID == "" || c.config.TokenSecret == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("PVEAPIToken=%s=%s", c.config.TokenID, c.config.TokenSecret)}
+}
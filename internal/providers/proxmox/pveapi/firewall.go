@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// FirewallRule is a rule of a guest firewall, as returned by
+// GET /nodes/{node}/qemu/{vmid}/firewall/rules. A rule of type "group"
+// applies the security group named by Action.
+type FirewallRule struct {
+	Pos     int    `json:"pos"`
+	Type    string `json:"type"`
+	Action  string `json:"action"`
+	Iface   string `json:"iface,omitempty"`
+	Enable  int    `json:"enable,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ListSDNVNets returns the IDs of the cluster's SDN vnets
+// (GET /cluster/sdn/vnets).
+func (c *Client) ListSDNVNets(ctx context.Context) ([]string, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/sdn/vnets", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SDN vnets: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list SDN vnets failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []struct {
+			VNet string `json:"vnet"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode SDN vnets response: %w", err)
+	}
+	vnets := make([]string, 0, len(out.Data))
+	for _, v := range out.Data {
+		vnets = append(vnets, v.VNet)
+	}
+	return vnets, nil
+}
+
+// ListSecurityGroups returns the names of the cluster firewall's security
+// groups (GET /cluster/firewall/groups).
+func (c *Client) ListSecurityGroups(ctx context.Context) ([]string, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/firewall/groups", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list security groups failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []struct {
+			Group string `json:"group"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode security groups response: %w", err)
+	}
+	groups := make([]string, 0, len(out.Data))
+	for _, g := range out.Data {
+		groups = append(groups, g.Group)
+	}
+	return groups, nil
+}
+
+// ListVMFirewallRules returns the rules of a VM's firewall in order.
+func (c *Client) ListVMFirewallRules(ctx context.Context, node string, vmid int) ([]FirewallRule, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/firewall/rules", node, vmid)
+
+	resp, err := c.request(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list firewall rules failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []FirewallRule `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode firewall rules: %w", err)
+	}
+	return out.Data, nil
+}
+
+// AddVMFirewallGroupRule adds an enabled rule applying security group to
+// the VM's interface iface ("net0"). An empty iface applies it to every
+// interface.
+func (c *Client) AddVMFirewallGroupRule(ctx context.Context, node string, vmid int, group, iface, comment string) error {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/firewall/rules", node, vmid)
+
+	values := url.Values{}
+	values.Set("type", "group")
+	values.Set("action", group)
+	values.Set("enable", "1")
+	if iface != "" {
+		values.Set("iface", iface)
+	}
+	if comment != "" {
+		values.Set("comment", comment)
+	}
+
+	resp, err := c.request(ctx, "POST", path, values)
+	if err != nil {
+		return fmt.Errorf("failed to add firewall rule: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("add firewall rule failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// DeleteVMFirewallRule deletes the VM firewall rule at pos. The rules after
+// it move up one position.
+func (c *Client) DeleteVMFirewallRule(ctx context.Context, node string, vmid, pos int) error {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/firewall/rules/%d", node, vmid, pos)
+
+	resp, err := c.request(ctx, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall rule: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete firewall rule failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// GetVMFirewallEnabled reports whether the VM's firewall is enabled
+// (GET /nodes/{node}/qemu/{vmid}/firewall/options).
+func (c *Client) GetVMFirewallEnabled(ctx context.Context, node string, vmid int) (bool, error) {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/firewall/options", node, vmid)
+
+	resp, err := c.request(ctx, "GET", path, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get firewall options: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("get firewall options failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data struct {
+			Enable int `json:"enable"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("failed to decode firewall options: %w", err)
+	}
+	return out.Data.Enable == 1, nil
+}
+
+// EnableVMFirewall turns the VM's firewall on. Rules, including the
+// interface firewall flag, have no effect while it is off.
+func (c *Client) EnableVMFirewall(ctx context.Context, node string, vmid int) error {
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/firewall/options", node, vmid)
+
+	resp, err := c.request(ctx, "PUT", path, url.Values{"enable": {"1"}})
+	if err != nil {
+		return fmt.Errorf("failed to enable firewall: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("enable firewall failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// HAResource is a guest managed by the PVE HA manager, as returned by
+// GET /cluster/ha/resources/{sid}.
+type HAResource struct {
+	SID   string `json:"sid"`             // "vm:<vmid>"
+	Group string `json:"group,omitempty"` // HA group restricting the nodes it may run on
+	State string `json:"state,omitempty"` // requested state: started, stopped, enabled, disabled or ignored
+}
+
+// HAStates are the requested states the HA manager accepts for a resource.
+var HAStates = []string{"started", "stopped", "enabled", "disabled", "ignored"}
+
+// haSID is the HA resource ID of a QEMU guest.
+func haSID(vmid int) string {
+	return fmt.Sprintf("vm:%d", vmid)
+}
+
+// haResourceMissing reports whether an HA API error body says the resource
+// is not configured. PVE answers 500 rather than 404 for an unknown SID.
+func haResourceMissing(status int, body string) bool {
+	return status == 404 || strings.Contains(body, "no such resource")
+}
+
+// GetHAResource returns the HA configuration of vmid, or nil if the HA
+// manager does not manage it.
+func (c *Client) GetHAResource(ctx context.Context, vmid int) (*HAResource, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/ha/resources/"+haSID(vmid), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HA resource: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		if haResourceMissing(resp.StatusCode, string(body)) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get HA resource failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data *HAResource `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode HA resource: %w", err)
+	}
+	return out.Data, nil
+}
+
+// AddHAResource puts vmid under HA management (POST /cluster/ha/resources).
+// An empty group lets the guest run on any node; an empty state leaves the
+// PVE default, started.
+func (c *Client) AddHAResource(ctx context.Context, vmid int, group, state string) error {
+	values := url.Values{}
+	values.Set("sid", haSID(vmid))
+	if group != "" {
+		values.Set("group", group)
+	}
+	if state != "" {
+		values.Set("state", state)
+	}
+
+	resp, err := c.request(ctx, "POST", "/api2/json/cluster/ha/resources", values)
+	if err != nil {
+		return fmt.Errorf("failed to add HA resource: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("add HA resource failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// RemoveHAResource takes vmid out of HA management
+// (DELETE /cluster/ha/resources/{sid}). A guest that is not managed is not
+// an error.
+func (c *Client) RemoveHAResource(ctx context.Context, vmid int) error {
+	resp, err := c.request(ctx, "DELETE", "/api2/json/cluster/ha/resources/"+haSID(vmid), nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove HA resource: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		if haResourceMissing(resp.StatusCode, string(body)) {
+			return nil
+		}
+		return fmt.Errorf("remove HA resource failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// HAMaintenanceNodes returns the nodes the HA manager has in maintenance
+// mode (GET /cluster/ha/status/manager_status). A cluster without an active
+// HA manager has none.
+func (c *Client) HAMaintenanceNodes(ctx context.Context) ([]string, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/ha/status/manager_status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HA manager status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get HA manager status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data struct {
+			ManagerStatus struct {
+				NodeStatus map[string]string `json:"node_status"`
+			} `json:"manager_status"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode HA manager status: %w", err)
+	}
+	var nodes []string
+	for node, state := range out.Data.ManagerStatus.NodeStatus {
+		if state == "maintenance" {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// ListPools returns the IDs of the cluster's resource pools (GET /pools).
+func (c *Client) ListPools(ctx context.Context) ([]string, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/pools", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pools: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list pools failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []struct {
+			PoolID string `json:"poolid"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode pools response: %w", err)
+	}
+	pools := make([]string, 0, len(out.Data))
+	for _, p := range out.Data {
+		pools = append(pools, p.PoolID)
+	}
+	return pools, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PVE declares the qemu sshkeys option with format "urlencoded": the value
+// must match ^[-%a-zA-Z0-9_.!~*'()]*$ and is percent-decoded by PVE before
+// it is split into authorized_keys lines. The web UI produces it with
+// JavaScript's encodeURIComponent, and this encoder reproduces that exactly;
+// the form encoding of the request body is then a second, ordinary layer.
+// Anything else (spaces as "+", raw "@" or "=", CR bytes from a Windows
+// editor) is rejected as "invalid format" or ends up verbatim in the key.
+
+// sshKeysSafe are the characters encodeURIComponent leaves as they are.
+const sshKeysSafe = "-_.!~*'()"
+
+// NormalizeSSHKeys splits keys into one authorized_keys entry per element.
+// Each input may hold several newline-separated keys, with LF or CRLF line
+// endings; blank lines and "#" comment lines are dropped, as are exact
+// duplicates. Key comments, including ones with spaces, are kept.
+func NormalizeSSHKeys(keys ...string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, k := range keys {
+		for _, line := range strings.Split(strings.ReplaceAll(k, "\r\n", "\n"), "\n") {
+			line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+			if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+				continue
+			}
+			seen[line] = true
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// EncodeSSHKeys renders keys as a PVE sshkeys value: the normalized keys
+// joined by LF and percent-encoded as encodeURIComponent does. It returns
+// "" when there are no keys.
+func EncodeSSHKeys(keys ...string) string {
+	normalized := NormalizeSSHKeys(keys...)
+	if len(normalized) == 0 {
+		return ""
+	}
+	raw := strings.Join(normalized, "\n")
+
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || strings.IndexByte(sshKeysSafe, c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte("0123456789ABCDEF"[c>>4])
+		b.WriteByte("0123456789ABCDEF"[c&0xF])
+	}
+	return b.String()
+}
+
+// SetSSHKeys sets the sshkeys option in values to the encoded keys, or
+// leaves it unset when there are none.
+func SetSSHKeys(values url.Values, keys ...string) {
+	if encoded := EncodeSSHKeys(keys...); encoded != "" {
+		values.Set("sshkeys", encoded)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rsaKey     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC7+x/z= alice laptop"
+	ed25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB/0 bob@example.com"
+	ecdsaKey   = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTY= ops (prod, rotated 2026)"
+)
+
+// pveURLEncoded is PVE's "urlencoded" format check for sshkeys.
+var pveURLEncoded = regexp.MustCompile(`^[-%a-zA-Z0-9_.!~*'()]*$`)
+
+// The expected values are what encodeURIComponent, as used by the PVE web
+// UI, produces for the same input.
+func TestEncodeSSHKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want string
+	}{
+		{"rsa with spaced comment", []string{rsaKey},
+			"ssh-rsa%20AAAAB3NzaC1yc2EAAAADAQABAAABAQC7%2Bx%2Fz%3D%20alice%20laptop"},
+		{"ed25519", []string{ed25519Key},
+			"ssh-ed25519%20AAAAC3NzaC1lZDI1NTE5AAAAIB%2F0%20bob%40example.com"},
+		{"ecdsa with punctuation in comment", []string{ecdsaKey},
+			"ecdsa-sha2-nistp256%20AAAAE2VjZHNhLXNoYTItbmlzdHAyNTY%3D%20ops%20(prod%2C%20rotated%202026)"},
+		{"multiple keys in one string", []string{ed25519Key + "\n" + rsaKey + "\n"},
+			"ssh-ed25519%20AAAAC3NzaC1lZDI1NTE5AAAAIB%2F0%20bob%40example.com%0Assh-rsa%20AAAAB3NzaC1yc2EAAAADAQABAAABAQC7%2Bx%2Fz%3D%20alice%20laptop"},
+		{"windows newlines", []string{ed25519Key + "\r\n" + rsaKey + "\r\n"},
+			"ssh-ed25519%20AAAAC3NzaC1lZDI1NTE5AAAAIB%2F0%20bob%40example.com%0Assh-rsa%20AAAAB3NzaC1yc2EAAAADAQABAAABAQC7%2Bx%2Fz%3D%20alice%20laptop"},
+		{"blank, comment and duplicate lines", []string{"\n# deploy keys\n" + ed25519Key, "  " + ed25519Key + "  "},
+			"ssh-ed25519%20AAAAC3NzaC1lZDI1NTE5AAAAIB%2F0%20bob%40example.com"},
+		{"nothing", []string{"", " \r\n"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeSSHKeys(tt.in...)
+			assert.Equal(t, tt.want, got)
+			assert.Regexp(t, pveURLEncoded, got)
+		})
+	}
+}
+
+// TestSSHKeysRequestBody checks the value reaches PVE encoded twice, once
+// by EncodeSSHKeys and once by the form encoding, and decodes back to the
+// keys.
+func TestSSHKeysRequestBody(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = io.WriteString(w, `{"data":null}`)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL, TokenID: "root@pam!t", TokenSecret: "s"})
+	require.NoError(t, err)
+
+	values := url.Values{}
+	values.Set("ciuser", "ubuntu")
+	SetSSHKeys(values, rsaKey+"\r\n"+ed25519Key)
+	_, err = c.ReconfigureVMRaw(context.Background(), "pve", 100, values)
+	require.NoError(t, err)
+
+	assert.Contains(t, body, "sshkeys=ssh-rsa%2520AAAAB3NzaC1yc2EAAAADAQABAAABAQC7%252Bx%252Fz%253D%2520alice%2520laptop%250Assh-ed25519")
+	form, err := url.ParseQuery(body)
+	require.NoError(t, err)
+	decoded, err := url.PathUnescape(form.Get("sshkeys"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{rsaKey, ed25519Key}, strings.Split(decoded, "\n"))
+	assert.Equal(t, "ubuntu", form.Get("ciuser"))
+}
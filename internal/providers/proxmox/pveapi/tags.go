@@ -0,0 +1,63 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SplitTags splits a guest's tags option. PVE stores tags ";"-separated but
+// accepts "," and spaces as separators too.
+func SplitTags(tags string) []string {
+	return strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+}
+
+// HasTag reports whether the guest carries tag.
+func (vm ClusterVM) HasTag(tag string) bool {
+	return slices.Contains(SplitTags(vm.Tags), tag)
+}
+
+// AddVMTags adds tags to the ones vmid already has, e.g. those a clone
+// inherited from its template.
+func (c *Client) AddVMTags(ctx context.Context, node string, vmid int, tags ...string) error {
+	config, err := c.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return err
+	}
+	existing, _ := config["tags"].(string)
+	merged := SplitTags(existing)
+	for _, tag := range tags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	taskID, err := c.ReconfigureVMRaw(ctx, node, vmid, url.Values{"tags": {strings.Join(merged, ";")}})
+	if err != nil {
+		return fmt.Errorf("failed to set VM tags: %w", err)
+	}
+	if taskID != "" {
+		return c.WaitForTask(ctx, node, taskID)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pveapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ClusterTask is an entry of the cluster-wide task log, as returned by
+// GET /cluster/tasks. PVE keeps the most recent tasks of every node there.
+type ClusterTask struct {
+	UPID      string `json:"upid"`
+	Node      string `json:"node"`
+	Type      string `json:"type"` // e.g. "qmstart", "vzdump", "qmigrate"
+	ID        string `json:"id"`   // the VMID for guest tasks
+	User      string `json:"user"`
+	TokenID   string `json:"tokenid,omitempty"` // token name, when started with an API token
+	StartTime int64  `json:"starttime"`
+	EndTime   int64  `json:"endtime,omitempty"` // zero while running
+	// Status is "OK", "WARNINGS: <n>" or the error a failed task ended
+	// with; empty while running.
+	Status string `json:"status,omitempty"`
+}
+
+// Owner returns who started the task, as "user@realm" or, for API tokens,
+// "user@realm!token".
+func (t ClusterTask) Owner() string {
+	if t.TokenID != "" {
+		return t.User + "!" + t.TokenID
+	}
+	return t.User
+}
+
+// ClusterTasks returns the cluster task log (GET /cluster/tasks).
+func (c *Client) ClusterTasks(ctx context.Context) ([]ClusterTask, error) {
+	resp, err := c.request(ctx, "GET", "/api2/json/cluster/tasks", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster tasks: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close is not critical
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list cluster tasks failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Data []ClusterTask `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster tasks: %w", err)
+	}
+	return out.Data, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvefake
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Fault makes requests to one route of the fake fail. Unlike the
+// FAKE_PVE_FAILURE_MODE environment variable, a fault belongs to the
+// server it was injected into, so tests running in parallel each get their
+// own.
+type Fault struct {
+	// Method is the HTTP method the fault applies to; empty matches any.
+	Method string
+	// Route is the route template below /api2/json, as RequestCount takes
+	// it, e.g. "/nodes/{node}/qemu/{vmid}/clone".
+	Route string
+	// Status is the HTTP status returned; zero means 500.
+	Status int
+	// Message is the error PVE reports; empty means "Injected failure".
+	Message string
+	// Times is how many requests fail before the route recovers; zero
+	// means every request fails until ClearFaults.
+	Times int
+}
+
+// InjectFault makes requests matching f fail. Safe for concurrent use.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault and task failure. Safe for
+// concurrent use.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+	s.taskFailures = nil
+}
+
+// FailTasks makes every task of taskType, e.g. "qmclone" or "qmsnapshot",
+// finish with exitStatus instead of OK. Safe for concurrent use.
+func (s *Server) FailTasks(taskType, exitStatus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.taskFailures == nil {
+		s.taskFailures = make(map[string]string)
+	}
+	s.taskFailures[taskType] = exitStatus
+}
+
+// SetLatency delays every request to route, a template as Fault.Route
+// takes it, by d before it is served. An empty route delays every request.
+// Safe for concurrent use.
+func (s *Server) SetLatency(route string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[route] = d
+}
+
+// SetTaskDelay sets how long tasks run before they complete. Safe for
+// concurrent use.
+func (s *Server) SetTaskDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.TaskDelay = d
+}
+
+// injectFaults delays and fails routed requests as SetLatency and
+// InjectFault configured.
+func (s *Server) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := ""
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = strings.TrimPrefix(tmpl, "/api2/json")
+			}
+		}
+
+		s.mu.Lock()
+		delay := s.latency[""] + s.latency[route]
+		var fault *Fault
+		for i, f := range s.faults {
+			if f.Route != route || (f.Method != "" && f.Method != r.Method) {
+				continue
+			}
+			fault = f
+			if f.Times > 0 {
+				if f.Times--; f.Times == 0 {
+					s.faults = append(s.faults[:i], s.faults[i+1:]...)
+				}
+			}
+			break
+		}
+		s.mu.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault != nil {
+			status, message := fault.Status, fault.Message
+			if status == 0 {
+				status = http.StatusInternalServerError
+			}
+			if message == "" {
+				message = "Injected failure"
+			}
+			s.writeError(w, status, message)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewTestServer starts a fake PVE server for t on a loopback port of its
+// own and returns it with its endpoint. Tasks complete after 50ms rather
+// than the default 2s. The server is closed when t ends, so tests can run
+// in parallel, each against its own fake.
+func NewTestServer(t testing.TB) (*Server, string) {
+	t.Helper()
+	s := NewServer()
+	s.SetTaskDelay(50 * time.Millisecond)
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return s, ts.URL
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvefake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// FirewallRule is a rule of a VM's fake firewall.
+type FirewallRule struct {
+	Type    string
+	Action  string
+	Iface   string
+	Enable  bool
+	Comment string
+}
+
+// vmFirewall is the firewall state of one VM.
+type vmFirewall struct {
+	enabled bool
+	rules   []FirewallRule
+}
+
+// AddVNet creates an SDN vnet VMs can attach to.
+func (s *Server) AddVNet(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vnets[name] = true
+}
+
+// AddSecurityGroup creates a cluster firewall security group.
+func (s *Server) AddSecurityGroup(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secGroups[name] = true
+}
+
+// FirewallRules returns the firewall rules of vmid in position order.
+func (s *Server) FirewallRules(vmid int) []FirewallRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if fw, ok := s.firewalls[vmid]; ok {
+		return append([]FirewallRule(nil), fw.rules...)
+	}
+	return nil
+}
+
+// FirewallEnabled reports whether the firewall of vmid is enabled.
+func (s *Server) FirewallEnabled(vmid int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fw, ok := s.firewalls[vmid]
+	return ok && fw.enabled
+}
+
+// SetFirewallRules replaces the firewall rules of vmid, e.g. with rules a
+// test pretends were added by hand.
+func (s *Server) SetFirewallRules(vmid int, rules []FirewallRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vmFirewall(vmid).rules = append([]FirewallRule(nil), rules...)
+}
+
+// vmFirewall returns the firewall state of vmid, creating it. The caller
+// holds s.mu.
+func (s *Server) vmFirewall(vmid int) *vmFirewall {
+	fw, ok := s.firewalls[vmid]
+	if !ok {
+		fw = &vmFirewall{}
+		s.firewalls[vmid] = fw
+	}
+	return fw
+}
+
+// firewallVM parses the vmid route variable and checks the VM exists,
+// writing the error response when it does not. The caller holds s.mu.
+func (s *Server) firewallVM(w http.ResponseWriter, r *http.Request) (int, bool) {
+	vmid, err := strconv.Atoi(mux.Vars(r)["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return 0, false
+	}
+	if _, exists := s.vms[vmid]; !exists {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to find configuration file for VM %d", vmid))
+		return 0, false
+	}
+	return vmid, true
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// handleListVNets mimics GET /cluster/sdn/vnets.
+func (s *Server) handleListVNets(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]map[string]interface{}, 0, len(s.vnets))
+	for _, vnet := range sortedKeys(s.vnets) {
+		list = append(list, map[string]interface{}{"vnet": vnet, "zone": "tenants", "type": "vnet"})
+	}
+	s.writeResponse(w, list)
+}
+
+// handleListSecurityGroups mimics GET /cluster/firewall/groups.
+func (s *Server) handleListSecurityGroups(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]map[string]interface{}, 0, len(s.secGroups))
+	for _, group := range sortedKeys(s.secGroups) {
+		list = append(list, map[string]interface{}{"group": group, "digest": "0"})
+	}
+	s.writeResponse(w, list)
+}
+
+// handleListFirewallRules mimics GET /nodes/{node}/qemu/{vmid}/firewall/rules.
+func (s *Server) handleListFirewallRules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vmid, ok := s.firewallVM(w, r)
+	if !ok {
+		return
+	}
+
+	rules := s.vmFirewall(vmid).rules
+	list := make([]map[string]interface{}, 0, len(rules))
+	for pos, rule := range rules {
+		entry := map[string]interface{}{
+			"pos":    pos,
+			"type":   rule.Type,
+			"action": rule.Action,
+			"enable": 0,
+		}
+		if rule.Enable {
+			entry["enable"] = 1
+		}
+		if rule.Iface != "" {
+			entry["iface"] = rule.Iface
+		}
+		if rule.Comment != "" {
+			entry["comment"] = rule.Comment
+		}
+		list = append(list, entry)
+	}
+	s.writeResponse(w, list)
+}
+
+// handleAddFirewallRule mimics POST /nodes/{node}/qemu/{vmid}/firewall/rules.
+// Like PVE it inserts the rule at position 0 and refuses a group rule for a
+// security group that does not exist.
+func (s *Server) handleAddFirewallRule(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vmid, ok := s.firewallVM(w, r)
+	if !ok {
+		return
+	}
+
+	rule := FirewallRule{
+		Type:    r.FormValue("type"),
+		Action:  r.FormValue("action"),
+		Iface:   r.FormValue("iface"),
+		Enable:  r.FormValue("enable") == "1",
+		Comment: r.FormValue("comment"),
+	}
+	if rule.Type == "group" && !s.secGroups[rule.Action] {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("security group '%s' does not exist", rule.Action))
+		return
+	}
+	fw := s.vmFirewall(vmid)
+	fw.rules = append([]FirewallRule{rule}, fw.rules...)
+	s.writeResponse(w, nil)
+}
+
+// handleDeleteFirewallRule mimics
+// DELETE /nodes/{node}/qemu/{vmid}/firewall/rules/{pos}.
+func (s *Server) handleDeleteFirewallRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vmid, ok := s.firewallVM(w, r)
+	if !ok {
+		return
+	}
+
+	fw := s.vmFirewall(vmid)
+	pos, err := strconv.Atoi(mux.Vars(r)["pos"])
+	if err != nil || pos < 0 || pos >= len(fw.rules) {
+		s.writeError(w, http.StatusBadRequest, "no rule at position "+mux.Vars(r)["pos"])
+		return
+	}
+	fw.rules = append(fw.rules[:pos], fw.rules[pos+1:]...)
+	s.writeResponse(w, nil)
+}
+
+// handleGetFirewallOptions mimics
+// GET /nodes/{node}/qemu/{vmid}/firewall/options.
+func (s *Server) handleGetFirewallOptions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vmid, ok := s.firewallVM(w, r)
+	if !ok {
+		return
+	}
+
+	enable := 0
+	if s.vmFirewall(vmid).enabled {
+		enable = 1
+	}
+	s.writeResponse(w, map[string]interface{}{"enable": enable, "policy_in": "DROP"})
+}
+
+// handleSetFirewallOptions mimics
+// PUT /nodes/{node}/qemu/{vmid}/firewall/options for the enable option.
+func (s *Server) handleSetFirewallOptions(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vmid, ok := s.firewallVM(w, r)
+	if !ok {
+		return
+	}
+	if enable := r.FormValue("enable"); enable != "" {
+		s.vmFirewall(vmid).enabled = enable == "1"
+	}
+	s.writeResponse(w, nil)
+}
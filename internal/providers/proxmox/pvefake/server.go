@@ -21,11 +21,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math/rand"
 	"net"
 	"net/http"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,14 +42,35 @@ type Server struct {
 	vms          map[int]*VM
 	tasks        map[string]*Task
 	snapshots    map[string][]*Snapshot
+	pools        map[string]bool
+	haResources  map[int]*HAResource
+	nodeStorages map[string]map[string]Storage
+	nodes        []Node
+	haMaintained map[string]bool
 	lastDownload *DownloadRequest
 	lastPowerOp  *PowerOpRequest
+	requests     map[string]int
 	nextID       int
+	nextPID      int
+	guestExecs   map[int]*guestExecResult
+	vnets        map[string]bool
+	secGroups    map[string]bool
+	firewalls    map[int]*vmFirewall
+	clusterTasks []ClusterTask
+	faults       []*Fault
+	taskFailures map[string]string
+	latency      map[string]time.Duration
 	mu           sync.RWMutex
 	logger       *slog.Logger
 	config       *Config
 }
 
+// guestExecResult is the outcome of a fake guest agent exec.
+type guestExecResult struct {
+	exitCode       int
+	stdout, stderr string
+}
+
 // DownloadRequest records the parameters of the most recent storage download-url
 // request so ImagePrepare tests can assert node/storage/content/filename/url
 // propagation.
@@ -81,6 +106,155 @@ func (s *Server) LastPowerOp() *PowerOpRequest {
 	return s.lastPowerOp
 }
 
+// HAResource is a guest's entry in the fake HA manager.
+type HAResource struct {
+	Group string
+	State string
+}
+
+// AddPool creates a resource pool VMs can be placed in.
+func (s *Server) AddPool(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pools[name] = true
+}
+
+// Storage is a storage AddStorage configures on one node.
+type Storage struct {
+	Content  string
+	TotalGiB int64
+	UsedGiB  int64
+	// Inactive marks a disabled storage, or one the node cannot reach.
+	Inactive bool
+}
+
+// Node is a cluster node SetNodes configures.
+type Node struct {
+	Name   string
+	Status string // "online" when empty
+	// MemTotalGiB and MemUsedGiB are the node's memory and its use.
+	MemTotalGiB int64
+	MemUsedGiB  int64
+}
+
+// SetNodes replaces the cluster's stock nodes, "pve" and "pve2", so tests
+// can give placement different inventories.
+func (s *Server) SetNodes(nodes ...Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes = slices.Clone(nodes)
+}
+
+// SetHAMaintenance puts node in or out of HA maintenance mode.
+func (s *Server) SetHAMaintenance(node string, maintenance bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.haMaintained[node] = maintenance
+}
+
+// AddStorage configures storage name on node alongside the stock "local"
+// and "local-lvm", replacing a stock storage of the same name there, so
+// tests can give nodes different storage layouts.
+func (s *Server) AddStorage(node, name string, storage Storage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nodeStorages[node] == nil {
+		s.nodeStorages[node] = map[string]Storage{}
+	}
+	s.nodeStorages[node][name] = storage
+}
+
+// storageStatus returns the status of storage on node, or false if the
+// node does not have it.
+func (s *Server) storageStatus(node, storage string) (map[string]interface{}, bool) {
+	s.mu.RLock()
+	added, ok := s.nodeStorages[node][storage]
+	s.mu.RUnlock()
+	if ok {
+		active := 1
+		if added.Inactive {
+			active = 0
+		}
+		return map[string]interface{}{
+			"type":    "rbd",
+			"content": added.Content,
+			"active":  active,
+			"enabled": active,
+			"total":   added.TotalGiB << 30,
+			"used":    added.UsedGiB << 30,
+		}, true
+	}
+	if _, ok := fakeStorages[storage]; !ok {
+		return nil, false
+	}
+	return fakeStorageStatus(storage), true
+}
+
+// HAResource returns the HA entry of vmid, or nil if it has none.
+func (s *Server) HAResource(vmid int) *HAResource {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if res, ok := s.haResources[vmid]; ok {
+		copied := *res
+		return &copied
+	}
+	return nil
+}
+
+// VMPool returns the pool vmid was placed in, if any.
+func (s *Server) VMPool(vmid int) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if vm, ok := s.vms[vmid]; ok {
+		return vm.Pool
+	}
+	return ""
+}
+
+// VMNode returns the node vmid is on, or "" if there is no such VM.
+func (s *Server) VMNode(vmid int) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if vm, ok := s.vms[vmid]; ok {
+		return vm.Node
+	}
+	return ""
+}
+
+// VMLinked reports whether vmid was made as a linked clone.
+func (s *Server) VMLinked(vmid int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if vm, ok := s.vms[vmid]; ok {
+		return vm.Linked
+	}
+	return false
+}
+
+// AddVM adds vm to the fake cluster as if it had been created there.
+func (s *Server) AddVM(vm *VM) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vms[vm.VMID] = vm
+}
+
+// EnableConfigLocks makes config writes to a VM fail while an earlier
+// config or resize task on it runs. Safe for concurrent use.
+func (s *Server) EnableConfigLocks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.ConfigLocks = true
+}
+
+// RequestCount returns how many requests the server received whose path,
+// below /api2/json, matches the route template, e.g.
+// "/nodes/{node}/qemu/{vmid}/status/current".
+func (s *Server) RequestCount(route string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.requests[route]
+}
+
 // Config holds fake server configuration
 type Config struct {
 	// FailureMode can be "none", "random", "always"
@@ -91,6 +265,9 @@ type Config struct {
 	FailureRate float64
 	// TaskDelay simulates async task processing time
 	TaskDelay time.Duration
+	// ConfigLocks makes a config write fail while a config or resize task
+	// on the same VM is still running, as PVE's per-VM config lock does
+	ConfigLocks bool
 }
 
 // VM represents a fake VM in the server
@@ -101,14 +278,37 @@ type VM struct {
 	Node      string            `json:"node"`
 	CPUs      int               `json:"cpus,omitempty"`
 	Memory    int64             `json:"maxmem,omitempty"`
+	CPU       float64           `json:"cpu,omitempty"`
+	Mem       int64             `json:"mem,omitempty"`
+	Uptime    int64             `json:"uptime,omitempty"`
 	Template  int               `json:"template,omitempty"`
 	QMPStatus string            `json:"qmpstatus,omitempty"`
 	PID       int               `json:"pid,omitempty"`
 	Lock      string            `json:"lock,omitempty"`
+	Pool      string            `json:"-"`
+	Tags      string            `json:"tags,omitempty"` // ";"-separated
 	Config    map[string]string `json:"-"`
 	Networks  []NetworkConfig   `json:"-"`
 	IPAddrs   []string          `json:"-"`
 	CreatedAt time.Time         `json:"-"`
+
+	// GuestExec answers guest agent exec requests while the VM runs. When
+	// nil, every command exits 127 as if it were not installed.
+	GuestExec func(command []string) (exitCode int, stdout, stderr string) `json:"-"`
+	// Linked records that the VM was cloned with full=0, sharing its
+	// template's disks.
+	Linked bool `json:"-"`
+	// AgentDown makes the guest agent ping fail although the VM runs.
+	AgentDown bool `json:"-"`
+	// BalloonInfo is reported by status/current as the guest's balloon
+	// driver would; nil means no driver is loaded.
+	BalloonInfo *BalloonInfo `json:"ballooninfo,omitempty"`
+}
+
+// BalloonInfo is the balloon driver's view of guest memory, in bytes.
+type BalloonInfo struct {
+	Actual int64 `json:"actual"`
+	MaxMem int64 `json:"max_mem"`
 }
 
 // NetworkConfig represents a fake network interface
@@ -171,12 +371,21 @@ func NewServer() *Server {
 	}
 
 	s := &Server{
-		router:    mux.NewRouter(),
-		vms:       make(map[int]*VM),
-		tasks:     make(map[string]*Task),
-		snapshots: make(map[string][]*Snapshot),
-		logger:    slog.Default(),
-		config:    config,
+		router:       mux.NewRouter(),
+		vms:          make(map[int]*VM),
+		tasks:        make(map[string]*Task),
+		snapshots:    make(map[string][]*Snapshot),
+		pools:        make(map[string]bool),
+		haResources:  make(map[int]*HAResource),
+		nodeStorages: make(map[string]map[string]Storage),
+		haMaintained: make(map[string]bool),
+		requests:     make(map[string]int),
+		vnets:        make(map[string]bool),
+		secGroups:    make(map[string]bool),
+		firewalls:    make(map[int]*vmFirewall),
+		latency:      make(map[string]time.Duration),
+		logger:       slog.Default(),
+		config:       config,
 	}
 
 	s.setupRoutes()
@@ -192,6 +401,7 @@ func (s *Server) setupRoutes() {
 	// Cluster
 	api.HandleFunc("/nodes", s.handleListNodes).Methods("GET")
 	api.HandleFunc("/cluster/nextid", s.handleClusterNextID).Methods("GET")
+	api.HandleFunc("/version", s.handleVersion).Methods("GET")
 
 	// VM operations
 	api.HandleFunc("/nodes/{node}/qemu", s.handleCreateVM).Methods("POST")
@@ -202,27 +412,72 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/config", s.handleReconfigureVM).Methods("PUT")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/resize", s.handleResizeDisk).Methods("PUT")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/clone", s.handleCloneVM).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/template", s.handleConvertToTemplate).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/agent/network-get-interfaces", s.handleGuestNetworkInterfaces).Methods("GET")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/agent/exec", s.handleGuestExec).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/agent/exec-status", s.handleGuestExecStatus).Methods("GET")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/agent/ping", s.handleGuestPing).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/vncproxy", s.handleVNCProxy).Methods("POST")
 
 	// Power operations
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/status/start", s.handlePowerOp("start")).Methods("POST")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/status/stop", s.handlePowerOp("stop")).Methods("POST")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/status/reboot", s.handlePowerOp("reboot")).Methods("POST")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/status/shutdown", s.handlePowerOp("shutdown")).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/status/reset", s.handlePowerOp("reset")).Methods("POST")
 
 	// Storage operations
 	api.HandleFunc("/nodes/{node}/storage/{storage}/download-url", s.handleDownloadURL).Methods("POST")
 	api.HandleFunc("/nodes/{node}/storage/{storage}/content", s.handleStorageContent).Methods("GET")
+	api.HandleFunc("/nodes/{node}/storage/{storage}/status", s.handleStorageStatus).Methods("GET")
+	api.HandleFunc("/nodes/{node}/storage", s.handleListStorages).Methods("GET")
+	api.HandleFunc("/cluster/resources", s.handleClusterResources).Methods("GET")
+	api.HandleFunc("/nodes/{node}/status", s.handleNodeStatus).Methods("GET")
+	api.HandleFunc("/pools", s.handleListPools).Methods("GET")
+
+	// HA manager
+	api.HandleFunc("/cluster/ha/resources", s.handleAddHAResource).Methods("POST")
+	api.HandleFunc("/cluster/ha/status/manager_status", s.handleHAManagerStatus).Methods("GET")
+	api.HandleFunc("/cluster/ha/resources/{sid}", s.handleGetHAResource).Methods("GET")
+	api.HandleFunc("/cluster/ha/resources/{sid}", s.handleRemoveHAResource).Methods("DELETE")
+
+	// SDN and firewall
+	api.HandleFunc("/cluster/sdn/vnets", s.handleListVNets).Methods("GET")
+	api.HandleFunc("/cluster/firewall/groups", s.handleListSecurityGroups).Methods("GET")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/firewall/rules", s.handleListFirewallRules).Methods("GET")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/firewall/rules", s.handleAddFirewallRule).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/firewall/rules/{pos}", s.handleDeleteFirewallRule).Methods("DELETE")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/firewall/options", s.handleGetFirewallOptions).Methods("GET")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/firewall/options", s.handleSetFirewallOptions).Methods("PUT")
 
 	// Task operations
 	api.HandleFunc("/nodes/{node}/tasks/{taskid}/status", s.handleGetTaskStatus).Methods("GET")
+	api.HandleFunc("/cluster/tasks", s.handleClusterTasks).Methods("GET")
 
 	// Snapshot operations
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/snapshot", s.handleCreateSnapshot).Methods("POST")
+	api.HandleFunc("/nodes/{node}/qemu/{vmid}/snapshot", s.handleListSnapshots).Methods("GET")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/snapshot/{snapname}", s.handleDeleteSnapshot).Methods("DELETE")
 	api.HandleFunc("/nodes/{node}/qemu/{vmid}/snapshot/{snapname}/rollback", s.handleRevertSnapshot).Methods("POST")
 
 	// Health check
 	s.router.HandleFunc("/health", s.handleHealth).Methods("GET")
+
+	s.router.Use(s.countRequests, s.injectFaults)
+}
+
+// countRequests records each routed request under its route template.
+func (s *Server) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				s.mu.Lock()
+				s.requests[strings.TrimPrefix(tmpl, "/api2/json")]++
+				s.mu.Unlock()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 // seedData creates some initial test data
@@ -247,6 +502,8 @@ func (s *Server) seedData() {
 		Node:      "pve",
 		CPUs:      2,
 		Memory:    2048 * 1024 * 1024, // 2GB in bytes
+		CPU:       0.25,
+		Mem:       512 * 1024 * 1024,
 		QMPStatus: "running",
 		PID:       12345,
 		CreatedAt: time.Now(),
@@ -317,6 +574,12 @@ func (s *Server) handleCreateVM(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pool := r.FormValue("pool")
+	if pool != "" && !s.pools[pool] {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("pool '%s' does not exist", pool))
+		return
+	}
+
 	// Create new VM
 	vm := &VM{
 		VMID:      vmid,
@@ -324,6 +587,8 @@ func (s *Server) handleCreateVM(w http.ResponseWriter, r *http.Request) {
 		Status:    "stopped",
 		Node:      node,
 		QMPStatus: "stopped",
+		Pool:      pool,
+		Tags:      r.FormValue("tags"),
 		CreatedAt: time.Now(),
 	}
 
@@ -339,6 +604,7 @@ func (s *Server) handleCreateVM(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	recordHardwareConfig(vm, r)
 	s.vms[vmid] = vm
 
 	// Create async task
@@ -350,13 +616,51 @@ func (s *Server) handleCreateVM(w http.ResponseWriter, r *http.Request) {
 // handleListVMs handles listing VMs on a node (GET /nodes/{node}/qemu). It
 // returns all seeded/created VMs, including templates, so ImagePrepare's
 // verify-by-name and idempotency probes can find them.
-// handleListNodes returns the cluster's nodes. It reports two nodes (one not
-// named "pve") so a test can prove the provider discovers the node from the API
-// rather than assuming the legacy "pve" default.
+// handleListNodes returns the cluster's nodes. Unless SetNodes replaced them it
+// reports two nodes (one not named "pve") so a test can prove the provider
+// discovers the node from the API rather than assuming the legacy "pve" default.
 func (s *Server) handleListNodes(w http.ResponseWriter, _ *http.Request) {
-	s.writeResponse(w, []map[string]interface{}{
-		{"node": "pve", "status": "online"},
-		{"node": "pve2", "status": "online"},
+	s.mu.RLock()
+	nodes := s.nodes
+	s.mu.RUnlock()
+	if nodes == nil {
+		s.writeResponse(w, []map[string]interface{}{
+			{"node": "pve", "status": "online"},
+			{"node": "pve2", "status": "online"},
+		})
+		return
+	}
+	out := make([]map[string]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		status := n.Status
+		if status == "" {
+			status = "online"
+		}
+		out = append(out, map[string]interface{}{
+			"node":   n.Name,
+			"status": status,
+			"maxmem": n.MemTotalGiB << 30,
+			"mem":    n.MemUsedGiB << 30,
+		})
+	}
+	s.writeResponse(w, out)
+}
+
+// handleHAManagerStatus mimics GET /cluster/ha/status/manager_status,
+// reporting each node SetHAMaintenance put in maintenance mode.
+func (s *Server) handleHAManagerStatus(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	nodeStatus := map[string]string{}
+	for node, maintenance := range s.haMaintained {
+		if maintenance {
+			nodeStatus[node] = "maintenance"
+		} else {
+			nodeStatus[node] = "online"
+		}
+	}
+	s.mu.RUnlock()
+	s.writeResponse(w, map[string]interface{}{
+		"manager_status": map[string]interface{}{"node_status": nodeStatus},
 	})
 }
 
@@ -383,6 +687,8 @@ func (s *Server) handleClusterNextID(w http.ResponseWriter, _ *http.Request) {
 // GetDiskInfo can report true values instead of guessing from the config string.
 // The reported size (20 GiB) deliberately differs from the config's 32 GiB so a
 // test can prove the values came from the storage API, not the config.
+// Snapshots taken with RAM add a vm-<vmid>-state-<snap> volume on storages
+// that hold images.
 func (s *Server) handleStorageContent(w http.ResponseWriter, r *http.Request) {
 	storage := mux.Vars(r)["storage"]
 
@@ -395,12 +701,257 @@ func (s *Server) handleStorageContent(w http.ResponseWriter, r *http.Request) {
 			"used":   int64(5 * 1024 * 1024 * 1024),  // 5 GiB actually used (thin)
 			"format": "raw",
 		})
+		if !strings.Contains(fakeStorages[storage], "images") {
+			continue
+		}
+		for _, snap := range s.snapshots[strconv.Itoa(vmid)] {
+			if snap.VMSTATE == 0 {
+				continue
+			}
+			vols = append(vols, map[string]interface{}{
+				"volid":  fmt.Sprintf("%s:vm-%d-state-%s", storage, vmid, snap.Name),
+				"size":   int64(4 * 1024 * 1024 * 1024), // 4 GiB of RAM state
+				"used":   int64(3 * 1024 * 1024 * 1024),
+				"format": "raw",
+			})
+		}
 	}
 	s.mu.RUnlock()
 
 	s.writeResponse(w, vols)
 }
 
+// fakeStorages mirrors a stock PVE install: "local" is a directory storage
+// for ISOs/templates/backups only, "local-lvm" holds VM disks.
+var fakeStorages = map[string]string{
+	"local":     "iso,vztmpl,backup",
+	"local-lvm": "images,rootdir",
+}
+
+// handleStorageStatus mimics PVE's /nodes/{node}/storage/{storage}/status,
+// answering 500 for an unknown storage as PVE does.
+func (s *Server) handleStorageStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	status, ok := s.storageStatus(vars["node"], vars["storage"])
+	if !ok {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("storage '%s' does not exist", vars["storage"]))
+		return
+	}
+	s.writeResponse(w, status)
+}
+
+// handleListStorages mimics PVE's /nodes/{node}/storage: every storage on
+// the node with the same figures as its status endpoint.
+func (s *Server) handleListStorages(w http.ResponseWriter, r *http.Request) {
+	node := mux.Vars(r)["node"]
+	names := slices.Collect(maps.Keys(fakeStorages))
+	s.mu.RLock()
+	for name := range s.nodeStorages[node] {
+		if _, stock := fakeStorages[name]; !stock {
+			names = append(names, name)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Strings(names)
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		entry, _ := s.storageStatus(node, name)
+		entry["storage"] = name
+		list = append(list, entry)
+	}
+	s.writeResponse(w, list)
+}
+
+func fakeStorageStatus(storage string) map[string]interface{} {
+	storageType := "dir"
+	if storage == "local-lvm" {
+		storageType = "lvmthin"
+	}
+	return map[string]interface{}{
+		"type":    storageType,
+		"content": fakeStorages[storage],
+		"active":  1,
+		"enabled": 1,
+		"total":   int64(500) << 30,
+		"used":    int64(125) << 30,
+	}
+}
+
+// handleClusterResources mimics PVE's /cluster/resources?type=vm, placing
+// VMs created without a node on "pve".
+func (s *Server) handleClusterResources(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]map[string]interface{}, 0, len(s.vms))
+	for vmid, vm := range s.vms {
+		node := vm.Node
+		if node == "" {
+			node = "pve"
+		}
+		entry := map[string]interface{}{
+			"vmid":   vmid,
+			"node":   node,
+			"name":   vm.Name,
+			"type":   "qemu",
+			"status": vm.Status,
+		}
+		if vm.Pool != "" {
+			entry["pool"] = vm.Pool
+		}
+		if vm.Tags != "" {
+			entry["tags"] = vm.Tags
+		}
+		if vm.Status == "running" {
+			entry["cpu"] = vm.CPU
+			entry["mem"] = vm.Mem
+			entry["uptime"] = vm.Uptime
+		}
+		if vm.Memory != 0 {
+			entry["maxmem"] = vm.Memory
+		}
+		if vm.Lock != "" {
+			entry["lock"] = vm.Lock
+		}
+		list = append(list, entry)
+	}
+	s.writeResponse(w, list)
+}
+
+// handleListPools mimics PVE's GET /pools.
+func (s *Server) handleListPools(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]map[string]interface{}, 0, len(s.pools))
+	for pool := range s.pools {
+		list = append(list, map[string]interface{}{"poolid": pool})
+	}
+	s.writeResponse(w, list)
+}
+
+// parseHASID parses a "vm:<vmid>" HA resource ID.
+func parseHASID(sid string) (int, bool) {
+	id, ok := strings.CutPrefix(sid, "vm:")
+	if !ok {
+		return 0, false
+	}
+	vmid, err := strconv.Atoi(id)
+	return vmid, err == nil
+}
+
+// handleAddHAResource mimics POST /cluster/ha/resources. Like PVE it refuses
+// a guest that is already managed.
+func (s *Server) handleAddHAResource(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	sid := r.FormValue("sid")
+	vmid, ok := parseHASID(sid)
+	if !ok {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid resource ID '%s'", sid))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.vms[vmid]; !exists {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to find configuration file for VM %d", vmid))
+		return
+	}
+	if _, exists := s.haResources[vmid]; exists {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("resource ID '%s' already defined", sid))
+		return
+	}
+	state := r.FormValue("state")
+	if state == "" {
+		state = "started"
+	}
+	s.haResources[vmid] = &HAResource{Group: r.FormValue("group"), State: state}
+	s.writeResponse(w, nil)
+}
+
+// handleGetHAResource mimics GET /cluster/ha/resources/{sid}, including the
+// 500 PVE answers for an unmanaged guest.
+func (s *Server) handleGetHAResource(w http.ResponseWriter, r *http.Request) {
+	sid := mux.Vars(r)["sid"]
+	vmid, _ := parseHASID(sid)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res, ok := s.haResources[vmid]
+	if !ok {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("no such resource '%s'", sid))
+		return
+	}
+	s.writeResponse(w, map[string]interface{}{
+		"sid":   sid,
+		"type":  "vm",
+		"group": res.Group,
+		"state": res.State,
+	})
+}
+
+// handleRemoveHAResource mimics DELETE /cluster/ha/resources/{sid}. The fake
+// does not drop HA entries when a VM is destroyed, so tests see whether the
+// provider removed them itself.
+func (s *Server) handleRemoveHAResource(w http.ResponseWriter, r *http.Request) {
+	sid := mux.Vars(r)["sid"]
+	vmid, _ := parseHASID(sid)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.haResources[vmid]; !ok {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("no such resource '%s'", sid))
+		return
+	}
+	delete(s.haResources, vmid)
+	s.writeResponse(w, nil)
+}
+
+// handleVersion mimics PVE's /version for a fixed 8.2.4 release.
+func (s *Server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	s.writeResponse(w, map[string]interface{}{
+		"version": "8.2.4",
+		"release": "8.2",
+		"repoid":  "faa83925c9641325",
+	})
+}
+
+// handleNodeStatus mimics PVE's /nodes/{node}/status with fixed figures: 8
+// CPUs at 25% load and 32 GiB of memory, 8 GiB of it in use.
+func (s *Server) handleNodeStatus(w http.ResponseWriter, _ *http.Request) {
+	s.writeResponse(w, map[string]interface{}{
+		"cpu":     0.25,
+		"cpuinfo": map[string]interface{}{"cpus": 8, "cores": 4, "sockets": 1},
+		"memory": map[string]interface{}{
+			"total": int64(32) << 30,
+			"used":  int64(8) << 30,
+			"free":  int64(24) << 30,
+		},
+	})
+}
+
+// hardwareConfigKeys are the VM config keys the fake stores verbatim from
+// create/reconfigure and reports back from GET config, so tests can assert
+// the VMClass profile, NIC mappings and cloud-init options.
+var hardwareConfigKeys = []string{"cpu", "bios", "machine", "efidisk0", "tpmstate0", "hugepages", "numa", "balloon", "shares", "hotplug", "agent", "net0", "net1", "net2", "net3",
+	"ide2", "ciuser", "sshkeys", "searchdomain", "nameserver", "ipconfig0", "ipconfig1", "ipconfig2", "ipconfig3"}
+
+// recordHardwareConfig copies the hardware keys present in the form into the
+// VM's config. Callers hold s.mu.
+func recordHardwareConfig(vm *VM, r *http.Request) {
+	for _, key := range hardwareConfigKeys {
+		if v := r.FormValue(key); v != "" {
+			if vm.Config == nil {
+				vm.Config = make(map[string]string)
+			}
+			vm.Config[key] = v
+		}
+	}
+}
+
 func (s *Server) handleListVMs(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	node := vars["node"]
@@ -507,6 +1058,134 @@ func (s *Server) handleGetVM(w http.ResponseWriter, r *http.Request) {
 	s.writeResponse(w, vm)
 }
 
+// handleGuestNetworkInterfaces mimics the guest agent's
+// network-get-interfaces, reporting a running VM's IPAddrs on eth0.
+func (s *Server) handleGuestNetworkInterfaces(w http.ResponseWriter, r *http.Request) {
+	vmid, err := strconv.Atoi(mux.Vars(r)["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+
+	s.mu.RLock()
+	vm, exists := s.vms[vmid]
+	var addrs []map[string]interface{}
+	if exists {
+		for _, ip := range vm.IPAddrs {
+			addrs = append(addrs, map[string]interface{}{"ip-address": ip, "ip-address-type": "ipv4", "prefix": 24})
+		}
+	}
+	running := exists && vm.Status == "running"
+	s.mu.RUnlock()
+
+	if !running {
+		s.writeError(w, http.StatusInternalServerError, "QEMU guest agent is not running")
+		return
+	}
+	s.writeResponse(w, map[string]interface{}{
+		"result": []map[string]interface{}{{"name": "eth0", "ip-addresses": addrs}},
+	})
+}
+
+// handleGuestPing mimics the guest agent's ping, which answers while the
+// VM runs unless its AgentDown is set.
+func (s *Server) handleGuestPing(w http.ResponseWriter, r *http.Request) {
+	vmid, err := strconv.Atoi(mux.Vars(r)["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+
+	s.mu.RLock()
+	vm, exists := s.vms[vmid]
+	answers := exists && vm.Status == "running" && !vm.AgentDown
+	s.mu.RUnlock()
+
+	if !answers {
+		s.writeError(w, http.StatusInternalServerError, "QEMU guest agent is not running")
+		return
+	}
+	s.writeResponse(w, map[string]interface{}{"result": map[string]interface{}{}})
+}
+
+// handleGuestExec mimics the guest agent's exec. The command runs to
+// completion at once through the VM's GuestExec; exec-status reports it.
+func (s *Server) handleGuestExec(w http.ResponseWriter, r *http.Request) {
+	vmid, err := strconv.Atoi(mux.Vars(r)["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vm, exists := s.vms[vmid]
+	if !exists || vm.Status != "running" {
+		s.writeError(w, http.StatusInternalServerError, "QEMU guest agent is not running")
+		return
+	}
+
+	result := &guestExecResult{exitCode: 127, stderr: "sh: 1: not found"}
+	if vm.GuestExec != nil {
+		result.exitCode, result.stdout, result.stderr = vm.GuestExec(r.Form["command"])
+	}
+	s.nextPID++
+	if s.guestExecs == nil {
+		s.guestExecs = make(map[int]*guestExecResult)
+	}
+	s.guestExecs[s.nextPID] = result
+	s.writeResponse(w, map[string]interface{}{"pid": s.nextPID})
+}
+
+// handleVNCProxy opens a VNC session on a running VM. Like PVE it reports
+// the port as a string.
+func (s *Server) handleVNCProxy(w http.ResponseWriter, r *http.Request) {
+	vmid, err := strconv.Atoi(mux.Vars(r)["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+
+	s.mu.RLock()
+	vm, exists := s.vms[vmid]
+	s.mu.RUnlock()
+	if !exists || vm.Status != "running" {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d not running", vmid))
+		return
+	}
+	s.writeResponse(w, map[string]interface{}{
+		"port":   strconv.Itoa(5900 + vmid%100),
+		"ticket": fmt.Sprintf("PVEVNC:%d::fake", vmid),
+	})
+}
+
+// handleGuestExecStatus reports a command started by handleGuestExec.
+func (s *Server) handleGuestExecStatus(w http.ResponseWriter, r *http.Request) {
+	pid, err := strconv.Atoi(r.URL.Query().Get("pid"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid pid")
+		return
+	}
+
+	s.mu.RLock()
+	result, exists := s.guestExecs[pid]
+	s.mu.RUnlock()
+	if !exists {
+		s.writeError(w, http.StatusInternalServerError, "Invalid parameter 'pid'")
+		return
+	}
+	s.writeResponse(w, map[string]interface{}{
+		"exited":   1,
+		"exitcode": result.exitCode,
+		"out-data": result.stdout,
+		"err-data": result.stderr,
+	})
+}
+
 // handleCloneVM handles VM cloning
 func (s *Server) handleCloneVM(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -547,16 +1226,56 @@ func (s *Server) handleCloneVM(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pool := r.FormValue("pool")
+	if pool != "" && !s.pools[pool] {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("pool '%s' does not exist", pool))
+		return
+	}
+
+	if snapname := r.FormValue("snapname"); snapname != "" {
+		found := false
+		for _, snap := range s.snapshots[sourceVMIDStr] {
+			found = found || snap.Name == snapname
+		}
+		if !found {
+			s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("snapshot '%s' does not exist", snapname))
+			return
+		}
+	}
+
+	// Like PVE, a linked clone (full=0) shares the template's disks, so it
+	// needs a template and cannot be sent to another storage.
+	linked := r.FormValue("full") == "0"
+	if linked && r.FormValue("storage") != "" {
+		s.writeError(w, http.StatusBadRequest, "parameter 'storage' not allowed for linked clones")
+		return
+	}
+	if linked && sourceVM.Template != 1 {
+		s.writeError(w, http.StatusInternalServerError, "linked clone feature is only available for templates")
+		return
+	}
+
+	// PVE's target parameter places the clone on another node.
+	targetNode := node
+	if target := r.FormValue("target"); target != "" {
+		targetNode = target
+	}
+
 	// Create cloned VM
 	clonedVM := &VM{
 		VMID:      targetVMID,
 		Name:      r.FormValue("name"),
 		Status:    "stopped",
-		Node:      node,
+		Node:      targetNode,
 		CPUs:      sourceVM.CPUs,
 		Memory:    sourceVM.Memory,
 		QMPStatus: "stopped",
+		Pool:      pool,
+		Tags:      sourceVM.Tags, // PVE copies the source's tags
+		Config:    maps.Clone(sourceVM.Config),
+		Networks:  slices.Clone(sourceVM.Networks),
 		CreatedAt: time.Now(),
+		Linked:    linked,
 	}
 
 	s.vms[targetVMID] = clonedVM
@@ -567,6 +1286,33 @@ func (s *Server) handleCloneVM(w http.ResponseWriter, r *http.Request) {
 	s.writeResponse(w, taskID)
 }
 
+// handleConvertToTemplate marks a stopped VM as a template. Like PVE it
+// refuses a running VM.
+func (s *Server) handleConvertToTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	vmid, err := strconv.Atoi(vars["vmid"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vm, exists := s.vms[vmid]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "VM not found")
+		return
+	}
+	if vm.Status == "running" {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d is running - convert to template failed", vmid))
+		return
+	}
+	vm.Template = 1
+
+	s.writeResponse(w, s.createTask(vars["node"], "qmtemplate", vars["vmid"]))
+}
+
 // handlePowerOp creates a handler for power operations
 func (s *Server) handlePowerOp(operation string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -607,8 +1353,8 @@ func (s *Server) handlePowerOp(operation string) http.HandlerFunc {
 			vm.Status = "stopped"
 			vm.QMPStatus = "stopped"
 			vm.PID = 0
-		case "reboot":
-			// Status stays the same for reboot
+		case "reboot", "reset":
+			// Status stays the same for reboot and reset
 		}
 
 		// Create async task
@@ -633,14 +1379,18 @@ func (s *Server) handleGetTaskStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Simulate task completion after delay
+	s.mu.Lock()
 	if task.Status == "running" && time.Since(task.CreatedAt) > s.config.TaskDelay {
-		s.mu.Lock()
 		task.Status = "stopped"
 		task.ExitCode = "OK"
-		s.mu.Unlock()
+		if exitStatus, failed := s.taskFailures[task.Type]; failed {
+			task.ExitCode = exitStatus
+		}
 	}
+	current := *task
+	s.mu.Unlock()
 
-	s.writeResponse(w, task)
+	s.writeResponse(w, current)
 }
 
 // handleCreateSnapshot handles snapshot creation
@@ -675,14 +1425,20 @@ func (s *Server) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create snapshot
+	// Create snapshot. Like PVE, the new snapshot's parent is the most
+	// recent one.
+	vmKey := fmt.Sprintf("%d", vmid)
 	snapshot := &Snapshot{
 		Name:        snapName,
 		Description: r.FormValue("description"),
 		SnapTime:    time.Now().Unix(),
 	}
-
-	vmKey := fmt.Sprintf("%d", vmid)
+	if r.FormValue("vmstate") == "1" {
+		snapshot.VMSTATE = 1
+	}
+	if existing := s.snapshots[vmKey]; len(existing) > 0 {
+		snapshot.Parent = existing[len(existing)-1].Name
+	}
 	s.snapshots[vmKey] = append(s.snapshots[vmKey], snapshot)
 
 	// Create async task
@@ -691,6 +1447,36 @@ func (s *Server) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
 	s.writeResponse(w, taskID)
 }
 
+// handleListSnapshots lists a VM's snapshots. As on a real PVE host, the
+// list ends with a "current" pseudo-entry for the running state.
+func (s *Server) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
+	vmidStr := mux.Vars(r)["vmid"]
+
+	vmid, err := strconv.Atoi(vmidStr)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid VMID")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.vms[vmid]; !exists {
+		s.writeError(w, http.StatusNotFound, "VM not found")
+		return
+	}
+
+	snapshots := make([]*Snapshot, 0, len(s.snapshots[vmidStr])+1)
+	snapshots = append(snapshots, s.snapshots[vmidStr]...)
+	current := &Snapshot{Name: "current", Description: "You are here!"}
+	if len(snapshots) > 0 {
+		current.Parent = snapshots[len(snapshots)-1].Name
+	}
+	snapshots = append(snapshots, current)
+
+	s.writeResponse(w, snapshots)
+}
+
 // handleDeleteSnapshot handles snapshot deletion
 func (s *Server) handleDeleteSnapshot(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -788,6 +1574,30 @@ func (s *Server) createTask(node, taskType, id string) string {
 	return taskID
 }
 
+// configLocked returns the error PVE gives for a config write to vm while
+// another change holds its config, or "" when the write may go ahead. A
+// lock recorded on the VM, as a backup or clone leaves it, refuses every
+// write. With ConfigLocks set, a config or resize task still running on
+// the VM holds the per-VM config file lock, so a second write times out
+// waiting for it. Callers hold s.mu.
+func (s *Server) configLocked(vm *VM, vmid string) string {
+	if vm.Lock != "" {
+		return fmt.Sprintf("VM is locked (%s)", vm.Lock)
+	}
+	if !s.config.ConfigLocks {
+		return ""
+	}
+	for _, task := range s.tasks {
+		if task.ID != vmid || (task.Type != "qmconfig" && task.Type != "qmresize") {
+			continue
+		}
+		if task.Status == "running" && time.Since(task.CreatedAt) <= s.config.TaskDelay {
+			return fmt.Sprintf("can't lock file '/var/lock/qemu-server/lock-%s.conf' - got timeout", vmid)
+		}
+	}
+	return ""
+}
+
 // writeResponse writes a successful JSON response
 func (s *Server) writeResponse(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -852,6 +1662,15 @@ func (s *Server) handleGetVMConfig(w http.ResponseWriter, r *http.Request) {
 	// Add disk config
 	config["scsi0"] = "local-lvm:vm-100-disk-0,size=32G"
 
+	for _, key := range hardwareConfigKeys {
+		if v, ok := vm.Config[key]; ok {
+			config[key] = v
+		}
+	}
+	if vm.Tags != "" {
+		config["tags"] = vm.Tags
+	}
+
 	s.writeResponse(w, config)
 }
 
@@ -880,6 +1699,10 @@ func (s *Server) handleReconfigureVM(w http.ResponseWriter, r *http.Request) {
 		s.writeError(w, http.StatusNotFound, "VM not found")
 		return
 	}
+	if msg := s.configLocked(vm, vmidStr); msg != "" {
+		s.writeError(w, http.StatusInternalServerError, msg)
+		return
+	}
 
 	// Update VM configuration
 	if cores := r.FormValue("cores"); cores != "" {
@@ -893,6 +1716,19 @@ func (s *Server) handleReconfigureVM(w http.ResponseWriter, r *http.Request) {
 			vm.Memory = m * 1024 * 1024 // Convert MB to bytes
 		}
 	}
+	// PVE resizes the balloon of a running guest straight away.
+	if balloon := r.FormValue("balloon"); balloon != "" && vm.BalloonInfo != nil && vm.Status == "running" {
+		if b, err := strconv.ParseInt(balloon, 10, 64); err == nil && b > 0 {
+			vm.BalloonInfo.Actual = b * 1024 * 1024
+		}
+	}
+	if _, ok := r.Form["tags"]; ok {
+		vm.Tags = r.FormValue("tags")
+	}
+	if name := r.FormValue("name"); name != "" {
+		vm.Name = name
+	}
+	recordHardwareConfig(vm, r)
 
 	// Create async task
 	taskID := s.createTask(node, "qmconfig", vmidStr)
@@ -926,6 +1762,11 @@ func (s *Server) handleResizeDisk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if msg := s.configLocked(vm, vmidStr); msg != "" {
+		s.writeError(w, http.StatusInternalServerError, msg)
+		return
+	}
+
 	disk := r.FormValue("disk")
 	size := r.FormValue("size")
 
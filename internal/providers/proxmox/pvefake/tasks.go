@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvefake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// ClusterTask is an entry of the fake cluster task log. Tasks the fake runs
+// for API calls are not listed there; tests add the tasks they need.
+type ClusterTask struct {
+	UPID      string `json:"upid"`
+	Node      string `json:"node"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	TokenID   string `json:"tokenid,omitempty"`
+	StartTime int64  `json:"starttime"`
+	EndTime   int64  `json:"endtime,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// AddClusterTask appends task to the cluster task log. An empty UPID is
+// derived from the node, type, ID and start time.
+func (s *Server) AddClusterTask(task ClusterTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if task.UPID == "" {
+		task.UPID = fmt.Sprintf("UPID:%s:%08X:%s:%s:%s:", task.Node, task.StartTime, task.Type, task.ID, task.User)
+	}
+	s.clusterTasks = append(s.clusterTasks, task)
+}
+
+// handleClusterTasks mimics PVE's GET /cluster/tasks, newest first.
+func (s *Server) handleClusterTasks(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := slices.Clone(s.clusterTasks)
+	slices.Reverse(list)
+	if list == nil {
+		list = []ClusterTask{}
+	}
+	s.writeResponse(w, list)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// agentPingTimeout bounds the guest agent ping made before a quiesced
+// snapshot.
+const agentPingTimeout = 10 * time.Second
+
+// snapshotQuiesce decides how a snapshot requested by req is quiesced.
+// PVE quiesces on its own: a snapshot without vmstate of a running VM with
+// the guest agent enabled runs guest-fsfreeze-freeze and -thaw around it,
+// and goes on unfrozen when the agent does not answer. So the agent is
+// pinged first, and a snapshot it would take unfrozen is refused with
+// FailedPrecondition, or reported degraded if req.AllowUnquiesced is set.
+// A stopped VM and a memory snapshot are not frozen: the disks of the one
+// are at rest, and the other captures the running guest.
+func (p *Provider) snapshotQuiesce(ctx context.Context, node string, vmid int, req *providerv1.SnapshotCreateRequest) (providerv1.QuiesceOutcome, string, error) {
+	if !req.Quiesce {
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "quiesce was not requested", nil
+	}
+	vm, err := p.client.GetVM(ctx, node, vmid)
+	if err != nil {
+		return 0, "", pveFailure("failed to get VM status", err)
+	}
+	switch {
+	case vm.Status != "running":
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "the VM is not running, so its disks are at rest", nil
+	case req.IncludeMemory:
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "the snapshot captures the running guest's memory", nil
+	}
+
+	config, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return 0, "", pveFailure("failed to get VM config", err)
+	}
+	problem := ""
+	if !agentEnabled(config) {
+		problem = "the QEMU guest agent is not enabled (agent: 1)"
+	} else {
+		pingCtx, cancel := context.WithTimeout(ctx, agentPingTimeout)
+		err := p.client.AgentPing(pingCtx, node, vmid)
+		cancel()
+		if err != nil {
+			problem = fmt.Sprintf("the QEMU guest agent does not respond: %v", err)
+		}
+	}
+	if problem == "" {
+		return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED, "", nil
+	}
+	if !req.AllowUnquiesced {
+		return 0, "", errors.NewFailedPrecondition("cannot quiesce VM %d: %s", vmid, problem)
+	}
+	p.logger.Warn("Taking a crash-consistent snapshot", "vmid", vmid, "reason", problem)
+	return providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, problem, nil
+}
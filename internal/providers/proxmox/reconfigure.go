@@ -0,0 +1,202 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// Reconfigure applies the manager's ChangeSet to a virtual machine. CPU
+// changes on a running VM without cpu hotplug are stored by PVE as pending
+// and reported as needing a power cycle; memory changes are resized through
+// the balloon where they can be (see planMemoryChange). Requests
+// from a manager that predates ChangeSet carry only DesiredJson; the change
+// set is then derived from the VM's current config.
+func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	vmid, node, err := p.parseVMReference(req.Id)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+
+	currentConfig, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		return nil, errors.NewInternal("failed to get current VM config", err)
+	}
+
+	changes := req.GetChanges()
+	if changes == nil {
+		if changes, err = legacyChangeSet(currentConfig, req.DesiredJson); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &providerv1.ReconfigureResponse{}
+	var taskID string
+
+	config := &pveapi.ReconfigureConfig{}
+	if cpu := changes.GetCpu(); cpu != nil && cpu.New > 0 {
+		cores := int(cpu.New)
+		config.CPUs = &cores
+	}
+	var memory memoryChange
+	if mem := changes.GetMemoryMib(); mem != nil && mem.New > 0 {
+		// PVE's memory and balloon fields are in MiB
+		memory = p.planMemoryChange(ctx, node, vmid, currentConfig, mem.New)
+		config.Memory, config.Balloon = memory.memory, memory.balloon
+	}
+	if config.CPUs != nil || config.Memory != nil || config.Balloon != nil {
+		if taskID, err = p.client.ReconfigureVM(ctx, node, vmid, config); err != nil {
+			return nil, pveFailure("failed to reconfigure VM", err)
+		}
+		resp.PowerCycleRequired = p.pendingUntilPowerCycle(ctx, node, vmid, currentConfig, changes, memory.powerCycle)
+	}
+
+	for _, disk := range changes.GetDisks() {
+		diskKey, ok := proxmoxDiskKey(disk.Name)
+		if !ok {
+			p.logger.Warn("Skipping expansion of a disk with no known PVE device", "vmid", vmid, "disk", disk.Name)
+			continue
+		}
+		// PVE runs one config task at a time per VM; let the previous one
+		// finish before resizing.
+		if err := p.client.WaitForTask(ctx, node, taskID); err != nil {
+			return nil, pveFailure("failed waiting for reconfigure task", err)
+		}
+		if taskID, err = p.client.ResizeDisk(ctx, node, vmid, diskKey, int64(disk.NewSizeGib)); err != nil {
+			return nil, pveFailure("failed to resize disk", err)
+		}
+	}
+
+	if groupChanges := changes.GetSecurityGroups(); len(groupChanges) > 0 {
+		if err := p.client.WaitForTask(ctx, node, taskID); err != nil {
+			return nil, pveFailure("failed waiting for reconfigure task", err)
+		}
+		taskID = ""
+		if err := p.reconfigureSecurityGroups(ctx, node, vmid, currentConfig, req.DesiredJson, groupChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(changes.GetNetworksAdded()) > 0 || len(changes.GetNetworksRemoved()) > 0 {
+		p.logger.Info("Network attachment changes are not applied by Reconfigure", "vmid", vmid,
+			"added", len(changes.GetNetworksAdded()), "removed", changes.GetNetworksRemoved())
+	}
+
+	if taskID != "" {
+		resp.Task = p.taskRef(taskID)
+	}
+	return resp, nil
+}
+
+// pendingUntilPowerCycle returns the CPU and memory changes PVE could not
+// apply to the running VM. Whether memory could is decided up front by
+// planMemoryChange.
+func (p *Provider) pendingUntilPowerCycle(ctx context.Context, node string, vmid int, currentConfig map[string]interface{}, changes *providerv1.ChangeSet, memoryPending bool) []string {
+	vm, err := p.client.GetVM(ctx, node, vmid)
+	if err != nil || vm.Status != "running" {
+		return nil
+	}
+	enabled := hotplugSet(currentConfig)
+
+	var pending []string
+	if changes.GetCpu() != nil && !enabled["cpu"] {
+		pending = append(pending, contracts.ChangeCPU)
+	}
+	if memoryPending {
+		pending = append(pending, contracts.ChangeMemoryMiB)
+	}
+	if len(pending) > 0 {
+		p.logger.Info("Changes stored as pending until the VM is power cycled", "vmid", vmid, "changes", pending)
+	}
+	return pending
+}
+
+// proxmoxDiskKey maps a ChangeSet disk name to its PVE device. Only the
+// boot disk, which Create always attaches as scsi0, is known.
+func proxmoxDiskKey(name string) (string, bool) {
+	if name == contracts.RootDiskName {
+		return "scsi0", true
+	}
+	return "", false
+}
+
+// legacyChangeSet derives a ChangeSet from a marshaled contracts.CreateRequest
+// by comparing it with the VM's config, the way Reconfigure worked before
+// the manager computed the diff. Every disk entry maps to scsi0, as it did
+// then.
+func legacyChangeSet(currentConfig map[string]interface{}, desiredJSON string) (*providerv1.ChangeSet, error) {
+	var desired contracts.CreateRequest
+	if err := contracts.UnmarshalPayload([]byte(desiredJSON), &desired); err != nil {
+		return nil, errors.NewInvalidSpec("failed to parse desired configuration: %v", err)
+	}
+
+	changes := &providerv1.ChangeSet{}
+	if desired.Class.CPU > 0 {
+		current, _ := currentConfig["cores"].(float64)
+		changes.Cpu = &providerv1.Int32Change{Old: int32(current), New: desired.Class.CPU}
+	}
+	if desired.Class.MemoryMiB > 0 {
+		current, _ := currentConfig["memory"].(float64)
+		changes.MemoryMib = &providerv1.Int64Change{Old: int64(current), New: int64(desired.Class.MemoryMiB)}
+	}
+
+	currentSize, hasSize := pveDiskSizeGiB(currentConfig["scsi0"])
+	for _, disk := range desired.Disks {
+		if disk.SizeGiB <= 0 || !hasSize {
+			continue
+		}
+		if int64(disk.SizeGiB) < currentSize {
+			return nil, errors.NewInvalidSpec("disk shrinking not allowed: current=%dG, requested=%dG", currentSize, disk.SizeGiB)
+		}
+		if int64(disk.SizeGiB) > currentSize {
+			changes.Disks = append(changes.Disks, &providerv1.DiskExpansion{
+				Name:       contracts.RootDiskName,
+				OldSizeGib: int32(currentSize), // #nosec G115 -- below the requested int32 size
+				NewSizeGib: disk.SizeGiB,
+			})
+			break
+		}
+	}
+	return changes, nil
+}
+
+// pveDiskSizeGiB parses the size from a disk config string such as
+// "local:vm-100-disk-0,size=32G".
+func pveDiskSizeGiB(value interface{}) (int64, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	for _, part := range strings.Split(s, ",") {
+		if sizeStr, found := strings.CutPrefix(part, "size="); found {
+			size, err := strconv.ParseInt(strings.TrimSuffix(sizeStr, "G"), 10, 64)
+			return size, err == nil
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TestProxmoxProvider_ReconfigureAppliesChangeSet drives Reconfigure with a
+// manager-computed ChangeSet against the fake's running VM 100.
+func TestProxmoxProvider_ReconfigureAppliesChangeSet(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	resp, err := provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id: "100",
+		Changes: &providerv1.ChangeSet{
+			Cpu:   &providerv1.Int32Change{Old: 2, New: 4},
+			Disks: []*providerv1.DiskExpansion{{Name: contracts.RootDiskName, OldSizeGib: 32, NewSizeGib: 64}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Task, "the disk grow runs as a task")
+	assert.Equal(t, []string{contracts.ChangeCPU}, resp.PowerCycleRequired,
+		"without cpu hotplug a running VM only picks up new cores after a power cycle")
+	cores, _ := vmConfigSize(t, provider, "100")
+	assert.Equal(t, 4, cores, "the change is still written to the config")
+
+	// With cpu and memory hotplug enabled nothing waits for a power cycle.
+	_, err = provider.client.ReconfigureVMRaw(ctx, "pve", 100, url.Values{"hotplug": {"network,disk,cpu,memory"}})
+	require.NoError(t, err)
+	resp, err = provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id: "100",
+		Changes: &providerv1.ChangeSet{
+			Cpu:       &providerv1.Int32Change{Old: 4, New: 6},
+			MemoryMib: &providerv1.Int64Change{Old: 2048, New: 4096},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.PowerCycleRequired)
+	cores, memMiB := vmConfigSize(t, provider, "100")
+	assert.Equal(t, 6, cores)
+	assert.Equal(t, 4096, memMiB)
+}
+
+// TestProxmoxProvider_ReconfigureChangeSetIgnoresDesiredJSON verifies the
+// provider no longer diffs DesiredJson when a ChangeSet is present: fields
+// absent from the ChangeSet are left alone.
+func TestProxmoxProvider_ReconfigureChangeSetIgnoresDesiredJSON(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	_, err = provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id:          "100",
+		DesiredJson: `{"Class":{"CPU":8,"MemoryMiB":16384}}`,
+		Changes:     &providerv1.ChangeSet{MemoryMib: &providerv1.Int64Change{Old: 2048, New: 3072}},
+	})
+	require.NoError(t, err)
+	cores, memMiB := vmConfigSize(t, provider, "100")
+	assert.Equal(t, 2, cores, "CPU is not in the ChangeSet")
+	assert.Equal(t, 3072, memMiB)
+}
+
+// TestProxmoxProvider_ReconfigureMemoryBalloon runs memory changes against a
+// running VM with an 8 GiB ceiling whose balloon currently sits at 4 GiB.
+func TestProxmoxProvider_ReconfigureMemoryBalloon(t *testing.T) {
+	tests := []struct {
+		name          string
+		driver        bool
+		oldMiB        int64
+		newMiB        int64
+		wantPending   []string
+		wantMemoryMiB int
+		wantBalloon   string
+	}{
+		{
+			name:   "grow within maxmem moves the balloon",
+			driver: true, oldMiB: 4096, newMiB: 6144,
+			wantMemoryMiB: 8192, wantBalloon: "6144",
+		},
+		{
+			name:   "shrink moves the balloon and keeps the ceiling",
+			driver: true, oldMiB: 4096, newMiB: 2048,
+			wantMemoryMiB: 8192, wantBalloon: "2048",
+		},
+		{
+			name:   "grow beyond maxmem waits for a power cycle",
+			driver: true, oldMiB: 4096, newMiB: 12288,
+			wantPending:   []string{contracts.ChangeMemoryMiB},
+			wantMemoryMiB: 12288, wantBalloon: "4096",
+		},
+		{
+			name:   "shrink without a balloon driver waits for a power cycle",
+			driver: false, oldMiB: 4096, newMiB: 2048,
+			wantPending:   []string{contracts.ChangeMemoryMiB},
+			wantMemoryMiB: 2048, wantBalloon: "2048",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, endpoint, err := pvefake.StartFakeServer()
+			require.NoError(t, err)
+			vm := &pvefake.VM{
+				VMID: 200, Name: "balloon-vm", Status: "running", Node: "pve",
+				CPUs: 2, Memory: 8192 << 20,
+				Config: map[string]string{"balloon": "4096"},
+			}
+			if tt.driver {
+				vm.BalloonInfo = &pvefake.BalloonInfo{Actual: 4096 << 20, MaxMem: 8192 << 20}
+			}
+			server.AddVM(vm)
+			provider := createTestProvider(endpoint)
+			ctx := context.Background()
+
+			resp, err := provider.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+				Id:      "200",
+				Changes: &providerv1.ChangeSet{MemoryMib: &providerv1.Int64Change{Old: tt.oldMiB, New: tt.newMiB}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPending, resp.PowerCycleRequired)
+
+			_, memMiB := vmConfigSize(t, provider, "200")
+			assert.Equal(t, tt.wantMemoryMiB, memMiB)
+			cfg, err := provider.client.GetVMConfig(ctx, "pve", 200)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBalloon, cfg["balloon"])
+
+			if tt.driver && tt.wantPending == nil {
+				status, err := provider.client.GetVM(ctx, "pve", 200)
+				require.NoError(t, err)
+				require.NotNil(t, status.BalloonInfo)
+				assert.Equal(t, tt.newMiB<<20, status.BalloonInfo.Actual, "the guest is resized online")
+			}
+		})
+	}
+}
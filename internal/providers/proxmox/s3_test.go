@@ -195,8 +195,8 @@ func TestBuildImportedDiskSetCommand(t *testing.T) {
 }
 
 // TestBuildImportedDiskCloudInitValues covers the API cloud-init values: a
-// cloud-init drive is always set; ciuser/sshkeys only when present. url.Values
-// handles the encoding (notably multi-line SSH keys).
+// cloud-init drive is always set; ciuser/sshkeys only when present, with the
+// keys in PVE's sshkeys encoding.
 func TestBuildImportedDiskCloudInitValues(t *testing.T) {
 	t.Run("drive only when no ciuser/keys", func(t *testing.T) {
 		v := buildImportedDiskCloudInitValues("local-lvm", &pveapi.VMConfig{})
@@ -213,7 +213,8 @@ func TestBuildImportedDiskCloudInitValues(t *testing.T) {
 		})
 		assert.Equal(t, "local-lvm:cloudinit", v.Get("ide2"))
 		assert.Equal(t, "ubuntu", v.Get("ciuser"))
-		assert.Equal(t, multiKey, v.Get("sshkeys"), "multi-line keys preserved, trailing newline trimmed")
+		assert.Equal(t, pveapi.EncodeSSHKeys(multiKey), v.Get("sshkeys"), "multi-line keys preserved, trailing newline trimmed")
+		assert.Contains(t, v.Get("sshkeys"), "one%0Assh-ed25519")
 	})
 }
 
@@ -319,7 +320,7 @@ func TestParseCreateRequest_ImportedDiskPath(t *testing.T) {
 		ImageJson: `{"Path":"/var/tmp/.virtrigaud-import-web-migrated.qcow2","Format":"qcow2"}`,
 	}
 
-	cfg, _, err := provider.parseCreateRequest(context.Background(), req)
+	cfg, err := provider.parseCreateRequest(context.Background(), req)
 	require.NoError(t, err)
 	assert.Equal(t, "/var/tmp/.virtrigaud-import-web-migrated.qcow2", cfg.ImportedDiskPath)
 	assert.Equal(t, "qcow2", cfg.ImportedDiskFormat)
@@ -339,7 +340,7 @@ func TestParseCreateRequest_TemplateWinsOverPath(t *testing.T) {
 		Name:      "tmpl-vm",
 		ImageJson: `{"TemplateName":"9000","Path":"/var/tmp/should-be-ignored.qcow2"}`,
 	}
-	cfg, _, err := provider.parseCreateRequest(context.Background(), req)
+	cfg, err := provider.parseCreateRequest(context.Background(), req)
 	require.NoError(t, err)
 	assert.Equal(t, "9000", cfg.Template)
 	assert.Empty(t, cfg.ImportedDiskPath, "template create must not capture the imported-disk path")
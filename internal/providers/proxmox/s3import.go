@@ -359,6 +359,7 @@ func (p *Provider) createFromImportedDisk(
 	p.cleanupNodeFile(vmConfig.ImportedDiskPath)
 
 	p.logger.Info("VM created from imported disk", "vmid", vmConfig.VMID, "boot_disk", importedVolume)
+	p.inventory().RecordVM(vmConfig.VMID, node)
 
 	return &providerv1.CreateResponse{
 		Id: fmt.Sprintf("%d", vmConfig.VMID),
@@ -434,10 +435,10 @@ func buildImportedDiskSetCommand(vmid int, importedVolume string) string {
 
 // buildImportedDiskCloudInitValues builds the url.Values for the API
 // ReconfigureVMRaw call that attaches a cloud-init drive and propagates the SSH
-// keys / user already parsed into vmConfig. Using url.Values (the same path the
-// clone+cloud-init Create flow uses) means PVE receives correctly-encoded values,
-// notably multi-line SSH keys, with none of the shell/command-line pitfalls of
-// `qm set --sshkeys`. Returns an empty map when there is nothing to attach. It is
+// keys / user already parsed into vmConfig. Going through the API (the same path
+// the clone+cloud-init Create flow uses) with pveapi.SetSSHKeys means PVE
+// receives correctly-encoded values, notably multi-line SSH keys, with none of
+// the shell/command-line pitfalls of `qm set --sshkeys`. Returns an empty map when there is nothing to attach. It is
 // a free function so the exact values are unit-testable without a live PVE.
 func buildImportedDiskCloudInitValues(storage string, vmConfig *pveapi.VMConfig) url.Values {
 	values := url.Values{}
@@ -445,8 +446,6 @@ func buildImportedDiskCloudInitValues(storage string, vmConfig *pveapi.VMConfig)
 	if vmConfig.CIUser != "" {
 		values.Set("ciuser", vmConfig.CIUser)
 	}
-	if vmConfig.SSHKeys != "" {
-		values.Set("sshkeys", strings.TrimSpace(vmConfig.SSHKeys))
-	}
+	pveapi.SetSSHKeys(values, vmConfig.SSHKeys)
 	return values
 }
@@ -0,0 +1,270 @@
+//go:build integration
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// The TestScenario* tests drive the provider's RPC handlers through whole
+// VM lifecycles against a fake PVE API of their own, with latency and
+// failures injected where a scenario calls for them. They run with
+// `make test-integration`, which sets the integration build tag.
+
+// scenarioTemplateID is the template the fake seeds.
+const scenarioTemplateID = 9000
+
+// newScenarioProvider returns a provider talking to a fresh fake PVE API
+// that polls tasks fast enough for the fake's short task delay.
+func newScenarioProvider(t *testing.T) (*Provider, *pvefake.Server) {
+	t.Helper()
+	srv, endpoint := pvefake.NewTestServer(t)
+	client, err := pveapi.NewClient(&pveapi.Config{
+		Endpoint:         endpoint,
+		TokenID:          "test@pve!token",
+		TokenSecret:      "secret",
+		TaskPollInterval: 20 * time.Millisecond,
+		TaskTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+	p := New()
+	p.client = client
+	return p, srv
+}
+
+// templateCreateRequest asks for a VM named name cloned from the seeded
+// template, shaped as the controller sends it.
+func templateCreateRequest(name string) *providerv1.CreateRequest {
+	return &providerv1.CreateRequest{
+		Name:           name,
+		ClassJson:      `{"cpu": 2, "memoryMiB": 2048}`,
+		ImageJson:      `{"templateName": "` + strconv.Itoa(scenarioTemplateID) + `"}`,
+		UserData:       []byte("#cloud-config\nhostname: " + name),
+		IdempotencyKey: "scenario-" + name,
+	}
+}
+
+// createFromTemplate clones the seeded template into a VM named name.
+func createFromTemplate(ctx context.Context, t *testing.T, p *Provider, name string) string {
+	t.Helper()
+	resp, err := p.Create(ctx, templateCreateRequest(name))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Id)
+	if resp.Task != nil {
+		require.NoError(t, waitForTask(ctx, p, resp.Task.Id))
+	}
+	return resp.Id
+}
+
+func vmConfigValue(ctx context.Context, t *testing.T, p *Provider, id, key string) string {
+	t.Helper()
+	vmid, err := strconv.Atoi(id)
+	require.NoError(t, err)
+	config, err := p.client.GetVMConfig(ctx, "pve", vmid)
+	require.NoError(t, err)
+	return fmt.Sprint(config[key])
+}
+
+func TestScenario_CreateFromTemplate(t *testing.T) {
+	t.Parallel()
+	p, srv := newScenarioProvider(t)
+	ctx := context.Background()
+
+	id := createFromTemplate(ctx, t, p, "scenario-clone")
+
+	assert.Equal(t, 1, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+	describe, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+	require.NoError(t, err)
+	assert.True(t, describe.Exists)
+	assert.Equal(t, "2", vmConfigValue(ctx, t, p, id, "cores"), "a clone takes the class's size, not the template's")
+	assert.Equal(t, "2048", vmConfigValue(ctx, t, p, id, "memory"))
+
+	// A retried Create carries the same idempotency key and finds the VM
+	// it already made.
+	again := createFromTemplate(ctx, t, p, "scenario-clone")
+	assert.Equal(t, id, again)
+	assert.Equal(t, 1, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+}
+
+func TestScenario_CreateFromTemplate_Failures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clone refused", func(t *testing.T) {
+		t.Parallel()
+		p, srv := newScenarioProvider(t)
+		srv.InjectFault(pvefake.Fault{Method: http.MethodPost, Route: "/nodes/{node}/qemu/{vmid}/clone", Times: 1})
+
+		_, err := p.Create(context.Background(), templateCreateRequest("scenario-refused"))
+		require.Error(t, err)
+
+		// The fault clears after one request; the retry goes through.
+		createFromTemplate(context.Background(), t, p, "scenario-refused")
+	})
+
+	t.Run("clone task fails", func(t *testing.T) {
+		t.Parallel()
+		p, srv := newScenarioProvider(t)
+		srv.FailTasks("qmclone", "clone failed: storage 'local-lvm' is full")
+
+		_, err := p.Create(context.Background(), templateCreateRequest("scenario-task-failed"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "storage 'local-lvm' is full")
+	})
+
+	t.Run("slow API", func(t *testing.T) {
+		t.Parallel()
+		p, srv := newScenarioProvider(t)
+		srv.SetLatency("", 20*time.Millisecond)
+
+		id := createFromTemplate(context.Background(), t, p, "scenario-slow")
+		assert.NotEmpty(t, id)
+	})
+
+	t.Run("clone slower than the caller waits", func(t *testing.T) {
+		t.Parallel()
+		p, srv := newScenarioProvider(t)
+		srv.SetLatency("/nodes/{node}/qemu/{vmid}/clone", 500*time.Millisecond)
+
+		first := make(chan string)
+		go func() {
+			resp, err := p.Create(context.Background(), templateCreateRequest("scenario-slow-clone"))
+			assert.NoError(t, err)
+			first <- resp.GetId()
+		}()
+		require.Eventually(t, func() bool {
+			return srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone") == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// A retry that gives up while the clone runs is told to come back.
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := p.Create(ctx, templateCreateRequest("scenario-slow-clone"))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		// Once the clone is done, a retry finds its VM.
+		id := <-first
+		require.NotEmpty(t, id)
+		assert.Equal(t, id, createFromTemplate(context.Background(), t, p, "scenario-slow-clone"))
+		assert.Equal(t, 1, srv.RequestCount("/nodes/{node}/qemu/{vmid}/clone"))
+	})
+}
+
+func TestScenario_Reconfigure(t *testing.T) {
+	t.Parallel()
+	p, _ := newScenarioProvider(t)
+	ctx := context.Background()
+	id := createFromTemplate(ctx, t, p, "scenario-reconfigure")
+
+	resp, err := p.Reconfigure(ctx, &providerv1.ReconfigureRequest{
+		Id: id,
+		Changes: &providerv1.ChangeSet{
+			Cpu:       &providerv1.Int32Change{Old: 2, New: 4},
+			MemoryMib: &providerv1.Int64Change{Old: 2048, New: 8192},
+		},
+	})
+	require.NoError(t, err)
+	if resp.Task != nil {
+		require.NoError(t, waitForTask(ctx, p, resp.Task.Id))
+	}
+
+	assert.Equal(t, "4", vmConfigValue(ctx, t, p, id, "cores"))
+	assert.Equal(t, "8192", vmConfigValue(ctx, t, p, id, "memory"))
+}
+
+func TestScenario_SnapshotLifecycle(t *testing.T) {
+	t.Parallel()
+	p, _ := newScenarioProvider(t)
+	ctx := context.Background()
+	id := createFromTemplate(ctx, t, p, "scenario-snapshots")
+
+	var ids []string
+	for _, name := range []string{"base", "upgrade"} {
+		resp, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: id, NameHint: name})
+		require.NoError(t, err)
+		if resp.Task != nil {
+			require.NoError(t, waitForTask(ctx, p, resp.Task.Id))
+		}
+		ids = append(ids, resp.SnapshotId)
+	}
+
+	list, err := p.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: id})
+	require.NoError(t, err)
+	require.Len(t, list.Snapshots, 2)
+	assert.Equal(t, ids[0], list.Snapshots[1].ParentId, "snapshots chain onto the latest one")
+
+	revert, err := p.SnapshotRevert(ctx, &providerv1.SnapshotRevertRequest{VmId: id, SnapshotId: ids[0]})
+	require.NoError(t, err)
+	if revert.Task != nil {
+		require.NoError(t, waitForTask(ctx, p, revert.Task.Id))
+	}
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		del, err := p.SnapshotDelete(ctx, &providerv1.SnapshotDeleteRequest{VmId: id, SnapshotId: ids[i]})
+		require.NoError(t, err)
+		if del.Task != nil {
+			require.NoError(t, waitForTask(ctx, p, del.Task.Id))
+		}
+	}
+	list, err = p.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: id})
+	require.NoError(t, err)
+	assert.Empty(t, list.Snapshots)
+}
+
+func TestScenario_DeleteIdempotent(t *testing.T) {
+	t.Parallel()
+	p, srv := newScenarioProvider(t)
+	ctx := context.Background()
+	id := createFromTemplate(ctx, t, p, "scenario-delete")
+	_, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: id, NameHint: "left-behind"})
+	require.NoError(t, err)
+
+	power, err := p.Power(ctx, &providerv1.PowerRequest{Id: id, Op: providerv1.PowerOp_POWER_OP_ON})
+	require.NoError(t, err)
+	if power.Task != nil {
+		require.NoError(t, waitForTask(ctx, p, power.Task.Id))
+	}
+
+	// A running VM with a snapshot is stopped and swept before it goes.
+	_, err = p.Delete(ctx, &providerv1.DeleteRequest{Id: id})
+	require.NoError(t, err)
+	describe, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+	require.NoError(t, err)
+	assert.False(t, describe.Exists)
+
+	// Deleting it again, as a controller retrying after a lost response
+	// does, succeeds without touching PVE's destroy endpoint.
+	destroys := srv.RequestCount("/nodes/{node}/qemu/{vmid}")
+	_, err = p.Delete(ctx, &providerv1.DeleteRequest{Id: id})
+	require.NoError(t, err)
+	assert.Equal(t, destroys+1, srv.RequestCount("/nodes/{node}/qemu/{vmid}"), "only the 404 of the already gone VM")
+}
@@ -25,15 +25,19 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	v1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
 	"github.com/projectbeskar/virtrigaud/internal/diskutil"
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
 	"github.com/projectbeskar/virtrigaud/internal/storage"
 	"github.com/projectbeskar/virtrigaud/internal/storage/migration"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
@@ -50,6 +54,42 @@ type Provider struct {
 	// that case rather than panicking. The control plane (Create/Delete/etc.)
 	// never depends on it.
 	ssh *sshTransport
+
+	// inventoryTTL bounds how long the node, storage and VMID inventory is
+	// served from cache (PROVIDER_INVENTORY_TTL). inv is built lazily for the
+	// current client; see inventory().
+	inventoryTTL time.Duration
+	invMu        sync.Mutex
+	inv          *inventory
+	invClient    *pveapi.Client
+
+	// snippetStorage is the PVE storage cicustom snippets are written to
+	// (PROVIDER_SNIPPETS_STORAGE); empty means defaultSnippetStorage.
+	snippetStorage string
+	// isoStorage is the PVE storage Sysprep answer-file ISOs are written to
+	// (PROVIDER_ISO_STORAGE); empty means defaultISOStorage.
+	isoStorage string
+	// storagePreference is the ordered list of storages tried for VM disks
+	// when a request names none (PROVIDER_STORAGE_PREFERENCE); see
+	// selectStorage.
+	storagePreference []string
+
+	// placementDefaults are the pool and HA settings for VMs whose
+	// placement names none (PROVIDER_DEFAULT_POOL, PROVIDER_DEFAULT_HA_*).
+	placementDefaults placementDefaults
+	// maintenanceNodes are the node names and glob patterns new VMs are
+	// kept off (PROVIDER_MAINTENANCE_NODES); see selectNode.
+	maintenanceNodes []string
+
+	// createFlights are the keyed Creates running in this process, so a
+	// retry arriving mid-clone waits for the first attempt's VM.
+	flightsMu     sync.Mutex
+	createFlights map[string]*createFlight
+
+	// clones caps the template clones running at once
+	// (PROVIDER_MAX_CONCURRENT_CLONES); a Create or Clone over the limit is
+	// turned away as busy.
+	clones *common.CloneLimiter
 }
 
 // readCredentialFile reads a credential from a mounted secret file
@@ -164,11 +204,24 @@ func New() *Provider {
 		slog.Warn("Migration SSH data-plane transport unavailable (S3 migration disabled until configured)", "error", sshErr)
 	}
 
+	snippetStorage := os.Getenv("PROVIDER_SNIPPETS_STORAGE")
+	if snippetStorage == "" {
+		snippetStorage = os.Getenv("PVE_SNIPPETS_STORAGE")
+	}
+
 	return &Provider{
-		client:       client,
-		capabilities: caps,
-		logger:       slog.Default(),
-		ssh:          sshTransport,
+		client:         client,
+		capabilities:   caps,
+		logger:         slog.Default(),
+		ssh:            sshTransport,
+		inventoryTTL:   inventoryTTLFromEnv(),
+		snippetStorage: strings.TrimSpace(snippetStorage),
+		isoStorage:     strings.TrimSpace(os.Getenv("PROVIDER_ISO_STORAGE")),
+
+		storagePreference: storagePreferenceFromEnv(),
+		placementDefaults: placementDefaultsFromEnv(),
+		maintenanceNodes:  maintenanceNodesFromEnv(),
+		clones:            common.CloneLimiterFromEnv(),
 	}
 }
 
@@ -181,7 +234,8 @@ func (p *Provider) Validate(ctx context.Context, req *providerv1.ValidateRequest
 		}, nil
 	}
 
-	// Test connectivity by trying to find a node
+	// Test connectivity by trying to find a node. This asks PVE directly
+	// rather than the inventory, which could answer from cache.
 	node, err := p.client.FindNode(ctx)
 	if err != nil {
 		return &providerv1.ValidateResponse{
@@ -196,26 +250,88 @@ func (p *Provider) Validate(ctx context.Context, req *providerv1.ValidateRequest
 	}, nil
 }
 
-// Create creates a new virtual machine
+// Create creates a new virtual machine. A request with an idempotency key
+// returns the VM an earlier request with that key created, or waits for it
+// while that request is still running; see createIdempotent.
 func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
 	if p.client == nil {
 		return nil, errors.NewUnavailable("PVE client not configured", nil)
 	}
+	if req.IdempotencyKey != "" {
+		return p.createIdempotent(ctx, req)
+	}
+	return p.create(ctx, req, "")
+}
 
+// create builds the VM req describes. A non-empty idempotencyTag is added
+// to the VM's tags so a retried request can find it.
+func (p *Provider) create(ctx context.Context, req *providerv1.CreateRequest, idempotencyTag string) (*providerv1.CreateResponse, error) {
 	// Parse the request
-	vmConfig, node, err := p.parseCreateRequest(ctx, req)
+	vmConfig, err := p.parseCreateRequest(ctx, req)
 	if err != nil {
 		return nil, errors.NewInvalidSpec("failed to parse create request: %v", err)
 	}
 
+	// A template clone takes a clone slot before anything is staged for
+	// it, so a request turned away as busy leaves nothing behind.
+	if vmConfig.Template != "" {
+		release, err := p.clones.Acquire()
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	pl, err := p.resolvePlacement(req.PlacementJson)
+	if err != nil {
+		return nil, err
+	}
+	var sel nodeSelection
+	if vmConfig.ImportedDiskPath != "" {
+		// ImportDisk staged the disk on the default node, so the VM has to
+		// be created there.
+		defaultNode, err := p.findNode(ctx)
+		if err != nil {
+			return nil, errors.NewInternal("failed to find node", err)
+		}
+		sel = nodeSelection{node: defaultNode, reason: "imported disk"}
+	} else if sel, err = p.selectNode(ctx, pl); err != nil {
+		return nil, err
+	}
+	node := sel.node
+	providerRaw, err := json.Marshal(sel.providerRaw())
+	if err != nil {
+		return nil, errors.NewInternal("failed to encode placement details", err)
+	}
+	if err := p.validatePool(ctx, pl.Pool); err != nil {
+		return nil, err
+	}
+	if err := p.validateNetworks(ctx, vmConfig.Networks); err != nil {
+		return nil, err
+	}
+	vmConfig.Pool = pl.Pool
+	vmConfig.Tags = p.requestedTags(req.Tags)
+	if idempotencyTag != "" {
+		vmConfig.Tags = append(vmConfig.Tags, idempotencyTag)
+	}
+
 	// Check if VM already exists (idempotency)
 	if existing, existErr := p.client.GetVM(ctx, node, vmConfig.VMID); existErr == nil && existing != nil {
 		// VM exists, check if it matches our requirements
 		if existing.Name == req.Name {
 			p.logger.Info("VM already exists with same name, skipping creation",
 				"vmid", vmConfig.VMID, "name", req.Name)
+			// A previous attempt may have failed between creating the VM
+			// and registering it with the HA manager.
+			if err := p.ensureHA(ctx, vmConfig.VMID, pl.HA); err != nil {
+				return nil, errors.NewInternal("failed to add VM to HA manager", err)
+			}
+			if err := p.applySecurityGroups(ctx, node, vmConfig.VMID, vmConfig.Networks); err != nil {
+				return nil, errors.NewInternal("failed to apply firewall security groups", err)
+			}
 			return &providerv1.CreateResponse{
-				Id: fmt.Sprintf("%d", vmConfig.VMID),
+				Id:              fmt.Sprintf("%d", vmConfig.VMID),
+				ProviderRawJson: string(providerRaw),
 			}, nil
 		}
 		// VM exists but with different name, generate new VMID
@@ -224,12 +340,97 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 		vmConfig.VMID = p.nextVMID(ctx)
 	}
 
+	// Check the requested storage on the node, or pick one from the
+	// preference list, before anything is created. An imported disk must
+	// land somewhere, so it falls back to the default storage.
+	storageReq := storageRequest{
+		node:    node,
+		hint:    vmConfig.Storage,
+		content: "images",
+		bytes:   requestedDiskBytes(req.ClassJson, req.DisksJson),
+	}
+	if pl.Datastore != "" {
+		storageReq.hint = pl.Datastore
+	}
+	if vmConfig.ImportedDiskPath != "" {
+		storageReq.fallback = p.profileStorage("")
+	}
+	if vmConfig.Storage, err = p.selectStorage(ctx, storageReq); err != nil {
+		return nil, err
+	}
+
+	// Resolve the VMClass performance/security profiles before anything is
+	// created so an unsupported combination fails as InvalidSpec instead of
+	// leaving a half-configured VM. A fresh VM takes them at create time; a
+	// clone gets them applied after the clone below.
+	profileStorage := p.profileStorage(vmConfig.Storage)
+	profiles, err := buildClassProfiles(req.ClassJson, profileStorage)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validateProfileStorage(ctx, node, profileStorage, profiles); err != nil {
+		return nil, err
+	}
+	if vmConfig.Template == "" && !profiles.empty() {
+		if vmConfig.Custom == nil {
+			vmConfig.Custom = make(map[string]string)
+		}
+		for k := range profiles.values {
+			vmConfig.Custom[k] = profiles.values.Get(k)
+		}
+	}
+
 	// ADR-0006 Proxmox TARGET: when the request carries a pre-staged imported
 	// disk (from a migration's ImportDisk), the create path builds a bare VM shell
 	// and attaches the staged disk via `qm importdisk` instead of cloning a
 	// template. Handled entirely by createFromImportedDisk and returns early.
 	if vmConfig.ImportedDiskPath != "" {
-		return p.createFromImportedDisk(ctx, req, vmConfig, node)
+		resp, err := p.createFromImportedDisk(ctx, req, vmConfig, node)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.ensureHA(ctx, vmConfig.VMID, pl.HA); err != nil {
+			return nil, errors.NewInternal("failed to add VM to HA manager", err)
+		}
+		if err := p.applySecurityGroups(ctx, node, vmConfig.VMID, vmConfig.Networks); err != nil {
+			return nil, errors.NewInternal("failed to apply firewall security groups", err)
+		}
+		resp.ProviderRawJson = string(providerRaw)
+		return resp, nil
+	}
+
+	// network-config, vendor-data and a large user-data reach the guest as
+	// cicustom snippets. They are uploaded before the VM exists so that a
+	// storage without snippet support fails the request instead of
+	// yielding a VM with the wrong network or configuration.
+	cicustom, err := p.uploadCloudInitSnippets(ctx, node, vmConfig.VMID, userDataSnippet(req.UserData), req.NetworkConfig, req.VendorData)
+	if err != nil {
+		return nil, err
+	}
+	if cicustom != "" {
+		if vmConfig.Custom == nil {
+			vmConfig.Custom = make(map[string]string)
+		}
+		vmConfig.Custom["cicustom"] = cicustom
+	}
+
+	// A Windows guest gets its answer file on an ISO, built up front for
+	// the same reason.
+	unattend, err := contracts.SysprepUnattendFromJSON(req.GuestCustomizationJson)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("%v", err)
+	}
+	var sysprepISO string
+	if unattend != "" {
+		if sysprepISO, err = p.uploadSysprepISO(ctx, node, vmConfig.VMID, unattend); err != nil {
+			return nil, err
+		}
+		if vmConfig.Template == "" {
+			if vmConfig.Custom == nil {
+				vmConfig.Custom = make(map[string]string)
+			}
+			vmConfig.Custom[sysprepDrive] = sysprepISO
+		}
 	}
 
 	var taskID string
@@ -245,24 +446,38 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 		// Clone from template
 		p.logger.Info("Cloning VM from template", "template_id", templateID, "new_vmid", vmConfig.VMID)
 
-		// Set full clone flag
 		if vmConfig.Custom == nil {
 			vmConfig.Custom = make(map[string]string)
 		}
-		vmConfig.Custom["full"] = "1" // Full clone by default
 
-		taskID, err = p.client.CloneVM(ctx, node, templateID, vmConfig)
+		// The clone is issued to the template's node; PVE's target
+		// parameter puts the VM on the selected node, which needs the
+		// template on shared storage.
+		sourceNode := p.templateNode(ctx, templateID, node)
+		if sourceNode != node {
+			vmConfig.Custom["target"] = node
+		}
+
+		taskID, err = p.cloneFromTemplate(ctx, sourceNode, templateID, vmConfig, vmConfig.Custom["full_clone"] == "0")
 		if err != nil {
 			return nil, errors.NewInvalidSpec("failed to clone template: %v", err)
 		}
 
 		// Wait for clone to complete
 		if taskID != "" {
-			if err = p.client.WaitForTask(ctx, node, taskID); err != nil {
+			if err = p.client.WaitForTask(ctx, sourceNode, taskID); err != nil {
 				return nil, errors.NewInternal("clone task failed", err)
 			}
 		}
 
+		// The clone API takes no tags, so the requested and idempotency
+		// tags are added as soon as the clone exists.
+		if len(vmConfig.Tags) > 0 {
+			if err := p.client.AddVMTags(ctx, node, vmConfig.VMID, vmConfig.Tags...); err != nil {
+				return nil, errors.NewInternal("failed to tag cloned VM", err)
+			}
+		}
+
 		// Apply the VMClass sizing on top of the cloned template. A PVE clone
 		// inherits the template's cores/memory, so they MUST be set explicitly or
 		// the VM comes up at the template's size, not the class's (#261 P1-1). This
@@ -272,9 +487,17 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 		if err := p.applyVMClassSizing(ctx, node, vmConfig.VMID, vmConfig.CPUs, vmConfig.Memory); err != nil {
 			return nil, errors.NewInternal("failed to apply VMClass sizing to cloned VM", err)
 		}
+		if err := p.applyClassProfiles(ctx, node, vmConfig.VMID, profiles); err != nil {
+			return nil, errors.NewInternal("failed to apply VMClass profiles to cloned VM", err)
+		}
+		if sysprepISO != "" {
+			if err := p.attachSysprepISO(ctx, node, vmConfig.VMID, sysprepISO); err != nil {
+				return nil, errors.NewInternal("failed to attach sysprep ISO to cloned VM", err)
+			}
+		}
 
 		// After cloning, we need to reconfigure the VM with cloud-init settings
-		if len(req.UserData) > 0 || vmConfig.SSHKeys != "" {
+		if len(req.UserData) > 0 || vmConfig.SSHKeys != "" || cicustom != "" {
 			p.logger.Info("Reconfiguring cloned VM with cloud-init", "vmid", vmConfig.VMID)
 
 			// Auto-detect primary boot disk from cloned VM
@@ -291,12 +514,13 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 			if vmConfig.IDE2 != "" {
 				reconfigValues.Set("ide2", vmConfig.IDE2)
 			}
-			if vmConfig.SSHKeys != "" {
-				reconfigValues.Set("sshkeys", vmConfig.SSHKeys)
-			}
+			pveapi.SetSSHKeys(reconfigValues, vmConfig.SSHKeys)
 			if vmConfig.CIUser != "" {
 				reconfigValues.Set("ciuser", vmConfig.CIUser)
 			}
+			if cicustom != "" {
+				reconfigValues.Set("cicustom", cicustom)
+			}
 			// Set boot order: detected primary disk first, then cloud-init drive
 			bootOrder := fmt.Sprintf("order=%s;ide2", primaryDisk)
 			reconfigValues.Set("boot", bootOrder)
@@ -340,6 +564,9 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 	if err != nil {
 		// Map specific PVE errors to appropriate SDK errors
 		errMsg := err.Error()
+		if isConfigLocked(err) {
+			return nil, pveFailure("failed to create VM", err)
+		}
 		if strings.Contains(errMsg, "already exists") {
 			return nil, errors.NewAlreadyExists("VM", fmt.Sprintf("%d", vmConfig.VMID))
 		}
@@ -357,13 +584,30 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 		}
 		return nil, errors.NewInternal("failed to create VM", err)
 	}
+	p.inventory().RecordVM(vmConfig.VMID, node)
+
+	// The HA manager and the guest firewall only accept a guest whose
+	// config exists, which for a fresh VM is once its create task has
+	// finished.
+	if (pl.HA != nil || hasSecurityGroups(vmConfig.Networks)) && taskID != "" && vmConfig.Template == "" {
+		if err := p.client.WaitForTask(ctx, node, taskID); err != nil {
+			return nil, errors.NewInternal("create task failed", err)
+		}
+	}
+	if err := p.ensureHA(ctx, vmConfig.VMID, pl.HA); err != nil {
+		return nil, errors.NewInternal("failed to add VM to HA manager", err)
+	}
+	if err := p.applySecurityGroups(ctx, node, vmConfig.VMID, vmConfig.Networks); err != nil {
+		return nil, errors.NewInternal("failed to apply firewall security groups", err)
+	}
 
 	result := &providerv1.CreateResponse{
-		Id: fmt.Sprintf("%d", vmConfig.VMID),
+		Id:              fmt.Sprintf("%d", vmConfig.VMID),
+		ProviderRawJson: string(providerRaw),
 	}
 
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 
 	return result, nil
@@ -380,6 +624,18 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
 	}
 
+	// Take the VM out of HA management first: the HA manager would
+	// otherwise start it again after the stop below, and its entry would be
+	// left pointing at a VMID that no longer exists. If the HA config cannot
+	// be read the delete still goes ahead; the purge below drops the entry.
+	if ha, haErr := p.client.GetHAResource(ctx, vmid); haErr != nil {
+		p.logger.Warn("Failed to look up HA resource before delete", "vmid", vmid, "error", haErr)
+	} else if ha != nil {
+		if err := p.client.RemoveHAResource(ctx, vmid); err != nil {
+			return nil, errors.NewInternal("failed to remove VM from HA manager", err)
+		}
+	}
+
 	// Proxmox refuses to destroy a running VM ("VM <id> is running - destroy
 	// failed"), so stop it first and wait for the stop to complete — mirroring
 	// vSphere (power-off → Destroy) and libvirt (destroyDomain → undefine). If PVE
@@ -391,7 +647,7 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 		case "running", "paused", "suspended":
 			stopTask, stopErr := p.client.PowerOperation(ctx, node, vmid, "stop")
 			if stopErr != nil {
-				return nil, errors.NewInternal("failed to stop VM before delete", stopErr)
+				return nil, pveFailure("failed to stop VM before delete", stopErr)
 			}
 			if stopTask != "" {
 				if werr := p.client.WaitForTask(ctx, node, stopTask); werr != nil {
@@ -401,6 +657,14 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 		}
 	}
 
+	// Snapshots go before the VM, leaves first, so ones taken by hand on the
+	// node are reclaimed too. A VM PVE no longer knows has none to sweep.
+	if err == nil && vm != nil {
+		if serr := p.sweepSnapshots(ctx, node, vmid); serr != nil {
+			return nil, errors.NewInternal("failed to delete VM snapshots before destroy", serr)
+		}
+	}
+
 	// Destroy with purge=1 so the config, disk volumes, and any backup/replication
 	// references are reclaimed (a bare DELETE leaves disks behind). Wait for the
 	// destroy task so a successful Delete RPC means the VM is actually gone — the
@@ -408,13 +672,16 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 	// VM still exists would orphan it.
 	taskID, err := p.client.DeleteVM(ctx, node, vmid, true)
 	if err != nil {
-		return nil, errors.NewInternal("failed to delete VM", err)
+		return nil, pveFailure("failed to delete VM", err)
 	}
 	if taskID != "" {
 		if werr := p.client.WaitForTask(ctx, node, taskID); werr != nil {
 			return nil, errors.NewInternal("failed waiting for VM destroy task", werr)
 		}
 	}
+	p.inventory().ForgetVM(vmid)
+	p.removeCloudInitSnippets(ctx, vmid)
+	p.removeSysprepISO(ctx, vmid)
 
 	return &providerv1.TaskResponse{}, nil
 }
@@ -440,6 +707,8 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 		operation = "reboot"
 	case providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL:
 		operation = "shutdown" // Proxmox supports graceful shutdown
+	case providerv1.PowerOp_POWER_OP_RESET:
+		operation = "reset"
 	default:
 		return nil, errors.NewInvalidSpec("unsupported power operation: %v", req.Op)
 	}
@@ -457,19 +726,19 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 		taskID, err = p.client.PowerOperation(ctx, node, vmid, operation)
 	}
 	if err != nil {
-		return nil, errors.NewInternal("failed to perform power operation", err)
+		return nil, pveFailure("failed to perform power operation", err)
 	}
 
 	result := &providerv1.TaskResponse{}
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 
 	return result, nil
 }
 
-// Reconfigure reconfigures a virtual machine
-func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+// Describe describes a virtual machine's current state
+func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
 	if p.client == nil {
 		return nil, errors.NewUnavailable("PVE client not configured", nil)
 	}
@@ -479,162 +748,141 @@ func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureR
 		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
 	}
 
-	// Get current VM configuration to check what can be changed online
-	currentConfig, err := p.client.GetVMConfig(ctx, node, vmid)
+	vm, err := p.client.GetVM(ctx, node, vmid)
 	if err != nil {
-		return nil, errors.NewInternal("failed to get current VM config", err)
-	}
-
-	// Parse the desired configuration
-	var desired map[string]interface{}
-	if err := json.Unmarshal([]byte(req.DesiredJson), &desired); err != nil {
-		return nil, errors.NewInvalidSpec("failed to parse desired configuration: %v", err)
-	}
-
-	// Extract reconfiguration parameters
-	config := &pveapi.ReconfigureConfig{}
-
-	// Handle CPU/memory changes. DesiredJson is a marshaled contracts.CreateRequest,
-	// so the nested VMClass is under the (tag-less) key "Class" with fields "CPU"
-	// and "MemoryMiB" — NOT "class"/"cpus"/"memory" (reading those silently
-	// dropped every reconfigure of CPU/memory; #261 P1-1).
-	if classData, ok := desired["Class"].(map[string]interface{}); ok {
-		if cpus, ok := classData["CPU"].(float64); ok && cpus > 0 {
-			cpuCount := int(cpus)
-			config.CPUs = &cpuCount
-
-			// Check if VM is running - CPU changes may require power cycle
-			vm, err := p.client.GetVM(ctx, node, vmid)
-			if err == nil && vm.Status == "running" {
-				// PVE supports online CPU changes in most cases, but check current config
-				if currentCPUs, exists := currentConfig["cores"]; exists {
-					if currentCPUCount, ok := currentCPUs.(float64); ok && int(currentCPUCount) != cpuCount {
-						// Online CPU change is supported in PVE for most guest OSes
-						p.logger.Info("CPU change will be applied online", "vmid", vmid, "old", int(currentCPUCount), "new", cpuCount)
-					}
-				}
-			}
+		if err == pveapi.ErrVMNotFound {
+			return &providerv1.DescribeResponse{Exists: false}, nil
 		}
+		return nil, errors.NewInternal("failed to describe VM", err)
+	}
 
-		// Handle memory changes. MemoryMiB is already in MiB, which is what PVE's
-		// `memory` config field expects.
-		if mem, ok := classData["MemoryMiB"].(float64); ok && mem > 0 {
-			memMB := int64(mem)
-			config.Memory = &memMB
-
-			// Check if VM is running - memory changes may require power cycle for some guest OSes
-			vm, err := p.client.GetVM(ctx, node, vmid)
-			if err == nil && vm.Status == "running" {
-				// PVE supports online memory changes with balloon driver
-				if currentMem, exists := currentConfig["memory"]; exists {
-					if currentMemMB, ok := currentMem.(float64); ok && int64(currentMemMB) != memMB {
-						p.logger.Info("Memory change will be applied online (requires balloon driver)", "vmid", vmid, "old", int64(currentMemMB), "new", memMB)
-					}
-				}
-			}
+	// Provider-specific details
+	providerRaw := vmProviderRaw(vm)
+	// Snapshot metadata is informational; a failed lookup must not fail
+	// the describe.
+	var snapshotStats *describe.SnapshotStats
+	if list, err := p.client.ListSnapshots(ctx, node, vmid); err != nil {
+		p.logger.Debug("Failed to list snapshots for describe", "error", err)
+	} else {
+		snapshots, active := splitSnapshotList(list)
+		snapshotStats = snapshotChain(snapshots, active)
+		if active != "" {
+			providerRaw["current_snapshot"] = active
 		}
 	}
 
-	// Handle disk changes. The marshaled contracts.CreateRequest nests disks under
-	// the tag-less key "Disks", each a DiskSpec with a numeric "SizeGiB" (GiB) and a
-	// "Name" — NOT "disks"/"size"/"name". Reading the wrong keys silently dropped
-	// every disk resize (same #261 wrong-key class as the CPU/memory fix above).
-	if disksData, ok := desired["Disks"].([]interface{}); ok {
-		for _, diskData := range disksData {
-			if disk, ok := diskData.(map[string]interface{}); ok {
-				if diskName, ok := disk["Name"].(string); ok {
-					if sizeVal, ok := disk["SizeGiB"].(float64); ok && sizeVal > 0 {
-						sizeGB := int64(sizeVal)
+	p.describePlacement(ctx, vmid, providerRaw)
+	p.describeFirewall(ctx, node, vmid, providerRaw)
+	p.describeDiskStorage(ctx, node, vmid, providerRaw)
 
-						// Find corresponding disk in current config
-						diskKey := "scsi0" // Default to scsi0, could be smarter
-						if diskName == "root" {
-							diskKey = "scsi0"
-						}
+	return p.describeResponse(ctx, node, vm, providerRaw, snapshotStats), nil
+}
 
-						// Check current disk size to prevent shrinking
-						if currentDisk, exists := currentConfig[diskKey]; exists {
-							if currentDiskStr, ok := currentDisk.(string); ok {
-								// Parse current disk size from config string (e.g., "local:vm-100-disk-0,size=32G")
-								if strings.Contains(currentDiskStr, "size=") {
-									parts := strings.Split(currentDiskStr, ",")
-									for _, part := range parts {
-										if strings.HasPrefix(part, "size=") {
-											sizeStr := strings.TrimPrefix(part, "size=")
-											sizeStr = strings.TrimSuffix(sizeStr, "G")
-											if currentSize, err := strconv.ParseInt(sizeStr, 10, 64); err == nil {
-												if sizeGB < currentSize {
-													return nil, errors.NewInvalidSpec("disk shrinking not allowed: current=%dG, requested=%dG", currentSize, sizeGB)
-												}
-												if sizeGB > currentSize {
-													// Use ResizeDisk for disk expansion
-													taskID, err := p.client.ResizeDisk(ctx, node, vmid, diskKey, sizeGB)
-													if err != nil {
-														return nil, errors.NewInternal("failed to resize disk", err)
-													}
-
-													result := &providerv1.TaskResponse{}
-													if taskID != "" {
-														result.Task = &providerv1.TaskRef{Id: taskID}
-													}
-													return result, nil
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+// describeBatchAgentQueries bounds the guest agent requests DescribeBatch
+// has in flight at once.
+const describeBatchAgentQueries = 8
+
+// DescribeBatch describes the requested VMs from one cluster/resources
+// listing, which carries every guest's node, status and usage. Only running
+// VMs cost a further request, to their guest agent for addresses. Unlike
+// Describe, the results leave out the snapshot and HA details, which would
+// take two more requests per VM.
+func (p *Provider) DescribeBatch(ctx context.Context, req *providerv1.DescribeBatchRequest) (*providerv1.DescribeBatchResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	guests, err := p.client.ClusterVMs(ctx)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list cluster resources", err)
+	}
+	byVMID := make(map[int]pveapi.ClusterVM, len(guests))
+	for _, guest := range guests {
+		if guest.Type == "qemu" {
+			byVMID[guest.VMID] = guest
 		}
 	}
 
-	// Apply CPU/Memory changes if any
-	if config.CPUs != nil || config.Memory != nil {
-		taskID, err := p.client.ReconfigureVM(ctx, node, vmid, config)
+	resp := &providerv1.DescribeBatchResponse{
+		Results: make(map[string]*providerv1.DescribeResponse, len(req.Ids)),
+	}
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, describeBatchAgentQueries)
+	)
+	for _, id := range req.Ids {
+		vmid, err := vmidFromReference(id)
 		if err != nil {
-			return nil, errors.NewInternal("failed to reconfigure VM", err)
+			if resp.Errors == nil {
+				resp.Errors = map[string]string{}
+			}
+			resp.Errors[id] = errors.NewInvalidSpec("invalid VM reference: %v", err).Error()
+			continue
 		}
-
-		result := &providerv1.TaskResponse{}
-		if taskID != "" {
-			result.Task = &providerv1.TaskRef{Id: taskID}
+		guest, ok := byVMID[vmid]
+		if !ok {
+			// Earlier IDs may still be writing to Results.
+			mu.Lock()
+			resp.Results[id] = &providerv1.DescribeResponse{Exists: false}
+			mu.Unlock()
+			continue
 		}
 
-		// Note: Power cycle requirement should be handled at a higher level
-		// The TaskResponse only contains task reference for async operations
-
-		return result, nil
-	}
-
-	// No changes needed
-	return &providerv1.TaskResponse{}, nil
+		vm := guest.VM()
+		raw := vmProviderRaw(vm)
+		if guest.Pool != "" {
+			raw["pool"] = guest.Pool
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() { <-sem; wg.Done() }()
+			desc := p.describeResponse(ctx, guest.Node, vm, raw, nil)
+			mu.Lock()
+			resp.Results[id] = desc
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return resp, nil
 }
 
-// Describe describes a virtual machine's current state
-func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
-	if p.client == nil {
-		return nil, errors.NewUnavailable("PVE client not configured", nil)
+// vmidFromReference returns the VMID of a "vmid" or "node:vmid" reference
+// without looking up its node.
+func vmidFromReference(ref string) (int, error) {
+	if _, vmid, ok := strings.Cut(ref, ":"); ok {
+		ref = vmid
 	}
-
-	vmid, node, err := p.parseVMReference(req.Id)
+	vmid, err := strconv.Atoi(ref)
 	if err != nil {
-		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+		return 0, fmt.Errorf("invalid VMID in reference: %s", ref)
 	}
+	return vmid, nil
+}
 
-	vm, err := p.client.GetVM(ctx, node, vmid)
-	if err != nil {
-		if err == pveapi.ErrVMNotFound {
-			return &providerv1.DescribeResponse{
-				Exists:     false,
-				PowerState: "notfound",
-			}, nil
-		}
-		return nil, errors.NewInternal("failed to describe VM", err)
+// vmProviderRaw returns the provider-specific details every describe of vm
+// reports, the extras of its describe layout. The node is the layout's host.
+func vmProviderRaw(vm *pveapi.VM) map[string]string {
+	raw := map[string]string{
+		"vmid":      strconv.Itoa(vm.VMID),
+		"status":    vm.Status,
+		"qmpstatus": vm.QMPStatus,
+	}
+	if vm.PID != 0 {
+		raw["pid"] = strconv.Itoa(vm.PID)
+	}
+	if vm.ConfigLock != "" {
+		raw["lock"] = vm.ConfigLock
 	}
+	return raw
+}
 
+// describeResponse builds the describe answer for vm on node, querying its
+// guest agent for addresses when it is running. extras are the
+// provider-specific details of its provider_raw_json, and snapshots its
+// snapshot counts, nil when they were not listed.
+func (p *Provider) describeResponse(ctx context.Context, node string, vm *pveapi.VM, extras map[string]string, snapshots *describe.SnapshotStats) *providerv1.DescribeResponse {
 	// Convert PVE status to the CRD's power-state enum ("On"/"Off"/"OffGraceful").
 	// The manager writes this value straight into VirtualMachine.status.powerState,
 	// so it MUST be a valid enum member — a lowercase "on"/"off" or "suspended" is
@@ -654,13 +902,15 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		endpoint = p.client.Config().Endpoint
 	}
 	consoleURL := fmt.Sprintf("%s/#v1:0:=qemu/%d:4:5:=console",
-		strings.TrimSuffix(endpoint, "/api2"), vmid)
+		strings.TrimSuffix(endpoint, "/api2"), vm.VMID)
 
 	// Extract IP addresses from guest agent
 	var ips []string
+	var addresses []*providerv1.GuestAddress
+	macs := map[string]string{}
 	if vm.Status == "running" {
 		// Try to get IP addresses from QEMU guest agent
-		interfaces, err := p.client.GetGuestNetworkInterfaces(ctx, node, vmid)
+		interfaces, err := p.client.GetGuestNetworkInterfaces(ctx, node, vm.VMID)
 		if err != nil {
 			p.logger.Debug("Failed to get guest network interfaces (guest agent may not be available)", "error", err)
 		} else {
@@ -670,6 +920,7 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 				if iface.Name == "lo" {
 					continue
 				}
+				macs[iface.Name] = iface.HWAddr
 
 				for _, ipAddr := range iface.IPAddresses {
 					// Filter out link-local addresses
@@ -678,35 +929,57 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 						!strings.HasPrefix(ip, "169.254.") &&
 						!strings.HasPrefix(ip, "fe80:") {
 						ips = append(ips, ip)
+						addresses = append(addresses, &providerv1.GuestAddress{Ip: ip, Interface: iface.Name})
 					}
 				}
 			}
 		}
 	}
 
-	// Provider-specific details
-	providerRaw := map[string]string{
-		"node":      vm.Node,
-		"vmid":      strconv.Itoa(vm.VMID),
-		"status":    vm.Status,
-		"qmpstatus": vm.QMPStatus,
-	}
-	if vm.PID != 0 {
-		providerRaw["pid"] = strconv.Itoa(vm.PID)
+	stats := guestStats(vm)
+	details := &describe.Details{
+		Host:       vm.Node,
+		Resources:  describe.NewResourceStats(int32(vm.CPUs), vm.Memory, stats),
+		Interfaces: describe.InterfacesFromAddresses(addresses, macs),
+		Snapshots:  snapshots,
+		BusyReason: busyReason(vm),
+		Extras:     extras,
 	}
-	if vm.ConfigLock != "" {
-		providerRaw["lock"] = vm.ConfigLock
+	providerRawJSON, err := details.Marshal()
+	if err != nil {
+		p.logger.Error("Failed to encode VM details", "vmid", vm.VMID, "error", err)
 	}
 
-	providerRawJSON, _ := json.Marshal(providerRaw)
-
 	return &providerv1.DescribeResponse{
 		Exists:          true,
 		PowerState:      powerState,
 		Ips:             ips,
+		Addresses:       addresses,
 		ConsoleUrl:      consoleURL,
-		ProviderRawJson: string(providerRawJSON),
-	}, nil
+		ProviderRawJson: providerRawJSON,
+		GuestStats:      stats,
+		Identity:        vmIdentity(vm.Tags),
+	}
+}
+
+// guestStats converts the usage PVE reports in VM status. The counters are
+// zero rather than absent for a stopped VM, so nothing is reported unless it
+// is running.
+func guestStats(vm *pveapi.VM) *providerv1.GuestStats {
+	if vm.Status != "running" {
+		return nil
+	}
+	cpu := vm.CPU * 100
+	stats := &providerv1.GuestStats{CpuUsagePercent: &cpu}
+	if vm.Mem > 0 {
+		mem := vm.Mem
+		stats.MemoryUsageBytes = &mem
+	}
+	if vm.Uptime > 0 {
+		uptime := vm.Uptime
+		stats.UptimeSeconds = &uptime
+	}
+	return stats
 }
 
 // TaskStatus checks the status of an async task
@@ -763,18 +1036,25 @@ func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotC
 		snapName = fmt.Sprintf("snapshot-%d", time.Now().Unix())
 	}
 
+	quiesce, quiesceMessage, err := p.snapshotQuiesce(ctx, node, vmid, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create snapshot
 	taskID, err := p.client.CreateSnapshot(ctx, node, vmid, snapName, req.Description, req.IncludeMemory)
 	if err != nil {
-		return nil, errors.NewInternal("failed to create snapshot", err)
+		return nil, pveFailure("failed to create snapshot", err)
 	}
 
 	result := &providerv1.SnapshotCreateResponse{
-		SnapshotId: snapName,
+		SnapshotId:     snapName,
+		Quiesce:        quiesce,
+		QuiesceMessage: quiesceMessage,
 	}
 
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 
 	return result, nil
@@ -793,12 +1073,12 @@ func (p *Provider) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotD
 
 	taskID, err := p.client.DeleteSnapshot(ctx, node, vmid, req.SnapshotId)
 	if err != nil {
-		return nil, errors.NewInternal("failed to delete snapshot", err)
+		return nil, pveFailure("failed to delete snapshot", err)
 	}
 
 	result := &providerv1.TaskResponse{}
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 
 	return result, nil
@@ -817,12 +1097,12 @@ func (p *Provider) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotR
 
 	taskID, err := p.client.RevertSnapshot(ctx, node, vmid, req.SnapshotId)
 	if err != nil {
-		return nil, errors.NewInternal("failed to revert snapshot", err)
+		return nil, pveFailure("failed to revert snapshot", err)
 	}
 
 	result := &providerv1.TaskResponse{}
 	if taskID != "" {
-		result.Task = &providerv1.TaskRef{Id: taskID}
+		result.Task = p.taskRef(taskID)
 	}
 
 	return result, nil
@@ -844,17 +1124,13 @@ func (p *Provider) nextVMID(ctx context.Context) int {
 }
 
 // parseClassSizing extracts the CPU count and memory (MiB) from a marshaled
-// contracts.VMClass (keys "CPU"/"MemoryMiB"; no json tags). Returns zeros when
-// the JSON is empty or unparseable.
+// contracts.VMClass. Returns zeros when the JSON is empty or unparseable.
 func parseClassSizing(classJSON string) (cpus int, memoryMiB int64) {
 	if classJSON == "" {
 		return 0, 0
 	}
-	var class struct {
-		CPU       int32 `json:"CPU"`
-		MemoryMiB int32 `json:"MemoryMiB"`
-	}
-	if err := json.Unmarshal([]byte(classJSON), &class); err != nil {
+	var class contracts.VMClass
+	if err := contracts.UnmarshalPayload([]byte(classJSON), &class); err != nil {
 		return 0, 0
 	}
 	return int(class.CPU), int64(class.MemoryMiB)
@@ -897,6 +1173,20 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 		return nil, errors.NewInvalidSpec("invalid source VM reference: %v", err)
 	}
 
+	var customization *contracts.CloneCustomization
+	if req.CustomizationJson != "" {
+		customization = &contracts.CloneCustomization{}
+		if err := contracts.UnmarshalPayload([]byte(req.CustomizationJson), customization); err != nil {
+			return nil, errors.NewInvalidSpec("invalid customization JSON: %v", err)
+		}
+	}
+
+	release, err := p.clones.Acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Generate new VMID for clone
 	targetVMID := p.nextVMID(ctx)
 
@@ -915,22 +1205,43 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 	// clone request is issued to the source node; PVE's `target` parameter places
 	// the result on another node, so the post-clone sizing must use that node.
 	targetNode := sourceNode
-	if req.PlacementJson != "" {
-		var placement struct {
-			Host      string `json:"Host"`
-			Datastore string `json:"Datastore"`
-		}
-		if err := json.Unmarshal([]byte(req.PlacementJson), &placement); err == nil {
-			if placement.Host != "" {
-				config.Custom["target"] = placement.Host
-				targetNode = placement.Host
-			}
-			if placement.Datastore != "" {
-				config.Storage = placement.Datastore
-			}
+	pl, err := p.resolvePlacement(req.PlacementJson)
+	if err != nil {
+		return nil, err
+	}
+	if pl.Host != "" {
+		config.Custom["target"] = pl.Host
+		targetNode = pl.Host
+	}
+	if pl.Datastore != "" {
+		config.Storage = pl.Datastore
+	}
+	if err := p.validatePool(ctx, pl.Pool); err != nil {
+		return nil, err
+	}
+	config.Pool = pl.Pool
+
+	// A linked clone shares the template's storage, so without a datastore
+	// it is left there rather than moved to a preferred storage.
+	if !req.Linked || config.Storage != "" {
+		storageReq := storageRequest{node: targetNode, hint: config.Storage, content: "images"}
+		if !req.Linked {
+			storageReq.bytes = requestedDiskBytes(req.ClassJson, "")
+		}
+		if config.Storage, err = p.selectStorage(ctx, storageReq); err != nil {
+			return nil, err
 		}
 	}
 
+	profileStorage := p.profileStorage(config.Storage)
+	profiles, err := buildClassProfiles(req.ClassJson, profileStorage)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validateProfileStorage(ctx, targetNode, profileStorage, profiles); err != nil {
+		return nil, err
+	}
+
 	cloneTask, err := p.client.CloneVM(ctx, sourceNode, sourceVMID, config)
 	if err != nil {
 		return nil, errors.NewInternal("failed to clone VM", err)
@@ -948,10 +1259,27 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 	if err := p.applyVMClassSizing(ctx, targetNode, targetVMID, cpus, memMiB); err != nil {
 		return nil, errors.NewInternal("failed to apply VMClass sizing to cloned VM", err)
 	}
+	if err := p.applyClassProfiles(ctx, targetNode, targetVMID, profiles); err != nil {
+		return nil, errors.NewInternal("failed to apply VMClass profiles to cloned VM", err)
+	}
+	if err := p.ensureHA(ctx, targetVMID, pl.HA); err != nil {
+		return nil, errors.NewInternal("failed to add cloned VM to HA manager", err)
+	}
 
-	return &providerv1.CloneResponse{
+	resp := &providerv1.CloneResponse{
 		TargetVmId: fmt.Sprintf("%d", targetVMID),
-	}, nil
+	}
+	// Customization failures are reported with the clone, which stays
+	// powered off, rather than failing the whole request.
+	if customization != nil {
+		if err := p.applyCloneCustomization(ctx, targetNode, targetVMID, customization); err != nil {
+			p.logger.Warn("Failed to customize cloned VM", "vmid", targetVMID, "error", err)
+			resp.CustomizationError = err.Error()
+		} else {
+			resp.CustomizationApplied = true
+		}
+	}
+	return resp, nil
 }
 
 // ImagePrepare is implemented in image.go (structured source parsing, target_name
@@ -964,8 +1292,79 @@ func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapab
 
 // Helper methods
 
+// inlineNetworkBridges maps the well-known inline network names used before
+// VMNetworkAttachment bindings existed onto bridges. Any other inline name
+// that looks like a bridge ("vmbr3") is used as is.
+var inlineNetworkBridges = map[string]string{
+	"lan":        "vmbr0",
+	"default":    "vmbr0",
+	"dmz":        "vmbr1",
+	"management": "vmbr2",
+	"mgmt":       "vmbr2",
+}
+
+// parseNetworkAttachments converts the CreateRequest networks payload into
+// NIC and ipconfig entries. An SDN vnet, or else a bridge, and the VLAN
+// resolved by the manager from a VMNetworkAttachment's proxmox binding take
+// precedence; otherwise the inline network name is mapped onto a bridge,
+// defaulting to vmbr0. Security groups imply the interface firewall.
+func parseNetworkAttachments(networksJSON string) ([]pveapi.NetworkConfig, []pveapi.IPConfig, error) {
+	var attachments []contracts.NetworkAttachment
+	if err := contracts.UnmarshalPayload([]byte(networksJSON), &attachments); err != nil {
+		return nil, nil, errors.NewInvalidSpec("invalid networks payload: %v", err)
+	}
+
+	networks := make([]pveapi.NetworkConfig, 0, len(attachments))
+	ipConfigs := make([]pveapi.IPConfig, 0, len(attachments))
+	for i, att := range attachments {
+		netConfig := pveapi.NetworkConfig{
+			Index:          i,
+			Model:          "virtio",
+			Bridge:         "vmbr0",
+			VLAN:           int(att.VLAN),
+			MAC:            att.MacAddress,
+			VNet:           att.VNet,
+			Firewall:       att.Firewall || len(att.SecurityGroups) > 0,
+			SecurityGroups: att.SecurityGroups,
+		}
+		if att.Model != "" {
+			netConfig.Model = att.Model
+		}
+		switch {
+		case att.VNet != "":
+			// A vnet is attached to as the bridge of the same name.
+			netConfig.Bridge = att.VNet
+		case att.Bridge != "":
+			netConfig.Bridge = att.Bridge
+		case inlineNetworkBridges[att.Name] != "":
+			netConfig.Bridge = inlineNetworkBridges[att.Name]
+		case strings.HasPrefix(att.Name, "vmbr"):
+			netConfig.Bridge = att.Name
+		}
+
+		ipConfig := pveapi.IPConfig{Index: i, DHCP: true}
+		if att.StaticIP != "" {
+			ipConfig.DHCP = false
+			ipConfig.IP = att.StaticIP
+			if att.Prefix > 0 && !strings.Contains(att.StaticIP, "/") {
+				ipConfig.IP = fmt.Sprintf("%s/%d", att.StaticIP, att.Prefix)
+			}
+			ipConfig.Gateway = att.Gateway
+			ipConfig.DNS = att.DNS
+		}
+		if att.StaticIPv6 != "" {
+			ipConfig.IP6 = att.StaticIPv6
+			ipConfig.Gateway6 = att.GatewayIPv6
+		}
+
+		networks = append(networks, netConfig)
+		ipConfigs = append(ipConfigs, ipConfig)
+	}
+	return networks, ipConfigs, nil
+}
+
 // parseCreateRequest parses the gRPC create request into PVE API format
-func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.CreateRequest) (*pveapi.VMConfig, string, error) {
+func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.CreateRequest) (*pveapi.VMConfig, error) {
 	// Generate VMID from name hash or use timestamp
 	vmid := p.nextVMID(ctx)
 
@@ -974,18 +1373,14 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 		Name: req.Name,
 	}
 
-	// Parse VMClass for CPU/memory. ClassJson is a marshaled contracts.VMClass,
-	// whose Go fields have NO json tags, so the keys are "CPU" and "MemoryMiB"
-	// (NOT "cpus"/"memory" — reading those left every Proxmox VM at the PVE
+	// Parse VMClass for CPU/memory. ClassJson is a marshaled contracts.VMClass
+	// (reading ad-hoc "cpus"/"memory" keys left every Proxmox VM at the PVE
 	// default of 1 core / 512 MB regardless of the VMClass; #261 P1-1). This
 	// mirrors the vSphere/libvirt parse of the same contract. MemoryMiB is already
 	// in MiB, which is exactly what the PVE `memory` config field expects.
 	if req.ClassJson != "" {
-		var class struct {
-			CPU       int32 `json:"CPU"`
-			MemoryMiB int32 `json:"MemoryMiB"`
-		}
-		if err := json.Unmarshal([]byte(req.ClassJson), &class); err == nil {
+		var class contracts.VMClass
+		if err := contracts.UnmarshalPayload([]byte(req.ClassJson), &class); err == nil {
 			if class.CPU > 0 {
 				config.CPUs = int(class.CPU)
 			}
@@ -999,12 +1394,17 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 	// The controller sends contracts.VMImage which has the template in TemplateName field
 	if req.ImageJson != "" {
 		// First try parsing as contracts.VMImage (sent by controller)
-		var contractsImage map[string]interface{}
-		if err := json.Unmarshal([]byte(req.ImageJson), &contractsImage); err == nil {
-			// Check for TemplateName field (from contracts.VMImage - note capital T)
-			if templateName, ok := contractsImage["TemplateName"].(string); ok && templateName != "" {
-				config.Template = templateName
-				p.logger.Info("Parsed template from contracts.VMImage", "template", templateName)
+		var contractsImage contracts.VMImage
+		if err := contracts.UnmarshalPayload([]byte(req.ImageJson), &contractsImage); err == nil {
+			if contractsImage.TemplateName != "" {
+				config.Template = contractsImage.TemplateName
+				p.logger.Info("Parsed template from contracts.VMImage", "template", contractsImage.TemplateName)
+			}
+			if contractsImage.LinkedClone {
+				if config.Custom == nil {
+					config.Custom = make(map[string]string)
+				}
+				config.Custom["full_clone"] = "0" // Linked clone
 			}
 
 			// ADR-0006 Proxmox TARGET: a migration's imported disk arrives as a
@@ -1012,19 +1412,23 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 			// threaded through VirtualMachine.Spec.ImportedDisk.Path) and no
 			// TemplateName. Capturing it here switches Create onto the
 			// importdisk-attach path. The Format guides the qemu-img/import format.
-			if path, ok := contractsImage["Path"].(string); ok && strings.TrimSpace(path) != "" && config.Template == "" {
-				config.ImportedDiskPath = strings.TrimSpace(path)
-				if format, ok := contractsImage["Format"].(string); ok && format != "" {
-					config.ImportedDiskFormat = format
+			if path := strings.TrimSpace(contractsImage.Path); path != "" && config.Template == "" {
+				config.ImportedDiskPath = path
+				if contractsImage.Format != "" {
+					config.ImportedDiskFormat = contractsImage.Format
 				}
 				p.logger.Info("Parsed imported-disk path from contracts.VMImage (migration import)",
 					"path", config.ImportedDiskPath, "format", config.ImportedDiskFormat)
 			}
 
-			// Check for storage hint
-			if storage, ok := contractsImage["storage"].(string); ok && storage != "" {
-				config.Storage = storage
-			}
+		}
+
+		// Check for storage hint
+		var hints struct {
+			Storage string `json:"storage"`
+		}
+		if err := json.Unmarshal([]byte(req.ImageJson), &hints); err == nil && hints.Storage != "" {
+			config.Storage = hints.Storage
 		}
 
 		// Fallback: try to parse as VMImageSpec for backwards compatibility
@@ -1070,79 +1474,12 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 
 	// Parse Networks configuration
 	if req.NetworksJson != "" {
-		var networksData []interface{}
-		if err := json.Unmarshal([]byte(req.NetworksJson), &networksData); err == nil {
-			config.Networks = make([]pveapi.NetworkConfig, 0, len(networksData))
-			config.IPConfigs = make([]pveapi.IPConfig, 0, len(networksData))
-
-			for i, netData := range networksData {
-				if network, ok := netData.(map[string]interface{}); ok {
-					netConfig := pveapi.NetworkConfig{
-						Index:  i,
-						Model:  "virtio", // Default model
-						Bridge: "vmbr0",  // Default bridge
-					}
-
-					ipConfig := pveapi.IPConfig{
-						Index: i,
-						DHCP:  true, // Default to DHCP
-					}
-
-					// Extract network name and map to bridge
-					if name, ok := network["name"].(string); ok {
-						// Map network names to bridges
-						switch name {
-						case "lan", "default":
-							netConfig.Bridge = "vmbr0"
-						case "dmz":
-							netConfig.Bridge = "vmbr1"
-						case "management", "mgmt":
-							netConfig.Bridge = "vmbr2"
-						default:
-							// Use the name as bridge if it looks like a bridge name
-							if strings.HasPrefix(name, "vmbr") {
-								netConfig.Bridge = name
-							}
-						}
-					}
-
-					// Check for VLAN configuration
-					if vlan, ok := network["vlan"].(float64); ok {
-						netConfig.VLAN = int(vlan)
-					}
-
-					// Check for static IP configuration
-					if staticIP, ok := network["static_ip"].(map[string]interface{}); ok {
-						if ip, ok := staticIP["address"].(string); ok {
-							ipConfig.IP = ip
-							ipConfig.DHCP = false
-						}
-						if gw, ok := staticIP["gateway"].(string); ok {
-							ipConfig.Gateway = gw
-						}
-						if dns, ok := staticIP["dns"].([]interface{}); ok {
-							var dnsServers []string
-							for _, d := range dns {
-								if dnsStr, ok := d.(string); ok {
-									dnsServers = append(dnsServers, dnsStr)
-								}
-							}
-							if len(dnsServers) > 0 {
-								ipConfig.DNS = strings.Join(dnsServers, ",")
-							}
-						}
-					}
-
-					// Check for MAC address
-					if mac, ok := network["mac"].(string); ok {
-						netConfig.MAC = mac
-					}
-
-					config.Networks = append(config.Networks, netConfig)
-					config.IPConfigs = append(config.IPConfigs, ipConfig)
-				}
-			}
+		networks, ipConfigs, err := parseNetworkAttachments(req.NetworksJson)
+		if err != nil {
+			return nil, err
 		}
+		config.Networks = networks
+		config.IPConfigs = ipConfigs
 	}
 
 	// Default network if none specified
@@ -1158,8 +1495,8 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 		}}
 	}
 
-	// Configure cloud-init if user data provided
-	if len(req.UserData) > 0 {
+	// Configure cloud-init if user data, network-config or vendor-data is provided
+	if len(req.UserData) > 0 || len(req.NetworkConfig) > 0 || len(req.VendorData) > 0 {
 		// IDE2 needs storage pool prefix, use the configured storage or default to 'local'
 		storage := config.Storage
 		if storage == "" {
@@ -1167,73 +1504,37 @@ func (p *Provider) parseCreateRequest(ctx context.Context, req *providerv1.Creat
 		}
 		config.IDE2 = fmt.Sprintf("%s:cloudinit", storage)
 
-		// Extract SSH keys and user from cloud-init data if possible
-		userData := string(req.UserData)
-		if strings.Contains(userData, "ssh_authorized_keys:") {
-			// Try to extract SSH keys from YAML
-			lines := strings.Split(userData, "\n")
-			var sshKeys []string
-			inKeys := false
-			for _, line := range lines {
-				if strings.Contains(line, "ssh_authorized_keys:") {
-					inKeys = true
-					continue
-				}
-				if inKeys && strings.HasPrefix(strings.TrimSpace(line), "- ") {
-					key := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "- "))
-					key = strings.Trim(key, "\"'")
-					// Extra safety: ensure no trailing/leading whitespace including newlines
-					key = strings.TrimSpace(key)
-					if key != "" {
-						sshKeys = append(sshKeys, key)
-					}
-				} else if inKeys && !strings.HasPrefix(strings.TrimSpace(line), " ") {
-					inKeys = false
-				}
+		// PVE generates the user-data document from ciuser and sshkeys, so
+		// the keys are lifted out of the supplied user-data. A user-data
+		// too large for that goes whole as a snippet instead.
+		if userDataSnippet(req.UserData) == nil {
+			if keys := cloudInitSSHKeys(req.UserData); len(keys) > 0 {
+				config.SSHKeys = strings.Join(keys, "\n")
 			}
-			if len(sshKeys) > 0 {
-				// Join multiple keys with newline separator (no trailing newline)
-				// Then trim again to be absolutely sure
-				config.SSHKeys = strings.TrimSpace(strings.Join(sshKeys, "\n"))
-			}
-		}
-
-		// Extract username
-		if strings.Contains(userData, "name:") {
-			lines := strings.Split(userData, "\n")
-			for _, line := range lines {
-				if strings.Contains(line, "name:") && !strings.Contains(line, "hostname:") {
-					parts := strings.Split(line, ":")
-					if len(parts) >= 2 {
-						username := strings.TrimSpace(parts[1])
-						username = strings.Trim(username, "\"' ")
-						if username != "" {
-							config.CIUser = username
-						}
-					}
-					break
-				}
+			if username := cloudInitUser(string(req.UserData)); username != "" {
+				config.CIUser = username
 			}
 		}
 	}
 
-	// Find appropriate node
-	node, err := p.client.FindNode(context.Background())
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to find node: %w", err)
-	}
-
-	return config, node, nil
+	return config, nil
 }
 
 // parseVMReference parses a VM reference (ID) into VMID and node
 func (p *Provider) parseVMReference(ref string) (int, string, error) {
 	// Try to parse as simple VMID first
 	if vmid, err := strconv.Atoi(ref); err == nil {
-		// Find node for this VMID
-		node, err := p.client.FindNode(context.Background())
+		// Look the VMID up in the cluster; a VMID PVE does not know yet (or
+		// an index that cannot be fetched) falls back to the default node.
+		ctx := context.Background()
+		node, ok, err := p.inventory().VMNode(ctx, vmid)
 		if err != nil {
-			return 0, "", fmt.Errorf("failed to find node: %w", err)
+			p.logger.Debug("VMID index unavailable, using the default node", "vmid", vmid, "error", err)
+		}
+		if !ok {
+			if node, err = p.findNode(ctx); err != nil {
+				return 0, "", fmt.Errorf("failed to find node: %w", err)
+			}
 		}
 		return vmid, node, nil
 	}
@@ -1267,14 +1568,21 @@ func (p *Provider) buildNetworkString(netConfig pveapi.NetworkConfig) string {
 	return result
 }
 
-// buildIPConfigString constructs IP configuration string for Proxmox
+// buildIPConfigString constructs IP configuration string for Proxmox. A
+// static IPv6 address is added as ip6=/gw6= next to the IPv4 settings.
 func (p *Provider) buildIPConfigString(ipConfig pveapi.IPConfig) string {
-	if ipConfig.DHCP {
-		return "ip=dhcp"
+	result := "ip=dhcp"
+	if !ipConfig.DHCP {
+		result = fmt.Sprintf("ip=%s", ipConfig.IP)
+		if ipConfig.Gateway != "" {
+			result += fmt.Sprintf(",gw=%s", ipConfig.Gateway)
+		}
 	}
-	result := fmt.Sprintf("ip=%s", ipConfig.IP)
-	if ipConfig.Gateway != "" {
-		result += fmt.Sprintf(",gw=%s", ipConfig.Gateway)
+	if ipConfig.IP6 != "" {
+		result += fmt.Sprintf(",ip6=%s", ipConfig.IP6)
+		if ipConfig.Gateway6 != "" {
+			result += fmt.Sprintf(",gw6=%s", ipConfig.Gateway6)
+		}
 	}
 	return result
 }
@@ -1386,6 +1694,7 @@ func (p *Provider) GetDiskInfo(ctx context.Context, req *providerv1.GetDiskInfoR
 		p.logger.Warn("Failed to list snapshots", "error", err)
 		snapshotList = []*pveapi.Snapshot{}
 	}
+	snapshotList, _ = splitSnapshotList(snapshotList)
 
 	// Convert snapshots to string list
 	snapshots := make([]string, 0, len(snapshotList))
@@ -1699,15 +2008,19 @@ func (p *Provider) ImportDisk(ctx context.Context, req *providerv1.ImportDiskReq
 	p.logger.Info("Importing disk", "source", req.SourceUrl, "storage", req.StorageHint)
 
 	// Find appropriate node
-	node, err := p.client.FindNode(ctx)
+	node, err := p.findNode(ctx)
 	if err != nil {
 		return nil, errors.NewInternal("failed to find node", err)
 	}
 
 	// Determine storage
-	pveStorage := "local-lvm"
-	if req.StorageHint != "" {
-		pveStorage = req.StorageHint
+	pveStorage, err := p.selectStorage(ctx, storageRequest{
+		node:     node,
+		hint:     strings.TrimSpace(req.StorageHint),
+		fallback: p.profileStorage(""),
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Determine target format
@@ -1882,13 +2195,9 @@ func (p *Provider) ListVMs(ctx context.Context, req *providerv1.ListVMsRequest)
 
 	// Use the configured node selector, or discover the cluster's nodes via the
 	// API when none is set (#261 P1-4) instead of failing.
-	nodes := p.client.Config().NodeSelector
-	if len(nodes) == 0 {
-		discovered, err := p.client.ListNodes(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("no node selector configured and node discovery failed: %w", err)
-		}
-		nodes = discovered
+	nodes, err := p.clusterNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no node selector configured and node discovery failed: %w", err)
 	}
 
 	var allVMs []*providerv1.VMInfo
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// currentSnapshotName is the pseudo-entry PVE appends to every snapshot
+// list to mark the running state; its parent is the active snapshot.
+const currentSnapshotName = "current"
+
+// splitSnapshotList separates the real snapshots from the "current"
+// pseudo-entry and returns the name of the snapshot the VM is running on
+// top of, if any.
+func splitSnapshotList(list []*pveapi.Snapshot) (snapshots []*pveapi.Snapshot, active string) {
+	snapshots = make([]*pveapi.Snapshot, 0, len(list))
+	for _, snap := range list {
+		if snap.Name == currentSnapshotName {
+			active = snap.Parent
+			continue
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, active
+}
+
+// snapshotChain counts snapshots, as splitSnapshotList returns them with
+// the snapshot active is. The disks of a VM on qcow2 storage read through
+// one layer for each snapshot in the chain.
+func snapshotChain(snapshots []*pveapi.Snapshot, active string) *describe.SnapshotStats {
+	parents := make(map[string]string, len(snapshots))
+	for _, snap := range snapshots {
+		parents[snap.Name] = snap.Parent
+	}
+	return describe.NewSnapshotStats(parents, active)
+}
+
+// SnapshotList lists the snapshots of a VM. PVE snapshots are addressed by
+// name, so the name doubles as the snapshot ID.
+func (p *Provider) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("PVE client not configured", nil)
+	}
+
+	vmid, node, err := p.parseVMReference(req.VmId)
+	if err != nil {
+		return nil, errors.NewInvalidSpec("invalid VM reference: %v", err)
+	}
+
+	list, err := p.client.ListSnapshots(ctx, node, vmid)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list snapshots", err)
+	}
+
+	snapshots, _ := splitSnapshotList(list)
+	var states map[string]pveapi.StorageVolume
+	for _, snap := range snapshots {
+		if snap.VMSTATE != 0 {
+			states = p.snapshotStateVolumes(ctx, node, vmid)
+			break
+		}
+	}
+
+	resp := &providerv1.SnapshotListResponse{}
+	for _, snap := range snapshots {
+		info := &providerv1.SnapshotInfo{
+			Id:          snap.Name,
+			Name:        snap.Name,
+			CreatedUnix: snap.SnapTime,
+			Description: snap.Description,
+			HasMemory:   snap.VMSTATE != 0,
+			ParentId:    snap.Parent,
+		}
+		if vol, ok := states[snap.Name]; ok && vol.Size > 0 {
+			size, used := vol.Size, vol.Used
+			if used <= 0 {
+				used = size
+			}
+			info.SizeBytes, info.ConsumedBytes = &size, &used
+		}
+		resp.Snapshots = append(resp.Snapshots, info)
+	}
+	return resp, nil
+}
+
+// snapshotStateVolumes returns the RAM state volumes (vm-<vmid>-state-<snap>)
+// of a VM's snapshots, keyed by snapshot name. PVE keeps disk snapshots
+// inside the disk volumes and does not report their size, so the state
+// volume is the only snapshot storage it accounts for. Storages that cannot
+// be listed are skipped: sizes are informational.
+func (p *Provider) snapshotStateVolumes(ctx context.Context, node string, vmid int) map[string]pveapi.StorageVolume {
+	storages, err := p.inventory().Storages(ctx, node)
+	if err != nil {
+		p.logger.Warn("Failed to list storages for snapshot sizes", "node", node, "error", err)
+		return nil
+	}
+	prefix := fmt.Sprintf("vm-%d-state-", vmid)
+	states := map[string]pveapi.StorageVolume{}
+	for _, storage := range storages {
+		if !storage.SupportsContent("images") {
+			continue
+		}
+		vols, err := p.client.GetStorageContent(ctx, node, storage.Storage)
+		if err != nil {
+			p.logger.Warn("Failed to list storage content for snapshot sizes", "storage", storage.Storage, "error", err)
+			continue
+		}
+		for _, vol := range vols {
+			if snap, ok := stateVolumeSnapshot(vol.VolID, prefix); ok {
+				states[snap] = vol
+			}
+		}
+	}
+	return states
+}
+
+// stateVolumeSnapshot returns the snapshot name of a state volume ID such
+// as "local-lvm:vm-100-state-snap1" or "local:100/vm-100-state-snap1.raw".
+func stateVolumeSnapshot(volID, prefix string) (string, bool) {
+	_, name, _ := strings.Cut(volID, ":")
+	name = path.Base(name)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	snap, ok := strings.CutPrefix(name, prefix)
+	if !ok || snap == "" {
+		return "", false
+	}
+	return snap, true
+}
+
+// snapshotDeleteOrder orders snapshots leaves first, so each snapshot is
+// deleted after all of its descendants and PVE never has to re-parent one.
+func snapshotDeleteOrder(snapshots []*pveapi.Snapshot) []string {
+	children := make(map[string]int, len(snapshots))
+	for _, snap := range snapshots {
+		if snap.Parent != "" {
+			children[snap.Parent]++
+		}
+	}
+	order := make([]string, 0, len(snapshots))
+	done := make(map[string]bool, len(snapshots))
+	for len(order) < len(snapshots) {
+		progressed := false
+		for _, snap := range snapshots {
+			if done[snap.Name] || children[snap.Name] > 0 {
+				continue
+			}
+			done[snap.Name] = true
+			order = append(order, snap.Name)
+			if snap.Parent != "" {
+				children[snap.Parent]--
+			}
+			progressed = true
+		}
+		if !progressed {
+			// A cycle cannot come from PVE; drop the rest in list order
+			// rather than loop forever.
+			for _, snap := range snapshots {
+				if !done[snap.Name] {
+					done[snap.Name] = true
+					order = append(order, snap.Name)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// sweepSnapshots deletes every snapshot of a VM, including ones taken
+// outside virtrigaud, before the VM itself is destroyed.
+func (p *Provider) sweepSnapshots(ctx context.Context, node string, vmid int) error {
+	list, err := p.client.ListSnapshots(ctx, node, vmid)
+	if err != nil {
+		return fmt.Errorf("listing snapshots: %w", err)
+	}
+	snapshots, _ := splitSnapshotList(list)
+	for _, name := range snapshotDeleteOrder(snapshots) {
+		p.logger.Info("Deleting snapshot before VM destroy", "vmid", vmid, "snapshot", name)
+		taskID, err := p.client.DeleteSnapshot(ctx, node, vmid, name)
+		if err != nil {
+			return fmt.Errorf("deleting snapshot %s: %w", name, err)
+		}
+		if taskID != "" {
+			if err := p.client.WaitForTask(ctx, node, taskID); err != nil {
+				return fmt.Errorf("waiting for snapshot %s delete: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
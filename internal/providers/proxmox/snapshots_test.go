@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// TestProxmoxProvider_SnapshotList lists a two-level snapshot chain and
+// drops the "current" pseudo-entry PVE appends to the list.
+func TestProxmoxProvider_SnapshotList(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	resp, err := provider.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: "100"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Snapshots)
+
+	_, err = provider.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{
+		VmId: "100", NameHint: "base", Description: "before upgrade",
+	})
+	require.NoError(t, err)
+	_, err = provider.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{
+		VmId: "100", NameHint: "with-ram", IncludeMemory: true,
+	})
+	require.NoError(t, err)
+
+	resp, err = provider.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: "100"})
+	require.NoError(t, err)
+	require.Len(t, resp.Snapshots, 2)
+
+	base := resp.Snapshots[0]
+	assert.Equal(t, "base", base.Id)
+	assert.Equal(t, "before upgrade", base.Description)
+	assert.Positive(t, base.CreatedUnix)
+	assert.False(t, base.HasMemory)
+	assert.Empty(t, base.ParentId)
+	assert.Nil(t, base.SizeBytes, "PVE does not account disk-only snapshots")
+	assert.Nil(t, base.ConsumedBytes)
+
+	withRAM := resp.Snapshots[1]
+	assert.Equal(t, "with-ram", withRAM.Id)
+	assert.True(t, withRAM.HasMemory)
+	assert.Equal(t, "base", withRAM.ParentId)
+	require.NotNil(t, withRAM.SizeBytes)
+	require.NotNil(t, withRAM.ConsumedBytes)
+	assert.Equal(t, int64(4<<30), *withRAM.SizeBytes, "size of the RAM state volume")
+	assert.Equal(t, int64(3<<30), *withRAM.ConsumedBytes)
+
+	// Describe carries a summary of the same list.
+	details, err := conformance.CheckDescribeDetails(ctx, provider, "100")
+	require.NoError(t, err)
+	assert.Equal(t, &describe.SnapshotStats{Count: 2, ChainDepth: 2}, details.Snapshots)
+	assert.Equal(t, "with-ram", details.Extras["current_snapshot"])
+}
+
+func TestSnapshotChain(t *testing.T) {
+	// Rolled back to base and snapshotted again: a second branch.
+	snapshots, active := splitSnapshotList([]*pveapi.Snapshot{
+		{Name: "base"},
+		{Name: "upgrade", Parent: "base"},
+		{Name: "upgrade-2", Parent: "upgrade"},
+		{Name: "retry", Parent: "base"},
+		{Name: currentSnapshotName, Parent: "retry"},
+	})
+	assert.Equal(t, &describe.SnapshotStats{Count: 4, ChainDepth: 2}, snapshotChain(snapshots, active))
+	assert.Equal(t, &describe.SnapshotStats{}, snapshotChain(nil, ""))
+}
+
+func TestProxmoxProvider_SnapshotList_InvalidVM(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.SnapshotList(context.Background(), &providerv1.SnapshotListRequest{VmId: "not-a-vmid"})
+	require.Error(t, err)
+}
+
+func TestStateVolumeSnapshot(t *testing.T) {
+	for volID, want := range map[string]string{
+		"local-lvm:vm-100-state-snap1":           "snap1",
+		"local:100/vm-100-state-pre-upgrade.raw": "pre-upgrade",
+		"ceph:vm-100-state-vm-100-state-x":       "vm-100-state-x",
+		"local-lvm:vm-100-disk-0":                "",
+		"local-lvm:vm-1000-state-snap1":          "",
+	} {
+		got, ok := stateVolumeSnapshot(volID, "vm-100-state-")
+		assert.Equal(t, want != "", ok, volID)
+		assert.Equal(t, want, got, volID)
+	}
+}
+
+func TestSnapshotDeleteOrder(t *testing.T) {
+	// base ─┬─ a ── a2
+	//       └─ b
+	snapshots := []*pveapi.Snapshot{
+		{Name: "base"},
+		{Name: "a", Parent: "base"},
+		{Name: "b", Parent: "base"},
+		{Name: "a2", Parent: "a"},
+	}
+	order := snapshotDeleteOrder(snapshots)
+	require.Len(t, order, 4)
+	pos := map[string]int{}
+	for i, name := range order {
+		pos[name] = i
+	}
+	assert.Less(t, pos["a2"], pos["a"])
+	assert.Less(t, pos["a"], pos["base"])
+	assert.Less(t, pos["b"], pos["base"])
+
+	assert.Empty(t, snapshotDeleteOrder(nil))
+}
+
+// TestProxmoxProvider_SweepSnapshots removes a chain that was never
+// recorded as VMSnapshots, as Delete does before destroying the VM.
+func TestProxmoxProvider_SweepSnapshots(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	for _, name := range []string{"manual-1", "manual-2"} {
+		_, err := provider.client.CreateSnapshot(ctx, "pve", 100, name, "taken by hand", false)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, provider.sweepSnapshots(ctx, "pve", 100))
+
+	resp, err := provider.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: "100"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Snapshots)
+}
+
+// TestProxmoxProvider_SnapshotQuiesce covers the guest agent check made
+// before a quiesced snapshot.
+func TestProxmoxProvider_SnapshotQuiesce(t *testing.T) {
+	srv, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+	agent := map[string]string{"agent": "1"}
+	srv.AddVM(&pvefake.VM{VMID: 401, Name: "agent", Node: "pve", Status: "running", Config: agent})
+	srv.AddVM(&pvefake.VM{VMID: 402, Name: "no-agent", Node: "pve", Status: "running", Config: map[string]string{}})
+	srv.AddVM(&pvefake.VM{VMID: 403, Name: "agent-down", Node: "pve", Status: "running", Config: agent, AgentDown: true})
+	srv.AddVM(&pvefake.VM{VMID: 404, Name: "stopped", Node: "pve", Status: "stopped", Config: map[string]string{}})
+
+	create := func(vmid string, allowUnquiesced bool) (*providerv1.SnapshotCreateResponse, error) {
+		return provider.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{
+			VmId: vmid, NameHint: "quiesced", Quiesce: true, AllowUnquiesced: allowUnquiesced,
+		})
+	}
+
+	resp, err := create("401", false)
+	require.NoError(t, err)
+	assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED, resp.Quiesce)
+
+	for _, vmid := range []string{"402", "403"} {
+		_, err = create(vmid, false)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "VM %s", vmid)
+		list, err := provider.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: vmid})
+		require.NoError(t, err)
+		assert.Empty(t, list.Snapshots, "no snapshot is taken of VM %s", vmid)
+
+		resp, err = create(vmid, true)
+		require.NoError(t, err)
+		assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, resp.Quiesce, "VM %s", vmid)
+		assert.NotEmpty(t, resp.QuiesceMessage)
+	}
+
+	resp, err = create("404", false)
+	require.NoError(t, err)
+	assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, resp.Quiesce)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+const gib = int64(1) << 30
+
+// storagePreferenceFromEnv reads PROVIDER_STORAGE_PREFERENCE (or
+// PVE_STORAGE_PREFERENCE), a comma-separated list of storages such as
+// "ceph-pool,local-lvm" tried in order for VM disks.
+func storagePreferenceFromEnv() []string {
+	raw := os.Getenv("PROVIDER_STORAGE_PREFERENCE")
+	if raw == "" {
+		raw = os.Getenv("PVE_STORAGE_PREFERENCE")
+	}
+	var prefs []string
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(prefs, name) {
+			prefs = append(prefs, name)
+		}
+	}
+	return prefs
+}
+
+// requestedDiskBytes totals the disks a create asks for: the class's root
+// disk and any additional disks. Sizes PVE picks itself (a template's disk
+// kept at its size) are not known here and count as zero.
+func requestedDiskBytes(classJSON, disksJSON string) int64 {
+	var total int64
+	if classJSON != "" {
+		var class contracts.VMClass
+		if err := contracts.UnmarshalPayload([]byte(classJSON), &class); err == nil && class.DiskDefaults != nil {
+			total += int64(class.DiskDefaults.SizeGiB) * gib
+		}
+	}
+	if disksJSON != "" {
+		var disks []contracts.DiskSpec
+		if err := contracts.UnmarshalPayload([]byte(disksJSON), &disks); err == nil {
+			for _, disk := range disks {
+				total += int64(disk.SizeGiB) * gib
+			}
+		}
+	}
+	return total
+}
+
+// storageRequest describes the storage a create, clone or import needs.
+type storageRequest struct {
+	node string
+	// hint is the storage the request names. When set it is the only
+	// candidate.
+	hint string
+	// fallback is tried when there is neither a hint nor a configured
+	// preference list.
+	fallback string
+	// content is the PVE content type the storage must accept, such as
+	// "images" for VM disks; empty accepts any.
+	content string
+	// bytes is the space the new volumes need.
+	bytes int64
+}
+
+// selectStorage picks the storage on req.node for a request's new volumes:
+// the hint if there is one, else the first of the configured preference
+// list, else the fallback, that fits. With none of those it returns "" and
+// PVE keeps its own choice (a template's storage, for a clone).
+//
+// A storage fits when the node has it, it is active, it accepts the
+// content and it has the bytes free. When nothing fits the error names the
+// node and the storages it does have: ResourceExhausted if a candidate
+// was only too small, InvalidSpec otherwise.
+func (p *Provider) selectStorage(ctx context.Context, req storageRequest) (string, error) {
+	candidates := p.storagePreference
+	if req.hint != "" {
+		candidates = []string{req.hint}
+	} else if len(candidates) == 0 && req.fallback != "" {
+		candidates = []string{req.fallback}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	storages, err := p.inventory().Storages(ctx, req.node)
+	if err != nil {
+		return "", errors.NewUnavailable(fmt.Sprintf("storage list of node %s", req.node), err)
+	}
+	byName := make(map[string]pveapi.NodeStorage, len(storages))
+	for _, s := range storages {
+		byName[s.Storage] = s
+	}
+
+	var reasons []string
+	tooSmall := false
+	for _, name := range candidates {
+		s, ok := byName[name]
+		switch {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("%s is not available on the node", name))
+		case s.Active == 0:
+			reasons = append(reasons, fmt.Sprintf("%s is disabled or inactive", name))
+		case req.content != "" && !s.SupportsContent(req.content):
+			reasons = append(reasons, fmt.Sprintf("%s does not accept %s (content %q)", name, req.content, s.Content))
+		case s.Free() < req.bytes:
+			tooSmall = true
+			reasons = append(reasons, fmt.Sprintf("%s has %d GiB free, %d GiB requested",
+				name, s.Free()/gib, (req.bytes+gib-1)/gib))
+		default:
+			if name != req.hint {
+				p.logger.Info("Selected storage", "node", req.node, "storage", name, "candidates", candidates)
+			}
+			return name, nil
+		}
+	}
+
+	available := availableStorages(storages, req.content)
+	msg := fmt.Sprintf("no storage fits on node %s: %s (available storages: %s)",
+		req.node, strings.Join(reasons, "; "), strings.Join(available, ", "))
+	var pe *errors.ProviderError
+	if tooSmall {
+		pe = errors.NewResourceExhausted("%s", msg)
+	} else {
+		pe = errors.NewInvalidSpec("%s", msg)
+	}
+	pe.Details = map[string]interface{}{"node": req.node, "storages": available}
+	return "", pe
+}
+
+// availableStorages lists the active storages that accept content, with
+// their free space, for error messages. It is "none" when there are none.
+func availableStorages(storages []pveapi.NodeStorage, content string) []string {
+	var out []string
+	for _, s := range storages {
+		if s.Active != 0 && (content == "" || s.SupportsContent(content)) {
+			out = append(out, fmt.Sprintf("%s (%d GiB free)", s.Storage, s.Free()/gib))
+		}
+	}
+	if len(out) == 0 {
+		return []string{"none"}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// describeDiskStorage adds the storage backing each of the VM's disks, as
+// "scsi0=local-lvm;scsi1=ceph-pool", to a Describe's provider details. It is
+// informational, so a failed lookup is logged and skipped.
+func (p *Provider) describeDiskStorage(ctx context.Context, node string, vmid int, raw map[string]string) {
+	config, err := p.client.GetVMConfig(ctx, node, vmid)
+	if err != nil {
+		p.logger.Debug("Failed to get VM config for describe", "error", err)
+		return
+	}
+	var disks []string
+	for key, value := range config {
+		volume, ok := value.(string)
+		if !ok || !isDiskKey(key) || strings.Contains(volume, "media=cdrom") {
+			continue
+		}
+		storage, _, found := strings.Cut(volume, ":")
+		if !found || storage == "" {
+			continue
+		}
+		disks = append(disks, key+"="+storage)
+	}
+	if len(disks) == 0 {
+		return
+	}
+	sort.Strings(disks)
+	raw["disk_storage"] = strings.Join(disks, ";")
+}
+
+// isDiskKey reports whether key is a disk slot of a PVE VM config, such as
+// "scsi0" or "virtio1".
+func isDiskKey(key string) bool {
+	for _, bus := range []string{"virtio", "scsi", "sata", "ide"} {
+		if n, ok := strings.CutPrefix(key, bus); ok && n != "" && strings.Trim(n, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
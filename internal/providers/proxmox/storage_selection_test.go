@@ -0,0 +1,149 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// asymmetricStorageCluster starts a fake cluster whose nodes differ: only
+// pve2 has ceph-pool, and pve's "fast" storage is nearly full.
+func asymmetricStorageCluster(t *testing.T) (*pvefake.Server, *Provider) {
+	t.Helper()
+	server, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	server.AddStorage("pve2", "ceph-pool", pvefake.Storage{Content: "images,rootdir", TotalGiB: 1000, UsedGiB: 100})
+	server.AddStorage("pve", "fast", pvefake.Storage{Content: "images", TotalGiB: 100, UsedGiB: 90})
+	server.AddStorage("pve", "offline", pvefake.Storage{Content: "images", TotalGiB: 100, Inactive: true})
+	return server, createTestProvider(endpoint)
+}
+
+func TestStoragePreferenceFromEnv(t *testing.T) {
+	t.Setenv("PROVIDER_STORAGE_PREFERENCE", " ceph-pool, local-lvm,,ceph-pool ")
+	assert.Equal(t, []string{"ceph-pool", "local-lvm"}, storagePreferenceFromEnv())
+
+	t.Setenv("PROVIDER_STORAGE_PREFERENCE", "")
+	t.Setenv("PVE_STORAGE_PREFERENCE", "nfs")
+	assert.Equal(t, []string{"nfs"}, storagePreferenceFromEnv())
+}
+
+func TestRequestedDiskBytes(t *testing.T) {
+	assert.Equal(t, 50*gib, requestedDiskBytes(
+		`{"diskDefaults":{"sizeGiB":40}}`, `[{"sizeGiB":10,"name":"data"}]`))
+	assert.Zero(t, requestedDiskBytes(`{"CPU":2}`, ""))
+}
+
+func TestSelectStorage_PreferenceFollowsEachNodesLayout(t *testing.T) {
+	_, provider := asymmetricStorageCluster(t)
+	provider.storagePreference = []string{"ceph-pool", "local-lvm"}
+	ctx := context.Background()
+
+	storage, err := provider.selectStorage(ctx, storageRequest{node: "pve2", content: "images", bytes: 40 * gib})
+	require.NoError(t, err)
+	assert.Equal(t, "ceph-pool", storage)
+
+	storage, err = provider.selectStorage(ctx, storageRequest{node: "pve", content: "images", bytes: 40 * gib})
+	require.NoError(t, err)
+	assert.Equal(t, "local-lvm", storage, "pve has no ceph-pool, so the next preference is used")
+
+	// Inactive and too-small storages are passed over too.
+	provider.storagePreference = []string{"offline", "fast", "local-lvm"}
+	storage, err = provider.selectStorage(ctx, storageRequest{node: "pve", content: "images", bytes: 20 * gib})
+	require.NoError(t, err)
+	assert.Equal(t, "local-lvm", storage)
+}
+
+func TestSelectStorage_NothingFits(t *testing.T) {
+	_, provider := asymmetricStorageCluster(t)
+	ctx := context.Background()
+
+	_, err := provider.selectStorage(ctx, storageRequest{node: "pve", hint: "ceph-pool", content: "images"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "node pve")
+	assert.Contains(t, err.Error(), "ceph-pool is not available on the node")
+	assert.Contains(t, err.Error(), "available storages: fast (10 GiB free), local-lvm (375 GiB free)")
+
+	_, err = provider.selectStorage(ctx, storageRequest{node: "pve", hint: "offline", content: "images"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "offline is disabled or inactive")
+
+	_, err = provider.selectStorage(ctx, storageRequest{node: "pve", hint: "local", content: "images"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), `local does not accept images`)
+
+	_, err = provider.selectStorage(ctx, storageRequest{node: "pve", hint: "fast", content: "images", bytes: 20 * gib})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "fast has 10 GiB free, 20 GiB requested")
+
+	// Without a hint, preference or fallback PVE keeps its own choice.
+	storage, err := provider.selectStorage(ctx, storageRequest{node: "pve", content: "images"})
+	require.NoError(t, err)
+	assert.Empty(t, storage)
+}
+
+func TestProxmoxProvider_CloneValidatesStorageOnTargetNode(t *testing.T) {
+	server, provider := asymmetricStorageCluster(t)
+	server.AddVM(&pvefake.VM{VMID: 410, Name: "storage-source", Node: "pve", Status: "stopped"})
+	ctx := context.Background()
+
+	_, err := provider.Clone(ctx, &providerv1.CloneRequest{
+		SourceVmId:    "410",
+		TargetName:    "on-pve",
+		PlacementJson: placementJSON(t, contracts.Placement{Datastore: "ceph-pool"}),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the source node pve has no ceph-pool")
+
+	resp, err := provider.Clone(ctx, &providerv1.CloneRequest{
+		SourceVmId:    "410",
+		TargetName:    "on-pve2",
+		PlacementJson: placementJSON(t, contracts.Placement{Host: "pve2", Datastore: "ceph-pool"}),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.TargetVmId)
+}
+
+func TestProxmoxProvider_CreateRejectsFullStorage(t *testing.T) {
+	_, provider := asymmetricStorageCluster(t)
+
+	_, err := provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:          "too-big",
+		ClassJson:     `{"CPU":1,"MemoryMiB":1024,"diskDefaults":{"sizeGiB":500}}`,
+		PlacementJson: placementJSON(t, contracts.Placement{Datastore: "local-lvm"}),
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestProxmoxProvider_DescribeReportsDiskStorage(t *testing.T) {
+	_, provider := asymmetricStorageCluster(t)
+
+	resp, err := provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:      "described",
+		ClassJson: `{"CPU":1,"MemoryMiB":1024}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "scsi0=local-lvm", describeRaw(t, provider, resp.Id)["disk_storage"])
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// defaultISOStorage is where Sysprep answer-file ISOs go unless
+// PROVIDER_ISO_STORAGE names another storage.
+const defaultISOStorage = "local"
+
+// sysprepDrive is the slot the answer-file ISO is attached to. ide2 is
+// taken by the cloud-init drive, which cloudbase-init can still read.
+const sysprepDrive = "ide3"
+
+// A Windows guest gets its unattend.xml on a CD-ROM: Windows Setup scans
+// removable media for an answer file during the specialize and oobeSystem
+// passes. Like cicustom snippets, the ISO is built over SSH, directly in the
+// path `pvesm path` reports for its volume.
+
+// sysprepISOVolume names vmid's answer-file ISO. Names are per VMID, so
+// re-creating a VM overwrites its previous ISO.
+func sysprepISOVolume(storage string, vmid int) string {
+	return fmt.Sprintf("%s:iso/virtrigaud-%d-sysprep.iso", storage, vmid)
+}
+
+// sysprepDriveValue renders volume as a CD-ROM drive option.
+func sysprepDriveValue(volume string) string {
+	return volume + ",media=cdrom"
+}
+
+// sysprepISOScript builds an ISO at file from the answer file on stdin. The
+// file is written as both Unattend.xml and Autounattend.xml, the names
+// Windows Setup looks for on read/write and read-only media respectively.
+func sysprepISOScript(file string) string {
+	return fmt.Sprintf(`d=$(mktemp -d) && cat > "$d/unattend.xml" && cp "$d/unattend.xml" "$d/autounattend.xml" && `+
+		`mkdir -p %s && genisoimage -quiet -output %s -volid UNATTEND -joliet -rock "$d"; rc=$?; rm -rf "$d"; exit $rc`,
+		proxmoxShellQuote(path.Dir(file)), proxmoxShellQuote(file))
+}
+
+func (p *Provider) isoStorageName() string {
+	if p.isoStorage != "" {
+		return p.isoStorage
+	}
+	return defaultISOStorage
+}
+
+// uploadSysprepISO writes vmid's answer-file ISO on node and returns the
+// drive value that attaches it.
+func (p *Provider) uploadSysprepISO(ctx context.Context, node string, vmid int, unattendXML string) (string, error) {
+	storage := p.isoStorageName()
+	volume := sysprepISOVolume(storage, vmid)
+
+	status, err := p.inventory().Storage(ctx, node, storage)
+	if err != nil {
+		return "", errors.NewInvalidSpec("sysprep guest customization needs ISO storage %q on node %s: %v", storage, node, err)
+	}
+	if !status.SupportsContent("iso") {
+		return "", errors.NewInvalidSpec(
+			"sysprep guest customization needs a storage that allows ISO images, and %q on node %s allows only %q; "+
+				"add iso to its content or set PROVIDER_ISO_STORAGE", storage, node, status.Content)
+	}
+	if p.ssh == nil {
+		return "", errors.NewUnavailable(
+			"sysprep answer files are uploaded as an ISO over SSH, and no SSH credentials are configured", nil)
+	}
+
+	stdout, stderr, err := p.ssh.runSSH(ctx, "pvesm path "+proxmoxShellQuote(volume))
+	if err != nil {
+		return "", errors.NewInternal(fmt.Sprintf("resolve ISO path for %s (stderr: %s)", volume, strings.TrimSpace(stderr)), err)
+	}
+	file := strings.TrimSpace(stdout)
+	if err := p.ssh.runSSHStdin(ctx, strings.NewReader(unattendXML), sysprepISOScript(file)); err != nil {
+		return "", errors.NewInternal(fmt.Sprintf("build sysprep ISO %s", volume), err)
+	}
+	p.logger.Info("Uploaded sysprep answer-file ISO", "vmid", vmid, "volume", volume)
+	return sysprepDriveValue(volume), nil
+}
+
+// attachSysprepISO attaches the answer-file ISO to a cloned VM; the clone
+// API takes no drive options.
+func (p *Provider) attachSysprepISO(ctx context.Context, node string, vmid int, drive string) error {
+	vals := url.Values{}
+	vals.Set(sysprepDrive, drive)
+	task, err := p.client.ReconfigureVMRaw(ctx, node, vmid, vals)
+	if err != nil {
+		return err
+	}
+	if task != "" {
+		return p.client.WaitForTask(ctx, node, task)
+	}
+	return nil
+}
+
+// removeSysprepISO deletes vmid's answer-file ISO, if any, after the VM is
+// destroyed. Failures are logged only.
+func (p *Provider) removeSysprepISO(ctx context.Context, vmid int) {
+	if p.ssh == nil {
+		return
+	}
+	volume := sysprepISOVolume(p.isoStorageName(), vmid)
+	cmd := fmt.Sprintf(`f=$(pvesm path %s 2>/dev/null) && rm -f "$f"; true`, proxmoxShellQuote(volume))
+	if _, stderr, err := p.ssh.runSSH(ctx, cmd); err != nil {
+		p.logger.Warn("Failed to remove sysprep ISO", "vmid", vmid, "error", err, "stderr", strings.TrimSpace(stderr))
+	}
+}
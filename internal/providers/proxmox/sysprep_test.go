@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+const testSysprepJSON = `{"Type":"Sysprep","Sysprep":{"UnattendXML":"<unattend/>"}}`
+
+func TestSysprepISO(t *testing.T) {
+	volume := sysprepISOVolume("local", 100)
+	assert.Equal(t, "local:iso/virtrigaud-100-sysprep.iso", volume)
+	assert.Equal(t, "local:iso/virtrigaud-100-sysprep.iso,media=cdrom", sysprepDriveValue(volume))
+
+	script := sysprepISOScript("/var/lib/vz/template/iso/virtrigaud-100-sysprep.iso")
+	assert.Contains(t, script, "-output '/var/lib/vz/template/iso/virtrigaud-100-sysprep.iso'")
+	assert.Contains(t, script, `"$d/autounattend.xml"`)
+	assert.Contains(t, script, `rm -rf "$d"`, "the staging directory is removed even when genisoimage fails")
+}
+
+func TestGetProviderCapabilities_Sysprep(t *testing.T) {
+	caps, err := GetProviderCapabilities().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	require.NoError(t, err)
+	assert.True(t, caps.SupportsSysprep)
+}
+
+// TestProxmoxProvider_CreateSysprepChecksISOStorage: the answer-file ISO is
+// built before the VM exists, so a storage without ISO content or a missing
+// SSH transport fails the request and leaves nothing behind.
+func TestProxmoxProvider_CreateSysprepChecksISOStorage(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	provider.ssh = nil
+	ctx := context.Background()
+
+	req := &providerv1.CreateRequest{
+		Name:                   "win-vm",
+		ClassJson:              `{"CPU":1,"MemoryMiB":1024}`,
+		GuestCustomizationJson: testSysprepJSON,
+	}
+
+	provider.isoStorage = "local-lvm"
+	_, err = provider.Create(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+	assert.Contains(t, err.Error(), "PROVIDER_ISO_STORAGE")
+
+	provider.isoStorage = ""
+	_, err = provider.Create(ctx, req)
+	assert.Equal(t, codes.Unavailable, s3GRPCCode(t, err))
+
+	list, err := provider.ListVMs(ctx, &providerv1.ListVMsRequest{})
+	require.NoError(t, err)
+	for _, vm := range list.Vms {
+		assert.NotEqual(t, "win-vm", vm.Name)
+	}
+}
+
+func TestProxmoxProvider_CreateRejectsUnknownGuestCustomization(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+
+	_, err = provider.Create(context.Background(), &providerv1.CreateRequest{
+		Name:                   "odd-vm",
+		ClassJson:              `{"CPU":1,"MemoryMiB":1024}`,
+		GuestCustomizationJson: `{"Type":"Ignition"}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, s3GRPCCode(t, err))
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	v1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/vmtags"
+)
+
+// requestedTags returns the tags of a Create that PVE can hold. The manager
+// validates tags against the same rules before sending them; one that
+// breaks them anyway is left off with a warning instead of failing the
+// create, since PVE would reject the whole tags option.
+func (p *Provider) requestedTags(tags []string) []string {
+	var valid []string
+	for _, tag := range tags {
+		if reason := vmtags.Check(v1beta1.ProviderTypeProxmox, tag); reason != "" {
+			p.logger.Warn("Leaving off tag Proxmox VE cannot hold", "tag", tag, "reason", reason)
+			continue
+		}
+		valid = append(valid, tag)
+	}
+	return valid
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pvefake"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestProxmoxProvider_CreateAppliesTags(t *testing.T) {
+	_, endpoint, err := pvefake.StartFakeServer()
+	require.NoError(t, err)
+	provider := createTestProvider(endpoint)
+	ctx := context.Background()
+
+	for _, req := range []*providerv1.CreateRequest{
+		{Name: "plain", ClassJson: `{"CPU":1,"MemoryMiB":1024}`, IdempotencyKey: "1a-uid"},
+		{Name: "cloned", ImageJson: `{"TemplateName":"9000"}`, IdempotencyKey: "2b-uid"},
+	} {
+		req.Tags = []string{"managed", "env=prod", "cost-center-42"}
+		_, err := provider.Create(ctx, req)
+		require.NoError(t, err, req.Name)
+
+		vm, err := provider.findTaggedVM(ctx, idempotencyTag(req.IdempotencyKey))
+		require.NoError(t, err, req.Name)
+		require.NotNil(t, vm, req.Name)
+		assert.ElementsMatch(t, []string{"managed", "cost-center-42", idempotencyTag(req.IdempotencyKey)},
+			pveapi.SplitTags(vm.Tags), "%s: the tag PVE cannot hold is left off", req.Name)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// taskRef is the TaskRef returned for a PVE task: its UPID, and a link to
+// the task's log on the node that runs it.
+func (p *Provider) taskRef(upid string) *providerv1.TaskRef {
+	return &providerv1.TaskRef{Id: upid, Link: p.taskLink(upid)}
+}
+
+// taskLink returns the URL of the log page of the task upid, as served by
+// the PVE API's HTML formatter to a browser logged in to the web UI. The
+// scheme and host come from the configured endpoint, never from the UPID;
+// "" when there is no endpoint or upid is not a UPID.
+func (p *Provider) taskLink(upid string) string {
+	if p.client == nil || p.client.Config() == nil {
+		return ""
+	}
+	base, err := url.Parse(p.client.Config().Endpoint)
+	if err != nil || base.Host == "" || (base.Scheme != "https" && base.Scheme != "http") {
+		return ""
+	}
+	// UPID:<node>:<pid>:<pstart>:<starttime>:<type>:<id>:<user>:
+	parts := strings.Split(upid, ":")
+	if len(parts) < 3 || parts[0] != "UPID" || parts[1] == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s/api2/html/nodes/%s/tasks/%s/log",
+		base.Scheme, base.Host, url.PathEscape(parts[1]), url.PathEscape(upid))
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxmoxProvider_TaskLink(t *testing.T) {
+	p := createTestProvider("https://pve.example.com:8006/api2/json")
+	upid := "UPID:pve1:0001B2C3:00A1B2C3:65F0A1B2:qmcreate:100:root@pam:"
+
+	ref := p.taskRef(upid)
+	assert.Equal(t, upid, ref.Id)
+	assert.Equal(t,
+		"https://pve.example.com:8006/api2/html/nodes/pve1/tasks/UPID:pve1:0001B2C3:00A1B2C3:65F0A1B2:qmcreate:100:root@pam:/log",
+		ref.Link)
+
+	// Path separators in the UPID stay inside their path segment.
+	assert.Equal(t,
+		"https://pve.example.com:8006/api2/html/nodes/..%2Fevil/tasks/UPID:..%2Fevil:1:2:3:x:1:u:/log",
+		p.taskLink("UPID:../evil:1:2:3:x:1:u:"))
+
+	assert.Empty(t, p.taskLink("not-a-upid"))
+	assert.Empty(t, createTestProvider("ftp://pve.example.com").taskLink(upid))
+	assert.Empty(t, (&Provider{}).taskLink(upid))
+}
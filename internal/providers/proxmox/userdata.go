@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/proxmox/pveapi"
+)
+
+// cloudConfigKeys is the part of a #cloud-config document that carries SSH
+// public keys: the top-level list and each user's list.
+type cloudConfigKeys struct {
+	SSHAuthorizedKeys []string `json:"ssh_authorized_keys"`
+	Users             []any    `json:"users"`
+}
+
+// cloudInitSSHKeys returns the SSH public keys in a #cloud-config user-data
+// document, normalized to one key per entry. User-data that is not YAML,
+// such as a shell script, has none.
+func cloudInitSSHKeys(userData []byte) []string {
+	var doc cloudConfigKeys
+	if len(userData) == 0 || yaml.Unmarshal(userData, &doc) != nil {
+		return nil
+	}
+	keys := append([]string(nil), doc.SSHAuthorizedKeys...)
+	for _, u := range doc.Users {
+		// Entries are either a mapping or a bare name such as "default".
+		user, ok := u.(map[string]any)
+		if !ok {
+			continue
+		}
+		list, _ := user["ssh_authorized_keys"].([]any)
+		for _, k := range list {
+			if s, ok := k.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+	}
+	return pveapi.NormalizeSSHKeys(keys...)
+}
+
+// cloudInitUser returns the user name of the first "name:" line in a
+// #cloud-config user-data document, which PVE takes as ciuser, or "" when
+// there is none.
+func cloudInitUser(userData string) string {
+	for _, line := range strings.Split(userData, "\n") {
+		if strings.Contains(line, "name:") && !strings.Contains(line, "hostname:") {
+			parts := strings.Split(line, ":")
+			if len(parts) < 2 {
+				return ""
+			}
+			return strings.Trim(strings.TrimSpace(parts[1]), "\"' ")
+		}
+	}
+	return ""
+}
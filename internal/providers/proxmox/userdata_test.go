@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxmox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudInitSSHKeys(t *testing.T) {
+	t.Run("top-level and per-user keys", func(t *testing.T) {
+		userData := `#cloud-config
+ssh_authorized_keys:
+  - ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA top-level
+users:
+  - default
+  - name: ubuntu
+    ssh_authorized_keys:
+      - "ssh-rsa AAAAB3NzaC1yc2E= alice laptop"
+      - 'ecdsa-sha2-nistp256 AAAAE2VjZHNh= ops (prod, 2026)'
+`
+		assert.Equal(t, []string{
+			"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA top-level",
+			"ssh-rsa AAAAB3NzaC1yc2E= alice laptop",
+			"ecdsa-sha2-nistp256 AAAAE2VjZHNh= ops (prod, 2026)",
+		}, cloudInitSSHKeys([]byte(userData)))
+	})
+
+	t.Run("windows newlines", func(t *testing.T) {
+		userData := strings.ReplaceAll(`#cloud-config
+users:
+  - name: admin
+    ssh_authorized_keys:
+      - ssh-ed25519 AAAAC3Nza one@host
+      - ssh-ed25519 AAAAC3Nzb two@host
+`, "\n", "\r\n")
+		assert.Equal(t, []string{"ssh-ed25519 AAAAC3Nza one@host", "ssh-ed25519 AAAAC3Nzb two@host"},
+			cloudInitSSHKeys([]byte(userData)))
+	})
+
+	t.Run("block scalar holding several keys", func(t *testing.T) {
+		userData := `#cloud-config
+ssh_authorized_keys:
+  - |
+    ssh-ed25519 AAAAC3Nza one@host
+    # rotated out: ssh-rsa AAAAold
+    ssh-ed25519 AAAAC3Nzb two@host
+`
+		assert.Equal(t, []string{"ssh-ed25519 AAAAC3Nza one@host", "ssh-ed25519 AAAAC3Nzb two@host"},
+			cloudInitSSHKeys([]byte(userData)))
+	})
+
+	t.Run("not cloud-config", func(t *testing.T) {
+		assert.Empty(t, cloudInitSSHKeys([]byte("#!/bin/sh\necho hi: there\n")))
+		assert.Empty(t, cloudInitSSHKeys(nil))
+	})
+}
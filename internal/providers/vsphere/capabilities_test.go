@@ -53,6 +53,8 @@ func TestGetCapabilities_DiskMigration(t *testing.T) {
 	// vSphere captures RAM-inclusive snapshots via CreateSnapshot(memory=true) when the
 	// VM is powered on; SnapshotCreate already honours req.IncludeMemory (issue #200).
 	assert.True(t, caps.SupportsMemorySnapshots)
+	assert.True(t, caps.SupportsQuiescedSnapshots, "CreateSnapshot(quiesce=true) goes through VMware Tools")
+	assert.True(t, caps.SupportsConsoleProxy, "WebMKS tickets reach the manager's console proxy")
 }
 
 // TestGetCapabilities_StorageBackends verifies vSphere advertises the honest
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// GetCapacity reports one entry per cluster (only the default cluster when
+// one is configured), summed from the cluster hosts' hardware and quick
+// stats. CPU utilization is the hosts' MHz usage over their total MHz.
+// Storage is the default datastore, when one is configured.
+func (p *Provider) GetCapacity(ctx context.Context, req *providerv1.GetCapacityRequest) (*providerv1.GetCapacityResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("vSphere client not configured", nil)
+	}
+
+	datacenter, err := p.finder.DefaultDatacenter(ctx)
+	if err != nil {
+		return nil, errors.NewUnavailable("failed to find default datacenter", err)
+	}
+	p.finder.SetDatacenter(datacenter)
+
+	var clusters []*object.ClusterComputeResource
+	if p.config.DefaultCluster != "" {
+		cluster, err := p.finder.ClusterComputeResource(ctx, p.config.DefaultCluster)
+		if err != nil {
+			return nil, errors.NewNotFound("cluster", p.config.DefaultCluster)
+		}
+		clusters = append(clusters, cluster)
+	} else if clusters, err = p.finder.ClusterComputeResourceList(ctx, "*"); err != nil {
+		return nil, errors.NewUnavailable("failed to list clusters", err)
+	}
+
+	var storageTotal, storageUsed int64
+	if p.config.DefaultDatastore != "" {
+		if ds, err := p.finder.Datastore(ctx, p.config.DefaultDatastore); err == nil {
+			var dsMo mo.Datastore
+			if err := ds.Properties(ctx, ds.Reference(), []string{"summary"}, &dsMo); err == nil {
+				storageTotal = dsMo.Summary.Capacity
+				storageUsed = dsMo.Summary.Capacity - dsMo.Summary.FreeSpace
+			}
+		}
+	}
+
+	pc := property.DefaultCollector(p.client.Client)
+	resp := &providerv1.GetCapacityResponse{}
+	for _, cluster := range clusters {
+		host, err := clusterCapacity(ctx, pc, cluster)
+		if err != nil {
+			p.logger.Warn("Failed to read cluster capacity", "cluster", cluster.Name(), "error", err)
+			continue
+		}
+		host.StorageTotalBytes = storageTotal
+		host.StorageUsedBytes = storageUsed
+		resp.Hosts = append(resp.Hosts, host)
+	}
+	if len(resp.Hosts) == 0 {
+		return nil, errors.NewUnavailable("no cluster reported its capacity", nil)
+	}
+	return resp, nil
+}
+
+// clusterCapacity sums the hardware and quick stats of a cluster's hosts.
+func clusterCapacity(ctx context.Context, pc *property.Collector, cluster *object.ClusterComputeResource) (*providerv1.HostCapacity, error) {
+	var cc mo.ClusterComputeResource
+	if err := pc.RetrieveOne(ctx, cluster.Reference(), []string{"name", "host"}, &cc); err != nil {
+		return nil, fmt.Errorf("failed to read cluster: %w", err)
+	}
+
+	out := &providerv1.HostCapacity{Name: cc.Name}
+	if len(cc.Host) == 0 {
+		return out, nil
+	}
+
+	var hosts []mo.HostSystem
+	if err := pc.Retrieve(ctx, cc.Host, []string{"summary.hardware", "summary.quickStats"}, &hosts); err != nil {
+		return nil, fmt.Errorf("failed to read cluster hosts: %w", err)
+	}
+
+	var totalMHz, usedMHz int64
+	for _, h := range hosts {
+		if hw := h.Summary.Hardware; hw != nil {
+			out.CpuCores += int32(hw.NumCpuCores)
+			totalMHz += int64(hw.CpuMhz) * int64(hw.NumCpuCores)
+			out.MemoryTotalBytes += hw.MemorySize
+		}
+		usedMHz += int64(h.Summary.QuickStats.OverallCpuUsage)
+		out.MemoryUsedBytes += int64(h.Summary.QuickStats.OverallMemoryUsage) * 1024 * 1024
+	}
+	if totalMHz > 0 {
+		out.CpuUtilization = float64(usedMHz) / float64(totalMHz)
+	}
+	return out, nil
+}
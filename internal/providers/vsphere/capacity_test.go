@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TestGetCapacity_ClusterSummary reads the simulator's default cluster and
+// datastore and checks the summed figures are populated and consistent.
+func TestGetCapacity_ClusterSummary(t *testing.T) {
+	cfg, cleanup := newSimConfig(t)
+	defer cleanup()
+	cfg.DefaultCluster = "DC0_C0"
+	cfg.DefaultDatastore = "LocalDS_0"
+
+	client, finder, err := createVSphereClient(cfg)
+	require.NoError(t, err)
+	defer func() { _ = client.Logout(context.Background()) }()
+
+	p := &Provider{client: client, finder: finder, config: cfg, logger: slog.Default()}
+	resp, err := p.GetCapacity(context.Background(), &providerv1.GetCapacityRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Hosts, 1)
+
+	host := resp.Hosts[0]
+	assert.Equal(t, "DC0_C0", host.Name)
+	assert.Positive(t, host.CpuCores)
+	assert.Positive(t, host.MemoryTotalBytes)
+	assert.GreaterOrEqual(t, host.CpuUtilization, 0.0)
+	assert.LessOrEqual(t, host.MemoryUsedBytes, host.MemoryTotalBytes)
+	assert.Positive(t, host.StorageTotalBytes)
+	assert.LessOrEqual(t, host.StorageUsedBytes, host.StorageTotalBytes)
+}
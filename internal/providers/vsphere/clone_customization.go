@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// customizeClone applies c to a cloned VM, which must still be powered off:
+// MAC overrides are set on its NICs, then guest customization runs with the
+// CustomizationSpec built from c. The guest applies it on first boot.
+func (p *Provider) customizeClone(ctx context.Context, vm *object.VirtualMachine, targetName string, c *contracts.CloneCustomization) error {
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the clone's devices: %w", err)
+	}
+	nics := devices.SelectByType((*types.VirtualEthernetCard)(nil))
+	for _, o := range c.Networks {
+		if o.Index >= len(nics) {
+			return fmt.Errorf("network %q is NIC %d, but the clone has %d NICs", o.Name, o.Index, len(nics))
+		}
+	}
+
+	var changes []types.BaseVirtualDeviceConfigSpec
+	for _, o := range c.Networks {
+		if o.MACAddress == "" {
+			continue
+		}
+		card := nics[o.Index].(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		card.AddressType = string(types.VirtualEthernetCardMacTypeManual)
+		card.MacAddress = o.MACAddress
+		changes = append(changes, &types.VirtualDeviceConfigSpec{
+			Operation: types.VirtualDeviceConfigSpecOperationEdit,
+			Device:    nics[o.Index],
+		})
+	}
+	if len(changes) > 0 {
+		task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{DeviceChange: changes})
+		if err != nil {
+			return fmt.Errorf("failed to set the clone's MAC addresses: %w", err)
+		}
+		if err := task.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to set the clone's MAC addresses: %w", err)
+		}
+	}
+
+	macs := make([]string, len(nics))
+	for i, nic := range nics {
+		macs[i] = nic.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard().MacAddress
+	}
+	cs, err := cloneCustomizationSpec(targetName, c, macs)
+	if err != nil {
+		return err
+	}
+
+	p.logger.Info("Applying guest customization to clone", "target_name", targetName, "nics", len(nics), "cloud_init", c.UserData != "")
+	task, err := vm.Customize(ctx, *cs)
+	if err != nil {
+		return fmt.Errorf("failed to start guest customization: %w", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("guest customization failed: %w", err)
+	}
+	return nil
+}
+
+// cloneCustomizationSpec builds the CustomizationSpec for a clone whose NICs
+// have macs. With user-data it is a cloud-init customization, whose metadata
+// carries the hostname and network-config under a new instance-id; without,
+// it is a Linux one that sets the hostname and maps each NIC, overridden
+// ones to their address and the others to DHCP.
+func cloneCustomizationSpec(targetName string, c *contracts.CloneCustomization, macs []string) (*types.CustomizationSpec, error) {
+	hostname := c.Hostname
+	if hostname == "" {
+		hostname = targetName
+	}
+
+	if c.UserData != "" {
+		networkConfig, err := c.NetworkConfig(macs)
+		if err != nil {
+			return nil, err
+		}
+		fqdn := hostname
+		if c.Domain != "" {
+			fqdn += "." + c.Domain
+		}
+		metaData, err := cloudInitMetaDataWithNetwork(
+			fmt.Sprintf("instance-id: %q\nlocal-hostname: %q\n", targetName, fqdn), networkConfig, targetName)
+		if err != nil {
+			return nil, err
+		}
+		return &types.CustomizationSpec{
+			Identity: &types.CustomizationCloudinitPrep{Metadata: metaData, Userdata: c.UserData},
+		}, nil
+	}
+
+	byIndex := map[int]contracts.CloneNetworkOverride{}
+	for _, o := range c.Networks {
+		byIndex[o.Index] = o
+	}
+	cs := &types.CustomizationSpec{
+		Identity: &types.CustomizationLinuxPrep{
+			HostName: &types.CustomizationFixedName{Name: hostname},
+			Domain:   c.Domain,
+		},
+		GlobalIPSettings: types.CustomizationGlobalIPSettings{},
+	}
+	var dns []string
+	for i := range macs {
+		adapter := types.CustomizationIPSettings{Ip: &types.CustomizationDhcpIpGenerator{}}
+		o := byIndex[i]
+		if o.IPAddress != "" {
+			adapter.Ip = &types.CustomizationFixedIp{IpAddress: o.IPAddress}
+			adapter.SubnetMask = net.IP(net.CIDRMask(o.Prefix, 32)).String()
+			if o.Gateway != "" {
+				adapter.Gateway = []string{o.Gateway}
+			}
+		}
+		for _, server := range o.DNS {
+			if !slices.Contains(dns, server) {
+				dns = append(dns, server)
+			}
+		}
+		cs.NicSettingMap = append(cs.NicSettingMap, types.CustomizationAdapterMapping{Adapter: adapter})
+	}
+	// Linux guests take name servers globally, not per adapter.
+	cs.GlobalIPSettings.DnsServerList = dns
+	if c.Domain != "" {
+		cs.GlobalIPSettings.DnsSuffixList = []string{c.Domain}
+	}
+	return cs, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestCloneCustomizationSpec_Linux(t *testing.T) {
+	c := &contracts.CloneCustomization{
+		Hostname: "web-01",
+		Domain:   "example.com",
+		Networks: []contracts.CloneNetworkOverride{{
+			Index: 1, Name: "lan", IPAddress: "10.0.0.5", Prefix: 24, Gateway: "10.0.0.1", DNS: []string{"10.0.0.2"},
+		}},
+	}
+
+	cs, err := cloneCustomizationSpec("web-clone", c, []string{"00:50:56:00:00:01", "00:50:56:00:00:02"})
+	require.NoError(t, err)
+
+	identity, ok := cs.Identity.(*types.CustomizationLinuxPrep)
+	require.True(t, ok)
+	assert.Equal(t, &types.CustomizationFixedName{Name: "web-01"}, identity.HostName)
+	assert.Equal(t, "example.com", identity.Domain)
+	assert.Equal(t, []string{"10.0.0.2"}, cs.GlobalIPSettings.DnsServerList)
+	require.Len(t, cs.NicSettingMap, 2)
+
+	_, ok = cs.NicSettingMap[0].Adapter.Ip.(*types.CustomizationDhcpIpGenerator)
+	assert.True(t, ok, "NICs without an override use DHCP")
+	second := cs.NicSettingMap[1].Adapter
+	fixed, ok := second.Ip.(*types.CustomizationFixedIp)
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.5", fixed.IpAddress)
+	assert.Equal(t, "255.255.255.0", second.SubnetMask)
+	assert.Equal(t, []string{"10.0.0.1"}, second.Gateway)
+}
+
+func TestCloneCustomizationSpec_CloudInit(t *testing.T) {
+	c := &contracts.CloneCustomization{
+		UserData: "#cloud-config\n",
+		Networks: []contracts.CloneNetworkOverride{{Index: 0, Name: "lan", IPAddress: "10.0.0.5", Prefix: 24}},
+	}
+
+	cs, err := cloneCustomizationSpec("web-clone", c, []string{"00:50:56:00:00:01"})
+	require.NoError(t, err)
+
+	identity, ok := cs.Identity.(*types.CustomizationCloudinitPrep)
+	require.True(t, ok)
+	assert.Equal(t, "#cloud-config\n", identity.Userdata)
+	assert.Contains(t, identity.Metadata, "instance-id: web-clone")
+	assert.Contains(t, identity.Metadata, "local-hostname: web-clone")
+	assert.Contains(t, identity.Metadata, "network.encoding: base64", "the network-config travels in the metadata")
+
+	_, err = cloneCustomizationSpec("web-clone", &contracts.CloneCustomization{
+		UserData: "#cloud-config\n",
+		Networks: []contracts.CloneNetworkOverride{{Index: 3, Name: "storage", DHCP: true}},
+	}, []string{"00:50:56:00:00:01"})
+	assert.ErrorContains(t, err, "NIC 3")
+}
+
+func TestClone_AppliesCustomization(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	source, err := p.finder.VirtualMachine(ctx, "DC0_H0_VM0")
+	require.NoError(t, err)
+	customization, err := json.Marshal(contracts.CloneCustomization{
+		Hostname: "web-01",
+		Networks: []contracts.CloneNetworkOverride{{Index: 0, Name: "lan", MACAddress: "00:50:56:aa:bb:cc"}},
+	})
+	require.NoError(t, err)
+
+	resp, err := p.Clone(ctx, &providerv1.CloneRequest{
+		SourceVmId:        source.Reference().Value,
+		TargetName:        "customized-clone",
+		CustomizationJson: string(customization),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.CustomizationError)
+	assert.True(t, resp.CustomizationApplied)
+
+	clone := object.NewVirtualMachine(p.client.Client, types.ManagedObjectReference{Type: "VirtualMachine", Value: resp.TargetVmId})
+	devices, err := clone.Device(ctx)
+	require.NoError(t, err)
+	nics := devices.SelectByType((*types.VirtualEthernetCard)(nil))
+	require.NotEmpty(t, nics)
+	assert.Equal(t, "00:50:56:aa:bb:cc", nics[0].(types.BaseVirtualEthernetCard).GetVirtualEthernetCard().MacAddress)
+
+	resp, err = p.Clone(ctx, &providerv1.CloneRequest{
+		SourceVmId:        source.Reference().Value,
+		TargetName:        "uncustomized-clone",
+		CustomizationJson: `{"networks":[{"index":5,"name":"storage"}]}`,
+	})
+	require.NoError(t, err, "the clone is kept when it cannot be customized")
+	assert.NotEmpty(t, resp.TargetVmId)
+	assert.False(t, resp.CustomizationApplied)
+	assert.Contains(t, resp.CustomizationError, "NIC 5")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+const (
+	// guestInfoGzipThreshold is the document size above which a cloud-init
+	// guestinfo value is gzipped before it is base64-encoded. Smaller
+	// documents stay plain base64, readable with any tool.
+	guestInfoGzipThreshold = 4 << 10
+
+	// maxGuestInfoValueBytes is the largest guestinfo value ESXi accepts by
+	// default. A document still larger once gzipped cannot reach the guest.
+	maxGuestInfoValueBytes = 64 << 10
+
+	// guestInfoEncodingBase64 and guestInfoEncodingGzipBase64 are the
+	// <key>.encoding values cloud-init's VMware datasource decodes.
+	guestInfoEncodingBase64     = "base64"
+	guestInfoEncodingGzipBase64 = "gzip+base64"
+)
+
+// encodeGuestInfo encodes doc for guestinfo, gzipped above
+// guestInfoGzipThreshold, and returns it with its encoding.
+func encodeGuestInfo(doc string) (string, string) {
+	if len(doc) <= guestInfoGzipThreshold {
+		return base64.StdEncoding.EncodeToString([]byte(doc)), guestInfoEncodingBase64
+	}
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	// Writes to a bytes.Buffer do not fail.
+	_, _ = zw.Write([]byte(doc))
+	_ = zw.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), guestInfoEncodingGzipBase64
+}
+
+// guestInfoOptions returns the extraConfig options carrying doc under the
+// guestinfo key and its encoding under key.encoding. A document too large
+// for guestinfo even gzipped is an InvalidSpec error.
+func guestInfoOptions(key, doc string) ([]types.BaseOptionValue, error) {
+	value, encoding := encodeGuestInfo(doc)
+	if len(value) > maxGuestInfoValueBytes {
+		return nil, errors.NewInvalidSpec(
+			"cloud-init %s is %d bytes, %d once encoded, over the %d bytes vSphere allows a guestinfo value",
+			key, len(doc), len(value), maxGuestInfoValueBytes)
+	}
+	return []types.BaseOptionValue{
+		&types.OptionValue{Key: key, Value: value},
+		&types.OptionValue{Key: key + ".encoding", Value: encoding},
+	}, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/common"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// cloudInitStatusGuestInfo is the guestinfo key a guest publishes the output
+// of `cloud-init status --format json` under, e.g. with
+// `vmware-rpctool "info-set guestinfo.cloudinit.status ..."`. Running
+// programs through VMware Tools needs guest credentials, which the provider
+// does not hold, so the guest reports its status instead.
+const cloudInitStatusGuestInfo = "guestinfo.cloudinit.status"
+
+// GetCloudInitStatus reads the cloud-init status the guest published in
+// guestinfo. Until the guest publishes one it is reported as not started;
+// while VMware Tools is not running it is reported as Unavailable so the
+// caller keeps asking.
+func (p *Provider) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	if p.client == nil {
+		return nil, errors.NewUnavailable("vSphere client not configured", nil)
+	}
+
+	vmRef := types.ManagedObjectReference{Type: "VirtualMachine", Value: req.Id}
+	var vmMo mo.VirtualMachine
+	pc := property.DefaultCollector(p.client.Client)
+	if err := pc.RetrieveOne(ctx, vmRef, []string{"config.extraConfig", "guest.toolsRunningStatus"}, &vmMo); err != nil {
+		return nil, errors.NewNotFound("VM", req.Id)
+	}
+
+	if vmMo.Config != nil {
+		for _, opt := range vmMo.Config.ExtraConfig {
+			ov := opt.GetOptionValue()
+			if ov == nil || ov.Key != cloudInitStatusGuestInfo {
+				continue
+			}
+			value, _ := ov.Value.(string)
+			return common.ParseCloudInitStatus(0, value, "")
+		}
+	}
+
+	if vmMo.Guest == nil || vmMo.Guest.ToolsRunningStatus != string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
+		return nil, errors.NewUnavailable("VMware Tools", fmt.Errorf("VMware Tools are not running in VM %s", req.Id))
+	}
+	return &providerv1.GetCloudInitStatusResponse{
+		Status: "not started",
+		Detail: fmt.Sprintf("the guest has not published %s", cloudInitStatusGuestInfo),
+	}, nil
+}
@@ -17,14 +17,22 @@ limitations under the License.
 package vsphere
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
 	"encoding/base64"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vmware/govmomi/vim25/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
 func TestAddCloudInitToConfigSpec(t *testing.T) {
@@ -365,3 +373,122 @@ func TestVMSpec_CloudInitMetaData(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudInitMetaDataWithNetwork(t *testing.T) {
+	network := "version: 2\nethernets:\n  ens192:\n    addresses: [10.0.0.5/24]"
+	encoded := base64.StdEncoding.EncodeToString([]byte(network))
+
+	unchanged, err := cloudInitMetaDataWithNetwork("instance-id: a", "", "vm")
+	require.NoError(t, err)
+	assert.Equal(t, "instance-id: a", unchanged)
+
+	out, err := cloudInitMetaDataWithNetwork("instance-id: web-01\nlocal-hostname: web", network, "vm")
+	require.NoError(t, err)
+	var doc map[string]string
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	assert.Equal(t, map[string]string{
+		"instance-id":      "web-01",
+		"local-hostname":   "web",
+		"network":          encoded,
+		"network.encoding": "base64",
+	}, doc)
+
+	// Without metadata the instance-id fallback is used; JSON metadata works too.
+	out, err = cloudInitMetaDataWithNetwork("", network, "vm")
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	assert.Equal(t, "vm", doc["instance-id"])
+	out, err = cloudInitMetaDataWithNetwork(`{"instance-id":"j"}`, network, "vm")
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	assert.Equal(t, "j", doc["instance-id"])
+
+	_, err = cloudInitMetaDataWithNetwork("- not\n- a mapping", network, "vm")
+	assert.Error(t, err)
+}
+
+func TestAddCloudInitDocuments_VendorDataOnly(t *testing.T) {
+	provider := &Provider{logger: slog.New(slog.NewTextHandler(os.Stdout, nil))}
+	configSpec := &types.VirtualMachineConfigSpec{Name: "vm"}
+	spec := &VMSpec{CloudInitVendorData: "#cloud-config\nntp: {}"}
+	require.True(t, spec.hasCloudInit())
+
+	require.NoError(t, provider.addCloudInitDocuments(configSpec, spec))
+	extra := map[string]string{}
+	for _, option := range configSpec.ExtraConfig {
+		ov := option.(*types.OptionValue)
+		extra[ov.Key] = ov.Value.(string)
+	}
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(spec.CloudInitVendorData)), extra["guestinfo.vendordata"])
+	assert.Equal(t, "base64", extra["guestinfo.vendordata.encoding"])
+	assert.NotEmpty(t, extra["guestinfo.metadata"], "metadata is needed for the datasource to be found")
+	assert.NotContains(t, extra, "guestinfo.userdata")
+}
+
+// cloudConfigOfSize returns a compressible cloud-config of exactly size
+// bytes, like one with embedded files.
+func cloudConfigOfSize(size int) string {
+	head := "#cloud-config\nwrite_files:\n  - path: /etc/app.conf\n    content: |\n"
+	line := "      setting = value\n"
+	doc := head + strings.Repeat(line, (size-len(head))/len(line)+1)
+	return doc[:size]
+}
+
+// decodeGuestInfo reverses encodeGuestInfo as cloud-init's VMware
+// datasource does.
+func decodeGuestInfo(t *testing.T, value, encoding string) string {
+	t.Helper()
+	raw, err := base64.StdEncoding.DecodeString(value)
+	require.NoError(t, err)
+	if encoding == guestInfoEncodingBase64 {
+		return string(raw)
+	}
+	require.Equal(t, guestInfoEncodingGzipBase64, encoding)
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+	out, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestAddCloudInitToConfigSpec_LargeUserData(t *testing.T) {
+	provider := &Provider{logger: slog.New(slog.NewTextHandler(os.Stdout, nil))}
+
+	for _, tc := range []struct {
+		size     int
+		encoding string
+	}{
+		{size: guestInfoGzipThreshold, encoding: "base64"},
+		{size: guestInfoGzipThreshold + 1, encoding: "gzip+base64"},
+		{size: 256 << 10, encoding: "gzip+base64"},
+		{size: 512 << 10, encoding: "gzip+base64"},
+	} {
+		userData := cloudConfigOfSize(tc.size)
+		configSpec := &types.VirtualMachineConfigSpec{Name: "big"}
+		require.NoError(t, provider.addCloudInitToConfigSpec(configSpec, userData, ""), "%d bytes", tc.size)
+
+		extra := map[string]string{}
+		for _, option := range configSpec.ExtraConfig {
+			ov := option.(*types.OptionValue)
+			extra[ov.Key] = ov.Value.(string)
+		}
+		assert.Equal(t, tc.encoding, extra["guestinfo.userdata.encoding"], "%d bytes", tc.size)
+		assert.LessOrEqual(t, len(extra["guestinfo.userdata"]), maxGuestInfoValueBytes, "%d bytes", tc.size)
+		assert.Equal(t, userData, decodeGuestInfo(t, extra["guestinfo.userdata"], extra["guestinfo.userdata.encoding"]), "%d bytes", tc.size)
+	}
+}
+
+func TestAddCloudInitToConfigSpec_IncompressibleUserDataTooLarge(t *testing.T) {
+	provider := &Provider{logger: slog.New(slog.NewTextHandler(os.Stdout, nil))}
+
+	// Random bytes do not compress; base64 of them is ~4/3 their size.
+	random := make([]byte, 64<<10)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+	userData := "#cloud-config\n# " + base64.StdEncoding.EncodeToString(random)
+
+	err = provider.addCloudInitToConfigSpec(&types.VirtualMachineConfigSpec{Name: "big"}, userData, "")
+	require.Error(t, err)
+	assert.True(t, errors.IsInvalidSpec(err))
+	assert.Contains(t, err.Error(), "guestinfo.userdata")
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// isConcurrentChange reports whether err is vCenter refusing an operation
+// because another one is under way on the VM: a task still running on it
+// (TaskInProgress), or a state another change left it in for the moment
+// (InvalidState). Subtypes such as InvalidPowerState are not matched; they
+// describe a request that does not fit the VM, not a race.
+func isConcurrentChange(err error) bool {
+	return fault.Is(err, &types.TaskInProgress{}) || fault.Is(err, &types.InvalidState{})
+}
+
+// vmTaskFailure returns the error for a VM operation that failed to start or
+// complete: a Conflict when it raced another change to the VM, which the
+// manager retries shortly, and the wrapped error otherwise.
+func vmTaskFailure(message string, err error) error {
+	if isConcurrentChange(err) {
+		return errors.NewConflict("%s: another change to the VM is in progress: %v", message, err)
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/task"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	perrors "github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+func TestVMTaskFailure(t *testing.T) {
+	taskErr := func(f types.BaseMethodFault) error {
+		return task.Error{LocalizedMethodFault: &types.LocalizedMethodFault{Fault: f, LocalizedMessage: "fault"}}
+	}
+	cases := []struct {
+		name     string
+		err      error
+		conflict bool
+	}{
+		{"task in progress on start", soap.WrapVimFault(&types.TaskInProgress{}), true},
+		{"task in progress from task", taskErr(&types.TaskInProgress{}), true},
+		{"invalid state from task", fmt.Errorf("wait: %w", taskErr(&types.InvalidState{})), true},
+		{"invalid power state", taskErr(&types.InvalidPowerState{}), false},
+		{"not found", soap.WrapVimFault(&types.ManagedObjectNotFound{}), false},
+		{"plain", errors.New("connection reset"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := vmTaskFailure("reconfiguration failed", tc.err)
+			assert.Equal(t, tc.conflict, perrors.IsConflict(err))
+			assert.ErrorContains(t, err, "reconfiguration failed")
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// TestWebMKSTicket verifies the URL is built from the ticket's parts when
+// vSphere gives none, and that the SHA-256 thumbprint is pinned in
+// preference to the SHA-1 one.
+func TestWebMKSTicket(t *testing.T) {
+	resp, err := webMKSTicket(&types.VirtualMachineTicket{
+		Ticket:        "52a8-ticket",
+		Port:          902,
+		SslThumbprint: "AA:BB",
+	}, "vcenter.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "webmks", resp.Protocol)
+	assert.Equal(t, "wss://vcenter.example.com:902/ticket/52a8-ticket", resp.Url)
+	assert.Equal(t, "AA:BB", resp.CertificateFingerprint)
+
+	resp, err = webMKSTicket(&types.VirtualMachineTicket{
+		Ticket:        "52a8-ticket",
+		Host:          "esxi-1.example.com",
+		Url:           "wss://esxi-1.example.com:443/ticket/52a8-ticket",
+		SslThumbprint: "AA:BB",
+		CertThumbprintList: []types.VirtualMachineCertThumbprint{
+			{Thumbprint: "CC:DD", HashAlgorithm: string(types.VirtualMachineCertThumbprintHashAlgorithmSha256)},
+		},
+	}, "vcenter.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "wss://esxi-1.example.com:443/ticket/52a8-ticket", resp.Url)
+	assert.Equal(t, "CC:DD", resp.CertificateFingerprint)
+
+	_, err = webMKSTicket(&types.VirtualMachineTicket{Ticket: "t", SslCertificate: "not pem"}, "vcenter.example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+const (
+	// librarySubscribed is the type of a library that mirrors a published one.
+	librarySubscribed = "SUBSCRIBED"
+
+	// libraryNamesListed caps how many library or item names a NotFound
+	// error lists.
+	libraryNamesListed = 20
+)
+
+// librarySyncPollInterval is how often a library item being synced is
+// checked for its content to arrive. Tests shorten it.
+var librarySyncPollInterval = 5 * time.Second
+
+// libraryItemCache remembers which item a library and item name resolved
+// to, so a Create does not list the library again. An entry is checked
+// against the item on every use: it is dropped when the item is gone and
+// replaced when the item's content version changed. The zero value is ready
+// to use.
+type libraryItemCache struct {
+	mu    sync.Mutex
+	items map[string]library.Item
+}
+
+func libraryItemKey(ref *contracts.ContentLibraryItem) string {
+	return ref.Library + "/" + ref.Item
+}
+
+func (c *libraryItemCache) get(key string) (library.Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[key]
+	return item, ok
+}
+
+func (c *libraryItemCache) put(key string, item library.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.items = make(map[string]library.Item)
+	}
+	c.items[key] = item
+}
+
+func (c *libraryItemCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+// libraryNotFound is a NotFound error for name that lists the names that
+// do exist, so a typo is obvious from the VM's status.
+func libraryNotFound(resource, name string, available []string) error {
+	err := errors.NewNotFound(resource, name)
+	sort.Strings(available)
+	switch {
+	case len(available) == 0:
+		err.Message += "; there are none"
+	case len(available) > libraryNamesListed:
+		err.Message += fmt.Sprintf("; available: %s and %d more",
+			strings.Join(available[:libraryNamesListed], ", "), len(available)-libraryNamesListed)
+	default:
+		err.Message += "; available: " + strings.Join(available, ", ")
+	}
+	return err
+}
+
+// findLibrary returns the content library named name.
+func findLibrary(ctx context.Context, mgr *library.Manager, name string) (*library.Library, error) {
+	libs, err := mgr.GetLibraries(ctx)
+	if err != nil {
+		return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+	}
+	names := make([]string, 0, len(libs))
+	for i := range libs {
+		if libs[i].Name == name {
+			return &libs[i], nil
+		}
+		names = append(names, libs[i].Name)
+	}
+	return nil, libraryNotFound("vSphere content library", name, names)
+}
+
+// findLibraryItem returns the item named name in lib.
+func findLibraryItem(ctx context.Context, mgr *library.Manager, lib *library.Library, name string) (*library.Item, error) {
+	items, err := mgr.GetLibraryItems(ctx, lib.ID)
+	if err != nil {
+		return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+	}
+	names := make([]string, 0, len(items))
+	for i := range items {
+		if items[i].Name == name {
+			return &items[i], nil
+		}
+		names = append(names, items[i].Name)
+	}
+	return nil, libraryNotFound("vSphere content library item", lib.Name+"/"+name, names)
+}
+
+// checkLibraryItemVersion reports a pinned version the item is not at as
+// NotFound: the version asked for does not exist.
+func checkLibraryItemVersion(ref *contracts.ContentLibraryItem, item *library.Item) error {
+	if ref.Version == "" || ref.Version == item.ContentVersion {
+		return nil
+	}
+	err := errors.NewNotFound("vSphere content library item version",
+		fmt.Sprintf("%s/%s@%s", ref.Library, ref.Item, ref.Version))
+	err.Message += fmt.Sprintf("; the item is at version %s", item.ContentVersion)
+	return err
+}
+
+// resolveLibraryItem returns the item ref names. A cached item is read back
+// by ID, which is cheaper than listing the library; if it is gone the names
+// are resolved again, and if its content version moved on the cache takes
+// the new version.
+func (p *Provider) resolveLibraryItem(ctx context.Context, mgr *library.Manager, ref *contracts.ContentLibraryItem) (*library.Item, error) {
+	if ref.Library == "" || ref.Item == "" {
+		return nil, errors.NewInvalidSpec("contentLibrary requires both library and item")
+	}
+	key := libraryItemKey(ref)
+
+	if cached, ok := p.libraryItems.get(key); ok {
+		item, err := mgr.GetLibraryItem(ctx, cached.ID)
+		switch {
+		case err == nil:
+			if item.ContentVersion != cached.ContentVersion {
+				p.logger.Info("Content library item changed version",
+					"library", ref.Library, "item", ref.Item,
+					"old_version", cached.ContentVersion, "new_version", item.ContentVersion)
+				p.libraryItems.put(key, *item)
+			}
+			return item, checkLibraryItemVersion(ref, item)
+		case rest.IsStatusError(err, http.StatusNotFound):
+			p.logger.Info("Cached content library item is gone; resolving again",
+				"library", ref.Library, "item", ref.Item, "item_id", cached.ID)
+			p.libraryItems.forget(key)
+		default:
+			return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+		}
+	}
+
+	lib, err := findLibrary(ctx, mgr, ref.Library)
+	if err != nil {
+		return nil, err
+	}
+	item, err := findLibraryItem(ctx, mgr, lib, ref.Item)
+	if err != nil {
+		return nil, err
+	}
+	p.libraryItems.put(key, *item)
+	p.logger.Info("Resolved content library item",
+		"library", ref.Library, "item", ref.Item, "item_id", item.ID,
+		"type", item.Type, "version", item.ContentVersion)
+	return item, checkLibraryItemVersion(ref, item)
+}
+
+// syncLibraryItem fetches the content of an item of a subscribed library
+// that is not cached on vCenter yet, such as one in an on-demand
+// subscription, and waits for it to arrive. Items of other libraries are
+// always local and are returned as they are.
+func (p *Provider) syncLibraryItem(ctx context.Context, mgr *library.Manager, item *library.Item) (*library.Item, error) {
+	lib, err := mgr.GetLibraryByID(ctx, item.LibraryID)
+	if err != nil {
+		return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+	}
+	if lib.Type != librarySubscribed || item.Cached {
+		return item, nil
+	}
+
+	// Without force an on-demand subscription syncs only the item's metadata.
+	p.logger.Info("Syncing content library item", "library", lib.Name, "item", item.Name, "item_id", item.ID)
+	if err := mgr.SyncLibraryItem(ctx, item, true); err != nil {
+		return nil, fmt.Errorf("sync content library item %s/%s: %w", lib.Name, item.Name, err)
+	}
+	for {
+		synced, err := mgr.GetLibraryItem(ctx, item.ID)
+		if err != nil {
+			return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+		}
+		if synced.Cached {
+			p.logger.Info("Content library item synced",
+				"library", lib.Name, "item", item.Name, "version", synced.ContentVersion)
+			return synced, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("sync content library item %s/%s: %w", lib.Name, item.Name, ctx.Err())
+		case <-time.After(librarySyncPollInterval):
+		}
+	}
+}
+
+// deployLibraryItem deploys the content library item spec.ContentLibrary
+// names as a powered-off VM called spec.Name. OVF items are deployed with
+// the OVF deployment API and VM template items with the template API;
+// either way vCenter fetches a subscribed item's content as needed.
+func (p *Provider) deployLibraryItem(ctx context.Context, spec *VMSpec, pool *object.ResourcePool,
+	datastore *object.Datastore, folder *object.Folder) (types.ManagedObjectReference, error) {
+	rc, logout, err := p.contentLibrarySession(ctx)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	defer logout()
+
+	ref := spec.ContentLibrary
+	item, err := p.resolveLibraryItem(ctx, library.NewManager(rc), ref)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+
+	p.logger.Info("Deploying virtual machine from content library",
+		"library", ref.Library, "item", ref.Item, "item_id", item.ID, "type", item.Type, "target", spec.Name)
+	var moref *types.ManagedObjectReference
+	switch item.Type {
+	case library.ItemTypeOVF:
+		moref, err = vcenter.NewManager(rc).DeployLibraryItem(ctx, item.ID, vcenter.Deploy{
+			DeploymentSpec: vcenter.DeploymentSpec{
+				Name:                spec.Name,
+				AcceptAllEULA:       true,
+				DefaultDatastoreID:  datastore.Reference().Value,
+				StorageProvisioning: ovaDiskProvisioningThin,
+			},
+			Target: vcenter.Target{
+				ResourcePoolID: pool.Reference().Value,
+				FolderID:       folder.Reference().Value,
+			},
+		})
+	case library.ItemTypeVMTX:
+		storage := &vcenter.DiskStorage{Datastore: datastore.Reference().Value}
+		moref, err = vcenter.NewManager(rc).DeployTemplateLibraryItem(ctx, item.ID, vcenter.DeployTemplate{
+			Name: spec.Name,
+			Placement: &vcenter.Placement{
+				ResourcePool: pool.Reference().Value,
+				Folder:       folder.Reference().Value,
+			},
+			DiskStorage:   storage,
+			VMHomeStorage: storage,
+		})
+	default:
+		return types.ManagedObjectReference{}, errors.NewInvalidSpec(
+			"content library item %s/%s is of type %q; only OVF and VM template items can be deployed",
+			ref.Library, ref.Item, item.Type)
+	}
+	if err != nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("failed to deploy content library item %s/%s: %w",
+			ref.Library, ref.Item, err)
+	}
+	return *moref, nil
+}
+
+// configureDeployedVM applies configSpec, which holds the class, network and
+// cloud-init settings, to a VM deployed from a content library item, then
+// runs sysprep customization when the VM asks for it.
+func (p *Provider) configureDeployedVM(ctx context.Context, vm *object.VirtualMachine,
+	configSpec *types.VirtualMachineConfigSpec, spec *VMSpec) error {
+	task, err := vm.Reconfigure(ctx, *configSpec)
+	if err != nil {
+		return fmt.Errorf("failed to start reconfiguration of deployed VM: %w", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("reconfiguration of deployed VM failed: %w", err)
+	}
+	if spec.SysprepUnattend != "" {
+		return p.customizeImportedVM(ctx, vm, spec)
+	}
+	return nil
+}
+
+// destroyDeployedVM removes a deployed VM that could not be configured, so
+// the retried Create does not find a half-configured VM under its name.
+func (p *Provider) destroyDeployedVM(ctx context.Context, vm *object.VirtualMachine, name string) {
+	task, err := vm.Destroy(ctx)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		p.logger.Warn("Failed to remove unconfigured VM deployed from content library",
+			"name", name, "vm", vm.Reference().Value, "error", err)
+	}
+}
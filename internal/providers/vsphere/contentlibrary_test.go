@@ -0,0 +1,296 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// newTestLibrary creates a local content library named "golden" holding a VM
+// template item "vm0" captured from a sim VM, and returns a REST session on
+// the sim plus the item ID.
+func newTestLibrary(t *testing.T, p *Provider) (*rest.Client, string) {
+	t.Helper()
+	ctx := context.Background()
+	rc, logout, err := p.contentLibrarySession(ctx)
+	require.NoError(t, err)
+	t.Cleanup(logout)
+
+	ds, err := p.finder.Datastore(ctx, p.config.DefaultDatastore)
+	require.NoError(t, err)
+	libID, err := library.NewManager(rc).CreateLibrary(ctx, library.Library{
+		Name:    "golden",
+		Type:    "LOCAL",
+		Storage: []library.StorageBacking{{DatastoreID: ds.Reference().Value, Type: "DATASTORE"}},
+	})
+	require.NoError(t, err)
+
+	return rc, createTestTemplateItem(t, p, rc, libID)
+}
+
+func createTestTemplateItem(t *testing.T, p *Provider, rc *rest.Client, libID string) string {
+	t.Helper()
+	ctx := context.Background()
+	vm := poweredOffSimVM(t, p)
+	pool, err := p.finder.ResourcePool(ctx, "DC0_C0/Resources")
+	require.NoError(t, err)
+	folder, err := p.finder.Folder(ctx, "DC0/vm")
+	require.NoError(t, err)
+	itemID, err := vcenter.NewManager(rc).CreateTemplate(ctx, vcenter.Template{
+		Name:     "vm0",
+		Library:  libID,
+		SourceVM: vm.Reference().Value,
+		Placement: &vcenter.Placement{
+			ResourcePool: pool.Reference().Value,
+			Folder:       folder.Reference().Value,
+		},
+	})
+	require.NoError(t, err)
+	return itemID
+}
+
+// createTestOVFItem adds an OVF item called name to the library, pulled by
+// vCenter from a test HTTP server.
+func createTestOVFItem(t *testing.T, rc *rest.Client, libID, name string) string {
+	t.Helper()
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(minimalDisklessOVF))
+	}))
+	t.Cleanup(srv.Close)
+
+	mgr := library.NewManager(rc)
+	itemID, err := mgr.CreateLibraryItem(ctx, library.Item{Name: name, Type: library.ItemTypeOVF, LibraryID: libID})
+	require.NoError(t, err)
+	session, err := mgr.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	require.NoError(t, err)
+	_, err = mgr.AddLibraryItemFileFromURI(ctx, session, name+".ovf", srv.URL+"/"+name+".ovf")
+	require.NoError(t, err)
+	require.NoError(t, mgr.CompleteLibraryItemUpdateSession(ctx, session))
+	return itemID
+}
+
+func TestCreate_ContentLibraryItem(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	rc, _ := newTestLibrary(t, p)
+	libs, err := library.NewManager(rc).FindLibrary(ctx, library.Find{Name: "golden"})
+	require.NoError(t, err)
+	createTestOVFItem(t, rc, libs[0], "appliance")
+
+	resp, err := p.Create(ctx, &providerv1.CreateRequest{
+		Name:      "from-library",
+		ClassJson: `{"CPU":2,"MemoryMiB":1024}`,
+		ImageJson: `{"contentLibrary":{"library":"golden","item":"appliance"}}`,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Id)
+
+	vm, err := p.finder.VirtualMachine(ctx, "from-library")
+	require.NoError(t, err)
+	assert.Equal(t, resp.Id, vm.Reference().Value)
+	var mvm mo.VirtualMachine
+	require.NoError(t, vm.Properties(ctx, vm.Reference(), []string{"config.hardware"}, &mvm))
+	assert.Equal(t, int32(2), mvm.Config.Hardware.NumCPU, "the class is applied to the deployed VM")
+	assert.Equal(t, int32(1024), mvm.Config.Hardware.MemoryMB)
+}
+
+func TestDeployLibraryItem_VMTemplate(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	newTestLibrary(t, p)
+
+	pool, err := p.finder.ResourcePool(ctx, "DC0_C0/Resources")
+	require.NoError(t, err)
+	ds, err := p.finder.Datastore(ctx, p.config.DefaultDatastore)
+	require.NoError(t, err)
+	folder, err := p.finder.Folder(ctx, "DC0/vm")
+	require.NoError(t, err)
+
+	ref, err := p.deployLibraryItem(ctx, &VMSpec{
+		Name:           "from-vmtx",
+		ContentLibrary: &contracts.ContentLibraryItem{Library: "golden", Item: "vm0"},
+	}, pool, ds, folder)
+	require.NoError(t, err)
+
+	vm, err := p.finder.VirtualMachine(ctx, "from-vmtx")
+	require.NoError(t, err)
+	assert.Equal(t, ref, vm.Reference())
+}
+
+func TestResolveLibraryItem_NotFound(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	rc, _ := newTestLibrary(t, p)
+	mgr := library.NewManager(rc)
+
+	_, err := p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{Library: "golden", Item: "vm9"})
+	require.True(t, errors.IsNotFound(err), "got %v", err)
+	assert.Contains(t, err.Error(), `"golden/vm9" not found; available: vm0`)
+
+	_, err = p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{Library: "silver", Item: "vm0"})
+	require.True(t, errors.IsNotFound(err), "got %v", err)
+	assert.Contains(t, err.Error(), "available: golden")
+
+	_, err = p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{Library: "golden"})
+	assert.True(t, errors.IsInvalidSpec(err), "got %v", err)
+}
+
+func TestResolveLibraryItem_VersionPin(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	rc, _ := newTestLibrary(t, p)
+	mgr := library.NewManager(rc)
+
+	item, err := p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{Library: "golden", Item: "vm0"})
+	require.NoError(t, err)
+
+	_, err = p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{
+		Library: "golden", Item: "vm0", Version: item.ContentVersion,
+	})
+	assert.NoError(t, err)
+
+	_, err = p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{
+		Library: "golden", Item: "vm0", Version: item.ContentVersion + "0",
+	})
+	require.True(t, errors.IsNotFound(err), "got %v", err)
+	assert.Contains(t, err.Error(), "the item is at version "+item.ContentVersion)
+}
+
+func TestResolveLibraryItem_CacheInvalidation(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	rc, itemID := newTestLibrary(t, p)
+	mgr := library.NewManager(rc)
+	ref := &contracts.ContentLibraryItem{Library: "golden", Item: "vm0"}
+
+	item, err := p.resolveLibraryItem(ctx, mgr, ref)
+	require.NoError(t, err)
+	assert.Equal(t, itemID, item.ID)
+	cached, ok := p.libraryItems.get(libraryItemKey(ref))
+	require.True(t, ok)
+	assert.Equal(t, itemID, cached.ID)
+
+	// Replace the item under the same name: the cached ID is gone.
+	require.NoError(t, mgr.DeleteLibraryItem(ctx, item))
+	replacement := createTestOVFItem(t, rc, item.LibraryID, "vm0")
+
+	item, err = p.resolveLibraryItem(ctx, mgr, ref)
+	require.NoError(t, err)
+	assert.Equal(t, replacement, item.ID, "a vanished cached item is resolved by name again")
+	cached, _ = p.libraryItems.get(libraryItemKey(ref))
+	assert.Equal(t, replacement, cached.ID)
+}
+
+func TestImagePrepare_ContentLibrary(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	_, itemID := newTestLibrary(t, p)
+
+	resp, err := p.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
+		TargetName: "DC0_H0_VM0", // taken, but a library item is not imported
+		ImageJson:  `{"source":{"vsphere":{"contentLibrary":{"library":"golden","item":"vm0"}}}}`,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Task)
+	assert.Equal(t, itemID, resp.PreparedImageId)
+
+	_, err = p.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
+		TargetName: "prepared",
+		ImageJson:  `{"source":{"vsphere":{"contentLibrary":{"library":"golden","item":"missing"}}}}`,
+	})
+	assert.True(t, errors.IsNotFound(err), "got %v", err)
+}
+
+func TestImagePrepare_ContentLibrarySyncsSubscribedItem(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	rc, logout, err := p.contentLibrarySession(ctx)
+	require.NoError(t, err)
+	defer logout()
+	mgr := library.NewManager(rc)
+
+	ds, err := p.finder.Datastore(ctx, p.config.DefaultDatastore)
+	require.NoError(t, err)
+	storage := []library.StorageBacking{{DatastoreID: ds.Reference().Value, Type: "DATASTORE"}}
+	published := true
+	pubID, err := mgr.CreateLibrary(ctx, library.Library{
+		Name: "published", Type: "LOCAL", Storage: storage,
+		Publication: &library.Publication{Published: &published},
+	})
+	require.NoError(t, err)
+	createTestOVFItem(t, rc, pubID, "appliance")
+	pub, err := mgr.GetLibraryByID(ctx, pubID)
+	require.NoError(t, err)
+
+	onDemand := true
+	_, err = mgr.CreateLibrary(ctx, library.Library{
+		Name: "mirror", Type: librarySubscribed, Storage: storage,
+		Subscription: &library.Subscription{SubscriptionURL: pub.Publication.PublishURL, OnDemand: &onDemand},
+	})
+	require.NoError(t, err)
+
+	ref := &contracts.ContentLibraryItem{Library: "mirror", Item: "appliance"}
+	item, err := p.resolveLibraryItem(ctx, mgr, ref)
+	require.NoError(t, err)
+	require.False(t, item.Cached, "an on-demand subscription does not download items up front")
+
+	resp, err := p.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
+		TargetName: "prepared",
+		ImageJson:  `{"source":{"vsphere":{"contentLibrary":{"library":"mirror","item":"appliance"}}}}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, item.ID, resp.PreparedImageId)
+
+	item, err = mgr.GetLibraryItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, item.Cached, "ImagePrepare syncs the item")
+}
+
+func TestLibraryNotFound_CapsNames(t *testing.T) {
+	names := make([]string, libraryNamesListed+3)
+	for i := range names {
+		names[i] = string(rune('a'+i%26)) + "-item"
+	}
+	err := libraryNotFound("vSphere content library item", "lib/x", names)
+	assert.Contains(t, err.Error(), "and 3 more")
+
+	err = libraryNotFound("vSphere content library", "x", nil)
+	assert.Contains(t, err.Error(), "there are none")
+}
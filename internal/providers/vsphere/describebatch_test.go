@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+)
+
+func newSimProvider(t *testing.T) (*Provider, []string) {
+	t.Helper()
+	cfg, cleanup := newSimConfig(t)
+	t.Cleanup(cleanup)
+
+	client, finder, err := createVSphereClient(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Logout(context.Background()) })
+
+	ctx := context.Background()
+	dc, err := finder.DefaultDatacenter(ctx)
+	require.NoError(t, err)
+	finder.SetDatacenter(dc)
+	vms, err := finder.VirtualMachineList(ctx, "*")
+	require.NoError(t, err)
+	require.NotEmpty(t, vms)
+	ids := make([]string, 0, len(vms))
+	for _, vm := range vms {
+		ids = append(ids, vm.Reference().Value)
+	}
+	return &Provider{client: client, finder: finder, config: cfg, logger: slog.Default()}, ids
+}
+
+// TestDescribeBatch_MatchesDescribe checks the single-retrieval answer is
+// the one Describe gives for each VM.
+func TestDescribeBatch_MatchesDescribe(t *testing.T) {
+	p, ids := newSimProvider(t)
+	ctx := context.Background()
+
+	resp, err := p.DescribeBatch(ctx, &providerv1.DescribeBatchRequest{Ids: ids})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, len(ids))
+	assert.Empty(t, resp.Errors)
+
+	for _, id := range ids {
+		want, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+		require.NoError(t, err)
+		got := resp.Results[id]
+		require.NotNil(t, got, id)
+		assert.True(t, got.Exists, id)
+		assert.Equal(t, want.PowerState, got.PowerState, id)
+		assert.Equal(t, want.Ips, got.Ips, id)
+		assert.JSONEq(t, want.ProviderRawJson, got.ProviderRawJson, id)
+
+		details, err := conformance.CheckDescribeDetails(ctx, p, id)
+		require.NoError(t, err)
+		assert.NotEmpty(t, details.Host, "%s reports the ESXi host it runs on", id)
+		require.NotNil(t, details.Resources, id)
+		assert.Positive(t, details.Resources.CPUCount, id)
+	}
+}
+
+// TestDescribeBatch_MissingVM reports a VM that no longer exists as not
+// existing, without losing the others.
+func TestDescribeBatch_MissingVM(t *testing.T) {
+	p, ids := newSimProvider(t)
+
+	resp, err := p.DescribeBatch(context.Background(), &providerv1.DescribeBatchRequest{Ids: append(ids, "vm-gone")})
+	require.NoError(t, err)
+	require.Contains(t, resp.Results, "vm-gone")
+	assert.False(t, resp.Results["vm-gone"].Exists)
+	for _, id := range ids {
+		require.Contains(t, resp.Results, id)
+		assert.True(t, resp.Results[id].Exists, id)
+	}
+}
+
+func TestTaskBusyReasons(t *testing.T) {
+	task := func(vm, descriptionID string, state types.TaskInfoState) mo.Task {
+		return mo.Task{Info: types.TaskInfo{
+			DescriptionId: descriptionID,
+			State:         state,
+			Entity:        &types.ManagedObjectReference{Type: "VirtualMachine", Value: vm},
+		}}
+	}
+	got := taskBusyReasons([]mo.Task{
+		task("vm-1", "VirtualMachine.migrate", types.TaskInfoStateRunning),
+		task("vm-2", "VirtualMachine.removeSnapshot", types.TaskInfoStateQueued),
+		task("vm-2", "VirtualMachine.createSnapshot", types.TaskInfoStateRunning),
+		task("vm-3", "VirtualMachine.migrate", types.TaskInfoStateSuccess),
+		task("vm-4", "VirtualMachine.reconfigure", types.TaskInfoStateRunning),
+	})
+	assert.Equal(t, map[string]string{
+		"vm-1": "task running: vMotion",
+		"vm-2": "task running: snapshot",
+	}, got)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"github.com/vmware/govmomi/vim25/types"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// guestStats normalizes the quick stats vCenter keeps for a VM. CPU usage is
+// reported in MHz, so it is scaled against the VM's CPU ceiling (maxCpuUsage,
+// also MHz); guest memory usage is the guest's active memory in MB. Quick
+// stats are stale for a VM that is not running, so nothing is reported then.
+func guestStats(power types.VirtualMachinePowerState, maxCPUMHz int32, qs types.VirtualMachineQuickStats) *providerv1.GuestStats {
+	if power != types.VirtualMachinePowerStatePoweredOn {
+		return nil
+	}
+	stats := &providerv1.GuestStats{}
+	if maxCPUMHz > 0 {
+		pct := float64(qs.OverallCpuUsage) / float64(maxCPUMHz) * 100
+		if pct > 100 {
+			pct = 100
+		}
+		stats.CpuUsagePercent = &pct
+	}
+	if qs.GuestMemoryUsage > 0 {
+		mem := int64(qs.GuestMemoryUsage) * 1024 * 1024
+		stats.MemoryUsageBytes = &mem
+	}
+	if qs.UptimeSeconds > 0 {
+		uptime := int64(qs.UptimeSeconds)
+		stats.UptimeSeconds = &uptime
+	}
+	if stats.CpuUsagePercent == nil && stats.MemoryUsageBytes == nil && stats.UptimeSeconds == nil {
+		return nil
+	}
+	return stats
+}
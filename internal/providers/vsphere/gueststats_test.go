@@ -0,0 +1,48 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestGuestStats(t *testing.T) {
+	qs := types.VirtualMachineQuickStats{OverallCpuUsage: 1200, GuestMemoryUsage: 512}
+
+	stats := guestStats(types.VirtualMachinePowerStatePoweredOn, 4800, qs)
+	require.NotNil(t, stats)
+	assert.InDelta(t, 25.0, stats.GetCpuUsagePercent(), 1e-9)
+	assert.Equal(t, int64(512<<20), stats.GetMemoryUsageBytes())
+	assert.Nil(t, stats.UptimeSeconds, "zero uptime is not reported")
+
+	qs.UptimeSeconds = 3600
+	stats = guestStats(types.VirtualMachinePowerStatePoweredOn, 4800, qs)
+	require.NotNil(t, stats)
+	assert.Equal(t, int64(3600), stats.GetUptimeSeconds())
+	qs.UptimeSeconds = 0
+
+	// Without a CPU ceiling only memory can be reported.
+	stats = guestStats(types.VirtualMachinePowerStatePoweredOn, 0, qs)
+	require.NotNil(t, stats)
+	assert.Nil(t, stats.CpuUsagePercent)
+
+	assert.Nil(t, guestStats(types.VirtualMachinePowerStatePoweredOff, 4800, qs))
+}
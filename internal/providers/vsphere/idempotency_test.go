@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/conformance"
+)
+
+func TestCreate_IdempotencyKey(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	req := &providerv1.CreateRequest{
+		Name:           "keyed-vm",
+		ClassJson:      `{"CPU":1,"MemoryMiB":512}`,
+		ImageJson:      `{"TemplateName":"DC0_H0_VM0"}`,
+		IdempotencyKey: "5b0e-uid",
+	}
+	id, err := conformance.CheckIdempotentCreate(ctx, p, req)
+	require.NoError(t, err)
+
+	vm, err := p.finder.VirtualMachine(ctx, req.Name)
+	require.NoError(t, err)
+	assert.Equal(t, id, vm.Reference().Value)
+
+	// vcsim drops the extraConfig of a clone spec, so record the key the
+	// way vCenter would have before asking with a different one.
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{ExtraConfig: []types.BaseOptionValue{
+		&types.OptionValue{Key: idempotencyKeyExtraConfig, Value: req.IdempotencyKey},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, task.Wait(ctx))
+
+	// Describe reports the recorded key as the VM's identity.
+	desc, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+	require.NoError(t, err)
+	assert.Equal(t, req.IdempotencyKey, desc.Identity)
+
+	resp, err := p.Create(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, id, resp.Id)
+
+	// Another VirtualMachine asking for the same name must not be handed
+	// this one.
+	_, err = p.Create(ctx, &providerv1.CreateRequest{
+		Name:           req.Name,
+		ImageJson:      req.ImageJson,
+		IdempotencyKey: "other-uid",
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+}
@@ -29,7 +29,6 @@ import (
 	"hash"
 	"io"
 	"net/http"
-	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -39,10 +38,10 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/ovf/importer"
 	"github.com/vmware/govmomi/vapi/library"
-	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vim25/progress"
 	"github.com/vmware/govmomi/vim25/types"
 
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
@@ -69,7 +68,8 @@ type vsphereImageSource struct {
 	// When set, ImagePrepare verifies its existence and does NOT import.
 	TemplateName string
 	// ContentLibrary references a content-library item. When set, ImagePrepare
-	// verifies the item exists; deploying it to a template is out of scope here.
+	// resolves the item and syncs a subscribed item's content; VMs are then
+	// deployed from the item itself, so nothing is imported.
 	ContentLibrary *vsphereContentLibraryRef
 	// OVAURL is an HTTP(S) URL to an .ova or .ovf to import as a template. This
 	// is the only source that performs a real import.
@@ -89,7 +89,7 @@ type vsphereContentLibraryRef struct {
 	Library string
 	// Item is the library-item name within Library.
 	Item string
-	// Version is the optional item version.
+	// Version is the optional item content version.
 	Version string
 }
 
@@ -161,12 +161,10 @@ func parseVSphereImageSource(imageJSON string) vsphereImageSource {
 		}
 	}
 
-	// Shape 2: flat contracts.VMImage (Go field names, no JSON tags). For vSphere
-	// only TemplateName is meaningful here.
-	var img struct {
-		TemplateName string
-	}
-	if err := json.Unmarshal([]byte(imageJSON), &img); err == nil && img.TemplateName != "" {
+	// Shape 2: flat contracts.VMImage. For vSphere only TemplateName is
+	// meaningful here.
+	var img contracts.VMImage
+	if err := contracts.UnmarshalPayload([]byte(imageJSON), &img); err == nil && img.TemplateName != "" {
 		src.TemplateName = img.TemplateName
 	}
 
@@ -197,11 +195,13 @@ func vsphereChecksumHasher(checksumType string) (h hash.Hash, ok bool) {
 // honoring three source kinds in precedence order:
 //
 //  1. Idempotency gate: if a template/VM named TargetName already exists, it
-//     returns success without importing — a re-run is a cheap no-op.
+//     returns success without importing — a re-run is a cheap no-op. A source
+//     with only a contentLibrary item skips the gate: nothing is created.
 //  2. source.vsphere.templateName: verify-only. The named template must already
 //     exist; if found, success; if missing, a NotFound error. No download.
-//  3. source.vsphere.contentLibrary: verify-only. The library item must exist;
-//     deploying it to a template is out of scope for this PR.
+//  3. source.vsphere.contentLibrary: the item must exist (a NotFound lists the
+//     names that do) and a subscribed library's item is synced so the first
+//     deploy does not wait on the download. The prepared image is the item ID.
 //  4. source.vsphere.ovaURL: the real work. Download the OVA/OVF, optionally
 //     verify its checksum, import it into vCenter via an NFC lease, and mark the
 //     resulting VM as a template.
@@ -242,7 +242,11 @@ func (p *Provider) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepar
 
 	// 1) Idempotency gate (load-bearing): a re-run must never re-import. If a
 	//    template/VM named targetName already exists, we are done — the prepared
-	//    image is that template, addressed by name.
+	//    image is that template, addressed by name. A content library item is
+	//    deployed as it is, so a VM holding the target name is irrelevant.
+	if src.TemplateName == "" && src.ContentLibrary != nil {
+		return p.imagePrepareSyncContentLibrary(ctx, src.ContentLibrary)
+	}
 	if vm := p.findExistingByName(ctx, targetName); vm != nil {
 		p.logger.Info("ImagePrepare: target already exists; nothing to do",
 			"target_name", targetName, "vm", vm.Reference().Value)
@@ -253,9 +257,6 @@ func (p *Provider) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepar
 	case src.TemplateName != "":
 		// 2) templateName: verify-only. Don't fabricate an import.
 		return p.imagePrepareVerifyTemplate(ctx, src.TemplateName)
-	case src.ContentLibrary != nil:
-		// 3) contentLibrary: verify-only.
-		return p.imagePrepareVerifyContentLibrary(ctx, src.ContentLibrary)
 	case src.OVAURL != "":
 		// 4) ovaURL: the real import.
 		return p.imagePrepareImportOVA(ctx, src, targetName, req.GetStorageHint())
@@ -317,54 +318,34 @@ func (p *Provider) imagePrepareVerifyTemplate(ctx context.Context, templateName
 	return imagePrepareDone(templateName), nil
 }
 
-// imagePrepareVerifyContentLibrary implements the contentLibrary source: verify
-// the referenced library and item exist via the vCenter REST (vAPI) endpoint.
-// Deploying a content-library item into a standalone template is intentionally
-// out of scope for this PR; an existing-but-unhandled item returns a clear
-// InvalidSpec pointing the user at templateName/ovaURL rather than a fake
-// success.
-func (p *Provider) imagePrepareVerifyContentLibrary(ctx context.Context, ref *vsphereContentLibraryRef) (*providerv1.ImagePrepareResponse, error) {
-	if ref.Library == "" || ref.Item == "" {
-		return nil, errors.NewInvalidSpec("ImagePrepare contentLibrary requires both library and item")
-	}
-
-	// The REST/vAPI client is a separate session from the vim25 SOAP client; log
-	// in with the same credentials and log out when done.
-	rc := rest.NewClient(p.client.Client)
-	userInfo := url.UserPassword(p.config.Username, p.config.Password)
-	if err := rc.Login(ctx, userInfo); err != nil {
-		return nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
-	}
-	defer func() {
-		if err := rc.Logout(ctx); err != nil {
-			p.logger.Warn("ImagePrepare: content-library REST logout failed", "error", err)
-		}
-	}()
-
-	mgr := library.NewManager(rc)
-	libs, err := mgr.FindLibrary(ctx, library.Find{Name: ref.Library})
+// imagePrepareSyncContentLibrary implements the contentLibrary source: it
+// resolves the referenced item through the vCenter REST (vAPI) endpoint, the
+// way Create does, and syncs the item when its library is a subscription that
+// has not downloaded it yet. A missing library, item or pinned version is a
+// NotFound that lists what does exist.
+func (p *Provider) imagePrepareSyncContentLibrary(ctx context.Context, ref *vsphereContentLibraryRef) (*providerv1.ImagePrepareResponse, error) {
+	rc, logout, err := p.contentLibrarySession(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("ImagePrepare: find content library %q: %w", ref.Library, err)
-	}
-	if len(libs) == 0 {
-		return nil, errors.NewNotFound("vSphere content library", ref.Library)
+		return nil, err
 	}
+	defer logout()
 
-	items, err := mgr.FindLibraryItems(ctx, library.FindItem{LibraryID: libs[0], Name: ref.Item})
+	mgr := library.NewManager(rc)
+	item, err := p.resolveLibraryItem(ctx, mgr, &contracts.ContentLibraryItem{
+		Library: ref.Library,
+		Item:    ref.Item,
+		Version: ref.Version,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("ImagePrepare: find library item %q in %q: %w", ref.Item, ref.Library, err)
+		return nil, err
 	}
-	if len(items) == 0 {
-		return nil, errors.NewNotFound("vSphere content library item", fmt.Sprintf("%s/%s", ref.Library, ref.Item))
+	if item, err = p.syncLibraryItem(ctx, mgr, item); err != nil {
+		return nil, err
 	}
 
-	p.logger.Info("ImagePrepare: content-library item exists",
-		"library", ref.Library, "item", ref.Item, "library_id", libs[0], "item_id", items[0])
-
-	// Verified, but deploying CL item -> template is not implemented in this PR.
-	return nil, errors.NewInvalidSpec(
-		"ImagePrepare: content library item %q/%q exists but content-library prepare is not yet implemented; "+
-			"use source.vsphere.templateName or source.vsphere.ovaURL", ref.Library, ref.Item)
+	p.logger.Info("ImagePrepare: content-library item ready",
+		"library", ref.Library, "item", ref.Item, "item_id", item.ID, "version", item.ContentVersion)
+	return imagePrepareDone(item.ID), nil
 }
 
 // imagePrepareImportOVA implements the ovaURL source: the real import. It
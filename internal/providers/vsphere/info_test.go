@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TestGetInfo_AboutInfo reports the simulator's AboutInfo.
+func TestGetInfo_AboutInfo(t *testing.T) {
+	cfg, cleanup := newSimConfig(t)
+	defer cleanup()
+
+	client, finder, err := createVSphereClient(cfg)
+	require.NoError(t, err)
+	defer func() { _ = client.Logout(context.Background()) }()
+
+	p := &Provider{client: client, finder: finder, config: cfg, logger: slog.Default()}
+	resp, err := p.GetInfo(context.Background(), &providerv1.GetInfoRequest{})
+	require.NoError(t, err)
+
+	about := client.ServiceContent.About
+	assert.Equal(t, about.Name, resp.HypervisorProduct)
+	assert.NotEmpty(t, resp.HypervisorVersion)
+	assert.Equal(t, about.Version, resp.HypervisorVersion)
+	assert.Contains(t, resp.HypervisorDetails, about.Build)
+}
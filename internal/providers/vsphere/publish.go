@@ -0,0 +1,219 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// PublishImage publishes a VM as an image named req.ImageName, leaving the
+// VM itself as it is:
+//
+//   - by default it is cloned straight into a template (CloneVM_Task with
+//     Template set), from options.snapshotID when given. The template is
+//     addressed by name and the clone task is returned for the caller to
+//     poll.
+//   - with options.contentLibrary it is exported as an OVF item of that
+//     content library instead. The export is synchronous and the image ID
+//     is the library item ID.
+//
+// vSphere cannot run virt-sysprep cleanup, so options asking for it are
+// rejected.
+func (p *Provider) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	if p.client == nil || p.finder == nil {
+		return nil, errors.NewUnavailable("vSphere", fmt.Errorf("provider client not initialized"))
+	}
+	if req.GetImageName() == "" {
+		return nil, errors.NewInvalidSpec("PublishImage: image name is required")
+	}
+	var options contracts.PublishImageOptions
+	if req.GetOptionsJson() != "" {
+		if err := contracts.UnmarshalPayload([]byte(req.GetOptionsJson()), &options); err != nil {
+			return nil, errors.NewInvalidSpec("PublishImage: invalid options JSON: %v", err)
+		}
+	}
+	if len(options.Cleanup) > 0 {
+		return nil, errors.NewInvalidSpec("PublishImage: vSphere cannot run cleanup operations %v; clean the guest before publishing", options.Cleanup)
+	}
+
+	datacenter, err := p.finder.DefaultDatacenter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PublishImage: find default datacenter: %w", err)
+	}
+	p.finder.SetDatacenter(datacenter)
+
+	vm := object.NewVirtualMachine(p.client.Client, types.ManagedObjectReference{Type: "VirtualMachine", Value: req.GetVmId()})
+	var vmMo mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"runtime.powerState", "snapshot", "config.template"}, &vmMo); err != nil {
+		return nil, errors.NewNotFound("VM", req.GetVmId())
+	}
+	if vmMo.Config != nil && vmMo.Config.Template {
+		return nil, errors.NewInvalidSpec("PublishImage: VM %s is already a template", req.GetVmId())
+	}
+	poweredOff := vmMo.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOff
+
+	if options.ContentLibrary != "" {
+		if options.SnapshotID != "" {
+			return nil, errors.NewInvalidSpec("PublishImage: a content library export reads the VM's current disks, not a snapshot")
+		}
+		if !poweredOff {
+			return nil, errors.NewInvalidSpec("PublishImage: VM %s must be powered off to export it", req.GetVmId())
+		}
+		return p.publishToContentLibrary(ctx, req.GetVmId(), req.GetImageName(), options.ContentLibrary, req.GetOverwrite())
+	}
+
+	var snapshot *types.ManagedObjectReference
+	if options.SnapshotID != "" {
+		var tree *types.VirtualMachineSnapshotTree
+		if vmMo.Snapshot != nil {
+			tree = p.findSnapshotByID(vmMo.Snapshot.RootSnapshotList, options.SnapshotID)
+		}
+		if tree == nil {
+			return nil, errors.NewNotFound("snapshot", options.SnapshotID)
+		}
+		snapshot = &tree.Snapshot
+	} else if !poweredOff {
+		return nil, errors.NewInvalidSpec("PublishImage: VM %s is %s; power it off or publish from a snapshot", req.GetVmId(), vmMo.Runtime.PowerState)
+	}
+
+	if err := p.replacePublishedTemplate(ctx, req.GetImageName(), req.GetOverwrite()); err != nil {
+		return nil, err
+	}
+	placement, err := p.resolveImagePlacement(ctx, options.Storage)
+	if err != nil {
+		return nil, err
+	}
+	spec := types.VirtualMachineCloneSpec{
+		Location: types.VirtualMachineRelocateSpec{
+			Datastore:    types.NewReference(placement.datastore.Reference()),
+			Pool:         types.NewReference(placement.resourcePool.Reference()),
+			DiskMoveType: string(types.VirtualMachineRelocateDiskMoveOptionsMoveAllDiskBackingsAndDisallowSharing),
+		},
+		Template: true,
+		Snapshot: snapshot,
+	}
+
+	p.logger.Info("Publishing VM as template", "vm_id", req.GetVmId(), "template", req.GetImageName(), "snapshot", options.SnapshotID)
+	task, err := vm.Clone(ctx, placement.folder, req.GetImageName(), spec)
+	if err != nil {
+		return nil, fmt.Errorf("PublishImage: start clone to template: %w", err)
+	}
+	return &providerv1.PublishImageResponse{
+		Task:    p.taskRef(task.Reference()),
+		ImageId: req.GetImageName(),
+	}, nil
+}
+
+// replacePublishedTemplate makes room for a template named name: an
+// existing template is destroyed when overwrite is set and is an
+// AlreadyExists error otherwise. A VM that is not a template is never
+// destroyed to make room.
+func (p *Provider) replacePublishedTemplate(ctx context.Context, name string, overwrite bool) error {
+	existing := p.findExistingByName(ctx, name)
+	if existing == nil {
+		return nil
+	}
+	if !overwrite {
+		return errors.NewAlreadyExists("vSphere template", name)
+	}
+	isTemplate, err := existing.IsTemplate(ctx)
+	if err != nil {
+		return fmt.Errorf("PublishImage: inspect existing %q: %w", name, err)
+	}
+	if !isTemplate {
+		return errors.NewInvalidSpec("PublishImage: %q is a VM, not a template; it cannot be overwritten", name)
+	}
+	p.logger.Info("Replacing published template", "template", name, "vm", existing.Reference().Value)
+	task, err := existing.Destroy(ctx)
+	if err != nil {
+		return fmt.Errorf("PublishImage: destroy template %q: %w", name, err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("PublishImage: destroy template %q: %w", name, err)
+	}
+	return nil
+}
+
+// publishToContentLibrary exports the VM as an OVF item named itemName in
+// libraryName. With overwrite an existing item of that name is updated in
+// place, keeping its ID.
+func (p *Provider) publishToContentLibrary(ctx context.Context, vmID, itemName, libraryName string, overwrite bool) (*providerv1.PublishImageResponse, error) {
+	rc, logout, err := p.contentLibrarySession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer logout()
+
+	mgr := library.NewManager(rc)
+	libs, err := mgr.FindLibrary(ctx, library.Find{Name: libraryName})
+	if err != nil {
+		return nil, fmt.Errorf("PublishImage: find content library %q: %w", libraryName, err)
+	}
+	if len(libs) == 0 {
+		return nil, errors.NewNotFound("vSphere content library", libraryName)
+	}
+	target := vcenter.LibraryTarget{LibraryID: libs[0]}
+	items, err := mgr.FindLibraryItems(ctx, library.FindItem{LibraryID: libs[0], Name: itemName})
+	if err != nil {
+		return nil, fmt.Errorf("PublishImage: find library item %q in %q: %w", itemName, libraryName, err)
+	}
+	if len(items) > 0 {
+		if !overwrite {
+			return nil, errors.NewAlreadyExists("vSphere content library item", libraryName+"/"+itemName)
+		}
+		target = vcenter.LibraryTarget{LibraryItemID: items[0]}
+	}
+
+	p.logger.Info("Exporting VM to content library", "vm_id", vmID, "library", libraryName, "item", itemName)
+	itemID, err := vcenter.NewManager(rc).CreateOVF(ctx, vcenter.OVF{
+		Spec:   vcenter.CreateSpec{Name: itemName, Description: fmt.Sprintf("Published from VM %s", vmID)},
+		Source: vcenter.ResourceID{Value: vmID},
+		Target: target,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PublishImage: export VM %s to content library %q: %w", vmID, libraryName, err)
+	}
+	return &providerv1.PublishImageResponse{ImageId: itemID}, nil
+}
+
+// contentLibrarySession logs in to the vCenter REST (vAPI) endpoint, a
+// separate session from the vim25 SOAP client, with the provider's
+// credentials. The returned func logs out.
+func (p *Provider) contentLibrarySession(ctx context.Context) (*rest.Client, func(), error) {
+	rc := rest.NewClient(p.client.Client)
+	if err := rc.Login(ctx, url.UserPassword(p.config.Username, p.config.Password)); err != nil {
+		return nil, nil, errors.NewUnavailable("vSphere content library (vAPI)", err)
+	}
+	return rc, func() {
+		if err := rc.Logout(ctx); err != nil {
+			p.logger.Warn("Content-library REST logout failed", "error", err)
+		}
+	}, nil
+}
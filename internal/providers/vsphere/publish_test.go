@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/object"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// poweredOffSimVM powers off the simulator's DC0_H0_VM0 and returns it.
+func poweredOffSimVM(t *testing.T, p *Provider) *object.VirtualMachine {
+	t.Helper()
+	ctx := context.Background()
+	vm, err := p.finder.VirtualMachine(ctx, "DC0_H0_VM0")
+	require.NoError(t, err)
+	task, err := vm.PowerOff(ctx)
+	require.NoError(t, err)
+	require.NoError(t, task.Wait(ctx))
+	return vm
+}
+
+func TestPublishImage_CloneToTemplate(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	vm := poweredOffSimVM(t, p)
+
+	publish := func(overwrite bool) (*providerv1.PublishImageResponse, error) {
+		resp, err := p.PublishImage(ctx, &providerv1.PublishImageRequest{
+			VmId: vm.Reference().Value, ImageName: "vm0-golden", Overwrite: overwrite,
+		})
+		if err == nil {
+			task, terr := p.TaskStatus(ctx, &providerv1.TaskStatusRequest{Task: resp.Task})
+			require.NoError(t, terr)
+			require.True(t, task.Done)
+			require.Empty(t, task.Error)
+		}
+		return resp, err
+	}
+
+	resp, err := publish(false)
+	require.NoError(t, err)
+	assert.Equal(t, "vm0-golden", resp.ImageId)
+	endpoint, err := url.Parse(p.config.Endpoint)
+	require.NoError(t, err)
+	link, err := url.Parse(resp.Task.Link)
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Host, link.Host, "the task link points at the configured vCenter")
+	assert.Contains(t, resp.Task.Link, url.QueryEscape("urn:vmomi:Task:"+resp.Task.Id+":"))
+	template, err := p.finder.VirtualMachine(ctx, "vm0-golden")
+	require.NoError(t, err)
+	isTemplate, err := template.IsTemplate(ctx)
+	require.NoError(t, err)
+	assert.True(t, isTemplate)
+	isTemplate, err = vm.IsTemplate(ctx)
+	require.NoError(t, err)
+	assert.False(t, isTemplate, "the source VM must not be converted")
+
+	_, err = publish(false)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = publish(true)
+	require.NoError(t, err)
+	_, err = template.IsTemplate(ctx)
+	assert.Error(t, err, "the replaced template should be destroyed")
+}
+
+func TestPublishImage_Rejections(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	running, err := p.finder.VirtualMachine(ctx, "DC0_H0_VM0")
+	require.NoError(t, err)
+	_, err = p.PublishImage(ctx, &providerv1.PublishImageRequest{VmId: running.Reference().Value, ImageName: "x"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a running VM needs a snapshot")
+
+	_, err = p.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: running.Reference().Value, ImageName: "x", OptionsJson: `{"snapshotID":"missing"}`,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = p.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: running.Reference().Value, ImageName: "x", OptionsJson: `{"cleanup":["machine-id"]}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = p.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId: running.Reference().Value, ImageName: "x", OptionsJson: `{"contentLibrary":"lib"}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "an export needs the VM powered off")
+}
@@ -92,3 +92,58 @@ func TestReconfigure_AppliesVMClassCPUMemory(t *testing.T) {
 	assert.Equal(t, int32(4), after.Config.Hardware.NumCPU, "Reconfigure must apply VMClass CPU")
 	assert.Equal(t, int32(8192), after.Config.Hardware.MemoryMB, "Reconfigure must apply VMClass MemoryMiB")
 }
+
+// TestReconfigure_ChangeSetOnPoweredOnVM verifies that a ChangeSet CPU change
+// vSphere cannot hot-plug is reported as needing a power cycle instead of
+// failing the ReconfigVM_Task, and is applied once the VM is off.
+func TestReconfigure_ChangeSetOnPoweredOnVM(t *testing.T) {
+	cfg, cleanup := newSimConfig(t)
+	defer cleanup()
+
+	client, finder, err := createVSphereClient(cfg)
+	require.NoError(t, err)
+	defer func() { _ = client.Logout(context.Background()) }()
+
+	p := &Provider{client: client, finder: finder, config: cfg, logger: slog.Default()}
+	ctx := context.Background()
+
+	dc, err := finder.DefaultDatacenter(ctx)
+	require.NoError(t, err)
+	finder.SetDatacenter(dc)
+
+	vms, err := finder.VirtualMachineList(ctx, "*")
+	require.NoError(t, err)
+	require.NotEmpty(t, vms)
+	vm := vms[0]
+	if task, perr := vm.PowerOn(ctx); perr == nil {
+		_, _ = task.WaitForResult(ctx, nil)
+	}
+
+	var before mo.VirtualMachine
+	require.NoError(t, vm.Properties(ctx, vm.Reference(), []string{"config.hardware.numCPU"}, &before))
+	newCPU := before.Config.Hardware.NumCPU + 2
+	req := &providerv1.ReconfigureRequest{
+		Id: vm.Reference().Value,
+		Changes: &providerv1.ChangeSet{
+			Cpu: &providerv1.Int32Change{Old: before.Config.Hardware.NumCPU, New: newCPU},
+		},
+	}
+
+	resp, err := p.Reconfigure(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{contracts.ChangeCPU}, resp.PowerCycleRequired)
+
+	var after mo.VirtualMachine
+	require.NoError(t, vm.Properties(ctx, vm.Reference(), []string{"config.hardware.numCPU"}, &after))
+	assert.Equal(t, before.Config.Hardware.NumCPU, after.Config.Hardware.NumCPU, "CPU hot-add is disabled")
+
+	task, err := vm.PowerOff(ctx)
+	require.NoError(t, err)
+	require.NoError(t, task.Wait(ctx))
+
+	resp, err = p.Reconfigure(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.PowerCycleRequired)
+	require.NoError(t, vm.Properties(ctx, vm.Reference(), []string{"config.hardware.numCPU"}, &after))
+	assert.Equal(t, newCPU, after.Config.Hardware.NumCPU)
+}
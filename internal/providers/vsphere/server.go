@@ -20,12 +20,12 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -42,11 +42,14 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
+	"gopkg.in/yaml.v3"
 
 	"github.com/projectbeskar/virtrigaud/internal/diskutil"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/storage"
 	"github.com/projectbeskar/virtrigaud/internal/storage/migration"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
@@ -62,6 +65,9 @@ type Provider struct {
 	finder *find.Finder
 	logger *slog.Logger
 	config *Config
+
+	// libraryItems caches content library items resolved by name
+	libraryItems libraryItemCache
 }
 
 // Config holds the vSphere provider configuration
@@ -457,14 +463,22 @@ func (p *Provider) Validate(ctx context.Context, req *providerv1.ValidateRequest
 //   - Image import from external sources
 //   - Disk types: thin, thick, eager-zeroed
 //   - Network types: standard vSwitch portgroups and distributed virtual switch portgroups
+//   - Sysprep guest customization of Windows guests via a CustomizationSpec
+//   - cloud-init status the guest publishes in guestinfo
+//   - WebMKS consoles through the manager's console proxy
 func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
 	return &providerv1.GetCapabilitiesResponse{
 		SupportsReconfigureOnline:   true,
 		SupportsDiskExpansionOnline: true,
 		SupportsSnapshots:           true,
 		SupportsMemorySnapshots:     true, // vSphere captures RAM-inclusive snapshots via CreateSnapshot(memory=true); requires the VM to be powered on
+		SupportsQuiescedSnapshots:   true, // CreateSnapshot(quiesce=true) freezes the guest through VMware Tools
 		SupportsLinkedClones:        true,
 		SupportsImageImport:         true, // ImagePrepare imports an OVA/OVF URL into vCenter as a template (#154)
+		SupportsSysprep:             true, // unattend.xml is applied as CustomizationSysprepText on clone
+		SupportsCloudInitStatus:     true, // read from guestinfo.cloudinit.status, which the guest publishes
+		SupportsConsoleProxy:        true, // WebMKS tickets from AcquireTicket, pinned to the ESXi host's thumbprint
+		SupportsImagePublish:        true, // CloneVM_Task into a template, or an OVF export to a content library
 		SupportedDiskTypes:          []string{"thin", "thick", "eager-zeroed"},
 		SupportedNetworkTypes:       []string{"standard", "distributed"},
 		// Disk migration: ExportDisk and ImportDisk are implemented (issue #178).
@@ -493,13 +507,18 @@ func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapab
 // based on the JSON-encoded specifications contained in req (VMClass, VMImage, networks,
 // cloud-init user-data, cloud-init metadata, and placement overrides).
 //
-// Two creation paths are supported:
+// Three creation paths are supported:
 //   - Template clone: when req.ImageJson contains a TemplateName the VM is created by
 //     cloning an existing vSphere VM or template with CloneVM_Task.
 //   - Imported disk: when req.ImageJson contains a Path the VM is created from scratch
 //     (CreateVM_Task) with the pre-uploaded VMDK attached as a persistent disk.
+//   - Content library: when req.ImageJson contains only a ContentLibrary item the VM
+//     is deployed from that OVF or VM template item and then reconfigured.
+//
+// A Sysprep guest customization (req.GuestCustomizationJson) is applied as a
+// CustomizationSpec in any path, in place of the cloud-init guestinfo keys.
 //
-// In both cases the VM is powered on immediately after creation. The returned
+// In every case the VM is powered on immediately after creation. The returned
 // CreateResponse.Id contains the vSphere ManagedObjectReference value (e.g. "vm-42")
 // which is used as the stable VM identifier in all subsequent API calls.
 func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
@@ -545,6 +564,9 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 			p.logger.Warn("VM already exists, will attempt to use existing VM",
 				"vm_name", vmSpec.Name,
 				"vm_ref", existingVM.Reference().Value)
+			if err := p.checkIdempotencyKey(ctx, existingVM, req.IdempotencyKey); err != nil {
+				return nil, err
+			}
 			return &providerv1.CreateResponse{
 				Id: existingVM.Reference().Value,
 			}, nil
@@ -563,6 +585,46 @@ func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*
 	}, nil
 }
 
+// idempotencyKeyExtraConfig is the extraConfig key recording the
+// idempotency key of the Create that made a VM. It is not a guestinfo.*
+// key, so the guest cannot read it.
+const idempotencyKeyExtraConfig = "virtrigaud.idempotencyKey"
+
+// checkIdempotencyKey decides whether vm, found under the name a Create
+// asked for, is the VM that Create's key refers to. A VM created for a
+// different key means two VirtualMachines share a name in one folder and
+// is reported as AlreadyExists; a VM without a recorded key (created
+// before keys were recorded, or by hand) is adopted as it always was.
+func (p *Provider) checkIdempotencyKey(ctx context.Context, vm *object.VirtualMachine, key string) error {
+	if key == "" {
+		return nil
+	}
+	var vmMo mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"config.extraConfig"}, &vmMo); err != nil {
+		return errors.NewUnavailable("vSphere VM properties", err)
+	}
+	if recorded := recordedIdempotencyKey(&vmMo); recorded != "" && recorded != key {
+		return errors.NewAlreadyExists("VM", vm.Reference().Value)
+	}
+	return nil
+}
+
+// recordedIdempotencyKey returns the idempotency key in vmMo's
+// config.extraConfig, or "" when none is recorded or the property was not
+// retrieved.
+func recordedIdempotencyKey(vmMo *mo.VirtualMachine) string {
+	if vmMo.Config == nil {
+		return ""
+	}
+	for _, opt := range vmMo.Config.ExtraConfig {
+		if ov := opt.GetOptionValue(); ov != nil && ov.Key == idempotencyKeyExtraConfig {
+			recorded, _ := ov.Value.(string)
+			return recorded
+		}
+	}
+	return ""
+}
+
 // Delete implements the ProviderServer interface. It removes the virtual machine
 // identified by req.Id (vSphere ManagedObjectReference value) from the vCenter
 // inventory and deletes all associated files from the datastore.
@@ -666,6 +728,7 @@ func (p *Provider) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*
 //     ShutdownGuest via VMware Tools and falls back to a hard PowerOff if Tools are
 //     unavailable or the graceful timeout (req.GracefulTimeoutSeconds, default 60 s)
 //     elapses.
+//   - POWER_OP_RESET: issues ResetVM_Task (hard reset) and waits for completion.
 //
 // All operations except REBOOT block until the underlying vSphere task completes.
 func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*providerv1.TaskResponse, error) {
@@ -696,12 +759,12 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	case providerv1.PowerOp_POWER_OP_ON:
 		task, err = vm.PowerOn(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to start power on task: %w", err)
+			return nil, vmTaskFailure("failed to start power on task", err)
 		}
 	case providerv1.PowerOp_POWER_OP_OFF:
 		task, err = vm.PowerOff(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to start power off task: %w", err)
+			return nil, vmTaskFailure("failed to start power off task", err)
 		}
 	case providerv1.PowerOp_POWER_OP_REBOOT:
 		// For reboot, we need to restart the guest OS
@@ -715,6 +778,11 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	case providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL:
 		// Graceful shutdown using guest tools
 		return p.performGracefulShutdown(ctx, vm, req)
+	case providerv1.PowerOp_POWER_OP_RESET:
+		task, err = vm.Reset(ctx)
+		if err != nil {
+			return nil, vmTaskFailure("failed to start reset task", err)
+		}
 	default:
 		return nil, fmt.Errorf("unsupported power operation: %s", req.Op.String())
 	}
@@ -723,7 +791,7 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	// In a real implementation, you might want to return the task reference for async tracking
 	_, err = task.WaitForResult(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("power operation failed: %w", err)
+		return nil, vmTaskFailure("power operation failed", err)
 	}
 
 	p.logger.Info("Power operation completed successfully", "vm_id", req.Id, "operation", req.Op.String())
@@ -847,7 +915,7 @@ func (p *Provider) fallbackToPowerOff(ctx context.Context, vm *object.VirtualMac
 
 	task, err := vm.PowerOff(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start power off task: %w", err)
+		return nil, vmTaskFailure("failed to start power off task", err)
 	}
 
 	// Wait for power off to complete
@@ -860,25 +928,25 @@ func (p *Provider) fallbackToPowerOff(ctx context.Context, vm *object.VirtualMac
 	return &providerv1.TaskResponse{}, nil
 }
 
-// Reconfigure implements the ProviderServer interface. It applies the desired
-// configuration described by req.DesiredJson to the virtual machine identified by
-// req.Id. The JSON payload is expected to contain a subset of the following top-level
-// keys:
+// Reconfigure implements the ProviderServer interface. It applies the manager's
+// ChangeSet (req.Changes) to the virtual machine identified by req.Id:
 //
-//   - "Class": object with "CPU" (number) and/or "MemoryMiB" (number, MiB) fields
-//     for CPU and memory adjustments.
-//   - "Disks": array where the first element may carry a "SizeGiB" (number, GiB)
-//     field to expand the primary disk.
+//   - Cpu / MemoryMib set NumCPUs / MemoryMB. On a powered-on VM a change is
+//     only applied when vSphere can hot-plug it (CpuHotAddEnabled for more
+//     CPUs, CpuHotRemoveEnabled for fewer, MemoryHotAddEnabled for more
+//     memory; memory is never hot-removed). Otherwise it is left out of the
+//     ReconfigVM_Task and reported in PowerCycleRequired, and the manager
+//     re-sends it once the VM is off.
+//   - Disks grows the primary disk (contracts.RootDiskName). Disks are never
+//     shrunk and a size at or below the current capacity is a no-op.
+//   - Network attachment changes are not applied.
 //
-// These are the Go field names of the marshaled contracts.CreateRequest — VMClass
-// and DiskSpec carry NO json tags — NOT lowercase cpus/memory/size.
+// A manager that predates ChangeSet sends only req.DesiredJson; the change set
+// is then derived by comparing it with the VM's current hardware.
 //
-// Current values are retrieved from vCenter before building the change-spec so that
-// only actual differences trigger a ReconfigVM_Task. If no changes are detected the
-// method returns success immediately without contacting vSphere. Disk shrinks are not
-// permitted; a resize is only applied when the desired size is larger than the current
-// allocated size.
-func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+// If nothing needs changing the method returns without issuing a task. The
+// reconfiguration task blocks until completion.
+func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	if p.client == nil {
 		return nil, fmt.Errorf("vSphere client not configured")
 	}
@@ -906,108 +974,149 @@ func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureR
 		"config.hardware.numCPU",
 		"config.hardware.memoryMB",
 		"config.hardware.device",
+		"config.cpuHotAddEnabled",
+		"config.cpuHotRemoveEnabled",
+		"config.memoryHotAddEnabled",
 		"runtime.powerState",
 	}, &vmMo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get VM properties: %w", err)
 	}
 
-	// Parse the desired configuration. DesiredJson is a marshaled
-	// contracts.CreateRequest; VMClass and DiskSpec carry NO json tags, so the keys
-	// are the Go field names — Class.CPU, Class.MemoryMiB (numeric MiB), and
-	// Disks[].SizeGiB (numeric GiB). Reading lowercase cpus/memory/size (the prior
-	// bug) never matched, so every CPU/memory change was silently dropped while the
-	// RPC reported success — the VM stayed at its creation-time size and status
-	// falsely reported the new size. Mirror the typed parse the Create path uses.
-	var desired struct {
-		Class struct {
-			CPU       int32 `json:"CPU"`
-			MemoryMiB int32 `json:"MemoryMiB"`
-		} `json:"Class"`
-		Disks []struct {
-			SizeGiB int32 `json:"SizeGiB"`
-		} `json:"Disks"`
-	}
-	if err := json.Unmarshal([]byte(req.DesiredJson), &desired); err != nil {
-		return nil, fmt.Errorf("failed to parse desired configuration: %w", err)
+	changes := req.GetChanges()
+	if changes == nil {
+		if changes, err = vsphereLegacyChangeSet(&vmMo, req.DesiredJson); err != nil {
+			return nil, err
+		}
 	}
 
-	// Build the reconfiguration spec
+	resp := &providerv1.ReconfigureResponse{}
 	configSpec := &types.VirtualMachineConfigSpec{}
 	hasChanges := false
+	poweredOn := vmMo.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn
 
 	// CPU
-	if desired.Class.CPU > 0 && desired.Class.CPU != vmMo.Config.Hardware.NumCPU {
-		p.logger.Info("CPU change requested", "vm_id", req.Id, "old", vmMo.Config.Hardware.NumCPU, "new", desired.Class.CPU)
-		configSpec.NumCPUs = desired.Class.CPU
-		hasChanges = true
+	if cpu := changes.GetCpu(); cpu != nil && cpu.New > 0 && cpu.New != vmMo.Config.Hardware.NumCPU {
+		hotPlug := cpu.New > vmMo.Config.Hardware.NumCPU && isTrue(vmMo.Config.CpuHotAddEnabled) ||
+			cpu.New < vmMo.Config.Hardware.NumCPU && isTrue(vmMo.Config.CpuHotRemoveEnabled)
+		if poweredOn && !hotPlug {
+			p.logger.Info("CPU change needs a power cycle", "vm_id", req.Id, "old", vmMo.Config.Hardware.NumCPU, "new", cpu.New)
+			resp.PowerCycleRequired = append(resp.PowerCycleRequired, contracts.ChangeCPU)
+		} else {
+			p.logger.Info("CPU change requested", "vm_id", req.Id, "old", vmMo.Config.Hardware.NumCPU, "new", cpu.New)
+			configSpec.NumCPUs = cpu.New
+			hasChanges = true
+		}
 	}
 
-	// Memory (MemoryMiB is already MiB; vSphere MemoryMB is the same numeric value)
-	if desired.Class.MemoryMiB > 0 {
-		newMemoryMB := int64(desired.Class.MemoryMiB)
+	// Memory (MiB; vSphere MemoryMB is the same numeric value)
+	if mem := changes.GetMemoryMib(); mem != nil && mem.New > 0 && mem.New != int64(vmMo.Config.Hardware.MemoryMB) {
 		currentMemoryMB := int64(vmMo.Config.Hardware.MemoryMB)
-		if newMemoryMB != currentMemoryMB {
-			p.logger.Info("Memory change requested", "vm_id", req.Id, "old_mb", currentMemoryMB, "new_mb", newMemoryMB)
-			configSpec.MemoryMB = newMemoryMB
+		if poweredOn && (mem.New < currentMemoryMB || !isTrue(vmMo.Config.MemoryHotAddEnabled)) {
+			p.logger.Info("Memory change needs a power cycle", "vm_id", req.Id, "old_mb", currentMemoryMB, "new_mb", mem.New)
+			resp.PowerCycleRequired = append(resp.PowerCycleRequired, contracts.ChangeMemoryMiB)
+		} else {
+			p.logger.Info("Memory change requested", "vm_id", req.Id, "old_mb", currentMemoryMB, "new_mb", mem.New)
+			configSpec.MemoryMB = mem.New
 			hasChanges = true
 		}
 	}
 
-	// Disk resize (grow-only): the primary disk's SizeGiB from the desired spec.
-	if len(desired.Disks) > 0 && desired.Disks[0].SizeGiB > 0 {
-		sizeGB := int64(desired.Disks[0].SizeGiB)
-		// Find the primary disk
-		var primaryDisk *types.VirtualDisk
-		for _, device := range vmMo.Config.Hardware.Device {
-			if disk, ok := device.(*types.VirtualDisk); ok {
-				primaryDisk = disk
-				break
-			}
+	// Disk resize (grow-only)
+	for _, expansion := range changes.GetDisks() {
+		if expansion.Name != contracts.RootDiskName {
+			p.logger.Warn("Skipping expansion of a non-root disk", "vm_id", req.Id, "disk", expansion.Name)
+			continue
 		}
-		if primaryDisk != nil {
-			currentSizeGB := primaryDisk.CapacityInKB / (1024 * 1024)
-			if sizeGB > currentSizeGB {
-				p.logger.Info("Disk resize requested", "vm_id", req.Id, "old_gb", currentSizeGB, "new_gb", sizeGB)
-
-				// Create a new disk with updated size
-				newDisk := *primaryDisk
-				newDisk.CapacityInKB = sizeGB * 1024 * 1024 // Convert GiB to KB
-
-				deviceSpec := &types.VirtualDeviceConfigSpec{
-					Operation: types.VirtualDeviceConfigSpecOperationEdit,
-					Device:    &newDisk,
-				}
-
-				configSpec.DeviceChange = append(configSpec.DeviceChange, deviceSpec)
-				hasChanges = true
+		primaryDisk := primaryVirtualDisk(&vmMo)
+		if primaryDisk == nil {
+			continue
+		}
+		sizeGB := int64(expansion.NewSizeGib)
+		currentSizeGB := primaryDisk.CapacityInKB / (1024 * 1024)
+		if sizeGB > currentSizeGB {
+			p.logger.Info("Disk resize requested", "vm_id", req.Id, "old_gb", currentSizeGB, "new_gb", sizeGB)
+
+			// Create a new disk with updated size
+			newDisk := *primaryDisk
+			newDisk.CapacityInKB = sizeGB * 1024 * 1024 // Convert GiB to KB
+
+			deviceSpec := &types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationEdit,
+				Device:    &newDisk,
 			}
+
+			configSpec.DeviceChange = append(configSpec.DeviceChange, deviceSpec)
+			hasChanges = true
 		}
 	}
 
+	if len(changes.GetNetworksAdded()) > 0 || len(changes.GetNetworksRemoved()) > 0 {
+		p.logger.Info("Network attachment changes are not applied by Reconfigure", "vm_id", req.Id)
+	}
+
 	// If no changes, return success immediately
 	if !hasChanges {
-		p.logger.Info("No configuration changes needed", "vm_id", req.Id)
-		return &providerv1.TaskResponse{}, nil
+		p.logger.Info("No configuration changes applied", "vm_id", req.Id, "power_cycle_required", resp.PowerCycleRequired)
+		return resp, nil
 	}
 
 	// Perform the reconfiguration
 	p.logger.Info("Applying VM reconfiguration", "vm_id", req.Id)
 	task, err := vm.Reconfigure(ctx, *configSpec)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start reconfiguration: %w", err)
+		return nil, vmTaskFailure("failed to start reconfiguration", err)
 	}
 
 	// Wait for the reconfiguration to complete
 	_, err = task.WaitForResult(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("reconfiguration failed: %w", err)
+		return nil, vmTaskFailure("reconfiguration failed", err)
 	}
 
 	p.logger.Info("VM reconfigured successfully", "vm_id", req.Id)
 
-	// Return empty task response since we completed synchronously
-	return &providerv1.TaskResponse{}, nil
+	// No task reference since we completed synchronously
+	return resp, nil
+}
+
+// vsphereLegacyChangeSet derives a ChangeSet from req.DesiredJson, a marshaled
+// contracts.CreateRequest: Class.CPU, Class.MemoryMiB (numeric MiB), and
+// Disks[].SizeGiB (numeric GiB), the first of which sizes the primary disk.
+// Reading ad-hoc cpus/memory/size keys (the #266 bug) never matched, so every
+// CPU/memory change was silently dropped while the RPC reported success.
+func vsphereLegacyChangeSet(vmMo *mo.VirtualMachine, desiredJSON string) (*providerv1.ChangeSet, error) {
+	var desired contracts.CreateRequest
+	if err := contracts.UnmarshalPayload([]byte(desiredJSON), &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse desired configuration: %w", err)
+	}
+
+	changes := &providerv1.ChangeSet{}
+	if desired.Class.CPU > 0 {
+		changes.Cpu = &providerv1.Int32Change{Old: vmMo.Config.Hardware.NumCPU, New: desired.Class.CPU}
+	}
+	if desired.Class.MemoryMiB > 0 {
+		changes.MemoryMib = &providerv1.Int64Change{Old: int64(vmMo.Config.Hardware.MemoryMB), New: int64(desired.Class.MemoryMiB)}
+	}
+	if len(desired.Disks) > 0 && desired.Disks[0].SizeGiB > 0 {
+		changes.Disks = []*providerv1.DiskExpansion{{Name: contracts.RootDiskName, NewSizeGib: desired.Disks[0].SizeGiB}}
+	}
+	return changes, nil
+}
+
+// isTrue reports whether an optional vSphere flag is set.
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// primaryVirtualDisk returns the VM's first virtual disk, or nil.
+func primaryVirtualDisk(vmMo *mo.VirtualMachine) *types.VirtualDisk {
+	for _, device := range vmMo.Config.Hardware.Device {
+		if disk, ok := device.(*types.VirtualDisk); ok {
+			return disk
+		}
+	}
+	return nil
 }
 
 // HardwareUpgrade implements the ProviderServer interface. It upgrades the virtual
@@ -1120,6 +1229,54 @@ func (p *Provider) isNewerHardwareVersion(current, target string) bool {
 	return targetNum > currentNum
 }
 
+// describeProperties are the VirtualMachine properties Describe and
+// DescribeBatch retrieve.
+var describeProperties = []string{
+	// Power and runtime state
+	"runtime.powerState",
+	"runtime.connectionState",
+	"runtime.bootTime",
+	"summary.runtime.powerState",
+	"summary.runtime.connectionState",
+
+	// Guest information
+	"guest.ipAddress",
+	"guest.net",
+	"guest.guestState",
+	"guest.toolsStatus",
+	"guest.toolsVersion",
+	"guest.guestFullName",
+	"guest.hostName",
+
+	// Configuration
+	"summary.config.name",
+	"summary.config.numCpu",
+	"summary.config.memorySizeMB",
+	"summary.config.vmPathName",
+	"summary.config.guestFullName",
+	"summary.config.annotation",
+
+	// Hardware and performance
+	"summary.quickStats.overallCpuUsage",
+	"summary.quickStats.guestMemoryUsage",
+	"summary.quickStats.hostMemoryUsage",
+	"summary.quickStats.uptimeSeconds",
+	"summary.runtime.maxCpuUsage",
+
+	// Network details
+	"network",
+	"summary.runtime.host",
+
+	// Identity marker
+	"config.extraConfig",
+
+	// Snapshot tree, for the snapshot count and chain depth
+	"snapshot",
+
+	// Tasks running on the VM, for its busy reason
+	"recentTask",
+}
+
 // Describe implements the ProviderServer interface. It retrieves a comprehensive
 // snapshot of the virtual machine identified by req.Id (vSphere ManagedObjectReference
 // value) and returns it as a DescribeResponse.
@@ -1128,10 +1285,14 @@ func (p *Provider) isNewerHardwareVersion(current, target string) bool {
 //   - Exists: false if the VM cannot be found or is inaccessible.
 //   - PowerState: "On", "Off" (suspended VMs are reported as "Off").
 //   - Ips: all non-loopback, non-link-local IPv4/IPv6 addresses reported by VMware Tools.
+//   - Addresses: those of Ips found on a NIC, with the NIC's port group.
 //   - ConsoleUrl: a vSphere web client URL for direct browser access to the VM console.
-//   - ProviderRawJson: a JSON object with extended fields (cpu_count, memory_mb,
-//     cpu_usage_mhz, memory_usage_mb, uptime_seconds, boot_time, guest OS, hostname,
-//     VMware Tools status and version).
+//   - ProviderRawJson: the VM's details in the SDK describe layout: the ESXi host
+//     it runs on, guest OS, hostname and VMware Tools status and version, vCPUs,
+//     memory and usage, NICs by port group, the snapshot count and chain depth,
+//     the busy reason while a vMotion or snapshot task runs on the VM, and the
+//     connection state and boot time as extras.
+//   - Identity: the idempotency key recorded in the VM's extraConfig at create time.
 //
 // If the property collector call fails (e.g. VM was deleted), the method returns
 // Exists: false rather than propagating a gRPC error.
@@ -1155,46 +1316,10 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		Value: req.Id,
 	}
 
-	// VM object will be used for property retrieval
-
 	// Get VM properties - comprehensive list for detailed monitoring
 	var vmMo mo.VirtualMachine
 	pc := property.DefaultCollector(p.client.Client)
-	err = pc.RetrieveOne(ctx, vmRef, []string{
-		// Power and runtime state
-		"runtime.powerState",
-		"runtime.connectionState",
-		"runtime.bootTime",
-		"summary.runtime.powerState",
-		"summary.runtime.connectionState",
-
-		// Guest information
-		"guest.ipAddress",
-		"guest.net",
-		"guest.guestState",
-		"guest.toolsStatus",
-		"guest.toolsVersion",
-		"guest.guestFullName",
-		"guest.hostName",
-
-		// Configuration
-		"summary.config.name",
-		"summary.config.numCpu",
-		"summary.config.memorySizeMB",
-		"summary.config.vmPathName",
-		"summary.config.guestFullName",
-		"summary.config.annotation",
-
-		// Hardware and performance
-		"summary.quickStats.overallCpuUsage",
-		"summary.quickStats.guestMemoryUsage",
-		"summary.quickStats.hostMemoryUsage",
-		"summary.quickStats.uptimeSeconds",
-
-		// Network details
-		"network",
-		"summary.runtime.host",
-	}, &vmMo)
+	err = pc.RetrieveOne(ctx, vmRef, describeProperties, &vmMo)
 
 	if err != nil {
 		// VM might not exist or be accessible
@@ -1204,12 +1329,114 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		}, nil
 	}
 
+	vms := []mo.VirtualMachine{vmMo}
+	return p.describeResponse(req.Id, &vmMo, hostNames(ctx, pc, vms), busyReasons(ctx, pc, vms)), nil
+}
+
+// hostNames returns the names of the ESXi hosts vms run on, keyed by host
+// managed object ID. Hosts whose names cannot be read are left out.
+func hostNames(ctx context.Context, pc *property.Collector, vms []mo.VirtualMachine) map[string]string {
+	var refs []types.ManagedObjectReference
+	for i := range vms {
+		if ref := vms[i].Summary.Runtime.Host; ref != nil && !slices.Contains(refs, *ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	names := map[string]string{}
+	if len(refs) == 0 {
+		return names
+	}
+	var hosts []mo.HostSystem
+	if err := pc.Retrieve(ctx, refs, []string{"name"}, &hosts); err != nil {
+		return names
+	}
+	for _, h := range hosts {
+		names[h.Self.Value] = h.Name
+	}
+	return names
+}
+
+// busyTaskOperations names the vCenter tasks, by description ID, that hold
+// a VM against other changes while they run: migrations, and the snapshot
+// work backup products do through the vSphere API.
+var busyTaskOperations = map[string]string{
+	"VirtualMachine.migrate":            "vMotion",
+	"Drm.ExecuteVMotionLRO":             "DRS vMotion",
+	"VirtualMachine.relocate":           "relocation",
+	"VirtualMachine.clone":              "clone",
+	"VirtualMachine.createSnapshot":     "snapshot",
+	"VirtualMachine.removeSnapshot":     "snapshot removal",
+	"VirtualMachine.removeAllSnapshots": "snapshot removal",
+	"VirtualMachine.consolidateDisks":   "disk consolidation",
+}
+
+// busyReasons returns the describe busyReason of each of vms with a
+// busyTaskOperations task queued or running, keyed by managed object ID.
+// It is best effort: a task that finished between the two reads fails the
+// whole task retrieval, and then no VM is reported busy.
+func busyReasons(ctx context.Context, pc *property.Collector, vms []mo.VirtualMachine) map[string]string {
+	var refs []types.ManagedObjectReference
+	for i := range vms {
+		refs = append(refs, vms[i].RecentTask...)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	var tasks []mo.Task
+	if err := pc.Retrieve(ctx, refs, []string{"info"}, &tasks); err != nil {
+		return nil
+	}
+	return taskBusyReasons(tasks)
+}
+
+// taskBusyReasons is busyReasons for retrieved tasks. A VM with several
+// such tasks is given the lexically first reason, so the answer does not
+// depend on the order vCenter lists them in.
+func taskBusyReasons(tasks []mo.Task) map[string]string {
+	reasons := map[string]string{}
+	for _, task := range tasks {
+		info := task.Info
+		if info.State != types.TaskInfoStateQueued && info.State != types.TaskInfoStateRunning {
+			continue
+		}
+		op, ok := busyTaskOperations[info.DescriptionId]
+		if !ok || info.Entity == nil || info.Entity.Type != "VirtualMachine" {
+			continue
+		}
+		reason := "task running: " + op
+		if cur, seen := reasons[info.Entity.Value]; !seen || reason < cur {
+			reasons[info.Entity.Value] = reason
+		}
+	}
+	return reasons
+}
+
+// nicMACs returns the MAC address of each of vmMo's NICs that VMware Tools
+// reports, keyed by port group as its GuestAddresses are.
+func nicMACs(vmMo *mo.VirtualMachine) map[string]string {
+	macs := map[string]string{}
+	if vmMo.Guest == nil {
+		return macs
+	}
+	for _, nic := range vmMo.Guest.Net {
+		if nic.Network != "" && nic.MacAddress != "" {
+			macs[nic.Network] = nic.MacAddress
+		}
+	}
+	return macs
+}
+
+// describeResponse builds the Describe answer for the VM with the given
+// managed object ID from its describeProperties, naming its host from
+// hosts and taking its busy reason from busy.
+func (p *Provider) describeResponse(id string, vmMo *mo.VirtualMachine, hosts, busy map[string]string) *providerv1.DescribeResponse {
 	// VM exists, gather comprehensive information
 	powerState := p.mapVSpherePowerState(string(vmMo.Runtime.PowerState))
 	connectionState := string(vmMo.Runtime.ConnectionState)
 
 	// Collect IP addresses with enhanced detection
 	var ips []string
+	var addresses []*providerv1.GuestAddress
 	var primaryIP string
 
 	if vmMo.Guest != nil {
@@ -1225,9 +1452,15 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 				if netInfo.IpConfig != nil {
 					for _, ipConfig := range netInfo.IpConfig.IpAddress {
 						ip := ipConfig.IpAddress
-						if ip != "" && !contains(ips, ip) && p.isValidIPAddress(ip) {
+						if ip == "" || !p.isValidIPAddress(ip) {
+							continue
+						}
+						if !contains(ips, ip) {
 							ips = append(ips, ip)
 						}
+						// VMware Tools does not report the guest's name for
+						// the NIC, so identify it by its port group.
+						addresses = append(addresses, &providerv1.GuestAddress{Ip: ip, Interface: netInfo.Network})
 					}
 				}
 			}
@@ -1255,59 +1488,47 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		}
 	}
 
-	// Get resource information (handle potential nil values safely)
-	cpuCount := int32(0)
-	memoryMB := int32(0)
-	cpuUsage := int32(0)
-	memoryUsage := int32(0)
-	uptimeSeconds := int64(0)
-
-	// Summary.Config and Summary.QuickStats are structs, not pointers
-	cpuCount = vmMo.Summary.Config.NumCpu
-	memoryMB = vmMo.Summary.Config.MemorySizeMB
-
-	cpuUsage = vmMo.Summary.QuickStats.OverallCpuUsage
-	memoryUsage = vmMo.Summary.QuickStats.GuestMemoryUsage
-	uptimeSeconds = int64(vmMo.Summary.QuickStats.UptimeSeconds)
-
 	// Boot time
 	bootTime := ""
 	if vmMo.Runtime.BootTime != nil {
 		bootTime = vmMo.Runtime.BootTime.Format("2006-01-02T15:04:05Z")
 	}
 
-	// Create comprehensive provider raw JSON with detailed VM info
-	providerRawJson := fmt.Sprintf(`{
-		"vm_id": "%s",
-		"name": "%s",
-		"power_state": "%s",
-		"connection_state": "%s",
-		"primary_ip": "%s",
-		"hostname": "%s",
-		"guest_os": "%s",
-		"tools_status": "%s",
-		"tools_version": "%s",
-		"cpu_count": %d,
-		"memory_mb": %d,
-		"cpu_usage_mhz": %d,
-		"memory_usage_mb": %d,
-		"uptime_seconds": %d,
-		"boot_time": "%s"
-	}`, req.Id,
-		vmMo.Summary.Config.Name,
-		powerState,
-		connectionState,
-		primaryIP,
-		hostname,
-		guestOS,
-		toolsStatus,
-		toolsVersion,
-		cpuCount,
-		memoryMB,
-		cpuUsage,
-		memoryUsage,
-		uptimeSeconds,
-		bootTime)
+	host := ""
+	if ref := vmMo.Summary.Runtime.Host; ref != nil {
+		host = hosts[ref.Value]
+	}
+
+	stats := guestStats(vmMo.Runtime.PowerState, vmMo.Summary.Runtime.MaxCpuUsage, vmMo.Summary.QuickStats)
+	details := &describe.Details{
+		Host: host,
+		Guest: &describe.GuestInfo{
+			Hostname:     hostname,
+			OSName:       guestOS,
+			ToolsStatus:  toolsStatus,
+			ToolsVersion: toolsVersion,
+		},
+		// Summary.Config and Summary.QuickStats are structs, not pointers
+		Resources:  describe.NewResourceStats(vmMo.Summary.Config.NumCpu, int64(vmMo.Summary.Config.MemorySizeMB)<<20, stats),
+		Interfaces: describe.InterfacesFromAddresses(addresses, nicMACs(vmMo)),
+		Snapshots:  snapshotStats(vmMo.Snapshot),
+		BusyReason: busy[id],
+		Extras: map[string]string{
+			"vm_id":            id,
+			"name":             vmMo.Summary.Config.Name,
+			"power_state":      powerState,
+			"connection_state": connectionState,
+			"primary_ip":       primaryIP,
+			"boot_time":        bootTime,
+		},
+	}
+	if *details.Guest == (describe.GuestInfo{}) {
+		details.Guest = nil
+	}
+	providerRawJson, err := details.Marshal()
+	if err != nil {
+		p.logger.Error("Failed to encode VM details", "vm_id", id, "error", err)
+	}
 
 	// Generate console URL for vSphere web client
 	consoleURL := ""
@@ -1316,7 +1537,7 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		// Simpler format that works for most vSphere versions
 		consoleURL = fmt.Sprintf("%s/ui/#?extensionId=vsphere.core.vm.summary&objectId=urn:vmomi:VirtualMachine:%s:%s",
 			strings.TrimSuffix(p.config.Endpoint, "/sdk"),
-			req.Id,
+			id,
 			vmMo.Summary.Config.InstanceUuid)
 	}
 
@@ -1324,9 +1545,63 @@ func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest
 		Exists:          true,
 		PowerState:      powerState,
 		Ips:             ips,
+		Addresses:       addresses,
 		ConsoleUrl:      consoleURL,
 		ProviderRawJson: providerRawJson,
-	}, nil
+		GuestStats:      stats,
+		Identity:        recordedIdempotencyKey(vmMo),
+	}
+}
+
+// DescribeBatch implements the ProviderServer interface. It retrieves
+// describeProperties for all requested VMs with a single PropertyCollector
+// call instead of one per VM. vCenter fails the whole retrieval when any
+// reference no longer exists, so in that case each VM is described on its
+// own, which reports the missing ones with Exists: false.
+func (p *Provider) DescribeBatch(ctx context.Context, req *providerv1.DescribeBatchRequest) (*providerv1.DescribeBatchResponse, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("vSphere client not configured")
+	}
+
+	resp := &providerv1.DescribeBatchResponse{
+		Results: make(map[string]*providerv1.DescribeResponse, len(req.Ids)),
+	}
+	refs := make([]types.ManagedObjectReference, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		refs = append(refs, types.ManagedObjectReference{Type: "VirtualMachine", Value: id})
+	}
+	if len(refs) == 0 {
+		return resp, nil
+	}
+
+	var vms []mo.VirtualMachine
+	pc := property.DefaultCollector(p.client.Client)
+	if err := pc.Retrieve(ctx, refs, describeProperties, &vms); err != nil {
+		p.logger.Debug("Batch property retrieval failed, describing VMs one by one", "count", len(refs), "error", err)
+		for _, id := range req.Ids {
+			desc, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+			if err != nil {
+				if resp.Errors == nil {
+					resp.Errors = map[string]string{}
+				}
+				resp.Errors[id] = err.Error()
+				continue
+			}
+			resp.Results[id] = desc
+		}
+		return resp, nil
+	}
+
+	hosts, busy := hostNames(ctx, pc, vms), busyReasons(ctx, pc, vms)
+	for i := range vms {
+		resp.Results[vms[i].Self.Value] = p.describeResponse(vms[i].Self.Value, &vms[i], hosts, busy)
+	}
+	for _, id := range req.Ids {
+		if _, ok := resp.Results[id]; !ok {
+			resp.Results[id] = &providerv1.DescribeResponse{Exists: false}
+		}
+	}
+	return resp, nil
 }
 
 // contains reports whether item is present in slice using a linear search.
@@ -1375,21 +1650,79 @@ func (p *Provider) mapVSpherePowerState(vspherePowerState string) string {
 	}
 }
 
+// hasCloudInit reports whether the VM is given any cloud-init document.
+func (spec *VMSpec) hasCloudInit() bool {
+	return spec.CloudInit != "" || spec.CloudInitNetworkConfig != "" || spec.CloudInitVendorData != ""
+}
+
+// addCloudInitDocuments adds all of spec's cloud-init documents to configSpec.
+// The VMware datasource has no guestinfo key of its own for network-config:
+// it reads it from the "network" key of the metadata, so the network-config
+// is embedded there. Vendor-data goes to guestinfo.vendordata.
+func (p *Provider) addCloudInitDocuments(configSpec *types.VirtualMachineConfigSpec, spec *VMSpec) error {
+	metaData, err := cloudInitMetaDataWithNetwork(spec.CloudInitMetaData, spec.CloudInitNetworkConfig, configSpec.Name)
+	if err != nil {
+		return err
+	}
+	if metaData == "" && spec.CloudInitVendorData != "" {
+		// The datasource only looks further once it finds metadata.
+		metaData = `instance-id: "` + configSpec.Name + `"`
+	}
+	if err := p.addCloudInitToConfigSpec(configSpec, spec.CloudInit, metaData); err != nil {
+		return err
+	}
+	if spec.CloudInitVendorData != "" {
+		options, err := guestInfoOptions("guestinfo.vendordata", spec.CloudInitVendorData)
+		if err != nil {
+			return err
+		}
+		configSpec.ExtraConfig = append(configSpec.ExtraConfig, options...)
+	}
+	return nil
+}
+
+// cloudInitMetaDataWithNetwork returns metaData with networkConfig set as its
+// base64-encoded "network" key. Without metaData it starts from the same
+// instance-id fallback addCloudInitToConfigSpec uses. metaData is returned
+// unchanged when there is no networkConfig.
+func cloudInitMetaDataWithNetwork(metaData, networkConfig, instanceID string) (string, error) {
+	if networkConfig == "" {
+		return metaData, nil
+	}
+	doc := map[string]interface{}{}
+	if strings.TrimSpace(metaData) != "" {
+		if err := yaml.Unmarshal([]byte(metaData), &doc); err != nil {
+			return "", fmt.Errorf("cloud-init metadata is not a YAML or JSON mapping, so network-config cannot be added to it: %w", err)
+		}
+	}
+	if _, ok := doc["instance-id"]; !ok {
+		doc["instance-id"] = instanceID
+	}
+	doc["network"] = base64.StdEncoding.EncodeToString([]byte(networkConfig))
+	doc["network.encoding"] = "base64"
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("encode cloud-init metadata: %w", err)
+	}
+	return string(out), nil
+}
+
 // addCloudInitToConfigSpec injects cloud-init configuration into configSpec using the
 // VMware guestinfo mechanism, which is the standard way to pass cloud-init data to VMs
-// without a CD-ROM or network datasource. Both values are base64-encoded and stored in
-// the VM's ExtraConfig alongside their encoding hint keys so that cloud-init inside the
+// without a CD-ROM or network datasource. Both values are stored in the VM's
+// ExtraConfig alongside their encoding hint keys so that cloud-init inside the
 // guest can locate and decode them at boot:
 //
-//   - guestinfo.userdata        → base64(cloudInitData)
-//   - guestinfo.userdata.encoding → "base64"
-//   - guestinfo.metadata        → base64(cloudInitMetaData or fallback)
-//   - guestinfo.metadata.encoding → "base64"
+//   - guestinfo.userdata        → base64(cloudInitData), gzipped first when large
+//   - guestinfo.userdata.encoding → "base64" or "gzip+base64"
+//   - guestinfo.metadata        → base64(cloudInitMetaData or fallback), likewise
+//   - guestinfo.metadata.encoding → "base64" or "gzip+base64"
 //
 // If cloudInitMetaData is empty, a minimal metadata document containing only
 // "instance-id: <vmName>" is used as a fallback. If both cloudInitData and
 // cloudInitMetaData are empty the method returns nil immediately without modifying
-// configSpec. New ExtraConfig entries are appended to any existing entries.
+// configSpec. New ExtraConfig entries are appended to any existing entries. A
+// document too large for guestinfo is an InvalidSpec error.
 func (p *Provider) addCloudInitToConfigSpec(configSpec *types.VirtualMachineConfigSpec, cloudInitData string, cloudInitMetaData string) error {
 	// Defensive: return early if both userdata and metadata are empty
 	if cloudInitData == "" && cloudInitMetaData == "" {
@@ -1397,51 +1730,29 @@ func (p *Provider) addCloudInitToConfigSpec(configSpec *types.VirtualMachineConf
 		return nil
 	}
 
+	var extraConfig []types.BaseOptionValue
+
 	// Defensive: if only userdata is empty, skip adding it
-	var encodedUserData string
 	if cloudInitData != "" {
-		p.logger.Debug("Adding cloudInitData to VM config", "vm_id", configSpec.Name)
-		encodedUserData = base64.StdEncoding.EncodeToString([]byte(cloudInitData))
+		p.logger.Debug("Adding cloudInitData to VM config", "vm_id", configSpec.Name, "bytes", len(cloudInitData))
+		options, err := guestInfoOptions("guestinfo.userdata", cloudInitData)
+		if err != nil {
+			return err
+		}
+		extraConfig = append(extraConfig, options...)
 	}
 
-	// Defensive: if only metadata is empty, skip adding it
-	var metadataValue string
-	if cloudInitMetaData != "" {
-		metadataValue = cloudInitMetaData
-	} else {
+	// Defensive: if only metadata is empty, fall back to the instance-id
+	metadataValue := cloudInitMetaData
+	if metadataValue == "" {
 		metadataValue = `instance-id: "` + configSpec.Name + `"`
 	}
-	var encodedMetaData string
-	if metadataValue != "" {
-		p.logger.Debug("Adding metadata to VM config", "vm_id", configSpec.Name)
-		encodedMetaData = base64.StdEncoding.EncodeToString([]byte(metadataValue))
-	}
-
-	var extraConfig []types.BaseOptionValue
-	if encodedUserData != "" {
-		extraConfig = append(extraConfig, &types.OptionValue{
-			Key:   "guestinfo.userdata",
-			Value: encodedUserData,
-		})
-		extraConfig = append(extraConfig, &types.OptionValue{
-			Key:   "guestinfo.userdata.encoding",
-			Value: "base64",
-		})
-	}
-	if encodedMetaData != "" {
-		extraConfig = append(extraConfig, &types.OptionValue{
-			Key:   "guestinfo.metadata",
-			Value: encodedMetaData,
-		})
-		extraConfig = append(extraConfig, &types.OptionValue{
-			Key:   "guestinfo.metadata.encoding",
-			Value: "base64",
-		})
-	}
-
-	if len(extraConfig) == 0 {
-		return nil
+	p.logger.Debug("Adding metadata to VM config", "vm_id", configSpec.Name)
+	options, err := guestInfoOptions("guestinfo.metadata", metadataValue)
+	if err != nil {
+		return err
 	}
+	extraConfig = append(extraConfig, options...)
 
 	// Add to existing extra config or create new
 	if configSpec.ExtraConfig != nil {
@@ -1546,8 +1857,9 @@ func (p *Provider) TaskStatus(ctx context.Context, req *providerv1.TaskStatusReq
 // GetCapabilities reports SupportsMemorySnapshots: true; capturing memory requires the
 // VM to be powered on (vSphere rejects memory snapshots of a powered-off VM).
 //
-// Quiesce: filesystem quiescing (which requires VMware Tools and guest coordination) is
-// always disabled in this implementation. The SnapshotCreateResponse.SnapshotId contains
+// Quiesce: req.Quiesce freezes the guest's filesystems through VMware Tools, as decided by
+// snapshotQuiesce. A quiesced snapshot that fails is retried unquiesced when
+// req.AllowUnquiesced is set. The SnapshotCreateResponse.SnapshotId contains
 // the ManagedObjectReference value of the newly created VirtualMachineSnapshot object,
 // which is used in subsequent SnapshotDelete and SnapshotRevert calls.
 func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotCreateRequest) (*providerv1.SnapshotCreateResponse, error) {
@@ -1584,9 +1896,11 @@ func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotC
 	// Description defaults to empty string if not provided
 	description := req.Description
 
-	// Quiesce filesystem (false by default, requires VMware Tools)
-	// TODO: Make this configurable via API when proto is updated
-	quiesce := false
+	// Quiesce filesystem (requires VMware Tools)
+	quiesce, quiesceOutcome, quiesceMessage, err := p.snapshotQuiesce(ctx, vm, req)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the snapshot
 	// Parameters: name, description, includeMemory, quiesce
@@ -1598,13 +1912,23 @@ func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotC
 
 	task, err := vm.CreateSnapshot(ctx, snapshotName, description, req.IncludeMemory, quiesce)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create snapshot task: %w", err)
+		return nil, vmTaskFailure("failed to create snapshot task", err)
 	}
 
 	// Wait for snapshot creation to complete
 	taskInfo, err := task.WaitForResult(ctx, nil)
+	if err != nil && quiesce && req.AllowUnquiesced {
+		quiesceOutcome = providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED
+		quiesceMessage = fmt.Sprintf("the quiesced snapshot failed: %v", err)
+		p.logger.Warn("Quiesced snapshot failed; taking a crash-consistent one", "vm_id", req.VmId, "error", err)
+		task, err = vm.CreateSnapshot(ctx, snapshotName, description, req.IncludeMemory, false)
+		if err != nil {
+			return nil, vmTaskFailure("failed to create snapshot task", err)
+		}
+		taskInfo, err = task.WaitForResult(ctx, nil)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("snapshot creation failed: %w", err)
+		return nil, vmTaskFailure("snapshot creation failed", err)
 	}
 
 	// Extract snapshot reference from task result
@@ -1622,9 +1946,13 @@ func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotC
 		"snapshot_id", snapshotRef.Value,
 		"snapshot_name", snapshotName)
 
-	return &providerv1.SnapshotCreateResponse{
-		SnapshotId: snapshotRef.Value,
-	}, nil
+	resp := &providerv1.SnapshotCreateResponse{
+		SnapshotId:     snapshotRef.Value,
+		Quiesce:        quiesceOutcome,
+		QuiesceMessage: quiesceMessage,
+	}
+	p.describeNewSnapshot(ctx, vm, resp)
+	return resp, nil
 }
 
 // SnapshotDelete implements the ProviderServer interface. It removes the snapshot
@@ -1682,13 +2010,13 @@ func (p *Provider) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotD
 
 	task, err := vm.RemoveSnapshot(ctx, snapshot.Snapshot.Value, removeChildren, &consolidate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start snapshot removal: %w", err)
+		return nil, vmTaskFailure("failed to start snapshot removal", err)
 	}
 
 	// Wait for snapshot deletion to complete
 	_, err = task.WaitForResult(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("snapshot deletion failed: %w", err)
+		return nil, vmTaskFailure("snapshot deletion failed", err)
 	}
 
 	p.logger.Info("Snapshot deleted successfully", "vm_id", req.VmId, "snapshot_id", req.SnapshotId)
@@ -1754,13 +2082,13 @@ func (p *Provider) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotR
 
 	task, err := vm.RevertToSnapshot(ctx, snapshot.Snapshot.Value, suppressPowerOn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start snapshot revert: %w", err)
+		return nil, vmTaskFailure("failed to start snapshot revert", err)
 	}
 
 	// Wait for snapshot revert to complete
 	_, err = task.WaitForResult(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("snapshot revert failed: %w", err)
+		return nil, vmTaskFailure("snapshot revert failed", err)
 	}
 
 	p.logger.Info("Snapshot revert completed successfully",
@@ -1814,11 +2142,23 @@ func (p *Provider) findSnapshotByID(snapshots []types.VirtualMachineSnapshotTree
 // back to the datacenter's default VM folder if the configured folder path is not found.
 // The cloned VM is left powered off. The returned CloneResponse.TargetVmId contains
 // the ManagedObjectReference value of the new VM.
+//
+// A customization (req.CustomizationJson) is applied to the powered-off clone as
+// a CustomizationSpec. When it fails the clone is kept and the failure is
+// returned as CloneResponse.CustomizationError.
 func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*providerv1.CloneResponse, error) {
 	if p.client == nil {
 		return nil, fmt.Errorf("vSphere client not configured")
 	}
 
+	var customization *contracts.CloneCustomization
+	if req.CustomizationJson != "" {
+		customization = &contracts.CloneCustomization{}
+		if err := contracts.UnmarshalPayload([]byte(req.CustomizationJson), customization); err != nil {
+			return nil, fmt.Errorf("failed to parse customization JSON: %w", err)
+		}
+	}
+
 	p.logger.Info("Cloning virtual machine", "source_vm_id", req.SourceVmId, "target_name", req.TargetName, "linked", req.Linked)
 
 	// Set datacenter context for finder
@@ -1955,10 +2295,20 @@ func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*pr
 
 	p.logger.Info("Virtual machine cloned successfully", "source_vm_id", req.SourceVmId, "target_vm_id", targetVMID, "target_name", req.TargetName)
 
-	return &providerv1.CloneResponse{
+	resp := &providerv1.CloneResponse{
 		TargetVmId: targetVMID,
 		// No task reference since we completed synchronously
-	}, nil
+	}
+	if customization != nil {
+		targetVM := object.NewVirtualMachine(p.client.Client, targetVMRef)
+		if err := p.customizeClone(ctx, targetVM, req.TargetName, customization); err != nil {
+			p.logger.Warn("Cloned VM could not be customized", "target_vm_id", targetVMID, "error", err)
+			resp.CustomizationError = err.Error()
+		} else {
+			resp.CustomizationApplied = true
+		}
+	}
+	return resp, nil
 }
 
 // ImagePrepare is implemented in image.go: it imports an OVA/OVF from a URL into
@@ -1973,9 +2323,11 @@ type VMSpec struct {
 	DiskSizeGB   int64
 	DiskType     string
 	TemplateName string
-	DiskPath     string // Path to existing disk (for imported disks)
-	DiskFormat   string // Format of existing disk (for imported disks)
-	NetworkName  string
+	// ContentLibrary is the library item to deploy when TemplateName is empty
+	ContentLibrary *contracts.ContentLibraryItem
+	DiskPath       string // Path to existing disk (for imported disks)
+	DiskFormat     string // Format of existing disk (for imported disks)
+	NetworkName    string
 	// Static network configuration applied via guestinfo.network.* keys.
 	// Populated from the first entry of NetworksJson (VirtualMachine.spec.networks[0]).
 	// A guest-side script (baked into the template) reads these at boot to assign a
@@ -1988,6 +2340,9 @@ type VMSpec struct {
 	HardwareVersion             *int32 // VM hardware compatibility version
 	CloudInit                   string // Cloud-init user data
 	CloudInitMetaData           string // Cloud-init metadata
+	CloudInitNetworkConfig      string // Cloud-init network-config
+	CloudInitVendorData         string // Cloud-init vendor-data
+	SysprepUnattend             string // Windows unattend.xml; replaces cloud-init when set
 	NestedVirtualization        bool   // Enable nested virtualization
 	VirtualizationBasedSecurity bool   // Enable VBS features
 	CPUHotAddEnabled            bool   // Enable CPU hot-add
@@ -2003,6 +2358,9 @@ type VMSpec struct {
 	StoragePod string // Datastore Cluster override (empty = use provider default; ignored when Datastore is set)
 	Folder     string // Folder override (empty = use provider default)
 	Host       string // Host override (empty = use provider default)
+	// IdempotencyKey is recorded in the VM's extraConfig so a retried
+	// Create can tell its own VM from another one with the same name.
+	IdempotencyKey string
 }
 
 // AdditionalDiskSpec defines an additional disk to attach to a VM
@@ -2023,13 +2381,16 @@ type AdditionalDiskSpec struct {
 //     VBS, CPU/memory hot-add), SecurityProfile (SecureBoot, TPM, VT-d).
 //   - req.ImageJson  — contracts.VMImage: TemplateName (for template clones) or Path +
 //     Format (for imported-disk VMs). When Path is non-empty, disk-based creation is
-//     used and TemplateName is ignored.
+//     used and TemplateName is ignored. ContentLibrary is used only when neither
+//     is set.
 //   - req.NetworksJson — []contracts.NetworkAttachment: the first element's
 //     NetworkName attaches a single network adapter, and its StaticIP/Prefix/
 //     Gateway/DNS are surfaced to the guest as guestinfo.network.* for static IP
 //     assignment (see createVirtualMachine).
 //   - req.UserData    — raw cloud-init user-data bytes.
 //   - req.MetaData    — raw cloud-init metadata bytes.
+//   - req.NetworkConfig, req.VendorData — raw cloud-init network-config and
+//     vendor-data bytes.
 //   - req.PlacementJson — contracts.Placement: optional per-VM overrides for Cluster,
 //     Datastore, StoragePod, Folder, and Host.
 //   - req.DisksJson — []contracts.DiskSpec: additional disks to attach beyond the root disk.
@@ -2044,34 +2405,14 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 		"placementJsonLength", len(req.PlacementJson))
 
 	spec := &VMSpec{
-		Name: req.Name,
+		Name:           req.Name,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
 	// Parse VMClass from JSON (contracts.VMClass structure)
 	if req.ClassJson != "" {
-		var vmClass struct {
-			CPU          int32             `json:"CPU"`
-			MemoryMiB    int32             `json:"MemoryMiB"`
-			Firmware     string            `json:"Firmware"`
-			ExtraConfig  map[string]string `json:"ExtraConfig"`
-			DiskDefaults *struct {
-				Type    string `json:"Type"`
-				SizeGiB int32  `json:"SizeGiB"`
-			} `json:"DiskDefaults"`
-			PerformanceProfile *struct {
-				NestedVirtualization        bool `json:"NestedVirtualization"`
-				VirtualizationBasedSecurity bool `json:"VirtualizationBasedSecurity"`
-				CPUHotAddEnabled            bool `json:"CPUHotAddEnabled"`
-				MemoryHotAddEnabled         bool `json:"MemoryHotAddEnabled"`
-			} `json:"PerformanceProfile"`
-			SecurityProfile *struct {
-				SecureBoot bool `json:"SecureBoot"`
-				TPMEnabled bool `json:"TPMEnabled"`
-				VTDEnabled bool `json:"VTDEnabled"`
-			} `json:"SecurityProfile"`
-		}
-
-		if err := json.Unmarshal([]byte(req.ClassJson), &vmClass); err != nil {
+		var vmClass contracts.VMClass
+		if err := contracts.UnmarshalPayload([]byte(req.ClassJson), &vmClass); err != nil {
 			return nil, fmt.Errorf("failed to parse VMClass JSON: %w", err)
 		}
 
@@ -2111,17 +2452,18 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 			spec.TPMEnabled = vmClass.SecurityProfile.TPMEnabled
 			spec.VTDEnabled = vmClass.SecurityProfile.VTDEnabled
 		}
+
+		// UEFI-SecureBoot is UEFI firmware with Secure Boot on.
+		if strings.EqualFold(vmClass.Firmware, "UEFI-SecureBoot") {
+			spec.Firmware = "UEFI"
+			spec.SecureBoot = true
+		}
 	}
 
 	// Parse VMImage from JSON (contracts.VMImage structure)
 	if req.ImageJson != "" {
-		var vmImage struct {
-			TemplateName string `json:"TemplateName"`
-			Path         string `json:"Path"`
-			Format       string `json:"Format"`
-		}
-
-		if err := json.Unmarshal([]byte(req.ImageJson), &vmImage); err != nil {
+		var vmImage contracts.VMImage
+		if err := contracts.UnmarshalPayload([]byte(req.ImageJson), &vmImage); err != nil {
 			return nil, fmt.Errorf("failed to parse VMImage JSON: %w", err)
 		}
 
@@ -2135,6 +2477,9 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 		} else {
 			// Otherwise, it's a template-based VM
 			spec.TemplateName = vmImage.TemplateName
+			if spec.TemplateName == "" {
+				spec.ContentLibrary = vmImage.ContentLibrary
+			}
 		}
 	}
 
@@ -2144,15 +2489,8 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 	// via guestinfo.network.* in createVirtualMachine. Leaving StaticIP empty means
 	// DHCP / the template's existing configuration is used.
 	if req.NetworksJson != "" {
-		var networks []struct {
-			NetworkName string `json:"NetworkName"`
-			StaticIP    string `json:"StaticIP"`
-			Prefix      int32  `json:"Prefix"`
-			Gateway     string `json:"Gateway"`
-			DNS         string `json:"DNS"`
-		}
-
-		if err := json.Unmarshal([]byte(req.NetworksJson), &networks); err != nil {
+		var networks []contracts.NetworkAttachment
+		if err := contracts.UnmarshalPayload([]byte(req.NetworksJson), &networks); err != nil {
 			return nil, fmt.Errorf("failed to parse Networks JSON: %w", err)
 		}
 
@@ -2185,19 +2523,22 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 		spec.CloudInitMetaData = string(req.MetaData)
 	}
 
+	// Parse network-config and vendor-data
+	spec.CloudInitNetworkConfig = string(req.NetworkConfig)
+	spec.CloudInitVendorData = string(req.VendorData)
+
+	unattend, err := contracts.SysprepUnattendFromJSON(req.GuestCustomizationJson)
+	if err != nil {
+		return nil, err
+	}
+	spec.SysprepUnattend = unattend
+
 	// Parse Placement from JSON (contracts.Placement structure)
 	if req.PlacementJson != "" {
 		p.logger.Info("Parsing placement JSON", "json", req.PlacementJson, "vm_name", spec.Name)
 
-		var placement struct {
-			Cluster    string `json:"Cluster"`
-			Datastore  string `json:"Datastore"`
-			StoragePod string `json:"StoragePod"`
-			Folder     string `json:"Folder"`
-			Host       string `json:"Host"`
-		}
-
-		if err := json.Unmarshal([]byte(req.PlacementJson), &placement); err != nil {
+		var placement contracts.Placement
+		if err := contracts.UnmarshalPayload([]byte(req.PlacementJson), &placement); err != nil {
 			return nil, fmt.Errorf("failed to parse Placement JSON: %w", err)
 		}
 
@@ -2220,17 +2561,17 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 	// Parse Disks from JSON ([]contracts.DiskSpec structure)
 	if req.DisksJson != "" {
 		var disks []struct {
-			Name    string `json:"Name"`
-			SizeGiB int32  `json:"SizeGiB"`
-			Type    string `json:"Type"`
+			Name    string `json:"name"`
+			SizeGiB int32  `json:"sizeGiB"`
+			Type    string `json:"type"`
 			SCSI    *struct {
 				Controller     *int32 `json:"controller"`
 				SharedBus      string `json:"sharedBus"`
 				ControllerType string `json:"controllerType"`
-			} `json:"SCSI"`
+			} `json:"scsi"`
 		}
 
-		if err := json.Unmarshal([]byte(req.DisksJson), &disks); err != nil {
+		if err := contracts.UnmarshalPayload([]byte(req.DisksJson), &disks); err != nil {
 			return nil, fmt.Errorf("failed to parse Disks JSON: %w", err)
 		}
 
@@ -2277,10 +2618,13 @@ func (p *Provider) parseCreateRequest(req *providerv1.CreateRequest) (*VMSpec, e
 // VM creation path:
 //   - If spec.DiskPath is set: CreateVM_Task with an attached existing VMDK and an LSI
 //     Logic SCSI controller added to DeviceChange.
+//   - If spec.ContentLibrary is set: the library item is deployed and the VM is
+//     reconfigured with the class, network and cloud-init settings.
 //   - Otherwise: CloneVM_Task from the template named spec.TemplateName.
 //
-// In both cases, cloud-init data (if provided) is embedded via addCloudInitToConfigSpec
-// before the task is submitted so that guestinfo properties are set at creation time.
+// In every case, cloud-init data (if provided) is embedded via addCloudInitToConfigSpec
+// before the task is submitted so that guestinfo properties are set at creation time;
+// a deployed library item gets them in the reconfiguration that follows the deploy.
 // After the task completes, the primary disk is optionally grown via resizeVMDisk and
 // the VM is powered on.
 func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (string, error) {
@@ -2303,15 +2647,19 @@ func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (stri
 
 	// Find the template VM (only if not using an imported disk)
 	var template *object.VirtualMachine
-	if spec.DiskPath == "" {
+	switch {
+	case spec.DiskPath == "" && spec.TemplateName == "" && spec.ContentLibrary != nil:
+		p.logger.Info("Using content library item, skipping template lookup",
+			"library", spec.ContentLibrary.Library, "item", spec.ContentLibrary.Item)
+	case spec.DiskPath == "":
 		if spec.TemplateName == "" {
-			return "", fmt.Errorf("either templateName or diskPath must be specified")
+			return "", fmt.Errorf("one of templateName, contentLibrary or diskPath must be specified")
 		}
 		template, err = p.finder.VirtualMachine(ctx, spec.TemplateName)
 		if err != nil {
 			return "", fmt.Errorf("failed to find template VM '%s': %w", spec.TemplateName, err)
 		}
-	} else {
+	default:
 		// Using imported disk - skip template lookup
 		p.logger.Info("Using imported disk, skipping template lookup", "disk_path", spec.DiskPath, "disk_format", spec.DiskFormat)
 	}
@@ -2565,6 +2913,13 @@ func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (stri
 		}
 	}
 
+	if spec.IdempotencyKey != "" {
+		extraConfig = append(extraConfig, &types.OptionValue{
+			Key:   idempotencyKeyExtraConfig,
+			Value: spec.IdempotencyKey,
+		})
+	}
+
 	// Apply extra configuration if any
 	if len(extraConfig) > 0 {
 		configSpec.ExtraConfig = extraConfig
@@ -2646,8 +3001,12 @@ func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (stri
 
 		// Add cloud-init data via guestinfo properties if provided
 		// Note: Must be called AFTER setting Name for imported disk VMs
-		if spec.CloudInit != "" {
-			if err := p.addCloudInitToConfigSpec(configSpec, spec.CloudInit, spec.CloudInitMetaData); err != nil {
+		if spec.hasCloudInit() {
+			if err := p.addCloudInitDocuments(configSpec, spec); errors.IsInvalidSpec(err) {
+				// A document that cannot reach the guest would leave it
+				// unconfigured.
+				return "", err
+			} else if err != nil {
 				p.logger.Warn("Failed to add cloud-init configuration", "error", err)
 				// Continue without cloud-init rather than failing
 			} else {
@@ -2655,6 +3014,14 @@ func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (stri
 			}
 		}
 
+		if spec.SysprepUnattend != "" {
+			customization, err := p.templateSysprepCustomization(ctx, template, configSpec, spec)
+			if err != nil {
+				return "", err
+			}
+			cloneSpec.Customization = customization
+		}
+
 		// Create VM config spec with disk attachment
 		// Parse datastore path: [datastore] path/file.vmdk
 		diskBacking := &types.VirtualDiskFlatVer2BackingInfo{
@@ -2720,14 +3087,47 @@ func (p *Provider) createVirtualMachine(ctx context.Context, spec *VMSpec) (stri
 
 		vmID = vmRef.Value
 		p.logger.Info("Virtual machine created successfully with imported disk", "vm_id", vmID, "name", spec.Name)
+
+		if spec.SysprepUnattend != "" {
+			if err := p.customizeImportedVM(ctx, object.NewVirtualMachine(p.client.Client, vmRef), spec); err != nil {
+				return "", err
+			}
+		}
+	} else if spec.TemplateName == "" {
+		// Using a content library item - deploy it, then apply the class,
+		// network and cloud-init settings a clone would have carried
+		if spec.hasCloudInit() {
+			if err := p.addCloudInitDocuments(configSpec, spec); errors.IsInvalidSpec(err) {
+				return "", err
+			} else if err != nil {
+				p.logger.Warn("Failed to add cloud-init configuration", "error", err)
+			}
+		}
+
+		vmRef, err = p.deployLibraryItem(ctx, spec, resourcePool, datastore, folder)
+		if err != nil {
+			return "", err
+		}
+		vmID = vmRef.Value
+		deployed := object.NewVirtualMachine(p.client.Client, vmRef)
+
+		if err := p.configureDeployedVM(ctx, deployed, configSpec, spec); err != nil {
+			p.destroyDeployedVM(ctx, deployed, spec.Name)
+			return "", err
+		}
+		p.logger.Info("Virtual machine deployed successfully from content library", "vm_id", vmID, "name", spec.Name)
 	} else {
 		// Using template - clone from template
 		// Set VM name for template-based VMs (needed for cloud-init)
 		configSpec.Name = spec.Name
 
 		// Add cloud-init data via guestinfo properties if provided
-		if spec.CloudInit != "" {
-			if err := p.addCloudInitToConfigSpec(configSpec, spec.CloudInit, spec.CloudInitMetaData); err != nil {
+		if spec.hasCloudInit() {
+			if err := p.addCloudInitDocuments(configSpec, spec); errors.IsInvalidSpec(err) {
+				// A document that cannot reach the guest would leave it
+				// unconfigured.
+				return "", err
+			} else if err != nil {
 				p.logger.Warn("Failed to add cloud-init configuration", "error", err)
 				// Continue without cloud-init rather than failing
 			} else {
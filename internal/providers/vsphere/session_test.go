@@ -36,6 +36,8 @@ func newSimConfig(t *testing.T) (*Config, func()) {
 	t.Helper()
 	model := simulator.VPX()
 	require.NoError(t, model.Create())
+	// Serve the vAPI (REST) endpoints too, for the content library.
+	model.Service.RegisterEndpoints = true
 	server := model.Service.NewServer()
 
 	u := server.URL // includes embedded user:pass
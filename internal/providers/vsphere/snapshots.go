@@ -0,0 +1,235 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// snapshotSize is the storage one snapshot occupies. Nil fields are unknown.
+type snapshotSize struct {
+	size, consumed *int64
+}
+
+// SnapshotList implements the ProviderServer interface. It walks the VM's
+// snapshot tree, parents before children, and sizes each snapshot from the
+// VM's layoutEx.
+func (p *Provider) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("vSphere client not configured")
+	}
+
+	vm := object.NewVirtualMachine(p.client.Client, types.ManagedObjectReference{Type: "VirtualMachine", Value: req.VmId})
+	var vmObj mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"snapshot", "layoutEx"}, &vmObj); err != nil {
+		return nil, fmt.Errorf("failed to get VM snapshot properties: %w", err)
+	}
+
+	resp := &providerv1.SnapshotListResponse{}
+	if vmObj.Snapshot == nil {
+		return resp, nil
+	}
+	sizes := snapshotLayoutSizes(vmObj.LayoutEx)
+	var walk func(trees []types.VirtualMachineSnapshotTree, parent string)
+	walk = func(trees []types.VirtualMachineSnapshotTree, parent string) {
+		for _, tree := range trees {
+			size := sizes[tree.Snapshot.Value]
+			resp.Snapshots = append(resp.Snapshots, &providerv1.SnapshotInfo{
+				Id:            tree.Snapshot.Value,
+				Name:          tree.Name,
+				CreatedUnix:   tree.CreateTime.Unix(),
+				Description:   tree.Description,
+				HasMemory:     tree.State == types.VirtualMachinePowerStatePoweredOn,
+				ParentId:      parent,
+				SizeBytes:     size.size,
+				ConsumedBytes: size.consumed,
+			})
+			walk(tree.ChildSnapshotList, tree.Snapshot.Value)
+		}
+	}
+	walk(vmObj.Snapshot.RootSnapshotList, "")
+	return resp, nil
+}
+
+// snapshotStats counts the snapshots of info, a VM's snapshot property,
+// which is nil for a VM without snapshots. Each snapshot is a delta disk
+// of its parent, so the chain depth is how many deltas the VM reads
+// through.
+func snapshotStats(info *types.VirtualMachineSnapshotInfo) *describe.SnapshotStats {
+	parents := map[string]string{}
+	current := ""
+	if info != nil {
+		var walk func(trees []types.VirtualMachineSnapshotTree, parent string)
+		walk = func(trees []types.VirtualMachineSnapshotTree, parent string) {
+			for _, tree := range trees {
+				parents[tree.Snapshot.Value] = parent
+				walk(tree.ChildSnapshotList, tree.Snapshot.Value)
+			}
+		}
+		walk(info.RootSnapshotList, "")
+		if info.CurrentSnapshot != nil {
+			current = info.CurrentSnapshot.Value
+		}
+	}
+	return describe.NewSnapshotStats(parents, current)
+}
+
+// snapshotQuiesce decides whether the snapshot requested by req is taken
+// quiesced, that is with the guest's filesystems frozen through VMware
+// Tools. A powered-off VM and a memory snapshot are not quiesced: the disks
+// of the one are at rest, and vSphere does not quiesce the other. When
+// Tools are not running the snapshot is refused with FailedPrecondition, or
+// taken crash-consistent and reported degraded if req.AllowUnquiesced is
+// set.
+func (p *Provider) snapshotQuiesce(ctx context.Context, vm *object.VirtualMachine, req *providerv1.SnapshotCreateRequest) (bool, providerv1.QuiesceOutcome, string, error) {
+	if !req.Quiesce {
+		return false, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "quiesce was not requested", nil
+	}
+	var vmObj mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"runtime.powerState", "guest.toolsRunningStatus"}, &vmObj); err != nil {
+		return false, 0, "", fmt.Errorf("failed to get VM power and tools state: %w", err)
+	}
+	switch {
+	case vmObj.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn:
+		return false, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "the VM is not powered on, so its disks are at rest", nil
+	case req.IncludeMemory:
+		return false, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, "the snapshot captures the running guest's memory", nil
+	case vmObj.Guest != nil && vmObj.Guest.ToolsRunningStatus == string(types.VirtualMachineToolsRunningStatusGuestToolsRunning):
+		return true, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED, "", nil
+	}
+	problem := "VMware Tools are not running in the guest"
+	if !req.AllowUnquiesced {
+		return false, 0, "", errors.NewFailedPrecondition("cannot quiesce VM %s: %s", req.VmId, problem)
+	}
+	p.logger.Warn("Taking a crash-consistent snapshot", "vm_id", req.VmId, "reason", problem)
+	return false, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, problem, nil
+}
+
+// describeNewSnapshot fills the creation time and size of a snapshot just
+// taken into resp. It is best effort; the controller picks up whatever is
+// missing from SnapshotList.
+func (p *Provider) describeNewSnapshot(ctx context.Context, vm *object.VirtualMachine, resp *providerv1.SnapshotCreateResponse) {
+	var vmObj mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"snapshot", "layoutEx"}, &vmObj); err != nil {
+		p.logger.Warn("Failed to read new snapshot details", "snapshot_id", resp.SnapshotId, "error", err)
+		return
+	}
+	if vmObj.Snapshot != nil {
+		if tree := p.findSnapshotByID(vmObj.Snapshot.RootSnapshotList, resp.SnapshotId); tree != nil {
+			resp.CreatedUnix = tree.CreateTime.Unix()
+		}
+	}
+	size := snapshotLayoutSizes(vmObj.LayoutEx)[resp.SnapshotId]
+	resp.SizeBytes, resp.ConsumedBytes = size.size, size.consumed
+}
+
+// snapshotLayoutSizes sizes every snapshot in layout, keyed by snapshot
+// reference value. A snapshot owns its .vmsn data file, its .vmem memory
+// file, and for each disk the delta created when it was taken: the chain
+// unit that follows the snapshot's own chain in the current disk chain or
+// in a later snapshot's chain. consumedBytes sums the files' uniqueSize and
+// is unknown when the datastore does not report it.
+func snapshotLayoutSizes(layout *types.VirtualMachineFileLayoutEx) map[string]snapshotSize {
+	if layout == nil {
+		return nil
+	}
+	files := make(map[int32]types.VirtualMachineFileLayoutExFileInfo, len(layout.File))
+	for _, f := range layout.File {
+		files[f.Key] = f
+	}
+
+	// Every known chain of each disk, the current one first.
+	chains := map[int32][][]types.VirtualMachineFileLayoutExDiskUnit{}
+	for _, d := range layout.Disk {
+		chains[d.Key] = append(chains[d.Key], d.Chain)
+	}
+	for _, s := range layout.Snapshot {
+		for _, d := range s.Disk {
+			chains[d.Key] = append(chains[d.Key], d.Chain)
+		}
+	}
+
+	sizes := make(map[string]snapshotSize, len(layout.Snapshot))
+	for _, s := range layout.Snapshot {
+		var keys []int32
+		if f, ok := files[s.DataKey]; ok && f.Type == string(types.VirtualMachineFileLayoutExFileTypeSnapshotData) {
+			keys = append(keys, s.DataKey)
+		}
+		if f, ok := files[s.MemoryKey]; ok && f.Type == string(types.VirtualMachineFileLayoutExFileTypeSnapshotMemory) {
+			keys = append(keys, s.MemoryKey)
+		}
+		for _, d := range s.Disk {
+			if next := nextChainUnit(d.Chain, chains[d.Key]); next != nil {
+				keys = append(keys, next.FileKey...)
+			}
+		}
+
+		var size, consumed int64
+		complete, unique := true, true
+		for _, key := range keys {
+			f, ok := files[key]
+			if !ok {
+				complete = false
+				break
+			}
+			size += f.Size
+			if f.UniqueSize <= 0 {
+				unique = false
+			}
+			consumed += f.UniqueSize
+		}
+		if !complete || size <= 0 {
+			continue
+		}
+		out := snapshotSize{size: &size}
+		if unique {
+			out.consumed = &consumed
+		}
+		sizes[s.Key.Value] = out
+	}
+	return sizes
+}
+
+// nextChainUnit returns the unit that extends chain in any of candidates.
+func nextChainUnit(chain []types.VirtualMachineFileLayoutExDiskUnit, candidates [][]types.VirtualMachineFileLayoutExDiskUnit) *types.VirtualMachineFileLayoutExDiskUnit {
+	for _, candidate := range candidates {
+		if len(candidate) <= len(chain) {
+			continue
+		}
+		prefix := true
+		for i := range chain {
+			if !slices.Equal(chain[i].FileKey, candidate[i].FileKey) {
+				prefix = false
+				break
+			}
+		}
+		if prefix {
+			return &candidate[len(chain)]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// TestSnapshotLayoutSizes sizes two chained snapshots of one disk: each
+// owns its .vmsn, its .vmem if any, and the delta taken with it.
+func TestSnapshotLayoutSizes(t *testing.T) {
+	unit := func(keys ...int32) types.VirtualMachineFileLayoutExDiskUnit {
+		return types.VirtualMachineFileLayoutExDiskUnit{FileKey: keys}
+	}
+	base, delta1, delta2 := unit(10, 11), unit(20, 21), unit(30, 31)
+	layout := &types.VirtualMachineFileLayoutEx{
+		File: []types.VirtualMachineFileLayoutExFileInfo{
+			{Key: 0, Type: "config", Size: 4 << 10, UniqueSize: 4 << 10},
+			{Key: 1, Type: "snapshotData", Size: 1 << 20, UniqueSize: 1 << 20},
+			{Key: 2, Type: "snapshotMemory", Size: 4 << 30, UniqueSize: 4 << 30},
+			{Key: 3, Type: "snapshotData", Size: 1 << 20, UniqueSize: 1 << 20},
+			{Key: 10, Type: "diskDescriptor", Size: 1 << 10, UniqueSize: 1 << 10},
+			{Key: 11, Type: "diskExtent", Size: 40 << 30, UniqueSize: 12 << 30},
+			{Key: 20, Type: "diskDescriptor", Size: 1 << 10, UniqueSize: 1 << 10},
+			{Key: 21, Type: "diskExtent", Size: 3 << 30, UniqueSize: 2 << 30},
+			{Key: 30, Type: "diskDescriptor", Size: 1 << 10},
+			{Key: 31, Type: "diskExtent", Size: 500 << 20},
+		},
+		Disk: []types.VirtualMachineFileLayoutExDiskLayout{
+			{Key: 2000, Chain: []types.VirtualMachineFileLayoutExDiskUnit{base, delta1, delta2}},
+		},
+		Snapshot: []types.VirtualMachineFileLayoutExSnapshotLayout{
+			{
+				Key:     types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-1"},
+				DataKey: 1, MemoryKey: 2,
+				Disk: []types.VirtualMachineFileLayoutExDiskLayout{{Key: 2000, Chain: []types.VirtualMachineFileLayoutExDiskUnit{base}}},
+			},
+			{
+				// MemoryKey 0 is the unset value, not the config file.
+				Key:     types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-2"},
+				DataKey: 3,
+				Disk:    []types.VirtualMachineFileLayoutExDiskLayout{{Key: 2000, Chain: []types.VirtualMachineFileLayoutExDiskUnit{base, delta1}}},
+			},
+		},
+	}
+
+	sizes := snapshotLayoutSizes(layout)
+
+	first := sizes["snapshot-1"]
+	require.NotNil(t, first.size)
+	require.NotNil(t, first.consumed)
+	assert.Equal(t, int64(1<<20+4<<30+1<<10+3<<30), *first.size)
+	assert.Equal(t, int64(1<<20+4<<30+1<<10+2<<30), *first.consumed)
+
+	second := sizes["snapshot-2"]
+	require.NotNil(t, second.size)
+	assert.Equal(t, int64(1<<20+1<<10+500<<20), *second.size)
+	assert.Nil(t, second.consumed, "uniqueSize is not reported for the current delta")
+
+	assert.Nil(t, snapshotLayoutSizes(nil))
+}
+
+// TestSnapshotList_Simulator lists a chain of snapshots taken through
+// SnapshotCreate, parents first.
+func TestSnapshotList_Simulator(t *testing.T) {
+	p, ids := newSimProvider(t)
+	ctx := context.Background()
+
+	resp, err := p.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: ids[0]})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Snapshots)
+
+	first, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "base", Description: "before upgrade"})
+	require.NoError(t, err)
+	assert.Positive(t, first.CreatedUnix)
+	second, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "upgrade"})
+	require.NoError(t, err)
+
+	resp, err = p.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: ids[0]})
+	require.NoError(t, err)
+	require.Len(t, resp.Snapshots, 2)
+	assert.Equal(t, first.SnapshotId, resp.Snapshots[0].Id)
+	assert.Equal(t, "base", resp.Snapshots[0].Name)
+	assert.Equal(t, "before upgrade", resp.Snapshots[0].Description)
+	assert.Equal(t, first.CreatedUnix, resp.Snapshots[0].CreatedUnix)
+	assert.Empty(t, resp.Snapshots[0].ParentId)
+	assert.Equal(t, second.SnapshotId, resp.Snapshots[1].Id)
+	assert.Equal(t, first.SnapshotId, resp.Snapshots[1].ParentId)
+}
+
+// TestDescribe_SnapshotStats_Simulator checks Describe counts the
+// snapshots taken through SnapshotCreate and follows the chain to the
+// current one.
+func TestDescribe_SnapshotStats_Simulator(t *testing.T) {
+	p, ids := newSimProvider(t)
+	ctx := context.Background()
+
+	stats := func() describe.SnapshotStats {
+		t.Helper()
+		resp, err := p.Describe(ctx, &providerv1.DescribeRequest{Id: ids[0]})
+		require.NoError(t, err)
+		details, err := describe.Parse(resp.ProviderRawJson)
+		require.NoError(t, err)
+		require.NotNil(t, details.Snapshots)
+		return *details.Snapshots
+	}
+	assert.Equal(t, describe.SnapshotStats{}, stats())
+
+	for _, name := range []string{"base", "upgrade", "nightly"} {
+		_, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: name})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, describe.SnapshotStats{Count: 3, ChainDepth: 3}, stats())
+}
+
+// TestSnapshotCreate_Quiesce_Simulator checks the quiesce outcome
+// SnapshotCreate reports for the simulator's powered-on VMs, which do not
+// run VMware Tools and so cannot be quiesced.
+func TestSnapshotCreate_Quiesce_Simulator(t *testing.T) {
+	p, ids := newSimProvider(t)
+	ctx := context.Background()
+
+	resp, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "plain"})
+	require.NoError(t, err)
+	assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, resp.Quiesce)
+
+	resp, err = p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "memory", Quiesce: true, IncludeMemory: true})
+	require.NoError(t, err)
+	assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED, resp.Quiesce)
+
+	_, err = p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "refused", Quiesce: true})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	resp, err = p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{VmId: ids[0], NameHint: "degraded", Quiesce: true, AllowUnquiesced: true})
+	require.NoError(t, err)
+	assert.Equal(t, providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED, resp.Quiesce)
+	assert.Contains(t, resp.QuiesceMessage, "VMware Tools")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// templateSysprepCustomization builds the CustomizationSpec for a clone of
+// template. vCenter requires one adapter mapping per NIC the clone ends up
+// with: the template's own plus any configSpec adds.
+func (p *Provider) templateSysprepCustomization(ctx context.Context, template *object.VirtualMachine, configSpec *types.VirtualMachineConfigSpec, spec *VMSpec) (*types.CustomizationSpec, error) {
+	devices, err := template.Device(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template devices for sysprep customization: %w", err)
+	}
+	nics := len(devices.SelectByType((*types.VirtualEthernetCard)(nil)))
+	for _, change := range configSpec.DeviceChange {
+		dc := change.GetVirtualDeviceConfigSpec()
+		if _, ok := dc.Device.(types.BaseVirtualEthernetCard); ok && dc.Operation == types.VirtualDeviceConfigSpecOperationAdd {
+			nics++
+		}
+	}
+	p.logger.Info("Applying sysprep customization to clone", "vm_name", spec.Name, "nics", nics)
+	return sysprepCustomizationSpec(spec, nics), nil
+}
+
+// customizeImportedVM runs sysprep customization on a VM created from an
+// imported disk, which has no clone spec to carry it. The VM must still be
+// powered off; Windows applies the answer file on first boot.
+func (p *Provider) customizeImportedVM(ctx context.Context, vm *object.VirtualMachine, spec *VMSpec) error {
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list VM devices for sysprep customization: %w", err)
+	}
+	nics := len(devices.SelectByType((*types.VirtualEthernetCard)(nil)))
+
+	p.logger.Info("Applying sysprep customization to imported VM", "vm_name", spec.Name, "nics", nics)
+	task, err := vm.Customize(ctx, *sysprepCustomizationSpec(spec, nics))
+	if err != nil {
+		return fmt.Errorf("failed to start sysprep customization: %w", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("sysprep customization failed: %w", err)
+	}
+	return nil
+}
+
+// sysprepCustomizationSpec hands spec.SysprepUnattend to guest customization
+// as a raw answer file and maps nics adapters. The first adapter takes the
+// static address from spec.networks[0] when one is set; every other adapter
+// uses DHCP.
+func sysprepCustomizationSpec(spec *VMSpec, nics int) *types.CustomizationSpec {
+	cs := &types.CustomizationSpec{
+		Identity:         &types.CustomizationSysprepText{Value: spec.SysprepUnattend},
+		GlobalIPSettings: types.CustomizationGlobalIPSettings{},
+		Options:          &types.CustomizationWinOptions{ChangeSID: true},
+	}
+	for i := 0; i < nics; i++ {
+		adapter := types.CustomizationIPSettings{Ip: &types.CustomizationDhcpIpGenerator{}}
+		if i == 0 && spec.StaticIP != "" {
+			adapter.Ip = &types.CustomizationFixedIp{IpAddress: spec.StaticIP}
+			if spec.Prefix > 0 {
+				adapter.SubnetMask = net.IP(net.CIDRMask(int(spec.Prefix), 32)).String()
+			}
+			if spec.Gateway != "" {
+				adapter.Gateway = []string{spec.Gateway}
+			}
+			if spec.DNS != "" {
+				for _, server := range strings.Split(spec.DNS, ",") {
+					if server = strings.TrimSpace(server); server != "" {
+						adapter.DnsServerList = append(adapter.DnsServerList, server)
+					}
+				}
+			}
+		}
+		cs.NicSettingMap = append(cs.NicSettingMap, types.CustomizationAdapterMapping{Adapter: adapter})
+	}
+	return cs
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/types"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+const testSysprepJSON = `{"Type":"Sysprep","Sysprep":{"UnattendXML":"<unattend/>"}}`
+
+func TestSysprepCustomizationSpec(t *testing.T) {
+	spec := &VMSpec{
+		SysprepUnattend: "<unattend/>",
+		StaticIP:        "10.0.0.5",
+		Prefix:          24,
+		Gateway:         "10.0.0.1",
+		DNS:             "10.0.0.2, 10.0.0.3",
+	}
+
+	cs := sysprepCustomizationSpec(spec, 2)
+
+	identity, ok := cs.Identity.(*types.CustomizationSysprepText)
+	require.True(t, ok, "identity must be the raw answer file")
+	assert.Equal(t, "<unattend/>", identity.Value)
+	require.Len(t, cs.NicSettingMap, 2)
+
+	first := cs.NicSettingMap[0].Adapter
+	fixed, ok := first.Ip.(*types.CustomizationFixedIp)
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.5", fixed.IpAddress)
+	assert.Equal(t, "255.255.255.0", first.SubnetMask)
+	assert.Equal(t, []string{"10.0.0.1"}, first.Gateway)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, first.DnsServerList)
+
+	_, ok = cs.NicSettingMap[1].Adapter.Ip.(*types.CustomizationDhcpIpGenerator)
+	assert.True(t, ok, "remaining adapters use DHCP")
+}
+
+func TestParseCreateRequest_Sysprep(t *testing.T) {
+	p := &Provider{logger: slog.Default()}
+
+	spec, err := p.parseCreateRequest(&providerv1.CreateRequest{Name: "win", GuestCustomizationJson: testSysprepJSON})
+	require.NoError(t, err)
+	assert.Equal(t, "<unattend/>", spec.SysprepUnattend)
+
+	_, err = p.parseCreateRequest(&providerv1.CreateRequest{Name: "win", GuestCustomizationJson: `{"Type":"Ignition"}`})
+	assert.Error(t, err)
+}
+
+func TestCreate_SysprepClone(t *testing.T) {
+	p, cleanup := newImageTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	resp, err := p.Create(ctx, &providerv1.CreateRequest{
+		Name:                   "win-vm",
+		ClassJson:              `{"CPU":1,"MemoryMiB":512}`,
+		ImageJson:              `{"TemplateName":"DC0_H0_VM0"}`,
+		GuestCustomizationJson: testSysprepJSON,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Id)
+}
+
+func TestGetCapabilities_Sysprep(t *testing.T) {
+	caps, err := (&Provider{}).GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	require.NoError(t, err)
+	assert.True(t, caps.SupportsSysprep)
+}
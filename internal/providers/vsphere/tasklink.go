@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// taskRef is the TaskRef returned for a vCenter task: its MoRef value, and
+// a link to the task in the vSphere Client.
+func (p *Provider) taskRef(task types.ManagedObjectReference) *providerv1.TaskRef {
+	return &providerv1.TaskRef{Id: task.Value, Link: p.taskLink(task.Value)}
+}
+
+// taskLink returns the vSphere Client URL of the task with MoRef value
+// moref. The scheme and host come from the configured endpoint; the object
+// ID pairs the MoRef with the vCenter's instance UUID, as the client's
+// urn:vmomi object IDs do. "" when either is unknown.
+func (p *Provider) taskLink(moref string) string {
+	if p.config == nil || p.client == nil || p.client.Client == nil || moref == "" {
+		return ""
+	}
+	base, err := url.Parse(p.config.Endpoint)
+	if err != nil || base.Host == "" || (base.Scheme != "https" && base.Scheme != "http") {
+		return ""
+	}
+	serverGUID := p.client.ServiceContent.About.InstanceUuid
+	if serverGUID == "" {
+		return ""
+	}
+	objectID := fmt.Sprintf("urn:vmomi:Task:%s:%s", moref, serverGUID)
+	return fmt.Sprintf("%s://%s/ui/#?extensionId=vsphere.core.inventory.serverObjectViewsExtension&objectId=%s",
+		base.Scheme, base.Host, url.QueryEscape(objectID))
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota measures what a namespace consumes and enforces its
+// VirtrigaudQuotas. The admission webhooks call Check for fast feedback,
+// and the controllers call it again, counting only older objects, right
+// before they act: two requests admitted at the same moment cannot both
+// slip under a limit, and the older one wins.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+)
+
+// Dimensions, named after the VirtrigaudQuota spec fields that limit them.
+const (
+	DimensionVMs            = "maxVMs"
+	DimensionSnapshotsPerVM = "maxSnapshotsPerVM"
+	DimensionTotalSnapshots = "maxTotalSnapshots"
+	DimensionClonesInFlight = "maxClonesInFlight"
+	DimensionTotalDiskGiB   = "maxTotalDiskGiB"
+)
+
+// Request is what an operation adds to a namespace's usage. Dimensions left
+// at zero are not checked, so an operation is never denied for a limit it
+// does not add to.
+type Request struct {
+	VMs       int32
+	DiskBytes int64
+	// Snapshots are taken of SnapshotVM, the VM name.
+	Snapshots  int32
+	SnapshotVM string
+	Clones     int32
+}
+
+// Usage is what a namespace consumes.
+type Usage struct {
+	VMs           int32
+	DiskBytes     int64
+	Snapshots     int32
+	SnapshotsByVM map[string]int32
+	Clones        int32
+}
+
+// Status renders u for VirtrigaudQuota status.
+func (u *Usage) Status() *infrav1beta1.VirtrigaudQuotaUsage {
+	s := &infrav1beta1.VirtrigaudQuotaUsage{
+		VMs:            u.VMs,
+		TotalSnapshots: u.Snapshots,
+		ClonesInFlight: u.Clones,
+		TotalDiskGiB:   usage.CeilGiB(u.DiskBytes),
+	}
+	for _, n := range u.SnapshotsByVM {
+		s.MostSnapshotsPerVM = max(s.MostSnapshotsPerVM, n)
+	}
+	return s
+}
+
+// Filter selects the objects Measure counts. A nil Filter counts all.
+type Filter func(client.Object) bool
+
+// CreatedBefore counts only objects created before obj, ordering objects
+// created in the same second by name. obj itself is never counted.
+func CreatedBefore(obj client.Object) Filter {
+	at := obj.GetCreationTimestamp()
+	return func(o client.Object) bool {
+		if o.GetUID() == obj.GetUID() {
+			return false
+		}
+		other := o.GetCreationTimestamp()
+		if !other.Equal(&at) {
+			return other.Before(&at)
+		}
+		return o.GetName() < obj.GetName()
+	}
+}
+
+// Measure totals the namespace's usage. Every VM counts, including ones
+// being deleted, as their disks stay allocated until the provider removes
+// them. Failed snapshots and finished or deleted clones do not count.
+func Measure(ctx context.Context, c client.Reader, namespace string, filter Filter) (*Usage, error) {
+	counted := func(o client.Object) bool { return filter == nil || filter(o) }
+	u := &Usage{SnapshotsByVM: map[string]int32{}}
+
+	vms := &infrav1beta1.VirtualMachineList{}
+	if err := c.List(ctx, vms, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list VirtualMachines: %w", err)
+	}
+	classes := map[client.ObjectKey]*infrav1beta1.VMClass{}
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		if !counted(vm) {
+			continue
+		}
+		class, err := cachedClass(ctx, c, vm, classes)
+		if err != nil {
+			return nil, err
+		}
+		u.VMs++
+		u.DiskBytes += usage.ResolveVMAllocation(vm, class).DiskBytes
+	}
+
+	snapshots := &infrav1beta1.VMSnapshotList{}
+	if err := c.List(ctx, snapshots, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list VMSnapshots: %w", err)
+	}
+	for i := range snapshots.Items {
+		s := &snapshots.Items[i]
+		if s.Status.Phase == infrav1beta1.SnapshotPhaseFailed || !counted(s) {
+			continue
+		}
+		u.Snapshots++
+		u.SnapshotsByVM[s.Spec.VMRef.Name]++
+	}
+
+	clones := &infrav1beta1.VMCloneList{}
+	if err := c.List(ctx, clones, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list VMClones: %w", err)
+	}
+	for i := range clones.Items {
+		clone := &clones.Items[i]
+		if !CloneInFlight(clone) || !counted(clone) {
+			continue
+		}
+		u.Clones++
+	}
+	return u, nil
+}
+
+// CloneInFlight reports whether clone counts against maxClonesInFlight.
+func CloneInFlight(clone *infrav1beta1.VMClone) bool {
+	if clone.DeletionTimestamp != nil {
+		return false
+	}
+	switch clone.Status.Phase {
+	case infrav1beta1.ClonePhaseReady, infrav1beta1.ClonePhaseFailed:
+		return false
+	}
+	return true
+}
+
+// VMRequest is what creating vm adds: one VM and its disk allocation.
+func VMRequest(ctx context.Context, c client.Reader, vm *infrav1beta1.VirtualMachine) (Request, error) {
+	class, err := cachedClass(ctx, c, vm, map[client.ObjectKey]*infrav1beta1.VMClass{})
+	if err != nil {
+		return Request{}, err
+	}
+	return Request{VMs: 1, DiskBytes: usage.ResolveVMAllocation(vm, class).DiskBytes}, nil
+}
+
+// VMUpdateRequest is what changing oldVM into vm adds: the growth of its
+// disk allocation, if any.
+func VMUpdateRequest(ctx context.Context, c client.Reader, oldVM, vm *infrav1beta1.VirtualMachine) (Request, error) {
+	classes := map[client.ObjectKey]*infrav1beta1.VMClass{}
+	oldClass, err := cachedClass(ctx, c, oldVM, classes)
+	if err != nil {
+		return Request{}, err
+	}
+	class, err := cachedClass(ctx, c, vm, classes)
+	if err != nil {
+		return Request{}, err
+	}
+	growth := usage.ResolveVMAllocation(vm, class).DiskBytes - usage.ResolveVMAllocation(oldVM, oldClass).DiskBytes
+	return Request{DiskBytes: max(growth, 0)}, nil
+}
+
+// CloneTargetRequest is what the target VM of a clone of sourceVM adds:
+// one VM and the default disk of its class, which is the clone's class
+// override or the source's class, resolved from the source's namespace.
+func CloneTargetRequest(ctx context.Context, c client.Reader, clone *infrav1beta1.VMClone,
+	sourceVM *infrav1beta1.VirtualMachine) (Request, error) {
+	classRef := sourceVM.Spec.ClassRef
+	if clone.Spec.Target.ClassRef != nil && clone.Spec.Target.ClassRef.Name != "" {
+		classRef = infrav1beta1.ObjectRef{Name: clone.Spec.Target.ClassRef.Name}
+	}
+	target := &infrav1beta1.VirtualMachine{}
+	target.Namespace = sourceVM.Namespace
+	target.Spec.ClassRef = classRef
+	return VMRequest(ctx, c, target)
+}
+
+// cachedClass returns the resolved VMClass of vm, fetching it once per key.
+// A missing class, or one whose inheritance cannot be resolved, contributes
+// no disk, as the VM cannot be created until it is fixed.
+func cachedClass(ctx context.Context, c client.Reader, vm *infrav1beta1.VirtualMachine,
+	classes map[client.ObjectKey]*infrav1beta1.VMClass) (*infrav1beta1.VMClass, error) {
+	key := k8sutil.RefKey(vm.Spec.ClassRef, vm.Namespace)
+	if class, seen := classes[key]; seen {
+		return class, nil
+	}
+	class, err := vmclass.Get(ctx, c, key)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil && !errors.Is(err, vmclass.ErrInheritance) {
+			return nil, fmt.Errorf("get VMClass %s: %w", key, err)
+		}
+		class = nil
+	}
+	classes[key] = class
+	return class, nil
+}
+
+// ExceededError is returned by Check when a request would take a namespace
+// past a limit. Its message names the quota and the limiting dimension.
+type ExceededError struct {
+	Quota     client.ObjectKey
+	Dimension string
+	// VM is the VM whose snapshots hit maxSnapshotsPerVM.
+	VM        string
+	Limit     int64
+	Used      int64
+	Requested int64
+}
+
+func (e *ExceededError) Error() string {
+	unit := ""
+	if e.Dimension == DimensionTotalDiskGiB {
+		unit = " GiB"
+	}
+	scope := ""
+	if e.VM != "" {
+		scope = fmt.Sprintf(" for VM %q", e.VM)
+	}
+	return fmt.Sprintf("exceeded VirtrigaudQuota %s: %s%s is %d%s, %d%s in use, %d%s requested",
+		e.Quota.Name, e.Dimension, scope, e.Limit, unit, e.Used, unit, e.Requested, unit)
+}
+
+// IsExceeded reports whether err is, or wraps, an ExceededError.
+func IsExceeded(err error) bool {
+	var exceeded *ExceededError
+	return errors.As(err, &exceeded)
+}
+
+// Check returns an ExceededError when adding req to the usage of namespace,
+// as counted by filter, would exceed a limit of any VirtrigaudQuota in the
+// namespace. A namespace without quotas is not measured.
+func Check(ctx context.Context, c client.Reader, namespace string, req Request, filter Filter) error {
+	quotas := &infrav1beta1.VirtrigaudQuotaList{}
+	if err := c.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("list VirtrigaudQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+	used, err := Measure(ctx, c, namespace, filter)
+	if err != nil {
+		return err
+	}
+	for i := range quotas.Items {
+		if err := exceeds(&quotas.Items[i], used, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exceeds checks req against the limits of q that it adds to.
+func exceeds(q *infrav1beta1.VirtrigaudQuota, used *Usage, req Request) error {
+	over := func(dimension string, limit *int32, used, requested int32) *ExceededError {
+		if requested <= 0 || limit == nil || used+requested <= *limit {
+			return nil
+		}
+		return &ExceededError{Quota: client.ObjectKeyFromObject(q), Dimension: dimension,
+			Limit: int64(*limit), Used: int64(used), Requested: int64(requested)}
+	}
+	spec := &q.Spec
+	if err := over(DimensionVMs, spec.MaxVMs, used.VMs, req.VMs); err != nil {
+		return err
+	}
+	if err := over(DimensionTotalSnapshots, spec.MaxTotalSnapshots, used.Snapshots, req.Snapshots); err != nil {
+		return err
+	}
+	if err := over(DimensionSnapshotsPerVM, spec.MaxSnapshotsPerVM, used.SnapshotsByVM[req.SnapshotVM], req.Snapshots); err != nil {
+		err.VM = req.SnapshotVM
+		return err
+	}
+	if err := over(DimensionClonesInFlight, spec.MaxClonesInFlight, used.Clones, req.Clones); err != nil {
+		return err
+	}
+	if limit := spec.MaxTotalDiskGiB; req.DiskBytes > 0 && limit != nil && used.DiskBytes+req.DiskBytes > *limit*usage.BytesPerGiB {
+		return &ExceededError{Quota: client.ObjectKeyFromObject(q), Dimension: DimensionTotalDiskGiB,
+			Limit: *limit, Used: usage.CeilGiB(used.DiskBytes), Requested: usage.CeilGiB(req.DiskBytes)}
+	}
+	return nil
+}
+
+// Exceeded lists the dimensions whose usage is above the limits of q.
+func Exceeded(q *infrav1beta1.VirtrigaudQuota, used *Usage) []string {
+	var dims []string
+	check := func(dimension string, limit *int32, used int32) {
+		if limit != nil && used > *limit {
+			dims = append(dims, dimension)
+		}
+	}
+	status := used.Status()
+	check(DimensionVMs, q.Spec.MaxVMs, used.VMs)
+	check(DimensionSnapshotsPerVM, q.Spec.MaxSnapshotsPerVM, status.MostSnapshotsPerVM)
+	check(DimensionTotalSnapshots, q.Spec.MaxTotalSnapshots, used.Snapshots)
+	check(DimensionClonesInFlight, q.Spec.MaxClonesInFlight, used.Clones)
+	if limit := q.Spec.MaxTotalDiskGiB; limit != nil && used.DiskBytes > *limit*usage.BytesPerGiB {
+		dims = append(dims, DimensionTotalDiskGiB)
+	}
+	return dims
+}
+
+// CauseTypeExceeded marks the cause of an admission denial issued for an
+// ExceededError, so a client creating objects on a tenant's behalf, such
+// as the VMClone controller, can tell a quota denial from other
+// rejections. The cause's field is the limiting dimension.
+const CauseTypeExceeded metav1.CauseType = "VirtrigaudQuotaExceeded"
+
+// AdmissionError converts an error from Check into the error a webhook
+// returns: Forbidden for an ExceededError, an internal error otherwise.
+func AdmissionError(gr schema.GroupResource, name string, err error) error {
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) {
+		return apierrors.NewInternalError(err)
+	}
+	denial := apierrors.NewForbidden(gr, name, exceeded)
+	denial.ErrStatus.Details.Causes = append(denial.ErrStatus.Details.Causes, metav1.StatusCause{
+		Type:    CauseTypeExceeded,
+		Message: exceeded.Error(),
+		Field:   exceeded.Dimension,
+	})
+	return denial
+}
+
+// IsDenied reports whether err is an API error for a request that
+// admission denied under a VirtrigaudQuota.
+func IsDenied(err error) bool {
+	if !apierrors.IsForbidden(err) {
+		return false
+	}
+	_, ok := apierrors.StatusCause(err, CauseTypeExceeded)
+	return ok
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/usage"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func meta(name string, age int) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: name, Namespace: "team-a", UID: types.UID(name),
+		CreationTimestamp: metav1.NewTime(epoch.Add(time.Duration(age) * time.Minute)),
+	}
+}
+
+func vm(name string, age int, diskGiB int32) *infrav1beta1.VirtualMachine {
+	v := &infrav1beta1.VirtualMachine{ObjectMeta: meta(name, age)}
+	v.Spec.ClassRef = infrav1beta1.ObjectRef{Name: "small"}
+	if diskGiB > 0 {
+		v.Spec.Disks = []infrav1beta1.DiskSpec{{Name: "data", SizeGiB: diskGiB}}
+	}
+	return v
+}
+
+func snapshot(name string, age int, vmName string, phase infrav1beta1.SnapshotPhase) *infrav1beta1.VMSnapshot {
+	s := &infrav1beta1.VMSnapshot{ObjectMeta: meta(name, age)}
+	s.Spec.VMRef = infrav1beta1.LocalObjectReference{Name: vmName}
+	s.Status.Phase = phase
+	return s
+}
+
+func clone(name string, age int, phase infrav1beta1.ClonePhase) *infrav1beta1.VMClone {
+	c := &infrav1beta1.VMClone{ObjectMeta: meta(name, age)}
+	c.Spec.Source.VMRef = &infrav1beta1.LocalObjectReference{Name: "web"}
+	c.Spec.Target.Name = name + "-vm"
+	c.Status.Phase = phase
+	return c
+}
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	class := &infrav1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "team-a"},
+		Spec: infrav1beta1.VMClassSpec{
+			CPU: 2, Memory: resource.MustParse("4Gi"),
+			DiskDefaults: &infrav1beta1.DiskDefaults{Size: resource.MustParse("20Gi")},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, class)...).Build()
+}
+
+func TestMeasure(t *testing.T) {
+	c := newClient(t,
+		vm("web", 0, 0), vm("db", 1, 100),
+		snapshot("s1", 2, "web", infrav1beta1.SnapshotPhaseReady),
+		snapshot("s2", 3, "web", ""),
+		snapshot("s3", 4, "db", infrav1beta1.SnapshotPhaseCreating),
+		snapshot("broken", 5, "db", infrav1beta1.SnapshotPhaseFailed),
+		clone("c1", 6, infrav1beta1.ClonePhaseCloning),
+		clone("c2", 7, ""),
+		clone("done", 8, infrav1beta1.ClonePhaseReady),
+		clone("failed", 9, infrav1beta1.ClonePhaseFailed),
+	)
+
+	used, err := Measure(context.Background(), c, "team-a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, &infrav1beta1.VirtrigaudQuotaUsage{
+		VMs: 2, MostSnapshotsPerVM: 2, TotalSnapshots: 3, ClonesInFlight: 2, TotalDiskGiB: 140,
+	}, used.Status())
+
+	// The disk figure is the one the allocation gauges use.
+	db := vm("db", 1, 100)
+	class := &infrav1beta1.VMClass{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "small"}, class))
+	assert.Equal(t, usage.ResolveVMAllocation(db, class).DiskBytes+usage.ResolveVMAllocation(vm("web", 0, 0), class).DiskBytes,
+		used.DiskBytes)
+
+	older, err := Measure(context.Background(), c, "team-a", CreatedBefore(snapshot("s3", 4, "db", "")))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), older.Snapshots, "only s1 and s2 are older than s3")
+	assert.Equal(t, int32(2), older.VMs)
+	assert.Zero(t, older.Clones)
+}
+
+func TestCreatedBeforeBreaksTiesByName(t *testing.T) {
+	a, b := snapshot("a", 0, "web", ""), snapshot("b", 0, "web", "")
+	assert.True(t, CreatedBefore(b)(a))
+	assert.False(t, CreatedBefore(a)(b))
+	assert.False(t, CreatedBefore(a)(a), "an object never counts against itself")
+}
+
+func TestCheck(t *testing.T) {
+	q := &infrav1beta1.VirtrigaudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "team-a"},
+		Spec: infrav1beta1.VirtrigaudQuotaSpec{
+			MaxVMs:            ptr.To[int32](2),
+			MaxSnapshotsPerVM: ptr.To[int32](2),
+			MaxTotalSnapshots: ptr.To[int32](3),
+			MaxClonesInFlight: ptr.To[int32](1),
+			MaxTotalDiskGiB:   ptr.To[int64](100),
+		},
+	}
+	c := newClient(t, q,
+		vm("web", 0, 0),
+		snapshot("s1", 1, "web", infrav1beta1.SnapshotPhaseReady),
+		snapshot("s2", 2, "web", infrav1beta1.SnapshotPhaseReady),
+		clone("c1", 3, infrav1beta1.ClonePhaseCloning),
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       Request
+		dimension string
+	}{
+		{name: "a VM that fits", req: Request{VMs: 1, DiskBytes: 20 * usage.BytesPerGiB}},
+		{name: "a VM past the disk limit", req: Request{VMs: 1, DiskBytes: 81 * usage.BytesPerGiB}, dimension: DimensionTotalDiskGiB},
+		{name: "two more VMs", req: Request{VMs: 2}, dimension: DimensionVMs},
+		{name: "a snapshot of another VM", req: Request{Snapshots: 1, SnapshotVM: "db"}},
+		{name: "a third snapshot of web", req: Request{Snapshots: 1, SnapshotVM: "web"}, dimension: DimensionSnapshotsPerVM},
+		{name: "two snapshots of another VM", req: Request{Snapshots: 2, SnapshotVM: "db"}, dimension: DimensionTotalSnapshots},
+		{name: "a second clone", req: Request{Clones: 1}, dimension: DimensionClonesInFlight},
+		{name: "nothing added", req: Request{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check(ctx, c, "team-a", tc.req, nil)
+			if tc.dimension == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var exceeded *ExceededError
+			require.True(t, errors.As(err, &exceeded), "got %v", err)
+			assert.Equal(t, tc.dimension, exceeded.Dimension)
+			assert.Contains(t, err.Error(), tc.dimension)
+			assert.Contains(t, err.Error(), "limits")
+		})
+	}
+
+	err := Check(ctx, c, "team-a", Request{Snapshots: 1, SnapshotVM: "web"}, nil)
+	assert.EqualError(t, err, `exceeded VirtrigaudQuota limits: maxSnapshotsPerVM for VM "web" is 2, 2 in use, 1 requested`)
+
+	assert.NoError(t, Check(ctx, c, "other", Request{VMs: 100}, nil), "a namespace without quotas is unlimited")
+}
+
+func TestAdmissionErrorMarksQuotaDenials(t *testing.T) {
+	gr := infrav1beta1.GroupVersion.WithResource("vmsnapshots").GroupResource()
+	denial := AdmissionError(gr, "s3", &ExceededError{Dimension: DimensionTotalSnapshots, Limit: 3, Used: 3, Requested: 1})
+	assert.True(t, IsDenied(denial))
+	assert.Contains(t, denial.Error(), DimensionTotalSnapshots)
+
+	assert.False(t, IsDenied(AdmissionError(gr, "s3", errors.New("list failed"))))
+}
+
+func TestExceeded(t *testing.T) {
+	q := &infrav1beta1.VirtrigaudQuota{Spec: infrav1beta1.VirtrigaudQuotaSpec{
+		MaxVMs:          ptr.To[int32](1),
+		MaxTotalDiskGiB: ptr.To[int64](100),
+	}}
+	assert.Empty(t, Exceeded(q, &Usage{VMs: 1, DiskBytes: 100 * usage.BytesPerGiB}), "a limit reached is not exceeded")
+	assert.Equal(t, []string{DimensionVMs, DimensionTotalDiskGiB},
+		Exceeded(q, &Usage{VMs: 2, DiskBytes: 100*usage.BytesPerGiB + 1}))
+}
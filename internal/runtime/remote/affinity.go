@@ -0,0 +1,222 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// grpcPortName is the name of the gRPC port on provider Services.
+const grpcPortName = "grpc"
+
+// podEndpoint is one ready provider replica behind the headless Service.
+type podEndpoint struct {
+	// id identifies the replica across IP changes: the pod name, or the
+	// address when the endpoint has no pod reference.
+	id      string
+	address string
+}
+
+// GetProviderForVM resolves provider like GetProvider. For a Provider with
+// PerVM session affinity it instead returns a client for the replica the
+// VM is pinned to, so a Create and the TaskStatus polls that follow it
+// reach the same pod. A VM stays on its replica until that replica stops
+// being a ready endpoint; only then is it re-pinned.
+//
+// Until the Provider controller has published the headless Service the
+// call falls back to the load-balanced client.
+func (r *Resolver) GetProviderForVM(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, vmUID types.UID) (contracts.Provider, error) {
+	if !perVMAffinity(provider) || vmUID == "" {
+		return r.GetProvider(ctx, provider)
+	}
+	if provider.Status.Runtime.Phase != infravirtrigaudiov1beta1.ProviderRuntimePhaseRunning {
+		return nil, fmt.Errorf("remote provider runtime is not ready: phase=%s", provider.Status.Runtime.Phase)
+	}
+
+	endpoints, err := r.providerEndpoints(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("remote provider runtime is not ready: headless service %s has no ready endpoints",
+			provider.Status.Runtime.HeadlessServiceRef.Name)
+	}
+
+	ep := r.pinVM(ctx, provider, vmUID, endpoints)
+	client, err := r.cachedClient(ctx, provider, endpointKey(provider, ep.address), ep.address)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// ReleaseVM forgets the replica a VM was pinned to. Call it once the VM
+// is gone so the pin table does not grow with deleted VMs.
+func (r *Resolver) ReleaseVM(provider *infravirtrigaudiov1beta1.Provider, vmUID types.UID) {
+	r.clientsMutex.Lock()
+	defer r.clientsMutex.Unlock()
+	delete(r.pins[providerKey(provider)], vmUID)
+}
+
+// perVMAffinity reports whether provider asks for PerVM session affinity
+// and its headless Service is in place.
+func perVMAffinity(provider *infravirtrigaudiov1beta1.Provider) bool {
+	return provider.Spec.Runtime != nil &&
+		provider.Spec.Runtime.SessionAffinity == infravirtrigaudiov1beta1.ProviderSessionAffinityPerVM &&
+		provider.Status.Runtime != nil &&
+		provider.Status.Runtime.HeadlessServiceRef != nil
+}
+
+// providerEndpoints lists the ready gRPC endpoints of the provider's
+// headless Service, one per pod, sorted by id.
+func (r *Resolver) providerEndpoints(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) ([]podEndpoint, error) {
+	service := provider.Status.Runtime.HeadlessServiceRef.Name
+	var slices discoveryv1.EndpointSliceList
+	if err := r.client.List(ctx, &slices,
+		client.InNamespace(provider.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service},
+	); err != nil {
+		return nil, fmt.Errorf("list endpoints of headless service %s/%s: %w", provider.Namespace, service, err)
+	}
+
+	seen := map[string]bool{}
+	var endpoints []podEndpoint
+	for i := range slices.Items {
+		slice := &slices.Items[i]
+		port, ok := grpcPort(slice)
+		if !ok {
+			continue
+		}
+		for _, e := range slice.Endpoints {
+			if len(e.Addresses) == 0 || (e.Conditions.Ready != nil && !*e.Conditions.Ready) {
+				continue
+			}
+			id := e.Addresses[0]
+			if e.TargetRef != nil && e.TargetRef.Name != "" {
+				id = e.TargetRef.Name
+			}
+			// Dual-stack Services list each pod once per address family.
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			endpoints = append(endpoints, podEndpoint{
+				id:      id,
+				address: net.JoinHostPort(e.Addresses[0], strconv.Itoa(int(port))),
+			})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].id < endpoints[j].id })
+	return endpoints, nil
+}
+
+// grpcPort returns the slice's gRPC port, or its only port when unnamed.
+func grpcPort(slice *discoveryv1.EndpointSlice) (int32, bool) {
+	for _, p := range slice.Ports {
+		if p.Port == nil {
+			continue
+		}
+		if (p.Name != nil && *p.Name == grpcPortName) || len(slice.Ports) == 1 {
+			return *p.Port, true
+		}
+	}
+	return 0, false
+}
+
+// pinVM returns the endpoint vmUID is pinned to, choosing one when the VM
+// has no pin or its pinned replica is gone. Clients of replicas that are
+// no longer endpoints are closed.
+func (r *Resolver) pinVM(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, vmUID types.UID, endpoints []podEndpoint) podEndpoint {
+	key := providerKey(provider)
+	byID := make(map[string]podEndpoint, len(endpoints))
+	live := make(map[string]bool, len(endpoints))
+	for _, ep := range endpoints {
+		byID[ep.id] = ep
+		live[endpointKey(provider, ep.address)] = true
+	}
+
+	r.clientsMutex.Lock()
+	defer r.clientsMutex.Unlock()
+	r.closeEndpointClientsLocked(key, live)
+
+	pins := r.pins[key]
+	if pins == nil {
+		pins = make(map[types.UID]string)
+		r.pins[key] = pins
+	}
+	previous, pinned := pins[vmUID]
+	if ep, ok := byID[previous]; pinned && ok {
+		return ep
+	}
+
+	ep := rendezvous(vmUID, endpoints)
+	pins[vmUID] = ep.id
+	ctrl.LoggerFrom(ctx).V(1).Info("Pinned VM to provider replica",
+		"provider", key, "vmUID", vmUID, "replica", ep.id, "address", ep.address,
+		"previousReplica", previous, "replicas", len(endpoints))
+	return ep
+}
+
+// rendezvous picks the endpoint with the highest hash of (vmUID, id), so a
+// VM's choice depends only on the replicas present, not on their order.
+func rendezvous(vmUID types.UID, endpoints []podEndpoint) podEndpoint {
+	var best podEndpoint
+	var bestScore uint64
+	for i, ep := range endpoints {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(vmUID))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(ep.id))
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = ep, score
+		}
+	}
+	return best
+}
+
+// endpointKey is the cache key of the client dialled to one replica.
+func endpointKey(provider *infravirtrigaudiov1beta1.Provider, address string) string {
+	return providerKey(provider) + "@" + address
+}
+
+// closeEndpointClientsLocked closes the per-replica clients of the
+// Provider with cache key key, except those in keep. The caller holds
+// clientsMutex.
+func (r *Resolver) closeEndpointClientsLocked(key string, keep map[string]bool) {
+	prefix := key + "@"
+	for k, c := range r.clients {
+		if strings.HasPrefix(k, prefix) && !keep[k] {
+			c.Close() //nolint:errcheck // Client cleanup not critical
+			delete(r.clients, k)
+			delete(r.tlsVersions, k)
+		}
+	}
+}
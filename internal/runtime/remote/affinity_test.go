@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func perVMProvider() *infravirtrigaudiov1beta1.Provider {
+	return &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "libvirt", Namespace: "virtrigaud-system"},
+		Spec: infravirtrigaudiov1beta1.ProviderSpec{
+			Runtime: &infravirtrigaudiov1beta1.ProviderRuntimeSpec{
+				SessionAffinity: infravirtrigaudiov1beta1.ProviderSessionAffinityPerVM,
+			},
+		},
+		Status: infravirtrigaudiov1beta1.ProviderStatus{
+			Runtime: &infravirtrigaudiov1beta1.ProviderRuntimeStatus{
+				Phase:              infravirtrigaudiov1beta1.ProviderRuntimePhaseRunning,
+				Endpoint:           "virtrigaud-provider.virtrigaud-system.svc.cluster.local:9443",
+				HeadlessServiceRef: &corev1.LocalObjectReference{Name: "libvirt-pods"},
+			},
+		},
+	}
+}
+
+func endpointSlice(name, service string, ready map[string]bool) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "virtrigaud-system",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("grpc"), Port: ptr.To(int32(9443))}},
+	}
+	i := 0
+	for pod, isReady := range ready {
+		i++
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{fmt.Sprintf("10.0.0.%d", i)},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(isReady)},
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod},
+		})
+	}
+	return slice
+}
+
+func TestProviderEndpoints_ReadyPodsOfHeadlessService(t *testing.T) {
+	sch := newResolverTestScheme(t)
+	require.NoError(t, discoveryv1.AddToScheme(sch))
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(
+		endpointSlice("libvirt-pods-a", "libvirt-pods", map[string]bool{"pod-b": true, "pod-a": true, "pod-c": false}),
+		endpointSlice("other", "unrelated", map[string]bool{"pod-x": true}),
+	).Build()
+	r := NewResolver(cli, nil)
+
+	endpoints, err := r.providerEndpoints(context.Background(), perVMProvider())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "pod-a", endpoints[0].id)
+	assert.Equal(t, "pod-b", endpoints[1].id)
+	assert.Contains(t, endpoints[0].address, ":9443")
+}
+
+func TestPinVM_StableUntilReplicaDisappears(t *testing.T) {
+	r := NewResolver(nil, nil)
+	prov := perVMProvider()
+	ctx := context.Background()
+	three := []podEndpoint{
+		{id: "pod-a", address: "10.0.0.1:9443"},
+		{id: "pod-b", address: "10.0.0.2:9443"},
+		{id: "pod-c", address: "10.0.0.3:9443"},
+	}
+
+	uid := types.UID("0b6f6c1e-5f7a-4c36-9a55-0c1f0f1d2e3a")
+	first := r.pinVM(ctx, prov, uid, three)
+	assert.Equal(t, first, r.pinVM(ctx, prov, uid, three), "repeat resolution must keep the pin")
+
+	// A new replica does not move a VM whose replica is still there.
+	four := append(append([]podEndpoint{}, three...), podEndpoint{id: "pod-d", address: "10.0.0.4:9443"})
+	assert.Equal(t, first, r.pinVM(ctx, prov, uid, four))
+
+	// Losing the pinned replica re-pins to a surviving one.
+	var survivors []podEndpoint
+	for _, ep := range four {
+		if ep.id != first.id {
+			survivors = append(survivors, ep)
+		}
+	}
+	moved := r.pinVM(ctx, prov, uid, survivors)
+	assert.NotEqual(t, first.id, moved.id)
+	assert.Contains(t, survivors, moved)
+
+	r.ReleaseVM(prov, uid)
+	assert.NotContains(t, r.pins[providerKey(prov)], uid)
+}
+
+func TestRendezvous_SpreadsVMsAcrossReplicas(t *testing.T) {
+	endpoints := []podEndpoint{{id: "pod-a"}, {id: "pod-b"}, {id: "pod-c"}}
+	counts := map[string]int{}
+	for i := range 300 {
+		uid := types.UID(fmt.Sprintf("vm-%d", i))
+		ep := rendezvous(uid, endpoints)
+		counts[ep.id]++
+		// The choice does not depend on endpoint order.
+		reversed := []podEndpoint{endpoints[2], endpoints[1], endpoints[0]}
+		assert.Equal(t, ep, rendezvous(uid, reversed))
+	}
+	for _, ep := range endpoints {
+		assert.Greater(t, counts[ep.id], 50, "replica %s is starved", ep.id)
+	}
+}
+
+func TestGetProviderForVM_NoEndpoints(t *testing.T) {
+	sch := newResolverTestScheme(t)
+	require.NoError(t, discoveryv1.AddToScheme(sch))
+	r := NewResolver(fake.NewClientBuilder().WithScheme(sch).Build(), nil)
+
+	_, err := r.GetProviderForVM(context.Background(), perVMProvider(), "vm-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no ready endpoints")
+}
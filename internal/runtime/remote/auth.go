@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// Layout of the per-provider token Secret. The ProviderController writes
+// it and mounts it into the provider pod, where the SDK accepts either
+// key (sdk/provider/server.ProviderAuthTokenFile and
+// ProviderAuthPreviousTokenFile); the resolver reads it to authenticate
+// the manager's calls.
+const (
+	AuthTokenKey         = "token"
+	AuthPreviousTokenKey = "previous-token"
+
+	// AuthTokenRotatedAtAnnotation records, in RFC 3339, when the token
+	// was last rotated.
+	AuthTokenRotatedAtAnnotation = "virtrigaud.io/auth-token-rotated-at"
+
+	// AuthTokenGracePeriod is how long the previous token stays valid
+	// after a rotation. It must exceed the kubelet's Secret sync delay,
+	// so the provider has the new token before the manager sends it.
+	AuthTokenGracePeriod = 5 * time.Minute
+)
+
+// AuthSecretName returns the name of a provider's token Secret.
+func AuthSecretName(provider *infravirtrigaudiov1beta1.Provider) string {
+	return fmt.Sprintf("virtrigaud-provider-%s-%s-auth", provider.Namespace, provider.Name)
+}
+
+// ActiveAuthToken returns the token the manager should present. During the
+// grace window after a rotation that is still the previous token: the
+// provider accepts it before and after its mounted Secret catches up,
+// whereas the new token only works once it has.
+func ActiveAuthToken(secret *corev1.Secret, now time.Time) string {
+	previous := string(secret.Data[AuthPreviousTokenKey])
+	if previous != "" {
+		rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[AuthTokenRotatedAtAnnotation])
+		if err == nil && now.Before(rotatedAt.Add(AuthTokenGracePeriod)) {
+			return previous
+		}
+	}
+	return string(secret.Data[AuthTokenKey])
+}
+
+// authToken reads the provider's current token. A missing Secret yields
+// no token, for providers deployed before token auth existed.
+func (r *Resolver) authToken(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (string, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: provider.Namespace, Name: AuthSecretName(provider)}
+	if err := r.client.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get token Secret %s: %w", key, err)
+	}
+	return ActiveAuthToken(secret, time.Now()), nil
+}
+
+// bearerCredentials attaches the provider's token to every RPC. The token
+// is looked up per call, so a rotation needs no reconnect.
+type bearerCredentials struct {
+	token func(ctx context.Context) (string, error)
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c bearerCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials. The
+// token is only ever sent over TLS.
+func (bearerCredentials) RequireTransportSecurity() bool {
+	return true
+}
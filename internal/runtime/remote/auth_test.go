@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestActiveAuthToken(t *testing.T) {
+	rotated := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			AuthTokenRotatedAtAnnotation: rotated.Format(time.RFC3339),
+		}},
+		Data: map[string][]byte{AuthTokenKey: []byte("new"), AuthPreviousTokenKey: []byte("old")},
+	}
+
+	assert.Equal(t, "old", ActiveAuthToken(secret, rotated.Add(time.Minute)))
+	assert.Equal(t, "new", ActiveAuthToken(secret, rotated.Add(AuthTokenGracePeriod)))
+
+	delete(secret.Data, AuthPreviousTokenKey)
+	assert.Equal(t, "new", ActiveAuthToken(secret, rotated.Add(time.Minute)))
+}
+
+func TestBearerCredentials(t *testing.T) {
+	creds := bearerCredentials{token: func(context.Context) (string, error) { return "abc", nil }}
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer abc"}, md)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	empty := bearerCredentials{token: func(context.Context) (string, error) { return "", nil }}
+	md, err = empty.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, md, "providers without a token Secret get no authorization header")
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// Layout of the CA bundle ConfigMap of a Provider whose certificate comes
+// from cert-manager. The ProviderController copies the issuer's CA into it
+// on every reconcile; the resolver trusts what it holds and redials when
+// it changes.
+const (
+	// CABundleLabel marks the CA bundle ConfigMaps.
+	CABundleLabel = "virtrigaud.io/provider-ca"
+
+	// CABundleKey is the ConfigMap key of the PEM bundle.
+	CABundleKey = "ca.crt"
+)
+
+// UsesCertManager reports whether the Provider's certificate is requested
+// from cert-manager.
+func UsesCertManager(provider *infravirtrigaudiov1beta1.Provider) bool {
+	return provider.Spec.Runtime != nil && provider.Spec.Runtime.Service != nil &&
+		provider.Spec.Runtime.Service.TLS != nil && provider.Spec.Runtime.Service.TLS.CertManager != nil
+}
+
+// TLSSecretName returns the name of the Secret holding a provider's TLS
+// material: secretRef when set, otherwise the name the ProviderController
+// has cert-manager issue into. It is empty for a Provider with neither.
+func TLSSecretName(provider *infravirtrigaudiov1beta1.Provider) string {
+	if provider.Spec.Runtime == nil || provider.Spec.Runtime.Service == nil || provider.Spec.Runtime.Service.TLS == nil {
+		return ""
+	}
+	tlsSpec := provider.Spec.Runtime.Service.TLS
+	if tlsSpec.SecretRef != nil && tlsSpec.SecretRef.Name != "" {
+		return tlsSpec.SecretRef.Name
+	}
+	if tlsSpec.CertManager != nil {
+		return fmt.Sprintf("virtrigaud-provider-%s-%s-tls", provider.Namespace, provider.Name)
+	}
+	return ""
+}
+
+// CABundleConfigMapName returns the name of a provider's CA bundle
+// ConfigMap.
+func CABundleConfigMapName(provider *infravirtrigaudiov1beta1.Provider) string {
+	return fmt.Sprintf("virtrigaud-provider-%s-%s-ca", provider.Namespace, provider.Name)
+}
+
+// caBundle reads the provider's CA bundle ConfigMap. A missing ConfigMap,
+// e.g. before the first reconcile, yields no bundle.
+func (r *Resolver) caBundle(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: provider.Namespace, Name: CABundleConfigMapName(provider)}
+	if err := r.client.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get CA bundle ConfigMap %s: %w", key, err)
+	}
+	return cm, nil
+}
+
+// tlsMaterialVersion identifies the TLS material a cert-manager Provider's
+// client was dialed with, from the resource versions of its Secret and CA
+// bundle. It is empty for other Providers, whose material is not tracked.
+// The reads hit the manager's informer cache, so this is cheap per call.
+func (r *Resolver) tlsMaterialVersion(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) string {
+	if !UsesCertManager(provider) || !provider.Spec.Runtime.Service.TLS.Enabled {
+		return ""
+	}
+	version := "secret:"
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: provider.Namespace, Name: TLSSecretName(provider)}, secret); err == nil {
+		version += secret.ResourceVersion
+	}
+	version += "/ca:"
+	if cm, err := r.caBundle(ctx, provider); err == nil && cm != nil {
+		version += cm.ResourceVersion
+	}
+	return version
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func certManagerTLSSpec() *infravirtrigaudiov1beta1.ProviderTLSSpec {
+	return &infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled: true,
+		CertManager: &infravirtrigaudiov1beta1.ProviderCertManagerSpec{
+			IssuerRef: infravirtrigaudiov1beta1.CertManagerIssuerReference{Name: "ca-issuer"},
+		},
+	}
+}
+
+func TestTLSSecretName(t *testing.T) {
+	prov := newTestProvider(certManagerTLSSpec())
+	assert.Equal(t, "virtrigaud-provider-default-test-provider-tls", TLSSecretName(prov))
+
+	prov.Spec.Runtime.Service.TLS.SecretRef = &corev1.LocalObjectReference{Name: "custom"}
+	assert.Equal(t, "custom", TLSSecretName(prov), "secretRef names the Secret cert-manager issues into")
+
+	assert.Empty(t, TLSSecretName(newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: true})))
+	assert.Empty(t, TLSSecretName(newTestProvider(nil)))
+}
+
+// TestBuildTLSConfig_CertManager_TrustsCABundle — a cert-manager Provider
+// loads the issued Secret by its derived name and trusts the CA bundle
+// ConfigMap as well as the Secret's ca.crt.
+func TestBuildTLSConfig_CertManager_TrustsCABundle(t *testing.T) {
+	sch := newResolverTestScheme(t)
+	prov := newTestProvider(certManagerTLSSpec())
+	certPEM, keyPEM := genTestCertPEM(t)
+	caPEM, _ := genTestCertPEM(t)
+	bundlePEM, _ := genTestCertPEM(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: TLSSecretName(prov), Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM, "ca.crt": caPEM},
+	}
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CABundleConfigMapName(prov),
+			Namespace: "default",
+			Labels:    map[string]string{CABundleLabel: "true"},
+		},
+		Data: map[string]string{CABundleKey: string(bundlePEM)},
+	}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(secret, bundle).Build()
+	r := NewResolver(cli, nil)
+
+	cfg, err := r.buildTLSConfig(context.Background(), prov)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	want := x509.NewCertPool()
+	require.True(t, want.AppendCertsFromPEM(caPEM))
+	require.True(t, want.AppendCertsFromPEM(bundlePEM))
+	assert.True(t, want.Equal(cfg.PrebuiltConfig.RootCAs), "RootCAs must hold both the Secret's CA and the bundle's")
+}
+
+// TestTLSMaterialVersion_TracksRenewal — the version the resolver caches
+// clients under changes when the Secret or the CA bundle does, and is
+// empty for Providers not using cert-manager.
+func TestTLSMaterialVersion_TracksRenewal(t *testing.T) {
+	ctx := context.Background()
+	sch := newResolverTestScheme(t)
+	prov := newTestProvider(certManagerTLSSpec())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: TLSSecretName(prov), Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": []byte("one")},
+	}
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CABundleConfigMapName(prov), Namespace: "default"},
+		Data:       map[string]string{CABundleKey: "one"},
+	}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(secret, bundle).Build()
+	r := NewResolver(cli, nil)
+
+	initial := r.tlsMaterialVersion(ctx, prov)
+	require.NotEmpty(t, initial)
+	assert.Equal(t, initial, r.tlsMaterialVersion(ctx, prov))
+
+	secret.Data["tls.crt"] = []byte("two")
+	require.NoError(t, cli.Update(ctx, secret))
+	renewed := r.tlsMaterialVersion(ctx, prov)
+	assert.NotEqual(t, initial, renewed)
+
+	bundle.Data[CABundleKey] = "two"
+	require.NoError(t, cli.Update(ctx, bundle))
+	assert.NotEqual(t, renewed, r.tlsMaterialVersion(ctx, prov))
+
+	plain := newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled:   true,
+		SecretRef: &corev1.LocalObjectReference{Name: secret.Name},
+	})
+	assert.Empty(t, r.tlsMaterialVersion(ctx, plain))
+}
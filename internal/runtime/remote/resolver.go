@@ -24,15 +24,20 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/audit"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/resilience"
 	grpcClient "github.com/projectbeskar/virtrigaud/internal/transport/grpc"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
 )
 
 // Required key names inside a Provider TLS Secret.
@@ -64,6 +69,11 @@ var ErrTLSBlockMissing = errors.New("provider.spec.runtime.service.tls is unset;
 // material to load.
 var ErrTLSSecretRefMissing = errors.New("provider.spec.runtime.service.tls.enabled=true requires a non-empty secretRef")
 
+// ErrSPIFFESourceUnavailable is returned when a Provider selects SPIFFE
+// mTLS (tls.spiffe) but the manager was started without a Workload API
+// connection (--spiffe-endpoint-socket / SPIFFE_ENDPOINT_SOCKET unset).
+var ErrSPIFFESourceUnavailable = errors.New("provider.spec.runtime.service.tls.spiffe is set but the manager has no SPIFFE Workload API source; start the manager with --spiffe-endpoint-socket")
+
 // circuitBreakerName is the logical name passed to the resilience
 // Registry when getting/creating a CircuitBreaker per Provider CR. We
 // use a single name ("rpc") because today there is one breaker per
@@ -81,6 +91,24 @@ type Resolver struct {
 	// are constructed without circuit-breaker protection (intended for
 	// tests that don't exercise the breaker path).
 	cbRegistry *resilience.Registry
+	// spiffeSource supplies the manager's own SVID for Providers using
+	// SPIFFE mTLS. Nil unless SetSPIFFESource was called at startup.
+	spiffeSource *spiffe.X509Source
+	// rpcTimeouts supplies the deadlines of every client's RPCs. Nil
+	// leaves clients on grpcClient.DefaultRPCTimeouts.
+	rpcTimeouts func() grpcClient.RPCTimeouts
+	// pins maps a Provider's cache key to the pod endpoint each of its
+	// VMs is pinned to, for Providers with PerVM session affinity.
+	// Guarded by clientsMutex.
+	pins map[string]map[types.UID]string
+	// tlsVersions maps a cache key to the tlsMaterialVersion its client
+	// was dialed with, so a renewed cert-manager certificate or CA gets a
+	// fresh client. Guarded by clientsMutex.
+	tlsVersions map[string]string
+	// endpoints maps a cache key to the endpoint its client was dialed
+	// at, so a Service whose port changed gets a fresh client. Guarded by
+	// clientsMutex.
+	endpoints map[string]string
 }
 
 // NewResolver creates a new remote provider resolver.
@@ -94,12 +122,34 @@ type Resolver struct {
 // virtrigaud_circuit_breaker_* samples.
 func NewResolver(k8sClient client.Client, cbRegistry *resilience.Registry) *Resolver {
 	return &Resolver{
-		client:     k8sClient,
-		clients:    make(map[string]*grpcClient.Client),
-		cbRegistry: cbRegistry,
+		client:      k8sClient,
+		clients:     make(map[string]*grpcClient.Client),
+		pins:        make(map[string]map[types.UID]string),
+		tlsVersions: make(map[string]string),
+		endpoints:   make(map[string]string),
+		cbRegistry:  cbRegistry,
 	}
 }
 
+// resolverLogger returns the caller's logger named "provider.resolver", so
+// its level can be set on its own (VirtrigaudConfig logLevels).
+func resolverLogger(ctx context.Context) logr.Logger {
+	return ctrl.LoggerFrom(ctx).WithName("provider").WithName("resolver")
+}
+
+// SetSPIFFESource enables SPIFFE mTLS for Providers that request it. The
+// resolver does not take ownership; the caller closes the source.
+func (r *Resolver) SetSPIFFESource(src *spiffe.X509Source) {
+	r.spiffeSource = src
+}
+
+// SetRPCTimeouts makes every client this resolver creates read its RPC
+// deadlines from fn, so changes apply without reconnecting. Call it before
+// the first GetProvider.
+func (r *Resolver) SetRPCTimeouts(fn func() grpcClient.RPCTimeouts) {
+	r.rpcTimeouts = fn
+}
+
 // GetProvider resolves a Provider object to a remote provider implementation
 func (r *Resolver) GetProvider(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (contracts.Provider, error) {
 	// All providers are now remote
@@ -117,13 +167,58 @@ func (r *Resolver) getRemoteProvider(ctx context.Context, provider *infravirtrig
 		return nil, fmt.Errorf("remote provider runtime is not ready: phase=%s", provider.Status.Runtime.Phase)
 	}
 
-	// Create cache key
-	cacheKey := fmt.Sprintf("%s/%s", provider.Namespace, provider.Name)
+	endpoint, err := r.runtimeEndpoint(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	client, err := r.cachedClient(ctx, provider, providerKey(provider), endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// runtimeEndpoint returns the host:port to dial for provider. When the
+// ProviderController recorded status.runtime.endpointRef, the Service it
+// names is read now, so a changed Service port is dialed at once rather
+// than after the next Provider reconcile rewrites status.runtime.endpoint.
+func (r *Resolver) runtimeEndpoint(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider) (string, error) {
+	ref := provider.Status.Runtime.EndpointRef
+	if ref == nil {
+		return provider.Status.Runtime.Endpoint, nil
+	}
+	host, port, err := k8sutil.ResolveServiceEndpoint(ctx, r.client, "status.runtime.endpointRef", *ref, provider.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("remote provider runtime is not ready: %w", err)
+	}
+	return fmt.Sprintf("%s:%d", host, port), nil
+}
+
+// cachedClient returns the validated client cached under cacheKey, dialing
+// endpoint when there is none, the cached one has gone bad, or its
+// cert-manager TLS material has since been renewed.
+func (r *Resolver) cachedClient(ctx context.Context, provider *infravirtrigaudiov1beta1.Provider, cacheKey, endpoint string) (*grpcClient.Client, error) {
+	tlsVersion := r.tlsMaterialVersion(ctx, provider)
 
 	r.clientsMutex.RLock()
 	existingClient, exists := r.clients[cacheKey]
+	dialedVersion := r.tlsVersions[cacheKey]
+	dialedEndpoint := r.endpoints[cacheKey]
 	r.clientsMutex.RUnlock()
 
+	if exists && (dialedVersion != tlsVersion || dialedEndpoint != endpoint) {
+		resolverLogger(ctx).V(1).Info("Provider TLS material or endpoint changed, redialing",
+			"provider", cacheKey, "from", dialedVersion, "to", tlsVersion,
+			"fromEndpoint", dialedEndpoint, "toEndpoint", endpoint)
+		r.clientsMutex.Lock()
+		if r.clients[cacheKey] == existingClient {
+			delete(r.clients, cacheKey)
+			existingClient.Close() //nolint:errcheck // Client cleanup not critical
+		}
+		r.clientsMutex.Unlock()
+		exists = false
+	}
+
 	if exists {
 		// Validate that the client is still usable
 		if err := existingClient.Validate(ctx); err != nil {
@@ -155,10 +250,32 @@ func (r *Resolver) getRemoteProvider(ctx context.Context, provider *infravirtrig
 	if r.cbRegistry != nil {
 		cb = r.cbRegistry.GetOrCreate(circuitBreakerName, string(provider.Spec.Type), provider.Name)
 	}
-	client, err := grpcClient.NewClient(ctx, provider.Status.Runtime.Endpoint, string(provider.Spec.Type), provider.Name, cb, tlsConfig)
+	// The provider's bearer token rides on every call, over TLS only;
+	// a plaintext provider skips auth altogether.
+	var dialOpts []grpc.DialOption
+	if tlsConfig != nil {
+		key := provider.DeepCopy()
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerCredentials{
+			token: func(ctx context.Context) (string, error) { return r.authToken(ctx, key) },
+		}))
+	}
+	// The sanitizer wraps the audit interceptor, so audit logs record the
+	// response as the provider sent it.
+	dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(
+		responseSanitizerInterceptor(string(provider.Spec.Type), provider.Name),
+		audit.UnaryClientInterceptor(audit.Provider{
+			Namespace: provider.Namespace,
+			Name:      provider.Name,
+			Type:      string(provider.Spec.Type),
+		}),
+	))
+	client, err := grpcClient.NewClient(ctx, endpoint, string(provider.Spec.Type), provider.Name, cb, tlsConfig, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
 	}
+	if r.rpcTimeouts != nil {
+		client.SetTimeouts(r.rpcTimeouts)
+	}
 
 	// Validate the new client
 	if err := client.Validate(ctx); err != nil {
@@ -169,6 +286,8 @@ func (r *Resolver) getRemoteProvider(ctx context.Context, provider *infravirtrig
 	// Cache the client
 	r.clientsMutex.Lock()
 	r.clients[cacheKey] = client
+	r.tlsVersions[cacheKey] = tlsVersion
+	r.endpoints[cacheKey] = endpoint
 	r.clientsMutex.Unlock()
 
 	return client, nil
@@ -188,8 +307,14 @@ func (r *Resolver) getRemoteProvider(ctx context.Context, provider *infravirtrig
 //   - tls.enabled=false → return (nil, nil). Explicit plaintext opt-out;
 //     manager dials over insecure credentials. Compensating control
 //     (NetworkPolicy + encrypted CNI) is the operator's responsibility.
+//   - tls.enabled=true, tls.spiffe set → authenticate with the manager's
+//     SVID from the Workload API and verify the provider's SVID; see
+//     buildSPIFFETLSConfig.
 //   - tls.enabled=true  → load tls.crt / tls.key / ca.crt from the
 //     referenced Secret and build a *tls.Config with TLS1.3 floor.
+//   - tls.certManager set → as above, from the Secret cert-manager issues
+//     into (TLSSecretName), trusting the CA bundle ConfigMap as well as
+//     the Secret's ca.crt so a CA rollover verifies both ways.
 //
 // Both `kubernetes.io/tls`-typed Secrets (canonical tls.crt/tls.key plus
 // extra ca.crt) and plain Opaque Secrets (all three keys explicit) are
@@ -214,7 +339,12 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 		return nil, nil
 	}
 
-	if tlsSpec.SecretRef == nil || tlsSpec.SecretRef.Name == "" {
+	if tlsSpec.SPIFFE != nil {
+		return r.buildSPIFFETLSConfig(tlsSpec.SPIFFE)
+	}
+
+	secretName := TLSSecretName(provider)
+	if secretName == "" {
 		return nil, ErrTLSSecretRefMissing
 	}
 
@@ -223,9 +353,9 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 	secret := &corev1.Secret{}
 	if err := r.client.Get(ctx, types.NamespacedName{
 		Namespace: provider.Namespace,
-		Name:      tlsSpec.SecretRef.Name,
+		Name:      secretName,
 	}, secret); err != nil {
-		return nil, fmt.Errorf("get TLS Secret %s/%s: %w", provider.Namespace, tlsSpec.SecretRef.Name, err)
+		return nil, fmt.Errorf("get TLS Secret %s/%s: %w", provider.Namespace, secretName, err)
 	}
 
 	// Validate required keys are present. Both kubernetes.io/tls
@@ -247,28 +377,42 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 	}
 	if len(missing) > 0 {
 		return nil, fmt.Errorf("TLS Secret %s/%s is missing required key(s): %v (expected: tls.crt, tls.key, ca.crt)",
-			provider.Namespace, tlsSpec.SecretRef.Name, missing)
+			provider.Namespace, secretName, missing)
 	}
 
 	// Parse certificate + key into a usable tls.Certificate.
 	cert, err := tls.X509KeyPair(certPEM, keyPEM)
 	if err != nil {
-		return nil, fmt.Errorf("parse client cert/key from TLS Secret %s/%s: %w", provider.Namespace, tlsSpec.SecretRef.Name, err)
+		return nil, fmt.Errorf("parse client cert/key from TLS Secret %s/%s: %w", provider.Namespace, secretName, err)
 	}
 
 	// Build the CA pool that the manager uses to verify the provider's
 	// server certificate.
 	caPool := x509.NewCertPool()
 	if !caPool.AppendCertsFromPEM(caPEM) {
-		return nil, fmt.Errorf("parse ca.crt from TLS Secret %s/%s: no valid PEM certificates found", provider.Namespace, tlsSpec.SecretRef.Name)
+		return nil, fmt.Errorf("parse ca.crt from TLS Secret %s/%s: no valid PEM certificates found", provider.Namespace, secretName)
+	}
+	if tlsSpec.CertManager != nil {
+		cm, err := r.caBundle(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		if cm != nil && cm.Data[CABundleKey] != "" && !caPool.AppendCertsFromPEM([]byte(cm.Data[CABundleKey])) {
+			return nil, fmt.Errorf("parse %s from CA bundle ConfigMap %s/%s: no valid PEM certificates found", CABundleKey, provider.Namespace, cm.Name)
+		}
 	}
 
-	// ServerName mirrors how the dial target is constructed in
-	// reconcileRemoteRuntime: <service>.<namespace>.svc.cluster.local.
-	// Anchoring SNI to the Service FQDN lets operators mint provider
-	// server certs against a deterministic SAN.
+	// ServerName is the DNS name of the Service dialed:
+	// <service>.<namespace>.svc.cluster.local, from
+	// status.runtime.endpointRef or, before the ProviderController has
+	// recorded one, from the Service name it derives. Anchoring SNI to the
+	// Service FQDN lets operators mint provider server certs against a
+	// deterministic SAN.
 	serverName := fmt.Sprintf("virtrigaud-provider-%s-%s.%s.svc.cluster.local",
 		provider.Namespace, provider.Name, provider.Namespace)
+	if provider.Status.Runtime != nil && provider.Status.Runtime.EndpointRef != nil {
+		serverName = k8sutil.ServiceDNSName(*provider.Status.Runtime.EndpointRef, provider.Namespace)
+	}
 
 	// Loud, per-reconcile signal when the operator has opted into the
 	// dev-only escape hatch. ADR-0003 mandates a steady drumbeat in the
@@ -276,7 +420,7 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 	// environment. Info level (logr has no Warn); the "WARNING:" prefix
 	// and structured K/V fields keep the line greppable.
 	if tlsSpec.InsecureSkipVerify {
-		ctrl.LoggerFrom(ctx).Info(
+		resolverLogger(ctx).Info(
 			"WARNING: Provider has spec.runtime.service.tls.insecureSkipVerify=true; the manager will NOT verify the provider gRPC server certificate. Use only for lab / first-bootstrap scenarios; this defeats mTLS.",
 			"provider", provider.Name,
 			"namespace", provider.Namespace,
@@ -304,6 +448,25 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 	return &grpcClient.TLSConfig{PrebuiltConfig: tlsCfg}, nil
 }
 
+// buildSPIFFETLSConfig builds the client config for a Provider using SPIFFE
+// workload identity. The manager presents its current SVID and accepts the
+// provider's SVID if it chains to the trust bundle and belongs to the
+// configured trust domain (and matches providerID when set). Both sides
+// read rotated material per handshake, so no Secret or restart is involved.
+func (r *Resolver) buildSPIFFETLSConfig(spec *infravirtrigaudiov1beta1.ProviderSPIFFESpec) (*grpcClient.TLSConfig, error) {
+	if r.spiffeSource == nil {
+		return nil, ErrSPIFFESourceUnavailable
+	}
+	authz := spiffe.Authorizer{TrustDomain: spec.TrustDomain}
+	if spec.ProviderID != "" {
+		authz.IDs = []string{spec.ProviderID}
+	}
+	if err := authz.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tls.spiffe configuration: %w", err)
+	}
+	return &grpcClient.TLSConfig{PrebuiltConfig: spiffe.ClientTLSConfig(r.spiffeSource, authz)}, nil
+}
+
 // CleanupClient removes and closes a cached gRPC client.
 //
 // Also removes the per-Provider CircuitBreaker from the registry (G6 /
@@ -312,7 +475,7 @@ func (r *Resolver) buildTLSConfig(ctx context.Context, provider *infravirtrigaud
 // the Provider CR is gone. Without this cleanup, deleted Providers
 // would leak both a CB struct and stale metric samples indefinitely.
 func (r *Resolver) CleanupClient(provider *infravirtrigaudiov1beta1.Provider) {
-	cacheKey := fmt.Sprintf("%s/%s", provider.Namespace, provider.Name)
+	cacheKey := providerKey(provider)
 
 	r.clientsMutex.Lock()
 	defer r.clientsMutex.Unlock()
@@ -321,6 +484,10 @@ func (r *Resolver) CleanupClient(provider *infravirtrigaudiov1beta1.Provider) {
 		client.Close() //nolint:errcheck // Client cleanup not critical
 		delete(r.clients, cacheKey)
 	}
+	delete(r.tlsVersions, cacheKey)
+	delete(r.endpoints, cacheKey)
+	r.closeEndpointClientsLocked(cacheKey, nil)
+	delete(r.pins, cacheKey)
 	if r.cbRegistry != nil {
 		r.cbRegistry.Remove(circuitBreakerName, string(provider.Spec.Type), provider.Name)
 	}
@@ -335,6 +502,14 @@ func (r *Resolver) CleanupAllClients() {
 		client.Close() //nolint:errcheck // Client cleanup not critical
 		delete(r.clients, key)
 	}
+	clear(r.pins)
+	clear(r.tlsVersions)
+	clear(r.endpoints)
+}
+
+// providerKey is the cache key of a Provider's load-balanced client.
+func providerKey(provider *infravirtrigaudiov1beta1.Provider) string {
+	return fmt.Sprintf("%s/%s", provider.Namespace, provider.Name)
 }
 
 // IsRemoteProvider checks if a provider is configured for remote runtime
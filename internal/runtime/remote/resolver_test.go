@@ -39,11 +39,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe/spiffetest"
 )
 
 // newResolverTestScheme registers v1beta1 + corev1 (for Secret reads).
@@ -410,3 +413,123 @@ func TestBuildTLSConfig_EnabledTrue_InsecureSkipVerifyHonored(t *testing.T) {
 	assert.NotEmpty(t, warnLine,
 		"expected a WARNING log line naming the Provider when InsecureSkipVerify=true; got captured lines: %v", captured)
 }
+
+// TestBuildTLSConfig_SPIFFE_NoSource — a Provider selecting SPIFFE mTLS on
+// a manager started without a Workload API connection must fail with a
+// discriminating error rather than fall back to the Secret path.
+func TestBuildTLSConfig_SPIFFE_NoSource(t *testing.T) {
+	sch := newResolverTestScheme(t)
+	prov := newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled: true,
+		SPIFFE:  &infravirtrigaudiov1beta1.ProviderSPIFFESpec{TrustDomain: "example.org"},
+	})
+	cli := fake.NewClientBuilder().WithScheme(sch).Build()
+	r := NewResolver(cli, nil)
+
+	_, err := r.buildTLSConfig(context.Background(), prov)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSPIFFESourceUnavailable), "got %v", err)
+}
+
+// TestBuildTLSConfig_SPIFFE_Happy — with a Workload API source the manager
+// presents its own SVID and needs no Secret.
+func TestBuildTLSConfig_SPIFFE_Happy(t *testing.T) {
+	ca := spiffetest.NewCA(t, "example.org")
+	api := spiffetest.NewWorkloadAPI(t)
+	api.SetSVID(t, ca, "spiffe://example.org/ns/virtrigaud-system/sa/manager")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	src, err := spiffe.NewX509Source(ctx, api.Addr, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = src.Close() })
+
+	sch := newResolverTestScheme(t)
+	prov := newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled: true,
+		SPIFFE: &infravirtrigaudiov1beta1.ProviderSPIFFESpec{
+			TrustDomain: "example.org",
+			ProviderID:  "spiffe://example.org/ns/default/sa/provider",
+		},
+	})
+	cli := fake.NewClientBuilder().WithScheme(sch).Build()
+	r := NewResolver(cli, nil)
+	r.SetSPIFFESource(src)
+
+	cfg, err := r.buildTLSConfig(context.Background(), prov)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	require.NotNil(t, cfg.PrebuiltConfig)
+	assert.Equal(t, uint16(tls.VersionTLS13), cfg.PrebuiltConfig.MinVersion)
+	require.NotNil(t, cfg.PrebuiltConfig.GetClientCertificate)
+	cert, err := cfg.PrebuiltConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://example.org/ns/virtrigaud-system/sa/manager", cert.Leaf.URIs[0].String())
+
+	// A ProviderID outside the trust domain is a configuration error.
+	prov.Spec.Runtime.Service.TLS.SPIFFE.ProviderID = "spiffe://other.org/provider"
+	_, err = r.buildTLSConfig(context.Background(), prov)
+	require.Error(t, err)
+}
+
+// TestBuildTLSConfig_EndpointRefServerName — with status.runtime.endpointRef
+// recorded, SNI is the DNS name of the Service it names.
+func TestBuildTLSConfig_EndpointRefServerName(t *testing.T) {
+	sch := newResolverTestScheme(t)
+	certPEM, keyPEM := genTestCertPEM(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls-secret", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM, "ca.crt": certPEM},
+	}
+	prov := newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{
+		Enabled:   true,
+		SecretRef: &corev1.LocalObjectReference{Name: "tls-secret"},
+	})
+	prov.Status.Runtime = &infravirtrigaudiov1beta1.ProviderRuntimeStatus{
+		EndpointRef: &infravirtrigaudiov1beta1.ServiceEndpointRef{ServiceName: "pve-provider"},
+	}
+	r := NewResolver(fake.NewClientBuilder().WithScheme(sch).WithObjects(secret).Build(), nil)
+
+	cfg, err := r.buildTLSConfig(context.Background(), prov)
+	require.NoError(t, err)
+	assert.Equal(t, "pve-provider.default.svc.cluster.local", cfg.PrebuiltConfig.ServerName)
+}
+
+// TestRuntimeEndpoint — status.runtime.endpointRef is resolved against the
+// Service at dial time, so a changed port is dialed without waiting for
+// status.runtime.endpoint.
+func TestRuntimeEndpoint(t *testing.T) {
+	ctx := context.Background()
+	sch := newResolverTestScheme(t)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "virtrigaud-provider-default-test-provider", Namespace: "default"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "grpc", Port: 9443},
+			{Name: "metrics", Port: 8080},
+		}},
+	}
+	cli := fake.NewClientBuilder().WithScheme(sch).WithObjects(svc).Build()
+	r := NewResolver(cli, nil)
+
+	prov := newTestProvider(&infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false})
+	prov.Status.Runtime = &infravirtrigaudiov1beta1.ProviderRuntimeStatus{
+		Endpoint: "virtrigaud-provider-default-test-provider.default.svc.cluster.local:9443",
+	}
+	endpoint, err := r.runtimeEndpoint(ctx, prov)
+	require.NoError(t, err)
+	assert.Equal(t, prov.Status.Runtime.Endpoint, endpoint, "without endpointRef the recorded endpoint is dialed")
+
+	grpcPort := intstr.FromString("grpc")
+	prov.Status.Runtime.EndpointRef = &infravirtrigaudiov1beta1.ServiceEndpointRef{
+		ServiceName: svc.Name, Namespace: "default", Port: &grpcPort,
+	}
+	svc.Spec.Ports[0].Port = 9555
+	require.NoError(t, cli.Update(ctx, svc))
+	endpoint, err = r.runtimeEndpoint(ctx, prov)
+	require.NoError(t, err)
+	assert.Equal(t, "virtrigaud-provider-default-test-provider.default.svc.cluster.local:9555", endpoint)
+
+	require.NoError(t, cli.Delete(ctx, svc))
+	_, err = r.runtimeEndpoint(ctx, prov)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote provider runtime is not ready")
+}
@@ -0,0 +1,452 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// Limits on what a provider response may carry into the manager, and from
+// there into object status and conditions.
+const (
+	// maxProviderRawJSONBytes caps provider_raw_json. A larger object is
+	// cut down to the keys that fit and marked with truncatedKey.
+	maxProviderRawJSONBytes = 16 << 10
+	// maxTaskIDLength caps a task ID. A task ID cannot be shortened and
+	// still name its task, so a longer one fails the call.
+	maxTaskIDLength = 512
+	// maxMessageLength caps an error or progress message, which the
+	// controllers copy into conditions and events.
+	maxMessageLength = 1024
+
+	// truncatedKey is the provider_raw_json key that says keys were
+	// dropped to fit maxProviderRawJSONBytes.
+	truncatedKey = "virtrigaud.io/truncated"
+)
+
+// Rule names, the values of the rule label on
+// virtrigaud_provider_response_violations_total.
+const (
+	ruleIP              = "ip"
+	ruleURL             = "url"
+	rulePowerState      = "power_state"
+	ruleProviderRawSize = "provider_raw_size"
+	ruleTaskID          = "task_id"
+	ruleMessageLength   = "message_length"
+)
+
+// valueRule checks one string value of a provider response.
+type valueRule struct {
+	// name labels the rule's violations.
+	name string
+	// check returns the value to keep in place of v and, when v broke the
+	// rule, what was wrong with it. Keeping "" drops v from a repeated
+	// field.
+	check func(v string) (keep, problem string)
+	// reject fails the response instead of keeping a substitute, for
+	// values nothing else would stand in for.
+	reject bool
+}
+
+var (
+	ipRule = valueRule{name: ruleIP, check: checkIP}
+	// consoleURLRule admits the browser and VNC/SPICE URLs providers
+	// return for Describe's console_url.
+	consoleURLRule = valueRule{name: ruleURL, check: urlChecker("http", "https", "vnc", "spice")}
+	// consoleTicketURLRule admits the WebSocket URLs the console proxy
+	// connects to.
+	consoleTicketURLRule = valueRule{name: ruleURL, check: urlChecker("ws", "wss")}
+	powerStateRule       = valueRule{name: rulePowerState, check: checkPowerState}
+	providerRawRule      = valueRule{name: ruleProviderRawSize, check: checkProviderRaw}
+	taskIDRule           = valueRule{name: ruleTaskID, check: checkTaskID, reject: true}
+	messageRule          = valueRule{name: ruleMessageLength, check: checkMessage}
+)
+
+// fieldRule applies a valueRule to a field of the provider.v1 protocol.
+type fieldRule struct {
+	rule valueRule
+	// dropElement drops the whole element of a repeated message field
+	// that the violating value belongs to, for values the element is
+	// meaningless without.
+	dropElement bool
+}
+
+// responseRules are the checks applied to every unary provider response,
+// keyed by field. A repeated field has each element checked, a map field
+// each value.
+var responseRules = map[protoreflect.FullName]fieldRule{
+	"provider.v1.TaskRef.id": {rule: taskIDRule},
+
+	"provider.v1.CreateResponse.provider_raw_json": {rule: providerRawRule},
+
+	"provider.v1.DescribeResponse.power_state":       {rule: powerStateRule},
+	"provider.v1.DescribeResponse.ips":               {rule: ipRule},
+	"provider.v1.DescribeResponse.console_url":       {rule: consoleURLRule},
+	"provider.v1.DescribeResponse.provider_raw_json": {rule: providerRawRule},
+	"provider.v1.DescribeBatchResponse.errors":       {rule: messageRule},
+	"provider.v1.GuestAddress.ip":                    {rule: ipRule, dropElement: true},
+
+	"provider.v1.TaskStatusResponse.error":   {rule: messageRule},
+	"provider.v1.TaskStatusResponse.message": {rule: messageRule},
+
+	"provider.v1.VMInfo.power_state":        {rule: powerStateRule},
+	"provider.v1.VMInfo.ips":                {rule: ipRule},
+	"provider.v1.NetworkInfo.ip_address":    {rule: ipRule},
+	"provider.v1.ConsoleTicketResponse.url": {rule: consoleTicketURLRule},
+}
+
+// knownPowerStates are the power states a provider may report; see
+// contracts.PowerState. Anything else becomes "Unknown".
+var knownPowerStates = map[string]bool{"On": true, "Off": true, "Suspended": true, "Unknown": true}
+
+func checkIP(v string) (string, string) {
+	if _, err := netip.ParseAddr(v); err != nil {
+		return "", "not an IP address"
+	}
+	return v, ""
+}
+
+// urlChecker returns a check admitting absolute URLs with a host and one
+// of schemes.
+func urlChecker(schemes ...string) func(string) (string, string) {
+	return func(v string) (string, string) {
+		u, err := url.Parse(v)
+		if err != nil {
+			return "", "not a URL"
+		}
+		scheme := strings.ToLower(u.Scheme)
+		for _, s := range schemes {
+			if scheme == s {
+				if u.Host == "" {
+					return "", "URL has no host"
+				}
+				return v, ""
+			}
+		}
+		return "", fmt.Sprintf("URL scheme %q is not one of %s", u.Scheme, strings.Join(schemes, ", "))
+	}
+}
+
+func checkPowerState(v string) (string, string) {
+	if knownPowerStates[v] {
+		return v, ""
+	}
+	return "Unknown", "not a known power state"
+}
+
+func checkTaskID(v string) (string, string) {
+	if len(v) > maxTaskIDLength {
+		return "", fmt.Sprintf("task ID of %d bytes exceeds the %d byte limit", len(v), maxTaskIDLength)
+	}
+	if strings.ContainsFunc(v, isControl) {
+		return "", "task ID contains control characters"
+	}
+	return v, ""
+}
+
+func checkMessage(v string) (string, string) {
+	if len(v) <= maxMessageLength {
+		return v, ""
+	}
+	return truncateMessage(v, maxMessageLength), fmt.Sprintf("message of %d bytes exceeds the %d byte limit", len(v), maxMessageLength)
+}
+
+// truncateMessage cuts v to at most limit bytes, on a rune boundary, and
+// says how much was cut.
+func truncateMessage(v string, limit int) string {
+	// Leave room for the suffix, whose count has at most 20 digits.
+	cut := limit - len("... [ bytes truncated]") - 20
+	for cut > 0 && !utf8.RuneStart(v[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [%d bytes truncated]", v[:cut], len(v)-cut)
+}
+
+// checkProviderRaw keeps, in key order, the keys of an oversized
+// provider_raw_json object that fit the limit and adds truncatedKey saying
+// how many were dropped. An oversized payload that is not an object is
+// replaced by the marker alone.
+func checkProviderRaw(v string) (string, string) {
+	if len(v) <= maxProviderRawJSONBytes {
+		return v, ""
+	}
+	problem := fmt.Sprintf("provider_raw_json of %d bytes exceeds the %d byte limit", len(v), maxProviderRawJSONBytes)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(v), &raw); err != nil {
+		marker, _ := json.Marshal(map[string]string{truncatedKey: problem})
+		return string(marker), problem
+	}
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Leave room for the marker; the size of each kept key is its
+	// encoding plus the separators around it.
+	budget := maxProviderRawJSONBytes - 256
+	kept := make(map[string]json.RawMessage, len(raw))
+	for _, k := range keys {
+		encodedKey, _ := json.Marshal(k)
+		size := len(encodedKey) + len(raw[k]) + 2
+		if size > budget {
+			continue
+		}
+		budget -= size
+		kept[k] = raw[k]
+	}
+	marker, _ := json.Marshal(fmt.Sprintf("%d of %d keys dropped: %s", len(raw)-len(kept), len(raw), problem))
+	if _, typed := kept["schema"]; typed {
+		// Details in the describe layout carry the marker among their
+		// extras: the manager ignores unknown keys at the top level.
+		extras := map[string]json.RawMessage{}
+		_ = json.Unmarshal(kept["extras"], &extras)
+		extras[truncatedKey] = marker
+		kept["extras"], _ = json.Marshal(extras)
+	} else {
+		kept[truncatedKey] = marker
+	}
+	out, err := json.Marshal(kept)
+	if err != nil {
+		// A value that unmarshalled as raw JSON always marshals again.
+		return "{}", problem
+	}
+	return string(out), problem
+}
+
+func isControl(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
+// violation is a value a response broke a rule with.
+type violation struct {
+	rule    string
+	field   string
+	problem string
+}
+
+// sanitizeResponse applies responseRules to msg in place and returns the
+// values it changed. It fails when a rejecting rule was broken, leaving
+// msg partly sanitized.
+func sanitizeResponse(msg proto.Message) ([]violation, error) {
+	var vs []violation
+	_, err := sanitizeMessage(msg.ProtoReflect(), &vs)
+	return vs, err
+}
+
+// sanitizeMessage sanitizes m and its nested messages, and reports
+// whether a dropElement rule asks for m to be dropped from its list.
+func sanitizeMessage(m protoreflect.Message, vs *[]violation) (bool, error) {
+	drop := false
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if err := sanitizeMap(m.Mutable(fd).Map(), fd, vs); err != nil {
+				return false, err
+			}
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := m.Mutable(fd).List()
+			n := 0
+			for j := 0; j < list.Len(); j++ {
+				elem := list.Get(j)
+				dropElem, err := sanitizeMessage(elem.Message(), vs)
+				if err != nil {
+					return false, err
+				}
+				if !dropElem {
+					list.Set(n, elem)
+					n++
+				}
+			}
+			list.Truncate(n)
+		case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+			fr, ok := responseRules[fd.FullName()]
+			if !ok {
+				continue
+			}
+			list := m.Mutable(fd).List()
+			n := 0
+			for j := 0; j < list.Len(); j++ {
+				keep, err := applyRule(fr.rule, fd, list.Get(j).String(), vs)
+				if err != nil {
+					return false, err
+				}
+				if keep != "" {
+					list.Set(n, protoreflect.ValueOfString(keep))
+					n++
+				}
+			}
+			list.Truncate(n)
+		case fd.Kind() == protoreflect.MessageKind:
+			if _, err := sanitizeMessage(m.Mutable(fd).Message(), vs); err != nil {
+				return false, err
+			}
+		case fd.Kind() == protoreflect.StringKind:
+			fr, ok := responseRules[fd.FullName()]
+			if !ok {
+				continue
+			}
+			v := m.Get(fd).String()
+			keep, err := applyRule(fr.rule, fd, v, vs)
+			if err != nil {
+				return false, err
+			}
+			if keep == v {
+				continue
+			}
+			if keep == "" {
+				m.Clear(fd)
+			} else {
+				m.Set(fd, protoreflect.ValueOfString(keep))
+			}
+			drop = drop || fr.dropElement
+		}
+	}
+	return drop, nil
+}
+
+// sanitizeMap sanitizes the message values of a map field, or checks its
+// string values against the field's rule.
+func sanitizeMap(mp protoreflect.Map, fd protoreflect.FieldDescriptor, vs *[]violation) error {
+	var err error
+	switch fd.MapValue().Kind() {
+	case protoreflect.MessageKind:
+		mp.Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			_, err = sanitizeMessage(v.Message(), vs)
+			return err == nil
+		})
+	case protoreflect.StringKind:
+		fr, ok := responseRules[fd.FullName()]
+		if !ok {
+			return nil
+		}
+		type change struct {
+			key  protoreflect.MapKey
+			keep string
+		}
+		var changes []change
+		mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			var keep string
+			if keep, err = applyRule(fr.rule, fd, v.String(), vs); err != nil {
+				return false
+			}
+			if keep != v.String() {
+				changes = append(changes, change{k, keep})
+			}
+			return true
+		})
+		for _, c := range changes {
+			mp.Set(c.key, protoreflect.ValueOfString(c.keep))
+		}
+	}
+	return err
+}
+
+// applyRule checks v, a value of fd, against rule and records a violation.
+func applyRule(rule valueRule, fd protoreflect.FieldDescriptor, v string, vs *[]violation) (string, error) {
+	keep, problem := rule.check(v)
+	if problem == "" {
+		return v, nil
+	}
+	*vs = append(*vs, violation{rule: rule.name, field: string(fd.FullName()), problem: problem})
+	if rule.reject {
+		return "", status.Errorf(codes.Internal, "provider response rejected: %s: %s", fd.FullName(), problem)
+	}
+	return keep, nil
+}
+
+// sanitizeStatus truncates the message of a gRPC status error; providers
+// return their errors' text there, and controllers copy it into
+// conditions.
+func sanitizeStatus(err error) (error, *violation) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err, nil
+	}
+	keep, problem := checkMessage(st.Message())
+	if problem == "" {
+		return err, nil
+	}
+	p := st.Proto()
+	p.Message = keep
+	return status.ErrorProto(p), &violation{rule: ruleMessageLength, field: "grpc-status.message", problem: problem}
+}
+
+// responseSanitizerInterceptor returns a UnaryClientInterceptor that holds
+// every reply and error status of a provider to responseRules before the
+// client converts it for the controllers. Each value it drops, replaces
+// or truncates is logged and counted in
+// virtrigaud_provider_response_violations_total, so a provider that keeps
+// sending bad data shows up on dashboards.
+func responseSanitizerInterceptor(providerType, providerName string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		fullMethod string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, fullMethod, req, reply, cc, opts...)
+
+		var vs []violation
+		if err != nil {
+			var v *violation
+			if err, v = sanitizeStatus(err); v != nil {
+				vs = append(vs, *v)
+			}
+		} else if msg, ok := reply.(proto.Message); ok {
+			var serr error
+			vs, serr = sanitizeResponse(msg)
+			err = serr
+		}
+
+		if len(vs) > 0 {
+			logger := ctrl.LoggerFrom(ctx).WithName("provider").WithName("sanitizer")
+			method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+			for _, v := range vs {
+				metrics.RecordProviderResponseViolation(providerType, providerName, v.rule)
+				logger.Info("WARNING: provider response violated a rule and was sanitized",
+					"provider", providerName, "providerType", providerType,
+					"method", method, "rule", v.rule, "field", v.field, "problem", v.problem)
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// TestResponseRulesNameStringFields guards the table against fields that
+// are renamed or removed from the protocol, which would silently stop
+// being checked.
+func TestResponseRulesNameStringFields(t *testing.T) {
+	for name := range responseRules {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+		require.NoError(t, err, name)
+		fd, ok := d.(protoreflect.FieldDescriptor)
+		require.True(t, ok, "%s is not a field", name)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		assert.Equal(t, protoreflect.StringKind, fd.Kind(), name)
+	}
+}
+
+func TestValueRules(t *testing.T) {
+	long := strings.Repeat("x", maxMessageLength+1)
+	tests := []struct {
+		name      string
+		rule      valueRule
+		in        string
+		want      string
+		violation bool
+	}{
+		{"IPv4", ipRule, "10.0.0.5", "10.0.0.5", false},
+		{"IPv6", ipRule, "fd00::5", "fd00::5", false},
+		{"IPv6 link-local with zone", ipRule, "fe80::1%eth0", "fe80::1%eth0", false},
+		{"hostname", ipRule, "db.example.com", "", true},
+		{"CIDR", ipRule, "10.0.0.5/24", "", true},
+		{"octet out of range", ipRule, "10.0.0.256", "", true},
+		{"empty", ipRule, "", "", true},
+		{"markup", ipRule, "<script>alert(1)</script>", "", true},
+		{"trailing newline", ipRule, "10.0.0.5\n", "", true},
+
+		{"https console", consoleURLRule, "https://pve.example.com:8006/#v1:0:=qemu/100:4:5:=console", "https://pve.example.com:8006/#v1:0:=qemu/100:4:5:=console", false},
+		{"vnc console", consoleURLRule, "vnc://hv1:5901", "vnc://hv1:5901", false},
+		{"upper-case scheme", consoleURLRule, "HTTPS://vc.example.com/ui", "HTTPS://vc.example.com/ui", false},
+		{"javascript", consoleURLRule, "javascript:alert(document.cookie)", "", true},
+		{"javascript with slashes", consoleURLRule, "javascript://vc.example.com/%0Aalert(1)", "", true},
+		{"data", consoleURLRule, "data:text/html;base64,PHNjcmlwdD4=", "", true},
+		{"file", consoleURLRule, "file:///etc/shadow", "", true},
+		{"relative", consoleURLRule, "/ui/console", "", true},
+		{"no host", consoleURLRule, "https:///ui", "", true},
+		{"control character", consoleURLRule, "https://vc.example.com/\x00", "", true},
+		{"wss ticket", consoleTicketURLRule, "wss://esx1:443/ticket/abc", "wss://esx1:443/ticket/abc", false},
+		{"https ticket", consoleTicketURLRule, "https://esx1/ticket/abc", "", true},
+
+		{"On", powerStateRule, "On", "On", false},
+		{"Suspended", powerStateRule, "Suspended", "Suspended", false},
+		{"vSphere name", powerStateRule, "poweredOn", "Unknown", true},
+		{"lower case", powerStateRule, "on", "Unknown", true},
+		{"hostile", powerStateRule, "On\"; DROP TABLE vms", "Unknown", true},
+
+		{"task ID", taskIDRule, "UPID:pve:000A:0001:65:qmclone:100:root@pam:", "UPID:pve:000A:0001:65:qmclone:100:root@pam:", false},
+		{"task ID too long", taskIDRule, strings.Repeat("t", maxTaskIDLength+1), "", true},
+		{"task ID with newline", taskIDRule, "task-1\nforged log line", "", true},
+
+		{"short message", messageRule, "clone failed", "clone failed", false},
+		{"message at the limit", messageRule, long[1:], long[1:], false},
+		{"long message", messageRule, long, long[:maxMessageLength-42] + "... [43 bytes truncated]", true},
+
+		{"small provider raw", providerRawRule, `{"host":"esx1"}`, `{"host":"esx1"}`, false},
+		{"oversized non-object", providerRawRule, strings.Repeat("A", maxProviderRawJSONBytes+1), `{"virtrigaud.io/truncated":"provider_raw_json of 16385 bytes exceeds the 16384 byte limit"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problem := tt.rule.check(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.violation, problem != "", "problem: %q", problem)
+		})
+	}
+}
+
+func TestTruncateMessageKeepsRunes(t *testing.T) {
+	in := strings.Repeat("ü", maxMessageLength)
+	got, problem := checkMessage(in)
+	require.NotEmpty(t, problem)
+	assert.LessOrEqual(t, len(got), maxMessageLength)
+	assert.True(t, strings.HasPrefix(got, "ü"))
+	assert.NotContains(t, got, "\uFFFD")
+	assert.True(t, utf8.ValidString(got))
+}
+
+func TestCheckProviderRawKeepsWhatFits(t *testing.T) {
+	raw := map[string]string{
+		"datastore": "ds1",
+		"host":      "esx1",
+		"notes":     strings.Repeat("n", maxProviderRawJSONBytes),
+	}
+	in, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	out, problem := checkProviderRaw(string(in))
+	require.NotEmpty(t, problem)
+	assert.LessOrEqual(t, len(out), maxProviderRawJSONBytes)
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, "ds1", got["datastore"])
+	assert.Equal(t, "esx1", got["host"])
+	assert.NotContains(t, got, "notes")
+	assert.Contains(t, got[truncatedKey], "1 of 3 keys dropped")
+}
+
+func TestCheckProviderRawMarksTypedDetailsInExtras(t *testing.T) {
+	in, err := (&describe.Details{
+		Host:   "esx1",
+		Guest:  &describe.GuestInfo{Hostname: strings.Repeat("n", maxProviderRawJSONBytes)},
+		Extras: map[string]string{"connection_state": "connected"},
+	}).Marshal()
+	require.NoError(t, err)
+
+	out, problem := checkProviderRaw(in)
+	require.NotEmpty(t, problem)
+	details, err := describe.Parse(out)
+	require.NoError(t, err)
+	assert.Equal(t, "esx1", details.Host)
+	assert.Nil(t, details.Guest)
+	assert.Equal(t, "connected", details.Extras["connection_state"])
+	assert.Contains(t, details.Extras[truncatedKey], "1 of 4 keys dropped")
+}
+
+func TestSanitizeResponse(t *testing.T) {
+	t.Run("describe", func(t *testing.T) {
+		resp := &providerv1.DescribeResponse{
+			Exists:     true,
+			PowerState: "running",
+			Ips:        []string{"10.0.0.5", "not-an-ip", "fd00::5", ""},
+			ConsoleUrl: "javascript:alert(1)",
+			Addresses: []*providerv1.GuestAddress{
+				{Ip: "10.0.0.5", Interface: "eth0"},
+				{Ip: "10.0.0.999", Interface: "eth1"},
+			},
+			ProviderRawJson: `{"host":"esx1"}`,
+		}
+		vs, err := sanitizeResponse(resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Unknown", resp.PowerState)
+		assert.Equal(t, []string{"10.0.0.5", "fd00::5"}, resp.Ips)
+		assert.Empty(t, resp.ConsoleUrl)
+		require.Len(t, resp.Addresses, 1, "an address whose IP is dropped goes with it")
+		assert.Equal(t, "eth0", resp.Addresses[0].Interface)
+		assert.Equal(t, `{"host":"esx1"}`, resp.ProviderRawJson)
+		assert.Len(t, vs, 5)
+	})
+
+	t.Run("nested in a map and a list", func(t *testing.T) {
+		resp := &providerv1.DescribeBatchResponse{
+			Results: map[string]*providerv1.DescribeResponse{
+				"vm-1": {Exists: true, PowerState: "On", Ips: []string{"192.168.1.1", "bogus"}},
+			},
+			Errors: map[string]string{"vm-2": strings.Repeat("e", 4*maxMessageLength)},
+		}
+		vs, err := sanitizeResponse(resp)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.168.1.1"}, resp.Results["vm-1"].Ips)
+		assert.LessOrEqual(t, len(resp.Errors["vm-2"]), maxMessageLength)
+		assert.Len(t, vs, 2)
+
+		list := &providerv1.ListVMsResponse{Vms: []*providerv1.VMInfo{{
+			Id:         "vm-1",
+			PowerState: "Off",
+			Networks:   []*providerv1.NetworkInfo{{Name: "lan", IpAddress: "999.1.1.1"}},
+		}}}
+		vs, err = sanitizeResponse(list)
+		require.NoError(t, err)
+		require.Len(t, list.Vms[0].Networks, 1, "a NIC stays without its bad static IP")
+		assert.Empty(t, list.Vms[0].Networks[0].IpAddress)
+		assert.Len(t, vs, 1)
+	})
+
+	t.Run("oversized task ID fails the response", func(t *testing.T) {
+		resp := &providerv1.TaskResponse{Task: &providerv1.TaskRef{Id: strings.Repeat("t", 1<<20)}}
+		_, err := sanitizeResponse(resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("clean response is untouched", func(t *testing.T) {
+		resp := &providerv1.CreateResponse{Id: "vm-1", Task: &providerv1.TaskRef{Id: "task-1"}, ProviderRawJson: `{"placement":"host-1"}`}
+		vs, err := sanitizeResponse(resp)
+		require.NoError(t, err)
+		assert.Empty(t, vs)
+		assert.Equal(t, "task-1", resp.Task.Id)
+	})
+}
+
+func TestResponseSanitizerInterceptor(t *testing.T) {
+	interceptor := responseSanitizerInterceptor("test", "sanitizer-test")
+	before := violationCount(t, "sanitizer-test", ruleIP)
+
+	reply := &providerv1.DescribeResponse{Exists: true, PowerState: "On", Ips: []string{"10.0.0.5", "'; rm -rf /"}}
+	err := interceptor(context.Background(), "/provider.v1.Provider/Describe", &providerv1.DescribeRequest{Id: "vm-1"}, reply, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.5"}, reply.Ips)
+	assert.Equal(t, before+1, violationCount(t, "sanitizer-test", ruleIP))
+
+	// An error status keeps its code but not its bulk.
+	err = interceptor(context.Background(), "/provider.v1.Provider/Create", &providerv1.CreateRequest{}, &providerv1.CreateResponse{}, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return status.Error(codes.FailedPrecondition, strings.Repeat("datastore full ", 10000))
+		})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.LessOrEqual(t, len(status.Convert(err).Message()), maxMessageLength)
+	assert.Equal(t, float64(1), violationCount(t, "sanitizer-test", ruleMessageLength))
+}
+
+// violationCount reads virtrigaud_provider_response_violations_total for
+// provider and rule.
+func violationCount(t *testing.T, provider, rule string) float64 {
+	t.Helper()
+	families, err := metrics.GetRegistry().Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != "virtrigaud_provider_response_violations_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["provider"] == provider && labels["rule"] == rule {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,555 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+)
+
+// Markers in the scaffolded provider package where generated code is
+// inserted. Generated code goes immediately above the marker line, so the
+// marker keeps its place at the end of what has been generated so far.
+const (
+	rpcStubsMarker     = "// +vrtg-provider:scaffold:rpcs"
+	capabilitiesMarker = "// +vrtg-provider:scaffold:capabilities"
+)
+
+const (
+	providerFile     = "internal/provider/provider.go"
+	capabilitiesFile = "internal/provider/capabilities.go"
+	conformanceDir   = "test/conformance"
+)
+
+// rpcStub is a provider RPC that starts out returning Unimplemented.
+type rpcStub struct {
+	Name     string
+	Request  string
+	Response string
+	// Doc is the method's doc comment, without the leading "// ".
+	Doc string
+	// Todo completes "TODO: Implement ... for <type>".
+	Todo string
+}
+
+// capabilitySpec is what one optional capability adds to a provider
+// project.
+type capabilitySpec struct {
+	// Flag is the capability the provider advertises and conformance
+	// tests require.
+	Flag capabilities.Capability
+	// Builder is the capabilities.Builder method that advertises Flag.
+	Builder string
+	// Requires names capabilities generated first.
+	Requires []string
+	RPCs     []rpcStub
+	// Steps is the body of the conformance test, run against a VM named
+	// {{.VM}} that is Ready.
+	Steps string
+}
+
+var snapshotRPCs = []rpcStub{
+	{"SnapshotCreate", "SnapshotCreateRequest", "SnapshotCreateResponse", "SnapshotCreate creates a VM snapshot.", "snapshot creation"},
+	{"SnapshotDelete", "SnapshotDeleteRequest", "TaskResponse", "SnapshotDelete deletes a VM snapshot.", "snapshot deletion"},
+	{"SnapshotRevert", "SnapshotRevertRequest", "TaskResponse", "SnapshotRevert reverts a VM to a snapshot.", "snapshot revert"},
+	{"SnapshotList", "SnapshotListRequest", "SnapshotListResponse", "SnapshotList lists the snapshots of a VM that exist on {{.ProviderType}}.", "snapshot listing"},
+}
+
+// capabilityCatalog holds the capabilities generate capability knows,
+// keyed by name: the capability flag with '-' for '_'.
+var capabilityCatalog = map[string]capabilitySpec{
+	"snapshots": {
+		Flag:    capabilities.CapabilitySnapshots,
+		Builder: "Snapshots",
+		RPCs:    snapshotRPCs,
+		Steps: `    - name: create-snapshot
+      type: create
+      timeout: 5m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMSnapshot
+        metadata:
+          name: {{.VM}}-snap
+          namespace: default
+        spec:
+          vmRef:
+            name: {{.VM}}
+      validate:
+        - path: .status.phase
+          operator: eq
+          value: Ready
+        - path: .status.snapshotID
+          operator: exists
+
+    - name: delete-snapshot
+      type: delete
+      timeout: 3m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMSnapshot
+        metadata:
+          name: {{.VM}}-snap
+          namespace: default
+`,
+	},
+	"memory-snapshots": {
+		Flag:     capabilities.CapabilityMemorySnapshots,
+		Builder:  "MemorySnapshots",
+		Requires: []string{"snapshots"},
+		Steps: `    - name: create-memory-snapshot
+      type: create
+      timeout: 5m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMSnapshot
+        metadata:
+          name: {{.VM}}-snap
+          namespace: default
+        spec:
+          vmRef:
+            name: {{.VM}}
+          snapshotConfig:
+            includeMemory: true
+      validate:
+        - path: .status.phase
+          operator: eq
+          value: Ready
+
+    - name: delete-memory-snapshot
+      type: delete
+      timeout: 3m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMSnapshot
+        metadata:
+          name: {{.VM}}-snap
+          namespace: default
+`,
+	},
+	"linked-clones": {
+		Flag:    capabilities.CapabilityLinkedClones,
+		Builder: "LinkedClones",
+		RPCs: []rpcStub{
+			{"Clone", "CloneRequest", "CloneResponse", "Clone clones a virtual machine.", "VM cloning"},
+		},
+		Steps: `    - name: create-linked-clone
+      type: create
+      timeout: 10m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMClone
+        metadata:
+          name: {{.VM}}-clone
+          namespace: default
+        spec:
+          source:
+            vmRef:
+              name: {{.VM}}
+          target:
+            name: {{.VM}}-linked
+          options:
+            type: LinkedClone
+      validate:
+        - path: .status.phase
+          operator: eq
+          value: Ready
+
+    - name: delete-clone
+      type: delete
+      timeout: 3m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: {{.VM}}-linked
+          namespace: default
+`,
+	},
+	"image-import": {
+		Flag:    capabilities.CapabilityImageImport,
+		Builder: "ImageImport",
+		RPCs: []rpcStub{
+			{"ImagePrepare", "ImagePrepareRequest", "ImagePrepareResponse", "ImagePrepare prepares an image for use and reports where the prepared\nimage lives.", "image preparation"},
+		},
+		Steps: `    - name: import-image
+      type: create
+      timeout: 15m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMImage
+        metadata:
+          name: {{.VM}}-image
+          namespace: default
+        spec:
+          source:
+            http:
+              url: "http://example.com/test.qcow2"
+          prepare:
+            onMissing: Import
+      validate:
+        - path: .status.phase
+          operator: eq
+          value: Ready
+
+    - name: delete-image
+      type: delete
+      timeout: 3m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VMImage
+        metadata:
+          name: {{.VM}}-image
+          namespace: default
+`,
+	},
+	"console-output": {
+		Flag:    capabilities.CapabilityConsoleOutput,
+		Builder: "ConsoleOutput",
+		RPCs: []rpcStub{
+			{"GetConsoleOutput", "GetConsoleOutputRequest", "GetConsoleOutputResponse", "GetConsoleOutput returns recent serial console output of a VM.", "console output capture"},
+		},
+		Steps: `    - name: request-console-log
+      type: update
+      timeout: 30s
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: {{.VM}}
+          namespace: default
+          annotations:
+            virtrigaud.io/console-log-request: "50"
+
+    - name: validate-console-log
+      type: validate
+      timeout: 1m
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: {{.VM}}-console-log
+          namespace: default
+      validate:
+        - path: .data.output
+          operator: exists
+`,
+	},
+}
+
+// typeCapabilities lists the capabilities init generates for each provider
+// type.
+var typeCapabilities = map[string][]string{
+	"vsphere": {"snapshots", "memory-snapshots", "linked-clones", "image-import"},
+	"libvirt": {"snapshots", "linked-clones"},
+	"qemu":    {"snapshots", "linked-clones"},
+}
+
+// CapabilityNames returns the names AddCapability accepts, sorted.
+func CapabilityNames() []string {
+	names := make([]string, 0, len(capabilityCatalog))
+	for name := range capabilityCatalog {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// AddCapability adds a capability to the provider project at
+// Config.TargetDir: Unimplemented stubs for each of its RPCs the provider
+// package does not define yet, above the rpcs marker in provider.go; a
+// builder call advertising it, above the capabilities marker in
+// capabilities.go; and a conformance test requiring it under
+// test/conformance, unless that file exists and Config.Force is unset.
+// Capabilities it requires are added first. Adding a capability again
+// changes nothing. It returns the files it wrote, relative to TargetDir.
+func (s *Scaffolder) AddCapability(name string) ([]string, error) {
+	name = strings.ReplaceAll(name, "_", "-")
+	spec, ok := capabilityCatalog[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown capability %q; known capabilities: %s", name, strings.Join(CapabilityNames(), ", "))
+	}
+
+	var written []string
+	for _, required := range spec.Requires {
+		files, err := s.AddCapability(required)
+		if err != nil {
+			return nil, err
+		}
+		written = appendNew(written, files...)
+	}
+
+	steps := []func(string, capabilitySpec) (string, error){s.addRPCStubs, s.addBuilderCall, s.addConformanceTest}
+	for _, step := range steps {
+		file, err := step(name, spec)
+		if err != nil {
+			return nil, fmt.Errorf("capability %s: %w", name, err)
+		}
+		if file != "" {
+			written = appendNew(written, file)
+		}
+	}
+	return written, nil
+}
+
+// addRPCStubs inserts the stubs of spec's RPCs that no Provider method
+// implements yet.
+func (s *Scaffolder) addRPCStubs(_ string, spec capabilitySpec) (string, error) {
+	defined, err := providerMethods(filepath.Join(s.config.TargetDir, filepath.Dir(providerFile)))
+	if err != nil {
+		return "", err
+	}
+
+	var stubs bytes.Buffer
+	for _, rpc := range spec.RPCs {
+		if defined[rpc.Name] {
+			continue
+		}
+		doc, err := s.render(rpc.Doc)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(doc, "\n") {
+			fmt.Fprintf(&stubs, "// %s\n", line)
+		}
+		fmt.Fprintf(&stubs, "func (p *Provider) %s(ctx context.Context, req *providerv1.%s) (*providerv1.%s, error) {\n", rpc.Name, rpc.Request, rpc.Response)
+		fmt.Fprintf(&stubs, "\t// TODO: Implement %s for %s\n", rpc.Todo, s.config.ProviderType)
+		fmt.Fprintf(&stubs, "\treturn nil, errors.NewUnimplemented(%q)\n}\n\n", rpc.Name)
+	}
+	if stubs.Len() == 0 {
+		return "", nil
+	}
+	return providerFile, s.insertAboveMarker(providerFile, rpcStubsMarker, stubs.String())
+}
+
+// addBuilderCall advertises spec.Flag unless GetProviderCapabilities
+// already calls spec.Builder.
+func (s *Scaffolder) addBuilderCall(_ string, spec capabilitySpec) (string, error) {
+	project, err := readCapabilities(filepath.Join(s.config.TargetDir, capabilitiesFile))
+	if err != nil {
+		return "", err
+	}
+	if project.builderCalls[spec.Builder] {
+		return "", nil
+	}
+	return capabilitiesFile, s.insertAboveMarker(capabilitiesFile, capabilitiesMarker, fmt.Sprintf("builder = builder.%s()\n", spec.Builder))
+}
+
+// addConformanceTest writes test/conformance/<name>.yaml, a test that
+// requires spec.Flag and exercises it on a fresh VM.
+func (s *Scaffolder) addConformanceTest(name string, spec capabilitySpec) (string, error) {
+	relativePath := filepath.ToSlash(filepath.Join(conformanceDir, name+".yaml"))
+	targetPath := filepath.Join(s.config.TargetDir, relativePath)
+	if !s.config.Force {
+		if _, err := os.Stat(targetPath); err == nil {
+			return "", nil
+		}
+	}
+
+	required := []string{string(capabilities.CapabilityCreate), string(capabilities.CapabilityDelete)}
+	for _, r := range spec.Requires {
+		required = append(required, string(capabilityCatalog[r].Flag))
+	}
+	required = append(required, string(spec.Flag))
+
+	vm := fmt.Sprintf("conformance-%s", name)
+	steps, err := s.renderWith(spec.Steps, map[string]any{"VM": vm})
+	if err != nil {
+		return "", err
+	}
+	content, err := s.renderWith(conformanceTestTemplate, map[string]any{
+		"Name":     name,
+		"Flag":     spec.Flag,
+		"Required": required,
+		"VM":       vm,
+		"Steps":    steps,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if existing, err := os.ReadFile(targetPath); err == nil && string(existing) == content {
+		return "", nil
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(targetPath, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", relativePath, err)
+	}
+	return relativePath, nil
+}
+
+// insertAboveMarker inserts code above the line holding marker in the Go
+// file at relativePath, indented like the marker, and gofmts the result.
+func (s *Scaffolder) insertAboveMarker(relativePath, marker, code string) error {
+	targetPath := filepath.Join(s.config.TargetDir, relativePath)
+	src, err := os.ReadFile(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", relativePath, err)
+	}
+
+	lines := strings.SplitAfter(string(src), "\n")
+	at := slices.IndexFunc(lines, func(line string) bool { return strings.TrimSpace(line) == marker })
+	if at < 0 {
+		return fmt.Errorf("%s has no %q line to generate code above", relativePath, marker)
+	}
+	indent := lines[at][:len(lines[at])-len(strings.TrimLeft(lines[at], " \t"))]
+
+	var out strings.Builder
+	for _, line := range lines[:at] {
+		out.WriteString(line)
+	}
+	for _, line := range strings.SplitAfter(code, "\n") {
+		if strings.TrimSpace(line) != "" {
+			out.WriteString(indent)
+		}
+		out.WriteString(line)
+	}
+	for _, line := range lines[at:] {
+		out.WriteString(line)
+	}
+
+	formatted, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return fmt.Errorf("generated code in %s does not parse: %w", relativePath, err)
+	}
+	if err := os.WriteFile(targetPath, formatted, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", relativePath, err)
+	}
+	return nil
+}
+
+// render executes tmplContent with the scaffold's template context.
+func (s *Scaffolder) render(tmplContent string) (string, error) {
+	return s.renderWith(tmplContent, s.createTemplateContext())
+}
+
+func (s *Scaffolder) renderWith(tmplContent string, data any) (string, error) {
+	tmpl, err := template.New("generate").Parse(tmplContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// providerMethods returns the names of the methods declared on Provider in
+// the non-test Go files of dir.
+func providerMethods(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	methods := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == "Provider" {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+	return methods, nil
+}
+
+func appendNew(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+const conformanceTestTemplate = `# Generated by vrtg-provider generate capability {{.Name}}. It assumes the
+# test-provider, test-class and test-image resources of the core tests.
+- name: {{.Name}}
+  description: Test the {{.Flag}} capability on a running VM
+  requiredCapabilities:
+{{- range .Required}}
+    - {{.}}
+{{- end}}
+  timeout: 20m
+  labels:
+    category: capability
+    capability: {{.Flag}}
+  steps:
+    - name: create-vm
+      type: create
+      timeout: 5m
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: {{.VM}}
+          namespace: default
+        spec:
+          providerRef:
+            name: test-provider
+          classRef:
+            name: test-class
+          imageRef:
+            name: test-image
+
+    - name: wait-vm-ready
+      type: wait
+      timeout: 3m
+      waitFor:
+        condition: Ready
+
+{{.Steps}}
+  cleanup:
+    - name: cleanup-vm
+      type: delete
+      resource:
+        apiVersion: infra.virtrigaud.io/v1beta1
+        kind: VirtualMachine
+        metadata:
+          name: {{.VM}}
+          namespace: default
+`
@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/conformance"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files under testdata")
+
+// snapshotFiles reads every regular file under dir, keyed by slash path
+// relative to dir.
+func snapshotFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+// assertGolden compares the files at paths under dir with
+// testdata/golden/<name>/<base name>.golden. Run with -update to rewrite
+// the golden files.
+func assertGolden(t *testing.T, name, dir string, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		got, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		golden := filepath.Join("testdata", "golden", name, filepath.Base(path)+".golden")
+		if *updateGolden {
+			require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+			require.NoError(t, os.WriteFile(golden, got, 0o644))
+		}
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err, "run go test -update to create %s", golden)
+		assert.Equal(t, string(want), string(got), "%s differs from %s", path, golden)
+	}
+}
+
+func TestAddCapability_Golden(t *testing.T) {
+	dir := generate(t, "generic")
+	s := New(Config{ProviderName: "acme", ProviderType: "generic", TargetDir: dir})
+
+	written, err := s.AddCapability("memory-snapshots")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/provider/provider.go",
+		"internal/provider/capabilities.go",
+		"test/conformance/snapshots.yaml",
+		"test/conformance/memory-snapshots.yaml",
+	}, written, "snapshots is generated first")
+	for _, name := range []string{"linked-clones", "console_output"} {
+		_, err := s.AddCapability(name)
+		require.NoError(t, err)
+	}
+
+	assertGolden(t, "capability", dir,
+		"internal/provider/provider.go",
+		"internal/provider/capabilities.go",
+		"test/conformance/snapshots.yaml",
+		"test/conformance/memory-snapshots.yaml",
+		"test/conformance/linked-clones.yaml",
+		"test/conformance/console-output.yaml",
+	)
+
+	// Generating again, even with --force, changes nothing.
+	before := snapshotFiles(t, dir)
+	s = New(Config{ProviderName: "acme", ProviderType: "generic", TargetDir: dir, Force: true})
+	for _, name := range []string{"snapshots", "memory-snapshots", "linked-clones", "console-output"} {
+		written, err := s.AddCapability(name)
+		require.NoError(t, err)
+		assert.Empty(t, written, name)
+	}
+	assert.Equal(t, before, snapshotFiles(t, dir))
+}
+
+// TestAddCapability_ConformanceTestsValidate checks every generated
+// conformance test against the spec validator vcts runs.
+func TestAddCapability_ConformanceTestsValidate(t *testing.T) {
+	dir := generate(t, "generic")
+	s := New(Config{ProviderName: "acme", ProviderType: "generic", TargetDir: dir})
+	for _, name := range CapabilityNames() {
+		_, err := s.AddCapability(name)
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, conformanceDir, name+".yaml")
+		tests, err := conformance.NewValidator().SetStrict(true).ValidateFile(path)
+		require.NoError(t, err, name)
+		require.Len(t, tests, 1)
+		assert.Contains(t, tests[0].RequiredCapabilities, string(capabilityCatalog[name].Flag))
+	}
+}
+
+// TestAddCapability_KeepsExistingCode checks that an RPC the author has
+// already implemented is not stubbed again.
+func TestAddCapability_KeepsExistingCode(t *testing.T) {
+	dir := generate(t, "generic")
+	impl := "package provider\n\n" +
+		"import (\n\t\"context\"\n\n\tproviderv1 \"github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1\"\n)\n\n" +
+		"func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*providerv1.CloneResponse, error) {\n\treturn &providerv1.CloneResponse{}, nil\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "internal/provider/clone.go"), []byte(impl), 0o644))
+
+	written, err := New(Config{ProviderType: "generic", TargetDir: dir}).AddCapability("linked-clones")
+	require.NoError(t, err)
+	assert.NotContains(t, written, providerFile)
+	assert.Contains(t, written, capabilitiesFile)
+
+	src, err := os.ReadFile(filepath.Join(dir, providerFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(src), "Clone(")
+}
+
+func TestAddCapability_Errors(t *testing.T) {
+	dir := generate(t, "generic")
+	s := New(Config{ProviderType: "generic", TargetDir: dir})
+
+	_, err := s.AddCapability("snapshot")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "known capabilities: console-output, image-import")
+
+	path := filepath.Join(dir, capabilitiesFile)
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.ReplaceAll(string(src), capabilitiesMarker, "")), 0o644))
+	_, err = s.AddCapability("linked-clones")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), capabilitiesMarker)
+}
+
+// TestGenerate_TypeCapabilities checks that init generates the
+// capabilities of the provider type, so generating them again is a no-op.
+func TestGenerate_TypeCapabilities(t *testing.T) {
+	dir := generate(t, "vsphere")
+
+	config, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", config.ProviderName)
+	assert.Equal(t, "vsphere", config.ProviderType)
+
+	methods, err := providerMethods(filepath.Join(dir, "internal/provider"))
+	require.NoError(t, err)
+	for _, rpc := range []string{"SnapshotCreate", "SnapshotList", "Clone", "ImagePrepare"} {
+		assert.True(t, methods[rpc], rpc)
+	}
+	assert.False(t, methods["GetConsoleOutput"])
+
+	before := snapshotFiles(t, dir)
+	for _, name := range typeCapabilities["vsphere"] {
+		written, err := New(config).AddCapability(name)
+		require.NoError(t, err)
+		assert.Empty(t, written, name)
+	}
+	assert.Equal(t, before, snapshotFiles(t, dir))
+}
+
+func TestGenerateCRDExamples_Golden(t *testing.T) {
+	for _, providerType := range []string{"vsphere", "libvirt", "generic"} {
+		t.Run(providerType, func(t *testing.T) {
+			dir := generate(t, providerType)
+			config, err := LoadConfig(dir)
+			require.NoError(t, err)
+
+			written, err := New(config).GenerateCRDExamples()
+			require.NoError(t, err)
+			assert.Len(t, written, 4)
+			assertGolden(t, filepath.Join("crd-examples", providerType), dir, written...)
+
+			before := snapshotFiles(t, dir)
+			config.Force = true
+			written, err = New(config).GenerateCRDExamples()
+			require.NoError(t, err)
+			assert.Empty(t, written)
+			assert.Equal(t, before, snapshotFiles(t, dir))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const examplesDir = "config/examples"
+
+// classDiskTypes maps provider disk types to the VMClass diskDefaults.type
+// they correspond to.
+var classDiskTypes = map[string]string{
+	"thin":             "thin",
+	"thick":            "thick",
+	"eager_zero":       "eagerzeroedthick",
+	"eagerzeroedthick": "eagerzeroedthick",
+	"ssd":              "ssd",
+	"hdd":              "hdd",
+	"nvme":             "nvme",
+}
+
+// imageFormats are the VMImage formats a provider disk type can name.
+var imageFormats = []string{"qcow2", "raw", "vmdk", "vhd", "vhdx"}
+
+// attachmentNetworkTypes maps provider network types to the
+// VMNetworkAttachment network.type they correspond to.
+var attachmentNetworkTypes = map[string]string{
+	"bridge":    "bridged",
+	"bridged":   "bridged",
+	"nat":       "nat",
+	"user":      "nat",
+	"isolated":  "isolated",
+	"host-only": "host-only",
+	"external":  "external",
+}
+
+// GenerateCRDExamples writes example Provider, VMClass, VMImage and
+// VMNetworkAttachment manifests for the provider project at
+// Config.TargetDir to config/examples, using the disk and network types
+// GetProviderCapabilities declares. Existing files are kept unless
+// Config.Force is set. It returns the files it wrote, relative to
+// TargetDir.
+func (s *Scaffolder) GenerateCRDExamples() ([]string, error) {
+	declared, err := readCapabilities(filepath.Join(s.config.TargetDir, capabilitiesFile))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := s.createTemplateContext()
+	ctx["DiskTypes"] = strings.Join(declared.diskTypes, ", ")
+	ctx["NetworkTypes"] = strings.Join(declared.networkTypes, ", ")
+	ctx["ClassDiskType"] = firstMapped(declared.diskTypes, classDiskTypes)
+	ctx["ImageFormat"] = ""
+	if i := slices.IndexFunc(declared.diskTypes, func(t string) bool { return slices.Contains(imageFormats, t) }); i >= 0 {
+		ctx["ImageFormat"] = declared.diskTypes[i]
+	}
+	ctx["NetworkType"] = firstMapped(declared.networkTypes, attachmentNetworkTypes)
+	ctx["NetworkName"] = "default"
+	if len(declared.networkTypes) > 0 {
+		ctx["NetworkName"] = declared.networkTypes[0]
+	}
+	// Provider.spec.type has no generic value.
+	ctx["CRDType"] = s.config.ProviderType
+	if s.config.ProviderType == "generic" {
+		ctx["CRDType"] = "qemu"
+	}
+
+	var written []string
+	for _, name := range []string{"provider", "vmclass", "vmimage", "vmnetworkattachment"} {
+		relativePath := filepath.ToSlash(filepath.Join(examplesDir, name+".yaml"))
+		targetPath := filepath.Join(s.config.TargetDir, relativePath)
+		if !s.config.Force {
+			if _, err := os.Stat(targetPath); err == nil {
+				continue
+			}
+		}
+
+		content, err := s.renderWith(exampleTemplates[name], ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", relativePath, err)
+		}
+		if existing, err := os.ReadFile(targetPath); err == nil && string(existing) == content {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(targetPath, []byte(content), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", relativePath, err)
+		}
+		written = append(written, relativePath)
+	}
+	return written, nil
+}
+
+// firstMapped returns the mapping of the first of types that m maps.
+func firstMapped(types []string, m map[string]string) string {
+	for _, t := range types {
+		if mapped, ok := m[t]; ok {
+			return mapped
+		}
+	}
+	return ""
+}
+
+const examplesHeader = `# Example for the {{.ProviderName}} provider ({{.ProviderType}}), generated by
+# vrtg-provider generate crd-examples.
+{{- if .DiskTypes}}
+# Declared disk types: {{.DiskTypes}}.
+{{- end}}
+{{- if .NetworkTypes}}
+# Declared network types: {{.NetworkTypes}}.
+{{- end}}
+`
+
+var exampleTemplates = map[string]string{
+	"provider": examplesHeader + `{{if eq .ProviderType "generic"}}# spec.type has no generic value; set the hypervisor family it manages.
+{{end}}apiVersion: infra.virtrigaud.io/v1beta1
+kind: Provider
+metadata:
+  name: {{.ProviderName}}
+  namespace: default
+spec:
+  type: {{.CRDType}}
+{{- if .IsVSphere}}
+  endpoint: https://vcenter.example.com
+{{- else if .IsLibvirt}}
+  endpoint: qemu+tcp://libvirt.example.com/system
+{{- else}}
+  endpoint: tcp://{{.ProviderName}}.example.com:9443
+{{- end}}
+  credentialSecretRef:
+    name: {{.ProviderName}}-credentials
+  runtime:
+    mode: Remote
+    image: provider-{{.ProviderName}}:latest
+    service:
+      port: 9443
+`,
+	"vmclass": examplesHeader + `apiVersion: infra.virtrigaud.io/v1beta1
+kind: VMClass
+metadata:
+  name: {{.ProviderName}}-small
+  namespace: default
+spec:
+  cpu: 2
+  memory: 4Gi
+  diskDefaults:
+{{- if .ClassDiskType}}
+    type: {{.ClassDiskType}}
+{{- end}}
+    size: 40Gi
+`,
+	"vmimage": examplesHeader + `apiVersion: infra.virtrigaud.io/v1beta1
+kind: VMImage
+metadata:
+  name: {{.ProviderName}}-ubuntu
+  namespace: default
+spec:
+  source:
+{{- if .IsVSphere}}
+    vsphere:
+      templateName: ubuntu-24.04-template
+{{- else if .IsLibvirt}}
+    libvirt:
+      url: https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img
+      format: {{or .ImageFormat "qcow2"}}
+{{- else}}
+    http:
+      url: https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img
+{{- end}}
+  prepare:
+    onMissing: Import
+`,
+	"vmnetworkattachment": examplesHeader + `apiVersion: infra.virtrigaud.io/v1beta1
+kind: VMNetworkAttachment
+metadata:
+  name: {{.ProviderName}}-{{.NetworkName}}
+  namespace: default
+spec:
+  network:
+{{- if .IsVSphere}}
+    vsphere:
+      portgroup: VM Network
+{{- else if .IsLibvirt}}
+    libvirt:
+      networkName: default
+{{- end}}
+{{- if .NetworkType}}
+    type: {{.NetworkType}}
+{{- end}}
+`,
+}
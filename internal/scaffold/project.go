@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// typeBuilders maps the capabilities.Builder method marking a provider's
+// type to that type. A project calling none of them is generic.
+var typeBuilders = map[string]string{
+	"VSphere":     "vsphere",
+	"Libvirt":     "libvirt",
+	"Firecracker": "firecracker",
+	"QEMU":        "qemu",
+}
+
+// declaredCapabilities is what GetProviderCapabilities in a scaffolded
+// project advertises.
+type declaredCapabilities struct {
+	// builderCalls holds every capabilities.Builder method it calls.
+	builderCalls map[string]bool
+	diskTypes    []string
+	networkTypes []string
+}
+
+// LoadConfig reads the Config of the provider project scaffolded into dir
+// back from it: the name from the module path in go.mod, and the type from
+// the capabilities GetProviderCapabilities advertises.
+func LoadConfig(dir string) (Config, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	var modulePath string
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			modulePath = strings.Trim(strings.TrimSpace(rest), `"`)
+			break
+		}
+	}
+	name, ok := strings.CutPrefix(modulePath, "provider-")
+	if !ok || name == "" {
+		return Config{}, fmt.Errorf("module %q is not a scaffolded provider (want provider-<name>)", modulePath)
+	}
+
+	declared, err := readCapabilities(filepath.Join(dir, capabilitiesFile))
+	if err != nil {
+		return Config{}, err
+	}
+	providerType := "generic"
+	for method, t := range typeBuilders {
+		if declared.builderCalls[method] {
+			providerType = t
+		}
+	}
+
+	return Config{ProviderName: name, ProviderType: providerType, TargetDir: dir}, nil
+}
+
+// readCapabilities parses GetProviderCapabilities in the file at path.
+func readCapabilities(path string) (*declaredCapabilities, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	var fn *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if d, ok := decl.(*ast.FuncDecl); ok && d.Recv == nil && d.Name.Name == "GetProviderCapabilities" {
+			fn = d
+		}
+	}
+	if fn == nil || fn.Body == nil {
+		return nil, fmt.Errorf("%s does not define GetProviderCapabilities", filepath.Base(path))
+	}
+
+	declared := &declaredCapabilities{builderCalls: map[string]bool{}}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		declared.builderCalls[sel.Sel.Name] = true
+		switch sel.Sel.Name {
+		case "DiskTypes":
+			declared.diskTypes = stringArgs(call)
+		case "NetworkTypes":
+			declared.networkTypes = stringArgs(call)
+		}
+		return true
+	})
+	return declared, nil
+}
+
+// stringArgs returns the string literal arguments of call.
+func stringArgs(call *ast.CallExpr) []string {
+	var args []string
+	for _, arg := range call.Args {
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			continue
+		}
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			args = append(args, s)
+		}
+	}
+	return args
+}
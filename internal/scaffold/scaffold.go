@@ -19,12 +19,25 @@ package scaffold
 
 import (
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 )
 
+// DefaultSDKVersion is the version of the virtrigaud sdk and proto modules
+// a generated go.mod requires when Config.SDKVersion is empty.
+const DefaultSDKVersion = "v0.1.0"
+
+// goVersion and grpcVersion are written to the generated go.mod. They track
+// the go directive and google.golang.org/grpc requirement of sdk/go.mod, so
+// the generated module builds against the SDK without a version bump.
+const (
+	goVersion   = "1.26.4"
+	grpcVersion = "v1.80.0"
+)
+
 // Config holds scaffolding configuration.
 type Config struct {
 	ProviderName string
@@ -32,6 +45,13 @@ type Config struct {
 	TargetDir    string
 	Remote       bool
 	Force        bool
+
+	// SDKVersion overrides DefaultSDKVersion.
+	SDKVersion string
+	// VirtrigaudPath, when set, is a local virtrigaud checkout the
+	// generated go.mod replaces the root, sdk and proto modules with, for
+	// building against unreleased SDK changes.
+	VirtrigaudPath string
 }
 
 // Scaffolder generates provider project structure.
@@ -57,6 +77,13 @@ func (s *Scaffolder) Generate() error {
 		}
 	}
 
+	// Generate the optional capabilities this provider type starts with
+	for _, name := range typeCapabilities[s.config.ProviderType] {
+		if _, err := s.AddCapability(name); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -67,6 +94,10 @@ func (s *Scaffolder) createTemplateContext() map[string]interface{} {
 	providerNameCamel := toCamelCase(providerName)
 	providerNameUpper := strings.ToUpper(strings.ReplaceAll(providerName, "-", "_"))
 	moduleName := fmt.Sprintf("provider-%s", providerName)
+	sdkVersion := s.config.SDKVersion
+	if sdkVersion == "" {
+		sdkVersion = DefaultSDKVersion
+	}
 
 	return map[string]interface{}{
 		"ProviderName":      providerName,
@@ -80,6 +111,10 @@ func (s *Scaffolder) createTemplateContext() map[string]interface{} {
 		"IsFirecracker":     s.config.ProviderType == "firecracker",
 		"IsQEMU":            s.config.ProviderType == "qemu",
 		"IsGeneric":         s.config.ProviderType == "generic",
+		"GoVersion":         goVersion,
+		"GRPCVersion":       grpcVersion,
+		"SDKVersion":        sdkVersion,
+		"VirtrigaudPath":    filepath.ToSlash(s.config.VirtrigaudPath),
 	}
 }
 
@@ -116,14 +151,24 @@ func (s *Scaffolder) generateFile(relativePath, tmplContent string, ctx map[stri
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(targetPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	var content strings.Builder
+	if err := tmpl.Execute(&content, ctx); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	out := []byte(content.String())
+
+	// Conditional template sections leave stray blank lines and alignment
+	// behind; gofmt the result so the project passes its own fmt check.
+	if strings.HasSuffix(targetPath, ".go") {
+		formatted, err := format.Source(out)
+		if err != nil {
+			return fmt.Errorf("generated code does not parse: %w", err)
+		}
+		out = formatted
 	}
-	defer file.Close() //nolint:errcheck // File close in defer is not critical
 
-	if err := tmpl.Execute(file, ctx); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if err := os.WriteFile(targetPath, out, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return nil
@@ -144,6 +189,8 @@ func (s *Scaffolder) getFileTemplates() map[string]string {
 		"internal/provider/provider.go":      providerTemplate,
 		"internal/provider/capabilities.go":  capabilitiesTemplate,
 		"internal/provider/provider_test.go": providerTestTemplate,
+		"internal/provider/fake.go":          fakeTemplate,
+		"internal/provider/fake_test.go":     fakeTestTemplate,
 	}
 }
 
@@ -160,7 +207,7 @@ func toCamelCase(s string) string {
 
 // Template constants (defined in separate files for clarity)
 const mainGoTemplate = `/*
-Copyright 2025.
+Copyright 2026.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -179,30 +226,67 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 
-	"github.com/projectbeskar/virtrigaud/sdk/provider/server"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
-	"github.com/projectbeskar/virtrigaud/internal/version"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/server"
+
 	"{{.ModuleName}}/internal/provider"
 )
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
-	// Handle --version flag
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
-		println("{{.ProviderName}}-provider", version.String())
+	showVersion := flag.Bool("version", false, "Print the version and exit")
+	port := flag.Int("port", 9443, "gRPC server port")
+	healthPort := flag.Int("health-port", 8080, "Health check server port")
+	fake := flag.Bool("fake", false, "Serve an in-memory fake instead of {{.ProviderType}}, for conformance runs")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("provider-{{.ProviderName}} %s\n", version)
 		os.Exit(0)
 	}
 
 	// Create logger
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: getLogLevel(),
 	}))
 
+	// Resolve TLS material and the auth contract from the mount path and
+	// environment the manager sets up for remote providers.
+	tlsResolution, tlsErr := server.ResolveTLSAndAuth()
+	switch {
+	case tlsErr == nil:
+		logger.Info("mTLS enabled")
+	case errors.Is(tlsErr, server.ErrInsecureModeOptedIn):
+		logger.Warn("Starting in plaintext mode; manager-provider traffic is neither encrypted nor authenticated",
+			"env", server.EnvInsecure,
+		)
+	default:
+		logger.Error("Failed to resolve TLS configuration", "error", tlsErr)
+		os.Exit(1)
+	}
+
+	// Audit record of the RPCs that change hypervisor state, or of all RPCs.
+	audit, err := middleware.AuditConfigFromEnv(logger)
+	if err != nil {
+		logger.Error("Invalid audit configuration", "error", err)
+		os.Exit(1)
+	}
+
 	// Create server configuration
 	config := server.DefaultConfig()
+	config.Port = *port
+	config.HealthPort = *healthPort
 	config.Logger = logger
+	config.TLS = tlsResolution.TLS
 	config.Middleware = &middleware.Config{
 		Logging: &middleware.LoggingConfig{
 			Enabled: true,
@@ -212,6 +296,8 @@ func main() {
 			Enabled: true,
 			Logger:  logger,
 		},
+		Auth:  tlsResolution.Auth,
+		Audit: audit,
 	}
 
 	// Create server
@@ -222,34 +308,65 @@ func main() {
 	}
 
 	// Create and register provider
-	providerImpl := provider.New()
-	srv.RegisterProvider(providerImpl)
+	var impl providerv1.ProviderServer = provider.New()
+	if *fake {
+		logger.Warn("Serving the in-memory fake; no {{.ProviderType}} resources will be touched")
+		impl = provider.NewFake()
+	}
+	srv.RegisterProvider(impl)
 
 	// Start server
-	logger.Info("Starting {{.ProviderNameCamel}} provider server", "version", version.String())
+	logger.Info("Starting {{.ProviderNameCamel}} provider server", "version", version, "port", config.Port, "fake", *fake)
 	if err := srv.Serve(context.Background()); err != nil {
 		logger.Error("Server failed", "error", err)
 		os.Exit(1)
 	}
-}`
+}
+
+// getLogLevel returns the log level from the LOG_LEVEL environment variable.
+func getLogLevel() slog.Level {
+	switch os.Getenv("LOG_LEVEL") {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+`
 
 const goModTemplate = `module {{.ModuleName}}
 
-go 1.23
+go {{.GoVersion}}
 
 require (
-	github.com/projectbeskar/virtrigaud v0.1.0
+	github.com/projectbeskar/virtrigaud/proto {{.SDKVersion}}
+	github.com/projectbeskar/virtrigaud/sdk {{.SDKVersion}}
+	google.golang.org/grpc {{.GRPCVersion}}
 )
-
-// For local development, uncomment and adjust path:
-// replace github.com/projectbeskar/virtrigaud => ../../
-`
+{{if .VirtrigaudPath}}
+// Build against a local virtrigaud checkout.
+replace (
+	github.com/projectbeskar/virtrigaud => {{.VirtrigaudPath}}
+	github.com/projectbeskar/virtrigaud/proto => {{.VirtrigaudPath}}/proto
+	github.com/projectbeskar/virtrigaud/sdk => {{.VirtrigaudPath}}/sdk
+)
+{{else}}
+// To build against a local virtrigaud checkout, uncomment and adjust:
+// replace (
+// 	github.com/projectbeskar/virtrigaud => ../virtrigaud
+// 	github.com/projectbeskar/virtrigaud/proto => ../virtrigaud/proto
+// 	github.com/projectbeskar/virtrigaud/sdk => ../virtrigaud/sdk
+// )
+{{end}}`
 
 const makefileTemplate = `# Makefile for {{.ProviderNameCamel}} Provider
 
-VERSION ?= $(shell git describe --tags --always --dirty)
-GIT_SHA ?= $(shell git rev-parse HEAD)
-LDFLAGS := -X github.com/projectbeskar/virtrigaud/internal/version.Version=$(VERSION) -X github.com/projectbeskar/virtrigaud/internal/version.GitSHA=$(GIT_SHA)
+VERSION ?= $(shell git describe --tags --always --dirty 2>/dev/null || echo dev)
+LDFLAGS := -X main.version=$(VERSION)
 
 .PHONY: all
 all: build
@@ -274,9 +391,13 @@ fmt:
 vet:
 	go vet ./...
 
+.PHONY: run-fake
+run-fake: build
+	VIRTRIGAUD_PROVIDER_INSECURE=true ./bin/provider-{{.ProviderName}} --fake
+
 .PHONY: docker-build
 docker-build:
-	docker build -t provider-{{.ProviderName}}:$(VERSION) .
+	docker build --build-arg VERSION=$(VERSION) -t provider-{{.ProviderName}}:$(VERSION) .
 
 .PHONY: clean
 clean:
@@ -299,6 +420,7 @@ help:
 	@echo "  lint         - Run linter"
 	@echo "  fmt          - Format code"
 	@echo "  vet          - Run go vet"
+	@echo "  run-fake     - Run the in-memory fake for conformance tests"
 	@echo "  docker-build - Build Docker image"
 	@echo "  clean        - Clean build artifacts"
 	@echo "  deps         - Download dependencies"
@@ -306,7 +428,7 @@ help:
 `
 
 const dockerfileTemplate = `# Build stage
-FROM golang:1.25-bookworm AS builder
+FROM golang:{{.GoVersion}}-bookworm AS builder
 
 WORKDIR /workspace
 
@@ -324,9 +446,8 @@ COPY . .
 ARG TARGETOS
 ARG TARGETARCH
 ARG VERSION=dev
-ARG GIT_SHA=unknown
 RUN CGO_ENABLED=0 GOOS=${TARGETOS:-linux} GOARCH=${TARGETARCH} go build \
-    -ldflags "-X github.com/projectbeskar/virtrigaud/internal/version.Version=${VERSION} -X github.com/projectbeskar/virtrigaud/internal/version.GitSHA=${GIT_SHA}" \
+    -ldflags "-X main.version=${VERSION}" \
     -a -o provider-{{.ProviderName}} .
 
 # Runtime stage
@@ -369,6 +490,7 @@ This provider implements the VirtRigaud provider interface to manage virtual mac
 ### Building
 
 '''bash
+go mod tidy
 make build
 '''
 
@@ -410,6 +532,7 @@ The provider accepts the following configuration:
 |-----------|-------------|---------|
 | --port | gRPC server port | 9443 |
 | --health-port | Health check port | 8080 |
+| --fake | Serve the in-memory fake instead of {{.ProviderType}} | false |
 
 {{if .IsVSphere}}
 ### vSphere Configuration
@@ -437,7 +560,7 @@ export LIBVIRT_URI=qemu:///system
 
 ### Prerequisites
 
-- Go 1.23+
+- Go {{.GoVersion}}+
 - Docker
 - kubectl (for Kubernetes deployment)
 {{if .IsVSphere}}- vSphere environment access{{end}}
@@ -448,12 +571,31 @@ export LIBVIRT_URI=qemu:///system
 '''
 ├── main.go                    # Entry point
 ├── internal/
-│   └── provider/             # Provider implementation
+│   └── provider/             # Provider implementation and in-memory fake
 ├── config/                   # Kubernetes manifests
+├── test/conformance/         # Conformance tests of optional capabilities
 ├── Dockerfile               # Container build
 └── Makefile                # Build targets
 '''
 
+### Adding Capabilities
+
+Add the RPC stubs, capability advertisement and conformance test of an
+optional capability:
+
+'''bash
+vrtg-provider generate capability snapshots
+'''
+
+Generated code goes above the '+vrtg-provider:scaffold' markers in
+'internal/provider'; keep them in place. Example Provider, VMClass, VMImage
+and VMNetworkAttachment manifests for the declared disk and network types
+are written to 'config/examples' by:
+
+'''bash
+vrtg-provider generate crd-examples
+'''
+
 ### Testing
 
 Run the full test suite:
@@ -468,6 +610,17 @@ Run conformance tests:
 vrtg-provider verify --profile core
 '''
 
+### Fake Mode
+
+'--fake' serves an in-memory implementation of the core profile (Create,
+Describe, Power, Delete) from 'internal/provider/fake.go' instead of
+{{.ProviderType}}. Point a Provider resource at a fake-mode deployment to run
+the conformance suite before the {{.ProviderType}} integration exists:
+
+'''bash
+make run-fake
+'''
+
 ## Contributing
 
 1. Fork the repository
@@ -527,7 +680,7 @@ on:
     branches: [ main, develop ]
 
 {{ "env:" }}
-  GO_VERSION: '1.23'
+  GO_VERSION: '{{.GoVersion}}'
 
 jobs:
   test:
@@ -693,7 +846,7 @@ spec:
 # No service needed{{end}}`
 
 const providerTemplate = `/*
-Copyright 2025.
+Copyright 2026.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -708,52 +861,42 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package provider implements the {{.ProviderNameCamel}} provider. Every RPC
+// starts out returning Unimplemented; replace the bodies as the {{.ProviderType}}
+// integration grows. The RPCs of optional capabilities are added by
+// "vrtg-provider generate capability <name>", which also advertises the
+// capability in capabilities.go.
 package provider
 
 import (
 	"context"
-	"fmt"
 
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
-	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
 )
 
+var _ providerv1.ProviderServer = (*Provider)(nil)
+
 // Provider implements the {{.ProviderNameCamel}} provider.
 type Provider struct {
 	providerv1.UnimplementedProviderServer
 	capabilities *capabilities.Manager
+	tasks        *tasks.Tracker
 }
 
 // New creates a new {{.ProviderNameCamel}} provider.
 func New() *Provider {
-	// Build capabilities for this provider
-	caps := capabilities.NewBuilder().
-		Core().
-		{{if eq .ProviderType "vsphere"}}VSphere().
-		Snapshots().
-		LinkedClones().
-		OnlineReconfigure().
-		DiskTypes("thin", "thick", "eager_zero").
-		NetworkTypes("distributed", "standard").{{end}}
-		{{if eq .ProviderType "libvirt"}}Libvirt().
-		Snapshots().
-		LinkedClones().
-		DiskTypes("qcow2", "raw").
-		NetworkTypes("bridge", "nat").{{end}}
-		{{if eq .ProviderType "firecracker"}}Firecracker().
-		DiskTypes("raw").
-		NetworkTypes("tap").{{end}}
-		{{if eq .ProviderType "qemu"}}QEMU().
-		Snapshots().
-		DiskTypes("qcow2", "raw", "vmdk").
-		NetworkTypes("bridge", "user").{{end}}
-		{{if eq .ProviderType "generic"}}DiskTypes("raw").
-		NetworkTypes("bridge").{{end}}
-		Build()
+	// Task records survive a restart when the manager mounted a task store.
+	store, err := tasks.OpenStore()
+	if err != nil {
+		store = tasks.NewMemoryStore()
+	}
 
 	return &Provider{
-		capabilities: caps,
+		capabilities: GetProviderCapabilities(),
+		tasks:        tasks.NewTracker(store),
 	}
 }
 
@@ -766,7 +909,9 @@ func (p *Provider) Validate(ctx context.Context, req *providerv1.ValidateRequest
 	}, nil
 }
 
-// Create creates a new virtual machine.
+// Create creates a new virtual machine. A request repeating the
+// IdempotencyKey of an earlier one must return that VM instead of creating
+// a second: the manager retries Create when a response is lost.
 func (p *Provider) Create(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
 	// TODO: Implement VM creation for {{.ProviderType}}
 	return nil, errors.NewUnimplemented("Create")
@@ -784,62 +929,87 @@ func (p *Provider) Power(ctx context.Context, req *providerv1.PowerRequest) (*pr
 	return nil, errors.NewUnimplemented("Power")
 }
 
-// Reconfigure reconfigures a virtual machine.
-func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+// Reconfigure applies req.Changes, the fields that differ from what was
+// last applied, and reports in PowerCycleRequired any it could not apply
+// to the running VM.
+func (p *Provider) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	// TODO: Implement VM reconfiguration for {{.ProviderType}}
 	return nil, errors.NewUnimplemented("Reconfigure")
 }
 
+// HardwareUpgrade upgrades a virtual machine's hardware version.
+func (p *Provider) HardwareUpgrade(ctx context.Context, req *providerv1.HardwareUpgradeRequest) (*providerv1.TaskResponse, error) {
+	// TODO: Implement hardware upgrades for {{.ProviderType}}, if it versions hardware
+	return nil, errors.NewUnimplemented("HardwareUpgrade")
+}
+
 // Describe describes a virtual machine's current state.
 func (p *Provider) Describe(ctx context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
 	// TODO: Implement VM description for {{.ProviderType}}
 	return nil, errors.NewUnimplemented("Describe")
 }
 
-// TaskStatus checks the status of an async task.
+// TaskStatus checks the status of an async task. Record tasks with
+// p.tasks.Begin when starting them and p.tasks.Finish when they end, or
+// replace this with a lookup of the {{.ProviderType}} task if its IDs
+// survive a provider restart.
 func (p *Provider) TaskStatus(ctx context.Context, req *providerv1.TaskStatusRequest) (*providerv1.TaskStatusResponse, error) {
-	// TODO: Implement task status checking for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("TaskStatus")
+	return p.tasks.Status(ctx, req.GetTask().GetId())
 }
 
-// SnapshotCreate creates a VM snapshot.
-func (p *Provider) SnapshotCreate(ctx context.Context, req *providerv1.SnapshotCreateRequest) (*providerv1.SnapshotCreateResponse, error) {
-	// TODO: Implement snapshot creation for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("SnapshotCreate")
+// GetCapabilities returns the provider's capabilities.
+func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
+	return p.capabilities.GetCapabilities(ctx, req)
 }
 
-// SnapshotDelete deletes a VM snapshot.
-func (p *Provider) SnapshotDelete(ctx context.Context, req *providerv1.SnapshotDeleteRequest) (*providerv1.TaskResponse, error) {
-	// TODO: Implement snapshot deletion for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("SnapshotDelete")
+// ExportDisk exports a VM disk for migration.
+func (p *Provider) ExportDisk(ctx context.Context, req *providerv1.ExportDiskRequest) (*providerv1.ExportDiskResponse, error) {
+	// TODO: Implement disk export for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("ExportDisk")
 }
 
-// SnapshotRevert reverts a VM to a snapshot.
-func (p *Provider) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotRevertRequest) (*providerv1.TaskResponse, error) {
-	// TODO: Implement snapshot revert for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("SnapshotRevert")
+// ImportDisk imports a disk exported by another provider.
+func (p *Provider) ImportDisk(ctx context.Context, req *providerv1.ImportDiskRequest) (*providerv1.ImportDiskResponse, error) {
+	// TODO: Implement disk import for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("ImportDisk")
 }
 
-// Clone clones a virtual machine.
-func (p *Provider) Clone(ctx context.Context, req *providerv1.CloneRequest) (*providerv1.CloneResponse, error) {
-	// TODO: Implement VM cloning for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("Clone")
+// GetDiskInfo reports the size and format of a VM disk.
+func (p *Provider) GetDiskInfo(ctx context.Context, req *providerv1.GetDiskInfoRequest) (*providerv1.GetDiskInfoResponse, error) {
+	// TODO: Implement disk inspection for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("GetDiskInfo")
 }
 
-// ImagePrepare prepares an image for use.
-func (p *Provider) ImagePrepare(ctx context.Context, req *providerv1.ImagePrepareRequest) (*providerv1.TaskResponse, error) {
-	// TODO: Implement image preparation for {{.ProviderType}}
-	return nil, errors.NewUnimplemented("ImagePrepare")
+// ListVMs lists the VMs this provider manages.
+func (p *Provider) ListVMs(ctx context.Context, req *providerv1.ListVMsRequest) (*providerv1.ListVMsResponse, error) {
+	// TODO: Implement VM listing for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("ListVMs")
 }
 
-// GetCapabilities returns the provider's capabilities.
-func (p *Provider) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
-	return p.capabilities.GetCapabilities(ctx, req)
+// GetCloudInitStatus reports cloud-init's status inside a VM's guest.
+func (p *Provider) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	// TODO: Implement cloud-init status through the guest agent for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("GetCloudInitStatus")
+}
+
+// GetCapacity reports {{.ProviderType}} capacity and utilization.
+func (p *Provider) GetCapacity(ctx context.Context, req *providerv1.GetCapacityRequest) (*providerv1.GetCapacityResponse, error) {
+	// TODO: Implement capacity reporting for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("GetCapacity")
 }
+
+// GetInfo reports the provider build and the {{.ProviderType}} product and
+// version it is connected to.
+func (p *Provider) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	// TODO: Implement build and hypervisor reporting for {{.ProviderType}}
+	return nil, errors.NewUnimplemented("GetInfo")
+}
+
+// +vrtg-provider:scaffold:rpcs
 `
 
 const capabilitiesTemplate = `/*
-Copyright 2025.
+Copyright 2026.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -861,26 +1031,22 @@ import (
 )
 
 // GetProviderCapabilities returns the capabilities for this provider type.
+// The manager and the conformance suite pick operations and tests from
+// what is advertised here, so add a capability only once its RPCs work.
 func GetProviderCapabilities() *capabilities.Manager {
 	builder := capabilities.NewBuilder().Core()
 
 	{{if eq .ProviderType "vsphere"}}// vSphere-specific capabilities
 	builder = builder.
 		VSphere().
-		Snapshots().
-		MemorySnapshots().
-		LinkedClones().
 		OnlineReconfigure().
 		OnlineDiskExpansion().
-		ImageImport().
 		DiskTypes("thin", "thick", "eager_zero").
 		NetworkTypes("distributed", "standard", "vlan"){{end}}
 
 	{{if eq .ProviderType "libvirt"}}// Libvirt-specific capabilities
 	builder = builder.
 		Libvirt().
-		Snapshots().
-		LinkedClones().
 		OnlineReconfigure().
 		DiskTypes("qcow2", "raw", "vmdk").
 		NetworkTypes("bridge", "nat", "ovs"){{end}}
@@ -894,8 +1060,6 @@ func GetProviderCapabilities() *capabilities.Manager {
 	{{if eq .ProviderType "qemu"}}// QEMU-specific capabilities
 	builder = builder.
 		QEMU().
-		Snapshots().
-		LinkedClones().
 		DiskTypes("qcow2", "raw", "vmdk", "vdi").
 		NetworkTypes("bridge", "user", "tap"){{end}}
 
@@ -904,12 +1068,15 @@ func GetProviderCapabilities() *capabilities.Manager {
 		DiskTypes("raw").
 		NetworkTypes("bridge"){{end}}
 
+	// Capabilities added by vrtg-provider generate capability.
+	// +vrtg-provider:scaffold:capabilities
+
 	return builder.Build()
 }
 `
 
 const providerTestTemplate = `/*
-Copyright 2025.
+Copyright 2026.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -930,7 +1097,11 @@ import (
 	"context"
 	"testing"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
 )
 
 func TestProvider_Validate(t *testing.T) {
@@ -984,52 +1155,286 @@ func TestProvider_GetCapabilities(t *testing.T) {
 	}
 }
 
-func TestProvider_UnimplementedOperations(t *testing.T) {
-	provider := New()
+// TestProvider_UnknownTaskRef checks the TaskStatus contract for a task
+// issued before a restart: it must stay pending, not fail.
+func TestProvider_UnknownTaskRef(t *testing.T) {
+	if err := tasks.CheckUnknownRef(context.Background(), New(), "task-issued-before-restart"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestProvider_UnimplementedRPCs checks that every RPC still stubbed out
+// fails with Unimplemented, which the manager treats as "not supported"
+// rather than as a retryable fault. Delete an RPC's row once it is
+// implemented and covered by its own test.
+func TestProvider_UnimplementedRPCs(t *testing.T) {
+	p := New()
 	ctx := context.Background()
 
-	// Test that unimplemented operations return appropriate errors
 	tests := []struct {
-		name string
-		fn   func() error
+		rpc  string
+		call func() error
 	}{
-		{
-			name: "Create",
-			fn: func() error {
-				_, err := provider.Create(ctx, &providerv1.CreateRequest{})
-				return err
-			},
-		},
-		{
-			name: "Delete",
-			fn: func() error {
-				_, err := provider.Delete(ctx, &providerv1.DeleteRequest{})
-				return err
-			},
-		},
-		{
-			name: "Power",
-			fn: func() error {
-				_, err := provider.Power(ctx, &providerv1.PowerRequest{})
-				return err
-			},
-		},
-		{
-			name: "Describe",
-			fn: func() error {
-				_, err := provider.Describe(ctx, &providerv1.DescribeRequest{})
-				return err
-			},
-		},
+		{"Create", func() error { _, err := p.Create(ctx, &providerv1.CreateRequest{}); return err }},
+		{"Delete", func() error { _, err := p.Delete(ctx, &providerv1.DeleteRequest{}); return err }},
+		{"Power", func() error { _, err := p.Power(ctx, &providerv1.PowerRequest{}); return err }},
+		{"Reconfigure", func() error { _, err := p.Reconfigure(ctx, &providerv1.ReconfigureRequest{}); return err }},
+		{"HardwareUpgrade", func() error { _, err := p.HardwareUpgrade(ctx, &providerv1.HardwareUpgradeRequest{}); return err }},
+		{"Describe", func() error { _, err := p.Describe(ctx, &providerv1.DescribeRequest{}); return err }},
+		{"SnapshotCreate", func() error { _, err := p.SnapshotCreate(ctx, &providerv1.SnapshotCreateRequest{}); return err }},
+		{"SnapshotDelete", func() error { _, err := p.SnapshotDelete(ctx, &providerv1.SnapshotDeleteRequest{}); return err }},
+		{"SnapshotRevert", func() error { _, err := p.SnapshotRevert(ctx, &providerv1.SnapshotRevertRequest{}); return err }},
+		{"SnapshotList", func() error { _, err := p.SnapshotList(ctx, &providerv1.SnapshotListRequest{}); return err }},
+		{"Clone", func() error { _, err := p.Clone(ctx, &providerv1.CloneRequest{}); return err }},
+		{"ImagePrepare", func() error { _, err := p.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{}); return err }},
+		{"ExportDisk", func() error { _, err := p.ExportDisk(ctx, &providerv1.ExportDiskRequest{}); return err }},
+		{"ImportDisk", func() error { _, err := p.ImportDisk(ctx, &providerv1.ImportDiskRequest{}); return err }},
+		{"GetDiskInfo", func() error { _, err := p.GetDiskInfo(ctx, &providerv1.GetDiskInfoRequest{}); return err }},
+		{"ListVMs", func() error { _, err := p.ListVMs(ctx, &providerv1.ListVMsRequest{}); return err }},
+		{"GetConsoleOutput", func() error { _, err := p.GetConsoleOutput(ctx, &providerv1.GetConsoleOutputRequest{}); return err }},
+		{"GetCloudInitStatus", func() error { _, err := p.GetCloudInitStatus(ctx, &providerv1.GetCloudInitStatusRequest{}); return err }},
+		{"GetCapacity", func() error { _, err := p.GetCapacity(ctx, &providerv1.GetCapacityRequest{}); return err }},
+		{"GetInfo", func() error { _, err := p.GetInfo(ctx, &providerv1.GetInfoRequest{}); return err }},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := tt.fn()
-			if err == nil {
-				t.Errorf("Expected %s to return unimplemented error", tt.name)
+		t.Run(tt.rpc, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != codes.Unimplemented {
+				t.Errorf("%s returned code %v, want %v", tt.rpc, code, codes.Unimplemented)
 			}
 		})
 	}
 }
 `
+
+const fakeTemplate = `/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// Fake is an in-memory provider that implements the core conformance
+// profile (Create, Describe, Power and Delete, each completing at once) so
+// the deployment, the Provider resource and the conformance suite can be
+// exercised before the {{.ProviderType}} integration exists. main serves it
+// with --fake. Every other RPC falls through to Provider.
+type Fake struct {
+	*Provider
+
+	mu     sync.Mutex
+	nextID int
+	vms    map[string]*fakeVM
+}
+
+type fakeVM struct {
+	name       string
+	key        string
+	powerState string
+	ip         string
+}
+
+// NewFake creates a Fake with no VMs. It advertises only the core profile,
+// whatever GetProviderCapabilities advertises for the real provider.
+func NewFake() *Fake {
+	p := New()
+	p.capabilities = capabilities.NewBuilder().
+		Core().
+		DiskTypes("raw").
+		NetworkTypes("bridge").
+		Build()
+	return &Fake{Provider: p, vms: map[string]*fakeVM{}}
+}
+
+// Create adds a powered-off VM. A request repeating the idempotency key
+// of an existing VM (or, without a key, its name) returns that VM, as the
+// manager retries Create after a lost response.
+func (f *Fake) Create(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
+	if req.GetName() == "" {
+		return nil, errors.NewInvalidSpec("name is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := req.GetIdempotencyKey()
+	for id, vm := range f.vms {
+		if (key != "" && vm.key == key) || (key == "" && vm.name == req.GetName()) {
+			return &providerv1.CreateResponse{Id: id}, nil
+		}
+	}
+
+	f.nextID++
+	id := fmt.Sprintf("fake-%d", f.nextID)
+	f.vms[id] = &fakeVM{
+		name:       req.GetName(),
+		key:        key,
+		powerState: "Off",
+		// 192.0.2.0/24 is reserved for documentation, so it never collides
+		// with a real guest.
+		ip: fmt.Sprintf("192.0.2.%d", f.nextID%254+1),
+	}
+	return &providerv1.CreateResponse{Id: id}, nil
+}
+
+// Delete removes a VM. Deleting a VM that does not exist succeeds.
+func (f *Fake) Delete(ctx context.Context, req *providerv1.DeleteRequest) (*providerv1.TaskResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.vms, req.GetId())
+	return &providerv1.TaskResponse{}, nil
+}
+
+// Power changes a VM's power state.
+func (f *Fake) Power(ctx context.Context, req *providerv1.PowerRequest) (*providerv1.TaskResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	vm, ok := f.vms[req.GetId()]
+	if !ok {
+		return nil, errors.NewNotFound("VirtualMachine", req.GetId())
+	}
+
+	switch req.GetOp() {
+	case providerv1.PowerOp_POWER_OP_ON, providerv1.PowerOp_POWER_OP_REBOOT, providerv1.PowerOp_POWER_OP_RESET:
+		vm.powerState = "On"
+	case providerv1.PowerOp_POWER_OP_OFF, providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL:
+		vm.powerState = "Off"
+	default:
+		return nil, errors.NewInvalidSpec("unsupported power operation %v", req.GetOp())
+	}
+	return &providerv1.TaskResponse{}, nil
+}
+
+// Describe reports a VM's power state, and its IP while it is on.
+func (f *Fake) Describe(ctx context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	vm, ok := f.vms[req.GetId()]
+	if !ok {
+		return &providerv1.DescribeResponse{Exists: false}, nil
+	}
+
+	resp := &providerv1.DescribeResponse{Exists: true, PowerState: vm.powerState}
+	if vm.powerState == "On" {
+		resp.Ips = []string{vm.ip}
+	}
+	return resp, nil
+}
+`
+
+const fakeTestTemplate = `/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestFake_Lifecycle(t *testing.T) {
+	f := NewFake()
+	ctx := context.Background()
+
+	created, err := f.Create(ctx, &providerv1.CreateRequest{Name: "vm-1"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	again, err := f.Create(ctx, &providerv1.CreateRequest{Name: "vm-1"})
+	if err != nil {
+		t.Fatalf("repeated Create failed: %v", err)
+	}
+	if again.Id != created.Id {
+		t.Errorf("repeated Create returned %q, want existing VM %q", again.Id, created.Id)
+	}
+	keyed, err := f.Create(ctx, &providerv1.CreateRequest{Name: "vm-2", IdempotencyKey: "uid-2"})
+	if err != nil {
+		t.Fatalf("keyed Create failed: %v", err)
+	}
+	retried, err := f.Create(ctx, &providerv1.CreateRequest{Name: "vm-2", IdempotencyKey: "uid-2"})
+	if err != nil {
+		t.Fatalf("retried keyed Create failed: %v", err)
+	}
+	if retried.Id != keyed.Id {
+		t.Errorf("Create repeating a key returned %q, want %q", retried.Id, keyed.Id)
+	}
+	if _, err := f.Delete(ctx, &providerv1.DeleteRequest{Id: keyed.Id}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	desc, err := f.Describe(ctx, &providerv1.DescribeRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("Describe failed: %v", err)
+	}
+	if !desc.Exists || desc.PowerState != "Off" {
+		t.Errorf("new VM: exists=%t power=%q, want exists=true power=Off", desc.Exists, desc.PowerState)
+	}
+
+	if _, err := f.Power(ctx, &providerv1.PowerRequest{Id: created.Id, Op: providerv1.PowerOp_POWER_OP_ON}); err != nil {
+		t.Fatalf("Power on failed: %v", err)
+	}
+	desc, err = f.Describe(ctx, &providerv1.DescribeRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("Describe failed: %v", err)
+	}
+	if desc.PowerState != "On" || len(desc.Ips) == 0 {
+		t.Errorf("powered-on VM: power=%q ips=%v, want power=On with an IP", desc.PowerState, desc.Ips)
+	}
+
+	if _, err := f.Delete(ctx, &providerv1.DeleteRequest{Id: created.Id}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	desc, err = f.Describe(ctx, &providerv1.DescribeRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("Describe after Delete failed: %v", err)
+	}
+	if desc.Exists {
+		t.Error("VM still exists after Delete")
+	}
+}
+
+func TestFake_AdvertisesCoreOnly(t *testing.T) {
+	resp, err := NewFake().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities failed: %v", err)
+	}
+	if resp.SupportsSnapshots || resp.SupportsLinkedClones || resp.SupportsReconfigureOnline {
+		t.Errorf("fake advertises more than the core profile: %+v", resp)
+	}
+}
+`
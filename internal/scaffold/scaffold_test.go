@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoRoot is the virtrigaud checkout this package lives in.
+func repoRoot(t *testing.T) string {
+	t.Helper()
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	return root
+}
+
+func generate(t *testing.T, providerType string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := New(Config{
+		ProviderName:   "acme",
+		ProviderType:   providerType,
+		TargetDir:      dir,
+		VirtrigaudPath: repoRoot(t),
+	}).Generate()
+	require.NoError(t, err)
+	return dir
+}
+
+func TestGenerate_GoFilesAreFormatted(t *testing.T) {
+	for _, providerType := range []string{"vsphere", "libvirt", "firecracker", "qemu", "generic"} {
+		t.Run(providerType, func(t *testing.T) {
+			dir := generate(t, providerType)
+			err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+					return err
+				}
+				src, err := os.ReadFile(path)
+				require.NoError(t, err)
+				formatted, err := format.Source(src)
+				require.NoError(t, err, path)
+				assert.Equal(t, string(formatted), string(src), "%s is not gofmt-clean", path)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerate_GoModTracksSDK(t *testing.T) {
+	sdkMod, err := os.ReadFile(filepath.Join(repoRoot(t), "sdk", "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sdkMod), "\ngo "+goVersion+"\n", "goVersion no longer matches sdk/go.mod")
+	assert.Contains(t, string(sdkMod), "google.golang.org/grpc "+grpcVersion+"\n", "grpcVersion no longer matches sdk/go.mod")
+
+	goMod, err := os.ReadFile(filepath.Join(generate(t, "generic"), "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/projectbeskar/virtrigaud/sdk "+DefaultSDKVersion)
+	assert.Contains(t, string(goMod), "github.com/projectbeskar/virtrigaud/sdk => "+filepath.ToSlash(repoRoot(t))+"/sdk")
+}
+
+// TestGenerate_BuildsAndPasses builds the generated module against this
+// checkout and runs its tests, so a template that stops compiling or an
+// SDK change that breaks scaffolded providers fails here.
+func TestGenerate_BuildsAndPasses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a generated module")
+	}
+	dir := generate(t, "vsphere")
+
+	for _, args := range [][]string{{"vet", "./..."}, {"test", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "go %s:\n%s", strings.Join(args, " "), out)
+	}
+}
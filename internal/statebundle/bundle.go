@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statebundle exports virtrigaud's desired state to a portable YAML
+// bundle and imports it into another cluster, for rebuilding a management
+// cluster after a disaster.
+//
+// A VirtualMachine's link to its hypervisor VM lives in status.id, which a
+// backup of specs loses. Export records it, and the Provider the VM was
+// bound to, as annotations. Import recreates such VMs with
+// spec.adoptExisting, so the controller reattaches to the existing VM
+// instead of creating a second one.
+package statebundle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+const (
+	// ProviderIDAnnotation records a VirtualMachine's status.id at export.
+	ProviderIDAnnotation = "virtrigaud.io/exported-provider-id"
+	// ProviderAnnotation records, as namespace/name, the Provider a
+	// VirtualMachine was bound to at export.
+	ProviderAnnotation = "virtrigaud.io/exported-provider"
+)
+
+// Kinds are the kinds a bundle holds, in the order Import creates them.
+// What VirtualMachines reference comes before them, and Providers come last
+// so that no provider is serving, or adopting VMs, before the VMs that are
+// bound to it exist. VMSnapshots, VMClones and VMMigrations are records of
+// one-off operations and are left out: recreating them would repeat the
+// operation.
+var Kinds = []string{
+	"VMClass",
+	"VMImage",
+	"VMNetworkAttachment",
+	"VMPlacementPolicy",
+	"VirtrigaudQuota",
+	"VirtualMachine",
+	"VMSet",
+	"Provider",
+}
+
+// gvk returns the v1beta1 GroupVersionKind of kind.
+func gvk(kind string) schema.GroupVersionKind {
+	return infrav1beta1.GroupVersion.WithKind(kind)
+}
+
+// sortObjects orders objs by their kind's position in Kinds, then by
+// namespace and name.
+func sortObjects(objs []*unstructured.Unstructured) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if ka, kb := slices.Index(Kinds, a.GetKind()), slices.Index(Kinds, b.GetKind()); ka != kb {
+			return ka < kb
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+// Write writes objs to w as a multi-document YAML bundle.
+func Write(w io.Writer, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read parses a bundle written by Write. It rejects objects of other API
+// groups and kinds outside Kinds, so that a mistaken file is not imported.
+func Read(r io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	var objs []*unstructured.Unstructured
+	for {
+		var doc map[string]interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse bundle: %w", err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: doc}
+		if gv := obj.GroupVersionKind().GroupVersion(); gv != infrav1beta1.GroupVersion {
+			return nil, fmt.Errorf("%s %q: apiVersion %q is not %s", obj.GetKind(), obj.GetName(), obj.GetAPIVersion(), infrav1beta1.GroupVersion)
+		}
+		if !slices.Contains(Kinds, obj.GetKind()) {
+			return nil, fmt.Errorf("%q: kind %q cannot be imported", obj.GetName(), obj.GetKind())
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// portable strips what the API server assigns, and the status, from obj so
+// it can be created in another cluster. Owner references are dropped too,
+// as the owners' UIDs will differ.
+func portable(obj *unstructured.Unstructured) {
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetSelfLink("")
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebundle
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Export lists every object of Kinds in all namespaces and returns them
+// ready to Write. Objects being deleted are left out. Kinds the cluster
+// does not serve, such as ones added by a newer release, are skipped.
+func Export(ctx context.Context, c client.Client) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, kind := range Kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk(kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if kind == "VirtualMachine" {
+				if err := recordBinding(obj); err != nil {
+					return nil, err
+				}
+			}
+			portable(obj)
+			obj.SetGroupVersionKind(gvk(kind))
+			objs = append(objs, obj)
+		}
+	}
+	sortObjects(objs)
+	return objs, nil
+}
+
+// recordBinding copies a VirtualMachine's status.id, and the Provider its
+// providerRef resolves to, into annotations that survive portable. A VM
+// with no ID yet has nothing on the hypervisor to reattach to and is
+// exported as it is.
+func recordBinding(vm *unstructured.Unstructured) error {
+	id, _, err := unstructured.NestedString(vm.Object, "status", "id")
+	if err != nil {
+		return fmt.Errorf("VirtualMachine %s/%s: %w", vm.GetNamespace(), vm.GetName(), err)
+	}
+	annotations := vm.GetAnnotations()
+	delete(annotations, ProviderIDAnnotation)
+	delete(annotations, ProviderAnnotation)
+	if id != "" {
+		provider, err := providerKey(vm)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ProviderIDAnnotation] = id
+		annotations[ProviderAnnotation] = provider.String()
+	}
+	vm.SetAnnotations(annotations)
+	return nil
+}
+
+// providerKey resolves vm's spec.providerRef, which defaults to the VM's
+// own namespace.
+func providerKey(vm *unstructured.Unstructured) (client.ObjectKey, error) {
+	name, _, err := unstructured.NestedString(vm.Object, "spec", "providerRef", "name")
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("VirtualMachine %s/%s: %w", vm.GetNamespace(), vm.GetName(), err)
+	}
+	namespace, _, err := unstructured.NestedString(vm.Object, "spec", "providerRef", "namespace")
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("VirtualMachine %s/%s: %w", vm.GetNamespace(), vm.GetName(), err)
+	}
+	if namespace == "" {
+		namespace = vm.GetNamespace()
+	}
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// Remap rewrites a bundle for a cluster whose Providers have different
+// names, or whose hypervisor VMs have different IDs, than where the bundle
+// was exported. Providers are written as namespace/name.
+//
+//	providers:
+//	  default/vsphere-prod: dr/vsphere-dr
+//	ids:
+//	  default/vsphere-prod:
+//	    vm-1042: vm-2187
+type Remap struct {
+	// Providers maps an exported Provider to the one to use instead. The
+	// exported Provider object is imported under the new name.
+	Providers map[string]string `json:"providers,omitempty"`
+	// IDs maps, per exported Provider, a VM's exported ID to its ID now.
+	IDs map[string]map[string]string `json:"ids,omitempty"`
+}
+
+// LoadRemap reads a Remap from a YAML file.
+func LoadRemap(path string) (*Remap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	remap := &Remap{}
+	if err := yaml.UnmarshalStrict(data, remap); err != nil {
+		return nil, fmt.Errorf("failed to parse remap file %s: %w", path, err)
+	}
+	for from, to := range remap.Providers {
+		for _, key := range []string{from, to} {
+			if _, err := parseKey(key); err != nil {
+				return nil, fmt.Errorf("remap file %s: providers: %w", path, err)
+			}
+		}
+	}
+	for from := range remap.IDs {
+		if _, err := parseKey(from); err != nil {
+			return nil, fmt.Errorf("remap file %s: ids: %w", path, err)
+		}
+	}
+	return remap, nil
+}
+
+// parseKey parses a namespace/name reference.
+func parseKey(s string) (client.ObjectKey, error) {
+	namespace, name, ok := strings.Cut(s, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return client.ObjectKey{}, fmt.Errorf("%q is not namespace/name", s)
+	}
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
+
+// provider returns the Provider to use for the exported one.
+func (m *Remap) provider(exported client.ObjectKey) client.ObjectKey {
+	if m != nil {
+		if to, ok := m.Providers[exported.String()]; ok {
+			key, _ := parseKey(to)
+			return key
+		}
+	}
+	return exported
+}
+
+// id returns the ID to adopt for a VM exported with id on provider.
+func (m *Remap) id(exported client.ObjectKey, id string) string {
+	if m != nil {
+		if to, ok := m.IDs[exported.String()][id]; ok {
+			return to
+		}
+	}
+	return id
+}
+
+// Prepare returns copies of objs rewritten for import: remapped Providers
+// are renamed, VirtualMachines point at the remapped Provider, and a VM
+// exported with an ID adopts it through spec.adoptExisting. A VM that was
+// created by virtrigaud gets deletionPolicy Delete, so that deleting it
+// later still removes the hypervisor VM as it did before; a VM that was
+// already adopted keeps its policy.
+func Prepare(objs []*unstructured.Unstructured, remap *Remap) ([]*unstructured.Unstructured, error) {
+	out := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		obj = obj.DeepCopy()
+		switch obj.GetKind() {
+		case "Provider":
+			key := remap.provider(client.ObjectKeyFromObject(obj))
+			obj.SetNamespace(key.Namespace)
+			obj.SetName(key.Name)
+		case "VirtualMachine":
+			if err := prepareVM(obj, remap); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, obj)
+	}
+	sortObjects(out)
+	return out, nil
+}
+
+func prepareVM(vm *unstructured.Unstructured, remap *Remap) error {
+	exported, err := providerKey(vm)
+	if err != nil {
+		return err
+	}
+	if recorded, ok := vm.GetAnnotations()[ProviderAnnotation]; ok {
+		if exported, err = parseKey(recorded); err != nil {
+			return fmt.Errorf("VirtualMachine %s/%s: annotation %s: %w", vm.GetNamespace(), vm.GetName(), ProviderAnnotation, err)
+		}
+	}
+
+	if provider := remap.provider(exported); provider != exported {
+		ref := map[string]interface{}{"name": provider.Name}
+		if provider.Namespace != vm.GetNamespace() {
+			ref["namespace"] = provider.Namespace
+		}
+		if err := unstructured.SetNestedMap(vm.Object, ref, "spec", "providerRef"); err != nil {
+			return err
+		}
+	}
+
+	id := vm.GetAnnotations()[ProviderIDAnnotation]
+	if id == "" {
+		return nil
+	}
+	id = remap.id(exported, id)
+	if _, adopted, _ := unstructured.NestedMap(vm.Object, "spec", "adoptExisting"); adopted {
+		return unstructured.SetNestedField(vm.Object, id, "spec", "adoptExisting", "id")
+	}
+	return unstructured.SetNestedMap(vm.Object, map[string]interface{}{
+		"id":             id,
+		"deletionPolicy": string(infrav1beta1.AdoptDeletionPolicyDelete),
+	}, "spec", "adoptExisting")
+}
+
+// Action is what Import did with one object.
+type Action string
+
+const (
+	// ActionCreated means the object was created.
+	ActionCreated Action = "Created"
+	// ActionAdopting means a VirtualMachine was created in adoption mode.
+	ActionAdopting Action = "Adopting"
+	// ActionExists means an object of that name was already there and was
+	// left untouched.
+	ActionExists Action = "Exists"
+	// ActionFailed means the object could not be created.
+	ActionFailed Action = "Failed"
+)
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// DryRun has the API server validate each create without persisting
+	// it.
+	DryRun bool
+}
+
+// ImportResult reports what happened to one object.
+type ImportResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Action    Action
+	// AdoptID is the hypervisor VM a VirtualMachine adopts.
+	AdoptID string
+	Err     error
+}
+
+// Import creates objs, as returned by Prepare, in order. Existing objects
+// are never modified. A failed create is reported in its result and does
+// not stop the import; only a cancelled ctx does.
+func Import(ctx context.Context, c client.Client, objs []*unstructured.Unstructured, opts ImportOptions) ([]ImportResult, error) {
+	var createOpts []client.CreateOption
+	if opts.DryRun {
+		createOpts = append(createOpts, client.DryRunAll)
+	}
+
+	results := make([]ImportResult, 0, len(objs))
+	for _, obj := range objs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := ImportResult{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName(), Action: ActionCreated}
+		if obj.GetKind() == "VirtualMachine" {
+			result.AdoptID, _, _ = unstructured.NestedString(obj.Object, "spec", "adoptExisting", "id")
+			if result.AdoptID != "" {
+				result.Action = ActionAdopting
+			}
+		}
+		err := c.Create(ctx, obj.DeepCopy(), createOpts...)
+		switch {
+		case apierrors.IsAlreadyExists(err):
+			result.Action = ActionExists
+		case err != nil:
+			result.Action = ActionFailed
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
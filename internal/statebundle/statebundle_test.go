@@ -0,0 +1,225 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebundle
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func vm(name, id string) *infrav1beta1.VirtualMachine {
+	v := &infrav1beta1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{
+		Name: name, Namespace: "apps", UID: types.UID("uid-" + name), ResourceVersion: "7",
+		Finalizers: []string{infrav1beta1.VirtualMachineFinalizer},
+	}}
+	v.Spec.ProviderRef = infrav1beta1.ObjectRef{Name: "vsphere", Namespace: "infra"}
+	v.Spec.ClassRef = infrav1beta1.ObjectRef{Name: "small"}
+	v.Status.ID = id
+	return v
+}
+
+// sourceCluster holds a created VM, an adopted VM, a VM not yet created on
+// the hypervisor and a snapshot, which is not part of a bundle.
+func sourceCluster(t *testing.T) client.Client {
+	adopted := vm("legacy", "vm-9")
+	adopted.Spec.AdoptExisting = &infrav1beta1.AdoptExistingSpec{ID: "vm-9", DeletionPolicy: infrav1beta1.AdoptDeletionPolicyRetain}
+
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "vsphere", Namespace: "infra"}}
+	provider.Spec.Type = infrav1beta1.ProviderTypeVSphere
+	class := &infrav1beta1.VMClass{ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "apps"}}
+	snapshot := &infrav1beta1.VMSnapshot{ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "apps"}}
+
+	return fake.NewClientBuilder().WithScheme(newScheme(t)).
+		WithObjects(vm("web", "vm-1"), adopted, vm("pending", ""), provider, class, snapshot).
+		Build()
+}
+
+func exportBundle(t *testing.T) string {
+	t.Helper()
+	objs, err := Export(context.Background(), sourceCluster(t))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, objs))
+	return buf.String()
+}
+
+func TestExport(t *testing.T) {
+	bundle := exportBundle(t)
+
+	objs, err := Read(strings.NewReader(bundle))
+	require.NoError(t, err)
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+		assert.Empty(t, obj.GetResourceVersion(), obj.GetName())
+		assert.Empty(t, obj.GetUID(), obj.GetName())
+		assert.Empty(t, obj.GetFinalizers(), obj.GetName())
+		assert.NotContains(t, obj.Object, "status", obj.GetName())
+	}
+	assert.Equal(t, []string{
+		"VMClass/small", "VirtualMachine/legacy", "VirtualMachine/pending", "VirtualMachine/web", "Provider/vsphere",
+	}, names, "snapshots are left out and providers come last")
+
+	annotations := objs[3].GetAnnotations()
+	assert.Equal(t, "vm-1", annotations[ProviderIDAnnotation])
+	assert.Equal(t, "infra/vsphere", annotations[ProviderAnnotation])
+	assert.NotContains(t, objs[2].GetAnnotations(), ProviderIDAnnotation, "a VM with no ID has nothing to adopt")
+}
+
+func TestRead_RejectsForeignObjects(t *testing.T) {
+	_, err := Read(strings.NewReader("apiVersion: v1\nkind: Secret\nmetadata:\n  name: creds\n"))
+	assert.ErrorContains(t, err, "is not infra.virtrigaud.io/v1beta1")
+
+	_, err = Read(strings.NewReader("apiVersion: infra.virtrigaud.io/v1beta1\nkind: VMClone\nmetadata:\n  name: c\n"))
+	assert.ErrorContains(t, err, "cannot be imported")
+}
+
+func TestImport(t *testing.T) {
+	objs, err := Read(strings.NewReader(exportBundle(t)))
+	require.NoError(t, err)
+	objs, err = Prepare(objs, &Remap{
+		Providers: map[string]string{"infra/vsphere": "apps/vsphere-dr"},
+		IDs:       map[string]map[string]string{"infra/vsphere": {"vm-1": "vm-100"}},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	target := fake.NewClientBuilder().WithScheme(newScheme(t)).
+		WithObjects(&infrav1beta1.VMClass{ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "apps"}}).
+		Build()
+	results, err := Import(ctx, target, objs, ImportOptions{})
+	require.NoError(t, err)
+
+	actions := map[string]Action{}
+	for _, r := range results {
+		require.NoError(t, r.Err, r.Name)
+		actions[r.Kind+"/"+r.Name] = r.Action
+	}
+	assert.Equal(t, map[string]Action{
+		"VMClass/small":          ActionExists,
+		"VirtualMachine/legacy":  ActionAdopting,
+		"VirtualMachine/pending": ActionCreated,
+		"VirtualMachine/web":     ActionAdopting,
+		"Provider/vsphere-dr":    ActionCreated,
+	}, actions)
+
+	web := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "web"}, web))
+	assert.Equal(t, infrav1beta1.ObjectRef{Name: "vsphere-dr"}, web.Spec.ProviderRef,
+		"a provider remapped into the VM's namespace is referenced without one")
+	assert.Equal(t, &infrav1beta1.AdoptExistingSpec{ID: "vm-100", DeletionPolicy: infrav1beta1.AdoptDeletionPolicyDelete},
+		web.Spec.AdoptExisting, "a VM virtrigaud created is still deleted with its VirtualMachine")
+
+	legacy := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "legacy"}, legacy))
+	assert.Equal(t, &infrav1beta1.AdoptExistingSpec{ID: "vm-9", DeletionPolicy: infrav1beta1.AdoptDeletionPolicyRetain},
+		legacy.Spec.AdoptExisting)
+
+	pending := &infrav1beta1.VirtualMachine{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "pending"}, pending))
+	assert.Nil(t, pending.Spec.AdoptExisting)
+
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "vsphere-dr"}, &infrav1beta1.Provider{}))
+}
+
+func TestLoadRemap(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "remap.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	remap, err := LoadRemap(write("providers:\n  infra/vsphere: dr/vsphere\nids:\n  infra/vsphere:\n    vm-1: vm-2\n"))
+	require.NoError(t, err)
+	assert.Equal(t, client.ObjectKey{Namespace: "dr", Name: "vsphere"}, remap.provider(client.ObjectKey{Namespace: "infra", Name: "vsphere"}))
+	assert.Equal(t, "vm-2", remap.id(client.ObjectKey{Namespace: "infra", Name: "vsphere"}, "vm-1"))
+	assert.Equal(t, "vm-3", remap.id(client.ObjectKey{Namespace: "infra", Name: "vsphere"}, "vm-3"))
+
+	_, err = LoadRemap(write("providers:\n  vsphere: dr/vsphere\n"))
+	assert.ErrorContains(t, err, "is not namespace/name")
+
+	_, err = LoadRemap(write("provider:\n  infra/vsphere: dr/vsphere\n"))
+	assert.Error(t, err, "unknown fields are rejected")
+}
+
+type fakeProvider struct {
+	contracts.Provider
+	exists map[string]bool
+}
+
+func (p *fakeProvider) Describe(_ context.Context, id string) (contracts.DescribeResponse, error) {
+	if !p.exists[id] {
+		return contracts.DescribeResponse{}, contracts.NewNotFoundError("no such VM", nil)
+	}
+	return contracts.DescribeResponse{Exists: true, PowerState: "On"}, nil
+}
+
+type fakeResolver struct{ provider contracts.Provider }
+
+func (r fakeResolver) GetProvider(context.Context, *infrav1beta1.Provider) (contracts.Provider, error) {
+	return r.provider, nil
+}
+
+func TestVerify(t *testing.T) {
+	objs, err := Read(strings.NewReader(exportBundle(t)))
+	require.NoError(t, err)
+	objs, err = Prepare(objs, nil)
+	require.NoError(t, err)
+
+	// legacy was re-adopted by hand under another ID before the verify.
+	rebound := vm("legacy", "vm-42")
+	rebound.Spec.AdoptExisting = &infrav1beta1.AdoptExistingSpec{ID: "vm-42"}
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).
+		WithObjects(rebound, &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "vsphere", Namespace: "infra"}}).
+		Build()
+	provider := &fakeProvider{exists: map[string]bool{"vm-1": true, "vm-9": true}}
+
+	results, err := Verify(context.Background(), c, fakeResolver{provider}, objs)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "legacy", results[0].Name)
+	assert.Equal(t, VerifyMismatch, results[0].Status)
+	assert.Equal(t, "web", results[1].Name)
+	assert.Equal(t, VerifyOK, results[1].Status)
+
+	provider.exists["vm-1"] = false
+	results, err = Verify(context.Background(), c, fakeResolver{provider}, objs)
+	require.NoError(t, err)
+	assert.Equal(t, VerifyMissing, results[1].Status)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebundle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	k8sutil "github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// ProviderResolver resolves a Provider object to a provider client.
+type ProviderResolver interface {
+	GetProvider(ctx context.Context, provider *infrav1beta1.Provider) (contracts.Provider, error)
+}
+
+// VerifyStatus is the outcome of verifying one adopting VirtualMachine.
+type VerifyStatus string
+
+const (
+	// VerifyOK means the provider reports the VM to adopt.
+	VerifyOK VerifyStatus = "OK"
+	// VerifyMissing means the provider reports no VM with the ID.
+	VerifyMissing VerifyStatus = "Missing"
+	// VerifyMismatch means the VirtualMachine in the cluster is bound to a
+	// different ID or Provider than the bundle says.
+	VerifyMismatch VerifyStatus = "Mismatch"
+	// VerifyError means the VM could not be checked.
+	VerifyError VerifyStatus = "Error"
+)
+
+// VerifyResult reports on one adopting VirtualMachine.
+type VerifyResult struct {
+	Namespace string
+	Name      string
+	Provider  client.ObjectKey
+	ID        string
+	Status    VerifyStatus
+	Message   string
+}
+
+// Verify checks every VirtualMachine in objs, as returned by Prepare, that
+// adopts a hypervisor VM: the VirtualMachine in the cluster, if there is
+// one, must be bound to the same ID and Provider, and the Provider must
+// report the VM as existing. The VMs of one Provider are described in one
+// batch. Verify changes nothing.
+func Verify(ctx context.Context, c client.Client, resolver ProviderResolver, objs []*unstructured.Unstructured) ([]VerifyResult, error) {
+	var results []*VerifyResult
+	byProvider := map[client.ObjectKey][]*VerifyResult{}
+	for _, obj := range objs {
+		if obj.GetKind() != "VirtualMachine" {
+			continue
+		}
+		want := &infrav1beta1.VirtualMachine{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, want); err != nil {
+			return nil, fmt.Errorf("VirtualMachine %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		if want.Spec.AdoptExisting == nil || want.Spec.AdoptExisting.ID == "" {
+			continue
+		}
+		result := &VerifyResult{
+			Namespace: want.Namespace,
+			Name:      want.Name,
+			Provider:  k8sutil.RefKey(want.Spec.ProviderRef, want.Namespace),
+			ID:        want.Spec.AdoptExisting.ID,
+		}
+		results = append(results, result)
+
+		if err := checkBinding(ctx, c, result); err != nil {
+			return nil, err
+		}
+		if result.Status == "" {
+			byProvider[result.Provider] = append(byProvider[result.Provider], result)
+		}
+	}
+
+	for key, pending := range byProvider {
+		describeAll(ctx, c, resolver, key, pending)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Namespace != results[j].Namespace {
+			return results[i].Namespace < results[j].Namespace
+		}
+		return results[i].Name < results[j].Name
+	})
+	out := make([]VerifyResult, len(results))
+	for i, r := range results {
+		out[i] = *r
+	}
+	return out, nil
+}
+
+// checkBinding marks result Mismatch when the VirtualMachine in the
+// cluster is bound elsewhere. A VirtualMachine that is not there, as after
+// a dry run, is not an error.
+func checkBinding(ctx context.Context, c client.Client, result *VerifyResult) error {
+	vm := &infrav1beta1.VirtualMachine{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: result.Namespace, Name: result.Name}, vm)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get VirtualMachine %s/%s: %w", result.Namespace, result.Name, err)
+	}
+
+	if provider := k8sutil.RefKey(vm.Spec.ProviderRef, vm.Namespace); provider != result.Provider {
+		result.Status = VerifyMismatch
+		result.Message = fmt.Sprintf("VirtualMachine uses provider %s", provider)
+		return nil
+	}
+	bound := vm.Status.ID
+	if bound == "" && vm.Spec.AdoptExisting != nil {
+		bound = vm.Spec.AdoptExisting.ID
+	}
+	if bound != result.ID {
+		result.Status = VerifyMismatch
+		result.Message = fmt.Sprintf("VirtualMachine is bound to %q", bound)
+	}
+	return nil
+}
+
+// describeAll describes the pending VMs of one Provider and sets their
+// status.
+func describeAll(ctx context.Context, c client.Client, resolver ProviderResolver, key client.ObjectKey, pending []*VerifyResult) {
+	fail := func(message string) {
+		for _, result := range pending {
+			result.Status = VerifyError
+			result.Message = message
+		}
+	}
+
+	provider := &infrav1beta1.Provider{}
+	if err := c.Get(ctx, key, provider); err != nil {
+		fail(fmt.Sprintf("failed to get provider %s: %v", key, err))
+		return
+	}
+	instance, err := resolver.GetProvider(ctx, provider)
+	if err != nil {
+		fail(fmt.Sprintf("provider %s unavailable: %v", key, err))
+		return
+	}
+
+	ids := make([]string, 0, len(pending))
+	for _, result := range pending {
+		ids = append(ids, result.ID)
+	}
+	described, err := contracts.DescribeMany(ctx, instance, ids)
+	if err != nil {
+		fail(fmt.Sprintf("failed to describe VMs on provider %s: %v", key, err))
+		return
+	}
+	for _, result := range pending {
+		if err := described.Errors[result.ID]; err != nil && !contracts.IsNotFound(err) {
+			result.Status = VerifyError
+			result.Message = err.Error()
+			continue
+		}
+		desc, ok := described.Results[result.ID]
+		if !ok || !desc.Exists {
+			result.Status = VerifyMissing
+			result.Message = "provider reports no VM with this ID"
+			continue
+		}
+		result.Status = VerifyOK
+		result.Message = "power state " + desc.PowerState
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -36,8 +38,26 @@ type PVCStorage struct {
 	config    StorageConfig
 	mountPath string
 	verified  bool
+
+	// availableBytes reports the space left on the filesystem holding a
+	// directory, or -1 when unknown.
+	availableBytes func(dir string) (int64, error)
 }
 
+const (
+	// pvcLockDir holds the lock files that serialize writers of the same
+	// path. It lives at the root of the mount so that every pod sharing
+	// the PVC takes the same locks.
+	pvcLockDir = ".virtrigaud-locks"
+	// partialSuffix marks a file that is still being written. Completed
+	// files are renamed into place, so a file under its final name is
+	// always whole.
+	partialSuffix = ".partial"
+	// lockPollInterval is how often a writer waiting for a path lock
+	// retries.
+	lockPollInterval = 100 * time.Millisecond
+)
+
 // NewPVCStorage creates a new PVC storage backend
 func NewPVCStorage(config StorageConfig) (*PVCStorage, error) {
 	log.Printf("INFO Initializing PVC storage: pvc=%s/%s mount=%s",
@@ -53,9 +73,10 @@ func NewPVCStorage(config StorageConfig) (*PVCStorage, error) {
 	mountPath = filepath.Clean(mountPath)
 
 	storage := &PVCStorage{
-		config:    config,
-		mountPath: mountPath,
-		verified:  false,
+		config:         config,
+		mountPath:      mountPath,
+		verified:       false,
+		availableBytes: statAvailable,
 	}
 
 	// Verify mount is accessible
@@ -159,8 +180,21 @@ func (p *PVCStorage) Upload(ctx context.Context, req UploadRequest) (UploadRespo
 		}
 	}
 
-	// Create destination file
-	destFile, err := os.Create(destPath)
+	// Serialize writers of the same path; a second migration writing here
+	// waits until the first one is done.
+	unlock, err := p.lockPath(ctx, destPath)
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	defer unlock()
+
+	if err := p.checkSpace(destDir, contentLength); err != nil {
+		return UploadResponse{}, err
+	}
+
+	// Write to a temporary file that is only renamed into place once
+	// complete, so a partial export is never taken for a finished one.
+	destFile, err := os.CreateTemp(destDir, "."+filepath.Base(destPath)+".*"+partialSuffix)
 	if err != nil {
 		return UploadResponse{}, &StorageError{
 			Type:    ErrorTypeOperationFailed,
@@ -168,7 +202,13 @@ func (p *PVCStorage) Upload(ctx context.Context, req UploadRequest) (UploadRespo
 			Cause:   err,
 		}
 	}
-	defer destFile.Close()
+	committed := false
+	defer func() {
+		if !committed {
+			destFile.Close()
+			os.Remove(destFile.Name())
+		}
+	}()
 
 	// Copy data with checksum calculation
 	hasher := sha256.New()
@@ -192,11 +232,7 @@ func (p *PVCStorage) Upload(ctx context.Context, req UploadRequest) (UploadRespo
 			}
 			bytesTransferred += int64(nw)
 			if ew != nil {
-				return UploadResponse{}, &StorageError{
-					Type:    ErrorTypeOperationFailed,
-					Message: "failed to write to destination",
-					Cause:   ew,
-				}
+				return UploadResponse{}, writeError(destPath, ew)
 			}
 			if nr != nw {
 				return UploadResponse{}, &StorageError{
@@ -222,28 +258,31 @@ func (p *PVCStorage) Upload(ctx context.Context, req UploadRequest) (UploadRespo
 		}
 	}
 
-	// Sync to disk
+	// Sync to disk; CreateTemp made the file private, give it the mode
+	// os.Create would have
 	if err := destFile.Sync(); err != nil {
-		return UploadResponse{}, &StorageError{
-			Type:    ErrorTypeOperationFailed,
-			Message: "failed to sync file to disk",
-			Cause:   err,
-		}
+		return UploadResponse{}, writeError(destPath, err)
+	}
+	if err := destFile.Chmod(0644); err != nil {
+		return UploadResponse{}, writeError(destPath, err)
 	}
 
 	checksum := hex.EncodeToString(hasher.Sum(nil))
 	duration := time.Since(startTime)
 
-	// Verify checksum if provided
+	// Verify checksum if provided; the partial file is removed on return
 	if req.Checksum != "" && req.Checksum != checksum {
-		// Remove corrupted file
-		os.Remove(destPath)
 		return UploadResponse{}, &StorageError{
 			Type:    ErrorTypeChecksumMismatch,
 			Message: fmt.Sprintf("checksum mismatch: expected=%s actual=%s", req.Checksum, checksum),
 		}
 	}
 
+	if err := p.commit(destFile, destPath); err != nil {
+		return UploadResponse{}, err
+	}
+	committed = true
+
 	log.Printf("INFO Upload completed: %s (%d bytes in %v)", destPath, bytesTransferred, duration)
 
 	return UploadResponse{
@@ -433,6 +472,13 @@ func (p *PVCStorage) Delete(ctx context.Context, url string) error {
 		return err
 	}
 
+	// Do not race a writer of the same path
+	unlock, err := p.lockPath(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		// File doesn't exist, consider it deleted
@@ -522,20 +568,13 @@ func (p *PVCStorage) Close() error {
 
 // parsePath converts a URL to an absolute PVC path
 // Supports formats: pvc://path, /path, path
+// The path must stay under the mount, also once symlinks are resolved.
 func (p *PVCStorage) parsePath(url string) (string, error) {
 	url = strings.TrimSpace(url)
 
 	// Handle pvc:// URL format: pvc://<pvc-name>/<file-path>
 	url = strings.TrimPrefix(url, "pvc://")
 
-	// Ensure path doesn't try to escape the mount
-	if strings.Contains(url, "..") {
-		return "", &StorageError{
-			Type:    ErrorTypeInvalidConfig,
-			Message: "invalid path: contains '..'",
-		}
-	}
-
 	// Parse the URL to extract PVC name and file path
 	// Format: <pvc-name>/<file-path>
 	// Example: rbc-demo-migration-storage/vmmigrations/default/rbc-demo-migration/export.qcow2
@@ -550,12 +589,175 @@ func (p *PVCStorage) parsePath(url string) (string, error) {
 	pvcName := parts[0]
 	filePath := parts[1]
 
+	// Ensure path doesn't try to escape the mount: no "..", no absolute
+	// paths, and it must name something below the mount root
+	if !filepath.IsLocal(filePath) || filepath.Clean(filePath) == "." {
+		return "", &StorageError{
+			Type:    ErrorTypeInvalidConfig,
+			Message: fmt.Sprintf("invalid path: %q does not stay under the PVC mount", filePath),
+		}
+	}
+
 	// Construct the full path using the configured mount path
 	// MountPath should already include the PVC name (e.g., /mnt/migration-storage/<pvc-name>)
 	// So we just append the file path
 	absPath := filepath.Join(p.mountPath, filePath)
+	if err := p.checkWithinMount(absPath); err != nil {
+		return "", err
+	}
 
 	log.Printf("DEBUG Parsed PVC path: url=%s pvc=%s file=%s mount=%s abs=%s", url, pvcName, filePath, p.mountPath, absPath)
 
 	return absPath, nil
 }
+
+// checkWithinMount rejects absPath when a symlink along it leads out of
+// the mount. Only the part of the path that exists is resolved.
+func (p *PVCStorage) checkWithinMount(absPath string) error {
+	root, err := filepath.EvalSymlinks(p.mountPath)
+	if err != nil {
+		return &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to resolve PVC mount path: %s", p.mountPath),
+			Cause:   err,
+		}
+	}
+
+	existing := absPath
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to resolve path: %s", absPath),
+			Cause:   err,
+		}
+	}
+
+	if rel, err := filepath.Rel(root, resolved); err != nil || !filepath.IsLocal(rel) {
+		return &StorageError{
+			Type:    ErrorTypeInvalidConfig,
+			Message: fmt.Sprintf("invalid path: %s resolves outside the PVC mount", absPath),
+		}
+	}
+	return nil
+}
+
+// lockPath takes the advisory lock that serializes writers of absPath,
+// waiting until it is free or ctx is done. The returned func releases it.
+func (p *PVCStorage) lockPath(ctx context.Context, absPath string) (func(), error) {
+	rel, err := filepath.Rel(p.mountPath, absPath)
+	if err != nil {
+		return nil, &StorageError{
+			Type:    ErrorTypeInvalidConfig,
+			Message: fmt.Sprintf("path is not under the PVC mount: %s", absPath),
+			Cause:   err,
+		}
+	}
+
+	lockDir := filepath.Join(p.mountPath, pvcLockDir)
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return nil, &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to create lock directory: %s", lockDir),
+			Cause:   err,
+		}
+	}
+	sum := sha256.Sum256([]byte(filepath.ToSlash(rel)))
+	lockFilePath := filepath.Join(lockDir, hex.EncodeToString(sum[:])+".lock")
+	f, err := os.OpenFile(lockFilePath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to open lock file for %s", absPath),
+			Cause:   err,
+		}
+	}
+	if err := lockFile(ctx, f); err != nil {
+		f.Close()
+		return nil, &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to lock %s", absPath),
+			Cause:   err,
+		}
+	}
+	return func() { f.Close() }, nil
+}
+
+// checkSpace fails with ErrorTypeResourceExhausted when the filesystem
+// holding dir has less than size bytes available. An unknown size or
+// available space is not checked.
+func (p *PVCStorage) checkSpace(dir string, size int64) error {
+	if size <= 0 {
+		return nil
+	}
+	available, err := p.availableBytes(dir)
+	if err != nil {
+		log.Printf("WARN Could not check free space on PVC: %v", err)
+		return nil
+	}
+	if available >= 0 && size > available {
+		return &StorageError{
+			Type:    ErrorTypeResourceExhausted,
+			Message: fmt.Sprintf("not enough space on PVC: need %d bytes, %d available", size, available),
+		}
+	}
+	return nil
+}
+
+// commit closes the synced partial file f and renames it to destPath,
+// then syncs the directory so the rename survives a crash.
+func (p *PVCStorage) commit(f *os.File, destPath string) error {
+	if err := f.Close(); err != nil {
+		return writeError(destPath, err)
+	}
+	if err := os.Rename(f.Name(), destPath); err != nil {
+		return &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: fmt.Sprintf("failed to move completed file into place: %s", destPath),
+			Cause:   err,
+		}
+	}
+	dir, err := os.Open(filepath.Dir(destPath))
+	if err != nil {
+		return &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: "failed to open destination directory for sync",
+			Cause:   err,
+		}
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return &StorageError{
+			Type:    ErrorTypeOperationFailed,
+			Message: "failed to sync destination directory",
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// writeError types a failed write, telling a full volume apart.
+func writeError(destPath string, err error) error {
+	if errors.Is(err, syscall.ENOSPC) {
+		return &StorageError{
+			Type:    ErrorTypeResourceExhausted,
+			Message: fmt.Sprintf("PVC ran out of space writing %s", destPath),
+			Cause:   err,
+		}
+	}
+	return &StorageError{
+		Type:    ErrorTypeOperationFailed,
+		Message: "failed to write to destination",
+		Cause:   err,
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !linux && !darwin
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"os"
+)
+
+// lockFile is a no-op where flock is unavailable; the PVC backend only
+// runs in Linux provider pods.
+func lockFile(_ context.Context, _ *os.File) error {
+	return nil
+}
+
+// statAvailable reports the available space as unknown.
+func statAvailable(_ string) (int64, error) {
+	return -1, nil
+}
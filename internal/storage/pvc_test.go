@@ -0,0 +1,257 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPVCStorage(t *testing.T) *PVCStorage {
+	t.Helper()
+	p, err := NewPVCStorage(StorageConfig{PVCName: "migration", MountPath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewPVCStorage: %v", err)
+	}
+	return p
+}
+
+func storageErrorType(err error) ErrorType {
+	var se *StorageError
+	if errors.As(err, &se) {
+		return se.Type
+	}
+	return ""
+}
+
+// assertNoPartials fails when a partial file was left in dir.
+func assertNoPartials(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("ReadDir(%s): %v", dir, err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), partialSuffix) {
+			t.Errorf("partial file left behind: %s", e.Name())
+		}
+	}
+}
+
+// TestPVCParsePath_Traversal covers paths that would leave the mount,
+// lexically or through a symlink.
+func TestPVCParsePath_Traversal(t *testing.T) {
+	p := newTestPVCStorage(t)
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(p.mountPath, "escape")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(p.mountPath, "inside"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(p.mountPath, "inside"), filepath.Join(p.mountPath, "alias")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	rejected := []string{
+		"pvc://migration/../../etc/passwd",
+		"pvc://migration/vmmigrations/../../../etc",
+		"pvc://migration//etc/passwd",
+		"pvc://migration/",
+		"pvc://migration/.",
+		"pvc://migration/escape/export.qcow2",
+		"pvc://migration/escape",
+		"export.qcow2",
+	}
+	for _, url := range rejected {
+		if _, err := p.parsePath(url); storageErrorType(err) != ErrorTypeInvalidConfig {
+			t.Errorf("parsePath(%q) err = %v, want an InvalidConfig error", url, err)
+		}
+	}
+
+	accepted := map[string]string{
+		"pvc://migration/vmmigrations/default/m/export.qcow2": "vmmigrations/default/m/export.qcow2",
+		"pvc://migration/vmmigrations/x/../m/disk..0.qcow2":   "vmmigrations/m/disk..0.qcow2",
+		"pvc://migration/alias/export.qcow2":                  "alias/export.qcow2",
+	}
+	for url, rel := range accepted {
+		got, err := p.parsePath(url)
+		if err != nil {
+			t.Errorf("parsePath(%q) unexpected err: %v", url, err)
+			continue
+		}
+		if want := filepath.Join(p.mountPath, rel); got != want {
+			t.Errorf("parsePath(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+// TestPVCUpload_InsufficientSpace verifies an upload larger than the free
+// space fails before anything is written.
+func TestPVCUpload_InsufficientSpace(t *testing.T) {
+	p := newTestPVCStorage(t)
+	p.availableBytes = func(string) (int64, error) { return 100, nil }
+	data := bytes.Repeat([]byte("x"), 200)
+
+	_, err := p.Upload(context.Background(), UploadRequest{
+		DestinationURL: "pvc://migration/vmmigrations/default/m/export.qcow2",
+		Reader:         bytes.NewReader(data),
+		ContentLength:  int64(len(data)),
+	})
+	if storageErrorType(err) != ErrorTypeResourceExhausted {
+		t.Fatalf("Upload err = %v, want a ResourceExhausted error", err)
+	}
+	dir := filepath.Join(p.mountPath, "vmmigrations/default/m")
+	if _, err := os.Stat(filepath.Join(dir, "export.qcow2")); !os.IsNotExist(err) {
+		t.Errorf("destination must not exist, stat err = %v", err)
+	}
+	assertNoPartials(t, dir)
+
+	// An unknown size is not checked
+	if _, err := p.Upload(context.Background(), UploadRequest{
+		DestinationURL: "pvc://migration/vmmigrations/default/m/export.qcow2",
+		Reader:         bytes.NewReader(data),
+		ContentLength:  -1,
+	}); err != nil {
+		t.Fatalf("Upload with unknown size: %v", err)
+	}
+}
+
+// failingReader returns some data, then an error.
+type failingReader struct{ sent bool }
+
+func (r *failingReader) Read(b []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("connection reset")
+	}
+	r.sent = true
+	return copy(b, "partial export"), nil
+}
+
+// TestPVCUpload_Atomic verifies failed uploads never leave a file under the
+// final name, and a successful one replaces the previous file whole.
+func TestPVCUpload_Atomic(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPVCStorage(t)
+	url := "pvc://migration/vmmigrations/default/m/export.qcow2"
+	dest := filepath.Join(p.mountPath, "vmmigrations/default/m/export.qcow2")
+
+	if _, err := p.Upload(ctx, UploadRequest{DestinationURL: url, Reader: &failingReader{}}); err == nil {
+		t.Fatal("Upload from a failing reader succeeded")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("failed upload left %s behind (stat err = %v)", dest, err)
+	}
+
+	if _, err := p.Upload(ctx, UploadRequest{DestinationURL: url, Reader: strings.NewReader("v1")}); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	_, err := p.Upload(ctx, UploadRequest{DestinationURL: url, Reader: strings.NewReader("v2"), Checksum: "not-the-checksum"})
+	if storageErrorType(err) != ErrorTypeChecksumMismatch {
+		t.Fatalf("Upload err = %v, want a ChecksumMismatch error", err)
+	}
+	if got, _ := os.ReadFile(dest); string(got) != "v1" {
+		t.Errorf("a rejected upload replaced the file: got %q", got)
+	}
+	assertNoPartials(t, filepath.Dir(dest))
+}
+
+// gatedReader signals its first Read, then blocks until released.
+type gatedReader struct {
+	data    io.Reader
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *gatedReader) Read(b []byte) (int, error) {
+	if r.started != nil {
+		close(r.started)
+		r.started = nil
+		<-r.release
+	}
+	return r.data.Read(b)
+}
+
+// TestPVCUpload_ConcurrentWriters verifies a second writer of the same path
+// waits for the first one, so their writes never interleave.
+func TestPVCUpload_ConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPVCStorage(t)
+	url := "pvc://migration/vmmigrations/default/m/export.qcow2"
+	first := bytes.Repeat([]byte("a"), 1<<20)
+	second := bytes.Repeat([]byte("b"), 1<<20)
+
+	gate := &gatedReader{data: bytes.NewReader(first), started: make(chan struct{}), release: make(chan struct{})}
+	started := gate.started
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := p.Upload(ctx, UploadRequest{DestinationURL: url, Reader: gate})
+		firstDone <- err
+	}()
+	<-started
+
+	secondDone := make(chan error, 1)
+	go func() {
+		_, err := p.Upload(ctx, UploadRequest{DestinationURL: url, Reader: bytes.NewReader(second)})
+		secondDone <- err
+	}()
+	select {
+	case err := <-secondDone:
+		t.Fatalf("second writer finished while the first held the path: %v", err)
+	case <-time.After(3 * lockPollInterval):
+	}
+
+	close(gate.release)
+	if err := <-firstDone; err != nil {
+		t.Fatalf("first Upload: %v", err)
+	}
+	if err := <-secondDone; err != nil {
+		t.Fatalf("second Upload: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(p.mountPath, "vmmigrations/default/m/export.qcow2"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(got, second) {
+		t.Errorf("file is not the second writer's export whole (%d bytes)", len(got))
+	}
+}
+
+// TestPVCUpload_LockWaitHonoursContext verifies a writer waiting for a path
+// gives up when its context ends.
+func TestPVCUpload_LockWaitHonoursContext(t *testing.T) {
+	p := newTestPVCStorage(t)
+	unlock, err := p.lockPath(context.Background(), filepath.Join(p.mountPath, "export.qcow2"))
+	if err != nil {
+		t.Fatalf("lockPath: %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockPollInterval)
+	defer cancel()
+	_, err = p.Upload(ctx, UploadRequest{DestinationURL: "pvc://migration/export.qcow2", Reader: strings.NewReader("data")})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Upload err = %v, want the context deadline", err)
+	}
+}
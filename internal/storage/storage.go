@@ -87,7 +87,8 @@ type UploadRequest struct {
 	DestinationURL string
 	// Reader provides the data to upload (alternative to SourcePath)
 	Reader io.Reader
-	// ContentLength is the expected size in bytes
+	// ContentLength is the expected size in bytes. The PVC backend refuses
+	// the upload up front when the volume has less space available.
 	ContentLength int64
 	// Checksum is the expected checksum (SHA256)
 	Checksum string
@@ -246,4 +247,7 @@ const (
 	ErrorTypeInvalidConfig ErrorType = "InvalidConfig"
 	// ErrorTypeOperationFailed indicates a generic operation failure
 	ErrorTypeOperationFailed ErrorType = "OperationFailed"
+	// ErrorTypeResourceExhausted indicates the destination has no room for
+	// the file
+	ErrorTypeResourceExhausted ErrorType = "ResourceExhausted"
 )
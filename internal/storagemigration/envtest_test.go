@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// envTestBinaryDir finds the binaries installed by 'make setup-envtest'
+// when KUBEBUILDER_ASSETS is not set.
+func envTestBinaryDir() string {
+	if dir := os.Getenv("KUBEBUILDER_ASSETS"); dir != "" {
+		return dir
+	}
+	entries, err := os.ReadDir(filepath.Join("..", "..", "bin", "k8s"))
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			return filepath.Join("..", "..", "bin", "k8s", entry.Name())
+		}
+	}
+	return ""
+}
+
+// TestRun_EnvTest installs a CRD storing v1alpha1, creates objects, moves
+// storage to v1beta1 and checks that a migration rewrites every object and
+// leaves v1beta1 as the only stored version.
+func TestRun_EnvTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts an API server")
+	}
+	dir := envTestBinaryDir()
+	if dir == "" {
+		t.Skip("envtest binaries not found; run 'make setup-envtest' or set KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{
+		BinaryAssetsDirectory: dir,
+		CRDs:                  []*apiextensionsv1.CustomResourceDefinition{widgetCRD("v1alpha1")},
+	}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = testEnv.Stop() })
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	names := []string{"a", "b", "c", "d", "e"}
+	versions := map[string]string{}
+	for _, name := range names {
+		obj := widget("v1alpha1", name)
+		require.NoError(t, c.Create(ctx, obj))
+		versions[name] = obj.GetResourceVersion()
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "widgets." + testGroup}, crd))
+	crd.Spec.Versions[0].Storage = false
+	crd.Spec.Versions[1].Storage = true
+	require.NoError(t, c.Update(ctx, crd))
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"v1alpha1", "v1beta1"}, storedVersions(t, c))
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// A dry run reports the objects and leaves everything in place.
+	results, err := New(c, Options{DryRun: true}).Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, len(names), results[0].Objects)
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, storedVersions(t, c))
+
+	results, err = New(c, Options{PageSize: 2, QPS: 100}).Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, len(names), results[0].Migrated)
+	assert.Equal(t, []string{"v1beta1"}, storedVersions(t, c))
+
+	// The API server skips writes whose encoding is unchanged, so a new
+	// resourceVersion shows each object was re-encoded at v1beta1.
+	for _, name := range names {
+		obj := widget("v1beta1", name)
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+		assert.NotEqual(t, versions[name], obj.GetResourceVersion(), name)
+	}
+
+	// A second run finds nothing to do.
+	results, err = New(c, Options{}).Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].NeedsMigration())
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storagemigration rewrites custom resources still persisted in a
+// version other than their CRD's storage version, and then drops the old
+// versions from the CRD's status.storedVersions so they can be removed from
+// the CRD in a later release.
+//
+// Objects are rewritten with no-op updates: the API server re-encodes each
+// object at the current storage version, and only writes it to etcd when
+// that encoding differs from what is stored.
+package storagemigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultGroup is the API group whose CRDs are migrated when
+	// Options.Group is empty.
+	DefaultGroup = "infra.virtrigaud.io"
+
+	// DefaultQPS bounds object updates per second when Options.QPS is
+	// unset, so a migration does not compete with the controllers for
+	// API server capacity.
+	DefaultQPS = 10
+
+	// DefaultPageSize is the list page size when Options.PageSize is unset.
+	DefaultPageSize = 500
+)
+
+// Options configures a Migrator.
+type Options struct {
+	// Group selects the CRDs to migrate. Defaults to DefaultGroup.
+	Group string
+	// DryRun counts the objects that would be rewritten without writing
+	// objects or CRDs.
+	DryRun bool
+	// QPS limits object updates per second. Defaults to DefaultQPS.
+	QPS float32
+	// PageSize is the number of objects fetched per list call. Defaults
+	// to DefaultPageSize.
+	PageSize int64
+	// Out receives progress output. Nil discards it.
+	Out io.Writer
+}
+
+// Result reports what happened to one CRD.
+type Result struct {
+	// CRD is the CRD name, e.g. virtualmachines.infra.virtrigaud.io.
+	CRD  string
+	Kind string
+	// StorageVersion is the version objects are rewritten to.
+	StorageVersion string
+	// StaleVersions are the storedVersions other than StorageVersion.
+	// Empty means the CRD needed no migration.
+	StaleVersions []string
+	// Objects is the number of objects found.
+	Objects int
+	// Migrated is the number of objects rewritten; zero on a dry run.
+	Migrated int
+	// Failed is the number of objects that could not be rewritten. The
+	// CRD's storedVersions is left alone while any remain.
+	Failed int
+}
+
+// NeedsMigration reports whether the CRD had stale stored versions.
+func (r Result) NeedsMigration() bool {
+	return len(r.StaleVersions) > 0
+}
+
+// Migrator migrates the stored versions of every CRD in a group.
+type Migrator struct {
+	client  client.Client
+	opts    Options
+	limiter flowcontrol.RateLimiter
+}
+
+// New returns a Migrator. c must be able to read and update
+// CustomResourceDefinitions and their status, and to list and update the
+// migrated resources as unstructured objects.
+func New(c client.Client, opts Options) *Migrator {
+	if opts.Group == "" {
+		opts.Group = DefaultGroup
+	}
+	if opts.QPS <= 0 {
+		opts.QPS = DefaultQPS
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	burst := max(int(opts.QPS), 1)
+	return &Migrator{
+		client:  c,
+		opts:    opts,
+		limiter: flowcontrol.NewTokenBucketRateLimiter(opts.QPS, burst),
+	}
+}
+
+// Run migrates every CRD in the configured group, in name order, and
+// returns one Result per CRD. A CRD that fails does not stop the others;
+// their errors are joined in the returned error.
+func (m *Migrator) Run(ctx context.Context) ([]Result, error) {
+	var crds apiextensionsv1.CustomResourceDefinitionList
+	if err := m.client.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+	sort.Slice(crds.Items, func(i, j int) bool { return crds.Items[i].Name < crds.Items[j].Name })
+
+	var results []Result
+	var errs []error
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if crd.Spec.Group != m.opts.Group {
+			continue
+		}
+		res, err := m.migrate(ctx, crd)
+		results = append(results, res)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", crd.Name, err))
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+func (m *Migrator) migrate(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (Result, error) {
+	res := Result{CRD: crd.Name, Kind: crd.Spec.Names.Kind}
+
+	storage, ok := storageVersion(crd)
+	if !ok {
+		return res, errors.New("no version is marked as the storage version")
+	}
+	res.StorageVersion = storage
+	for _, v := range crd.Status.StoredVersions {
+		if v != storage {
+			res.StaleVersions = append(res.StaleVersions, v)
+		}
+	}
+	if !res.NeedsMigration() {
+		_, _ = fmt.Fprintf(m.opts.Out, "%s: up to date (%s)\n", crd.Name, storage)
+		return res, nil
+	}
+
+	verb := "migrating"
+	if m.opts.DryRun {
+		verb = "would migrate"
+	}
+	_, _ = fmt.Fprintf(m.opts.Out, "%s: %s stored versions %v to %s\n", crd.Name, verb, res.StaleVersions, storage)
+
+	gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: storage, Kind: crd.Spec.Names.ListKind}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	opts := []client.ListOption{client.Limit(m.opts.PageSize)}
+	for {
+		if err := m.client.List(ctx, list, opts...); err != nil {
+			return res, fmt.Errorf("failed to list %s: %w", crd.Spec.Names.Plural, err)
+		}
+		res.Objects += len(list.Items)
+		if !m.opts.DryRun {
+			for i := range list.Items {
+				if err := m.rewrite(ctx, &list.Items[i]); err != nil {
+					if ctx.Err() != nil {
+						return res, ctx.Err()
+					}
+					res.Failed++
+					_, _ = fmt.Fprintf(m.opts.Out, "  %s/%s: %v\n", list.Items[i].GetNamespace(), list.Items[i].GetName(), err)
+					continue
+				}
+				res.Migrated++
+			}
+			_, _ = fmt.Fprintf(m.opts.Out, "  %d objects rewritten\n", res.Migrated)
+		}
+		if list.GetContinue() == "" {
+			break
+		}
+		opts = []client.ListOption{client.Limit(m.opts.PageSize), client.Continue(list.GetContinue())}
+	}
+
+	if m.opts.DryRun {
+		_, _ = fmt.Fprintf(m.opts.Out, "  %d objects would be rewritten\n", res.Objects)
+		return res, nil
+	}
+	if res.Failed > 0 {
+		return res, fmt.Errorf("%d of %d objects could not be rewritten; storedVersions left unchanged", res.Failed, res.Objects)
+	}
+	if err := m.pruneStoredVersions(ctx, crd.Name, storage); err != nil {
+		return res, err
+	}
+	_, _ = fmt.Fprintf(m.opts.Out, "  storedVersions set to [%s]\n", storage)
+	return res, nil
+}
+
+// rewrite issues a no-op update of obj, refetching it on conflict. An
+// object deleted since it was listed needs no migration.
+func (m *Migrator) rewrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	key := client.ObjectKeyFromObject(obj)
+	first := true
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if !first {
+			if err := m.client.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		first = false
+		return m.client.Update(ctx, obj)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// pruneStoredVersions records that every object of the CRD is now stored
+// at storage.
+func (m *Migrator) pruneStoredVersions(ctx context.Context, name, storage string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := m.client.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			return err
+		}
+		// The storage version may have moved on while objects were being
+		// rewritten; pruning then would discard a version still in etcd.
+		if current, _ := storageVersion(crd); current != storage {
+			return fmt.Errorf("storage version changed from %s to %s during migration", storage, current)
+		}
+		if slices.Equal(crd.Status.StoredVersions, []string{storage}) {
+			return nil
+		}
+		crd.Status.StoredVersions = []string{storage}
+		if err := m.client.Status().Update(ctx, crd); err != nil {
+			return fmt.Errorf("failed to update storedVersions: %w", err)
+		}
+		return nil
+	})
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) (string, bool) {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name, true
+		}
+	}
+	return "", false
+}
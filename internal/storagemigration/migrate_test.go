@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testGroup = "infra.virtrigaud.io"
+
+// widgetCRD returns a CRD serving v1alpha1 and v1beta1 with storage set
+// to storage.
+func widgetCRD(storage string, stored ...string) *apiextensionsv1.CustomResourceDefinition {
+	validation := &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:                   "object",
+			XPreserveUnknownFields: ptrTo(true),
+		},
+	}
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets." + testGroup},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: testGroup,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: storage == "v1alpha1", Schema: validation},
+				{Name: "v1beta1", Served: true, Storage: storage == "v1beta1", Schema: validation},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: stored},
+	}
+}
+
+func widget(version, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: testGroup, Version: version, Kind: "Widget"})
+	u.SetNamespace("default")
+	u.SetName(name)
+	return u
+}
+
+func ptrTo[T any](v T) *T { return &v }
+
+func newFakeClient(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	for _, v := range []string{"v1alpha1", "v1beta1"} {
+		gv := schema.GroupVersion{Group: testGroup, Version: v}
+		scheme.AddKnownTypeWithName(gv.WithKind("Widget"), &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gv.WithKind("WidgetList"), &unstructured.UnstructuredList{})
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// countUpdates counts object updates and optionally fails some of them.
+func countUpdates(updates *int, fail func(client.Object) error) interceptor.Funcs {
+	return interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			*updates++
+			if fail != nil {
+				if err := fail(obj); err != nil {
+					return err
+				}
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}
+}
+
+func storedVersions(t *testing.T, c client.Client) []string {
+	t.Helper()
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "widgets." + testGroup}, crd))
+	return crd.Status.StoredVersions
+}
+
+func TestRun_MigratesAndPrunesStoredVersions(t *testing.T) {
+	var updates int
+	c := newFakeClient(t, countUpdates(&updates, nil),
+		widgetCRD("v1beta1", "v1alpha1", "v1beta1"),
+		widget("v1beta1", "a"), widget("v1beta1", "b"), widget("v1beta1", "c"),
+	)
+
+	var out bytes.Buffer
+	results, err := New(c, Options{PageSize: 2, QPS: 1000, Out: &out}).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, Result{
+		CRD:            "widgets." + testGroup,
+		Kind:           "Widget",
+		StorageVersion: "v1beta1",
+		StaleVersions:  []string{"v1alpha1"},
+		Objects:        3,
+		Migrated:       3,
+	}, results[0])
+	assert.Equal(t, 3, updates)
+	assert.Equal(t, []string{"v1beta1"}, storedVersions(t, c))
+	assert.Contains(t, out.String(), "migrating stored versions [v1alpha1] to v1beta1")
+	assert.Contains(t, out.String(), "storedVersions set to [v1beta1]")
+}
+
+func TestRun_DryRunWritesNothing(t *testing.T) {
+	var updates int
+	c := newFakeClient(t, countUpdates(&updates, nil),
+		widgetCRD("v1beta1", "v1alpha1", "v1beta1"),
+		widget("v1beta1", "a"), widget("v1beta1", "b"),
+	)
+
+	var out bytes.Buffer
+	results, err := New(c, Options{DryRun: true, Out: &out}).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].Objects)
+	assert.Zero(t, results[0].Migrated)
+	assert.Zero(t, updates)
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, storedVersions(t, c))
+	assert.Contains(t, out.String(), "2 objects would be rewritten")
+}
+
+func TestRun_UpToDateCRDIsSkipped(t *testing.T) {
+	var updates int
+	other := widgetCRD("v1beta1", "v1alpha1", "v1beta1")
+	other.Name = "widgets.example.com"
+	other.Spec.Group = "example.com"
+	c := newFakeClient(t, countUpdates(&updates, nil),
+		widgetCRD("v1beta1", "v1beta1"), other,
+		widget("v1beta1", "a"),
+	)
+
+	results, err := New(c, Options{}).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, results, 1, "CRDs outside the group are ignored")
+	assert.False(t, results[0].NeedsMigration())
+	assert.Zero(t, updates)
+}
+
+func TestRun_FailuresKeepStoredVersions(t *testing.T) {
+	var updates int
+	c := newFakeClient(t, countUpdates(&updates, func(obj client.Object) error {
+		if obj.GetName() == "b" {
+			return fmt.Errorf("admission webhook denied the request")
+		}
+		return nil
+	}),
+		widgetCRD("v1beta1", "v1alpha1", "v1beta1"),
+		widget("v1beta1", "a"), widget("v1beta1", "b"), widget("v1beta1", "c"),
+	)
+
+	results, err := New(c, Options{QPS: 1000}).Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 objects could not be rewritten")
+
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].Migrated)
+	assert.Equal(t, 1, results[0].Failed)
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, storedVersions(t, c))
+}
+
+func TestRun_NoStorageVersion(t *testing.T) {
+	crd := widgetCRD("", "v1alpha1")
+	c := newFakeClient(t, interceptor.Funcs{}, crd)
+
+	_, err := New(c, Options{}).Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no version is marked as the storage version")
+}
@@ -21,32 +21,44 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	"github.com/projectbeskar/virtrigaud/internal/resilience"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+	sdkerrors "github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
 // Compile-time assertions that the gRPC Client satisfies the core Provider
 // interface plus the optional capability interfaces it advertises via
 // type-assertion (issues #176, #179, #154).
 var (
-	_ contracts.Provider           = (*Client)(nil)
-	_ contracts.CapabilityReporter = (*Client)(nil)
-	_ contracts.Cloner             = (*Client)(nil)
-	_ contracts.ImagePreparer      = (*Client)(nil)
+	_ contracts.Provider            = (*Client)(nil)
+	_ contracts.CapabilityReporter  = (*Client)(nil)
+	_ contracts.Cloner              = (*Client)(nil)
+	_ contracts.ImagePreparer       = (*Client)(nil)
+	_ contracts.ImagePublisher      = (*Client)(nil)
+	_ contracts.ConsoleOutputReader = (*Client)(nil)
+	_ contracts.BatchDescriber      = (*Client)(nil)
+	_ contracts.TaskLinker          = (*Client)(nil)
 )
 
 // Client wraps a gRPC provider client and implements the contracts.Provider interface
@@ -78,6 +90,59 @@ type Client struct {
 	// the gauge measures "tasks THIS instance is tracking", not "tasks
 	// the provider thinks are in-flight").
 	inflightTasks map[string]struct{}
+	// taskLinksMu guards taskLinks.
+	taskLinksMu sync.Mutex
+	// taskLinks holds the link each in-flight task was returned with, for
+	// TaskLink. Entries go when the task is observed done.
+	taskLinks map[string]string
+
+	// timeouts returns the RPC deadlines to use. Read on every call so
+	// that a config reload applies to clients already connected; nil means
+	// DefaultRPCTimeouts.
+	timeouts func() RPCTimeouts
+
+	// describeBatchUnsupportedUntil is the UnixNano time until which
+	// DescribeBatch calls Describe per VM instead, set when the provider
+	// answers DescribeBatch with Unimplemented.
+	describeBatchUnsupportedUntil atomic.Int64
+}
+
+// RPCTimeouts are the deadlines the client puts on provider RPCs. Disk
+// export/import, GetDiskInfo and ListVMs keep their own fixed deadlines.
+type RPCTimeouts struct {
+	// Read covers Validate, GetCapabilities, Describe, GetInfo,
+	// GetCapacity, GetConsoleOutput, ConsoleTicket and SnapshotList, and each
+	// DescribeBatch call of up to describeBatchSize VMs.
+	Read time.Duration
+	// Mutating covers Create, Reconfigure, Clone, PrepareImage,
+	// SnapshotCreate and SnapshotRevert.
+	Mutating time.Duration
+	// Power covers Power, Delete, SnapshotDelete and MigrateHost.
+	Power time.Duration
+	// TaskStatus covers TaskStatus and IsTaskComplete.
+	TaskStatus time.Duration
+}
+
+// DefaultRPCTimeouts returns the deadlines used when none are configured.
+func DefaultRPCTimeouts() RPCTimeouts {
+	return RPCTimeouts{
+		Read:       30 * time.Second,
+		Mutating:   5 * time.Minute,
+		Power:      2 * time.Minute,
+		TaskStatus: 10 * time.Second,
+	}
+}
+
+// SetTimeouts makes the client ask fn for its RPC deadlines on every call.
+func (c *Client) SetTimeouts(fn func() RPCTimeouts) {
+	c.timeouts = fn
+}
+
+func (c *Client) rpcTimeouts() RPCTimeouts {
+	if c.timeouts == nil {
+		return DefaultRPCTimeouts()
+	}
+	return c.timeouts()
 }
 
 // NewClient creates a new gRPC provider client.
@@ -101,7 +166,9 @@ type Client struct {
 // Unavailable status until ResetTimeout elapses (G6 / #111). Pass nil to
 // disable circuit-breaker protection — useful in unit tests that exercise
 // real gRPC failure semantics without the breaker interposing.
-func NewClient(ctx context.Context, endpoint string, providerType string, providerName string, cb *resilience.CircuitBreaker, tlsConfig *TLSConfig) (*Client, error) {
+//
+// extraOpts are appended to the dial options, e.g. per-RPC credentials.
+func NewClient(ctx context.Context, endpoint string, providerType string, providerName string, cb *resilience.CircuitBreaker, tlsConfig *TLSConfig, extraOpts ...grpc.DialOption) (*Client, error) {
 	// Connection timeout is handled by grpc.NewClient internally
 	_ = ctx // Context available for future timeout implementation
 
@@ -131,6 +198,9 @@ func NewClient(ctx context.Context, endpoint string, providerType string, provid
 		// G4 (#90): record per-RPC latency + status code into the
 		// virtrigaud_provider_rpc_* metric families.
 		providerRPCMetricsInterceptor(providerType),
+		// Forward the reconcile's correlation ID so provider-side payload
+		// samples line up with manager logs.
+		correlationIDInterceptor(),
 	}
 	if cb != nil {
 		// G6 (#111): wrap RPCs with circuit-breaker fast-fail. Infra
@@ -147,6 +217,7 @@ func NewClient(ctx context.Context, endpoint string, providerType string, provid
 		),
 		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
 	)
+	opts = append(opts, extraOpts...)
 
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
@@ -203,6 +274,38 @@ func providerRPCMetricsInterceptor(providerType string) grpc.UnaryClientIntercep
 	}
 }
 
+// correlationIDMetadataKey carries the manager's correlation ID to the
+// provider. It must match middleware.CorrelationIDMetadataKey in the SDK.
+const correlationIDMetadataKey = "x-virtrigaud-correlation-id"
+
+// correlationIDInterceptor returns a UnaryClientInterceptor that attaches
+// the caller's correlation ID to outgoing metadata. The explicit ID set via
+// logging.WithCorrelationID wins; otherwise the controller-runtime reconcile
+// ID is used. RPCs made outside a reconcile carry no ID.
+func correlationIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		fullMethod string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if id := correlationIDFromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, correlationIDMetadataKey, id)
+		}
+		return invoker(ctx, fullMethod, req, reply, cc, opts...)
+	}
+}
+
+// correlationIDFromContext returns the correlation ID for ctx, or "".
+func correlationIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(logging.CorrelationIDKey).(string); ok && id != "" {
+		return id
+	}
+	return string(controller.ReconcileIDFromContext(ctx))
+}
+
 // shortRPCMethod extracts the RPC method name from a full gRPC method
 // path. gRPC formats the path as "/<package>.<Service>/<Method>"
 // (e.g. "/provider.v1.Provider/Validate" -> "Validate"). Falls back to
@@ -332,6 +435,10 @@ func isInfraFailure(err error) bool {
 //
 // Outcome derivation matches G1-G3's named-return reconcile pattern:
 //   - retErr == nil  → metrics.OutcomeSuccess
+//   - a Conflict     → metrics.OutcomeConflict, since the call raced
+//     another change and is retried rather than failed
+//   - a Busy         → metrics.OutcomeBusy, since the provider turned
+//     the call away to stay under a limit and it is retried later
 //   - retErr != nil  → metrics.OutcomeError
 //
 // The provider/provider_type labels come from the values passed to
@@ -341,12 +448,47 @@ func (c *Client) recordVMOp(op string, retErr *error) {
 		return
 	}
 	outcome := metrics.OutcomeSuccess
-	if retErr != nil && *retErr != nil {
+	switch {
+	case retErr == nil || *retErr == nil:
+	case contracts.IsConflict(*retErr):
+		outcome = metrics.OutcomeConflict
+	case isBusy(*retErr):
+		outcome = metrics.OutcomeBusy
+	default:
 		outcome = metrics.OutcomeError
 	}
 	c.vmOps.RecordOperation(op, outcome)
 }
 
+// isBusy reports whether err is a provider Busy error.
+func isBusy(err error) bool {
+	_, busy := contracts.IsBusy(err)
+	return busy
+}
+
+// trackTask records a task returned by a task-creating RPC: it counts it
+// as in flight and keeps the link the provider sent with it.
+func (c *Client) trackTask(task *providerv1.TaskRef) {
+	c.trackTaskStart(task.GetId())
+	if task.GetId() == "" || task.GetLink() == "" {
+		return
+	}
+	c.taskLinksMu.Lock()
+	defer c.taskLinksMu.Unlock()
+	if c.taskLinks == nil {
+		c.taskLinks = make(map[string]string)
+	}
+	c.taskLinks[task.GetId()] = task.GetLink()
+}
+
+// TaskLink implements contracts.TaskLinker. Links are only held while the
+// task is in flight, so callers read it when the task is started.
+func (c *Client) TaskLink(taskRef string) string {
+	c.taskLinksMu.Lock()
+	defer c.taskLinksMu.Unlock()
+	return c.taskLinks[taskRef]
+}
+
 // trackTaskStart adds taskID to the inflight set and pushes the new
 // gauge value to virtrigaud_provider_tasks_inflight{provider_type,
 // provider}. G7.3 / #129.
@@ -393,6 +535,9 @@ func (c *Client) trackTaskStart(taskID string) {
 //     this gauge measures "tasks THIS manager instance is tracking",
 //     not "tasks the provider believes are in-flight".
 func (c *Client) trackTaskDone(taskID string) {
+	c.taskLinksMu.Lock()
+	delete(c.taskLinks, taskID)
+	c.taskLinksMu.Unlock()
 	if c.tasks == nil || taskID == "" {
 		return
 	}
@@ -414,7 +559,7 @@ func (c *Client) Close() error {
 
 // Validate implements contracts.Provider
 func (c *Client) Validate(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
 	defer cancel()
 
 	resp, err := c.client.Validate(ctx, &providerv1.ValidateRequest{})
@@ -433,7 +578,7 @@ func (c *Client) Validate(ctx context.Context) error {
 // provider's advertised capabilities over gRPC so the manager can surface and
 // (when enabled) gate on them (issue #176).
 func (c *Client) GetCapabilities(ctx context.Context) (contracts.Capabilities, error) {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
 	defer cancel()
 
 	resp, err := c.client.GetCapabilities(ctx, &providerv1.GetCapabilitiesRequest{})
@@ -458,6 +603,17 @@ func (c *Client) GetCapabilities(ctx context.Context) (contracts.Capabilities, e
 		SupportedExportBackends:     resp.SupportedExportBackends,
 		SupportedImportBackends:     resp.SupportedImportBackends,
 		SupportedTransferModes:      resp.SupportedTransferModes,
+		SupportsConsoleOutput:       resp.SupportsConsoleOutput,
+		SupportsSysprep:             resp.SupportsSysprep,
+		SupportsCloudInitStatus:     resp.SupportsCloudInitStatus,
+		SupportsLiveMigration:       resp.SupportsLiveMigration,
+		MinProtocolVersion:          int32(resp.MinProtocolVersion),
+		MaxProtocolVersion:          int32(resp.MaxProtocolVersion),
+		SupportsConsoleProxy:        resp.SupportsConsoleProxy,
+		SupportsImagePublish:        resp.SupportsImagePublish,
+		SupportsEventWatch:          resp.SupportsEventWatch,
+		SupportsQuiescedSnapshots:   resp.SupportsQuiescedSnapshots,
+		CapabilityManifest:          resp.CapabilityManifest,
 	}, nil
 }
 
@@ -470,28 +626,39 @@ func (c *Client) Clone(ctx context.Context, req contracts.CloneRequest) (contrac
 	// Clone can be a long-running provider operation; use the same generous
 	// timeout as Create. The returned TaskRef lets the caller poll for
 	// completion regardless.
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
-	resp, err := c.client.Clone(ctx, &providerv1.CloneRequest{
+	grpcReq := &providerv1.CloneRequest{
 		SourceVmId:    req.SourceVmID,
 		TargetName:    req.TargetName,
 		Linked:        req.Linked,
 		ClassJson:     req.ClassJSON,
 		PlacementJson: req.PlacementJSON,
 		CustomizeJson: req.CustomizeJSON,
-	})
+	}
+	if req.Customization != nil {
+		customizationData, err := contracts.MarshalPayload(req.Customization)
+		if err != nil {
+			return contracts.CloneResponse{}, fmt.Errorf("failed to marshal clone customization: %w", err)
+		}
+		grpcReq.CustomizationJson = string(customizationData)
+	}
+
+	resp, err := c.client.Clone(ctx, grpcReq)
 	if err != nil {
 		return contracts.CloneResponse{}, c.mapGRPCError("clone", err)
 	}
 
 	result := contracts.CloneResponse{
-		TargetVmID: resp.TargetVmId,
+		TargetVmID:           resp.TargetVmId,
+		CustomizationApplied: resp.CustomizationApplied,
+		CustomizationError:   resp.CustomizationError,
 	}
 
 	if resp.Task != nil {
 		result.TaskRef = resp.Task.Id
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 	}
 
 	return result, nil
@@ -505,7 +672,7 @@ func (c *Client) Clone(ctx context.Context, req contracts.CloneRequest) (contrac
 // provider reports a TaskRef the caller polls it via IsTaskComplete; an empty
 // TaskRef means the operation completed synchronously.
 func (c *Client) PrepareImage(ctx context.Context, req contracts.ImagePrepareRequest) (contracts.ImagePrepareResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
 	resp, err := c.client.ImagePrepare(ctx, &providerv1.ImagePrepareRequest{
@@ -527,9 +694,46 @@ func (c *Client) PrepareImage(ctx context.Context, req contracts.ImagePrepareReq
 	}
 	if resp.Task != nil {
 		result.TaskRef = resp.Task.Id
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
+	}
+
+	return result, nil
+}
+
+// PublishImage implements contracts.ImagePublisher: it publishes a copy of
+// a VM as a template or image. Like PrepareImage it copies whole disks, so it
+// uses the Mutating timeout and reports a TaskRef for the caller to poll.
+func (c *Client) PublishImage(ctx context.Context, req contracts.PublishImageRequest) (contracts.PublishImageResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
+	defer cancel()
+
+	options, err := contracts.MarshalPayload(req.Options)
+	if err != nil {
+		return contracts.PublishImageResponse{}, fmt.Errorf("failed to marshal publish options: %w", err)
+	}
+	resp, err := c.client.PublishImage(ctx, &providerv1.PublishImageRequest{
+		VmId:        req.VMID,
+		ImageName:   req.ImageName,
+		OptionsJson: string(options),
+		Overwrite:   req.Overwrite,
+	})
+	if err != nil {
+		if st := status.Convert(err); st.Code() == codes.Unimplemented {
+			return contracts.PublishImageResponse{}, contracts.NewNotSupportedError("publishImage: " + st.Message())
+		}
+		return contracts.PublishImageResponse{}, c.mapGRPCError("publishImage", err)
 	}
 
+	result := contracts.PublishImageResponse{
+		ImageID:      resp.GetImageId(),
+		ImagePath:    resp.GetImagePath(),
+		Checksum:     resp.GetChecksum(),
+		ChecksumType: resp.GetChecksumType(),
+	}
+	if resp.Task != nil {
+		result.TaskRef = resp.Task.Id
+		c.trackTask(resp.Task) // G7.3 (#129)
+	}
 	return result, nil
 }
 
@@ -540,7 +744,7 @@ func (c *Client) PrepareImage(ctx context.Context, req contracts.ImagePrepareReq
 func (c *Client) Create(ctx context.Context, req contracts.CreateRequest) (result contracts.CreateResponse, retErr error) {
 	defer c.recordVMOp(metrics.OpCreate, &retErr)
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
 	grpcReq, err := c.convertCreateRequest(req)
@@ -554,12 +758,13 @@ func (c *Client) Create(ctx context.Context, req contracts.CreateRequest) (resul
 	}
 
 	result = contracts.CreateResponse{
-		ID: resp.Id,
+		ID:          resp.Id,
+		ProviderRaw: providerRawFromJSON(resp.ProviderRawJson),
 	}
 
 	if resp.Task != nil {
 		result.TaskRef = resp.Task.Id
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 	}
 
 	return result, nil
@@ -572,7 +777,7 @@ func (c *Client) Create(ctx context.Context, req contracts.CreateRequest) (resul
 func (c *Client) Delete(ctx context.Context, id string) (taskRef string, retErr error) {
 	defer c.recordVMOp(metrics.OpDelete, &retErr)
 
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Power)
 	defer cancel()
 
 	resp, err := c.client.Delete(ctx, &providerv1.DeleteRequest{Id: id})
@@ -581,7 +786,7 @@ func (c *Client) Delete(ctx context.Context, id string) (taskRef string, retErr
 	}
 
 	if resp.Task != nil {
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 		return resp.Task.Id, nil
 	}
 
@@ -595,7 +800,7 @@ func (c *Client) Delete(ctx context.Context, id string) (taskRef string, retErr
 func (c *Client) Power(ctx context.Context, id string, op contracts.PowerOp) (taskRef string, retErr error) {
 	defer c.recordVMOp(metrics.OpPower, &retErr)
 
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Power)
 	defer cancel()
 
 	grpcOp, err := c.convertPowerOp(op)
@@ -619,7 +824,7 @@ func (c *Client) Power(ctx context.Context, id string, op contracts.PowerOp) (ta
 	}
 
 	if resp.Task != nil {
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 		return resp.Task.Id, nil
 	}
 
@@ -631,31 +836,73 @@ func (c *Client) Power(ctx context.Context, id string, op contracts.PowerOp) (ta
 // Records virtrigaud_vm_operations_total{operation="Reconfigure",...}
 // via deferred recordVMOp using the named retErr return value (G7.1 /
 // #124).
-func (c *Client) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest) (taskRef string, retErr error) {
+func (c *Client) Reconfigure(ctx context.Context, id string, desired contracts.CreateRequest, changes contracts.ChangeSet) (result contracts.ReconfigureResult, retErr error) {
 	defer c.recordVMOp(metrics.OpReconfigure, &retErr)
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
-	desiredJSON, err := json.Marshal(desired)
+	desiredJSON, err := contracts.MarshalPayload(desired)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal desired configuration: %w", err)
+		return contracts.ReconfigureResult{}, fmt.Errorf("failed to marshal desired configuration: %w", err)
+	}
+	changeSet, err := changeSetToProto(changes)
+	if err != nil {
+		return contracts.ReconfigureResult{}, err
 	}
 
 	resp, err := c.client.Reconfigure(ctx, &providerv1.ReconfigureRequest{
 		Id:          id,
 		DesiredJson: string(desiredJSON),
+		Changes:     changeSet,
 	})
 	if err != nil {
-		return "", c.mapGRPCError("reconfigure", err)
+		return contracts.ReconfigureResult{}, c.mapGRPCError("reconfigure", err)
 	}
 
+	result.PowerCycleRequired = resp.PowerCycleRequired
 	if resp.Task != nil {
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
-		return resp.Task.Id, nil
+		c.trackTask(resp.Task) // G7.3 (#129)
+		result.TaskRef = resp.Task.Id
 	}
 
-	return "", nil
+	return result, nil
+}
+
+// changeSetToProto converts a contracts.ChangeSet for the wire. Added
+// network attachments travel as JSON, like CreateRequest.networks_json.
+func changeSetToProto(changes contracts.ChangeSet) (*providerv1.ChangeSet, error) {
+	out := &providerv1.ChangeSet{NetworksRemoved: changes.NetworksRemoved}
+	if changes.CPU != nil {
+		out.Cpu = &providerv1.Int32Change{Old: changes.CPU.Old, New: changes.CPU.New}
+	}
+	if changes.MemoryMiB != nil {
+		out.MemoryMib = &providerv1.Int64Change{Old: changes.MemoryMiB.Old, New: changes.MemoryMiB.New}
+	}
+	for _, d := range changes.Disks {
+		out.Disks = append(out.Disks, &providerv1.DiskExpansion{
+			Name:       d.Name,
+			OldSizeGib: d.OldSizeGiB,
+			NewSizeGib: d.NewSizeGiB,
+		})
+	}
+	for _, n := range changes.NetworksAdded {
+		attachment, err := contracts.MarshalPayload(n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal network attachment %q: %w", n.Name, err)
+		}
+		out.NetworksAdded = append(out.NetworksAdded, &providerv1.NetworkChange{
+			Name:           n.Name,
+			AttachmentJson: string(attachment),
+		})
+	}
+	for _, sg := range changes.SecurityGroups {
+		out.SecurityGroups = append(out.SecurityGroups, &providerv1.SecurityGroupChange{
+			Network: sg.Network,
+			Groups:  sg.Groups,
+		})
+	}
+	return out, nil
 }
 
 // Describe implements contracts.Provider.
@@ -665,7 +912,7 @@ func (c *Client) Reconfigure(ctx context.Context, id string, desired contracts.C
 func (c *Client) Describe(ctx context.Context, id string) (result contracts.DescribeResponse, retErr error) {
 	defer c.recordVMOp(metrics.OpDescribe, &retErr)
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
 	defer cancel()
 
 	resp, err := c.client.Describe(ctx, &providerv1.DescribeRequest{Id: id})
@@ -673,35 +920,180 @@ func (c *Client) Describe(ctx context.Context, id string) (result contracts.Desc
 		return contracts.DescribeResponse{}, c.mapGRPCError("describe", err)
 	}
 
-	// Parse provider raw data
-	var providerRaw map[string]string
-	if resp.ProviderRawJson != "" {
-		// First unmarshal to map[string]any, then convert to map[string]string
-		var rawData map[string]any
-		if err := json.Unmarshal([]byte(resp.ProviderRawJson), &rawData); err != nil {
-			// Log error but don't fail the entire operation
-			providerRaw = map[string]string{"parseError": err.Error()}
-		} else {
-			// Convert map[string]any to map[string]string
-			providerRaw = make(map[string]string)
-			for k, v := range rawData {
-				providerRaw[k] = fmt.Sprintf("%v", v)
-			}
-		}
-	}
+	return describeFromProto(resp), nil
+}
+
+// providerRawFromJSON converts a provider_raw_json object to a string map:
+// details in the describe layout flattened, a free-form object with each
+// value formatted. A payload that does not parse is reported under
+// "parseError" rather than failing the call it came with.
+func providerRawFromJSON(raw string) map[string]string {
+	providerRaw, _ := providerDetailsFromJSON(raw)
+	return providerRaw
+}
 
+// providerDetailsFromJSON is providerRawFromJSON that also returns the
+// typed details, or nil for a free-form or unparseable payload.
+func providerDetailsFromJSON(raw string) (map[string]string, *describe.Details) {
+	if raw == "" {
+		return nil, nil
+	}
+	details, err := describe.Parse(raw)
+	switch {
+	case err == nil:
+		return details.Flatten(), details
+	case !errors.Is(err, describe.ErrUnversioned):
+		return map[string]string{"parseError": err.Error()}, nil
+	}
+	// First unmarshal to map[string]any, then convert to map[string]string
+	var rawData map[string]any
+	if err := json.Unmarshal([]byte(raw), &rawData); err != nil {
+		return map[string]string{"parseError": err.Error()}, nil
+	}
+	providerRaw := make(map[string]string, len(rawData))
+	for k, v := range rawData {
+		providerRaw[k] = fmt.Sprintf("%v", v)
+	}
+	return providerRaw, nil
+}
+
+// describeFromProto converts a provider.v1 DescribeResponse. A provider
+// that reports usage only in its typed details has GuestStats taken from
+// them.
+func describeFromProto(resp *providerv1.DescribeResponse) contracts.DescribeResponse {
+	providerRaw, details := providerDetailsFromJSON(resp.ProviderRawJson)
+	guestStats := guestStatsFromProto(resp.GuestStats)
+	if guestStats == nil && details != nil {
+		guestStats = guestStatsFromDetails(details.Resources)
+	}
 	return contracts.DescribeResponse{
 		Exists:      resp.Exists,
 		PowerState:  resp.PowerState,
 		IPs:         resp.Ips,
 		ConsoleURL:  resp.ConsoleUrl,
 		ProviderRaw: providerRaw,
-	}, nil
+		GuestStats:  guestStats,
+		Addresses:   guestAddressesFromProto(resp.Addresses),
+		Identity:    resp.Identity,
+		Details:     details,
+	}
+}
+
+// describeBatchSize caps the ids sent in one DescribeBatch call, keeping
+// the response of a large fleet well below the gRPC message size limit.
+const describeBatchSize = 200
+
+// describeBatchRetryAfter is how long the client describes VMs one by one
+// after a provider answered DescribeBatch with Unimplemented, before it
+// tries the batch RPC again (the provider may have been upgraded).
+const describeBatchRetryAfter = 10 * time.Minute
+
+// DescribeBatch implements contracts.BatchDescriber. Against a provider
+// that predates DescribeBatch it falls back to one Describe per id.
+func (c *Client) DescribeBatch(ctx context.Context, ids []string) (contracts.DescribeBatchResponse, error) {
+	out := contracts.DescribeBatchResponse{Results: make(map[string]contracts.DescribeResponse, len(ids))}
+	for start := 0; start < len(ids); start += describeBatchSize {
+		chunk := ids[start:min(start+describeBatchSize, len(ids))]
+		if !c.describeBatchSupported() {
+			mergeDescribeBatch(&out, contracts.DescribeEach(ctx, c, chunk))
+			continue
+		}
+		resp, err := c.describeBatch(ctx, chunk)
+		if status.Code(err) == codes.Unimplemented {
+			c.describeBatchUnsupportedUntil.Store(time.Now().Add(describeBatchRetryAfter).UnixNano())
+			mergeDescribeBatch(&out, contracts.DescribeEach(ctx, c, chunk))
+			continue
+		}
+		if err != nil {
+			return contracts.DescribeBatchResponse{}, c.mapGRPCError("describeBatch", err)
+		}
+		mergeDescribeBatch(&out, describeBatchFromProto(chunk, resp))
+	}
+	return out, nil
+}
+
+func (c *Client) describeBatch(ctx context.Context, ids []string) (*providerv1.DescribeBatchResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+	return c.client.DescribeBatch(ctx, &providerv1.DescribeBatchRequest{Ids: ids})
+}
+
+func (c *Client) describeBatchSupported() bool {
+	return time.Now().UnixNano() >= c.describeBatchUnsupportedUntil.Load()
+}
+
+// describeBatchFromProto converts a provider.v1 DescribeBatchResponse to
+// the requested ids. An id the provider left out of both results and errors
+// is reported as an error.
+func describeBatchFromProto(ids []string, resp *providerv1.DescribeBatchResponse) contracts.DescribeBatchResponse {
+	out := contracts.DescribeBatchResponse{Results: make(map[string]contracts.DescribeResponse, len(ids))}
+	for _, id := range ids {
+		if desc, ok := resp.GetResults()[id]; ok && desc != nil {
+			out.Results[id] = describeFromProto(desc)
+			continue
+		}
+		if out.Errors == nil {
+			out.Errors = map[string]error{}
+		}
+		if msg, ok := resp.GetErrors()[id]; ok {
+			out.Errors[id] = fmt.Errorf("describe: %s", msg)
+		} else {
+			out.Errors[id] = fmt.Errorf("describe: provider returned no result for %q", id)
+		}
+	}
+	return out
+}
+
+func mergeDescribeBatch(into *contracts.DescribeBatchResponse, from contracts.DescribeBatchResponse) {
+	for id, desc := range from.Results {
+		into.Results[id] = desc
+	}
+	for id, err := range from.Errors {
+		if into.Errors == nil {
+			into.Errors = map[string]error{}
+		}
+		into.Errors[id] = err
+	}
+}
+
+func guestAddressesFromProto(addrs []*providerv1.GuestAddress) []contracts.GuestAddress {
+	if len(addrs) == 0 {
+		return nil
+	}
+	out := make([]contracts.GuestAddress, 0, len(addrs))
+	for _, a := range addrs {
+		out = append(out, contracts.GuestAddress{IP: a.GetIp(), Interface: a.GetInterface()})
+	}
+	return out
+}
+
+// guestStatsFromDetails converts the usage in typed describe details, nil
+// when they report none.
+func guestStatsFromDetails(r *describe.ResourceStats) *contracts.GuestStats {
+	if r == nil || (r.CPUUsagePercent == nil && r.MemoryUsageBytes == nil && r.UptimeSeconds == nil) {
+		return nil
+	}
+	return &contracts.GuestStats{
+		CPUUsagePercent:  r.CPUUsagePercent,
+		MemoryUsageBytes: r.MemoryUsageBytes,
+		UptimeSeconds:    r.UptimeSeconds,
+	}
+}
+
+func guestStatsFromProto(gs *providerv1.GuestStats) *contracts.GuestStats {
+	if gs == nil {
+		return nil
+	}
+	return &contracts.GuestStats{
+		CPUUsagePercent:  gs.CpuUsagePercent,
+		MemoryUsageBytes: gs.MemoryUsageBytes,
+		UptimeSeconds:    gs.UptimeSeconds,
+	}
 }
 
 // IsTaskComplete implements contracts.Provider
 func (c *Client) IsTaskComplete(ctx context.Context, taskRef string) (done bool, err error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().TaskStatus)
 	defer cancel()
 
 	resp, err := c.client.TaskStatus(ctx, &providerv1.TaskStatusRequest{
@@ -726,7 +1118,7 @@ func (c *Client) IsTaskComplete(ctx context.Context, taskRef string) (done bool,
 
 // TaskStatus checks the status of an async task
 func (c *Client) TaskStatus(ctx context.Context, taskRef string) (contracts.TaskStatus, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().TaskStatus)
 	defer cancel()
 
 	resp, err := c.client.TaskStatus(ctx, &providerv1.TaskStatusRequest{
@@ -746,21 +1138,22 @@ func (c *Client) TaskStatus(ctx context.Context, taskRef string) (contracts.Task
 	return contracts.TaskStatus{
 		IsCompleted: resp.Done,
 		Error:       resp.Error,
-		Message:     "", // Message field not in proto
+		Message:     resp.Message,
 	}, nil
 }
 
 // SnapshotCreate creates a VM snapshot
 func (c *Client) SnapshotCreate(ctx context.Context, req contracts.SnapshotCreateRequest) (contracts.SnapshotCreateResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
 	grpcReq := &providerv1.SnapshotCreateRequest{
-		VmId:          req.VmId,
-		NameHint:      req.NameHint,
-		Description:   req.Description,
-		IncludeMemory: req.IncludeMemory,
-		// Note: Quiesce not in proto yet, would need to add to provider.proto
+		VmId:            req.VmId,
+		NameHint:        req.NameHint,
+		Description:     req.Description,
+		IncludeMemory:   req.IncludeMemory,
+		Quiesce:         req.Quiesce,
+		AllowUnquiesced: req.AllowUnquiesced,
 	}
 
 	resp, err := c.client.SnapshotCreate(ctx, grpcReq)
@@ -769,22 +1162,45 @@ func (c *Client) SnapshotCreate(ctx context.Context, req contracts.SnapshotCreat
 	}
 
 	result := contracts.SnapshotCreateResponse{
-		SnapshotId: resp.SnapshotId,
+		SnapshotId:     resp.SnapshotId,
+		SizeBytes:      resp.SizeBytes,
+		ConsumedBytes:  resp.ConsumedBytes,
+		Quiesce:        quiesceOutcomeFromProto(resp.Quiesce),
+		QuiesceMessage: resp.QuiesceMessage,
+	}
+	if resp.CreatedUnix > 0 {
+		result.CreatedAt = time.Unix(resp.CreatedUnix, 0).UTC()
 	}
 
 	if resp.Task != nil {
 		result.Task = &contracts.TaskRef{
-			ID: resp.Task.Id,
+			ID:   resp.Task.Id,
+			Link: resp.Task.Link,
 		}
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 	}
 
 	return result, nil
 }
 
+// quiesceOutcomeFromProto maps a provider.v1 QuiesceOutcome onto its
+// contracts form; UNSPECIFIED and unknown values become QuiesceUnknown.
+func quiesceOutcomeFromProto(outcome providerv1.QuiesceOutcome) contracts.QuiesceOutcome {
+	switch outcome {
+	case providerv1.QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED:
+		return contracts.QuiesceAchieved
+	case providerv1.QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED:
+		return contracts.QuiesceDegraded
+	case providerv1.QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED:
+		return contracts.QuiesceSkipped
+	default:
+		return contracts.QuiesceUnknown
+	}
+}
+
 // SnapshotDelete deletes a VM snapshot
 func (c *Client) SnapshotDelete(ctx context.Context, vmId string, snapshotId string) (taskRef string, err error) {
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Power)
 	defer cancel()
 
 	grpcReq := &providerv1.SnapshotDeleteRequest{
@@ -798,7 +1214,7 @@ func (c *Client) SnapshotDelete(ctx context.Context, vmId string, snapshotId str
 	}
 
 	if resp.Task != nil {
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 		return resp.Task.Id, nil
 	}
 
@@ -807,7 +1223,7 @@ func (c *Client) SnapshotDelete(ctx context.Context, vmId string, snapshotId str
 
 // SnapshotRevert reverts a VM to a snapshot
 func (c *Client) SnapshotRevert(ctx context.Context, vmId string, snapshotId string) (taskRef string, err error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Mutating)
 	defer cancel()
 
 	grpcReq := &providerv1.SnapshotRevertRequest{
@@ -821,7 +1237,7 @@ func (c *Client) SnapshotRevert(ctx context.Context, vmId string, snapshotId str
 	}
 
 	if resp.Task != nil {
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 		return resp.Task.Id, nil
 	}
 
@@ -859,7 +1275,7 @@ func (c *Client) ExportDisk(ctx context.Context, req contracts.ExportDiskRequest
 
 	if resp.Task != nil {
 		result.TaskRef = resp.Task.Id
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 	}
 
 	return result, nil
@@ -897,7 +1313,7 @@ func (c *Client) ImportDisk(ctx context.Context, req contracts.ImportDiskRequest
 
 	if resp.Task != nil {
 		result.TaskRef = resp.Task.Id
-		c.trackTaskStart(resp.Task.Id) // G7.3 (#129)
+		c.trackTask(resp.Task) // G7.3 (#129)
 	}
 
 	return result, nil
@@ -986,16 +1402,318 @@ func (c *Client) ListVMs(ctx context.Context) ([]contracts.VMInfo, error) {
 	return vmInfos, nil
 }
 
+// GetConsoleOutput implements contracts.ConsoleOutputReader. It fetches the
+// tail of a VM's serial/console log so boot failures can be diagnosed without
+// hypervisor console access. Providers that do not capture console output
+// answer Unimplemented, which surfaces as a NotSupported error.
+func (c *Client) GetConsoleOutput(ctx context.Context, id string, tailLines int32) (contracts.ConsoleOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.GetConsoleOutput(ctx, &providerv1.GetConsoleOutputRequest{
+		Id:        id,
+		TailLines: tailLines,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return contracts.ConsoleOutput{}, contracts.NewNotSupportedError("getConsoleOutput: provider does not support console output")
+		}
+		return contracts.ConsoleOutput{}, c.mapGRPCError("getConsoleOutput", err)
+	}
+
+	return contracts.ConsoleOutput{
+		Output:    resp.Output,
+		Truncated: resp.Truncated,
+		Source:    resp.Source,
+	}, nil
+}
+
+// GetCloudInitStatus implements contracts.CloudInitStatusReader. Providers
+// without a guest channel answer Unimplemented, as do providers that cannot
+// read this particular guest; both surface as a NotSupported error carrying
+// the provider's explanation.
+func (c *Client) GetCloudInitStatus(ctx context.Context, id string) (contracts.CloudInitStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.GetCloudInitStatus(ctx, &providerv1.GetCloudInitStatusRequest{Id: id})
+	if err != nil {
+		if st := status.Convert(err); st.Code() == codes.Unimplemented {
+			return contracts.CloudInitStatus{}, contracts.NewNotSupportedError("getCloudInitStatus: " + st.Message())
+		}
+		return contracts.CloudInitStatus{}, c.mapGRPCError("getCloudInitStatus", err)
+	}
+
+	return contracts.CloudInitStatus{
+		Status:            resp.Status,
+		Errors:            resp.Errors,
+		RecoverableErrors: resp.RecoverableErrors,
+		Detail:            resp.Detail,
+	}, nil
+}
+
+// MigrateHost implements contracts.HostMigrator. Providers that manage a
+// single host answer Unimplemented, which surfaces as a NotSupported error.
+func (c *Client) MigrateHost(ctx context.Context, id, targetHost string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Power)
+	defer cancel()
+
+	resp, err := c.client.MigrateHost(ctx, &providerv1.MigrateHostRequest{Id: id, TargetHost: targetHost})
+	if err != nil {
+		if st := status.Convert(err); st.Code() == codes.Unimplemented {
+			return "", contracts.NewNotSupportedError("migrateHost: " + st.Message())
+		}
+		return "", c.mapGRPCError("migrateHost", err)
+	}
+
+	if resp.Task != nil {
+		c.trackTask(resp.Task)
+		return resp.Task.Id, nil
+	}
+	return "", nil
+}
+
+// ConsoleTicket implements contracts.ConsoleTicketIssuer. Providers without
+// a console proxy answer Unimplemented, which surfaces as a NotSupported
+// error.
+func (c *Client) ConsoleTicket(ctx context.Context, id string) (contracts.ConsoleTicket, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.ConsoleTicket(ctx, &providerv1.ConsoleTicketRequest{Id: id})
+	if err != nil {
+		if st := status.Convert(err); st.Code() == codes.Unimplemented {
+			return contracts.ConsoleTicket{}, contracts.NewNotSupportedError("consoleTicket: " + st.Message())
+		}
+		return contracts.ConsoleTicket{}, c.mapGRPCError("consoleTicket", err)
+	}
+
+	return contracts.ConsoleTicket{
+		Protocol:               resp.Protocol,
+		URL:                    resp.Url,
+		Headers:                resp.Headers,
+		Password:               resp.Password,
+		CABundle:               resp.CaBundle,
+		CertificateFingerprint: resp.CertificateFingerprint,
+		InsecureSkipVerify:     resp.InsecureSkipVerify,
+	}, nil
+}
+
+// ConsoleRelay implements contracts.ConsoleRelayer. The relay has no
+// deadline; it lasts until the returned connection is closed or ctx is
+// done. A provider that cannot relay surfaces on the first Read.
+func (c *Client) ConsoleRelay(ctx context.Context, id string) (io.ReadWriteCloser, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.client.ConsoleRelay(ctx)
+	if err != nil {
+		cancel()
+		return nil, c.mapGRPCError("consoleRelay", err)
+	}
+	if err := stream.Send(&providerv1.ConsoleRelayRequest{Id: id}); err != nil {
+		cancel()
+		return nil, c.mapGRPCError("consoleRelay", err)
+	}
+	return &consoleRelayConn{client: c, stream: stream, cancel: cancel}, nil
+}
+
+// consoleRelayConn is the byte stream of a ConsoleRelay.
+type consoleRelayConn struct {
+	client *Client
+	stream providerv1.Provider_ConsoleRelayClient
+	cancel context.CancelFunc
+	// pending is what the last response held beyond the last Read.
+	pending []byte
+}
+
+func (r *consoleRelayConn) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		resp, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			if st := status.Convert(err); st.Code() == codes.Unimplemented {
+				return 0, contracts.NewNotSupportedError("consoleRelay: " + st.Message())
+			}
+			if status.Code(err) == codes.Canceled {
+				return 0, io.EOF
+			}
+			return 0, r.client.mapGRPCError("consoleRelay", err)
+		}
+		r.pending = resp.Data
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *consoleRelayConn) Write(p []byte) (int, error) {
+	if err := r.stream.Send(&providerv1.ConsoleRelayRequest{Data: p}); err != nil {
+		return 0, r.client.mapGRPCError("consoleRelay", err)
+	}
+	return len(p), nil
+}
+
+func (r *consoleRelayConn) Close() error {
+	err := r.stream.CloseSend()
+	r.cancel()
+	return err
+}
+
+// WatchEvents implements contracts.EventWatcher. The call has no deadline;
+// it lasts until ctx is done or the provider ends the stream. Providers
+// that cannot watch answer Unimplemented, which surfaces as a NotSupported
+// error.
+func (c *Client) WatchEvents(ctx context.Context, since time.Time, handle func(contracts.HypervisorEvent)) error {
+	req := &providerv1.WatchEventsRequest{}
+	if !since.IsZero() {
+		req.SinceUnix = since.Unix()
+	}
+	stream, err := c.client.WatchEvents(ctx, req)
+	if err != nil {
+		return c.mapWatchEventsError(ctx, err)
+	}
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return c.mapWatchEventsError(ctx, err)
+		}
+		handle(contracts.HypervisorEvent{
+			VMID:     ev.VmId,
+			Severity: eventSeverityFromProto(ev.Severity),
+			Reason:   ev.Reason,
+			Message:  ev.Message,
+			Time:     time.Unix(ev.TimeUnix, 0),
+			Resolved: ev.Resolved,
+		})
+	}
+}
+
+func (c *Client) mapWatchEventsError(ctx context.Context, err error) error {
+	if ctx.Err() != nil || status.Code(err) == codes.Canceled {
+		return nil
+	}
+	if st := status.Convert(err); st.Code() == codes.Unimplemented {
+		return contracts.NewNotSupportedError("watchEvents: " + st.Message())
+	}
+	return c.mapGRPCError("watchEvents", err)
+}
+
+// eventSeverityFromProto maps an unset severity to Info, so an event from a
+// provider that does not rank them never raises an alarm.
+func eventSeverityFromProto(s providerv1.EventSeverity) contracts.EventSeverity {
+	switch s {
+	case providerv1.EventSeverity_EVENT_SEVERITY_WARNING:
+		return contracts.EventSeverityWarning
+	case providerv1.EventSeverity_EVENT_SEVERITY_ERROR:
+		return contracts.EventSeverityError
+	default:
+		return contracts.EventSeverityInfo
+	}
+}
+
+// GetCapacity implements contracts.CapacityReporter. Providers that cannot
+// report hypervisor capacity answer Unimplemented, which surfaces as a
+// NotSupported error.
+func (c *Client) GetCapacity(ctx context.Context) ([]contracts.HostCapacity, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.GetCapacity(ctx, &providerv1.GetCapacityRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, contracts.NewNotSupportedError("getCapacity: provider does not report capacity")
+		}
+		return nil, c.mapGRPCError("getCapacity", err)
+	}
+
+	hosts := make([]contracts.HostCapacity, 0, len(resp.Hosts))
+	for _, h := range resp.Hosts {
+		hosts = append(hosts, contracts.HostCapacity{
+			Name:              h.Name,
+			CPUCores:          h.CpuCores,
+			CPUUtilization:    h.CpuUtilization,
+			MemoryTotalBytes:  h.MemoryTotalBytes,
+			MemoryUsedBytes:   h.MemoryUsedBytes,
+			StorageTotalBytes: h.StorageTotalBytes,
+			StorageUsedBytes:  h.StorageUsedBytes,
+		})
+	}
+	return hosts, nil
+}
+
+// GetInfo implements contracts.InfoReporter. Providers that cannot report
+// their build or hypervisor version answer Unimplemented, which surfaces as
+// a NotSupported error.
+func (c *Client) GetInfo(ctx context.Context) (contracts.ProviderInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.GetInfo(ctx, &providerv1.GetInfoRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return contracts.ProviderInfo{}, contracts.NewNotSupportedError("getInfo: provider does not report version information")
+		}
+		return contracts.ProviderInfo{}, c.mapGRPCError("getInfo", err)
+	}
+
+	return contracts.ProviderInfo{
+		ProviderVersion:   resp.ProviderVersion,
+		GitSHA:            resp.GitSha,
+		HypervisorProduct: resp.HypervisorProduct,
+		HypervisorVersion: resp.HypervisorVersion,
+		HypervisorDetails: resp.HypervisorDetails,
+	}, nil
+}
+
+// SnapshotList returns the snapshots that exist on the hypervisor for a VM.
+func (c *Client) SnapshotList(ctx context.Context, vmID string) ([]contracts.SnapshotInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.rpcTimeouts().Read)
+	defer cancel()
+
+	resp, err := c.client.SnapshotList(ctx, &providerv1.SnapshotListRequest{VmId: vmID})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, contracts.NewNotSupportedError("snapshotList: provider does not list snapshots")
+		}
+		return nil, c.mapGRPCError("snapshotList", err)
+	}
+
+	snapshots := make([]contracts.SnapshotInfo, 0, len(resp.Snapshots))
+	for _, s := range resp.Snapshots {
+		info := contracts.SnapshotInfo{
+			ID:            s.Id,
+			Name:          s.Name,
+			Description:   s.Description,
+			HasMemory:     s.HasMemory,
+			ParentID:      s.ParentId,
+			SizeBytes:     s.SizeBytes,
+			ConsumedBytes: s.ConsumedBytes,
+		}
+		if s.CreatedUnix > 0 {
+			info.CreatedAt = time.Unix(s.CreatedUnix, 0).UTC()
+		}
+		snapshots = append(snapshots, info)
+	}
+	return snapshots, nil
+}
+
 // convertCreateRequest converts contracts.CreateRequest to gRPC format
 func (c *Client) convertCreateRequest(req contracts.CreateRequest) (*providerv1.CreateRequest, error) {
 	grpcReq := &providerv1.CreateRequest{
-		Name: req.Name,
-		Tags: req.Tags,
+		Name:           req.Name,
+		Tags:           req.Tags,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
 	// Convert UserData
 	if req.UserData != nil {
 		grpcReq.UserData = []byte(req.UserData.CloudInitData)
+		grpcReq.NetworkConfig = []byte(req.UserData.NetworkConfig)
+		grpcReq.VendorData = []byte(req.UserData.VendorData)
 	}
 
 	// Convert MetaData (independent of UserData; a VM may supply metadata
@@ -1005,19 +1723,19 @@ func (c *Client) convertCreateRequest(req contracts.CreateRequest) (*providerv1.
 	}
 
 	// Convert each component to JSON
-	if classData, err := json.Marshal(req.Class); err == nil {
+	if classData, err := contracts.MarshalPayload(req.Class); err == nil {
 		grpcReq.ClassJson = string(classData)
 	}
 
-	if imageData, err := json.Marshal(req.Image); err == nil {
+	if imageData, err := contracts.MarshalPayload(req.Image); err == nil {
 		grpcReq.ImageJson = string(imageData)
 	}
 
-	if networksData, err := json.Marshal(req.Networks); err == nil {
+	if networksData, err := contracts.MarshalPayload(req.Networks); err == nil {
 		grpcReq.NetworksJson = string(networksData)
 	}
 
-	if disksData, err := json.Marshal(req.Disks); err == nil {
+	if disksData, err := contracts.MarshalPayload(req.Disks); err == nil {
 		grpcReq.DisksJson = string(disksData)
 		if len(req.Disks) > 0 {
 			fmt.Printf("gRPC Client: DisksJson being sent to provider: disk_count=%d disks_json=%s\n",
@@ -1026,11 +1744,19 @@ func (c *Client) convertCreateRequest(req contracts.CreateRequest) (*providerv1.
 	}
 
 	if req.Placement != nil {
-		if placementData, err := json.Marshal(req.Placement); err == nil {
+		if placementData, err := contracts.MarshalPayload(req.Placement); err == nil {
 			grpcReq.PlacementJson = string(placementData)
 		}
 	}
 
+	if req.GuestCustomization != nil {
+		customizationData, err := contracts.MarshalPayload(req.GuestCustomization)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal guest customization: %w", err)
+		}
+		grpcReq.GuestCustomizationJson = string(customizationData)
+	}
+
 	return grpcReq, nil
 }
 
@@ -1045,6 +1771,8 @@ func (c *Client) convertPowerOp(op contracts.PowerOp) (providerv1.PowerOp, error
 		return providerv1.PowerOp_POWER_OP_REBOOT, nil
 	case contracts.PowerOpShutdownGraceful:
 		return providerv1.PowerOp_POWER_OP_SHUTDOWN_GRACEFUL, nil
+	case contracts.PowerOpReset:
+		return providerv1.PowerOp_POWER_OP_RESET, nil
 	default:
 		return providerv1.PowerOp_POWER_OP_UNSPECIFIED, fmt.Errorf("unsupported power operation: %s", op)
 	}
@@ -1064,6 +1792,18 @@ func (c *Client) mapGRPCError(operation string, err error) error {
 		return contracts.NewInvalidSpecError(fmt.Sprintf("%s: %s", operation, st.Message()), err)
 	case codes.Unavailable, codes.DeadlineExceeded:
 		return contracts.NewRetryableError(fmt.Sprintf("%s: %s", operation, st.Message()), err)
+	case codes.Aborted:
+		return contracts.NewConflictError(fmt.Sprintf("%s: %s", operation, st.Message()), err)
+	case codes.FailedPrecondition:
+		return contracts.NewFailedPreconditionError(fmt.Sprintf("%s: %s", operation, st.Message()), err)
+	case codes.ResourceExhausted:
+		// Only a ResourceExhausted that says when to come back is a busy
+		// signal; without a delay it is a capacity failure, such as a full
+		// datastore, and stays a plain error.
+		if delay := sdkerrors.RetryDelay(st); delay > 0 {
+			return contracts.NewBusyError(fmt.Sprintf("%s: %s", operation, st.Message()), delay, err)
+		}
+		return fmt.Errorf("%s failed: %s", operation, st.Message())
 	default:
 		return fmt.Errorf("%s failed: %s", operation, st.Message())
 	}
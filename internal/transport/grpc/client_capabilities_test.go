@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 )
 
@@ -51,3 +52,34 @@ func TestClient_GetCapabilities_StorageBackendsSurface(t *testing.T) {
 	assert.Equal(t, []string{"pvc"}, caps.SupportedImportBackends)
 	assert.Equal(t, []string{"relay"}, caps.SupportedTransferModes)
 }
+
+// TestClient_GetCapabilities_ProtocolVersions verifies the protocol versions
+// a provider reports reach contracts.Capabilities, where the manager
+// negotiates with them.
+func TestClient_GetCapabilities_ProtocolVersions(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &fakeProviderServer{
+		GetCapabilitiesFn: func(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
+			return &providerv1.GetCapabilitiesResponse{
+				MinProtocolVersion: providerv1.ProtocolVersion_PROTOCOL_VERSION_1,
+				MaxProtocolVersion: providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT,
+			}, nil
+		},
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-protocol")
+
+	caps, err := cli.GetCapabilities(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, contracts.ProtocolVersion1, caps.MinProtocolVersion)
+	assert.Equal(t, contracts.ProtocolVersionCurrent, caps.MaxProtocolVersion)
+}
+
+// TestProtocolVersionsMatchProto keeps the contracts protocol versions in
+// step with the provider.v1 ProtocolVersion enum the SDK stamps.
+func TestProtocolVersionsMatchProto(t *testing.T) {
+	assert.Equal(t, int32(providerv1.ProtocolVersion_PROTOCOL_VERSION_1), contracts.ProtocolVersion1)
+	assert.Equal(t, int32(providerv1.ProtocolVersion_PROTOCOL_VERSION_2), contracts.ProtocolVersion2)
+	assert.Equal(t, int32(providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT), contracts.ProtocolVersionCurrent,
+		"bump contracts.ProtocolVersionCurrent, and the features it brings, with the proto")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// capacityFakeServer answers GetCapacity with resp, or Unimplemented when
+// resp is nil.
+type capacityFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	resp *providerv1.GetCapacityResponse
+}
+
+func (s *capacityFakeServer) GetCapacity(ctx context.Context, req *providerv1.GetCapacityRequest) (*providerv1.GetCapacityResponse, error) {
+	if s.resp == nil {
+		return s.UnimplementedProviderServer.GetCapacity(ctx, req)
+	}
+	return s.resp, nil
+}
+
+func TestClient_GetCapacity(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &capacityFakeServer{resp: &providerv1.GetCapacityResponse{
+		Hosts: []*providerv1.HostCapacity{{
+			Name:              "pve",
+			CpuCores:          16,
+			CpuUtilization:    0.25,
+			MemoryTotalBytes:  64 << 30,
+			MemoryUsedBytes:   16 << 30,
+			StorageTotalBytes: 1 << 40,
+			StorageUsedBytes:  1 << 38,
+		}},
+	}})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-capacity")
+
+	hosts, err := cli.GetCapacity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []contracts.HostCapacity{{
+		Name:              "pve",
+		CPUCores:          16,
+		CPUUtilization:    0.25,
+		MemoryTotalBytes:  64 << 30,
+		MemoryUsedBytes:   16 << 30,
+		StorageTotalBytes: 1 << 40,
+		StorageUsedBytes:  1 << 38,
+	}}, hosts)
+}
+
+func TestClient_GetCapacity_Unimplemented(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &capacityFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-capacity")
+
+	_, err := cli.GetCapacity(context.Background())
+	var perr *contracts.ProviderError
+	require.True(t, stderrors.As(err, &perr), "got %v", err)
+	assert.Equal(t, contracts.ErrorTypeNotSupported, perr.Type)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	sdkerrors "github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// cloudInitFakeServer is a minimal ProviderServer whose GetCloudInitStatus is
+// configurable. A nil fn falls through to the embedded Unimplemented server.
+type cloudInitFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	fn func(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error)
+}
+
+func (s *cloudInitFakeServer) GetCloudInitStatus(ctx context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+	if s.fn == nil {
+		return s.UnimplementedProviderServer.GetCloudInitStatus(ctx, req)
+	}
+	return s.fn(ctx, req)
+}
+
+func TestClient_GetCloudInitStatus(t *testing.T) {
+	var gotID string
+	dialer, cleanup := startBufconnServer(t, &cloudInitFakeServer{
+		fn: func(_ context.Context, req *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+			gotID = req.GetId()
+			return &providerv1.GetCloudInitStatusResponse{
+				Status: "error",
+				Errors: []string{"('scripts_user', RuntimeError('Runparts: 1 failures'))"},
+				Detail: "DataSourceNoCloud [seed=/dev/sr0]",
+			}, nil
+		},
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-cloudinit")
+
+	st, err := cli.GetCloudInitStatus(context.Background(), "vm-1")
+	require.NoError(t, err)
+	assert.Equal(t, "vm-1", gotID)
+	assert.Equal(t, contracts.CloudInitError, st.Status)
+	assert.Equal(t, []string{"('scripts_user', RuntimeError('Runparts: 1 failures'))"}, st.Errors)
+	assert.Equal(t, "DataSourceNoCloud [seed=/dev/sr0]", st.Detail)
+}
+
+// TestClient_GetCloudInitStatus_NotSupported verifies that both a provider
+// without the RPC and a guest the provider cannot read surface as
+// NotSupported, keeping the provider's explanation.
+func TestClient_GetCloudInitStatus_NotSupported(t *testing.T) {
+	for name, srv := range map[string]*cloudInitFakeServer{
+		"unimplemented": {},
+		"guest": {fn: func(context.Context, *providerv1.GetCloudInitStatusRequest) (*providerv1.GetCloudInitStatusResponse, error) {
+			return nil, sdkerrors.NewNotSupported("VM 101 has the QEMU guest agent disabled")
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dialer, cleanup := startBufconnServer(t, srv)
+			defer cleanup()
+			cli := newTestClient(t, dialer, "test-cloudinit")
+
+			_, err := cli.GetCloudInitStatus(context.Background(), "vm-1")
+			var perr *contracts.ProviderError
+			require.True(t, stderrors.As(err, &perr), "got %v", err)
+			assert.Equal(t, contracts.ErrorTypeNotSupported, perr.Type)
+			if srv.fn != nil {
+				assert.Contains(t, err.Error(), "guest agent disabled")
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// consoleProxyFakeServer issues a fixed ticket and relays the console of VM
+// "web-1" as an echo. Without a ticket it falls through to the embedded
+// Unimplemented server.
+type consoleProxyFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	ticket *providerv1.ConsoleTicketResponse
+}
+
+func (s *consoleProxyFakeServer) ConsoleTicket(ctx context.Context, req *providerv1.ConsoleTicketRequest) (*providerv1.ConsoleTicketResponse, error) {
+	if s.ticket == nil {
+		return s.UnimplementedProviderServer.ConsoleTicket(ctx, req)
+	}
+	return s.ticket, nil
+}
+
+func (s *consoleProxyFakeServer) ConsoleRelay(stream providerv1.Provider_ConsoleRelayServer) error {
+	if s.ticket == nil {
+		return s.UnimplementedProviderServer.ConsoleRelay(stream)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&providerv1.ConsoleRelayResponse{Data: []byte("console of " + first.GetId() + "\n")}); err != nil {
+		return err
+	}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&providerv1.ConsoleRelayResponse{Data: req.GetData()}); err != nil {
+			return err
+		}
+	}
+}
+
+func TestClient_ConsoleTicket(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &consoleProxyFakeServer{ticket: &providerv1.ConsoleTicketResponse{
+		Protocol:               contracts.ConsoleProtocolVNC,
+		Url:                    "wss://pve1:8006/api2/json/nodes/pve1/qemu/100/vncwebsocket?port=5900",
+		Headers:                map[string]string{"Authorization": "PVEAPIToken=root@pam!virtrigaud=secret"},
+		Password:               "PVEVNC:ticket",
+		CertificateFingerprint: "AB:CD",
+	}})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-console")
+
+	ticket, err := cli.ConsoleTicket(context.Background(), "pve1/100")
+	require.NoError(t, err)
+	assert.Equal(t, contracts.ConsoleTicket{
+		Protocol:               contracts.ConsoleProtocolVNC,
+		URL:                    "wss://pve1:8006/api2/json/nodes/pve1/qemu/100/vncwebsocket?port=5900",
+		Headers:                map[string]string{"Authorization": "PVEAPIToken=root@pam!virtrigaud=secret"},
+		Password:               "PVEVNC:ticket",
+		CertificateFingerprint: "AB:CD",
+	}, ticket)
+}
+
+func TestClient_ConsoleRelay(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &consoleProxyFakeServer{ticket: &providerv1.ConsoleTicketResponse{}})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-console-relay")
+
+	conn, err := cli.ConsoleRelay(context.Background(), "web-1")
+	require.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	greeting := make([]byte, len("console of web-1\n"))
+	_, err = io.ReadFull(conn, greeting)
+	require.NoError(t, err)
+	assert.Equal(t, "console of web-1\n", string(greeting))
+
+	payload := bytes.Repeat([]byte("RFB"), 1000)
+	_, err = conn.Write(payload)
+	require.NoError(t, err)
+	echoed := make([]byte, len(payload))
+	_, err = io.ReadFull(conn, echoed)
+	require.NoError(t, err)
+	assert.Equal(t, payload, echoed)
+}
+
+func TestClient_Console_NotSupported(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &consoleProxyFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-console-unimplemented")
+
+	_, err := cli.ConsoleTicket(context.Background(), "web-1")
+	require.Error(t, err)
+	assert.True(t, contracts.IsNotSupported(err), "got %v", err)
+
+	conn, err := cli.ConsoleRelay(context.Background(), "web-1")
+	require.NoError(t, err, "the stream opens; the provider's answer arrives with the first response")
+	defer conn.Close() //nolint:errcheck
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.True(t, contracts.IsNotSupported(err), "got %v", err)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// consoleFakeServer is a minimal ProviderServer whose GetConsoleOutput is
+// configurable. A nil fn falls through to the embedded Unimplemented server.
+type consoleFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	fn func(ctx context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error)
+}
+
+func (s *consoleFakeServer) GetConsoleOutput(ctx context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error) {
+	if s.fn == nil {
+		return s.UnimplementedProviderServer.GetConsoleOutput(ctx, req)
+	}
+	return s.fn(ctx, req)
+}
+
+// TestClient_GetConsoleOutput verifies the request fields are forwarded and the
+// response is surfaced on the contract type.
+func TestClient_GetConsoleOutput(t *testing.T) {
+	var got *providerv1.GetConsoleOutputRequest
+	dialer, cleanup := startBufconnServer(t, &consoleFakeServer{
+		fn: func(_ context.Context, req *providerv1.GetConsoleOutputRequest) (*providerv1.GetConsoleOutputResponse, error) {
+			got = req
+			return &providerv1.GetConsoleOutputResponse{
+				Output:    "login:\n",
+				Truncated: true,
+				Source:    "/var/log/libvirt/qemu/vm-1-serial0.log",
+			}, nil
+		},
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-console")
+
+	out, err := cli.GetConsoleOutput(context.Background(), "vm-1", 50)
+	require.NoError(t, err)
+	assert.Equal(t, "login:\n", out.Output)
+	assert.True(t, out.Truncated)
+	assert.Equal(t, "/var/log/libvirt/qemu/vm-1-serial0.log", out.Source)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "vm-1", got.GetId())
+	assert.EqualValues(t, 50, got.GetTailLines())
+}
+
+// TestClient_GetConsoleOutput_Unimplemented verifies a provider without console
+// support (e.g. vSphere) surfaces as a NotSupported contract error.
+func TestClient_GetConsoleOutput_Unimplemented(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &consoleFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-console")
+
+	_, err := cli.GetConsoleOutput(context.Background(), "vm-1", 0)
+	require.Error(t, err)
+	var perr *contracts.ProviderError
+	require.True(t, stderrors.As(err, &perr))
+	assert.Equal(t, contracts.ErrorTypeNotSupported, perr.Type)
+}
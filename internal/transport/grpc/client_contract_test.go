@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+)
+
+// TestConvertCreateRequest_ContractPayloads verifies the *_json fields of
+// a CreateRequest decode on the provider side into exactly what the
+// manager sent, and that object payloads carry the contract version.
+func TestConvertCreateRequest_ContractPayloads(t *testing.T) {
+	slot := int32(192)
+	want := contracts.CreateRequest{
+		Name: "web-1",
+		Class: contracts.VMClass{
+			CPU:             4,
+			MemoryMiB:       8192,
+			Firmware:        "UEFI",
+			ExtraConfig:     map[string]string{"disk.EnableUUID": "TRUE"},
+			SecurityProfile: &contracts.SecurityProfile{SecureBoot: true, TPMEnabled: true},
+		},
+		Image:    contracts.VMImage{TemplateName: "ubuntu-24.04"},
+		Networks: []contracts.NetworkAttachment{{Name: "lan", NetworkName: "VM Network", PCISlotNumber: &slot}},
+		Disks:    []contracts.DiskSpec{{Name: "data", SizeGiB: 50, Type: "thin"}},
+		Placement: &contracts.Placement{
+			Cluster: "prod",
+			HA:      &contracts.HAPlacement{Group: "g1", State: "started"},
+		},
+		GuestCustomization: &contracts.GuestCustomization{
+			Type:    contracts.GuestCustomizationSysprep,
+			Sysprep: &contracts.SysprepCustomization{UnattendXML: "<unattend/>"},
+		},
+	}
+
+	req, err := (&Client{}).convertCreateRequest(want)
+	require.NoError(t, err)
+
+	for name, payload := range map[string]string{
+		"class":              req.ClassJson,
+		"image":              req.ImageJson,
+		"placement":          req.PlacementJson,
+		"guestCustomization": req.GuestCustomizationJson,
+	} {
+		assert.Contains(t, payload, `"contractVersion":"v1"`, name)
+	}
+
+	var got contracts.CreateRequest
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.ClassJson), &got.Class))
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.ImageJson), &got.Image))
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.NetworksJson), &got.Networks))
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.DisksJson), &got.Disks))
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.PlacementJson), &got.Placement))
+	require.NoError(t, contracts.UnmarshalPayload([]byte(req.GuestCustomizationJson), &got.GuestCustomization))
+
+	assert.Equal(t, want.Class, got.Class)
+	assert.Equal(t, want.Image, got.Image)
+	assert.Equal(t, want.Networks, got.Networks)
+	assert.Equal(t, want.Disks, got.Disks)
+	assert.Equal(t, want.Placement, got.Placement)
+	assert.Equal(t, want.GuestCustomization, got.GuestCustomization)
+}
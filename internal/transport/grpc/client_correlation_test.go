@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/projectbeskar/virtrigaud/internal/obs/logging"
+)
+
+// TestCorrelationIDInterceptor pins that the correlation ID reaches the
+// provider as metadata, and that no key is sent when there is none.
+// (controller-runtime offers no public way to seed a reconcile ID, so the
+// fallback is not exercised here.)
+func TestCorrelationIDInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"explicit correlation ID", logging.WithCorrelationID(context.Background(), "vmclone-default/web"), []string{"vmclone-default/web"}},
+		{"no ID outside a reconcile", context.Background(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get(correlationIDMetadataKey)
+				return nil
+			}
+			err := correlationIDInterceptor()(tt.ctx, "/provider.v1.Provider/Describe", nil, nil, nil, invoker)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
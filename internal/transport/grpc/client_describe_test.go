@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+func TestDescribeFromProto_TypedDetails(t *testing.T) {
+	raw, err := (&describe.Details{
+		Host:      "pve2",
+		Resources: &describe.ResourceStats{CPUCount: 2, CPUUsagePercent: ptr.To(40.0)},
+		Extras:    map[string]string{"lock": "backup"},
+	}).Marshal()
+	require.NoError(t, err)
+
+	got := describeFromProto(&providerv1.DescribeResponse{Exists: true, ProviderRawJson: raw})
+	require.NotNil(t, got.Details)
+	assert.Equal(t, "pve2", got.Details.Host)
+	assert.Equal(t, map[string]string{
+		"schema":                    describe.Schema,
+		"host":                      "pve2",
+		"resources.cpuCount":        "2",
+		"resources.cpuUsagePercent": "40",
+		"lock":                      "backup",
+	}, got.ProviderRaw)
+	// A provider that reports usage only in its details still has it
+	// surfaced as guest stats.
+	require.NotNil(t, got.GuestStats)
+	assert.Equal(t, ptr.To(40.0), got.GuestStats.CPUUsagePercent)
+}
+
+func TestDescribeFromProto_LegacyAndBrokenDetails(t *testing.T) {
+	legacy := describeFromProto(&providerv1.DescribeResponse{ProviderRawJson: `{"node":"pve","cpus":2}`})
+	assert.Nil(t, legacy.Details)
+	assert.Equal(t, map[string]string{"node": "pve", "cpus": "2"}, legacy.ProviderRaw)
+
+	newer := describeFromProto(&providerv1.DescribeResponse{ProviderRawJson: `{"schema":"virtrigaud.io/describe/v2"}`})
+	assert.Nil(t, newer.Details)
+	assert.Contains(t, newer.ProviderRaw["parseError"], "this release reads")
+
+	assert.Nil(t, describeFromProto(&providerv1.DescribeResponse{}).ProviderRaw)
+}
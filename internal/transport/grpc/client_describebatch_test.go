@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// describeBatchFakeServer counts Describe and DescribeBatch calls. With
+// batch false it leaves DescribeBatch unimplemented, like a provider built
+// before the RPC existed.
+type describeBatchFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	batch bool
+
+	mu                  sync.Mutex
+	describes, batches  int
+	largestBatchRequest int
+}
+
+func (s *describeBatchFakeServer) Describe(_ context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	s.mu.Lock()
+	s.describes++
+	s.mu.Unlock()
+	return &providerv1.DescribeResponse{Exists: req.Id != "gone", PowerState: "On"}, nil
+}
+
+func (s *describeBatchFakeServer) DescribeBatch(ctx context.Context, req *providerv1.DescribeBatchRequest) (*providerv1.DescribeBatchResponse, error) {
+	if !s.batch {
+		return s.UnimplementedProviderServer.DescribeBatch(ctx, req)
+	}
+	s.mu.Lock()
+	s.batches++
+	s.largestBatchRequest = max(s.largestBatchRequest, len(req.Ids))
+	s.mu.Unlock()
+	resp := &providerv1.DescribeBatchResponse{
+		Results: map[string]*providerv1.DescribeResponse{},
+		Errors:  map[string]string{},
+	}
+	for _, id := range req.Ids {
+		switch id {
+		case "broken":
+			resp.Errors[id] = "guest agent timed out"
+		case "forgotten":
+		default:
+			resp.Results[id] = &providerv1.DescribeResponse{Exists: id != "gone", PowerState: "On", ProviderRawJson: `{"node":"pve"}`}
+		}
+	}
+	return resp, nil
+}
+
+func vmIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("vm-%d", i)
+	}
+	return ids
+}
+
+func TestClient_DescribeBatch(t *testing.T) {
+	srv := &describeBatchFakeServer{batch: true}
+	dialer, cleanup := startBufconnServer(t, srv)
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-describe-batch")
+
+	got, err := cli.DescribeBatch(context.Background(), []string{"vm-1", "gone", "broken", "forgotten"})
+	require.NoError(t, err)
+
+	require.Contains(t, got.Results, "vm-1")
+	assert.True(t, got.Results["vm-1"].Exists)
+	assert.Equal(t, map[string]string{"node": "pve"}, got.Results["vm-1"].ProviderRaw)
+	require.Contains(t, got.Results, "gone")
+	assert.False(t, got.Results["gone"].Exists)
+	assert.ErrorContains(t, got.Errors["broken"], "guest agent timed out")
+	assert.Error(t, got.Errors["forgotten"], "an id the provider left out is an error, not a silent miss")
+	assert.Zero(t, srv.describes)
+}
+
+// The RPC count for a large fleet is one DescribeBatch per
+// describeBatchSize VMs instead of one Describe per VM.
+func TestClient_DescribeBatch_Chunks(t *testing.T) {
+	srv := &describeBatchFakeServer{batch: true}
+	dialer, cleanup := startBufconnServer(t, srv)
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-describe-batch")
+
+	ids := vmIDs(2000)
+	got, err := cli.DescribeBatch(context.Background(), ids)
+	require.NoError(t, err)
+	assert.Len(t, got.Results, len(ids))
+	assert.Equal(t, 2000/describeBatchSize, srv.batches)
+	assert.Equal(t, describeBatchSize, srv.largestBatchRequest)
+	assert.Zero(t, srv.describes)
+}
+
+func TestClient_DescribeBatch_FallsBackToDescribe(t *testing.T) {
+	srv := &describeBatchFakeServer{}
+	dialer, cleanup := startBufconnServer(t, srv)
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-describe-batch")
+
+	got, err := cli.DescribeBatch(context.Background(), []string{"vm-1", "gone"})
+	require.NoError(t, err)
+	assert.True(t, got.Results["vm-1"].Exists)
+	assert.False(t, got.Results["gone"].Exists)
+	assert.Equal(t, 2, srv.describes)
+	assert.False(t, cli.describeBatchSupported(), "the Unimplemented answer is remembered")
+
+	// Once the provider is upgraded and the retry window passed, the batch
+	// RPC is used again.
+	srv.batch = true
+	cli.describeBatchUnsupportedUntil.Store(0)
+	_, err = cli.DescribeBatch(context.Background(), []string{"vm-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.batches)
+	assert.Equal(t, 2, srv.describes)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/mock"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// TestClient_DualStackAddresses creates a VM with static IPv4 and IPv6
+// addresses through the real client and the mock provider, powers it on
+// and checks both families come back on the right NICs.
+func TestClient_DualStackAddresses(t *testing.T) {
+	c := newTestClientForVMOps(t, mock.NewProvider(), "mock", "mock-dualstack")
+	ctx := context.Background()
+
+	resp, err := c.Create(ctx, contracts.CreateRequest{
+		Name:  "dual-01",
+		Class: contracts.VMClass{CPU: 1, MemoryMiB: 1024},
+		Networks: []contracts.NetworkAttachment{
+			{Name: "lan", StaticIP: "10.0.0.5", Prefix: 24, StaticIPv6: "2001:db8::5/64", GatewayIPv6: "2001:db8::1"},
+			{Name: "storage", StaticIPv6: "2001:db8:1::5/64"},
+		},
+	})
+	require.NoError(t, err)
+	_, err = c.Power(ctx, resp.ID, contracts.PowerOpOn)
+	require.NoError(t, err)
+
+	var desc contracts.DescribeResponse
+	require.Eventually(t, func() bool {
+		desc, err = c.Describe(ctx, resp.ID)
+		return err == nil && len(desc.IPs) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.ElementsMatch(t, []string{"10.0.0.5", "2001:db8::5", "2001:db8:1::5"}, desc.IPs,
+		"no DHCP address is added next to a static IPv4 one")
+	assert.ElementsMatch(t, []contracts.GuestAddress{
+		{IP: "10.0.0.5", Interface: "eth0"},
+		{IP: "2001:db8::5", Interface: "eth0"},
+		{IP: "2001:db8:1::5", Interface: "eth1"},
+	}, desc.Addresses)
+
+	// Providers build their describe layout from the same addresses.
+	var reported []*providerv1.GuestAddress
+	for _, a := range desc.Addresses {
+		reported = append(reported, &providerv1.GuestAddress{Ip: a.IP, Interface: a.Interface})
+	}
+	ifaces := describe.InterfacesFromAddresses(reported, nil)
+	assert.Equal(t, []describe.NetworkInterface{
+		{Name: "eth0", IPs: []string{"10.0.0.5", "2001:db8::5"}, Addresses: []describe.InterfaceAddress{
+			{IP: "10.0.0.5", Family: describe.FamilyIPv4}, {IP: "2001:db8::5", Family: describe.FamilyIPv6},
+		}},
+		{Name: "eth1", IPs: []string{"2001:db8:1::5"}, Addresses: []describe.InterfaceAddress{
+			{IP: "2001:db8:1::5", Family: describe.FamilyIPv6},
+		}},
+	}, ifaces)
+}
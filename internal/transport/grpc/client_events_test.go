@@ -0,0 +1,99 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// eventsFakeServer sends events, echoing the requested since as the first
+// event's time. With no events it falls through to the embedded
+// Unimplemented server; with block set it then waits for the call to end.
+type eventsFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	events []*providerv1.HypervisorEvent
+	block  bool
+}
+
+func (s *eventsFakeServer) WatchEvents(req *providerv1.WatchEventsRequest, stream providerv1.Provider_WatchEventsServer) error {
+	if s.events == nil {
+		return s.UnimplementedProviderServer.WatchEvents(req, stream)
+	}
+	for i, ev := range s.events {
+		if i == 0 {
+			ev.TimeUnix = req.SinceUnix
+		}
+		if err := stream.Send(ev); err != nil {
+			return err
+		}
+	}
+	if s.block {
+		<-stream.Context().Done()
+	}
+	return nil
+}
+
+func TestClient_WatchEvents(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &eventsFakeServer{events: []*providerv1.HypervisorEvent{
+		{VmId: "pve1/100", Severity: providerv1.EventSeverity_EVENT_SEVERITY_ERROR, Reason: "TaskFailed", Message: "qmstart: timeout"},
+		{VmId: "pve1/100", Reason: "TaskFailed", Resolved: true, TimeUnix: 1700000100},
+	}})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-events")
+
+	since := time.Unix(1700000000, 0)
+	var got []contracts.HypervisorEvent
+	err := cli.WatchEvents(context.Background(), since, func(ev contracts.HypervisorEvent) {
+		got = append(got, ev)
+	})
+	require.NoError(t, err, "the provider ending the stream is not an error")
+	assert.Equal(t, []contracts.HypervisorEvent{
+		{VMID: "pve1/100", Severity: contracts.EventSeverityError, Reason: "TaskFailed", Message: "qmstart: timeout", Time: since},
+		{VMID: "pve1/100", Severity: contracts.EventSeverityInfo, Reason: "TaskFailed", Resolved: true, Time: time.Unix(1700000100, 0)},
+	}, got)
+}
+
+func TestClient_WatchEvents_Cancel(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &eventsFakeServer{
+		events: []*providerv1.HypervisorEvent{{VmId: "web-1", Reason: "Started"}},
+		block:  true,
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-events-cancel")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := cli.WatchEvents(ctx, time.Time{}, func(contracts.HypervisorEvent) { cancel() })
+	assert.NoError(t, err)
+}
+
+func TestClient_WatchEvents_NotSupported(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &eventsFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-events-unimplemented")
+
+	err := cli.WatchEvents(context.Background(), time.Time{}, func(contracts.HypervisorEvent) {})
+	require.Error(t, err)
+	assert.True(t, contracts.IsNotSupported(err), "got %v", err)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	"github.com/projectbeskar/virtrigaud/internal/providers/mock"
+)
+
+// TestClient_SysprepPassThrough drives a Windows create through the real
+// client and the mock provider, which reports a digest of the answer file
+// it received.
+func TestClient_SysprepPassThrough(t *testing.T) {
+	c := newTestClientForVMOps(t, mock.NewProvider(), "mock", "mock-sysprep")
+	ctx := context.Background()
+
+	caps, err := c.GetCapabilities(ctx)
+	require.NoError(t, err)
+	assert.True(t, caps.SupportsSysprep)
+
+	unattend := `<?xml version="1.0" encoding="UTF-8"?>
+<unattend xmlns="urn:schemas-microsoft-com:unattend"><settings pass="specialize"/></unattend>`
+	resp, err := c.Create(ctx, contracts.CreateRequest{
+		Name:  "win-01",
+		Class: contracts.VMClass{CPU: 2, MemoryMiB: 4096},
+		GuestCustomization: &contracts.GuestCustomization{
+			Type:    contracts.GuestCustomizationSysprep,
+			Sysprep: &contracts.SysprepCustomization{UnattendXML: unattend},
+		},
+	})
+	require.NoError(t, err)
+
+	desc, err := c.Describe(ctx, resp.ID)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte(unattend))
+	assert.Equal(t, contracts.GuestCustomizationSysprep, desc.ProviderRaw["guestCustomization"])
+	assert.Equal(t, hex.EncodeToString(sum[:]), desc.ProviderRaw["unattendSha256"])
+}
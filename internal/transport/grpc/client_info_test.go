@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// infoFakeServer answers GetInfo with resp, or Unimplemented when resp is
+// nil.
+type infoFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	resp *providerv1.GetInfoResponse
+}
+
+func (s *infoFakeServer) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	if s.resp == nil {
+		return s.UnimplementedProviderServer.GetInfo(ctx, req)
+	}
+	return s.resp, nil
+}
+
+func TestClient_GetInfo(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &infoFakeServer{resp: &providerv1.GetInfoResponse{
+		ProviderVersion:   "v0.4.0",
+		GitSha:            "abc1234",
+		HypervisorProduct: "Proxmox VE",
+		HypervisorVersion: "8.2.4",
+		HypervisorDetails: "release 8.2, repoid faa83925c9641325",
+	}})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-info")
+
+	info, err := cli.GetInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, contracts.ProviderInfo{
+		ProviderVersion:   "v0.4.0",
+		GitSHA:            "abc1234",
+		HypervisorProduct: "Proxmox VE",
+		HypervisorVersion: "8.2.4",
+		HypervisorDetails: "release 8.2, repoid faa83925c9641325",
+	}, info)
+}
+
+func TestClient_GetInfo_Unimplemented(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &infoFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-info")
+
+	_, err := cli.GetInfo(context.Background())
+	var perr *contracts.ProviderError
+	require.True(t, stderrors.As(err, &perr), "got %v", err)
+	assert.Equal(t, contracts.ErrorTypeNotSupported, perr.Type)
+}
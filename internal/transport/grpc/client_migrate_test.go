@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// migrateFakeServer is a minimal ProviderServer whose MigrateHost is
+// configurable. A nil fn falls through to the embedded Unimplemented server.
+type migrateFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	fn func(ctx context.Context, req *providerv1.MigrateHostRequest) (*providerv1.TaskResponse, error)
+}
+
+func (s *migrateFakeServer) MigrateHost(ctx context.Context, req *providerv1.MigrateHostRequest) (*providerv1.TaskResponse, error) {
+	if s.fn == nil {
+		return s.UnimplementedProviderServer.MigrateHost(ctx, req)
+	}
+	return s.fn(ctx, req)
+}
+
+func TestClient_MigrateHost(t *testing.T) {
+	var got *providerv1.MigrateHostRequest
+	dialer, cleanup := startBufconnServer(t, &migrateFakeServer{
+		fn: func(_ context.Context, req *providerv1.MigrateHostRequest) (*providerv1.TaskResponse, error) {
+			got = req
+			return &providerv1.TaskResponse{Task: &providerv1.TaskRef{Id: "migrate:web-1:1"}}, nil
+		},
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-migrate")
+
+	ref, err := cli.MigrateHost(context.Background(), "web-1", "kvm2")
+	require.NoError(t, err)
+	assert.Equal(t, "migrate:web-1:1", ref)
+	assert.Equal(t, "web-1", got.GetId())
+	assert.Equal(t, "kvm2", got.GetTargetHost())
+}
+
+func TestClient_MigrateHost_NotSupported(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &migrateFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-migrate-unimplemented")
+
+	_, err := cli.MigrateHost(context.Background(), "web-1", "kvm2")
+	require.Error(t, err)
+	assert.True(t, contracts.IsNotSupported(err), "got %v", err)
+}
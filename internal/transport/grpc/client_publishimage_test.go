@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// publishImageFakeServer answers PublishImage with fn, or falls through to
+// the embedded Unimplemented server when fn is nil.
+type publishImageFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	fn func(req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error)
+}
+
+func (s *publishImageFakeServer) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	if s.fn == nil {
+		return s.UnimplementedProviderServer.PublishImage(ctx, req)
+	}
+	return s.fn(req)
+}
+
+func TestClient_PublishImage(t *testing.T) {
+	var got *providerv1.PublishImageRequest
+	dialer, cleanup := startBufconnServer(t, &publishImageFakeServer{
+		fn: func(req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+			got = req
+			return &providerv1.PublishImageResponse{
+				Task:         &providerv1.TaskRef{Id: "task-7"},
+				ImageId:      "web-golden",
+				ImagePath:    "/pool/web-golden.qcow2",
+				Checksum:     "abc123",
+				ChecksumType: "sha256",
+			}, nil
+		},
+	})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-publish")
+
+	resp, err := cli.PublishImage(context.Background(), contracts.PublishImageRequest{
+		VMID:      "vm-1",
+		ImageName: "web-golden",
+		Options:   contracts.PublishImageOptions{Cleanup: []string{"machine-id"}},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, contracts.PublishImageResponse{
+		TaskRef:      "task-7",
+		ImageID:      "web-golden",
+		ImagePath:    "/pool/web-golden.qcow2",
+		Checksum:     "abc123",
+		ChecksumType: "sha256",
+	}, resp)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "vm-1", got.GetVmId())
+	assert.Equal(t, "web-golden", got.GetImageName())
+	assert.True(t, got.GetOverwrite())
+	var options contracts.PublishImageOptions
+	require.NoError(t, contracts.UnmarshalPayload([]byte(got.GetOptionsJson()), &options))
+	assert.Equal(t, []string{"machine-id"}, options.Cleanup)
+}
+
+func TestClient_PublishImage_Errors(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &publishImageFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-publish")
+
+	_, err := cli.PublishImage(context.Background(), contracts.PublishImageRequest{VMID: "vm-1", ImageName: "x"})
+	assert.True(t, contracts.IsNotSupported(err), "Unimplemented should map to NotSupported, got %v", err)
+
+	dialer, cleanup2 := startBufconnServer(t, &publishImageFakeServer{
+		fn: func(*providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+			return nil, status.Error(codes.AlreadyExists, `template "x" already exists`)
+		},
+	})
+	defer cleanup2()
+	cli = newTestClient(t, dialer, "test-publish")
+
+	_, err = cli.PublishImage(context.Background(), contracts.PublishImageRequest{VMID: "vm-1", ImageName: "x"})
+	require.Error(t, err)
+	assert.False(t, contracts.IsNotSupported(err))
+	assert.Contains(t, err.Error(), "already exists")
+}
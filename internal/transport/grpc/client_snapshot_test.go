@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// snapshotListFakeServer answers SnapshotList with resp, or Unimplemented
+// when resp is nil.
+type snapshotListFakeServer struct {
+	providerv1.UnimplementedProviderServer
+	resp    *providerv1.SnapshotListResponse
+	lastReq *providerv1.SnapshotListRequest
+}
+
+func (s *snapshotListFakeServer) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	s.lastReq = req
+	if s.resp == nil {
+		return s.UnimplementedProviderServer.SnapshotList(ctx, req)
+	}
+	return s.resp, nil
+}
+
+func TestClient_SnapshotList(t *testing.T) {
+	srv := &snapshotListFakeServer{resp: &providerv1.SnapshotListResponse{
+		Snapshots: []*providerv1.SnapshotInfo{
+			{Id: "base", Name: "base", CreatedUnix: 1700000000, Description: "before upgrade"},
+			{Id: "with-ram", Name: "with-ram", HasMemory: true, ParentId: "base", SizeBytes: ptr.To[int64](8 << 30), ConsumedBytes: ptr.To[int64](6 << 30)},
+		},
+	}}
+	dialer, cleanup := startBufconnServer(t, srv)
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-snapshot-list")
+
+	snapshots, err := cli.SnapshotList(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, "100", srv.lastReq.GetVmId())
+	assert.Equal(t, []contracts.SnapshotInfo{
+		{ID: "base", Name: "base", Description: "before upgrade", CreatedAt: time.Unix(1700000000, 0).UTC()},
+		{ID: "with-ram", Name: "with-ram", HasMemory: true, ParentID: "base", SizeBytes: ptr.To[int64](8 << 30), ConsumedBytes: ptr.To[int64](6 << 30)},
+	}, snapshots, "sizes the provider does not report stay nil")
+}
+
+func TestClient_SnapshotList_Unimplemented(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &snapshotListFakeServer{})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-snapshot-list")
+
+	_, err := cli.SnapshotList(context.Background(), "100")
+	var perr *contracts.ProviderError
+	require.True(t, stderrors.As(err, &perr), "got %v", err)
+	assert.Equal(t, contracts.ErrorTypeNotSupported, perr.Type)
+}
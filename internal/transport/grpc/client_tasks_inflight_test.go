@@ -295,3 +295,18 @@ func newClientWithTaskMetrics(providerType, provider string) *Client {
 		inflightTasks: make(map[string]struct{}),
 	}
 }
+
+// TestTaskLink covers the links kept for in-flight tasks: recorded when a
+// task-creating RPC returns one, gone once the task is done.
+func TestTaskLink(t *testing.T) {
+	c := &Client{}
+	c.trackTask(&providerv1.TaskRef{Id: "UPID:pve1:1", Link: "https://pve.example.com:8006/api2/html/nodes/pve1/tasks/UPID:pve1:1/log"})
+	c.trackTask(&providerv1.TaskRef{Id: "task-2"})
+
+	assert.Equal(t, "https://pve.example.com:8006/api2/html/nodes/pve1/tasks/UPID:pve1:1/log", c.TaskLink("UPID:pve1:1"))
+	assert.Empty(t, c.TaskLink("task-2"))
+	assert.Empty(t, c.TaskLink("unknown"))
+
+	c.trackTaskDone("UPID:pve1:1")
+	assert.Empty(t, c.TaskLink("UPID:pve1:1"))
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// slowDescribeServer answers Describe after delay, or when the caller
+// gives up.
+type slowDescribeServer struct {
+	providerv1.UnimplementedProviderServer
+	delay time.Duration
+}
+
+func (s *slowDescribeServer) Describe(ctx context.Context, _ *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	select {
+	case <-time.After(s.delay):
+		return &providerv1.DescribeResponse{Exists: true}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestClient_ConfiguredTimeouts(t *testing.T) {
+	dialer, cleanup := startBufconnServer(t, &slowDescribeServer{delay: 200 * time.Millisecond})
+	defer cleanup()
+	cli := newTestClient(t, dialer, "test-timeouts")
+
+	assert.Equal(t, DefaultRPCTimeouts(), cli.rpcTimeouts())
+	_, err := cli.Describe(context.Background(), "vm-1")
+	require.NoError(t, err)
+
+	// The deadline is read on every call, so a change applies to a
+	// client that is already connected.
+	timeouts := DefaultRPCTimeouts()
+	cli.SetTimeouts(func() RPCTimeouts { return timeouts })
+	timeouts.Read = 20 * time.Millisecond
+	start := time.Now()
+	_, err = cli.Describe(context.Background(), "vm-1")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,7 @@ import (
 	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
 	"github.com/projectbeskar/virtrigaud/internal/providers/contracts"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	sdkerrors "github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
 // fakeVMOpsServer implements enough of providerv1.ProviderServer to drive
@@ -42,12 +44,25 @@ import (
 type fakeVMOpsServer struct {
 	providerv1.UnimplementedProviderServer
 	fail bool
+
+	// conflict makes Reconfigure fail with Aborted, as for a VM config
+	// locked by another change.
+	conflict bool
+
+	// busy makes Create fail as a provider at its clone limit does.
+	busy bool
+
+	// lastReconfigure is the most recent Reconfigure request.
+	lastReconfigure *providerv1.ReconfigureRequest
 }
 
 func (f *fakeVMOpsServer) Create(ctx context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
 	if f.fail {
 		return nil, status.Error(codes.Unavailable, "induced create failure")
 	}
+	if f.busy {
+		return nil, sdkerrors.NewBusy(15*time.Second, "5 clones already in progress")
+	}
 	return &providerv1.CreateResponse{Id: "test-vm-id"}, nil
 }
 
@@ -69,14 +84,29 @@ func (f *fakeVMOpsServer) Describe(ctx context.Context, req *providerv1.Describe
 	if f.fail {
 		return nil, status.Error(codes.Unavailable, "induced describe failure")
 	}
-	return &providerv1.DescribeResponse{Exists: true, PowerState: "On"}, nil
+	cpu := 12.5
+	return &providerv1.DescribeResponse{
+		Exists:     true,
+		PowerState: "On",
+		GuestStats: &providerv1.GuestStats{CpuUsagePercent: &cpu},
+		Ips:        []string{"10.0.0.5", "10.0.0.6"},
+		Addresses:  []*providerv1.GuestAddress{{Ip: "10.0.0.5", Interface: "eth0"}},
+	}, nil
 }
 
-func (f *fakeVMOpsServer) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+func (f *fakeVMOpsServer) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	if f.fail {
 		return nil, status.Error(codes.Unavailable, "induced reconfigure failure")
 	}
-	return &providerv1.TaskResponse{}, nil
+	if f.conflict {
+		return nil, status.Error(codes.Aborted, "VM config is locked")
+	}
+	f.lastReconfigure = req
+	resp := &providerv1.ReconfigureResponse{}
+	if req.GetChanges().GetCpu() != nil {
+		resp.PowerCycleRequired = []string{contracts.ChangeCPU}
+	}
+	return resp, nil
 }
 
 // newTestClientForVMOps brings up an in-process gRPC server backed by
@@ -194,7 +224,7 @@ func TestClient_VMOperations_RecordOnSuccess(t *testing.T) {
 			return e
 		}},
 		{"Reconfigure", metrics.OpReconfigure, func() error {
-			_, e := cli.Reconfigure(ctx, "vm-1", contracts.CreateRequest{Name: "vm-1"})
+			_, e := cli.Reconfigure(ctx, "vm-1", contracts.CreateRequest{Name: "vm-1"}, contracts.ChangeSet{})
 			return e
 		}},
 	}
@@ -250,7 +280,7 @@ func TestClient_VMOperations_RecordOnError(t *testing.T) {
 			return e
 		}},
 		{"Reconfigure", metrics.OpReconfigure, func() error {
-			_, e := cli.Reconfigure(ctx, "vm-1", contracts.CreateRequest{Name: "vm-1"})
+			_, e := cli.Reconfigure(ctx, "vm-1", contracts.CreateRequest{Name: "vm-1"}, contracts.ChangeSet{})
 			return e
 		}},
 	}
@@ -271,3 +301,101 @@ func TestClient_VMOperations_RecordOnError(t *testing.T) {
 		})
 	}
 }
+
+// TestClient_Reconfigure_Conflict verifies an Aborted Reconfigure surfaces
+// as a contracts Conflict and counts as outcome="conflict", not "error".
+func TestClient_Reconfigure_Conflict(t *testing.T) {
+	const providerType, provider = "conflict", "conflict-provider"
+	cli := newTestClientForVMOps(t, &fakeVMOpsServer{conflict: true}, providerType, provider)
+	labels := func(outcome string) map[string]string {
+		return map[string]string{
+			"operation": metrics.OpReconfigure, "provider_type": providerType,
+			"provider": provider, "outcome": outcome,
+		}
+	}
+	errorsBefore := counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeError))
+	conflictsBefore := counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeConflict))
+
+	_, err := cli.Reconfigure(context.Background(), "vm-1", contracts.CreateRequest{Name: "vm-1"}, contracts.ChangeSet{})
+	require.Error(t, err)
+	assert.True(t, contracts.IsConflict(err), "Aborted must map to a Conflict, got %v", err)
+	assert.Equal(t, errorsBefore, counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeError)))
+	assert.Equal(t, conflictsBefore+1, counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeConflict)))
+}
+
+// TestClient_Create_Busy verifies a provider at its clone limit surfaces as
+// a contracts Busy error carrying the provider's retry delay, and counts as
+// outcome="busy", not "error".
+func TestClient_Create_Busy(t *testing.T) {
+	const providerType, provider = "busy", "busy-provider"
+	cli := newTestClientForVMOps(t, &fakeVMOpsServer{busy: true}, providerType, provider)
+	labels := func(outcome string) map[string]string {
+		return map[string]string{
+			"operation": metrics.OpCreate, "provider_type": providerType,
+			"provider": provider, "outcome": outcome,
+		}
+	}
+	errorsBefore := counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeError))
+	busyBefore := counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeBusy))
+
+	_, err := cli.Create(context.Background(), contracts.CreateRequest{Name: "vm-1"})
+	require.Error(t, err)
+	delay, busy := contracts.IsBusy(err)
+	assert.True(t, busy, "ResourceExhausted with RetryInfo must map to Busy, got %v", err)
+	assert.Equal(t, 15*time.Second, delay)
+	assert.Equal(t, errorsBefore, counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeError)))
+	assert.Equal(t, busyBefore+1, counterSampleByLabels(t, "virtrigaud_vm_operations_total", labels(metrics.OutcomeBusy)))
+}
+
+// TestClient_Reconfigure_SendsChangeSet verifies the ChangeSet reaches the
+// provider alongside the legacy desired JSON, and that the provider's
+// power-cycle report is returned.
+func TestClient_Reconfigure_SendsChangeSet(t *testing.T) {
+	srv := &fakeVMOpsServer{}
+	cli := newTestClientForVMOps(t, srv, "changeset", "changeset-provider")
+
+	result, err := cli.Reconfigure(context.Background(), "vm-1", contracts.CreateRequest{Name: "vm-1"}, contracts.ChangeSet{
+		CPU:             &contracts.Int32Change{Old: 2, New: 4},
+		Disks:           []contracts.DiskExpansion{{Name: "root", OldSizeGiB: 20, NewSizeGiB: 40}},
+		NetworksAdded:   []contracts.NetworkAttachment{{Name: "backend", Bridge: "vmbr1"}},
+		NetworksRemoved: []string{"legacy"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{contracts.ChangeCPU}, result.PowerCycleRequired)
+
+	req := srv.lastReconfigure
+	require.NotNil(t, req)
+	assert.Contains(t, req.DesiredJson, `"contractVersion":"v1","name":"vm-1"`)
+	changes := req.GetChanges()
+	assert.Equal(t, int32(4), changes.GetCpu().GetNew())
+	assert.Nil(t, changes.GetMemoryMib())
+	require.Len(t, changes.GetDisks(), 1)
+	assert.Equal(t, int32(40), changes.GetDisks()[0].GetNewSizeGib())
+	require.Len(t, changes.GetNetworksAdded(), 1)
+	assert.Contains(t, changes.GetNetworksAdded()[0].GetAttachmentJson(), `"bridge":"vmbr1"`)
+	assert.Equal(t, []string{"legacy"}, changes.GetNetworksRemoved())
+}
+
+// TestClient_Describe_GuestStats verifies guest stats are carried through,
+// with fields the provider left unset staying nil rather than zero.
+func TestClient_Describe_GuestStats(t *testing.T) {
+	cli := newTestClientForVMOps(t, &fakeVMOpsServer{}, "gueststats", "gueststats-provider")
+
+	resp, err := cli.Describe(context.Background(), "vm-1")
+	require.NoError(t, err)
+	require.NotNil(t, resp.GuestStats)
+	require.NotNil(t, resp.GuestStats.CPUUsagePercent)
+	assert.InDelta(t, 12.5, *resp.GuestStats.CPUUsagePercent, 1e-9)
+	assert.Nil(t, resp.GuestStats.MemoryUsageBytes)
+}
+
+// TestClient_Describe_Addresses verifies the per-interface addresses are
+// carried alongside the flat IP list.
+func TestClient_Describe_Addresses(t *testing.T) {
+	cli := newTestClientForVMOps(t, &fakeVMOpsServer{}, "addresses", "addresses-provider")
+
+	resp, err := cli.Describe(context.Background(), "vm-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, resp.IPs)
+	assert.Equal(t, []contracts.GuestAddress{{IP: "10.0.0.5", Interface: "eth0"}}, resp.Addresses)
+}
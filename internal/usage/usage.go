@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usage resolves how much a VirtualMachine asks its provider for.
+// The allocation gauges, vrtg's usage reports and VirtrigaudQuota
+// enforcement all size VMs here, so their figures agree.
+package usage
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+const (
+	BytesPerMiB = int64(1024 * 1024)
+	BytesPerGiB = 1024 * BytesPerMiB
+)
+
+// VMAllocation is the amount of resources a VirtualMachine asks its
+// provider for: the VMClass sizing with the VM's overrides applied.
+type VMAllocation struct {
+	CPU         int64
+	MemoryBytes int64
+	DiskBytes   int64
+}
+
+// ResolveVMAllocation computes a VM's allocation from its class. The disk
+// figure is the class default disk plus every additional disk in the spec.
+// A nil class contributes nothing.
+func ResolveVMAllocation(vm *infravirtrigaudiov1beta1.VirtualMachine, class *infravirtrigaudiov1beta1.VMClass) VMAllocation {
+	var alloc VMAllocation
+	if class != nil {
+		alloc.CPU = int64(class.Spec.CPU)
+		alloc.MemoryBytes = ClassMemoryMiB(&class.Spec) * BytesPerMiB
+		alloc.DiskBytes = ClassDiskSizeGiB(&class.Spec) * BytesPerGiB
+	}
+	if res := vm.Spec.Resources; res != nil {
+		if res.CPU != nil {
+			alloc.CPU = int64(*res.CPU)
+		}
+		if res.MemoryMiB != nil {
+			alloc.MemoryBytes = *res.MemoryMiB * BytesPerMiB
+		}
+	}
+	for _, disk := range vm.Spec.Disks {
+		alloc.DiskBytes += int64(disk.SizeGiB) * BytesPerGiB
+	}
+	return alloc
+}
+
+// ClassMemoryMiB converts spec.memory to whole MiB, rounding down.
+func ClassMemoryMiB(spec *infravirtrigaudiov1beta1.VMClassSpec) int64 {
+	return spec.Memory.Value() / BytesPerMiB
+}
+
+// ClassDiskSizeGiB converts spec.diskDefaults.size to whole GiB, rounding
+// up so a provider never allocates less than requested. Zero means unset.
+func ClassDiskSizeGiB(spec *infravirtrigaudiov1beta1.VMClassSpec) int64 {
+	if spec.DiskDefaults == nil {
+		return 0
+	}
+	return quantityCeil(spec.DiskDefaults.Size, BytesPerGiB)
+}
+
+func quantityCeil(q resource.Quantity, unit int64) int64 {
+	v := q.Value()
+	if v <= 0 {
+		return 0
+	}
+	return (v + unit - 1) / unit
+}
+
+// CeilGiB converts bytes to whole GiB, rounding up.
+func CeilGiB(bytes int64) int64 {
+	if bytes <= 0 {
+		return 0
+	}
+	return (bytes + BytesPerGiB - 1) / BytesPerGiB
+}
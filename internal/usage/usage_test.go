@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func TestResolveVMAllocation(t *testing.T) {
+	class := &infravirtrigaudiov1beta1.VMClass{Spec: infravirtrigaudiov1beta1.VMClassSpec{
+		CPU:          2,
+		Memory:       resource.MustParse("4Gi"),
+		DiskDefaults: &infravirtrigaudiov1beta1.DiskDefaults{Size: resource.MustParse("40Gi")},
+	}}
+
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	assert.Equal(t, VMAllocation{CPU: 2, MemoryBytes: 4 << 30, DiskBytes: 40 << 30}, ResolveVMAllocation(vm, class))
+
+	cpu, mem := int32(8), int64(16384)
+	vm.Spec.Resources = &infravirtrigaudiov1beta1.VirtualMachineResources{CPU: &cpu, MemoryMiB: &mem}
+	vm.Spec.Disks = []infravirtrigaudiov1beta1.DiskSpec{{Name: "data", SizeGiB: 100}}
+	assert.Equal(t, VMAllocation{CPU: 8, MemoryBytes: 16 << 30, DiskBytes: 140 << 30}, ResolveVMAllocation(vm, class))
+
+	assert.Equal(t, VMAllocation{CPU: 8, MemoryBytes: 16 << 30, DiskBytes: 100 << 30}, ResolveVMAllocation(vm, nil),
+		"a missing class leaves only the VM's own figures")
+}
+
+func TestClassDiskSizeGiBRoundsUp(t *testing.T) {
+	spec := &infravirtrigaudiov1beta1.VMClassSpec{}
+	assert.Zero(t, ClassDiskSizeGiB(spec))
+
+	spec.DiskDefaults = &infravirtrigaudiov1beta1.DiskDefaults{Size: resource.MustParse("10500Mi")}
+	assert.Equal(t, int64(11), ClassDiskSizeGiB(spec))
+}
+
+func TestCeilGiB(t *testing.T) {
+	assert.Zero(t, CeilGiB(0))
+	assert.Equal(t, int64(1), CeilGiB(1))
+	assert.Equal(t, int64(40), CeilGiB(40<<30))
+	assert.Equal(t, int64(41), CeilGiB(40<<30+1))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// Annotations set on a Namespace to give the VirtualMachines created in it
+// a providerRef, classRef or imageRef when their spec omits one. The value
+// is "name" for an object in the namespace itself, or "namespace/name".
+// They are applied at admission only, so changing them does not touch
+// existing VMs.
+const (
+	DefaultProviderAnnotation = "virtrigaud.io/default-provider"
+	DefaultClassAnnotation    = "virtrigaud.io/default-class"
+	DefaultImageAnnotation    = "virtrigaud.io/default-image"
+)
+
+// DefaultedRefsAnnotation is set on a VirtualMachine whose references were
+// filled in from its namespace's defaults. The value lists the fields, e.g.
+// "providerRef,classRef".
+const DefaultedRefsAnnotation = "virtrigaud.io/defaulted-refs"
+
+// NamespaceDefaults holds the default references of a namespace. A nil
+// field has no default.
+type NamespaceDefaults struct {
+	ProviderRef *infrav1beta1.ObjectRef
+	ClassRef    *infrav1beta1.ObjectRef
+	ImageRef    *infrav1beta1.ObjectRef
+}
+
+// GetNamespaceDefaults reads the default references from the annotations of
+// namespace. A namespace that does not exist has no defaults.
+func GetNamespaceDefaults(ctx context.Context, c client.Reader, namespace string) (NamespaceDefaults, error) {
+	var defaults NamespaceDefaults
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return defaults, nil
+		}
+		return defaults, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	for annotation, ref := range map[string]**infrav1beta1.ObjectRef{
+		DefaultProviderAnnotation: &defaults.ProviderRef,
+		DefaultClassAnnotation:    &defaults.ClassRef,
+		DefaultImageAnnotation:    &defaults.ImageRef,
+	} {
+		value, ok := ns.Annotations[annotation]
+		if !ok {
+			continue
+		}
+		parsed, err := ParseDefaultRef(value)
+		if err != nil {
+			return defaults, fmt.Errorf("namespace %s annotation %s: %w", namespace, annotation, err)
+		}
+		*ref = &parsed
+	}
+	return defaults, nil
+}
+
+// ParseDefaultRef parses the value of a default reference annotation:
+// "name" or "namespace/name".
+func ParseDefaultRef(value string) (infrav1beta1.ObjectRef, error) {
+	value = strings.TrimSpace(value)
+	var ref infrav1beta1.ObjectRef
+	if namespace, name, ok := strings.Cut(value, "/"); ok {
+		ref.Namespace, ref.Name = namespace, name
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return ref, fmt.Errorf("invalid namespace in %q: %s", value, strings.Join(errs, "; "))
+		}
+	} else {
+		ref.Name = value
+	}
+	if errs := validation.IsDNS1123Subdomain(ref.Name); len(errs) > 0 {
+		return ref, fmt.Errorf("invalid name in %q: %s", value, strings.Join(errs, "; "))
+	}
+	return ref, nil
+}
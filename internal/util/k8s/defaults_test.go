@@ -0,0 +1,68 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func TestParseDefaultRef(t *testing.T) {
+	ref, err := ParseDefaultRef("vsphere-prod")
+	require.NoError(t, err)
+	assert.Equal(t, infrav1beta1.ObjectRef{Name: "vsphere-prod"}, ref)
+
+	ref, err = ParseDefaultRef(" shared/small ")
+	require.NoError(t, err)
+	assert.Equal(t, infrav1beta1.ObjectRef{Namespace: "shared", Name: "small"}, ref)
+
+	for _, bad := range []string{"", "Shared/small", "shared/", "a/b/c"} {
+		_, err := ParseDefaultRef(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestGetNamespaceDefaults(t *testing.T) {
+	ctx := context.Background()
+	c := newRefsClient(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: map[string]string{
+		DefaultProviderAnnotation: "shared/pve",
+		DefaultClassAnnotation:    "small",
+	}}}, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "broken", Annotations: map[string]string{
+		DefaultImageAnnotation: "not a name",
+	}}})
+
+	defaults, err := GetNamespaceDefaults(ctx, c, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, &infrav1beta1.ObjectRef{Namespace: "shared", Name: "pve"}, defaults.ProviderRef)
+	assert.Equal(t, &infrav1beta1.ObjectRef{Name: "small"}, defaults.ClassRef)
+	assert.Nil(t, defaults.ImageRef)
+
+	_, err = GetNamespaceDefaults(ctx, c, "broken")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), DefaultImageAnnotation)
+
+	defaults, err = GetNamespaceDefaults(ctx, c, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDefaults{}, defaults)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// AbandonOnProviderLossAnnotation, set to "true", lets the controller
+	// remove the finalizer of an object whose deletion is blocked because
+	// its provider cannot be reached. The hypervisor object is then left
+	// behind.
+	AbandonOnProviderLossAnnotation = "virtrigaud.io/abandon-on-provider-loss"
+
+	// ConditionDeletionBlocked is set on an object whose deletion has found
+	// its provider unreachable for longer than the configured threshold.
+	ConditionDeletionBlocked = "DeletionBlocked"
+
+	// ReasonProviderUnreachable is the DeletionBlocked reason while the
+	// finalizer is kept.
+	ReasonProviderUnreachable = "ProviderUnreachable"
+	// ReasonFinalizerAbandoned is the DeletionBlocked reason once the
+	// finalizer is removed without the provider having deleted anything.
+	ReasonFinalizerAbandoned = "FinalizerAbandoned"
+
+	// unreachableAttemptsAnnotation, unreachableSinceAnnotation and
+	// unreachableLastAnnotation count the deletion attempts in a row that
+	// found the provider unreachable. They live on the object so the count
+	// survives manager restarts.
+	unreachableAttemptsAnnotation = "virtrigaud.io/deletion-unreachable-attempts"
+	unreachableSinceAnnotation    = "virtrigaud.io/deletion-unreachable-since"
+	unreachableLastAnnotation     = "virtrigaud.io/deletion-unreachable-last"
+)
+
+// EnsureFinalizer adds finalizer to obj with a single Update when obj does
+// not carry it yet. It reports whether obj was updated, in which case the
+// caller usually requeues.
+func EnsureFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) (bool, error) {
+	if !controllerutil.AddFinalizer(obj, finalizer) {
+		return false, nil
+	}
+	if err := c.Update(ctx, obj); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveFinalizer removes finalizer from obj. On a conflict it reads obj
+// again and retries, so a status write that raced the removal does not fail
+// the deletion. An object that is already gone counts as done.
+func RemoveFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
+	key := client.ObjectKeyFromObject(obj)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !controllerutil.RemoveFinalizer(obj, finalizer) {
+			return nil
+		}
+		err := c.Update(ctx, obj)
+		if apierrors.IsConflict(err) {
+			if getErr := c.Get(ctx, key, obj); getErr != nil {
+				return client.IgnoreNotFound(getErr)
+			}
+			return err
+		}
+		return client.IgnoreNotFound(err)
+	})
+}
+
+// AbandonsOnProviderLoss reports whether obj opted in to having its
+// finalizer removed when its deletion is blocked.
+func AbandonsOnProviderLoss(obj metav1.Object) bool {
+	return obj.GetAnnotations()[AbandonOnProviderLossAnnotation] == "true"
+}
+
+// UnreachableDeletion is the run of deletion attempts that found the
+// provider unreachable.
+type UnreachableDeletion struct {
+	// Attempts is the number of attempts in the run.
+	Attempts int
+	// Since is when the first of them happened.
+	Since time.Time
+}
+
+// Blocked reports whether the run has reached both thresholds: at least
+// attempts attempts, over at least after.
+func (d UnreachableDeletion) Blocked(attempts int, after time.Duration, now time.Time) bool {
+	return d.Attempts >= attempts && now.Sub(d.Since) >= after
+}
+
+// RecordUnreachableDeletion adds an attempt made at now to obj's run of
+// unreachable deletion attempts and returns the run. An attempt made less
+// than interval after the last counted one is not counted and obj is left
+// as it is: the updates that tracking the run makes to obj trigger
+// reconciles of their own, which are not retries.
+func RecordUnreachableDeletion(ctx context.Context, c client.Client, obj client.Object, now time.Time, interval time.Duration) (UnreachableDeletion, error) {
+	annotations := obj.GetAnnotations()
+	run := UnreachableDeletion{Since: now}
+	if n, err := strconv.Atoi(annotations[unreachableAttemptsAnnotation]); err == nil && n > 0 {
+		if since, err := time.Parse(time.RFC3339, annotations[unreachableSinceAnnotation]); err == nil {
+			run = UnreachableDeletion{Attempts: n, Since: since}
+		}
+	}
+	if run.Attempts > 0 {
+		if last, err := time.Parse(time.RFC3339, annotations[unreachableLastAnnotation]); err == nil && now.Sub(last) < interval {
+			return run, nil
+		}
+	}
+	run.Attempts++
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[unreachableAttemptsAnnotation] = strconv.Itoa(run.Attempts)
+	annotations[unreachableSinceAnnotation] = run.Since.UTC().Format(time.RFC3339)
+	annotations[unreachableLastAnnotation] = now.UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+	return run, c.Patch(ctx, obj, patch)
+}
+
+// ClearUnreachableDeletion ends obj's run of unreachable deletion attempts,
+// as when the provider answered again.
+func ClearUnreachableDeletion(ctx context.Context, c client.Client, obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[unreachableAttemptsAnnotation]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	delete(annotations, unreachableAttemptsAnnotation)
+	delete(annotations, unreachableSinceAnnotation)
+	delete(annotations, unreachableLastAnnotation)
+	obj.SetAnnotations(annotations)
+	return c.Patch(ctx, obj, patch)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testFinalizer = "test.virtrigaud.io/finalizer"
+
+func finalizerObject(finalizers ...string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:       "cm",
+		Namespace:  "default",
+		Finalizers: finalizers,
+	}}
+}
+
+func TestEnsureFinalizer(t *testing.T) {
+	ctx := context.Background()
+	obj := finalizerObject()
+	c := newRefsClient(t, obj)
+
+	added, err := EnsureFinalizer(ctx, c, obj, testFinalizer)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = EnsureFinalizer(ctx, c, obj, testFinalizer)
+	require.NoError(t, err)
+	assert.False(t, added, "a second call must not update the object")
+
+	var got corev1.ConfigMap
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &got))
+	assert.Equal(t, []string{testFinalizer}, got.Finalizers)
+}
+
+// TestRemoveFinalizer_RetriesOnConflict verifies a stale copy is read again
+// instead of failing the removal.
+func TestRemoveFinalizer_RetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	obj := finalizerObject(testFinalizer, "other.virtrigaud.io/finalizer")
+	c := newRefsClient(t, obj)
+
+	stale := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), stale))
+	fresh := stale.DeepCopy()
+	fresh.Data = map[string]string{"k": "v"}
+	require.NoError(t, c.Update(ctx, fresh))
+
+	require.NoError(t, RemoveFinalizer(ctx, c, stale, testFinalizer))
+
+	var got corev1.ConfigMap
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &got))
+	assert.Equal(t, []string{"other.virtrigaud.io/finalizer"}, got.Finalizers)
+	assert.Equal(t, "v", got.Data["k"], "the concurrent write must be kept")
+}
+
+func TestRemoveFinalizer_AlreadyGone(t *testing.T) {
+	c := newRefsClient(t)
+	assert.NoError(t, RemoveFinalizer(context.Background(), c, finalizerObject(testFinalizer), testFinalizer))
+}
+
+// TestUnreachableDeletion verifies the run grows across attempts, is blocked
+// only past both thresholds and starts over once cleared.
+func TestUnreachableDeletion(t *testing.T) {
+	ctx := context.Background()
+	obj := finalizerObject(testFinalizer)
+	c := newRefsClient(t, obj)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	run, err := RecordUnreachableDeletion(ctx, c, obj, start, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, UnreachableDeletion{Attempts: 1, Since: start}, run)
+
+	var got corev1.ConfigMap
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &got))
+	later := start.Add(2 * time.Hour)
+	run, err = RecordUnreachableDeletion(ctx, c, &got, later, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Attempts)
+	assert.True(t, run.Since.Equal(start), "the run keeps its first attempt")
+
+	assert.True(t, run.Blocked(2, time.Hour, later))
+	assert.False(t, run.Blocked(3, time.Hour, later), "too few attempts")
+	assert.False(t, run.Blocked(2, 3*time.Hour, later), "too short")
+
+	require.NoError(t, ClearUnreachableDeletion(ctx, c, &got))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &got))
+	assert.NotContains(t, got.Annotations, unreachableAttemptsAnnotation)
+	run, err = RecordUnreachableDeletion(ctx, c, &got, later, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, UnreachableDeletion{Attempts: 1, Since: later}, run)
+}
+
+// TestUnreachableDeletion_SpacedAttempts verifies attempts closer together
+// than the interval are counted once and leave the object alone.
+func TestUnreachableDeletion_SpacedAttempts(t *testing.T) {
+	ctx := context.Background()
+	obj := finalizerObject(testFinalizer)
+	c := newRefsClient(t, obj)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := client.ObjectKeyFromObject(obj)
+
+	_, err := RecordUnreachableDeletion(ctx, c, obj, start, 15*time.Second)
+	require.NoError(t, err)
+
+	var got corev1.ConfigMap
+	require.NoError(t, c.Get(ctx, key, &got))
+	version := got.ResourceVersion
+	for _, at := range []time.Duration{0, time.Second, 14 * time.Second} {
+		run, err := RecordUnreachableDeletion(ctx, c, &got, start.Add(at), 15*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Attempts, "an attempt %s after the last one must not count", at)
+	}
+	require.NoError(t, c.Get(ctx, key, &got))
+	assert.Equal(t, version, got.ResourceVersion, "an uncounted attempt must not update the object")
+
+	run, err := RecordUnreachableDeletion(ctx, c, &got, start.Add(15*time.Second), 15*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Attempts)
+	run, err = RecordUnreachableDeletion(ctx, c, &got, start.Add(20*time.Second), 15*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Attempts, "the interval runs from the last counted attempt")
+}
+
+func TestAbandonsOnProviderLoss(t *testing.T) {
+	obj := finalizerObject()
+	assert.False(t, AbandonsOnProviderLoss(obj))
+	obj.Annotations = map[string]string{AbandonOnProviderLossAnnotation: "false"}
+	assert.False(t, AbandonsOnProviderLoss(obj))
+	obj.Annotations[AbandonOnProviderLossAnnotation] = "true"
+	assert.True(t, AbandonsOnProviderLoss(obj))
+}
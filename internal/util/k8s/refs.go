@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// AllowReferencesFromAnnotation is set on a Namespace to let objects in
+// other namespaces reference the Providers, VMClasses, VMImages and other
+// objects it holds. The value is a comma-separated list of namespace
+// names, or "*" for every namespace. References within a namespace are
+// always allowed.
+const AllowReferencesFromAnnotation = "virtrigaud.io/allow-references-from"
+
+// AllowCredentialReferencesFromAnnotation is set on a Namespace to let
+// Providers in other namespaces use the credential Secrets it holds. It
+// takes the same values as AllowReferencesFromAnnotation but is granted
+// separately: admitting references to a namespace's Providers does not
+// hand out its hypervisor credentials.
+const AllowCredentialReferencesFromAnnotation = "virtrigaud.io/allow-credential-references-from"
+
+// CrossNamespaceRefError reports a reference into a namespace that does not
+// admit references from the referring object's namespace.
+type CrossNamespaceRefError struct {
+	// Field is the path of the reference in the referring object, e.g.
+	// "spec.providerRef".
+	Field         string
+	FromNamespace string
+	Namespace     string
+	Name          string
+	// Annotation is the grant the target namespace lacks. Empty means
+	// AllowReferencesFromAnnotation.
+	Annotation string
+}
+
+func (e *CrossNamespaceRefError) Error() string {
+	annotation := e.Annotation
+	if annotation == "" {
+		annotation = AllowReferencesFromAnnotation
+	}
+	return fmt.Sprintf("%s: reference to %s/%s from namespace %s is not allowed; "+
+		"annotate namespace %s with %s listing %s to permit it",
+		e.Field, e.Namespace, e.Name, e.FromNamespace, e.Namespace, annotation, e.FromNamespace)
+}
+
+// IsCrossNamespaceRefDenied reports whether err, or an error it wraps, is a
+// CrossNamespaceRefError.
+func IsCrossNamespaceRefDenied(err error) bool {
+	var refErr *CrossNamespaceRefError
+	return errors.As(err, &refErr)
+}
+
+// RefNamespace returns the namespace ref points into when it appears on an
+// object in fromNamespace: its own namespace if set, fromNamespace
+// otherwise.
+func RefNamespace(ref infrav1beta1.ObjectRef, fromNamespace string) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return fromNamespace
+}
+
+// RefKey returns the key of the object ref points at from fromNamespace.
+// It applies namespace defaulting only; use ResolveRef to also enforce the
+// cross-namespace policy.
+func RefKey(ref infrav1beta1.ObjectRef, fromNamespace string) client.ObjectKey {
+	return client.ObjectKey{Namespace: RefNamespace(ref, fromNamespace), Name: ref.Name}
+}
+
+// ReferencesAllowed reports whether target admits references from objects
+// in fromNamespace.
+func ReferencesAllowed(target *corev1.Namespace, fromNamespace string) bool {
+	return namespaceAdmits(target, AllowReferencesFromAnnotation, fromNamespace)
+}
+
+// CredentialReferencesAllowed reports whether target lets Providers in
+// fromNamespace use the credential Secrets it holds.
+func CredentialReferencesAllowed(target *corev1.Namespace, fromNamespace string) bool {
+	return namespaceAdmits(target, AllowCredentialReferencesFromAnnotation, fromNamespace)
+}
+
+func namespaceAdmits(target *corev1.Namespace, annotation, fromNamespace string) bool {
+	if target.Name == fromNamespace {
+		return true
+	}
+	for _, ns := range strings.Split(target.GetAnnotations()[annotation], ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" || ns == fromNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckNamespaceAccess returns a CrossNamespaceRefError unless objects in
+// fromNamespace may reference name in targetNamespace. A target namespace
+// that does not exist admits nothing.
+func CheckNamespaceAccess(ctx context.Context, c client.Reader, field, fromNamespace, targetNamespace, name string) error {
+	return checkAccess(ctx, c, AllowReferencesFromAnnotation, field, fromNamespace, targetNamespace, name)
+}
+
+// CheckCredentialAccess is CheckNamespaceAccess for credential Secrets: the
+// target namespace must carry AllowCredentialReferencesFromAnnotation.
+func CheckCredentialAccess(ctx context.Context, c client.Reader, field, fromNamespace, targetNamespace, name string) error {
+	return checkAccess(ctx, c, AllowCredentialReferencesFromAnnotation, field, fromNamespace, targetNamespace, name)
+}
+
+func checkAccess(ctx context.Context, c client.Reader, annotation, field, fromNamespace, targetNamespace, name string) error {
+	if targetNamespace == fromNamespace {
+		return nil
+	}
+	denied := &CrossNamespaceRefError{Field: field, FromNamespace: fromNamespace, Namespace: targetNamespace, Name: name}
+	if annotation != AllowReferencesFromAnnotation {
+		denied.Annotation = annotation
+	}
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: targetNamespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return denied
+		}
+		return fmt.Errorf("failed to get namespace %s: %w", targetNamespace, err)
+	}
+	if !namespaceAdmits(ns, annotation, fromNamespace) {
+		return denied
+	}
+	return nil
+}
+
+// ResolveRef returns the key ref resolves to from fromNamespace, or a
+// CrossNamespaceRefError if the target namespace does not admit the
+// reference. Every controller resolves providerRef, classRef, imageRef and
+// similar fields through here so the rules are applied the same way.
+func ResolveRef(ctx context.Context, c client.Reader, field string, ref infrav1beta1.ObjectRef, fromNamespace string) (client.ObjectKey, error) {
+	key := RefKey(ref, fromNamespace)
+	if err := CheckNamespaceAccess(ctx, c, field, fromNamespace, key.Namespace, key.Name); err != nil {
+		return key, err
+	}
+	return key, nil
+}
+
+// GetRef resolves ref with ResolveRef and reads the object into obj.
+func GetRef(ctx context.Context, c client.Reader, field string, ref infrav1beta1.ObjectRef, fromNamespace string, obj client.Object) error {
+	key, err := ResolveRef(ctx, c, field, ref, fromNamespace)
+	if err != nil {
+		return err
+	}
+	return c.Get(ctx, key, obj)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func namespace(name, allow string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if allow != "" {
+		ns.Annotations = map[string]string{AllowReferencesFromAnnotation: allow}
+	}
+	return ns
+}
+
+func newRefsClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestReferencesAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		target *corev1.Namespace
+		from   string
+		want   bool
+	}{
+		{name: "same namespace", target: namespace("infra", ""), from: "infra", want: true},
+		{name: "no annotation", target: namespace("infra", ""), from: "team-a", want: false},
+		{name: "listed", target: namespace("infra", "team-a"), from: "team-a", want: true},
+		{name: "listed among others", target: namespace("infra", "team-b, team-a"), from: "team-a", want: true},
+		{name: "wildcard", target: namespace("infra", "*"), from: "team-a", want: true},
+		{name: "not listed", target: namespace("infra", "team-b"), from: "team-a", want: false},
+		{name: "prefix is not a match", target: namespace("infra", "team"), from: "team-a", want: false},
+		{name: "empty annotation", target: namespace("infra", ","), from: "team-a", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReferencesAllowed(tt.target, tt.from))
+		})
+	}
+}
+
+func TestRefKey(t *testing.T) {
+	assert.Equal(t, client.ObjectKey{Namespace: "team-a", Name: "p"},
+		RefKey(infrav1beta1.ObjectRef{Name: "p"}, "team-a"))
+	assert.Equal(t, client.ObjectKey{Namespace: "infra", Name: "p"},
+		RefKey(infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"}, "team-a"))
+}
+
+func TestResolveRef(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []client.Object
+		ref        infrav1beta1.ObjectRef
+		wantKey    client.ObjectKey
+		wantDenied bool
+	}{
+		{
+			name:    "defaults to the referring namespace",
+			ref:     infrav1beta1.ObjectRef{Name: "p"},
+			wantKey: client.ObjectKey{Namespace: "team-a", Name: "p"},
+		},
+		{
+			name:    "explicit same namespace",
+			ref:     infrav1beta1.ObjectRef{Name: "p", Namespace: "team-a"},
+			wantKey: client.ObjectKey{Namespace: "team-a", Name: "p"},
+		},
+		{
+			name:       "cross-namespace granted",
+			namespaces: []client.Object{namespace("infra", "team-a")},
+			ref:        infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"},
+			wantKey:    client.ObjectKey{Namespace: "infra", Name: "p"},
+		},
+		{
+			name:       "cross-namespace granted to all",
+			namespaces: []client.Object{namespace("infra", "*")},
+			ref:        infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"},
+			wantKey:    client.ObjectKey{Namespace: "infra", Name: "p"},
+		},
+		{
+			name:       "cross-namespace without annotation",
+			namespaces: []client.Object{namespace("infra", "")},
+			ref:        infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"},
+			wantKey:    client.ObjectKey{Namespace: "infra", Name: "p"},
+			wantDenied: true,
+		},
+		{
+			name:       "cross-namespace granted to another namespace",
+			namespaces: []client.Object{namespace("infra", "team-b")},
+			ref:        infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"},
+			wantKey:    client.ObjectKey{Namespace: "infra", Name: "p"},
+			wantDenied: true,
+		},
+		{
+			name:       "target namespace missing",
+			ref:        infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"},
+			wantKey:    client.ObjectKey{Namespace: "infra", Name: "p"},
+			wantDenied: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRefsClient(t, tt.namespaces...)
+			key, err := ResolveRef(context.Background(), c, "spec.providerRef", tt.ref, "team-a")
+			assert.Equal(t, tt.wantKey, key)
+			if !tt.wantDenied {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, IsCrossNamespaceRefDenied(err))
+			assert.Contains(t, err.Error(), "spec.providerRef")
+			assert.Contains(t, err.Error(), AllowReferencesFromAnnotation)
+		})
+	}
+}
+
+func TestCheckCredentialAccess(t *testing.T) {
+	ctx := context.Background()
+	credentials := func(allow string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "vault",
+			Annotations: map[string]string{AllowCredentialReferencesFromAnnotation: allow},
+		}}
+	}
+
+	t.Run("same namespace", func(t *testing.T) {
+		c := newRefsClient(t)
+		require.NoError(t, CheckCredentialAccess(ctx, c, "spec.credentialSecretRef", "team-a", "team-a", "creds"))
+	})
+
+	t.Run("granted", func(t *testing.T) {
+		c := newRefsClient(t, credentials("team-b, team-a"))
+		require.NoError(t, CheckCredentialAccess(ctx, c, "spec.credentialSecretRef", "team-a", "vault", "creds"))
+	})
+
+	t.Run("general grant does not cover credentials", func(t *testing.T) {
+		c := newRefsClient(t, namespace("vault", "*"))
+		err := CheckCredentialAccess(ctx, c, "spec.credentialSecretRef", "team-a", "vault", "creds")
+		require.Error(t, err)
+		assert.True(t, IsCrossNamespaceRefDenied(err))
+		assert.Contains(t, err.Error(), AllowCredentialReferencesFromAnnotation)
+	})
+
+	t.Run("credential grant does not cover other references", func(t *testing.T) {
+		c := newRefsClient(t, credentials("*"))
+		err := CheckNamespaceAccess(ctx, c, "spec.providerRef", "team-a", "vault", "p")
+		assert.True(t, IsCrossNamespaceRefDenied(err))
+	})
+
+	t.Run("not listed", func(t *testing.T) {
+		c := newRefsClient(t, credentials("team-b"))
+		err := CheckCredentialAccess(ctx, c, "spec.credentialSecretRef", "team-a", "vault", "creds")
+		assert.True(t, IsCrossNamespaceRefDenied(err))
+	})
+}
+
+func TestGetRef(t *testing.T) {
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "infra"}}
+
+	t.Run("granted", func(t *testing.T) {
+		c := newRefsClient(t, namespace("infra", "team-a"), provider)
+		got := &infrav1beta1.Provider{}
+		require.NoError(t, GetRef(context.Background(), c, "spec.providerRef",
+			infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"}, "team-a", got))
+		assert.Equal(t, "p", got.Name)
+	})
+
+	t.Run("denied without reading the object", func(t *testing.T) {
+		c := newRefsClient(t, namespace("infra", ""), provider)
+		got := &infrav1beta1.Provider{}
+		err := GetRef(context.Background(), c, "spec.providerRef",
+			infrav1beta1.ObjectRef{Name: "p", Namespace: "infra"}, "team-a", got)
+		assert.True(t, IsCrossNamespaceRefDenied(err))
+		assert.Empty(t, got.Name)
+	})
+}
+
+func TestIsCrossNamespaceRefDenied(t *testing.T) {
+	denied := &CrossNamespaceRefError{Field: "spec.classRef", FromNamespace: "a", Namespace: "b", Name: "c"}
+	assert.True(t, IsCrossNamespaceRefDenied(denied))
+	assert.True(t, IsCrossNamespaceRefDenied(fmt.Errorf("failed to get vmclass c: %w", denied)))
+	assert.False(t, IsCrossNamespaceRefDenied(fmt.Errorf("boom")))
+	assert.False(t, IsCrossNamespaceRefDenied(nil))
+}
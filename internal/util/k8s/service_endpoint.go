@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// ServiceRefNamespace returns the namespace of the Service ref points at
+// when it appears on an object in fromNamespace.
+func ServiceRefNamespace(ref infrav1beta1.ServiceEndpointRef, fromNamespace string) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return fromNamespace
+}
+
+// ServiceDNSName returns the cluster DNS name of the Service ref points at
+// from fromNamespace.
+func ServiceDNSName(ref infrav1beta1.ServiceEndpointRef, fromNamespace string) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", ref.ServiceName, ServiceRefNamespace(ref, fromNamespace))
+}
+
+// ResolveServiceEndpoint reads the Service ref points at from fromNamespace
+// and returns its DNS name and the port ref selects. A reference into
+// another namespace is subject to CheckNamespaceAccess.
+func ResolveServiceEndpoint(ctx context.Context, c client.Reader, field string, ref infrav1beta1.ServiceEndpointRef, fromNamespace string) (string, int32, error) {
+	namespace := ServiceRefNamespace(ref, fromNamespace)
+	if err := CheckNamespaceAccess(ctx, c, field, fromNamespace, namespace, ref.ServiceName); err != nil {
+		return "", 0, err
+	}
+	svc := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.ServiceName}, svc); err != nil {
+		return "", 0, fmt.Errorf("%s: failed to get service %s/%s: %w", field, namespace, ref.ServiceName, err)
+	}
+	port, err := servicePort(svc, ref.Port)
+	if err != nil {
+		return "", 0, fmt.Errorf("%s: %w", field, err)
+	}
+	return ServiceDNSName(ref, fromNamespace), port, nil
+}
+
+// servicePort returns the port of svc that want selects: the one with that
+// number or name, or the only one when want is nil.
+func servicePort(svc *corev1.Service, want *intstr.IntOrString) (int32, error) {
+	if want == nil {
+		if len(svc.Spec.Ports) != 1 {
+			return 0, fmt.Errorf("service %s/%s has %d ports; set port to choose one", svc.Namespace, svc.Name, len(svc.Spec.Ports))
+		}
+		return svc.Spec.Ports[0].Port, nil
+	}
+	for _, p := range svc.Spec.Ports {
+		if want.Type == intstr.Int && p.Port == want.IntVal || want.Type == intstr.String && p.Name == want.StrVal {
+			return p.Port, nil
+		}
+	}
+	return 0, fmt.Errorf("service %s/%s has no port %s", svc.Namespace, svc.Name, want.String())
+}
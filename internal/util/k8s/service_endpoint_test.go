@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func service(namespace, name string, ports ...corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.ServiceSpec{Ports: ports},
+	}
+}
+
+func TestResolveServiceEndpoint(t *testing.T) {
+	ctx := context.Background()
+	port := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+	c := newRefsClient(t,
+		namespace("team-a", ""),
+		namespace("infra", "team-a"),
+		namespace("locked", ""),
+		service("team-a", "pve", corev1.ServicePort{Name: "api", Port: 8006}),
+		service("team-a", "vcsim",
+			corev1.ServicePort{Name: "https", Port: 443},
+			corev1.ServicePort{Name: "metrics", Port: 9090}),
+		service("infra", "libvirtd", corev1.ServicePort{Name: "tcp", Port: 16509}),
+		service("locked", "pve", corev1.ServicePort{Name: "api", Port: 8006}),
+	)
+
+	tests := []struct {
+		name     string
+		ref      infrav1beta1.ServiceEndpointRef
+		wantHost string
+		wantPort int32
+		wantErr  string
+		denied   bool
+	}{
+		{name: "only port", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "pve"},
+			wantHost: "pve.team-a.svc.cluster.local", wantPort: 8006},
+		{name: "port by name", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "vcsim", Port: port(intstr.FromString("https"))},
+			wantHost: "vcsim.team-a.svc.cluster.local", wantPort: 443},
+		{name: "port by number", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "vcsim", Port: port(intstr.FromInt32(9090))},
+			wantHost: "vcsim.team-a.svc.cluster.local", wantPort: 9090},
+		{name: "several ports, none chosen", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "vcsim"},
+			wantErr: "has 2 ports"},
+		{name: "unknown port", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "vcsim", Port: port(intstr.FromString("grpc"))},
+			wantErr: "has no port grpc"},
+		{name: "missing service", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "gone"},
+			wantErr: "failed to get service team-a/gone"},
+		{name: "admitted namespace", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "libvirtd", Namespace: "infra"},
+			wantHost: "libvirtd.infra.svc.cluster.local", wantPort: 16509},
+		{name: "denied namespace", ref: infrav1beta1.ServiceEndpointRef{ServiceName: "pve", Namespace: "locked"},
+			denied: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port, err := ResolveServiceEndpoint(ctx, c, "spec.endpointRef", tt.ref, "team-a")
+			switch {
+			case tt.denied:
+				assert.True(t, IsCrossNamespaceRefDenied(err), "got %v", err)
+			case tt.wantErr != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantHost, host)
+				assert.Equal(t, tt.wantPort, port)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vmclass resolves the effective spec of a VMClass from its
+// spec.inheritFrom chain. Everything that turns a class into VM settings
+// goes through Resolve, so a child class that only sets a few fields is
+// never used on its own.
+//
+// The chain is merged from the root ancestor down. A field a class sets
+// replaces the inherited value; a field it leaves unset, or at its zero
+// value, is inherited. Maps (extraConfig) merge key by key and nested
+// settings (diskDefaults, resourceLimits, performanceProfile,
+// securityProfile) merge field by field. Lists replace. Defaults that
+// used to be applied by the API server, such as firmware BIOS, are applied
+// after the merge so that they do not mask a parent's value.
+package vmclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// MaxDepth is the longest inheritFrom chain, counting the class itself.
+const MaxDepth = 8
+
+// ErrInheritance is wrapped by the errors Resolve returns for a cycle or a
+// chain longer than MaxDepth. A missing parent is reported as the API
+// server's NotFound error instead.
+var ErrInheritance = errors.New("invalid VMClass inheritance")
+
+// Resolved is the effective spec of a class.
+type Resolved struct {
+	// Spec is the merged spec with defaults applied. Its InheritFrom is nil.
+	Spec infrav1beta1.VMClassSpec
+	// Chain names the merged classes, from the root ancestor to the class.
+	Chain []string
+}
+
+// Resolve merges class with its ancestors, which are read from class's
+// namespace through c. class itself is used as given, so an object that is
+// being admitted can be resolved before it is stored.
+func Resolve(ctx context.Context, c client.Reader, class *infrav1beta1.VMClass) (*Resolved, error) {
+	classes := []*infrav1beta1.VMClass{class}
+	for current := class; current.Spec.InheritFrom != nil; {
+		name := current.Spec.InheritFrom.Name
+		for _, seen := range classes {
+			if seen.Name == name {
+				return nil, fmt.Errorf("%w: cycle %s", ErrInheritance, cyclePath(classes, name))
+			}
+		}
+		if len(classes) == MaxDepth {
+			return nil, fmt.Errorf("%w: chain from %s is longer than %d classes", ErrInheritance, class.Name, MaxDepth)
+		}
+		parent := &infrav1beta1.VMClass{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: class.Namespace, Name: name}, parent); err != nil {
+			return nil, fmt.Errorf("inheritFrom %s of VMClass %s: %w", name, current.Name, err)
+		}
+		classes = append(classes, parent)
+		current = parent
+	}
+
+	resolved := &Resolved{}
+	for i := len(classes) - 1; i >= 0; i-- {
+		resolved.Spec = Merge(resolved.Spec, classes[i].Spec)
+		resolved.Chain = append(resolved.Chain, classes[i].Name)
+	}
+	resolved.Spec.InheritFrom = nil
+	Default(&resolved.Spec)
+	return resolved, nil
+}
+
+// ResolveInto replaces class.Spec with its resolved spec.
+func ResolveInto(ctx context.Context, c client.Reader, class *infrav1beta1.VMClass) error {
+	resolved, err := Resolve(ctx, c, class)
+	if err != nil {
+		return err
+	}
+	class.Spec = resolved.Spec
+	return nil
+}
+
+// Get reads the class at key and resolves it.
+func Get(ctx context.Context, c client.Reader, key client.ObjectKey) (*infrav1beta1.VMClass, error) {
+	class := &infrav1beta1.VMClass{}
+	if err := c.Get(ctx, key, class); err != nil {
+		return nil, err
+	}
+	if err := ResolveInto(ctx, c, class); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// cyclePath renders the chain from the class that closes the cycle, e.g.
+// "a -> b -> a".
+func cyclePath(classes []*infrav1beta1.VMClass, closing string) string {
+	names := make([]string, 0, len(classes)+1)
+	for _, class := range classes {
+		names = append(names, class.Name)
+	}
+	return strings.Join(append(names, closing), " -> ")
+}
+
+// Merge returns parent overridden by child.
+func Merge(parent, child infrav1beta1.VMClassSpec) infrav1beta1.VMClassSpec {
+	out := *parent.DeepCopy()
+	child = *child.DeepCopy()
+
+	out.InheritFrom = child.InheritFrom
+	if child.CPU != 0 {
+		out.CPU = child.CPU
+	}
+	if !child.Memory.IsZero() {
+		out.Memory = child.Memory
+	}
+	if child.Firmware != "" {
+		out.Firmware = child.Firmware
+	}
+	if child.MachineType != "" {
+		out.MachineType = child.MachineType
+	}
+	if child.GuestToolsPolicy != "" {
+		out.GuestToolsPolicy = child.GuestToolsPolicy
+	}
+	if len(child.ExtraConfig) > 0 {
+		if out.ExtraConfig == nil {
+			out.ExtraConfig = make(map[string]string, len(child.ExtraConfig))
+		}
+		for key, value := range child.ExtraConfig {
+			out.ExtraConfig[key] = value
+		}
+	}
+	out.MemoryBalloon = mergeMemoryBalloon(out.MemoryBalloon, child.MemoryBalloon)
+	out.DiskDefaults = mergeDiskDefaults(out.DiskDefaults, child.DiskDefaults)
+	out.ResourceLimits = mergeResourceLimits(out.ResourceLimits, child.ResourceLimits)
+	out.PerformanceProfile = mergePerformanceProfile(out.PerformanceProfile, child.PerformanceProfile)
+	out.SecurityProfile = mergeSecurityProfile(out.SecurityProfile, child.SecurityProfile)
+	return out
+}
+
+// mergeMemoryBalloon lets a child's memoryBalloon turn the balloon on or
+// off, since enabled is required whenever the block is set.
+func mergeMemoryBalloon(parent, child *infrav1beta1.MemoryBalloon) *infrav1beta1.MemoryBalloon {
+	if parent == nil || child == nil {
+		return firstNonNil(child, parent)
+	}
+	parent.Enabled = child.Enabled
+	if child.MinimumMiB != nil {
+		parent.MinimumMiB = child.MinimumMiB
+	}
+	if child.Shares != nil {
+		parent.Shares = child.Shares
+	}
+	return parent
+}
+
+func mergeDiskDefaults(parent, child *infrav1beta1.DiskDefaults) *infrav1beta1.DiskDefaults {
+	if parent == nil || child == nil {
+		return firstNonNil(child, parent)
+	}
+	if child.Type != "" {
+		parent.Type = child.Type
+	}
+	if !child.Size.IsZero() {
+		parent.Size = child.Size
+	}
+	if child.IOPS != nil {
+		parent.IOPS = child.IOPS
+	}
+	if child.StorageClass != "" {
+		parent.StorageClass = child.StorageClass
+	}
+	return parent
+}
+
+func mergeResourceLimits(parent, child *infrav1beta1.VMResourceLimits) *infrav1beta1.VMResourceLimits {
+	if parent == nil || child == nil {
+		return firstNonNil(child, parent)
+	}
+	if child.CPULimit != nil {
+		parent.CPULimit = child.CPULimit
+	}
+	if child.CPUReservation != nil {
+		parent.CPUReservation = child.CPUReservation
+	}
+	if child.MemoryLimit != nil {
+		parent.MemoryLimit = child.MemoryLimit
+	}
+	if child.MemoryReservation != nil {
+		parent.MemoryReservation = child.MemoryReservation
+	}
+	if child.CPUShares != nil {
+		parent.CPUShares = child.CPUShares
+	}
+	return parent
+}
+
+func mergePerformanceProfile(parent, child *infrav1beta1.PerformanceProfile) *infrav1beta1.PerformanceProfile {
+	if parent == nil || child == nil {
+		return firstNonNil(child, parent)
+	}
+	if child.LatencySensitivity != "" {
+		parent.LatencySensitivity = child.LatencySensitivity
+	}
+	parent.CPUHotAddEnabled = parent.CPUHotAddEnabled || child.CPUHotAddEnabled
+	parent.MemoryHotAddEnabled = parent.MemoryHotAddEnabled || child.MemoryHotAddEnabled
+	parent.VirtualizationBasedSecurity = parent.VirtualizationBasedSecurity || child.VirtualizationBasedSecurity
+	parent.NestedVirtualization = parent.NestedVirtualization || child.NestedVirtualization
+	if child.HyperThreadingPolicy != "" {
+		parent.HyperThreadingPolicy = child.HyperThreadingPolicy
+	}
+	if child.HugePages != "" {
+		parent.HugePages = child.HugePages
+	}
+	return parent
+}
+
+func mergeSecurityProfile(parent, child *infrav1beta1.SecurityProfile) *infrav1beta1.SecurityProfile {
+	if parent == nil || child == nil {
+		return firstNonNil(child, parent)
+	}
+	parent.SecureBoot = parent.SecureBoot || child.SecureBoot
+	parent.TPMEnabled = parent.TPMEnabled || child.TPMEnabled
+	if child.TPMVersion != "" {
+		parent.TPMVersion = child.TPMVersion
+	}
+	parent.VTDEnabled = parent.VTDEnabled || child.VTDEnabled
+	switch {
+	case parent.EncryptionPolicy == nil:
+		parent.EncryptionPolicy = child.EncryptionPolicy
+	case child.EncryptionPolicy != nil:
+		parent.EncryptionPolicy.Enabled = parent.EncryptionPolicy.Enabled || child.EncryptionPolicy.Enabled
+		if child.EncryptionPolicy.KeyProvider != "" {
+			parent.EncryptionPolicy.KeyProvider = child.EncryptionPolicy.KeyProvider
+		}
+		parent.EncryptionPolicy.RequireEncryption = parent.EncryptionPolicy.RequireEncryption || child.EncryptionPolicy.RequireEncryption
+	}
+	return parent
+}
+
+func firstNonNil[T any](a, b *T) *T {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// Default fills in the defaults of fields the merged spec leaves unset.
+func Default(spec *infrav1beta1.VMClassSpec) {
+	if spec.Firmware == "" {
+		spec.Firmware = infrav1beta1.FirmwareTypeBIOS
+	}
+	if spec.GuestToolsPolicy == "" {
+		spec.GuestToolsPolicy = infrav1beta1.GuestToolsPolicyInstall
+	}
+	if dd := spec.DiskDefaults; dd != nil {
+		if dd.Type == "" {
+			dd.Type = infrav1beta1.DiskTypeThin
+		}
+		if dd.Size.IsZero() {
+			dd.Size = resource.MustParse("40Gi")
+		}
+	}
+	if pp := spec.PerformanceProfile; pp != nil {
+		if pp.LatencySensitivity == "" {
+			pp.LatencySensitivity = "normal"
+		}
+		if pp.HyperThreadingPolicy == "" {
+			pp.HyperThreadingPolicy = "auto"
+		}
+	}
+}
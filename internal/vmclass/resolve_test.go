@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmclass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func class(name, parent string, spec infrav1beta1.VMClassSpec) *infrav1beta1.VMClass {
+	c := &infrav1beta1.VMClass{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"}, Spec: spec}
+	if parent != "" {
+		c.Spec.InheritFrom = &infrav1beta1.LocalObjectReference{Name: parent}
+	}
+	return c
+}
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestResolve(t *testing.T) {
+	base := class("base", "", infrav1beta1.VMClassSpec{
+		CPU:         2,
+		Memory:      resource.MustParse("4Gi"),
+		Firmware:    infrav1beta1.FirmwareTypeUEFI,
+		ExtraConfig: map[string]string{"a": "base", "b": "base"},
+		DiskDefaults: &infrav1beta1.DiskDefaults{
+			Type: infrav1beta1.DiskTypeThick, Size: resource.MustParse("60Gi"), IOPS: ptr.To[int32](500),
+		},
+		SecurityProfile: &infrav1beta1.SecurityProfile{SecureBoot: true, TPMVersion: "2.0"},
+		MemoryBalloon:   &infrav1beta1.MemoryBalloon{Enabled: true, MinimumMiB: ptr.To[int32](2048), Shares: ptr.To[int32](500)},
+	})
+	large := class("large", "base", infrav1beta1.VMClassSpec{
+		CPU:           8,
+		MemoryBalloon: &infrav1beta1.MemoryBalloon{Enabled: true, MinimumMiB: ptr.To[int32](8192)},
+		ExtraConfig:   map[string]string{"b": "large"},
+		DiskDefaults:  &infrav1beta1.DiskDefaults{Size: resource.MustParse("100Gi")},
+	})
+	gpu := class("gpu", "large", infrav1beta1.VMClassSpec{
+		Memory:             resource.MustParse("32Gi"),
+		PerformanceProfile: &infrav1beta1.PerformanceProfile{NestedVirtualization: true},
+	})
+	c := newClient(t, base, large)
+
+	resolved, err := Resolve(context.Background(), c, gpu)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"base", "large", "gpu"}, resolved.Chain)
+
+	spec := resolved.Spec
+	assert.Nil(t, spec.InheritFrom)
+	assert.Equal(t, int32(8), spec.CPU)
+	assert.Equal(t, "32Gi", spec.Memory.String())
+	assert.Equal(t, infrav1beta1.FirmwareTypeUEFI, spec.Firmware, "inherited, not defaulted")
+	assert.Equal(t, infrav1beta1.GuestToolsPolicyInstall, spec.GuestToolsPolicy, "defaulted after the merge")
+	assert.Equal(t, map[string]string{"a": "base", "b": "large"}, spec.ExtraConfig)
+	assert.Equal(t, infrav1beta1.DiskTypeThick, spec.DiskDefaults.Type)
+	assert.Equal(t, "100Gi", spec.DiskDefaults.Size.String())
+	assert.Equal(t, ptr.To[int32](500), spec.DiskDefaults.IOPS)
+	assert.Equal(t, &infrav1beta1.SecurityProfile{SecureBoot: true, TPMVersion: "2.0"}, spec.SecurityProfile)
+	assert.Equal(t, &infrav1beta1.MemoryBalloon{
+		Enabled: true, MinimumMiB: ptr.To[int32](8192), Shares: ptr.To[int32](500),
+	}, spec.MemoryBalloon)
+	assert.Equal(t, &infrav1beta1.PerformanceProfile{
+		NestedVirtualization: true, LatencySensitivity: "normal", HyperThreadingPolicy: "auto",
+	}, spec.PerformanceProfile)
+
+	stored := &infrav1beta1.VMClass{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(base), stored))
+	assert.Equal(t, map[string]string{"a": "base", "b": "base"}, stored.Spec.ExtraConfig, "ancestors are not modified")
+}
+
+func TestResolve_WithoutInheritance(t *testing.T) {
+	resolved, err := Resolve(context.Background(), newClient(t), class("small", "", infrav1beta1.VMClassSpec{
+		CPU: 1, Memory: resource.MustParse("1Gi"), DiskDefaults: &infrav1beta1.DiskDefaults{},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"small"}, resolved.Chain)
+	assert.Equal(t, infrav1beta1.FirmwareTypeBIOS, resolved.Spec.Firmware)
+	assert.Equal(t, infrav1beta1.DiskTypeThin, resolved.Spec.DiskDefaults.Type)
+	assert.Equal(t, "40Gi", resolved.Spec.DiskDefaults.Size.String())
+}
+
+func TestResolve_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := Resolve(ctx, newClient(t), class("child", "missing", infrav1beta1.VMClassSpec{}))
+	assert.True(t, apierrors.IsNotFound(err), "a missing parent is NotFound: %v", err)
+
+	// a is being changed to inherit from c, which already inherits from a.
+	c := newClient(t, class("b", "a", infrav1beta1.VMClassSpec{}), class("c", "b", infrav1beta1.VMClassSpec{}))
+	_, err = Resolve(ctx, c, class("a", "c", infrav1beta1.VMClassSpec{}))
+	assert.ErrorIs(t, err, ErrInheritance)
+	assert.ErrorContains(t, err, "a -> c -> b -> a")
+
+	_, err = Resolve(ctx, newClient(t), class("self", "self", infrav1beta1.VMClassSpec{}))
+	assert.ErrorIs(t, err, ErrInheritance)
+
+	var objs []client.Object
+	for i := 1; i < MaxDepth; i++ {
+		objs = append(objs, class(string(rune('a'+i)), string(rune('a'+i+1)), infrav1beta1.VMClassSpec{}))
+	}
+	objs = append(objs, class(string(rune('a'+MaxDepth)), "", infrav1beta1.VMClassSpec{}))
+	_, err = Resolve(ctx, newClient(t, objs...), class("a", "b", infrav1beta1.VMClassSpec{}))
+	assert.ErrorIs(t, err, ErrInheritance)
+	assert.ErrorContains(t, err, "longer than")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vmtags builds the tags a VM is created with from its Provider's
+// spec.defaultTags and spec.defaultLabels and its own spec.tags, and checks
+// that a provider's backend can hold them.
+//
+// A label becomes the tag "key=value". The VM's tags win: a VM tag
+// "key=value" replaces a default label with the same key, and a VM tag
+// equal to a default one is not repeated.
+package vmtags
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// MaxLength is the longest tag any provider accepts.
+const MaxLength = 128
+
+// proxmoxTag is the PVE tag syntax: lowercase letters, digits and "_-+.",
+// not starting with "-", "+" or ".". The Proxmox provider maps its
+// idempotency tag to the same characters.
+var proxmoxTag = regexp.MustCompile(`^[a-z0-9_][a-z0-9_+.-]*$`)
+
+// Merge returns the tags a VM is created with: defaultTags, then
+// defaultLabels in key order, then tags, without repeats. It returns nil
+// when all three are empty.
+func Merge(defaultTags []string, defaultLabels map[string]string, tags []string) []string {
+	overridden := map[string]bool{}
+	for _, tag := range tags {
+		if key, _, ok := strings.Cut(tag, "="); ok {
+			overridden[key] = true
+		}
+	}
+
+	var merged []string
+	add := func(tag string) {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	for _, tag := range defaultTags {
+		add(tag)
+	}
+	keys := make([]string, 0, len(defaultLabels))
+	for key := range defaultLabels {
+		if !overridden[key] {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		add(key + "=" + defaultLabels[key])
+	}
+	for _, tag := range tags {
+		add(tag)
+	}
+	return merged
+}
+
+// ForVM returns the tags vm is created with on provider.
+func ForVM(provider *infrav1beta1.Provider, vm *infrav1beta1.VirtualMachine) []string {
+	return Merge(provider.Spec.DefaultTags, provider.Spec.DefaultLabels, vm.Spec.Tags)
+}
+
+// Check returns why a provider of type providerType cannot hold tag, or ""
+// when it can.
+func Check(providerType infrav1beta1.ProviderType, tag string) string {
+	switch {
+	case tag == "":
+		return "tags must not be empty"
+	case len(tag) > MaxLength:
+		return fmt.Sprintf("tags must be at most %d characters", MaxLength)
+	case strings.HasPrefix(tag, "="):
+		return "a key=value tag must have a key"
+	}
+	if providerType == infrav1beta1.ProviderTypeProxmox && !proxmoxTag.MatchString(tag) {
+		if strings.Contains(tag, "=") {
+			return "Proxmox VE tags carry no values, so key=value tags and provider defaultLabels cannot be used"
+		}
+		return "Proxmox VE tags are lowercase letters, digits and _-+., not starting with -, + or ."
+	}
+	return ""
+}
+
+// Validate checks every tag in tags against providerType and returns the
+// first problem found, naming the tag, or nil.
+func Validate(providerType infrav1beta1.ProviderType, tags []string) error {
+	for _, tag := range tags {
+		if reason := Check(providerType, tag); reason != "" {
+			return fmt.Errorf("tag %q: %s", tag, reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmtags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		name          string
+		defaultTags   []string
+		defaultLabels map[string]string
+		tags          []string
+		want          []string
+	}{
+		{name: "nothing"},
+		{name: "no defaults", tags: []string{"web", "env=dev"}, want: []string{"web", "env=dev"}},
+		{
+			name:          "defaults only",
+			defaultTags:   []string{"managed"},
+			defaultLabels: map[string]string{"env": "prod", "cost-center": "42"},
+			want:          []string{"managed", "cost-center=42", "env=prod"},
+		},
+		{
+			name:          "VM label wins",
+			defaultTags:   []string{"managed"},
+			defaultLabels: map[string]string{"env": "prod", "cost-center": "42"},
+			tags:          []string{"env=dev", "web"},
+			want:          []string{"managed", "cost-center=42", "env=dev", "web"},
+		},
+		{
+			name:        "repeats dropped",
+			defaultTags: []string{"managed", "web"},
+			tags:        []string{"web", "managed", "db"},
+			want:        []string{"managed", "web", "db"},
+		},
+		{
+			name:          "empty label value",
+			defaultLabels: map[string]string{"team": ""},
+			want:          []string{"team="},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Merge(tc.defaultTags, tc.defaultLabels, tc.tags))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		providerType infrav1beta1.ProviderType
+		tag          string
+		ok           bool
+	}{
+		{infrav1beta1.ProviderTypeProxmox, "cost-center_42", true},
+		{infrav1beta1.ProviderTypeProxmox, "v1.2+build", true},
+		{infrav1beta1.ProviderTypeProxmox, "_internal", true},
+		{infrav1beta1.ProviderTypeProxmox, "Prod", false},
+		{infrav1beta1.ProviderTypeProxmox, "env=prod", false},
+		{infrav1beta1.ProviderTypeProxmox, ".hidden", false},
+		{infrav1beta1.ProviderTypeProxmox, "two words", false},
+		{infrav1beta1.ProviderTypeVSphere, "Cost Center=42", true},
+		{infrav1beta1.ProviderTypeLibvirt, "env=prod", true},
+		{infrav1beta1.ProviderTypeLibvirt, "", false},
+		{infrav1beta1.ProviderTypeLibvirt, "=prod", false},
+		{infrav1beta1.ProviderTypeLibvirt, strings.Repeat("x", MaxLength+1), false},
+	}
+	for _, tc := range cases {
+		reason := Check(tc.providerType, tc.tag)
+		assert.Equal(t, tc.ok, reason == "", "%s %q: %s", tc.providerType, tc.tag, reason)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/obs/metrics"
+)
+
+// +kubebuilder:webhook:path=/warn-infra-virtrigaud-io-v1beta1-deprecations,mutating=false,failurePolicy=ignore,sideEffects=None,groups=infra.virtrigaud.io,resources=virtualmachines;vmsets;vmclones;vmmigrations;providers,verbs=create;update,versions=v1beta1,name=wdeprecations.kb.io,admissionReviewVersions=v1
+
+// DeprecationWebhookPath is the path the deprecation webhook is served at.
+const DeprecationWebhookPath = "/warn-infra-virtrigaud-io-v1beta1-deprecations"
+
+// DeprecationWarner is a validating webhook that never rejects anything: it
+// answers every create and update of an object using a field in
+// infrav1beta1.DeprecatedFields with a warning per use, which kubectl
+// prints, and counts the object in
+// virtrigaud_deprecated_field_usage_total.
+type DeprecationWarner struct {
+	Scheme *runtime.Scheme
+}
+
+var _ admission.Handler = &DeprecationWarner{}
+
+// SetupDeprecationWebhookWithManager registers the deprecation webhook with
+// the manager. It serves every kind that has deprecated fields under one
+// path, next to the kinds' own validating webhooks.
+func SetupDeprecationWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(DeprecationWebhookPath, &webhook.Admission{
+		Handler: &DeprecationWarner{Scheme: mgr.GetScheme()},
+	})
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (w *DeprecationWarner) Handle(ctx context.Context, req admission.Request) admission.Response {
+	allowed := admission.Allowed("")
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowed
+	}
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	obj, err := w.Scheme.New(gvk)
+	if err != nil {
+		return allowed
+	}
+	if err := admission.NewDecoder(w.Scheme).DecodeRaw(req.Object, obj); err != nil {
+		// The kind's own validation rejects what does not decode.
+		logf.FromContext(ctx).V(1).Info("Not checking object for deprecated fields", "kind", gvk.Kind, "error", err)
+		return allowed
+	}
+	if accessor, err := meta.Accessor(obj); err == nil && accessor.GetDeletionTimestamp() != nil {
+		return allowed
+	}
+
+	uses := infrav1beta1.FindDeprecations(obj)
+	warnings := make([]string, 0, len(uses))
+	counted := map[string]bool{}
+	for _, use := range uses {
+		warnings = append(warnings, use.Warning())
+		if !counted[use.Field] {
+			counted[use.Field] = true
+			metrics.RecordDeprecatedFieldUsage(use.Kind, use.Field)
+		}
+	}
+	return allowed.WithWarnings(warnings...)
+}
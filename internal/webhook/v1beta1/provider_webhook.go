@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-provider,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=providers,verbs=create;update,versions=v1beta1,name=vprovider.kb.io,admissionReviewVersions=v1
+
+// ProviderValidator rejects Providers whose credentialSecretRef points into
+// a namespace without a credential grant for theirs, and whose endpointRef
+// points into a namespace that does not admit them. The Provider
+// controller checks both again before using them.
+type ProviderValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &ProviderValidator{}
+
+// SetupProviderWebhookWithManager registers the Provider validating webhook
+// with the manager.
+func SetupProviderWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.Provider{}).
+		WithValidator(&ProviderValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *ProviderValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*infrav1beta1.Provider)
+	if !ok {
+		return nil, fmt.Errorf("expected a Provider but got %T", obj)
+	}
+	return nil, validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("Provider").GroupKind(),
+		o.Name, o.Namespace, providerRefs(o), nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *ProviderValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*infrav1beta1.Provider)
+	if !ok {
+		return nil, fmt.Errorf("expected a Provider but got %T", oldObj)
+	}
+	o, ok := newObj.(*infrav1beta1.Provider)
+	if !ok {
+		return nil, fmt.Errorf("expected a Provider but got %T", newObj)
+	}
+	if o.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return nil, validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("Provider").GroupKind(),
+		o.Name, o.Namespace, providerRefs(o), providerRefs(old))
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *ProviderValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// providerRefs lists the namespaces a Provider reaches into.
+func providerRefs(provider *infrav1beta1.Provider) []namespaceRef {
+	credentials := objectRef(field.NewPath("spec", "credentialSecretRef"), provider.Spec.CredentialSecretRef, provider.Namespace)
+	credentials.credentials = true
+	refs := []namespaceRef{credentials}
+	if ref := provider.Spec.EndpointRef; ref != nil {
+		refs = append(refs, namespaceRef{
+			path:      field.NewPath("spec", "endpointRef"),
+			namespace: k8s.ServiceRefNamespace(*ref, provider.Namespace),
+			name:      ref.ServiceName,
+		})
+	}
+	return refs
+}
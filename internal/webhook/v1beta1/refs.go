@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 holds the admission webhooks for the infra.virtrigaud.io
+// v1beta1 API.
+package v1beta1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+// namespaceRef is one field of an object that points into a namespace.
+type namespaceRef struct {
+	path      *field.Path
+	namespace string
+	name      string
+	// credentials marks a credential Secret reference, which needs the
+	// namespace's credential grant rather than the general one.
+	credentials bool
+}
+
+// objectRef returns the namespaceRef of an ObjectRef field on an object in
+// fromNamespace.
+func objectRef(path *field.Path, ref infrav1beta1.ObjectRef, fromNamespace string) namespaceRef {
+	return namespaceRef{path: path, namespace: k8s.RefNamespace(ref, fromNamespace), name: ref.Name}
+}
+
+// validateRefs rejects every reference in refs that points into a namespace
+// not admitting references from fromNamespace. References also present,
+// unchanged, in oldRefs are skipped, so revoking a grant does not block
+// unrelated updates such as finalizer removal.
+func validateRefs(ctx context.Context, c client.Reader, gk schema.GroupKind, name, fromNamespace string,
+	refs, oldRefs []namespaceRef) error {
+	unchanged := make(map[string]namespaceRef, len(oldRefs))
+	for _, ref := range oldRefs {
+		unchanged[ref.path.String()] = ref
+	}
+
+	var errs field.ErrorList
+	for _, ref := range refs {
+		if old, ok := unchanged[ref.path.String()]; ok && old.namespace == ref.namespace && old.name == ref.name {
+			continue
+		}
+		check := k8s.CheckNamespaceAccess
+		if ref.credentials {
+			check = k8s.CheckCredentialAccess
+		}
+		err := check(ctx, c, ref.path.String(), fromNamespace, ref.namespace, ref.name)
+		if k8s.IsCrossNamespaceRefDenied(err) {
+			errs = append(errs, field.Forbidden(ref.path, err.Error()))
+			continue
+		}
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(gk, name, errs)
+}
@@ -0,0 +1,509 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/capability"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+	"github.com/projectbeskar/virtrigaud/internal/vmtags"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-virtualmachine,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=virtualmachines,verbs=create;update,versions=v1beta1,name=vvirtualmachine.kb.io,admissionReviewVersions=v1
+
+// VirtualMachineValidator rejects VirtualMachines whose references point
+// into namespaces that do not admit them, namespace defaults naming objects
+// that do not exist, primary IP policies naming an invalid subnet, changes
+// to the ID of an adopted VM, tags the provider cannot hold, and VMs or disk
+// growth a VirtrigaudQuota of the namespace does not leave room for. It warns when an update waits for
+// a power cycle, and when the provider of a new or rebound VM does not
+// honor fields of its class or image.
+type VirtualMachineValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &VirtualMachineValidator{}
+
+// SetupVirtualMachineWebhookWithManager registers the VirtualMachine
+// validating webhook with the manager.
+func SetupVirtualMachineWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.VirtualMachine{}).
+		WithValidator(&VirtualMachineValidator{Client: mgr.GetAPIReader()}).
+		WithDefaulter(&VirtualMachineDefaulter{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infra-virtrigaud-io-v1beta1-virtualmachine,mutating=true,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=virtualmachines,verbs=create,versions=v1beta1,name=mvirtualmachine.kb.io,admissionReviewVersions=v1
+
+// VirtualMachineDefaulter fills in the providerRef, classRef and imageRef a
+// new VirtualMachine omits from the defaults annotated on its namespace
+// (see k8s.DefaultProviderAnnotation), and lists the fields it filled in
+// under k8s.DefaultedRefsAnnotation. Only creates are defaulted, so
+// changing a namespace's defaults leaves existing VMs as they are.
+type VirtualMachineDefaulter struct {
+	Client client.Reader
+}
+
+var _ admission.CustomDefaulter = &VirtualMachineDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *VirtualMachineDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	vm, ok := obj.(*infrav1beta1.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("expected a VirtualMachine but got %T", obj)
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	delete(vm.Annotations, k8s.DefaultedRefsAnnotation)
+
+	missingProvider, missingClass := vm.Spec.ProviderRef.Name == "", vm.Spec.ClassRef.Name == ""
+	missingImage := vm.Spec.ImageRef == nil && vm.Spec.ImportedDisk == nil && vm.Spec.AdoptExisting == nil
+	if !missingProvider && !missingClass && !missingImage {
+		return nil
+	}
+	defaults, err := k8s.GetNamespaceDefaults(ctx, d.Client, vm.Namespace)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	spec := field.NewPath("spec")
+	var defaulted []string
+	var errs field.ErrorList
+	switch {
+	case !missingProvider:
+	case defaults.ProviderRef != nil:
+		vm.Spec.ProviderRef = *defaults.ProviderRef
+		defaulted = append(defaulted, "providerRef")
+	default:
+		errs = append(errs, field.Required(spec.Child("providerRef"), fmt.Sprintf(
+			"set it, or annotate namespace %s with %s to give its VMs a default", vm.Namespace, k8s.DefaultProviderAnnotation)))
+	}
+	switch {
+	case !missingClass:
+	case defaults.ClassRef != nil:
+		vm.Spec.ClassRef = *defaults.ClassRef
+		defaulted = append(defaulted, "classRef")
+	default:
+		errs = append(errs, field.Required(spec.Child("classRef"), fmt.Sprintf(
+			"set it, or annotate namespace %s with %s to give its VMs a default", vm.Namespace, k8s.DefaultClassAnnotation)))
+	}
+	if missingImage && defaults.ImageRef != nil {
+		ref := *defaults.ImageRef
+		vm.Spec.ImageRef = &ref
+		defaulted = append(defaulted, "imageRef")
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(), vm.Name, errs)
+	}
+
+	if len(defaulted) > 0 {
+		if vm.Annotations == nil {
+			vm.Annotations = map[string]string{}
+		}
+		vm.Annotations[k8s.DefaultedRefsAnnotation] = strings.Join(defaulted, ",")
+	}
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *VirtualMachineValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vm, ok := obj.(*infrav1beta1.VirtualMachine)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualMachine but got %T", obj)
+	}
+	errs := validateNetworking(vm)
+	errs = append(errs, validateNetworkIPv6(vm)...)
+	errs = append(errs, validateGuestCustomization(vm)...)
+	errs = append(errs, validateUserData(vm)...)
+	errs = append(errs, v.validateTags(ctx, vm)...)
+	if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(), vm.Name, errs)
+	}
+	if err := validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(),
+		vm.Name, vm.Namespace, virtualMachineRefs(vm), nil); err != nil {
+		return nil, err
+	}
+	if err := validateDefaultedRefs(ctx, v.Client, vm); err != nil {
+		return nil, err
+	}
+	req, err := quota.VMRequest(ctx, v.Client, vm)
+	if err == nil {
+		err = quota.Check(ctx, v.Client, vm.Namespace, req, nil)
+	}
+	if err != nil {
+		return nil, quota.AdmissionError(infrav1beta1.GroupVersion.WithResource("virtualmachines").GroupResource(), vm.Name, err)
+	}
+	return v.portabilityWarnings(ctx, vm), nil
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *VirtualMachineValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldVM, ok := oldObj.(*infrav1beta1.VirtualMachine)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualMachine but got %T", oldObj)
+	}
+	vm, ok := newObj.(*infrav1beta1.VirtualMachine)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualMachine but got %T", newObj)
+	}
+	if vm.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	errs := validateNetworking(vm)
+	errs = append(errs, validateNetworkIPv6(vm)...)
+	errs = append(errs, validateGuestCustomization(vm)...)
+	errs = append(errs, validateUserData(vm)...)
+	errs = append(errs, validateAdoptExistingUpdate(oldVM, vm)...)
+	if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(), vm.Name, errs)
+	}
+	if err := validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(),
+		vm.Name, vm.Namespace, virtualMachineRefs(vm), virtualMachineRefs(oldVM)); err != nil {
+		return nil, err
+	}
+	req, err := quota.VMUpdateRequest(ctx, v.Client, oldVM, vm)
+	if err == nil {
+		err = quota.Check(ctx, v.Client, vm.Namespace, req, nil)
+	}
+	if err != nil {
+		return nil, quota.AdmissionError(infrav1beta1.GroupVersion.WithResource("virtualmachines").GroupResource(), vm.Name, err)
+	}
+	warnings := v.updateStrategyWarnings(ctx, oldVM, vm)
+	if !equality.Semantic.DeepEqual(oldVM.Spec.ProviderRef, vm.Spec.ProviderRef) ||
+		!equality.Semantic.DeepEqual(oldVM.Spec.ClassRef, vm.Spec.ClassRef) ||
+		!equality.Semantic.DeepEqual(oldVM.Spec.ImageRef, vm.Spec.ImageRef) {
+		warnings = append(warnings, v.portabilityWarnings(ctx, vm)...)
+	}
+	return warnings, nil
+}
+
+// updateStrategyWarnings tells the user when an update will not take effect
+// right away: CPU, memory or disk changes to a running VM whose provider
+// cannot apply them online wait for a power cycle, which spec.updateStrategy
+// decides on, and the apply-pending-changes annotation only acts under the
+// Manual strategy.
+func (v *VirtualMachineValidator) updateStrategyWarnings(ctx context.Context, oldVM, vm *infrav1beta1.VirtualMachine) admission.Warnings {
+	var warnings admission.Warnings
+	strategy := vm.Spec.UpdateStrategy
+	if strategy == "" {
+		strategy = infrav1beta1.VMUpdateStrategyLiveOnly
+	}
+	if _, ok := vm.Annotations[infrav1beta1.ApplyPendingChangesAnnotation]; ok && strategy != infrav1beta1.VMUpdateStrategyManual {
+		warnings = append(warnings, fmt.Sprintf("annotation %s has no effect unless spec.updateStrategy is Manual",
+			infrav1beta1.ApplyPendingChangesAnnotation))
+	}
+
+	if vm.Status.PowerState != infrav1beta1.PowerStateOn {
+		return warnings
+	}
+	resized := !equality.Semantic.DeepEqual(oldVM.Spec.Resources, vm.Spec.Resources)
+	grown := !equality.Semantic.DeepEqual(oldVM.Spec.Disks, vm.Spec.Disks)
+	if !resized && !grown {
+		return warnings
+	}
+	provider := &infrav1beta1.Provider{}
+	key := client.ObjectKey{Namespace: k8s.RefNamespace(vm.Spec.ProviderRef, vm.Namespace), Name: vm.Spec.ProviderRef.Name}
+	if err := v.Client.Get(ctx, key, provider); err != nil || provider.Status.ReportedCapabilities == nil {
+		return warnings
+	}
+	caps := provider.Status.ReportedCapabilities
+	if (!resized || caps.SupportsReconfigureOnline) && (!grown || caps.SupportsDiskExpansionOnline) {
+		return warnings
+	}
+	switch strategy {
+	case infrav1beta1.VMUpdateStrategyAutomatic:
+		warnings = append(warnings, "the provider cannot apply this change to a running VM; "+
+			"the VM will be shut down, reconfigured and powered back on")
+	case infrav1beta1.VMUpdateStrategyManual:
+		warnings = append(warnings, fmt.Sprintf("the provider cannot apply this change to a running VM; "+
+			"annotate the VM %s to power cycle it", infrav1beta1.ApplyPendingChangesAnnotation))
+	default:
+		warnings = append(warnings, "the provider cannot apply this change to a running VM; "+
+			"it stays pending until the VM is powered off (spec.updateStrategy is LiveOnly)")
+	}
+	return warnings
+}
+
+// portabilityWarnings lists the fields of the VM's class and image that its
+// provider ignores or cannot create a VM with. References that cannot be
+// read produce no warnings; the controller reports them.
+func (v *VirtualMachineValidator) portabilityWarnings(ctx context.Context, vm *infrav1beta1.VirtualMachine) admission.Warnings {
+	provider := &infrav1beta1.Provider{}
+	if err := k8s.GetRef(ctx, v.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
+		return nil
+	}
+	class := &infrav1beta1.VMClass{}
+	if err := k8s.GetRef(ctx, v.Client, "spec.classRef", vm.Spec.ClassRef, vm.Namespace, class); err != nil {
+		return nil
+	}
+	if err := vmclass.ResolveInto(ctx, v.Client, class); err != nil {
+		return nil
+	}
+	var image *infrav1beta1.VMImageSpec
+	if vm.Spec.ImageRef != nil {
+		vmImage := &infrav1beta1.VMImage{}
+		if err := k8s.GetRef(ctx, v.Client, "spec.imageRef", *vm.Spec.ImageRef, vm.Namespace, vmImage); err != nil {
+			return nil
+		}
+		image = &vmImage.Spec
+	}
+
+	var warnings admission.Warnings
+	for _, issue := range capability.CheckProvider(&class.Spec, image, provider) {
+		warnings = append(warnings, fmt.Sprintf("provider %s (%s): %s", provider.Name, provider.Spec.Type, issue))
+	}
+	return warnings
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *VirtualMachineValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// virtualMachineRefs lists the references the VirtualMachine controller
+// resolves.
+func virtualMachineRefs(vm *infrav1beta1.VirtualMachine) []namespaceRef {
+	spec := field.NewPath("spec")
+	refs := []namespaceRef{
+		objectRef(spec.Child("providerRef"), vm.Spec.ProviderRef, vm.Namespace),
+		objectRef(spec.Child("classRef"), vm.Spec.ClassRef, vm.Namespace),
+	}
+	if vm.Spec.ImageRef != nil {
+		refs = append(refs, objectRef(spec.Child("imageRef"), *vm.Spec.ImageRef, vm.Namespace))
+	}
+	for i, network := range vm.Spec.Networks {
+		if network.NetworkRef == nil {
+			continue
+		}
+		refs = append(refs, objectRef(spec.Child("networks").Index(i).Child("networkRef"), *network.NetworkRef, vm.Namespace))
+	}
+	return refs
+}
+
+// validateDefaultedRefs requires the references VirtualMachineDefaulter
+// filled in from the namespace defaults to exist. An explicit reference may
+// be created after the VM, but a default pointing at nothing is a stale
+// namespace annotation the VM would otherwise silently wait on.
+func validateDefaultedRefs(ctx context.Context, c client.Reader, vm *infrav1beta1.VirtualMachine) error {
+	value, ok := vm.Annotations[k8s.DefaultedRefsAnnotation]
+	if !ok {
+		return nil
+	}
+	spec := field.NewPath("spec")
+	var errs field.ErrorList
+	for _, name := range strings.Split(value, ",") {
+		var ref infrav1beta1.ObjectRef
+		var obj client.Object
+		var annotation string
+		switch name {
+		case "providerRef":
+			ref, obj, annotation = vm.Spec.ProviderRef, &infrav1beta1.Provider{}, k8s.DefaultProviderAnnotation
+		case "classRef":
+			ref, obj, annotation = vm.Spec.ClassRef, &infrav1beta1.VMClass{}, k8s.DefaultClassAnnotation
+		case "imageRef":
+			if vm.Spec.ImageRef == nil {
+				continue
+			}
+			ref, obj, annotation = *vm.Spec.ImageRef, &infrav1beta1.VMImage{}, k8s.DefaultImageAnnotation
+		default:
+			continue
+		}
+		key := k8s.RefKey(ref, vm.Namespace)
+		err := c.Get(ctx, key, obj)
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, field.NotFound(spec.Child(name), fmt.Sprintf(
+				"%s (from the %s annotation of namespace %s)", key, annotation, vm.Namespace)))
+			continue
+		}
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrav1beta1.GroupVersion.WithKind("VirtualMachine").GroupKind(), vm.Name, errs)
+}
+
+// validateNetworking checks the CIDR of a Subnet primary IP policy, which
+// the CRD pattern cannot.
+func validateNetworking(vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	if vm.Spec.Networking == nil {
+		return nil
+	}
+	policy := vm.Spec.Networking.PrimaryIPPolicy
+	cidr, ok := strings.CutPrefix(string(policy), infrav1beta1.PrimaryIPPolicySubnetPrefix)
+	if !ok {
+		return nil
+	}
+	if _, err := netip.ParsePrefix(cidr); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "networking", "primaryIPPolicy"), policy,
+			fmt.Sprintf("subnet must be a CIDR such as 10.0.0.0/24: %v", err))}
+	}
+	return nil
+}
+
+// validateNetworkIPv6 checks static IPv6 addresses, which the CRD cannot
+// describe with a pattern: the address needs a prefix length and both it
+// and the gateway must be IPv6.
+func validateNetworkIPv6(vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	var errs field.ErrorList
+	for i, network := range vm.Spec.Networks {
+		if network.IPv6 == nil {
+			continue
+		}
+		path := field.NewPath("spec", "networks").Index(i).Child("ipv6")
+		prefix, err := netip.ParsePrefix(network.IPv6.Address)
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(path.Child("address"), network.IPv6.Address,
+				fmt.Sprintf("must be an IPv6 address with a prefix length such as 2001:db8::10/64: %v", err)))
+		case !prefix.Addr().Is6() || prefix.Addr().Is4In6():
+			errs = append(errs, field.Invalid(path.Child("address"), network.IPv6.Address,
+				"must be an IPv6 address; set IPv4 addresses in ipAddress"))
+		case prefix.Addr().IsMulticast() || prefix.Addr().IsUnspecified() || prefix.Addr().IsLoopback():
+			errs = append(errs, field.Invalid(path.Child("address"), network.IPv6.Address,
+				"must be a unicast address"))
+		}
+		if gw := network.IPv6.Gateway; gw != "" {
+			addr, err := netip.ParseAddr(gw)
+			if err != nil || !addr.Is6() || addr.Is4In6() || addr.Zone() != "" {
+				errs = append(errs, field.Invalid(path.Child("gateway"), gw, "must be an IPv6 address such as 2001:db8::1"))
+			}
+		}
+	}
+	return errs
+}
+
+// validateTags checks the tags a new VM would be created with, its own and
+// its provider's defaults, against what the provider's backend can hold.
+// A provider that cannot be read is left to the controller, which checks
+// the tags again before creating the VM.
+func (v *VirtualMachineValidator) validateTags(ctx context.Context, vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	provider := &infrav1beta1.Provider{}
+	if err := k8s.GetRef(ctx, v.Client, "spec.providerRef", vm.Spec.ProviderRef, vm.Namespace, provider); err != nil {
+		return nil
+	}
+	var errs field.ErrorList
+	for i, tag := range vm.Spec.Tags {
+		if reason := vmtags.Check(provider.Spec.Type, tag); reason != "" {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "tags").Index(i), tag, reason))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := vmtags.Validate(provider.Spec.Type, vmtags.ForVM(provider, vm)); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "providerRef"), vm.Spec.ProviderRef.Name,
+			fmt.Sprintf("default tags of provider %s: %v", provider.Name, err)))
+	}
+	return errs
+}
+
+// validateGuestCustomization checks the combinations of guest
+// customization fields the CRD schema cannot express.
+func validateGuestCustomization(vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	gc := vm.Spec.GuestCustomization
+	if gc == nil {
+		return nil
+	}
+	path := field.NewPath("spec", "guestCustomization")
+	var errs field.ErrorList
+	if gc.Type != infrav1beta1.GuestCustomizationSysprep {
+		if gc.Sysprep != nil {
+			errs = append(errs, field.Forbidden(path.Child("sysprep"), "only allowed when type is Sysprep"))
+		}
+		return errs
+	}
+
+	if gc.Sysprep == nil {
+		return append(errs, field.Required(path.Child("sysprep"), "required when type is Sysprep"))
+	}
+	if vm.Spec.UserData != nil {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "userData"), "cannot be combined with Sysprep guest customization"))
+	}
+	if vm.Spec.MetaData != nil {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "metaData"), "cannot be combined with Sysprep guest customization"))
+	}
+	sp := gc.Sysprep
+	if u := sp.Unattend; u != nil {
+		if sp.Hostname != "" || sp.AdminPasswordSecretRef != nil || sp.Timezone != "" {
+			errs = append(errs, field.Forbidden(path.Child("sysprep", "unattend"),
+				"set unattend or hostname/adminPasswordSecretRef/timezone, not both"))
+		}
+		if (u.Inline == "") == (u.SecretRef == nil) {
+			errs = append(errs, field.Invalid(path.Child("sysprep", "unattend"), "",
+				"set exactly one of inline or secretRef"))
+		}
+	}
+	return errs
+}
+
+// maxInlineUserDataBytes caps spec.userData.cloudInit.inline. Larger
+// user-data belongs in a Secret, out of the VirtualMachine object every
+// client and etcd carry around.
+const maxInlineUserDataBytes = 256 << 10
+
+// validateUserData keeps inline cloud-init user-data under
+// maxInlineUserDataBytes.
+func validateUserData(vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	if vm.Spec.UserData == nil || vm.Spec.UserData.CloudInit == nil {
+		return nil
+	}
+	if n := len(vm.Spec.UserData.CloudInit.Inline); n > maxInlineUserDataBytes {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "userData", "cloudInit", "inline"),
+			fmt.Sprintf("is %d bytes, over the %d byte limit for inline user-data; "+
+				"put it in a Secret and reference it with spec.userData.cloudInit.secretRef and key", n, maxInlineUserDataBytes))}
+	}
+	return nil
+}
+
+// validateAdoptExistingUpdate keeps spec.adoptExisting.id fixed once set.
+// Pointing a VirtualMachine at a different hypervisor VM, or dropping the
+// adoption so deletion would destroy the VM, has to go through a new
+// object.
+func validateAdoptExistingUpdate(oldVM, vm *infrav1beta1.VirtualMachine) field.ErrorList {
+	if oldVM.Spec.AdoptExisting == nil || oldVM.Spec.AdoptExisting.ID == "" {
+		return nil
+	}
+	path := field.NewPath("spec", "adoptExisting")
+	if vm.Spec.AdoptExisting == nil {
+		return field.ErrorList{field.Forbidden(path, "cannot be removed once set")}
+	}
+	if vm.Spec.AdoptExisting.ID != oldVM.Spec.AdoptExisting.ID {
+		return field.ErrorList{field.Invalid(path.Child("id"), vm.Spec.AdoptExisting.ID, "field is immutable")}
+	}
+	return nil
+}
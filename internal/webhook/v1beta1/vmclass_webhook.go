@@ -0,0 +1,99 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/vmclass"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-vmclass,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=vmclasses,verbs=create;update,versions=v1beta1,name=vvmclass.kb.io,admissionReviewVersions=v1
+
+// VMClassValidator rejects VMClasses whose inheritFrom chain forms a cycle
+// or is longer than vmclass.MaxDepth. A parent that does not exist yet is
+// allowed with a warning; the VMClass controller reports it until it is
+// created.
+type VMClassValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &VMClassValidator{}
+
+// SetupVMClassWebhookWithManager registers the VMClass validating webhook
+// with the manager.
+func SetupVMClassWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.VMClass{}).
+		WithValidator(&VMClassValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *VMClassValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	class, ok := obj.(*infrav1beta1.VMClass)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMClass but got %T", obj)
+	}
+	return v.validateInheritance(ctx, class)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *VMClassValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	class, ok := newObj.(*infrav1beta1.VMClass)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMClass but got %T", newObj)
+	}
+	if class.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return v.validateInheritance(ctx, class)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *VMClassValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *VMClassValidator) validateInheritance(ctx context.Context, class *infrav1beta1.VMClass) (admission.Warnings, error) {
+	if class.Spec.InheritFrom == nil {
+		return nil, nil
+	}
+	_, err := vmclass.Resolve(ctx, v.Client, class)
+	switch {
+	case err == nil:
+		return nil, nil
+	case apierrors.IsNotFound(err):
+		return admission.Warnings{err.Error()}, nil
+	case errors.Is(err, vmclass.ErrInheritance):
+		return nil, apierrors.NewInvalid(infrav1beta1.GroupVersion.WithKind("VMClass").GroupKind(), class.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "inheritFrom", "name"), class.Spec.InheritFrom.Name, err.Error()),
+		})
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-vmclone,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=vmclones,verbs=create;update,versions=v1beta1,name=vvmclone.kb.io,admissionReviewVersions=v1
+
+// VMCloneValidator rejects VMClones that would create their target VM in a
+// namespace that does not admit it, and clones a VirtrigaudQuota of either
+// namespace does not leave room for.
+type VMCloneValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &VMCloneValidator{}
+
+// SetupVMCloneWebhookWithManager registers the VMClone validating webhook
+// with the manager.
+func SetupVMCloneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.VMClone{}).
+		WithValidator(&VMCloneValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *VMCloneValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*infrav1beta1.VMClone)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMClone but got %T", obj)
+	}
+	if err := validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VMClone").GroupKind(),
+		o.Name, o.Namespace, vmCloneRefs(o), nil); err != nil {
+		return nil, err
+	}
+	if err := v.checkQuota(ctx, o); err != nil {
+		return nil, quota.AdmissionError(infrav1beta1.GroupVersion.WithResource("vmclones").GroupResource(), o.Name, err)
+	}
+	return nil, nil
+}
+
+// checkQuota checks the clone against maxClonesInFlight of its namespace,
+// and its target VM against the quotas of the target namespace. Without a
+// source VM to size the target from, only the first check is possible; the
+// VMClone controller checks the target again before cloning.
+func (v *VMCloneValidator) checkQuota(ctx context.Context, clone *infrav1beta1.VMClone) error {
+	if err := quota.Check(ctx, v.Client, clone.Namespace, quota.Request{Clones: 1}, nil); err != nil {
+		return err
+	}
+	if clone.Spec.Source.VMRef == nil {
+		return nil
+	}
+	sourceVM := &infrav1beta1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: clone.Namespace, Name: clone.Spec.Source.VMRef.Name}
+	if err := v.Client.Get(ctx, key, sourceVM); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	req, err := quota.CloneTargetRequest(ctx, v.Client, clone, sourceVM)
+	if err != nil {
+		return err
+	}
+	targetNamespace := clone.Spec.Target.Namespace
+	if targetNamespace == "" {
+		targetNamespace = clone.Namespace
+	}
+	return quota.Check(ctx, v.Client, targetNamespace, req, nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *VMCloneValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*infrav1beta1.VMClone)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMClone but got %T", oldObj)
+	}
+	o, ok := newObj.(*infrav1beta1.VMClone)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMClone but got %T", newObj)
+	}
+	if o.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return nil, validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VMClone").GroupKind(),
+		o.Name, o.Namespace, vmCloneRefs(o), vmCloneRefs(old))
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *VMCloneValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// vmCloneRefs lists the namespaces a VMClone reaches into. The target VM is
+// created in spec.target.namespace, so that namespace must admit the clone.
+func vmCloneRefs(clone *infrav1beta1.VMClone) []namespaceRef {
+	if clone.Spec.Target.Namespace == "" {
+		return nil
+	}
+	return []namespaceRef{{
+		path:      field.NewPath("spec", "target", "namespace"),
+		namespace: clone.Spec.Target.Namespace,
+		name:      clone.Spec.Target.Name,
+	}}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-vmmigration,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=vmmigrations,verbs=create;update,versions=v1beta1,name=vvmmigration.kb.io,admissionReviewVersions=v1
+
+// VMMigrationValidator rejects VMMigrations whose providers or target namespace lie
+// in namespaces that do not admit them.
+type VMMigrationValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &VMMigrationValidator{}
+
+// SetupVMMigrationWebhookWithManager registers the VMMigration validating webhook
+// with the manager.
+func SetupVMMigrationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.VMMigration{}).
+		WithValidator(&VMMigrationValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *VMMigrationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*infrav1beta1.VMMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMMigration but got %T", obj)
+	}
+	return nil, validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VMMigration").GroupKind(),
+		o.Name, o.Namespace, vmMigrationRefs(o), nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *VMMigrationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*infrav1beta1.VMMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMMigration but got %T", oldObj)
+	}
+	o, ok := newObj.(*infrav1beta1.VMMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMMigration but got %T", newObj)
+	}
+	if o.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return nil, validateRefs(ctx, v.Client, infrav1beta1.GroupVersion.WithKind("VMMigration").GroupKind(),
+		o.Name, o.Namespace, vmMigrationRefs(o), vmMigrationRefs(old))
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *VMMigrationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// vmMigrationRefs lists the references the VMMigration controller resolves.
+func vmMigrationRefs(migration *infrav1beta1.VMMigration) []namespaceRef {
+	spec := field.NewPath("spec")
+	var refs []namespaceRef
+	if ref := migration.Spec.Source.ProviderRef; ref != nil {
+		refs = append(refs, objectRef(spec.Child("source", "providerRef"), *ref, migration.Namespace))
+	}
+	refs = append(refs, objectRef(spec.Child("target", "providerRef"), migration.Spec.Target.ProviderRef, migration.Namespace))
+	if ns := migration.Spec.Target.Namespace; ns != "" {
+		refs = append(refs, namespaceRef{
+			path:      spec.Child("target", "namespace"),
+			namespace: ns,
+			name:      migration.Spec.Target.Name,
+		})
+	}
+	return refs
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/quota"
+)
+
+// +kubebuilder:webhook:path=/validate-infra-virtrigaud-io-v1beta1-vmsnapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.virtrigaud.io,resources=vmsnapshots,verbs=create;update,versions=v1beta1,name=vvmsnapshot.kb.io,admissionReviewVersions=v1
+
+// VMSnapshotValidator rejects VMSnapshots a VirtrigaudQuota of the
+// namespace does not leave room for.
+type VMSnapshotValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &VMSnapshotValidator{}
+
+// SetupVMSnapshotWebhookWithManager registers the VMSnapshot validating
+// webhook with the manager.
+func SetupVMSnapshotWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1beta1.VMSnapshot{}).
+		WithValidator(&VMSnapshotValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *VMSnapshotValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	snapshot, ok := obj.(*infrav1beta1.VMSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a VMSnapshot but got %T", obj)
+	}
+	req := quota.Request{Snapshots: 1, SnapshotVM: snapshot.Spec.VMRef.Name}
+	if err := quota.Check(ctx, v.Client, snapshot.Namespace, req, nil); err != nil {
+		return nil, quota.AdmissionError(infrav1beta1.GroupVersion.WithResource("vmsnapshots").GroupResource(), snapshot.Name, err)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. Updates never add
+// snapshots, so there is nothing to check.
+func (v *VMSnapshotValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *VMSnapshotValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
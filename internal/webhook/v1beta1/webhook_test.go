@@ -0,0 +1,733 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/util/k8s"
+)
+
+func newWebhookClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared", Annotations: map[string]string{
+			k8s.AllowReferencesFromAnnotation: "team-a",
+		}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "private"}},
+	).WithObjects(objs...).Build()
+}
+
+func testVM(providerNamespace string) *infrav1beta1.VirtualMachine {
+	return &infrav1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "team-a"},
+		Spec: infrav1beta1.VirtualMachineSpec{
+			ProviderRef: infrav1beta1.ObjectRef{Name: "p", Namespace: providerNamespace},
+			ClassRef:    infrav1beta1.ObjectRef{Name: "small"},
+		},
+	}
+}
+
+func TestVirtualMachineValidator_Create(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+
+	for _, ns := range []string{"", "team-a", "shared"} {
+		_, err := v.ValidateCreate(ctx, testVM(ns))
+		assert.NoError(t, err, "provider namespace %q", ns)
+	}
+
+	_, err := v.ValidateCreate(ctx, testVM("private"))
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.providerRef")
+	assert.Contains(t, err.Error(), k8s.AllowReferencesFromAnnotation)
+
+	vm := testVM("")
+	vm.Spec.Networks = []infrav1beta1.VMNetworkRef{
+		{Name: "ok"},
+		{Name: "bad", NetworkRef: &infrav1beta1.ObjectRef{Name: "net", Namespace: "private"}},
+	}
+	_, err = v.ValidateCreate(ctx, vm)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.networks[1].networkRef")
+}
+
+func TestVirtualMachineValidator_Update(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+
+	// A reference admitted when the VM was created keeps working after the
+	// grant is revoked, so unrelated edits are not blocked.
+	old := testVM("private")
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"edited": "true"}
+	_, err := v.ValidateUpdate(ctx, old, updated)
+	assert.NoError(t, err)
+
+	changed := testVM("")
+	moved := changed.DeepCopy()
+	moved.Spec.ProviderRef.Namespace = "private"
+	_, err = v.ValidateUpdate(ctx, changed, moved)
+	assert.True(t, apierrors.IsInvalid(err))
+
+	now := metav1.Now()
+	moved.DeletionTimestamp = &now
+	_, err = v.ValidateUpdate(ctx, changed, moved)
+	assert.NoError(t, err)
+}
+
+func TestVirtualMachineValidator_AdoptExistingImmutable(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+
+	plain := testVM("")
+	adopted := plain.DeepCopy()
+	adopted.Spec.AdoptExisting = &infrav1beta1.AdoptExistingSpec{ID: "10042"}
+	_, err := v.ValidateUpdate(ctx, plain, adopted)
+	assert.NoError(t, err, "setting adoptExisting for the first time")
+
+	policy := adopted.DeepCopy()
+	policy.Spec.AdoptExisting.DeletionPolicy = infrav1beta1.AdoptDeletionPolicyDelete
+	_, err = v.ValidateUpdate(ctx, adopted, policy)
+	assert.NoError(t, err, "changing the deletion policy")
+
+	retargeted := adopted.DeepCopy()
+	retargeted.Spec.AdoptExisting.ID = "10043"
+	_, err = v.ValidateUpdate(ctx, adopted, retargeted)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.adoptExisting.id")
+
+	_, err = v.ValidateUpdate(ctx, adopted, plain)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.adoptExisting")
+}
+
+func TestVirtualMachineValidator_PrimaryIPPolicy(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+
+	for _, policy := range []infrav1beta1.PrimaryIPPolicy{"", "FirstIPv6", "Subnet:10.0.0.0/24", "Subnet:2001:db8::/64"} {
+		vm := testVM("")
+		vm.Spec.Networking = &infrav1beta1.VMNetworkingSpec{PrimaryIPPolicy: policy}
+		_, err := v.ValidateCreate(ctx, vm)
+		assert.NoError(t, err, "policy %q", policy)
+	}
+
+	vm := testVM("")
+	vm.Spec.Networking = &infrav1beta1.VMNetworkingSpec{PrimaryIPPolicy: "Subnet:10.0.0.0"}
+	_, err := v.ValidateCreate(ctx, vm)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.networking.primaryIPPolicy")
+
+	_, err = v.ValidateUpdate(ctx, testVM(""), vm)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+}
+
+func TestVirtualMachineValidator_NetworkIPv6(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+	withIPv6 := func(address, gateway string) *infrav1beta1.VirtualMachine {
+		vm := testVM("")
+		vm.Spec.Networks = []infrav1beta1.VMNetworkRef{{
+			Name:      "lan",
+			IPAddress: "10.0.0.5",
+			IPv6:      &infrav1beta1.IPv6StaticConfig{Address: address, Gateway: gateway},
+		}}
+		return vm
+	}
+
+	_, err := v.ValidateCreate(ctx, withIPv6("2001:db8::10/64", "fe80::1"))
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		vm   *infrav1beta1.VirtualMachine
+		path string
+	}{
+		"no prefix length": {withIPv6("2001:db8::10", ""), "spec.networks[0].ipv6.address"},
+		"IPv4 address":     {withIPv6("10.0.0.6/24", ""), "spec.networks[0].ipv6.address"},
+		"multicast":        {withIPv6("ff02::1/64", ""), "spec.networks[0].ipv6.address"},
+		"IPv4 gateway":     {withIPv6("2001:db8::10/64", "10.0.0.1"), "spec.networks[0].ipv6.gateway"},
+	}
+	for name, tc := range cases {
+		_, err := v.ValidateCreate(ctx, tc.vm)
+		require.Error(t, err, name)
+		assert.True(t, apierrors.IsInvalid(err), name)
+		assert.Contains(t, err.Error(), tc.path, name)
+	}
+}
+
+func TestVirtualMachineValidator_Tags(t *testing.T) {
+	proxmox := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeProxmox, DefaultTags: []string{"cost-center-42"}},
+	}
+	v := &VirtualMachineValidator{Client: newWebhookClient(t, proxmox)}
+	ctx := context.Background()
+	withTags := func(tags ...string) *infrav1beta1.VirtualMachine {
+		vm := testVM("")
+		vm.Spec.Tags = tags
+		return vm
+	}
+
+	_, err := v.ValidateCreate(ctx, withTags("web", "env.prod"))
+	assert.NoError(t, err)
+
+	for _, tag := range []string{"Web", "env=prod", "-web"} {
+		_, err := v.ValidateCreate(ctx, withTags("web", tag))
+		require.Error(t, err, tag)
+		assert.True(t, apierrors.IsInvalid(err), tag)
+		assert.Contains(t, err.Error(), "spec.tags[1]", tag)
+	}
+
+	// Defaults the CRD would reject on a Proxmox provider are caught too.
+	proxmox.Spec.DefaultLabels = map[string]string{"env": "prod"}
+	v = &VirtualMachineValidator{Client: newWebhookClient(t, proxmox)}
+	_, err = v.ValidateCreate(ctx, withTags("web"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "default tags of provider p")
+
+	// Other backends hold key=value tags.
+	libvirt := proxmox.DeepCopy()
+	libvirt.Spec.Type = infrav1beta1.ProviderTypeLibvirt
+	v = &VirtualMachineValidator{Client: newWebhookClient(t, libvirt)}
+	_, err = v.ValidateCreate(ctx, withTags("env=dev", "Web"))
+	assert.NoError(t, err)
+}
+
+func TestVirtualMachineValidator_GuestCustomization(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+	sysprep := func(sp *infrav1beta1.SysprepSpec) *infrav1beta1.VirtualMachine {
+		vm := testVM("")
+		vm.Spec.GuestCustomization = &infrav1beta1.GuestCustomization{
+			Type:    infrav1beta1.GuestCustomizationSysprep,
+			Sysprep: sp,
+		}
+		return vm
+	}
+
+	for name, vm := range map[string]*infrav1beta1.VirtualMachine{
+		"basic fields": sysprep(&infrav1beta1.SysprepSpec{Hostname: "web01", Timezone: "UTC"}),
+		"inline unattend": sysprep(&infrav1beta1.SysprepSpec{
+			Unattend: &infrav1beta1.UnattendDocument{Inline: "<unattend/>"},
+		}),
+	} {
+		_, err := v.ValidateCreate(ctx, vm)
+		assert.NoError(t, err, name)
+	}
+
+	withUserData := sysprep(&infrav1beta1.SysprepSpec{})
+	withUserData.Spec.UserData = &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{Inline: "#cloud-config"}}
+	underCloudInit := sysprep(&infrav1beta1.SysprepSpec{})
+	underCloudInit.Spec.GuestCustomization.Type = infrav1beta1.GuestCustomizationCloudInit
+
+	cases := map[string]struct {
+		vm   *infrav1beta1.VirtualMachine
+		path string
+	}{
+		"missing sysprep": {sysprep(nil), "spec.guestCustomization.sysprep"},
+		"unattend with basic fields": {sysprep(&infrav1beta1.SysprepSpec{
+			Hostname: "web01",
+			Unattend: &infrav1beta1.UnattendDocument{Inline: "<unattend/>"},
+		}), "spec.guestCustomization.sysprep.unattend"},
+		"empty unattend": {sysprep(&infrav1beta1.SysprepSpec{
+			Unattend: &infrav1beta1.UnattendDocument{},
+		}), "spec.guestCustomization.sysprep.unattend"},
+		"sysprep with userData":   {withUserData, "spec.userData"},
+		"sysprep under CloudInit": {underCloudInit, "spec.guestCustomization.sysprep"},
+	}
+	for name, tc := range cases {
+		_, err := v.ValidateCreate(ctx, tc.vm)
+		require.Error(t, err, name)
+		assert.True(t, apierrors.IsInvalid(err), name)
+		assert.Contains(t, err.Error(), tc.path, name)
+	}
+}
+
+func TestVirtualMachineValidator_InlineUserDataSize(t *testing.T) {
+	v := &VirtualMachineValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+	withInline := func(size int) *infrav1beta1.VirtualMachine {
+		vm := testVM("")
+		vm.Spec.UserData = &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{
+			Inline: "#cloud-config\n" + strings.Repeat("#", size-len("#cloud-config\n")),
+		}}
+		return vm
+	}
+
+	_, err := v.ValidateCreate(ctx, withInline(maxInlineUserDataBytes))
+	assert.NoError(t, err, "inline user-data at the limit")
+
+	tooLarge := withInline(maxInlineUserDataBytes + 1)
+	_, err = v.ValidateCreate(ctx, tooLarge)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.userData.cloudInit.inline")
+	assert.Contains(t, err.Error(), "spec.userData.cloudInit.secretRef")
+
+	_, err = v.ValidateUpdate(ctx, testVM(""), tooLarge)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+
+	fromSecret := testVM("")
+	fromSecret.Spec.UserData = &infrav1beta1.UserData{CloudInit: &infrav1beta1.CloudInit{
+		SecretRef: &infrav1beta1.LocalObjectReference{Name: "web-user-data"},
+		Key:       "user-data",
+	}}
+	_, err = v.ValidateCreate(ctx, fromSecret)
+	assert.NoError(t, err, "user-data from a Secret")
+}
+
+func TestVMCloneValidator(t *testing.T) {
+	v := &VMCloneValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+	clone := func(targetNamespace string) *infrav1beta1.VMClone {
+		return &infrav1beta1.VMClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "team-a"},
+			Spec: infrav1beta1.VMCloneSpec{
+				Target: infrav1beta1.VMCloneTarget{Name: "copy", Namespace: targetNamespace},
+			},
+		}
+	}
+
+	for _, ns := range []string{"", "team-a", "shared"} {
+		_, err := v.ValidateCreate(ctx, clone(ns))
+		assert.NoError(t, err, "target namespace %q", ns)
+	}
+	for _, ns := range []string{"private", "missing"} {
+		_, err := v.ValidateCreate(ctx, clone(ns))
+		require.Error(t, err, "target namespace %q", ns)
+		assert.Contains(t, err.Error(), "spec.target.namespace")
+	}
+}
+
+func TestVMMigrationValidator(t *testing.T) {
+	v := &VMMigrationValidator{Client: newWebhookClient(t)}
+	ctx := context.Background()
+	migration := func(sourceNamespace, targetNamespace string) *infrav1beta1.VMMigration {
+		return &infrav1beta1.VMMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "team-a"},
+			Spec: infrav1beta1.VMMigrationSpec{
+				Source: infrav1beta1.MigrationSource{
+					VMRef:       infrav1beta1.LocalObjectReference{Name: "vm"},
+					ProviderRef: &infrav1beta1.ObjectRef{Name: "src", Namespace: sourceNamespace},
+				},
+				Target: infrav1beta1.MigrationTarget{
+					Name:        "vm",
+					Namespace:   targetNamespace,
+					ProviderRef: infrav1beta1.ObjectRef{Name: "dst", Namespace: "shared"},
+				},
+			},
+		}
+	}
+
+	_, err := v.ValidateCreate(ctx, migration("", "shared"))
+	assert.NoError(t, err)
+
+	_, err = v.ValidateCreate(ctx, migration("private", "private"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.source.providerRef")
+	assert.Contains(t, err.Error(), "spec.target.namespace")
+	assert.NotContains(t, err.Error(), "spec.target.providerRef")
+}
+
+func TestProviderValidator(t *testing.T) {
+	vault := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vault", Annotations: map[string]string{
+		k8s.AllowCredentialReferencesFromAnnotation: "team-a",
+	}}}
+	v := &ProviderValidator{Client: newWebhookClient(t, vault)}
+	ctx := context.Background()
+	provider := func(credentialsNamespace string) *infrav1beta1.Provider {
+		return &infrav1beta1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+			Spec: infrav1beta1.ProviderSpec{
+				Type:                infrav1beta1.ProviderTypeVSphere,
+				Endpoint:            "https://vcenter.example.com",
+				CredentialSecretRef: infrav1beta1.ObjectRef{Name: "creds", Namespace: credentialsNamespace},
+			},
+		}
+	}
+
+	for _, ns := range []string{"", "team-a", "vault"} {
+		_, err := v.ValidateCreate(ctx, provider(ns))
+		assert.NoError(t, err, "credentials namespace %q", ns)
+	}
+	// "shared" admits references from team-a, but not to its credentials.
+	for _, ns := range []string{"shared", "private", "missing"} {
+		_, err := v.ValidateCreate(ctx, provider(ns))
+		require.Error(t, err, "credentials namespace %q", ns)
+		assert.Contains(t, err.Error(), "spec.credentialSecretRef")
+		assert.Contains(t, err.Error(), k8s.AllowCredentialReferencesFromAnnotation)
+	}
+
+	withEndpointRef := provider("")
+	withEndpointRef.Spec.Endpoint = ""
+	withEndpointRef.Spec.EndpointRef = &infrav1beta1.ServiceEndpointRef{ServiceName: "vcsa", Namespace: "private"}
+	_, err := v.ValidateCreate(ctx, withEndpointRef)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.endpointRef")
+	withEndpointRef.Spec.EndpointRef.Namespace = "shared"
+	_, err = v.ValidateCreate(ctx, withEndpointRef)
+	assert.NoError(t, err)
+
+	// A grant revoked after creation does not block unrelated updates.
+	old := provider("private")
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"team": "a"}
+	_, err = v.ValidateUpdate(ctx, old, updated)
+	assert.NoError(t, err)
+}
+
+func TestValidatorsEnforceQuota(t *testing.T) {
+	quota := &infrav1beta1.VirtrigaudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "team-a"},
+		Spec: infrav1beta1.VirtrigaudQuotaSpec{
+			MaxVMs:            ptr.To[int32](1),
+			MaxTotalSnapshots: ptr.To[int32](1),
+			MaxClonesInFlight: ptr.To[int32](1),
+			MaxTotalDiskGiB:   ptr.To[int64](50),
+		},
+	}
+	existing := testVM("")
+	snapshot := &infrav1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "team-a"},
+		Spec:       infrav1beta1.VMSnapshotSpec{VMRef: infrav1beta1.LocalObjectReference{Name: "vm"}},
+	}
+	c := newWebhookClient(t, quota, existing, snapshot)
+	ctx := context.Background()
+
+	second := testVM("")
+	second.Name = "vm-2"
+	_, err := (&VirtualMachineValidator{Client: c}).ValidateCreate(ctx, second)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.Contains(t, err.Error(), "maxVMs")
+
+	grown := existing.DeepCopy()
+	grown.Spec.Disks = []infrav1beta1.DiskSpec{{Name: "data", SizeGiB: 60}}
+	_, err = (&VirtualMachineValidator{Client: c}).ValidateUpdate(ctx, existing, grown)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maxTotalDiskGiB")
+	grown.Spec.Disks[0].SizeGiB = 50
+	_, err = (&VirtualMachineValidator{Client: c}).ValidateUpdate(ctx, existing, grown)
+	assert.NoError(t, err, "growth up to the limit is allowed")
+
+	another := snapshot.DeepCopy()
+	another.Name = "weekly"
+	_, err = (&VMSnapshotValidator{Client: c}).ValidateCreate(ctx, another)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.Contains(t, err.Error(), "maxTotalSnapshots")
+	_, err = (&VMSnapshotValidator{Client: c}).ValidateUpdate(ctx, snapshot, snapshot)
+	assert.NoError(t, err, "updates add no snapshots")
+
+	clone := &infrav1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "copy", Namespace: "team-a"},
+		Spec: infrav1beta1.VMCloneSpec{
+			Source: infrav1beta1.CloneSource{VMRef: &infrav1beta1.LocalObjectReference{Name: "vm"}},
+			Target: infrav1beta1.VMCloneTarget{Name: "copy"},
+		},
+	}
+	_, err = (&VMCloneValidator{Client: c}).ValidateCreate(ctx, clone)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maxVMs", "the target VM counts against the target namespace")
+
+	clone.Spec.Target.Namespace = "shared"
+	_, err = (&VMCloneValidator{Client: c}).ValidateCreate(ctx, clone)
+	assert.NoError(t, err, "the shared namespace has no quota")
+}
+
+func TestVMClassValidator(t *testing.T) {
+	class := func(name, parent string) *infrav1beta1.VMClass {
+		return &infrav1beta1.VMClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec:       infrav1beta1.VMClassSpec{InheritFrom: &infrav1beta1.LocalObjectReference{Name: parent}},
+		}
+	}
+	base := class("base", "")
+	base.Spec.InheritFrom = nil
+	v := &VMClassValidator{Client: newWebhookClient(t, base, class("large", "base"))}
+	ctx := context.Background()
+
+	warnings, err := v.ValidateCreate(ctx, class("xlarge", "large"))
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	warnings, err = v.ValidateCreate(ctx, class("gpu", "missing"))
+	assert.NoError(t, err, "the parent may be created later")
+	assert.NotEmpty(t, warnings)
+
+	_, err = v.ValidateUpdate(ctx, base, class("base", "large"))
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.inheritFrom.name")
+	assert.Contains(t, err.Error(), "base -> large -> base")
+}
+
+func TestVirtualMachineValidator_PortabilityWarnings(t *testing.T) {
+	provider := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec:       infrav1beta1.ProviderSpec{Type: infrav1beta1.ProviderTypeProxmox},
+	}
+	base := &infrav1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "vsphere-base", Namespace: "team-a"},
+		Spec: infrav1beta1.VMClassSpec{
+			CPU: 2, Memory: resource.MustParse("4Gi"),
+			DiskDefaults: &infrav1beta1.DiskDefaults{Type: infrav1beta1.DiskTypeEagerZeroedThick},
+		},
+	}
+	small := &infrav1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "team-a"},
+		Spec:       infrav1beta1.VMClassSpec{InheritFrom: &infrav1beta1.LocalObjectReference{Name: "vsphere-base"}},
+	}
+	v := &VirtualMachineValidator{Client: newWebhookClient(t, provider, base, small)}
+	ctx := context.Background()
+
+	warnings, err := v.ValidateCreate(ctx, testVM(""))
+	require.NoError(t, err)
+	require.Len(t, warnings, 1, "inherited fields are checked")
+	assert.Equal(t, "provider p (proxmox): VMClass spec.diskDefaults.type (thick, eagerzeroedthick): ignored by proxmox providers", warnings[0])
+
+	// Only a change of provider, class or image warns again.
+	old := testVM("")
+	edited := old.DeepCopy()
+	edited.Labels = map[string]string{"edited": "true"}
+	warnings, err = v.ValidateUpdate(ctx, old, edited)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	rebound := old.DeepCopy()
+	rebound.Spec.ClassRef.Name = "vsphere-base"
+	warnings, err = v.ValidateUpdate(ctx, old, rebound)
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	// References that cannot be read are left to the controller.
+	missing := testVM("")
+	missing.Spec.ClassRef.Name = "missing"
+	warnings, err = v.ValidateCreate(ctx, missing)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestVirtualMachineValidator_UpdateStrategyWarnings(t *testing.T) {
+	provider := &infrav1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Status: infrav1beta1.ProviderStatus{ReportedCapabilities: &infrav1beta1.ReportedCapabilities{
+			SupportsDiskExpansionOnline: true,
+		}},
+	}
+	v := &VirtualMachineValidator{Client: newWebhookClient(t, provider)}
+	ctx := context.Background()
+
+	old := testVM("")
+	old.Status.PowerState = infrav1beta1.PowerStateOn
+	resized := old.DeepCopy()
+	resized.Spec.Resources = &infrav1beta1.VirtualMachineResources{CPU: ptr.To[int32](8)}
+
+	warnings, err := v.ValidateUpdate(ctx, old, resized)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "LiveOnly")
+
+	resized.Spec.UpdateStrategy = infrav1beta1.VMUpdateStrategyAutomatic
+	warnings, err = v.ValidateUpdate(ctx, old, resized)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "powered back on")
+
+	// Disks the provider grows online, and a stopped VM, need no power cycle.
+	grown := old.DeepCopy()
+	grown.Spec.Disks = []infrav1beta1.DiskSpec{{Name: "data", SizeGiB: 20}}
+	warnings, err = v.ValidateUpdate(ctx, old, grown)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	stopped := old.DeepCopy()
+	stopped.Status.PowerState = infrav1beta1.PowerStateOff
+	resized.Status.PowerState = infrav1beta1.PowerStateOff
+	warnings, err = v.ValidateUpdate(ctx, stopped, resized)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	annotated := old.DeepCopy()
+	annotated.Annotations = map[string]string{infrav1beta1.ApplyPendingChangesAnnotation: ""}
+	warnings, err = v.ValidateUpdate(ctx, old, annotated)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Manual")
+	annotated.Spec.UpdateStrategy = infrav1beta1.VMUpdateStrategyManual
+	warnings, err = v.ValidateUpdate(ctx, old, annotated)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestVirtualMachineDefaulter(t *testing.T) {
+	tenant := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Annotations: map[string]string{
+		k8s.DefaultProviderAnnotation: "shared/pve",
+		k8s.DefaultClassAnnotation:    "small",
+		k8s.DefaultImageAnnotation:    "ubuntu",
+	}}}
+	bare := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bare"}}
+	d := &VirtualMachineDefaulter{Client: newWebhookClient(t, tenant, bare)}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create},
+	})
+	newVM := func(ns string) *infrav1beta1.VirtualMachine {
+		return &infrav1beta1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: ns}}
+	}
+
+	t.Run("fills in what is omitted", func(t *testing.T) {
+		vm := newVM("tenant")
+		require.NoError(t, d.Default(ctx, vm))
+		assert.Equal(t, infrav1beta1.ObjectRef{Name: "pve", Namespace: "shared"}, vm.Spec.ProviderRef)
+		assert.Equal(t, infrav1beta1.ObjectRef{Name: "small"}, vm.Spec.ClassRef)
+		assert.Equal(t, &infrav1beta1.ObjectRef{Name: "ubuntu"}, vm.Spec.ImageRef)
+		assert.Equal(t, "providerRef,classRef,imageRef", vm.Annotations[k8s.DefaultedRefsAnnotation])
+	})
+
+	t.Run("explicit references override the defaults", func(t *testing.T) {
+		vm := newVM("tenant")
+		vm.Spec.ProviderRef = infrav1beta1.ObjectRef{Name: "vsphere"}
+		vm.Spec.ImportedDisk = &infrav1beta1.ImportedDiskRef{DiskID: "disk-1"}
+		// A defaulted-refs annotation the user set is not trusted.
+		vm.Annotations = map[string]string{k8s.DefaultedRefsAnnotation: "providerRef"}
+		require.NoError(t, d.Default(ctx, vm))
+		assert.Equal(t, infrav1beta1.ObjectRef{Name: "vsphere"}, vm.Spec.ProviderRef)
+		assert.Equal(t, infrav1beta1.ObjectRef{Name: "small"}, vm.Spec.ClassRef)
+		assert.Nil(t, vm.Spec.ImageRef, "an imported disk needs no image")
+		assert.Equal(t, "classRef", vm.Annotations[k8s.DefaultedRefsAnnotation])
+
+		complete := testVM("")
+		complete.Namespace = "tenant"
+		complete.Spec.ImageRef = &infrav1beta1.ObjectRef{Name: "debian"}
+		require.NoError(t, d.Default(ctx, complete))
+		assert.NotContains(t, complete.Annotations, k8s.DefaultedRefsAnnotation)
+	})
+
+	t.Run("missing defaults say how to set them", func(t *testing.T) {
+		err := d.Default(ctx, newVM("bare"))
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.providerRef")
+		assert.Contains(t, err.Error(), k8s.DefaultProviderAnnotation)
+		assert.Contains(t, err.Error(), "spec.classRef")
+		assert.Contains(t, err.Error(), k8s.DefaultClassAnnotation)
+	})
+
+	t.Run("updates are left alone", func(t *testing.T) {
+		update := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update},
+		})
+		vm := testVM("")
+		vm.Namespace = "tenant"
+		require.NoError(t, d.Default(update, vm))
+		assert.Nil(t, vm.Spec.ImageRef, "a changed default must not reach existing VMs")
+	})
+
+	t.Run("dry-run VMs are defaulted like any other", func(t *testing.T) {
+		vm := newVM("tenant")
+		vm.Annotations = map[string]string{"virtrigaud.io/dry-run": "true"}
+		require.NoError(t, d.Default(ctx, vm))
+		assert.Equal(t, "pve", vm.Spec.ProviderRef.Name)
+		assert.Equal(t, "true", vm.Annotations["virtrigaud.io/dry-run"])
+		assert.Equal(t, "providerRef,classRef,imageRef", vm.Annotations[k8s.DefaultedRefsAnnotation])
+	})
+}
+
+func TestVirtualMachineValidator_DefaultedRefsMustExist(t *testing.T) {
+	provider := &infrav1beta1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"}}
+	v := &VirtualMachineValidator{Client: newWebhookClient(t, provider)}
+	ctx := context.Background()
+
+	vm := testVM("")
+	vm.Annotations = map[string]string{k8s.DefaultedRefsAnnotation: "providerRef,classRef"}
+	_, err := v.ValidateCreate(ctx, vm)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.classRef")
+	assert.Contains(t, err.Error(), k8s.DefaultClassAnnotation)
+	assert.NotContains(t, err.Error(), "spec.providerRef")
+
+	// References the user wrote may be created after the VM.
+	delete(vm.Annotations, k8s.DefaultedRefsAnnotation)
+	_, err = v.ValidateCreate(ctx, vm)
+	assert.NoError(t, err)
+}
+
+func TestDeprecationWarner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1beta1.AddToScheme(scheme))
+	w := &DeprecationWarner{Scheme: scheme}
+	request := func(op admissionv1.Operation, obj runtime.Object, kind string) admission.Request {
+		raw, err := json.Marshal(obj)
+		require.NoError(t, err)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: infrav1beta1.GroupVersion.Group, Version: "v1beta1", Kind: kind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	vm := testVM("")
+	vm.Spec.Networks = []infrav1beta1.VMNetworkRef{{Name: "vmbr0"}, {Name: "lan"}}
+	resp := w.Handle(context.Background(), request(admissionv1.Create, vm, "VirtualMachine"))
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Warnings, 2)
+	assert.Contains(t, resp.Warnings[0], "spec.networks[0].name")
+	assert.Contains(t, resp.Warnings[0], "networkRef")
+
+	provider := &infrav1beta1.Provider{Spec: infrav1beta1.ProviderSpec{InsecureSkipVerify: true}}
+	resp = w.Handle(context.Background(), request(admissionv1.Update, provider, "Provider"))
+	assert.True(t, resp.Allowed)
+	assert.Len(t, resp.Warnings, 1)
+
+	vm.DeletionTimestamp = ptr.To(metav1.Now())
+	resp = w.Handle(context.Background(), request(admissionv1.Update, vm, "VirtualMachine"))
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Warnings, "objects being deleted are not warned about")
+
+	resp = w.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Kind:      metav1.GroupVersionKind{Group: infrav1beta1.GroupVersion.Group, Version: "v1beta1", Kind: "VirtualMachine"},
+		Object:    runtime.RawExtension{Raw: []byte("not json")},
+	}})
+	assert.True(t, resp.Allowed, "the webhook never rejects a request")
+}
@@ -29,6 +29,7 @@ const (
 	PowerOp_POWER_OP_OFF               PowerOp = 2
 	PowerOp_POWER_OP_REBOOT            PowerOp = 3
 	PowerOp_POWER_OP_SHUTDOWN_GRACEFUL PowerOp = 4 // Graceful shutdown using guest tools
+	PowerOp_POWER_OP_RESET             PowerOp = 5 // Hard reset, without involving the guest
 )
 
 // Enum value maps for PowerOp.
@@ -39,6 +40,7 @@ var (
 		2: "POWER_OP_OFF",
 		3: "POWER_OP_REBOOT",
 		4: "POWER_OP_SHUTDOWN_GRACEFUL",
+		5: "POWER_OP_RESET",
 	}
 	PowerOp_value = map[string]int32{
 		"POWER_OP_UNSPECIFIED":       0,
@@ -46,6 +48,7 @@ var (
 		"POWER_OP_OFF":               2,
 		"POWER_OP_REBOOT":            3,
 		"POWER_OP_SHUTDOWN_GRACEFUL": 4,
+		"POWER_OP_RESET":             5,
 	}
 )
 
@@ -76,6 +79,169 @@ func (PowerOp) EnumDescriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{0}
 }
 
+// Whether a snapshot was taken with the guest's filesystems quiesced
+type QuiesceOutcome int32
+
+const (
+	QuiesceOutcome_QUIESCE_OUTCOME_UNSPECIFIED QuiesceOutcome = 0 // Not reported: the provider predates quiesce support
+	QuiesceOutcome_QUIESCE_OUTCOME_ACHIEVED    QuiesceOutcome = 1 // The guest's filesystems were frozen for the snapshot
+	QuiesceOutcome_QUIESCE_OUTCOME_DEGRADED    QuiesceOutcome = 2 // Quiesce was requested but not possible; the snapshot is crash-consistent
+	QuiesceOutcome_QUIESCE_OUTCOME_SKIPPED     QuiesceOutcome = 3 // Quiesce was not requested, or not needed: the VM was off or its memory was captured
+)
+
+// Enum value maps for QuiesceOutcome.
+var (
+	QuiesceOutcome_name = map[int32]string{
+		0: "QUIESCE_OUTCOME_UNSPECIFIED",
+		1: "QUIESCE_OUTCOME_ACHIEVED",
+		2: "QUIESCE_OUTCOME_DEGRADED",
+		3: "QUIESCE_OUTCOME_SKIPPED",
+	}
+	QuiesceOutcome_value = map[string]int32{
+		"QUIESCE_OUTCOME_UNSPECIFIED": 0,
+		"QUIESCE_OUTCOME_ACHIEVED":    1,
+		"QUIESCE_OUTCOME_DEGRADED":    2,
+		"QUIESCE_OUTCOME_SKIPPED":     3,
+	}
+)
+
+func (x QuiesceOutcome) Enum() *QuiesceOutcome {
+	p := new(QuiesceOutcome)
+	*p = x
+	return p
+}
+
+func (x QuiesceOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuiesceOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_provider_v1_provider_proto_enumTypes[1].Descriptor()
+}
+
+func (QuiesceOutcome) Type() protoreflect.EnumType {
+	return &file_provider_v1_provider_proto_enumTypes[1]
+}
+
+func (x QuiesceOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuiesceOutcome.Descriptor instead.
+func (QuiesceOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{1}
+}
+
+type EventSeverity int32
+
+const (
+	EventSeverity_EVENT_SEVERITY_UNSPECIFIED EventSeverity = 0
+	EventSeverity_EVENT_SEVERITY_INFO        EventSeverity = 1
+	EventSeverity_EVENT_SEVERITY_WARNING     EventSeverity = 2
+	EventSeverity_EVENT_SEVERITY_ERROR       EventSeverity = 3
+)
+
+// Enum value maps for EventSeverity.
+var (
+	EventSeverity_name = map[int32]string{
+		0: "EVENT_SEVERITY_UNSPECIFIED",
+		1: "EVENT_SEVERITY_INFO",
+		2: "EVENT_SEVERITY_WARNING",
+		3: "EVENT_SEVERITY_ERROR",
+	}
+	EventSeverity_value = map[string]int32{
+		"EVENT_SEVERITY_UNSPECIFIED": 0,
+		"EVENT_SEVERITY_INFO":        1,
+		"EVENT_SEVERITY_WARNING":     2,
+		"EVENT_SEVERITY_ERROR":       3,
+	}
+)
+
+func (x EventSeverity) Enum() *EventSeverity {
+	p := new(EventSeverity)
+	*p = x
+	return p
+}
+
+func (x EventSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_provider_v1_provider_proto_enumTypes[2].Descriptor()
+}
+
+func (EventSeverity) Type() protoreflect.EnumType {
+	return &file_provider_v1_provider_proto_enumTypes[2]
+}
+
+func (x EventSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventSeverity.Descriptor instead.
+func (EventSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{2}
+}
+
+// Versions of the provider protocol: the RPCs and fields a provider
+// understands. A provider reports the range it implements in
+// GetCapabilitiesResponse, and the manager refuses providers whose range
+// does not overlap its own. Add a version, and move CURRENT to it, whenever
+// the manager starts relying on a new RPC, field or enum value that an older
+// provider would reject or silently ignore.
+type ProtocolVersion int32
+
+const (
+	ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED ProtocolVersion = 0 // Not reported: the provider predates versioning and is read as 1
+	ProtocolVersion_PROTOCOL_VERSION_1           ProtocolVersion = 1 // The protocol before versioning
+	ProtocolVersion_PROTOCOL_VERSION_2           ProtocolVersion = 2 // POWER_OP_RESET, cloud-init network_config/vendor_data, Create idempotency_key, Reconfigure changes
+	ProtocolVersion_PROTOCOL_VERSION_CURRENT     ProtocolVersion = 2 // The latest version; what providers built from this file implement
+)
+
+// Enum value maps for ProtocolVersion.
+var (
+	ProtocolVersion_name = map[int32]string{
+		0: "PROTOCOL_VERSION_UNSPECIFIED",
+		1: "PROTOCOL_VERSION_1",
+		2: "PROTOCOL_VERSION_2",
+		// Duplicate value: 2: "PROTOCOL_VERSION_CURRENT",
+	}
+	ProtocolVersion_value = map[string]int32{
+		"PROTOCOL_VERSION_UNSPECIFIED": 0,
+		"PROTOCOL_VERSION_1":           1,
+		"PROTOCOL_VERSION_2":           2,
+		"PROTOCOL_VERSION_CURRENT":     2,
+	}
+)
+
+func (x ProtocolVersion) Enum() *ProtocolVersion {
+	p := new(ProtocolVersion)
+	*p = x
+	return p
+}
+
+func (x ProtocolVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProtocolVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_provider_v1_provider_proto_enumTypes[3].Descriptor()
+}
+
+func (ProtocolVersion) Type() protoreflect.EnumType {
+	return &file_provider_v1_provider_proto_enumTypes[3]
+}
+
+func (x ProtocolVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProtocolVersion.Descriptor instead.
+func (ProtocolVersion) EnumDescriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{3}
+}
+
 // Task reference for async operations
 type TaskRef struct {
 	state         protoimpl.MessageState
@@ -83,6 +249,9 @@ type TaskRef struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Where an operator can open the task in the hypervisor's own UI, built
+	// from the provider's configured endpoint; empty when there is no such page
+	Link string `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
 }
 
 func (x *TaskRef) Reset() {
@@ -124,6 +293,13 @@ func (x *TaskRef) GetId() string {
 	return ""
 }
 
+func (x *TaskRef) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
 // Empty message for operations with no parameters
 type Empty struct {
 	state         protoimpl.MessageState
@@ -267,12 +443,22 @@ type CreateRequest struct {
 	UserData []byte `protobuf:"bytes,2,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"` // Already rendered cloud-init/ignition data
 	MetaData []byte `protobuf:"bytes,9,opt,name=meta_data,json=metaData,proto3" json:"meta_data,omitempty"` // Cloud-init metadata (YAML format)
 	// JSON-encoded provider-agnostic specifications
-	ClassJson     string   `protobuf:"bytes,3,opt,name=class_json,json=classJson,proto3" json:"class_json,omitempty"`             // VMClass
-	ImageJson     string   `protobuf:"bytes,4,opt,name=image_json,json=imageJson,proto3" json:"image_json,omitempty"`             // VMImage
-	NetworksJson  string   `protobuf:"bytes,5,opt,name=networks_json,json=networksJson,proto3" json:"networks_json,omitempty"`    // []NetworkAttachment
-	DisksJson     string   `protobuf:"bytes,6,opt,name=disks_json,json=disksJson,proto3" json:"disks_json,omitempty"`             // []DiskSpec
-	PlacementJson string   `protobuf:"bytes,7,opt,name=placement_json,json=placementJson,proto3" json:"placement_json,omitempty"` // Placement
-	Tags          []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                        // Tags
+	ClassJson     string   `protobuf:"bytes,3,opt,name=class_json,json=classJson,proto3" json:"class_json,omitempty"`              // VMClass
+	ImageJson     string   `protobuf:"bytes,4,opt,name=image_json,json=imageJson,proto3" json:"image_json,omitempty"`              // VMImage
+	NetworksJson  string   `protobuf:"bytes,5,opt,name=networks_json,json=networksJson,proto3" json:"networks_json,omitempty"`     // []NetworkAttachment
+	DisksJson     string   `protobuf:"bytes,6,opt,name=disks_json,json=disksJson,proto3" json:"disks_json,omitempty"`              // []DiskSpec
+	PlacementJson string   `protobuf:"bytes,7,opt,name=placement_json,json=placementJson,proto3" json:"placement_json,omitempty"`  // Placement
+	Tags          []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                         // Tags
+	NetworkConfig []byte   `protobuf:"bytes,10,opt,name=network_config,json=networkConfig,proto3" json:"network_config,omitempty"` // Cloud-init network-config (version 1 or 2)
+	VendorData    []byte   `protobuf:"bytes,11,opt,name=vendor_data,json=vendorData,proto3" json:"vendor_data,omitempty"`          // Cloud-init vendor-data
+	// Stable key identifying the intended VM across retries (the VM resource
+	// UID). A Create repeating the key of an earlier one must return that
+	// VM, or resume its creation, instead of creating another.
+	IdempotencyKey string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// JSON-encoded GuestCustomization. Empty means cloud-init from the
+	// user_data/meta_data fields above; a Sysprep customization carries the
+	// rendered unattend.xml, and user_data is then empty.
+	GuestCustomizationJson string `protobuf:"bytes,13,opt,name=guest_customization_json,json=guestCustomizationJson,proto3" json:"guest_customization_json,omitempty"`
 }
 
 func (x *CreateRequest) Reset() {
@@ -370,13 +556,42 @@ func (x *CreateRequest) GetTags() []string {
 	return nil
 }
 
+func (x *CreateRequest) GetNetworkConfig() []byte {
+	if x != nil {
+		return x.NetworkConfig
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetVendorData() []byte {
+	if x != nil {
+		return x.VendorData
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetGuestCustomizationJson() string {
+	if x != nil {
+		return x.GuestCustomizationJson
+	}
+	return ""
+}
+
 type CreateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // Provider-specific VM identifier
-	Task *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"` // Optional task reference for async operations
+	Id              string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                    // Provider-specific VM identifier
+	Task            *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`                                                // Optional task reference for async operations
+	ProviderRawJson string   `protobuf:"bytes,3,opt,name=provider_raw_json,json=providerRawJson,proto3" json:"provider_raw_json,omitempty"` // Provider-specific details, e.g. how the VM was placed
 }
 
 func (x *CreateResponse) Reset() {
@@ -425,6 +640,13 @@ func (x *CreateResponse) GetTask() *TaskRef {
 	return nil
 }
 
+func (x *CreateResponse) GetProviderRawJson() string {
+	if x != nil {
+		return x.ProviderRawJson
+	}
+	return ""
+}
+
 // Delete a virtual machine
 type DeleteRequest struct {
 	state         protoimpl.MessageState
@@ -432,6 +654,10 @@ type DeleteRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Whether to also delete the storage the provider created for the VM,
+	// such as its disk volumes. Unset means true. Storage the provider did
+	// not create is never deleted.
+	DeleteVolumes *bool `protobuf:"varint,2,opt,name=delete_volumes,json=deleteVolumes,proto3,oneof" json:"delete_volumes,omitempty"`
 }
 
 func (x *DeleteRequest) Reset() {
@@ -473,6 +699,13 @@ func (x *DeleteRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteRequest) GetDeleteVolumes() bool {
+	if x != nil && x.DeleteVolumes != nil {
+		return *x.DeleteVolumes
+	}
+	return false
+}
+
 // Power control operations
 type PowerRequest struct {
 	state         protoimpl.MessageState
@@ -544,7 +777,11 @@ type ReconfigureRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DesiredJson string `protobuf:"bytes,2,opt,name=desired_json,json=desiredJson,proto3" json:"desired_json,omitempty"` // JSON-encoded desired state
+	DesiredJson string `protobuf:"bytes,2,opt,name=desired_json,json=desiredJson,proto3" json:"desired_json,omitempty"` // JSON-encoded desired state (legacy; superseded by changes)
+	// Differences between the last-applied and desired spec, computed by the
+	// manager. When set, providers apply exactly these changes and need not
+	// diff desired_json against the hypervisor.
+	Changes *ChangeSet `protobuf:"bytes,3,opt,name=changes,proto3" json:"changes,omitempty"`
 }
 
 func (x *ReconfigureRequest) Reset() {
@@ -593,18 +830,25 @@ func (x *ReconfigureRequest) GetDesiredJson() string {
 	return ""
 }
 
-// Upgrade VM hardware version
-type HardwareUpgradeRequest struct {
+func (x *ReconfigureRequest) GetChanges() *ChangeSet {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Old and new value of a changed scalar field
+type Int32Change struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TargetVersion int32  `protobuf:"varint,2,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"` // Target hardware version (e.g., 21)
+	Old int32 `protobuf:"varint,1,opt,name=old,proto3" json:"old,omitempty"`
+	New int32 `protobuf:"varint,2,opt,name=new,proto3" json:"new,omitempty"`
 }
 
-func (x *HardwareUpgradeRequest) Reset() {
-	*x = HardwareUpgradeRequest{}
+func (x *Int32Change) Reset() {
+	*x = Int32Change{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -612,13 +856,13 @@ func (x *HardwareUpgradeRequest) Reset() {
 	}
 }
 
-func (x *HardwareUpgradeRequest) String() string {
+func (x *Int32Change) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HardwareUpgradeRequest) ProtoMessage() {}
+func (*Int32Change) ProtoMessage() {}
 
-func (x *HardwareUpgradeRequest) ProtoReflect() protoreflect.Message {
+func (x *Int32Change) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -630,36 +874,36 @@ func (x *HardwareUpgradeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HardwareUpgradeRequest.ProtoReflect.Descriptor instead.
-func (*HardwareUpgradeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Int32Change.ProtoReflect.Descriptor instead.
+func (*Int32Change) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *HardwareUpgradeRequest) GetId() string {
+func (x *Int32Change) GetOld() int32 {
 	if x != nil {
-		return x.Id
+		return x.Old
 	}
-	return ""
+	return 0
 }
 
-func (x *HardwareUpgradeRequest) GetTargetVersion() int32 {
+func (x *Int32Change) GetNew() int32 {
 	if x != nil {
-		return x.TargetVersion
+		return x.New
 	}
 	return 0
 }
 
-// Generic task response for async operations
-type TaskResponse struct {
+type Int64Change struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Old int64 `protobuf:"varint,1,opt,name=old,proto3" json:"old,omitempty"`
+	New int64 `protobuf:"varint,2,opt,name=new,proto3" json:"new,omitempty"`
 }
 
-func (x *TaskResponse) Reset() {
-	*x = TaskResponse{}
+func (x *Int64Change) Reset() {
+	*x = Int64Change{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,13 +911,13 @@ func (x *TaskResponse) Reset() {
 	}
 }
 
-func (x *TaskResponse) String() string {
+func (x *Int64Change) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskResponse) ProtoMessage() {}
+func (*Int64Change) ProtoMessage() {}
 
-func (x *TaskResponse) ProtoReflect() protoreflect.Message {
+func (x *Int64Change) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -685,29 +929,38 @@ func (x *TaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskResponse.ProtoReflect.Descriptor instead.
-func (*TaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Int64Change.ProtoReflect.Descriptor instead.
+func (*Int64Change) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *TaskResponse) GetTask() *TaskRef {
+func (x *Int64Change) GetOld() int64 {
 	if x != nil {
-		return x.Task
+		return x.Old
 	}
-	return nil
+	return 0
 }
 
-// Describe virtual machine current state
-type DescribeRequest struct {
+func (x *Int64Change) GetNew() int64 {
+	if x != nil {
+		return x.New
+	}
+	return 0
+}
+
+// A disk to grow. Disks are never shrunk.
+type DiskExpansion struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // DiskSpec name; "root" for the class default disk
+	OldSizeGib int32  `protobuf:"varint,2,opt,name=old_size_gib,json=oldSizeGib,proto3" json:"old_size_gib,omitempty"`
+	NewSizeGib int32  `protobuf:"varint,3,opt,name=new_size_gib,json=newSizeGib,proto3" json:"new_size_gib,omitempty"`
 }
 
-func (x *DescribeRequest) Reset() {
-	*x = DescribeRequest{}
+func (x *DiskExpansion) Reset() {
+	*x = DiskExpansion{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -715,13 +968,13 @@ func (x *DescribeRequest) Reset() {
 	}
 }
 
-func (x *DescribeRequest) String() string {
+func (x *DiskExpansion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DescribeRequest) ProtoMessage() {}
+func (*DiskExpansion) ProtoMessage() {}
 
-func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
+func (x *DiskExpansion) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -733,32 +986,44 @@ func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DescribeRequest.ProtoReflect.Descriptor instead.
-func (*DescribeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiskExpansion.ProtoReflect.Descriptor instead.
+func (*DiskExpansion) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DescribeRequest) GetId() string {
+func (x *DiskExpansion) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type DescribeResponse struct {
+func (x *DiskExpansion) GetOldSizeGib() int32 {
+	if x != nil {
+		return x.OldSizeGib
+	}
+	return 0
+}
+
+func (x *DiskExpansion) GetNewSizeGib() int32 {
+	if x != nil {
+		return x.NewSizeGib
+	}
+	return 0
+}
+
+// A network attachment added to the VM
+type NetworkChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Exists          bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
-	PowerState      string   `protobuf:"bytes,2,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`
-	Ips             []string `protobuf:"bytes,3,rep,name=ips,proto3" json:"ips,omitempty"`
-	ConsoleUrl      string   `protobuf:"bytes,4,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`
-	ProviderRawJson string   `protobuf:"bytes,5,opt,name=provider_raw_json,json=providerRawJson,proto3" json:"provider_raw_json,omitempty"` // Provider-specific additional data
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AttachmentJson string `protobuf:"bytes,2,opt,name=attachment_json,json=attachmentJson,proto3" json:"attachment_json,omitempty"` // NetworkAttachment
 }
 
-func (x *DescribeResponse) Reset() {
-	*x = DescribeResponse{}
+func (x *NetworkChange) Reset() {
+	*x = NetworkChange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -766,13 +1031,13 @@ func (x *DescribeResponse) Reset() {
 	}
 }
 
-func (x *DescribeResponse) String() string {
+func (x *NetworkChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DescribeResponse) ProtoMessage() {}
+func (*NetworkChange) ProtoMessage() {}
 
-func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
+func (x *NetworkChange) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -784,57 +1049,38 @@ func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DescribeResponse.ProtoReflect.Descriptor instead.
-func (*DescribeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkChange.ProtoReflect.Descriptor instead.
+func (*NetworkChange) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DescribeResponse) GetExists() bool {
-	if x != nil {
-		return x.Exists
-	}
-	return false
-}
-
-func (x *DescribeResponse) GetPowerState() string {
-	if x != nil {
-		return x.PowerState
-	}
-	return ""
-}
-
-func (x *DescribeResponse) GetIps() []string {
-	if x != nil {
-		return x.Ips
-	}
-	return nil
-}
-
-func (x *DescribeResponse) GetConsoleUrl() string {
+func (x *NetworkChange) GetName() string {
 	if x != nil {
-		return x.ConsoleUrl
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DescribeResponse) GetProviderRawJson() string {
+func (x *NetworkChange) GetAttachmentJson() string {
 	if x != nil {
-		return x.ProviderRawJson
+		return x.AttachmentJson
 	}
 	return ""
 }
 
-// Check async task status
-type TaskStatusRequest struct {
+// The firewall security groups a network attachment should have. An empty
+// list removes every group the provider applied to it.
+type SecurityGroupChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Network string   `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"` // attachment name
+	Groups  []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
 }
 
-func (x *TaskStatusRequest) Reset() {
-	*x = TaskStatusRequest{}
+func (x *SecurityGroupChange) Reset() {
+	*x = SecurityGroupChange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -842,13 +1088,13 @@ func (x *TaskStatusRequest) Reset() {
 	}
 }
 
-func (x *TaskStatusRequest) String() string {
+func (x *SecurityGroupChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskStatusRequest) ProtoMessage() {}
+func (*SecurityGroupChange) ProtoMessage() {}
 
-func (x *TaskStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *SecurityGroupChange) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -860,29 +1106,41 @@ func (x *TaskStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskStatusRequest.ProtoReflect.Descriptor instead.
-func (*TaskStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SecurityGroupChange.ProtoReflect.Descriptor instead.
+func (*SecurityGroupChange) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *TaskStatusRequest) GetTask() *TaskRef {
+func (x *SecurityGroupChange) GetNetwork() string {
 	if x != nil {
-		return x.Task
+		return x.Network
+	}
+	return ""
+}
+
+func (x *SecurityGroupChange) GetGroups() []string {
+	if x != nil {
+		return x.Groups
 	}
 	return nil
 }
 
-type TaskStatusResponse struct {
+// Set of changes for a Reconfigure. Unset fields are unchanged.
+type ChangeSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Done  bool   `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Error message if task failed
+	Cpu             *Int32Change           `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryMib       *Int64Change           `protobuf:"bytes,2,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`
+	Disks           []*DiskExpansion       `protobuf:"bytes,3,rep,name=disks,proto3" json:"disks,omitempty"`
+	NetworksAdded   []*NetworkChange       `protobuf:"bytes,4,rep,name=networks_added,json=networksAdded,proto3" json:"networks_added,omitempty"`
+	NetworksRemoved []string               `protobuf:"bytes,5,rep,name=networks_removed,json=networksRemoved,proto3" json:"networks_removed,omitempty"` // attachment names
+	SecurityGroups  []*SecurityGroupChange `protobuf:"bytes,6,rep,name=security_groups,json=securityGroups,proto3" json:"security_groups,omitempty"`
 }
 
-func (x *TaskStatusResponse) Reset() {
-	*x = TaskStatusResponse{}
+func (x *ChangeSet) Reset() {
+	*x = ChangeSet{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -890,13 +1148,13 @@ func (x *TaskStatusResponse) Reset() {
 	}
 }
 
-func (x *TaskStatusResponse) String() string {
+func (x *ChangeSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskStatusResponse) ProtoMessage() {}
+func (*ChangeSet) ProtoMessage() {}
 
-func (x *TaskStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangeSet) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -908,39 +1166,69 @@ func (x *TaskStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskStatusResponse.ProtoReflect.Descriptor instead.
-func (*TaskStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeSet.ProtoReflect.Descriptor instead.
+func (*ChangeSet) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *TaskStatusResponse) GetDone() bool {
+func (x *ChangeSet) GetCpu() *Int32Change {
 	if x != nil {
-		return x.Done
+		return x.Cpu
 	}
-	return false
+	return nil
 }
 
-func (x *TaskStatusResponse) GetError() string {
+func (x *ChangeSet) GetMemoryMib() *Int64Change {
 	if x != nil {
-		return x.Error
+		return x.MemoryMib
 	}
-	return ""
+	return nil
 }
 
-// Snapshot operations
-type SnapshotCreateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	VmId          string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
-	NameHint      string `protobuf:"bytes,2,opt,name=name_hint,json=nameHint,proto3" json:"name_hint,omitempty"`
-	IncludeMemory bool   `protobuf:"varint,3,opt,name=include_memory,json=includeMemory,proto3" json:"include_memory,omitempty"` // Include memory state if supported
-	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+func (x *ChangeSet) GetDisks() []*DiskExpansion {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
 }
 
-func (x *SnapshotCreateRequest) Reset() {
-	*x = SnapshotCreateRequest{}
+func (x *ChangeSet) GetNetworksAdded() []*NetworkChange {
+	if x != nil {
+		return x.NetworksAdded
+	}
+	return nil
+}
+
+func (x *ChangeSet) GetNetworksRemoved() []string {
+	if x != nil {
+		return x.NetworksRemoved
+	}
+	return nil
+}
+
+func (x *ChangeSet) GetSecurityGroups() []*SecurityGroupChange {
+	if x != nil {
+		return x.SecurityGroups
+	}
+	return nil
+}
+
+// Result of a Reconfigure. Field 1 matches TaskResponse, so responses from
+// providers built before this message existed still decode.
+type ReconfigureResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// ChangeSet fields ("cpu", "memoryMiB", "disks", "networks",
+	// "securityGroups") that could not be applied to the running VM. They take
+	// effect, or can be applied, only after the VM is powered off.
+	PowerCycleRequired []string `protobuf:"bytes,2,rep,name=power_cycle_required,json=powerCycleRequired,proto3" json:"power_cycle_required,omitempty"`
+}
+
+func (x *ReconfigureResponse) Reset() {
+	*x = ReconfigureResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -948,13 +1236,13 @@ func (x *SnapshotCreateRequest) Reset() {
 	}
 }
 
-func (x *SnapshotCreateRequest) String() string {
+func (x *ReconfigureResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotCreateRequest) ProtoMessage() {}
+func (*ReconfigureResponse) ProtoMessage() {}
 
-func (x *SnapshotCreateRequest) ProtoReflect() protoreflect.Message {
+func (x *ReconfigureResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -966,50 +1254,37 @@ func (x *SnapshotCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotCreateRequest.ProtoReflect.Descriptor instead.
-func (*SnapshotCreateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReconfigureResponse.ProtoReflect.Descriptor instead.
+func (*ReconfigureResponse) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *SnapshotCreateRequest) GetVmId() string {
-	if x != nil {
-		return x.VmId
-	}
-	return ""
-}
-
-func (x *SnapshotCreateRequest) GetNameHint() string {
-	if x != nil {
-		return x.NameHint
-	}
-	return ""
-}
-
-func (x *SnapshotCreateRequest) GetIncludeMemory() bool {
+func (x *ReconfigureResponse) GetTask() *TaskRef {
 	if x != nil {
-		return x.IncludeMemory
+		return x.Task
 	}
-	return false
+	return nil
 }
 
-func (x *SnapshotCreateRequest) GetDescription() string {
+func (x *ReconfigureResponse) GetPowerCycleRequired() []string {
 	if x != nil {
-		return x.Description
+		return x.PowerCycleRequired
 	}
-	return ""
+	return nil
 }
 
-type SnapshotCreateResponse struct {
+// Upgrade VM hardware version
+type HardwareUpgradeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SnapshotId string   `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
-	Task       *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TargetVersion int32  `protobuf:"varint,2,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"` // Target hardware version (e.g., 21)
 }
 
-func (x *SnapshotCreateResponse) Reset() {
-	*x = SnapshotCreateResponse{}
+func (x *HardwareUpgradeRequest) Reset() {
+	*x = HardwareUpgradeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1017,13 +1292,13 @@ func (x *SnapshotCreateResponse) Reset() {
 	}
 }
 
-func (x *SnapshotCreateResponse) String() string {
+func (x *HardwareUpgradeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotCreateResponse) ProtoMessage() {}
+func (*HardwareUpgradeRequest) ProtoMessage() {}
 
-func (x *SnapshotCreateResponse) ProtoReflect() protoreflect.Message {
+func (x *HardwareUpgradeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1035,36 +1310,36 @@ func (x *SnapshotCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotCreateResponse.ProtoReflect.Descriptor instead.
-func (*SnapshotCreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use HardwareUpgradeRequest.ProtoReflect.Descriptor instead.
+func (*HardwareUpgradeRequest) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *SnapshotCreateResponse) GetSnapshotId() string {
+func (x *HardwareUpgradeRequest) GetId() string {
 	if x != nil {
-		return x.SnapshotId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SnapshotCreateResponse) GetTask() *TaskRef {
+func (x *HardwareUpgradeRequest) GetTargetVersion() int32 {
 	if x != nil {
-		return x.Task
+		return x.TargetVersion
 	}
-	return nil
+	return 0
 }
 
-type SnapshotDeleteRequest struct {
+// Generic task response for async operations
+type TaskResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
-	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 }
 
-func (x *SnapshotDeleteRequest) Reset() {
-	*x = SnapshotDeleteRequest{}
+func (x *TaskResponse) Reset() {
+	*x = TaskResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1072,13 +1347,13 @@ func (x *SnapshotDeleteRequest) Reset() {
 	}
 }
 
-func (x *SnapshotDeleteRequest) String() string {
+func (x *TaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotDeleteRequest) ProtoMessage() {}
+func (*TaskResponse) ProtoMessage() {}
 
-func (x *SnapshotDeleteRequest) ProtoReflect() protoreflect.Message {
+func (x *TaskResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1090,36 +1365,29 @@ func (x *SnapshotDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotDeleteRequest.ProtoReflect.Descriptor instead.
-func (*SnapshotDeleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TaskResponse.ProtoReflect.Descriptor instead.
+func (*TaskResponse) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SnapshotDeleteRequest) GetVmId() string {
-	if x != nil {
-		return x.VmId
-	}
-	return ""
-}
-
-func (x *SnapshotDeleteRequest) GetSnapshotId() string {
+func (x *TaskResponse) GetTask() *TaskRef {
 	if x != nil {
-		return x.SnapshotId
+		return x.Task
 	}
-	return ""
+	return nil
 }
 
-type SnapshotRevertRequest struct {
+// Describe virtual machine current state
+type DescribeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
-	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *SnapshotRevertRequest) Reset() {
-	*x = SnapshotRevertRequest{}
+func (x *DescribeRequest) Reset() {
+	*x = DescribeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1127,13 +1395,13 @@ func (x *SnapshotRevertRequest) Reset() {
 	}
 }
 
-func (x *SnapshotRevertRequest) String() string {
+func (x *DescribeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotRevertRequest) ProtoMessage() {}
+func (*DescribeRequest) ProtoMessage() {}
 
-func (x *SnapshotRevertRequest) ProtoReflect() protoreflect.Message {
+func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1145,42 +1413,41 @@ func (x *SnapshotRevertRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotRevertRequest.ProtoReflect.Descriptor instead.
-func (*SnapshotRevertRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeRequest.ProtoReflect.Descriptor instead.
+func (*DescribeRequest) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SnapshotRevertRequest) GetVmId() string {
-	if x != nil {
-		return x.VmId
-	}
-	return ""
-}
-
-func (x *SnapshotRevertRequest) GetSnapshotId() string {
+func (x *DescribeRequest) GetId() string {
 	if x != nil {
-		return x.SnapshotId
+		return x.Id
 	}
 	return ""
 }
 
-// Clone operations
-type CloneRequest struct {
+type DescribeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceVmId string `protobuf:"bytes,1,opt,name=source_vm_id,json=sourceVmId,proto3" json:"source_vm_id,omitempty"`
-	TargetName string `protobuf:"bytes,2,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`
-	Linked     bool   `protobuf:"varint,3,opt,name=linked,proto3" json:"linked,omitempty"` // Best-effort linked clone
-	// JSON-encoded specifications for customization
-	ClassJson     string `protobuf:"bytes,4,opt,name=class_json,json=classJson,proto3" json:"class_json,omitempty"`             // VMClass overrides
-	PlacementJson string `protobuf:"bytes,5,opt,name=placement_json,json=placementJson,proto3" json:"placement_json,omitempty"` // Placement hints
-	CustomizeJson string `protobuf:"bytes,6,opt,name=customize_json,json=customizeJson,proto3" json:"customize_json,omitempty"` // Customization spec
+	Exists          bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	PowerState      string   `protobuf:"bytes,2,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`
+	Ips             []string `protobuf:"bytes,3,rep,name=ips,proto3" json:"ips,omitempty"`
+	ConsoleUrl      string   `protobuf:"bytes,4,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`
+	ProviderRawJson string   `protobuf:"bytes,5,opt,name=provider_raw_json,json=providerRawJson,proto3" json:"provider_raw_json,omitempty"` // Provider-specific additional data
+	// Guest resource usage; unset when the provider cannot observe it, e.g.
+	// for a powered-off VM
+	GuestStats *GuestStats `protobuf:"bytes,6,opt,name=guest_stats,json=guestStats,proto3" json:"guest_stats,omitempty"`
+	// The addresses in ips with the NIC each was found on, where the provider
+	// knows it. An address in ips but not here has no known interface.
+	Addresses []*GuestAddress `protobuf:"bytes,7,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// The identity marker stamped on the VM when it was created (the create
+	// request's idempotency key); empty when the VM carries none
+	Identity string `protobuf:"bytes,8,opt,name=identity,proto3" json:"identity,omitempty"`
 }
 
-func (x *CloneRequest) Reset() {
-	*x = CloneRequest{}
+func (x *DescribeResponse) Reset() {
+	*x = DescribeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1188,13 +1455,13 @@ func (x *CloneRequest) Reset() {
 	}
 }
 
-func (x *CloneRequest) String() string {
+func (x *DescribeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloneRequest) ProtoMessage() {}
+func (*DescribeResponse) ProtoMessage() {}
 
-func (x *CloneRequest) ProtoReflect() protoreflect.Message {
+func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1206,64 +1473,77 @@ func (x *CloneRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloneRequest.ProtoReflect.Descriptor instead.
-func (*CloneRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeResponse.ProtoReflect.Descriptor instead.
+func (*DescribeResponse) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CloneRequest) GetSourceVmId() string {
+func (x *DescribeResponse) GetExists() bool {
 	if x != nil {
-		return x.SourceVmId
+		return x.Exists
 	}
-	return ""
+	return false
 }
 
-func (x *CloneRequest) GetTargetName() string {
+func (x *DescribeResponse) GetPowerState() string {
 	if x != nil {
-		return x.TargetName
+		return x.PowerState
 	}
 	return ""
 }
 
-func (x *CloneRequest) GetLinked() bool {
+func (x *DescribeResponse) GetIps() []string {
 	if x != nil {
-		return x.Linked
+		return x.Ips
 	}
-	return false
+	return nil
 }
 
-func (x *CloneRequest) GetClassJson() string {
+func (x *DescribeResponse) GetConsoleUrl() string {
 	if x != nil {
-		return x.ClassJson
+		return x.ConsoleUrl
 	}
 	return ""
 }
 
-func (x *CloneRequest) GetPlacementJson() string {
+func (x *DescribeResponse) GetProviderRawJson() string {
 	if x != nil {
-		return x.PlacementJson
+		return x.ProviderRawJson
 	}
 	return ""
 }
 
-func (x *CloneRequest) GetCustomizeJson() string {
+func (x *DescribeResponse) GetGuestStats() *GuestStats {
 	if x != nil {
-		return x.CustomizeJson
+		return x.GuestStats
+	}
+	return nil
+}
+
+func (x *DescribeResponse) GetAddresses() []*GuestAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *DescribeResponse) GetIdentity() string {
+	if x != nil {
+		return x.Identity
 	}
 	return ""
 }
 
-type CloneResponse struct {
+type DescribeBatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TargetVmId string   `protobuf:"bytes,1,opt,name=target_vm_id,json=targetVmId,proto3" json:"target_vm_id,omitempty"`
-	Task       *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 }
 
-func (x *CloneResponse) Reset() {
-	*x = CloneResponse{}
+func (x *DescribeBatchRequest) Reset() {
+	*x = DescribeBatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1271,13 +1551,13 @@ func (x *CloneResponse) Reset() {
 	}
 }
 
-func (x *CloneResponse) String() string {
+func (x *DescribeBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloneResponse) ProtoMessage() {}
+func (*DescribeBatchRequest) ProtoMessage() {}
 
-func (x *CloneResponse) ProtoReflect() protoreflect.Message {
+func (x *DescribeBatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1289,38 +1569,33 @@ func (x *CloneResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloneResponse.ProtoReflect.Descriptor instead.
-func (*CloneResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeBatchRequest.ProtoReflect.Descriptor instead.
+func (*DescribeBatchRequest) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CloneResponse) GetTargetVmId() string {
-	if x != nil {
-		return x.TargetVmId
-	}
-	return ""
-}
-
-func (x *CloneResponse) GetTask() *TaskRef {
+func (x *DescribeBatchRequest) GetIds() []string {
 	if x != nil {
-		return x.Task
+		return x.Ids
 	}
 	return nil
 }
 
-// Image preparation operations
-type ImagePrepareRequest struct {
+type DescribeBatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ImageJson   string `protobuf:"bytes,1,opt,name=image_json,json=imageJson,proto3" json:"image_json,omitempty"`       // JSON-encoded VMImage spec
-	TargetName  string `protobuf:"bytes,2,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`    // Target template/image name
-	StorageHint string `protobuf:"bytes,3,opt,name=storage_hint,json=storageHint,proto3" json:"storage_hint,omitempty"` // Storage location hint (datastore, pool, etc.)
+	// Keyed by requested id. An id with no VM behind it maps to a response
+	// with exists=false, as Describe would return.
+	Results map[string]*DescribeResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Ids that could not be described, with the reason. Each requested id is
+	// in exactly one of results and errors.
+	Errors map[string]string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ImagePrepareRequest) Reset() {
-	*x = ImagePrepareRequest{}
+func (x *DescribeBatchResponse) Reset() {
+	*x = DescribeBatchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_provider_v1_provider_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1328,13 +1603,13 @@ func (x *ImagePrepareRequest) Reset() {
 	}
 }
 
-func (x *ImagePrepareRequest) String() string {
+func (x *DescribeBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImagePrepareRequest) ProtoMessage() {}
+func (*DescribeBatchResponse) ProtoMessage() {}
 
-func (x *ImagePrepareRequest) ProtoReflect() protoreflect.Message {
+func (x *DescribeBatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_provider_v1_provider_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1346,80 +1621,2035 @@ func (x *ImagePrepareRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImagePrepareRequest.ProtoReflect.Descriptor instead.
-func (*ImagePrepareRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeBatchResponse.ProtoReflect.Descriptor instead.
+func (*DescribeBatchResponse) Descriptor() ([]byte, []int) {
 	return file_provider_v1_provider_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ImagePrepareRequest) GetImageJson() string {
+func (x *DescribeBatchResponse) GetResults() map[string]*DescribeResponse {
 	if x != nil {
-		return x.ImageJson
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-func (x *ImagePrepareRequest) GetTargetName() string {
+func (x *DescribeBatchResponse) GetErrors() map[string]string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// GuestAddress is an IP address reported for a VM.
+type GuestAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	// The guest's name for the interface where the guest agent reports it,
+	// otherwise the network the NIC is attached to
+	Interface string `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`
+}
+
+func (x *GuestAddress) Reset() {
+	*x = GuestAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestAddress) ProtoMessage() {}
+
+func (x *GuestAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestAddress.ProtoReflect.Descriptor instead.
+func (*GuestAddress) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GuestAddress) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GuestAddress) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+// GuestStats is a normalized sample of a VM's resource usage. Each field is
+// unset when the hypervisor does not report it.
+type GuestStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CPU utilization across all of the VM's vCPUs, 0-100
+	CpuUsagePercent *float64 `protobuf:"fixed64,1,opt,name=cpu_usage_percent,json=cpuUsagePercent,proto3,oneof" json:"cpu_usage_percent,omitempty"`
+	// Memory in use by the guest
+	MemoryUsageBytes *int64 `protobuf:"varint,2,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3,oneof" json:"memory_usage_bytes,omitempty"`
+	// Seconds since the VM was last powered on
+	UptimeSeconds *int64 `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3,oneof" json:"uptime_seconds,omitempty"`
+}
+
+func (x *GuestStats) Reset() {
+	*x = GuestStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestStats) ProtoMessage() {}
+
+func (x *GuestStats) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestStats.ProtoReflect.Descriptor instead.
+func (*GuestStats) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GuestStats) GetCpuUsagePercent() float64 {
+	if x != nil && x.CpuUsagePercent != nil {
+		return *x.CpuUsagePercent
+	}
+	return 0
+}
+
+func (x *GuestStats) GetMemoryUsageBytes() int64 {
+	if x != nil && x.MemoryUsageBytes != nil {
+		return *x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *GuestStats) GetUptimeSeconds() int64 {
+	if x != nil && x.UptimeSeconds != nil {
+		return *x.UptimeSeconds
+	}
+	return 0
+}
+
+// Check async task status
+type TaskStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+}
+
+func (x *TaskStatusRequest) Reset() {
+	*x = TaskStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatusRequest) ProtoMessage() {}
+
+func (x *TaskStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*TaskStatusRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskStatusRequest) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type TaskStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Done  bool   `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Error message if task failed
+	// Human-readable detail about a task still in progress, e.g. that a
+	// restarted provider does not know the task yet and is still verifying it
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TaskStatusResponse) Reset() {
+	*x = TaskStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatusResponse) ProtoMessage() {}
+
+func (x *TaskStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*TaskStatusResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TaskStatusResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *TaskStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Snapshot operations
+type SnapshotCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId          string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
+	NameHint      string `protobuf:"bytes,2,opt,name=name_hint,json=nameHint,proto3" json:"name_hint,omitempty"`
+	IncludeMemory bool   `protobuf:"varint,3,opt,name=include_memory,json=includeMemory,proto3" json:"include_memory,omitempty"` // Include memory state if supported
+	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Freeze the guest's filesystems through its agent while the snapshot is
+	// taken. When the agent does not answer, the provider fails with
+	// FAILED_PRECONDITION, or takes a crash-consistent snapshot and reports
+	// QUIESCE_OUTCOME_DEGRADED if allow_unquiesced is set.
+	Quiesce         bool `protobuf:"varint,5,opt,name=quiesce,proto3" json:"quiesce,omitempty"`
+	AllowUnquiesced bool `protobuf:"varint,6,opt,name=allow_unquiesced,json=allowUnquiesced,proto3" json:"allow_unquiesced,omitempty"`
+}
+
+func (x *SnapshotCreateRequest) Reset() {
+	*x = SnapshotCreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotCreateRequest) ProtoMessage() {}
+
+func (x *SnapshotCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotCreateRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotCreateRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SnapshotCreateRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *SnapshotCreateRequest) GetNameHint() string {
+	if x != nil {
+		return x.NameHint
+	}
+	return ""
+}
+
+func (x *SnapshotCreateRequest) GetIncludeMemory() bool {
+	if x != nil {
+		return x.IncludeMemory
+	}
+	return false
+}
+
+func (x *SnapshotCreateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SnapshotCreateRequest) GetQuiesce() bool {
+	if x != nil {
+		return x.Quiesce
+	}
+	return false
+}
+
+func (x *SnapshotCreateRequest) GetAllowUnquiesced() bool {
+	if x != nil {
+		return x.AllowUnquiesced
+	}
+	return false
+}
+
+type SnapshotCreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SnapshotId string   `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Task       *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Size of the snapshot's data (memory state and disk deltas); unset when
+	// the provider cannot compute it or the snapshot is still being taken
+	SizeBytes *int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3,oneof" json:"size_bytes,omitempty"`
+	// Storage the snapshot occupies; unset when unknown
+	ConsumedBytes  *int64         `protobuf:"varint,4,opt,name=consumed_bytes,json=consumedBytes,proto3,oneof" json:"consumed_bytes,omitempty"`
+	CreatedUnix    int64          `protobuf:"varint,5,opt,name=created_unix,json=createdUnix,proto3" json:"created_unix,omitempty"` // Creation time on the hypervisor in Unix seconds; 0 when unknown
+	Quiesce        QuiesceOutcome `protobuf:"varint,6,opt,name=quiesce,proto3,enum=provider.v1.QuiesceOutcome" json:"quiesce,omitempty"`
+	QuiesceMessage string         `protobuf:"bytes,7,opt,name=quiesce_message,json=quiesceMessage,proto3" json:"quiesce_message,omitempty"` // Why quiesce was degraded or skipped
+}
+
+func (x *SnapshotCreateResponse) Reset() {
+	*x = SnapshotCreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotCreateResponse) ProtoMessage() {}
+
+func (x *SnapshotCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotCreateResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotCreateResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SnapshotCreateResponse) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *SnapshotCreateResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SnapshotCreateResponse) GetSizeBytes() int64 {
+	if x != nil && x.SizeBytes != nil {
+		return *x.SizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotCreateResponse) GetConsumedBytes() int64 {
+	if x != nil && x.ConsumedBytes != nil {
+		return *x.ConsumedBytes
+	}
+	return 0
+}
+
+func (x *SnapshotCreateResponse) GetCreatedUnix() int64 {
+	if x != nil {
+		return x.CreatedUnix
+	}
+	return 0
+}
+
+func (x *SnapshotCreateResponse) GetQuiesce() QuiesceOutcome {
+	if x != nil {
+		return x.Quiesce
+	}
+	return QuiesceOutcome_QUIESCE_OUTCOME_UNSPECIFIED
+}
+
+func (x *SnapshotCreateResponse) GetQuiesceMessage() string {
+	if x != nil {
+		return x.QuiesceMessage
+	}
+	return ""
+}
+
+type SnapshotDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+}
+
+func (x *SnapshotDeleteRequest) Reset() {
+	*x = SnapshotDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotDeleteRequest) ProtoMessage() {}
+
+func (x *SnapshotDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotDeleteRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SnapshotDeleteRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *SnapshotDeleteRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type SnapshotRevertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+}
+
+func (x *SnapshotRevertRequest) Reset() {
+	*x = SnapshotRevertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRevertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRevertRequest) ProtoMessage() {}
+
+func (x *SnapshotRevertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRevertRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRevertRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SnapshotRevertRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *SnapshotRevertRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type SnapshotListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`
+}
+
+func (x *SnapshotListRequest) Reset() {
+	*x = SnapshotListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotListRequest) ProtoMessage() {}
+
+func (x *SnapshotListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotListRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotListRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SnapshotListRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+type SnapshotInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Provider snapshot ID, as returned by SnapshotCreate
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedUnix int64  `protobuf:"varint,3,opt,name=created_unix,json=createdUnix,proto3" json:"created_unix,omitempty"` // Creation time in Unix seconds; 0 when unknown
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	HasMemory   bool   `protobuf:"varint,5,opt,name=has_memory,json=hasMemory,proto3" json:"has_memory,omitempty"` // Snapshot includes memory state
+	ParentId    string `protobuf:"bytes,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`     // Parent snapshot ID; empty for a root snapshot
+	// Size of the snapshot's data (memory state and disk deltas); unset when
+	// the provider cannot compute it
+	SizeBytes *int64 `protobuf:"varint,7,opt,name=size_bytes,json=sizeBytes,proto3,oneof" json:"size_bytes,omitempty"`
+	// Storage the snapshot occupies; unset when unknown
+	ConsumedBytes *int64 `protobuf:"varint,8,opt,name=consumed_bytes,json=consumedBytes,proto3,oneof" json:"consumed_bytes,omitempty"`
+}
+
+func (x *SnapshotInfo) Reset() {
+	*x = SnapshotInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotInfo) ProtoMessage() {}
+
+func (x *SnapshotInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotInfo.ProtoReflect.Descriptor instead.
+func (*SnapshotInfo) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SnapshotInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnapshotInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SnapshotInfo) GetCreatedUnix() int64 {
+	if x != nil {
+		return x.CreatedUnix
+	}
+	return 0
+}
+
+func (x *SnapshotInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SnapshotInfo) GetHasMemory() bool {
+	if x != nil {
+		return x.HasMemory
+	}
+	return false
+}
+
+func (x *SnapshotInfo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *SnapshotInfo) GetSizeBytes() int64 {
+	if x != nil && x.SizeBytes != nil {
+		return *x.SizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotInfo) GetConsumedBytes() int64 {
+	if x != nil && x.ConsumedBytes != nil {
+		return *x.ConsumedBytes
+	}
+	return 0
+}
+
+type SnapshotListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*SnapshotInfo `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *SnapshotListResponse) Reset() {
+	*x = SnapshotListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotListResponse) ProtoMessage() {}
+
+func (x *SnapshotListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotListResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotListResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SnapshotListResponse) GetSnapshots() []*SnapshotInfo {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// Clone operations
+type CloneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceVmId string `protobuf:"bytes,1,opt,name=source_vm_id,json=sourceVmId,proto3" json:"source_vm_id,omitempty"`
+	TargetName string `protobuf:"bytes,2,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`
+	Linked     bool   `protobuf:"varint,3,opt,name=linked,proto3" json:"linked,omitempty"` // Best-effort linked clone
+	// JSON-encoded specifications for customization
+	ClassJson     string `protobuf:"bytes,4,opt,name=class_json,json=classJson,proto3" json:"class_json,omitempty"`             // VMClass overrides
+	PlacementJson string `protobuf:"bytes,5,opt,name=placement_json,json=placementJson,proto3" json:"placement_json,omitempty"` // Placement hints
+	CustomizeJson string `protobuf:"bytes,6,opt,name=customize_json,json=customizeJson,proto3" json:"customize_json,omitempty"` // Customization spec
+	// JSON-encoded CloneCustomization: the hostname, cloud-init user-data and
+	// per-NIC network overrides to apply to the clone before it first boots.
+	// Empty means no customization. customize_json carries the unresolved
+	// VMClone spec and is kept for providers that read it.
+	CustomizationJson string `protobuf:"bytes,7,opt,name=customization_json,json=customizationJson,proto3" json:"customization_json,omitempty"`
+}
+
+func (x *CloneRequest) Reset() {
+	*x = CloneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneRequest) ProtoMessage() {}
+
+func (x *CloneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneRequest.ProtoReflect.Descriptor instead.
+func (*CloneRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CloneRequest) GetSourceVmId() string {
+	if x != nil {
+		return x.SourceVmId
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetTargetName() string {
+	if x != nil {
+		return x.TargetName
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetLinked() bool {
+	if x != nil {
+		return x.Linked
+	}
+	return false
+}
+
+func (x *CloneRequest) GetClassJson() string {
+	if x != nil {
+		return x.ClassJson
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetPlacementJson() string {
+	if x != nil {
+		return x.PlacementJson
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetCustomizeJson() string {
+	if x != nil {
+		return x.CustomizeJson
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetCustomizationJson() string {
+	if x != nil {
+		return x.CustomizationJson
+	}
+	return ""
+}
+
+type CloneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetVmId string   `protobuf:"bytes,1,opt,name=target_vm_id,json=targetVmId,proto3" json:"target_vm_id,omitempty"`
+	Task       *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Set when the customization in customization_json was applied. A
+	// provider that predates customization_json leaves it false, and the
+	// manager then treats the clone as not customized.
+	CustomizationApplied bool `protobuf:"varint,3,opt,name=customization_applied,json=customizationApplied,proto3" json:"customization_applied,omitempty"`
+	// Why customization failed. The clone exists as target_vm_id but is not
+	// customized; the provider leaves it powered off.
+	CustomizationError string `protobuf:"bytes,4,opt,name=customization_error,json=customizationError,proto3" json:"customization_error,omitempty"`
+}
+
+func (x *CloneResponse) Reset() {
+	*x = CloneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneResponse) ProtoMessage() {}
+
+func (x *CloneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneResponse.ProtoReflect.Descriptor instead.
+func (*CloneResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CloneResponse) GetTargetVmId() string {
+	if x != nil {
+		return x.TargetVmId
+	}
+	return ""
+}
+
+func (x *CloneResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *CloneResponse) GetCustomizationApplied() bool {
+	if x != nil {
+		return x.CustomizationApplied
+	}
+	return false
+}
+
+func (x *CloneResponse) GetCustomizationError() string {
+	if x != nil {
+		return x.CustomizationError
+	}
+	return ""
+}
+
+// Image preparation operations
+type ImagePrepareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImageJson   string `protobuf:"bytes,1,opt,name=image_json,json=imageJson,proto3" json:"image_json,omitempty"`       // JSON-encoded VMImage spec
+	TargetName  string `protobuf:"bytes,2,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`    // Target template/image name
+	StorageHint string `protobuf:"bytes,3,opt,name=storage_hint,json=storageHint,proto3" json:"storage_hint,omitempty"` // Storage location hint (datastore, pool, etc.)
+}
+
+func (x *ImagePrepareRequest) Reset() {
+	*x = ImagePrepareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImagePrepareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImagePrepareRequest) ProtoMessage() {}
+
+func (x *ImagePrepareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImagePrepareRequest.ProtoReflect.Descriptor instead.
+func (*ImagePrepareRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ImagePrepareRequest) GetImageJson() string {
+	if x != nil {
+		return x.ImageJson
+	}
+	return ""
+}
+
+func (x *ImagePrepareRequest) GetTargetName() string {
+	if x != nil {
+		return x.TargetName
+	}
+	return ""
+}
+
+func (x *ImagePrepareRequest) GetStorageHint() string {
+	if x != nil {
+		return x.StorageHint
+	}
+	return ""
+}
+
+// ImagePrepareResponse reports where the provider placed the prepared image so
+// the manager can later create VMs from that prepared location instead of
+// re-resolving (and possibly re-downloading) the original source (issue #154,
+// PR-6 / #214).
+//
+// It mirrors the CloneResponse precedent: `task` stays at field 1 so this
+// message is wire-compatible with the TaskResponse it replaces — a manager that
+// still decodes a TaskResponse reads field 1 (the TaskRef) unchanged, and the
+// new id/path fields are simply ignored by older decoders. The change is
+// therefore additive and non-breaking.
+type ImagePrepareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// task references an async prepare operation; an empty/nil task means the
+	// prepare completed synchronously. Field 1 is intentionally identical to
+	// TaskResponse.task for wire compatibility.
+	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// prepared_image_id is the provider-specific identifier of the prepared image
+	// — e.g. a vSphere template name / MoRef value, or a Proxmox template
+	// name/VMID. The manager stamps this onto VMImage.status and uses it as the
+	// template ref at create time. Known at trigger time even for async prepares.
+	PreparedImageId string `protobuf:"bytes,2,opt,name=prepared_image_id,json=preparedImageId,proto3" json:"prepared_image_id,omitempty"`
+	// prepared_image_path is the provider-specific path of the prepared image —
+	// e.g. a libvirt pool path (<poolPath>/<target>.qcow2). Empty for providers
+	// that address prepared images by id/name only (vSphere, Proxmox). Known at
+	// trigger time even for async prepares.
+	PreparedImagePath string `protobuf:"bytes,3,opt,name=prepared_image_path,json=preparedImagePath,proto3" json:"prepared_image_path,omitempty"`
+}
+
+func (x *ImagePrepareResponse) Reset() {
+	*x = ImagePrepareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImagePrepareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImagePrepareResponse) ProtoMessage() {}
+
+func (x *ImagePrepareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImagePrepareResponse.ProtoReflect.Descriptor instead.
+func (*ImagePrepareResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ImagePrepareResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ImagePrepareResponse) GetPreparedImageId() string {
+	if x != nil {
+		return x.PreparedImageId
+	}
+	return ""
+}
+
+func (x *ImagePrepareResponse) GetPreparedImagePath() string {
+	if x != nil {
+		return x.PreparedImagePath
+	}
+	return ""
+}
+
+// PublishImageRequest turns an existing VM into an image new VMs can be
+// created from. The source VM is copied, never converted in place, so it
+// keeps running as it was.
+type PublishImageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId        string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`                      // Source VM identifier
+	ImageName   string `protobuf:"bytes,2,opt,name=image_name,json=imageName,proto3" json:"image_name,omitempty"`       // Name of the published template or image
+	OptionsJson string `protobuf:"bytes,3,opt,name=options_json,json=optionsJson,proto3" json:"options_json,omitempty"` // JSON-encoded PublishImageOptions
+	Overwrite   bool   `protobuf:"varint,4,opt,name=overwrite,proto3" json:"overwrite,omitempty"`                       // Replace an existing image of the same name instead of failing with ALREADY_EXISTS
+}
+
+func (x *PublishImageRequest) Reset() {
+	*x = PublishImageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishImageRequest) ProtoMessage() {}
+
+func (x *PublishImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishImageRequest.ProtoReflect.Descriptor instead.
+func (*PublishImageRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PublishImageRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *PublishImageRequest) GetImageName() string {
+	if x != nil {
+		return x.ImageName
+	}
+	return ""
+}
+
+func (x *PublishImageRequest) GetOptionsJson() string {
+	if x != nil {
+		return x.OptionsJson
+	}
+	return ""
+}
+
+func (x *PublishImageRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+type PublishImageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Task         *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`                                     // Async publish; empty when it completed synchronously
+	ImageId      string   `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`                // Provider-specific id of the published image, e.g. a Proxmox template VMID or a content library item id
+	ImagePath    string   `protobuf:"bytes,3,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`          // Path of the published image file, e.g. a libvirt pool path; empty for providers that address images by id
+	Checksum     string   `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`                             // Checksum of the published image file; empty when the provider publishes no file
+	ChecksumType string   `protobuf:"bytes,5,opt,name=checksum_type,json=checksumType,proto3" json:"checksum_type,omitempty"` // Algorithm of checksum, e.g. "sha256"
+}
+
+func (x *PublishImageResponse) Reset() {
+	*x = PublishImageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishImageResponse) ProtoMessage() {}
+
+func (x *PublishImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishImageResponse.ProtoReflect.Descriptor instead.
+func (*PublishImageResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PublishImageResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *PublishImageResponse) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *PublishImageResponse) GetImagePath() string {
+	if x != nil {
+		return x.ImagePath
+	}
+	return ""
+}
+
+func (x *PublishImageResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *PublishImageResponse) GetChecksumType() string {
+	if x != nil {
+		return x.ChecksumType
+	}
+	return ""
+}
+
+// Disk export operations for migration
+type ExportDiskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId               string            `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`                                                                                           // VM identifier
+	DiskId             string            `protobuf:"bytes,2,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                                                                                     // Disk identifier (optional, defaults to primary disk)
+	SnapshotId         string            `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`                                                                         // Snapshot to export from (optional)
+	DestinationUrl     string            `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`                                                             // Where to upload the disk (S3, HTTP, etc.)
+	Format             string            `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`                                                                                                   // Desired export format (qcow2, vmdk, raw)
+	Compress           bool              `protobuf:"varint,6,opt,name=compress,proto3" json:"compress,omitempty"`                                                                                              // Enable compression during export
+	Credentials        map[string]string `protobuf:"bytes,7,rep,name=credentials,proto3" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Credentials for destination (access keys, tokens)
+	BackendType        string            `protobuf:"bytes,8,opt,name=backend_type,json=backendType,proto3" json:"backend_type,omitempty"`                                                                      // Staging backend: "pvc" | "nfs" | "s3"; empty == legacy pvc (ADR-0006)
+	TransferMode       string            `protobuf:"bytes,9,opt,name=transfer_mode,json=transferMode,proto3" json:"transfer_mode,omitempty"`                                                                   // Transfer mode: "auto" | "relay" | "direct" (ADR-0006)
+	StorageOptionsJson string            `protobuf:"bytes,10,opt,name=storage_options_json,json=storageOptionsJson,proto3" json:"storage_options_json,omitempty"`                                              // JSON-encoded backend-specific options (ADR-0006; empty in Slice 0)
+}
+
+func (x *ExportDiskRequest) Reset() {
+	*x = ExportDiskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportDiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDiskRequest) ProtoMessage() {}
+
+func (x *ExportDiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDiskRequest.ProtoReflect.Descriptor instead.
+func (*ExportDiskRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExportDiskRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetDiskId() string {
+	if x != nil {
+		return x.DiskId
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetDestinationUrl() string {
+	if x != nil {
+		return x.DestinationUrl
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+func (x *ExportDiskRequest) GetCredentials() map[string]string {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *ExportDiskRequest) GetBackendType() string {
+	if x != nil {
+		return x.BackendType
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetTransferMode() string {
+	if x != nil {
+		return x.TransferMode
+	}
+	return ""
+}
+
+func (x *ExportDiskRequest) GetStorageOptionsJson() string {
+	if x != nil {
+		return x.StorageOptionsJson
+	}
+	return ""
+}
+
+type ExportDiskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExportId           string   `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`                                  // Export operation identifier
+	Task               *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`                                                          // Task reference for async tracking
+	EstimatedSizeBytes int64    `protobuf:"varint,3,opt,name=estimated_size_bytes,json=estimatedSizeBytes,proto3" json:"estimated_size_bytes,omitempty"` // Estimated size of export
+	Checksum           string   `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`                                                  // Checksum of exported disk (SHA256)
+}
+
+func (x *ExportDiskResponse) Reset() {
+	*x = ExportDiskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportDiskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDiskResponse) ProtoMessage() {}
+
+func (x *ExportDiskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDiskResponse.ProtoReflect.Descriptor instead.
+func (*ExportDiskResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExportDiskResponse) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *ExportDiskResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ExportDiskResponse) GetEstimatedSizeBytes() int64 {
+	if x != nil {
+		return x.EstimatedSizeBytes
+	}
+	return 0
+}
+
+func (x *ExportDiskResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// Disk import operations for migration.
+// See the ExportDiskRequest host-vs-pod execution contract above: disk bytes
+// never traverse the gRPC channel; backend_type/transfer_mode carry the same
+// meaning for the import (target) side (ADR-0006).
+type ImportDiskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceUrl          string            `protobuf:"bytes,1,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`                                                                            // Where to download the disk from (S3, HTTP, etc.)
+	StorageHint        string            `protobuf:"bytes,2,opt,name=storage_hint,json=storageHint,proto3" json:"storage_hint,omitempty"`                                                                      // Target storage location (datastore, pool, etc.)
+	Format             string            `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                                                                                                   // Source disk format (qcow2, vmdk, raw)
+	TargetName         string            `protobuf:"bytes,4,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`                                                                         // Name for the imported disk
+	VerifyChecksum     bool              `protobuf:"varint,5,opt,name=verify_checksum,json=verifyChecksum,proto3" json:"verify_checksum,omitempty"`                                                            // Verify checksum after import
+	ExpectedChecksum   string            `protobuf:"bytes,6,opt,name=expected_checksum,json=expectedChecksum,proto3" json:"expected_checksum,omitempty"`                                                       // Expected checksum (SHA256)
+	Credentials        map[string]string `protobuf:"bytes,7,rep,name=credentials,proto3" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Credentials for source (access keys, tokens)
+	BackendType        string            `protobuf:"bytes,8,opt,name=backend_type,json=backendType,proto3" json:"backend_type,omitempty"`                                                                      // Staging backend: "pvc" | "nfs" | "s3"; empty == legacy pvc (ADR-0006)
+	TransferMode       string            `protobuf:"bytes,9,opt,name=transfer_mode,json=transferMode,proto3" json:"transfer_mode,omitempty"`                                                                   // Transfer mode: "auto" | "relay" | "direct" (ADR-0006)
+	StorageOptionsJson string            `protobuf:"bytes,10,opt,name=storage_options_json,json=storageOptionsJson,proto3" json:"storage_options_json,omitempty"`                                              // JSON-encoded backend-specific options (ADR-0006; empty in Slice 0)
+}
+
+func (x *ImportDiskRequest) Reset() {
+	*x = ImportDiskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportDiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDiskRequest) ProtoMessage() {}
+
+func (x *ImportDiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDiskRequest.ProtoReflect.Descriptor instead.
+func (*ImportDiskRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ImportDiskRequest) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetStorageHint() string {
+	if x != nil {
+		return x.StorageHint
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetTargetName() string {
 	if x != nil {
 		return x.TargetName
 	}
-	return ""
+	return ""
+}
+
+func (x *ImportDiskRequest) GetVerifyChecksum() bool {
+	if x != nil {
+		return x.VerifyChecksum
+	}
+	return false
+}
+
+func (x *ImportDiskRequest) GetExpectedChecksum() string {
+	if x != nil {
+		return x.ExpectedChecksum
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetCredentials() map[string]string {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *ImportDiskRequest) GetBackendType() string {
+	if x != nil {
+		return x.BackendType
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetTransferMode() string {
+	if x != nil {
+		return x.TransferMode
+	}
+	return ""
+}
+
+func (x *ImportDiskRequest) GetStorageOptionsJson() string {
+	if x != nil {
+		return x.StorageOptionsJson
+	}
+	return ""
+}
+
+type ImportDiskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DiskId          string   `protobuf:"bytes,1,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                               // Imported disk identifier
+	Path            string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                                 // Path to imported disk
+	Task            *TaskRef `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`                                                 // Task reference for async tracking
+	ActualSizeBytes int64    `protobuf:"varint,4,opt,name=actual_size_bytes,json=actualSizeBytes,proto3" json:"actual_size_bytes,omitempty"` // Actual size of imported disk
+	Checksum        string   `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`                                         // Checksum of imported disk (SHA256)
+}
+
+func (x *ImportDiskResponse) Reset() {
+	*x = ImportDiskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportDiskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDiskResponse) ProtoMessage() {}
+
+func (x *ImportDiskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDiskResponse.ProtoReflect.Descriptor instead.
+func (*ImportDiskResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ImportDiskResponse) GetDiskId() string {
+	if x != nil {
+		return x.DiskId
+	}
+	return ""
+}
+
+func (x *ImportDiskResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ImportDiskResponse) GetTask() *TaskRef {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ImportDiskResponse) GetActualSizeBytes() int64 {
+	if x != nil {
+		return x.ActualSizeBytes
+	}
+	return 0
+}
+
+func (x *ImportDiskResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// Get disk information for migration planning
+type GetDiskInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`                   // VM identifier
+	DiskId     string `protobuf:"bytes,2,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`             // Disk identifier (optional, defaults to primary disk)
+	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"` // Get info for specific snapshot (optional)
+}
+
+func (x *GetDiskInfoRequest) Reset() {
+	*x = GetDiskInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDiskInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiskInfoRequest) ProtoMessage() {}
+
+func (x *GetDiskInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiskInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetDiskInfoRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetDiskInfoRequest) GetVmId() string {
+	if x != nil {
+		return x.VmId
+	}
+	return ""
+}
+
+func (x *GetDiskInfoRequest) GetDiskId() string {
+	if x != nil {
+		return x.DiskId
+	}
+	return ""
+}
+
+func (x *GetDiskInfoRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type GetDiskInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DiskId           string            `protobuf:"bytes,1,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                                                                               // Disk identifier
+	Format           string            `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                                                                                             // Disk format (qcow2, vmdk, raw)
+	VirtualSizeBytes int64             `protobuf:"varint,3,opt,name=virtual_size_bytes,json=virtualSizeBytes,proto3" json:"virtual_size_bytes,omitempty"`                                              // Virtual size (capacity)
+	ActualSizeBytes  int64             `protobuf:"varint,4,opt,name=actual_size_bytes,json=actualSizeBytes,proto3" json:"actual_size_bytes,omitempty"`                                                 // Actual size (allocated)
+	Path             string            `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`                                                                                                 // Path or location
+	IsBootable       bool              `protobuf:"varint,6,opt,name=is_bootable,json=isBootable,proto3" json:"is_bootable,omitempty"`                                                                  // Is this a boot disk
+	Snapshots        []string          `protobuf:"bytes,7,rep,name=snapshots,proto3" json:"snapshots,omitempty"`                                                                                       // Available snapshots
+	BackingFile      string            `protobuf:"bytes,8,opt,name=backing_file,json=backingFile,proto3" json:"backing_file,omitempty"`                                                                // Backing file (for linked clones)
+	Metadata         map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Additional provider-specific metadata
+}
+
+func (x *GetDiskInfoResponse) Reset() {
+	*x = GetDiskInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDiskInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiskInfoResponse) ProtoMessage() {}
+
+func (x *GetDiskInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiskInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetDiskInfoResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetDiskInfoResponse) GetDiskId() string {
+	if x != nil {
+		return x.DiskId
+	}
+	return ""
+}
+
+func (x *GetDiskInfoResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *GetDiskInfoResponse) GetVirtualSizeBytes() int64 {
+	if x != nil {
+		return x.VirtualSizeBytes
+	}
+	return 0
+}
+
+func (x *GetDiskInfoResponse) GetActualSizeBytes() int64 {
+	if x != nil {
+		return x.ActualSizeBytes
+	}
+	return 0
+}
+
+func (x *GetDiskInfoResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetDiskInfoResponse) GetIsBootable() bool {
+	if x != nil {
+		return x.IsBootable
+	}
+	return false
+}
+
+func (x *GetDiskInfoResponse) GetSnapshots() []string {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+func (x *GetDiskInfoResponse) GetBackingFile() string {
+	if x != nil {
+		return x.BackingFile
+	}
+	return ""
+}
+
+func (x *GetDiskInfoResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// List all VMs managed by this provider
+type ListVMsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListVMsRequest) Reset() {
+	*x = ListVMsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVMsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVMsRequest) ProtoMessage() {}
+
+func (x *ListVMsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVMsRequest.ProtoReflect.Descriptor instead.
+func (*ListVMsRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{45}
+}
+
+type ListVMsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vms []*VMInfo `protobuf:"bytes,1,rep,name=vms,proto3" json:"vms,omitempty"`
+}
+
+func (x *ListVMsResponse) Reset() {
+	*x = ListVMsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVMsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVMsResponse) ProtoMessage() {}
+
+func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVMsResponse.ProtoReflect.Descriptor instead.
+func (*ListVMsResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListVMsResponse) GetVms() []*VMInfo {
+	if x != nil {
+		return x.Vms
+	}
+	return nil
+}
+
+type VMInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                                              // Provider-specific VM identifier
+	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                          // VM name
+	PowerState  string            `protobuf:"bytes,3,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`                                                                                            // Current power state
+	Ips         []string          `protobuf:"bytes,4,rep,name=ips,proto3" json:"ips,omitempty"`                                                                                                                            // IP addresses
+	Cpu         int32             `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                                                           // Number of virtual CPUs
+	MemoryMib   int64             `protobuf:"varint,6,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`                                                                                              // Memory in MiB
+	Disks       []*DiskInfo       `protobuf:"bytes,7,rep,name=disks,proto3" json:"disks,omitempty"`                                                                                                                        // Disk information
+	Networks    []*NetworkInfo    `protobuf:"bytes,8,rep,name=networks,proto3" json:"networks,omitempty"`                                                                                                                  // Network information
+	ProviderRaw map[string]string `protobuf:"bytes,9,rep,name=provider_raw,json=providerRaw,proto3" json:"provider_raw,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Provider-specific metadata
+}
+
+func (x *VMInfo) Reset() {
+	*x = VMInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMInfo) ProtoMessage() {}
+
+func (x *VMInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMInfo.ProtoReflect.Descriptor instead.
+func (*VMInfo) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VMInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VMInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMInfo) GetPowerState() string {
+	if x != nil {
+		return x.PowerState
+	}
+	return ""
+}
+
+func (x *VMInfo) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+func (x *VMInfo) GetCpu() int32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *VMInfo) GetMemoryMib() int64 {
+	if x != nil {
+		return x.MemoryMib
+	}
+	return 0
 }
 
-func (x *ImagePrepareRequest) GetStorageHint() string {
+func (x *VMInfo) GetDisks() []*DiskInfo {
 	if x != nil {
-		return x.StorageHint
+		return x.Disks
 	}
-	return ""
+	return nil
 }
 
-// ImagePrepareResponse reports where the provider placed the prepared image so
-// the manager can later create VMs from that prepared location instead of
-// re-resolving (and possibly re-downloading) the original source (issue #154,
-// PR-6 / #214).
-//
-// It mirrors the CloneResponse precedent: `task` stays at field 1 so this
-// message is wire-compatible with the TaskResponse it replaces — a manager that
-// still decodes a TaskResponse reads field 1 (the TaskRef) unchanged, and the
-// new id/path fields are simply ignored by older decoders. The change is
-// therefore additive and non-breaking.
-type ImagePrepareResponse struct {
+func (x *VMInfo) GetNetworks() []*NetworkInfo {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *VMInfo) GetProviderRaw() map[string]string {
+	if x != nil {
+		return x.ProviderRaw
+	}
+	return nil
+}
+
+type DiskInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// task references an async prepare operation; an empty/nil task means the
-	// prepare completed synchronously. Field 1 is intentionally identical to
-	// TaskResponse.task for wire compatibility.
-	Task *TaskRef `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
-	// prepared_image_id is the provider-specific identifier of the prepared image
-	// — e.g. a vSphere template name / MoRef value, or a Proxmox template
-	// name/VMID. The manager stamps this onto VMImage.status and uses it as the
-	// template ref at create time. Known at trigger time even for async prepares.
-	PreparedImageId string `protobuf:"bytes,2,opt,name=prepared_image_id,json=preparedImageId,proto3" json:"prepared_image_id,omitempty"`
-	// prepared_image_path is the provider-specific path of the prepared image —
-	// e.g. a libvirt pool path (<poolPath>/<target>.qcow2). Empty for providers
-	// that address prepared images by id/name only (vSphere, Proxmox). Known at
-	// trigger time even for async prepares.
-	PreparedImagePath string `protobuf:"bytes,3,opt,name=prepared_image_path,json=preparedImagePath,proto3" json:"prepared_image_path,omitempty"`
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                           // Disk identifier
+	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                       // Disk path
+	SizeGib int32  `protobuf:"varint,3,opt,name=size_gib,json=sizeGib,proto3" json:"size_gib,omitempty"` // Disk size in GiB
+	Format  string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                   // Disk format (qcow2, vmdk, raw, etc.)
 }
 
-func (x *ImagePrepareResponse) Reset() {
-	*x = ImagePrepareResponse{}
+func (x *DiskInfo) Reset() {
+	*x = DiskInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[22]
+		mi := &file_provider_v1_provider_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ImagePrepareResponse) String() string {
+func (x *DiskInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImagePrepareResponse) ProtoMessage() {}
+func (*DiskInfo) ProtoMessage() {}
 
-func (x *ImagePrepareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[22]
+func (x *DiskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1430,67 +3660,66 @@ func (x *ImagePrepareResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImagePrepareResponse.ProtoReflect.Descriptor instead.
-func (*ImagePrepareResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use DiskInfo.ProtoReflect.Descriptor instead.
+func (*DiskInfo) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ImagePrepareResponse) GetTask() *TaskRef {
+func (x *DiskInfo) GetId() string {
 	if x != nil {
-		return x.Task
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ImagePrepareResponse) GetPreparedImageId() string {
+func (x *DiskInfo) GetPath() string {
 	if x != nil {
-		return x.PreparedImageId
+		return x.Path
 	}
 	return ""
 }
 
-func (x *ImagePrepareResponse) GetPreparedImagePath() string {
+func (x *DiskInfo) GetSizeGib() int32 {
 	if x != nil {
-		return x.PreparedImagePath
+		return x.SizeGib
+	}
+	return 0
+}
+
+func (x *DiskInfo) GetFormat() string {
+	if x != nil {
+		return x.Format
 	}
 	return ""
 }
 
-// Disk export operations for migration
-type ExportDiskRequest struct {
+type NetworkInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VmId               string            `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`                                                                                           // VM identifier
-	DiskId             string            `protobuf:"bytes,2,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                                                                                     // Disk identifier (optional, defaults to primary disk)
-	SnapshotId         string            `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`                                                                         // Snapshot to export from (optional)
-	DestinationUrl     string            `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`                                                             // Where to upload the disk (S3, HTTP, etc.)
-	Format             string            `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`                                                                                                   // Desired export format (qcow2, vmdk, raw)
-	Compress           bool              `protobuf:"varint,6,opt,name=compress,proto3" json:"compress,omitempty"`                                                                                              // Enable compression during export
-	Credentials        map[string]string `protobuf:"bytes,7,rep,name=credentials,proto3" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Credentials for destination (access keys, tokens)
-	BackendType        string            `protobuf:"bytes,8,opt,name=backend_type,json=backendType,proto3" json:"backend_type,omitempty"`                                                                      // Staging backend: "pvc" | "nfs" | "s3"; empty == legacy pvc (ADR-0006)
-	TransferMode       string            `protobuf:"bytes,9,opt,name=transfer_mode,json=transferMode,proto3" json:"transfer_mode,omitempty"`                                                                   // Transfer mode: "auto" | "relay" | "direct" (ADR-0006)
-	StorageOptionsJson string            `protobuf:"bytes,10,opt,name=storage_options_json,json=storageOptionsJson,proto3" json:"storage_options_json,omitempty"`                                              // JSON-encoded backend-specific options (ADR-0006; empty in Slice 0)
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // Network name
+	Mac       string `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`                              // MAC address
+	IpAddress string `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // IP address if static
 }
 
-func (x *ExportDiskRequest) Reset() {
-	*x = ExportDiskRequest{}
+func (x *NetworkInfo) Reset() {
+	*x = NetworkInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[23]
+		mi := &file_provider_v1_provider_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExportDiskRequest) String() string {
+func (x *NetworkInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportDiskRequest) ProtoMessage() {}
+func (*NetworkInfo) ProtoMessage() {}
 
-func (x *ExportDiskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[23]
+func (x *NetworkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,109 +3730,182 @@ func (x *ExportDiskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExportDiskRequest.ProtoReflect.Descriptor instead.
-func (*ExportDiskRequest) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use NetworkInfo.ProtoReflect.Descriptor instead.
+func (*NetworkInfo) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ExportDiskRequest) GetVmId() string {
+func (x *NetworkInfo) GetName() string {
 	if x != nil {
-		return x.VmId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ExportDiskRequest) GetDiskId() string {
+func (x *NetworkInfo) GetMac() string {
 	if x != nil {
-		return x.DiskId
+		return x.Mac
 	}
 	return ""
 }
 
-func (x *ExportDiskRequest) GetSnapshotId() string {
+func (x *NetworkInfo) GetIpAddress() string {
 	if x != nil {
-		return x.SnapshotId
+		return x.IpAddress
 	}
 	return ""
 }
 
-func (x *ExportDiskRequest) GetDestinationUrl() string {
-	if x != nil {
-		return x.DestinationUrl
+// Retrieve recent serial/console output for a VM. Intended for debugging
+// boot failures (kernel panics, cloud-init errors, sshd not starting)
+// without requiring interactive console access to the hypervisor.
+type GetConsoleOutputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                 // VM identifier
+	TailLines int32  `protobuf:"varint,2,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"` // Return only the last N lines (0 = provider default)
+}
+
+func (x *GetConsoleOutputRequest) Reset() {
+	*x = GetConsoleOutputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *ExportDiskRequest) GetFormat() string {
+func (x *GetConsoleOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleOutputRequest) ProtoMessage() {}
+
+func (x *GetConsoleOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleOutputRequest.ProtoReflect.Descriptor instead.
+func (*GetConsoleOutputRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetConsoleOutputRequest) GetId() string {
 	if x != nil {
-		return x.Format
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ExportDiskRequest) GetCompress() bool {
+func (x *GetConsoleOutputRequest) GetTailLines() int32 {
 	if x != nil {
-		return x.Compress
+		return x.TailLines
 	}
-	return false
+	return 0
 }
 
-func (x *ExportDiskRequest) GetCredentials() map[string]string {
-	if x != nil {
-		return x.Credentials
+type GetConsoleOutputResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output    string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`        // Console output as text (best-effort UTF-8)
+	Truncated bool   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // True if older output was dropped to honour tail_lines
+	Source    string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`        // Where the output came from (e.g. "serial0", file path)
+}
+
+func (x *GetConsoleOutputResponse) Reset() {
+	*x = GetConsoleOutputResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ExportDiskRequest) GetBackendType() string {
+func (x *GetConsoleOutputResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleOutputResponse) ProtoMessage() {}
+
+func (x *GetConsoleOutputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleOutputResponse.ProtoReflect.Descriptor instead.
+func (*GetConsoleOutputResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetConsoleOutputResponse) GetOutput() string {
 	if x != nil {
-		return x.BackendType
+		return x.Output
 	}
 	return ""
 }
 
-func (x *ExportDiskRequest) GetTransferMode() string {
+func (x *GetConsoleOutputResponse) GetTruncated() bool {
 	if x != nil {
-		return x.TransferMode
+		return x.Truncated
 	}
-	return ""
+	return false
 }
 
-func (x *ExportDiskRequest) GetStorageOptionsJson() string {
+func (x *GetConsoleOutputResponse) GetSource() string {
 	if x != nil {
-		return x.StorageOptionsJson
+		return x.Source
 	}
 	return ""
 }
 
-type ExportDiskResponse struct {
+// Report cloud-init's progress inside a guest, read through the hypervisor's
+// guest channel (QEMU guest agent exec, vSphere guestinfo). Used to hold a
+// VM's readiness until first-boot provisioning has finished. Providers never
+// block until cloud-init finishes; a call waits at most a few seconds.
+type GetCloudInitStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ExportId           string   `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`                                  // Export operation identifier
-	Task               *TaskRef `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`                                                          // Task reference for async tracking
-	EstimatedSizeBytes int64    `protobuf:"varint,3,opt,name=estimated_size_bytes,json=estimatedSizeBytes,proto3" json:"estimated_size_bytes,omitempty"` // Estimated size of export
-	Checksum           string   `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`                                                  // Checksum of exported disk (SHA256)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // VM identifier
 }
 
-func (x *ExportDiskResponse) Reset() {
-	*x = ExportDiskResponse{}
+func (x *GetCloudInitStatusRequest) Reset() {
+	*x = GetCloudInitStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[24]
+		mi := &file_provider_v1_provider_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExportDiskResponse) String() string {
+func (x *GetCloudInitStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportDiskResponse) ProtoMessage() {}
+func (*GetCloudInitStatusRequest) ProtoMessage() {}
 
-func (x *ExportDiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[24]
+func (x *GetCloudInitStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1614,77 +3916,115 @@ func (x *ExportDiskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExportDiskResponse.ProtoReflect.Descriptor instead.
-func (*ExportDiskResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use GetCloudInitStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCloudInitStatusRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetCloudInitStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCloudInitStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status            string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                                // cloud-init status: "not started", "running", "done", "error" or "disabled"
+	Errors            []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`                                                // Errors cloud-init reported (status "error")
+	RecoverableErrors []string `protobuf:"bytes,3,rep,name=recoverable_errors,json=recoverableErrors,proto3" json:"recoverable_errors,omitempty"` // Warnings cloud-init recovered from ("degraded done")
+	Detail            string   `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`                                                // Extended status or explanation, e.g. the datasource in use
+}
+
+func (x *GetCloudInitStatusResponse) Reset() {
+	*x = GetCloudInitStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCloudInitStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCloudInitStatusResponse) ProtoMessage() {}
+
+func (x *GetCloudInitStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *ExportDiskResponse) GetExportId() string {
+// Deprecated: Use GetCloudInitStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCloudInitStatusResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetCloudInitStatusResponse) GetStatus() string {
 	if x != nil {
-		return x.ExportId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *ExportDiskResponse) GetTask() *TaskRef {
+func (x *GetCloudInitStatusResponse) GetErrors() []string {
 	if x != nil {
-		return x.Task
+		return x.Errors
 	}
 	return nil
 }
 
-func (x *ExportDiskResponse) GetEstimatedSizeBytes() int64 {
+func (x *GetCloudInitStatusResponse) GetRecoverableErrors() []string {
 	if x != nil {
-		return x.EstimatedSizeBytes
+		return x.RecoverableErrors
 	}
-	return 0
+	return nil
 }
 
-func (x *ExportDiskResponse) GetChecksum() string {
+func (x *GetCloudInitStatusResponse) GetDetail() string {
 	if x != nil {
-		return x.Checksum
+		return x.Detail
 	}
 	return ""
 }
 
-// Disk import operations for migration.
-// See the ExportDiskRequest host-vs-pod execution contract above: disk bytes
-// never traverse the gRPC channel; backend_type/transfer_mode carry the same
-// meaning for the import (target) side (ADR-0006).
-type ImportDiskRequest struct {
+// Hypervisor capacity and utilization, for headroom reporting. Providers
+// report one entry per placement target they manage (a PVE node, a vSphere
+// cluster, a libvirt host).
+type GetCapacityRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	SourceUrl          string            `protobuf:"bytes,1,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`                                                                            // Where to download the disk from (S3, HTTP, etc.)
-	StorageHint        string            `protobuf:"bytes,2,opt,name=storage_hint,json=storageHint,proto3" json:"storage_hint,omitempty"`                                                                      // Target storage location (datastore, pool, etc.)
-	Format             string            `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                                                                                                   // Source disk format (qcow2, vmdk, raw)
-	TargetName         string            `protobuf:"bytes,4,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`                                                                         // Name for the imported disk
-	VerifyChecksum     bool              `protobuf:"varint,5,opt,name=verify_checksum,json=verifyChecksum,proto3" json:"verify_checksum,omitempty"`                                                            // Verify checksum after import
-	ExpectedChecksum   string            `protobuf:"bytes,6,opt,name=expected_checksum,json=expectedChecksum,proto3" json:"expected_checksum,omitempty"`                                                       // Expected checksum (SHA256)
-	Credentials        map[string]string `protobuf:"bytes,7,rep,name=credentials,proto3" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Credentials for source (access keys, tokens)
-	BackendType        string            `protobuf:"bytes,8,opt,name=backend_type,json=backendType,proto3" json:"backend_type,omitempty"`                                                                      // Staging backend: "pvc" | "nfs" | "s3"; empty == legacy pvc (ADR-0006)
-	TransferMode       string            `protobuf:"bytes,9,opt,name=transfer_mode,json=transferMode,proto3" json:"transfer_mode,omitempty"`                                                                   // Transfer mode: "auto" | "relay" | "direct" (ADR-0006)
-	StorageOptionsJson string            `protobuf:"bytes,10,opt,name=storage_options_json,json=storageOptionsJson,proto3" json:"storage_options_json,omitempty"`                                              // JSON-encoded backend-specific options (ADR-0006; empty in Slice 0)
 }
 
-func (x *ImportDiskRequest) Reset() {
-	*x = ImportDiskRequest{}
+func (x *GetCapacityRequest) Reset() {
+	*x = GetCapacityRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[25]
+		mi := &file_provider_v1_provider_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ImportDiskRequest) String() string {
+func (x *GetCapacityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImportDiskRequest) ProtoMessage() {}
+func (*GetCapacityRequest) ProtoMessage() {}
 
-func (x *ImportDiskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[25]
+func (x *GetCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1695,110 +4035,131 @@ func (x *ImportDiskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImportDiskRequest.ProtoReflect.Descriptor instead.
-func (*ImportDiskRequest) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use GetCapacityRequest.ProtoReflect.Descriptor instead.
+func (*GetCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *ImportDiskRequest) GetSourceUrl() string {
-	if x != nil {
-		return x.SourceUrl
-	}
-	return ""
+type HostCapacity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                             // Node, cluster or host name
+	CpuCores          int32   `protobuf:"varint,2,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`                    // Physical CPU cores (or threads, as the hypervisor counts them)
+	CpuUtilization    float64 `protobuf:"fixed64,3,opt,name=cpu_utilization,json=cpuUtilization,proto3" json:"cpu_utilization,omitempty"` // Current CPU utilization, 0.0-1.0
+	MemoryTotalBytes  int64   `protobuf:"varint,4,opt,name=memory_total_bytes,json=memoryTotalBytes,proto3" json:"memory_total_bytes,omitempty"`
+	MemoryUsedBytes   int64   `protobuf:"varint,5,opt,name=memory_used_bytes,json=memoryUsedBytes,proto3" json:"memory_used_bytes,omitempty"`
+	StorageTotalBytes int64   `protobuf:"varint,6,opt,name=storage_total_bytes,json=storageTotalBytes,proto3" json:"storage_total_bytes,omitempty"` // Default VM storage; 0 when not reported
+	StorageUsedBytes  int64   `protobuf:"varint,7,opt,name=storage_used_bytes,json=storageUsedBytes,proto3" json:"storage_used_bytes,omitempty"`
 }
 
-func (x *ImportDiskRequest) GetStorageHint() string {
-	if x != nil {
-		return x.StorageHint
+func (x *HostCapacity) Reset() {
+	*x = HostCapacity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *ImportDiskRequest) GetFormat() string {
-	if x != nil {
-		return x.Format
+func (x *HostCapacity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostCapacity) ProtoMessage() {}
+
+func (x *HostCapacity) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ImportDiskRequest) GetTargetName() string {
+// Deprecated: Use HostCapacity.ProtoReflect.Descriptor instead.
+func (*HostCapacity) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *HostCapacity) GetName() string {
 	if x != nil {
-		return x.TargetName
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ImportDiskRequest) GetVerifyChecksum() bool {
+func (x *HostCapacity) GetCpuCores() int32 {
 	if x != nil {
-		return x.VerifyChecksum
+		return x.CpuCores
 	}
-	return false
+	return 0
 }
 
-func (x *ImportDiskRequest) GetExpectedChecksum() string {
+func (x *HostCapacity) GetCpuUtilization() float64 {
 	if x != nil {
-		return x.ExpectedChecksum
+		return x.CpuUtilization
 	}
-	return ""
+	return 0
 }
 
-func (x *ImportDiskRequest) GetCredentials() map[string]string {
+func (x *HostCapacity) GetMemoryTotalBytes() int64 {
 	if x != nil {
-		return x.Credentials
+		return x.MemoryTotalBytes
 	}
-	return nil
+	return 0
 }
 
-func (x *ImportDiskRequest) GetBackendType() string {
+func (x *HostCapacity) GetMemoryUsedBytes() int64 {
 	if x != nil {
-		return x.BackendType
+		return x.MemoryUsedBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *ImportDiskRequest) GetTransferMode() string {
+func (x *HostCapacity) GetStorageTotalBytes() int64 {
 	if x != nil {
-		return x.TransferMode
+		return x.StorageTotalBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *ImportDiskRequest) GetStorageOptionsJson() string {
+func (x *HostCapacity) GetStorageUsedBytes() int64 {
 	if x != nil {
-		return x.StorageOptionsJson
+		return x.StorageUsedBytes
 	}
-	return ""
+	return 0
 }
 
-type ImportDiskResponse struct {
+type GetCapacityResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DiskId          string   `protobuf:"bytes,1,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                               // Imported disk identifier
-	Path            string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                                 // Path to imported disk
-	Task            *TaskRef `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`                                                 // Task reference for async tracking
-	ActualSizeBytes int64    `protobuf:"varint,4,opt,name=actual_size_bytes,json=actualSizeBytes,proto3" json:"actual_size_bytes,omitempty"` // Actual size of imported disk
-	Checksum        string   `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`                                         // Checksum of imported disk (SHA256)
+	Hosts []*HostCapacity `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
 }
 
-func (x *ImportDiskResponse) Reset() {
-	*x = ImportDiskResponse{}
+func (x *GetCapacityResponse) Reset() {
+	*x = GetCapacityResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[26]
+		mi := &file_provider_v1_provider_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ImportDiskResponse) String() string {
+func (x *GetCapacityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImportDiskResponse) ProtoMessage() {}
+func (*GetCapacityResponse) ProtoMessage() {}
 
-func (x *ImportDiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[26]
+func (x *GetCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1809,74 +4170,105 @@ func (x *ImportDiskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImportDiskResponse.ProtoReflect.Descriptor instead.
-func (*ImportDiskResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetCapacityResponse.ProtoReflect.Descriptor instead.
+func (*GetCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ImportDiskResponse) GetDiskId() string {
+func (x *GetCapacityResponse) GetHosts() []*HostCapacity {
 	if x != nil {
-		return x.DiskId
+		return x.Hosts
 	}
-	return ""
+	return nil
 }
 
-func (x *ImportDiskResponse) GetPath() string {
-	if x != nil {
-		return x.Path
+// Live-migrate a running VM to another host managed by the same provider.
+// The VM keeps running; the returned task reports progress through
+// TaskStatus.message until the VM runs on the target host.
+type MigrateHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // VM identifier
+	TargetHost string `protobuf:"bytes,2,opt,name=target_host,json=targetHost,proto3" json:"target_host,omitempty"` // Host name, as reported in the "host" key of provider_raw_json
+}
+
+func (x *MigrateHostRequest) Reset() {
+	*x = MigrateHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *ImportDiskResponse) GetTask() *TaskRef {
-	if x != nil {
-		return x.Task
+func (x *MigrateHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateHostRequest) ProtoMessage() {}
+
+func (x *MigrateHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ImportDiskResponse) GetActualSizeBytes() int64 {
+// Deprecated: Use MigrateHostRequest.ProtoReflect.Descriptor instead.
+func (*MigrateHostRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *MigrateHostRequest) GetId() string {
 	if x != nil {
-		return x.ActualSizeBytes
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *ImportDiskResponse) GetChecksum() string {
+func (x *MigrateHostRequest) GetTargetHost() string {
 	if x != nil {
-		return x.Checksum
+		return x.TargetHost
 	}
 	return ""
 }
 
-// Get disk information for migration planning
-type GetDiskInfoRequest struct {
+// Open an interactive console on a VM for the manager's console proxy.
+// The response says where the manager connects to reach the console; the
+// manager uses it at once, so providers hand out the shortest-lived,
+// single-use credentials the hypervisor offers.
+type ConsoleTicketRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VmId       string `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"`                   // VM identifier
-	DiskId     string `protobuf:"bytes,2,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`             // Disk identifier (optional, defaults to primary disk)
-	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"` // Get info for specific snapshot (optional)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // VM identifier
 }
 
-func (x *GetDiskInfoRequest) Reset() {
-	*x = GetDiskInfoRequest{}
+func (x *ConsoleTicketRequest) Reset() {
+	*x = ConsoleTicketRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[27]
+		mi := &file_provider_v1_provider_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetDiskInfoRequest) String() string {
+func (x *ConsoleTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDiskInfoRequest) ProtoMessage() {}
+func (*ConsoleTicketRequest) ProtoMessage() {}
 
-func (x *GetDiskInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[27]
+func (x *ConsoleTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1887,65 +4279,49 @@ func (x *GetDiskInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDiskInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetDiskInfoRequest) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *GetDiskInfoRequest) GetVmId() string {
-	if x != nil {
-		return x.VmId
-	}
-	return ""
-}
-
-func (x *GetDiskInfoRequest) GetDiskId() string {
-	if x != nil {
-		return x.DiskId
-	}
-	return ""
+// Deprecated: Use ConsoleTicketRequest.ProtoReflect.Descriptor instead.
+func (*ConsoleTicketRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *GetDiskInfoRequest) GetSnapshotId() string {
+func (x *ConsoleTicketRequest) GetId() string {
 	if x != nil {
-		return x.SnapshotId
+		return x.Id
 	}
 	return ""
 }
 
-type GetDiskInfoResponse struct {
+type ConsoleTicketResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DiskId           string            `protobuf:"bytes,1,opt,name=disk_id,json=diskId,proto3" json:"disk_id,omitempty"`                                                                               // Disk identifier
-	Format           string            `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                                                                                             // Disk format (qcow2, vmdk, raw)
-	VirtualSizeBytes int64             `protobuf:"varint,3,opt,name=virtual_size_bytes,json=virtualSizeBytes,proto3" json:"virtual_size_bytes,omitempty"`                                              // Virtual size (capacity)
-	ActualSizeBytes  int64             `protobuf:"varint,4,opt,name=actual_size_bytes,json=actualSizeBytes,proto3" json:"actual_size_bytes,omitempty"`                                                 // Actual size (allocated)
-	Path             string            `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`                                                                                                 // Path or location
-	IsBootable       bool              `protobuf:"varint,6,opt,name=is_bootable,json=isBootable,proto3" json:"is_bootable,omitempty"`                                                                  // Is this a boot disk
-	Snapshots        []string          `protobuf:"bytes,7,rep,name=snapshots,proto3" json:"snapshots,omitempty"`                                                                                       // Available snapshots
-	BackingFile      string            `protobuf:"bytes,8,opt,name=backing_file,json=backingFile,proto3" json:"backing_file,omitempty"`                                                                // Backing file (for linked clones)
-	Metadata         map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Additional provider-specific metadata
+	Protocol               string            `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                                       // "vnc" (RFB) or "webmks" (vSphere)
+	Url                    string            `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                                                                                                 // ws:// or wss:// URL to connect to; empty when the provider relays the console itself through ConsoleRelay
+	Headers                map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // HTTP headers to send when connecting to url, e.g. hypervisor credentials
+	Password               string            `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`                                                                                       // VNC password to answer the console's VNC authentication with; empty when it asks for none
+	CaBundle               []byte            `protobuf:"bytes,5,opt,name=ca_bundle,json=caBundle,proto3" json:"ca_bundle,omitempty"`                                                                       // PEM CA bundle to verify url's certificate with; system roots when empty
+	CertificateFingerprint string            `protobuf:"bytes,6,opt,name=certificate_fingerprint,json=certificateFingerprint,proto3" json:"certificate_fingerprint,omitempty"`                             // SHA-1 or SHA-256 fingerprint of url's certificate, hex with optional colons; pins it instead of a CA
+	InsecureSkipVerify     bool              `protobuf:"varint,7,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`                                      // Do not verify url's certificate
 }
 
-func (x *GetDiskInfoResponse) Reset() {
-	*x = GetDiskInfoResponse{}
+func (x *ConsoleTicketResponse) Reset() {
+	*x = ConsoleTicketResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[28]
+		mi := &file_provider_v1_provider_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetDiskInfoResponse) String() string {
+func (x *ConsoleTicketResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDiskInfoResponse) ProtoMessage() {}
+func (*ConsoleTicketResponse) ProtoMessage() {}
 
-func (x *GetDiskInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[28]
+func (x *ConsoleTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1956,98 +4332,143 @@ func (x *GetDiskInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDiskInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetDiskInfoResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ConsoleTicketResponse.ProtoReflect.Descriptor instead.
+func (*ConsoleTicketResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *GetDiskInfoResponse) GetDiskId() string {
+func (x *ConsoleTicketResponse) GetProtocol() string {
 	if x != nil {
-		return x.DiskId
+		return x.Protocol
 	}
 	return ""
 }
 
-func (x *GetDiskInfoResponse) GetFormat() string {
+func (x *ConsoleTicketResponse) GetUrl() string {
 	if x != nil {
-		return x.Format
+		return x.Url
 	}
 	return ""
 }
 
-func (x *GetDiskInfoResponse) GetVirtualSizeBytes() int64 {
+func (x *ConsoleTicketResponse) GetHeaders() map[string]string {
 	if x != nil {
-		return x.VirtualSizeBytes
+		return x.Headers
 	}
-	return 0
+	return nil
 }
 
-func (x *GetDiskInfoResponse) GetActualSizeBytes() int64 {
+func (x *ConsoleTicketResponse) GetPassword() string {
 	if x != nil {
-		return x.ActualSizeBytes
+		return x.Password
 	}
-	return 0
+	return ""
 }
 
-func (x *GetDiskInfoResponse) GetPath() string {
+func (x *ConsoleTicketResponse) GetCaBundle() []byte {
 	if x != nil {
-		return x.Path
+		return x.CaBundle
+	}
+	return nil
+}
+
+func (x *ConsoleTicketResponse) GetCertificateFingerprint() string {
+	if x != nil {
+		return x.CertificateFingerprint
 	}
 	return ""
 }
 
-func (x *GetDiskInfoResponse) GetIsBootable() bool {
+func (x *ConsoleTicketResponse) GetInsecureSkipVerify() bool {
 	if x != nil {
-		return x.IsBootable
+		return x.InsecureSkipVerify
 	}
 	return false
 }
 
-func (x *GetDiskInfoResponse) GetSnapshots() []string {
-	if x != nil {
-		return x.Snapshots
+// One message of a relayed console. The first message from the manager
+// names the VM; after that, data carries the console's bytes in both
+// directions.
+type ConsoleRelayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // VM identifier, first message only
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ConsoleRelayRequest) Reset() {
+	*x = ConsoleRelayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_provider_v1_provider_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *GetDiskInfoResponse) GetBackingFile() string {
+func (x *ConsoleRelayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleRelayRequest) ProtoMessage() {}
+
+func (x *ConsoleRelayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleRelayRequest.ProtoReflect.Descriptor instead.
+func (*ConsoleRelayRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ConsoleRelayRequest) GetId() string {
 	if x != nil {
-		return x.BackingFile
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetDiskInfoResponse) GetMetadata() map[string]string {
+func (x *ConsoleRelayRequest) GetData() []byte {
 	if x != nil {
-		return x.Metadata
+		return x.Data
 	}
 	return nil
 }
 
-// List all VMs managed by this provider
-type ListVMsRequest struct {
+type ConsoleRelayResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *ListVMsRequest) Reset() {
-	*x = ListVMsRequest{}
+func (x *ConsoleRelayResponse) Reset() {
+	*x = ConsoleRelayResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[29]
+		mi := &file_provider_v1_provider_proto_msgTypes[61]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListVMsRequest) String() string {
+func (x *ConsoleRelayResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVMsRequest) ProtoMessage() {}
+func (*ConsoleRelayResponse) ProtoMessage() {}
 
-func (x *ListVMsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[29]
+func (x *ConsoleRelayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[61]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2058,36 +4479,46 @@ func (x *ListVMsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVMsRequest.ProtoReflect.Descriptor instead.
-func (*ListVMsRequest) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ConsoleRelayResponse.ProtoReflect.Descriptor instead.
+func (*ConsoleRelayResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{61}
 }
 
-type ListVMsResponse struct {
+func (x *ConsoleRelayResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Stream what the hypervisor reports about VMs: tasks started outside
+// virtrigaud that failed, alarms, lifecycle changes. Events are normalized
+// so the manager can attach them to the VirtualMachine whose id matches.
+type WatchEventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Vms []*VMInfo `protobuf:"bytes,1,rep,name=vms,proto3" json:"vms,omitempty"`
+	SinceUnix int64 `protobuf:"varint,1,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"` // Also send events from this Unix time on, where the provider keeps a history; 0 sends new events only
 }
 
-func (x *ListVMsResponse) Reset() {
-	*x = ListVMsResponse{}
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[30]
+		mi := &file_provider_v1_provider_proto_msgTypes[62]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListVMsResponse) String() string {
+func (x *WatchEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVMsResponse) ProtoMessage() {}
+func (*WatchEventsRequest) ProtoMessage() {}
 
-func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[30]
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[62]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2098,51 +4529,48 @@ func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVMsResponse.ProtoReflect.Descriptor instead.
-func (*ListVMsResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *ListVMsResponse) GetVms() []*VMInfo {
+func (x *WatchEventsRequest) GetSinceUnix() int64 {
 	if x != nil {
-		return x.Vms
+		return x.SinceUnix
 	}
-	return nil
+	return 0
 }
 
-type VMInfo struct {
+type HypervisorEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                                              // Provider-specific VM identifier
-	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                          // VM name
-	PowerState  string            `protobuf:"bytes,3,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`                                                                                            // Current power state
-	Ips         []string          `protobuf:"bytes,4,rep,name=ips,proto3" json:"ips,omitempty"`                                                                                                                            // IP addresses
-	Cpu         int32             `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                                                           // Number of virtual CPUs
-	MemoryMib   int64             `protobuf:"varint,6,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`                                                                                              // Memory in MiB
-	Disks       []*DiskInfo       `protobuf:"bytes,7,rep,name=disks,proto3" json:"disks,omitempty"`                                                                                                                        // Disk information
-	Networks    []*NetworkInfo    `protobuf:"bytes,8,rep,name=networks,proto3" json:"networks,omitempty"`                                                                                                                  // Network information
-	ProviderRaw map[string]string `protobuf:"bytes,9,rep,name=provider_raw,json=providerRaw,proto3" json:"provider_raw,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Provider-specific metadata
+	VmId     string        `protobuf:"bytes,1,opt,name=vm_id,json=vmId,proto3" json:"vm_id,omitempty"` // VM identifier, as returned by Create; empty for events that concern no single VM
+	Severity EventSeverity `protobuf:"varint,2,opt,name=severity,proto3,enum=provider.v1.EventSeverity" json:"severity,omitempty"`
+	Reason   string        `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Short UpperCamelCase cause, e.g. "TaskFailed", "DomainCrashed"
+	Message  string        `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	TimeUnix int64         `protobuf:"varint,5,opt,name=time_unix,json=timeUnix,proto3" json:"time_unix,omitempty"` // When the hypervisor recorded the event, in Unix seconds
+	Resolved bool          `protobuf:"varint,6,opt,name=resolved,proto3" json:"resolved,omitempty"`                 // The condition an earlier warning or error with the same vm_id and reason reported has cleared
 }
 
-func (x *VMInfo) Reset() {
-	*x = VMInfo{}
+func (x *HypervisorEvent) Reset() {
+	*x = HypervisorEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[31]
+		mi := &file_provider_v1_provider_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VMInfo) String() string {
+func (x *HypervisorEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VMInfo) ProtoMessage() {}
+func (*HypervisorEvent) ProtoMessage() {}
 
-func (x *VMInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[31]
+func (x *HypervisorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2153,102 +4581,78 @@ func (x *VMInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VMInfo.ProtoReflect.Descriptor instead.
-func (*VMInfo) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use HypervisorEvent.ProtoReflect.Descriptor instead.
+func (*HypervisorEvent) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *VMInfo) GetId() string {
+func (x *HypervisorEvent) GetVmId() string {
 	if x != nil {
-		return x.Id
+		return x.VmId
 	}
 	return ""
 }
 
-func (x *VMInfo) GetName() string {
+func (x *HypervisorEvent) GetSeverity() EventSeverity {
 	if x != nil {
-		return x.Name
+		return x.Severity
 	}
-	return ""
+	return EventSeverity_EVENT_SEVERITY_UNSPECIFIED
 }
 
-func (x *VMInfo) GetPowerState() string {
+func (x *HypervisorEvent) GetReason() string {
 	if x != nil {
-		return x.PowerState
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *VMInfo) GetIps() []string {
-	if x != nil {
-		return x.Ips
-	}
-	return nil
-}
-
-func (x *VMInfo) GetCpu() int32 {
+func (x *HypervisorEvent) GetMessage() string {
 	if x != nil {
-		return x.Cpu
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *VMInfo) GetMemoryMib() int64 {
+func (x *HypervisorEvent) GetTimeUnix() int64 {
 	if x != nil {
-		return x.MemoryMib
+		return x.TimeUnix
 	}
 	return 0
 }
 
-func (x *VMInfo) GetDisks() []*DiskInfo {
-	if x != nil {
-		return x.Disks
-	}
-	return nil
-}
-
-func (x *VMInfo) GetNetworks() []*NetworkInfo {
-	if x != nil {
-		return x.Networks
-	}
-	return nil
-}
-
-func (x *VMInfo) GetProviderRaw() map[string]string {
+func (x *HypervisorEvent) GetResolved() bool {
 	if x != nil {
-		return x.ProviderRaw
+		return x.Resolved
 	}
-	return nil
+	return false
 }
 
-type DiskInfo struct {
+// Build and backend version information, for attributing behaviour to a
+// specific provider image and hypervisor release.
+type GetInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                           // Disk identifier
-	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                       // Disk path
-	SizeGib int32  `protobuf:"varint,3,opt,name=size_gib,json=sizeGib,proto3" json:"size_gib,omitempty"` // Disk size in GiB
-	Format  string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                   // Disk format (qcow2, vmdk, raw, etc.)
 }
 
-func (x *DiskInfo) Reset() {
-	*x = DiskInfo{}
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[32]
+		mi := &file_provider_v1_provider_proto_msgTypes[64]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DiskInfo) String() string {
+func (x *GetInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskInfo) ProtoMessage() {}
+func (*GetInfoRequest) ProtoMessage() {}
 
-func (x *DiskInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[32]
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[64]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2259,66 +4663,40 @@ func (x *DiskInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskInfo.ProtoReflect.Descriptor instead.
-func (*DiskInfo) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *DiskInfo) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *DiskInfo) GetPath() string {
-	if x != nil {
-		return x.Path
-	}
-	return ""
-}
-
-func (x *DiskInfo) GetSizeGib() int32 {
-	if x != nil {
-		return x.SizeGib
-	}
-	return 0
-}
-
-func (x *DiskInfo) GetFormat() string {
-	if x != nil {
-		return x.Format
-	}
-	return ""
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{64}
 }
 
-type NetworkInfo struct {
+type GetInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // Network name
-	Mac       string `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`                              // MAC address
-	IpAddress string `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // IP address if static
+	ProviderVersion   string `protobuf:"bytes,1,opt,name=provider_version,json=providerVersion,proto3" json:"provider_version,omitempty"`       // Provider build version (internal/version.Version)
+	GitSha            string `protobuf:"bytes,2,opt,name=git_sha,json=gitSha,proto3" json:"git_sha,omitempty"`                                  // Commit the provider was built from
+	HypervisorProduct string `protobuf:"bytes,3,opt,name=hypervisor_product,json=hypervisorProduct,proto3" json:"hypervisor_product,omitempty"` // e.g. "Proxmox VE", "VMware vCenter Server", "libvirt"
+	HypervisorVersion string `protobuf:"bytes,4,opt,name=hypervisor_version,json=hypervisorVersion,proto3" json:"hypervisor_version,omitempty"` // e.g. "8.2.4", "8.0.2", "10.0.0"
+	HypervisorDetails string `protobuf:"bytes,5,opt,name=hypervisor_details,json=hypervisorDetails,proto3" json:"hypervisor_details,omitempty"` // Free-form extra detail: build number, release, driver version
 }
 
-func (x *NetworkInfo) Reset() {
-	*x = NetworkInfo{}
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[33]
+		mi := &file_provider_v1_provider_proto_msgTypes[65]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NetworkInfo) String() string {
+func (x *GetInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkInfo) ProtoMessage() {}
+func (*GetInfoResponse) ProtoMessage() {}
 
-func (x *NetworkInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[33]
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_v1_provider_proto_msgTypes[65]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2329,28 +4707,42 @@ func (x *NetworkInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkInfo.ProtoReflect.Descriptor instead.
-func (*NetworkInfo) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *NetworkInfo) GetName() string {
+func (x *GetInfoResponse) GetProviderVersion() string {
 	if x != nil {
-		return x.Name
+		return x.ProviderVersion
 	}
 	return ""
 }
 
-func (x *NetworkInfo) GetMac() string {
+func (x *GetInfoResponse) GetGitSha() string {
 	if x != nil {
-		return x.Mac
+		return x.GitSha
 	}
 	return ""
 }
 
-func (x *NetworkInfo) GetIpAddress() string {
+func (x *GetInfoResponse) GetHypervisorProduct() string {
 	if x != nil {
-		return x.IpAddress
+		return x.HypervisorProduct
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetHypervisorVersion() string {
+	if x != nil {
+		return x.HypervisorVersion
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetHypervisorDetails() string {
+	if x != nil {
+		return x.HypervisorDetails
 	}
 	return ""
 }
@@ -2365,7 +4757,7 @@ type GetCapabilitiesRequest struct {
 func (x *GetCapabilitiesRequest) Reset() {
 	*x = GetCapabilitiesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[34]
+		mi := &file_provider_v1_provider_proto_msgTypes[66]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2378,7 +4770,7 @@ func (x *GetCapabilitiesRequest) String() string {
 func (*GetCapabilitiesRequest) ProtoMessage() {}
 
 func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[34]
+	mi := &file_provider_v1_provider_proto_msgTypes[66]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2391,7 +4783,7 @@ func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
 func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{34}
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{66}
 }
 
 type GetCapabilitiesResponse struct {
@@ -2415,12 +4807,28 @@ type GetCapabilitiesResponse struct {
 	SupportedExportBackends     []string `protobuf:"bytes,14,rep,name=supported_export_backends,json=supportedExportBackends,proto3" json:"supported_export_backends,omitempty"`        // Export staging backends: "pvc"|"nfs"|"s3" (ADR-0006; empty == pvc-only)
 	SupportedImportBackends     []string `protobuf:"bytes,15,rep,name=supported_import_backends,json=supportedImportBackends,proto3" json:"supported_import_backends,omitempty"`        // Import staging backends: "pvc"|"nfs"|"s3" (ADR-0006; empty == pvc-only)
 	SupportedTransferModes      []string `protobuf:"bytes,16,rep,name=supported_transfer_modes,json=supportedTransferModes,proto3" json:"supported_transfer_modes,omitempty"`           // Transfer modes: "relay"|"direct" (ADR-0006; empty == relay-only)
+	SupportsConsoleOutput       bool     `protobuf:"varint,17,opt,name=supports_console_output,json=supportsConsoleOutput,proto3" json:"supports_console_output,omitempty"`             // Implements GetConsoleOutput
+	SupportsSysprep             bool     `protobuf:"varint,18,opt,name=supports_sysprep,json=supportsSysprep,proto3" json:"supports_sysprep,omitempty"`                                 // Applies Sysprep guest customization (unattend.xml) at create
+	SupportsCloudInitStatus     bool     `protobuf:"varint,19,opt,name=supports_cloud_init_status,json=supportsCloudInitStatus,proto3" json:"supports_cloud_init_status,omitempty"`     // Implements GetCloudInitStatus
+	SupportsLiveMigration       bool     `protobuf:"varint,20,opt,name=supports_live_migration,json=supportsLiveMigration,proto3" json:"supports_live_migration,omitempty"`             // Implements MigrateHost
+	// Oldest and newest protocol versions the provider implements. The SDK
+	// server fills them in from PROTOCOL_VERSION_CURRENT when left unset.
+	MinProtocolVersion   ProtocolVersion `protobuf:"varint,21,opt,name=min_protocol_version,json=minProtocolVersion,proto3,enum=provider.v1.ProtocolVersion" json:"min_protocol_version,omitempty"`
+	MaxProtocolVersion   ProtocolVersion `protobuf:"varint,22,opt,name=max_protocol_version,json=maxProtocolVersion,proto3,enum=provider.v1.ProtocolVersion" json:"max_protocol_version,omitempty"`
+	SupportsConsoleProxy bool            `protobuf:"varint,23,opt,name=supports_console_proxy,json=supportsConsoleProxy,proto3" json:"supports_console_proxy,omitempty"` // Implements ConsoleTicket, and ConsoleRelay where tickets carry no url
+	SupportsImagePublish bool            `protobuf:"varint,24,opt,name=supports_image_publish,json=supportsImagePublish,proto3" json:"supports_image_publish,omitempty"` // Implements PublishImage
+	// Capability flags the provider image was built with, as set by its
+	// capabilities.Builder call ("image_import", "snapshots", ...). Empty
+	// from images built before the manifest existed.
+	CapabilityManifest        []string `protobuf:"bytes,25,rep,name=capability_manifest,json=capabilityManifest,proto3" json:"capability_manifest,omitempty"`
+	SupportsEventWatch        bool     `protobuf:"varint,26,opt,name=supports_event_watch,json=supportsEventWatch,proto3" json:"supports_event_watch,omitempty"`                      // Implements WatchEvents
+	SupportsQuiescedSnapshots bool     `protobuf:"varint,27,opt,name=supports_quiesced_snapshots,json=supportsQuiescedSnapshots,proto3" json:"supports_quiesced_snapshots,omitempty"` // Honors SnapshotCreateRequest.quiesce and reports the outcome
 }
 
 func (x *GetCapabilitiesResponse) Reset() {
 	*x = GetCapabilitiesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_provider_v1_provider_proto_msgTypes[35]
+		mi := &file_provider_v1_provider_proto_msgTypes[67]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2433,7 +4841,7 @@ func (x *GetCapabilitiesResponse) String() string {
 func (*GetCapabilitiesResponse) ProtoMessage() {}
 
 func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_provider_v1_provider_proto_msgTypes[35]
+	mi := &file_provider_v1_provider_proto_msgTypes[67]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2446,7 +4854,7 @@ func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
 func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
-	return file_provider_v1_provider_proto_rawDescGZIP(), []int{35}
+	return file_provider_v1_provider_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *GetCapabilitiesResponse) GetSupportsReconfigureOnline() bool {
@@ -2465,100 +4873,177 @@ func (x *GetCapabilitiesResponse) GetSupportsDiskExpansionOnline() bool {
 
 func (x *GetCapabilitiesResponse) GetSupportsSnapshots() bool {
 	if x != nil {
-		return x.SupportsSnapshots
+		return x.SupportsSnapshots
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsMemorySnapshots() bool {
+	if x != nil {
+		return x.SupportsMemorySnapshots
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsLinkedClones() bool {
+	if x != nil {
+		return x.SupportsLinkedClones
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsImageImport() bool {
+	if x != nil {
+		return x.SupportsImageImport
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportedDiskTypes() []string {
+	if x != nil {
+		return x.SupportedDiskTypes
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetSupportedNetworkTypes() []string {
+	if x != nil {
+		return x.SupportedNetworkTypes
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsDiskExport() bool {
+	if x != nil {
+		return x.SupportsDiskExport
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsDiskImport() bool {
+	if x != nil {
+		return x.SupportsDiskImport
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportedExportFormats() []string {
+	if x != nil {
+		return x.SupportedExportFormats
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetSupportedImportFormats() []string {
+	if x != nil {
+		return x.SupportedImportFormats
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetSupportsExportCompression() bool {
+	if x != nil {
+		return x.SupportsExportCompression
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetSupportedExportBackends() []string {
+	if x != nil {
+		return x.SupportedExportBackends
 	}
-	return false
+	return nil
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsMemorySnapshots() bool {
+func (x *GetCapabilitiesResponse) GetSupportedImportBackends() []string {
 	if x != nil {
-		return x.SupportsMemorySnapshots
+		return x.SupportedImportBackends
 	}
-	return false
+	return nil
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsLinkedClones() bool {
+func (x *GetCapabilitiesResponse) GetSupportedTransferModes() []string {
 	if x != nil {
-		return x.SupportsLinkedClones
+		return x.SupportedTransferModes
 	}
-	return false
+	return nil
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsImageImport() bool {
+func (x *GetCapabilitiesResponse) GetSupportsConsoleOutput() bool {
 	if x != nil {
-		return x.SupportsImageImport
+		return x.SupportsConsoleOutput
 	}
 	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedDiskTypes() []string {
+func (x *GetCapabilitiesResponse) GetSupportsSysprep() bool {
 	if x != nil {
-		return x.SupportedDiskTypes
+		return x.SupportsSysprep
 	}
-	return nil
+	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedNetworkTypes() []string {
+func (x *GetCapabilitiesResponse) GetSupportsCloudInitStatus() bool {
 	if x != nil {
-		return x.SupportedNetworkTypes
+		return x.SupportsCloudInitStatus
 	}
-	return nil
+	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsDiskExport() bool {
+func (x *GetCapabilitiesResponse) GetSupportsLiveMigration() bool {
 	if x != nil {
-		return x.SupportsDiskExport
+		return x.SupportsLiveMigration
 	}
 	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsDiskImport() bool {
+func (x *GetCapabilitiesResponse) GetMinProtocolVersion() ProtocolVersion {
 	if x != nil {
-		return x.SupportsDiskImport
+		return x.MinProtocolVersion
 	}
-	return false
+	return ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedExportFormats() []string {
+func (x *GetCapabilitiesResponse) GetMaxProtocolVersion() ProtocolVersion {
 	if x != nil {
-		return x.SupportedExportFormats
+		return x.MaxProtocolVersion
 	}
-	return nil
+	return ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedImportFormats() []string {
+func (x *GetCapabilitiesResponse) GetSupportsConsoleProxy() bool {
 	if x != nil {
-		return x.SupportedImportFormats
+		return x.SupportsConsoleProxy
 	}
-	return nil
+	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportsExportCompression() bool {
+func (x *GetCapabilitiesResponse) GetSupportsImagePublish() bool {
 	if x != nil {
-		return x.SupportsExportCompression
+		return x.SupportsImagePublish
 	}
 	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedExportBackends() []string {
+func (x *GetCapabilitiesResponse) GetCapabilityManifest() []string {
 	if x != nil {
-		return x.SupportedExportBackends
+		return x.CapabilityManifest
 	}
 	return nil
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedImportBackends() []string {
+func (x *GetCapabilitiesResponse) GetSupportsEventWatch() bool {
 	if x != nil {
-		return x.SupportedImportBackends
+		return x.SupportsEventWatch
 	}
-	return nil
+	return false
 }
 
-func (x *GetCapabilitiesResponse) GetSupportedTransferModes() []string {
+func (x *GetCapabilitiesResponse) GetSupportsQuiescedSnapshots() bool {
 	if x != nil {
-		return x.SupportedTransferModes
+		return x.SupportsQuiescedSnapshots
 	}
-	return nil
+	return false
 }
 
 var File_provider_v1_provider_proto protoreflect.FileDescriptor
@@ -2566,300 +5051,616 @@ var File_provider_v1_provider_proto protoreflect.FileDescriptor
 var file_provider_v1_provider_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72,
 	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x19, 0x0a, 0x07, 0x54, 0x61, 0x73,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x2d, 0x0a, 0x07, 0x54, 0x61, 0x73,
 	0x6b, 0x52, 0x65, 0x66, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x11, 0x0a,
-	0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x3c, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9a,
-	0x02, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d,
-	0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
-	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x4a, 0x73, 0x6f,
-	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x4a, 0x73, 0x6f, 0x6e,
-	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6a, 0x73,
-	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x4a, 0x0a, 0x0e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a,
-	0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
-	0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x1f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x11, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0xc5, 0x03, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4a, 0x73, 0x6f,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x73, 0x6f, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x5f, 0x6a, 0x73, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x73,
+	0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x76, 0x65, 0x6e,
+	0x64, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79,
+	0x12, 0x38, 0x0a, 0x18, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x16, 0x67, 0x75, 0x65, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x76, 0x0a, 0x0e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66,
+	0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x5f, 0x72, 0x61, 0x77, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x4a, 0x73,
+	0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0d, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42,
+	0x11, 0x0a, 0x0f, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x73, 0x22, 0x7e, 0x0a, 0x0c, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x24, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x38, 0x0a, 0x18, 0x67, 0x72, 0x61, 0x63,
+	0x65, 0x66, 0x75, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x67, 0x72, 0x61, 0x63,
+	0x65, 0x66, 0x75, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x22, 0x79, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
 	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x7e, 0x0a, 0x0c, 0x50, 0x6f, 0x77, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x38,
-	0x0a, 0x18, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x16, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x47, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21,
-	0x0a, 0x0c, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x4a, 0x73, 0x6f,
-	0x6e, 0x22, 0x4f, 0x0a, 0x16, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67,
-	0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x0c, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x21, 0x0a, 0x0f,
-	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0xaa, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
-	0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12,
-	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x55, 0x72, 0x6c,
-	0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x77,
-	0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x11,
-	0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
-	0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x3e, 0x0a, 0x12, 0x54,
-	0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x92, 0x01, 0x0a, 0x15,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x53, 0x65, 0x74, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x31, 0x0a,
+	0x0b, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6f, 0x6c, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6e, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6e, 0x65, 0x77,
+	0x22, 0x31, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6f, 0x6c,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x6e, 0x65, 0x77, 0x22, 0x67, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x6b, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x67, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x6f, 0x6c, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x47, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x65,
+	0x77, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x67, 0x69, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x6e, 0x65, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x47, 0x69, 0x62, 0x22, 0x4c, 0x0a, 0x0d,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x47, 0x0a, 0x13, 0x53, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x22, 0xdb, 0x02, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65,
+	0x74, 0x12, 0x2a, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74,
+	0x33, 0x32, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x37, 0x0a,
+	0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x74, 0x36, 0x34, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x09, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4d, 0x69, 0x62, 0x12, 0x30, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x41, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0d, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x49, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x22, 0x71, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61,
+	0x73, 0x6b, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x12, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x16, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x0c, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22,
+	0x21, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0xb9, 0x02, 0x0a, 0x10, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69,
+	0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65,
+	0x55, 0x72, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f,
+	0x72, 0x61, 0x77, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x4a, 0x73, 0x6f, 0x6e, 0x12,
+	0x38, 0x0a, 0x0b, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x67,
+	0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x28,
+	0x0a, 0x14, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0xc0, 0x02, 0x0a, 0x15, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x49, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x46, 0x0a,
+	0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0x59, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x39, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x0c, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x22, 0xdc, 0x01, 0x0a, 0x0a, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x11, 0x63, 0x70, 0x75, 0x5f,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e,
+	0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01, 0x01, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x63, 0x70, 0x75,
+	0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x15,
+	0x0a, 0x13, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x3d, 0x0a, 0x11, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
+	0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x58, 0x0a, 0x12, 0x54, 0x61, 0x73, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xd7, 0x01, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65,
+	0x12, 0x29, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x75, 0x6e, 0x71, 0x75, 0x69, 0x65,
+	0x73, 0x63, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x55, 0x6e, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x64, 0x22, 0xd8, 0x02, 0x0a, 0x16,
 	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e,
-	0x61, 0x6d, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
-	0x64, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x63, 0x0a, 0x16, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74,
-	0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52,
-	0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x4d, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73,
+	0x6b, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x55, 0x6e, 0x69, 0x78, 0x12, 0x35, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x52, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x71,
+	0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x4d, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x76, 0x6d, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13,
 	0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76,
 	0x6d, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f,
 	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a,
-	0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d,
-	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x49, 0x64, 0x22, 0xd6, 0x01, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x76,
-	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x56, 0x6d, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25,
-	0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6a, 0x73, 0x6f, 0x6e,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69,
-	0x7a, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
-	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x0d,
-	0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
-	0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x6d, 0x49, 0x64, 0x12,
-	0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
-	0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x78, 0x0a, 0x13, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x12,
-	0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x48,
-	0x69, 0x6e, 0x74, 0x22, 0x9c, 0x01, 0x0a, 0x14, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65,
-	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04,
-	0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66,
-	0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72,
-	0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x69,
-	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x11, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61,
-	0x74, 0x68, 0x22, 0xcc, 0x03, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73,
-	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64, 0x12, 0x17, 0x0a,
-	0x07, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x64, 0x69, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x6c,
-	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x51, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69,
-	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x65,
-	0x6e, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
-	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12,
-	0x30, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f,
-	0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0xa9, 0x01, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x6f,
-	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20,
+	0x6f, 0x74, 0x49, 0x64, 0x22, 0x2a, 0x0a, 0x13, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64,
+	0x22, 0xa5, 0x02, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x61,
+	0x73, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x68, 0x61, 0x73, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x73, 0x69,
+	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x01, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x85, 0x02, 0x0a, 0x0c, 0x43, 0x6c,
+	0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x6d, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x4a, 0x73,
+	0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x73, 0x6f,
+	0x6e, 0x22, 0xc1, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x56, 0x6d, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
 	0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12,
-	0x30, 0x0a, 0x14, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x65,
-	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0xf1, 0x03,
-	0x0a, 0x11, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55,
-	0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x69,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a,
-	0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27,
-	0x0a, 0x0f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
-	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x73, 0x75, 0x6d, 0x12, 0x51, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69,
-	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x65,
-	0x6e, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
-	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12,
-	0x30, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f,
-	0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
+	0x33, 0x0a, 0x15, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x78, 0x0a, 0x13, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x22,
+	0x9c, 0x01, 0x0a, 0x14, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61,
+	0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x2e,
+	0x0a, 0x13, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x8a,
+	0x01, 0x0a, 0x13, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x22, 0xbb, 0x01, 0x0a, 0x14,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x19,
+	0x0a, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x22, 0xcc, 0x03, 0x0a, 0x11, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x76, 0x6d, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x51, 0x0a, 0x0b, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa9, 0x01, 0x0a, 0x12, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66,
+	0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x53,
+	0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x22, 0xf1, 0x03, 0x0a, 0x11, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44,
+	0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x2b,
+	0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x51, 0x0a, 0x0b, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x12, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x69, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66,
+	0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x63,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x69, 0x73,
+	0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x73, 0x6b,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x49, 0x64, 0x22, 0x9f, 0x03, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69,
+	0x73, 0x6b, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2c, 0x0a, 0x12,
+	0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6c, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63,
+	0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73,
+	0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x69, 0x73, 0x42, 0x6f, 0x6f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x63,
+	0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x4a, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x10, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x4d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x76, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x76, 0x6d,
+	0x73, 0x22, 0xfc, 0x02, 0x0a, 0x06, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03,
+	0x69, 0x70, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f,
+	0x6d, 0x69, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x4d, 0x69, 0x62, 0x12, 0x2b, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b,
+	0x73, 0x12, 0x34, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x47, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x4d, 0x49, 0x6e,
+	0x66, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77,
+	0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x61, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x67, 0x69, 0x62, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x73, 0x69, 0x7a, 0x65, 0x47, 0x69, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x22, 0x52, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x48, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x61, 0x69, 0x6c, 0x4c, 0x69, 0x6e, 0x65,
+	0x73, 0x22, 0x68, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x2b, 0x0a, 0x19, 0x47,
+	0x65, 0x74, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x93, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x14,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0xa0, 0x02, 0x0a, 0x0c, 0x48, 0x6f, 0x73, 0x74, 0x43, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75,
+	0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x70,
+	0x75, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x74,
+	0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0e, 0x63, 0x70, 0x75, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x55, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73,
+	0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
+	0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74,
+	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22,
+	0x45, 0x0a, 0x12, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c,
+	0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf0,
+	0x02, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x49, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x61, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x63, 0x61, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x37, 0x0a, 0x17, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x5f,
+	0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0xb3, 0x01, 0x0a, 0x12, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x6b,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x73, 0x6b, 0x49,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12,
-	0x2a, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62,
-	0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x75,
-	0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63,
-	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
-	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x63, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x69,
-	0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x0a,
-	0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6d,
-	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x22, 0x9f, 0x03, 0x0a,
-	0x13, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x10, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79,
-	0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x69,
-	0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
-	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
-	0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x42, 0x6f, 0x6f, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e,
-	0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x4a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x10,
-	0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x38, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x76, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56,
-	0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x76, 0x6d, 0x73, 0x22, 0xfc, 0x02, 0x0a, 0x06, 0x56,
-	0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x77,
-	0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x10, 0x0a, 0x03,
-	0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x1d,
-	0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x69, 0x62, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x09, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x69, 0x62, 0x12, 0x2b, 0x0a,
-	0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73,
-	0x12, 0x47, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x77,
-	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x61, 0x77, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x61, 0x0a, 0x08, 0x44, 0x69, 0x73,
-	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x7a,
-	0x65, 0x5f, 0x67, 0x69, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x69, 0x7a,
-	0x65, 0x47, 0x69, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x52, 0x0a, 0x0b,
-	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61,
-	0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x01, 0x22, 0x39, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2a, 0x0a, 0x14,
+	0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x33, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x22, 0xc9, 0x01,
+	0x0a, 0x0f, 0x48, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x13, 0x0a, 0x05, 0x76, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x76, 0x6d, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x69,
+	0x74, 0x5f, 0x73, 0x68, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x69, 0x74,
+	0x53, 0x68, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x68,
+	0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
 	0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa7, 0x07, 0x0a, 0x17, 0x47,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xae, 0x0c, 0x0a, 0x17, 0x47,
 	0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65,
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
 	0x74, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x5f, 0x6f,
@@ -2918,46 +5719,119 @@ var file_provider_v1_provider_proto_rawDesc = []byte{
 	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
 	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x73, 0x75,
 	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4d,
-	0x6f, 0x64, 0x65, 0x73, 0x2a, 0x7b, 0x0a, 0x07, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x70, 0x12,
-	0x18, 0x0a, 0x14, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x57,
-	0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x4f,
-	0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x4f, 0x46, 0x46, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f,
-	0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x52, 0x45, 0x42, 0x4f, 0x4f, 0x54, 0x10,
-	0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x53, 0x48,
-	0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x5f, 0x47, 0x52, 0x41, 0x43, 0x45, 0x46, 0x55, 0x4c, 0x10,
-	0x04, 0x32, 0xf2, 0x0a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x47,
-	0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x43,
+	0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x10,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x73, 0x79, 0x73, 0x70, 0x72, 0x65, 0x70,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x53, 0x79, 0x73, 0x70, 0x72, 0x65, 0x70, 0x12, 0x3b, 0x0a, 0x1a, 0x73, 0x75, 0x70, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x5f, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c,
+	0x69, 0x76, 0x65, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x14,
+	0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x14,
+	0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x16,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x78, 0x79, 0x12, 0x34, 0x0a, 0x16, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x14, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x18,
+	0x19, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x77, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3e, 0x0a, 0x1b, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x64,
+	0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x19, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x51, 0x75, 0x69, 0x65, 0x73, 0x63,
+	0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x2a, 0x8f, 0x01, 0x0a, 0x07,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x70, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x4f, 0x57, 0x45, 0x52,
+	0x5f, 0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x4f, 0x4e,
+	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x4f,
+	0x46, 0x46, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4f, 0x50,
+	0x5f, 0x52, 0x45, 0x42, 0x4f, 0x4f, 0x54, 0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x4f, 0x57,
+	0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x5f, 0x47,
+	0x52, 0x41, 0x43, 0x45, 0x46, 0x55, 0x4c, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4f, 0x57,
+	0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x05, 0x2a, 0x8a, 0x01,
+	0x0a, 0x0e, 0x51, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x12, 0x1f, 0x0a, 0x1b, 0x51, 0x55, 0x49, 0x45, 0x53, 0x43, 0x45, 0x5f, 0x4f, 0x55, 0x54, 0x43,
+	0x4f, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1c, 0x0a, 0x18, 0x51, 0x55, 0x49, 0x45, 0x53, 0x43, 0x45, 0x5f, 0x4f, 0x55, 0x54,
+	0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x41, 0x43, 0x48, 0x49, 0x45, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12,
+	0x1c, 0x0a, 0x18, 0x51, 0x55, 0x49, 0x45, 0x53, 0x43, 0x45, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f,
+	0x4d, 0x45, 0x5f, 0x44, 0x45, 0x47, 0x52, 0x41, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a,
+	0x17, 0x51, 0x55, 0x49, 0x45, 0x53, 0x43, 0x45, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45,
+	0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x7e, 0x0a, 0x0d, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x49, 0x4e,
+	0x46, 0x4f, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x45,
+	0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02,
+	0x12, 0x18, 0x0a, 0x14, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x2a, 0x85, 0x01, 0x0a, 0x0f, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20,
+	0x0a, 0x1c, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x56, 0x45, 0x52,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x31, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x32, 0x10, 0x02,
+	0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x56, 0x45, 0x52,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x1a, 0x02,
+	0x10, 0x01, 0x32, 0xa7, 0x12, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x47, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x05,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x50,
-	0x6f, 0x77, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
-	0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0b, 0x52, 0x65,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
-	0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x55,
-	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x08, 0x44, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x62, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0f, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x08, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
 	0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
 	0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
 	0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
@@ -2978,54 +5852,107 @@ var file_provider_v1_provider_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61,
 	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x05, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a,
-	0x0c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x20, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x4d, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1e,
-	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x4d, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1e, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
-	0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
-	0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50,
-	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x2e,
-	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44,
-	0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
-	0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x44, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xb3, 0x01, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x70,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x62,
-	0x65, 0x73, 0x6b, 0x61, 0x72, 0x2f, 0x76, 0x69, 0x72, 0x74, 0x72, 0x69, 0x67, 0x61, 0x75, 0x64,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x76,
-	0x31, 0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x0b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x17, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5c, 0x56,
-	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0c,
-	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a,
+	0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61,
+	0x72, 0x65, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x23, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x4d, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5f, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73,
+	0x6f, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x65, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e,
+	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x49, 0x0a, 0x0b, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x12,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x43,
+	0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x6f,
+	0x6c, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0b,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72,
+	0x76, 0x69, 0x73, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0xb3, 0x01, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x42, 0x0d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x62, 0x65, 0x73, 0x6b, 0x61, 0x72, 0x2f, 0x76, 0x69, 0x72, 0x74,
+	0x72, 0x69, 0x67, 0x61, 0x75, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63,
+	0x2f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x0b,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0b, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x17, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x3a, 0x3a,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3040,109 +5967,189 @@ func file_provider_v1_provider_proto_rawDescGZIP() []byte {
 	return file_provider_v1_provider_proto_rawDescData
 }
 
-var file_provider_v1_provider_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_provider_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_provider_v1_provider_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_provider_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 75)
 var file_provider_v1_provider_proto_goTypes = []any{
-	(PowerOp)(0),                    // 0: provider.v1.PowerOp
-	(*TaskRef)(nil),                 // 1: provider.v1.TaskRef
-	(*Empty)(nil),                   // 2: provider.v1.Empty
-	(*ValidateRequest)(nil),         // 3: provider.v1.ValidateRequest
-	(*ValidateResponse)(nil),        // 4: provider.v1.ValidateResponse
-	(*CreateRequest)(nil),           // 5: provider.v1.CreateRequest
-	(*CreateResponse)(nil),          // 6: provider.v1.CreateResponse
-	(*DeleteRequest)(nil),           // 7: provider.v1.DeleteRequest
-	(*PowerRequest)(nil),            // 8: provider.v1.PowerRequest
-	(*ReconfigureRequest)(nil),      // 9: provider.v1.ReconfigureRequest
-	(*HardwareUpgradeRequest)(nil),  // 10: provider.v1.HardwareUpgradeRequest
-	(*TaskResponse)(nil),            // 11: provider.v1.TaskResponse
-	(*DescribeRequest)(nil),         // 12: provider.v1.DescribeRequest
-	(*DescribeResponse)(nil),        // 13: provider.v1.DescribeResponse
-	(*TaskStatusRequest)(nil),       // 14: provider.v1.TaskStatusRequest
-	(*TaskStatusResponse)(nil),      // 15: provider.v1.TaskStatusResponse
-	(*SnapshotCreateRequest)(nil),   // 16: provider.v1.SnapshotCreateRequest
-	(*SnapshotCreateResponse)(nil),  // 17: provider.v1.SnapshotCreateResponse
-	(*SnapshotDeleteRequest)(nil),   // 18: provider.v1.SnapshotDeleteRequest
-	(*SnapshotRevertRequest)(nil),   // 19: provider.v1.SnapshotRevertRequest
-	(*CloneRequest)(nil),            // 20: provider.v1.CloneRequest
-	(*CloneResponse)(nil),           // 21: provider.v1.CloneResponse
-	(*ImagePrepareRequest)(nil),     // 22: provider.v1.ImagePrepareRequest
-	(*ImagePrepareResponse)(nil),    // 23: provider.v1.ImagePrepareResponse
-	(*ExportDiskRequest)(nil),       // 24: provider.v1.ExportDiskRequest
-	(*ExportDiskResponse)(nil),      // 25: provider.v1.ExportDiskResponse
-	(*ImportDiskRequest)(nil),       // 26: provider.v1.ImportDiskRequest
-	(*ImportDiskResponse)(nil),      // 27: provider.v1.ImportDiskResponse
-	(*GetDiskInfoRequest)(nil),      // 28: provider.v1.GetDiskInfoRequest
-	(*GetDiskInfoResponse)(nil),     // 29: provider.v1.GetDiskInfoResponse
-	(*ListVMsRequest)(nil),          // 30: provider.v1.ListVMsRequest
-	(*ListVMsResponse)(nil),         // 31: provider.v1.ListVMsResponse
-	(*VMInfo)(nil),                  // 32: provider.v1.VMInfo
-	(*DiskInfo)(nil),                // 33: provider.v1.DiskInfo
-	(*NetworkInfo)(nil),             // 34: provider.v1.NetworkInfo
-	(*GetCapabilitiesRequest)(nil),  // 35: provider.v1.GetCapabilitiesRequest
-	(*GetCapabilitiesResponse)(nil), // 36: provider.v1.GetCapabilitiesResponse
-	nil,                             // 37: provider.v1.ExportDiskRequest.CredentialsEntry
-	nil,                             // 38: provider.v1.ImportDiskRequest.CredentialsEntry
-	nil,                             // 39: provider.v1.GetDiskInfoResponse.MetadataEntry
-	nil,                             // 40: provider.v1.VMInfo.ProviderRawEntry
+	(PowerOp)(0),                       // 0: provider.v1.PowerOp
+	(QuiesceOutcome)(0),                // 1: provider.v1.QuiesceOutcome
+	(EventSeverity)(0),                 // 2: provider.v1.EventSeverity
+	(ProtocolVersion)(0),               // 3: provider.v1.ProtocolVersion
+	(*TaskRef)(nil),                    // 4: provider.v1.TaskRef
+	(*Empty)(nil),                      // 5: provider.v1.Empty
+	(*ValidateRequest)(nil),            // 6: provider.v1.ValidateRequest
+	(*ValidateResponse)(nil),           // 7: provider.v1.ValidateResponse
+	(*CreateRequest)(nil),              // 8: provider.v1.CreateRequest
+	(*CreateResponse)(nil),             // 9: provider.v1.CreateResponse
+	(*DeleteRequest)(nil),              // 10: provider.v1.DeleteRequest
+	(*PowerRequest)(nil),               // 11: provider.v1.PowerRequest
+	(*ReconfigureRequest)(nil),         // 12: provider.v1.ReconfigureRequest
+	(*Int32Change)(nil),                // 13: provider.v1.Int32Change
+	(*Int64Change)(nil),                // 14: provider.v1.Int64Change
+	(*DiskExpansion)(nil),              // 15: provider.v1.DiskExpansion
+	(*NetworkChange)(nil),              // 16: provider.v1.NetworkChange
+	(*SecurityGroupChange)(nil),        // 17: provider.v1.SecurityGroupChange
+	(*ChangeSet)(nil),                  // 18: provider.v1.ChangeSet
+	(*ReconfigureResponse)(nil),        // 19: provider.v1.ReconfigureResponse
+	(*HardwareUpgradeRequest)(nil),     // 20: provider.v1.HardwareUpgradeRequest
+	(*TaskResponse)(nil),               // 21: provider.v1.TaskResponse
+	(*DescribeRequest)(nil),            // 22: provider.v1.DescribeRequest
+	(*DescribeResponse)(nil),           // 23: provider.v1.DescribeResponse
+	(*DescribeBatchRequest)(nil),       // 24: provider.v1.DescribeBatchRequest
+	(*DescribeBatchResponse)(nil),      // 25: provider.v1.DescribeBatchResponse
+	(*GuestAddress)(nil),               // 26: provider.v1.GuestAddress
+	(*GuestStats)(nil),                 // 27: provider.v1.GuestStats
+	(*TaskStatusRequest)(nil),          // 28: provider.v1.TaskStatusRequest
+	(*TaskStatusResponse)(nil),         // 29: provider.v1.TaskStatusResponse
+	(*SnapshotCreateRequest)(nil),      // 30: provider.v1.SnapshotCreateRequest
+	(*SnapshotCreateResponse)(nil),     // 31: provider.v1.SnapshotCreateResponse
+	(*SnapshotDeleteRequest)(nil),      // 32: provider.v1.SnapshotDeleteRequest
+	(*SnapshotRevertRequest)(nil),      // 33: provider.v1.SnapshotRevertRequest
+	(*SnapshotListRequest)(nil),        // 34: provider.v1.SnapshotListRequest
+	(*SnapshotInfo)(nil),               // 35: provider.v1.SnapshotInfo
+	(*SnapshotListResponse)(nil),       // 36: provider.v1.SnapshotListResponse
+	(*CloneRequest)(nil),               // 37: provider.v1.CloneRequest
+	(*CloneResponse)(nil),              // 38: provider.v1.CloneResponse
+	(*ImagePrepareRequest)(nil),        // 39: provider.v1.ImagePrepareRequest
+	(*ImagePrepareResponse)(nil),       // 40: provider.v1.ImagePrepareResponse
+	(*PublishImageRequest)(nil),        // 41: provider.v1.PublishImageRequest
+	(*PublishImageResponse)(nil),       // 42: provider.v1.PublishImageResponse
+	(*ExportDiskRequest)(nil),          // 43: provider.v1.ExportDiskRequest
+	(*ExportDiskResponse)(nil),         // 44: provider.v1.ExportDiskResponse
+	(*ImportDiskRequest)(nil),          // 45: provider.v1.ImportDiskRequest
+	(*ImportDiskResponse)(nil),         // 46: provider.v1.ImportDiskResponse
+	(*GetDiskInfoRequest)(nil),         // 47: provider.v1.GetDiskInfoRequest
+	(*GetDiskInfoResponse)(nil),        // 48: provider.v1.GetDiskInfoResponse
+	(*ListVMsRequest)(nil),             // 49: provider.v1.ListVMsRequest
+	(*ListVMsResponse)(nil),            // 50: provider.v1.ListVMsResponse
+	(*VMInfo)(nil),                     // 51: provider.v1.VMInfo
+	(*DiskInfo)(nil),                   // 52: provider.v1.DiskInfo
+	(*NetworkInfo)(nil),                // 53: provider.v1.NetworkInfo
+	(*GetConsoleOutputRequest)(nil),    // 54: provider.v1.GetConsoleOutputRequest
+	(*GetConsoleOutputResponse)(nil),   // 55: provider.v1.GetConsoleOutputResponse
+	(*GetCloudInitStatusRequest)(nil),  // 56: provider.v1.GetCloudInitStatusRequest
+	(*GetCloudInitStatusResponse)(nil), // 57: provider.v1.GetCloudInitStatusResponse
+	(*GetCapacityRequest)(nil),         // 58: provider.v1.GetCapacityRequest
+	(*HostCapacity)(nil),               // 59: provider.v1.HostCapacity
+	(*GetCapacityResponse)(nil),        // 60: provider.v1.GetCapacityResponse
+	(*MigrateHostRequest)(nil),         // 61: provider.v1.MigrateHostRequest
+	(*ConsoleTicketRequest)(nil),       // 62: provider.v1.ConsoleTicketRequest
+	(*ConsoleTicketResponse)(nil),      // 63: provider.v1.ConsoleTicketResponse
+	(*ConsoleRelayRequest)(nil),        // 64: provider.v1.ConsoleRelayRequest
+	(*ConsoleRelayResponse)(nil),       // 65: provider.v1.ConsoleRelayResponse
+	(*WatchEventsRequest)(nil),         // 66: provider.v1.WatchEventsRequest
+	(*HypervisorEvent)(nil),            // 67: provider.v1.HypervisorEvent
+	(*GetInfoRequest)(nil),             // 68: provider.v1.GetInfoRequest
+	(*GetInfoResponse)(nil),            // 69: provider.v1.GetInfoResponse
+	(*GetCapabilitiesRequest)(nil),     // 70: provider.v1.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil),    // 71: provider.v1.GetCapabilitiesResponse
+	nil,                                // 72: provider.v1.DescribeBatchResponse.ResultsEntry
+	nil,                                // 73: provider.v1.DescribeBatchResponse.ErrorsEntry
+	nil,                                // 74: provider.v1.ExportDiskRequest.CredentialsEntry
+	nil,                                // 75: provider.v1.ImportDiskRequest.CredentialsEntry
+	nil,                                // 76: provider.v1.GetDiskInfoResponse.MetadataEntry
+	nil,                                // 77: provider.v1.VMInfo.ProviderRawEntry
+	nil,                                // 78: provider.v1.ConsoleTicketResponse.HeadersEntry
 }
 var file_provider_v1_provider_proto_depIdxs = []int32{
-	1,  // 0: provider.v1.CreateResponse.task:type_name -> provider.v1.TaskRef
+	4,  // 0: provider.v1.CreateResponse.task:type_name -> provider.v1.TaskRef
 	0,  // 1: provider.v1.PowerRequest.op:type_name -> provider.v1.PowerOp
-	1,  // 2: provider.v1.TaskResponse.task:type_name -> provider.v1.TaskRef
-	1,  // 3: provider.v1.TaskStatusRequest.task:type_name -> provider.v1.TaskRef
-	1,  // 4: provider.v1.SnapshotCreateResponse.task:type_name -> provider.v1.TaskRef
-	1,  // 5: provider.v1.CloneResponse.task:type_name -> provider.v1.TaskRef
-	1,  // 6: provider.v1.ImagePrepareResponse.task:type_name -> provider.v1.TaskRef
-	37, // 7: provider.v1.ExportDiskRequest.credentials:type_name -> provider.v1.ExportDiskRequest.CredentialsEntry
-	1,  // 8: provider.v1.ExportDiskResponse.task:type_name -> provider.v1.TaskRef
-	38, // 9: provider.v1.ImportDiskRequest.credentials:type_name -> provider.v1.ImportDiskRequest.CredentialsEntry
-	1,  // 10: provider.v1.ImportDiskResponse.task:type_name -> provider.v1.TaskRef
-	39, // 11: provider.v1.GetDiskInfoResponse.metadata:type_name -> provider.v1.GetDiskInfoResponse.MetadataEntry
-	32, // 12: provider.v1.ListVMsResponse.vms:type_name -> provider.v1.VMInfo
-	33, // 13: provider.v1.VMInfo.disks:type_name -> provider.v1.DiskInfo
-	34, // 14: provider.v1.VMInfo.networks:type_name -> provider.v1.NetworkInfo
-	40, // 15: provider.v1.VMInfo.provider_raw:type_name -> provider.v1.VMInfo.ProviderRawEntry
-	3,  // 16: provider.v1.Provider.Validate:input_type -> provider.v1.ValidateRequest
-	5,  // 17: provider.v1.Provider.Create:input_type -> provider.v1.CreateRequest
-	7,  // 18: provider.v1.Provider.Delete:input_type -> provider.v1.DeleteRequest
-	8,  // 19: provider.v1.Provider.Power:input_type -> provider.v1.PowerRequest
-	9,  // 20: provider.v1.Provider.Reconfigure:input_type -> provider.v1.ReconfigureRequest
-	10, // 21: provider.v1.Provider.HardwareUpgrade:input_type -> provider.v1.HardwareUpgradeRequest
-	12, // 22: provider.v1.Provider.Describe:input_type -> provider.v1.DescribeRequest
-	14, // 23: provider.v1.Provider.TaskStatus:input_type -> provider.v1.TaskStatusRequest
-	16, // 24: provider.v1.Provider.SnapshotCreate:input_type -> provider.v1.SnapshotCreateRequest
-	18, // 25: provider.v1.Provider.SnapshotDelete:input_type -> provider.v1.SnapshotDeleteRequest
-	19, // 26: provider.v1.Provider.SnapshotRevert:input_type -> provider.v1.SnapshotRevertRequest
-	20, // 27: provider.v1.Provider.Clone:input_type -> provider.v1.CloneRequest
-	22, // 28: provider.v1.Provider.ImagePrepare:input_type -> provider.v1.ImagePrepareRequest
-	35, // 29: provider.v1.Provider.GetCapabilities:input_type -> provider.v1.GetCapabilitiesRequest
-	24, // 30: provider.v1.Provider.ExportDisk:input_type -> provider.v1.ExportDiskRequest
-	26, // 31: provider.v1.Provider.ImportDisk:input_type -> provider.v1.ImportDiskRequest
-	28, // 32: provider.v1.Provider.GetDiskInfo:input_type -> provider.v1.GetDiskInfoRequest
-	30, // 33: provider.v1.Provider.ListVMs:input_type -> provider.v1.ListVMsRequest
-	4,  // 34: provider.v1.Provider.Validate:output_type -> provider.v1.ValidateResponse
-	6,  // 35: provider.v1.Provider.Create:output_type -> provider.v1.CreateResponse
-	11, // 36: provider.v1.Provider.Delete:output_type -> provider.v1.TaskResponse
-	11, // 37: provider.v1.Provider.Power:output_type -> provider.v1.TaskResponse
-	11, // 38: provider.v1.Provider.Reconfigure:output_type -> provider.v1.TaskResponse
-	11, // 39: provider.v1.Provider.HardwareUpgrade:output_type -> provider.v1.TaskResponse
-	13, // 40: provider.v1.Provider.Describe:output_type -> provider.v1.DescribeResponse
-	15, // 41: provider.v1.Provider.TaskStatus:output_type -> provider.v1.TaskStatusResponse
-	17, // 42: provider.v1.Provider.SnapshotCreate:output_type -> provider.v1.SnapshotCreateResponse
-	11, // 43: provider.v1.Provider.SnapshotDelete:output_type -> provider.v1.TaskResponse
-	11, // 44: provider.v1.Provider.SnapshotRevert:output_type -> provider.v1.TaskResponse
-	21, // 45: provider.v1.Provider.Clone:output_type -> provider.v1.CloneResponse
-	23, // 46: provider.v1.Provider.ImagePrepare:output_type -> provider.v1.ImagePrepareResponse
-	36, // 47: provider.v1.Provider.GetCapabilities:output_type -> provider.v1.GetCapabilitiesResponse
-	25, // 48: provider.v1.Provider.ExportDisk:output_type -> provider.v1.ExportDiskResponse
-	27, // 49: provider.v1.Provider.ImportDisk:output_type -> provider.v1.ImportDiskResponse
-	29, // 50: provider.v1.Provider.GetDiskInfo:output_type -> provider.v1.GetDiskInfoResponse
-	31, // 51: provider.v1.Provider.ListVMs:output_type -> provider.v1.ListVMsResponse
-	34, // [34:52] is the sub-list for method output_type
-	16, // [16:34] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	18, // 2: provider.v1.ReconfigureRequest.changes:type_name -> provider.v1.ChangeSet
+	13, // 3: provider.v1.ChangeSet.cpu:type_name -> provider.v1.Int32Change
+	14, // 4: provider.v1.ChangeSet.memory_mib:type_name -> provider.v1.Int64Change
+	15, // 5: provider.v1.ChangeSet.disks:type_name -> provider.v1.DiskExpansion
+	16, // 6: provider.v1.ChangeSet.networks_added:type_name -> provider.v1.NetworkChange
+	17, // 7: provider.v1.ChangeSet.security_groups:type_name -> provider.v1.SecurityGroupChange
+	4,  // 8: provider.v1.ReconfigureResponse.task:type_name -> provider.v1.TaskRef
+	4,  // 9: provider.v1.TaskResponse.task:type_name -> provider.v1.TaskRef
+	27, // 10: provider.v1.DescribeResponse.guest_stats:type_name -> provider.v1.GuestStats
+	26, // 11: provider.v1.DescribeResponse.addresses:type_name -> provider.v1.GuestAddress
+	72, // 12: provider.v1.DescribeBatchResponse.results:type_name -> provider.v1.DescribeBatchResponse.ResultsEntry
+	73, // 13: provider.v1.DescribeBatchResponse.errors:type_name -> provider.v1.DescribeBatchResponse.ErrorsEntry
+	4,  // 14: provider.v1.TaskStatusRequest.task:type_name -> provider.v1.TaskRef
+	4,  // 15: provider.v1.SnapshotCreateResponse.task:type_name -> provider.v1.TaskRef
+	1,  // 16: provider.v1.SnapshotCreateResponse.quiesce:type_name -> provider.v1.QuiesceOutcome
+	35, // 17: provider.v1.SnapshotListResponse.snapshots:type_name -> provider.v1.SnapshotInfo
+	4,  // 18: provider.v1.CloneResponse.task:type_name -> provider.v1.TaskRef
+	4,  // 19: provider.v1.ImagePrepareResponse.task:type_name -> provider.v1.TaskRef
+	4,  // 20: provider.v1.PublishImageResponse.task:type_name -> provider.v1.TaskRef
+	74, // 21: provider.v1.ExportDiskRequest.credentials:type_name -> provider.v1.ExportDiskRequest.CredentialsEntry
+	4,  // 22: provider.v1.ExportDiskResponse.task:type_name -> provider.v1.TaskRef
+	75, // 23: provider.v1.ImportDiskRequest.credentials:type_name -> provider.v1.ImportDiskRequest.CredentialsEntry
+	4,  // 24: provider.v1.ImportDiskResponse.task:type_name -> provider.v1.TaskRef
+	76, // 25: provider.v1.GetDiskInfoResponse.metadata:type_name -> provider.v1.GetDiskInfoResponse.MetadataEntry
+	51, // 26: provider.v1.ListVMsResponse.vms:type_name -> provider.v1.VMInfo
+	52, // 27: provider.v1.VMInfo.disks:type_name -> provider.v1.DiskInfo
+	53, // 28: provider.v1.VMInfo.networks:type_name -> provider.v1.NetworkInfo
+	77, // 29: provider.v1.VMInfo.provider_raw:type_name -> provider.v1.VMInfo.ProviderRawEntry
+	59, // 30: provider.v1.GetCapacityResponse.hosts:type_name -> provider.v1.HostCapacity
+	78, // 31: provider.v1.ConsoleTicketResponse.headers:type_name -> provider.v1.ConsoleTicketResponse.HeadersEntry
+	2,  // 32: provider.v1.HypervisorEvent.severity:type_name -> provider.v1.EventSeverity
+	3,  // 33: provider.v1.GetCapabilitiesResponse.min_protocol_version:type_name -> provider.v1.ProtocolVersion
+	3,  // 34: provider.v1.GetCapabilitiesResponse.max_protocol_version:type_name -> provider.v1.ProtocolVersion
+	23, // 35: provider.v1.DescribeBatchResponse.ResultsEntry.value:type_name -> provider.v1.DescribeResponse
+	6,  // 36: provider.v1.Provider.Validate:input_type -> provider.v1.ValidateRequest
+	8,  // 37: provider.v1.Provider.Create:input_type -> provider.v1.CreateRequest
+	10, // 38: provider.v1.Provider.Delete:input_type -> provider.v1.DeleteRequest
+	11, // 39: provider.v1.Provider.Power:input_type -> provider.v1.PowerRequest
+	12, // 40: provider.v1.Provider.Reconfigure:input_type -> provider.v1.ReconfigureRequest
+	20, // 41: provider.v1.Provider.HardwareUpgrade:input_type -> provider.v1.HardwareUpgradeRequest
+	22, // 42: provider.v1.Provider.Describe:input_type -> provider.v1.DescribeRequest
+	24, // 43: provider.v1.Provider.DescribeBatch:input_type -> provider.v1.DescribeBatchRequest
+	28, // 44: provider.v1.Provider.TaskStatus:input_type -> provider.v1.TaskStatusRequest
+	30, // 45: provider.v1.Provider.SnapshotCreate:input_type -> provider.v1.SnapshotCreateRequest
+	32, // 46: provider.v1.Provider.SnapshotDelete:input_type -> provider.v1.SnapshotDeleteRequest
+	33, // 47: provider.v1.Provider.SnapshotRevert:input_type -> provider.v1.SnapshotRevertRequest
+	34, // 48: provider.v1.Provider.SnapshotList:input_type -> provider.v1.SnapshotListRequest
+	37, // 49: provider.v1.Provider.Clone:input_type -> provider.v1.CloneRequest
+	39, // 50: provider.v1.Provider.ImagePrepare:input_type -> provider.v1.ImagePrepareRequest
+	41, // 51: provider.v1.Provider.PublishImage:input_type -> provider.v1.PublishImageRequest
+	70, // 52: provider.v1.Provider.GetCapabilities:input_type -> provider.v1.GetCapabilitiesRequest
+	43, // 53: provider.v1.Provider.ExportDisk:input_type -> provider.v1.ExportDiskRequest
+	45, // 54: provider.v1.Provider.ImportDisk:input_type -> provider.v1.ImportDiskRequest
+	47, // 55: provider.v1.Provider.GetDiskInfo:input_type -> provider.v1.GetDiskInfoRequest
+	49, // 56: provider.v1.Provider.ListVMs:input_type -> provider.v1.ListVMsRequest
+	54, // 57: provider.v1.Provider.GetConsoleOutput:input_type -> provider.v1.GetConsoleOutputRequest
+	56, // 58: provider.v1.Provider.GetCloudInitStatus:input_type -> provider.v1.GetCloudInitStatusRequest
+	58, // 59: provider.v1.Provider.GetCapacity:input_type -> provider.v1.GetCapacityRequest
+	68, // 60: provider.v1.Provider.GetInfo:input_type -> provider.v1.GetInfoRequest
+	61, // 61: provider.v1.Provider.MigrateHost:input_type -> provider.v1.MigrateHostRequest
+	62, // 62: provider.v1.Provider.ConsoleTicket:input_type -> provider.v1.ConsoleTicketRequest
+	64, // 63: provider.v1.Provider.ConsoleRelay:input_type -> provider.v1.ConsoleRelayRequest
+	66, // 64: provider.v1.Provider.WatchEvents:input_type -> provider.v1.WatchEventsRequest
+	7,  // 65: provider.v1.Provider.Validate:output_type -> provider.v1.ValidateResponse
+	9,  // 66: provider.v1.Provider.Create:output_type -> provider.v1.CreateResponse
+	21, // 67: provider.v1.Provider.Delete:output_type -> provider.v1.TaskResponse
+	21, // 68: provider.v1.Provider.Power:output_type -> provider.v1.TaskResponse
+	19, // 69: provider.v1.Provider.Reconfigure:output_type -> provider.v1.ReconfigureResponse
+	21, // 70: provider.v1.Provider.HardwareUpgrade:output_type -> provider.v1.TaskResponse
+	23, // 71: provider.v1.Provider.Describe:output_type -> provider.v1.DescribeResponse
+	25, // 72: provider.v1.Provider.DescribeBatch:output_type -> provider.v1.DescribeBatchResponse
+	29, // 73: provider.v1.Provider.TaskStatus:output_type -> provider.v1.TaskStatusResponse
+	31, // 74: provider.v1.Provider.SnapshotCreate:output_type -> provider.v1.SnapshotCreateResponse
+	21, // 75: provider.v1.Provider.SnapshotDelete:output_type -> provider.v1.TaskResponse
+	21, // 76: provider.v1.Provider.SnapshotRevert:output_type -> provider.v1.TaskResponse
+	36, // 77: provider.v1.Provider.SnapshotList:output_type -> provider.v1.SnapshotListResponse
+	38, // 78: provider.v1.Provider.Clone:output_type -> provider.v1.CloneResponse
+	40, // 79: provider.v1.Provider.ImagePrepare:output_type -> provider.v1.ImagePrepareResponse
+	42, // 80: provider.v1.Provider.PublishImage:output_type -> provider.v1.PublishImageResponse
+	71, // 81: provider.v1.Provider.GetCapabilities:output_type -> provider.v1.GetCapabilitiesResponse
+	44, // 82: provider.v1.Provider.ExportDisk:output_type -> provider.v1.ExportDiskResponse
+	46, // 83: provider.v1.Provider.ImportDisk:output_type -> provider.v1.ImportDiskResponse
+	48, // 84: provider.v1.Provider.GetDiskInfo:output_type -> provider.v1.GetDiskInfoResponse
+	50, // 85: provider.v1.Provider.ListVMs:output_type -> provider.v1.ListVMsResponse
+	55, // 86: provider.v1.Provider.GetConsoleOutput:output_type -> provider.v1.GetConsoleOutputResponse
+	57, // 87: provider.v1.Provider.GetCloudInitStatus:output_type -> provider.v1.GetCloudInitStatusResponse
+	60, // 88: provider.v1.Provider.GetCapacity:output_type -> provider.v1.GetCapacityResponse
+	69, // 89: provider.v1.Provider.GetInfo:output_type -> provider.v1.GetInfoResponse
+	21, // 90: provider.v1.Provider.MigrateHost:output_type -> provider.v1.TaskResponse
+	63, // 91: provider.v1.Provider.ConsoleTicket:output_type -> provider.v1.ConsoleTicketResponse
+	65, // 92: provider.v1.Provider.ConsoleRelay:output_type -> provider.v1.ConsoleRelayResponse
+	67, // 93: provider.v1.Provider.WatchEvents:output_type -> provider.v1.HypervisorEvent
+	65, // [65:94] is the sub-list for method output_type
+	36, // [36:65] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_provider_v1_provider_proto_init() }
@@ -3163,8 +6170,200 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Empty); i {
+		file_provider_v1_provider_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PowerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ReconfigureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Int32Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Int64Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*DiskExpansion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*NetworkChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*SecurityGroupChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ReconfigureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*HardwareUpgradeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*TaskResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3175,8 +6374,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*ValidateRequest); i {
+		file_provider_v1_provider_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*DescribeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3187,8 +6386,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ValidateResponse); i {
+		file_provider_v1_provider_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*DescribeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3199,8 +6398,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateRequest); i {
+		file_provider_v1_provider_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*DescribeBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3211,8 +6410,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateResponse); i {
+		file_provider_v1_provider_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*DescribeBatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3223,8 +6422,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*DeleteRequest); i {
+		file_provider_v1_provider_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GuestAddress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3235,8 +6434,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*PowerRequest); i {
+		file_provider_v1_provider_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*GuestStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3247,8 +6446,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*ReconfigureRequest); i {
+		file_provider_v1_provider_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*TaskStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3259,8 +6458,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*HardwareUpgradeRequest); i {
+		file_provider_v1_provider_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*TaskStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3271,8 +6470,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*TaskResponse); i {
+		file_provider_v1_provider_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3283,8 +6482,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*DescribeRequest); i {
+		file_provider_v1_provider_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3295,8 +6494,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*DescribeResponse); i {
+		file_provider_v1_provider_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3307,8 +6506,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*TaskStatusRequest); i {
+		file_provider_v1_provider_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotRevertRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3319,8 +6518,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*TaskStatusResponse); i {
+		file_provider_v1_provider_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3331,8 +6530,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*SnapshotCreateRequest); i {
+		file_provider_v1_provider_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3343,8 +6542,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[16].Exporter = func(v any, i int) any {
-			switch v := v.(*SnapshotCreateResponse); i {
+		file_provider_v1_provider_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3355,8 +6554,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*SnapshotDeleteRequest); i {
+		file_provider_v1_provider_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*CloneRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3367,8 +6566,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[18].Exporter = func(v any, i int) any {
-			switch v := v.(*SnapshotRevertRequest); i {
+		file_provider_v1_provider_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*CloneResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3379,8 +6578,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[19].Exporter = func(v any, i int) any {
-			switch v := v.(*CloneRequest); i {
+		file_provider_v1_provider_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*ImagePrepareRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3391,8 +6590,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[20].Exporter = func(v any, i int) any {
-			switch v := v.(*CloneResponse); i {
+		file_provider_v1_provider_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*ImagePrepareResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3403,8 +6602,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[21].Exporter = func(v any, i int) any {
-			switch v := v.(*ImagePrepareRequest); i {
+		file_provider_v1_provider_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*PublishImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3415,8 +6614,8 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[22].Exporter = func(v any, i int) any {
-			switch v := v.(*ImagePrepareResponse); i {
+		file_provider_v1_provider_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*PublishImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3427,7 +6626,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[23].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[39].Exporter = func(v any, i int) any {
 			switch v := v.(*ExportDiskRequest); i {
 			case 0:
 				return &v.state
@@ -3439,7 +6638,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[24].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[40].Exporter = func(v any, i int) any {
 			switch v := v.(*ExportDiskResponse); i {
 			case 0:
 				return &v.state
@@ -3451,7 +6650,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[25].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[41].Exporter = func(v any, i int) any {
 			switch v := v.(*ImportDiskRequest); i {
 			case 0:
 				return &v.state
@@ -3463,7 +6662,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[26].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[42].Exporter = func(v any, i int) any {
 			switch v := v.(*ImportDiskResponse); i {
 			case 0:
 				return &v.state
@@ -3475,7 +6674,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[27].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[43].Exporter = func(v any, i int) any {
 			switch v := v.(*GetDiskInfoRequest); i {
 			case 0:
 				return &v.state
@@ -3487,7 +6686,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[28].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[44].Exporter = func(v any, i int) any {
 			switch v := v.(*GetDiskInfoResponse); i {
 			case 0:
 				return &v.state
@@ -3499,7 +6698,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[29].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[45].Exporter = func(v any, i int) any {
 			switch v := v.(*ListVMsRequest); i {
 			case 0:
 				return &v.state
@@ -3511,7 +6710,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[30].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[46].Exporter = func(v any, i int) any {
 			switch v := v.(*ListVMsResponse); i {
 			case 0:
 				return &v.state
@@ -3523,7 +6722,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[31].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[47].Exporter = func(v any, i int) any {
 			switch v := v.(*VMInfo); i {
 			case 0:
 				return &v.state
@@ -3535,7 +6734,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[32].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[48].Exporter = func(v any, i int) any {
 			switch v := v.(*DiskInfo); i {
 			case 0:
 				return &v.state
@@ -3547,7 +6746,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[33].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[49].Exporter = func(v any, i int) any {
 			switch v := v.(*NetworkInfo); i {
 			case 0:
 				return &v.state
@@ -3559,7 +6758,199 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[34].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[50].Exporter = func(v any, i int) any {
+			switch v := v.(*GetConsoleOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[51].Exporter = func(v any, i int) any {
+			switch v := v.(*GetConsoleOutputResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[52].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCloudInitStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[53].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCloudInitStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[54].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCapacityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[55].Exporter = func(v any, i int) any {
+			switch v := v.(*HostCapacity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[56].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCapacityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[57].Exporter = func(v any, i int) any {
+			switch v := v.(*MigrateHostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[58].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsoleTicketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[59].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsoleTicketResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[60].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsoleRelayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[61].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsoleRelayResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[62].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[63].Exporter = func(v any, i int) any {
+			switch v := v.(*HypervisorEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[64].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[65].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_provider_v1_provider_proto_msgTypes[66].Exporter = func(v any, i int) any {
 			switch v := v.(*GetCapabilitiesRequest); i {
 			case 0:
 				return &v.state
@@ -3571,7 +6962,7 @@ func file_provider_v1_provider_proto_init() {
 				return nil
 			}
 		}
-		file_provider_v1_provider_proto_msgTypes[35].Exporter = func(v any, i int) any {
+		file_provider_v1_provider_proto_msgTypes[67].Exporter = func(v any, i int) any {
 			switch v := v.(*GetCapabilitiesResponse); i {
 			case 0:
 				return &v.state
@@ -3584,13 +6975,17 @@ func file_provider_v1_provider_proto_init() {
 			}
 		}
 	}
+	file_provider_v1_provider_proto_msgTypes[6].OneofWrappers = []any{}
+	file_provider_v1_provider_proto_msgTypes[23].OneofWrappers = []any{}
+	file_provider_v1_provider_proto_msgTypes[27].OneofWrappers = []any{}
+	file_provider_v1_provider_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_provider_v1_provider_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   40,
+			NumEnums:      4,
+			NumMessages:   75,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,24 +19,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Provider_Validate_FullMethodName        = "/provider.v1.Provider/Validate"
-	Provider_Create_FullMethodName          = "/provider.v1.Provider/Create"
-	Provider_Delete_FullMethodName          = "/provider.v1.Provider/Delete"
-	Provider_Power_FullMethodName           = "/provider.v1.Provider/Power"
-	Provider_Reconfigure_FullMethodName     = "/provider.v1.Provider/Reconfigure"
-	Provider_HardwareUpgrade_FullMethodName = "/provider.v1.Provider/HardwareUpgrade"
-	Provider_Describe_FullMethodName        = "/provider.v1.Provider/Describe"
-	Provider_TaskStatus_FullMethodName      = "/provider.v1.Provider/TaskStatus"
-	Provider_SnapshotCreate_FullMethodName  = "/provider.v1.Provider/SnapshotCreate"
-	Provider_SnapshotDelete_FullMethodName  = "/provider.v1.Provider/SnapshotDelete"
-	Provider_SnapshotRevert_FullMethodName  = "/provider.v1.Provider/SnapshotRevert"
-	Provider_Clone_FullMethodName           = "/provider.v1.Provider/Clone"
-	Provider_ImagePrepare_FullMethodName    = "/provider.v1.Provider/ImagePrepare"
-	Provider_GetCapabilities_FullMethodName = "/provider.v1.Provider/GetCapabilities"
-	Provider_ExportDisk_FullMethodName      = "/provider.v1.Provider/ExportDisk"
-	Provider_ImportDisk_FullMethodName      = "/provider.v1.Provider/ImportDisk"
-	Provider_GetDiskInfo_FullMethodName     = "/provider.v1.Provider/GetDiskInfo"
-	Provider_ListVMs_FullMethodName         = "/provider.v1.Provider/ListVMs"
+	Provider_Validate_FullMethodName           = "/provider.v1.Provider/Validate"
+	Provider_Create_FullMethodName             = "/provider.v1.Provider/Create"
+	Provider_Delete_FullMethodName             = "/provider.v1.Provider/Delete"
+	Provider_Power_FullMethodName              = "/provider.v1.Provider/Power"
+	Provider_Reconfigure_FullMethodName        = "/provider.v1.Provider/Reconfigure"
+	Provider_HardwareUpgrade_FullMethodName    = "/provider.v1.Provider/HardwareUpgrade"
+	Provider_Describe_FullMethodName           = "/provider.v1.Provider/Describe"
+	Provider_DescribeBatch_FullMethodName      = "/provider.v1.Provider/DescribeBatch"
+	Provider_TaskStatus_FullMethodName         = "/provider.v1.Provider/TaskStatus"
+	Provider_SnapshotCreate_FullMethodName     = "/provider.v1.Provider/SnapshotCreate"
+	Provider_SnapshotDelete_FullMethodName     = "/provider.v1.Provider/SnapshotDelete"
+	Provider_SnapshotRevert_FullMethodName     = "/provider.v1.Provider/SnapshotRevert"
+	Provider_SnapshotList_FullMethodName       = "/provider.v1.Provider/SnapshotList"
+	Provider_Clone_FullMethodName              = "/provider.v1.Provider/Clone"
+	Provider_ImagePrepare_FullMethodName       = "/provider.v1.Provider/ImagePrepare"
+	Provider_PublishImage_FullMethodName       = "/provider.v1.Provider/PublishImage"
+	Provider_GetCapabilities_FullMethodName    = "/provider.v1.Provider/GetCapabilities"
+	Provider_ExportDisk_FullMethodName         = "/provider.v1.Provider/ExportDisk"
+	Provider_ImportDisk_FullMethodName         = "/provider.v1.Provider/ImportDisk"
+	Provider_GetDiskInfo_FullMethodName        = "/provider.v1.Provider/GetDiskInfo"
+	Provider_ListVMs_FullMethodName            = "/provider.v1.Provider/ListVMs"
+	Provider_GetConsoleOutput_FullMethodName   = "/provider.v1.Provider/GetConsoleOutput"
+	Provider_GetCloudInitStatus_FullMethodName = "/provider.v1.Provider/GetCloudInitStatus"
+	Provider_GetCapacity_FullMethodName        = "/provider.v1.Provider/GetCapacity"
+	Provider_GetInfo_FullMethodName            = "/provider.v1.Provider/GetInfo"
+	Provider_MigrateHost_FullMethodName        = "/provider.v1.Provider/MigrateHost"
+	Provider_ConsoleTicket_FullMethodName      = "/provider.v1.Provider/ConsoleTicket"
+	Provider_ConsoleRelay_FullMethodName       = "/provider.v1.Provider/ConsoleRelay"
+	Provider_WatchEvents_FullMethodName        = "/provider.v1.Provider/WatchEvents"
 )
 
 // ProviderClient is the client API for Provider service.
@@ -54,17 +65,31 @@ type ProviderClient interface {
 	// Perform power operations on a virtual machine
 	Power(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	// Reconfigure virtual machine resources
-	Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error)
 	// Upgrade VM hardware version
 	HardwareUpgrade(ctx context.Context, in *HardwareUpgradeRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	// Describe current virtual machine state
 	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
-	// Check the status of an async task
+	// Describe several VMs in one call. Providers whose hypervisor can answer
+	// for many VMs at once implement it natively; the SDK server answers it
+	// for the rest by calling Describe per id, and the manager falls back to
+	// per-VM Describe against providers that return UNIMPLEMENTED. Results
+	// may omit provider_raw_json details that cost an extra hypervisor call
+	// per VM.
+	DescribeBatch(ctx context.Context, in *DescribeBatchRequest, opts ...grpc.CallOption) (*DescribeBatchResponse, error)
+	// Check the status of an async task. A well-formed TaskRef the provider
+	// does not know (typically after a restart) must be reported as not done,
+	// with no error, for a grace period rather than failed; see
+	// sdk/provider/tasks.
 	TaskStatus(ctx context.Context, in *TaskStatusRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error)
 	// Snapshot operations
 	SnapshotCreate(ctx context.Context, in *SnapshotCreateRequest, opts ...grpc.CallOption) (*SnapshotCreateResponse, error)
 	SnapshotDelete(ctx context.Context, in *SnapshotDeleteRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	SnapshotRevert(ctx context.Context, in *SnapshotRevertRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	// List the snapshots that exist on the hypervisor. Providers that cannot
+	// enumerate snapshots return UNIMPLEMENTED (the embedded Unimplemented
+	// server's default).
+	SnapshotList(ctx context.Context, in *SnapshotListRequest, opts ...grpc.CallOption) (*SnapshotListResponse, error)
 	// Clone operations
 	Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*CloneResponse, error)
 	// Image preparation and import. Returns the prepared image's provider-specific
@@ -73,6 +98,10 @@ type ProviderClient interface {
 	// is wire-compatible with the TaskResponse this previously returned (task at
 	// field 1), so the change is non-breaking (issue #154, PR-6 / #214).
 	ImagePrepare(ctx context.Context, in *ImagePrepareRequest, opts ...grpc.CallOption) (*ImagePrepareResponse, error)
+	// Publish a copy of an existing VM as a template or image. Providers that
+	// cannot return UNIMPLEMENTED (the embedded Unimplemented server's default)
+	// and leave supports_image_publish false.
+	PublishImage(ctx context.Context, in *PublishImageRequest, opts ...grpc.CallOption) (*PublishImageResponse, error)
 	// Get provider capabilities
 	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 	// Disk migration operations
@@ -81,6 +110,38 @@ type ProviderClient interface {
 	GetDiskInfo(ctx context.Context, in *GetDiskInfoRequest, opts ...grpc.CallOption) (*GetDiskInfoResponse, error)
 	// List all VMs managed by this provider
 	ListVMs(ctx context.Context, in *ListVMsRequest, opts ...grpc.CallOption) (*ListVMsResponse, error)
+	// Retrieve recent serial/console output. Providers that do not capture
+	// console output return UNIMPLEMENTED (the embedded Unimplemented server
+	// does this by default) and leave supports_console_output false.
+	GetConsoleOutput(ctx context.Context, in *GetConsoleOutputRequest, opts ...grpc.CallOption) (*GetConsoleOutputResponse, error)
+	// Report cloud-init's status inside the guest. Providers without a guest
+	// channel return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default) and leave supports_cloud_init_status false.
+	GetCloudInitStatus(ctx context.Context, in *GetCloudInitStatusRequest, opts ...grpc.CallOption) (*GetCloudInitStatusResponse, error)
+	// Report hypervisor capacity and utilization. Providers that cannot
+	// report it return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default).
+	GetCapacity(ctx context.Context, in *GetCapacityRequest, opts ...grpc.CallOption) (*GetCapacityResponse, error)
+	// Report the provider build and the hypervisor product and version it is
+	// connected to. Providers that cannot report it return UNIMPLEMENTED (the
+	// embedded Unimplemented server's default).
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Live-migrate a running VM to another host the provider manages.
+	// Providers that manage a single host return UNIMPLEMENTED (the embedded
+	// Unimplemented server's default) and leave supports_live_migration false.
+	MigrateHost(ctx context.Context, in *MigrateHostRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	// Open an interactive console for the manager's console proxy. Providers
+	// without one return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default) and leave supports_console_proxy false.
+	ConsoleTicket(ctx context.Context, in *ConsoleTicketRequest, opts ...grpc.CallOption) (*ConsoleTicketResponse, error)
+	// Relay a console whose ticket has no url through the provider: bytes
+	// sent are written to the VM's console and the console's output is
+	// streamed back, until either side closes.
+	ConsoleRelay(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ConsoleRelayRequest, ConsoleRelayResponse], error)
+	// Stream hypervisor events until the manager cancels the call. Providers
+	// that cannot watch return UNIMPLEMENTED (the embedded Unimplemented
+	// server's default) and leave supports_event_watch false.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HypervisorEvent], error)
 }
 
 type providerClient struct {
@@ -131,9 +192,9 @@ func (c *providerClient) Power(ctx context.Context, in *PowerRequest, opts ...gr
 	return out, nil
 }
 
-func (c *providerClient) Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+func (c *providerClient) Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(TaskResponse)
+	out := new(ReconfigureResponse)
 	err := c.cc.Invoke(ctx, Provider_Reconfigure_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -161,6 +222,16 @@ func (c *providerClient) Describe(ctx context.Context, in *DescribeRequest, opts
 	return out, nil
 }
 
+func (c *providerClient) DescribeBatch(ctx context.Context, in *DescribeBatchRequest, opts ...grpc.CallOption) (*DescribeBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeBatchResponse)
+	err := c.cc.Invoke(ctx, Provider_DescribeBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *providerClient) TaskStatus(ctx context.Context, in *TaskStatusRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TaskStatusResponse)
@@ -201,6 +272,16 @@ func (c *providerClient) SnapshotRevert(ctx context.Context, in *SnapshotRevertR
 	return out, nil
 }
 
+func (c *providerClient) SnapshotList(ctx context.Context, in *SnapshotListRequest, opts ...grpc.CallOption) (*SnapshotListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotListResponse)
+	err := c.cc.Invoke(ctx, Provider_SnapshotList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *providerClient) Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*CloneResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CloneResponse)
@@ -221,6 +302,16 @@ func (c *providerClient) ImagePrepare(ctx context.Context, in *ImagePrepareReque
 	return out, nil
 }
 
+func (c *providerClient) PublishImage(ctx context.Context, in *PublishImageRequest, opts ...grpc.CallOption) (*PublishImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishImageResponse)
+	err := c.cc.Invoke(ctx, Provider_PublishImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *providerClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCapabilitiesResponse)
@@ -271,6 +362,98 @@ func (c *providerClient) ListVMs(ctx context.Context, in *ListVMsRequest, opts .
 	return out, nil
 }
 
+func (c *providerClient) GetConsoleOutput(ctx context.Context, in *GetConsoleOutputRequest, opts ...grpc.CallOption) (*GetConsoleOutputResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConsoleOutputResponse)
+	err := c.cc.Invoke(ctx, Provider_GetConsoleOutput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetCloudInitStatus(ctx context.Context, in *GetCloudInitStatusRequest, opts ...grpc.CallOption) (*GetCloudInitStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCloudInitStatusResponse)
+	err := c.cc.Invoke(ctx, Provider_GetCloudInitStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetCapacity(ctx context.Context, in *GetCapacityRequest, opts ...grpc.CallOption) (*GetCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapacityResponse)
+	err := c.cc.Invoke(ctx, Provider_GetCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, Provider_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) MigrateHost(ctx context.Context, in *MigrateHostRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, Provider_MigrateHost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) ConsoleTicket(ctx context.Context, in *ConsoleTicketRequest, opts ...grpc.CallOption) (*ConsoleTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsoleTicketResponse)
+	err := c.cc.Invoke(ctx, Provider_ConsoleTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) ConsoleRelay(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ConsoleRelayRequest, ConsoleRelayResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Provider_ServiceDesc.Streams[0], Provider_ConsoleRelay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConsoleRelayRequest, ConsoleRelayResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Provider_ConsoleRelayClient = grpc.BidiStreamingClient[ConsoleRelayRequest, ConsoleRelayResponse]
+
+func (c *providerClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HypervisorEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Provider_ServiceDesc.Streams[1], Provider_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, HypervisorEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Provider_WatchEventsClient = grpc.ServerStreamingClient[HypervisorEvent]
+
 // ProviderServer is the server API for Provider service.
 // All implementations must embed UnimplementedProviderServer
 // for forward compatibility.
@@ -286,17 +469,31 @@ type ProviderServer interface {
 	// Perform power operations on a virtual machine
 	Power(context.Context, *PowerRequest) (*TaskResponse, error)
 	// Reconfigure virtual machine resources
-	Reconfigure(context.Context, *ReconfigureRequest) (*TaskResponse, error)
+	Reconfigure(context.Context, *ReconfigureRequest) (*ReconfigureResponse, error)
 	// Upgrade VM hardware version
 	HardwareUpgrade(context.Context, *HardwareUpgradeRequest) (*TaskResponse, error)
 	// Describe current virtual machine state
 	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
-	// Check the status of an async task
+	// Describe several VMs in one call. Providers whose hypervisor can answer
+	// for many VMs at once implement it natively; the SDK server answers it
+	// for the rest by calling Describe per id, and the manager falls back to
+	// per-VM Describe against providers that return UNIMPLEMENTED. Results
+	// may omit provider_raw_json details that cost an extra hypervisor call
+	// per VM.
+	DescribeBatch(context.Context, *DescribeBatchRequest) (*DescribeBatchResponse, error)
+	// Check the status of an async task. A well-formed TaskRef the provider
+	// does not know (typically after a restart) must be reported as not done,
+	// with no error, for a grace period rather than failed; see
+	// sdk/provider/tasks.
 	TaskStatus(context.Context, *TaskStatusRequest) (*TaskStatusResponse, error)
 	// Snapshot operations
 	SnapshotCreate(context.Context, *SnapshotCreateRequest) (*SnapshotCreateResponse, error)
 	SnapshotDelete(context.Context, *SnapshotDeleteRequest) (*TaskResponse, error)
 	SnapshotRevert(context.Context, *SnapshotRevertRequest) (*TaskResponse, error)
+	// List the snapshots that exist on the hypervisor. Providers that cannot
+	// enumerate snapshots return UNIMPLEMENTED (the embedded Unimplemented
+	// server's default).
+	SnapshotList(context.Context, *SnapshotListRequest) (*SnapshotListResponse, error)
 	// Clone operations
 	Clone(context.Context, *CloneRequest) (*CloneResponse, error)
 	// Image preparation and import. Returns the prepared image's provider-specific
@@ -305,6 +502,10 @@ type ProviderServer interface {
 	// is wire-compatible with the TaskResponse this previously returned (task at
 	// field 1), so the change is non-breaking (issue #154, PR-6 / #214).
 	ImagePrepare(context.Context, *ImagePrepareRequest) (*ImagePrepareResponse, error)
+	// Publish a copy of an existing VM as a template or image. Providers that
+	// cannot return UNIMPLEMENTED (the embedded Unimplemented server's default)
+	// and leave supports_image_publish false.
+	PublishImage(context.Context, *PublishImageRequest) (*PublishImageResponse, error)
 	// Get provider capabilities
 	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
 	// Disk migration operations
@@ -313,6 +514,38 @@ type ProviderServer interface {
 	GetDiskInfo(context.Context, *GetDiskInfoRequest) (*GetDiskInfoResponse, error)
 	// List all VMs managed by this provider
 	ListVMs(context.Context, *ListVMsRequest) (*ListVMsResponse, error)
+	// Retrieve recent serial/console output. Providers that do not capture
+	// console output return UNIMPLEMENTED (the embedded Unimplemented server
+	// does this by default) and leave supports_console_output false.
+	GetConsoleOutput(context.Context, *GetConsoleOutputRequest) (*GetConsoleOutputResponse, error)
+	// Report cloud-init's status inside the guest. Providers without a guest
+	// channel return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default) and leave supports_cloud_init_status false.
+	GetCloudInitStatus(context.Context, *GetCloudInitStatusRequest) (*GetCloudInitStatusResponse, error)
+	// Report hypervisor capacity and utilization. Providers that cannot
+	// report it return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default).
+	GetCapacity(context.Context, *GetCapacityRequest) (*GetCapacityResponse, error)
+	// Report the provider build and the hypervisor product and version it is
+	// connected to. Providers that cannot report it return UNIMPLEMENTED (the
+	// embedded Unimplemented server's default).
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// Live-migrate a running VM to another host the provider manages.
+	// Providers that manage a single host return UNIMPLEMENTED (the embedded
+	// Unimplemented server's default) and leave supports_live_migration false.
+	MigrateHost(context.Context, *MigrateHostRequest) (*TaskResponse, error)
+	// Open an interactive console for the manager's console proxy. Providers
+	// without one return UNIMPLEMENTED (the embedded Unimplemented server's
+	// default) and leave supports_console_proxy false.
+	ConsoleTicket(context.Context, *ConsoleTicketRequest) (*ConsoleTicketResponse, error)
+	// Relay a console whose ticket has no url through the provider: bytes
+	// sent are written to the VM's console and the console's output is
+	// streamed back, until either side closes.
+	ConsoleRelay(grpc.BidiStreamingServer[ConsoleRelayRequest, ConsoleRelayResponse]) error
+	// Stream hypervisor events until the manager cancels the call. Providers
+	// that cannot watch return UNIMPLEMENTED (the embedded Unimplemented
+	// server's default) and leave supports_event_watch false.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[HypervisorEvent]) error
 	mustEmbedUnimplementedProviderServer()
 }
 
@@ -335,7 +568,7 @@ func (UnimplementedProviderServer) Delete(context.Context, *DeleteRequest) (*Tas
 func (UnimplementedProviderServer) Power(context.Context, *PowerRequest) (*TaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Power not implemented")
 }
-func (UnimplementedProviderServer) Reconfigure(context.Context, *ReconfigureRequest) (*TaskResponse, error) {
+func (UnimplementedProviderServer) Reconfigure(context.Context, *ReconfigureRequest) (*ReconfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reconfigure not implemented")
 }
 func (UnimplementedProviderServer) HardwareUpgrade(context.Context, *HardwareUpgradeRequest) (*TaskResponse, error) {
@@ -344,6 +577,9 @@ func (UnimplementedProviderServer) HardwareUpgrade(context.Context, *HardwareUpg
 func (UnimplementedProviderServer) Describe(context.Context, *DescribeRequest) (*DescribeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Describe not implemented")
 }
+func (UnimplementedProviderServer) DescribeBatch(context.Context, *DescribeBatchRequest) (*DescribeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeBatch not implemented")
+}
 func (UnimplementedProviderServer) TaskStatus(context.Context, *TaskStatusRequest) (*TaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TaskStatus not implemented")
 }
@@ -356,12 +592,18 @@ func (UnimplementedProviderServer) SnapshotDelete(context.Context, *SnapshotDele
 func (UnimplementedProviderServer) SnapshotRevert(context.Context, *SnapshotRevertRequest) (*TaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRevert not implemented")
 }
+func (UnimplementedProviderServer) SnapshotList(context.Context, *SnapshotListRequest) (*SnapshotListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotList not implemented")
+}
 func (UnimplementedProviderServer) Clone(context.Context, *CloneRequest) (*CloneResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Clone not implemented")
 }
 func (UnimplementedProviderServer) ImagePrepare(context.Context, *ImagePrepareRequest) (*ImagePrepareResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ImagePrepare not implemented")
 }
+func (UnimplementedProviderServer) PublishImage(context.Context, *PublishImageRequest) (*PublishImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishImage not implemented")
+}
 func (UnimplementedProviderServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
 }
@@ -377,6 +619,30 @@ func (UnimplementedProviderServer) GetDiskInfo(context.Context, *GetDiskInfoRequ
 func (UnimplementedProviderServer) ListVMs(context.Context, *ListVMsRequest) (*ListVMsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVMs not implemented")
 }
+func (UnimplementedProviderServer) GetConsoleOutput(context.Context, *GetConsoleOutputRequest) (*GetConsoleOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsoleOutput not implemented")
+}
+func (UnimplementedProviderServer) GetCloudInitStatus(context.Context, *GetCloudInitStatusRequest) (*GetCloudInitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCloudInitStatus not implemented")
+}
+func (UnimplementedProviderServer) GetCapacity(context.Context, *GetCapacityRequest) (*GetCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapacity not implemented")
+}
+func (UnimplementedProviderServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedProviderServer) MigrateHost(context.Context, *MigrateHostRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateHost not implemented")
+}
+func (UnimplementedProviderServer) ConsoleTicket(context.Context, *ConsoleTicketRequest) (*ConsoleTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsoleTicket not implemented")
+}
+func (UnimplementedProviderServer) ConsoleRelay(grpc.BidiStreamingServer[ConsoleRelayRequest, ConsoleRelayResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ConsoleRelay not implemented")
+}
+func (UnimplementedProviderServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[HypervisorEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
 func (UnimplementedProviderServer) mustEmbedUnimplementedProviderServer() {}
 func (UnimplementedProviderServer) testEmbeddedByValue()                  {}
 
@@ -524,6 +790,24 @@ func _Provider_Describe_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Provider_DescribeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).DescribeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_DescribeBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).DescribeBatch(ctx, req.(*DescribeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Provider_TaskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TaskStatusRequest)
 	if err := dec(in); err != nil {
@@ -596,6 +880,24 @@ func _Provider_SnapshotRevert_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Provider_SnapshotList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).SnapshotList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_SnapshotList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).SnapshotList(ctx, req.(*SnapshotListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Provider_Clone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CloneRequest)
 	if err := dec(in); err != nil {
@@ -632,6 +934,24 @@ func _Provider_ImagePrepare_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Provider_PublishImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).PublishImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_PublishImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).PublishImage(ctx, req.(*PublishImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Provider_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCapabilitiesRequest)
 	if err := dec(in); err != nil {
@@ -722,6 +1042,132 @@ func _Provider_ListVMs_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Provider_GetConsoleOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsoleOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetConsoleOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_GetConsoleOutput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetConsoleOutput(ctx, req.(*GetConsoleOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetCloudInitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCloudInitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetCloudInitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_GetCloudInitStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetCloudInitStatus(ctx, req.(*GetCloudInitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_GetCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetCapacity(ctx, req.(*GetCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_MigrateHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).MigrateHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_MigrateHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).MigrateHost(ctx, req.(*MigrateHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_ConsoleTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsoleTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).ConsoleTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Provider_ConsoleTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).ConsoleTicket(ctx, req.(*ConsoleTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_ConsoleRelay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProviderServer).ConsoleRelay(&grpc.GenericServerStream[ConsoleRelayRequest, ConsoleRelayResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Provider_ConsoleRelayServer = grpc.BidiStreamingServer[ConsoleRelayRequest, ConsoleRelayResponse]
+
+func _Provider_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProviderServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, HypervisorEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Provider_WatchEventsServer = grpc.ServerStreamingServer[HypervisorEvent]
+
 // Provider_ServiceDesc is the grpc.ServiceDesc for Provider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -757,6 +1203,10 @@ var Provider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Describe",
 			Handler:    _Provider_Describe_Handler,
 		},
+		{
+			MethodName: "DescribeBatch",
+			Handler:    _Provider_DescribeBatch_Handler,
+		},
 		{
 			MethodName: "TaskStatus",
 			Handler:    _Provider_TaskStatus_Handler,
@@ -773,6 +1223,10 @@ var Provider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SnapshotRevert",
 			Handler:    _Provider_SnapshotRevert_Handler,
 		},
+		{
+			MethodName: "SnapshotList",
+			Handler:    _Provider_SnapshotList_Handler,
+		},
 		{
 			MethodName: "Clone",
 			Handler:    _Provider_Clone_Handler,
@@ -781,6 +1235,10 @@ var Provider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ImagePrepare",
 			Handler:    _Provider_ImagePrepare_Handler,
 		},
+		{
+			MethodName: "PublishImage",
+			Handler:    _Provider_PublishImage_Handler,
+		},
 		{
 			MethodName: "GetCapabilities",
 			Handler:    _Provider_GetCapabilities_Handler,
@@ -801,7 +1259,43 @@ var Provider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListVMs",
 			Handler:    _Provider_ListVMs_Handler,
 		},
+		{
+			MethodName: "GetConsoleOutput",
+			Handler:    _Provider_GetConsoleOutput_Handler,
+		},
+		{
+			MethodName: "GetCloudInitStatus",
+			Handler:    _Provider_GetCloudInitStatus_Handler,
+		},
+		{
+			MethodName: "GetCapacity",
+			Handler:    _Provider_GetCapacity_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _Provider_GetInfo_Handler,
+		},
+		{
+			MethodName: "MigrateHost",
+			Handler:    _Provider_MigrateHost_Handler,
+		},
+		{
+			MethodName: "ConsoleTicket",
+			Handler:    _Provider_ConsoleTicket_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ConsoleRelay",
+			Handler:       _Provider_ConsoleRelay_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Provider_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "provider/v1/provider.proto",
 }
@@ -48,6 +48,14 @@ const (
 	CapabilityDiskImport          Capability = "disk_import"
 	CapabilityExportCompression   Capability = "export_compression"
 	CapabilityTaskStatus          Capability = "task_status"
+	CapabilityConsoleOutput       Capability = "console_output"
+	CapabilitySysprep             Capability = "sysprep"
+	CapabilityCloudInitStatus     Capability = "cloud_init_status"
+	CapabilityLiveMigration       Capability = "live_migration"
+	CapabilityConsoleProxy        Capability = "console_proxy"
+	CapabilityImagePublish        Capability = "image_publish"
+	CapabilityEventWatch          Capability = "event_watch"
+	CapabilityQuiescedSnapshots   Capability = "quiesced_snapshots"
 
 	// Provider-specific capabilities
 	CapabilityVSphere     Capability = "vsphere"
@@ -57,6 +65,58 @@ const (
 	CapabilityMock        Capability = "mock"
 )
 
+// allCapabilities lists every standard flag above, in declaration order.
+var allCapabilities = []Capability{
+	CapabilityValidate,
+	CapabilityCreate,
+	CapabilityDelete,
+	CapabilityPower,
+	CapabilityDescribe,
+	CapabilityGetCapabilities,
+	CapabilityReconfigure,
+	CapabilityReconfigureOnline,
+	CapabilityDiskExpansionOnline,
+	CapabilitySnapshots,
+	CapabilityMemorySnapshots,
+	CapabilityLinkedClones,
+	CapabilityImageImport,
+	CapabilityDiskExport,
+	CapabilityDiskImport,
+	CapabilityExportCompression,
+	CapabilityTaskStatus,
+	CapabilityConsoleOutput,
+	CapabilitySysprep,
+	CapabilityCloudInitStatus,
+	CapabilityLiveMigration,
+	CapabilityConsoleProxy,
+	CapabilityImagePublish,
+	CapabilityEventWatch,
+	CapabilityQuiescedSnapshots,
+	CapabilityVSphere,
+	CapabilityLibvirt,
+	CapabilityFirecracker,
+	CapabilityQEMU,
+	CapabilityMock,
+}
+
+// All returns the canonical list of standard capability flags. Tools that
+// accept capability names from users (conformance specs, CLIs) should
+// validate against it.
+func All() []Capability {
+	return append([]Capability(nil), allCapabilities...)
+}
+
+// Parse returns the standard capability with the given name, or false if
+// name is not one of the flags returned by All.
+func Parse(name string) (Capability, bool) {
+	for _, c := range allCapabilities {
+		if string(c) == name {
+			return c, true
+		}
+	}
+	return "", false
+}
+
 // Profile represents a set of capabilities that form a functional profile.
 type Profile string
 
@@ -159,6 +219,20 @@ func (m *Manager) SetSupportedTransferModes(modes []string) *Manager {
 	return m
 }
 
+// Manifest returns the names of the capabilities the manager holds, in the
+// order of All. It is the capability manifest a provider image reports in
+// GetCapabilities: since the capabilities come from the Builder call
+// compiled into the provider, it is fixed when the image is built.
+func (m *Manager) Manifest() []string {
+	var manifest []string
+	for _, c := range allCapabilities {
+		if m.capabilities[c] {
+			manifest = append(manifest, string(c))
+		}
+	}
+	return manifest
+}
+
 // GetCapabilities returns the capabilities response for gRPC.
 func (m *Manager) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
 	return &providerv1.GetCapabilitiesResponse{
@@ -178,9 +252,35 @@ func (m *Manager) GetCapabilities(ctx context.Context, req *providerv1.GetCapabi
 		SupportedExportBackends:     m.supportedExportBackends,
 		SupportedImportBackends:     m.supportedImportBackends,
 		SupportedTransferModes:      m.supportedTransferModes,
+		SupportsConsoleOutput:       m.HasCapability(CapabilityConsoleOutput),
+		SupportsSysprep:             m.HasCapability(CapabilitySysprep),
+		SupportsCloudInitStatus:     m.HasCapability(CapabilityCloudInitStatus),
+		SupportsLiveMigration:       m.HasCapability(CapabilityLiveMigration),
+		MinProtocolVersion:          providerv1.ProtocolVersion_PROTOCOL_VERSION_1,
+		MaxProtocolVersion:          providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT,
+		SupportsConsoleProxy:        m.HasCapability(CapabilityConsoleProxy),
+		SupportsImagePublish:        m.HasCapability(CapabilityImagePublish),
+		SupportsEventWatch:          m.HasCapability(CapabilityEventWatch),
+		SupportsQuiescedSnapshots:   m.HasCapability(CapabilityQuiescedSnapshots),
+		CapabilityManifest:          m.Manifest(),
 	}, nil
 }
 
+// StampProtocolVersions fills in the protocol versions resp leaves unset:
+// the oldest version this SDK still serves and the newest it was built
+// with. The manager uses them to decide what it may ask the provider for.
+func StampProtocolVersions(resp *providerv1.GetCapabilitiesResponse) {
+	if resp == nil {
+		return
+	}
+	if resp.MinProtocolVersion == providerv1.ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED {
+		resp.MinProtocolVersion = providerv1.ProtocolVersion_PROTOCOL_VERSION_1
+	}
+	if resp.MaxProtocolVersion == providerv1.ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED {
+		resp.MaxProtocolVersion = providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT
+	}
+}
+
 // GetProfileCapabilities returns the capabilities required for a profile.
 func GetProfileCapabilities(profile Profile) []Capability {
 	switch profile {
@@ -310,6 +410,62 @@ func (b *Builder) TaskStatus() *Builder {
 	return b
 }
 
+// ConsoleOutput marks that the provider implements GetConsoleOutput
+// (serial/console log retrieval).
+func (b *Builder) ConsoleOutput() *Builder {
+	b.manager.AddCapability(CapabilityConsoleOutput)
+	return b
+}
+
+// Sysprep marks that the provider applies Sysprep guest customization, so
+// Windows VMs can be created from generalized templates.
+func (b *Builder) Sysprep() *Builder {
+	b.manager.AddCapability(CapabilitySysprep)
+	return b
+}
+
+// CloudInitStatus marks that the provider implements GetCloudInitStatus, so
+// VM readiness can wait for first-boot provisioning.
+func (b *Builder) CloudInitStatus() *Builder {
+	b.manager.AddCapability(CapabilityCloudInitStatus)
+	return b
+}
+
+// LiveMigration marks that the provider implements MigrateHost, so running
+// VMs can be moved between the hosts it manages.
+func (b *Builder) LiveMigration() *Builder {
+	b.manager.AddCapability(CapabilityLiveMigration)
+	return b
+}
+
+// ConsoleProxy marks that the provider implements ConsoleTicket, so the
+// manager's console proxy can open interactive consoles on its VMs.
+func (b *Builder) ConsoleProxy() *Builder {
+	b.manager.AddCapability(CapabilityConsoleProxy)
+	return b
+}
+
+// ImagePublish marks that the provider implements PublishImage, so VMs
+// can be published as templates or images.
+func (b *Builder) ImagePublish() *Builder {
+	b.manager.AddCapability(CapabilityImagePublish)
+	return b
+}
+
+// EventWatch marks that the provider implements WatchEvents, so the manager
+// streams its hypervisor events onto the VirtualMachines they concern.
+func (b *Builder) EventWatch() *Builder {
+	b.manager.AddCapability(CapabilityEventWatch)
+	return b
+}
+
+// QuiescedSnapshots marks that SnapshotCreate honors quiesce, freezing the
+// guest's filesystems through its agent, and reports the outcome.
+func (b *Builder) QuiescedSnapshots() *Builder {
+	b.manager.AddCapability(CapabilityQuiescedSnapshots)
+	return b
+}
+
 // DiskExport adds disk-export capability and, optionally, the supported export
 // formats (e.g. "qcow2", "raw", "vmdk").
 func (b *Builder) DiskExport(formats ...string) *Builder {
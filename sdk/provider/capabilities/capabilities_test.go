@@ -116,3 +116,203 @@ func TestBuilder_StorageBackendsDefaultEmpty(t *testing.T) {
 		t.Error("backend/transfer-mode lists should be empty when not advertised")
 	}
 }
+
+// TestBuilder_ConsoleOutput verifies the console-output capability surfaces on
+// the GetCapabilitiesResponse only when advertised.
+func TestBuilder_ConsoleOutput(t *testing.T) {
+	resp, err := NewBuilder().Core().ConsoleOutput().Build().
+		GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if !resp.SupportsConsoleOutput {
+		t.Error("SupportsConsoleOutput should be true")
+	}
+
+	resp, err = NewBuilder().Core().Build().
+		GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if resp.SupportsConsoleOutput {
+		t.Error("SupportsConsoleOutput should default to false when not advertised")
+	}
+}
+
+// TestBuilder_Sysprep verifies the Sysprep capability surfaces on the
+// GetCapabilitiesResponse only when advertised.
+func TestBuilder_Sysprep(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.Sysprep()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsSysprep != advertise {
+			t.Errorf("SupportsSysprep = %v, want %v", resp.SupportsSysprep, advertise)
+		}
+	}
+}
+
+// TestBuilder_CloudInitStatus verifies the cloud-init status capability
+// surfaces on the GetCapabilitiesResponse only when advertised.
+func TestBuilder_CloudInitStatus(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.CloudInitStatus()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsCloudInitStatus != advertise {
+			t.Errorf("SupportsCloudInitStatus = %v, want %v", resp.SupportsCloudInitStatus, advertise)
+		}
+	}
+}
+
+// TestBuilder_LiveMigration verifies the live-migration capability surfaces
+// on the GetCapabilitiesResponse only when advertised.
+func TestBuilder_LiveMigration(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.LiveMigration()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsLiveMigration != advertise {
+			t.Errorf("SupportsLiveMigration = %v, want %v", resp.SupportsLiveMigration, advertise)
+		}
+	}
+}
+
+// TestBuilder_ConsoleProxy verifies the console-proxy capability surfaces
+// on the GetCapabilitiesResponse only when advertised.
+func TestBuilder_ConsoleProxy(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.ConsoleProxy()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsConsoleProxy != advertise {
+			t.Errorf("SupportsConsoleProxy = %v, want %v", resp.SupportsConsoleProxy, advertise)
+		}
+	}
+}
+
+// TestBuilder_ImagePublish verifies the image-publish capability surfaces
+// on the GetCapabilitiesResponse only when advertised.
+func TestBuilder_ImagePublish(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.ImagePublish()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsImagePublish != advertise {
+			t.Errorf("SupportsImagePublish = %v, want %v", resp.SupportsImagePublish, advertise)
+		}
+	}
+}
+
+// TestBuilder_EventWatch verifies the event-watch capability surfaces on
+// the GetCapabilitiesResponse only when advertised.
+func TestBuilder_EventWatch(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core()
+		if advertise {
+			b.EventWatch()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsEventWatch != advertise {
+			t.Errorf("SupportsEventWatch = %v, want %v", resp.SupportsEventWatch, advertise)
+		}
+	}
+}
+
+// TestBuilder_QuiescedSnapshots verifies the quiesced-snapshots capability
+// surfaces on the GetCapabilitiesResponse only when advertised.
+func TestBuilder_QuiescedSnapshots(t *testing.T) {
+	for _, advertise := range []bool{false, true} {
+		b := NewBuilder().Core().Snapshots()
+		if advertise {
+			b.QuiescedSnapshots()
+		}
+		resp, err := b.Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+		if err != nil {
+			t.Fatalf("GetCapabilities: %v", err)
+		}
+		if resp.SupportsQuiescedSnapshots != advertise {
+			t.Errorf("SupportsQuiescedSnapshots = %v, want %v", resp.SupportsQuiescedSnapshots, advertise)
+		}
+	}
+}
+
+// TestBuilder_Manifest verifies the manifest lists exactly what the Builder
+// added, in canonical order, and is reported by GetCapabilities.
+func TestBuilder_Manifest(t *testing.T) {
+	m := NewBuilder().ImageImport().Core().Snapshots().Build()
+	want := []string{"validate", "create", "delete", "power", "describe", "get_capabilities", "snapshots", "image_import"}
+	if got := m.Manifest(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Manifest() = %v, want %v", got, want)
+	}
+	resp, err := m.GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if !reflect.DeepEqual(resp.CapabilityManifest, want) {
+		t.Errorf("CapabilityManifest = %v, want %v", resp.CapabilityManifest, want)
+	}
+}
+
+// TestBuilder_ProtocolVersions verifies the Manager advertises the protocol
+// versions of the proto it was built with.
+func TestBuilder_ProtocolVersions(t *testing.T) {
+	resp, err := NewBuilder().Core().Build().GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if resp.MinProtocolVersion != providerv1.ProtocolVersion_PROTOCOL_VERSION_1 ||
+		resp.MaxProtocolVersion != providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT {
+		t.Errorf("protocol versions = %v..%v, want PROTOCOL_VERSION_1..PROTOCOL_VERSION_CURRENT",
+			resp.MinProtocolVersion, resp.MaxProtocolVersion)
+	}
+}
+
+// TestParse_CoversAllCapabilities guards the canonical list: every flag in
+// All must round-trip through Parse, and near-misses must be rejected.
+func TestParse_CoversAllCapabilities(t *testing.T) {
+	for _, c := range All() {
+		got, ok := Parse(string(c))
+		if !ok || got != c {
+			t.Errorf("Parse(%q) = %q, %v", c, got, ok)
+		}
+	}
+	for _, name := range []string{"", "snapshotss", "Snapshots", "vm-create"} {
+		if _, ok := Parse(name); ok {
+			t.Errorf("Parse(%q) should fail", name)
+		}
+	}
+	for _, c := range GetProfileCapabilities(ProfileCore) {
+		if _, ok := Parse(string(c)); !ok {
+			t.Errorf("core profile capability %q missing from All", c)
+		}
+	}
+}
@@ -245,7 +245,7 @@ func (c *Client) Power(ctx context.Context, req *providerv1.PowerRequest) (*prov
 }
 
 // Reconfigure reconfigures a virtual machine.
-func (c *Client) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.TaskResponse, error) {
+func (c *Client) Reconfigure(ctx context.Context, req *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
 	ctx = c.withTimeout(ctx, "/provider.v1.Provider/Reconfigure")
 	resp, err := c.client.Reconfigure(ctx, req)
 	return resp, errors.FromGRPCError(err)
@@ -296,6 +296,20 @@ func (c *Client) SnapshotRevert(ctx context.Context, req *providerv1.SnapshotRev
 	return resp, errors.FromGRPCError(err)
 }
 
+// SnapshotList lists the snapshots that exist for a VM on the hypervisor.
+func (c *Client) SnapshotList(ctx context.Context, req *providerv1.SnapshotListRequest) (*providerv1.SnapshotListResponse, error) {
+	ctx = c.withTimeout(ctx, "/provider.v1.Provider/SnapshotList")
+	resp, err := c.client.SnapshotList(ctx, req)
+	return resp, errors.FromGRPCError(err)
+}
+
+// PublishImage publishes a copy of a virtual machine as a template or image.
+func (c *Client) PublishImage(ctx context.Context, req *providerv1.PublishImageRequest) (*providerv1.PublishImageResponse, error) {
+	ctx = c.withTimeout(ctx, "/provider.v1.Provider/PublishImage")
+	resp, err := c.client.PublishImage(ctx, req)
+	return resp, errors.FromGRPCError(err)
+}
+
 // Clone clones a virtual machine.
 func (c *Client) Clone(ctx context.Context, req *providerv1.CloneRequest) (*providerv1.CloneResponse, error) {
 	ctx = c.withTimeout(ctx, "/provider.v1.Provider/Clone")
@@ -320,6 +334,13 @@ func (c *Client) GetCapabilities(ctx context.Context, req *providerv1.GetCapabil
 	return resp, errors.FromGRPCError(err)
 }
 
+// GetInfo gets the provider build and hypervisor version information.
+func (c *Client) GetInfo(ctx context.Context, req *providerv1.GetInfoRequest) (*providerv1.GetInfoResponse, error) {
+	ctx = c.withTimeout(ctx, "/provider.v1.Provider/GetInfo")
+	resp, err := c.client.GetInfo(ctx, req)
+	return resp, errors.FromGRPCError(err)
+}
+
 // withTimeout adds a timeout to the context if configured.
 func (c *Client) withTimeout(ctx context.Context, method string) context.Context {
 	if c.config.Timeout == nil {
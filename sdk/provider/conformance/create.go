@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance holds checks a provider's own tests run to show it
+// honors parts of the provider contract that a single RPC cannot verify.
+package conformance
+
+import (
+	"context"
+	"fmt"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// CreateServer is the part of providerv1.ProviderServer
+// CheckIdempotentCreate exercises.
+type CreateServer interface {
+	Create(context.Context, *providerv1.CreateRequest) (*providerv1.CreateResponse, error)
+	ListVMs(context.Context, *providerv1.ListVMsRequest) (*providerv1.ListVMsResponse, error)
+}
+
+// CheckIdempotentCreate is the conformance check for CreateRequest's
+// idempotency key. It sends req twice, as the manager does when the first
+// response is lost, and requires both calls to return the same ID and
+// exactly one VM named req.Name to exist afterwards. req must carry an
+// idempotency key and a name no other VM on the provider uses. The VM is
+// left in place for the caller to delete; its ID is returned.
+func CheckIdempotentCreate(ctx context.Context, srv CreateServer, req *providerv1.CreateRequest) (string, error) {
+	if req.GetIdempotencyKey() == "" || req.GetName() == "" {
+		return "", fmt.Errorf("CheckIdempotentCreate needs a request with a name and an idempotency key")
+	}
+
+	first, err := srv.Create(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("first Create failed: %w", err)
+	}
+	second, err := srv.Create(ctx, req)
+	if err != nil {
+		return first.GetId(), fmt.Errorf("Create repeating idempotency key %q failed: %w; it must return the existing VM", req.GetIdempotencyKey(), err)
+	}
+	if second.GetId() != first.GetId() {
+		return first.GetId(), fmt.Errorf("Create repeating idempotency key %q returned VM %q, want %q from the first call",
+			req.GetIdempotencyKey(), second.GetId(), first.GetId())
+	}
+
+	list, err := srv.ListVMs(ctx, &providerv1.ListVMsRequest{})
+	if err != nil {
+		return first.GetId(), fmt.Errorf("ListVMs failed: %w", err)
+	}
+	var ids []string
+	for _, vm := range list.GetVms() {
+		if vm.GetName() == req.GetName() {
+			ids = append(ids, vm.GetId())
+		}
+	}
+	if len(ids) != 1 {
+		return first.GetId(), fmt.Errorf("found %d VMs named %q after two Creates with one idempotency key (%v), want exactly 1",
+			len(ids), req.GetName(), ids)
+	}
+	return first.GetId(), nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// vmServer creates a VM per Create call, or per distinct key when
+// honorKeys is set.
+type vmServer struct {
+	honorKeys bool
+	vms       []*providerv1.VMInfo
+	keys      map[string]string
+}
+
+func (s *vmServer) Create(_ context.Context, req *providerv1.CreateRequest) (*providerv1.CreateResponse, error) {
+	if id, ok := s.keys[req.IdempotencyKey]; ok && s.honorKeys {
+		return &providerv1.CreateResponse{Id: id}, nil
+	}
+	id := fmt.Sprintf("vm-%d", len(s.vms)+1)
+	s.vms = append(s.vms, &providerv1.VMInfo{Id: id, Name: req.Name})
+	if s.keys == nil {
+		s.keys = map[string]string{}
+	}
+	s.keys[req.IdempotencyKey] = id
+	return &providerv1.CreateResponse{Id: id}, nil
+}
+
+func (s *vmServer) ListVMs(context.Context, *providerv1.ListVMsRequest) (*providerv1.ListVMsResponse, error) {
+	return &providerv1.ListVMsResponse{Vms: s.vms}, nil
+}
+
+func TestCheckIdempotentCreate(t *testing.T) {
+	ctx := context.Background()
+	req := &providerv1.CreateRequest{Name: "web-0", IdempotencyKey: "uid-1"}
+
+	id, err := CheckIdempotentCreate(ctx, &vmServer{honorKeys: true}, req)
+	if err != nil {
+		t.Fatalf("key-honoring provider failed the check: %v", err)
+	}
+	if id != "vm-1" {
+		t.Errorf("returned ID %q, want vm-1", id)
+	}
+
+	if _, err := CheckIdempotentCreate(ctx, &vmServer{}, req); err == nil {
+		t.Error("provider creating a second VM passed the check")
+	}
+
+	if _, err := CheckIdempotentCreate(ctx, &vmServer{honorKeys: true}, &providerv1.CreateRequest{Name: "web-0"}); err == nil {
+		t.Error("check accepted a request without an idempotency key")
+	}
+}
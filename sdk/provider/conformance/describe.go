@@ -0,0 +1,50 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/describe"
+)
+
+// DescribeServer is the part of providerv1.ProviderServer
+// CheckDescribeDetails exercises.
+type DescribeServer interface {
+	Describe(context.Context, *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error)
+}
+
+// CheckDescribeDetails is the conformance check for provider_raw_json. It
+// describes the existing VM id and requires its provider_raw_json to be in
+// the describe package's layout, with no keys the layout does not define:
+// provider-specific details belong in Extras. The manager reads anything
+// else as a free-form map, which no consumer can rely on.
+func CheckDescribeDetails(ctx context.Context, srv DescribeServer, id string) (*describe.Details, error) {
+	desc, err := srv.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("Describe of VM %q failed: %w", id, err)
+	}
+	if !desc.GetExists() {
+		return nil, fmt.Errorf("Describe reports VM %q as missing", id)
+	}
+	if err := describe.Validate(desc.GetProviderRawJson()); err != nil {
+		return nil, fmt.Errorf("Describe of VM %q: %w", id, err)
+	}
+	return describe.Parse(desc.GetProviderRawJson())
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// rawServer describes every VM with the same provider_raw_json.
+type rawServer string
+
+func (s rawServer) Describe(_ context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	if req.Id == "gone" {
+		return &providerv1.DescribeResponse{}, nil
+	}
+	return &providerv1.DescribeResponse{Exists: true, PowerState: "On", ProviderRawJson: string(s)}, nil
+}
+
+func TestCheckDescribeDetails(t *testing.T) {
+	ctx := context.Background()
+
+	d, err := CheckDescribeDetails(ctx, rawServer(`{"schema":"virtrigaud.io/describe/v1","host":"pve1","extras":{"qmpstatus":"running"}}`), "100")
+	if err != nil {
+		t.Fatalf("typed details failed the check: %v", err)
+	}
+	if d.Host != "pve1" || d.Extras["qmpstatus"] != "running" {
+		t.Errorf("check returned %+v", d)
+	}
+
+	for name, srv := range map[string]rawServer{
+		"free-form map":           `{"node":"pve1","qmpstatus":"running"}`,
+		"ad-hoc top-level key":    `{"schema":"virtrigaud.io/describe/v1","qmpstatus":"running"}`,
+		"empty provider_raw_json": ``,
+	} {
+		if _, err := CheckDescribeDetails(ctx, srv, "100"); err == nil {
+			t.Errorf("%s passed the check", name)
+		}
+	}
+	if _, err := CheckDescribeDetails(ctx, rawServer(`{"schema":"virtrigaud.io/describe/v1"}`), "gone"); err == nil {
+		t.Error("check passed for a VM Describe reports as missing")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// IdentityServer is the part of providerv1.ProviderServer
+// CheckIdentityRoundTrip exercises.
+type IdentityServer interface {
+	Create(context.Context, *providerv1.CreateRequest) (*providerv1.CreateResponse, error)
+	Describe(context.Context, *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error)
+}
+
+// CheckIdentityRoundTrip is the conformance check for the identity marker.
+// It creates a VM from req and requires Describe to report req's
+// idempotency key as the VM's identity, which the manager compares with
+// the VirtualMachine's UID before acting on the VM. req must carry an
+// idempotency key in the form of a Kubernetes UID (lowercase hex and
+// dashes), which every hypervisor can store unchanged. The VM is left in
+// place for the caller to delete; its ID is returned.
+func CheckIdentityRoundTrip(ctx context.Context, srv IdentityServer, req *providerv1.CreateRequest) (string, error) {
+	if req.GetIdempotencyKey() == "" {
+		return "", fmt.Errorf("CheckIdentityRoundTrip needs a request with an idempotency key")
+	}
+
+	created, err := srv.Create(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("Create failed: %w", err)
+	}
+	desc, err := srv.Describe(ctx, &providerv1.DescribeRequest{Id: created.GetId()})
+	if err != nil {
+		return created.GetId(), fmt.Errorf("Describe of VM %q failed: %w", created.GetId(), err)
+	}
+	if !desc.GetExists() {
+		return created.GetId(), fmt.Errorf("Describe reports the VM %q it just created as missing", created.GetId())
+	}
+	if desc.GetIdentity() != req.GetIdempotencyKey() {
+		return created.GetId(), fmt.Errorf("Describe of VM %q reports identity %q, want the idempotency key %q it was created with",
+			created.GetId(), desc.GetIdentity(), req.GetIdempotencyKey())
+	}
+	return created.GetId(), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// markingServer is a vmServer whose Describe reports the key each VM was
+// created with, unless dropMarker is set.
+type markingServer struct {
+	vmServer
+	dropMarker bool
+}
+
+func (s *markingServer) Describe(_ context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	for key, id := range s.keys {
+		if id == req.Id {
+			if s.dropMarker {
+				key = ""
+			}
+			return &providerv1.DescribeResponse{Exists: true, PowerState: "On", Identity: key}, nil
+		}
+	}
+	return &providerv1.DescribeResponse{Exists: false}, nil
+}
+
+func TestCheckIdentityRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	req := &providerv1.CreateRequest{Name: "web-0", IdempotencyKey: "3f6c1a9e-0b1d-4c2a-9e7f-1234567890ab"}
+
+	id, err := CheckIdentityRoundTrip(ctx, &markingServer{}, req)
+	if err != nil {
+		t.Fatalf("marking provider failed the check: %v", err)
+	}
+	if id != "vm-1" {
+		t.Errorf("returned ID %q, want vm-1", id)
+	}
+
+	if _, err := CheckIdentityRoundTrip(ctx, &markingServer{dropMarker: true}, req); err == nil {
+		t.Error("provider without a marker passed the check")
+	}
+
+	if _, err := CheckIdentityRoundTrip(ctx, &markingServer{}, &providerv1.CreateRequest{Name: "web-0"}); err == nil {
+		t.Error("check accepted a request without an idempotency key")
+	}
+}
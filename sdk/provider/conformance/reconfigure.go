@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// ReconfigureServer is the part of providerv1.ProviderServer
+// CheckConcurrentReconfigure exercises.
+type ReconfigureServer interface {
+	Reconfigure(context.Context, *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error)
+}
+
+// CheckConcurrentReconfigure is the conformance check for changes that race
+// on one VM, as two reconciles or a reconcile and a hypervisor UI user do.
+// It sends reqs at the same time and requires each to succeed or fail with
+// Aborted (errors.NewConflict), which the manager retries shortly. Any other
+// failure, such as an Internal error for a locked VM config, fails the
+// check. reqs must all target one VM. It returns how many calls conflicted.
+func CheckConcurrentReconfigure(ctx context.Context, srv ReconfigureServer, reqs ...*providerv1.ReconfigureRequest) (int, error) {
+	if len(reqs) < 2 {
+		return 0, fmt.Errorf("CheckConcurrentReconfigure needs at least two requests")
+	}
+	for _, req := range reqs[1:] {
+		if req.GetId() != reqs[0].GetId() {
+			return 0, fmt.Errorf("CheckConcurrentReconfigure needs requests for one VM, got %q and %q", reqs[0].GetId(), req.GetId())
+		}
+	}
+
+	errs := make([]error, len(reqs))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = srv.Reconfigure(ctx, req)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	conflicts := 0
+	var failures []string
+	for i, err := range errs {
+		switch code := status.Code(err); code {
+		case codes.OK:
+		case codes.Aborted:
+			conflicts++
+		default:
+			failures = append(failures, fmt.Sprintf("call %d: %s: %v", i, code, err))
+		}
+	}
+	if len(failures) > 0 {
+		return conflicts, fmt.Errorf("concurrent Reconfigure calls must succeed or return Aborted, got %s", strings.Join(failures, "; "))
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	perrors "github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// lockingServer lets the first Reconfigure hold the VM's config until the
+// others have been turned away with lockedErr.
+type lockingServer struct {
+	mu        sync.Mutex
+	held      bool
+	rejected  int
+	others    int
+	release   chan struct{}
+	lockedErr error
+}
+
+func newLockingServer(others int, lockedErr error) *lockingServer {
+	return &lockingServer{others: others, release: make(chan struct{}), lockedErr: lockedErr}
+}
+
+func (s *lockingServer) Reconfigure(context.Context, *providerv1.ReconfigureRequest) (*providerv1.ReconfigureResponse, error) {
+	s.mu.Lock()
+	if s.held {
+		s.rejected++
+		if s.rejected == s.others {
+			close(s.release)
+		}
+		s.mu.Unlock()
+		return nil, s.lockedErr
+	}
+	s.held = true
+	s.mu.Unlock()
+	<-s.release
+	return &providerv1.ReconfigureResponse{}, nil
+}
+
+func TestCheckConcurrentReconfigure(t *testing.T) {
+	ctx := context.Background()
+	reqs := []*providerv1.ReconfigureRequest{{Id: "vm-1"}, {Id: "vm-1"}, {Id: "vm-1"}}
+
+	conflicts, err := CheckConcurrentReconfigure(ctx, newLockingServer(2, perrors.NewConflict("VM config is locked")), reqs...)
+	if err != nil {
+		t.Fatalf("provider returning Conflict failed the check: %v", err)
+	}
+	if conflicts != 2 {
+		t.Errorf("counted %d conflicts, want 2", conflicts)
+	}
+
+	internal := newLockingServer(2, perrors.NewInternal("failed to reconfigure VM", errors.New("can't lock file")))
+	if _, err := CheckConcurrentReconfigure(ctx, internal, reqs...); err == nil {
+		t.Error("provider returning Internal for a locked config passed the check")
+	}
+
+	two := []*providerv1.ReconfigureRequest{{Id: "vm-1"}, {Id: "vm-2"}}
+	if _, err := CheckConcurrentReconfigure(ctx, newLockingServer(1, nil), two...); err == nil {
+		t.Error("check accepted requests for two VMs")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describe
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// NewResourceStats returns the resources of a VM with cpuCount vCPUs and
+// memoryMaxBytes of memory, taking its usage from the GuestStats sent in
+// the same DescribeResponse so the two never disagree. Zero sizes and a nil
+// usage are left out.
+func NewResourceStats(cpuCount int32, memoryMaxBytes int64, usage *providerv1.GuestStats) *ResourceStats {
+	r := &ResourceStats{CPUCount: cpuCount}
+	if memoryMaxBytes > 0 {
+		r.MemoryMaxBytes = &memoryMaxBytes
+	}
+	if usage != nil {
+		r.CPUUsagePercent = usage.CpuUsagePercent
+		r.MemoryUsageBytes = usage.MemoryUsageBytes
+		r.UptimeSeconds = usage.UptimeSeconds
+	}
+	if *r == (ResourceStats{}) {
+		return nil
+	}
+	return r
+}
+
+// InterfacesFromAddresses groups the GuestAddresses of a DescribeResponse
+// by interface, in the order the interfaces first appear, and gives each
+// the MAC macs has for its name. Addresses without an interface are left
+// out: the layout has no name to put them under.
+//
+// Each interface's addresses are normalized so they do not depend on the
+// order the guest enumerates them in: IPv4 before IPv6, each family in
+// address order, without duplicates. Addresses that do not parse, and
+// loopback, link-local, multicast and unspecified ones, are dropped.
+func InterfacesFromAddresses(addresses []*providerv1.GuestAddress, macs map[string]string) []NetworkInterface {
+	var (
+		out   []NetworkInterface
+		addrs [][]netip.Addr
+	)
+	index := map[string]int{}
+	for _, a := range addresses {
+		if a.GetInterface() == "" {
+			continue
+		}
+		i, ok := index[a.Interface]
+		if !ok {
+			i = len(out)
+			index[a.Interface] = i
+			out = append(out, NetworkInterface{Name: a.Interface, MAC: macs[a.Interface]})
+			addrs = append(addrs, nil)
+		}
+		if addr, ok := parseAddress(a.Ip); ok {
+			addrs[i] = append(addrs[i], addr)
+		}
+	}
+
+	for i := range out {
+		// netip orders IPv4 before IPv6 and then by address bytes.
+		slices.SortFunc(addrs[i], netip.Addr.Compare)
+		for _, addr := range slices.Compact(addrs[i]) {
+			family := FamilyIPv6
+			if addr.Is4() {
+				family = FamilyIPv4
+			}
+			out[i].IPs = append(out[i].IPs, addr.String())
+			out[i].Addresses = append(out[i].Addresses, InterfaceAddress{IP: addr.String(), Family: family})
+		}
+	}
+	return out
+}
+
+// parseAddress parses an address as guests report it, tolerating a zone
+// ("fe80::1%eth0") or prefix length ("10.0.0.5/24"), and reports whether it
+// reaches the VM from outside.
+func parseAddress(raw string) (netip.Addr, bool) {
+	raw = strings.TrimSpace(raw)
+	if i := strings.IndexByte(raw, '/'); i >= 0 {
+		raw = raw[:i]
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr = addr.WithZone("").Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// NewSnapshotStats counts the snapshots of a VM. parents maps each snapshot
+// to its parent, "" for a root; current is the snapshot the running state
+// was taken after, "" when there is none. A parent missing from parents
+// ends the chain.
+func NewSnapshotStats(parents map[string]string, current string) *SnapshotStats {
+	s := &SnapshotStats{Count: int32(len(parents))}
+	seen := map[string]bool{}
+	for id := current; id != "" && !seen[id]; id = parents[id] {
+		if _, ok := parents[id]; !ok {
+			break
+		}
+		seen[id] = true
+		s.ChainDepth++
+	}
+	return s
+}
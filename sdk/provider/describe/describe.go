@@ -0,0 +1,280 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package describe is the layout of a DescribeResponse's provider_raw_json.
+// A provider fills a Details and sends Marshal's output; the manager reads
+// it back with Parse. The fields every hypervisor can report have a typed
+// home, so consumers find a VM's host, guest OS or memory under the same
+// name whichever provider runs it; anything else goes in Extras.
+//
+// The layout is versioned by its "schema" key, Schema. Fields are only
+// added within a version; a rename or a change of meaning is a new version.
+package describe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Schema is the value of the "schema" key of this layout.
+const Schema = "virtrigaud.io/describe/v1"
+
+// schemaPrefix starts the schema of every version of the layout.
+const schemaPrefix = "virtrigaud.io/describe/"
+
+// ErrUnversioned is returned by Parse for a JSON object without a "schema"
+// key: the free-form map providers sent before this layout existed.
+var ErrUnversioned = errors.New("provider_raw_json has no schema key")
+
+// Details is what a provider reports about a VM beyond the typed fields of
+// DescribeResponse. Every field is optional.
+type Details struct {
+	// Host is the hypervisor host the VM runs on, e.g. the ESXi host, the
+	// Proxmox VE node or the libvirt host. It is the name MigrateHost and
+	// placement hints take.
+	Host string `json:"host,omitempty"`
+	// Guest is what the guest tools or agent report from inside the VM.
+	Guest *GuestInfo `json:"guest,omitempty"`
+	// Resources are the VM's configured size and its current usage.
+	Resources *ResourceStats `json:"resources,omitempty"`
+	// Interfaces are the VM's network interfaces.
+	Interfaces []NetworkInterface `json:"interfaces,omitempty"`
+	// Snapshots counts the VM's snapshots; nil when the provider cannot
+	// list them.
+	Snapshots *SnapshotStats `json:"snapshots,omitempty"`
+	// BusyReason is set while the hypervisor is running an operation on
+	// the VM that refuses or delays other changes to it, such as a backup
+	// or a migration, and says which, e.g. "config locked: backup". It is
+	// "" when the VM is free or the provider cannot tell.
+	BusyReason string `json:"busyReason,omitempty"`
+	// Extras holds provider-specific details with no typed field, e.g. a
+	// Proxmox VE lock or a vSphere connection state. Keys are snake_case.
+	Extras map[string]string `json:"extras,omitempty"`
+}
+
+// GuestInfo is what the guest tools or agent report.
+type GuestInfo struct {
+	// Hostname is the guest's own host name.
+	Hostname string `json:"hostname,omitempty"`
+	// OSName is the guest operating system, as the tools name it, e.g.
+	// "Ubuntu 24.04.1 LTS".
+	OSName string `json:"osName,omitempty"`
+	// ToolsStatus is the state of the guest tools or agent in the
+	// hypervisor's own terms, e.g. "toolsOk" or "connected".
+	ToolsStatus string `json:"toolsStatus,omitempty"`
+	// ToolsVersion is the version of the guest tools or agent.
+	ToolsVersion string `json:"toolsVersion,omitempty"`
+}
+
+// ResourceStats are a VM's configured size and current usage. Usage fields
+// are nil when the hypervisor does not know them, e.g. for a stopped VM.
+type ResourceStats struct {
+	// CPUCount is the number of vCPUs configured.
+	CPUCount int32 `json:"cpuCount,omitempty"`
+	// CPUUsagePercent is CPU utilization across all vCPUs, 0-100.
+	CPUUsagePercent *float64 `json:"cpuUsagePercent,omitempty"`
+	// MemoryUsageBytes is the memory in use by the guest.
+	MemoryUsageBytes *int64 `json:"memoryUsageBytes,omitempty"`
+	// MemoryMaxBytes is the memory configured.
+	MemoryMaxBytes *int64 `json:"memoryMaxBytes,omitempty"`
+	// UptimeSeconds is the time since the VM was last powered on.
+	UptimeSeconds *int64 `json:"uptimeSeconds,omitempty"`
+}
+
+// NetworkInterface is one network interface of a VM.
+type NetworkInterface struct {
+	// Name is the interface's name: the guest's name for it when the guest
+	// reports one, e.g. "eth0", otherwise the hypervisor's, e.g. a port
+	// group.
+	Name string `json:"name"`
+	// MAC is the interface's hardware address.
+	MAC string `json:"mac,omitempty"`
+	// IPs are the addresses the guest reports on the interface.
+	IPs []string `json:"ips,omitempty"`
+	// Addresses are IPs tagged with their family, in the same order.
+	Addresses []InterfaceAddress `json:"addresses,omitempty"`
+}
+
+// IP families of an InterfaceAddress.
+const (
+	FamilyIPv4 = "IPv4"
+	FamilyIPv6 = "IPv6"
+)
+
+// InterfaceAddress is one IP address of a network interface.
+type InterfaceAddress struct {
+	// IP is the address, without a prefix length or zone.
+	IP string `json:"ip"`
+	// Family is FamilyIPv4 or FamilyIPv6.
+	Family string `json:"family"`
+}
+
+// SnapshotStats counts a VM's snapshots. Hypervisors that keep each
+// snapshot as a delta of its parent read every delta below the running
+// state, so a deep chain slows the VM's disks; some operations, such as
+// growing a disk, fail while any snapshot exists.
+type SnapshotStats struct {
+	// Count is the number of snapshots, on every branch of the tree.
+	Count int32 `json:"count"`
+	// ChainDepth is the number of snapshots the running state is built
+	// on: the current snapshot and its ancestors. It is at most Count, and
+	// equals it when the snapshots form a single chain.
+	ChainDepth int32 `json:"chainDepth"`
+}
+
+// details has Details' fields without its methods, for encoding next to
+// the schema key.
+type details Details
+
+type versioned struct {
+	Schema string `json:"schema"`
+	*details
+}
+
+// Marshal returns d as provider_raw_json.
+func (d *Details) Marshal() (string, error) {
+	if d == nil {
+		d = &Details{}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Console and guest strings are shown as they are; HTML escaping would
+	// only garble them.
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(versioned{Schema: Schema, details: (*details)(d)}); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Parse reads provider_raw_json in this layout. Unknown keys are ignored,
+// so a manager reads details from a provider built against a later release
+// of the same version. It returns ErrUnversioned for a JSON object without
+// a schema, and an error for another version of the layout.
+func Parse(raw string) (*Details, error) {
+	return parse(raw, false)
+}
+
+// Validate is Parse for conformance tests: it also rejects keys the layout
+// does not define, and values a consumer could not use, such as an
+// interface without a name.
+func Validate(raw string) error {
+	d, err := parse(raw, true)
+	if err != nil {
+		return err
+	}
+	for i, iface := range d.Interfaces {
+		if iface.Name == "" {
+			return fmt.Errorf("interfaces[%d] has no name", i)
+		}
+	}
+	if r := d.Resources; r != nil && r.CPUUsagePercent != nil && (*r.CPUUsagePercent < 0 || *r.CPUUsagePercent > 100) {
+		return fmt.Errorf("resources.cpuUsagePercent is %g, want 0-100", *r.CPUUsagePercent)
+	}
+	if s := d.Snapshots; s != nil && (s.Count < 0 || s.ChainDepth < 0 || s.ChainDepth > s.Count) {
+		return fmt.Errorf("snapshots.chainDepth is %d with %d snapshots, want 0-count", s.ChainDepth, s.Count)
+	}
+	for k := range d.Extras {
+		if k == "" {
+			return fmt.Errorf("extras has an empty key")
+		}
+	}
+	return nil
+}
+
+func parse(raw string, strict bool) (*Details, error) {
+	var head struct {
+		Schema *string `json:"schema"`
+	}
+	if err := json.Unmarshal([]byte(raw), &head); err != nil {
+		return nil, fmt.Errorf("provider_raw_json is not a JSON object: %w", err)
+	}
+	switch {
+	case head.Schema == nil:
+		return nil, ErrUnversioned
+	case *head.Schema == Schema:
+	case strings.HasPrefix(*head.Schema, schemaPrefix):
+		return nil, fmt.Errorf("provider_raw_json has schema %q, this release reads %q", *head.Schema, Schema)
+	default:
+		return nil, fmt.Errorf("provider_raw_json has unknown schema %q", *head.Schema)
+	}
+
+	d := &Details{}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&versioned{details: (*details)(d)}); err != nil {
+		return nil, fmt.Errorf("provider_raw_json does not match %s: %w", Schema, err)
+	}
+	return d, nil
+}
+
+// Flatten returns d as the flat string map of VirtualMachine
+// status.provider. Typed fields are keyed by their JSON path, e.g.
+// "guest.osName" or "interfaces.eth0.mac"; extras keep their own keys
+// unless they clash with one of those.
+func (d *Details) Flatten() map[string]string {
+	out := map[string]string{"schema": Schema}
+	set := func(k, v string) {
+		if v != "" {
+			out[k] = v
+		}
+	}
+	set("host", d.Host)
+	set("busyReason", d.BusyReason)
+	if g := d.Guest; g != nil {
+		set("guest.hostname", g.Hostname)
+		set("guest.osName", g.OSName)
+		set("guest.toolsStatus", g.ToolsStatus)
+		set("guest.toolsVersion", g.ToolsVersion)
+	}
+	if r := d.Resources; r != nil {
+		if r.CPUCount != 0 {
+			set("resources.cpuCount", strconv.Itoa(int(r.CPUCount)))
+		}
+		if r.CPUUsagePercent != nil {
+			set("resources.cpuUsagePercent", strconv.FormatFloat(*r.CPUUsagePercent, 'f', -1, 64))
+		}
+		for k, v := range map[string]*int64{
+			"resources.memoryUsageBytes": r.MemoryUsageBytes,
+			"resources.memoryMaxBytes":   r.MemoryMaxBytes,
+			"resources.uptimeSeconds":    r.UptimeSeconds,
+		} {
+			if v != nil {
+				set(k, strconv.FormatInt(*v, 10))
+			}
+		}
+	}
+	for _, iface := range d.Interfaces {
+		set("interfaces."+iface.Name+".mac", iface.MAC)
+		set("interfaces."+iface.Name+".ips", strings.Join(iface.IPs, ","))
+	}
+	if s := d.Snapshots; s != nil {
+		set("snapshots.count", strconv.Itoa(int(s.Count)))
+		set("snapshots.chainDepth", strconv.Itoa(int(s.ChainDepth)))
+	}
+	for k, v := range d.Extras {
+		if _, typed := out[k]; !typed {
+			set(k, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describe
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+var sample = &Details{
+	Host: "esx-03.lab",
+	Guest: &GuestInfo{
+		Hostname:     "web-1",
+		OSName:       "Ubuntu 24.04.1 LTS",
+		ToolsStatus:  "toolsOk",
+		ToolsVersion: "12416",
+	},
+	Resources: &ResourceStats{
+		CPUCount:         4,
+		CPUUsagePercent:  ptr(12.5),
+		MemoryUsageBytes: ptr(int64(2 << 30)),
+		MemoryMaxBytes:   ptr(int64(8 << 30)),
+		UptimeSeconds:    ptr(int64(3600)),
+	},
+	Interfaces: []NetworkInterface{{Name: "eth0", MAC: "00:50:56:aa:bb:cc", IPs: []string{"10.0.0.5", "fd00::5"}}},
+	Snapshots:  &SnapshotStats{Count: 3, ChainDepth: 2},
+	BusyReason: "task running: vMotion",
+	Extras:     map[string]string{"connection_state": "connected", "note": "<a&b>"},
+}
+
+// The layout is part of the provider protocol: this pins it.
+const sampleJSON = `{"schema":"virtrigaud.io/describe/v1","host":"esx-03.lab",` +
+	`"guest":{"hostname":"web-1","osName":"Ubuntu 24.04.1 LTS","toolsStatus":"toolsOk","toolsVersion":"12416"},` +
+	`"resources":{"cpuCount":4,"cpuUsagePercent":12.5,"memoryUsageBytes":2147483648,"memoryMaxBytes":8589934592,"uptimeSeconds":3600},` +
+	`"interfaces":[{"name":"eth0","mac":"00:50:56:aa:bb:cc","ips":["10.0.0.5","fd00::5"]}],` +
+	`"snapshots":{"count":3,"chainDepth":2},"busyReason":"task running: vMotion",` +
+	`"extras":{"connection_state":"connected","note":"<a&b>"}}`
+
+func TestMarshalLayout(t *testing.T) {
+	got, err := sample.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != sampleJSON {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", got, sampleJSON)
+	}
+
+	empty, err := (&Details{}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty != `{"schema":"virtrigaud.io/describe/v1"}` {
+		t.Errorf("empty Details marshal to %s", empty)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	d, err := Parse(sampleJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := d.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != sampleJSON {
+		t.Errorf("round trip changed the details:\n%s", again)
+	}
+	if err := Validate(sampleJSON); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse(`{"node":"pve1","qmpstatus":"running"}`); !errors.Is(err, ErrUnversioned) {
+		t.Errorf("free-form map: got %v, want ErrUnversioned", err)
+	}
+	for raw, want := range map[string]string{
+		`[1,2]`:                                            "not a JSON object",
+		`{"schema":"virtrigaud.io/describe/v2"}`:           "this release reads",
+		`{"schema":"example.com/other"}`:                   "unknown schema",
+		`{"schema":"virtrigaud.io/describe/v1","host":42}`: "does not match",
+	} {
+		_, err := Parse(raw)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%s) = %v, want an error containing %q", raw, err, want)
+		}
+	}
+}
+
+func TestParseIgnoresUnknownKeysButValidateDoesNot(t *testing.T) {
+	raw := `{"schema":"virtrigaud.io/describe/v1","host":"pve1","cpu_usage_mhz":300}`
+	d, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Host != "pve1" {
+		t.Errorf("Host = %q", d.Host)
+	}
+	if err := Validate(raw); err == nil || !strings.Contains(err.Error(), "cpu_usage_mhz") {
+		t.Errorf("Validate = %v, want the unknown key named", err)
+	}
+
+	for raw, want := range map[string]string{
+		`{"schema":"virtrigaud.io/describe/v1","interfaces":[{"mac":"00:11:22:33:44:55"}]}`: "interfaces[0] has no name",
+		`{"schema":"virtrigaud.io/describe/v1","resources":{"cpuUsagePercent":250}}`:        "want 0-100",
+		`{"schema":"virtrigaud.io/describe/v1","extras":{"":"x"}}`:                          "empty key",
+		`{"schema":"virtrigaud.io/describe/v1","snapshots":{"count":1,"chainDepth":2}}`:     "want 0-count",
+	} {
+		if err := Validate(raw); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate(%s) = %v, want an error containing %q", raw, err, want)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	d := *sample
+	d.Extras = map[string]string{"connection_state": "connected", "host": "shadowed"}
+	got := d.Flatten()
+	want := map[string]string{
+		"schema":                     Schema,
+		"host":                       "esx-03.lab",
+		"guest.hostname":             "web-1",
+		"guest.osName":               "Ubuntu 24.04.1 LTS",
+		"guest.toolsStatus":          "toolsOk",
+		"guest.toolsVersion":         "12416",
+		"resources.cpuCount":         "4",
+		"resources.cpuUsagePercent":  "12.5",
+		"resources.memoryUsageBytes": "2147483648",
+		"resources.memoryMaxBytes":   "8589934592",
+		"resources.uptimeSeconds":    "3600",
+		"interfaces.eth0.mac":        "00:50:56:aa:bb:cc",
+		"interfaces.eth0.ips":        "10.0.0.5,fd00::5",
+		"snapshots.count":            "3",
+		"snapshots.chainDepth":       "2",
+		"busyReason":                 "task running: vMotion",
+		"connection_state":           "connected",
+	}
+	if len(got) != len(want) {
+		t.Errorf("Flatten() has %d keys, want %d: %v", len(got), len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Flatten()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestNewResourceStats(t *testing.T) {
+	if r := NewResourceStats(0, 0, nil); r != nil {
+		t.Errorf("nothing to report: got %+v, want nil", r)
+	}
+	usage := &providerv1.GuestStats{CpuUsagePercent: ptr(3.5), UptimeSeconds: ptr(int64(60))}
+	r := NewResourceStats(2, 4<<30, usage)
+	if r.CPUCount != 2 || *r.MemoryMaxBytes != 4<<30 || *r.CPUUsagePercent != 3.5 || *r.UptimeSeconds != 60 || r.MemoryUsageBytes != nil {
+		t.Errorf("NewResourceStats = %+v", r)
+	}
+}
+
+func TestInterfacesFromAddresses(t *testing.T) {
+	got := InterfacesFromAddresses([]*providerv1.GuestAddress{
+		{Ip: "fd00::5", Interface: "eth0"},
+		{Ip: "192.168.1.9", Interface: "eth1"},
+		{Ip: "fe80::1%eth0", Interface: "eth0"},
+		{Ip: "10.0.0.5/24", Interface: "eth0"},
+		{Ip: "10.0.0.5", Interface: "eth0"},
+		{Ip: "127.0.0.1", Interface: "lo"},
+		{Ip: "172.16.0.1"},
+	}, map[string]string{"eth0": "52:54:00:aa:bb:cc"})
+	want := []NetworkInterface{
+		{Name: "eth0", MAC: "52:54:00:aa:bb:cc", IPs: []string{"10.0.0.5", "fd00::5"}, Addresses: []InterfaceAddress{
+			{IP: "10.0.0.5", Family: FamilyIPv4}, {IP: "fd00::5", Family: FamilyIPv6},
+		}},
+		{Name: "eth1", IPs: []string{"192.168.1.9"}, Addresses: []InterfaceAddress{{IP: "192.168.1.9", Family: FamilyIPv4}}},
+		{Name: "lo"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InterfacesFromAddresses = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewSnapshotStats(t *testing.T) {
+	// base <- nightly-1 <- nightly-2 (current), and base <- experiment
+	parents := map[string]string{"base": "", "nightly-1": "base", "nightly-2": "nightly-1", "experiment": "base"}
+	for current, want := range map[string]SnapshotStats{
+		"nightly-2":  {Count: 4, ChainDepth: 3},
+		"experiment": {Count: 4, ChainDepth: 2},
+		"":           {Count: 4},
+		"unknown":    {Count: 4},
+	} {
+		if got := NewSnapshotStats(parents, current); *got != want {
+			t.Errorf("NewSnapshotStats(current %q) = %+v, want %+v", current, *got, want)
+		}
+	}
+	if got := NewSnapshotStats(nil, ""); *got != (SnapshotStats{}) {
+		t.Errorf("no snapshots: got %+v", *got)
+	}
+	if got := NewSnapshotStats(map[string]string{"a": "b", "b": "a"}, "a"); got.ChainDepth != 2 {
+		t.Errorf("parent cycle: chain depth %d, want 2", got.ChainDepth)
+	}
+}
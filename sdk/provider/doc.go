@@ -24,6 +24,9 @@ The SDK includes the following packages:
   - middleware: gRPC interceptors for logging, authentication, rate limiting, recovery, and timeouts
   - capabilities: Provider capability management and advertisement
   - client: High-level gRPC client with retries, circuit breakers, and typed error mapping
+  - tasks: Async task bookkeeping that survives provider restarts, and the TaskStatus contract for unknown TaskRefs
+  - conformance: Checks a provider's tests run against contract rules such as idempotent Create
+  - describe: The typed layout of DescribeResponse.provider_raw_json: host, guest info, resources, interfaces and provider extras
 
 # Basic Usage
 
@@ -22,8 +22,10 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Provider error types that map to gRPC status codes.
@@ -54,6 +56,10 @@ var (
 
 	// Canceled indicates the operation was canceled
 	ErrCanceled = errors.New("operation canceled")
+
+	// Conflict indicates a concurrent change to the same resource got there
+	// first, such as a locked VM config or a task already running on the VM
+	ErrConflict = errors.New("conflicting change in progress")
 )
 
 // ProviderError wraps a native error with provider-specific context.
@@ -90,9 +96,19 @@ func (e *ProviderError) Unwrap() error {
 	return e.Cause
 }
 
-// GRPCStatus converts the error to a gRPC status.
+// GRPCStatus converts the error to a gRPC status. A RetryAfter hint travels
+// as an errdetails.RetryInfo detail, so the manager can tell "back off for a
+// while" apart from other failures sharing the same code.
 func (e *ProviderError) GRPCStatus() *status.Status {
-	return status.New(e.Code, e.Error())
+	st := status.New(e.Code, e.Error())
+	if e.RetryAfter <= 0 {
+		return st
+	}
+	withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	if err != nil {
+		return st
+	}
+	return withInfo
 }
 
 // NewInvalidSpec creates an invalid specification error.
@@ -160,6 +176,17 @@ func NewUnimplemented(operation string) *ProviderError {
 	}
 }
 
+// NewNotSupported creates an error for an operation the provider implements
+// but cannot perform for this resource, such as a guest without an agent. It
+// maps to Unimplemented, like an operation the provider lacks altogether.
+func NewNotSupported(message string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Code:      codes.Unimplemented,
+		Message:   fmt.Sprintf(message, args...),
+		Retryable: false,
+	}
+}
+
 // NewTimeout creates a timeout error.
 func NewTimeout(operation string, duration time.Duration) *ProviderError {
 	return &ProviderError{
@@ -179,6 +206,55 @@ func NewRateLimit(retryAfter time.Duration) *ProviderError {
 	}
 }
 
+// NewResourceExhausted creates an error for a request the provider cannot
+// satisfy with the capacity it has, such as a datastore without room for the
+// requested disks. It is retryable, since capacity may free up.
+func NewResourceExhausted(message string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Code:      codes.ResourceExhausted,
+		Message:   fmt.Sprintf(message, args...),
+		Retryable: true,
+	}
+}
+
+// NewBusy creates an error for a request the provider turned away because it
+// is already running as much of that work as it allows at once, such as the
+// maximum number of concurrent clones. It maps to ResourceExhausted with a
+// RetryAfter hint; the manager treats it as backpressure and retries after
+// the hint instead of recording a failure.
+func NewBusy(retryAfter time.Duration, message string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Code:       codes.ResourceExhausted,
+		Message:    fmt.Sprintf(message, args...),
+		Retryable:  true,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewConflict creates an error for a request that raced another change to
+// the same resource, such as a VM config locked by a running task. It maps
+// to Aborted and is retryable: the manager requeues shortly instead of
+// treating it as a failure.
+func NewConflict(message string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Code:      codes.Aborted,
+		Message:   fmt.Sprintf(message, args...),
+		Retryable: true,
+	}
+}
+
+// NewFailedPrecondition creates an error for a request the resource is not
+// in a state to satisfy, such as a quiesced snapshot of a VM whose guest
+// agent does not answer. It is not retryable: the same request fails until
+// the resource changes.
+func NewFailedPrecondition(message string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Code:      codes.FailedPrecondition,
+		Message:   fmt.Sprintf(message, args...),
+		Retryable: false,
+	}
+}
+
 // NewCanceled creates a canceled operation error.
 func NewCanceled(operation string) *ProviderError {
 	return &ProviderError{
@@ -243,10 +319,23 @@ func FromGRPCError(err error) *ProviderError {
 	}
 
 	return &ProviderError{
-		Code:      st.Code(),
-		Message:   st.Message(),
-		Retryable: isRetryable(st.Code()),
+		Code:       st.Code(),
+		Message:    st.Message(),
+		Retryable:  isRetryable(st.Code()),
+		RetryAfter: RetryDelay(st),
+	}
+}
+
+// RetryDelay returns the delay a status asks the caller to wait before
+// retrying, carried as an errdetails.RetryInfo detail, or zero when the
+// status has none.
+func RetryDelay(st *status.Status) time.Duration {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration()
+		}
 	}
+	return 0
 }
 
 // IsRetryable checks if an error indicates a retryable operation.
@@ -288,6 +377,35 @@ func IsInvalidSpec(err error) bool {
 	return errors.Is(err, ErrInvalidSpec)
 }
 
+// IsConflict checks if an error indicates a conflicting concurrent change.
+func IsConflict(err error) bool {
+	if pe, ok := err.(*ProviderError); ok {
+		return pe.Code == codes.Aborted
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st.Code() == codes.Aborted
+	}
+
+	return errors.Is(err, ErrConflict)
+}
+
+// IsBusy checks if an error indicates the provider turned the request away
+// to stay under a concurrency limit. It returns the suggested retry delay.
+func IsBusy(err error) (time.Duration, bool) {
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		return pe.RetryAfter, pe.Code == codes.ResourceExhausted && pe.RetryAfter > 0
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() == codes.ResourceExhausted {
+		delay := RetryDelay(st)
+		return delay, delay > 0
+	}
+
+	return 0, false
+}
+
 // classifyError attempts to classify a native error into a gRPC code.
 func classifyError(err error) codes.Code {
 	switch {
@@ -307,6 +425,8 @@ func classifyError(err error) codes.Code {
 		return codes.DeadlineExceeded
 	case errors.Is(err, ErrCanceled):
 		return codes.Canceled
+	case errors.Is(err, ErrConflict):
+		return codes.Aborted
 	default:
 		return codes.Internal
 	}
@@ -315,7 +435,7 @@ func classifyError(err error) codes.Code {
 // isRetryable determines if a gRPC code indicates a retryable error.
 func isRetryable(code codes.Code) bool {
 	switch code {
-	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted:
 		return true
 	default:
 		return false
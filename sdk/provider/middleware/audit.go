@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// EnvAuditLevel selects the provider's audit level: "off", "mutations" or
+// "all". Unset means "mutations".
+const EnvAuditLevel = "VIRTRIGAUD_PROVIDER_AUDIT"
+
+// AuditLogger is the value of the "logger" attribute on every audit record,
+// so that audit records can be routed away from the operational log.
+const AuditLogger = "audit"
+
+// AuditLevel selects which RPCs are audited.
+type AuditLevel string
+
+const (
+	// AuditOff audits nothing.
+	AuditOff AuditLevel = "off"
+	// AuditMutations audits the RPCs that change hypervisor state.
+	AuditMutations AuditLevel = "mutations"
+	// AuditAll audits every RPC.
+	AuditAll AuditLevel = "all"
+)
+
+// ParseAuditLevel parses an audit level. The empty string is AuditMutations.
+func ParseAuditLevel(s string) (AuditLevel, error) {
+	switch level := AuditLevel(strings.ToLower(strings.TrimSpace(s))); level {
+	case "":
+		return AuditMutations, nil
+	case AuditOff, AuditMutations, AuditAll:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid audit level %q: want off, mutations or all", s)
+	}
+}
+
+// Audits reports whether an RPC to method, a short or full method name, is
+// audited at this level.
+func (l AuditLevel) Audits(method string) bool {
+	switch l {
+	case AuditAll:
+		return true
+	case AuditMutations:
+		return IsMutatingMethod(method)
+	default:
+		return false
+	}
+}
+
+// mutatingMethods are the provider RPCs that change hypervisor state.
+// ConsoleTicket counts among them: a console hands out the same control
+// over the guest.
+var mutatingMethods = map[string]bool{
+	"Create":          true,
+	"Delete":          true,
+	"Power":           true,
+	"Reconfigure":     true,
+	"HardwareUpgrade": true,
+	"SnapshotCreate":  true,
+	"SnapshotDelete":  true,
+	"SnapshotRevert":  true,
+	"Clone":           true,
+	"ImagePrepare":    true,
+	"ExportDisk":      true,
+	"ImportDisk":      true,
+	"ConsoleTicket":   true,
+	"PublishImage":    true,
+}
+
+// IsMutatingMethod reports whether method, a short name such as "Create" or
+// a full gRPC method path, changes hypervisor state.
+func IsMutatingMethod(method string) bool {
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		method = method[i+1:]
+	}
+	return mutatingMethods[method]
+}
+
+// RedactPayload renders payload as JSON with the fields of the built-in
+// deny-list, such as user data, passwords and credentials, redacted. It is
+// the form audit records carry request summaries in.
+func RedactPayload(payload interface{}) string {
+	return defaultRedactor.payloadJSON(payload)
+}
+
+var defaultRedactor = newRedactor(nil)
+
+// AuditConfig configures the audit interceptor. Each audited RPC produces
+// one record when it arrives and one when it completes, tagged with the
+// manager's correlation ID so they line up with the manager's own audit
+// records.
+type AuditConfig struct {
+	// Level selects the audited RPCs; empty means AuditMutations and
+	// AuditOff disables the interceptor.
+	Level AuditLevel
+
+	// Logger receives the records (uses slog.Default() if nil).
+	Logger *slog.Logger
+
+	// RedactFields extends the built-in deny-list.
+	RedactFields []string
+}
+
+// AuditConfigFromEnv reads EnvAuditLevel. It returns nil when auditing is
+// off.
+func AuditConfigFromEnv(logger *slog.Logger) (*AuditConfig, error) {
+	level, err := ParseAuditLevel(os.Getenv(EnvAuditLevel))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EnvAuditLevel, err)
+	}
+	if level == AuditOff {
+		return nil, nil
+	}
+	return &AuditConfig{Level: level, Logger: logger}, nil
+}
+
+// auditUnaryInterceptor writes audit records for unary RPCs. It runs ahead
+// of authentication so that rejected calls are recorded too.
+func auditUnaryInterceptor(config *AuditConfig) grpc.UnaryServerInterceptor {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With("logger", AuditLogger)
+	r := newRedactor(config.RedactFields)
+	level := config.Level
+	if level == "" {
+		level = AuditMutations
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !level.Audits(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		attrs := []any{
+			"method", info.FullMethod,
+			"mutating", IsMutatingMethod(info.FullMethod),
+			"correlation_id", correlationIDFromContext(ctx),
+			"peer", peerIdentity(ctx),
+		}
+		logger.Info("provider RPC received", append(attrs, "stage", "request", "request", r.payloadJSON(req))...)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		attrs = append(attrs,
+			"stage", "response",
+			"outcome", status.Code(err).String(),
+			"duration", time.Since(start),
+		)
+		if err != nil {
+			attrs = append(attrs, "error", status.Convert(err).Message())
+		}
+		logger.Info("provider RPC completed", attrs...)
+
+		return resp, err
+	}
+}
+
+// peerIdentity names the caller: the first URI or DNS SAN, else the CN, of
+// a verified client certificate, else the remote address.
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok &&
+		len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+		leaf := tlsInfo.State.VerifiedChains[0][0]
+		switch {
+		case len(leaf.URIs) > 0:
+			return leaf.URIs[0].String()
+		case len(leaf.DNSNames) > 0:
+			return leaf.DNSNames[0]
+		case leaf.Subject.CommonName != "":
+			return leaf.Subject.CommonName
+		}
+	}
+	if p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
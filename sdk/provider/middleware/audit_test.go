@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestAuditUnaryInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := auditUnaryInterceptor(&AuditConfig{Logger: slog.New(slog.NewJSONHandler(&buf, nil))})
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(CorrelationIDMetadataKey, "vm-default/web"))
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.ResourceExhausted, "datastore full")
+	}
+
+	// A read is not audited at the default level.
+	_, _ = interceptor(ctx, &providerv1.DescribeRequest{Id: "vm-1"},
+		&grpc.UnaryServerInfo{FullMethod: "/provider.v1.Provider/Describe"}, failing)
+	if buf.Len() != 0 {
+		t.Fatalf("Describe was audited at the mutations level: %s", buf.String())
+	}
+
+	req := &providerv1.CreateRequest{
+		Name:      "web",
+		UserData:  []byte(secretUserData),
+		ImageJson: `{"TemplateName":"ubuntu","Credentials":{"Password":"` + secretPassword + `"}}`,
+	}
+	_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/provider.v1.Provider/Create"}, failing)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("the handler's error must pass through, got %v", err)
+	}
+
+	out := buf.String()
+	assertNoSecrets(t, out)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a request and a response record, got:\n%s", out)
+	}
+	for _, want := range []string{
+		`"logger":"audit"`, `"stage":"request"`, `"correlation_id":"vm-default/web"`, `"mutating":true`,
+		`\"name\":\"web\"`, `\"TemplateName\":\"ubuntu\"`,
+	} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("expected %s in request record:\n%s", want, lines[0])
+		}
+	}
+	for _, want := range []string{`"stage":"response"`, `"outcome":"ResourceExhausted"`, `"error":"datastore full"`} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("expected %s in response record:\n%s", want, lines[1])
+		}
+	}
+}
+
+func TestAuditLevel(t *testing.T) {
+	tests := []struct {
+		level   AuditLevel
+		method  string
+		audited bool
+	}{
+		{AuditOff, "/provider.v1.Provider/Create", false},
+		{AuditMutations, "/provider.v1.Provider/Create", true},
+		{AuditMutations, "/provider.v1.Provider/SnapshotRevert", true},
+		{AuditMutations, "/provider.v1.Provider/Describe", false},
+		{AuditAll, "/provider.v1.Provider/Describe", true},
+		{AuditMutations, "ImportDisk", true},
+	}
+	for _, tt := range tests {
+		if got := tt.level.Audits(tt.method); got != tt.audited {
+			t.Errorf("%s.Audits(%s) = %v, want %v", tt.level, tt.method, got, tt.audited)
+		}
+	}
+
+	for value, want := range map[string]AuditLevel{"": AuditMutations, "ALL": AuditAll, "off": AuditOff} {
+		if got, err := ParseAuditLevel(value); err != nil || got != want {
+			t.Errorf("ParseAuditLevel(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseAuditLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
@@ -31,8 +31,6 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
-
-	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
 )
 
 // Config holds middleware configuration.
@@ -54,6 +52,12 @@ type Config struct {
 
 	// Metrics configuration
 	Metrics *MetricsConfig
+
+	// DebugSample configures opt-in, redacted payload sampling
+	DebugSample *DebugSampleConfig
+
+	// Audit configures the audit record of provider RPCs
+	Audit *AuditConfig
 }
 
 // LoggingConfig configures request/response logging.
@@ -64,7 +68,8 @@ type LoggingConfig struct {
 	// Logger instance (uses slog.Default() if nil)
 	Logger *slog.Logger
 
-	// LogPayloads enables logging of request/response payloads
+	// LogPayloads enables logging of request/response payloads, with the
+	// same field redaction as DebugSampleConfig
 	LogPayloads bool
 
 	// SlowThreshold logs requests slower than this duration
@@ -140,6 +145,11 @@ func Build(config *Config) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerIn
 		streamInterceptors = append(streamInterceptors, recoveryStreamInterceptor(config.Recovery))
 	}
 
+	// Audit (ahead of authentication, so rejected calls are recorded)
+	if config.Audit != nil && config.Audit.Level != AuditOff {
+		unaryInterceptors = append(unaryInterceptors, auditUnaryInterceptor(config.Audit))
+	}
+
 	// Authentication
 	if config.Auth != nil && (config.Auth.RequireTLS || config.Auth.BearerTokenAuth) {
 		unaryInterceptors = append(unaryInterceptors, authUnaryInterceptor(config.Auth))
@@ -164,6 +174,12 @@ func Build(config *Config) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerIn
 		streamInterceptors = append(streamInterceptors, metricsStreamInterceptor(config.Metrics))
 	}
 
+	// Debug payload sampling (after metrics so samples carry the final
+	// result code)
+	if config.DebugSample != nil && config.DebugSample.Rate > 0 {
+		unaryInterceptors = append(unaryInterceptors, debugSampleUnaryInterceptor(config.DebugSample))
+	}
+
 	// Logging (should be last to log the final result)
 	if config.Logging != nil && config.Logging.Enabled {
 		unaryInterceptors = append(unaryInterceptors, loggingUnaryInterceptor(config.Logging))
@@ -245,8 +261,9 @@ func authStreamInterceptor(config *AuthConfig) grpc.StreamServerInterceptor {
 //
 // Errors returned from validateTLSPeer are already gRPC status errors carrying
 // the right code (Unauthenticated vs PermissionDenied) — pass them through so
-// callers can distinguish missing-cert from rejected-cert. Bearer-token failures
-// keep the existing PermissionDenied wrapping.
+// callers can distinguish missing-cert from rejected-cert. A missing or
+// unaccepted bearer token is Unauthenticated: the caller has not proven who
+// it is.
 func authenticateRequest(ctx context.Context, config *AuthConfig) error {
 	// Check mTLS if required
 	if config.RequireTLS {
@@ -258,7 +275,12 @@ func authenticateRequest(ctx context.Context, config *AuthConfig) error {
 	// Check bearer token if required
 	if config.BearerTokenAuth {
 		if err := validateBearerToken(ctx, config.ValidateToken); err != nil {
-			return errors.NewPermissionDenied("token authentication failed").GRPCStatus().Err()
+			attrs := []any{"error", err}
+			if p, ok := peer.FromContext(ctx); ok {
+				attrs = append(attrs, "addr", p.Addr.String())
+			}
+			slog.Default().Warn("Token rejection", attrs...)
+			return status.Error(codes.Unauthenticated, "token authentication failed")
 		}
 	}
 
@@ -392,6 +414,9 @@ func validateBearerToken(ctx context.Context, validateFunc func(context.Context,
 		return fmt.Errorf("invalid authorization header format")
 	}
 
+	if validateFunc == nil {
+		return fmt.Errorf("no token validator configured")
+	}
 	return validateFunc(ctx, token[7:])
 }
 
@@ -488,12 +513,15 @@ func loggingUnaryInterceptor(config *LoggingConfig) grpc.UnaryServerInterceptor
 		logger = slog.Default()
 	}
 
+	r := newRedactor(nil)
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
+		logger := withCorrelationID(ctx, logger)
 
 		logger.Debug("gRPC request started",
 			"method", info.FullMethod,
-			"payload", getPayloadLog(req, config.LogPayloads),
+			"payload", getPayloadLog(r, req, config.LogPayloads),
 		)
 
 		resp, err := handler(ctx, req)
@@ -510,7 +538,7 @@ func loggingUnaryInterceptor(config *LoggingConfig) grpc.UnaryServerInterceptor
 			"method", info.FullMethod,
 			"duration", duration,
 			"error", err,
-			"response", getPayloadLog(resp, config.LogPayloads),
+			"response", getPayloadLog(r, resp, config.LogPayloads),
 		)
 
 		return resp, err
@@ -526,6 +554,7 @@ func loggingStreamInterceptor(config *LoggingConfig) grpc.StreamServerIntercepto
 
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		start := time.Now()
+		logger := withCorrelationID(ss.Context(), logger)
 
 		logger.Debug("gRPC stream started", "method", info.FullMethod)
 
@@ -547,10 +576,20 @@ func loggingStreamInterceptor(config *LoggingConfig) grpc.StreamServerIntercepto
 	}
 }
 
+// withCorrelationID returns logger with the correlation ID the manager
+// sent with the RPC, if any, so provider logs can be matched to the
+// reconcile that caused them.
+func withCorrelationID(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id := correlationIDFromContext(ctx); id != "" {
+		return logger.With("correlation_id", id)
+	}
+	return logger
+}
+
 // getPayloadLog returns a loggable representation of the payload.
-func getPayloadLog(payload interface{}, logPayloads bool) interface{} {
+func getPayloadLog(r *redactor, payload interface{}, logPayloads bool) interface{} {
 	if !logPayloads {
-		return "<redacted>"
+		return redactedValue
 	}
-	return payload
+	return r.payloadJSON(payload)
 }
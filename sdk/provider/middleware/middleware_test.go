@@ -17,6 +17,7 @@ limitations under the License.
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -24,14 +25,18 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"log/slog"
 	"math/big"
 	"net"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
@@ -263,3 +268,27 @@ func TestAuthenticateRequest_PropagatesPermissionDenied(t *testing.T) {
 		t.Errorf("expected PermissionDenied to propagate, got %s", got)
 	}
 }
+
+func TestLoggingUnaryInterceptor_CorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := loggingUnaryInterceptor(&LoggingConfig{Logger: slog.New(slog.NewJSONHandler(&buf, nil))})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/provider.v1.Provider/Describe"}
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(CorrelationIDMetadataKey, "vm-default/web"))
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"correlation_id":"vm-default/web"`) {
+		t.Errorf("expected the correlation ID in the log:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if _, err := interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "correlation_id") {
+		t.Errorf("expected no correlation ID without metadata:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// EnvDebugSampleRate enables payload sampling when set to a fraction in
+	// (0, 1]; "1" logs every RPC. Unset or "0" disables sampling.
+	EnvDebugSampleRate = "VIRTRIGAUD_PROVIDER_DEBUG_SAMPLE_RATE"
+
+	// CorrelationIDMetadataKey is the gRPC metadata key the manager uses to
+	// forward its correlation (or reconcile) ID with every provider RPC.
+	CorrelationIDMetadataKey = "x-virtrigaud-correlation-id"
+
+	// redactedValue replaces the value of every deny-listed field.
+	redactedValue = "<redacted>"
+)
+
+// defaultRedactFields is the built-in deny-list. Keys are matched
+// case-insensitively after stripping '_' and '-', and by substring, so
+// "sshKeys", "ssh_authorized_keys", "cipassword" and "tokenSecret" are all
+// caught. "unattend" covers Sysprep answer files, which carry the
+// administrator password.
+var defaultRedactFields = []string{
+	"userdata",
+	"vendordata",
+	"password",
+	"passwd",
+	"token",
+	"sshkey",
+	"authorizedkey",
+	"credential",
+	"secret",
+	"privatekey",
+	"unattend",
+}
+
+// DebugSampleConfig configures request/response payload sampling for
+// debugging provider contract mismatches. Sampled payloads are logged as
+// JSON with deny-listed fields redacted, including inside JSON-encoded
+// string fields such as CreateRequest.class_json.
+type DebugSampleConfig struct {
+	// Rate is the fraction of unary RPCs to sample, in (0, 1].
+	Rate float64
+
+	// Logger receives the samples (uses slog.Default() if nil).
+	Logger *slog.Logger
+
+	// RedactFields extends the built-in deny-list.
+	RedactFields []string
+}
+
+// DebugSampleConfigFromEnv reads EnvDebugSampleRate. It returns nil when
+// sampling is not enabled and an error when the value is not a number in
+// [0, 1].
+func DebugSampleConfigFromEnv(logger *slog.Logger) (*DebugSampleConfig, error) {
+	raw := strings.TrimSpace(os.Getenv(EnvDebugSampleRate))
+	if raw == "" {
+		return nil, nil
+	}
+	rate, err := strconv.ParseFloat(raw, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("%s=%q: want a fraction between 0 and 1", EnvDebugSampleRate, raw)
+	}
+	if rate == 0 {
+		return nil, nil
+	}
+	return &DebugSampleConfig{Rate: rate, Logger: logger}, nil
+}
+
+// sampleFloat is the sampling source; tests replace it.
+var sampleFloat = rand.Float64
+
+// debugSampleUnaryInterceptor logs a redacted copy of a fraction of unary
+// RPC payloads, tagged with the caller's correlation ID.
+func debugSampleUnaryInterceptor(config *DebugSampleConfig) grpc.UnaryServerInterceptor {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	r := newRedactor(config.RedactFields)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if sampleFloat() >= config.Rate {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		attrs := []any{
+			"method", info.FullMethod,
+			"correlation_id", correlationIDFromContext(ctx),
+			"duration", time.Since(start),
+			"code", status.Code(err).String(),
+			"request", r.payloadJSON(req),
+		}
+		if err == nil {
+			attrs = append(attrs, "response", r.payloadJSON(resp))
+		}
+		logger.Info("gRPC payload sample", attrs...)
+
+		return resp, err
+	}
+}
+
+// correlationIDFromContext returns the correlation ID forwarded by the
+// manager, or "" when the caller sent none.
+func correlationIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(CorrelationIDMetadataKey); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// redactor renders payloads as JSON with deny-listed fields replaced.
+type redactor struct {
+	deny []string
+}
+
+func newRedactor(extra []string) *redactor {
+	deny := append([]string(nil), defaultRedactFields...)
+	for _, f := range extra {
+		if f = normalizeFieldName(f); f != "" {
+			deny = append(deny, f)
+		}
+	}
+	return &redactor{deny: deny}
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+func (r *redactor) denied(key string) bool {
+	key = normalizeFieldName(key)
+	for _, d := range r.deny {
+		if strings.Contains(key, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// payloadJSON returns the redacted JSON form of a payload. A payload that
+// cannot be rendered is reported by type only, never in raw form.
+func (r *redactor) payloadJSON(payload interface{}) string {
+	if payload == nil {
+		return "null"
+	}
+	var raw []byte
+	var err error
+	if msg, ok := payload.(proto.Message); ok {
+		raw, err = protojson.Marshal(msg)
+	} else {
+		raw, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return fmt.Sprintf("<unrenderable %T>", payload)
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Sprintf("<unrenderable %T>", payload)
+	}
+	var out strings.Builder
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(r.redact(v)); err != nil {
+		return fmt.Sprintf("<unrenderable %T>", payload)
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// redact walks a decoded JSON value. String values holding a JSON object
+// or array (the *_json contract fields) are decoded and walked as well, so
+// a UserData buried in desired_json is redacted too.
+func (r *redactor) redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if r.denied(k) {
+				if !isEmptyJSON(val) {
+					t[k] = redactedValue
+				}
+				continue
+			}
+			t[k] = r.redact(val)
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = r.redact(t[i])
+		}
+		return t
+	case string:
+		trimmed := strings.TrimSpace(t)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var nested interface{}
+			if err := json.Unmarshal([]byte(trimmed), &nested); err == nil {
+				return r.redact(nested)
+			}
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// isEmptyJSON reports values that carry nothing worth hiding, so a sample
+// still shows that a deny-listed field was sent empty.
+func isEmptyJSON(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// Each secret is unique so a leak can be traced to the field that carried it.
+const (
+	secretUserData    = "#cloud-config\npassword: leaked-userdata"
+	secretNestedUser  = "nested-userdata-secret"
+	secretSSHKey      = "ssh-ed25519 AAAAleakedkey"
+	secretPassword    = "leaked-password"
+	secretToken       = "leaked-token"
+	secretCredentials = "leaked-credentials"
+	secretVendorData  = "#cloud-config\nchpasswd: leaked-vendordata"
+)
+
+var allSecrets = []string{
+	"leaked-userdata", secretNestedUser, "AAAAleakedkey", secretPassword, secretToken, secretCredentials,
+	"leaked-vendordata",
+}
+
+func assertNoSecrets(t *testing.T, out string) {
+	t.Helper()
+	for _, s := range allSecrets {
+		if strings.Contains(out, s) {
+			t.Errorf("output leaks %q:\n%s", s, out)
+		}
+	}
+}
+
+func TestRedactor_ProtoPayload(t *testing.T) {
+	req := &providerv1.CreateRequest{
+		Name:       "web-1",
+		UserData:   []byte(secretUserData),
+		VendorData: []byte(secretVendorData),
+		// The *_json fields are marshaled contracts with Go field names.
+		ClassJson: `{"CPU":2,"MemoryMiB":2048,"ExtraConfig":{"guestinfo.token":"` + secretToken + `"}}`,
+		ImageJson: `{"TemplateName":"ubuntu","Credentials":{"Password":"` + secretPassword + `"}}`,
+		NetworksJson: `[{"Name":"lan","SSH_Authorized_Keys":["` + secretSSHKey + `"],` +
+			`"Nested":"{\"UserData\":\"` + secretNestedUser + `\"}"}]`,
+		PlacementJson: `{"Host":"pve","credentialsRef":"` + secretCredentials + `"}`,
+	}
+
+	out := newRedactor(nil).payloadJSON(req)
+	assertNoSecrets(t, out)
+
+	// Non-sensitive fields stay visible, including inside the *_json
+	// fields, so the sample is still useful for contract debugging.
+	for _, want := range []string{`"name":"web-1"`, `"TemplateName":"ubuntu"`, `"MemoryMiB":2048`, `"Host":"pve"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in redacted output:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, `"userData":"<redacted>"`) {
+		t.Errorf("expected userData to be marked redacted:\n%s", out)
+	}
+}
+
+func TestRedactor_DesiredJSONAndExtraFields(t *testing.T) {
+	req := &providerv1.ReconfigureRequest{
+		Id:          "vm-1",
+		DesiredJson: `{"Name":"vm-1","UserData":{"CloudInitData":"` + secretNestedUser + `"},"Tags":["a"],"apiKey":"k-123"}`,
+	}
+
+	out := newRedactor([]string{"api_key"}).payloadJSON(req)
+	assertNoSecrets(t, out)
+	if strings.Contains(out, "k-123") {
+		t.Errorf("extra redact field was not applied:\n%s", out)
+	}
+	if !strings.Contains(out, `"Tags":["a"]`) {
+		t.Errorf("expected non-sensitive fields to survive:\n%s", out)
+	}
+}
+
+func TestRedactor_SysprepUnattendXML(t *testing.T) {
+	req := &providerv1.CreateRequest{
+		Name: "win-1",
+		GuestCustomizationJson: `{"type":"Sysprep","sysprep":{"unattendXML":` +
+			`"<unattend><AdministratorPassword><Value>leaked-admin</Value></AdministratorPassword></unattend>"}}`,
+	}
+
+	out := newRedactor(nil).payloadJSON(req)
+	if strings.Contains(out, "leaked-admin") {
+		t.Errorf("output leaks the Sysprep administrator password:\n%s", out)
+	}
+	if !strings.Contains(out, `"unattendXML":"<redacted>"`) {
+		t.Errorf("expected unattendXML to be marked redacted:\n%s", out)
+	}
+	if !strings.Contains(out, `"type":"Sysprep"`) {
+		t.Errorf("expected the customization type to survive:\n%s", out)
+	}
+}
+
+func TestRedactor_EmptySensitiveFieldsStayVisible(t *testing.T) {
+	out := newRedactor(nil).payloadJSON(map[string]interface{}{"password": "", "name": "x"})
+	if out != `{"name":"x","password":""}` {
+		t.Errorf("unexpected output %s", out)
+	}
+}
+
+func TestDebugSampleUnaryInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	orig := sampleFloat
+	t.Cleanup(func() { sampleFloat = orig })
+
+	interceptor := debugSampleUnaryInterceptor(&DebugSampleConfig{Rate: 0.5, Logger: logger})
+	info := &grpc.UnaryServerInfo{FullMethod: "/provider.v1.Provider/Create"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &providerv1.CreateResponse{Id: "vm-42"}, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(CorrelationIDMetadataKey, "vmclone-default/web"))
+	req := &providerv1.CreateRequest{Name: "web", UserData: []byte(secretUserData)}
+
+	// Above the rate: not sampled.
+	sampleFloat = func() float64 { return 0.9 }
+	if _, err := interceptor(ctx, req, info, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("unsampled RPC was logged: %s", buf.String())
+	}
+
+	// Below the rate: sampled with correlation ID, redacted request and response.
+	sampleFloat = func() float64 { return 0.1 }
+	if _, err := interceptor(ctx, req, info, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	out := buf.String()
+	assertNoSecrets(t, out)
+	for _, want := range []string{`"correlation_id":"vmclone-default/web"`, `vm-42`, `/provider.v1.Provider/Create`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in sample:\n%s", want, out)
+		}
+	}
+}
+
+func TestDebugSampleConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantRate float64 // 0 means nil config
+		wantErr  bool
+	}{
+		{value: ""},
+		{value: "0"},
+		{value: "0.25", wantRate: 0.25},
+		{value: "1", wantRate: 1},
+		{value: "1.5", wantErr: true},
+		{value: "-0.1", wantErr: true},
+		{value: "often", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(EnvDebugSampleRate, tt.value)
+			cfg, err := DebugSampleConfigFromEnv(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantRate == 0 && cfg != nil:
+				t.Fatalf("expected no config, got %+v", cfg)
+			case tt.wantRate != 0 && (cfg == nil || cfg.Rate != tt.wantRate):
+				t.Fatalf("config = %+v, want rate %v", cfg, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestGetPayloadLog_RedactsWhenEnabled(t *testing.T) {
+	req := &providerv1.CreateRequest{Name: "web", UserData: []byte(secretUserData)}
+	out, _ := getPayloadLog(newRedactor(nil), req, true).(string)
+	assertNoSecrets(t, out)
+	if !strings.Contains(out, `"name":"web"`) {
+		t.Errorf("expected payload to be rendered: %s", out)
+	}
+}
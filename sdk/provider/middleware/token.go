@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileTokenValidator accepts the bearer tokens stored in files, typically a
+// mounted Kubernetes Secret. The files are re-read whenever they change, so
+// a rotated Secret takes effect without a restart. Listing both the current
+// and the previous token lets callers still holding the previous one through
+// a rotation grace window.
+type FileTokenValidator struct {
+	paths []string
+
+	mu     sync.Mutex
+	mtimes []time.Time
+	tokens [][]byte
+}
+
+// NewFileTokenValidator returns a validator for the tokens in paths. A
+// missing or empty file contributes no token; at least one must be present
+// for any call to succeed.
+func NewFileTokenValidator(paths ...string) *FileTokenValidator {
+	return &FileTokenValidator{paths: paths}
+}
+
+// Validate reports whether token matches one of the accepted tokens. It has
+// the signature AuthConfig.ValidateToken expects.
+func (v *FileTokenValidator) Validate(_ context.Context, token string) error {
+	accepted := v.load()
+	if len(accepted) == 0 {
+		return fmt.Errorf("no accepted token configured")
+	}
+	match := 0
+	for _, want := range accepted {
+		// Compare against every entry so timing does not reveal which one
+		// matched.
+		match |= subtle.ConstantTimeCompare([]byte(token), want)
+	}
+	if match != 1 {
+		return fmt.Errorf("token not accepted")
+	}
+	return nil
+}
+
+// load returns the current tokens, re-reading the files when any of their
+// modification times changed.
+func (v *FileTokenValidator) load() [][]byte {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	mtimes := make([]time.Time, len(v.paths))
+	for i, p := range v.paths {
+		if info, err := os.Stat(filepath.Clean(p)); err == nil {
+			mtimes[i] = info.ModTime()
+		}
+	}
+	if v.tokens != nil && equalTimes(mtimes, v.mtimes) {
+		return v.tokens
+	}
+
+	tokens := [][]byte{}
+	for i, p := range v.paths {
+		if mtimes[i].IsZero() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			continue
+		}
+		if token := strings.TrimSpace(string(data)); token != "" {
+			tokens = append(tokens, []byte(token))
+		}
+	}
+	v.mtimes = mtimes
+	v.tokens = tokens
+	return tokens
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
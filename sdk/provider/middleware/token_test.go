@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func writeToken(t *testing.T, path, token string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	// Pin the mtime so a rewrite within the filesystem's timestamp
+	// granularity is still seen as a change.
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("chtimes %s: %v", path, err)
+	}
+}
+
+func TestFileTokenValidator_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "token")
+	previous := filepath.Join(dir, "previous-token")
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+
+	v := NewFileTokenValidator(current, previous)
+	if err := v.Validate(ctx, "anything"); err == nil {
+		t.Fatal("expected rejection with no token files")
+	}
+
+	writeToken(t, current, "old", start)
+	if err := v.Validate(ctx, "old"); err != nil {
+		t.Fatalf("current token rejected: %v", err)
+	}
+	if err := v.Validate(ctx, "new"); err == nil {
+		t.Fatal("expected unknown token to be rejected")
+	}
+
+	// Rotation: the old token moves to previous-token and stays valid.
+	writeToken(t, current, "new", start.Add(time.Minute))
+	writeToken(t, previous, "old", start.Add(time.Minute))
+	for _, token := range []string{"new", "old"} {
+		if err := v.Validate(ctx, token); err != nil {
+			t.Errorf("token %q rejected during grace window: %v", token, err)
+		}
+	}
+
+	// End of the grace window: previous-token is emptied.
+	writeToken(t, previous, "", start.Add(2*time.Minute))
+	if err := v.Validate(ctx, "old"); err == nil {
+		t.Error("expected previous token to be rejected after the grace window")
+	}
+	if err := v.Validate(ctx, ""); err == nil {
+		t.Error("expected empty token to be rejected")
+	}
+}
+
+func TestAuthenticateRequest_BearerToken(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "token")
+	writeToken(t, current, "s3cret", time.Now())
+	cfg := &AuthConfig{
+		BearerTokenAuth: true,
+		ValidateToken:   NewFileTokenValidator(current).Validate,
+	}
+
+	withAuth := func(value string) context.Context {
+		return metadata.NewIncomingContext(ctxWithPlaintextPeer(), metadata.Pairs("authorization", value))
+	}
+
+	if err := authenticateRequest(withAuth("Bearer s3cret"), cfg); err != nil {
+		t.Errorf("expected valid token to pass, got %v", err)
+	}
+	for name, ctx := range map[string]context.Context{
+		"no metadata": ctxWithPlaintextPeer(),
+		"wrong token": withAuth("Bearer guess"),
+		"not bearer":  withAuth("Basic s3cret"),
+	} {
+		err := authenticateRequest(ctx, cfg)
+		if got := status.Code(err); got != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %s (%v)", name, got, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// describeBatchFallback answers DescribeBatch for a provider that leaves it
+// unimplemented by calling the provider's Describe once per id. Providers
+// registered through RegisterProvider therefore always serve DescribeBatch;
+// the ones whose hypervisor can answer for many VMs at once override it.
+type describeBatchFallback struct {
+	providerv1.ProviderServer
+}
+
+// DescribeBatch implements providerv1.ProviderServer.
+func (f describeBatchFallback) DescribeBatch(ctx context.Context, req *providerv1.DescribeBatchRequest) (*providerv1.DescribeBatchResponse, error) {
+	resp, err := f.ProviderServer.DescribeBatch(ctx, req)
+	if status.Code(err) != codes.Unimplemented {
+		return resp, err
+	}
+	return describeEach(ctx, f.ProviderServer, req.GetIds()), nil
+}
+
+// describeEach describes ids one at a time. An id whose Describe fails is
+// reported in the response's errors rather than failing the whole batch.
+func describeEach(ctx context.Context, srv providerv1.ProviderServer, ids []string) *providerv1.DescribeBatchResponse {
+	resp := &providerv1.DescribeBatchResponse{
+		Results: make(map[string]*providerv1.DescribeResponse, len(ids)),
+	}
+	for _, id := range ids {
+		if _, done := resp.Results[id]; done {
+			continue
+		}
+		desc, err := srv.Describe(ctx, &providerv1.DescribeRequest{Id: id})
+		if err != nil {
+			if resp.Errors == nil {
+				resp.Errors = map[string]string{}
+			}
+			resp.Errors[id] = err.Error()
+			continue
+		}
+		resp.Results[id] = desc
+	}
+	return resp
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// unaryOnlyProvider implements Describe but not DescribeBatch.
+type unaryOnlyProvider struct {
+	providerv1.UnimplementedProviderServer
+	calls int
+}
+
+func (p *unaryOnlyProvider) Describe(_ context.Context, req *providerv1.DescribeRequest) (*providerv1.DescribeResponse, error) {
+	p.calls++
+	switch req.Id {
+	case "broken":
+		return nil, errors.New("hypervisor unreachable")
+	case "gone":
+		return &providerv1.DescribeResponse{Exists: false}, nil
+	}
+	return &providerv1.DescribeResponse{Exists: true, PowerState: "On"}, nil
+}
+
+// batchProvider implements DescribeBatch natively.
+type batchProvider struct {
+	unaryOnlyProvider
+}
+
+func (p *batchProvider) DescribeBatch(_ context.Context, req *providerv1.DescribeBatchRequest) (*providerv1.DescribeBatchResponse, error) {
+	resp := &providerv1.DescribeBatchResponse{Results: map[string]*providerv1.DescribeResponse{}}
+	for _, id := range req.Ids {
+		resp.Results[id] = &providerv1.DescribeResponse{Exists: true, PowerState: "Off"}
+	}
+	return resp, nil
+}
+
+func TestDescribeBatchFallbackCallsDescribePerID(t *testing.T) {
+	provider := &unaryOnlyProvider{}
+	srv := describeBatchFallback{ProviderServer: provider}
+
+	resp, err := srv.DescribeBatch(context.Background(), &providerv1.DescribeBatchRequest{
+		Ids: []string{"vm-1", "gone", "broken", "vm-1"},
+	})
+	if err != nil {
+		t.Fatalf("DescribeBatch: %v", err)
+	}
+	if provider.calls != 3 {
+		t.Errorf("Describe called %d times, want 3 (duplicate ids are described once)", provider.calls)
+	}
+	if got := resp.Results["vm-1"]; got == nil || !got.Exists || got.PowerState != "On" {
+		t.Errorf("vm-1 = %v, want an existing VM that is On", got)
+	}
+	if got := resp.Results["gone"]; got == nil || got.Exists {
+		t.Errorf("gone = %v, want exists=false", got)
+	}
+	if _, ok := resp.Results["broken"]; ok {
+		t.Error("broken should not have a result")
+	}
+	if resp.Errors["broken"] != "hypervisor unreachable" {
+		t.Errorf("errors[broken] = %q, want the Describe error", resp.Errors["broken"])
+	}
+}
+
+func TestDescribeBatchFallbackPrefersNativeImplementation(t *testing.T) {
+	provider := &batchProvider{}
+	srv := describeBatchFallback{ProviderServer: provider}
+
+	resp, err := srv.DescribeBatch(context.Background(), &providerv1.DescribeBatchRequest{Ids: []string{"vm-1"}})
+	if err != nil {
+		t.Fatalf("DescribeBatch: %v", err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("Describe called %d times, want 0", provider.calls)
+	}
+	if got := resp.Results["vm-1"]; got == nil || got.PowerState != "Off" {
+		t.Errorf("vm-1 = %v, want the native answer", got)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/capabilities"
+)
+
+// protocolVersionStamp reports the protocol versions this SDK was built with
+// on every GetCapabilities answer that does not report its own, so a
+// provider rebuilt against a newer SDK advertises the newer protocol
+// without code changes.
+type protocolVersionStamp struct {
+	providerv1.ProviderServer
+}
+
+// GetCapabilities implements providerv1.ProviderServer.
+func (p protocolVersionStamp) GetCapabilities(ctx context.Context, req *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
+	resp, err := p.ProviderServer.GetCapabilities(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	capabilities.StampProtocolVersions(resp)
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// capsProvider answers GetCapabilities with resp.
+type capsProvider struct {
+	providerv1.UnimplementedProviderServer
+	resp *providerv1.GetCapabilitiesResponse
+}
+
+func (p *capsProvider) GetCapabilities(context.Context, *providerv1.GetCapabilitiesRequest) (*providerv1.GetCapabilitiesResponse, error) {
+	return p.resp, nil
+}
+
+func TestProtocolVersionStampFillsUnsetVersions(t *testing.T) {
+	srv := protocolVersionStamp{ProviderServer: &capsProvider{resp: &providerv1.GetCapabilitiesResponse{SupportsSnapshots: true}}}
+
+	resp, err := srv.GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if resp.MinProtocolVersion != providerv1.ProtocolVersion_PROTOCOL_VERSION_1 {
+		t.Errorf("MinProtocolVersion = %v, want PROTOCOL_VERSION_1", resp.MinProtocolVersion)
+	}
+	if resp.MaxProtocolVersion != providerv1.ProtocolVersion_PROTOCOL_VERSION_CURRENT {
+		t.Errorf("MaxProtocolVersion = %v, want PROTOCOL_VERSION_CURRENT", resp.MaxProtocolVersion)
+	}
+	if !resp.SupportsSnapshots {
+		t.Error("the provider's own answer must be kept")
+	}
+}
+
+func TestProtocolVersionStampKeepsReportedVersions(t *testing.T) {
+	srv := protocolVersionStamp{ProviderServer: &capsProvider{resp: &providerv1.GetCapabilitiesResponse{
+		MinProtocolVersion: providerv1.ProtocolVersion_PROTOCOL_VERSION_1,
+		MaxProtocolVersion: providerv1.ProtocolVersion_PROTOCOL_VERSION_1,
+	}}}
+
+	resp, err := srv.GetCapabilities(context.Background(), &providerv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if resp.MaxProtocolVersion != providerv1.ProtocolVersion_PROTOCOL_VERSION_1 {
+		t.Errorf("MaxProtocolVersion = %v, want the provider's PROTOCOL_VERSION_1", resp.MaxProtocolVersion)
+	}
+}
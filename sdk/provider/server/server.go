@@ -42,6 +42,7 @@ import (
 	"github.com/projectbeskar/virtrigaud/internal/version"
 	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
 	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
 )
 
 // Config holds server configuration options.
@@ -106,6 +107,12 @@ type TLSConfig struct {
 	// in ADR-0003 PR-2: the cert/key are read once via
 	// tls.LoadX509KeyPair and never refreshed.
 	AutoReload bool
+
+	// SPIFFE, when non-nil, sources the server identity and client trust
+	// from the SPIFFE Workload API instead of files. CertFile, KeyFile,
+	// CAFile and AutoReload are ignored; client certificates are always
+	// required in this mode.
+	SPIFFE *SPIFFEConfig
 }
 
 // KeepAliveConfig holds keep-alive settings.
@@ -156,6 +163,10 @@ type Server struct {
 	// done, giving the goroutine a clean cancellation story (no bare
 	// go func without ctx — per project rules).
 	certWatcher *certwatcher.CertWatcher
+
+	// spiffeSource is non-nil only in SPIFFE mode. It streams SVID
+	// rotations in the background and is closed on shutdown.
+	spiffeSource *spiffe.X509Source
 }
 
 // New creates a new provider server with the given configuration.
@@ -201,7 +212,15 @@ func New(config *Config) (*Server, error) {
 	// rotated leaf cert on the next handshake. The watcher is retained on
 	// the Server so Serve can run its Start loop under the serve context.
 	var certWatcher *certwatcher.CertWatcher
-	if config.TLS != nil {
+	var spiffeSource *spiffe.X509Source
+	if config.TLS != nil && config.TLS.SPIFFE != nil {
+		creds, src, err := buildSPIFFECredentials(config.TLS.SPIFFE, config.Logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build SPIFFE credentials: %w", err)
+		}
+		spiffeSource = src
+		opts = append(opts, grpc.Creds(creds))
+	} else if config.TLS != nil {
 		creds, watcher, err := buildTLSCredentials(config.TLS)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build TLS credentials: %w", err)
@@ -252,6 +271,7 @@ func New(config *Config) (*Server, error) {
 		httpServer:    httpServer,
 		logger:        config.Logger,
 		certWatcher:   certWatcher,
+		spiffeSource:  spiffeSource,
 	}, nil
 }
 
@@ -261,7 +281,13 @@ func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
 }
 
 // RegisterProvider is a convenience method to register a provider service.
+// A provider that does not implement DescribeBatch has it answered by
+// calling its Describe once per id, and GetCapabilities answers carry the
+// SDK's protocol versions unless the provider reports its own.
 func (s *Server) RegisterProvider(service interface{}) {
+	if provider, ok := service.(providerv1.ProviderServer); ok {
+		service = protocolVersionStamp{ProviderServer: describeBatchFallback{ProviderServer: provider}}
+	}
 	// Register the provider service using the generated service descriptor
 	s.grpcServer.RegisterService(&providerv1.Provider_ServiceDesc, service)
 }
@@ -386,6 +412,13 @@ func (s *Server) shutdown() error {
 		s.grpcServer.GracefulStop()
 		close(stopped)
 	}()
+	if s.spiffeSource != nil {
+		defer func() {
+			if err := s.spiffeSource.Close(); err != nil {
+				s.logger.Warn("SPIFFE workload API source close error", "error", err)
+			}
+		}()
+	}
 
 	select {
 	case <-stopped:
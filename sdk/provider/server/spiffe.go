@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
+)
+
+// defaultSPIFFEFetchTimeout bounds how long New waits for the first SVID.
+const defaultSPIFFEFetchTimeout = 30 * time.Second
+
+// SPIFFEConfig configures workload-identity mTLS. The server SVID and trust
+// bundle come from the SPIFFE Workload API and rotate without a restart;
+// no cert files are read.
+type SPIFFEConfig struct {
+	// WorkloadAPIAddr is the Workload API endpoint, e.g.
+	// "unix:///spiffe-workload-api/spire-agent.sock". Empty uses
+	// SPIFFE_ENDPOINT_SOCKET, then spiffe.DefaultEndpointSocket.
+	WorkloadAPIAddr string
+
+	// TrustDomain is the trust domain the client (manager) SVID must
+	// belong to. Required.
+	TrustDomain string
+
+	// AllowedIDs optionally restricts clients to these SPIFFE IDs.
+	AllowedIDs []string
+
+	// InitialFetchTimeout bounds how long New blocks waiting for the
+	// first SVID (default: 30s).
+	InitialFetchTimeout time.Duration
+}
+
+func (c *SPIFFEConfig) authorizer() spiffe.Authorizer {
+	return spiffe.Authorizer{TrustDomain: c.TrustDomain, IDs: c.AllowedIDs}
+}
+
+// buildSPIFFECredentials connects to the Workload API and returns server
+// credentials backed by the resulting X509Source. The caller owns the
+// source and must Close it on shutdown.
+func buildSPIFFECredentials(cfg *SPIFFEConfig, logger *slog.Logger) (credentials.TransportCredentials, *spiffe.X509Source, error) {
+	authz := cfg.authorizer()
+	if err := authz.Validate(); err != nil {
+		return nil, nil, err
+	}
+	timeout := cfg.InitialFetchTimeout
+	if timeout == 0 {
+		timeout = defaultSPIFFEFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	src, err := spiffe.NewX509Source(ctx, cfg.WorkloadAPIAddr, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to obtain SVID: %w", err)
+	}
+	return credentials.NewTLS(spiffe.ServerTLSConfig(src, authz)), src, nil
+}
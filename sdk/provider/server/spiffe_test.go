@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe/spiffetest"
+)
+
+const (
+	testManagerSPIFFEID  = "spiffe://example.org/ns/virtrigaud-system/sa/manager"
+	testProviderSPIFFEID = "spiffe://example.org/ns/virtrigaud-system/sa/provider"
+)
+
+func TestResolveTLSAndAuth_SPIFFEMode(t *testing.T) {
+	t.Setenv(EnvTLSMode, "spiffe")
+	t.Setenv(EnvSPIFFETrustDomain, "example.org")
+	t.Setenv(EnvSPIFFEAllowedIDs, " "+testManagerSPIFFEID+" ,")
+	// Neither the mounted-file nor the opt-out path may influence SPIFFE mode.
+	t.Setenv(EnvInsecure, "true")
+
+	resolution, err := ResolveTLSAndAuth()
+	if err != nil {
+		t.Fatalf("ResolveTLSAndAuth: %v", err)
+	}
+	if resolution.Insecure {
+		t.Error("expected Insecure=false in SPIFFE mode")
+	}
+	if resolution.TLS == nil || resolution.TLS.SPIFFE == nil {
+		t.Fatalf("expected TLS.SPIFFE to be set, got %+v", resolution.TLS)
+	}
+	if !resolution.TLS.RequireClientCert {
+		t.Error("expected RequireClientCert=true")
+	}
+	if got := resolution.TLS.SPIFFE.TrustDomain; got != "example.org" {
+		t.Errorf("TrustDomain = %q, want example.org", got)
+	}
+	want := []string{testManagerSPIFFEID}
+	if !reflect.DeepEqual(resolution.TLS.SPIFFE.AllowedIDs, want) {
+		t.Errorf("AllowedIDs = %v, want %v", resolution.TLS.SPIFFE.AllowedIDs, want)
+	}
+	if !resolution.Auth.RequireTLS || !reflect.DeepEqual(resolution.Auth.AllowedSANs, want) {
+		t.Errorf("Auth = %+v, want RequireTLS with AllowedSANs %v", resolution.Auth, want)
+	}
+}
+
+func TestResolveTLSAndAuth_SPIFFEModeErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		mode        string
+		trustDomain string
+		allowedIDs  string
+	}{
+		{"missing trust domain", "spiffe", "", ""},
+		{"allowed ID outside trust domain", "spiffe", "example.org", "spiffe://other.org/manager"},
+		{"unknown mode", "vault", "example.org", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvTLSMode, tc.mode)
+			t.Setenv(EnvSPIFFETrustDomain, tc.trustDomain)
+			t.Setenv(EnvSPIFFEAllowedIDs, tc.allowedIDs)
+
+			if resolution, err := ResolveTLSAndAuth(); err == nil {
+				t.Fatalf("expected error, got resolution %+v", resolution)
+			}
+		})
+	}
+}
+
+// TestNew_SPIFFEMode serves the gRPC health service with credentials from
+// one in-memory Workload API and dials it with the manager-side SPIFFE
+// client config backed by another, end to end through the auth middleware.
+func TestNew_SPIFFEMode(t *testing.T) {
+	ca := spiffetest.NewCA(t, "example.org")
+	providerAPI := spiffetest.NewWorkloadAPI(t)
+	providerAPI.SetSVID(t, ca, testProviderSPIFFEID)
+
+	cfg := DefaultConfig()
+	cfg.TLS = &TLSConfig{
+		RequireClientCert: true,
+		SPIFFE: &SPIFFEConfig{
+			WorkloadAPIAddr:     providerAPI.Addr,
+			TrustDomain:         "example.org",
+			AllowedIDs:          []string{testManagerSPIFFEID},
+			InitialFetchTimeout: 10 * time.Second,
+		},
+	}
+	cfg.Middleware = &middleware.Config{
+		Auth: &middleware.AuthConfig{RequireTLS: true, AllowedSANs: []string{testManagerSPIFFEID}},
+	}
+	srv, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if srv.spiffeSource == nil {
+		t.Fatal("expected a SPIFFE source in SPIFFE mode")
+	}
+	t.Cleanup(func() { _ = srv.spiffeSource.Close() })
+
+	grpc_health_v1.RegisterHealthServer(srv.grpcServer, srv.healthServer)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() { _ = srv.grpcServer.Serve(lis) }()
+	t.Cleanup(srv.grpcServer.Stop)
+
+	check := func(clientID string) error {
+		api := spiffetest.NewWorkloadAPI(t)
+		api.SetSVID(t, ca, clientID)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		src, err := spiffe.NewX509Source(ctx, api.Addr, nil)
+		if err != nil {
+			t.Fatalf("client source: %v", err)
+		}
+		defer func() { _ = src.Close() }()
+
+		tlsCfg := spiffe.ClientTLSConfig(src, spiffe.Authorizer{
+			TrustDomain: "example.org",
+			IDs:         []string{testProviderSPIFFEID},
+		})
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		defer func() { _ = conn.Close() }()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return err
+	}
+
+	if err := check(testManagerSPIFFEID); err != nil {
+		t.Fatalf("health check with allowed manager SVID: %v", err)
+	}
+	if err := check("spiffe://example.org/ns/default/sa/intruder"); err == nil {
+		t.Fatal("health check with a non-allowlisted SVID succeeded")
+	}
+}
@@ -46,6 +46,22 @@ const (
 	ProviderTLSCAFile = ProviderTLSMountPath + "/ca.crt"
 )
 
+// Mount-path constants for the per-provider bearer token the manager
+// generates. They MUST match internal/controller/provider_controller.go.
+const (
+	// ProviderAuthMountPath is the in-pod directory where the manager
+	// mounts the provider's token Secret.
+	ProviderAuthMountPath = "/etc/virtrigaud/auth"
+
+	// ProviderAuthTokenFile holds the current token.
+	ProviderAuthTokenFile = ProviderAuthMountPath + "/token"
+
+	// ProviderAuthPreviousTokenFile holds the token replaced by the last
+	// rotation. The manager keeps it in the Secret for a grace window, so
+	// calls made while the new token propagates are still accepted.
+	ProviderAuthPreviousTokenFile = ProviderAuthMountPath + "/previous-token"
+)
+
 // Environment-variable names consumed by ResolveTLSAndAuth.
 const (
 	// EnvAllowedSANs is a comma-separated list of SAN/CN values the
@@ -59,6 +75,26 @@ const (
 	// plaintext mode with a loud WARN log. Any other combination (cert
 	// files present, or env-var unset) keeps TLS-mandatory semantics.
 	EnvInsecure = "VIRTRIGAUD_PROVIDER_INSECURE"
+
+	// EnvTLSMode selects where the provider's TLS identity comes from.
+	// Unset (or "files") keeps the mounted-cert behavior described on
+	// ResolveTLSAndAuth; TLSModeSPIFFE fetches an X.509 SVID from the
+	// SPIFFE Workload API instead (socket from SPIFFE_ENDPOINT_SOCKET).
+	EnvTLSMode = "VIRTRIGAUD_PROVIDER_TLS_MODE"
+
+	// EnvSPIFFETrustDomain is the trust domain the manager's client SVID
+	// must belong to in SPIFFE mode. Required when EnvTLSMode=spiffe.
+	EnvSPIFFETrustDomain = "VIRTRIGAUD_PROVIDER_SPIFFE_TRUST_DOMAIN"
+
+	// EnvSPIFFEAllowedIDs is a comma-separated allowlist of manager SPIFFE
+	// IDs. Empty accepts any ID in EnvSPIFFETrustDomain.
+	EnvSPIFFEAllowedIDs = "VIRTRIGAUD_PROVIDER_SPIFFE_ALLOWED_IDS"
+)
+
+// Values accepted in EnvTLSMode.
+const (
+	TLSModeFiles  = "files"
+	TLSModeSPIFFE = "spiffe"
 )
 
 // ErrInsecureModeOptedIn is returned by ResolveTLSAndAuth when the operator
@@ -74,7 +110,7 @@ var ErrInsecureModeOptedIn = errors.New("provider started in plaintext mode (VIR
 //   - Insecure == true → provider should bind plaintext and log a WARN
 //
 // Auth is always populated; in the insecure branch its RequireTLS field is
-// false so the middleware does no auth checks.
+// false and token auth is off, so the middleware does no auth checks.
 type TLSResolution struct {
 	TLS      *TLSConfig
 	Auth     *middleware.AuthConfig
@@ -105,7 +141,45 @@ type TLSResolution struct {
 // trimmed, empty entries dropped. An empty/unset env-var yields an empty
 // allow-list, which the SDK middleware treats as permissive (any cert
 // from the CA accepted) per ADR-0003 decision #5.
+//
+// The table above applies when EnvTLSMode is unset or "files". With
+// EnvTLSMode=spiffe the mounted files and EnvInsecure are ignored; see
+// resolveSPIFFE.
+//
+// When a token is mounted at ProviderAuthTokenFile, the TLS branches also
+// require every call to carry it (or the previous token, during a
+// rotation) as a bearer token; see withTokenAuth. The plaintext opt-out
+// skips token checks too, so the provider main must log that loudly.
 func ResolveTLSAndAuth() (*TLSResolution, error) {
+	res, err := resolveTLS()
+	if err == nil {
+		res.Auth = withTokenAuth(res.Auth, ProviderAuthTokenFile, ProviderAuthPreviousTokenFile)
+	}
+	return res, err
+}
+
+// withTokenAuth turns on bearer-token checks when tokenFile exists. A
+// provider deployed without a token Secret keeps working on mTLS alone.
+func withTokenAuth(auth *middleware.AuthConfig, tokenFile, previousTokenFile string) *middleware.AuthConfig {
+	if !fileExists(tokenFile) {
+		return auth
+	}
+	auth.BearerTokenAuth = true
+	auth.ValidateToken = middleware.NewFileTokenValidator(tokenFile, previousTokenFile).Validate
+	return auth
+}
+
+// resolveTLS implements the table documented on ResolveTLSAndAuth.
+func resolveTLS() (*TLSResolution, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv(EnvTLSMode))); mode {
+	case "", TLSModeFiles:
+	case TLSModeSPIFFE:
+		return resolveSPIFFE()
+	default:
+		return nil, fmt.Errorf("unsupported %s=%q (expected %q or %q)",
+			EnvTLSMode, mode, TLSModeFiles, TLSModeSPIFFE)
+	}
+
 	certPath := ProviderTLSCertFile
 	keyPath := ProviderTLSKeyFile
 	caPath := ProviderTLSCAFile
@@ -175,6 +249,35 @@ func ResolveTLSAndAuth() (*TLSResolution, error) {
 	}
 }
 
+// resolveSPIFFE builds the SPIFFE-mode resolution. The trust domain is
+// mandatory so a missing env-var fails closed instead of accepting any
+// SVID the agent's bundle happens to verify. The ID allowlist is applied
+// both during the handshake and by the auth middleware (SPIFFE IDs are
+// URI SANs, which validateTLSPeer already matches).
+func resolveSPIFFE() (*TLSResolution, error) {
+	cfg := &SPIFFEConfig{
+		TrustDomain: strings.TrimSpace(os.Getenv(EnvSPIFFETrustDomain)),
+		AllowedIDs:  parseAllowedSANs(os.Getenv(EnvSPIFFEAllowedIDs)),
+	}
+	if cfg.TrustDomain == "" {
+		return nil, fmt.Errorf("%s=%s requires %s to be set",
+			EnvTLSMode, TLSModeSPIFFE, EnvSPIFFETrustDomain)
+	}
+	if err := cfg.authorizer().Validate(); err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE configuration: %w", err)
+	}
+	return &TLSResolution{
+		TLS: &TLSConfig{
+			SPIFFE:            cfg,
+			RequireClientCert: true,
+		},
+		Auth: &middleware.AuthConfig{
+			RequireTLS:  true,
+			AllowedSANs: cfg.AllowedIDs,
+		},
+	}, nil
+}
+
 // parseAllowedSANs splits the comma-separated env-var value into a
 // trimmed, non-empty list. An empty/unset value yields a nil slice.
 func parseAllowedSANs(raw string) []string {
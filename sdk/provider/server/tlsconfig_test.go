@@ -17,9 +17,14 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/middleware"
 )
 
 func TestParseAllowedSANs(t *testing.T) {
@@ -138,3 +143,28 @@ func TestResolveTLSAndAuth_AllowedSANsPropagated(t *testing.T) {
 	}
 	_ = resolution
 }
+
+func TestWithTokenAuth(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	previousFile := filepath.Join(dir, "previous-token")
+
+	auth := withTokenAuth(&middleware.AuthConfig{RequireTLS: true}, tokenFile, previousFile)
+	if auth.BearerTokenAuth {
+		t.Fatal("token auth must stay off when no token is mounted")
+	}
+
+	if err := os.WriteFile(tokenFile, []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	auth = withTokenAuth(&middleware.AuthConfig{RequireTLS: true}, tokenFile, previousFile)
+	if !auth.BearerTokenAuth || auth.ValidateToken == nil {
+		t.Fatal("expected token auth when a token is mounted")
+	}
+	if !auth.RequireTLS {
+		t.Error("token auth must not replace the mTLS requirement")
+	}
+	if err := auth.ValidateToken(context.Background(), "s3cret"); err != nil {
+		t.Errorf("mounted token rejected: %v", err)
+	}
+}
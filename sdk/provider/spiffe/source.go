@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spiffe implements the SPIFFE workload-identity TLS mode used
+// between the manager and provider servers.
+//
+// It is a thin layer over go-spiffe: an X509Source connected to the
+// Workload API at the address virtrigaud mounts by default, and tls.Config
+// builders that authenticate peers by SPIFFE ID rather than by DNS name.
+package spiffe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+const (
+	// EnvEndpointSocket is the standard SPIFFE environment variable naming
+	// the Workload API endpoint (e.g. "unix:///run/spire/agent.sock").
+	EnvEndpointSocket = workloadapi.SocketEnv
+
+	// DefaultEndpointSocket is the Workload API address used when
+	// EnvEndpointSocket is unset. It matches the mount path used by the
+	// SPIFFE CSI driver in the virtrigaud manifests.
+	DefaultEndpointSocket = "unix:///spiffe-workload-api/spire-agent.sock"
+)
+
+// X509Source keeps the workload's current X.509 SVID and trust bundle,
+// updated from a long-lived Workload API stream. TLS configs built from it
+// pick up rotated material on the next handshake.
+type X509Source = workloadapi.X509Source
+
+// NewX509Source connects to the Workload API at addr and blocks until the
+// first SVID has been received or ctx is done. An empty addr falls back to
+// EnvEndpointSocket and then DefaultEndpointSocket.
+//
+// The returned source keeps streaming updates in the background until
+// Close is called.
+func NewX509Source(ctx context.Context, addr string, logger *slog.Logger) (*X509Source, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if addr == "" {
+		addr = lookupEndpointSocket()
+	}
+	if err := workloadapi.ValidateAddress(addr); err != nil {
+		return nil, fmt.Errorf("invalid workload API address %q: %w", addr, err)
+	}
+	src, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(
+		workloadapi.WithAddr(addr),
+		workloadapi.WithLogger(slogLogger{logger}),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("waiting for initial SVID from workload API %s: %w", addr, err)
+	}
+	if svid, err := src.GetX509SVID(); err == nil {
+		logger.Info("Received X.509 SVID from workload API",
+			"spiffe_id", svid.ID.String(),
+			"not_after", svid.Certificates[0].NotAfter)
+	}
+	return src, nil
+}
+
+// lookupEndpointSocket resolves the Workload API address from the
+// environment, falling back to DefaultEndpointSocket.
+func lookupEndpointSocket() string {
+	if v := strings.TrimSpace(os.Getenv(EnvEndpointSocket)); v != "" {
+		return v
+	}
+	return DefaultEndpointSocket
+}
+
+// slogLogger routes go-spiffe's Workload API client logs, such as stream
+// reconnects, to slog.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Debugf(format string, args ...any) { l.logger.Debug(fmt.Sprintf(format, args...)) }
+func (l slogLogger) Infof(format string, args ...any)  { l.logger.Info(fmt.Sprintf(format, args...)) }
+func (l slogLogger) Warnf(format string, args ...any)  { l.logger.Warn(fmt.Sprintf(format, args...)) }
+func (l slogLogger) Errorf(format string, args ...any) { l.logger.Error(fmt.Sprintf(format, args...)) }
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spiffe
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/projectbeskar/virtrigaud/sdk/provider/spiffe/spiffetest"
+)
+
+const (
+	testManagerID  = "spiffe://example.org/ns/virtrigaud-system/sa/manager"
+	testProviderID = "spiffe://example.org/ns/virtrigaud-system/sa/provider-libvirt"
+)
+
+// newTestSource starts an in-memory Workload API serving id and returns a
+// source connected to it.
+func newTestSource(t *testing.T, ca *spiffetest.CA, id string) (*X509Source, *spiffetest.WorkloadAPI) {
+	t.Helper()
+	api := spiffetest.NewWorkloadAPI(t)
+	api.SetSVID(t, ca, id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	src, err := NewX509Source(ctx, api.Addr, nil)
+	if err != nil {
+		t.Fatalf("NewX509Source: %v", err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+	return src, api
+}
+
+// handshake runs a TLS handshake between the two configs over an in-memory
+// pipe and returns the client- and server-side errors.
+func handshake(t *testing.T, clientCfg, serverCfg *tls.Config) (clientErr, serverErr error) {
+	t.Helper()
+	c, s := net.Pipe()
+	defer func() { _ = c.Close() }()
+	defer func() { _ = s.Close() }()
+
+	done := make(chan error, 1)
+	go func() {
+		srv := tls.Server(s, serverCfg)
+		err := srv.Handshake()
+		if err != nil {
+			_ = s.Close()
+		}
+		done <- err
+	}()
+	cli := tls.Client(c, clientCfg)
+	clientErr = cli.Handshake()
+	if clientErr != nil {
+		_ = c.Close()
+	} else {
+		// TLS 1.3 finishes the client side before the server has
+		// verified the client certificate; a read surfaces that result.
+		_ = cli.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		if _, err := cli.Read(make([]byte, 1)); err != nil {
+			if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+				clientErr = err
+			}
+		}
+		_ = c.Close()
+	}
+	return clientErr, <-done
+}
+
+func TestX509Source_InitialSVIDAndRotation(t *testing.T) {
+	ca := spiffetest.NewCA(t, "example.org")
+	src, api := newTestSource(t, ca, testProviderID)
+
+	first, err := src.GetX509SVID()
+	if err != nil {
+		t.Fatalf("GetX509SVID: %v", err)
+	}
+	if first.ID.String() != testProviderID {
+		t.Fatalf("SVID ID = %s, want %s", first.ID, testProviderID)
+	}
+	if _, err := src.GetX509BundleForTrustDomain(spiffeid.RequireTrustDomainFromString("example.org")); err != nil {
+		t.Fatalf("GetX509BundleForTrustDomain: %v", err)
+	}
+
+	// Rotate: the agent pushes a fresh SVID on the open stream.
+	api.SetSVID(t, ca, testProviderID)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		cur, err := src.GetX509SVID()
+		if err != nil {
+			t.Fatalf("GetX509SVID: %v", err)
+		}
+		if cur.Certificates[0].SerialNumber.Cmp(first.Certificates[0].SerialNumber) != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("rotated SVID was not picked up")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestNewX509Source_TimesOutWithoutSVID(t *testing.T) {
+	api := spiffetest.NewWorkloadAPI(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := NewX509Source(ctx, api.Addr, nil); err == nil {
+		t.Fatal("expected an error when the workload API never serves an SVID")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := spiffetest.NewCA(t, "example.org")
+	managerSrc, _ := newTestSource(t, ca, testManagerID)
+	providerSrc, _ := newTestSource(t, ca, testProviderID)
+
+	tests := []struct {
+		name        string
+		clientAuthz Authorizer
+		serverAuthz Authorizer
+		wantErr     bool
+	}{
+		{
+			name:        "trust domain only",
+			clientAuthz: Authorizer{TrustDomain: "example.org"},
+			serverAuthz: Authorizer{TrustDomain: "example.org"},
+		},
+		{
+			name:        "explicit ID allowlists",
+			clientAuthz: Authorizer{TrustDomain: "example.org", IDs: []string{testProviderID}},
+			serverAuthz: Authorizer{TrustDomain: "example.org", IDs: []string{testManagerID}},
+		},
+		{
+			name:        "server rejects manager ID",
+			clientAuthz: Authorizer{TrustDomain: "example.org"},
+			serverAuthz: Authorizer{TrustDomain: "example.org", IDs: []string{"spiffe://example.org/other"}},
+			wantErr:     true,
+		},
+		{
+			name:        "client rejects provider ID",
+			clientAuthz: Authorizer{TrustDomain: "example.org", IDs: []string{"spiffe://example.org/other"}},
+			serverAuthz: Authorizer{TrustDomain: "example.org"},
+			wantErr:     true,
+		},
+		{
+			name:        "foreign trust domain",
+			clientAuthz: Authorizer{TrustDomain: "other.org"},
+			serverAuthz: Authorizer{TrustDomain: "example.org"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientErr, serverErr := handshake(t,
+				ClientTLSConfig(managerSrc, tt.clientAuthz),
+				ServerTLSConfig(providerSrc, tt.serverAuthz))
+			failed := clientErr != nil || serverErr != nil
+			if failed != tt.wantErr {
+				t.Fatalf("handshake failed=%v (client=%v, server=%v), wantErr=%v",
+					failed, clientErr, serverErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMutualTLS_UntrustedCA(t *testing.T) {
+	managerSrc, _ := newTestSource(t, spiffetest.NewCA(t, "example.org"), testManagerID)
+	providerSrc, _ := newTestSource(t, spiffetest.NewCA(t, "example.org"), testProviderID)
+
+	authz := Authorizer{TrustDomain: "example.org"}
+	clientErr, serverErr := handshake(t, ClientTLSConfig(managerSrc, authz), ServerTLSConfig(providerSrc, authz))
+	if clientErr == nil && serverErr == nil {
+		t.Fatal("handshake succeeded across unrelated CAs")
+	}
+}
+
+func TestAuthorizer_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		authz   Authorizer
+		wantErr bool
+	}{
+		{"valid", Authorizer{TrustDomain: "example.org", IDs: []string{testManagerID}}, false},
+		{"missing trust domain", Authorizer{}, true},
+		{"trust domain given as URI", Authorizer{TrustDomain: "spiffe://example.org"}, true},
+		{"ID outside trust domain", Authorizer{TrustDomain: "example.org", IDs: []string{"spiffe://other.org/x"}}, true},
+		{"non-SPIFFE ID", Authorizer{TrustDomain: "example.org", IDs: []string{"https://example.org/x"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.authz.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewX509Source_InvalidAddress(t *testing.T) {
+	for _, addr := range []string{"unix://", "http://127.0.0.1:8081", "tcp://localhost:8081"} {
+		t.Run(addr, func(t *testing.T) {
+			if _, err := NewX509Source(context.Background(), addr, nil); err == nil {
+				t.Fatalf("NewX509Source(%q) succeeded, want an address error", addr)
+			}
+		})
+	}
+}
+
+func TestLookupEndpointSocket(t *testing.T) {
+	t.Setenv(EnvEndpointSocket, "")
+	if got := lookupEndpointSocket(); got != DefaultEndpointSocket {
+		t.Errorf("lookupEndpointSocket() = %q, want the default %q", got, DefaultEndpointSocket)
+	}
+	t.Setenv(EnvEndpointSocket, " unix:///run/spire/agent.sock ")
+	if got := lookupEndpointSocket(); got != "unix:///run/spire/agent.sock" {
+		t.Errorf("lookupEndpointSocket() = %q, want the environment value", got)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spiffetest provides an in-memory SPIFFE Workload API server and a
+// throwaway CA for testing code built on the spiffe package's go-spiffe
+// X509Source without a running SPIRE agent.
+package spiffetest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CA is a self-signed SPIFFE trust-domain CA.
+type CA struct {
+	TrustDomain string
+
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// NewCA creates a CA for trustDomain (e.g. "example.org").
+func NewCA(t testing.TB, trustDomain string) *CA {
+	t.Helper()
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(t),
+		Subject:               pkix.Name{Organization: []string{"spiffetest"}, CommonName: trustDomain},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: trustDomain}},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse CA certificate: %v", err)
+	}
+	return &CA{TrustDomain: trustDomain, cert: cert, key: key}
+}
+
+// Certificate returns the CA certificate.
+func (ca *CA) Certificate() *x509.Certificate {
+	return ca.cert
+}
+
+// IssueSVID mints an X.509 SVID for id ("spiffe://<trust domain>/...") and
+// returns its DER certificate and PKCS#8 DER key.
+func (ca *CA) IssueSVID(t testing.TB, id string) (certDER, keyDER []byte) {
+	t.Helper()
+	u, err := url.Parse(id)
+	if err != nil {
+		t.Fatalf("parse SPIFFE ID %q: %v", id, err)
+	}
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber: newSerial(t),
+		Subject:      pkix.Name{Organization: []string{"spiffetest"}},
+		URIs:         []*url.URL{u},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err = x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("create SVID certificate: %v", err)
+	}
+	keyDER, err = x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal SVID key: %v", err)
+	}
+	return certDER, keyDER
+}
+
+// WorkloadAPI is an in-memory Workload API server listening on a unix
+// socket. It serves FetchX509SVID and pushes the latest SVID set with
+// SetSVID to every open stream, which lets tests exercise rotation.
+type WorkloadAPI struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+
+	// Addr is the SPIFFE endpoint address, e.g. "unix:///tmp/.../agent.sock".
+	Addr string
+
+	mu      sync.Mutex
+	latest  *workload.X509SVIDResponse
+	changed chan struct{}
+}
+
+// NewWorkloadAPI starts a Workload API server that is stopped when the test
+// ends.
+func NewWorkloadAPI(t testing.TB) *WorkloadAPI {
+	t.Helper()
+	// t.TempDir paths can exceed the 108-byte unix socket limit, so use a
+	// short directory under the system temp dir instead.
+	dir, err := os.MkdirTemp("", "spiffe")
+	if err != nil {
+		t.Fatalf("create socket dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	sock := filepath.Join(dir, "agent.sock")
+
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatalf("listen on %s: %v", sock, err)
+	}
+	w := &WorkloadAPI{Addr: "unix://" + sock, changed: make(chan struct{})}
+	srv := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(srv, w)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return w
+}
+
+// SetSVID makes id, issued by ca, the SVID served to all current and future
+// streams. The trust bundle is ca's certificate.
+func (w *WorkloadAPI) SetSVID(t testing.TB, ca *CA, id string) {
+	t.Helper()
+	certDER, keyDER := ca.IssueSVID(t, id)
+	resp := &workload.X509SVIDResponse{
+		Svids: []*workload.X509SVID{{
+			SpiffeId:    id,
+			X509Svid:    certDER,
+			X509SvidKey: keyDER,
+			Bundle:      ca.cert.Raw,
+		}},
+	}
+
+	w.mu.Lock()
+	w.latest = resp
+	close(w.changed)
+	w.changed = make(chan struct{})
+	w.mu.Unlock()
+}
+
+// FetchX509SVID streams the latest SVID, mirroring the agent's security
+// header check.
+func (w *WorkloadAPI) FetchX509SVID(_ *workload.X509SVIDRequest, stream grpc.ServerStreamingServer[workload.X509SVIDResponse]) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if v := md.Get("workload.spiffe.io"); len(v) != 1 || v[0] != "true" {
+		return status.Error(codes.InvalidArgument, "security header missing from request")
+	}
+
+	for {
+		w.mu.Lock()
+		resp, changed := w.latest, w.changed
+		w.mu.Unlock()
+		if resp != nil {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func newSerial(t testing.TB) *big.Int {
+	t.Helper()
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+	if err != nil {
+		t.Fatalf("generate serial: %v", err)
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+// TaskStatusServer is the part of providerv1.ProviderServer CheckUnknownRef
+// exercises.
+type TaskStatusServer interface {
+	TaskStatus(context.Context, *providerv1.TaskStatusRequest) (*providerv1.TaskStatusResponse, error)
+}
+
+// CheckUnknownRef is the conformance check for TaskRefs a provider does not
+// know, e.g. because it restarted since issuing them. Call it on a freshly
+// started provider with a ref that has the provider's TaskRef format but was
+// never issued. The provider must answer "not done" with no error, or a
+// retryable Unavailable, so the manager keeps polling; reporting the task
+// failed or not found would wedge the VM waiting on it.
+func CheckUnknownRef(ctx context.Context, srv TaskStatusServer, ref string) error {
+	resp, err := srv.TaskStatus(ctx, &providerv1.TaskStatusRequest{Task: &providerv1.TaskRef{Id: ref}})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil
+		}
+		return fmt.Errorf("TaskStatus(%q) returned terminal error %v; an unknown well-formed TaskRef must stay pending during the grace period", ref, err)
+	}
+	if resp.GetDone() || resp.GetError() != "" {
+		return fmt.Errorf("TaskStatus(%q) reported done=%t error=%q; an unknown well-formed TaskRef must stay pending during the grace period",
+			ref, resp.GetDone(), resp.GetError())
+	}
+	return nil
+}
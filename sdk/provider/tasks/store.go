@@ -0,0 +1,236 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tasks helps providers keep async task references valid across
+// restarts.
+//
+// The manager records the TaskRef a provider returns and polls TaskStatus
+// with it until the task is done, possibly long after the provider pod that
+// issued it was replaced. A provider whose TaskRefs map to in-memory state
+// persists that state in a Store keyed by the TaskRef, and answers
+// TaskStatus through a Tracker. Providers whose TaskRefs are hypervisor task
+// IDs that survive a restart (Proxmox UPIDs, vSphere task MoRefs) need
+// neither.
+package tasks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layout of the provider pod's task store. The manager mounts an emptyDir
+// at DefaultDir, which survives container restarts, and sets EnvDir to it.
+const (
+	EnvDir     = "VIRTRIGAUD_TASK_STORE_DIR"
+	DefaultDir = "/var/lib/virtrigaud/tasks"
+)
+
+// Record is a provider's bookkeeping for one async task.
+type Record struct {
+	// Ref is the TaskRef returned to the manager.
+	Ref string `json:"ref"`
+	// Operation names what the task does, e.g. "create".
+	Operation string `json:"operation,omitempty"`
+	// InternalID is the provider's own handle for the task, if it differs
+	// from Ref.
+	InternalID string `json:"internalID,omitempty"`
+	// Data holds whatever else the provider needs to resume tracking.
+	Data map[string]string `json:"data,omitempty"`
+
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store persists task records keyed by TaskRef.
+type Store interface {
+	// Put creates or replaces the record for rec.Ref.
+	Put(ctx context.Context, rec Record) error
+	// Get returns the record for ref; ok is false when there is none.
+	Get(ctx context.Context, ref string) (rec Record, ok bool, err error)
+	// Delete removes the record for ref. Deleting a missing record is not
+	// an error.
+	Delete(ctx context.Context, ref string) error
+	// List returns every record, in no particular order.
+	List(ctx context.Context) ([]Record, error)
+}
+
+// OpenStore returns a FileStore in the directory named by EnvDir, or a
+// MemoryStore when it is unset, e.g. when running outside Kubernetes.
+func OpenStore() (Store, error) {
+	dir := os.Getenv(EnvDir)
+	if dir == "" {
+		return NewMemoryStore(), nil
+	}
+	return NewFileStore(dir)
+}
+
+// MemoryStore is a Store that does not survive a restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]Record{}}
+}
+
+// Put implements Store.
+func (s *MemoryStore) Put(_ context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[rec.Ref] = rec
+	return nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, ref string) (Record, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[ref]
+	return rec, ok, nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(_ context.Context, ref string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, ref)
+	return nil
+}
+
+// List implements Store.
+func (s *MemoryStore) List(_ context.Context) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	recs := make([]Record, 0, len(s.records))
+	for _, rec := range s.records {
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// FileStore is a Store keeping one JSON file per task in a directory,
+// typically an emptyDir or PVC mount. Writes go through a rename, so a
+// crash never leaves a partial record behind.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore returns a FileStore in dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create task store directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the file for ref. TaskRefs are provider-defined and may
+// contain path separators, so the name is a hash of the ref.
+func (s *FileStore) path(ref string) string {
+	sum := sha256.Sum256([]byte(ref))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Put implements Store.
+func (s *FileStore) Put(_ context.Context, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode task %s: %w", rec.Ref, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tmp, err := os.CreateTemp(s.dir, ".task-*")
+	if err != nil {
+		return fmt.Errorf("write task %s: %w", rec.Ref, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // gone after the rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("write task %s: %w", rec.Ref, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("write task %s: %w", rec.Ref, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write task %s: %w", rec.Ref, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(rec.Ref)); err != nil {
+		return fmt.Errorf("write task %s: %w", rec.Ref, err)
+	}
+	return nil
+}
+
+// Get implements Store.
+func (s *FileStore) Get(_ context.Context, ref string) (Record, bool, error) {
+	data, err := os.ReadFile(s.path(ref))
+	if os.IsNotExist(err) {
+		return Record{}, false, nil
+	} else if err != nil {
+		return Record{}, false, fmt.Errorf("read task %s: %w", ref, err)
+	}
+	var rec Record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return Record{}, false, fmt.Errorf("decode task %s: %w", ref, err)
+	}
+	return rec, true, nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(_ context.Context, ref string) error {
+	if err := os.Remove(s.path(ref)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete task %s: %w", ref, err)
+	}
+	return nil
+}
+
+// List implements Store. Unreadable files are skipped rather than failing
+// the whole listing.
+func (s *FileStore) List(_ context.Context) ([]Record, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	var recs []Record
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var rec Record
+		if json.Unmarshal(data, &rec) == nil {
+			recs = append(recs, rec)
+		}
+	}
+	return recs, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/errors"
+)
+
+// DefaultUnknownTaskGrace is how long after starting a Tracker reports a
+// well-formed but unknown TaskRef as still in progress. It covers a store
+// that is still catching up, or a task issued by a replica that has not
+// written its record yet.
+const DefaultUnknownTaskGrace = 5 * time.Minute
+
+// Tracker records a provider's tasks in a Store and answers TaskStatus from
+// it, following the contract for TaskRefs it does not know: within
+// UnknownGrace of the Tracker starting they are reported as not done with no
+// error, so the manager keeps polling; afterwards they are NotFound.
+type Tracker struct {
+	store Store
+
+	// UnknownGrace overrides DefaultUnknownTaskGrace.
+	UnknownGrace time.Duration
+	// ValidRef reports whether ref has the shape of a TaskRef this provider
+	// issues. Malformed refs are rejected with InvalidArgument at once.
+	// Nil accepts every non-empty ref.
+	ValidRef func(ref string) bool
+
+	started time.Time
+	now     func() time.Time
+}
+
+// NewTracker returns a Tracker backed by store. Its grace period for
+// unknown TaskRefs starts now.
+func NewTracker(store Store) *Tracker {
+	return &Tracker{store: store, started: time.Now(), now: time.Now}
+}
+
+// Begin records a task that has started. Created and Updated default to
+// now.
+func (t *Tracker) Begin(ctx context.Context, rec Record) error {
+	now := t.now()
+	if rec.Created.IsZero() {
+		rec.Created = now
+	}
+	rec.Updated = now
+	rec.Done = false
+	return t.store.Put(ctx, rec)
+}
+
+// Finish marks the task done, failed when taskErr is non-nil. Finishing an
+// unknown task records it, so its outcome still reaches the manager.
+func (t *Tracker) Finish(ctx context.Context, ref string, taskErr error) error {
+	rec, ok, err := t.store.Get(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		rec = Record{Ref: ref, Created: t.now()}
+	}
+	rec.Done = true
+	rec.Error = ""
+	if taskErr != nil {
+		rec.Error = taskErr.Error()
+	}
+	rec.Updated = t.now()
+	return t.store.Put(ctx, rec)
+}
+
+// Get returns the record for ref; ok is false when there is none.
+func (t *Tracker) Get(ctx context.Context, ref string) (Record, bool, error) {
+	return t.store.Get(ctx, ref)
+}
+
+// Status implements the TaskStatus RPC.
+func (t *Tracker) Status(ctx context.Context, ref string) (*providerv1.TaskStatusResponse, error) {
+	if ref == "" || (t.ValidRef != nil && !t.ValidRef(ref)) {
+		return nil, errors.NewInvalidSpec("malformed task reference %q", ref)
+	}
+	rec, ok, err := t.store.Get(ctx, ref)
+	if err != nil {
+		return nil, errors.NewUnavailable("task store", err)
+	}
+	if ok {
+		return &providerv1.TaskStatusResponse{Done: rec.Done, Error: rec.Error}, nil
+	}
+
+	grace := t.UnknownGrace
+	if grace == 0 {
+		grace = DefaultUnknownTaskGrace
+	}
+	if t.now().Sub(t.started) < grace {
+		return &providerv1.TaskStatusResponse{
+			Message: fmt.Sprintf("task %s is unknown to this provider instance; still verifying", ref),
+		}, nil
+	}
+	return nil, errors.NewNotFound("task", ref)
+}
+
+// Prune deletes records of tasks that finished more than maxAge ago. The
+// manager stops polling a task once it sees it done, so old records are
+// never read again.
+func (t *Tracker) Prune(ctx context.Context, maxAge time.Duration) error {
+	recs, err := t.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	cutoff := t.now().Add(-maxAge)
+	for _, rec := range recs {
+		if rec.Done && rec.Updated.Before(cutoff) {
+			if err := t.store.Delete(ctx, rec.Ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	providerv1 "github.com/projectbeskar/virtrigaud/proto/rpc/provider/v1"
+)
+
+func TestFileStoreSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := Record{Ref: "clone/vm-1", Operation: "clone", InternalID: "job-7", Data: map[string]string{"node": "n1"}}
+	if err := store.Put(ctx, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := reopened.Get(ctx, "clone/vm-1")
+	if err != nil || !ok {
+		t.Fatalf("Get after reopen: ok=%t err=%v", ok, err)
+	}
+	if got.InternalID != "job-7" || got.Data["node"] != "n1" {
+		t.Errorf("record not preserved: %+v", got)
+	}
+
+	recs, err := reopened.List(ctx)
+	if err != nil || len(recs) != 1 {
+		t.Fatalf("List: %v %v", recs, err)
+	}
+
+	if err := reopened.Delete(ctx, "clone/vm-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete(ctx, "clone/vm-1"); err != nil {
+		t.Errorf("deleting a missing record: %v", err)
+	}
+	if _, ok, _ := reopened.Get(ctx, "clone/vm-1"); ok {
+		t.Error("record still present after Delete")
+	}
+}
+
+func TestTrackerStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := NewTracker(NewMemoryStore())
+	tr.started, tr.now = now, func() time.Time { return now }
+	tr.ValidRef = func(ref string) bool { return strings.HasPrefix(ref, "task-") }
+
+	if err := tr.Begin(ctx, Record{Ref: "task-1"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := tr.Status(ctx, "task-1")
+	if err != nil || resp.Done {
+		t.Fatalf("running task: %v %v", resp, err)
+	}
+	if err := tr.Finish(ctx, "task-1", errors.New("disk full")); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = tr.Status(ctx, "task-1")
+	if err != nil || !resp.Done || resp.Error != "disk full" {
+		t.Fatalf("failed task: %v %v", resp, err)
+	}
+
+	if _, err := tr.Status(ctx, "bogus"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("malformed ref: got %v, want InvalidArgument", err)
+	}
+
+	// Unknown refs stay pending through the grace period, then are NotFound.
+	if err := CheckUnknownRef(ctx, trackerServer{tr}, "task-404"); err != nil {
+		t.Errorf("within grace: %v", err)
+	}
+	now = now.Add(DefaultUnknownTaskGrace)
+	if _, err := tr.Status(ctx, "task-404"); status.Code(err) != codes.NotFound {
+		t.Errorf("after grace: got %v, want NotFound", err)
+	}
+	if err := CheckUnknownRef(ctx, trackerServer{tr}, "task-404"); err == nil {
+		t.Error("CheckUnknownRef accepted NotFound")
+	}
+
+	// Prune drops finished records once they are old enough.
+	if err := tr.Prune(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := tr.Get(ctx, "task-1"); !ok {
+		t.Error("pruned a recent record")
+	}
+	now = now.Add(2 * time.Hour)
+	if err := tr.Prune(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := tr.Get(ctx, "task-1"); ok {
+		t.Error("old record not pruned")
+	}
+}
+
+type trackerServer struct{ *Tracker }
+
+func (s trackerServer) TaskStatus(ctx context.Context, req *providerv1.TaskStatusRequest) (*providerv1.TaskStatusResponse, error) {
+	return s.Status(ctx, req.GetTask().GetId())
+}
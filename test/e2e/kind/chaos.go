@@ -0,0 +1,291 @@
+//go:build e2e
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/events"
+	"github.com/projectbeskar/virtrigaud/sdk/provider/tasks"
+)
+
+const (
+	// chaosTaskDuration is how long the chaos Provider's mock tasks take,
+	// through MOCK_TASK_DURATION.
+	chaosTaskDuration = 60 * time.Second
+	// chaosKillAfter is how far into the clone task the pod is killed.
+	chaosKillAfter = 20 * time.Second
+)
+
+// chaosVictim is the pod a chaos scenario kills.
+type chaosVictim string
+
+const (
+	// chaosKillProvider kills the Provider's runtime pod, which holds the
+	// clone task. Its task store is an emptyDir, so the replacement pod
+	// does not know the task.
+	chaosKillProvider chaosVictim = "provider"
+	// chaosKillManager kills the manager pod, which polls the task.
+	chaosKillManager chaosVictim = "manager"
+)
+
+// RunCloneChaos clones a VM on a mock Provider whose tasks take
+// chaosTaskDuration, kills the victim's pod chaosKillAfter into the clone
+// task, and checks what follows once the Deployment has replaced the pod:
+//
+//   - the VMClone ends Ready, or Failed with ProviderError when the
+//     provider lost the task, in which case recreating the VMClone, the
+//     documented retry, ends Ready;
+//   - a Ready clone has exactly one target VirtualMachine, bound to the
+//     provider VM the clone reported;
+//   - the clone's conditions and events agree with its phase.
+//
+// Each victim gets its own Provider, so killing it leaves the lifecycle
+// scenario's Provider alone.
+func RunCloneChaos(t *testing.T, cfg *Config, victim chaosVictim) {
+	if !cfg.Chaos {
+		t.Skip("chaos scenarios run with -chaos")
+	}
+	if !cfg.usesMockProvider() {
+		t.Skip("chaos scenarios need the mock provider's MOCK_TASK_DURATION")
+	}
+	ctx := context.Background()
+	c := cfg.Client()
+	require.NotNil(t, c, "the Connect setup step has not run")
+
+	vmName := "chaos-" + string(victim)
+	provider := chaosProviderFixture(cfg, vmName)
+	require.NoError(t, c.Create(ctx, provider))
+	require.NoError(t, waitForProvider(ctx, cfg, provider.Name))
+	createShared(ctx, t, c, vmClassFixture(cfg))
+	createShared(ctx, t, c, vmImageFixture(cfg))
+
+	vm := vmFixture(cfg, vmName)
+	vm.Spec.ProviderRef.Name = provider.Name
+	require.NoError(t, c.Create(ctx, vm))
+	waitForVM(ctx, t, cfg, vmName, isReadyWithIPs)
+
+	clone := vmCloneFixture(cfg, vmName, vmName+"-clone")
+	require.NoError(t, c.Create(ctx, clone))
+	started := waitForCloneTask(ctx, t, cfg, clone)
+	t.Logf("clone task %s started at %s", started.Status.TaskRef, started.Status.StartTime)
+
+	time.Sleep(time.Until(started.Status.StartTime.Add(chaosKillAfter)))
+	switch victim {
+	case chaosKillProvider:
+		killPods(ctx, t, cfg, cfg.Namespace, client.MatchingLabels{
+			"app.kubernetes.io/name":     "virtrigaud-provider",
+			"app.kubernetes.io/instance": provider.Name,
+		})
+	case chaosKillManager:
+		killPods(ctx, t, cfg, managerNamespace, client.MatchingLabels{"control-plane": "controller-manager"})
+	}
+
+	// A provider that lost the task reports it unknown, and so in progress,
+	// for the tracker's grace period before the clone can fail.
+	timeout := cfg.Timeout
+	if victim == chaosKillProvider {
+		timeout += tasks.DefaultUnknownTaskGrace
+	}
+	finished := waitForCloneFinished(ctx, t, c, clone, timeout)
+
+	if finished.Status.Phase == infravirtrigaudiov1beta1.ClonePhaseFailed {
+		require.Equal(t, chaosKillProvider, victim, "the provider kept the task, so the clone must not fail: %s", finished.Status.Message)
+		checkFailedClone(ctx, t, c, cfg, finished, started.Status.TargetVMID)
+
+		t.Log("clone failed with the lost task; retrying it by recreating the VMClone")
+		deleteAndWait(ctx, t, c, cfg, finished)
+		clone = vmCloneFixture(cfg, vmName, vmName+"-clone")
+		require.NoError(t, c.Create(ctx, clone))
+		finished = waitForCloneFinished(ctx, t, c, clone, cfg.Timeout+chaosTaskDuration)
+		require.Equal(t, infravirtrigaudiov1beta1.ClonePhaseReady, finished.Status.Phase, finished.Status.Message)
+	}
+	target := checkReadyClone(ctx, t, c, cfg, finished)
+
+	deleteAndWait(ctx, t, c, cfg, finished)
+	deleteAndWait(ctx, t, c, cfg, target)
+	deleteAndWait(ctx, t, c, cfg, vm)
+	deleteAndWait(ctx, t, c, cfg, provider)
+}
+
+// chaosProviderFixture is providerFixture under its own name, sharing the
+// credentials Secret, with mock tasks stretched to chaosTaskDuration.
+func chaosProviderFixture(cfg *Config, name string) *infravirtrigaudiov1beta1.Provider {
+	provider := providerFixture(cfg)
+	provider.Name = name
+	provider.Spec.Runtime.Env = []corev1.EnvVar{{Name: "MOCK_TASK_DURATION", Value: chaosTaskDuration.String()}}
+	return provider
+}
+
+// waitForCloneTask waits until the clone has an async task in flight and
+// returns it.
+func waitForCloneTask(ctx context.Context, t *testing.T, cfg *Config, clone *infravirtrigaudiov1beta1.VMClone) *infravirtrigaudiov1beta1.VMClone {
+	t.Helper()
+	got := &infravirtrigaudiov1beta1.VMClone{}
+	require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+		if err := cfg.Client().Get(ctx, client.ObjectKeyFromObject(clone), got); err != nil {
+			return false, err.Error(), nil
+		}
+		if got.Status.Phase == infravirtrigaudiov1beta1.ClonePhaseFailed {
+			return false, "", fmt.Errorf("clone failed before the pod was killed: %s", got.Status.Message)
+		}
+		return got.Status.TaskRef != "" && got.Status.StartTime != nil,
+			fmt.Sprintf("phase=%s task=%q", got.Status.Phase, got.Status.TaskRef), nil
+	}), "VMClone %s", clone.Name)
+	return got
+}
+
+// killPods force-deletes the pods matching labels in namespace and waits
+// until a replacement is Ready.
+func killPods(ctx context.Context, t *testing.T, cfg *Config, namespace string, labels client.MatchingLabels) {
+	t.Helper()
+	c := cfg.Client()
+	pods := &corev1.PodList{}
+	require.NoError(t, c.List(ctx, pods, client.InNamespace(namespace), labels))
+	require.NotEmpty(t, pods.Items, "no pods match %v in %s", labels, namespace)
+
+	killed := map[types.UID]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		t.Logf("killing pod %s/%s", pod.Namespace, pod.Name)
+		require.NoError(t, client.IgnoreNotFound(c.Delete(ctx, pod, client.GracePeriodSeconds(0))))
+		killed[pod.UID] = true
+	}
+
+	require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+		if err := c.List(ctx, pods, client.InNamespace(namespace), labels); err != nil {
+			return false, err.Error(), nil
+		}
+		for _, pod := range pods.Items {
+			if !killed[pod.UID] && pod.DeletionTimestamp == nil && podReady(&pod) {
+				return true, "", nil
+			}
+		}
+		return false, fmt.Sprintf("%d pods, none a Ready replacement", len(pods.Items)), nil
+	}), "replacing pods %v in %s", labels, namespace)
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// waitForCloneFinished waits until the clone is Ready or Failed and returns
+// it.
+func waitForCloneFinished(ctx context.Context, t *testing.T, c client.Client,
+	clone *infravirtrigaudiov1beta1.VMClone, timeout time.Duration) *infravirtrigaudiov1beta1.VMClone {
+	t.Helper()
+	got := &infravirtrigaudiov1beta1.VMClone{}
+	require.NoError(t, waitFor(ctx, timeout, func(ctx context.Context) (bool, string, error) {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(clone), got); err != nil {
+			return false, err.Error(), nil
+		}
+		switch got.Status.Phase {
+		case infravirtrigaudiov1beta1.ClonePhaseReady, infravirtrigaudiov1beta1.ClonePhaseFailed:
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("phase=%s task=%q message=%q", got.Status.Phase, got.Status.TaskRef, got.Status.Message), nil
+	}), "VMClone %s", clone.Name)
+	return got
+}
+
+// checkReadyClone checks that a Ready clone made exactly one target
+// VirtualMachine, bound to the clone's TargetVMID, and said so once. It
+// returns the target.
+func checkReadyClone(ctx context.Context, t *testing.T, c client.Client, cfg *Config,
+	clone *infravirtrigaudiov1beta1.VMClone) *infravirtrigaudiov1beta1.VirtualMachine {
+	t.Helper()
+	require.True(t, meta.IsStatusConditionTrue(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionReady))
+	require.False(t, meta.IsStatusConditionTrue(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionFailed))
+	require.False(t, meta.IsStatusConditionTrue(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionCloning))
+	require.NotEmpty(t, clone.Status.TargetVMID)
+	require.NotNil(t, clone.Status.TargetRef)
+
+	vms := &infravirtrigaudiov1beta1.VirtualMachineList{}
+	require.NoError(t, c.List(ctx, vms, client.InNamespace(cfg.Namespace)))
+	var targets []infravirtrigaudiov1beta1.VirtualMachine
+	for _, vm := range vms.Items {
+		if vm.Name == clone.Spec.Target.Name || vm.Status.ID == clone.Status.TargetVMID {
+			targets = append(targets, vm)
+		}
+	}
+	require.Len(t, targets, 1, "the clone must have made one target VirtualMachine")
+	require.Equal(t, clone.Status.TargetVMID, targets[0].Status.ID)
+
+	reasons := eventReasons(ctx, t, c, clone)
+	require.Equal(t, 1, reasons[events.ReasonCloneCompleted], "events: %v", reasons)
+	require.Zero(t, reasons[events.ReasonCloneFailed], "events: %v", reasons)
+	return &targets[0]
+}
+
+// checkFailedClone checks that a clone which lost its task failed with a
+// provider error, made no target VirtualMachine and scheduled the provider
+// VM it had started, targetVMID, for cleanup.
+func checkFailedClone(ctx context.Context, t *testing.T, c client.Client, cfg *Config,
+	clone *infravirtrigaudiov1beta1.VMClone, targetVMID string) {
+	t.Helper()
+	failed := meta.FindStatusCondition(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionFailed)
+	require.NotNil(t, failed)
+	require.Equal(t, metav1.ConditionTrue, failed.Status)
+	require.Equal(t, infravirtrigaudiov1beta1.VMCloneReasonProviderError, failed.Reason)
+	require.False(t, meta.IsStatusConditionTrue(clone.Status.Conditions, infravirtrigaudiov1beta1.VMCloneConditionReady))
+	require.Empty(t, clone.Status.TaskRef)
+
+	target := &infravirtrigaudiov1beta1.VirtualMachine{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: clone.Spec.Target.Name}, target)
+	require.True(t, apierrors.IsNotFound(err), "a failed clone must not create its target VirtualMachine: %v", err)
+
+	reasons := eventReasons(ctx, t, c, clone)
+	require.Equal(t, 1, reasons[events.ReasonCloneFailed], "events: %v", reasons)
+	require.Zero(t, reasons[events.ReasonCloneCompleted], "events: %v", reasons)
+	if targetVMID != "" {
+		require.Equal(t, 1, reasons[events.ReasonArtifactCleanupScheduled], "events: %v", reasons)
+	}
+}
+
+// eventReasons counts the events recorded for obj by reason. Events are
+// matched on UID, so a recreated object does not see its predecessor's.
+func eventReasons(ctx context.Context, t *testing.T, c client.Client, obj client.Object) map[string]int {
+	t.Helper()
+	list := &corev1.EventList{}
+	require.NoError(t, c.List(ctx, list, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{"involvedObject.uid": string(obj.GetUID())}))
+	reasons := map[string]int{}
+	for _, event := range list.Items {
+		reasons[event.Reason] += int(max(event.Count, 1))
+	}
+	return reasons
+}
@@ -0,0 +1,161 @@
+//go:build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kind is an end-to-end smoke test that runs the manager, the
+// ProviderController and a provider over real gRPC inside a kind cluster.
+//
+// By default it builds the manager and mock provider images from the
+// working tree. Provider teams can point the same scenario at their own
+// provider image with flags, e.g.
+//
+//	go test -tags=e2e ./test/e2e/kind/ -args \
+//	    -provider-type=proxmox -provider-image=registry/provider-proxmox:dev \
+//	    -provider-endpoint=https://pve.lab:8006 -credentials-file=pve.env \
+//	    -image-source=ubuntu-22-template
+package kind
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// Config carries the knobs of a run and the state shared between the
+// setup steps and the scenario, in the spirit of e2e-framework's envconf.
+type Config struct {
+	// ClusterName is the kind cluster to create, or to reuse with
+	// UseExistingCluster.
+	ClusterName string
+	// UseExistingCluster skips cluster creation and deletion.
+	UseExistingCluster bool
+	// KeepCluster leaves a created cluster behind for debugging.
+	KeepCluster bool
+	// BuildImages builds ManagerImage and, for the mock provider,
+	// ProviderImage from the working tree before loading them into kind.
+	BuildImages bool
+
+	// ManagerImage is the manager image deployed with config/default.
+	ManagerImage string
+	// ProviderType is the Provider spec.type the scenario registers.
+	ProviderType infravirtrigaudiov1beta1.ProviderType
+	// ProviderImage is the provider runtime image.
+	ProviderImage string
+	// ProviderEndpoint is the hypervisor endpoint passed to the provider.
+	ProviderEndpoint string
+	// CredentialsFile is a KEY=VALUE file turned into the provider
+	// credentials Secret. Empty creates a placeholder Secret, which is all
+	// the mock provider needs.
+	CredentialsFile string
+	// ImageSource is the template name or path the VMImage points at.
+	ImageSource string
+
+	// Namespace holds the Provider and every object the scenario creates.
+	Namespace string
+	// Timeout bounds each wait in the scenario.
+	Timeout time.Duration
+	// SkipSnapshot and SkipClone drop steps a provider does not support.
+	SkipSnapshot bool
+	SkipClone    bool
+	// Chaos runs the chaos scenarios, which kill the provider and manager
+	// pods mid-clone. They need the mock provider and take several minutes.
+	Chaos bool
+
+	kubeconfig string
+	client     client.Client
+}
+
+// Client returns the client for the cluster under test. It is nil until
+// the Connect step has run.
+func (c *Config) Client() client.Client {
+	return c.client
+}
+
+// ProviderName is the name of the Provider object the scenario uses.
+func (c *Config) ProviderName() string {
+	return "e2e-" + string(c.ProviderType)
+}
+
+// mockProviderImage is the tag the mock provider is built and loaded as.
+const mockProviderImage = "example.com/virtrigaud-provider-mock:e2e"
+
+// NewConfigFromFlags registers the harness flags on fs, parses args and
+// returns the resulting Config.
+func NewConfigFromFlags(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := &Config{}
+	var providerType string
+	fs.StringVar(&cfg.ClusterName, "kind-cluster", "virtrigaud-e2e", "name of the kind cluster")
+	fs.BoolVar(&cfg.UseExistingCluster, "use-existing-cluster", false, "run against an existing kind cluster instead of creating one")
+	fs.BoolVar(&cfg.KeepCluster, "keep-cluster", false, "do not delete the kind cluster after the run")
+	fs.BoolVar(&cfg.BuildImages, "build-images", true, "build the manager (and mock provider) images from the working tree")
+	fs.StringVar(&cfg.ManagerImage, "manager-image", "example.com/virtrigaud:e2e", "manager image to deploy")
+	fs.StringVar(&providerType, "provider-type", string(infravirtrigaudiov1beta1.ProviderTypeLibvirt), "Provider spec.type")
+	fs.StringVar(&cfg.ProviderImage, "provider-image", mockProviderImage, "provider runtime image; defaults to the mock provider")
+	fs.StringVar(&cfg.ProviderEndpoint, "provider-endpoint", "grpc://mock.invalid:9443", "hypervisor endpoint passed to the provider")
+	fs.StringVar(&cfg.CredentialsFile, "credentials-file", "", "KEY=VALUE file used as the provider credentials Secret")
+	fs.StringVar(&cfg.ImageSource, "image-source", "e2e-template", "template name or image path for the VMImage")
+	fs.StringVar(&cfg.Namespace, "namespace", "virtrigaud-e2e", "namespace for the scenario objects")
+	fs.DurationVar(&cfg.Timeout, "wait-timeout", 3*time.Minute, "timeout of each wait in the scenario")
+	fs.BoolVar(&cfg.SkipSnapshot, "skip-snapshot", false, "skip the snapshot step")
+	fs.BoolVar(&cfg.SkipClone, "skip-clone", false, "skip the clone step")
+	fs.BoolVar(&cfg.Chaos, "chaos", false, "run the chaos scenarios that kill pods mid-clone (mock provider only)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg.ProviderType = infravirtrigaudiov1beta1.ProviderType(providerType)
+	if cfg.ProviderImage == "" {
+		return nil, fmt.Errorf("-provider-image must not be empty")
+	}
+	return cfg, nil
+}
+
+// usesMockProvider reports whether the run targets the in-tree mock
+// provider, which is the only provider image the harness builds itself.
+func (c *Config) usesMockProvider() bool {
+	return c.ProviderImage == mockProviderImage
+}
+
+// credentials reads CredentialsFile into Secret string data.
+func (c *Config) credentials() (map[string]string, error) {
+	if c.CredentialsFile == "" {
+		return map[string]string{"username": "e2e", "password": "e2e"}, nil
+	}
+	raw, err := os.ReadFile(c.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	data := map[string]string{}
+	for i, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", c.CredentialsFile, i+1)
+		}
+		data[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return data, nil
+}
@@ -0,0 +1,261 @@
+//go:build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/test/utils"
+)
+
+// Func is a setup or teardown step. Steps run in order against the shared
+// Config; the first failing setup step aborts the run.
+type Func func(ctx context.Context, cfg *Config) error
+
+// Environment sequences setup steps, the tests, and teardown steps.
+type Environment struct {
+	cfg    *Config
+	setup  []Func
+	finish []Func
+}
+
+// NewEnvironment returns an Environment for cfg.
+func NewEnvironment(cfg *Config) *Environment {
+	return &Environment{cfg: cfg}
+}
+
+// Setup appends steps run before the tests.
+func (e *Environment) Setup(fns ...Func) *Environment {
+	e.setup = append(e.setup, fns...)
+	return e
+}
+
+// Finish appends steps run after the tests, even when setup failed.
+func (e *Environment) Finish(fns ...Func) *Environment {
+	e.finish = append(e.finish, fns...)
+	return e
+}
+
+// Run executes setup, m.Run and teardown, and returns the exit code.
+func (e *Environment) Run(ctx context.Context, m *testing.M) int {
+	code := 0
+	for _, fn := range e.setup {
+		if err := fn(ctx, e.cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "e2e setup failed: %v\n", err)
+			code = 1
+			break
+		}
+	}
+	if code == 0 {
+		code = m.Run()
+	}
+	for _, fn := range e.finish {
+		if err := fn(ctx, e.cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "e2e teardown failed: %v\n", err)
+		}
+	}
+	return code
+}
+
+// run executes a command from the project root. Once CreateCluster has run,
+// KUBECONFIG in the inherited environment points at the cluster under test.
+func run(name string, args ...string) (string, error) {
+	return utils.Run(exec.Command(name, args...))
+}
+
+// CreateCluster creates the kind cluster unless UseExistingCluster is set,
+// then points KUBECONFIG at it for every later command.
+func CreateCluster(_ context.Context, cfg *Config) error {
+	if !cfg.UseExistingCluster {
+		if _, err := run("kind", "create", "cluster", "--name", cfg.ClusterName, "--wait", "2m"); err != nil {
+			return err
+		}
+	}
+	kubeconfig, err := run("kind", "get", "kubeconfig", "--name", cfg.ClusterName)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "virtrigaud-e2e-")
+	if err != nil {
+		return err
+	}
+	cfg.kubeconfig = filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(cfg.kubeconfig, []byte(kubeconfig), 0o600); err != nil {
+		return err
+	}
+	// utils.Run inherits the process environment, so this reaches kubectl
+	// and the make targets as well. KIND_CLUSTER is read by
+	// utils.LoadImageToKindClusterWithName.
+	if err := os.Setenv("KUBECONFIG", cfg.kubeconfig); err != nil {
+		return err
+	}
+	return os.Setenv("KIND_CLUSTER", cfg.ClusterName)
+}
+
+// DeleteCluster removes the kind cluster the run created.
+func DeleteCluster(_ context.Context, cfg *Config) error {
+	if cfg.kubeconfig != "" {
+		_ = os.RemoveAll(filepath.Dir(cfg.kubeconfig))
+	}
+	if cfg.UseExistingCluster || cfg.KeepCluster {
+		return nil
+	}
+	_, err := run("kind", "delete", "cluster", "--name", cfg.ClusterName)
+	return err
+}
+
+// BuildImages builds the manager and, when the scenario uses it, the mock
+// provider from the working tree.
+func BuildImages(_ context.Context, cfg *Config) error {
+	if !cfg.BuildImages {
+		return nil
+	}
+	if _, err := run("make", "docker-build", "CONTROLLER_IMG="+cfg.ManagerImage); err != nil {
+		return err
+	}
+	if cfg.usesMockProvider() {
+		if _, err := run("make", "docker-provider-mock", "PROVIDER_MOCK_IMG="+cfg.ProviderImage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadImages loads the manager and provider images into the kind nodes.
+// A provider image that is not available locally is left for the nodes to
+// pull.
+func LoadImages(_ context.Context, cfg *Config) error {
+	if err := utils.LoadImageToKindClusterWithName(cfg.ManagerImage); err != nil {
+		return err
+	}
+	if err := utils.LoadImageToKindClusterWithName(cfg.ProviderImage); err != nil && cfg.usesMockProvider() {
+		return err
+	}
+	return nil
+}
+
+// DeployManager installs the CRDs and the manager from config/default and
+// waits for the manager to become available.
+func DeployManager(_ context.Context, cfg *Config) error {
+	if _, err := run("make", "deploy", "CONTROLLER_IMG="+cfg.ManagerImage); err != nil {
+		return err
+	}
+	_, err := run("kubectl", "-n", managerNamespace, "wait", "deployment",
+		"-l", "control-plane=controller-manager", "--for=condition=Available",
+		fmt.Sprintf("--timeout=%s", cfg.Timeout))
+	return err
+}
+
+// UndeployManager removes the manager and CRDs. It only matters on
+// clusters that outlive the run.
+func UndeployManager(_ context.Context, cfg *Config) error {
+	if !cfg.UseExistingCluster {
+		return nil
+	}
+	_, err := run("make", "undeploy", "ignore-not-found=true")
+	return err
+}
+
+// managerNamespace is where config/default installs the manager.
+const managerNamespace = "virtrigaud-system"
+
+// Connect builds the client the scenario uses from the cluster kubeconfig.
+func Connect(_ context.Context, cfg *Config) error {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", cfg.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := infravirtrigaudiov1beta1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	cfg.client, err = client.New(restConfig, client.Options{Scheme: scheme})
+	return err
+}
+
+// DeployProvider creates the scenario namespace, the credentials Secret and
+// a Remote-runtime Provider, and waits until the ProviderController reports
+// it healthy. The gRPC channel is plaintext, so the run also covers the
+// TLS opt-out wiring between the controller and the provider pod.
+func DeployProvider(ctx context.Context, cfg *Config) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cfg.Namespace}}
+	if err := cfg.client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	data, err := cfg.credentials()
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: cfg.ProviderName() + "-credentials", Namespace: cfg.Namespace},
+		StringData: data,
+	}
+	if err := cfg.client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	if err := cfg.client.Create(ctx, providerFixture(cfg)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return waitForProvider(ctx, cfg, cfg.ProviderName())
+}
+
+// waitForProvider waits until the ProviderController reports the named
+// Provider healthy, failing early when its runtime has failed.
+func waitForProvider(ctx context.Context, cfg *Config, name string) error {
+	return waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+		provider := &infravirtrigaudiov1beta1.Provider{}
+		if err := cfg.client.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: name}, provider); err != nil {
+			return false, err.Error(), nil
+		}
+		status := provider.Status.Runtime
+		if status == nil {
+			return false, "runtime status not reported yet", nil
+		}
+		if status.Phase == infravirtrigaudiov1beta1.ProviderRuntimePhaseFailed {
+			return false, "", fmt.Errorf("provider runtime failed: %s", status.Message)
+		}
+		return provider.Status.Healthy, fmt.Sprintf("phase=%s healthy=%t", status.Phase, provider.Status.Healthy), nil
+	})
+}
+
+// DeleteNamespace removes the scenario namespace.
+func DeleteNamespace(ctx context.Context, cfg *Config) error {
+	if cfg.client == nil {
+		return nil
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cfg.Namespace}}
+	return client.IgnoreNotFound(cfg.client.Delete(ctx, ns))
+}
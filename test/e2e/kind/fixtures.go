@@ -0,0 +1,144 @@
+//go:build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+)
+
+// providerFixture is a Remote-runtime Provider running cfg.ProviderImage
+// with plaintext gRPC.
+func providerFixture(cfg *Config) *infravirtrigaudiov1beta1.Provider {
+	return &infravirtrigaudiov1beta1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: cfg.ProviderName(), Namespace: cfg.Namespace},
+		Spec: infravirtrigaudiov1beta1.ProviderSpec{
+			Type:                cfg.ProviderType,
+			Endpoint:            cfg.ProviderEndpoint,
+			CredentialSecretRef: infravirtrigaudiov1beta1.ObjectRef{Name: cfg.ProviderName() + "-credentials"},
+			Runtime: &infravirtrigaudiov1beta1.ProviderRuntimeSpec{
+				Mode:            infravirtrigaudiov1beta1.RuntimeModeRemote,
+				Image:           cfg.ProviderImage,
+				ImagePullPolicy: "IfNotPresent",
+				Service: &infravirtrigaudiov1beta1.ProviderServiceSpec{
+					TLS: &infravirtrigaudiov1beta1.ProviderTLSSpec{Enabled: false},
+				},
+			},
+		},
+	}
+}
+
+// vmClassFixture is the smallest class every provider can satisfy.
+func vmClassFixture(cfg *Config) *infravirtrigaudiov1beta1.VMClass {
+	return &infravirtrigaudiov1beta1.VMClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "e2e-small", Namespace: cfg.Namespace},
+		Spec: infravirtrigaudiov1beta1.VMClassSpec{
+			CPU:    1,
+			Memory: resource.MustParse("512Mi"),
+		},
+	}
+}
+
+// vmImageFixture points at cfg.ImageSource through the source block that
+// matches the provider type.
+func vmImageFixture(cfg *Config) *infravirtrigaudiov1beta1.VMImage {
+	image := &infravirtrigaudiov1beta1.VMImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "e2e-image", Namespace: cfg.Namespace},
+	}
+	switch cfg.ProviderType {
+	case infravirtrigaudiov1beta1.ProviderTypeVSphere:
+		image.Spec.Source.VSphere = &infravirtrigaudiov1beta1.VSphereImageSource{TemplateName: cfg.ImageSource}
+	case infravirtrigaudiov1beta1.ProviderTypeProxmox:
+		image.Spec.Source.Proxmox = &infravirtrigaudiov1beta1.ProxmoxImageSource{TemplateName: cfg.ImageSource}
+	default:
+		image.Spec.Source.Libvirt = &infravirtrigaudiov1beta1.LibvirtImageSource{Path: cfg.ImageSource}
+	}
+	return image
+}
+
+// vmFixture is a powered-on VM built from the class and image fixtures.
+func vmFixture(cfg *Config, name string) *infravirtrigaudiov1beta1.VirtualMachine {
+	return &infravirtrigaudiov1beta1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cfg.Namespace},
+		Spec: infravirtrigaudiov1beta1.VirtualMachineSpec{
+			ProviderRef: infravirtrigaudiov1beta1.ObjectRef{Name: cfg.ProviderName()},
+			ClassRef:    infravirtrigaudiov1beta1.ObjectRef{Name: "e2e-small"},
+			ImageRef:    &infravirtrigaudiov1beta1.ObjectRef{Name: "e2e-image"},
+			PowerState:  infravirtrigaudiov1beta1.PowerStateOn,
+		},
+	}
+}
+
+// vmSnapshotFixture snapshots vmName without memory, so it works on
+// providers that cannot capture a running VM's RAM.
+func vmSnapshotFixture(cfg *Config, vmName string) *infravirtrigaudiov1beta1.VMSnapshot {
+	return &infravirtrigaudiov1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: vmName + "-snap", Namespace: cfg.Namespace},
+		Spec: infravirtrigaudiov1beta1.VMSnapshotSpec{
+			VMRef: infravirtrigaudiov1beta1.LocalObjectReference{Name: vmName},
+			SnapshotConfig: &infravirtrigaudiov1beta1.SnapshotConfig{
+				Name:        "e2e",
+				Description: "virtrigaud kind smoke test",
+			},
+		},
+	}
+}
+
+// vmCloneFixture clones vmName into target.
+func vmCloneFixture(cfg *Config, vmName, target string) *infravirtrigaudiov1beta1.VMClone {
+	return &infravirtrigaudiov1beta1.VMClone{
+		ObjectMeta: metav1.ObjectMeta{Name: target, Namespace: cfg.Namespace},
+		Spec: infravirtrigaudiov1beta1.VMCloneSpec{
+			Source: infravirtrigaudiov1beta1.CloneSource{
+				VMRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: vmName},
+			},
+			Target: infravirtrigaudiov1beta1.VMCloneTarget{
+				Name:     target,
+				ClassRef: &infravirtrigaudiov1beta1.LocalObjectReference{Name: "e2e-small"},
+			},
+		},
+	}
+}
+
+// condition checks one poll of a wait. It returns done, a description of
+// the current state for the timeout message, and an error that aborts the
+// wait.
+type condition func(ctx context.Context) (bool, string, error)
+
+// waitFor polls check every two seconds until it is done, fails, or
+// timeout elapses.
+func waitFor(ctx context.Context, timeout time.Duration, check condition) error {
+	var last string
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		done, state, err := check(ctx)
+		last = state
+		return done, err
+	})
+	if err != nil {
+		return fmt.Errorf("%w (last state: %s)", err, last)
+	}
+	return nil
+}
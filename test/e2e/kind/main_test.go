@@ -0,0 +1,47 @@
+//go:build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// testConfig is shared by the tests of the package once TestMain has set
+// the environment up.
+var testConfig *Config
+
+func TestMain(m *testing.M) {
+	// The harness flags share the default FlagSet with the testing flags so
+	// a single parse covers both; m.Run then skips its own flag.Parse.
+	cfg, err := NewConfigFromFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	testConfig = cfg
+
+	env := NewEnvironment(cfg).
+		Setup(CreateCluster, BuildImages, LoadImages, DeployManager, Connect, DeployProvider).
+		Finish(DeleteNamespace, UndeployManager, DeleteCluster)
+	os.Exit(env.Run(context.Background(), m))
+}
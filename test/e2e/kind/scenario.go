@@ -0,0 +1,191 @@
+//go:build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infravirtrigaudiov1beta1 "github.com/projectbeskar/virtrigaud/api/infra.virtrigaud.io/v1beta1"
+	"github.com/projectbeskar/virtrigaud/internal/k8s"
+)
+
+// RunVMLifecycle drives one VM through create, power off/on, snapshot,
+// clone and delete against the Provider set up by DeployProvider. Each
+// step is a subtest; the scenario stops at the first failing step since
+// every later step depends on it.
+func RunVMLifecycle(t *testing.T, cfg *Config) {
+	ctx := context.Background()
+	c := cfg.Client()
+	require.NotNil(t, c, "the Connect setup step has not run")
+	const vmName = "e2e-vm"
+
+	steps := []struct {
+		name string
+		skip bool
+		fn   func(t *testing.T)
+	}{
+		{name: "create", fn: func(t *testing.T) {
+			createShared(ctx, t, c, vmClassFixture(cfg))
+			createShared(ctx, t, c, vmImageFixture(cfg))
+			require.NoError(t, c.Create(ctx, vmFixture(cfg, vmName)))
+
+			vm := waitForVM(ctx, t, cfg, vmName, isReadyWithIPs)
+			require.NotEmpty(t, vm.Status.ID)
+			require.Contains(t, vm.Finalizers, infravirtrigaudiov1beta1.VirtualMachineFinalizer)
+		}},
+		{name: "power-off", fn: func(t *testing.T) {
+			setPowerState(ctx, t, c, cfg, vmName, infravirtrigaudiov1beta1.PowerStateOff)
+			waitForVM(ctx, t, cfg, vmName, func(vm *infravirtrigaudiov1beta1.VirtualMachine) (bool, string) {
+				return vm.Status.PowerState == infravirtrigaudiov1beta1.PowerStateOff, describeVM(vm)
+			})
+		}},
+		{name: "power-on", fn: func(t *testing.T) {
+			setPowerState(ctx, t, c, cfg, vmName, infravirtrigaudiov1beta1.PowerStateOn)
+			waitForVM(ctx, t, cfg, vmName, isReadyWithIPs)
+		}},
+		{name: "snapshot", skip: cfg.SkipSnapshot, fn: func(t *testing.T) {
+			snapshot := vmSnapshotFixture(cfg, vmName)
+			require.NoError(t, c.Create(ctx, snapshot))
+			require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+				got := &infravirtrigaudiov1beta1.VMSnapshot{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), got); err != nil {
+					return false, err.Error(), nil
+				}
+				if got.Status.Phase == infravirtrigaudiov1beta1.SnapshotPhaseFailed {
+					return false, "", fmt.Errorf("snapshot failed: %s", got.Status.Message)
+				}
+				return got.Status.Phase == infravirtrigaudiov1beta1.SnapshotPhaseReady && got.Status.SnapshotID != "",
+					fmt.Sprintf("phase=%s id=%q", got.Status.Phase, got.Status.SnapshotID), nil
+			}))
+			deleteAndWait(ctx, t, c, cfg, snapshot)
+		}},
+		{name: "clone", skip: cfg.SkipClone, fn: func(t *testing.T) {
+			clone := vmCloneFixture(cfg, vmName, vmName+"-clone")
+			require.NoError(t, c.Create(ctx, clone))
+			require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+				got := &infravirtrigaudiov1beta1.VMClone{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(clone), got); err != nil {
+					return false, err.Error(), nil
+				}
+				if got.Status.Phase == infravirtrigaudiov1beta1.ClonePhaseFailed {
+					return false, "", fmt.Errorf("clone failed: %s", got.Status.Message)
+				}
+				return got.Status.Phase == infravirtrigaudiov1beta1.ClonePhaseReady,
+					fmt.Sprintf("phase=%s", got.Status.Phase), nil
+			}))
+			target := &infravirtrigaudiov1beta1.VirtualMachine{}
+			require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: clone.Spec.Target.Name}, target))
+
+			deleteAndWait(ctx, t, c, cfg, clone)
+			deleteAndWait(ctx, t, c, cfg, target)
+		}},
+		{name: "delete", fn: func(t *testing.T) {
+			vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+			require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: vmName}, vm))
+			deleteAndWait(ctx, t, c, cfg, vm)
+		}},
+	}
+
+	for _, step := range steps {
+		if step.skip {
+			t.Logf("skipping %s", step.name)
+			continue
+		}
+		if !t.Run(step.name, step.fn) {
+			return
+		}
+	}
+}
+
+// isReadyWithIPs is true once the VM is Ready, powered on and reports at
+// least one address.
+func isReadyWithIPs(vm *infravirtrigaudiov1beta1.VirtualMachine) (bool, string) {
+	ready := k8s.IsConditionTrue(vm.Status.Conditions, k8s.ConditionReady)
+	return ready && vm.Status.PowerState == infravirtrigaudiov1beta1.PowerStateOn && len(vm.Status.IPs) > 0,
+		describeVM(vm)
+}
+
+// describeVM summarizes a VM's status for timeout messages.
+func describeVM(vm *infravirtrigaudiov1beta1.VirtualMachine) string {
+	state := fmt.Sprintf("id=%q power=%s ips=%v", vm.Status.ID, vm.Status.PowerState, vm.Status.IPs)
+	if cond := meta.FindStatusCondition(vm.Status.Conditions, k8s.ConditionReady); cond != nil {
+		state += fmt.Sprintf(" ready=%s/%s: %s", cond.Status, cond.Reason, cond.Message)
+	}
+	return state
+}
+
+// waitForVM waits until done holds for the named VM and returns it.
+func waitForVM(ctx context.Context, t *testing.T, cfg *Config, name string,
+	done func(*infravirtrigaudiov1beta1.VirtualMachine) (bool, string)) *infravirtrigaudiov1beta1.VirtualMachine {
+	t.Helper()
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+		if err := cfg.Client().Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: name}, vm); err != nil {
+			return false, err.Error(), nil
+		}
+		ok, state := done(vm)
+		return ok, state, nil
+	}), "VirtualMachine %s", name)
+	return vm
+}
+
+// setPowerState patches spec.powerState of the named VM.
+func setPowerState(ctx context.Context, t *testing.T, c client.Client, cfg *Config, name string, state infravirtrigaudiov1beta1.PowerState) {
+	t.Helper()
+	vm := &infravirtrigaudiov1beta1.VirtualMachine{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: name}, vm))
+	patch := client.MergeFrom(vm.DeepCopy())
+	vm.Spec.PowerState = state
+	require.NoError(t, c.Patch(ctx, vm, patch))
+}
+
+// createShared creates obj unless it exists already, for the class and
+// image fixtures that every scenario uses.
+func createShared(ctx context.Context, t *testing.T, c client.Client, obj client.Object) {
+	t.Helper()
+	if err := c.Create(ctx, obj); !apierrors.IsAlreadyExists(err) {
+		require.NoError(t, err)
+	}
+}
+
+// deleteAndWait deletes obj and waits until it is gone, which proves its
+// finalizers were released.
+func deleteAndWait(ctx context.Context, t *testing.T, c client.Client, cfg *Config, obj client.Object) {
+	t.Helper()
+	require.NoError(t, client.IgnoreNotFound(c.Delete(ctx, obj)))
+	key := client.ObjectKeyFromObject(obj)
+	require.NoError(t, waitFor(ctx, cfg.Timeout, func(ctx context.Context) (bool, string, error) {
+		current := obj.DeepCopyObject().(client.Object)
+		err := c.Get(ctx, key, current)
+		if apierrors.IsNotFound(err) {
+			return true, "", nil
+		}
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		return false, fmt.Sprintf("finalizers=%v deleting=%t", current.GetFinalizers(), current.GetDeletionTimestamp() != nil), nil
+	}), "deleting %s", key)
+}
@@ -0,0 +1,34 @@
+#!/bin/sh
+# Starts libvirtd and sshd for the libvirt scenario tests. PUBLIC_KEY is the
+# key the tests log in as root with.
+set -eu
+
+if [ -z "${PUBLIC_KEY:-}" ]; then
+    echo "PUBLIC_KEY is required" >&2
+    exit 1
+fi
+install -d -m 0700 /root/.ssh
+printf '%s\n' "$PUBLIC_KEY" > /root/.ssh/authorized_keys
+chmod 0600 /root/.ssh/authorized_keys
+
+ssh-keygen -A
+mkdir -p /run/sshd
+cat > /etc/ssh/sshd_config.d/fixture.conf <<CONF
+Port 2222
+PermitRootLogin prohibit-password
+PasswordAuthentication no
+MaxSessions 32
+MaxStartups 32:30:64
+CONF
+
+virtlogd -d
+libvirtd -d
+
+# The base image the tests create VMs from: blank, so the guests never boot
+# an OS, which none of the scenarios need.
+mkdir -p /var/lib/libvirt/images
+if [ ! -f /var/lib/libvirt/images/scenario-base.qcow2 ]; then
+    qemu-img create -q -f qcow2 /var/lib/libvirt/images/scenario-base.qcow2 1G
+fi
+
+exec /usr/sbin/sshd -D -e
@@ -1,6 +1,6 @@
 module github.com/projectbeskar/virtrigaud/test/proxmox-grpc-test
 
-go 1.24.0
+go 1.26.4
 
 require (
 	github.com/projectbeskar/virtrigaud/proto v0.1.0
@@ -8,9 +8,9 @@ require (
 )
 
 require (
-	golang.org/x/net v0.48.0 // indirect
-	golang.org/x/sys v0.39.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
+	golang.org/x/net v0.55.0 // indirect
+	golang.org/x/sys v0.45.0 // indirect
+	golang.org/x/text v0.37.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
 )
@@ -197,7 +197,11 @@ func GetProjectDir() (string, error) {
 	if err != nil {
 		return wd, err
 	}
-	wd = strings.ReplaceAll(wd, "/test/e2e", "")
+	// Trim at the first test/e2e element so suites in subdirectories
+	// (test/e2e/kind) resolve to the same root.
+	if i := strings.Index(wd, "/test/e2e"); i >= 0 {
+		wd = wd[:i]
+	}
 	return wd, nil
 }
 